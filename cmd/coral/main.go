@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/coral-mesh/coral/internal/cli"
@@ -9,7 +8,6 @@ import (
 
 func main() {
 	if err := cli.Execute(); err != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err) // TODO: errcheck
-		os.Exit(1)
+		os.Exit(cli.ReportError(os.Stderr, err))
 	}
 }
@@ -203,6 +203,8 @@ const (
 	EbpfCollectorKind_EBPF_COLLECTOR_KIND_CPU_PROFILE   EbpfCollectorKind = 3
 	EbpfCollectorKind_EBPF_COLLECTOR_KIND_TCP_METRICS   EbpfCollectorKind = 4
 	EbpfCollectorKind_EBPF_COLLECTOR_KIND_UPROBE        EbpfCollectorKind = 5 // RFD 059 - Application-level function debugging
+	EbpfCollectorKind_EBPF_COLLECTOR_KIND_HTTP_CAPTURE  EbpfCollectorKind = 6 // Sampled HTTP payload capture from sockets
+	EbpfCollectorKind_EBPF_COLLECTOR_KIND_TLS_CAPTURE   EbpfCollectorKind = 7 // TLS plaintext capture with uprobes on TLS libraries
 )
 
 // Enum value maps for EbpfCollectorKind.
@@ -214,6 +216,8 @@ var (
 		3: "EBPF_COLLECTOR_KIND_CPU_PROFILE",
 		4: "EBPF_COLLECTOR_KIND_TCP_METRICS",
 		5: "EBPF_COLLECTOR_KIND_UPROBE",
+		6: "EBPF_COLLECTOR_KIND_HTTP_CAPTURE",
+		7: "EBPF_COLLECTOR_KIND_TLS_CAPTURE",
 	}
 	EbpfCollectorKind_value = map[string]int32{
 		"EBPF_COLLECTOR_KIND_UNSPECIFIED":   0,
@@ -222,6 +226,8 @@ var (
 		"EBPF_COLLECTOR_KIND_CPU_PROFILE":   3,
 		"EBPF_COLLECTOR_KIND_TCP_METRICS":   4,
 		"EBPF_COLLECTOR_KIND_UPROBE":        5,
+		"EBPF_COLLECTOR_KIND_HTTP_CAPTURE":  6,
+		"EBPF_COLLECTOR_KIND_TLS_CAPTURE":   7,
 	}
 )
 
@@ -364,9 +370,11 @@ type RuntimeContextResponse struct {
 	// Agent ID.
 	AgentId string `protobuf:"bytes,10,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
 	// Structured mesh info (parity with /status).
-	Wireguard     *v1.MeshTelemetry `protobuf:"bytes,11,opt,name=wireguard,proto3" json:"wireguard,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Wireguard *v1.MeshTelemetry `protobuf:"bytes,11,opt,name=wireguard,proto3" json:"wireguard,omitempty"`
+	// Resource safety valve state (unset when no limits are configured).
+	ResourceShedding *ResourceShedding `protobuf:"bytes,12,opt,name=resource_shedding,json=resourceShedding,proto3" json:"resource_shedding,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *RuntimeContextResponse) Reset() {
@@ -476,6 +484,124 @@ func (x *RuntimeContextResponse) GetWireguard() *v1.MeshTelemetry {
 	return nil
 }
 
+func (x *RuntimeContextResponse) GetResourceShedding() *ResourceShedding {
+	if x != nil {
+		return x.ResourceShedding
+	}
+	return nil
+}
+
+// ResourceShedding reports load shedding by the agent's resource safety valve.
+// When the agent nears its CPU or memory self-limits it sheds load in stages:
+// continuous profiling is paused first, then uprobe sampling is reduced.
+type ResourceShedding struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether any stage is currently shed.
+	Active bool `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	// Shed stages in the order they were applied
+	// ("continuous_profiling", "uprobe_sampling").
+	Stages []string `protobuf:"bytes,2,rep,name=stages,proto3" json:"stages,omitempty"`
+	// Human-readable reason for the last transition.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Agent CPU usage at the last check, in percent of one core.
+	CpuPercent float64 `protobuf:"fixed64,4,opt,name=cpu_percent,json=cpuPercent,proto3" json:"cpu_percent,omitempty"`
+	// Agent resident memory at the last check.
+	MemoryBytes uint64 `protobuf:"varint,5,opt,name=memory_bytes,json=memoryBytes,proto3" json:"memory_bytes,omitempty"`
+	// Configured limits (0 = unlimited).
+	CpuLimitPercent  float64 `protobuf:"fixed64,6,opt,name=cpu_limit_percent,json=cpuLimitPercent,proto3" json:"cpu_limit_percent,omitempty"`
+	MemoryLimitBytes uint64  `protobuf:"varint,7,opt,name=memory_limit_bytes,json=memoryLimitBytes,proto3" json:"memory_limit_bytes,omitempty"`
+	// When the current state was entered.
+	Since         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceShedding) Reset() {
+	*x = ResourceShedding{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceShedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceShedding) ProtoMessage() {}
+
+func (x *ResourceShedding) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceShedding.ProtoReflect.Descriptor instead.
+func (*ResourceShedding) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResourceShedding) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *ResourceShedding) GetStages() []string {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *ResourceShedding) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ResourceShedding) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *ResourceShedding) GetMemoryBytes() uint64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *ResourceShedding) GetCpuLimitPercent() float64 {
+	if x != nil {
+		return x.CpuLimitPercent
+	}
+	return 0
+}
+
+func (x *ResourceShedding) GetMemoryLimitBytes() uint64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (x *ResourceShedding) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
 type PlatformInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Operating system: "linux", "darwin", "windows".
@@ -492,7 +618,7 @@ type PlatformInfo struct {
 
 func (x *PlatformInfo) Reset() {
 	*x = PlatformInfo{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[2]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -504,7 +630,7 @@ func (x *PlatformInfo) String() string {
 func (*PlatformInfo) ProtoMessage() {}
 
 func (x *PlatformInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[2]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -517,7 +643,7 @@ func (x *PlatformInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlatformInfo.ProtoReflect.Descriptor instead.
 func (*PlatformInfo) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{2}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *PlatformInfo) GetOs() string {
@@ -562,7 +688,7 @@ type CRISocketInfo struct {
 
 func (x *CRISocketInfo) Reset() {
 	*x = CRISocketInfo{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[3]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -574,7 +700,7 @@ func (x *CRISocketInfo) String() string {
 func (*CRISocketInfo) ProtoMessage() {}
 
 func (x *CRISocketInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[3]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -587,7 +713,7 @@ func (x *CRISocketInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CRISocketInfo.ProtoReflect.Descriptor instead.
 func (*CRISocketInfo) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{3}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CRISocketInfo) GetPath() string {
@@ -629,7 +755,7 @@ type VisibilityScope struct {
 
 func (x *VisibilityScope) Reset() {
 	*x = VisibilityScope{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[4]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -641,7 +767,7 @@ func (x *VisibilityScope) String() string {
 func (*VisibilityScope) ProtoMessage() {}
 
 func (x *VisibilityScope) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[4]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -654,7 +780,7 @@ func (x *VisibilityScope) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VisibilityScope.ProtoReflect.Descriptor instead.
 func (*VisibilityScope) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{4}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *VisibilityScope) GetAllPids() bool {
@@ -712,7 +838,7 @@ type Capabilities struct {
 
 func (x *Capabilities) Reset() {
 	*x = Capabilities{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[5]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -724,7 +850,7 @@ func (x *Capabilities) String() string {
 func (*Capabilities) ProtoMessage() {}
 
 func (x *Capabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[5]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -737,7 +863,7 @@ func (x *Capabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
 func (*Capabilities) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{5}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Capabilities) GetCanRun() bool {
@@ -810,7 +936,7 @@ type LinuxCapabilities struct {
 
 func (x *LinuxCapabilities) Reset() {
 	*x = LinuxCapabilities{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[6]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -822,7 +948,7 @@ func (x *LinuxCapabilities) String() string {
 func (*LinuxCapabilities) ProtoMessage() {}
 
 func (x *LinuxCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[6]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -835,7 +961,7 @@ func (x *LinuxCapabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LinuxCapabilities.ProtoReflect.Descriptor instead.
 func (*LinuxCapabilities) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{6}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *LinuxCapabilities) GetCapNetAdmin() bool {
@@ -929,7 +1055,7 @@ type ExecCapabilities struct {
 
 func (x *ExecCapabilities) Reset() {
 	*x = ExecCapabilities{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[7]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -941,7 +1067,7 @@ func (x *ExecCapabilities) String() string {
 func (*ExecCapabilities) ProtoMessage() {}
 
 func (x *ExecCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[7]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -954,7 +1080,7 @@ func (x *ExecCapabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecCapabilities.ProtoReflect.Descriptor instead.
 func (*ExecCapabilities) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{7}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ExecCapabilities) GetMode() ExecMode {
@@ -1027,7 +1153,7 @@ type ConnectServiceRequest struct {
 
 func (x *ConnectServiceRequest) Reset() {
 	*x = ConnectServiceRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[8]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1039,7 +1165,7 @@ func (x *ConnectServiceRequest) String() string {
 func (*ConnectServiceRequest) ProtoMessage() {}
 
 func (x *ConnectServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[8]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1052,7 +1178,7 @@ func (x *ConnectServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectServiceRequest.ProtoReflect.Descriptor instead.
 func (*ConnectServiceRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{8}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ConnectServiceRequest) GetName() string {
@@ -1110,15 +1236,17 @@ type ServiceSdkCapabilities struct {
 	HasDwarfSymbols bool   `protobuf:"varint,6,opt,name=has_dwarf_symbols,json=hasDwarfSymbols,proto3" json:"has_dwarf_symbols,omitempty"` // DWARF debug info present
 	FunctionCount   uint32 `protobuf:"varint,7,opt,name=function_count,json=functionCount,proto3" json:"function_count,omitempty"`         // Number of discoverable functions
 	// Binary information
-	BinaryPath    string `protobuf:"bytes,10,opt,name=binary_path,json=binaryPath,proto3" json:"binary_path,omitempty"` // Path to executable
-	BinaryHash    string `protobuf:"bytes,11,opt,name=binary_hash,json=binaryHash,proto3" json:"binary_hash,omitempty"` // Hash for cache invalidation
+	BinaryPath string `protobuf:"bytes,10,opt,name=binary_path,json=binaryPath,proto3" json:"binary_path,omitempty"` // Path to executable
+	BinaryHash string `protobuf:"bytes,11,opt,name=binary_hash,json=binaryHash,proto3" json:"binary_hash,omitempty"` // Hash for cache invalidation
+	// Labels set through the SDK options, added to the service labels.
+	Labels        map[string]string `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ServiceSdkCapabilities) Reset() {
 	*x = ServiceSdkCapabilities{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[9]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1130,7 +1258,7 @@ func (x *ServiceSdkCapabilities) String() string {
 func (*ServiceSdkCapabilities) ProtoMessage() {}
 
 func (x *ServiceSdkCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[9]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1143,7 +1271,7 @@ func (x *ServiceSdkCapabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceSdkCapabilities.ProtoReflect.Descriptor instead.
 func (*ServiceSdkCapabilities) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{9}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ServiceSdkCapabilities) GetServiceName() string {
@@ -1209,6 +1337,13 @@ func (x *ServiceSdkCapabilities) GetBinaryHash() string {
 	return ""
 }
 
+func (x *ServiceSdkCapabilities) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type ConnectServiceResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Success indicator.
@@ -1223,7 +1358,7 @@ type ConnectServiceResponse struct {
 
 func (x *ConnectServiceResponse) Reset() {
 	*x = ConnectServiceResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[10]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1235,7 +1370,7 @@ func (x *ConnectServiceResponse) String() string {
 func (*ConnectServiceResponse) ProtoMessage() {}
 
 func (x *ConnectServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[10]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1248,7 +1383,7 @@ func (x *ConnectServiceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectServiceResponse.ProtoReflect.Descriptor instead.
 func (*ConnectServiceResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{10}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ConnectServiceResponse) GetSuccess() bool {
@@ -1283,7 +1418,7 @@ type DisconnectServiceRequest struct {
 
 func (x *DisconnectServiceRequest) Reset() {
 	*x = DisconnectServiceRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[11]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1295,7 +1430,7 @@ func (x *DisconnectServiceRequest) String() string {
 func (*DisconnectServiceRequest) ProtoMessage() {}
 
 func (x *DisconnectServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[11]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1308,7 +1443,7 @@ func (x *DisconnectServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisconnectServiceRequest.ProtoReflect.Descriptor instead.
 func (*DisconnectServiceRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{11}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DisconnectServiceRequest) GetServiceName() string {
@@ -1330,7 +1465,7 @@ type DisconnectServiceResponse struct {
 
 func (x *DisconnectServiceResponse) Reset() {
 	*x = DisconnectServiceResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[12]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1342,7 +1477,7 @@ func (x *DisconnectServiceResponse) String() string {
 func (*DisconnectServiceResponse) ProtoMessage() {}
 
 func (x *DisconnectServiceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[12]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1355,7 +1490,7 @@ func (x *DisconnectServiceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisconnectServiceResponse.ProtoReflect.Descriptor instead.
 func (*DisconnectServiceResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{12}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DisconnectServiceResponse) GetSuccess() bool {
@@ -1381,7 +1516,7 @@ type ListServicesRequest struct {
 
 func (x *ListServicesRequest) Reset() {
 	*x = ListServicesRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[13]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1393,7 +1528,7 @@ func (x *ListServicesRequest) String() string {
 func (*ListServicesRequest) ProtoMessage() {}
 
 func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[13]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1406,7 +1541,7 @@ func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServicesRequest.ProtoReflect.Descriptor instead.
 func (*ListServicesRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{13}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{14}
 }
 
 type ListServicesResponse struct {
@@ -1419,7 +1554,7 @@ type ListServicesResponse struct {
 
 func (x *ListServicesResponse) Reset() {
 	*x = ListServicesResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[14]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1431,7 +1566,7 @@ func (x *ListServicesResponse) String() string {
 func (*ListServicesResponse) ProtoMessage() {}
 
 func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[14]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1444,7 +1579,7 @@ func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServicesResponse.ProtoReflect.Descriptor instead.
 func (*ListServicesResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{14}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListServicesResponse) GetServices() []*ServiceStatus {
@@ -1482,7 +1617,7 @@ type ServiceStatus struct {
 
 func (x *ServiceStatus) Reset() {
 	*x = ServiceStatus{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[15]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1494,7 +1629,7 @@ func (x *ServiceStatus) String() string {
 func (*ServiceStatus) ProtoMessage() {}
 
 func (x *ServiceStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[15]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1507,7 +1642,7 @@ func (x *ServiceStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceStatus.ProtoReflect.Descriptor instead.
 func (*ServiceStatus) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{15}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ServiceStatus) GetName() string {
@@ -1596,13 +1731,14 @@ type EbpfCapabilities struct {
 	CapBpf              bool                           `protobuf:"varint,4,opt,name=cap_bpf,json=capBpf,proto3" json:"cap_bpf,omitempty"`                     // CAP_BPF capability
 	AvailableCollectors []EbpfCollectorKind            `protobuf:"varint,5,rep,packed,name=available_collectors,json=availableCollectors,proto3,enum=coral.agent.v1.EbpfCollectorKind" json:"available_collectors,omitempty"`
 	EbpfObservability   *EbpfObservabilityCapabilities `protobuf:"bytes,6,opt,name=ebpf_observability,json=ebpfObservability,proto3" json:"ebpf_observability,omitempty"` // eBPF observability capabilities (RFD 032)
+	KernelFeatures      *EbpfKernelFeatures            `protobuf:"bytes,7,opt,name=kernel_features,json=kernelFeatures,proto3" json:"kernel_features,omitempty"`          // probed kernel features used to select program variants
 	unknownFields       protoimpl.UnknownFields
 	sizeCache           protoimpl.SizeCache
 }
 
 func (x *EbpfCapabilities) Reset() {
 	*x = EbpfCapabilities{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[16]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1614,7 +1750,7 @@ func (x *EbpfCapabilities) String() string {
 func (*EbpfCapabilities) ProtoMessage() {}
 
 func (x *EbpfCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[16]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1627,7 +1763,7 @@ func (x *EbpfCapabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EbpfCapabilities.ProtoReflect.Descriptor instead.
 func (*EbpfCapabilities) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{16}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *EbpfCapabilities) GetSupported() bool {
@@ -1672,6 +1808,100 @@ func (x *EbpfCapabilities) GetEbpfObservability() *EbpfObservabilityCapabilities
 	return nil
 }
 
+func (x *EbpfCapabilities) GetKernelFeatures() *EbpfKernelFeatures {
+	if x != nil {
+		return x.KernelFeatures
+	}
+	return nil
+}
+
+// EbpfKernelFeatures describes the kernel eBPF features probed at agent startup.
+// The agent uses them to select compatible program variants (e.g. perf buffer
+// instead of ring buffer) and reports actionable warnings for unsupported kernels.
+type EbpfKernelFeatures struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ringbuf        bool                   `protobuf:"varint,1,opt,name=ringbuf,proto3" json:"ringbuf,omitempty"`                                    // BPF_MAP_TYPE_RINGBUF (kernel 5.8+)
+	Perfbuf        bool                   `protobuf:"varint,2,opt,name=perfbuf,proto3" json:"perfbuf,omitempty"`                                    // BPF_MAP_TYPE_PERF_EVENT_ARRAY
+	BpfLoop        bool                   `protobuf:"varint,3,opt,name=bpf_loop,json=bpfLoop,proto3" json:"bpf_loop,omitempty"`                     // bpf_loop() helper (kernel 5.17+)
+	BtfSource      string                 `protobuf:"bytes,4,opt,name=btf_source,json=btfSource,proto3" json:"btf_source,omitempty"`                // "kernel", "external", or "none"
+	EventTransport string                 `protobuf:"bytes,5,opt,name=event_transport,json=eventTransport,proto3" json:"event_transport,omitempty"` // selected transport: "ringbuf", "perfbuf", or "none"
+	Warnings       []string               `protobuf:"bytes,6,rep,name=warnings,proto3" json:"warnings,omitempty"`                                   // actionable messages for missing features
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *EbpfKernelFeatures) Reset() {
+	*x = EbpfKernelFeatures{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EbpfKernelFeatures) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EbpfKernelFeatures) ProtoMessage() {}
+
+func (x *EbpfKernelFeatures) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EbpfKernelFeatures.ProtoReflect.Descriptor instead.
+func (*EbpfKernelFeatures) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EbpfKernelFeatures) GetRingbuf() bool {
+	if x != nil {
+		return x.Ringbuf
+	}
+	return false
+}
+
+func (x *EbpfKernelFeatures) GetPerfbuf() bool {
+	if x != nil {
+		return x.Perfbuf
+	}
+	return false
+}
+
+func (x *EbpfKernelFeatures) GetBpfLoop() bool {
+	if x != nil {
+		return x.BpfLoop
+	}
+	return false
+}
+
+func (x *EbpfKernelFeatures) GetBtfSource() string {
+	if x != nil {
+		return x.BtfSource
+	}
+	return ""
+}
+
+func (x *EbpfKernelFeatures) GetEventTransport() string {
+	if x != nil {
+		return x.EventTransport
+	}
+	return ""
+}
+
+func (x *EbpfKernelFeatures) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
 // EbpfObservabilityCapabilities describes eBPF observability features (RFD 032).
 type EbpfObservabilityCapabilities struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -1686,7 +1916,7 @@ type EbpfObservabilityCapabilities struct {
 
 func (x *EbpfObservabilityCapabilities) Reset() {
 	*x = EbpfObservabilityCapabilities{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[17]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1698,7 +1928,7 @@ func (x *EbpfObservabilityCapabilities) String() string {
 func (*EbpfObservabilityCapabilities) ProtoMessage() {}
 
 func (x *EbpfObservabilityCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[17]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1711,7 +1941,7 @@ func (x *EbpfObservabilityCapabilities) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EbpfObservabilityCapabilities.ProtoReflect.Descriptor instead.
 func (*EbpfObservabilityCapabilities) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{17}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *EbpfObservabilityCapabilities) GetEnabled() bool {
@@ -1782,7 +2012,7 @@ type TelemetrySpan struct {
 
 func (x *TelemetrySpan) Reset() {
 	*x = TelemetrySpan{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[18]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1794,7 +2024,7 @@ func (x *TelemetrySpan) String() string {
 func (*TelemetrySpan) ProtoMessage() {}
 
 func (x *TelemetrySpan) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[18]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1807,7 +2037,7 @@ func (x *TelemetrySpan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TelemetrySpan.ProtoReflect.Descriptor instead.
 func (*TelemetrySpan) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{18}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *TelemetrySpan) GetTimestamp() int64 {
@@ -1909,7 +2139,7 @@ type QueryTelemetryRequest struct {
 
 func (x *QueryTelemetryRequest) Reset() {
 	*x = QueryTelemetryRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[19]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1921,7 +2151,7 @@ func (x *QueryTelemetryRequest) String() string {
 func (*QueryTelemetryRequest) ProtoMessage() {}
 
 func (x *QueryTelemetryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[19]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1934,7 +2164,7 @@ func (x *QueryTelemetryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryTelemetryRequest.ProtoReflect.Descriptor instead.
 func (*QueryTelemetryRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{19}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *QueryTelemetryRequest) GetServiceNames() []string {
@@ -1975,7 +2205,7 @@ type QueryTelemetryResponse struct {
 
 func (x *QueryTelemetryResponse) Reset() {
 	*x = QueryTelemetryResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[20]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1987,7 +2217,7 @@ func (x *QueryTelemetryResponse) String() string {
 func (*QueryTelemetryResponse) ProtoMessage() {}
 
 func (x *QueryTelemetryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[20]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2000,7 +2230,7 @@ func (x *QueryTelemetryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryTelemetryResponse.ProtoReflect.Descriptor instead.
 func (*QueryTelemetryResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{20}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *QueryTelemetryResponse) GetSpans() []*TelemetrySpan {
@@ -2050,14 +2280,24 @@ type QueryEbpfMetricsRequest struct {
 	SqlStartSeqId    uint64 `protobuf:"varint,8,opt,name=sql_start_seq_id,json=sqlStartSeqId,proto3" json:"sql_start_seq_id,omitempty"`
 	TracesStartSeqId uint64 `protobuf:"varint,9,opt,name=traces_start_seq_id,json=tracesStartSeqId,proto3" json:"traces_start_seq_id,omitempty"`
 	// Maximum number of records to return per metric type. Default: 10000.
-	MaxRecords    int32 `protobuf:"varint,10,opt,name=max_records,json=maxRecords,proto3" json:"max_records,omitempty"`
+	MaxRecords int32 `protobuf:"varint,10,opt,name=max_records,json=maxRecords,proto3" json:"max_records,omitempty"`
+	// Highest seq_id per metric type the colony has durably stored. The agent
+	// keeps records past their retention until they are acknowledged, so data
+	// is not lost while the colony is unreachable. 0 = no acknowledgement.
+	HttpAckSeqId   uint64 `protobuf:"varint,11,opt,name=http_ack_seq_id,json=httpAckSeqId,proto3" json:"http_ack_seq_id,omitempty"`
+	GrpcAckSeqId   uint64 `protobuf:"varint,12,opt,name=grpc_ack_seq_id,json=grpcAckSeqId,proto3" json:"grpc_ack_seq_id,omitempty"`
+	SqlAckSeqId    uint64 `protobuf:"varint,13,opt,name=sql_ack_seq_id,json=sqlAckSeqId,proto3" json:"sql_ack_seq_id,omitempty"`
+	TracesAckSeqId uint64 `protobuf:"varint,14,opt,name=traces_ack_seq_id,json=tracesAckSeqId,proto3" json:"traces_ack_seq_id,omitempty"`
+	// Agent session the acknowledged seq_ids belong to. Acknowledgements for
+	// another session, e.g. of a recreated database, are ignored.
+	AckSessionId  string `protobuf:"bytes,15,opt,name=ack_session_id,json=ackSessionId,proto3" json:"ack_session_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QueryEbpfMetricsRequest) Reset() {
 	*x = QueryEbpfMetricsRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[21]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2069,7 +2309,7 @@ func (x *QueryEbpfMetricsRequest) String() string {
 func (*QueryEbpfMetricsRequest) ProtoMessage() {}
 
 func (x *QueryEbpfMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[21]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2082,7 +2322,7 @@ func (x *QueryEbpfMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryEbpfMetricsRequest.ProtoReflect.Descriptor instead.
 func (*QueryEbpfMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{21}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *QueryEbpfMetricsRequest) GetServiceNames() []string {
@@ -2155,6 +2395,41 @@ func (x *QueryEbpfMetricsRequest) GetMaxRecords() int32 {
 	return 0
 }
 
+func (x *QueryEbpfMetricsRequest) GetHttpAckSeqId() uint64 {
+	if x != nil {
+		return x.HttpAckSeqId
+	}
+	return 0
+}
+
+func (x *QueryEbpfMetricsRequest) GetGrpcAckSeqId() uint64 {
+	if x != nil {
+		return x.GrpcAckSeqId
+	}
+	return 0
+}
+
+func (x *QueryEbpfMetricsRequest) GetSqlAckSeqId() uint64 {
+	if x != nil {
+		return x.SqlAckSeqId
+	}
+	return 0
+}
+
+func (x *QueryEbpfMetricsRequest) GetTracesAckSeqId() uint64 {
+	if x != nil {
+		return x.TracesAckSeqId
+	}
+	return 0
+}
+
+func (x *QueryEbpfMetricsRequest) GetAckSessionId() string {
+	if x != nil {
+		return x.AckSessionId
+	}
+	return ""
+}
+
 // QueryEbpfMetricsResponse contains eBPF metrics from agent's local storage.
 type QueryEbpfMetricsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2183,7 +2458,7 @@ type QueryEbpfMetricsResponse struct {
 
 func (x *QueryEbpfMetricsResponse) Reset() {
 	*x = QueryEbpfMetricsResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[22]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2195,7 +2470,7 @@ func (x *QueryEbpfMetricsResponse) String() string {
 func (*QueryEbpfMetricsResponse) ProtoMessage() {}
 
 func (x *QueryEbpfMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[22]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2208,7 +2483,7 @@ func (x *QueryEbpfMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryEbpfMetricsResponse.ProtoReflect.Descriptor instead.
 func (*QueryEbpfMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{22}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *QueryEbpfMetricsResponse) GetHttpMetrics() []*EbpfHttpMetric {
@@ -2317,7 +2592,7 @@ type EbpfHttpMetric struct {
 
 func (x *EbpfHttpMetric) Reset() {
 	*x = EbpfHttpMetric{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[23]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2329,7 +2604,7 @@ func (x *EbpfHttpMetric) String() string {
 func (*EbpfHttpMetric) ProtoMessage() {}
 
 func (x *EbpfHttpMetric) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[23]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2342,7 +2617,7 @@ func (x *EbpfHttpMetric) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EbpfHttpMetric.ProtoReflect.Descriptor instead.
 func (*EbpfHttpMetric) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{23}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *EbpfHttpMetric) GetTimestamp() int64 {
@@ -2442,7 +2717,7 @@ type EbpfGrpcMetric struct {
 
 func (x *EbpfGrpcMetric) Reset() {
 	*x = EbpfGrpcMetric{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[24]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2454,7 +2729,7 @@ func (x *EbpfGrpcMetric) String() string {
 func (*EbpfGrpcMetric) ProtoMessage() {}
 
 func (x *EbpfGrpcMetric) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[24]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2467,7 +2742,7 @@ func (x *EbpfGrpcMetric) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EbpfGrpcMetric.ProtoReflect.Descriptor instead.
 func (*EbpfGrpcMetric) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{24}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *EbpfGrpcMetric) GetTimestamp() int64 {
@@ -2560,7 +2835,7 @@ type EbpfSqlMetric struct {
 
 func (x *EbpfSqlMetric) Reset() {
 	*x = EbpfSqlMetric{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[25]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2572,7 +2847,7 @@ func (x *EbpfSqlMetric) String() string {
 func (*EbpfSqlMetric) ProtoMessage() {}
 
 func (x *EbpfSqlMetric) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[25]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2585,7 +2860,7 @@ func (x *EbpfSqlMetric) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EbpfSqlMetric.ProtoReflect.Descriptor instead.
 func (*EbpfSqlMetric) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{25}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *EbpfSqlMetric) GetTimestamp() int64 {
@@ -2682,7 +2957,7 @@ type EbpfTraceSpan struct {
 
 func (x *EbpfTraceSpan) Reset() {
 	*x = EbpfTraceSpan{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[26]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2694,7 +2969,7 @@ func (x *EbpfTraceSpan) String() string {
 func (*EbpfTraceSpan) ProtoMessage() {}
 
 func (x *EbpfTraceSpan) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[26]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2707,7 +2982,7 @@ func (x *EbpfTraceSpan) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EbpfTraceSpan.ProtoReflect.Descriptor instead.
 func (*EbpfTraceSpan) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{26}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *EbpfTraceSpan) GetTraceId() string {
@@ -2802,7 +3077,7 @@ type ShellRequest struct {
 
 func (x *ShellRequest) Reset() {
 	*x = ShellRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[27]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2814,7 +3089,7 @@ func (x *ShellRequest) String() string {
 func (*ShellRequest) ProtoMessage() {}
 
 func (x *ShellRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[27]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2827,7 +3102,7 @@ func (x *ShellRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShellRequest.ProtoReflect.Descriptor instead.
 func (*ShellRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{27}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ShellRequest) GetPayload() isShellRequest_Payload {
@@ -2902,19 +3177,24 @@ func (*ShellRequest_Resize) isShellRequest_Payload() {}
 func (*ShellRequest_Signal) isShellRequest_Payload() {}
 
 type ShellStart struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Shell         string                 `protobuf:"bytes,1,opt,name=shell,proto3" json:"shell,omitempty"`                                                                       // /bin/bash, /bin/sh (default: /bin/bash)
-	Env           map[string]string      `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional environment variables
-	Size          *TerminalSize          `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`                                                                         // Initial terminal size
-	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                       // User making request (for audit)
-	ApprovalId    string                 `protobuf:"bytes,5,opt,name=approval_id,json=approvalId,proto3" json:"approval_id,omitempty"`                                           // Approval request ID (if required)
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Shell      string                 `protobuf:"bytes,1,opt,name=shell,proto3" json:"shell,omitempty"`                                                                       // /bin/bash, /bin/sh (default: /bin/bash)
+	Env        map[string]string      `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Additional environment variables
+	Size       *TerminalSize          `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`                                                                         // Initial terminal size
+	UserId     string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                       // User making request (for audit)
+	ApprovalId string                 `protobuf:"bytes,5,opt,name=approval_id,json=approvalId,proto3" json:"approval_id,omitempty"`                                           // Approval request ID (if required)
+	// ExecInteractive only.
+	Command       []string `protobuf:"bytes,6,rep,name=command,proto3" json:"command,omitempty"`                                  // Command and arguments (default: the shell)
+	InContainer   bool     `protobuf:"varint,7,opt,name=in_container,json=inContainer,proto3" json:"in_container,omitempty"`      // Run in the namespaces of the monitored container
+	ContainerName string   `protobuf:"bytes,8,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"` // Container name (optional in sidecar mode)
+	Namespaces    []string `protobuf:"bytes,9,rep,name=namespaces,proto3" json:"namespaces,omitempty"`                            // Namespaces to enter (default: ["mnt"])
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ShellStart) Reset() {
 	*x = ShellStart{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[28]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2926,7 +3206,7 @@ func (x *ShellStart) String() string {
 func (*ShellStart) ProtoMessage() {}
 
 func (x *ShellStart) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[28]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2939,7 +3219,7 @@ func (x *ShellStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShellStart.ProtoReflect.Descriptor instead.
 func (*ShellStart) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{28}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ShellStart) GetShell() string {
@@ -2977,6 +3257,34 @@ func (x *ShellStart) GetApprovalId() string {
 	return ""
 }
 
+func (x *ShellStart) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *ShellStart) GetInContainer() bool {
+	if x != nil {
+		return x.InContainer
+	}
+	return false
+}
+
+func (x *ShellStart) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *ShellStart) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
 type ShellResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Payload:
@@ -2990,7 +3298,7 @@ type ShellResponse struct {
 
 func (x *ShellResponse) Reset() {
 	*x = ShellResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[29]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3002,7 +3310,7 @@ func (x *ShellResponse) String() string {
 func (*ShellResponse) ProtoMessage() {}
 
 func (x *ShellResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[29]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3015,7 +3323,7 @@ func (x *ShellResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShellResponse.ProtoReflect.Descriptor instead.
 func (*ShellResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{29}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *ShellResponse) GetPayload() isShellResponse_Payload {
@@ -3069,7 +3377,7 @@ type ShellExit struct {
 
 func (x *ShellExit) Reset() {
 	*x = ShellExit{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[30]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3081,7 +3389,7 @@ func (x *ShellExit) String() string {
 func (*ShellExit) ProtoMessage() {}
 
 func (x *ShellExit) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[30]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3094,7 +3402,7 @@ func (x *ShellExit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShellExit.ProtoReflect.Descriptor instead.
 func (*ShellExit) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{30}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ShellExit) GetExitCode() int32 {
@@ -3121,7 +3429,7 @@ type TerminalSize struct {
 
 func (x *TerminalSize) Reset() {
 	*x = TerminalSize{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[31]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3133,7 +3441,7 @@ func (x *TerminalSize) String() string {
 func (*TerminalSize) ProtoMessage() {}
 
 func (x *TerminalSize) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[31]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3146,7 +3454,7 @@ func (x *TerminalSize) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TerminalSize.ProtoReflect.Descriptor instead.
 func (*TerminalSize) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{31}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *TerminalSize) GetRows() uint32 {
@@ -3173,7 +3481,7 @@ type ShellResize struct {
 
 func (x *ShellResize) Reset() {
 	*x = ShellResize{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[32]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3185,7 +3493,7 @@ func (x *ShellResize) String() string {
 func (*ShellResize) ProtoMessage() {}
 
 func (x *ShellResize) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[32]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3198,7 +3506,7 @@ func (x *ShellResize) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShellResize.ProtoReflect.Descriptor instead.
 func (*ShellResize) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{32}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *ShellResize) GetRows() uint32 {
@@ -3224,7 +3532,7 @@ type ShellSignal struct {
 
 func (x *ShellSignal) Reset() {
 	*x = ShellSignal{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[33]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3236,7 +3544,7 @@ func (x *ShellSignal) String() string {
 func (*ShellSignal) ProtoMessage() {}
 
 func (x *ShellSignal) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[33]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3249,7 +3557,7 @@ func (x *ShellSignal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShellSignal.ProtoReflect.Descriptor instead.
 func (*ShellSignal) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{33}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ShellSignal) GetSignal() string {
@@ -3270,7 +3578,7 @@ type ResizeShellTerminalRequest struct {
 
 func (x *ResizeShellTerminalRequest) Reset() {
 	*x = ResizeShellTerminalRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[34]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3282,7 +3590,7 @@ func (x *ResizeShellTerminalRequest) String() string {
 func (*ResizeShellTerminalRequest) ProtoMessage() {}
 
 func (x *ResizeShellTerminalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[34]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3295,7 +3603,7 @@ func (x *ResizeShellTerminalRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResizeShellTerminalRequest.ProtoReflect.Descriptor instead.
 func (*ResizeShellTerminalRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{34}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *ResizeShellTerminalRequest) GetSessionId() string {
@@ -3329,7 +3637,7 @@ type ResizeShellTerminalResponse struct {
 
 func (x *ResizeShellTerminalResponse) Reset() {
 	*x = ResizeShellTerminalResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[35]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3341,7 +3649,7 @@ func (x *ResizeShellTerminalResponse) String() string {
 func (*ResizeShellTerminalResponse) ProtoMessage() {}
 
 func (x *ResizeShellTerminalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[35]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3354,7 +3662,7 @@ func (x *ResizeShellTerminalResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResizeShellTerminalResponse.ProtoReflect.Descriptor instead.
 func (*ResizeShellTerminalResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{35}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ResizeShellTerminalResponse) GetSuccess() bool {
@@ -3381,7 +3689,7 @@ type SendShellSignalRequest struct {
 
 func (x *SendShellSignalRequest) Reset() {
 	*x = SendShellSignalRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[36]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3393,7 +3701,7 @@ func (x *SendShellSignalRequest) String() string {
 func (*SendShellSignalRequest) ProtoMessage() {}
 
 func (x *SendShellSignalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[36]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3406,7 +3714,7 @@ func (x *SendShellSignalRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendShellSignalRequest.ProtoReflect.Descriptor instead.
 func (*SendShellSignalRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{36}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *SendShellSignalRequest) GetSessionId() string {
@@ -3433,7 +3741,7 @@ type SendShellSignalResponse struct {
 
 func (x *SendShellSignalResponse) Reset() {
 	*x = SendShellSignalResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[37]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3445,7 +3753,7 @@ func (x *SendShellSignalResponse) String() string {
 func (*SendShellSignalResponse) ProtoMessage() {}
 
 func (x *SendShellSignalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[37]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3458,7 +3766,7 @@ func (x *SendShellSignalResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendShellSignalResponse.ProtoReflect.Descriptor instead.
 func (*SendShellSignalResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{37}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *SendShellSignalResponse) GetSuccess() bool {
@@ -3484,7 +3792,7 @@ type KillShellSessionRequest struct {
 
 func (x *KillShellSessionRequest) Reset() {
 	*x = KillShellSessionRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[38]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3496,7 +3804,7 @@ func (x *KillShellSessionRequest) String() string {
 func (*KillShellSessionRequest) ProtoMessage() {}
 
 func (x *KillShellSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[38]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3509,7 +3817,7 @@ func (x *KillShellSessionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KillShellSessionRequest.ProtoReflect.Descriptor instead.
 func (*KillShellSessionRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{38}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *KillShellSessionRequest) GetSessionId() string {
@@ -3529,7 +3837,7 @@ type KillShellSessionResponse struct {
 
 func (x *KillShellSessionResponse) Reset() {
 	*x = KillShellSessionResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[39]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3541,7 +3849,7 @@ func (x *KillShellSessionResponse) String() string {
 func (*KillShellSessionResponse) ProtoMessage() {}
 
 func (x *KillShellSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[39]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3554,7 +3862,7 @@ func (x *KillShellSessionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KillShellSessionResponse.ProtoReflect.Descriptor instead.
 func (*KillShellSessionResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{39}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *KillShellSessionResponse) GetSuccess() bool {
@@ -3571,38 +3879,31 @@ func (x *KillShellSessionResponse) GetError() string {
 	return ""
 }
 
-type ShellExecRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Command as array (no shell interpretation - prevents injection).
-	// Example: ["ps", "aux"] or ["tcpdump", "-i", "any", "port", "80"]
-	Command []string `protobuf:"bytes,1,rep,name=command,proto3" json:"command,omitempty"`
-	// User making request (for audit).
-	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	// Timeout in seconds (default: 30, max: 300).
-	TimeoutSeconds uint32 `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
-	// Working directory (default: agent's working dir).
-	WorkingDir string `protobuf:"bytes,4,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
-	// Additional environment variables.
-	Env           map[string]string `protobuf:"bytes,5,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+type FileInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`  // Absolute path on the agent host
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"` // Size in bytes
+	Mode          uint32                 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"` // Permission bits
+	ModTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=mod_time,json=modTime,proto3" json:"mod_time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShellExecRequest) Reset() {
-	*x = ShellExecRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[40]
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShellExecRequest) String() string {
+func (x *FileInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShellExecRequest) ProtoMessage() {}
+func (*FileInfo) ProtoMessage() {}
 
-func (x *ShellExecRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[40]
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3613,79 +3914,63 @@ func (x *ShellExecRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShellExecRequest.ProtoReflect.Descriptor instead.
-func (*ShellExecRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{40}
-}
-
-func (x *ShellExecRequest) GetCommand() []string {
-	if x != nil {
-		return x.Command
-	}
-	return nil
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ShellExecRequest) GetUserId() string {
+func (x *FileInfo) GetPath() string {
 	if x != nil {
-		return x.UserId
+		return x.Path
 	}
 	return ""
 }
 
-func (x *ShellExecRequest) GetTimeoutSeconds() uint32 {
+func (x *FileInfo) GetSize() int64 {
 	if x != nil {
-		return x.TimeoutSeconds
+		return x.Size
 	}
 	return 0
 }
 
-func (x *ShellExecRequest) GetWorkingDir() string {
+func (x *FileInfo) GetMode() uint32 {
 	if x != nil {
-		return x.WorkingDir
+		return x.Mode
 	}
-	return ""
+	return 0
 }
 
-func (x *ShellExecRequest) GetEnv() map[string]string {
+func (x *FileInfo) GetModTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Env
+		return x.ModTime
 	}
 	return nil
 }
 
-type ShellExecResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Standard output from command.
-	Stdout []byte `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
-	// Standard error from command.
-	Stderr []byte `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
-	// Exit code from command.
-	ExitCode int32 `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
-	// Session ID for audit reference.
-	SessionId string `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	// Execution duration in milliseconds.
-	DurationMs uint32 `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
-	// Error message if execution failed (timeout, command not found, etc.).
-	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+type ReadFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                          // Absolute path on the agent host
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`        // User making request (for audit)
+	MaxBytes      int64                  `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"` // Largest file to copy (default and cap: the agent's limit)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShellExecResponse) Reset() {
-	*x = ShellExecResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[41]
+func (x *ReadFileRequest) Reset() {
+	*x = ReadFileRequest{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShellExecResponse) String() string {
+func (x *ReadFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShellExecResponse) ProtoMessage() {}
+func (*ReadFileRequest) ProtoMessage() {}
 
-func (x *ShellExecResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[41]
+func (x *ReadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3696,38 +3981,499 @@ func (x *ShellExecResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShellExecResponse.ProtoReflect.Descriptor instead.
-func (*ShellExecResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use ReadFileRequest.ProtoReflect.Descriptor instead.
+func (*ReadFileRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ShellExecResponse) GetStdout() []byte {
+func (x *ReadFileRequest) GetPath() string {
 	if x != nil {
-		return x.Stdout
+		return x.Path
 	}
-	return nil
+	return ""
 }
 
-func (x *ShellExecResponse) GetStderr() []byte {
+func (x *ReadFileRequest) GetUserId() string {
 	if x != nil {
-		return x.Stderr
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-func (x *ShellExecResponse) GetExitCode() int32 {
+func (x *ReadFileRequest) GetMaxBytes() int64 {
 	if x != nil {
-		return x.ExitCode
+		return x.MaxBytes
 	}
 	return 0
 }
 
-func (x *ShellExecResponse) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
+type ReadFileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ReadFileResponse_Info
+	//	*ReadFileResponse_Data
+	//	*ReadFileResponse_Sha256
+	Payload       isReadFileResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReadFileResponse) Reset() {
+	*x = ReadFileResponse{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadFileResponse) ProtoMessage() {}
+
+func (x *ReadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadFileResponse.ProtoReflect.Descriptor instead.
+func (*ReadFileResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ReadFileResponse) GetPayload() isReadFileResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ReadFileResponse) GetInfo() *FileInfo {
+	if x != nil {
+		if x, ok := x.Payload.(*ReadFileResponse_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *ReadFileResponse) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ReadFileResponse_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *ReadFileResponse) GetSha256() string {
+	if x != nil {
+		if x, ok := x.Payload.(*ReadFileResponse_Sha256); ok {
+			return x.Sha256
+		}
+	}
+	return ""
+}
+
+type isReadFileResponse_Payload interface {
+	isReadFileResponse_Payload()
+}
+
+type ReadFileResponse_Info struct {
+	Info *FileInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"` // First message
+}
+
+type ReadFileResponse_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"` // Next chunk of content
+}
+
+type ReadFileResponse_Sha256 struct {
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3,oneof"` // Last message: hex SHA-256 of the content
+}
+
+func (*ReadFileResponse_Info) isReadFileResponse_Payload() {}
+
+func (*ReadFileResponse_Data) isReadFileResponse_Payload() {}
+
+func (*ReadFileResponse_Sha256) isReadFileResponse_Payload() {}
+
+type WriteFileStart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Info          *FileInfo              `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`                   // Destination path, size and mode
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // User making request (for audit)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteFileStart) Reset() {
+	*x = WriteFileStart{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteFileStart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteFileStart) ProtoMessage() {}
+
+func (x *WriteFileStart) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteFileStart.ProtoReflect.Descriptor instead.
+func (*WriteFileStart) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *WriteFileStart) GetInfo() *FileInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *WriteFileStart) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type WriteFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*WriteFileRequest_Start
+	//	*WriteFileRequest_Data
+	//	*WriteFileRequest_Sha256
+	Payload       isWriteFileRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteFileRequest) Reset() {
+	*x = WriteFileRequest{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteFileRequest) ProtoMessage() {}
+
+func (x *WriteFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteFileRequest.ProtoReflect.Descriptor instead.
+func (*WriteFileRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *WriteFileRequest) GetPayload() isWriteFileRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *WriteFileRequest) GetStart() *WriteFileStart {
+	if x != nil {
+		if x, ok := x.Payload.(*WriteFileRequest_Start); ok {
+			return x.Start
+		}
+	}
+	return nil
+}
+
+func (x *WriteFileRequest) GetData() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*WriteFileRequest_Data); ok {
+			return x.Data
+		}
+	}
+	return nil
+}
+
+func (x *WriteFileRequest) GetSha256() string {
+	if x != nil {
+		if x, ok := x.Payload.(*WriteFileRequest_Sha256); ok {
+			return x.Sha256
+		}
+	}
+	return ""
+}
+
+type isWriteFileRequest_Payload interface {
+	isWriteFileRequest_Payload()
+}
+
+type WriteFileRequest_Start struct {
+	Start *WriteFileStart `protobuf:"bytes,1,opt,name=start,proto3,oneof"` // First message
+}
+
+type WriteFileRequest_Data struct {
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3,oneof"` // Next chunk of content
+}
+
+type WriteFileRequest_Sha256 struct {
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3,oneof"` // Last message: hex SHA-256 of the content
+}
+
+func (*WriteFileRequest_Start) isWriteFileRequest_Payload() {}
+
+func (*WriteFileRequest_Data) isWriteFileRequest_Payload() {}
+
+func (*WriteFileRequest_Sha256) isWriteFileRequest_Payload() {}
+
+type WriteFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	BytesWritten  int64                  `protobuf:"varint,2,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteFileResponse) Reset() {
+	*x = WriteFileResponse{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteFileResponse) ProtoMessage() {}
+
+func (x *WriteFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteFileResponse.ProtoReflect.Descriptor instead.
+func (*WriteFileResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *WriteFileResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *WriteFileResponse) GetBytesWritten() int64 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *WriteFileResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type ShellExecRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Command as array (no shell interpretation - prevents injection).
+	// Example: ["ps", "aux"] or ["tcpdump", "-i", "any", "port", "80"]
+	Command []string `protobuf:"bytes,1,rep,name=command,proto3" json:"command,omitempty"`
+	// User making request (for audit).
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Timeout in seconds (default: 30, max: 300).
+	TimeoutSeconds uint32 `protobuf:"varint,3,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// Working directory (default: agent's working dir).
+	WorkingDir string `protobuf:"bytes,4,opt,name=working_dir,json=workingDir,proto3" json:"working_dir,omitempty"`
+	// Additional environment variables.
+	Env           map[string]string `protobuf:"bytes,5,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShellExecRequest) Reset() {
+	*x = ShellExecRequest{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellExecRequest) ProtoMessage() {}
+
+func (x *ShellExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellExecRequest.ProtoReflect.Descriptor instead.
+func (*ShellExecRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ShellExecRequest) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *ShellExecRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShellExecRequest) GetTimeoutSeconds() uint32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ShellExecRequest) GetWorkingDir() string {
+	if x != nil {
+		return x.WorkingDir
+	}
+	return ""
+}
+
+func (x *ShellExecRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+type ShellExecResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Standard output from command.
+	Stdout []byte `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	// Standard error from command.
+	Stderr []byte `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// Exit code from command.
+	ExitCode int32 `protobuf:"varint,3,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// Session ID for audit reference.
+	SessionId string `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Execution duration in milliseconds.
+	DurationMs uint32 `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Error message if execution failed (timeout, command not found, etc.).
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	// Set when the agent masked credentials or personal data in the output.
+	Redacted      bool `protobuf:"varint,7,opt,name=redacted,proto3" json:"redacted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShellExecResponse) Reset() {
+	*x = ShellExecResponse{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellExecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellExecResponse) ProtoMessage() {}
+
+func (x *ShellExecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellExecResponse.ProtoReflect.Descriptor instead.
+func (*ShellExecResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ShellExecResponse) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *ShellExecResponse) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *ShellExecResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ShellExecResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
 
 func (x *ShellExecResponse) GetDurationMs() uint32 {
 	if x != nil {
@@ -3743,6 +4489,13 @@ func (x *ShellExecResponse) GetError() string {
 	return ""
 }
 
+func (x *ShellExecResponse) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
 type ContainerExecRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Container name (optional in sidecar mode - defaults to main container).
@@ -3767,7 +4520,7 @@ type ContainerExecRequest struct {
 
 func (x *ContainerExecRequest) Reset() {
 	*x = ContainerExecRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[42]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3779,7 +4532,7 @@ func (x *ContainerExecRequest) String() string {
 func (*ContainerExecRequest) ProtoMessage() {}
 
 func (x *ContainerExecRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[42]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3792,7 +4545,7 @@ func (x *ContainerExecRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContainerExecRequest.ProtoReflect.Descriptor instead.
 func (*ContainerExecRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{42}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *ContainerExecRequest) GetContainerName() string {
@@ -3862,13 +4615,15 @@ type ContainerExecResponse struct {
 	ContainerPid int32 `protobuf:"varint,7,opt,name=container_pid,json=containerPid,proto3" json:"container_pid,omitempty"`
 	// Namespaces that were entered.
 	NamespacesEntered []string `protobuf:"bytes,8,rep,name=namespaces_entered,json=namespacesEntered,proto3" json:"namespaces_entered,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// Set when the agent masked credentials or personal data in the output.
+	Redacted      bool `protobuf:"varint,9,opt,name=redacted,proto3" json:"redacted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ContainerExecResponse) Reset() {
 	*x = ContainerExecResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[43]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3880,7 +4635,7 @@ func (x *ContainerExecResponse) String() string {
 func (*ContainerExecResponse) ProtoMessage() {}
 
 func (x *ContainerExecResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[43]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3893,7 +4648,7 @@ func (x *ContainerExecResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ContainerExecResponse.ProtoReflect.Descriptor instead.
 func (*ContainerExecResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{43}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *ContainerExecResponse) GetStdout() []byte {
@@ -3952,6 +4707,13 @@ func (x *ContainerExecResponse) GetNamespacesEntered() []string {
 	return nil
 }
 
+func (x *ContainerExecResponse) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
 type DebugEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
@@ -3965,7 +4727,7 @@ type DebugEvent struct {
 
 func (x *DebugEvent) Reset() {
 	*x = DebugEvent{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[44]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3977,7 +4739,7 @@ func (x *DebugEvent) String() string {
 func (*DebugEvent) ProtoMessage() {}
 
 func (x *DebugEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[44]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3990,7 +4752,7 @@ func (x *DebugEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DebugEvent.ProtoReflect.Descriptor instead.
 func (*DebugEvent) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{44}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *DebugEvent) GetSessionId() string {
@@ -4038,7 +4800,7 @@ type DebugCommand struct {
 
 func (x *DebugCommand) Reset() {
 	*x = DebugCommand{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[45]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4050,7 +4812,7 @@ func (x *DebugCommand) String() string {
 func (*DebugCommand) ProtoMessage() {}
 
 func (x *DebugCommand) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[45]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4063,7 +4825,7 @@ func (x *DebugCommand) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DebugCommand.ProtoReflect.Descriptor instead.
 func (*DebugCommand) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{45}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *DebugCommand) GetSessionId() string {
@@ -4091,7 +4853,7 @@ type GetFunctionsRequest struct {
 
 func (x *GetFunctionsRequest) Reset() {
 	*x = GetFunctionsRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[46]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4103,7 +4865,7 @@ func (x *GetFunctionsRequest) String() string {
 func (*GetFunctionsRequest) ProtoMessage() {}
 
 func (x *GetFunctionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[46]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4116,7 +4878,7 @@ func (x *GetFunctionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionsRequest.ProtoReflect.Descriptor instead.
 func (*GetFunctionsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{46}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{54}
 }
 
 func (x *GetFunctionsRequest) GetServiceName() string {
@@ -4138,7 +4900,7 @@ type GetFunctionsResponse struct {
 
 func (x *GetFunctionsResponse) Reset() {
 	*x = GetFunctionsResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[47]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4150,7 +4912,7 @@ func (x *GetFunctionsResponse) String() string {
 func (*GetFunctionsResponse) ProtoMessage() {}
 
 func (x *GetFunctionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[47]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4163,7 +4925,7 @@ func (x *GetFunctionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFunctionsResponse.ProtoReflect.Descriptor instead.
 func (*GetFunctionsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{47}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{55}
 }
 
 func (x *GetFunctionsResponse) GetFunctions() []*FunctionInfo {
@@ -4204,7 +4966,7 @@ type FunctionInfo struct {
 
 func (x *FunctionInfo) Reset() {
 	*x = FunctionInfo{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[48]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4216,7 +4978,7 @@ func (x *FunctionInfo) String() string {
 func (*FunctionInfo) ProtoMessage() {}
 
 func (x *FunctionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[48]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4229,7 +4991,7 @@ func (x *FunctionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionInfo.ProtoReflect.Descriptor instead.
 func (*FunctionInfo) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{48}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{56}
 }
 
 func (x *FunctionInfo) GetName() string {
@@ -4311,7 +5073,7 @@ type SystemMetric struct {
 
 func (x *SystemMetric) Reset() {
 	*x = SystemMetric{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[49]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4323,7 +5085,7 @@ func (x *SystemMetric) String() string {
 func (*SystemMetric) ProtoMessage() {}
 
 func (x *SystemMetric) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[49]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4336,7 +5098,7 @@ func (x *SystemMetric) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SystemMetric.ProtoReflect.Descriptor instead.
 func (*SystemMetric) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{49}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{57}
 }
 
 func (x *SystemMetric) GetTimestamp() int64 {
@@ -4403,7 +5165,7 @@ type QuerySystemMetricsRequest struct {
 
 func (x *QuerySystemMetricsRequest) Reset() {
 	*x = QuerySystemMetricsRequest{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[50]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4415,7 +5177,7 @@ func (x *QuerySystemMetricsRequest) String() string {
 func (*QuerySystemMetricsRequest) ProtoMessage() {}
 
 func (x *QuerySystemMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[50]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4428,7 +5190,7 @@ func (x *QuerySystemMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuerySystemMetricsRequest.ProtoReflect.Descriptor instead.
 func (*QuerySystemMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{50}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *QuerySystemMetricsRequest) GetMetricNames() []string {
@@ -4469,7 +5231,7 @@ type QuerySystemMetricsResponse struct {
 
 func (x *QuerySystemMetricsResponse) Reset() {
 	*x = QuerySystemMetricsResponse{}
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[51]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4481,7 +5243,7 @@ func (x *QuerySystemMetricsResponse) String() string {
 func (*QuerySystemMetricsResponse) ProtoMessage() {}
 
 func (x *QuerySystemMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_agent_proto_msgTypes[51]
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4494,7 +5256,7 @@ func (x *QuerySystemMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QuerySystemMetricsResponse.ProtoReflect.Descriptor instead.
 func (*QuerySystemMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{51}
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *QuerySystemMetricsResponse) GetMetrics() []*SystemMetric {
@@ -4525,12 +5287,104 @@ func (x *QuerySystemMetricsResponse) GetSessionId() string {
 	return ""
 }
 
+// UpdateColonySecretRequest carries a new colony secret.
+type UpdateColonySecretRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The new colony secret.
+	ColonySecret string `protobuf:"bytes,1,opt,name=colony_secret,json=colonySecret,proto3" json:"colony_secret,omitempty"`
+	// HMAC-SHA256 of the new secret keyed with the secret the agent holds,
+	// proving the update comes from the colony.
+	Proof         []byte `protobuf:"bytes,2,opt,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateColonySecretRequest) Reset() {
+	*x = UpdateColonySecretRequest{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateColonySecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateColonySecretRequest) ProtoMessage() {}
+
+func (x *UpdateColonySecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateColonySecretRequest.ProtoReflect.Descriptor instead.
+func (*UpdateColonySecretRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *UpdateColonySecretRequest) GetColonySecret() string {
+	if x != nil {
+		return x.ColonySecret
+	}
+	return ""
+}
+
+func (x *UpdateColonySecretRequest) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+type UpdateColonySecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateColonySecretResponse) Reset() {
+	*x = UpdateColonySecretResponse{}
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateColonySecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateColonySecretResponse) ProtoMessage() {}
+
+func (x *UpdateColonySecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_agent_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateColonySecretResponse.ProtoReflect.Descriptor instead.
+func (*UpdateColonySecretResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_agent_proto_rawDescGZIP(), []int{61}
+}
+
 var File_coral_agent_v1_agent_proto protoreflect.FileDescriptor
 
 const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\n" +
 	"\x1acoral/agent/v1/agent.proto\x12\x0ecoral.agent.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1ecoral/network/v1/network.proto\"\x1a\n" +
-	"\x18GetRuntimeContextRequest\"\x96\x05\n" +
+	"\x18GetRuntimeContextRequest\"\xe5\x05\n" +
 	"\x16RuntimeContextResponse\x128\n" +
 	"\bplatform\x18\x01 \x01(\v2\x1c.coral.agent.v1.PlatformInfoR\bplatform\x12A\n" +
 	"\fruntime_type\x18\x02 \x01(\x0e2\x1e.coral.agent.v1.RuntimeContextR\vruntimeType\x12>\n" +
@@ -4547,7 +5401,18 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\x11ebpf_capabilities\x18\t \x01(\v2 .coral.agent.v1.EbpfCapabilitiesR\x10ebpfCapabilities\x12\x19\n" +
 	"\bagent_id\x18\n" +
 	" \x01(\tR\aagentId\x12=\n" +
-	"\twireguard\x18\v \x01(\v2\x1f.coral.network.v1.MeshTelemetryR\twireguard\"i\n" +
+	"\twireguard\x18\v \x01(\v2\x1f.coral.network.v1.MeshTelemetryR\twireguard\x12M\n" +
+	"\x11resource_shedding\x18\f \x01(\v2 .coral.agent.v1.ResourceSheddingR\x10resourceShedding\"\xaa\x02\n" +
+	"\x10ResourceShedding\x12\x16\n" +
+	"\x06active\x18\x01 \x01(\bR\x06active\x12\x16\n" +
+	"\x06stages\x18\x02 \x03(\tR\x06stages\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1f\n" +
+	"\vcpu_percent\x18\x04 \x01(\x01R\n" +
+	"cpuPercent\x12!\n" +
+	"\fmemory_bytes\x18\x05 \x01(\x04R\vmemoryBytes\x12*\n" +
+	"\x11cpu_limit_percent\x18\x06 \x01(\x01R\x0fcpuLimitPercent\x12,\n" +
+	"\x12memory_limit_bytes\x18\a \x01(\x04R\x10memoryLimitBytes\x120\n" +
+	"\x05since\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"i\n" +
 	"\fPlatformInfo\x12\x0e\n" +
 	"\x02os\x18\x01 \x01(\tR\x02os\x12\x12\n" +
 	"\x04arch\x18\x02 \x01(\tR\x04arch\x12\x1d\n" +
@@ -4605,7 +5470,7 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\x10sdk_capabilities\x18\x06 \x01(\v2&.coral.agent.v1.ServiceSdkCapabilitiesR\x0fsdkCapabilities\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcc\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd3\x03\n" +
 	"\x16ServiceSdkCapabilities\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1d\n" +
 	"\n" +
@@ -4621,7 +5486,11 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	" \x01(\tR\n" +
 	"binaryPath\x12\x1f\n" +
 	"\vbinary_hash\x18\v \x01(\tR\n" +
-	"binaryHash\"k\n" +
+	"binaryHash\x12J\n" +
+	"\x06labels\x18\f \x03(\v22.coral.agent.v1.ServiceSdkCapabilities.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"k\n" +
 	"\x16ConnectServiceResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12!\n" +
@@ -4653,14 +5522,23 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"binaryHash\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc9\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x96\x03\n" +
 	"\x10EbpfCapabilities\x12\x1c\n" +
 	"\tsupported\x18\x01 \x01(\bR\tsupported\x12%\n" +
 	"\x0ekernel_version\x18\x02 \x01(\tR\rkernelVersion\x12#\n" +
 	"\rbtf_available\x18\x03 \x01(\bR\fbtfAvailable\x12\x17\n" +
 	"\acap_bpf\x18\x04 \x01(\bR\x06capBpf\x12T\n" +
 	"\x14available_collectors\x18\x05 \x03(\x0e2!.coral.agent.v1.EbpfCollectorKindR\x13availableCollectors\x12\\\n" +
-	"\x12ebpf_observability\x18\x06 \x01(\v2-.coral.agent.v1.EbpfObservabilityCapabilitiesR\x11ebpfObservability\"\xdc\x01\n" +
+	"\x12ebpf_observability\x18\x06 \x01(\v2-.coral.agent.v1.EbpfObservabilityCapabilitiesR\x11ebpfObservability\x12K\n" +
+	"\x0fkernel_features\x18\a \x01(\v2\".coral.agent.v1.EbpfKernelFeaturesR\x0ekernelFeatures\"\xc7\x01\n" +
+	"\x12EbpfKernelFeatures\x12\x18\n" +
+	"\aringbuf\x18\x01 \x01(\bR\aringbuf\x12\x18\n" +
+	"\aperfbuf\x18\x02 \x01(\bR\aperfbuf\x12\x19\n" +
+	"\bbpf_loop\x18\x03 \x01(\bR\abpfLoop\x12\x1d\n" +
+	"\n" +
+	"btf_source\x18\x04 \x01(\tR\tbtfSource\x12'\n" +
+	"\x0fevent_transport\x18\x05 \x01(\tR\x0eeventTransport\x12\x1a\n" +
+	"\bwarnings\x18\x06 \x03(\tR\bwarnings\"\xdc\x01\n" +
 	"\x1dEbpfObservabilityCapabilities\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12/\n" +
@@ -4703,7 +5581,7 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\n" +
 	"max_seq_id\x18\x03 \x01(\x04R\bmaxSeqId\x12\x1d\n" +
 	"\n" +
-	"session_id\x18\x04 \x01(\tR\tsessionId\"\xb1\x03\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\"\xf5\x04\n" +
 	"\x17QueryEbpfMetricsRequest\x12#\n" +
 	"\rservice_names\x18\x01 \x03(\tR\fserviceNames\x12A\n" +
 	"\fmetric_types\x18\x02 \x03(\x0e2\x1e.coral.agent.v1.EbpfMetricTypeR\vmetricTypes\x12\x19\n" +
@@ -4717,7 +5595,12 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\x13traces_start_seq_id\x18\t \x01(\x04R\x10tracesStartSeqId\x12\x1f\n" +
 	"\vmax_records\x18\n" +
 	" \x01(\x05R\n" +
-	"maxRecords\"\xa5\x04\n" +
+	"maxRecords\x12%\n" +
+	"\x0fhttp_ack_seq_id\x18\v \x01(\x04R\fhttpAckSeqId\x12%\n" +
+	"\x0fgrpc_ack_seq_id\x18\f \x01(\x04R\fgrpcAckSeqId\x12#\n" +
+	"\x0esql_ack_seq_id\x18\r \x01(\x04R\vsqlAckSeqId\x12)\n" +
+	"\x11traces_ack_seq_id\x18\x0e \x01(\x04R\x0etracesAckSeqId\x12$\n" +
+	"\x0eack_session_id\x18\x0f \x01(\tR\fackSessionId\"\xa5\x04\n" +
 	"\x18QueryEbpfMetricsResponse\x12A\n" +
 	"\fhttp_metrics\x18\x01 \x03(\v2\x1e.coral.agent.v1.EbpfHttpMetricR\vhttpMetrics\x12A\n" +
 	"\fgrpc_metrics\x18\x02 \x03(\v2\x1e.coral.agent.v1.EbpfGrpcMetricR\vgrpcMetrics\x12>\n" +
@@ -4812,7 +5695,7 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\x05stdin\x18\x02 \x01(\fH\x00R\x05stdin\x125\n" +
 	"\x06resize\x18\x03 \x01(\v2\x1b.coral.agent.v1.ShellResizeH\x00R\x06resize\x125\n" +
 	"\x06signal\x18\x04 \x01(\v2\x1b.coral.agent.v1.ShellSignalH\x00R\x06signalB\t\n" +
-	"\apayload\"\xfd\x01\n" +
+	"\apayload\"\x81\x03\n" +
 	"\n" +
 	"ShellStart\x12\x14\n" +
 	"\x05shell\x18\x01 \x01(\tR\x05shell\x125\n" +
@@ -4820,7 +5703,13 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\x04size\x18\x03 \x01(\v2\x1c.coral.agent.v1.TerminalSizeR\x04size\x12\x17\n" +
 	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vapproval_id\x18\x05 \x01(\tR\n" +
-	"approvalId\x1a6\n" +
+	"approvalId\x12\x18\n" +
+	"\acommand\x18\x06 \x03(\tR\acommand\x12!\n" +
+	"\fin_container\x18\a \x01(\bR\vinContainer\x12%\n" +
+	"\x0econtainer_name\x18\b \x01(\tR\rcontainerName\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\t \x03(\tR\n" +
+	"namespaces\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"e\n" +
@@ -4860,7 +5749,33 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\"J\n" +
 	"\x18KillShellSessionResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\x84\x02\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"}\n" +
+	"\bFileInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\rR\x04mode\x125\n" +
+	"\bmod_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\amodTime\"[\n" +
+	"\x0fReadFileRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmax_bytes\x18\x03 \x01(\x03R\bmaxBytes\"}\n" +
+	"\x10ReadFileResponse\x12.\n" +
+	"\x04info\x18\x01 \x01(\v2\x18.coral.agent.v1.FileInfoH\x00R\x04info\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04data\x12\x18\n" +
+	"\x06sha256\x18\x03 \x01(\tH\x00R\x06sha256B\t\n" +
+	"\apayload\"W\n" +
+	"\x0eWriteFileStart\x12,\n" +
+	"\x04info\x18\x01 \x01(\v2\x18.coral.agent.v1.FileInfoR\x04info\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x85\x01\n" +
+	"\x10WriteFileRequest\x126\n" +
+	"\x05start\x18\x01 \x01(\v2\x1e.coral.agent.v1.WriteFileStartH\x00R\x05start\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04data\x12\x18\n" +
+	"\x06sha256\x18\x03 \x01(\tH\x00R\x06sha256B\t\n" +
+	"\apayload\"d\n" +
+	"\x11WriteFileResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12#\n" +
+	"\rbytes_written\x18\x02 \x01(\x03R\fbytesWritten\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\"\x84\x02\n" +
 	"\x10ShellExecRequest\x12\x18\n" +
 	"\acommand\x18\x01 \x03(\tR\acommand\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12'\n" +
@@ -4870,7 +5785,7 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\x03env\x18\x05 \x03(\v2).coral.agent.v1.ShellExecRequest.EnvEntryR\x03env\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb6\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd2\x01\n" +
 	"\x11ShellExecResponse\x12\x16\n" +
 	"\x06stdout\x18\x01 \x01(\fR\x06stdout\x12\x16\n" +
 	"\x06stderr\x18\x02 \x01(\fR\x06stderr\x12\x1b\n" +
@@ -4879,7 +5794,8 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"session_id\x18\x04 \x01(\tR\tsessionId\x12\x1f\n" +
 	"\vduration_ms\x18\x05 \x01(\rR\n" +
 	"durationMs\x12\x14\n" +
-	"\x05error\x18\x06 \x01(\tR\x05error\"\xd3\x02\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x1a\n" +
+	"\bredacted\x18\a \x01(\bR\bredacted\"\xd3\x02\n" +
 	"\x14ContainerExecRequest\x12%\n" +
 	"\x0econtainer_name\x18\x01 \x01(\tR\rcontainerName\x12\x18\n" +
 	"\acommand\x18\x02 \x03(\tR\acommand\x12\x17\n" +
@@ -4893,7 +5809,7 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"namespaces\x1a6\n" +
 	"\bEnvEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8e\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xaa\x02\n" +
 	"\x15ContainerExecResponse\x12\x16\n" +
 	"\x06stdout\x18\x01 \x01(\fR\x06stdout\x12\x16\n" +
 	"\x06stderr\x18\x02 \x01(\fR\x06stderr\x12\x1b\n" +
@@ -4904,7 +5820,8 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"durationMs\x12\x14\n" +
 	"\x05error\x18\x06 \x01(\tR\x05error\x12#\n" +
 	"\rcontainer_pid\x18\a \x01(\x05R\fcontainerPid\x12-\n" +
-	"\x12namespaces_entered\x18\b \x03(\tR\x11namespacesEntered\"\x8e\x01\n" +
+	"\x12namespaces_entered\x18\b \x03(\tR\x11namespacesEntered\x12\x1a\n" +
+	"\bredacted\x18\t \x01(\bR\bredacted\"\x8e\x01\n" +
 	"\n" +
 	"DebugEvent\x12\x1d\n" +
 	"\n" +
@@ -4956,7 +5873,11 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\n" +
 	"max_seq_id\x18\x03 \x01(\x04R\bmaxSeqId\x12\x1d\n" +
 	"\n" +
-	"session_id\x18\x04 \x01(\tR\tsessionId*_\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\"V\n" +
+	"\x19UpdateColonySecretRequest\x12#\n" +
+	"\rcolony_secret\x18\x01 \x01(\tR\fcolonySecret\x12\x14\n" +
+	"\x05proof\x18\x02 \x01(\fR\x05proof\"\x1c\n" +
+	"\x1aUpdateColonySecretResponse*_\n" +
 	"\bExecMode\x12\x15\n" +
 	"\x11EXEC_MODE_UNKNOWN\x10\x00\x12\x12\n" +
 	"\x0eEXEC_MODE_NONE\x10\x01\x12\x11\n" +
@@ -4972,19 +5893,21 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\x14SIDECAR_MODE_UNKNOWN\x10\x00\x12\x14\n" +
 	"\x10SIDECAR_MODE_CRI\x10\x01\x12\x1a\n" +
 	"\x16SIDECAR_MODE_SHARED_NS\x10\x02\x12\x18\n" +
-	"\x14SIDECAR_MODE_PASSIVE\x10\x03*\xef\x01\n" +
+	"\x14SIDECAR_MODE_PASSIVE\x10\x03*\xba\x02\n" +
 	"\x11EbpfCollectorKind\x12#\n" +
 	"\x1fEBPF_COLLECTOR_KIND_UNSPECIFIED\x10\x00\x12%\n" +
 	"!EBPF_COLLECTOR_KIND_SYSCALL_STATS\x10\x01\x12$\n" +
 	" EBPF_COLLECTOR_KIND_HTTP_LATENCY\x10\x02\x12#\n" +
 	"\x1fEBPF_COLLECTOR_KIND_CPU_PROFILE\x10\x03\x12#\n" +
 	"\x1fEBPF_COLLECTOR_KIND_TCP_METRICS\x10\x04\x12\x1e\n" +
-	"\x1aEBPF_COLLECTOR_KIND_UPROBE\x10\x05*\x82\x01\n" +
+	"\x1aEBPF_COLLECTOR_KIND_UPROBE\x10\x05\x12$\n" +
+	" EBPF_COLLECTOR_KIND_HTTP_CAPTURE\x10\x06\x12#\n" +
+	"\x1fEBPF_COLLECTOR_KIND_TLS_CAPTURE\x10\a*\x82\x01\n" +
 	"\x0eEbpfMetricType\x12 \n" +
 	"\x1cEBPF_METRIC_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15EBPF_METRIC_TYPE_HTTP\x10\x01\x12\x19\n" +
 	"\x15EBPF_METRIC_TYPE_GRPC\x10\x02\x12\x18\n" +
-	"\x14EBPF_METRIC_TYPE_SQL\x10\x032\xb3\v\n" +
+	"\x14EBPF_METRIC_TYPE_SQL\x10\x032\x99\x0e\n" +
 	"\fAgentService\x12e\n" +
 	"\x11GetRuntimeContext\x12(.coral.agent.v1.GetRuntimeContextRequest\x1a&.coral.agent.v1.RuntimeContextResponse\x12_\n" +
 	"\x0eConnectService\x12%.coral.agent.v1.ConnectServiceRequest\x1a&.coral.agent.v1.ConnectServiceResponse\x12h\n" +
@@ -4995,12 +5918,16 @@ const file_coral_agent_v1_agent_proto_rawDesc = "" +
 	"\x12QuerySystemMetrics\x12).coral.agent.v1.QuerySystemMetricsRequest\x1a*.coral.agent.v1.QuerySystemMetricsResponse\x12H\n" +
 	"\x05Shell\x12\x1c.coral.agent.v1.ShellRequest\x1a\x1d.coral.agent.v1.ShellResponse(\x010\x01\x12P\n" +
 	"\tShellExec\x12 .coral.agent.v1.ShellExecRequest\x1a!.coral.agent.v1.ShellExecResponse\x12\\\n" +
-	"\rContainerExec\x12$.coral.agent.v1.ContainerExecRequest\x1a%.coral.agent.v1.ContainerExecResponse\x12n\n" +
+	"\rContainerExec\x12$.coral.agent.v1.ContainerExecRequest\x1a%.coral.agent.v1.ContainerExecResponse\x12R\n" +
+	"\x0fExecInteractive\x12\x1c.coral.agent.v1.ShellRequest\x1a\x1d.coral.agent.v1.ShellResponse(\x010\x01\x12O\n" +
+	"\bReadFile\x12\x1f.coral.agent.v1.ReadFileRequest\x1a .coral.agent.v1.ReadFileResponse0\x01\x12R\n" +
+	"\tWriteFile\x12 .coral.agent.v1.WriteFileRequest\x1a!.coral.agent.v1.WriteFileResponse(\x01\x12n\n" +
 	"\x13ResizeShellTerminal\x12*.coral.agent.v1.ResizeShellTerminalRequest\x1a+.coral.agent.v1.ResizeShellTerminalResponse\x12b\n" +
 	"\x0fSendShellSignal\x12&.coral.agent.v1.SendShellSignalRequest\x1a'.coral.agent.v1.SendShellSignalResponse\x12e\n" +
 	"\x10KillShellSession\x12'.coral.agent.v1.KillShellSessionRequest\x1a(.coral.agent.v1.KillShellSessionResponse\x12Q\n" +
 	"\x11StreamDebugEvents\x12\x1c.coral.agent.v1.DebugCommand\x1a\x1a.coral.agent.v1.DebugEvent(\x010\x01\x12Y\n" +
-	"\fGetFunctions\x12#.coral.agent.v1.GetFunctionsRequest\x1a$.coral.agent.v1.GetFunctionsResponseB\xae\x01\n" +
+	"\fGetFunctions\x12#.coral.agent.v1.GetFunctionsRequest\x1a$.coral.agent.v1.GetFunctionsResponse\x12k\n" +
+	"\x12UpdateColonySecret\x12).coral.agent.v1.UpdateColonySecretRequest\x1a*.coral.agent.v1.UpdateColonySecretResponseB\xae\x01\n" +
 	"\x12com.coral.agent.v1B\n" +
 	"AgentProtoP\x01Z2github.com/coral-mesh/coral/coral/agent/v1;agentv1\xa2\x02\x03CAX\xaa\x02\x0eCoral.Agent.V1\xca\x02\x0eCoral\\Agent\\V1\xe2\x02\x1aCoral\\Agent\\V1\\GPBMetadata\xea\x02\x10Coral::Agent::V1b\x06proto3"
 
@@ -5017,7 +5944,7 @@ func file_coral_agent_v1_agent_proto_rawDescGZIP() []byte {
 }
 
 var file_coral_agent_v1_agent_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_coral_agent_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
+var file_coral_agent_v1_agent_proto_msgTypes = make([]protoimpl.MessageInfo, 73)
 var file_coral_agent_v1_agent_proto_goTypes = []any{
 	(ExecMode)(0),                         // 0: coral.agent.v1.ExecMode
 	(RuntimeContext)(0),                   // 1: coral.agent.v1.RuntimeContext
@@ -5026,145 +5953,172 @@ var file_coral_agent_v1_agent_proto_goTypes = []any{
 	(EbpfMetricType)(0),                   // 4: coral.agent.v1.EbpfMetricType
 	(*GetRuntimeContextRequest)(nil),      // 5: coral.agent.v1.GetRuntimeContextRequest
 	(*RuntimeContextResponse)(nil),        // 6: coral.agent.v1.RuntimeContextResponse
-	(*PlatformInfo)(nil),                  // 7: coral.agent.v1.PlatformInfo
-	(*CRISocketInfo)(nil),                 // 8: coral.agent.v1.CRISocketInfo
-	(*VisibilityScope)(nil),               // 9: coral.agent.v1.VisibilityScope
-	(*Capabilities)(nil),                  // 10: coral.agent.v1.Capabilities
-	(*LinuxCapabilities)(nil),             // 11: coral.agent.v1.LinuxCapabilities
-	(*ExecCapabilities)(nil),              // 12: coral.agent.v1.ExecCapabilities
-	(*ConnectServiceRequest)(nil),         // 13: coral.agent.v1.ConnectServiceRequest
-	(*ServiceSdkCapabilities)(nil),        // 14: coral.agent.v1.ServiceSdkCapabilities
-	(*ConnectServiceResponse)(nil),        // 15: coral.agent.v1.ConnectServiceResponse
-	(*DisconnectServiceRequest)(nil),      // 16: coral.agent.v1.DisconnectServiceRequest
-	(*DisconnectServiceResponse)(nil),     // 17: coral.agent.v1.DisconnectServiceResponse
-	(*ListServicesRequest)(nil),           // 18: coral.agent.v1.ListServicesRequest
-	(*ListServicesResponse)(nil),          // 19: coral.agent.v1.ListServicesResponse
-	(*ServiceStatus)(nil),                 // 20: coral.agent.v1.ServiceStatus
-	(*EbpfCapabilities)(nil),              // 21: coral.agent.v1.EbpfCapabilities
-	(*EbpfObservabilityCapabilities)(nil), // 22: coral.agent.v1.EbpfObservabilityCapabilities
-	(*TelemetrySpan)(nil),                 // 23: coral.agent.v1.TelemetrySpan
-	(*QueryTelemetryRequest)(nil),         // 24: coral.agent.v1.QueryTelemetryRequest
-	(*QueryTelemetryResponse)(nil),        // 25: coral.agent.v1.QueryTelemetryResponse
-	(*QueryEbpfMetricsRequest)(nil),       // 26: coral.agent.v1.QueryEbpfMetricsRequest
-	(*QueryEbpfMetricsResponse)(nil),      // 27: coral.agent.v1.QueryEbpfMetricsResponse
-	(*EbpfHttpMetric)(nil),                // 28: coral.agent.v1.EbpfHttpMetric
-	(*EbpfGrpcMetric)(nil),                // 29: coral.agent.v1.EbpfGrpcMetric
-	(*EbpfSqlMetric)(nil),                 // 30: coral.agent.v1.EbpfSqlMetric
-	(*EbpfTraceSpan)(nil),                 // 31: coral.agent.v1.EbpfTraceSpan
-	(*ShellRequest)(nil),                  // 32: coral.agent.v1.ShellRequest
-	(*ShellStart)(nil),                    // 33: coral.agent.v1.ShellStart
-	(*ShellResponse)(nil),                 // 34: coral.agent.v1.ShellResponse
-	(*ShellExit)(nil),                     // 35: coral.agent.v1.ShellExit
-	(*TerminalSize)(nil),                  // 36: coral.agent.v1.TerminalSize
-	(*ShellResize)(nil),                   // 37: coral.agent.v1.ShellResize
-	(*ShellSignal)(nil),                   // 38: coral.agent.v1.ShellSignal
-	(*ResizeShellTerminalRequest)(nil),    // 39: coral.agent.v1.ResizeShellTerminalRequest
-	(*ResizeShellTerminalResponse)(nil),   // 40: coral.agent.v1.ResizeShellTerminalResponse
-	(*SendShellSignalRequest)(nil),        // 41: coral.agent.v1.SendShellSignalRequest
-	(*SendShellSignalResponse)(nil),       // 42: coral.agent.v1.SendShellSignalResponse
-	(*KillShellSessionRequest)(nil),       // 43: coral.agent.v1.KillShellSessionRequest
-	(*KillShellSessionResponse)(nil),      // 44: coral.agent.v1.KillShellSessionResponse
-	(*ShellExecRequest)(nil),              // 45: coral.agent.v1.ShellExecRequest
-	(*ShellExecResponse)(nil),             // 46: coral.agent.v1.ShellExecResponse
-	(*ContainerExecRequest)(nil),          // 47: coral.agent.v1.ContainerExecRequest
-	(*ContainerExecResponse)(nil),         // 48: coral.agent.v1.ContainerExecResponse
-	(*DebugEvent)(nil),                    // 49: coral.agent.v1.DebugEvent
-	(*DebugCommand)(nil),                  // 50: coral.agent.v1.DebugCommand
-	(*GetFunctionsRequest)(nil),           // 51: coral.agent.v1.GetFunctionsRequest
-	(*GetFunctionsResponse)(nil),          // 52: coral.agent.v1.GetFunctionsResponse
-	(*FunctionInfo)(nil),                  // 53: coral.agent.v1.FunctionInfo
-	(*SystemMetric)(nil),                  // 54: coral.agent.v1.SystemMetric
-	(*QuerySystemMetricsRequest)(nil),     // 55: coral.agent.v1.QuerySystemMetricsRequest
-	(*QuerySystemMetricsResponse)(nil),    // 56: coral.agent.v1.QuerySystemMetricsResponse
-	nil,                                   // 57: coral.agent.v1.ConnectServiceRequest.LabelsEntry
-	nil,                                   // 58: coral.agent.v1.ServiceStatus.LabelsEntry
-	nil,                                   // 59: coral.agent.v1.TelemetrySpan.AttributesEntry
-	nil,                                   // 60: coral.agent.v1.EbpfHttpMetric.AttributesEntry
-	nil,                                   // 61: coral.agent.v1.EbpfGrpcMetric.AttributesEntry
-	nil,                                   // 62: coral.agent.v1.EbpfSqlMetric.AttributesEntry
-	nil,                                   // 63: coral.agent.v1.EbpfTraceSpan.AttributesEntry
-	nil,                                   // 64: coral.agent.v1.ShellStart.EnvEntry
-	nil,                                   // 65: coral.agent.v1.ShellExecRequest.EnvEntry
-	nil,                                   // 66: coral.agent.v1.ContainerExecRequest.EnvEntry
-	(*timestamppb.Timestamp)(nil),         // 67: google.protobuf.Timestamp
-	(*v1.MeshTelemetry)(nil),              // 68: coral.network.v1.MeshTelemetry
+	(*ResourceShedding)(nil),              // 7: coral.agent.v1.ResourceShedding
+	(*PlatformInfo)(nil),                  // 8: coral.agent.v1.PlatformInfo
+	(*CRISocketInfo)(nil),                 // 9: coral.agent.v1.CRISocketInfo
+	(*VisibilityScope)(nil),               // 10: coral.agent.v1.VisibilityScope
+	(*Capabilities)(nil),                  // 11: coral.agent.v1.Capabilities
+	(*LinuxCapabilities)(nil),             // 12: coral.agent.v1.LinuxCapabilities
+	(*ExecCapabilities)(nil),              // 13: coral.agent.v1.ExecCapabilities
+	(*ConnectServiceRequest)(nil),         // 14: coral.agent.v1.ConnectServiceRequest
+	(*ServiceSdkCapabilities)(nil),        // 15: coral.agent.v1.ServiceSdkCapabilities
+	(*ConnectServiceResponse)(nil),        // 16: coral.agent.v1.ConnectServiceResponse
+	(*DisconnectServiceRequest)(nil),      // 17: coral.agent.v1.DisconnectServiceRequest
+	(*DisconnectServiceResponse)(nil),     // 18: coral.agent.v1.DisconnectServiceResponse
+	(*ListServicesRequest)(nil),           // 19: coral.agent.v1.ListServicesRequest
+	(*ListServicesResponse)(nil),          // 20: coral.agent.v1.ListServicesResponse
+	(*ServiceStatus)(nil),                 // 21: coral.agent.v1.ServiceStatus
+	(*EbpfCapabilities)(nil),              // 22: coral.agent.v1.EbpfCapabilities
+	(*EbpfKernelFeatures)(nil),            // 23: coral.agent.v1.EbpfKernelFeatures
+	(*EbpfObservabilityCapabilities)(nil), // 24: coral.agent.v1.EbpfObservabilityCapabilities
+	(*TelemetrySpan)(nil),                 // 25: coral.agent.v1.TelemetrySpan
+	(*QueryTelemetryRequest)(nil),         // 26: coral.agent.v1.QueryTelemetryRequest
+	(*QueryTelemetryResponse)(nil),        // 27: coral.agent.v1.QueryTelemetryResponse
+	(*QueryEbpfMetricsRequest)(nil),       // 28: coral.agent.v1.QueryEbpfMetricsRequest
+	(*QueryEbpfMetricsResponse)(nil),      // 29: coral.agent.v1.QueryEbpfMetricsResponse
+	(*EbpfHttpMetric)(nil),                // 30: coral.agent.v1.EbpfHttpMetric
+	(*EbpfGrpcMetric)(nil),                // 31: coral.agent.v1.EbpfGrpcMetric
+	(*EbpfSqlMetric)(nil),                 // 32: coral.agent.v1.EbpfSqlMetric
+	(*EbpfTraceSpan)(nil),                 // 33: coral.agent.v1.EbpfTraceSpan
+	(*ShellRequest)(nil),                  // 34: coral.agent.v1.ShellRequest
+	(*ShellStart)(nil),                    // 35: coral.agent.v1.ShellStart
+	(*ShellResponse)(nil),                 // 36: coral.agent.v1.ShellResponse
+	(*ShellExit)(nil),                     // 37: coral.agent.v1.ShellExit
+	(*TerminalSize)(nil),                  // 38: coral.agent.v1.TerminalSize
+	(*ShellResize)(nil),                   // 39: coral.agent.v1.ShellResize
+	(*ShellSignal)(nil),                   // 40: coral.agent.v1.ShellSignal
+	(*ResizeShellTerminalRequest)(nil),    // 41: coral.agent.v1.ResizeShellTerminalRequest
+	(*ResizeShellTerminalResponse)(nil),   // 42: coral.agent.v1.ResizeShellTerminalResponse
+	(*SendShellSignalRequest)(nil),        // 43: coral.agent.v1.SendShellSignalRequest
+	(*SendShellSignalResponse)(nil),       // 44: coral.agent.v1.SendShellSignalResponse
+	(*KillShellSessionRequest)(nil),       // 45: coral.agent.v1.KillShellSessionRequest
+	(*KillShellSessionResponse)(nil),      // 46: coral.agent.v1.KillShellSessionResponse
+	(*FileInfo)(nil),                      // 47: coral.agent.v1.FileInfo
+	(*ReadFileRequest)(nil),               // 48: coral.agent.v1.ReadFileRequest
+	(*ReadFileResponse)(nil),              // 49: coral.agent.v1.ReadFileResponse
+	(*WriteFileStart)(nil),                // 50: coral.agent.v1.WriteFileStart
+	(*WriteFileRequest)(nil),              // 51: coral.agent.v1.WriteFileRequest
+	(*WriteFileResponse)(nil),             // 52: coral.agent.v1.WriteFileResponse
+	(*ShellExecRequest)(nil),              // 53: coral.agent.v1.ShellExecRequest
+	(*ShellExecResponse)(nil),             // 54: coral.agent.v1.ShellExecResponse
+	(*ContainerExecRequest)(nil),          // 55: coral.agent.v1.ContainerExecRequest
+	(*ContainerExecResponse)(nil),         // 56: coral.agent.v1.ContainerExecResponse
+	(*DebugEvent)(nil),                    // 57: coral.agent.v1.DebugEvent
+	(*DebugCommand)(nil),                  // 58: coral.agent.v1.DebugCommand
+	(*GetFunctionsRequest)(nil),           // 59: coral.agent.v1.GetFunctionsRequest
+	(*GetFunctionsResponse)(nil),          // 60: coral.agent.v1.GetFunctionsResponse
+	(*FunctionInfo)(nil),                  // 61: coral.agent.v1.FunctionInfo
+	(*SystemMetric)(nil),                  // 62: coral.agent.v1.SystemMetric
+	(*QuerySystemMetricsRequest)(nil),     // 63: coral.agent.v1.QuerySystemMetricsRequest
+	(*QuerySystemMetricsResponse)(nil),    // 64: coral.agent.v1.QuerySystemMetricsResponse
+	(*UpdateColonySecretRequest)(nil),     // 65: coral.agent.v1.UpdateColonySecretRequest
+	(*UpdateColonySecretResponse)(nil),    // 66: coral.agent.v1.UpdateColonySecretResponse
+	nil,                                   // 67: coral.agent.v1.ConnectServiceRequest.LabelsEntry
+	nil,                                   // 68: coral.agent.v1.ServiceSdkCapabilities.LabelsEntry
+	nil,                                   // 69: coral.agent.v1.ServiceStatus.LabelsEntry
+	nil,                                   // 70: coral.agent.v1.TelemetrySpan.AttributesEntry
+	nil,                                   // 71: coral.agent.v1.EbpfHttpMetric.AttributesEntry
+	nil,                                   // 72: coral.agent.v1.EbpfGrpcMetric.AttributesEntry
+	nil,                                   // 73: coral.agent.v1.EbpfSqlMetric.AttributesEntry
+	nil,                                   // 74: coral.agent.v1.EbpfTraceSpan.AttributesEntry
+	nil,                                   // 75: coral.agent.v1.ShellStart.EnvEntry
+	nil,                                   // 76: coral.agent.v1.ShellExecRequest.EnvEntry
+	nil,                                   // 77: coral.agent.v1.ContainerExecRequest.EnvEntry
+	(*timestamppb.Timestamp)(nil),         // 78: google.protobuf.Timestamp
+	(*v1.MeshTelemetry)(nil),              // 79: coral.network.v1.MeshTelemetry
 }
 var file_coral_agent_v1_agent_proto_depIdxs = []int32{
-	7,  // 0: coral.agent.v1.RuntimeContextResponse.platform:type_name -> coral.agent.v1.PlatformInfo
+	8,  // 0: coral.agent.v1.RuntimeContextResponse.platform:type_name -> coral.agent.v1.PlatformInfo
 	1,  // 1: coral.agent.v1.RuntimeContextResponse.runtime_type:type_name -> coral.agent.v1.RuntimeContext
 	2,  // 2: coral.agent.v1.RuntimeContextResponse.sidecar_mode:type_name -> coral.agent.v1.SidecarMode
-	8,  // 3: coral.agent.v1.RuntimeContextResponse.cri_socket:type_name -> coral.agent.v1.CRISocketInfo
-	10, // 4: coral.agent.v1.RuntimeContextResponse.capabilities:type_name -> coral.agent.v1.Capabilities
-	9,  // 5: coral.agent.v1.RuntimeContextResponse.visibility:type_name -> coral.agent.v1.VisibilityScope
-	67, // 6: coral.agent.v1.RuntimeContextResponse.detected_at:type_name -> google.protobuf.Timestamp
-	21, // 7: coral.agent.v1.RuntimeContextResponse.ebpf_capabilities:type_name -> coral.agent.v1.EbpfCapabilities
-	68, // 8: coral.agent.v1.RuntimeContextResponse.wireguard:type_name -> coral.network.v1.MeshTelemetry
-	12, // 9: coral.agent.v1.Capabilities.exec_capabilities:type_name -> coral.agent.v1.ExecCapabilities
-	11, // 10: coral.agent.v1.Capabilities.linux_capabilities:type_name -> coral.agent.v1.LinuxCapabilities
-	0,  // 11: coral.agent.v1.ExecCapabilities.mode:type_name -> coral.agent.v1.ExecMode
-	57, // 12: coral.agent.v1.ConnectServiceRequest.labels:type_name -> coral.agent.v1.ConnectServiceRequest.LabelsEntry
-	14, // 13: coral.agent.v1.ConnectServiceRequest.sdk_capabilities:type_name -> coral.agent.v1.ServiceSdkCapabilities
-	20, // 14: coral.agent.v1.ListServicesResponse.services:type_name -> coral.agent.v1.ServiceStatus
-	58, // 15: coral.agent.v1.ServiceStatus.labels:type_name -> coral.agent.v1.ServiceStatus.LabelsEntry
-	67, // 16: coral.agent.v1.ServiceStatus.last_check:type_name -> google.protobuf.Timestamp
-	3,  // 17: coral.agent.v1.EbpfCapabilities.available_collectors:type_name -> coral.agent.v1.EbpfCollectorKind
-	22, // 18: coral.agent.v1.EbpfCapabilities.ebpf_observability:type_name -> coral.agent.v1.EbpfObservabilityCapabilities
-	59, // 19: coral.agent.v1.TelemetrySpan.attributes:type_name -> coral.agent.v1.TelemetrySpan.AttributesEntry
-	23, // 20: coral.agent.v1.QueryTelemetryResponse.spans:type_name -> coral.agent.v1.TelemetrySpan
-	4,  // 21: coral.agent.v1.QueryEbpfMetricsRequest.metric_types:type_name -> coral.agent.v1.EbpfMetricType
-	28, // 22: coral.agent.v1.QueryEbpfMetricsResponse.http_metrics:type_name -> coral.agent.v1.EbpfHttpMetric
-	29, // 23: coral.agent.v1.QueryEbpfMetricsResponse.grpc_metrics:type_name -> coral.agent.v1.EbpfGrpcMetric
-	30, // 24: coral.agent.v1.QueryEbpfMetricsResponse.sql_metrics:type_name -> coral.agent.v1.EbpfSqlMetric
-	31, // 25: coral.agent.v1.QueryEbpfMetricsResponse.trace_spans:type_name -> coral.agent.v1.EbpfTraceSpan
-	60, // 26: coral.agent.v1.EbpfHttpMetric.attributes:type_name -> coral.agent.v1.EbpfHttpMetric.AttributesEntry
-	61, // 27: coral.agent.v1.EbpfGrpcMetric.attributes:type_name -> coral.agent.v1.EbpfGrpcMetric.AttributesEntry
-	62, // 28: coral.agent.v1.EbpfSqlMetric.attributes:type_name -> coral.agent.v1.EbpfSqlMetric.AttributesEntry
-	63, // 29: coral.agent.v1.EbpfTraceSpan.attributes:type_name -> coral.agent.v1.EbpfTraceSpan.AttributesEntry
-	33, // 30: coral.agent.v1.ShellRequest.start:type_name -> coral.agent.v1.ShellStart
-	37, // 31: coral.agent.v1.ShellRequest.resize:type_name -> coral.agent.v1.ShellResize
-	38, // 32: coral.agent.v1.ShellRequest.signal:type_name -> coral.agent.v1.ShellSignal
-	64, // 33: coral.agent.v1.ShellStart.env:type_name -> coral.agent.v1.ShellStart.EnvEntry
-	36, // 34: coral.agent.v1.ShellStart.size:type_name -> coral.agent.v1.TerminalSize
-	35, // 35: coral.agent.v1.ShellResponse.exit:type_name -> coral.agent.v1.ShellExit
-	65, // 36: coral.agent.v1.ShellExecRequest.env:type_name -> coral.agent.v1.ShellExecRequest.EnvEntry
-	66, // 37: coral.agent.v1.ContainerExecRequest.env:type_name -> coral.agent.v1.ContainerExecRequest.EnvEntry
-	53, // 38: coral.agent.v1.GetFunctionsResponse.functions:type_name -> coral.agent.v1.FunctionInfo
-	54, // 39: coral.agent.v1.QuerySystemMetricsResponse.metrics:type_name -> coral.agent.v1.SystemMetric
-	5,  // 40: coral.agent.v1.AgentService.GetRuntimeContext:input_type -> coral.agent.v1.GetRuntimeContextRequest
-	13, // 41: coral.agent.v1.AgentService.ConnectService:input_type -> coral.agent.v1.ConnectServiceRequest
-	16, // 42: coral.agent.v1.AgentService.DisconnectService:input_type -> coral.agent.v1.DisconnectServiceRequest
-	18, // 43: coral.agent.v1.AgentService.ListServices:input_type -> coral.agent.v1.ListServicesRequest
-	24, // 44: coral.agent.v1.AgentService.QueryTelemetry:input_type -> coral.agent.v1.QueryTelemetryRequest
-	26, // 45: coral.agent.v1.AgentService.QueryEbpfMetrics:input_type -> coral.agent.v1.QueryEbpfMetricsRequest
-	55, // 46: coral.agent.v1.AgentService.QuerySystemMetrics:input_type -> coral.agent.v1.QuerySystemMetricsRequest
-	32, // 47: coral.agent.v1.AgentService.Shell:input_type -> coral.agent.v1.ShellRequest
-	45, // 48: coral.agent.v1.AgentService.ShellExec:input_type -> coral.agent.v1.ShellExecRequest
-	47, // 49: coral.agent.v1.AgentService.ContainerExec:input_type -> coral.agent.v1.ContainerExecRequest
-	39, // 50: coral.agent.v1.AgentService.ResizeShellTerminal:input_type -> coral.agent.v1.ResizeShellTerminalRequest
-	41, // 51: coral.agent.v1.AgentService.SendShellSignal:input_type -> coral.agent.v1.SendShellSignalRequest
-	43, // 52: coral.agent.v1.AgentService.KillShellSession:input_type -> coral.agent.v1.KillShellSessionRequest
-	50, // 53: coral.agent.v1.AgentService.StreamDebugEvents:input_type -> coral.agent.v1.DebugCommand
-	51, // 54: coral.agent.v1.AgentService.GetFunctions:input_type -> coral.agent.v1.GetFunctionsRequest
-	6,  // 55: coral.agent.v1.AgentService.GetRuntimeContext:output_type -> coral.agent.v1.RuntimeContextResponse
-	15, // 56: coral.agent.v1.AgentService.ConnectService:output_type -> coral.agent.v1.ConnectServiceResponse
-	17, // 57: coral.agent.v1.AgentService.DisconnectService:output_type -> coral.agent.v1.DisconnectServiceResponse
-	19, // 58: coral.agent.v1.AgentService.ListServices:output_type -> coral.agent.v1.ListServicesResponse
-	25, // 59: coral.agent.v1.AgentService.QueryTelemetry:output_type -> coral.agent.v1.QueryTelemetryResponse
-	27, // 60: coral.agent.v1.AgentService.QueryEbpfMetrics:output_type -> coral.agent.v1.QueryEbpfMetricsResponse
-	56, // 61: coral.agent.v1.AgentService.QuerySystemMetrics:output_type -> coral.agent.v1.QuerySystemMetricsResponse
-	34, // 62: coral.agent.v1.AgentService.Shell:output_type -> coral.agent.v1.ShellResponse
-	46, // 63: coral.agent.v1.AgentService.ShellExec:output_type -> coral.agent.v1.ShellExecResponse
-	48, // 64: coral.agent.v1.AgentService.ContainerExec:output_type -> coral.agent.v1.ContainerExecResponse
-	40, // 65: coral.agent.v1.AgentService.ResizeShellTerminal:output_type -> coral.agent.v1.ResizeShellTerminalResponse
-	42, // 66: coral.agent.v1.AgentService.SendShellSignal:output_type -> coral.agent.v1.SendShellSignalResponse
-	44, // 67: coral.agent.v1.AgentService.KillShellSession:output_type -> coral.agent.v1.KillShellSessionResponse
-	49, // 68: coral.agent.v1.AgentService.StreamDebugEvents:output_type -> coral.agent.v1.DebugEvent
-	52, // 69: coral.agent.v1.AgentService.GetFunctions:output_type -> coral.agent.v1.GetFunctionsResponse
-	55, // [55:70] is the sub-list for method output_type
-	40, // [40:55] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	9,  // 3: coral.agent.v1.RuntimeContextResponse.cri_socket:type_name -> coral.agent.v1.CRISocketInfo
+	11, // 4: coral.agent.v1.RuntimeContextResponse.capabilities:type_name -> coral.agent.v1.Capabilities
+	10, // 5: coral.agent.v1.RuntimeContextResponse.visibility:type_name -> coral.agent.v1.VisibilityScope
+	78, // 6: coral.agent.v1.RuntimeContextResponse.detected_at:type_name -> google.protobuf.Timestamp
+	22, // 7: coral.agent.v1.RuntimeContextResponse.ebpf_capabilities:type_name -> coral.agent.v1.EbpfCapabilities
+	79, // 8: coral.agent.v1.RuntimeContextResponse.wireguard:type_name -> coral.network.v1.MeshTelemetry
+	7,  // 9: coral.agent.v1.RuntimeContextResponse.resource_shedding:type_name -> coral.agent.v1.ResourceShedding
+	78, // 10: coral.agent.v1.ResourceShedding.since:type_name -> google.protobuf.Timestamp
+	13, // 11: coral.agent.v1.Capabilities.exec_capabilities:type_name -> coral.agent.v1.ExecCapabilities
+	12, // 12: coral.agent.v1.Capabilities.linux_capabilities:type_name -> coral.agent.v1.LinuxCapabilities
+	0,  // 13: coral.agent.v1.ExecCapabilities.mode:type_name -> coral.agent.v1.ExecMode
+	67, // 14: coral.agent.v1.ConnectServiceRequest.labels:type_name -> coral.agent.v1.ConnectServiceRequest.LabelsEntry
+	15, // 15: coral.agent.v1.ConnectServiceRequest.sdk_capabilities:type_name -> coral.agent.v1.ServiceSdkCapabilities
+	68, // 16: coral.agent.v1.ServiceSdkCapabilities.labels:type_name -> coral.agent.v1.ServiceSdkCapabilities.LabelsEntry
+	21, // 17: coral.agent.v1.ListServicesResponse.services:type_name -> coral.agent.v1.ServiceStatus
+	69, // 18: coral.agent.v1.ServiceStatus.labels:type_name -> coral.agent.v1.ServiceStatus.LabelsEntry
+	78, // 19: coral.agent.v1.ServiceStatus.last_check:type_name -> google.protobuf.Timestamp
+	3,  // 20: coral.agent.v1.EbpfCapabilities.available_collectors:type_name -> coral.agent.v1.EbpfCollectorKind
+	24, // 21: coral.agent.v1.EbpfCapabilities.ebpf_observability:type_name -> coral.agent.v1.EbpfObservabilityCapabilities
+	23, // 22: coral.agent.v1.EbpfCapabilities.kernel_features:type_name -> coral.agent.v1.EbpfKernelFeatures
+	70, // 23: coral.agent.v1.TelemetrySpan.attributes:type_name -> coral.agent.v1.TelemetrySpan.AttributesEntry
+	25, // 24: coral.agent.v1.QueryTelemetryResponse.spans:type_name -> coral.agent.v1.TelemetrySpan
+	4,  // 25: coral.agent.v1.QueryEbpfMetricsRequest.metric_types:type_name -> coral.agent.v1.EbpfMetricType
+	30, // 26: coral.agent.v1.QueryEbpfMetricsResponse.http_metrics:type_name -> coral.agent.v1.EbpfHttpMetric
+	31, // 27: coral.agent.v1.QueryEbpfMetricsResponse.grpc_metrics:type_name -> coral.agent.v1.EbpfGrpcMetric
+	32, // 28: coral.agent.v1.QueryEbpfMetricsResponse.sql_metrics:type_name -> coral.agent.v1.EbpfSqlMetric
+	33, // 29: coral.agent.v1.QueryEbpfMetricsResponse.trace_spans:type_name -> coral.agent.v1.EbpfTraceSpan
+	71, // 30: coral.agent.v1.EbpfHttpMetric.attributes:type_name -> coral.agent.v1.EbpfHttpMetric.AttributesEntry
+	72, // 31: coral.agent.v1.EbpfGrpcMetric.attributes:type_name -> coral.agent.v1.EbpfGrpcMetric.AttributesEntry
+	73, // 32: coral.agent.v1.EbpfSqlMetric.attributes:type_name -> coral.agent.v1.EbpfSqlMetric.AttributesEntry
+	74, // 33: coral.agent.v1.EbpfTraceSpan.attributes:type_name -> coral.agent.v1.EbpfTraceSpan.AttributesEntry
+	35, // 34: coral.agent.v1.ShellRequest.start:type_name -> coral.agent.v1.ShellStart
+	39, // 35: coral.agent.v1.ShellRequest.resize:type_name -> coral.agent.v1.ShellResize
+	40, // 36: coral.agent.v1.ShellRequest.signal:type_name -> coral.agent.v1.ShellSignal
+	75, // 37: coral.agent.v1.ShellStart.env:type_name -> coral.agent.v1.ShellStart.EnvEntry
+	38, // 38: coral.agent.v1.ShellStart.size:type_name -> coral.agent.v1.TerminalSize
+	37, // 39: coral.agent.v1.ShellResponse.exit:type_name -> coral.agent.v1.ShellExit
+	78, // 40: coral.agent.v1.FileInfo.mod_time:type_name -> google.protobuf.Timestamp
+	47, // 41: coral.agent.v1.ReadFileResponse.info:type_name -> coral.agent.v1.FileInfo
+	47, // 42: coral.agent.v1.WriteFileStart.info:type_name -> coral.agent.v1.FileInfo
+	50, // 43: coral.agent.v1.WriteFileRequest.start:type_name -> coral.agent.v1.WriteFileStart
+	76, // 44: coral.agent.v1.ShellExecRequest.env:type_name -> coral.agent.v1.ShellExecRequest.EnvEntry
+	77, // 45: coral.agent.v1.ContainerExecRequest.env:type_name -> coral.agent.v1.ContainerExecRequest.EnvEntry
+	61, // 46: coral.agent.v1.GetFunctionsResponse.functions:type_name -> coral.agent.v1.FunctionInfo
+	62, // 47: coral.agent.v1.QuerySystemMetricsResponse.metrics:type_name -> coral.agent.v1.SystemMetric
+	5,  // 48: coral.agent.v1.AgentService.GetRuntimeContext:input_type -> coral.agent.v1.GetRuntimeContextRequest
+	14, // 49: coral.agent.v1.AgentService.ConnectService:input_type -> coral.agent.v1.ConnectServiceRequest
+	17, // 50: coral.agent.v1.AgentService.DisconnectService:input_type -> coral.agent.v1.DisconnectServiceRequest
+	19, // 51: coral.agent.v1.AgentService.ListServices:input_type -> coral.agent.v1.ListServicesRequest
+	26, // 52: coral.agent.v1.AgentService.QueryTelemetry:input_type -> coral.agent.v1.QueryTelemetryRequest
+	28, // 53: coral.agent.v1.AgentService.QueryEbpfMetrics:input_type -> coral.agent.v1.QueryEbpfMetricsRequest
+	63, // 54: coral.agent.v1.AgentService.QuerySystemMetrics:input_type -> coral.agent.v1.QuerySystemMetricsRequest
+	34, // 55: coral.agent.v1.AgentService.Shell:input_type -> coral.agent.v1.ShellRequest
+	53, // 56: coral.agent.v1.AgentService.ShellExec:input_type -> coral.agent.v1.ShellExecRequest
+	55, // 57: coral.agent.v1.AgentService.ContainerExec:input_type -> coral.agent.v1.ContainerExecRequest
+	34, // 58: coral.agent.v1.AgentService.ExecInteractive:input_type -> coral.agent.v1.ShellRequest
+	48, // 59: coral.agent.v1.AgentService.ReadFile:input_type -> coral.agent.v1.ReadFileRequest
+	51, // 60: coral.agent.v1.AgentService.WriteFile:input_type -> coral.agent.v1.WriteFileRequest
+	41, // 61: coral.agent.v1.AgentService.ResizeShellTerminal:input_type -> coral.agent.v1.ResizeShellTerminalRequest
+	43, // 62: coral.agent.v1.AgentService.SendShellSignal:input_type -> coral.agent.v1.SendShellSignalRequest
+	45, // 63: coral.agent.v1.AgentService.KillShellSession:input_type -> coral.agent.v1.KillShellSessionRequest
+	58, // 64: coral.agent.v1.AgentService.StreamDebugEvents:input_type -> coral.agent.v1.DebugCommand
+	59, // 65: coral.agent.v1.AgentService.GetFunctions:input_type -> coral.agent.v1.GetFunctionsRequest
+	65, // 66: coral.agent.v1.AgentService.UpdateColonySecret:input_type -> coral.agent.v1.UpdateColonySecretRequest
+	6,  // 67: coral.agent.v1.AgentService.GetRuntimeContext:output_type -> coral.agent.v1.RuntimeContextResponse
+	16, // 68: coral.agent.v1.AgentService.ConnectService:output_type -> coral.agent.v1.ConnectServiceResponse
+	18, // 69: coral.agent.v1.AgentService.DisconnectService:output_type -> coral.agent.v1.DisconnectServiceResponse
+	20, // 70: coral.agent.v1.AgentService.ListServices:output_type -> coral.agent.v1.ListServicesResponse
+	27, // 71: coral.agent.v1.AgentService.QueryTelemetry:output_type -> coral.agent.v1.QueryTelemetryResponse
+	29, // 72: coral.agent.v1.AgentService.QueryEbpfMetrics:output_type -> coral.agent.v1.QueryEbpfMetricsResponse
+	64, // 73: coral.agent.v1.AgentService.QuerySystemMetrics:output_type -> coral.agent.v1.QuerySystemMetricsResponse
+	36, // 74: coral.agent.v1.AgentService.Shell:output_type -> coral.agent.v1.ShellResponse
+	54, // 75: coral.agent.v1.AgentService.ShellExec:output_type -> coral.agent.v1.ShellExecResponse
+	56, // 76: coral.agent.v1.AgentService.ContainerExec:output_type -> coral.agent.v1.ContainerExecResponse
+	36, // 77: coral.agent.v1.AgentService.ExecInteractive:output_type -> coral.agent.v1.ShellResponse
+	49, // 78: coral.agent.v1.AgentService.ReadFile:output_type -> coral.agent.v1.ReadFileResponse
+	52, // 79: coral.agent.v1.AgentService.WriteFile:output_type -> coral.agent.v1.WriteFileResponse
+	42, // 80: coral.agent.v1.AgentService.ResizeShellTerminal:output_type -> coral.agent.v1.ResizeShellTerminalResponse
+	44, // 81: coral.agent.v1.AgentService.SendShellSignal:output_type -> coral.agent.v1.SendShellSignalResponse
+	46, // 82: coral.agent.v1.AgentService.KillShellSession:output_type -> coral.agent.v1.KillShellSessionResponse
+	57, // 83: coral.agent.v1.AgentService.StreamDebugEvents:output_type -> coral.agent.v1.DebugEvent
+	60, // 84: coral.agent.v1.AgentService.GetFunctions:output_type -> coral.agent.v1.GetFunctionsResponse
+	66, // 85: coral.agent.v1.AgentService.UpdateColonySecret:output_type -> coral.agent.v1.UpdateColonySecretResponse
+	67, // [67:86] is the sub-list for method output_type
+	48, // [48:67] is the sub-list for method input_type
+	48, // [48:48] is the sub-list for extension type_name
+	48, // [48:48] is the sub-list for extension extendee
+	0,  // [0:48] is the sub-list for field type_name
 }
 
 func init() { file_coral_agent_v1_agent_proto_init() }
@@ -5172,23 +6126,33 @@ func file_coral_agent_v1_agent_proto_init() {
 	if File_coral_agent_v1_agent_proto != nil {
 		return
 	}
-	file_coral_agent_v1_agent_proto_msgTypes[27].OneofWrappers = []any{
+	file_coral_agent_v1_agent_proto_msgTypes[29].OneofWrappers = []any{
 		(*ShellRequest_Start)(nil),
 		(*ShellRequest_Stdin)(nil),
 		(*ShellRequest_Resize)(nil),
 		(*ShellRequest_Signal)(nil),
 	}
-	file_coral_agent_v1_agent_proto_msgTypes[29].OneofWrappers = []any{
+	file_coral_agent_v1_agent_proto_msgTypes[31].OneofWrappers = []any{
 		(*ShellResponse_Output)(nil),
 		(*ShellResponse_Exit)(nil),
 	}
+	file_coral_agent_v1_agent_proto_msgTypes[44].OneofWrappers = []any{
+		(*ReadFileResponse_Info)(nil),
+		(*ReadFileResponse_Data)(nil),
+		(*ReadFileResponse_Sha256)(nil),
+	}
+	file_coral_agent_v1_agent_proto_msgTypes[46].OneofWrappers = []any{
+		(*WriteFileRequest_Start)(nil),
+		(*WriteFileRequest_Data)(nil),
+		(*WriteFileRequest_Sha256)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_coral_agent_v1_agent_proto_rawDesc), len(file_coral_agent_v1_agent_proto_rawDesc)),
 			NumEnums:      5,
-			NumMessages:   62,
+			NumMessages:   73,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -62,6 +62,13 @@ const (
 	// AgentServiceContainerExecProcedure is the fully-qualified name of the AgentService's
 	// ContainerExec RPC.
 	AgentServiceContainerExecProcedure = "/coral.agent.v1.AgentService/ContainerExec"
+	// AgentServiceExecInteractiveProcedure is the fully-qualified name of the AgentService's
+	// ExecInteractive RPC.
+	AgentServiceExecInteractiveProcedure = "/coral.agent.v1.AgentService/ExecInteractive"
+	// AgentServiceReadFileProcedure is the fully-qualified name of the AgentService's ReadFile RPC.
+	AgentServiceReadFileProcedure = "/coral.agent.v1.AgentService/ReadFile"
+	// AgentServiceWriteFileProcedure is the fully-qualified name of the AgentService's WriteFile RPC.
+	AgentServiceWriteFileProcedure = "/coral.agent.v1.AgentService/WriteFile"
 	// AgentServiceResizeShellTerminalProcedure is the fully-qualified name of the AgentService's
 	// ResizeShellTerminal RPC.
 	AgentServiceResizeShellTerminalProcedure = "/coral.agent.v1.AgentService/ResizeShellTerminal"
@@ -77,6 +84,9 @@ const (
 	// AgentServiceGetFunctionsProcedure is the fully-qualified name of the AgentService's GetFunctions
 	// RPC.
 	AgentServiceGetFunctionsProcedure = "/coral.agent.v1.AgentService/GetFunctions"
+	// AgentServiceUpdateColonySecretProcedure is the fully-qualified name of the AgentService's
+	// UpdateColonySecret RPC.
+	AgentServiceUpdateColonySecretProcedure = "/coral.agent.v1.AgentService/UpdateColonySecret"
 )
 
 // AgentServiceClient is a client for the coral.agent.v1.AgentService service.
@@ -101,6 +111,13 @@ type AgentServiceClient interface {
 	ShellExec(context.Context, *connect.Request[v1.ShellExecRequest]) (*connect.Response[v1.ShellExecResponse], error)
 	// ContainerExec: Execute command in container namespace (RFD 056).
 	ContainerExec(context.Context, *connect.Request[v1.ContainerExecRequest]) (*connect.Response[v1.ContainerExecResponse], error)
+	// ExecInteractive: Command with a PTY on the agent host or in a container's
+	// namespaces, streaming terminal input and output (coral exec -it).
+	ExecInteractive(context.Context) *connect.BidiStreamForClient[v1.ShellRequest, v1.ShellResponse]
+	// ReadFile streams a file of the agent host in chunks (coral cp).
+	ReadFile(context.Context, *connect.Request[v1.ReadFileRequest]) (*connect.ServerStreamForClient[v1.ReadFileResponse], error)
+	// WriteFile writes a file streamed in chunks to the agent host (coral cp).
+	WriteFile(context.Context) *connect.ClientStreamForClient[v1.WriteFileRequest, v1.WriteFileResponse]
 	// Resize shell terminal (RFD 026).
 	ResizeShellTerminal(context.Context, *connect.Request[v1.ResizeShellTerminalRequest]) (*connect.Response[v1.ResizeShellTerminalResponse], error)
 	// Send signal to shell session (RFD 026).
@@ -111,6 +128,9 @@ type AgentServiceClient interface {
 	StreamDebugEvents(context.Context) *connect.BidiStreamForClient[v1.DebugCommand, v1.DebugEvent]
 	// Function Discovery (RFD 063) - pull-based model.
 	GetFunctions(context.Context, *connect.Request[v1.GetFunctionsRequest]) (*connect.Response[v1.GetFunctionsResponse], error)
+	// Replace the colony secret the agent registers with, pushed by the colony
+	// when it rotates its secret.
+	UpdateColonySecret(context.Context, *connect.Request[v1.UpdateColonySecretRequest]) (*connect.Response[v1.UpdateColonySecretResponse], error)
 }
 
 // NewAgentServiceClient constructs a client for the coral.agent.v1.AgentService service. By
@@ -184,6 +204,24 @@ func NewAgentServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(agentServiceMethods.ByName("ContainerExec")),
 			connect.WithClientOptions(opts...),
 		),
+		execInteractive: connect.NewClient[v1.ShellRequest, v1.ShellResponse](
+			httpClient,
+			baseURL+AgentServiceExecInteractiveProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("ExecInteractive")),
+			connect.WithClientOptions(opts...),
+		),
+		readFile: connect.NewClient[v1.ReadFileRequest, v1.ReadFileResponse](
+			httpClient,
+			baseURL+AgentServiceReadFileProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("ReadFile")),
+			connect.WithClientOptions(opts...),
+		),
+		writeFile: connect.NewClient[v1.WriteFileRequest, v1.WriteFileResponse](
+			httpClient,
+			baseURL+AgentServiceWriteFileProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("WriteFile")),
+			connect.WithClientOptions(opts...),
+		),
 		resizeShellTerminal: connect.NewClient[v1.ResizeShellTerminalRequest, v1.ResizeShellTerminalResponse](
 			httpClient,
 			baseURL+AgentServiceResizeShellTerminalProcedure,
@@ -214,6 +252,12 @@ func NewAgentServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(agentServiceMethods.ByName("GetFunctions")),
 			connect.WithClientOptions(opts...),
 		),
+		updateColonySecret: connect.NewClient[v1.UpdateColonySecretRequest, v1.UpdateColonySecretResponse](
+			httpClient,
+			baseURL+AgentServiceUpdateColonySecretProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("UpdateColonySecret")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -229,11 +273,15 @@ type agentServiceClient struct {
 	shell               *connect.Client[v1.ShellRequest, v1.ShellResponse]
 	shellExec           *connect.Client[v1.ShellExecRequest, v1.ShellExecResponse]
 	containerExec       *connect.Client[v1.ContainerExecRequest, v1.ContainerExecResponse]
+	execInteractive     *connect.Client[v1.ShellRequest, v1.ShellResponse]
+	readFile            *connect.Client[v1.ReadFileRequest, v1.ReadFileResponse]
+	writeFile           *connect.Client[v1.WriteFileRequest, v1.WriteFileResponse]
 	resizeShellTerminal *connect.Client[v1.ResizeShellTerminalRequest, v1.ResizeShellTerminalResponse]
 	sendShellSignal     *connect.Client[v1.SendShellSignalRequest, v1.SendShellSignalResponse]
 	killShellSession    *connect.Client[v1.KillShellSessionRequest, v1.KillShellSessionResponse]
 	streamDebugEvents   *connect.Client[v1.DebugCommand, v1.DebugEvent]
 	getFunctions        *connect.Client[v1.GetFunctionsRequest, v1.GetFunctionsResponse]
+	updateColonySecret  *connect.Client[v1.UpdateColonySecretRequest, v1.UpdateColonySecretResponse]
 }
 
 // GetRuntimeContext calls coral.agent.v1.AgentService.GetRuntimeContext.
@@ -286,6 +334,21 @@ func (c *agentServiceClient) ContainerExec(ctx context.Context, req *connect.Req
 	return c.containerExec.CallUnary(ctx, req)
 }
 
+// ExecInteractive calls coral.agent.v1.AgentService.ExecInteractive.
+func (c *agentServiceClient) ExecInteractive(ctx context.Context) *connect.BidiStreamForClient[v1.ShellRequest, v1.ShellResponse] {
+	return c.execInteractive.CallBidiStream(ctx)
+}
+
+// ReadFile calls coral.agent.v1.AgentService.ReadFile.
+func (c *agentServiceClient) ReadFile(ctx context.Context, req *connect.Request[v1.ReadFileRequest]) (*connect.ServerStreamForClient[v1.ReadFileResponse], error) {
+	return c.readFile.CallServerStream(ctx, req)
+}
+
+// WriteFile calls coral.agent.v1.AgentService.WriteFile.
+func (c *agentServiceClient) WriteFile(ctx context.Context) *connect.ClientStreamForClient[v1.WriteFileRequest, v1.WriteFileResponse] {
+	return c.writeFile.CallClientStream(ctx)
+}
+
 // ResizeShellTerminal calls coral.agent.v1.AgentService.ResizeShellTerminal.
 func (c *agentServiceClient) ResizeShellTerminal(ctx context.Context, req *connect.Request[v1.ResizeShellTerminalRequest]) (*connect.Response[v1.ResizeShellTerminalResponse], error) {
 	return c.resizeShellTerminal.CallUnary(ctx, req)
@@ -311,6 +374,11 @@ func (c *agentServiceClient) GetFunctions(ctx context.Context, req *connect.Requ
 	return c.getFunctions.CallUnary(ctx, req)
 }
 
+// UpdateColonySecret calls coral.agent.v1.AgentService.UpdateColonySecret.
+func (c *agentServiceClient) UpdateColonySecret(ctx context.Context, req *connect.Request[v1.UpdateColonySecretRequest]) (*connect.Response[v1.UpdateColonySecretResponse], error) {
+	return c.updateColonySecret.CallUnary(ctx, req)
+}
+
 // AgentServiceHandler is an implementation of the coral.agent.v1.AgentService service.
 type AgentServiceHandler interface {
 	// Get runtime context information.
@@ -333,6 +401,13 @@ type AgentServiceHandler interface {
 	ShellExec(context.Context, *connect.Request[v1.ShellExecRequest]) (*connect.Response[v1.ShellExecResponse], error)
 	// ContainerExec: Execute command in container namespace (RFD 056).
 	ContainerExec(context.Context, *connect.Request[v1.ContainerExecRequest]) (*connect.Response[v1.ContainerExecResponse], error)
+	// ExecInteractive: Command with a PTY on the agent host or in a container's
+	// namespaces, streaming terminal input and output (coral exec -it).
+	ExecInteractive(context.Context, *connect.BidiStream[v1.ShellRequest, v1.ShellResponse]) error
+	// ReadFile streams a file of the agent host in chunks (coral cp).
+	ReadFile(context.Context, *connect.Request[v1.ReadFileRequest], *connect.ServerStream[v1.ReadFileResponse]) error
+	// WriteFile writes a file streamed in chunks to the agent host (coral cp).
+	WriteFile(context.Context, *connect.ClientStream[v1.WriteFileRequest]) (*connect.Response[v1.WriteFileResponse], error)
 	// Resize shell terminal (RFD 026).
 	ResizeShellTerminal(context.Context, *connect.Request[v1.ResizeShellTerminalRequest]) (*connect.Response[v1.ResizeShellTerminalResponse], error)
 	// Send signal to shell session (RFD 026).
@@ -343,6 +418,9 @@ type AgentServiceHandler interface {
 	StreamDebugEvents(context.Context, *connect.BidiStream[v1.DebugCommand, v1.DebugEvent]) error
 	// Function Discovery (RFD 063) - pull-based model.
 	GetFunctions(context.Context, *connect.Request[v1.GetFunctionsRequest]) (*connect.Response[v1.GetFunctionsResponse], error)
+	// Replace the colony secret the agent registers with, pushed by the colony
+	// when it rotates its secret.
+	UpdateColonySecret(context.Context, *connect.Request[v1.UpdateColonySecretRequest]) (*connect.Response[v1.UpdateColonySecretResponse], error)
 }
 
 // NewAgentServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -412,6 +490,24 @@ func NewAgentServiceHandler(svc AgentServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(agentServiceMethods.ByName("ContainerExec")),
 		connect.WithHandlerOptions(opts...),
 	)
+	agentServiceExecInteractiveHandler := connect.NewBidiStreamHandler(
+		AgentServiceExecInteractiveProcedure,
+		svc.ExecInteractive,
+		connect.WithSchema(agentServiceMethods.ByName("ExecInteractive")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceReadFileHandler := connect.NewServerStreamHandler(
+		AgentServiceReadFileProcedure,
+		svc.ReadFile,
+		connect.WithSchema(agentServiceMethods.ByName("ReadFile")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceWriteFileHandler := connect.NewClientStreamHandler(
+		AgentServiceWriteFileProcedure,
+		svc.WriteFile,
+		connect.WithSchema(agentServiceMethods.ByName("WriteFile")),
+		connect.WithHandlerOptions(opts...),
+	)
 	agentServiceResizeShellTerminalHandler := connect.NewUnaryHandler(
 		AgentServiceResizeShellTerminalProcedure,
 		svc.ResizeShellTerminal,
@@ -442,6 +538,12 @@ func NewAgentServiceHandler(svc AgentServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(agentServiceMethods.ByName("GetFunctions")),
 		connect.WithHandlerOptions(opts...),
 	)
+	agentServiceUpdateColonySecretHandler := connect.NewUnaryHandler(
+		AgentServiceUpdateColonySecretProcedure,
+		svc.UpdateColonySecret,
+		connect.WithSchema(agentServiceMethods.ByName("UpdateColonySecret")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/coral.agent.v1.AgentService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AgentServiceGetRuntimeContextProcedure:
@@ -464,6 +566,12 @@ func NewAgentServiceHandler(svc AgentServiceHandler, opts ...connect.HandlerOpti
 			agentServiceShellExecHandler.ServeHTTP(w, r)
 		case AgentServiceContainerExecProcedure:
 			agentServiceContainerExecHandler.ServeHTTP(w, r)
+		case AgentServiceExecInteractiveProcedure:
+			agentServiceExecInteractiveHandler.ServeHTTP(w, r)
+		case AgentServiceReadFileProcedure:
+			agentServiceReadFileHandler.ServeHTTP(w, r)
+		case AgentServiceWriteFileProcedure:
+			agentServiceWriteFileHandler.ServeHTTP(w, r)
 		case AgentServiceResizeShellTerminalProcedure:
 			agentServiceResizeShellTerminalHandler.ServeHTTP(w, r)
 		case AgentServiceSendShellSignalProcedure:
@@ -474,6 +582,8 @@ func NewAgentServiceHandler(svc AgentServiceHandler, opts ...connect.HandlerOpti
 			agentServiceStreamDebugEventsHandler.ServeHTTP(w, r)
 		case AgentServiceGetFunctionsProcedure:
 			agentServiceGetFunctionsHandler.ServeHTTP(w, r)
+		case AgentServiceUpdateColonySecretProcedure:
+			agentServiceUpdateColonySecretHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -523,6 +633,18 @@ func (UnimplementedAgentServiceHandler) ContainerExec(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentService.ContainerExec is not implemented"))
 }
 
+func (UnimplementedAgentServiceHandler) ExecInteractive(context.Context, *connect.BidiStream[v1.ShellRequest, v1.ShellResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentService.ExecInteractive is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) ReadFile(context.Context, *connect.Request[v1.ReadFileRequest], *connect.ServerStream[v1.ReadFileResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentService.ReadFile is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) WriteFile(context.Context, *connect.ClientStream[v1.WriteFileRequest]) (*connect.Response[v1.WriteFileResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentService.WriteFile is not implemented"))
+}
+
 func (UnimplementedAgentServiceHandler) ResizeShellTerminal(context.Context, *connect.Request[v1.ResizeShellTerminalRequest]) (*connect.Response[v1.ResizeShellTerminalResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentService.ResizeShellTerminal is not implemented"))
 }
@@ -542,3 +664,7 @@ func (UnimplementedAgentServiceHandler) StreamDebugEvents(context.Context, *conn
 func (UnimplementedAgentServiceHandler) GetFunctions(context.Context, *connect.Request[v1.GetFunctionsRequest]) (*connect.Response[v1.GetFunctionsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentService.GetFunctions is not implemented"))
 }
+
+func (UnimplementedAgentServiceHandler) UpdateColonySecret(context.Context, *connect.Request[v1.UpdateColonySecretRequest]) (*connect.Response[v1.UpdateColonySecretResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentService.UpdateColonySecret is not implemented"))
+}
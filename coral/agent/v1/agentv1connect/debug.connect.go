@@ -55,6 +55,12 @@ const (
 	// AgentDebugServiceProfileMemoryProcedure is the fully-qualified name of the AgentDebugService's
 	// ProfileMemory RPC.
 	AgentDebugServiceProfileMemoryProcedure = "/coral.agent.v1.AgentDebugService/ProfileMemory"
+	// AgentDebugServiceProfileIOProcedure is the fully-qualified name of the AgentDebugService's
+	// ProfileIO RPC.
+	AgentDebugServiceProfileIOProcedure = "/coral.agent.v1.AgentDebugService/ProfileIO"
+	// AgentDebugServiceProfileLocksProcedure is the fully-qualified name of the AgentDebugService's
+	// ProfileLocks RPC.
+	AgentDebugServiceProfileLocksProcedure = "/coral.agent.v1.AgentDebugService/ProfileLocks"
 	// AgentDebugServiceQueryMemoryProfileSamplesProcedure is the fully-qualified name of the
 	// AgentDebugService's QueryMemoryProfileSamples RPC.
 	AgentDebugServiceQueryMemoryProfileSamplesProcedure = "/coral.agent.v1.AgentDebugService/QueryMemoryProfileSamples"
@@ -67,6 +73,27 @@ const (
 	// AgentDebugServiceListCorrelationsProcedure is the fully-qualified name of the AgentDebugService's
 	// ListCorrelations RPC.
 	AgentDebugServiceListCorrelationsProcedure = "/coral.agent.v1.AgentDebugService/ListCorrelations"
+	// AgentDebugServiceListCoreDumpsProcedure is the fully-qualified name of the AgentDebugService's
+	// ListCoreDumps RPC.
+	AgentDebugServiceListCoreDumpsProcedure = "/coral.agent.v1.AgentDebugService/ListCoreDumps"
+	// AgentDebugServiceDownloadCoreDumpProcedure is the fully-qualified name of the AgentDebugService's
+	// DownloadCoreDump RPC.
+	AgentDebugServiceDownloadCoreDumpProcedure = "/coral.agent.v1.AgentDebugService/DownloadCoreDump"
+	// AgentDebugServiceDescribeFunctionProcedure is the fully-qualified name of the AgentDebugService's
+	// DescribeFunction RPC.
+	AgentDebugServiceDescribeFunctionProcedure = "/coral.agent.v1.AgentDebugService/DescribeFunction"
+	// AgentDebugServiceStartHttpCaptureProcedure is the fully-qualified name of the AgentDebugService's
+	// StartHttpCapture RPC.
+	AgentDebugServiceStartHttpCaptureProcedure = "/coral.agent.v1.AgentDebugService/StartHttpCapture"
+	// AgentDebugServiceStartTlsCaptureProcedure is the fully-qualified name of the AgentDebugService's
+	// StartTlsCapture RPC.
+	AgentDebugServiceStartTlsCaptureProcedure = "/coral.agent.v1.AgentDebugService/StartTlsCapture"
+	// AgentDebugServiceTraceRuntimeProcedure is the fully-qualified name of the AgentDebugService's
+	// TraceRuntime RPC.
+	AgentDebugServiceTraceRuntimeProcedure = "/coral.agent.v1.AgentDebugService/TraceRuntime"
+	// AgentDebugServiceTraceSyscallsProcedure is the fully-qualified name of the AgentDebugService's
+	// TraceSyscalls RPC.
+	AgentDebugServiceTraceSyscallsProcedure = "/coral.agent.v1.AgentDebugService/TraceSyscalls"
 )
 
 // AgentDebugServiceClient is a client for the coral.agent.v1.AgentDebugService service.
@@ -86,6 +113,12 @@ type AgentDebugServiceClient interface {
 	QueryCPUProfileSamples(context.Context, *connect.Request[v1.QueryCPUProfileSamplesRequest]) (*connect.Response[v1.QueryCPUProfileSamplesResponse], error)
 	// Collect memory profile for a target process (RFD 077).
 	ProfileMemory(context.Context, *connect.Request[v1.ProfileMemoryAgentRequest]) (*connect.Response[v1.ProfileMemoryAgentResponse], error)
+	// ProfileIO profiles the file reads and writes of a service for a
+	// duration, by file and device.
+	ProfileIO(context.Context, *connect.Request[v1.ProfileIOAgentRequest]) (*connect.Response[v1.ProfileIOAgentResponse], error)
+	// ProfileLocks times the futex waits of a service by user stack, for a
+	// lock contention flame graph.
+	ProfileLocks(context.Context, *connect.Request[v1.ProfileLocksAgentRequest]) (*connect.Response[v1.ProfileLocksAgentResponse], error)
 	// Query historical memory profile samples from continuous profiling (RFD 077).
 	QueryMemoryProfileSamples(context.Context, *connect.Request[v1.QueryMemoryProfileSamplesRequest]) (*connect.Response[v1.QueryMemoryProfileSamplesResponse], error)
 	// DeployCorrelation installs a correlation descriptor on the agent (RFD 091).
@@ -95,6 +128,26 @@ type AgentDebugServiceClient interface {
 	RemoveCorrelation(context.Context, *connect.Request[v1.RemoveCorrelationRequest]) (*connect.Response[v1.RemoveCorrelationResponse], error)
 	// ListCorrelations returns all active descriptors on this agent (RFD 091).
 	ListCorrelations(context.Context, *connect.Request[v1.ListCorrelationsRequest]) (*connect.Response[v1.ListCorrelationsResponse], error)
+	// ListCoreDumps returns core dumps captured from crashed services.
+	ListCoreDumps(context.Context, *connect.Request[v1.ListCoreDumpsRequest]) (*connect.Response[v1.ListCoreDumpsResponse], error)
+	// DownloadCoreDump streams a stored core dump in gzip-compressed chunks.
+	DownloadCoreDump(context.Context, *connect.Request[v1.DownloadCoreDumpRequest]) (*connect.ServerStreamForClient[v1.CoreDumpChunk], error)
+	// DescribeFunction returns the signature, argument locations and
+	// probeability of a function.
+	DescribeFunction(context.Context, *connect.Request[v1.DescribeFunctionRequest]) (*connect.Response[v1.DescribeFunctionResponse], error)
+	// StartHttpCapture captures sampled HTTP exchanges of a service from its
+	// sockets. Stop it with StopUprobeCollector.
+	StartHttpCapture(context.Context, *connect.Request[v1.StartHttpCaptureRequest]) (*connect.Response[v1.StartHttpCaptureResponse], error)
+	// StartTlsCapture captures the plaintext of TLS connections of a service
+	// with uprobes on its TLS library. Requires debug.tls_capture.enabled on
+	// the agent. Stop it with StopUprobeCollector.
+	StartTlsCapture(context.Context, *connect.Request[v1.StartTlsCaptureRequest]) (*connect.Response[v1.StartTlsCaptureResponse], error)
+	// TraceRuntime traces the Go runtime of a process for a duration: pauses,
+	// GC cycles and scheduling latency.
+	TraceRuntime(context.Context, *connect.Request[v1.TraceRuntimeAgentRequest]) (*connect.Response[v1.TraceRuntimeAgentResponse], error)
+	// TraceSyscalls counts the syscalls of a service for a duration, with
+	// their latency and error codes.
+	TraceSyscalls(context.Context, *connect.Request[v1.TraceSyscallsAgentRequest]) (*connect.Response[v1.TraceSyscallsAgentResponse], error)
 }
 
 // NewAgentDebugServiceClient constructs a client for the coral.agent.v1.AgentDebugService service.
@@ -150,6 +203,18 @@ func NewAgentDebugServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(agentDebugServiceMethods.ByName("ProfileMemory")),
 			connect.WithClientOptions(opts...),
 		),
+		profileIO: connect.NewClient[v1.ProfileIOAgentRequest, v1.ProfileIOAgentResponse](
+			httpClient,
+			baseURL+AgentDebugServiceProfileIOProcedure,
+			connect.WithSchema(agentDebugServiceMethods.ByName("ProfileIO")),
+			connect.WithClientOptions(opts...),
+		),
+		profileLocks: connect.NewClient[v1.ProfileLocksAgentRequest, v1.ProfileLocksAgentResponse](
+			httpClient,
+			baseURL+AgentDebugServiceProfileLocksProcedure,
+			connect.WithSchema(agentDebugServiceMethods.ByName("ProfileLocks")),
+			connect.WithClientOptions(opts...),
+		),
 		queryMemoryProfileSamples: connect.NewClient[v1.QueryMemoryProfileSamplesRequest, v1.QueryMemoryProfileSamplesResponse](
 			httpClient,
 			baseURL+AgentDebugServiceQueryMemoryProfileSamplesProcedure,
@@ -174,6 +239,48 @@ func NewAgentDebugServiceClient(httpClient connect.HTTPClient, baseURL string, o
 			connect.WithSchema(agentDebugServiceMethods.ByName("ListCorrelations")),
 			connect.WithClientOptions(opts...),
 		),
+		listCoreDumps: connect.NewClient[v1.ListCoreDumpsRequest, v1.ListCoreDumpsResponse](
+			httpClient,
+			baseURL+AgentDebugServiceListCoreDumpsProcedure,
+			connect.WithSchema(agentDebugServiceMethods.ByName("ListCoreDumps")),
+			connect.WithClientOptions(opts...),
+		),
+		downloadCoreDump: connect.NewClient[v1.DownloadCoreDumpRequest, v1.CoreDumpChunk](
+			httpClient,
+			baseURL+AgentDebugServiceDownloadCoreDumpProcedure,
+			connect.WithSchema(agentDebugServiceMethods.ByName("DownloadCoreDump")),
+			connect.WithClientOptions(opts...),
+		),
+		describeFunction: connect.NewClient[v1.DescribeFunctionRequest, v1.DescribeFunctionResponse](
+			httpClient,
+			baseURL+AgentDebugServiceDescribeFunctionProcedure,
+			connect.WithSchema(agentDebugServiceMethods.ByName("DescribeFunction")),
+			connect.WithClientOptions(opts...),
+		),
+		startHttpCapture: connect.NewClient[v1.StartHttpCaptureRequest, v1.StartHttpCaptureResponse](
+			httpClient,
+			baseURL+AgentDebugServiceStartHttpCaptureProcedure,
+			connect.WithSchema(agentDebugServiceMethods.ByName("StartHttpCapture")),
+			connect.WithClientOptions(opts...),
+		),
+		startTlsCapture: connect.NewClient[v1.StartTlsCaptureRequest, v1.StartTlsCaptureResponse](
+			httpClient,
+			baseURL+AgentDebugServiceStartTlsCaptureProcedure,
+			connect.WithSchema(agentDebugServiceMethods.ByName("StartTlsCapture")),
+			connect.WithClientOptions(opts...),
+		),
+		traceRuntime: connect.NewClient[v1.TraceRuntimeAgentRequest, v1.TraceRuntimeAgentResponse](
+			httpClient,
+			baseURL+AgentDebugServiceTraceRuntimeProcedure,
+			connect.WithSchema(agentDebugServiceMethods.ByName("TraceRuntime")),
+			connect.WithClientOptions(opts...),
+		),
+		traceSyscalls: connect.NewClient[v1.TraceSyscallsAgentRequest, v1.TraceSyscallsAgentResponse](
+			httpClient,
+			baseURL+AgentDebugServiceTraceSyscallsProcedure,
+			connect.WithSchema(agentDebugServiceMethods.ByName("TraceSyscalls")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -186,10 +293,19 @@ type agentDebugServiceClient struct {
 	profileCPU                *connect.Client[v1.ProfileCPUAgentRequest, v1.ProfileCPUAgentResponse]
 	queryCPUProfileSamples    *connect.Client[v1.QueryCPUProfileSamplesRequest, v1.QueryCPUProfileSamplesResponse]
 	profileMemory             *connect.Client[v1.ProfileMemoryAgentRequest, v1.ProfileMemoryAgentResponse]
+	profileIO                 *connect.Client[v1.ProfileIOAgentRequest, v1.ProfileIOAgentResponse]
+	profileLocks              *connect.Client[v1.ProfileLocksAgentRequest, v1.ProfileLocksAgentResponse]
 	queryMemoryProfileSamples *connect.Client[v1.QueryMemoryProfileSamplesRequest, v1.QueryMemoryProfileSamplesResponse]
 	deployCorrelation         *connect.Client[v1.DeployCorrelationRequest, v1.DeployCorrelationResponse]
 	removeCorrelation         *connect.Client[v1.RemoveCorrelationRequest, v1.RemoveCorrelationResponse]
 	listCorrelations          *connect.Client[v1.ListCorrelationsRequest, v1.ListCorrelationsResponse]
+	listCoreDumps             *connect.Client[v1.ListCoreDumpsRequest, v1.ListCoreDumpsResponse]
+	downloadCoreDump          *connect.Client[v1.DownloadCoreDumpRequest, v1.CoreDumpChunk]
+	describeFunction          *connect.Client[v1.DescribeFunctionRequest, v1.DescribeFunctionResponse]
+	startHttpCapture          *connect.Client[v1.StartHttpCaptureRequest, v1.StartHttpCaptureResponse]
+	startTlsCapture           *connect.Client[v1.StartTlsCaptureRequest, v1.StartTlsCaptureResponse]
+	traceRuntime              *connect.Client[v1.TraceRuntimeAgentRequest, v1.TraceRuntimeAgentResponse]
+	traceSyscalls             *connect.Client[v1.TraceSyscallsAgentRequest, v1.TraceSyscallsAgentResponse]
 }
 
 // StartUprobeCollector calls coral.agent.v1.AgentDebugService.StartUprobeCollector.
@@ -227,6 +343,16 @@ func (c *agentDebugServiceClient) ProfileMemory(ctx context.Context, req *connec
 	return c.profileMemory.CallUnary(ctx, req)
 }
 
+// ProfileIO calls coral.agent.v1.AgentDebugService.ProfileIO.
+func (c *agentDebugServiceClient) ProfileIO(ctx context.Context, req *connect.Request[v1.ProfileIOAgentRequest]) (*connect.Response[v1.ProfileIOAgentResponse], error) {
+	return c.profileIO.CallUnary(ctx, req)
+}
+
+// ProfileLocks calls coral.agent.v1.AgentDebugService.ProfileLocks.
+func (c *agentDebugServiceClient) ProfileLocks(ctx context.Context, req *connect.Request[v1.ProfileLocksAgentRequest]) (*connect.Response[v1.ProfileLocksAgentResponse], error) {
+	return c.profileLocks.CallUnary(ctx, req)
+}
+
 // QueryMemoryProfileSamples calls coral.agent.v1.AgentDebugService.QueryMemoryProfileSamples.
 func (c *agentDebugServiceClient) QueryMemoryProfileSamples(ctx context.Context, req *connect.Request[v1.QueryMemoryProfileSamplesRequest]) (*connect.Response[v1.QueryMemoryProfileSamplesResponse], error) {
 	return c.queryMemoryProfileSamples.CallUnary(ctx, req)
@@ -247,6 +373,41 @@ func (c *agentDebugServiceClient) ListCorrelations(ctx context.Context, req *con
 	return c.listCorrelations.CallUnary(ctx, req)
 }
 
+// ListCoreDumps calls coral.agent.v1.AgentDebugService.ListCoreDumps.
+func (c *agentDebugServiceClient) ListCoreDumps(ctx context.Context, req *connect.Request[v1.ListCoreDumpsRequest]) (*connect.Response[v1.ListCoreDumpsResponse], error) {
+	return c.listCoreDumps.CallUnary(ctx, req)
+}
+
+// DownloadCoreDump calls coral.agent.v1.AgentDebugService.DownloadCoreDump.
+func (c *agentDebugServiceClient) DownloadCoreDump(ctx context.Context, req *connect.Request[v1.DownloadCoreDumpRequest]) (*connect.ServerStreamForClient[v1.CoreDumpChunk], error) {
+	return c.downloadCoreDump.CallServerStream(ctx, req)
+}
+
+// DescribeFunction calls coral.agent.v1.AgentDebugService.DescribeFunction.
+func (c *agentDebugServiceClient) DescribeFunction(ctx context.Context, req *connect.Request[v1.DescribeFunctionRequest]) (*connect.Response[v1.DescribeFunctionResponse], error) {
+	return c.describeFunction.CallUnary(ctx, req)
+}
+
+// StartHttpCapture calls coral.agent.v1.AgentDebugService.StartHttpCapture.
+func (c *agentDebugServiceClient) StartHttpCapture(ctx context.Context, req *connect.Request[v1.StartHttpCaptureRequest]) (*connect.Response[v1.StartHttpCaptureResponse], error) {
+	return c.startHttpCapture.CallUnary(ctx, req)
+}
+
+// StartTlsCapture calls coral.agent.v1.AgentDebugService.StartTlsCapture.
+func (c *agentDebugServiceClient) StartTlsCapture(ctx context.Context, req *connect.Request[v1.StartTlsCaptureRequest]) (*connect.Response[v1.StartTlsCaptureResponse], error) {
+	return c.startTlsCapture.CallUnary(ctx, req)
+}
+
+// TraceRuntime calls coral.agent.v1.AgentDebugService.TraceRuntime.
+func (c *agentDebugServiceClient) TraceRuntime(ctx context.Context, req *connect.Request[v1.TraceRuntimeAgentRequest]) (*connect.Response[v1.TraceRuntimeAgentResponse], error) {
+	return c.traceRuntime.CallUnary(ctx, req)
+}
+
+// TraceSyscalls calls coral.agent.v1.AgentDebugService.TraceSyscalls.
+func (c *agentDebugServiceClient) TraceSyscalls(ctx context.Context, req *connect.Request[v1.TraceSyscallsAgentRequest]) (*connect.Response[v1.TraceSyscallsAgentResponse], error) {
+	return c.traceSyscalls.CallUnary(ctx, req)
+}
+
 // AgentDebugServiceHandler is an implementation of the coral.agent.v1.AgentDebugService service.
 type AgentDebugServiceHandler interface {
 	// Start a uprobe collector on an agent.
@@ -264,6 +425,12 @@ type AgentDebugServiceHandler interface {
 	QueryCPUProfileSamples(context.Context, *connect.Request[v1.QueryCPUProfileSamplesRequest]) (*connect.Response[v1.QueryCPUProfileSamplesResponse], error)
 	// Collect memory profile for a target process (RFD 077).
 	ProfileMemory(context.Context, *connect.Request[v1.ProfileMemoryAgentRequest]) (*connect.Response[v1.ProfileMemoryAgentResponse], error)
+	// ProfileIO profiles the file reads and writes of a service for a
+	// duration, by file and device.
+	ProfileIO(context.Context, *connect.Request[v1.ProfileIOAgentRequest]) (*connect.Response[v1.ProfileIOAgentResponse], error)
+	// ProfileLocks times the futex waits of a service by user stack, for a
+	// lock contention flame graph.
+	ProfileLocks(context.Context, *connect.Request[v1.ProfileLocksAgentRequest]) (*connect.Response[v1.ProfileLocksAgentResponse], error)
 	// Query historical memory profile samples from continuous profiling (RFD 077).
 	QueryMemoryProfileSamples(context.Context, *connect.Request[v1.QueryMemoryProfileSamplesRequest]) (*connect.Response[v1.QueryMemoryProfileSamplesResponse], error)
 	// DeployCorrelation installs a correlation descriptor on the agent (RFD 091).
@@ -273,6 +440,26 @@ type AgentDebugServiceHandler interface {
 	RemoveCorrelation(context.Context, *connect.Request[v1.RemoveCorrelationRequest]) (*connect.Response[v1.RemoveCorrelationResponse], error)
 	// ListCorrelations returns all active descriptors on this agent (RFD 091).
 	ListCorrelations(context.Context, *connect.Request[v1.ListCorrelationsRequest]) (*connect.Response[v1.ListCorrelationsResponse], error)
+	// ListCoreDumps returns core dumps captured from crashed services.
+	ListCoreDumps(context.Context, *connect.Request[v1.ListCoreDumpsRequest]) (*connect.Response[v1.ListCoreDumpsResponse], error)
+	// DownloadCoreDump streams a stored core dump in gzip-compressed chunks.
+	DownloadCoreDump(context.Context, *connect.Request[v1.DownloadCoreDumpRequest], *connect.ServerStream[v1.CoreDumpChunk]) error
+	// DescribeFunction returns the signature, argument locations and
+	// probeability of a function.
+	DescribeFunction(context.Context, *connect.Request[v1.DescribeFunctionRequest]) (*connect.Response[v1.DescribeFunctionResponse], error)
+	// StartHttpCapture captures sampled HTTP exchanges of a service from its
+	// sockets. Stop it with StopUprobeCollector.
+	StartHttpCapture(context.Context, *connect.Request[v1.StartHttpCaptureRequest]) (*connect.Response[v1.StartHttpCaptureResponse], error)
+	// StartTlsCapture captures the plaintext of TLS connections of a service
+	// with uprobes on its TLS library. Requires debug.tls_capture.enabled on
+	// the agent. Stop it with StopUprobeCollector.
+	StartTlsCapture(context.Context, *connect.Request[v1.StartTlsCaptureRequest]) (*connect.Response[v1.StartTlsCaptureResponse], error)
+	// TraceRuntime traces the Go runtime of a process for a duration: pauses,
+	// GC cycles and scheduling latency.
+	TraceRuntime(context.Context, *connect.Request[v1.TraceRuntimeAgentRequest]) (*connect.Response[v1.TraceRuntimeAgentResponse], error)
+	// TraceSyscalls counts the syscalls of a service for a duration, with
+	// their latency and error codes.
+	TraceSyscalls(context.Context, *connect.Request[v1.TraceSyscallsAgentRequest]) (*connect.Response[v1.TraceSyscallsAgentResponse], error)
 }
 
 // NewAgentDebugServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -324,6 +511,18 @@ func NewAgentDebugServiceHandler(svc AgentDebugServiceHandler, opts ...connect.H
 		connect.WithSchema(agentDebugServiceMethods.ByName("ProfileMemory")),
 		connect.WithHandlerOptions(opts...),
 	)
+	agentDebugServiceProfileIOHandler := connect.NewUnaryHandler(
+		AgentDebugServiceProfileIOProcedure,
+		svc.ProfileIO,
+		connect.WithSchema(agentDebugServiceMethods.ByName("ProfileIO")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentDebugServiceProfileLocksHandler := connect.NewUnaryHandler(
+		AgentDebugServiceProfileLocksProcedure,
+		svc.ProfileLocks,
+		connect.WithSchema(agentDebugServiceMethods.ByName("ProfileLocks")),
+		connect.WithHandlerOptions(opts...),
+	)
 	agentDebugServiceQueryMemoryProfileSamplesHandler := connect.NewUnaryHandler(
 		AgentDebugServiceQueryMemoryProfileSamplesProcedure,
 		svc.QueryMemoryProfileSamples,
@@ -348,6 +547,48 @@ func NewAgentDebugServiceHandler(svc AgentDebugServiceHandler, opts ...connect.H
 		connect.WithSchema(agentDebugServiceMethods.ByName("ListCorrelations")),
 		connect.WithHandlerOptions(opts...),
 	)
+	agentDebugServiceListCoreDumpsHandler := connect.NewUnaryHandler(
+		AgentDebugServiceListCoreDumpsProcedure,
+		svc.ListCoreDumps,
+		connect.WithSchema(agentDebugServiceMethods.ByName("ListCoreDumps")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentDebugServiceDownloadCoreDumpHandler := connect.NewServerStreamHandler(
+		AgentDebugServiceDownloadCoreDumpProcedure,
+		svc.DownloadCoreDump,
+		connect.WithSchema(agentDebugServiceMethods.ByName("DownloadCoreDump")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentDebugServiceDescribeFunctionHandler := connect.NewUnaryHandler(
+		AgentDebugServiceDescribeFunctionProcedure,
+		svc.DescribeFunction,
+		connect.WithSchema(agentDebugServiceMethods.ByName("DescribeFunction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentDebugServiceStartHttpCaptureHandler := connect.NewUnaryHandler(
+		AgentDebugServiceStartHttpCaptureProcedure,
+		svc.StartHttpCapture,
+		connect.WithSchema(agentDebugServiceMethods.ByName("StartHttpCapture")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentDebugServiceStartTlsCaptureHandler := connect.NewUnaryHandler(
+		AgentDebugServiceStartTlsCaptureProcedure,
+		svc.StartTlsCapture,
+		connect.WithSchema(agentDebugServiceMethods.ByName("StartTlsCapture")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentDebugServiceTraceRuntimeHandler := connect.NewUnaryHandler(
+		AgentDebugServiceTraceRuntimeProcedure,
+		svc.TraceRuntime,
+		connect.WithSchema(agentDebugServiceMethods.ByName("TraceRuntime")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentDebugServiceTraceSyscallsHandler := connect.NewUnaryHandler(
+		AgentDebugServiceTraceSyscallsProcedure,
+		svc.TraceSyscalls,
+		connect.WithSchema(agentDebugServiceMethods.ByName("TraceSyscalls")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/coral.agent.v1.AgentDebugService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AgentDebugServiceStartUprobeCollectorProcedure:
@@ -364,6 +605,10 @@ func NewAgentDebugServiceHandler(svc AgentDebugServiceHandler, opts ...connect.H
 			agentDebugServiceQueryCPUProfileSamplesHandler.ServeHTTP(w, r)
 		case AgentDebugServiceProfileMemoryProcedure:
 			agentDebugServiceProfileMemoryHandler.ServeHTTP(w, r)
+		case AgentDebugServiceProfileIOProcedure:
+			agentDebugServiceProfileIOHandler.ServeHTTP(w, r)
+		case AgentDebugServiceProfileLocksProcedure:
+			agentDebugServiceProfileLocksHandler.ServeHTTP(w, r)
 		case AgentDebugServiceQueryMemoryProfileSamplesProcedure:
 			agentDebugServiceQueryMemoryProfileSamplesHandler.ServeHTTP(w, r)
 		case AgentDebugServiceDeployCorrelationProcedure:
@@ -372,6 +617,20 @@ func NewAgentDebugServiceHandler(svc AgentDebugServiceHandler, opts ...connect.H
 			agentDebugServiceRemoveCorrelationHandler.ServeHTTP(w, r)
 		case AgentDebugServiceListCorrelationsProcedure:
 			agentDebugServiceListCorrelationsHandler.ServeHTTP(w, r)
+		case AgentDebugServiceListCoreDumpsProcedure:
+			agentDebugServiceListCoreDumpsHandler.ServeHTTP(w, r)
+		case AgentDebugServiceDownloadCoreDumpProcedure:
+			agentDebugServiceDownloadCoreDumpHandler.ServeHTTP(w, r)
+		case AgentDebugServiceDescribeFunctionProcedure:
+			agentDebugServiceDescribeFunctionHandler.ServeHTTP(w, r)
+		case AgentDebugServiceStartHttpCaptureProcedure:
+			agentDebugServiceStartHttpCaptureHandler.ServeHTTP(w, r)
+		case AgentDebugServiceStartTlsCaptureProcedure:
+			agentDebugServiceStartTlsCaptureHandler.ServeHTTP(w, r)
+		case AgentDebugServiceTraceRuntimeProcedure:
+			agentDebugServiceTraceRuntimeHandler.ServeHTTP(w, r)
+		case AgentDebugServiceTraceSyscallsProcedure:
+			agentDebugServiceTraceSyscallsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -409,6 +668,14 @@ func (UnimplementedAgentDebugServiceHandler) ProfileMemory(context.Context, *con
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.ProfileMemory is not implemented"))
 }
 
+func (UnimplementedAgentDebugServiceHandler) ProfileIO(context.Context, *connect.Request[v1.ProfileIOAgentRequest]) (*connect.Response[v1.ProfileIOAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.ProfileIO is not implemented"))
+}
+
+func (UnimplementedAgentDebugServiceHandler) ProfileLocks(context.Context, *connect.Request[v1.ProfileLocksAgentRequest]) (*connect.Response[v1.ProfileLocksAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.ProfileLocks is not implemented"))
+}
+
 func (UnimplementedAgentDebugServiceHandler) QueryMemoryProfileSamples(context.Context, *connect.Request[v1.QueryMemoryProfileSamplesRequest]) (*connect.Response[v1.QueryMemoryProfileSamplesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.QueryMemoryProfileSamples is not implemented"))
 }
@@ -424,3 +691,31 @@ func (UnimplementedAgentDebugServiceHandler) RemoveCorrelation(context.Context,
 func (UnimplementedAgentDebugServiceHandler) ListCorrelations(context.Context, *connect.Request[v1.ListCorrelationsRequest]) (*connect.Response[v1.ListCorrelationsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.ListCorrelations is not implemented"))
 }
+
+func (UnimplementedAgentDebugServiceHandler) ListCoreDumps(context.Context, *connect.Request[v1.ListCoreDumpsRequest]) (*connect.Response[v1.ListCoreDumpsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.ListCoreDumps is not implemented"))
+}
+
+func (UnimplementedAgentDebugServiceHandler) DownloadCoreDump(context.Context, *connect.Request[v1.DownloadCoreDumpRequest], *connect.ServerStream[v1.CoreDumpChunk]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.DownloadCoreDump is not implemented"))
+}
+
+func (UnimplementedAgentDebugServiceHandler) DescribeFunction(context.Context, *connect.Request[v1.DescribeFunctionRequest]) (*connect.Response[v1.DescribeFunctionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.DescribeFunction is not implemented"))
+}
+
+func (UnimplementedAgentDebugServiceHandler) StartHttpCapture(context.Context, *connect.Request[v1.StartHttpCaptureRequest]) (*connect.Response[v1.StartHttpCaptureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.StartHttpCapture is not implemented"))
+}
+
+func (UnimplementedAgentDebugServiceHandler) StartTlsCapture(context.Context, *connect.Request[v1.StartTlsCaptureRequest]) (*connect.Response[v1.StartTlsCaptureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.StartTlsCapture is not implemented"))
+}
+
+func (UnimplementedAgentDebugServiceHandler) TraceRuntime(context.Context, *connect.Request[v1.TraceRuntimeAgentRequest]) (*connect.Response[v1.TraceRuntimeAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.TraceRuntime is not implemented"))
+}
+
+func (UnimplementedAgentDebugServiceHandler) TraceSyscalls(context.Context, *connect.Request[v1.TraceSyscallsAgentRequest]) (*connect.Response[v1.TraceSyscallsAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.agent.v1.AgentDebugService.TraceSyscalls is not implemented"))
+}
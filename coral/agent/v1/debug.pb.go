@@ -32,8 +32,9 @@ type StartUprobeCollectorRequest struct {
 	FunctionName  string                 `protobuf:"bytes,3,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"` // e.g., "github.com/myapp/pkg.ValidateCard"
 	Duration      *durationpb.Duration   `protobuf:"bytes,4,opt,name=duration,proto3" json:"duration,omitempty"`                             // Max 600s
 	Config        *UprobeConfig          `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
-	SdkAddr       string                 `protobuf:"bytes,6,opt,name=sdk_addr,json=sdkAddr,proto3" json:"sdk_addr,omitempty"` // SDK debug service address (e.g., "localhost:50051")
-	Filter        *UprobeFilter          `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`                  // Optional kernel-level filter (RFD 090).
+	SdkAddr       string                 `protobuf:"bytes,6,opt,name=sdk_addr,json=sdkAddr,proto3" json:"sdk_addr,omitempty"`       // SDK debug service address (e.g., "localhost:50051")
+	Filter        *UprobeFilter          `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`                        // Optional kernel-level filter (RFD 090).
+	SessionId     string                 `protobuf:"bytes,8,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Colony session ID; events are pushed to the colony tagged with it.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -117,6 +118,13 @@ func (x *StartUprobeCollectorRequest) GetFilter() *UprobeFilter {
 	return nil
 }
 
+func (x *StartUprobeCollectorRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
 // UprobeConfig specifies what data to capture from function calls.
 type UprobeConfig struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -726,9 +734,21 @@ type UprobeEvent struct {
 	Pid          int32                  `protobuf:"varint,8,opt,name=pid,proto3" json:"pid,omitempty"`
 	Tid          int32                  `protobuf:"varint,9,opt,name=tid,proto3" json:"tid,omitempty"`
 	// Argument/return value capture (optional, based on config).
-	Args          []*FunctionArgument  `protobuf:"bytes,10,rep,name=args,proto3" json:"args,omitempty"`
-	ReturnValue   *FunctionReturnValue `protobuf:"bytes,11,opt,name=return_value,json=returnValue,proto3" json:"return_value,omitempty"`
-	Labels        map[string]string    `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Args        []*FunctionArgument  `protobuf:"bytes,10,rep,name=args,proto3" json:"args,omitempty"`
+	ReturnValue *FunctionReturnValue `protobuf:"bytes,11,opt,name=return_value,json=returnValue,proto3" json:"return_value,omitempty"`
+	Labels      map[string]string    `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Set when the agent masked credentials or personal data in the captured
+	// arguments or return value (debug.redaction).
+	Redacted bool `protobuf:"varint,13,opt,name=redacted,proto3" json:"redacted,omitempty"`
+	// ID of the goroutine that made the call, read from the runtime g struct.
+	// Entry and return events of one invocation share it. 0 when unknown (no
+	// DWARF for runtime.g in the target binary).
+	GoroutineId uint64 `protobuf:"varint,14,opt,name=goroutine_id,json=goroutineId,proto3" json:"goroutine_id,omitempty"`
+	// Captured HTTP exchange, for event_type "http" (HTTP payload capture).
+	// duration_ns holds the time from the request to the response.
+	Http *HttpExchange `protobuf:"bytes,15,opt,name=http,proto3" json:"http,omitempty"`
+	// Captured TLS plaintext, for event_type "tls" (TLS traffic inspection).
+	Tls           *TlsData `protobuf:"bytes,16,opt,name=tls,proto3" json:"tls,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -847,6 +867,307 @@ func (x *UprobeEvent) GetLabels() map[string]string {
 	return nil
 }
 
+func (x *UprobeEvent) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
+func (x *UprobeEvent) GetGoroutineId() uint64 {
+	if x != nil {
+		return x.GoroutineId
+	}
+	return 0
+}
+
+func (x *UprobeEvent) GetHttp() *HttpExchange {
+	if x != nil {
+		return x.Http
+	}
+	return nil
+}
+
+func (x *UprobeEvent) GetTls() *TlsData {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+// HttpHeader is one captured HTTP header. Repeated headers appear once per value.
+type HttpHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HttpHeader) Reset() {
+	*x = HttpHeader{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpHeader) ProtoMessage() {}
+
+func (x *HttpHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpHeader.ProtoReflect.Descriptor instead.
+func (*HttpHeader) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HttpHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HttpHeader) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// HttpExchange is an HTTP/1.x request and its response, captured from the
+// service's sockets.
+type HttpExchange struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Method                string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Path                  string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Query                 string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"` // Raw query string, without "?".
+	Route                 string                 `protobuf:"bytes,4,opt,name=route,proto3" json:"route,omitempty"` // Route pattern that matched the path.
+	StatusCode            int32                  `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	RequestHeaders        []*HttpHeader          `protobuf:"bytes,6,rep,name=request_headers,json=requestHeaders,proto3" json:"request_headers,omitempty"`
+	ResponseHeaders       []*HttpHeader          `protobuf:"bytes,7,rep,name=response_headers,json=responseHeaders,proto3" json:"response_headers,omitempty"`
+	RequestBody           string                 `protobuf:"bytes,8,opt,name=request_body,json=requestBody,proto3" json:"request_body,omitempty"` // Empty unless bodies are captured.
+	ResponseBody          string                 `protobuf:"bytes,9,opt,name=response_body,json=responseBody,proto3" json:"response_body,omitempty"`
+	RequestBodyTruncated  bool                   `protobuf:"varint,10,opt,name=request_body_truncated,json=requestBodyTruncated,proto3" json:"request_body_truncated,omitempty"` // Body was longer than max_body_bytes.
+	ResponseBodyTruncated bool                   `protobuf:"varint,11,opt,name=response_body_truncated,json=responseBodyTruncated,proto3" json:"response_body_truncated,omitempty"`
+	ClientAddr            string                 `protobuf:"bytes,12,opt,name=client_addr,json=clientAddr,proto3" json:"client_addr,omitempty"` // Remote address of the connection.
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *HttpExchange) Reset() {
+	*x = HttpExchange{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HttpExchange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HttpExchange) ProtoMessage() {}
+
+func (x *HttpExchange) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HttpExchange.ProtoReflect.Descriptor instead.
+func (*HttpExchange) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *HttpExchange) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetRoute() string {
+	if x != nil {
+		return x.Route
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *HttpExchange) GetRequestHeaders() []*HttpHeader {
+	if x != nil {
+		return x.RequestHeaders
+	}
+	return nil
+}
+
+func (x *HttpExchange) GetResponseHeaders() []*HttpHeader {
+	if x != nil {
+		return x.ResponseHeaders
+	}
+	return nil
+}
+
+func (x *HttpExchange) GetRequestBody() string {
+	if x != nil {
+		return x.RequestBody
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetResponseBody() string {
+	if x != nil {
+		return x.ResponseBody
+	}
+	return ""
+}
+
+func (x *HttpExchange) GetRequestBodyTruncated() bool {
+	if x != nil {
+		return x.RequestBodyTruncated
+	}
+	return false
+}
+
+func (x *HttpExchange) GetResponseBodyTruncated() bool {
+	if x != nil {
+		return x.ResponseBodyTruncated
+	}
+	return false
+}
+
+func (x *HttpExchange) GetClientAddr() string {
+	if x != nil {
+		return x.ClientAddr
+	}
+	return ""
+}
+
+// TlsData is the plaintext of one read or write on a TLS connection, captured
+// from the TLS library of the service.
+type TlsData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Library       string                 `protobuf:"bytes,1,opt,name=library,proto3" json:"library,omitempty"`                                // "go" (crypto/tls) or "openssl".
+	Direction     string                 `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`                            // "write" (sent by the service) or "read" (received).
+	ConnectionId  uint64                 `protobuf:"varint,3,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"` // Address of the library's connection object.
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                                      // Plaintext, up to max_data_bytes.
+	Length        uint32                 `protobuf:"varint,5,opt,name=length,proto3" json:"length,omitempty"`                                 // Bytes read or written by the call.
+	Truncated     bool                   `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`                           // length exceeded max_data_bytes.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TlsData) Reset() {
+	*x = TlsData{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TlsData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TlsData) ProtoMessage() {}
+
+func (x *TlsData) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TlsData.ProtoReflect.Descriptor instead.
+func (*TlsData) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TlsData) GetLibrary() string {
+	if x != nil {
+		return x.Library
+	}
+	return ""
+}
+
+func (x *TlsData) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *TlsData) GetConnectionId() uint64 {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return 0
+}
+
+func (x *TlsData) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *TlsData) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *TlsData) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
 // EbpfEvent is used for QueryUprobeEventsResponse (contains various event types).
 // Note: This references the EbpfEvent from mesh/v1/ebpf.proto, but we need to import it.
 // For now, we'll define QueryUprobeEventsResponse to return UprobeEvent directly.
@@ -860,7 +1181,7 @@ type QueryUprobeEventsResponse struct {
 
 func (x *QueryUprobeEventsResponse) Reset() {
 	*x = QueryUprobeEventsResponse{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[12]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -872,7 +1193,7 @@ func (x *QueryUprobeEventsResponse) String() string {
 func (*QueryUprobeEventsResponse) ProtoMessage() {}
 
 func (x *QueryUprobeEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[12]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -885,7 +1206,7 @@ func (x *QueryUprobeEventsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryUprobeEventsResponse.ProtoReflect.Descriptor instead.
 func (*QueryUprobeEventsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{12}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *QueryUprobeEventsResponse) GetEvents() []*UprobeEvent {
@@ -910,13 +1231,14 @@ type ProfileCPUAgentRequest struct {
 	Pid             int32                  `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`                                                // Target process ID
 	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Profiling duration (default: 30s, max: 300s)
 	FrequencyHz     int32                  `protobuf:"varint,5,opt,name=frequency_hz,json=frequencyHz,proto3" json:"frequency_hz,omitempty"`             // Sampling frequency (default: 99Hz, max: 1000Hz)
+	StoreLocally    bool                   `protobuf:"varint,6,opt,name=store_locally,json=storeLocally,proto3" json:"store_locally,omitempty"`          // Persist the profile in the agent's local DuckDB (offline debugging)
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ProfileCPUAgentRequest) Reset() {
 	*x = ProfileCPUAgentRequest{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[13]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -928,7 +1250,7 @@ func (x *ProfileCPUAgentRequest) String() string {
 func (*ProfileCPUAgentRequest) ProtoMessage() {}
 
 func (x *ProfileCPUAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[13]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -941,7 +1263,7 @@ func (x *ProfileCPUAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileCPUAgentRequest.ProtoReflect.Descriptor instead.
 func (*ProfileCPUAgentRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{13}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *ProfileCPUAgentRequest) GetAgentId() string {
@@ -979,6 +1301,13 @@ func (x *ProfileCPUAgentRequest) GetFrequencyHz() int32 {
 	return 0
 }
 
+func (x *ProfileCPUAgentRequest) GetStoreLocally() bool {
+	if x != nil {
+		return x.StoreLocally
+	}
+	return false
+}
+
 // StackSample represents a unique stack trace with sample count.
 type StackSample struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -990,7 +1319,7 @@ type StackSample struct {
 
 func (x *StackSample) Reset() {
 	*x = StackSample{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[14]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1002,7 +1331,7 @@ func (x *StackSample) String() string {
 func (*StackSample) ProtoMessage() {}
 
 func (x *StackSample) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[14]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +1344,7 @@ func (x *StackSample) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StackSample.ProtoReflect.Descriptor instead.
 func (*StackSample) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{14}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *StackSample) GetFrameNames() []string {
@@ -1040,13 +1369,14 @@ type ProfileCPUAgentResponse struct {
 	LostSamples   uint32                 `protobuf:"varint,3,opt,name=lost_samples,json=lostSamples,proto3" json:"lost_samples,omitempty"`    // Samples lost due to map overflow
 	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`                                    // Error message if collection failed
 	Success       bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`                               // Whether profiling succeeded
+	ProfileId     string                 `protobuf:"bytes,6,opt,name=profile_id,json=profileId,proto3" json:"profile_id,omitempty"`           // ID of the locally stored profile (if store_locally)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ProfileCPUAgentResponse) Reset() {
 	*x = ProfileCPUAgentResponse{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[15]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1058,7 +1388,7 @@ func (x *ProfileCPUAgentResponse) String() string {
 func (*ProfileCPUAgentResponse) ProtoMessage() {}
 
 func (x *ProfileCPUAgentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[15]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,7 +1401,7 @@ func (x *ProfileCPUAgentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileCPUAgentResponse.ProtoReflect.Descriptor instead.
 func (*ProfileCPUAgentResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{15}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ProfileCPUAgentResponse) GetSamples() []*StackSample {
@@ -1109,6 +1439,13 @@ func (x *ProfileCPUAgentResponse) GetSuccess() bool {
 	return false
 }
 
+func (x *ProfileCPUAgentResponse) GetProfileId() string {
+	if x != nil {
+		return x.ProfileId
+	}
+	return ""
+}
+
 // QueryCPUProfileSamplesRequest retrieves historical CPU profile samples from agent's local storage.
 type QueryCPUProfileSamplesRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1126,7 +1463,7 @@ type QueryCPUProfileSamplesRequest struct {
 
 func (x *QueryCPUProfileSamplesRequest) Reset() {
 	*x = QueryCPUProfileSamplesRequest{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[16]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1138,7 +1475,7 @@ func (x *QueryCPUProfileSamplesRequest) String() string {
 func (*QueryCPUProfileSamplesRequest) ProtoMessage() {}
 
 func (x *QueryCPUProfileSamplesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[16]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1151,7 +1488,7 @@ func (x *QueryCPUProfileSamplesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryCPUProfileSamplesRequest.ProtoReflect.Descriptor instead.
 func (*QueryCPUProfileSamplesRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{16}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *QueryCPUProfileSamplesRequest) GetServiceName() string {
@@ -1198,7 +1535,7 @@ type CPUProfileSample struct {
 
 func (x *CPUProfileSample) Reset() {
 	*x = CPUProfileSample{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[17]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1210,7 +1547,7 @@ func (x *CPUProfileSample) String() string {
 func (*CPUProfileSample) ProtoMessage() {}
 
 func (x *CPUProfileSample) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[17]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1223,7 +1560,7 @@ func (x *CPUProfileSample) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CPUProfileSample.ProtoReflect.Descriptor instead.
 func (*CPUProfileSample) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{17}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CPUProfileSample) GetTimestamp() *timestamppb.Timestamp {
@@ -1284,7 +1621,7 @@ type QueryCPUProfileSamplesResponse struct {
 
 func (x *QueryCPUProfileSamplesResponse) Reset() {
 	*x = QueryCPUProfileSamplesResponse{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[18]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1296,7 +1633,7 @@ func (x *QueryCPUProfileSamplesResponse) String() string {
 func (*QueryCPUProfileSamplesResponse) ProtoMessage() {}
 
 func (x *QueryCPUProfileSamplesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[18]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1309,7 +1646,7 @@ func (x *QueryCPUProfileSamplesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryCPUProfileSamplesResponse.ProtoReflect.Descriptor instead.
 func (*QueryCPUProfileSamplesResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{18}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *QueryCPUProfileSamplesResponse) GetSamples() []*CPUProfileSample {
@@ -1362,7 +1699,7 @@ type ProfileMemoryAgentRequest struct {
 
 func (x *ProfileMemoryAgentRequest) Reset() {
 	*x = ProfileMemoryAgentRequest{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[19]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1374,7 +1711,7 @@ func (x *ProfileMemoryAgentRequest) String() string {
 func (*ProfileMemoryAgentRequest) ProtoMessage() {}
 
 func (x *ProfileMemoryAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[19]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1387,7 +1724,7 @@ func (x *ProfileMemoryAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileMemoryAgentRequest.ProtoReflect.Descriptor instead.
 func (*ProfileMemoryAgentRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{19}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ProfileMemoryAgentRequest) GetAgentId() string {
@@ -1446,7 +1783,7 @@ type MemoryStats struct {
 
 func (x *MemoryStats) Reset() {
 	*x = MemoryStats{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[20]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1458,7 +1795,7 @@ func (x *MemoryStats) String() string {
 func (*MemoryStats) ProtoMessage() {}
 
 func (x *MemoryStats) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[20]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1471,7 +1808,7 @@ func (x *MemoryStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoryStats.ProtoReflect.Descriptor instead.
 func (*MemoryStats) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{20}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *MemoryStats) GetAllocBytes() int64 {
@@ -1521,7 +1858,7 @@ type MemoryStackSample struct {
 
 func (x *MemoryStackSample) Reset() {
 	*x = MemoryStackSample{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[21]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1533,7 +1870,7 @@ func (x *MemoryStackSample) String() string {
 func (*MemoryStackSample) ProtoMessage() {}
 
 func (x *MemoryStackSample) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[21]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1546,7 +1883,7 @@ func (x *MemoryStackSample) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoryStackSample.ProtoReflect.Descriptor instead.
 func (*MemoryStackSample) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{21}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *MemoryStackSample) GetFrameNames() []string {
@@ -1583,7 +1920,7 @@ type TopAllocFunction struct {
 
 func (x *TopAllocFunction) Reset() {
 	*x = TopAllocFunction{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[22]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1595,7 +1932,7 @@ func (x *TopAllocFunction) String() string {
 func (*TopAllocFunction) ProtoMessage() {}
 
 func (x *TopAllocFunction) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[22]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1608,7 +1945,7 @@ func (x *TopAllocFunction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopAllocFunction.ProtoReflect.Descriptor instead.
 func (*TopAllocFunction) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{22}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *TopAllocFunction) GetFunction() string {
@@ -1652,7 +1989,7 @@ type TopAllocType struct {
 
 func (x *TopAllocType) Reset() {
 	*x = TopAllocType{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[23]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1664,7 +2001,7 @@ func (x *TopAllocType) String() string {
 func (*TopAllocType) ProtoMessage() {}
 
 func (x *TopAllocType) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[23]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1677,7 +2014,7 @@ func (x *TopAllocType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopAllocType.ProtoReflect.Descriptor instead.
 func (*TopAllocType) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{23}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *TopAllocType) GetTypeName() string {
@@ -1723,7 +2060,7 @@ type ProfileMemoryAgentResponse struct {
 
 func (x *ProfileMemoryAgentResponse) Reset() {
 	*x = ProfileMemoryAgentResponse{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[24]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1735,7 +2072,7 @@ func (x *ProfileMemoryAgentResponse) String() string {
 func (*ProfileMemoryAgentResponse) ProtoMessage() {}
 
 func (x *ProfileMemoryAgentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[24]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1748,7 +2085,7 @@ func (x *ProfileMemoryAgentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileMemoryAgentResponse.ProtoReflect.Descriptor instead.
 func (*ProfileMemoryAgentResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{24}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ProfileMemoryAgentResponse) GetSamples() []*MemoryStackSample {
@@ -1810,7 +2147,7 @@ type QueryMemoryProfileSamplesRequest struct {
 
 func (x *QueryMemoryProfileSamplesRequest) Reset() {
 	*x = QueryMemoryProfileSamplesRequest{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[25]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1822,7 +2159,7 @@ func (x *QueryMemoryProfileSamplesRequest) String() string {
 func (*QueryMemoryProfileSamplesRequest) ProtoMessage() {}
 
 func (x *QueryMemoryProfileSamplesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[25]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1835,7 +2172,7 @@ func (x *QueryMemoryProfileSamplesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryMemoryProfileSamplesRequest.ProtoReflect.Descriptor instead.
 func (*QueryMemoryProfileSamplesRequest) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{25}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *QueryMemoryProfileSamplesRequest) GetServiceName() string {
@@ -1883,7 +2220,7 @@ type MemoryProfileSample struct {
 
 func (x *MemoryProfileSample) Reset() {
 	*x = MemoryProfileSample{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[26]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1895,7 +2232,7 @@ func (x *MemoryProfileSample) String() string {
 func (*MemoryProfileSample) ProtoMessage() {}
 
 func (x *MemoryProfileSample) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[26]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1908,7 +2245,7 @@ func (x *MemoryProfileSample) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoryProfileSample.ProtoReflect.Descriptor instead.
 func (*MemoryProfileSample) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{26}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *MemoryProfileSample) GetTimestamp() *timestamppb.Timestamp {
@@ -1976,7 +2313,7 @@ type QueryMemoryProfileSamplesResponse struct {
 
 func (x *QueryMemoryProfileSamplesResponse) Reset() {
 	*x = QueryMemoryProfileSamplesResponse{}
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[27]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1988,7 +2325,7 @@ func (x *QueryMemoryProfileSamplesResponse) String() string {
 func (*QueryMemoryProfileSamplesResponse) ProtoMessage() {}
 
 func (x *QueryMemoryProfileSamplesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_agent_v1_debug_proto_msgTypes[27]
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2001,7 +2338,7 @@ func (x *QueryMemoryProfileSamplesResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use QueryMemoryProfileSamplesResponse.ProtoReflect.Descriptor instead.
 func (*QueryMemoryProfileSamplesResponse) Descriptor() ([]byte, []int) {
-	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{27}
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *QueryMemoryProfileSamplesResponse) GetSamples() []*MemoryProfileSample {
@@ -2039,183 +2376,2738 @@ func (x *QueryMemoryProfileSamplesResponse) GetSessionId() string {
 	return ""
 }
 
-var File_coral_agent_v1_debug_proto protoreflect.FileDescriptor
+// CoreDumpInfo describes a core dump captured from a crashed service.
+type CoreDumpInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                   // Identifier, unique per agent.
+	ServiceName     string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`              // Service the crashed process belonged to.
+	Pid             int32                  `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`                                                // PID of the crashed process.
+	Executable      string                 `protobuf:"bytes,4,opt,name=executable,proto3" json:"executable,omitempty"`                                   // Executable of the crashed process.
+	Signal          int32                  `protobuf:"varint,5,opt,name=signal,proto3" json:"signal,omitempty"`                                          // Signal that caused the dump.
+	CrashedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=crashed_at,json=crashedAt,proto3" json:"crashed_at,omitempty"`                    // Time of the crash.
+	SizeBytes       uint64                 `protobuf:"varint,7,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`                   // Uncompressed size.
+	CompressedBytes uint64                 `protobuf:"varint,8,opt,name=compressed_bytes,json=compressedBytes,proto3" json:"compressed_bytes,omitempty"` // Stored (gzip) size.
+	Truncated       bool                   `protobuf:"varint,9,opt,name=truncated,proto3" json:"truncated,omitempty"`                                    // Exceeded the per-dump size limit.
+	AgentId         string                 `protobuf:"bytes,10,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                         // Agent holding the dump.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
 
-const file_coral_agent_v1_debug_proto_rawDesc = "" +
-	"\n" +
-	"\x1acoral/agent/v1/debug.proto\x12\x0ecoral.agent.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1acoral/agent/v1/agent.proto\x1a coral/agent/v1/correlation.proto\"\xbe\x02\n" +
-	"\x1bStartUprobeCollectorRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
-	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12#\n" +
-	"\rfunction_name\x18\x03 \x01(\tR\ffunctionName\x125\n" +
-	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\x124\n" +
-	"\x06config\x18\x05 \x01(\v2\x1c.coral.agent.v1.UprobeConfigR\x06config\x12\x19\n" +
-	"\bsdk_addr\x18\x06 \x01(\tR\asdkAddr\x124\n" +
-	"\x06filter\x18\a \x01(\v2\x1c.coral.agent.v1.UprobeFilterR\x06filter\"\x98\x01\n" +
-	"\fUprobeConfig\x12!\n" +
-	"\fcapture_args\x18\x01 \x01(\bR\vcaptureArgs\x12%\n" +
-	"\x0ecapture_return\x18\x02 \x01(\bR\rcaptureReturn\x12\x1f\n" +
-	"\vsample_rate\x18\x03 \x01(\rR\n" +
-	"sampleRate\x12\x1d\n" +
-	"\n" +
-	"max_events\x18\x04 \x01(\rR\tmaxEvents\"\x7f\n" +
-	"\fUprobeFilter\x12&\n" +
-	"\x0fmin_duration_ns\x18\x01 \x01(\x04R\rminDurationNs\x12&\n" +
-	"\x0fmax_duration_ns\x18\x02 \x01(\x04R\rmaxDurationNs\x12\x1f\n" +
-	"\vsample_rate\x18\x03 \x01(\rR\n" +
-	"sampleRate\"s\n" +
-	"\x18UpdateProbeFilterRequest\x12!\n" +
-	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\x124\n" +
-	"\x06filter\x18\x02 \x01(\v2\x1c.coral.agent.v1.UprobeFilterR\x06filter\"\x1b\n" +
-	"\x19UpdateProbeFilterResponse\"\xb0\x01\n" +
-	"\x1cStartUprobeCollectorResponse\x12!\n" +
-	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\x129\n" +
-	"\n" +
-	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1c\n" +
-	"\tsupported\x18\x03 \x01(\bR\tsupported\x12\x14\n" +
-	"\x05error\x18\x04 \x01(\tR\x05error\"?\n" +
-	"\x1aStopUprobeCollectorRequest\x12!\n" +
-	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\"M\n" +
-	"\x1bStopUprobeCollectorResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\xce\x01\n" +
-	"\x18QueryUprobeEventsRequest\x12!\n" +
-	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\x129\n" +
-	"\n" +
-	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
-	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1d\n" +
-	"\n" +
-	"max_events\x18\x04 \x01(\x05R\tmaxEvents\"P\n" +
-	"\x10FunctionArgument\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
-	"\x05value\x18\x03 \x01(\tR\x05value\"\x7f\n" +
-	"\x13FunctionReturnValue\x12\x12\n" +
-	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value\x12\x19\n" +
-	"\bis_error\x18\x03 \x01(\bR\aisError\x12#\n" +
-	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"\xab\x04\n" +
-	"\vUprobeEvent\x128\n" +
-	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12!\n" +
-	"\fcollector_id\x18\x02 \x01(\tR\vcollectorId\x12\x19\n" +
-	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12!\n" +
-	"\fservice_name\x18\x04 \x01(\tR\vserviceName\x12#\n" +
-	"\rfunction_name\x18\x05 \x01(\tR\ffunctionName\x12\x1d\n" +
-	"\n" +
-	"event_type\x18\x06 \x01(\tR\teventType\x12\x1f\n" +
-	"\vduration_ns\x18\a \x01(\x04R\n" +
-	"durationNs\x12\x10\n" +
-	"\x03pid\x18\b \x01(\x05R\x03pid\x12\x10\n" +
-	"\x03tid\x18\t \x01(\x05R\x03tid\x124\n" +
-	"\x04args\x18\n" +
-	" \x03(\v2 .coral.agent.v1.FunctionArgumentR\x04args\x12F\n" +
-	"\freturn_value\x18\v \x01(\v2#.coral.agent.v1.FunctionReturnValueR\vreturnValue\x12?\n" +
-	"\x06labels\x18\f \x03(\v2'.coral.agent.v1.UprobeEvent.LabelsEntryR\x06labels\x1a9\n" +
-	"\vLabelsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"k\n" +
-	"\x19QueryUprobeEventsResponse\x123\n" +
-	"\x06events\x18\x01 \x03(\v2\x1b.coral.agent.v1.UprobeEventR\x06events\x12\x19\n" +
-	"\bhas_more\x18\x02 \x01(\bR\ahasMore\"\xb6\x01\n" +
-	"\x16ProfileCPUAgentRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
-	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
-	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12)\n" +
-	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\x12!\n" +
-	"\ffrequency_hz\x18\x05 \x01(\x05R\vfrequencyHz\"D\n" +
-	"\vStackSample\x12\x1f\n" +
-	"\vframe_names\x18\x01 \x03(\tR\n" +
-	"frameNames\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x04R\x05count\"\xc8\x01\n" +
-	"\x17ProfileCPUAgentResponse\x125\n" +
-	"\asamples\x18\x01 \x03(\v2\x1b.coral.agent.v1.StackSampleR\asamples\x12#\n" +
-	"\rtotal_samples\x18\x02 \x01(\x04R\ftotalSamples\x12!\n" +
-	"\flost_samples\x18\x03 \x01(\rR\vlostSamples\x12\x14\n" +
-	"\x05error\x18\x04 \x01(\tR\x05error\x12\x18\n" +
-	"\asuccess\x18\x05 \x01(\bR\asuccess\"\xa0\x01\n" +
-	"\x1dQueryCPUProfileSamplesRequest\x12!\n" +
-	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12 \n" +
-	"\fstart_seq_id\x18\x02 \x01(\x04R\n" +
-	"startSeqId\x12\x1f\n" +
-	"\vmax_records\x18\x03 \x01(\x05R\n" +
-	"maxRecords\x12\x19\n" +
-	"\bpod_name\x18\x04 \x01(\tR\apodName\"\xe7\x01\n" +
-	"\x10CPUProfileSample\x128\n" +
-	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
-	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x12!\n" +
-	"\fstack_frames\x18\x03 \x03(\tR\vstackFrames\x12!\n" +
-	"\fsample_count\x18\x04 \x01(\rR\vsampleCount\x12!\n" +
-	"\fservice_name\x18\x05 \x01(\tR\vserviceName\x12\x15\n" +
-	"\x06seq_id\x18\x06 \x01(\x04R\x05seqId\"\xd4\x01\n" +
-	"\x1eQueryCPUProfileSamplesResponse\x12:\n" +
-	"\asamples\x18\x01 \x03(\v2 .coral.agent.v1.CPUProfileSampleR\asamples\x12#\n" +
-	"\rtotal_samples\x18\x02 \x01(\x04R\ftotalSamples\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1c\n" +
-	"\n" +
-	"max_seq_id\x18\x04 \x01(\x04R\bmaxSeqId\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x05 \x01(\tR\tsessionId\"\xdd\x01\n" +
-	"\x19ProfileMemoryAgentRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
-	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
-	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12)\n" +
-	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\x12*\n" +
-	"\x11sample_rate_bytes\x18\x05 \x01(\x05R\x0fsampleRateBytes\x12\x19\n" +
-	"\bsdk_addr\x18\x06 \x01(\tR\asdkAddr\"\xc8\x01\n" +
-	"\vMemoryStats\x12\x1f\n" +
-	"\valloc_bytes\x18\x01 \x01(\x03R\n" +
-	"allocBytes\x12*\n" +
-	"\x11total_alloc_bytes\x18\x02 \x01(\x03R\x0ftotalAllocBytes\x12\x1b\n" +
-	"\tsys_bytes\x18\x03 \x01(\x03R\bsysBytes\x12\x15\n" +
-	"\x06num_gc\x18\x04 \x01(\x03R\x05numGc\x128\n" +
-	"\x19heap_growth_bytes_per_sec\x18\x05 \x01(\x01R\x15heapGrowthBytesPerSec\"z\n" +
-	"\x11MemoryStackSample\x12\x1f\n" +
-	"\vframe_names\x18\x01 \x03(\tR\n" +
-	"frameNames\x12\x1f\n" +
-	"\valloc_bytes\x18\x02 \x01(\x03R\n" +
-	"allocBytes\x12#\n" +
-	"\ralloc_objects\x18\x03 \x01(\x03R\fallocObjects\"p\n" +
-	"\x10TopAllocFunction\x12\x1a\n" +
-	"\bfunction\x18\x01 \x01(\tR\bfunction\x12\x14\n" +
-	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12\x18\n" +
-	"\aobjects\x18\x03 \x01(\x03R\aobjects\x12\x10\n" +
-	"\x03pct\x18\x04 \x01(\x01R\x03pct\"m\n" +
-	"\fTopAllocType\x12\x1b\n" +
-	"\ttype_name\x18\x01 \x01(\tR\btypeName\x12\x14\n" +
-	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12\x18\n" +
-	"\aobjects\x18\x03 \x01(\x03R\aobjects\x12\x10\n" +
-	"\x03pct\x18\x04 \x01(\x01R\x03pct\"\xbe\x02\n" +
-	"\x1aProfileMemoryAgentResponse\x12;\n" +
-	"\asamples\x18\x01 \x03(\v2!.coral.agent.v1.MemoryStackSampleR\asamples\x121\n" +
-	"\x05stats\x18\x02 \x01(\v2\x1b.coral.agent.v1.MemoryStatsR\x05stats\x12E\n" +
-	"\rtop_functions\x18\x03 \x03(\v2 .coral.agent.v1.TopAllocFunctionR\ftopFunctions\x129\n" +
-	"\ttop_types\x18\x04 \x03(\v2\x1c.coral.agent.v1.TopAllocTypeR\btopTypes\x12\x14\n" +
-	"\x05error\x18\x05 \x01(\tR\x05error\x12\x18\n" +
-	"\asuccess\x18\x06 \x01(\bR\asuccess\"\xa3\x01\n" +
-	" QueryMemoryProfileSamplesRequest\x12!\n" +
-	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x19\n" +
-	"\bpod_name\x18\x02 \x01(\tR\apodName\x12 \n" +
-	"\fstart_seq_id\x18\x03 \x01(\x04R\n" +
-	"startSeqId\x12\x1f\n" +
-	"\vmax_records\x18\x04 \x01(\x05R\n" +
-	"maxRecords\"\x8d\x02\n" +
-	"\x13MemoryProfileSample\x128\n" +
-	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
-	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x12!\n" +
-	"\fstack_frames\x18\x03 \x03(\tR\vstackFrames\x12\x1f\n" +
-	"\valloc_bytes\x18\x04 \x01(\x03R\n" +
-	"allocBytes\x12#\n" +
-	"\ralloc_objects\x18\x05 \x01(\x03R\fallocObjects\x12!\n" +
-	"\fservice_name\x18\x06 \x01(\tR\vserviceName\x12\x15\n" +
-	"\x06seq_id\x18\a \x01(\x04R\x05seqId\"\xe1\x01\n" +
-	"!QueryMemoryProfileSamplesResponse\x12=\n" +
-	"\asamples\x18\x01 \x03(\v2#.coral.agent.v1.MemoryProfileSampleR\asamples\x12*\n" +
-	"\x11total_alloc_bytes\x18\x02 \x01(\x03R\x0ftotalAllocBytes\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1c\n" +
-	"\n" +
-	"max_seq_id\x18\x04 \x01(\x04R\bmaxSeqId\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x05 \x01(\tR\tsessionId2\xc8\t\n" +
+func (x *CoreDumpInfo) Reset() {
+	*x = CoreDumpInfo{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreDumpInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreDumpInfo) ProtoMessage() {}
+
+func (x *CoreDumpInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreDumpInfo.ProtoReflect.Descriptor instead.
+func (*CoreDumpInfo) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CoreDumpInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CoreDumpInfo) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *CoreDumpInfo) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *CoreDumpInfo) GetExecutable() string {
+	if x != nil {
+		return x.Executable
+	}
+	return ""
+}
+
+func (x *CoreDumpInfo) GetSignal() int32 {
+	if x != nil {
+		return x.Signal
+	}
+	return 0
+}
+
+func (x *CoreDumpInfo) GetCrashedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CrashedAt
+	}
+	return nil
+}
+
+func (x *CoreDumpInfo) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *CoreDumpInfo) GetCompressedBytes() uint64 {
+	if x != nil {
+		return x.CompressedBytes
+	}
+	return 0
+}
+
+func (x *CoreDumpInfo) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *CoreDumpInfo) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+// ListCoreDumpsRequest lists core dumps stored on the agent.
+type ListCoreDumpsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"` // Optional service filter.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoreDumpsRequest) Reset() {
+	*x = ListCoreDumpsRequest{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoreDumpsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoreDumpsRequest) ProtoMessage() {}
+
+func (x *ListCoreDumpsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoreDumpsRequest.ProtoReflect.Descriptor instead.
+func (*ListCoreDumpsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListCoreDumpsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ListCoreDumpsResponse returns stored core dumps, newest first.
+type ListCoreDumpsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dumps         []*CoreDumpInfo        `protobuf:"bytes,1,rep,name=dumps,proto3" json:"dumps,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"` // Core dump capture is enabled on the agent.
+	Mode          string                 `protobuf:"bytes,3,opt,name=mode,proto3" json:"mode,omitempty"`        // Capture mode (core_pattern, coredumpctl).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoreDumpsResponse) Reset() {
+	*x = ListCoreDumpsResponse{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoreDumpsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoreDumpsResponse) ProtoMessage() {}
+
+func (x *ListCoreDumpsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoreDumpsResponse.ProtoReflect.Descriptor instead.
+func (*ListCoreDumpsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListCoreDumpsResponse) GetDumps() []*CoreDumpInfo {
+	if x != nil {
+		return x.Dumps
+	}
+	return nil
+}
+
+func (x *ListCoreDumpsResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ListCoreDumpsResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// DownloadCoreDumpRequest requests a stored core dump.
+type DownloadCoreDumpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadCoreDumpRequest) Reset() {
+	*x = DownloadCoreDumpRequest{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadCoreDumpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadCoreDumpRequest) ProtoMessage() {}
+
+func (x *DownloadCoreDumpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadCoreDumpRequest.ProtoReflect.Descriptor instead.
+func (*DownloadCoreDumpRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DownloadCoreDumpRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// CoreDumpChunk is part of a gzip-compressed core dump stream. The first
+// chunk carries the dump metadata.
+type CoreDumpChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Info          *CoreDumpInfo          `protobuf:"bytes,1,opt,name=info,proto3" json:"info,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoreDumpChunk) Reset() {
+	*x = CoreDumpChunk{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoreDumpChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoreDumpChunk) ProtoMessage() {}
+
+func (x *CoreDumpChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoreDumpChunk.ProtoReflect.Descriptor instead.
+func (*CoreDumpChunk) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CoreDumpChunk) GetInfo() *CoreDumpInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *CoreDumpChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// DescribeFunctionRequest asks for the signature of a function of a service.
+type DescribeFunctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	FunctionName  string                 `protobuf:"bytes,2,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeFunctionRequest) Reset() {
+	*x = DescribeFunctionRequest{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeFunctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeFunctionRequest) ProtoMessage() {}
+
+func (x *DescribeFunctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeFunctionRequest.ProtoReflect.Descriptor instead.
+func (*DescribeFunctionRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DescribeFunctionRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *DescribeFunctionRequest) GetFunctionName() string {
+	if x != nil {
+		return x.FunctionName
+	}
+	return ""
+}
+
+// DescribeFunctionResponse describes the function.
+type DescribeFunctionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Function      *FunctionDescription   `protobuf:"bytes,1,opt,name=function,proto3" json:"function,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DescribeFunctionResponse) Reset() {
+	*x = DescribeFunctionResponse{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeFunctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeFunctionResponse) ProtoMessage() {}
+
+func (x *DescribeFunctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeFunctionResponse.ProtoReflect.Descriptor instead.
+func (*DescribeFunctionResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DescribeFunctionResponse) GetFunction() *FunctionDescription {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+// StartHttpCaptureRequest starts capturing HTTP exchanges of a service whose
+// path matches a route.
+type StartHttpCaptureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Route         string                 `protobuf:"bytes,3,opt,name=route,proto3" json:"route,omitempty"`                               // e.g., "/api/checkout", "/api/orders/{id}", "/api/*"
+	SampleRate    float64                `protobuf:"fixed64,4,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"` // Fraction of matching requests to capture, (0, 1]. 0 = all.
+	CaptureBodies bool                   `protobuf:"varint,5,opt,name=capture_bodies,json=captureBodies,proto3" json:"capture_bodies,omitempty"`
+	MaxBodyBytes  uint32                 `protobuf:"varint,6,opt,name=max_body_bytes,json=maxBodyBytes,proto3" json:"max_body_bytes,omitempty"` // Body cap when capture_bodies is set. 0 = 4096.
+	Duration      *durationpb.Duration   `protobuf:"bytes,7,opt,name=duration,proto3" json:"duration,omitempty"`                                // Max 600s
+	SessionId     string                 `protobuf:"bytes,8,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`             // Colony session ID; events are pushed to the colony tagged with it.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartHttpCaptureRequest) Reset() {
+	*x = StartHttpCaptureRequest{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartHttpCaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartHttpCaptureRequest) ProtoMessage() {}
+
+func (x *StartHttpCaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartHttpCaptureRequest.ProtoReflect.Descriptor instead.
+func (*StartHttpCaptureRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StartHttpCaptureRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *StartHttpCaptureRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *StartHttpCaptureRequest) GetRoute() string {
+	if x != nil {
+		return x.Route
+	}
+	return ""
+}
+
+func (x *StartHttpCaptureRequest) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *StartHttpCaptureRequest) GetCaptureBodies() bool {
+	if x != nil {
+		return x.CaptureBodies
+	}
+	return false
+}
+
+func (x *StartHttpCaptureRequest) GetMaxBodyBytes() uint32 {
+	if x != nil {
+		return x.MaxBodyBytes
+	}
+	return 0
+}
+
+func (x *StartHttpCaptureRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *StartHttpCaptureRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// StartHttpCaptureResponse confirms the capture started.
+type StartHttpCaptureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectorId   string                 `protobuf:"bytes,1,opt,name=collector_id,json=collectorId,proto3" json:"collector_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Supported     bool                   `protobuf:"varint,3,opt,name=supported,proto3" json:"supported,omitempty"` // false if socket capture is not available
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartHttpCaptureResponse) Reset() {
+	*x = StartHttpCaptureResponse{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartHttpCaptureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartHttpCaptureResponse) ProtoMessage() {}
+
+func (x *StartHttpCaptureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartHttpCaptureResponse.ProtoReflect.Descriptor instead.
+func (*StartHttpCaptureResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StartHttpCaptureResponse) GetCollectorId() string {
+	if x != nil {
+		return x.CollectorId
+	}
+	return ""
+}
+
+func (x *StartHttpCaptureResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *StartHttpCaptureResponse) GetSupported() bool {
+	if x != nil {
+		return x.Supported
+	}
+	return false
+}
+
+func (x *StartHttpCaptureResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// StartTlsCaptureRequest starts capturing the plaintext of TLS connections
+// of a service.
+type StartTlsCaptureRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Library       string                 `protobuf:"bytes,3,opt,name=library,proto3" json:"library,omitempty"`                                  // "go", "openssl" or "" to detect.
+	Match         string                 `protobuf:"bytes,4,opt,name=match,proto3" json:"match,omitempty"`                                      // Capture connections whose plaintext contains it. Empty = all.
+	MaxDataBytes  uint32                 `protobuf:"varint,5,opt,name=max_data_bytes,json=maxDataBytes,proto3" json:"max_data_bytes,omitempty"` // Plaintext kept per read or write. 0 = 4096, max 16384.
+	Duration      *durationpb.Duration   `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`                                // Max 600s
+	SessionId     string                 `protobuf:"bytes,7,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`             // Colony session ID; events are pushed to the colony tagged with it.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTlsCaptureRequest) Reset() {
+	*x = StartTlsCaptureRequest{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTlsCaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTlsCaptureRequest) ProtoMessage() {}
+
+func (x *StartTlsCaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTlsCaptureRequest.ProtoReflect.Descriptor instead.
+func (*StartTlsCaptureRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StartTlsCaptureRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *StartTlsCaptureRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *StartTlsCaptureRequest) GetLibrary() string {
+	if x != nil {
+		return x.Library
+	}
+	return ""
+}
+
+func (x *StartTlsCaptureRequest) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *StartTlsCaptureRequest) GetMaxDataBytes() uint32 {
+	if x != nil {
+		return x.MaxDataBytes
+	}
+	return 0
+}
+
+func (x *StartTlsCaptureRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *StartTlsCaptureRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// StartTlsCaptureResponse confirms the capture started.
+type StartTlsCaptureResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectorId   string                 `protobuf:"bytes,1,opt,name=collector_id,json=collectorId,proto3" json:"collector_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Supported     bool                   `protobuf:"varint,3,opt,name=supported,proto3" json:"supported,omitempty"` // false if uprobes are not available
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTlsCaptureResponse) Reset() {
+	*x = StartTlsCaptureResponse{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTlsCaptureResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTlsCaptureResponse) ProtoMessage() {}
+
+func (x *StartTlsCaptureResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTlsCaptureResponse.ProtoReflect.Descriptor instead.
+func (*StartTlsCaptureResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StartTlsCaptureResponse) GetCollectorId() string {
+	if x != nil {
+		return x.CollectorId
+	}
+	return ""
+}
+
+func (x *StartTlsCaptureResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *StartTlsCaptureResponse) GetSupported() bool {
+	if x != nil {
+		return x.Supported
+	}
+	return false
+}
+
+func (x *StartTlsCaptureResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// TraceRuntimeAgentRequest traces the Go runtime of a process: stop-the-world
+// pauses, GC cycles and goroutine scheduling latency.
+type TraceRuntimeAgentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AgentId         string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ServiceName     string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Pid             int32                  `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`                                                // Target process ID
+	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Tracing duration (default: 30s, max: 300s)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TraceRuntimeAgentRequest) Reset() {
+	*x = TraceRuntimeAgentRequest{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceRuntimeAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceRuntimeAgentRequest) ProtoMessage() {}
+
+func (x *TraceRuntimeAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceRuntimeAgentRequest.ProtoReflect.Descriptor instead.
+func (*TraceRuntimeAgentRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TraceRuntimeAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TraceRuntimeAgentRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *TraceRuntimeAgentRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *TraceRuntimeAgentRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+// RuntimePause is a stop-the-world pause of the Go runtime.
+type RuntimePause struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	DurationNs    uint64                 `protobuf:"varint,2,opt,name=duration_ns,json=durationNs,proto3" json:"duration_ns,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // e.g. "GC mark termination"; "unknown" before Go 1.21
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuntimePause) Reset() {
+	*x = RuntimePause{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuntimePause) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimePause) ProtoMessage() {}
+
+func (x *RuntimePause) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimePause.ProtoReflect.Descriptor instead.
+func (*RuntimePause) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RuntimePause) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *RuntimePause) GetDurationNs() uint64 {
+	if x != nil {
+		return x.DurationNs
+	}
+	return 0
+}
+
+func (x *RuntimePause) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// GcCycle is a garbage collection cycle, from the stop of sweep termination
+// to the restart after mark termination.
+type GcCycle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	DurationNs    uint64                 `protobuf:"varint,2,opt,name=duration_ns,json=durationNs,proto3" json:"duration_ns,omitempty"`
+	PauseNs       uint64                 `protobuf:"varint,3,opt,name=pause_ns,json=pauseNs,proto3" json:"pause_ns,omitempty"` // Stop-the-world time of the cycle
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GcCycle) Reset() {
+	*x = GcCycle{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GcCycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GcCycle) ProtoMessage() {}
+
+func (x *GcCycle) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GcCycle.ProtoReflect.Descriptor instead.
+func (*GcCycle) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GcCycle) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *GcCycle) GetDurationNs() uint64 {
+	if x != nil {
+		return x.DurationNs
+	}
+	return 0
+}
+
+func (x *GcCycle) GetPauseNs() uint64 {
+	if x != nil {
+		return x.PauseNs
+	}
+	return 0
+}
+
+// LatencyBucket counts latencies below upper_bound_ns and at least half of it.
+type LatencyBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpperBoundNs  uint64                 `protobuf:"varint,1,opt,name=upper_bound_ns,json=upperBoundNs,proto3" json:"upper_bound_ns,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LatencyBucket) Reset() {
+	*x = LatencyBucket{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LatencyBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyBucket) ProtoMessage() {}
+
+func (x *LatencyBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyBucket.ProtoReflect.Descriptor instead.
+func (*LatencyBucket) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *LatencyBucket) GetUpperBoundNs() uint64 {
+	if x != nil {
+		return x.UpperBoundNs
+	}
+	return 0
+}
+
+func (x *LatencyBucket) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// TraceRuntimeAgentResponse returns what the Go runtime did during the trace.
+type TraceRuntimeAgentResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Pauses   []*RuntimePause        `protobuf:"bytes,1,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	GcCycles []*GcCycle             `protobuf:"bytes,2,rep,name=gc_cycles,json=gcCycles,proto3" json:"gc_cycles,omitempty"`
+	// Time goroutines waited in a run queue before running, in log2 buckets.
+	// Empty if the scheduler functions could not be probed.
+	SchedLatency  []*LatencyBucket       `protobuf:"bytes,3,rep,name=sched_latency,json=schedLatency,proto3" json:"sched_latency,omitempty"`
+	GoVersion     string                 `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Success       bool                   `protobuf:"varint,8,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceRuntimeAgentResponse) Reset() {
+	*x = TraceRuntimeAgentResponse{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceRuntimeAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceRuntimeAgentResponse) ProtoMessage() {}
+
+func (x *TraceRuntimeAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceRuntimeAgentResponse.ProtoReflect.Descriptor instead.
+func (*TraceRuntimeAgentResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *TraceRuntimeAgentResponse) GetPauses() []*RuntimePause {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
+
+func (x *TraceRuntimeAgentResponse) GetGcCycles() []*GcCycle {
+	if x != nil {
+		return x.GcCycles
+	}
+	return nil
+}
+
+func (x *TraceRuntimeAgentResponse) GetSchedLatency() []*LatencyBucket {
+	if x != nil {
+		return x.SchedLatency
+	}
+	return nil
+}
+
+func (x *TraceRuntimeAgentResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *TraceRuntimeAgentResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TraceRuntimeAgentResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TraceRuntimeAgentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TraceRuntimeAgentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// TraceSyscallsAgentRequest traces the syscalls of the service running a
+// process: its container or systemd service when it has its own cgroup v2,
+// the process and its descendants otherwise.
+type TraceSyscallsAgentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AgentId         string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ServiceName     string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Pid             int32                  `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`                                                // Target process ID
+	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Tracing duration (default: 30s, max: 300s)
+	Syscalls        []string               `protobuf:"bytes,5,rep,name=syscalls,proto3" json:"syscalls,omitempty"`                                       // Syscall names, e.g. "openat"; all if empty
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TraceSyscallsAgentRequest) Reset() {
+	*x = TraceSyscallsAgentRequest{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceSyscallsAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceSyscallsAgentRequest) ProtoMessage() {}
+
+func (x *TraceSyscallsAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceSyscallsAgentRequest.ProtoReflect.Descriptor instead.
+func (*TraceSyscallsAgentRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *TraceSyscallsAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TraceSyscallsAgentRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *TraceSyscallsAgentRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *TraceSyscallsAgentRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *TraceSyscallsAgentRequest) GetSyscalls() []string {
+	if x != nil {
+		return x.Syscalls
+	}
+	return nil
+}
+
+// SyscallError counts the calls of a syscall failing with an error code.
+type SyscallError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // e.g. "ENOENT"
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyscallError) Reset() {
+	*x = SyscallError{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyscallError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyscallError) ProtoMessage() {}
+
+func (x *SyscallError) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyscallError.ProtoReflect.Descriptor instead.
+func (*SyscallError) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SyscallError) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SyscallError) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// SyscallStats aggregates the calls of one syscall.
+type SyscallStats struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Name    string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Number  uint32                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Count   uint64                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	TotalNs uint64                 `protobuf:"varint,4,opt,name=total_ns,json=totalNs,proto3" json:"total_ns,omitempty"` // Time spent in the syscall
+	// Latency of the calls, in log2 buckets.
+	Latency []*LatencyBucket `protobuf:"bytes,5,rep,name=latency,proto3" json:"latency,omitempty"`
+	// Failed calls by error code, most frequent first.
+	Errors        []*SyscallError `protobuf:"bytes,6,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SyscallStats) Reset() {
+	*x = SyscallStats{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SyscallStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyscallStats) ProtoMessage() {}
+
+func (x *SyscallStats) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyscallStats.ProtoReflect.Descriptor instead.
+func (*SyscallStats) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SyscallStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SyscallStats) GetNumber() uint32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *SyscallStats) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SyscallStats) GetTotalNs() uint64 {
+	if x != nil {
+		return x.TotalNs
+	}
+	return 0
+}
+
+func (x *SyscallStats) GetLatency() []*LatencyBucket {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *SyscallStats) GetErrors() []*SyscallError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// TraceSyscallsAgentResponse returns the syscalls made during the trace.
+type TraceSyscallsAgentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sorted by total time, longest first.
+	Syscalls      []*SyscallStats        `protobuf:"bytes,1,rep,name=syscalls,proto3" json:"syscalls,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"` // Traced processes, e.g. "cgroup /system.slice/api.service"
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	Success       bool                   `protobuf:"varint,6,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceSyscallsAgentResponse) Reset() {
+	*x = TraceSyscallsAgentResponse{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceSyscallsAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceSyscallsAgentResponse) ProtoMessage() {}
+
+func (x *TraceSyscallsAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceSyscallsAgentResponse.ProtoReflect.Descriptor instead.
+func (*TraceSyscallsAgentResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *TraceSyscallsAgentResponse) GetSyscalls() []*SyscallStats {
+	if x != nil {
+		return x.Syscalls
+	}
+	return nil
+}
+
+func (x *TraceSyscallsAgentResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *TraceSyscallsAgentResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TraceSyscallsAgentResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TraceSyscallsAgentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TraceSyscallsAgentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// ProfileIOAgentRequest profiles the file I/O of the service running a
+// process, targeted like TraceSyscallsAgentRequest.
+type ProfileIOAgentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AgentId         string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ServiceName     string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Pid             int32                  `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`                                                // Target process ID
+	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Profiling duration (default: 30s, max: 300s)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProfileIOAgentRequest) Reset() {
+	*x = ProfileIOAgentRequest{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileIOAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileIOAgentRequest) ProtoMessage() {}
+
+func (x *ProfileIOAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileIOAgentRequest.ProtoReflect.Descriptor instead.
+func (*ProfileIOAgentRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ProfileIOAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ProfileIOAgentRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProfileIOAgentRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProfileIOAgentRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+// IOStats aggregates the reads and writes of a file or device.
+type IOStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reads         uint64                 `protobuf:"varint,1,opt,name=reads,proto3" json:"reads,omitempty"`
+	Writes        uint64                 `protobuf:"varint,2,opt,name=writes,proto3" json:"writes,omitempty"`
+	ReadBytes     uint64                 `protobuf:"varint,3,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteBytes    uint64                 `protobuf:"varint,4,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	ReadNs        uint64                 `protobuf:"varint,5,opt,name=read_ns,json=readNs,proto3" json:"read_ns,omitempty"`                     // Time spent reading
+	WriteNs       uint64                 `protobuf:"varint,6,opt,name=write_ns,json=writeNs,proto3" json:"write_ns,omitempty"`                  // Time spent writing
+	MaxLatencyNs  uint64                 `protobuf:"varint,7,opt,name=max_latency_ns,json=maxLatencyNs,proto3" json:"max_latency_ns,omitempty"` // Slowest read or write
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IOStats) Reset() {
+	*x = IOStats{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IOStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IOStats) ProtoMessage() {}
+
+func (x *IOStats) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IOStats.ProtoReflect.Descriptor instead.
+func (*IOStats) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *IOStats) GetReads() uint64 {
+	if x != nil {
+		return x.Reads
+	}
+	return 0
+}
+
+func (x *IOStats) GetWrites() uint64 {
+	if x != nil {
+		return x.Writes
+	}
+	return 0
+}
+
+func (x *IOStats) GetReadBytes() uint64 {
+	if x != nil {
+		return x.ReadBytes
+	}
+	return 0
+}
+
+func (x *IOStats) GetWriteBytes() uint64 {
+	if x != nil {
+		return x.WriteBytes
+	}
+	return 0
+}
+
+func (x *IOStats) GetReadNs() uint64 {
+	if x != nil {
+		return x.ReadNs
+	}
+	return 0
+}
+
+func (x *IOStats) GetWriteNs() uint64 {
+	if x != nil {
+		return x.WriteNs
+	}
+	return 0
+}
+
+func (x *IOStats) GetMaxLatencyNs() uint64 {
+	if x != nil {
+		return x.MaxLatencyNs
+	}
+	return 0
+}
+
+// FileIO is the I/O of a service on one file.
+type FileIO struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`     // Empty if the file was not open when listed
+	Device        string                 `protobuf:"bytes,2,opt,name=device,proto3" json:"device,omitempty"` // "major:minor" of the filesystem
+	Inode         uint64                 `protobuf:"varint,3,opt,name=inode,proto3" json:"inode,omitempty"`
+	Stats         *IOStats               `protobuf:"bytes,4,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileIO) Reset() {
+	*x = FileIO{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileIO) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileIO) ProtoMessage() {}
+
+func (x *FileIO) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileIO.ProtoReflect.Descriptor instead.
+func (*FileIO) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *FileIO) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileIO) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *FileIO) GetInode() uint64 {
+	if x != nil {
+		return x.Inode
+	}
+	return 0
+}
+
+func (x *FileIO) GetStats() *IOStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// DeviceIO is the I/O of a service on one device.
+type DeviceIO struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Device        string                 `protobuf:"bytes,1,opt,name=device,proto3" json:"device,omitempty"` // "major:minor"
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"` // Mount source, e.g. "/dev/nvme0n1p1"
+	FsType        string                 `protobuf:"bytes,3,opt,name=fs_type,json=fsType,proto3" json:"fs_type,omitempty"`
+	MountPoint    string                 `protobuf:"bytes,4,opt,name=mount_point,json=mountPoint,proto3" json:"mount_point,omitempty"`
+	Stats         *IOStats               `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeviceIO) Reset() {
+	*x = DeviceIO{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeviceIO) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeviceIO) ProtoMessage() {}
+
+func (x *DeviceIO) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeviceIO.ProtoReflect.Descriptor instead.
+func (*DeviceIO) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeviceIO) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *DeviceIO) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *DeviceIO) GetFsType() string {
+	if x != nil {
+		return x.FsType
+	}
+	return ""
+}
+
+func (x *DeviceIO) GetMountPoint() string {
+	if x != nil {
+		return x.MountPoint
+	}
+	return ""
+}
+
+func (x *DeviceIO) GetStats() *IOStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// ProfileIOAgentResponse returns the file I/O of the service during the
+// profile.
+type ProfileIOAgentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sorted by I/O time, longest first.
+	Files   []*FileIO   `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Devices []*DeviceIO `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	// Latency of all reads and writes, in log2 buckets.
+	ReadLatency   []*LatencyBucket       `protobuf:"bytes,3,rep,name=read_latency,json=readLatency,proto3" json:"read_latency,omitempty"`
+	WriteLatency  []*LatencyBucket       `protobuf:"bytes,4,rep,name=write_latency,json=writeLatency,proto3" json:"write_latency,omitempty"`
+	Target        string                 `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"` // Traced processes, e.g. "cgroup /system.slice/api.service"
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Success       bool                   `protobuf:"varint,9,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileIOAgentResponse) Reset() {
+	*x = ProfileIOAgentResponse{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileIOAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileIOAgentResponse) ProtoMessage() {}
+
+func (x *ProfileIOAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileIOAgentResponse.ProtoReflect.Descriptor instead.
+func (*ProfileIOAgentResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ProfileIOAgentResponse) GetFiles() []*FileIO {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ProfileIOAgentResponse) GetDevices() []*DeviceIO {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *ProfileIOAgentResponse) GetReadLatency() []*LatencyBucket {
+	if x != nil {
+		return x.ReadLatency
+	}
+	return nil
+}
+
+func (x *ProfileIOAgentResponse) GetWriteLatency() []*LatencyBucket {
+	if x != nil {
+		return x.WriteLatency
+	}
+	return nil
+}
+
+func (x *ProfileIOAgentResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ProfileIOAgentResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ProfileIOAgentResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ProfileIOAgentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProfileIOAgentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// ProfileLocksAgentRequest profiles the lock contention of the service
+// running a process, targeted like TraceSyscallsAgentRequest.
+type ProfileLocksAgentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AgentId         string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ServiceName     string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Pid             int32                  `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`                                                // Target process ID
+	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Profiling duration (default: 30s, max: 300s)
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProfileLocksAgentRequest) Reset() {
+	*x = ProfileLocksAgentRequest{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileLocksAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileLocksAgentRequest) ProtoMessage() {}
+
+func (x *ProfileLocksAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileLocksAgentRequest.ProtoReflect.Descriptor instead.
+func (*ProfileLocksAgentRequest) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ProfileLocksAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ProfileLocksAgentRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProfileLocksAgentRequest) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *ProfileLocksAgentRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+// LockWaitStack is the time a service spent blocked on futexes (mutexes,
+// condition variables, semaphores) from one user stack.
+type LockWaitStack struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FrameNames    []string               `protobuf:"bytes,1,rep,name=frame_names,json=frameNames,proto3" json:"frame_names,omitempty"` // Stack frames from innermost to outermost
+	Waits         uint64                 `protobuf:"varint,2,opt,name=waits,proto3" json:"waits,omitempty"`                            // Number of futex waits
+	WaitNs        uint64                 `protobuf:"varint,3,opt,name=wait_ns,json=waitNs,proto3" json:"wait_ns,omitempty"`            // Total time blocked
+	MaxWaitNs     uint64                 `protobuf:"varint,4,opt,name=max_wait_ns,json=maxWaitNs,proto3" json:"max_wait_ns,omitempty"` // Longest wait
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockWaitStack) Reset() {
+	*x = LockWaitStack{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockWaitStack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockWaitStack) ProtoMessage() {}
+
+func (x *LockWaitStack) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockWaitStack.ProtoReflect.Descriptor instead.
+func (*LockWaitStack) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *LockWaitStack) GetFrameNames() []string {
+	if x != nil {
+		return x.FrameNames
+	}
+	return nil
+}
+
+func (x *LockWaitStack) GetWaits() uint64 {
+	if x != nil {
+		return x.Waits
+	}
+	return 0
+}
+
+func (x *LockWaitStack) GetWaitNs() uint64 {
+	if x != nil {
+		return x.WaitNs
+	}
+	return 0
+}
+
+func (x *LockWaitStack) GetMaxWaitNs() uint64 {
+	if x != nil {
+		return x.MaxWaitNs
+	}
+	return 0
+}
+
+// ProfileLocksAgentResponse returns the futex waits of the service during
+// the profile, by stack.
+type ProfileLocksAgentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sorted by wait time, longest first.
+	Stacks        []*LockWaitStack       `protobuf:"bytes,1,rep,name=stacks,proto3" json:"stacks,omitempty"`
+	TotalWaits    uint64                 `protobuf:"varint,2,opt,name=total_waits,json=totalWaits,proto3" json:"total_waits,omitempty"`
+	TotalWaitNs   uint64                 `protobuf:"varint,3,opt,name=total_wait_ns,json=totalWaitNs,proto3" json:"total_wait_ns,omitempty"`
+	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"` // Traced processes, e.g. "cgroup /system.slice/api.service"
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Success       bool                   `protobuf:"varint,8,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileLocksAgentResponse) Reset() {
+	*x = ProfileLocksAgentResponse{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileLocksAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileLocksAgentResponse) ProtoMessage() {}
+
+func (x *ProfileLocksAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileLocksAgentResponse.ProtoReflect.Descriptor instead.
+func (*ProfileLocksAgentResponse) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ProfileLocksAgentResponse) GetStacks() []*LockWaitStack {
+	if x != nil {
+		return x.Stacks
+	}
+	return nil
+}
+
+func (x *ProfileLocksAgentResponse) GetTotalWaits() uint64 {
+	if x != nil {
+		return x.TotalWaits
+	}
+	return 0
+}
+
+func (x *ProfileLocksAgentResponse) GetTotalWaitNs() uint64 {
+	if x != nil {
+		return x.TotalWaitNs
+	}
+	return 0
+}
+
+func (x *ProfileLocksAgentResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ProfileLocksAgentResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ProfileLocksAgentResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ProfileLocksAgentResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProfileLocksAgentResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// FunctionDescription is the signature of a function and how it can be probed,
+// as discovered through the SDK or by scanning the binary.
+type FunctionDescription struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`           // Fully qualified name (e.g., "main.ProcessPayment").
+	Signature       string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"` // Go signature, e.g. "func main.ProcessPayment(amount int) error".
+	BinaryPath      string                 `protobuf:"bytes,3,opt,name=binary_path,json=binaryPath,proto3" json:"binary_path,omitempty"`
+	Offset          uint64                 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`                                         // Entry offset in the binary.
+	SizeBytes       uint64                 `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`                  // 0 when unknown.
+	DiscoveryMethod string                 `protobuf:"bytes,6,opt,name=discovery_method,json=discoveryMethod,proto3" json:"discovery_method,omitempty"` // "sdk" or "binary".
+	Arguments       []*FunctionParameter   `protobuf:"bytes,7,rep,name=arguments,proto3" json:"arguments,omitempty"`
+	ReturnValues    []*FunctionParameter   `protobuf:"bytes,8,rep,name=return_values,json=returnValues,proto3" json:"return_values,omitempty"`
+	Probeability    *Probeability          `protobuf:"bytes,9,opt,name=probeability,proto3" json:"probeability,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FunctionDescription) Reset() {
+	*x = FunctionDescription{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionDescription) ProtoMessage() {}
+
+func (x *FunctionDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionDescription.ProtoReflect.Descriptor instead.
+func (*FunctionDescription) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *FunctionDescription) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetBinaryPath() string {
+	if x != nil {
+		return x.BinaryPath
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *FunctionDescription) GetDiscoveryMethod() string {
+	if x != nil {
+		return x.DiscoveryMethod
+	}
+	return ""
+}
+
+func (x *FunctionDescription) GetArguments() []*FunctionParameter {
+	if x != nil {
+		return x.Arguments
+	}
+	return nil
+}
+
+func (x *FunctionDescription) GetReturnValues() []*FunctionParameter {
+	if x != nil {
+		return x.ReturnValues
+	}
+	return nil
+}
+
+func (x *FunctionDescription) GetProbeability() *Probeability {
+	if x != nil {
+		return x.Probeability
+	}
+	return nil
+}
+
+// FunctionParameter is an argument or return value of a function.
+type FunctionParameter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"` // Register(s) or stack slot at entry, e.g. "rbx, rcx" or "fbreg+8". Empty if unknown.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FunctionParameter) Reset() {
+	*x = FunctionParameter{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FunctionParameter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FunctionParameter) ProtoMessage() {}
+
+func (x *FunctionParameter) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FunctionParameter.ProtoReflect.Descriptor instead.
+func (*FunctionParameter) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *FunctionParameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FunctionParameter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *FunctionParameter) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+// Probeability assesses what a probe on the function can capture.
+type Probeability struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Probeable          bool                   `protobuf:"varint,1,opt,name=probeable,proto3" json:"probeable,omitempty"`                                          // An entry probe can be attached.
+	DurationAvailable  bool                   `protobuf:"varint,2,opt,name=duration_available,json=durationAvailable,proto3" json:"duration_available,omitempty"` // Return instructions were found, so durations are measured.
+	ReturnInstructions int32                  `protobuf:"varint,3,opt,name=return_instructions,json=returnInstructions,proto3" json:"return_instructions,omitempty"`
+	ArgumentsLocated   bool                   `protobuf:"varint,4,opt,name=arguments_located,json=argumentsLocated,proto3" json:"arguments_located,omitempty"` // All argument locations are known.
+	Notes              []string               `protobuf:"bytes,5,rep,name=notes,proto3" json:"notes,omitempty"`                                                // Reasons for limitations, in order of importance.
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Probeability) Reset() {
+	*x = Probeability{}
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Probeability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Probeability) ProtoMessage() {}
+
+func (x *Probeability) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_agent_v1_debug_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Probeability.ProtoReflect.Descriptor instead.
+func (*Probeability) Descriptor() ([]byte, []int) {
+	return file_coral_agent_v1_debug_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *Probeability) GetProbeable() bool {
+	if x != nil {
+		return x.Probeable
+	}
+	return false
+}
+
+func (x *Probeability) GetDurationAvailable() bool {
+	if x != nil {
+		return x.DurationAvailable
+	}
+	return false
+}
+
+func (x *Probeability) GetReturnInstructions() int32 {
+	if x != nil {
+		return x.ReturnInstructions
+	}
+	return 0
+}
+
+func (x *Probeability) GetArgumentsLocated() bool {
+	if x != nil {
+		return x.ArgumentsLocated
+	}
+	return false
+}
+
+func (x *Probeability) GetNotes() []string {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+var File_coral_agent_v1_debug_proto protoreflect.FileDescriptor
+
+const file_coral_agent_v1_debug_proto_rawDesc = "" +
+	"\n" +
+	"\x1acoral/agent/v1/debug.proto\x12\x0ecoral.agent.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1acoral/agent/v1/agent.proto\x1a coral/agent/v1/correlation.proto\"\xdd\x02\n" +
+	"\x1bStartUprobeCollectorRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12#\n" +
+	"\rfunction_name\x18\x03 \x01(\tR\ffunctionName\x125\n" +
+	"\bduration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\bduration\x124\n" +
+	"\x06config\x18\x05 \x01(\v2\x1c.coral.agent.v1.UprobeConfigR\x06config\x12\x19\n" +
+	"\bsdk_addr\x18\x06 \x01(\tR\asdkAddr\x124\n" +
+	"\x06filter\x18\a \x01(\v2\x1c.coral.agent.v1.UprobeFilterR\x06filter\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\b \x01(\tR\tsessionId\"\x98\x01\n" +
+	"\fUprobeConfig\x12!\n" +
+	"\fcapture_args\x18\x01 \x01(\bR\vcaptureArgs\x12%\n" +
+	"\x0ecapture_return\x18\x02 \x01(\bR\rcaptureReturn\x12\x1f\n" +
+	"\vsample_rate\x18\x03 \x01(\rR\n" +
+	"sampleRate\x12\x1d\n" +
+	"\n" +
+	"max_events\x18\x04 \x01(\rR\tmaxEvents\"\x7f\n" +
+	"\fUprobeFilter\x12&\n" +
+	"\x0fmin_duration_ns\x18\x01 \x01(\x04R\rminDurationNs\x12&\n" +
+	"\x0fmax_duration_ns\x18\x02 \x01(\x04R\rmaxDurationNs\x12\x1f\n" +
+	"\vsample_rate\x18\x03 \x01(\rR\n" +
+	"sampleRate\"s\n" +
+	"\x18UpdateProbeFilterRequest\x12!\n" +
+	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\x124\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1c.coral.agent.v1.UprobeFilterR\x06filter\"\x1b\n" +
+	"\x19UpdateProbeFilterResponse\"\xb0\x01\n" +
+	"\x1cStartUprobeCollectorResponse\x12!\n" +
+	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1c\n" +
+	"\tsupported\x18\x03 \x01(\bR\tsupported\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"?\n" +
+	"\x1aStopUprobeCollectorRequest\x12!\n" +
+	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\"M\n" +
+	"\x1bStopUprobeCollectorResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xce\x01\n" +
+	"\x18QueryUprobeEventsRequest\x12!\n" +
+	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1d\n" +
+	"\n" +
+	"max_events\x18\x04 \x01(\x05R\tmaxEvents\"P\n" +
+	"\x10FunctionArgument\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"\x7f\n" +
+	"\x13FunctionReturnValue\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x19\n" +
+	"\bis_error\x18\x03 \x01(\bR\aisError\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"\xc7\x05\n" +
+	"\vUprobeEvent\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12!\n" +
+	"\fcollector_id\x18\x02 \x01(\tR\vcollectorId\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x04 \x01(\tR\vserviceName\x12#\n" +
+	"\rfunction_name\x18\x05 \x01(\tR\ffunctionName\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x06 \x01(\tR\teventType\x12\x1f\n" +
+	"\vduration_ns\x18\a \x01(\x04R\n" +
+	"durationNs\x12\x10\n" +
+	"\x03pid\x18\b \x01(\x05R\x03pid\x12\x10\n" +
+	"\x03tid\x18\t \x01(\x05R\x03tid\x124\n" +
+	"\x04args\x18\n" +
+	" \x03(\v2 .coral.agent.v1.FunctionArgumentR\x04args\x12F\n" +
+	"\freturn_value\x18\v \x01(\v2#.coral.agent.v1.FunctionReturnValueR\vreturnValue\x12?\n" +
+	"\x06labels\x18\f \x03(\v2'.coral.agent.v1.UprobeEvent.LabelsEntryR\x06labels\x12\x1a\n" +
+	"\bredacted\x18\r \x01(\bR\bredacted\x12!\n" +
+	"\fgoroutine_id\x18\x0e \x01(\x04R\vgoroutineId\x120\n" +
+	"\x04http\x18\x0f \x01(\v2\x1c.coral.agent.v1.HttpExchangeR\x04http\x12)\n" +
+	"\x03tls\x18\x10 \x01(\v2\x17.coral.agent.v1.TlsDataR\x03tls\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"6\n" +
+	"\n" +
+	"HttpHeader\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xea\x03\n" +
+	"\fHttpExchange\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x14\n" +
+	"\x05route\x18\x04 \x01(\tR\x05route\x12\x1f\n" +
+	"\vstatus_code\x18\x05 \x01(\x05R\n" +
+	"statusCode\x12C\n" +
+	"\x0frequest_headers\x18\x06 \x03(\v2\x1a.coral.agent.v1.HttpHeaderR\x0erequestHeaders\x12E\n" +
+	"\x10response_headers\x18\a \x03(\v2\x1a.coral.agent.v1.HttpHeaderR\x0fresponseHeaders\x12!\n" +
+	"\frequest_body\x18\b \x01(\tR\vrequestBody\x12#\n" +
+	"\rresponse_body\x18\t \x01(\tR\fresponseBody\x124\n" +
+	"\x16request_body_truncated\x18\n" +
+	" \x01(\bR\x14requestBodyTruncated\x126\n" +
+	"\x17response_body_truncated\x18\v \x01(\bR\x15responseBodyTruncated\x12\x1f\n" +
+	"\vclient_addr\x18\f \x01(\tR\n" +
+	"clientAddr\"\xb0\x01\n" +
+	"\aTlsData\x12\x18\n" +
+	"\alibrary\x18\x01 \x01(\tR\alibrary\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12#\n" +
+	"\rconnection_id\x18\x03 \x01(\x04R\fconnectionId\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x16\n" +
+	"\x06length\x18\x05 \x01(\rR\x06length\x12\x1c\n" +
+	"\ttruncated\x18\x06 \x01(\bR\ttruncated\"k\n" +
+	"\x19QueryUprobeEventsResponse\x123\n" +
+	"\x06events\x18\x01 \x03(\v2\x1b.coral.agent.v1.UprobeEventR\x06events\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\"\xdb\x01\n" +
+	"\x16ProfileCPUAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\x12!\n" +
+	"\ffrequency_hz\x18\x05 \x01(\x05R\vfrequencyHz\x12#\n" +
+	"\rstore_locally\x18\x06 \x01(\bR\fstoreLocally\"D\n" +
+	"\vStackSample\x12\x1f\n" +
+	"\vframe_names\x18\x01 \x03(\tR\n" +
+	"frameNames\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"\xe7\x01\n" +
+	"\x17ProfileCPUAgentResponse\x125\n" +
+	"\asamples\x18\x01 \x03(\v2\x1b.coral.agent.v1.StackSampleR\asamples\x12#\n" +
+	"\rtotal_samples\x18\x02 \x01(\x04R\ftotalSamples\x12!\n" +
+	"\flost_samples\x18\x03 \x01(\rR\vlostSamples\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x1d\n" +
+	"\n" +
+	"profile_id\x18\x06 \x01(\tR\tprofileId\"\xa0\x01\n" +
+	"\x1dQueryCPUProfileSamplesRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12 \n" +
+	"\fstart_seq_id\x18\x02 \x01(\x04R\n" +
+	"startSeqId\x12\x1f\n" +
+	"\vmax_records\x18\x03 \x01(\x05R\n" +
+	"maxRecords\x12\x19\n" +
+	"\bpod_name\x18\x04 \x01(\tR\apodName\"\xe7\x01\n" +
+	"\x10CPUProfileSample\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x12!\n" +
+	"\fstack_frames\x18\x03 \x03(\tR\vstackFrames\x12!\n" +
+	"\fsample_count\x18\x04 \x01(\rR\vsampleCount\x12!\n" +
+	"\fservice_name\x18\x05 \x01(\tR\vserviceName\x12\x15\n" +
+	"\x06seq_id\x18\x06 \x01(\x04R\x05seqId\"\xd4\x01\n" +
+	"\x1eQueryCPUProfileSamplesResponse\x12:\n" +
+	"\asamples\x18\x01 \x03(\v2 .coral.agent.v1.CPUProfileSampleR\asamples\x12#\n" +
+	"\rtotal_samples\x18\x02 \x01(\x04R\ftotalSamples\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1c\n" +
+	"\n" +
+	"max_seq_id\x18\x04 \x01(\x04R\bmaxSeqId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x05 \x01(\tR\tsessionId\"\xdd\x01\n" +
+	"\x19ProfileMemoryAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\x12*\n" +
+	"\x11sample_rate_bytes\x18\x05 \x01(\x05R\x0fsampleRateBytes\x12\x19\n" +
+	"\bsdk_addr\x18\x06 \x01(\tR\asdkAddr\"\xc8\x01\n" +
+	"\vMemoryStats\x12\x1f\n" +
+	"\valloc_bytes\x18\x01 \x01(\x03R\n" +
+	"allocBytes\x12*\n" +
+	"\x11total_alloc_bytes\x18\x02 \x01(\x03R\x0ftotalAllocBytes\x12\x1b\n" +
+	"\tsys_bytes\x18\x03 \x01(\x03R\bsysBytes\x12\x15\n" +
+	"\x06num_gc\x18\x04 \x01(\x03R\x05numGc\x128\n" +
+	"\x19heap_growth_bytes_per_sec\x18\x05 \x01(\x01R\x15heapGrowthBytesPerSec\"z\n" +
+	"\x11MemoryStackSample\x12\x1f\n" +
+	"\vframe_names\x18\x01 \x03(\tR\n" +
+	"frameNames\x12\x1f\n" +
+	"\valloc_bytes\x18\x02 \x01(\x03R\n" +
+	"allocBytes\x12#\n" +
+	"\ralloc_objects\x18\x03 \x01(\x03R\fallocObjects\"p\n" +
+	"\x10TopAllocFunction\x12\x1a\n" +
+	"\bfunction\x18\x01 \x01(\tR\bfunction\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12\x18\n" +
+	"\aobjects\x18\x03 \x01(\x03R\aobjects\x12\x10\n" +
+	"\x03pct\x18\x04 \x01(\x01R\x03pct\"m\n" +
+	"\fTopAllocType\x12\x1b\n" +
+	"\ttype_name\x18\x01 \x01(\tR\btypeName\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x03R\x05bytes\x12\x18\n" +
+	"\aobjects\x18\x03 \x01(\x03R\aobjects\x12\x10\n" +
+	"\x03pct\x18\x04 \x01(\x01R\x03pct\"\xbe\x02\n" +
+	"\x1aProfileMemoryAgentResponse\x12;\n" +
+	"\asamples\x18\x01 \x03(\v2!.coral.agent.v1.MemoryStackSampleR\asamples\x121\n" +
+	"\x05stats\x18\x02 \x01(\v2\x1b.coral.agent.v1.MemoryStatsR\x05stats\x12E\n" +
+	"\rtop_functions\x18\x03 \x03(\v2 .coral.agent.v1.TopAllocFunctionR\ftopFunctions\x129\n" +
+	"\ttop_types\x18\x04 \x03(\v2\x1c.coral.agent.v1.TopAllocTypeR\btopTypes\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x18\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\"\xa3\x01\n" +
+	" QueryMemoryProfileSamplesRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x19\n" +
+	"\bpod_name\x18\x02 \x01(\tR\apodName\x12 \n" +
+	"\fstart_seq_id\x18\x03 \x01(\x04R\n" +
+	"startSeqId\x12\x1f\n" +
+	"\vmax_records\x18\x04 \x01(\x05R\n" +
+	"maxRecords\"\x8d\x02\n" +
+	"\x13MemoryProfileSample\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bbuild_id\x18\x02 \x01(\tR\abuildId\x12!\n" +
+	"\fstack_frames\x18\x03 \x03(\tR\vstackFrames\x12\x1f\n" +
+	"\valloc_bytes\x18\x04 \x01(\x03R\n" +
+	"allocBytes\x12#\n" +
+	"\ralloc_objects\x18\x05 \x01(\x03R\fallocObjects\x12!\n" +
+	"\fservice_name\x18\x06 \x01(\tR\vserviceName\x12\x15\n" +
+	"\x06seq_id\x18\a \x01(\x04R\x05seqId\"\xe1\x01\n" +
+	"!QueryMemoryProfileSamplesResponse\x12=\n" +
+	"\asamples\x18\x01 \x03(\v2#.coral.agent.v1.MemoryProfileSampleR\asamples\x12*\n" +
+	"\x11total_alloc_bytes\x18\x02 \x01(\x03R\x0ftotalAllocBytes\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1c\n" +
+	"\n" +
+	"max_seq_id\x18\x04 \x01(\x04R\bmaxSeqId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x05 \x01(\tR\tsessionId\"\xc9\x02\n" +
+	"\fCoreDumpInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12\x1e\n" +
+	"\n" +
+	"executable\x18\x04 \x01(\tR\n" +
+	"executable\x12\x16\n" +
+	"\x06signal\x18\x05 \x01(\x05R\x06signal\x129\n" +
+	"\n" +
+	"crashed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcrashedAt\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\a \x01(\x04R\tsizeBytes\x12)\n" +
+	"\x10compressed_bytes\x18\b \x01(\x04R\x0fcompressedBytes\x12\x1c\n" +
+	"\ttruncated\x18\t \x01(\bR\ttruncated\x12\x19\n" +
+	"\bagent_id\x18\n" +
+	" \x01(\tR\aagentId\"9\n" +
+	"\x14ListCoreDumpsRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"y\n" +
+	"\x15ListCoreDumpsResponse\x122\n" +
+	"\x05dumps\x18\x01 \x03(\v2\x1c.coral.agent.v1.CoreDumpInfoR\x05dumps\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\tR\x04mode\")\n" +
+	"\x17DownloadCoreDumpRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"U\n" +
+	"\rCoreDumpChunk\x120\n" +
+	"\x04info\x18\x01 \x01(\v2\x1c.coral.agent.v1.CoreDumpInfoR\x04info\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"a\n" +
+	"\x17DescribeFunctionRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12#\n" +
+	"\rfunction_name\x18\x02 \x01(\tR\ffunctionName\"[\n" +
+	"\x18DescribeFunctionResponse\x12?\n" +
+	"\bfunction\x18\x01 \x01(\v2#.coral.agent.v1.FunctionDescriptionR\bfunction\"\xb1\x02\n" +
+	"\x17StartHttpCaptureRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05route\x18\x03 \x01(\tR\x05route\x12\x1f\n" +
+	"\vsample_rate\x18\x04 \x01(\x01R\n" +
+	"sampleRate\x12%\n" +
+	"\x0ecapture_bodies\x18\x05 \x01(\bR\rcaptureBodies\x12$\n" +
+	"\x0emax_body_bytes\x18\x06 \x01(\rR\fmaxBodyBytes\x125\n" +
+	"\bduration\x18\a \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\b \x01(\tR\tsessionId\"\xac\x01\n" +
+	"\x18StartHttpCaptureResponse\x12!\n" +
+	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1c\n" +
+	"\tsupported\x18\x03 \x01(\bR\tsupported\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x82\x02\n" +
+	"\x16StartTlsCaptureRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x18\n" +
+	"\alibrary\x18\x03 \x01(\tR\alibrary\x12\x14\n" +
+	"\x05match\x18\x04 \x01(\tR\x05match\x12$\n" +
+	"\x0emax_data_bytes\x18\x05 \x01(\rR\fmaxDataBytes\x125\n" +
+	"\bduration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\a \x01(\tR\tsessionId\"\xab\x01\n" +
+	"\x17StartTlsCaptureResponse\x12!\n" +
+	"\fcollector_id\x18\x01 \x01(\tR\vcollectorId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1c\n" +
+	"\tsupported\x18\x03 \x01(\bR\tsupported\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x95\x01\n" +
+	"\x18TraceRuntimeAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\"y\n" +
+	"\fRuntimePause\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x1f\n" +
+	"\vduration_ns\x18\x02 \x01(\x04R\n" +
+	"durationNs\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"w\n" +
+	"\aGcCycle\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12\x1f\n" +
+	"\vduration_ns\x18\x02 \x01(\x04R\n" +
+	"durationNs\x12\x19\n" +
+	"\bpause_ns\x18\x03 \x01(\x04R\apauseNs\"K\n" +
+	"\rLatencyBucket\x12$\n" +
+	"\x0eupper_bound_ns\x18\x01 \x01(\x04R\fupperBoundNs\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"\x8c\x03\n" +
+	"\x19TraceRuntimeAgentResponse\x124\n" +
+	"\x06pauses\x18\x01 \x03(\v2\x1c.coral.agent.v1.RuntimePauseR\x06pauses\x124\n" +
+	"\tgc_cycles\x18\x02 \x03(\v2\x17.coral.agent.v1.GcCycleR\bgcCycles\x12B\n" +
+	"\rsched_latency\x18\x03 \x03(\v2\x1d.coral.agent.v1.LatencyBucketR\fschedLatency\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x04 \x01(\tR\tgoVersion\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x18\n" +
+	"\asuccess\x18\b \x01(\bR\asuccess\"\xb2\x01\n" +
+	"\x19TraceSyscallsAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\x12\x1a\n" +
+	"\bsyscalls\x18\x05 \x03(\tR\bsyscalls\"8\n" +
+	"\fSyscallError\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"\xda\x01\n" +
+	"\fSyscallStats\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\rR\x06number\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x04R\x05count\x12\x19\n" +
+	"\btotal_ns\x18\x04 \x01(\x04R\atotalNs\x127\n" +
+	"\alatency\x18\x05 \x03(\v2\x1d.coral.agent.v1.LatencyBucketR\alatency\x124\n" +
+	"\x06errors\x18\x06 \x03(\v2\x1c.coral.agent.v1.SyscallErrorR\x06errors\"\x90\x02\n" +
+	"\x1aTraceSyscallsAgentResponse\x128\n" +
+	"\bsyscalls\x18\x01 \x03(\v2\x1c.coral.agent.v1.SyscallStatsR\bsyscalls\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x129\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x18\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\"\x92\x01\n" +
+	"\x15ProfileIOAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\"\xd1\x01\n" +
+	"\aIOStats\x12\x14\n" +
+	"\x05reads\x18\x01 \x01(\x04R\x05reads\x12\x16\n" +
+	"\x06writes\x18\x02 \x01(\x04R\x06writes\x12\x1d\n" +
+	"\n" +
+	"read_bytes\x18\x03 \x01(\x04R\treadBytes\x12\x1f\n" +
+	"\vwrite_bytes\x18\x04 \x01(\x04R\n" +
+	"writeBytes\x12\x17\n" +
+	"\aread_ns\x18\x05 \x01(\x04R\x06readNs\x12\x19\n" +
+	"\bwrite_ns\x18\x06 \x01(\x04R\awriteNs\x12$\n" +
+	"\x0emax_latency_ns\x18\a \x01(\x04R\fmaxLatencyNs\"y\n" +
+	"\x06FileIO\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06device\x18\x02 \x01(\tR\x06device\x12\x14\n" +
+	"\x05inode\x18\x03 \x01(\x04R\x05inode\x12-\n" +
+	"\x05stats\x18\x04 \x01(\v2\x17.coral.agent.v1.IOStatsR\x05stats\"\xa3\x01\n" +
+	"\bDeviceIO\x12\x16\n" +
+	"\x06device\x18\x01 \x01(\tR\x06device\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x17\n" +
+	"\afs_type\x18\x03 \x01(\tR\x06fsType\x12\x1f\n" +
+	"\vmount_point\x18\x04 \x01(\tR\n" +
+	"mountPoint\x12-\n" +
+	"\x05stats\x18\x05 \x01(\v2\x17.coral.agent.v1.IOStatsR\x05stats\"\xba\x03\n" +
+	"\x16ProfileIOAgentResponse\x12,\n" +
+	"\x05files\x18\x01 \x03(\v2\x16.coral.agent.v1.FileIOR\x05files\x122\n" +
+	"\adevices\x18\x02 \x03(\v2\x18.coral.agent.v1.DeviceIOR\adevices\x12@\n" +
+	"\fread_latency\x18\x03 \x03(\v2\x1d.coral.agent.v1.LatencyBucketR\vreadLatency\x12B\n" +
+	"\rwrite_latency\x18\x04 \x03(\v2\x1d.coral.agent.v1.LatencyBucketR\fwriteLatency\x12\x16\n" +
+	"\x06target\x18\x05 \x01(\tR\x06target\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12\x18\n" +
+	"\asuccess\x18\t \x01(\bR\asuccess\"\x95\x01\n" +
+	"\x18ProfileLocksAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x10\n" +
+	"\x03pid\x18\x03 \x01(\x05R\x03pid\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\"\x7f\n" +
+	"\rLockWaitStack\x12\x1f\n" +
+	"\vframe_names\x18\x01 \x03(\tR\n" +
+	"frameNames\x12\x14\n" +
+	"\x05waits\x18\x02 \x01(\x04R\x05waits\x12\x17\n" +
+	"\await_ns\x18\x03 \x01(\x04R\x06waitNs\x12\x1e\n" +
+	"\vmax_wait_ns\x18\x04 \x01(\x04R\tmaxWaitNs\"\xd1\x02\n" +
+	"\x19ProfileLocksAgentResponse\x125\n" +
+	"\x06stacks\x18\x01 \x03(\v2\x1d.coral.agent.v1.LockWaitStackR\x06stacks\x12\x1f\n" +
+	"\vtotal_waits\x18\x02 \x01(\x04R\n" +
+	"totalWaits\x12\"\n" +
+	"\rtotal_wait_ns\x18\x03 \x01(\x04R\vtotalWaitNs\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\x129\n" +
+	"\n" +
+	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x18\n" +
+	"\asuccess\x18\b \x01(\bR\asuccess\"\x95\x03\n" +
+	"\x13FunctionDescription\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignature\x12\x1f\n" +
+	"\vbinary_path\x18\x03 \x01(\tR\n" +
+	"binaryPath\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x04R\x06offset\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x04R\tsizeBytes\x12)\n" +
+	"\x10discovery_method\x18\x06 \x01(\tR\x0fdiscoveryMethod\x12?\n" +
+	"\targuments\x18\a \x03(\v2!.coral.agent.v1.FunctionParameterR\targuments\x12F\n" +
+	"\rreturn_values\x18\b \x03(\v2!.coral.agent.v1.FunctionParameterR\freturnValues\x12@\n" +
+	"\fprobeability\x18\t \x01(\v2\x1c.coral.agent.v1.ProbeabilityR\fprobeability\"W\n" +
+	"\x11FunctionParameter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\"\xcf\x01\n" +
+	"\fProbeability\x12\x1c\n" +
+	"\tprobeable\x18\x01 \x01(\bR\tprobeable\x12-\n" +
+	"\x12duration_available\x18\x02 \x01(\bR\x11durationAvailable\x12/\n" +
+	"\x13return_instructions\x18\x03 \x01(\x05R\x12returnInstructions\x12+\n" +
+	"\x11arguments_located\x18\x04 \x01(\bR\x10argumentsLocated\x12\x14\n" +
+	"\x05notes\x18\x05 \x03(\tR\x05notes2\xc4\x10\n" +
 	"\x11AgentDebugService\x12q\n" +
 	"\x14StartUprobeCollector\x12+.coral.agent.v1.StartUprobeCollectorRequest\x1a,.coral.agent.v1.StartUprobeCollectorResponse\x12n\n" +
 	"\x13StopUprobeCollector\x12*.coral.agent.v1.StopUprobeCollectorRequest\x1a+.coral.agent.v1.StopUprobeCollectorResponse\x12h\n" +
@@ -2224,11 +5116,20 @@ const file_coral_agent_v1_debug_proto_rawDesc = "" +
 	"\n" +
 	"ProfileCPU\x12&.coral.agent.v1.ProfileCPUAgentRequest\x1a'.coral.agent.v1.ProfileCPUAgentResponse\x12w\n" +
 	"\x16QueryCPUProfileSamples\x12-.coral.agent.v1.QueryCPUProfileSamplesRequest\x1a..coral.agent.v1.QueryCPUProfileSamplesResponse\x12f\n" +
-	"\rProfileMemory\x12).coral.agent.v1.ProfileMemoryAgentRequest\x1a*.coral.agent.v1.ProfileMemoryAgentResponse\x12\x80\x01\n" +
+	"\rProfileMemory\x12).coral.agent.v1.ProfileMemoryAgentRequest\x1a*.coral.agent.v1.ProfileMemoryAgentResponse\x12Z\n" +
+	"\tProfileIO\x12%.coral.agent.v1.ProfileIOAgentRequest\x1a&.coral.agent.v1.ProfileIOAgentResponse\x12c\n" +
+	"\fProfileLocks\x12(.coral.agent.v1.ProfileLocksAgentRequest\x1a).coral.agent.v1.ProfileLocksAgentResponse\x12\x80\x01\n" +
 	"\x19QueryMemoryProfileSamples\x120.coral.agent.v1.QueryMemoryProfileSamplesRequest\x1a1.coral.agent.v1.QueryMemoryProfileSamplesResponse\x12h\n" +
 	"\x11DeployCorrelation\x12(.coral.agent.v1.DeployCorrelationRequest\x1a).coral.agent.v1.DeployCorrelationResponse\x12h\n" +
 	"\x11RemoveCorrelation\x12(.coral.agent.v1.RemoveCorrelationRequest\x1a).coral.agent.v1.RemoveCorrelationResponse\x12e\n" +
-	"\x10ListCorrelations\x12'.coral.agent.v1.ListCorrelationsRequest\x1a(.coral.agent.v1.ListCorrelationsResponseB\xae\x01\n" +
+	"\x10ListCorrelations\x12'.coral.agent.v1.ListCorrelationsRequest\x1a(.coral.agent.v1.ListCorrelationsResponse\x12\\\n" +
+	"\rListCoreDumps\x12$.coral.agent.v1.ListCoreDumpsRequest\x1a%.coral.agent.v1.ListCoreDumpsResponse\x12\\\n" +
+	"\x10DownloadCoreDump\x12'.coral.agent.v1.DownloadCoreDumpRequest\x1a\x1d.coral.agent.v1.CoreDumpChunk0\x01\x12e\n" +
+	"\x10DescribeFunction\x12'.coral.agent.v1.DescribeFunctionRequest\x1a(.coral.agent.v1.DescribeFunctionResponse\x12e\n" +
+	"\x10StartHttpCapture\x12'.coral.agent.v1.StartHttpCaptureRequest\x1a(.coral.agent.v1.StartHttpCaptureResponse\x12b\n" +
+	"\x0fStartTlsCapture\x12&.coral.agent.v1.StartTlsCaptureRequest\x1a'.coral.agent.v1.StartTlsCaptureResponse\x12c\n" +
+	"\fTraceRuntime\x12(.coral.agent.v1.TraceRuntimeAgentRequest\x1a).coral.agent.v1.TraceRuntimeAgentResponse\x12f\n" +
+	"\rTraceSyscalls\x12).coral.agent.v1.TraceSyscallsAgentRequest\x1a*.coral.agent.v1.TraceSyscallsAgentResponseB\xae\x01\n" +
 	"\x12com.coral.agent.v1B\n" +
 	"DebugProtoP\x01Z2github.com/coral-mesh/coral/coral/agent/v1;agentv1\xa2\x02\x03CAX\xaa\x02\x0eCoral.Agent.V1\xca\x02\x0eCoral\\Agent\\V1\xe2\x02\x1aCoral\\Agent\\V1\\GPBMetadata\xea\x02\x10Coral::Agent::V1b\x06proto3"
 
@@ -2244,7 +5145,7 @@ func file_coral_agent_v1_debug_proto_rawDescGZIP() []byte {
 	return file_coral_agent_v1_debug_proto_rawDescData
 }
 
-var file_coral_agent_v1_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_coral_agent_v1_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_coral_agent_v1_debug_proto_goTypes = []any{
 	(*StartUprobeCollectorRequest)(nil),       // 0: coral.agent.v1.StartUprobeCollectorRequest
 	(*UprobeConfig)(nil),                      // 1: coral.agent.v1.UprobeConfig
@@ -2258,81 +5159,171 @@ var file_coral_agent_v1_debug_proto_goTypes = []any{
 	(*FunctionArgument)(nil),                  // 9: coral.agent.v1.FunctionArgument
 	(*FunctionReturnValue)(nil),               // 10: coral.agent.v1.FunctionReturnValue
 	(*UprobeEvent)(nil),                       // 11: coral.agent.v1.UprobeEvent
-	(*QueryUprobeEventsResponse)(nil),         // 12: coral.agent.v1.QueryUprobeEventsResponse
-	(*ProfileCPUAgentRequest)(nil),            // 13: coral.agent.v1.ProfileCPUAgentRequest
-	(*StackSample)(nil),                       // 14: coral.agent.v1.StackSample
-	(*ProfileCPUAgentResponse)(nil),           // 15: coral.agent.v1.ProfileCPUAgentResponse
-	(*QueryCPUProfileSamplesRequest)(nil),     // 16: coral.agent.v1.QueryCPUProfileSamplesRequest
-	(*CPUProfileSample)(nil),                  // 17: coral.agent.v1.CPUProfileSample
-	(*QueryCPUProfileSamplesResponse)(nil),    // 18: coral.agent.v1.QueryCPUProfileSamplesResponse
-	(*ProfileMemoryAgentRequest)(nil),         // 19: coral.agent.v1.ProfileMemoryAgentRequest
-	(*MemoryStats)(nil),                       // 20: coral.agent.v1.MemoryStats
-	(*MemoryStackSample)(nil),                 // 21: coral.agent.v1.MemoryStackSample
-	(*TopAllocFunction)(nil),                  // 22: coral.agent.v1.TopAllocFunction
-	(*TopAllocType)(nil),                      // 23: coral.agent.v1.TopAllocType
-	(*ProfileMemoryAgentResponse)(nil),        // 24: coral.agent.v1.ProfileMemoryAgentResponse
-	(*QueryMemoryProfileSamplesRequest)(nil),  // 25: coral.agent.v1.QueryMemoryProfileSamplesRequest
-	(*MemoryProfileSample)(nil),               // 26: coral.agent.v1.MemoryProfileSample
-	(*QueryMemoryProfileSamplesResponse)(nil), // 27: coral.agent.v1.QueryMemoryProfileSamplesResponse
-	nil,                               // 28: coral.agent.v1.UprobeEvent.LabelsEntry
-	(*durationpb.Duration)(nil),       // 29: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil),     // 30: google.protobuf.Timestamp
-	(*DeployCorrelationRequest)(nil),  // 31: coral.agent.v1.DeployCorrelationRequest
-	(*RemoveCorrelationRequest)(nil),  // 32: coral.agent.v1.RemoveCorrelationRequest
-	(*ListCorrelationsRequest)(nil),   // 33: coral.agent.v1.ListCorrelationsRequest
-	(*DeployCorrelationResponse)(nil), // 34: coral.agent.v1.DeployCorrelationResponse
-	(*RemoveCorrelationResponse)(nil), // 35: coral.agent.v1.RemoveCorrelationResponse
-	(*ListCorrelationsResponse)(nil),  // 36: coral.agent.v1.ListCorrelationsResponse
+	(*HttpHeader)(nil),                        // 12: coral.agent.v1.HttpHeader
+	(*HttpExchange)(nil),                      // 13: coral.agent.v1.HttpExchange
+	(*TlsData)(nil),                           // 14: coral.agent.v1.TlsData
+	(*QueryUprobeEventsResponse)(nil),         // 15: coral.agent.v1.QueryUprobeEventsResponse
+	(*ProfileCPUAgentRequest)(nil),            // 16: coral.agent.v1.ProfileCPUAgentRequest
+	(*StackSample)(nil),                       // 17: coral.agent.v1.StackSample
+	(*ProfileCPUAgentResponse)(nil),           // 18: coral.agent.v1.ProfileCPUAgentResponse
+	(*QueryCPUProfileSamplesRequest)(nil),     // 19: coral.agent.v1.QueryCPUProfileSamplesRequest
+	(*CPUProfileSample)(nil),                  // 20: coral.agent.v1.CPUProfileSample
+	(*QueryCPUProfileSamplesResponse)(nil),    // 21: coral.agent.v1.QueryCPUProfileSamplesResponse
+	(*ProfileMemoryAgentRequest)(nil),         // 22: coral.agent.v1.ProfileMemoryAgentRequest
+	(*MemoryStats)(nil),                       // 23: coral.agent.v1.MemoryStats
+	(*MemoryStackSample)(nil),                 // 24: coral.agent.v1.MemoryStackSample
+	(*TopAllocFunction)(nil),                  // 25: coral.agent.v1.TopAllocFunction
+	(*TopAllocType)(nil),                      // 26: coral.agent.v1.TopAllocType
+	(*ProfileMemoryAgentResponse)(nil),        // 27: coral.agent.v1.ProfileMemoryAgentResponse
+	(*QueryMemoryProfileSamplesRequest)(nil),  // 28: coral.agent.v1.QueryMemoryProfileSamplesRequest
+	(*MemoryProfileSample)(nil),               // 29: coral.agent.v1.MemoryProfileSample
+	(*QueryMemoryProfileSamplesResponse)(nil), // 30: coral.agent.v1.QueryMemoryProfileSamplesResponse
+	(*CoreDumpInfo)(nil),                      // 31: coral.agent.v1.CoreDumpInfo
+	(*ListCoreDumpsRequest)(nil),              // 32: coral.agent.v1.ListCoreDumpsRequest
+	(*ListCoreDumpsResponse)(nil),             // 33: coral.agent.v1.ListCoreDumpsResponse
+	(*DownloadCoreDumpRequest)(nil),           // 34: coral.agent.v1.DownloadCoreDumpRequest
+	(*CoreDumpChunk)(nil),                     // 35: coral.agent.v1.CoreDumpChunk
+	(*DescribeFunctionRequest)(nil),           // 36: coral.agent.v1.DescribeFunctionRequest
+	(*DescribeFunctionResponse)(nil),          // 37: coral.agent.v1.DescribeFunctionResponse
+	(*StartHttpCaptureRequest)(nil),           // 38: coral.agent.v1.StartHttpCaptureRequest
+	(*StartHttpCaptureResponse)(nil),          // 39: coral.agent.v1.StartHttpCaptureResponse
+	(*StartTlsCaptureRequest)(nil),            // 40: coral.agent.v1.StartTlsCaptureRequest
+	(*StartTlsCaptureResponse)(nil),           // 41: coral.agent.v1.StartTlsCaptureResponse
+	(*TraceRuntimeAgentRequest)(nil),          // 42: coral.agent.v1.TraceRuntimeAgentRequest
+	(*RuntimePause)(nil),                      // 43: coral.agent.v1.RuntimePause
+	(*GcCycle)(nil),                           // 44: coral.agent.v1.GcCycle
+	(*LatencyBucket)(nil),                     // 45: coral.agent.v1.LatencyBucket
+	(*TraceRuntimeAgentResponse)(nil),         // 46: coral.agent.v1.TraceRuntimeAgentResponse
+	(*TraceSyscallsAgentRequest)(nil),         // 47: coral.agent.v1.TraceSyscallsAgentRequest
+	(*SyscallError)(nil),                      // 48: coral.agent.v1.SyscallError
+	(*SyscallStats)(nil),                      // 49: coral.agent.v1.SyscallStats
+	(*TraceSyscallsAgentResponse)(nil),        // 50: coral.agent.v1.TraceSyscallsAgentResponse
+	(*ProfileIOAgentRequest)(nil),             // 51: coral.agent.v1.ProfileIOAgentRequest
+	(*IOStats)(nil),                           // 52: coral.agent.v1.IOStats
+	(*FileIO)(nil),                            // 53: coral.agent.v1.FileIO
+	(*DeviceIO)(nil),                          // 54: coral.agent.v1.DeviceIO
+	(*ProfileIOAgentResponse)(nil),            // 55: coral.agent.v1.ProfileIOAgentResponse
+	(*ProfileLocksAgentRequest)(nil),          // 56: coral.agent.v1.ProfileLocksAgentRequest
+	(*LockWaitStack)(nil),                     // 57: coral.agent.v1.LockWaitStack
+	(*ProfileLocksAgentResponse)(nil),         // 58: coral.agent.v1.ProfileLocksAgentResponse
+	(*FunctionDescription)(nil),               // 59: coral.agent.v1.FunctionDescription
+	(*FunctionParameter)(nil),                 // 60: coral.agent.v1.FunctionParameter
+	(*Probeability)(nil),                      // 61: coral.agent.v1.Probeability
+	nil,                                       // 62: coral.agent.v1.UprobeEvent.LabelsEntry
+	(*durationpb.Duration)(nil),               // 63: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),             // 64: google.protobuf.Timestamp
+	(*DeployCorrelationRequest)(nil),          // 65: coral.agent.v1.DeployCorrelationRequest
+	(*RemoveCorrelationRequest)(nil),          // 66: coral.agent.v1.RemoveCorrelationRequest
+	(*ListCorrelationsRequest)(nil),           // 67: coral.agent.v1.ListCorrelationsRequest
+	(*DeployCorrelationResponse)(nil),         // 68: coral.agent.v1.DeployCorrelationResponse
+	(*RemoveCorrelationResponse)(nil),         // 69: coral.agent.v1.RemoveCorrelationResponse
+	(*ListCorrelationsResponse)(nil),          // 70: coral.agent.v1.ListCorrelationsResponse
 }
 var file_coral_agent_v1_debug_proto_depIdxs = []int32{
-	29, // 0: coral.agent.v1.StartUprobeCollectorRequest.duration:type_name -> google.protobuf.Duration
+	63, // 0: coral.agent.v1.StartUprobeCollectorRequest.duration:type_name -> google.protobuf.Duration
 	1,  // 1: coral.agent.v1.StartUprobeCollectorRequest.config:type_name -> coral.agent.v1.UprobeConfig
 	2,  // 2: coral.agent.v1.StartUprobeCollectorRequest.filter:type_name -> coral.agent.v1.UprobeFilter
 	2,  // 3: coral.agent.v1.UpdateProbeFilterRequest.filter:type_name -> coral.agent.v1.UprobeFilter
-	30, // 4: coral.agent.v1.StartUprobeCollectorResponse.expires_at:type_name -> google.protobuf.Timestamp
-	30, // 5: coral.agent.v1.QueryUprobeEventsRequest.start_time:type_name -> google.protobuf.Timestamp
-	30, // 6: coral.agent.v1.QueryUprobeEventsRequest.end_time:type_name -> google.protobuf.Timestamp
-	30, // 7: coral.agent.v1.UprobeEvent.timestamp:type_name -> google.protobuf.Timestamp
+	64, // 4: coral.agent.v1.StartUprobeCollectorResponse.expires_at:type_name -> google.protobuf.Timestamp
+	64, // 5: coral.agent.v1.QueryUprobeEventsRequest.start_time:type_name -> google.protobuf.Timestamp
+	64, // 6: coral.agent.v1.QueryUprobeEventsRequest.end_time:type_name -> google.protobuf.Timestamp
+	64, // 7: coral.agent.v1.UprobeEvent.timestamp:type_name -> google.protobuf.Timestamp
 	9,  // 8: coral.agent.v1.UprobeEvent.args:type_name -> coral.agent.v1.FunctionArgument
 	10, // 9: coral.agent.v1.UprobeEvent.return_value:type_name -> coral.agent.v1.FunctionReturnValue
-	28, // 10: coral.agent.v1.UprobeEvent.labels:type_name -> coral.agent.v1.UprobeEvent.LabelsEntry
-	11, // 11: coral.agent.v1.QueryUprobeEventsResponse.events:type_name -> coral.agent.v1.UprobeEvent
-	14, // 12: coral.agent.v1.ProfileCPUAgentResponse.samples:type_name -> coral.agent.v1.StackSample
-	30, // 13: coral.agent.v1.CPUProfileSample.timestamp:type_name -> google.protobuf.Timestamp
-	17, // 14: coral.agent.v1.QueryCPUProfileSamplesResponse.samples:type_name -> coral.agent.v1.CPUProfileSample
-	21, // 15: coral.agent.v1.ProfileMemoryAgentResponse.samples:type_name -> coral.agent.v1.MemoryStackSample
-	20, // 16: coral.agent.v1.ProfileMemoryAgentResponse.stats:type_name -> coral.agent.v1.MemoryStats
-	22, // 17: coral.agent.v1.ProfileMemoryAgentResponse.top_functions:type_name -> coral.agent.v1.TopAllocFunction
-	23, // 18: coral.agent.v1.ProfileMemoryAgentResponse.top_types:type_name -> coral.agent.v1.TopAllocType
-	30, // 19: coral.agent.v1.MemoryProfileSample.timestamp:type_name -> google.protobuf.Timestamp
-	26, // 20: coral.agent.v1.QueryMemoryProfileSamplesResponse.samples:type_name -> coral.agent.v1.MemoryProfileSample
-	0,  // 21: coral.agent.v1.AgentDebugService.StartUprobeCollector:input_type -> coral.agent.v1.StartUprobeCollectorRequest
-	6,  // 22: coral.agent.v1.AgentDebugService.StopUprobeCollector:input_type -> coral.agent.v1.StopUprobeCollectorRequest
-	8,  // 23: coral.agent.v1.AgentDebugService.QueryUprobeEvents:input_type -> coral.agent.v1.QueryUprobeEventsRequest
-	3,  // 24: coral.agent.v1.AgentDebugService.UpdateProbeFilter:input_type -> coral.agent.v1.UpdateProbeFilterRequest
-	13, // 25: coral.agent.v1.AgentDebugService.ProfileCPU:input_type -> coral.agent.v1.ProfileCPUAgentRequest
-	16, // 26: coral.agent.v1.AgentDebugService.QueryCPUProfileSamples:input_type -> coral.agent.v1.QueryCPUProfileSamplesRequest
-	19, // 27: coral.agent.v1.AgentDebugService.ProfileMemory:input_type -> coral.agent.v1.ProfileMemoryAgentRequest
-	25, // 28: coral.agent.v1.AgentDebugService.QueryMemoryProfileSamples:input_type -> coral.agent.v1.QueryMemoryProfileSamplesRequest
-	31, // 29: coral.agent.v1.AgentDebugService.DeployCorrelation:input_type -> coral.agent.v1.DeployCorrelationRequest
-	32, // 30: coral.agent.v1.AgentDebugService.RemoveCorrelation:input_type -> coral.agent.v1.RemoveCorrelationRequest
-	33, // 31: coral.agent.v1.AgentDebugService.ListCorrelations:input_type -> coral.agent.v1.ListCorrelationsRequest
-	5,  // 32: coral.agent.v1.AgentDebugService.StartUprobeCollector:output_type -> coral.agent.v1.StartUprobeCollectorResponse
-	7,  // 33: coral.agent.v1.AgentDebugService.StopUprobeCollector:output_type -> coral.agent.v1.StopUprobeCollectorResponse
-	12, // 34: coral.agent.v1.AgentDebugService.QueryUprobeEvents:output_type -> coral.agent.v1.QueryUprobeEventsResponse
-	4,  // 35: coral.agent.v1.AgentDebugService.UpdateProbeFilter:output_type -> coral.agent.v1.UpdateProbeFilterResponse
-	15, // 36: coral.agent.v1.AgentDebugService.ProfileCPU:output_type -> coral.agent.v1.ProfileCPUAgentResponse
-	18, // 37: coral.agent.v1.AgentDebugService.QueryCPUProfileSamples:output_type -> coral.agent.v1.QueryCPUProfileSamplesResponse
-	24, // 38: coral.agent.v1.AgentDebugService.ProfileMemory:output_type -> coral.agent.v1.ProfileMemoryAgentResponse
-	27, // 39: coral.agent.v1.AgentDebugService.QueryMemoryProfileSamples:output_type -> coral.agent.v1.QueryMemoryProfileSamplesResponse
-	34, // 40: coral.agent.v1.AgentDebugService.DeployCorrelation:output_type -> coral.agent.v1.DeployCorrelationResponse
-	35, // 41: coral.agent.v1.AgentDebugService.RemoveCorrelation:output_type -> coral.agent.v1.RemoveCorrelationResponse
-	36, // 42: coral.agent.v1.AgentDebugService.ListCorrelations:output_type -> coral.agent.v1.ListCorrelationsResponse
-	32, // [32:43] is the sub-list for method output_type
-	21, // [21:32] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	62, // 10: coral.agent.v1.UprobeEvent.labels:type_name -> coral.agent.v1.UprobeEvent.LabelsEntry
+	13, // 11: coral.agent.v1.UprobeEvent.http:type_name -> coral.agent.v1.HttpExchange
+	14, // 12: coral.agent.v1.UprobeEvent.tls:type_name -> coral.agent.v1.TlsData
+	12, // 13: coral.agent.v1.HttpExchange.request_headers:type_name -> coral.agent.v1.HttpHeader
+	12, // 14: coral.agent.v1.HttpExchange.response_headers:type_name -> coral.agent.v1.HttpHeader
+	11, // 15: coral.agent.v1.QueryUprobeEventsResponse.events:type_name -> coral.agent.v1.UprobeEvent
+	17, // 16: coral.agent.v1.ProfileCPUAgentResponse.samples:type_name -> coral.agent.v1.StackSample
+	64, // 17: coral.agent.v1.CPUProfileSample.timestamp:type_name -> google.protobuf.Timestamp
+	20, // 18: coral.agent.v1.QueryCPUProfileSamplesResponse.samples:type_name -> coral.agent.v1.CPUProfileSample
+	24, // 19: coral.agent.v1.ProfileMemoryAgentResponse.samples:type_name -> coral.agent.v1.MemoryStackSample
+	23, // 20: coral.agent.v1.ProfileMemoryAgentResponse.stats:type_name -> coral.agent.v1.MemoryStats
+	25, // 21: coral.agent.v1.ProfileMemoryAgentResponse.top_functions:type_name -> coral.agent.v1.TopAllocFunction
+	26, // 22: coral.agent.v1.ProfileMemoryAgentResponse.top_types:type_name -> coral.agent.v1.TopAllocType
+	64, // 23: coral.agent.v1.MemoryProfileSample.timestamp:type_name -> google.protobuf.Timestamp
+	29, // 24: coral.agent.v1.QueryMemoryProfileSamplesResponse.samples:type_name -> coral.agent.v1.MemoryProfileSample
+	64, // 25: coral.agent.v1.CoreDumpInfo.crashed_at:type_name -> google.protobuf.Timestamp
+	31, // 26: coral.agent.v1.ListCoreDumpsResponse.dumps:type_name -> coral.agent.v1.CoreDumpInfo
+	31, // 27: coral.agent.v1.CoreDumpChunk.info:type_name -> coral.agent.v1.CoreDumpInfo
+	59, // 28: coral.agent.v1.DescribeFunctionResponse.function:type_name -> coral.agent.v1.FunctionDescription
+	63, // 29: coral.agent.v1.StartHttpCaptureRequest.duration:type_name -> google.protobuf.Duration
+	64, // 30: coral.agent.v1.StartHttpCaptureResponse.expires_at:type_name -> google.protobuf.Timestamp
+	63, // 31: coral.agent.v1.StartTlsCaptureRequest.duration:type_name -> google.protobuf.Duration
+	64, // 32: coral.agent.v1.StartTlsCaptureResponse.expires_at:type_name -> google.protobuf.Timestamp
+	64, // 33: coral.agent.v1.RuntimePause.start:type_name -> google.protobuf.Timestamp
+	64, // 34: coral.agent.v1.GcCycle.start:type_name -> google.protobuf.Timestamp
+	43, // 35: coral.agent.v1.TraceRuntimeAgentResponse.pauses:type_name -> coral.agent.v1.RuntimePause
+	44, // 36: coral.agent.v1.TraceRuntimeAgentResponse.gc_cycles:type_name -> coral.agent.v1.GcCycle
+	45, // 37: coral.agent.v1.TraceRuntimeAgentResponse.sched_latency:type_name -> coral.agent.v1.LatencyBucket
+	64, // 38: coral.agent.v1.TraceRuntimeAgentResponse.start_time:type_name -> google.protobuf.Timestamp
+	64, // 39: coral.agent.v1.TraceRuntimeAgentResponse.end_time:type_name -> google.protobuf.Timestamp
+	45, // 40: coral.agent.v1.SyscallStats.latency:type_name -> coral.agent.v1.LatencyBucket
+	48, // 41: coral.agent.v1.SyscallStats.errors:type_name -> coral.agent.v1.SyscallError
+	49, // 42: coral.agent.v1.TraceSyscallsAgentResponse.syscalls:type_name -> coral.agent.v1.SyscallStats
+	64, // 43: coral.agent.v1.TraceSyscallsAgentResponse.start_time:type_name -> google.protobuf.Timestamp
+	64, // 44: coral.agent.v1.TraceSyscallsAgentResponse.end_time:type_name -> google.protobuf.Timestamp
+	52, // 45: coral.agent.v1.FileIO.stats:type_name -> coral.agent.v1.IOStats
+	52, // 46: coral.agent.v1.DeviceIO.stats:type_name -> coral.agent.v1.IOStats
+	53, // 47: coral.agent.v1.ProfileIOAgentResponse.files:type_name -> coral.agent.v1.FileIO
+	54, // 48: coral.agent.v1.ProfileIOAgentResponse.devices:type_name -> coral.agent.v1.DeviceIO
+	45, // 49: coral.agent.v1.ProfileIOAgentResponse.read_latency:type_name -> coral.agent.v1.LatencyBucket
+	45, // 50: coral.agent.v1.ProfileIOAgentResponse.write_latency:type_name -> coral.agent.v1.LatencyBucket
+	64, // 51: coral.agent.v1.ProfileIOAgentResponse.start_time:type_name -> google.protobuf.Timestamp
+	64, // 52: coral.agent.v1.ProfileIOAgentResponse.end_time:type_name -> google.protobuf.Timestamp
+	57, // 53: coral.agent.v1.ProfileLocksAgentResponse.stacks:type_name -> coral.agent.v1.LockWaitStack
+	64, // 54: coral.agent.v1.ProfileLocksAgentResponse.start_time:type_name -> google.protobuf.Timestamp
+	64, // 55: coral.agent.v1.ProfileLocksAgentResponse.end_time:type_name -> google.protobuf.Timestamp
+	60, // 56: coral.agent.v1.FunctionDescription.arguments:type_name -> coral.agent.v1.FunctionParameter
+	60, // 57: coral.agent.v1.FunctionDescription.return_values:type_name -> coral.agent.v1.FunctionParameter
+	61, // 58: coral.agent.v1.FunctionDescription.probeability:type_name -> coral.agent.v1.Probeability
+	0,  // 59: coral.agent.v1.AgentDebugService.StartUprobeCollector:input_type -> coral.agent.v1.StartUprobeCollectorRequest
+	6,  // 60: coral.agent.v1.AgentDebugService.StopUprobeCollector:input_type -> coral.agent.v1.StopUprobeCollectorRequest
+	8,  // 61: coral.agent.v1.AgentDebugService.QueryUprobeEvents:input_type -> coral.agent.v1.QueryUprobeEventsRequest
+	3,  // 62: coral.agent.v1.AgentDebugService.UpdateProbeFilter:input_type -> coral.agent.v1.UpdateProbeFilterRequest
+	16, // 63: coral.agent.v1.AgentDebugService.ProfileCPU:input_type -> coral.agent.v1.ProfileCPUAgentRequest
+	19, // 64: coral.agent.v1.AgentDebugService.QueryCPUProfileSamples:input_type -> coral.agent.v1.QueryCPUProfileSamplesRequest
+	22, // 65: coral.agent.v1.AgentDebugService.ProfileMemory:input_type -> coral.agent.v1.ProfileMemoryAgentRequest
+	51, // 66: coral.agent.v1.AgentDebugService.ProfileIO:input_type -> coral.agent.v1.ProfileIOAgentRequest
+	56, // 67: coral.agent.v1.AgentDebugService.ProfileLocks:input_type -> coral.agent.v1.ProfileLocksAgentRequest
+	28, // 68: coral.agent.v1.AgentDebugService.QueryMemoryProfileSamples:input_type -> coral.agent.v1.QueryMemoryProfileSamplesRequest
+	65, // 69: coral.agent.v1.AgentDebugService.DeployCorrelation:input_type -> coral.agent.v1.DeployCorrelationRequest
+	66, // 70: coral.agent.v1.AgentDebugService.RemoveCorrelation:input_type -> coral.agent.v1.RemoveCorrelationRequest
+	67, // 71: coral.agent.v1.AgentDebugService.ListCorrelations:input_type -> coral.agent.v1.ListCorrelationsRequest
+	32, // 72: coral.agent.v1.AgentDebugService.ListCoreDumps:input_type -> coral.agent.v1.ListCoreDumpsRequest
+	34, // 73: coral.agent.v1.AgentDebugService.DownloadCoreDump:input_type -> coral.agent.v1.DownloadCoreDumpRequest
+	36, // 74: coral.agent.v1.AgentDebugService.DescribeFunction:input_type -> coral.agent.v1.DescribeFunctionRequest
+	38, // 75: coral.agent.v1.AgentDebugService.StartHttpCapture:input_type -> coral.agent.v1.StartHttpCaptureRequest
+	40, // 76: coral.agent.v1.AgentDebugService.StartTlsCapture:input_type -> coral.agent.v1.StartTlsCaptureRequest
+	42, // 77: coral.agent.v1.AgentDebugService.TraceRuntime:input_type -> coral.agent.v1.TraceRuntimeAgentRequest
+	47, // 78: coral.agent.v1.AgentDebugService.TraceSyscalls:input_type -> coral.agent.v1.TraceSyscallsAgentRequest
+	5,  // 79: coral.agent.v1.AgentDebugService.StartUprobeCollector:output_type -> coral.agent.v1.StartUprobeCollectorResponse
+	7,  // 80: coral.agent.v1.AgentDebugService.StopUprobeCollector:output_type -> coral.agent.v1.StopUprobeCollectorResponse
+	15, // 81: coral.agent.v1.AgentDebugService.QueryUprobeEvents:output_type -> coral.agent.v1.QueryUprobeEventsResponse
+	4,  // 82: coral.agent.v1.AgentDebugService.UpdateProbeFilter:output_type -> coral.agent.v1.UpdateProbeFilterResponse
+	18, // 83: coral.agent.v1.AgentDebugService.ProfileCPU:output_type -> coral.agent.v1.ProfileCPUAgentResponse
+	21, // 84: coral.agent.v1.AgentDebugService.QueryCPUProfileSamples:output_type -> coral.agent.v1.QueryCPUProfileSamplesResponse
+	27, // 85: coral.agent.v1.AgentDebugService.ProfileMemory:output_type -> coral.agent.v1.ProfileMemoryAgentResponse
+	55, // 86: coral.agent.v1.AgentDebugService.ProfileIO:output_type -> coral.agent.v1.ProfileIOAgentResponse
+	58, // 87: coral.agent.v1.AgentDebugService.ProfileLocks:output_type -> coral.agent.v1.ProfileLocksAgentResponse
+	30, // 88: coral.agent.v1.AgentDebugService.QueryMemoryProfileSamples:output_type -> coral.agent.v1.QueryMemoryProfileSamplesResponse
+	68, // 89: coral.agent.v1.AgentDebugService.DeployCorrelation:output_type -> coral.agent.v1.DeployCorrelationResponse
+	69, // 90: coral.agent.v1.AgentDebugService.RemoveCorrelation:output_type -> coral.agent.v1.RemoveCorrelationResponse
+	70, // 91: coral.agent.v1.AgentDebugService.ListCorrelations:output_type -> coral.agent.v1.ListCorrelationsResponse
+	33, // 92: coral.agent.v1.AgentDebugService.ListCoreDumps:output_type -> coral.agent.v1.ListCoreDumpsResponse
+	35, // 93: coral.agent.v1.AgentDebugService.DownloadCoreDump:output_type -> coral.agent.v1.CoreDumpChunk
+	37, // 94: coral.agent.v1.AgentDebugService.DescribeFunction:output_type -> coral.agent.v1.DescribeFunctionResponse
+	39, // 95: coral.agent.v1.AgentDebugService.StartHttpCapture:output_type -> coral.agent.v1.StartHttpCaptureResponse
+	41, // 96: coral.agent.v1.AgentDebugService.StartTlsCapture:output_type -> coral.agent.v1.StartTlsCaptureResponse
+	46, // 97: coral.agent.v1.AgentDebugService.TraceRuntime:output_type -> coral.agent.v1.TraceRuntimeAgentResponse
+	50, // 98: coral.agent.v1.AgentDebugService.TraceSyscalls:output_type -> coral.agent.v1.TraceSyscallsAgentResponse
+	79, // [79:99] is the sub-list for method output_type
+	59, // [59:79] is the sub-list for method input_type
+	59, // [59:59] is the sub-list for extension type_name
+	59, // [59:59] is the sub-list for extension extendee
+	0,  // [0:59] is the sub-list for field type_name
 }
 
 func init() { file_coral_agent_v1_debug_proto_init() }
@@ -2348,7 +5339,7 @@ func file_coral_agent_v1_debug_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_coral_agent_v1_debug_proto_rawDesc), len(file_coral_agent_v1_debug_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -16,6 +16,7 @@ import (
 	v1 "github.com/coral-mesh/coral/coral/network/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -37,6 +38,9 @@ const (
 	EvidenceLayer_EVIDENCE_LAYER_L4_NETWORK EvidenceLayer = 2
 	// Connection confirmed by both trace data and network observation.
 	EvidenceLayer_EVIDENCE_LAYER_BOTH EvidenceLayer = 3
+	// Connection derived from client-side data only: SQL query metrics, or gRPC
+	// client spans to a server without instrumentation.
+	EvidenceLayer_EVIDENCE_LAYER_L7_CLIENT EvidenceLayer = 4
 )
 
 // Enum value maps for EvidenceLayer.
@@ -46,12 +50,14 @@ var (
 		1: "EVIDENCE_LAYER_L7_TRACE",
 		2: "EVIDENCE_LAYER_L4_NETWORK",
 		3: "EVIDENCE_LAYER_BOTH",
+		4: "EVIDENCE_LAYER_L7_CLIENT",
 	}
 	EvidenceLayer_value = map[string]int32{
 		"EVIDENCE_LAYER_UNSPECIFIED": 0,
 		"EVIDENCE_LAYER_L7_TRACE":    1,
 		"EVIDENCE_LAYER_L4_NETWORK":  2,
 		"EVIDENCE_LAYER_BOTH":        3,
+		"EVIDENCE_LAYER_L7_CLIENT":   4,
 	}
 )
 
@@ -82,6 +88,78 @@ func (EvidenceLayer) EnumDescriptor() ([]byte, []int) {
 	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{0}
 }
 
+// Type of a colony event.
+type ColonyEventType int32
+
+const (
+	ColonyEventType_COLONY_EVENT_TYPE_UNSPECIFIED ColonyEventType = 0
+	// Agent registered, or resumed heartbeats after being disconnected.
+	ColonyEventType_COLONY_EVENT_TYPE_AGENT_CONNECTED ColonyEventType = 1
+	// Agent stopped sending heartbeats and is considered unhealthy.
+	ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED ColonyEventType = 2
+	// Service was added to an agent's registration.
+	ColonyEventType_COLONY_EVENT_TYPE_SERVICE_REGISTERED ColonyEventType = 3
+	// Service was removed from an agent's registration.
+	ColonyEventType_COLONY_EVENT_TYPE_SERVICE_DEREGISTERED ColonyEventType = 4
+	// Debug session (uprobe) was attached.
+	ColonyEventType_COLONY_EVENT_TYPE_DEBUG_SESSION_STARTED ColonyEventType = 5
+	// Debug session (uprobe) was detached.
+	ColonyEventType_COLONY_EVENT_TYPE_DEBUG_SESSION_STOPPED ColonyEventType = 6
+	// On-demand profiling (CPU, memory or functions) completed.
+	ColonyEventType_COLONY_EVENT_TYPE_PROFILING_COMPLETED ColonyEventType = 7
+)
+
+// Enum value maps for ColonyEventType.
+var (
+	ColonyEventType_name = map[int32]string{
+		0: "COLONY_EVENT_TYPE_UNSPECIFIED",
+		1: "COLONY_EVENT_TYPE_AGENT_CONNECTED",
+		2: "COLONY_EVENT_TYPE_AGENT_DISCONNECTED",
+		3: "COLONY_EVENT_TYPE_SERVICE_REGISTERED",
+		4: "COLONY_EVENT_TYPE_SERVICE_DEREGISTERED",
+		5: "COLONY_EVENT_TYPE_DEBUG_SESSION_STARTED",
+		6: "COLONY_EVENT_TYPE_DEBUG_SESSION_STOPPED",
+		7: "COLONY_EVENT_TYPE_PROFILING_COMPLETED",
+	}
+	ColonyEventType_value = map[string]int32{
+		"COLONY_EVENT_TYPE_UNSPECIFIED":           0,
+		"COLONY_EVENT_TYPE_AGENT_CONNECTED":       1,
+		"COLONY_EVENT_TYPE_AGENT_DISCONNECTED":    2,
+		"COLONY_EVENT_TYPE_SERVICE_REGISTERED":    3,
+		"COLONY_EVENT_TYPE_SERVICE_DEREGISTERED":  4,
+		"COLONY_EVENT_TYPE_DEBUG_SESSION_STARTED": 5,
+		"COLONY_EVENT_TYPE_DEBUG_SESSION_STOPPED": 6,
+		"COLONY_EVENT_TYPE_PROFILING_COMPLETED":   7,
+	}
+)
+
+func (x ColonyEventType) Enum() *ColonyEventType {
+	p := new(ColonyEventType)
+	*p = x
+	return p
+}
+
+func (x ColonyEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ColonyEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_coral_colony_v1_colony_proto_enumTypes[1].Descriptor()
+}
+
+func (ColonyEventType) Type() protoreflect.EnumType {
+	return &file_coral_colony_v1_colony_proto_enumTypes[1]
+}
+
+func (x ColonyEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ColonyEventType.Descriptor instead.
+func (ColonyEventType) EnumDescriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{1}
+}
+
 type GetStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -328,7 +406,13 @@ func (x *GetStatusResponse) GetWireguard() *v1.MeshTelemetry {
 }
 
 type ListAgentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Also list the agents of child colonies (federation.children in the
+	// colony config), labeled with their colony_id.
+	Federated bool `protobuf:"varint,1,opt,name=federated,proto3" json:"federated,omitempty"`
+	// Only list agents whose labels match this selector, e.g.
+	// "env=prod,region!=us-east". Empty lists all agents.
+	Selector      string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -363,11 +447,29 @@ func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
 	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{2}
 }
 
+func (x *ListAgentsRequest) GetFederated() bool {
+	if x != nil {
+		return x.Federated
+	}
+	return false
+}
+
+func (x *ListAgentsRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
 type ListAgentsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Agents        []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Agents []*Agent               `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	// Child colonies that could not be queried in a federated request.
+	FederationErrors []*FederationError `protobuf:"bytes,2,rep,name=federation_errors,json=federationErrors,proto3" json:"federation_errors,omitempty"`
+	// Protocol version of the colony (RFD 018).
+	ProtocolVersion uint32 `protobuf:"varint,3,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ListAgentsResponse) Reset() {
@@ -407,6 +509,20 @@ func (x *ListAgentsResponse) GetAgents() []*Agent {
 	return nil
 }
 
+func (x *ListAgentsResponse) GetFederationErrors() []*FederationError {
+	if x != nil {
+		return x.FederationErrors
+	}
+	return nil
+}
+
+func (x *ListAgentsResponse) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
 type Agent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Agent identifier.
@@ -428,8 +544,26 @@ type Agent struct {
 	Services []*v11.ServiceInfo `protobuf:"bytes,7,rep,name=services,proto3" json:"services,omitempty"`
 	// NEW: Runtime context (RFD 018).
 	RuntimeContext *v12.RuntimeContextResponse `protobuf:"bytes,8,opt,name=runtime_context,json=runtimeContext,proto3" json:"runtime_context,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Resource safety valve state last reported by the agent.
+	ResourceShedding *v12.ResourceShedding `protobuf:"bytes,9,opt,name=resource_shedding,json=resourceShedding,proto3" json:"resource_shedding,omitempty"`
+	// Health score from 0 (unusable) to 100 (fully healthy), computed from
+	// heartbeat freshness and the agent's reported health metrics.
+	HealthScore int32 `protobuf:"varint,10,opt,name=health_score,json=healthScore,proto3" json:"health_score,omitempty"`
+	// Causes of a reduced health score, e.g. "clock skew 42s".
+	HealthReasons []string `protobuf:"bytes,11,rep,name=health_reasons,json=healthReasons,proto3" json:"health_reasons,omitempty"`
+	// Colony the agent is connected to. Set in federated requests.
+	ColonyId string `protobuf:"bytes,12,opt,name=colony_id,json=colonyId,proto3" json:"colony_id,omitempty"`
+	// Protocol version the agent reported at registration (RFD 018).
+	ProtocolVersion uint32 `protobuf:"varint,13,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// Capabilities of the colony's protocol version the agent lacks.
+	MissingCapabilities []*ProtocolCapability `protobuf:"bytes,14,rep,name=missing_capabilities,json=missingCapabilities,proto3" json:"missing_capabilities,omitempty"`
+	// Labels of the agent, matched by agent selectors: the labels it
+	// registered with, overridden by the labels assigned through the colony.
+	Labels map[string]string `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// The agent is drained: the colony starts no new sessions on it.
+	Drained       bool `protobuf:"varint,16,opt,name=drained,proto3" json:"drained,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Agent) Reset() {
@@ -519,26 +653,89 @@ func (x *Agent) GetRuntimeContext() *v12.RuntimeContextResponse {
 	return nil
 }
 
-type GetTopologyRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *Agent) GetResourceShedding() *v12.ResourceShedding {
+	if x != nil {
+		return x.ResourceShedding
+	}
+	return nil
+}
+
+func (x *Agent) GetHealthScore() int32 {
+	if x != nil {
+		return x.HealthScore
+	}
+	return 0
+}
+
+func (x *Agent) GetHealthReasons() []string {
+	if x != nil {
+		return x.HealthReasons
+	}
+	return nil
+}
+
+func (x *Agent) GetColonyId() string {
+	if x != nil {
+		return x.ColonyId
+	}
+	return ""
+}
+
+func (x *Agent) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *Agent) GetMissingCapabilities() []*ProtocolCapability {
+	if x != nil {
+		return x.MissingCapabilities
+	}
+	return nil
+}
+
+func (x *Agent) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Agent) GetDrained() bool {
+	if x != nil {
+		return x.Drained
+	}
+	return false
+}
+
+// ProtocolCapability is a feature of the agent-colony protocol.
+type ProtocolCapability struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Capability name, e.g. "agent_updates".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Protocol version that added the capability.
+	Since uint32 `protobuf:"varint,2,opt,name=since,proto3" json:"since,omitempty"`
+	// What the colony cannot do with agents lacking it.
+	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTopologyRequest) Reset() {
-	*x = GetTopologyRequest{}
+func (x *ProtocolCapability) Reset() {
+	*x = ProtocolCapability{}
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTopologyRequest) String() string {
+func (x *ProtocolCapability) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTopologyRequest) ProtoMessage() {}
+func (*ProtocolCapability) ProtoMessage() {}
 
-func (x *GetTopologyRequest) ProtoReflect() protoreflect.Message {
+func (x *ProtocolCapability) ProtoReflect() protoreflect.Message {
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -550,37 +747,58 @@ func (x *GetTopologyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTopologyRequest.ProtoReflect.Descriptor instead.
-func (*GetTopologyRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProtocolCapability.ProtoReflect.Descriptor instead.
+func (*ProtocolCapability) Descriptor() ([]byte, []int) {
 	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{5}
 }
 
-type GetTopologyResponse struct {
+func (x *ProtocolCapability) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProtocolCapability) GetSince() uint32 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *ProtocolCapability) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetAgentHistoryRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Colony identifier.
-	ColonyId string `protobuf:"bytes,1,opt,name=colony_id,json=colonyId,proto3" json:"colony_id,omitempty"`
-	// List of agents.
-	Agents []*Agent `protobuf:"bytes,2,rep,name=agents,proto3" json:"agents,omitempty"`
-	// List of connections between components.
-	Connections   []*Connection `protobuf:"bytes,3,rep,name=connections,proto3" json:"connections,omitempty"`
+	// Only include events at or after this time (default: 7 days ago).
+	Since *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	// Only include this agent (optional).
+	AgentId string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Maximum number of events (default 100). Agent summaries are not limited.
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTopologyResponse) Reset() {
-	*x = GetTopologyResponse{}
+func (x *GetAgentHistoryRequest) Reset() {
+	*x = GetAgentHistoryRequest{}
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTopologyResponse) String() string {
+func (x *GetAgentHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTopologyResponse) ProtoMessage() {}
+func (*GetAgentHistoryRequest) ProtoMessage() {}
 
-func (x *GetTopologyResponse) ProtoReflect() protoreflect.Message {
+func (x *GetAgentHistoryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -592,60 +810,56 @@ func (x *GetTopologyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTopologyResponse.ProtoReflect.Descriptor instead.
-func (*GetTopologyResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAgentHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentHistoryRequest) Descriptor() ([]byte, []int) {
 	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetTopologyResponse) GetColonyId() string {
+func (x *GetAgentHistoryRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ColonyId
+		return x.Since
 	}
-	return ""
+	return nil
 }
 
-func (x *GetTopologyResponse) GetAgents() []*Agent {
+func (x *GetAgentHistoryRequest) GetAgentId() string {
 	if x != nil {
-		return x.Agents
+		return x.AgentId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetTopologyResponse) GetConnections() []*Connection {
+func (x *GetAgentHistoryRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Connections
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-type Connection struct {
+type GetAgentHistoryResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Source component ID.
-	SourceId string `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
-	// Target component ID.
-	TargetId string `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
-	// Connection type ("http", "grpc", "database", "tcp", etc.).
-	ConnectionType string `protobuf:"bytes,3,opt,name=connection_type,json=connectionType,proto3" json:"connection_type,omitempty"`
-	// Evidence layer indicating how this connection was observed (RFD 033).
-	EvidenceLayer EvidenceLayer `protobuf:"varint,4,opt,name=evidence_layer,json=evidenceLayer,proto3,enum=coral.colony.v1.EvidenceLayer" json:"evidence_layer,omitempty"`
+	// Per-agent summaries for agents with events in the window.
+	Agents []*AgentHistorySummary `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	// Events, newest first.
+	Events        []*AgentHistoryEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Connection) Reset() {
-	*x = Connection{}
+func (x *GetAgentHistoryResponse) Reset() {
+	*x = GetAgentHistoryResponse{}
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Connection) String() string {
+func (x *GetAgentHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Connection) ProtoMessage() {}
+func (*GetAgentHistoryResponse) ProtoMessage() {}
 
-func (x *Connection) ProtoReflect() protoreflect.Message {
+func (x *GetAgentHistoryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -657,65 +871,57 @@ func (x *Connection) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
-func (*Connection) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetAgentHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentHistoryResponse) Descriptor() ([]byte, []int) {
 	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Connection) GetSourceId() string {
-	if x != nil {
-		return x.SourceId
-	}
-	return ""
-}
-
-func (x *Connection) GetTargetId() string {
-	if x != nil {
-		return x.TargetId
-	}
-	return ""
-}
-
-func (x *Connection) GetConnectionType() string {
+func (x *GetAgentHistoryResponse) GetAgents() []*AgentHistorySummary {
 	if x != nil {
-		return x.ConnectionType
+		return x.Agents
 	}
-	return ""
+	return nil
 }
 
-func (x *Connection) GetEvidenceLayer() EvidenceLayer {
+func (x *GetAgentHistoryResponse) GetEvents() []*AgentHistoryEvent {
 	if x != nil {
-		return x.EvidenceLayer
+		return x.Events
 	}
-	return EvidenceLayer_EVIDENCE_LAYER_UNSPECIFIED
+	return nil
 }
 
-// ReportConnectionsRequest carries a batch of aggregated outbound L4 connections
-// from a single agent. Agents stream these periodically (default: every 30s).
-type ReportConnectionsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Reporting agent identifier.
-	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	// Aggregated connection entries observed since the last batch.
-	Connections   []*L4ConnectionEntry `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty"`
+// History of a single agent within the requested window.
+type AgentHistorySummary struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// First event ever recorded for the agent, regardless of the window.
+	FirstSeen *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastEvent *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_event,json=lastEvent,proto3" json:"last_event,omitempty"`
+	// Registrations and reconnects.
+	Connects    int32 `protobuf:"varint,4,opt,name=connects,proto3" json:"connects,omitempty"`
+	Disconnects int32 `protobuf:"varint,5,opt,name=disconnects,proto3" json:"disconnects,omitempty"`
+	// Transitions between healthy and degraded.
+	StatusChanges int32 `protobuf:"varint,6,opt,name=status_changes,json=statusChanges,proto3" json:"status_changes,omitempty"`
+	// True if the agent disconnected repeatedly within the window.
+	Flapping      bool `protobuf:"varint,7,opt,name=flapping,proto3" json:"flapping,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportConnectionsRequest) Reset() {
-	*x = ReportConnectionsRequest{}
+func (x *AgentHistorySummary) Reset() {
+	*x = AgentHistorySummary{}
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportConnectionsRequest) String() string {
+func (x *AgentHistorySummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportConnectionsRequest) ProtoMessage() {}
+func (*AgentHistorySummary) ProtoMessage() {}
 
-func (x *ReportConnectionsRequest) ProtoReflect() protoreflect.Message {
+func (x *AgentHistorySummary) ProtoReflect() protoreflect.Message {
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -727,47 +933,90 @@ func (x *ReportConnectionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportConnectionsRequest.ProtoReflect.Descriptor instead.
-func (*ReportConnectionsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AgentHistorySummary.ProtoReflect.Descriptor instead.
+func (*AgentHistorySummary) Descriptor() ([]byte, []int) {
 	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ReportConnectionsRequest) GetAgentId() string {
+func (x *AgentHistorySummary) GetAgentId() string {
 	if x != nil {
 		return x.AgentId
 	}
 	return ""
 }
 
-func (x *ReportConnectionsRequest) GetConnections() []*L4ConnectionEntry {
+func (x *AgentHistorySummary) GetFirstSeen() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Connections
+		return x.FirstSeen
 	}
 	return nil
 }
 
-// ReportConnectionsResponse is the server acknowledgement for ReportConnections.
-// The colony sends this once when the stream closes.
-type ReportConnectionsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *AgentHistorySummary) GetLastEvent() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastEvent
+	}
+	return nil
+}
+
+func (x *AgentHistorySummary) GetConnects() int32 {
+	if x != nil {
+		return x.Connects
+	}
+	return 0
+}
+
+func (x *AgentHistorySummary) GetDisconnects() int32 {
+	if x != nil {
+		return x.Disconnects
+	}
+	return 0
+}
+
+func (x *AgentHistorySummary) GetStatusChanges() int32 {
+	if x != nil {
+		return x.StatusChanges
+	}
+	return 0
+}
+
+func (x *AgentHistorySummary) GetFlapping() bool {
+	if x != nil {
+		return x.Flapping
+	}
+	return false
+}
+
+// An agent connectivity or status change.
+type AgentHistoryEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	AgentId   string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// "registered", "connected", "disconnected" or "status_changed".
+	Event string `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	// Agent status after the event.
+	Status        string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	MeshIpv4      string `protobuf:"bytes,6,opt,name=mesh_ipv4,json=meshIpv4,proto3" json:"mesh_ipv4,omitempty"`
+	Message       string `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReportConnectionsResponse) Reset() {
-	*x = ReportConnectionsResponse{}
+func (x *AgentHistoryEvent) Reset() {
+	*x = AgentHistoryEvent{}
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReportConnectionsResponse) String() string {
+func (x *AgentHistoryEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReportConnectionsResponse) ProtoMessage() {}
+func (*AgentHistoryEvent) ProtoMessage() {}
 
-func (x *ReportConnectionsResponse) ProtoReflect() protoreflect.Message {
+func (x *AgentHistoryEvent) ProtoReflect() protoreflect.Message {
 	mi := &file_coral_colony_v1_colony_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -779,28 +1028,388 @@ func (x *ReportConnectionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReportConnectionsResponse.ProtoReflect.Descriptor instead.
-func (*ReportConnectionsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AgentHistoryEvent.ProtoReflect.Descriptor instead.
+func (*AgentHistoryEvent) Descriptor() ([]byte, []int) {
 	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{9}
 }
 
-// L4ConnectionEntry represents a single aggregated outbound TCP connection edge.
-// Metrics are accumulated over the reporting interval.
-type L4ConnectionEntry struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Destination IP address.
-	RemoteIp string `protobuf:"bytes,1,opt,name=remote_ip,json=remoteIp,proto3" json:"remote_ip,omitempty"`
-	// Destination port.
-	RemotePort uint32 `protobuf:"varint,2,opt,name=remote_port,json=remotePort,proto3" json:"remote_port,omitempty"`
-	// Transport protocol ("tcp", "udp").
-	Protocol string `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
-	// Total bytes sent over this edge since last report.
-	BytesSent uint64 `protobuf:"varint,4,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
-	// Total bytes received over this edge since last report.
-	BytesReceived uint64 `protobuf:"varint,5,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
-	// TCP retransmit count since last report (0 on netstat fallback path).
-	Retransmits uint32 `protobuf:"varint,6,opt,name=retransmits,proto3" json:"retransmits,omitempty"`
-	// Smoothed round-trip time in microseconds (0 on netstat fallback path).
+func (x *AgentHistoryEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AgentHistoryEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *AgentHistoryEvent) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentHistoryEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *AgentHistoryEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AgentHistoryEvent) GetMeshIpv4() string {
+	if x != nil {
+		return x.MeshIpv4
+	}
+	return ""
+}
+
+func (x *AgentHistoryEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetTopologyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Time window of observed calls, e.g. "30m" (default: "1h").
+	Since         string `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopologyRequest) Reset() {
+	*x = GetTopologyRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopologyRequest) ProtoMessage() {}
+
+func (x *GetTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopologyRequest.ProtoReflect.Descriptor instead.
+func (*GetTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTopologyRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+type GetTopologyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Colony identifier.
+	ColonyId string `protobuf:"bytes,1,opt,name=colony_id,json=colonyId,proto3" json:"colony_id,omitempty"`
+	// List of agents.
+	Agents []*Agent `protobuf:"bytes,2,rep,name=agents,proto3" json:"agents,omitempty"`
+	// List of connections between components.
+	Connections   []*Connection `protobuf:"bytes,3,rep,name=connections,proto3" json:"connections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopologyResponse) Reset() {
+	*x = GetTopologyResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopologyResponse) ProtoMessage() {}
+
+func (x *GetTopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopologyResponse.ProtoReflect.Descriptor instead.
+func (*GetTopologyResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTopologyResponse) GetColonyId() string {
+	if x != nil {
+		return x.ColonyId
+	}
+	return ""
+}
+
+func (x *GetTopologyResponse) GetAgents() []*Agent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *GetTopologyResponse) GetConnections() []*Connection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+type Connection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source component ID.
+	SourceId string `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	// Target component ID.
+	TargetId string `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	// Connection type ("http", "grpc", "database", "tcp", etc.).
+	ConnectionType string `protobuf:"bytes,3,opt,name=connection_type,json=connectionType,proto3" json:"connection_type,omitempty"`
+	// Evidence layer indicating how this connection was observed (RFD 033).
+	EvidenceLayer EvidenceLayer `protobuf:"varint,4,opt,name=evidence_layer,json=evidenceLayer,proto3,enum=coral.colony.v1.EvidenceLayer" json:"evidence_layer,omitempty"`
+	// Calls observed in the window, from trace data or client metrics. Zero
+	// for L4-only edges.
+	RequestCount int64 `protobuf:"varint,5,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`
+	// Average calls per second over the window.
+	RequestsPerSecond float64 `protobuf:"fixed64,6,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	// Percentage of calls that failed with an HTTP 5xx status.
+	ErrorRate float64 `protobuf:"fixed64,7,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`
+	// 95th percentile latency of the calls, in milliseconds.
+	P95LatencyMs  float64 `protobuf:"fixed64,8,opt,name=p95_latency_ms,json=p95LatencyMs,proto3" json:"p95_latency_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Connection) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *Connection) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *Connection) GetConnectionType() string {
+	if x != nil {
+		return x.ConnectionType
+	}
+	return ""
+}
+
+func (x *Connection) GetEvidenceLayer() EvidenceLayer {
+	if x != nil {
+		return x.EvidenceLayer
+	}
+	return EvidenceLayer_EVIDENCE_LAYER_UNSPECIFIED
+}
+
+func (x *Connection) GetRequestCount() int64 {
+	if x != nil {
+		return x.RequestCount
+	}
+	return 0
+}
+
+func (x *Connection) GetRequestsPerSecond() float64 {
+	if x != nil {
+		return x.RequestsPerSecond
+	}
+	return 0
+}
+
+func (x *Connection) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *Connection) GetP95LatencyMs() float64 {
+	if x != nil {
+		return x.P95LatencyMs
+	}
+	return 0
+}
+
+// ReportConnectionsRequest carries a batch of aggregated outbound L4 connections
+// from a single agent. Agents stream these periodically (default: every 30s).
+type ReportConnectionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reporting agent identifier.
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Aggregated connection entries observed since the last batch.
+	Connections   []*L4ConnectionEntry `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportConnectionsRequest) Reset() {
+	*x = ReportConnectionsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportConnectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportConnectionsRequest) ProtoMessage() {}
+
+func (x *ReportConnectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportConnectionsRequest.ProtoReflect.Descriptor instead.
+func (*ReportConnectionsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReportConnectionsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ReportConnectionsRequest) GetConnections() []*L4ConnectionEntry {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+// ReportConnectionsResponse is the server acknowledgement for ReportConnections.
+// The colony sends this once when the stream closes.
+type ReportConnectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportConnectionsResponse) Reset() {
+	*x = ReportConnectionsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportConnectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportConnectionsResponse) ProtoMessage() {}
+
+func (x *ReportConnectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportConnectionsResponse.ProtoReflect.Descriptor instead.
+func (*ReportConnectionsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{14}
+}
+
+// L4ConnectionEntry represents a single aggregated outbound TCP connection edge.
+// Metrics are accumulated over the reporting interval.
+type L4ConnectionEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Destination IP address.
+	RemoteIp string `protobuf:"bytes,1,opt,name=remote_ip,json=remoteIp,proto3" json:"remote_ip,omitempty"`
+	// Destination port.
+	RemotePort uint32 `protobuf:"varint,2,opt,name=remote_port,json=remotePort,proto3" json:"remote_port,omitempty"`
+	// Transport protocol ("tcp", "udp").
+	Protocol string `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// Total bytes sent over this edge since last report.
+	BytesSent uint64 `protobuf:"varint,4,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	// Total bytes received over this edge since last report.
+	BytesReceived uint64 `protobuf:"varint,5,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	// TCP retransmit count since last report (0 on netstat fallback path).
+	Retransmits uint32 `protobuf:"varint,6,opt,name=retransmits,proto3" json:"retransmits,omitempty"`
+	// Smoothed round-trip time in microseconds (0 on netstat fallback path).
 	RttUs uint32 `protobuf:"varint,7,opt,name=rtt_us,json=rttUs,proto3" json:"rtt_us,omitempty"`
 	// Timestamp of the most recent observation within this batch.
 	LastObserved  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_observed,json=lastObserved,proto3" json:"last_observed,omitempty"`
@@ -808,21 +1417,4573 @@ type L4ConnectionEntry struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *L4ConnectionEntry) Reset() {
-	*x = L4ConnectionEntry{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[10]
+func (x *L4ConnectionEntry) Reset() {
+	*x = L4ConnectionEntry{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *L4ConnectionEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*L4ConnectionEntry) ProtoMessage() {}
+
+func (x *L4ConnectionEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use L4ConnectionEntry.ProtoReflect.Descriptor instead.
+func (*L4ConnectionEntry) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *L4ConnectionEntry) GetRemoteIp() string {
+	if x != nil {
+		return x.RemoteIp
+	}
+	return ""
+}
+
+func (x *L4ConnectionEntry) GetRemotePort() uint32 {
+	if x != nil {
+		return x.RemotePort
+	}
+	return 0
+}
+
+func (x *L4ConnectionEntry) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *L4ConnectionEntry) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *L4ConnectionEntry) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *L4ConnectionEntry) GetRetransmits() uint32 {
+	if x != nil {
+		return x.Retransmits
+	}
+	return 0
+}
+
+func (x *L4ConnectionEntry) GetRttUs() uint32 {
+	if x != nil {
+		return x.RttUs
+	}
+	return 0
+}
+
+func (x *L4ConnectionEntry) GetLastObserved() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastObserved
+	}
+	return nil
+}
+
+// RequestCertificateRequest requests a client certificate using a bootstrap token.
+type RequestCertificateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bootstrap token (JWT).
+	Jwt string `protobuf:"bytes,1,opt,name=jwt,proto3" json:"jwt,omitempty"`
+	// Certificate signing request (PEM-encoded).
+	Csr []byte `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"`
+	// Bootstrap pre-shared key (RFD 088). Required for first-time bootstrap,
+	// ignored for mTLS-authenticated renewals.
+	BootstrapPsk  string `protobuf:"bytes,3,opt,name=bootstrap_psk,json=bootstrapPsk,proto3" json:"bootstrap_psk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestCertificateRequest) Reset() {
+	*x = RequestCertificateRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestCertificateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestCertificateRequest) ProtoMessage() {}
+
+func (x *RequestCertificateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestCertificateRequest.ProtoReflect.Descriptor instead.
+func (*RequestCertificateRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RequestCertificateRequest) GetJwt() string {
+	if x != nil {
+		return x.Jwt
+	}
+	return ""
+}
+
+func (x *RequestCertificateRequest) GetCsr() []byte {
+	if x != nil {
+		return x.Csr
+	}
+	return nil
+}
+
+func (x *RequestCertificateRequest) GetBootstrapPsk() string {
+	if x != nil {
+		return x.BootstrapPsk
+	}
+	return ""
+}
+
+// RequestCertificateResponse returns the issued certificate.
+type RequestCertificateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Client certificate (PEM-encoded).
+	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// CA certificate chain (PEM-encoded).
+	CaChain []byte `protobuf:"bytes,2,opt,name=ca_chain,json=caChain,proto3" json:"ca_chain,omitempty"`
+	// Certificate expiration timestamp (Unix seconds).
+	ExpiresAt     int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestCertificateResponse) Reset() {
+	*x = RequestCertificateResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestCertificateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestCertificateResponse) ProtoMessage() {}
+
+func (x *RequestCertificateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestCertificateResponse.ProtoReflect.Descriptor instead.
+func (*RequestCertificateResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RequestCertificateResponse) GetCertificate() []byte {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *RequestCertificateResponse) GetCaChain() []byte {
+	if x != nil {
+		return x.CaChain
+	}
+	return nil
+}
+
+func (x *RequestCertificateResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// RevokeCertificateRequest revokes a previously issued certificate.
+type RevokeCertificateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Certificate serial number.
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// Revocation reason.
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeCertificateRequest) Reset() {
+	*x = RevokeCertificateRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeCertificateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeCertificateRequest) ProtoMessage() {}
+
+func (x *RevokeCertificateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeCertificateRequest.ProtoReflect.Descriptor instead.
+func (*RevokeCertificateRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RevokeCertificateRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *RevokeCertificateRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// RevokeCertificateResponse confirms certificate revocation.
+type RevokeCertificateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Revocation successful.
+	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeCertificateResponse) Reset() {
+	*x = RevokeCertificateResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeCertificateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeCertificateResponse) ProtoMessage() {}
+
+func (x *RevokeCertificateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeCertificateResponse.ProtoReflect.Descriptor instead.
+func (*RevokeCertificateResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RevokeCertificateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// RevokeAgentRequest revokes all active certificates issued to an agent.
+type RevokeAgentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Agent ID.
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Revocation reason.
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAgentRequest) Reset() {
+	*x = RevokeAgentRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAgentRequest) ProtoMessage() {}
+
+func (x *RevokeAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAgentRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAgentRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RevokeAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *RevokeAgentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// RevokeAgentResponse lists the revoked certificates.
+type RevokeAgentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Serial numbers of the certificates revoked.
+	RevokedSerialNumbers []string `protobuf:"bytes,1,rep,name=revoked_serial_numbers,json=revokedSerialNumbers,proto3" json:"revoked_serial_numbers,omitempty"`
+	// The agent was registered and has been evicted from the registry and
+	// WireGuard peers.
+	Evicted       bool `protobuf:"varint,2,opt,name=evicted,proto3" json:"evicted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAgentResponse) Reset() {
+	*x = RevokeAgentResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAgentResponse) ProtoMessage() {}
+
+func (x *RevokeAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAgentResponse.ProtoReflect.Descriptor instead.
+func (*RevokeAgentResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RevokeAgentResponse) GetRevokedSerialNumbers() []string {
+	if x != nil {
+		return x.RevokedSerialNumbers
+	}
+	return nil
+}
+
+func (x *RevokeAgentResponse) GetEvicted() bool {
+	if x != nil {
+		return x.Evicted
+	}
+	return false
+}
+
+// MintCapabilityTokenRequest requests a capability token.
+type MintCapabilityTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Scopes granted, as "<permission>[:<service>]", e.g. "debug:api".
+	Scopes []string `protobuf:"bytes,1,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Token lifetime (default: 1h, max: 24h).
+	Ttl *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Who the token is for, e.g. a CI job (default: the caller).
+	Subject       string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintCapabilityTokenRequest) Reset() {
+	*x = MintCapabilityTokenRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintCapabilityTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintCapabilityTokenRequest) ProtoMessage() {}
+
+func (x *MintCapabilityTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintCapabilityTokenRequest.ProtoReflect.Descriptor instead.
+func (*MintCapabilityTokenRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MintCapabilityTokenRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *MintCapabilityTokenRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *MintCapabilityTokenRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+// MintCapabilityTokenResponse holds the minted capability token.
+type MintCapabilityTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bearer token, accepted by the colony and agents.
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Token ID, recorded with the operations it authorizes.
+	TokenId string `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	// Subject the token was minted for.
+	Subject string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Scopes granted.
+	Scopes []string `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// Expiry of the token.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MintCapabilityTokenResponse) Reset() {
+	*x = MintCapabilityTokenResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MintCapabilityTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintCapabilityTokenResponse) ProtoMessage() {}
+
+func (x *MintCapabilityTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintCapabilityTokenResponse.ProtoReflect.Descriptor instead.
+func (*MintCapabilityTokenResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *MintCapabilityTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *MintCapabilityTokenResponse) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *MintCapabilityTokenResponse) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *MintCapabilityTokenResponse) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *MintCapabilityTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// RotateColonySecretRequest rotates the colony secret.
+type RotateColonySecretRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// How long the previous secret is still accepted (default: 24h).
+	GracePeriod   *durationpb.Duration `protobuf:"bytes,1,opt,name=grace_period,json=gracePeriod,proto3" json:"grace_period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateColonySecretRequest) Reset() {
+	*x = RotateColonySecretRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateColonySecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateColonySecretRequest) ProtoMessage() {}
+
+func (x *RotateColonySecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateColonySecretRequest.ProtoReflect.Descriptor instead.
+func (*RotateColonySecretRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RotateColonySecretRequest) GetGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.GracePeriod
+	}
+	return nil
+}
+
+// RotateColonySecretResponse reports the rotation.
+type RotateColonySecretResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The new colony secret, to update agent deployments with.
+	ColonySecret string `protobuf:"bytes,1,opt,name=colony_secret,json=colonySecret,proto3" json:"colony_secret,omitempty"`
+	// When the previous secret stops being accepted.
+	PreviousExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=previous_expires_at,json=previousExpiresAt,proto3" json:"previous_expires_at,omitempty"`
+	// Agents that received the new secret.
+	UpdatedAgents []string `protobuf:"bytes,3,rep,name=updated_agents,json=updatedAgents,proto3" json:"updated_agents,omitempty"`
+	// Agents still on the previous secret.
+	StaleAgents   []*StaleColonySecretAgent `protobuf:"bytes,4,rep,name=stale_agents,json=staleAgents,proto3" json:"stale_agents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateColonySecretResponse) Reset() {
+	*x = RotateColonySecretResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateColonySecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateColonySecretResponse) ProtoMessage() {}
+
+func (x *RotateColonySecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateColonySecretResponse.ProtoReflect.Descriptor instead.
+func (*RotateColonySecretResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RotateColonySecretResponse) GetColonySecret() string {
+	if x != nil {
+		return x.ColonySecret
+	}
+	return ""
+}
+
+func (x *RotateColonySecretResponse) GetPreviousExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousExpiresAt
+	}
+	return nil
+}
+
+func (x *RotateColonySecretResponse) GetUpdatedAgents() []string {
+	if x != nil {
+		return x.UpdatedAgents
+	}
+	return nil
+}
+
+func (x *RotateColonySecretResponse) GetStaleAgents() []*StaleColonySecretAgent {
+	if x != nil {
+		return x.StaleAgents
+	}
+	return nil
+}
+
+// StaleColonySecretAgent is an agent the new colony secret could not be
+// pushed to.
+type StaleColonySecretAgent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Agent ID.
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Why the agent did not receive the new secret.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StaleColonySecretAgent) Reset() {
+	*x = StaleColonySecretAgent{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StaleColonySecretAgent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StaleColonySecretAgent) ProtoMessage() {}
+
+func (x *StaleColonySecretAgent) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StaleColonySecretAgent.ProtoReflect.Descriptor instead.
+func (*StaleColonySecretAgent) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StaleColonySecretAgent) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *StaleColonySecretAgent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// AgentArtifact is the agent executable of a release for one platform.
+type AgentArtifact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Operating system, e.g. "linux".
+	Os string `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	// Architecture, e.g. "amd64".
+	Arch string `protobuf:"bytes,2,opt,name=arch,proto3" json:"arch,omitempty"`
+	// Download URL of the executable.
+	Url string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	// Hex-encoded SHA-256 of the executable.
+	Sha256        string `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentArtifact) Reset() {
+	*x = AgentArtifact{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentArtifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentArtifact) ProtoMessage() {}
+
+func (x *AgentArtifact) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentArtifact.ProtoReflect.Descriptor instead.
+func (*AgentArtifact) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AgentArtifact) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *AgentArtifact) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *AgentArtifact) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *AgentArtifact) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+// UpgradeAgentsRequest starts or cancels an agent release rollout. Starting
+// a rollout replaces the current one.
+type UpgradeAgentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Release version, e.g. "v0.5.0".
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Executables of the release, at least one.
+	Artifacts []*AgentArtifact `protobuf:"bytes,2,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// Only upgrade these agents (default: all agents).
+	AgentIds []string `protobuf:"bytes,3,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	// Cancel the current rollout instead. Agents already upgraded are not
+	// downgraded.
+	Cancel        bool `protobuf:"varint,4,opt,name=cancel,proto3" json:"cancel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeAgentsRequest) Reset() {
+	*x = UpgradeAgentsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeAgentsRequest) ProtoMessage() {}
+
+func (x *UpgradeAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeAgentsRequest.ProtoReflect.Descriptor instead.
+func (*UpgradeAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpgradeAgentsRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *UpgradeAgentsRequest) GetArtifacts() []*AgentArtifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *UpgradeAgentsRequest) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *UpgradeAgentsRequest) GetCancel() bool {
+	if x != nil {
+		return x.Cancel
+	}
+	return false
+}
+
+// UpgradeAgentsResponse reports the rollout.
+type UpgradeAgentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Upgrade       *AgentUpgrade          `protobuf:"bytes,1,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeAgentsResponse) Reset() {
+	*x = UpgradeAgentsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeAgentsResponse) ProtoMessage() {}
+
+func (x *UpgradeAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeAgentsResponse.ProtoReflect.Descriptor instead.
+func (*UpgradeAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpgradeAgentsResponse) GetUpgrade() *AgentUpgrade {
+	if x != nil {
+		return x.Upgrade
+	}
+	return nil
+}
+
+type GetAgentUpgradeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentUpgradeRequest) Reset() {
+	*x = GetAgentUpgradeRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentUpgradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentUpgradeRequest) ProtoMessage() {}
+
+func (x *GetAgentUpgradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentUpgradeRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentUpgradeRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{30}
+}
+
+// GetAgentUpgradeResponse reports the rollout.
+type GetAgentUpgradeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Upgrade       *AgentUpgrade          `protobuf:"bytes,1,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentUpgradeResponse) Reset() {
+	*x = GetAgentUpgradeResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentUpgradeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentUpgradeResponse) ProtoMessage() {}
+
+func (x *GetAgentUpgradeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentUpgradeResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentUpgradeResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetAgentUpgradeResponse) GetUpgrade() *AgentUpgrade {
+	if x != nil {
+		return x.Upgrade
+	}
+	return nil
+}
+
+// AgentUpgrade is an agent release rollout.
+type AgentUpgrade struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Release version; empty when no rollout is in progress.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Executables of the release.
+	Artifacts []*AgentArtifact `protobuf:"bytes,2,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// Agents the rollout is restricted to; empty for all agents.
+	AgentIds []string `protobuf:"bytes,3,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	// When the rollout started.
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Progress of the registered agents the rollout targets.
+	Agents        []*AgentUpgradeStatus `protobuf:"bytes,5,rep,name=agents,proto3" json:"agents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentUpgrade) Reset() {
+	*x = AgentUpgrade{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentUpgrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentUpgrade) ProtoMessage() {}
+
+func (x *AgentUpgrade) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentUpgrade.ProtoReflect.Descriptor instead.
+func (*AgentUpgrade) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AgentUpgrade) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AgentUpgrade) GetArtifacts() []*AgentArtifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *AgentUpgrade) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *AgentUpgrade) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *AgentUpgrade) GetAgents() []*AgentUpgradeStatus {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+// AgentUpgradeStatus is the progress of an agent in a rollout.
+type AgentUpgradeStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Agent ID.
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Version the agent last reported.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// "upgraded", "pending", "failed" or "unsupported" (the agent's protocol
+	// version predates agent updates).
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// Why the agent failed to install the release.
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentUpgradeStatus) Reset() {
+	*x = AgentUpgradeStatus{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentUpgradeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentUpgradeStatus) ProtoMessage() {}
+
+func (x *AgentUpgradeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentUpgradeStatus.ProtoReflect.Descriptor instead.
+func (*AgentUpgradeStatus) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AgentUpgradeStatus) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentUpgradeStatus) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AgentUpgradeStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *AgentUpgradeStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ExecAgentsRequest runs a command on the agents matching a selector.
+// Agents must match both the selector and the agent IDs if both are given.
+type ExecAgentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Label selector, e.g. "env=prod,region=us-east".
+	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Agent IDs.
+	AgentIds []string `protobuf:"bytes,2,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	// Maximum number of agents running the command at once (default: 10).
+	Concurrency int32 `protobuf:"varint,3,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// Command as array (no shell interpretation).
+	Command []string `protobuf:"bytes,4,rep,name=command,proto3" json:"command,omitempty"`
+	// Timeout of the command on each agent in seconds (default: 30, max: 300).
+	TimeoutSeconds uint32 `protobuf:"varint,5,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	// Ignored: the colony reports the user of the caller's API token to the
+	// agents' audit logs.
+	UserId        string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecAgentsRequest) Reset() {
+	*x = ExecAgentsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecAgentsRequest) ProtoMessage() {}
+
+func (x *ExecAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ExecAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExecAgentsRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *ExecAgentsRequest) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *ExecAgentsRequest) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *ExecAgentsRequest) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *ExecAgentsRequest) GetTimeoutSeconds() uint32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ExecAgentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExecAgentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Result of each targeted agent, ordered by agent ID.
+	Results       []*BulkAgentResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecAgentsResponse) Reset() {
+	*x = ExecAgentsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecAgentsResponse) ProtoMessage() {}
+
+func (x *ExecAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ExecAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ExecAgentsResponse) GetResults() []*BulkAgentResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// DrainAgentsRequest drains or undrains the agents matching a selector.
+type DrainAgentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Label selector, e.g. "env=prod,region=us-east".
+	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Agent IDs.
+	AgentIds []string `protobuf:"bytes,2,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	// Undrain the agents instead.
+	Undrain       bool `protobuf:"varint,3,opt,name=undrain,proto3" json:"undrain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrainAgentsRequest) Reset() {
+	*x = DrainAgentsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainAgentsRequest) ProtoMessage() {}
+
+func (x *DrainAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainAgentsRequest.ProtoReflect.Descriptor instead.
+func (*DrainAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DrainAgentsRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *DrainAgentsRequest) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *DrainAgentsRequest) GetUndrain() bool {
+	if x != nil {
+		return x.Undrain
+	}
+	return false
+}
+
+type DrainAgentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Result of each targeted agent, ordered by agent ID.
+	Results       []*BulkAgentResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DrainAgentsResponse) Reset() {
+	*x = DrainAgentsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DrainAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainAgentsResponse) ProtoMessage() {}
+
+func (x *DrainAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainAgentsResponse.ProtoReflect.Descriptor instead.
+func (*DrainAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DrainAgentsResponse) GetResults() []*BulkAgentResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// LabelAgentsRequest sets or removes labels of the agents matching a
+// selector.
+type LabelAgentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Label selector, e.g. "env=prod,region=us-east".
+	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Agent IDs.
+	AgentIds []string `protobuf:"bytes,2,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	// Labels to set.
+	Set map[string]string `protobuf:"bytes,3,rep,name=set,proto3" json:"set,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Keys of the labels to remove.
+	Remove        []string `protobuf:"bytes,4,rep,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelAgentsRequest) Reset() {
+	*x = LabelAgentsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelAgentsRequest) ProtoMessage() {}
+
+func (x *LabelAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelAgentsRequest.ProtoReflect.Descriptor instead.
+func (*LabelAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *LabelAgentsRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *LabelAgentsRequest) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *LabelAgentsRequest) GetSet() map[string]string {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *LabelAgentsRequest) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+type LabelAgentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Result of each targeted agent, ordered by agent ID.
+	Results       []*BulkAgentResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelAgentsResponse) Reset() {
+	*x = LabelAgentsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelAgentsResponse) ProtoMessage() {}
+
+func (x *LabelAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelAgentsResponse.ProtoReflect.Descriptor instead.
+func (*LabelAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *LabelAgentsResponse) GetResults() []*BulkAgentResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// BulkAgentResult is the outcome of a bulk operation on an agent.
+type BulkAgentResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Agent ID.
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// The operation succeeded on the agent. A command succeeded if it exited
+	// with code 0.
+	Success bool `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	// Why the operation failed on the agent.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// Exit code, output and duration of the command (ExecAgents).
+	ExitCode   int32  `protobuf:"varint,4,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	Stdout     []byte `protobuf:"bytes,5,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr     []byte `protobuf:"bytes,6,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	DurationMs uint32 `protobuf:"varint,7,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Labels of the agent after the operation (LabelAgents).
+	Labels        map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkAgentResult) Reset() {
+	*x = BulkAgentResult{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkAgentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAgentResult) ProtoMessage() {}
+
+func (x *BulkAgentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAgentResult.ProtoReflect.Descriptor instead.
+func (*BulkAgentResult) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *BulkAgentResult) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *BulkAgentResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BulkAgentResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BulkAgentResult) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *BulkAgentResult) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *BulkAgentResult) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *BulkAgentResult) GetDurationMs() uint32 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *BulkAgentResult) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type GetCAStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCAStatusRequest) Reset() {
+	*x = GetCAStatusRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCAStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCAStatusRequest) ProtoMessage() {}
+
+func (x *GetCAStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCAStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetCAStatusRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{41}
+}
+
+type GetCAStatusResponse struct {
+	state              protoimpl.MessageState          `protogen:"open.v1"`
+	RootCa             *GetCAStatusResponse_CertStatus `protobuf:"bytes,1,opt,name=root_ca,json=rootCa,proto3" json:"root_ca,omitempty"`
+	ServerIntermediate *GetCAStatusResponse_CertStatus `protobuf:"bytes,2,opt,name=server_intermediate,json=serverIntermediate,proto3" json:"server_intermediate,omitempty"`
+	AgentIntermediate  *GetCAStatusResponse_CertStatus `protobuf:"bytes,3,opt,name=agent_intermediate,json=agentIntermediate,proto3" json:"agent_intermediate,omitempty"`
+	PolicySigning      *GetCAStatusResponse_CertStatus `protobuf:"bytes,4,opt,name=policy_signing,json=policySigning,proto3" json:"policy_signing,omitempty"`
+	ColonySpiffeId     string                          `protobuf:"bytes,5,opt,name=colony_spiffe_id,json=colonySpiffeId,proto3" json:"colony_spiffe_id,omitempty"`
+	Statistics         *GetCAStatusResponse_Stats      `protobuf:"bytes,6,opt,name=statistics,proto3" json:"statistics,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetCAStatusResponse) Reset() {
+	*x = GetCAStatusResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCAStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCAStatusResponse) ProtoMessage() {}
+
+func (x *GetCAStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCAStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetCAStatusResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetCAStatusResponse) GetRootCa() *GetCAStatusResponse_CertStatus {
+	if x != nil {
+		return x.RootCa
+	}
+	return nil
+}
+
+func (x *GetCAStatusResponse) GetServerIntermediate() *GetCAStatusResponse_CertStatus {
+	if x != nil {
+		return x.ServerIntermediate
+	}
+	return nil
+}
+
+func (x *GetCAStatusResponse) GetAgentIntermediate() *GetCAStatusResponse_CertStatus {
+	if x != nil {
+		return x.AgentIntermediate
+	}
+	return nil
+}
+
+func (x *GetCAStatusResponse) GetPolicySigning() *GetCAStatusResponse_CertStatus {
+	if x != nil {
+		return x.PolicySigning
+	}
+	return nil
+}
+
+func (x *GetCAStatusResponse) GetColonySpiffeId() string {
+	if x != nil {
+		return x.ColonySpiffeId
+	}
+	return ""
+}
+
+func (x *GetCAStatusResponse) GetStatistics() *GetCAStatusResponse_Stats {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+type MeshPingRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Target agent ID. If empty, pings all connected agents.
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Number of pings to send for each agent.
+	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Timeout for each ping response in milliseconds.
+	TimeoutMs     int32 `protobuf:"varint,3,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshPingRequest) Reset() {
+	*x = MeshPingRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshPingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshPingRequest) ProtoMessage() {}
+
+func (x *MeshPingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshPingRequest.ProtoReflect.Descriptor instead.
+func (*MeshPingRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *MeshPingRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *MeshPingRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *MeshPingRequest) GetTimeoutMs() int32 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+type MeshPingResponse struct {
+	state         protoimpl.MessageState              `protogen:"open.v1"`
+	Results       []*MeshPingResponse_AgentPingResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshPingResponse) Reset() {
+	*x = MeshPingResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshPingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshPingResponse) ProtoMessage() {}
+
+func (x *MeshPingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshPingResponse.ProtoReflect.Descriptor instead.
+func (*MeshPingResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *MeshPingResponse) GetResults() []*MeshPingResponse_AgentPingResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type MeshAuditRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Target agent ID. If empty, audits all connected agents.
+	AgentId       string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshAuditRequest) Reset() {
+	*x = MeshAuditRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshAuditRequest) ProtoMessage() {}
+
+func (x *MeshAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshAuditRequest.ProtoReflect.Descriptor instead.
+func (*MeshAuditRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *MeshAuditRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type MeshAuditResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Results       []*MeshAuditAgentResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshAuditResponse) Reset() {
+	*x = MeshAuditResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshAuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshAuditResponse) ProtoMessage() {}
+
+func (x *MeshAuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshAuditResponse.ProtoReflect.Descriptor instead.
+func (*MeshAuditResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *MeshAuditResponse) GetResults() []*MeshAuditAgentResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type MeshAuditAgentResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Agent identifier.
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Agent's mesh IP.
+	MeshIp string `protobuf:"bytes,2,opt,name=mesh_ip,json=meshIp,proto3" json:"mesh_ip,omitempty"`
+	// Endpoint Colony's WireGuard currently observes (live UAPI).
+	// Empty if no packets received yet.
+	ColonyObservedEndpoint string `protobuf:"bytes,3,opt,name=colony_observed_endpoint,json=colonyObservedEndpoint,proto3" json:"colony_observed_endpoint,omitempty"`
+	// Endpoint the agent announced at registration (STUN-discovered).
+	// Empty if agent registered without STUN (roaming mode).
+	AgentRegisteredEndpoint string `protobuf:"bytes,4,opt,name=agent_registered_endpoint,json=agentRegisteredEndpoint,proto3" json:"agent_registered_endpoint,omitempty"`
+	// NAT assessment: "direct", "symmetric", "relayed", "roaming", "no_handshake", "unexpected", "error".
+	NatType string `protobuf:"bytes,5,opt,name=nat_type,json=natType,proto3" json:"nat_type,omitempty"`
+	// Seconds since last WireGuard handshake. -1 if no handshake ever.
+	HandshakeAgeSeconds int64 `protobuf:"varint,6,opt,name=handshake_age_seconds,json=handshakeAgeSeconds,proto3" json:"handshake_age_seconds,omitempty"`
+	// Bytes received by Colony from this agent.
+	RxBytes int64 `protobuf:"varint,7,opt,name=rx_bytes,json=rxBytes,proto3" json:"rx_bytes,omitempty"`
+	// Bytes sent by Colony to this agent.
+	TxBytes int64 `protobuf:"varint,8,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	// Error message if this agent could not be assessed.
+	Error         string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshAuditAgentResult) Reset() {
+	*x = MeshAuditAgentResult{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshAuditAgentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshAuditAgentResult) ProtoMessage() {}
+
+func (x *MeshAuditAgentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshAuditAgentResult.ProtoReflect.Descriptor instead.
+func (*MeshAuditAgentResult) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *MeshAuditAgentResult) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *MeshAuditAgentResult) GetMeshIp() string {
+	if x != nil {
+		return x.MeshIp
+	}
+	return ""
+}
+
+func (x *MeshAuditAgentResult) GetColonyObservedEndpoint() string {
+	if x != nil {
+		return x.ColonyObservedEndpoint
+	}
+	return ""
+}
+
+func (x *MeshAuditAgentResult) GetAgentRegisteredEndpoint() string {
+	if x != nil {
+		return x.AgentRegisteredEndpoint
+	}
+	return ""
+}
+
+func (x *MeshAuditAgentResult) GetNatType() string {
+	if x != nil {
+		return x.NatType
+	}
+	return ""
+}
+
+func (x *MeshAuditAgentResult) GetHandshakeAgeSeconds() int64 {
+	if x != nil {
+		return x.HandshakeAgeSeconds
+	}
+	return 0
+}
+
+func (x *MeshAuditAgentResult) GetRxBytes() int64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
+}
+
+func (x *MeshAuditAgentResult) GetTxBytes() int64 {
+	if x != nil {
+		return x.TxBytes
+	}
+	return 0
+}
+
+func (x *MeshAuditAgentResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type SubscribeEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event types to receive. Empty receives all types.
+	Types []ColonyEventType `protobuf:"varint,1,rep,packed,name=types,proto3,enum=coral.colony.v1.ColonyEventType" json:"types,omitempty"`
+	// Only receive events for this service (optional).
+	ServiceName string `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Only receive events for this agent (optional).
+	AgentId string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Replay recent events kept by the colony (bounded history) first.
+	Replay bool `protobuf:"varint,4,opt,name=replay,proto3" json:"replay,omitempty"`
+	// Keep streaming new events. When false, the stream ends after the replay.
+	Follow        bool `protobuf:"varint,5,opt,name=follow,proto3" json:"follow,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SubscribeEventsRequest) GetTypes() []ColonyEventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *SubscribeEventsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *SubscribeEventsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *SubscribeEventsRequest) GetReplay() bool {
+	if x != nil {
+		return x.Replay
+	}
+	return false
+}
+
+func (x *SubscribeEventsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+type ColonyEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Type      ColonyEventType        `protobuf:"varint,1,opt,name=type,proto3,enum=coral.colony.v1.ColonyEventType" json:"type,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Agent the event relates to, if any.
+	AgentId string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Service the event relates to, if any.
+	ServiceName string `protobuf:"bytes,4,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Debug session the event relates to, if any.
+	SessionId string `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Human-readable summary.
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// Event-specific details, e.g. "profile_type" or "expires_at".
+	Attributes    map[string]string `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyEvent) Reset() {
+	*x = ColonyEvent{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyEvent) ProtoMessage() {}
+
+func (x *ColonyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyEvent.ProtoReflect.Descriptor instead.
+func (*ColonyEvent) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ColonyEvent) GetType() ColonyEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ColonyEventType_COLONY_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ColonyEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ColonyEvent) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ColonyEvent) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ColonyEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ColonyEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ColonyEvent) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// LogLine is a line written by a service, collected by its agent.
+type LogLine struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the line was written, or collected if the source has no timestamps.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Agent that collected the line.
+	AgentId string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Service that wrote the line.
+	Service string `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	// Where the line was read: "stdout", "stderr", "journald" or a file path.
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// The line, without its trailing newline.
+	Line          string `protobuf:"bytes,5,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLine) Reset() {
+	*x = LogLine{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLine) ProtoMessage() {}
+
+func (x *LogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLine.ProtoReflect.Descriptor instead.
+func (*LogLine) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *LogLine) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *LogLine) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *LogLine) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *LogLine) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *LogLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+type ReportLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reporting agent.
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Collected lines, oldest first.
+	Lines []*LogLine `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	// Lines dropped by the agent since its previous report because the colony
+	// was unreachable or too slow.
+	Dropped       uint64 `protobuf:"varint,3,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportLogsRequest) Reset() {
+	*x = ReportLogsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportLogsRequest) ProtoMessage() {}
+
+func (x *ReportLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportLogsRequest.ProtoReflect.Descriptor instead.
+func (*ReportLogsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ReportLogsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ReportLogsRequest) GetLines() []*LogLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ReportLogsRequest) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type ReportLogsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of lines added to the ring buffer.
+	LinesAccepted int64 `protobuf:"varint,1,opt,name=lines_accepted,json=linesAccepted,proto3" json:"lines_accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportLogsResponse) Reset() {
+	*x = ReportLogsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportLogsResponse) ProtoMessage() {}
+
+func (x *ReportLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportLogsResponse.ProtoReflect.Descriptor instead.
+func (*ReportLogsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ReportLogsResponse) GetLinesAccepted() int64 {
+	if x != nil {
+		return x.LinesAccepted
+	}
+	return 0
+}
+
+type TailLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only return lines of this service (optional).
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Only return lines collected by this agent (optional).
+	AgentId string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Only return lines matching this RE2 regular expression (optional).
+	Grep string `protobuf:"bytes,3,opt,name=grep,proto3" json:"grep,omitempty"`
+	// Number of buffered lines to return first (default: 50).
+	Lines int32 `protobuf:"varint,4,opt,name=lines,proto3" json:"lines,omitempty"`
+	// Keep streaming new lines. When false, the stream ends after the
+	// buffered lines.
+	Follow bool `protobuf:"varint,5,opt,name=follow,proto3" json:"follow,omitempty"`
+	// Only return lines newer than this time (optional). Used to resume a
+	// follow stream without repeating lines.
+	Since         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TailLogsRequest) Reset() {
+	*x = TailLogsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TailLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailLogsRequest) ProtoMessage() {}
+
+func (x *TailLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailLogsRequest.ProtoReflect.Descriptor instead.
+func (*TailLogsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *TailLogsRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *TailLogsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TailLogsRequest) GetGrep() string {
+	if x != nil {
+		return x.Grep
+	}
+	return ""
+}
+
+func (x *TailLogsRequest) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+func (x *TailLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+func (x *TailLogsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type GetIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIdentityRequest) Reset() {
+	*x = GetIdentityRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIdentityRequest) ProtoMessage() {}
+
+func (x *GetIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIdentityRequest.ProtoReflect.Descriptor instead.
+func (*GetIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{54}
+}
+
+type GetIdentityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True if the request carried a valid API token.
+	Authenticated bool `protobuf:"varint,1,opt,name=authenticated,proto3" json:"authenticated,omitempty"`
+	// Token ID, user and role of the authenticated token.
+	TokenId string `protobuf:"bytes,2,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	User    string `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Role    string `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	// Effective permissions of the token, including those granted by its role.
+	Permissions []string `protobuf:"bytes,5,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	// True if the colony requires a token with the matching role for actions
+	// (mcp.security.require_rbac_for_actions).
+	RbacForActions bool `protobuf:"varint,6,opt,name=rbac_for_actions,json=rbacForActions,proto3" json:"rbac_for_actions,omitempty"`
+	// MCP tools whose calls the colony holds for a human decision
+	// (mcp.security.require_approval).
+	ApprovalRequiredTools []string `protobuf:"bytes,7,rep,name=approval_required_tools,json=approvalRequiredTools,proto3" json:"approval_required_tools,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetIdentityResponse) Reset() {
+	*x = GetIdentityResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIdentityResponse) ProtoMessage() {}
+
+func (x *GetIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIdentityResponse.ProtoReflect.Descriptor instead.
+func (*GetIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetIdentityResponse) GetAuthenticated() bool {
+	if x != nil {
+		return x.Authenticated
+	}
+	return false
+}
+
+func (x *GetIdentityResponse) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *GetIdentityResponse) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *GetIdentityResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GetIdentityResponse) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *GetIdentityResponse) GetRbacForActions() bool {
+	if x != nil {
+		return x.RbacForActions
+	}
+	return false
+}
+
+func (x *GetIdentityResponse) GetApprovalRequiredTools() []string {
+	if x != nil {
+		return x.ApprovalRequiredTools
+	}
+	return nil
+}
+
+// Entry of the append-only control-plane audit log.
+type AuditEvent struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Who performed the action: token user or ID, else the caller's address.
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// What was done, e.g. "AttachUprobe", "ProfileCPU", "ContainerExec" or
+	// "MCPToolCall".
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// What the action applied to, e.g. "service=api function=main.handle".
+	Target string `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`
+	// SHA-256 of the action's arguments (hex).
+	ArgumentsHash string `protobuf:"bytes,6,opt,name=arguments_hash,json=argumentsHash,proto3" json:"arguments_hash,omitempty"`
+	// "ok" or the error code.
+	Result string `protobuf:"bytes,7,opt,name=result,proto3" json:"result,omitempty"`
+	// Error message when the action failed.
+	Error         string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *AuditEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetArgumentsHash() string {
+	if x != nil {
+		return x.ArgumentsHash
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RecordAuditEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	ArgumentsHash string                 `protobuf:"bytes,3,opt,name=arguments_hash,json=argumentsHash,proto3" json:"arguments_hash,omitempty"`
+	Result        string                 `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// Local user running the client. Only used when the request carries no
+	// API token.
+	User          string `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordAuditEventRequest) Reset() {
+	*x = RecordAuditEventRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordAuditEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordAuditEventRequest) ProtoMessage() {}
+
+func (x *RecordAuditEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordAuditEventRequest.ProtoReflect.Descriptor instead.
+func (*RecordAuditEventRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RecordAuditEventRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *RecordAuditEventRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *RecordAuditEventRequest) GetArgumentsHash() string {
+	if x != nil {
+		return x.ArgumentsHash
+	}
+	return ""
+}
+
+func (x *RecordAuditEventRequest) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *RecordAuditEventRequest) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RecordAuditEventRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type RecordAuditEventResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// False if auditing is disabled (mcp.security.audit_enabled).
+	Recorded      bool `protobuf:"varint,1,opt,name=recorded,proto3" json:"recorded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordAuditEventResponse) Reset() {
+	*x = RecordAuditEventResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordAuditEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordAuditEventResponse) ProtoMessage() {}
+
+func (x *RecordAuditEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordAuditEventResponse.ProtoReflect.Descriptor instead.
+func (*RecordAuditEventResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RecordAuditEventResponse) GetRecorded() bool {
+	if x != nil {
+		return x.Recorded
+	}
+	return false
+}
+
+type ListAuditEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only return entries at or after this time (optional).
+	Since *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	// Only return entries for this action (optional).
+	Action string `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	// Only return entries for this actor (optional).
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Maximum number of entries (default 100).
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListAuditEventsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListAuditEventsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entries, newest first.
+	Events []*AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// True if auditing is enabled (mcp.security.audit_enabled).
+	Enabled       bool `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// MCP tool call held for a human decision (mcp.security.require_approval).
+type MCPApproval struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Tool requiring approval, e.g. "coral_shell_exec".
+	Tool string `protobuf:"bytes,2,opt,name=tool,proto3" json:"tool,omitempty"`
+	// The coral command the call runs, e.g. "shell --agent api -- ls /tmp".
+	Command string `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	// Who made the call: the token user, else the client's user and address.
+	Requester string                 `protobuf:"bytes,4,opt,name=requester,proto3" json:"requester,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// When a pending call expires unless decided.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// "pending", "approved", "denied" or "expired".
+	Status string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	// Who decided, when, and why (optional).
+	DecidedBy     string                 `protobuf:"bytes,8,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"`
+	DecidedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	Reason        string                 `protobuf:"bytes,10,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MCPApproval) Reset() {
+	*x = MCPApproval{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MCPApproval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MCPApproval) ProtoMessage() {}
+
+func (x *MCPApproval) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MCPApproval.ProtoReflect.Descriptor instead.
+func (*MCPApproval) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *MCPApproval) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MCPApproval) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *MCPApproval) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *MCPApproval) GetRequester() string {
+	if x != nil {
+		return x.Requester
+	}
+	return ""
+}
+
+func (x *MCPApproval) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MCPApproval) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *MCPApproval) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MCPApproval) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+func (x *MCPApproval) GetDecidedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return nil
+}
+
+func (x *MCPApproval) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CreateMCPApprovalRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Tool    string                 `protobuf:"bytes,1,opt,name=tool,proto3" json:"tool,omitempty"`
+	Command string                 `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	// Local user running the client. Only used when the request carries no
+	// API token.
+	User          string `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMCPApprovalRequest) Reset() {
+	*x = CreateMCPApprovalRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMCPApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMCPApprovalRequest) ProtoMessage() {}
+
+func (x *CreateMCPApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMCPApprovalRequest.ProtoReflect.Descriptor instead.
+func (*CreateMCPApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CreateMCPApprovalRequest) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *CreateMCPApprovalRequest) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *CreateMCPApprovalRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type CreateMCPApprovalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approval      *MCPApproval           `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMCPApprovalResponse) Reset() {
+	*x = CreateMCPApprovalResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMCPApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMCPApprovalResponse) ProtoMessage() {}
+
+func (x *CreateMCPApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMCPApprovalResponse.ProtoReflect.Descriptor instead.
+func (*CreateMCPApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *CreateMCPApprovalResponse) GetApproval() *MCPApproval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+type GetMCPApprovalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMCPApprovalRequest) Reset() {
+	*x = GetMCPApprovalRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMCPApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMCPApprovalRequest) ProtoMessage() {}
+
+func (x *GetMCPApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMCPApprovalRequest.ProtoReflect.Descriptor instead.
+func (*GetMCPApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetMCPApprovalRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetMCPApprovalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approval      *MCPApproval           `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMCPApprovalResponse) Reset() {
+	*x = GetMCPApprovalResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMCPApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMCPApprovalResponse) ProtoMessage() {}
+
+func (x *GetMCPApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMCPApprovalResponse.ProtoReflect.Descriptor instead.
+func (*GetMCPApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetMCPApprovalResponse) GetApproval() *MCPApproval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+type ListMCPApprovalsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only return approvals with this status (optional).
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMCPApprovalsRequest) Reset() {
+	*x = ListMCPApprovalsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMCPApprovalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMCPApprovalsRequest) ProtoMessage() {}
+
+func (x *ListMCPApprovalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMCPApprovalsRequest.ProtoReflect.Descriptor instead.
+func (*ListMCPApprovalsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListMCPApprovalsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListMCPApprovalsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Approvals, oldest first.
+	Approvals     []*MCPApproval `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMCPApprovalsResponse) Reset() {
+	*x = ListMCPApprovalsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMCPApprovalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMCPApprovalsResponse) ProtoMessage() {}
+
+func (x *ListMCPApprovalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMCPApprovalsResponse.ProtoReflect.Descriptor instead.
+func (*ListMCPApprovalsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListMCPApprovalsResponse) GetApprovals() []*MCPApproval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+type DecideMCPApprovalRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// True to run the call, false to reject it.
+	Approve bool `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	// Shown to the requester (optional).
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Local user deciding. Only used when the request carries no API token.
+	User          string `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideMCPApprovalRequest) Reset() {
+	*x = DecideMCPApprovalRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideMCPApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideMCPApprovalRequest) ProtoMessage() {}
+
+func (x *DecideMCPApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideMCPApprovalRequest.ProtoReflect.Descriptor instead.
+func (*DecideMCPApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DecideMCPApprovalRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DecideMCPApprovalRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+func (x *DecideMCPApprovalRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DecideMCPApprovalRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type DecideMCPApprovalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approval      *MCPApproval           `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideMCPApprovalResponse) Reset() {
+	*x = DecideMCPApprovalResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideMCPApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideMCPApprovalResponse) ProtoMessage() {}
+
+func (x *DecideMCPApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideMCPApprovalResponse.ProtoReflect.Descriptor instead.
+func (*DecideMCPApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *DecideMCPApprovalResponse) GetApproval() *MCPApproval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+// An MCP tool call recorded in the tool call history. Unlike the audit log,
+// the history keeps the call's arguments so that it can be replayed.
+type MCPToolCall struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// MCP client that made the call, from its clientInfo (e.g.
+	// "claude-ai 0.1.0"), or the transport if it sent none.
+	Client string `protobuf:"bytes,3,opt,name=client,proto3" json:"client,omitempty"`
+	// User the call ran as: token user, or the local user of a stdio proxy.
+	Actor string `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	// MCP tool name, e.g. "coral_cli".
+	Tool string `protobuf:"bytes,5,opt,name=tool,proto3" json:"tool,omitempty"`
+	// Tool arguments as sent by the client (JSON object).
+	ArgumentsJson string `protobuf:"bytes,6,opt,name=arguments_json,json=argumentsJson,proto3" json:"arguments_json,omitempty"`
+	// coral CLI arguments the call ran.
+	Command    []string `protobuf:"bytes,7,rep,name=command,proto3" json:"command,omitempty"`
+	DurationMs float64  `protobuf:"fixed64,8,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// Size of the full result, before pagination.
+	ResultBytes int64 `protobuf:"varint,9,opt,name=result_bytes,json=resultBytes,proto3" json:"result_bytes,omitempty"`
+	// "ok", "permission_denied" or "failed".
+	Result string `protobuf:"bytes,10,opt,name=result,proto3" json:"result,omitempty"`
+	// Error message when the call was denied or failed.
+	Error         string `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MCPToolCall) Reset() {
+	*x = MCPToolCall{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MCPToolCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MCPToolCall) ProtoMessage() {}
+
+func (x *MCPToolCall) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MCPToolCall.ProtoReflect.Descriptor instead.
+func (*MCPToolCall) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *MCPToolCall) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MCPToolCall) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *MCPToolCall) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *MCPToolCall) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *MCPToolCall) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *MCPToolCall) GetArgumentsJson() string {
+	if x != nil {
+		return x.ArgumentsJson
+	}
+	return ""
+}
+
+func (x *MCPToolCall) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *MCPToolCall) GetDurationMs() float64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *MCPToolCall) GetResultBytes() int64 {
+	if x != nil {
+		return x.ResultBytes
+	}
+	return 0
+}
+
+func (x *MCPToolCall) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *MCPToolCall) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RecordMCPToolCallRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id and timestamp are ignored; the colony assigns them. actor is only
+	// used when the request carries no API token.
+	Call          *MCPToolCall `protobuf:"bytes,1,opt,name=call,proto3" json:"call,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordMCPToolCallRequest) Reset() {
+	*x = RecordMCPToolCallRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordMCPToolCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordMCPToolCallRequest) ProtoMessage() {}
+
+func (x *RecordMCPToolCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordMCPToolCallRequest.ProtoReflect.Descriptor instead.
+func (*RecordMCPToolCallRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *RecordMCPToolCallRequest) GetCall() *MCPToolCall {
+	if x != nil {
+		return x.Call
+	}
+	return nil
+}
+
+type RecordMCPToolCallResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordMCPToolCallResponse) Reset() {
+	*x = RecordMCPToolCallResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordMCPToolCallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordMCPToolCallResponse) ProtoMessage() {}
+
+func (x *RecordMCPToolCallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordMCPToolCallResponse.ProtoReflect.Descriptor instead.
+func (*RecordMCPToolCallResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{72}
+}
+
+type ListMCPToolCallsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only return calls at or after this time (optional).
+	Since *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	// Only return calls to this tool (optional).
+	Tool string `protobuf:"bytes,2,opt,name=tool,proto3" json:"tool,omitempty"`
+	// Only return calls by this actor (optional).
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Only return calls from this client (optional).
+	Client string `protobuf:"bytes,4,opt,name=client,proto3" json:"client,omitempty"`
+	// Maximum number of calls (default 50).
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMCPToolCallsRequest) Reset() {
+	*x = ListMCPToolCallsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMCPToolCallsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMCPToolCallsRequest) ProtoMessage() {}
+
+func (x *ListMCPToolCallsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMCPToolCallsRequest.ProtoReflect.Descriptor instead.
+func (*ListMCPToolCallsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListMCPToolCallsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListMCPToolCallsRequest) GetTool() string {
+	if x != nil {
+		return x.Tool
+	}
+	return ""
+}
+
+func (x *ListMCPToolCallsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListMCPToolCallsRequest) GetClient() string {
+	if x != nil {
+		return x.Client
+	}
+	return ""
+}
+
+func (x *ListMCPToolCallsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListMCPToolCallsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Calls, newest first.
+	Calls         []*MCPToolCall `protobuf:"bytes,1,rep,name=calls,proto3" json:"calls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMCPToolCallsResponse) Reset() {
+	*x = ListMCPToolCallsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMCPToolCallsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMCPToolCallsResponse) ProtoMessage() {}
+
+func (x *ListMCPToolCallsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMCPToolCallsResponse.ProtoReflect.Descriptor instead.
+func (*ListMCPToolCallsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListMCPToolCallsResponse) GetCalls() []*MCPToolCall {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+type GetMCPToolCallRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMCPToolCallRequest) Reset() {
+	*x = GetMCPToolCallRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMCPToolCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMCPToolCallRequest) ProtoMessage() {}
+
+func (x *GetMCPToolCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMCPToolCallRequest.ProtoReflect.Descriptor instead.
+func (*GetMCPToolCallRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetMCPToolCallRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetMCPToolCallResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Call          *MCPToolCall           `protobuf:"bytes,1,opt,name=call,proto3" json:"call,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMCPToolCallResponse) Reset() {
+	*x = GetMCPToolCallResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMCPToolCallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMCPToolCallResponse) ProtoMessage() {}
+
+func (x *GetMCPToolCallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMCPToolCallResponse.ProtoReflect.Descriptor instead.
+func (*GetMCPToolCallResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *GetMCPToolCallResponse) GetCall() *MCPToolCall {
+	if x != nil {
+		return x.Call
+	}
+	return nil
+}
+
+// Rule that fires when a service metric exceeds a threshold.
+type AlertRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Service the rule applies to. Empty applies it to every service.
+	ServiceName string `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Metric compared to the threshold: "latency_p95" (milliseconds),
+	// "error_rate" (percent of requests) or "heap_growth" (percent growth of
+	// allocated bytes over the previous window).
+	Metric string `protobuf:"bytes,4,opt,name=metric,proto3" json:"metric,omitempty"`
+	// The rule fires while the metric is above the threshold.
+	Threshold float64 `protobuf:"fixed64,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Time window the metric is computed over.
+	Window *durationpb.Duration `protobuf:"bytes,6,opt,name=window,proto3" json:"window,omitempty"`
+	// Names of the notification sinks (alerting.sinks in the colony config).
+	Sinks           []string               `protobuf:"bytes,7,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	Enabled         bool                   `protobuf:"varint,8,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastEvaluatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_evaluated_at,json=lastEvaluatedAt,proto3" json:"last_evaluated_at,omitempty"`
+	// Services the rule is currently firing for.
+	Firing        []*AlertFiring `protobuf:"bytes,11,rep,name=firing,proto3" json:"firing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertRule) Reset() {
+	*x = AlertRule{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertRule) ProtoMessage() {}
+
+func (x *AlertRule) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertRule.ProtoReflect.Descriptor instead.
+func (*AlertRule) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *AlertRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AlertRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AlertRule) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *AlertRule) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *AlertRule) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *AlertRule) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *AlertRule) GetSinks() []string {
+	if x != nil {
+		return x.Sinks
+	}
+	return nil
+}
+
+func (x *AlertRule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *AlertRule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AlertRule) GetLastEvaluatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastEvaluatedAt
+	}
+	return nil
+}
+
+func (x *AlertRule) GetFiring() []*AlertFiring {
+	if x != nil {
+		return x.Firing
+	}
+	return nil
+}
+
+// A service an alert rule is firing for.
+type AlertFiring struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Metric value at the last evaluation.
+	Value float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	// When the rule started firing for the service.
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AlertFiring) Reset() {
+	*x = AlertFiring{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlertFiring) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlertFiring) ProtoMessage() {}
+
+func (x *AlertFiring) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlertFiring.ProtoReflect.Descriptor instead.
+func (*AlertFiring) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *AlertFiring) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *AlertFiring) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *AlertFiring) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type CreateAlertRuleRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ServiceName string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Metric      string                 `protobuf:"bytes,3,opt,name=metric,proto3" json:"metric,omitempty"`
+	Threshold   float64                `protobuf:"fixed64,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Default: 5m.
+	Window        *durationpb.Duration `protobuf:"bytes,5,opt,name=window,proto3" json:"window,omitempty"`
+	Sinks         []string             `protobuf:"bytes,6,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertRuleRequest) Reset() {
+	*x = CreateAlertRuleRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRuleRequest) ProtoMessage() {}
+
+func (x *CreateAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*CreateAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *CreateAlertRuleRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAlertRuleRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *CreateAlertRuleRequest) GetMetric() string {
+	if x != nil {
+		return x.Metric
+	}
+	return ""
+}
+
+func (x *CreateAlertRuleRequest) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *CreateAlertRuleRequest) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *CreateAlertRuleRequest) GetSinks() []string {
+	if x != nil {
+		return x.Sinks
+	}
+	return nil
+}
+
+type CreateAlertRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *AlertRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAlertRuleResponse) Reset() {
+	*x = CreateAlertRuleResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAlertRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAlertRuleResponse) ProtoMessage() {}
+
+func (x *CreateAlertRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAlertRuleResponse.ProtoReflect.Descriptor instead.
+func (*CreateAlertRuleResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *CreateAlertRuleResponse) GetRule() *AlertRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type ListAlertRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesRequest) Reset() {
+	*x = ListAlertRulesRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesRequest) ProtoMessage() {}
+
+func (x *ListAlertRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{81}
+}
+
+type ListAlertRulesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rules []*AlertRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Names of the configured notification sinks.
+	Sinks         []string `protobuf:"bytes,2,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAlertRulesResponse) Reset() {
+	*x = ListAlertRulesResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAlertRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAlertRulesResponse) ProtoMessage() {}
+
+func (x *ListAlertRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAlertRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListAlertRulesResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ListAlertRulesResponse) GetRules() []*AlertRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *ListAlertRulesResponse) GetSinks() []string {
+	if x != nil {
+		return x.Sinks
+	}
+	return nil
+}
+
+type DeleteAlertRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAlertRuleRequest) Reset() {
+	*x = DeleteAlertRuleRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAlertRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAlertRuleRequest) ProtoMessage() {}
+
+func (x *DeleteAlertRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAlertRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAlertRuleRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *DeleteAlertRuleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteAlertRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAlertRuleResponse) Reset() {
+	*x = DeleteAlertRuleResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAlertRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAlertRuleResponse) ProtoMessage() {}
+
+func (x *DeleteAlertRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAlertRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAlertRuleResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{84}
+}
+
+type SetAlertRuleEnabledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAlertRuleEnabledRequest) Reset() {
+	*x = SetAlertRuleEnabledRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAlertRuleEnabledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAlertRuleEnabledRequest) ProtoMessage() {}
+
+func (x *SetAlertRuleEnabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAlertRuleEnabledRequest.ProtoReflect.Descriptor instead.
+func (*SetAlertRuleEnabledRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *SetAlertRuleEnabledRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetAlertRuleEnabledRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetAlertRuleEnabledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAlertRuleEnabledResponse) Reset() {
+	*x = SetAlertRuleEnabledResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *L4ConnectionEntry) String() string {
+func (x *SetAlertRuleEnabledResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*L4ConnectionEntry) ProtoMessage() {}
+func (*SetAlertRuleEnabledResponse) ProtoMessage() {}
 
-func (x *L4ConnectionEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[10]
+func (x *SetAlertRuleEnabledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -833,96 +5994,195 @@ func (x *L4ConnectionEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use L4ConnectionEntry.ProtoReflect.Descriptor instead.
-func (*L4ConnectionEntry) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use SetAlertRuleEnabledResponse.ProtoReflect.Descriptor instead.
+func (*SetAlertRuleEnabledResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *L4ConnectionEntry) GetRemoteIp() string {
+// Named set of services targeted together, e.g. "checkout-path" for the
+// services serving checkout.
+type ServiceGroup struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Services    []string               `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Defined in service_groups of the colony config. Such groups cannot be
+	// changed or deleted through the API.
+	FromConfig bool `protobuf:"varint,4,opt,name=from_config,json=fromConfig,proto3" json:"from_config,omitempty"`
+	// When the group was last set through the API; unset for config groups.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceGroup) Reset() {
+	*x = ServiceGroup{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceGroup) ProtoMessage() {}
+
+func (x *ServiceGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[87]
 	if x != nil {
-		return x.RemoteIp
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceGroup.ProtoReflect.Descriptor instead.
+func (*ServiceGroup) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ServiceGroup) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *L4ConnectionEntry) GetRemotePort() uint32 {
+func (x *ServiceGroup) GetServices() []string {
 	if x != nil {
-		return x.RemotePort
+		return x.Services
 	}
-	return 0
+	return nil
 }
 
-func (x *L4ConnectionEntry) GetProtocol() string {
+func (x *ServiceGroup) GetDescription() string {
 	if x != nil {
-		return x.Protocol
+		return x.Description
 	}
 	return ""
 }
 
-func (x *L4ConnectionEntry) GetBytesSent() uint64 {
+func (x *ServiceGroup) GetFromConfig() bool {
 	if x != nil {
-		return x.BytesSent
+		return x.FromConfig
 	}
-	return 0
+	return false
 }
 
-func (x *L4ConnectionEntry) GetBytesReceived() uint64 {
+func (x *ServiceGroup) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.BytesReceived
+		return x.UpdatedAt
 	}
-	return 0
+	return nil
 }
 
-func (x *L4ConnectionEntry) GetRetransmits() uint32 {
+type ListServiceGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceGroupsRequest) Reset() {
+	*x = ListServiceGroupsRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceGroupsRequest) ProtoMessage() {}
+
+func (x *ListServiceGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[88]
 	if x != nil {
-		return x.Retransmits
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *L4ConnectionEntry) GetRttUs() uint32 {
+// Deprecated: Use ListServiceGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListServiceGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{88}
+}
+
+type ListServiceGroupsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Groups ordered by name.
+	Groups        []*ServiceGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListServiceGroupsResponse) Reset() {
+	*x = ListServiceGroupsResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListServiceGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListServiceGroupsResponse) ProtoMessage() {}
+
+func (x *ListServiceGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[89]
 	if x != nil {
-		return x.RttUs
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *L4ConnectionEntry) GetLastObserved() *timestamppb.Timestamp {
+// Deprecated: Use ListServiceGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListServiceGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListServiceGroupsResponse) GetGroups() []*ServiceGroup {
 	if x != nil {
-		return x.LastObserved
+		return x.Groups
 	}
 	return nil
 }
 
-// RequestCertificateRequest requests a client certificate using a bootstrap token.
-type RequestCertificateRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Bootstrap token (JWT).
-	Jwt string `protobuf:"bytes,1,opt,name=jwt,proto3" json:"jwt,omitempty"`
-	// Certificate signing request (PEM-encoded).
-	Csr []byte `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"`
-	// Bootstrap pre-shared key (RFD 088). Required for first-time bootstrap,
-	// ignored for mTLS-authenticated renewals.
-	BootstrapPsk  string `protobuf:"bytes,3,opt,name=bootstrap_psk,json=bootstrapPsk,proto3" json:"bootstrap_psk,omitempty"`
+type GetServiceGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RequestCertificateRequest) Reset() {
-	*x = RequestCertificateRequest{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[11]
+func (x *GetServiceGroupRequest) Reset() {
+	*x = GetServiceGroupRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RequestCertificateRequest) String() string {
+func (x *GetServiceGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RequestCertificateRequest) ProtoMessage() {}
+func (*GetServiceGroupRequest) ProtoMessage() {}
 
-func (x *RequestCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[11]
+func (x *GetServiceGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -933,60 +6193,86 @@ func (x *RequestCertificateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RequestCertificateRequest.ProtoReflect.Descriptor instead.
-func (*RequestCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use GetServiceGroupRequest.ProtoReflect.Descriptor instead.
+func (*GetServiceGroupRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *RequestCertificateRequest) GetJwt() string {
+func (x *GetServiceGroupRequest) GetName() string {
 	if x != nil {
-		return x.Jwt
+		return x.Name
 	}
 	return ""
 }
 
-func (x *RequestCertificateRequest) GetCsr() []byte {
+type GetServiceGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *ServiceGroup          `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServiceGroupResponse) Reset() {
+	*x = GetServiceGroupResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServiceGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceGroupResponse) ProtoMessage() {}
+
+func (x *GetServiceGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[91]
 	if x != nil {
-		return x.Csr
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *RequestCertificateRequest) GetBootstrapPsk() string {
+// Deprecated: Use GetServiceGroupResponse.ProtoReflect.Descriptor instead.
+func (*GetServiceGroupResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetServiceGroupResponse) GetGroup() *ServiceGroup {
 	if x != nil {
-		return x.BootstrapPsk
+		return x.Group
 	}
-	return ""
+	return nil
 }
 
-// RequestCertificateResponse returns the issued certificate.
-type RequestCertificateResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Client certificate (PEM-encoded).
-	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
-	// CA certificate chain (PEM-encoded).
-	CaChain []byte `protobuf:"bytes,2,opt,name=ca_chain,json=caChain,proto3" json:"ca_chain,omitempty"`
-	// Certificate expiration timestamp (Unix seconds).
-	ExpiresAt     int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+type SetServiceGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Services      []string               `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RequestCertificateResponse) Reset() {
-	*x = RequestCertificateResponse{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[12]
+func (x *SetServiceGroupRequest) Reset() {
+	*x = SetServiceGroupRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RequestCertificateResponse) String() string {
+func (x *SetServiceGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RequestCertificateResponse) ProtoMessage() {}
+func (*SetServiceGroupRequest) ProtoMessage() {}
 
-func (x *RequestCertificateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[12]
+func (x *SetServiceGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -997,58 +6283,54 @@ func (x *RequestCertificateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RequestCertificateResponse.ProtoReflect.Descriptor instead.
-func (*RequestCertificateResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use SetServiceGroupRequest.ProtoReflect.Descriptor instead.
+func (*SetServiceGroupRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *RequestCertificateResponse) GetCertificate() []byte {
+func (x *SetServiceGroupRequest) GetName() string {
 	if x != nil {
-		return x.Certificate
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *RequestCertificateResponse) GetCaChain() []byte {
+func (x *SetServiceGroupRequest) GetServices() []string {
 	if x != nil {
-		return x.CaChain
+		return x.Services
 	}
 	return nil
 }
 
-func (x *RequestCertificateResponse) GetExpiresAt() int64 {
+func (x *SetServiceGroupRequest) GetDescription() string {
 	if x != nil {
-		return x.ExpiresAt
+		return x.Description
 	}
-	return 0
+	return ""
 }
 
-// RevokeCertificateRequest revokes a previously issued certificate.
-type RevokeCertificateRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Certificate serial number.
-	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
-	// Revocation reason.
-	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+type SetServiceGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *ServiceGroup          `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RevokeCertificateRequest) Reset() {
-	*x = RevokeCertificateRequest{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[13]
+func (x *SetServiceGroupResponse) Reset() {
+	*x = SetServiceGroupResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RevokeCertificateRequest) String() string {
+func (x *SetServiceGroupResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RevokeCertificateRequest) ProtoMessage() {}
+func (*SetServiceGroupResponse) ProtoMessage() {}
 
-func (x *RevokeCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[13]
+func (x *SetServiceGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1059,49 +6341,40 @@ func (x *RevokeCertificateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RevokeCertificateRequest.ProtoReflect.Descriptor instead.
-func (*RevokeCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *RevokeCertificateRequest) GetSerialNumber() string {
-	if x != nil {
-		return x.SerialNumber
-	}
-	return ""
+// Deprecated: Use SetServiceGroupResponse.ProtoReflect.Descriptor instead.
+func (*SetServiceGroupResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *RevokeCertificateRequest) GetReason() string {
+func (x *SetServiceGroupResponse) GetGroup() *ServiceGroup {
 	if x != nil {
-		return x.Reason
+		return x.Group
 	}
-	return ""
+	return nil
 }
 
-// RevokeCertificateResponse confirms certificate revocation.
-type RevokeCertificateResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Revocation successful.
-	Success       bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+type DeleteServiceGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RevokeCertificateResponse) Reset() {
-	*x = RevokeCertificateResponse{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[14]
+func (x *DeleteServiceGroupRequest) Reset() {
+	*x = DeleteServiceGroupRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RevokeCertificateResponse) String() string {
+func (x *DeleteServiceGroupRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RevokeCertificateResponse) ProtoMessage() {}
+func (*DeleteServiceGroupRequest) ProtoMessage() {}
 
-func (x *RevokeCertificateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[14]
+func (x *DeleteServiceGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1112,39 +6385,39 @@ func (x *RevokeCertificateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RevokeCertificateResponse.ProtoReflect.Descriptor instead.
-func (*RevokeCertificateResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use DeleteServiceGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteServiceGroupRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *RevokeCertificateResponse) GetSuccess() bool {
+func (x *DeleteServiceGroupRequest) GetName() string {
 	if x != nil {
-		return x.Success
+		return x.Name
 	}
-	return false
+	return ""
 }
 
-type GetCAStatusRequest struct {
+type DeleteServiceGroupResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCAStatusRequest) Reset() {
-	*x = GetCAStatusRequest{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[15]
+func (x *DeleteServiceGroupResponse) Reset() {
+	*x = DeleteServiceGroupResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCAStatusRequest) String() string {
+func (x *DeleteServiceGroupResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCAStatusRequest) ProtoMessage() {}
+func (*DeleteServiceGroupResponse) ProtoMessage() {}
 
-func (x *GetCAStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[15]
+func (x *DeleteServiceGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1155,38 +6428,42 @@ func (x *GetCAStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCAStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetCAStatusRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use DeleteServiceGroupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteServiceGroupResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{95}
 }
 
-type GetCAStatusResponse struct {
-	state              protoimpl.MessageState          `protogen:"open.v1"`
-	RootCa             *GetCAStatusResponse_CertStatus `protobuf:"bytes,1,opt,name=root_ca,json=rootCa,proto3" json:"root_ca,omitempty"`
-	ServerIntermediate *GetCAStatusResponse_CertStatus `protobuf:"bytes,2,opt,name=server_intermediate,json=serverIntermediate,proto3" json:"server_intermediate,omitempty"`
-	AgentIntermediate  *GetCAStatusResponse_CertStatus `protobuf:"bytes,3,opt,name=agent_intermediate,json=agentIntermediate,proto3" json:"agent_intermediate,omitempty"`
-	PolicySigning      *GetCAStatusResponse_CertStatus `protobuf:"bytes,4,opt,name=policy_signing,json=policySigning,proto3" json:"policy_signing,omitempty"`
-	ColonySpiffeId     string                          `protobuf:"bytes,5,opt,name=colony_spiffe_id,json=colonySpiffeId,proto3" json:"colony_spiffe_id,omitempty"`
-	Statistics         *GetCAStatusResponse_Stats      `protobuf:"bytes,6,opt,name=statistics,proto3" json:"statistics,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+// A service freeze blocks new debug and profiling sessions against a
+// service, e.g. during an incident or a load test.
+type ServiceFreeze struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Service string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// When the freeze expires.
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Why the service is frozen, e.g. "load test".
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Who froze the service.
+	FrozenBy      string                 `protobuf:"bytes,4,opt,name=frozen_by,json=frozenBy,proto3" json:"frozen_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCAStatusResponse) Reset() {
-	*x = GetCAStatusResponse{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[16]
+func (x *ServiceFreeze) Reset() {
+	*x = ServiceFreeze{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCAStatusResponse) String() string {
+func (x *ServiceFreeze) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCAStatusResponse) ProtoMessage() {}
+func (*ServiceFreeze) ProtoMessage() {}
 
-func (x *GetCAStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[16]
+func (x *ServiceFreeze) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1197,80 +6474,74 @@ func (x *GetCAStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCAStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetCAStatusResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *GetCAStatusResponse) GetRootCa() *GetCAStatusResponse_CertStatus {
-	if x != nil {
-		return x.RootCa
-	}
-	return nil
+// Deprecated: Use ServiceFreeze.ProtoReflect.Descriptor instead.
+func (*ServiceFreeze) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *GetCAStatusResponse) GetServerIntermediate() *GetCAStatusResponse_CertStatus {
+func (x *ServiceFreeze) GetService() string {
 	if x != nil {
-		return x.ServerIntermediate
+		return x.Service
 	}
-	return nil
+	return ""
 }
 
-func (x *GetCAStatusResponse) GetAgentIntermediate() *GetCAStatusResponse_CertStatus {
+func (x *ServiceFreeze) GetExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AgentIntermediate
+		return x.ExpiresAt
 	}
 	return nil
 }
 
-func (x *GetCAStatusResponse) GetPolicySigning() *GetCAStatusResponse_CertStatus {
+func (x *ServiceFreeze) GetReason() string {
 	if x != nil {
-		return x.PolicySigning
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-func (x *GetCAStatusResponse) GetColonySpiffeId() string {
+func (x *ServiceFreeze) GetFrozenBy() string {
 	if x != nil {
-		return x.ColonySpiffeId
+		return x.FrozenBy
 	}
 	return ""
 }
 
-func (x *GetCAStatusResponse) GetStatistics() *GetCAStatusResponse_Stats {
+func (x *ServiceFreeze) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Statistics
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type MeshPingRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Target agent ID. If empty, pings all connected agents.
-	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	// Number of pings to send for each agent.
-	Count int32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
-	// Timeout for each ping response in milliseconds.
-	TimeoutMs     int32 `protobuf:"varint,3,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+type FreezeServiceRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Service string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// How long the service stays frozen.
+	Duration *durationpb.Duration `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	Reason   string               `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Local user running the client. Only used when the request carries no
+	// API token.
+	User          string `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MeshPingRequest) Reset() {
-	*x = MeshPingRequest{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[17]
+func (x *FreezeServiceRequest) Reset() {
+	*x = FreezeServiceRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MeshPingRequest) String() string {
+func (x *FreezeServiceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MeshPingRequest) ProtoMessage() {}
+func (*FreezeServiceRequest) ProtoMessage() {}
 
-func (x *MeshPingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[17]
+func (x *FreezeServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1281,54 +6552,61 @@ func (x *MeshPingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MeshPingRequest.ProtoReflect.Descriptor instead.
-func (*MeshPingRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use FreezeServiceRequest.ProtoReflect.Descriptor instead.
+func (*FreezeServiceRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *MeshPingRequest) GetAgentId() string {
+func (x *FreezeServiceRequest) GetService() string {
 	if x != nil {
-		return x.AgentId
+		return x.Service
 	}
 	return ""
 }
 
-func (x *MeshPingRequest) GetCount() int32 {
+func (x *FreezeServiceRequest) GetDuration() *durationpb.Duration {
 	if x != nil {
-		return x.Count
+		return x.Duration
 	}
-	return 0
+	return nil
 }
 
-func (x *MeshPingRequest) GetTimeoutMs() int32 {
+func (x *FreezeServiceRequest) GetReason() string {
 	if x != nil {
-		return x.TimeoutMs
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-type MeshPingResponse struct {
-	state         protoimpl.MessageState              `protogen:"open.v1"`
-	Results       []*MeshPingResponse_AgentPingResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+func (x *FreezeServiceRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+type FreezeServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Freeze        *ServiceFreeze         `protobuf:"bytes,1,opt,name=freeze,proto3" json:"freeze,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MeshPingResponse) Reset() {
-	*x = MeshPingResponse{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[18]
+func (x *FreezeServiceResponse) Reset() {
+	*x = FreezeServiceResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MeshPingResponse) String() string {
+func (x *FreezeServiceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MeshPingResponse) ProtoMessage() {}
+func (*FreezeServiceResponse) ProtoMessage() {}
 
-func (x *MeshPingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[18]
+func (x *FreezeServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1339,41 +6617,40 @@ func (x *MeshPingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MeshPingResponse.ProtoReflect.Descriptor instead.
-func (*MeshPingResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use FreezeServiceResponse.ProtoReflect.Descriptor instead.
+func (*FreezeServiceResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *MeshPingResponse) GetResults() []*MeshPingResponse_AgentPingResult {
+func (x *FreezeServiceResponse) GetFreeze() *ServiceFreeze {
 	if x != nil {
-		return x.Results
+		return x.Freeze
 	}
 	return nil
 }
 
-type MeshAuditRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Target agent ID. If empty, audits all connected agents.
-	AgentId       string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+type UnfreezeServiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MeshAuditRequest) Reset() {
-	*x = MeshAuditRequest{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[19]
+func (x *UnfreezeServiceRequest) Reset() {
+	*x = UnfreezeServiceRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MeshAuditRequest) String() string {
+func (x *UnfreezeServiceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MeshAuditRequest) ProtoMessage() {}
+func (*UnfreezeServiceRequest) ProtoMessage() {}
 
-func (x *MeshAuditRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[19]
+func (x *UnfreezeServiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1384,40 +6661,39 @@ func (x *MeshAuditRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MeshAuditRequest.ProtoReflect.Descriptor instead.
-func (*MeshAuditRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use UnfreezeServiceRequest.ProtoReflect.Descriptor instead.
+func (*UnfreezeServiceRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *MeshAuditRequest) GetAgentId() string {
+func (x *UnfreezeServiceRequest) GetService() string {
 	if x != nil {
-		return x.AgentId
+		return x.Service
 	}
 	return ""
 }
 
-type MeshAuditResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Results       []*MeshAuditAgentResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+type UnfreezeServiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MeshAuditResponse) Reset() {
-	*x = MeshAuditResponse{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[20]
+func (x *UnfreezeServiceResponse) Reset() {
+	*x = UnfreezeServiceResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MeshAuditResponse) String() string {
+func (x *UnfreezeServiceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MeshAuditResponse) ProtoMessage() {}
+func (*UnfreezeServiceResponse) ProtoMessage() {}
 
-func (x *MeshAuditResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[20]
+func (x *UnfreezeServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1428,59 +6704,32 @@ func (x *MeshAuditResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MeshAuditResponse.ProtoReflect.Descriptor instead.
-func (*MeshAuditResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *MeshAuditResponse) GetResults() []*MeshAuditAgentResult {
-	if x != nil {
-		return x.Results
-	}
-	return nil
+// Deprecated: Use UnfreezeServiceResponse.ProtoReflect.Descriptor instead.
+func (*UnfreezeServiceResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{100}
 }
 
-type MeshAuditAgentResult struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Agent identifier.
-	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	// Agent's mesh IP.
-	MeshIp string `protobuf:"bytes,2,opt,name=mesh_ip,json=meshIp,proto3" json:"mesh_ip,omitempty"`
-	// Endpoint Colony's WireGuard currently observes (live UAPI).
-	// Empty if no packets received yet.
-	ColonyObservedEndpoint string `protobuf:"bytes,3,opt,name=colony_observed_endpoint,json=colonyObservedEndpoint,proto3" json:"colony_observed_endpoint,omitempty"`
-	// Endpoint the agent announced at registration (STUN-discovered).
-	// Empty if agent registered without STUN (roaming mode).
-	AgentRegisteredEndpoint string `protobuf:"bytes,4,opt,name=agent_registered_endpoint,json=agentRegisteredEndpoint,proto3" json:"agent_registered_endpoint,omitempty"`
-	// NAT assessment: "direct", "symmetric", "roaming", "no_handshake", "unexpected", "error".
-	NatType string `protobuf:"bytes,5,opt,name=nat_type,json=natType,proto3" json:"nat_type,omitempty"`
-	// Seconds since last WireGuard handshake. -1 if no handshake ever.
-	HandshakeAgeSeconds int64 `protobuf:"varint,6,opt,name=handshake_age_seconds,json=handshakeAgeSeconds,proto3" json:"handshake_age_seconds,omitempty"`
-	// Bytes received by Colony from this agent.
-	RxBytes int64 `protobuf:"varint,7,opt,name=rx_bytes,json=rxBytes,proto3" json:"rx_bytes,omitempty"`
-	// Bytes sent by Colony to this agent.
-	TxBytes int64 `protobuf:"varint,8,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
-	// Error message if this agent could not be assessed.
-	Error         string `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+type ListServiceFreezesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MeshAuditAgentResult) Reset() {
-	*x = MeshAuditAgentResult{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[21]
+func (x *ListServiceFreezesRequest) Reset() {
+	*x = ListServiceFreezesRequest{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MeshAuditAgentResult) String() string {
+func (x *ListServiceFreezesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MeshAuditAgentResult) ProtoMessage() {}
+func (*ListServiceFreezesRequest) ProtoMessage() {}
 
-func (x *MeshAuditAgentResult) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[21]
+func (x *ListServiceFreezesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1491,72 +6740,54 @@ func (x *MeshAuditAgentResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MeshAuditAgentResult.ProtoReflect.Descriptor instead.
-func (*MeshAuditAgentResult) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *MeshAuditAgentResult) GetAgentId() string {
-	if x != nil {
-		return x.AgentId
-	}
-	return ""
-}
-
-func (x *MeshAuditAgentResult) GetMeshIp() string {
-	if x != nil {
-		return x.MeshIp
-	}
-	return ""
+// Deprecated: Use ListServiceFreezesRequest.ProtoReflect.Descriptor instead.
+func (*ListServiceFreezesRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *MeshAuditAgentResult) GetColonyObservedEndpoint() string {
-	if x != nil {
-		return x.ColonyObservedEndpoint
-	}
-	return ""
+type ListServiceFreezesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Active freezes ordered by service.
+	Freezes       []*ServiceFreeze `protobuf:"bytes,1,rep,name=freezes,proto3" json:"freezes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MeshAuditAgentResult) GetAgentRegisteredEndpoint() string {
-	if x != nil {
-		return x.AgentRegisteredEndpoint
-	}
-	return ""
+func (x *ListServiceFreezesResponse) Reset() {
+	*x = ListServiceFreezesResponse{}
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *MeshAuditAgentResult) GetNatType() string {
-	if x != nil {
-		return x.NatType
-	}
-	return ""
+func (x *ListServiceFreezesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *MeshAuditAgentResult) GetHandshakeAgeSeconds() int64 {
-	if x != nil {
-		return x.HandshakeAgeSeconds
-	}
-	return 0
-}
+func (*ListServiceFreezesResponse) ProtoMessage() {}
 
-func (x *MeshAuditAgentResult) GetRxBytes() int64 {
+func (x *ListServiceFreezesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[102]
 	if x != nil {
-		return x.RxBytes
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *MeshAuditAgentResult) GetTxBytes() int64 {
-	if x != nil {
-		return x.TxBytes
-	}
-	return 0
+// Deprecated: Use ListServiceFreezesResponse.ProtoReflect.Descriptor instead.
+func (*ListServiceFreezesResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{102}
 }
 
-func (x *MeshAuditAgentResult) GetError() string {
+func (x *ListServiceFreezesResponse) GetFreezes() []*ServiceFreeze {
 	if x != nil {
-		return x.Error
+		return x.Freezes
 	}
-	return ""
+	return nil
 }
 
 type GetCAStatusResponse_CertStatus struct {
@@ -1571,7 +6802,7 @@ type GetCAStatusResponse_CertStatus struct {
 
 func (x *GetCAStatusResponse_CertStatus) Reset() {
 	*x = GetCAStatusResponse_CertStatus{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[22]
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1583,7 +6814,7 @@ func (x *GetCAStatusResponse_CertStatus) String() string {
 func (*GetCAStatusResponse_CertStatus) ProtoMessage() {}
 
 func (x *GetCAStatusResponse_CertStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[22]
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1596,7 +6827,7 @@ func (x *GetCAStatusResponse_CertStatus) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCAStatusResponse_CertStatus.ProtoReflect.Descriptor instead.
 func (*GetCAStatusResponse_CertStatus) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{16, 0}
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{42, 0}
 }
 
 func (x *GetCAStatusResponse_CertStatus) GetPath() string {
@@ -1638,7 +6869,7 @@ type GetCAStatusResponse_Stats struct {
 
 func (x *GetCAStatusResponse_Stats) Reset() {
 	*x = GetCAStatusResponse_Stats{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[23]
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1650,7 +6881,7 @@ func (x *GetCAStatusResponse_Stats) String() string {
 func (*GetCAStatusResponse_Stats) ProtoMessage() {}
 
 func (x *GetCAStatusResponse_Stats) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[23]
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1663,7 +6894,7 @@ func (x *GetCAStatusResponse_Stats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCAStatusResponse_Stats.ProtoReflect.Descriptor instead.
 func (*GetCAStatusResponse_Stats) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{16, 1}
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{42, 1}
 }
 
 func (x *GetCAStatusResponse_Stats) GetTotalIssued() int32 {
@@ -1704,7 +6935,7 @@ type MeshPingResponse_AgentPingResult struct {
 
 func (x *MeshPingResponse_AgentPingResult) Reset() {
 	*x = MeshPingResponse_AgentPingResult{}
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[24]
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1716,7 +6947,7 @@ func (x *MeshPingResponse_AgentPingResult) String() string {
 func (*MeshPingResponse_AgentPingResult) ProtoMessage() {}
 
 func (x *MeshPingResponse_AgentPingResult) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_colony_proto_msgTypes[24]
+	mi := &file_coral_colony_v1_colony_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1729,7 +6960,7 @@ func (x *MeshPingResponse_AgentPingResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MeshPingResponse_AgentPingResult.ProtoReflect.Descriptor instead.
 func (*MeshPingResponse_AgentPingResult) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{18, 0}
+	return file_coral_colony_v1_colony_proto_rawDescGZIP(), []int{44, 0}
 }
 
 func (x *MeshPingResponse_AgentPingResult) GetAgentId() string {
@@ -1799,7 +7030,7 @@ var File_coral_colony_v1_colony_proto protoreflect.FileDescriptor
 
 const file_coral_colony_v1_colony_proto_rawDesc = "" +
 	"\n" +
-	"\x1ccoral/colony/v1/colony.proto\x12\x0fcoral.colony.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18coral/mesh/v1/auth.proto\x1a\x1acoral/agent/v1/agent.proto\x1a\x19coral/colony/v1/mcp.proto\x1a\x1dcoral/colony/v1/queries.proto\x1a\x1ecoral/network/v1/network.proto\"\x12\n" +
+	"\x1ccoral/colony/v1/colony.proto\x12\x0fcoral.colony.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x18coral/mesh/v1/auth.proto\x1a\x1acoral/agent/v1/agent.proto\x1a\x19coral/colony/v1/mcp.proto\x1a\x1dcoral/colony/v1/queries.proto\x1a\x1ecoral/network/v1/network.proto\"\x12\n" +
 	"\x10GetStatusRequest\"\x88\x06\n" +
 	"\x11GetStatusResponse\x12\x1b\n" +
 	"\tcolony_id\x18\x01 \x01(\tR\bcolonyId\x12\x19\n" +
@@ -1823,10 +7054,14 @@ const file_coral_colony_v1_colony_proto_rawDesc = "" +
 	"\x12active_agent_count\x18\x10 \x01(\x05R\x10activeAgentCount\x120\n" +
 	"\x14degraded_agent_count\x18\x11 \x01(\x05R\x12degradedAgentCount\x12.\n" +
 	"\x13public_endpoint_url\x18\x12 \x01(\tR\x11publicEndpointUrl\x12=\n" +
-	"\twireguard\x18\x13 \x01(\v2\x1f.coral.network.v1.MeshTelemetryR\twireguard\"\x13\n" +
-	"\x11ListAgentsRequest\"D\n" +
+	"\twireguard\x18\x13 \x01(\v2\x1f.coral.network.v1.MeshTelemetryR\twireguard\"M\n" +
+	"\x11ListAgentsRequest\x12\x1c\n" +
+	"\tfederated\x18\x01 \x01(\bR\tfederated\x12\x1a\n" +
+	"\bselector\x18\x02 \x01(\tR\bselector\"\xbe\x01\n" +
 	"\x12ListAgentsResponse\x12.\n" +
-	"\x06agents\x18\x01 \x03(\v2\x16.coral.colony.v1.AgentR\x06agents\"\xe1\x02\n" +
+	"\x06agents\x18\x01 \x03(\v2\x16.coral.colony.v1.AgentR\x06agents\x12M\n" +
+	"\x11federation_errors\x18\x02 \x03(\v2 .coral.colony.v1.FederationErrorR\x10federationErrors\x12)\n" +
+	"\x10protocol_version\x18\x03 \x01(\rR\x0fprotocolVersion\"\xab\x06\n" +
 	"\x05Agent\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12)\n" +
 	"\x0ecomponent_name\x18\x02 \x01(\tB\x02\x18\x01R\rcomponentName\x12\x1b\n" +
@@ -1835,18 +7070,65 @@ const file_coral_colony_v1_colony_proto_rawDesc = "" +
 	"\tlast_seen\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x16\n" +
 	"\x06status\x18\x06 \x01(\tR\x06status\x126\n" +
 	"\bservices\x18\a \x03(\v2\x1a.coral.mesh.v1.ServiceInfoR\bservices\x12O\n" +
-	"\x0fruntime_context\x18\b \x01(\v2&.coral.agent.v1.RuntimeContextResponseR\x0eruntimeContext\"\x14\n" +
-	"\x12GetTopologyRequest\"\xa1\x01\n" +
+	"\x0fruntime_context\x18\b \x01(\v2&.coral.agent.v1.RuntimeContextResponseR\x0eruntimeContext\x12M\n" +
+	"\x11resource_shedding\x18\t \x01(\v2 .coral.agent.v1.ResourceSheddingR\x10resourceShedding\x12!\n" +
+	"\fhealth_score\x18\n" +
+	" \x01(\x05R\vhealthScore\x12%\n" +
+	"\x0ehealth_reasons\x18\v \x03(\tR\rhealthReasons\x12\x1b\n" +
+	"\tcolony_id\x18\f \x01(\tR\bcolonyId\x12)\n" +
+	"\x10protocol_version\x18\r \x01(\rR\x0fprotocolVersion\x12V\n" +
+	"\x14missing_capabilities\x18\x0e \x03(\v2#.coral.colony.v1.ProtocolCapabilityR\x13missingCapabilities\x12:\n" +
+	"\x06labels\x18\x0f \x03(\v2\".coral.colony.v1.Agent.LabelsEntryR\x06labels\x12\x18\n" +
+	"\adrained\x18\x10 \x01(\bR\adrained\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"`\n" +
+	"\x12ProtocolCapability\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\rR\x05since\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"{\n" +
+	"\x16GetAgentHistoryRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x93\x01\n" +
+	"\x17GetAgentHistoryResponse\x12<\n" +
+	"\x06agents\x18\x01 \x03(\v2$.coral.colony.v1.AgentHistorySummaryR\x06agents\x12:\n" +
+	"\x06events\x18\x02 \x03(\v2\".coral.colony.v1.AgentHistoryEventR\x06events\"\xa7\x02\n" +
+	"\x13AgentHistorySummary\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x129\n" +
+	"\n" +
+	"first_seen\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x129\n" +
+	"\n" +
+	"last_event\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tlastEvent\x12\x1a\n" +
+	"\bconnects\x18\x04 \x01(\x05R\bconnects\x12 \n" +
+	"\vdisconnects\x18\x05 \x01(\x05R\vdisconnects\x12%\n" +
+	"\x0estatus_changes\x18\x06 \x01(\x05R\rstatusChanges\x12\x1a\n" +
+	"\bflapping\x18\a \x01(\bR\bflapping\"\xdd\x01\n" +
+	"\x11AgentHistoryEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05event\x18\x04 \x01(\tR\x05event\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1b\n" +
+	"\tmesh_ipv4\x18\x06 \x01(\tR\bmeshIpv4\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\"*\n" +
+	"\x12GetTopologyRequest\x12\x14\n" +
+	"\x05since\x18\x01 \x01(\tR\x05since\"\xa1\x01\n" +
 	"\x13GetTopologyResponse\x12\x1b\n" +
 	"\tcolony_id\x18\x01 \x01(\tR\bcolonyId\x12.\n" +
 	"\x06agents\x18\x02 \x03(\v2\x16.coral.colony.v1.AgentR\x06agents\x12=\n" +
-	"\vconnections\x18\x03 \x03(\v2\x1b.coral.colony.v1.ConnectionR\vconnections\"\xb6\x01\n" +
+	"\vconnections\x18\x03 \x03(\v2\x1b.coral.colony.v1.ConnectionR\vconnections\"\xd0\x02\n" +
 	"\n" +
 	"Connection\x12\x1b\n" +
 	"\tsource_id\x18\x01 \x01(\tR\bsourceId\x12\x1b\n" +
 	"\ttarget_id\x18\x02 \x01(\tR\btargetId\x12'\n" +
 	"\x0fconnection_type\x18\x03 \x01(\tR\x0econnectionType\x12E\n" +
-	"\x0eevidence_layer\x18\x04 \x01(\x0e2\x1e.coral.colony.v1.EvidenceLayerR\revidenceLayer\"{\n" +
+	"\x0eevidence_layer\x18\x04 \x01(\x0e2\x1e.coral.colony.v1.EvidenceLayerR\revidenceLayer\x12#\n" +
+	"\rrequest_count\x18\x05 \x01(\x03R\frequestCount\x12.\n" +
+	"\x13requests_per_second\x18\x06 \x01(\x01R\x11requestsPerSecond\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\a \x01(\x01R\terrorRate\x12$\n" +
+	"\x0ep95_latency_ms\x18\b \x01(\x01R\fp95LatencyMs\"{\n" +
 	"\x18ReportConnectionsRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12D\n" +
 	"\vconnections\x18\x02 \x03(\v2\".coral.colony.v1.L4ConnectionEntryR\vconnections\"\x1b\n" +
@@ -1875,7 +7157,99 @@ const file_coral_colony_v1_colony_proto_rawDesc = "" +
 	"\rserial_number\x18\x01 \x01(\tR\fserialNumber\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\"5\n" +
 	"\x19RevokeCertificateResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x14\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"G\n" +
+	"\x12RevokeAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"e\n" +
+	"\x13RevokeAgentResponse\x124\n" +
+	"\x16revoked_serial_numbers\x18\x01 \x03(\tR\x14revokedSerialNumbers\x12\x18\n" +
+	"\aevicted\x18\x02 \x01(\bR\aevicted\"{\n" +
+	"\x1aMintCapabilityTokenRequest\x12\x16\n" +
+	"\x06scopes\x18\x01 \x03(\tR\x06scopes\x12+\n" +
+	"\x03ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\"\xbb\x01\n" +
+	"\x1bMintCapabilityTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x19\n" +
+	"\btoken_id\x18\x02 \x01(\tR\atokenId\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"Y\n" +
+	"\x19RotateColonySecretRequest\x12<\n" +
+	"\fgrace_period\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\vgracePeriod\"\x80\x02\n" +
+	"\x1aRotateColonySecretResponse\x12#\n" +
+	"\rcolony_secret\x18\x01 \x01(\tR\fcolonySecret\x12J\n" +
+	"\x13previous_expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x11previousExpiresAt\x12%\n" +
+	"\x0eupdated_agents\x18\x03 \x03(\tR\rupdatedAgents\x12J\n" +
+	"\fstale_agents\x18\x04 \x03(\v2'.coral.colony.v1.StaleColonySecretAgentR\vstaleAgents\"I\n" +
+	"\x16StaleColonySecretAgent\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"]\n" +
+	"\rAgentArtifact\x12\x0e\n" +
+	"\x02os\x18\x01 \x01(\tR\x02os\x12\x12\n" +
+	"\x04arch\x18\x02 \x01(\tR\x04arch\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\"\xa3\x01\n" +
+	"\x14UpgradeAgentsRequest\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12<\n" +
+	"\tartifacts\x18\x02 \x03(\v2\x1e.coral.colony.v1.AgentArtifactR\tartifacts\x12\x1b\n" +
+	"\tagent_ids\x18\x03 \x03(\tR\bagentIds\x12\x16\n" +
+	"\x06cancel\x18\x04 \x01(\bR\x06cancel\"P\n" +
+	"\x15UpgradeAgentsResponse\x127\n" +
+	"\aupgrade\x18\x01 \x01(\v2\x1d.coral.colony.v1.AgentUpgradeR\aupgrade\"\x18\n" +
+	"\x16GetAgentUpgradeRequest\"R\n" +
+	"\x17GetAgentUpgradeResponse\x127\n" +
+	"\aupgrade\x18\x01 \x01(\v2\x1d.coral.colony.v1.AgentUpgradeR\aupgrade\"\xfb\x01\n" +
+	"\fAgentUpgrade\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12<\n" +
+	"\tartifacts\x18\x02 \x03(\v2\x1e.coral.colony.v1.AgentArtifactR\tartifacts\x12\x1b\n" +
+	"\tagent_ids\x18\x03 \x03(\tR\bagentIds\x129\n" +
+	"\n" +
+	"started_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\x06agents\x18\x05 \x03(\v2#.coral.colony.v1.AgentUpgradeStatusR\x06agents\"u\n" +
+	"\x12AgentUpgradeStatus\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\xca\x01\n" +
+	"\x11ExecAgentsRequest\x12\x1a\n" +
+	"\bselector\x18\x01 \x01(\tR\bselector\x12\x1b\n" +
+	"\tagent_ids\x18\x02 \x03(\tR\bagentIds\x12 \n" +
+	"\vconcurrency\x18\x03 \x01(\x05R\vconcurrency\x12\x18\n" +
+	"\acommand\x18\x04 \x03(\tR\acommand\x12'\n" +
+	"\x0ftimeout_seconds\x18\x05 \x01(\rR\x0etimeoutSeconds\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\tR\x06userId\"P\n" +
+	"\x12ExecAgentsResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .coral.colony.v1.BulkAgentResultR\aresults\"g\n" +
+	"\x12DrainAgentsRequest\x12\x1a\n" +
+	"\bselector\x18\x01 \x01(\tR\bselector\x12\x1b\n" +
+	"\tagent_ids\x18\x02 \x03(\tR\bagentIds\x12\x18\n" +
+	"\aundrain\x18\x03 \x01(\bR\aundrain\"Q\n" +
+	"\x13DrainAgentsResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .coral.colony.v1.BulkAgentResultR\aresults\"\xdd\x01\n" +
+	"\x12LabelAgentsRequest\x12\x1a\n" +
+	"\bselector\x18\x01 \x01(\tR\bselector\x12\x1b\n" +
+	"\tagent_ids\x18\x02 \x03(\tR\bagentIds\x12>\n" +
+	"\x03set\x18\x03 \x03(\v2,.coral.colony.v1.LabelAgentsRequest.SetEntryR\x03set\x12\x16\n" +
+	"\x06remove\x18\x04 \x03(\tR\x06remove\x1a6\n" +
+	"\bSetEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Q\n" +
+	"\x13LabelAgentsResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .coral.colony.v1.BulkAgentResultR\aresults\"\xcb\x02\n" +
+	"\x0fBulkAgentResult\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1b\n" +
+	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x12\x16\n" +
+	"\x06stdout\x18\x05 \x01(\fR\x06stdout\x12\x16\n" +
+	"\x06stderr\x18\x06 \x01(\fR\x06stderr\x12\x1f\n" +
+	"\vduration_ms\x18\a \x01(\rR\n" +
+	"durationMs\x12D\n" +
+	"\x06labels\x18\b \x03(\v2,.coral.colony.v1.BulkAgentResult.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x14\n" +
 	"\x12GetCAStatusRequest\"\xe7\x05\n" +
 	"\x13GetCAStatusResponse\x12H\n" +
 	"\aroot_ca\x18\x01 \x01(\v2/.coral.colony.v1.GetCAStatusResponse.CertStatusR\x06rootCa\x12`\n" +
@@ -1930,36 +7304,310 @@ const file_coral_colony_v1_colony_proto_rawDesc = "" +
 	"\x15handshake_age_seconds\x18\x06 \x01(\x03R\x13handshakeAgeSeconds\x12\x19\n" +
 	"\brx_bytes\x18\a \x01(\x03R\arxBytes\x12\x19\n" +
 	"\btx_bytes\x18\b \x01(\x03R\atxBytes\x12\x14\n" +
-	"\x05error\x18\t \x01(\tR\x05error*\x84\x01\n" +
+	"\x05error\x18\t \x01(\tR\x05error\"\xbe\x01\n" +
+	"\x16SubscribeEventsRequest\x126\n" +
+	"\x05types\x18\x01 \x03(\x0e2 .coral.colony.v1.ColonyEventTypeR\x05types\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12\x16\n" +
+	"\x06replay\x18\x04 \x01(\bR\x06replay\x12\x16\n" +
+	"\x06follow\x18\x05 \x01(\bR\x06follow\"\x81\x03\n" +
+	"\vColonyEvent\x124\n" +
+	"\x04type\x18\x01 \x01(\x0e2 .coral.colony.v1.ColonyEventTypeR\x04type\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12!\n" +
+	"\fservice_name\x18\x04 \x01(\tR\vserviceName\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x05 \x01(\tR\tsessionId\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12L\n" +
+	"\n" +
+	"attributes\x18\a \x03(\v2,.coral.colony.v1.ColonyEvent.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa4\x01\n" +
+	"\aLogLine\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x12\n" +
+	"\x04line\x18\x05 \x01(\tR\x04line\"x\n" +
+	"\x11ReportLogsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12.\n" +
+	"\x05lines\x18\x02 \x03(\v2\x18.coral.colony.v1.LogLineR\x05lines\x12\x18\n" +
+	"\adropped\x18\x03 \x01(\x04R\adropped\";\n" +
+	"\x12ReportLogsResponse\x12%\n" +
+	"\x0elines_accepted\x18\x01 \x01(\x03R\rlinesAccepted\"\xba\x01\n" +
+	"\x0fTailLogsRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x12\n" +
+	"\x04grep\x18\x03 \x01(\tR\x04grep\x12\x14\n" +
+	"\x05lines\x18\x04 \x01(\x05R\x05lines\x12\x16\n" +
+	"\x06follow\x18\x05 \x01(\bR\x06follow\x120\n" +
+	"\x05since\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\x14\n" +
+	"\x12GetIdentityRequest\"\x82\x02\n" +
+	"\x13GetIdentityResponse\x12$\n" +
+	"\rauthenticated\x18\x01 \x01(\bR\rauthenticated\x12\x19\n" +
+	"\btoken_id\x18\x02 \x01(\tR\atokenId\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12 \n" +
+	"\vpermissions\x18\x05 \x03(\tR\vpermissions\x12(\n" +
+	"\x10rbac_for_actions\x18\x06 \x01(\bR\x0erbacForActions\x126\n" +
+	"\x17approval_required_tools\x18\a \x03(\tR\x15approvalRequiredTools\"\xf1\x01\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x16\n" +
+	"\x06target\x18\x05 \x01(\tR\x06target\x12%\n" +
+	"\x0earguments_hash\x18\x06 \x01(\tR\rargumentsHash\x12\x16\n" +
+	"\x06result\x18\a \x01(\tR\x06result\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"\xb2\x01\n" +
+	"\x17RecordAuditEventRequest\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12%\n" +
+	"\x0earguments_hash\x18\x03 \x01(\tR\rargumentsHash\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x12\n" +
+	"\x04user\x18\x06 \x01(\tR\x04user\"6\n" +
+	"\x18RecordAuditEventResponse\x12\x1a\n" +
+	"\brecorded\x18\x01 \x01(\bR\brecorded\"\x8e\x01\n" +
+	"\x16ListAuditEventsRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"h\n" +
+	"\x17ListAuditEventsResponse\x123\n" +
+	"\x06events\x18\x01 \x03(\v2\x1b.coral.colony.v1.AuditEventR\x06events\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"\xe9\x02\n" +
+	"\vMCPApproval\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04tool\x18\x02 \x01(\tR\x04tool\x12\x18\n" +
+	"\acommand\x18\x03 \x01(\tR\acommand\x12\x1c\n" +
+	"\trequester\x18\x04 \x01(\tR\trequester\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"decided_by\x18\b \x01(\tR\tdecidedBy\x129\n" +
+	"\n" +
+	"decided_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdecidedAt\x12\x16\n" +
+	"\x06reason\x18\n" +
+	" \x01(\tR\x06reason\"\\\n" +
+	"\x18CreateMCPApprovalRequest\x12\x12\n" +
+	"\x04tool\x18\x01 \x01(\tR\x04tool\x12\x18\n" +
+	"\acommand\x18\x02 \x01(\tR\acommand\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\"U\n" +
+	"\x19CreateMCPApprovalResponse\x128\n" +
+	"\bapproval\x18\x01 \x01(\v2\x1c.coral.colony.v1.MCPApprovalR\bapproval\"'\n" +
+	"\x15GetMCPApprovalRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"R\n" +
+	"\x16GetMCPApprovalResponse\x128\n" +
+	"\bapproval\x18\x01 \x01(\v2\x1c.coral.colony.v1.MCPApprovalR\bapproval\"1\n" +
+	"\x17ListMCPApprovalsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"V\n" +
+	"\x18ListMCPApprovalsResponse\x12:\n" +
+	"\tapprovals\x18\x01 \x03(\v2\x1c.coral.colony.v1.MCPApprovalR\tapprovals\"p\n" +
+	"\x18DecideMCPApprovalRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x12\n" +
+	"\x04user\x18\x04 \x01(\tR\x04user\"U\n" +
+	"\x19DecideMCPApprovalResponse\x128\n" +
+	"\bapproval\x18\x01 \x01(\v2\x1c.coral.colony.v1.MCPApprovalR\bapproval\"\xcc\x02\n" +
+	"\vMCPToolCall\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x16\n" +
+	"\x06client\x18\x03 \x01(\tR\x06client\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x12\n" +
+	"\x04tool\x18\x05 \x01(\tR\x04tool\x12%\n" +
+	"\x0earguments_json\x18\x06 \x01(\tR\rargumentsJson\x12\x18\n" +
+	"\acommand\x18\a \x03(\tR\acommand\x12\x1f\n" +
+	"\vduration_ms\x18\b \x01(\x01R\n" +
+	"durationMs\x12!\n" +
+	"\fresult_bytes\x18\t \x01(\x03R\vresultBytes\x12\x16\n" +
+	"\x06result\x18\n" +
+	" \x01(\tR\x06result\x12\x14\n" +
+	"\x05error\x18\v \x01(\tR\x05error\"L\n" +
+	"\x18RecordMCPToolCallRequest\x120\n" +
+	"\x04call\x18\x01 \x01(\v2\x1c.coral.colony.v1.MCPToolCallR\x04call\"\x1b\n" +
+	"\x19RecordMCPToolCallResponse\"\xa3\x01\n" +
+	"\x17ListMCPToolCallsRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x12\n" +
+	"\x04tool\x18\x02 \x01(\tR\x04tool\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06client\x18\x04 \x01(\tR\x06client\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"N\n" +
+	"\x18ListMCPToolCallsResponse\x122\n" +
+	"\x05calls\x18\x01 \x03(\v2\x1c.coral.colony.v1.MCPToolCallR\x05calls\"'\n" +
+	"\x15GetMCPToolCallRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"J\n" +
+	"\x16GetMCPToolCallResponse\x120\n" +
+	"\x04call\x18\x01 \x01(\v2\x1c.coral.colony.v1.MCPToolCallR\x04call\"\xa4\x03\n" +
+	"\tAlertRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x16\n" +
+	"\x06metric\x18\x04 \x01(\tR\x06metric\x12\x1c\n" +
+	"\tthreshold\x18\x05 \x01(\x01R\tthreshold\x121\n" +
+	"\x06window\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x14\n" +
+	"\x05sinks\x18\a \x03(\tR\x05sinks\x12\x18\n" +
+	"\aenabled\x18\b \x01(\bR\aenabled\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12F\n" +
+	"\x11last_evaluated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0flastEvaluatedAt\x124\n" +
+	"\x06firing\x18\v \x03(\v2\x1c.coral.colony.v1.AlertFiringR\x06firing\"x\n" +
+	"\vAlertFiring\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\xce\x01\n" +
+	"\x16CreateAlertRuleRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x16\n" +
+	"\x06metric\x18\x03 \x01(\tR\x06metric\x12\x1c\n" +
+	"\tthreshold\x18\x04 \x01(\x01R\tthreshold\x121\n" +
+	"\x06window\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x14\n" +
+	"\x05sinks\x18\x06 \x03(\tR\x05sinks\"I\n" +
+	"\x17CreateAlertRuleResponse\x12.\n" +
+	"\x04rule\x18\x01 \x01(\v2\x1a.coral.colony.v1.AlertRuleR\x04rule\"\x17\n" +
+	"\x15ListAlertRulesRequest\"`\n" +
+	"\x16ListAlertRulesResponse\x120\n" +
+	"\x05rules\x18\x01 \x03(\v2\x1a.coral.colony.v1.AlertRuleR\x05rules\x12\x14\n" +
+	"\x05sinks\x18\x02 \x03(\tR\x05sinks\"(\n" +
+	"\x16DeleteAlertRuleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x19\n" +
+	"\x17DeleteAlertRuleResponse\"F\n" +
+	"\x1aSetAlertRuleEnabledRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"\x1d\n" +
+	"\x1bSetAlertRuleEnabledResponse\"\xbc\x01\n" +
+	"\fServiceGroup\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bservices\x18\x02 \x03(\tR\bservices\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vfrom_config\x18\x04 \x01(\bR\n" +
+	"fromConfig\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x1a\n" +
+	"\x18ListServiceGroupsRequest\"R\n" +
+	"\x19ListServiceGroupsResponse\x125\n" +
+	"\x06groups\x18\x01 \x03(\v2\x1d.coral.colony.v1.ServiceGroupR\x06groups\",\n" +
+	"\x16GetServiceGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"N\n" +
+	"\x17GetServiceGroupResponse\x123\n" +
+	"\x05group\x18\x01 \x01(\v2\x1d.coral.colony.v1.ServiceGroupR\x05group\"j\n" +
+	"\x16SetServiceGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bservices\x18\x02 \x03(\tR\bservices\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"N\n" +
+	"\x17SetServiceGroupResponse\x123\n" +
+	"\x05group\x18\x01 \x01(\v2\x1d.coral.colony.v1.ServiceGroupR\x05group\"/\n" +
+	"\x19DeleteServiceGroupRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1c\n" +
+	"\x1aDeleteServiceGroupResponse\"\xd4\x01\n" +
+	"\rServiceFreeze\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1b\n" +
+	"\tfrozen_by\x18\x04 \x01(\tR\bfrozenBy\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x93\x01\n" +
+	"\x14FreezeServiceRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x125\n" +
+	"\bduration\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x12\n" +
+	"\x04user\x18\x04 \x01(\tR\x04user\"O\n" +
+	"\x15FreezeServiceResponse\x126\n" +
+	"\x06freeze\x18\x01 \x01(\v2\x1e.coral.colony.v1.ServiceFreezeR\x06freeze\"2\n" +
+	"\x16UnfreezeServiceRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\"\x19\n" +
+	"\x17UnfreezeServiceResponse\"\x1b\n" +
+	"\x19ListServiceFreezesRequest\"V\n" +
+	"\x1aListServiceFreezesResponse\x128\n" +
+	"\afreezes\x18\x01 \x03(\v2\x1e.coral.colony.v1.ServiceFreezeR\afreezes*\xa2\x01\n" +
 	"\rEvidenceLayer\x12\x1e\n" +
 	"\x1aEVIDENCE_LAYER_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17EVIDENCE_LAYER_L7_TRACE\x10\x01\x12\x1d\n" +
 	"\x19EVIDENCE_LAYER_L4_NETWORK\x10\x02\x12\x17\n" +
-	"\x13EVIDENCE_LAYER_BOTH\x10\x032\xa5\x10\n" +
+	"\x13EVIDENCE_LAYER_BOTH\x10\x03\x12\x1c\n" +
+	"\x18EVIDENCE_LAYER_L7_CLIENT\x10\x04*\xe0\x02\n" +
+	"\x0fColonyEventType\x12!\n" +
+	"\x1dCOLONY_EVENT_TYPE_UNSPECIFIED\x10\x00\x12%\n" +
+	"!COLONY_EVENT_TYPE_AGENT_CONNECTED\x10\x01\x12(\n" +
+	"$COLONY_EVENT_TYPE_AGENT_DISCONNECTED\x10\x02\x12(\n" +
+	"$COLONY_EVENT_TYPE_SERVICE_REGISTERED\x10\x03\x12*\n" +
+	"&COLONY_EVENT_TYPE_SERVICE_DEREGISTERED\x10\x04\x12+\n" +
+	"'COLONY_EVENT_TYPE_DEBUG_SESSION_STARTED\x10\x05\x12+\n" +
+	"'COLONY_EVENT_TYPE_DEBUG_SESSION_STOPPED\x10\x06\x12)\n" +
+	"%COLONY_EVENT_TYPE_PROFILING_COMPLETED\x10\a2\xe6-\n" +
 	"\rColonyService\x12R\n" +
 	"\tGetStatus\x12!.coral.colony.v1.GetStatusRequest\x1a\".coral.colony.v1.GetStatusResponse\x12U\n" +
 	"\n" +
-	"ListAgents\x12\".coral.colony.v1.ListAgentsRequest\x1a#.coral.colony.v1.ListAgentsResponse\x12X\n" +
+	"ListAgents\x12\".coral.colony.v1.ListAgentsRequest\x1a#.coral.colony.v1.ListAgentsResponse\x12d\n" +
+	"\x0fGetAgentHistory\x12'.coral.colony.v1.GetAgentHistoryRequest\x1a(.coral.colony.v1.GetAgentHistoryResponse\x12X\n" +
 	"\vGetTopology\x12#.coral.colony.v1.GetTopologyRequest\x1a$.coral.colony.v1.GetTopologyResponse\x12p\n" +
 	"\x13QueryUnifiedSummary\x12+.coral.colony.v1.QueryUnifiedSummaryRequest\x1a,.coral.colony.v1.QueryUnifiedSummaryResponse\x12m\n" +
 	"\x12QueryUnifiedTraces\x12*.coral.colony.v1.QueryUnifiedTracesRequest\x1a+.coral.colony.v1.QueryUnifiedTracesResponse\x12p\n" +
 	"\x13QueryUnifiedMetrics\x12+.coral.colony.v1.QueryUnifiedMetricsRequest\x1a,.coral.colony.v1.QueryUnifiedMetricsResponse\x12g\n" +
-	"\x10QueryUnifiedLogs\x12(.coral.colony.v1.QueryUnifiedLogsRequest\x1a).coral.colony.v1.QueryUnifiedLogsResponse\x12[\n" +
+	"\x10QueryUnifiedLogs\x12(.coral.colony.v1.QueryUnifiedLogsRequest\x1a).coral.colony.v1.QueryUnifiedLogsResponse\x12m\n" +
+	"\x12CompareDeployments\x12*.coral.colony.v1.CompareDeploymentsRequest\x1a+.coral.colony.v1.CompareDeploymentsResponse\x12X\n" +
+	"\vQueryErrors\x12#.coral.colony.v1.QueryErrorsRequest\x1a$.coral.colony.v1.QueryErrorsResponse\x12O\n" +
+	"\bQuerySLO\x12 .coral.colony.v1.QuerySLORequest\x1a!.coral.colony.v1.QuerySLOResponse\x12a\n" +
+	"\x0eQueryAnomalies\x12&.coral.colony.v1.QueryAnomaliesRequest\x1a'.coral.colony.v1.QueryAnomaliesResponse\x12[\n" +
 	"\fListServices\x12$.coral.colony.v1.ListServicesRequest\x1a%.coral.colony.v1.ListServicesResponse\x12p\n" +
 	"\x13GetMetricPercentile\x12+.coral.colony.v1.GetMetricPercentileRequest\x1a,.coral.colony.v1.GetMetricPercentileResponse\x12m\n" +
 	"\x12GetServiceActivity\x12*.coral.colony.v1.GetServiceActivityRequest\x1a+.coral.colony.v1.GetServiceActivityResponse\x12p\n" +
 	"\x13ListServiceActivity\x12+.coral.colony.v1.ListServiceActivityRequest\x1a,.coral.colony.v1.ListServiceActivityResponse\x12[\n" +
 	"\fExecuteQuery\x12$.coral.colony.v1.ExecuteQueryRequest\x1a%.coral.colony.v1.ExecuteQueryResponse\x12O\n" +
+	"\bQuerySQL\x12 .coral.colony.v1.QuerySQLRequest\x1a!.coral.colony.v1.QuerySQLResponse\x12O\n" +
 	"\bCallTool\x12 .coral.colony.v1.CallToolRequest\x1a!.coral.colony.v1.CallToolResponse\x12Y\n" +
 	"\n" +
 	"StreamTool\x12\".coral.colony.v1.StreamToolRequest\x1a#.coral.colony.v1.StreamToolResponse(\x010\x01\x12R\n" +
 	"\tListTools\x12!.coral.colony.v1.ListToolsRequest\x1a\".coral.colony.v1.ListToolsResponse\x12m\n" +
 	"\x12RequestCertificate\x12*.coral.colony.v1.RequestCertificateRequest\x1a+.coral.colony.v1.RequestCertificateResponse\x12j\n" +
 	"\x11RevokeCertificate\x12).coral.colony.v1.RevokeCertificateRequest\x1a*.coral.colony.v1.RevokeCertificateResponse\x12X\n" +
+	"\vRevokeAgent\x12#.coral.colony.v1.RevokeAgentRequest\x1a$.coral.colony.v1.RevokeAgentResponse\x12p\n" +
+	"\x13MintCapabilityToken\x12+.coral.colony.v1.MintCapabilityTokenRequest\x1a,.coral.colony.v1.MintCapabilityTokenResponse\x12m\n" +
+	"\x12RotateColonySecret\x12*.coral.colony.v1.RotateColonySecretRequest\x1a+.coral.colony.v1.RotateColonySecretResponse\x12^\n" +
+	"\rUpgradeAgents\x12%.coral.colony.v1.UpgradeAgentsRequest\x1a&.coral.colony.v1.UpgradeAgentsResponse\x12d\n" +
+	"\x0fGetAgentUpgrade\x12'.coral.colony.v1.GetAgentUpgradeRequest\x1a(.coral.colony.v1.GetAgentUpgradeResponse\x12U\n" +
+	"\n" +
+	"ExecAgents\x12\".coral.colony.v1.ExecAgentsRequest\x1a#.coral.colony.v1.ExecAgentsResponse\x12X\n" +
+	"\vDrainAgents\x12#.coral.colony.v1.DrainAgentsRequest\x1a$.coral.colony.v1.DrainAgentsResponse\x12X\n" +
+	"\vLabelAgents\x12#.coral.colony.v1.LabelAgentsRequest\x1a$.coral.colony.v1.LabelAgentsResponse\x12X\n" +
 	"\vGetCAStatus\x12#.coral.colony.v1.GetCAStatusRequest\x1a$.coral.colony.v1.GetCAStatusResponse\x12O\n" +
 	"\bMeshPing\x12 .coral.colony.v1.MeshPingRequest\x1a!.coral.colony.v1.MeshPingResponse\x12R\n" +
 	"\tMeshAudit\x12!.coral.colony.v1.MeshAuditRequest\x1a\".coral.colony.v1.MeshAuditResponse\x12l\n" +
-	"\x11ReportConnections\x12).coral.colony.v1.ReportConnectionsRequest\x1a*.coral.colony.v1.ReportConnectionsResponse(\x01B\xb6\x01\n" +
+	"\x11ReportConnections\x12).coral.colony.v1.ReportConnectionsRequest\x1a*.coral.colony.v1.ReportConnectionsResponse(\x01\x12Z\n" +
+	"\x0fSubscribeEvents\x12'.coral.colony.v1.SubscribeEventsRequest\x1a\x1c.coral.colony.v1.ColonyEvent0\x01\x12W\n" +
+	"\n" +
+	"ReportLogs\x12\".coral.colony.v1.ReportLogsRequest\x1a#.coral.colony.v1.ReportLogsResponse(\x01\x12H\n" +
+	"\bTailLogs\x12 .coral.colony.v1.TailLogsRequest\x1a\x18.coral.colony.v1.LogLine0\x01\x12X\n" +
+	"\vGetIdentity\x12#.coral.colony.v1.GetIdentityRequest\x1a$.coral.colony.v1.GetIdentityResponse\x12g\n" +
+	"\x10RecordAuditEvent\x12(.coral.colony.v1.RecordAuditEventRequest\x1a).coral.colony.v1.RecordAuditEventResponse\x12d\n" +
+	"\x0fListAuditEvents\x12'.coral.colony.v1.ListAuditEventsRequest\x1a(.coral.colony.v1.ListAuditEventsResponse\x12j\n" +
+	"\x11CreateMCPApproval\x12).coral.colony.v1.CreateMCPApprovalRequest\x1a*.coral.colony.v1.CreateMCPApprovalResponse\x12a\n" +
+	"\x0eGetMCPApproval\x12&.coral.colony.v1.GetMCPApprovalRequest\x1a'.coral.colony.v1.GetMCPApprovalResponse\x12g\n" +
+	"\x10ListMCPApprovals\x12(.coral.colony.v1.ListMCPApprovalsRequest\x1a).coral.colony.v1.ListMCPApprovalsResponse\x12j\n" +
+	"\x11DecideMCPApproval\x12).coral.colony.v1.DecideMCPApprovalRequest\x1a*.coral.colony.v1.DecideMCPApprovalResponse\x12j\n" +
+	"\x11RecordMCPToolCall\x12).coral.colony.v1.RecordMCPToolCallRequest\x1a*.coral.colony.v1.RecordMCPToolCallResponse\x12g\n" +
+	"\x10ListMCPToolCalls\x12(.coral.colony.v1.ListMCPToolCallsRequest\x1a).coral.colony.v1.ListMCPToolCallsResponse\x12a\n" +
+	"\x0eGetMCPToolCall\x12&.coral.colony.v1.GetMCPToolCallRequest\x1a'.coral.colony.v1.GetMCPToolCallResponse\x12d\n" +
+	"\x0fCreateAlertRule\x12'.coral.colony.v1.CreateAlertRuleRequest\x1a(.coral.colony.v1.CreateAlertRuleResponse\x12a\n" +
+	"\x0eListAlertRules\x12&.coral.colony.v1.ListAlertRulesRequest\x1a'.coral.colony.v1.ListAlertRulesResponse\x12d\n" +
+	"\x0fDeleteAlertRule\x12'.coral.colony.v1.DeleteAlertRuleRequest\x1a(.coral.colony.v1.DeleteAlertRuleResponse\x12p\n" +
+	"\x13SetAlertRuleEnabled\x12+.coral.colony.v1.SetAlertRuleEnabledRequest\x1a,.coral.colony.v1.SetAlertRuleEnabledResponse\x12j\n" +
+	"\x11ListServiceGroups\x12).coral.colony.v1.ListServiceGroupsRequest\x1a*.coral.colony.v1.ListServiceGroupsResponse\x12d\n" +
+	"\x0fGetServiceGroup\x12'.coral.colony.v1.GetServiceGroupRequest\x1a(.coral.colony.v1.GetServiceGroupResponse\x12d\n" +
+	"\x0fSetServiceGroup\x12'.coral.colony.v1.SetServiceGroupRequest\x1a(.coral.colony.v1.SetServiceGroupResponse\x12m\n" +
+	"\x12DeleteServiceGroup\x12*.coral.colony.v1.DeleteServiceGroupRequest\x1a+.coral.colony.v1.DeleteServiceGroupResponse\x12^\n" +
+	"\rFreezeService\x12%.coral.colony.v1.FreezeServiceRequest\x1a&.coral.colony.v1.FreezeServiceResponse\x12d\n" +
+	"\x0fUnfreezeService\x12'.coral.colony.v1.UnfreezeServiceRequest\x1a(.coral.colony.v1.UnfreezeServiceResponse\x12m\n" +
+	"\x12ListServiceFreezes\x12*.coral.colony.v1.ListServiceFreezesRequest\x1a+.coral.colony.v1.ListServiceFreezesResponseB\xb6\x01\n" +
 	"\x13com.coral.colony.v1B\vColonyProtoP\x01Z4github.com/coral-mesh/coral/coral/colony/v1;colonyv1\xa2\x02\x03CCX\xaa\x02\x0fCoral.Colony.V1\xca\x02\x0fCoral\\Colony\\V1\xe2\x02\x1bCoral\\Colony\\V1\\GPBMetadata\xea\x02\x11Coral::Colony::V1b\x06proto3"
 
 var (
@@ -1974,131 +7622,371 @@ func file_coral_colony_v1_colony_proto_rawDescGZIP() []byte {
 	return file_coral_colony_v1_colony_proto_rawDescData
 }
 
-var file_coral_colony_v1_colony_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_coral_colony_v1_colony_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_coral_colony_v1_colony_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_coral_colony_v1_colony_proto_msgTypes = make([]protoimpl.MessageInfo, 110)
 var file_coral_colony_v1_colony_proto_goTypes = []any{
 	(EvidenceLayer)(0),                       // 0: coral.colony.v1.EvidenceLayer
-	(*GetStatusRequest)(nil),                 // 1: coral.colony.v1.GetStatusRequest
-	(*GetStatusResponse)(nil),                // 2: coral.colony.v1.GetStatusResponse
-	(*ListAgentsRequest)(nil),                // 3: coral.colony.v1.ListAgentsRequest
-	(*ListAgentsResponse)(nil),               // 4: coral.colony.v1.ListAgentsResponse
-	(*Agent)(nil),                            // 5: coral.colony.v1.Agent
-	(*GetTopologyRequest)(nil),               // 6: coral.colony.v1.GetTopologyRequest
-	(*GetTopologyResponse)(nil),              // 7: coral.colony.v1.GetTopologyResponse
-	(*Connection)(nil),                       // 8: coral.colony.v1.Connection
-	(*ReportConnectionsRequest)(nil),         // 9: coral.colony.v1.ReportConnectionsRequest
-	(*ReportConnectionsResponse)(nil),        // 10: coral.colony.v1.ReportConnectionsResponse
-	(*L4ConnectionEntry)(nil),                // 11: coral.colony.v1.L4ConnectionEntry
-	(*RequestCertificateRequest)(nil),        // 12: coral.colony.v1.RequestCertificateRequest
-	(*RequestCertificateResponse)(nil),       // 13: coral.colony.v1.RequestCertificateResponse
-	(*RevokeCertificateRequest)(nil),         // 14: coral.colony.v1.RevokeCertificateRequest
-	(*RevokeCertificateResponse)(nil),        // 15: coral.colony.v1.RevokeCertificateResponse
-	(*GetCAStatusRequest)(nil),               // 16: coral.colony.v1.GetCAStatusRequest
-	(*GetCAStatusResponse)(nil),              // 17: coral.colony.v1.GetCAStatusResponse
-	(*MeshPingRequest)(nil),                  // 18: coral.colony.v1.MeshPingRequest
-	(*MeshPingResponse)(nil),                 // 19: coral.colony.v1.MeshPingResponse
-	(*MeshAuditRequest)(nil),                 // 20: coral.colony.v1.MeshAuditRequest
-	(*MeshAuditResponse)(nil),                // 21: coral.colony.v1.MeshAuditResponse
-	(*MeshAuditAgentResult)(nil),             // 22: coral.colony.v1.MeshAuditAgentResult
-	(*GetCAStatusResponse_CertStatus)(nil),   // 23: coral.colony.v1.GetCAStatusResponse.CertStatus
-	(*GetCAStatusResponse_Stats)(nil),        // 24: coral.colony.v1.GetCAStatusResponse.Stats
-	(*MeshPingResponse_AgentPingResult)(nil), // 25: coral.colony.v1.MeshPingResponse.AgentPingResult
-	(*timestamppb.Timestamp)(nil),            // 26: google.protobuf.Timestamp
-	(*v1.MeshTelemetry)(nil),                 // 27: coral.network.v1.MeshTelemetry
-	(*v11.ServiceInfo)(nil),                  // 28: coral.mesh.v1.ServiceInfo
-	(*v12.RuntimeContextResponse)(nil),       // 29: coral.agent.v1.RuntimeContextResponse
-	(*QueryUnifiedSummaryRequest)(nil),       // 30: coral.colony.v1.QueryUnifiedSummaryRequest
-	(*QueryUnifiedTracesRequest)(nil),        // 31: coral.colony.v1.QueryUnifiedTracesRequest
-	(*QueryUnifiedMetricsRequest)(nil),       // 32: coral.colony.v1.QueryUnifiedMetricsRequest
-	(*QueryUnifiedLogsRequest)(nil),          // 33: coral.colony.v1.QueryUnifiedLogsRequest
-	(*ListServicesRequest)(nil),              // 34: coral.colony.v1.ListServicesRequest
-	(*GetMetricPercentileRequest)(nil),       // 35: coral.colony.v1.GetMetricPercentileRequest
-	(*GetServiceActivityRequest)(nil),        // 36: coral.colony.v1.GetServiceActivityRequest
-	(*ListServiceActivityRequest)(nil),       // 37: coral.colony.v1.ListServiceActivityRequest
-	(*ExecuteQueryRequest)(nil),              // 38: coral.colony.v1.ExecuteQueryRequest
-	(*CallToolRequest)(nil),                  // 39: coral.colony.v1.CallToolRequest
-	(*StreamToolRequest)(nil),                // 40: coral.colony.v1.StreamToolRequest
-	(*ListToolsRequest)(nil),                 // 41: coral.colony.v1.ListToolsRequest
-	(*QueryUnifiedSummaryResponse)(nil),      // 42: coral.colony.v1.QueryUnifiedSummaryResponse
-	(*QueryUnifiedTracesResponse)(nil),       // 43: coral.colony.v1.QueryUnifiedTracesResponse
-	(*QueryUnifiedMetricsResponse)(nil),      // 44: coral.colony.v1.QueryUnifiedMetricsResponse
-	(*QueryUnifiedLogsResponse)(nil),         // 45: coral.colony.v1.QueryUnifiedLogsResponse
-	(*ListServicesResponse)(nil),             // 46: coral.colony.v1.ListServicesResponse
-	(*GetMetricPercentileResponse)(nil),      // 47: coral.colony.v1.GetMetricPercentileResponse
-	(*GetServiceActivityResponse)(nil),       // 48: coral.colony.v1.GetServiceActivityResponse
-	(*ListServiceActivityResponse)(nil),      // 49: coral.colony.v1.ListServiceActivityResponse
-	(*ExecuteQueryResponse)(nil),             // 50: coral.colony.v1.ExecuteQueryResponse
-	(*CallToolResponse)(nil),                 // 51: coral.colony.v1.CallToolResponse
-	(*StreamToolResponse)(nil),               // 52: coral.colony.v1.StreamToolResponse
-	(*ListToolsResponse)(nil),                // 53: coral.colony.v1.ListToolsResponse
+	(ColonyEventType)(0),                     // 1: coral.colony.v1.ColonyEventType
+	(*GetStatusRequest)(nil),                 // 2: coral.colony.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),                // 3: coral.colony.v1.GetStatusResponse
+	(*ListAgentsRequest)(nil),                // 4: coral.colony.v1.ListAgentsRequest
+	(*ListAgentsResponse)(nil),               // 5: coral.colony.v1.ListAgentsResponse
+	(*Agent)(nil),                            // 6: coral.colony.v1.Agent
+	(*ProtocolCapability)(nil),               // 7: coral.colony.v1.ProtocolCapability
+	(*GetAgentHistoryRequest)(nil),           // 8: coral.colony.v1.GetAgentHistoryRequest
+	(*GetAgentHistoryResponse)(nil),          // 9: coral.colony.v1.GetAgentHistoryResponse
+	(*AgentHistorySummary)(nil),              // 10: coral.colony.v1.AgentHistorySummary
+	(*AgentHistoryEvent)(nil),                // 11: coral.colony.v1.AgentHistoryEvent
+	(*GetTopologyRequest)(nil),               // 12: coral.colony.v1.GetTopologyRequest
+	(*GetTopologyResponse)(nil),              // 13: coral.colony.v1.GetTopologyResponse
+	(*Connection)(nil),                       // 14: coral.colony.v1.Connection
+	(*ReportConnectionsRequest)(nil),         // 15: coral.colony.v1.ReportConnectionsRequest
+	(*ReportConnectionsResponse)(nil),        // 16: coral.colony.v1.ReportConnectionsResponse
+	(*L4ConnectionEntry)(nil),                // 17: coral.colony.v1.L4ConnectionEntry
+	(*RequestCertificateRequest)(nil),        // 18: coral.colony.v1.RequestCertificateRequest
+	(*RequestCertificateResponse)(nil),       // 19: coral.colony.v1.RequestCertificateResponse
+	(*RevokeCertificateRequest)(nil),         // 20: coral.colony.v1.RevokeCertificateRequest
+	(*RevokeCertificateResponse)(nil),        // 21: coral.colony.v1.RevokeCertificateResponse
+	(*RevokeAgentRequest)(nil),               // 22: coral.colony.v1.RevokeAgentRequest
+	(*RevokeAgentResponse)(nil),              // 23: coral.colony.v1.RevokeAgentResponse
+	(*MintCapabilityTokenRequest)(nil),       // 24: coral.colony.v1.MintCapabilityTokenRequest
+	(*MintCapabilityTokenResponse)(nil),      // 25: coral.colony.v1.MintCapabilityTokenResponse
+	(*RotateColonySecretRequest)(nil),        // 26: coral.colony.v1.RotateColonySecretRequest
+	(*RotateColonySecretResponse)(nil),       // 27: coral.colony.v1.RotateColonySecretResponse
+	(*StaleColonySecretAgent)(nil),           // 28: coral.colony.v1.StaleColonySecretAgent
+	(*AgentArtifact)(nil),                    // 29: coral.colony.v1.AgentArtifact
+	(*UpgradeAgentsRequest)(nil),             // 30: coral.colony.v1.UpgradeAgentsRequest
+	(*UpgradeAgentsResponse)(nil),            // 31: coral.colony.v1.UpgradeAgentsResponse
+	(*GetAgentUpgradeRequest)(nil),           // 32: coral.colony.v1.GetAgentUpgradeRequest
+	(*GetAgentUpgradeResponse)(nil),          // 33: coral.colony.v1.GetAgentUpgradeResponse
+	(*AgentUpgrade)(nil),                     // 34: coral.colony.v1.AgentUpgrade
+	(*AgentUpgradeStatus)(nil),               // 35: coral.colony.v1.AgentUpgradeStatus
+	(*ExecAgentsRequest)(nil),                // 36: coral.colony.v1.ExecAgentsRequest
+	(*ExecAgentsResponse)(nil),               // 37: coral.colony.v1.ExecAgentsResponse
+	(*DrainAgentsRequest)(nil),               // 38: coral.colony.v1.DrainAgentsRequest
+	(*DrainAgentsResponse)(nil),              // 39: coral.colony.v1.DrainAgentsResponse
+	(*LabelAgentsRequest)(nil),               // 40: coral.colony.v1.LabelAgentsRequest
+	(*LabelAgentsResponse)(nil),              // 41: coral.colony.v1.LabelAgentsResponse
+	(*BulkAgentResult)(nil),                  // 42: coral.colony.v1.BulkAgentResult
+	(*GetCAStatusRequest)(nil),               // 43: coral.colony.v1.GetCAStatusRequest
+	(*GetCAStatusResponse)(nil),              // 44: coral.colony.v1.GetCAStatusResponse
+	(*MeshPingRequest)(nil),                  // 45: coral.colony.v1.MeshPingRequest
+	(*MeshPingResponse)(nil),                 // 46: coral.colony.v1.MeshPingResponse
+	(*MeshAuditRequest)(nil),                 // 47: coral.colony.v1.MeshAuditRequest
+	(*MeshAuditResponse)(nil),                // 48: coral.colony.v1.MeshAuditResponse
+	(*MeshAuditAgentResult)(nil),             // 49: coral.colony.v1.MeshAuditAgentResult
+	(*SubscribeEventsRequest)(nil),           // 50: coral.colony.v1.SubscribeEventsRequest
+	(*ColonyEvent)(nil),                      // 51: coral.colony.v1.ColonyEvent
+	(*LogLine)(nil),                          // 52: coral.colony.v1.LogLine
+	(*ReportLogsRequest)(nil),                // 53: coral.colony.v1.ReportLogsRequest
+	(*ReportLogsResponse)(nil),               // 54: coral.colony.v1.ReportLogsResponse
+	(*TailLogsRequest)(nil),                  // 55: coral.colony.v1.TailLogsRequest
+	(*GetIdentityRequest)(nil),               // 56: coral.colony.v1.GetIdentityRequest
+	(*GetIdentityResponse)(nil),              // 57: coral.colony.v1.GetIdentityResponse
+	(*AuditEvent)(nil),                       // 58: coral.colony.v1.AuditEvent
+	(*RecordAuditEventRequest)(nil),          // 59: coral.colony.v1.RecordAuditEventRequest
+	(*RecordAuditEventResponse)(nil),         // 60: coral.colony.v1.RecordAuditEventResponse
+	(*ListAuditEventsRequest)(nil),           // 61: coral.colony.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),          // 62: coral.colony.v1.ListAuditEventsResponse
+	(*MCPApproval)(nil),                      // 63: coral.colony.v1.MCPApproval
+	(*CreateMCPApprovalRequest)(nil),         // 64: coral.colony.v1.CreateMCPApprovalRequest
+	(*CreateMCPApprovalResponse)(nil),        // 65: coral.colony.v1.CreateMCPApprovalResponse
+	(*GetMCPApprovalRequest)(nil),            // 66: coral.colony.v1.GetMCPApprovalRequest
+	(*GetMCPApprovalResponse)(nil),           // 67: coral.colony.v1.GetMCPApprovalResponse
+	(*ListMCPApprovalsRequest)(nil),          // 68: coral.colony.v1.ListMCPApprovalsRequest
+	(*ListMCPApprovalsResponse)(nil),         // 69: coral.colony.v1.ListMCPApprovalsResponse
+	(*DecideMCPApprovalRequest)(nil),         // 70: coral.colony.v1.DecideMCPApprovalRequest
+	(*DecideMCPApprovalResponse)(nil),        // 71: coral.colony.v1.DecideMCPApprovalResponse
+	(*MCPToolCall)(nil),                      // 72: coral.colony.v1.MCPToolCall
+	(*RecordMCPToolCallRequest)(nil),         // 73: coral.colony.v1.RecordMCPToolCallRequest
+	(*RecordMCPToolCallResponse)(nil),        // 74: coral.colony.v1.RecordMCPToolCallResponse
+	(*ListMCPToolCallsRequest)(nil),          // 75: coral.colony.v1.ListMCPToolCallsRequest
+	(*ListMCPToolCallsResponse)(nil),         // 76: coral.colony.v1.ListMCPToolCallsResponse
+	(*GetMCPToolCallRequest)(nil),            // 77: coral.colony.v1.GetMCPToolCallRequest
+	(*GetMCPToolCallResponse)(nil),           // 78: coral.colony.v1.GetMCPToolCallResponse
+	(*AlertRule)(nil),                        // 79: coral.colony.v1.AlertRule
+	(*AlertFiring)(nil),                      // 80: coral.colony.v1.AlertFiring
+	(*CreateAlertRuleRequest)(nil),           // 81: coral.colony.v1.CreateAlertRuleRequest
+	(*CreateAlertRuleResponse)(nil),          // 82: coral.colony.v1.CreateAlertRuleResponse
+	(*ListAlertRulesRequest)(nil),            // 83: coral.colony.v1.ListAlertRulesRequest
+	(*ListAlertRulesResponse)(nil),           // 84: coral.colony.v1.ListAlertRulesResponse
+	(*DeleteAlertRuleRequest)(nil),           // 85: coral.colony.v1.DeleteAlertRuleRequest
+	(*DeleteAlertRuleResponse)(nil),          // 86: coral.colony.v1.DeleteAlertRuleResponse
+	(*SetAlertRuleEnabledRequest)(nil),       // 87: coral.colony.v1.SetAlertRuleEnabledRequest
+	(*SetAlertRuleEnabledResponse)(nil),      // 88: coral.colony.v1.SetAlertRuleEnabledResponse
+	(*ServiceGroup)(nil),                     // 89: coral.colony.v1.ServiceGroup
+	(*ListServiceGroupsRequest)(nil),         // 90: coral.colony.v1.ListServiceGroupsRequest
+	(*ListServiceGroupsResponse)(nil),        // 91: coral.colony.v1.ListServiceGroupsResponse
+	(*GetServiceGroupRequest)(nil),           // 92: coral.colony.v1.GetServiceGroupRequest
+	(*GetServiceGroupResponse)(nil),          // 93: coral.colony.v1.GetServiceGroupResponse
+	(*SetServiceGroupRequest)(nil),           // 94: coral.colony.v1.SetServiceGroupRequest
+	(*SetServiceGroupResponse)(nil),          // 95: coral.colony.v1.SetServiceGroupResponse
+	(*DeleteServiceGroupRequest)(nil),        // 96: coral.colony.v1.DeleteServiceGroupRequest
+	(*DeleteServiceGroupResponse)(nil),       // 97: coral.colony.v1.DeleteServiceGroupResponse
+	(*ServiceFreeze)(nil),                    // 98: coral.colony.v1.ServiceFreeze
+	(*FreezeServiceRequest)(nil),             // 99: coral.colony.v1.FreezeServiceRequest
+	(*FreezeServiceResponse)(nil),            // 100: coral.colony.v1.FreezeServiceResponse
+	(*UnfreezeServiceRequest)(nil),           // 101: coral.colony.v1.UnfreezeServiceRequest
+	(*UnfreezeServiceResponse)(nil),          // 102: coral.colony.v1.UnfreezeServiceResponse
+	(*ListServiceFreezesRequest)(nil),        // 103: coral.colony.v1.ListServiceFreezesRequest
+	(*ListServiceFreezesResponse)(nil),       // 104: coral.colony.v1.ListServiceFreezesResponse
+	nil,                                      // 105: coral.colony.v1.Agent.LabelsEntry
+	nil,                                      // 106: coral.colony.v1.LabelAgentsRequest.SetEntry
+	nil,                                      // 107: coral.colony.v1.BulkAgentResult.LabelsEntry
+	(*GetCAStatusResponse_CertStatus)(nil),   // 108: coral.colony.v1.GetCAStatusResponse.CertStatus
+	(*GetCAStatusResponse_Stats)(nil),        // 109: coral.colony.v1.GetCAStatusResponse.Stats
+	(*MeshPingResponse_AgentPingResult)(nil), // 110: coral.colony.v1.MeshPingResponse.AgentPingResult
+	nil,                                      // 111: coral.colony.v1.ColonyEvent.AttributesEntry
+	(*timestamppb.Timestamp)(nil),            // 112: google.protobuf.Timestamp
+	(*v1.MeshTelemetry)(nil),                 // 113: coral.network.v1.MeshTelemetry
+	(*FederationError)(nil),                  // 114: coral.colony.v1.FederationError
+	(*v11.ServiceInfo)(nil),                  // 115: coral.mesh.v1.ServiceInfo
+	(*v12.RuntimeContextResponse)(nil),       // 116: coral.agent.v1.RuntimeContextResponse
+	(*v12.ResourceShedding)(nil),             // 117: coral.agent.v1.ResourceShedding
+	(*durationpb.Duration)(nil),              // 118: google.protobuf.Duration
+	(*QueryUnifiedSummaryRequest)(nil),       // 119: coral.colony.v1.QueryUnifiedSummaryRequest
+	(*QueryUnifiedTracesRequest)(nil),        // 120: coral.colony.v1.QueryUnifiedTracesRequest
+	(*QueryUnifiedMetricsRequest)(nil),       // 121: coral.colony.v1.QueryUnifiedMetricsRequest
+	(*QueryUnifiedLogsRequest)(nil),          // 122: coral.colony.v1.QueryUnifiedLogsRequest
+	(*CompareDeploymentsRequest)(nil),        // 123: coral.colony.v1.CompareDeploymentsRequest
+	(*QueryErrorsRequest)(nil),               // 124: coral.colony.v1.QueryErrorsRequest
+	(*QuerySLORequest)(nil),                  // 125: coral.colony.v1.QuerySLORequest
+	(*QueryAnomaliesRequest)(nil),            // 126: coral.colony.v1.QueryAnomaliesRequest
+	(*ListServicesRequest)(nil),              // 127: coral.colony.v1.ListServicesRequest
+	(*GetMetricPercentileRequest)(nil),       // 128: coral.colony.v1.GetMetricPercentileRequest
+	(*GetServiceActivityRequest)(nil),        // 129: coral.colony.v1.GetServiceActivityRequest
+	(*ListServiceActivityRequest)(nil),       // 130: coral.colony.v1.ListServiceActivityRequest
+	(*ExecuteQueryRequest)(nil),              // 131: coral.colony.v1.ExecuteQueryRequest
+	(*QuerySQLRequest)(nil),                  // 132: coral.colony.v1.QuerySQLRequest
+	(*CallToolRequest)(nil),                  // 133: coral.colony.v1.CallToolRequest
+	(*StreamToolRequest)(nil),                // 134: coral.colony.v1.StreamToolRequest
+	(*ListToolsRequest)(nil),                 // 135: coral.colony.v1.ListToolsRequest
+	(*QueryUnifiedSummaryResponse)(nil),      // 136: coral.colony.v1.QueryUnifiedSummaryResponse
+	(*QueryUnifiedTracesResponse)(nil),       // 137: coral.colony.v1.QueryUnifiedTracesResponse
+	(*QueryUnifiedMetricsResponse)(nil),      // 138: coral.colony.v1.QueryUnifiedMetricsResponse
+	(*QueryUnifiedLogsResponse)(nil),         // 139: coral.colony.v1.QueryUnifiedLogsResponse
+	(*CompareDeploymentsResponse)(nil),       // 140: coral.colony.v1.CompareDeploymentsResponse
+	(*QueryErrorsResponse)(nil),              // 141: coral.colony.v1.QueryErrorsResponse
+	(*QuerySLOResponse)(nil),                 // 142: coral.colony.v1.QuerySLOResponse
+	(*QueryAnomaliesResponse)(nil),           // 143: coral.colony.v1.QueryAnomaliesResponse
+	(*ListServicesResponse)(nil),             // 144: coral.colony.v1.ListServicesResponse
+	(*GetMetricPercentileResponse)(nil),      // 145: coral.colony.v1.GetMetricPercentileResponse
+	(*GetServiceActivityResponse)(nil),       // 146: coral.colony.v1.GetServiceActivityResponse
+	(*ListServiceActivityResponse)(nil),      // 147: coral.colony.v1.ListServiceActivityResponse
+	(*ExecuteQueryResponse)(nil),             // 148: coral.colony.v1.ExecuteQueryResponse
+	(*QuerySQLResponse)(nil),                 // 149: coral.colony.v1.QuerySQLResponse
+	(*CallToolResponse)(nil),                 // 150: coral.colony.v1.CallToolResponse
+	(*StreamToolResponse)(nil),               // 151: coral.colony.v1.StreamToolResponse
+	(*ListToolsResponse)(nil),                // 152: coral.colony.v1.ListToolsResponse
 }
 var file_coral_colony_v1_colony_proto_depIdxs = []int32{
-	26, // 0: coral.colony.v1.GetStatusResponse.started_at:type_name -> google.protobuf.Timestamp
-	27, // 1: coral.colony.v1.GetStatusResponse.wireguard:type_name -> coral.network.v1.MeshTelemetry
-	5,  // 2: coral.colony.v1.ListAgentsResponse.agents:type_name -> coral.colony.v1.Agent
-	26, // 3: coral.colony.v1.Agent.last_seen:type_name -> google.protobuf.Timestamp
-	28, // 4: coral.colony.v1.Agent.services:type_name -> coral.mesh.v1.ServiceInfo
-	29, // 5: coral.colony.v1.Agent.runtime_context:type_name -> coral.agent.v1.RuntimeContextResponse
-	5,  // 6: coral.colony.v1.GetTopologyResponse.agents:type_name -> coral.colony.v1.Agent
-	8,  // 7: coral.colony.v1.GetTopologyResponse.connections:type_name -> coral.colony.v1.Connection
-	0,  // 8: coral.colony.v1.Connection.evidence_layer:type_name -> coral.colony.v1.EvidenceLayer
-	11, // 9: coral.colony.v1.ReportConnectionsRequest.connections:type_name -> coral.colony.v1.L4ConnectionEntry
-	26, // 10: coral.colony.v1.L4ConnectionEntry.last_observed:type_name -> google.protobuf.Timestamp
-	23, // 11: coral.colony.v1.GetCAStatusResponse.root_ca:type_name -> coral.colony.v1.GetCAStatusResponse.CertStatus
-	23, // 12: coral.colony.v1.GetCAStatusResponse.server_intermediate:type_name -> coral.colony.v1.GetCAStatusResponse.CertStatus
-	23, // 13: coral.colony.v1.GetCAStatusResponse.agent_intermediate:type_name -> coral.colony.v1.GetCAStatusResponse.CertStatus
-	23, // 14: coral.colony.v1.GetCAStatusResponse.policy_signing:type_name -> coral.colony.v1.GetCAStatusResponse.CertStatus
-	24, // 15: coral.colony.v1.GetCAStatusResponse.statistics:type_name -> coral.colony.v1.GetCAStatusResponse.Stats
-	25, // 16: coral.colony.v1.MeshPingResponse.results:type_name -> coral.colony.v1.MeshPingResponse.AgentPingResult
-	22, // 17: coral.colony.v1.MeshAuditResponse.results:type_name -> coral.colony.v1.MeshAuditAgentResult
-	26, // 18: coral.colony.v1.GetCAStatusResponse.CertStatus.expires_at:type_name -> google.protobuf.Timestamp
-	1,  // 19: coral.colony.v1.ColonyService.GetStatus:input_type -> coral.colony.v1.GetStatusRequest
-	3,  // 20: coral.colony.v1.ColonyService.ListAgents:input_type -> coral.colony.v1.ListAgentsRequest
-	6,  // 21: coral.colony.v1.ColonyService.GetTopology:input_type -> coral.colony.v1.GetTopologyRequest
-	30, // 22: coral.colony.v1.ColonyService.QueryUnifiedSummary:input_type -> coral.colony.v1.QueryUnifiedSummaryRequest
-	31, // 23: coral.colony.v1.ColonyService.QueryUnifiedTraces:input_type -> coral.colony.v1.QueryUnifiedTracesRequest
-	32, // 24: coral.colony.v1.ColonyService.QueryUnifiedMetrics:input_type -> coral.colony.v1.QueryUnifiedMetricsRequest
-	33, // 25: coral.colony.v1.ColonyService.QueryUnifiedLogs:input_type -> coral.colony.v1.QueryUnifiedLogsRequest
-	34, // 26: coral.colony.v1.ColonyService.ListServices:input_type -> coral.colony.v1.ListServicesRequest
-	35, // 27: coral.colony.v1.ColonyService.GetMetricPercentile:input_type -> coral.colony.v1.GetMetricPercentileRequest
-	36, // 28: coral.colony.v1.ColonyService.GetServiceActivity:input_type -> coral.colony.v1.GetServiceActivityRequest
-	37, // 29: coral.colony.v1.ColonyService.ListServiceActivity:input_type -> coral.colony.v1.ListServiceActivityRequest
-	38, // 30: coral.colony.v1.ColonyService.ExecuteQuery:input_type -> coral.colony.v1.ExecuteQueryRequest
-	39, // 31: coral.colony.v1.ColonyService.CallTool:input_type -> coral.colony.v1.CallToolRequest
-	40, // 32: coral.colony.v1.ColonyService.StreamTool:input_type -> coral.colony.v1.StreamToolRequest
-	41, // 33: coral.colony.v1.ColonyService.ListTools:input_type -> coral.colony.v1.ListToolsRequest
-	12, // 34: coral.colony.v1.ColonyService.RequestCertificate:input_type -> coral.colony.v1.RequestCertificateRequest
-	14, // 35: coral.colony.v1.ColonyService.RevokeCertificate:input_type -> coral.colony.v1.RevokeCertificateRequest
-	16, // 36: coral.colony.v1.ColonyService.GetCAStatus:input_type -> coral.colony.v1.GetCAStatusRequest
-	18, // 37: coral.colony.v1.ColonyService.MeshPing:input_type -> coral.colony.v1.MeshPingRequest
-	20, // 38: coral.colony.v1.ColonyService.MeshAudit:input_type -> coral.colony.v1.MeshAuditRequest
-	9,  // 39: coral.colony.v1.ColonyService.ReportConnections:input_type -> coral.colony.v1.ReportConnectionsRequest
-	2,  // 40: coral.colony.v1.ColonyService.GetStatus:output_type -> coral.colony.v1.GetStatusResponse
-	4,  // 41: coral.colony.v1.ColonyService.ListAgents:output_type -> coral.colony.v1.ListAgentsResponse
-	7,  // 42: coral.colony.v1.ColonyService.GetTopology:output_type -> coral.colony.v1.GetTopologyResponse
-	42, // 43: coral.colony.v1.ColonyService.QueryUnifiedSummary:output_type -> coral.colony.v1.QueryUnifiedSummaryResponse
-	43, // 44: coral.colony.v1.ColonyService.QueryUnifiedTraces:output_type -> coral.colony.v1.QueryUnifiedTracesResponse
-	44, // 45: coral.colony.v1.ColonyService.QueryUnifiedMetrics:output_type -> coral.colony.v1.QueryUnifiedMetricsResponse
-	45, // 46: coral.colony.v1.ColonyService.QueryUnifiedLogs:output_type -> coral.colony.v1.QueryUnifiedLogsResponse
-	46, // 47: coral.colony.v1.ColonyService.ListServices:output_type -> coral.colony.v1.ListServicesResponse
-	47, // 48: coral.colony.v1.ColonyService.GetMetricPercentile:output_type -> coral.colony.v1.GetMetricPercentileResponse
-	48, // 49: coral.colony.v1.ColonyService.GetServiceActivity:output_type -> coral.colony.v1.GetServiceActivityResponse
-	49, // 50: coral.colony.v1.ColonyService.ListServiceActivity:output_type -> coral.colony.v1.ListServiceActivityResponse
-	50, // 51: coral.colony.v1.ColonyService.ExecuteQuery:output_type -> coral.colony.v1.ExecuteQueryResponse
-	51, // 52: coral.colony.v1.ColonyService.CallTool:output_type -> coral.colony.v1.CallToolResponse
-	52, // 53: coral.colony.v1.ColonyService.StreamTool:output_type -> coral.colony.v1.StreamToolResponse
-	53, // 54: coral.colony.v1.ColonyService.ListTools:output_type -> coral.colony.v1.ListToolsResponse
-	13, // 55: coral.colony.v1.ColonyService.RequestCertificate:output_type -> coral.colony.v1.RequestCertificateResponse
-	15, // 56: coral.colony.v1.ColonyService.RevokeCertificate:output_type -> coral.colony.v1.RevokeCertificateResponse
-	17, // 57: coral.colony.v1.ColonyService.GetCAStatus:output_type -> coral.colony.v1.GetCAStatusResponse
-	19, // 58: coral.colony.v1.ColonyService.MeshPing:output_type -> coral.colony.v1.MeshPingResponse
-	21, // 59: coral.colony.v1.ColonyService.MeshAudit:output_type -> coral.colony.v1.MeshAuditResponse
-	10, // 60: coral.colony.v1.ColonyService.ReportConnections:output_type -> coral.colony.v1.ReportConnectionsResponse
-	40, // [40:61] is the sub-list for method output_type
-	19, // [19:40] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	112, // 0: coral.colony.v1.GetStatusResponse.started_at:type_name -> google.protobuf.Timestamp
+	113, // 1: coral.colony.v1.GetStatusResponse.wireguard:type_name -> coral.network.v1.MeshTelemetry
+	6,   // 2: coral.colony.v1.ListAgentsResponse.agents:type_name -> coral.colony.v1.Agent
+	114, // 3: coral.colony.v1.ListAgentsResponse.federation_errors:type_name -> coral.colony.v1.FederationError
+	112, // 4: coral.colony.v1.Agent.last_seen:type_name -> google.protobuf.Timestamp
+	115, // 5: coral.colony.v1.Agent.services:type_name -> coral.mesh.v1.ServiceInfo
+	116, // 6: coral.colony.v1.Agent.runtime_context:type_name -> coral.agent.v1.RuntimeContextResponse
+	117, // 7: coral.colony.v1.Agent.resource_shedding:type_name -> coral.agent.v1.ResourceShedding
+	7,   // 8: coral.colony.v1.Agent.missing_capabilities:type_name -> coral.colony.v1.ProtocolCapability
+	105, // 9: coral.colony.v1.Agent.labels:type_name -> coral.colony.v1.Agent.LabelsEntry
+	112, // 10: coral.colony.v1.GetAgentHistoryRequest.since:type_name -> google.protobuf.Timestamp
+	10,  // 11: coral.colony.v1.GetAgentHistoryResponse.agents:type_name -> coral.colony.v1.AgentHistorySummary
+	11,  // 12: coral.colony.v1.GetAgentHistoryResponse.events:type_name -> coral.colony.v1.AgentHistoryEvent
+	112, // 13: coral.colony.v1.AgentHistorySummary.first_seen:type_name -> google.protobuf.Timestamp
+	112, // 14: coral.colony.v1.AgentHistorySummary.last_event:type_name -> google.protobuf.Timestamp
+	112, // 15: coral.colony.v1.AgentHistoryEvent.timestamp:type_name -> google.protobuf.Timestamp
+	6,   // 16: coral.colony.v1.GetTopologyResponse.agents:type_name -> coral.colony.v1.Agent
+	14,  // 17: coral.colony.v1.GetTopologyResponse.connections:type_name -> coral.colony.v1.Connection
+	0,   // 18: coral.colony.v1.Connection.evidence_layer:type_name -> coral.colony.v1.EvidenceLayer
+	17,  // 19: coral.colony.v1.ReportConnectionsRequest.connections:type_name -> coral.colony.v1.L4ConnectionEntry
+	112, // 20: coral.colony.v1.L4ConnectionEntry.last_observed:type_name -> google.protobuf.Timestamp
+	118, // 21: coral.colony.v1.MintCapabilityTokenRequest.ttl:type_name -> google.protobuf.Duration
+	112, // 22: coral.colony.v1.MintCapabilityTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	118, // 23: coral.colony.v1.RotateColonySecretRequest.grace_period:type_name -> google.protobuf.Duration
+	112, // 24: coral.colony.v1.RotateColonySecretResponse.previous_expires_at:type_name -> google.protobuf.Timestamp
+	28,  // 25: coral.colony.v1.RotateColonySecretResponse.stale_agents:type_name -> coral.colony.v1.StaleColonySecretAgent
+	29,  // 26: coral.colony.v1.UpgradeAgentsRequest.artifacts:type_name -> coral.colony.v1.AgentArtifact
+	34,  // 27: coral.colony.v1.UpgradeAgentsResponse.upgrade:type_name -> coral.colony.v1.AgentUpgrade
+	34,  // 28: coral.colony.v1.GetAgentUpgradeResponse.upgrade:type_name -> coral.colony.v1.AgentUpgrade
+	29,  // 29: coral.colony.v1.AgentUpgrade.artifacts:type_name -> coral.colony.v1.AgentArtifact
+	112, // 30: coral.colony.v1.AgentUpgrade.started_at:type_name -> google.protobuf.Timestamp
+	35,  // 31: coral.colony.v1.AgentUpgrade.agents:type_name -> coral.colony.v1.AgentUpgradeStatus
+	42,  // 32: coral.colony.v1.ExecAgentsResponse.results:type_name -> coral.colony.v1.BulkAgentResult
+	42,  // 33: coral.colony.v1.DrainAgentsResponse.results:type_name -> coral.colony.v1.BulkAgentResult
+	106, // 34: coral.colony.v1.LabelAgentsRequest.set:type_name -> coral.colony.v1.LabelAgentsRequest.SetEntry
+	42,  // 35: coral.colony.v1.LabelAgentsResponse.results:type_name -> coral.colony.v1.BulkAgentResult
+	107, // 36: coral.colony.v1.BulkAgentResult.labels:type_name -> coral.colony.v1.BulkAgentResult.LabelsEntry
+	108, // 37: coral.colony.v1.GetCAStatusResponse.root_ca:type_name -> coral.colony.v1.GetCAStatusResponse.CertStatus
+	108, // 38: coral.colony.v1.GetCAStatusResponse.server_intermediate:type_name -> coral.colony.v1.GetCAStatusResponse.CertStatus
+	108, // 39: coral.colony.v1.GetCAStatusResponse.agent_intermediate:type_name -> coral.colony.v1.GetCAStatusResponse.CertStatus
+	108, // 40: coral.colony.v1.GetCAStatusResponse.policy_signing:type_name -> coral.colony.v1.GetCAStatusResponse.CertStatus
+	109, // 41: coral.colony.v1.GetCAStatusResponse.statistics:type_name -> coral.colony.v1.GetCAStatusResponse.Stats
+	110, // 42: coral.colony.v1.MeshPingResponse.results:type_name -> coral.colony.v1.MeshPingResponse.AgentPingResult
+	49,  // 43: coral.colony.v1.MeshAuditResponse.results:type_name -> coral.colony.v1.MeshAuditAgentResult
+	1,   // 44: coral.colony.v1.SubscribeEventsRequest.types:type_name -> coral.colony.v1.ColonyEventType
+	1,   // 45: coral.colony.v1.ColonyEvent.type:type_name -> coral.colony.v1.ColonyEventType
+	112, // 46: coral.colony.v1.ColonyEvent.timestamp:type_name -> google.protobuf.Timestamp
+	111, // 47: coral.colony.v1.ColonyEvent.attributes:type_name -> coral.colony.v1.ColonyEvent.AttributesEntry
+	112, // 48: coral.colony.v1.LogLine.timestamp:type_name -> google.protobuf.Timestamp
+	52,  // 49: coral.colony.v1.ReportLogsRequest.lines:type_name -> coral.colony.v1.LogLine
+	112, // 50: coral.colony.v1.TailLogsRequest.since:type_name -> google.protobuf.Timestamp
+	112, // 51: coral.colony.v1.AuditEvent.timestamp:type_name -> google.protobuf.Timestamp
+	112, // 52: coral.colony.v1.ListAuditEventsRequest.since:type_name -> google.protobuf.Timestamp
+	58,  // 53: coral.colony.v1.ListAuditEventsResponse.events:type_name -> coral.colony.v1.AuditEvent
+	112, // 54: coral.colony.v1.MCPApproval.created_at:type_name -> google.protobuf.Timestamp
+	112, // 55: coral.colony.v1.MCPApproval.expires_at:type_name -> google.protobuf.Timestamp
+	112, // 56: coral.colony.v1.MCPApproval.decided_at:type_name -> google.protobuf.Timestamp
+	63,  // 57: coral.colony.v1.CreateMCPApprovalResponse.approval:type_name -> coral.colony.v1.MCPApproval
+	63,  // 58: coral.colony.v1.GetMCPApprovalResponse.approval:type_name -> coral.colony.v1.MCPApproval
+	63,  // 59: coral.colony.v1.ListMCPApprovalsResponse.approvals:type_name -> coral.colony.v1.MCPApproval
+	63,  // 60: coral.colony.v1.DecideMCPApprovalResponse.approval:type_name -> coral.colony.v1.MCPApproval
+	112, // 61: coral.colony.v1.MCPToolCall.timestamp:type_name -> google.protobuf.Timestamp
+	72,  // 62: coral.colony.v1.RecordMCPToolCallRequest.call:type_name -> coral.colony.v1.MCPToolCall
+	112, // 63: coral.colony.v1.ListMCPToolCallsRequest.since:type_name -> google.protobuf.Timestamp
+	72,  // 64: coral.colony.v1.ListMCPToolCallsResponse.calls:type_name -> coral.colony.v1.MCPToolCall
+	72,  // 65: coral.colony.v1.GetMCPToolCallResponse.call:type_name -> coral.colony.v1.MCPToolCall
+	118, // 66: coral.colony.v1.AlertRule.window:type_name -> google.protobuf.Duration
+	112, // 67: coral.colony.v1.AlertRule.created_at:type_name -> google.protobuf.Timestamp
+	112, // 68: coral.colony.v1.AlertRule.last_evaluated_at:type_name -> google.protobuf.Timestamp
+	80,  // 69: coral.colony.v1.AlertRule.firing:type_name -> coral.colony.v1.AlertFiring
+	112, // 70: coral.colony.v1.AlertFiring.since:type_name -> google.protobuf.Timestamp
+	118, // 71: coral.colony.v1.CreateAlertRuleRequest.window:type_name -> google.protobuf.Duration
+	79,  // 72: coral.colony.v1.CreateAlertRuleResponse.rule:type_name -> coral.colony.v1.AlertRule
+	79,  // 73: coral.colony.v1.ListAlertRulesResponse.rules:type_name -> coral.colony.v1.AlertRule
+	112, // 74: coral.colony.v1.ServiceGroup.updated_at:type_name -> google.protobuf.Timestamp
+	89,  // 75: coral.colony.v1.ListServiceGroupsResponse.groups:type_name -> coral.colony.v1.ServiceGroup
+	89,  // 76: coral.colony.v1.GetServiceGroupResponse.group:type_name -> coral.colony.v1.ServiceGroup
+	89,  // 77: coral.colony.v1.SetServiceGroupResponse.group:type_name -> coral.colony.v1.ServiceGroup
+	112, // 78: coral.colony.v1.ServiceFreeze.expires_at:type_name -> google.protobuf.Timestamp
+	112, // 79: coral.colony.v1.ServiceFreeze.created_at:type_name -> google.protobuf.Timestamp
+	118, // 80: coral.colony.v1.FreezeServiceRequest.duration:type_name -> google.protobuf.Duration
+	98,  // 81: coral.colony.v1.FreezeServiceResponse.freeze:type_name -> coral.colony.v1.ServiceFreeze
+	98,  // 82: coral.colony.v1.ListServiceFreezesResponse.freezes:type_name -> coral.colony.v1.ServiceFreeze
+	112, // 83: coral.colony.v1.GetCAStatusResponse.CertStatus.expires_at:type_name -> google.protobuf.Timestamp
+	2,   // 84: coral.colony.v1.ColonyService.GetStatus:input_type -> coral.colony.v1.GetStatusRequest
+	4,   // 85: coral.colony.v1.ColonyService.ListAgents:input_type -> coral.colony.v1.ListAgentsRequest
+	8,   // 86: coral.colony.v1.ColonyService.GetAgentHistory:input_type -> coral.colony.v1.GetAgentHistoryRequest
+	12,  // 87: coral.colony.v1.ColonyService.GetTopology:input_type -> coral.colony.v1.GetTopologyRequest
+	119, // 88: coral.colony.v1.ColonyService.QueryUnifiedSummary:input_type -> coral.colony.v1.QueryUnifiedSummaryRequest
+	120, // 89: coral.colony.v1.ColonyService.QueryUnifiedTraces:input_type -> coral.colony.v1.QueryUnifiedTracesRequest
+	121, // 90: coral.colony.v1.ColonyService.QueryUnifiedMetrics:input_type -> coral.colony.v1.QueryUnifiedMetricsRequest
+	122, // 91: coral.colony.v1.ColonyService.QueryUnifiedLogs:input_type -> coral.colony.v1.QueryUnifiedLogsRequest
+	123, // 92: coral.colony.v1.ColonyService.CompareDeployments:input_type -> coral.colony.v1.CompareDeploymentsRequest
+	124, // 93: coral.colony.v1.ColonyService.QueryErrors:input_type -> coral.colony.v1.QueryErrorsRequest
+	125, // 94: coral.colony.v1.ColonyService.QuerySLO:input_type -> coral.colony.v1.QuerySLORequest
+	126, // 95: coral.colony.v1.ColonyService.QueryAnomalies:input_type -> coral.colony.v1.QueryAnomaliesRequest
+	127, // 96: coral.colony.v1.ColonyService.ListServices:input_type -> coral.colony.v1.ListServicesRequest
+	128, // 97: coral.colony.v1.ColonyService.GetMetricPercentile:input_type -> coral.colony.v1.GetMetricPercentileRequest
+	129, // 98: coral.colony.v1.ColonyService.GetServiceActivity:input_type -> coral.colony.v1.GetServiceActivityRequest
+	130, // 99: coral.colony.v1.ColonyService.ListServiceActivity:input_type -> coral.colony.v1.ListServiceActivityRequest
+	131, // 100: coral.colony.v1.ColonyService.ExecuteQuery:input_type -> coral.colony.v1.ExecuteQueryRequest
+	132, // 101: coral.colony.v1.ColonyService.QuerySQL:input_type -> coral.colony.v1.QuerySQLRequest
+	133, // 102: coral.colony.v1.ColonyService.CallTool:input_type -> coral.colony.v1.CallToolRequest
+	134, // 103: coral.colony.v1.ColonyService.StreamTool:input_type -> coral.colony.v1.StreamToolRequest
+	135, // 104: coral.colony.v1.ColonyService.ListTools:input_type -> coral.colony.v1.ListToolsRequest
+	18,  // 105: coral.colony.v1.ColonyService.RequestCertificate:input_type -> coral.colony.v1.RequestCertificateRequest
+	20,  // 106: coral.colony.v1.ColonyService.RevokeCertificate:input_type -> coral.colony.v1.RevokeCertificateRequest
+	22,  // 107: coral.colony.v1.ColonyService.RevokeAgent:input_type -> coral.colony.v1.RevokeAgentRequest
+	24,  // 108: coral.colony.v1.ColonyService.MintCapabilityToken:input_type -> coral.colony.v1.MintCapabilityTokenRequest
+	26,  // 109: coral.colony.v1.ColonyService.RotateColonySecret:input_type -> coral.colony.v1.RotateColonySecretRequest
+	30,  // 110: coral.colony.v1.ColonyService.UpgradeAgents:input_type -> coral.colony.v1.UpgradeAgentsRequest
+	32,  // 111: coral.colony.v1.ColonyService.GetAgentUpgrade:input_type -> coral.colony.v1.GetAgentUpgradeRequest
+	36,  // 112: coral.colony.v1.ColonyService.ExecAgents:input_type -> coral.colony.v1.ExecAgentsRequest
+	38,  // 113: coral.colony.v1.ColonyService.DrainAgents:input_type -> coral.colony.v1.DrainAgentsRequest
+	40,  // 114: coral.colony.v1.ColonyService.LabelAgents:input_type -> coral.colony.v1.LabelAgentsRequest
+	43,  // 115: coral.colony.v1.ColonyService.GetCAStatus:input_type -> coral.colony.v1.GetCAStatusRequest
+	45,  // 116: coral.colony.v1.ColonyService.MeshPing:input_type -> coral.colony.v1.MeshPingRequest
+	47,  // 117: coral.colony.v1.ColonyService.MeshAudit:input_type -> coral.colony.v1.MeshAuditRequest
+	15,  // 118: coral.colony.v1.ColonyService.ReportConnections:input_type -> coral.colony.v1.ReportConnectionsRequest
+	50,  // 119: coral.colony.v1.ColonyService.SubscribeEvents:input_type -> coral.colony.v1.SubscribeEventsRequest
+	53,  // 120: coral.colony.v1.ColonyService.ReportLogs:input_type -> coral.colony.v1.ReportLogsRequest
+	55,  // 121: coral.colony.v1.ColonyService.TailLogs:input_type -> coral.colony.v1.TailLogsRequest
+	56,  // 122: coral.colony.v1.ColonyService.GetIdentity:input_type -> coral.colony.v1.GetIdentityRequest
+	59,  // 123: coral.colony.v1.ColonyService.RecordAuditEvent:input_type -> coral.colony.v1.RecordAuditEventRequest
+	61,  // 124: coral.colony.v1.ColonyService.ListAuditEvents:input_type -> coral.colony.v1.ListAuditEventsRequest
+	64,  // 125: coral.colony.v1.ColonyService.CreateMCPApproval:input_type -> coral.colony.v1.CreateMCPApprovalRequest
+	66,  // 126: coral.colony.v1.ColonyService.GetMCPApproval:input_type -> coral.colony.v1.GetMCPApprovalRequest
+	68,  // 127: coral.colony.v1.ColonyService.ListMCPApprovals:input_type -> coral.colony.v1.ListMCPApprovalsRequest
+	70,  // 128: coral.colony.v1.ColonyService.DecideMCPApproval:input_type -> coral.colony.v1.DecideMCPApprovalRequest
+	73,  // 129: coral.colony.v1.ColonyService.RecordMCPToolCall:input_type -> coral.colony.v1.RecordMCPToolCallRequest
+	75,  // 130: coral.colony.v1.ColonyService.ListMCPToolCalls:input_type -> coral.colony.v1.ListMCPToolCallsRequest
+	77,  // 131: coral.colony.v1.ColonyService.GetMCPToolCall:input_type -> coral.colony.v1.GetMCPToolCallRequest
+	81,  // 132: coral.colony.v1.ColonyService.CreateAlertRule:input_type -> coral.colony.v1.CreateAlertRuleRequest
+	83,  // 133: coral.colony.v1.ColonyService.ListAlertRules:input_type -> coral.colony.v1.ListAlertRulesRequest
+	85,  // 134: coral.colony.v1.ColonyService.DeleteAlertRule:input_type -> coral.colony.v1.DeleteAlertRuleRequest
+	87,  // 135: coral.colony.v1.ColonyService.SetAlertRuleEnabled:input_type -> coral.colony.v1.SetAlertRuleEnabledRequest
+	90,  // 136: coral.colony.v1.ColonyService.ListServiceGroups:input_type -> coral.colony.v1.ListServiceGroupsRequest
+	92,  // 137: coral.colony.v1.ColonyService.GetServiceGroup:input_type -> coral.colony.v1.GetServiceGroupRequest
+	94,  // 138: coral.colony.v1.ColonyService.SetServiceGroup:input_type -> coral.colony.v1.SetServiceGroupRequest
+	96,  // 139: coral.colony.v1.ColonyService.DeleteServiceGroup:input_type -> coral.colony.v1.DeleteServiceGroupRequest
+	99,  // 140: coral.colony.v1.ColonyService.FreezeService:input_type -> coral.colony.v1.FreezeServiceRequest
+	101, // 141: coral.colony.v1.ColonyService.UnfreezeService:input_type -> coral.colony.v1.UnfreezeServiceRequest
+	103, // 142: coral.colony.v1.ColonyService.ListServiceFreezes:input_type -> coral.colony.v1.ListServiceFreezesRequest
+	3,   // 143: coral.colony.v1.ColonyService.GetStatus:output_type -> coral.colony.v1.GetStatusResponse
+	5,   // 144: coral.colony.v1.ColonyService.ListAgents:output_type -> coral.colony.v1.ListAgentsResponse
+	9,   // 145: coral.colony.v1.ColonyService.GetAgentHistory:output_type -> coral.colony.v1.GetAgentHistoryResponse
+	13,  // 146: coral.colony.v1.ColonyService.GetTopology:output_type -> coral.colony.v1.GetTopologyResponse
+	136, // 147: coral.colony.v1.ColonyService.QueryUnifiedSummary:output_type -> coral.colony.v1.QueryUnifiedSummaryResponse
+	137, // 148: coral.colony.v1.ColonyService.QueryUnifiedTraces:output_type -> coral.colony.v1.QueryUnifiedTracesResponse
+	138, // 149: coral.colony.v1.ColonyService.QueryUnifiedMetrics:output_type -> coral.colony.v1.QueryUnifiedMetricsResponse
+	139, // 150: coral.colony.v1.ColonyService.QueryUnifiedLogs:output_type -> coral.colony.v1.QueryUnifiedLogsResponse
+	140, // 151: coral.colony.v1.ColonyService.CompareDeployments:output_type -> coral.colony.v1.CompareDeploymentsResponse
+	141, // 152: coral.colony.v1.ColonyService.QueryErrors:output_type -> coral.colony.v1.QueryErrorsResponse
+	142, // 153: coral.colony.v1.ColonyService.QuerySLO:output_type -> coral.colony.v1.QuerySLOResponse
+	143, // 154: coral.colony.v1.ColonyService.QueryAnomalies:output_type -> coral.colony.v1.QueryAnomaliesResponse
+	144, // 155: coral.colony.v1.ColonyService.ListServices:output_type -> coral.colony.v1.ListServicesResponse
+	145, // 156: coral.colony.v1.ColonyService.GetMetricPercentile:output_type -> coral.colony.v1.GetMetricPercentileResponse
+	146, // 157: coral.colony.v1.ColonyService.GetServiceActivity:output_type -> coral.colony.v1.GetServiceActivityResponse
+	147, // 158: coral.colony.v1.ColonyService.ListServiceActivity:output_type -> coral.colony.v1.ListServiceActivityResponse
+	148, // 159: coral.colony.v1.ColonyService.ExecuteQuery:output_type -> coral.colony.v1.ExecuteQueryResponse
+	149, // 160: coral.colony.v1.ColonyService.QuerySQL:output_type -> coral.colony.v1.QuerySQLResponse
+	150, // 161: coral.colony.v1.ColonyService.CallTool:output_type -> coral.colony.v1.CallToolResponse
+	151, // 162: coral.colony.v1.ColonyService.StreamTool:output_type -> coral.colony.v1.StreamToolResponse
+	152, // 163: coral.colony.v1.ColonyService.ListTools:output_type -> coral.colony.v1.ListToolsResponse
+	19,  // 164: coral.colony.v1.ColonyService.RequestCertificate:output_type -> coral.colony.v1.RequestCertificateResponse
+	21,  // 165: coral.colony.v1.ColonyService.RevokeCertificate:output_type -> coral.colony.v1.RevokeCertificateResponse
+	23,  // 166: coral.colony.v1.ColonyService.RevokeAgent:output_type -> coral.colony.v1.RevokeAgentResponse
+	25,  // 167: coral.colony.v1.ColonyService.MintCapabilityToken:output_type -> coral.colony.v1.MintCapabilityTokenResponse
+	27,  // 168: coral.colony.v1.ColonyService.RotateColonySecret:output_type -> coral.colony.v1.RotateColonySecretResponse
+	31,  // 169: coral.colony.v1.ColonyService.UpgradeAgents:output_type -> coral.colony.v1.UpgradeAgentsResponse
+	33,  // 170: coral.colony.v1.ColonyService.GetAgentUpgrade:output_type -> coral.colony.v1.GetAgentUpgradeResponse
+	37,  // 171: coral.colony.v1.ColonyService.ExecAgents:output_type -> coral.colony.v1.ExecAgentsResponse
+	39,  // 172: coral.colony.v1.ColonyService.DrainAgents:output_type -> coral.colony.v1.DrainAgentsResponse
+	41,  // 173: coral.colony.v1.ColonyService.LabelAgents:output_type -> coral.colony.v1.LabelAgentsResponse
+	44,  // 174: coral.colony.v1.ColonyService.GetCAStatus:output_type -> coral.colony.v1.GetCAStatusResponse
+	46,  // 175: coral.colony.v1.ColonyService.MeshPing:output_type -> coral.colony.v1.MeshPingResponse
+	48,  // 176: coral.colony.v1.ColonyService.MeshAudit:output_type -> coral.colony.v1.MeshAuditResponse
+	16,  // 177: coral.colony.v1.ColonyService.ReportConnections:output_type -> coral.colony.v1.ReportConnectionsResponse
+	51,  // 178: coral.colony.v1.ColonyService.SubscribeEvents:output_type -> coral.colony.v1.ColonyEvent
+	54,  // 179: coral.colony.v1.ColonyService.ReportLogs:output_type -> coral.colony.v1.ReportLogsResponse
+	52,  // 180: coral.colony.v1.ColonyService.TailLogs:output_type -> coral.colony.v1.LogLine
+	57,  // 181: coral.colony.v1.ColonyService.GetIdentity:output_type -> coral.colony.v1.GetIdentityResponse
+	60,  // 182: coral.colony.v1.ColonyService.RecordAuditEvent:output_type -> coral.colony.v1.RecordAuditEventResponse
+	62,  // 183: coral.colony.v1.ColonyService.ListAuditEvents:output_type -> coral.colony.v1.ListAuditEventsResponse
+	65,  // 184: coral.colony.v1.ColonyService.CreateMCPApproval:output_type -> coral.colony.v1.CreateMCPApprovalResponse
+	67,  // 185: coral.colony.v1.ColonyService.GetMCPApproval:output_type -> coral.colony.v1.GetMCPApprovalResponse
+	69,  // 186: coral.colony.v1.ColonyService.ListMCPApprovals:output_type -> coral.colony.v1.ListMCPApprovalsResponse
+	71,  // 187: coral.colony.v1.ColonyService.DecideMCPApproval:output_type -> coral.colony.v1.DecideMCPApprovalResponse
+	74,  // 188: coral.colony.v1.ColonyService.RecordMCPToolCall:output_type -> coral.colony.v1.RecordMCPToolCallResponse
+	76,  // 189: coral.colony.v1.ColonyService.ListMCPToolCalls:output_type -> coral.colony.v1.ListMCPToolCallsResponse
+	78,  // 190: coral.colony.v1.ColonyService.GetMCPToolCall:output_type -> coral.colony.v1.GetMCPToolCallResponse
+	82,  // 191: coral.colony.v1.ColonyService.CreateAlertRule:output_type -> coral.colony.v1.CreateAlertRuleResponse
+	84,  // 192: coral.colony.v1.ColonyService.ListAlertRules:output_type -> coral.colony.v1.ListAlertRulesResponse
+	86,  // 193: coral.colony.v1.ColonyService.DeleteAlertRule:output_type -> coral.colony.v1.DeleteAlertRuleResponse
+	88,  // 194: coral.colony.v1.ColonyService.SetAlertRuleEnabled:output_type -> coral.colony.v1.SetAlertRuleEnabledResponse
+	91,  // 195: coral.colony.v1.ColonyService.ListServiceGroups:output_type -> coral.colony.v1.ListServiceGroupsResponse
+	93,  // 196: coral.colony.v1.ColonyService.GetServiceGroup:output_type -> coral.colony.v1.GetServiceGroupResponse
+	95,  // 197: coral.colony.v1.ColonyService.SetServiceGroup:output_type -> coral.colony.v1.SetServiceGroupResponse
+	97,  // 198: coral.colony.v1.ColonyService.DeleteServiceGroup:output_type -> coral.colony.v1.DeleteServiceGroupResponse
+	100, // 199: coral.colony.v1.ColonyService.FreezeService:output_type -> coral.colony.v1.FreezeServiceResponse
+	102, // 200: coral.colony.v1.ColonyService.UnfreezeService:output_type -> coral.colony.v1.UnfreezeServiceResponse
+	104, // 201: coral.colony.v1.ColonyService.ListServiceFreezes:output_type -> coral.colony.v1.ListServiceFreezesResponse
+	143, // [143:202] is the sub-list for method output_type
+	84,  // [84:143] is the sub-list for method input_type
+	84,  // [84:84] is the sub-list for extension type_name
+	84,  // [84:84] is the sub-list for extension extendee
+	0,   // [0:84] is the sub-list for field type_name
 }
 
 func init() { file_coral_colony_v1_colony_proto_init() }
@@ -2113,8 +8001,8 @@ func file_coral_colony_v1_colony_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_coral_colony_v1_colony_proto_rawDesc), len(file_coral_colony_v1_colony_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   25,
+			NumEnums:      2,
+			NumMessages:   110,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -39,6 +39,9 @@ const (
 	// ColonyServiceListAgentsProcedure is the fully-qualified name of the ColonyService's ListAgents
 	// RPC.
 	ColonyServiceListAgentsProcedure = "/coral.colony.v1.ColonyService/ListAgents"
+	// ColonyServiceGetAgentHistoryProcedure is the fully-qualified name of the ColonyService's
+	// GetAgentHistory RPC.
+	ColonyServiceGetAgentHistoryProcedure = "/coral.colony.v1.ColonyService/GetAgentHistory"
 	// ColonyServiceGetTopologyProcedure is the fully-qualified name of the ColonyService's GetTopology
 	// RPC.
 	ColonyServiceGetTopologyProcedure = "/coral.colony.v1.ColonyService/GetTopology"
@@ -54,6 +57,17 @@ const (
 	// ColonyServiceQueryUnifiedLogsProcedure is the fully-qualified name of the ColonyService's
 	// QueryUnifiedLogs RPC.
 	ColonyServiceQueryUnifiedLogsProcedure = "/coral.colony.v1.ColonyService/QueryUnifiedLogs"
+	// ColonyServiceCompareDeploymentsProcedure is the fully-qualified name of the ColonyService's
+	// CompareDeployments RPC.
+	ColonyServiceCompareDeploymentsProcedure = "/coral.colony.v1.ColonyService/CompareDeployments"
+	// ColonyServiceQueryErrorsProcedure is the fully-qualified name of the ColonyService's QueryErrors
+	// RPC.
+	ColonyServiceQueryErrorsProcedure = "/coral.colony.v1.ColonyService/QueryErrors"
+	// ColonyServiceQuerySLOProcedure is the fully-qualified name of the ColonyService's QuerySLO RPC.
+	ColonyServiceQuerySLOProcedure = "/coral.colony.v1.ColonyService/QuerySLO"
+	// ColonyServiceQueryAnomaliesProcedure is the fully-qualified name of the ColonyService's
+	// QueryAnomalies RPC.
+	ColonyServiceQueryAnomaliesProcedure = "/coral.colony.v1.ColonyService/QueryAnomalies"
 	// ColonyServiceListServicesProcedure is the fully-qualified name of the ColonyService's
 	// ListServices RPC.
 	ColonyServiceListServicesProcedure = "/coral.colony.v1.ColonyService/ListServices"
@@ -69,6 +83,8 @@ const (
 	// ColonyServiceExecuteQueryProcedure is the fully-qualified name of the ColonyService's
 	// ExecuteQuery RPC.
 	ColonyServiceExecuteQueryProcedure = "/coral.colony.v1.ColonyService/ExecuteQuery"
+	// ColonyServiceQuerySQLProcedure is the fully-qualified name of the ColonyService's QuerySQL RPC.
+	ColonyServiceQuerySQLProcedure = "/coral.colony.v1.ColonyService/QuerySQL"
 	// ColonyServiceCallToolProcedure is the fully-qualified name of the ColonyService's CallTool RPC.
 	ColonyServiceCallToolProcedure = "/coral.colony.v1.ColonyService/CallTool"
 	// ColonyServiceStreamToolProcedure is the fully-qualified name of the ColonyService's StreamTool
@@ -82,6 +98,30 @@ const (
 	// ColonyServiceRevokeCertificateProcedure is the fully-qualified name of the ColonyService's
 	// RevokeCertificate RPC.
 	ColonyServiceRevokeCertificateProcedure = "/coral.colony.v1.ColonyService/RevokeCertificate"
+	// ColonyServiceRevokeAgentProcedure is the fully-qualified name of the ColonyService's RevokeAgent
+	// RPC.
+	ColonyServiceRevokeAgentProcedure = "/coral.colony.v1.ColonyService/RevokeAgent"
+	// ColonyServiceMintCapabilityTokenProcedure is the fully-qualified name of the ColonyService's
+	// MintCapabilityToken RPC.
+	ColonyServiceMintCapabilityTokenProcedure = "/coral.colony.v1.ColonyService/MintCapabilityToken"
+	// ColonyServiceRotateColonySecretProcedure is the fully-qualified name of the ColonyService's
+	// RotateColonySecret RPC.
+	ColonyServiceRotateColonySecretProcedure = "/coral.colony.v1.ColonyService/RotateColonySecret"
+	// ColonyServiceUpgradeAgentsProcedure is the fully-qualified name of the ColonyService's
+	// UpgradeAgents RPC.
+	ColonyServiceUpgradeAgentsProcedure = "/coral.colony.v1.ColonyService/UpgradeAgents"
+	// ColonyServiceGetAgentUpgradeProcedure is the fully-qualified name of the ColonyService's
+	// GetAgentUpgrade RPC.
+	ColonyServiceGetAgentUpgradeProcedure = "/coral.colony.v1.ColonyService/GetAgentUpgrade"
+	// ColonyServiceExecAgentsProcedure is the fully-qualified name of the ColonyService's ExecAgents
+	// RPC.
+	ColonyServiceExecAgentsProcedure = "/coral.colony.v1.ColonyService/ExecAgents"
+	// ColonyServiceDrainAgentsProcedure is the fully-qualified name of the ColonyService's DrainAgents
+	// RPC.
+	ColonyServiceDrainAgentsProcedure = "/coral.colony.v1.ColonyService/DrainAgents"
+	// ColonyServiceLabelAgentsProcedure is the fully-qualified name of the ColonyService's LabelAgents
+	// RPC.
+	ColonyServiceLabelAgentsProcedure = "/coral.colony.v1.ColonyService/LabelAgents"
 	// ColonyServiceGetCAStatusProcedure is the fully-qualified name of the ColonyService's GetCAStatus
 	// RPC.
 	ColonyServiceGetCAStatusProcedure = "/coral.colony.v1.ColonyService/GetCAStatus"
@@ -92,6 +132,77 @@ const (
 	// ColonyServiceReportConnectionsProcedure is the fully-qualified name of the ColonyService's
 	// ReportConnections RPC.
 	ColonyServiceReportConnectionsProcedure = "/coral.colony.v1.ColonyService/ReportConnections"
+	// ColonyServiceSubscribeEventsProcedure is the fully-qualified name of the ColonyService's
+	// SubscribeEvents RPC.
+	ColonyServiceSubscribeEventsProcedure = "/coral.colony.v1.ColonyService/SubscribeEvents"
+	// ColonyServiceReportLogsProcedure is the fully-qualified name of the ColonyService's ReportLogs
+	// RPC.
+	ColonyServiceReportLogsProcedure = "/coral.colony.v1.ColonyService/ReportLogs"
+	// ColonyServiceTailLogsProcedure is the fully-qualified name of the ColonyService's TailLogs RPC.
+	ColonyServiceTailLogsProcedure = "/coral.colony.v1.ColonyService/TailLogs"
+	// ColonyServiceGetIdentityProcedure is the fully-qualified name of the ColonyService's GetIdentity
+	// RPC.
+	ColonyServiceGetIdentityProcedure = "/coral.colony.v1.ColonyService/GetIdentity"
+	// ColonyServiceRecordAuditEventProcedure is the fully-qualified name of the ColonyService's
+	// RecordAuditEvent RPC.
+	ColonyServiceRecordAuditEventProcedure = "/coral.colony.v1.ColonyService/RecordAuditEvent"
+	// ColonyServiceListAuditEventsProcedure is the fully-qualified name of the ColonyService's
+	// ListAuditEvents RPC.
+	ColonyServiceListAuditEventsProcedure = "/coral.colony.v1.ColonyService/ListAuditEvents"
+	// ColonyServiceCreateMCPApprovalProcedure is the fully-qualified name of the ColonyService's
+	// CreateMCPApproval RPC.
+	ColonyServiceCreateMCPApprovalProcedure = "/coral.colony.v1.ColonyService/CreateMCPApproval"
+	// ColonyServiceGetMCPApprovalProcedure is the fully-qualified name of the ColonyService's
+	// GetMCPApproval RPC.
+	ColonyServiceGetMCPApprovalProcedure = "/coral.colony.v1.ColonyService/GetMCPApproval"
+	// ColonyServiceListMCPApprovalsProcedure is the fully-qualified name of the ColonyService's
+	// ListMCPApprovals RPC.
+	ColonyServiceListMCPApprovalsProcedure = "/coral.colony.v1.ColonyService/ListMCPApprovals"
+	// ColonyServiceDecideMCPApprovalProcedure is the fully-qualified name of the ColonyService's
+	// DecideMCPApproval RPC.
+	ColonyServiceDecideMCPApprovalProcedure = "/coral.colony.v1.ColonyService/DecideMCPApproval"
+	// ColonyServiceRecordMCPToolCallProcedure is the fully-qualified name of the ColonyService's
+	// RecordMCPToolCall RPC.
+	ColonyServiceRecordMCPToolCallProcedure = "/coral.colony.v1.ColonyService/RecordMCPToolCall"
+	// ColonyServiceListMCPToolCallsProcedure is the fully-qualified name of the ColonyService's
+	// ListMCPToolCalls RPC.
+	ColonyServiceListMCPToolCallsProcedure = "/coral.colony.v1.ColonyService/ListMCPToolCalls"
+	// ColonyServiceGetMCPToolCallProcedure is the fully-qualified name of the ColonyService's
+	// GetMCPToolCall RPC.
+	ColonyServiceGetMCPToolCallProcedure = "/coral.colony.v1.ColonyService/GetMCPToolCall"
+	// ColonyServiceCreateAlertRuleProcedure is the fully-qualified name of the ColonyService's
+	// CreateAlertRule RPC.
+	ColonyServiceCreateAlertRuleProcedure = "/coral.colony.v1.ColonyService/CreateAlertRule"
+	// ColonyServiceListAlertRulesProcedure is the fully-qualified name of the ColonyService's
+	// ListAlertRules RPC.
+	ColonyServiceListAlertRulesProcedure = "/coral.colony.v1.ColonyService/ListAlertRules"
+	// ColonyServiceDeleteAlertRuleProcedure is the fully-qualified name of the ColonyService's
+	// DeleteAlertRule RPC.
+	ColonyServiceDeleteAlertRuleProcedure = "/coral.colony.v1.ColonyService/DeleteAlertRule"
+	// ColonyServiceSetAlertRuleEnabledProcedure is the fully-qualified name of the ColonyService's
+	// SetAlertRuleEnabled RPC.
+	ColonyServiceSetAlertRuleEnabledProcedure = "/coral.colony.v1.ColonyService/SetAlertRuleEnabled"
+	// ColonyServiceListServiceGroupsProcedure is the fully-qualified name of the ColonyService's
+	// ListServiceGroups RPC.
+	ColonyServiceListServiceGroupsProcedure = "/coral.colony.v1.ColonyService/ListServiceGroups"
+	// ColonyServiceGetServiceGroupProcedure is the fully-qualified name of the ColonyService's
+	// GetServiceGroup RPC.
+	ColonyServiceGetServiceGroupProcedure = "/coral.colony.v1.ColonyService/GetServiceGroup"
+	// ColonyServiceSetServiceGroupProcedure is the fully-qualified name of the ColonyService's
+	// SetServiceGroup RPC.
+	ColonyServiceSetServiceGroupProcedure = "/coral.colony.v1.ColonyService/SetServiceGroup"
+	// ColonyServiceDeleteServiceGroupProcedure is the fully-qualified name of the ColonyService's
+	// DeleteServiceGroup RPC.
+	ColonyServiceDeleteServiceGroupProcedure = "/coral.colony.v1.ColonyService/DeleteServiceGroup"
+	// ColonyServiceFreezeServiceProcedure is the fully-qualified name of the ColonyService's
+	// FreezeService RPC.
+	ColonyServiceFreezeServiceProcedure = "/coral.colony.v1.ColonyService/FreezeService"
+	// ColonyServiceUnfreezeServiceProcedure is the fully-qualified name of the ColonyService's
+	// UnfreezeService RPC.
+	ColonyServiceUnfreezeServiceProcedure = "/coral.colony.v1.ColonyService/UnfreezeService"
+	// ColonyServiceListServiceFreezesProcedure is the fully-qualified name of the ColonyService's
+	// ListServiceFreezes RPC.
+	ColonyServiceListServiceFreezesProcedure = "/coral.colony.v1.ColonyService/ListServiceFreezes"
 )
 
 // ColonyServiceClient is a client for the coral.colony.v1.ColonyService service.
@@ -100,6 +211,9 @@ type ColonyServiceClient interface {
 	GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error)
 	// List connected agents.
 	ListAgents(context.Context, *connect.Request[v1.ListAgentsRequest]) (*connect.Response[v1.ListAgentsResponse], error)
+	// Return the persisted history of agent registrations, disconnects and
+	// status transitions.
+	GetAgentHistory(context.Context, *connect.Request[v1.GetAgentHistoryRequest]) (*connect.Response[v1.GetAgentHistoryResponse], error)
 	// Get network topology.
 	GetTopology(context.Context, *connect.Request[v1.GetTopologyRequest]) (*connect.Response[v1.GetTopologyResponse], error)
 	// Unified query interface (RFD 067) - comprehensive queries for detailed analysis.
@@ -107,12 +221,27 @@ type ColonyServiceClient interface {
 	QueryUnifiedTraces(context.Context, *connect.Request[v1.QueryUnifiedTracesRequest]) (*connect.Response[v1.QueryUnifiedTracesResponse], error)
 	QueryUnifiedMetrics(context.Context, *connect.Request[v1.QueryUnifiedMetricsRequest]) (*connect.Response[v1.QueryUnifiedMetricsResponse], error)
 	QueryUnifiedLogs(context.Context, *connect.Request[v1.QueryUnifiedLogsRequest]) (*connect.Response[v1.QueryUnifiedLogsResponse], error)
+	// Compare a service before and after a deployment: latency, error rate,
+	// CPU hotspots and new kinds of errors.
+	CompareDeployments(context.Context, *connect.Request[v1.CompareDeploymentsRequest]) (*connect.Response[v1.CompareDeploymentsResponse], error)
+	// Aggregate failed requests and captured Go errors by service, route and
+	// signature, with their trend over the time range.
+	QueryErrors(context.Context, *connect.Request[v1.QueryErrorsRequest]) (*connect.Response[v1.QueryErrorsResponse], error)
+	// Report the compliance and error budget burn rates of the SLOs defined in
+	// the colony config.
+	QuerySLO(context.Context, *connect.Request[v1.QuerySLORequest]) (*connect.Response[v1.QuerySLOResponse], error)
+	// List the route latency and error rate anomalies scored by the colony
+	// against the baselines learned from its history.
+	QueryAnomalies(context.Context, *connect.Request[v1.QueryAnomaliesRequest]) (*connect.Response[v1.QueryAnomaliesResponse], error)
 	// Focused query interface (RFD 076) - focused queries for scripting and CLI.
 	ListServices(context.Context, *connect.Request[v1.ListServicesRequest]) (*connect.Response[v1.ListServicesResponse], error)
 	GetMetricPercentile(context.Context, *connect.Request[v1.GetMetricPercentileRequest]) (*connect.Response[v1.GetMetricPercentileResponse], error)
 	GetServiceActivity(context.Context, *connect.Request[v1.GetServiceActivityRequest]) (*connect.Response[v1.GetServiceActivityResponse], error)
 	ListServiceActivity(context.Context, *connect.Request[v1.ListServiceActivityRequest]) (*connect.Response[v1.ListServiceActivityResponse], error)
 	ExecuteQuery(context.Context, *connect.Request[v1.ExecuteQueryRequest]) (*connect.Response[v1.ExecuteQueryResponse], error)
+	// Run a read-only SELECT with row limits and a statement timeout, for
+	// dashboards and MCP tools without file access to the colony host.
+	QuerySQL(context.Context, *connect.Request[v1.QuerySQLRequest]) (*connect.Response[v1.QuerySQLResponse], error)
 	// Execute an MCP tool and return the result.
 	CallTool(context.Context, *connect.Request[v1.CallToolRequest]) (*connect.Response[v1.CallToolResponse], error)
 	// Execute an MCP tool with streaming (bidirectional).
@@ -123,6 +252,27 @@ type ColonyServiceClient interface {
 	RequestCertificate(context.Context, *connect.Request[v1.RequestCertificateRequest]) (*connect.Response[v1.RequestCertificateResponse], error)
 	// Revoke an issued certificate.
 	RevokeCertificate(context.Context, *connect.Request[v1.RevokeCertificateRequest]) (*connect.Response[v1.RevokeCertificateResponse], error)
+	// Revoke all certificates of an agent and evict it from the mesh.
+	RevokeAgent(context.Context, *connect.Request[v1.RevokeAgentRequest]) (*connect.Response[v1.RevokeAgentResponse], error)
+	// Mint a short-lived capability token granting a subset of the caller's
+	// permissions, optionally restricted to a service.
+	MintCapabilityToken(context.Context, *connect.Request[v1.MintCapabilityTokenRequest]) (*connect.Response[v1.MintCapabilityTokenResponse], error)
+	// Rotate the colony secret agents register with, accepting the previous
+	// one for a grace period, and push the new one to connected agents.
+	RotateColonySecret(context.Context, *connect.Request[v1.RotateColonySecretRequest]) (*connect.Response[v1.RotateColonySecretResponse], error)
+	// Roll out an agent release: the colony advertises it in heartbeat
+	// responses, and agents that enabled updates install it and restart.
+	UpgradeAgents(context.Context, *connect.Request[v1.UpgradeAgentsRequest]) (*connect.Response[v1.UpgradeAgentsResponse], error)
+	// Get the agent release rollout and the progress of its agents.
+	GetAgentUpgrade(context.Context, *connect.Request[v1.GetAgentUpgradeRequest]) (*connect.Response[v1.GetAgentUpgradeResponse], error)
+	// Run a command on the agents matching a selector, a bounded number of
+	// agents at a time, and report the result of each agent.
+	ExecAgents(context.Context, *connect.Request[v1.ExecAgentsRequest]) (*connect.Response[v1.ExecAgentsResponse], error)
+	// Drain or undrain the agents matching a selector. The colony starts no
+	// new debug sessions or profiles on drained agents.
+	DrainAgents(context.Context, *connect.Request[v1.DrainAgentsRequest]) (*connect.Response[v1.DrainAgentsResponse], error)
+	// Set or remove the labels of the agents matching a selector.
+	LabelAgents(context.Context, *connect.Request[v1.LabelAgentsRequest]) (*connect.Response[v1.LabelAgentsResponse], error)
 	// Get CA status and fingerprint (RFD 047).
 	GetCAStatus(context.Context, *connect.Request[v1.GetCAStatusRequest]) (*connect.Response[v1.GetCAStatusResponse], error)
 	// Troubleshoot the WireGuard-based control mesh (RFD 097).
@@ -135,6 +285,61 @@ type ColonyServiceClient interface {
 	// Agents send periodic batches of aggregated outbound connections; the colony
 	// correlates IP addresses against the agent registry and upserts the results.
 	ReportConnections(context.Context) *connect.ClientStreamForClient[v1.ReportConnectionsRequest, v1.ReportConnectionsResponse]
+	// Stream colony events as they happen: agent connectivity, service
+	// registration, debug session lifecycle and profiling completion.
+	SubscribeEvents(context.Context, *connect.Request[v1.SubscribeEventsRequest]) (*connect.ServerStreamForClient[v1.ColonyEvent], error)
+	// Stream service log lines collected by an agent to the colony, which keeps
+	// the most recent lines of each service in a ring buffer.
+	ReportLogs(context.Context) *connect.ClientStreamForClient[v1.ReportLogsRequest, v1.ReportLogsResponse]
+	// Return recent service log lines from the colony's ring buffer and, with
+	// follow, stream new lines as agents report them.
+	TailLogs(context.Context, *connect.Request[v1.TailLogsRequest]) (*connect.ServerStreamForClient[v1.LogLine], error)
+	// Return the identity (user, role and permissions) of the API token
+	// presented with the request, and whether actions require a token.
+	GetIdentity(context.Context, *connect.Request[v1.GetIdentityRequest]) (*connect.Response[v1.GetIdentityResponse], error)
+	// Record an action performed outside the colony (shell or container exec
+	// run directly against an agent, MCP tool calls) in the audit log.
+	RecordAuditEvent(context.Context, *connect.Request[v1.RecordAuditEventRequest]) (*connect.Response[v1.RecordAuditEventResponse], error)
+	// List entries of the control-plane audit log.
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
+	// Hold an MCP tool call that requires approval until a human decides
+	// (mcp.security.require_approval).
+	CreateMCPApproval(context.Context, *connect.Request[v1.CreateMCPApprovalRequest]) (*connect.Response[v1.CreateMCPApprovalResponse], error)
+	// Return the status of an MCP tool call approval.
+	GetMCPApproval(context.Context, *connect.Request[v1.GetMCPApprovalRequest]) (*connect.Response[v1.GetMCPApprovalResponse], error)
+	// List MCP tool call approvals, pending and recently decided.
+	ListMCPApprovals(context.Context, *connect.Request[v1.ListMCPApprovalsRequest]) (*connect.Response[v1.ListMCPApprovalsResponse], error)
+	// Approve or deny a pending MCP tool call.
+	DecideMCPApproval(context.Context, *connect.Request[v1.DecideMCPApprovalRequest]) (*connect.Response[v1.DecideMCPApprovalResponse], error)
+	// Record an MCP tool call in the tool call history.
+	RecordMCPToolCall(context.Context, *connect.Request[v1.RecordMCPToolCallRequest]) (*connect.Response[v1.RecordMCPToolCallResponse], error)
+	// List recorded MCP tool calls.
+	ListMCPToolCalls(context.Context, *connect.Request[v1.ListMCPToolCallsRequest]) (*connect.Response[v1.ListMCPToolCallsResponse], error)
+	// Return one recorded MCP tool call, e.g. to replay it.
+	GetMCPToolCall(context.Context, *connect.Request[v1.GetMCPToolCallRequest]) (*connect.Response[v1.GetMCPToolCallResponse], error)
+	// Create an alert rule evaluated by the colony on a schedule.
+	CreateAlertRule(context.Context, *connect.Request[v1.CreateAlertRuleRequest]) (*connect.Response[v1.CreateAlertRuleResponse], error)
+	// List alert rules with the services they are firing for.
+	ListAlertRules(context.Context, *connect.Request[v1.ListAlertRulesRequest]) (*connect.Response[v1.ListAlertRulesResponse], error)
+	// Delete an alert rule.
+	DeleteAlertRule(context.Context, *connect.Request[v1.DeleteAlertRuleRequest]) (*connect.Response[v1.DeleteAlertRuleResponse], error)
+	// Enable or disable an alert rule.
+	SetAlertRuleEnabled(context.Context, *connect.Request[v1.SetAlertRuleEnabledRequest]) (*connect.Response[v1.SetAlertRuleEnabledResponse], error)
+	// List the service groups defined in the colony config and through the API.
+	ListServiceGroups(context.Context, *connect.Request[v1.ListServiceGroupsRequest]) (*connect.Response[v1.ListServiceGroupsResponse], error)
+	// Return one service group.
+	GetServiceGroup(context.Context, *connect.Request[v1.GetServiceGroupRequest]) (*connect.Response[v1.GetServiceGroupResponse], error)
+	// Create or replace a service group.
+	SetServiceGroup(context.Context, *connect.Request[v1.SetServiceGroupRequest]) (*connect.Response[v1.SetServiceGroupResponse], error)
+	// Delete a service group created through the API.
+	DeleteServiceGroup(context.Context, *connect.Request[v1.DeleteServiceGroupRequest]) (*connect.Response[v1.DeleteServiceGroupResponse], error)
+	// Freeze a service: the colony starts no new debug or profiling sessions
+	// against it until the freeze expires or is lifted.
+	FreezeService(context.Context, *connect.Request[v1.FreezeServiceRequest]) (*connect.Response[v1.FreezeServiceResponse], error)
+	// Lift the freeze of a service.
+	UnfreezeService(context.Context, *connect.Request[v1.UnfreezeServiceRequest]) (*connect.Response[v1.UnfreezeServiceResponse], error)
+	// List the services currently frozen.
+	ListServiceFreezes(context.Context, *connect.Request[v1.ListServiceFreezesRequest]) (*connect.Response[v1.ListServiceFreezesResponse], error)
 }
 
 // NewColonyServiceClient constructs a client for the coral.colony.v1.ColonyService service. By
@@ -160,6 +365,12 @@ func NewColonyServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(colonyServiceMethods.ByName("ListAgents")),
 			connect.WithClientOptions(opts...),
 		),
+		getAgentHistory: connect.NewClient[v1.GetAgentHistoryRequest, v1.GetAgentHistoryResponse](
+			httpClient,
+			baseURL+ColonyServiceGetAgentHistoryProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("GetAgentHistory")),
+			connect.WithClientOptions(opts...),
+		),
 		getTopology: connect.NewClient[v1.GetTopologyRequest, v1.GetTopologyResponse](
 			httpClient,
 			baseURL+ColonyServiceGetTopologyProcedure,
@@ -190,6 +401,30 @@ func NewColonyServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(colonyServiceMethods.ByName("QueryUnifiedLogs")),
 			connect.WithClientOptions(opts...),
 		),
+		compareDeployments: connect.NewClient[v1.CompareDeploymentsRequest, v1.CompareDeploymentsResponse](
+			httpClient,
+			baseURL+ColonyServiceCompareDeploymentsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("CompareDeployments")),
+			connect.WithClientOptions(opts...),
+		),
+		queryErrors: connect.NewClient[v1.QueryErrorsRequest, v1.QueryErrorsResponse](
+			httpClient,
+			baseURL+ColonyServiceQueryErrorsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("QueryErrors")),
+			connect.WithClientOptions(opts...),
+		),
+		querySLO: connect.NewClient[v1.QuerySLORequest, v1.QuerySLOResponse](
+			httpClient,
+			baseURL+ColonyServiceQuerySLOProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("QuerySLO")),
+			connect.WithClientOptions(opts...),
+		),
+		queryAnomalies: connect.NewClient[v1.QueryAnomaliesRequest, v1.QueryAnomaliesResponse](
+			httpClient,
+			baseURL+ColonyServiceQueryAnomaliesProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("QueryAnomalies")),
+			connect.WithClientOptions(opts...),
+		),
 		listServices: connect.NewClient[v1.ListServicesRequest, v1.ListServicesResponse](
 			httpClient,
 			baseURL+ColonyServiceListServicesProcedure,
@@ -220,6 +455,12 @@ func NewColonyServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(colonyServiceMethods.ByName("ExecuteQuery")),
 			connect.WithClientOptions(opts...),
 		),
+		querySQL: connect.NewClient[v1.QuerySQLRequest, v1.QuerySQLResponse](
+			httpClient,
+			baseURL+ColonyServiceQuerySQLProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("QuerySQL")),
+			connect.WithClientOptions(opts...),
+		),
 		callTool: connect.NewClient[v1.CallToolRequest, v1.CallToolResponse](
 			httpClient,
 			baseURL+ColonyServiceCallToolProcedure,
@@ -250,6 +491,54 @@ func NewColonyServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(colonyServiceMethods.ByName("RevokeCertificate")),
 			connect.WithClientOptions(opts...),
 		),
+		revokeAgent: connect.NewClient[v1.RevokeAgentRequest, v1.RevokeAgentResponse](
+			httpClient,
+			baseURL+ColonyServiceRevokeAgentProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("RevokeAgent")),
+			connect.WithClientOptions(opts...),
+		),
+		mintCapabilityToken: connect.NewClient[v1.MintCapabilityTokenRequest, v1.MintCapabilityTokenResponse](
+			httpClient,
+			baseURL+ColonyServiceMintCapabilityTokenProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("MintCapabilityToken")),
+			connect.WithClientOptions(opts...),
+		),
+		rotateColonySecret: connect.NewClient[v1.RotateColonySecretRequest, v1.RotateColonySecretResponse](
+			httpClient,
+			baseURL+ColonyServiceRotateColonySecretProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("RotateColonySecret")),
+			connect.WithClientOptions(opts...),
+		),
+		upgradeAgents: connect.NewClient[v1.UpgradeAgentsRequest, v1.UpgradeAgentsResponse](
+			httpClient,
+			baseURL+ColonyServiceUpgradeAgentsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("UpgradeAgents")),
+			connect.WithClientOptions(opts...),
+		),
+		getAgentUpgrade: connect.NewClient[v1.GetAgentUpgradeRequest, v1.GetAgentUpgradeResponse](
+			httpClient,
+			baseURL+ColonyServiceGetAgentUpgradeProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("GetAgentUpgrade")),
+			connect.WithClientOptions(opts...),
+		),
+		execAgents: connect.NewClient[v1.ExecAgentsRequest, v1.ExecAgentsResponse](
+			httpClient,
+			baseURL+ColonyServiceExecAgentsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("ExecAgents")),
+			connect.WithClientOptions(opts...),
+		),
+		drainAgents: connect.NewClient[v1.DrainAgentsRequest, v1.DrainAgentsResponse](
+			httpClient,
+			baseURL+ColonyServiceDrainAgentsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("DrainAgents")),
+			connect.WithClientOptions(opts...),
+		),
+		labelAgents: connect.NewClient[v1.LabelAgentsRequest, v1.LabelAgentsResponse](
+			httpClient,
+			baseURL+ColonyServiceLabelAgentsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("LabelAgents")),
+			connect.WithClientOptions(opts...),
+		),
 		getCAStatus: connect.NewClient[v1.GetCAStatusRequest, v1.GetCAStatusResponse](
 			httpClient,
 			baseURL+ColonyServiceGetCAStatusProcedure,
@@ -274,6 +563,150 @@ func NewColonyServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(colonyServiceMethods.ByName("ReportConnections")),
 			connect.WithClientOptions(opts...),
 		),
+		subscribeEvents: connect.NewClient[v1.SubscribeEventsRequest, v1.ColonyEvent](
+			httpClient,
+			baseURL+ColonyServiceSubscribeEventsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("SubscribeEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		reportLogs: connect.NewClient[v1.ReportLogsRequest, v1.ReportLogsResponse](
+			httpClient,
+			baseURL+ColonyServiceReportLogsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("ReportLogs")),
+			connect.WithClientOptions(opts...),
+		),
+		tailLogs: connect.NewClient[v1.TailLogsRequest, v1.LogLine](
+			httpClient,
+			baseURL+ColonyServiceTailLogsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("TailLogs")),
+			connect.WithClientOptions(opts...),
+		),
+		getIdentity: connect.NewClient[v1.GetIdentityRequest, v1.GetIdentityResponse](
+			httpClient,
+			baseURL+ColonyServiceGetIdentityProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("GetIdentity")),
+			connect.WithClientOptions(opts...),
+		),
+		recordAuditEvent: connect.NewClient[v1.RecordAuditEventRequest, v1.RecordAuditEventResponse](
+			httpClient,
+			baseURL+ColonyServiceRecordAuditEventProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("RecordAuditEvent")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse](
+			httpClient,
+			baseURL+ColonyServiceListAuditEventsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("ListAuditEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		createMCPApproval: connect.NewClient[v1.CreateMCPApprovalRequest, v1.CreateMCPApprovalResponse](
+			httpClient,
+			baseURL+ColonyServiceCreateMCPApprovalProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("CreateMCPApproval")),
+			connect.WithClientOptions(opts...),
+		),
+		getMCPApproval: connect.NewClient[v1.GetMCPApprovalRequest, v1.GetMCPApprovalResponse](
+			httpClient,
+			baseURL+ColonyServiceGetMCPApprovalProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("GetMCPApproval")),
+			connect.WithClientOptions(opts...),
+		),
+		listMCPApprovals: connect.NewClient[v1.ListMCPApprovalsRequest, v1.ListMCPApprovalsResponse](
+			httpClient,
+			baseURL+ColonyServiceListMCPApprovalsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("ListMCPApprovals")),
+			connect.WithClientOptions(opts...),
+		),
+		decideMCPApproval: connect.NewClient[v1.DecideMCPApprovalRequest, v1.DecideMCPApprovalResponse](
+			httpClient,
+			baseURL+ColonyServiceDecideMCPApprovalProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("DecideMCPApproval")),
+			connect.WithClientOptions(opts...),
+		),
+		recordMCPToolCall: connect.NewClient[v1.RecordMCPToolCallRequest, v1.RecordMCPToolCallResponse](
+			httpClient,
+			baseURL+ColonyServiceRecordMCPToolCallProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("RecordMCPToolCall")),
+			connect.WithClientOptions(opts...),
+		),
+		listMCPToolCalls: connect.NewClient[v1.ListMCPToolCallsRequest, v1.ListMCPToolCallsResponse](
+			httpClient,
+			baseURL+ColonyServiceListMCPToolCallsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("ListMCPToolCalls")),
+			connect.WithClientOptions(opts...),
+		),
+		getMCPToolCall: connect.NewClient[v1.GetMCPToolCallRequest, v1.GetMCPToolCallResponse](
+			httpClient,
+			baseURL+ColonyServiceGetMCPToolCallProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("GetMCPToolCall")),
+			connect.WithClientOptions(opts...),
+		),
+		createAlertRule: connect.NewClient[v1.CreateAlertRuleRequest, v1.CreateAlertRuleResponse](
+			httpClient,
+			baseURL+ColonyServiceCreateAlertRuleProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("CreateAlertRule")),
+			connect.WithClientOptions(opts...),
+		),
+		listAlertRules: connect.NewClient[v1.ListAlertRulesRequest, v1.ListAlertRulesResponse](
+			httpClient,
+			baseURL+ColonyServiceListAlertRulesProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("ListAlertRules")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteAlertRule: connect.NewClient[v1.DeleteAlertRuleRequest, v1.DeleteAlertRuleResponse](
+			httpClient,
+			baseURL+ColonyServiceDeleteAlertRuleProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("DeleteAlertRule")),
+			connect.WithClientOptions(opts...),
+		),
+		setAlertRuleEnabled: connect.NewClient[v1.SetAlertRuleEnabledRequest, v1.SetAlertRuleEnabledResponse](
+			httpClient,
+			baseURL+ColonyServiceSetAlertRuleEnabledProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("SetAlertRuleEnabled")),
+			connect.WithClientOptions(opts...),
+		),
+		listServiceGroups: connect.NewClient[v1.ListServiceGroupsRequest, v1.ListServiceGroupsResponse](
+			httpClient,
+			baseURL+ColonyServiceListServiceGroupsProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("ListServiceGroups")),
+			connect.WithClientOptions(opts...),
+		),
+		getServiceGroup: connect.NewClient[v1.GetServiceGroupRequest, v1.GetServiceGroupResponse](
+			httpClient,
+			baseURL+ColonyServiceGetServiceGroupProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("GetServiceGroup")),
+			connect.WithClientOptions(opts...),
+		),
+		setServiceGroup: connect.NewClient[v1.SetServiceGroupRequest, v1.SetServiceGroupResponse](
+			httpClient,
+			baseURL+ColonyServiceSetServiceGroupProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("SetServiceGroup")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteServiceGroup: connect.NewClient[v1.DeleteServiceGroupRequest, v1.DeleteServiceGroupResponse](
+			httpClient,
+			baseURL+ColonyServiceDeleteServiceGroupProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("DeleteServiceGroup")),
+			connect.WithClientOptions(opts...),
+		),
+		freezeService: connect.NewClient[v1.FreezeServiceRequest, v1.FreezeServiceResponse](
+			httpClient,
+			baseURL+ColonyServiceFreezeServiceProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("FreezeService")),
+			connect.WithClientOptions(opts...),
+		),
+		unfreezeService: connect.NewClient[v1.UnfreezeServiceRequest, v1.UnfreezeServiceResponse](
+			httpClient,
+			baseURL+ColonyServiceUnfreezeServiceProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("UnfreezeService")),
+			connect.WithClientOptions(opts...),
+		),
+		listServiceFreezes: connect.NewClient[v1.ListServiceFreezesRequest, v1.ListServiceFreezesResponse](
+			httpClient,
+			baseURL+ColonyServiceListServiceFreezesProcedure,
+			connect.WithSchema(colonyServiceMethods.ByName("ListServiceFreezes")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -281,25 +714,63 @@ func NewColonyServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 type colonyServiceClient struct {
 	getStatus           *connect.Client[v1.GetStatusRequest, v1.GetStatusResponse]
 	listAgents          *connect.Client[v1.ListAgentsRequest, v1.ListAgentsResponse]
+	getAgentHistory     *connect.Client[v1.GetAgentHistoryRequest, v1.GetAgentHistoryResponse]
 	getTopology         *connect.Client[v1.GetTopologyRequest, v1.GetTopologyResponse]
 	queryUnifiedSummary *connect.Client[v1.QueryUnifiedSummaryRequest, v1.QueryUnifiedSummaryResponse]
 	queryUnifiedTraces  *connect.Client[v1.QueryUnifiedTracesRequest, v1.QueryUnifiedTracesResponse]
 	queryUnifiedMetrics *connect.Client[v1.QueryUnifiedMetricsRequest, v1.QueryUnifiedMetricsResponse]
 	queryUnifiedLogs    *connect.Client[v1.QueryUnifiedLogsRequest, v1.QueryUnifiedLogsResponse]
+	compareDeployments  *connect.Client[v1.CompareDeploymentsRequest, v1.CompareDeploymentsResponse]
+	queryErrors         *connect.Client[v1.QueryErrorsRequest, v1.QueryErrorsResponse]
+	querySLO            *connect.Client[v1.QuerySLORequest, v1.QuerySLOResponse]
+	queryAnomalies      *connect.Client[v1.QueryAnomaliesRequest, v1.QueryAnomaliesResponse]
 	listServices        *connect.Client[v1.ListServicesRequest, v1.ListServicesResponse]
 	getMetricPercentile *connect.Client[v1.GetMetricPercentileRequest, v1.GetMetricPercentileResponse]
 	getServiceActivity  *connect.Client[v1.GetServiceActivityRequest, v1.GetServiceActivityResponse]
 	listServiceActivity *connect.Client[v1.ListServiceActivityRequest, v1.ListServiceActivityResponse]
 	executeQuery        *connect.Client[v1.ExecuteQueryRequest, v1.ExecuteQueryResponse]
+	querySQL            *connect.Client[v1.QuerySQLRequest, v1.QuerySQLResponse]
 	callTool            *connect.Client[v1.CallToolRequest, v1.CallToolResponse]
 	streamTool          *connect.Client[v1.StreamToolRequest, v1.StreamToolResponse]
 	listTools           *connect.Client[v1.ListToolsRequest, v1.ListToolsResponse]
 	requestCertificate  *connect.Client[v1.RequestCertificateRequest, v1.RequestCertificateResponse]
 	revokeCertificate   *connect.Client[v1.RevokeCertificateRequest, v1.RevokeCertificateResponse]
+	revokeAgent         *connect.Client[v1.RevokeAgentRequest, v1.RevokeAgentResponse]
+	mintCapabilityToken *connect.Client[v1.MintCapabilityTokenRequest, v1.MintCapabilityTokenResponse]
+	rotateColonySecret  *connect.Client[v1.RotateColonySecretRequest, v1.RotateColonySecretResponse]
+	upgradeAgents       *connect.Client[v1.UpgradeAgentsRequest, v1.UpgradeAgentsResponse]
+	getAgentUpgrade     *connect.Client[v1.GetAgentUpgradeRequest, v1.GetAgentUpgradeResponse]
+	execAgents          *connect.Client[v1.ExecAgentsRequest, v1.ExecAgentsResponse]
+	drainAgents         *connect.Client[v1.DrainAgentsRequest, v1.DrainAgentsResponse]
+	labelAgents         *connect.Client[v1.LabelAgentsRequest, v1.LabelAgentsResponse]
 	getCAStatus         *connect.Client[v1.GetCAStatusRequest, v1.GetCAStatusResponse]
 	meshPing            *connect.Client[v1.MeshPingRequest, v1.MeshPingResponse]
 	meshAudit           *connect.Client[v1.MeshAuditRequest, v1.MeshAuditResponse]
 	reportConnections   *connect.Client[v1.ReportConnectionsRequest, v1.ReportConnectionsResponse]
+	subscribeEvents     *connect.Client[v1.SubscribeEventsRequest, v1.ColonyEvent]
+	reportLogs          *connect.Client[v1.ReportLogsRequest, v1.ReportLogsResponse]
+	tailLogs            *connect.Client[v1.TailLogsRequest, v1.LogLine]
+	getIdentity         *connect.Client[v1.GetIdentityRequest, v1.GetIdentityResponse]
+	recordAuditEvent    *connect.Client[v1.RecordAuditEventRequest, v1.RecordAuditEventResponse]
+	listAuditEvents     *connect.Client[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse]
+	createMCPApproval   *connect.Client[v1.CreateMCPApprovalRequest, v1.CreateMCPApprovalResponse]
+	getMCPApproval      *connect.Client[v1.GetMCPApprovalRequest, v1.GetMCPApprovalResponse]
+	listMCPApprovals    *connect.Client[v1.ListMCPApprovalsRequest, v1.ListMCPApprovalsResponse]
+	decideMCPApproval   *connect.Client[v1.DecideMCPApprovalRequest, v1.DecideMCPApprovalResponse]
+	recordMCPToolCall   *connect.Client[v1.RecordMCPToolCallRequest, v1.RecordMCPToolCallResponse]
+	listMCPToolCalls    *connect.Client[v1.ListMCPToolCallsRequest, v1.ListMCPToolCallsResponse]
+	getMCPToolCall      *connect.Client[v1.GetMCPToolCallRequest, v1.GetMCPToolCallResponse]
+	createAlertRule     *connect.Client[v1.CreateAlertRuleRequest, v1.CreateAlertRuleResponse]
+	listAlertRules      *connect.Client[v1.ListAlertRulesRequest, v1.ListAlertRulesResponse]
+	deleteAlertRule     *connect.Client[v1.DeleteAlertRuleRequest, v1.DeleteAlertRuleResponse]
+	setAlertRuleEnabled *connect.Client[v1.SetAlertRuleEnabledRequest, v1.SetAlertRuleEnabledResponse]
+	listServiceGroups   *connect.Client[v1.ListServiceGroupsRequest, v1.ListServiceGroupsResponse]
+	getServiceGroup     *connect.Client[v1.GetServiceGroupRequest, v1.GetServiceGroupResponse]
+	setServiceGroup     *connect.Client[v1.SetServiceGroupRequest, v1.SetServiceGroupResponse]
+	deleteServiceGroup  *connect.Client[v1.DeleteServiceGroupRequest, v1.DeleteServiceGroupResponse]
+	freezeService       *connect.Client[v1.FreezeServiceRequest, v1.FreezeServiceResponse]
+	unfreezeService     *connect.Client[v1.UnfreezeServiceRequest, v1.UnfreezeServiceResponse]
+	listServiceFreezes  *connect.Client[v1.ListServiceFreezesRequest, v1.ListServiceFreezesResponse]
 }
 
 // GetStatus calls coral.colony.v1.ColonyService.GetStatus.
@@ -312,6 +783,11 @@ func (c *colonyServiceClient) ListAgents(ctx context.Context, req *connect.Reque
 	return c.listAgents.CallUnary(ctx, req)
 }
 
+// GetAgentHistory calls coral.colony.v1.ColonyService.GetAgentHistory.
+func (c *colonyServiceClient) GetAgentHistory(ctx context.Context, req *connect.Request[v1.GetAgentHistoryRequest]) (*connect.Response[v1.GetAgentHistoryResponse], error) {
+	return c.getAgentHistory.CallUnary(ctx, req)
+}
+
 // GetTopology calls coral.colony.v1.ColonyService.GetTopology.
 func (c *colonyServiceClient) GetTopology(ctx context.Context, req *connect.Request[v1.GetTopologyRequest]) (*connect.Response[v1.GetTopologyResponse], error) {
 	return c.getTopology.CallUnary(ctx, req)
@@ -337,6 +813,26 @@ func (c *colonyServiceClient) QueryUnifiedLogs(ctx context.Context, req *connect
 	return c.queryUnifiedLogs.CallUnary(ctx, req)
 }
 
+// CompareDeployments calls coral.colony.v1.ColonyService.CompareDeployments.
+func (c *colonyServiceClient) CompareDeployments(ctx context.Context, req *connect.Request[v1.CompareDeploymentsRequest]) (*connect.Response[v1.CompareDeploymentsResponse], error) {
+	return c.compareDeployments.CallUnary(ctx, req)
+}
+
+// QueryErrors calls coral.colony.v1.ColonyService.QueryErrors.
+func (c *colonyServiceClient) QueryErrors(ctx context.Context, req *connect.Request[v1.QueryErrorsRequest]) (*connect.Response[v1.QueryErrorsResponse], error) {
+	return c.queryErrors.CallUnary(ctx, req)
+}
+
+// QuerySLO calls coral.colony.v1.ColonyService.QuerySLO.
+func (c *colonyServiceClient) QuerySLO(ctx context.Context, req *connect.Request[v1.QuerySLORequest]) (*connect.Response[v1.QuerySLOResponse], error) {
+	return c.querySLO.CallUnary(ctx, req)
+}
+
+// QueryAnomalies calls coral.colony.v1.ColonyService.QueryAnomalies.
+func (c *colonyServiceClient) QueryAnomalies(ctx context.Context, req *connect.Request[v1.QueryAnomaliesRequest]) (*connect.Response[v1.QueryAnomaliesResponse], error) {
+	return c.queryAnomalies.CallUnary(ctx, req)
+}
+
 // ListServices calls coral.colony.v1.ColonyService.ListServices.
 func (c *colonyServiceClient) ListServices(ctx context.Context, req *connect.Request[v1.ListServicesRequest]) (*connect.Response[v1.ListServicesResponse], error) {
 	return c.listServices.CallUnary(ctx, req)
@@ -362,6 +858,11 @@ func (c *colonyServiceClient) ExecuteQuery(ctx context.Context, req *connect.Req
 	return c.executeQuery.CallUnary(ctx, req)
 }
 
+// QuerySQL calls coral.colony.v1.ColonyService.QuerySQL.
+func (c *colonyServiceClient) QuerySQL(ctx context.Context, req *connect.Request[v1.QuerySQLRequest]) (*connect.Response[v1.QuerySQLResponse], error) {
+	return c.querySQL.CallUnary(ctx, req)
+}
+
 // CallTool calls coral.colony.v1.ColonyService.CallTool.
 func (c *colonyServiceClient) CallTool(ctx context.Context, req *connect.Request[v1.CallToolRequest]) (*connect.Response[v1.CallToolResponse], error) {
 	return c.callTool.CallUnary(ctx, req)
@@ -387,6 +888,46 @@ func (c *colonyServiceClient) RevokeCertificate(ctx context.Context, req *connec
 	return c.revokeCertificate.CallUnary(ctx, req)
 }
 
+// RevokeAgent calls coral.colony.v1.ColonyService.RevokeAgent.
+func (c *colonyServiceClient) RevokeAgent(ctx context.Context, req *connect.Request[v1.RevokeAgentRequest]) (*connect.Response[v1.RevokeAgentResponse], error) {
+	return c.revokeAgent.CallUnary(ctx, req)
+}
+
+// MintCapabilityToken calls coral.colony.v1.ColonyService.MintCapabilityToken.
+func (c *colonyServiceClient) MintCapabilityToken(ctx context.Context, req *connect.Request[v1.MintCapabilityTokenRequest]) (*connect.Response[v1.MintCapabilityTokenResponse], error) {
+	return c.mintCapabilityToken.CallUnary(ctx, req)
+}
+
+// RotateColonySecret calls coral.colony.v1.ColonyService.RotateColonySecret.
+func (c *colonyServiceClient) RotateColonySecret(ctx context.Context, req *connect.Request[v1.RotateColonySecretRequest]) (*connect.Response[v1.RotateColonySecretResponse], error) {
+	return c.rotateColonySecret.CallUnary(ctx, req)
+}
+
+// UpgradeAgents calls coral.colony.v1.ColonyService.UpgradeAgents.
+func (c *colonyServiceClient) UpgradeAgents(ctx context.Context, req *connect.Request[v1.UpgradeAgentsRequest]) (*connect.Response[v1.UpgradeAgentsResponse], error) {
+	return c.upgradeAgents.CallUnary(ctx, req)
+}
+
+// GetAgentUpgrade calls coral.colony.v1.ColonyService.GetAgentUpgrade.
+func (c *colonyServiceClient) GetAgentUpgrade(ctx context.Context, req *connect.Request[v1.GetAgentUpgradeRequest]) (*connect.Response[v1.GetAgentUpgradeResponse], error) {
+	return c.getAgentUpgrade.CallUnary(ctx, req)
+}
+
+// ExecAgents calls coral.colony.v1.ColonyService.ExecAgents.
+func (c *colonyServiceClient) ExecAgents(ctx context.Context, req *connect.Request[v1.ExecAgentsRequest]) (*connect.Response[v1.ExecAgentsResponse], error) {
+	return c.execAgents.CallUnary(ctx, req)
+}
+
+// DrainAgents calls coral.colony.v1.ColonyService.DrainAgents.
+func (c *colonyServiceClient) DrainAgents(ctx context.Context, req *connect.Request[v1.DrainAgentsRequest]) (*connect.Response[v1.DrainAgentsResponse], error) {
+	return c.drainAgents.CallUnary(ctx, req)
+}
+
+// LabelAgents calls coral.colony.v1.ColonyService.LabelAgents.
+func (c *colonyServiceClient) LabelAgents(ctx context.Context, req *connect.Request[v1.LabelAgentsRequest]) (*connect.Response[v1.LabelAgentsResponse], error) {
+	return c.labelAgents.CallUnary(ctx, req)
+}
+
 // GetCAStatus calls coral.colony.v1.ColonyService.GetCAStatus.
 func (c *colonyServiceClient) GetCAStatus(ctx context.Context, req *connect.Request[v1.GetCAStatusRequest]) (*connect.Response[v1.GetCAStatusResponse], error) {
 	return c.getCAStatus.CallUnary(ctx, req)
@@ -407,12 +948,135 @@ func (c *colonyServiceClient) ReportConnections(ctx context.Context) *connect.Cl
 	return c.reportConnections.CallClientStream(ctx)
 }
 
+// SubscribeEvents calls coral.colony.v1.ColonyService.SubscribeEvents.
+func (c *colonyServiceClient) SubscribeEvents(ctx context.Context, req *connect.Request[v1.SubscribeEventsRequest]) (*connect.ServerStreamForClient[v1.ColonyEvent], error) {
+	return c.subscribeEvents.CallServerStream(ctx, req)
+}
+
+// ReportLogs calls coral.colony.v1.ColonyService.ReportLogs.
+func (c *colonyServiceClient) ReportLogs(ctx context.Context) *connect.ClientStreamForClient[v1.ReportLogsRequest, v1.ReportLogsResponse] {
+	return c.reportLogs.CallClientStream(ctx)
+}
+
+// TailLogs calls coral.colony.v1.ColonyService.TailLogs.
+func (c *colonyServiceClient) TailLogs(ctx context.Context, req *connect.Request[v1.TailLogsRequest]) (*connect.ServerStreamForClient[v1.LogLine], error) {
+	return c.tailLogs.CallServerStream(ctx, req)
+}
+
+// GetIdentity calls coral.colony.v1.ColonyService.GetIdentity.
+func (c *colonyServiceClient) GetIdentity(ctx context.Context, req *connect.Request[v1.GetIdentityRequest]) (*connect.Response[v1.GetIdentityResponse], error) {
+	return c.getIdentity.CallUnary(ctx, req)
+}
+
+// RecordAuditEvent calls coral.colony.v1.ColonyService.RecordAuditEvent.
+func (c *colonyServiceClient) RecordAuditEvent(ctx context.Context, req *connect.Request[v1.RecordAuditEventRequest]) (*connect.Response[v1.RecordAuditEventResponse], error) {
+	return c.recordAuditEvent.CallUnary(ctx, req)
+}
+
+// ListAuditEvents calls coral.colony.v1.ColonyService.ListAuditEvents.
+func (c *colonyServiceClient) ListAuditEvents(ctx context.Context, req *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
+// CreateMCPApproval calls coral.colony.v1.ColonyService.CreateMCPApproval.
+func (c *colonyServiceClient) CreateMCPApproval(ctx context.Context, req *connect.Request[v1.CreateMCPApprovalRequest]) (*connect.Response[v1.CreateMCPApprovalResponse], error) {
+	return c.createMCPApproval.CallUnary(ctx, req)
+}
+
+// GetMCPApproval calls coral.colony.v1.ColonyService.GetMCPApproval.
+func (c *colonyServiceClient) GetMCPApproval(ctx context.Context, req *connect.Request[v1.GetMCPApprovalRequest]) (*connect.Response[v1.GetMCPApprovalResponse], error) {
+	return c.getMCPApproval.CallUnary(ctx, req)
+}
+
+// ListMCPApprovals calls coral.colony.v1.ColonyService.ListMCPApprovals.
+func (c *colonyServiceClient) ListMCPApprovals(ctx context.Context, req *connect.Request[v1.ListMCPApprovalsRequest]) (*connect.Response[v1.ListMCPApprovalsResponse], error) {
+	return c.listMCPApprovals.CallUnary(ctx, req)
+}
+
+// DecideMCPApproval calls coral.colony.v1.ColonyService.DecideMCPApproval.
+func (c *colonyServiceClient) DecideMCPApproval(ctx context.Context, req *connect.Request[v1.DecideMCPApprovalRequest]) (*connect.Response[v1.DecideMCPApprovalResponse], error) {
+	return c.decideMCPApproval.CallUnary(ctx, req)
+}
+
+// RecordMCPToolCall calls coral.colony.v1.ColonyService.RecordMCPToolCall.
+func (c *colonyServiceClient) RecordMCPToolCall(ctx context.Context, req *connect.Request[v1.RecordMCPToolCallRequest]) (*connect.Response[v1.RecordMCPToolCallResponse], error) {
+	return c.recordMCPToolCall.CallUnary(ctx, req)
+}
+
+// ListMCPToolCalls calls coral.colony.v1.ColonyService.ListMCPToolCalls.
+func (c *colonyServiceClient) ListMCPToolCalls(ctx context.Context, req *connect.Request[v1.ListMCPToolCallsRequest]) (*connect.Response[v1.ListMCPToolCallsResponse], error) {
+	return c.listMCPToolCalls.CallUnary(ctx, req)
+}
+
+// GetMCPToolCall calls coral.colony.v1.ColonyService.GetMCPToolCall.
+func (c *colonyServiceClient) GetMCPToolCall(ctx context.Context, req *connect.Request[v1.GetMCPToolCallRequest]) (*connect.Response[v1.GetMCPToolCallResponse], error) {
+	return c.getMCPToolCall.CallUnary(ctx, req)
+}
+
+// CreateAlertRule calls coral.colony.v1.ColonyService.CreateAlertRule.
+func (c *colonyServiceClient) CreateAlertRule(ctx context.Context, req *connect.Request[v1.CreateAlertRuleRequest]) (*connect.Response[v1.CreateAlertRuleResponse], error) {
+	return c.createAlertRule.CallUnary(ctx, req)
+}
+
+// ListAlertRules calls coral.colony.v1.ColonyService.ListAlertRules.
+func (c *colonyServiceClient) ListAlertRules(ctx context.Context, req *connect.Request[v1.ListAlertRulesRequest]) (*connect.Response[v1.ListAlertRulesResponse], error) {
+	return c.listAlertRules.CallUnary(ctx, req)
+}
+
+// DeleteAlertRule calls coral.colony.v1.ColonyService.DeleteAlertRule.
+func (c *colonyServiceClient) DeleteAlertRule(ctx context.Context, req *connect.Request[v1.DeleteAlertRuleRequest]) (*connect.Response[v1.DeleteAlertRuleResponse], error) {
+	return c.deleteAlertRule.CallUnary(ctx, req)
+}
+
+// SetAlertRuleEnabled calls coral.colony.v1.ColonyService.SetAlertRuleEnabled.
+func (c *colonyServiceClient) SetAlertRuleEnabled(ctx context.Context, req *connect.Request[v1.SetAlertRuleEnabledRequest]) (*connect.Response[v1.SetAlertRuleEnabledResponse], error) {
+	return c.setAlertRuleEnabled.CallUnary(ctx, req)
+}
+
+// ListServiceGroups calls coral.colony.v1.ColonyService.ListServiceGroups.
+func (c *colonyServiceClient) ListServiceGroups(ctx context.Context, req *connect.Request[v1.ListServiceGroupsRequest]) (*connect.Response[v1.ListServiceGroupsResponse], error) {
+	return c.listServiceGroups.CallUnary(ctx, req)
+}
+
+// GetServiceGroup calls coral.colony.v1.ColonyService.GetServiceGroup.
+func (c *colonyServiceClient) GetServiceGroup(ctx context.Context, req *connect.Request[v1.GetServiceGroupRequest]) (*connect.Response[v1.GetServiceGroupResponse], error) {
+	return c.getServiceGroup.CallUnary(ctx, req)
+}
+
+// SetServiceGroup calls coral.colony.v1.ColonyService.SetServiceGroup.
+func (c *colonyServiceClient) SetServiceGroup(ctx context.Context, req *connect.Request[v1.SetServiceGroupRequest]) (*connect.Response[v1.SetServiceGroupResponse], error) {
+	return c.setServiceGroup.CallUnary(ctx, req)
+}
+
+// DeleteServiceGroup calls coral.colony.v1.ColonyService.DeleteServiceGroup.
+func (c *colonyServiceClient) DeleteServiceGroup(ctx context.Context, req *connect.Request[v1.DeleteServiceGroupRequest]) (*connect.Response[v1.DeleteServiceGroupResponse], error) {
+	return c.deleteServiceGroup.CallUnary(ctx, req)
+}
+
+// FreezeService calls coral.colony.v1.ColonyService.FreezeService.
+func (c *colonyServiceClient) FreezeService(ctx context.Context, req *connect.Request[v1.FreezeServiceRequest]) (*connect.Response[v1.FreezeServiceResponse], error) {
+	return c.freezeService.CallUnary(ctx, req)
+}
+
+// UnfreezeService calls coral.colony.v1.ColonyService.UnfreezeService.
+func (c *colonyServiceClient) UnfreezeService(ctx context.Context, req *connect.Request[v1.UnfreezeServiceRequest]) (*connect.Response[v1.UnfreezeServiceResponse], error) {
+	return c.unfreezeService.CallUnary(ctx, req)
+}
+
+// ListServiceFreezes calls coral.colony.v1.ColonyService.ListServiceFreezes.
+func (c *colonyServiceClient) ListServiceFreezes(ctx context.Context, req *connect.Request[v1.ListServiceFreezesRequest]) (*connect.Response[v1.ListServiceFreezesResponse], error) {
+	return c.listServiceFreezes.CallUnary(ctx, req)
+}
+
 // ColonyServiceHandler is an implementation of the coral.colony.v1.ColonyService service.
 type ColonyServiceHandler interface {
 	// Get colony status and health.
 	GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error)
 	// List connected agents.
 	ListAgents(context.Context, *connect.Request[v1.ListAgentsRequest]) (*connect.Response[v1.ListAgentsResponse], error)
+	// Return the persisted history of agent registrations, disconnects and
+	// status transitions.
+	GetAgentHistory(context.Context, *connect.Request[v1.GetAgentHistoryRequest]) (*connect.Response[v1.GetAgentHistoryResponse], error)
 	// Get network topology.
 	GetTopology(context.Context, *connect.Request[v1.GetTopologyRequest]) (*connect.Response[v1.GetTopologyResponse], error)
 	// Unified query interface (RFD 067) - comprehensive queries for detailed analysis.
@@ -420,12 +1084,27 @@ type ColonyServiceHandler interface {
 	QueryUnifiedTraces(context.Context, *connect.Request[v1.QueryUnifiedTracesRequest]) (*connect.Response[v1.QueryUnifiedTracesResponse], error)
 	QueryUnifiedMetrics(context.Context, *connect.Request[v1.QueryUnifiedMetricsRequest]) (*connect.Response[v1.QueryUnifiedMetricsResponse], error)
 	QueryUnifiedLogs(context.Context, *connect.Request[v1.QueryUnifiedLogsRequest]) (*connect.Response[v1.QueryUnifiedLogsResponse], error)
+	// Compare a service before and after a deployment: latency, error rate,
+	// CPU hotspots and new kinds of errors.
+	CompareDeployments(context.Context, *connect.Request[v1.CompareDeploymentsRequest]) (*connect.Response[v1.CompareDeploymentsResponse], error)
+	// Aggregate failed requests and captured Go errors by service, route and
+	// signature, with their trend over the time range.
+	QueryErrors(context.Context, *connect.Request[v1.QueryErrorsRequest]) (*connect.Response[v1.QueryErrorsResponse], error)
+	// Report the compliance and error budget burn rates of the SLOs defined in
+	// the colony config.
+	QuerySLO(context.Context, *connect.Request[v1.QuerySLORequest]) (*connect.Response[v1.QuerySLOResponse], error)
+	// List the route latency and error rate anomalies scored by the colony
+	// against the baselines learned from its history.
+	QueryAnomalies(context.Context, *connect.Request[v1.QueryAnomaliesRequest]) (*connect.Response[v1.QueryAnomaliesResponse], error)
 	// Focused query interface (RFD 076) - focused queries for scripting and CLI.
 	ListServices(context.Context, *connect.Request[v1.ListServicesRequest]) (*connect.Response[v1.ListServicesResponse], error)
 	GetMetricPercentile(context.Context, *connect.Request[v1.GetMetricPercentileRequest]) (*connect.Response[v1.GetMetricPercentileResponse], error)
 	GetServiceActivity(context.Context, *connect.Request[v1.GetServiceActivityRequest]) (*connect.Response[v1.GetServiceActivityResponse], error)
 	ListServiceActivity(context.Context, *connect.Request[v1.ListServiceActivityRequest]) (*connect.Response[v1.ListServiceActivityResponse], error)
 	ExecuteQuery(context.Context, *connect.Request[v1.ExecuteQueryRequest]) (*connect.Response[v1.ExecuteQueryResponse], error)
+	// Run a read-only SELECT with row limits and a statement timeout, for
+	// dashboards and MCP tools without file access to the colony host.
+	QuerySQL(context.Context, *connect.Request[v1.QuerySQLRequest]) (*connect.Response[v1.QuerySQLResponse], error)
 	// Execute an MCP tool and return the result.
 	CallTool(context.Context, *connect.Request[v1.CallToolRequest]) (*connect.Response[v1.CallToolResponse], error)
 	// Execute an MCP tool with streaming (bidirectional).
@@ -436,6 +1115,27 @@ type ColonyServiceHandler interface {
 	RequestCertificate(context.Context, *connect.Request[v1.RequestCertificateRequest]) (*connect.Response[v1.RequestCertificateResponse], error)
 	// Revoke an issued certificate.
 	RevokeCertificate(context.Context, *connect.Request[v1.RevokeCertificateRequest]) (*connect.Response[v1.RevokeCertificateResponse], error)
+	// Revoke all certificates of an agent and evict it from the mesh.
+	RevokeAgent(context.Context, *connect.Request[v1.RevokeAgentRequest]) (*connect.Response[v1.RevokeAgentResponse], error)
+	// Mint a short-lived capability token granting a subset of the caller's
+	// permissions, optionally restricted to a service.
+	MintCapabilityToken(context.Context, *connect.Request[v1.MintCapabilityTokenRequest]) (*connect.Response[v1.MintCapabilityTokenResponse], error)
+	// Rotate the colony secret agents register with, accepting the previous
+	// one for a grace period, and push the new one to connected agents.
+	RotateColonySecret(context.Context, *connect.Request[v1.RotateColonySecretRequest]) (*connect.Response[v1.RotateColonySecretResponse], error)
+	// Roll out an agent release: the colony advertises it in heartbeat
+	// responses, and agents that enabled updates install it and restart.
+	UpgradeAgents(context.Context, *connect.Request[v1.UpgradeAgentsRequest]) (*connect.Response[v1.UpgradeAgentsResponse], error)
+	// Get the agent release rollout and the progress of its agents.
+	GetAgentUpgrade(context.Context, *connect.Request[v1.GetAgentUpgradeRequest]) (*connect.Response[v1.GetAgentUpgradeResponse], error)
+	// Run a command on the agents matching a selector, a bounded number of
+	// agents at a time, and report the result of each agent.
+	ExecAgents(context.Context, *connect.Request[v1.ExecAgentsRequest]) (*connect.Response[v1.ExecAgentsResponse], error)
+	// Drain or undrain the agents matching a selector. The colony starts no
+	// new debug sessions or profiles on drained agents.
+	DrainAgents(context.Context, *connect.Request[v1.DrainAgentsRequest]) (*connect.Response[v1.DrainAgentsResponse], error)
+	// Set or remove the labels of the agents matching a selector.
+	LabelAgents(context.Context, *connect.Request[v1.LabelAgentsRequest]) (*connect.Response[v1.LabelAgentsResponse], error)
 	// Get CA status and fingerprint (RFD 047).
 	GetCAStatus(context.Context, *connect.Request[v1.GetCAStatusRequest]) (*connect.Response[v1.GetCAStatusResponse], error)
 	// Troubleshoot the WireGuard-based control mesh (RFD 097).
@@ -448,6 +1148,61 @@ type ColonyServiceHandler interface {
 	// Agents send periodic batches of aggregated outbound connections; the colony
 	// correlates IP addresses against the agent registry and upserts the results.
 	ReportConnections(context.Context, *connect.ClientStream[v1.ReportConnectionsRequest]) (*connect.Response[v1.ReportConnectionsResponse], error)
+	// Stream colony events as they happen: agent connectivity, service
+	// registration, debug session lifecycle and profiling completion.
+	SubscribeEvents(context.Context, *connect.Request[v1.SubscribeEventsRequest], *connect.ServerStream[v1.ColonyEvent]) error
+	// Stream service log lines collected by an agent to the colony, which keeps
+	// the most recent lines of each service in a ring buffer.
+	ReportLogs(context.Context, *connect.ClientStream[v1.ReportLogsRequest]) (*connect.Response[v1.ReportLogsResponse], error)
+	// Return recent service log lines from the colony's ring buffer and, with
+	// follow, stream new lines as agents report them.
+	TailLogs(context.Context, *connect.Request[v1.TailLogsRequest], *connect.ServerStream[v1.LogLine]) error
+	// Return the identity (user, role and permissions) of the API token
+	// presented with the request, and whether actions require a token.
+	GetIdentity(context.Context, *connect.Request[v1.GetIdentityRequest]) (*connect.Response[v1.GetIdentityResponse], error)
+	// Record an action performed outside the colony (shell or container exec
+	// run directly against an agent, MCP tool calls) in the audit log.
+	RecordAuditEvent(context.Context, *connect.Request[v1.RecordAuditEventRequest]) (*connect.Response[v1.RecordAuditEventResponse], error)
+	// List entries of the control-plane audit log.
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
+	// Hold an MCP tool call that requires approval until a human decides
+	// (mcp.security.require_approval).
+	CreateMCPApproval(context.Context, *connect.Request[v1.CreateMCPApprovalRequest]) (*connect.Response[v1.CreateMCPApprovalResponse], error)
+	// Return the status of an MCP tool call approval.
+	GetMCPApproval(context.Context, *connect.Request[v1.GetMCPApprovalRequest]) (*connect.Response[v1.GetMCPApprovalResponse], error)
+	// List MCP tool call approvals, pending and recently decided.
+	ListMCPApprovals(context.Context, *connect.Request[v1.ListMCPApprovalsRequest]) (*connect.Response[v1.ListMCPApprovalsResponse], error)
+	// Approve or deny a pending MCP tool call.
+	DecideMCPApproval(context.Context, *connect.Request[v1.DecideMCPApprovalRequest]) (*connect.Response[v1.DecideMCPApprovalResponse], error)
+	// Record an MCP tool call in the tool call history.
+	RecordMCPToolCall(context.Context, *connect.Request[v1.RecordMCPToolCallRequest]) (*connect.Response[v1.RecordMCPToolCallResponse], error)
+	// List recorded MCP tool calls.
+	ListMCPToolCalls(context.Context, *connect.Request[v1.ListMCPToolCallsRequest]) (*connect.Response[v1.ListMCPToolCallsResponse], error)
+	// Return one recorded MCP tool call, e.g. to replay it.
+	GetMCPToolCall(context.Context, *connect.Request[v1.GetMCPToolCallRequest]) (*connect.Response[v1.GetMCPToolCallResponse], error)
+	// Create an alert rule evaluated by the colony on a schedule.
+	CreateAlertRule(context.Context, *connect.Request[v1.CreateAlertRuleRequest]) (*connect.Response[v1.CreateAlertRuleResponse], error)
+	// List alert rules with the services they are firing for.
+	ListAlertRules(context.Context, *connect.Request[v1.ListAlertRulesRequest]) (*connect.Response[v1.ListAlertRulesResponse], error)
+	// Delete an alert rule.
+	DeleteAlertRule(context.Context, *connect.Request[v1.DeleteAlertRuleRequest]) (*connect.Response[v1.DeleteAlertRuleResponse], error)
+	// Enable or disable an alert rule.
+	SetAlertRuleEnabled(context.Context, *connect.Request[v1.SetAlertRuleEnabledRequest]) (*connect.Response[v1.SetAlertRuleEnabledResponse], error)
+	// List the service groups defined in the colony config and through the API.
+	ListServiceGroups(context.Context, *connect.Request[v1.ListServiceGroupsRequest]) (*connect.Response[v1.ListServiceGroupsResponse], error)
+	// Return one service group.
+	GetServiceGroup(context.Context, *connect.Request[v1.GetServiceGroupRequest]) (*connect.Response[v1.GetServiceGroupResponse], error)
+	// Create or replace a service group.
+	SetServiceGroup(context.Context, *connect.Request[v1.SetServiceGroupRequest]) (*connect.Response[v1.SetServiceGroupResponse], error)
+	// Delete a service group created through the API.
+	DeleteServiceGroup(context.Context, *connect.Request[v1.DeleteServiceGroupRequest]) (*connect.Response[v1.DeleteServiceGroupResponse], error)
+	// Freeze a service: the colony starts no new debug or profiling sessions
+	// against it until the freeze expires or is lifted.
+	FreezeService(context.Context, *connect.Request[v1.FreezeServiceRequest]) (*connect.Response[v1.FreezeServiceResponse], error)
+	// Lift the freeze of a service.
+	UnfreezeService(context.Context, *connect.Request[v1.UnfreezeServiceRequest]) (*connect.Response[v1.UnfreezeServiceResponse], error)
+	// List the services currently frozen.
+	ListServiceFreezes(context.Context, *connect.Request[v1.ListServiceFreezesRequest]) (*connect.Response[v1.ListServiceFreezesResponse], error)
 }
 
 // NewColonyServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -469,6 +1224,12 @@ func NewColonyServiceHandler(svc ColonyServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(colonyServiceMethods.ByName("ListAgents")),
 		connect.WithHandlerOptions(opts...),
 	)
+	colonyServiceGetAgentHistoryHandler := connect.NewUnaryHandler(
+		ColonyServiceGetAgentHistoryProcedure,
+		svc.GetAgentHistory,
+		connect.WithSchema(colonyServiceMethods.ByName("GetAgentHistory")),
+		connect.WithHandlerOptions(opts...),
+	)
 	colonyServiceGetTopologyHandler := connect.NewUnaryHandler(
 		ColonyServiceGetTopologyProcedure,
 		svc.GetTopology,
@@ -499,6 +1260,30 @@ func NewColonyServiceHandler(svc ColonyServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(colonyServiceMethods.ByName("QueryUnifiedLogs")),
 		connect.WithHandlerOptions(opts...),
 	)
+	colonyServiceCompareDeploymentsHandler := connect.NewUnaryHandler(
+		ColonyServiceCompareDeploymentsProcedure,
+		svc.CompareDeployments,
+		connect.WithSchema(colonyServiceMethods.ByName("CompareDeployments")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceQueryErrorsHandler := connect.NewUnaryHandler(
+		ColonyServiceQueryErrorsProcedure,
+		svc.QueryErrors,
+		connect.WithSchema(colonyServiceMethods.ByName("QueryErrors")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceQuerySLOHandler := connect.NewUnaryHandler(
+		ColonyServiceQuerySLOProcedure,
+		svc.QuerySLO,
+		connect.WithSchema(colonyServiceMethods.ByName("QuerySLO")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceQueryAnomaliesHandler := connect.NewUnaryHandler(
+		ColonyServiceQueryAnomaliesProcedure,
+		svc.QueryAnomalies,
+		connect.WithSchema(colonyServiceMethods.ByName("QueryAnomalies")),
+		connect.WithHandlerOptions(opts...),
+	)
 	colonyServiceListServicesHandler := connect.NewUnaryHandler(
 		ColonyServiceListServicesProcedure,
 		svc.ListServices,
@@ -529,6 +1314,12 @@ func NewColonyServiceHandler(svc ColonyServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(colonyServiceMethods.ByName("ExecuteQuery")),
 		connect.WithHandlerOptions(opts...),
 	)
+	colonyServiceQuerySQLHandler := connect.NewUnaryHandler(
+		ColonyServiceQuerySQLProcedure,
+		svc.QuerySQL,
+		connect.WithSchema(colonyServiceMethods.ByName("QuerySQL")),
+		connect.WithHandlerOptions(opts...),
+	)
 	colonyServiceCallToolHandler := connect.NewUnaryHandler(
 		ColonyServiceCallToolProcedure,
 		svc.CallTool,
@@ -559,6 +1350,54 @@ func NewColonyServiceHandler(svc ColonyServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(colonyServiceMethods.ByName("RevokeCertificate")),
 		connect.WithHandlerOptions(opts...),
 	)
+	colonyServiceRevokeAgentHandler := connect.NewUnaryHandler(
+		ColonyServiceRevokeAgentProcedure,
+		svc.RevokeAgent,
+		connect.WithSchema(colonyServiceMethods.ByName("RevokeAgent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceMintCapabilityTokenHandler := connect.NewUnaryHandler(
+		ColonyServiceMintCapabilityTokenProcedure,
+		svc.MintCapabilityToken,
+		connect.WithSchema(colonyServiceMethods.ByName("MintCapabilityToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceRotateColonySecretHandler := connect.NewUnaryHandler(
+		ColonyServiceRotateColonySecretProcedure,
+		svc.RotateColonySecret,
+		connect.WithSchema(colonyServiceMethods.ByName("RotateColonySecret")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceUpgradeAgentsHandler := connect.NewUnaryHandler(
+		ColonyServiceUpgradeAgentsProcedure,
+		svc.UpgradeAgents,
+		connect.WithSchema(colonyServiceMethods.ByName("UpgradeAgents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceGetAgentUpgradeHandler := connect.NewUnaryHandler(
+		ColonyServiceGetAgentUpgradeProcedure,
+		svc.GetAgentUpgrade,
+		connect.WithSchema(colonyServiceMethods.ByName("GetAgentUpgrade")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceExecAgentsHandler := connect.NewUnaryHandler(
+		ColonyServiceExecAgentsProcedure,
+		svc.ExecAgents,
+		connect.WithSchema(colonyServiceMethods.ByName("ExecAgents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceDrainAgentsHandler := connect.NewUnaryHandler(
+		ColonyServiceDrainAgentsProcedure,
+		svc.DrainAgents,
+		connect.WithSchema(colonyServiceMethods.ByName("DrainAgents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceLabelAgentsHandler := connect.NewUnaryHandler(
+		ColonyServiceLabelAgentsProcedure,
+		svc.LabelAgents,
+		connect.WithSchema(colonyServiceMethods.ByName("LabelAgents")),
+		connect.WithHandlerOptions(opts...),
+	)
 	colonyServiceGetCAStatusHandler := connect.NewUnaryHandler(
 		ColonyServiceGetCAStatusProcedure,
 		svc.GetCAStatus,
@@ -583,12 +1422,158 @@ func NewColonyServiceHandler(svc ColonyServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(colonyServiceMethods.ByName("ReportConnections")),
 		connect.WithHandlerOptions(opts...),
 	)
+	colonyServiceSubscribeEventsHandler := connect.NewServerStreamHandler(
+		ColonyServiceSubscribeEventsProcedure,
+		svc.SubscribeEvents,
+		connect.WithSchema(colonyServiceMethods.ByName("SubscribeEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceReportLogsHandler := connect.NewClientStreamHandler(
+		ColonyServiceReportLogsProcedure,
+		svc.ReportLogs,
+		connect.WithSchema(colonyServiceMethods.ByName("ReportLogs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceTailLogsHandler := connect.NewServerStreamHandler(
+		ColonyServiceTailLogsProcedure,
+		svc.TailLogs,
+		connect.WithSchema(colonyServiceMethods.ByName("TailLogs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceGetIdentityHandler := connect.NewUnaryHandler(
+		ColonyServiceGetIdentityProcedure,
+		svc.GetIdentity,
+		connect.WithSchema(colonyServiceMethods.ByName("GetIdentity")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceRecordAuditEventHandler := connect.NewUnaryHandler(
+		ColonyServiceRecordAuditEventProcedure,
+		svc.RecordAuditEvent,
+		connect.WithSchema(colonyServiceMethods.ByName("RecordAuditEvent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceListAuditEventsHandler := connect.NewUnaryHandler(
+		ColonyServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(colonyServiceMethods.ByName("ListAuditEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceCreateMCPApprovalHandler := connect.NewUnaryHandler(
+		ColonyServiceCreateMCPApprovalProcedure,
+		svc.CreateMCPApproval,
+		connect.WithSchema(colonyServiceMethods.ByName("CreateMCPApproval")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceGetMCPApprovalHandler := connect.NewUnaryHandler(
+		ColonyServiceGetMCPApprovalProcedure,
+		svc.GetMCPApproval,
+		connect.WithSchema(colonyServiceMethods.ByName("GetMCPApproval")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceListMCPApprovalsHandler := connect.NewUnaryHandler(
+		ColonyServiceListMCPApprovalsProcedure,
+		svc.ListMCPApprovals,
+		connect.WithSchema(colonyServiceMethods.ByName("ListMCPApprovals")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceDecideMCPApprovalHandler := connect.NewUnaryHandler(
+		ColonyServiceDecideMCPApprovalProcedure,
+		svc.DecideMCPApproval,
+		connect.WithSchema(colonyServiceMethods.ByName("DecideMCPApproval")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceRecordMCPToolCallHandler := connect.NewUnaryHandler(
+		ColonyServiceRecordMCPToolCallProcedure,
+		svc.RecordMCPToolCall,
+		connect.WithSchema(colonyServiceMethods.ByName("RecordMCPToolCall")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceListMCPToolCallsHandler := connect.NewUnaryHandler(
+		ColonyServiceListMCPToolCallsProcedure,
+		svc.ListMCPToolCalls,
+		connect.WithSchema(colonyServiceMethods.ByName("ListMCPToolCalls")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceGetMCPToolCallHandler := connect.NewUnaryHandler(
+		ColonyServiceGetMCPToolCallProcedure,
+		svc.GetMCPToolCall,
+		connect.WithSchema(colonyServiceMethods.ByName("GetMCPToolCall")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceCreateAlertRuleHandler := connect.NewUnaryHandler(
+		ColonyServiceCreateAlertRuleProcedure,
+		svc.CreateAlertRule,
+		connect.WithSchema(colonyServiceMethods.ByName("CreateAlertRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceListAlertRulesHandler := connect.NewUnaryHandler(
+		ColonyServiceListAlertRulesProcedure,
+		svc.ListAlertRules,
+		connect.WithSchema(colonyServiceMethods.ByName("ListAlertRules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceDeleteAlertRuleHandler := connect.NewUnaryHandler(
+		ColonyServiceDeleteAlertRuleProcedure,
+		svc.DeleteAlertRule,
+		connect.WithSchema(colonyServiceMethods.ByName("DeleteAlertRule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceSetAlertRuleEnabledHandler := connect.NewUnaryHandler(
+		ColonyServiceSetAlertRuleEnabledProcedure,
+		svc.SetAlertRuleEnabled,
+		connect.WithSchema(colonyServiceMethods.ByName("SetAlertRuleEnabled")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceListServiceGroupsHandler := connect.NewUnaryHandler(
+		ColonyServiceListServiceGroupsProcedure,
+		svc.ListServiceGroups,
+		connect.WithSchema(colonyServiceMethods.ByName("ListServiceGroups")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceGetServiceGroupHandler := connect.NewUnaryHandler(
+		ColonyServiceGetServiceGroupProcedure,
+		svc.GetServiceGroup,
+		connect.WithSchema(colonyServiceMethods.ByName("GetServiceGroup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceSetServiceGroupHandler := connect.NewUnaryHandler(
+		ColonyServiceSetServiceGroupProcedure,
+		svc.SetServiceGroup,
+		connect.WithSchema(colonyServiceMethods.ByName("SetServiceGroup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceDeleteServiceGroupHandler := connect.NewUnaryHandler(
+		ColonyServiceDeleteServiceGroupProcedure,
+		svc.DeleteServiceGroup,
+		connect.WithSchema(colonyServiceMethods.ByName("DeleteServiceGroup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceFreezeServiceHandler := connect.NewUnaryHandler(
+		ColonyServiceFreezeServiceProcedure,
+		svc.FreezeService,
+		connect.WithSchema(colonyServiceMethods.ByName("FreezeService")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceUnfreezeServiceHandler := connect.NewUnaryHandler(
+		ColonyServiceUnfreezeServiceProcedure,
+		svc.UnfreezeService,
+		connect.WithSchema(colonyServiceMethods.ByName("UnfreezeService")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyServiceListServiceFreezesHandler := connect.NewUnaryHandler(
+		ColonyServiceListServiceFreezesProcedure,
+		svc.ListServiceFreezes,
+		connect.WithSchema(colonyServiceMethods.ByName("ListServiceFreezes")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/coral.colony.v1.ColonyService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ColonyServiceGetStatusProcedure:
 			colonyServiceGetStatusHandler.ServeHTTP(w, r)
 		case ColonyServiceListAgentsProcedure:
 			colonyServiceListAgentsHandler.ServeHTTP(w, r)
+		case ColonyServiceGetAgentHistoryProcedure:
+			colonyServiceGetAgentHistoryHandler.ServeHTTP(w, r)
 		case ColonyServiceGetTopologyProcedure:
 			colonyServiceGetTopologyHandler.ServeHTTP(w, r)
 		case ColonyServiceQueryUnifiedSummaryProcedure:
@@ -599,6 +1584,14 @@ func NewColonyServiceHandler(svc ColonyServiceHandler, opts ...connect.HandlerOp
 			colonyServiceQueryUnifiedMetricsHandler.ServeHTTP(w, r)
 		case ColonyServiceQueryUnifiedLogsProcedure:
 			colonyServiceQueryUnifiedLogsHandler.ServeHTTP(w, r)
+		case ColonyServiceCompareDeploymentsProcedure:
+			colonyServiceCompareDeploymentsHandler.ServeHTTP(w, r)
+		case ColonyServiceQueryErrorsProcedure:
+			colonyServiceQueryErrorsHandler.ServeHTTP(w, r)
+		case ColonyServiceQuerySLOProcedure:
+			colonyServiceQuerySLOHandler.ServeHTTP(w, r)
+		case ColonyServiceQueryAnomaliesProcedure:
+			colonyServiceQueryAnomaliesHandler.ServeHTTP(w, r)
 		case ColonyServiceListServicesProcedure:
 			colonyServiceListServicesHandler.ServeHTTP(w, r)
 		case ColonyServiceGetMetricPercentileProcedure:
@@ -609,6 +1602,8 @@ func NewColonyServiceHandler(svc ColonyServiceHandler, opts ...connect.HandlerOp
 			colonyServiceListServiceActivityHandler.ServeHTTP(w, r)
 		case ColonyServiceExecuteQueryProcedure:
 			colonyServiceExecuteQueryHandler.ServeHTTP(w, r)
+		case ColonyServiceQuerySQLProcedure:
+			colonyServiceQuerySQLHandler.ServeHTTP(w, r)
 		case ColonyServiceCallToolProcedure:
 			colonyServiceCallToolHandler.ServeHTTP(w, r)
 		case ColonyServiceStreamToolProcedure:
@@ -619,6 +1614,22 @@ func NewColonyServiceHandler(svc ColonyServiceHandler, opts ...connect.HandlerOp
 			colonyServiceRequestCertificateHandler.ServeHTTP(w, r)
 		case ColonyServiceRevokeCertificateProcedure:
 			colonyServiceRevokeCertificateHandler.ServeHTTP(w, r)
+		case ColonyServiceRevokeAgentProcedure:
+			colonyServiceRevokeAgentHandler.ServeHTTP(w, r)
+		case ColonyServiceMintCapabilityTokenProcedure:
+			colonyServiceMintCapabilityTokenHandler.ServeHTTP(w, r)
+		case ColonyServiceRotateColonySecretProcedure:
+			colonyServiceRotateColonySecretHandler.ServeHTTP(w, r)
+		case ColonyServiceUpgradeAgentsProcedure:
+			colonyServiceUpgradeAgentsHandler.ServeHTTP(w, r)
+		case ColonyServiceGetAgentUpgradeProcedure:
+			colonyServiceGetAgentUpgradeHandler.ServeHTTP(w, r)
+		case ColonyServiceExecAgentsProcedure:
+			colonyServiceExecAgentsHandler.ServeHTTP(w, r)
+		case ColonyServiceDrainAgentsProcedure:
+			colonyServiceDrainAgentsHandler.ServeHTTP(w, r)
+		case ColonyServiceLabelAgentsProcedure:
+			colonyServiceLabelAgentsHandler.ServeHTTP(w, r)
 		case ColonyServiceGetCAStatusProcedure:
 			colonyServiceGetCAStatusHandler.ServeHTTP(w, r)
 		case ColonyServiceMeshPingProcedure:
@@ -627,6 +1638,54 @@ func NewColonyServiceHandler(svc ColonyServiceHandler, opts ...connect.HandlerOp
 			colonyServiceMeshAuditHandler.ServeHTTP(w, r)
 		case ColonyServiceReportConnectionsProcedure:
 			colonyServiceReportConnectionsHandler.ServeHTTP(w, r)
+		case ColonyServiceSubscribeEventsProcedure:
+			colonyServiceSubscribeEventsHandler.ServeHTTP(w, r)
+		case ColonyServiceReportLogsProcedure:
+			colonyServiceReportLogsHandler.ServeHTTP(w, r)
+		case ColonyServiceTailLogsProcedure:
+			colonyServiceTailLogsHandler.ServeHTTP(w, r)
+		case ColonyServiceGetIdentityProcedure:
+			colonyServiceGetIdentityHandler.ServeHTTP(w, r)
+		case ColonyServiceRecordAuditEventProcedure:
+			colonyServiceRecordAuditEventHandler.ServeHTTP(w, r)
+		case ColonyServiceListAuditEventsProcedure:
+			colonyServiceListAuditEventsHandler.ServeHTTP(w, r)
+		case ColonyServiceCreateMCPApprovalProcedure:
+			colonyServiceCreateMCPApprovalHandler.ServeHTTP(w, r)
+		case ColonyServiceGetMCPApprovalProcedure:
+			colonyServiceGetMCPApprovalHandler.ServeHTTP(w, r)
+		case ColonyServiceListMCPApprovalsProcedure:
+			colonyServiceListMCPApprovalsHandler.ServeHTTP(w, r)
+		case ColonyServiceDecideMCPApprovalProcedure:
+			colonyServiceDecideMCPApprovalHandler.ServeHTTP(w, r)
+		case ColonyServiceRecordMCPToolCallProcedure:
+			colonyServiceRecordMCPToolCallHandler.ServeHTTP(w, r)
+		case ColonyServiceListMCPToolCallsProcedure:
+			colonyServiceListMCPToolCallsHandler.ServeHTTP(w, r)
+		case ColonyServiceGetMCPToolCallProcedure:
+			colonyServiceGetMCPToolCallHandler.ServeHTTP(w, r)
+		case ColonyServiceCreateAlertRuleProcedure:
+			colonyServiceCreateAlertRuleHandler.ServeHTTP(w, r)
+		case ColonyServiceListAlertRulesProcedure:
+			colonyServiceListAlertRulesHandler.ServeHTTP(w, r)
+		case ColonyServiceDeleteAlertRuleProcedure:
+			colonyServiceDeleteAlertRuleHandler.ServeHTTP(w, r)
+		case ColonyServiceSetAlertRuleEnabledProcedure:
+			colonyServiceSetAlertRuleEnabledHandler.ServeHTTP(w, r)
+		case ColonyServiceListServiceGroupsProcedure:
+			colonyServiceListServiceGroupsHandler.ServeHTTP(w, r)
+		case ColonyServiceGetServiceGroupProcedure:
+			colonyServiceGetServiceGroupHandler.ServeHTTP(w, r)
+		case ColonyServiceSetServiceGroupProcedure:
+			colonyServiceSetServiceGroupHandler.ServeHTTP(w, r)
+		case ColonyServiceDeleteServiceGroupProcedure:
+			colonyServiceDeleteServiceGroupHandler.ServeHTTP(w, r)
+		case ColonyServiceFreezeServiceProcedure:
+			colonyServiceFreezeServiceHandler.ServeHTTP(w, r)
+		case ColonyServiceUnfreezeServiceProcedure:
+			colonyServiceUnfreezeServiceHandler.ServeHTTP(w, r)
+		case ColonyServiceListServiceFreezesProcedure:
+			colonyServiceListServiceFreezesHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -644,6 +1703,10 @@ func (UnimplementedColonyServiceHandler) ListAgents(context.Context, *connect.Re
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ListAgents is not implemented"))
 }
 
+func (UnimplementedColonyServiceHandler) GetAgentHistory(context.Context, *connect.Request[v1.GetAgentHistoryRequest]) (*connect.Response[v1.GetAgentHistoryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.GetAgentHistory is not implemented"))
+}
+
 func (UnimplementedColonyServiceHandler) GetTopology(context.Context, *connect.Request[v1.GetTopologyRequest]) (*connect.Response[v1.GetTopologyResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.GetTopology is not implemented"))
 }
@@ -664,6 +1727,22 @@ func (UnimplementedColonyServiceHandler) QueryUnifiedLogs(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.QueryUnifiedLogs is not implemented"))
 }
 
+func (UnimplementedColonyServiceHandler) CompareDeployments(context.Context, *connect.Request[v1.CompareDeploymentsRequest]) (*connect.Response[v1.CompareDeploymentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.CompareDeployments is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) QueryErrors(context.Context, *connect.Request[v1.QueryErrorsRequest]) (*connect.Response[v1.QueryErrorsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.QueryErrors is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) QuerySLO(context.Context, *connect.Request[v1.QuerySLORequest]) (*connect.Response[v1.QuerySLOResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.QuerySLO is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) QueryAnomalies(context.Context, *connect.Request[v1.QueryAnomaliesRequest]) (*connect.Response[v1.QueryAnomaliesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.QueryAnomalies is not implemented"))
+}
+
 func (UnimplementedColonyServiceHandler) ListServices(context.Context, *connect.Request[v1.ListServicesRequest]) (*connect.Response[v1.ListServicesResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ListServices is not implemented"))
 }
@@ -684,6 +1763,10 @@ func (UnimplementedColonyServiceHandler) ExecuteQuery(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ExecuteQuery is not implemented"))
 }
 
+func (UnimplementedColonyServiceHandler) QuerySQL(context.Context, *connect.Request[v1.QuerySQLRequest]) (*connect.Response[v1.QuerySQLResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.QuerySQL is not implemented"))
+}
+
 func (UnimplementedColonyServiceHandler) CallTool(context.Context, *connect.Request[v1.CallToolRequest]) (*connect.Response[v1.CallToolResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.CallTool is not implemented"))
 }
@@ -704,6 +1787,38 @@ func (UnimplementedColonyServiceHandler) RevokeCertificate(context.Context, *con
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.RevokeCertificate is not implemented"))
 }
 
+func (UnimplementedColonyServiceHandler) RevokeAgent(context.Context, *connect.Request[v1.RevokeAgentRequest]) (*connect.Response[v1.RevokeAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.RevokeAgent is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) MintCapabilityToken(context.Context, *connect.Request[v1.MintCapabilityTokenRequest]) (*connect.Response[v1.MintCapabilityTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.MintCapabilityToken is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) RotateColonySecret(context.Context, *connect.Request[v1.RotateColonySecretRequest]) (*connect.Response[v1.RotateColonySecretResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.RotateColonySecret is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) UpgradeAgents(context.Context, *connect.Request[v1.UpgradeAgentsRequest]) (*connect.Response[v1.UpgradeAgentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.UpgradeAgents is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) GetAgentUpgrade(context.Context, *connect.Request[v1.GetAgentUpgradeRequest]) (*connect.Response[v1.GetAgentUpgradeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.GetAgentUpgrade is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) ExecAgents(context.Context, *connect.Request[v1.ExecAgentsRequest]) (*connect.Response[v1.ExecAgentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ExecAgents is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) DrainAgents(context.Context, *connect.Request[v1.DrainAgentsRequest]) (*connect.Response[v1.DrainAgentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.DrainAgents is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) LabelAgents(context.Context, *connect.Request[v1.LabelAgentsRequest]) (*connect.Response[v1.LabelAgentsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.LabelAgents is not implemented"))
+}
+
 func (UnimplementedColonyServiceHandler) GetCAStatus(context.Context, *connect.Request[v1.GetCAStatusRequest]) (*connect.Response[v1.GetCAStatusResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.GetCAStatus is not implemented"))
 }
@@ -719,3 +1834,99 @@ func (UnimplementedColonyServiceHandler) MeshAudit(context.Context, *connect.Req
 func (UnimplementedColonyServiceHandler) ReportConnections(context.Context, *connect.ClientStream[v1.ReportConnectionsRequest]) (*connect.Response[v1.ReportConnectionsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ReportConnections is not implemented"))
 }
+
+func (UnimplementedColonyServiceHandler) SubscribeEvents(context.Context, *connect.Request[v1.SubscribeEventsRequest], *connect.ServerStream[v1.ColonyEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.SubscribeEvents is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) ReportLogs(context.Context, *connect.ClientStream[v1.ReportLogsRequest]) (*connect.Response[v1.ReportLogsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ReportLogs is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) TailLogs(context.Context, *connect.Request[v1.TailLogsRequest], *connect.ServerStream[v1.LogLine]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.TailLogs is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) GetIdentity(context.Context, *connect.Request[v1.GetIdentityRequest]) (*connect.Response[v1.GetIdentityResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.GetIdentity is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) RecordAuditEvent(context.Context, *connect.Request[v1.RecordAuditEventRequest]) (*connect.Response[v1.RecordAuditEventResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.RecordAuditEvent is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ListAuditEvents is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) CreateMCPApproval(context.Context, *connect.Request[v1.CreateMCPApprovalRequest]) (*connect.Response[v1.CreateMCPApprovalResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.CreateMCPApproval is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) GetMCPApproval(context.Context, *connect.Request[v1.GetMCPApprovalRequest]) (*connect.Response[v1.GetMCPApprovalResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.GetMCPApproval is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) ListMCPApprovals(context.Context, *connect.Request[v1.ListMCPApprovalsRequest]) (*connect.Response[v1.ListMCPApprovalsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ListMCPApprovals is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) DecideMCPApproval(context.Context, *connect.Request[v1.DecideMCPApprovalRequest]) (*connect.Response[v1.DecideMCPApprovalResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.DecideMCPApproval is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) RecordMCPToolCall(context.Context, *connect.Request[v1.RecordMCPToolCallRequest]) (*connect.Response[v1.RecordMCPToolCallResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.RecordMCPToolCall is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) ListMCPToolCalls(context.Context, *connect.Request[v1.ListMCPToolCallsRequest]) (*connect.Response[v1.ListMCPToolCallsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ListMCPToolCalls is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) GetMCPToolCall(context.Context, *connect.Request[v1.GetMCPToolCallRequest]) (*connect.Response[v1.GetMCPToolCallResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.GetMCPToolCall is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) CreateAlertRule(context.Context, *connect.Request[v1.CreateAlertRuleRequest]) (*connect.Response[v1.CreateAlertRuleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.CreateAlertRule is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) ListAlertRules(context.Context, *connect.Request[v1.ListAlertRulesRequest]) (*connect.Response[v1.ListAlertRulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ListAlertRules is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) DeleteAlertRule(context.Context, *connect.Request[v1.DeleteAlertRuleRequest]) (*connect.Response[v1.DeleteAlertRuleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.DeleteAlertRule is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) SetAlertRuleEnabled(context.Context, *connect.Request[v1.SetAlertRuleEnabledRequest]) (*connect.Response[v1.SetAlertRuleEnabledResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.SetAlertRuleEnabled is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) ListServiceGroups(context.Context, *connect.Request[v1.ListServiceGroupsRequest]) (*connect.Response[v1.ListServiceGroupsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ListServiceGroups is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) GetServiceGroup(context.Context, *connect.Request[v1.GetServiceGroupRequest]) (*connect.Response[v1.GetServiceGroupResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.GetServiceGroup is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) SetServiceGroup(context.Context, *connect.Request[v1.SetServiceGroupRequest]) (*connect.Response[v1.SetServiceGroupResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.SetServiceGroup is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) DeleteServiceGroup(context.Context, *connect.Request[v1.DeleteServiceGroupRequest]) (*connect.Response[v1.DeleteServiceGroupResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.DeleteServiceGroup is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) FreezeService(context.Context, *connect.Request[v1.FreezeServiceRequest]) (*connect.Response[v1.FreezeServiceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.FreezeService is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) UnfreezeService(context.Context, *connect.Request[v1.UnfreezeServiceRequest]) (*connect.Response[v1.UnfreezeServiceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.UnfreezeService is not implemented"))
+}
+
+func (UnimplementedColonyServiceHandler) ListServiceFreezes(context.Context, *connect.Request[v1.ListServiceFreezesRequest]) (*connect.Response[v1.ListServiceFreezesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyService.ListServiceFreezes is not implemented"))
+}
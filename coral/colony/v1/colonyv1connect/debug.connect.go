@@ -11,6 +11,7 @@ import (
 	strings "strings"
 
 	connect "connectrpc.com/connect"
+	v11 "github.com/coral-mesh/coral/coral/agent/v1"
 	v1 "github.com/coral-mesh/coral/coral/colony/v1"
 )
 
@@ -46,6 +47,9 @@ const (
 	// ColonyDebugServiceQueryUprobeEventsProcedure is the fully-qualified name of the
 	// ColonyDebugService's QueryUprobeEvents RPC.
 	ColonyDebugServiceQueryUprobeEventsProcedure = "/coral.colony.v1.ColonyDebugService/QueryUprobeEvents"
+	// ColonyDebugServiceIngestUprobeEventsProcedure is the fully-qualified name of the
+	// ColonyDebugService's IngestUprobeEvents RPC.
+	ColonyDebugServiceIngestUprobeEventsProcedure = "/coral.colony.v1.ColonyDebugService/IngestUprobeEvents"
 	// ColonyDebugServiceListDebugSessionsProcedure is the fully-qualified name of the
 	// ColonyDebugService's ListDebugSessions RPC.
 	ColonyDebugServiceListDebugSessionsProcedure = "/coral.colony.v1.ColonyDebugService/ListDebugSessions"
@@ -61,6 +65,9 @@ const (
 	// ColonyDebugServiceProfileFunctionsProcedure is the fully-qualified name of the
 	// ColonyDebugService's ProfileFunctions RPC.
 	ColonyDebugServiceProfileFunctionsProcedure = "/coral.colony.v1.ColonyDebugService/ProfileFunctions"
+	// ColonyDebugServiceCancelProfileFunctionsProcedure is the fully-qualified name of the
+	// ColonyDebugService's CancelProfileFunctions RPC.
+	ColonyDebugServiceCancelProfileFunctionsProcedure = "/coral.colony.v1.ColonyDebugService/CancelProfileFunctions"
 	// ColonyDebugServiceProfileCPUProcedure is the fully-qualified name of the ColonyDebugService's
 	// ProfileCPU RPC.
 	ColonyDebugServiceProfileCPUProcedure = "/coral.colony.v1.ColonyDebugService/ProfileCPU"
@@ -70,6 +77,12 @@ const (
 	// ColonyDebugServiceProfileMemoryProcedure is the fully-qualified name of the ColonyDebugService's
 	// ProfileMemory RPC.
 	ColonyDebugServiceProfileMemoryProcedure = "/coral.colony.v1.ColonyDebugService/ProfileMemory"
+	// ColonyDebugServiceProfileIOProcedure is the fully-qualified name of the ColonyDebugService's
+	// ProfileIO RPC.
+	ColonyDebugServiceProfileIOProcedure = "/coral.colony.v1.ColonyDebugService/ProfileIO"
+	// ColonyDebugServiceProfileLocksProcedure is the fully-qualified name of the ColonyDebugService's
+	// ProfileLocks RPC.
+	ColonyDebugServiceProfileLocksProcedure = "/coral.colony.v1.ColonyDebugService/ProfileLocks"
 	// ColonyDebugServiceQueryHistoricalMemoryProfileProcedure is the fully-qualified name of the
 	// ColonyDebugService's QueryHistoricalMemoryProfile RPC.
 	ColonyDebugServiceQueryHistoricalMemoryProfileProcedure = "/coral.colony.v1.ColonyDebugService/QueryHistoricalMemoryProfile"
@@ -82,6 +95,51 @@ const (
 	// ColonyDebugServiceListCorrelationsProcedure is the fully-qualified name of the
 	// ColonyDebugService's ListCorrelations RPC.
 	ColonyDebugServiceListCorrelationsProcedure = "/coral.colony.v1.ColonyDebugService/ListCorrelations"
+	// ColonyDebugServiceListCoreDumpsProcedure is the fully-qualified name of the ColonyDebugService's
+	// ListCoreDumps RPC.
+	ColonyDebugServiceListCoreDumpsProcedure = "/coral.colony.v1.ColonyDebugService/ListCoreDumps"
+	// ColonyDebugServiceDownloadCoreDumpProcedure is the fully-qualified name of the
+	// ColonyDebugService's DownloadCoreDump RPC.
+	ColonyDebugServiceDownloadCoreDumpProcedure = "/coral.colony.v1.ColonyDebugService/DownloadCoreDump"
+	// ColonyDebugServiceCreateProfileScheduleProcedure is the fully-qualified name of the
+	// ColonyDebugService's CreateProfileSchedule RPC.
+	ColonyDebugServiceCreateProfileScheduleProcedure = "/coral.colony.v1.ColonyDebugService/CreateProfileSchedule"
+	// ColonyDebugServiceListProfileSchedulesProcedure is the fully-qualified name of the
+	// ColonyDebugService's ListProfileSchedules RPC.
+	ColonyDebugServiceListProfileSchedulesProcedure = "/coral.colony.v1.ColonyDebugService/ListProfileSchedules"
+	// ColonyDebugServiceDeleteProfileScheduleProcedure is the fully-qualified name of the
+	// ColonyDebugService's DeleteProfileSchedule RPC.
+	ColonyDebugServiceDeleteProfileScheduleProcedure = "/coral.colony.v1.ColonyDebugService/DeleteProfileSchedule"
+	// ColonyDebugServiceListProfileRunsProcedure is the fully-qualified name of the
+	// ColonyDebugService's ListProfileRuns RPC.
+	ColonyDebugServiceListProfileRunsProcedure = "/coral.colony.v1.ColonyDebugService/ListProfileRuns"
+	// ColonyDebugServiceGetProfileRunProcedure is the fully-qualified name of the ColonyDebugService's
+	// GetProfileRun RPC.
+	ColonyDebugServiceGetProfileRunProcedure = "/coral.colony.v1.ColonyDebugService/GetProfileRun"
+	// ColonyDebugServiceDescribeFunctionProcedure is the fully-qualified name of the
+	// ColonyDebugService's DescribeFunction RPC.
+	ColonyDebugServiceDescribeFunctionProcedure = "/coral.colony.v1.ColonyDebugService/DescribeFunction"
+	// ColonyDebugServiceCaptureHttpProcedure is the fully-qualified name of the ColonyDebugService's
+	// CaptureHttp RPC.
+	ColonyDebugServiceCaptureHttpProcedure = "/coral.colony.v1.ColonyDebugService/CaptureHttp"
+	// ColonyDebugServiceCaptureTlsProcedure is the fully-qualified name of the ColonyDebugService's
+	// CaptureTls RPC.
+	ColonyDebugServiceCaptureTlsProcedure = "/coral.colony.v1.ColonyDebugService/CaptureTls"
+	// ColonyDebugServiceTraceRuntimeProcedure is the fully-qualified name of the ColonyDebugService's
+	// TraceRuntime RPC.
+	ColonyDebugServiceTraceRuntimeProcedure = "/coral.colony.v1.ColonyDebugService/TraceRuntime"
+	// ColonyDebugServiceTraceSyscallsProcedure is the fully-qualified name of the ColonyDebugService's
+	// TraceSyscalls RPC.
+	ColonyDebugServiceTraceSyscallsProcedure = "/coral.colony.v1.ColonyDebugService/TraceSyscalls"
+	// ColonyDebugServiceUploadShellRecordingProcedure is the fully-qualified name of the
+	// ColonyDebugService's UploadShellRecording RPC.
+	ColonyDebugServiceUploadShellRecordingProcedure = "/coral.colony.v1.ColonyDebugService/UploadShellRecording"
+	// ColonyDebugServiceListShellRecordingsProcedure is the fully-qualified name of the
+	// ColonyDebugService's ListShellRecordings RPC.
+	ColonyDebugServiceListShellRecordingsProcedure = "/coral.colony.v1.ColonyDebugService/ListShellRecordings"
+	// ColonyDebugServiceGetShellRecordingProcedure is the fully-qualified name of the
+	// ColonyDebugService's GetShellRecording RPC.
+	ColonyDebugServiceGetShellRecordingProcedure = "/coral.colony.v1.ColonyDebugService/GetShellRecording"
 )
 
 // ColonyDebugServiceClient is a client for the coral.colony.v1.ColonyDebugService service.
@@ -94,6 +152,9 @@ type ColonyDebugServiceClient interface {
 	DetachUprobe(context.Context, *connect.Request[v1.DetachUprobeRequest]) (*connect.Response[v1.DetachUprobeResponse], error)
 	// Query uprobe events (pull-based, like Beyla).
 	QueryUprobeEvents(context.Context, *connect.Request[v1.QueryUprobeEventsRequest]) (*connect.Response[v1.QueryUprobeEventsResponse], error)
+	// Stream uprobe events from an agent as they are captured. Agents send
+	// batches tagged with the colony session ID; the colony persists them.
+	IngestUprobeEvents(context.Context) *connect.ClientStreamForClient[v1.IngestUprobeEventsRequest, v1.IngestUprobeEventsResponse]
 	// List active debug sessions.
 	ListDebugSessions(context.Context, *connect.Request[v1.ListDebugSessionsRequest]) (*connect.Response[v1.ListDebugSessionsResponse], error)
 	// Trace request path (RFD 062).
@@ -104,12 +165,20 @@ type ColonyDebugServiceClient interface {
 	QueryFunctions(context.Context, *connect.Request[v1.QueryFunctionsRequest]) (*connect.Response[v1.QueryFunctionsResponse], error)
 	// Profile multiple functions with automatic analysis (RFD 069).
 	ProfileFunctions(context.Context, *connect.Request[v1.ProfileFunctionsRequest]) (*connect.Response[v1.ProfileFunctionsResponse], error)
+	// Cancel an async ProfileFunctions run, detaching all of its probes.
+	CancelProfileFunctions(context.Context, *connect.Request[v1.CancelProfileFunctionsRequest]) (*connect.Response[v1.CancelProfileFunctionsResponse], error)
 	// Collect CPU profile samples for a target service/pod (RFD 070).
 	ProfileCPU(context.Context, *connect.Request[v1.ProfileCPURequest]) (*connect.Response[v1.ProfileCPUResponse], error)
 	// Query historical CPU profiles from continuous profiling (RFD 072).
 	QueryHistoricalCPUProfile(context.Context, *connect.Request[v1.QueryHistoricalCPUProfileRequest]) (*connect.Response[v1.QueryHistoricalCPUProfileResponse], error)
 	// Collect memory profile for a target service/pod (RFD 077).
 	ProfileMemory(context.Context, *connect.Request[v1.ProfileMemoryRequest]) (*connect.Response[v1.ProfileMemoryResponse], error)
+	// ProfileIO profiles the file reads and writes of a service for a
+	// duration, by file and device.
+	ProfileIO(context.Context, *connect.Request[v1.ProfileIORequest]) (*connect.Response[v1.ProfileIOResponse], error)
+	// ProfileLocks profiles the lock contention of a service for a duration,
+	// as the time its threads spent blocked on futexes by user stack.
+	ProfileLocks(context.Context, *connect.Request[v1.ProfileLocksRequest]) (*connect.Response[v1.ProfileLocksResponse], error)
 	// Query historical memory profiles from continuous profiling (RFD 077).
 	QueryHistoricalMemoryProfile(context.Context, *connect.Request[v1.QueryHistoricalMemoryProfileRequest]) (*connect.Response[v1.QueryHistoricalMemoryProfileResponse], error)
 	// DeployCorrelation validates the descriptor, resolves the target agent for
@@ -120,6 +189,45 @@ type ColonyDebugServiceClient interface {
 	// ListCorrelations returns descriptors across all agents, optionally filtered
 	// by service (RFD 091).
 	ListCorrelations(context.Context, *connect.Request[v1.ColonyListCorrelationsRequest]) (*connect.Response[v1.ColonyListCorrelationsResponse], error)
+	// ListCoreDumps returns core dumps captured across all agents, optionally
+	// filtered by service.
+	ListCoreDumps(context.Context, *connect.Request[v1.ColonyListCoreDumpsRequest]) (*connect.Response[v1.ColonyListCoreDumpsResponse], error)
+	// DownloadCoreDump streams a core dump from the agent holding it.
+	DownloadCoreDump(context.Context, *connect.Request[v1.ColonyDownloadCoreDumpRequest]) (*connect.ServerStreamForClient[v11.CoreDumpChunk], error)
+	// CreateProfileSchedule defines a recurring profiling job run by the colony.
+	CreateProfileSchedule(context.Context, *connect.Request[v1.CreateProfileScheduleRequest]) (*connect.Response[v1.CreateProfileScheduleResponse], error)
+	// ListProfileSchedules returns all recurring profiling jobs.
+	ListProfileSchedules(context.Context, *connect.Request[v1.ListProfileSchedulesRequest]) (*connect.Response[v1.ListProfileSchedulesResponse], error)
+	// DeleteProfileSchedule removes a recurring profiling job and its results.
+	DeleteProfileSchedule(context.Context, *connect.Request[v1.DeleteProfileScheduleRequest]) (*connect.Response[v1.DeleteProfileScheduleResponse], error)
+	// ListProfileRuns returns retained results of scheduled profiling jobs,
+	// newest first.
+	ListProfileRuns(context.Context, *connect.Request[v1.ListProfileRunsRequest]) (*connect.Response[v1.ListProfileRunsResponse], error)
+	// GetProfileRun returns a retained profiling result with its stacks.
+	GetProfileRun(context.Context, *connect.Request[v1.GetProfileRunRequest]) (*connect.Response[v1.GetProfileRunResponse], error)
+	// DescribeFunction returns the signature, argument locations and
+	// probeability of a function, from the agent running the service.
+	DescribeFunction(context.Context, *connect.Request[v1.ColonyDescribeFunctionRequest]) (*connect.Response[v1.ColonyDescribeFunctionResponse], error)
+	// CaptureHttp starts a debug session capturing sampled HTTP exchanges of a
+	// service. Stop it with DetachUprobe.
+	CaptureHttp(context.Context, *connect.Request[v1.CaptureHttpRequest]) (*connect.Response[v1.CaptureHttpResponse], error)
+	// CaptureTls starts a debug session capturing the plaintext of TLS
+	// connections of a service. Stop it with DetachUprobe.
+	CaptureTls(context.Context, *connect.Request[v1.CaptureTlsRequest]) (*connect.Response[v1.CaptureTlsResponse], error)
+	// TraceRuntime traces the Go runtime of a service for a duration and
+	// correlates its pauses with the latency of the service's requests.
+	TraceRuntime(context.Context, *connect.Request[v1.TraceRuntimeRequest]) (*connect.Response[v1.TraceRuntimeResponse], error)
+	// TraceSyscalls counts the syscalls of a service for a duration, with
+	// their latency and error codes.
+	TraceSyscalls(context.Context, *connect.Request[v1.TraceSyscallsRequest]) (*connect.Response[v1.TraceSyscallsResponse], error)
+	// UploadShellRecording stores the recording of a shell or exec session
+	// that ended on an agent.
+	UploadShellRecording(context.Context, *connect.Request[v1.UploadShellRecordingRequest]) (*connect.Response[v1.UploadShellRecordingResponse], error)
+	// ListShellRecordings returns stored shell and exec session recordings,
+	// newest first, without their events.
+	ListShellRecordings(context.Context, *connect.Request[v1.ListShellRecordingsRequest]) (*connect.Response[v1.ListShellRecordingsResponse], error)
+	// GetShellRecording returns a recording with its events.
+	GetShellRecording(context.Context, *connect.Request[v1.GetShellRecordingRequest]) (*connect.Response[v1.GetShellRecordingResponse], error)
 }
 
 // NewColonyDebugServiceClient constructs a client for the coral.colony.v1.ColonyDebugService
@@ -157,6 +265,12 @@ func NewColonyDebugServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(colonyDebugServiceMethods.ByName("QueryUprobeEvents")),
 			connect.WithClientOptions(opts...),
 		),
+		ingestUprobeEvents: connect.NewClient[v1.IngestUprobeEventsRequest, v1.IngestUprobeEventsResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceIngestUprobeEventsProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("IngestUprobeEvents")),
+			connect.WithClientOptions(opts...),
+		),
 		listDebugSessions: connect.NewClient[v1.ListDebugSessionsRequest, v1.ListDebugSessionsResponse](
 			httpClient,
 			baseURL+ColonyDebugServiceListDebugSessionsProcedure,
@@ -187,6 +301,12 @@ func NewColonyDebugServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(colonyDebugServiceMethods.ByName("ProfileFunctions")),
 			connect.WithClientOptions(opts...),
 		),
+		cancelProfileFunctions: connect.NewClient[v1.CancelProfileFunctionsRequest, v1.CancelProfileFunctionsResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceCancelProfileFunctionsProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("CancelProfileFunctions")),
+			connect.WithClientOptions(opts...),
+		),
 		profileCPU: connect.NewClient[v1.ProfileCPURequest, v1.ProfileCPUResponse](
 			httpClient,
 			baseURL+ColonyDebugServiceProfileCPUProcedure,
@@ -205,6 +325,18 @@ func NewColonyDebugServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(colonyDebugServiceMethods.ByName("ProfileMemory")),
 			connect.WithClientOptions(opts...),
 		),
+		profileIO: connect.NewClient[v1.ProfileIORequest, v1.ProfileIOResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceProfileIOProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("ProfileIO")),
+			connect.WithClientOptions(opts...),
+		),
+		profileLocks: connect.NewClient[v1.ProfileLocksRequest, v1.ProfileLocksResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceProfileLocksProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("ProfileLocks")),
+			connect.WithClientOptions(opts...),
+		),
 		queryHistoricalMemoryProfile: connect.NewClient[v1.QueryHistoricalMemoryProfileRequest, v1.QueryHistoricalMemoryProfileResponse](
 			httpClient,
 			baseURL+ColonyDebugServiceQueryHistoricalMemoryProfileProcedure,
@@ -229,6 +361,96 @@ func NewColonyDebugServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(colonyDebugServiceMethods.ByName("ListCorrelations")),
 			connect.WithClientOptions(opts...),
 		),
+		listCoreDumps: connect.NewClient[v1.ColonyListCoreDumpsRequest, v1.ColonyListCoreDumpsResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceListCoreDumpsProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("ListCoreDumps")),
+			connect.WithClientOptions(opts...),
+		),
+		downloadCoreDump: connect.NewClient[v1.ColonyDownloadCoreDumpRequest, v11.CoreDumpChunk](
+			httpClient,
+			baseURL+ColonyDebugServiceDownloadCoreDumpProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("DownloadCoreDump")),
+			connect.WithClientOptions(opts...),
+		),
+		createProfileSchedule: connect.NewClient[v1.CreateProfileScheduleRequest, v1.CreateProfileScheduleResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceCreateProfileScheduleProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("CreateProfileSchedule")),
+			connect.WithClientOptions(opts...),
+		),
+		listProfileSchedules: connect.NewClient[v1.ListProfileSchedulesRequest, v1.ListProfileSchedulesResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceListProfileSchedulesProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("ListProfileSchedules")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteProfileSchedule: connect.NewClient[v1.DeleteProfileScheduleRequest, v1.DeleteProfileScheduleResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceDeleteProfileScheduleProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("DeleteProfileSchedule")),
+			connect.WithClientOptions(opts...),
+		),
+		listProfileRuns: connect.NewClient[v1.ListProfileRunsRequest, v1.ListProfileRunsResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceListProfileRunsProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("ListProfileRuns")),
+			connect.WithClientOptions(opts...),
+		),
+		getProfileRun: connect.NewClient[v1.GetProfileRunRequest, v1.GetProfileRunResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceGetProfileRunProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("GetProfileRun")),
+			connect.WithClientOptions(opts...),
+		),
+		describeFunction: connect.NewClient[v1.ColonyDescribeFunctionRequest, v1.ColonyDescribeFunctionResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceDescribeFunctionProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("DescribeFunction")),
+			connect.WithClientOptions(opts...),
+		),
+		captureHttp: connect.NewClient[v1.CaptureHttpRequest, v1.CaptureHttpResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceCaptureHttpProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("CaptureHttp")),
+			connect.WithClientOptions(opts...),
+		),
+		captureTls: connect.NewClient[v1.CaptureTlsRequest, v1.CaptureTlsResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceCaptureTlsProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("CaptureTls")),
+			connect.WithClientOptions(opts...),
+		),
+		traceRuntime: connect.NewClient[v1.TraceRuntimeRequest, v1.TraceRuntimeResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceTraceRuntimeProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("TraceRuntime")),
+			connect.WithClientOptions(opts...),
+		),
+		traceSyscalls: connect.NewClient[v1.TraceSyscallsRequest, v1.TraceSyscallsResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceTraceSyscallsProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("TraceSyscalls")),
+			connect.WithClientOptions(opts...),
+		),
+		uploadShellRecording: connect.NewClient[v1.UploadShellRecordingRequest, v1.UploadShellRecordingResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceUploadShellRecordingProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("UploadShellRecording")),
+			connect.WithClientOptions(opts...),
+		),
+		listShellRecordings: connect.NewClient[v1.ListShellRecordingsRequest, v1.ListShellRecordingsResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceListShellRecordingsProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("ListShellRecordings")),
+			connect.WithClientOptions(opts...),
+		),
+		getShellRecording: connect.NewClient[v1.GetShellRecordingRequest, v1.GetShellRecordingResponse](
+			httpClient,
+			baseURL+ColonyDebugServiceGetShellRecordingProcedure,
+			connect.WithSchema(colonyDebugServiceMethods.ByName("GetShellRecording")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -238,18 +460,37 @@ type colonyDebugServiceClient struct {
 	updateProbeFilter            *connect.Client[v1.UpdateProbeFilterRequest, v1.UpdateProbeFilterResponse]
 	detachUprobe                 *connect.Client[v1.DetachUprobeRequest, v1.DetachUprobeResponse]
 	queryUprobeEvents            *connect.Client[v1.QueryUprobeEventsRequest, v1.QueryUprobeEventsResponse]
+	ingestUprobeEvents           *connect.Client[v1.IngestUprobeEventsRequest, v1.IngestUprobeEventsResponse]
 	listDebugSessions            *connect.Client[v1.ListDebugSessionsRequest, v1.ListDebugSessionsResponse]
 	traceRequestPath             *connect.Client[v1.TraceRequestPathRequest, v1.TraceRequestPathResponse]
 	getDebugResults              *connect.Client[v1.GetDebugResultsRequest, v1.GetDebugResultsResponse]
 	queryFunctions               *connect.Client[v1.QueryFunctionsRequest, v1.QueryFunctionsResponse]
 	profileFunctions             *connect.Client[v1.ProfileFunctionsRequest, v1.ProfileFunctionsResponse]
+	cancelProfileFunctions       *connect.Client[v1.CancelProfileFunctionsRequest, v1.CancelProfileFunctionsResponse]
 	profileCPU                   *connect.Client[v1.ProfileCPURequest, v1.ProfileCPUResponse]
 	queryHistoricalCPUProfile    *connect.Client[v1.QueryHistoricalCPUProfileRequest, v1.QueryHistoricalCPUProfileResponse]
 	profileMemory                *connect.Client[v1.ProfileMemoryRequest, v1.ProfileMemoryResponse]
+	profileIO                    *connect.Client[v1.ProfileIORequest, v1.ProfileIOResponse]
+	profileLocks                 *connect.Client[v1.ProfileLocksRequest, v1.ProfileLocksResponse]
 	queryHistoricalMemoryProfile *connect.Client[v1.QueryHistoricalMemoryProfileRequest, v1.QueryHistoricalMemoryProfileResponse]
 	deployCorrelation            *connect.Client[v1.ColonyDeployCorrelationRequest, v1.ColonyDeployCorrelationResponse]
 	removeCorrelation            *connect.Client[v1.ColonyRemoveCorrelationRequest, v1.ColonyRemoveCorrelationResponse]
 	listCorrelations             *connect.Client[v1.ColonyListCorrelationsRequest, v1.ColonyListCorrelationsResponse]
+	listCoreDumps                *connect.Client[v1.ColonyListCoreDumpsRequest, v1.ColonyListCoreDumpsResponse]
+	downloadCoreDump             *connect.Client[v1.ColonyDownloadCoreDumpRequest, v11.CoreDumpChunk]
+	createProfileSchedule        *connect.Client[v1.CreateProfileScheduleRequest, v1.CreateProfileScheduleResponse]
+	listProfileSchedules         *connect.Client[v1.ListProfileSchedulesRequest, v1.ListProfileSchedulesResponse]
+	deleteProfileSchedule        *connect.Client[v1.DeleteProfileScheduleRequest, v1.DeleteProfileScheduleResponse]
+	listProfileRuns              *connect.Client[v1.ListProfileRunsRequest, v1.ListProfileRunsResponse]
+	getProfileRun                *connect.Client[v1.GetProfileRunRequest, v1.GetProfileRunResponse]
+	describeFunction             *connect.Client[v1.ColonyDescribeFunctionRequest, v1.ColonyDescribeFunctionResponse]
+	captureHttp                  *connect.Client[v1.CaptureHttpRequest, v1.CaptureHttpResponse]
+	captureTls                   *connect.Client[v1.CaptureTlsRequest, v1.CaptureTlsResponse]
+	traceRuntime                 *connect.Client[v1.TraceRuntimeRequest, v1.TraceRuntimeResponse]
+	traceSyscalls                *connect.Client[v1.TraceSyscallsRequest, v1.TraceSyscallsResponse]
+	uploadShellRecording         *connect.Client[v1.UploadShellRecordingRequest, v1.UploadShellRecordingResponse]
+	listShellRecordings          *connect.Client[v1.ListShellRecordingsRequest, v1.ListShellRecordingsResponse]
+	getShellRecording            *connect.Client[v1.GetShellRecordingRequest, v1.GetShellRecordingResponse]
 }
 
 // AttachUprobe calls coral.colony.v1.ColonyDebugService.AttachUprobe.
@@ -272,6 +513,11 @@ func (c *colonyDebugServiceClient) QueryUprobeEvents(ctx context.Context, req *c
 	return c.queryUprobeEvents.CallUnary(ctx, req)
 }
 
+// IngestUprobeEvents calls coral.colony.v1.ColonyDebugService.IngestUprobeEvents.
+func (c *colonyDebugServiceClient) IngestUprobeEvents(ctx context.Context) *connect.ClientStreamForClient[v1.IngestUprobeEventsRequest, v1.IngestUprobeEventsResponse] {
+	return c.ingestUprobeEvents.CallClientStream(ctx)
+}
+
 // ListDebugSessions calls coral.colony.v1.ColonyDebugService.ListDebugSessions.
 func (c *colonyDebugServiceClient) ListDebugSessions(ctx context.Context, req *connect.Request[v1.ListDebugSessionsRequest]) (*connect.Response[v1.ListDebugSessionsResponse], error) {
 	return c.listDebugSessions.CallUnary(ctx, req)
@@ -297,6 +543,11 @@ func (c *colonyDebugServiceClient) ProfileFunctions(ctx context.Context, req *co
 	return c.profileFunctions.CallUnary(ctx, req)
 }
 
+// CancelProfileFunctions calls coral.colony.v1.ColonyDebugService.CancelProfileFunctions.
+func (c *colonyDebugServiceClient) CancelProfileFunctions(ctx context.Context, req *connect.Request[v1.CancelProfileFunctionsRequest]) (*connect.Response[v1.CancelProfileFunctionsResponse], error) {
+	return c.cancelProfileFunctions.CallUnary(ctx, req)
+}
+
 // ProfileCPU calls coral.colony.v1.ColonyDebugService.ProfileCPU.
 func (c *colonyDebugServiceClient) ProfileCPU(ctx context.Context, req *connect.Request[v1.ProfileCPURequest]) (*connect.Response[v1.ProfileCPUResponse], error) {
 	return c.profileCPU.CallUnary(ctx, req)
@@ -312,6 +563,16 @@ func (c *colonyDebugServiceClient) ProfileMemory(ctx context.Context, req *conne
 	return c.profileMemory.CallUnary(ctx, req)
 }
 
+// ProfileIO calls coral.colony.v1.ColonyDebugService.ProfileIO.
+func (c *colonyDebugServiceClient) ProfileIO(ctx context.Context, req *connect.Request[v1.ProfileIORequest]) (*connect.Response[v1.ProfileIOResponse], error) {
+	return c.profileIO.CallUnary(ctx, req)
+}
+
+// ProfileLocks calls coral.colony.v1.ColonyDebugService.ProfileLocks.
+func (c *colonyDebugServiceClient) ProfileLocks(ctx context.Context, req *connect.Request[v1.ProfileLocksRequest]) (*connect.Response[v1.ProfileLocksResponse], error) {
+	return c.profileLocks.CallUnary(ctx, req)
+}
+
 // QueryHistoricalMemoryProfile calls
 // coral.colony.v1.ColonyDebugService.QueryHistoricalMemoryProfile.
 func (c *colonyDebugServiceClient) QueryHistoricalMemoryProfile(ctx context.Context, req *connect.Request[v1.QueryHistoricalMemoryProfileRequest]) (*connect.Response[v1.QueryHistoricalMemoryProfileResponse], error) {
@@ -333,6 +594,81 @@ func (c *colonyDebugServiceClient) ListCorrelations(ctx context.Context, req *co
 	return c.listCorrelations.CallUnary(ctx, req)
 }
 
+// ListCoreDumps calls coral.colony.v1.ColonyDebugService.ListCoreDumps.
+func (c *colonyDebugServiceClient) ListCoreDumps(ctx context.Context, req *connect.Request[v1.ColonyListCoreDumpsRequest]) (*connect.Response[v1.ColonyListCoreDumpsResponse], error) {
+	return c.listCoreDumps.CallUnary(ctx, req)
+}
+
+// DownloadCoreDump calls coral.colony.v1.ColonyDebugService.DownloadCoreDump.
+func (c *colonyDebugServiceClient) DownloadCoreDump(ctx context.Context, req *connect.Request[v1.ColonyDownloadCoreDumpRequest]) (*connect.ServerStreamForClient[v11.CoreDumpChunk], error) {
+	return c.downloadCoreDump.CallServerStream(ctx, req)
+}
+
+// CreateProfileSchedule calls coral.colony.v1.ColonyDebugService.CreateProfileSchedule.
+func (c *colonyDebugServiceClient) CreateProfileSchedule(ctx context.Context, req *connect.Request[v1.CreateProfileScheduleRequest]) (*connect.Response[v1.CreateProfileScheduleResponse], error) {
+	return c.createProfileSchedule.CallUnary(ctx, req)
+}
+
+// ListProfileSchedules calls coral.colony.v1.ColonyDebugService.ListProfileSchedules.
+func (c *colonyDebugServiceClient) ListProfileSchedules(ctx context.Context, req *connect.Request[v1.ListProfileSchedulesRequest]) (*connect.Response[v1.ListProfileSchedulesResponse], error) {
+	return c.listProfileSchedules.CallUnary(ctx, req)
+}
+
+// DeleteProfileSchedule calls coral.colony.v1.ColonyDebugService.DeleteProfileSchedule.
+func (c *colonyDebugServiceClient) DeleteProfileSchedule(ctx context.Context, req *connect.Request[v1.DeleteProfileScheduleRequest]) (*connect.Response[v1.DeleteProfileScheduleResponse], error) {
+	return c.deleteProfileSchedule.CallUnary(ctx, req)
+}
+
+// ListProfileRuns calls coral.colony.v1.ColonyDebugService.ListProfileRuns.
+func (c *colonyDebugServiceClient) ListProfileRuns(ctx context.Context, req *connect.Request[v1.ListProfileRunsRequest]) (*connect.Response[v1.ListProfileRunsResponse], error) {
+	return c.listProfileRuns.CallUnary(ctx, req)
+}
+
+// GetProfileRun calls coral.colony.v1.ColonyDebugService.GetProfileRun.
+func (c *colonyDebugServiceClient) GetProfileRun(ctx context.Context, req *connect.Request[v1.GetProfileRunRequest]) (*connect.Response[v1.GetProfileRunResponse], error) {
+	return c.getProfileRun.CallUnary(ctx, req)
+}
+
+// DescribeFunction calls coral.colony.v1.ColonyDebugService.DescribeFunction.
+func (c *colonyDebugServiceClient) DescribeFunction(ctx context.Context, req *connect.Request[v1.ColonyDescribeFunctionRequest]) (*connect.Response[v1.ColonyDescribeFunctionResponse], error) {
+	return c.describeFunction.CallUnary(ctx, req)
+}
+
+// CaptureHttp calls coral.colony.v1.ColonyDebugService.CaptureHttp.
+func (c *colonyDebugServiceClient) CaptureHttp(ctx context.Context, req *connect.Request[v1.CaptureHttpRequest]) (*connect.Response[v1.CaptureHttpResponse], error) {
+	return c.captureHttp.CallUnary(ctx, req)
+}
+
+// CaptureTls calls coral.colony.v1.ColonyDebugService.CaptureTls.
+func (c *colonyDebugServiceClient) CaptureTls(ctx context.Context, req *connect.Request[v1.CaptureTlsRequest]) (*connect.Response[v1.CaptureTlsResponse], error) {
+	return c.captureTls.CallUnary(ctx, req)
+}
+
+// TraceRuntime calls coral.colony.v1.ColonyDebugService.TraceRuntime.
+func (c *colonyDebugServiceClient) TraceRuntime(ctx context.Context, req *connect.Request[v1.TraceRuntimeRequest]) (*connect.Response[v1.TraceRuntimeResponse], error) {
+	return c.traceRuntime.CallUnary(ctx, req)
+}
+
+// TraceSyscalls calls coral.colony.v1.ColonyDebugService.TraceSyscalls.
+func (c *colonyDebugServiceClient) TraceSyscalls(ctx context.Context, req *connect.Request[v1.TraceSyscallsRequest]) (*connect.Response[v1.TraceSyscallsResponse], error) {
+	return c.traceSyscalls.CallUnary(ctx, req)
+}
+
+// UploadShellRecording calls coral.colony.v1.ColonyDebugService.UploadShellRecording.
+func (c *colonyDebugServiceClient) UploadShellRecording(ctx context.Context, req *connect.Request[v1.UploadShellRecordingRequest]) (*connect.Response[v1.UploadShellRecordingResponse], error) {
+	return c.uploadShellRecording.CallUnary(ctx, req)
+}
+
+// ListShellRecordings calls coral.colony.v1.ColonyDebugService.ListShellRecordings.
+func (c *colonyDebugServiceClient) ListShellRecordings(ctx context.Context, req *connect.Request[v1.ListShellRecordingsRequest]) (*connect.Response[v1.ListShellRecordingsResponse], error) {
+	return c.listShellRecordings.CallUnary(ctx, req)
+}
+
+// GetShellRecording calls coral.colony.v1.ColonyDebugService.GetShellRecording.
+func (c *colonyDebugServiceClient) GetShellRecording(ctx context.Context, req *connect.Request[v1.GetShellRecordingRequest]) (*connect.Response[v1.GetShellRecordingResponse], error) {
+	return c.getShellRecording.CallUnary(ctx, req)
+}
+
 // ColonyDebugServiceHandler is an implementation of the coral.colony.v1.ColonyDebugService service.
 type ColonyDebugServiceHandler interface {
 	// Start uprobe debug session.
@@ -343,6 +679,9 @@ type ColonyDebugServiceHandler interface {
 	DetachUprobe(context.Context, *connect.Request[v1.DetachUprobeRequest]) (*connect.Response[v1.DetachUprobeResponse], error)
 	// Query uprobe events (pull-based, like Beyla).
 	QueryUprobeEvents(context.Context, *connect.Request[v1.QueryUprobeEventsRequest]) (*connect.Response[v1.QueryUprobeEventsResponse], error)
+	// Stream uprobe events from an agent as they are captured. Agents send
+	// batches tagged with the colony session ID; the colony persists them.
+	IngestUprobeEvents(context.Context, *connect.ClientStream[v1.IngestUprobeEventsRequest]) (*connect.Response[v1.IngestUprobeEventsResponse], error)
 	// List active debug sessions.
 	ListDebugSessions(context.Context, *connect.Request[v1.ListDebugSessionsRequest]) (*connect.Response[v1.ListDebugSessionsResponse], error)
 	// Trace request path (RFD 062).
@@ -353,12 +692,20 @@ type ColonyDebugServiceHandler interface {
 	QueryFunctions(context.Context, *connect.Request[v1.QueryFunctionsRequest]) (*connect.Response[v1.QueryFunctionsResponse], error)
 	// Profile multiple functions with automatic analysis (RFD 069).
 	ProfileFunctions(context.Context, *connect.Request[v1.ProfileFunctionsRequest]) (*connect.Response[v1.ProfileFunctionsResponse], error)
+	// Cancel an async ProfileFunctions run, detaching all of its probes.
+	CancelProfileFunctions(context.Context, *connect.Request[v1.CancelProfileFunctionsRequest]) (*connect.Response[v1.CancelProfileFunctionsResponse], error)
 	// Collect CPU profile samples for a target service/pod (RFD 070).
 	ProfileCPU(context.Context, *connect.Request[v1.ProfileCPURequest]) (*connect.Response[v1.ProfileCPUResponse], error)
 	// Query historical CPU profiles from continuous profiling (RFD 072).
 	QueryHistoricalCPUProfile(context.Context, *connect.Request[v1.QueryHistoricalCPUProfileRequest]) (*connect.Response[v1.QueryHistoricalCPUProfileResponse], error)
 	// Collect memory profile for a target service/pod (RFD 077).
 	ProfileMemory(context.Context, *connect.Request[v1.ProfileMemoryRequest]) (*connect.Response[v1.ProfileMemoryResponse], error)
+	// ProfileIO profiles the file reads and writes of a service for a
+	// duration, by file and device.
+	ProfileIO(context.Context, *connect.Request[v1.ProfileIORequest]) (*connect.Response[v1.ProfileIOResponse], error)
+	// ProfileLocks profiles the lock contention of a service for a duration,
+	// as the time its threads spent blocked on futexes by user stack.
+	ProfileLocks(context.Context, *connect.Request[v1.ProfileLocksRequest]) (*connect.Response[v1.ProfileLocksResponse], error)
 	// Query historical memory profiles from continuous profiling (RFD 077).
 	QueryHistoricalMemoryProfile(context.Context, *connect.Request[v1.QueryHistoricalMemoryProfileRequest]) (*connect.Response[v1.QueryHistoricalMemoryProfileResponse], error)
 	// DeployCorrelation validates the descriptor, resolves the target agent for
@@ -369,6 +716,45 @@ type ColonyDebugServiceHandler interface {
 	// ListCorrelations returns descriptors across all agents, optionally filtered
 	// by service (RFD 091).
 	ListCorrelations(context.Context, *connect.Request[v1.ColonyListCorrelationsRequest]) (*connect.Response[v1.ColonyListCorrelationsResponse], error)
+	// ListCoreDumps returns core dumps captured across all agents, optionally
+	// filtered by service.
+	ListCoreDumps(context.Context, *connect.Request[v1.ColonyListCoreDumpsRequest]) (*connect.Response[v1.ColonyListCoreDumpsResponse], error)
+	// DownloadCoreDump streams a core dump from the agent holding it.
+	DownloadCoreDump(context.Context, *connect.Request[v1.ColonyDownloadCoreDumpRequest], *connect.ServerStream[v11.CoreDumpChunk]) error
+	// CreateProfileSchedule defines a recurring profiling job run by the colony.
+	CreateProfileSchedule(context.Context, *connect.Request[v1.CreateProfileScheduleRequest]) (*connect.Response[v1.CreateProfileScheduleResponse], error)
+	// ListProfileSchedules returns all recurring profiling jobs.
+	ListProfileSchedules(context.Context, *connect.Request[v1.ListProfileSchedulesRequest]) (*connect.Response[v1.ListProfileSchedulesResponse], error)
+	// DeleteProfileSchedule removes a recurring profiling job and its results.
+	DeleteProfileSchedule(context.Context, *connect.Request[v1.DeleteProfileScheduleRequest]) (*connect.Response[v1.DeleteProfileScheduleResponse], error)
+	// ListProfileRuns returns retained results of scheduled profiling jobs,
+	// newest first.
+	ListProfileRuns(context.Context, *connect.Request[v1.ListProfileRunsRequest]) (*connect.Response[v1.ListProfileRunsResponse], error)
+	// GetProfileRun returns a retained profiling result with its stacks.
+	GetProfileRun(context.Context, *connect.Request[v1.GetProfileRunRequest]) (*connect.Response[v1.GetProfileRunResponse], error)
+	// DescribeFunction returns the signature, argument locations and
+	// probeability of a function, from the agent running the service.
+	DescribeFunction(context.Context, *connect.Request[v1.ColonyDescribeFunctionRequest]) (*connect.Response[v1.ColonyDescribeFunctionResponse], error)
+	// CaptureHttp starts a debug session capturing sampled HTTP exchanges of a
+	// service. Stop it with DetachUprobe.
+	CaptureHttp(context.Context, *connect.Request[v1.CaptureHttpRequest]) (*connect.Response[v1.CaptureHttpResponse], error)
+	// CaptureTls starts a debug session capturing the plaintext of TLS
+	// connections of a service. Stop it with DetachUprobe.
+	CaptureTls(context.Context, *connect.Request[v1.CaptureTlsRequest]) (*connect.Response[v1.CaptureTlsResponse], error)
+	// TraceRuntime traces the Go runtime of a service for a duration and
+	// correlates its pauses with the latency of the service's requests.
+	TraceRuntime(context.Context, *connect.Request[v1.TraceRuntimeRequest]) (*connect.Response[v1.TraceRuntimeResponse], error)
+	// TraceSyscalls counts the syscalls of a service for a duration, with
+	// their latency and error codes.
+	TraceSyscalls(context.Context, *connect.Request[v1.TraceSyscallsRequest]) (*connect.Response[v1.TraceSyscallsResponse], error)
+	// UploadShellRecording stores the recording of a shell or exec session
+	// that ended on an agent.
+	UploadShellRecording(context.Context, *connect.Request[v1.UploadShellRecordingRequest]) (*connect.Response[v1.UploadShellRecordingResponse], error)
+	// ListShellRecordings returns stored shell and exec session recordings,
+	// newest first, without their events.
+	ListShellRecordings(context.Context, *connect.Request[v1.ListShellRecordingsRequest]) (*connect.Response[v1.ListShellRecordingsResponse], error)
+	// GetShellRecording returns a recording with its events.
+	GetShellRecording(context.Context, *connect.Request[v1.GetShellRecordingRequest]) (*connect.Response[v1.GetShellRecordingResponse], error)
 }
 
 // NewColonyDebugServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -402,6 +788,12 @@ func NewColonyDebugServiceHandler(svc ColonyDebugServiceHandler, opts ...connect
 		connect.WithSchema(colonyDebugServiceMethods.ByName("QueryUprobeEvents")),
 		connect.WithHandlerOptions(opts...),
 	)
+	colonyDebugServiceIngestUprobeEventsHandler := connect.NewClientStreamHandler(
+		ColonyDebugServiceIngestUprobeEventsProcedure,
+		svc.IngestUprobeEvents,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("IngestUprobeEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
 	colonyDebugServiceListDebugSessionsHandler := connect.NewUnaryHandler(
 		ColonyDebugServiceListDebugSessionsProcedure,
 		svc.ListDebugSessions,
@@ -432,6 +824,12 @@ func NewColonyDebugServiceHandler(svc ColonyDebugServiceHandler, opts ...connect
 		connect.WithSchema(colonyDebugServiceMethods.ByName("ProfileFunctions")),
 		connect.WithHandlerOptions(opts...),
 	)
+	colonyDebugServiceCancelProfileFunctionsHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceCancelProfileFunctionsProcedure,
+		svc.CancelProfileFunctions,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("CancelProfileFunctions")),
+		connect.WithHandlerOptions(opts...),
+	)
 	colonyDebugServiceProfileCPUHandler := connect.NewUnaryHandler(
 		ColonyDebugServiceProfileCPUProcedure,
 		svc.ProfileCPU,
@@ -450,6 +848,18 @@ func NewColonyDebugServiceHandler(svc ColonyDebugServiceHandler, opts ...connect
 		connect.WithSchema(colonyDebugServiceMethods.ByName("ProfileMemory")),
 		connect.WithHandlerOptions(opts...),
 	)
+	colonyDebugServiceProfileIOHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceProfileIOProcedure,
+		svc.ProfileIO,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("ProfileIO")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceProfileLocksHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceProfileLocksProcedure,
+		svc.ProfileLocks,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("ProfileLocks")),
+		connect.WithHandlerOptions(opts...),
+	)
 	colonyDebugServiceQueryHistoricalMemoryProfileHandler := connect.NewUnaryHandler(
 		ColonyDebugServiceQueryHistoricalMemoryProfileProcedure,
 		svc.QueryHistoricalMemoryProfile,
@@ -474,6 +884,96 @@ func NewColonyDebugServiceHandler(svc ColonyDebugServiceHandler, opts ...connect
 		connect.WithSchema(colonyDebugServiceMethods.ByName("ListCorrelations")),
 		connect.WithHandlerOptions(opts...),
 	)
+	colonyDebugServiceListCoreDumpsHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceListCoreDumpsProcedure,
+		svc.ListCoreDumps,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("ListCoreDumps")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceDownloadCoreDumpHandler := connect.NewServerStreamHandler(
+		ColonyDebugServiceDownloadCoreDumpProcedure,
+		svc.DownloadCoreDump,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("DownloadCoreDump")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceCreateProfileScheduleHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceCreateProfileScheduleProcedure,
+		svc.CreateProfileSchedule,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("CreateProfileSchedule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceListProfileSchedulesHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceListProfileSchedulesProcedure,
+		svc.ListProfileSchedules,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("ListProfileSchedules")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceDeleteProfileScheduleHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceDeleteProfileScheduleProcedure,
+		svc.DeleteProfileSchedule,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("DeleteProfileSchedule")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceListProfileRunsHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceListProfileRunsProcedure,
+		svc.ListProfileRuns,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("ListProfileRuns")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceGetProfileRunHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceGetProfileRunProcedure,
+		svc.GetProfileRun,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("GetProfileRun")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceDescribeFunctionHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceDescribeFunctionProcedure,
+		svc.DescribeFunction,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("DescribeFunction")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceCaptureHttpHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceCaptureHttpProcedure,
+		svc.CaptureHttp,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("CaptureHttp")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceCaptureTlsHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceCaptureTlsProcedure,
+		svc.CaptureTls,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("CaptureTls")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceTraceRuntimeHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceTraceRuntimeProcedure,
+		svc.TraceRuntime,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("TraceRuntime")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceTraceSyscallsHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceTraceSyscallsProcedure,
+		svc.TraceSyscalls,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("TraceSyscalls")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceUploadShellRecordingHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceUploadShellRecordingProcedure,
+		svc.UploadShellRecording,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("UploadShellRecording")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceListShellRecordingsHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceListShellRecordingsProcedure,
+		svc.ListShellRecordings,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("ListShellRecordings")),
+		connect.WithHandlerOptions(opts...),
+	)
+	colonyDebugServiceGetShellRecordingHandler := connect.NewUnaryHandler(
+		ColonyDebugServiceGetShellRecordingProcedure,
+		svc.GetShellRecording,
+		connect.WithSchema(colonyDebugServiceMethods.ByName("GetShellRecording")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/coral.colony.v1.ColonyDebugService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ColonyDebugServiceAttachUprobeProcedure:
@@ -484,6 +984,8 @@ func NewColonyDebugServiceHandler(svc ColonyDebugServiceHandler, opts ...connect
 			colonyDebugServiceDetachUprobeHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceQueryUprobeEventsProcedure:
 			colonyDebugServiceQueryUprobeEventsHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceIngestUprobeEventsProcedure:
+			colonyDebugServiceIngestUprobeEventsHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceListDebugSessionsProcedure:
 			colonyDebugServiceListDebugSessionsHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceTraceRequestPathProcedure:
@@ -494,12 +996,18 @@ func NewColonyDebugServiceHandler(svc ColonyDebugServiceHandler, opts ...connect
 			colonyDebugServiceQueryFunctionsHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceProfileFunctionsProcedure:
 			colonyDebugServiceProfileFunctionsHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceCancelProfileFunctionsProcedure:
+			colonyDebugServiceCancelProfileFunctionsHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceProfileCPUProcedure:
 			colonyDebugServiceProfileCPUHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceQueryHistoricalCPUProfileProcedure:
 			colonyDebugServiceQueryHistoricalCPUProfileHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceProfileMemoryProcedure:
 			colonyDebugServiceProfileMemoryHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceProfileIOProcedure:
+			colonyDebugServiceProfileIOHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceProfileLocksProcedure:
+			colonyDebugServiceProfileLocksHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceQueryHistoricalMemoryProfileProcedure:
 			colonyDebugServiceQueryHistoricalMemoryProfileHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceDeployCorrelationProcedure:
@@ -508,6 +1016,36 @@ func NewColonyDebugServiceHandler(svc ColonyDebugServiceHandler, opts ...connect
 			colonyDebugServiceRemoveCorrelationHandler.ServeHTTP(w, r)
 		case ColonyDebugServiceListCorrelationsProcedure:
 			colonyDebugServiceListCorrelationsHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceListCoreDumpsProcedure:
+			colonyDebugServiceListCoreDumpsHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceDownloadCoreDumpProcedure:
+			colonyDebugServiceDownloadCoreDumpHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceCreateProfileScheduleProcedure:
+			colonyDebugServiceCreateProfileScheduleHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceListProfileSchedulesProcedure:
+			colonyDebugServiceListProfileSchedulesHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceDeleteProfileScheduleProcedure:
+			colonyDebugServiceDeleteProfileScheduleHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceListProfileRunsProcedure:
+			colonyDebugServiceListProfileRunsHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceGetProfileRunProcedure:
+			colonyDebugServiceGetProfileRunHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceDescribeFunctionProcedure:
+			colonyDebugServiceDescribeFunctionHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceCaptureHttpProcedure:
+			colonyDebugServiceCaptureHttpHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceCaptureTlsProcedure:
+			colonyDebugServiceCaptureTlsHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceTraceRuntimeProcedure:
+			colonyDebugServiceTraceRuntimeHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceTraceSyscallsProcedure:
+			colonyDebugServiceTraceSyscallsHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceUploadShellRecordingProcedure:
+			colonyDebugServiceUploadShellRecordingHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceListShellRecordingsProcedure:
+			colonyDebugServiceListShellRecordingsHandler.ServeHTTP(w, r)
+		case ColonyDebugServiceGetShellRecordingProcedure:
+			colonyDebugServiceGetShellRecordingHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -533,6 +1071,10 @@ func (UnimplementedColonyDebugServiceHandler) QueryUprobeEvents(context.Context,
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.QueryUprobeEvents is not implemented"))
 }
 
+func (UnimplementedColonyDebugServiceHandler) IngestUprobeEvents(context.Context, *connect.ClientStream[v1.IngestUprobeEventsRequest]) (*connect.Response[v1.IngestUprobeEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.IngestUprobeEvents is not implemented"))
+}
+
 func (UnimplementedColonyDebugServiceHandler) ListDebugSessions(context.Context, *connect.Request[v1.ListDebugSessionsRequest]) (*connect.Response[v1.ListDebugSessionsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ListDebugSessions is not implemented"))
 }
@@ -553,6 +1095,10 @@ func (UnimplementedColonyDebugServiceHandler) ProfileFunctions(context.Context,
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ProfileFunctions is not implemented"))
 }
 
+func (UnimplementedColonyDebugServiceHandler) CancelProfileFunctions(context.Context, *connect.Request[v1.CancelProfileFunctionsRequest]) (*connect.Response[v1.CancelProfileFunctionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.CancelProfileFunctions is not implemented"))
+}
+
 func (UnimplementedColonyDebugServiceHandler) ProfileCPU(context.Context, *connect.Request[v1.ProfileCPURequest]) (*connect.Response[v1.ProfileCPUResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ProfileCPU is not implemented"))
 }
@@ -565,6 +1111,14 @@ func (UnimplementedColonyDebugServiceHandler) ProfileMemory(context.Context, *co
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ProfileMemory is not implemented"))
 }
 
+func (UnimplementedColonyDebugServiceHandler) ProfileIO(context.Context, *connect.Request[v1.ProfileIORequest]) (*connect.Response[v1.ProfileIOResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ProfileIO is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) ProfileLocks(context.Context, *connect.Request[v1.ProfileLocksRequest]) (*connect.Response[v1.ProfileLocksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ProfileLocks is not implemented"))
+}
+
 func (UnimplementedColonyDebugServiceHandler) QueryHistoricalMemoryProfile(context.Context, *connect.Request[v1.QueryHistoricalMemoryProfileRequest]) (*connect.Response[v1.QueryHistoricalMemoryProfileResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.QueryHistoricalMemoryProfile is not implemented"))
 }
@@ -580,3 +1134,63 @@ func (UnimplementedColonyDebugServiceHandler) RemoveCorrelation(context.Context,
 func (UnimplementedColonyDebugServiceHandler) ListCorrelations(context.Context, *connect.Request[v1.ColonyListCorrelationsRequest]) (*connect.Response[v1.ColonyListCorrelationsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ListCorrelations is not implemented"))
 }
+
+func (UnimplementedColonyDebugServiceHandler) ListCoreDumps(context.Context, *connect.Request[v1.ColonyListCoreDumpsRequest]) (*connect.Response[v1.ColonyListCoreDumpsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ListCoreDumps is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) DownloadCoreDump(context.Context, *connect.Request[v1.ColonyDownloadCoreDumpRequest], *connect.ServerStream[v11.CoreDumpChunk]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.DownloadCoreDump is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) CreateProfileSchedule(context.Context, *connect.Request[v1.CreateProfileScheduleRequest]) (*connect.Response[v1.CreateProfileScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.CreateProfileSchedule is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) ListProfileSchedules(context.Context, *connect.Request[v1.ListProfileSchedulesRequest]) (*connect.Response[v1.ListProfileSchedulesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ListProfileSchedules is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) DeleteProfileSchedule(context.Context, *connect.Request[v1.DeleteProfileScheduleRequest]) (*connect.Response[v1.DeleteProfileScheduleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.DeleteProfileSchedule is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) ListProfileRuns(context.Context, *connect.Request[v1.ListProfileRunsRequest]) (*connect.Response[v1.ListProfileRunsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ListProfileRuns is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) GetProfileRun(context.Context, *connect.Request[v1.GetProfileRunRequest]) (*connect.Response[v1.GetProfileRunResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.GetProfileRun is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) DescribeFunction(context.Context, *connect.Request[v1.ColonyDescribeFunctionRequest]) (*connect.Response[v1.ColonyDescribeFunctionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.DescribeFunction is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) CaptureHttp(context.Context, *connect.Request[v1.CaptureHttpRequest]) (*connect.Response[v1.CaptureHttpResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.CaptureHttp is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) CaptureTls(context.Context, *connect.Request[v1.CaptureTlsRequest]) (*connect.Response[v1.CaptureTlsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.CaptureTls is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) TraceRuntime(context.Context, *connect.Request[v1.TraceRuntimeRequest]) (*connect.Response[v1.TraceRuntimeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.TraceRuntime is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) TraceSyscalls(context.Context, *connect.Request[v1.TraceSyscallsRequest]) (*connect.Response[v1.TraceSyscallsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.TraceSyscalls is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) UploadShellRecording(context.Context, *connect.Request[v1.UploadShellRecordingRequest]) (*connect.Response[v1.UploadShellRecordingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.UploadShellRecording is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) ListShellRecordings(context.Context, *connect.Request[v1.ListShellRecordingsRequest]) (*connect.Response[v1.ListShellRecordingsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.ListShellRecordings is not implemented"))
+}
+
+func (UnimplementedColonyDebugServiceHandler) GetShellRecording(context.Context, *connect.Request[v1.GetShellRecordingRequest]) (*connect.Response[v1.GetShellRecordingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.colony.v1.ColonyDebugService.GetShellRecording is not implemented"))
+}
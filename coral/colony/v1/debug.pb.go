@@ -12,6 +12,7 @@ import (
 	unsafe "unsafe"
 
 	v1 "github.com/coral-mesh/coral/coral/agent/v1"
+	v11 "github.com/coral-mesh/coral/coral/errors/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
@@ -27,16 +28,18 @@ const (
 
 // AttachUprobeRequest initiates a debug session on a specific function.
 type AttachUprobeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
-	FunctionName  string                 `protobuf:"bytes,2,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
-	Duration      *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"` // Default: 60s, Max: 600s
-	Config        *v1.UprobeConfig       `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
-	AgentId       string                 `protobuf:"bytes,5,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Manual override (until service discovery integration)
-	SdkAddr       string                 `protobuf:"bytes,6,opt,name=sdk_addr,json=sdkAddr,proto3" json:"sdk_addr,omitempty"` // Manual override (until service discovery integration)
-	Filter        *v1.UprobeFilter       `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`                  // Optional kernel-level filter (RFD 090).
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName  string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	FunctionName string                 `protobuf:"bytes,2,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	Duration     *durationpb.Duration   `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"` // Default: 60s, Max: 600s
+	Config       *v1.UprobeConfig       `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	AgentId      string                 `protobuf:"bytes,5,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Manual override (until service discovery integration)
+	SdkAddr      string                 `protobuf:"bytes,6,opt,name=sdk_addr,json=sdkAddr,proto3" json:"sdk_addr,omitempty"` // Manual override (until service discovery integration)
+	Filter       *v1.UprobeFilter       `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`                  // Optional kernel-level filter (RFD 090).
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,8,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *AttachUprobeRequest) Reset() {
@@ -118,6 +121,13 @@ func (x *AttachUprobeRequest) GetFilter() *v1.UprobeFilter {
 	return nil
 }
 
+func (x *AttachUprobeRequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
 // UpdateProbeFilterRequest updates filter parameters for an active debug session (RFD 090).
 type UpdateProbeFilterRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -218,11 +228,13 @@ func (*UpdateProbeFilterResponse) Descriptor() ([]byte, []int) {
 
 // AttachUprobeResponse confirms debug session creation.
 type AttachUprobeResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Success   bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Error     string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Classification of the failure, when known.
+	ErrorInfo     *v11.ErrorInfo `protobuf:"bytes,5,opt,name=error_info,json=errorInfo,proto3" json:"error_info,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -285,6 +297,13 @@ func (x *AttachUprobeResponse) GetError() string {
 	return ""
 }
 
+func (x *AttachUprobeResponse) GetErrorInfo() *v11.ErrorInfo {
+	if x != nil {
+		return x.ErrorInfo
+	}
+	return nil
+}
+
 // DetachUprobeRequest stops a debug session early.
 type DetachUprobeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -505,6 +524,128 @@ func (x *QueryUprobeEventsResponse) GetHasMore() bool {
 	return false
 }
 
+// IngestUprobeEventsRequest is a batch of events of a single debug session.
+type IngestUprobeEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Events        []*v1.UprobeEvent      `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	DroppedEvents uint64                 `protobuf:"varint,4,opt,name=dropped_events,json=droppedEvents,proto3" json:"dropped_events,omitempty"` // Events dropped by the agent since the previous batch
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestUprobeEventsRequest) Reset() {
+	*x = IngestUprobeEventsRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestUprobeEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestUprobeEventsRequest) ProtoMessage() {}
+
+func (x *IngestUprobeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestUprobeEventsRequest.ProtoReflect.Descriptor instead.
+func (*IngestUprobeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *IngestUprobeEventsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *IngestUprobeEventsRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *IngestUprobeEventsRequest) GetEvents() []*v1.UprobeEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *IngestUprobeEventsRequest) GetDroppedEvents() uint64 {
+	if x != nil {
+		return x.DroppedEvents
+	}
+	return 0
+}
+
+// IngestUprobeEventsResponse is sent once when the stream closes.
+type IngestUprobeEventsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	EventsAccepted    int64                  `protobuf:"varint,1,opt,name=events_accepted,json=eventsAccepted,proto3" json:"events_accepted,omitempty"`
+	UnknownSessionIds []string               `protobuf:"bytes,2,rep,name=unknown_session_ids,json=unknownSessionIds,proto3" json:"unknown_session_ids,omitempty"` // Sessions the agent should stop pushing
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *IngestUprobeEventsResponse) Reset() {
+	*x = IngestUprobeEventsResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestUprobeEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestUprobeEventsResponse) ProtoMessage() {}
+
+func (x *IngestUprobeEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestUprobeEventsResponse.ProtoReflect.Descriptor instead.
+func (*IngestUprobeEventsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *IngestUprobeEventsResponse) GetEventsAccepted() int64 {
+	if x != nil {
+		return x.EventsAccepted
+	}
+	return 0
+}
+
+func (x *IngestUprobeEventsResponse) GetUnknownSessionIds() []string {
+	if x != nil {
+		return x.UnknownSessionIds
+	}
+	return nil
+}
+
 // ListDebugSessionsRequest retrieves active debug sessions.
 type ListDebugSessionsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -518,7 +659,7 @@ type ListDebugSessionsRequest struct {
 
 func (x *ListDebugSessionsRequest) Reset() {
 	*x = ListDebugSessionsRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[8]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -530,7 +671,7 @@ func (x *ListDebugSessionsRequest) String() string {
 func (*ListDebugSessionsRequest) ProtoMessage() {}
 
 func (x *ListDebugSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[8]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -543,7 +684,7 @@ func (x *ListDebugSessionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDebugSessionsRequest.ProtoReflect.Descriptor instead.
 func (*ListDebugSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{8}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListDebugSessionsRequest) GetServiceName() string {
@@ -570,7 +711,7 @@ type ListDebugSessionsResponse struct {
 
 func (x *ListDebugSessionsResponse) Reset() {
 	*x = ListDebugSessionsResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[9]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -582,7 +723,7 @@ func (x *ListDebugSessionsResponse) String() string {
 func (*ListDebugSessionsResponse) ProtoMessage() {}
 
 func (x *ListDebugSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[9]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -595,7 +736,7 @@ func (x *ListDebugSessionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListDebugSessionsResponse.ProtoReflect.Descriptor instead.
 func (*ListDebugSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{9}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListDebugSessionsResponse) GetSessions() []*DebugSession {
@@ -623,7 +764,7 @@ type DebugSession struct {
 
 func (x *DebugSession) Reset() {
 	*x = DebugSession{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[10]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -635,7 +776,7 @@ func (x *DebugSession) String() string {
 func (*DebugSession) ProtoMessage() {}
 
 func (x *DebugSession) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[10]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,7 +789,7 @@ func (x *DebugSession) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DebugSession.ProtoReflect.Descriptor instead.
 func (*DebugSession) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{10}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DebugSession) GetSessionId() string {
@@ -727,7 +868,7 @@ type TraceRequestPathRequest struct {
 
 func (x *TraceRequestPathRequest) Reset() {
 	*x = TraceRequestPathRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[11]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -739,7 +880,7 @@ func (x *TraceRequestPathRequest) String() string {
 func (*TraceRequestPathRequest) ProtoMessage() {}
 
 func (x *TraceRequestPathRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[11]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -752,7 +893,7 @@ func (x *TraceRequestPathRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TraceRequestPathRequest.ProtoReflect.Descriptor instead.
 func (*TraceRequestPathRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{11}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TraceRequestPathRequest) GetServiceName() string {
@@ -798,7 +939,7 @@ type TraceRequestPathResponse struct {
 
 func (x *TraceRequestPathResponse) Reset() {
 	*x = TraceRequestPathResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[12]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -810,7 +951,7 @@ func (x *TraceRequestPathResponse) String() string {
 func (*TraceRequestPathResponse) ProtoMessage() {}
 
 func (x *TraceRequestPathResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[12]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -823,7 +964,7 @@ func (x *TraceRequestPathResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TraceRequestPathResponse.ProtoReflect.Descriptor instead.
 func (*TraceRequestPathResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{12}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *TraceRequestPathResponse) GetSessionId() string {
@@ -866,7 +1007,7 @@ type GetDebugResultsRequest struct {
 
 func (x *GetDebugResultsRequest) Reset() {
 	*x = GetDebugResultsRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[13]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -878,7 +1019,7 @@ func (x *GetDebugResultsRequest) String() string {
 func (*GetDebugResultsRequest) ProtoMessage() {}
 
 func (x *GetDebugResultsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[13]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -891,7 +1032,7 @@ func (x *GetDebugResultsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDebugResultsRequest.ProtoReflect.Descriptor instead.
 func (*GetDebugResultsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{13}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetDebugResultsRequest) GetSessionId() string {
@@ -932,7 +1073,7 @@ type GetDebugResultsResponse struct {
 
 func (x *GetDebugResultsResponse) Reset() {
 	*x = GetDebugResultsResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[14]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -944,7 +1085,7 @@ func (x *GetDebugResultsResponse) String() string {
 func (*GetDebugResultsResponse) ProtoMessage() {}
 
 func (x *GetDebugResultsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[14]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -957,7 +1098,7 @@ func (x *GetDebugResultsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDebugResultsResponse.ProtoReflect.Descriptor instead.
 func (*GetDebugResultsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{14}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetDebugResultsResponse) GetSessionId() string {
@@ -1029,7 +1170,7 @@ type DebugStatistics struct {
 
 func (x *DebugStatistics) Reset() {
 	*x = DebugStatistics{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[15]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1041,7 +1182,7 @@ func (x *DebugStatistics) String() string {
 func (*DebugStatistics) ProtoMessage() {}
 
 func (x *DebugStatistics) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[15]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1054,7 +1195,7 @@ func (x *DebugStatistics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DebugStatistics.ProtoReflect.Descriptor instead.
 func (*DebugStatistics) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{15}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *DebugStatistics) GetTotalCalls() int64 {
@@ -1103,7 +1244,7 @@ type SlowOutlier struct {
 
 func (x *SlowOutlier) Reset() {
 	*x = SlowOutlier{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[16]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1115,7 +1256,7 @@ func (x *SlowOutlier) String() string {
 func (*SlowOutlier) ProtoMessage() {}
 
 func (x *SlowOutlier) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[16]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1128,7 +1269,7 @@ func (x *SlowOutlier) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SlowOutlier.ProtoReflect.Descriptor instead.
 func (*SlowOutlier) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{16}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SlowOutlier) GetDuration() *durationpb.Duration {
@@ -1163,7 +1304,7 @@ type CallTree struct {
 
 func (x *CallTree) Reset() {
 	*x = CallTree{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[17]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1175,7 +1316,7 @@ func (x *CallTree) String() string {
 func (*CallTree) ProtoMessage() {}
 
 func (x *CallTree) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[17]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1188,7 +1329,7 @@ func (x *CallTree) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallTree.ProtoReflect.Descriptor instead.
 func (*CallTree) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{17}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CallTree) GetRoot() *CallTreeNode {
@@ -1220,7 +1361,7 @@ type CallTreeNode struct {
 
 func (x *CallTreeNode) Reset() {
 	*x = CallTreeNode{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[18]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1232,7 +1373,7 @@ func (x *CallTreeNode) String() string {
 func (*CallTreeNode) ProtoMessage() {}
 
 func (x *CallTreeNode) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[18]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1245,7 +1386,7 @@ func (x *CallTreeNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallTreeNode.ProtoReflect.Descriptor instead.
 func (*CallTreeNode) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{18}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CallTreeNode) GetFunctionName() string {
@@ -1304,7 +1445,7 @@ type QueryFunctionsRequest struct {
 
 func (x *QueryFunctionsRequest) Reset() {
 	*x = QueryFunctionsRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[19]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1316,7 +1457,7 @@ func (x *QueryFunctionsRequest) String() string {
 func (*QueryFunctionsRequest) ProtoMessage() {}
 
 func (x *QueryFunctionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[19]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1329,7 +1470,7 @@ func (x *QueryFunctionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryFunctionsRequest.ProtoReflect.Descriptor instead.
 func (*QueryFunctionsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{19}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *QueryFunctionsRequest) GetServiceName() string {
@@ -1381,7 +1522,7 @@ type QueryFunctionsResponse struct {
 
 func (x *QueryFunctionsResponse) Reset() {
 	*x = QueryFunctionsResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[20]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1393,7 +1534,7 @@ func (x *QueryFunctionsResponse) String() string {
 func (*QueryFunctionsResponse) ProtoMessage() {}
 
 func (x *QueryFunctionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[20]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1406,7 +1547,7 @@ func (x *QueryFunctionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryFunctionsResponse.ProtoReflect.Descriptor instead.
 func (*QueryFunctionsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{20}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *QueryFunctionsResponse) GetServiceName() string {
@@ -1458,7 +1599,7 @@ type FunctionResult struct {
 
 func (x *FunctionResult) Reset() {
 	*x = FunctionResult{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[21]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1470,7 +1611,7 @@ func (x *FunctionResult) String() string {
 func (*FunctionResult) ProtoMessage() {}
 
 func (x *FunctionResult) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[21]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1483,7 +1624,7 @@ func (x *FunctionResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionResult.ProtoReflect.Descriptor instead.
 func (*FunctionResult) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{21}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *FunctionResult) GetFunction() *FunctionMetadata {
@@ -1536,7 +1677,7 @@ type FunctionMetadata struct {
 
 func (x *FunctionMetadata) Reset() {
 	*x = FunctionMetadata{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[22]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1548,7 +1689,7 @@ func (x *FunctionMetadata) String() string {
 func (*FunctionMetadata) ProtoMessage() {}
 
 func (x *FunctionMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[22]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1561,7 +1702,7 @@ func (x *FunctionMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionMetadata.ProtoReflect.Descriptor instead.
 func (*FunctionMetadata) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{22}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *FunctionMetadata) GetId() string {
@@ -1617,7 +1758,7 @@ type SearchInfo struct {
 
 func (x *SearchInfo) Reset() {
 	*x = SearchInfo{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[23]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1629,7 +1770,7 @@ func (x *SearchInfo) String() string {
 func (*SearchInfo) ProtoMessage() {}
 
 func (x *SearchInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[23]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1642,7 +1783,7 @@ func (x *SearchInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchInfo.ProtoReflect.Descriptor instead.
 func (*SearchInfo) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{23}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SearchInfo) GetScore() float64 {
@@ -1676,7 +1817,7 @@ type FunctionMetrics struct {
 
 func (x *FunctionMetrics) Reset() {
 	*x = FunctionMetrics{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[24]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1688,7 +1829,7 @@ func (x *FunctionMetrics) String() string {
 func (*FunctionMetrics) ProtoMessage() {}
 
 func (x *FunctionMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[24]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1701,7 +1842,7 @@ func (x *FunctionMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FunctionMetrics.ProtoReflect.Descriptor instead.
 func (*FunctionMetrics) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{24}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *FunctionMetrics) GetSource() string {
@@ -1773,7 +1914,7 @@ type InstrumentationInfo struct {
 
 func (x *InstrumentationInfo) Reset() {
 	*x = InstrumentationInfo{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[25]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1785,7 +1926,7 @@ func (x *InstrumentationInfo) String() string {
 func (*InstrumentationInfo) ProtoMessage() {}
 
 func (x *InstrumentationInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[25]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1798,7 +1939,7 @@ func (x *InstrumentationInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstrumentationInfo.ProtoReflect.Descriptor instead.
 func (*InstrumentationInfo) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{25}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *InstrumentationInfo) GetIsProbeable() bool {
@@ -1845,7 +1986,7 @@ type ProfileFunctionsRequest struct {
 
 func (x *ProfileFunctionsRequest) Reset() {
 	*x = ProfileFunctionsRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[26]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1857,7 +1998,7 @@ func (x *ProfileFunctionsRequest) String() string {
 func (*ProfileFunctionsRequest) ProtoMessage() {}
 
 func (x *ProfileFunctionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[26]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1870,7 +2011,7 @@ func (x *ProfileFunctionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileFunctionsRequest.ProtoReflect.Descriptor instead.
 func (*ProfileFunctionsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{26}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ProfileFunctionsRequest) GetServiceName() string {
@@ -1941,7 +2082,7 @@ type ProfileFunctionsResponse struct {
 
 func (x *ProfileFunctionsResponse) Reset() {
 	*x = ProfileFunctionsResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[27]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1953,7 +2094,7 @@ func (x *ProfileFunctionsResponse) String() string {
 func (*ProfileFunctionsResponse) ProtoMessage() {}
 
 func (x *ProfileFunctionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[27]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1966,7 +2107,7 @@ func (x *ProfileFunctionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileFunctionsResponse.ProtoReflect.Descriptor instead.
 func (*ProfileFunctionsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{27}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ProfileFunctionsResponse) GetSessionId() string {
@@ -2039,33 +2180,29 @@ func (x *ProfileFunctionsResponse) GetNextSteps() []string {
 	return nil
 }
 
-// ProfileSummary contains high-level profiling statistics.
-type ProfileSummary struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	FunctionsSelected   int32                  `protobuf:"varint,1,opt,name=functions_selected,json=functionsSelected,proto3" json:"functions_selected,omitempty"`
-	FunctionsProbed     int32                  `protobuf:"varint,2,opt,name=functions_probed,json=functionsProbed,proto3" json:"functions_probed,omitempty"`
-	ProbesFailed        int32                  `protobuf:"varint,3,opt,name=probes_failed,json=probesFailed,proto3" json:"probes_failed,omitempty"`
-	TotalEventsCaptured int64                  `protobuf:"varint,4,opt,name=total_events_captured,json=totalEventsCaptured,proto3" json:"total_events_captured,omitempty"`
-	Duration            *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+// CancelProfileFunctionsRequest cancels an async profiling run.
+type CancelProfileFunctionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Session ID returned by ProfileFunctions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProfileSummary) Reset() {
-	*x = ProfileSummary{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[28]
+func (x *CancelProfileFunctionsRequest) Reset() {
+	*x = CancelProfileFunctionsRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProfileSummary) String() string {
+func (x *CancelProfileFunctionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProfileSummary) ProtoMessage() {}
+func (*CancelProfileFunctionsRequest) ProtoMessage() {}
 
-func (x *ProfileSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[28]
+func (x *CancelProfileFunctionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2076,14 +2213,116 @@ func (x *ProfileSummary) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProfileSummary.ProtoReflect.Descriptor instead.
-func (*ProfileSummary) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use CancelProfileFunctionsRequest.ProtoReflect.Descriptor instead.
+func (*CancelProfileFunctionsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ProfileSummary) GetFunctionsSelected() int32 {
+func (x *CancelProfileFunctionsRequest) GetSessionId() string {
 	if x != nil {
-		return x.FunctionsSelected
+		return x.SessionId
+	}
+	return ""
+}
+
+// CancelProfileFunctionsResponse reports the sessions detached.
+type CancelProfileFunctionsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	SessionsDetached int32                  `protobuf:"varint,1,opt,name=sessions_detached,json=sessionsDetached,proto3" json:"sessions_detached,omitempty"`
+	SessionsFailed   int32                  `protobuf:"varint,2,opt,name=sessions_failed,json=sessionsFailed,proto3" json:"sessions_failed,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CancelProfileFunctionsResponse) Reset() {
+	*x = CancelProfileFunctionsResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelProfileFunctionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelProfileFunctionsResponse) ProtoMessage() {}
+
+func (x *CancelProfileFunctionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelProfileFunctionsResponse.ProtoReflect.Descriptor instead.
+func (*CancelProfileFunctionsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CancelProfileFunctionsResponse) GetSessionsDetached() int32 {
+	if x != nil {
+		return x.SessionsDetached
+	}
+	return 0
+}
+
+func (x *CancelProfileFunctionsResponse) GetSessionsFailed() int32 {
+	if x != nil {
+		return x.SessionsFailed
+	}
+	return 0
+}
+
+// ProfileSummary contains high-level profiling statistics.
+type ProfileSummary struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	FunctionsSelected   int32                  `protobuf:"varint,1,opt,name=functions_selected,json=functionsSelected,proto3" json:"functions_selected,omitempty"`
+	FunctionsProbed     int32                  `protobuf:"varint,2,opt,name=functions_probed,json=functionsProbed,proto3" json:"functions_probed,omitempty"`
+	ProbesFailed        int32                  `protobuf:"varint,3,opt,name=probes_failed,json=probesFailed,proto3" json:"probes_failed,omitempty"`
+	TotalEventsCaptured int64                  `protobuf:"varint,4,opt,name=total_events_captured,json=totalEventsCaptured,proto3" json:"total_events_captured,omitempty"`
+	Duration            *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ProfileSummary) Reset() {
+	*x = ProfileSummary{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileSummary) ProtoMessage() {}
+
+func (x *ProfileSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileSummary.ProtoReflect.Descriptor instead.
+func (*ProfileSummary) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ProfileSummary) GetFunctionsSelected() int32 {
+	if x != nil {
+		return x.FunctionsSelected
 	}
 	return 0
 }
@@ -2129,7 +2368,7 @@ type ProfileResult struct {
 
 func (x *ProfileResult) Reset() {
 	*x = ProfileResult{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[29]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2141,7 +2380,7 @@ func (x *ProfileResult) String() string {
 func (*ProfileResult) ProtoMessage() {}
 
 func (x *ProfileResult) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[29]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2154,7 +2393,7 @@ func (x *ProfileResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileResult.ProtoReflect.Descriptor instead.
 func (*ProfileResult) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{29}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ProfileResult) GetFunction() string {
@@ -2198,7 +2437,7 @@ type CallContribution struct {
 
 func (x *CallContribution) Reset() {
 	*x = CallContribution{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[30]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2210,7 +2449,7 @@ func (x *CallContribution) String() string {
 func (*CallContribution) ProtoMessage() {}
 
 func (x *CallContribution) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[30]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2223,7 +2462,7 @@ func (x *CallContribution) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallContribution.ProtoReflect.Descriptor instead.
 func (*CallContribution) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{30}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *CallContribution) GetCallee() string {
@@ -2269,7 +2508,7 @@ type Bottleneck struct {
 
 func (x *Bottleneck) Reset() {
 	*x = Bottleneck{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[31]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2281,7 +2520,7 @@ func (x *Bottleneck) String() string {
 func (*Bottleneck) ProtoMessage() {}
 
 func (x *Bottleneck) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[31]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2294,7 +2533,7 @@ func (x *Bottleneck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Bottleneck.ProtoReflect.Descriptor instead.
 func (*Bottleneck) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{31}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *Bottleneck) GetFunction() string {
@@ -2346,13 +2585,15 @@ type ProfileCPURequest struct {
 	PodName         string                 `protobuf:"bytes,2,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`                          // Optional, specific pod instance.
 	DurationSeconds int32                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Profiling duration (default: 30s, max: 300s).
 	FrequencyHz     int32                  `protobuf:"varint,4,opt,name=frequency_hz,json=frequencyHz,proto3" json:"frequency_hz,omitempty"`             // Sampling frequency (default: 99Hz, max: 1000Hz).
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,5,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ProfileCPURequest) Reset() {
 	*x = ProfileCPURequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[32]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2364,7 +2605,7 @@ func (x *ProfileCPURequest) String() string {
 func (*ProfileCPURequest) ProtoMessage() {}
 
 func (x *ProfileCPURequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[32]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2377,7 +2618,7 @@ func (x *ProfileCPURequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileCPURequest.ProtoReflect.Descriptor instead.
 func (*ProfileCPURequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{32}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *ProfileCPURequest) GetServiceName() string {
@@ -2408,6 +2649,13 @@ func (x *ProfileCPURequest) GetFrequencyHz() int32 {
 	return 0
 }
 
+func (x *ProfileCPURequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
 // ProfileCPUResponse returns CPU profile samples (RFD 070).
 type ProfileCPUResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2422,7 +2670,7 @@ type ProfileCPUResponse struct {
 
 func (x *ProfileCPUResponse) Reset() {
 	*x = ProfileCPUResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[33]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2434,7 +2682,7 @@ func (x *ProfileCPUResponse) String() string {
 func (*ProfileCPUResponse) ProtoMessage() {}
 
 func (x *ProfileCPUResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[33]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2447,7 +2695,7 @@ func (x *ProfileCPUResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileCPUResponse.ProtoReflect.Descriptor instead.
 func (*ProfileCPUResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{33}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ProfileCPUResponse) GetSamples() []*v1.StackSample {
@@ -2497,7 +2745,7 @@ type QueryHistoricalCPUProfileRequest struct {
 
 func (x *QueryHistoricalCPUProfileRequest) Reset() {
 	*x = QueryHistoricalCPUProfileRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[34]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2509,7 +2757,7 @@ func (x *QueryHistoricalCPUProfileRequest) String() string {
 func (*QueryHistoricalCPUProfileRequest) ProtoMessage() {}
 
 func (x *QueryHistoricalCPUProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[34]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2522,7 +2770,7 @@ func (x *QueryHistoricalCPUProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryHistoricalCPUProfileRequest.ProtoReflect.Descriptor instead.
 func (*QueryHistoricalCPUProfileRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{34}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *QueryHistoricalCPUProfileRequest) GetServiceName() string {
@@ -2559,7 +2807,7 @@ type QueryHistoricalCPUProfileResponse struct {
 
 func (x *QueryHistoricalCPUProfileResponse) Reset() {
 	*x = QueryHistoricalCPUProfileResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[35]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2571,7 +2819,7 @@ func (x *QueryHistoricalCPUProfileResponse) String() string {
 func (*QueryHistoricalCPUProfileResponse) ProtoMessage() {}
 
 func (x *QueryHistoricalCPUProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[35]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2584,7 +2832,7 @@ func (x *QueryHistoricalCPUProfileResponse) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use QueryHistoricalCPUProfileResponse.ProtoReflect.Descriptor instead.
 func (*QueryHistoricalCPUProfileResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{35}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *QueryHistoricalCPUProfileResponse) GetSamples() []*v1.StackSample {
@@ -2622,13 +2870,15 @@ type ProfileMemoryRequest struct {
 	PodName         string                 `protobuf:"bytes,2,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`                            // Optional, specific pod instance.
 	DurationSeconds int32                  `protobuf:"varint,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`   // Profiling duration (default: 30s, max: 300s).
 	SampleRateBytes int32                  `protobuf:"varint,4,opt,name=sample_rate_bytes,json=sampleRateBytes,proto3" json:"sample_rate_bytes,omitempty"` // Allocation sampling rate in bytes (default: 512KB).
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,5,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ProfileMemoryRequest) Reset() {
 	*x = ProfileMemoryRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[36]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2640,7 +2890,7 @@ func (x *ProfileMemoryRequest) String() string {
 func (*ProfileMemoryRequest) ProtoMessage() {}
 
 func (x *ProfileMemoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[36]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2653,7 +2903,7 @@ func (x *ProfileMemoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileMemoryRequest.ProtoReflect.Descriptor instead.
 func (*ProfileMemoryRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{36}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ProfileMemoryRequest) GetServiceName() string {
@@ -2684,6 +2934,13 @@ func (x *ProfileMemoryRequest) GetSampleRateBytes() int32 {
 	return 0
 }
 
+func (x *ProfileMemoryRequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
 // ProfileMemoryResponse returns memory profile results (RFD 077).
 type ProfileMemoryResponse struct {
 	state         protoimpl.MessageState  `protogen:"open.v1"`
@@ -2699,7 +2956,7 @@ type ProfileMemoryResponse struct {
 
 func (x *ProfileMemoryResponse) Reset() {
 	*x = ProfileMemoryResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[37]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2711,7 +2968,7 @@ func (x *ProfileMemoryResponse) String() string {
 func (*ProfileMemoryResponse) ProtoMessage() {}
 
 func (x *ProfileMemoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[37]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2724,7 +2981,7 @@ func (x *ProfileMemoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfileMemoryResponse.ProtoReflect.Descriptor instead.
 func (*ProfileMemoryResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{37}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *ProfileMemoryResponse) GetSamples() []*v1.MemoryStackSample {
@@ -2775,13 +3032,14 @@ type QueryHistoricalMemoryProfileRequest struct {
 	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"` // Target service name.
 	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`       // Query start time.
 	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`             // Query end time.
+	BuildId       string                 `protobuf:"bytes,4,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`             // Optional build ID filter.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QueryHistoricalMemoryProfileRequest) Reset() {
 	*x = QueryHistoricalMemoryProfileRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[38]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2793,7 +3051,7 @@ func (x *QueryHistoricalMemoryProfileRequest) String() string {
 func (*QueryHistoricalMemoryProfileRequest) ProtoMessage() {}
 
 func (x *QueryHistoricalMemoryProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[38]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2806,7 +3064,7 @@ func (x *QueryHistoricalMemoryProfileRequest) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use QueryHistoricalMemoryProfileRequest.ProtoReflect.Descriptor instead.
 func (*QueryHistoricalMemoryProfileRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{38}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *QueryHistoricalMemoryProfileRequest) GetServiceName() string {
@@ -2830,6 +3088,13 @@ func (x *QueryHistoricalMemoryProfileRequest) GetEndTime() *timestamppb.Timestam
 	return nil
 }
 
+func (x *QueryHistoricalMemoryProfileRequest) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
 // QueryHistoricalMemoryProfileResponse returns aggregated historical memory profiles (RFD 077).
 type QueryHistoricalMemoryProfileResponse struct {
 	state           protoimpl.MessageState  `protogen:"open.v1"`
@@ -2840,13 +3105,15 @@ type QueryHistoricalMemoryProfileResponse struct {
 	TopFunctions    []*v1.TopAllocFunction  `protobuf:"bytes,5,rep,name=top_functions,json=topFunctions,proto3" json:"top_functions,omitempty"`             // Top allocating functions (summarized).
 	TopTypes        []*v1.TopAllocType      `protobuf:"bytes,6,rep,name=top_types,json=topTypes,proto3" json:"top_types,omitempty"`                         // Top allocation types (summarized).
 	UniqueStacks    int32                   `protobuf:"varint,7,opt,name=unique_stacks,json=uniqueStacks,proto3" json:"unique_stacks,omitempty"`            // Number of unique stack traces.
+	Growth          []*MemoryGrowthBucket   `protobuf:"bytes,8,rep,name=growth,proto3" json:"growth,omitempty"`                                             // Allocation volume over time, oldest first.
+	LeakCandidates  []*MemoryLeakCandidate  `protobuf:"bytes,9,rep,name=leak_candidates,json=leakCandidates,proto3" json:"leak_candidates,omitempty"`       // Stacks whose allocation rate keeps growing.
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *QueryHistoricalMemoryProfileResponse) Reset() {
 	*x = QueryHistoricalMemoryProfileResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[39]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2858,7 +3125,7 @@ func (x *QueryHistoricalMemoryProfileResponse) String() string {
 func (*QueryHistoricalMemoryProfileResponse) ProtoMessage() {}
 
 func (x *QueryHistoricalMemoryProfileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[39]
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2871,7 +3138,7 @@ func (x *QueryHistoricalMemoryProfileResponse) ProtoReflect() protoreflect.Messa
 
 // Deprecated: Use QueryHistoricalMemoryProfileResponse.ProtoReflect.Descriptor instead.
 func (*QueryHistoricalMemoryProfileResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{39}
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *QueryHistoricalMemoryProfileResponse) GetSamples() []*v1.MemoryStackSample {
@@ -2923,31 +3190,46 @@ func (x *QueryHistoricalMemoryProfileResponse) GetUniqueStacks() int32 {
 	return 0
 }
 
-// ColonyDeployCorrelationRequest validates and deploys a correlation descriptor
-// to the agent hosting the named service (RFD 091).
-type ColonyDeployCorrelationRequest struct {
-	state         protoimpl.MessageState    `protogen:"open.v1"`
-	ServiceName   string                    `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
-	Descriptor_   *v1.CorrelationDescriptor `protobuf:"bytes,2,opt,name=descriptor,proto3" json:"descriptor,omitempty"`
+func (x *QueryHistoricalMemoryProfileResponse) GetGrowth() []*MemoryGrowthBucket {
+	if x != nil {
+		return x.Growth
+	}
+	return nil
+}
+
+func (x *QueryHistoricalMemoryProfileResponse) GetLeakCandidates() []*MemoryLeakCandidate {
+	if x != nil {
+		return x.LeakCandidates
+	}
+	return nil
+}
+
+// MemoryGrowthBucket is the allocation volume of a service over a slice of the
+// queried time range.
+type MemoryGrowthBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	AllocBytes    int64                  `protobuf:"varint,2,opt,name=alloc_bytes,json=allocBytes,proto3" json:"alloc_bytes,omitempty"`
+	AllocObjects  int64                  `protobuf:"varint,3,opt,name=alloc_objects,json=allocObjects,proto3" json:"alloc_objects,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ColonyDeployCorrelationRequest) Reset() {
-	*x = ColonyDeployCorrelationRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[40]
+func (x *MemoryGrowthBucket) Reset() {
+	*x = MemoryGrowthBucket{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ColonyDeployCorrelationRequest) String() string {
+func (x *MemoryGrowthBucket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ColonyDeployCorrelationRequest) ProtoMessage() {}
+func (*MemoryGrowthBucket) ProtoMessage() {}
 
-func (x *ColonyDeployCorrelationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[40]
+func (x *MemoryGrowthBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2958,51 +3240,59 @@ func (x *ColonyDeployCorrelationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ColonyDeployCorrelationRequest.ProtoReflect.Descriptor instead.
-func (*ColonyDeployCorrelationRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use MemoryGrowthBucket.ProtoReflect.Descriptor instead.
+func (*MemoryGrowthBucket) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *ColonyDeployCorrelationRequest) GetServiceName() string {
+func (x *MemoryGrowthBucket) GetStartTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ServiceName
+		return x.StartTime
 	}
-	return ""
+	return nil
 }
 
-func (x *ColonyDeployCorrelationRequest) GetDescriptor_() *v1.CorrelationDescriptor {
+func (x *MemoryGrowthBucket) GetAllocBytes() int64 {
 	if x != nil {
-		return x.Descriptor_
+		return x.AllocBytes
 	}
-	return nil
+	return 0
 }
 
-// ColonyDeployCorrelationResponse confirms descriptor deployment (RFD 091).
-type ColonyDeployCorrelationResponse struct {
+func (x *MemoryGrowthBucket) GetAllocObjects() int64 {
+	if x != nil {
+		return x.AllocObjects
+	}
+	return 0
+}
+
+// MemoryLeakCandidate is a stack whose allocation rate grew between the first
+// and second half of the queried time range, a common signature of a leak.
+type MemoryLeakCandidate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
-	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	FrameNames    []string               `protobuf:"bytes,1,rep,name=frame_names,json=frameNames,proto3" json:"frame_names,omitempty"`           // Stack frames from innermost to outermost.
+	AllocBytes    int64                  `protobuf:"varint,2,opt,name=alloc_bytes,json=allocBytes,proto3" json:"alloc_bytes,omitempty"`          // Total allocation bytes across time range.
+	GrowthPct     float64                `protobuf:"fixed64,3,opt,name=growth_pct,json=growthPct,proto3" json:"growth_pct,omitempty"`            // Growth of the second half over the first.
+	ActiveBuckets int32                  `protobuf:"varint,4,opt,name=active_buckets,json=activeBuckets,proto3" json:"active_buckets,omitempty"` // Growth buckets in which the stack allocated.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ColonyDeployCorrelationResponse) Reset() {
-	*x = ColonyDeployCorrelationResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[41]
+func (x *MemoryLeakCandidate) Reset() {
+	*x = MemoryLeakCandidate{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ColonyDeployCorrelationResponse) String() string {
+func (x *MemoryLeakCandidate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ColonyDeployCorrelationResponse) ProtoMessage() {}
+func (*MemoryLeakCandidate) ProtoMessage() {}
 
-func (x *ColonyDeployCorrelationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[41]
+func (x *MemoryLeakCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3013,43 +3303,175 @@ func (x *ColonyDeployCorrelationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ColonyDeployCorrelationResponse.ProtoReflect.Descriptor instead.
-func (*ColonyDeployCorrelationResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use MemoryLeakCandidate.ProtoReflect.Descriptor instead.
+func (*MemoryLeakCandidate) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *ColonyDeployCorrelationResponse) GetCorrelationId() string {
+func (x *MemoryLeakCandidate) GetFrameNames() []string {
 	if x != nil {
-		return x.CorrelationId
+		return x.FrameNames
 	}
-	return ""
+	return nil
 }
 
-func (x *ColonyDeployCorrelationResponse) GetAgentId() string {
+func (x *MemoryLeakCandidate) GetAllocBytes() int64 {
 	if x != nil {
-		return x.AgentId
+		return x.AllocBytes
 	}
-	return ""
+	return 0
 }
 
-func (x *ColonyDeployCorrelationResponse) GetError() string {
+func (x *MemoryLeakCandidate) GetGrowthPct() float64 {
 	if x != nil {
-		return x.Error
+		return x.GrowthPct
 	}
-	return ""
+	return 0
 }
 
-func (x *ColonyDeployCorrelationResponse) GetSuccess() bool {
+func (x *MemoryLeakCandidate) GetActiveBuckets() int32 {
 	if x != nil {
-		return x.Success
+		return x.ActiveBuckets
 	}
-	return false
+	return 0
 }
 
-// ColonyRemoveCorrelationRequest routes removal to the agent hosting the
-// named service (RFD 091).
-type ColonyRemoveCorrelationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// ColonyDeployCorrelationRequest validates and deploys a correlation descriptor
+// to the agent hosting the named service (RFD 091).
+type ColonyDeployCorrelationRequest struct {
+	state       protoimpl.MessageState    `protogen:"open.v1"`
+	ServiceName string                    `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Descriptor_ *v1.CorrelationDescriptor `protobuf:"bytes,2,opt,name=descriptor,proto3" json:"descriptor,omitempty"`
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,3,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ColonyDeployCorrelationRequest) Reset() {
+	*x = ColonyDeployCorrelationRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyDeployCorrelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyDeployCorrelationRequest) ProtoMessage() {}
+
+func (x *ColonyDeployCorrelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyDeployCorrelationRequest.ProtoReflect.Descriptor instead.
+func (*ColonyDeployCorrelationRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ColonyDeployCorrelationRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ColonyDeployCorrelationRequest) GetDescriptor_() *v1.CorrelationDescriptor {
+	if x != nil {
+		return x.Descriptor_
+	}
+	return nil
+}
+
+func (x *ColonyDeployCorrelationRequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
+// ColonyDeployCorrelationResponse confirms descriptor deployment (RFD 091).
+type ColonyDeployCorrelationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
+	AgentId       string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyDeployCorrelationResponse) Reset() {
+	*x = ColonyDeployCorrelationResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyDeployCorrelationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyDeployCorrelationResponse) ProtoMessage() {}
+
+func (x *ColonyDeployCorrelationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyDeployCorrelationResponse.ProtoReflect.Descriptor instead.
+func (*ColonyDeployCorrelationResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ColonyDeployCorrelationResponse) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ColonyDeployCorrelationResponse) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ColonyDeployCorrelationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ColonyDeployCorrelationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// ColonyRemoveCorrelationRequest routes removal to the agent hosting the
+// named service (RFD 091).
+type ColonyRemoveCorrelationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	CorrelationId string                 `protobuf:"bytes,1,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`
 	// service_name is optional — used to route removal when correlation_id alone
 	// is insufficient.
@@ -3058,21 +3480,2686 @@ type ColonyRemoveCorrelationRequest struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ColonyRemoveCorrelationRequest) Reset() {
-	*x = ColonyRemoveCorrelationRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[42]
+func (x *ColonyRemoveCorrelationRequest) Reset() {
+	*x = ColonyRemoveCorrelationRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyRemoveCorrelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyRemoveCorrelationRequest) ProtoMessage() {}
+
+func (x *ColonyRemoveCorrelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyRemoveCorrelationRequest.ProtoReflect.Descriptor instead.
+func (*ColonyRemoveCorrelationRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ColonyRemoveCorrelationRequest) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *ColonyRemoveCorrelationRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ColonyRemoveCorrelationResponse confirms removal (RFD 091).
+type ColonyRemoveCorrelationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyRemoveCorrelationResponse) Reset() {
+	*x = ColonyRemoveCorrelationResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyRemoveCorrelationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyRemoveCorrelationResponse) ProtoMessage() {}
+
+func (x *ColonyRemoveCorrelationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyRemoveCorrelationResponse.ProtoReflect.Descriptor instead.
+func (*ColonyRemoveCorrelationResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{49}
+}
+
+// ColonyListCorrelationsRequest lists active correlation descriptors (RFD 091).
+type ColonyListCorrelationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// service_name is optional — filters results to a single service.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyListCorrelationsRequest) Reset() {
+	*x = ColonyListCorrelationsRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyListCorrelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyListCorrelationsRequest) ProtoMessage() {}
+
+func (x *ColonyListCorrelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyListCorrelationsRequest.ProtoReflect.Descriptor instead.
+func (*ColonyListCorrelationsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ColonyListCorrelationsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ColonyListCorrelationsResponse returns active correlation descriptors (RFD 091).
+type ColonyListCorrelationsResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Descriptors   []*v1.CorrelationDescriptor `protobuf:"bytes,1,rep,name=descriptors,proto3" json:"descriptors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyListCorrelationsResponse) Reset() {
+	*x = ColonyListCorrelationsResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyListCorrelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyListCorrelationsResponse) ProtoMessage() {}
+
+func (x *ColonyListCorrelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyListCorrelationsResponse.ProtoReflect.Descriptor instead.
+func (*ColonyListCorrelationsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ColonyListCorrelationsResponse) GetDescriptors() []*v1.CorrelationDescriptor {
+	if x != nil {
+		return x.Descriptors
+	}
+	return nil
+}
+
+// ColonyListCoreDumpsRequest lists captured core dumps.
+type ColonyListCoreDumpsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// service_name is optional — filters results to a single service.
+	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyListCoreDumpsRequest) Reset() {
+	*x = ColonyListCoreDumpsRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyListCoreDumpsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyListCoreDumpsRequest) ProtoMessage() {}
+
+func (x *ColonyListCoreDumpsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyListCoreDumpsRequest.ProtoReflect.Descriptor instead.
+func (*ColonyListCoreDumpsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ColonyListCoreDumpsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ColonyListCoreDumpsResponse returns captured core dumps, newest first.
+type ColonyListCoreDumpsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dumps         []*v1.CoreDumpInfo     `protobuf:"bytes,1,rep,name=dumps,proto3" json:"dumps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyListCoreDumpsResponse) Reset() {
+	*x = ColonyListCoreDumpsResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyListCoreDumpsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyListCoreDumpsResponse) ProtoMessage() {}
+
+func (x *ColonyListCoreDumpsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyListCoreDumpsResponse.ProtoReflect.Descriptor instead.
+func (*ColonyListCoreDumpsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ColonyListCoreDumpsResponse) GetDumps() []*v1.CoreDumpInfo {
+	if x != nil {
+		return x.Dumps
+	}
+	return nil
+}
+
+// ColonyDownloadCoreDumpRequest identifies a core dump on an agent.
+type ColonyDownloadCoreDumpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyDownloadCoreDumpRequest) Reset() {
+	*x = ColonyDownloadCoreDumpRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyDownloadCoreDumpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyDownloadCoreDumpRequest) ProtoMessage() {}
+
+func (x *ColonyDownloadCoreDumpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyDownloadCoreDumpRequest.ProtoReflect.Descriptor instead.
+func (*ColonyDownloadCoreDumpRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ColonyDownloadCoreDumpRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ColonyDownloadCoreDumpRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ColonyDescribeFunctionRequest asks for the signature of a function.
+type ColonyDescribeFunctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	FunctionName  string                 `protobuf:"bytes,2,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyDescribeFunctionRequest) Reset() {
+	*x = ColonyDescribeFunctionRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyDescribeFunctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyDescribeFunctionRequest) ProtoMessage() {}
+
+func (x *ColonyDescribeFunctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyDescribeFunctionRequest.ProtoReflect.Descriptor instead.
+func (*ColonyDescribeFunctionRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ColonyDescribeFunctionRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ColonyDescribeFunctionRequest) GetFunctionName() string {
+	if x != nil {
+		return x.FunctionName
+	}
+	return ""
+}
+
+// ColonyDescribeFunctionResponse describes the function.
+type ColonyDescribeFunctionResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	AgentId       string                  `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Agent that described the function.
+	Function      *v1.FunctionDescription `protobuf:"bytes,2,opt,name=function,proto3" json:"function,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyDescribeFunctionResponse) Reset() {
+	*x = ColonyDescribeFunctionResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyDescribeFunctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyDescribeFunctionResponse) ProtoMessage() {}
+
+func (x *ColonyDescribeFunctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyDescribeFunctionResponse.ProtoReflect.Descriptor instead.
+func (*ColonyDescribeFunctionResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ColonyDescribeFunctionResponse) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ColonyDescribeFunctionResponse) GetFunction() *v1.FunctionDescription {
+	if x != nil {
+		return x.Function
+	}
+	return nil
+}
+
+// ProfileSchedule is a recurring profiling job.
+type ProfileSchedule struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceName     string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	PodName         string                 `protobuf:"bytes,3,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`                          // Optional, specific pod instance.
+	ProfileType     string                 `protobuf:"bytes,4,opt,name=profile_type,json=profileType,proto3" json:"profile_type,omitempty"`              // "cpu" or "memory".
+	DurationSeconds int32                  `protobuf:"varint,5,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Profiling duration of each run.
+	Interval        *durationpb.Duration   `protobuf:"bytes,6,opt,name=interval,proto3" json:"interval,omitempty"`                                       // Time between runs.
+	FrequencyHz     int32                  `protobuf:"varint,7,opt,name=frequency_hz,json=frequencyHz,proto3" json:"frequency_hz,omitempty"`             // CPU sampling frequency.
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	NextRunAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	LastRunAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"`  // Unset until the first run.
+	LastStatus      string                 `protobuf:"bytes,11,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"` // Status of the last run.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ProfileSchedule) Reset() {
+	*x = ProfileSchedule{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileSchedule) ProtoMessage() {}
+
+func (x *ProfileSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileSchedule.ProtoReflect.Descriptor instead.
+func (*ProfileSchedule) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ProfileSchedule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProfileSchedule) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProfileSchedule) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *ProfileSchedule) GetProfileType() string {
+	if x != nil {
+		return x.ProfileType
+	}
+	return ""
+}
+
+func (x *ProfileSchedule) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *ProfileSchedule) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *ProfileSchedule) GetFrequencyHz() int32 {
+	if x != nil {
+		return x.FrequencyHz
+	}
+	return 0
+}
+
+func (x *ProfileSchedule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ProfileSchedule) GetNextRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *ProfileSchedule) GetLastRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return nil
+}
+
+func (x *ProfileSchedule) GetLastStatus() string {
+	if x != nil {
+		return x.LastStatus
+	}
+	return ""
+}
+
+// ProfileRun is one execution of a profiling schedule.
+type ProfileRun struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ScheduleId    string                 `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	ProfileType   string                 `protobuf:"bytes,4,opt,name=profile_type,json=profileType,proto3" json:"profile_type,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // "success" or "failed".
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	Total         uint64                 `protobuf:"varint,9,opt,name=total,proto3" json:"total,omitempty"` // CPU samples, or allocated bytes for memory.
+	UniqueStacks  int32                  `protobuf:"varint,10,opt,name=unique_stacks,json=uniqueStacks,proto3" json:"unique_stacks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileRun) Reset() {
+	*x = ProfileRun{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileRun) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileRun) ProtoMessage() {}
+
+func (x *ProfileRun) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileRun.ProtoReflect.Descriptor instead.
+func (*ProfileRun) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ProfileRun) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProfileRun) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *ProfileRun) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProfileRun) GetProfileType() string {
+	if x != nil {
+		return x.ProfileType
+	}
+	return ""
+}
+
+func (x *ProfileRun) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ProfileRun) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *ProfileRun) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProfileRun) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProfileRun) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ProfileRun) GetUniqueStacks() int32 {
+	if x != nil {
+		return x.UniqueStacks
+	}
+	return 0
+}
+
+// CreateProfileScheduleRequest defines a recurring profiling job.
+type CreateProfileScheduleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName     string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	PodName         string                 `protobuf:"bytes,2,opt,name=pod_name,json=podName,proto3" json:"pod_name,omitempty"`
+	ProfileType     string                 `protobuf:"bytes,3,opt,name=profile_type,json=profileType,proto3" json:"profile_type,omitempty"`              // "cpu" (default) or "memory".
+	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Default: 30s, max: 300s.
+	Interval        *durationpb.Duration   `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`                                       // Must be longer than the duration.
+	FrequencyHz     int32                  `protobuf:"varint,6,opt,name=frequency_hz,json=frequencyHz,proto3" json:"frequency_hz,omitempty"`             // CPU only. Default: 99Hz, max: 1000Hz.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateProfileScheduleRequest) Reset() {
+	*x = CreateProfileScheduleRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProfileScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProfileScheduleRequest) ProtoMessage() {}
+
+func (x *CreateProfileScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProfileScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CreateProfileScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CreateProfileScheduleRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *CreateProfileScheduleRequest) GetPodName() string {
+	if x != nil {
+		return x.PodName
+	}
+	return ""
+}
+
+func (x *CreateProfileScheduleRequest) GetProfileType() string {
+	if x != nil {
+		return x.ProfileType
+	}
+	return ""
+}
+
+func (x *CreateProfileScheduleRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *CreateProfileScheduleRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *CreateProfileScheduleRequest) GetFrequencyHz() int32 {
+	if x != nil {
+		return x.FrequencyHz
+	}
+	return 0
+}
+
+// CreateProfileScheduleResponse returns the created schedule.
+type CreateProfileScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *ProfileSchedule       `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProfileScheduleResponse) Reset() {
+	*x = CreateProfileScheduleResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProfileScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProfileScheduleResponse) ProtoMessage() {}
+
+func (x *CreateProfileScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProfileScheduleResponse.ProtoReflect.Descriptor instead.
+func (*CreateProfileScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CreateProfileScheduleResponse) GetSchedule() *ProfileSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+// ListProfileSchedulesRequest lists recurring profiling jobs.
+type ListProfileSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"` // Optional service filter.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProfileSchedulesRequest) Reset() {
+	*x = ListProfileSchedulesRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProfileSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfileSchedulesRequest) ProtoMessage() {}
+
+func (x *ListProfileSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfileSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListProfileSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListProfileSchedulesRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+// ListProfileSchedulesResponse returns recurring profiling jobs.
+type ListProfileSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*ProfileSchedule     `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProfileSchedulesResponse) Reset() {
+	*x = ListProfileSchedulesResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProfileSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfileSchedulesResponse) ProtoMessage() {}
+
+func (x *ListProfileSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfileSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListProfileSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListProfileSchedulesResponse) GetSchedules() []*ProfileSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+// DeleteProfileScheduleRequest identifies a schedule to delete.
+type DeleteProfileScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProfileScheduleRequest) Reset() {
+	*x = DeleteProfileScheduleRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProfileScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProfileScheduleRequest) ProtoMessage() {}
+
+func (x *DeleteProfileScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProfileScheduleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProfileScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *DeleteProfileScheduleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// DeleteProfileScheduleResponse is empty.
+type DeleteProfileScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProfileScheduleResponse) Reset() {
+	*x = DeleteProfileScheduleResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProfileScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProfileScheduleResponse) ProtoMessage() {}
+
+func (x *DeleteProfileScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProfileScheduleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProfileScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{64}
+}
+
+// ListProfileRunsRequest lists results of scheduled profiling jobs.
+type ListProfileRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduleId    string                 `protobuf:"bytes,1,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`    // Optional schedule filter.
+	ServiceName   string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"` // Optional service filter.
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                               // Default: 50.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProfileRunsRequest) Reset() {
+	*x = ListProfileRunsRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProfileRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfileRunsRequest) ProtoMessage() {}
+
+func (x *ListProfileRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfileRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListProfileRunsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ListProfileRunsRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *ListProfileRunsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ListProfileRunsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListProfileRunsResponse returns scheduled profiling results, newest first.
+type ListProfileRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*ProfileRun          `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProfileRunsResponse) Reset() {
+	*x = ListProfileRunsResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProfileRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProfileRunsResponse) ProtoMessage() {}
+
+func (x *ListProfileRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProfileRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListProfileRunsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ListProfileRunsResponse) GetRuns() []*ProfileRun {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+// GetProfileRunRequest identifies a profiling result.
+type GetProfileRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileRunRequest) Reset() {
+	*x = GetProfileRunRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileRunRequest) ProtoMessage() {}
+
+func (x *GetProfileRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileRunRequest.ProtoReflect.Descriptor instead.
+func (*GetProfileRunRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetProfileRunRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// GetProfileRunResponse returns a profiling result and its stacks.
+type GetProfileRunResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Run   *ProfileRun            `protobuf:"bytes,1,opt,name=run,proto3" json:"run,omitempty"`
+	// Stacks in folded format ("outer;inner value" per line), ready for
+	// flamegraph.pl. Values are sample counts for CPU and bytes for memory.
+	Folded        string `protobuf:"bytes,2,opt,name=folded,proto3" json:"folded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfileRunResponse) Reset() {
+	*x = GetProfileRunResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfileRunResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfileRunResponse) ProtoMessage() {}
+
+func (x *GetProfileRunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfileRunResponse.ProtoReflect.Descriptor instead.
+func (*GetProfileRunResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetProfileRunResponse) GetRun() *ProfileRun {
+	if x != nil {
+		return x.Run
+	}
+	return nil
+}
+
+func (x *GetProfileRunResponse) GetFolded() string {
+	if x != nil {
+		return x.Folded
+	}
+	return ""
+}
+
+// CaptureHttpRequest starts an HTTP payload capture session.
+type CaptureHttpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName   string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Route         string                 `protobuf:"bytes,2,opt,name=route,proto3" json:"route,omitempty"`                               // Path pattern; "{name}" matches one segment, trailing "*" any suffix.
+	SampleRate    float64                `protobuf:"fixed64,3,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"` // Fraction of matching requests to capture, (0, 1]. 0 = all.
+	CaptureBodies bool                   `protobuf:"varint,4,opt,name=capture_bodies,json=captureBodies,proto3" json:"capture_bodies,omitempty"`
+	MaxBodyBytes  uint32                 `protobuf:"varint,5,opt,name=max_body_bytes,json=maxBodyBytes,proto3" json:"max_body_bytes,omitempty"` // 0 = 4096.
+	Duration      *durationpb.Duration   `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`                                // Default: 60s, Max: 600s
+	AgentId       string                 `protobuf:"bytes,7,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                   // Manual override
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,8,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CaptureHttpRequest) Reset() {
+	*x = CaptureHttpRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHttpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHttpRequest) ProtoMessage() {}
+
+func (x *CaptureHttpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHttpRequest.ProtoReflect.Descriptor instead.
+func (*CaptureHttpRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CaptureHttpRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *CaptureHttpRequest) GetRoute() string {
+	if x != nil {
+		return x.Route
+	}
+	return ""
+}
+
+func (x *CaptureHttpRequest) GetSampleRate() float64 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *CaptureHttpRequest) GetCaptureBodies() bool {
+	if x != nil {
+		return x.CaptureBodies
+	}
+	return false
+}
+
+func (x *CaptureHttpRequest) GetMaxBodyBytes() uint32 {
+	if x != nil {
+		return x.MaxBodyBytes
+	}
+	return 0
+}
+
+func (x *CaptureHttpRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *CaptureHttpRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CaptureHttpRequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
+// CaptureHttpResponse confirms the capture session.
+type CaptureHttpResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Success   bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Error     string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Classification of the failure, when known.
+	ErrorInfo     *v11.ErrorInfo `protobuf:"bytes,5,opt,name=error_info,json=errorInfo,proto3" json:"error_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureHttpResponse) Reset() {
+	*x = CaptureHttpResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureHttpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureHttpResponse) ProtoMessage() {}
+
+func (x *CaptureHttpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureHttpResponse.ProtoReflect.Descriptor instead.
+func (*CaptureHttpResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CaptureHttpResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CaptureHttpResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CaptureHttpResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CaptureHttpResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CaptureHttpResponse) GetErrorInfo() *v11.ErrorInfo {
+	if x != nil {
+		return x.ErrorInfo
+	}
+	return nil
+}
+
+// CaptureTlsRequest starts a TLS plaintext capture session.
+type CaptureTlsRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName  string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Library      string                 `protobuf:"bytes,2,opt,name=library,proto3" json:"library,omitempty"`                                  // "go", "openssl" or "" to detect.
+	Match        string                 `protobuf:"bytes,3,opt,name=match,proto3" json:"match,omitempty"`                                      // Capture connections whose plaintext contains it. Empty = all.
+	MaxDataBytes uint32                 `protobuf:"varint,4,opt,name=max_data_bytes,json=maxDataBytes,proto3" json:"max_data_bytes,omitempty"` // 0 = 4096, max 16384.
+	Duration     *durationpb.Duration   `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`                                // Default: 60s, Max: 600s
+	AgentId      string                 `protobuf:"bytes,6,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                   // Manual override
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,7,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CaptureTlsRequest) Reset() {
+	*x = CaptureTlsRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureTlsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureTlsRequest) ProtoMessage() {}
+
+func (x *CaptureTlsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureTlsRequest.ProtoReflect.Descriptor instead.
+func (*CaptureTlsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CaptureTlsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *CaptureTlsRequest) GetLibrary() string {
+	if x != nil {
+		return x.Library
+	}
+	return ""
+}
+
+func (x *CaptureTlsRequest) GetMatch() string {
+	if x != nil {
+		return x.Match
+	}
+	return ""
+}
+
+func (x *CaptureTlsRequest) GetMaxDataBytes() uint32 {
+	if x != nil {
+		return x.MaxDataBytes
+	}
+	return 0
+}
+
+func (x *CaptureTlsRequest) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *CaptureTlsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CaptureTlsRequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
+// CaptureTlsResponse confirms the capture session.
+type CaptureTlsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Success   bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Error     string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Classification of the failure, when known.
+	ErrorInfo     *v11.ErrorInfo `protobuf:"bytes,5,opt,name=error_info,json=errorInfo,proto3" json:"error_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureTlsResponse) Reset() {
+	*x = CaptureTlsResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureTlsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureTlsResponse) ProtoMessage() {}
+
+func (x *CaptureTlsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureTlsResponse.ProtoReflect.Descriptor instead.
+func (*CaptureTlsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *CaptureTlsResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *CaptureTlsResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *CaptureTlsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CaptureTlsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CaptureTlsResponse) GetErrorInfo() *v11.ErrorInfo {
+	if x != nil {
+		return x.ErrorInfo
+	}
+	return nil
+}
+
+// TraceRuntimeRequest traces the Go runtime of a service.
+type TraceRuntimeRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName     string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Tracing duration (default: 30s, max: 300s).
+	AgentId         string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                          // Optional: target agent, found from the service otherwise.
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,4,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TraceRuntimeRequest) Reset() {
+	*x = TraceRuntimeRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceRuntimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceRuntimeRequest) ProtoMessage() {}
+
+func (x *TraceRuntimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceRuntimeRequest.ProtoReflect.Descriptor instead.
+func (*TraceRuntimeRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *TraceRuntimeRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *TraceRuntimeRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *TraceRuntimeRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TraceRuntimeRequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
+// RequestLatencyCorrelation relates the latency of the server spans of a
+// service to the runtime pauses and GC cycles of the same window.
+type RequestLatencyCorrelation struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Requests uint64                 `protobuf:"varint,1,opt,name=requests,proto3" json:"requests,omitempty"` // Server spans started in the window
+	P50Us    uint64                 `protobuf:"varint,2,opt,name=p50_us,json=p50Us,proto3" json:"p50_us,omitempty"`
+	P99Us    uint64                 `protobuf:"varint,3,opt,name=p99_us,json=p99Us,proto3" json:"p99_us,omitempty"`
+	// Requests slower than p99_us, and those of them that overlapped a
+	// stop-the-world pause or a GC cycle.
+	SlowRequests    uint64 `protobuf:"varint,4,opt,name=slow_requests,json=slowRequests,proto3" json:"slow_requests,omitempty"`
+	SlowDuringPause uint64 `protobuf:"varint,5,opt,name=slow_during_pause,json=slowDuringPause,proto3" json:"slow_during_pause,omitempty"`
+	SlowDuringGc    uint64 `protobuf:"varint,6,opt,name=slow_during_gc,json=slowDuringGc,proto3" json:"slow_during_gc,omitempty"`
+	// All requests that overlapped a GC cycle, and the p99 latency of the
+	// requests that did and did not.
+	RequestsDuringGc uint64 `protobuf:"varint,7,opt,name=requests_during_gc,json=requestsDuringGc,proto3" json:"requests_during_gc,omitempty"`
+	P99DuringGcUs    uint64 `protobuf:"varint,8,opt,name=p99_during_gc_us,json=p99DuringGcUs,proto3" json:"p99_during_gc_us,omitempty"`
+	P99OutsideGcUs   uint64 `protobuf:"varint,9,opt,name=p99_outside_gc_us,json=p99OutsideGcUs,proto3" json:"p99_outside_gc_us,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RequestLatencyCorrelation) Reset() {
+	*x = RequestLatencyCorrelation{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestLatencyCorrelation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestLatencyCorrelation) ProtoMessage() {}
+
+func (x *RequestLatencyCorrelation) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestLatencyCorrelation.ProtoReflect.Descriptor instead.
+func (*RequestLatencyCorrelation) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RequestLatencyCorrelation) GetRequests() uint64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *RequestLatencyCorrelation) GetP50Us() uint64 {
+	if x != nil {
+		return x.P50Us
+	}
+	return 0
+}
+
+func (x *RequestLatencyCorrelation) GetP99Us() uint64 {
+	if x != nil {
+		return x.P99Us
+	}
+	return 0
+}
+
+func (x *RequestLatencyCorrelation) GetSlowRequests() uint64 {
+	if x != nil {
+		return x.SlowRequests
+	}
+	return 0
+}
+
+func (x *RequestLatencyCorrelation) GetSlowDuringPause() uint64 {
+	if x != nil {
+		return x.SlowDuringPause
+	}
+	return 0
+}
+
+func (x *RequestLatencyCorrelation) GetSlowDuringGc() uint64 {
+	if x != nil {
+		return x.SlowDuringGc
+	}
+	return 0
+}
+
+func (x *RequestLatencyCorrelation) GetRequestsDuringGc() uint64 {
+	if x != nil {
+		return x.RequestsDuringGc
+	}
+	return 0
+}
+
+func (x *RequestLatencyCorrelation) GetP99DuringGcUs() uint64 {
+	if x != nil {
+		return x.P99DuringGcUs
+	}
+	return 0
+}
+
+func (x *RequestLatencyCorrelation) GetP99OutsideGcUs() uint64 {
+	if x != nil {
+		return x.P99OutsideGcUs
+	}
+	return 0
+}
+
+// TraceRuntimeResponse is the runtime health report of a service.
+type TraceRuntimeResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Success      bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error        string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ServiceName  string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	AgentId      string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	GoVersion    string                 `protobuf:"bytes,5,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	StartTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Pauses       []*v1.RuntimePause     `protobuf:"bytes,8,rep,name=pauses,proto3" json:"pauses,omitempty"`
+	GcCycles     []*v1.GcCycle          `protobuf:"bytes,9,rep,name=gc_cycles,json=gcCycles,proto3" json:"gc_cycles,omitempty"`
+	SchedLatency []*v1.LatencyBucket    `protobuf:"bytes,10,rep,name=sched_latency,json=schedLatency,proto3" json:"sched_latency,omitempty"`
+	// Absent if no request spans of the service were stored for the window.
+	Requests *RequestLatencyCorrelation `protobuf:"bytes,11,opt,name=requests,proto3" json:"requests,omitempty"`
+	// Classification of the failure, when known.
+	ErrorInfo     *v11.ErrorInfo `protobuf:"bytes,12,opt,name=error_info,json=errorInfo,proto3" json:"error_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceRuntimeResponse) Reset() {
+	*x = TraceRuntimeResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceRuntimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceRuntimeResponse) ProtoMessage() {}
+
+func (x *TraceRuntimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceRuntimeResponse.ProtoReflect.Descriptor instead.
+func (*TraceRuntimeResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *TraceRuntimeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TraceRuntimeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TraceRuntimeResponse) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *TraceRuntimeResponse) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TraceRuntimeResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+func (x *TraceRuntimeResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TraceRuntimeResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TraceRuntimeResponse) GetPauses() []*v1.RuntimePause {
+	if x != nil {
+		return x.Pauses
+	}
+	return nil
+}
+
+func (x *TraceRuntimeResponse) GetGcCycles() []*v1.GcCycle {
+	if x != nil {
+		return x.GcCycles
+	}
+	return nil
+}
+
+func (x *TraceRuntimeResponse) GetSchedLatency() []*v1.LatencyBucket {
+	if x != nil {
+		return x.SchedLatency
+	}
+	return nil
+}
+
+func (x *TraceRuntimeResponse) GetRequests() *RequestLatencyCorrelation {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *TraceRuntimeResponse) GetErrorInfo() *v11.ErrorInfo {
+	if x != nil {
+		return x.ErrorInfo
+	}
+	return nil
+}
+
+// TraceSyscallsRequest traces the syscalls of a service.
+type TraceSyscallsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName     string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Tracing duration (default: 30s, max: 300s).
+	Syscalls        []string               `protobuf:"bytes,3,rep,name=syscalls,proto3" json:"syscalls,omitempty"`                                       // Syscall names, e.g. "openat"; all if empty.
+	AgentId         string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                          // Optional: target agent, found from the service otherwise.
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,5,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TraceSyscallsRequest) Reset() {
+	*x = TraceSyscallsRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceSyscallsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceSyscallsRequest) ProtoMessage() {}
+
+func (x *TraceSyscallsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceSyscallsRequest.ProtoReflect.Descriptor instead.
+func (*TraceSyscallsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *TraceSyscallsRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *TraceSyscallsRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *TraceSyscallsRequest) GetSyscalls() []string {
+	if x != nil {
+		return x.Syscalls
+	}
+	return nil
+}
+
+func (x *TraceSyscallsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TraceSyscallsRequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
+// TraceSyscallsResponse is the syscall report of a service.
+type TraceSyscallsResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Success     bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error       string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ServiceName string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	AgentId     string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Target      string                 `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"` // Traced processes, e.g. "cgroup /system.slice/api.service"
+	StartTime   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Sorted by total time, longest first.
+	Syscalls []*v1.SyscallStats `protobuf:"bytes,8,rep,name=syscalls,proto3" json:"syscalls,omitempty"`
+	// Classification of the failure, when known.
+	ErrorInfo     *v11.ErrorInfo `protobuf:"bytes,9,opt,name=error_info,json=errorInfo,proto3" json:"error_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceSyscallsResponse) Reset() {
+	*x = TraceSyscallsResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceSyscallsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceSyscallsResponse) ProtoMessage() {}
+
+func (x *TraceSyscallsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceSyscallsResponse.ProtoReflect.Descriptor instead.
+func (*TraceSyscallsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *TraceSyscallsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TraceSyscallsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TraceSyscallsResponse) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *TraceSyscallsResponse) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *TraceSyscallsResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *TraceSyscallsResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TraceSyscallsResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TraceSyscallsResponse) GetSyscalls() []*v1.SyscallStats {
+	if x != nil {
+		return x.Syscalls
+	}
+	return nil
+}
+
+func (x *TraceSyscallsResponse) GetErrorInfo() *v11.ErrorInfo {
+	if x != nil {
+		return x.ErrorInfo
+	}
+	return nil
+}
+
+// ProfileIORequest profiles the file I/O of a service.
+type ProfileIORequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName     string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Profiling duration (default: 30s, max: 300s).
+	AgentId         string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                          // Optional: target agent, found from the service otherwise.
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,4,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProfileIORequest) Reset() {
+	*x = ProfileIORequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileIORequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileIORequest) ProtoMessage() {}
+
+func (x *ProfileIORequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileIORequest.ProtoReflect.Descriptor instead.
+func (*ProfileIORequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ProfileIORequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProfileIORequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *ProfileIORequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ProfileIORequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
+// ProfileIOResponse is the file I/O profile of a service.
+type ProfileIOResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Success     bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error       string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ServiceName string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	AgentId     string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Target      string                 `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"` // Traced processes, e.g. "cgroup /system.slice/api.service"
+	StartTime   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Sorted by I/O time, longest first.
+	Files        []*v1.FileIO        `protobuf:"bytes,8,rep,name=files,proto3" json:"files,omitempty"`
+	Devices      []*v1.DeviceIO      `protobuf:"bytes,9,rep,name=devices,proto3" json:"devices,omitempty"`
+	ReadLatency  []*v1.LatencyBucket `protobuf:"bytes,10,rep,name=read_latency,json=readLatency,proto3" json:"read_latency,omitempty"`
+	WriteLatency []*v1.LatencyBucket `protobuf:"bytes,11,rep,name=write_latency,json=writeLatency,proto3" json:"write_latency,omitempty"`
+	// Classification of the failure, when known.
+	ErrorInfo     *v11.ErrorInfo `protobuf:"bytes,12,opt,name=error_info,json=errorInfo,proto3" json:"error_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileIOResponse) Reset() {
+	*x = ProfileIOResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileIOResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileIOResponse) ProtoMessage() {}
+
+func (x *ProfileIOResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileIOResponse.ProtoReflect.Descriptor instead.
+func (*ProfileIOResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ProfileIOResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ProfileIOResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProfileIOResponse) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProfileIOResponse) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ProfileIOResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ProfileIOResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ProfileIOResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ProfileIOResponse) GetFiles() []*v1.FileIO {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ProfileIOResponse) GetDevices() []*v1.DeviceIO {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *ProfileIOResponse) GetReadLatency() []*v1.LatencyBucket {
+	if x != nil {
+		return x.ReadLatency
+	}
+	return nil
+}
+
+func (x *ProfileIOResponse) GetWriteLatency() []*v1.LatencyBucket {
+	if x != nil {
+		return x.WriteLatency
+	}
+	return nil
+}
+
+func (x *ProfileIOResponse) GetErrorInfo() *v11.ErrorInfo {
+	if x != nil {
+		return x.ErrorInfo
+	}
+	return nil
+}
+
+// ProfileLocksRequest profiles the lock contention of a service.
+type ProfileLocksRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName     string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Profiling duration (default: 30s, max: 300s).
+	AgentId         string                 `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`                          // Optional: target agent, found from the service otherwise.
+	// Start even if the service is frozen. Requires admin permission.
+	OverrideFreeze bool `protobuf:"varint,4,opt,name=override_freeze,json=overrideFreeze,proto3" json:"override_freeze,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ProfileLocksRequest) Reset() {
+	*x = ProfileLocksRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileLocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileLocksRequest) ProtoMessage() {}
+
+func (x *ProfileLocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileLocksRequest.ProtoReflect.Descriptor instead.
+func (*ProfileLocksRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ProfileLocksRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProfileLocksRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *ProfileLocksRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ProfileLocksRequest) GetOverrideFreeze() bool {
+	if x != nil {
+		return x.OverrideFreeze
+	}
+	return false
+}
+
+// ProfileLocksResponse is the lock contention profile of a service.
+type ProfileLocksResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Success     bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error       string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ServiceName string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	AgentId     string                 `protobuf:"bytes,4,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Target      string                 `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"` // Traced processes, e.g. "cgroup /system.slice/api.service"
+	StartTime   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Sorted by wait time, longest first.
+	Stacks      []*v1.LockWaitStack `protobuf:"bytes,8,rep,name=stacks,proto3" json:"stacks,omitempty"`
+	TotalWaits  uint64              `protobuf:"varint,9,opt,name=total_waits,json=totalWaits,proto3" json:"total_waits,omitempty"`
+	TotalWaitNs uint64              `protobuf:"varint,10,opt,name=total_wait_ns,json=totalWaitNs,proto3" json:"total_wait_ns,omitempty"`
+	// Classification of the failure, when known.
+	ErrorInfo     *v11.ErrorInfo `protobuf:"bytes,11,opt,name=error_info,json=errorInfo,proto3" json:"error_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProfileLocksResponse) Reset() {
+	*x = ProfileLocksResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProfileLocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileLocksResponse) ProtoMessage() {}
+
+func (x *ProfileLocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileLocksResponse.ProtoReflect.Descriptor instead.
+func (*ProfileLocksResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ProfileLocksResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ProfileLocksResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProfileLocksResponse) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ProfileLocksResponse) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ProfileLocksResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ProfileLocksResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ProfileLocksResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ProfileLocksResponse) GetStacks() []*v1.LockWaitStack {
+	if x != nil {
+		return x.Stacks
+	}
+	return nil
+}
+
+func (x *ProfileLocksResponse) GetTotalWaits() uint64 {
+	if x != nil {
+		return x.TotalWaits
+	}
+	return 0
+}
+
+func (x *ProfileLocksResponse) GetTotalWaitNs() uint64 {
+	if x != nil {
+		return x.TotalWaitNs
+	}
+	return 0
+}
+
+func (x *ProfileLocksResponse) GetErrorInfo() *v11.ErrorInfo {
+	if x != nil {
+		return x.ErrorInfo
+	}
+	return nil
+}
+
+// ShellRecordingEvent is terminal input, output or a resize of a recorded
+// session, as in asciicast v2.
+type ShellRecordingEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Microseconds since the session started.
+	OffsetUs int64 `protobuf:"varint,1,opt,name=offset_us,json=offsetUs,proto3" json:"offset_us,omitempty"`
+	// "i" for input, "o" for output, "r" for a resize.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Bytes read or written; "COLSxROWS" for a resize.
+	Data          []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShellRecordingEvent) Reset() {
+	*x = ShellRecordingEvent{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellRecordingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellRecordingEvent) ProtoMessage() {}
+
+func (x *ShellRecordingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellRecordingEvent.ProtoReflect.Descriptor instead.
+func (*ShellRecordingEvent) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *ShellRecordingEvent) GetOffsetUs() int64 {
+	if x != nil {
+		return x.OffsetUs
+	}
+	return 0
+}
+
+func (x *ShellRecordingEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ShellRecordingEvent) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ShellRecording is the input and output of a shell or exec session run on
+// an agent, recorded for incident review.
+type ShellRecording struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	AgentId   string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// "shell" for interactive sessions, "exec" or "container_exec" for
+	// one-off commands.
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// User running the session, as reported by the client.
+	UserId string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Command of an exec session, or the shell of an interactive session.
+	Command []string `protobuf:"bytes,5,rep,name=command,proto3" json:"command,omitempty"`
+	// Container of a container_exec session.
+	ContainerName string                 `protobuf:"bytes,6,opt,name=container_name,json=containerName,proto3" json:"container_name,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	EndedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	ExitCode      int32                  `protobuf:"varint,9,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// Initial terminal size.
+	Cols uint32 `protobuf:"varint,10,opt,name=cols,proto3" json:"cols,omitempty"`
+	Rows uint32 `protobuf:"varint,11,opt,name=rows,proto3" json:"rows,omitempty"`
+	// Total bytes of input and output recorded.
+	SizeBytes uint64 `protobuf:"varint,12,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Events past the agent's size limit were not recorded.
+	Truncated bool `protobuf:"varint,13,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Events in order. Not set by ListShellRecordings.
+	Events        []*ShellRecordingEvent `protobuf:"bytes,14,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShellRecording) Reset() {
+	*x = ShellRecording{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShellRecording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShellRecording) ProtoMessage() {}
+
+func (x *ShellRecording) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShellRecording.ProtoReflect.Descriptor instead.
+func (*ShellRecording) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *ShellRecording) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ShellRecording) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ShellRecording) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ShellRecording) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShellRecording) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *ShellRecording) GetContainerName() string {
+	if x != nil {
+		return x.ContainerName
+	}
+	return ""
+}
+
+func (x *ShellRecording) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *ShellRecording) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *ShellRecording) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ShellRecording) GetCols() uint32 {
+	if x != nil {
+		return x.Cols
+	}
+	return 0
+}
+
+func (x *ShellRecording) GetRows() uint32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *ShellRecording) GetSizeBytes() uint64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ShellRecording) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *ShellRecording) GetEvents() []*ShellRecordingEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type UploadShellRecordingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recording     *ShellRecording        `protobuf:"bytes,1,opt,name=recording,proto3" json:"recording,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadShellRecordingRequest) Reset() {
+	*x = UploadShellRecordingRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ColonyRemoveCorrelationRequest) String() string {
+func (x *UploadShellRecordingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ColonyRemoveCorrelationRequest) ProtoMessage() {}
+func (*UploadShellRecordingRequest) ProtoMessage() {}
 
-func (x *ColonyRemoveCorrelationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[42]
+func (x *UploadShellRecordingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3083,47 +6170,144 @@ func (x *ColonyRemoveCorrelationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ColonyRemoveCorrelationRequest.ProtoReflect.Descriptor instead.
-func (*ColonyRemoveCorrelationRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use UploadShellRecordingRequest.ProtoReflect.Descriptor instead.
+func (*UploadShellRecordingRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *ColonyRemoveCorrelationRequest) GetCorrelationId() string {
+func (x *UploadShellRecordingRequest) GetRecording() *ShellRecording {
 	if x != nil {
-		return x.CorrelationId
+		return x.Recording
+	}
+	return nil
+}
+
+type UploadShellRecordingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadShellRecordingResponse) Reset() {
+	*x = UploadShellRecordingResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadShellRecordingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadShellRecordingResponse) ProtoMessage() {}
+
+func (x *UploadShellRecordingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadShellRecordingResponse.ProtoReflect.Descriptor instead.
+func (*UploadShellRecordingResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{85}
+}
+
+type ListShellRecordingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"` // Optional filter.
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`    // Optional filter.
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`                    // Optional: sessions started at or after.
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`                   // Default: 50.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShellRecordingsRequest) Reset() {
+	*x = ListShellRecordingsRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShellRecordingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShellRecordingsRequest) ProtoMessage() {}
+
+func (x *ListShellRecordingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShellRecordingsRequest.ProtoReflect.Descriptor instead.
+func (*ListShellRecordingsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *ListShellRecordingsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
 	}
 	return ""
 }
 
-func (x *ColonyRemoveCorrelationRequest) GetServiceName() string {
+func (x *ListShellRecordingsRequest) GetUserId() string {
 	if x != nil {
-		return x.ServiceName
+		return x.UserId
 	}
 	return ""
 }
 
-// ColonyRemoveCorrelationResponse confirms removal (RFD 091).
-type ColonyRemoveCorrelationResponse struct {
+func (x *ListShellRecordingsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListShellRecordingsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListShellRecordingsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recordings    []*ShellRecording      `protobuf:"bytes,1,rep,name=recordings,proto3" json:"recordings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ColonyRemoveCorrelationResponse) Reset() {
-	*x = ColonyRemoveCorrelationResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[43]
+func (x *ListShellRecordingsResponse) Reset() {
+	*x = ListShellRecordingsResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ColonyRemoveCorrelationResponse) String() string {
+func (x *ListShellRecordingsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ColonyRemoveCorrelationResponse) ProtoMessage() {}
+func (*ListShellRecordingsResponse) ProtoMessage() {}
 
-func (x *ColonyRemoveCorrelationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[43]
+func (x *ListShellRecordingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3134,35 +6318,40 @@ func (x *ColonyRemoveCorrelationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ColonyRemoveCorrelationResponse.ProtoReflect.Descriptor instead.
-func (*ColonyRemoveCorrelationResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use ListShellRecordingsResponse.ProtoReflect.Descriptor instead.
+func (*ListShellRecordingsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{87}
 }
 
-// ColonyListCorrelationsRequest lists active correlation descriptors (RFD 091).
-type ColonyListCorrelationsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// service_name is optional — filters results to a single service.
-	ServiceName   string `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+func (x *ListShellRecordingsResponse) GetRecordings() []*ShellRecording {
+	if x != nil {
+		return x.Recordings
+	}
+	return nil
+}
+
+type GetShellRecordingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ColonyListCorrelationsRequest) Reset() {
-	*x = ColonyListCorrelationsRequest{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[44]
+func (x *GetShellRecordingRequest) Reset() {
+	*x = GetShellRecordingRequest{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ColonyListCorrelationsRequest) String() string {
+func (x *GetShellRecordingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ColonyListCorrelationsRequest) ProtoMessage() {}
+func (*GetShellRecordingRequest) ProtoMessage() {}
 
-func (x *ColonyListCorrelationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[44]
+func (x *GetShellRecordingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3173,41 +6362,40 @@ func (x *ColonyListCorrelationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ColonyListCorrelationsRequest.ProtoReflect.Descriptor instead.
-func (*ColonyListCorrelationsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{44}
+// Deprecated: Use GetShellRecordingRequest.ProtoReflect.Descriptor instead.
+func (*GetShellRecordingRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *ColonyListCorrelationsRequest) GetServiceName() string {
+func (x *GetShellRecordingRequest) GetSessionId() string {
 	if x != nil {
-		return x.ServiceName
+		return x.SessionId
 	}
 	return ""
 }
 
-// ColonyListCorrelationsResponse returns active correlation descriptors (RFD 091).
-type ColonyListCorrelationsResponse struct {
-	state         protoimpl.MessageState      `protogen:"open.v1"`
-	Descriptors   []*v1.CorrelationDescriptor `protobuf:"bytes,1,rep,name=descriptors,proto3" json:"descriptors,omitempty"`
+type GetShellRecordingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recording     *ShellRecording        `protobuf:"bytes,1,opt,name=recording,proto3" json:"recording,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ColonyListCorrelationsResponse) Reset() {
-	*x = ColonyListCorrelationsResponse{}
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[45]
+func (x *GetShellRecordingResponse) Reset() {
+	*x = GetShellRecordingResponse{}
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ColonyListCorrelationsResponse) String() string {
+func (x *GetShellRecordingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ColonyListCorrelationsResponse) ProtoMessage() {}
+func (*GetShellRecordingResponse) ProtoMessage() {}
 
-func (x *ColonyListCorrelationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_debug_proto_msgTypes[45]
+func (x *GetShellRecordingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_debug_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3218,14 +6406,14 @@ func (x *ColonyListCorrelationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ColonyListCorrelationsResponse.ProtoReflect.Descriptor instead.
-func (*ColonyListCorrelationsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{45}
+// Deprecated: Use GetShellRecordingResponse.ProtoReflect.Descriptor instead.
+func (*GetShellRecordingResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_debug_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *ColonyListCorrelationsResponse) GetDescriptors() []*v1.CorrelationDescriptor {
+func (x *GetShellRecordingResponse) GetRecording() *ShellRecording {
 	if x != nil {
-		return x.Descriptors
+		return x.Recording
 	}
 	return nil
 }
@@ -3234,7 +6422,7 @@ var File_coral_colony_v1_debug_proto protoreflect.FileDescriptor
 
 const file_coral_colony_v1_debug_proto_rawDesc = "" +
 	"\n" +
-	"\x1bcoral/colony/v1/debug.proto\x12\x0fcoral.colony.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1acoral/agent/v1/debug.proto\x1a coral/agent/v1/correlation.proto\"\xb6\x02\n" +
+	"\x1bcoral/colony/v1/debug.proto\x12\x0fcoral.colony.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1acoral/agent/v1/debug.proto\x1a coral/agent/v1/correlation.proto\x1a\x1ccoral/errors/v1/errors.proto\"\xdf\x02\n" +
 	"\x13AttachUprobeRequest\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12#\n" +
 	"\rfunction_name\x18\x02 \x01(\tR\ffunctionName\x125\n" +
@@ -3242,20 +6430,23 @@ const file_coral_colony_v1_debug_proto_rawDesc = "" +
 	"\x06config\x18\x04 \x01(\v2\x1c.coral.agent.v1.UprobeConfigR\x06config\x12\x19\n" +
 	"\bagent_id\x18\x05 \x01(\tR\aagentId\x12\x19\n" +
 	"\bsdk_addr\x18\x06 \x01(\tR\asdkAddr\x124\n" +
-	"\x06filter\x18\a \x01(\v2\x1c.coral.agent.v1.UprobeFilterR\x06filter\"\x8a\x01\n" +
+	"\x06filter\x18\a \x01(\v2\x1c.coral.agent.v1.UprobeFilterR\x06filter\x12'\n" +
+	"\x0foverride_freeze\x18\b \x01(\bR\x0eoverrideFreeze\"\x8a\x01\n" +
 	"\x18UpdateProbeFilterRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x19\n" +
 	"\bagent_id\x18\x02 \x01(\tR\aagentId\x124\n" +
 	"\x06filter\x18\x03 \x01(\v2\x1c.coral.agent.v1.UprobeFilterR\x06filter\"\x1b\n" +
-	"\x19UpdateProbeFilterResponse\"\xa0\x01\n" +
+	"\x19UpdateProbeFilterResponse\"\xdb\x01\n" +
 	"\x14AttachUprobeResponse\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x129\n" +
 	"\n" +
 	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
 	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x04 \x01(\tR\x05error\"4\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"error_info\x18\x05 \x01(\v2\x1a.coral.errors.v1.ErrorInfoR\terrorInfo\"4\n" +
 	"\x13DetachUprobeRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\"F\n" +
@@ -3272,7 +6463,16 @@ const file_coral_colony_v1_debug_proto_rawDesc = "" +
 	"max_events\x18\x04 \x01(\x05R\tmaxEvents\"k\n" +
 	"\x19QueryUprobeEventsResponse\x123\n" +
 	"\x06events\x18\x01 \x03(\v2\x1b.coral.agent.v1.UprobeEventR\x06events\x12\x19\n" +
-	"\bhas_more\x18\x02 \x01(\bR\ahasMore\"U\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\"\xb1\x01\n" +
+	"\x19IngestUprobeEventsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x123\n" +
+	"\x06events\x18\x03 \x03(\v2\x1b.coral.agent.v1.UprobeEventR\x06events\x12%\n" +
+	"\x0edropped_events\x18\x04 \x01(\x04R\rdroppedEvents\"u\n" +
+	"\x1aIngestUprobeEventsResponse\x12'\n" +
+	"\x0fevents_accepted\x18\x01 \x01(\x03R\x0eeventsAccepted\x12.\n" +
+	"\x13unknown_session_ids\x18\x02 \x03(\tR\x11unknownSessionIds\"U\n" +
 	"\x18ListDebugSessionsRequest\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\"V\n" +
@@ -3420,7 +6620,13 @@ const file_coral_colony_v1_debug_proto_rawDesc = "" +
 	"\x0erecommendation\x18\t \x01(\tR\x0erecommendation\x12\x1d\n" +
 	"\n" +
 	"next_steps\x18\n" +
-	" \x03(\tR\tnextSteps\"\xfa\x01\n" +
+	" \x03(\tR\tnextSteps\">\n" +
+	"\x1dCancelProfileFunctionsRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"v\n" +
+	"\x1eCancelProfileFunctionsResponse\x12+\n" +
+	"\x11sessions_detached\x18\x01 \x01(\x05R\x10sessionsDetached\x12'\n" +
+	"\x0fsessions_failed\x18\x02 \x01(\x05R\x0esessionsFailed\"\xfa\x01\n" +
 	"\x0eProfileSummary\x12-\n" +
 	"\x12functions_selected\x18\x01 \x01(\x05R\x11functionsSelected\x12)\n" +
 	"\x10functions_probed\x18\x02 \x01(\x05R\x0ffunctionsProbed\x12#\n" +
@@ -3444,12 +6650,13 @@ const file_coral_colony_v1_debug_proto_rawDesc = "" +
 	"\x10contribution_pct\x18\x03 \x01(\x05R\x0fcontributionPct\x12\x1a\n" +
 	"\bseverity\x18\x04 \x01(\tR\bseverity\x12\x16\n" +
 	"\x06impact\x18\x05 \x01(\tR\x06impact\x12&\n" +
-	"\x0erecommendation\x18\x06 \x01(\tR\x0erecommendation\"\x9f\x01\n" +
+	"\x0erecommendation\x18\x06 \x01(\tR\x0erecommendation\"\xc8\x01\n" +
 	"\x11ProfileCPURequest\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x19\n" +
 	"\bpod_name\x18\x02 \x01(\tR\apodName\x12)\n" +
 	"\x10duration_seconds\x18\x03 \x01(\x05R\x0fdurationSeconds\x12!\n" +
-	"\ffrequency_hz\x18\x04 \x01(\x05R\vfrequencyHz\"\xc3\x01\n" +
+	"\ffrequency_hz\x18\x04 \x01(\x05R\vfrequencyHz\x12'\n" +
+	"\x0foverride_freeze\x18\x05 \x01(\bR\x0eoverrideFreeze\"\xc3\x01\n" +
 	"\x12ProfileCPUResponse\x125\n" +
 	"\asamples\x18\x01 \x03(\v2\x1b.coral.agent.v1.StackSampleR\asamples\x12#\n" +
 	"\rtotal_samples\x18\x02 \x01(\x04R\ftotalSamples\x12!\n" +
@@ -3465,24 +6672,26 @@ const file_coral_colony_v1_debug_proto_rawDesc = "" +
 	"\asamples\x18\x01 \x03(\v2\x1b.coral.agent.v1.StackSampleR\asamples\x12#\n" +
 	"\rtotal_samples\x18\x02 \x01(\x04R\ftotalSamples\x12\x14\n" +
 	"\x05error\x18\x03 \x01(\tR\x05error\x12\x18\n" +
-	"\asuccess\x18\x04 \x01(\bR\asuccess\"\xab\x01\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\"\xd4\x01\n" +
 	"\x14ProfileMemoryRequest\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x19\n" +
 	"\bpod_name\x18\x02 \x01(\tR\apodName\x12)\n" +
 	"\x10duration_seconds\x18\x03 \x01(\x05R\x0fdurationSeconds\x12*\n" +
-	"\x11sample_rate_bytes\x18\x04 \x01(\x05R\x0fsampleRateBytes\"\xb9\x02\n" +
+	"\x11sample_rate_bytes\x18\x04 \x01(\x05R\x0fsampleRateBytes\x12'\n" +
+	"\x0foverride_freeze\x18\x05 \x01(\bR\x0eoverrideFreeze\"\xb9\x02\n" +
 	"\x15ProfileMemoryResponse\x12;\n" +
 	"\asamples\x18\x01 \x03(\v2!.coral.agent.v1.MemoryStackSampleR\asamples\x121\n" +
 	"\x05stats\x18\x02 \x01(\v2\x1b.coral.agent.v1.MemoryStatsR\x05stats\x12E\n" +
 	"\rtop_functions\x18\x03 \x03(\v2 .coral.agent.v1.TopAllocFunctionR\ftopFunctions\x129\n" +
 	"\ttop_types\x18\x04 \x03(\v2\x1c.coral.agent.v1.TopAllocTypeR\btopTypes\x12\x14\n" +
 	"\x05error\x18\x05 \x01(\tR\x05error\x12\x18\n" +
-	"\asuccess\x18\x06 \x01(\bR\asuccess\"\xba\x01\n" +
+	"\asuccess\x18\x06 \x01(\bR\asuccess\"\xd5\x01\n" +
 	"#QueryHistoricalMemoryProfileRequest\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x129\n" +
 	"\n" +
 	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
-	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\"\xe6\x02\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x19\n" +
+	"\bbuild_id\x18\x04 \x01(\tR\abuildId\"\xf2\x03\n" +
 	"$QueryHistoricalMemoryProfileResponse\x12;\n" +
 	"\asamples\x18\x01 \x03(\v2!.coral.agent.v1.MemoryStackSampleR\asamples\x12*\n" +
 	"\x11total_alloc_bytes\x18\x02 \x01(\x03R\x0ftotalAllocBytes\x12\x14\n" +
@@ -3490,12 +6699,29 @@ const file_coral_colony_v1_debug_proto_rawDesc = "" +
 	"\asuccess\x18\x04 \x01(\bR\asuccess\x12E\n" +
 	"\rtop_functions\x18\x05 \x03(\v2 .coral.agent.v1.TopAllocFunctionR\ftopFunctions\x129\n" +
 	"\ttop_types\x18\x06 \x03(\v2\x1c.coral.agent.v1.TopAllocTypeR\btopTypes\x12#\n" +
-	"\runique_stacks\x18\a \x01(\x05R\funiqueStacks\"\x8a\x01\n" +
+	"\runique_stacks\x18\a \x01(\x05R\funiqueStacks\x12;\n" +
+	"\x06growth\x18\b \x03(\v2#.coral.colony.v1.MemoryGrowthBucketR\x06growth\x12M\n" +
+	"\x0fleak_candidates\x18\t \x03(\v2$.coral.colony.v1.MemoryLeakCandidateR\x0eleakCandidates\"\x95\x01\n" +
+	"\x12MemoryGrowthBucket\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x12\x1f\n" +
+	"\valloc_bytes\x18\x02 \x01(\x03R\n" +
+	"allocBytes\x12#\n" +
+	"\ralloc_objects\x18\x03 \x01(\x03R\fallocObjects\"\x9d\x01\n" +
+	"\x13MemoryLeakCandidate\x12\x1f\n" +
+	"\vframe_names\x18\x01 \x03(\tR\n" +
+	"frameNames\x12\x1f\n" +
+	"\valloc_bytes\x18\x02 \x01(\x03R\n" +
+	"allocBytes\x12\x1d\n" +
+	"\n" +
+	"growth_pct\x18\x03 \x01(\x01R\tgrowthPct\x12%\n" +
+	"\x0eactive_buckets\x18\x04 \x01(\x05R\ractiveBuckets\"\xb3\x01\n" +
 	"\x1eColonyDeployCorrelationRequest\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12E\n" +
 	"\n" +
 	"descriptor\x18\x02 \x01(\v2%.coral.agent.v1.CorrelationDescriptorR\n" +
-	"descriptor\"\x93\x01\n" +
+	"descriptor\x12'\n" +
+	"\x0foverride_freeze\x18\x03 \x01(\bR\x0eoverrideFreeze\"\x93\x01\n" +
 	"\x1fColonyDeployCorrelationResponse\x12%\n" +
 	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12\x19\n" +
 	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x14\n" +
@@ -3508,25 +6734,285 @@ const file_coral_colony_v1_debug_proto_rawDesc = "" +
 	"\x1dColonyListCorrelationsRequest\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"i\n" +
 	"\x1eColonyListCorrelationsResponse\x12G\n" +
-	"\vdescriptors\x18\x01 \x03(\v2%.coral.agent.v1.CorrelationDescriptorR\vdescriptors2\xdc\r\n" +
+	"\vdescriptors\x18\x01 \x03(\v2%.coral.agent.v1.CorrelationDescriptorR\vdescriptors\"?\n" +
+	"\x1aColonyListCoreDumpsRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"Q\n" +
+	"\x1bColonyListCoreDumpsResponse\x122\n" +
+	"\x05dumps\x18\x01 \x03(\v2\x1c.coral.agent.v1.CoreDumpInfoR\x05dumps\"J\n" +
+	"\x1dColonyDownloadCoreDumpRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"g\n" +
+	"\x1dColonyDescribeFunctionRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12#\n" +
+	"\rfunction_name\x18\x02 \x01(\tR\ffunctionName\"|\n" +
+	"\x1eColonyDescribeFunctionResponse\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12?\n" +
+	"\bfunction\x18\x02 \x01(\v2#.coral.agent.v1.FunctionDescriptionR\bfunction\"\xdb\x03\n" +
+	"\x0fProfileSchedule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x19\n" +
+	"\bpod_name\x18\x03 \x01(\tR\apodName\x12!\n" +
+	"\fprofile_type\x18\x04 \x01(\tR\vprofileType\x12)\n" +
+	"\x10duration_seconds\x18\x05 \x01(\x05R\x0fdurationSeconds\x125\n" +
+	"\binterval\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12!\n" +
+	"\ffrequency_hz\x18\a \x01(\x05R\vfrequencyHz\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12:\n" +
+	"\vnext_run_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tnextRunAt\x12:\n" +
+	"\vlast_run_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tlastRunAt\x12\x1f\n" +
+	"\vlast_status\x18\v \x01(\tR\n" +
+	"lastStatus\"\xe4\x02\n" +
+	"\n" +
+	"ProfileRun\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vschedule_id\x18\x02 \x01(\tR\n" +
+	"scheduleId\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12!\n" +
+	"\fprofile_type\x18\x04 \x01(\tR\vprofileType\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12\x14\n" +
+	"\x05total\x18\t \x01(\x04R\x05total\x12#\n" +
+	"\runique_stacks\x18\n" +
+	" \x01(\x05R\funiqueStacks\"\x84\x02\n" +
+	"\x1cCreateProfileScheduleRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x19\n" +
+	"\bpod_name\x18\x02 \x01(\tR\apodName\x12!\n" +
+	"\fprofile_type\x18\x03 \x01(\tR\vprofileType\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\x125\n" +
+	"\binterval\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12!\n" +
+	"\ffrequency_hz\x18\x06 \x01(\x05R\vfrequencyHz\"]\n" +
+	"\x1dCreateProfileScheduleResponse\x12<\n" +
+	"\bschedule\x18\x01 \x01(\v2 .coral.colony.v1.ProfileScheduleR\bschedule\"@\n" +
+	"\x1bListProfileSchedulesRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\"^\n" +
+	"\x1cListProfileSchedulesResponse\x12>\n" +
+	"\tschedules\x18\x01 \x03(\v2 .coral.colony.v1.ProfileScheduleR\tschedules\".\n" +
+	"\x1cDeleteProfileScheduleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1f\n" +
+	"\x1dDeleteProfileScheduleResponse\"r\n" +
+	"\x16ListProfileRunsRequest\x12\x1f\n" +
+	"\vschedule_id\x18\x01 \x01(\tR\n" +
+	"scheduleId\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"J\n" +
+	"\x17ListProfileRunsResponse\x12/\n" +
+	"\x04runs\x18\x01 \x03(\v2\x1b.coral.colony.v1.ProfileRunR\x04runs\"&\n" +
+	"\x14GetProfileRunRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"^\n" +
+	"\x15GetProfileRunResponse\x12-\n" +
+	"\x03run\x18\x01 \x01(\v2\x1b.coral.colony.v1.ProfileRunR\x03run\x12\x16\n" +
+	"\x06folded\x18\x02 \x01(\tR\x06folded\"\xb6\x02\n" +
+	"\x12CaptureHttpRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x14\n" +
+	"\x05route\x18\x02 \x01(\tR\x05route\x12\x1f\n" +
+	"\vsample_rate\x18\x03 \x01(\x01R\n" +
+	"sampleRate\x12%\n" +
+	"\x0ecapture_bodies\x18\x04 \x01(\bR\rcaptureBodies\x12$\n" +
+	"\x0emax_body_bytes\x18\x05 \x01(\rR\fmaxBodyBytes\x125\n" +
+	"\bduration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x19\n" +
+	"\bagent_id\x18\a \x01(\tR\aagentId\x12'\n" +
+	"\x0foverride_freeze\x18\b \x01(\bR\x0eoverrideFreeze\"\xda\x01\n" +
+	"\x13CaptureHttpResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"error_info\x18\x05 \x01(\v2\x1a.coral.errors.v1.ErrorInfoR\terrorInfo\"\x87\x02\n" +
+	"\x11CaptureTlsRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x18\n" +
+	"\alibrary\x18\x02 \x01(\tR\alibrary\x12\x14\n" +
+	"\x05match\x18\x03 \x01(\tR\x05match\x12$\n" +
+	"\x0emax_data_bytes\x18\x04 \x01(\rR\fmaxDataBytes\x125\n" +
+	"\bduration\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x19\n" +
+	"\bagent_id\x18\x06 \x01(\tR\aagentId\x12'\n" +
+	"\x0foverride_freeze\x18\a \x01(\bR\x0eoverrideFreeze\"\xd9\x01\n" +
+	"\x12CaptureTlsResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"error_info\x18\x05 \x01(\v2\x1a.coral.errors.v1.ErrorInfoR\terrorInfo\"\xa7\x01\n" +
+	"\x13TraceRuntimeRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x05R\x0fdurationSeconds\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12'\n" +
+	"\x0foverride_freeze\x18\x04 \x01(\bR\x0eoverrideFreeze\"\xde\x02\n" +
+	"\x19RequestLatencyCorrelation\x12\x1a\n" +
+	"\brequests\x18\x01 \x01(\x04R\brequests\x12\x15\n" +
+	"\x06p50_us\x18\x02 \x01(\x04R\x05p50Us\x12\x15\n" +
+	"\x06p99_us\x18\x03 \x01(\x04R\x05p99Us\x12#\n" +
+	"\rslow_requests\x18\x04 \x01(\x04R\fslowRequests\x12*\n" +
+	"\x11slow_during_pause\x18\x05 \x01(\x04R\x0fslowDuringPause\x12$\n" +
+	"\x0eslow_during_gc\x18\x06 \x01(\x04R\fslowDuringGc\x12,\n" +
+	"\x12requests_during_gc\x18\a \x01(\x04R\x10requestsDuringGc\x12'\n" +
+	"\x10p99_during_gc_us\x18\b \x01(\x04R\rp99DuringGcUs\x12)\n" +
+	"\x11p99_outside_gc_us\x18\t \x01(\x04R\x0ep99OutsideGcUs\"\xc8\x04\n" +
+	"\x14TraceRuntimeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x19\n" +
+	"\bagent_id\x18\x04 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x05 \x01(\tR\tgoVersion\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x124\n" +
+	"\x06pauses\x18\b \x03(\v2\x1c.coral.agent.v1.RuntimePauseR\x06pauses\x124\n" +
+	"\tgc_cycles\x18\t \x03(\v2\x17.coral.agent.v1.GcCycleR\bgcCycles\x12B\n" +
+	"\rsched_latency\x18\n" +
+	" \x03(\v2\x1d.coral.agent.v1.LatencyBucketR\fschedLatency\x12F\n" +
+	"\brequests\x18\v \x01(\v2*.coral.colony.v1.RequestLatencyCorrelationR\brequests\x129\n" +
+	"\n" +
+	"error_info\x18\f \x01(\v2\x1a.coral.errors.v1.ErrorInfoR\terrorInfo\"\xc4\x01\n" +
+	"\x14TraceSyscallsRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x05R\x0fdurationSeconds\x12\x1a\n" +
+	"\bsyscalls\x18\x03 \x03(\tR\bsyscalls\x12\x19\n" +
+	"\bagent_id\x18\x04 \x01(\tR\aagentId\x12'\n" +
+	"\x0foverride_freeze\x18\x05 \x01(\bR\x0eoverrideFreeze\"\x84\x03\n" +
+	"\x15TraceSyscallsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x19\n" +
+	"\bagent_id\x18\x04 \x01(\tR\aagentId\x12\x16\n" +
+	"\x06target\x18\x05 \x01(\tR\x06target\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x128\n" +
+	"\bsyscalls\x18\b \x03(\v2\x1c.coral.agent.v1.SyscallStatsR\bsyscalls\x129\n" +
+	"\n" +
+	"error_info\x18\t \x01(\v2\x1a.coral.errors.v1.ErrorInfoR\terrorInfo\"\xa4\x01\n" +
+	"\x10ProfileIORequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x05R\x0fdurationSeconds\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12'\n" +
+	"\x0foverride_freeze\x18\x04 \x01(\bR\x0eoverrideFreeze\"\xae\x04\n" +
+	"\x11ProfileIOResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x19\n" +
+	"\bagent_id\x18\x04 \x01(\tR\aagentId\x12\x16\n" +
+	"\x06target\x18\x05 \x01(\tR\x06target\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12,\n" +
+	"\x05files\x18\b \x03(\v2\x16.coral.agent.v1.FileIOR\x05files\x122\n" +
+	"\adevices\x18\t \x03(\v2\x18.coral.agent.v1.DeviceIOR\adevices\x12@\n" +
+	"\fread_latency\x18\n" +
+	" \x03(\v2\x1d.coral.agent.v1.LatencyBucketR\vreadLatency\x12B\n" +
+	"\rwrite_latency\x18\v \x03(\v2\x1d.coral.agent.v1.LatencyBucketR\fwriteLatency\x129\n" +
+	"\n" +
+	"error_info\x18\f \x01(\v2\x1a.coral.errors.v1.ErrorInfoR\terrorInfo\"\xa7\x01\n" +
+	"\x13ProfileLocksRequest\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x05R\x0fdurationSeconds\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12'\n" +
+	"\x0foverride_freeze\x18\x04 \x01(\bR\x0eoverrideFreeze\"\xc5\x03\n" +
+	"\x14ProfileLocksResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x19\n" +
+	"\bagent_id\x18\x04 \x01(\tR\aagentId\x12\x16\n" +
+	"\x06target\x18\x05 \x01(\tR\x06target\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x125\n" +
+	"\x06stacks\x18\b \x03(\v2\x1d.coral.agent.v1.LockWaitStackR\x06stacks\x12\x1f\n" +
+	"\vtotal_waits\x18\t \x01(\x04R\n" +
+	"totalWaits\x12\"\n" +
+	"\rtotal_wait_ns\x18\n" +
+	" \x01(\x04R\vtotalWaitNs\x129\n" +
+	"\n" +
+	"error_info\x18\v \x01(\v2\x1a.coral.errors.v1.ErrorInfoR\terrorInfo\"Z\n" +
+	"\x13ShellRecordingEvent\x12\x1b\n" +
+	"\toffset_us\x18\x01 \x01(\x03R\boffsetUs\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\xea\x03\n" +
+	"\x0eShellRecording\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x18\n" +
+	"\acommand\x18\x05 \x03(\tR\acommand\x12%\n" +
+	"\x0econtainer_name\x18\x06 \x01(\tR\rcontainerName\x129\n" +
+	"\n" +
+	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bended_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12\x1b\n" +
+	"\texit_code\x18\t \x01(\x05R\bexitCode\x12\x12\n" +
+	"\x04cols\x18\n" +
+	" \x01(\rR\x04cols\x12\x12\n" +
+	"\x04rows\x18\v \x01(\rR\x04rows\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\f \x01(\x04R\tsizeBytes\x12\x1c\n" +
+	"\ttruncated\x18\r \x01(\bR\ttruncated\x12<\n" +
+	"\x06events\x18\x0e \x03(\v2$.coral.colony.v1.ShellRecordingEventR\x06events\"\\\n" +
+	"\x1bUploadShellRecordingRequest\x12=\n" +
+	"\trecording\x18\x01 \x01(\v2\x1f.coral.colony.v1.ShellRecordingR\trecording\"\x1e\n" +
+	"\x1cUploadShellRecordingResponse\"\x98\x01\n" +
+	"\x1aListShellRecordingsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"^\n" +
+	"\x1bListShellRecordingsResponse\x12?\n" +
+	"\n" +
+	"recordings\x18\x01 \x03(\v2\x1f.coral.colony.v1.ShellRecordingR\n" +
+	"recordings\"9\n" +
+	"\x18GetShellRecordingRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\"Z\n" +
+	"\x19GetShellRecordingResponse\x12=\n" +
+	"\trecording\x18\x01 \x01(\v2\x1f.coral.colony.v1.ShellRecordingR\trecording2\xab\x1d\n" +
 	"\x12ColonyDebugService\x12[\n" +
 	"\fAttachUprobe\x12$.coral.colony.v1.AttachUprobeRequest\x1a%.coral.colony.v1.AttachUprobeResponse\x12j\n" +
 	"\x11UpdateProbeFilter\x12).coral.colony.v1.UpdateProbeFilterRequest\x1a*.coral.colony.v1.UpdateProbeFilterResponse\x12[\n" +
 	"\fDetachUprobe\x12$.coral.colony.v1.DetachUprobeRequest\x1a%.coral.colony.v1.DetachUprobeResponse\x12j\n" +
-	"\x11QueryUprobeEvents\x12).coral.colony.v1.QueryUprobeEventsRequest\x1a*.coral.colony.v1.QueryUprobeEventsResponse\x12j\n" +
+	"\x11QueryUprobeEvents\x12).coral.colony.v1.QueryUprobeEventsRequest\x1a*.coral.colony.v1.QueryUprobeEventsResponse\x12o\n" +
+	"\x12IngestUprobeEvents\x12*.coral.colony.v1.IngestUprobeEventsRequest\x1a+.coral.colony.v1.IngestUprobeEventsResponse(\x01\x12j\n" +
 	"\x11ListDebugSessions\x12).coral.colony.v1.ListDebugSessionsRequest\x1a*.coral.colony.v1.ListDebugSessionsResponse\x12g\n" +
 	"\x10TraceRequestPath\x12(.coral.colony.v1.TraceRequestPathRequest\x1a).coral.colony.v1.TraceRequestPathResponse\x12d\n" +
 	"\x0fGetDebugResults\x12'.coral.colony.v1.GetDebugResultsRequest\x1a(.coral.colony.v1.GetDebugResultsResponse\x12a\n" +
 	"\x0eQueryFunctions\x12&.coral.colony.v1.QueryFunctionsRequest\x1a'.coral.colony.v1.QueryFunctionsResponse\x12g\n" +
-	"\x10ProfileFunctions\x12(.coral.colony.v1.ProfileFunctionsRequest\x1a).coral.colony.v1.ProfileFunctionsResponse\x12U\n" +
+	"\x10ProfileFunctions\x12(.coral.colony.v1.ProfileFunctionsRequest\x1a).coral.colony.v1.ProfileFunctionsResponse\x12y\n" +
+	"\x16CancelProfileFunctions\x12..coral.colony.v1.CancelProfileFunctionsRequest\x1a/.coral.colony.v1.CancelProfileFunctionsResponse\x12U\n" +
 	"\n" +
 	"ProfileCPU\x12\".coral.colony.v1.ProfileCPURequest\x1a#.coral.colony.v1.ProfileCPUResponse\x12\x82\x01\n" +
 	"\x19QueryHistoricalCPUProfile\x121.coral.colony.v1.QueryHistoricalCPUProfileRequest\x1a2.coral.colony.v1.QueryHistoricalCPUProfileResponse\x12^\n" +
-	"\rProfileMemory\x12%.coral.colony.v1.ProfileMemoryRequest\x1a&.coral.colony.v1.ProfileMemoryResponse\x12\x8b\x01\n" +
+	"\rProfileMemory\x12%.coral.colony.v1.ProfileMemoryRequest\x1a&.coral.colony.v1.ProfileMemoryResponse\x12R\n" +
+	"\tProfileIO\x12!.coral.colony.v1.ProfileIORequest\x1a\".coral.colony.v1.ProfileIOResponse\x12[\n" +
+	"\fProfileLocks\x12$.coral.colony.v1.ProfileLocksRequest\x1a%.coral.colony.v1.ProfileLocksResponse\x12\x8b\x01\n" +
 	"\x1cQueryHistoricalMemoryProfile\x124.coral.colony.v1.QueryHistoricalMemoryProfileRequest\x1a5.coral.colony.v1.QueryHistoricalMemoryProfileResponse\x12v\n" +
 	"\x11DeployCorrelation\x12/.coral.colony.v1.ColonyDeployCorrelationRequest\x1a0.coral.colony.v1.ColonyDeployCorrelationResponse\x12v\n" +
 	"\x11RemoveCorrelation\x12/.coral.colony.v1.ColonyRemoveCorrelationRequest\x1a0.coral.colony.v1.ColonyRemoveCorrelationResponse\x12s\n" +
-	"\x10ListCorrelations\x12..coral.colony.v1.ColonyListCorrelationsRequest\x1a/.coral.colony.v1.ColonyListCorrelationsResponseB\xb5\x01\n" +
+	"\x10ListCorrelations\x12..coral.colony.v1.ColonyListCorrelationsRequest\x1a/.coral.colony.v1.ColonyListCorrelationsResponse\x12j\n" +
+	"\rListCoreDumps\x12+.coral.colony.v1.ColonyListCoreDumpsRequest\x1a,.coral.colony.v1.ColonyListCoreDumpsResponse\x12c\n" +
+	"\x10DownloadCoreDump\x12..coral.colony.v1.ColonyDownloadCoreDumpRequest\x1a\x1d.coral.agent.v1.CoreDumpChunk0\x01\x12v\n" +
+	"\x15CreateProfileSchedule\x12-.coral.colony.v1.CreateProfileScheduleRequest\x1a..coral.colony.v1.CreateProfileScheduleResponse\x12s\n" +
+	"\x14ListProfileSchedules\x12,.coral.colony.v1.ListProfileSchedulesRequest\x1a-.coral.colony.v1.ListProfileSchedulesResponse\x12v\n" +
+	"\x15DeleteProfileSchedule\x12-.coral.colony.v1.DeleteProfileScheduleRequest\x1a..coral.colony.v1.DeleteProfileScheduleResponse\x12d\n" +
+	"\x0fListProfileRuns\x12'.coral.colony.v1.ListProfileRunsRequest\x1a(.coral.colony.v1.ListProfileRunsResponse\x12^\n" +
+	"\rGetProfileRun\x12%.coral.colony.v1.GetProfileRunRequest\x1a&.coral.colony.v1.GetProfileRunResponse\x12s\n" +
+	"\x10DescribeFunction\x12..coral.colony.v1.ColonyDescribeFunctionRequest\x1a/.coral.colony.v1.ColonyDescribeFunctionResponse\x12X\n" +
+	"\vCaptureHttp\x12#.coral.colony.v1.CaptureHttpRequest\x1a$.coral.colony.v1.CaptureHttpResponse\x12U\n" +
+	"\n" +
+	"CaptureTls\x12\".coral.colony.v1.CaptureTlsRequest\x1a#.coral.colony.v1.CaptureTlsResponse\x12[\n" +
+	"\fTraceRuntime\x12$.coral.colony.v1.TraceRuntimeRequest\x1a%.coral.colony.v1.TraceRuntimeResponse\x12^\n" +
+	"\rTraceSyscalls\x12%.coral.colony.v1.TraceSyscallsRequest\x1a&.coral.colony.v1.TraceSyscallsResponse\x12s\n" +
+	"\x14UploadShellRecording\x12,.coral.colony.v1.UploadShellRecordingRequest\x1a-.coral.colony.v1.UploadShellRecordingResponse\x12p\n" +
+	"\x13ListShellRecordings\x12+.coral.colony.v1.ListShellRecordingsRequest\x1a,.coral.colony.v1.ListShellRecordingsResponse\x12j\n" +
+	"\x11GetShellRecording\x12).coral.colony.v1.GetShellRecordingRequest\x1a*.coral.colony.v1.GetShellRecordingResponseB\xb5\x01\n" +
 	"\x13com.coral.colony.v1B\n" +
 	"DebugProtoP\x01Z4github.com/coral-mesh/coral/coral/colony/v1;colonyv1\xa2\x02\x03CCX\xaa\x02\x0fCoral.Colony.V1\xca\x02\x0fCoral\\Colony\\V1\xe2\x02\x1bCoral\\Colony\\V1\\GPBMetadata\xea\x02\x11Coral::Colony::V1b\x06proto3"
 
@@ -3542,7 +7028,7 @@ func file_coral_colony_v1_debug_proto_rawDescGZIP() []byte {
 	return file_coral_colony_v1_debug_proto_rawDescData
 }
 
-var file_coral_colony_v1_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
+var file_coral_colony_v1_debug_proto_msgTypes = make([]protoimpl.MessageInfo, 91)
 var file_coral_colony_v1_debug_proto_goTypes = []any{
 	(*AttachUprobeRequest)(nil),                  // 0: coral.colony.v1.AttachUprobeRequest
 	(*UpdateProbeFilterRequest)(nil),             // 1: coral.colony.v1.UpdateProbeFilterRequest
@@ -3552,156 +7038,302 @@ var file_coral_colony_v1_debug_proto_goTypes = []any{
 	(*DetachUprobeResponse)(nil),                 // 5: coral.colony.v1.DetachUprobeResponse
 	(*QueryUprobeEventsRequest)(nil),             // 6: coral.colony.v1.QueryUprobeEventsRequest
 	(*QueryUprobeEventsResponse)(nil),            // 7: coral.colony.v1.QueryUprobeEventsResponse
-	(*ListDebugSessionsRequest)(nil),             // 8: coral.colony.v1.ListDebugSessionsRequest
-	(*ListDebugSessionsResponse)(nil),            // 9: coral.colony.v1.ListDebugSessionsResponse
-	(*DebugSession)(nil),                         // 10: coral.colony.v1.DebugSession
-	(*TraceRequestPathRequest)(nil),              // 11: coral.colony.v1.TraceRequestPathRequest
-	(*TraceRequestPathResponse)(nil),             // 12: coral.colony.v1.TraceRequestPathResponse
-	(*GetDebugResultsRequest)(nil),               // 13: coral.colony.v1.GetDebugResultsRequest
-	(*GetDebugResultsResponse)(nil),              // 14: coral.colony.v1.GetDebugResultsResponse
-	(*DebugStatistics)(nil),                      // 15: coral.colony.v1.DebugStatistics
-	(*SlowOutlier)(nil),                          // 16: coral.colony.v1.SlowOutlier
-	(*CallTree)(nil),                             // 17: coral.colony.v1.CallTree
-	(*CallTreeNode)(nil),                         // 18: coral.colony.v1.CallTreeNode
-	(*QueryFunctionsRequest)(nil),                // 19: coral.colony.v1.QueryFunctionsRequest
-	(*QueryFunctionsResponse)(nil),               // 20: coral.colony.v1.QueryFunctionsResponse
-	(*FunctionResult)(nil),                       // 21: coral.colony.v1.FunctionResult
-	(*FunctionMetadata)(nil),                     // 22: coral.colony.v1.FunctionMetadata
-	(*SearchInfo)(nil),                           // 23: coral.colony.v1.SearchInfo
-	(*FunctionMetrics)(nil),                      // 24: coral.colony.v1.FunctionMetrics
-	(*InstrumentationInfo)(nil),                  // 25: coral.colony.v1.InstrumentationInfo
-	(*ProfileFunctionsRequest)(nil),              // 26: coral.colony.v1.ProfileFunctionsRequest
-	(*ProfileFunctionsResponse)(nil),             // 27: coral.colony.v1.ProfileFunctionsResponse
-	(*ProfileSummary)(nil),                       // 28: coral.colony.v1.ProfileSummary
-	(*ProfileResult)(nil),                        // 29: coral.colony.v1.ProfileResult
-	(*CallContribution)(nil),                     // 30: coral.colony.v1.CallContribution
-	(*Bottleneck)(nil),                           // 31: coral.colony.v1.Bottleneck
-	(*ProfileCPURequest)(nil),                    // 32: coral.colony.v1.ProfileCPURequest
-	(*ProfileCPUResponse)(nil),                   // 33: coral.colony.v1.ProfileCPUResponse
-	(*QueryHistoricalCPUProfileRequest)(nil),     // 34: coral.colony.v1.QueryHistoricalCPUProfileRequest
-	(*QueryHistoricalCPUProfileResponse)(nil),    // 35: coral.colony.v1.QueryHistoricalCPUProfileResponse
-	(*ProfileMemoryRequest)(nil),                 // 36: coral.colony.v1.ProfileMemoryRequest
-	(*ProfileMemoryResponse)(nil),                // 37: coral.colony.v1.ProfileMemoryResponse
-	(*QueryHistoricalMemoryProfileRequest)(nil),  // 38: coral.colony.v1.QueryHistoricalMemoryProfileRequest
-	(*QueryHistoricalMemoryProfileResponse)(nil), // 39: coral.colony.v1.QueryHistoricalMemoryProfileResponse
-	(*ColonyDeployCorrelationRequest)(nil),       // 40: coral.colony.v1.ColonyDeployCorrelationRequest
-	(*ColonyDeployCorrelationResponse)(nil),      // 41: coral.colony.v1.ColonyDeployCorrelationResponse
-	(*ColonyRemoveCorrelationRequest)(nil),       // 42: coral.colony.v1.ColonyRemoveCorrelationRequest
-	(*ColonyRemoveCorrelationResponse)(nil),      // 43: coral.colony.v1.ColonyRemoveCorrelationResponse
-	(*ColonyListCorrelationsRequest)(nil),        // 44: coral.colony.v1.ColonyListCorrelationsRequest
-	(*ColonyListCorrelationsResponse)(nil),       // 45: coral.colony.v1.ColonyListCorrelationsResponse
-	nil,                                          // 46: coral.colony.v1.SlowOutlier.LabelsEntry
-	(*durationpb.Duration)(nil),                  // 47: google.protobuf.Duration
-	(*v1.UprobeConfig)(nil),                      // 48: coral.agent.v1.UprobeConfig
-	(*v1.UprobeFilter)(nil),                      // 49: coral.agent.v1.UprobeFilter
-	(*timestamppb.Timestamp)(nil),                // 50: google.protobuf.Timestamp
-	(*v1.UprobeEvent)(nil),                       // 51: coral.agent.v1.UprobeEvent
-	(*v1.StackSample)(nil),                       // 52: coral.agent.v1.StackSample
-	(*v1.MemoryStackSample)(nil),                 // 53: coral.agent.v1.MemoryStackSample
-	(*v1.MemoryStats)(nil),                       // 54: coral.agent.v1.MemoryStats
-	(*v1.TopAllocFunction)(nil),                  // 55: coral.agent.v1.TopAllocFunction
-	(*v1.TopAllocType)(nil),                      // 56: coral.agent.v1.TopAllocType
-	(*v1.CorrelationDescriptor)(nil),             // 57: coral.agent.v1.CorrelationDescriptor
+	(*IngestUprobeEventsRequest)(nil),            // 8: coral.colony.v1.IngestUprobeEventsRequest
+	(*IngestUprobeEventsResponse)(nil),           // 9: coral.colony.v1.IngestUprobeEventsResponse
+	(*ListDebugSessionsRequest)(nil),             // 10: coral.colony.v1.ListDebugSessionsRequest
+	(*ListDebugSessionsResponse)(nil),            // 11: coral.colony.v1.ListDebugSessionsResponse
+	(*DebugSession)(nil),                         // 12: coral.colony.v1.DebugSession
+	(*TraceRequestPathRequest)(nil),              // 13: coral.colony.v1.TraceRequestPathRequest
+	(*TraceRequestPathResponse)(nil),             // 14: coral.colony.v1.TraceRequestPathResponse
+	(*GetDebugResultsRequest)(nil),               // 15: coral.colony.v1.GetDebugResultsRequest
+	(*GetDebugResultsResponse)(nil),              // 16: coral.colony.v1.GetDebugResultsResponse
+	(*DebugStatistics)(nil),                      // 17: coral.colony.v1.DebugStatistics
+	(*SlowOutlier)(nil),                          // 18: coral.colony.v1.SlowOutlier
+	(*CallTree)(nil),                             // 19: coral.colony.v1.CallTree
+	(*CallTreeNode)(nil),                         // 20: coral.colony.v1.CallTreeNode
+	(*QueryFunctionsRequest)(nil),                // 21: coral.colony.v1.QueryFunctionsRequest
+	(*QueryFunctionsResponse)(nil),               // 22: coral.colony.v1.QueryFunctionsResponse
+	(*FunctionResult)(nil),                       // 23: coral.colony.v1.FunctionResult
+	(*FunctionMetadata)(nil),                     // 24: coral.colony.v1.FunctionMetadata
+	(*SearchInfo)(nil),                           // 25: coral.colony.v1.SearchInfo
+	(*FunctionMetrics)(nil),                      // 26: coral.colony.v1.FunctionMetrics
+	(*InstrumentationInfo)(nil),                  // 27: coral.colony.v1.InstrumentationInfo
+	(*ProfileFunctionsRequest)(nil),              // 28: coral.colony.v1.ProfileFunctionsRequest
+	(*ProfileFunctionsResponse)(nil),             // 29: coral.colony.v1.ProfileFunctionsResponse
+	(*CancelProfileFunctionsRequest)(nil),        // 30: coral.colony.v1.CancelProfileFunctionsRequest
+	(*CancelProfileFunctionsResponse)(nil),       // 31: coral.colony.v1.CancelProfileFunctionsResponse
+	(*ProfileSummary)(nil),                       // 32: coral.colony.v1.ProfileSummary
+	(*ProfileResult)(nil),                        // 33: coral.colony.v1.ProfileResult
+	(*CallContribution)(nil),                     // 34: coral.colony.v1.CallContribution
+	(*Bottleneck)(nil),                           // 35: coral.colony.v1.Bottleneck
+	(*ProfileCPURequest)(nil),                    // 36: coral.colony.v1.ProfileCPURequest
+	(*ProfileCPUResponse)(nil),                   // 37: coral.colony.v1.ProfileCPUResponse
+	(*QueryHistoricalCPUProfileRequest)(nil),     // 38: coral.colony.v1.QueryHistoricalCPUProfileRequest
+	(*QueryHistoricalCPUProfileResponse)(nil),    // 39: coral.colony.v1.QueryHistoricalCPUProfileResponse
+	(*ProfileMemoryRequest)(nil),                 // 40: coral.colony.v1.ProfileMemoryRequest
+	(*ProfileMemoryResponse)(nil),                // 41: coral.colony.v1.ProfileMemoryResponse
+	(*QueryHistoricalMemoryProfileRequest)(nil),  // 42: coral.colony.v1.QueryHistoricalMemoryProfileRequest
+	(*QueryHistoricalMemoryProfileResponse)(nil), // 43: coral.colony.v1.QueryHistoricalMemoryProfileResponse
+	(*MemoryGrowthBucket)(nil),                   // 44: coral.colony.v1.MemoryGrowthBucket
+	(*MemoryLeakCandidate)(nil),                  // 45: coral.colony.v1.MemoryLeakCandidate
+	(*ColonyDeployCorrelationRequest)(nil),       // 46: coral.colony.v1.ColonyDeployCorrelationRequest
+	(*ColonyDeployCorrelationResponse)(nil),      // 47: coral.colony.v1.ColonyDeployCorrelationResponse
+	(*ColonyRemoveCorrelationRequest)(nil),       // 48: coral.colony.v1.ColonyRemoveCorrelationRequest
+	(*ColonyRemoveCorrelationResponse)(nil),      // 49: coral.colony.v1.ColonyRemoveCorrelationResponse
+	(*ColonyListCorrelationsRequest)(nil),        // 50: coral.colony.v1.ColonyListCorrelationsRequest
+	(*ColonyListCorrelationsResponse)(nil),       // 51: coral.colony.v1.ColonyListCorrelationsResponse
+	(*ColonyListCoreDumpsRequest)(nil),           // 52: coral.colony.v1.ColonyListCoreDumpsRequest
+	(*ColonyListCoreDumpsResponse)(nil),          // 53: coral.colony.v1.ColonyListCoreDumpsResponse
+	(*ColonyDownloadCoreDumpRequest)(nil),        // 54: coral.colony.v1.ColonyDownloadCoreDumpRequest
+	(*ColonyDescribeFunctionRequest)(nil),        // 55: coral.colony.v1.ColonyDescribeFunctionRequest
+	(*ColonyDescribeFunctionResponse)(nil),       // 56: coral.colony.v1.ColonyDescribeFunctionResponse
+	(*ProfileSchedule)(nil),                      // 57: coral.colony.v1.ProfileSchedule
+	(*ProfileRun)(nil),                           // 58: coral.colony.v1.ProfileRun
+	(*CreateProfileScheduleRequest)(nil),         // 59: coral.colony.v1.CreateProfileScheduleRequest
+	(*CreateProfileScheduleResponse)(nil),        // 60: coral.colony.v1.CreateProfileScheduleResponse
+	(*ListProfileSchedulesRequest)(nil),          // 61: coral.colony.v1.ListProfileSchedulesRequest
+	(*ListProfileSchedulesResponse)(nil),         // 62: coral.colony.v1.ListProfileSchedulesResponse
+	(*DeleteProfileScheduleRequest)(nil),         // 63: coral.colony.v1.DeleteProfileScheduleRequest
+	(*DeleteProfileScheduleResponse)(nil),        // 64: coral.colony.v1.DeleteProfileScheduleResponse
+	(*ListProfileRunsRequest)(nil),               // 65: coral.colony.v1.ListProfileRunsRequest
+	(*ListProfileRunsResponse)(nil),              // 66: coral.colony.v1.ListProfileRunsResponse
+	(*GetProfileRunRequest)(nil),                 // 67: coral.colony.v1.GetProfileRunRequest
+	(*GetProfileRunResponse)(nil),                // 68: coral.colony.v1.GetProfileRunResponse
+	(*CaptureHttpRequest)(nil),                   // 69: coral.colony.v1.CaptureHttpRequest
+	(*CaptureHttpResponse)(nil),                  // 70: coral.colony.v1.CaptureHttpResponse
+	(*CaptureTlsRequest)(nil),                    // 71: coral.colony.v1.CaptureTlsRequest
+	(*CaptureTlsResponse)(nil),                   // 72: coral.colony.v1.CaptureTlsResponse
+	(*TraceRuntimeRequest)(nil),                  // 73: coral.colony.v1.TraceRuntimeRequest
+	(*RequestLatencyCorrelation)(nil),            // 74: coral.colony.v1.RequestLatencyCorrelation
+	(*TraceRuntimeResponse)(nil),                 // 75: coral.colony.v1.TraceRuntimeResponse
+	(*TraceSyscallsRequest)(nil),                 // 76: coral.colony.v1.TraceSyscallsRequest
+	(*TraceSyscallsResponse)(nil),                // 77: coral.colony.v1.TraceSyscallsResponse
+	(*ProfileIORequest)(nil),                     // 78: coral.colony.v1.ProfileIORequest
+	(*ProfileIOResponse)(nil),                    // 79: coral.colony.v1.ProfileIOResponse
+	(*ProfileLocksRequest)(nil),                  // 80: coral.colony.v1.ProfileLocksRequest
+	(*ProfileLocksResponse)(nil),                 // 81: coral.colony.v1.ProfileLocksResponse
+	(*ShellRecordingEvent)(nil),                  // 82: coral.colony.v1.ShellRecordingEvent
+	(*ShellRecording)(nil),                       // 83: coral.colony.v1.ShellRecording
+	(*UploadShellRecordingRequest)(nil),          // 84: coral.colony.v1.UploadShellRecordingRequest
+	(*UploadShellRecordingResponse)(nil),         // 85: coral.colony.v1.UploadShellRecordingResponse
+	(*ListShellRecordingsRequest)(nil),           // 86: coral.colony.v1.ListShellRecordingsRequest
+	(*ListShellRecordingsResponse)(nil),          // 87: coral.colony.v1.ListShellRecordingsResponse
+	(*GetShellRecordingRequest)(nil),             // 88: coral.colony.v1.GetShellRecordingRequest
+	(*GetShellRecordingResponse)(nil),            // 89: coral.colony.v1.GetShellRecordingResponse
+	nil,                                          // 90: coral.colony.v1.SlowOutlier.LabelsEntry
+	(*durationpb.Duration)(nil),                  // 91: google.protobuf.Duration
+	(*v1.UprobeConfig)(nil),                      // 92: coral.agent.v1.UprobeConfig
+	(*v1.UprobeFilter)(nil),                      // 93: coral.agent.v1.UprobeFilter
+	(*timestamppb.Timestamp)(nil),                // 94: google.protobuf.Timestamp
+	(*v11.ErrorInfo)(nil),                        // 95: coral.errors.v1.ErrorInfo
+	(*v1.UprobeEvent)(nil),                       // 96: coral.agent.v1.UprobeEvent
+	(*v1.StackSample)(nil),                       // 97: coral.agent.v1.StackSample
+	(*v1.MemoryStackSample)(nil),                 // 98: coral.agent.v1.MemoryStackSample
+	(*v1.MemoryStats)(nil),                       // 99: coral.agent.v1.MemoryStats
+	(*v1.TopAllocFunction)(nil),                  // 100: coral.agent.v1.TopAllocFunction
+	(*v1.TopAllocType)(nil),                      // 101: coral.agent.v1.TopAllocType
+	(*v1.CorrelationDescriptor)(nil),             // 102: coral.agent.v1.CorrelationDescriptor
+	(*v1.CoreDumpInfo)(nil),                      // 103: coral.agent.v1.CoreDumpInfo
+	(*v1.FunctionDescription)(nil),               // 104: coral.agent.v1.FunctionDescription
+	(*v1.RuntimePause)(nil),                      // 105: coral.agent.v1.RuntimePause
+	(*v1.GcCycle)(nil),                           // 106: coral.agent.v1.GcCycle
+	(*v1.LatencyBucket)(nil),                     // 107: coral.agent.v1.LatencyBucket
+	(*v1.SyscallStats)(nil),                      // 108: coral.agent.v1.SyscallStats
+	(*v1.FileIO)(nil),                            // 109: coral.agent.v1.FileIO
+	(*v1.DeviceIO)(nil),                          // 110: coral.agent.v1.DeviceIO
+	(*v1.LockWaitStack)(nil),                     // 111: coral.agent.v1.LockWaitStack
+	(*v1.CoreDumpChunk)(nil),                     // 112: coral.agent.v1.CoreDumpChunk
 }
 var file_coral_colony_v1_debug_proto_depIdxs = []int32{
-	47, // 0: coral.colony.v1.AttachUprobeRequest.duration:type_name -> google.protobuf.Duration
-	48, // 1: coral.colony.v1.AttachUprobeRequest.config:type_name -> coral.agent.v1.UprobeConfig
-	49, // 2: coral.colony.v1.AttachUprobeRequest.filter:type_name -> coral.agent.v1.UprobeFilter
-	49, // 3: coral.colony.v1.UpdateProbeFilterRequest.filter:type_name -> coral.agent.v1.UprobeFilter
-	50, // 4: coral.colony.v1.AttachUprobeResponse.expires_at:type_name -> google.protobuf.Timestamp
-	50, // 5: coral.colony.v1.QueryUprobeEventsRequest.start_time:type_name -> google.protobuf.Timestamp
-	50, // 6: coral.colony.v1.QueryUprobeEventsRequest.end_time:type_name -> google.protobuf.Timestamp
-	51, // 7: coral.colony.v1.QueryUprobeEventsResponse.events:type_name -> coral.agent.v1.UprobeEvent
-	10, // 8: coral.colony.v1.ListDebugSessionsResponse.sessions:type_name -> coral.colony.v1.DebugSession
-	50, // 9: coral.colony.v1.DebugSession.started_at:type_name -> google.protobuf.Timestamp
-	50, // 10: coral.colony.v1.DebugSession.expires_at:type_name -> google.protobuf.Timestamp
-	47, // 11: coral.colony.v1.TraceRequestPathRequest.duration:type_name -> google.protobuf.Duration
-	47, // 12: coral.colony.v1.GetDebugResultsResponse.duration:type_name -> google.protobuf.Duration
-	15, // 13: coral.colony.v1.GetDebugResultsResponse.statistics:type_name -> coral.colony.v1.DebugStatistics
-	16, // 14: coral.colony.v1.GetDebugResultsResponse.slow_outliers:type_name -> coral.colony.v1.SlowOutlier
-	17, // 15: coral.colony.v1.GetDebugResultsResponse.call_tree:type_name -> coral.colony.v1.CallTree
-	47, // 16: coral.colony.v1.DebugStatistics.duration_p50:type_name -> google.protobuf.Duration
-	47, // 17: coral.colony.v1.DebugStatistics.duration_p95:type_name -> google.protobuf.Duration
-	47, // 18: coral.colony.v1.DebugStatistics.duration_p99:type_name -> google.protobuf.Duration
-	47, // 19: coral.colony.v1.DebugStatistics.duration_max:type_name -> google.protobuf.Duration
-	47, // 20: coral.colony.v1.SlowOutlier.duration:type_name -> google.protobuf.Duration
-	50, // 21: coral.colony.v1.SlowOutlier.timestamp:type_name -> google.protobuf.Timestamp
-	46, // 22: coral.colony.v1.SlowOutlier.labels:type_name -> coral.colony.v1.SlowOutlier.LabelsEntry
-	18, // 23: coral.colony.v1.CallTree.root:type_name -> coral.colony.v1.CallTreeNode
-	47, // 24: coral.colony.v1.CallTreeNode.total_duration:type_name -> google.protobuf.Duration
-	47, // 25: coral.colony.v1.CallTreeNode.self_duration:type_name -> google.protobuf.Duration
-	18, // 26: coral.colony.v1.CallTreeNode.children:type_name -> coral.colony.v1.CallTreeNode
-	21, // 27: coral.colony.v1.QueryFunctionsResponse.results:type_name -> coral.colony.v1.FunctionResult
-	22, // 28: coral.colony.v1.FunctionResult.function:type_name -> coral.colony.v1.FunctionMetadata
-	23, // 29: coral.colony.v1.FunctionResult.search:type_name -> coral.colony.v1.SearchInfo
-	24, // 30: coral.colony.v1.FunctionResult.metrics:type_name -> coral.colony.v1.FunctionMetrics
-	25, // 31: coral.colony.v1.FunctionResult.instrumentation:type_name -> coral.colony.v1.InstrumentationInfo
-	50, // 32: coral.colony.v1.FunctionMetrics.last_measured:type_name -> google.protobuf.Timestamp
-	47, // 33: coral.colony.v1.FunctionMetrics.p50:type_name -> google.protobuf.Duration
-	47, // 34: coral.colony.v1.FunctionMetrics.p95:type_name -> google.protobuf.Duration
-	47, // 35: coral.colony.v1.FunctionMetrics.p99:type_name -> google.protobuf.Duration
-	50, // 36: coral.colony.v1.InstrumentationInfo.last_probed:type_name -> google.protobuf.Timestamp
-	47, // 37: coral.colony.v1.ProfileFunctionsRequest.duration:type_name -> google.protobuf.Duration
-	28, // 38: coral.colony.v1.ProfileFunctionsResponse.summary:type_name -> coral.colony.v1.ProfileSummary
-	29, // 39: coral.colony.v1.ProfileFunctionsResponse.results:type_name -> coral.colony.v1.ProfileResult
-	31, // 40: coral.colony.v1.ProfileFunctionsResponse.bottlenecks:type_name -> coral.colony.v1.Bottleneck
-	47, // 41: coral.colony.v1.ProfileSummary.duration:type_name -> google.protobuf.Duration
-	24, // 42: coral.colony.v1.ProfileResult.metrics:type_name -> coral.colony.v1.FunctionMetrics
-	30, // 43: coral.colony.v1.ProfileResult.calls:type_name -> coral.colony.v1.CallContribution
-	47, // 44: coral.colony.v1.CallContribution.p95:type_name -> google.protobuf.Duration
-	47, // 45: coral.colony.v1.Bottleneck.p95:type_name -> google.protobuf.Duration
-	52, // 46: coral.colony.v1.ProfileCPUResponse.samples:type_name -> coral.agent.v1.StackSample
-	50, // 47: coral.colony.v1.QueryHistoricalCPUProfileRequest.start_time:type_name -> google.protobuf.Timestamp
-	50, // 48: coral.colony.v1.QueryHistoricalCPUProfileRequest.end_time:type_name -> google.protobuf.Timestamp
-	52, // 49: coral.colony.v1.QueryHistoricalCPUProfileResponse.samples:type_name -> coral.agent.v1.StackSample
-	53, // 50: coral.colony.v1.ProfileMemoryResponse.samples:type_name -> coral.agent.v1.MemoryStackSample
-	54, // 51: coral.colony.v1.ProfileMemoryResponse.stats:type_name -> coral.agent.v1.MemoryStats
-	55, // 52: coral.colony.v1.ProfileMemoryResponse.top_functions:type_name -> coral.agent.v1.TopAllocFunction
-	56, // 53: coral.colony.v1.ProfileMemoryResponse.top_types:type_name -> coral.agent.v1.TopAllocType
-	50, // 54: coral.colony.v1.QueryHistoricalMemoryProfileRequest.start_time:type_name -> google.protobuf.Timestamp
-	50, // 55: coral.colony.v1.QueryHistoricalMemoryProfileRequest.end_time:type_name -> google.protobuf.Timestamp
-	53, // 56: coral.colony.v1.QueryHistoricalMemoryProfileResponse.samples:type_name -> coral.agent.v1.MemoryStackSample
-	55, // 57: coral.colony.v1.QueryHistoricalMemoryProfileResponse.top_functions:type_name -> coral.agent.v1.TopAllocFunction
-	56, // 58: coral.colony.v1.QueryHistoricalMemoryProfileResponse.top_types:type_name -> coral.agent.v1.TopAllocType
-	57, // 59: coral.colony.v1.ColonyDeployCorrelationRequest.descriptor:type_name -> coral.agent.v1.CorrelationDescriptor
-	57, // 60: coral.colony.v1.ColonyListCorrelationsResponse.descriptors:type_name -> coral.agent.v1.CorrelationDescriptor
-	0,  // 61: coral.colony.v1.ColonyDebugService.AttachUprobe:input_type -> coral.colony.v1.AttachUprobeRequest
-	1,  // 62: coral.colony.v1.ColonyDebugService.UpdateProbeFilter:input_type -> coral.colony.v1.UpdateProbeFilterRequest
-	4,  // 63: coral.colony.v1.ColonyDebugService.DetachUprobe:input_type -> coral.colony.v1.DetachUprobeRequest
-	6,  // 64: coral.colony.v1.ColonyDebugService.QueryUprobeEvents:input_type -> coral.colony.v1.QueryUprobeEventsRequest
-	8,  // 65: coral.colony.v1.ColonyDebugService.ListDebugSessions:input_type -> coral.colony.v1.ListDebugSessionsRequest
-	11, // 66: coral.colony.v1.ColonyDebugService.TraceRequestPath:input_type -> coral.colony.v1.TraceRequestPathRequest
-	13, // 67: coral.colony.v1.ColonyDebugService.GetDebugResults:input_type -> coral.colony.v1.GetDebugResultsRequest
-	19, // 68: coral.colony.v1.ColonyDebugService.QueryFunctions:input_type -> coral.colony.v1.QueryFunctionsRequest
-	26, // 69: coral.colony.v1.ColonyDebugService.ProfileFunctions:input_type -> coral.colony.v1.ProfileFunctionsRequest
-	32, // 70: coral.colony.v1.ColonyDebugService.ProfileCPU:input_type -> coral.colony.v1.ProfileCPURequest
-	34, // 71: coral.colony.v1.ColonyDebugService.QueryHistoricalCPUProfile:input_type -> coral.colony.v1.QueryHistoricalCPUProfileRequest
-	36, // 72: coral.colony.v1.ColonyDebugService.ProfileMemory:input_type -> coral.colony.v1.ProfileMemoryRequest
-	38, // 73: coral.colony.v1.ColonyDebugService.QueryHistoricalMemoryProfile:input_type -> coral.colony.v1.QueryHistoricalMemoryProfileRequest
-	40, // 74: coral.colony.v1.ColonyDebugService.DeployCorrelation:input_type -> coral.colony.v1.ColonyDeployCorrelationRequest
-	42, // 75: coral.colony.v1.ColonyDebugService.RemoveCorrelation:input_type -> coral.colony.v1.ColonyRemoveCorrelationRequest
-	44, // 76: coral.colony.v1.ColonyDebugService.ListCorrelations:input_type -> coral.colony.v1.ColonyListCorrelationsRequest
-	3,  // 77: coral.colony.v1.ColonyDebugService.AttachUprobe:output_type -> coral.colony.v1.AttachUprobeResponse
-	2,  // 78: coral.colony.v1.ColonyDebugService.UpdateProbeFilter:output_type -> coral.colony.v1.UpdateProbeFilterResponse
-	5,  // 79: coral.colony.v1.ColonyDebugService.DetachUprobe:output_type -> coral.colony.v1.DetachUprobeResponse
-	7,  // 80: coral.colony.v1.ColonyDebugService.QueryUprobeEvents:output_type -> coral.colony.v1.QueryUprobeEventsResponse
-	9,  // 81: coral.colony.v1.ColonyDebugService.ListDebugSessions:output_type -> coral.colony.v1.ListDebugSessionsResponse
-	12, // 82: coral.colony.v1.ColonyDebugService.TraceRequestPath:output_type -> coral.colony.v1.TraceRequestPathResponse
-	14, // 83: coral.colony.v1.ColonyDebugService.GetDebugResults:output_type -> coral.colony.v1.GetDebugResultsResponse
-	20, // 84: coral.colony.v1.ColonyDebugService.QueryFunctions:output_type -> coral.colony.v1.QueryFunctionsResponse
-	27, // 85: coral.colony.v1.ColonyDebugService.ProfileFunctions:output_type -> coral.colony.v1.ProfileFunctionsResponse
-	33, // 86: coral.colony.v1.ColonyDebugService.ProfileCPU:output_type -> coral.colony.v1.ProfileCPUResponse
-	35, // 87: coral.colony.v1.ColonyDebugService.QueryHistoricalCPUProfile:output_type -> coral.colony.v1.QueryHistoricalCPUProfileResponse
-	37, // 88: coral.colony.v1.ColonyDebugService.ProfileMemory:output_type -> coral.colony.v1.ProfileMemoryResponse
-	39, // 89: coral.colony.v1.ColonyDebugService.QueryHistoricalMemoryProfile:output_type -> coral.colony.v1.QueryHistoricalMemoryProfileResponse
-	41, // 90: coral.colony.v1.ColonyDebugService.DeployCorrelation:output_type -> coral.colony.v1.ColonyDeployCorrelationResponse
-	43, // 91: coral.colony.v1.ColonyDebugService.RemoveCorrelation:output_type -> coral.colony.v1.ColonyRemoveCorrelationResponse
-	45, // 92: coral.colony.v1.ColonyDebugService.ListCorrelations:output_type -> coral.colony.v1.ColonyListCorrelationsResponse
-	77, // [77:93] is the sub-list for method output_type
-	61, // [61:77] is the sub-list for method input_type
-	61, // [61:61] is the sub-list for extension type_name
-	61, // [61:61] is the sub-list for extension extendee
-	0,  // [0:61] is the sub-list for field type_name
+	91,  // 0: coral.colony.v1.AttachUprobeRequest.duration:type_name -> google.protobuf.Duration
+	92,  // 1: coral.colony.v1.AttachUprobeRequest.config:type_name -> coral.agent.v1.UprobeConfig
+	93,  // 2: coral.colony.v1.AttachUprobeRequest.filter:type_name -> coral.agent.v1.UprobeFilter
+	93,  // 3: coral.colony.v1.UpdateProbeFilterRequest.filter:type_name -> coral.agent.v1.UprobeFilter
+	94,  // 4: coral.colony.v1.AttachUprobeResponse.expires_at:type_name -> google.protobuf.Timestamp
+	95,  // 5: coral.colony.v1.AttachUprobeResponse.error_info:type_name -> coral.errors.v1.ErrorInfo
+	94,  // 6: coral.colony.v1.QueryUprobeEventsRequest.start_time:type_name -> google.protobuf.Timestamp
+	94,  // 7: coral.colony.v1.QueryUprobeEventsRequest.end_time:type_name -> google.protobuf.Timestamp
+	96,  // 8: coral.colony.v1.QueryUprobeEventsResponse.events:type_name -> coral.agent.v1.UprobeEvent
+	96,  // 9: coral.colony.v1.IngestUprobeEventsRequest.events:type_name -> coral.agent.v1.UprobeEvent
+	12,  // 10: coral.colony.v1.ListDebugSessionsResponse.sessions:type_name -> coral.colony.v1.DebugSession
+	94,  // 11: coral.colony.v1.DebugSession.started_at:type_name -> google.protobuf.Timestamp
+	94,  // 12: coral.colony.v1.DebugSession.expires_at:type_name -> google.protobuf.Timestamp
+	91,  // 13: coral.colony.v1.TraceRequestPathRequest.duration:type_name -> google.protobuf.Duration
+	91,  // 14: coral.colony.v1.GetDebugResultsResponse.duration:type_name -> google.protobuf.Duration
+	17,  // 15: coral.colony.v1.GetDebugResultsResponse.statistics:type_name -> coral.colony.v1.DebugStatistics
+	18,  // 16: coral.colony.v1.GetDebugResultsResponse.slow_outliers:type_name -> coral.colony.v1.SlowOutlier
+	19,  // 17: coral.colony.v1.GetDebugResultsResponse.call_tree:type_name -> coral.colony.v1.CallTree
+	91,  // 18: coral.colony.v1.DebugStatistics.duration_p50:type_name -> google.protobuf.Duration
+	91,  // 19: coral.colony.v1.DebugStatistics.duration_p95:type_name -> google.protobuf.Duration
+	91,  // 20: coral.colony.v1.DebugStatistics.duration_p99:type_name -> google.protobuf.Duration
+	91,  // 21: coral.colony.v1.DebugStatistics.duration_max:type_name -> google.protobuf.Duration
+	91,  // 22: coral.colony.v1.SlowOutlier.duration:type_name -> google.protobuf.Duration
+	94,  // 23: coral.colony.v1.SlowOutlier.timestamp:type_name -> google.protobuf.Timestamp
+	90,  // 24: coral.colony.v1.SlowOutlier.labels:type_name -> coral.colony.v1.SlowOutlier.LabelsEntry
+	20,  // 25: coral.colony.v1.CallTree.root:type_name -> coral.colony.v1.CallTreeNode
+	91,  // 26: coral.colony.v1.CallTreeNode.total_duration:type_name -> google.protobuf.Duration
+	91,  // 27: coral.colony.v1.CallTreeNode.self_duration:type_name -> google.protobuf.Duration
+	20,  // 28: coral.colony.v1.CallTreeNode.children:type_name -> coral.colony.v1.CallTreeNode
+	23,  // 29: coral.colony.v1.QueryFunctionsResponse.results:type_name -> coral.colony.v1.FunctionResult
+	24,  // 30: coral.colony.v1.FunctionResult.function:type_name -> coral.colony.v1.FunctionMetadata
+	25,  // 31: coral.colony.v1.FunctionResult.search:type_name -> coral.colony.v1.SearchInfo
+	26,  // 32: coral.colony.v1.FunctionResult.metrics:type_name -> coral.colony.v1.FunctionMetrics
+	27,  // 33: coral.colony.v1.FunctionResult.instrumentation:type_name -> coral.colony.v1.InstrumentationInfo
+	94,  // 34: coral.colony.v1.FunctionMetrics.last_measured:type_name -> google.protobuf.Timestamp
+	91,  // 35: coral.colony.v1.FunctionMetrics.p50:type_name -> google.protobuf.Duration
+	91,  // 36: coral.colony.v1.FunctionMetrics.p95:type_name -> google.protobuf.Duration
+	91,  // 37: coral.colony.v1.FunctionMetrics.p99:type_name -> google.protobuf.Duration
+	94,  // 38: coral.colony.v1.InstrumentationInfo.last_probed:type_name -> google.protobuf.Timestamp
+	91,  // 39: coral.colony.v1.ProfileFunctionsRequest.duration:type_name -> google.protobuf.Duration
+	32,  // 40: coral.colony.v1.ProfileFunctionsResponse.summary:type_name -> coral.colony.v1.ProfileSummary
+	33,  // 41: coral.colony.v1.ProfileFunctionsResponse.results:type_name -> coral.colony.v1.ProfileResult
+	35,  // 42: coral.colony.v1.ProfileFunctionsResponse.bottlenecks:type_name -> coral.colony.v1.Bottleneck
+	91,  // 43: coral.colony.v1.ProfileSummary.duration:type_name -> google.protobuf.Duration
+	26,  // 44: coral.colony.v1.ProfileResult.metrics:type_name -> coral.colony.v1.FunctionMetrics
+	34,  // 45: coral.colony.v1.ProfileResult.calls:type_name -> coral.colony.v1.CallContribution
+	91,  // 46: coral.colony.v1.CallContribution.p95:type_name -> google.protobuf.Duration
+	91,  // 47: coral.colony.v1.Bottleneck.p95:type_name -> google.protobuf.Duration
+	97,  // 48: coral.colony.v1.ProfileCPUResponse.samples:type_name -> coral.agent.v1.StackSample
+	94,  // 49: coral.colony.v1.QueryHistoricalCPUProfileRequest.start_time:type_name -> google.protobuf.Timestamp
+	94,  // 50: coral.colony.v1.QueryHistoricalCPUProfileRequest.end_time:type_name -> google.protobuf.Timestamp
+	97,  // 51: coral.colony.v1.QueryHistoricalCPUProfileResponse.samples:type_name -> coral.agent.v1.StackSample
+	98,  // 52: coral.colony.v1.ProfileMemoryResponse.samples:type_name -> coral.agent.v1.MemoryStackSample
+	99,  // 53: coral.colony.v1.ProfileMemoryResponse.stats:type_name -> coral.agent.v1.MemoryStats
+	100, // 54: coral.colony.v1.ProfileMemoryResponse.top_functions:type_name -> coral.agent.v1.TopAllocFunction
+	101, // 55: coral.colony.v1.ProfileMemoryResponse.top_types:type_name -> coral.agent.v1.TopAllocType
+	94,  // 56: coral.colony.v1.QueryHistoricalMemoryProfileRequest.start_time:type_name -> google.protobuf.Timestamp
+	94,  // 57: coral.colony.v1.QueryHistoricalMemoryProfileRequest.end_time:type_name -> google.protobuf.Timestamp
+	98,  // 58: coral.colony.v1.QueryHistoricalMemoryProfileResponse.samples:type_name -> coral.agent.v1.MemoryStackSample
+	100, // 59: coral.colony.v1.QueryHistoricalMemoryProfileResponse.top_functions:type_name -> coral.agent.v1.TopAllocFunction
+	101, // 60: coral.colony.v1.QueryHistoricalMemoryProfileResponse.top_types:type_name -> coral.agent.v1.TopAllocType
+	44,  // 61: coral.colony.v1.QueryHistoricalMemoryProfileResponse.growth:type_name -> coral.colony.v1.MemoryGrowthBucket
+	45,  // 62: coral.colony.v1.QueryHistoricalMemoryProfileResponse.leak_candidates:type_name -> coral.colony.v1.MemoryLeakCandidate
+	94,  // 63: coral.colony.v1.MemoryGrowthBucket.start_time:type_name -> google.protobuf.Timestamp
+	102, // 64: coral.colony.v1.ColonyDeployCorrelationRequest.descriptor:type_name -> coral.agent.v1.CorrelationDescriptor
+	102, // 65: coral.colony.v1.ColonyListCorrelationsResponse.descriptors:type_name -> coral.agent.v1.CorrelationDescriptor
+	103, // 66: coral.colony.v1.ColonyListCoreDumpsResponse.dumps:type_name -> coral.agent.v1.CoreDumpInfo
+	104, // 67: coral.colony.v1.ColonyDescribeFunctionResponse.function:type_name -> coral.agent.v1.FunctionDescription
+	91,  // 68: coral.colony.v1.ProfileSchedule.interval:type_name -> google.protobuf.Duration
+	94,  // 69: coral.colony.v1.ProfileSchedule.created_at:type_name -> google.protobuf.Timestamp
+	94,  // 70: coral.colony.v1.ProfileSchedule.next_run_at:type_name -> google.protobuf.Timestamp
+	94,  // 71: coral.colony.v1.ProfileSchedule.last_run_at:type_name -> google.protobuf.Timestamp
+	94,  // 72: coral.colony.v1.ProfileRun.started_at:type_name -> google.protobuf.Timestamp
+	94,  // 73: coral.colony.v1.ProfileRun.finished_at:type_name -> google.protobuf.Timestamp
+	91,  // 74: coral.colony.v1.CreateProfileScheduleRequest.interval:type_name -> google.protobuf.Duration
+	57,  // 75: coral.colony.v1.CreateProfileScheduleResponse.schedule:type_name -> coral.colony.v1.ProfileSchedule
+	57,  // 76: coral.colony.v1.ListProfileSchedulesResponse.schedules:type_name -> coral.colony.v1.ProfileSchedule
+	58,  // 77: coral.colony.v1.ListProfileRunsResponse.runs:type_name -> coral.colony.v1.ProfileRun
+	58,  // 78: coral.colony.v1.GetProfileRunResponse.run:type_name -> coral.colony.v1.ProfileRun
+	91,  // 79: coral.colony.v1.CaptureHttpRequest.duration:type_name -> google.protobuf.Duration
+	94,  // 80: coral.colony.v1.CaptureHttpResponse.expires_at:type_name -> google.protobuf.Timestamp
+	95,  // 81: coral.colony.v1.CaptureHttpResponse.error_info:type_name -> coral.errors.v1.ErrorInfo
+	91,  // 82: coral.colony.v1.CaptureTlsRequest.duration:type_name -> google.protobuf.Duration
+	94,  // 83: coral.colony.v1.CaptureTlsResponse.expires_at:type_name -> google.protobuf.Timestamp
+	95,  // 84: coral.colony.v1.CaptureTlsResponse.error_info:type_name -> coral.errors.v1.ErrorInfo
+	94,  // 85: coral.colony.v1.TraceRuntimeResponse.start_time:type_name -> google.protobuf.Timestamp
+	94,  // 86: coral.colony.v1.TraceRuntimeResponse.end_time:type_name -> google.protobuf.Timestamp
+	105, // 87: coral.colony.v1.TraceRuntimeResponse.pauses:type_name -> coral.agent.v1.RuntimePause
+	106, // 88: coral.colony.v1.TraceRuntimeResponse.gc_cycles:type_name -> coral.agent.v1.GcCycle
+	107, // 89: coral.colony.v1.TraceRuntimeResponse.sched_latency:type_name -> coral.agent.v1.LatencyBucket
+	74,  // 90: coral.colony.v1.TraceRuntimeResponse.requests:type_name -> coral.colony.v1.RequestLatencyCorrelation
+	95,  // 91: coral.colony.v1.TraceRuntimeResponse.error_info:type_name -> coral.errors.v1.ErrorInfo
+	94,  // 92: coral.colony.v1.TraceSyscallsResponse.start_time:type_name -> google.protobuf.Timestamp
+	94,  // 93: coral.colony.v1.TraceSyscallsResponse.end_time:type_name -> google.protobuf.Timestamp
+	108, // 94: coral.colony.v1.TraceSyscallsResponse.syscalls:type_name -> coral.agent.v1.SyscallStats
+	95,  // 95: coral.colony.v1.TraceSyscallsResponse.error_info:type_name -> coral.errors.v1.ErrorInfo
+	94,  // 96: coral.colony.v1.ProfileIOResponse.start_time:type_name -> google.protobuf.Timestamp
+	94,  // 97: coral.colony.v1.ProfileIOResponse.end_time:type_name -> google.protobuf.Timestamp
+	109, // 98: coral.colony.v1.ProfileIOResponse.files:type_name -> coral.agent.v1.FileIO
+	110, // 99: coral.colony.v1.ProfileIOResponse.devices:type_name -> coral.agent.v1.DeviceIO
+	107, // 100: coral.colony.v1.ProfileIOResponse.read_latency:type_name -> coral.agent.v1.LatencyBucket
+	107, // 101: coral.colony.v1.ProfileIOResponse.write_latency:type_name -> coral.agent.v1.LatencyBucket
+	95,  // 102: coral.colony.v1.ProfileIOResponse.error_info:type_name -> coral.errors.v1.ErrorInfo
+	94,  // 103: coral.colony.v1.ProfileLocksResponse.start_time:type_name -> google.protobuf.Timestamp
+	94,  // 104: coral.colony.v1.ProfileLocksResponse.end_time:type_name -> google.protobuf.Timestamp
+	111, // 105: coral.colony.v1.ProfileLocksResponse.stacks:type_name -> coral.agent.v1.LockWaitStack
+	95,  // 106: coral.colony.v1.ProfileLocksResponse.error_info:type_name -> coral.errors.v1.ErrorInfo
+	94,  // 107: coral.colony.v1.ShellRecording.started_at:type_name -> google.protobuf.Timestamp
+	94,  // 108: coral.colony.v1.ShellRecording.ended_at:type_name -> google.protobuf.Timestamp
+	82,  // 109: coral.colony.v1.ShellRecording.events:type_name -> coral.colony.v1.ShellRecordingEvent
+	83,  // 110: coral.colony.v1.UploadShellRecordingRequest.recording:type_name -> coral.colony.v1.ShellRecording
+	94,  // 111: coral.colony.v1.ListShellRecordingsRequest.since:type_name -> google.protobuf.Timestamp
+	83,  // 112: coral.colony.v1.ListShellRecordingsResponse.recordings:type_name -> coral.colony.v1.ShellRecording
+	83,  // 113: coral.colony.v1.GetShellRecordingResponse.recording:type_name -> coral.colony.v1.ShellRecording
+	0,   // 114: coral.colony.v1.ColonyDebugService.AttachUprobe:input_type -> coral.colony.v1.AttachUprobeRequest
+	1,   // 115: coral.colony.v1.ColonyDebugService.UpdateProbeFilter:input_type -> coral.colony.v1.UpdateProbeFilterRequest
+	4,   // 116: coral.colony.v1.ColonyDebugService.DetachUprobe:input_type -> coral.colony.v1.DetachUprobeRequest
+	6,   // 117: coral.colony.v1.ColonyDebugService.QueryUprobeEvents:input_type -> coral.colony.v1.QueryUprobeEventsRequest
+	8,   // 118: coral.colony.v1.ColonyDebugService.IngestUprobeEvents:input_type -> coral.colony.v1.IngestUprobeEventsRequest
+	10,  // 119: coral.colony.v1.ColonyDebugService.ListDebugSessions:input_type -> coral.colony.v1.ListDebugSessionsRequest
+	13,  // 120: coral.colony.v1.ColonyDebugService.TraceRequestPath:input_type -> coral.colony.v1.TraceRequestPathRequest
+	15,  // 121: coral.colony.v1.ColonyDebugService.GetDebugResults:input_type -> coral.colony.v1.GetDebugResultsRequest
+	21,  // 122: coral.colony.v1.ColonyDebugService.QueryFunctions:input_type -> coral.colony.v1.QueryFunctionsRequest
+	28,  // 123: coral.colony.v1.ColonyDebugService.ProfileFunctions:input_type -> coral.colony.v1.ProfileFunctionsRequest
+	30,  // 124: coral.colony.v1.ColonyDebugService.CancelProfileFunctions:input_type -> coral.colony.v1.CancelProfileFunctionsRequest
+	36,  // 125: coral.colony.v1.ColonyDebugService.ProfileCPU:input_type -> coral.colony.v1.ProfileCPURequest
+	38,  // 126: coral.colony.v1.ColonyDebugService.QueryHistoricalCPUProfile:input_type -> coral.colony.v1.QueryHistoricalCPUProfileRequest
+	40,  // 127: coral.colony.v1.ColonyDebugService.ProfileMemory:input_type -> coral.colony.v1.ProfileMemoryRequest
+	78,  // 128: coral.colony.v1.ColonyDebugService.ProfileIO:input_type -> coral.colony.v1.ProfileIORequest
+	80,  // 129: coral.colony.v1.ColonyDebugService.ProfileLocks:input_type -> coral.colony.v1.ProfileLocksRequest
+	42,  // 130: coral.colony.v1.ColonyDebugService.QueryHistoricalMemoryProfile:input_type -> coral.colony.v1.QueryHistoricalMemoryProfileRequest
+	46,  // 131: coral.colony.v1.ColonyDebugService.DeployCorrelation:input_type -> coral.colony.v1.ColonyDeployCorrelationRequest
+	48,  // 132: coral.colony.v1.ColonyDebugService.RemoveCorrelation:input_type -> coral.colony.v1.ColonyRemoveCorrelationRequest
+	50,  // 133: coral.colony.v1.ColonyDebugService.ListCorrelations:input_type -> coral.colony.v1.ColonyListCorrelationsRequest
+	52,  // 134: coral.colony.v1.ColonyDebugService.ListCoreDumps:input_type -> coral.colony.v1.ColonyListCoreDumpsRequest
+	54,  // 135: coral.colony.v1.ColonyDebugService.DownloadCoreDump:input_type -> coral.colony.v1.ColonyDownloadCoreDumpRequest
+	59,  // 136: coral.colony.v1.ColonyDebugService.CreateProfileSchedule:input_type -> coral.colony.v1.CreateProfileScheduleRequest
+	61,  // 137: coral.colony.v1.ColonyDebugService.ListProfileSchedules:input_type -> coral.colony.v1.ListProfileSchedulesRequest
+	63,  // 138: coral.colony.v1.ColonyDebugService.DeleteProfileSchedule:input_type -> coral.colony.v1.DeleteProfileScheduleRequest
+	65,  // 139: coral.colony.v1.ColonyDebugService.ListProfileRuns:input_type -> coral.colony.v1.ListProfileRunsRequest
+	67,  // 140: coral.colony.v1.ColonyDebugService.GetProfileRun:input_type -> coral.colony.v1.GetProfileRunRequest
+	55,  // 141: coral.colony.v1.ColonyDebugService.DescribeFunction:input_type -> coral.colony.v1.ColonyDescribeFunctionRequest
+	69,  // 142: coral.colony.v1.ColonyDebugService.CaptureHttp:input_type -> coral.colony.v1.CaptureHttpRequest
+	71,  // 143: coral.colony.v1.ColonyDebugService.CaptureTls:input_type -> coral.colony.v1.CaptureTlsRequest
+	73,  // 144: coral.colony.v1.ColonyDebugService.TraceRuntime:input_type -> coral.colony.v1.TraceRuntimeRequest
+	76,  // 145: coral.colony.v1.ColonyDebugService.TraceSyscalls:input_type -> coral.colony.v1.TraceSyscallsRequest
+	84,  // 146: coral.colony.v1.ColonyDebugService.UploadShellRecording:input_type -> coral.colony.v1.UploadShellRecordingRequest
+	86,  // 147: coral.colony.v1.ColonyDebugService.ListShellRecordings:input_type -> coral.colony.v1.ListShellRecordingsRequest
+	88,  // 148: coral.colony.v1.ColonyDebugService.GetShellRecording:input_type -> coral.colony.v1.GetShellRecordingRequest
+	3,   // 149: coral.colony.v1.ColonyDebugService.AttachUprobe:output_type -> coral.colony.v1.AttachUprobeResponse
+	2,   // 150: coral.colony.v1.ColonyDebugService.UpdateProbeFilter:output_type -> coral.colony.v1.UpdateProbeFilterResponse
+	5,   // 151: coral.colony.v1.ColonyDebugService.DetachUprobe:output_type -> coral.colony.v1.DetachUprobeResponse
+	7,   // 152: coral.colony.v1.ColonyDebugService.QueryUprobeEvents:output_type -> coral.colony.v1.QueryUprobeEventsResponse
+	9,   // 153: coral.colony.v1.ColonyDebugService.IngestUprobeEvents:output_type -> coral.colony.v1.IngestUprobeEventsResponse
+	11,  // 154: coral.colony.v1.ColonyDebugService.ListDebugSessions:output_type -> coral.colony.v1.ListDebugSessionsResponse
+	14,  // 155: coral.colony.v1.ColonyDebugService.TraceRequestPath:output_type -> coral.colony.v1.TraceRequestPathResponse
+	16,  // 156: coral.colony.v1.ColonyDebugService.GetDebugResults:output_type -> coral.colony.v1.GetDebugResultsResponse
+	22,  // 157: coral.colony.v1.ColonyDebugService.QueryFunctions:output_type -> coral.colony.v1.QueryFunctionsResponse
+	29,  // 158: coral.colony.v1.ColonyDebugService.ProfileFunctions:output_type -> coral.colony.v1.ProfileFunctionsResponse
+	31,  // 159: coral.colony.v1.ColonyDebugService.CancelProfileFunctions:output_type -> coral.colony.v1.CancelProfileFunctionsResponse
+	37,  // 160: coral.colony.v1.ColonyDebugService.ProfileCPU:output_type -> coral.colony.v1.ProfileCPUResponse
+	39,  // 161: coral.colony.v1.ColonyDebugService.QueryHistoricalCPUProfile:output_type -> coral.colony.v1.QueryHistoricalCPUProfileResponse
+	41,  // 162: coral.colony.v1.ColonyDebugService.ProfileMemory:output_type -> coral.colony.v1.ProfileMemoryResponse
+	79,  // 163: coral.colony.v1.ColonyDebugService.ProfileIO:output_type -> coral.colony.v1.ProfileIOResponse
+	81,  // 164: coral.colony.v1.ColonyDebugService.ProfileLocks:output_type -> coral.colony.v1.ProfileLocksResponse
+	43,  // 165: coral.colony.v1.ColonyDebugService.QueryHistoricalMemoryProfile:output_type -> coral.colony.v1.QueryHistoricalMemoryProfileResponse
+	47,  // 166: coral.colony.v1.ColonyDebugService.DeployCorrelation:output_type -> coral.colony.v1.ColonyDeployCorrelationResponse
+	49,  // 167: coral.colony.v1.ColonyDebugService.RemoveCorrelation:output_type -> coral.colony.v1.ColonyRemoveCorrelationResponse
+	51,  // 168: coral.colony.v1.ColonyDebugService.ListCorrelations:output_type -> coral.colony.v1.ColonyListCorrelationsResponse
+	53,  // 169: coral.colony.v1.ColonyDebugService.ListCoreDumps:output_type -> coral.colony.v1.ColonyListCoreDumpsResponse
+	112, // 170: coral.colony.v1.ColonyDebugService.DownloadCoreDump:output_type -> coral.agent.v1.CoreDumpChunk
+	60,  // 171: coral.colony.v1.ColonyDebugService.CreateProfileSchedule:output_type -> coral.colony.v1.CreateProfileScheduleResponse
+	62,  // 172: coral.colony.v1.ColonyDebugService.ListProfileSchedules:output_type -> coral.colony.v1.ListProfileSchedulesResponse
+	64,  // 173: coral.colony.v1.ColonyDebugService.DeleteProfileSchedule:output_type -> coral.colony.v1.DeleteProfileScheduleResponse
+	66,  // 174: coral.colony.v1.ColonyDebugService.ListProfileRuns:output_type -> coral.colony.v1.ListProfileRunsResponse
+	68,  // 175: coral.colony.v1.ColonyDebugService.GetProfileRun:output_type -> coral.colony.v1.GetProfileRunResponse
+	56,  // 176: coral.colony.v1.ColonyDebugService.DescribeFunction:output_type -> coral.colony.v1.ColonyDescribeFunctionResponse
+	70,  // 177: coral.colony.v1.ColonyDebugService.CaptureHttp:output_type -> coral.colony.v1.CaptureHttpResponse
+	72,  // 178: coral.colony.v1.ColonyDebugService.CaptureTls:output_type -> coral.colony.v1.CaptureTlsResponse
+	75,  // 179: coral.colony.v1.ColonyDebugService.TraceRuntime:output_type -> coral.colony.v1.TraceRuntimeResponse
+	77,  // 180: coral.colony.v1.ColonyDebugService.TraceSyscalls:output_type -> coral.colony.v1.TraceSyscallsResponse
+	85,  // 181: coral.colony.v1.ColonyDebugService.UploadShellRecording:output_type -> coral.colony.v1.UploadShellRecordingResponse
+	87,  // 182: coral.colony.v1.ColonyDebugService.ListShellRecordings:output_type -> coral.colony.v1.ListShellRecordingsResponse
+	89,  // 183: coral.colony.v1.ColonyDebugService.GetShellRecording:output_type -> coral.colony.v1.GetShellRecordingResponse
+	149, // [149:184] is the sub-list for method output_type
+	114, // [114:149] is the sub-list for method input_type
+	114, // [114:114] is the sub-list for extension type_name
+	114, // [114:114] is the sub-list for extension extendee
+	0,   // [0:114] is the sub-list for field type_name
 }
 
 func init() { file_coral_colony_v1_debug_proto_init() }
@@ -3715,7 +7347,7 @@ func file_coral_colony_v1_debug_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_coral_colony_v1_debug_proto_rawDesc), len(file_coral_colony_v1_debug_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   47,
+			NumMessages:   91,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
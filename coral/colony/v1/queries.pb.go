@@ -14,6 +14,7 @@ import (
 	v1 "github.com/coral-mesh/coral/coral/agent/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -202,7 +203,22 @@ type QueryUnifiedSummaryRequest struct {
 	// Include CPU profiling hotspots in summary (RFD 074). Default: true.
 	IncludeProfiling bool `protobuf:"varint,3,opt,name=include_profiling,json=includeProfiling,proto3" json:"include_profiling,omitempty"`
 	// Number of top CPU hotspots to include (RFD 074). Default: 5, max: 20.
-	TopKHotspots  int32 `protobuf:"varint,4,opt,name=top_k_hotspots,json=topKHotspots,proto3" json:"top_k_hotspots,omitempty"`
+	TopKHotspots int32 `protobuf:"varint,4,opt,name=top_k_hotspots,json=topKHotspots,proto3" json:"top_k_hotspots,omitempty"`
+	// Also query child colonies (federation.children in the colony config).
+	Federated bool `protobuf:"varint,5,opt,name=federated,proto3" json:"federated,omitempty"`
+	// Compare each service's metrics with its trailing baseline.
+	IncludeBaseline bool `protobuf:"varint,6,opt,name=include_baseline,json=includeBaseline,proto3" json:"include_baseline,omitempty"`
+	// Length of the trailing baseline preceding time_range (e.g., "1h").
+	// Default: 1h.
+	BaselineWindow string `protobuf:"bytes,7,opt,name=baseline_window,json=baselineWindow,proto3" json:"baseline_window,omitempty"`
+	// Deviation from the baseline mean, in standard deviations, above which a
+	// metric is flagged as anomalous. Default: 3.
+	AnomalySigma float64 `protobuf:"fixed64,8,opt,name=anomaly_sigma,json=anomalySigma,proto3" json:"anomaly_sigma,omitempty"`
+	// Only summarize services whose labels match this selector, see
+	// ListServicesRequest.selector.
+	Selector string `protobuf:"bytes,9,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Only summarize the services of this service group.
+	Group         string `protobuf:"bytes,10,opt,name=group,proto3" json:"group,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -265,6 +281,48 @@ func (x *QueryUnifiedSummaryRequest) GetTopKHotspots() int32 {
 	return 0
 }
 
+func (x *QueryUnifiedSummaryRequest) GetFederated() bool {
+	if x != nil {
+		return x.Federated
+	}
+	return false
+}
+
+func (x *QueryUnifiedSummaryRequest) GetIncludeBaseline() bool {
+	if x != nil {
+		return x.IncludeBaseline
+	}
+	return false
+}
+
+func (x *QueryUnifiedSummaryRequest) GetBaselineWindow() string {
+	if x != nil {
+		return x.BaselineWindow
+	}
+	return ""
+}
+
+func (x *QueryUnifiedSummaryRequest) GetAnomalySigma() float64 {
+	if x != nil {
+		return x.AnomalySigma
+	}
+	return 0
+}
+
+func (x *QueryUnifiedSummaryRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *QueryUnifiedSummaryRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
 type UnifiedSummaryResult struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Service name.
@@ -293,7 +351,12 @@ type UnifiedSummaryResult struct {
 	// Deployment context (RFD 074).
 	Deployment *DeploymentContext `protobuf:"bytes,15,opt,name=deployment,proto3" json:"deployment,omitempty"`
 	// Regression indicators compared to previous deployment (RFD 074).
-	Regressions   []*RegressionIndicator `protobuf:"bytes,16,rep,name=regressions,proto3" json:"regressions,omitempty"`
+	Regressions []*RegressionIndicator `protobuf:"bytes,16,rep,name=regressions,proto3" json:"regressions,omitempty"`
+	// Colony the service reports to. Set in federated requests.
+	ColonyId string `protobuf:"bytes,17,opt,name=colony_id,json=colonyId,proto3" json:"colony_id,omitempty"`
+	// Metrics compared with the trailing baseline. Set when include_baseline
+	// is requested.
+	Baseline      *ServiceBaseline `protobuf:"bytes,18,opt,name=baseline,proto3" json:"baseline,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -440,17 +503,186 @@ func (x *UnifiedSummaryResult) GetRegressions() []*RegressionIndicator {
 	return nil
 }
 
-type QueryUnifiedSummaryResponse struct {
+func (x *UnifiedSummaryResult) GetColonyId() string {
+	if x != nil {
+		return x.ColonyId
+	}
+	return ""
+}
+
+func (x *UnifiedSummaryResult) GetBaseline() *ServiceBaseline {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+// Request metrics of a service over time_range compared with the trailing
+// baseline, computed over intervals of the same length.
+type ServiceBaseline struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	RequestsPerSecond *MetricBaseline        `protobuf:"bytes,1,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	P95LatencyMs      *MetricBaseline        `protobuf:"bytes,2,opt,name=p95_latency_ms,json=p95LatencyMs,proto3" json:"p95_latency_ms,omitempty"`
+	ErrorRate         *MetricBaseline        `protobuf:"bytes,3,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`
+	// Number of baseline intervals. Anomalies are only flagged with enough
+	// intervals to estimate the deviation.
+	Intervals     int32 `protobuf:"varint,4,opt,name=intervals,proto3" json:"intervals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceBaseline) Reset() {
+	*x = ServiceBaseline{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceBaseline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceBaseline) ProtoMessage() {}
+
+func (x *ServiceBaseline) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceBaseline.ProtoReflect.Descriptor instead.
+func (*ServiceBaseline) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ServiceBaseline) GetRequestsPerSecond() *MetricBaseline {
+	if x != nil {
+		return x.RequestsPerSecond
+	}
+	return nil
+}
+
+func (x *ServiceBaseline) GetP95LatencyMs() *MetricBaseline {
+	if x != nil {
+		return x.P95LatencyMs
+	}
+	return nil
+}
+
+func (x *ServiceBaseline) GetErrorRate() *MetricBaseline {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return nil
+}
+
+func (x *ServiceBaseline) GetIntervals() int32 {
+	if x != nil {
+		return x.Intervals
+	}
+	return 0
+}
+
+// A metric compared with its baseline.
+type MetricBaseline struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Structured summary results.
-	Summaries     []*UnifiedSummaryResult `protobuf:"bytes,1,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	// Value over time_range.
+	Current float64 `protobuf:"fixed64,1,opt,name=current,proto3" json:"current,omitempty"`
+	// Mean and standard deviation over the baseline intervals.
+	Mean   float64 `protobuf:"fixed64,2,opt,name=mean,proto3" json:"mean,omitempty"`
+	Stddev float64 `protobuf:"fixed64,3,opt,name=stddev,proto3" json:"stddev,omitempty"`
+	// (current - mean) / stddev.
+	Sigma float64 `protobuf:"fixed64,4,opt,name=sigma,proto3" json:"sigma,omitempty"`
+	// Set when |sigma| exceeds anomaly_sigma.
+	Anomalous     bool `protobuf:"varint,5,opt,name=anomalous,proto3" json:"anomalous,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *MetricBaseline) Reset() {
+	*x = MetricBaseline{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricBaseline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricBaseline) ProtoMessage() {}
+
+func (x *MetricBaseline) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricBaseline.ProtoReflect.Descriptor instead.
+func (*MetricBaseline) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MetricBaseline) GetCurrent() float64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *MetricBaseline) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *MetricBaseline) GetStddev() float64 {
+	if x != nil {
+		return x.Stddev
+	}
+	return 0
+}
+
+func (x *MetricBaseline) GetSigma() float64 {
+	if x != nil {
+		return x.Sigma
+	}
+	return 0
+}
+
+func (x *MetricBaseline) GetAnomalous() bool {
+	if x != nil {
+		return x.Anomalous
+	}
+	return false
+}
+
+type QueryUnifiedSummaryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Structured summary results.
+	Summaries []*UnifiedSummaryResult `protobuf:"bytes,1,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	// Child colonies that could not be queried in a federated request.
+	FederationErrors []*FederationError `protobuf:"bytes,2,rep,name=federation_errors,json=federationErrors,proto3" json:"federation_errors,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
 func (x *QueryUnifiedSummaryResponse) Reset() {
 	*x = QueryUnifiedSummaryResponse{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[2]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -462,7 +694,7 @@ func (x *QueryUnifiedSummaryResponse) String() string {
 func (*QueryUnifiedSummaryResponse) ProtoMessage() {}
 
 func (x *QueryUnifiedSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[2]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -475,7 +707,7 @@ func (x *QueryUnifiedSummaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryUnifiedSummaryResponse.ProtoReflect.Descriptor instead.
 func (*QueryUnifiedSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{2}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *QueryUnifiedSummaryResponse) GetSummaries() []*UnifiedSummaryResult {
@@ -485,6 +717,13 @@ func (x *QueryUnifiedSummaryResponse) GetSummaries() []*UnifiedSummaryResult {
 	return nil
 }
 
+func (x *QueryUnifiedSummaryResponse) GetFederationErrors() []*FederationError {
+	if x != nil {
+		return x.FederationErrors
+	}
+	return nil
+}
+
 // CPU profiling summary with top-K hotspots (RFD 074).
 type ProfilingSummary struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -508,7 +747,7 @@ type ProfilingSummary struct {
 
 func (x *ProfilingSummary) Reset() {
 	*x = ProfilingSummary{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[3]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -520,7 +759,7 @@ func (x *ProfilingSummary) String() string {
 func (*ProfilingSummary) ProtoMessage() {}
 
 func (x *ProfilingSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[3]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,7 +772,7 @@ func (x *ProfilingSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProfilingSummary.ProtoReflect.Descriptor instead.
 func (*ProfilingSummary) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{3}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ProfilingSummary) GetTopCpuHotspots() []*CPUHotspot {
@@ -604,7 +843,7 @@ type MemoryHotspot struct {
 
 func (x *MemoryHotspot) Reset() {
 	*x = MemoryHotspot{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[4]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -616,7 +855,7 @@ func (x *MemoryHotspot) String() string {
 func (*MemoryHotspot) ProtoMessage() {}
 
 func (x *MemoryHotspot) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[4]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -629,7 +868,7 @@ func (x *MemoryHotspot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MemoryHotspot.ProtoReflect.Descriptor instead.
 func (*MemoryHotspot) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{4}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *MemoryHotspot) GetRank() int32 {
@@ -687,7 +926,7 @@ type CPUHotspot struct {
 
 func (x *CPUHotspot) Reset() {
 	*x = CPUHotspot{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[5]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -699,7 +938,7 @@ func (x *CPUHotspot) String() string {
 func (*CPUHotspot) ProtoMessage() {}
 
 func (x *CPUHotspot) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[5]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -712,7 +951,7 @@ func (x *CPUHotspot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CPUHotspot.ProtoReflect.Descriptor instead.
 func (*CPUHotspot) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{5}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CPUHotspot) GetRank() int32 {
@@ -765,7 +1004,7 @@ type DeploymentContext struct {
 
 func (x *DeploymentContext) Reset() {
 	*x = DeploymentContext{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[6]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -777,7 +1016,7 @@ func (x *DeploymentContext) String() string {
 func (*DeploymentContext) ProtoMessage() {}
 
 func (x *DeploymentContext) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[6]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -790,7 +1029,7 @@ func (x *DeploymentContext) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeploymentContext.ProtoReflect.Descriptor instead.
 func (*DeploymentContext) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{6}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeploymentContext) GetBuildId() string {
@@ -833,7 +1072,7 @@ type RegressionIndicator struct {
 
 func (x *RegressionIndicator) Reset() {
 	*x = RegressionIndicator{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[7]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -845,7 +1084,7 @@ func (x *RegressionIndicator) String() string {
 func (*RegressionIndicator) ProtoMessage() {}
 
 func (x *RegressionIndicator) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[7]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -858,7 +1097,7 @@ func (x *RegressionIndicator) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegressionIndicator.ProtoReflect.Descriptor instead.
 func (*RegressionIndicator) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{7}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RegressionIndicator) GetType() RegressionType {
@@ -909,14 +1148,22 @@ type QueryUnifiedTracesRequest struct {
 	// Optional: filter slow traces (milliseconds).
 	MinDurationMs int32 `protobuf:"varint,5,opt,name=min_duration_ms,json=minDurationMs,proto3" json:"min_duration_ms,omitempty"`
 	// Maximum traces to return.
-	MaxTraces     int32 `protobuf:"varint,6,opt,name=max_traces,json=maxTraces,proto3" json:"max_traces,omitempty"`
+	MaxTraces int32 `protobuf:"varint,6,opt,name=max_traces,json=maxTraces,proto3" json:"max_traces,omitempty"`
+	// Also query child colonies (federation.children in the colony config).
+	// Spans are labeled with the "colony.id" attribute.
+	Federated bool `protobuf:"varint,7,opt,name=federated,proto3" json:"federated,omitempty"`
+	// Only include spans of services whose labels match this selector, see
+	// ListServicesRequest.selector.
+	Selector string `protobuf:"bytes,8,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Only include spans of the services of this service group.
+	Group         string `protobuf:"bytes,9,opt,name=group,proto3" json:"group,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QueryUnifiedTracesRequest) Reset() {
 	*x = QueryUnifiedTracesRequest{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[8]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -928,7 +1175,7 @@ func (x *QueryUnifiedTracesRequest) String() string {
 func (*QueryUnifiedTracesRequest) ProtoMessage() {}
 
 func (x *QueryUnifiedTracesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[8]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -941,7 +1188,7 @@ func (x *QueryUnifiedTracesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryUnifiedTracesRequest.ProtoReflect.Descriptor instead.
 func (*QueryUnifiedTracesRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{8}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *QueryUnifiedTracesRequest) GetService() string {
@@ -986,19 +1233,42 @@ func (x *QueryUnifiedTracesRequest) GetMaxTraces() int32 {
 	return 0
 }
 
+func (x *QueryUnifiedTracesRequest) GetFederated() bool {
+	if x != nil {
+		return x.Federated
+	}
+	return false
+}
+
+func (x *QueryUnifiedTracesRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *QueryUnifiedTracesRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
 type QueryUnifiedTracesResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Structured trace spans (eBPF + OTLP).
 	Spans []*v1.EbpfTraceSpan `protobuf:"bytes,1,rep,name=spans,proto3" json:"spans,omitempty"`
 	// Total traces returned.
-	TotalTraces   int32 `protobuf:"varint,2,opt,name=total_traces,json=totalTraces,proto3" json:"total_traces,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	TotalTraces int32 `protobuf:"varint,2,opt,name=total_traces,json=totalTraces,proto3" json:"total_traces,omitempty"`
+	// Child colonies that could not be queried in a federated request.
+	FederationErrors []*FederationError `protobuf:"bytes,3,rep,name=federation_errors,json=federationErrors,proto3" json:"federation_errors,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *QueryUnifiedTracesResponse) Reset() {
 	*x = QueryUnifiedTracesResponse{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[9]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1010,7 +1280,7 @@ func (x *QueryUnifiedTracesResponse) String() string {
 func (*QueryUnifiedTracesResponse) ProtoMessage() {}
 
 func (x *QueryUnifiedTracesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[9]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1023,7 +1293,7 @@ func (x *QueryUnifiedTracesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryUnifiedTracesResponse.ProtoReflect.Descriptor instead.
 func (*QueryUnifiedTracesResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{9}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *QueryUnifiedTracesResponse) GetSpans() []*v1.EbpfTraceSpan {
@@ -1040,6 +1310,13 @@ func (x *QueryUnifiedTracesResponse) GetTotalTraces() int32 {
 	return 0
 }
 
+func (x *QueryUnifiedTracesResponse) GetFederationErrors() []*FederationError {
+	if x != nil {
+		return x.FederationErrors
+	}
+	return nil
+}
+
 type QueryUnifiedMetricsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Optional: filter by service.
@@ -1056,13 +1333,21 @@ type QueryUnifiedMetricsRequest struct {
 	HttpMethod string `protobuf:"bytes,6,opt,name=http_method,json=httpMethod,proto3" json:"http_method,omitempty"`
 	// Optional: HTTP status code range filter.
 	StatusCodeRange string `protobuf:"bytes,7,opt,name=status_code_range,json=statusCodeRange,proto3" json:"status_code_range,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Also query child colonies (federation.children in the colony config).
+	// Metrics are labeled with the "colony.id" attribute.
+	Federated bool `protobuf:"varint,8,opt,name=federated,proto3" json:"federated,omitempty"`
+	// Only include metrics of services whose labels match this selector, see
+	// ListServicesRequest.selector.
+	Selector string `protobuf:"bytes,9,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Only include metrics of the services of this service group.
+	Group         string `protobuf:"bytes,10,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QueryUnifiedMetricsRequest) Reset() {
 	*x = QueryUnifiedMetricsRequest{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[10]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1074,7 +1359,7 @@ func (x *QueryUnifiedMetricsRequest) String() string {
 func (*QueryUnifiedMetricsRequest) ProtoMessage() {}
 
 func (x *QueryUnifiedMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[10]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1087,7 +1372,7 @@ func (x *QueryUnifiedMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryUnifiedMetricsRequest.ProtoReflect.Descriptor instead.
 func (*QueryUnifiedMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{10}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *QueryUnifiedMetricsRequest) GetService() string {
@@ -1139,6 +1424,27 @@ func (x *QueryUnifiedMetricsRequest) GetStatusCodeRange() string {
 	return ""
 }
 
+func (x *QueryUnifiedMetricsRequest) GetFederated() bool {
+	if x != nil {
+		return x.Federated
+	}
+	return false
+}
+
+func (x *QueryUnifiedMetricsRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *QueryUnifiedMetricsRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
 type QueryUnifiedMetricsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Structured HTTP metrics (eBPF + OTLP).
@@ -1148,14 +1454,16 @@ type QueryUnifiedMetricsResponse struct {
 	// Structured SQL metrics (eBPF + OTLP).
 	SqlMetrics []*v1.EbpfSqlMetric `protobuf:"bytes,3,rep,name=sql_metrics,json=sqlMetrics,proto3" json:"sql_metrics,omitempty"`
 	// Total metrics returned.
-	TotalMetrics  int32 `protobuf:"varint,4,opt,name=total_metrics,json=totalMetrics,proto3" json:"total_metrics,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	TotalMetrics int32 `protobuf:"varint,4,opt,name=total_metrics,json=totalMetrics,proto3" json:"total_metrics,omitempty"`
+	// Child colonies that could not be queried in a federated request.
+	FederationErrors []*FederationError `protobuf:"bytes,5,rep,name=federation_errors,json=federationErrors,proto3" json:"federation_errors,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *QueryUnifiedMetricsResponse) Reset() {
 	*x = QueryUnifiedMetricsResponse{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[11]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1167,7 +1475,7 @@ func (x *QueryUnifiedMetricsResponse) String() string {
 func (*QueryUnifiedMetricsResponse) ProtoMessage() {}
 
 func (x *QueryUnifiedMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[11]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1180,7 +1488,7 @@ func (x *QueryUnifiedMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryUnifiedMetricsResponse.ProtoReflect.Descriptor instead.
 func (*QueryUnifiedMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{11}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *QueryUnifiedMetricsResponse) GetHttpMetrics() []*v1.EbpfHttpMetric {
@@ -1211,9 +1519,72 @@ func (x *QueryUnifiedMetricsResponse) GetTotalMetrics() int32 {
 	return 0
 }
 
-type QueryUnifiedLogsRequest struct {
+func (x *QueryUnifiedMetricsResponse) GetFederationErrors() []*FederationError {
+	if x != nil {
+		return x.FederationErrors
+	}
+	return nil
+}
+
+// FederationError reports a child colony that failed to answer a federated
+// request. Results from the other colonies are still returned.
+type FederationError struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Optional: filter by service.
+	// Child colony ID.
+	ColonyId string `protobuf:"bytes,1,opt,name=colony_id,json=colonyId,proto3" json:"colony_id,omitempty"`
+	// Error returned by, or connecting to, the child colony.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FederationError) Reset() {
+	*x = FederationError{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FederationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FederationError) ProtoMessage() {}
+
+func (x *FederationError) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FederationError.ProtoReflect.Descriptor instead.
+func (*FederationError) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FederationError) GetColonyId() string {
+	if x != nil {
+		return x.ColonyId
+	}
+	return ""
+}
+
+func (x *FederationError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type QueryUnifiedLogsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: filter by service.
 	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
 	// Time range (default: "1h").
 	TimeRange string `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
@@ -1229,7 +1600,7 @@ type QueryUnifiedLogsRequest struct {
 
 func (x *QueryUnifiedLogsRequest) Reset() {
 	*x = QueryUnifiedLogsRequest{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[12]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1241,7 +1612,7 @@ func (x *QueryUnifiedLogsRequest) String() string {
 func (*QueryUnifiedLogsRequest) ProtoMessage() {}
 
 func (x *QueryUnifiedLogsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[12]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1254,7 +1625,7 @@ func (x *QueryUnifiedLogsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryUnifiedLogsRequest.ProtoReflect.Descriptor instead.
 func (*QueryUnifiedLogsRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{12}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *QueryUnifiedLogsRequest) GetService() string {
@@ -1294,37 +1665,1139 @@ func (x *QueryUnifiedLogsRequest) GetMaxLogs() int32 {
 
 type UnifiedLogEntry struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Timestamp (Unix milliseconds).
-	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	// Service name.
-	ServiceName string `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
-	// Log level: debug, info, warn, error.
-	Level string `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`
-	// Log message.
-	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	// Associated trace ID (if any).
-	TraceId string `protobuf:"bytes,5,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
-	// Additional attributes.
-	Attributes    map[string]string `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Timestamp (Unix milliseconds).
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Service name.
+	ServiceName string `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	// Log level: debug, info, warn, error.
+	Level string `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`
+	// Log message.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Associated trace ID (if any).
+	TraceId string `protobuf:"bytes,5,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// Additional attributes.
+	Attributes    map[string]string `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnifiedLogEntry) Reset() {
+	*x = UnifiedLogEntry{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnifiedLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnifiedLogEntry) ProtoMessage() {}
+
+func (x *UnifiedLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnifiedLogEntry.ProtoReflect.Descriptor instead.
+func (*UnifiedLogEntry) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UnifiedLogEntry) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *UnifiedLogEntry) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *UnifiedLogEntry) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *UnifiedLogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UnifiedLogEntry) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *UnifiedLogEntry) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type QueryUnifiedLogsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Structured log entries.
+	Logs []*UnifiedLogEntry `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	// Total logs returned.
+	TotalLogs     int32 `protobuf:"varint,2,opt,name=total_logs,json=totalLogs,proto3" json:"total_logs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryUnifiedLogsResponse) Reset() {
+	*x = QueryUnifiedLogsResponse{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryUnifiedLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryUnifiedLogsResponse) ProtoMessage() {}
+
+func (x *QueryUnifiedLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryUnifiedLogsResponse.ProtoReflect.Descriptor instead.
+func (*QueryUnifiedLogsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *QueryUnifiedLogsResponse) GetLogs() []*UnifiedLogEntry {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *QueryUnifiedLogsResponse) GetTotalLogs() int32 {
+	if x != nil {
+		return x.TotalLogs
+	}
+	return 0
+}
+
+type CompareDeploymentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service to compare.
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Deployment time: the end of the baseline window and the start of the
+	// current one (default: when the service's latest build was first seen).
+	DeployTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deploy_time,json=deployTime,proto3" json:"deploy_time,omitempty"`
+	// Length of each window (default: "30m"). The current window ends at the
+	// latest now.
+	Window        string `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareDeploymentsRequest) Reset() {
+	*x = CompareDeploymentsRequest{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareDeploymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareDeploymentsRequest) ProtoMessage() {}
+
+func (x *CompareDeploymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareDeploymentsRequest.ProtoReflect.Descriptor instead.
+func (*CompareDeploymentsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CompareDeploymentsRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *CompareDeploymentsRequest) GetDeployTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeployTime
+	}
+	return nil
+}
+
+func (x *CompareDeploymentsRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+// DeploymentWindowStats are the request metrics of a service over one window.
+type DeploymentWindowStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	RequestCount  int64                  `protobuf:"varint,3,opt,name=request_count,json=requestCount,proto3" json:"request_count,omitempty"`
+	ErrorRate     float64                `protobuf:"fixed64,4,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"` // Percentage of failed requests.
+	P50Ms         float64                `protobuf:"fixed64,5,opt,name=p50_ms,json=p50Ms,proto3" json:"p50_ms,omitempty"`
+	P95Ms         float64                `protobuf:"fixed64,6,opt,name=p95_ms,json=p95Ms,proto3" json:"p95_ms,omitempty"`
+	P99Ms         float64                `protobuf:"fixed64,7,opt,name=p99_ms,json=p99Ms,proto3" json:"p99_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentWindowStats) Reset() {
+	*x = DeploymentWindowStats{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentWindowStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentWindowStats) ProtoMessage() {}
+
+func (x *DeploymentWindowStats) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentWindowStats.ProtoReflect.Descriptor instead.
+func (*DeploymentWindowStats) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeploymentWindowStats) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *DeploymentWindowStats) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *DeploymentWindowStats) GetRequestCount() int64 {
+	if x != nil {
+		return x.RequestCount
+	}
+	return 0
+}
+
+func (x *DeploymentWindowStats) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *DeploymentWindowStats) GetP50Ms() float64 {
+	if x != nil {
+		return x.P50Ms
+	}
+	return 0
+}
+
+func (x *DeploymentWindowStats) GetP95Ms() float64 {
+	if x != nil {
+		return x.P95Ms
+	}
+	return 0
+}
+
+func (x *DeploymentWindowStats) GetP99Ms() float64 {
+	if x != nil {
+		return x.P99Ms
+	}
+	return 0
+}
+
+// NewErrorSignature is a kind of failed request seen only after the
+// deployment, such as "HTTP 503 POST /checkout".
+type NewErrorSignature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Signature     string                 `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewErrorSignature) Reset() {
+	*x = NewErrorSignature{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewErrorSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewErrorSignature) ProtoMessage() {}
+
+func (x *NewErrorSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewErrorSignature.ProtoReflect.Descriptor instead.
+func (*NewErrorSignature) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *NewErrorSignature) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *NewErrorSignature) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CompareDeploymentsResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Service    string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	DeployTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deploy_time,json=deployTime,proto3" json:"deploy_time,omitempty"`
+	// Build deployed at deploy_time, when known.
+	BuildId  string                 `protobuf:"bytes,3,opt,name=build_id,json=buildId,proto3" json:"build_id,omitempty"`
+	Baseline *DeploymentWindowStats `protobuf:"bytes,4,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	Current  *DeploymentWindowStats `protobuf:"bytes,5,opt,name=current,proto3" json:"current,omitempty"`
+	// CPU hotspots that appeared, grew or shrank (RFD 074).
+	CpuRegressions []*RegressionIndicator `protobuf:"bytes,6,rep,name=cpu_regressions,json=cpuRegressions,proto3" json:"cpu_regressions,omitempty"`
+	// Kinds of errors absent from the baseline window.
+	NewErrors []*NewErrorSignature `protobuf:"bytes,7,rep,name=new_errors,json=newErrors,proto3" json:"new_errors,omitempty"`
+	// Human-readable regressions, most severe first.
+	Findings []string `protobuf:"bytes,8,rep,name=findings,proto3" json:"findings,omitempty"`
+	// Whether any regression was found.
+	Regressed     bool `protobuf:"varint,9,opt,name=regressed,proto3" json:"regressed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareDeploymentsResponse) Reset() {
+	*x = CompareDeploymentsResponse{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareDeploymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareDeploymentsResponse) ProtoMessage() {}
+
+func (x *CompareDeploymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareDeploymentsResponse.ProtoReflect.Descriptor instead.
+func (*CompareDeploymentsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CompareDeploymentsResponse) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *CompareDeploymentsResponse) GetDeployTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeployTime
+	}
+	return nil
+}
+
+func (x *CompareDeploymentsResponse) GetBuildId() string {
+	if x != nil {
+		return x.BuildId
+	}
+	return ""
+}
+
+func (x *CompareDeploymentsResponse) GetBaseline() *DeploymentWindowStats {
+	if x != nil {
+		return x.Baseline
+	}
+	return nil
+}
+
+func (x *CompareDeploymentsResponse) GetCurrent() *DeploymentWindowStats {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *CompareDeploymentsResponse) GetCpuRegressions() []*RegressionIndicator {
+	if x != nil {
+		return x.CpuRegressions
+	}
+	return nil
+}
+
+func (x *CompareDeploymentsResponse) GetNewErrors() []*NewErrorSignature {
+	if x != nil {
+		return x.NewErrors
+	}
+	return nil
+}
+
+func (x *CompareDeploymentsResponse) GetFindings() []string {
+	if x != nil {
+		return x.Findings
+	}
+	return nil
+}
+
+func (x *CompareDeploymentsResponse) GetRegressed() bool {
+	if x != nil {
+		return x.Regressed
+	}
+	return false
+}
+
+type QueryErrorsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: only errors of this service.
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Time range (e.g., "1h", "30m"; default: "1h").
+	TimeRange string `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	// Number of buckets the time range is split into for the trend of each
+	// group (default: 20, max: 100).
+	TrendBuckets int32 `protobuf:"varint,3,opt,name=trend_buckets,json=trendBuckets,proto3" json:"trend_buckets,omitempty"`
+	// Maximum groups to return, most frequent first (default: 20).
+	MaxGroups     int32 `protobuf:"varint,4,opt,name=max_groups,json=maxGroups,proto3" json:"max_groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryErrorsRequest) Reset() {
+	*x = QueryErrorsRequest{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryErrorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryErrorsRequest) ProtoMessage() {}
+
+func (x *QueryErrorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryErrorsRequest.ProtoReflect.Descriptor instead.
+func (*QueryErrorsRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *QueryErrorsRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *QueryErrorsRequest) GetTimeRange() string {
+	if x != nil {
+		return x.TimeRange
+	}
+	return ""
+}
+
+func (x *QueryErrorsRequest) GetTrendBuckets() int32 {
+	if x != nil {
+		return x.TrendBuckets
+	}
+	return 0
+}
+
+func (x *QueryErrorsRequest) GetMaxGroups() int32 {
+	if x != nil {
+		return x.MaxGroups
+	}
+	return 0
+}
+
+// ErrorGroup is a kind of error of a service and how often it occurred.
+type ErrorGroup struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Service string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Where the errors were seen: "http" (5xx responses), "grpc" (non-OK
+	// statuses) or "go" (errors returned by functions in debug sessions).
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// HTTP method and route, gRPC method, or Go function.
+	Route string `protobuf:"bytes,3,opt,name=route,proto3" json:"route,omitempty"`
+	// "HTTP 503", "gRPC Unavailable", or the Go error message with IDs,
+	// addresses and numbers replaced by placeholders.
+	Signature string `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	// One of the Go error messages of the group, verbatim.
+	Example   string                 `protobuf:"bytes,5,opt,name=example,proto3" json:"example,omitempty"`
+	Count     int64                  `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	FirstSeen *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	// Errors per trend bucket, oldest first.
+	Trend         []int64 `protobuf:"varint,9,rep,packed,name=trend,proto3" json:"trend,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorGroup) Reset() {
+	*x = ErrorGroup{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorGroup) ProtoMessage() {}
+
+func (x *ErrorGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorGroup.ProtoReflect.Descriptor instead.
+func (*ErrorGroup) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ErrorGroup) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *ErrorGroup) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ErrorGroup) GetRoute() string {
+	if x != nil {
+		return x.Route
+	}
+	return ""
+}
+
+func (x *ErrorGroup) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *ErrorGroup) GetExample() string {
+	if x != nil {
+		return x.Example
+	}
+	return ""
+}
+
+func (x *ErrorGroup) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ErrorGroup) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *ErrorGroup) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *ErrorGroup) GetTrend() []int64 {
+	if x != nil {
+		return x.Trend
+	}
+	return nil
+}
+
+type QueryErrorsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Error groups, most frequent first.
+	Groups    []*ErrorGroup          `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Length of each trend bucket in milliseconds.
+	BucketMs int64 `protobuf:"varint,4,opt,name=bucket_ms,json=bucketMs,proto3" json:"bucket_ms,omitempty"`
+	// Errors and groups over the time range, including groups beyond
+	// max_groups.
+	TotalErrors   int64 `protobuf:"varint,5,opt,name=total_errors,json=totalErrors,proto3" json:"total_errors,omitempty"`
+	TotalGroups   int32 `protobuf:"varint,6,opt,name=total_groups,json=totalGroups,proto3" json:"total_groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryErrorsResponse) Reset() {
+	*x = QueryErrorsResponse{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryErrorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryErrorsResponse) ProtoMessage() {}
+
+func (x *QueryErrorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryErrorsResponse.ProtoReflect.Descriptor instead.
+func (*QueryErrorsResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *QueryErrorsResponse) GetGroups() []*ErrorGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *QueryErrorsResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *QueryErrorsResponse) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *QueryErrorsResponse) GetBucketMs() int64 {
+	if x != nil {
+		return x.BucketMs
+	}
+	return 0
+}
+
+func (x *QueryErrorsResponse) GetTotalErrors() int64 {
+	if x != nil {
+		return x.TotalErrors
+	}
+	return 0
+}
+
+func (x *QueryErrorsResponse) GetTotalGroups() int32 {
+	if x != nil {
+		return x.TotalGroups
+	}
+	return 0
+}
+
+type QuerySLORequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: only the SLOs of this service.
+	Service       string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySLORequest) Reset() {
+	*x = QuerySLORequest{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySLORequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySLORequest) ProtoMessage() {}
+
+func (x *QuerySLORequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySLORequest.ProtoReflect.Descriptor instead.
+func (*QuerySLORequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QuerySLORequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+// SLOBurnRate is how fast an SLO spent its error budget over a window.
+type SLOBurnRate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Window        *durationpb.Duration   `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	TotalRequests int64                  `protobuf:"varint,2,opt,name=total_requests,json=totalRequests,proto3" json:"total_requests,omitempty"`
+	BadRequests   int64                  `protobuf:"varint,3,opt,name=bad_requests,json=badRequests,proto3" json:"bad_requests,omitempty"`
+	// Ratio of bad requests divided by the ratio allowed by the target: at 1,
+	// the error budget lasts exactly the period.
+	BurnRate      float64 `protobuf:"fixed64,4,opt,name=burn_rate,json=burnRate,proto3" json:"burn_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SLOBurnRate) Reset() {
+	*x = SLOBurnRate{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOBurnRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOBurnRate) ProtoMessage() {}
+
+func (x *SLOBurnRate) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLOBurnRate.ProtoReflect.Descriptor instead.
+func (*SLOBurnRate) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SLOBurnRate) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (x *SLOBurnRate) GetTotalRequests() int64 {
+	if x != nil {
+		return x.TotalRequests
+	}
+	return 0
+}
+
+func (x *SLOBurnRate) GetBadRequests() int64 {
+	if x != nil {
+		return x.BadRequests
+	}
+	return 0
+}
+
+func (x *SLOBurnRate) GetBurnRate() float64 {
+	if x != nil {
+		return x.BurnRate
+	}
+	return 0
+}
+
+// SLOStatus is the state of one objective of a service.
+type SLOStatus struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Service string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// "availability" (requests that did not fail) or "latency" (requests
+	// faster than latency_threshold_ms).
+	Objective string `protobuf:"bytes,2,opt,name=objective,proto3" json:"objective,omitempty"`
+	// Target percentage of good requests, e.g. 99.9.
+	Target float64 `protobuf:"fixed64,3,opt,name=target,proto3" json:"target,omitempty"`
+	// Latency objectives: requests slower than this are bad.
+	LatencyThresholdMs float64 `protobuf:"fixed64,4,opt,name=latency_threshold_ms,json=latencyThresholdMs,proto3" json:"latency_threshold_ms,omitempty"`
+	// Compliance period the error budget is spread over.
+	Period *durationpb.Duration `protobuf:"bytes,5,opt,name=period,proto3" json:"period,omitempty"`
+	// Percentage of good requests over the period (100 without requests).
+	Compliance float64 `protobuf:"fixed64,6,opt,name=compliance,proto3" json:"compliance,omitempty"`
+	// Percentage of the error budget left over the period, negative once
+	// overspent.
+	ErrorBudgetRemaining float64 `protobuf:"fixed64,7,opt,name=error_budget_remaining,json=errorBudgetRemaining,proto3" json:"error_budget_remaining,omitempty"`
+	// Burn rates over windows from 5 minutes to 3 days, shortest first.
+	BurnRates []*SLOBurnRate `protobuf:"bytes,8,rep,name=burn_rates,json=burnRates,proto3" json:"burn_rates,omitempty"`
+	// "ok", "slow_burn" (the budget runs out before the end of the period),
+	// "fast_burn" (it runs out within days) or "exhausted".
+	Status        string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SLOStatus) Reset() {
+	*x = SLOStatus{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOStatus) ProtoMessage() {}
+
+func (x *SLOStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLOStatus.ProtoReflect.Descriptor instead.
+func (*SLOStatus) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SLOStatus) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *SLOStatus) GetObjective() string {
+	if x != nil {
+		return x.Objective
+	}
+	return ""
+}
+
+func (x *SLOStatus) GetTarget() float64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetLatencyThresholdMs() float64 {
+	if x != nil {
+		return x.LatencyThresholdMs
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.Period
+	}
+	return nil
+}
+
+func (x *SLOStatus) GetCompliance() float64 {
+	if x != nil {
+		return x.Compliance
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetErrorBudgetRemaining() float64 {
+	if x != nil {
+		return x.ErrorBudgetRemaining
+	}
+	return 0
+}
+
+func (x *SLOStatus) GetBurnRates() []*SLOBurnRate {
+	if x != nil {
+		return x.BurnRates
+	}
+	return nil
+}
+
+func (x *SLOStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type QuerySLOResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slos          []*SLOStatus           `protobuf:"bytes,1,rep,name=slos,proto3" json:"slos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySLOResponse) Reset() {
+	*x = QuerySLOResponse{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySLOResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySLOResponse) ProtoMessage() {}
+
+func (x *QuerySLOResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySLOResponse.ProtoReflect.Descriptor instead.
+func (*QuerySLOResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *QuerySLOResponse) GetSlos() []*SLOStatus {
+	if x != nil {
+		return x.Slos
+	}
+	return nil
+}
+
+type QueryAnomaliesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: only anomalies of this service.
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// Time range (e.g., "1h", "30m"; default: "1h").
+	TimeRange string `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
+	// Include the scores of metrics within their usual range.
+	IncludeNormal bool `protobuf:"varint,3,opt,name=include_normal,json=includeNormal,proto3" json:"include_normal,omitempty"`
+	// Maximum scores to return, newest first (default: 100).
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAnomaliesRequest) Reset() {
+	*x = QueryAnomaliesRequest{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAnomaliesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAnomaliesRequest) ProtoMessage() {}
+
+func (x *QueryAnomaliesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAnomaliesRequest.ProtoReflect.Descriptor instead.
+func (*QueryAnomaliesRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QueryAnomaliesRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *QueryAnomaliesRequest) GetTimeRange() string {
+	if x != nil {
+		return x.TimeRange
+	}
+	return ""
+}
+
+func (x *QueryAnomaliesRequest) GetIncludeNormal() bool {
+	if x != nil {
+		return x.IncludeNormal
+	}
+	return false
+}
+
+func (x *QueryAnomaliesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Anomaly is the deviation of a route metric over a scoring window from its
+// baseline at that hour of the day.
+type Anomaly struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// End of the scored window.
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Service   string                 `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// HTTP method and route, or gRPC method.
+	Route string `protobuf:"bytes,3,opt,name=route,proto3" json:"route,omitempty"`
+	// "latency_p95" (milliseconds) or "error_rate" (percentage).
+	Metric         string  `protobuf:"bytes,4,opt,name=metric,proto3" json:"metric,omitempty"`
+	Value          float64 `protobuf:"fixed64,5,opt,name=value,proto3" json:"value,omitempty"`
+	BaselineMean   float64 `protobuf:"fixed64,6,opt,name=baseline_mean,json=baselineMean,proto3" json:"baseline_mean,omitempty"`
+	BaselineStddev float64 `protobuf:"fixed64,7,opt,name=baseline_stddev,json=baselineStddev,proto3" json:"baseline_stddev,omitempty"`
+	// Deviation from the baseline mean in standard deviations, negative below
+	// it.
+	Score         float64 `protobuf:"fixed64,8,opt,name=score,proto3" json:"score,omitempty"`
+	Anomalous     bool    `protobuf:"varint,9,opt,name=anomalous,proto3" json:"anomalous,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UnifiedLogEntry) Reset() {
-	*x = UnifiedLogEntry{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[13]
+func (x *Anomaly) Reset() {
+	*x = Anomaly{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UnifiedLogEntry) String() string {
+func (x *Anomaly) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnifiedLogEntry) ProtoMessage() {}
+func (*Anomaly) ProtoMessage() {}
 
-func (x *UnifiedLogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[13]
+func (x *Anomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1335,78 +2808,98 @@ func (x *UnifiedLogEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnifiedLogEntry.ProtoReflect.Descriptor instead.
-func (*UnifiedLogEntry) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use Anomaly.ProtoReflect.Descriptor instead.
+func (*Anomaly) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *UnifiedLogEntry) GetTimestamp() int64 {
+func (x *Anomaly) GetTimestamp() *timestamppb.Timestamp {
 	if x != nil {
 		return x.Timestamp
 	}
-	return 0
+	return nil
 }
 
-func (x *UnifiedLogEntry) GetServiceName() string {
+func (x *Anomaly) GetService() string {
 	if x != nil {
-		return x.ServiceName
+		return x.Service
 	}
 	return ""
 }
 
-func (x *UnifiedLogEntry) GetLevel() string {
+func (x *Anomaly) GetRoute() string {
 	if x != nil {
-		return x.Level
+		return x.Route
 	}
 	return ""
 }
 
-func (x *UnifiedLogEntry) GetMessage() string {
+func (x *Anomaly) GetMetric() string {
 	if x != nil {
-		return x.Message
+		return x.Metric
 	}
 	return ""
 }
 
-func (x *UnifiedLogEntry) GetTraceId() string {
+func (x *Anomaly) GetValue() float64 {
 	if x != nil {
-		return x.TraceId
+		return x.Value
 	}
-	return ""
+	return 0
 }
 
-func (x *UnifiedLogEntry) GetAttributes() map[string]string {
+func (x *Anomaly) GetBaselineMean() float64 {
 	if x != nil {
-		return x.Attributes
+		return x.BaselineMean
 	}
-	return nil
+	return 0
 }
 
-type QueryUnifiedLogsResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Structured log entries.
-	Logs []*UnifiedLogEntry `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
-	// Total logs returned.
-	TotalLogs     int32 `protobuf:"varint,2,opt,name=total_logs,json=totalLogs,proto3" json:"total_logs,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Anomaly) GetBaselineStddev() float64 {
+	if x != nil {
+		return x.BaselineStddev
+	}
+	return 0
 }
 
-func (x *QueryUnifiedLogsResponse) Reset() {
-	*x = QueryUnifiedLogsResponse{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[14]
+func (x *Anomaly) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Anomaly) GetAnomalous() bool {
+	if x != nil {
+		return x.Anomalous
+	}
+	return false
+}
+
+type QueryAnomaliesResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Anomalies []*Anomaly             `protobuf:"bytes,1,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+	// When the baselines were last learned; unset if they never were.
+	BaselinesLearnedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=baselines_learned_at,json=baselinesLearnedAt,proto3" json:"baselines_learned_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *QueryAnomaliesResponse) Reset() {
+	*x = QueryAnomaliesResponse{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryUnifiedLogsResponse) String() string {
+func (x *QueryAnomaliesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryUnifiedLogsResponse) ProtoMessage() {}
+func (*QueryAnomaliesResponse) ProtoMessage() {}
 
-func (x *QueryUnifiedLogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[14]
+func (x *QueryAnomaliesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1417,23 +2910,23 @@ func (x *QueryUnifiedLogsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryUnifiedLogsResponse.ProtoReflect.Descriptor instead.
-func (*QueryUnifiedLogsResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use QueryAnomaliesResponse.ProtoReflect.Descriptor instead.
+func (*QueryAnomaliesResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *QueryUnifiedLogsResponse) GetLogs() []*UnifiedLogEntry {
+func (x *QueryAnomaliesResponse) GetAnomalies() []*Anomaly {
 	if x != nil {
-		return x.Logs
+		return x.Anomalies
 	}
 	return nil
 }
 
-func (x *QueryUnifiedLogsResponse) GetTotalLogs() int32 {
+func (x *QueryAnomaliesResponse) GetBaselinesLearnedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TotalLogs
+		return x.BaselinesLearnedAt
 	}
-	return 0
+	return nil
 }
 
 type ListServicesRequest struct {
@@ -1446,14 +2939,20 @@ type ListServicesRequest struct {
 	TimeRange string `protobuf:"bytes,2,opt,name=time_range,json=timeRange,proto3" json:"time_range,omitempty"`
 	// Filter by service source (RFD 084).
 	// If unspecified, returns all services regardless of source.
-	SourceFilter  *ServiceSource `protobuf:"varint,3,opt,name=source_filter,json=sourceFilter,proto3,enum=coral.colony.v1.ServiceSource,oneof" json:"source_filter,omitempty"`
+	SourceFilter *ServiceSource `protobuf:"varint,3,opt,name=source_filter,json=sourceFilter,proto3,enum=coral.colony.v1.ServiceSource,oneof" json:"source_filter,omitempty"`
+	// Only include services whose labels match this selector, e.g.
+	// "env=prod". A service has the labels of its agent, overridden by its own.
+	Selector string `protobuf:"bytes,4,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Only include the services of this service group, see
+	// ListServiceGroups. Combined with a selector, services must match both.
+	Group         string `protobuf:"bytes,5,opt,name=group,proto3" json:"group,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListServicesRequest) Reset() {
 	*x = ListServicesRequest{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[15]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1465,7 +2964,7 @@ func (x *ListServicesRequest) String() string {
 func (*ListServicesRequest) ProtoMessage() {}
 
 func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[15]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1478,7 +2977,7 @@ func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServicesRequest.ProtoReflect.Descriptor instead.
 func (*ListServicesRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{15}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ListServicesRequest) GetNamespace() string {
@@ -1502,6 +3001,20 @@ func (x *ListServicesRequest) GetSourceFilter() ServiceSource {
 	return ServiceSource_SERVICE_SOURCE_UNSPECIFIED
 }
 
+func (x *ListServicesRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *ListServicesRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
 type ListServicesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Services      []*ServiceSummary      `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
@@ -1511,7 +3024,7 @@ type ListServicesResponse struct {
 
 func (x *ListServicesResponse) Reset() {
 	*x = ListServicesResponse{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[16]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1523,7 +3036,7 @@ func (x *ListServicesResponse) String() string {
 func (*ListServicesResponse) ProtoMessage() {}
 
 func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[16]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1536,7 +3049,7 @@ func (x *ListServicesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServicesResponse.ProtoReflect.Descriptor instead.
 func (*ListServicesResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{16}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *ListServicesResponse) GetServices() []*ServiceSummary {
@@ -1559,14 +3072,17 @@ type ServiceSummary struct {
 	Status *ServiceStatus `protobuf:"varint,6,opt,name=status,proto3,enum=coral.colony.v1.ServiceStatus,oneof" json:"status,omitempty"`
 	// Agent ID where service is registered (if applicable) (RFD 084).
 	// Only set when source includes SERVICE_SOURCE_REGISTERED.
-	AgentId       *string `protobuf:"bytes,7,opt,name=agent_id,json=agentId,proto3,oneof" json:"agent_id,omitempty"`
+	AgentId *string `protobuf:"bytes,7,opt,name=agent_id,json=agentId,proto3,oneof" json:"agent_id,omitempty"`
+	// Labels of the service, including those of its agent. Empty for
+	// services only seen in telemetry.
+	Labels        map[string]string `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ServiceSummary) Reset() {
 	*x = ServiceSummary{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[17]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1578,7 +3094,7 @@ func (x *ServiceSummary) String() string {
 func (*ServiceSummary) ProtoMessage() {}
 
 func (x *ServiceSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[17]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1591,7 +3107,7 @@ func (x *ServiceSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceSummary.ProtoReflect.Descriptor instead.
 func (*ServiceSummary) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{17}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *ServiceSummary) GetName() string {
@@ -1643,6 +3159,13 @@ func (x *ServiceSummary) GetAgentId() string {
 	return ""
 }
 
+func (x *ServiceSummary) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
 type GetMetricPercentileRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Service name.
@@ -1659,7 +3182,7 @@ type GetMetricPercentileRequest struct {
 
 func (x *GetMetricPercentileRequest) Reset() {
 	*x = GetMetricPercentileRequest{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[18]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1671,7 +3194,7 @@ func (x *GetMetricPercentileRequest) String() string {
 func (*GetMetricPercentileRequest) ProtoMessage() {}
 
 func (x *GetMetricPercentileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[18]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1684,7 +3207,7 @@ func (x *GetMetricPercentileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMetricPercentileRequest.ProtoReflect.Descriptor instead.
 func (*GetMetricPercentileRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{18}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetMetricPercentileRequest) GetService() string {
@@ -1729,7 +3252,7 @@ type GetMetricPercentileResponse struct {
 
 func (x *GetMetricPercentileResponse) Reset() {
 	*x = GetMetricPercentileResponse{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[19]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1741,7 +3264,7 @@ func (x *GetMetricPercentileResponse) String() string {
 func (*GetMetricPercentileResponse) ProtoMessage() {}
 
 func (x *GetMetricPercentileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[19]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1754,7 +3277,7 @@ func (x *GetMetricPercentileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMetricPercentileResponse.ProtoReflect.Descriptor instead.
 func (*GetMetricPercentileResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{19}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GetMetricPercentileResponse) GetValue() float64 {
@@ -1790,7 +3313,7 @@ type GetServiceActivityRequest struct {
 
 func (x *GetServiceActivityRequest) Reset() {
 	*x = GetServiceActivityRequest{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[20]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1802,7 +3325,7 @@ func (x *GetServiceActivityRequest) String() string {
 func (*GetServiceActivityRequest) ProtoMessage() {}
 
 func (x *GetServiceActivityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[20]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1815,7 +3338,7 @@ func (x *GetServiceActivityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetServiceActivityRequest.ProtoReflect.Descriptor instead.
 func (*GetServiceActivityRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{20}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *GetServiceActivityRequest) GetService() string {
@@ -1850,7 +3373,7 @@ type GetServiceActivityResponse struct {
 
 func (x *GetServiceActivityResponse) Reset() {
 	*x = GetServiceActivityResponse{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[21]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1862,7 +3385,7 @@ func (x *GetServiceActivityResponse) String() string {
 func (*GetServiceActivityResponse) ProtoMessage() {}
 
 func (x *GetServiceActivityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[21]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1875,7 +3398,7 @@ func (x *GetServiceActivityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetServiceActivityResponse.ProtoReflect.Descriptor instead.
 func (*GetServiceActivityResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{21}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *GetServiceActivityResponse) GetServiceName() string {
@@ -1923,7 +3446,7 @@ type ListServiceActivityRequest struct {
 
 func (x *ListServiceActivityRequest) Reset() {
 	*x = ListServiceActivityRequest{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[22]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1935,7 +3458,7 @@ func (x *ListServiceActivityRequest) String() string {
 func (*ListServiceActivityRequest) ProtoMessage() {}
 
 func (x *ListServiceActivityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[22]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1948,7 +3471,7 @@ func (x *ListServiceActivityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServiceActivityRequest.ProtoReflect.Descriptor instead.
 func (*ListServiceActivityRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{22}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ListServiceActivityRequest) GetTimeRangeMs() int64 {
@@ -1968,7 +3491,7 @@ type ListServiceActivityResponse struct {
 
 func (x *ListServiceActivityResponse) Reset() {
 	*x = ListServiceActivityResponse{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[23]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1980,7 +3503,7 @@ func (x *ListServiceActivityResponse) String() string {
 func (*ListServiceActivityResponse) ProtoMessage() {}
 
 func (x *ListServiceActivityResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[23]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1993,7 +3516,7 @@ func (x *ListServiceActivityResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServiceActivityResponse.ProtoReflect.Descriptor instead.
 func (*ListServiceActivityResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{23}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *ListServiceActivityResponse) GetServices() []*ServiceActivity {
@@ -2019,7 +3542,7 @@ type ServiceActivity struct {
 
 func (x *ServiceActivity) Reset() {
 	*x = ServiceActivity{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[24]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2031,7 +3554,7 @@ func (x *ServiceActivity) String() string {
 func (*ServiceActivity) ProtoMessage() {}
 
 func (x *ServiceActivity) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[24]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2044,7 +3567,7 @@ func (x *ServiceActivity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceActivity.ProtoReflect.Descriptor instead.
 func (*ServiceActivity) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{24}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *ServiceActivity) GetServiceName() string {
@@ -2087,7 +3610,7 @@ type ExecuteQueryRequest struct {
 
 func (x *ExecuteQueryRequest) Reset() {
 	*x = ExecuteQueryRequest{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[25]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2099,7 +3622,7 @@ func (x *ExecuteQueryRequest) String() string {
 func (*ExecuteQueryRequest) ProtoMessage() {}
 
 func (x *ExecuteQueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[25]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2112,7 +3635,7 @@ func (x *ExecuteQueryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteQueryRequest.ProtoReflect.Descriptor instead.
 func (*ExecuteQueryRequest) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{25}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *ExecuteQueryRequest) GetSql() string {
@@ -2143,7 +3666,7 @@ type ExecuteQueryResponse struct {
 
 func (x *ExecuteQueryResponse) Reset() {
 	*x = ExecuteQueryResponse{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[26]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2155,7 +3678,7 @@ func (x *ExecuteQueryResponse) String() string {
 func (*ExecuteQueryResponse) ProtoMessage() {}
 
 func (x *ExecuteQueryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[26]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2168,7 +3691,7 @@ func (x *ExecuteQueryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteQueryResponse.ProtoReflect.Descriptor instead.
 func (*ExecuteQueryResponse) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{26}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *ExecuteQueryResponse) GetRows() []*QueryRow {
@@ -2202,7 +3725,7 @@ type QueryRow struct {
 
 func (x *QueryRow) Reset() {
 	*x = QueryRow{}
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[27]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2214,7 +3737,7 @@ func (x *QueryRow) String() string {
 func (*QueryRow) ProtoMessage() {}
 
 func (x *QueryRow) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_colony_v1_queries_proto_msgTypes[27]
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2227,7 +3750,7 @@ func (x *QueryRow) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryRow.ProtoReflect.Descriptor instead.
 func (*QueryRow) Descriptor() ([]byte, []int) {
-	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{27}
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *QueryRow) GetValues() []string {
@@ -2237,17 +3760,178 @@ func (x *QueryRow) GetValues() []string {
 	return nil
 }
 
+type QuerySQLRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A single SELECT statement (WITH ... SELECT is allowed). Statements that
+	// write, attach databases or read files are rejected.
+	Sql string `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	// Optional: maximum rows to return (default: 1000, max: 10000).
+	MaxRows int32 `protobuf:"varint,2,opt,name=max_rows,json=maxRows,proto3" json:"max_rows,omitempty"`
+	// Optional: statement timeout in milliseconds (default: 30s, max: 2m).
+	TimeoutMs     int64 `protobuf:"varint,3,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySQLRequest) Reset() {
+	*x = QuerySQLRequest{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySQLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySQLRequest) ProtoMessage() {}
+
+func (x *QuerySQLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySQLRequest.ProtoReflect.Descriptor instead.
+func (*QuerySQLRequest) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *QuerySQLRequest) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *QuerySQLRequest) GetMaxRows() int32 {
+	if x != nil {
+		return x.MaxRows
+	}
+	return 0
+}
+
+func (x *QuerySQLRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+type QuerySQLResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Column names.
+	Columns []string `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	// DuckDB type of each column, e.g. BIGINT, VARCHAR, TIMESTAMP WITH TIME ZONE.
+	ColumnTypes []string `protobuf:"bytes,2,rep,name=column_types,json=columnTypes,proto3" json:"column_types,omitempty"`
+	// Query results as rows. NULL values are empty strings.
+	Rows []*QueryRow `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	// Number of rows returned.
+	RowCount int32 `protobuf:"varint,4,opt,name=row_count,json=rowCount,proto3" json:"row_count,omitempty"`
+	// True if the query produced more rows than max_rows.
+	Truncated bool `protobuf:"varint,5,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// Query execution time in milliseconds.
+	DurationMs    int64 `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuerySQLResponse) Reset() {
+	*x = QuerySQLResponse{}
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuerySQLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuerySQLResponse) ProtoMessage() {}
+
+func (x *QuerySQLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_colony_v1_queries_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuerySQLResponse.ProtoReflect.Descriptor instead.
+func (*QuerySQLResponse) Descriptor() ([]byte, []int) {
+	return file_coral_colony_v1_queries_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *QuerySQLResponse) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *QuerySQLResponse) GetColumnTypes() []string {
+	if x != nil {
+		return x.ColumnTypes
+	}
+	return nil
+}
+
+func (x *QuerySQLResponse) GetRows() []*QueryRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *QuerySQLResponse) GetRowCount() int32 {
+	if x != nil {
+		return x.RowCount
+	}
+	return 0
+}
+
+func (x *QuerySQLResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *QuerySQLResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
 var File_coral_colony_v1_queries_proto protoreflect.FileDescriptor
 
 const file_coral_colony_v1_queries_proto_rawDesc = "" +
 	"\n" +
-	"\x1dcoral/colony/v1/queries.proto\x12\x0fcoral.colony.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1acoral/agent/v1/agent.proto\"\xa8\x01\n" +
+	"\x1dcoral/colony/v1/queries.proto\x12\x0fcoral.colony.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1acoral/agent/v1/agent.proto\"\xf1\x02\n" +
 	"\x1aQueryUnifiedSummaryRequest\x12\x18\n" +
 	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1d\n" +
 	"\n" +
 	"time_range\x18\x02 \x01(\tR\ttimeRange\x12+\n" +
 	"\x11include_profiling\x18\x03 \x01(\bR\x10includeProfiling\x12$\n" +
-	"\x0etop_k_hotspots\x18\x04 \x01(\x05R\ftopKHotspots\"\xe7\x05\n" +
+	"\x0etop_k_hotspots\x18\x04 \x01(\x05R\ftopKHotspots\x12\x1c\n" +
+	"\tfederated\x18\x05 \x01(\bR\tfederated\x12)\n" +
+	"\x10include_baseline\x18\x06 \x01(\bR\x0fincludeBaseline\x12'\n" +
+	"\x0fbaseline_window\x18\a \x01(\tR\x0ebaselineWindow\x12#\n" +
+	"\ranomaly_sigma\x18\b \x01(\x01R\fanomalySigma\x12\x1a\n" +
+	"\bselector\x18\t \x01(\tR\bselector\x12\x14\n" +
+	"\x05group\x18\n" +
+	" \x01(\tR\x05group\"\xc2\x06\n" +
 	"\x14UnifiedSummaryResult\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12#\n" +
@@ -2268,9 +3952,24 @@ const file_coral_colony_v1_queries_proto_rawDesc = "" +
 	"\n" +
 	"deployment\x18\x0f \x01(\v2\".coral.colony.v1.DeploymentContextR\n" +
 	"deployment\x12F\n" +
-	"\vregressions\x18\x10 \x03(\v2$.coral.colony.v1.RegressionIndicatorR\vregressions\"b\n" +
+	"\vregressions\x18\x10 \x03(\v2$.coral.colony.v1.RegressionIndicatorR\vregressions\x12\x1b\n" +
+	"\tcolony_id\x18\x11 \x01(\tR\bcolonyId\x12<\n" +
+	"\bbaseline\x18\x12 \x01(\v2 .coral.colony.v1.ServiceBaselineR\bbaseline\"\x87\x02\n" +
+	"\x0fServiceBaseline\x12O\n" +
+	"\x13requests_per_second\x18\x01 \x01(\v2\x1f.coral.colony.v1.MetricBaselineR\x11requestsPerSecond\x12E\n" +
+	"\x0ep95_latency_ms\x18\x02 \x01(\v2\x1f.coral.colony.v1.MetricBaselineR\fp95LatencyMs\x12>\n" +
+	"\n" +
+	"error_rate\x18\x03 \x01(\v2\x1f.coral.colony.v1.MetricBaselineR\terrorRate\x12\x1c\n" +
+	"\tintervals\x18\x04 \x01(\x05R\tintervals\"\x8a\x01\n" +
+	"\x0eMetricBaseline\x12\x18\n" +
+	"\acurrent\x18\x01 \x01(\x01R\acurrent\x12\x12\n" +
+	"\x04mean\x18\x02 \x01(\x01R\x04mean\x12\x16\n" +
+	"\x06stddev\x18\x03 \x01(\x01R\x06stddev\x12\x14\n" +
+	"\x05sigma\x18\x04 \x01(\x01R\x05sigma\x12\x1c\n" +
+	"\tanomalous\x18\x05 \x01(\bR\tanomalous\"\xb1\x01\n" +
 	"\x1bQueryUnifiedSummaryResponse\x12C\n" +
-	"\tsummaries\x18\x01 \x03(\v2%.coral.colony.v1.UnifiedSummaryResultR\tsummaries\"\xee\x02\n" +
+	"\tsummaries\x18\x01 \x03(\v2%.coral.colony.v1.UnifiedSummaryResultR\tsummaries\x12M\n" +
+	"\x11federation_errors\x18\x02 \x03(\v2 .coral.colony.v1.FederationErrorR\x10federationErrors\"\xee\x02\n" +
 	"\x10ProfilingSummary\x12E\n" +
 	"\x10top_cpu_hotspots\x18\x01 \x03(\v2\x1b.coral.colony.v1.CPUHotspotR\x0etopCpuHotspots\x12#\n" +
 	"\rtotal_samples\x18\x02 \x01(\x04R\ftotalSamples\x12'\n" +
@@ -2307,7 +4006,7 @@ const file_coral_colony_v1_queries_proto_rawDesc = "" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
 	"\x13baseline_percentage\x18\x03 \x01(\x01R\x12baselinePercentage\x12-\n" +
 	"\x12current_percentage\x18\x04 \x01(\x01R\x11currentPercentage\x12\x14\n" +
-	"\x05delta\x18\x05 \x01(\x01R\x05delta\"\xce\x01\n" +
+	"\x05delta\x18\x05 \x01(\x01R\x05delta\"\x9e\x02\n" +
 	"\x19QueryUnifiedTracesRequest\x12\x18\n" +
 	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1d\n" +
 	"\n" +
@@ -2316,10 +4015,14 @@ const file_coral_colony_v1_queries_proto_rawDesc = "" +
 	"\btrace_id\x18\x04 \x01(\tR\atraceId\x12&\n" +
 	"\x0fmin_duration_ms\x18\x05 \x01(\x05R\rminDurationMs\x12\x1d\n" +
 	"\n" +
-	"max_traces\x18\x06 \x01(\x05R\tmaxTraces\"t\n" +
+	"max_traces\x18\x06 \x01(\x05R\tmaxTraces\x12\x1c\n" +
+	"\tfederated\x18\a \x01(\bR\tfederated\x12\x1a\n" +
+	"\bselector\x18\b \x01(\tR\bselector\x12\x14\n" +
+	"\x05group\x18\t \x01(\tR\x05group\"\xc3\x01\n" +
 	"\x1aQueryUnifiedTracesResponse\x123\n" +
 	"\x05spans\x18\x01 \x03(\v2\x1d.coral.agent.v1.EbpfTraceSpanR\x05spans\x12!\n" +
-	"\ftotal_traces\x18\x02 \x01(\x05R\vtotalTraces\"\xf5\x01\n" +
+	"\ftotal_traces\x18\x02 \x01(\x05R\vtotalTraces\x12M\n" +
+	"\x11federation_errors\x18\x03 \x03(\v2 .coral.colony.v1.FederationErrorR\x10federationErrors\"\xc5\x02\n" +
 	"\x1aQueryUnifiedMetricsRequest\x12\x18\n" +
 	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1d\n" +
 	"\n" +
@@ -2330,13 +4033,21 @@ const file_coral_colony_v1_queries_proto_rawDesc = "" +
 	"http_route\x18\x05 \x01(\tR\thttpRoute\x12\x1f\n" +
 	"\vhttp_method\x18\x06 \x01(\tR\n" +
 	"httpMethod\x12*\n" +
-	"\x11status_code_range\x18\a \x01(\tR\x0fstatusCodeRange\"\x88\x02\n" +
+	"\x11status_code_range\x18\a \x01(\tR\x0fstatusCodeRange\x12\x1c\n" +
+	"\tfederated\x18\b \x01(\bR\tfederated\x12\x1a\n" +
+	"\bselector\x18\t \x01(\tR\bselector\x12\x14\n" +
+	"\x05group\x18\n" +
+	" \x01(\tR\x05group\"\xd7\x02\n" +
 	"\x1bQueryUnifiedMetricsResponse\x12A\n" +
 	"\fhttp_metrics\x18\x01 \x03(\v2\x1e.coral.agent.v1.EbpfHttpMetricR\vhttpMetrics\x12A\n" +
 	"\fgrpc_metrics\x18\x02 \x03(\v2\x1e.coral.agent.v1.EbpfGrpcMetricR\vgrpcMetrics\x12>\n" +
 	"\vsql_metrics\x18\x03 \x03(\v2\x1d.coral.agent.v1.EbpfSqlMetricR\n" +
 	"sqlMetrics\x12#\n" +
-	"\rtotal_metrics\x18\x04 \x01(\x05R\ftotalMetrics\"\x9b\x01\n" +
+	"\rtotal_metrics\x18\x04 \x01(\x05R\ftotalMetrics\x12M\n" +
+	"\x11federation_errors\x18\x05 \x03(\v2 .coral.colony.v1.FederationErrorR\x10federationErrors\"D\n" +
+	"\x0fFederationError\x12\x1b\n" +
+	"\tcolony_id\x18\x01 \x01(\tR\bcolonyId\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x9b\x01\n" +
 	"\x17QueryUnifiedLogsRequest\x12\x18\n" +
 	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1d\n" +
 	"\n" +
@@ -2359,15 +4070,115 @@ const file_coral_colony_v1_queries_proto_rawDesc = "" +
 	"\x18QueryUnifiedLogsResponse\x124\n" +
 	"\x04logs\x18\x01 \x03(\v2 .coral.colony.v1.UnifiedLogEntryR\x04logs\x12\x1d\n" +
 	"\n" +
-	"total_logs\x18\x02 \x01(\x05R\ttotalLogs\"\xae\x01\n" +
+	"total_logs\x18\x02 \x01(\x05R\ttotalLogs\"\x8a\x01\n" +
+	"\x19CompareDeploymentsRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12;\n" +
+	"\vdeploy_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deployTime\x12\x16\n" +
+	"\x06window\x18\x03 \x01(\tR\x06window\"\x92\x02\n" +
+	"\x15DeploymentWindowStats\x129\n" +
+	"\n" +
+	"start_time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12#\n" +
+	"\rrequest_count\x18\x03 \x01(\x03R\frequestCount\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\x04 \x01(\x01R\terrorRate\x12\x15\n" +
+	"\x06p50_ms\x18\x05 \x01(\x01R\x05p50Ms\x12\x15\n" +
+	"\x06p95_ms\x18\x06 \x01(\x01R\x05p95Ms\x12\x15\n" +
+	"\x06p99_ms\x18\a \x01(\x01R\x05p99Ms\"G\n" +
+	"\x11NewErrorSignature\x12\x1c\n" +
+	"\tsignature\x18\x01 \x01(\tR\tsignature\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xe0\x03\n" +
+	"\x1aCompareDeploymentsResponse\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12;\n" +
+	"\vdeploy_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deployTime\x12\x19\n" +
+	"\bbuild_id\x18\x03 \x01(\tR\abuildId\x12B\n" +
+	"\bbaseline\x18\x04 \x01(\v2&.coral.colony.v1.DeploymentWindowStatsR\bbaseline\x12@\n" +
+	"\acurrent\x18\x05 \x01(\v2&.coral.colony.v1.DeploymentWindowStatsR\acurrent\x12M\n" +
+	"\x0fcpu_regressions\x18\x06 \x03(\v2$.coral.colony.v1.RegressionIndicatorR\x0ecpuRegressions\x12A\n" +
+	"\n" +
+	"new_errors\x18\a \x03(\v2\".coral.colony.v1.NewErrorSignatureR\tnewErrors\x12\x1a\n" +
+	"\bfindings\x18\b \x03(\tR\bfindings\x12\x1c\n" +
+	"\tregressed\x18\t \x01(\bR\tregressed\"\x91\x01\n" +
+	"\x12QueryErrorsRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1d\n" +
+	"\n" +
+	"time_range\x18\x02 \x01(\tR\ttimeRange\x12#\n" +
+	"\rtrend_buckets\x18\x03 \x01(\x05R\ftrendBuckets\x12\x1d\n" +
+	"\n" +
+	"max_groups\x18\x04 \x01(\x05R\tmaxGroups\"\xac\x02\n" +
+	"\n" +
+	"ErrorGroup\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x14\n" +
+	"\x05route\x18\x03 \x01(\tR\x05route\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\tR\tsignature\x12\x18\n" +
+	"\aexample\x18\x05 \x01(\tR\aexample\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\x03R\x05count\x129\n" +
+	"\n" +
+	"first_seen\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
+	"\tlast_seen\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x12\x14\n" +
+	"\x05trend\x18\t \x03(\x03R\x05trend\"\x9f\x02\n" +
+	"\x13QueryErrorsResponse\x123\n" +
+	"\x06groups\x18\x01 \x03(\v2\x1b.coral.colony.v1.ErrorGroupR\x06groups\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x1b\n" +
+	"\tbucket_ms\x18\x04 \x01(\x03R\bbucketMs\x12!\n" +
+	"\ftotal_errors\x18\x05 \x01(\x03R\vtotalErrors\x12!\n" +
+	"\ftotal_groups\x18\x06 \x01(\x05R\vtotalGroups\"+\n" +
+	"\x0fQuerySLORequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\"\xa7\x01\n" +
+	"\vSLOBurnRate\x121\n" +
+	"\x06window\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12%\n" +
+	"\x0etotal_requests\x18\x02 \x01(\x03R\rtotalRequests\x12!\n" +
+	"\fbad_requests\x18\x03 \x01(\x03R\vbadRequests\x12\x1b\n" +
+	"\tburn_rate\x18\x04 \x01(\x01R\bburnRate\"\xeb\x02\n" +
+	"\tSLOStatus\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1c\n" +
+	"\tobjective\x18\x02 \x01(\tR\tobjective\x12\x16\n" +
+	"\x06target\x18\x03 \x01(\x01R\x06target\x120\n" +
+	"\x14latency_threshold_ms\x18\x04 \x01(\x01R\x12latencyThresholdMs\x121\n" +
+	"\x06period\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\x06period\x12\x1e\n" +
+	"\n" +
+	"compliance\x18\x06 \x01(\x01R\n" +
+	"compliance\x124\n" +
+	"\x16error_budget_remaining\x18\a \x01(\x01R\x14errorBudgetRemaining\x12;\n" +
+	"\n" +
+	"burn_rates\x18\b \x03(\v2\x1c.coral.colony.v1.SLOBurnRateR\tburnRates\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\"B\n" +
+	"\x10QuerySLOResponse\x12.\n" +
+	"\x04slos\x18\x01 \x03(\v2\x1a.coral.colony.v1.SLOStatusR\x04slos\"\x8d\x01\n" +
+	"\x15QueryAnomaliesRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1d\n" +
+	"\n" +
+	"time_range\x18\x02 \x01(\tR\ttimeRange\x12%\n" +
+	"\x0einclude_normal\x18\x03 \x01(\bR\rincludeNormal\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xa3\x02\n" +
+	"\aAnomaly\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x18\n" +
+	"\aservice\x18\x02 \x01(\tR\aservice\x12\x14\n" +
+	"\x05route\x18\x03 \x01(\tR\x05route\x12\x16\n" +
+	"\x06metric\x18\x04 \x01(\tR\x06metric\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\x01R\x05value\x12#\n" +
+	"\rbaseline_mean\x18\x06 \x01(\x01R\fbaselineMean\x12'\n" +
+	"\x0fbaseline_stddev\x18\a \x01(\x01R\x0ebaselineStddev\x12\x14\n" +
+	"\x05score\x18\b \x01(\x01R\x05score\x12\x1c\n" +
+	"\tanomalous\x18\t \x01(\bR\tanomalous\"\x9e\x01\n" +
+	"\x16QueryAnomaliesResponse\x126\n" +
+	"\tanomalies\x18\x01 \x03(\v2\x18.coral.colony.v1.AnomalyR\tanomalies\x12L\n" +
+	"\x14baselines_learned_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x12baselinesLearnedAt\"\xe0\x01\n" +
 	"\x13ListServicesRequest\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1d\n" +
 	"\n" +
 	"time_range\x18\x02 \x01(\tR\ttimeRange\x12H\n" +
-	"\rsource_filter\x18\x03 \x01(\x0e2\x1e.coral.colony.v1.ServiceSourceH\x00R\fsourceFilter\x88\x01\x01B\x10\n" +
+	"\rsource_filter\x18\x03 \x01(\x0e2\x1e.coral.colony.v1.ServiceSourceH\x00R\fsourceFilter\x88\x01\x01\x12\x1a\n" +
+	"\bselector\x18\x04 \x01(\tR\bselector\x12\x14\n" +
+	"\x05group\x18\x05 \x01(\tR\x05groupB\x10\n" +
 	"\x0e_source_filter\"S\n" +
 	"\x14ListServicesResponse\x12;\n" +
-	"\bservices\x18\x01 \x03(\v2\x1f.coral.colony.v1.ServiceSummaryR\bservices\"\xcf\x02\n" +
+	"\bservices\x18\x01 \x03(\v2\x1f.coral.colony.v1.ServiceSummaryR\bservices\"\xcf\x03\n" +
 	"\x0eServiceSummary\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
 	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12%\n" +
@@ -2375,7 +4186,11 @@ const file_coral_colony_v1_queries_proto_rawDesc = "" +
 	"\tlast_seen\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\x126\n" +
 	"\x06source\x18\x05 \x01(\x0e2\x1e.coral.colony.v1.ServiceSourceR\x06source\x12;\n" +
 	"\x06status\x18\x06 \x01(\x0e2\x1e.coral.colony.v1.ServiceStatusH\x00R\x06status\x88\x01\x01\x12\x1e\n" +
-	"\bagent_id\x18\a \x01(\tH\x01R\aagentId\x88\x01\x01B\t\n" +
+	"\bagent_id\x18\a \x01(\tH\x01R\aagentId\x88\x01\x01\x12C\n" +
+	"\x06labels\x18\b \x03(\v2+.coral.colony.v1.ServiceSummary.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
 	"\a_statusB\v\n" +
 	"\t_agent_id\"\x92\x01\n" +
 	"\x1aGetMetricPercentileRequest\x12\x18\n" +
@@ -2419,7 +4234,20 @@ const file_coral_colony_v1_queries_proto_rawDesc = "" +
 	"\trow_count\x18\x02 \x01(\x05R\browCount\x12\x18\n" +
 	"\acolumns\x18\x03 \x03(\tR\acolumns\"\"\n" +
 	"\bQueryRow\x12\x16\n" +
-	"\x06values\x18\x01 \x03(\tR\x06values*\x7f\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"]\n" +
+	"\x0fQuerySQLRequest\x12\x10\n" +
+	"\x03sql\x18\x01 \x01(\tR\x03sql\x12\x19\n" +
+	"\bmax_rows\x18\x02 \x01(\x05R\amaxRows\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x03 \x01(\x03R\ttimeoutMs\"\xda\x01\n" +
+	"\x10QuerySQLResponse\x12\x18\n" +
+	"\acolumns\x18\x01 \x03(\tR\acolumns\x12!\n" +
+	"\fcolumn_types\x18\x02 \x03(\tR\vcolumnTypes\x12-\n" +
+	"\x04rows\x18\x03 \x03(\v2\x19.coral.colony.v1.QueryRowR\x04rows\x12\x1b\n" +
+	"\trow_count\x18\x04 \x01(\x05R\browCount\x12\x1c\n" +
+	"\ttruncated\x18\x05 \x01(\bR\ttruncated\x12\x1f\n" +
+	"\vduration_ms\x18\x06 \x01(\x03R\n" +
+	"durationMs*\x7f\n" +
 	"\x0eRegressionType\x12\x1f\n" +
 	"\x1bREGRESSION_TYPE_NEW_HOTSPOT\x10\x00\x12%\n" +
 	"!REGRESSION_TYPE_INCREASED_HOTSPOT\x10\x01\x12%\n" +
@@ -2450,75 +4278,125 @@ func file_coral_colony_v1_queries_proto_rawDescGZIP() []byte {
 }
 
 var file_coral_colony_v1_queries_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_coral_colony_v1_queries_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_coral_colony_v1_queries_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_coral_colony_v1_queries_proto_goTypes = []any{
 	(RegressionType)(0),                 // 0: coral.colony.v1.RegressionType
 	(ServiceSource)(0),                  // 1: coral.colony.v1.ServiceSource
 	(ServiceStatus)(0),                  // 2: coral.colony.v1.ServiceStatus
 	(*QueryUnifiedSummaryRequest)(nil),  // 3: coral.colony.v1.QueryUnifiedSummaryRequest
 	(*UnifiedSummaryResult)(nil),        // 4: coral.colony.v1.UnifiedSummaryResult
-	(*QueryUnifiedSummaryResponse)(nil), // 5: coral.colony.v1.QueryUnifiedSummaryResponse
-	(*ProfilingSummary)(nil),            // 6: coral.colony.v1.ProfilingSummary
-	(*MemoryHotspot)(nil),               // 7: coral.colony.v1.MemoryHotspot
-	(*CPUHotspot)(nil),                  // 8: coral.colony.v1.CPUHotspot
-	(*DeploymentContext)(nil),           // 9: coral.colony.v1.DeploymentContext
-	(*RegressionIndicator)(nil),         // 10: coral.colony.v1.RegressionIndicator
-	(*QueryUnifiedTracesRequest)(nil),   // 11: coral.colony.v1.QueryUnifiedTracesRequest
-	(*QueryUnifiedTracesResponse)(nil),  // 12: coral.colony.v1.QueryUnifiedTracesResponse
-	(*QueryUnifiedMetricsRequest)(nil),  // 13: coral.colony.v1.QueryUnifiedMetricsRequest
-	(*QueryUnifiedMetricsResponse)(nil), // 14: coral.colony.v1.QueryUnifiedMetricsResponse
-	(*QueryUnifiedLogsRequest)(nil),     // 15: coral.colony.v1.QueryUnifiedLogsRequest
-	(*UnifiedLogEntry)(nil),             // 16: coral.colony.v1.UnifiedLogEntry
-	(*QueryUnifiedLogsResponse)(nil),    // 17: coral.colony.v1.QueryUnifiedLogsResponse
-	(*ListServicesRequest)(nil),         // 18: coral.colony.v1.ListServicesRequest
-	(*ListServicesResponse)(nil),        // 19: coral.colony.v1.ListServicesResponse
-	(*ServiceSummary)(nil),              // 20: coral.colony.v1.ServiceSummary
-	(*GetMetricPercentileRequest)(nil),  // 21: coral.colony.v1.GetMetricPercentileRequest
-	(*GetMetricPercentileResponse)(nil), // 22: coral.colony.v1.GetMetricPercentileResponse
-	(*GetServiceActivityRequest)(nil),   // 23: coral.colony.v1.GetServiceActivityRequest
-	(*GetServiceActivityResponse)(nil),  // 24: coral.colony.v1.GetServiceActivityResponse
-	(*ListServiceActivityRequest)(nil),  // 25: coral.colony.v1.ListServiceActivityRequest
-	(*ListServiceActivityResponse)(nil), // 26: coral.colony.v1.ListServiceActivityResponse
-	(*ServiceActivity)(nil),             // 27: coral.colony.v1.ServiceActivity
-	(*ExecuteQueryRequest)(nil),         // 28: coral.colony.v1.ExecuteQueryRequest
-	(*ExecuteQueryResponse)(nil),        // 29: coral.colony.v1.ExecuteQueryResponse
-	(*QueryRow)(nil),                    // 30: coral.colony.v1.QueryRow
-	nil,                                 // 31: coral.colony.v1.UnifiedLogEntry.AttributesEntry
-	(*timestamppb.Timestamp)(nil),       // 32: google.protobuf.Timestamp
-	(*v1.EbpfTraceSpan)(nil),            // 33: coral.agent.v1.EbpfTraceSpan
-	(*v1.EbpfHttpMetric)(nil),           // 34: coral.agent.v1.EbpfHttpMetric
-	(*v1.EbpfGrpcMetric)(nil),           // 35: coral.agent.v1.EbpfGrpcMetric
-	(*v1.EbpfSqlMetric)(nil),            // 36: coral.agent.v1.EbpfSqlMetric
+	(*ServiceBaseline)(nil),             // 5: coral.colony.v1.ServiceBaseline
+	(*MetricBaseline)(nil),              // 6: coral.colony.v1.MetricBaseline
+	(*QueryUnifiedSummaryResponse)(nil), // 7: coral.colony.v1.QueryUnifiedSummaryResponse
+	(*ProfilingSummary)(nil),            // 8: coral.colony.v1.ProfilingSummary
+	(*MemoryHotspot)(nil),               // 9: coral.colony.v1.MemoryHotspot
+	(*CPUHotspot)(nil),                  // 10: coral.colony.v1.CPUHotspot
+	(*DeploymentContext)(nil),           // 11: coral.colony.v1.DeploymentContext
+	(*RegressionIndicator)(nil),         // 12: coral.colony.v1.RegressionIndicator
+	(*QueryUnifiedTracesRequest)(nil),   // 13: coral.colony.v1.QueryUnifiedTracesRequest
+	(*QueryUnifiedTracesResponse)(nil),  // 14: coral.colony.v1.QueryUnifiedTracesResponse
+	(*QueryUnifiedMetricsRequest)(nil),  // 15: coral.colony.v1.QueryUnifiedMetricsRequest
+	(*QueryUnifiedMetricsResponse)(nil), // 16: coral.colony.v1.QueryUnifiedMetricsResponse
+	(*FederationError)(nil),             // 17: coral.colony.v1.FederationError
+	(*QueryUnifiedLogsRequest)(nil),     // 18: coral.colony.v1.QueryUnifiedLogsRequest
+	(*UnifiedLogEntry)(nil),             // 19: coral.colony.v1.UnifiedLogEntry
+	(*QueryUnifiedLogsResponse)(nil),    // 20: coral.colony.v1.QueryUnifiedLogsResponse
+	(*CompareDeploymentsRequest)(nil),   // 21: coral.colony.v1.CompareDeploymentsRequest
+	(*DeploymentWindowStats)(nil),       // 22: coral.colony.v1.DeploymentWindowStats
+	(*NewErrorSignature)(nil),           // 23: coral.colony.v1.NewErrorSignature
+	(*CompareDeploymentsResponse)(nil),  // 24: coral.colony.v1.CompareDeploymentsResponse
+	(*QueryErrorsRequest)(nil),          // 25: coral.colony.v1.QueryErrorsRequest
+	(*ErrorGroup)(nil),                  // 26: coral.colony.v1.ErrorGroup
+	(*QueryErrorsResponse)(nil),         // 27: coral.colony.v1.QueryErrorsResponse
+	(*QuerySLORequest)(nil),             // 28: coral.colony.v1.QuerySLORequest
+	(*SLOBurnRate)(nil),                 // 29: coral.colony.v1.SLOBurnRate
+	(*SLOStatus)(nil),                   // 30: coral.colony.v1.SLOStatus
+	(*QuerySLOResponse)(nil),            // 31: coral.colony.v1.QuerySLOResponse
+	(*QueryAnomaliesRequest)(nil),       // 32: coral.colony.v1.QueryAnomaliesRequest
+	(*Anomaly)(nil),                     // 33: coral.colony.v1.Anomaly
+	(*QueryAnomaliesResponse)(nil),      // 34: coral.colony.v1.QueryAnomaliesResponse
+	(*ListServicesRequest)(nil),         // 35: coral.colony.v1.ListServicesRequest
+	(*ListServicesResponse)(nil),        // 36: coral.colony.v1.ListServicesResponse
+	(*ServiceSummary)(nil),              // 37: coral.colony.v1.ServiceSummary
+	(*GetMetricPercentileRequest)(nil),  // 38: coral.colony.v1.GetMetricPercentileRequest
+	(*GetMetricPercentileResponse)(nil), // 39: coral.colony.v1.GetMetricPercentileResponse
+	(*GetServiceActivityRequest)(nil),   // 40: coral.colony.v1.GetServiceActivityRequest
+	(*GetServiceActivityResponse)(nil),  // 41: coral.colony.v1.GetServiceActivityResponse
+	(*ListServiceActivityRequest)(nil),  // 42: coral.colony.v1.ListServiceActivityRequest
+	(*ListServiceActivityResponse)(nil), // 43: coral.colony.v1.ListServiceActivityResponse
+	(*ServiceActivity)(nil),             // 44: coral.colony.v1.ServiceActivity
+	(*ExecuteQueryRequest)(nil),         // 45: coral.colony.v1.ExecuteQueryRequest
+	(*ExecuteQueryResponse)(nil),        // 46: coral.colony.v1.ExecuteQueryResponse
+	(*QueryRow)(nil),                    // 47: coral.colony.v1.QueryRow
+	(*QuerySQLRequest)(nil),             // 48: coral.colony.v1.QuerySQLRequest
+	(*QuerySQLResponse)(nil),            // 49: coral.colony.v1.QuerySQLResponse
+	nil,                                 // 50: coral.colony.v1.UnifiedLogEntry.AttributesEntry
+	nil,                                 // 51: coral.colony.v1.ServiceSummary.LabelsEntry
+	(*timestamppb.Timestamp)(nil),       // 52: google.protobuf.Timestamp
+	(*v1.EbpfTraceSpan)(nil),            // 53: coral.agent.v1.EbpfTraceSpan
+	(*v1.EbpfHttpMetric)(nil),           // 54: coral.agent.v1.EbpfHttpMetric
+	(*v1.EbpfGrpcMetric)(nil),           // 55: coral.agent.v1.EbpfGrpcMetric
+	(*v1.EbpfSqlMetric)(nil),            // 56: coral.agent.v1.EbpfSqlMetric
+	(*durationpb.Duration)(nil),         // 57: google.protobuf.Duration
 }
 var file_coral_colony_v1_queries_proto_depIdxs = []int32{
-	6,  // 0: coral.colony.v1.UnifiedSummaryResult.profiling_summary:type_name -> coral.colony.v1.ProfilingSummary
-	9,  // 1: coral.colony.v1.UnifiedSummaryResult.deployment:type_name -> coral.colony.v1.DeploymentContext
-	10, // 2: coral.colony.v1.UnifiedSummaryResult.regressions:type_name -> coral.colony.v1.RegressionIndicator
-	4,  // 3: coral.colony.v1.QueryUnifiedSummaryResponse.summaries:type_name -> coral.colony.v1.UnifiedSummaryResult
-	8,  // 4: coral.colony.v1.ProfilingSummary.top_cpu_hotspots:type_name -> coral.colony.v1.CPUHotspot
-	7,  // 5: coral.colony.v1.ProfilingSummary.top_memory_hotspots:type_name -> coral.colony.v1.MemoryHotspot
-	32, // 6: coral.colony.v1.DeploymentContext.deployed_at:type_name -> google.protobuf.Timestamp
-	0,  // 7: coral.colony.v1.RegressionIndicator.type:type_name -> coral.colony.v1.RegressionType
-	33, // 8: coral.colony.v1.QueryUnifiedTracesResponse.spans:type_name -> coral.agent.v1.EbpfTraceSpan
-	34, // 9: coral.colony.v1.QueryUnifiedMetricsResponse.http_metrics:type_name -> coral.agent.v1.EbpfHttpMetric
-	35, // 10: coral.colony.v1.QueryUnifiedMetricsResponse.grpc_metrics:type_name -> coral.agent.v1.EbpfGrpcMetric
-	36, // 11: coral.colony.v1.QueryUnifiedMetricsResponse.sql_metrics:type_name -> coral.agent.v1.EbpfSqlMetric
-	31, // 12: coral.colony.v1.UnifiedLogEntry.attributes:type_name -> coral.colony.v1.UnifiedLogEntry.AttributesEntry
-	16, // 13: coral.colony.v1.QueryUnifiedLogsResponse.logs:type_name -> coral.colony.v1.UnifiedLogEntry
-	1,  // 14: coral.colony.v1.ListServicesRequest.source_filter:type_name -> coral.colony.v1.ServiceSource
-	20, // 15: coral.colony.v1.ListServicesResponse.services:type_name -> coral.colony.v1.ServiceSummary
-	32, // 16: coral.colony.v1.ServiceSummary.last_seen:type_name -> google.protobuf.Timestamp
-	1,  // 17: coral.colony.v1.ServiceSummary.source:type_name -> coral.colony.v1.ServiceSource
-	2,  // 18: coral.colony.v1.ServiceSummary.status:type_name -> coral.colony.v1.ServiceStatus
-	32, // 19: coral.colony.v1.GetMetricPercentileResponse.timestamp:type_name -> google.protobuf.Timestamp
-	32, // 20: coral.colony.v1.GetServiceActivityResponse.timestamp:type_name -> google.protobuf.Timestamp
-	27, // 21: coral.colony.v1.ListServiceActivityResponse.services:type_name -> coral.colony.v1.ServiceActivity
-	30, // 22: coral.colony.v1.ExecuteQueryResponse.rows:type_name -> coral.colony.v1.QueryRow
-	23, // [23:23] is the sub-list for method output_type
-	23, // [23:23] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	8,  // 0: coral.colony.v1.UnifiedSummaryResult.profiling_summary:type_name -> coral.colony.v1.ProfilingSummary
+	11, // 1: coral.colony.v1.UnifiedSummaryResult.deployment:type_name -> coral.colony.v1.DeploymentContext
+	12, // 2: coral.colony.v1.UnifiedSummaryResult.regressions:type_name -> coral.colony.v1.RegressionIndicator
+	5,  // 3: coral.colony.v1.UnifiedSummaryResult.baseline:type_name -> coral.colony.v1.ServiceBaseline
+	6,  // 4: coral.colony.v1.ServiceBaseline.requests_per_second:type_name -> coral.colony.v1.MetricBaseline
+	6,  // 5: coral.colony.v1.ServiceBaseline.p95_latency_ms:type_name -> coral.colony.v1.MetricBaseline
+	6,  // 6: coral.colony.v1.ServiceBaseline.error_rate:type_name -> coral.colony.v1.MetricBaseline
+	4,  // 7: coral.colony.v1.QueryUnifiedSummaryResponse.summaries:type_name -> coral.colony.v1.UnifiedSummaryResult
+	17, // 8: coral.colony.v1.QueryUnifiedSummaryResponse.federation_errors:type_name -> coral.colony.v1.FederationError
+	10, // 9: coral.colony.v1.ProfilingSummary.top_cpu_hotspots:type_name -> coral.colony.v1.CPUHotspot
+	9,  // 10: coral.colony.v1.ProfilingSummary.top_memory_hotspots:type_name -> coral.colony.v1.MemoryHotspot
+	52, // 11: coral.colony.v1.DeploymentContext.deployed_at:type_name -> google.protobuf.Timestamp
+	0,  // 12: coral.colony.v1.RegressionIndicator.type:type_name -> coral.colony.v1.RegressionType
+	53, // 13: coral.colony.v1.QueryUnifiedTracesResponse.spans:type_name -> coral.agent.v1.EbpfTraceSpan
+	17, // 14: coral.colony.v1.QueryUnifiedTracesResponse.federation_errors:type_name -> coral.colony.v1.FederationError
+	54, // 15: coral.colony.v1.QueryUnifiedMetricsResponse.http_metrics:type_name -> coral.agent.v1.EbpfHttpMetric
+	55, // 16: coral.colony.v1.QueryUnifiedMetricsResponse.grpc_metrics:type_name -> coral.agent.v1.EbpfGrpcMetric
+	56, // 17: coral.colony.v1.QueryUnifiedMetricsResponse.sql_metrics:type_name -> coral.agent.v1.EbpfSqlMetric
+	17, // 18: coral.colony.v1.QueryUnifiedMetricsResponse.federation_errors:type_name -> coral.colony.v1.FederationError
+	50, // 19: coral.colony.v1.UnifiedLogEntry.attributes:type_name -> coral.colony.v1.UnifiedLogEntry.AttributesEntry
+	19, // 20: coral.colony.v1.QueryUnifiedLogsResponse.logs:type_name -> coral.colony.v1.UnifiedLogEntry
+	52, // 21: coral.colony.v1.CompareDeploymentsRequest.deploy_time:type_name -> google.protobuf.Timestamp
+	52, // 22: coral.colony.v1.DeploymentWindowStats.start_time:type_name -> google.protobuf.Timestamp
+	52, // 23: coral.colony.v1.DeploymentWindowStats.end_time:type_name -> google.protobuf.Timestamp
+	52, // 24: coral.colony.v1.CompareDeploymentsResponse.deploy_time:type_name -> google.protobuf.Timestamp
+	22, // 25: coral.colony.v1.CompareDeploymentsResponse.baseline:type_name -> coral.colony.v1.DeploymentWindowStats
+	22, // 26: coral.colony.v1.CompareDeploymentsResponse.current:type_name -> coral.colony.v1.DeploymentWindowStats
+	12, // 27: coral.colony.v1.CompareDeploymentsResponse.cpu_regressions:type_name -> coral.colony.v1.RegressionIndicator
+	23, // 28: coral.colony.v1.CompareDeploymentsResponse.new_errors:type_name -> coral.colony.v1.NewErrorSignature
+	52, // 29: coral.colony.v1.ErrorGroup.first_seen:type_name -> google.protobuf.Timestamp
+	52, // 30: coral.colony.v1.ErrorGroup.last_seen:type_name -> google.protobuf.Timestamp
+	26, // 31: coral.colony.v1.QueryErrorsResponse.groups:type_name -> coral.colony.v1.ErrorGroup
+	52, // 32: coral.colony.v1.QueryErrorsResponse.start_time:type_name -> google.protobuf.Timestamp
+	52, // 33: coral.colony.v1.QueryErrorsResponse.end_time:type_name -> google.protobuf.Timestamp
+	57, // 34: coral.colony.v1.SLOBurnRate.window:type_name -> google.protobuf.Duration
+	57, // 35: coral.colony.v1.SLOStatus.period:type_name -> google.protobuf.Duration
+	29, // 36: coral.colony.v1.SLOStatus.burn_rates:type_name -> coral.colony.v1.SLOBurnRate
+	30, // 37: coral.colony.v1.QuerySLOResponse.slos:type_name -> coral.colony.v1.SLOStatus
+	52, // 38: coral.colony.v1.Anomaly.timestamp:type_name -> google.protobuf.Timestamp
+	33, // 39: coral.colony.v1.QueryAnomaliesResponse.anomalies:type_name -> coral.colony.v1.Anomaly
+	52, // 40: coral.colony.v1.QueryAnomaliesResponse.baselines_learned_at:type_name -> google.protobuf.Timestamp
+	1,  // 41: coral.colony.v1.ListServicesRequest.source_filter:type_name -> coral.colony.v1.ServiceSource
+	37, // 42: coral.colony.v1.ListServicesResponse.services:type_name -> coral.colony.v1.ServiceSummary
+	52, // 43: coral.colony.v1.ServiceSummary.last_seen:type_name -> google.protobuf.Timestamp
+	1,  // 44: coral.colony.v1.ServiceSummary.source:type_name -> coral.colony.v1.ServiceSource
+	2,  // 45: coral.colony.v1.ServiceSummary.status:type_name -> coral.colony.v1.ServiceStatus
+	51, // 46: coral.colony.v1.ServiceSummary.labels:type_name -> coral.colony.v1.ServiceSummary.LabelsEntry
+	52, // 47: coral.colony.v1.GetMetricPercentileResponse.timestamp:type_name -> google.protobuf.Timestamp
+	52, // 48: coral.colony.v1.GetServiceActivityResponse.timestamp:type_name -> google.protobuf.Timestamp
+	44, // 49: coral.colony.v1.ListServiceActivityResponse.services:type_name -> coral.colony.v1.ServiceActivity
+	47, // 50: coral.colony.v1.ExecuteQueryResponse.rows:type_name -> coral.colony.v1.QueryRow
+	47, // 51: coral.colony.v1.QuerySQLResponse.rows:type_name -> coral.colony.v1.QueryRow
+	52, // [52:52] is the sub-list for method output_type
+	52, // [52:52] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_coral_colony_v1_queries_proto_init() }
@@ -2526,15 +4404,15 @@ func file_coral_colony_v1_queries_proto_init() {
 	if File_coral_colony_v1_queries_proto != nil {
 		return
 	}
-	file_coral_colony_v1_queries_proto_msgTypes[15].OneofWrappers = []any{}
-	file_coral_colony_v1_queries_proto_msgTypes[17].OneofWrappers = []any{}
+	file_coral_colony_v1_queries_proto_msgTypes[32].OneofWrappers = []any{}
+	file_coral_colony_v1_queries_proto_msgTypes[34].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_coral_colony_v1_queries_proto_rawDesc), len(file_coral_colony_v1_queries_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   29,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
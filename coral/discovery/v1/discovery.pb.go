@@ -146,8 +146,12 @@ type RegisterColonyRequest struct {
 	PublicPort uint32 `protobuf:"varint,9,opt,name=public_port,json=publicPort,proto3" json:"public_port,omitempty"`
 	// Public HTTPS endpoint information for CLI access (RFD 085).
 	PublicEndpoint *PublicEndpointInfo `protobuf:"bytes,10,opt,name=public_endpoint,json=publicEndpoint,proto3" json:"public_endpoint,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Proof that the registrant holds the colony secret (optional).
+	// Discovery pins the public key on first registration of a mesh ID and
+	// rejects later registrations signed with another key.
+	Signature     *RegistrationSignature `protobuf:"bytes,11,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegisterColonyRequest) Reset() {
@@ -250,6 +254,97 @@ func (x *RegisterColonyRequest) GetPublicEndpoint() *PublicEndpointInfo {
 	return nil
 }
 
+func (x *RegisterColonyRequest) GetSignature() *RegistrationSignature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// RegistrationSignature authenticates a colony registration.
+type RegistrationSignature struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Ed25519 public key derived from the colony secret.
+	PublicKey []byte `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Unix time (seconds) when the request was signed; stale signatures are rejected.
+	SignedAt int64 `protobuf:"varint,2,opt,name=signed_at,json=signedAt,proto3" json:"signed_at,omitempty"`
+	// Ed25519 signature over the canonical registration payload
+	// (mesh_id, pubkey, endpoints and signed_at).
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	// While the colony secret is rotated: the public key derived from the
+	// previous secret, and its signature endorsing public_key, so that the
+	// discovery service can re-pin the mesh to the new key.
+	PreviousPublicKey []byte `protobuf:"bytes,4,opt,name=previous_public_key,json=previousPublicKey,proto3" json:"previous_public_key,omitempty"`
+	PreviousSignature []byte `protobuf:"bytes,5,opt,name=previous_signature,json=previousSignature,proto3" json:"previous_signature,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RegistrationSignature) Reset() {
+	*x = RegistrationSignature{}
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistrationSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistrationSignature) ProtoMessage() {}
+
+func (x *RegistrationSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistrationSignature.ProtoReflect.Descriptor instead.
+func (*RegistrationSignature) Descriptor() ([]byte, []int) {
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegistrationSignature) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *RegistrationSignature) GetSignedAt() int64 {
+	if x != nil {
+		return x.SignedAt
+	}
+	return 0
+}
+
+func (x *RegistrationSignature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *RegistrationSignature) GetPreviousPublicKey() []byte {
+	if x != nil {
+		return x.PreviousPublicKey
+	}
+	return nil
+}
+
+func (x *RegistrationSignature) GetPreviousSignature() []byte {
+	if x != nil {
+		return x.PreviousSignature
+	}
+	return nil
+}
+
 type RegisterColonyResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Registration successful
@@ -270,7 +365,7 @@ type RegisterColonyResponse struct {
 
 func (x *RegisterColonyResponse) Reset() {
 	*x = RegisterColonyResponse{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[1]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -282,7 +377,7 @@ func (x *RegisterColonyResponse) String() string {
 func (*RegisterColonyResponse) ProtoMessage() {}
 
 func (x *RegisterColonyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[1]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +390,7 @@ func (x *RegisterColonyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterColonyResponse.ProtoReflect.Descriptor instead.
 func (*RegisterColonyResponse) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{1}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *RegisterColonyResponse) GetSuccess() bool {
@@ -344,7 +439,7 @@ type LookupColonyRequest struct {
 
 func (x *LookupColonyRequest) Reset() {
 	*x = LookupColonyRequest{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[2]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -356,7 +451,7 @@ func (x *LookupColonyRequest) String() string {
 func (*LookupColonyRequest) ProtoMessage() {}
 
 func (x *LookupColonyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[2]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +464,7 @@ func (x *LookupColonyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LookupColonyRequest.ProtoReflect.Descriptor instead.
 func (*LookupColonyRequest) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{2}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LookupColonyRequest) GetMeshId() string {
@@ -413,7 +508,7 @@ type LookupColonyResponse struct {
 
 func (x *LookupColonyResponse) Reset() {
 	*x = LookupColonyResponse{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[3]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -425,7 +520,7 @@ func (x *LookupColonyResponse) String() string {
 func (*LookupColonyResponse) ProtoMessage() {}
 
 func (x *LookupColonyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[3]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -438,7 +533,7 @@ func (x *LookupColonyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LookupColonyResponse.ProtoReflect.Descriptor instead.
 func (*LookupColonyResponse) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{3}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *LookupColonyResponse) GetMeshId() string {
@@ -541,7 +636,7 @@ type HealthRequest struct {
 
 func (x *HealthRequest) Reset() {
 	*x = HealthRequest{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[4]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -553,7 +648,7 @@ func (x *HealthRequest) String() string {
 func (*HealthRequest) ProtoMessage() {}
 
 func (x *HealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[4]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -566,7 +661,7 @@ func (x *HealthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
 func (*HealthRequest) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{4}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{5}
 }
 
 type HealthResponse struct {
@@ -585,7 +680,7 @@ type HealthResponse struct {
 
 func (x *HealthResponse) Reset() {
 	*x = HealthResponse{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[5]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -597,7 +692,7 @@ func (x *HealthResponse) String() string {
 func (*HealthResponse) ProtoMessage() {}
 
 func (x *HealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[5]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -610,7 +705,7 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
 func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{5}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *HealthResponse) GetStatus() string {
@@ -658,7 +753,7 @@ type Endpoint struct {
 
 func (x *Endpoint) Reset() {
 	*x = Endpoint{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[6]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -670,7 +765,7 @@ func (x *Endpoint) String() string {
 func (*Endpoint) ProtoMessage() {}
 
 func (x *Endpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[6]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -683,7 +778,7 @@ func (x *Endpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
 func (*Endpoint) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{6}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Endpoint) GetIp() string {
@@ -731,7 +826,7 @@ type RelayOption struct {
 
 func (x *RelayOption) Reset() {
 	*x = RelayOption{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[7]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -743,7 +838,7 @@ func (x *RelayOption) String() string {
 func (*RelayOption) ProtoMessage() {}
 
 func (x *RelayOption) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[7]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -756,7 +851,7 @@ func (x *RelayOption) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RelayOption.ProtoReflect.Descriptor instead.
 func (*RelayOption) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{7}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RelayOption) GetEndpoint() *Endpoint {
@@ -802,7 +897,7 @@ type RequestRelayRequest struct {
 
 func (x *RequestRelayRequest) Reset() {
 	*x = RequestRelayRequest{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[8]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -814,7 +909,7 @@ func (x *RequestRelayRequest) String() string {
 func (*RequestRelayRequest) ProtoMessage() {}
 
 func (x *RequestRelayRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[8]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -827,7 +922,7 @@ func (x *RequestRelayRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestRelayRequest.ProtoReflect.Descriptor instead.
 func (*RequestRelayRequest) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{8}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RequestRelayRequest) GetMeshId() string {
@@ -868,7 +963,7 @@ type RequestRelayResponse struct {
 
 func (x *RequestRelayResponse) Reset() {
 	*x = RequestRelayResponse{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[9]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -880,7 +975,7 @@ func (x *RequestRelayResponse) String() string {
 func (*RequestRelayResponse) ProtoMessage() {}
 
 func (x *RequestRelayResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[9]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -893,7 +988,7 @@ func (x *RequestRelayResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestRelayResponse.ProtoReflect.Descriptor instead.
 func (*RequestRelayResponse) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{9}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RequestRelayResponse) GetRelayEndpoint() *Endpoint {
@@ -937,7 +1032,7 @@ type ReleaseRelayRequest struct {
 
 func (x *ReleaseRelayRequest) Reset() {
 	*x = ReleaseRelayRequest{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[10]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -949,7 +1044,7 @@ func (x *ReleaseRelayRequest) String() string {
 func (*ReleaseRelayRequest) ProtoMessage() {}
 
 func (x *ReleaseRelayRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[10]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -962,7 +1057,7 @@ func (x *ReleaseRelayRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseRelayRequest.ProtoReflect.Descriptor instead.
 func (*ReleaseRelayRequest) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{10}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ReleaseRelayRequest) GetSessionId() string {
@@ -990,7 +1085,7 @@ type ReleaseRelayResponse struct {
 
 func (x *ReleaseRelayResponse) Reset() {
 	*x = ReleaseRelayResponse{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[11]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1002,7 +1097,7 @@ func (x *ReleaseRelayResponse) String() string {
 func (*ReleaseRelayResponse) ProtoMessage() {}
 
 func (x *ReleaseRelayResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[11]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +1110,7 @@ func (x *ReleaseRelayResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReleaseRelayResponse.ProtoReflect.Descriptor instead.
 func (*ReleaseRelayResponse) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{11}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ReleaseRelayResponse) GetSuccess() bool {
@@ -1039,14 +1134,17 @@ type RegisterAgentRequest struct {
 	// Observed endpoint from external STUN server (optional)
 	ObservedEndpoint *Endpoint `protobuf:"bytes,5,opt,name=observed_endpoint,json=observedEndpoint,proto3" json:"observed_endpoint,omitempty"`
 	// Optional metadata
-	Metadata      map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Referral ticket issued by CreateBootstrapToken for this agent and mesh
+	// (RFD 049), proving the agent was admitted to the mesh (optional).
+	ReferralTicket string `protobuf:"bytes,7,opt,name=referral_ticket,json=referralTicket,proto3" json:"referral_ticket,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RegisterAgentRequest) Reset() {
 	*x = RegisterAgentRequest{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[12]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1058,7 +1156,7 @@ func (x *RegisterAgentRequest) String() string {
 func (*RegisterAgentRequest) ProtoMessage() {}
 
 func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[12]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,7 +1169,7 @@ func (x *RegisterAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterAgentRequest.ProtoReflect.Descriptor instead.
 func (*RegisterAgentRequest) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{12}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *RegisterAgentRequest) GetAgentId() string {
@@ -1116,6 +1214,13 @@ func (x *RegisterAgentRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *RegisterAgentRequest) GetReferralTicket() string {
+	if x != nil {
+		return x.ReferralTicket
+	}
+	return ""
+}
+
 // RegisterAgentResponse returns registration result.
 type RegisterAgentResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1137,7 +1242,7 @@ type RegisterAgentResponse struct {
 
 func (x *RegisterAgentResponse) Reset() {
 	*x = RegisterAgentResponse{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[13]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1149,7 +1254,7 @@ func (x *RegisterAgentResponse) String() string {
 func (*RegisterAgentResponse) ProtoMessage() {}
 
 func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[13]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,7 +1267,7 @@ func (x *RegisterAgentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterAgentResponse.ProtoReflect.Descriptor instead.
 func (*RegisterAgentResponse) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{13}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RegisterAgentResponse) GetSuccess() bool {
@@ -1215,7 +1320,7 @@ type LookupAgentRequest struct {
 
 func (x *LookupAgentRequest) Reset() {
 	*x = LookupAgentRequest{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[14]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1227,7 +1332,7 @@ func (x *LookupAgentRequest) String() string {
 func (*LookupAgentRequest) ProtoMessage() {}
 
 func (x *LookupAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[14]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1240,7 +1345,7 @@ func (x *LookupAgentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LookupAgentRequest.ProtoReflect.Descriptor instead.
 func (*LookupAgentRequest) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{14}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *LookupAgentRequest) GetAgentId() string {
@@ -1282,7 +1387,7 @@ type LookupAgentResponse struct {
 
 func (x *LookupAgentResponse) Reset() {
 	*x = LookupAgentResponse{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[15]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1294,7 +1399,7 @@ func (x *LookupAgentResponse) String() string {
 func (*LookupAgentResponse) ProtoMessage() {}
 
 func (x *LookupAgentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[15]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1307,7 +1412,7 @@ func (x *LookupAgentResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LookupAgentResponse.ProtoReflect.Descriptor instead.
 func (*LookupAgentResponse) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{15}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *LookupAgentResponse) GetAgentId() string {
@@ -1386,7 +1491,7 @@ type PublicEndpointInfo struct {
 
 func (x *PublicEndpointInfo) Reset() {
 	*x = PublicEndpointInfo{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[16]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1398,7 +1503,7 @@ func (x *PublicEndpointInfo) String() string {
 func (*PublicEndpointInfo) ProtoMessage() {}
 
 func (x *PublicEndpointInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[16]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1411,7 +1516,7 @@ func (x *PublicEndpointInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicEndpointInfo.ProtoReflect.Descriptor instead.
 func (*PublicEndpointInfo) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{16}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *PublicEndpointInfo) GetEnabled() bool {
@@ -1463,7 +1568,7 @@ type CertificateFingerprint struct {
 
 func (x *CertificateFingerprint) Reset() {
 	*x = CertificateFingerprint{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[17]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1475,7 +1580,7 @@ func (x *CertificateFingerprint) String() string {
 func (*CertificateFingerprint) ProtoMessage() {}
 
 func (x *CertificateFingerprint) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[17]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1488,7 +1593,7 @@ func (x *CertificateFingerprint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CertificateFingerprint.ProtoReflect.Descriptor instead.
 func (*CertificateFingerprint) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{17}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *CertificateFingerprint) GetAlgorithm() FingerprintAlgorithm {
@@ -1522,7 +1627,7 @@ type CreateBootstrapTokenRequest struct {
 
 func (x *CreateBootstrapTokenRequest) Reset() {
 	*x = CreateBootstrapTokenRequest{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[18]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1534,7 +1639,7 @@ func (x *CreateBootstrapTokenRequest) String() string {
 func (*CreateBootstrapTokenRequest) ProtoMessage() {}
 
 func (x *CreateBootstrapTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[18]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1547,7 +1652,7 @@ func (x *CreateBootstrapTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBootstrapTokenRequest.ProtoReflect.Descriptor instead.
 func (*CreateBootstrapTokenRequest) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{18}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *CreateBootstrapTokenRequest) GetReefId() string {
@@ -1591,7 +1696,7 @@ type CreateBootstrapTokenResponse struct {
 
 func (x *CreateBootstrapTokenResponse) Reset() {
 	*x = CreateBootstrapTokenResponse{}
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[19]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1603,7 +1708,7 @@ func (x *CreateBootstrapTokenResponse) String() string {
 func (*CreateBootstrapTokenResponse) ProtoMessage() {}
 
 func (x *CreateBootstrapTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[19]
+	mi := &file_coral_discovery_v1_discovery_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1616,7 +1721,7 @@ func (x *CreateBootstrapTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBootstrapTokenResponse.ProtoReflect.Descriptor instead.
 func (*CreateBootstrapTokenResponse) Descriptor() ([]byte, []int) {
-	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{19}
+	return file_coral_discovery_v1_discovery_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *CreateBootstrapTokenResponse) GetJwt() string {
@@ -1637,7 +1742,7 @@ var File_coral_discovery_v1_discovery_proto protoreflect.FileDescriptor
 
 const file_coral_discovery_v1_discovery_proto_rawDesc = "" +
 	"\n" +
-	"\"coral/discovery/v1/discovery.proto\x12\x12coral.discovery.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x92\x04\n" +
+	"\"coral/discovery/v1/discovery.proto\x12\x12coral.discovery.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdb\x04\n" +
 	"\x15RegisterColonyRequest\x12\x17\n" +
 	"\amesh_id\x18\x01 \x01(\tR\x06meshId\x12\x16\n" +
 	"\x06pubkey\x18\x02 \x01(\tR\x06pubkey\x12\x1c\n" +
@@ -1650,10 +1755,18 @@ const file_coral_discovery_v1_discovery_proto_rawDesc = "" +
 	"\vpublic_port\x18\t \x01(\rR\n" +
 	"publicPort\x12O\n" +
 	"\x0fpublic_endpoint\x18\n" +
-	" \x01(\v2&.coral.discovery.v1.PublicEndpointInfoR\x0epublicEndpoint\x1a;\n" +
+	" \x01(\v2&.coral.discovery.v1.PublicEndpointInfoR\x0epublicEndpoint\x12G\n" +
+	"\tsignature\x18\v \x01(\v2).coral.discovery.v1.RegistrationSignatureR\tsignature\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xed\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd0\x01\n" +
+	"\x15RegistrationSignature\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x01 \x01(\fR\tpublicKey\x12\x1b\n" +
+	"\tsigned_at\x18\x02 \x01(\x03R\bsignedAt\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\fR\tsignature\x12.\n" +
+	"\x13previous_public_key\x18\x04 \x01(\fR\x11previousPublicKey\x12-\n" +
+	"\x12previous_signature\x18\x05 \x01(\fR\x11previousSignature\"\xed\x01\n" +
 	"\x16RegisterColonyResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x10\n" +
 	"\x03ttl\x18\x02 \x01(\x05R\x03ttl\x129\n" +
@@ -1714,14 +1827,15 @@ const file_coral_discovery_v1_discovery_proto_rawDesc = "" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
 	"\amesh_id\x18\x02 \x01(\tR\x06meshId\"0\n" +
 	"\x14ReleaseRelayResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xdc\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x85\x03\n" +
 	"\x14RegisterAgentRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x17\n" +
 	"\amesh_id\x18\x02 \x01(\tR\x06meshId\x12\x16\n" +
 	"\x06pubkey\x18\x03 \x01(\tR\x06pubkey\x12\x1c\n" +
 	"\tendpoints\x18\x04 \x03(\tR\tendpoints\x12I\n" +
 	"\x11observed_endpoint\x18\x05 \x01(\v2\x1c.coral.discovery.v1.EndpointR\x10observedEndpoint\x12R\n" +
-	"\bmetadata\x18\x06 \x03(\v26.coral.discovery.v1.RegisterAgentRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x06 \x03(\v26.coral.discovery.v1.RegisterAgentRequest.MetadataEntryR\bmetadata\x12'\n" +
+	"\x0freferral_ticket\x18\a \x01(\tR\x0ereferralTicket\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xec\x01\n" +
@@ -1798,83 +1912,85 @@ func file_coral_discovery_v1_discovery_proto_rawDescGZIP() []byte {
 }
 
 var file_coral_discovery_v1_discovery_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_coral_discovery_v1_discovery_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_coral_discovery_v1_discovery_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_coral_discovery_v1_discovery_proto_goTypes = []any{
 	(NatHint)(0),                         // 0: coral.discovery.v1.NatHint
 	(FingerprintAlgorithm)(0),            // 1: coral.discovery.v1.FingerprintAlgorithm
 	(*RegisterColonyRequest)(nil),        // 2: coral.discovery.v1.RegisterColonyRequest
-	(*RegisterColonyResponse)(nil),       // 3: coral.discovery.v1.RegisterColonyResponse
-	(*LookupColonyRequest)(nil),          // 4: coral.discovery.v1.LookupColonyRequest
-	(*LookupColonyResponse)(nil),         // 5: coral.discovery.v1.LookupColonyResponse
-	(*HealthRequest)(nil),                // 6: coral.discovery.v1.HealthRequest
-	(*HealthResponse)(nil),               // 7: coral.discovery.v1.HealthResponse
-	(*Endpoint)(nil),                     // 8: coral.discovery.v1.Endpoint
-	(*RelayOption)(nil),                  // 9: coral.discovery.v1.RelayOption
-	(*RequestRelayRequest)(nil),          // 10: coral.discovery.v1.RequestRelayRequest
-	(*RequestRelayResponse)(nil),         // 11: coral.discovery.v1.RequestRelayResponse
-	(*ReleaseRelayRequest)(nil),          // 12: coral.discovery.v1.ReleaseRelayRequest
-	(*ReleaseRelayResponse)(nil),         // 13: coral.discovery.v1.ReleaseRelayResponse
-	(*RegisterAgentRequest)(nil),         // 14: coral.discovery.v1.RegisterAgentRequest
-	(*RegisterAgentResponse)(nil),        // 15: coral.discovery.v1.RegisterAgentResponse
-	(*LookupAgentRequest)(nil),           // 16: coral.discovery.v1.LookupAgentRequest
-	(*LookupAgentResponse)(nil),          // 17: coral.discovery.v1.LookupAgentResponse
-	(*PublicEndpointInfo)(nil),           // 18: coral.discovery.v1.PublicEndpointInfo
-	(*CertificateFingerprint)(nil),       // 19: coral.discovery.v1.CertificateFingerprint
-	(*CreateBootstrapTokenRequest)(nil),  // 20: coral.discovery.v1.CreateBootstrapTokenRequest
-	(*CreateBootstrapTokenResponse)(nil), // 21: coral.discovery.v1.CreateBootstrapTokenResponse
-	nil,                                  // 22: coral.discovery.v1.RegisterColonyRequest.MetadataEntry
-	nil,                                  // 23: coral.discovery.v1.LookupColonyResponse.MetadataEntry
-	nil,                                  // 24: coral.discovery.v1.RegisterAgentRequest.MetadataEntry
-	nil,                                  // 25: coral.discovery.v1.LookupAgentResponse.MetadataEntry
-	(*timestamppb.Timestamp)(nil),        // 26: google.protobuf.Timestamp
+	(*RegistrationSignature)(nil),        // 3: coral.discovery.v1.RegistrationSignature
+	(*RegisterColonyResponse)(nil),       // 4: coral.discovery.v1.RegisterColonyResponse
+	(*LookupColonyRequest)(nil),          // 5: coral.discovery.v1.LookupColonyRequest
+	(*LookupColonyResponse)(nil),         // 6: coral.discovery.v1.LookupColonyResponse
+	(*HealthRequest)(nil),                // 7: coral.discovery.v1.HealthRequest
+	(*HealthResponse)(nil),               // 8: coral.discovery.v1.HealthResponse
+	(*Endpoint)(nil),                     // 9: coral.discovery.v1.Endpoint
+	(*RelayOption)(nil),                  // 10: coral.discovery.v1.RelayOption
+	(*RequestRelayRequest)(nil),          // 11: coral.discovery.v1.RequestRelayRequest
+	(*RequestRelayResponse)(nil),         // 12: coral.discovery.v1.RequestRelayResponse
+	(*ReleaseRelayRequest)(nil),          // 13: coral.discovery.v1.ReleaseRelayRequest
+	(*ReleaseRelayResponse)(nil),         // 14: coral.discovery.v1.ReleaseRelayResponse
+	(*RegisterAgentRequest)(nil),         // 15: coral.discovery.v1.RegisterAgentRequest
+	(*RegisterAgentResponse)(nil),        // 16: coral.discovery.v1.RegisterAgentResponse
+	(*LookupAgentRequest)(nil),           // 17: coral.discovery.v1.LookupAgentRequest
+	(*LookupAgentResponse)(nil),          // 18: coral.discovery.v1.LookupAgentResponse
+	(*PublicEndpointInfo)(nil),           // 19: coral.discovery.v1.PublicEndpointInfo
+	(*CertificateFingerprint)(nil),       // 20: coral.discovery.v1.CertificateFingerprint
+	(*CreateBootstrapTokenRequest)(nil),  // 21: coral.discovery.v1.CreateBootstrapTokenRequest
+	(*CreateBootstrapTokenResponse)(nil), // 22: coral.discovery.v1.CreateBootstrapTokenResponse
+	nil,                                  // 23: coral.discovery.v1.RegisterColonyRequest.MetadataEntry
+	nil,                                  // 24: coral.discovery.v1.LookupColonyResponse.MetadataEntry
+	nil,                                  // 25: coral.discovery.v1.RegisterAgentRequest.MetadataEntry
+	nil,                                  // 26: coral.discovery.v1.LookupAgentResponse.MetadataEntry
+	(*timestamppb.Timestamp)(nil),        // 27: google.protobuf.Timestamp
 }
 var file_coral_discovery_v1_discovery_proto_depIdxs = []int32{
-	22, // 0: coral.discovery.v1.RegisterColonyRequest.metadata:type_name -> coral.discovery.v1.RegisterColonyRequest.MetadataEntry
-	8,  // 1: coral.discovery.v1.RegisterColonyRequest.observed_endpoint:type_name -> coral.discovery.v1.Endpoint
-	18, // 2: coral.discovery.v1.RegisterColonyRequest.public_endpoint:type_name -> coral.discovery.v1.PublicEndpointInfo
-	26, // 3: coral.discovery.v1.RegisterColonyResponse.expires_at:type_name -> google.protobuf.Timestamp
-	8,  // 4: coral.discovery.v1.RegisterColonyResponse.observed_endpoint:type_name -> coral.discovery.v1.Endpoint
-	23, // 5: coral.discovery.v1.LookupColonyResponse.metadata:type_name -> coral.discovery.v1.LookupColonyResponse.MetadataEntry
-	26, // 6: coral.discovery.v1.LookupColonyResponse.last_seen:type_name -> google.protobuf.Timestamp
-	8,  // 7: coral.discovery.v1.LookupColonyResponse.observed_endpoints:type_name -> coral.discovery.v1.Endpoint
-	0,  // 8: coral.discovery.v1.LookupColonyResponse.nat:type_name -> coral.discovery.v1.NatHint
-	9,  // 9: coral.discovery.v1.LookupColonyResponse.relays:type_name -> coral.discovery.v1.RelayOption
-	18, // 10: coral.discovery.v1.LookupColonyResponse.public_endpoint:type_name -> coral.discovery.v1.PublicEndpointInfo
-	8,  // 11: coral.discovery.v1.RelayOption.endpoint:type_name -> coral.discovery.v1.Endpoint
-	8,  // 12: coral.discovery.v1.RequestRelayResponse.relay_endpoint:type_name -> coral.discovery.v1.Endpoint
-	26, // 13: coral.discovery.v1.RequestRelayResponse.expires_at:type_name -> google.protobuf.Timestamp
-	8,  // 14: coral.discovery.v1.RegisterAgentRequest.observed_endpoint:type_name -> coral.discovery.v1.Endpoint
-	24, // 15: coral.discovery.v1.RegisterAgentRequest.metadata:type_name -> coral.discovery.v1.RegisterAgentRequest.MetadataEntry
-	26, // 16: coral.discovery.v1.RegisterAgentResponse.expires_at:type_name -> google.protobuf.Timestamp
-	8,  // 17: coral.discovery.v1.RegisterAgentResponse.observed_endpoint:type_name -> coral.discovery.v1.Endpoint
-	8,  // 18: coral.discovery.v1.LookupAgentResponse.observed_endpoints:type_name -> coral.discovery.v1.Endpoint
-	0,  // 19: coral.discovery.v1.LookupAgentResponse.nat:type_name -> coral.discovery.v1.NatHint
-	25, // 20: coral.discovery.v1.LookupAgentResponse.metadata:type_name -> coral.discovery.v1.LookupAgentResponse.MetadataEntry
-	26, // 21: coral.discovery.v1.LookupAgentResponse.last_seen:type_name -> google.protobuf.Timestamp
-	19, // 22: coral.discovery.v1.PublicEndpointInfo.ca_fingerprint:type_name -> coral.discovery.v1.CertificateFingerprint
-	26, // 23: coral.discovery.v1.PublicEndpointInfo.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 24: coral.discovery.v1.CertificateFingerprint.algorithm:type_name -> coral.discovery.v1.FingerprintAlgorithm
-	2,  // 25: coral.discovery.v1.DiscoveryService.RegisterColony:input_type -> coral.discovery.v1.RegisterColonyRequest
-	4,  // 26: coral.discovery.v1.DiscoveryService.LookupColony:input_type -> coral.discovery.v1.LookupColonyRequest
-	14, // 27: coral.discovery.v1.DiscoveryService.RegisterAgent:input_type -> coral.discovery.v1.RegisterAgentRequest
-	16, // 28: coral.discovery.v1.DiscoveryService.LookupAgent:input_type -> coral.discovery.v1.LookupAgentRequest
-	10, // 29: coral.discovery.v1.DiscoveryService.RequestRelay:input_type -> coral.discovery.v1.RequestRelayRequest
-	12, // 30: coral.discovery.v1.DiscoveryService.ReleaseRelay:input_type -> coral.discovery.v1.ReleaseRelayRequest
-	6,  // 31: coral.discovery.v1.DiscoveryService.Health:input_type -> coral.discovery.v1.HealthRequest
-	20, // 32: coral.discovery.v1.DiscoveryService.CreateBootstrapToken:input_type -> coral.discovery.v1.CreateBootstrapTokenRequest
-	3,  // 33: coral.discovery.v1.DiscoveryService.RegisterColony:output_type -> coral.discovery.v1.RegisterColonyResponse
-	5,  // 34: coral.discovery.v1.DiscoveryService.LookupColony:output_type -> coral.discovery.v1.LookupColonyResponse
-	15, // 35: coral.discovery.v1.DiscoveryService.RegisterAgent:output_type -> coral.discovery.v1.RegisterAgentResponse
-	17, // 36: coral.discovery.v1.DiscoveryService.LookupAgent:output_type -> coral.discovery.v1.LookupAgentResponse
-	11, // 37: coral.discovery.v1.DiscoveryService.RequestRelay:output_type -> coral.discovery.v1.RequestRelayResponse
-	13, // 38: coral.discovery.v1.DiscoveryService.ReleaseRelay:output_type -> coral.discovery.v1.ReleaseRelayResponse
-	7,  // 39: coral.discovery.v1.DiscoveryService.Health:output_type -> coral.discovery.v1.HealthResponse
-	21, // 40: coral.discovery.v1.DiscoveryService.CreateBootstrapToken:output_type -> coral.discovery.v1.CreateBootstrapTokenResponse
-	33, // [33:41] is the sub-list for method output_type
-	25, // [25:33] is the sub-list for method input_type
-	25, // [25:25] is the sub-list for extension type_name
-	25, // [25:25] is the sub-list for extension extendee
-	0,  // [0:25] is the sub-list for field type_name
+	23, // 0: coral.discovery.v1.RegisterColonyRequest.metadata:type_name -> coral.discovery.v1.RegisterColonyRequest.MetadataEntry
+	9,  // 1: coral.discovery.v1.RegisterColonyRequest.observed_endpoint:type_name -> coral.discovery.v1.Endpoint
+	19, // 2: coral.discovery.v1.RegisterColonyRequest.public_endpoint:type_name -> coral.discovery.v1.PublicEndpointInfo
+	3,  // 3: coral.discovery.v1.RegisterColonyRequest.signature:type_name -> coral.discovery.v1.RegistrationSignature
+	27, // 4: coral.discovery.v1.RegisterColonyResponse.expires_at:type_name -> google.protobuf.Timestamp
+	9,  // 5: coral.discovery.v1.RegisterColonyResponse.observed_endpoint:type_name -> coral.discovery.v1.Endpoint
+	24, // 6: coral.discovery.v1.LookupColonyResponse.metadata:type_name -> coral.discovery.v1.LookupColonyResponse.MetadataEntry
+	27, // 7: coral.discovery.v1.LookupColonyResponse.last_seen:type_name -> google.protobuf.Timestamp
+	9,  // 8: coral.discovery.v1.LookupColonyResponse.observed_endpoints:type_name -> coral.discovery.v1.Endpoint
+	0,  // 9: coral.discovery.v1.LookupColonyResponse.nat:type_name -> coral.discovery.v1.NatHint
+	10, // 10: coral.discovery.v1.LookupColonyResponse.relays:type_name -> coral.discovery.v1.RelayOption
+	19, // 11: coral.discovery.v1.LookupColonyResponse.public_endpoint:type_name -> coral.discovery.v1.PublicEndpointInfo
+	9,  // 12: coral.discovery.v1.RelayOption.endpoint:type_name -> coral.discovery.v1.Endpoint
+	9,  // 13: coral.discovery.v1.RequestRelayResponse.relay_endpoint:type_name -> coral.discovery.v1.Endpoint
+	27, // 14: coral.discovery.v1.RequestRelayResponse.expires_at:type_name -> google.protobuf.Timestamp
+	9,  // 15: coral.discovery.v1.RegisterAgentRequest.observed_endpoint:type_name -> coral.discovery.v1.Endpoint
+	25, // 16: coral.discovery.v1.RegisterAgentRequest.metadata:type_name -> coral.discovery.v1.RegisterAgentRequest.MetadataEntry
+	27, // 17: coral.discovery.v1.RegisterAgentResponse.expires_at:type_name -> google.protobuf.Timestamp
+	9,  // 18: coral.discovery.v1.RegisterAgentResponse.observed_endpoint:type_name -> coral.discovery.v1.Endpoint
+	9,  // 19: coral.discovery.v1.LookupAgentResponse.observed_endpoints:type_name -> coral.discovery.v1.Endpoint
+	0,  // 20: coral.discovery.v1.LookupAgentResponse.nat:type_name -> coral.discovery.v1.NatHint
+	26, // 21: coral.discovery.v1.LookupAgentResponse.metadata:type_name -> coral.discovery.v1.LookupAgentResponse.MetadataEntry
+	27, // 22: coral.discovery.v1.LookupAgentResponse.last_seen:type_name -> google.protobuf.Timestamp
+	20, // 23: coral.discovery.v1.PublicEndpointInfo.ca_fingerprint:type_name -> coral.discovery.v1.CertificateFingerprint
+	27, // 24: coral.discovery.v1.PublicEndpointInfo.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 25: coral.discovery.v1.CertificateFingerprint.algorithm:type_name -> coral.discovery.v1.FingerprintAlgorithm
+	2,  // 26: coral.discovery.v1.DiscoveryService.RegisterColony:input_type -> coral.discovery.v1.RegisterColonyRequest
+	5,  // 27: coral.discovery.v1.DiscoveryService.LookupColony:input_type -> coral.discovery.v1.LookupColonyRequest
+	15, // 28: coral.discovery.v1.DiscoveryService.RegisterAgent:input_type -> coral.discovery.v1.RegisterAgentRequest
+	17, // 29: coral.discovery.v1.DiscoveryService.LookupAgent:input_type -> coral.discovery.v1.LookupAgentRequest
+	11, // 30: coral.discovery.v1.DiscoveryService.RequestRelay:input_type -> coral.discovery.v1.RequestRelayRequest
+	13, // 31: coral.discovery.v1.DiscoveryService.ReleaseRelay:input_type -> coral.discovery.v1.ReleaseRelayRequest
+	7,  // 32: coral.discovery.v1.DiscoveryService.Health:input_type -> coral.discovery.v1.HealthRequest
+	21, // 33: coral.discovery.v1.DiscoveryService.CreateBootstrapToken:input_type -> coral.discovery.v1.CreateBootstrapTokenRequest
+	4,  // 34: coral.discovery.v1.DiscoveryService.RegisterColony:output_type -> coral.discovery.v1.RegisterColonyResponse
+	6,  // 35: coral.discovery.v1.DiscoveryService.LookupColony:output_type -> coral.discovery.v1.LookupColonyResponse
+	16, // 36: coral.discovery.v1.DiscoveryService.RegisterAgent:output_type -> coral.discovery.v1.RegisterAgentResponse
+	18, // 37: coral.discovery.v1.DiscoveryService.LookupAgent:output_type -> coral.discovery.v1.LookupAgentResponse
+	12, // 38: coral.discovery.v1.DiscoveryService.RequestRelay:output_type -> coral.discovery.v1.RequestRelayResponse
+	14, // 39: coral.discovery.v1.DiscoveryService.ReleaseRelay:output_type -> coral.discovery.v1.ReleaseRelayResponse
+	8,  // 40: coral.discovery.v1.DiscoveryService.Health:output_type -> coral.discovery.v1.HealthResponse
+	22, // 41: coral.discovery.v1.DiscoveryService.CreateBootstrapToken:output_type -> coral.discovery.v1.CreateBootstrapTokenResponse
+	34, // [34:42] is the sub-list for method output_type
+	26, // [26:34] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_coral_discovery_v1_discovery_proto_init() }
@@ -1888,7 +2004,7 @@ func file_coral_discovery_v1_discovery_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_coral_discovery_v1_discovery_proto_rawDesc), len(file_coral_discovery_v1_discovery_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   24,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
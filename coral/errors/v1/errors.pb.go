@@ -0,0 +1,248 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: coral/errors/v1/errors.proto
+
+package errorsv1
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ErrorCode classifies a failure so that it can be handled without parsing
+// the error message. Agents and the colony attach it to Connect errors as an
+// ErrorInfo detail, and the CLI maps it to an exit code.
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED ErrorCode = 0
+	// The colony could not reach an agent over the mesh.
+	ErrorCode_ERROR_CODE_AGENT_UNREACHABLE ErrorCode = 1
+	// No agent with the requested ID is registered.
+	ErrorCode_ERROR_CODE_AGENT_NOT_FOUND ErrorCode = 2
+	// No agent runs the requested service.
+	ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND ErrorCode = 3
+	// An eBPF probe could not be attached to the target process.
+	ErrorCode_ERROR_CODE_PROBE_ATTACH_FAILED ErrorCode = 4
+	// The caller is authenticated but lacks the required permission.
+	ErrorCode_ERROR_CODE_PERMISSION_DENIED ErrorCode = 5
+	// The caller did not present valid credentials.
+	ErrorCode_ERROR_CODE_UNAUTHENTICATED ErrorCode = 6
+	// The CLI could not reach the colony.
+	ErrorCode_ERROR_CODE_COLONY_UNREACHABLE ErrorCode = 7
+	// The request is malformed.
+	ErrorCode_ERROR_CODE_INVALID_ARGUMENT ErrorCode = 8
+	// The operation did not complete in time.
+	ErrorCode_ERROR_CODE_TIMEOUT ErrorCode = 9
+	// The agent is drained: the colony starts no new sessions on it.
+	ErrorCode_ERROR_CODE_AGENT_DRAINED ErrorCode = 10
+	// The service is frozen: the colony starts no new sessions against it.
+	ErrorCode_ERROR_CODE_SERVICE_FROZEN ErrorCode = 11
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0:  "ERROR_CODE_UNSPECIFIED",
+		1:  "ERROR_CODE_AGENT_UNREACHABLE",
+		2:  "ERROR_CODE_AGENT_NOT_FOUND",
+		3:  "ERROR_CODE_SERVICE_NOT_FOUND",
+		4:  "ERROR_CODE_PROBE_ATTACH_FAILED",
+		5:  "ERROR_CODE_PERMISSION_DENIED",
+		6:  "ERROR_CODE_UNAUTHENTICATED",
+		7:  "ERROR_CODE_COLONY_UNREACHABLE",
+		8:  "ERROR_CODE_INVALID_ARGUMENT",
+		9:  "ERROR_CODE_TIMEOUT",
+		10: "ERROR_CODE_AGENT_DRAINED",
+		11: "ERROR_CODE_SERVICE_FROZEN",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":         0,
+		"ERROR_CODE_AGENT_UNREACHABLE":   1,
+		"ERROR_CODE_AGENT_NOT_FOUND":     2,
+		"ERROR_CODE_SERVICE_NOT_FOUND":   3,
+		"ERROR_CODE_PROBE_ATTACH_FAILED": 4,
+		"ERROR_CODE_PERMISSION_DENIED":   5,
+		"ERROR_CODE_UNAUTHENTICATED":     6,
+		"ERROR_CODE_COLONY_UNREACHABLE":  7,
+		"ERROR_CODE_INVALID_ARGUMENT":    8,
+		"ERROR_CODE_TIMEOUT":             9,
+		"ERROR_CODE_AGENT_DRAINED":       10,
+		"ERROR_CODE_SERVICE_FROZEN":      11,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_coral_errors_v1_errors_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_coral_errors_v1_errors_proto_enumTypes[0]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_coral_errors_v1_errors_proto_rawDescGZIP(), []int{0}
+}
+
+// ErrorInfo is the Connect error detail carrying the code of a failure.
+type ErrorInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Code  ErrorCode              `protobuf:"varint,1,opt,name=code,proto3,enum=coral.errors.v1.ErrorCode" json:"code,omitempty"`
+	// Context of the failure, such as agent_id, service or function.
+	Metadata      map[string]string `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorInfo) Reset() {
+	*x = ErrorInfo{}
+	mi := &file_coral_errors_v1_errors_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorInfo) ProtoMessage() {}
+
+func (x *ErrorInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_errors_v1_errors_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorInfo.ProtoReflect.Descriptor instead.
+func (*ErrorInfo) Descriptor() ([]byte, []int) {
+	return file_coral_errors_v1_errors_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ErrorInfo) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *ErrorInfo) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+var File_coral_errors_v1_errors_proto protoreflect.FileDescriptor
+
+const file_coral_errors_v1_errors_proto_rawDesc = "" +
+	"\n" +
+	"\x1ccoral/errors/v1/errors.proto\x12\x0fcoral.errors.v1\"\xbe\x01\n" +
+	"\tErrorInfo\x12.\n" +
+	"\x04code\x18\x01 \x01(\x0e2\x1a.coral.errors.v1.ErrorCodeR\x04code\x12D\n" +
+	"\bmetadata\x18\x02 \x03(\v2(.coral.errors.v1.ErrorInfo.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*\x8a\x03\n" +
+	"\tErrorCode\x12\x1a\n" +
+	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cERROR_CODE_AGENT_UNREACHABLE\x10\x01\x12\x1e\n" +
+	"\x1aERROR_CODE_AGENT_NOT_FOUND\x10\x02\x12 \n" +
+	"\x1cERROR_CODE_SERVICE_NOT_FOUND\x10\x03\x12\"\n" +
+	"\x1eERROR_CODE_PROBE_ATTACH_FAILED\x10\x04\x12 \n" +
+	"\x1cERROR_CODE_PERMISSION_DENIED\x10\x05\x12\x1e\n" +
+	"\x1aERROR_CODE_UNAUTHENTICATED\x10\x06\x12!\n" +
+	"\x1dERROR_CODE_COLONY_UNREACHABLE\x10\a\x12\x1f\n" +
+	"\x1bERROR_CODE_INVALID_ARGUMENT\x10\b\x12\x16\n" +
+	"\x12ERROR_CODE_TIMEOUT\x10\t\x12\x1c\n" +
+	"\x18ERROR_CODE_AGENT_DRAINED\x10\n" +
+	"\x12\x1d\n" +
+	"\x19ERROR_CODE_SERVICE_FROZEN\x10\vB\xb6\x01\n" +
+	"\x13com.coral.errors.v1B\vErrorsProtoP\x01Z4github.com/coral-mesh/coral/coral/errors/v1;errorsv1\xa2\x02\x03CEX\xaa\x02\x0fCoral.Errors.V1\xca\x02\x0fCoral\\Errors\\V1\xe2\x02\x1bCoral\\Errors\\V1\\GPBMetadata\xea\x02\x11Coral::Errors::V1b\x06proto3"
+
+var (
+	file_coral_errors_v1_errors_proto_rawDescOnce sync.Once
+	file_coral_errors_v1_errors_proto_rawDescData []byte
+)
+
+func file_coral_errors_v1_errors_proto_rawDescGZIP() []byte {
+	file_coral_errors_v1_errors_proto_rawDescOnce.Do(func() {
+		file_coral_errors_v1_errors_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_coral_errors_v1_errors_proto_rawDesc), len(file_coral_errors_v1_errors_proto_rawDesc)))
+	})
+	return file_coral_errors_v1_errors_proto_rawDescData
+}
+
+var file_coral_errors_v1_errors_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_coral_errors_v1_errors_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_coral_errors_v1_errors_proto_goTypes = []any{
+	(ErrorCode)(0),    // 0: coral.errors.v1.ErrorCode
+	(*ErrorInfo)(nil), // 1: coral.errors.v1.ErrorInfo
+	nil,               // 2: coral.errors.v1.ErrorInfo.MetadataEntry
+}
+var file_coral_errors_v1_errors_proto_depIdxs = []int32{
+	0, // 0: coral.errors.v1.ErrorInfo.code:type_name -> coral.errors.v1.ErrorCode
+	2, // 1: coral.errors.v1.ErrorInfo.metadata:type_name -> coral.errors.v1.ErrorInfo.MetadataEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_coral_errors_v1_errors_proto_init() }
+func file_coral_errors_v1_errors_proto_init() {
+	if File_coral_errors_v1_errors_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_coral_errors_v1_errors_proto_rawDesc), len(file_coral_errors_v1_errors_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_coral_errors_v1_errors_proto_goTypes,
+		DependencyIndexes: file_coral_errors_v1_errors_proto_depIdxs,
+		EnumInfos:         file_coral_errors_v1_errors_proto_enumTypes,
+		MessageInfos:      file_coral_errors_v1_errors_proto_msgTypes,
+	}.Build()
+	File_coral_errors_v1_errors_proto = out.File
+	file_coral_errors_v1_errors_proto_goTypes = nil
+	file_coral_errors_v1_errors_proto_depIdxs = nil
+}
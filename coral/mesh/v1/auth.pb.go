@@ -149,7 +149,8 @@ type RegisterRequest struct {
 	Services []*ServiceInfo `protobuf:"bytes,10,rep,name=services,proto3" json:"services,omitempty"`
 	// NEW: Runtime context (RFD 018).
 	RuntimeContext *v1.RuntimeContextResponse `protobuf:"bytes,11,opt,name=runtime_context,json=runtimeContext,proto3" json:"runtime_context,omitempty"`
-	// Protocol version (RFD 018).
+	// Protocol version (RFD 018), e.g. "2". Agents that do not report one
+	// speak version 1.
 	ProtocolVersion string `protobuf:"bytes,12,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
 	// NEW: eBPF capabilities (RFD 013).
 	EbpfCapabilities *v1.EbpfCapabilities `protobuf:"bytes,13,opt,name=ebpf_capabilities,json=ebpfCapabilities,proto3" json:"ebpf_capabilities,omitempty"`
@@ -275,9 +276,19 @@ type RegisterResponse struct {
 	MeshSubnet string      `protobuf:"bytes,4,opt,name=mesh_subnet,json=meshSubnet,proto3" json:"mesh_subnet,omitempty"` // Colony's mesh subnet (e.g., "10.100.0.0/16")
 	Peers      []*PeerInfo `protobuf:"bytes,5,rep,name=peers,proto3" json:"peers,omitempty"`                             // Other agents in mesh
 	// Colony info
-	RegisteredAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	RegisteredAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=registered_at,json=registeredAt,proto3" json:"registered_at,omitempty"`
+	// IPv6 mesh assignment, empty if the colony has no IPv6 mesh network.
+	AssignedIpv6   string `protobuf:"bytes,7,opt,name=assigned_ipv6,json=assignedIpv6,proto3" json:"assigned_ipv6,omitempty"`         // Agent's IPv6 in WireGuard mesh (e.g., "fd42::2a")
+	MeshSubnetIpv6 string `protobuf:"bytes,8,opt,name=mesh_subnet_ipv6,json=meshSubnetIpv6,proto3" json:"mesh_subnet_ipv6,omitempty"` // Colony's IPv6 mesh subnet (e.g., "fd42::/48")
+	// Protocol versions the colony accepts (RFD 018). Set on rejections with
+	// reason "unsupported_protocol_version" too.
+	MinProtocolVersion uint32 `protobuf:"varint,9,opt,name=min_protocol_version,json=minProtocolVersion,proto3" json:"min_protocol_version,omitempty"`
+	MaxProtocolVersion uint32 `protobuf:"varint,10,opt,name=max_protocol_version,json=maxProtocolVersion,proto3" json:"max_protocol_version,omitempty"`
+	// Protocol version negotiated for the agent: the lower of the agent's and
+	// max_protocol_version.
+	ProtocolVersion uint32 `protobuf:"varint,11,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *RegisterResponse) Reset() {
@@ -352,6 +363,41 @@ func (x *RegisterResponse) GetRegisteredAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RegisterResponse) GetAssignedIpv6() string {
+	if x != nil {
+		return x.AssignedIpv6
+	}
+	return ""
+}
+
+func (x *RegisterResponse) GetMeshSubnetIpv6() string {
+	if x != nil {
+		return x.MeshSubnetIpv6
+	}
+	return ""
+}
+
+func (x *RegisterResponse) GetMinProtocolVersion() uint32 {
+	if x != nil {
+		return x.MinProtocolVersion
+	}
+	return 0
+}
+
+func (x *RegisterResponse) GetMaxProtocolVersion() uint32 {
+	if x != nil {
+		return x.MaxProtocolVersion
+	}
+	return 0
+}
+
+func (x *RegisterResponse) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
 type PeerInfo struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	AgentId         string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
@@ -427,7 +473,18 @@ type HeartbeatRequest struct {
 	// Optional: agent can report current status
 	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "healthy", "degraded", "unhealthy"
 	// Optional: updated service information
-	Services      []*ServiceInfo `protobuf:"bytes,3,rep,name=services,proto3" json:"services,omitempty"`
+	Services []*ServiceInfo `protobuf:"bytes,3,rep,name=services,proto3" json:"services,omitempty"`
+	// Optional: resource safety valve state. Sent immediately when the agent
+	// starts or stops shedding load.
+	ResourceShedding *v1.ResourceShedding `protobuf:"bytes,4,opt,name=resource_shedding,json=resourceShedding,proto3" json:"resource_shedding,omitempty"`
+	// Optional: agent health metrics the colony uses to score agent health.
+	HealthMetrics *AgentHealthMetrics `protobuf:"bytes,5,opt,name=health_metrics,json=healthMetrics,proto3" json:"health_metrics,omitempty"`
+	// Agent clock time when the heartbeat was sent, used to detect clock skew.
+	SentAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=sent_at,json=sentAt,proto3" json:"sent_at,omitempty"`
+	// Optional: agent version, to track release rollouts.
+	Version string `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
+	// Optional: why the agent failed to install the advertised release.
+	UpdateError   string `protobuf:"bytes,8,opt,name=update_error,json=updateError,proto3" json:"update_error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -483,18 +540,111 @@ func (x *HeartbeatRequest) GetServices() []*ServiceInfo {
 	return nil
 }
 
+func (x *HeartbeatRequest) GetResourceShedding() *v1.ResourceShedding {
+	if x != nil {
+		return x.ResourceShedding
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetHealthMetrics() *AgentHealthMetrics {
+	if x != nil {
+		return x.HealthMetrics
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
+}
+
+func (x *HeartbeatRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *HeartbeatRequest) GetUpdateError() string {
+	if x != nil {
+		return x.UpdateError
+	}
+	return ""
+}
+
+// AgentHealthMetrics reports agent internals that degrade debugging
+// capability before the agent stops responding.
+type AgentHealthMetrics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// uprobe events buffered in memory and not yet written to the local store.
+	QueueDepth uint32 `protobuf:"varint,1,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	// eBPF collectors that failed to load or attach in the recent window.
+	EbpfFailures  uint32 `protobuf:"varint,2,opt,name=ebpf_failures,json=ebpfFailures,proto3" json:"ebpf_failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentHealthMetrics) Reset() {
+	*x = AgentHealthMetrics{}
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentHealthMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentHealthMetrics) ProtoMessage() {}
+
+func (x *AgentHealthMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentHealthMetrics.ProtoReflect.Descriptor instead.
+func (*AgentHealthMetrics) Descriptor() ([]byte, []int) {
+	return file_coral_mesh_v1_auth_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AgentHealthMetrics) GetQueueDepth() uint32 {
+	if x != nil {
+		return x.QueueDepth
+	}
+	return 0
+}
+
+func (x *AgentHealthMetrics) GetEbpfFailures() uint32 {
+	if x != nil {
+		return x.EbpfFailures
+	}
+	return 0
+}
+
 type HeartbeatResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Ok    bool                   `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
 	// Colony can send commands to agent (future use)
-	Commands      []string `protobuf:"bytes,2,rep,name=commands,proto3" json:"commands,omitempty"`
+	Commands []string `protobuf:"bytes,2,rep,name=commands,proto3" json:"commands,omitempty"`
+	// Agent release the colony wants the agent to run, if any.
+	AgentUpdate   *AgentUpdate `protobuf:"bytes,3,opt,name=agent_update,json=agentUpdate,proto3" json:"agent_update,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *HeartbeatResponse) Reset() {
 	*x = HeartbeatResponse{}
-	mi := &file_coral_mesh_v1_auth_proto_msgTypes[5]
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -506,7 +656,7 @@ func (x *HeartbeatResponse) String() string {
 func (*HeartbeatResponse) ProtoMessage() {}
 
 func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_coral_mesh_v1_auth_proto_msgTypes[5]
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,7 +669,7 @@ func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
 func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_coral_mesh_v1_auth_proto_rawDescGZIP(), []int{5}
+	return file_coral_mesh_v1_auth_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *HeartbeatResponse) GetOk() bool {
@@ -536,6 +686,206 @@ func (x *HeartbeatResponse) GetCommands() []string {
 	return nil
 }
 
+func (x *HeartbeatResponse) GetAgentUpdate() *AgentUpdate {
+	if x != nil {
+		return x.AgentUpdate
+	}
+	return nil
+}
+
+// AgentUpdate advertises an agent release.
+type AgentUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Release version, e.g. "v0.5.0".
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// The release (version and executables with their SHA-256) signed with
+	// the colony's policy signing key. Agents only install what it holds.
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentUpdate) Reset() {
+	*x = AgentUpdate{}
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentUpdate) ProtoMessage() {}
+
+func (x *AgentUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentUpdate.ProtoReflect.Descriptor instead.
+func (*AgentUpdate) Descriptor() ([]byte, []int) {
+	return file_coral_mesh_v1_auth_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AgentUpdate) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *AgentUpdate) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// PunchHoleRequest asks the colony to coordinate hole punching with an agent
+// whose WireGuard tunnel does not come up.
+type PunchHoleRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AgentId         string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	WireguardPubkey string                 `protobuf:"bytes,2,opt,name=wireguard_pubkey,json=wireguardPubkey,proto3" json:"wireguard_pubkey,omitempty"`
+	// Endpoints ("ip:port") the colony should probe: the agent's STUN-observed
+	// endpoint and its host addresses on the WireGuard port.
+	Candidates []string `protobuf:"bytes,3,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// Agent's WireGuard listen port. The colony also probes it on the address
+	// this request came from.
+	ListenPort    uint32 `protobuf:"varint,4,opt,name=listen_port,json=listenPort,proto3" json:"listen_port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PunchHoleRequest) Reset() {
+	*x = PunchHoleRequest{}
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PunchHoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PunchHoleRequest) ProtoMessage() {}
+
+func (x *PunchHoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PunchHoleRequest.ProtoReflect.Descriptor instead.
+func (*PunchHoleRequest) Descriptor() ([]byte, []int) {
+	return file_coral_mesh_v1_auth_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PunchHoleRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *PunchHoleRequest) GetWireguardPubkey() string {
+	if x != nil {
+		return x.WireguardPubkey
+	}
+	return ""
+}
+
+func (x *PunchHoleRequest) GetCandidates() []string {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *PunchHoleRequest) GetListenPort() uint32 {
+	if x != nil {
+		return x.ListenPort
+	}
+	return 0
+}
+
+type PunchHoleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the attempt in probe packets sent by both peers.
+	SessionId []byte `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Endpoints ("ip:port") the agent should probe.
+	Candidates []string `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// When both peers start probing.
+	StartAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_at,json=startAt,proto3" json:"start_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PunchHoleResponse) Reset() {
+	*x = PunchHoleResponse{}
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PunchHoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PunchHoleResponse) ProtoMessage() {}
+
+func (x *PunchHoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_coral_mesh_v1_auth_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PunchHoleResponse.ProtoReflect.Descriptor instead.
+func (*PunchHoleResponse) Descriptor() ([]byte, []int) {
+	return file_coral_mesh_v1_auth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PunchHoleResponse) GetSessionId() []byte {
+	if x != nil {
+		return x.SessionId
+	}
+	return nil
+}
+
+func (x *PunchHoleResponse) GetCandidates() []string {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *PunchHoleResponse) GetStartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartAt
+	}
+	return nil
+}
+
 var File_coral_mesh_v1_auth_proto protoreflect.FileDescriptor
 
 const file_coral_mesh_v1_auth_proto_rawDesc = "" +
@@ -571,7 +921,7 @@ const file_coral_mesh_v1_auth_proto_rawDesc = "" +
 	"\x11ebpf_capabilities\x18\r \x01(\v2 .coral.agent.v1.EbpfCapabilitiesR\x10ebpfCapabilities\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf8\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd6\x03\n" +
 	"\x10RegisterResponse\x12\x1a\n" +
 	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1f\n" +
@@ -580,22 +930,57 @@ const file_coral_mesh_v1_auth_proto_rawDesc = "" +
 	"\vmesh_subnet\x18\x04 \x01(\tR\n" +
 	"meshSubnet\x12-\n" +
 	"\x05peers\x18\x05 \x03(\v2\x17.coral.mesh.v1.PeerInfoR\x05peers\x12?\n" +
-	"\rregistered_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\"\x90\x01\n" +
+	"\rregistered_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\fregisteredAt\x12#\n" +
+	"\rassigned_ipv6\x18\a \x01(\tR\fassignedIpv6\x12(\n" +
+	"\x10mesh_subnet_ipv6\x18\b \x01(\tR\x0emeshSubnetIpv6\x120\n" +
+	"\x14min_protocol_version\x18\t \x01(\rR\x12minProtocolVersion\x120\n" +
+	"\x14max_protocol_version\x18\n" +
+	" \x01(\rR\x12maxProtocolVersion\x12)\n" +
+	"\x10protocol_version\x18\v \x01(\rR\x0fprotocolVersion\"\x90\x01\n" +
 	"\bPeerInfo\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12%\n" +
 	"\x0ecomponent_name\x18\x02 \x01(\tR\rcomponentName\x12\x17\n" +
 	"\amesh_ip\x18\x03 \x01(\tR\x06meshIp\x12)\n" +
-	"\x10wireguard_pubkey\x18\x04 \x01(\tR\x0fwireguardPubkey\"}\n" +
+	"\x10wireguard_pubkey\x18\x04 \x01(\tR\x0fwireguardPubkey\"\x88\x03\n" +
 	"\x10HeartbeatRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x126\n" +
-	"\bservices\x18\x03 \x03(\v2\x1a.coral.mesh.v1.ServiceInfoR\bservices\"?\n" +
+	"\bservices\x18\x03 \x03(\v2\x1a.coral.mesh.v1.ServiceInfoR\bservices\x12M\n" +
+	"\x11resource_shedding\x18\x04 \x01(\v2 .coral.agent.v1.ResourceSheddingR\x10resourceShedding\x12H\n" +
+	"\x0ehealth_metrics\x18\x05 \x01(\v2!.coral.mesh.v1.AgentHealthMetricsR\rhealthMetrics\x123\n" +
+	"\asent_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06sentAt\x12\x18\n" +
+	"\aversion\x18\a \x01(\tR\aversion\x12!\n" +
+	"\fupdate_error\x18\b \x01(\tR\vupdateError\"Z\n" +
+	"\x12AgentHealthMetrics\x12\x1f\n" +
+	"\vqueue_depth\x18\x01 \x01(\rR\n" +
+	"queueDepth\x12#\n" +
+	"\rebpf_failures\x18\x02 \x01(\rR\febpfFailures\"~\n" +
 	"\x11HeartbeatResponse\x12\x0e\n" +
 	"\x02ok\x18\x01 \x01(\bR\x02ok\x12\x1a\n" +
-	"\bcommands\x18\x02 \x03(\tR\bcommands2\xaa\x01\n" +
+	"\bcommands\x18\x02 \x03(\tR\bcommands\x12=\n" +
+	"\fagent_update\x18\x03 \x01(\v2\x1a.coral.mesh.v1.AgentUpdateR\vagentUpdate\"=\n" +
+	"\vAgentUpdate\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x99\x01\n" +
+	"\x10PunchHoleRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12)\n" +
+	"\x10wireguard_pubkey\x18\x02 \x01(\tR\x0fwireguardPubkey\x12\x1e\n" +
+	"\n" +
+	"candidates\x18\x03 \x03(\tR\n" +
+	"candidates\x12\x1f\n" +
+	"\vlisten_port\x18\x04 \x01(\rR\n" +
+	"listenPort\"\x89\x01\n" +
+	"\x11PunchHoleResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\fR\tsessionId\x12\x1e\n" +
+	"\n" +
+	"candidates\x18\x02 \x03(\tR\n" +
+	"candidates\x125\n" +
+	"\bstart_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt2\xfa\x01\n" +
 	"\vMeshService\x12K\n" +
 	"\bRegister\x12\x1e.coral.mesh.v1.RegisterRequest\x1a\x1f.coral.mesh.v1.RegisterResponse\x12N\n" +
-	"\tHeartbeat\x12\x1f.coral.mesh.v1.HeartbeatRequest\x1a .coral.mesh.v1.HeartbeatResponseB\xa6\x01\n" +
+	"\tHeartbeat\x12\x1f.coral.mesh.v1.HeartbeatRequest\x1a .coral.mesh.v1.HeartbeatResponse\x12N\n" +
+	"\tPunchHole\x12\x1f.coral.mesh.v1.PunchHoleRequest\x1a .coral.mesh.v1.PunchHoleResponseB\xa6\x01\n" +
 	"\x11com.coral.mesh.v1B\tAuthProtoP\x01Z0github.com/coral-mesh/coral/coral/mesh/v1;meshv1\xa2\x02\x03CMX\xaa\x02\rCoral.Mesh.V1\xca\x02\rCoral\\Mesh\\V1\xe2\x02\x19Coral\\Mesh\\V1\\GPBMetadata\xea\x02\x0fCoral::Mesh::V1b\x06proto3"
 
 var (
@@ -610,38 +995,50 @@ func file_coral_mesh_v1_auth_proto_rawDescGZIP() []byte {
 	return file_coral_mesh_v1_auth_proto_rawDescData
 }
 
-var file_coral_mesh_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_coral_mesh_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_coral_mesh_v1_auth_proto_goTypes = []any{
 	(*ServiceInfo)(nil),               // 0: coral.mesh.v1.ServiceInfo
 	(*RegisterRequest)(nil),           // 1: coral.mesh.v1.RegisterRequest
 	(*RegisterResponse)(nil),          // 2: coral.mesh.v1.RegisterResponse
 	(*PeerInfo)(nil),                  // 3: coral.mesh.v1.PeerInfo
 	(*HeartbeatRequest)(nil),          // 4: coral.mesh.v1.HeartbeatRequest
-	(*HeartbeatResponse)(nil),         // 5: coral.mesh.v1.HeartbeatResponse
-	nil,                               // 6: coral.mesh.v1.ServiceInfo.LabelsEntry
-	nil,                               // 7: coral.mesh.v1.RegisterRequest.LabelsEntry
-	(*v1.RuntimeContextResponse)(nil), // 8: coral.agent.v1.RuntimeContextResponse
-	(*v1.EbpfCapabilities)(nil),       // 9: coral.agent.v1.EbpfCapabilities
-	(*timestamppb.Timestamp)(nil),     // 10: google.protobuf.Timestamp
+	(*AgentHealthMetrics)(nil),        // 5: coral.mesh.v1.AgentHealthMetrics
+	(*HeartbeatResponse)(nil),         // 6: coral.mesh.v1.HeartbeatResponse
+	(*AgentUpdate)(nil),               // 7: coral.mesh.v1.AgentUpdate
+	(*PunchHoleRequest)(nil),          // 8: coral.mesh.v1.PunchHoleRequest
+	(*PunchHoleResponse)(nil),         // 9: coral.mesh.v1.PunchHoleResponse
+	nil,                               // 10: coral.mesh.v1.ServiceInfo.LabelsEntry
+	nil,                               // 11: coral.mesh.v1.RegisterRequest.LabelsEntry
+	(*v1.RuntimeContextResponse)(nil), // 12: coral.agent.v1.RuntimeContextResponse
+	(*v1.EbpfCapabilities)(nil),       // 13: coral.agent.v1.EbpfCapabilities
+	(*timestamppb.Timestamp)(nil),     // 14: google.protobuf.Timestamp
+	(*v1.ResourceShedding)(nil),       // 15: coral.agent.v1.ResourceShedding
 }
 var file_coral_mesh_v1_auth_proto_depIdxs = []int32{
-	6,  // 0: coral.mesh.v1.ServiceInfo.labels:type_name -> coral.mesh.v1.ServiceInfo.LabelsEntry
-	7,  // 1: coral.mesh.v1.RegisterRequest.labels:type_name -> coral.mesh.v1.RegisterRequest.LabelsEntry
+	10, // 0: coral.mesh.v1.ServiceInfo.labels:type_name -> coral.mesh.v1.ServiceInfo.LabelsEntry
+	11, // 1: coral.mesh.v1.RegisterRequest.labels:type_name -> coral.mesh.v1.RegisterRequest.LabelsEntry
 	0,  // 2: coral.mesh.v1.RegisterRequest.services:type_name -> coral.mesh.v1.ServiceInfo
-	8,  // 3: coral.mesh.v1.RegisterRequest.runtime_context:type_name -> coral.agent.v1.RuntimeContextResponse
-	9,  // 4: coral.mesh.v1.RegisterRequest.ebpf_capabilities:type_name -> coral.agent.v1.EbpfCapabilities
+	12, // 3: coral.mesh.v1.RegisterRequest.runtime_context:type_name -> coral.agent.v1.RuntimeContextResponse
+	13, // 4: coral.mesh.v1.RegisterRequest.ebpf_capabilities:type_name -> coral.agent.v1.EbpfCapabilities
 	3,  // 5: coral.mesh.v1.RegisterResponse.peers:type_name -> coral.mesh.v1.PeerInfo
-	10, // 6: coral.mesh.v1.RegisterResponse.registered_at:type_name -> google.protobuf.Timestamp
+	14, // 6: coral.mesh.v1.RegisterResponse.registered_at:type_name -> google.protobuf.Timestamp
 	0,  // 7: coral.mesh.v1.HeartbeatRequest.services:type_name -> coral.mesh.v1.ServiceInfo
-	1,  // 8: coral.mesh.v1.MeshService.Register:input_type -> coral.mesh.v1.RegisterRequest
-	4,  // 9: coral.mesh.v1.MeshService.Heartbeat:input_type -> coral.mesh.v1.HeartbeatRequest
-	2,  // 10: coral.mesh.v1.MeshService.Register:output_type -> coral.mesh.v1.RegisterResponse
-	5,  // 11: coral.mesh.v1.MeshService.Heartbeat:output_type -> coral.mesh.v1.HeartbeatResponse
-	10, // [10:12] is the sub-list for method output_type
-	8,  // [8:10] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	15, // 8: coral.mesh.v1.HeartbeatRequest.resource_shedding:type_name -> coral.agent.v1.ResourceShedding
+	5,  // 9: coral.mesh.v1.HeartbeatRequest.health_metrics:type_name -> coral.mesh.v1.AgentHealthMetrics
+	14, // 10: coral.mesh.v1.HeartbeatRequest.sent_at:type_name -> google.protobuf.Timestamp
+	7,  // 11: coral.mesh.v1.HeartbeatResponse.agent_update:type_name -> coral.mesh.v1.AgentUpdate
+	14, // 12: coral.mesh.v1.PunchHoleResponse.start_at:type_name -> google.protobuf.Timestamp
+	1,  // 13: coral.mesh.v1.MeshService.Register:input_type -> coral.mesh.v1.RegisterRequest
+	4,  // 14: coral.mesh.v1.MeshService.Heartbeat:input_type -> coral.mesh.v1.HeartbeatRequest
+	8,  // 15: coral.mesh.v1.MeshService.PunchHole:input_type -> coral.mesh.v1.PunchHoleRequest
+	2,  // 16: coral.mesh.v1.MeshService.Register:output_type -> coral.mesh.v1.RegisterResponse
+	6,  // 17: coral.mesh.v1.MeshService.Heartbeat:output_type -> coral.mesh.v1.HeartbeatResponse
+	9,  // 18: coral.mesh.v1.MeshService.PunchHole:output_type -> coral.mesh.v1.PunchHoleResponse
+	16, // [16:19] is the sub-list for method output_type
+	13, // [13:16] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_coral_mesh_v1_auth_proto_init() }
@@ -655,7 +1052,7 @@ func file_coral_mesh_v1_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_coral_mesh_v1_auth_proto_rawDesc), len(file_coral_mesh_v1_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
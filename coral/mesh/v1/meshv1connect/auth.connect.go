@@ -38,6 +38,8 @@ const (
 	MeshServiceRegisterProcedure = "/coral.mesh.v1.MeshService/Register"
 	// MeshServiceHeartbeatProcedure is the fully-qualified name of the MeshService's Heartbeat RPC.
 	MeshServiceHeartbeatProcedure = "/coral.mesh.v1.MeshService/Heartbeat"
+	// MeshServicePunchHoleProcedure is the fully-qualified name of the MeshService's PunchHole RPC.
+	MeshServicePunchHoleProcedure = "/coral.mesh.v1.MeshService/PunchHole"
 )
 
 // MeshServiceClient is a client for the coral.mesh.v1.MeshService service.
@@ -46,6 +48,9 @@ type MeshServiceClient interface {
 	Register(context.Context, *connect.Request[v1.RegisterRequest]) (*connect.Response[v1.RegisterResponse], error)
 	// Send periodic heartbeat to update last_seen timestamp
 	Heartbeat(context.Context, *connect.Request[v1.HeartbeatRequest]) (*connect.Response[v1.HeartbeatResponse], error)
+	// Exchange candidate endpoints and start simultaneous probing, for agents
+	// whose tunnel does not come up behind NAT.
+	PunchHole(context.Context, *connect.Request[v1.PunchHoleRequest]) (*connect.Response[v1.PunchHoleResponse], error)
 }
 
 // NewMeshServiceClient constructs a client for the coral.mesh.v1.MeshService service. By default,
@@ -71,6 +76,12 @@ func NewMeshServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(meshServiceMethods.ByName("Heartbeat")),
 			connect.WithClientOptions(opts...),
 		),
+		punchHole: connect.NewClient[v1.PunchHoleRequest, v1.PunchHoleResponse](
+			httpClient,
+			baseURL+MeshServicePunchHoleProcedure,
+			connect.WithSchema(meshServiceMethods.ByName("PunchHole")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -78,6 +89,7 @@ func NewMeshServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 type meshServiceClient struct {
 	register  *connect.Client[v1.RegisterRequest, v1.RegisterResponse]
 	heartbeat *connect.Client[v1.HeartbeatRequest, v1.HeartbeatResponse]
+	punchHole *connect.Client[v1.PunchHoleRequest, v1.PunchHoleResponse]
 }
 
 // Register calls coral.mesh.v1.MeshService.Register.
@@ -90,12 +102,20 @@ func (c *meshServiceClient) Heartbeat(ctx context.Context, req *connect.Request[
 	return c.heartbeat.CallUnary(ctx, req)
 }
 
+// PunchHole calls coral.mesh.v1.MeshService.PunchHole.
+func (c *meshServiceClient) PunchHole(ctx context.Context, req *connect.Request[v1.PunchHoleRequest]) (*connect.Response[v1.PunchHoleResponse], error) {
+	return c.punchHole.CallUnary(ctx, req)
+}
+
 // MeshServiceHandler is an implementation of the coral.mesh.v1.MeshService service.
 type MeshServiceHandler interface {
 	// Register an agent with the colony
 	Register(context.Context, *connect.Request[v1.RegisterRequest]) (*connect.Response[v1.RegisterResponse], error)
 	// Send periodic heartbeat to update last_seen timestamp
 	Heartbeat(context.Context, *connect.Request[v1.HeartbeatRequest]) (*connect.Response[v1.HeartbeatResponse], error)
+	// Exchange candidate endpoints and start simultaneous probing, for agents
+	// whose tunnel does not come up behind NAT.
+	PunchHole(context.Context, *connect.Request[v1.PunchHoleRequest]) (*connect.Response[v1.PunchHoleResponse], error)
 }
 
 // NewMeshServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -117,12 +137,20 @@ func NewMeshServiceHandler(svc MeshServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(meshServiceMethods.ByName("Heartbeat")),
 		connect.WithHandlerOptions(opts...),
 	)
+	meshServicePunchHoleHandler := connect.NewUnaryHandler(
+		MeshServicePunchHoleProcedure,
+		svc.PunchHole,
+		connect.WithSchema(meshServiceMethods.ByName("PunchHole")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/coral.mesh.v1.MeshService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case MeshServiceRegisterProcedure:
 			meshServiceRegisterHandler.ServeHTTP(w, r)
 		case MeshServiceHeartbeatProcedure:
 			meshServiceHeartbeatHandler.ServeHTTP(w, r)
+		case MeshServicePunchHoleProcedure:
+			meshServicePunchHoleHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -139,3 +167,7 @@ func (UnimplementedMeshServiceHandler) Register(context.Context, *connect.Reques
 func (UnimplementedMeshServiceHandler) Heartbeat(context.Context, *connect.Request[v1.HeartbeatRequest]) (*connect.Response[v1.HeartbeatResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.mesh.v1.MeshService.Heartbeat is not implemented"))
 }
+
+func (UnimplementedMeshServiceHandler) PunchHole(context.Context, *connect.Request[v1.PunchHoleRequest]) (*connect.Response[v1.PunchHoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("coral.mesh.v1.MeshService.PunchHole is not implemented"))
+}
@@ -9,13 +9,22 @@ import (
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/agent/autodiscovery"
 	"github.com/coral-mesh/coral/internal/agent/beyla"
+	"github.com/coral-mesh/coral/internal/agent/coredump"
 	"github.com/coral-mesh/coral/internal/agent/correlation"
 	"github.com/coral-mesh/coral/internal/agent/debug"
 	"github.com/coral-mesh/coral/internal/agent/ebpf"
+	"github.com/coral-mesh/coral/internal/agent/redact"
+	"github.com/coral-mesh/coral/internal/agent/safety"
 	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
 )
 
 // AgentStatus represents the overall agent health status.
@@ -37,12 +46,16 @@ type Lifecycle interface {
 // Defined as an interface to support Linux/non-Linux builds without import cycles.
 type cpuProfiler interface {
 	AddService(serviceID string, pid int, binaryPath string)
+	Pause()
+	Resume()
 	Stop()
 }
 
 // memProfiler is the subset of profiler.ContinuousMemoryProfiler used by the agent.
 type memProfiler interface {
 	AddService(serviceID string, pid int, binaryPath string, sdkAddr string)
+	Pause()
+	Resume()
 	Stop()
 }
 
@@ -58,6 +71,13 @@ type Agent struct {
 	continuousProfiler       cpuProfiler         // RFD 072: Continuous CPU profiler.
 	continuousMemoryProfiler memProfiler         // RFD 077: Continuous memory profiler.
 	functionCache            *FunctionCache      // RFD 063: Function discovery cache
+	profileStore             *debug.ProfileStore // On-demand profiles kept for offline debugging.
+	redactor                 *redact.Engine      // Masks secrets in captures; nil when disabled.
+	colonySecret             *ColonySecret       // Colony secret to register with; may be nil.
+	valve                    *safety.Valve       // Resource self-limits; nil when no limits are configured.
+	coreDumps                *coredump.Capturer  // Crash capture; nil unless enabled.
+	profilingPaused          bool                // Continuous profiling is shed by the valve.
+	sheddingListeners        []func(*agentv1.ResourceShedding)
 	logger                   zerolog.Logger
 	mu                       sync.RWMutex
 	ctx                      context.Context
@@ -71,8 +91,27 @@ type Config struct {
 	Services      []*meshv1.ServiceInfo
 	BeylaConfig   *beyla.Config
 	DebugConfig   config.DebugConfig
-	FunctionCache *FunctionCache // RFD 063: Optional function cache
+	FunctionCache *FunctionCache      // RFD 063: Optional function cache
+	EventStore    *ebpf.EventStore    // Optional on-disk uprobe event ring
+	ProfileStore  *debug.ProfileStore // Optional local store for on-demand profiles
 	Logger        zerolog.Logger
+
+	// ServiceDiscovery registers services from systemd units and containers.
+	ServiceDiscovery config.ServiceDiscoveryConfig
+
+	// ResourceLimits bounds the agent's own CPU and memory usage.
+	ResourceLimits config.ResourceLimitsConfig
+
+	// CoreDumps captures core dumps of crashed services.
+	CoreDumps config.CoreDumpConfig
+
+	// CoreDumpHelperCommand is the command line that runs the core_pattern
+	// helper; defaults to "<executable> agent coredump-helper".
+	CoreDumpHelperCommand []string
+
+	// ColonySecret is the colony secret the agent registers with, updated
+	// when the colony rotates it. Optional.
+	ColonySecret *ColonySecret
 }
 
 // New creates a new agent.
@@ -84,11 +123,21 @@ func New(config Config) (*Agent, error) {
 		return nil, fmt.Errorf("context is required")
 	}
 
+	// Mask credentials and personal data in captures before they leave the host.
+	redactor, err := newRedactor(config.DebugConfig.Redaction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid debug.redaction config: %w", err)
+	}
+
 	ctx, cancel := context.WithCancel(config.Context)
 
 	// Initialize eBPF manager.
 	ebpfManager := ebpf.NewManager(ebpf.Config{
-		Logger: config.Logger,
+		Logger:     config.Logger,
+		BTFPath:    config.DebugConfig.BPF.BTFPath,
+		EventStore: config.EventStore,
+		Redactor:   redactor,
+		TLSCapture: config.DebugConfig.TLSCapture.Enabled,
 	})
 
 	// Initialize Beyla manager (RFD 032/110).
@@ -115,8 +164,13 @@ func New(config Config) (*Agent, error) {
 	corrEngine := correlation.NewEngine(config.AgentID, config.Logger)
 
 	// Build the ordered component list. ebpfManager starts first so it is
-	// available to collectors before Beyla (RFD 032) begins discovery.
-	components := []Lifecycle{ebpfManager}
+	// available to collectors before Beyla (RFD 032) begins discovery. The
+	// event store precedes it so it stops last and flushes collected events.
+	var components []Lifecycle
+	if config.EventStore != nil {
+		components = append(components, config.EventStore)
+	}
+	components = append(components, ebpfManager)
 	if beylaManager != nil {
 		components = append(components, beylaManager)
 	}
@@ -129,6 +183,9 @@ func New(config Config) (*Agent, error) {
 		beylaManager:      beylaManager,
 		correlationEngine: corrEngine,
 		functionCache:     config.FunctionCache,
+		profileStore:      config.ProfileStore,
+		redactor:          redactor,
+		colonySecret:      config.ColonySecret,
 		logger:            config.Logger.With().Str("agent_id", config.AgentID).Logger(),
 		ctx:               ctx,
 		cancel:            cancel,
@@ -149,6 +206,38 @@ func New(config Config) (*Agent, error) {
 		agent.monitors[service.Name] = monitor
 	}
 
+	// Shed load when the agent nears its own resource limits. Started first
+	// so it stops last and guards the whole agent lifetime.
+	agent.initSafetyValve(config.ResourceLimits)
+
+	// Capture core dumps of crashed services.
+	if config.CoreDumps.Enabled {
+		agent.coreDumps = coredump.NewCapturer(coredump.Config{
+			Mode:          config.CoreDumps.Mode,
+			Directory:     config.CoreDumps.Directory,
+			MaxTotalBytes: int64(config.CoreDumps.MaxTotalMB) << 20,
+			MaxDumpBytes:  int64(config.CoreDumps.MaxDumpMB) << 20,
+			PollInterval:  config.CoreDumps.PollInterval,
+			HelperCommand: config.CoreDumpHelperCommand,
+			Logger:        config.Logger,
+		}, agent.serviceForCrash)
+		agent.components = append(agent.components, agent.coreDumps)
+	}
+
+	// Register services from systemd units and container cgroups. Started
+	// last so configured services take precedence over discovered names.
+	if config.ServiceDiscovery.Enabled {
+		agent.components = append(agent.components, autodiscovery.NewDiscoverer(autodiscovery.Config{
+			Include:      config.ServiceDiscovery.Include,
+			Exclude:      config.ServiceDiscovery.Exclude,
+			Interval:     config.ServiceDiscovery.Interval,
+			MinUptime:    config.ServiceDiscovery.MinUptime,
+			ServiceLabel: config.ServiceDiscovery.ServiceLabel,
+			DockerRoot:   config.ServiceDiscovery.DockerRoot,
+			Logger:       config.Logger,
+		}, agent))
+	}
+
 	return agent, nil
 }
 
@@ -214,6 +303,9 @@ func (a *Agent) SetContinuousProfiler(profiler cpuProfiler) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	a.continuousProfiler = profiler
+	if a.profilingPaused {
+		profiler.Pause()
+	}
 }
 
 // SetContinuousMemoryProfiler sets the continuous memory profiler (RFD 077).
@@ -221,6 +313,111 @@ func (a *Agent) SetContinuousMemoryProfiler(profiler memProfiler) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 	a.continuousMemoryProfiler = profiler
+	if a.profilingPaused {
+		profiler.Pause()
+	}
+}
+
+// initSafetyValve creates the resource safety valve when limits are
+// configured. Load is shed in priority order: continuous profiling is paused
+// first, then uprobe sampling is reduced.
+func (a *Agent) initSafetyValve(limits config.ResourceLimitsConfig) {
+	cfg := safety.Config{
+		MaxCPUPercent:  limits.MaxCPUPercent,
+		MaxMemoryBytes: uint64(max(limits.MaxMemoryMB, 0)) << 20,
+		CheckInterval:  limits.CheckInterval,
+		HighWatermark:  limits.HighWatermark,
+		LowWatermark:   limits.LowWatermark,
+		Logger:         a.logger,
+	}
+	if !cfg.Enabled() {
+		return
+	}
+
+	divisor := limits.UprobeSampleDivisor
+	if divisor == 0 {
+		divisor = constants.DefaultShedUprobeSampleDivisor
+	}
+
+	a.valve = safety.NewValve(cfg, []safety.Stage{
+		{
+			Name:    safety.StageContinuousProfiling,
+			Shed:    func() { a.setProfilingPaused(true) },
+			Restore: func() { a.setProfilingPaused(false) },
+		},
+		{
+			Name:    safety.StageUprobeSampling,
+			Shed:    func() { a.ebpfManager.SetUprobeSampleDivisor(divisor) },
+			Restore: func() { a.ebpfManager.SetUprobeSampleDivisor(1) },
+		},
+	})
+	a.valve.OnChange(func(safety.State) {
+		status := a.valve.Status()
+
+		a.mu.RLock()
+		listeners := a.sheddingListeners
+		a.mu.RUnlock()
+
+		for _, fn := range listeners {
+			fn(status)
+		}
+	})
+
+	// Prepend so the valve starts first and stops last.
+	a.components = append([]Lifecycle{a.valve}, a.components...)
+}
+
+// setProfilingPaused pauses or resumes continuous CPU and memory profiling.
+func (a *Agent) setProfilingPaused(paused bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.profilingPaused = paused
+	for _, p := range []interface {
+		Pause()
+		Resume()
+	}{a.continuousProfiler, a.continuousMemoryProfiler} {
+		if p == nil {
+			continue
+		}
+		if paused {
+			p.Pause()
+		} else {
+			p.Resume()
+		}
+	}
+}
+
+// ResourceShedding returns the current resource safety valve state, or nil
+// when no resource limits are configured.
+func (a *Agent) ResourceShedding() *agentv1.ResourceShedding {
+	if a.valve == nil {
+		return nil
+	}
+	return a.valve.Status()
+}
+
+// HealthMetrics returns the agent internals reported in heartbeats for
+// health scoring.
+func (a *Agent) HealthMetrics() *meshv1.AgentHealthMetrics {
+	metrics := &meshv1.AgentHealthMetrics{}
+	if a.ebpfManager == nil {
+		return metrics
+	}
+
+	metrics.EbpfFailures = uint32(a.ebpfManager.RecentFailures(constants.DefaultAgentHealthFailureWindow)) // #nosec G115 -- small count.
+	if store := a.ebpfManager.EventStore(); store != nil {
+		metrics.QueueDepth = uint32(store.PendingCount()) // #nosec G115 -- bounded by MaxEvents.
+	}
+	return metrics
+}
+
+// OnResourceSheddingChange registers a callback invoked whenever the agent
+// starts or stops shedding a stage, e.g. to notify the colony immediately.
+func (a *Agent) OnResourceSheddingChange(fn func(*agentv1.ResourceShedding)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sheddingListeners = append(a.sheddingListeners, fn)
 }
 
 // onProcessDiscovered is called when a service's PID is discovered by a monitor.
@@ -326,6 +523,21 @@ func (a *Agent) GetServiceStatuses() map[string]ServiceStatusInfo {
 	return statuses
 }
 
+// ServiceInfos returns copies of the registered services, with the process
+// ID their monitor last saw.
+func (a *Agent) ServiceInfos() []*meshv1.ServiceInfo {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	infos := make([]*meshv1.ServiceInfo, 0, len(a.monitors))
+	for _, monitor := range a.monitors {
+		info := proto.Clone(monitor.service).(*meshv1.ServiceInfo)
+		info.ProcessId = monitor.GetStatus().ProcessID
+		infos = append(infos, info)
+	}
+	return infos
+}
+
 // GetServiceCount returns the number of services being monitored.
 func (a *Agent) GetServiceCount() int {
 	a.mu.RLock()
@@ -407,11 +619,67 @@ func (a *Agent) DisconnectService(serviceName string) error {
 	return nil
 }
 
+// CoreDumps returns the core dump capturer, or nil when capture is disabled.
+func (a *Agent) CoreDumps() *coredump.Capturer {
+	return a.coreDumps
+}
+
+// serviceForCrash maps a crashed process to a registered service, first by
+// the PID its monitor last saw and then by executable when exactly one
+// service runs it (the monitor may already track a restarted process).
+func (a *Agent) serviceForCrash(pid int32, executable string) (string, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var byExecutable []string
+	for name, monitor := range a.monitors {
+		status := monitor.GetStatus()
+		if status.ProcessID == pid {
+			return name, true
+		}
+		if executable != "" && status.BinaryPath == executable {
+			byExecutable = append(byExecutable, name)
+		}
+	}
+
+	if len(byExecutable) == 1 {
+		return byExecutable[0], true
+	}
+	return "", false
+}
+
 // GetEbpfManager returns the eBPF manager for this agent.
 func (a *Agent) GetEbpfManager() *ebpf.Manager {
 	return a.ebpfManager
 }
 
+// Redactor returns the engine masking credentials and personal data in what
+// the agent captures, or nil if redaction is disabled.
+func (a *Agent) Redactor() *redact.Engine {
+	return a.redactor
+}
+
+// ColonySecret returns the colony secret the agent registers with, or nil.
+func (a *Agent) ColonySecret() *ColonySecret {
+	return a.colonySecret
+}
+
+// newRedactor creates the redaction engine of cfg.
+func newRedactor(cfg config.RedactionConfig) (*redact.Engine, error) {
+	services := make(map[string]redact.ServiceRules, len(cfg.Services))
+	for name, svc := range cfg.Services {
+		services[name] = redact.ServiceRules{
+			Disabled: svc.Disabled,
+			Rules:    redact.Rules{Patterns: svc.Patterns, Fields: svc.Fields},
+		}
+	}
+	return redact.New(redact.Config{
+		Disabled: cfg.Disabled,
+		Rules:    redact.Rules{Patterns: cfg.Patterns, Fields: cfg.Fields},
+		Services: services,
+	})
+}
+
 // GetBeylaManager returns the Beyla manager for this agent (RFD 032).
 func (a *Agent) GetBeylaManager() *beyla.Manager {
 	return a.beylaManager
@@ -422,6 +690,9 @@ func (a *Agent) GetBeylaManager() *beyla.Manager {
 func (a *Agent) collectPortsLocked() map[int]string {
 	portMap := make(map[int]string)
 	for name, monitor := range a.monitors {
+		if monitor.service.Port == 0 {
+			continue // Port-less services (e.g. cgroup discovery).
+		}
 		portMap[int(monitor.service.Port)] = name
 	}
 	return portMap
@@ -434,7 +705,7 @@ func (a *Agent) Resolve(serviceName string) (string, error) {
 
 	monitor, ok := a.monitors[serviceName]
 	if !ok {
-		return "", fmt.Errorf("service not found: %s", serviceName)
+		return "", errServiceNotFound(serviceName)
 	}
 
 	// TODO: Support remote pods (Node Agent mode)
@@ -442,6 +713,12 @@ func (a *Agent) Resolve(serviceName string) (string, error) {
 	return fmt.Sprintf("localhost:%d", monitor.service.Port), nil
 }
 
+// errServiceNotFound is the error of a service the agent does not monitor.
+func errServiceNotFound(serviceName string) error {
+	return coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND,
+		fmt.Errorf("service not found: %s", serviceName), "service", serviceName)
+}
+
 // ResolveSDK resolves service name to SDK debug address (ServiceResolver interface).
 func (a *Agent) ResolveSDK(serviceName string) (string, error) {
 	a.mu.RLock()
@@ -449,7 +726,7 @@ func (a *Agent) ResolveSDK(serviceName string) (string, error) {
 
 	monitor, ok := a.monitors[serviceName]
 	if !ok {
-		return "", fmt.Errorf("service not found: %s", serviceName)
+		return "", errServiceNotFound(serviceName)
 	}
 
 	caps := monitor.GetSdkCapabilities()
@@ -232,6 +232,30 @@ func TestAgent_GetServiceStatuses(t *testing.T) {
 	assert.False(t, statuses["frontend"].LastCheck.IsZero())
 }
 
+func TestAgent_ServiceInfos(t *testing.T) {
+	agent, err := New(Config{
+		Context: context.Background(),
+		AgentID: "test-agent",
+		Services: []*meshv1.ServiceInfo{
+			{Name: "api", Port: 8080, Labels: map[string]string{"systemd.unit": "api.service"}},
+		},
+		Logger: zerolog.Nop(),
+	})
+	require.NoError(t, err)
+
+	agent.monitors["api"].mu.Lock()
+	agent.monitors["api"].processID = 4242
+	agent.monitors["api"].mu.Unlock()
+
+	infos := agent.ServiceInfos()
+	require.Len(t, infos, 1)
+	assert.Equal(t, "api.service", infos[0].Labels["systemd.unit"])
+	assert.Equal(t, int32(4242), infos[0].ProcessId)
+
+	infos[0].Labels["systemd.unit"] = "changed"
+	assert.Equal(t, "api.service", agent.ServiceInfos()[0].Labels["systemd.unit"], "infos are copies")
+}
+
 // TestAgent_BeylaIntegration tests Beyla integration with agent (RFD 032).
 func TestAgent_BeylaIntegration(t *testing.T) {
 	logger := zerolog.Nop()
@@ -0,0 +1,164 @@
+package autodiscovery
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SourceKind identifies where a discovered service comes from.
+type SourceKind string
+
+const (
+	SourceSystemd   SourceKind = "systemd"
+	SourceContainer SourceKind = "container"
+)
+
+// containerIDRegex matches a full 64 character container ID inside a cgroup
+// path segment, e.g. "docker-<id>.scope", "cri-containerd-<id>.scope",
+// "crio-<id>.scope", "libpod-<id>.scope" or a bare "<id>" (cgroup v1).
+var containerIDRegex = regexp.MustCompile(`(?:^|[-/])([0-9a-f]{64})(?:\.scope)?$`)
+
+// invalidNameChars matches characters not allowed in service names.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9\-]+`)
+
+// Source is the systemd unit or container a process belongs to.
+type Source struct {
+	Kind SourceKind
+
+	// Unit is the systemd unit name (e.g. "nginx.service").
+	Unit string
+
+	// ContainerID is the full container ID.
+	ContainerID string
+
+	// CgroupPath is the unified (or name=systemd) cgroup path of the process.
+	CgroupPath string
+}
+
+// readCgroupPath returns the cgroup path of pid. The cgroup v2 unified entry
+// is preferred, falling back to the v1 name=systemd hierarchy and then to the
+// first non-root path.
+func readCgroupPath(procRoot string, pid int) (string, error) {
+	f, err := os.Open(filepath.Join(procRoot, fmt.Sprint(pid), "cgroup")) // #nosec G304 - path built from /proc.
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }() // TODO: errcheck
+
+	var unified, systemd, fallback string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Format: hierarchy-ID:controller-list:cgroup-path.
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 || parts[2] == "/" {
+			continue
+		}
+
+		switch {
+		case parts[0] == "0" && parts[1] == "":
+			unified = parts[2]
+		case parts[1] == "name=systemd":
+			systemd = parts[2]
+		case fallback == "":
+			fallback = parts[2]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	for _, path := range []string{unified, systemd, fallback} {
+		if path != "" {
+			return path, nil
+		}
+	}
+	return "/", nil
+}
+
+// classifyCgroup determines the unit or container owning a cgroup path.
+// Containers take precedence since they usually run inside a systemd scope.
+func classifyCgroup(cgroupPath string) (Source, bool) {
+	segments := strings.Split(strings.Trim(cgroupPath, "/"), "/")
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		// CRI-O runs its container monitor in a sibling scope.
+		if strings.HasPrefix(segments[i], "crio-conmon-") {
+			return Source{}, false
+		}
+		if m := containerIDRegex.FindStringSubmatch(segments[i]); m != nil {
+			return Source{Kind: SourceContainer, ContainerID: m[1], CgroupPath: cgroupPath}, true
+		}
+	}
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		if strings.HasSuffix(segments[i], ".service") {
+			return Source{Kind: SourceSystemd, Unit: segments[i], CgroupPath: cgroupPath}, true
+		}
+	}
+
+	// Session scopes, init.scope and slices are not long-running services.
+	return Source{}, false
+}
+
+// SourceOf returns the systemd unit or container a running process belongs
+// to, or false if it belongs to neither, e.g. a process of a login session.
+func SourceOf(pid int) (Source, bool) {
+	cgroupPath, err := readCgroupPath("/proc", pid)
+	if err != nil {
+		return Source{}, false
+	}
+	return classifyCgroup(cgroupPath)
+}
+
+// unitServiceName derives a service name from a systemd unit name, e.g.
+// "nginx.service" -> "nginx" and "worker@2.service" -> "worker-2".
+func unitServiceName(unit string) string {
+	return sanitizeServiceName(strings.TrimSuffix(unit, ".service"))
+}
+
+// sanitizeServiceName converts name to a valid service name
+// (alphanumerics and hyphens, starting with an alphanumeric).
+func sanitizeServiceName(name string) string {
+	name = invalidNameChars.ReplaceAllString(name, "-")
+	return strings.Trim(name, "-")
+}
+
+// dockerContainer is the subset of Docker's config.v2.json used for naming.
+type dockerContainer struct {
+	Name   string `json:"Name"`
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+}
+
+// containerServiceName resolves the service name of a Docker container from
+// its on-disk metadata: the configured service label first, then the Compose
+// service label, then the container name.
+func containerServiceName(dockerRoot, containerID, serviceLabel string) (string, error) {
+	path := filepath.Join(dockerRoot, "containers", containerID, "config.v2.json")
+	data, err := os.ReadFile(path) // #nosec G304 - path built from container ID.
+	if err != nil {
+		return "", fmt.Errorf("failed to read container metadata: %w", err)
+	}
+
+	var container dockerContainer
+	if err := json.Unmarshal(data, &container); err != nil {
+		return "", fmt.Errorf("failed to parse container metadata: %w", err)
+	}
+
+	for _, key := range []string{serviceLabel, "com.docker.compose.service"} {
+		if key == "" {
+			continue
+		}
+		if name := container.Config.Labels[key]; name != "" {
+			return sanitizeServiceName(name), nil
+		}
+	}
+
+	return sanitizeServiceName(strings.TrimPrefix(container.Name, "/")), nil
+}
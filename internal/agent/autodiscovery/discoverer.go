@@ -0,0 +1,344 @@
+// Package autodiscovery registers long-running processes as services based on
+// the systemd unit or container cgroup they run in, without requiring the
+// SDK or manual 'coral connect' configuration.
+package autodiscovery
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// LabelDiscoveredBy marks services registered by this package.
+const LabelDiscoveredBy = "coral.discovered_by"
+
+// Registry connects and disconnects services. Implemented by agent.Agent.
+type Registry interface {
+	ConnectService(service *meshv1.ServiceInfo) error
+	DisconnectService(serviceName string) error
+}
+
+// Config contains cgroup discovery configuration.
+type Config struct {
+	// Include and Exclude are glob patterns matched against the service name
+	// and the unit or container name. An empty Include matches everything;
+	// Exclude always wins.
+	Include []string
+	Exclude []string
+
+	// Interval is how often cgroups are scanned.
+	Interval time.Duration
+
+	// MinUptime is how long a unit or container must be observed before it
+	// is registered, so short-lived jobs are ignored.
+	MinUptime time.Duration
+
+	// ServiceLabel is the container label holding the service name.
+	ServiceLabel string
+
+	// DockerRoot is the Docker data directory used to read container names
+	// and labels.
+	DockerRoot string
+
+	Logger zerolog.Logger
+}
+
+// Candidate is a service found by a cgroup scan.
+type Candidate struct {
+	Name       string
+	PID        int
+	BinaryPath string
+	Source     Source
+}
+
+// Discoverer periodically scans /proc and registers one service per systemd
+// unit or container. Services it registered are disconnected again once their
+// cgroup disappears; services connected by other means are left untouched.
+type Discoverer struct {
+	config   Config
+	registry Registry
+	logger   zerolog.Logger
+
+	// procRoot and selfPID are overridable for tests.
+	procRoot string
+	selfPID  int
+
+	mu         sync.Mutex
+	firstSeen  map[string]time.Time
+	registered map[string]Candidate // Services owned by the discoverer.
+	external   map[string]bool      // Names already connected by other means.
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewDiscoverer creates a new cgroup discoverer.
+func NewDiscoverer(config Config, registry Registry) *Discoverer {
+	if config.Interval <= 0 {
+		config.Interval = constants.DefaultServiceDiscoveryInterval
+	}
+	if config.DockerRoot == "" {
+		config.DockerRoot = constants.DefaultDockerRoot
+	}
+
+	return &Discoverer{
+		config:     config,
+		registry:   registry,
+		logger:     config.Logger.With().Str("component", "cgroup_discovery").Logger(),
+		procRoot:   "/proc",
+		selfPID:    os.Getpid(),
+		firstSeen:  make(map[string]time.Time),
+		registered: make(map[string]Candidate),
+		external:   make(map[string]bool),
+	}
+}
+
+// Start begins periodic discovery.
+func (d *Discoverer) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	go d.run(ctx)
+
+	d.logger.Info().
+		Strs("include", d.config.Include).
+		Strs("exclude", d.config.Exclude).
+		Dur("interval", d.config.Interval).
+		Msg("Started cgroup service discovery")
+
+	return nil
+}
+
+// Stop stops periodic discovery. Registered services stay connected.
+func (d *Discoverer) Stop() error {
+	if d.cancel != nil {
+		d.cancel()
+		<-d.done
+	}
+	return nil
+}
+
+// run scans immediately and then on every interval.
+func (d *Discoverer) run(ctx context.Context) {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		d.Reconcile(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile scans cgroups once and connects or disconnects services to match.
+func (d *Discoverer) Reconcile(now time.Time) {
+	candidates, err := d.Scan()
+	if err != nil {
+		d.logger.Warn().Err(err).Msg("Failed to scan cgroups")
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	present := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		present[c.Name] = true
+		if _, ok := d.firstSeen[c.Name]; !ok {
+			d.firstSeen[c.Name] = now
+		}
+	}
+
+	// Forget services that went away; disconnect the ones we own.
+	for name := range d.firstSeen {
+		if present[name] {
+			continue
+		}
+		delete(d.firstSeen, name)
+		delete(d.external, name)
+		if _, ok := d.registered[name]; ok {
+			d.disconnectLocked(name)
+		}
+	}
+
+	for _, c := range candidates {
+		if d.external[c.Name] || now.Sub(d.firstSeen[c.Name]) < d.config.MinUptime {
+			continue
+		}
+
+		if prev, ok := d.registered[c.Name]; ok {
+			if prev.PID == c.PID {
+				continue
+			}
+			// The main process was replaced (unit or container restart).
+			d.disconnectLocked(c.Name)
+		}
+
+		d.connectLocked(c)
+	}
+}
+
+// connectLocked registers a candidate. Caller must hold d.mu.
+func (d *Discoverer) connectLocked(c Candidate) {
+	labels := map[string]string{LabelDiscoveredBy: "cgroup"}
+	switch c.Source.Kind {
+	case SourceSystemd:
+		labels["systemd.unit"] = c.Source.Unit
+	case SourceContainer:
+		labels["container.id"] = c.Source.ContainerID
+	}
+
+	err := d.registry.ConnectService(&meshv1.ServiceInfo{
+		Name:       c.Name,
+		Labels:     labels,
+		ProcessId:  int32(c.PID), // #nosec G115 - PIDs fit in int32.
+		BinaryPath: c.BinaryPath,
+	})
+	if status.Code(err) == codes.AlreadyExists {
+		// Connected manually or via config; never manage it.
+		d.external[c.Name] = true
+		return
+	}
+	if err != nil {
+		d.logger.Warn().Err(err).Str("service", c.Name).Msg("Failed to register discovered service")
+		return
+	}
+
+	d.registered[c.Name] = c
+	d.logger.Info().
+		Str("service", c.Name).
+		Str("source", string(c.Source.Kind)).
+		Int("pid", c.PID).
+		Msg("Registered discovered service")
+}
+
+// disconnectLocked removes a service registered by the discoverer.
+// Caller must hold d.mu.
+func (d *Discoverer) disconnectLocked(name string) {
+	if err := d.registry.DisconnectService(name); err != nil {
+		d.logger.Debug().Err(err).Str("service", name).Msg("Failed to disconnect discovered service")
+	}
+	delete(d.registered, name)
+}
+
+// Scan enumerates processes and returns one candidate per systemd unit or
+// container that passes the include/exclude patterns. The lowest PID in each
+// cgroup is taken as its main process.
+func (d *Discoverer) Scan() ([]Candidate, error) {
+	entries, err := os.ReadDir(d.procRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	pids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		if pid, err := strconv.Atoi(entry.Name()); err == nil && entry.IsDir() {
+			pids = append(pids, pid)
+		}
+	}
+	sort.Ints(pids)
+
+	// The agent's own cgroup is never registered.
+	selfCgroup, _ := readCgroupPath(d.procRoot, d.selfPID)
+
+	seen := make(map[string]bool)
+	names := make(map[string]bool)
+	var candidates []Candidate
+	for _, pid := range pids {
+		if pid == d.selfPID {
+			continue
+		}
+
+		// Kernel threads have no executable.
+		exe, err := os.Readlink(filepath.Join(d.procRoot, strconv.Itoa(pid), "exe"))
+		if err != nil {
+			continue
+		}
+
+		cgroupPath, err := readCgroupPath(d.procRoot, pid)
+		if err != nil || cgroupPath == selfCgroup {
+			continue
+		}
+
+		source, ok := classifyCgroup(cgroupPath)
+		if !ok || seen[cgroupPath] {
+			continue
+		}
+		seen[cgroupPath] = true
+
+		candidate, ok := d.resolve(pid, exe, source)
+		if !ok || names[candidate.Name] {
+			// Replicas sharing a name (e.g. scaled Compose services) are
+			// represented by the first one.
+			continue
+		}
+		names[candidate.Name] = true
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// resolve names a candidate and applies the include/exclude patterns.
+func (d *Discoverer) resolve(pid int, exe string, source Source) (Candidate, bool) {
+	var name, sourceName string
+	switch source.Kind {
+	case SourceSystemd:
+		name = unitServiceName(source.Unit)
+		sourceName = source.Unit
+	case SourceContainer:
+		var err error
+		name, err = containerServiceName(d.config.DockerRoot, source.ContainerID, d.config.ServiceLabel)
+		if err != nil {
+			// Only Docker metadata is readable without a runtime client.
+			d.logger.Debug().Err(err).Str("container_id", source.ContainerID).Msg("Skipping unnamed container")
+			return Candidate{}, false
+		}
+		sourceName = name
+	}
+
+	if name == "" || !d.matches(name, sourceName) {
+		return Candidate{}, false
+	}
+
+	return Candidate{Name: name, PID: pid, BinaryPath: exe, Source: source}, true
+}
+
+// matches applies the include and exclude patterns to a service.
+func (d *Discoverer) matches(names ...string) bool {
+	if matchAny(d.config.Exclude, names) {
+		return false
+	}
+	return len(d.config.Include) == 0 || matchAny(d.config.Include, names)
+}
+
+// matchAny reports whether any pattern matches any of names.
+func matchAny(patterns, names []string) bool {
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package autodiscovery
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+)
+
+const testContainerID = "4f1d2c3b4a5968778695a4b3c2d1e0f4f1d2c3b4a5968778695a4b3c2d1e0f00"
+
+// fakeRegistry records connected services.
+type fakeRegistry struct {
+	mu       sync.Mutex
+	services map[string]*meshv1.ServiceInfo
+}
+
+func newFakeRegistry(existing ...string) *fakeRegistry {
+	r := &fakeRegistry{services: make(map[string]*meshv1.ServiceInfo)}
+	for _, name := range existing {
+		r.services[name] = &meshv1.ServiceInfo{Name: name, Port: 8080}
+	}
+	return r
+}
+
+func (r *fakeRegistry) ConnectService(service *meshv1.ServiceInfo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.services[service.Name]; ok {
+		return status.Errorf(codes.AlreadyExists, "service %s already connected", service.Name)
+	}
+	r.services[service.Name] = service
+	return nil
+}
+
+func (r *fakeRegistry) DisconnectService(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.services, name)
+	return nil
+}
+
+func (r *fakeRegistry) get(name string) *meshv1.ServiceInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.services[name]
+}
+
+// addProc creates a fake /proc/<pid> entry with the given cgroup file.
+// An empty exe simulates a kernel thread.
+func addProc(t *testing.T, procRoot string, pid int, exe, cgroup string) {
+	t.Helper()
+	dir := filepath.Join(procRoot, strconv.Itoa(pid))
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cgroup"), []byte(cgroup), 0o644))
+	if exe != "" {
+		require.NoError(t, os.Symlink(exe, filepath.Join(dir, "exe")))
+	}
+}
+
+func newTestDiscoverer(t *testing.T, config Config, registry Registry) (*Discoverer, string) {
+	t.Helper()
+	root := t.TempDir()
+	procRoot := filepath.Join(root, "proc")
+	require.NoError(t, os.MkdirAll(procRoot, 0o755))
+
+	config.Logger = zerolog.Nop()
+	if config.DockerRoot == "" {
+		config.DockerRoot = filepath.Join(root, "docker")
+	}
+
+	d := NewDiscoverer(config, registry)
+	d.procRoot = procRoot
+	d.selfPID = 1
+	return d, procRoot
+}
+
+func TestDiscoverer_Scan(t *testing.T) {
+	d, procRoot := newTestDiscoverer(t, Config{
+		Exclude:      []string{"systemd-*"},
+		ServiceLabel: "coral.service",
+	}, newFakeRegistry())
+
+	// The agent itself.
+	addProc(t, procRoot, 1, "/usr/bin/coral", "0::/system.slice/coral-agent.service\n")
+	// Kernel thread.
+	addProc(t, procRoot, 2, "", "0::/\n")
+	// systemd unit with a forked worker.
+	addProc(t, procRoot, 100, "/usr/sbin/nginx", "0::/system.slice/nginx.service\n")
+	addProc(t, procRoot, 101, "/usr/sbin/nginx", "0::/system.slice/nginx.service\n")
+	// Excluded system unit.
+	addProc(t, procRoot, 200, "/lib/systemd/systemd-journald", "0::/system.slice/systemd-journald.service\n")
+	// Login session, not a service.
+	addProc(t, procRoot, 300, "/bin/bash", "0::/user.slice/user-1000.slice/session-3.scope\n")
+	// Templated unit on cgroup v1.
+	addProc(t, procRoot, 400, "/opt/worker", "12:pids:/system.slice/worker@2.service\n1:name=systemd:/system.slice/worker@2.service\n")
+	// Docker container named through a label.
+	addProc(t, procRoot, 500, "/app/server", "0::/system.slice/docker-"+testContainerID+".scope\n")
+	containerDir := filepath.Join(d.config.DockerRoot, "containers", testContainerID)
+	require.NoError(t, os.MkdirAll(containerDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(containerDir, "config.v2.json"),
+		[]byte(`{"Name":"/shop_api_1","Config":{"Labels":{"coral.service":"checkout_api"}}}`), 0o644))
+
+	candidates, err := d.Scan()
+	require.NoError(t, err)
+
+	byName := make(map[string]Candidate)
+	for _, c := range candidates {
+		byName[c.Name] = c
+	}
+	require.Len(t, byName, 3)
+
+	assert.Equal(t, 100, byName["nginx"].PID)
+	assert.Equal(t, "nginx.service", byName["nginx"].Source.Unit)
+	assert.Equal(t, 400, byName["worker-2"].PID)
+	assert.Equal(t, SourceContainer, byName["checkout-api"].Source.Kind)
+	assert.Equal(t, testContainerID, byName["checkout-api"].Source.ContainerID)
+	assert.Equal(t, "/app/server", byName["checkout-api"].BinaryPath)
+}
+
+func TestDiscoverer_IncludePatterns(t *testing.T) {
+	d, procRoot := newTestDiscoverer(t, Config{
+		Include: []string{"api-*", "redis.service"},
+	}, newFakeRegistry())
+
+	addProc(t, procRoot, 100, "/opt/api", "0::/system.slice/api-gateway.service\n")
+	addProc(t, procRoot, 200, "/usr/bin/redis-server", "0::/system.slice/redis.service\n")
+	addProc(t, procRoot, 300, "/usr/sbin/nginx", "0::/system.slice/nginx.service\n")
+
+	candidates, err := d.Scan()
+	require.NoError(t, err)
+
+	var names []string
+	for _, c := range candidates {
+		names = append(names, c.Name)
+	}
+	assert.ElementsMatch(t, []string{"api-gateway", "redis"}, names)
+}
+
+func TestDiscoverer_Reconcile(t *testing.T) {
+	registry := newFakeRegistry("redis")
+	d, procRoot := newTestDiscoverer(t, Config{MinUptime: time.Minute}, registry)
+
+	addProc(t, procRoot, 100, "/usr/sbin/nginx", "0::/system.slice/nginx.service\n")
+	addProc(t, procRoot, 200, "/usr/bin/redis-server", "0::/system.slice/redis.service\n")
+
+	start := time.Now()
+
+	// Not registered before MinUptime.
+	d.Reconcile(start)
+	assert.Nil(t, registry.get("nginx"))
+
+	d.Reconcile(start.Add(time.Minute))
+	nginx := registry.get("nginx")
+	require.NotNil(t, nginx)
+	assert.Equal(t, int32(100), nginx.ProcessId)
+	assert.Zero(t, nginx.Port)
+	assert.Equal(t, "cgroup", nginx.Labels[LabelDiscoveredBy])
+	assert.Equal(t, "nginx.service", nginx.Labels["systemd.unit"])
+
+	// Manually connected services are left untouched.
+	assert.Equal(t, int32(8080), registry.get("redis").Port)
+
+	// Unit restart: the new main PID is registered.
+	require.NoError(t, os.RemoveAll(filepath.Join(procRoot, "100")))
+	addProc(t, procRoot, 150, "/usr/sbin/nginx", "0::/system.slice/nginx.service\n")
+	d.Reconcile(start.Add(2 * time.Minute))
+	assert.Equal(t, int32(150), registry.get("nginx").ProcessId)
+
+	// Unit stopped: discovered service is disconnected, manual one is kept.
+	require.NoError(t, os.RemoveAll(filepath.Join(procRoot, "150")))
+	require.NoError(t, os.RemoveAll(filepath.Join(procRoot, "200")))
+	d.Reconcile(start.Add(3 * time.Minute))
+	assert.Nil(t, registry.get("nginx"))
+	assert.NotNil(t, registry.get("redis"))
+}
+
+func TestClassifyCgroup(t *testing.T) {
+	tests := []struct {
+		path string
+		want Source
+		ok   bool
+	}{
+		{path: "/system.slice/nginx.service", want: Source{Kind: SourceSystemd, Unit: "nginx.service"}, ok: true},
+		{path: "/system.slice/docker-" + testContainerID + ".scope", want: Source{Kind: SourceContainer, ContainerID: testContainerID}, ok: true},
+		{path: "/docker/" + testContainerID, want: Source{Kind: SourceContainer, ContainerID: testContainerID}, ok: true},
+		{path: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1.slice/cri-containerd-" + testContainerID + ".scope", want: Source{Kind: SourceContainer, ContainerID: testContainerID}, ok: true},
+		{path: "/user.slice/user-1000.slice/session-2.scope", ok: false},
+		{path: "/init.scope", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Trim(tt.path, "/"), func(t *testing.T) {
+			got, ok := classifyCgroup(tt.path)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				tt.want.CgroupPath = tt.path
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
@@ -368,6 +368,15 @@ func (m *Manager) QueryTracesBySeqID(ctx context.Context, startSeqID uint64, max
 	return m.beylaStorage.QueryTracesBySeqID(ctx, startSeqID, maxRecords, serviceNames)
 }
 
+// Acknowledge records that the colony has stored the records of dataType up
+// to seqID, which cleanup may then delete (RFD 089).
+func (m *Manager) Acknowledge(ctx context.Context, dataType string, seqID uint64) error {
+	if m.beylaStorage == nil {
+		return fmt.Errorf("beyla storage not initialized")
+	}
+	return m.beylaStorage.Acknowledge(ctx, dataType, seqID)
+}
+
 // IsRunning returns whether Beyla is currently running.
 func (m *Manager) IsRunning() bool {
 	m.mu.RLock()
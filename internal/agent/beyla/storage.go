@@ -67,6 +67,19 @@ type beylaTraceDB struct {
 	CreatedAt    time.Time `duckdb:"created_at,immutable"`
 }
 
+// Data types whose seq_ids the colony acknowledges (RFD 089).
+const (
+	DataTypeHTTP   = "http"
+	DataTypeGRPC   = "grpc"
+	DataTypeSQL    = "sql"
+	DataTypeTraces = "traces"
+)
+
+// maxUnackedRetention bounds how long records the colony has not
+// acknowledged are kept past their retention, so that an agent whose colony
+// is gone does not grow its database without limit.
+const maxUnackedRetention = 24 * time.Hour
+
 // BeylaStorage handles local storage of Beyla metrics in agent's DuckDB.
 // Metrics are stored for ~1 hour and queried by Colony on-demand (RFD 025 pull-based).
 type BeylaStorage struct {
@@ -103,6 +116,9 @@ func NewBeylaStorage(db *sql.DB, dbPath string, logger zerolog.Logger) (*BeylaSt
 	return s, nil
 }
 
+// migrations are the Beyla schema changes applied after initSchema.
+var migrations []duckdb.Migration
+
 // initSchema creates the Beyla metrics tables in agent's local DuckDB.
 func (s *BeylaStorage) initSchema() error {
 	// Sequences for checkpoint-based polling (RFD 089).
@@ -235,6 +251,22 @@ func (s *BeylaStorage) initSchema() error {
 		return fmt.Errorf("failed to create traces schema: %w", err)
 	}
 
+	// Highest seq_id per data type stored by the colony (RFD 089).
+	acksSchema := `
+		CREATE TABLE IF NOT EXISTS beyla_colony_acks (
+			data_type  VARCHAR PRIMARY KEY,
+			seq_id     UBIGINT NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+	`
+	if _, err := s.db.Exec(acksSchema); err != nil {
+		return fmt.Errorf("failed to create colony acks schema: %w", err)
+	}
+
+	if err := duckdb.ApplyMigrations(context.Background(), s.db, "beyla", migrations, s.logger); err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
 	s.logger.Info().Msg("Beyla storage schema initialized")
 
 	// Set a low WAL auto-checkpoint limit (e.g., 4MB) to ensure data is flushed frequently
@@ -961,6 +993,64 @@ func (s *BeylaStorage) QueryTraceByID(ctx context.Context, traceID string) ([]*e
 	return spans, nil
 }
 
+// Acknowledge records that the colony has stored the records of dataType up
+// to seqID, so that cleanup may delete them (RFD 089). Acknowledgements only
+// move forward: a lower seqID, e.g. from a colony that lost its checkpoints,
+// is ignored.
+func (s *BeylaStorage) Acknowledge(ctx context.Context, dataType string, seqID uint64) error {
+	if seqID == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO beyla_colony_acks (data_type, seq_id, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (data_type) DO UPDATE SET
+			seq_id = greatest(beyla_colony_acks.seq_id, EXCLUDED.seq_id),
+			updated_at = now()
+	`, dataType, seqID)
+	if err != nil {
+		return fmt.Errorf("failed to record %s acknowledgement: %w", dataType, err)
+	}
+	return nil
+}
+
+// AcknowledgedSeqID returns the highest seq_id of dataType acknowledged by
+// the colony, and false if the colony never acknowledged any.
+func (s *BeylaStorage) AcknowledgedSeqID(ctx context.Context, dataType string) (uint64, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	acks, err := s.acknowledgedSeqIDs(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	seqID, ok := acks[dataType]
+	return seqID, ok, nil
+}
+
+func (s *BeylaStorage) acknowledgedSeqIDs(ctx context.Context) (map[string]uint64, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT data_type, seq_id FROM beyla_colony_acks")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query colony acknowledgements: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	acks := make(map[string]uint64)
+	for rows.Next() {
+		var dataType string
+		var seqID uint64
+		if err := rows.Scan(&dataType, &seqID); err != nil {
+			return nil, fmt.Errorf("failed to scan colony acknowledgement: %w", err)
+		}
+		acks[dataType] = seqID
+	}
+	return acks, rows.Err()
+}
+
 // RunCleanupLoop periodically removes old metrics (default: 1 hour retention).
 func (s *BeylaStorage) RunCleanupLoop(ctx context.Context, retention time.Duration) {
 	ticker := time.NewTicker(10 * time.Minute)
@@ -973,37 +1063,53 @@ func (s *BeylaStorage) RunCleanupLoop(ctx context.Context, retention time.Durati
 			return
 
 		case <-ticker.C:
-			cutoff := time.Now().Add(-retention)
-
-			s.mu.Lock()
-
-			// Clean HTTP metrics.
-			if _, err := s.db.ExecContext(ctx, "DELETE FROM beyla_http_metrics_local WHERE timestamp < ?", cutoff); err != nil {
-				s.logger.Error().Err(err).Msg("Failed to clean HTTP metrics")
-			}
-
-			// Clean gRPC metrics.
-			if _, err := s.db.ExecContext(ctx, "DELETE FROM beyla_grpc_metrics_local WHERE timestamp < ?", cutoff); err != nil {
-				s.logger.Error().Err(err).Msg("Failed to clean gRPC metrics")
-			}
+			s.cleanup(ctx, time.Now(), retention)
+		}
+	}
+}
 
-			// Clean SQL metrics.
-			if _, err := s.db.ExecContext(ctx, "DELETE FROM beyla_sql_metrics_local WHERE timestamp < ?", cutoff); err != nil {
-				s.logger.Error().Err(err).Msg("Failed to clean SQL metrics")
-			}
+// cleanup removes the metrics and traces older than retention. Once the
+// colony acknowledges a data type, records it has not stored yet are kept
+// until acknowledged, or for up to maxUnackedRetention (RFD 089).
+func (s *BeylaStorage) cleanup(ctx context.Context, now time.Time, retention time.Duration) {
+	cutoff := now.Add(-retention)
+	unackedCutoff := now.Add(-max(retention, maxUnackedRetention))
 
-			// Clean traces (RFD 036).
-			if _, err := s.db.ExecContext(ctx, "DELETE FROM beyla_traces_local WHERE start_time < ?", cutoff); err != nil {
-				s.logger.Error().Err(err).Msg("Failed to clean traces")
-			}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-			s.mu.Unlock()
+	acks, err := s.acknowledgedSeqIDs(ctx)
+	if err != nil {
+		// Without acknowledgements, fall back to plain retention.
+		s.logger.Warn().Err(err).Msg("Failed to read colony acknowledgements")
+	}
 
-			s.logger.Debug().
-				Time("cutoff", cutoff).
-				Msg("Cleaned old Beyla metrics and traces")
+	tables := []struct {
+		name       string
+		timeColumn string
+		dataType   string
+	}{
+		{"beyla_http_metrics_local", "timestamp", DataTypeHTTP},
+		{"beyla_grpc_metrics_local", "timestamp", DataTypeGRPC},
+		{"beyla_sql_metrics_local", "timestamp", DataTypeSQL},
+		{"beyla_traces_local", "start_time", DataTypeTraces}, // RFD 036.
+	}
+	for _, t := range tables {
+		// #nosec G201 - the table and column names are constants, not user input.
+		query := fmt.Sprintf("DELETE FROM %s WHERE %s < ?", t.name, t.timeColumn)
+		args := []interface{}{cutoff}
+		if acked, ok := acks[t.dataType]; ok {
+			query += fmt.Sprintf(" AND (seq_id <= ? OR %s < ?)", t.timeColumn)
+			args = append(args, acked, unackedCutoff)
+		}
+		if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+			s.logger.Error().Err(err).Str("table", t.name).Msg("Failed to clean Beyla table")
 		}
 	}
+
+	s.logger.Debug().
+		Time("cutoff", cutoff).
+		Msg("Cleaned old Beyla metrics and traces")
 }
 
 // GetDatabasePath returns the file path to the DuckDB database (RFD 039).
@@ -399,3 +399,102 @@ func TestTraceCleanup(t *testing.T) {
 		t.Errorf("Recent trace should exist, found %d spans", len(recentSpans))
 	}
 }
+
+func TestAcknowledge(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	if _, ok, err := storage.AcknowledgedSeqID(ctx, DataTypeHTTP); err != nil || ok {
+		t.Fatalf("AcknowledgedSeqID() before any ack = ok %v, err %v; want false, nil", ok, err)
+	}
+
+	// Acknowledgements only move forward.
+	for _, seqID := range []uint64{10, 5, 0} {
+		if err := storage.Acknowledge(ctx, DataTypeHTTP, seqID); err != nil {
+			t.Fatalf("Acknowledge(%d) error: %v", seqID, err)
+		}
+	}
+	seqID, ok, err := storage.AcknowledgedSeqID(ctx, DataTypeHTTP)
+	if err != nil {
+		t.Fatalf("AcknowledgedSeqID() error: %v", err)
+	}
+	if !ok || seqID != 10 {
+		t.Errorf("AcknowledgedSeqID() = %d, %v; want 10, true", seqID, ok)
+	}
+
+	// Data types are acknowledged independently.
+	if _, ok, _ := storage.AcknowledgedSeqID(ctx, DataTypeTraces); ok {
+		t.Error("Traces should not be acknowledged")
+	}
+}
+
+func TestCleanupKeepsUnacknowledged(t *testing.T) {
+	storage, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	storeSpan := func(spanID string, startTime time.Time) {
+		t.Helper()
+		event := &ebpfpb.EbpfEvent{
+			Timestamp:   timestamppb.New(startTime),
+			AgentId:     "test-agent",
+			ServiceName: "api-service",
+			Payload: &ebpfpb.EbpfEvent_BeylaTrace{
+				BeylaTrace: &ebpfpb.BeylaTraceSpan{
+					TraceId:     "trace000000000000000000000000001",
+					SpanId:      spanID,
+					ServiceName: "api-service",
+					SpanName:    "GET /test",
+					SpanKind:    "server",
+					StartTime:   timestamppb.New(startTime),
+					Duration:    durationpb.New(100 * time.Millisecond),
+					StatusCode:  200,
+					Attributes:  map[string]string{},
+				},
+			},
+		}
+		if err := storage.StoreTrace(ctx, event); err != nil {
+			t.Fatalf("StoreTrace() error: %v", err)
+		}
+	}
+	spanIDs := func() map[string]bool {
+		t.Helper()
+		spans, _, err := storage.QueryTracesBySeqID(ctx, 0, 100, nil)
+		if err != nil {
+			t.Fatalf("QueryTracesBySeqID() error: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, span := range spans {
+			ids[span.SpanId] = true
+		}
+		return ids
+	}
+
+	storeSpan("acked", now.Add(-2*time.Hour))      // seq_id 1
+	storeSpan("unacked", now.Add(-2*time.Hour))    // seq_id 2
+	storeSpan("abandoned", now.Add(-25*time.Hour)) // seq_id 3
+	storeSpan("recent", now.Add(-30*time.Minute))  // seq_id 4
+
+	// Until the colony acknowledges anything, only retention applies.
+	storage.cleanup(ctx, now, 3*time.Hour)
+	if got := spanIDs(); len(got) != 3 || got["abandoned"] {
+		t.Fatalf("After cleanup without acks, spans = %v; want all but abandoned", got)
+	}
+
+	storeSpan("abandoned", now.Add(-25*time.Hour)) // seq_id 5
+	if err := storage.Acknowledge(ctx, DataTypeTraces, 1); err != nil {
+		t.Fatalf("Acknowledge() error: %v", err)
+	}
+
+	// Unacknowledged spans outlive retention, up to maxUnackedRetention.
+	storage.cleanup(ctx, now, time.Hour)
+	got := spanIDs()
+	want := map[string]bool{"unacked": true, "recent": true}
+	if len(got) != len(want) || !got["unacked"] || !got["recent"] {
+		t.Errorf("After cleanup, spans = %v; want %v", got, want)
+	}
+}
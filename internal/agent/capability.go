@@ -0,0 +1,126 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+// capabilityProcedures are the agent procedures that require a capability
+// token granting debug: they run commands on the agent's host or in the
+// containers it monitors, or copy files to and from the host.
+var capabilityProcedures = map[string]bool{
+	agentv1connect.AgentServiceShellProcedure:               true,
+	agentv1connect.AgentServiceShellExecProcedure:           true,
+	agentv1connect.AgentServiceContainerExecProcedure:       true,
+	agentv1connect.AgentServiceExecInteractiveProcedure:     true,
+	agentv1connect.AgentServiceReadFileProcedure:            true,
+	agentv1connect.AgentServiceWriteFileProcedure:           true,
+	agentv1connect.AgentServiceResizeShellTerminalProcedure: true,
+	agentv1connect.AgentServiceSendShellSignalProcedure:     true,
+	agentv1connect.AgentServiceKillShellSessionProcedure:    true,
+}
+
+// CapabilityInterceptor requires a capability token minted by the colony for
+// shell and exec RPCs. A token scoped to a service, e.g. "debug:api", is
+// accepted by the agents monitoring that service.
+type CapabilityInterceptor struct {
+	verifier *auth.CapabilityVerifier
+	services func() []string
+	logger   logging.Logger
+}
+
+// NewCapabilityInterceptor creates a capability interceptor. services returns
+// the services the agent monitors. A nil verifier rejects all guarded calls,
+// e.g. when the agent has no root CA to verify tokens with.
+func NewCapabilityInterceptor(verifier *auth.CapabilityVerifier, services func() []string, logger logging.Logger) *CapabilityInterceptor {
+	return &CapabilityInterceptor{
+		verifier: verifier,
+		services: services,
+		logger:   logger,
+	}
+}
+
+func (i *CapabilityInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := i.authorize(req.Spec().Procedure, req.Header()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *CapabilityInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *CapabilityInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.authorize(conn.Spec().Procedure, conn.RequestHeader()); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// authorize checks the capability token of a call to procedure.
+func (i *CapabilityInterceptor) authorize(procedure string, header http.Header) error {
+	if !capabilityProcedures[procedure] {
+		return nil
+	}
+
+	scheme, token, _ := strings.Cut(header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "bearer") || !auth.IsCapabilityToken(token) {
+		return connect.NewError(connect.CodeUnauthenticated,
+			fmt.Errorf("this agent requires a capability token (mint one with 'coral colony token mint --scope debug')"))
+	}
+	if i.verifier == nil {
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent cannot verify capability tokens: no colony root CA"))
+	}
+
+	c, err := i.verifier.Verify(token)
+	if err != nil {
+		i.logger.Warn().Err(err).Str("procedure", procedure).Msg("Rejected capability token")
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	if !i.allows(c) {
+		i.logger.Warn().
+			Str("capability_id", c.ID).
+			Str("subject", c.Subject).
+			Str("procedure", procedure).
+			Msg("Capability token does not grant debug on this agent")
+		return connect.NewError(connect.CodePermissionDenied,
+			fmt.Errorf("capability token does not grant debug for the services of this agent"))
+	}
+
+	i.logger.Info().
+		Str("capability_id", c.ID).
+		Str("subject", c.Subject).
+		Str("procedure", procedure).
+		Msg("Capability token accepted")
+	return nil
+}
+
+// allows reports whether a capability grants debug on all services or on a
+// service of this agent.
+func (i *CapabilityInterceptor) allows(c *auth.Capability) bool {
+	if c.Allows(auth.PermissionDebug, "") {
+		return true
+	}
+	if i.services == nil {
+		return false
+	}
+	for _, service := range i.services() {
+		if c.Allows(auth.PermissionDebug, service) {
+			return true
+		}
+	}
+	return false
+}
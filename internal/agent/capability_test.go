@@ -0,0 +1,128 @@
+package agent
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	"github.com/coral-mesh/coral/internal/auth"
+)
+
+// capabilityTestHandler answers the guarded and unguarded agent RPCs.
+type capabilityTestHandler struct {
+	agentv1connect.UnimplementedAgentServiceHandler
+}
+
+func (capabilityTestHandler) ShellExec(
+	context.Context,
+	*connect.Request[agentv1.ShellExecRequest],
+) (*connect.Response[agentv1.ShellExecResponse], error) {
+	return connect.NewResponse(&agentv1.ShellExecResponse{}), nil
+}
+
+func (capabilityTestHandler) ListServices(
+	context.Context,
+	*connect.Request[agentv1.ListServicesRequest],
+) (*connect.Response[agentv1.ListServicesResponse], error) {
+	return connect.NewResponse(&agentv1.ListServicesResponse{}), nil
+}
+
+// newCapabilityIssuer returns a root CA pool and a function minting
+// capability tokens of colony-1 with scopes, signed by a policy signing
+// certificate of that root.
+func newCapabilityIssuer(t *testing.T) (*x509.CertPool, func(scopes ...string) string) {
+	t.Helper()
+
+	newCert := func(isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(time.Now().UnixNano()),
+			NotBefore:             time.Now().Add(-time.Minute),
+			NotAfter:              time.Now().Add(time.Hour),
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+		}
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert, key
+	}
+
+	rootCert, rootKey := newCert(true, nil, nil)
+	signingCert, signingKey := newCert(false, rootCert, rootKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCert)
+
+	return roots, func(scopes ...string) string {
+		c := &auth.Capability{
+			ColonyID:  "colony-1",
+			Subject:   "ci-deploy",
+			IssuedAt:  time.Now(),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		for _, s := range scopes {
+			scope, err := auth.ParseScope(s)
+			require.NoError(t, err)
+			c.Scopes = append(c.Scopes, scope)
+		}
+		token, err := auth.IssueCapabilityToken(c, signingCert, signingKey)
+		require.NoError(t, err)
+		return token
+	}
+}
+
+func TestCapabilityInterceptor(t *testing.T) {
+	roots, mint := newCapabilityIssuer(t)
+	interceptor := NewCapabilityInterceptor(
+		auth.NewCapabilityVerifier(roots, "colony-1"),
+		func() []string { return []string{"api"} },
+		zerolog.Nop(),
+	)
+
+	path, handler := agentv1connect.NewAgentServiceHandler(capabilityTestHandler{}, connect.WithInterceptors(interceptor))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	client := agentv1connect.NewAgentServiceClient(srv.Client(), srv.URL)
+
+	exec := func(token string) error {
+		req := connect.NewRequest(&agentv1.ShellExecRequest{Command: []string{"true"}})
+		if token != "" {
+			req.Header().Set("Authorization", "Bearer "+token)
+		}
+		_, err := client.ShellExec(context.Background(), req)
+		return err
+	}
+
+	_, err := client.ListServices(context.Background(), connect.NewRequest(&agentv1.ListServicesRequest{}))
+	require.NoError(t, err, "unguarded procedures need no token")
+
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(exec("")))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(exec("coral_api-token")))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(exec(mint("debug")[:40])))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(exec(mint("query"))))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(exec(mint("debug:web"))))
+	assert.NoError(t, exec(mint("debug")))
+	assert.NoError(t, exec(mint("debug:api")))
+}
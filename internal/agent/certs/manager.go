@@ -33,6 +33,10 @@ const (
 	// AgentIDFileName stores the agent ID for persistence.
 	AgentIDFileName = "agent-id"
 
+	// ColonySecretFileName stores the colony secret pushed by the colony
+	// when it rotates its secret.
+	ColonySecretFileName = "colony-secret.json"
+
 	// RenewalThreshold is when to start renewing (30 days before expiry).
 	RenewalThreshold = 30 * 24 * time.Hour
 
@@ -380,3 +384,9 @@ func (m *Manager) GetRootCAPath() string {
 func (m *Manager) GetCAChainPath() string {
 	return filepath.Join(m.certsDir, CAChainFileName)
 }
+
+// GetColonySecretPath returns the full path to the colony secret pushed by
+// the colony.
+func (m *Manager) GetColonySecretPath() string {
+	return filepath.Join(m.certsDir, ColonySecretFileName)
+}
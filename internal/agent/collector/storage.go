@@ -63,6 +63,9 @@ func NewStorage(db *sql.DB, logger zerolog.Logger) (*Storage, error) {
 	return s, nil
 }
 
+// migrations are the system metrics schema changes applied after initSchema.
+var migrations []duckdb.Migration
+
 // initSchema creates the local system metrics table.
 func (s *Storage) initSchema() error {
 	schema := `
@@ -97,6 +100,10 @@ func (s *Storage) initSchema() error {
 		return fmt.Errorf("failed to create schema: %w", err)
 	}
 
+	if err := duckdb.ApplyMigrations(context.Background(), s.db, "system_metrics", migrations, s.logger); err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
 	// Force WAL checkpoint so remote HTTP clients can see the schema.
 	if _, err := s.db.Exec("CHECKPOINT"); err != nil {
 		s.logger.Warn().Err(err).Msg("Failed to checkpoint database")
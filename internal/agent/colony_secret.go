@@ -0,0 +1,118 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/coral-mesh/coral/internal/auth"
+)
+
+// ErrNoColonySecret is returned when a colony secret is pushed to an agent
+// that does not register with one.
+var ErrNoColonySecret = errors.New("agent has no colony secret")
+
+// ErrInvalidColonySecretProof is returned when a pushed colony secret does
+// not come with a proof made with the secret the agent holds.
+var ErrInvalidColonySecretProof = errors.New("invalid colony secret rotation proof")
+
+// ColonySecret is the colony secret the agent registers with (RFD 002). It is
+// the configured one (CORAL_COLONY_SECRET) until the colony rotates its
+// secret and pushes the new one, which is kept in a file so that the agent
+// still registers with it after a restart.
+type ColonySecret struct {
+	mu       sync.RWMutex
+	path     string
+	secret   string
+	replaces string // Hash of the configured secret the pushed one replaces.
+}
+
+// storedColonySecret is the file format of a pushed colony secret.
+type storedColonySecret struct {
+	Secret string `json:"secret"`
+
+	// Replaces is the SHA-256 of the configured secret when the secret was
+	// pushed. Once the configuration changes, it takes precedence again.
+	Replaces string `json:"replaces"`
+}
+
+// NewColonySecret returns the colony secret of an agent configured with
+// configured, or with the secret stored at path if the colony pushed one
+// since. path may be empty, in which case pushed secrets are not kept.
+func NewColonySecret(path, configured string) (*ColonySecret, error) {
+	s := &ColonySecret{path: path, secret: configured}
+	if configured == "" || path == "" {
+		return s, nil
+	}
+	s.replaces = hashColonySecret(configured)
+
+	//nolint:gosec // G304: Path is in the agent's certificate directory.
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read colony secret: %w", err)
+	}
+	var stored storedColonySecret
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse colony secret %s: %w", path, err)
+	}
+	if stored.Secret != "" && stored.Replaces == s.replaces {
+		s.secret = stored.Secret
+	}
+	return s, nil
+}
+
+// Get returns the colony secret, or "" if the agent has none.
+func (s *ColonySecret) Get() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.secret
+}
+
+// Update replaces the colony secret with next, pushed by the colony, if
+// proof shows that the colony holds the current secret.
+func (s *ColonySecret) Update(next string, proof []byte) error {
+	if s == nil {
+		return ErrNoColonySecret
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.secret == "" {
+		return ErrNoColonySecret
+	}
+	if next == "" || !auth.VerifyColonySecretProof(s.secret, next, proof) {
+		return ErrInvalidColonySecretProof
+	}
+
+	if s.path != "" {
+		data, err := json.Marshal(storedColonySecret{Secret: next, Replaces: s.replaces})
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+			return fmt.Errorf("failed to create colony secret directory: %w", err)
+		}
+		if err := os.WriteFile(s.path, data, 0600); err != nil {
+			return fmt.Errorf("failed to save colony secret: %w", err)
+		}
+	}
+	s.secret = next
+	return nil
+}
+
+// hashColonySecret identifies a configured colony secret without storing it.
+func hashColonySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package agent
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/auth"
+)
+
+func TestColonySecret_Update(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "certs", "colony-secret.json")
+
+	s, err := NewColonySecret(path, "old")
+	require.NoError(t, err)
+	assert.Equal(t, "old", s.Get())
+
+	assert.ErrorIs(t, s.Update("forged", auth.ColonySecretProof("guessed", "forged")), ErrInvalidColonySecretProof)
+	assert.Equal(t, "old", s.Get())
+
+	require.NoError(t, s.Update("new", auth.ColonySecretProof("old", "new")))
+	assert.Equal(t, "new", s.Get())
+	require.NoError(t, s.Update("newer", auth.ColonySecretProof("new", "newer")))
+
+	// The pushed secret survives restarts while the configuration is
+	// unchanged, and yields to a new configured secret.
+	restarted, err := NewColonySecret(path, "old")
+	require.NoError(t, err)
+	assert.Equal(t, "newer", restarted.Get())
+
+	reconfigured, err := NewColonySecret(path, "configured")
+	require.NoError(t, err)
+	assert.Equal(t, "configured", reconfigured.Get())
+
+	// Agents without a colony secret take no pushed one.
+	none, err := NewColonySecret(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "", none.Get())
+	assert.ErrorIs(t, none.Update("new", auth.ColonySecretProof("", "new")), ErrNoColonySecret)
+
+	var unset *ColonySecret
+	assert.Equal(t, "", unset.Get())
+	assert.ErrorIs(t, unset.Update("new", nil), ErrNoColonySecret)
+}
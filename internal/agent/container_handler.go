@@ -14,13 +14,17 @@ import (
 	"github.com/google/uuid"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/recording"
+	"github.com/coral-mesh/coral/internal/agent/redact"
 	"github.com/coral-mesh/coral/internal/logging"
 	"github.com/coral-mesh/coral/internal/sys/proc"
 )
 
 // ContainerHandler implements the container exec RPC methods for the agent (RFD 056).
 type ContainerHandler struct {
-	logger logging.Logger
+	logger        logging.Logger
+	redactor      *redact.Engine
+	recordingSink recording.Sink
 }
 
 // NewContainerHandler creates a new container handler.
@@ -30,6 +34,16 @@ func NewContainerHandler(logger logging.Logger) *ContainerHandler {
 	}
 }
 
+// SetRedactor sets the engine redacting ContainerExec output.
+func (h *ContainerHandler) SetRedactor(redactor *redact.Engine) {
+	h.redactor = redactor
+}
+
+// SetRecordingSink sets where the recordings of container exec sessions go.
+func (h *ContainerHandler) SetRecordingSink(sink recording.Sink) {
+	h.recordingSink = sink
+}
+
 // ContainerExec executes a command in a container's namespace using nsenter (RFD 056).
 func (h *ContainerHandler) ContainerExec(
 	ctx context.Context,
@@ -103,6 +117,13 @@ func (h *ContainerHandler) ContainerExec(
 		Msg("Executing container command")
 
 	startTime := time.Now()
+	rec := recording.New(h.recordingSink, recording.Session{
+		Kind:          recording.KindContainerExec,
+		SessionID:     sessionID,
+		UserID:        input.UserId,
+		Command:       input.Command,
+		ContainerName: input.ContainerName,
+	})
 
 	// Build nsenter command.
 	nsenterArgs := h.buildNsenterCommand(containerPID, namespaces, input.WorkingDir, input.Command)
@@ -135,9 +156,12 @@ func (h *ContainerHandler) ContainerExec(
 	// Wait for command to complete.
 	execErr := cmd.Wait()
 
-	// Get output from buffers.
-	stdout := stdoutBuf.Bytes()
-	stderr := stderrBuf.Bytes()
+	// Get output from buffers, masking credentials and personal data.
+	stdout, stdoutRedacted := h.redactor.Bytes("", stdoutBuf.Bytes())
+	stderr, stderrRedacted := h.redactor.Bytes("", stderrBuf.Bytes())
+	redacted := stdoutRedacted || stderrRedacted
+	rec.Output(stdout)
+	rec.Output(stderr)
 
 	duration := time.Since(startTime)
 	exitCode := int32(0)
@@ -177,6 +201,8 @@ func (h *ContainerHandler) ContainerExec(
 		Int("stderr_bytes", len(stderr)).
 		Msg("Container command execution completed")
 
+	rec.Close(exitCode)
+
 	// Return response.
 	resp := &agentv1.ContainerExecResponse{
 		Stdout:            stdout,
@@ -187,6 +213,7 @@ func (h *ContainerHandler) ContainerExec(
 		Error:             errorMsg,
 		ContainerPid:      int32(containerPID),
 		NamespacesEntered: namespaces,
+		Redacted:          redacted,
 	}
 
 	return connect.NewResponse(resp), nil
@@ -0,0 +1,414 @@
+package coredump
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Capture modes.
+const (
+	// ModeAuto uses coredumpctl when systemd-coredump handles core dumps and
+	// the core_pattern helper otherwise.
+	ModeAuto = "auto"
+
+	// ModeCorePattern registers the agent as the kernel core_pattern helper.
+	ModeCorePattern = "core_pattern"
+
+	// ModeCoredumpctl collects core dumps recorded by systemd-coredump.
+	ModeCoredumpctl = "coredumpctl"
+)
+
+// spoolStaleAfter is how long an incomplete spooled dump is kept before it is
+// considered abandoned by a helper that died mid-write.
+const spoolStaleAfter = time.Hour
+
+// Resolver maps a crashed process to the name of a registered service.
+type Resolver func(pid int32, executable string) (serviceName string, ok bool)
+
+// Config contains core dump capture configuration.
+type Config struct {
+	Mode          string
+	Directory     string
+	MaxTotalBytes int64 // 0 = unlimited.
+	MaxDumpBytes  int64 // 0 = unlimited.
+	PollInterval  time.Duration
+
+	// HelperCommand is the command line the kernel runs as core_pattern
+	// helper (default: the running executable with "agent coredump-helper").
+	HelperCommand []string
+
+	Logger zerolog.Logger
+}
+
+// Capturer collects core dumps of registered services into a Store. Dumps of
+// processes that do not belong to a registered service are discarded.
+type Capturer struct {
+	config  Config
+	store   *Store
+	resolve Resolver
+	logger  zerolog.Logger
+
+	spoolDir string
+	mode     string // Resolved capture mode.
+
+	// core_pattern mode.
+	installedPattern string
+	previousPattern  string
+
+	// coredumpctl mode.
+	runCmd commandRunner
+	since  time.Time
+	seen   map[string]time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewCapturer creates a core dump capturer.
+func NewCapturer(config Config, resolve Resolver) *Capturer {
+	if config.Mode == "" {
+		config.Mode = ModeAuto
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = constants.DefaultCoreDumpPollInterval
+	}
+
+	return &Capturer{
+		config:   config,
+		store:    NewStore(config.Directory, config.MaxTotalBytes),
+		resolve:  resolve,
+		logger:   config.Logger.With().Str("component", "coredump_capturer").Logger(),
+		spoolDir: filepath.Join(config.Directory, "spool"),
+		runCmd:   runCommand,
+		seen:     make(map[string]time.Time),
+	}
+}
+
+// Store returns the core dump store.
+func (c *Capturer) Store() *Store {
+	return c.store
+}
+
+// Mode returns the capture mode in use, resolved from ModeAuto on Start.
+func (c *Capturer) Mode() string {
+	if c.mode == "" {
+		return c.config.Mode
+	}
+	return c.mode
+}
+
+// Start sets up the capture source and begins collecting core dumps.
+func (c *Capturer) Start() error {
+	if err := os.MkdirAll(c.spoolDir, 0700); err != nil {
+		return fmt.Errorf("failed to create core dump spool directory: %w", err)
+	}
+
+	c.mode = c.config.Mode
+	if c.mode == ModeAuto {
+		c.mode = detectMode()
+	}
+
+	switch c.mode {
+	case ModeCorePattern:
+		if err := c.installCorePattern(); err != nil {
+			return err
+		}
+	case ModeCoredumpctl:
+		if _, err := exec.LookPath("coredumpctl"); err != nil {
+			return fmt.Errorf("coredumpctl not found: %w", err)
+		}
+		c.since = time.Now()
+	default:
+		return fmt.Errorf("unknown core dump capture mode %q", c.mode)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go c.loop(ctx)
+
+	c.logger.Info().
+		Str("mode", c.mode).
+		Str("directory", c.config.Directory).
+		Int64("max_total_bytes", c.config.MaxTotalBytes).
+		Msg("Started core dump capture")
+
+	return nil
+}
+
+// Stop stops collecting core dumps and restores the previous core_pattern.
+func (c *Capturer) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+
+	if c.mode == ModeCorePattern && c.installedPattern != "" {
+		return c.restoreCorePattern()
+	}
+	return nil
+}
+
+// loop polls the capture source on every interval.
+func (c *Capturer) loop(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.poll(ctx)
+		}
+	}
+}
+
+// poll collects new core dumps from the capture source.
+func (c *Capturer) poll(ctx context.Context) {
+	switch c.mode {
+	case ModeCorePattern:
+		c.pollSpool(time.Now())
+	case ModeCoredumpctl:
+		c.pollCoredumpctl(ctx)
+	}
+}
+
+// detectMode picks coredumpctl when systemd-coredump owns core_pattern.
+func detectMode() string {
+	pattern, err := readCorePattern()
+	if err == nil && strings.Contains(pattern, "systemd-coredump") {
+		if _, err := exec.LookPath("coredumpctl"); err == nil {
+			return ModeCoredumpctl
+		}
+	}
+	return ModeCorePattern
+}
+
+// originalPatternFile stores the core_pattern that was active before the
+// agent installed its helper, so it can be restored after an agent crash.
+func (c *Capturer) originalPatternFile() string {
+	return filepath.Join(c.config.Directory, "core_pattern.orig")
+}
+
+// installCorePattern registers the helper as the kernel core_pattern.
+func (c *Capturer) installCorePattern() error {
+	command := c.config.HelperCommand
+	if len(command) == 0 {
+		exe, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to resolve agent executable: %w", err)
+		}
+		command = []string{exe, "agent", HelperCommand}
+	}
+
+	pattern, err := CorePattern(command, c.spoolDir, c.config.MaxDumpBytes)
+	if err != nil {
+		return err
+	}
+
+	current, err := readCorePattern()
+	if err != nil {
+		return err
+	}
+
+	previous := current
+	if current == pattern {
+		// Left over from an agent that did not shut down cleanly.
+		if data, err := os.ReadFile(c.originalPatternFile()); err == nil {
+			previous = string(data)
+		} else {
+			previous = "core"
+		}
+	}
+
+	if err := os.WriteFile(c.originalPatternFile(), []byte(previous), 0600); err != nil {
+		return fmt.Errorf("failed to save previous core_pattern: %w", err)
+	}
+	if err := writeCorePattern(pattern); err != nil {
+		return err
+	}
+
+	c.installedPattern = pattern
+	c.previousPattern = previous
+	return nil
+}
+
+// restoreCorePattern reinstates the previous core_pattern unless it was
+// changed by someone else in the meantime.
+func (c *Capturer) restoreCorePattern() error {
+	current, err := readCorePattern()
+	if err != nil {
+		return err
+	}
+	if current != c.installedPattern {
+		c.logger.Warn().
+			Str("core_pattern", current).
+			Msg("core_pattern changed while the agent was running, leaving it as is")
+		return nil
+	}
+
+	if err := writeCorePattern(c.previousPattern); err != nil {
+		return err
+	}
+	_ = os.Remove(c.originalPatternFile())
+	return nil
+}
+
+// pollSpool moves complete dumps written by the helper into the store.
+func (c *Capturer) pollSpool(now time.Time) {
+	entries, err := os.ReadDir(c.spoolDir)
+	if err != nil {
+		c.logger.Debug().Err(err).Msg("Failed to read core dump spool")
+		return
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(c.spoolDir, name)
+
+		if strings.HasSuffix(name, tmpSuffix) {
+			if info, err := entry.Info(); err == nil && now.Sub(info.ModTime()) > spoolStaleAfter {
+				_ = os.Remove(path)
+			}
+			continue
+		}
+		if !strings.HasSuffix(name, metaSuffix) {
+			continue
+		}
+
+		var info Info
+		if err := readJSON(path, &info); err != nil {
+			c.logger.Warn().Err(err).Str("file", name).Msg("Discarding unreadable spooled core dump")
+			c.discardSpooled(strings.TrimSuffix(name, metaSuffix))
+			continue
+		}
+
+		c.ingest(info, filepath.Join(c.spoolDir, info.ID+dumpSuffix))
+		_ = os.Remove(path)
+	}
+}
+
+// discardSpooled removes a spooled dump and its metadata.
+func (c *Capturer) discardSpooled(id string) {
+	_ = os.Remove(filepath.Join(c.spoolDir, id+dumpSuffix))
+	_ = os.Remove(filepath.Join(c.spoolDir, id+metaSuffix))
+}
+
+// ingest stores a compressed dump if it belongs to a registered service.
+func (c *Capturer) ingest(info Info, gzPath string) {
+	service, ok := c.resolve(info.PID, info.Executable)
+	if !ok {
+		c.logger.Debug().
+			Int32("pid", info.PID).
+			Str("executable", info.Executable).
+			Msg("Discarding core dump of unregistered process")
+		_ = os.Remove(gzPath)
+		return
+	}
+	info.ServiceName = service
+
+	stored, err := c.store.Import(info, gzPath)
+	if err != nil {
+		c.logger.Error().Err(err).Str("service", service).Msg("Failed to store core dump")
+		_ = os.Remove(gzPath)
+		return
+	}
+	c.logCaptured(stored)
+}
+
+// pollCoredumpctl collects dumps recorded by systemd-coredump since the last
+// poll.
+func (c *Capturer) pollCoredumpctl(ctx context.Context) {
+	entries, err := listCoredumpctl(ctx, c.runCmd, c.since)
+	if err != nil {
+		c.logger.Debug().Err(err).Msg("Failed to list core dumps")
+		return
+	}
+
+	for _, entry := range entries {
+		crashedAt := entry.crashTime()
+		key := fmt.Sprintf("%d-%d", entry.Time, entry.PID)
+		if _, ok := c.seen[key]; ok {
+			continue
+		}
+		c.seen[key] = crashedAt
+		if crashedAt.After(c.since) {
+			c.since = crashedAt
+		}
+
+		if entry.CoreFile != "present" {
+			continue
+		}
+
+		service, ok := c.resolve(entry.PID, entry.Exe)
+		if !ok {
+			continue
+		}
+
+		info := Info{
+			ServiceName: service,
+			PID:         entry.PID,
+			Executable:  entry.Exe,
+			Signal:      entry.Signal,
+			CrashedAt:   crashedAt,
+		}
+		if err := c.collectCoredumpctl(ctx, info); err != nil {
+			c.logger.Error().Err(err).Str("service", service).Msg("Failed to collect core dump")
+		}
+	}
+
+	// Entries at the boundary of --since are listed again; forget the rest.
+	for key, t := range c.seen {
+		if t.Before(c.since) {
+			delete(c.seen, key)
+		}
+	}
+}
+
+// collectCoredumpctl extracts a dump with coredumpctl and compresses it into
+// the store.
+func (c *Capturer) collectCoredumpctl(ctx context.Context, info Info) error {
+	raw := filepath.Join(c.spoolDir, dumpID(info)+".core"+tmpSuffix)
+	defer func() { _ = os.Remove(raw) }()
+
+	if err := dumpCoredumpctl(ctx, c.runCmd, info.PID, raw); err != nil {
+		return err
+	}
+
+	f, err := os.Open(raw) // #nosec G304 - path is built by the capturer.
+	if err != nil {
+		return fmt.Errorf("failed to open extracted core dump: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	stored, err := c.store.Write(info, f, c.config.MaxDumpBytes)
+	if err != nil {
+		return err
+	}
+	c.logCaptured(stored)
+	return nil
+}
+
+func (c *Capturer) logCaptured(info Info) {
+	c.logger.Warn().
+		Str("service", info.ServiceName).
+		Int32("pid", info.PID).
+		Int32("signal", info.Signal).
+		Str("id", info.ID).
+		Uint64("size_bytes", info.SizeBytes).
+		Bool("truncated", info.Truncated).
+		Msg("Captured core dump of crashed service")
+}
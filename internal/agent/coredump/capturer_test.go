@@ -0,0 +1,134 @@
+package coredump
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCapturer(t *testing.T, mode string, services map[int32]string) *Capturer {
+	t.Helper()
+
+	return NewCapturer(Config{
+		Mode:      mode,
+		Directory: t.TempDir(),
+		Logger:    zerolog.Nop(),
+	}, func(pid int32, _ string) (string, bool) {
+		name, ok := services[pid]
+		return name, ok
+	})
+}
+
+func TestCorePattern(t *testing.T) {
+	pattern, err := CorePattern([]string{"/usr/bin/coral", "agent", HelperCommand}, "/var/lib/coral/spool", 1<<30)
+	require.NoError(t, err)
+	assert.Equal(t, "|/usr/bin/coral agent coredump-helper /var/lib/coral/spool 1073741824 %P %t %s", pattern)
+
+	_, err = CorePattern([]string{"/usr/bin/coral"}, "/"+strings.Repeat("x", 128), 0)
+	assert.Error(t, err, "patterns over the kernel limit are rejected")
+}
+
+func TestCapturer_CorePatternInstallRestore(t *testing.T) {
+	patternFile := filepath.Join(t.TempDir(), "core_pattern")
+	require.NoError(t, os.WriteFile(patternFile, []byte("core\n"), 0600))
+
+	orig := corePatternPath
+	corePatternPath = patternFile
+	t.Cleanup(func() { corePatternPath = orig })
+
+	c := newTestCapturer(t, ModeCorePattern, nil)
+	c.config.HelperCommand = []string{"/usr/bin/coral", "agent", HelperCommand}
+	c.config.PollInterval = time.Hour
+
+	require.NoError(t, c.Start())
+	current, err := readCorePattern()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(current, "|/usr/bin/coral agent coredump-helper "))
+
+	require.NoError(t, c.Stop())
+	current, err = readCorePattern()
+	require.NoError(t, err)
+	assert.Equal(t, "core", current)
+}
+
+func TestCapturer_PollSpool(t *testing.T) {
+	c := newTestCapturer(t, ModeCorePattern, map[int32]string{4242: "api"})
+	c.mode = ModeCorePattern
+	require.NoError(t, os.MkdirAll(c.spoolDir, 0700))
+
+	// Simulate the kernel running the helper for a service and an unrelated process.
+	require.NoError(t, RunHelper([]string{c.spoolDir, "0", "4242", "1767225600", "11"},
+		strings.NewReader("api core")))
+	require.NoError(t, RunHelper([]string{c.spoolDir, "0", "999", "1767225601", "6"},
+		strings.NewReader("other core")))
+
+	c.pollSpool(time.Now())
+
+	infos, err := c.Store().List("")
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "api", infos[0].ServiceName)
+	assert.Equal(t, int32(11), infos[0].Signal)
+	assert.Equal(t, uint64(len("api core")), infos[0].SizeBytes)
+
+	_, data := readDump(t, c.Store(), infos[0].ID)
+	assert.Equal(t, "api core", string(data))
+
+	spooled, err := os.ReadDir(c.spoolDir)
+	require.NoError(t, err)
+	assert.Empty(t, spooled, "spool is drained, unregistered dumps are discarded")
+}
+
+func TestCapturer_PollCoredumpctl(t *testing.T) {
+	c := newTestCapturer(t, ModeCoredumpctl, map[int32]string{4242: "api"})
+	c.mode = ModeCoredumpctl
+	c.since = time.UnixMicro(1767225600000000)
+	require.NoError(t, os.MkdirAll(c.spoolDir, 0700))
+
+	entries := []struct {
+		at   int64
+		json string
+	}{
+		{1767225601, `{"time":1767225601000000,"pid":4242,"sig":11,"corefile":"present","exe":"/usr/bin/api"}`},
+		{1767225602, `{"time":1767225602000000,"pid":999,"sig":6,"corefile":"present","exe":"/usr/bin/other"}`},
+		{1767225603, `{"time":1767225603000000,"pid":4242,"sig":11,"corefile":"missing","exe":"/usr/bin/api"}`},
+	}
+
+	var dumps int
+	c.runCmd = func(_ context.Context, name string, args ...string) ([]byte, error) {
+		require.Equal(t, "coredumpctl", name)
+
+		last := args[len(args)-1]
+		if since, ok := strings.CutPrefix(last, "--since=@"); ok {
+			var listed []string
+			for _, e := range entries {
+				if strconv.FormatInt(e.at, 10) >= since {
+					listed = append(listed, e.json)
+				}
+			}
+			return []byte("[" + strings.Join(listed, ",") + "]"), nil
+		}
+
+		dumps++
+		return nil, os.WriteFile(strings.TrimPrefix(last, "--output="), []byte("api core"), 0600)
+	}
+
+	c.pollCoredumpctl(context.Background())
+	// Entries already seen are not collected twice.
+	c.pollCoredumpctl(context.Background())
+
+	assert.Equal(t, 1, dumps)
+	infos, err := c.Store().List("api")
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "/usr/bin/api", infos[0].Executable)
+	assert.Equal(t, time.UnixMicro(1767225601000000).Unix(), infos[0].CrashedAt.Unix())
+}
@@ -0,0 +1,63 @@
+package coredump
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// coredumpctlEntry is one element of `coredumpctl --json=short list`.
+type coredumpctlEntry struct {
+	Time     int64  `json:"time"` // Microseconds since the epoch.
+	PID      int32  `json:"pid"`
+	Signal   int32  `json:"sig"`
+	CoreFile string `json:"corefile"`
+	Exe      string `json:"exe"`
+}
+
+// commandRunner runs a command and returns its standard output.
+type commandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// runCommand is the default commandRunner.
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output() // #nosec G204 - fixed binary, agent-built arguments.
+}
+
+// listCoredumpctl returns core dumps recorded by systemd-coredump since the
+// given time.
+func listCoredumpctl(ctx context.Context, run commandRunner, since time.Time) ([]coredumpctlEntry, error) {
+	out, err := run(ctx, "coredumpctl", "--json=short", "--no-pager", "list",
+		"--since=@"+strconv.FormatInt(since.Unix(), 10))
+	if err != nil {
+		// coredumpctl exits non-zero when no entries match.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && strings.Contains(string(exitErr.Stderr), "No coredumps found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("coredumpctl list failed: %w", err)
+	}
+
+	var entries []coredumpctlEntry
+	if err := json.Unmarshal(out, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse coredumpctl output: %w", err)
+	}
+	return entries, nil
+}
+
+// dumpCoredumpctl extracts the core dump of pid into path.
+func dumpCoredumpctl(ctx context.Context, run commandRunner, pid int32, path string) error {
+	if _, err := run(ctx, "coredumpctl", "--no-pager", "dump", strconv.Itoa(int(pid)), "--output="+path); err != nil {
+		return fmt.Errorf("coredumpctl dump failed: %w", err)
+	}
+	return nil
+}
+
+// crashTime converts a coredumpctl timestamp to time.Time.
+func (e coredumpctlEntry) crashTime() time.Time {
+	return time.UnixMicro(e.Time)
+}
@@ -0,0 +1,114 @@
+package coredump
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// corePatternPath is the kernel core_pattern file, overridable for tests.
+var corePatternPath = "/proc/sys/kernel/core_pattern"
+
+// maxCorePatternLen is the kernel limit on core_pattern (CORENAME_MAX_SIZE
+// minus the terminating NUL).
+const maxCorePatternLen = 127
+
+// HelperCommand is the agent subcommand the kernel pipes core dumps into.
+const HelperCommand = "coredump-helper"
+
+// CorePattern returns the core_pattern that pipes core dumps into the helper
+// command, e.g. ["/usr/bin/coral", "agent", "coredump-helper"]. The kernel
+// expands %P (global PID), %t (crash time) and %s (signal) when it runs the
+// helper.
+func CorePattern(command []string, spoolDir string, maxDumpBytes int64) (string, error) {
+	pattern := fmt.Sprintf("|%s %s %d %%P %%t %%s", strings.Join(command, " "), spoolDir, maxDumpBytes)
+	if len(pattern) > maxCorePatternLen {
+		return "", fmt.Errorf("core_pattern exceeds %d bytes, use a shorter core dump directory: %s",
+			maxCorePatternLen, pattern)
+	}
+	return pattern, nil
+}
+
+// readCorePattern returns the current kernel core_pattern.
+func readCorePattern() (string, error) {
+	data, err := os.ReadFile(corePatternPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read core_pattern: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// writeCorePattern sets the kernel core_pattern.
+func writeCorePattern(pattern string) error {
+	if err := os.WriteFile(corePatternPath, []byte(pattern), 0600); err != nil {
+		return fmt.Errorf("failed to write core_pattern: %w", err)
+	}
+	return nil
+}
+
+// RunHelper is the entry point of the core_pattern helper. The kernel runs it
+// with the core dump on stdin and the arguments built by CorePattern:
+// <spool dir> <max dump bytes> <pid> <unix time> <signal>. The dump is
+// compressed into the spool directory, where the agent picks it up.
+func RunHelper(args []string, core io.Reader) error {
+	if len(args) != 5 {
+		return fmt.Errorf("expected 5 arguments, got %d", len(args))
+	}
+
+	spoolDir := args[0]
+	maxBytes, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid max dump bytes %q: %w", args[1], err)
+	}
+	pid, err := strconv.ParseInt(args[2], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid pid %q: %w", args[2], err)
+	}
+	crashedAt, err := strconv.ParseInt(args[3], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid crash time %q: %w", args[3], err)
+	}
+	signal, err := strconv.ParseInt(args[4], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid signal %q: %w", args[4], err)
+	}
+
+	if err := os.MkdirAll(spoolDir, 0700); err != nil {
+		return fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	info := Info{
+		PID:       int32(pid),
+		Signal:    int32(signal),
+		CrashedAt: time.Unix(crashedAt, 0),
+	}
+	info.ID = dumpID(info)
+
+	// The crashed process stays in /proc until the helper exits.
+	if exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid)); err == nil {
+		info.Executable = strings.TrimSuffix(exe, " (deleted)")
+	}
+
+	base := filepath.Join(spoolDir, info.ID)
+	tmp := base + dumpSuffix + tmpSuffix
+
+	size, truncated, err := compressTo(tmp, core, maxBytes)
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	info.SizeBytes = size
+	info.Truncated = truncated
+
+	if err := os.Rename(tmp, base+dumpSuffix); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write core dump: %w", err)
+	}
+
+	// Metadata is written last and marks the spooled dump as complete.
+	return writeJSON(base+metaSuffix, info)
+}
@@ -0,0 +1,303 @@
+// Package coredump captures core dumps of crashed services, compresses them,
+// and keeps them on disk within a size quota for later download.
+package coredump
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	dumpSuffix = ".core.gz"
+	metaSuffix = ".json"
+	tmpSuffix  = ".tmp"
+)
+
+// ErrNotFound is returned when a core dump does not exist in the store.
+var ErrNotFound = errors.New("core dump not found")
+
+// Info describes a stored core dump.
+type Info struct {
+	ID              string    `json:"id"`
+	ServiceName     string    `json:"service_name"`
+	PID             int32     `json:"pid"`
+	Executable      string    `json:"executable"`
+	Signal          int32     `json:"signal"`
+	CrashedAt       time.Time `json:"crashed_at"`
+	SizeBytes       uint64    `json:"size_bytes"`       // Uncompressed size.
+	CompressedBytes uint64    `json:"compressed_bytes"` // Size on disk.
+	Truncated       bool      `json:"truncated"`        // Exceeded the per-dump limit.
+}
+
+// Store keeps gzip-compressed core dumps and their metadata in a directory.
+// The oldest dumps are evicted once the total size exceeds the quota.
+type Store struct {
+	dir           string
+	maxTotalBytes int64 // 0 = unlimited.
+
+	mu sync.Mutex
+}
+
+// NewStore creates a store in dir. The directory is created on first write.
+func NewStore(dir string, maxTotalBytes int64) *Store {
+	return &Store{
+		dir:           dir,
+		maxTotalBytes: maxTotalBytes,
+	}
+}
+
+// Dir returns the store directory.
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Write compresses a raw core dump from r into the store, reading at most
+// maxBytes (0 = unlimited), and evicts old dumps if over quota.
+func (s *Store) Write(info Info, r io.Reader, maxBytes int64) (Info, error) {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return Info{}, fmt.Errorf("failed to create core dump directory: %w", err)
+	}
+
+	info.ID = dumpID(info)
+	tmp := filepath.Join(s.dir, info.ID+dumpSuffix+tmpSuffix)
+
+	size, truncated, err := compressTo(tmp, r, maxBytes)
+	if err != nil {
+		_ = os.Remove(tmp)
+		return Info{}, err
+	}
+	info.SizeBytes = size
+	info.Truncated = truncated
+
+	return s.Import(info, tmp)
+}
+
+// Import moves an already compressed core dump at gzPath into the store and
+// evicts old dumps if over quota. gzPath must be on the same filesystem.
+func (s *Store) Import(info Info, gzPath string) (Info, error) {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return Info{}, fmt.Errorf("failed to create core dump directory: %w", err)
+	}
+
+	if info.ID == "" {
+		info.ID = dumpID(info)
+	}
+
+	stat, err := os.Stat(gzPath)
+	if err != nil {
+		return Info{}, fmt.Errorf("failed to stat core dump: %w", err)
+	}
+	info.CompressedBytes = uint64(stat.Size()) // #nosec G115 - file sizes are non-negative.
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Rename(gzPath, s.path(info.ID, dumpSuffix)); err != nil {
+		return Info{}, fmt.Errorf("failed to move core dump into store: %w", err)
+	}
+	if err := writeJSON(s.path(info.ID, metaSuffix), info); err != nil {
+		_ = os.Remove(s.path(info.ID, dumpSuffix))
+		return Info{}, err
+	}
+
+	s.enforceQuotaLocked()
+	return info, nil
+}
+
+// List returns stored core dumps, newest first, optionally filtered by
+// service name.
+func (s *Store) List(serviceName string) ([]Info, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos, err := s.listLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := infos[:0]
+	for _, info := range infos {
+		if serviceName == "" || info.ServiceName == serviceName {
+			filtered = append(filtered, info)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CrashedAt.After(filtered[j].CrashedAt)
+	})
+	return filtered, nil
+}
+
+// Open returns the metadata and compressed contents of a core dump. The
+// caller must close the reader.
+func (s *Store) Open(id string) (Info, io.ReadCloser, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return Info{}, nil, ErrNotFound
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var info Info
+	if err := readJSON(s.path(id, metaSuffix), &info); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Info{}, nil, ErrNotFound
+		}
+		return Info{}, nil, err
+	}
+
+	f, err := os.Open(s.path(id, dumpSuffix))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Info{}, nil, ErrNotFound
+		}
+		return Info{}, nil, fmt.Errorf("failed to open core dump: %w", err)
+	}
+	return info, f, nil
+}
+
+// listLocked reads all metadata files in the store.
+func (s *Store) listLocked() ([]Info, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read core dump directory: %w", err)
+	}
+
+	var infos []Info
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), metaSuffix) {
+			continue
+		}
+		var info Info
+		if err := readJSON(filepath.Join(s.dir, entry.Name()), &info); err != nil {
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// enforceQuotaLocked deletes the oldest dumps until the store fits its quota.
+func (s *Store) enforceQuotaLocked() {
+	if s.maxTotalBytes <= 0 {
+		return
+	}
+
+	infos, err := s.listLocked()
+	if err != nil {
+		return
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].CrashedAt.Before(infos[j].CrashedAt)
+	})
+
+	var total int64
+	for _, info := range infos {
+		total += int64(info.CompressedBytes) // #nosec G115 - bounded by file size.
+	}
+
+	for _, info := range infos {
+		if total <= s.maxTotalBytes {
+			return
+		}
+		s.removeLocked(info.ID)
+		total -= int64(info.CompressedBytes) // #nosec G115 - bounded by file size.
+	}
+}
+
+// removeLocked deletes a dump and its metadata.
+func (s *Store) removeLocked(id string) {
+	_ = os.Remove(s.path(id, metaSuffix))
+	_ = os.Remove(s.path(id, dumpSuffix))
+}
+
+func (s *Store) path(id, suffix string) string {
+	return filepath.Join(s.dir, id+suffix)
+}
+
+// dumpID derives a stable identifier from the crash time and PID.
+func dumpID(info Info) string {
+	return fmt.Sprintf("%d-%d", info.CrashedAt.Unix(), info.PID)
+}
+
+// compressTo gzips at most maxBytes (0 = unlimited) of r into path. It
+// returns the number of raw bytes written and whether r was truncated.
+func compressTo(path string, r io.Reader, maxBytes int64) (uint64, bool, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) // #nosec G304 - path is built by the store.
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create core dump file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	zw := gzip.NewWriter(f)
+
+	src := r
+	if maxBytes > 0 {
+		src = io.LimitReader(r, maxBytes)
+	}
+
+	n, err := io.Copy(zw, src)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to compress core dump: %w", err)
+	}
+
+	truncated := false
+	if maxBytes > 0 && n == maxBytes {
+		// Probe one more byte to detect truncation.
+		var probe [1]byte
+		m, _ := io.ReadFull(r, probe[:])
+		truncated = m > 0
+	}
+
+	if err := zw.Close(); err != nil {
+		return 0, false, fmt.Errorf("failed to compress core dump: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return 0, false, fmt.Errorf("failed to write core dump: %w", err)
+	}
+
+	return uint64(n), truncated, nil // #nosec G115 - io.Copy returns a non-negative count.
+}
+
+// writeJSON atomically writes v as JSON to path.
+func writeJSON(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode core dump metadata: %w", err)
+	}
+
+	tmp := path + tmpSuffix
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write core dump metadata: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write core dump metadata: %w", err)
+	}
+	return nil
+}
+
+// readJSON decodes the JSON file at path into v.
+func readJSON(path string, v any) error {
+	data, err := os.ReadFile(path) // #nosec G304 - path is built by the store.
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode core dump metadata: %w", err)
+	}
+	return nil
+}
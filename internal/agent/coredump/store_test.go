@@ -0,0 +1,99 @@
+package coredump
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readDump decompresses a stored dump.
+func readDump(t *testing.T, s *Store, id string) (Info, []byte) {
+	t.Helper()
+
+	info, r, err := s.Open(id)
+	require.NoError(t, err)
+	defer func() { _ = r.Close() }()
+
+	zr, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	return info, data
+}
+
+func TestStore_WriteListOpen(t *testing.T) {
+	s := NewStore(t.TempDir(), 0)
+	crashed := time.Unix(1767225600, 0)
+
+	info, err := s.Write(Info{ServiceName: "api", PID: 42, Signal: 11, CrashedAt: crashed},
+		strings.NewReader("core contents"), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "1767225600-42", info.ID)
+	assert.Equal(t, uint64(len("core contents")), info.SizeBytes)
+	assert.NotZero(t, info.CompressedBytes)
+	assert.False(t, info.Truncated)
+
+	_, err = s.Write(Info{ServiceName: "worker", PID: 7, CrashedAt: crashed.Add(time.Minute)},
+		strings.NewReader("other"), 0)
+	require.NoError(t, err)
+
+	all, err := s.List("")
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "worker", all[0].ServiceName, "newest first")
+
+	api, err := s.List("api")
+	require.NoError(t, err)
+	require.Len(t, api, 1)
+
+	got, data := readDump(t, s, info.ID)
+	assert.Equal(t, "core contents", string(data))
+	assert.Equal(t, int32(11), got.Signal)
+
+	_, _, err = s.Open("../etc/passwd")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, _, err = s.Open("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_TruncatesLargeDumps(t *testing.T) {
+	s := NewStore(t.TempDir(), 0)
+
+	info, err := s.Write(Info{ServiceName: "api", PID: 1, CrashedAt: time.Now()},
+		bytes.NewReader(make([]byte, 100)), 64)
+	require.NoError(t, err)
+	assert.True(t, info.Truncated)
+	assert.Equal(t, uint64(64), info.SizeBytes)
+
+	_, data := readDump(t, s, info.ID)
+	assert.Len(t, data, 64)
+}
+
+func TestStore_EvictsOldestOverQuota(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Unix(1767225600, 0)
+
+	// Measure the compressed size of one dump to size the quota.
+	probe := NewStore(t.TempDir(), 0)
+	sample, err := probe.Write(Info{CrashedAt: base}, strings.NewReader("dump"), 0)
+	require.NoError(t, err)
+
+	s := NewStore(dir, int64(sample.CompressedBytes)*2)
+	for i := 0; i < 3; i++ {
+		_, err := s.Write(Info{ServiceName: "api", PID: int32(i + 1), CrashedAt: base.Add(time.Duration(i) * time.Minute)},
+			strings.NewReader("dump"), 0)
+		require.NoError(t, err)
+	}
+
+	infos, err := s.List("api")
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, int32(3), infos[0].PID)
+	assert.Equal(t, int32(2), infos[1].PID, "the oldest dump is evicted")
+}
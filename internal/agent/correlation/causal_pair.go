@@ -130,6 +130,8 @@ func (c *causalPair) extractJoin(event *agentv1.UprobeEvent) string {
 		return fmt.Sprintf("%d", event.Pid)
 	case "tid":
 		return fmt.Sprintf("%d", event.Tid)
+	case "goroutine_id":
+		return fmt.Sprintf("%d", event.GoroutineId)
 	}
 	return ""
 }
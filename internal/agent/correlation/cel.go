@@ -69,6 +69,7 @@ func eventToMap(e *agentv1.UprobeEvent) map[string]any {
 		"duration_ns":   int64(e.DurationNs),
 		"pid":           int64(e.Pid),
 		"tid":           int64(e.Tid),
+		"goroutine_id":  int64(e.GoroutineId), //nolint:gosec // G115: goroutine IDs fit in int64
 		"function_name": e.FunctionName,
 		"event_type":    e.EventType,
 		"service_name":  e.ServiceName,
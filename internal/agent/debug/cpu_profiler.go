@@ -14,6 +14,7 @@ import (
 	"golang.org/x/sys/unix"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/bpfsign"
 	"github.com/coral-mesh/coral/internal/safe"
 	"github.com/coral-mesh/coral/internal/sys/proc"
 )
@@ -69,6 +70,9 @@ func StartCPUProfile(pid int, durationSeconds int, frequencyHz int, kernelSymbol
 	}
 
 	// Load BPF program.
+	if err := bpfsign.Verify(bpfsign.ObjectName("cpu_profile"), _Cpu_profileBytes); err != nil {
+		return nil, err
+	}
 	objs := &cpu_profileObjects{}
 	if err := loadCpu_profileObjects(objs, nil); err != nil {
 		return nil, fmt.Errorf("load BPF objects: %w", err)
@@ -53,6 +53,10 @@ func NewFunctionCache(db *sql.DB, logger zerolog.Logger) (*FunctionCache, error)
 	return cache, nil
 }
 
+// functionCacheMigrations are the functions_cache schema changes applied
+// after initSchema.
+var functionCacheMigrations []duckdb.Migration
+
 // initSchema creates the functions cache table in agent's local DuckDB.
 func (c *FunctionCache) initSchema() error {
 	schema := `
@@ -92,6 +96,10 @@ func (c *FunctionCache) initSchema() error {
 		return fmt.Errorf("failed to create function cache schema: %w", err)
 	}
 
+	if err := duckdb.ApplyMigrations(context.Background(), c.db, "function_cache", functionCacheMigrations, c.logger); err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
 	c.logger.Info().Msg("Function cache schema initialized")
 	return nil
 }
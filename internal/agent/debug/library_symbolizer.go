@@ -0,0 +1,184 @@
+//go:build linux
+// +build linux
+
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LibrarySymbol is an address resolved in a file mapped by a process.
+type LibrarySymbol struct {
+	Library      string // Base name of the file, e.g. "libc.so.6"
+	Offset       uint64 // Offset of the address in the file
+	FunctionName string // Empty if the file has no symbol for the address
+}
+
+// LibrarySymbolizer resolves addresses in the executable file mappings of a
+// process, such as its shared libraries, to ELF symbols. Unlike Symbolizer,
+// it covers code outside the main binary, e.g. libc or libraries called
+// through cgo, but without file and line.
+type LibrarySymbolizer struct {
+	pid      int
+	mappings []fileMapping        // Sorted by start address
+	files    map[string]*elfIndex // By path, nil if unreadable
+}
+
+// fileMapping is an executable mapping of /proc/PID/maps.
+type fileMapping struct {
+	start, end, offset uint64
+	path               string
+}
+
+// elfIndex locates the functions of an ELF file by virtual address.
+type elfIndex struct {
+	loads   []elf.ProgHeader
+	symbols []elf.Symbol // Functions sorted by address
+}
+
+// NewLibrarySymbolizer reads the executable file mappings of pid.
+func NewLibrarySymbolizer(pid int) (*LibrarySymbolizer, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/maps", pid)) // #nosec G304: pid is int so it's safe
+	if err != nil {
+		return nil, fmt.Errorf("failed to read maps: %w", err)
+	}
+	return &LibrarySymbolizer{
+		pid:      pid,
+		mappings: parseFileMappings(data),
+		files:    make(map[string]*elfIndex),
+	}, nil
+}
+
+// parseFileMappings returns the executable mappings of files in a
+// /proc/PID/maps table.
+// Format: address perms offset dev inode pathname.
+func parseFileMappings(data []byte) []fileMapping {
+	var mappings []fileMapping
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 6 || !strings.Contains(fields[1], "x") || !strings.HasPrefix(fields[5], "/") {
+			continue
+		}
+		start, end, ok := strings.Cut(fields[0], "-")
+		if !ok {
+			continue
+		}
+		var m fileMapping
+		var err1, err2, err3 error
+		m.start, err1 = strconv.ParseUint(start, 16, 64)
+		m.end, err2 = strconv.ParseUint(end, 16, 64)
+		m.offset, err3 = strconv.ParseUint(fields[2], 16, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		m.path = fields[5]
+		mappings = append(mappings, m)
+	}
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].start < mappings[j].start })
+	return mappings
+}
+
+// Resolve resolves an address to the symbol of the file mapped there.
+func (s *LibrarySymbolizer) Resolve(addr uint64) (LibrarySymbol, error) {
+	i := sort.Search(len(s.mappings), func(i int) bool { return s.mappings[i].end > addr })
+	if i == len(s.mappings) || addr < s.mappings[i].start {
+		return LibrarySymbol{}, fmt.Errorf("address 0x%x is not in a mapped file", addr)
+	}
+	m := s.mappings[i]
+
+	sym := LibrarySymbol{
+		Library: filepath.Base(m.path),
+		Offset:  addr - m.start + m.offset,
+	}
+	if index := s.index(m.path); index != nil {
+		sym.FunctionName = index.lookup(sym.Offset)
+	}
+	return sym, nil
+}
+
+// index returns the functions of a mapped file, read through the root of
+// the process so that files of containers are found.
+func (s *LibrarySymbolizer) index(path string) *elfIndex {
+	if index, ok := s.files[path]; ok {
+		return index
+	}
+
+	index, err := readELFIndex(fmt.Sprintf("/proc/%d/root%s", s.pid, path))
+	if err != nil {
+		index = nil
+	}
+	s.files[path] = index
+	return index
+}
+
+func readELFIndex(path string) (*elfIndex, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint:errcheck
+
+	index := &elfIndex{}
+	for _, prog := range f.Progs {
+		if prog.Type == elf.PT_LOAD {
+			index.loads = append(index.loads, prog.ProgHeader)
+		}
+	}
+
+	// Stripped libraries keep their exported functions in .dynsym.
+	symbols, _ := f.Symbols()
+	dynamic, _ := f.DynamicSymbols()
+	for _, sym := range append(symbols, dynamic...) {
+		if elf.ST_TYPE(sym.Info) == elf.STT_FUNC && sym.Value != 0 && sym.Size > 0 {
+			index.symbols = append(index.symbols, sym)
+		}
+	}
+	sort.Slice(index.symbols, func(i, j int) bool { return index.symbols[i].Value < index.symbols[j].Value })
+	return index, nil
+}
+
+// lookup returns the function at a file offset, or "" if none.
+func (x *elfIndex) lookup(offset uint64) string {
+	// Convert the offset to the virtual address of the segment loading it.
+	var addr uint64
+	found := false
+	for _, load := range x.loads {
+		if offset >= load.Off && offset < load.Off+load.Filesz {
+			addr = offset - load.Off + load.Vaddr
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ""
+	}
+
+	i := sort.Search(len(x.symbols), func(i int) bool { return x.symbols[i].Value > addr })
+	if i == 0 {
+		return ""
+	}
+	sym := x.symbols[i-1]
+	if addr >= sym.Value+sym.Size {
+		return ""
+	}
+	return sym.Name
+}
+
+// FormatLibrarySymbol formats a library symbol for display, e.g.
+// "[libc.so.6] pthread_mutex_lock", or with the offset if the function is
+// not known.
+func FormatLibrarySymbol(sym LibrarySymbol) string {
+	if sym.FunctionName != "" {
+		return fmt.Sprintf("[%s] %s", sym.Library, sym.FunctionName)
+	}
+	return fmt.Sprintf("[%s] +0x%x", sym.Library, sym.Offset)
+}
@@ -0,0 +1,34 @@
+//go:build !linux
+// +build !linux
+
+package debug
+
+import "fmt"
+
+// LibrarySymbol is an address resolved in a mapped file (stub for non-Linux).
+type LibrarySymbol struct {
+	Library      string
+	Offset       uint64
+	FunctionName string
+}
+
+// LibrarySymbolizer stub for non-Linux platforms.
+type LibrarySymbolizer struct{}
+
+// NewLibrarySymbolizer returns an error on non-Linux platforms.
+func NewLibrarySymbolizer(pid int) (*LibrarySymbolizer, error) {
+	return nil, fmt.Errorf("symbolization is only supported on Linux")
+}
+
+// Resolve returns an error on non-Linux platforms.
+func (s *LibrarySymbolizer) Resolve(addr uint64) (LibrarySymbol, error) {
+	return LibrarySymbol{}, fmt.Errorf("symbolization is only supported on Linux")
+}
+
+// FormatLibrarySymbol formats a library symbol for display.
+func FormatLibrarySymbol(sym LibrarySymbol) string {
+	if sym.FunctionName != "" {
+		return fmt.Sprintf("[%s] %s", sym.Library, sym.FunctionName)
+	}
+	return fmt.Sprintf("[%s] +0x%x", sym.Library, sym.Offset)
+}
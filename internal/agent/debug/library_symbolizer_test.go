@@ -0,0 +1,75 @@
+//go:build linux
+// +build linux
+
+package debug
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibrarySymbolizer_Resolve(t *testing.T) {
+	s, err := NewLibrarySymbolizer(os.Getpid())
+	require.NoError(t, err)
+
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	// Addresses of the test binary are found in its mapping.
+	addr := uint64(reflect.ValueOf(TestLibrarySymbolizer_Resolve).Pointer()) // #nosec G115
+	sym, err := s.Resolve(addr + 1)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Base(exe), sym.Library)
+
+	// Its exported functions are named from the file offset, even when the
+	// symbol table is stripped as by go test.
+	index, err := readELFIndex(exe)
+	require.NoError(t, err)
+	f, err := elf.Open(exe)
+	require.NoError(t, err)
+	defer f.Close() // nolint:errcheck
+	dynamic, err := f.DynamicSymbols()
+	require.NoError(t, err)
+	names := make(map[uint64][]string) // Aliases share an address.
+	for _, fn := range dynamic {
+		if elf.ST_TYPE(fn.Info) == elf.STT_FUNC && fn.Value != 0 && fn.Size > 0 {
+			names[fn.Value] = append(names[fn.Value], fn.Name)
+		}
+	}
+	for value, aliases := range names {
+		for _, load := range index.loads {
+			if value >= load.Vaddr && value < load.Vaddr+load.Filesz {
+				assert.Contains(t, aliases, index.lookup(value-load.Vaddr+load.Off+1))
+			}
+		}
+	}
+
+	_, err = s.Resolve(0x10)
+	assert.Error(t, err, "unmapped address")
+}
+
+func TestParseFileMappings(t *testing.T) {
+	maps := []byte(`7f1c2a400000-7f1c2a428000 r--p 00000000 08:01 1835 /usr/lib/x86_64-linux-gnu/libc.so.6
+7f1c2a428000-7f1c2a5bd000 r-xp 00028000 08:01 1835 /usr/lib/x86_64-linux-gnu/libc.so.6
+555555554000-555555556000 r-xp 00001000 08:01 4242 /app/server
+7ffd3b9f1000-7ffd3b9f3000 r-xp 00000000 00:00 0 [vdso]
+7f1c2a600000-7f1c2a700000 rw-p 00000000 00:00 0
+`)
+
+	mappings := parseFileMappings(maps)
+	require.Len(t, mappings, 2, "executable file mappings only")
+	assert.Equal(t, fileMapping{start: 0x555555554000, end: 0x555555556000, offset: 0x1000, path: "/app/server"}, mappings[0])
+	assert.Equal(t, "/usr/lib/x86_64-linux-gnu/libc.so.6", mappings[1].path)
+	assert.Equal(t, uint64(0x28000), mappings[1].offset)
+
+	s := &LibrarySymbolizer{mappings: mappings, files: map[string]*elfIndex{"/usr/lib/x86_64-linux-gnu/libc.so.6": nil}}
+	sym, err := s.Resolve(0x7f1c2a428010)
+	require.NoError(t, err)
+	assert.Equal(t, "[libc.so.6] +0x28010", FormatLibrarySymbol(sym))
+}
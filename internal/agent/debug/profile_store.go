@@ -0,0 +1,163 @@
+package debug
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// ORM models for on-demand profile tables.
+
+type cpuProfileDB struct {
+	ProfileID       string    `duckdb:"profile_id,pk"`
+	ServiceName     string    `duckdb:"service_name"`
+	PID             int32     `duckdb:"pid"`
+	DurationSeconds int32     `duckdb:"duration_seconds"`
+	FrequencyHz     int32     `duckdb:"frequency_hz"`
+	TotalSamples    uint64    `duckdb:"total_samples"`
+	LostSamples     uint32    `duckdb:"lost_samples"`
+	CreatedAt       time.Time `duckdb:"created_at,immutable"`
+}
+
+type cpuProfileStackDB struct {
+	ProfileID string `duckdb:"profile_id"`
+	Stack     string `duckdb:"stack"`
+	Count     uint64 `duckdb:"count"`
+}
+
+// CPUProfileInfo describes an on-demand CPU profile.
+type CPUProfileInfo struct {
+	ServiceName     string
+	PID             int32
+	DurationSeconds int32
+	FrequencyHz     int32
+}
+
+// ProfileStore persists on-demand profiles in the agent's local DuckDB so
+// they remain available when the colony is unreachable (offline debugging).
+// Stacks are stored in folded format (root;...;leaf) for flame graph tooling.
+type ProfileStore struct {
+	db          *sql.DB
+	logger      zerolog.Logger
+	profiles    *duckdb.Table[cpuProfileDB]
+	stackCounts *duckdb.Table[cpuProfileStackDB]
+}
+
+// NewProfileStore creates a new on-demand profile store.
+func NewProfileStore(db *sql.DB, logger zerolog.Logger) (*ProfileStore, error) {
+	s := &ProfileStore{
+		db:          db,
+		logger:      logger.With().Str("component", "profile_store").Logger(),
+		profiles:    duckdb.NewTable[cpuProfileDB](db, "cpu_profiles_local"),
+		stackCounts: duckdb.NewTable[cpuProfileStackDB](db, "cpu_profile_stacks_local"),
+	}
+
+	if err := s.initSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	return s, nil
+}
+
+// profileStoreMigrations are the on-demand profile schema changes applied
+// after initSchema.
+var profileStoreMigrations []duckdb.Migration
+
+// initSchema creates the on-demand profile tables.
+func (s *ProfileStore) initSchema() error {
+	schema := `
+		CREATE TABLE IF NOT EXISTS cpu_profiles_local (
+			profile_id        VARCHAR PRIMARY KEY,
+			service_name      VARCHAR,
+			pid               INTEGER,
+			duration_seconds  INTEGER,
+			frequency_hz      INTEGER,
+			total_samples     UBIGINT,
+			lost_samples      UINTEGER,
+			created_at        TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS cpu_profile_stacks_local (
+			profile_id  VARCHAR NOT NULL,
+			stack       VARCHAR NOT NULL,
+			count       UBIGINT NOT NULL
+		);
+
+		CREATE INDEX IF NOT EXISTS idx_cpu_profile_stacks_profile
+		ON cpu_profile_stacks_local(profile_id);
+	`
+
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	if err := duckdb.ApplyMigrations(context.Background(), s.db, "profile_store", profileStoreMigrations, s.logger); err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
+	// Force WAL checkpoint so remote HTTP clients can see the schema.
+	if _, err := s.db.Exec("CHECKPOINT"); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to checkpoint database")
+	}
+
+	return nil
+}
+
+// StoreCPUProfile stores a CPU profile and returns its generated ID.
+func (s *ProfileStore) StoreCPUProfile(ctx context.Context, info CPUProfileInfo, result *CPUProfileResult) (string, error) {
+	profileID := uuid.New().String()
+
+	if err := s.profiles.Upsert(ctx, &cpuProfileDB{
+		ProfileID:       profileID,
+		ServiceName:     info.ServiceName,
+		PID:             info.PID,
+		DurationSeconds: info.DurationSeconds,
+		FrequencyHz:     info.FrequencyHz,
+		TotalSamples:    result.TotalSamples,
+		LostSamples:     result.LostSamples,
+		CreatedAt:       time.Now(),
+	}); err != nil {
+		return "", fmt.Errorf("failed to store CPU profile: %w", err)
+	}
+
+	stacks := make([]*cpuProfileStackDB, 0, len(result.Samples))
+	for _, sample := range result.Samples {
+		if len(sample.FrameNames) == 0 {
+			continue
+		}
+		stacks = append(stacks, &cpuProfileStackDB{
+			ProfileID: profileID,
+			Stack:     foldStack(sample.FrameNames),
+			Count:     sample.Count,
+		})
+	}
+
+	if err := s.stackCounts.BatchUpsert(ctx, stacks); err != nil {
+		return "", fmt.Errorf("failed to store CPU profile stacks: %w", err)
+	}
+
+	s.logger.Info().
+		Str("profile_id", profileID).
+		Str("service", info.ServiceName).
+		Int("stacks", len(stacks)).
+		Msg("Stored CPU profile locally")
+
+	return profileID, nil
+}
+
+// foldStack converts frames captured innermost-first into folded format,
+// ordered from outermost (root) to innermost (leaf).
+func foldStack(frames []string) string {
+	folded := make([]string, len(frames))
+	for i, frame := range frames {
+		folded[len(frames)-1-i] = frame
+	}
+	return strings.Join(folded, ";")
+}
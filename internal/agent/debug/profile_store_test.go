@@ -0,0 +1,65 @@
+package debug
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/marcboeker/go-duckdb"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+)
+
+func TestProfileStore_StoreCPUProfile(t *testing.T) {
+	// Use in-memory DuckDB for testing.
+	db, err := sql.Open("duckdb", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	store, err := NewProfileStore(db, zerolog.Nop())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	result := &CPUProfileResult{
+		Samples: []*agentv1.StackSample{
+			{FrameNames: []string{"leaf", "middle", "main"}, Count: 7},
+			{FrameNames: nil, Count: 1}, // Empty stacks are skipped.
+		},
+		TotalSamples: 8,
+	}
+
+	profileID, err := store.StoreCPUProfile(ctx, CPUProfileInfo{
+		ServiceName:     "api",
+		PID:             42,
+		DurationSeconds: 10,
+		FrequencyHz:     99,
+	}, result)
+	require.NoError(t, err)
+	require.NotEmpty(t, profileID)
+
+	var service string
+	var total uint64
+	err = db.QueryRow("SELECT service_name, total_samples FROM cpu_profiles_local WHERE profile_id = ?", profileID).
+		Scan(&service, &total)
+	require.NoError(t, err)
+	assert.Equal(t, "api", service)
+	assert.Equal(t, uint64(8), total)
+
+	rows, err := db.Query("SELECT stack, count FROM cpu_profile_stacks_local WHERE profile_id = ?", profileID)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var stacks []string
+	for rows.Next() {
+		var stack string
+		var count uint64
+		require.NoError(t, rows.Scan(&stack, &count))
+		assert.Equal(t, uint64(7), count)
+		stacks = append(stacks, stack)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"main;middle;leaf"}, stacks)
+}
@@ -6,7 +6,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/cilium/ebpf/ringbuf"
 	"github.com/rs/zerolog"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
@@ -32,7 +31,7 @@ type DebugSession struct {
 	// eBPF resources
 	AttachResult *uprobe.AttachResult
 	BPFObjects   *uprobe_monitorObjects // BPF programs and maps
-	Reader       *ringbuf.Reader        // Cached from AttachResult for convenience
+	Reader       uprobe.EventReader     // Cached from AttachResult for convenience
 }
 
 // SessionManager manages active debug sessions.
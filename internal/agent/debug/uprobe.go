@@ -5,9 +5,8 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/cilium/ebpf/ringbuf"
-
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/bpfsign"
 	"github.com/coral-mesh/coral/internal/agent/ebpf/uprobe"
 )
 
@@ -22,6 +21,9 @@ func (m *SessionManager) attachUprobeLocked(
 	offset uint64,
 ) error {
 	// 1. Load BPF program.
+	if err := bpfsign.Verify(bpfsign.ObjectName("uprobe_monitor"), _Uprobe_monitorBytes); err != nil {
+		return err
+	}
 	objs := &uprobe_monitorObjects{}
 	if err := loadUprobe_monitorObjects(objs, nil); err != nil {
 		return fmt.Errorf("load BPF objects: %w", err)
@@ -88,11 +90,11 @@ func (m *SessionManager) DetachUprobe(sessionID string) error {
 	return nil
 }
 
-func (m *SessionManager) readEvents(sessionID string, reader *ringbuf.Reader) {
+func (m *SessionManager) readEvents(sessionID string, reader uprobe.EventReader) {
 	for {
-		record, err := reader.Read()
+		sample, err := reader.Read()
 		if err != nil {
-			if errors.Is(err, ringbuf.ErrClosed) {
+			if errors.Is(err, uprobe.ErrClosed) {
 				return
 			}
 			m.logger.Error().Err(err).Str("session_id", sessionID).Msg("Error reading ringbuf event")
@@ -107,15 +109,15 @@ func (m *SessionManager) readEvents(sessionID string, reader *ringbuf.Reader) {
 		//     __u64 duration_ns;
 		// };
 
-		if len(record.RawSample) < 24 { // 8 + 4 + 4 + 8 = 24 bytes
-			m.logger.Warn().Int("len", len(record.RawSample)).Msg("Invalid event size")
+		if len(sample) < 24 { // 8 + 4 + 4 + 8 = 24 bytes
+			m.logger.Warn().Int("len", len(sample)).Msg("Invalid event size")
 			continue
 		}
 
-		timestamp := binary.LittleEndian.Uint64(record.RawSample[0:8])
-		pid := binary.LittleEndian.Uint32(record.RawSample[8:12])
-		tid := binary.LittleEndian.Uint32(record.RawSample[12:16])
-		duration := binary.LittleEndian.Uint64(record.RawSample[16:24])
+		timestamp := binary.LittleEndian.Uint64(sample[0:8])
+		pid := binary.LittleEndian.Uint32(sample[8:12])
+		tid := binary.LittleEndian.Uint32(sample[12:16])
+		duration := binary.LittleEndian.Uint64(sample[16:24])
 
 		event := &agentv1.DebugEvent{
 			SessionId:  sessionID,
@@ -2,16 +2,22 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/agent/coredump"
 	"github.com/coral-mesh/coral/internal/agent/debug"
 	"github.com/coral-mesh/coral/internal/agent/ebpf"
 	"github.com/coral-mesh/coral/internal/agent/profiler"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
 	"github.com/rs/zerolog"
 )
 
@@ -20,6 +26,9 @@ type DebugService struct {
 	agent     *Agent
 	logger    zerolog.Logger
 	sessionID string // Database session UUID for checkpoint tracking (RFD 089).
+
+	// flushEvents sends uprobe events still queued for the colony.
+	flushEvents func(ctx context.Context) error
 }
 
 // NewDebugService creates a new debug service.
@@ -35,6 +44,13 @@ func (s *DebugService) SetSessionID(sessionID string) {
 	s.sessionID = sessionID
 }
 
+// SetEventFlusher sets the function that sends uprobe events still queued for
+// the colony. It is called when a collector stops so that the colony has all
+// of the session's events once StopUprobeCollector returns.
+func (s *DebugService) SetEventFlusher(flush func(ctx context.Context) error) {
+	s.flushEvents = flush
+}
+
 // resolveSdkAddr returns provided if non-empty, otherwise resolves it via the
 // agent's service registry using serviceName.
 func (s *DebugService) resolveSdkAddr(serviceName, provided string) (string, error) {
@@ -56,10 +72,12 @@ func (s *DebugService) StartUprobeCollector(
 
 	sdkAddr, err := s.resolveSdkAddr(req.ServiceName, req.SdkAddr)
 	if err != nil {
-		return &agentv1.StartUprobeCollectorResponse{
-			Supported: false,
-			Error:     fmt.Sprintf("failed to resolve sdk_addr: %v", err),
-		}, nil
+		// Services without SDK leave no address to attach the probe through.
+		if coralerrors.CodeOf(err) == errorsv1.ErrorCode_ERROR_CODE_UNSPECIFIED {
+			err = coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_PROBE_ATTACH_FAILED, err,
+				"service", req.ServiceName, "function", req.FunctionName)
+		}
+		return nil, coralerrors.ToConnect(fmt.Errorf("failed to resolve sdk_addr: %w", err))
 	}
 
 	// Build config map for eBPF manager
@@ -69,6 +87,11 @@ func (s *DebugService) StartUprobeCollector(
 		"sdk_addr":      sdkAddr,
 	}
 
+	// Events of colony sessions are pushed to the colony as they are captured.
+	if req.SessionId != "" {
+		config["session_id"] = req.SessionId
+	}
+
 	if req.Config != nil {
 		if req.Config.CaptureArgs {
 			config["capture_args"] = "true"
@@ -109,10 +132,9 @@ func (s *DebugService) StartUprobeCollector(
 	resp, err := s.agent.ebpfManager.StartCollector(ctx, ebpfReq)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to start uprobe collector")
-		return &agentv1.StartUprobeCollectorResponse{
-			Supported: true,
-			Error:     fmt.Sprintf("failed to start collector: %v", err),
-		}, nil
+		return nil, coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_PROBE_ATTACH_FAILED,
+			fmt.Errorf("failed to start collector: %w", err),
+			"service", req.ServiceName, "function", req.FunctionName))
 	}
 
 	return &agentv1.StartUprobeCollectorResponse{
@@ -141,6 +163,15 @@ func (s *DebugService) StopUprobeCollector(
 		}, nil
 	}
 
+	// Push the last events of the session before acknowledging.
+	if s.flushEvents != nil {
+		if err := s.flushEvents(ctx); err != nil {
+			s.logger.Warn().Err(err).
+				Str("collector_id", req.CollectorId).
+				Msg("Failed to push remaining uprobe events to colony")
+		}
+	}
+
 	return &agentv1.StopUprobeCollectorResponse{
 		Success: true,
 	}, nil
@@ -158,10 +189,21 @@ func (s *DebugService) QueryUprobeEvents(
 	// Get events from eBPF manager
 	events, err := s.agent.ebpfManager.GetEvents(req.CollectorId)
 	if err != nil {
+		// The collector is gone (e.g. agent restart); serve persisted events.
+		if errors.Is(err, ebpf.ErrCollectorNotFound) && s.agent.ebpfManager.EventStore() != nil {
+			return s.queryStoredUprobeEvents(ctx, req)
+		}
 		s.logger.Error().Err(err).Msg("Failed to get uprobe events")
 		return nil, fmt.Errorf("failed to get events: %w", err)
 	}
 
+	// The in-memory buffer drops its oldest events when full. If the store
+	// still holds requested events older than the buffer (e.g. the colony is
+	// backfilling after an outage), serve the range from the store instead.
+	if s.needsStoreBackfill(ctx, req, events) {
+		return s.queryStoredUprobeEvents(ctx, req)
+	}
+
 	// Filter events by time range if specified
 	var filteredEvents []*agentv1.UprobeEvent
 	for _, event := range events {
@@ -193,6 +235,74 @@ func (s *DebugService) QueryUprobeEvents(
 	}, nil
 }
 
+// queryStoredUprobeEvents serves a QueryUprobeEvents request from the local
+// event store.
+func (s *DebugService) queryStoredUprobeEvents(
+	ctx context.Context,
+	req *agentv1.QueryUprobeEventsRequest,
+) (*agentv1.QueryUprobeEventsResponse, error) {
+	var start, end time.Time
+	if req.StartTime != nil {
+		start = req.StartTime.AsTime()
+	}
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+
+	events, hasMore, err := s.agent.ebpfManager.EventStore().Query(ctx, req.CollectorId, start, end, int(req.MaxEvents))
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to query stored uprobe events")
+		return nil, fmt.Errorf("failed to query stored events: %w", err)
+	}
+
+	s.logger.Debug().
+		Str("collector_id", req.CollectorId).
+		Int("event_count", len(events)).
+		Msg("Served uprobe events from local event store")
+
+	return &agentv1.QueryUprobeEventsResponse{
+		Events:  events,
+		HasMore: hasMore,
+	}, nil
+}
+
+// needsStoreBackfill reports whether the local event store holds events in
+// the requested range that are older than the in-memory buffer.
+func (s *DebugService) needsStoreBackfill(
+	ctx context.Context,
+	req *agentv1.QueryUprobeEventsRequest,
+	events []*meshv1.EbpfEvent,
+) bool {
+	store := s.agent.ebpfManager.EventStore()
+	if store == nil || req.StartTime == nil {
+		return false
+	}
+
+	oldest := oldestUprobeEventTime(events)
+	if oldest.IsZero() || !oldest.After(req.StartTime.AsTime()) {
+		return false
+	}
+
+	older, _, err := store.Query(ctx, req.CollectorId, req.StartTime.AsTime(), oldest.Add(-time.Nanosecond), 1)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to check local event store for backfill")
+		return false
+	}
+
+	return len(older) > 0
+}
+
+// oldestUprobeEventTime returns the timestamp of the first uprobe event, or
+// the zero time if there is none.
+func oldestUprobeEventTime(events []*meshv1.EbpfEvent) time.Time {
+	for _, event := range events {
+		if uprobeEvent, ok := event.Payload.(*meshv1.EbpfEvent_UprobeEvent); ok {
+			return uprobeEvent.UprobeEvent.Timestamp.AsTime()
+		}
+	}
+	return time.Time{}
+}
+
 // UpdateProbeFilter updates the kernel-level event filter for an active probe session
 // without detaching or interrupting event collection (RFD 090).
 func (s *DebugService) UpdateProbeFilter(
@@ -261,12 +371,35 @@ func (s *DebugService) ProfileCPU(
 		})
 	}
 
-	return &agentv1.ProfileCPUAgentResponse{
+	resp := &agentv1.ProfileCPUAgentResponse{
 		Samples:      samples,
 		TotalSamples: result.TotalSamples,
 		LostSamples:  result.LostSamples,
 		Success:      true,
-	}, nil
+	}
+
+	// Persist the profile for offline debugging when requested by the local CLI.
+	if req.StoreLocally {
+		if s.agent.profileStore == nil {
+			resp.Error = "profile not stored: local storage unavailable"
+			return resp, nil
+		}
+
+		profileID, err := s.agent.profileStore.StoreCPUProfile(ctx, debug.CPUProfileInfo{
+			ServiceName:     req.ServiceName,
+			PID:             req.Pid,
+			DurationSeconds: req.DurationSeconds,
+			FrequencyHz:     req.FrequencyHz,
+		}, result)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to store CPU profile locally")
+			resp.Error = fmt.Sprintf("profile not stored: %v", err)
+			return resp, nil
+		}
+		resp.ProfileId = profileID
+	}
+
+	return resp, nil
 }
 
 // QueryCPUProfileSamples handles requests to query historical CPU profile samples using sequence-based polling.
@@ -537,3 +670,100 @@ func (s *DebugService) ListCorrelations(
 		Descriptors: engine.List(),
 	}, nil
 }
+
+// coreDumpChunkSize is the size of each streamed core dump chunk.
+const coreDumpChunkSize = 256 * 1024
+
+// ListCoreDumps returns core dumps captured from crashed services.
+func (s *DebugService) ListCoreDumps(
+	ctx context.Context,
+	req *agentv1.ListCoreDumpsRequest,
+) (*agentv1.ListCoreDumpsResponse, error) {
+	capturer := s.agent.CoreDumps()
+	if capturer == nil {
+		return &agentv1.ListCoreDumpsResponse{Enabled: false}, nil
+	}
+
+	infos, err := capturer.Store().List(req.ServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("list core dumps: %w", err)
+	}
+
+	dumps := make([]*agentv1.CoreDumpInfo, 0, len(infos))
+	for _, info := range infos {
+		dumps = append(dumps, s.coreDumpInfoToProto(info))
+	}
+
+	return &agentv1.ListCoreDumpsResponse{
+		Dumps:   dumps,
+		Enabled: true,
+		Mode:    capturer.Mode(),
+	}, nil
+}
+
+// DownloadCoreDump streams a stored core dump in gzip-compressed chunks.
+func (s *DebugService) DownloadCoreDump(
+	ctx context.Context,
+	req *agentv1.DownloadCoreDumpRequest,
+	send func(*agentv1.CoreDumpChunk) error,
+) error {
+	capturer := s.agent.CoreDumps()
+	if capturer == nil {
+		return connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("core dump capture is not enabled on this agent"))
+	}
+
+	info, r, err := capturer.Store().Open(req.Id)
+	if err != nil {
+		if errors.Is(err, coredump.ErrNotFound) {
+			return connect.NewError(connect.CodeNotFound,
+				fmt.Errorf("core dump %s not found", req.Id))
+		}
+		return fmt.Errorf("open core dump: %w", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	chunk := &agentv1.CoreDumpChunk{Info: s.coreDumpInfoToProto(info)}
+	buf := make([]byte, coreDumpChunkSize)
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			chunk.Data = buf[:n]
+			if err := send(chunk); err != nil {
+				return err
+			}
+			chunk = &agentv1.CoreDumpChunk{}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("read core dump: %w", readErr)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	// An empty dump still reports its metadata.
+	if chunk.Info != nil {
+		return send(chunk)
+	}
+	return nil
+}
+
+// coreDumpInfoToProto converts stored core dump metadata to its wire format.
+func (s *DebugService) coreDumpInfoToProto(info coredump.Info) *agentv1.CoreDumpInfo {
+	return &agentv1.CoreDumpInfo{
+		Id:              info.ID,
+		ServiceName:     info.ServiceName,
+		Pid:             info.PID,
+		Executable:      info.Executable,
+		Signal:          info.Signal,
+		CrashedAt:       timestamppb.New(info.CrashedAt),
+		SizeBytes:       info.SizeBytes,
+		CompressedBytes: info.CompressedBytes,
+		Truncated:       info.Truncated,
+		AgentId:         s.agent.id,
+	}
+}
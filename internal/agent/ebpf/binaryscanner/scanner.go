@@ -145,12 +145,14 @@ func (s *Scanner) GetFunctionMetadata(ctx context.Context, pid uint32, functionN
 
 	// 5. Convert to our FunctionMetadata type.
 	return &FunctionMetadata{
-		Name:       meta.Name,
-		BinaryPath: binaryPath, // Use original path, not temporary
-		Offset:     meta.Offset,
-		PID:        uint32(meta.PID),
-		SizeBytes:  meta.SizeBytes,
-		HasSize:    meta.HasSize,
+		Name:         meta.Name,
+		BinaryPath:   binaryPath, // Use original path, not temporary
+		Offset:       meta.Offset,
+		PID:          uint32(meta.PID),
+		SizeBytes:    meta.SizeBytes,
+		HasSize:      meta.HasSize,
+		Arguments:    meta.Arguments,
+		ReturnValues: meta.ReturnValues,
 	}, nil
 }
 
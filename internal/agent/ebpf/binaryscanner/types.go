@@ -1,5 +1,7 @@
 package binaryscanner
 
+import "github.com/coral-mesh/coral/pkg/sdk/debug"
+
 // FunctionMetadata contains function information extracted from binary scanning.
 type FunctionMetadata struct {
 	// Name is the function name.
@@ -19,6 +21,10 @@ type FunctionMetadata struct {
 
 	// HasSize indicates whether function size is available.
 	HasSize bool
+
+	// Arguments and ReturnValues describe the function signature (from DWARF).
+	Arguments    []*debug.ArgumentMetadata
+	ReturnValues []*debug.ReturnValueMetadata
 }
 
 // BasicInfo contains minimal function metadata for listing.
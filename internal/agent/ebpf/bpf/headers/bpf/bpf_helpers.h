@@ -10,6 +10,10 @@
  */
 #define SEC(NAME) __attribute__((section(NAME), used))
 
+#ifndef __always_inline
+#define __always_inline inline __attribute__((always_inline))
+#endif
+
 /* Map definition macros */
 #define __uint(name, val) int (*name)[val]
 #define __type(name, val) typeof(val) *name
@@ -18,6 +22,7 @@
 /* BPF map types */
 #define BPF_MAP_TYPE_HASH 1
 #define BPF_MAP_TYPE_ARRAY 2
+#define BPF_MAP_TYPE_PERF_EVENT_ARRAY 4
 #define BPF_MAP_TYPE_PERCPU_ARRAY 6
 #define BPF_MAP_TYPE_STACK_TRACE 7
 #define BPF_MAP_TYPE_RINGBUF 27
@@ -26,6 +31,9 @@
 #define BPF_ANY 0
 #define BPF_NOEXIST 1
 
+/* BPF perf_event_output flags */
+#define BPF_F_CURRENT_CPU 0xffffffffULL
+
 /* BPF get_stackid flags */
 #define BPF_F_USER_STACK (1ULL << 8)
 
@@ -36,9 +44,17 @@ static void *(*bpf_map_lookup_elem)(void *map, const void *key) = (void *) 1;
 static long (*bpf_map_delete_elem)(void *map, const void *key) = (void *) 3;
 static unsigned long long (*bpf_get_current_pid_tgid)(void) = (void *) 14;
 
+/* Memory read helper. Reads user memory too on x86-64 and arm64, and unlike
+ * bpf_probe_read_user (5.5+) is available on every supported kernel. */
+static long (*bpf_probe_read)(void *dst, unsigned int size, const void *unsafe_ptr) = (void *) 4;
+
 /* Ring buffer helpers */
 static void *(*bpf_ringbuf_reserve)(void *ringbuf, unsigned long long size, unsigned long long flags) = (void *) 131;
 static void (*bpf_ringbuf_submit)(void *data, unsigned long long flags) = (void *) 132;
+static long (*bpf_ringbuf_output)(void *ringbuf, void *data, unsigned long long size, unsigned long long flags) = (void *) 130;
+
+/* Perf event array helper (fallback for kernels without ring buffers) */
+static long (*bpf_perf_event_output)(void *ctx, void *map, unsigned long long flags, void *data, unsigned long long size) = (void *) 25;
 
 /* Stack trace helper */
 static long (*bpf_get_stackid)(void *ctx, void *map, unsigned long long flags) = (void *) 27;
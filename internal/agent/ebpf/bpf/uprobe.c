@@ -21,6 +21,8 @@ struct uprobe_event {
     __u32 tid;
     __u8  event_type;  // 0=entry, 1=return
     __u64 duration_ns;
+    __u64 goroutine_id; // runtime.g.goid, 0 if goid_offset is unknown
+    __u64 g_addr;       // address of the runtime.g of the calling goroutine
 };
 
 // filter_config holds runtime-configurable filter criteria (RFD 090).
@@ -45,7 +47,19 @@ struct entry_value {
     __u64 created_at;   // For cleanup of orphaned entries
 };
 
-// Ring buffer for streaming events to userspace
+// use_perfbuf selects the event transport at load time. The loader sets it
+// (and retypes the events map to BPF_MAP_TYPE_PERF_EVENT_ARRAY) on kernels
+// without BPF ring buffer support (< 5.8). The verifier prunes the unused
+// branch because the value lives in frozen .rodata.
+const volatile __u8 use_perfbuf = 0;
+
+// goid_offset is the offset of the goid field in the runtime.g struct of the
+// target binary, set by the loader from its DWARF. 0 = unknown: events then
+// only carry the g address, which still identifies the goroutine.
+const volatile __u32 goid_offset = 0;
+
+// Ring buffer for streaming events to userspace.
+// Retyped to a perf event array by the loader when use_perfbuf is set.
 struct {
     __uint(type, BPF_MAP_TYPE_RINGBUF);
     __uint(max_entries, 256 * 1024);  // 256KB ring buffer
@@ -80,6 +94,42 @@ struct {
     __uint(max_entries, 1);
 } sample_counter SEC(".maps");
 
+// submit_event emits an event through whichever transport the loader selected.
+static __always_inline void submit_event(struct pt_regs *ctx, struct uprobe_event *event) {
+    if (use_perfbuf) {
+        bpf_perf_event_output(ctx, &events, BPF_F_CURRENT_CPU, event, sizeof(*event));
+        return;
+    }
+    bpf_ringbuf_output(&events, event, sizeof(*event), 0);
+}
+
+// current_g returns the runtime.g of the calling goroutine. Since Go 1.17
+// (register ABI), Go code keeps it in a dedicated register, mirrored in TLS:
+// R14 on amd64 and x28 on arm64. Both entry and RET instructions run Go code,
+// so the register holds g at every probe site.
+static __always_inline __u64 current_g(struct pt_regs *ctx) {
+#if defined(__TARGET_ARCH_arm64) || defined(__aarch64__)
+    return ctx->regs[28];
+#else
+    return ctx->r14;
+#endif
+}
+
+// fill_goroutine sets the goroutine identity of an event, so userspace can
+// match entry and return events of one invocation explicitly even when the
+// goroutine migrated to another thread or its stack was moved.
+static __always_inline void fill_goroutine(struct pt_regs *ctx, struct uprobe_event *event) {
+    __u64 g = current_g(ctx);
+    event->g_addr = g;
+
+    if (g && goid_offset) {
+        __u64 goid = 0;
+        if (bpf_probe_read(&goid, sizeof(goid), (void *)(g + goid_offset)) == 0) {
+            event->goroutine_id = goid;
+        }
+    }
+}
+
 // Uprobe handler - called on function entry
 SEC("uprobe/function_entry")
 int uprobe_entry(struct pt_regs *ctx) {
@@ -105,22 +155,19 @@ int uprobe_entry(struct pt_regs *ctx) {
 
     bpf_printk("uprobe_entry: pid=%d tid=%d\n", pid, tid);
 
-    // Reserve space in ring buffer
-    struct uprobe_event *event;
-    event = bpf_ringbuf_reserve(&events, sizeof(*event), 0);
-    if (!event) {
-        return 0;  // Ring buffer full, drop event
-    }
-
-    // Populate event
-    event->timestamp_ns = ts;
-    event->pid = pid;
-    event->tid = tid;
-    event->event_type = 0;  // entry
-    event->duration_ns = 0;
-
-    // Submit event to ring buffer
-    bpf_ringbuf_submit(event, 0);
+    // Populate event. Zero first so padding bytes are initialized, which
+    // the verifier requires for stack memory passed to output helpers.
+    struct uprobe_event event;
+    __builtin_memset(&event, 0, sizeof(event));
+    event.timestamp_ns = ts;
+    event.pid = pid;
+    event.tid = tid;
+    event.event_type = 0;  // entry
+    event.duration_ns = 0;
+    fill_goroutine(ctx, &event);
+
+    // Submit event (dropped by the kernel if the buffer is full)
+    submit_event(ctx, &event);
     return 0;
 }
 
@@ -178,22 +225,19 @@ int uprobe_return(struct pt_regs *ctx) {
 
     bpf_printk("uprobe_return: pid=%d tid=%d duration=%llu\n", pid, tid, duration);
 
-    // Reserve space in ring buffer
-    struct uprobe_event *event;
-    event = bpf_ringbuf_reserve(&events, sizeof(*event), 0);
-    if (!event) {
-        return 0;  // Ring buffer full, drop event
-    }
-
-    // Populate event
-    event->timestamp_ns = ts;
-    event->pid = pid;
-    event->tid = tid;
-    event->event_type = 1;  // return
-    event->duration_ns = duration;
-
-    // Submit event to ring buffer
-    bpf_ringbuf_submit(event, 0);
+    // Populate event. Zero first so padding bytes are initialized, which
+    // the verifier requires for stack memory passed to output helpers.
+    struct uprobe_event event;
+    __builtin_memset(&event, 0, sizeof(event));
+    event.timestamp_ns = ts;
+    event.pid = pid;
+    event.tid = tid;
+    event.event_type = 1;  // return
+    event.duration_ns = duration;
+    fill_goroutine(ctx, &event);
+
+    // Submit event (dropped by the kernel if the buffer is full)
+    submit_event(ctx, &event);
     return 0;
 }
 
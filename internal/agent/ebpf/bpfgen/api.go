@@ -3,7 +3,13 @@
 // This package is Linux-only; do not import it from platform-agnostic code.
 package bpfgen
 
-import ceebpf "github.com/cilium/ebpf"
+import (
+	"fmt"
+
+	ceebpf "github.com/cilium/ebpf"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/bpfsign"
+)
 
 // Objects holds all eBPF programs and maps loaded into the kernel.
 type Objects = uprobeObjects
@@ -16,16 +22,47 @@ type UprobeEntryKey = uprobeEntryKey
 // Includes created_at for orphaned entry cleanup.
 type UprobeEntryValue = uprobeEntryValue
 
+// Compat selects the program variant to load for the running kernel.
+type Compat struct {
+	// PerfBuffer emits events through a perf event array instead of a ring
+	// buffer, for kernels without BPF_MAP_TYPE_RINGBUF (< 5.8).
+	PerfBuffer bool
+
+	// GoidOffset is the offset of goid in the runtime.g struct of the target
+	// binary. 0 leaves goroutine IDs out of events; the g address is still
+	// reported.
+	GoidOffset uint32
+}
+
 // LoadObjects loads the compiled eBPF programs and maps into the kernel.
 // Fixes the uprobe_return program's attach type: the .o declares it as
 // uretprobe (SEC("uretprobe/...")) but RFD 073 attaches it as a regular
 // uprobe to RET instruction offsets.
-func LoadObjects(obj *Objects, opts *ceebpf.CollectionOptions) error {
+//
+// CO-RE relocations are resolved against the kernel BTF, or against
+// opts.Programs.KernelTypes when set (external BTF).
+func LoadObjects(obj *Objects, compat Compat, opts *ceebpf.CollectionOptions) error {
+	if err := bpfsign.Verify(bpfsign.ObjectName("uprobe"), _UprobeBytes); err != nil {
+		return err
+	}
+
 	spec, err := loadUprobe()
 	if err != nil {
 		return err
 	}
 
+	if compat.PerfBuffer {
+		if err := usePerfBuffer(spec); err != nil {
+			return err
+		}
+	}
+
+	if compat.GoidOffset != 0 {
+		if err := spec.RewriteConstants(map[string]interface{}{"goid_offset": compat.GoidOffset}); err != nil {
+			return fmt.Errorf("eBPF object lacks goroutine ID support (regenerate with make generate): %w", err)
+		}
+	}
+
 	// RFD 073 attaches uprobe_return as a regular uprobe to RET instruction offsets,
 	// not via the kernel's uretprobe mechanism. Clear any uretprobe attach type that
 	// cilium/ebpf infers from the ELF section name, so the kernel accepts attachment.
@@ -36,3 +73,23 @@ func LoadObjects(obj *Objects, opts *ceebpf.CollectionOptions) error {
 
 	return spec.LoadAndAssign(obj, opts)
 }
+
+// usePerfBuffer switches the events map to a perf event array and sets the
+// use_perfbuf constant so the programs call bpf_perf_event_output.
+func usePerfBuffer(spec *ceebpf.CollectionSpec) error {
+	events, ok := spec.Maps["events"]
+	if !ok {
+		return fmt.Errorf("events map not found in eBPF object")
+	}
+
+	if err := spec.RewriteConstants(map[string]interface{}{"use_perfbuf": uint8(1)}); err != nil {
+		return fmt.Errorf("eBPF object lacks perf buffer variant (regenerate with make generate): %w", err)
+	}
+
+	// Perf event arrays are sized by the loader to the number of CPUs.
+	events.Type = ceebpf.PerfEventArray
+	events.KeySize = 4
+	events.ValueSize = 4
+	events.MaxEntries = 0
+	return nil
+}
@@ -0,0 +1,152 @@
+// Package bpfsign signs the eBPF objects embedded in the agent and verifies
+// them before they are loaded into the kernel.
+//
+// The release build signs each object with an Ed25519 key (see
+// ./sign) and embeds the signatures in signatures.json. Operators pin the
+// public keys they trust in the agent configuration; with
+// agent.security.require_signed_ebpf, objects without a valid signature are
+// not loaded.
+package bpfsign
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// signaturePrefix is the domain separation prefix of signed objects.
+const signaturePrefix = "coral-ebpf-object/v1"
+
+// ErrUnsigned is returned for objects without a signature.
+var ErrUnsigned = errors.New("eBPF object is not signed")
+
+// ErrInvalidSignature is returned for objects whose signature does not
+// verify with any trusted key.
+var ErrInvalidSignature = errors.New("invalid eBPF object signature")
+
+// Manifest holds the signatures of the embedded eBPF objects.
+type Manifest struct {
+	// Objects maps object file names (e.g. "uprobe_bpfel.o") to their
+	// base64-encoded signatures.
+	Objects map[string]string `json:"objects"`
+}
+
+//go:embed signatures.json
+var manifestJSON []byte
+
+var (
+	policyMu   sync.RWMutex
+	trusted    []ed25519.PublicKey
+	required   bool
+	logger     = zerolog.Nop()
+	signatures = mustParseManifest(manifestJSON)
+)
+
+// Configure sets the public keys objects must be signed with. Objects that
+// fail verification are only refused if requireSigned is set; otherwise a
+// warning is logged and they are loaded. Without keys, objects are not
+// verified. It should be called before any object is loaded.
+func Configure(keys []ed25519.PublicKey, requireSigned bool, log zerolog.Logger) {
+	policyMu.Lock()
+	defer policyMu.Unlock()
+
+	trusted = keys
+	required = requireSigned
+	logger = log.With().Str("component", "ebpf_signing").Logger()
+}
+
+// Verify checks the signature of the embedded object name (see ObjectName)
+// before it is loaded.
+func Verify(name string, object []byte) error {
+	policyMu.RLock()
+	keys, requireSigned, log := trusted, required, logger
+	policyMu.RUnlock()
+
+	if len(keys) == 0 && !requireSigned {
+		return nil
+	}
+
+	err := verify(keys, name, object, signatures.Objects[name])
+	if err == nil {
+		log.Debug().Str("object", name).Msg("Verified eBPF object signature")
+		return nil
+	}
+	if requireSigned {
+		return fmt.Errorf("refusing to load %s: %w", name, err)
+	}
+	log.Warn().Err(err).Str("object", name).Msg("Loading eBPF object that failed signature verification")
+	return nil
+}
+
+// Sign returns the signature of object, embedded as name.
+func Sign(key ed25519.PrivateKey, name string, object []byte) []byte {
+	return ed25519.Sign(key, signedMessage(name, object))
+}
+
+// ObjectName returns the file name of the object bpf2go generated for stem
+// on this architecture's byte order, e.g. "uprobe_bpfel.o".
+func ObjectName(stem string) string {
+	if binary.NativeEndian.Uint16([]byte{0, 1}) == 1 {
+		return stem + "_bpfeb.o"
+	}
+	return stem + "_bpfel.o"
+}
+
+// ParsePublicKeys decodes base64-encoded Ed25519 public keys.
+func ParsePublicKeys(encoded []string) ([]ed25519.PublicKey, error) {
+	keys := make([]ed25519.PublicKey, 0, len(encoded))
+	for _, s := range encoded {
+		key, err := base64.StdEncoding.DecodeString(s)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid eBPF signing public key %q: must be a base64-encoded Ed25519 key", s)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// verify checks the base64-encoded signature of object against keys.
+func verify(keys []ed25519.PublicKey, name string, object []byte, signature string) error {
+	if signature == "" {
+		return ErrUnsigned
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	message := signedMessage(name, object)
+	for _, key := range keys {
+		if ed25519.Verify(key, message, sig) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// signedMessage binds the object digest to its name, so that a signed
+// object cannot be substituted for another one.
+func signedMessage(name string, object []byte) []byte {
+	digest := sha256.Sum256(object)
+	message := make([]byte, 0, len(signaturePrefix)+len(name)+2+len(digest))
+	message = append(message, signaturePrefix...)
+	message = append(message, 0)
+	message = append(message, name...)
+	message = append(message, 0)
+	return append(message, digest[:]...)
+}
+
+func mustParseManifest(data []byte) Manifest {
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		panic(fmt.Sprintf("bpfsign: invalid signatures.json: %v", err))
+	}
+	return m
+}
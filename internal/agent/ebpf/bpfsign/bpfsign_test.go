@@ -0,0 +1,107 @@
+package bpfsign
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return pub, priv
+}
+
+// withManifest replaces the embedded signatures for the duration of the test.
+func withManifest(t *testing.T, objects map[string]string) {
+	t.Helper()
+	saved := signatures
+	signatures = Manifest{Objects: objects}
+	t.Cleanup(func() {
+		signatures = saved
+		Configure(nil, false, zerolog.Nop())
+	})
+}
+
+func TestVerify(t *testing.T) {
+	pub, priv := generateKey(t)
+	otherPub, _ := generateKey(t)
+	object := []byte("\x7fELF eBPF object")
+	signature := base64.StdEncoding.EncodeToString(Sign(priv, "uprobe_bpfel.o", object))
+
+	tests := []struct {
+		name    string
+		keys    []ed25519.PublicKey
+		object  []byte
+		objName string
+		wantErr error
+	}{
+		{name: "valid", keys: []ed25519.PublicKey{pub}, object: object, objName: "uprobe_bpfel.o"},
+		{name: "any trusted key", keys: []ed25519.PublicKey{otherPub, pub}, object: object, objName: "uprobe_bpfel.o"},
+		{name: "untrusted key", keys: []ed25519.PublicKey{otherPub}, object: object, objName: "uprobe_bpfel.o", wantErr: ErrInvalidSignature},
+		{name: "modified object", keys: []ed25519.PublicKey{pub}, object: []byte("\x7fELF patched"), objName: "uprobe_bpfel.o", wantErr: ErrInvalidSignature},
+		{name: "substituted object", keys: []ed25519.PublicKey{pub}, object: object, objName: "cpu_profile_bpfel.o", wantErr: ErrInvalidSignature},
+		{name: "unsigned", keys: []ed25519.PublicKey{pub}, object: object, objName: "uprobe_monitor_bpfel.o", wantErr: ErrUnsigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withManifest(t, map[string]string{
+				"uprobe_bpfel.o":      signature,
+				"cpu_profile_bpfel.o": signature,
+			})
+			Configure(tt.keys, true, zerolog.Nop())
+
+			err := Verify(tt.objName, tt.object)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestVerify_Policy(t *testing.T) {
+	pub, _ := generateKey(t)
+	object := []byte("\x7fELF eBPF object")
+
+	t.Run("no keys", func(t *testing.T) {
+		withManifest(t, nil)
+		Configure(nil, false, zerolog.Nop())
+		assert.NoError(t, Verify("uprobe_bpfel.o", object))
+	})
+
+	t.Run("not required", func(t *testing.T) {
+		withManifest(t, nil)
+		Configure([]ed25519.PublicKey{pub}, false, zerolog.Nop())
+		assert.NoError(t, Verify("uprobe_bpfel.o", object))
+	})
+
+	t.Run("required", func(t *testing.T) {
+		withManifest(t, nil)
+		Configure([]ed25519.PublicKey{pub}, true, zerolog.Nop())
+		assert.ErrorIs(t, Verify("uprobe_bpfel.o", object), ErrUnsigned)
+	})
+}
+
+func TestParsePublicKeys(t *testing.T) {
+	pub, _ := generateKey(t)
+
+	keys, err := ParsePublicKeys([]string{base64.StdEncoding.EncodeToString(pub)})
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, pub.Equal(keys[0]))
+
+	_, err = ParsePublicKeys([]string{"not-base64"})
+	assert.Error(t, err)
+
+	_, err = ParsePublicKeys([]string{base64.StdEncoding.EncodeToString(pub[:16])})
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+// Command sign signs the eBPF objects embedded in the agent and writes their
+// signatures to the bpfsign manifest. It runs in release builds after the
+// objects are generated:
+//
+//	go run ./internal/agent/ebpf/bpfsign/sign -key signing.key internal/agent/debug/*_bpf*.o ...
+//
+// The private key is the base64-encoded Ed25519 seed, read from -key or
+// CORAL_EBPF_SIGNING_KEY. -generate-key writes a new one and prints its
+// public key, which agents trust with agent.security.ebpf_signing_keys.
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/bpfsign"
+)
+
+func main() {
+	var (
+		keyFile     = flag.String("key", "", "File holding the base64-encoded Ed25519 seed (default: CORAL_EBPF_SIGNING_KEY)")
+		out         = flag.String("out", "internal/agent/ebpf/bpfsign/signatures.json", "Signature manifest to write")
+		generateKey = flag.String("generate-key", "", "Write a new signing key to this file and print its public key")
+	)
+	flag.Parse()
+
+	if err := run(*keyFile, *out, *generateKey, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "sign: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(keyFile, out, generateKey string, objects []string) error {
+	if generateKey != "" {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(generateKey, []byte(base64.StdEncoding.EncodeToString(key.Seed())+"\n"), 0600); err != nil {
+			return err
+		}
+		fmt.Println(base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)))
+		return nil
+	}
+
+	encoded := os.Getenv("CORAL_EBPF_SIGNING_KEY")
+	if keyFile != "" {
+		//nolint:gosec // G304: Key file is given by the release build.
+		data, err := os.ReadFile(keyFile)
+		if err != nil {
+			return err
+		}
+		encoded = string(data)
+	}
+	seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return fmt.Errorf("signing key must be a base64-encoded %d-byte Ed25519 seed", ed25519.SeedSize)
+	}
+	key := ed25519.NewKeyFromSeed(seed)
+
+	if len(objects) == 0 {
+		return fmt.Errorf("no eBPF objects to sign")
+	}
+	manifest := bpfsign.Manifest{Objects: make(map[string]string, len(objects))}
+	for _, path := range objects {
+		//nolint:gosec // G304: Objects are given by the release build.
+		object, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name := filepath.Base(path)
+		manifest.Objects[name] = base64.StdEncoding.EncodeToString(bpfsign.Sign(key, name, object))
+		fmt.Printf("signed %s\n", name)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, append(data, '\n'), 0600)
+}
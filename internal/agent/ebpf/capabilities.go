@@ -9,8 +9,9 @@ import (
 	"github.com/coral-mesh/coral/internal/sys/sysfs"
 )
 
-// detectCapabilities detects available eBPF capabilities on this system.
-func detectCapabilities() *agentv1.EbpfCapabilities {
+// detectCapabilities detects available eBPF capabilities on this system,
+// using the given kernel feature probe result.
+func detectCapabilities(feats *KernelFeatures) *agentv1.EbpfCapabilities {
 	// Check if running on Linux (eBPF only works on Linux).
 	if runtime.GOOS != "linux" {
 		return &agentv1.EbpfCapabilities{
@@ -19,6 +20,7 @@ func detectCapabilities() *agentv1.EbpfCapabilities {
 			BtfAvailable:        false,
 			CapBpf:              false,
 			AvailableCollectors: []agentv1.EbpfCollectorKind{},
+			KernelFeatures:      feats.ToProto(),
 		}
 	}
 
@@ -32,18 +34,27 @@ func detectCapabilities() *agentv1.EbpfCapabilities {
 	capBPF := checkCapBPF()
 
 	// Determine supported collectors based on capabilities.
-	// For minimal implementation, only support syscall stats.
+	// Uprobes need an event transport (ring buffer or perf buffer fallback);
+	// HTTP capture only needs socket filters, available on every kernel; TLS
+	// capture emits through perf buffers.
 	collectors := []agentv1.EbpfCollectorKind{
 		agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_SYSCALL_STATS,
-		agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_UPROBE,
+		agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_HTTP_CAPTURE,
+	}
+	if feats.Supported() {
+		collectors = append(collectors, agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_UPROBE)
+	}
+	if feats.PerfBuf {
+		collectors = append(collectors, agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_TLS_CAPTURE)
 	}
 
 	return &agentv1.EbpfCapabilities{
-		Supported:           true,
+		Supported:           feats.Supported(),
 		KernelVersion:       kernelVersion,
 		BtfAvailable:        btfAvailable,
 		CapBpf:              capBPF,
 		AvailableCollectors: collectors,
+		KernelFeatures:      feats.ToProto(),
 	}
 }
 
@@ -1,11 +1,14 @@
 package ebpf
 
 import (
+	"os"
+
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 )
 
 // DetectCapabilities detects eBPF capabilities without creating a manager.
 // This is useful for reporting capabilities before the manager is created.
+// An external BTF file for CO-RE can be provided via CORAL_BTF_PATH.
 func DetectCapabilities() *agentv1.EbpfCapabilities {
-	return detectCapabilities()
+	return detectCapabilities(ProbeKernelFeatures(os.Getenv(EnvBTFPath)))
 }
@@ -161,6 +161,21 @@ func (s *DiscoveryService) DiscoverFunction(
 				SizeBytes:  scannerMeta.SizeBytes,
 				HasSize:    scannerMeta.HasSize,
 			}
+			for _, arg := range scannerMeta.Arguments {
+				metadata.Arguments = append(metadata.Arguments, &ArgumentMetadata{
+					Name:     arg.Name,
+					Type:     arg.Type,
+					Offset:   arg.Offset,
+					Location: arg.Location,
+				})
+			}
+			for _, ret := range scannerMeta.ReturnValues {
+				metadata.ReturnValues = append(metadata.ReturnValues, &ReturnValueMetadata{
+					Name:   ret.Name,
+					Type:   ret.Type,
+					Offset: ret.Offset,
+				})
+			}
 
 			return &DiscoveryResult{
 				Method:   DiscoveryMethodBinary,
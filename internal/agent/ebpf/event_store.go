@@ -0,0 +1,364 @@
+package ebpf
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// ORM model for the local uprobe events table.
+
+type uprobeEventDB struct {
+	CollectorID  string    `duckdb:"collector_id"`
+	Timestamp    time.Time `duckdb:"timestamp"`
+	ServiceName  string    `duckdb:"service_name"`
+	FunctionName string    `duckdb:"function_name"`
+	EventType    string    `duckdb:"event_type"`
+	DurationNs   uint64    `duckdb:"duration_ns"`
+	Pid          int32     `duckdb:"pid"`
+	Tid          int32     `duckdb:"tid"`
+	GoroutineID  uint64    `duckdb:"goroutine_id"`
+	HTTP         string    `duckdb:"http"` // JSON-encoded HttpExchange of "http" events.
+	TLS          string    `duckdb:"tls"`  // JSON-encoded TlsData of "tls" events.
+	CreatedAt    time.Time `duckdb:"created_at,immutable"`
+}
+
+// EventStoreConfig contains configuration for the local event store.
+type EventStoreConfig struct {
+	// MaxEvents bounds the number of stored events. Once exceeded, the
+	// oldest events are dropped (ring semantics).
+	MaxEvents int
+
+	// FlushInterval controls how often buffered events are written to disk.
+	FlushInterval time.Duration
+}
+
+// EventStore persists uprobe events in the agent's local DuckDB as a
+// size-bounded ring. Events survive agent restarts and colony outages and are
+// served from disk when the colony backfills after reconnecting.
+type EventStore struct {
+	db          *sql.DB
+	logger      zerolog.Logger
+	config      EventStoreConfig
+	eventsTable *duckdb.Table[uprobeEventDB]
+
+	mu      sync.Mutex
+	pending []*uprobeEventDB
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewEventStore creates a new event store backed by db.
+func NewEventStore(db *sql.DB, config EventStoreConfig, logger zerolog.Logger) (*EventStore, error) {
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+
+	s := &EventStore{
+		db:          db,
+		logger:      logger.With().Str("component", "uprobe_event_store").Logger(),
+		config:      config,
+		eventsTable: duckdb.NewTable[uprobeEventDB](db, "uprobe_events_local"),
+	}
+
+	if err := s.initSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+	}
+
+	return s, nil
+}
+
+// eventStoreMigrations are the uprobe_events_local schema changes applied
+// after initSchema.
+var eventStoreMigrations = []duckdb.Migration{
+	{
+		Version: 1,
+		Name:    "add_uprobe_events_goroutine_id",
+		SQL:     `ALTER TABLE uprobe_events_local ADD COLUMN goroutine_id UBIGINT DEFAULT 0;`,
+	},
+	{
+		Version: 2,
+		Name:    "add_uprobe_events_http",
+		SQL:     `ALTER TABLE uprobe_events_local ADD COLUMN http VARCHAR;`,
+	},
+	{
+		Version: 3,
+		Name:    "add_uprobe_events_tls",
+		SQL:     `ALTER TABLE uprobe_events_local ADD COLUMN tls VARCHAR;`,
+	},
+}
+
+// initSchema creates the local uprobe events table.
+func (s *EventStore) initSchema() error {
+	schema := `
+		CREATE SEQUENCE IF NOT EXISTS seq_uprobe_events START 1;
+
+		CREATE TABLE IF NOT EXISTS uprobe_events_local (
+			seq_id           UBIGINT DEFAULT nextval('seq_uprobe_events'),
+			collector_id     VARCHAR NOT NULL,
+			timestamp        TIMESTAMP NOT NULL,
+			service_name     VARCHAR,
+			function_name    VARCHAR,
+			event_type       VARCHAR(10),
+			duration_ns      UBIGINT,
+			pid              INTEGER,
+			tid              INTEGER,
+			created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);
+
+		-- Index for per-collector time-range queries.
+		CREATE INDEX IF NOT EXISTS idx_uprobe_events_collector_time
+		ON uprobe_events_local(collector_id, timestamp);
+
+		-- Index for ring trimming.
+		CREATE INDEX IF NOT EXISTS idx_uprobe_events_seq_id
+		ON uprobe_events_local(seq_id);
+	`
+
+	if _, err := s.db.Exec(schema); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	if err := duckdb.ApplyMigrations(context.Background(), s.db, "uprobe_events", eventStoreMigrations, s.logger); err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
+	// Force WAL checkpoint so remote HTTP clients can see the schema.
+	if _, err := s.db.Exec("CHECKPOINT"); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to checkpoint database")
+	}
+
+	s.logger.Info().Msg("Uprobe event store schema initialized")
+
+	return nil
+}
+
+// PendingCount returns the number of buffered events not yet written to disk.
+func (s *EventStore) PendingCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.pending)
+}
+
+// Append buffers events for the given collector. Buffered events are written
+// to disk by the flush loop, so Append never blocks on I/O.
+func (s *EventStore) Append(collectorID string, events ...*agentv1.UprobeEvent) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		var httpJSON, tlsJSON string
+		if event.Http != nil {
+			if data, err := protojson.Marshal(event.Http); err == nil {
+				httpJSON = string(data)
+			}
+		}
+		if event.Tls != nil {
+			if data, err := protojson.Marshal(event.Tls); err == nil {
+				tlsJSON = string(data)
+			}
+		}
+
+		s.pending = append(s.pending, &uprobeEventDB{
+			CollectorID:  collectorID,
+			Timestamp:    event.Timestamp.AsTime(),
+			ServiceName:  event.ServiceName,
+			FunctionName: event.FunctionName,
+			EventType:    event.EventType,
+			DurationNs:   event.DurationNs,
+			Pid:          event.Pid,
+			Tid:          event.Tid,
+			GoroutineID:  event.GoroutineId,
+			HTTP:         httpJSON,
+			TLS:          tlsJSON,
+			CreatedAt:    now,
+		})
+	}
+
+	// Never hold more than the ring can keep.
+	if s.config.MaxEvents > 0 && len(s.pending) > s.config.MaxEvents {
+		s.pending = s.pending[len(s.pending)-s.config.MaxEvents:]
+	}
+}
+
+// Flush writes buffered events to disk and trims the ring to MaxEvents.
+func (s *EventStore) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	if err := s.eventsTable.BatchUpsert(ctx, pending); err != nil {
+		return fmt.Errorf("failed to store uprobe events: %w", err)
+	}
+
+	if s.config.MaxEvents > 0 {
+		result, err := s.db.ExecContext(ctx, `
+			DELETE FROM uprobe_events_local
+			WHERE seq_id <= (SELECT max(seq_id) FROM uprobe_events_local) - ?
+		`, s.config.MaxEvents)
+		if err != nil {
+			return fmt.Errorf("failed to trim uprobe events: %w", err)
+		}
+
+		if dropped, _ := result.RowsAffected(); dropped > 0 {
+			s.logger.Debug().
+				Int64("rows_deleted", dropped).
+				Int("max_events", s.config.MaxEvents).
+				Msg("Dropped oldest uprobe events from local store")
+		}
+	}
+
+	return nil
+}
+
+// Query returns stored events for a collector within [start, end], ordered by
+// timestamp. Zero start or end leaves that side of the range open. hasMore is
+// true when more events match than limit allowed.
+func (s *EventStore) Query(
+	ctx context.Context,
+	collectorID string,
+	start, end time.Time,
+	limit int,
+) (events []*agentv1.UprobeEvent, hasMore bool, err error) {
+	query := `
+		SELECT timestamp, service_name, function_name, event_type, duration_ns, pid, tid,
+		       COALESCE(goroutine_id, 0), COALESCE(http, ''), COALESCE(tls, '')
+		FROM uprobe_events_local
+		WHERE collector_id = ?
+	`
+	args := []interface{}{collectorID}
+
+	if !start.IsZero() {
+		query += " AND timestamp >= ?"
+		args = append(args, start)
+	}
+	if !end.IsZero() {
+		query += " AND timestamp <= ?"
+		args = append(args, end)
+	}
+
+	query += " ORDER BY timestamp ASC, seq_id ASC"
+	if limit > 0 {
+		// Fetch one extra row to detect whether more events remain.
+		query += " LIMIT ?"
+		args = append(args, limit+1)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query uprobe events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var (
+			ts       time.Time
+			httpJSON string
+			tlsJSON  string
+			event    = &agentv1.UprobeEvent{CollectorId: collectorID}
+		)
+
+		if err := rows.Scan(
+			&ts,
+			&event.ServiceName,
+			&event.FunctionName,
+			&event.EventType,
+			&event.DurationNs,
+			&event.Pid,
+			&event.Tid,
+			&event.GoroutineId,
+			&httpJSON,
+			&tlsJSON,
+		); err != nil {
+			return nil, false, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if httpJSON != "" {
+			event.Http = &agentv1.HttpExchange{}
+			if err := protojson.Unmarshal([]byte(httpJSON), event.Http); err != nil {
+				return nil, false, fmt.Errorf("failed to decode http exchange: %w", err)
+			}
+		}
+		if tlsJSON != "" {
+			event.Tls = &agentv1.TlsData{}
+			if err := protojson.Unmarshal([]byte(tlsJSON), event.Tls); err != nil {
+				return nil, false, fmt.Errorf("failed to decode tls data: %w", err)
+			}
+		}
+
+		event.Timestamp = timestamppb.New(ts)
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	if limit > 0 && len(events) > limit {
+		return events[:limit], true, nil
+	}
+
+	return events, false, nil
+}
+
+// Start begins the background flush loop.
+func (s *EventStore) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.runFlushLoop(ctx)
+
+	s.logger.Info().
+		Int("max_events", s.config.MaxEvents).
+		Dur("flush_interval", s.config.FlushInterval).
+		Msg("Started uprobe event store")
+
+	return nil
+}
+
+// Stop stops the flush loop and writes any remaining buffered events.
+func (s *EventStore) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+		<-s.done
+	}
+
+	return s.Flush(context.Background())
+}
+
+// runFlushLoop periodically writes buffered events to disk.
+func (s *EventStore) runFlushLoop(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Flush(ctx); err != nil {
+				s.logger.Error().Err(err).Msg("Failed to flush uprobe events")
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package ebpf
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/marcboeker/go-duckdb"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+)
+
+func newTestEventStore(t *testing.T, maxEvents int) *EventStore {
+	t.Helper()
+
+	// Use in-memory DuckDB for testing.
+	db, err := sql.Open("duckdb", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	store, err := NewEventStore(db, EventStoreConfig{MaxEvents: maxEvents}, zerolog.Nop())
+	require.NoError(t, err)
+
+	return store
+}
+
+func testUprobeEvent(ts time.Time, durationNs uint64) *agentv1.UprobeEvent {
+	return &agentv1.UprobeEvent{
+		Timestamp:    timestamppb.New(ts),
+		ServiceName:  "api",
+		FunctionName: "main.handle",
+		EventType:    "return",
+		DurationNs:   durationNs,
+		Pid:          42,
+		Tid:          43,
+	}
+}
+
+func TestEventStore_AppendFlushQuery(t *testing.T) {
+	store := newTestEventStore(t, 0)
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Microsecond)
+
+	store.Append("c1",
+		testUprobeEvent(base, 100),
+		testUprobeEvent(base.Add(time.Second), 200),
+		testUprobeEvent(base.Add(2*time.Second), 300),
+	)
+	store.Append("c2", testUprobeEvent(base, 999))
+
+	// Nothing is visible before flushing.
+	events, _, err := store.Query(ctx, "c1", time.Time{}, time.Time{}, 0)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	require.NoError(t, store.Flush(ctx))
+
+	events, hasMore, err := store.Query(ctx, "c1", time.Time{}, time.Time{}, 0)
+	require.NoError(t, err)
+	assert.False(t, hasMore)
+	require.Len(t, events, 3)
+	assert.Equal(t, "c1", events[0].CollectorId)
+	assert.Equal(t, "main.handle", events[0].FunctionName)
+	assert.Equal(t, uint64(100), events[0].DurationNs)
+	assert.Equal(t, int32(42), events[0].Pid)
+	assert.True(t, base.Equal(events[0].Timestamp.AsTime()))
+
+	// Time range and limit.
+	events, hasMore, err = store.Query(ctx, "c1", base.Add(time.Second), time.Time{}, 1)
+	require.NoError(t, err)
+	assert.True(t, hasMore)
+	require.Len(t, events, 1)
+	assert.Equal(t, uint64(200), events[0].DurationNs)
+}
+
+func TestEventStore_RingBound(t *testing.T) {
+	store := newTestEventStore(t, 3)
+	ctx := context.Background()
+	base := time.Now().UTC().Truncate(time.Microsecond)
+
+	for i := 0; i < 5; i++ {
+		store.Append("c1", testUprobeEvent(base.Add(time.Duration(i)*time.Second), uint64(i)))
+		require.NoError(t, store.Flush(ctx))
+	}
+
+	events, _, err := store.Query(ctx, "c1", time.Time{}, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, uint64(2), events[0].DurationNs, "oldest events should be dropped first")
+	assert.Equal(t, uint64(4), events[2].DurationNs)
+}
+
+func TestEventStore_StopFlushesPending(t *testing.T) {
+	store := newTestEventStore(t, 0)
+	ctx := context.Background()
+
+	require.NoError(t, store.Start())
+	store.Append("c1", testUprobeEvent(time.Now(), 1))
+	require.NoError(t, store.Stop())
+
+	events, _, err := store.Query(ctx, "c1", time.Time{}, time.Time{}, 0)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestEventStore_HTTPExchange(t *testing.T) {
+	store := newTestEventStore(t, 0)
+	ctx := context.Background()
+
+	event := testUprobeEvent(time.Now(), 5000)
+	event.EventType = "http"
+	event.FunctionName = "http:/api/checkout"
+	event.Http = &agentv1.HttpExchange{
+		Method:         "POST",
+		Path:           "/api/checkout",
+		StatusCode:     201,
+		RequestHeaders: []*agentv1.HttpHeader{{Name: "Content-Type", Value: "application/json"}},
+		RequestBody:    `{"amount":42}`,
+	}
+	store.Append("c1", event, testUprobeEvent(time.Now(), 1))
+	require.NoError(t, store.Flush(ctx))
+
+	events, _, err := store.Query(ctx, "c1", time.Time{}, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.NotNil(t, events[0].Http)
+	assert.Equal(t, "/api/checkout", events[0].Http.Path)
+	assert.Equal(t, int32(201), events[0].Http.StatusCode)
+	assert.Equal(t, "application/json", events[0].Http.RequestHeaders[0].Value)
+	assert.Equal(t, `{"amount":42}`, events[0].Http.RequestBody)
+	assert.Nil(t, events[1].Http)
+}
+
+func TestEventStore_TLSData(t *testing.T) {
+	store := newTestEventStore(t, 0)
+	ctx := context.Background()
+
+	event := testUprobeEvent(time.Now(), 0)
+	event.EventType = "tls"
+	event.FunctionName = "tls:go"
+	event.Tls = &agentv1.TlsData{
+		Library:      "go",
+		Direction:    "write",
+		ConnectionId: 0xc000123456,
+		Data:         []byte("GET / HTTP/1.1\r\n\x00\xff"),
+		Length:       4096,
+		Truncated:    true,
+	}
+	store.Append("c1", event)
+	require.NoError(t, store.Flush(ctx))
+
+	events, _, err := store.Query(ctx, "c1", time.Time{}, time.Time{}, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.NotNil(t, events[0].Tls)
+	assert.Equal(t, "write", events[0].Tls.Direction)
+	assert.Equal(t, uint64(0xc000123456), events[0].Tls.ConnectionId)
+	assert.Equal(t, []byte("GET / HTTP/1.1\r\n\x00\xff"), events[0].Tls.Data)
+	assert.True(t, events[0].Tls.Truncated)
+}
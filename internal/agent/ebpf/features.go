@@ -0,0 +1,107 @@
+package ebpf
+
+import (
+	"fmt"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+)
+
+// EventTransport identifies the kernel-to-userspace channel used by eBPF programs.
+type EventTransport string
+
+const (
+	// EventTransportRingBuf uses BPF_MAP_TYPE_RINGBUF (kernel 5.8+).
+	EventTransportRingBuf EventTransport = "ringbuf"
+
+	// EventTransportPerfBuf uses BPF_MAP_TYPE_PERF_EVENT_ARRAY (older kernels).
+	EventTransportPerfBuf EventTransport = "perfbuf"
+
+	// EventTransportNone means no supported transport is available.
+	EventTransportNone EventTransport = "none"
+)
+
+// BTF sources used for CO-RE relocations.
+const (
+	BTFSourceKernel   = "kernel"   // /sys/kernel/btf/vmlinux.
+	BTFSourceExternal = "external" // User-provided BTF file (e.g. from BTFHub).
+	BTFSourceNone     = "none"     // No BTF, CO-RE relocations cannot be resolved.
+)
+
+// EnvBTFPath is the environment variable pointing to an external vmlinux BTF
+// file, used for CO-RE relocations on kernels built without CONFIG_DEBUG_INFO_BTF.
+const EnvBTFPath = "CORAL_BTF_PATH"
+
+// KernelFeatures holds the result of the kernel feature probe run at startup.
+type KernelFeatures struct {
+	// KernelVersion is the running kernel release.
+	KernelVersion string
+
+	// RingBuf reports BPF ring buffer map support (kernel 5.8+).
+	RingBuf bool
+
+	// PerfBuf reports perf event array map support.
+	PerfBuf bool
+
+	// BPFLoop reports bpf_loop() helper availability (kernel 5.17+).
+	BPFLoop bool
+
+	// BTFSource is where BTF for CO-RE relocations comes from.
+	BTFSource string
+
+	// BTFPath is the external BTF file, set when BTFSource is external.
+	BTFPath string
+}
+
+// EventTransport returns the transport eBPF programs should use, preferring
+// the ring buffer and falling back to the perf buffer on older kernels.
+func (f *KernelFeatures) EventTransport() EventTransport {
+	switch {
+	case f.RingBuf:
+		return EventTransportRingBuf
+	case f.PerfBuf:
+		return EventTransportPerfBuf
+	default:
+		return EventTransportNone
+	}
+}
+
+// Supported reports whether eBPF programs can be loaded on this kernel.
+func (f *KernelFeatures) Supported() bool {
+	return f.EventTransport() != EventTransportNone
+}
+
+// Warnings returns actionable messages describing missing kernel features.
+func (f *KernelFeatures) Warnings() []string {
+	var warnings []string
+
+	switch f.EventTransport() {
+	case EventTransportNone:
+		warnings = append(warnings, fmt.Sprintf(
+			"kernel %s supports neither BPF ring buffers nor perf event arrays; "+
+				"upgrade to Linux 4.15+ (5.8+ recommended) and ensure the agent runs with CAP_BPF or CAP_SYS_ADMIN",
+			f.KernelVersion))
+	case EventTransportPerfBuf:
+		warnings = append(warnings,
+			"BPF ring buffer unavailable (requires Linux 5.8+); using perf buffer fallback with higher per-event overhead")
+	}
+
+	if f.BTFSource == BTFSourceNone {
+		warnings = append(warnings, fmt.Sprintf(
+			"kernel BTF not found at /sys/kernel/btf/vmlinux; rebuild the kernel with CONFIG_DEBUG_INFO_BTF=y "+
+				"or set %s to a vmlinux BTF file (e.g. from BTFHub)", EnvBTFPath))
+	}
+
+	return warnings
+}
+
+// ToProto converts the probe result to its protobuf representation.
+func (f *KernelFeatures) ToProto() *agentv1.EbpfKernelFeatures {
+	return &agentv1.EbpfKernelFeatures{
+		Ringbuf:        f.RingBuf,
+		Perfbuf:        f.PerfBuf,
+		BpfLoop:        f.BPFLoop,
+		BtfSource:      f.BTFSource,
+		EventTransport: string(f.EventTransport()),
+		Warnings:       f.Warnings(),
+	}
+}
@@ -0,0 +1,57 @@
+//go:build linux
+
+package ebpf
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	ciliumebpf "github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/btf"
+	"github.com/cilium/ebpf/features"
+)
+
+// ProbeKernelFeatures probes the running kernel for the eBPF features the
+// agent's programs depend on. btfPath is an optional external vmlinux BTF file
+// used for CO-RE relocations when the kernel does not expose its own BTF.
+func ProbeKernelFeatures(btfPath string) *KernelFeatures {
+	f := &KernelFeatures{
+		KernelVersion: getKernelVersion(),
+		RingBuf:       features.HaveMapType(ciliumebpf.RingBuf) == nil,
+		PerfBuf:       features.HaveMapType(ciliumebpf.PerfEventArray) == nil,
+		BPFLoop:       features.HaveProgramHelper(ciliumebpf.Kprobe, asm.FnLoop) == nil,
+		BTFSource:     BTFSourceNone,
+	}
+
+	switch {
+	case checkBTF():
+		f.BTFSource = BTFSourceKernel
+	case btfPath != "":
+		if _, err := os.Stat(btfPath); err == nil {
+			f.BTFSource = BTFSourceExternal
+			f.BTFPath = btfPath
+		}
+	}
+
+	return f
+}
+
+// LoadKernelTypes returns the BTF spec to use for CO-RE relocations.
+// Returns nil when the kernel BTF should be used (the loader's default), and
+// the external spec when BTFSource is external.
+func (f *KernelFeatures) LoadKernelTypes() (*btf.Spec, error) {
+	if f.BTFSource != BTFSourceExternal {
+		return nil, nil
+	}
+
+	spec, err := btf.LoadSpec(f.BTFPath)
+	if err != nil {
+		return nil, fmt.Errorf("load external BTF %s: %w", f.BTFPath, err)
+	}
+	return spec, nil
+}
+
+// errUnsupportedKernel is returned when no event transport is available.
+var errUnsupportedKernel = errors.New("kernel does not support the eBPF features required by coral")
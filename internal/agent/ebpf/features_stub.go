@@ -0,0 +1,14 @@
+//go:build !linux
+
+package ebpf
+
+import "runtime"
+
+// ProbeKernelFeatures is a stub for non-Linux platforms.
+// eBPF requires Linux, so no features are reported.
+func ProbeKernelFeatures(_ string) *KernelFeatures {
+	return &KernelFeatures{
+		KernelVersion: runtime.GOOS + " (not Linux)",
+		BTFSource:     BTFSourceNone,
+	}
+}
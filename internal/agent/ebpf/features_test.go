@@ -0,0 +1,66 @@
+package ebpf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKernelFeatures_EventTransport(t *testing.T) {
+	tests := []struct {
+		name     string
+		features KernelFeatures
+		want     EventTransport
+	}{
+		{"ringbuf preferred", KernelFeatures{RingBuf: true, PerfBuf: true}, EventTransportRingBuf},
+		{"perfbuf fallback", KernelFeatures{PerfBuf: true}, EventTransportPerfBuf},
+		{"no transport", KernelFeatures{}, EventTransportNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.features.EventTransport())
+			assert.Equal(t, tt.want != EventTransportNone, tt.features.Supported())
+		})
+	}
+}
+
+func TestKernelFeatures_Warnings(t *testing.T) {
+	t.Run("fully supported kernel has no warnings", func(t *testing.T) {
+		f := KernelFeatures{RingBuf: true, PerfBuf: true, BPFLoop: true, BTFSource: BTFSourceKernel}
+		assert.Empty(t, f.Warnings())
+	})
+
+	t.Run("perf buffer fallback is reported", func(t *testing.T) {
+		f := KernelFeatures{PerfBuf: true, BTFSource: BTFSourceKernel}
+		warnings := f.Warnings()
+		assert.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "5.8")
+	})
+
+	t.Run("missing BTF suggests external BTF", func(t *testing.T) {
+		f := KernelFeatures{RingBuf: true, BTFSource: BTFSourceNone}
+		warnings := f.Warnings()
+		assert.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], EnvBTFPath)
+	})
+
+	t.Run("unsupported kernel is actionable", func(t *testing.T) {
+		f := KernelFeatures{KernelVersion: "4.9.0", BTFSource: BTFSourceNone}
+		warnings := f.Warnings()
+		assert.Len(t, warnings, 2)
+		assert.Contains(t, warnings[0], "4.9.0")
+		assert.Contains(t, warnings[0], "upgrade")
+	})
+}
+
+func TestKernelFeatures_ToProto(t *testing.T) {
+	f := KernelFeatures{PerfBuf: true, BTFSource: BTFSourceExternal, BTFPath: "/tmp/vmlinux.btf"}
+	pb := f.ToProto()
+
+	assert.False(t, pb.Ringbuf)
+	assert.True(t, pb.Perfbuf)
+	assert.Equal(t, BTFSourceExternal, pb.BtfSource)
+	assert.Equal(t, string(EventTransportPerfBuf), pb.EventTransport)
+	assert.Len(t, pb.Warnings, 1)
+}
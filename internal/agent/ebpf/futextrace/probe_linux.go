@@ -0,0 +1,407 @@
+//go:build linux
+
+package futextrace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	ciliumebpf "github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/link"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/syscalltrace"
+)
+
+const (
+	// maxPIDs bounds the processes traced when filtering by PID.
+	maxPIDs = 4096
+
+	// maxInflight bounds the threads waiting on a futex tracked between
+	// sys_enter and sys_exit. Threads exiting while waiting never reach
+	// sys_exit, so the least recently used are evicted.
+	maxInflight = 16384
+
+	// maxStacks bounds the distinct stacks captured.
+	maxStacks = 8192
+
+	// maxStats bounds the (process, stack) statistics.
+	maxStats = 16384
+
+	// Futex commands that block, after masking FUTEX_PRIVATE_FLAG and
+	// FUTEX_CLOCK_REALTIME out of the operation.
+	futexWait          = 0
+	futexLockPI        = 6
+	futexWaitBitset    = 9
+	futexWaitRequeuePI = 11
+	futexLockPI2       = 13
+	futexFlags         = 128 | 256
+
+	// bpfFUserStack makes bpf_get_stackid capture the user stack.
+	bpfFUserStack = 1 << 8
+)
+
+// Config selects the processes whose futex waits are traced.
+type Config struct {
+	// CgroupID selects the processes of a cgroup v2, including those
+	// started during the trace. PIDs is used when it is 0.
+	CgroupID uint64
+
+	// PIDs selects processes by PID (thread group ID).
+	PIDs []uint32
+}
+
+// syscalls are the numbers of the futex syscalls. futex blocks for some
+// operations only; futex_wait and futex_waitv always do.
+type syscalls struct {
+	futex, wait, waitv uint32
+}
+
+// opOffset returns the offset in struct pt_regs of the second syscall
+// argument, the futex operation, on goarch.
+func opOffset(goarch string) (int16, error) {
+	switch goarch {
+	case "amd64":
+		// RSI.
+		return 104, nil
+	case "arm64":
+		// X1.
+		return 8, nil
+	default:
+		return 0, fmt.Errorf("lock profiling is not supported on %s", goarch)
+	}
+}
+
+// Probe traces the futex waits of a set of processes.
+type Probe struct {
+	stats  *ciliumebpf.Map
+	stacks *ciliumebpf.Map
+	maps   []*ciliumebpf.Map
+	progs  []*ciliumebpf.Program
+	links  []link.Link
+}
+
+// Attach loads the tracing programs and attaches them to the sys_enter and
+// sys_exit raw tracepoints.
+func Attach(cfg Config) (*Probe, error) {
+	if cfg.CgroupID == 0 && len(cfg.PIDs) == 0 {
+		return nil, fmt.Errorf("a cgroup or PIDs are required")
+	}
+	if len(cfg.PIDs) > maxPIDs {
+		return nil, fmt.Errorf("cannot trace more than %d processes", maxPIDs)
+	}
+	offset, err := opOffset(runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := syscalltrace.Numbers([]string{"futex", "futex_wait", "futex_waitv"})
+	if err != nil {
+		return nil, fmt.Errorf("lock profiling is not supported on %s: %w", runtime.GOARCH, err)
+	}
+
+	p := &Probe{}
+	if err := p.attach(cfg, syscalls{futex: numbers[0], wait: numbers[1], waitv: numbers[2]}, offset); err != nil {
+		_ = p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Probe) attach(cfg Config, nrs syscalls, opOffset int16) error {
+	var (
+		pids *ciliumebpf.Map
+		err  error
+	)
+	if cfg.CgroupID == 0 {
+		pids, err = p.newMap(&ciliumebpf.MapSpec{
+			Name:       "fx_pids",
+			Type:       ciliumebpf.Hash,
+			KeySize:    4,
+			ValueSize:  4,
+			MaxEntries: maxPIDs,
+		})
+		if err != nil {
+			return err
+		}
+		for _, pid := range cfg.PIDs {
+			if err := pids.Put(pid, uint32(1)); err != nil {
+				return fmt.Errorf("select processes: %w", err)
+			}
+		}
+	}
+
+	inflight, err := p.newMap(&ciliumebpf.MapSpec{
+		Name:       "fx_inflight",
+		Type:       ciliumebpf.LRUHash,
+		KeySize:    8,
+		ValueSize:  8,
+		MaxEntries: maxInflight,
+	})
+	if err != nil {
+		return err
+	}
+	p.stacks, err = p.newMap(&ciliumebpf.MapSpec{
+		Name:       "fx_stacks",
+		Type:       ciliumebpf.StackTrace,
+		KeySize:    4,
+		ValueSize:  maxStackDepth * 8,
+		MaxEntries: maxStacks,
+	})
+	if err != nil {
+		return err
+	}
+	p.stats, err = p.newMap(&ciliumebpf.MapSpec{
+		Name:       "fx_stats",
+		Type:       ciliumebpf.PerCPUHash,
+		KeySize:    8,
+		ValueSize:  24,
+		MaxEntries: maxStats,
+	})
+	if err != nil {
+		return err
+	}
+
+	enter, err := p.newProgram("fx_enter", enterProgram(nrs, opOffset, pids, inflight, cfg.CgroupID))
+	if err != nil {
+		return err
+	}
+	exit, err := p.newProgram("fx_exit", exitProgram(inflight, p.stacks, p.stats))
+	if err != nil {
+		return err
+	}
+
+	// Attach sys_exit first, so that no wait is timed from sys_enter without
+	// being counted at its exit.
+	for _, tp := range []struct {
+		name string
+		prog *ciliumebpf.Program
+	}{{"sys_exit", exit}, {"sys_enter", enter}} {
+		l, err := link.AttachRawTracepoint(link.RawTracepointOptions{Name: tp.name, Program: tp.prog})
+		if err != nil {
+			return fmt.Errorf("attach raw tracepoint %s: %w", tp.name, err)
+		}
+		p.links = append(p.links, l)
+	}
+	return nil
+}
+
+func (p *Probe) newMap(spec *ciliumebpf.MapSpec) (*ciliumebpf.Map, error) {
+	m, err := ciliumebpf.NewMap(spec)
+	if err != nil {
+		return nil, fmt.Errorf("create %s map: %w", spec.Name, err)
+	}
+	p.maps = append(p.maps, m)
+	return m, nil
+}
+
+func (p *Probe) newProgram(name string, insns asm.Instructions) (*ciliumebpf.Program, error) {
+	prog, err := ciliumebpf.NewProgram(&ciliumebpf.ProgramSpec{
+		Name:         name,
+		Type:         ciliumebpf.RawTracepoint,
+		Instructions: insns,
+		License:      "GPL",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load %s program: %w", name, err)
+	}
+	p.progs = append(p.progs, prog)
+	return prog, nil
+}
+
+// Collect traces futex waits until ctx is done and returns the report.
+func (p *Probe) Collect(ctx context.Context) (*Report, error) {
+	report := &Report{Start: time.Now()}
+	<-ctx.Done()
+	report.End = time.Now()
+
+	stats := make(map[statKey][]statValue)
+	var (
+		key    statKey
+		values []statValue
+	)
+	iter := p.stats.Iterate()
+	for iter.Next(&key, &values) {
+		stats[key] = values
+		values = nil
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("read wait statistics: %w", err)
+	}
+
+	report.Stacks = aggregate(stats, p.lookupStack)
+	return report, nil
+}
+
+// lookupStack returns the addresses of a captured stack, or nil if it was
+// evicted by a hash collision.
+func (p *Probe) lookupStack(stackID int32) []uint64 {
+	var frames [maxStackDepth]uint64
+	if err := p.stacks.Lookup(uint32(stackID), &frames); err != nil { // #nosec G115 -- stack IDs are not negative
+		return nil
+	}
+	var addrs []uint64
+	for _, addr := range frames {
+		if addr == 0 {
+			break
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// Close detaches the programs and releases their resources.
+func (p *Probe) Close() error {
+	var errs []error
+	for _, l := range p.links {
+		errs = append(errs, l.Close())
+	}
+	for _, prog := range p.progs {
+		errs = append(errs, prog.Close())
+	}
+	for _, m := range p.maps {
+		errs = append(errs, m.Close())
+	}
+	p.links, p.progs, p.maps = nil, nil, nil
+	return errors.Join(errs...)
+}
+
+// enterProgram records when a thread of a selected process starts waiting
+// on a futex: inflight[pid_tgid] = ts. The arguments of sys_enter are the
+// registers of the syscall and its number; the futex operation is read from
+// the registers.
+func enterProgram(nrs syscalls, opOffset int16, pids, inflight *ciliumebpf.Map, cgroupID uint64) asm.Instructions {
+	insns := asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.LoadMem(asm.R9, asm.R6, 8, asm.DWord),
+		asm.JEq.Imm(asm.R9, int32(nrs.wait), "waits"),  // #nosec G115 -- syscall numbers are small
+		asm.JEq.Imm(asm.R9, int32(nrs.waitv), "waits"), // #nosec G115
+		asm.JNE.Imm(asm.R9, int32(nrs.futex), "exit"),  // #nosec G115
+
+		// R1 = futex operation, of int type.
+		asm.LoadMem(asm.R3, asm.R6, 0, asm.DWord),
+		asm.Add.Imm(asm.R3, int32(opOffset)),
+		asm.Mov.Reg(asm.R1, asm.RFP),
+		asm.Add.Imm(asm.R1, -8),
+		asm.Mov.Imm(asm.R2, 8),
+		asm.FnProbeReadKernel.Call(),
+		asm.JNE.Imm(asm.R0, 0, "exit"),
+		asm.LoadMem(asm.R1, asm.RFP, -8, asm.DWord),
+		asm.And.Imm32(asm.R1, ^int32(futexFlags)),
+		asm.JEq.Imm(asm.R1, futexWait, "waits"),
+		asm.JEq.Imm(asm.R1, futexLockPI, "waits"),
+		asm.JEq.Imm(asm.R1, futexWaitBitset, "waits"),
+		asm.JEq.Imm(asm.R1, futexWaitRequeuePI, "waits"),
+		asm.JEq.Imm(asm.R1, futexLockPI2, "waits"),
+		asm.Ja.Label("exit"),
+	}
+
+	if cgroupID != 0 {
+		insns = append(insns,
+			asm.FnGetCurrentCgroupId.Call().WithSymbol("waits"),
+			asm.LoadImm(asm.R1, int64(cgroupID), asm.DWord), // #nosec G115 -- cgroup IDs are inode numbers
+			asm.JNE.Reg(asm.R0, asm.R1, "exit"),
+		)
+	} else {
+		insns = append(insns,
+			asm.FnGetCurrentPidTgid.Call().WithSymbol("waits"),
+			asm.RSh.Imm(asm.R0, 32),
+			asm.StoreMem(asm.RFP, -8, asm.R0, asm.Word),
+			asm.LoadMapPtr(asm.R1, pids.FD()),
+			asm.Mov.Reg(asm.R2, asm.RFP),
+			asm.Add.Imm(asm.R2, -8),
+			asm.FnMapLookupElem.Call(),
+			asm.JEq.Imm(asm.R0, 0, "exit"),
+		)
+	}
+
+	return append(insns,
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.StoreMem(asm.RFP, -16, asm.R0, asm.DWord),
+		asm.FnKtimeGetNs.Call(),
+		asm.StoreMem(asm.RFP, -24, asm.R0, asm.DWord),
+
+		asm.LoadMapPtr(asm.R1, inflight.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -16),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, -24),
+		asm.Mov.Imm(asm.R4, 0), // BPF_ANY
+		asm.FnMapUpdateElem.Call(),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("exit"),
+		asm.Return(),
+	)
+}
+
+// exitProgram adds a wait timed by enterProgram to the statistics of the
+// process and the user stack it waited in. A thread waits in one syscall at
+// a time, so the syscall exiting is the futex wait entered.
+func exitProgram(inflight, stacks, stats *ciliumebpf.Map) asm.Instructions {
+	return asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.StoreMem(asm.RFP, -8, asm.R0, asm.DWord),
+
+		// Look up and forget when the thread started waiting.
+		asm.LoadMapPtr(asm.R1, inflight.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+		asm.LoadMem(asm.R7, asm.R0, 0, asm.DWord),
+		asm.LoadMapPtr(asm.R1, inflight.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapDeleteElem.Call(),
+
+		// R7 = wait time.
+		asm.FnKtimeGetNs.Call(),
+		asm.Sub.Reg(asm.R0, asm.R7),
+		asm.Mov.Reg(asm.R7, asm.R0),
+
+		// Key {tgid, stack ID}, with -1 for stacks that were not captured.
+		asm.Mov.Reg(asm.R1, asm.R6),
+		asm.LoadMapPtr(asm.R2, stacks.FD()),
+		asm.Mov.Imm(asm.R3, bpfFUserStack),
+		asm.FnGetStackid.Call(),
+		asm.JSGE.Imm(asm.R0, 0, "key"),
+		asm.Mov.Imm(asm.R0, -1),
+		asm.StoreMem(asm.RFP, -12, asm.R0, asm.Word).WithSymbol("key"),
+		asm.LoadMem(asm.R1, asm.RFP, -8, asm.DWord),
+		asm.RSh.Imm(asm.R1, 32),
+		asm.StoreMem(asm.RFP, -16, asm.R1, asm.Word),
+
+		asm.LoadMapPtr(asm.R1, stats.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -16),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "new"),
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.DWord),
+		asm.Add.Imm(asm.R1, 1),
+		asm.StoreMem(asm.R0, 0, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R1, asm.R0, 8, asm.DWord),
+		asm.Add.Reg(asm.R1, asm.R7),
+		asm.StoreMem(asm.R0, 8, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R1, asm.R0, 16, asm.DWord),
+		asm.JGE.Reg(asm.R1, asm.R7, "exit"),
+		asm.StoreMem(asm.R0, 16, asm.R7, asm.DWord),
+		asm.Ja.Label("exit"),
+
+		asm.StoreImm(asm.RFP, -40, 1, asm.DWord).WithSymbol("new"),
+		asm.StoreMem(asm.RFP, -32, asm.R7, asm.DWord),
+		asm.StoreMem(asm.RFP, -24, asm.R7, asm.DWord),
+		asm.LoadMapPtr(asm.R1, stats.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -16),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, -40),
+		asm.Mov.Imm(asm.R4, 1), // BPF_NOEXIST
+		asm.FnMapUpdateElem.Call(),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("exit"),
+		asm.Return(),
+	}
+}
@@ -0,0 +1,54 @@
+//go:build linux
+
+package futextrace
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestCollect(t *testing.T) {
+	probe, err := Attach(Config{PIDs: []uint32{uint32(os.Getpid())}}) // #nosec G115
+	if err != nil {
+		t.Skipf("cannot attach futex probes: %v", err)
+	}
+	defer probe.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	// Wait on a futex nobody wakes, timing out after 20ms each time.
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		var word uint32
+		timeout := unix.NsecToTimespec(int64(20 * time.Millisecond))
+		for i := 0; i < 5; i++ {
+			_, _, _ = unix.Syscall6(unix.SYS_FUTEX, uintptr(unsafe.Pointer(&word)),
+				futexWait|128 /* FUTEX_PRIVATE_FLAG */, 0, uintptr(unsafe.Pointer(&timeout)), 0, 0)
+		}
+	}()
+
+	report, err := probe.Collect(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, report.Stacks)
+
+	var waited time.Duration
+	for _, s := range report.Stacks {
+		assert.Equal(t, uint32(os.Getpid()), s.PID) // #nosec G115
+		if s.MaxWait >= 20*time.Millisecond {
+			waited += s.Time
+			assert.NotEmpty(t, s.Addrs, "user stack of the waits")
+		}
+	}
+	assert.GreaterOrEqual(t, waited, 100*time.Millisecond)
+}
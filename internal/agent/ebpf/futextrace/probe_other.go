@@ -0,0 +1,32 @@
+//go:build !linux
+
+package futextrace
+
+import (
+	"context"
+	"fmt"
+)
+
+// Config selects the processes whose futex waits are traced.
+type Config struct {
+	CgroupID uint64
+	PIDs     []uint32
+}
+
+// Probe is a stub for non-Linux platforms.
+type Probe struct{}
+
+// Attach is a stub for non-Linux platforms.
+func Attach(_ Config) (*Probe, error) {
+	return nil, fmt.Errorf("lock profiling requires Linux")
+}
+
+// Collect is a stub for non-Linux platforms.
+func (p *Probe) Collect(_ context.Context) (*Report, error) {
+	return nil, fmt.Errorf("lock profiling requires Linux")
+}
+
+// Close is a stub for non-Linux platforms.
+func (p *Probe) Close() error {
+	return nil
+}
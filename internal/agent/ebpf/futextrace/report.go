@@ -0,0 +1,84 @@
+// Package futextrace profiles lock contention with raw tracepoints on
+// sys_enter and sys_exit, timing the futex waits of a service.
+//
+// Contended pthread mutexes, condition variables and semaphores, like the
+// locks of most language runtimes, block in the futex syscalls, so timing
+// these waits by user stack shows which code paths spend time blocked on
+// locks, whether in C called through cgo or in non-Go services. Waits are aggregated in the
+// kernel by process and stack; stacks are unwound with frame pointers.
+package futextrace
+
+import (
+	"sort"
+	"time"
+)
+
+// maxStackDepth is the number of frames of the stacks captured, the
+// kernel's default PERF_MAX_STACK_DEPTH.
+const maxStackDepth = 127
+
+// statKey is the key of the wait statistics map: a process and the stack it
+// waited in, or -1 if the stack could not be captured.
+type statKey struct {
+	PID     uint32
+	StackID int32
+}
+
+// statValue aggregates the waits of a statKey.
+type statValue struct {
+	Count uint64
+	SumNs uint64
+	MaxNs uint64
+}
+
+// Stack is the time the threads of a process spent waiting on futexes from
+// one user stack.
+type Stack struct {
+	PID uint32
+
+	// Addrs are the return addresses of the stack, innermost first. It is
+	// empty if the stack could not be captured.
+	Addrs []uint64
+
+	Waits   uint64
+	Time    time.Duration
+	MaxWait time.Duration
+}
+
+// Report is the lock contention of the traced processes during a trace.
+type Report struct {
+	Start, End time.Time
+
+	// Target describes the traced processes, e.g. their cgroup.
+	Target string
+
+	// Stacks are sorted by wait time, longest first.
+	Stacks []Stack
+}
+
+// aggregate merges the per-CPU wait statistics of the kernel into stacks,
+// reading their addresses with lookup.
+func aggregate(stats map[statKey][]statValue, lookup func(stackID int32) []uint64) []Stack {
+	stacks := make([]Stack, 0, len(stats))
+	for key, values := range stats {
+		s := Stack{PID: key.PID}
+		if key.StackID >= 0 {
+			s.Addrs = lookup(key.StackID)
+		}
+		for _, v := range values {
+			s.Waits += v.Count
+			s.Time += time.Duration(v.SumNs)                   // #nosec G115 -- sums of wait times fit
+			s.MaxWait = max(s.MaxWait, time.Duration(v.MaxNs)) // #nosec G115
+		}
+		if s.Waits > 0 {
+			stacks = append(stacks, s)
+		}
+	}
+	sort.Slice(stacks, func(i, j int) bool {
+		if stacks[i].Time != stacks[j].Time {
+			return stacks[i].Time > stacks[j].Time
+		}
+		return stacks[i].PID < stacks[j].PID
+	})
+	return stacks
+}
@@ -0,0 +1,34 @@
+package futextrace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	stacks := map[int32][]uint64{
+		1: {0x401000, 0x402000},
+		2: {0x403000},
+	}
+	stats := map[statKey][]statValue{
+		// Waits over two CPUs.
+		{PID: 10, StackID: 1}: {{Count: 2, SumNs: 3_000, MaxNs: 2_000}, {Count: 1, SumNs: 5_000, MaxNs: 5_000}},
+		{PID: 10, StackID: 2}: {{Count: 1, SumNs: 1_000_000, MaxNs: 1_000_000}, {}},
+		// A stack that could not be captured.
+		{PID: 11, StackID: -1}: {{}, {Count: 4, SumNs: 400, MaxNs: 100}},
+		// A stack evicted before it was read.
+		{PID: 11, StackID: 3}: {{Count: 1, SumNs: 200, MaxNs: 200}},
+		{PID: 12, StackID: 2}: {{}, {}},
+	}
+
+	got := aggregate(stats, func(id int32) []uint64 { return stacks[id] })
+	require.Len(t, got, 4, "empty statistics are dropped")
+
+	assert.Equal(t, Stack{PID: 10, Addrs: stacks[2], Waits: 1, Time: time.Millisecond, MaxWait: time.Millisecond}, got[0])
+	assert.Equal(t, Stack{PID: 10, Addrs: stacks[1], Waits: 3, Time: 8 * time.Microsecond, MaxWait: 5 * time.Microsecond}, got[1])
+	assert.Equal(t, Stack{PID: 11, Waits: 4, Time: 400, MaxWait: 100}, got[2])
+	assert.Equal(t, Stack{PID: 11, Waits: 1, Time: 200, MaxWait: 200}, got[3])
+}
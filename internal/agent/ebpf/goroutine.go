@@ -0,0 +1,168 @@
+package ebpf
+
+import (
+	"debug/dwarf"
+	"debug/elf"
+	"fmt"
+)
+
+// invocationMaxAgeNs bounds how long an entry event waits for its return
+// event; older entries are dropped (panics, SIGKILL, filtered returns).
+const invocationMaxAgeNs = 60_000_000_000 // 60 seconds
+
+// goidOffset returns the offset of the goid field in the runtime.g struct of
+// a Go binary, read from its DWARF. The layout of runtime.g changes between
+// Go releases, so it is resolved per binary rather than hardcoded.
+func goidOffset(binaryPath string) (uint32, error) {
+	f, err := elf.Open(binaryPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open binary: %w", err)
+	}
+	defer f.Close() // nolint:errcheck
+
+	data, err := f.DWARF()
+	if err != nil {
+		return 0, fmt.Errorf("no DWARF in binary: %w", err)
+	}
+
+	reader := data.Reader()
+	for {
+		entry, err := reader.Next()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read DWARF: %w", err)
+		}
+		if entry == nil {
+			return 0, fmt.Errorf("runtime.g not found in DWARF")
+		}
+
+		// Types are direct children of compile units.
+		if entry.Tag == dwarf.TagCompileUnit {
+			continue
+		}
+		if entry.Tag != dwarf.TagStructType || entry.Val(dwarf.AttrName) != "runtime.g" {
+			reader.SkipChildren()
+			continue
+		}
+
+		typ, err := data.Type(entry.Offset)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read runtime.g type: %w", err)
+		}
+		st, ok := typ.(*dwarf.StructType)
+		if !ok {
+			return 0, fmt.Errorf("runtime.g is not a struct")
+		}
+		for _, field := range st.Field {
+			if field.Name == "goid" {
+				return uint32(field.ByteOffset), nil // #nosec G115
+			}
+		}
+		return 0, fmt.Errorf("runtime.g has no goid field")
+	}
+}
+
+// goroutineKey identifies a goroutine by the address of its runtime.g, which
+// is stable for the goroutine's lifetime, unlike its thread or stack pointer.
+type goroutineKey struct {
+	pid  uint32
+	gPtr uint64
+}
+
+// goroutineCalls holds the in-flight invocations of one goroutine.
+type goroutineCalls struct {
+	// entries are the entry timestamps of in-flight calls, innermost last.
+	entries []uint64
+	// lastReturn is the timestamp of the latest return event.
+	lastReturn uint64
+}
+
+// invocationTracker matches entry and return events of the same invocation.
+// A goroutine runs one call at a time, so its returns come in reverse order
+// of its entries, even for recursive functions. Timestamps are BPF
+// monotonic clock values. Not safe for concurrent use.
+type invocationTracker struct {
+	goroutines map[goroutineKey]*goroutineCalls
+	latest     uint64
+	lastExpiry uint64
+}
+
+func newInvocationTracker() *invocationTracker {
+	return &invocationTracker{goroutines: make(map[goroutineKey]*goroutineCalls)}
+}
+
+// entry records the entry event of an invocation.
+func (t *invocationTracker) entry(key goroutineKey, ts uint64) {
+	t.observe(ts)
+
+	calls := t.goroutines[key]
+	if calls == nil {
+		calls = &goroutineCalls{}
+		t.goroutines[key] = calls
+	}
+
+	// Perf buffers are per CPU, so an entry may be read after the return
+	// of its invocation when the goroutine moved to another CPU. That
+	// return was already reported unmatched; keeping the entry would pair
+	// it with an unrelated later return.
+	if ts < calls.lastReturn {
+		return
+	}
+	calls.entries = append(calls.entries, ts)
+}
+
+// exit matches a return event with the innermost in-flight entry of the
+// goroutine and returns the invocation duration. ok is false when no entry
+// precedes the return.
+func (t *invocationTracker) exit(key goroutineKey, ts uint64) (duration uint64, ok bool) {
+	t.observe(ts)
+
+	calls := t.goroutines[key]
+	if calls == nil {
+		calls = &goroutineCalls{}
+		t.goroutines[key] = calls
+	}
+	calls.lastReturn = max(calls.lastReturn, ts)
+
+	n := len(calls.entries)
+	if n == 0 || calls.entries[n-1] > ts {
+		return 0, false
+	}
+
+	entryTs := calls.entries[n-1]
+	calls.entries = calls.entries[:n-1]
+	return ts - entryTs, true
+}
+
+// pending returns the number of entries waiting for their return event.
+func (t *invocationTracker) pending() int {
+	n := 0
+	for _, calls := range t.goroutines {
+		n += len(calls.entries)
+	}
+	return n
+}
+
+// observe advances the tracker clock and drops state older than
+// invocationMaxAgeNs, at most once per half of that period.
+func (t *invocationTracker) observe(ts uint64) {
+	t.latest = max(t.latest, ts)
+	if t.latest < invocationMaxAgeNs || t.latest-t.lastExpiry < invocationMaxAgeNs/2 {
+		return
+	}
+	t.lastExpiry = t.latest
+
+	cutoff := t.latest - invocationMaxAgeNs
+	for key, calls := range t.goroutines {
+		kept := calls.entries[:0]
+		for _, entryTs := range calls.entries {
+			if entryTs >= cutoff {
+				kept = append(kept, entryTs)
+			}
+		}
+		calls.entries = kept
+
+		if len(calls.entries) == 0 && calls.lastReturn < cutoff {
+			delete(t.goroutines, key)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package ebpf
+
+import (
+	"debug/elf"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvocationTracker(t *testing.T) {
+	g1 := goroutineKey{pid: 100, gPtr: 0xc000001000}
+	g2 := goroutineKey{pid: 100, gPtr: 0xc000002000}
+
+	t.Run("concurrent goroutines", func(t *testing.T) {
+		tr := newInvocationTracker()
+
+		// Interleaved calls of two goroutines, finishing in the opposite order.
+		tr.entry(g1, 1000)
+		tr.entry(g2, 1100)
+		d, ok := tr.exit(g2, 1300)
+		require.True(t, ok)
+		assert.Equal(t, uint64(200), d)
+		d, ok = tr.exit(g1, 5000)
+		require.True(t, ok)
+		assert.Equal(t, uint64(4000), d)
+		assert.Zero(t, tr.pending())
+	})
+
+	t.Run("recursion", func(t *testing.T) {
+		tr := newInvocationTracker()
+
+		tr.entry(g1, 1000)
+		tr.entry(g1, 1100)
+		d, ok := tr.exit(g1, 1150)
+		require.True(t, ok)
+		assert.Equal(t, uint64(50), d)
+		d, ok = tr.exit(g1, 2000)
+		require.True(t, ok)
+		assert.Equal(t, uint64(1000), d)
+	})
+
+	t.Run("return without entry", func(t *testing.T) {
+		tr := newInvocationTracker()
+
+		_, ok := tr.exit(g1, 1000)
+		assert.False(t, ok)
+	})
+
+	t.Run("entry read after its return", func(t *testing.T) {
+		tr := newInvocationTracker()
+
+		// Perf buffer reordering: the return of the call at 1000 is read first.
+		_, ok := tr.exit(g1, 2000)
+		assert.False(t, ok)
+		tr.entry(g1, 1000)
+		assert.Zero(t, tr.pending())
+
+		// The late entry is not paired with the next call's return.
+		tr.entry(g1, 3000)
+		d, ok := tr.exit(g1, 3500)
+		require.True(t, ok)
+		assert.Equal(t, uint64(500), d)
+	})
+
+	t.Run("orphaned entries expire", func(t *testing.T) {
+		tr := newInvocationTracker()
+
+		// Entry whose return never came (panic, filtered in kernel).
+		tr.entry(g1, 1000)
+		tr.entry(g2, invocationMaxAgeNs+2000)
+		assert.Equal(t, 1, tr.pending())
+
+		_, ok := tr.exit(g1, invocationMaxAgeNs+3000)
+		assert.False(t, ok)
+	})
+}
+
+func TestGoidOffset(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	f, err := elf.Open(exe)
+	require.NoError(t, err)
+	_, dwarfErr := f.DWARF()
+	_ = f.Close()
+	if dwarfErr != nil {
+		t.Skip("test binary built without DWARF")
+	}
+
+	offset, err := goidOffset(exe)
+	require.NoError(t, err)
+	// goid follows the stack bounds, scheduling state and other fields.
+	assert.Greater(t, offset, uint32(64))
+
+	_, err = goidOffset("/nonexistent/binary")
+	assert.Error(t, err)
+}
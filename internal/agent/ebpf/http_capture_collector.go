@@ -0,0 +1,193 @@
+package ebpf
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/httpcapture"
+)
+
+const (
+	// maxHTTPCaptureEvents bounds the exchanges kept by a collector.
+	maxHTTPCaptureEvents = 10000
+
+	// packetBufferSize fits the GRO/GSO aggregates seen by packet sockets.
+	packetBufferSize = 1 << 17
+
+	// httpFlowExpiryInterval is how often idle connections are dropped.
+	httpFlowExpiryInterval = 10 * time.Second
+)
+
+// HTTPCaptureCollector implements the Collector interface for capturing
+// HTTP exchanges of a service from its sockets. Captured exchanges are
+// reported as UprobeEvents of type "http", so that they flow through debug
+// sessions like function calls.
+type HTTPCaptureCollector struct {
+	logger   zerolog.Logger
+	config   *HTTPCaptureConfig
+	capturer *httpcapture.Capturer
+	socket   *httpcapture.Socket
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	events []*agentv1.UprobeEvent
+	mu     sync.Mutex
+}
+
+// NewHTTPCaptureCollector creates a new HTTP capture collector.
+func NewHTTPCaptureCollector(logger zerolog.Logger, config *HTTPCaptureConfig) (*HTTPCaptureCollector, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if config.PID == 0 {
+		return nil, fmt.Errorf("PID is required")
+	}
+	if config.Port == 0 {
+		return nil, fmt.Errorf("port is required")
+	}
+
+	route, err := httpcapture.ParseRoute(config.Route)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HTTPCaptureCollector{
+		logger: logger.With().Str("collector", "http_capture").Str("route", config.Route).Logger(),
+		config: config,
+		capturer: httpcapture.NewCapturer(httpcapture.Config{
+			Port:          config.Port,
+			Route:         route,
+			SampleRate:    config.SampleRate,
+			CaptureBodies: config.CaptureBodies,
+			MaxBodyBytes:  int(config.MaxBodyBytes),
+		}),
+	}, nil
+}
+
+// Start opens the capture socket in the service's network namespace.
+func (c *HTTPCaptureCollector) Start(ctx context.Context) error {
+	socket, err := httpcapture.Listen(c.config.PID, c.config.Port)
+	if err != nil {
+		return err
+	}
+	c.socket = socket
+
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.done = make(chan struct{})
+	go c.readPackets(ctx)
+
+	c.logger.Info().
+		Uint32("pid", c.config.PID).
+		Uint16("port", c.config.Port).
+		Float64("sample_rate", c.config.SampleRate).
+		Bool("bodies", c.config.CaptureBodies).
+		Msg("Started HTTP capture")
+	return nil
+}
+
+// Stop stops capturing and closes the socket.
+func (c *HTTPCaptureCollector) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	if c.socket != nil {
+		if err := c.socket.Close(); err != nil {
+			c.logger.Error().Err(err).Msg("Error closing capture socket")
+		}
+		c.socket = nil
+	}
+
+	c.logger.Info().Msg("HTTP capture stopped")
+	return nil
+}
+
+// GetEvents retrieves the captured exchanges.
+func (c *HTTPCaptureCollector) GetEvents() ([]*meshv1.EbpfEvent, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	events := make([]*meshv1.EbpfEvent, len(c.events))
+	for i, event := range c.events {
+		events[i] = &meshv1.EbpfEvent{
+			Timestamp:   event.Timestamp,
+			CollectorId: "http-capture-" + c.config.Route,
+			ServiceName: c.config.ServiceName,
+			Payload: &meshv1.EbpfEvent_UprobeEvent{
+				UprobeEvent: event,
+			},
+		}
+	}
+
+	// Events are kept for historical queries until the collector stops.
+	return events, nil
+}
+
+// readPackets reads packets from the socket until ctx is canceled.
+func (c *HTTPCaptureCollector) readPackets(ctx context.Context) {
+	defer close(c.done)
+
+	buf := make([]byte, packetBufferSize)
+	lastExpiry := time.Now()
+	for ctx.Err() == nil {
+		pkt, err := c.socket.Read(buf)
+		if err != nil {
+			c.logger.Error().Err(err).Msg("Failed to read from capture socket")
+			return
+		}
+
+		now := time.Now()
+		if now.Sub(lastExpiry) > httpFlowExpiryInterval {
+			c.capturer.Expire(now)
+			lastExpiry = now
+		}
+
+		// Loopback packets are seen once sent and once received.
+		if pkt.Data == nil || pkt.LoopbackOutgoing {
+			continue
+		}
+
+		exchanges, err := c.capturer.HandlePacket(pkt.Data, now)
+		if err != nil {
+			c.logger.Debug().Err(err).Msg("Skipping undecodable packet")
+			continue
+		}
+		for _, exchange := range exchanges {
+			c.appendEvent(exchange)
+		}
+	}
+}
+
+// appendEvent redacts and buffers a captured exchange and forwards it to
+// the OnEvent hook.
+func (c *HTTPCaptureCollector) appendEvent(exchange *httpcapture.Exchange) {
+	event := &agentv1.UprobeEvent{
+		Timestamp:    timestamppb.New(exchange.Start),
+		ServiceName:  c.config.ServiceName,
+		FunctionName: "http:" + c.config.Route,
+		EventType:    "http",
+		DurationNs:   uint64(exchange.Duration.Nanoseconds()), // #nosec G115
+		Pid:          int32(c.config.PID),                     // #nosec G115
+		Http:         exchange.HTTP,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.config.Redactor.UprobeEvent(event)
+	c.events = append(c.events, event)
+	if len(c.events) > maxHTTPCaptureEvents {
+		c.events = c.events[1:] // Drop oldest
+	}
+
+	if c.config.OnEvent != nil {
+		c.config.OnEvent(event)
+	}
+}
@@ -0,0 +1,164 @@
+// Package httpcapture captures HTTP/1.x exchanges of a service from its
+// sockets. An eBPF socket filter selects the TCP segments of the service's
+// port in the kernel; they are reassembled and parsed in user space, so the
+// service needs no SDK, proxy or restart. TLS and HTTP/2 traffic is ignored.
+package httpcapture
+
+import (
+	"math/rand/v2"
+	"net/netip"
+	"time"
+)
+
+const (
+	// DefaultMaxBodyBytes is the body capture cap when none is configured.
+	DefaultMaxBodyBytes = 4096
+
+	// maxFlows bounds the connections tracked at once.
+	maxFlows = 4096
+
+	// flowIdleTimeout is how long a connection is tracked without traffic.
+	flowIdleTimeout = 2 * time.Minute
+)
+
+// Config configures what the capturer keeps.
+type Config struct {
+	// Port is the port the service listens on.
+	Port uint16
+
+	// Route selects the requests to capture.
+	Route *Route
+
+	// SampleRate is the fraction of matching requests captured, in (0, 1].
+	// 0 captures all of them.
+	SampleRate float64
+
+	// CaptureBodies keeps request and response bodies, up to MaxBodyBytes.
+	CaptureBodies bool
+	MaxBodyBytes  int
+
+	// random returns a number in [0, 1) for sampling (rand.Float64 if nil).
+	random func() float64
+}
+
+// sample reports whether to capture a matching request.
+func (c *Config) sample() bool {
+	if c.SampleRate <= 0 || c.SampleRate >= 1 {
+		return true
+	}
+	random := c.random
+	if random == nil {
+		random = rand.Float64
+	}
+	return random() < c.SampleRate
+}
+
+// flowKey identifies a connection to the service.
+type flowKey struct {
+	client, server netip.AddrPort
+}
+
+// flow is a tracked connection.
+type flow struct {
+	toServer, toClient stream
+	conn               conn
+	clientFin          bool
+	serverFin          bool
+	lastSeen           time.Time
+}
+
+// Capturer turns the packets of a service's port into captured exchanges.
+// Not safe for concurrent use.
+type Capturer struct {
+	config Config
+	flows  map[flowKey]*flow
+}
+
+// NewCapturer creates a capturer.
+func NewCapturer(config Config) *Capturer {
+	if config.Route == nil {
+		config.Route = &Route{pattern: "/*", prefix: true}
+	}
+	if config.CaptureBodies && config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	return &Capturer{
+		config: config,
+		flows:  make(map[flowKey]*flow),
+	}
+}
+
+// HandlePacket processes a packet, starting at its network header, seen at
+// ts, and returns the exchanges it completes.
+func (c *Capturer) HandlePacket(pkt []byte, ts time.Time) ([]*Exchange, error) {
+	seg, err := decodeSegment(pkt)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		key      flowKey
+		toServer bool
+	)
+	switch c.config.Port {
+	case seg.dst.Port():
+		key, toServer = flowKey{client: seg.src, server: seg.dst}, true
+	case seg.src.Port():
+		key = flowKey{client: seg.dst, server: seg.src}
+	default:
+		return nil, nil
+	}
+
+	f := c.flows[key]
+	if seg.flags&tcpRst != 0 {
+		delete(c.flows, key)
+		return nil, nil
+	}
+	if f == nil {
+		if len(seg.payload) == 0 && seg.flags&tcpSyn == 0 {
+			return nil, nil
+		}
+		if len(c.flows) >= maxFlows {
+			return nil, nil
+		}
+		f = &flow{conn: conn{config: &c.config, client: key.client.String()}}
+		c.flows[key] = f
+	}
+	f.lastSeen = ts
+
+	var exchanges []*Exchange
+	if toServer {
+		data, gap := f.toServer.push(seg.seq, seg.flags, seg.payload)
+		if gap {
+			f.conn.requests.desync()
+		}
+		if len(data) > 0 {
+			f.conn.addRequestData(data, ts)
+		}
+		f.clientFin = f.clientFin || seg.flags&tcpFin != 0
+	} else {
+		data, gap := f.toClient.push(seg.seq, seg.flags, seg.payload)
+		if gap {
+			f.conn.responses.desync()
+		}
+		f.serverFin = f.serverFin || seg.flags&tcpFin != 0
+		if len(data) > 0 || f.serverFin {
+			exchanges = f.conn.addResponseData(data, ts, f.serverFin)
+		}
+	}
+
+	if f.clientFin && f.serverFin {
+		delete(c.flows, key)
+	}
+	return exchanges, nil
+}
+
+// Expire stops tracking connections idle since before now minus the idle
+// timeout.
+func (c *Capturer) Expire(now time.Time) {
+	for key, f := range c.flows {
+		if now.Sub(f.lastSeen) > flowIdleTimeout {
+			delete(c.flows, key)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package httpcapture
+
+import (
+	"encoding/binary"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+)
+
+var (
+	testClient = netip.MustParseAddrPort("10.0.0.1:51000")
+	testServer = netip.MustParseAddrPort("10.0.0.2:8080")
+)
+
+// tcpPacket builds an IPv4 or IPv6 packet carrying a TCP segment.
+func tcpPacket(src, dst netip.AddrPort, seq uint32, flags uint8, payload string) []byte {
+	tcp := make([]byte, 20, 20+len(payload))
+	binary.BigEndian.PutUint16(tcp[0:2], src.Port())
+	binary.BigEndian.PutUint16(tcp[2:4], dst.Port())
+	binary.BigEndian.PutUint32(tcp[4:8], seq)
+	tcp[12] = 5 << 4
+	tcp[13] = flags
+	tcp = append(tcp, payload...)
+
+	if src.Addr().Is4() {
+		ip := make([]byte, 20, 20+len(tcp))
+		ip[0] = 0x45
+		binary.BigEndian.PutUint16(ip[2:4], uint16(20+len(tcp))) // #nosec G115
+		ip[9] = ipProtoTCP
+		copy(ip[12:16], src.Addr().AsSlice())
+		copy(ip[16:20], dst.Addr().AsSlice())
+		return append(ip, tcp...)
+	}
+
+	ip := make([]byte, 40, 40+len(tcp))
+	ip[0] = 0x60
+	binary.BigEndian.PutUint16(ip[4:6], uint16(len(tcp))) // #nosec G115
+	ip[6] = ipProtoTCP
+	copy(ip[8:24], src.Addr().AsSlice())
+	copy(ip[24:40], dst.Addr().AsSlice())
+	return append(ip, tcp...)
+}
+
+// connection drives a TCP connection through a capturer.
+type connection struct {
+	t                 *testing.T
+	c                 *Capturer
+	client, server    netip.AddrPort
+	clientSeq, srvSeq uint32
+	now               time.Time
+	exchanges         []*Exchange
+}
+
+func newConnection(t *testing.T, c *Capturer) *connection {
+	conn := &connection{
+		t: t, c: c, client: testClient, server: testServer,
+		clientSeq: 1000, srvSeq: 9000, now: time.Unix(1700000000, 0),
+	}
+	conn.handle(tcpPacket(conn.client, conn.server, conn.clientSeq, tcpSyn, ""))
+	conn.handle(tcpPacket(conn.server, conn.client, conn.srvSeq, tcpSyn, ""))
+	conn.clientSeq++
+	conn.srvSeq++
+	return conn
+}
+
+func (c *connection) handle(pkt []byte) {
+	c.now = c.now.Add(time.Millisecond)
+	exchanges, err := c.c.HandlePacket(pkt, c.now)
+	require.NoError(c.t, err)
+	c.exchanges = append(c.exchanges, exchanges...)
+}
+
+func (c *connection) send(data string) {
+	c.handle(tcpPacket(c.client, c.server, c.clientSeq, 0, data))
+	c.clientSeq += uint32(len(data)) // #nosec G115
+}
+
+func (c *connection) reply(data string) {
+	c.handle(tcpPacket(c.server, c.client, c.srvSeq, 0, data))
+	c.srvSeq += uint32(len(data)) // #nosec G115
+}
+
+func (c *connection) closeServer() {
+	c.handle(tcpPacket(c.server, c.client, c.srvSeq, tcpFin, ""))
+}
+
+func newTestCapturer(t *testing.T, route string, bodies bool) *Capturer {
+	r, err := ParseRoute(route)
+	require.NoError(t, err)
+	return NewCapturer(Config{Port: testServer.Port(), Route: r, CaptureBodies: bodies, MaxBodyBytes: 16})
+}
+
+func header(headers []*agentv1.HttpHeader, name string) string {
+	for _, h := range headers {
+		if h.Name == name {
+			return h.Value
+		}
+	}
+	return ""
+}
+
+func TestCapturer(t *testing.T) {
+	t.Run("request and response", func(t *testing.T) {
+		conn := newConnection(t, newTestCapturer(t, "/api/checkout", true))
+
+		conn.send("POST /api/checkout?cart=7 HTTP/1.1\r\nHost: api\r\nContent-Type: application/json\r\nContent-Length: 13\r\n\r\n")
+		conn.send(`{"amount":42}`)
+		conn.reply("HTTP/1.1 201 Created\r\nContent-Length: 11\r\nX-Request-Id: r1\r\n\r\n{\"ok\":true}")
+
+		require.Len(t, conn.exchanges, 1)
+		ex := conn.exchanges[0]
+		assert.Equal(t, "POST", ex.HTTP.Method)
+		assert.Equal(t, "/api/checkout", ex.HTTP.Path)
+		assert.Equal(t, "cart=7", ex.HTTP.Query)
+		assert.Equal(t, "/api/checkout", ex.HTTP.Route)
+		assert.Equal(t, int32(201), ex.HTTP.StatusCode)
+		assert.Equal(t, "api", header(ex.HTTP.RequestHeaders, "Host"))
+		assert.Equal(t, "application/json", header(ex.HTTP.RequestHeaders, "Content-Type"))
+		assert.Equal(t, "r1", header(ex.HTTP.ResponseHeaders, "X-Request-Id"))
+		assert.Equal(t, `{"amount":42}`, ex.HTTP.RequestBody)
+		assert.Equal(t, `{"ok":true}`, ex.HTTP.ResponseBody)
+		assert.Equal(t, testClient.String(), ex.HTTP.ClientAddr)
+		assert.Equal(t, 2*time.Millisecond, ex.Duration)
+	})
+
+	t.Run("other routes are skipped", func(t *testing.T) {
+		conn := newConnection(t, newTestCapturer(t, "/api/checkout", false))
+
+		conn.send("GET /health HTTP/1.1\r\nHost: api\r\n\r\n")
+		conn.send("GET /api/checkout HTTP/1.1\r\nHost: api\r\n\r\n")
+		conn.reply("HTTP/1.1 200 OK\r\nContent-Length: 2\r\n\r\nok")
+		conn.reply("HTTP/1.1 404 Not Found\r\nContent-Length: 0\r\n\r\n")
+
+		// Pipelined: the second response answers the captured request.
+		require.Len(t, conn.exchanges, 1)
+		assert.Equal(t, int32(404), conn.exchanges[0].HTTP.StatusCode)
+		assert.Empty(t, conn.exchanges[0].HTTP.ResponseBody)
+	})
+
+	t.Run("body cap", func(t *testing.T) {
+		conn := newConnection(t, newTestCapturer(t, "/*", true))
+
+		conn.send("GET / HTTP/1.1\r\nHost: api\r\n\r\n")
+		conn.reply("HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n")
+		conn.reply("14\r\n" + strings.Repeat("x", 20) + "\r\n0\r\n\r\n")
+
+		require.Len(t, conn.exchanges, 1)
+		assert.Equal(t, strings.Repeat("x", 16), conn.exchanges[0].HTTP.ResponseBody)
+		assert.True(t, conn.exchanges[0].HTTP.ResponseBodyTruncated)
+	})
+
+	t.Run("response until close", func(t *testing.T) {
+		conn := newConnection(t, newTestCapturer(t, "/*", true))
+
+		conn.send("GET / HTTP/1.0\r\n\r\n")
+		conn.reply("HTTP/1.0 200 OK\r\n\r\nhello")
+		assert.Empty(t, conn.exchanges)
+
+		conn.closeServer()
+		require.Len(t, conn.exchanges, 1)
+		assert.Equal(t, "hello", conn.exchanges[0].HTTP.ResponseBody)
+	})
+
+	t.Run("informational response", func(t *testing.T) {
+		conn := newConnection(t, newTestCapturer(t, "/*", false))
+
+		conn.send("PUT /upload HTTP/1.1\r\nHost: api\r\nExpect: 100-continue\r\nContent-Length: 3\r\n\r\n")
+		conn.reply("HTTP/1.1 100 Continue\r\n\r\n")
+		conn.send("abc")
+		conn.reply("HTTP/1.1 204 No Content\r\n\r\n")
+
+		require.Len(t, conn.exchanges, 1)
+		assert.Equal(t, int32(204), conn.exchanges[0].HTTP.StatusCode)
+	})
+
+	t.Run("binary body", func(t *testing.T) {
+		conn := newConnection(t, newTestCapturer(t, "/*", true))
+
+		conn.send("GET / HTTP/1.1\r\nHost: api\r\n\r\n")
+		conn.reply("HTTP/1.1 200 OK\r\nContent-Length: 4\r\n\r\n\xff\xfe\x00\x01")
+
+		require.Len(t, conn.exchanges, 1)
+		assert.Equal(t, "<4 bytes of binary data>", conn.exchanges[0].HTTP.ResponseBody)
+	})
+
+	t.Run("joined mid-stream", func(t *testing.T) {
+		c := newTestCapturer(t, "/*", false)
+		conn := &connection{t: t, c: c, client: testClient, server: testServer, clientSeq: 500, srvSeq: 700, now: time.Unix(1700000000, 0)}
+
+		// The tail of a request and its response, sent before the capture.
+		conn.send("ent-Length: 0\r\n\r\n")
+		conn.reply("HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n")
+		conn.send("GET /next HTTP/1.1\r\nHost: api\r\n\r\n")
+		conn.reply("HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n")
+
+		require.Len(t, conn.exchanges, 1)
+		assert.Equal(t, "/next", conn.exchanges[0].HTTP.Path)
+	})
+
+	t.Run("IPv6", func(t *testing.T) {
+		conn := newConnection(t, newTestCapturer(t, "/*", false))
+		conn.client = netip.MustParseAddrPort("[2001:db8::1]:51000")
+		conn.server = netip.MustParseAddrPort("[2001:db8::2]:8080")
+
+		conn.send("GET / HTTP/1.1\r\nHost: api\r\n\r\n")
+		conn.reply("HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n")
+
+		require.Len(t, conn.exchanges, 1)
+		assert.Equal(t, "[2001:db8::1]:51000", conn.exchanges[0].HTTP.ClientAddr)
+	})
+
+	t.Run("HTTP/2 is ignored", func(t *testing.T) {
+		conn := newConnection(t, newTestCapturer(t, "/*", false))
+
+		conn.send("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n")
+		conn.send("GET / HTTP/1.1\r\nHost: api\r\n\r\n")
+		conn.reply("HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n")
+
+		assert.Empty(t, conn.exchanges)
+	})
+}
+
+func TestCapturerSampling(t *testing.T) {
+	r, err := ParseRoute("/*")
+	require.NoError(t, err)
+
+	draws := []float64{0.5, 0.005, 0.9}
+	c := NewCapturer(Config{Port: testServer.Port(), Route: r, SampleRate: 0.01})
+	c.config.random = func() float64 {
+		d := draws[0]
+		draws = draws[1:]
+		return d
+	}
+
+	conn := newConnection(t, c)
+	for range 3 {
+		conn.send("GET / HTTP/1.1\r\nHost: api\r\n\r\n")
+		conn.reply("HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n")
+	}
+	assert.Len(t, conn.exchanges, 1)
+}
+
+func TestCapturerFlows(t *testing.T) {
+	c := newTestCapturer(t, "/*", false)
+
+	conn := newConnection(t, c)
+	conn.send("GET / HTTP/1.1\r\nHost: api\r\n")
+	assert.Len(t, c.flows, 1)
+
+	// Traffic of other ports is ignored.
+	_, err := c.HandlePacket(tcpPacket(testClient, netip.MustParseAddrPort("10.0.0.2:9090"), 1, tcpSyn, ""), conn.now)
+	require.NoError(t, err)
+	assert.Len(t, c.flows, 1)
+
+	c.Expire(conn.now.Add(flowIdleTimeout + time.Second))
+	assert.Empty(t, c.flows)
+
+	conn = newConnection(t, c)
+	conn.handle(tcpPacket(conn.client, conn.server, conn.clientSeq, tcpRst, ""))
+	assert.Empty(t, c.flows)
+}
+
+func TestDecodeSegment(t *testing.T) {
+	pkt := tcpPacket(testClient, testServer, 42, tcpSyn|tcpFin, "data")
+	seg, err := decodeSegment(pkt)
+	require.NoError(t, err)
+	assert.Equal(t, testClient, seg.src)
+	assert.Equal(t, testServer, seg.dst)
+	assert.Equal(t, uint32(42), seg.seq)
+	assert.Equal(t, uint8(tcpSyn|tcpFin), seg.flags)
+	assert.Equal(t, "data", string(seg.payload))
+
+	// Ethernet padding after the IP packet is not payload.
+	seg, err = decodeSegment(append(tcpPacket(testClient, testServer, 1, 0, ""), 0, 0, 0, 0))
+	require.NoError(t, err)
+	assert.Empty(t, seg.payload)
+
+	udp := tcpPacket(testClient, testServer, 1, 0, "")
+	udp[9] = 17
+	_, err = decodeSegment(udp)
+	assert.Error(t, err)
+
+	_, err = decodeSegment(pkt[:30])
+	assert.Error(t, err)
+}
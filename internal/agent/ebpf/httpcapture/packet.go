@@ -0,0 +1,94 @@
+package httpcapture
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// TCP flags.
+const (
+	tcpFin = 0x01
+	tcpSyn = 0x02
+	tcpRst = 0x04
+)
+
+const ipProtoTCP = 6
+
+// segment is a decoded TCP segment.
+type segment struct {
+	src, dst netip.AddrPort
+	seq      uint32
+	flags    uint8
+	payload  []byte
+}
+
+// decodeSegment decodes a TCP segment from an IPv4 or IPv6 packet, starting
+// at the network header. IPv6 extension headers and IP fragments are not
+// supported.
+func decodeSegment(pkt []byte) (segment, error) {
+	if len(pkt) < 1 {
+		return segment{}, errors.New("empty packet")
+	}
+
+	var (
+		seg    segment
+		srcIP  netip.Addr
+		dstIP  netip.Addr
+		tcpHdr []byte
+	)
+	switch pkt[0] >> 4 {
+	case 4:
+		if len(pkt) < 20 {
+			return segment{}, errors.New("short IPv4 header")
+		}
+		ihl := int(pkt[0]&0x0f) * 4
+		if ihl < 20 || len(pkt) < ihl {
+			return segment{}, fmt.Errorf("invalid IPv4 header length %d", ihl)
+		}
+		if pkt[9] != ipProtoTCP {
+			return segment{}, fmt.Errorf("not TCP: protocol %d", pkt[9])
+		}
+		if binary.BigEndian.Uint16(pkt[6:8])&0x1fff != 0 {
+			return segment{}, errors.New("IPv4 fragment")
+		}
+		// Total length is 0 for GSO packets larger than 64KiB.
+		if total := int(binary.BigEndian.Uint16(pkt[2:4])); total >= ihl && total < len(pkt) {
+			pkt = pkt[:total]
+		}
+		srcIP = netip.AddrFrom4([4]byte(pkt[12:16]))
+		dstIP = netip.AddrFrom4([4]byte(pkt[16:20]))
+		tcpHdr = pkt[ihl:]
+	case 6:
+		if len(pkt) < 40 {
+			return segment{}, errors.New("short IPv6 header")
+		}
+		if pkt[6] != ipProtoTCP {
+			return segment{}, fmt.Errorf("not TCP: next header %d", pkt[6])
+		}
+		if payloadLen := int(binary.BigEndian.Uint16(pkt[4:6])); payloadLen > 0 && 40+payloadLen < len(pkt) {
+			pkt = pkt[:40+payloadLen]
+		}
+		srcIP = netip.AddrFrom16([16]byte(pkt[8:24])).Unmap()
+		dstIP = netip.AddrFrom16([16]byte(pkt[24:40])).Unmap()
+		tcpHdr = pkt[40:]
+	default:
+		return segment{}, fmt.Errorf("unknown IP version %d", pkt[0]>>4)
+	}
+
+	if len(tcpHdr) < 20 {
+		return segment{}, errors.New("short TCP header")
+	}
+	dataOffset := int(tcpHdr[12]>>4) * 4
+	if dataOffset < 20 || len(tcpHdr) < dataOffset {
+		return segment{}, fmt.Errorf("invalid TCP data offset %d", dataOffset)
+	}
+
+	seg.src = netip.AddrPortFrom(srcIP, binary.BigEndian.Uint16(tcpHdr[0:2]))
+	seg.dst = netip.AddrPortFrom(dstIP, binary.BigEndian.Uint16(tcpHdr[2:4]))
+	seg.seq = binary.BigEndian.Uint32(tcpHdr[4:8])
+	seg.flags = tcpHdr[13]
+	seg.payload = tcpHdr[dataOffset:]
+	return seg, nil
+}
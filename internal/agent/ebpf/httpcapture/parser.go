@@ -0,0 +1,319 @@
+package httpcapture
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+)
+
+// maxMessageBytes bounds the bytes buffered for one HTTP message. Larger
+// messages are skipped, along with the rest of the connection's messages
+// until a new one starts at a segment boundary.
+const maxMessageBytes = 1 << 20
+
+// requestMethods are the methods a request may start with, used to find the
+// start of the next request after skipping data.
+var requestMethods = []string{
+	"GET ", "POST ", "PUT ", "DELETE ", "PATCH ", "HEAD ", "OPTIONS ", "CONNECT ", "TRACE ",
+}
+
+// messageBuffer holds the bytes of one direction of a connection that were
+// not parsed into messages yet.
+type messageBuffer struct {
+	data    []byte
+	started time.Time // Arrival of the first buffered byte.
+	skip    bool      // Skipping data until a message starts.
+}
+
+// add appends data that arrived at ts. While skipping, data is dropped
+// unless it starts a message.
+func (b *messageBuffer) add(data []byte, ts time.Time, startsMessage func([]byte) bool) {
+	if b.skip {
+		if !startsMessage(data) {
+			return
+		}
+		b.skip = false
+	}
+	if len(b.data) == 0 {
+		b.started = ts
+	}
+	b.data = append(b.data, data...)
+	if len(b.data) > maxMessageBytes {
+		b.desync()
+	}
+}
+
+// consume drops the first n bytes, which arrived before ts.
+func (b *messageBuffer) consume(n int, ts time.Time) {
+	b.data = b.data[n:]
+	if len(b.data) == 0 {
+		b.data = nil
+	}
+	b.started = ts
+}
+
+// desync drops the buffered data and skips data until a message starts.
+func (b *messageBuffer) desync() {
+	b.data = nil
+	b.skip = true
+}
+
+// inflightRequest is a request waiting for its response.
+type inflightRequest struct {
+	method   string
+	start    time.Time
+	exchange *agentv1.HttpExchange // nil unless the request is captured.
+}
+
+// Exchange is a captured HTTP request and its response.
+type Exchange struct {
+	// Start is when the first byte of the request was seen.
+	Start time.Time
+	// Duration is the time from Start to the last byte of the response.
+	Duration time.Duration
+	HTTP     *agentv1.HttpExchange
+}
+
+// conn parses the HTTP/1.x messages of a connection and pairs requests with
+// their responses.
+type conn struct {
+	config    *Config
+	client    string
+	requests  messageBuffer
+	responses messageBuffer
+	inflight  []*inflightRequest
+	// unsupported is set when the connection does not carry HTTP/1.x, e.g.
+	// HTTP/2 or after a protocol upgrade; its data is ignored.
+	unsupported bool
+}
+
+// addRequestData parses the requests completed by data sent by the client.
+func (c *conn) addRequestData(data []byte, ts time.Time) {
+	if c.unsupported {
+		return
+	}
+	c.requests.add(data, ts, startsRequest)
+
+	for len(c.requests.data) > 0 {
+		r := bytes.NewReader(c.requests.data)
+		br := bufio.NewReader(r)
+		req, err := http.ReadRequest(br)
+		if incomplete(err) {
+			return
+		}
+		if err != nil {
+			c.requests.desync()
+			return
+		}
+		if req.ProtoMajor != 1 {
+			c.unsupported = true
+			return
+		}
+
+		var exchange *agentv1.HttpExchange
+		if c.config.Route.Match(req.URL.Path) && c.config.sample() {
+			exchange = &agentv1.HttpExchange{
+				Method:         req.Method,
+				Path:           req.URL.Path,
+				Query:          req.URL.RawQuery,
+				Route:          c.config.Route.String(),
+				RequestHeaders: headers(req.Header, req.Host),
+				ClientAddr:     c.client,
+			}
+		}
+
+		body, truncated, err := readBody(req.Body, c.bodyLimit(exchange))
+		if incomplete(err) {
+			return
+		}
+		if err != nil {
+			c.requests.desync()
+			return
+		}
+		if exchange != nil && c.config.CaptureBodies {
+			exchange.RequestBody = bodyString(body, truncated)
+			exchange.RequestBodyTruncated = truncated
+		}
+
+		c.inflight = append(c.inflight, &inflightRequest{
+			method:   req.Method,
+			start:    c.requests.started,
+			exchange: exchange,
+		})
+		c.requests.consume(len(c.requests.data)-r.Len()-br.Buffered(), ts)
+	}
+}
+
+// addResponseData parses the responses completed by data sent by the
+// server and returns the captured exchanges they complete. closed is true
+// when the server closed the connection, which ends responses without
+// Content-Length.
+func (c *conn) addResponseData(data []byte, ts time.Time, closed bool) []*Exchange {
+	if c.unsupported {
+		return nil
+	}
+	c.responses.add(data, ts, startsResponse)
+
+	var exchanges []*Exchange
+	for len(c.responses.data) > 0 {
+		var inflight *inflightRequest
+		if len(c.inflight) > 0 {
+			inflight = c.inflight[0]
+		}
+
+		r := bytes.NewReader(c.responses.data)
+		br := bufio.NewReader(r)
+		var req *http.Request
+		if inflight != nil {
+			// The method tells whether the response has a body.
+			req = &http.Request{Method: inflight.method}
+		}
+		resp, err := http.ReadResponse(br, req)
+		if incomplete(err) {
+			return exchanges
+		}
+		if err != nil {
+			c.responses.desync()
+			return exchanges
+		}
+
+		// Informational responses, e.g. 100 Continue, precede the final one.
+		if resp.StatusCode >= 100 && resp.StatusCode < 200 && resp.StatusCode != http.StatusSwitchingProtocols {
+			c.responses.consume(len(c.responses.data)-r.Len()-br.Buffered(), ts)
+			continue
+		}
+
+		// Without Content-Length or chunked encoding, the body lasts until
+		// the connection is closed.
+		untilClose := resp.Body != http.NoBody && resp.ContentLength < 0 && len(resp.TransferEncoding) == 0
+		if untilClose && !closed {
+			return exchanges
+		}
+
+		var exchange *agentv1.HttpExchange
+		if inflight != nil {
+			exchange = inflight.exchange
+		}
+		body, truncated, err := readBody(resp.Body, c.bodyLimit(exchange))
+		if incomplete(err) && !untilClose {
+			return exchanges
+		}
+		if err != nil && !untilClose {
+			c.responses.desync()
+			return exchanges
+		}
+		c.responses.consume(len(c.responses.data)-r.Len()-br.Buffered(), ts)
+
+		if inflight == nil {
+			// Response to a request sent before the capture started.
+			continue
+		}
+		c.inflight = c.inflight[1:]
+
+		if exchange != nil {
+			exchange.StatusCode = int32(resp.StatusCode) // #nosec G115
+			exchange.ResponseHeaders = headers(resp.Header, "")
+			if c.config.CaptureBodies {
+				exchange.ResponseBody = bodyString(body, truncated)
+				exchange.ResponseBodyTruncated = truncated
+			}
+			exchanges = append(exchanges, &Exchange{
+				Start:    inflight.start,
+				Duration: ts.Sub(inflight.start),
+				HTTP:     exchange,
+			})
+		}
+
+		if resp.StatusCode == http.StatusSwitchingProtocols {
+			c.unsupported = true
+			return exchanges
+		}
+	}
+	return exchanges
+}
+
+// bodyLimit returns how many body bytes to keep for exchange.
+func (c *conn) bodyLimit(exchange *agentv1.HttpExchange) int {
+	if exchange == nil || !c.config.CaptureBodies {
+		return 0
+	}
+	return c.config.MaxBodyBytes
+}
+
+// readBody reads body to its end and returns up to limit bytes of it.
+// truncated is true when the body was longer than limit.
+func readBody(body io.Reader, limit int) (data []byte, truncated bool, err error) {
+	if limit > 0 {
+		data, err = io.ReadAll(io.LimitReader(body, int64(limit)))
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	rest, err := io.Copy(io.Discard, body)
+	if err != nil {
+		return nil, false, err
+	}
+	return data, rest > 0, nil
+}
+
+// incomplete reports whether err means the message has not fully arrived.
+func incomplete(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// headers converts h to captured headers, sorted by name. host is the
+// request's Host header, which net/http keeps out of h.
+func headers(h http.Header, host string) []*agentv1.HttpHeader {
+	var out []*agentv1.HttpHeader
+	if host != "" {
+		out = append(out, &agentv1.HttpHeader{Name: "Host", Value: host})
+	}
+
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range h[name] {
+			out = append(out, &agentv1.HttpHeader{Name: name, Value: value})
+		}
+	}
+	return out
+}
+
+// bodyString returns body as text, or a placeholder for binary bodies.
+func bodyString(body []byte, truncated bool) string {
+	// A truncated body may end in the middle of a UTF-8 sequence.
+	for i := 0; truncated && i < utf8.UTFMax-1 && len(body) > 0 && !utf8.Valid(body); i++ {
+		body = body[:len(body)-1]
+	}
+	if !utf8.Valid(body) {
+		return fmt.Sprintf("<%d bytes of binary data>", len(body))
+	}
+	return string(body)
+}
+
+// startsRequest reports whether data starts with an HTTP request line.
+func startsRequest(data []byte) bool {
+	for _, method := range requestMethods {
+		if bytes.HasPrefix(data, []byte(method)) {
+			return true
+		}
+	}
+	return false
+}
+
+// startsResponse reports whether data starts with an HTTP/1.x status line.
+func startsResponse(data []byte) bool {
+	return strings.HasPrefix(string(data[:min(len(data), 7)]), "HTTP/1.")
+}
@@ -0,0 +1,71 @@
+package httpcapture
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Route matches request paths against a pattern. Segments written "{name}"
+// or ":name" match any single segment, and a final "*" segment matches the
+// rest of the path: "/api/orders/{id}" matches "/api/orders/42", "/api/*"
+// matches "/api/orders/42/items". Trailing slashes are ignored.
+type Route struct {
+	pattern  string
+	segments []string // "" for parameter segments.
+	prefix   bool
+}
+
+// ParseRoute parses a route pattern.
+func ParseRoute(pattern string) (*Route, error) {
+	if !strings.HasPrefix(pattern, "/") {
+		return nil, fmt.Errorf("route %q must start with /", pattern)
+	}
+
+	r := &Route{pattern: pattern}
+	parts := splitPath(pattern)
+	for i, part := range parts {
+		switch {
+		case part == "*":
+			if i != len(parts)-1 {
+				return nil, fmt.Errorf("route %q: * is only allowed as the last segment", pattern)
+			}
+			r.prefix = true
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"),
+			strings.HasPrefix(part, ":") && len(part) > 1:
+			r.segments = append(r.segments, "")
+		case strings.ContainsAny(part, "{}*"):
+			return nil, fmt.Errorf("route %q: invalid segment %q", pattern, part)
+		default:
+			r.segments = append(r.segments, part)
+		}
+	}
+	return r, nil
+}
+
+// String returns the route pattern.
+func (r *Route) String() string {
+	return r.pattern
+}
+
+// Match reports whether path, without query string, matches the route.
+func (r *Route) Match(path string) bool {
+	parts := splitPath(path)
+	if len(parts) < len(r.segments) || (!r.prefix && len(parts) != len(r.segments)) {
+		return false
+	}
+	for i, seg := range r.segments {
+		if parts[i] == "" || (seg != "" && seg != parts[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitPath splits a path into its non-empty segments.
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
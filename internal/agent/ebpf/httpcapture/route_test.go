@@ -0,0 +1,47 @@
+package httpcapture
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoute(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/api/checkout", "/api/checkout", true},
+		{"/api/checkout", "/api/checkout/", true},
+		{"/api/checkout", "/api/checkout/confirm", false},
+		{"/api/checkout", "/api", false},
+		{"/api/orders/{id}", "/api/orders/42", true},
+		{"/api/orders/:id", "/api/orders/42", true},
+		{"/api/orders/{id}", "/api/orders", false},
+		{"/api/orders/{id}", "/api/orders/42/items", false},
+		{"/api/orders/{id}/items", "/api/orders/42/items", true},
+		{"/api/*", "/api/orders/42", true},
+		{"/api/*", "/api", true},
+		{"/api/*", "/apis", false},
+		{"/*", "/anything/at/all", true},
+		{"/", "/", true},
+		{"/", "/api", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			r, err := ParseRoute(tt.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, r.Match(tt.path))
+		})
+	}
+}
+
+func TestParseRouteErrors(t *testing.T) {
+	for _, pattern := range []string{"", "api/checkout", "/api/*/items", "/api/{id", "/api/v*"} {
+		_, err := ParseRoute(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
@@ -0,0 +1,205 @@
+//go:build linux
+
+package httpcapture
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	ciliumebpf "github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"golang.org/x/sys/unix"
+)
+
+// readTimeout bounds how long Read blocks, so that readers can notice
+// cancellation and expire idle connections.
+const readTimeout = 500 * time.Millisecond
+
+// Socket receives the TCP segments of one port, from the network namespace
+// of a process.
+type Socket struct {
+	fd   int
+	prog *ciliumebpf.Program
+}
+
+// Packet is a packet read from a Socket.
+type Packet struct {
+	// Data starts at the network header.
+	Data []byte
+	// LoopbackOutgoing is true for packets sent on the loopback interface,
+	// which are seen again as incoming.
+	LoopbackOutgoing bool
+}
+
+// Listen opens a packet socket in the network namespace of pid that receives
+// the IPv4 and IPv6 TCP segments from and to port.
+func Listen(pid uint32, port uint16) (*Socket, error) {
+	prog, err := ciliumebpf.NewProgram(&ciliumebpf.ProgramSpec{
+		Name:         "http_capture",
+		Type:         ciliumebpf.SocketFilter,
+		Instructions: portFilter(port),
+		License:      "GPL",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load socket filter: %w", err)
+	}
+
+	fd, err := socketInNetns(pid)
+	if err != nil {
+		_ = prog.Close()
+		return nil, err
+	}
+	s := &Socket{fd: fd, prog: prog}
+
+	// The socket receives nothing until bound to a protocol, so no packet
+	// reaches it before the filter is attached.
+	if err := unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_ATTACH_BPF, prog.FD()); err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("failed to attach socket filter: %w", err)
+	}
+	tv := unix.NsecToTimeval(readTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("failed to set read timeout: %w", err)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(unix.ETH_P_ALL)}); err != nil {
+		_ = s.Close()
+		return nil, fmt.Errorf("failed to bind packet socket: %w", err)
+	}
+	return s, nil
+}
+
+// Read reads a packet into buf. It returns a nil Data when no packet arrived
+// within the read timeout.
+func (s *Socket) Read(buf []byte) (Packet, error) {
+	n, from, err := unix.Recvfrom(s.fd, buf, 0)
+	if err != nil {
+		if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+			return Packet{}, nil
+		}
+		return Packet{}, err
+	}
+
+	pkt := Packet{Data: buf[:n]}
+	if ll, ok := from.(*unix.SockaddrLinklayer); ok {
+		pkt.LoopbackOutgoing = ll.Hatype == unix.ARPHRD_LOOPBACK && ll.Pkttype == unix.PACKET_OUTGOING
+	}
+	return pkt, nil
+}
+
+// Close closes the socket and unloads its filter.
+func (s *Socket) Close() error {
+	err := unix.Close(s.fd)
+	if cerr := s.prog.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// socketInNetns creates an unbound packet socket in the network namespace
+// of pid. Sockets stay in the namespace they were created in.
+func socketInNetns(pid uint32) (int, error) {
+	target, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return -1, fmt.Errorf("failed to open network namespace of process %d: %w", pid, err)
+	}
+	defer target.Close() // nolint:errcheck
+
+	type result struct {
+		fd  int
+		err error
+	}
+	done := make(chan result, 1)
+
+	// Namespaces are per thread: switch on a dedicated thread, which is
+	// discarded rather than reused if it cannot be switched back.
+	go func() {
+		runtime.LockOSThread()
+
+		self, err := os.Open("/proc/thread-self/ns/net")
+		if err != nil {
+			runtime.UnlockOSThread()
+			done <- result{-1, fmt.Errorf("failed to open own network namespace: %w", err)}
+			return
+		}
+		defer self.Close() // nolint:errcheck
+
+		if err := unix.Setns(int(target.Fd()), unix.CLONE_NEWNET); err != nil {
+			runtime.UnlockOSThread()
+			done <- result{-1, fmt.Errorf("failed to enter network namespace of process %d: %w", pid, err)}
+			return
+		}
+
+		// SOCK_DGRAM strips link-layer headers, so packets of every
+		// interface type start at the network header.
+		fd, sockErr := unix.Socket(unix.AF_PACKET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+		if sockErr != nil {
+			sockErr = fmt.Errorf("failed to create packet socket: %w", sockErr)
+		}
+
+		if err := unix.Setns(int(self.Fd()), unix.CLONE_NEWNET); err == nil {
+			runtime.UnlockOSThread()
+		}
+		done <- result{fd, sockErr}
+	}()
+
+	res := <-done
+	return res.fd, res.err
+}
+
+// portFilter returns a socket filter program that accepts IPv4 and IPv6 TCP
+// segments whose source or destination port is port. Packets start at the
+// network header; IPv4 fragments after the first are rejected.
+func portFilter(port uint16) asm.Instructions {
+	return asm.Instructions{
+		// Packet loads read from the socket buffer in R6.
+		asm.Mov.Reg(asm.R6, asm.R1),
+
+		asm.LoadAbs(0, asm.Byte),
+		asm.Mov.Reg(asm.R7, asm.R0),
+		asm.RSh.Imm(asm.R7, 4),
+		asm.JEq.Imm(asm.R7, 4, "ipv4"),
+		asm.JEq.Imm(asm.R7, 6, "ipv6"),
+		asm.Ja.Label("drop"),
+
+		// IPv4: protocol, fragment offset, then ports after the IHL words.
+		asm.LoadAbs(9, asm.Byte).WithSymbol("ipv4"),
+		asm.JNE.Imm(asm.R0, ipProtoTCP, "drop"),
+		asm.LoadAbs(6, asm.Half),
+		asm.And.Imm(asm.R0, 0x1fff),
+		asm.JNE.Imm(asm.R0, 0, "drop"),
+		asm.LoadAbs(0, asm.Byte),
+		asm.Mov.Reg(asm.R8, asm.R0),
+		asm.And.Imm(asm.R8, 0x0f),
+		asm.LSh.Imm(asm.R8, 2),
+		asm.LoadInd(asm.R0, asm.R8, 0, asm.Half),
+		asm.JEq.Imm(asm.R0, int32(port), "accept"),
+		asm.LoadInd(asm.R0, asm.R8, 2, asm.Half),
+		asm.JEq.Imm(asm.R0, int32(port), "accept"),
+		asm.Ja.Label("drop"),
+
+		// IPv6: next header, then ports after the fixed header.
+		asm.LoadAbs(6, asm.Byte).WithSymbol("ipv6"),
+		asm.JNE.Imm(asm.R0, ipProtoTCP, "drop"),
+		asm.LoadAbs(40, asm.Half),
+		asm.JEq.Imm(asm.R0, int32(port), "accept"),
+		asm.LoadAbs(42, asm.Half),
+		asm.JEq.Imm(asm.R0, int32(port), "accept"),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("drop"),
+		asm.Return(),
+
+		// Keep the whole packet.
+		asm.Mov.Imm(asm.R0, -1).WithSymbol("accept"),
+		asm.Return(),
+	}
+}
+
+// htons converts a uint16 to network byte order.
+func htons(v uint16) uint16 {
+	return binary.NativeEndian.Uint16(binary.BigEndian.AppendUint16(nil, v))
+}
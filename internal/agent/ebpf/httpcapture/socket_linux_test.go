@@ -0,0 +1,67 @@
+//go:build linux
+
+package httpcapture
+
+import (
+	"net/netip"
+	"testing"
+
+	ciliumebpf "github.com/cilium/ebpf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortFilter(t *testing.T) {
+	prog, err := ciliumebpf.NewProgram(&ciliumebpf.ProgramSpec{
+		Type:         ciliumebpf.SocketFilter,
+		Instructions: portFilter(testServer.Port()),
+		License:      "GPL",
+	})
+	if err != nil {
+		t.Skipf("cannot load socket filter: %v", err)
+	}
+	defer prog.Close() // nolint:errcheck
+
+	v6Client := netip.MustParseAddrPort("[2001:db8::1]:51000")
+	v6Server := netip.MustParseAddrPort("[2001:db8::2]:8080")
+	other := netip.MustParseAddrPort("10.0.0.2:9090")
+
+	udp := tcpPacket(testClient, testServer, 1, 0, "")
+	udp[9] = 17
+	fragment := tcpPacket(testClient, testServer, 1, 0, "")
+	fragment[7] = 0x10
+
+	tests := []struct {
+		name   string
+		pkt    []byte
+		accept bool
+	}{
+		{"to port", tcpPacket(testClient, testServer, 1, 0, "GET"), true},
+		{"from port", tcpPacket(testServer, testClient, 1, 0, "HTTP"), true},
+		{"IPv6 to port", tcpPacket(v6Client, v6Server, 1, 0, "GET"), true},
+		{"IPv6 from port", tcpPacket(v6Server, v6Client, 1, 0, "HTTP"), true},
+		{"other port", tcpPacket(testClient, other, 1, 0, "GET"), false},
+		{"UDP", udp, false},
+		{"IPv4 fragment", fragment, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Test runs take an Ethernet frame and strip its header, as
+			// packet sockets of type SOCK_DGRAM do.
+			frame := make([]byte, 14, 14+len(tt.pkt))
+			frame[12], frame[13] = 0x08, 0x00
+			if tt.pkt[0]>>4 == 6 {
+				frame[12], frame[13] = 0x86, 0xdd
+			}
+			frame = append(frame, tt.pkt...)
+
+			ret, _, err := prog.Test(frame)
+			if err != nil {
+				t.Skipf("cannot run socket filter: %v", err)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.accept, ret != 0)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !linux
+
+package httpcapture
+
+import "fmt"
+
+// Socket is a stub for non-Linux platforms.
+type Socket struct{}
+
+// Packet is a packet read from a Socket.
+type Packet struct {
+	Data             []byte
+	LoopbackOutgoing bool
+}
+
+// Listen is a stub for non-Linux platforms.
+func Listen(_ uint32, _ uint16) (*Socket, error) {
+	return nil, fmt.Errorf("HTTP capture requires Linux")
+}
+
+// Read is a stub for non-Linux platforms.
+func (s *Socket) Read(_ []byte) (Packet, error) {
+	return Packet{}, fmt.Errorf("HTTP capture requires Linux")
+}
+
+// Close is a stub for non-Linux platforms.
+func (s *Socket) Close() error {
+	return nil
+}
@@ -0,0 +1,89 @@
+package httpcapture
+
+// maxOutOfOrder bounds the segments held back waiting for a missing one.
+// When exceeded, the missing bytes are given up on.
+const maxOutOfOrder = 64
+
+// stream reassembles one direction of a TCP connection into in-order bytes.
+// Retransmitted and duplicate segments, e.g. loopback traffic seen once per
+// direction of the interface, are dropped.
+type stream struct {
+	next    uint32 // Sequence number of the next expected byte.
+	synced  bool
+	pending map[uint32][]byte
+}
+
+// push adds a segment to the stream and returns the bytes that became
+// contiguous. gap is true when bytes were lost and the returned data does
+// not follow the data previously returned.
+func (s *stream) push(seq uint32, flags uint8, payload []byte) (data []byte, gap bool) {
+	if flags&tcpSyn != 0 {
+		// Data carried by the SYN (TCP Fast Open) starts after it.
+		s.next = seq + 1
+		s.synced = true
+		s.pending = nil
+		seq++
+	}
+	if len(payload) == 0 {
+		return nil, false
+	}
+	if !s.synced {
+		// Connection established before the capture started.
+		s.next = seq
+		s.synced = true
+	}
+
+	if ahead := int32(seq - s.next); ahead > 0 {
+		if len(s.pending) < maxOutOfOrder {
+			if s.pending == nil {
+				s.pending = make(map[uint32][]byte)
+			}
+			s.pending[seq] = append([]byte(nil), payload...)
+			return nil, false
+		}
+		// Too much missing data: skip to the earliest segment held back.
+		s.next = s.earliestPending(seq)
+		gap = true
+		if seq != s.next {
+			s.pending[seq] = append([]byte(nil), payload...)
+			payload = nil
+		}
+	}
+
+	data = s.appendInOrder(nil, seq, payload)
+	for progress := true; progress; {
+		progress = false
+		for pseq, p := range s.pending {
+			if int32(pseq-s.next) <= 0 {
+				delete(s.pending, pseq)
+				data = s.appendInOrder(data, pseq, p)
+				progress = true
+			}
+		}
+	}
+	return data, gap
+}
+
+// appendInOrder appends the part of payload, starting at seq, that follows
+// the bytes already returned.
+func (s *stream) appendInOrder(data []byte, seq uint32, payload []byte) []byte {
+	overlap := int64(int32(s.next - seq))
+	if overlap < 0 || overlap >= int64(len(payload)) {
+		return data
+	}
+	payload = payload[overlap:]
+	s.next += uint32(len(payload)) // #nosec G115
+	return append(data, payload...)
+}
+
+// earliestPending returns the lowest sequence number among the held back
+// segments and seq.
+func (s *stream) earliestPending(seq uint32) uint32 {
+	earliest := seq
+	for pseq := range s.pending {
+		if int32(pseq-earliest) < 0 {
+			earliest = pseq
+		}
+	}
+	return earliest
+}
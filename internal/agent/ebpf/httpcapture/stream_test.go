@@ -0,0 +1,78 @@
+package httpcapture
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	t.Run("in order", func(t *testing.T) {
+		var s stream
+		data, gap := s.push(1000, tcpSyn, nil)
+		assert.Empty(t, data)
+		assert.False(t, gap)
+
+		data, _ = s.push(1001, 0, []byte("GET "))
+		assert.Equal(t, "GET ", string(data))
+		data, _ = s.push(1005, 0, []byte("/ HTTP/1.1"))
+		assert.Equal(t, "/ HTTP/1.1", string(data))
+	})
+
+	t.Run("retransmission and duplicates", func(t *testing.T) {
+		var s stream
+		s.push(1000, tcpSyn, nil)
+		s.push(1001, 0, []byte("abcd"))
+
+		data, _ := s.push(1001, 0, []byte("abcd"))
+		assert.Empty(t, data)
+
+		// Partially new data.
+		data, _ = s.push(1003, 0, []byte("cdef"))
+		assert.Equal(t, "ef", string(data))
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		var s stream
+		s.push(1000, tcpSyn, nil)
+
+		data, _ := s.push(1005, 0, []byte("efgh"))
+		assert.Empty(t, data)
+		data, _ = s.push(1001, 0, []byte("abcd"))
+		assert.Equal(t, "abcdefgh", string(data))
+	})
+
+	t.Run("joined mid-stream", func(t *testing.T) {
+		var s stream
+		data, gap := s.push(5000, 0, []byte("xyz"))
+		assert.Equal(t, "xyz", string(data))
+		assert.False(t, gap)
+	})
+
+	t.Run("lost segment", func(t *testing.T) {
+		var s stream
+		s.push(0, tcpSyn, nil)
+
+		// Segment at 1 never arrives.
+		seq := uint32(11)
+		for range maxOutOfOrder {
+			data, _ := s.push(seq, 0, []byte("0123456789"))
+			assert.Empty(t, data)
+			seq += 10
+		}
+
+		data, gap := s.push(seq, 0, []byte("0123456789"))
+		assert.True(t, gap)
+		assert.Len(t, data, (maxOutOfOrder+1)*10)
+	})
+
+	t.Run("sequence wraparound", func(t *testing.T) {
+		var s stream
+		s.push(0xfffffffd, tcpSyn, nil)
+
+		data, _ := s.push(0xfffffffe, 0, []byte("abcd"))
+		assert.Equal(t, "abcd", string(data))
+		data, _ = s.push(2, 0, []byte("ef"))
+		assert.Equal(t, "ef", string(data))
+	})
+}
@@ -0,0 +1,40 @@
+package ebpf
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/iotrace"
+)
+
+// ProfileIO profiles the file reads and writes of the service running pid
+// for duration, by file and device. Like TraceSyscalls, it targets the
+// container or systemd service of pid when it has its own cgroup v2.
+func ProfileIO(ctx context.Context, logger zerolog.Logger, pid uint32, duration time.Duration) (*iotrace.Report, error) {
+	target, err := newServiceTarget(logger, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	probe, err := iotrace.Attach(iotrace.Config{
+		CgroupID:  target.cgroupID,
+		PIDs:      target.pids,
+		Processes: target.processes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach I/O probes: %w", err)
+	}
+	defer probe.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	report, err := probe.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Target = target.description
+	return report, nil
+}
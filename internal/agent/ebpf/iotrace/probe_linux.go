@@ -0,0 +1,616 @@
+//go:build linux
+
+package iotrace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	ciliumebpf "github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/btf"
+	"github.com/cilium/ebpf/link"
+	"golang.org/x/sys/unix"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/runtimetrace"
+)
+
+const (
+	// maxPIDs bounds the processes traced when filtering by PID.
+	maxPIDs = 4096
+
+	// maxInflight bounds the reads and writes tracked between entry and
+	// return. Threads exiting in a call never return, so the least recently
+	// used are evicted.
+	maxInflight = 16384
+
+	// maxFiles bounds the (file, operation) statistics.
+	maxFiles = 16384
+
+	// scanInterval is how often the open files of the traced processes are
+	// listed, to name files that are closed before the trace ends.
+	scanInterval = time.Second
+
+	// File types of inode.i_mode.
+	modeTypeMask = 0o170000
+	modeRegular  = 0o100000
+	modeBlock    = 0o060000
+)
+
+// vfsFunctions are the kernel functions probed, by operation. The vectored
+// ones are optional, since they may be inlined.
+var vfsFunctions = []struct {
+	symbol   string
+	op       Op
+	optional bool
+}{
+	{"vfs_read", OpRead, false},
+	{"vfs_write", OpWrite, false},
+	{"vfs_readv", OpRead, true},
+	{"vfs_writev", OpWrite, true},
+}
+
+// Config selects the processes whose file I/O is profiled.
+type Config struct {
+	// CgroupID selects the processes of a cgroup v2, including those
+	// started during the trace. PIDs is used when it is 0.
+	CgroupID uint64
+
+	// PIDs selects processes by PID (thread group ID).
+	PIDs []uint32
+
+	// Processes lists the traced processes, whose open files name the
+	// inodes of the report.
+	Processes func() []int
+}
+
+// callingConvention locates, in the registers saved at a kernel probe, the
+// first argument and the return value, as offsets in struct pt_regs.
+type callingConvention struct {
+	arg0, ret int16
+}
+
+// conventionFor returns the kernel calling convention of goarch.
+func conventionFor(goarch string) (callingConvention, error) {
+	switch goarch {
+	case "amd64":
+		// RDI for the first argument, RAX for the result.
+		return callingConvention{arg0: 112, ret: 80}, nil
+	case "arm64":
+		// X0 for both.
+		return callingConvention{arg0: 0, ret: 0}, nil
+	default:
+		return callingConvention{}, fmt.Errorf("I/O profiling is not supported on %s", goarch)
+	}
+}
+
+// kernelOffsets are the offsets of the kernel structure fields read by the
+// programs, from the kernel's BTF.
+type kernelOffsets struct {
+	fileInode  int32 // struct file.f_inode
+	inodeMode  int32 // struct inode.i_mode
+	inodeIno   int32 // struct inode.i_ino
+	inodeSb    int32 // struct inode.i_sb
+	superBlock int32 // struct super_block.s_dev
+}
+
+// loadKernelOffsets locates the fields read by the programs in the kernel's
+// BTF.
+func loadKernelOffsets() (kernelOffsets, error) {
+	spec, err := btf.LoadKernelSpec()
+	if err != nil {
+		return kernelOffsets{}, fmt.Errorf("load kernel BTF: %w", err)
+	}
+
+	var errs []error
+	offset := func(structName, member string) int32 {
+		var s *btf.Struct
+		if err := spec.TypeByName(structName, &s); err != nil {
+			errs = append(errs, fmt.Errorf("struct %s: %w", structName, err))
+			return 0
+		}
+		off, ok := memberOffset(s.Members, member)
+		if !ok {
+			errs = append(errs, fmt.Errorf("struct %s has no member %s", structName, member))
+		}
+		return int32(off) // #nosec G115 -- kernel structs are small
+	}
+
+	offsets := kernelOffsets{
+		fileInode:  offset("file", "f_inode"),
+		inodeMode:  offset("inode", "i_mode"),
+		inodeIno:   offset("inode", "i_ino"),
+		inodeSb:    offset("inode", "i_sb"),
+		superBlock: offset("super_block", "s_dev"),
+	}
+	return offsets, errors.Join(errs...)
+}
+
+// memberOffset returns the byte offset of a member, looking into anonymous
+// structs and unions.
+func memberOffset(members []btf.Member, name string) (uint32, bool) {
+	for _, m := range members {
+		if m.Name == name {
+			return m.Offset.Bytes(), true
+		}
+		if m.Name != "" {
+			continue
+		}
+		var nested []btf.Member
+		switch t := btf.UnderlyingType(m.Type).(type) {
+		case *btf.Struct:
+			nested = t.Members
+		case *btf.Union:
+			nested = t.Members
+		}
+		if off, ok := memberOffset(nested, name); ok {
+			return m.Offset.Bytes() + off, true
+		}
+	}
+	return 0, false
+}
+
+// Probe profiles the file I/O of a set of processes.
+type Probe struct {
+	processes func() []int
+
+	files *ciliumebpf.Map
+	hist  *ciliumebpf.Map
+	maps  []*ciliumebpf.Map
+	progs []*ciliumebpf.Program
+	links []link.Link
+}
+
+// Attach loads the profiling programs and attaches them to the kernel's VFS
+// read and write functions.
+func Attach(cfg Config) (*Probe, error) {
+	conv, err := conventionFor(runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CgroupID == 0 && len(cfg.PIDs) == 0 {
+		return nil, fmt.Errorf("a cgroup or PIDs are required")
+	}
+	if len(cfg.PIDs) > maxPIDs {
+		return nil, fmt.Errorf("cannot trace more than %d processes", maxPIDs)
+	}
+	offsets, err := loadKernelOffsets()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Probe{processes: cfg.Processes}
+	if err := p.attach(cfg, conv, offsets); err != nil {
+		_ = p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Probe) attach(cfg Config, conv callingConvention, offsets kernelOffsets) error {
+	var (
+		pids *ciliumebpf.Map
+		err  error
+	)
+	if cfg.CgroupID == 0 {
+		pids, err = p.newMap(&ciliumebpf.MapSpec{
+			Name:       "io_pids",
+			Type:       ciliumebpf.Hash,
+			KeySize:    4,
+			ValueSize:  4,
+			MaxEntries: maxPIDs,
+		})
+		if err != nil {
+			return err
+		}
+		for _, pid := range cfg.PIDs {
+			if err := pids.Put(pid, uint32(1)); err != nil {
+				return fmt.Errorf("select processes: %w", err)
+			}
+		}
+	}
+
+	inflight, err := p.newMap(&ciliumebpf.MapSpec{
+		Name:       "io_inflight",
+		Type:       ciliumebpf.LRUHash,
+		KeySize:    8,
+		ValueSize:  24,
+		MaxEntries: maxInflight,
+	})
+	if err != nil {
+		return err
+	}
+	p.files, err = p.newMap(&ciliumebpf.MapSpec{
+		Name:       "io_files",
+		Type:       ciliumebpf.PerCPUHash,
+		KeySize:    16,
+		ValueSize:  32,
+		MaxEntries: maxFiles,
+	})
+	if err != nil {
+		return err
+	}
+	p.hist, err = p.newMap(&ciliumebpf.MapSpec{
+		Name:       "io_latency",
+		Type:       ciliumebpf.PerCPUArray,
+		KeySize:    4,
+		ValueSize:  8,
+		MaxEntries: uint32(numOps) * runtimetrace.HistogramBuckets,
+	})
+	if err != nil {
+		return err
+	}
+
+	enter := make(map[Op]*ciliumebpf.Program)
+	for _, op := range []Op{OpRead, OpWrite} {
+		prog, err := p.newProgram(fmt.Sprintf("io_enter_%d", op), enterProgram(op, conv, offsets, pids, inflight, cfg.CgroupID))
+		if err != nil {
+			return err
+		}
+		enter[op] = prog
+	}
+	exit, err := p.newProgram("io_exit", exitProgram(conv, inflight, p.files, p.hist))
+	if err != nil {
+		return err
+	}
+
+	for _, fn := range vfsFunctions {
+		// Attach the return probe first, so that no call is timed from its
+		// entry without being counted at its return.
+		ret, err := link.Kretprobe(fn.symbol, exit, nil)
+		if err != nil {
+			if fn.optional {
+				continue
+			}
+			return fmt.Errorf("attach kretprobe %s: %w", fn.symbol, err)
+		}
+		p.links = append(p.links, ret)
+
+		l, err := link.Kprobe(fn.symbol, enter[fn.op], nil)
+		if err != nil {
+			return fmt.Errorf("attach kprobe %s: %w", fn.symbol, err)
+		}
+		p.links = append(p.links, l)
+	}
+	return nil
+}
+
+func (p *Probe) newMap(spec *ciliumebpf.MapSpec) (*ciliumebpf.Map, error) {
+	m, err := ciliumebpf.NewMap(spec)
+	if err != nil {
+		return nil, fmt.Errorf("create %s map: %w", spec.Name, err)
+	}
+	p.maps = append(p.maps, m)
+	return m, nil
+}
+
+func (p *Probe) newProgram(name string, insns asm.Instructions) (*ciliumebpf.Program, error) {
+	prog, err := ciliumebpf.NewProgram(&ciliumebpf.ProgramSpec{
+		Name:         name,
+		Type:         ciliumebpf.Kprobe,
+		Instructions: insns,
+		License:      "GPL",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load %s program: %w", name, err)
+	}
+	p.progs = append(p.progs, prog)
+	return prog, nil
+}
+
+// Collect profiles file I/O until ctx is done and returns the report.
+func (p *Probe) Collect(ctx context.Context) (*Report, error) {
+	report := &Report{Start: time.Now()}
+	paths := make(map[inodeKey]string)
+
+	ticker := time.NewTicker(scanInterval)
+	defer ticker.Stop()
+	p.scanOpenFiles(paths)
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			done = true
+		case <-ticker.C:
+			p.scanOpenFiles(paths)
+		}
+	}
+	report.End = time.Now()
+	p.scanOpenFiles(paths)
+
+	stats := make(map[fileKey][]fileValue)
+	var (
+		key    fileKey
+		values []fileValue
+	)
+	iter := p.files.Iterate()
+	for iter.Next(&key, &values) {
+		stats[key] = values
+		values = nil
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("read file statistics: %w", err)
+	}
+
+	for i := 0; i < int(numOps)*runtimetrace.HistogramBuckets; i++ {
+		var counts []uint64
+		if err := p.hist.Lookup(uint32(i), &counts); err != nil { // #nosec G115 -- i is small
+			return nil, fmt.Errorf("read latency histogram: %w", err)
+		}
+		hist := &report.ReadLatency
+		if Op(i/runtimetrace.HistogramBuckets) == OpWrite { // #nosec G115
+			hist = &report.WriteLatency
+		}
+		for _, c := range counts {
+			hist[i%runtimetrace.HistogramBuckets] += c
+		}
+	}
+
+	var mounts map[Device]mount
+	if pids := p.listProcesses(); len(pids) > 0 {
+		mounts = readMounts(pids[0])
+	}
+	report.Files, report.Devices = aggregate(stats, paths, mounts)
+	return report, nil
+}
+
+func (p *Probe) listProcesses() []int {
+	if p.processes == nil {
+		return nil
+	}
+	return p.processes()
+}
+
+// scanOpenFiles names the inodes of the files the traced processes hold
+// open. Sockets, pipes and other files without a path are skipped.
+func (p *Probe) scanOpenFiles(paths map[inodeKey]string) {
+	for _, pid := range p.listProcesses() {
+		dir := fmt.Sprintf("/proc/%d/fd", pid)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue // Exited, or not ours to read.
+		}
+		for _, entry := range entries {
+			fd := filepath.Join(dir, entry.Name())
+			path, err := os.Readlink(fd)
+			if err != nil || !strings.HasPrefix(path, "/") {
+				continue
+			}
+			var st unix.Stat_t
+			if err := unix.Stat(fd, &st); err != nil {
+				continue
+			}
+			key := inodeKey{dev: Device{Major: unix.Major(st.Dev), Minor: unix.Minor(st.Dev)}, ino: st.Ino}
+			if _, ok := paths[key]; !ok {
+				paths[key] = path
+			}
+		}
+	}
+}
+
+// readMounts reads the mount table of a process, in its mount namespace.
+func readMounts(pid int) map[Device]mount {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/mountinfo", pid))
+	if err != nil {
+		return nil
+	}
+	defer f.Close() // nolint:errcheck
+
+	mounts, err := parseMountInfo(f)
+	if err != nil {
+		return nil
+	}
+	return mounts
+}
+
+// Close detaches the programs and releases their resources.
+func (p *Probe) Close() error {
+	var errs []error
+	for _, l := range p.links {
+		errs = append(errs, l.Close())
+	}
+	for _, prog := range p.progs {
+		errs = append(errs, prog.Close())
+	}
+	for _, m := range p.maps {
+		errs = append(errs, m.Close())
+	}
+	p.links, p.progs, p.maps = nil, nil, nil
+	return errors.Join(errs...)
+}
+
+// enterProgram records a read or write of a regular file or block device by
+// a selected process: inflight[pid_tgid] = {ino, dev, op, ts}. The file is
+// the first argument of the probed functions.
+func enterProgram(op Op, conv callingConvention, offsets kernelOffsets, pids, inflight *ciliumebpf.Map, cgroupID uint64) asm.Instructions {
+	// readKernel copies size bytes at R7+off to the stack at dst.
+	readKernel := func(dst int16, size int32, off int32) asm.Instructions {
+		return asm.Instructions{
+			asm.Mov.Reg(asm.R1, asm.RFP),
+			asm.Add.Imm(asm.R1, int32(dst)),
+			asm.Mov.Imm(asm.R2, size),
+			asm.Mov.Reg(asm.R3, asm.R7),
+			asm.Add.Imm(asm.R3, off),
+			asm.FnProbeReadKernel.Call(),
+			asm.JNE.Imm(asm.R0, 0, "exit"),
+		}
+	}
+
+	insns := asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+	}
+	if cgroupID != 0 {
+		insns = append(insns,
+			asm.FnGetCurrentCgroupId.Call(),
+			asm.LoadImm(asm.R1, int64(cgroupID), asm.DWord), // #nosec G115 -- cgroup IDs are inode numbers
+			asm.JNE.Reg(asm.R0, asm.R1, "exit"),
+		)
+	} else {
+		insns = append(insns,
+			asm.FnGetCurrentPidTgid.Call(),
+			asm.RSh.Imm(asm.R0, 32),
+			asm.StoreMem(asm.RFP, -8, asm.R0, asm.Word),
+			asm.LoadMapPtr(asm.R1, pids.FD()),
+			asm.Mov.Reg(asm.R2, asm.RFP),
+			asm.Add.Imm(asm.R2, -8),
+			asm.FnMapLookupElem.Call(),
+			asm.JEq.Imm(asm.R0, 0, "exit"),
+		)
+	}
+
+	// R7 = file->f_inode.
+	insns = append(insns, asm.LoadMem(asm.R7, asm.R6, conv.arg0, asm.DWord))
+	insns = append(insns, readKernel(-16, 8, offsets.fileInode)...)
+	insns = append(insns,
+		asm.LoadMem(asm.R7, asm.RFP, -16, asm.DWord),
+		asm.JEq.Imm(asm.R7, 0, "exit"),
+	)
+
+	// Only regular files and block devices.
+	insns = append(insns, readKernel(-16, 2, offsets.inodeMode)...)
+	insns = append(insns,
+		asm.LoadMem(asm.R1, asm.RFP, -16, asm.Half),
+		asm.And.Imm(asm.R1, modeTypeMask),
+		asm.JEq.Imm(asm.R1, modeRegular, "file"),
+		asm.JNE.Imm(asm.R1, modeBlock, "exit"),
+	)
+
+	// Value at fp-48: ino, dev = inode->i_sb->s_dev, op, ts.
+	read := readKernel(-48, 8, offsets.inodeIno)
+	read[0] = read[0].WithSymbol("file")
+	insns = append(insns, read...)
+	insns = append(insns, readKernel(-16, 8, offsets.inodeSb)...)
+	insns = append(insns,
+		asm.LoadMem(asm.R7, asm.RFP, -16, asm.DWord),
+		asm.JEq.Imm(asm.R7, 0, "exit"),
+	)
+	insns = append(insns, readKernel(-40, 4, offsets.superBlock)...)
+
+	return append(insns,
+		asm.StoreImm(asm.RFP, -36, int64(op), asm.Word),
+		asm.FnKtimeGetNs.Call(),
+		asm.StoreMem(asm.RFP, -32, asm.R0, asm.DWord),
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.StoreMem(asm.RFP, -16, asm.R0, asm.DWord),
+
+		asm.LoadMapPtr(asm.R1, inflight.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -16),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, -48),
+		asm.Mov.Imm(asm.R4, 0), // BPF_ANY
+		asm.FnMapUpdateElem.Call(),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("exit"),
+		asm.Return(),
+	)
+}
+
+// exitProgram adds a read or write timed by enterProgram to the statistics
+// of its file and to the latency histogram of its operation. Failed calls
+// are not counted. The bytes transferred are the return value.
+func exitProgram(conv callingConvention, inflight, files, hist *ciliumebpf.Map) asm.Instructions {
+	return asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.StoreMem(asm.RFP, -8, asm.R0, asm.DWord),
+
+		// Copy the file key {ino, dev, op} to fp-48 and forget the call.
+		asm.LoadMapPtr(asm.R1, inflight.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.DWord),
+		asm.StoreMem(asm.RFP, -48, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R1, asm.R0, 8, asm.DWord),
+		asm.StoreMem(asm.RFP, -40, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R7, asm.R0, 16, asm.DWord),
+		asm.LoadMapPtr(asm.R1, inflight.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapDeleteElem.Call(),
+
+		// R8 = bytes, R7 = latency.
+		asm.LoadMem(asm.R8, asm.R6, conv.ret, asm.DWord),
+		asm.JSLT.Imm(asm.R8, 0, "exit"),
+		asm.FnKtimeGetNs.Call(),
+		asm.Sub.Reg(asm.R0, asm.R7),
+		asm.Mov.Reg(asm.R7, asm.R0),
+
+		asm.LoadMapPtr(asm.R1, files.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -48),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "new"),
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.DWord),
+		asm.Add.Imm(asm.R1, 1),
+		asm.StoreMem(asm.R0, 0, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R1, asm.R0, 8, asm.DWord),
+		asm.Add.Reg(asm.R1, asm.R8),
+		asm.StoreMem(asm.R0, 8, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R1, asm.R0, 16, asm.DWord),
+		asm.Add.Reg(asm.R1, asm.R7),
+		asm.StoreMem(asm.R0, 16, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R1, asm.R0, 24, asm.DWord),
+		asm.JGE.Reg(asm.R1, asm.R7, "histogram"),
+		asm.StoreMem(asm.R0, 24, asm.R7, asm.DWord),
+		asm.Ja.Label("histogram"),
+
+		asm.StoreImm(asm.RFP, -80, 1, asm.DWord).WithSymbol("new"),
+		asm.StoreMem(asm.RFP, -72, asm.R8, asm.DWord),
+		asm.StoreMem(asm.RFP, -64, asm.R7, asm.DWord),
+		asm.StoreMem(asm.RFP, -56, asm.R7, asm.DWord),
+		asm.LoadMapPtr(asm.R1, files.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -48),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, -80),
+		asm.Mov.Imm(asm.R4, 1), // BPF_NOEXIST
+		asm.FnMapUpdateElem.Call(),
+
+		// R2 = log2(R7), by halving.
+		asm.Mov.Reg(asm.R1, asm.R7).WithSymbol("histogram"),
+		asm.Mov.Imm(asm.R2, 0),
+		asm.LoadImm(asm.R3, 0xffffffff, asm.DWord),
+		asm.JLE.Reg(asm.R1, asm.R3, "le32"),
+		asm.RSh.Imm(asm.R1, 32),
+		asm.Add.Imm(asm.R2, 32),
+		asm.JLE.Imm(asm.R1, 0xffff, "le16").WithSymbol("le32"),
+		asm.RSh.Imm(asm.R1, 16),
+		asm.Add.Imm(asm.R2, 16),
+		asm.JLE.Imm(asm.R1, 0xff, "le8").WithSymbol("le16"),
+		asm.RSh.Imm(asm.R1, 8),
+		asm.Add.Imm(asm.R2, 8),
+		asm.JLE.Imm(asm.R1, 0xf, "le4").WithSymbol("le8"),
+		asm.RSh.Imm(asm.R1, 4),
+		asm.Add.Imm(asm.R2, 4),
+		asm.JLE.Imm(asm.R1, 0x3, "le2").WithSymbol("le4"),
+		asm.RSh.Imm(asm.R1, 2),
+		asm.Add.Imm(asm.R2, 2),
+		asm.JLE.Imm(asm.R1, 0x1, "bucket").WithSymbol("le2"),
+		asm.Add.Imm(asm.R2, 1),
+
+		// hist[op*HistogramBuckets + bucket]++.
+		asm.LoadMem(asm.R1, asm.RFP, -36, asm.Word).WithSymbol("bucket"),
+		asm.LSh.Imm(asm.R1, 6),
+		asm.Add.Reg(asm.R1, asm.R2),
+		asm.StoreMem(asm.RFP, -4, asm.R1, asm.Word),
+		asm.LoadMapPtr(asm.R1, hist.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -4),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.DWord),
+		asm.Add.Imm(asm.R1, 1),
+		asm.StoreMem(asm.R0, 0, asm.R1, asm.DWord),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("exit"),
+		asm.Return(),
+	}
+}
@@ -0,0 +1,80 @@
+//go:build linux
+
+package iotrace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollect(t *testing.T) {
+	pid := os.Getpid()
+	probe, err := Attach(Config{
+		PIDs:      []uint32{uint32(pid)}, // #nosec G115
+		Processes: func() []int { return []int{pid} },
+	})
+	if err != nil {
+		t.Skipf("cannot attach I/O probes: %v", err)
+	}
+	defer probe.Close() // nolint:errcheck
+
+	path := filepath.Join(t.TempDir(), "data.bin")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	chunk := make([]byte, 4096)
+	go func() {
+		for i := 0; i < 10; i++ {
+			_, _ = f.Write(chunk)
+		}
+		_, _ = f.ReadAt(chunk, 0)
+	}()
+
+	report, err := probe.Collect(ctx)
+	require.NoError(t, err)
+
+	var file *File
+	for i := range report.Files {
+		if report.Files[i].Path == path {
+			file = &report.Files[i]
+		}
+	}
+	require.NotNil(t, file, "the written file is named from its open descriptor: %+v", report.Files)
+	assert.Equal(t, uint64(10), file.Writes)
+	assert.Equal(t, uint64(10*4096), file.WriteBytes)
+	assert.Equal(t, uint64(1), file.Reads)
+	assert.Positive(t, file.WriteTime)
+	assert.GreaterOrEqual(t, report.WriteLatency.Count(), uint64(10))
+
+	require.NotEmpty(t, report.Devices)
+	var device *DeviceStats
+	for i := range report.Devices {
+		if report.Devices[i].Device == file.Device {
+			device = &report.Devices[i]
+		}
+	}
+	require.NotNil(t, device)
+	assert.GreaterOrEqual(t, device.WriteBytes, file.WriteBytes)
+}
+
+func TestLoadKernelOffsets(t *testing.T) {
+	if _, err := os.Stat("/sys/kernel/btf/vmlinux"); err != nil {
+		t.Skip("kernel BTF is not available")
+	}
+
+	offsets, err := loadKernelOffsets()
+	require.NoError(t, err)
+	assert.NotEqual(t, offsets.inodeIno, offsets.inodeSb)
+	assert.Positive(t, offsets.fileInode)
+	assert.Positive(t, offsets.superBlock)
+}
@@ -0,0 +1,33 @@
+//go:build !linux
+
+package iotrace
+
+import (
+	"context"
+	"fmt"
+)
+
+// Config selects the processes whose file I/O is profiled.
+type Config struct {
+	CgroupID  uint64
+	PIDs      []uint32
+	Processes func() []int
+}
+
+// Probe is a stub for non-Linux platforms.
+type Probe struct{}
+
+// Attach is a stub for non-Linux platforms.
+func Attach(_ Config) (*Probe, error) {
+	return nil, fmt.Errorf("I/O profiling requires Linux")
+}
+
+// Collect is a stub for non-Linux platforms.
+func (p *Probe) Collect(_ context.Context) (*Report, error) {
+	return nil, fmt.Errorf("I/O profiling requires Linux")
+}
+
+// Close is a stub for non-Linux platforms.
+func (p *Probe) Close() error {
+	return nil
+}
@@ -0,0 +1,243 @@
+// Package iotrace profiles the file I/O of a service with kprobes on the
+// kernel's VFS read and write functions.
+//
+// Reads and writes of regular files and block devices by the processes of
+// the service are timed and aggregated in the kernel by file (device and
+// inode). Inodes are named after the fact from the file descriptors the
+// processes hold open, and devices from their mount table.
+package iotrace
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/runtimetrace"
+)
+
+// Op is a VFS operation.
+type Op uint32
+
+const (
+	OpRead Op = iota
+	OpWrite
+
+	numOps
+)
+
+// fileKey is the key of the file statistics map.
+type fileKey struct {
+	Ino uint64
+	Dev uint32 // Kernel encoding, see devMajor and devMinor.
+	Op  Op
+}
+
+// fileValue aggregates the operations of a fileKey.
+type fileValue struct {
+	Count uint64
+	Bytes uint64
+	SumNs uint64
+	MaxNs uint64
+}
+
+// devMajor and devMinor decode a dev_t as the kernel stores it in
+// super_block.s_dev (MINORBITS = 20).
+func devMajor(dev uint32) uint32 { return dev >> 20 }
+func devMinor(dev uint32) uint32 { return dev & (1<<20 - 1) }
+
+// Stats aggregates the reads and writes of a file or device.
+type Stats struct {
+	Reads, Writes         uint64
+	ReadBytes, WriteBytes uint64
+	ReadTime, WriteTime   time.Duration
+	MaxLatency            time.Duration
+}
+
+// Time returns the time spent reading and writing.
+func (s *Stats) Time() time.Duration {
+	return s.ReadTime + s.WriteTime
+}
+
+// Bytes returns the bytes read and written.
+func (s *Stats) Bytes() uint64 {
+	return s.ReadBytes + s.WriteBytes
+}
+
+func (s *Stats) add(op Op, v fileValue) {
+	switch op {
+	case OpRead:
+		s.Reads += v.Count
+		s.ReadBytes += v.Bytes
+		s.ReadTime += time.Duration(v.SumNs) // #nosec G115 -- sums of latencies fit
+	case OpWrite:
+		s.Writes += v.Count
+		s.WriteBytes += v.Bytes
+		s.WriteTime += time.Duration(v.SumNs) // #nosec G115
+	}
+	s.MaxLatency = max(s.MaxLatency, time.Duration(v.MaxNs)) // #nosec G115
+}
+
+func (s *Stats) merge(o Stats) {
+	s.Reads += o.Reads
+	s.Writes += o.Writes
+	s.ReadBytes += o.ReadBytes
+	s.WriteBytes += o.WriteBytes
+	s.ReadTime += o.ReadTime
+	s.WriteTime += o.WriteTime
+	s.MaxLatency = max(s.MaxLatency, o.MaxLatency)
+}
+
+// Device identifies a block device or filesystem by its major and minor
+// numbers.
+type Device struct {
+	Major, Minor uint32
+}
+
+func (d Device) String() string {
+	return fmt.Sprintf("%d:%d", d.Major, d.Minor)
+}
+
+// File is the I/O of the traced processes on one file.
+type File struct {
+	Device Device
+	Inode  uint64
+
+	// Path is the file as opened by a traced process, or empty if no traced
+	// process held it open when the open files were listed.
+	Path string
+
+	Stats
+}
+
+// DeviceStats is the I/O of the traced processes on one device.
+type DeviceStats struct {
+	Device Device
+
+	// Source, FSType and MountPoint describe the first mount of the device
+	// in the mount table of the traced processes, if any.
+	Source, FSType, MountPoint string
+
+	Stats
+}
+
+// Report is the file I/O of the traced processes during a trace.
+type Report struct {
+	Start, End time.Time
+
+	// Target describes the traced processes, e.g. their cgroup.
+	Target string
+
+	// Files and Devices are sorted by I/O time, longest first.
+	Files   []File
+	Devices []DeviceStats
+
+	ReadLatency, WriteLatency runtimetrace.Histogram
+}
+
+// inodeKey identifies a file across devices.
+type inodeKey struct {
+	dev Device
+	ino uint64
+}
+
+// mount is a line of a mount table.
+type mount struct {
+	source, fsType, mountPoint string
+}
+
+// aggregate merges the per-CPU file statistics of the kernel into files and
+// devices, naming them with paths and mounts.
+func aggregate(stats map[fileKey][]fileValue, paths map[inodeKey]string, mounts map[Device]mount) ([]File, []DeviceStats) {
+	files := make(map[inodeKey]*File)
+	for key, values := range stats {
+		dev := Device{Major: devMajor(key.Dev), Minor: devMinor(key.Dev)}
+		ik := inodeKey{dev: dev, ino: key.Ino}
+		f := files[ik]
+		if f == nil {
+			f = &File{Device: dev, Inode: key.Ino, Path: paths[ik]}
+			files[ik] = f
+		}
+
+		var sum fileValue
+		for _, v := range values {
+			sum.Count += v.Count
+			sum.Bytes += v.Bytes
+			sum.SumNs += v.SumNs
+			sum.MaxNs = max(sum.MaxNs, v.MaxNs)
+		}
+		f.add(key.Op, sum)
+	}
+
+	devices := make(map[Device]*DeviceStats)
+	result := make([]File, 0, len(files))
+	for _, f := range files {
+		result = append(result, *f)
+
+		d := devices[f.Device]
+		if d == nil {
+			m := mounts[f.Device]
+			d = &DeviceStats{Device: f.Device, Source: m.source, FSType: m.fsType, MountPoint: m.mountPoint}
+			devices[f.Device] = d
+		}
+		d.merge(f.Stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Time() != result[j].Time() {
+			return result[i].Time() > result[j].Time()
+		}
+		return result[i].Inode < result[j].Inode
+	})
+
+	devs := make([]DeviceStats, 0, len(devices))
+	for _, d := range devices {
+		devs = append(devs, *d)
+	}
+	sort.Slice(devs, func(i, j int) bool {
+		if devs[i].Time() != devs[j].Time() {
+			return devs[i].Time() > devs[j].Time()
+		}
+		return devs[i].Device.String() < devs[j].Device.String()
+	})
+	return result, devs
+}
+
+// parseMountInfo reads the first mount of each device from a
+// /proc/PID/mountinfo table.
+func parseMountInfo(r io.Reader) (map[Device]mount, error) {
+	mounts := make(map[Device]mount)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// Format: id parent major:minor root mount-point options [optional...] - fstype source super-options.
+		fields := strings.Fields(scanner.Text())
+		sep := -1
+		for i, f := range fields {
+			if f == "-" {
+				sep = i
+				break
+			}
+		}
+		if sep < 5 || len(fields) < sep+3 {
+			continue
+		}
+
+		major, minor, ok := strings.Cut(fields[2], ":")
+		if !ok {
+			continue
+		}
+		maj, err1 := strconv.ParseUint(major, 10, 32)
+		mnr, err2 := strconv.ParseUint(minor, 10, 32)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+
+		dev := Device{Major: uint32(maj), Minor: uint32(mnr)}
+		if _, ok := mounts[dev]; !ok {
+			mounts[dev] = mount{source: fields[sep+2], fsType: fields[sep+1], mountPoint: fields[4]}
+		}
+	}
+	return mounts, scanner.Err()
+}
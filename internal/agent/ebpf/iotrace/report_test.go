@@ -0,0 +1,79 @@
+package iotrace
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregate(t *testing.T) {
+	sda1 := uint32(8<<20 | 1)
+	nvme := uint32(259<<20 | 2)
+
+	stats := map[fileKey][]fileValue{
+		// A log written on two CPUs.
+		{Ino: 100, Dev: sda1, Op: OpWrite}: {
+			{Count: 10, Bytes: 40960, SumNs: 1_000_000, MaxNs: 300_000},
+			{Count: 5, Bytes: 20480, SumNs: 500_000, MaxNs: 400_000},
+		},
+		// A database file read and written.
+		{Ino: 7, Dev: nvme, Op: OpRead}:  {{Count: 100, Bytes: 819200, SumNs: 9_000_000, MaxNs: 2_000_000}},
+		{Ino: 7, Dev: nvme, Op: OpWrite}: {{Count: 3, Bytes: 24576, SumNs: 600_000, MaxNs: 250_000}},
+		// A closed file, not named.
+		{Ino: 101, Dev: sda1, Op: OpRead}: {{Count: 1, Bytes: 10, SumNs: 1_000, MaxNs: 1_000}},
+	}
+	paths := map[inodeKey]string{
+		{dev: Device{8, 1}, ino: 100}: "/var/log/api.log",
+		{dev: Device{259, 2}, ino: 7}: "/data/app.db",
+	}
+	mounts := map[Device]mount{
+		{259, 2}: {source: "/dev/nvme0n1p2", fsType: "ext4", mountPoint: "/data"},
+	}
+
+	files, devices := aggregate(stats, paths, mounts)
+	require.Len(t, files, 3)
+
+	db := files[0]
+	assert.Equal(t, "/data/app.db", db.Path)
+	assert.Equal(t, uint64(100), db.Reads)
+	assert.Equal(t, uint64(3), db.Writes)
+	assert.Equal(t, uint64(819200+24576), db.Bytes())
+	assert.Equal(t, 9600*time.Microsecond, db.Time())
+	assert.Equal(t, 2*time.Millisecond, db.MaxLatency)
+
+	log := files[1]
+	assert.Equal(t, "/var/log/api.log", log.Path)
+	assert.Equal(t, uint64(15), log.Writes)
+	assert.Equal(t, uint64(61440), log.WriteBytes)
+	assert.Equal(t, 1500*time.Microsecond, log.WriteTime)
+	assert.Equal(t, 400*time.Microsecond, log.MaxLatency, "max across CPUs")
+
+	assert.Empty(t, files[2].Path)
+	assert.Equal(t, uint64(101), files[2].Inode)
+
+	require.Len(t, devices, 2)
+	assert.Equal(t, Device{259, 2}, devices[0].Device)
+	assert.Equal(t, "/dev/nvme0n1p2", devices[0].Source)
+	assert.Equal(t, "/data", devices[0].MountPoint)
+	assert.Equal(t, Device{8, 1}, devices[1].Device)
+	assert.Equal(t, uint64(15), devices[1].Writes)
+	assert.Equal(t, uint64(1), devices[1].Reads)
+	assert.Empty(t, devices[1].Source, "unmounted in the traced processes")
+}
+
+func TestParseMountInfo(t *testing.T) {
+	table := `22 1 259:2 / / rw,relatime shared:1 - ext4 /dev/nvme0n1p2 rw
+23 22 0:21 / /proc rw,nosuid shared:12 - proc proc rw
+24 22 259:2 /var/lib/app /srv rw,relatime - ext4 /dev/nvme0n1p2 rw
+25 22 0:45 / /run rw master:3 shared:4 - tmpfs tmpfs rw,size=1024k
+malformed line
+`
+	mounts, err := parseMountInfo(strings.NewReader(table))
+	require.NoError(t, err)
+	assert.Equal(t, mount{source: "/dev/nvme0n1p2", fsType: "ext4", mountPoint: "/"}, mounts[Device{259, 2}], "first mount wins")
+	assert.Equal(t, mount{source: "tmpfs", fsType: "tmpfs", mountPoint: "/run"}, mounts[Device{0, 45}])
+	assert.Len(t, mounts, 3)
+}
@@ -0,0 +1,39 @@
+package ebpf
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/futextrace"
+)
+
+// ProfileLocks times the futex waits of the service running pid for
+// duration, by process and user stack. The service is targeted as by
+// TraceSyscalls.
+func ProfileLocks(ctx context.Context, logger zerolog.Logger, pid uint32, duration time.Duration) (*futextrace.Report, error) {
+	target, err := newServiceTarget(logger, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	probe, err := futextrace.Attach(futextrace.Config{
+		CgroupID: target.cgroupID,
+		PIDs:     target.pids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach futex probes: %w", err)
+	}
+	defer probe.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	report, err := probe.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Target = target.description
+	return report, nil
+}
@@ -2,6 +2,7 @@ package ebpf
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -12,22 +13,46 @@ import (
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/agent/redact"
 )
 
+// ErrCollectorNotFound is returned when a collector ID is not tracked by the
+// manager, e.g. because the agent restarted since the collector was started.
+var ErrCollectorNotFound = errors.New("collector not found")
+
+// errTLSCaptureDisabled is returned for TLS capture collectors unless the
+// agent opted in.
+var errTLSCaptureDisabled = errors.New("TLS capture is disabled on this agent (set debug.tls_capture.enabled)")
+
 // EventSubscriber is a callback invoked with new UprobeEvents when GetEvents
 // is called. Used by the correlation engine (RFD 091) to receive events in
 // near-real-time without requiring a separate polling goroutine.
 type EventSubscriber func(events []*meshv1.EbpfEvent)
 
+// UprobeEventSink is invoked with every uprobe event of a colony debug
+// session as soon as it is captured. It must not block.
+type UprobeEventSink func(sessionID string, event *agentv1.UprobeEvent)
+
 // Manager handles eBPF collector lifecycle.
 type Manager struct {
 	logger     zerolog.Logger
 	collectors map[string]*runningCollector
 	mu         sync.RWMutex
 	caps       *agentv1.EbpfCapabilities
+	features   *KernelFeatures
+	eventStore *EventStore
+	redactor   *redact.Engine
+	// tlsCapture allows TLS plaintext capture collectors.
+	tlsCapture bool
+	// sampleDivisor reduces uprobe sampling while the agent sheds load.
+	sampleDivisor uint32
 	// subscriber is an optional callback invoked when GetEvents returns events.
 	subscriber EventSubscriber
-	subMu      sync.RWMutex
+	// sink is an optional callback invoked with every captured uprobe event.
+	sink  UprobeEventSink
+	subMu sync.RWMutex
+	// failures records when collectors failed to load or attach.
+	failures []time.Time
 }
 
 // runningCollector tracks a single active collector instance.
@@ -45,16 +70,49 @@ type runningCollector struct {
 // Config contains manager configuration.
 type Config struct {
 	Logger zerolog.Logger
+
+	// BTFPath is an optional external vmlinux BTF file used for CO-RE
+	// relocations on kernels without /sys/kernel/btf/vmlinux.
+	BTFPath string
+
+	// EventStore optionally persists uprobe events to local disk so they
+	// survive agent restarts and colony outages.
+	EventStore *EventStore
+
+	// Redactor optionally masks credentials and personal data in uprobe
+	// events as they are captured.
+	Redactor *redact.Engine
+
+	// TLSCapture allows collectors capturing the plaintext of TLS
+	// connections. It is off unless enabled in the agent configuration.
+	TLSCapture bool
 }
 
 // NewManager creates a new eBPF manager.
+// Kernel features are probed once at startup and used to select compatible
+// program variants for every collector.
 func NewManager(config Config) *Manager {
-	caps := detectCapabilities()
+	feats := ProbeKernelFeatures(config.BTFPath)
+	caps := detectCapabilities(feats)
 
 	m := &Manager{
 		logger:     config.Logger.With().Str("component", "ebpf_manager").Logger(),
 		collectors: make(map[string]*runningCollector),
 		caps:       caps,
+		features:   feats,
+		eventStore: config.EventStore,
+		redactor:   config.Redactor,
+		tlsCapture: config.TLSCapture,
+	}
+
+	m.logger.Info().
+		Str("kernel", feats.KernelVersion).
+		Str("event_transport", string(feats.EventTransport())).
+		Str("btf_source", feats.BTFSource).
+		Bool("bpf_loop", feats.BPFLoop).
+		Msg("Probed kernel eBPF features")
+	for _, w := range feats.Warnings() {
+		m.logger.Warn().Msg(w)
 	}
 
 	// Start background janitor to clean up expired collectors.
@@ -69,6 +127,16 @@ func (m *Manager) GetCapabilities() *agentv1.EbpfCapabilities {
 	return m.caps
 }
 
+// KernelFeatures returns the kernel features probed at startup.
+func (m *Manager) KernelFeatures() *KernelFeatures {
+	return m.features
+}
+
+// EventStore returns the local event store, or nil if persistence is disabled.
+func (m *Manager) EventStore() *EventStore {
+	return m.eventStore
+}
+
 // StartCollector starts a new eBPF collector.
 func (m *Manager) StartCollector(ctx context.Context, req *meshv1.StartEbpfCollectorRequest) (*meshv1.StartEbpfCollectorResponse, error) {
 	m.mu.Lock()
@@ -76,9 +144,13 @@ func (m *Manager) StartCollector(ctx context.Context, req *meshv1.StartEbpfColle
 
 	// Check if eBPF is supported.
 	if !m.caps.Supported {
+		errMsg := "eBPF not supported on this system"
+		if warnings := m.features.Warnings(); len(warnings) > 0 {
+			errMsg = fmt.Sprintf("%s: %s", errMsg, warnings[0])
+		}
 		return &meshv1.StartEbpfCollectorResponse{
 			Supported: false,
-			Error:     "eBPF not supported on this system",
+			Error:     errMsg,
 		}, nil
 	}
 
@@ -94,8 +166,9 @@ func (m *Manager) StartCollector(ctx context.Context, req *meshv1.StartEbpfColle
 	collectorID := uuid.New().String()
 
 	// Create collector based on kind.
-	collector, err := m.createCollector(req.Kind, req.Config)
+	collector, err := m.createCollector(collectorID, req.Kind, req.Config)
 	if err != nil {
+		m.recordFailure()
 		return &meshv1.StartEbpfCollectorResponse{
 			Supported: true,
 			Error:     fmt.Sprintf("failed to create collector: %v", err),
@@ -114,6 +187,7 @@ func (m *Manager) StartCollector(ctx context.Context, req *meshv1.StartEbpfColle
 	// Start collector.
 	if err := collector.Start(collectorCtx); err != nil {
 		cancel()
+		m.recordFailure()
 		return &meshv1.StartEbpfCollectorResponse{
 			Supported: true,
 			Error:     fmt.Sprintf("failed to start collector: %v", err),
@@ -155,6 +229,36 @@ func (m *Manager) StartCollector(ctx context.Context, req *meshv1.StartEbpfColle
 	}, nil
 }
 
+// recordFailure records a collector that failed to load or attach.
+// Caller must hold m.mu.
+func (m *Manager) recordFailure() {
+	m.failures = append(m.failures, time.Now())
+}
+
+// RecentFailures returns the number of collectors that failed to load or
+// attach within the window, and forgets older failures.
+func (m *Manager) RecentFailures(window time.Duration) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cutoff := time.Now().Add(-window)
+	recent := m.failures[:0]
+	for _, t := range m.failures {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	m.failures = recent
+	return len(recent)
+}
+
+// ActiveCollectors returns the number of running collectors.
+func (m *Manager) ActiveCollectors() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.collectors)
+}
+
 // StopCollector stops a running collector.
 func (m *Manager) StopCollector(collectorID string) error {
 	m.mu.Lock()
@@ -162,7 +266,7 @@ func (m *Manager) StopCollector(collectorID string) error {
 
 	running, ok := m.collectors[collectorID]
 	if !ok {
-		return fmt.Errorf("collector not found: %s", collectorID)
+		return fmt.Errorf("%w: %s", ErrCollectorNotFound, collectorID)
 	}
 
 	// Stop collector.
@@ -188,6 +292,21 @@ func (m *Manager) SetEventSubscriber(sub EventSubscriber) {
 	m.subMu.Unlock()
 }
 
+// SetUprobeEventSink registers a callback to receive uprobe events of colony
+// debug sessions as they are captured. Pass nil to unregister.
+func (m *Manager) SetUprobeEventSink(sink UprobeEventSink) {
+	m.subMu.Lock()
+	m.sink = sink
+	m.subMu.Unlock()
+}
+
+// uprobeEventSink returns the registered uprobe event sink, if any.
+func (m *Manager) uprobeEventSink() UprobeEventSink {
+	m.subMu.RLock()
+	defer m.subMu.RUnlock()
+	return m.sink
+}
+
 // GetEvents retrieves events from a running collector.
 func (m *Manager) GetEvents(collectorID string) ([]*meshv1.EbpfEvent, error) {
 	m.mu.RLock()
@@ -213,7 +332,7 @@ func (m *Manager) GetEvents(collectorID string) ([]*meshv1.EbpfEvent, error) {
 			Str("collector_id", collectorID).
 			Strs("available_collectors", collectorIDs).
 			Msg("Collector not found in tracking map")
-		return nil, fmt.Errorf("collector not found: %s", collectorID)
+		return nil, fmt.Errorf("%w: %s", ErrCollectorNotFound, collectorID)
 	}
 
 	events, err := running.collector.GetEvents()
@@ -236,7 +355,7 @@ func (m *Manager) UpdateFilter(collectorID string, filter UprobeFilter) error {
 	m.mu.RUnlock()
 
 	if !ok {
-		return fmt.Errorf("collector not found: %s", collectorID)
+		return fmt.Errorf("%w: %s", ErrCollectorNotFound, collectorID)
 	}
 
 	uc, ok := running.collector.(*UprobeCollector)
@@ -247,6 +366,25 @@ func (m *Manager) UpdateFilter(collectorID string, filter UprobeFilter) error {
 	return uc.UpdateFilter(filter)
 }
 
+// SetUprobeSampleDivisor reduces event sampling of all running and future
+// uprobe collectors by divisor, on top of their own filters. The agent uses
+// it to shed load; 0 or 1 restores the requested sampling.
+func (m *Manager) SetUprobeSampleDivisor(divisor uint32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sampleDivisor = divisor
+	for id, running := range m.collectors {
+		uc, ok := running.collector.(*UprobeCollector)
+		if !ok || running.expired {
+			continue
+		}
+		if err := uc.SetSampleDivisor(divisor); err != nil {
+			m.logger.Warn().Err(err).Str("collector_id", id).Msg("Failed to update uprobe sampling")
+		}
+	}
+}
+
 // Start is a no-op; the manager is ready after NewManager.
 func (m *Manager) Start() error { return nil }
 
@@ -296,7 +434,7 @@ func (m *Manager) isCollectorSupported(kind agentv1.EbpfCollectorKind) bool {
 }
 
 // createCollector creates a collector instance based on kind.
-func (m *Manager) createCollector(kind agentv1.EbpfCollectorKind, config map[string]string) (Collector, error) {
+func (m *Manager) createCollector(collectorID string, kind agentv1.EbpfCollectorKind, config map[string]string) (Collector, error) {
 	switch kind {
 	case agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_UPROBE:
 		// Parse uprobe configuration
@@ -313,11 +451,16 @@ func (m *Manager) createCollector(kind agentv1.EbpfCollectorKind, config map[str
 		serviceName := config["service_name"]
 
 		uprobeConfig := &UprobeConfig{
-			ServiceName:  serviceName,
-			FunctionName: functionName,
-			SDKAddr:      sdkAddr,
+			ServiceName:    serviceName,
+			FunctionName:   functionName,
+			SDKAddr:        sdkAddr,
+			KernelFeatures: m.features,
+			SampleDivisor:  m.sampleDivisor,
+			Redactor:       m.redactor,
 		}
 
+		uprobeConfig.OnEvent = m.eventHook(collectorID, config["session_id"])
+
 		// Parse optional config
 		if captureArgs, ok := config["capture_args"]; ok && captureArgs == "true" {
 			uprobeConfig.CaptureArgs = true
@@ -350,6 +493,55 @@ func (m *Manager) createCollector(kind agentv1.EbpfCollectorKind, config map[str
 
 		return NewUprobeCollector(m.logger, uprobeConfig)
 
+	case agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_HTTP_CAPTURE:
+		captureConfig := &HTTPCaptureConfig{
+			ServiceName:   config["service_name"],
+			Route:         config["route"],
+			CaptureBodies: config["capture_bodies"] == "true",
+			Redactor:      m.redactor,
+			OnEvent:       m.eventHook(collectorID, config["session_id"]),
+		}
+		if _, err := fmt.Sscanf(config["pid"], "%d", &captureConfig.PID); err != nil {
+			return nil, fmt.Errorf("unable to scan pid: %w", err)
+		}
+		if _, err := fmt.Sscanf(config["port"], "%d", &captureConfig.Port); err != nil {
+			return nil, fmt.Errorf("unable to scan port: %w", err)
+		}
+		if v, ok := config["sample_rate"]; ok {
+			if _, err := fmt.Sscanf(v, "%g", &captureConfig.SampleRate); err != nil {
+				return nil, fmt.Errorf("unable to scan sample_rate: %w", err)
+			}
+		}
+		if v, ok := config["max_body_bytes"]; ok {
+			if _, err := fmt.Sscanf(v, "%d", &captureConfig.MaxBodyBytes); err != nil {
+				return nil, fmt.Errorf("unable to scan max_body_bytes: %w", err)
+			}
+		}
+
+		return NewHTTPCaptureCollector(m.logger, captureConfig)
+
+	case agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_TLS_CAPTURE:
+		if !m.tlsCapture {
+			return nil, errTLSCaptureDisabled
+		}
+		captureConfig := &TLSCaptureConfig{
+			ServiceName: config["service_name"],
+			Library:     config["library"],
+			Match:       config["match"],
+			Redactor:    m.redactor,
+			OnEvent:     m.eventHook(collectorID, config["session_id"]),
+		}
+		if _, err := fmt.Sscanf(config["pid"], "%d", &captureConfig.PID); err != nil {
+			return nil, fmt.Errorf("unable to scan pid: %w", err)
+		}
+		if v, ok := config["max_data_bytes"]; ok {
+			if _, err := fmt.Sscanf(v, "%d", &captureConfig.MaxDataBytes); err != nil {
+				return nil, fmt.Errorf("unable to scan max_data_bytes: %w", err)
+			}
+		}
+
+		return NewTLSCaptureCollector(m.logger, captureConfig)
+
 	case agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_SYSCALL_STATS:
 		return NewSyscallStatsCollector(m.logger, config), nil
 	default:
@@ -357,6 +549,29 @@ func (m *Manager) createCollector(kind agentv1.EbpfCollectorKind, config map[str
 	}
 }
 
+// eventHook returns the OnEvent hook of a collector: events are persisted
+// locally and, for colony debug sessions, pushed to the colony as they are
+// captured. It returns nil when events go nowhere.
+func (m *Manager) eventHook(collectorID, sessionID string) func(event *agentv1.UprobeEvent) {
+	store := m.eventStore
+	if store == nil && sessionID == "" {
+		return nil
+	}
+	return func(event *agentv1.UprobeEvent) {
+		if event.CollectorId == "" {
+			event.CollectorId = collectorID
+		}
+		if store != nil {
+			store.Append(collectorID, event)
+		}
+		if sessionID != "" {
+			if sink := m.uprobeEventSink(); sink != nil {
+				sink(sessionID, event)
+			}
+		}
+	}
+}
+
 // janitor periodically cleans up expired collectors that haven't been explicitly stopped.
 // Gives a 1-hour grace period after expiration for event fetching before cleanup.
 func (m *Manager) janitor() {
@@ -0,0 +1,170 @@
+package ebpf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/sys/proc"
+)
+
+// DefaultProcessWatchInterval is how often watched processes are polled.
+const DefaultProcessWatchInterval = 2 * time.Second
+
+// EventTypeRestart marks a target process restart in the uprobe event stream.
+const EventTypeRestart = "restart"
+
+// ProcessIdentity identifies a single process instance. PIDs are reused after
+// a process exits, so the start time is needed to tell instances apart.
+type ProcessIdentity struct {
+	PID       uint32
+	StartTime uint64
+
+	// ExePath is the /proc/PID/exe target, used to find the process that
+	// replaces this one after a restart.
+	ExePath string
+}
+
+// IdentifyProcess returns the identity of the running process with pid.
+func IdentifyProcess(pid uint32) (ProcessIdentity, error) {
+	startTime, err := proc.GetStartTime(int(pid))
+	if err != nil {
+		return ProcessIdentity{}, err
+	}
+
+	exePath, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+	if err != nil {
+		return ProcessIdentity{}, fmt.Errorf("failed to resolve executable for pid %d: %w", pid, err)
+	}
+
+	return ProcessIdentity{
+		PID:       pid,
+		StartTime: startTime,
+		// The kernel appends " (deleted)" once the binary is replaced on disk,
+		// which is exactly what happens during a redeploy.
+		ExePath: strings.TrimSuffix(exePath, " (deleted)"),
+	}, nil
+}
+
+// RestartHandler is invoked when the watched process has been replaced by a
+// new instance of the same executable. Returning an error keeps the watcher
+// on the old identity so the restart is retried on the next poll.
+type RestartHandler func(ctx context.Context, previous, current ProcessIdentity) error
+
+// ProcessWatcher polls /proc to detect when a watched process exits and a new
+// instance of the same executable starts in its place.
+type ProcessWatcher struct {
+	logger    zerolog.Logger
+	interval  time.Duration
+	onRestart RestartHandler
+
+	// Process lookups, overridable for tests.
+	identify func(pid uint32) (ProcessIdentity, error)
+	listPids func() ([]int, error)
+}
+
+// NewProcessWatcher creates a process watcher polling every interval.
+func NewProcessWatcher(logger zerolog.Logger, interval time.Duration, onRestart RestartHandler) *ProcessWatcher {
+	if interval <= 0 {
+		interval = DefaultProcessWatchInterval
+	}
+
+	return &ProcessWatcher{
+		logger:    logger.With().Str("component", "process_watcher").Logger(),
+		interval:  interval,
+		onRestart: onRestart,
+		identify:  IdentifyProcess,
+		listPids:  proc.ListPids,
+	}
+}
+
+// Watch polls target until ctx is done, invoking the restart handler each
+// time the process is replaced.
+func (w *ProcessWatcher) Watch(ctx context.Context, target ProcessIdentity) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	exited := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if w.alive(target) {
+			continue
+		}
+
+		if !exited {
+			w.logger.Warn().
+				Uint32("pid", target.PID).
+				Str("exe", target.ExePath).
+				Msg("Watched process exited, waiting for restart")
+			exited = true
+		}
+
+		replacement, ok := w.findReplacement(target)
+		if !ok {
+			continue
+		}
+
+		if err := w.onRestart(ctx, target, replacement); err != nil {
+			w.logger.Warn().Err(err).
+				Uint32("pid", replacement.PID).
+				Msg("Failed to handle process restart, will retry")
+			continue
+		}
+
+		w.logger.Info().
+			Uint32("previous_pid", target.PID).
+			Uint32("pid", replacement.PID).
+			Msg("Re-attached to restarted process")
+
+		target = replacement
+		exited = false
+	}
+}
+
+// alive reports whether the process instance identified by target is running.
+func (w *ProcessWatcher) alive(target ProcessIdentity) bool {
+	current, err := w.identify(target.PID)
+	if err != nil {
+		return false
+	}
+	return current.StartTime == target.StartTime
+}
+
+// findReplacement returns the most recently started process running the same
+// executable as previous.
+func (w *ProcessWatcher) findReplacement(previous ProcessIdentity) (ProcessIdentity, bool) {
+	pids, err := w.listPids()
+	if err != nil {
+		w.logger.Debug().Err(err).Msg("Failed to list processes")
+		return ProcessIdentity{}, false
+	}
+
+	var (
+		best  ProcessIdentity
+		found bool
+	)
+	for _, pid := range pids {
+		candidate, err := w.identify(uint32(pid)) //nolint:gosec // G115: PIDs from /proc are positive.
+		if err != nil || candidate.ExePath != previous.ExePath {
+			continue
+		}
+		if candidate.PID == previous.PID && candidate.StartTime == previous.StartTime {
+			continue
+		}
+		if !found || candidate.StartTime > best.StartTime {
+			best = candidate
+			found = true
+		}
+	}
+
+	return best, found
+}
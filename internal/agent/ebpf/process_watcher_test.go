@@ -0,0 +1,167 @@
+package ebpf
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProcTable is an in-memory process table for watcher tests.
+type fakeProcTable struct {
+	mu    sync.Mutex
+	procs map[uint32]ProcessIdentity
+}
+
+func (f *fakeProcTable) set(procs ...ProcessIdentity) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.procs = make(map[uint32]ProcessIdentity)
+	for _, p := range procs {
+		f.procs[p.PID] = p
+	}
+}
+
+func (f *fakeProcTable) identify(pid uint32) (ProcessIdentity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.procs[pid]
+	if !ok {
+		return ProcessIdentity{}, os.ErrNotExist
+	}
+	return p, nil
+}
+
+func (f *fakeProcTable) listPids() ([]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pids := make([]int, 0, len(f.procs))
+	for pid := range f.procs {
+		pids = append(pids, int(pid))
+	}
+	return pids, nil
+}
+
+func newTestProcessWatcher(table *fakeProcTable, onRestart RestartHandler) *ProcessWatcher {
+	w := NewProcessWatcher(zerolog.Nop(), 5*time.Millisecond, onRestart)
+	w.identify = table.identify
+	w.listPids = table.listPids
+	return w
+}
+
+func TestProcessWatcher_DetectsRestart(t *testing.T) {
+	original := ProcessIdentity{PID: 100, StartTime: 1, ExePath: "/app/server"}
+	other := ProcessIdentity{PID: 150, StartTime: 3, ExePath: "/usr/bin/other"}
+	table := &fakeProcTable{}
+	table.set(original, other)
+
+	restarts := make(chan [2]ProcessIdentity, 1)
+	w := newTestProcessWatcher(table, func(_ context.Context, previous, current ProcessIdentity) error {
+		restarts <- [2]ProcessIdentity{previous, current}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Watch(ctx, original)
+
+	// Process exits and a new instance of the same binary starts.
+	replacement := ProcessIdentity{PID: 200, StartTime: 5, ExePath: "/app/server"}
+	table.set(replacement, other)
+
+	select {
+	case got := <-restarts:
+		assert.Equal(t, original, got[0])
+		assert.Equal(t, replacement, got[1])
+	case <-time.After(time.Second):
+		t.Fatal("restart was not detected")
+	}
+}
+
+func TestProcessWatcher_DetectsPIDReuse(t *testing.T) {
+	original := ProcessIdentity{PID: 100, StartTime: 1, ExePath: "/app/server"}
+	table := &fakeProcTable{}
+	table.set(original)
+
+	restarts := make(chan ProcessIdentity, 1)
+	w := newTestProcessWatcher(table, func(_ context.Context, _, current ProcessIdentity) error {
+		restarts <- current
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Watch(ctx, original)
+
+	// Same PID, different start time: a new process instance.
+	reused := ProcessIdentity{PID: 100, StartTime: 7, ExePath: "/app/server"}
+	table.set(reused)
+
+	select {
+	case got := <-restarts:
+		assert.Equal(t, reused, got)
+	case <-time.After(time.Second):
+		t.Fatal("PID reuse was not detected as a restart")
+	}
+}
+
+func TestProcessWatcher_RetriesFailedRestart(t *testing.T) {
+	original := ProcessIdentity{PID: 100, StartTime: 1, ExePath: "/app/server"}
+	replacement := ProcessIdentity{PID: 200, StartTime: 5, ExePath: "/app/server"}
+	table := &fakeProcTable{}
+	table.set(replacement)
+
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	done := make(chan struct{})
+	w := newTestProcessWatcher(table, func(_ context.Context, _, _ ProcessIdentity) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			return errors.New("sdk not ready")
+		}
+		close(done)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Watch(ctx, original)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("failed restart was not retried")
+	}
+}
+
+func TestProcessWatcher_FindReplacementIgnoresOtherBinaries(t *testing.T) {
+	original := ProcessIdentity{PID: 100, StartTime: 1, ExePath: "/app/server"}
+	table := &fakeProcTable{}
+	table.set(ProcessIdentity{PID: 300, StartTime: 9, ExePath: "/usr/bin/other"})
+
+	w := newTestProcessWatcher(table, nil)
+
+	_, ok := w.findReplacement(original)
+	assert.False(t, ok)
+}
+
+func TestIdentifyProcess_Self(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("/proc not available")
+	}
+
+	identity, err := IdentifyProcess(uint32(os.Getpid())) //nolint:gosec // G115: test PID.
+	require.NoError(t, err)
+	assert.NotZero(t, identity.StartTime)
+	assert.NotEmpty(t, identity.ExePath)
+}
@@ -0,0 +1,69 @@
+package ebpf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/runtimetrace"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/uprobe"
+)
+
+// TraceGoRuntime traces the Go runtime of a process for duration: its
+// stop-the-world pauses, GC cycles and goroutine scheduling latency.
+// Scheduling latency is left empty when the scheduler functions cannot be
+// probed, e.g. when they are inlined.
+func TraceGoRuntime(ctx context.Context, logger zerolog.Logger, pid uint32, duration time.Duration) (*runtimetrace.Report, error) {
+	discovery, err := NewDiscoveryService(DefaultDiscoveryConfig(slog.Default()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery service: %w", err)
+	}
+	defer discovery.Close() // nolint:errcheck
+
+	locate := func(name string) (runtimetrace.Function, error) {
+		result, err := discovery.DiscoverFunction(ctx, "", pid, name)
+		if err != nil {
+			return runtimetrace.Function{}, fmt.Errorf("failed to discover %s: %w", name, err)
+		}
+		offset := result.Metadata.Offset
+
+		// A probe at a wrong offset corrupts the instruction it lands on.
+		if err := uprobe.ValidateOffset(pid, offset); errors.Is(err, uprobe.ErrOffsetMismatch) {
+			return runtimetrace.Function{}, fmt.Errorf("refusing to probe %s: %w", name, err)
+		}
+		return runtimetrace.Function{Symbol: name, Address: offset}, nil
+	}
+
+	cfg := runtimetrace.Config{
+		PID:  pid,
+		Path: fmt.Sprintf("/proc/%d/exe", pid),
+	}
+	if cfg.StopTheWorld, err = locate(runtimetrace.FuncStopTheWorld); err != nil {
+		return nil, err
+	}
+	if cfg.StartTheWorld, err = locate(runtimetrace.FuncStartTheWorld); err != nil {
+		return nil, err
+	}
+
+	runqput, runqputErr := locate(runtimetrace.FuncRunqput)
+	execute, executeErr := locate(runtimetrace.FuncExecute)
+	if err := errors.Join(runqputErr, executeErr); err != nil {
+		logger.Warn().Err(err).Uint32("pid", pid).Msg("Scheduling latency will not be measured")
+	} else {
+		cfg.Runqput, cfg.Execute = runqput, execute
+	}
+
+	probe, err := runtimetrace.Attach(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach runtime probes: %w", err)
+	}
+	defer probe.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	return probe.Collect(ctx)
+}
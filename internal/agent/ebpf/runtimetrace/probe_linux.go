@@ -0,0 +1,426 @@
+//go:build linux
+
+package runtimetrace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	ciliumebpf "github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/link"
+	"golang.org/x/sys/unix"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/uprobe"
+)
+
+const (
+	// maxRunnable bounds the runnable goroutines tracked between runqput
+	// and execute.
+	maxRunnable = 16384
+
+	// readTimeout bounds how long Collect blocks on the reader, so that it
+	// notices cancellation.
+	readTimeout = 200 * time.Millisecond
+)
+
+// Config configures the runtime functions to probe.
+type Config struct {
+	// PID is the process to trace; probes fire for it only.
+	PID uint32
+
+	// Path is the executable of the process, as seen from the agent.
+	Path string
+
+	// StopTheWorld and StartTheWorld locate the functions stopping and
+	// restarting the world. Both are required.
+	StopTheWorld, StartTheWorld Function
+
+	// Runqput and Execute locate the scheduler functions. Scheduling
+	// latency is not measured if either is zero.
+	Runqput, Execute Function
+}
+
+// Function locates a probed function.
+type Function struct {
+	// Symbol names the function. It is resolved when Address is 0.
+	Symbol string
+
+	// Address is the file offset of the function entry.
+	Address uint64
+}
+
+func (f Function) isZero() bool {
+	return f.Symbol == "" && f.Address == 0
+}
+
+// Probe traces the Go runtime of a process.
+type Probe struct {
+	goVersion string
+	sched     bool
+
+	// monotonic and wall are the clocks read together at attach time, to
+	// convert event timestamps to wall clock time.
+	monotonic uint64
+	wall      time.Time
+
+	events *ciliumebpf.Map
+	hist   *ciliumebpf.Map
+	maps   []*ciliumebpf.Map
+	progs  []*ciliumebpf.Program
+	links  []link.Link
+	reader uprobe.EventReader
+}
+
+// callingConvention locates, in the registers saved at a probe, the first
+// two arguments of Go functions, as offsets in struct pt_regs.
+type callingConvention struct {
+	arg0, arg1 int16
+}
+
+// conventionFor returns the Go register ABI of goarch.
+func conventionFor(goarch string) (callingConvention, error) {
+	switch goarch {
+	case "amd64":
+		// RAX, RBX.
+		return callingConvention{arg0: 80, arg1: 40}, nil
+	case "arm64":
+		// X0, X1.
+		return callingConvention{arg0: 0, arg1: 8}, nil
+	default:
+		return callingConvention{}, fmt.Errorf("runtime tracing is not supported on %s", goarch)
+	}
+}
+
+// Attach loads the tracing programs and attaches them to the runtime of the
+// process.
+func Attach(cfg Config) (*Probe, error) {
+	conv, err := conventionFor(runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.StopTheWorld.isZero() || cfg.StartTheWorld.isZero() {
+		return nil, fmt.Errorf("%s and %s are required", FuncStopTheWorld, FuncStartTheWorld)
+	}
+	goVersion, err := GoVersion(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Probe{
+		goVersion: goVersion,
+		sched:     !cfg.Runqput.isZero() && !cfg.Execute.isZero(),
+	}
+	if err := p.attach(cfg, conv); err != nil {
+		_ = p.Close()
+		return nil, err
+	}
+
+	var ts unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		_ = p.Close()
+		return nil, fmt.Errorf("read monotonic clock: %w", err)
+	}
+	p.monotonic, p.wall = uint64(ts.Nano()), time.Now() // #nosec G115 -- monotonic time is positive
+	return p, nil
+}
+
+func (p *Probe) attach(cfg Config, conv callingConvention) error {
+	var err error
+	p.events, err = p.newMap(&ciliumebpf.MapSpec{
+		Name: "rt_events",
+		Type: ciliumebpf.PerfEventArray,
+	})
+	if err != nil {
+		return err
+	}
+
+	stop, err := p.newProgram("rt_stw_stop", pauseProgram(conv, p.events, eventStop))
+	if err != nil {
+		return err
+	}
+	start, err := p.newProgram("rt_stw_start", pauseProgram(conv, p.events, eventStart))
+	if err != nil {
+		return err
+	}
+
+	exe, err := link.OpenExecutable(cfg.Path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", cfg.Path, err)
+	}
+	if err := p.attachFunction(exe, cfg.PID, cfg.StopTheWorld, stop); err != nil {
+		return err
+	}
+	if err := p.attachFunction(exe, cfg.PID, cfg.StartTheWorld, start); err != nil {
+		return err
+	}
+
+	if p.sched {
+		if err := p.attachScheduler(exe, cfg, conv); err != nil {
+			return err
+		}
+	}
+
+	p.reader, err = uprobe.NewEventReader(p.events, 0)
+	return err
+}
+
+// attachScheduler attaches the programs measuring scheduling latency.
+func (p *Probe) attachScheduler(exe *link.Executable, cfg Config, conv callingConvention) error {
+	runnable, err := p.newMap(&ciliumebpf.MapSpec{
+		Name:       "rt_runnable",
+		Type:       ciliumebpf.LRUHash,
+		KeySize:    8,
+		ValueSize:  8,
+		MaxEntries: maxRunnable,
+	})
+	if err != nil {
+		return err
+	}
+	p.hist, err = p.newMap(&ciliumebpf.MapSpec{
+		Name:       "rt_sched_hist",
+		Type:       ciliumebpf.PerCPUArray,
+		KeySize:    4,
+		ValueSize:  8,
+		MaxEntries: HistogramBuckets,
+	})
+	if err != nil {
+		return err
+	}
+
+	runqput, err := p.newProgram("rt_runqput", runqputProgram(conv, runnable))
+	if err != nil {
+		return err
+	}
+	execute, err := p.newProgram("rt_execute", executeProgram(conv, runnable, p.hist))
+	if err != nil {
+		return err
+	}
+	if err := p.attachFunction(exe, cfg.PID, cfg.Runqput, runqput); err != nil {
+		return err
+	}
+	return p.attachFunction(exe, cfg.PID, cfg.Execute, execute)
+}
+
+func (p *Probe) attachFunction(exe *link.Executable, pid uint32, fn Function, prog *ciliumebpf.Program) error {
+	l, err := exe.Uprobe(fn.Symbol, prog, &link.UprobeOptions{
+		Address: fn.Address,
+		PID:     int(pid), //nolint:gosec // G115: PIDs are small positive integers.
+	})
+	if err != nil {
+		return fmt.Errorf("attach uprobe to %s: %w", fn.Symbol, err)
+	}
+	p.links = append(p.links, l)
+	return nil
+}
+
+func (p *Probe) newMap(spec *ciliumebpf.MapSpec) (*ciliumebpf.Map, error) {
+	m, err := ciliumebpf.NewMap(spec)
+	if err != nil {
+		return nil, fmt.Errorf("create %s map: %w", spec.Name, err)
+	}
+	p.maps = append(p.maps, m)
+	return m, nil
+}
+
+func (p *Probe) newProgram(name string, insns asm.Instructions) (*ciliumebpf.Program, error) {
+	prog, err := ciliumebpf.NewProgram(&ciliumebpf.ProgramSpec{
+		Name:         name,
+		Type:         ciliumebpf.Kprobe,
+		Instructions: insns,
+		License:      "GPL",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load %s program: %w", name, err)
+	}
+	p.progs = append(p.progs, prog)
+	return prog, nil
+}
+
+// Collect traces the runtime until ctx is done and returns the report.
+func (p *Probe) Collect(ctx context.Context) (*Report, error) {
+	report := &Report{GoVersion: p.goVersion, Start: time.Now()}
+
+	var events []event
+	for ctx.Err() == nil {
+		p.reader.SetDeadline(time.Now().Add(readTimeout))
+		raw, err := p.reader.Read()
+		switch {
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("read runtime events: %w", err)
+		}
+
+		e, err := decodeEvent(raw)
+		if err != nil || len(events) >= maxEvents {
+			continue
+		}
+		events = append(events, e)
+	}
+	report.End = time.Now()
+
+	report.Pauses, report.GCCycles = analyze(events, p.goVersion, p.toTime)
+	if p.sched {
+		if err := p.readHistogram(&report.SchedLatency); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// readHistogram sums the per-CPU scheduling latency histograms.
+func (p *Probe) readHistogram(h *Histogram) error {
+	var counts []uint64
+	for i := range h {
+		if err := p.hist.Lookup(uint32(i), &counts); err != nil { // #nosec G115 -- i < HistogramBuckets
+			return fmt.Errorf("read scheduling latency histogram: %w", err)
+		}
+		for _, c := range counts {
+			h[i] += c
+		}
+	}
+	return nil
+}
+
+// toTime converts a CLOCK_MONOTONIC timestamp to wall clock time.
+func (p *Probe) toTime(ts uint64) time.Time {
+	return p.wall.Add(time.Duration(int64(ts - p.monotonic))) // #nosec G115 -- wraps to a negative offset
+}
+
+// Close detaches the probes and releases their resources.
+func (p *Probe) Close() error {
+	var errs []error
+	for _, l := range p.links {
+		errs = append(errs, l.Close())
+	}
+	if p.reader != nil {
+		errs = append(errs, p.reader.Close())
+	}
+	for _, prog := range p.progs {
+		errs = append(errs, prog.Close())
+	}
+	for _, m := range p.maps {
+		errs = append(errs, m.Close())
+	}
+	p.links, p.reader, p.progs, p.maps = nil, nil, nil, nil
+	return errors.Join(errs...)
+}
+
+// pauseProgram emits a {ts, kind, reason} event when the world is stopped
+// or restarted. The reason is the first argument of stopTheWorldWithSema.
+func pauseProgram(conv callingConvention, events *ciliumebpf.Map, kind eventKind) asm.Instructions {
+	insns := asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.StoreImm(asm.RFP, -4, 0, asm.Word),
+	}
+	if kind == eventStop {
+		insns = append(insns,
+			asm.LoadMem(asm.R1, asm.R6, conv.arg0, asm.Byte),
+			asm.StoreMem(asm.RFP, -4, asm.R1, asm.Word),
+		)
+	}
+	return append(insns,
+		asm.StoreImm(asm.RFP, -8, int64(kind), asm.Word),
+		asm.FnKtimeGetNs.Call(),
+		asm.StoreMem(asm.RFP, -16, asm.R0, asm.DWord),
+
+		asm.Mov.Reg(asm.R1, asm.R6),
+		asm.LoadMapPtr(asm.R2, events.FD()),
+		asm.LoadImm(asm.R3, 0xffffffff, asm.DWord), // BPF_F_CURRENT_CPU
+		asm.Mov.Reg(asm.R4, asm.RFP),
+		asm.Add.Imm(asm.R4, -16),
+		asm.Mov.Imm(asm.R5, eventSize),
+		asm.FnPerfEventOutput.Call(),
+
+		asm.Mov.Imm(asm.R0, 0),
+		asm.Return(),
+	)
+}
+
+// runqputProgram records when a goroutine, the second argument of runqput,
+// became runnable.
+func runqputProgram(conv callingConvention, runnable *ciliumebpf.Map) asm.Instructions {
+	return asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.LoadMem(asm.R1, asm.R6, conv.arg1, asm.DWord),
+		asm.StoreMem(asm.RFP, -8, asm.R1, asm.DWord),
+		asm.FnKtimeGetNs.Call(),
+		asm.StoreMem(asm.RFP, -16, asm.R0, asm.DWord),
+
+		asm.LoadMapPtr(asm.R1, runnable.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, -16),
+		asm.Mov.Imm(asm.R4, 0), // BPF_ANY
+		asm.FnMapUpdateElem.Call(),
+
+		asm.Mov.Imm(asm.R0, 0),
+		asm.Return(),
+	}
+}
+
+// executeProgram counts, in the log2 histogram, the time since the
+// goroutine about to run, the first argument of execute, became runnable.
+func executeProgram(conv callingConvention, runnable, hist *ciliumebpf.Map) asm.Instructions {
+	return asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.LoadMem(asm.R1, asm.R6, conv.arg0, asm.DWord),
+		asm.StoreMem(asm.RFP, -8, asm.R1, asm.DWord),
+
+		// Look up and forget when the goroutine became runnable.
+		asm.LoadMapPtr(asm.R1, runnable.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+		asm.LoadMem(asm.R7, asm.R0, 0, asm.DWord),
+		asm.LoadMapPtr(asm.R1, runnable.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapDeleteElem.Call(),
+
+		asm.FnKtimeGetNs.Call(),
+		asm.Mov.Reg(asm.R1, asm.R0),
+		asm.Sub.Reg(asm.R1, asm.R7),
+
+		// R2 = log2(R1), by halving.
+		asm.Mov.Imm(asm.R2, 0),
+		asm.LoadImm(asm.R3, 0xffffffff, asm.DWord),
+		asm.JLE.Reg(asm.R1, asm.R3, "le32"),
+		asm.RSh.Imm(asm.R1, 32),
+		asm.Add.Imm(asm.R2, 32),
+		asm.JLE.Imm(asm.R1, 0xffff, "le16").WithSymbol("le32"),
+		asm.RSh.Imm(asm.R1, 16),
+		asm.Add.Imm(asm.R2, 16),
+		asm.JLE.Imm(asm.R1, 0xff, "le8").WithSymbol("le16"),
+		asm.RSh.Imm(asm.R1, 8),
+		asm.Add.Imm(asm.R2, 8),
+		asm.JLE.Imm(asm.R1, 0xf, "le4").WithSymbol("le8"),
+		asm.RSh.Imm(asm.R1, 4),
+		asm.Add.Imm(asm.R2, 4),
+		asm.JLE.Imm(asm.R1, 0x3, "le2").WithSymbol("le4"),
+		asm.RSh.Imm(asm.R1, 2),
+		asm.Add.Imm(asm.R2, 2),
+		asm.JLE.Imm(asm.R1, 0x1, "count").WithSymbol("le2"),
+		asm.Add.Imm(asm.R2, 1),
+
+		asm.StoreMem(asm.RFP, -12, asm.R2, asm.Word).WithSymbol("count"),
+		asm.LoadMapPtr(asm.R1, hist.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -12),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.DWord),
+		asm.Add.Imm(asm.R1, 1),
+		asm.StoreMem(asm.R0, 0, asm.R1, asm.DWord),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("exit"),
+		asm.Return(),
+	}
+}
@@ -0,0 +1,107 @@
+//go:build linux
+
+package runtimetrace
+
+import (
+	"context"
+	"debug/elf"
+	"debug/gosym"
+	"os"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goFunction locates a function of the test binary from its pclntab, which
+// is kept when the symbol table is stripped.
+func goFunction(t *testing.T, path, name string) Function {
+	t.Helper()
+
+	f, err := elf.Open(path)
+	require.NoError(t, err)
+	defer f.Close() // nolint:errcheck
+
+	pclntab := f.Section(".gopclntab")
+	text := f.Section(".text")
+	if pclntab == nil || text == nil {
+		t.Skip("test binary has no pclntab")
+	}
+	data, err := pclntab.Data()
+	require.NoError(t, err)
+	table, err := gosym.NewTable(nil, gosym.NewLineTable(data, text.Addr))
+	require.NoError(t, err)
+
+	fn := table.LookupFunc(name)
+	if fn == nil {
+		t.Skipf("function %s not found in test binary", name)
+	}
+	for _, prog := range f.Progs {
+		if prog.Type == elf.PT_LOAD && fn.Entry >= prog.Vaddr && fn.Entry < prog.Vaddr+prog.Memsz {
+			return Function{Symbol: name, Address: fn.Entry - prog.Vaddr + prog.Off}
+		}
+	}
+	t.Fatalf("function %s is not in a loadable segment", name)
+	return Function{}
+}
+
+func TestCollect(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	probe, err := Attach(Config{
+		PID:           uint32(os.Getpid()), // #nosec G115
+		Path:          exe,
+		StopTheWorld:  goFunction(t, exe, FuncStopTheWorld),
+		StartTheWorld: goFunction(t, exe, FuncStartTheWorld),
+		Runqput:       goFunction(t, exe, FuncRunqput),
+		Execute:       goFunction(t, exe, FuncExecute),
+	})
+	if err != nil {
+		t.Skipf("cannot attach uprobes: %v", err)
+	}
+	defer probe.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	go func() {
+		for i := 0; i < 3; i++ {
+			runtime.GC()
+		}
+		// Wake goroutines up to exercise the scheduler.
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				time.Sleep(time.Millisecond)
+			}()
+		}
+		wg.Wait()
+	}()
+
+	report, err := probe.Collect(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, runtime.Version(), report.GoVersion)
+	assert.GreaterOrEqual(t, len(report.GCCycles), 3)
+	for _, cycle := range report.GCCycles {
+		assert.Positive(t, cycle.Pause)
+		assert.GreaterOrEqual(t, cycle.Duration, cycle.Pause)
+		assert.WithinRange(t, cycle.Start, report.Start.Add(-time.Second), report.End)
+	}
+	assert.Positive(t, report.SchedLatency.Count())
+}
+
+func TestConventionFor(t *testing.T) {
+	conv, err := conventionFor("amd64")
+	require.NoError(t, err)
+	assert.Equal(t, int16(80), conv.arg0)
+
+	_, err = conventionFor("riscv64")
+	assert.Error(t, err)
+}
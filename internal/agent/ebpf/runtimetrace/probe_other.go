@@ -0,0 +1,40 @@
+//go:build !linux
+
+package runtimetrace
+
+import (
+	"context"
+	"fmt"
+)
+
+// Config configures the runtime functions to probe.
+type Config struct {
+	PID                         uint32
+	Path                        string
+	StopTheWorld, StartTheWorld Function
+	Runqput, Execute            Function
+}
+
+// Function locates a probed function.
+type Function struct {
+	Symbol  string
+	Address uint64
+}
+
+// Probe is a stub for non-Linux platforms.
+type Probe struct{}
+
+// Attach is a stub for non-Linux platforms.
+func Attach(_ Config) (*Probe, error) {
+	return nil, fmt.Errorf("runtime tracing requires Linux")
+}
+
+// Collect is a stub for non-Linux platforms.
+func (p *Probe) Collect(_ context.Context) (*Report, error) {
+	return nil, fmt.Errorf("runtime tracing requires Linux")
+}
+
+// Close is a stub for non-Linux platforms.
+func (p *Probe) Close() error {
+	return nil
+}
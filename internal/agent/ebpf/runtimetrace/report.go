@@ -0,0 +1,231 @@
+// Package runtimetrace traces the Go runtime of a process with uprobes:
+// stop-the-world pauses, GC cycles and goroutine scheduling latency.
+//
+// Pauses are measured from runtime.stopTheWorldWithSema to
+// runtime.startTheWorldWithSema. Since Go 1.21 the former takes the reason
+// of the pause, which tells the two pauses of a GC cycle from the others.
+// Scheduling latency is measured in the kernel, from runtime.runqput to
+// runtime.execute of the same goroutine, and aggregated into a log2
+// histogram so that the scheduler paths emit no events.
+package runtimetrace
+
+import (
+	"debug/buildinfo"
+	"encoding/binary"
+	"fmt"
+	"go/version"
+	"sort"
+	"time"
+)
+
+// Probed runtime functions.
+const (
+	FuncStopTheWorld  = "runtime.stopTheWorldWithSema"
+	FuncStartTheWorld = "runtime.startTheWorldWithSema"
+	FuncRunqput       = "runtime.runqput"
+	FuncExecute       = "runtime.execute"
+)
+
+const (
+	// HistogramBuckets is the number of log2 latency buckets.
+	HistogramBuckets = 64
+
+	// eventSize is the size of a pause event: ts u64, kind u32, reason u32.
+	eventSize = 16
+
+	// maxEvents bounds the pause events kept by a trace.
+	maxEvents = 1 << 16
+
+	// minReasonVersion is the first Go version passing the reason of a
+	// pause to stopTheWorldWithSema.
+	minReasonVersion = "go1.21"
+)
+
+// stwReason values of the runtime (runtime/proc.go).
+const (
+	stwGCMarkTerm  = 1
+	stwGCSweepTerm = 2
+)
+
+// stwReasons names the stwReason values of the runtime.
+var stwReasons = []string{
+	"unknown",
+	"GC mark termination",
+	"GC sweep termination",
+	"write heap dump",
+	"goroutine profile",
+	"goroutine profile cleanup",
+	"all goroutines stack trace",
+	"read mem stats",
+	"AllThreadsSyscall",
+	"GOMAXPROCS",
+	"start trace",
+	"stop trace",
+}
+
+// Pause is a stop-the-world pause.
+type Pause struct {
+	Start    time.Time
+	Duration time.Duration
+	Reason   string
+}
+
+// GCCycle is a garbage collection cycle, from the stop of sweep termination
+// to the restart after mark termination.
+type GCCycle struct {
+	Start    time.Time
+	Duration time.Duration
+
+	// Pause is the stop-the-world time of the cycle.
+	Pause time.Duration
+}
+
+// Histogram counts latencies in log2 buckets: bucket i counts latencies
+// below 2^(i+1) nanoseconds and at least 2^i, except bucket 0 which starts
+// at 0.
+type Histogram [HistogramBuckets]uint64
+
+// UpperBound returns the exclusive upper bound of bucket i.
+func UpperBound(i int) time.Duration {
+	if i >= 62 {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(uint64(1) << (i + 1))
+}
+
+// Count returns the number of latencies in h.
+func (h *Histogram) Count() uint64 {
+	var n uint64
+	for _, c := range h {
+		n += c
+	}
+	return n
+}
+
+// Percentile returns the upper bound of the bucket holding the q-th
+// quantile of h, or 0 if h is empty.
+func (h *Histogram) Percentile(q float64) time.Duration {
+	total := h.Count()
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(q * float64(total))
+	var seen uint64
+	for i, c := range h {
+		seen += c
+		if seen > rank || seen == total {
+			return UpperBound(i)
+		}
+	}
+	return UpperBound(HistogramBuckets - 1)
+}
+
+// Report is what the Go runtime of a process did during a trace.
+type Report struct {
+	GoVersion string
+	Start     time.Time
+	End       time.Time
+	Pauses    []Pause
+	GCCycles  []GCCycle
+
+	// SchedLatency is empty if the scheduler functions were not probed.
+	SchedLatency Histogram
+}
+
+// GoVersion returns the Go version a binary was built with.
+func GoVersion(path string) (string, error) {
+	info, err := buildinfo.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("not a Go binary: %w", err)
+	}
+	return info.GoVersion, nil
+}
+
+// eventKind tells the stop of the world from its restart.
+type eventKind uint32
+
+const (
+	eventStop eventKind = iota
+	eventStart
+)
+
+// event is a stop or restart of the world, timed by CLOCK_MONOTONIC.
+type event struct {
+	ts     uint64
+	kind   eventKind
+	reason uint32
+}
+
+func decodeEvent(raw []byte) (event, error) {
+	if len(raw) < eventSize {
+		return event{}, fmt.Errorf("runtime event too short: %d bytes", len(raw))
+	}
+	order := binary.NativeEndian
+	return event{
+		ts:     order.Uint64(raw[0:]),
+		kind:   eventKind(order.Uint32(raw[8:])),
+		reason: order.Uint32(raw[12:]),
+	}, nil
+}
+
+// reasonName names a stwReason, or "unknown" when the Go version does not
+// pass it.
+func reasonName(reason uint32, known bool) string {
+	if !known || int(reason) >= len(stwReasons) {
+		return stwReasons[0]
+	}
+	return stwReasons[reason]
+}
+
+// analyze pairs the stops and restarts of the world into pauses, and the
+// pauses of sweep and mark termination into GC cycles. toTime converts
+// event timestamps to wall clock time.
+func analyze(events []event, goVersion string, toTime func(uint64) time.Time) ([]Pause, []GCCycle) {
+	// Events of different CPUs are read out of order.
+	sort.Slice(events, func(i, j int) bool { return events[i].ts < events[j].ts })
+	reasonsKnown := version.Compare(goVersion, minReasonVersion) >= 0
+
+	var (
+		pauses  []Pause
+		cycles  []GCCycle
+		stop    *event
+		gcStart *event
+		gcPause time.Duration
+	)
+	for i := range events {
+		e := &events[i]
+		if e.kind == eventStop {
+			stop = e
+			continue
+		}
+		if stop == nil {
+			// The world was stopped before the trace started.
+			continue
+		}
+
+		duration := time.Duration(e.ts - stop.ts) // #nosec G115 -- events are sorted
+		pauses = append(pauses, Pause{
+			Start:    toTime(stop.ts),
+			Duration: duration,
+			Reason:   reasonName(stop.reason, reasonsKnown),
+		})
+
+		if reasonsKnown {
+			switch stop.reason {
+			case stwGCSweepTerm:
+				gcStart, gcPause = stop, duration
+			case stwGCMarkTerm:
+				if gcStart != nil {
+					cycles = append(cycles, GCCycle{
+						Start:    toTime(gcStart.ts),
+						Duration: time.Duration(e.ts - gcStart.ts), // #nosec G115
+						Pause:    gcPause + duration,
+					})
+					gcStart = nil
+				}
+			}
+		}
+		stop = nil
+	}
+	return pauses, cycles
+}
@@ -0,0 +1,72 @@
+package runtimetrace
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	raw := make([]byte, eventSize)
+	binary.NativeEndian.PutUint64(raw[0:], 123456789)
+	binary.NativeEndian.PutUint32(raw[8:], uint32(eventStop))
+	binary.NativeEndian.PutUint32(raw[12:], stwGCSweepTerm)
+
+	e, err := decodeEvent(raw)
+	require.NoError(t, err)
+	assert.Equal(t, event{ts: 123456789, kind: eventStop, reason: stwGCSweepTerm}, e)
+
+	_, err = decodeEvent(raw[:eventSize-1])
+	assert.Error(t, err)
+}
+
+func TestAnalyze(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	toTime := func(ts uint64) time.Time { return base.Add(time.Duration(ts)) }
+	ms := uint64(time.Millisecond)
+
+	// Read out of order, starting with a restart whose stop was missed.
+	events := []event{
+		{ts: 1 * ms, kind: eventStart},
+		{ts: 10*ms + 200_000, kind: eventStart},
+		{ts: 10 * ms, kind: eventStop, reason: stwGCSweepTerm},
+		{ts: 14 * ms, kind: eventStop, reason: stwGCMarkTerm},
+		{ts: 14*ms + 500_000, kind: eventStart},
+		{ts: 20 * ms, kind: eventStop, reason: 7},
+		{ts: 20*ms + 50_000, kind: eventStart},
+	}
+
+	pauses, cycles := analyze(events, "go1.22.3", toTime)
+	require.Len(t, pauses, 3)
+	assert.Equal(t, Pause{Start: toTime(10 * ms), Duration: 200 * time.Microsecond, Reason: "GC sweep termination"}, pauses[0])
+	assert.Equal(t, Pause{Start: toTime(14 * ms), Duration: 500 * time.Microsecond, Reason: "GC mark termination"}, pauses[1])
+	assert.Equal(t, "read mem stats", pauses[2].Reason)
+
+	require.Len(t, cycles, 1)
+	assert.Equal(t, GCCycle{
+		Start:    toTime(10 * ms),
+		Duration: 4500 * time.Microsecond,
+		Pause:    700 * time.Microsecond,
+	}, cycles[0])
+
+	// Before Go 1.21 the reason is not passed: pauses are not attributed.
+	pauses, cycles = analyze(events, "go1.20.14", toTime)
+	require.Len(t, pauses, 3)
+	assert.Equal(t, "unknown", pauses[0].Reason)
+	assert.Empty(t, cycles)
+}
+
+func TestHistogram(t *testing.T) {
+	var h Histogram
+	assert.Zero(t, h.Percentile(0.99))
+
+	h[10] = 90 // [1024ns, 2048ns)
+	h[20] = 10 // [~1ms, ~2ms)
+	assert.Equal(t, uint64(100), h.Count())
+	assert.Equal(t, 2048*time.Nanosecond, h.Percentile(0.5))
+	assert.Equal(t, UpperBound(20), h.Percentile(0.99))
+	assert.Equal(t, time.Duration(1<<21), UpperBound(20))
+}
@@ -51,11 +51,13 @@ func (c *SDKClient) GetFunctionMetadata(ctx context.Context, functionName string
 
 	// Define struct to match JSON response from SDK
 	type ArgumentMetadataJSON struct {
-		Name   string `json:"name"`
-		Type   string `json:"type"`
-		Offset int64  `json:"offset"`
+		Name     string `json:"name"`
+		Type     string `json:"type"`
+		Offset   int64  `json:"offset"`
+		Location string `json:"location"`
 	}
 	type ReturnValueMetadataJSON struct {
+		Name   string `json:"name"`
 		Type   string `json:"type"`
 		Offset int64  `json:"offset"`
 	}
@@ -93,14 +95,16 @@ func (c *SDKClient) GetFunctionMetadata(ctx context.Context, functionName string
 
 	for _, arg := range meta.Arguments {
 		nativeMeta.Arguments = append(nativeMeta.Arguments, &ArgumentMetadata{
-			Name:   arg.Name,
-			Type:   arg.Type,
-			Offset: uint64(arg.Offset),
+			Name:     arg.Name,
+			Type:     arg.Type,
+			Offset:   uint64(arg.Offset),
+			Location: arg.Location,
 		})
 	}
 
 	for _, ret := range meta.ReturnValues {
 		nativeMeta.ReturnValues = append(nativeMeta.ReturnValues, &ReturnValueMetadata{
+			Name:   ret.Name,
 			Type:   ret.Type,
 			Offset: uint64(ret.Offset),
 		})
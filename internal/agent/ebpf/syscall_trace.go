@@ -0,0 +1,45 @@
+package ebpf
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/syscalltrace"
+)
+
+// TraceSyscalls traces the named syscalls, or all of them if names is
+// empty, of the service running pid for duration. The whole container or
+// systemd service of pid is traced when it has its own cgroup v2, and pid
+// and its descendants otherwise.
+func TraceSyscalls(ctx context.Context, logger zerolog.Logger, pid uint32, duration time.Duration, names []string) (*syscalltrace.Report, error) {
+	numbers, err := syscalltrace.Numbers(names)
+	if err != nil {
+		return nil, err
+	}
+	target, err := newServiceTarget(logger, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	probe, err := syscalltrace.Attach(syscalltrace.Config{
+		CgroupID: target.cgroupID,
+		PIDs:     target.pids,
+		Syscalls: numbers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach syscall probes: %w", err)
+	}
+	defer probe.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	report, err := probe.Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report.Target = target.description
+	return report, nil
+}
@@ -0,0 +1,388 @@
+//go:build linux
+
+package syscalltrace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ciliumebpf "github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/link"
+)
+
+const (
+	// maxSyscalls bounds the syscall numbers traced.
+	maxSyscalls = 1024
+
+	// maxPIDs bounds the processes traced when filtering by PID.
+	maxPIDs = 4096
+
+	// maxInflight bounds the threads in a syscall tracked between sys_enter
+	// and sys_exit. Threads exiting in a syscall never reach sys_exit, so
+	// the least recently used are evicted.
+	maxInflight = 16384
+
+	// maxStats bounds the (syscall, slot) statistics.
+	maxStats = 16384
+)
+
+// Config selects the syscalls to trace.
+type Config struct {
+	// CgroupID selects the processes of a cgroup v2, including those
+	// started during the trace. PIDs is used when it is 0.
+	CgroupID uint64
+
+	// PIDs selects processes by PID (thread group ID).
+	PIDs []uint32
+
+	// Syscalls are the syscall numbers to trace, or all if empty.
+	Syscalls []uint32
+}
+
+// Probe traces the syscalls of a set of processes.
+type Probe struct {
+	stats *ciliumebpf.Map
+	maps  []*ciliumebpf.Map
+	progs []*ciliumebpf.Program
+	links []link.Link
+}
+
+// Attach loads the tracing programs and attaches them to the sys_enter and
+// sys_exit raw tracepoints.
+func Attach(cfg Config) (*Probe, error) {
+	if len(syscallNames) == 0 {
+		return nil, fmt.Errorf("syscall tracing is not supported on this architecture")
+	}
+	if cfg.CgroupID == 0 && len(cfg.PIDs) == 0 {
+		return nil, fmt.Errorf("a cgroup or PIDs are required")
+	}
+	if len(cfg.PIDs) > maxPIDs {
+		return nil, fmt.Errorf("cannot trace more than %d processes", maxPIDs)
+	}
+
+	p := &Probe{}
+	if err := p.attach(cfg); err != nil {
+		_ = p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Probe) attach(cfg Config) error {
+	syscalls, err := p.newMap(&ciliumebpf.MapSpec{
+		Name:       "sc_syscalls",
+		Type:       ciliumebpf.Array,
+		KeySize:    4,
+		ValueSize:  4,
+		MaxEntries: maxSyscalls,
+	})
+	if err != nil {
+		return err
+	}
+	if len(cfg.Syscalls) == 0 {
+		for nr := range syscallNames {
+			if err := syscalls.Put(uint32(nr), uint32(1)); err != nil { // #nosec G115 -- nr < maxSyscalls
+				return fmt.Errorf("select syscalls: %w", err)
+			}
+		}
+	}
+	for _, nr := range cfg.Syscalls {
+		if nr >= maxSyscalls {
+			return fmt.Errorf("syscall number %d out of range", nr)
+		}
+		if err := syscalls.Put(nr, uint32(1)); err != nil {
+			return fmt.Errorf("select syscalls: %w", err)
+		}
+	}
+
+	var pids *ciliumebpf.Map
+	if cfg.CgroupID == 0 {
+		pids, err = p.newMap(&ciliumebpf.MapSpec{
+			Name:       "sc_pids",
+			Type:       ciliumebpf.Hash,
+			KeySize:    4,
+			ValueSize:  4,
+			MaxEntries: maxPIDs,
+		})
+		if err != nil {
+			return err
+		}
+		for _, pid := range cfg.PIDs {
+			if err := pids.Put(pid, uint32(1)); err != nil {
+				return fmt.Errorf("select processes: %w", err)
+			}
+		}
+	}
+
+	inflight, err := p.newMap(&ciliumebpf.MapSpec{
+		Name:       "sc_inflight",
+		Type:       ciliumebpf.LRUHash,
+		KeySize:    8,
+		ValueSize:  16,
+		MaxEntries: maxInflight,
+	})
+	if err != nil {
+		return err
+	}
+	p.stats, err = p.newMap(&ciliumebpf.MapSpec{
+		Name:       "sc_stats",
+		Type:       ciliumebpf.PerCPUHash,
+		KeySize:    8,
+		ValueSize:  16,
+		MaxEntries: maxStats,
+	})
+	if err != nil {
+		return err
+	}
+
+	enter, err := p.newProgram("sc_enter", enterProgram(syscalls, pids, inflight, cfg.CgroupID))
+	if err != nil {
+		return err
+	}
+	exit, err := p.newProgram("sc_exit", exitProgram(inflight, p.stats))
+	if err != nil {
+		return err
+	}
+
+	// Attach sys_exit first, so that no call is timed from sys_enter without
+	// being counted at its exit.
+	for _, tp := range []struct {
+		name string
+		prog *ciliumebpf.Program
+	}{{"sys_exit", exit}, {"sys_enter", enter}} {
+		l, err := link.AttachRawTracepoint(link.RawTracepointOptions{Name: tp.name, Program: tp.prog})
+		if err != nil {
+			return fmt.Errorf("attach raw tracepoint %s: %w", tp.name, err)
+		}
+		p.links = append(p.links, l)
+	}
+	return nil
+}
+
+func (p *Probe) newMap(spec *ciliumebpf.MapSpec) (*ciliumebpf.Map, error) {
+	m, err := ciliumebpf.NewMap(spec)
+	if err != nil {
+		return nil, fmt.Errorf("create %s map: %w", spec.Name, err)
+	}
+	p.maps = append(p.maps, m)
+	return m, nil
+}
+
+func (p *Probe) newProgram(name string, insns asm.Instructions) (*ciliumebpf.Program, error) {
+	prog, err := ciliumebpf.NewProgram(&ciliumebpf.ProgramSpec{
+		Name:         name,
+		Type:         ciliumebpf.RawTracepoint,
+		Instructions: insns,
+		License:      "GPL",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load %s program: %w", name, err)
+	}
+	p.progs = append(p.progs, prog)
+	return prog, nil
+}
+
+// Collect traces syscalls until ctx is done and returns the report.
+func (p *Probe) Collect(ctx context.Context) (*Report, error) {
+	report := &Report{Start: time.Now()}
+	<-ctx.Done()
+	report.End = time.Now()
+
+	stats := make(map[statKey][]statValue)
+	var (
+		key    statKey
+		values []statValue
+	)
+	iter := p.stats.Iterate()
+	for iter.Next(&key, &values) {
+		stats[key] = values
+		values = nil
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("read syscall statistics: %w", err)
+	}
+
+	report.Syscalls = aggregate(stats)
+	return report, nil
+}
+
+// Close detaches the programs and releases their resources.
+func (p *Probe) Close() error {
+	var errs []error
+	for _, l := range p.links {
+		errs = append(errs, l.Close())
+	}
+	for _, prog := range p.progs {
+		errs = append(errs, prog.Close())
+	}
+	for _, m := range p.maps {
+		errs = append(errs, m.Close())
+	}
+	p.links, p.progs, p.maps = nil, nil, nil
+	return errors.Join(errs...)
+}
+
+// enterProgram records when a selected process enters a selected syscall:
+// inflight[pid_tgid] = {nr, ts}. The syscall number is the second argument
+// of sys_enter.
+func enterProgram(syscalls, pids, inflight *ciliumebpf.Map, cgroupID uint64) asm.Instructions {
+	insns := asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.LoadMem(asm.R9, asm.R6, 8, asm.DWord),
+		// Also skips the negative numbers of invalid syscalls.
+		asm.JGE.Imm(asm.R9, maxSyscalls, "exit"),
+
+		asm.StoreMem(asm.RFP, -4, asm.R9, asm.Word),
+		asm.LoadMapPtr(asm.R1, syscalls.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -4),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.Word),
+		asm.JEq.Imm(asm.R1, 0, "exit"),
+	}
+
+	if cgroupID != 0 {
+		insns = append(insns,
+			asm.FnGetCurrentCgroupId.Call(),
+			asm.LoadImm(asm.R1, int64(cgroupID), asm.DWord), // #nosec G115 -- cgroup IDs are inode numbers
+			asm.JNE.Reg(asm.R0, asm.R1, "exit"),
+		)
+	} else {
+		insns = append(insns,
+			asm.FnGetCurrentPidTgid.Call(),
+			asm.RSh.Imm(asm.R0, 32),
+			asm.StoreMem(asm.RFP, -8, asm.R0, asm.Word),
+			asm.LoadMapPtr(asm.R1, pids.FD()),
+			asm.Mov.Reg(asm.R2, asm.RFP),
+			asm.Add.Imm(asm.R2, -8),
+			asm.FnMapLookupElem.Call(),
+			asm.JEq.Imm(asm.R0, 0, "exit"),
+		)
+	}
+
+	return append(insns,
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.StoreMem(asm.RFP, -16, asm.R0, asm.DWord),
+		asm.StoreMem(asm.RFP, -32, asm.R9, asm.DWord),
+		asm.FnKtimeGetNs.Call(),
+		asm.StoreMem(asm.RFP, -24, asm.R0, asm.DWord),
+
+		asm.LoadMapPtr(asm.R1, inflight.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -16),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, -32),
+		asm.Mov.Imm(asm.R4, 0), // BPF_ANY
+		asm.FnMapUpdateElem.Call(),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("exit"),
+		asm.Return(),
+	)
+}
+
+// exitProgram counts a syscall timed by enterProgram in the latency bucket
+// of its duration and, if it failed, in the slot of its error code. The
+// return value is the second argument of sys_exit.
+func exitProgram(inflight, stats *ciliumebpf.Map) asm.Instructions {
+	insns := asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.StoreMem(asm.RFP, -8, asm.R0, asm.DWord),
+
+		// Look up and forget the syscall the thread entered.
+		asm.LoadMapPtr(asm.R1, inflight.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+		asm.LoadMem(asm.R9, asm.R0, 0, asm.DWord),
+		asm.LoadMem(asm.R7, asm.R0, 8, asm.DWord),
+		asm.LoadMapPtr(asm.R1, inflight.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapDeleteElem.Call(),
+
+		// R7 = latency, R8 = return value.
+		asm.FnKtimeGetNs.Call(),
+		asm.Sub.Reg(asm.R0, asm.R7),
+		asm.Mov.Reg(asm.R7, asm.R0),
+		asm.LoadMem(asm.R8, asm.R6, 8, asm.DWord),
+
+		// R2 = log2(R7), by halving.
+		asm.Mov.Reg(asm.R1, asm.R7),
+		asm.Mov.Imm(asm.R2, 0),
+		asm.LoadImm(asm.R3, 0xffffffff, asm.DWord),
+		asm.JLE.Reg(asm.R1, asm.R3, "le32"),
+		asm.RSh.Imm(asm.R1, 32),
+		asm.Add.Imm(asm.R2, 32),
+		asm.JLE.Imm(asm.R1, 0xffff, "le16").WithSymbol("le32"),
+		asm.RSh.Imm(asm.R1, 16),
+		asm.Add.Imm(asm.R2, 16),
+		asm.JLE.Imm(asm.R1, 0xff, "le8").WithSymbol("le16"),
+		asm.RSh.Imm(asm.R1, 8),
+		asm.Add.Imm(asm.R2, 8),
+		asm.JLE.Imm(asm.R1, 0xf, "le4").WithSymbol("le8"),
+		asm.RSh.Imm(asm.R1, 4),
+		asm.Add.Imm(asm.R2, 4),
+		asm.JLE.Imm(asm.R1, 0x3, "le2").WithSymbol("le4"),
+		asm.RSh.Imm(asm.R1, 2),
+		asm.Add.Imm(asm.R2, 2),
+		asm.JLE.Imm(asm.R1, 0x1, "count").WithSymbol("le2"),
+		asm.Add.Imm(asm.R2, 1),
+
+		asm.StoreMem(asm.RFP, -16, asm.R9, asm.Word).WithSymbol("count"),
+		asm.StoreMem(asm.RFP, -12, asm.R2, asm.Word),
+	}
+	insns = append(insns, incrementStat(stats, -16, "latency")...)
+
+	// Errors are returned as -errno.
+	insns = append(insns,
+		asm.JSGE.Imm(asm.R8, 0, "exit"),
+		asm.Mov.Imm(asm.R1, 0),
+		asm.Sub.Reg(asm.R1, asm.R8),
+		asm.JGT.Imm(asm.R1, maxErrno, "exit"),
+		asm.Or.Imm(asm.R1, errnoSlot),
+		asm.StoreMem(asm.RFP, -12, asm.R1, asm.Word),
+	)
+	insns = append(insns, incrementStat(stats, -16, "errno")...)
+
+	return append(insns,
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("exit"),
+		asm.Return(),
+	)
+}
+
+// incrementStat adds a call taking R7 nanoseconds to stats[key], the key
+// being on the stack at offset key. Labels are prefixed with label.
+func incrementStat(stats *ciliumebpf.Map, key int32, label string) asm.Instructions {
+	return asm.Instructions{
+		asm.LoadMapPtr(asm.R1, stats.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, key),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, label+"_new"),
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.DWord),
+		asm.Add.Imm(asm.R1, 1),
+		asm.StoreMem(asm.R0, 0, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R1, asm.R0, 8, asm.DWord),
+		asm.Add.Reg(asm.R1, asm.R7),
+		asm.StoreMem(asm.R0, 8, asm.R1, asm.DWord),
+		asm.Ja.Label(label + "_done"),
+
+		asm.StoreImm(asm.RFP, -32, 1, asm.DWord).WithSymbol(label + "_new"),
+		asm.StoreMem(asm.RFP, -24, asm.R7, asm.DWord),
+		asm.LoadMapPtr(asm.R1, stats.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, key),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, -32),
+		asm.Mov.Imm(asm.R4, 1), // BPF_NOEXIST
+		asm.FnMapUpdateElem.Call(),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol(label + "_done"),
+	}
+}
@@ -0,0 +1,52 @@
+//go:build linux
+
+package syscalltrace
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollect(t *testing.T) {
+	if len(syscallNames) == 0 {
+		t.Skip("syscall numbers are not known on this platform")
+	}
+	numbers, err := Numbers([]string{"openat"})
+	require.NoError(t, err)
+
+	probe, err := Attach(Config{
+		PIDs:     []uint32{uint32(os.Getpid())}, // #nosec G115
+		Syscalls: numbers,
+	})
+	if err != nil {
+		t.Skipf("cannot attach syscall probes: %v", err)
+	}
+	defer probe.Close() // nolint:errcheck
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	go func() {
+		for i := 0; i < 10; i++ {
+			_, _ = os.Open(missing)
+		}
+	}()
+
+	report, err := probe.Collect(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, report.Syscalls, 1, "only openat is traced")
+	s := report.Syscalls[0]
+	assert.Equal(t, "openat", s.Name)
+	assert.GreaterOrEqual(t, s.Count, uint64(10))
+	assert.GreaterOrEqual(t, s.Errors["ENOENT"], uint64(10))
+	assert.Equal(t, s.Count, s.Latency.Count())
+	assert.Positive(t, s.Total)
+}
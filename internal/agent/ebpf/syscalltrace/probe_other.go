@@ -0,0 +1,33 @@
+//go:build !linux
+
+package syscalltrace
+
+import (
+	"context"
+	"fmt"
+)
+
+// Config selects the syscalls to trace.
+type Config struct {
+	CgroupID uint64
+	PIDs     []uint32
+	Syscalls []uint32
+}
+
+// Probe is a stub for non-Linux platforms.
+type Probe struct{}
+
+// Attach is a stub for non-Linux platforms.
+func Attach(_ Config) (*Probe, error) {
+	return nil, fmt.Errorf("syscall tracing requires Linux")
+}
+
+// Collect is a stub for non-Linux platforms.
+func (p *Probe) Collect(_ context.Context) (*Report, error) {
+	return nil, fmt.Errorf("syscall tracing requires Linux")
+}
+
+// Close is a stub for non-Linux platforms.
+func (p *Probe) Close() error {
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package syscalltrace traces the syscalls of a service with raw
+// tracepoints on sys_enter and sys_exit.
+//
+// The programs only consider the processes of the service, selected by
+// cgroup or by PID, and the requested syscalls. Syscalls are timed and
+// aggregated in the kernel, into a log2 latency histogram and a count of
+// error codes per syscall, so that tracing busy services emits no events.
+package syscalltrace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/runtimetrace"
+)
+
+const (
+	// errnoSlot marks the slots of statKey counting an error code rather
+	// than a latency bucket.
+	errnoSlot = 1 << 16
+
+	// maxErrno is the largest error code returned by syscalls.
+	maxErrno = 4095
+)
+
+// statKey is the key of the syscall statistics map: a syscall and either a
+// latency bucket or errnoSlot|errno.
+type statKey struct {
+	Nr   uint32
+	Slot uint32
+}
+
+// statValue counts the syscalls of a slot and sums their latency.
+type statValue struct {
+	Count uint64
+	SumNs uint64
+}
+
+// Syscall is what the traced processes did with one syscall.
+type Syscall struct {
+	Name    string
+	Number  uint32
+	Count   uint64
+	Total   time.Duration
+	Latency runtimetrace.Histogram
+
+	// Errors counts failed calls by error name, e.g. "ENOENT".
+	Errors map[string]uint64
+}
+
+// ErrorCount returns the number of failed calls.
+func (s *Syscall) ErrorCount() uint64 {
+	var n uint64
+	for _, c := range s.Errors {
+		n += c
+	}
+	return n
+}
+
+// Report is what the traced processes did during a trace, by syscall.
+type Report struct {
+	Start, End time.Time
+
+	// Target describes the traced processes, e.g. their cgroup.
+	Target string
+
+	// Syscalls are sorted by total time, longest first.
+	Syscalls []Syscall
+}
+
+// Numbers returns the numbers of the named syscalls, e.g. "openat".
+func Numbers(names []string) ([]uint32, error) {
+	numbers := make([]uint32, 0, len(names))
+	for _, name := range names {
+		nr, ok := number(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("unknown syscall %q", name)
+		}
+		numbers = append(numbers, nr)
+	}
+	return numbers, nil
+}
+
+// number returns the number of a syscall.
+func number(name string) (uint32, bool) {
+	for nr, n := range syscallNames {
+		if n != "" && n == name {
+			return uint32(nr), true // #nosec G115 -- syscall tables are small
+		}
+	}
+	return 0, false
+}
+
+// Name returns the name of a syscall number, or "syscall_<nr>" if it is
+// unknown.
+func Name(nr uint32) string {
+	if int(nr) < len(syscallNames) && syscallNames[nr] != "" {
+		return syscallNames[nr]
+	}
+	return fmt.Sprintf("syscall_%d", nr)
+}
+
+// errnoName names an error code, e.g. "ENOENT".
+func errnoName(errno uint32) string {
+	if name := unix.ErrnoName(unix.Errno(errno)); name != "" {
+		return name
+	}
+	return fmt.Sprintf("errno %d", errno)
+}
+
+// aggregate sums the per-CPU statistics of the kernel into one entry per
+// syscall, sorted by total time.
+func aggregate(stats map[statKey][]statValue) []Syscall {
+	byNr := make(map[uint32]*Syscall)
+	for key, values := range stats {
+		s := byNr[key.Nr]
+		if s == nil {
+			s = &Syscall{Name: Name(key.Nr), Number: key.Nr}
+			byNr[key.Nr] = s
+		}
+
+		var sum statValue
+		for _, v := range values {
+			sum.Count += v.Count
+			sum.SumNs += v.SumNs
+		}
+
+		if key.Slot&errnoSlot != 0 {
+			if s.Errors == nil {
+				s.Errors = make(map[string]uint64)
+			}
+			s.Errors[errnoName(key.Slot&^errnoSlot)] += sum.Count
+			continue
+		}
+		if key.Slot < runtimetrace.HistogramBuckets {
+			s.Latency[key.Slot] += sum.Count
+		}
+		s.Count += sum.Count
+		s.Total += time.Duration(sum.SumNs) // #nosec G115 -- sums of latencies fit
+	}
+
+	syscalls := make([]Syscall, 0, len(byNr))
+	for _, s := range byNr {
+		syscalls = append(syscalls, *s)
+	}
+	sort.Slice(syscalls, func(i, j int) bool {
+		if syscalls[i].Total != syscalls[j].Total {
+			return syscalls[i].Total > syscalls[j].Total
+		}
+		return syscalls[i].Number < syscalls[j].Number
+	})
+	return syscalls
+}
@@ -0,0 +1,57 @@
+package syscalltrace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumbers(t *testing.T) {
+	if len(syscallNames) == 0 {
+		t.Skip("syscall numbers are not known on this platform")
+	}
+
+	numbers, err := Numbers([]string{"openat", " connect"})
+	require.NoError(t, err)
+	require.Len(t, numbers, 2)
+	assert.Equal(t, "openat", Name(numbers[0]))
+	assert.Equal(t, "connect", Name(numbers[1]))
+
+	_, err = Numbers([]string{"openat", "frobnicate"})
+	assert.ErrorContains(t, err, "frobnicate")
+
+	assert.Equal(t, "syscall_99999", Name(99999))
+}
+
+func TestAggregate(t *testing.T) {
+	const openat, connect = 257, 42
+
+	stats := map[statKey][]statValue{
+		// 3 openat calls of ~1µs and 1 of ~1ms, over two CPUs.
+		{Nr: openat, Slot: 10}: {{Count: 2, SumNs: 2_100}, {Count: 1, SumNs: 1_050}},
+		{Nr: openat, Slot: 20}: {{Count: 1, SumNs: 1_100_000}, {}},
+		// Two of which failed.
+		{Nr: openat, Slot: errnoSlot | 2}:  {{Count: 1}, {Count: 0}},
+		{Nr: openat, Slot: errnoSlot | 13}: {{}, {Count: 1}},
+		{Nr: connect, Slot: 15}:            {{Count: 1, SumNs: 40_000}, {}},
+	}
+
+	syscalls := aggregate(stats)
+	require.Len(t, syscalls, 2)
+
+	s := syscalls[0]
+	assert.Equal(t, uint32(openat), s.Number)
+	assert.Equal(t, uint64(4), s.Count)
+	assert.Equal(t, 1_103_150*time.Nanosecond, s.Total)
+	assert.Equal(t, uint64(3), s.Latency[10])
+	assert.Equal(t, uint64(1), s.Latency[20])
+	assert.Equal(t, uint64(4), s.Latency.Count())
+	assert.Equal(t, map[string]uint64{"ENOENT": 1, "EACCES": 1}, s.Errors)
+	assert.Equal(t, uint64(2), s.ErrorCount())
+
+	assert.Equal(t, uint32(connect), syscalls[1].Number, "sorted by total time")
+	assert.Equal(t, uint64(1), syscalls[1].Count)
+	assert.Zero(t, syscalls[1].ErrorCount())
+}
@@ -0,0 +1,6 @@
+//go:build !linux || (!amd64 && !arm64)
+
+package syscalltrace
+
+// syscallNames is empty where the syscall numbers are not known.
+var syscallNames = [...]string{}
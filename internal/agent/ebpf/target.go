@@ -0,0 +1,99 @@
+package ebpf
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/agent/autodiscovery"
+	"github.com/coral-mesh/coral/internal/sys/proc"
+)
+
+// cgroupRoot is where the cgroup v2 hierarchy is mounted.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// serviceTarget selects the processes of the service running a process, for
+// programs filtering on the current task in the kernel.
+type serviceTarget struct {
+	// cgroupID selects the container or systemd service of the process when
+	// it has its own cgroup v2, including processes started later. It is 0
+	// otherwise.
+	cgroupID   uint64
+	cgroupPath string
+
+	// pids are the process and its descendants when cgroupID is 0.
+	pids []uint32
+
+	// description names the traced processes in reports.
+	description string
+}
+
+// newServiceTarget selects the whole container or systemd service of pid
+// when it has its own cgroup v2, and pid and its descendants otherwise.
+func newServiceTarget(logger zerolog.Logger, pid uint32) (*serviceTarget, error) {
+	if source, ok := autodiscovery.SourceOf(int(pid)); ok {
+		id, err := cgroupID(source.CgroupPath)
+		if err == nil {
+			return &serviceTarget{
+				cgroupID:    id,
+				cgroupPath:  source.CgroupPath,
+				description: "cgroup " + source.CgroupPath,
+			}, nil
+		}
+		logger.Debug().Err(err).Str("cgroup", source.CgroupPath).Msg("Cannot trace cgroup, tracing processes")
+	}
+
+	pids, err := proc.Descendants(int(pid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	t := &serviceTarget{description: fmt.Sprintf("PID %d and %d descendants", pid, len(pids)-1)}
+	for _, p := range pids {
+		t.pids = append(t.pids, uint32(p)) // #nosec G115 -- PIDs are positive
+	}
+	return t, nil
+}
+
+// processes returns the PIDs of the processes currently targeted.
+func (t *serviceTarget) processes() []int {
+	if t.cgroupID == 0 {
+		pids := make([]int, 0, len(t.pids))
+		for _, pid := range t.pids {
+			pids = append(pids, int(pid))
+		}
+		return pids
+	}
+
+	data, err := os.ReadFile(filepath.Join(cgroupRoot, t.cgroupPath, "cgroup.procs")) // #nosec G304 -- path from /proc/PID/cgroup
+	if err != nil {
+		return nil
+	}
+	var pids []int
+	for _, line := range strings.Fields(string(data)) {
+		if pid, err := strconv.Atoi(line); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+// cgroupID returns the ID of a cgroup v2, the inode of its directory.
+func cgroupID(cgroupPath string) (uint64, error) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return 0, fmt.Errorf("cgroup v2 is not mounted at %s", cgroupRoot)
+	}
+	info, err := os.Stat(filepath.Join(cgroupRoot, cgroupPath))
+	if err != nil {
+		return 0, err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, fmt.Errorf("cannot read the inode of %s", cgroupPath)
+	}
+	return stat.Ino, nil
+}
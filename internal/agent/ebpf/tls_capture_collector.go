@@ -0,0 +1,272 @@
+package ebpf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/disasm"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/tlscapture"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/uprobe"
+)
+
+const (
+	// maxTLSCaptureEvents bounds the records kept by a collector.
+	maxTLSCaptureEvents = 10000
+
+	// tlsReadTimeout bounds how long the reader blocks, so that it notices
+	// cancellation and expires idle connections.
+	tlsReadTimeout = 500 * time.Millisecond
+
+	// tlsConnExpiryInterval is how often idle connections are forgotten.
+	tlsConnExpiryInterval = 10 * time.Second
+
+	// goTLSRead and goTLSWrite are the crypto/tls functions probed in Go
+	// binaries.
+	goTLSRead  = "crypto/tls.(*Conn).Read"
+	goTLSWrite = "crypto/tls.(*Conn).Write"
+)
+
+// TLSCaptureCollector implements the Collector interface for capturing the
+// plaintext of a service's TLS connections with uprobes on its TLS library.
+// Captured reads and writes are reported as UprobeEvents of type "tls".
+type TLSCaptureCollector struct {
+	logger  zerolog.Logger
+	config  *TLSCaptureConfig
+	library tlscapture.Library
+	matcher *tlscapture.Matcher
+	probe   *tlscapture.Probe
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	events []*agentv1.UprobeEvent
+	mu     sync.Mutex
+}
+
+// NewTLSCaptureCollector creates a new TLS capture collector.
+func NewTLSCaptureCollector(logger zerolog.Logger, config *TLSCaptureConfig) (*TLSCaptureCollector, error) {
+	if config == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if config.PID == 0 {
+		return nil, fmt.Errorf("PID is required")
+	}
+
+	library, err := tlscapture.ParseLibrary(config.Library)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TLSCaptureCollector{
+		logger:  logger.With().Str("collector", "tls_capture").Logger(),
+		config:  config,
+		library: library,
+		matcher: tlscapture.NewMatcher(config.Match),
+	}, nil
+}
+
+// Start locates the TLS library of the service and attaches the probes.
+func (c *TLSCaptureCollector) Start(ctx context.Context) error {
+	probeConfig, err := c.locate(ctx)
+	if err != nil {
+		return err
+	}
+	probeConfig.PID = c.config.PID
+	probeConfig.MaxDataBytes = int(c.config.MaxDataBytes)
+
+	c.probe, err = tlscapture.Attach(probeConfig)
+	if err != nil {
+		return fmt.Errorf("failed to attach TLS probes: %w", err)
+	}
+	c.library = probeConfig.Library
+
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.done = make(chan struct{})
+	go c.readRecords(ctx)
+
+	c.logger.Info().
+		Uint32("pid", c.config.PID).
+		Str("library", string(c.library)).
+		Str("path", probeConfig.Path).
+		Str("match", c.config.Match).
+		Msg("Started TLS capture")
+	return nil
+}
+
+// locate finds the read and write functions of the TLS library: crypto/tls
+// if the service is a Go binary using it, OpenSSL otherwise.
+func (c *TLSCaptureCollector) locate(ctx context.Context) (tlscapture.Config, error) {
+	if c.library == "" || c.library == tlscapture.LibraryGo {
+		cfg, err := c.locateGo(ctx)
+		if err == nil || c.library == tlscapture.LibraryGo {
+			return cfg, err
+		}
+		c.logger.Debug().Err(err).Msg("crypto/tls not found, looking for OpenSSL")
+	}
+
+	path, err := tlscapture.FindOpenSSL(c.config.PID)
+	if err != nil {
+		if c.library == "" {
+			return tlscapture.Config{}, fmt.Errorf("no supported TLS library found in process %d: %w", c.config.PID, err)
+		}
+		return tlscapture.Config{}, err
+	}
+	return tlscapture.Config{
+		Library: tlscapture.LibraryOpenSSL,
+		Path:    path,
+		Read:    tlscapture.Function{Symbol: tlscapture.OpenSSLRead},
+		Write:   tlscapture.Function{Symbol: tlscapture.OpenSSLWrite},
+	}, nil
+}
+
+// locateGo finds crypto/tls.(*Conn).Read and Write and their RET
+// instructions in the service's binary.
+func (c *TLSCaptureCollector) locateGo(ctx context.Context) (tlscapture.Config, error) {
+	discovery, err := NewDiscoveryService(DefaultDiscoveryConfig(slog.Default()))
+	if err != nil {
+		return tlscapture.Config{}, fmt.Errorf("failed to create discovery service: %w", err)
+	}
+	defer discovery.Close() // nolint:errcheck
+
+	cfg := tlscapture.Config{
+		Library: tlscapture.LibraryGo,
+		Path:    fmt.Sprintf("/proc/%d/exe", c.config.PID),
+	}
+	for _, fn := range []struct {
+		name string
+		dst  *tlscapture.Function
+	}{{goTLSRead, &cfg.Read}, {goTLSWrite, &cfg.Write}} {
+		result, err := discovery.DiscoverFunction(ctx, "", c.config.PID, fn.name)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to discover %s: %w", fn.name, err)
+		}
+		meta := result.Metadata
+		if !meta.HasSize || meta.SizeBytes == 0 {
+			return cfg, fmt.Errorf("size of %s is unknown, its returns cannot be probed", fn.name)
+		}
+
+		// A probe at a wrong offset corrupts the instruction it lands on.
+		if err := uprobe.ValidateOffset(c.config.PID, meta.Offset); errors.Is(err, uprobe.ErrOffsetMismatch) {
+			return cfg, fmt.Errorf("refusing to probe %s: %w", fn.name, err)
+		}
+
+		returns, err := disasm.NewNativeDisassembler().FindRETOffsets(cfg.Path, meta.Offset, meta.SizeBytes)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to disassemble %s: %w", fn.name, err)
+		}
+		*fn.dst = tlscapture.Function{Symbol: fn.name, Address: meta.Offset, Returns: returns}
+	}
+	return cfg, nil
+}
+
+// Stop detaches the probes.
+func (c *TLSCaptureCollector) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	if c.probe != nil {
+		if err := c.probe.Close(); err != nil {
+			c.logger.Error().Err(err).Msg("Error detaching TLS probes")
+		}
+		c.probe = nil
+	}
+
+	c.logger.Info().Msg("TLS capture stopped")
+	return nil
+}
+
+// GetEvents retrieves the captured reads and writes.
+func (c *TLSCaptureCollector) GetEvents() ([]*meshv1.EbpfEvent, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	events := make([]*meshv1.EbpfEvent, len(c.events))
+	for i, event := range c.events {
+		events[i] = &meshv1.EbpfEvent{
+			Timestamp:   event.Timestamp,
+			CollectorId: "tls-capture-" + string(c.library),
+			ServiceName: c.config.ServiceName,
+			Payload: &meshv1.EbpfEvent_UprobeEvent{
+				UprobeEvent: event,
+			},
+		}
+	}
+
+	// Events are kept for historical queries until the collector stops.
+	return events, nil
+}
+
+// readRecords reads captured records until ctx is canceled.
+func (c *TLSCaptureCollector) readRecords(ctx context.Context) {
+	defer close(c.done)
+
+	lastExpiry := time.Now()
+	for ctx.Err() == nil {
+		c.probe.SetDeadline(time.Now().Add(tlsReadTimeout))
+		record, err := c.probe.Read()
+
+		now := time.Now()
+		if now.Sub(lastExpiry) > tlsConnExpiryInterval {
+			c.matcher.Expire(now)
+			lastExpiry = now
+		}
+
+		switch {
+		case errors.Is(err, os.ErrDeadlineExceeded):
+			continue
+		case errors.Is(err, uprobe.ErrClosed):
+			return
+		case err != nil:
+			c.logger.Debug().Err(err).Msg("Skipping undecodable TLS record")
+			continue
+		}
+
+		if c.matcher.Match(record, now) {
+			c.appendEvent(record, now)
+		}
+	}
+}
+
+// appendEvent redacts and buffers a captured record and forwards it to the
+// OnEvent hook.
+func (c *TLSCaptureCollector) appendEvent(record *tlscapture.Record, now time.Time) {
+	event := &agentv1.UprobeEvent{
+		Timestamp:    timestamppb.New(now),
+		ServiceName:  c.config.ServiceName,
+		FunctionName: "tls:" + string(c.library),
+		EventType:    "tls",
+		Pid:          int32(record.PID), // #nosec G115
+		Tid:          int32(record.TID), // #nosec G115
+		Tls: &agentv1.TlsData{
+			Library:      string(c.library),
+			Direction:    record.Direction.String(),
+			ConnectionId: record.Conn,
+			Data:         record.Data,
+			Length:       record.Length,
+			Truncated:    record.Truncated(),
+		},
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.config.Redactor.UprobeEvent(event)
+	c.events = append(c.events, event)
+	if len(c.events) > maxTLSCaptureEvents {
+		c.events = c.events[1:] // Drop oldest
+	}
+
+	if c.config.OnEvent != nil {
+		c.config.OnEvent(event)
+	}
+}
@@ -0,0 +1,80 @@
+package tlscapture
+
+import (
+	"bufio"
+	"debug/elf"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OpenSSL functions probed for plaintext.
+const (
+	OpenSSLRead  = "SSL_read"
+	OpenSSLWrite = "SSL_write"
+)
+
+// FindOpenSSL returns the file providing the OpenSSL API to pid, as seen
+// from the agent: the libssl loaded by the process, or its executable when
+// it links OpenSSL statically.
+func FindOpenSSL(pid uint32) (string, error) {
+	procDir := fmt.Sprintf("/proc/%d", pid)
+
+	maps, err := os.Open(procDir + "/maps") // #nosec G304: path is built from a PID
+	if err != nil {
+		return "", fmt.Errorf("open memory maps: %w", err)
+	}
+	lib, err := libsslPath(maps)
+	maps.Close() // nolint:errcheck
+	if err != nil {
+		return "", fmt.Errorf("read memory maps: %w", err)
+	}
+	if lib != "" {
+		return procDir + "/root" + lib, nil
+	}
+
+	exe := procDir + "/exe"
+	if exportsSymbol(exe, OpenSSLWrite) {
+		return exe, nil
+	}
+	return "", fmt.Errorf("process %d does not use OpenSSL", pid)
+}
+
+// libsslPath returns the path of the libssl mapped in the process whose
+// /proc/{pid}/maps is read from maps, or "" if there is none.
+func libsslPath(maps io.Reader) (string, error) {
+	scanner := bufio.NewScanner(maps)
+	for scanner.Scan() {
+		// address perms offset dev inode [pathname]
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		path := strings.Join(fields[5:], " ")
+		if strings.HasPrefix(filepath.Base(path), "libssl.so") {
+			return path, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// exportsSymbol reports whether the ELF file at path defines symbol.
+func exportsSymbol(path, symbol string) bool {
+	f, err := elf.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close() // nolint:errcheck
+
+	for _, load := range []func() ([]elf.Symbol, error){f.DynamicSymbols, f.Symbols} {
+		syms, _ := load()
+		for _, sym := range syms {
+			if sym.Name == symbol && sym.Section != elf.SHN_UNDEF {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,382 @@
+//go:build linux
+
+package tlscapture
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	ciliumebpf "github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+	"github.com/cilium/ebpf/link"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/uprobe"
+)
+
+// maxInflightCalls bounds the reads and writes tracked between entry and
+// return.
+const maxInflightCalls = 4096
+
+// Config configures the functions to probe.
+type Config struct {
+	Library Library
+
+	// PID is the process to capture; probes fire for it only.
+	PID uint32
+
+	// Path is the executable or shared library containing the functions,
+	// as seen from the agent.
+	Path string
+
+	// Read and Write locate the read and write functions of the library.
+	Read, Write Function
+
+	// MaxDataBytes is the plaintext kept per read or write, up to
+	// MaxDataBytes. 0 uses DefaultMaxDataBytes.
+	MaxDataBytes int
+}
+
+// Function locates a probed function.
+type Function struct {
+	// Symbol names the function. It is resolved when Address is 0.
+	Symbol string
+
+	// Address is the file offset of the function entry.
+	Address uint64
+
+	// Returns are the offsets of the RET instructions of the function,
+	// relative to Address. Required for Go functions: uretprobes corrupt
+	// Go stacks, so returns are probed at each RET instruction instead.
+	Returns []uint64
+}
+
+// Probe captures the TLS plaintext of a process.
+type Probe struct {
+	maps   []*ciliumebpf.Map
+	progs  []*ciliumebpf.Program
+	links  []link.Link
+	reader uprobe.EventReader
+}
+
+// callingConvention locates, in the registers saved at a probe, the first
+// two arguments and the return value of the probed functions, as offsets
+// in struct pt_regs.
+type callingConvention struct {
+	arg0, arg1, ret int16
+
+	// goroutine is the register holding the current goroutine in Go code,
+	// or -1. Calls are matched between entry and return by goroutine in Go
+	// code, since goroutines move between threads while blocked, and by
+	// thread otherwise.
+	goroutine int16
+}
+
+// conventionFor returns the calling convention of library on goarch.
+func conventionFor(library Library, goarch string) (callingConvention, error) {
+	switch {
+	case goarch == "amd64" && library == LibraryGo:
+		// Go register ABI: RAX, RBX for arguments and results, g in R14.
+		return callingConvention{arg0: 80, arg1: 40, ret: 80, goroutine: 8}, nil
+	case goarch == "amd64":
+		// System V: RDI, RSI for arguments, RAX for the result.
+		return callingConvention{arg0: 112, arg1: 104, ret: 80, goroutine: -1}, nil
+	case goarch == "arm64" && library == LibraryGo:
+		// Go register ABI: X0, X1 for arguments and results, g in X28.
+		return callingConvention{arg0: 0, arg1: 8, ret: 0, goroutine: 28 * 8}, nil
+	case goarch == "arm64":
+		// AAPCS64: X0, X1 for arguments, X0 for the result.
+		return callingConvention{arg0: 0, arg1: 8, ret: 0, goroutine: -1}, nil
+	default:
+		return callingConvention{}, fmt.Errorf("TLS capture is not supported on %s", goarch)
+	}
+}
+
+// Attach loads the capture programs and attaches them to the read and
+// write functions of the library.
+func Attach(cfg Config) (*Probe, error) {
+	conv, err := conventionFor(cfg.Library, runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+
+	maxData := cfg.MaxDataBytes
+	if maxData <= 0 {
+		maxData = DefaultMaxDataBytes
+	}
+	maxData = min(maxData, MaxDataBytes)
+
+	p := &Probe{}
+	if err := p.attach(cfg, conv, maxData); err != nil {
+		_ = p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Probe) attach(cfg Config, conv callingConvention, maxData int) error {
+	calls, err := p.newMap(&ciliumebpf.MapSpec{
+		Name:       "tls_calls",
+		Type:       ciliumebpf.LRUHash,
+		KeySize:    8,
+		ValueSize:  24,
+		MaxEntries: maxInflightCalls,
+	})
+	if err != nil {
+		return err
+	}
+	scratch, err := p.newMap(&ciliumebpf.MapSpec{
+		Name:       "tls_scratch",
+		Type:       ciliumebpf.PerCPUArray,
+		KeySize:    4,
+		ValueSize:  recordHeaderSize + MaxDataBytes,
+		MaxEntries: 1,
+	})
+	if err != nil {
+		return err
+	}
+	events, err := p.newMap(&ciliumebpf.MapSpec{
+		Name: "tls_events",
+		Type: ciliumebpf.PerfEventArray,
+	})
+	if err != nil {
+		return err
+	}
+
+	writeEntry, err := p.newProgram("tls_write_entry", entryProgram(conv, calls, DirectionWrite))
+	if err != nil {
+		return err
+	}
+	readEntry, err := p.newProgram("tls_read_entry", entryProgram(conv, calls, DirectionRead))
+	if err != nil {
+		return err
+	}
+	ret, err := p.newProgram("tls_return", returnProgram(conv, calls, scratch, events, maxData))
+	if err != nil {
+		return err
+	}
+
+	exe, err := link.OpenExecutable(cfg.Path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", cfg.Path, err)
+	}
+	if err := p.attachFunction(exe, cfg, cfg.Write, writeEntry, ret); err != nil {
+		return err
+	}
+	if err := p.attachFunction(exe, cfg, cfg.Read, readEntry, ret); err != nil {
+		return err
+	}
+
+	p.reader, err = uprobe.NewEventReader(events, 0)
+	return err
+}
+
+// attachFunction attaches the entry program to fn and the return program
+// to its returns.
+func (p *Probe) attachFunction(exe *link.Executable, cfg Config, fn Function, entry, ret *ciliumebpf.Program) error {
+	opts := func(offset uint64) *link.UprobeOptions {
+		return &link.UprobeOptions{
+			Address: fn.Address,
+			Offset:  offset,
+			PID:     int(cfg.PID), //nolint:gosec // G115: PIDs are small positive integers.
+		}
+	}
+
+	l, err := exe.Uprobe(fn.Symbol, entry, opts(0))
+	if err != nil {
+		return fmt.Errorf("attach uprobe to %s: %w", fn.Symbol, err)
+	}
+	p.links = append(p.links, l)
+
+	if cfg.Library != LibraryGo {
+		l, err := exe.Uretprobe(fn.Symbol, ret, opts(0))
+		if err != nil {
+			return fmt.Errorf("attach uretprobe to %s: %w", fn.Symbol, err)
+		}
+		p.links = append(p.links, l)
+		return nil
+	}
+
+	if len(fn.Returns) == 0 {
+		return fmt.Errorf("no return instructions found in %s", fn.Symbol)
+	}
+	for _, offset := range fn.Returns {
+		l, err := exe.Uprobe(fn.Symbol, ret, opts(offset))
+		if err != nil {
+			return fmt.Errorf("attach return uprobe to %s+0x%x: %w", fn.Symbol, offset, err)
+		}
+		p.links = append(p.links, l)
+	}
+	return nil
+}
+
+func (p *Probe) newMap(spec *ciliumebpf.MapSpec) (*ciliumebpf.Map, error) {
+	m, err := ciliumebpf.NewMap(spec)
+	if err != nil {
+		return nil, fmt.Errorf("create %s map: %w", spec.Name, err)
+	}
+	p.maps = append(p.maps, m)
+	return m, nil
+}
+
+func (p *Probe) newProgram(name string, insns asm.Instructions) (*ciliumebpf.Program, error) {
+	prog, err := ciliumebpf.NewProgram(&ciliumebpf.ProgramSpec{
+		Name:         name,
+		Type:         ciliumebpf.Kprobe,
+		Instructions: insns,
+		License:      "GPL",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load %s program: %w", name, err)
+	}
+	p.progs = append(p.progs, prog)
+	return prog, nil
+}
+
+// Read blocks until a record is captured, the deadline passes or the probe
+// is closed.
+func (p *Probe) Read() (*Record, error) {
+	raw, err := p.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	return decodeRecord(raw)
+}
+
+// SetDeadline sets the deadline for the next Read calls.
+func (p *Probe) SetDeadline(t time.Time) {
+	p.reader.SetDeadline(t)
+}
+
+// Close detaches the probes and releases their resources.
+func (p *Probe) Close() error {
+	var errs []error
+	for _, l := range p.links {
+		errs = append(errs, l.Close())
+	}
+	if p.reader != nil {
+		errs = append(errs, p.reader.Close())
+	}
+	for _, prog := range p.progs {
+		errs = append(errs, prog.Close())
+	}
+	for _, m := range p.maps {
+		errs = append(errs, m.Close())
+	}
+	p.links, p.reader, p.progs, p.maps = nil, nil, nil, nil
+	return errors.Join(errs...)
+}
+
+// callKey stores the key of the current call at fp-8: the goroutine in Go
+// code, the thread otherwise. The context must be in R6.
+func callKey(conv callingConvention) asm.Instructions {
+	if conv.goroutine >= 0 {
+		return asm.Instructions{
+			asm.LoadMem(asm.R0, asm.R6, conv.goroutine, asm.DWord),
+			asm.StoreMem(asm.RFP, -8, asm.R0, asm.DWord),
+		}
+	}
+	return asm.Instructions{
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.StoreMem(asm.RFP, -8, asm.R0, asm.DWord),
+	}
+}
+
+// entryProgram records the connection and buffer of a read or write call,
+// {conn, buf, direction}, in calls.
+func entryProgram(conv callingConvention, calls *ciliumebpf.Map, dir Direction) asm.Instructions {
+	insns := asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+	}
+	insns = append(insns, callKey(conv)...)
+	return append(insns,
+		asm.LoadMem(asm.R1, asm.R6, conv.arg0, asm.DWord),
+		asm.StoreMem(asm.RFP, -32, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R1, asm.R6, conv.arg1, asm.DWord),
+		asm.StoreMem(asm.RFP, -24, asm.R1, asm.DWord),
+		asm.StoreImm(asm.RFP, -16, int64(dir), asm.DWord),
+
+		asm.LoadMapPtr(asm.R1, calls.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, -32),
+		asm.Mov.Imm(asm.R4, 0), // BPF_ANY
+		asm.FnMapUpdateElem.Call(),
+
+		asm.Mov.Imm(asm.R0, 0),
+		asm.Return(),
+	)
+}
+
+// returnProgram emits a record with the data transferred by the call
+// recorded by the entry program, when it returns a positive byte count.
+func returnProgram(conv callingConvention, calls, scratch, events *ciliumebpf.Map, maxData int) asm.Instructions {
+	insns := asm.Instructions{
+		asm.Mov.Reg(asm.R6, asm.R1),
+	}
+	insns = append(insns, callKey(conv)...)
+	return append(insns,
+		// Look up and forget the call.
+		asm.LoadMapPtr(asm.R1, calls.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+		asm.LoadMem(asm.R1, asm.R0, 0, asm.DWord),
+		asm.StoreMem(asm.RFP, -24, asm.R1, asm.DWord),
+		asm.LoadMem(asm.R9, asm.R0, 8, asm.DWord),
+		asm.LoadMem(asm.R1, asm.R0, 16, asm.DWord),
+		asm.StoreMem(asm.RFP, -32, asm.R1, asm.DWord),
+		asm.LoadMapPtr(asm.R1, calls.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -8),
+		asm.FnMapDeleteElem.Call(),
+
+		// Errors and EOF transfer nothing.
+		asm.LoadMem(asm.R8, asm.R6, conv.ret, asm.DWord),
+		asm.JSLE.Imm(asm.R8, 0, "exit"),
+
+		asm.StoreImm(asm.RFP, -12, 0, asm.Word),
+		asm.LoadMapPtr(asm.R1, scratch.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, -12),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, "exit"),
+		asm.Mov.Reg(asm.R7, asm.R0),
+
+		// Header.
+		asm.LoadMem(asm.R1, asm.RFP, -24, asm.DWord),
+		asm.StoreMem(asm.R7, 0, asm.R1, asm.DWord),
+		asm.FnGetCurrentPidTgid.Call(),
+		asm.StoreMem(asm.R7, 12, asm.R0, asm.Word),
+		asm.RSh.Imm(asm.R0, 32),
+		asm.StoreMem(asm.R7, 8, asm.R0, asm.Word),
+		asm.StoreMem(asm.R7, 16, asm.R8, asm.Word),
+		asm.LoadMem(asm.R1, asm.RFP, -32, asm.DWord),
+		asm.StoreMem(asm.R7, 24, asm.R1, asm.Byte),
+
+		// Data, up to maxData bytes.
+		asm.JLE.Imm(asm.R8, int32(maxData), "copy"), // #nosec G115 -- maxData <= MaxDataBytes
+		asm.Mov.Imm(asm.R8, int32(maxData)),         // #nosec G115
+		asm.StoreMem(asm.R7, 20, asm.R8, asm.Word).WithSymbol("copy"),
+		asm.Mov.Reg(asm.R1, asm.R7),
+		asm.Add.Imm(asm.R1, recordHeaderSize),
+		asm.Mov.Reg(asm.R2, asm.R8),
+		asm.Mov.Reg(asm.R3, asm.R9),
+		asm.FnProbeReadUser.Call(),
+		asm.JNE.Imm(asm.R0, 0, "exit"),
+
+		asm.Mov.Reg(asm.R1, asm.R6),
+		asm.LoadMapPtr(asm.R2, events.FD()),
+		asm.LoadImm(asm.R3, 0xffffffff, asm.DWord), // BPF_F_CURRENT_CPU
+		asm.Mov.Reg(asm.R4, asm.R7),
+		asm.Mov.Reg(asm.R5, asm.R8),
+		asm.Add.Imm(asm.R5, recordHeaderSize),
+		asm.FnPerfEventOutput.Call(),
+
+		asm.Mov.Imm(asm.R0, 0).WithSymbol("exit"),
+		asm.Return(),
+	)
+}
@@ -0,0 +1,129 @@
+//go:build linux
+
+package tlscapture
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/gosym"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/disasm"
+)
+
+// goFunction locates a function of the test binary from its pclntab, which
+// is kept when the symbol table is stripped.
+func goFunction(t *testing.T, path, name string) Function {
+	t.Helper()
+
+	f, err := elf.Open(path)
+	require.NoError(t, err)
+	defer f.Close() // nolint:errcheck
+
+	pclntab := f.Section(".gopclntab")
+	text := f.Section(".text")
+	if pclntab == nil || text == nil {
+		t.Skip("test binary has no pclntab")
+	}
+	data, err := pclntab.Data()
+	require.NoError(t, err)
+	table, err := gosym.NewTable(nil, gosym.NewLineTable(data, text.Addr))
+	require.NoError(t, err)
+
+	fn := table.LookupFunc(name)
+	if fn == nil {
+		t.Skipf("function %s not found in test binary", name)
+	}
+	for _, prog := range f.Progs {
+		if prog.Type != elf.PT_LOAD || fn.Entry < prog.Vaddr || fn.Entry >= prog.Vaddr+prog.Memsz {
+			continue
+		}
+		located := Function{Symbol: name, Address: fn.Entry - prog.Vaddr + prog.Off}
+		located.Returns, err = disasm.NewNativeDisassembler().FindRETOffsets(path, located.Address, fn.End-fn.Entry)
+		require.NoError(t, err)
+		return located
+	}
+	t.Fatalf("function %s is not in a loadable segment", name)
+	return Function{}
+}
+
+func TestAttachGo(t *testing.T) {
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	probe, err := Attach(Config{
+		Library:      LibraryGo,
+		PID:          uint32(os.Getpid()), // #nosec G115
+		Path:         exe,
+		Read:         goFunction(t, exe, "crypto/tls.(*Conn).Read"),
+		Write:        goFunction(t, exe, "crypto/tls.(*Conn).Write"),
+		MaxDataBytes: 64,
+	})
+	if err != nil {
+		t.Skipf("cannot attach uprobes: %v", err)
+	}
+	defer probe.Close() // nolint:errcheck
+
+	body := bytes.Repeat([]byte("x"), 1000)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + "/tls-capture-test")
+	require.NoError(t, err)
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	// The client writes the request and the server reads it; the server
+	// writes the response and the client reads it.
+	var requestWrites, requestReads, truncated int
+	probe.SetDeadline(time.Now().Add(2 * time.Second))
+	for {
+		r, err := probe.Read()
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			break
+		}
+		require.NoError(t, err)
+		assert.Equal(t, uint32(os.Getpid()), r.PID) // #nosec G115
+		assert.LessOrEqual(t, len(r.Data), 64)
+
+		if bytes.HasPrefix(r.Data, []byte("GET /tls-capture-test HTTP/1.1")) {
+			if r.Direction == DirectionWrite {
+				requestWrites++
+			} else {
+				requestReads++
+			}
+		}
+		if r.Truncated() {
+			truncated++
+		}
+	}
+
+	assert.Equal(t, 1, requestWrites)
+	assert.Equal(t, 1, requestReads)
+	assert.Positive(t, truncated)
+}
+
+func TestConventionFor(t *testing.T) {
+	conv, err := conventionFor(LibraryGo, "amd64")
+	require.NoError(t, err)
+	assert.Equal(t, int16(8), conv.goroutine)
+
+	conv, err = conventionFor(LibraryOpenSSL, "arm64")
+	require.NoError(t, err)
+	assert.Equal(t, int16(-1), conv.goroutine)
+
+	_, err = conventionFor(LibraryGo, "riscv64")
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+//go:build !linux
+
+package tlscapture
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config configures the functions to probe.
+type Config struct {
+	Library      Library
+	PID          uint32
+	Path         string
+	Read, Write  Function
+	MaxDataBytes int
+}
+
+// Function locates a probed function.
+type Function struct {
+	Symbol  string
+	Address uint64
+	Returns []uint64
+}
+
+// Probe is a stub for non-Linux platforms.
+type Probe struct{}
+
+// Attach is a stub for non-Linux platforms.
+func Attach(_ Config) (*Probe, error) {
+	return nil, fmt.Errorf("TLS capture requires Linux")
+}
+
+// Read is a stub for non-Linux platforms.
+func (p *Probe) Read() (*Record, error) {
+	return nil, fmt.Errorf("TLS capture requires Linux")
+}
+
+// SetDeadline is a stub for non-Linux platforms.
+func (p *Probe) SetDeadline(_ time.Time) {}
+
+// Close is a stub for non-Linux platforms.
+func (p *Probe) Close() error {
+	return nil
+}
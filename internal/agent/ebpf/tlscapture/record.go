@@ -0,0 +1,175 @@
+// Package tlscapture captures the plaintext of TLS connections of a process
+// with uprobes on its TLS library: crypto/tls for Go binaries, OpenSSL
+// (SSL_read and SSL_write) otherwise. Data is read from the caller's buffer
+// when a read or write returns, so only the bytes actually transferred are
+// captured. The eBPF programs are assembled at load time and need no
+// compiler or kernel headers.
+package tlscapture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// Library is a TLS implementation that can be probed.
+type Library string
+
+const (
+	// LibraryGo is Go's crypto/tls.
+	LibraryGo Library = "go"
+
+	// LibraryOpenSSL is OpenSSL's libssl, or a library exporting its API
+	// such as BoringSSL.
+	LibraryOpenSSL Library = "openssl"
+)
+
+// ParseLibrary parses a library name; "" means detect.
+func ParseLibrary(s string) (Library, error) {
+	switch Library(s) {
+	case "", LibraryGo, LibraryOpenSSL:
+		return Library(s), nil
+	default:
+		return "", fmt.Errorf("unknown TLS library %q (supported: go, openssl)", s)
+	}
+}
+
+// Direction tells whether data was written or read by the process.
+type Direction uint8
+
+const (
+	// DirectionWrite is data sent by the process.
+	DirectionWrite Direction = 0
+
+	// DirectionRead is data received by the process.
+	DirectionRead Direction = 1
+)
+
+// String returns "write" or "read".
+func (d Direction) String() string {
+	if d == DirectionRead {
+		return "read"
+	}
+	return "write"
+}
+
+const (
+	// DefaultMaxDataBytes is the plaintext kept per read or write when none
+	// is configured.
+	DefaultMaxDataBytes = 4096
+
+	// MaxDataBytes bounds the plaintext copied per read or write: the
+	// largest TLS record.
+	MaxDataBytes = 16384
+
+	// recordHeaderSize is the size of the record header preceding the data.
+	recordHeaderSize = 32
+)
+
+// Record is the plaintext of one read or write on a TLS connection.
+//
+// The kernel emits records with this layout, in native byte order:
+//
+//	0  conn       u64  connection object address
+//	8  pid        u32
+//	12 tid        u32
+//	16 length     u32  bytes read or written
+//	20 data_len   u32  bytes of data that follow
+//	24 direction  u8
+//	32 data
+type Record struct {
+	Conn      uint64
+	PID       uint32
+	TID       uint32
+	Direction Direction
+	Length    uint32
+	Data      []byte
+}
+
+// Truncated reports whether only part of the data was captured.
+func (r *Record) Truncated() bool {
+	return int(r.Length) > len(r.Data)
+}
+
+// decodeRecord decodes a record emitted by the return programs. Data
+// aliases raw.
+func decodeRecord(raw []byte) (*Record, error) {
+	if len(raw) < recordHeaderSize {
+		return nil, fmt.Errorf("TLS record too short: %d bytes", len(raw))
+	}
+
+	order := binary.NativeEndian
+	r := &Record{
+		Conn:      order.Uint64(raw[0:]),
+		PID:       order.Uint32(raw[8:]),
+		TID:       order.Uint32(raw[12:]),
+		Length:    order.Uint32(raw[16:]),
+		Direction: Direction(raw[24]),
+	}
+
+	dataLen := int(order.Uint32(raw[20:]))
+	if dataLen > len(raw)-recordHeaderSize {
+		return nil, fmt.Errorf("TLS record data length %d exceeds record size %d", dataLen, len(raw))
+	}
+	r.Data = raw[recordHeaderSize : recordHeaderSize+dataLen]
+	return r, nil
+}
+
+const (
+	// maxConns bounds the connections tracked by a matcher.
+	maxConns = 4096
+
+	// connIdleTimeout is how long a connection is tracked without data.
+	connIdleTimeout = 2 * time.Minute
+)
+
+// Matcher selects the connections to capture: those whose plaintext
+// contains a pattern, from the first read or write that contains it. An
+// empty pattern matches every connection.
+//
+// Connections are identified by the address of their connection object,
+// which the library may reuse after the connection is closed; connections
+// are forgotten after connIdleTimeout without data.
+type Matcher struct {
+	pattern []byte
+	conns   map[uint64]time.Time
+}
+
+// NewMatcher creates a matcher for pattern.
+func NewMatcher(pattern string) *Matcher {
+	return &Matcher{
+		pattern: []byte(pattern),
+		conns:   make(map[uint64]time.Time),
+	}
+}
+
+// Match reports whether the record belongs to a matching connection.
+func (m *Matcher) Match(r *Record, now time.Time) bool {
+	if len(m.pattern) == 0 {
+		return true
+	}
+
+	if _, ok := m.conns[r.Conn]; !ok {
+		if !bytes.Contains(r.Data, m.pattern) {
+			return false
+		}
+		if len(m.conns) >= maxConns {
+			m.Expire(now)
+		}
+		if len(m.conns) >= maxConns {
+			return true
+		}
+	}
+	m.conns[r.Conn] = now
+	return true
+}
+
+// Expire forgets connections idle for longer than connIdleTimeout.
+func (m *Matcher) Expire(now time.Time) {
+	for conn, seen := range m.conns {
+		if now.Sub(seen) > connIdleTimeout {
+			delete(m.conns, conn)
+		}
+	}
+}
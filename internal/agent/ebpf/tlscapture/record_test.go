@@ -0,0 +1,100 @@
+package tlscapture
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rawRecord encodes a record as emitted by the return program.
+func rawRecord(conn uint64, dir Direction, length uint32, data string) []byte {
+	raw := make([]byte, recordHeaderSize, recordHeaderSize+len(data))
+	order := binary.NativeEndian
+	order.PutUint64(raw[0:], conn)
+	order.PutUint32(raw[8:], 1234)
+	order.PutUint32(raw[12:], 1240)
+	order.PutUint32(raw[16:], length)
+	order.PutUint32(raw[20:], uint32(len(data))) // #nosec G115
+	raw[24] = byte(dir)
+	return append(raw, data...)
+}
+
+func TestDecodeRecord(t *testing.T) {
+	r, err := decodeRecord(rawRecord(0xc000123456, DirectionRead, 17, "HTTP/1.1 200 OK\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0xc000123456), r.Conn)
+	assert.Equal(t, uint32(1234), r.PID)
+	assert.Equal(t, uint32(1240), r.TID)
+	assert.Equal(t, DirectionRead, r.Direction)
+	assert.Equal(t, "read", r.Direction.String())
+	assert.Equal(t, "HTTP/1.1 200 OK\r\n", string(r.Data))
+	assert.False(t, r.Truncated())
+
+	r, err = decodeRecord(rawRecord(1, DirectionWrite, 20000, "POST /"))
+	require.NoError(t, err)
+	assert.Equal(t, "write", r.Direction.String())
+	assert.True(t, r.Truncated())
+
+	_, err = decodeRecord(make([]byte, recordHeaderSize-1))
+	assert.Error(t, err)
+
+	raw := rawRecord(1, DirectionWrite, 10, "abc")
+	_, err = decodeRecord(raw[:len(raw)-1])
+	assert.Error(t, err)
+}
+
+func TestMatcher(t *testing.T) {
+	record := func(conn uint64, data string) *Record {
+		return &Record{Conn: conn, Data: []byte(data)}
+	}
+	now := time.Now()
+
+	t.Run("empty pattern", func(t *testing.T) {
+		m := NewMatcher("")
+		assert.True(t, m.Match(record(1, "anything"), now))
+	})
+
+	t.Run("from first match", func(t *testing.T) {
+		m := NewMatcher("Host: api.stripe.com")
+		assert.False(t, m.Match(record(1, "GET / HTTP/1.1\r\nHost: example.com\r\n"), now))
+		assert.True(t, m.Match(record(2, "POST /v1/charges HTTP/1.1\r\nHost: api.stripe.com\r\n"), now))
+		assert.True(t, m.Match(record(2, `{"id": "ch_1"}`), now))
+		assert.False(t, m.Match(record(1, "HTTP/1.1 200 OK\r\n"), now))
+	})
+
+	t.Run("idle connections expire", func(t *testing.T) {
+		m := NewMatcher("secret")
+		assert.True(t, m.Match(record(1, "secret"), now))
+		m.Expire(now.Add(connIdleTimeout + time.Second))
+		assert.False(t, m.Match(record(1, "other"), now))
+	})
+}
+
+func TestParseLibrary(t *testing.T) {
+	for _, s := range []string{"", "go", "openssl"} {
+		lib, err := ParseLibrary(s)
+		require.NoError(t, err)
+		assert.Equal(t, Library(s), lib)
+	}
+	_, err := ParseLibrary("gnutls")
+	assert.Error(t, err)
+}
+
+func TestLibsslPath(t *testing.T) {
+	maps := `55d0c0a00000-55d0c0a02000 r--p 00000000 08:01 1234 /usr/bin/curl
+7f1c2a000000-7f1c2a020000 r--p 00000000 08:01 5678 /usr/lib/x86_64-linux-gnu/libcrypto.so.3
+7f1c2b000000-7f1c2b020000 r-xp 00020000 08:01 5679 /usr/lib/x86_64-linux-gnu/libssl.so.3
+7ffd1c000000-7ffd1c021000 rw-p 00000000 00:00 0 [stack]
+`
+	path, err := libsslPath(strings.NewReader(maps))
+	require.NoError(t, err)
+	assert.Equal(t, "/usr/lib/x86_64-linux-gnu/libssl.so.3", path)
+
+	path, err = libsslPath(strings.NewReader("7ffd1c000000-7ffd1c021000 rw-p 00000000 00:00 0 [stack]\n"))
+	require.NoError(t, err)
+	assert.Empty(t, path)
+}
@@ -1,6 +1,11 @@
 package ebpf
 
-import "time"
+import (
+	"time"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/redact"
+)
 
 // UprobeFilter holds runtime filter criteria applied at the eBPF level (RFD 090).
 // Zero values mean no filter is applied for that dimension, preserving backward compatibility.
@@ -26,8 +31,71 @@ type UprobeConfig struct {
 	Duration      time.Duration
 	Filter        UprobeFilter // Optional kernel-level filter (RFD 090).
 
+	// SampleDivisor further reduces sampling on top of Filter.SampleRate
+	// while the agent sheds load. 0 or 1 = no reduction.
+	SampleDivisor uint32
+
 	// Discovery configuration (optional, uses defaults if nil).
 	DiscoveryConfig *DiscoveryConfig
+
+	// KernelFeatures selects the program variant and BTF source
+	// (optional, probed on start if nil).
+	KernelFeatures *KernelFeatures
+
+	// Redactor masks credentials and personal data in captured arguments
+	// and return values before events are stored or forwarded (optional).
+	Redactor *redact.Engine
+
+	// OnEvent is invoked for every collected event (optional). The manager
+	// uses it to persist events to the local event store.
+	OnEvent func(event *agentv1.UprobeEvent)
+}
+
+// HTTPCaptureConfig contains configuration for an HTTP capture collector.
+type HTTPCaptureConfig struct {
+	ServiceName string
+	PID         uint32 // Process whose network namespace is captured.
+	Port        uint16 // Port the service listens on.
+	Route       string // Route pattern of the requests to capture.
+
+	// SampleRate is the fraction of matching requests captured, in (0, 1].
+	// 0 captures all of them.
+	SampleRate float64
+
+	// CaptureBodies keeps bodies up to MaxBodyBytes (0 = default cap).
+	CaptureBodies bool
+	MaxBodyBytes  uint32
+
+	// Redactor masks credentials and personal data in captured headers,
+	// query strings and bodies (optional).
+	Redactor *redact.Engine
+
+	// OnEvent is invoked for every captured exchange (optional).
+	OnEvent func(event *agentv1.UprobeEvent)
+}
+
+// TLSCaptureConfig contains configuration for a TLS capture collector.
+type TLSCaptureConfig struct {
+	ServiceName string
+	PID         uint32 // Process whose TLS library is probed.
+
+	// Library is the TLS library to probe, "go" or "openssl"; empty detects
+	// it, preferring crypto/tls.
+	Library string
+
+	// Match selects the connections whose plaintext contains it. Empty
+	// captures every connection.
+	Match string
+
+	// MaxDataBytes is the plaintext kept per read or write (0 = default).
+	MaxDataBytes uint32
+
+	// Redactor masks credentials and personal data in captured plaintext
+	// (optional).
+	Redactor *redact.Engine
+
+	// OnEvent is invoked for every captured read or write (optional).
+	OnEvent func(event *agentv1.UprobeEvent)
 }
 
 // FunctionMetadata contains all information needed for uprobe attachment.
@@ -44,13 +112,15 @@ type FunctionMetadata struct {
 
 // ArgumentMetadata describes a function argument.
 type ArgumentMetadata struct {
-	Name   string
-	Type   string // Go type string
-	Offset uint64 // Stack/register offset
+	Name     string
+	Type     string // Go type string
+	Offset   uint64 // Stack/register offset
+	Location string // Register(s) or stack slot at function entry, if known
 }
 
 // ReturnValueMetadata describes a return value.
 type ReturnValueMetadata struct {
+	Name   string
 	Type   string
 	Offset uint64
 }
@@ -2,11 +2,11 @@
 package uprobe
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/cilium/ebpf"
 	"github.com/cilium/ebpf/link"
-	"github.com/cilium/ebpf/ringbuf"
 	"github.com/rs/zerolog"
 )
 
@@ -32,6 +32,10 @@ type AttachConfig struct {
 	// and will miss goroutines that migrate CPUs during blocking calls.
 	PIDFilter int
 
+	// PerfBufferPages sizes the per-CPU perf buffer when the events map is a
+	// perf event array (older kernels). 0 uses DefaultPerfBufferPages.
+	PerfBufferPages int
+
 	// Logger for debug/error messages.
 	Logger zerolog.Logger
 }
@@ -49,8 +53,8 @@ type AttachResult struct {
 	// Each link is attached to a RET instruction offset within the function.
 	ReturnLinks []link.Link
 
-	// Reader is the ring buffer reader for events.
-	Reader *ringbuf.Reader
+	// Reader reads events from the ring buffer or perf buffer.
+	Reader EventReader
 }
 
 // Close cleans up all resources.
@@ -63,10 +67,27 @@ func (r *AttachResult) Close() error {
 		}
 	}
 
+	if err := r.CloseLinks(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors during cleanup: %v", errs)
+	}
+
+	return nil
+}
+
+// CloseLinks detaches all probes but keeps the reader open, so probes can be
+// re-attached to a restarted process without losing buffered events.
+func (r *AttachResult) CloseLinks() error {
+	var errs []error
+
 	if r.ReturnLink != nil {
 		if err := r.ReturnLink.Close(); err != nil {
 			errs = append(errs, fmt.Errorf("close return link: %w", err))
 		}
+		r.ReturnLink = nil
 	}
 
 	// Close all RET-instruction return links (RFD 073).
@@ -77,15 +98,17 @@ func (r *AttachResult) Close() error {
 			}
 		}
 	}
+	r.ReturnLinks = nil
 
 	if r.EntryLink != nil {
 		if err := r.EntryLink.Close(); err != nil {
 			errs = append(errs, fmt.Errorf("close entry link: %w", err))
 		}
+		r.EntryLink = nil
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf("errors during cleanup: %v", errs)
+		return fmt.Errorf("errors closing links: %v", errs)
 	}
 
 	return nil
@@ -141,7 +164,8 @@ func AttachReturnProbes(
 
 // AttachUprobe attaches eBPF uprobe to a function in a binary.
 // It resolves the binary path using /proc/{pid}/exe (works in sidecar mode with
-// shared PID namespace), attaches uprobe/uretprobe, and creates a ring buffer reader.
+// shared PID namespace), attaches uprobe/uretprobe, and creates an event reader
+// matching the events map type (ring buffer or perf event array).
 //
 // The caller is responsible for closing the BPF programs and maps, but must call
 // AttachResult.Close() to clean up links and reader.
@@ -151,14 +175,45 @@ func AttachUprobe(
 	returnProg *ebpf.Program,
 	eventsMap *ebpf.Map,
 ) (*AttachResult, error) {
-	if entryProg == nil {
-		return nil, fmt.Errorf("entry program is required")
-	}
-
 	if eventsMap == nil {
 		return nil, fmt.Errorf("events map is required")
 	}
 
+	result, err := AttachProbes(cfg, entryProg, returnProg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create event reader for the events map.
+	result.Reader, err = NewEventReader(eventsMap, cfg.PerfBufferPages)
+	if err != nil {
+		// Clean up links on error.
+		result.CloseLinks() // nolint:errcheck
+		return nil, err
+	}
+
+	cfg.Logger.Info().
+		Str("binary_path", fmt.Sprintf("/proc/%d/exe", cfg.PID)).
+		Uint64("offset", cfg.Offset).
+		Uint32("pid", cfg.PID).
+		Bool("attach_return", cfg.AttachReturn).
+		Msg("Successfully attached uprobe")
+
+	return result, nil
+}
+
+// AttachProbes attaches the entry uprobe (and uretprobe if requested) without
+// creating an event reader. It is used to re-attach probes to a restarted
+// process while keeping the existing reader.
+func AttachProbes(
+	cfg AttachConfig,
+	entryProg *ebpf.Program,
+	returnProg *ebpf.Program,
+) (*AttachResult, error) {
+	if entryProg == nil {
+		return nil, fmt.Errorf("entry program is required")
+	}
+
 	if cfg.AttachReturn && returnProg == nil {
 		return nil, fmt.Errorf("return program is required when AttachReturn is true")
 	}
@@ -176,7 +231,19 @@ func AttachUprobe(
 		Uint64("offset", cfg.Offset).
 		Msg("Using /proc/{pid}/exe for uprobe attachment")
 
-	// 2. Open executable for uprobe attachment.
+	// 2. Verify the offset points at the function in the live process, so a
+	// stale or miscomputed offset never installs a probe at a wrong address.
+	if err := ValidateOffset(cfg.PID, cfg.Offset); err != nil {
+		if errors.Is(err, ErrOffsetMismatch) {
+			return nil, fmt.Errorf("refusing to attach uprobe: %w", err)
+		}
+		cfg.Logger.Warn().Err(err).
+			Uint32("pid", cfg.PID).
+			Uint64("offset", cfg.Offset).
+			Msg("Unable to validate uprobe offset against process memory, attaching anyway")
+	}
+
+	// 3. Open executable for uprobe attachment.
 	exe, err := link.OpenExecutable(resolvedPath)
 	if err != nil {
 		return nil, fmt.Errorf("open executable (path=%s): %w", resolvedPath, err)
@@ -188,7 +255,7 @@ func AttachUprobe(
 
 	result := &AttachResult{}
 
-	// 3. Attach uprobe (function entry).
+	// 4. Attach uprobe (function entry).
 	// Use Address field for absolute address from SDK (not Offset which is relative).
 	// Pass empty symbol since we're using absolute addressing.
 	cfg.Logger.Debug().
@@ -206,7 +273,7 @@ func AttachUprobe(
 
 	cfg.Logger.Debug().Msg("Successfully attached uprobe to function entry")
 
-	// 4. Attach uretprobe (function return) if requested.
+	// 5. Attach uretprobe (function return) if requested.
 	if cfg.AttachReturn {
 		cfg.Logger.Debug().
 			Uint64("address", cfg.Offset).
@@ -226,23 +293,5 @@ func AttachUprobe(
 		cfg.Logger.Debug().Msg("Successfully attached uretprobe to function return")
 	}
 
-	// 5. Create ring buffer reader for events.
-	result.Reader, err = ringbuf.NewReader(eventsMap)
-	if err != nil {
-		// Clean up links on error.
-		if result.ReturnLink != nil {
-			result.ReturnLink.Close() // nolint:errcheck
-		}
-		result.EntryLink.Close() // nolint:errcheck
-		return nil, fmt.Errorf("create ringbuf reader: %w", err)
-	}
-
-	cfg.Logger.Info().
-		Str("binary_path", resolvedPath).
-		Uint64("offset", cfg.Offset).
-		Uint32("pid", cfg.PID).
-		Bool("attach_return", cfg.AttachReturn).
-		Msg("Successfully attached uprobe")
-
 	return result, nil
 }
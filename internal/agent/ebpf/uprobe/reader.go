@@ -0,0 +1,102 @@
+package uprobe
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/perf"
+	"github.com/cilium/ebpf/ringbuf"
+)
+
+// DefaultPerfBufferPages is the per-CPU perf buffer size (in pages) used when
+// the events map is a perf event array.
+const DefaultPerfBufferPages = 64
+
+// ErrClosed is returned by EventReader.Read after the reader has been closed.
+var ErrClosed = os.ErrClosed
+
+// EventReader reads raw event samples from the events map, independently of
+// whether the program variant emits to a ring buffer or a perf event array.
+type EventReader interface {
+	// Read blocks until a sample is available, the deadline passes, or the
+	// reader is closed.
+	Read() ([]byte, error)
+
+	// SetDeadline sets the deadline for the next Read calls.
+	SetDeadline(t time.Time)
+
+	// Close releases the reader.
+	Close() error
+}
+
+// NewEventReader creates a reader matching the type of eventsMap.
+// perfBufferPages sizes the per-CPU perf buffer; 0 uses DefaultPerfBufferPages.
+func NewEventReader(eventsMap *ebpf.Map, perfBufferPages int) (EventReader, error) {
+	switch eventsMap.Type() {
+	case ebpf.RingBuf:
+		rd, err := ringbuf.NewReader(eventsMap)
+		if err != nil {
+			return nil, fmt.Errorf("create ringbuf reader: %w", err)
+		}
+		return &ringbufReader{rd: rd}, nil
+
+	case ebpf.PerfEventArray:
+		if perfBufferPages <= 0 {
+			perfBufferPages = DefaultPerfBufferPages
+		}
+		rd, err := perf.NewReader(eventsMap, perfBufferPages*os.Getpagesize())
+		if err != nil {
+			return nil, fmt.Errorf("create perf reader: %w", err)
+		}
+		return &perfReader{rd: rd}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported events map type: %s", eventsMap.Type())
+	}
+}
+
+// ringbufReader adapts ringbuf.Reader to EventReader.
+type ringbufReader struct {
+	rd *ringbuf.Reader
+}
+
+func (r *ringbufReader) Read() ([]byte, error) {
+	record, err := r.rd.Read()
+	if err != nil {
+		return nil, err
+	}
+	return record.RawSample, nil
+}
+
+func (r *ringbufReader) SetDeadline(t time.Time) { r.rd.SetDeadline(t) }
+
+func (r *ringbufReader) Close() error { return r.rd.Close() }
+
+// perfReader adapts perf.Reader to EventReader.
+// Records reporting lost samples are skipped.
+type perfReader struct {
+	rd *perf.Reader
+}
+
+func (r *perfReader) Read() ([]byte, error) {
+	for {
+		record, err := r.rd.Read()
+		if err != nil {
+			if errors.Is(err, perf.ErrClosed) {
+				return nil, ErrClosed
+			}
+			return nil, err
+		}
+		if record.LostSamples > 0 {
+			continue
+		}
+		return record.RawSample, nil
+	}
+}
+
+func (r *perfReader) SetDeadline(t time.Time) { r.rd.SetDeadline(t) }
+
+func (r *perfReader) Close() error { return r.rd.Close() }
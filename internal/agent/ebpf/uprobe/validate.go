@@ -0,0 +1,204 @@
+package uprobe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// prologueSize is the number of bytes compared between the binary on disk and
+// the live process memory at the probed function.
+const prologueSize = 16
+
+// ErrOffsetMismatch is returned when the resolved offset does not point at
+// the same code in the running process as in its binary, for instance because
+// the offset was resolved against another build of the binary. A uprobe at
+// such an offset would be installed in the middle of an unrelated instruction.
+var ErrOffsetMismatch = errors.New("uprobe offset does not match the live process")
+
+// memoryMapping is an entry of /proc/{pid}/maps.
+type memoryMapping struct {
+	Start, End uint64
+	Perms      string
+	FileOffset uint64
+	Path       string
+}
+
+// ValidateOffset verifies that the function at the file offset of the
+// executable of pid is loaded where expected: the offset must fall in an
+// executable mapping of the binary, and the bytes at the runtime address
+// (mapping start plus the offset within the mapping, which accounts for the
+// PIE/ASLR load base) must match the bytes on disk.
+//
+// It returns an error wrapping ErrOffsetMismatch when attaching at offset
+// would install a probe at a wrong address, and another error when the
+// process memory cannot be inspected.
+func ValidateOffset(pid uint32, offset uint64) error {
+	procDir := fmt.Sprintf("/proc/%d", pid)
+	exePath := procDir + "/exe"
+
+	target, err := os.Readlink(exePath)
+	if err != nil {
+		return fmt.Errorf("resolve executable: %w", err)
+	}
+
+	mapsFile, err := os.Open(procDir + "/maps") // #nosec G304: path is built from a PID
+	if err != nil {
+		return fmt.Errorf("open memory maps: %w", err)
+	}
+	mappings, err := parseMaps(mapsFile)
+	mapsFile.Close() // nolint:errcheck
+	if err != nil {
+		return fmt.Errorf("parse memory maps: %w", err)
+	}
+
+	mapping, loadBase, err := findMapping(mappings, target, offset)
+	if err != nil {
+		return err
+	}
+	addr := mapping.Start + (offset - mapping.FileOffset)
+
+	size := uint64(prologueSize)
+	if remaining := mapping.End - addr; remaining < size {
+		size = remaining
+	}
+
+	onDisk, err := readAt(exePath, offset, size)
+	if err != nil {
+		return fmt.Errorf("read %s at offset 0x%x: %w", target, offset, err)
+	}
+	live, err := readAt(procDir+"/mem", addr, size)
+	if err != nil {
+		return fmt.Errorf("read process memory at 0x%x: %w", addr, err)
+	}
+
+	if !samePrologue(onDisk, live) {
+		return fmt.Errorf("%w: offset 0x%x of %s maps to address 0x%x (load base 0x%x), "+
+			"which holds %x in memory but %x on disk",
+			ErrOffsetMismatch, offset, target, addr, loadBase, live, onDisk)
+	}
+
+	return nil
+}
+
+// parseMaps parses the content of /proc/{pid}/maps.
+func parseMaps(r io.Reader) ([]memoryMapping, error) {
+	var mappings []memoryMapping
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// address perms offset dev inode [pathname]
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		start, end, ok := strings.Cut(fields[0], "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid address range %q", fields[0])
+		}
+		m := memoryMapping{Perms: fields[1]}
+		var err error
+		if m.Start, err = strconv.ParseUint(start, 16, 64); err != nil {
+			return nil, fmt.Errorf("invalid start address %q: %w", start, err)
+		}
+		if m.End, err = strconv.ParseUint(end, 16, 64); err != nil {
+			return nil, fmt.Errorf("invalid end address %q: %w", end, err)
+		}
+		if m.FileOffset, err = strconv.ParseUint(fields[2], 16, 64); err != nil {
+			return nil, fmt.Errorf("invalid offset %q: %w", fields[2], err)
+		}
+		if len(fields) > 5 {
+			// Paths may contain spaces and end with " (deleted)".
+			m.Path = strings.Join(fields[5:], " ")
+		}
+
+		mappings = append(mappings, m)
+	}
+
+	return mappings, scanner.Err()
+}
+
+// findMapping returns the mapping of path that contains the file offset, and
+// the load base of path (the start of its lowest mapping). The mapping must be
+// executable.
+func findMapping(mappings []memoryMapping, path string, offset uint64) (memoryMapping, uint64, error) {
+	var (
+		found    *memoryMapping
+		loadBase uint64
+		mapped   bool
+	)
+	for i := range mappings {
+		m := &mappings[i]
+		if m.Path != path {
+			continue
+		}
+		if !mapped || m.Start < loadBase {
+			loadBase = m.Start
+		}
+		mapped = true
+		if found == nil && offset >= m.FileOffset && offset-m.FileOffset < m.End-m.Start {
+			found = m
+		}
+	}
+
+	switch {
+	case !mapped:
+		return memoryMapping{}, 0, fmt.Errorf("%w: %s is not mapped in the process", ErrOffsetMismatch, path)
+	case found == nil:
+		return memoryMapping{}, loadBase, fmt.Errorf("%w: offset 0x%x is outside the mapped segments of %s (load base 0x%x)",
+			ErrOffsetMismatch, offset, path, loadBase)
+	case !strings.Contains(found.Perms, "x"):
+		return memoryMapping{}, loadBase, fmt.Errorf("%w: offset 0x%x of %s is in a non-executable segment (%s at 0x%x, load base 0x%x)",
+			ErrOffsetMismatch, offset, path, found.Perms, found.Start, loadBase)
+	}
+
+	return *found, loadBase, nil
+}
+
+// samePrologue reports whether the live bytes of a function match its bytes
+// on disk. A breakpoint at the first instruction is accepted: it is how the
+// kernel installs a uprobe already attached to the function, for instance by
+// a debug session.
+func samePrologue(onDisk, live []byte) bool {
+	if bytes.Equal(onDisk, live) {
+		return true
+	}
+	if len(onDisk) != len(live) {
+		return false
+	}
+
+	switch runtime.GOARCH {
+	case "amd64":
+		// int3.
+		return len(live) >= 1 && live[0] == 0xcc && bytes.Equal(onDisk[1:], live[1:])
+	case "arm64":
+		// BRK #0x5, the arm64 uprobe breakpoint.
+		return len(live) >= 4 && binary.LittleEndian.Uint32(live) == 0xd42000a0 &&
+			bytes.Equal(onDisk[4:], live[4:])
+	}
+
+	return false
+}
+
+// readAt reads size bytes of the file at path from offset.
+func readAt(path string, offset, size uint64) ([]byte, error) {
+	f, err := os.Open(path) // #nosec G304: path is under /proc/{pid}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint:errcheck
+
+	buf := make([]byte, size)
+	if _, err := f.ReadAt(buf, int64(offset)); err != nil { // #nosec G115
+		return nil, err
+	}
+
+	return buf, nil
+}
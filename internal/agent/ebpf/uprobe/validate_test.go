@@ -0,0 +1,106 @@
+package uprobe
+
+import (
+	"debug/elf"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMaps = `00400000-00401000 r--p 00000000 08:01 1234 /usr/bin/app
+00401000-00500000 r-xp 00001000 08:01 1234 /usr/bin/app
+00500000-00600000 rw-p 00100000 08:01 1234 /usr/bin/app
+7f0000000000-7f0000021000 rw-p 00000000 00:00 0
+7ffd00000000-7ffd00021000 rw-p 00000000 00:00 0 [stack]
+`
+
+func TestParseMaps(t *testing.T) {
+	mappings, err := parseMaps(strings.NewReader(testMaps +
+		"7f1000000000-7f1000001000 r-xp 00000000 08:01 99 /opt/my app (deleted)\n"))
+	require.NoError(t, err)
+	require.Len(t, mappings, 6)
+
+	assert.Equal(t, memoryMapping{
+		Start:      0x401000,
+		End:        0x500000,
+		Perms:      "r-xp",
+		FileOffset: 0x1000,
+		Path:       "/usr/bin/app",
+	}, mappings[1])
+	assert.Empty(t, mappings[3].Path)
+	assert.Equal(t, "[stack]", mappings[4].Path)
+	assert.Equal(t, "/opt/my app (deleted)", mappings[5].Path)
+
+	_, err = parseMaps(strings.NewReader("zzzz-0001 r-xp 00000000 08:01 1 /bin/x\n"))
+	assert.Error(t, err)
+}
+
+func TestFindMapping(t *testing.T) {
+	mappings, err := parseMaps(strings.NewReader(testMaps))
+	require.NoError(t, err)
+
+	m, loadBase, err := findMapping(mappings, "/usr/bin/app", 0x2345)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x401000), m.Start)
+	assert.Equal(t, uint64(0x400000), loadBase)
+
+	_, _, err = findMapping(mappings, "/usr/bin/app", 0x100010)
+	assert.ErrorIs(t, err, ErrOffsetMismatch)
+	assert.Contains(t, err.Error(), "non-executable")
+
+	_, _, err = findMapping(mappings, "/usr/bin/app", 0x900000)
+	assert.ErrorIs(t, err, ErrOffsetMismatch)
+	assert.Contains(t, err.Error(), "outside the mapped segments")
+
+	_, _, err = findMapping(mappings, "/usr/bin/other", 0x2345)
+	assert.ErrorIs(t, err, ErrOffsetMismatch)
+}
+
+func TestSamePrologue(t *testing.T) {
+	onDisk := []byte{0x49, 0x3b, 0x66, 0x10, 0x76, 0x2b, 0x55, 0x48}
+
+	assert.True(t, samePrologue(onDisk, onDisk))
+	assert.False(t, samePrologue(onDisk, []byte{0x90, 0x3b, 0x66, 0x10, 0x76, 0x2b, 0x55, 0x48}))
+	assert.False(t, samePrologue(onDisk, onDisk[:4]))
+
+	// A uprobe already attached at the function entry.
+	var probed []byte
+	switch runtime.GOARCH {
+	case "amd64":
+		probed = append([]byte{0xcc}, onDisk[1:]...)
+	case "arm64":
+		probed = append([]byte{0xa0, 0x00, 0x20, 0xd4}, onDisk[4:]...)
+	default:
+		t.Skipf("no uprobe breakpoint for %s", runtime.GOARCH)
+	}
+	assert.True(t, samePrologue(onDisk, probed))
+}
+
+func TestValidateOffset_Self(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("requires /proc")
+	}
+
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	f, err := elf.Open(exe)
+	require.NoError(t, err)
+	defer f.Close() // nolint:errcheck
+
+	text := f.Section(".text")
+	require.NotNil(t, text)
+	pid := uint32(os.Getpid()) // #nosec G115
+
+	// The start of .text is code, loaded wherever the test binary was placed.
+	require.NoError(t, ValidateOffset(pid, text.Offset))
+
+	// Data is not code, so no probe must be attached there.
+	data := f.Section(".data")
+	require.NotNil(t, data)
+	err = ValidateOffset(pid, data.Offset)
+	assert.ErrorIs(t, err, ErrOffsetMismatch)
+}
@@ -13,7 +13,8 @@ import (
 	"sync"
 	"time"
 
-	"github.com/cilium/ebpf/ringbuf"
+	ciliumebpf "github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/link"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -32,6 +33,8 @@ type uprobeEvent struct {
 	EventType   uint8
 	_           [7]byte // padding
 	DurationNs  uint64
+	GoroutineID uint64
+	GAddr       uint64
 }
 
 // uprobeFilterConfig matches the C struct filter_config in bpf/uprobe.c (RFD 090).
@@ -56,11 +59,21 @@ type UprobeCollector struct {
 	hasFuncSize      bool
 	binaryPath       string
 	pid              uint32
+	restarts         int // Number of times the target process was re-attached.
+	goidOffset       uint32
+
+	// Entry/return matching by goroutine, owned by the reader goroutine.
+	invocations *invocationTracker
+
+	// Kernel-level filter as requested, and the load-shedding divisor applied
+	// on top of its sample rate.
+	filter        UprobeFilter
+	sampleDivisor uint32
 
 	// eBPF resources
 	objs         *bpfgen.Objects
 	attachResult *uprobe.AttachResult
-	reader       *ringbuf.Reader
+	reader       uprobe.EventReader
 
 	// Event collection
 	ctx    context.Context
@@ -103,6 +116,7 @@ func NewUprobeCollector(logger zerolog.Logger, config *UprobeConfig) (*UprobeCol
 		config:           config,
 		functionName:     config.FunctionName,
 		discoveryService: discoveryService,
+		invocations:      newInvocationTracker(),
 		events:           make([]*agentv1.UprobeEvent, 0),
 	}, nil
 }
@@ -145,17 +159,49 @@ func (c *UprobeCollector) Start(ctx context.Context) error {
 		Str("discovery_method", string(result.Method)).
 		Msg("Successfully discovered function metadata")
 
-	// Step 2: Load eBPF program
+	// Step 2: Select the program variant for this kernel and load it.
+	feats := c.config.KernelFeatures
+	if feats == nil {
+		feats = ProbeKernelFeatures("")
+	}
+	if !feats.Supported() {
+		return fmt.Errorf("%w: %v", errUnsupportedKernel, feats.Warnings())
+	}
+
+	kernelTypes, err := feats.LoadKernelTypes()
+	if err != nil {
+		return err
+	}
+
+	// Goroutine IDs need the runtime.g layout of the target binary; without
+	// DWARF, events still carry the g address for entry/return matching.
+	c.goidOffset, err = goidOffset(fmt.Sprintf("/proc/%d/exe", c.pid))
+	if err != nil {
+		c.logger.Debug().Err(err).Msg("Goroutine IDs unavailable, matching events by goroutine address")
+	}
+
 	c.objs = &bpfgen.Objects{}
-	if err := bpfgen.LoadObjects(c.objs, nil); err != nil {
+	compat := bpfgen.Compat{
+		PerfBuffer: feats.EventTransport() == EventTransportPerfBuf,
+		GoidOffset: c.goidOffset,
+	}
+	opts := &ciliumebpf.CollectionOptions{
+		Programs: ciliumebpf.ProgramOptions{KernelTypes: kernelTypes},
+	}
+	if err := bpfgen.LoadObjects(c.objs, compat, opts); err != nil {
 		return fmt.Errorf("failed to load eBPF objects: %w", err)
 	}
 
-	c.logger.Debug().Msg("Loaded eBPF objects")
+	c.logger.Debug().
+		Str("event_transport", string(feats.EventTransport())).
+		Str("btf_source", feats.BTFSource).
+		Msg("Loaded eBPF objects")
 
 	// Step 2b: Write initial filter config if one was specified (RFD 090).
 	// The filter_config_map is optional; if the compiled .o lacks it the field is nil.
-	if err := c.writeFilterConfig(c.config.Filter); err != nil {
+	c.filter = c.config.Filter
+	c.sampleDivisor = c.config.SampleDivisor
+	if err := c.writeFilterConfig(c.filter); err != nil {
 		c.objs.Close() //nolint:errcheck
 		return fmt.Errorf("failed to write initial filter config: %w", err)
 	}
@@ -187,38 +233,7 @@ func (c *UprobeCollector) Start(ctx context.Context) error {
 	}
 
 	// Step 4: Attach return probes to RET instructions (RFD 073).
-	// Disassemble function to find all RET instruction offsets, then attach
-	// uprobes to each one. Falls back to entry-only if disassembly fails.
-	if c.hasFuncSize && c.funcSizeBytes > 0 {
-		resolvedPath := fmt.Sprintf("/proc/%d/exe", c.pid)
-		d := disasm.NewX86Disassembler()
-		retOffsets, disasmErr := d.FindRETOffsets(resolvedPath, c.funcOffset, c.funcSizeBytes)
-		if disasmErr != nil {
-			c.logger.Warn().Err(disasmErr).
-				Msg("Could not disassemble function, duration metrics unavailable")
-		} else if len(retOffsets) == 0 {
-			c.logger.Warn().
-				Msg("No RET instructions found (tail-call optimized?), duration metrics unavailable")
-		} else {
-			c.logger.Info().
-				Int("ret_count", len(retOffsets)).
-				Msg("Found RET instructions, attaching return probes")
-
-			returnLinks, retErr := uprobe.AttachReturnProbes(attachCfg, c.objs.UprobeReturn, retOffsets)
-			if retErr != nil {
-				c.logger.Warn().Err(retErr).
-					Msg("Failed to attach return probes, continuing with entry-only")
-			} else {
-				c.attachResult.ReturnLinks = returnLinks
-				c.logger.Info().
-					Int("return_probes", len(returnLinks)).
-					Msg("Successfully attached return probes to RET instructions")
-			}
-		}
-	} else {
-		c.logger.Info().
-			Msg("Function size not available, entry-only probe (no duration metrics)")
-	}
+	c.attachResult.ReturnLinks = c.attachReturnProbes(attachCfg)
 
 	// Store reader reference for easy access.
 	c.reader = c.attachResult.Reader
@@ -231,10 +246,136 @@ func (c *UprobeCollector) Start(ctx context.Context) error {
 		go c.cleanupOrphanedEntries()
 	}
 
+	// Watch the target process so probes follow it across restarts.
+	if identity, err := IdentifyProcess(c.pid); err != nil {
+		c.logger.Warn().Err(err).Msg("Unable to identify target process, restarts will not be detected")
+	} else {
+		watcher := NewProcessWatcher(c.logger, DefaultProcessWatchInterval, c.handleRestart)
+		go watcher.Watch(c.ctx, identity)
+	}
+
 	c.logger.Info().Msg("Uprobe collector started successfully")
 	return nil
 }
 
+// attachReturnProbes disassembles the function to find all RET instruction
+// offsets and attaches a uprobe to each one (RFD 073). Returns nil when
+// duration metrics are unavailable, in which case the probe is entry-only.
+func (c *UprobeCollector) attachReturnProbes(attachCfg uprobe.AttachConfig) []link.Link {
+	if !c.hasFuncSize || c.funcSizeBytes == 0 {
+		c.logger.Info().
+			Msg("Function size not available, entry-only probe (no duration metrics)")
+		return nil
+	}
+
+	resolvedPath := fmt.Sprintf("/proc/%d/exe", attachCfg.PID)
+	d := disasm.NewX86Disassembler()
+	retOffsets, err := d.FindRETOffsets(resolvedPath, c.funcOffset, c.funcSizeBytes)
+	if err != nil {
+		c.logger.Warn().Err(err).
+			Msg("Could not disassemble function, duration metrics unavailable")
+		return nil
+	}
+	if len(retOffsets) == 0 {
+		c.logger.Warn().
+			Msg("No RET instructions found (tail-call optimized?), duration metrics unavailable")
+		return nil
+	}
+
+	c.logger.Info().
+		Int("ret_count", len(retOffsets)).
+		Msg("Found RET instructions, attaching return probes")
+
+	returnLinks, err := uprobe.AttachReturnProbes(attachCfg, c.objs.UprobeReturn, retOffsets)
+	if err != nil {
+		c.logger.Warn().Err(err).
+			Msg("Failed to attach return probes, continuing with entry-only")
+		return nil
+	}
+
+	c.logger.Info().
+		Int("return_probes", len(returnLinks)).
+		Msg("Successfully attached return probes to RET instructions")
+
+	return returnLinks
+}
+
+// handleRestart re-resolves the function offset in the restarted process and
+// moves the probes over to it. The BPF objects and event reader are kept, so
+// no buffered events are lost. A restart marker event is recorded so
+// consumers can tell where the process instance changed.
+func (c *UprobeCollector) handleRestart(ctx context.Context, previous, current ProcessIdentity) error {
+	result, err := c.discoveryService.DiscoverFunction(ctx, c.config.SDKAddr, current.PID, c.functionName)
+	if err != nil {
+		return fmt.Errorf("failed to re-discover function metadata: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The collector may have been stopped while discovery was running.
+	if c.ctx.Err() != nil {
+		return nil
+	}
+
+	// The previous process is gone, so its links no longer fire.
+	if err := c.attachResult.CloseLinks(); err != nil {
+		c.logger.Debug().Err(err).Msg("Error closing probes of exited process")
+	}
+
+	c.pid = current.PID
+	c.funcOffset = result.Metadata.Offset
+	c.binaryPath = result.Metadata.BinaryPath
+	c.funcSizeBytes = result.Metadata.SizeBytes
+	c.hasFuncSize = result.Metadata.HasSize
+
+	// The goid offset is fixed when the BPF objects are loaded.
+	if offset, err := goidOffset(fmt.Sprintf("/proc/%d/exe", c.pid)); err == nil && offset != c.goidOffset {
+		c.logger.Warn().
+			Uint32("goid_offset", offset).
+			Uint32("loaded_goid_offset", c.goidOffset).
+			Msg("Restarted binary has a different runtime.g layout, goroutine IDs are unreliable until the session is restarted")
+	}
+
+	attachCfg := uprobe.AttachConfig{
+		PID:        c.pid,
+		Offset:     c.funcOffset,
+		BinaryPath: c.binaryPath,
+		PIDFilter:  int(c.pid), //nolint:gosec // G115: PID is always a small positive integer.
+		Logger:     c.logger,
+	}
+
+	probes, err := uprobe.AttachProbes(attachCfg, c.objs.UprobeEntry, nil)
+	if err != nil {
+		return fmt.Errorf("failed to re-attach uprobe: %w", err)
+	}
+	c.attachResult.EntryLink = probes.EntryLink
+	c.attachResult.ReturnLinks = c.attachReturnProbes(attachCfg)
+
+	c.restarts++
+	marker := &agentv1.UprobeEvent{
+		Timestamp:    timestamppb.Now(),
+		FunctionName: c.functionName,
+		ServiceName:  c.config.ServiceName,
+		EventType:    EventTypeRestart,
+		Pid:          int32(current.PID), //nolint:gosec // G115: PID conversion is safe
+		Labels: map[string]string{
+			"previous_pid":  fmt.Sprintf("%d", previous.PID),
+			"restart_count": fmt.Sprintf("%d", c.restarts),
+		},
+	}
+	c.appendEventLocked(marker)
+
+	c.logger.Info().
+		Uint32("previous_pid", previous.PID).
+		Uint32("pid", current.PID).
+		Uint64("offset", c.funcOffset).
+		Int("restarts", c.restarts).
+		Msg("Re-attached uprobe to restarted process")
+
+	return nil
+}
+
 // Stop stops the collector and cleans up resources.
 func (c *UprobeCollector) Stop() error {
 	c.logger.Info().Msg("Stopping uprobe collector")
@@ -293,7 +434,7 @@ func (c *UprobeCollector) GetEvents() ([]*meshv1.EbpfEvent, error) {
 	return events, nil
 }
 
-// readEvents reads events from the ring buffer in a goroutine.
+// readEvents reads events from the ring buffer (or perf buffer) in a goroutine.
 func (c *UprobeCollector) readEvents() {
 	c.logger.Info().
 		Str("function", c.functionName).
@@ -312,10 +453,10 @@ func (c *UprobeCollector) readEvents() {
 		// This allows us to periodically log that we're still waiting
 		c.reader.SetDeadline(time.Now().Add(5 * time.Second))
 
-		record, err := c.reader.Read()
+		sample, err := c.reader.Read()
 		if err != nil {
-			if errors.Is(err, ringbuf.ErrClosed) {
-				c.logger.Info().Msg("Ring buffer closed, exiting event reader")
+			if errors.Is(err, uprobe.ErrClosed) {
+				c.logger.Info().Msg("Event buffer closed, exiting event reader")
 				return
 			}
 			// Check if it's a timeout error (os.ErrDeadlineExceeded)
@@ -328,12 +469,12 @@ func (c *UprobeCollector) readEvents() {
 		}
 
 		c.logger.Debug().
-			Int("size", len(record.RawSample)).
-			Msg("✓ Read record from ring buffer")
+			Int("size", len(sample)).
+			Msg("✓ Read event record")
 
 		// Parse event from raw bytes
 		var rawEvent uprobeEvent
-		if err := binary.Read(bytes.NewBuffer(record.RawSample), binary.LittleEndian, &rawEvent); err != nil {
+		if err := binary.Read(bytes.NewBuffer(sample), binary.LittleEndian, &rawEvent); err != nil {
 			c.logger.Error().Err(err).Msg("Failed to parse event")
 			continue
 		}
@@ -347,16 +488,13 @@ func (c *UprobeCollector) readEvents() {
 			DurationNs:   rawEvent.DurationNs,
 			Pid:          int32(rawEvent.Pid), //nolint:gosec // G115: PID conversion is safe
 			Tid:          int32(rawEvent.Tid), //nolint:gosec // G115: TID conversion is safe
+			GoroutineId:  rawEvent.GoroutineID,
 		}
+		c.matchInvocation(&rawEvent, event)
 
 		// Store event
 		c.mu.Lock()
-		c.events = append(c.events, event)
-
-		// Enforce max events limit
-		if c.config.MaxEvents > 0 && len(c.events) > int(c.config.MaxEvents) {
-			c.events = c.events[1:] // Drop oldest
-		}
+		c.appendEventLocked(event)
 		c.mu.Unlock()
 
 		c.logger.Debug().
@@ -366,6 +504,42 @@ func (c *UprobeCollector) readEvents() {
 	}
 }
 
+// matchInvocation pairs the entry and return events of an invocation by
+// goroutine and sets the duration of return events from their own entry. The
+// in-kernel match keys on the stack pointer, which changes when the
+// goroutine's stack is moved to grow it; the goroutine does not change.
+func (c *UprobeCollector) matchInvocation(raw *uprobeEvent, event *agentv1.UprobeEvent) {
+	if raw.GAddr == 0 {
+		return
+	}
+
+	key := goroutineKey{pid: raw.Pid, gPtr: raw.GAddr}
+	if raw.EventType == 0 {
+		c.invocations.entry(key, raw.TimestampNs)
+		return
+	}
+
+	if duration, ok := c.invocations.exit(key, raw.TimestampNs); ok {
+		event.DurationNs = duration
+	}
+}
+
+// appendEventLocked redacts and buffers an event and forwards it to the
+// OnEvent hook. Must be called with c.mu held.
+func (c *UprobeCollector) appendEventLocked(event *agentv1.UprobeEvent) {
+	c.config.Redactor.UprobeEvent(event)
+	c.events = append(c.events, event)
+
+	// Enforce max events limit
+	if c.config.MaxEvents > 0 && len(c.events) > int(c.config.MaxEvents) {
+		c.events = c.events[1:] // Drop oldest
+	}
+
+	if c.config.OnEvent != nil {
+		c.config.OnEvent(event)
+	}
+}
+
 // cleanupOrphanedEntries periodically removes stale entries from the BPF entry_times map.
 // Orphaned entries occur when functions panic, are killed, or enter infinite loops (RFD 073).
 func (c *UprobeCollector) cleanupOrphanedEntries() {
@@ -421,10 +595,30 @@ func (c *UprobeCollector) doCleanup() {
 func (c *UprobeCollector) UpdateFilter(f UprobeFilter) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	c.filter = f
 	return c.writeFilterConfig(f)
 }
 
-// writeFilterConfig writes f into the filter_config BPF map.
+// SetSampleDivisor reduces event sampling by divisor on top of the requested
+// filter, used by the agent to shed load. 0 or 1 restores the requested rate.
+func (c *UprobeCollector) SetSampleDivisor(divisor uint32) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sampleDivisor = divisor
+	return c.writeFilterConfig(c.filter)
+}
+
+// effectiveFilter returns f with the load-shedding divisor applied to its
+// sample rate.
+func (c *UprobeCollector) effectiveFilter(f UprobeFilter) UprobeFilter {
+	if c.sampleDivisor > 1 {
+		f.SampleRate = max(f.SampleRate, 1) * c.sampleDivisor
+	}
+	return f
+}
+
+// writeFilterConfig writes f, with the load-shedding divisor applied, into the
+// filter_config BPF map.
 // Must be called with c.mu held (or before the reader goroutine is started).
 func (c *UprobeCollector) writeFilterConfig(f UprobeFilter) error {
 	if c.objs == nil || c.objs.FilterConfigMap == nil {
@@ -432,6 +626,7 @@ func (c *UprobeCollector) writeFilterConfig(f UprobeFilter) error {
 		return nil
 	}
 
+	f = c.effectiveFilter(f)
 	cfg := uprobeFilterConfig{
 		MinDurationNs: f.MinDurationNs,
 		MaxDurationNs: f.MaxDurationNs,
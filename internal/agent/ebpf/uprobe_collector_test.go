@@ -22,12 +22,14 @@ func TestUprobeEventStructLayout(t *testing.T) {
 	//     __u8  event_type;   // 16
 	//     // 7 bytes padding  // 17
 	//     __u64 duration_ns;  // 24
-	// };                      // Total: 32
+	//     __u64 goroutine_id; // 32
+	//     __u64 g_addr;       // 40
+	// };                      // Total: 48
 
 	var event uprobeEvent
 
 	// Check total size
-	assert.Equal(t, uintptr(32), unsafe.Sizeof(event), "uprobeEvent size should be 32 bytes")
+	assert.Equal(t, uintptr(48), unsafe.Sizeof(event), "uprobeEvent size should be 48 bytes")
 
 	// Check offsets
 	assert.Equal(t, uintptr(0), unsafe.Offsetof(event.TimestampNs), "TimestampNs offset")
@@ -35,6 +37,8 @@ func TestUprobeEventStructLayout(t *testing.T) {
 	assert.Equal(t, uintptr(12), unsafe.Offsetof(event.Tid), "Tid offset")
 	assert.Equal(t, uintptr(16), unsafe.Offsetof(event.EventType), "EventType offset")
 	assert.Equal(t, uintptr(24), unsafe.Offsetof(event.DurationNs), "DurationNs offset")
+	assert.Equal(t, uintptr(32), unsafe.Offsetof(event.GoroutineID), "GoroutineID offset")
+	assert.Equal(t, uintptr(40), unsafe.Offsetof(event.GAddr), "GAddr offset")
 }
 
 func TestUprobeFilterConfigStructLayout(t *testing.T) {
@@ -85,6 +89,29 @@ func TestUprobeCollectorUpdateFilterNilMap(t *testing.T) {
 	require.NoError(t, err, "UpdateFilter must be a no-op when filter maps are absent")
 }
 
+// TestUprobeCollectorSampleDivisor verifies that the load-shedding divisor
+// multiplies the configured sample rate and is removed again on restore.
+func TestUprobeCollectorSampleDivisor(t *testing.T) {
+	c := &UprobeCollector{
+		objs: &bpfgen.Objects{},
+	}
+	require.NoError(t, c.UpdateFilter(UprobeFilter{MinDurationNs: 1000, SampleRate: 4}))
+
+	// No divisor: filter is unchanged.
+	assert.Equal(t, uint32(4), c.effectiveFilter(c.filter).SampleRate)
+
+	require.NoError(t, c.SetSampleDivisor(10))
+	f := c.effectiveFilter(c.filter)
+	assert.Equal(t, uint32(40), f.SampleRate)
+	assert.Equal(t, uint64(1000), f.MinDurationNs, "duration filters are not affected")
+
+	// "Emit all" (0) becomes 1 in divisor.
+	assert.Equal(t, uint32(10), c.effectiveFilter(UprobeFilter{}).SampleRate)
+
+	require.NoError(t, c.SetSampleDivisor(1))
+	assert.Equal(t, uint32(4), c.effectiveFilter(c.filter).SampleRate)
+}
+
 // TestUprobeFilterZeroIsPassthrough verifies that a zero-value UprobeFilter
 // is semantically equivalent to "no filter" (backward compatible default).
 func TestUprobeFilterZeroIsPassthrough(t *testing.T) {
@@ -0,0 +1,366 @@
+// Package eventpush streams uprobe events of colony debug sessions to the
+// colony as they are captured, via the IngestUprobeEvents client-streaming RPC.
+package eventpush
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/bandwidth"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// stopFlushTimeout bounds the final push when the pusher stops.
+const stopFlushTimeout = 10 * time.Second
+
+// URLProvider returns the current colony base URL, or an empty string if the
+// colony is not yet reachable.
+type URLProvider func() string
+
+// Config holds configuration for the Pusher.
+type Config struct {
+	// AgentID is the pushing agent identifier (required).
+	AgentID string
+
+	// URLProvider returns the colony base URL on demand. Events stay queued
+	// while it returns "".
+	URLProvider URLProvider
+
+	// FlushInterval controls how often queued events are pushed
+	// (default: constants.DefaultEventPushInterval).
+	FlushInterval time.Duration
+
+	// BatchSize is the maximum number of events per message
+	// (default: constants.DefaultEventPushBatchSize).
+	BatchSize int
+
+	// QueueSize bounds the number of queued events
+	// (default: constants.DefaultEventPushQueueSize).
+	QueueSize int
+
+	// Logger is the zerolog logger for this component.
+	Logger zerolog.Logger
+}
+
+// queuedEvent is an event waiting to be pushed.
+type queuedEvent struct {
+	sessionID string
+	event     *agentv1.UprobeEvent
+}
+
+// Pusher queues uprobe events and pushes them to the colony in batches.
+//
+// Flow control: Push never blocks the eBPF reader. Events are held in a
+// bounded queue that drops the oldest events when full, and the number of
+// dropped events is reported to the colony with the next batch. At most one
+// stream is in flight at a time; events of a failed push are queued again
+// and retried.
+type Pusher struct {
+	agentID       string
+	urlProvider   URLProvider
+	flushInterval time.Duration
+	batchSize     int
+	queueSize     int
+	httpClient    *http.Client
+	logger        zerolog.Logger
+
+	mu      sync.Mutex
+	queue   []queuedEvent
+	dropped map[string]uint64 // sessionID -> events dropped since the last push
+	ignored map[string]bool   // sessions the colony does not know
+
+	// droppedTotal counts events dropped since the pusher was created.
+	droppedTotal uint64
+
+	// sendMu serializes pushes so that events are sent in order.
+	sendMu sync.Mutex
+	// full is signalled when a full batch is queued.
+	full chan struct{}
+	// uncompressed is set once the colony rejected zstd compressed pushes;
+	// colonies predating compression support do not accept them.
+	uncompressed atomic.Bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPusher creates a new Pusher. Call Start to begin pushing.
+func NewPusher(cfg Config) *Pusher {
+	if cfg.FlushInterval == 0 {
+		cfg.FlushInterval = constants.DefaultEventPushInterval
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = constants.DefaultEventPushBatchSize
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = constants.DefaultEventPushQueueSize
+	}
+
+	return &Pusher{
+		agentID:       cfg.AgentID,
+		urlProvider:   cfg.URLProvider,
+		flushInterval: cfg.FlushInterval,
+		batchSize:     cfg.BatchSize,
+		queueSize:     cfg.QueueSize,
+		httpClient:    newColonyHTTPClient(),
+		logger:        cfg.Logger.With().Str("component", "event_pusher").Logger(),
+		dropped:       make(map[string]uint64),
+		ignored:       make(map[string]bool),
+		full:          make(chan struct{}, 1),
+	}
+}
+
+// Start begins the push loop. It returns immediately; the loop runs in a
+// background goroutine until Stop is called.
+func (p *Pusher) Start() error {
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.wg.Add(1)
+	go p.run()
+	p.logger.Info().
+		Dur("flush_interval", p.flushInterval).
+		Msg("Uprobe event push started")
+	return nil
+}
+
+// Stop pushes the events still queued and stops the push loop.
+func (p *Pusher) Stop() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopFlushTimeout)
+	defer cancel()
+	if err := p.Flush(ctx); err != nil {
+		p.logger.Warn().Err(err).Msg("Failed to push remaining uprobe events")
+	}
+
+	p.logger.Info().Msg("Uprobe event push stopped")
+	return nil
+}
+
+// Push queues an event of a colony debug session. It never blocks: when the
+// queue is full the oldest event is dropped.
+func (p *Pusher) Push(sessionID string, event *agentv1.UprobeEvent) {
+	p.mu.Lock()
+	if p.ignored[sessionID] {
+		p.mu.Unlock()
+		return
+	}
+	p.queue = append(p.queue, queuedEvent{sessionID: sessionID, event: event})
+	p.trimLocked()
+	full := len(p.queue) >= p.batchSize
+	p.mu.Unlock()
+
+	if full {
+		select {
+		case p.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Stats returns the number of queued events and of events dropped since the
+// pusher was created.
+func (p *Pusher) Stats() (queued int, dropped uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.queue), p.droppedTotal
+}
+
+// Flush pushes all queued events. Events of a failed push are queued again.
+func (p *Pusher) Flush(ctx context.Context) error {
+	p.sendMu.Lock()
+	defer p.sendMu.Unlock()
+
+	p.mu.Lock()
+	events := p.queue
+	dropped := p.dropped
+	p.queue = nil
+	p.dropped = make(map[string]uint64)
+	p.mu.Unlock()
+
+	if len(events) == 0 && len(dropped) == 0 {
+		return nil
+	}
+
+	unknown, err := p.send(ctx, events, dropped)
+	if err != nil {
+		p.requeue(events, dropped)
+		return err
+	}
+
+	if len(unknown) > 0 {
+		p.mu.Lock()
+		for _, sessionID := range unknown {
+			p.ignored[sessionID] = true
+		}
+		p.mu.Unlock()
+
+		p.logger.Warn().
+			Strs("session_ids", unknown).
+			Msg("Colony does not know debug sessions, dropping their events")
+	}
+
+	return nil
+}
+
+// run is the main push loop.
+func (p *Pusher) run() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-p.full:
+		}
+
+		if err := p.Flush(p.ctx); err != nil && p.ctx.Err() == nil {
+			p.logger.Debug().Err(err).Msg("Failed to push uprobe events to colony, will retry")
+		}
+	}
+}
+
+// send opens an IngestUprobeEvents stream, sends the events in per-session
+// batches and closes the stream. A new stream is opened per push; this avoids
+// managing long-lived stream state across network interruptions. It returns
+// the sessions the colony does not know.
+func (p *Pusher) send(ctx context.Context, events []queuedEvent, dropped map[string]uint64) ([]string, error) {
+	colonyURL := ""
+	if p.urlProvider != nil {
+		colonyURL = p.urlProvider()
+	}
+	if colonyURL == "" {
+		return nil, fmt.Errorf("colony URL not yet available")
+	}
+
+	compressed := !p.uncompressed.Load()
+	opts := bandwidth.ClientOptions()
+	if compressed {
+		opts = append(opts, connect.WithSendCompression(bandwidth.Zstd))
+	}
+	client := colonyv1connect.NewColonyDebugServiceClient(p.httpClient, colonyURL, opts...)
+	stream := client.IngestUprobeEvents(ctx)
+
+	for _, msg := range p.batches(events, dropped) {
+		if err := stream.Send(msg); err != nil {
+			_, closeErr := stream.CloseAndReceive()
+			p.checkCompression(compressed, closeErr)
+			return nil, fmt.Errorf("send failed: %w", err)
+		}
+	}
+
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		p.checkCompression(compressed, err)
+		return nil, fmt.Errorf("CloseAndReceive failed: %w", err)
+	}
+
+	p.logger.Debug().
+		Int("events", len(events)).
+		Int64("accepted", resp.Msg.EventsAccepted).
+		Msg("Pushed uprobe events to colony")
+
+	return resp.Msg.UnknownSessionIds, nil
+}
+
+// checkCompression falls back to uncompressed pushes if the colony rejected a
+// compressed push as unimplemented. The events are retried with the next push.
+func (p *Pusher) checkCompression(compressed bool, err error) {
+	if compressed && connect.CodeOf(err) == connect.CodeUnimplemented {
+		p.uncompressed.Store(true)
+		p.logger.Info().Err(err).Msg("Colony does not accept compressed events, pushing them uncompressed")
+	}
+}
+
+// batches groups events by session, preserving their order, into messages of
+// at most batchSize events.
+func (p *Pusher) batches(events []queuedEvent, dropped map[string]uint64) []*colonyv1.IngestUprobeEventsRequest {
+	var (
+		msgs    []*colonyv1.IngestUprobeEventsRequest
+		current = make(map[string]*colonyv1.IngestUprobeEventsRequest)
+	)
+
+	batchFor := func(sessionID string) *colonyv1.IngestUprobeEventsRequest {
+		msg, ok := current[sessionID]
+		if !ok || len(msg.Events) >= p.batchSize {
+			msg = &colonyv1.IngestUprobeEventsRequest{
+				AgentId:   p.agentID,
+				SessionId: sessionID,
+			}
+			if !ok {
+				msg.DroppedEvents = dropped[sessionID]
+			}
+			current[sessionID] = msg
+			msgs = append(msgs, msg)
+		}
+		return msg
+	}
+
+	for _, e := range events {
+		msg := batchFor(e.sessionID)
+		msg.Events = append(msg.Events, e.event)
+	}
+
+	// Report drops of sessions without queued events.
+	for sessionID := range dropped {
+		batchFor(sessionID)
+	}
+
+	return msgs
+}
+
+// requeue puts the events of a failed push back in front of the queue.
+func (p *Pusher) requeue(events []queuedEvent, dropped map[string]uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.queue = append(events, p.queue...)
+	for sessionID, n := range dropped {
+		p.dropped[sessionID] += n
+	}
+	p.trimLocked()
+}
+
+// trimLocked drops the oldest events beyond the queue size.
+// Must be called with p.mu held.
+func (p *Pusher) trimLocked() {
+	excess := len(p.queue) - p.queueSize
+	if excess <= 0 {
+		return
+	}
+
+	for _, e := range p.queue[:excess] {
+		p.dropped[e.sessionID]++
+	}
+	p.droppedTotal += uint64(excess) // #nosec G115 -- excess is positive.
+	p.queue = p.queue[excess:]
+}
+
+// newColonyHTTPClient returns a plain HTTP client suitable for use over the
+// WireGuard mesh (encryption is provided by WireGuard, not TLS).
+func newColonyHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			MaxIdleConns:    10,
+			IdleConnTimeout: 90 * time.Second,
+		},
+	}
+}
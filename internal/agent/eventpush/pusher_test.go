@@ -0,0 +1,214 @@
+package eventpush
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/bandwidth"
+)
+
+// fakeColony records pushed batches.
+type fakeColony struct {
+	colonyv1connect.UnimplementedColonyDebugServiceHandler
+
+	mu      sync.Mutex
+	fail    bool
+	unknown map[string]bool
+	batches []*colonyv1.IngestUprobeEventsRequest
+}
+
+func (c *fakeColony) IngestUprobeEvents(
+	_ context.Context,
+	stream *connect.ClientStream[colonyv1.IngestUprobeEventsRequest],
+) (*connect.Response[colonyv1.IngestUprobeEventsResponse], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fail {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("colony unavailable"))
+	}
+
+	resp := &colonyv1.IngestUprobeEventsResponse{}
+	for stream.Receive() {
+		msg := stream.Msg()
+		if c.unknown[msg.SessionId] {
+			resp.UnknownSessionIds = append(resp.UnknownSessionIds, msg.SessionId)
+			continue
+		}
+		c.batches = append(c.batches, msg)
+		resp.EventsAccepted += int64(len(msg.Events))
+	}
+	return connect.NewResponse(resp), stream.Err()
+}
+
+func (c *fakeColony) events(sessionID string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var names []string
+	for _, b := range c.batches {
+		if b.SessionId != sessionID {
+			continue
+		}
+		for _, e := range b.Events {
+			names = append(names, e.FunctionName)
+		}
+	}
+	return names
+}
+
+func newTestPusher(t *testing.T, colony *fakeColony, batchSize, queueSize int) *Pusher {
+	t.Helper()
+	return newTestPusherWithOptions(t, colony, batchSize, queueSize, bandwidth.HandlerOptions()...)
+}
+
+func newTestPusherWithOptions(t *testing.T, colony *fakeColony, batchSize, queueSize int, opts ...connect.HandlerOption) *Pusher {
+	t.Helper()
+
+	_, h := colonyv1connect.NewColonyDebugServiceHandler(colony, opts...)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	p := NewPusher(Config{
+		AgentID:     "agent-1",
+		URLProvider: func() string { return srv.URL },
+		BatchSize:   batchSize,
+		QueueSize:   queueSize,
+		Logger:      zerolog.Nop(),
+	})
+	p.httpClient = http.DefaultClient
+	return p
+}
+
+func event(name string) *agentv1.UprobeEvent {
+	return &agentv1.UprobeEvent{FunctionName: name}
+}
+
+func TestPusher_BatchesPerSession(t *testing.T) {
+	colony := &fakeColony{}
+	p := newTestPusher(t, colony, 2, 100)
+
+	for _, name := range []string{"a1", "a2", "a3"} {
+		p.Push("session-a", event(name))
+	}
+	p.Push("session-b", event("b1"))
+
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if got := colony.events("session-a"); len(got) != 3 || got[0] != "a1" || got[2] != "a3" {
+		t.Errorf("expected session-a events in order, got %v", got)
+	}
+	if got := colony.events("session-b"); len(got) != 1 {
+		t.Errorf("expected 1 session-b event, got %v", got)
+	}
+
+	for _, b := range colony.batches {
+		if len(b.Events) > 2 {
+			t.Errorf("expected batches of at most 2 events, got %d", len(b.Events))
+		}
+		if b.AgentId != "agent-1" {
+			t.Errorf("expected agent_id agent-1, got %s", b.AgentId)
+		}
+	}
+}
+
+func TestPusher_DropsOldestWhenFull(t *testing.T) {
+	colony := &fakeColony{}
+	p := newTestPusher(t, colony, 100, 2)
+
+	for _, name := range []string{"e1", "e2", "e3"} {
+		p.Push("session-a", event(name))
+	}
+
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if got := colony.events("session-a"); len(got) != 2 || got[0] != "e2" {
+		t.Errorf("expected oldest event dropped, got %v", got)
+	}
+	if dropped := colony.batches[0].DroppedEvents; dropped != 1 {
+		t.Errorf("expected 1 dropped event reported, got %d", dropped)
+	}
+	if queued, dropped := p.Stats(); queued != 0 || dropped != 1 {
+		t.Errorf("expected empty queue and 1 dropped event in stats, got %d queued, %d dropped", queued, dropped)
+	}
+}
+
+func TestPusher_RetriesFailedPush(t *testing.T) {
+	colony := &fakeColony{fail: true}
+	p := newTestPusher(t, colony, 100, 100)
+
+	p.Push("session-a", event("e1"))
+	if err := p.Flush(context.Background()); err == nil {
+		t.Fatal("expected Flush to fail while the colony is unavailable")
+	}
+
+	colony.mu.Lock()
+	colony.fail = false
+	colony.mu.Unlock()
+
+	p.Push("session-a", event("e2"))
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if got := colony.events("session-a"); len(got) != 2 || got[0] != "e1" || got[1] != "e2" {
+		t.Errorf("expected failed events to be pushed again in order, got %v", got)
+	}
+}
+
+func TestPusher_StopsPushingUnknownSessions(t *testing.T) {
+	colony := &fakeColony{unknown: map[string]bool{"stale": true}}
+	p := newTestPusher(t, colony, 100, 100)
+
+	p.Push("stale", event("e1"))
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	p.Push("stale", event("e2"))
+
+	p.mu.Lock()
+	queued := len(p.queue)
+	p.mu.Unlock()
+	if queued != 0 {
+		t.Errorf("expected events of unknown sessions to be ignored, %d queued", queued)
+	}
+}
+
+func TestPusher_FallsBackToUncompressed(t *testing.T) {
+	// A colony without zstd support rejects compressed pushes.
+	colony := &fakeColony{}
+	p := newTestPusherWithOptions(t, colony, 100, 100)
+
+	// Large enough to be compressed.
+	name := strings.Repeat("handler", 1024)
+	p.Push("session-a", event(name))
+	if err := p.Flush(context.Background()); err == nil {
+		t.Fatal("expected the compressed push to be rejected")
+	}
+	if !p.uncompressed.Load() {
+		t.Fatal("expected the pusher to fall back to uncompressed pushes")
+	}
+
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if got := colony.events("session-a"); len(got) != 1 || got[0] != name {
+		t.Errorf("expected the rejected event to be pushed again, got %d events", len(got))
+	}
+}
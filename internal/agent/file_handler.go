@@ -0,0 +1,267 @@
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+// FileHandler implements the file transfer RPCs copying files to and from the
+// agent host (coral cp).
+type FileHandler struct {
+	logger   logging.Logger
+	maxBytes int64
+}
+
+// NewFileHandler creates a file handler copying files of at most maxBytes,
+// or constants.DefaultFileTransferMaxBytes if maxBytes is not positive.
+func NewFileHandler(maxBytes int64, logger logging.Logger) *FileHandler {
+	if maxBytes <= 0 {
+		maxBytes = constants.DefaultFileTransferMaxBytes
+	}
+	return &FileHandler{
+		logger:   logger,
+		maxBytes: maxBytes,
+	}
+}
+
+// ReadFile streams a file of the agent host: its info, its content in chunks
+// and the SHA-256 checksum of the content.
+func (h *FileHandler) ReadFile(
+	ctx context.Context,
+	req *connect.Request[agentv1.ReadFileRequest],
+	stream *connect.ServerStream[agentv1.ReadFileResponse],
+) error {
+	path, err := cleanTransferPath(req.Msg.Path)
+	if err != nil {
+		return err
+	}
+	limit := h.maxBytes
+	if req.Msg.MaxBytes > 0 && req.Msg.MaxBytes < limit {
+		limit = req.Msg.MaxBytes
+	}
+
+	f, err := os.Open(path) // #nosec G304 - copying files of the host is the point of coral cp.
+	if err != nil {
+		return fileError(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return fileError(err)
+	}
+	if !stat.Mode().IsRegular() {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%s is not a regular file", path))
+	}
+	if stat.Size() > limit {
+		return connect.NewError(connect.CodeResourceExhausted,
+			fmt.Errorf("%s is %d bytes, over the %d byte limit", path, stat.Size(), limit))
+	}
+
+	if err := stream.Send(&agentv1.ReadFileResponse{
+		Payload: &agentv1.ReadFileResponse_Info{Info: &agentv1.FileInfo{
+			Path:    path,
+			Size:    stat.Size(),
+			Mode:    uint32(stat.Mode().Perm()),
+			ModTime: timestamppb.New(stat.ModTime()),
+		}},
+	}); err != nil {
+		return err
+	}
+
+	// Files may grow while they are read, e.g. logs, and files of /proc have
+	// no size, so the limit applies to what is read rather than to the size.
+	hash := sha256.New()
+	r := io.LimitReader(f, limit+1)
+	buf := make([]byte, constants.FileTransferChunkSize)
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := r.Read(buf)
+		if n > 0 {
+			total += int64(n)
+			if total > limit {
+				return connect.NewError(connect.CodeResourceExhausted,
+					fmt.Errorf("%s grew over the %d byte limit while copying", path, limit))
+			}
+			hash.Write(buf[:n])
+			if err := stream.Send(&agentv1.ReadFileResponse{
+				Payload: &agentv1.ReadFileResponse_Data{Data: buf[:n]},
+			}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read %s: %w", path, err))
+		}
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	h.logger.Info().
+		Str("path", path).
+		Str("user_id", req.Msg.UserId).
+		Int64("bytes", total).
+		Str("sha256", sum).
+		Msg("File copied from agent")
+
+	return stream.Send(&agentv1.ReadFileResponse{
+		Payload: &agentv1.ReadFileResponse_Sha256{Sha256: sum},
+	})
+}
+
+// WriteFile writes a file streamed to the agent host. The content goes to a
+// temporary file next to the destination, which replaces the destination
+// only once the size and checksum match.
+func (h *FileHandler) WriteFile(
+	ctx context.Context,
+	stream *connect.ClientStream[agentv1.WriteFileRequest],
+) (*connect.Response[agentv1.WriteFileResponse], error) {
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("missing start message"))
+	}
+	start := stream.Msg().GetStart()
+	if start.GetInfo() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("first message must be start"))
+	}
+	info := start.Info
+
+	path, err := cleanTransferPath(info.Path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size > h.maxBytes {
+		return nil, connect.NewError(connect.CodeResourceExhausted,
+			fmt.Errorf("file is %d bytes, over the %d byte limit", info.Size, h.maxBytes))
+	}
+	if stat, err := os.Stat(path); err == nil && !stat.Mode().IsRegular() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%s is not a regular file", path))
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".coral-*")
+	if err != nil {
+		return nil, fileError(err)
+	}
+	committed := false
+	defer func() {
+		_ = tmp.Close()
+		if !committed {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	hash := sha256.New()
+	var (
+		total    int64
+		expected string
+	)
+	for stream.Receive() {
+		if expected != "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("data after checksum"))
+		}
+		switch payload := stream.Msg().Payload.(type) {
+		case *agentv1.WriteFileRequest_Data:
+			total += int64(len(payload.Data))
+			if total > info.Size {
+				return nil, connect.NewError(connect.CodeInvalidArgument,
+					fmt.Errorf("received more than the announced %d bytes", info.Size))
+			}
+			hash.Write(payload.Data)
+			if _, err := tmp.Write(payload.Data); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to write %s: %w", path, err))
+			}
+		case *agentv1.WriteFileRequest_Sha256:
+			expected = payload.Sha256
+		default:
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unexpected message"))
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	switch {
+	case expected == "":
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("missing checksum"))
+	case total != info.Size:
+		return nil, connect.NewError(connect.CodeDataLoss,
+			fmt.Errorf("received %d of %d bytes", total, info.Size))
+	case sum != expected:
+		return nil, connect.NewError(connect.CodeDataLoss,
+			fmt.Errorf("checksum mismatch: got %s, want %s", sum, expected))
+	}
+
+	mode := fs.FileMode(info.Mode).Perm()
+	if mode == 0 {
+		mode = 0o644
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		return nil, fileError(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fileError(err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fileError(err)
+	}
+	committed = true
+
+	h.logger.Info().
+		Str("path", path).
+		Str("user_id", start.UserId).
+		Int64("bytes", total).
+		Str("sha256", sum).
+		Msg("File copied to agent")
+
+	return connect.NewResponse(&agentv1.WriteFileResponse{
+		Path:         path,
+		BytesWritten: total,
+		Sha256:       sum,
+	}), nil
+}
+
+// cleanTransferPath validates the path of a file transfer. Paths must be
+// absolute, as the agent's working directory means nothing to the caller.
+func cleanTransferPath(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("path must be absolute: %q", path))
+	}
+	return filepath.Clean(path), nil
+}
+
+// fileError converts a file system error to a connect error.
+func fileError(err error) error {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, fs.ErrPermission):
+		return connect.NewError(connect.CodePermissionDenied, err)
+	default:
+		return connect.NewError(connect.CodeInternal, err)
+	}
+}
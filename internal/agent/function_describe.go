@@ -0,0 +1,182 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/disasm"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/uprobe"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// DescribeFunction returns the signature, argument locations and probeability
+// of a function, discovered the same way the uprobe collector does: through
+// the SDK when the service has one, or else by scanning its binary.
+func (s *DebugService) DescribeFunction(
+	ctx context.Context,
+	req *agentv1.DescribeFunctionRequest,
+) (*agentv1.DescribeFunctionResponse, error) {
+	if req.ServiceName == "" || req.FunctionName == "" {
+		return nil, coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+			errors.New("service_name and function_name are required")))
+	}
+
+	status, ok := s.agent.GetServiceStatuses()[req.ServiceName]
+	if !ok {
+		return nil, coralerrors.ToConnect(errServiceNotFound(req.ServiceName))
+	}
+
+	// Without SDK, the function is looked up in the binary of the process.
+	sdkAddr, _ := s.agent.ResolveSDK(req.ServiceName)
+	pid := uint32(status.ProcessID) // #nosec G115
+	if sdkAddr == "" && pid == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("no SDK and no running process known for service %s", req.ServiceName))
+	}
+
+	discovery, err := ebpf.NewDiscoveryService(ebpf.DefaultDiscoveryConfig(slog.Default()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery service: %w", err)
+	}
+	defer discovery.Close() // nolint:errcheck
+
+	result, err := discovery.DiscoverFunction(ctx, sdkAddr, pid, req.FunctionName)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	return &agentv1.DescribeFunctionResponse{
+		Function: describeFunction(result),
+	}, nil
+}
+
+// describeFunction converts discovered function metadata to its description.
+func describeFunction(result *ebpf.DiscoveryResult) *agentv1.FunctionDescription {
+	meta := result.Metadata
+
+	desc := &agentv1.FunctionDescription{
+		Name:            meta.Name,
+		BinaryPath:      meta.BinaryPath,
+		Offset:          meta.Offset,
+		DiscoveryMethod: string(result.Method),
+		Probeability:    assessProbeability(meta),
+	}
+	if meta.HasSize {
+		desc.SizeBytes = meta.SizeBytes
+	}
+	for _, arg := range meta.Arguments {
+		desc.Arguments = append(desc.Arguments, &agentv1.FunctionParameter{
+			Name:     arg.Name,
+			Type:     arg.Type,
+			Location: arg.Location,
+		})
+	}
+	for _, ret := range meta.ReturnValues {
+		desc.ReturnValues = append(desc.ReturnValues, &agentv1.FunctionParameter{
+			Name: ret.Name,
+			Type: ret.Type,
+		})
+	}
+	desc.Signature = formatSignature(desc)
+
+	return desc
+}
+
+// formatSignature renders a function description as a Go signature. Go names
+// unnamed results "~r0", "~r1"... in DWARF; those are left unnamed.
+func formatSignature(desc *agentv1.FunctionDescription) string {
+	args := make([]string, 0, len(desc.Arguments))
+	for _, arg := range desc.Arguments {
+		args = append(args, strings.TrimSpace(arg.Name+" "+arg.Type))
+	}
+
+	results := make([]string, 0, len(desc.ReturnValues))
+	named := false
+	for _, ret := range desc.ReturnValues {
+		if ret.Name != "" && !strings.HasPrefix(ret.Name, "~") {
+			named = true
+		}
+	}
+	for _, ret := range desc.ReturnValues {
+		if named {
+			results = append(results, strings.TrimSpace(ret.Name+" "+ret.Type))
+		} else {
+			results = append(results, ret.Type)
+		}
+	}
+
+	sig := fmt.Sprintf("func %s(%s)", desc.Name, strings.Join(args, ", "))
+	switch {
+	case len(results) == 1 && !named:
+		sig += " " + results[0]
+	case len(results) > 0:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+
+	return sig
+}
+
+// assessProbeability reports what a uprobe on the function can capture: it
+// checks the entry offset against the live process, looks for the return
+// instructions that duration measurement needs (RFD 073), and whether the
+// arguments can be located at entry.
+func assessProbeability(meta *ebpf.FunctionMetadata) *agentv1.Probeability {
+	p := &agentv1.Probeability{Probeable: meta.Offset != 0}
+
+	if meta.Offset == 0 {
+		p.Notes = append(p.Notes, "no entry offset: the function may be fully inlined")
+	} else if meta.Pid != 0 {
+		if err := uprobe.ValidateOffset(meta.Pid, meta.Offset); err != nil {
+			if errors.Is(err, uprobe.ErrOffsetMismatch) {
+				p.Probeable = false
+			}
+			p.Notes = append(p.Notes, err.Error())
+		}
+	}
+
+	binaryPath := meta.BinaryPath
+	if meta.Pid != 0 {
+		binaryPath = fmt.Sprintf("/proc/%d/exe", meta.Pid)
+	}
+	switch {
+	case !p.Probeable:
+	case !meta.HasSize || meta.SizeBytes == 0:
+		p.Notes = append(p.Notes, "function size unknown (no DWARF): entry-only probe, no duration")
+	default:
+		retOffsets, err := disasm.NewNativeDisassembler().FindRETOffsets(binaryPath, meta.Offset, meta.SizeBytes)
+		switch {
+		case err != nil:
+			p.Notes = append(p.Notes, fmt.Sprintf("could not disassemble function: %v", err))
+		case len(retOffsets) == 0:
+			p.Notes = append(p.Notes, "no return instructions (tail call): entry-only probe, no duration")
+		default:
+			p.DurationAvailable = true
+			p.ReturnInstructions = int32(len(retOffsets)) // #nosec G115
+		}
+	}
+
+	var unlocated []string
+	for _, arg := range meta.Arguments {
+		if arg.Location == "" || strings.Contains(arg.Location, "<optimized out>") {
+			unlocated = append(unlocated, arg.Name)
+		}
+	}
+	p.ArgumentsLocated = len(unlocated) == 0
+	switch {
+	case len(meta.Arguments) == 0 && len(meta.ReturnValues) == 0 && !meta.HasSize:
+		p.ArgumentsLocated = false
+		p.Notes = append(p.Notes, "no signature information: build without -ldflags=\"-w\" to keep DWARF")
+	case len(unlocated) > 0:
+		p.Notes = append(p.Notes, fmt.Sprintf("location at entry unknown for: %s", strings.Join(unlocated, ", ")))
+	}
+
+	return p
+}
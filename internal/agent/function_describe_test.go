@@ -0,0 +1,93 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf"
+)
+
+func TestFormatSignature(t *testing.T) {
+	tests := []struct {
+		name string
+		desc *agentv1.FunctionDescription
+		want string
+	}{
+		{
+			name: "no parameters",
+			desc: &agentv1.FunctionDescription{Name: "main.run"},
+			want: "func main.run()",
+		},
+		{
+			name: "single unnamed result",
+			desc: &agentv1.FunctionDescription{
+				Name: "main.ProcessPayment",
+				Arguments: []*agentv1.FunctionParameter{
+					{Name: "ctx", Type: "context.Context"},
+					{Name: "amount", Type: "int"},
+				},
+				ReturnValues: []*agentv1.FunctionParameter{{Name: "~r0", Type: "error"}},
+			},
+			want: "func main.ProcessPayment(ctx context.Context, amount int) error",
+		},
+		{
+			name: "multiple unnamed results",
+			desc: &agentv1.FunctionDescription{
+				Name:         "main.parse",
+				Arguments:    []*agentv1.FunctionParameter{{Name: "s", Type: "string"}},
+				ReturnValues: []*agentv1.FunctionParameter{{Name: "~r0", Type: "int"}, {Name: "~r1", Type: "error"}},
+			},
+			want: "func main.parse(s string) (int, error)",
+		},
+		{
+			name: "named results",
+			desc: &agentv1.FunctionDescription{
+				Name:         "main.read",
+				ReturnValues: []*agentv1.FunctionParameter{{Name: "n", Type: "int"}, {Name: "err", Type: "error"}},
+			},
+			want: "func main.read() (n int, err error)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatSignature(tt.desc))
+		})
+	}
+}
+
+func TestAssessProbeability(t *testing.T) {
+	t.Run("inlined", func(t *testing.T) {
+		p := assessProbeability(&ebpf.FunctionMetadata{Name: "main.add"})
+		assert.False(t, p.Probeable)
+		assert.False(t, p.DurationAvailable)
+		assert.Contains(t, p.Notes[0], "inlined")
+	})
+
+	t.Run("stripped binary", func(t *testing.T) {
+		p := assessProbeability(&ebpf.FunctionMetadata{Name: "main.handle", Offset: 0x1000})
+		assert.True(t, p.Probeable)
+		assert.False(t, p.DurationAvailable)
+		assert.False(t, p.ArgumentsLocated)
+		assert.Len(t, p.Notes, 2)
+		assert.Contains(t, p.Notes[0], "entry-only")
+		assert.Contains(t, p.Notes[1], "no signature information")
+	})
+
+	t.Run("unlocated arguments", func(t *testing.T) {
+		p := assessProbeability(&ebpf.FunctionMetadata{
+			Name:   "main.handle",
+			Offset: 0x1000,
+			Arguments: []*ebpf.ArgumentMetadata{
+				{Name: "amount", Type: "int", Location: "rax"},
+				{Name: "name", Type: "string", Location: "<optimized out>, rcx"},
+				{Name: "opts", Type: "*main.Options"},
+			},
+		})
+		assert.True(t, p.Probeable)
+		assert.False(t, p.ArgumentsLocated)
+		assert.Contains(t, p.Notes, "location at entry unknown for: name, opts")
+	})
+}
@@ -0,0 +1,78 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/runtimetrace"
+)
+
+// TraceRuntime traces the Go runtime of a process for the requested
+// duration: stop-the-world pauses, GC cycles and goroutine scheduling
+// latency.
+func (s *DebugService) TraceRuntime(
+	ctx context.Context,
+	req *agentv1.TraceRuntimeAgentRequest,
+) (*agentv1.TraceRuntimeAgentResponse, error) {
+	s.logger.Info().
+		Str("service", req.ServiceName).
+		Int32("pid", req.Pid).
+		Int32("duration_seconds", req.DurationSeconds).
+		Msg("Starting Go runtime trace")
+
+	if req.Pid <= 0 {
+		return &agentv1.TraceRuntimeAgentResponse{
+			Success: false,
+			Error:   "pid is required",
+		}, nil
+	}
+
+	duration := int(req.DurationSeconds)
+	if duration <= 0 {
+		duration = 30
+	}
+
+	report, err := ebpf.TraceGoRuntime(ctx, s.logger, uint32(req.Pid), time.Duration(duration)*time.Second) // #nosec G115 -- checked positive
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to trace Go runtime")
+		return &agentv1.TraceRuntimeAgentResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to trace Go runtime: %v", err),
+		}, nil
+	}
+
+	resp := &agentv1.TraceRuntimeAgentResponse{
+		GoVersion: report.GoVersion,
+		StartTime: timestamppb.New(report.Start),
+		EndTime:   timestamppb.New(report.End),
+		Success:   true,
+	}
+	for _, pause := range report.Pauses {
+		resp.Pauses = append(resp.Pauses, &agentv1.RuntimePause{
+			Start:      timestamppb.New(pause.Start),
+			DurationNs: uint64(pause.Duration), // #nosec G115 -- durations are positive
+			Reason:     pause.Reason,
+		})
+	}
+	for _, cycle := range report.GCCycles {
+		resp.GcCycles = append(resp.GcCycles, &agentv1.GcCycle{
+			Start:      timestamppb.New(cycle.Start),
+			DurationNs: uint64(cycle.Duration), // #nosec G115
+			PauseNs:    uint64(cycle.Pause),    // #nosec G115
+		})
+	}
+	for i, count := range report.SchedLatency {
+		if count > 0 {
+			resp.SchedLatency = append(resp.SchedLatency, &agentv1.LatencyBucket{
+				UpperBoundNs: uint64(runtimetrace.UpperBound(i)), // #nosec G115
+				Count:        count,
+			})
+		}
+	}
+	return resp, nil
+}
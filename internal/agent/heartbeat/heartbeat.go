@@ -15,7 +15,9 @@ import (
 	"time"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
 	"github.com/coral-mesh/coral/coral/mesh/v1/meshv1connect"
 	"github.com/coral-mesh/coral/internal/constants"
@@ -24,8 +26,13 @@ import (
 // Agent manages periodic heartbeats to the colony.
 // It is composable and accepts a mesh client for easy testing.
 type Agent struct {
-	id     string
-	client meshv1connect.MeshServiceClient
+	id       string
+	client   meshv1connect.MeshServiceClient
+	shedding *agentv1.ResourceShedding
+	health   *meshv1.AgentHealthMetrics
+
+	version     string
+	updateError string
 }
 
 // NewAgent creates a new heartbeat agent with the given ID and mesh client.
@@ -36,6 +43,42 @@ func NewAgent(id string, client meshv1connect.MeshServiceClient) *Agent {
 	}
 }
 
+// SetResourceShedding attaches the agent's resource safety valve state to
+// subsequent heartbeats. An active state reports the agent as degraded.
+func (a *Agent) SetResourceShedding(shedding *agentv1.ResourceShedding) {
+	a.shedding = shedding
+}
+
+// SetHealthMetrics attaches agent health metrics to subsequent heartbeats.
+func (a *Agent) SetHealthMetrics(metrics *meshv1.AgentHealthMetrics) {
+	a.health = metrics
+}
+
+// SetVersion reports the agent version in subsequent heartbeats, along with
+// why the agent failed to install the release advertised by the colony, if
+// it did.
+func (a *Agent) SetVersion(version, updateError string) {
+	a.version = version
+	a.updateError = updateError
+}
+
+// newRequest builds a heartbeat request for the current agent state.
+func (a *Agent) newRequest() *meshv1.HeartbeatRequest {
+	status := "healthy"
+	if a.shedding.GetActive() {
+		status = "degraded"
+	}
+	return &meshv1.HeartbeatRequest{
+		AgentId:          a.id,
+		Status:           status,
+		ResourceShedding: a.shedding,
+		HealthMetrics:    a.health,
+		SentAt:           timestamppb.Now(),
+		Version:          a.version,
+		UpdateError:      a.updateError,
+	}
+}
+
 // StartHeartbeat begins the heartbeat loop with the specified interval.
 // It returns only when the context is cancelled.
 // Errors are ignored to ensure the loop continues even after failures.
@@ -50,10 +93,7 @@ func (a *Agent) StartHeartbeat(ctx context.Context, interval time.Duration) {
 		case <-ticker.C:
 			// Use a timeout context for each heartbeat to prevent hanging.
 			heartbeatCtx, cancel := context.WithTimeout(ctx, constants.DefaultHeartbeatTimeout)
-			_, _ = a.client.Heartbeat(heartbeatCtx, connect.NewRequest(&meshv1.HeartbeatRequest{
-				AgentId: a.id,
-				Status:  "healthy",
-			}))
+			_, _ = a.client.Heartbeat(heartbeatCtx, connect.NewRequest(a.newRequest()))
 			cancel()
 		}
 	}
@@ -62,10 +102,7 @@ func (a *Agent) StartHeartbeat(ctx context.Context, interval time.Duration) {
 // SendHeartbeat sends a single heartbeat and returns the response and any error.
 // This method is useful for explicit heartbeat attempts with error handling.
 func (a *Agent) SendHeartbeat(ctx context.Context) (*meshv1.HeartbeatResponse, error) {
-	resp, err := a.client.Heartbeat(ctx, connect.NewRequest(&meshv1.HeartbeatRequest{
-		AgentId: a.id,
-		Status:  "healthy",
-	}))
+	resp, err := a.client.Heartbeat(ctx, connect.NewRequest(a.newRequest()))
 	if err != nil {
 		return nil, err
 	}
@@ -25,6 +25,10 @@ type heartbeatCall struct {
 	agentID   string
 	status    string
 	timestamp time.Time
+	health    *meshv1.AgentHealthMetrics
+	sentAt    time.Time
+	version   string
+	updateErr string
 }
 
 func newMockClient() *mockMeshServiceClient {
@@ -69,6 +73,10 @@ func (m *mockMeshServiceClient) Heartbeat(
 		agentID:   req.Msg.AgentId,
 		status:    req.Msg.Status,
 		timestamp: time.Now(),
+		health:    req.Msg.HealthMetrics,
+		sentAt:    req.Msg.SentAt.AsTime(),
+		version:   req.Msg.Version,
+		updateErr: req.Msg.UpdateError,
 	})
 	m.mu.Unlock()
 
@@ -78,6 +86,13 @@ func (m *mockMeshServiceClient) Heartbeat(
 }
 
 // awaitHeartbeats waits for N heartbeats to be received.
+func (m *mockMeshServiceClient) PunchHole(
+	ctx context.Context,
+	req *connect.Request[meshv1.PunchHoleRequest],
+) (*connect.Response[meshv1.PunchHoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
 func (m *mockMeshServiceClient) awaitHeartbeats(n int) {
 	for i := 0; i < n; i++ {
 		<-m.heartbeatReceived
@@ -213,6 +228,36 @@ func TestHeartbeatAgent(t *testing.T) {
 		assert.Equal(t, "healthy", heartbeats[0].status)
 	})
 
+	t.Run("SendHeartbeat includes health metrics and send time", func(t *testing.T) {
+		mockClient := &mockMeshServiceClient{}
+		agent := NewAgent("test-agent-6", mockClient)
+		agent.SetHealthMetrics(&meshv1.AgentHealthMetrics{QueueDepth: 42, EbpfFailures: 2})
+
+		before := time.Now()
+		_, err := agent.SendHeartbeat(context.Background())
+		require.NoError(t, err)
+
+		heartbeats := mockClient.getHeartbeats()
+		require.Len(t, heartbeats, 1)
+		assert.Equal(t, uint32(42), heartbeats[0].health.GetQueueDepth())
+		assert.Equal(t, uint32(2), heartbeats[0].health.GetEbpfFailures())
+		assert.False(t, heartbeats[0].sentAt.Before(before.Truncate(time.Microsecond)))
+	})
+
+	t.Run("SendHeartbeat includes version and update error", func(t *testing.T) {
+		mockClient := &mockMeshServiceClient{}
+		agent := NewAgent("test-agent-7", mockClient)
+		agent.SetVersion("v0.4.0", "checksum mismatch")
+
+		_, err := agent.SendHeartbeat(context.Background())
+		require.NoError(t, err)
+
+		heartbeats := mockClient.getHeartbeats()
+		require.Len(t, heartbeats, 1)
+		assert.Equal(t, "v0.4.0", heartbeats[0].version)
+		assert.Equal(t, "checksum mismatch", heartbeats[0].updateErr)
+	})
+
 	t.Run("SendHeartbeat returns error on failure", func(t *testing.T) {
 		mockClient := &mockMeshServiceClient{shouldFail: true}
 		agent := NewAgent("test-agent-5", mockClient)
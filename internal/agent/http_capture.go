@@ -0,0 +1,98 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/httpcapture"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// StartHttpCapture starts capturing the HTTP exchanges of a service whose
+// path matches a route, from the sockets of the service's port. Unlike
+// uprobes, it needs neither SDK nor debug info, only the port and a running
+// process.
+func (s *DebugService) StartHttpCapture(
+	ctx context.Context,
+	req *agentv1.StartHttpCaptureRequest,
+) (*agentv1.StartHttpCaptureResponse, error) {
+	s.logger.Info().
+		Str("service", req.ServiceName).
+		Str("route", req.Route).
+		Float64("sample_rate", req.SampleRate).
+		Msg("Starting HTTP capture")
+
+	if _, err := httpcapture.ParseRoute(req.Route); err != nil {
+		return nil, coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, err,
+			"route", req.Route))
+	}
+	if req.SampleRate < 0 || req.SampleRate > 1 {
+		return nil, coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+			fmt.Errorf("sample_rate must be between 0 and 1, got %g", req.SampleRate)))
+	}
+
+	var service *meshv1.ServiceInfo
+	for _, info := range s.agent.ServiceInfos() {
+		if info.Name == req.ServiceName {
+			service = info
+			break
+		}
+	}
+	if service == nil {
+		return nil, coralerrors.ToConnect(errServiceNotFound(req.ServiceName))
+	}
+	if service.ProcessId == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("no running process known for service %s", req.ServiceName))
+	}
+	if service.Port <= 0 || service.Port > 65535 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("service %s has no port to capture", req.ServiceName))
+	}
+
+	config := map[string]string{
+		"service_name": req.ServiceName,
+		"route":        req.Route,
+		"pid":          strconv.Itoa(int(service.ProcessId)),
+		"port":         strconv.Itoa(int(service.Port)),
+	}
+	if req.SessionId != "" {
+		config["session_id"] = req.SessionId
+	}
+	if req.SampleRate > 0 {
+		config["sample_rate"] = strconv.FormatFloat(req.SampleRate, 'g', -1, 64)
+	}
+	if req.CaptureBodies {
+		config["capture_bodies"] = "true"
+	}
+	if req.MaxBodyBytes > 0 {
+		config["max_body_bytes"] = strconv.FormatUint(uint64(req.MaxBodyBytes), 10)
+	}
+
+	resp, err := s.agent.ebpfManager.StartCollector(ctx, &meshv1.StartEbpfCollectorRequest{
+		AgentId:     req.AgentId,
+		ServiceName: req.ServiceName,
+		Kind:        agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_HTTP_CAPTURE,
+		Duration:    req.Duration,
+		Config:      config,
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to start HTTP capture")
+		return nil, coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_PROBE_ATTACH_FAILED,
+			fmt.Errorf("failed to start collector: %w", err),
+			"service", req.ServiceName, "route", req.Route))
+	}
+
+	return &agentv1.StartHttpCaptureResponse{
+		CollectorId: resp.CollectorId,
+		ExpiresAt:   resp.ExpiresAt,
+		Supported:   resp.Supported,
+		Error:       resp.Error,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/iotrace"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/runtimetrace"
+)
+
+// ProfileIO profiles the file reads and writes of the service running a
+// process for the requested duration, by file and device.
+func (s *DebugService) ProfileIO(
+	ctx context.Context,
+	req *agentv1.ProfileIOAgentRequest,
+) (*agentv1.ProfileIOAgentResponse, error) {
+	s.logger.Info().
+		Str("service", req.ServiceName).
+		Int32("pid", req.Pid).
+		Int32("duration_seconds", req.DurationSeconds).
+		Msg("Starting I/O profile")
+
+	if req.Pid <= 0 {
+		return &agentv1.ProfileIOAgentResponse{
+			Success: false,
+			Error:   "pid is required",
+		}, nil
+	}
+
+	duration := int(req.DurationSeconds)
+	if duration <= 0 {
+		duration = 30
+	}
+
+	report, err := ebpf.ProfileIO(ctx, s.logger, uint32(req.Pid), time.Duration(duration)*time.Second) // #nosec G115 -- checked positive
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to profile I/O")
+		return &agentv1.ProfileIOAgentResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to profile I/O: %v", err),
+		}, nil
+	}
+
+	resp := &agentv1.ProfileIOAgentResponse{
+		ReadLatency:  latencyBuckets(&report.ReadLatency),
+		WriteLatency: latencyBuckets(&report.WriteLatency),
+		Target:       report.Target,
+		StartTime:    timestamppb.New(report.Start),
+		EndTime:      timestamppb.New(report.End),
+		Success:      true,
+	}
+	for _, f := range report.Files {
+		resp.Files = append(resp.Files, &agentv1.FileIO{
+			Path:   f.Path,
+			Device: f.Device.String(),
+			Inode:  f.Inode,
+			Stats:  ioStats(&f.Stats),
+		})
+	}
+	for _, d := range report.Devices {
+		resp.Devices = append(resp.Devices, &agentv1.DeviceIO{
+			Device:     d.Device.String(),
+			Source:     d.Source,
+			FsType:     d.FSType,
+			MountPoint: d.MountPoint,
+			Stats:      ioStats(&d.Stats),
+		})
+	}
+	return resp, nil
+}
+
+func ioStats(s *iotrace.Stats) *agentv1.IOStats {
+	return &agentv1.IOStats{
+		Reads:        s.Reads,
+		Writes:       s.Writes,
+		ReadBytes:    s.ReadBytes,
+		WriteBytes:   s.WriteBytes,
+		ReadNs:       uint64(s.ReadTime),   // #nosec G115 -- durations are positive
+		WriteNs:      uint64(s.WriteTime),  // #nosec G115
+		MaxLatencyNs: uint64(s.MaxLatency), // #nosec G115
+	}
+}
+
+// latencyBuckets returns the non-empty buckets of a histogram.
+func latencyBuckets(h *runtimetrace.Histogram) []*agentv1.LatencyBucket {
+	var buckets []*agentv1.LatencyBucket
+	for i, count := range h {
+		if count > 0 {
+			buckets = append(buckets, &agentv1.LatencyBucket{
+				UpperBoundNs: uint64(runtimetrace.UpperBound(i)), // #nosec G115
+				Count:        count,
+			})
+		}
+	}
+	return buckets
+}
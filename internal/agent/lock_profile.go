@@ -0,0 +1,167 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/debug"
+	"github.com/coral-mesh/coral/internal/agent/ebpf"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/futextrace"
+	"github.com/coral-mesh/coral/internal/sys/proc"
+)
+
+// unknownStack names the waits whose user stack could not be captured.
+const unknownStack = "[unknown]"
+
+// ProfileLocks times the futex waits of the service running a process for
+// the requested duration, by symbolized user stack.
+func (s *DebugService) ProfileLocks(
+	ctx context.Context,
+	req *agentv1.ProfileLocksAgentRequest,
+) (*agentv1.ProfileLocksAgentResponse, error) {
+	s.logger.Info().
+		Str("service", req.ServiceName).
+		Int32("pid", req.Pid).
+		Int32("duration_seconds", req.DurationSeconds).
+		Msg("Starting lock profile")
+
+	if req.Pid <= 0 {
+		return &agentv1.ProfileLocksAgentResponse{
+			Success: false,
+			Error:   "pid is required",
+		}, nil
+	}
+
+	duration := int(req.DurationSeconds)
+	if duration <= 0 {
+		duration = 30
+	}
+
+	report, err := ebpf.ProfileLocks(ctx, s.logger, uint32(req.Pid), time.Duration(duration)*time.Second) // #nosec G115 -- checked positive
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to profile locks")
+		return &agentv1.ProfileLocksAgentResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to profile locks: %v", err),
+		}, nil
+	}
+
+	resp := &agentv1.ProfileLocksAgentResponse{
+		Stacks:    symbolizeLockStacks(report.Stacks, s.logger),
+		Target:    report.Target,
+		StartTime: timestamppb.New(report.Start),
+		EndTime:   timestamppb.New(report.End),
+		Success:   true,
+	}
+	for _, stack := range resp.Stacks {
+		resp.TotalWaits += stack.Waits
+		resp.TotalWaitNs += stack.WaitNs
+	}
+	return resp, nil
+}
+
+// symbolizeLockStacks names the frames of stacks with the symbols of their
+// process, merging the stacks that are the same once symbolized, e.g. of
+// several processes running the same binary.
+func symbolizeLockStacks(stacks []futextrace.Stack, logger zerolog.Logger) []*agentv1.LockWaitStack {
+	symbolizers := make(map[uint32]*stackSymbolizer)
+	defer func() {
+		for _, sym := range symbolizers {
+			sym.close()
+		}
+	}()
+
+	merged := make(map[string]*agentv1.LockWaitStack)
+	for _, stack := range stacks {
+		frames := []string{unknownStack}
+		if len(stack.Addrs) > 0 {
+			sym := symbolizers[stack.PID]
+			if sym == nil {
+				sym = newStackSymbolizer(int(stack.PID), logger)
+				symbolizers[stack.PID] = sym
+			}
+			frames = sym.frames(stack.Addrs)
+		}
+
+		key := strings.Join(frames, ";")
+		m := merged[key]
+		if m == nil {
+			m = &agentv1.LockWaitStack{FrameNames: frames}
+			merged[key] = m
+		}
+		m.Waits += stack.Waits
+		m.WaitNs += uint64(stack.Time)                        // #nosec G115 -- durations are positive
+		m.MaxWaitNs = max(m.MaxWaitNs, uint64(stack.MaxWait)) // #nosec G115
+	}
+
+	result := make([]*agentv1.LockWaitStack, 0, len(merged))
+	for _, m := range merged {
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].WaitNs != result[j].WaitNs {
+			return result[i].WaitNs > result[j].WaitNs
+		}
+		return strings.Join(result[i].FrameNames, ";") < strings.Join(result[j].FrameNames, ";")
+	})
+	return result
+}
+
+// stackSymbolizer resolves the user addresses of a process, with the debug
+// info of its binary first and the symbols of its mapped libraries second.
+type stackSymbolizer struct {
+	binary    *debug.Symbolizer
+	libraries *debug.LibrarySymbolizer
+}
+
+func newStackSymbolizer(pid int, logger zerolog.Logger) *stackSymbolizer {
+	s := &stackSymbolizer{}
+	binaryPath, err := proc.GetBinaryPath(pid)
+	if err == nil {
+		s.binary, err = debug.NewSymbolizer(binaryPath, pid, logger)
+	}
+	if err != nil {
+		logger.Debug().Err(err).Int("pid", pid).Msg("Cannot symbolize binary, using library symbols")
+	}
+	s.libraries, err = debug.NewLibrarySymbolizer(pid)
+	if err != nil {
+		logger.Debug().Err(err).Int("pid", pid).Msg("Cannot symbolize libraries")
+	}
+	return s
+}
+
+// frames returns the names of addrs, or their addresses when unknown.
+func (s *stackSymbolizer) frames(addrs []uint64) []string {
+	frames := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		frames = append(frames, s.frame(addr))
+	}
+	return frames
+}
+
+func (s *stackSymbolizer) frame(addr uint64) string {
+	if s.binary != nil {
+		if sym, err := s.binary.Resolve(addr); err == nil {
+			return debug.FormatSymbol(sym)
+		}
+	}
+	if s.libraries != nil {
+		if sym, err := s.libraries.Resolve(addr); err == nil {
+			return debug.FormatLibrarySymbol(sym)
+		}
+	}
+	return fmt.Sprintf("0x%x", addr)
+}
+
+func (s *stackSymbolizer) close() {
+	if s.binary != nil {
+		_ = s.binary.Close()
+	}
+}
@@ -0,0 +1,32 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/agent/ebpf/futextrace"
+)
+
+func TestSymbolizeLockStacks(t *testing.T) {
+	// Stacks that could not be captured merge across processes.
+	stacks := []futextrace.Stack{
+		{PID: 1, Waits: 2, Time: 3 * time.Millisecond, MaxWait: 2 * time.Millisecond},
+		{PID: 2, Waits: 1, Time: 5 * time.Millisecond, MaxWait: 5 * time.Millisecond},
+		// An address outside any mapping of a process that is gone.
+		{PID: 1 << 30, Addrs: []uint64{0x10}, Waits: 1, Time: time.Millisecond, MaxWait: time.Millisecond},
+	}
+
+	got := symbolizeLockStacks(stacks, zerolog.Nop())
+	require.Len(t, got, 2)
+
+	assert.Equal(t, []string{unknownStack}, got[0].FrameNames)
+	assert.Equal(t, uint64(3), got[0].Waits)
+	assert.Equal(t, uint64(8*time.Millisecond), got[0].WaitNs)
+	assert.Equal(t, uint64(5*time.Millisecond), got[0].MaxWaitNs)
+
+	assert.Equal(t, []string{"0x10"}, got[1].FrameNames)
+}
@@ -0,0 +1,453 @@
+// Package logtail collects the log lines of registered services and pushes
+// them to the colony, where coral tail reads them.
+//
+// Collection is opt-in per service. A service's logs are read from its
+// container's json-file log when it runs in a Docker container, from the
+// journal when it runs in a systemd unit, and otherwise from the files its
+// stdout and stderr are redirected to. Log files can also be configured
+// explicitly.
+package logtail
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/agent/redact"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// stopFlushTimeout bounds the final push when the collector stops.
+const stopFlushTimeout = 5 * time.Second
+
+// URLProvider returns the current colony base URL, or an empty string if the
+// colony is not yet reachable.
+type URLProvider func() string
+
+// Service is a service registered with the agent.
+type Service struct {
+	Name   string
+	Labels map[string]string
+	PID    int32
+}
+
+// ServiceProvider returns the services currently registered with the agent.
+type ServiceProvider func() []Service
+
+// File is a log file collected as the logs of a service.
+type File struct {
+	Service string
+	Path    string
+}
+
+// Config holds configuration for the Collector.
+type Config struct {
+	// AgentID is the reporting agent identifier (required).
+	AgentID string
+
+	// URLProvider returns the colony base URL on demand. Lines stay queued
+	// while it returns "".
+	URLProvider URLProvider
+
+	// Services lists the registered services.
+	Services ServiceProvider
+
+	// Include are glob patterns of the registered services whose logs are
+	// collected. Nothing is collected from services that match none.
+	Include []string
+
+	// Files are collected in addition to the logs of included services.
+	Files []File
+
+	// DockerRoot is the Docker data directory (default: /var/lib/docker).
+	DockerRoot string
+
+	// Interval controls how often sources are read and lines pushed
+	// (default: constants.DefaultLogCollectInterval).
+	Interval time.Duration
+
+	// MaxLineBytes truncates longer lines
+	// (default: constants.DefaultLogMaxLineBytes).
+	MaxLineBytes int
+
+	// QueueSize bounds the number of queued lines
+	// (default: constants.DefaultLogQueueSize).
+	QueueSize int
+
+	// BatchSize is the maximum number of lines per message
+	// (default: constants.DefaultLogBatchSize).
+	BatchSize int
+
+	// Redactor masks credentials in lines before they leave the agent.
+	Redactor *redact.Engine
+
+	// Logger is the zerolog logger for this component.
+	Logger zerolog.Logger
+}
+
+// Collector reads the logs of opted-in services and pushes them to the colony.
+//
+// Lines are held in a bounded queue that drops the oldest lines when the
+// colony is unreachable, and the number of dropped lines is reported with the
+// next push.
+type Collector struct {
+	agentID      string
+	urlProvider  URLProvider
+	services     ServiceProvider
+	include      []string
+	files        []File
+	dockerRoot   string
+	procRoot     string
+	interval     time.Duration
+	maxLineBytes int
+	queueSize    int
+	batchSize    int
+	redactor     *redact.Engine
+	httpClient   *http.Client
+	logger       zerolog.Logger
+
+	// sources are keyed by service and location; only the run loop uses them.
+	sources map[sourceKey]source
+	failed  map[sourceKey]bool // Sources that failed to open, logged once.
+
+	mu      sync.Mutex
+	queue   []*colonyv1.LogLine
+	dropped uint64 // Lines dropped since the last push.
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// sourceKey identifies a source of a service.
+type sourceKey struct {
+	service  string
+	location string // File path, or the unit of a journal.
+}
+
+// NewCollector creates a new Collector. Call Start to begin collecting.
+func NewCollector(cfg Config) *Collector {
+	if cfg.DockerRoot == "" {
+		cfg.DockerRoot = constants.DefaultDockerRoot
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = constants.DefaultLogCollectInterval
+	}
+	if cfg.MaxLineBytes == 0 {
+		cfg.MaxLineBytes = constants.DefaultLogMaxLineBytes
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = constants.DefaultLogQueueSize
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = constants.DefaultLogBatchSize
+	}
+
+	return &Collector{
+		agentID:      cfg.AgentID,
+		urlProvider:  cfg.URLProvider,
+		services:     cfg.Services,
+		include:      cfg.Include,
+		files:        cfg.Files,
+		dockerRoot:   cfg.DockerRoot,
+		procRoot:     "/proc",
+		interval:     cfg.Interval,
+		maxLineBytes: cfg.MaxLineBytes,
+		queueSize:    cfg.QueueSize,
+		batchSize:    cfg.BatchSize,
+		redactor:     cfg.Redactor,
+		httpClient:   newColonyHTTPClient(),
+		logger:       cfg.Logger.With().Str("component", "log_collector").Logger(),
+		sources:      make(map[sourceKey]source),
+		failed:       make(map[sourceKey]bool),
+	}
+}
+
+// Start begins the collect loop. It returns immediately; the loop runs in a
+// background goroutine until Stop is called.
+func (c *Collector) Start() error {
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.wg.Add(1)
+	go c.run()
+	c.logger.Info().
+		Strs("include", c.include).
+		Int("files", len(c.files)).
+		Msg("Service log collection started")
+	return nil
+}
+
+// Stop stops the collect loop, closes the sources and pushes the lines still
+// queued.
+func (c *Collector) Stop() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	for key, src := range c.sources {
+		src.close()
+		delete(c.sources, key)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopFlushTimeout)
+	defer cancel()
+	if err := c.Flush(ctx); err != nil {
+		c.logger.Debug().Err(err).Msg("Failed to push remaining log lines")
+	}
+
+	c.logger.Info().Msg("Service log collection stopped")
+	return nil
+}
+
+// run is the main collect loop.
+func (c *Collector) run() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		c.sync()
+		c.collect()
+		if err := c.Flush(c.ctx); err != nil && c.ctx.Err() == nil {
+			c.logger.Debug().Err(err).Msg("Failed to push log lines to colony, will retry")
+		}
+	}
+}
+
+// sync opens the sources of newly included services and closes those of
+// services that are gone.
+func (c *Collector) sync() {
+	wanted := make(map[sourceKey]func() (source, error))
+	for _, f := range c.files {
+		wanted[sourceKey{service: f.Service, location: f.Path}] = c.fileOpener(f.Path, f.Path, plainLine)
+	}
+	if c.services != nil {
+		for _, svc := range c.services() {
+			if c.included(svc.Name) {
+				c.serviceSources(svc, wanted)
+			}
+		}
+	}
+
+	for key, src := range c.sources {
+		if _, ok := wanted[key]; !ok {
+			src.close()
+			delete(c.sources, key)
+		}
+	}
+	for key, open := range wanted {
+		if _, ok := c.sources[key]; ok {
+			continue
+		}
+		src, err := open()
+		if err != nil {
+			if !c.failed[key] {
+				c.failed[key] = true
+				c.logger.Warn().Err(err).
+					Str("service", key.service).
+					Str("location", key.location).
+					Msg("Failed to open service logs")
+			}
+			continue
+		}
+		delete(c.failed, key)
+		c.sources[key] = src
+	}
+}
+
+// included reports whether the logs of a registered service are collected.
+func (c *Collector) included(service string) bool {
+	for _, pattern := range c.include {
+		if ok, _ := path.Match(pattern, service); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceSources adds the log sources of a registered service to wanted.
+func (c *Collector) serviceSources(svc Service, wanted map[sourceKey]func() (source, error)) {
+	if id := svc.Labels["container.id"]; id != "" {
+		logPath := filepath.Join(c.dockerRoot, "containers", id, id+"-json.log")
+		wanted[sourceKey{service: svc.Name, location: logPath}] = c.fileOpener(logPath, "stdout", decodeDockerLine)
+		return
+	}
+
+	if unit := svc.Labels["systemd.unit"]; unit != "" {
+		wanted[sourceKey{service: svc.Name, location: unit}] = func() (source, error) {
+			return newJournalSource(unit, c.maxLineBytes, c.queueSize)
+		}
+		return
+	}
+
+	if svc.PID <= 0 {
+		return
+	}
+	for fd, label := range map[int]string{1: "stdout", 2: "stderr"} {
+		target, err := os.Readlink(filepath.Join(c.procRoot, fmt.Sprint(svc.PID), "fd", fmt.Sprint(fd)))
+		if err != nil || !filepath.IsAbs(target) {
+			continue // Not redirected to a file (e.g. a pipe or a terminal).
+		}
+		if info, err := os.Stat(target); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		key := sourceKey{service: svc.Name, location: target}
+		if _, ok := wanted[key]; !ok || label == "stdout" {
+			wanted[key] = c.fileOpener(target, label, plainLine)
+		}
+	}
+}
+
+// fileOpener returns a function opening a tailer of the file at logPath.
+func (c *Collector) fileOpener(logPath, label string, decode decodeFunc) func() (source, error) {
+	return func() (source, error) {
+		return newFileTailer(logPath, label, decode, c.maxLineBytes), nil
+	}
+}
+
+// collect polls the sources and queues their new lines.
+func (c *Collector) collect() {
+	now := time.Now()
+	for key, src := range c.sources {
+		entries, err := src.poll()
+		for _, e := range entries {
+			c.enqueue(key.service, e, now)
+		}
+		if err != nil {
+			// Reopened by the next sync.
+			c.logger.Debug().Err(err).
+				Str("service", key.service).
+				Str("location", key.location).
+				Msg("Log source failed, reopening")
+			src.close()
+			delete(c.sources, key)
+		}
+	}
+}
+
+// enqueue redacts and queues a line. When the queue is full the oldest line
+// is dropped.
+func (c *Collector) enqueue(service string, e entry, now time.Time) {
+	text, _ := c.redactor.String(service, e.text)
+	at := e.at
+	if at.IsZero() {
+		at = now
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queue = append(c.queue, &colonyv1.LogLine{
+		Timestamp: timestamppb.New(at),
+		AgentId:   c.agentID,
+		Service:   service,
+		Source:    e.source,
+		Line:      text,
+	})
+	c.trimLocked()
+}
+
+// Flush pushes all queued lines. Lines of a failed push are queued again.
+func (c *Collector) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	lines := c.queue
+	dropped := c.dropped
+	c.queue = nil
+	c.dropped = 0
+	c.mu.Unlock()
+
+	if len(lines) == 0 && dropped == 0 {
+		return nil
+	}
+
+	if err := c.send(ctx, lines, dropped); err != nil {
+		c.mu.Lock()
+		c.queue = append(lines, c.queue...)
+		c.dropped += dropped
+		c.trimLocked()
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// send opens a ReportLogs stream, sends the lines in batches and closes the
+// stream.
+func (c *Collector) send(ctx context.Context, lines []*colonyv1.LogLine, dropped uint64) error {
+	colonyURL := ""
+	if c.urlProvider != nil {
+		colonyURL = c.urlProvider()
+	}
+	if colonyURL == "" {
+		return fmt.Errorf("colony URL not yet available")
+	}
+
+	client := colonyv1connect.NewColonyServiceClient(c.httpClient, colonyURL)
+	stream := client.ReportLogs(ctx)
+
+	for start := 0; start < len(lines) || start == 0; start += c.batchSize {
+		end := min(start+c.batchSize, len(lines))
+		msg := &colonyv1.ReportLogsRequest{
+			AgentId: c.agentID,
+			Lines:   lines[start:end],
+		}
+		if start == 0 {
+			msg.Dropped = dropped
+		}
+		if err := stream.Send(msg); err != nil {
+			_, _ = stream.CloseAndReceive()
+			return fmt.Errorf("send failed: %w", err)
+		}
+	}
+
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		return fmt.Errorf("CloseAndReceive failed: %w", err)
+	}
+
+	c.logger.Debug().
+		Int("lines", len(lines)).
+		Int64("accepted", resp.Msg.LinesAccepted).
+		Msg("Pushed service log lines to colony")
+	return nil
+}
+
+// trimLocked drops the oldest lines beyond the queue size.
+// Must be called with c.mu held.
+func (c *Collector) trimLocked() {
+	excess := len(c.queue) - c.queueSize
+	if excess <= 0 {
+		return
+	}
+	c.dropped += uint64(excess) // #nosec G115 -- excess is positive.
+	c.queue = c.queue[excess:]
+}
+
+// newColonyHTTPClient returns a plain HTTP client suitable for use over the
+// WireGuard mesh (encryption is provided by WireGuard, not TLS).
+func newColonyHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			MaxIdleConns:    10,
+			IdleConnTimeout: 90 * time.Second,
+		},
+	}
+}
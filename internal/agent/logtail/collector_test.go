@@ -0,0 +1,193 @@
+package logtail
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+)
+
+// fakeColony records pushed log batches.
+type fakeColony struct {
+	colonyv1connect.UnimplementedColonyServiceHandler
+
+	mu      sync.Mutex
+	fail    bool
+	batches []*colonyv1.ReportLogsRequest
+}
+
+func (c *fakeColony) ReportLogs(
+	_ context.Context,
+	stream *connect.ClientStream[colonyv1.ReportLogsRequest],
+) (*connect.Response[colonyv1.ReportLogsResponse], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fail {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("colony unavailable"))
+	}
+
+	resp := &colonyv1.ReportLogsResponse{}
+	for stream.Receive() {
+		c.batches = append(c.batches, stream.Msg())
+		resp.LinesAccepted += int64(len(stream.Msg().Lines))
+	}
+	return connect.NewResponse(resp), stream.Err()
+}
+
+func (c *fakeColony) lines() []*colonyv1.LogLine {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var lines []*colonyv1.LogLine
+	for _, b := range c.batches {
+		lines = append(lines, b.Lines...)
+	}
+	return lines
+}
+
+func newTestCollector(t *testing.T, colony *fakeColony, cfg Config) *Collector {
+	t.Helper()
+
+	_, h := colonyv1connect.NewColonyServiceHandler(colony)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	cfg.AgentID = "agent-1"
+	cfg.URLProvider = func() string { return srv.URL }
+	cfg.Logger = zerolog.Nop()
+	c := NewCollector(cfg)
+	c.httpClient = http.DefaultClient
+	c.procRoot = t.TempDir()
+	t.Cleanup(func() { _ = c.Stop() })
+	return c
+}
+
+func TestCollector_ConfiguredFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worker.log")
+	appendFile(t, path, "")
+
+	colony := &fakeColony{}
+	c := newTestCollector(t, colony, Config{
+		Files:     []File{{Service: "worker", Path: path}},
+		BatchSize: 2,
+	})
+
+	c.sync()
+	appendFile(t, path, "job 1 done\njob 2 done\njob 3 failed\n")
+	c.collect()
+	require.NoError(t, c.Flush(context.Background()))
+
+	lines := colony.lines()
+	require.Len(t, lines, 3)
+	assert.Equal(t, "job 3 failed", lines[2].Line)
+	assert.Equal(t, "worker", lines[2].Service)
+	assert.Equal(t, path, lines[2].Source)
+	assert.Equal(t, "agent-1", lines[2].AgentId)
+	assert.Len(t, colony.batches, 2, "lines are sent in batches")
+}
+
+func TestCollector_OptInServices(t *testing.T) {
+	dockerRoot := t.TempDir()
+	containerDir := filepath.Join(dockerRoot, "containers", "abc123")
+	require.NoError(t, os.MkdirAll(containerDir, 0o750))
+	logPath := filepath.Join(containerDir, "abc123-json.log")
+	appendFile(t, logPath, "")
+
+	services := []Service{
+		{Name: "api", Labels: map[string]string{"container.id": "abc123"}},
+		{Name: "cache", Labels: map[string]string{"container.id": "def456"}},
+	}
+
+	colony := &fakeColony{}
+	c := newTestCollector(t, colony, Config{
+		Services:   func() []Service { return services },
+		Include:    []string{"api*"},
+		DockerRoot: dockerRoot,
+	})
+
+	c.sync()
+	require.Len(t, c.sources, 1, "only included services are collected")
+
+	appendFile(t, logPath, `{"log":"listening on :8080\n","stream":"stderr","time":"2026-03-01T10:00:00Z"}`+"\n")
+	c.collect()
+	require.NoError(t, c.Flush(context.Background()))
+
+	lines := colony.lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "api", lines[0].Service)
+	assert.Equal(t, "stderr", lines[0].Source)
+	assert.Equal(t, "listening on :8080", lines[0].Line)
+
+	services = nil
+	c.sync()
+	assert.Empty(t, c.sources, "sources of removed services are closed")
+}
+
+func TestCollector_ProcessOutputFiles(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "out.log")
+	appendFile(t, logPath, "")
+
+	colony := &fakeColony{}
+	c := newTestCollector(t, colony, Config{
+		Services: func() []Service { return []Service{{Name: "batch", PID: 42}} },
+		Include:  []string{"*"},
+	})
+
+	fdDir := filepath.Join(c.procRoot, strconv.Itoa(42), "fd")
+	require.NoError(t, os.MkdirAll(fdDir, 0o750))
+	require.NoError(t, os.Symlink(logPath, filepath.Join(fdDir, "1")))
+	require.NoError(t, os.Symlink(logPath, filepath.Join(fdDir, "2")))
+	require.NoError(t, os.Symlink("pipe:[1234]", filepath.Join(fdDir, "0")))
+
+	c.sync()
+	require.Len(t, c.sources, 1, "stdout and stderr share the file")
+
+	appendFile(t, logPath, "processed 10 items\n")
+	c.collect()
+	require.NoError(t, c.Flush(context.Background()))
+
+	lines := colony.lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "stdout", lines[0].Source)
+}
+
+func TestCollector_DropsOldestWhenColonyUnreachable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "")
+
+	colony := &fakeColony{fail: true}
+	c := newTestCollector(t, colony, Config{
+		Files:     []File{{Service: "app", Path: path}},
+		QueueSize: 2,
+	})
+
+	c.sync()
+	appendFile(t, path, "one\ntwo\nthree\n")
+	c.collect()
+	require.Error(t, c.Flush(context.Background()))
+
+	colony.mu.Lock()
+	colony.fail = false
+	colony.mu.Unlock()
+	require.NoError(t, c.Flush(context.Background()))
+
+	lines := colony.lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "two", lines[0].Line)
+	assert.Equal(t, uint64(1), colony.batches[0].Dropped)
+}
@@ -0,0 +1,95 @@
+package logtail
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// journalSource follows the journal of a systemd unit with journalctl.
+type journalSource struct {
+	unit    string
+	maxKeep int
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	pending []entry
+	err     error
+}
+
+// newJournalSource starts following the journal of unit from now on. At most
+// maxKeep lines are kept between polls; the oldest are dropped.
+func newJournalSource(unit string, maxLineBytes, maxKeep int) (*journalSource, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := exec.CommandContext(ctx, "journalctl", // #nosec G204 - fixed binary, unit name from service discovery.
+		"--unit", unit, "--follow", "--lines", "0", "--output", "cat")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, fmt.Errorf("start journalctl: %w", err)
+	}
+
+	s := &journalSource{
+		unit:    unit,
+		maxKeep: maxKeep,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(s.done)
+
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 4096), maxLineBytes)
+		for scanner.Scan() {
+			s.add(entry{at: time.Now(), source: "journald", text: scanner.Text()})
+		}
+
+		err := scanner.Err()
+		if waitErr := cmd.Wait(); err == nil {
+			err = waitErr
+		}
+		if err == nil {
+			err = io.EOF
+		}
+		s.mu.Lock()
+		s.err = fmt.Errorf("journalctl for %s exited: %w", unit, err)
+		s.mu.Unlock()
+	}()
+
+	return s, nil
+}
+
+func (s *journalSource) add(e entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pending = append(s.pending, e)
+	if excess := len(s.pending) - s.maxKeep; excess > 0 {
+		s.pending = s.pending[excess:]
+	}
+}
+
+func (s *journalSource) poll() ([]entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := s.pending
+	s.pending = nil
+	return entries, s.err
+}
+
+func (s *journalSource) close() {
+	s.cancel()
+	<-s.done
+}
@@ -0,0 +1,188 @@
+package logtail
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// entry is a log line read from a source.
+type entry struct {
+	at     time.Time
+	source string
+	text   string
+}
+
+// source produces the log lines of one service.
+type source interface {
+	// poll returns the lines written since the previous poll.
+	poll() ([]entry, error)
+	// close releases the source.
+	close()
+}
+
+// decodeFunc turns a raw line of a file into an entry. It returns false for
+// lines that should be skipped.
+type decodeFunc func(raw []byte, fallback string) (entry, bool)
+
+// plainLine decodes a line of a plain text log file.
+func plainLine(raw []byte, label string) (entry, bool) {
+	return entry{at: time.Now(), source: label, text: string(raw)}, true
+}
+
+// dockerLine is a line of a Docker json-file log.
+type dockerLine struct {
+	Log    string    `json:"log"`
+	Stream string    `json:"stream"`
+	Time   time.Time `json:"time"`
+}
+
+// decodeDockerLine decodes a line of a Docker json-file log, keeping the
+// stream (stdout or stderr) as the source.
+func decodeDockerLine(raw []byte, fallback string) (entry, bool) {
+	var l dockerLine
+	if err := json.Unmarshal(raw, &l); err != nil {
+		return entry{}, false
+	}
+
+	e := entry{at: l.Time, source: l.Stream, text: strings.TrimRight(l.Log, "\r\n")}
+	if e.at.IsZero() {
+		e.at = time.Now()
+	}
+	if e.source == "" {
+		e.source = fallback
+	}
+	return e, true
+}
+
+// fileTailer follows a log file from its end, like tail -F: it reopens the
+// path when the file is rotated and starts over when it is truncated.
+type fileTailer struct {
+	path         string
+	label        string // Source reported with plain lines.
+	decode       decodeFunc
+	maxLineBytes int
+
+	file    *os.File
+	offset  int64
+	partial []byte // Incomplete last line, completed by the next poll.
+}
+
+// newFileTailer creates a tailer positioned at the end of path. The file
+// may not exist yet; it is opened once it appears.
+func newFileTailer(path, label string, decode decodeFunc, maxLineBytes int) *fileTailer {
+	t := &fileTailer{
+		path:         path,
+		label:        label,
+		decode:       decode,
+		maxLineBytes: maxLineBytes,
+	}
+	_ = t.open(true)
+	return t
+}
+
+// open opens the path, at its end if atEnd is set.
+func (t *fileTailer) open(atEnd bool) error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+
+	var offset int64
+	if atEnd {
+		if offset, err = f.Seek(0, io.SeekEnd); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+
+	t.file = f
+	t.offset = offset
+	t.partial = nil
+	return nil
+}
+
+func (t *fileTailer) poll() ([]entry, error) {
+	if t.file == nil {
+		// The file did not exist yet: everything in it is new.
+		if err := t.open(false); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+
+	info, err := t.file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", t.path, err)
+	}
+	if info.Size() < t.offset {
+		// Truncated in place (copytruncate rotation).
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		t.offset = 0
+		t.partial = nil
+	}
+
+	entries, err := t.read()
+	if err != nil {
+		return entries, err
+	}
+
+	// Replaced by a new file (rename rotation): the rest of the old file was
+	// read above, continue with the new one from its start.
+	current, err := os.Stat(t.path)
+	if err == nil && !os.SameFile(info, current) {
+		t.close()
+		if err := t.open(false); err != nil {
+			return entries, nil
+		}
+		more, err := t.read()
+		return append(entries, more...), err
+	}
+
+	return entries, nil
+}
+
+// read reads and decodes the complete lines from the current offset. Lines
+// longer than maxLineBytes are truncated.
+func (t *fileTailer) read() ([]entry, error) {
+	var entries []entry
+	reader := bufio.NewReader(t.file)
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		t.offset += int64(len(chunk))
+		if room := t.maxLineBytes - len(t.partial); room > 0 {
+			t.partial = append(t.partial, chunk[:min(room, len(chunk))]...)
+		}
+
+		switch {
+		case err == nil:
+			if e, ok := t.decode(bytes.TrimRight(t.partial, "\r\n"), t.label); ok {
+				entries = append(entries, e)
+			}
+			t.partial = t.partial[:0]
+		case errors.Is(err, bufio.ErrBufferFull):
+			// Longer than the read buffer, the line continues.
+		case errors.Is(err, io.EOF):
+			return entries, nil
+		default:
+			return entries, err
+		}
+	}
+}
+
+func (t *fileTailer) close() {
+	if t.file != nil {
+		_ = t.file.Close()
+		t.file = nil
+	}
+}
@@ -0,0 +1,116 @@
+package logtail
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+}
+
+func pollTexts(t *testing.T, src source) []string {
+	t.Helper()
+	entries, err := src.poll()
+	require.NoError(t, err)
+	out := make([]string, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, e.text)
+	}
+	return out
+}
+
+func TestFileTailer_StartsAtEndAndFollows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "old line\n")
+
+	tailer := newFileTailer(path, "stdout", plainLine, 1024)
+	defer tailer.close()
+	assert.Empty(t, pollTexts(t, tailer), "existing content is skipped")
+
+	appendFile(t, path, "first\nsecond\npart")
+	assert.Equal(t, []string{"first", "second"}, pollTexts(t, tailer))
+
+	appendFile(t, path, "ial\r\n")
+	entries, err := tailer.poll()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "partial", entries[0].text, "incomplete lines wait for their newline")
+	assert.Equal(t, "stdout", entries[0].source)
+}
+
+func TestFileTailer_FileCreatedLater(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	tailer := newFileTailer(path, "stdout", plainLine, 1024)
+	defer tailer.close()
+	assert.Empty(t, pollTexts(t, tailer))
+
+	appendFile(t, path, "hello\n")
+	assert.Equal(t, []string{"hello"}, pollTexts(t, tailer))
+}
+
+func TestFileTailer_Truncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "")
+
+	tailer := newFileTailer(path, "stdout", plainLine, 1024)
+	defer tailer.close()
+	appendFile(t, path, "before truncation\n")
+	assert.Equal(t, []string{"before truncation"}, pollTexts(t, tailer))
+
+	require.NoError(t, os.Truncate(path, 0))
+	appendFile(t, path, "after\n")
+	assert.Equal(t, []string{"after"}, pollTexts(t, tailer))
+}
+
+func TestFileTailer_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "")
+
+	tailer := newFileTailer(path, "stdout", plainLine, 1024)
+	defer tailer.close()
+
+	appendFile(t, path, "last of old file\n")
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "app.log.1")))
+	appendFile(t, path, "first of new file\n")
+
+	assert.Equal(t, []string{"last of old file", "first of new file"}, pollTexts(t, tailer))
+
+	appendFile(t, path, "next\n")
+	assert.Equal(t, []string{"next"}, pollTexts(t, tailer))
+}
+
+func TestFileTailer_TruncatesLongLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "")
+
+	tailer := newFileTailer(path, "stdout", plainLine, 10)
+	defer tailer.close()
+
+	appendFile(t, path, strings.Repeat("x", 10000)+"\nshort\n")
+	assert.Equal(t, []string{strings.Repeat("x", 10), "short"}, pollTexts(t, tailer))
+}
+
+func TestDecodeDockerLine(t *testing.T) {
+	e, ok := decodeDockerLine([]byte(`{"log":"GET /health 200\n","stream":"stderr","time":"2026-03-01T10:00:00.5Z"}`), "stdout")
+	require.True(t, ok)
+	assert.Equal(t, "GET /health 200", e.text)
+	assert.Equal(t, "stderr", e.source)
+	assert.Equal(t, time.Date(2026, 3, 1, 10, 0, 0, 500_000_000, time.UTC), e.at)
+
+	_, ok = decodeDockerLine([]byte("not json"), "stdout")
+	assert.False(t, ok)
+}
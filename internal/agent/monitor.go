@@ -43,6 +43,7 @@ type ServiceStatusInfo struct {
 type ServiceMonitor struct {
 	service             *meshv1.ServiceInfo
 	sdkCapabilities     *agentv1.ServiceSdkCapabilities // RFD 060
+	labels              map[string]string               // Service labels merged with the SDK ones; nil without SDK labels
 	status              ServiceStatus
 	lastCheck           time.Time
 	lastError           error
@@ -183,8 +184,7 @@ func (m *ServiceMonitor) discoverProcessInfo() {
 		return
 	}
 
-	// findPidByPort finds the PID of the process listening on the given port.
-	pid, err := proc.FindPidByPort(int(m.service.Port))
+	pid, err := m.resolvePID()
 	if err != nil {
 		// Don't log error on every check to avoid spam, unless debug logging is on
 		m.logger.Debug().Err(err).Msg("Failed to discover process ID")
@@ -249,6 +249,20 @@ func (m *ServiceMonitor) discoverProcessInfo() {
 	m.mu.Unlock()
 }
 
+// resolvePID returns the PID of the service process. Services without a port
+// (e.g. discovered from cgroups) are registered with their PID.
+func (m *ServiceMonitor) resolvePID() (int32, error) {
+	if m.service.Port == 0 {
+		if m.service.ProcessId == 0 || !proc.Exists(int(m.service.ProcessId)) {
+			return 0, nil
+		}
+		return m.service.ProcessId, nil
+	}
+
+	// findPidByPort finds the PID of the process listening on the given port.
+	return proc.FindPidByPort(int(m.service.Port))
+}
+
 // performHealthCheck executes a health check for the service.
 func (m *ServiceMonitor) performHealthCheck() {
 	ctx, cancel := context.WithTimeout(m.ctx, m.checkTimeout)
@@ -261,6 +275,9 @@ func (m *ServiceMonitor) performHealthCheck() {
 	if m.service.HealthEndpoint != "" {
 		// HTTP health check.
 		err = m.checkHTTPHealth(ctx)
+	} else if m.service.Port == 0 && m.service.ProcessId != 0 {
+		// Process liveness check for services without a port.
+		err = m.checkProcessHealth()
 	} else {
 		// TCP port check (basic connectivity).
 		err = m.checkTCPHealth(ctx)
@@ -321,6 +338,14 @@ func (m *ServiceMonitor) checkTCPHealth(ctx context.Context) error {
 	return nil
 }
 
+// checkProcessHealth checks that the service process is still running.
+func (m *ServiceMonitor) checkProcessHealth() error {
+	if !proc.Exists(int(m.service.ProcessId)) {
+		return fmt.Errorf("process %d is not running", m.service.ProcessId)
+	}
+	return nil
+}
+
 // SetSdkCapabilities updates the SDK capabilities for the service.
 func (m *ServiceMonitor) SetSdkCapabilities(caps *agentv1.ServiceSdkCapabilities) {
 	m.mu.Lock()
@@ -338,6 +363,18 @@ func (m *ServiceMonitor) SetSdkCapabilities(caps *agentv1.ServiceSdkCapabilities
 		m.processID = pid
 	}
 
+	// Add the SDK labels, keeping labels set when connecting the service.
+	if len(caps.Labels) > 0 {
+		labels := make(map[string]string, len(m.service.Labels)+len(caps.Labels))
+		for k, v := range caps.Labels {
+			labels[k] = v
+		}
+		for k, v := range m.service.Labels {
+			labels[k] = v
+		}
+		m.labels = labels
+	}
+
 	var shouldTriggerDiscovery bool
 	var shouldNotifySDK bool
 	var binaryPath, sdkAddr, serviceName, binaryHash string
@@ -431,6 +468,17 @@ func (m *ServiceMonitor) discoverSDKCapabilities() {
 		Msg("Discovered SDK via HTTP")
 }
 
+// Labels returns the labels of the service: those set when connecting it,
+// and those set through the SDK.
+func (m *ServiceMonitor) Labels() map[string]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.labels != nil {
+		return m.labels
+	}
+	return m.service.Labels
+}
+
 // GetSdkCapabilities returns the SDK capabilities for the service.
 func (m *ServiceMonitor) GetSdkCapabilities() *agentv1.ServiceSdkCapabilities {
 	m.mu.RLock()
@@ -476,5 +524,6 @@ func discoverSDKCapabilities(ctx context.Context, addr string, logger zerolog.Lo
 		BinaryPath:      capsResp.BinaryPath,
 		FunctionCount:   uint32(capsResp.FunctionCount),
 		BinaryHash:      capsResp.BinaryHash,
+		Labels:          capsResp.Labels,
 	}
 }
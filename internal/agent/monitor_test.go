@@ -72,3 +72,20 @@ func TestServiceMonitor_GetStatus(t *testing.T) {
 	assert.Equal(t, "/bin/test", status.BinaryPath)
 	assert.False(t, status.LastCheck.IsZero())
 }
+
+func TestServiceMonitor_SdkLabels(t *testing.T) {
+	service := &meshv1.ServiceInfo{
+		Name:   "test-service",
+		Labels: map[string]string{"env": "prod"},
+	}
+	monitor := NewServiceMonitor(context.Background(), service, nil, zerolog.Nop())
+	assert.Equal(t, map[string]string{"env": "prod"}, monitor.Labels())
+
+	monitor.SetSdkCapabilities(&agentv1.ServiceSdkCapabilities{
+		Labels: map[string]string{"env": "staging", "team": "payments"},
+	})
+
+	assert.Equal(t, map[string]string{"env": "prod", "team": "payments"}, monitor.Labels(),
+		"labels set when connecting take precedence")
+	assert.Equal(t, map[string]string{"env": "prod"}, service.Labels, "service info is not modified")
+}
@@ -28,6 +28,7 @@ type ContinuousCPUProfiler struct {
 	kernelSymbolizer *debug.KernelSymbolizer
 	activeServices   map[string]struct{} // Tracks services with active profiling loops.
 	activeServicesMu sync.Mutex
+	gate             *pauseGate
 }
 
 // Config holds configuration for continuous CPU profiling.
@@ -101,6 +102,7 @@ func NewContinuousCPUProfiler(
 		cancel:           cancel,
 		kernelSymbolizer: kernelSymbolizer,
 		activeServices:   make(map[string]struct{}),
+		gate:             newPauseGate(),
 	}
 
 	return profiler, nil
@@ -132,10 +134,46 @@ func (p *ContinuousCPUProfiler) Stop() {
 	p.cancel()
 }
 
-// profileServiceLoop starts a persistent BPF session and periodically drains
-// accumulated samples from the BPF maps. The BPF program runs continuously in
-// the kernel, so no samples are lost between collection ticks.
+// Pause stops sampling for all services until Resume is called. BPF sessions
+// are closed so the kernel stops sampling as well.
+func (p *ContinuousCPUProfiler) Pause() {
+	if p.gate.set(true) {
+		p.logger.Info().Msg("Paused continuous CPU profiling")
+	}
+}
+
+// Resume restarts sampling after Pause.
+func (p *ContinuousCPUProfiler) Resume() {
+	if p.gate.set(false) {
+		p.logger.Info().Msg("Resumed continuous CPU profiling")
+	}
+}
+
+// profileServiceLoop profiles a service until the profiler is stopped,
+// closing its BPF session while profiling is paused.
 func (p *ContinuousCPUProfiler) profileServiceLoop(service ServiceInfo) {
+	for {
+		paused, changed := p.gate.state()
+		if paused {
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-changed:
+				continue
+			}
+		}
+
+		if !p.runSession(service, changed) {
+			return
+		}
+	}
+}
+
+// runSession starts a persistent BPF session and periodically drains
+// accumulated samples from the BPF maps. The BPF program runs continuously in
+// the kernel, so no samples are lost between collection ticks. It returns true
+// when profiling was paused and false when the loop should exit.
+func (p *ContinuousCPUProfiler) runSession(service ServiceInfo, paused <-chan struct{}) bool {
 	p.logger.Info().
 		Str("service_id", service.ServiceID).
 		Int("pid", service.PID).
@@ -156,7 +194,7 @@ func (p *ContinuousCPUProfiler) profileServiceLoop(service ServiceInfo) {
 			Str("service_id", service.ServiceID).
 			Int("pid", service.PID).
 			Msg("Failed to start persistent CPU profile session")
-		return
+		return false
 	}
 	defer func() { _ = session.Close() }()
 
@@ -175,7 +213,9 @@ func (p *ContinuousCPUProfiler) profileServiceLoop(service ServiceInfo) {
 			p.logger.Info().
 				Str("service_id", service.ServiceID).
 				Msg("Stopping continuous profiling for service")
-			return
+			return false
+		case <-paused:
+			return true
 		case <-ticker.C:
 			if err := p.drainAndStore(session, service); err != nil {
 				p.logger.Error().
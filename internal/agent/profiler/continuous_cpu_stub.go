@@ -48,6 +48,12 @@ func (p *ContinuousCPUProfiler) Start(services []ServiceInfo) {}
 // Stop is a no-op on non-Linux platforms.
 func (p *ContinuousCPUProfiler) Stop() {}
 
+// Pause is a no-op on non-Linux platforms.
+func (p *ContinuousCPUProfiler) Pause() {}
+
+// Resume is a no-op on non-Linux platforms.
+func (p *ContinuousCPUProfiler) Resume() {}
+
 // AddService is a no-op on non-Linux platforms.
 func (p *ContinuousCPUProfiler) AddService(_ string, _ int, _ string) {}
 
@@ -38,6 +38,7 @@ type ContinuousMemoryProfiler struct {
 	cancel           context.CancelFunc
 	activeServices   map[string]MemoryServiceInfo
 	activeServicesMu sync.Mutex
+	gate             *pauseGate
 }
 
 // NewContinuousMemoryProfiler creates a new continuous memory profiler (RFD 077).
@@ -79,6 +80,7 @@ func NewContinuousMemoryProfiler(
 		ctx:            ctx,
 		cancel:         cancel,
 		activeServices: make(map[string]MemoryServiceInfo),
+		gate:           newPauseGate(),
 	}
 
 	return profiler, nil
@@ -105,6 +107,20 @@ func (p *ContinuousMemoryProfiler) Stop() {
 	p.cancel()
 }
 
+// Pause skips heap collection for all services until Resume is called.
+func (p *ContinuousMemoryProfiler) Pause() {
+	if p.gate.set(true) {
+		p.logger.Info().Msg("Paused continuous memory profiling")
+	}
+}
+
+// Resume restarts heap collection after Pause.
+func (p *ContinuousMemoryProfiler) Resume() {
+	if p.gate.set(false) {
+		p.logger.Info().Msg("Resumed continuous memory profiling")
+	}
+}
+
 // AddService adds a service to be memory-profiled continuously (RFD 077).
 func (p *ContinuousMemoryProfiler) AddService(serviceID string, pid int, binaryPath string, sdkAddr string) {
 	p.activeServicesMu.Lock()
@@ -147,6 +163,9 @@ func (p *ContinuousMemoryProfiler) profileServiceLoop(service MemoryServiceInfo)
 				Msg("Stopping continuous memory profiling for service")
 			return
 		case <-ticker.C:
+			if paused, _ := p.gate.state(); paused {
+				continue
+			}
 			if err := p.collectAndStore(service); err != nil {
 				p.logger.Error().
 					Err(err).
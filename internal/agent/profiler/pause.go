@@ -0,0 +1,36 @@
+package profiler
+
+import "sync"
+
+// pauseGate lets profiling loops be paused and resumed, e.g. when the agent
+// sheds load to stay within its resource limits.
+type pauseGate struct {
+	mu      sync.Mutex
+	paused  bool
+	changed chan struct{} // Closed and replaced on every state change.
+}
+
+func newPauseGate() *pauseGate {
+	return &pauseGate{changed: make(chan struct{})}
+}
+
+// set updates the paused state and reports whether it changed.
+func (g *pauseGate) set(paused bool) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.paused == paused {
+		return false
+	}
+	g.paused = paused
+	close(g.changed)
+	g.changed = make(chan struct{})
+	return true
+}
+
+// state returns the paused state and a channel closed on the next change.
+func (g *pauseGate) state() (bool, <-chan struct{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused, g.changed
+}
@@ -0,0 +1,36 @@
+package profiler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauseGate(t *testing.T) {
+	g := newPauseGate()
+
+	paused, changed := g.state()
+	assert.False(t, paused)
+
+	assert.True(t, g.set(true))
+	select {
+	case <-changed:
+	default:
+		t.Fatal("changed channel was not closed on pause")
+	}
+
+	paused, changed = g.state()
+	assert.True(t, paused)
+
+	// Setting the same state is not a change.
+	assert.False(t, g.set(true))
+	select {
+	case <-changed:
+		t.Fatal("changed channel closed without a state change")
+	default:
+	}
+
+	assert.True(t, g.set(false))
+	paused, _ = g.state()
+	assert.False(t, paused)
+}
@@ -94,6 +94,10 @@ func NewStorage(db *sql.DB, logger zerolog.Logger) (*Storage, error) {
 	return s, nil
 }
 
+// migrations are the profiling schema changes applied after initSchema; the
+// CREATE TABLE statements there only affect new databases.
+var migrations []duckdb.Migration
+
 // initSchema creates the local continuous profiling tables.
 func (s *Storage) initSchema() error {
 	schema := `
@@ -166,6 +170,10 @@ func (s *Storage) initSchema() error {
 		return fmt.Errorf("failed to create schema: %w", err)
 	}
 
+	if err := duckdb.ApplyMigrations(context.Background(), s.db, "profiler", migrations, s.logger); err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
 	// Force WAL checkpoint so remote HTTP clients can see the schema.
 	if _, err := s.db.Exec("CHECKPOINT"); err != nil {
 		s.logger.Warn().Err(err).Msg("Failed to checkpoint database")
@@ -0,0 +1,145 @@
+// Package recording records the input and output of shell and exec sessions
+// run on the agent and uploads the recordings to the colony for incident
+// review.
+package recording
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Kinds of recorded sessions.
+const (
+	KindShell         = "shell"
+	KindExec          = "exec"
+	KindContainerExec = "container_exec"
+
+	// Interactive exec sessions run with a PTY like shells.
+	KindExecTTY          = "exec_tty"
+	KindContainerExecTTY = "container_exec_tty"
+)
+
+// Event types, as in asciicast v2.
+const (
+	EventInput  = "i"
+	EventOutput = "o"
+	EventResize = "r"
+)
+
+// Sink receives the recording of a session once it has ended.
+type Sink func(rec *colonyv1.ShellRecording)
+
+// Session describes a recorded session.
+type Session struct {
+	Kind          string
+	SessionID     string
+	UserID        string
+	Command       []string
+	ContainerName string
+	Cols          uint32
+	Rows          uint32
+}
+
+// Recorder records the events of a session. All methods are safe for
+// concurrent use and on a nil Recorder, which records nothing.
+type Recorder struct {
+	sink     Sink
+	maxBytes uint64
+	started  time.Time
+
+	mu     sync.Mutex
+	rec    *colonyv1.ShellRecording
+	closed bool
+}
+
+// New starts recording a session, delivered to sink when the recorder is
+// closed. It returns nil if sink is nil.
+func New(sink Sink, session Session) *Recorder {
+	if sink == nil {
+		return nil
+	}
+
+	started := time.Now()
+	return &Recorder{
+		sink:     sink,
+		maxBytes: constants.DefaultShellRecordingMaxBytes,
+		started:  started,
+		rec: &colonyv1.ShellRecording{
+			SessionId:     session.SessionID,
+			Kind:          session.Kind,
+			UserId:        session.UserID,
+			Command:       session.Command,
+			ContainerName: session.ContainerName,
+			StartedAt:     timestamppb.New(started),
+			Cols:          session.Cols,
+			Rows:          session.Rows,
+		},
+	}
+}
+
+// Input records data written to the session.
+func (r *Recorder) Input(data []byte) {
+	r.record(EventInput, data)
+}
+
+// Output records data the session wrote.
+func (r *Recorder) Output(data []byte) {
+	r.record(EventOutput, data)
+}
+
+// Resize records a resize of the session's terminal.
+func (r *Recorder) Resize(cols, rows uint32) {
+	r.record(EventResize, []byte(fmt.Sprintf("%dx%d", cols, rows)))
+}
+
+// Close ends the recording and delivers it to the sink. Events recorded
+// after Close are ignored, as are further calls to Close.
+func (r *Recorder) Close(exitCode int32) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	rec := r.rec
+	rec.EndedAt = timestamppb.Now()
+	rec.ExitCode = exitCode
+	r.mu.Unlock()
+
+	r.sink(rec)
+}
+
+func (r *Recorder) record(eventType string, data []byte) {
+	if r == nil || len(data) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed || r.rec.Truncated {
+		return
+	}
+	if r.rec.SizeBytes+uint64(len(data)) > r.maxBytes {
+		r.rec.Truncated = true
+		return
+	}
+
+	r.rec.SizeBytes += uint64(len(data))
+	r.rec.Events = append(r.rec.Events, &colonyv1.ShellRecordingEvent{
+		OffsetUs: time.Since(r.started).Microseconds(),
+		Type:     eventType,
+		// Callers reuse their buffers.
+		Data: append([]byte(nil), data...),
+	})
+}
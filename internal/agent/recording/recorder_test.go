@@ -0,0 +1,159 @@
+package recording
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+)
+
+func TestRecorder(t *testing.T) {
+	var got *colonyv1.ShellRecording
+	r := New(func(rec *colonyv1.ShellRecording) { got = rec }, Session{
+		Kind:      KindShell,
+		SessionID: "s1",
+		UserID:    "alice",
+		Command:   []string{"/bin/bash"},
+		Cols:      80,
+		Rows:      24,
+	})
+
+	buf := []byte("ls\r")
+	r.Input(buf)
+	buf[0] = 'X' // Callers reuse their buffers.
+	r.Output([]byte("file.txt\r\n"))
+	r.Resize(120, 40)
+	r.Output(nil)
+	r.Close(0)
+	r.Output([]byte("after close"))
+	r.Close(1)
+
+	if got == nil {
+		t.Fatal("expected the recording to be delivered on Close")
+	}
+	if got.ExitCode != 0 || got.EndedAt == nil {
+		t.Errorf("expected exit code 0 and an end time, got %d, %v", got.ExitCode, got.EndedAt)
+	}
+	if len(got.Events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(got.Events))
+	}
+	if e := got.Events[0]; e.Type != EventInput || string(e.Data) != "ls\r" {
+		t.Errorf("unexpected input event %v", e)
+	}
+	if e := got.Events[2]; e.Type != EventResize || string(e.Data) != "120x40" {
+		t.Errorf("unexpected resize event %v", e)
+	}
+	if got.SizeBytes != uint64(len("ls\r")+len("file.txt\r\n")+len("120x40")) {
+		t.Errorf("unexpected size %d", got.SizeBytes)
+	}
+}
+
+func TestRecorder_Truncates(t *testing.T) {
+	var got *colonyv1.ShellRecording
+	r := New(func(rec *colonyv1.ShellRecording) { got = rec }, Session{Kind: KindExec, SessionID: "s1"})
+	r.maxBytes = 8
+
+	r.Output([]byte("12345"))
+	r.Output([]byte("67890"))
+	r.Output([]byte("1"))
+	r.Close(0)
+
+	if !got.Truncated || len(got.Events) != 1 || got.SizeBytes != 5 {
+		t.Errorf("expected events past the limit to be dropped, got truncated=%v, %d events, %d bytes",
+			got.Truncated, len(got.Events), got.SizeBytes)
+	}
+}
+
+func TestRecorder_NilWithoutSink(t *testing.T) {
+	r := New(nil, Session{Kind: KindExec})
+	if r != nil {
+		t.Fatal("expected no recorder without a sink")
+	}
+
+	// A nil recorder records nothing.
+	r.Input([]byte("x"))
+	r.Output([]byte("x"))
+	r.Resize(80, 24)
+	r.Close(0)
+}
+
+// fakeColony records uploaded recordings.
+type fakeColony struct {
+	colonyv1connect.UnimplementedColonyDebugServiceHandler
+
+	mu         sync.Mutex
+	fail       bool
+	recordings []*colonyv1.ShellRecording
+}
+
+func (c *fakeColony) UploadShellRecording(
+	_ context.Context,
+	req *connect.Request[colonyv1.UploadShellRecordingRequest],
+) (*connect.Response[colonyv1.UploadShellRecordingResponse], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.fail {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("colony unavailable"))
+	}
+	c.recordings = append(c.recordings, req.Msg.Recording)
+	return connect.NewResponse(&colonyv1.UploadShellRecordingResponse{}), nil
+}
+
+func newTestUploader(t *testing.T, colony *fakeColony, queueSize int) *Uploader {
+	t.Helper()
+
+	_, h := colonyv1connect.NewColonyDebugServiceHandler(colony)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	u := NewUploader(Config{
+		AgentID:     "agent-1",
+		URLProvider: func() string { return srv.URL },
+		QueueSize:   queueSize,
+		Logger:      zerolog.Nop(),
+	})
+	u.httpClient = http.DefaultClient
+	return u
+}
+
+func TestUploader_RetriesAndDropsOldest(t *testing.T) {
+	colony := &fakeColony{fail: true}
+	u := newTestUploader(t, colony, 2)
+
+	for _, id := range []string{"s1", "s2", "s3"} {
+		u.Push(&colonyv1.ShellRecording{SessionId: id})
+	}
+	if err := u.Flush(context.Background()); err == nil {
+		t.Fatal("expected Flush to fail while the colony is unavailable")
+	}
+	if queued := u.Queued(); queued != 2 {
+		t.Fatalf("expected 2 recordings queued for retry, got %d", queued)
+	}
+
+	colony.mu.Lock()
+	colony.fail = false
+	colony.mu.Unlock()
+
+	if err := u.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	if len(colony.recordings) != 2 || colony.recordings[0].SessionId != "s2" || colony.recordings[1].SessionId != "s3" {
+		t.Fatalf("expected the newest recordings uploaded in order, got %v", colony.recordings)
+	}
+	if colony.recordings[0].AgentId != "agent-1" {
+		t.Errorf("expected agent_id agent-1, got %s", colony.recordings[0].AgentId)
+	}
+	if queued := u.Queued(); queued != 0 {
+		t.Errorf("expected an empty queue, got %d", queued)
+	}
+}
@@ -0,0 +1,213 @@
+package recording
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/bandwidth"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// stopUploadTimeout bounds the final upload when the uploader stops.
+const stopUploadTimeout = 10 * time.Second
+
+// URLProvider returns the current colony base URL, or an empty string if the
+// colony is not yet reachable.
+type URLProvider func() string
+
+// Config holds configuration for the Uploader.
+type Config struct {
+	// AgentID is set on uploaded recordings (required).
+	AgentID string
+
+	// URLProvider returns the colony base URL on demand. Recordings stay
+	// queued while it returns "".
+	URLProvider URLProvider
+
+	// RetryInterval controls how often queued recordings are retried
+	// (default: constants.DefaultShellRecordingUploadInterval).
+	RetryInterval time.Duration
+
+	// QueueSize bounds the number of queued recordings
+	// (default: constants.DefaultShellRecordingQueueSize).
+	QueueSize int
+
+	// Logger is the zerolog logger for this component.
+	Logger zerolog.Logger
+}
+
+// Uploader uploads the recordings of ended sessions to the colony. Push
+// never blocks the session: recordings are queued, uploaded in the
+// background and retried while the colony is unreachable. When the queue is
+// full the oldest recording is dropped.
+type Uploader struct {
+	agentID       string
+	urlProvider   URLProvider
+	retryInterval time.Duration
+	queueSize     int
+	httpClient    *http.Client
+	logger        zerolog.Logger
+
+	mu    sync.Mutex
+	queue []*colonyv1.ShellRecording
+
+	// sendMu serializes uploads.
+	sendMu sync.Mutex
+	// pushed is signalled when a recording is queued.
+	pushed chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewUploader creates a new Uploader. Call Start to begin uploading.
+func NewUploader(cfg Config) *Uploader {
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = constants.DefaultShellRecordingUploadInterval
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = constants.DefaultShellRecordingQueueSize
+	}
+
+	return &Uploader{
+		agentID:       cfg.AgentID,
+		urlProvider:   cfg.URLProvider,
+		retryInterval: cfg.RetryInterval,
+		queueSize:     cfg.QueueSize,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: cfg.Logger.With().Str("component", "recording_uploader").Logger(),
+		pushed: make(chan struct{}, 1),
+	}
+}
+
+// Start begins the upload loop in a background goroutine.
+func (u *Uploader) Start() error {
+	u.ctx, u.cancel = context.WithCancel(context.Background())
+	u.wg.Add(1)
+	go u.run()
+	u.logger.Info().Msg("Shell recording upload started")
+	return nil
+}
+
+// Stop uploads the recordings still queued and stops the upload loop.
+func (u *Uploader) Stop() error {
+	if u.cancel != nil {
+		u.cancel()
+	}
+	u.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopUploadTimeout)
+	defer cancel()
+	if err := u.Flush(ctx); err != nil {
+		u.logger.Warn().Err(err).Msg("Failed to upload remaining shell recordings")
+	}
+
+	u.logger.Info().Msg("Shell recording upload stopped")
+	return nil
+}
+
+// Push queues the recording of an ended session. It is a Sink.
+func (u *Uploader) Push(rec *colonyv1.ShellRecording) {
+	rec.AgentId = u.agentID
+
+	u.mu.Lock()
+	u.queue = append(u.queue, rec)
+	if excess := len(u.queue) - u.queueSize; excess > 0 {
+		for _, dropped := range u.queue[:excess] {
+			u.logger.Warn().
+				Str("session_id", dropped.SessionId).
+				Msg("Shell recording queue full, dropping oldest recording")
+		}
+		u.queue = u.queue[excess:]
+	}
+	u.mu.Unlock()
+
+	select {
+	case u.pushed <- struct{}{}:
+	default:
+	}
+}
+
+// Queued returns the number of recordings waiting to be uploaded.
+func (u *Uploader) Queued() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return len(u.queue)
+}
+
+// Flush uploads the queued recordings in order. Recordings not uploaded
+// are queued again.
+func (u *Uploader) Flush(ctx context.Context) error {
+	u.sendMu.Lock()
+	defer u.sendMu.Unlock()
+
+	u.mu.Lock()
+	recordings := u.queue
+	u.queue = nil
+	u.mu.Unlock()
+
+	for i, rec := range recordings {
+		if err := u.upload(ctx, rec); err != nil {
+			u.mu.Lock()
+			u.queue = append(recordings[i:], u.queue...)
+			u.mu.Unlock()
+			return err
+		}
+	}
+	return nil
+}
+
+// run uploads recordings as they are pushed and retries failed uploads.
+func (u *Uploader) run() {
+	defer u.wg.Done()
+
+	ticker := time.NewTicker(u.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-u.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-u.pushed:
+		}
+
+		if err := u.Flush(u.ctx); err != nil && u.ctx.Err() == nil {
+			u.logger.Debug().Err(err).Msg("Failed to upload shell recordings to colony, will retry")
+		}
+	}
+}
+
+func (u *Uploader) upload(ctx context.Context, rec *colonyv1.ShellRecording) error {
+	colonyURL := ""
+	if u.urlProvider != nil {
+		colonyURL = u.urlProvider()
+	}
+	if colonyURL == "" {
+		return fmt.Errorf("colony URL not yet available")
+	}
+
+	client := colonyv1connect.NewColonyDebugServiceClient(u.httpClient, colonyURL, bandwidth.ClientOptions()...)
+	if _, err := client.UploadShellRecording(ctx, connect.NewRequest(&colonyv1.UploadShellRecordingRequest{
+		Recording: rec,
+	})); err != nil {
+		return fmt.Errorf("upload of session %s failed: %w", rec.SessionId, err)
+	}
+
+	u.logger.Debug().
+		Str("session_id", rec.SessionId).
+		Uint64("size_bytes", rec.SizeBytes).
+		Msg("Uploaded shell recording to colony")
+	return nil
+}
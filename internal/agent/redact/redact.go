@@ -0,0 +1,288 @@
+// Package redact masks credentials and personal data in what the agent
+// captures, uprobe arguments and return values, HTTP exchanges, TLS plaintext
+// and shell exec output, before it leaves the host.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+)
+
+// Mask replaces redacted values.
+const Mask = "[REDACTED]"
+
+// DefaultPatterns match values that look like credentials or personal data
+// wherever they appear.
+var DefaultPatterns = []string{
+	`(?i)\bbearer\s+[a-z0-9._~+/-]+=*`,                                           // Authorization header values
+	`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`,                          // JWTs, incl. capability tokens
+	`coral_[A-Za-z0-9_-]{43}`,                                                    // Coral API tokens
+	`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,                                              // AWS access key IDs
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,                                             // GitHub tokens
+	`\bxox[abprs]-[A-Za-z0-9-]{10,}`,                                             // Slack tokens
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`, // PEM private keys
+	`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`,                         // Email addresses
+}
+
+// DefaultFields are the field names whose values are masked. A name matches
+// when it contains a field, ignoring case, "_", "-" and ".": "password"
+// matches "db_password" and "DBPassword".
+var DefaultFields = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"accesskey",
+	"privatekey",
+	"authorization",
+	"credential",
+	"cookie",
+}
+
+// assignment matches "name=value", "name: value" and `"name": "value"` in
+// text, so that values of sensitive fields are masked in output too.
+var assignment = regexp.MustCompile(`([A-Za-z0-9_.-]+)(["']?\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s"',;&]+)`)
+
+// Rules are redaction rules added to the defaults.
+type Rules struct {
+	// Patterns are regular expressions whose matches are masked.
+	Patterns []string
+
+	// Fields are names whose values are masked.
+	Fields []string
+}
+
+// ServiceRules override the rules for the captures of one service.
+type ServiceRules struct {
+	// Disabled turns redaction off for the service.
+	Disabled bool
+
+	Rules
+}
+
+// Config configures the redaction engine.
+type Config struct {
+	// Disabled turns redaction off.
+	Disabled bool
+
+	// Rules are added to DefaultPatterns and DefaultFields.
+	Rules
+
+	// Services add rules for services, keyed by service name.
+	Services map[string]ServiceRules
+}
+
+// Engine redacts captured values. A nil Engine redacts nothing.
+type Engine struct {
+	global   *ruleSet
+	services map[string]*ruleSet // nil for services with redaction disabled.
+}
+
+// ruleSet is a compiled set of rules.
+type ruleSet struct {
+	patterns []*regexp.Regexp
+	fields   []string // Normalized.
+}
+
+// New creates a redaction engine. It returns nil if redaction is disabled.
+func New(cfg Config) (*Engine, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+
+	defaults := Rules{Patterns: DefaultPatterns, Fields: DefaultFields}
+	global, err := compile(defaults, cfg.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Engine{
+		global:   global,
+		services: make(map[string]*ruleSet, len(cfg.Services)),
+	}
+	for name, svc := range cfg.Services {
+		if svc.Disabled {
+			e.services[name] = nil
+			continue
+		}
+		rs, err := compile(defaults, cfg.Rules, svc.Rules)
+		if err != nil {
+			return nil, fmt.Errorf("service %q: %w", name, err)
+		}
+		e.services[name] = rs
+	}
+	return e, nil
+}
+
+// compile compiles the union of rules.
+func compile(rules ...Rules) (*ruleSet, error) {
+	rs := &ruleSet{}
+	for _, r := range rules {
+		for _, p := range r.Patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+			}
+			rs.patterns = append(rs.patterns, re)
+		}
+		for _, f := range r.Fields {
+			if f = normalize(f); f != "" {
+				rs.fields = append(rs.fields, f)
+			}
+		}
+	}
+	return rs, nil
+}
+
+// rules returns the rules of service, or nil if redaction is disabled for it.
+func (e *Engine) rules(service string) *ruleSet {
+	if e == nil {
+		return nil
+	}
+	if rs, ok := e.services[service]; ok {
+		return rs
+	}
+	return e.global
+}
+
+// String redacts s, captured from service ("" for captures not tied to a
+// service), and reports whether anything was masked.
+func (e *Engine) String(service, s string) (string, bool) {
+	rs := e.rules(service)
+	if rs == nil || s == "" {
+		return s, false
+	}
+	out := rs.redact(s)
+	return out, out != s
+}
+
+// Field redacts the value of the field name captured from service: the whole
+// value if the field is sensitive, otherwise what the patterns match.
+func (e *Engine) Field(service, name, value string) (string, bool) {
+	rs := e.rules(service)
+	if rs == nil || value == "" {
+		return value, false
+	}
+	if rs.sensitive(name) {
+		return Mask, value != Mask
+	}
+	out := rs.redact(value)
+	return out, out != value
+}
+
+// Bytes redacts output captured from service, e.g. shell exec output.
+func (e *Engine) Bytes(service string, b []byte) ([]byte, bool) {
+	if e.rules(service) == nil || len(b) == 0 {
+		return b, false
+	}
+	out, changed := e.String(service, string(b))
+	if !changed {
+		return b, false
+	}
+	return []byte(out), true
+}
+
+// UprobeEvent redacts the captured arguments, return value, HTTP exchange
+// and TLS plaintext of event in place and marks it redacted. It reports
+// whether anything was masked.
+func (e *Engine) UprobeEvent(event *agentv1.UprobeEvent) bool {
+	if e.rules(event.ServiceName) == nil {
+		return false
+	}
+
+	var redacted, changed bool
+	for _, arg := range event.Args {
+		arg.Value, changed = e.Field(event.ServiceName, arg.Name, arg.Value)
+		redacted = redacted || changed
+	}
+	if rv := event.ReturnValue; rv != nil {
+		rv.Value, changed = e.String(event.ServiceName, rv.Value)
+		redacted = redacted || changed
+		rv.ErrorMessage, changed = e.String(event.ServiceName, rv.ErrorMessage)
+		redacted = redacted || changed
+	}
+	if ex := event.Http; ex != nil {
+		for _, headers := range [][]*agentv1.HttpHeader{ex.RequestHeaders, ex.ResponseHeaders} {
+			for _, h := range headers {
+				h.Value, changed = e.Field(event.ServiceName, h.Name, h.Value)
+				redacted = redacted || changed
+			}
+		}
+		for _, s := range []*string{&ex.Query, &ex.RequestBody, &ex.ResponseBody} {
+			*s, changed = e.String(event.ServiceName, *s)
+			redacted = redacted || changed
+		}
+	}
+	if tls := event.Tls; tls != nil {
+		tls.Data, changed = e.Bytes(event.ServiceName, tls.Data)
+		redacted = redacted || changed
+	}
+
+	if redacted {
+		event.Redacted = true
+	}
+	return redacted
+}
+
+// redact masks pattern matches and the values of sensitive fields in s.
+func (rs *ruleSet) redact(s string) string {
+	for _, re := range rs.patterns {
+		s = re.ReplaceAllLiteralString(s, Mask)
+	}
+	if len(rs.fields) == 0 {
+		return s
+	}
+
+	matches := assignment.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		if !rs.sensitive(s[m[2]:m[3]]) {
+			continue
+		}
+		value := s[m[6]:m[7]]
+		b.WriteString(s[last:m[6]])
+		if q := value[0]; len(value) >= 2 && (q == '"' || q == '\'') {
+			b.WriteByte(q)
+			b.WriteString(Mask)
+			b.WriteByte(q)
+		} else {
+			b.WriteString(Mask)
+		}
+		last = m[7]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// sensitive reports whether name is a sensitive field.
+func (rs *ruleSet) sensitive(name string) bool {
+	name = normalize(name)
+	if name == "" {
+		return false
+	}
+	for _, f := range rs.fields {
+		if strings.Contains(name, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize lowercases name and drops separators.
+func normalize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '-', '.', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
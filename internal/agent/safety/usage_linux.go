@@ -0,0 +1,38 @@
+//go:build linux
+
+package safety
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// readProcessUsage returns the total CPU time consumed by the agent and its
+// resident set size.
+func readProcessUsage() (time.Duration, uint64, error) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, 0, fmt.Errorf("getrusage: %w", err)
+	}
+	cpu := time.Duration(ru.Utime.Nano() + ru.Stime.Nano())
+
+	// /proc/self/statm: size resident shared text lib data dt (in pages).
+	data, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read /proc/self/statm: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return 0, 0, fmt.Errorf("malformed /proc/self/statm")
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse resident pages: %w", err)
+	}
+
+	return cpu, pages * uint64(os.Getpagesize()), nil // #nosec G115 - page size is positive.
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package safety
+
+import (
+	"runtime"
+	"time"
+)
+
+// readProcessUsage approximates memory usage with the Go runtime's view of
+// memory obtained from the OS. CPU usage is only measured on Linux.
+func readProcessUsage() (time.Duration, uint64, error) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return 0, ms.Sys, nil
+}
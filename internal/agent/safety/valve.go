@@ -0,0 +1,280 @@
+// Package safety keeps the agent within its CPU and memory self-limits by
+// shedding load in priority order when usage nears a limit.
+package safety
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Stage names used by the agent, in shedding order.
+const (
+	StageContinuousProfiling = "continuous_profiling"
+	StageUprobeSampling      = "uprobe_sampling"
+)
+
+// Usage is a sample of the agent's own resource consumption.
+type Usage struct {
+	CPUPercent  float64 // Percent of one core since the previous sample.
+	MemoryBytes uint64  // Resident set size.
+}
+
+// Stage is a unit of load that can be shed and later restored.
+type Stage struct {
+	Name    string
+	Shed    func()
+	Restore func()
+}
+
+// Config contains safety valve configuration.
+type Config struct {
+	MaxCPUPercent  float64 // 0 = unlimited.
+	MaxMemoryBytes uint64  // 0 = unlimited.
+	CheckInterval  time.Duration
+
+	// HighWatermark and LowWatermark are fractions of a limit. One more
+	// stage is shed per check at or above HighWatermark, and the last shed
+	// stage is restored per check below LowWatermark.
+	HighWatermark float64
+	LowWatermark  float64
+
+	Logger zerolog.Logger
+}
+
+// Enabled reports whether any limit is configured.
+func (c Config) Enabled() bool {
+	return c.MaxCPUPercent > 0 || c.MaxMemoryBytes > 0
+}
+
+// State is the current shedding state.
+type State struct {
+	Stages []string // Shed stages, in the order they were shed.
+	Reason string   // Reason for the last transition.
+	Usage  Usage    // Usage at the last check.
+	Since  time.Time
+}
+
+// Active reports whether any stage is shed.
+func (s State) Active() bool {
+	return len(s.Stages) > 0
+}
+
+// Valve periodically samples the agent's resource usage and sheds stages in
+// order while usage is near a limit, restoring them in reverse order once
+// usage drops. The gap between the watermarks prevents flapping.
+type Valve struct {
+	config   Config
+	stages   []Stage
+	logger   zerolog.Logger
+	onChange func(State)
+
+	// sample reads current usage, overridable for tests.
+	sample func() (Usage, error)
+
+	mu    sync.Mutex
+	state State
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewValve creates a safety valve shedding stages in the given order.
+func NewValve(config Config, stages []Stage) *Valve {
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = constants.DefaultResourceCheckInterval
+	}
+	if config.HighWatermark <= 0 {
+		config.HighWatermark = constants.DefaultResourceHighWatermark
+	}
+	if config.LowWatermark <= 0 {
+		config.LowWatermark = constants.DefaultResourceLowWatermark
+	}
+
+	return &Valve{
+		config: config,
+		stages: stages,
+		logger: config.Logger.With().Str("component", "safety_valve").Logger(),
+		sample: (&processSampler{}).sample,
+		state:  State{Since: time.Now()},
+	}
+}
+
+// OnChange registers a callback invoked after every shedding transition.
+// Must be called before Start.
+func (v *Valve) OnChange(fn func(State)) {
+	v.onChange = fn
+}
+
+// State returns the current shedding state.
+func (v *Valve) State() State {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	state := v.state
+	state.Stages = append([]string(nil), v.state.Stages...)
+	return state
+}
+
+// Status returns the current shedding state in its wire format.
+func (v *Valve) Status() *agentv1.ResourceShedding {
+	state := v.State()
+	return &agentv1.ResourceShedding{
+		Active:           state.Active(),
+		Stages:           state.Stages,
+		Reason:           state.Reason,
+		CpuPercent:       state.Usage.CPUPercent,
+		MemoryBytes:      state.Usage.MemoryBytes,
+		CpuLimitPercent:  v.config.MaxCPUPercent,
+		MemoryLimitBytes: v.config.MaxMemoryBytes,
+		Since:            timestamppb.New(state.Since),
+	}
+}
+
+// Start begins periodic usage checks.
+func (v *Valve) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	v.cancel = cancel
+	v.done = make(chan struct{})
+
+	go v.run(ctx)
+
+	v.logger.Info().
+		Float64("max_cpu_percent", v.config.MaxCPUPercent).
+		Uint64("max_memory_bytes", v.config.MaxMemoryBytes).
+		Dur("check_interval", v.config.CheckInterval).
+		Msg("Started resource safety valve")
+
+	return nil
+}
+
+// Stop stops usage checks. Shed stages are not restored.
+func (v *Valve) Stop() error {
+	if v.cancel != nil {
+		v.cancel()
+		<-v.done
+	}
+	return nil
+}
+
+// run checks usage on every interval.
+func (v *Valve) run(ctx context.Context) {
+	defer close(v.done)
+
+	ticker := time.NewTicker(v.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			v.Check(now)
+		}
+	}
+}
+
+// Check samples usage once and sheds or restores at most one stage.
+func (v *Valve) Check(now time.Time) {
+	usage, err := v.sample()
+	if err != nil {
+		v.logger.Debug().Err(err).Msg("Failed to sample agent resource usage")
+		return
+	}
+
+	pressure, reason := v.pressure(usage)
+
+	v.mu.Lock()
+	v.state.Usage = usage
+	level := len(v.state.Stages)
+
+	changed := false
+	switch {
+	case pressure >= v.config.HighWatermark && level < len(v.stages):
+		stage := v.stages[level]
+		stage.Shed()
+		v.state.Stages = append(v.state.Stages, stage.Name)
+		v.state.Reason = fmt.Sprintf("%s, shed %s", reason, stage.Name)
+		changed = true
+
+		v.logger.Warn().
+			Str("stage", stage.Name).
+			Str("reason", reason).
+			Msg("Agent near resource limit, shedding load")
+
+	case pressure < v.config.LowWatermark && level > 0:
+		stage := v.stages[level-1]
+		stage.Restore()
+		v.state.Stages = v.state.Stages[:level-1]
+		v.state.Reason = fmt.Sprintf("%s, restored %s", reason, stage.Name)
+		changed = true
+
+		v.logger.Info().
+			Str("stage", stage.Name).
+			Str("reason", reason).
+			Msg("Agent resource usage recovered, restoring load")
+	}
+
+	if changed {
+		v.state.Since = now
+	}
+	v.mu.Unlock()
+
+	if changed && v.onChange != nil {
+		v.onChange(v.State())
+	}
+}
+
+// pressure returns the highest usage-to-limit ratio and describes it.
+func (v *Valve) pressure(usage Usage) (float64, string) {
+	var (
+		pressure float64
+		reason   string
+	)
+
+	if v.config.MaxCPUPercent > 0 {
+		pressure = usage.CPUPercent / v.config.MaxCPUPercent
+		reason = fmt.Sprintf("cpu %.1f%% of %.1f%% limit", usage.CPUPercent, v.config.MaxCPUPercent)
+	}
+
+	if v.config.MaxMemoryBytes > 0 {
+		memPressure := float64(usage.MemoryBytes) / float64(v.config.MaxMemoryBytes)
+		if reason == "" || memPressure > pressure {
+			pressure = memPressure
+			reason = fmt.Sprintf("memory %dMB of %dMB limit", usage.MemoryBytes>>20, v.config.MaxMemoryBytes>>20)
+		}
+	}
+
+	return pressure, reason
+}
+
+// processSampler measures the agent's CPU usage between consecutive samples.
+type processSampler struct {
+	lastCPU  time.Duration
+	lastWall time.Time
+}
+
+func (s *processSampler) sample() (Usage, error) {
+	cpu, rss, err := readProcessUsage()
+	if err != nil {
+		return Usage{}, err
+	}
+
+	now := time.Now()
+	usage := Usage{MemoryBytes: rss}
+	if !s.lastWall.IsZero() {
+		if wall := now.Sub(s.lastWall); wall > 0 {
+			usage.CPUPercent = float64(cpu-s.lastCPU) / float64(wall) * 100
+		}
+	}
+	s.lastCPU, s.lastWall = cpu, now
+
+	return usage, nil
+}
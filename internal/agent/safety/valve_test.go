@@ -0,0 +1,107 @@
+package safety
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingStage returns a stage that records shed/restore calls in log.
+func recordingStage(name string, log *[]string) Stage {
+	return Stage{
+		Name:    name,
+		Shed:    func() { *log = append(*log, "shed:"+name) },
+		Restore: func() { *log = append(*log, "restore:"+name) },
+	}
+}
+
+func newTestValve(usage *Usage, log *[]string) *Valve {
+	v := NewValve(Config{
+		MaxCPUPercent:  50,
+		MaxMemoryBytes: 100 << 20,
+		HighWatermark:  0.9,
+		LowWatermark:   0.7,
+		Logger:         zerolog.Nop(),
+	}, []Stage{
+		recordingStage(StageContinuousProfiling, log),
+		recordingStage(StageUprobeSampling, log),
+	})
+	v.sample = func() (Usage, error) { return *usage, nil }
+	return v
+}
+
+func TestValve_ShedsInOrderAndRestoresInReverse(t *testing.T) {
+	var log []string
+	usage := &Usage{CPUPercent: 10, MemoryBytes: 10 << 20}
+	v := newTestValve(usage, &log)
+
+	var transitions []State
+	v.OnChange(func(s State) { transitions = append(transitions, s) })
+
+	now := time.Now()
+
+	// Below limits: nothing happens.
+	v.Check(now)
+	assert.Empty(t, log)
+	assert.False(t, v.State().Active())
+
+	// Memory at 95% of the limit: one stage per check.
+	usage.MemoryBytes = 95 << 20
+	v.Check(now.Add(time.Second))
+	assert.Equal(t, []string{StageContinuousProfiling}, v.State().Stages)
+	v.Check(now.Add(2 * time.Second))
+	assert.Equal(t, []string{StageContinuousProfiling, StageUprobeSampling}, v.State().Stages)
+
+	// Nothing left to shed.
+	v.Check(now.Add(3 * time.Second))
+	assert.Len(t, log, 2)
+
+	// Between the watermarks: hold the current state.
+	usage.MemoryBytes = 80 << 20
+	v.Check(now.Add(4 * time.Second))
+	assert.Len(t, v.State().Stages, 2)
+
+	// Recovered: restore in reverse order.
+	usage.MemoryBytes = 20 << 20
+	v.Check(now.Add(5 * time.Second))
+	v.Check(now.Add(6 * time.Second))
+
+	assert.Equal(t, []string{
+		"shed:" + StageContinuousProfiling,
+		"shed:" + StageUprobeSampling,
+		"restore:" + StageUprobeSampling,
+		"restore:" + StageContinuousProfiling,
+	}, log)
+	assert.False(t, v.State().Active())
+	require.Len(t, transitions, 4)
+	assert.Contains(t, transitions[0].Reason, "memory 95MB of 100MB limit")
+}
+
+func TestValve_CPUPressure(t *testing.T) {
+	var log []string
+	usage := &Usage{CPUPercent: 48, MemoryBytes: 10 << 20}
+	v := newTestValve(usage, &log)
+
+	v.Check(time.Now())
+
+	state := v.State()
+	assert.Equal(t, []string{StageContinuousProfiling}, state.Stages)
+	assert.Contains(t, state.Reason, "cpu 48.0% of 50.0% limit")
+
+	status := v.Status()
+	assert.True(t, status.Active)
+	assert.Equal(t, float64(50), status.CpuLimitPercent)
+	assert.Equal(t, uint64(100<<20), status.MemoryLimitBytes)
+}
+
+func TestProcessSampler(t *testing.T) {
+	s := &processSampler{}
+
+	usage, err := s.sample()
+	require.NoError(t, err)
+	assert.NotZero(t, usage.MemoryBytes)
+	assert.Zero(t, usage.CPUPercent, "first sample has no CPU baseline")
+}
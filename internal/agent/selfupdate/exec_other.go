@@ -0,0 +1,9 @@
+//go:build !unix
+
+package selfupdate
+
+// Exec returns ErrRestartUnsupported: the agent must be restarted by its
+// service manager to run the new executable.
+func Exec(string) error {
+	return ErrRestartUnsupported
+}
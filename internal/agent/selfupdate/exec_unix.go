@@ -0,0 +1,15 @@
+//go:build unix
+
+package selfupdate
+
+import (
+	"os"
+	"syscall"
+)
+
+// Exec replaces the running process with executable, with the same
+// arguments and environment. It only returns on failure.
+func Exec(executable string) error {
+	//nolint:gosec // G204: The executable is the agent's own, just verified.
+	return syscall.Exec(executable, os.Args, os.Environ())
+}
@@ -0,0 +1,155 @@
+package selfupdate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// An installed release runs on trial until the agent running it heartbeats
+// the colony. The executable it replaced is kept next to it, so that a
+// release that fails to start is rolled back: if the agent starts again with
+// the release still on trial, e.g. restarted by its service manager after
+// crashing, it restores the previous executable instead.
+const (
+	trialSuffix    = ".update-trial"
+	previousSuffix = ".previous"
+	rejectedSuffix = ".update-rejected"
+)
+
+// trial is the state of the release on trial, stored next to the executable.
+type trial struct {
+	Version string `json:"version"`
+
+	// Starts counts the starts of the release.
+	Starts int `json:"starts"`
+}
+
+// CurrentExecutable returns the path of the running agent executable, with
+// symbolic links resolved.
+func CurrentExecutable() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the agent executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+	return executable, nil
+}
+
+// BeginTrial counts a start of the agent executable if it is a release on
+// trial. If the release already started once without reaching the colony, it
+// restores the previous executable and returns true: the caller must then run
+// the executable again (see Exec).
+func BeginTrial(executable string) (rolledBack bool, err error) {
+	data, err := os.ReadFile(executable + trialSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read the release trial: %w", err)
+	}
+	var t trial
+	if err := json.Unmarshal(data, &t); err != nil {
+		return false, fmt.Errorf("invalid release trial: %w", err)
+	}
+
+	if t.Starts > 0 {
+		if err := Rollback(executable); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	t.Starts++
+	return false, writeTrial(executable, t)
+}
+
+// Rollback restores the executable replaced by the release on trial and
+// rejects the release, so that it is not installed again.
+func Rollback(executable string) error {
+	data, err := os.ReadFile(executable + trialSuffix)
+	if err != nil {
+		return fmt.Errorf("no release to roll back: %w", err)
+	}
+	var t trial
+	_ = json.Unmarshal(data, &t)
+
+	if err := os.Rename(executable+previousSuffix, executable); err != nil {
+		return fmt.Errorf("failed to restore the previous agent executable: %w", err)
+	}
+	if t.Version != "" {
+		_ = os.WriteFile(executable+rejectedSuffix, []byte(t.Version), 0600) // #nosec G306
+	}
+	_ = os.Remove(executable + trialSuffix)
+	return nil
+}
+
+// endTrial accepts the release on trial, if any, removing the previous
+// executable.
+func endTrial(executable string) error {
+	if err := os.Remove(executable + trialSuffix); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return os.Remove(executable + previousSuffix)
+}
+
+// rejectedRelease returns the version of the release rolled back last, or "".
+func rejectedRelease(executable string) string {
+	data, err := os.ReadFile(executable + rejectedSuffix) // #nosec G304 -- next to the agent executable
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// keepPrevious saves the executable about to be replaced by the release
+// version and puts the release on trial.
+func keepPrevious(executable, version string) error {
+	previous := executable + previousSuffix
+	_ = os.Remove(previous)
+	if err := os.Link(executable, previous); err != nil {
+		if err := copyFile(executable, previous); err != nil {
+			return fmt.Errorf("failed to keep the previous agent executable: %w", err)
+		}
+	}
+	return writeTrial(executable, trial{Version: version})
+}
+
+func writeTrial(executable string, t trial) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(executable+trialSuffix, data, 0600); err != nil {
+		return fmt.Errorf("failed to write the release trial: %w", err)
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src) // #nosec G304 -- the agent executable
+	if err != nil {
+		return err
+	}
+	defer in.Close() // nolint:errcheck
+
+	//nolint:gosec // G302: The agent executable must be executable.
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
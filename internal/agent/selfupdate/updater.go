@@ -0,0 +1,297 @@
+// Package selfupdate installs the agent releases a colony rolls out with
+// `coral colony agents upgrade`.
+//
+// The colony advertises the release in its heartbeat responses, signed with
+// its policy signing key. An agent with updates enabled verifies the
+// signature against the colony's root CA, downloads the executable for its
+// platform, checks its SHA-256, replaces its own executable and restarts,
+// all within its maintenance window. Agents only install releases newer than
+// their own that are rolled out to them, and roll a release back if it fails
+// to start (see BeginTrial).
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+const (
+	// retryInterval is how long the agent waits before installing a release
+	// again after it failed to.
+	retryInterval = 30 * time.Minute
+
+	// maxExecutableSize bounds the size of downloaded executables.
+	maxExecutableSize = 512 << 20
+
+	// downloadTimeout bounds the download of an executable.
+	downloadTimeout = 10 * time.Minute
+)
+
+// Config configures an Updater.
+type Config struct {
+	// Version is the version of the running agent.
+	Version string
+
+	// AgentID is the ID of the agent; releases rolled out to other agents are
+	// refused.
+	AgentID string
+
+	// Executable is the path of the agent executable to replace.
+	Executable string
+
+	// Verifier verifies release tokens against the colony's root CA. Without
+	// one, no release is installed.
+	Verifier *auth.CapabilityVerifier
+
+	// Window is the maintenance window releases are installed in.
+	Window Window
+
+	// HTTPClient downloads executables (default: a client with a 10 minute
+	// timeout).
+	HTTPClient *http.Client
+
+	Logger logging.Logger
+}
+
+// Updater installs the agent releases advertised by the colony.
+type Updater struct {
+	cfg Config
+	now func() time.Time
+
+	mu            sync.Mutex
+	installing    bool
+	installed     bool
+	failedVersion string
+	failedAt      time.Time
+	lastErr       string
+	waiting       string // Version waiting for the maintenance window.
+	rejected      string // Version rolled back as it failed to start.
+	confirmed     bool
+	restart       chan struct{}
+}
+
+// New creates an updater.
+func New(cfg Config) *Updater {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: downloadTimeout}
+	}
+	u := &Updater{
+		cfg:     cfg,
+		now:     time.Now,
+		restart: make(chan struct{}),
+	}
+	if rejected := rejectedRelease(cfg.Executable); rejected != "" {
+		u.rejected = rejected
+		u.lastErr = fmt.Sprintf("release %s failed to start and was rolled back", rejected)
+	}
+	return u
+}
+
+// Version returns the version of the running agent.
+func (u *Updater) Version() string {
+	return u.cfg.Version
+}
+
+// LastError returns why the agent failed to install the advertised release,
+// or "" if it did not.
+func (u *Updater) LastError() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.lastErr
+}
+
+// Restart is closed once a release is installed: the agent must then stop
+// and run the new executable (see Exec).
+func (u *Updater) Restart() <-chan struct{} {
+	if u == nil {
+		return nil
+	}
+	return u.restart
+}
+
+// Executable returns the path of the agent executable.
+func (u *Updater) Executable() string {
+	return u.cfg.Executable
+}
+
+// Confirm accepts the running release once the agent reached the colony, so
+// that it is not rolled back on the next start.
+func (u *Updater) Confirm() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.confirmed {
+		return
+	}
+	u.confirmed = true
+	if err := endTrial(u.cfg.Executable); err != nil {
+		u.cfg.Logger.Warn().Err(err).Msg("Failed to accept the installed agent release")
+	}
+}
+
+// Offer handles the release advertised in a heartbeat response. Within the
+// maintenance window, it installs the release in the background, unless the
+// agent runs it already or failed to install it recently. update may be nil.
+func (u *Updater) Offer(ctx context.Context, update *meshv1.AgentUpdate) {
+	if update == nil || update.Version == "" || update.Version == u.cfg.Version {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if update.Version == u.rejected {
+		return
+	}
+	if u.installing || u.installed {
+		return
+	}
+	now := u.now()
+	if update.Version == u.failedVersion && now.Sub(u.failedAt) < retryInterval {
+		return
+	}
+	if !u.cfg.Window.Contains(now) {
+		if u.waiting != update.Version {
+			u.waiting = update.Version
+			u.cfg.Logger.Info().
+				Str("version", update.Version).
+				Str("maintenance_window", u.cfg.Window.String()).
+				Msg("Agent release advertised by the colony - waiting for the maintenance window")
+		}
+		return
+	}
+
+	u.installing = true
+	go func() {
+		err := u.install(ctx, update.Token)
+
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		u.installing = false
+		if err != nil {
+			u.failedVersion, u.failedAt, u.lastErr = update.Version, u.now(), err.Error()
+			u.cfg.Logger.Error().
+				Err(err).
+				Str("version", update.Version).
+				Msg("Failed to install agent release")
+			return
+		}
+		u.installed, u.lastErr = true, ""
+		close(u.restart)
+	}()
+}
+
+// install verifies the release token, then downloads the executable for
+// this platform and replaces the agent executable with it.
+func (u *Updater) install(ctx context.Context, token string) error {
+	if u.cfg.Verifier == nil {
+		return fmt.Errorf("no root CA to verify the release with")
+	}
+	release, err := u.cfg.Verifier.VerifyAgentRelease(token)
+	if err != nil {
+		return err
+	}
+	if !release.Targets(u.cfg.AgentID) {
+		return fmt.Errorf("release %s is not rolled out to agent %s", release.Version, u.cfg.AgentID)
+	}
+	if !auth.IsAgentUpgrade(u.cfg.Version, release.Version) {
+		return fmt.Errorf("release %s is not newer than the running version %s", release.Version, u.cfg.Version)
+	}
+	artifact := release.Artifact(runtime.GOOS, runtime.GOARCH)
+	if artifact == nil {
+		return fmt.Errorf("release %s has no executable for %s/%s", release.Version, runtime.GOOS, runtime.GOARCH)
+	}
+
+	u.cfg.Logger.Info().
+		Str("version", release.Version).
+		Str("url", artifact.URL).
+		Msg("Installing agent release")
+
+	if err := u.replaceExecutable(ctx, release.Version, artifact); err != nil {
+		return err
+	}
+
+	u.cfg.Logger.Info().
+		Str("version", release.Version).
+		Str("executable", u.cfg.Executable).
+		Msg("Agent release installed - restarting")
+	return nil
+}
+
+// replaceExecutable downloads the artifact next to the agent executable and
+// renames it over the executable once its checksum matches, keeping the
+// previous executable to roll back to.
+func (u *Updater) replaceExecutable(ctx context.Context, version string, artifact *auth.AgentArtifact) error {
+	ctx, cancel := context.WithTimeout(ctx, downloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, artifact.URL, nil)
+	if err != nil {
+		return fmt.Errorf("invalid download URL: %w", err)
+	}
+	resp, err := u.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download release: %w", err)
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download release: %s", resp.Status)
+	}
+
+	// Download next to the executable, so that it is replaced atomically.
+	dir := filepath.Dir(u.cfg.Executable)
+	tmp, err := os.CreateTemp(dir, ".coral-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to create download file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // nolint:errcheck
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(resp.Body, maxExecutableSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download release: %w", err)
+	}
+	if n > maxExecutableSize {
+		return fmt.Errorf("release executable exceeds %d MiB", maxExecutableSize>>20)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if subtle.ConstantTimeCompare([]byte(sum), []byte(strings.ToLower(artifact.SHA256))) != 1 {
+		return fmt.Errorf("checksum mismatch: got sha256 %s, want %s", sum, artifact.SHA256)
+	}
+
+	//nolint:gosec // G302: The agent executable must be executable.
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return fmt.Errorf("failed to make release executable: %w", err)
+	}
+	if err := keepPrevious(u.cfg.Executable, version); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), u.cfg.Executable); err != nil {
+		_ = endTrial(u.cfg.Executable)
+		return fmt.Errorf("failed to replace %s: %w", u.cfg.Executable, err)
+	}
+	return nil
+}
+
+// ErrRestartUnsupported is returned by Exec on platforms where a process
+// cannot replace itself.
+var ErrRestartUnsupported = errors.New("restarting in place is not supported on this platform")
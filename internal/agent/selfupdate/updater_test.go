@@ -0,0 +1,343 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+// testSigner signs agent releases like a colony: with a policy signing
+// certificate issued by its root CA.
+type testSigner struct {
+	roots *x509.CertPool
+	cert  *x509.Certificate
+	key   *ecdsa.PrivateKey
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	t.Helper()
+	newCert := func(isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber:          big.NewInt(time.Now().UnixNano()),
+			Subject:               pkix.Name{CommonName: "test"},
+			NotBefore:             time.Now().Add(-time.Minute),
+			NotAfter:              time.Now().Add(time.Hour),
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+		}
+		if !isCA {
+			template.KeyUsage = x509.KeyUsageDigitalSignature
+		}
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert, key
+	}
+
+	root, rootKey := newCert(true, nil, nil)
+	cert, key := newCert(false, root, rootKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	return &testSigner{roots: roots, cert: cert, key: key}
+}
+
+// update returns the heartbeat advertisement of a release of version
+// downloaded from url, with checksum sum, rolled out to agentIDs or all
+// agents.
+func (s *testSigner) update(t *testing.T, version, url, sum string, agentIDs ...string) *meshv1.AgentUpdate {
+	t.Helper()
+	token, err := auth.IssueAgentReleaseToken(&auth.AgentRelease{
+		ColonyID: "colony-1",
+		Version:  version,
+		Artifacts: []auth.AgentArtifact{{
+			OS:     runtime.GOOS,
+			Arch:   runtime.GOARCH,
+			URL:    url,
+			SHA256: sum,
+		}},
+		AgentIDs:  agentIDs,
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, s.cert, s.key)
+	require.NoError(t, err)
+	return &meshv1.AgentUpdate{Version: version, Token: token}
+}
+
+func newTestUpdater(t *testing.T, signer *testSigner, window Window) (*Updater, string) {
+	t.Helper()
+	executable := filepath.Join(t.TempDir(), "coral-agent")
+	require.NoError(t, os.WriteFile(executable, []byte("v0.4.0"), 0755))
+	return New(Config{
+		Version:    "v0.4.0",
+		AgentID:    "agent-1",
+		Executable: executable,
+		Verifier:   auth.NewCapabilityVerifier(signer.roots, "colony-1"),
+		Window:     window,
+		Logger:     logging.NewWithComponent(logging.Config{Level: "error"}, "selfupdate-test"),
+	}), executable
+}
+
+func serveRelease(t *testing.T, content string) (url, sum string) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/coral-agent" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	t.Cleanup(srv.Close)
+	hash := sha256.Sum256([]byte(content))
+	return srv.URL + "/coral-agent", hex.EncodeToString(hash[:])
+}
+
+func TestUpdater_Offer(t *testing.T) {
+	signer := newTestSigner(t)
+	updater, executable := newTestUpdater(t, signer, Window{})
+	url, sum := serveRelease(t, "v0.5.0")
+
+	updater.Offer(context.Background(), nil)
+	updater.Offer(context.Background(), signer.update(t, "v0.4.0", url, sum))
+	select {
+	case <-updater.Restart():
+		t.Fatal("the running version was installed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	updater.Offer(context.Background(), signer.update(t, "v0.5.0", url, sum))
+	select {
+	case <-updater.Restart():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("release was not installed: %s", updater.LastError())
+	}
+
+	data, err := os.ReadFile(executable)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.5.0", string(data))
+	info, err := os.Stat(executable)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	assert.Empty(t, updater.LastError())
+}
+
+func TestUpdater_Offer_Rejected(t *testing.T) {
+	signer := newTestSigner(t)
+	url, sum := serveRelease(t, "v0.5.0")
+
+	tests := map[string]struct {
+		update func(t *testing.T) *meshv1.AgentUpdate
+		err    string
+	}{
+		"checksum mismatch": {
+			update: func(t *testing.T) *meshv1.AgentUpdate {
+				return signer.update(t, "v0.5.0", url, hex.EncodeToString(make([]byte, 32)))
+			},
+			err: "checksum mismatch",
+		},
+		"untrusted signer": {
+			update: func(t *testing.T) *meshv1.AgentUpdate {
+				return newTestSigner(t).update(t, "v0.5.0", url, sum)
+			},
+			err: "invalid agent release token",
+		},
+		"older release": {
+			update: func(t *testing.T) *meshv1.AgentUpdate {
+				return signer.update(t, "v0.3.0", url, sum)
+			},
+			err: "not newer than the running version",
+		},
+		"other agents": {
+			update: func(t *testing.T) *meshv1.AgentUpdate {
+				return signer.update(t, "v0.5.0", url, sum, "agent-2")
+			},
+			err: "not rolled out to agent agent-1",
+		},
+		"download failure": {
+			update: func(t *testing.T) *meshv1.AgentUpdate {
+				return signer.update(t, "v0.5.0", url+"-missing", sum)
+			},
+			err: "failed to download release",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			updater, executable := newTestUpdater(t, signer, Window{})
+			update := tt.update(t)
+
+			updater.Offer(context.Background(), update)
+			require.Eventually(t, func() bool { return updater.LastError() != "" }, 5*time.Second, 10*time.Millisecond)
+			assert.Contains(t, updater.LastError(), tt.err)
+
+			data, err := os.ReadFile(executable)
+			require.NoError(t, err)
+			assert.Equal(t, "v0.4.0", string(data), "the executable is unchanged")
+			entries, err := os.ReadDir(filepath.Dir(executable))
+			require.NoError(t, err)
+			assert.Len(t, entries, 1, "the download is removed")
+
+			// The release is not installed again before the retry interval.
+			updater.Offer(context.Background(), update)
+			updater.mu.Lock()
+			installing := updater.installing
+			updater.mu.Unlock()
+			assert.False(t, installing)
+		})
+	}
+}
+
+func TestUpdater_Trial(t *testing.T) {
+	signer := newTestSigner(t)
+	url, sum := serveRelease(t, "v0.5.0")
+
+	install := func(t *testing.T) (*Updater, string) {
+		t.Helper()
+		updater, executable := newTestUpdater(t, signer, Window{})
+		updater.Offer(context.Background(), signer.update(t, "v0.5.0", url, sum, "agent-1"))
+		select {
+		case <-updater.Restart():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("release was not installed: %s", updater.LastError())
+		}
+		return updater, executable
+	}
+	readFile := func(t *testing.T, path string) string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("confirmed", func(t *testing.T) {
+		_, executable := install(t)
+		assert.Equal(t, "v0.4.0", readFile(t, executable+previousSuffix))
+
+		rolledBack, err := BeginTrial(executable)
+		require.NoError(t, err)
+		assert.False(t, rolledBack, "the release starts for the first time")
+
+		// The release reached the colony.
+		New(Config{Version: "v0.5.0", Executable: executable}).Confirm()
+		assert.NoFileExists(t, executable+trialSuffix)
+		assert.NoFileExists(t, executable+previousSuffix)
+
+		rolledBack, err = BeginTrial(executable)
+		require.NoError(t, err)
+		assert.False(t, rolledBack)
+		assert.Equal(t, "v0.5.0", readFile(t, executable))
+	})
+
+	t.Run("failed to start", func(t *testing.T) {
+		_, executable := install(t)
+
+		rolledBack, err := BeginTrial(executable)
+		require.NoError(t, err)
+		require.False(t, rolledBack)
+
+		// The release crashed before reaching the colony and was restarted.
+		rolledBack, err = BeginTrial(executable)
+		require.NoError(t, err)
+		assert.True(t, rolledBack)
+		assert.Equal(t, "v0.4.0", readFile(t, executable))
+		assert.NoFileExists(t, executable+trialSuffix)
+
+		// The previous executable reports the release and does not install it
+		// again.
+		updater := New(Config{
+			Version:    "v0.4.0",
+			AgentID:    "agent-1",
+			Executable: executable,
+			Verifier:   auth.NewCapabilityVerifier(signer.roots, "colony-1"),
+			Logger:     logging.NewWithComponent(logging.Config{Level: "error"}, "selfupdate-test"),
+		})
+		assert.Contains(t, updater.LastError(), "release v0.5.0 failed to start")
+		updater.Offer(context.Background(), signer.update(t, "v0.5.0", url, sum))
+		updater.mu.Lock()
+		installing := updater.installing
+		updater.mu.Unlock()
+		assert.False(t, installing)
+	})
+}
+
+func TestUpdater_Offer_MaintenanceWindow(t *testing.T) {
+	signer := newTestSigner(t)
+	url, sum := serveRelease(t, "v0.5.0")
+
+	window, err := ParseWindow("02:00-04:00")
+	require.NoError(t, err)
+	updater, executable := newTestUpdater(t, signer, window)
+	updater.now = func() time.Time { return time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) }
+
+	updater.Offer(context.Background(), signer.update(t, "v0.5.0", url, sum))
+	select {
+	case <-updater.Restart():
+		t.Fatal("release was installed outside of the maintenance window")
+	case <-time.After(50 * time.Millisecond):
+	}
+	data, err := os.ReadFile(executable)
+	require.NoError(t, err)
+	assert.Equal(t, "v0.4.0", string(data))
+
+	updater.now = func() time.Time { return time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC) }
+	updater.Offer(context.Background(), signer.update(t, "v0.5.0", url, sum))
+	select {
+	case <-updater.Restart():
+	case <-time.After(5 * time.Second):
+		t.Fatalf("release was not installed: %s", updater.LastError())
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	w, err := ParseWindow("02:00-04:30")
+	require.NoError(t, err)
+	assert.Equal(t, "02:00-04:30", w.String())
+	assert.False(t, w.Contains(at(1, 59)))
+	assert.True(t, w.Contains(at(2, 0)))
+	assert.True(t, w.Contains(at(4, 29)))
+	assert.False(t, w.Contains(at(4, 30)))
+
+	w, err = ParseWindow("23:00-01:00")
+	require.NoError(t, err)
+	assert.True(t, w.Contains(at(23, 30)))
+	assert.True(t, w.Contains(at(0, 30)))
+	assert.False(t, w.Contains(at(12, 0)))
+
+	w, err = ParseWindow("")
+	require.NoError(t, err)
+	assert.True(t, w.Contains(at(12, 0)), "the zero window allows any time")
+
+	for _, invalid := range []string{"02:00", "2am-4am", "02:00-25:00", "03:00-03:00"} {
+		_, err := ParseWindow(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
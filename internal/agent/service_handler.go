@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"connectrpc.com/connect"
@@ -12,6 +13,7 @@ import (
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/agent/beyla"
 	"github.com/coral-mesh/coral/internal/constants"
 	"github.com/coral-mesh/coral/internal/wireguard"
 )
@@ -26,6 +28,7 @@ type ServiceHandler struct {
 	telemetryReceiver    *TelemetryReceiver
 	shellHandler         *ShellHandler
 	containerHandler     *ContainerHandler
+	fileHandler          *FileHandler
 	functionCache        *FunctionCache
 	systemMetricsHandler *SystemMetricsHandler
 	sessionID            string // Database session UUID for checkpoint tracking (RFD 089).
@@ -50,6 +53,12 @@ func (h *ServiceHandler) SetSessionID(sessionID string) {
 	h.sessionID = sessionID
 }
 
+// SetFileHandler sets the handler of the file transfer RPCs. Without one,
+// they are unimplemented.
+func (h *ServiceHandler) SetFileHandler(fileHandler *FileHandler) {
+	h.fileHandler = fileHandler
+}
+
 // SetMeshInfoProvider sets the callback used for providing mesh metrics dynamically in GetRuntimeContext.
 func (h *ServiceHandler) SetMeshInfoProvider(provider MeshInfoProvider) {
 	h.meshInfoProvider = provider
@@ -75,6 +84,10 @@ func (h *ServiceHandler) GetRuntimeContext(
 		}
 	}
 
+	if h.agent != nil {
+		resp.ResourceShedding = h.agent.ResourceShedding()
+	}
+
 	return connect.NewResponse(resp), nil
 }
 
@@ -192,7 +205,7 @@ func (h *ServiceHandler) ListServices(
 			Port:           serviceInfo.Port,
 			HealthEndpoint: serviceInfo.HealthEndpoint,
 			ServiceType:    serviceInfo.ServiceType,
-			Labels:         serviceInfo.Labels,
+			Labels:         monitor.Labels(),
 			Status:         string(status.Status),
 			LastCheck:      timestamppb.New(status.LastCheck),
 			Error:          status.Error,
@@ -273,6 +286,23 @@ func (h *ServiceHandler) QueryEbpfMetrics(
 		}), nil
 	}
 
+	// Record what the colony has stored, so that cleanup keeps the records it
+	// has not until it does. Acknowledgements of another session refer to
+	// seq_ids of a previous database and are ignored.
+	if req.Msg.AckSessionId != "" && req.Msg.AckSessionId == h.sessionID {
+		acks := map[string]uint64{
+			beyla.DataTypeHTTP:   req.Msg.HttpAckSeqId,
+			beyla.DataTypeGRPC:   req.Msg.GrpcAckSeqId,
+			beyla.DataTypeSQL:    req.Msg.SqlAckSeqId,
+			beyla.DataTypeTraces: req.Msg.TracesAckSeqId,
+		}
+		for dataType, seqID := range acks {
+			if err := h.agent.beylaManager.Acknowledge(ctx, dataType, seqID); err != nil {
+				h.agent.logger.Warn().Err(err).Str("data_type", dataType).Msg("Failed to record Beyla acknowledgement")
+			}
+		}
+	}
+
 	response := &agentv1.QueryEbpfMetricsResponse{
 		HttpMetrics: []*agentv1.EbpfHttpMetric{},
 		GrpcMetrics: []*agentv1.EbpfGrpcMetric{},
@@ -418,6 +448,14 @@ func (h *ServiceHandler) Shell(
 	return h.shellHandler.Shell(ctx, stream)
 }
 
+// ExecInteractive implements the ExecInteractive RPC.
+func (h *ServiceHandler) ExecInteractive(
+	ctx context.Context,
+	stream *connect.BidiStream[agentv1.ShellRequest, agentv1.ShellResponse],
+) error {
+	return h.shellHandler.ExecInteractive(ctx, stream, h.containerHandler)
+}
+
 // ShellExec implements the ShellExec RPC (RFD 045).
 func (h *ServiceHandler) ShellExec(
 	ctx context.Context,
@@ -434,6 +472,29 @@ func (h *ServiceHandler) ContainerExec(
 	return h.containerHandler.ContainerExec(ctx, req)
 }
 
+// ReadFile implements the ReadFile RPC.
+func (h *ServiceHandler) ReadFile(
+	ctx context.Context,
+	req *connect.Request[agentv1.ReadFileRequest],
+	stream *connect.ServerStream[agentv1.ReadFileResponse],
+) error {
+	if h.fileHandler == nil {
+		return connect.NewError(connect.CodeUnimplemented, fmt.Errorf("file transfer not available"))
+	}
+	return h.fileHandler.ReadFile(ctx, req, stream)
+}
+
+// WriteFile implements the WriteFile RPC.
+func (h *ServiceHandler) WriteFile(
+	ctx context.Context,
+	stream *connect.ClientStream[agentv1.WriteFileRequest],
+) (*connect.Response[agentv1.WriteFileResponse], error) {
+	if h.fileHandler == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("file transfer not available"))
+	}
+	return h.fileHandler.WriteFile(ctx, stream)
+}
+
 // ResizeShellTerminal implements the ResizeShellTerminal RPC (RFD 026).
 func (h *ServiceHandler) ResizeShellTerminal(
 	ctx context.Context,
@@ -600,3 +661,26 @@ func (h *ServiceHandler) tryUpdateCacheIfNeeded(ctx context.Context, serviceName
 		}()
 	}
 }
+
+// UpdateColonySecret implements the UpdateColonySecret RPC: the colony pushes
+// its new secret when it rotates it, proving it holds the current one.
+func (h *ServiceHandler) UpdateColonySecret(
+	ctx context.Context,
+	req *connect.Request[agentv1.UpdateColonySecretRequest],
+) (*connect.Response[agentv1.UpdateColonySecretResponse], error) {
+	err := h.agent.ColonySecret().Update(req.Msg.ColonySecret, req.Msg.Proof)
+	switch {
+	case errors.Is(err, ErrNoColonySecret):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, ErrInvalidColonySecretProof):
+		h.agent.logger.Warn().
+			Str("peer_addr", req.Peer().Addr).
+			Msg("Rejected colony secret update with an invalid proof")
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	case err != nil:
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	h.agent.logger.Info().Msg("Colony secret updated by the colony")
+	return connect.NewResponse(&agentv1.UpdateColonySecretResponse{}), nil
+}
@@ -3,6 +3,7 @@ package agent
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -15,15 +16,19 @@ import (
 	"github.com/google/uuid"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/recording"
+	"github.com/coral-mesh/coral/internal/agent/redact"
 	"github.com/coral-mesh/coral/internal/logging"
 	"github.com/coral-mesh/coral/internal/sys/shell"
 )
 
 // ShellHandler implements the shell RPC methods for the agent (RFD 026).
 type ShellHandler struct {
-	logger   logging.Logger
-	sessions map[string]*shell.Session
-	mu       sync.RWMutex
+	logger        logging.Logger
+	redactor      *redact.Engine
+	recordingSink recording.Sink
+	sessions      map[string]*shell.Session
+	mu            sync.RWMutex
 }
 
 // NewShellHandler creates a new shell handler.
@@ -34,6 +39,18 @@ func NewShellHandler(logger logging.Logger) *ShellHandler {
 	}
 }
 
+// SetRedactor sets the engine redacting ShellExec output. Interactive shell
+// sessions are not redacted.
+func (h *ShellHandler) SetRedactor(redactor *redact.Engine) {
+	h.redactor = redactor
+}
+
+// SetRecordingSink sets where the recordings of shell and exec sessions go
+// once they end. Sessions are not recorded without a sink.
+func (h *ShellHandler) SetRecordingSink(sink recording.Sink) {
+	h.recordingSink = sink
+}
+
 // ShellExec executes a one-off command and returns the output (RFD 045).
 func (h *ShellHandler) ShellExec(
 	ctx context.Context,
@@ -71,6 +88,12 @@ func (h *ShellHandler) ShellExec(
 		Msg("Executing shell command")
 
 	startTime := time.Now()
+	rec := recording.New(h.recordingSink, recording.Session{
+		Kind:      recording.KindExec,
+		SessionID: sessionID,
+		UserID:    input.UserId,
+		Command:   input.Command,
+	})
 
 	// Create command.
 	//nolint:gosec // G204: Command execution is intentional for shell handler
@@ -105,9 +128,12 @@ func (h *ShellHandler) ShellExec(
 	// Wait for command to complete.
 	execErr := cmd.Wait()
 
-	// Get output from buffers.
-	stdout := stdoutBuf.Bytes()
-	stderr := stderrBuf.Bytes()
+	// Get output from buffers, masking credentials and personal data.
+	stdout, stdoutRedacted := h.redactor.Bytes("", stdoutBuf.Bytes())
+	stderr, stderrRedacted := h.redactor.Bytes("", stderrBuf.Bytes())
+	redacted := stdoutRedacted || stderrRedacted
+	rec.Output(stdout)
+	rec.Output(stderr)
 
 	duration := time.Since(startTime)
 	exitCode := int32(0)
@@ -123,6 +149,7 @@ func (h *ShellHandler) ShellExec(
 				Str("session_id", sessionID).
 				Dur("timeout", timeout).
 				Msg("Command execution timed out")
+			rec.Close(-1)
 			// Return a DeadlineExceeded error. Attach whatever stdout/stderr
 			// was captured before the kill as an error detail so callers can
 			// surface the partial output alongside the timeout signal.
@@ -135,6 +162,7 @@ func (h *ShellHandler) ShellExec(
 				SessionId:  sessionID,
 				DurationMs: uint32(duration.Milliseconds()),
 				Error:      fmt.Sprintf("command timed out after %s", timeout),
+				Redacted:   redacted,
 			}); detailErr == nil {
 				connectErr.AddDetail(detail)
 			}
@@ -164,6 +192,8 @@ func (h *ShellHandler) ShellExec(
 		Int("stderr_bytes", len(stderr)).
 		Msg("Shell command execution completed")
 
+	rec.Close(exitCode)
+
 	// Return response.
 	resp := &agentv1.ShellExecResponse{
 		Stdout:     stdout,
@@ -172,6 +202,7 @@ func (h *ShellHandler) ShellExec(
 		SessionId:  sessionID,
 		DurationMs: uint32(duration.Milliseconds()),
 		Error:      errorMsg,
+		Redacted:   redacted,
 	}
 
 	return connect.NewResponse(resp), nil
@@ -194,7 +225,7 @@ func (h *ShellHandler) Shell(
 	}
 
 	// Create and start shell session.
-	session, err := h.startShellSession(ctx, start)
+	session, err := h.startShellSession(ctx, start, nil)
 	if err != nil {
 		return fmt.Errorf("failed to start shell session: %w", err)
 	}
@@ -205,26 +236,120 @@ func (h *ShellHandler) Shell(
 		Str("shell", start.Shell).
 		Msg("Shell session started")
 
+	recorder := recording.New(h.recordingSink, recording.Session{
+		Kind:      recording.KindShell,
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		Command:   []string{session.Shell()},
+		Cols:      start.GetSize().GetCols(),
+		Rows:      start.GetSize().GetRows(),
+	})
+
+	return h.serveSession(ctx, stream, session, recorder)
+}
+
+// ExecInteractive runs a command with a PTY on the agent host, or in the
+// namespaces of the monitored container with nsenter, and streams its
+// terminal like Shell (coral exec -it).
+func (h *ShellHandler) ExecInteractive(
+	ctx context.Context,
+	stream *connect.BidiStream[agentv1.ShellRequest, agentv1.ShellResponse],
+	containers *ContainerHandler,
+) error {
+	req, err := stream.Receive()
+	if err != nil {
+		return fmt.Errorf("failed to receive start message: %w", err)
+	}
+
+	start := req.GetStart()
+	if start == nil {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("first message must be ShellStart"))
+	}
+
+	// Without a command, run the default shell of the host. Containers may
+	// lack bash, so they get /bin/sh.
+	command := start.Command
+	kind := recording.KindExecTTY
+	var argv []string
+	if start.InContainer {
+		if len(command) == 0 {
+			command = []string{"/bin/sh"}
+		}
+		containerPID, err := containers.detectContainerPID(start.ContainerName)
+		if err != nil {
+			return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("failed to detect container PID: %w", err))
+		}
+		namespaces := start.Namespaces
+		if len(namespaces) == 0 {
+			namespaces = []string{"mnt"}
+		}
+		argv = append([]string{"nsenter"}, containers.buildNsenterCommand(containerPID, namespaces, "", command)...)
+		kind = recording.KindContainerExecTTY
+	} else if len(command) > 0 {
+		argv = command
+	}
+
+	session, err := h.startShellSession(ctx, start, argv)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to start command: %w", err))
+	}
+	if len(command) == 0 {
+		command = []string{session.Shell()}
+	}
+
+	h.logger.Info().
+		Str("session_id", session.ID).
+		Str("user_id", session.UserID).
+		Strs("command", command).
+		Bool("in_container", start.InContainer).
+		Msg("Interactive exec session started")
+
+	recorder := recording.New(h.recordingSink, recording.Session{
+		Kind:          kind,
+		SessionID:     session.ID,
+		UserID:        session.UserID,
+		Command:       command,
+		ContainerName: start.ContainerName,
+		Cols:          start.GetSize().GetCols(),
+		Rows:          start.GetSize().GetRows(),
+	})
+
+	return h.serveSession(ctx, stream, session, recorder)
+}
+
+// serveSession streams the terminal of a started session until it exits or
+// the client goes away, then cleans it up and closes its recording.
+func (h *ShellHandler) serveSession(
+	ctx context.Context,
+	stream *connect.BidiStream[agentv1.ShellRequest, agentv1.ShellResponse],
+	session *shell.Session,
+	recorder *recording.Recorder,
+) error {
 	// Ensure cleanup on exit.
 	defer func() {
 		h.cleanupSession(session)
+		exitCode := session.ExitStatus()
+		recorder.Close(int32(exitCode)) //nolint:gosec // G115: Exit codes fit in int32.
 		h.logger.Info().
 			Str("session_id", session.ID).
-			Interface("exit_code", session.ExitCode).
+			Int("exit_code", exitCode).
 			Msg("Shell session ended")
 	}()
 
-	// Start goroutine to stream PTY output to client.
+	// Start goroutine to stream PTY output to client. The session ends once
+	// the exit message is sent.
 	errCh := make(chan error, 2)
 	go func() {
-		if err := h.streamOutput(stream, session); err != nil {
+		if err := h.streamOutput(stream, session, recorder); err != nil {
 			errCh <- fmt.Errorf("output stream error: %w", err)
+			return
 		}
+		errCh <- nil
 	}()
 
 	// Process client input.
 	go func() {
-		if err := h.processInput(stream, session); err != nil {
+		if err := h.processInput(stream, session, recorder); err != nil {
 			errCh <- fmt.Errorf("input stream error: %w", err)
 		}
 	}()
@@ -238,16 +363,19 @@ func (h *ShellHandler) Shell(
 	}
 }
 
-// startShellSession creates and starts a new shell session.
+// startShellSession creates and starts a new shell session, running command
+// instead of the shell if set.
 func (h *ShellHandler) startShellSession(
 	ctx context.Context,
 	start *agentv1.ShellStart,
+	command []string,
 ) (*shell.Session, error) {
 	// Prepare config
 	cfg := shell.StartConfig{
-		Shell:  start.Shell,
-		UserID: start.UserId,
-		Env:    start.Env,
+		Shell:   start.Shell,
+		Command: command,
+		UserID:  start.UserId,
+		Env:     start.Env,
 	}
 	if start.Size != nil {
 		cfg.Rows = uint16(start.Size.Rows) //nolint:gosec // G115: Terminal dimensions are small values
@@ -272,6 +400,7 @@ func (h *ShellHandler) startShellSession(
 func (h *ShellHandler) streamOutput(
 	stream *connect.BidiStream[agentv1.ShellRequest, agentv1.ShellResponse],
 	session *shell.Session,
+	recorder *recording.Recorder,
 ) error {
 	buf := make([]byte, 4096)
 	pty := session.PTY()
@@ -284,8 +413,9 @@ func (h *ShellHandler) streamOutput(
 
 		n, err := pty.Read(buf)
 		if err != nil {
-			// Check if this is a normal exit condition (EOF or EIO).
-			isExitError := err == io.EOF
+			// Check if this is a normal exit condition (EOF, EIO, or the
+			// PTY closed after the process exited).
+			isExitError := err == io.EOF || errors.Is(err, os.ErrClosed)
 			if !isExitError {
 				// Check for syscall.EIO (input/output error from PTY).
 				if pathErr, ok := err.(*os.PathError); ok {
@@ -295,10 +425,7 @@ func (h *ShellHandler) streamOutput(
 
 			if isExitError {
 				// Process exited, send exit response.
-				exitCode := 0
-				if session.ExitCode != nil {
-					exitCode = *session.ExitCode
-				}
+				exitCode := session.Wait()
 
 				return stream.Send(&agentv1.ShellResponse{
 					Payload: &agentv1.ShellResponse_Exit{
@@ -312,6 +439,8 @@ func (h *ShellHandler) streamOutput(
 			return fmt.Errorf("failed to read from PTY: %w", err)
 		}
 
+		recorder.Output(buf[:n])
+
 		// Send output to client.
 		if err := stream.Send(&agentv1.ShellResponse{
 			Payload: &agentv1.ShellResponse_Output{
@@ -330,6 +459,7 @@ func (h *ShellHandler) streamOutput(
 func (h *ShellHandler) processInput(
 	stream *connect.BidiStream[agentv1.ShellRequest, agentv1.ShellResponse],
 	session *shell.Session,
+	recorder *recording.Recorder,
 ) error {
 	pty := session.PTY()
 
@@ -345,6 +475,7 @@ func (h *ShellHandler) processInput(
 		switch payload := req.Payload.(type) {
 		case *agentv1.ShellRequest_Stdin:
 			// Write stdin to PTY.
+			recorder.Input(payload.Stdin)
 			if pty != nil {
 				if _, err := pty.Write(payload.Stdin); err != nil {
 					return fmt.Errorf("failed to write to PTY: %w", err)
@@ -354,6 +485,7 @@ func (h *ShellHandler) processInput(
 
 		case *agentv1.ShellRequest_Resize:
 			// Resize PTY.
+			recorder.Resize(payload.Resize.Cols, payload.Resize.Rows)
 			//nolint:gosec // G115: Terminal dimensions are small values
 			if err := session.Resize(uint16(payload.Resize.Rows), uint16(payload.Resize.Cols)); err != nil {
 				h.logger.Warn().Err(err).Msg("Failed to resize PTY")
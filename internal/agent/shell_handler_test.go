@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/agent/recording"
+)
+
+// execInteractiveTestHandler serves ExecInteractive of a ShellHandler.
+type execInteractiveTestHandler struct {
+	agentv1connect.UnimplementedAgentServiceHandler
+	shell *ShellHandler
+}
+
+func (h execInteractiveTestHandler) ExecInteractive(
+	ctx context.Context,
+	stream *connect.BidiStream[agentv1.ShellRequest, agentv1.ShellResponse],
+) error {
+	return h.shell.ExecInteractive(ctx, stream, NewContainerHandler(h.shell.logger))
+}
+
+func TestExecInteractive_Host(t *testing.T) {
+	shellHandler := NewShellHandler(zerolog.Nop())
+
+	var (
+		mu       sync.Mutex
+		recorded *colonyv1.ShellRecording
+	)
+	shellHandler.SetRecordingSink(func(rec *colonyv1.ShellRecording) {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded = rec
+	})
+
+	_, h := agentv1connect.NewAgentServiceHandler(execInteractiveTestHandler{shell: shellHandler})
+	srv := httptest.NewUnstartedServer(h)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client := agentv1connect.NewAgentServiceClient(srv.Client(), srv.URL)
+	stream := client.ExecInteractive(ctx)
+	require.NoError(t, stream.Send(&agentv1.ShellRequest{
+		Payload: &agentv1.ShellRequest_Start{Start: &agentv1.ShellStart{
+			UserId:  "alice",
+			Command: []string{"/bin/sh", "-c", "read line; echo got $line; exit 3"},
+			Size:    &agentv1.TerminalSize{Rows: 24, Cols: 80},
+		}},
+	}))
+	require.NoError(t, stream.Send(&agentv1.ShellRequest{
+		Payload: &agentv1.ShellRequest_Stdin{Stdin: []byte("hello\n")},
+	}))
+
+	var output strings.Builder
+	var exit *agentv1.ShellExit
+	for exit == nil {
+		resp, err := stream.Receive()
+		require.NoError(t, err)
+		switch payload := resp.Payload.(type) {
+		case *agentv1.ShellResponse_Output:
+			output.Write(payload.Output)
+		case *agentv1.ShellResponse_Exit:
+			exit = payload.Exit
+		}
+	}
+	_ = stream.CloseRequest()
+	_ = stream.CloseResponse()
+
+	assert.Contains(t, output.String(), "got hello")
+	assert.Equal(t, int32(3), exit.ExitCode)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return recorded != nil
+	}, 5*time.Second, 10*time.Millisecond, "the session is recorded when it ends")
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, recording.KindExecTTY, recorded.Kind)
+	assert.Equal(t, exit.SessionId, recorded.SessionId)
+	assert.Equal(t, "alice", recorded.UserId)
+	assert.Equal(t, []string{"/bin/sh", "-c", "read line; echo got $line; exit 3"}, recorded.Command)
+	require.NotEmpty(t, recorded.Events)
+	assert.Equal(t, recording.EventInput, recorded.Events[0].Type)
+}
@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf"
+)
+
+// TraceSyscalls counts the syscalls of the service running a process for
+// the requested duration, with their latency and error codes.
+func (s *DebugService) TraceSyscalls(
+	ctx context.Context,
+	req *agentv1.TraceSyscallsAgentRequest,
+) (*agentv1.TraceSyscallsAgentResponse, error) {
+	s.logger.Info().
+		Str("service", req.ServiceName).
+		Int32("pid", req.Pid).
+		Int32("duration_seconds", req.DurationSeconds).
+		Strs("syscalls", req.Syscalls).
+		Msg("Starting syscall trace")
+
+	if req.Pid <= 0 {
+		return &agentv1.TraceSyscallsAgentResponse{
+			Success: false,
+			Error:   "pid is required",
+		}, nil
+	}
+
+	duration := int(req.DurationSeconds)
+	if duration <= 0 {
+		duration = 30
+	}
+
+	report, err := ebpf.TraceSyscalls(ctx, s.logger, uint32(req.Pid), time.Duration(duration)*time.Second, req.Syscalls) // #nosec G115 -- checked positive
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to trace syscalls")
+		return &agentv1.TraceSyscallsAgentResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to trace syscalls: %v", err),
+		}, nil
+	}
+
+	resp := &agentv1.TraceSyscallsAgentResponse{
+		Target:    report.Target,
+		StartTime: timestamppb.New(report.Start),
+		EndTime:   timestamppb.New(report.End),
+		Success:   true,
+	}
+	for _, sc := range report.Syscalls {
+		stats := &agentv1.SyscallStats{
+			Name:    sc.Name,
+			Number:  sc.Number,
+			Count:   sc.Count,
+			TotalNs: uint64(sc.Total), // #nosec G115 -- durations are positive
+			Latency: latencyBuckets(&sc.Latency),
+		}
+		for name, count := range sc.Errors {
+			stats.Errors = append(stats.Errors, &agentv1.SyscallError{Name: name, Count: count})
+		}
+		sort.Slice(stats.Errors, func(i, j int) bool {
+			if stats.Errors[i].Count != stats.Errors[j].Count {
+				return stats.Errors[i].Count > stats.Errors[j].Count
+			}
+			return stats.Errors[i].Name < stats.Errors[j].Name
+		})
+		resp.Syscalls = append(resp.Syscalls, stats)
+	}
+	return resp, nil
+}
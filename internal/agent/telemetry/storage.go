@@ -56,6 +56,9 @@ func NewStorage(db *sql.DB, logger zerolog.Logger) (*Storage, error) {
 	return s, nil
 }
 
+// migrations are the otel_spans_local schema changes applied after initSchema.
+var migrations []duckdb.Migration
+
 // initSchema creates the local telemetry spans table.
 func (s *Storage) initSchema() error {
 	schema := `
@@ -97,6 +100,10 @@ func (s *Storage) initSchema() error {
 		return fmt.Errorf("failed to create schema: %w", err)
 	}
 
+	if err := duckdb.ApplyMigrations(context.Background(), s.db, "telemetry", migrations, s.logger); err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
 	// Force WAL checkpoint so remote HTTP clients can see the schema.
 	// Without this, the tables might not be visible when serving the file over HTTP.
 	if _, err := s.db.Exec("CHECKPOINT"); err != nil {
@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/tlscapture"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// StartTlsCapture starts capturing the plaintext of a service's TLS
+// connections with uprobes on its TLS library. The eBPF manager refuses it
+// unless debug.tls_capture.enabled is set.
+func (s *DebugService) StartTlsCapture(
+	ctx context.Context,
+	req *agentv1.StartTlsCaptureRequest,
+) (*agentv1.StartTlsCaptureResponse, error) {
+	s.logger.Info().
+		Str("service", req.ServiceName).
+		Str("library", req.Library).
+		Msg("Starting TLS capture")
+
+	if _, err := tlscapture.ParseLibrary(req.Library); err != nil {
+		return nil, coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, err,
+			"library", req.Library))
+	}
+	if req.MaxDataBytes > tlscapture.MaxDataBytes {
+		return nil, coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+			fmt.Errorf("max_data_bytes must be at most %d, got %d", tlscapture.MaxDataBytes, req.MaxDataBytes)))
+	}
+
+	var service *meshv1.ServiceInfo
+	for _, info := range s.agent.ServiceInfos() {
+		if info.Name == req.ServiceName {
+			service = info
+			break
+		}
+	}
+	if service == nil {
+		return nil, coralerrors.ToConnect(errServiceNotFound(req.ServiceName))
+	}
+	if service.ProcessId == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("no running process known for service %s", req.ServiceName))
+	}
+
+	config := map[string]string{
+		"service_name": req.ServiceName,
+		"pid":          strconv.Itoa(int(service.ProcessId)),
+	}
+	if req.SessionId != "" {
+		config["session_id"] = req.SessionId
+	}
+	if req.Library != "" {
+		config["library"] = req.Library
+	}
+	if req.Match != "" {
+		config["match"] = req.Match
+	}
+	if req.MaxDataBytes > 0 {
+		config["max_data_bytes"] = strconv.FormatUint(uint64(req.MaxDataBytes), 10)
+	}
+
+	resp, err := s.agent.ebpfManager.StartCollector(ctx, &meshv1.StartEbpfCollectorRequest{
+		AgentId:     req.AgentId,
+		ServiceName: req.ServiceName,
+		Kind:        agentv1.EbpfCollectorKind_EBPF_COLLECTOR_KIND_TLS_CAPTURE,
+		Duration:    req.Duration,
+		Config:      config,
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to start TLS capture")
+		return nil, coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_PROBE_ATTACH_FAILED,
+			fmt.Errorf("failed to start collector: %w", err),
+			"service", req.ServiceName))
+	}
+
+	return &agentv1.StartTlsCaptureResponse{
+		CollectorId: resp.CollectorId,
+		ExpiresAt:   resp.ExpiresAt,
+		Supported:   resp.Supported,
+		Error:       resp.Error,
+	}, nil
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/mod/semver"
+)
+
+// agentReleaseAudience distinguishes agent release tokens from capability
+// tokens, which are signed with the same key.
+const agentReleaseAudience = "coral-agent-release"
+
+// AgentArtifact is the agent executable of a release for one platform.
+type AgentArtifact struct {
+	OS     string `json:"os"`
+	Arch   string `json:"arch"`
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"` // Hex-encoded.
+}
+
+// Platform returns the artifact's platform in the form "os/arch".
+func (a AgentArtifact) Platform() string {
+	return a.OS + "/" + a.Arch
+}
+
+// AgentRelease is an agent release a colony rolls out to its agents.
+type AgentRelease struct {
+	// ColonyID is the colony that signed the release.
+	ColonyID string
+
+	// Version is the release version, e.g. "v0.5.0".
+	Version string
+
+	// Artifacts are the executables of the release.
+	Artifacts []AgentArtifact
+
+	// AgentIDs are the agents the release is rolled out to; empty for all
+	// agents. Other agents refuse to install it.
+	AgentIDs []string
+
+	// IssuedAt is when the release token was signed.
+	IssuedAt time.Time
+
+	// ExpiresAt is when the release token stops being valid.
+	ExpiresAt time.Time
+}
+
+// Validate checks that the release has a version and well-formed artifacts,
+// at most one per platform.
+func (r *AgentRelease) Validate() error {
+	if r.Version == "" {
+		return fmt.Errorf("release has no version")
+	}
+	if releaseSemver(r.Version) == "" {
+		return fmt.Errorf("release version %q is not a semantic version", r.Version)
+	}
+	if len(r.Artifacts) == 0 {
+		return fmt.Errorf("release has no artifacts")
+	}
+	platforms := make(map[string]bool)
+	for _, a := range r.Artifacts {
+		if a.OS == "" || a.Arch == "" {
+			return fmt.Errorf("artifact %q has no platform", a.URL)
+		}
+		if platforms[a.Platform()] {
+			return fmt.Errorf("duplicate artifact for %s", a.Platform())
+		}
+		platforms[a.Platform()] = true
+
+		u, err := url.Parse(a.URL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("artifact for %s: invalid URL %q", a.Platform(), a.URL)
+		}
+		if sum, err := hex.DecodeString(a.SHA256); err != nil || len(sum) != 32 {
+			return fmt.Errorf("artifact for %s: invalid SHA-256 %q", a.Platform(), a.SHA256)
+		}
+	}
+	return nil
+}
+
+// Artifact returns the executable of the release for goos/goarch, or nil if
+// the release has none.
+func (r *AgentRelease) Artifact(goos, goarch string) *AgentArtifact {
+	for i := range r.Artifacts {
+		if r.Artifacts[i].OS == goos && r.Artifacts[i].Arch == goarch {
+			return &r.Artifacts[i]
+		}
+	}
+	return nil
+}
+
+// Targets reports whether the release is rolled out to agentID.
+func (r *AgentRelease) Targets(agentID string) bool {
+	return len(r.AgentIDs) == 0 || slices.Contains(r.AgentIDs, agentID)
+}
+
+// releaseSemver returns version as a semantic version with a "v" prefix, or
+// "" if it is not one.
+func releaseSemver(version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	if !semver.IsValid(version) {
+		return ""
+	}
+	return version
+}
+
+// IsAgentUpgrade reports whether the release version next is newer than the
+// running version current. Development builds, whose version is not a
+// semantic version, accept any release.
+func IsAgentUpgrade(current, next string) bool {
+	n := releaseSemver(next)
+	if n == "" {
+		return false
+	}
+	c := releaseSemver(current)
+	return c == "" || semver.Compare(n, c) > 0
+}
+
+// agentReleaseClaims are the JWT claims of an agent release token.
+type agentReleaseClaims struct {
+	jwt.RegisteredClaims
+	Version   string          `json:"version"`
+	Artifacts []AgentArtifact `json:"artifacts"`
+	AgentIDs  []string        `json:"agent_ids,omitempty"`
+}
+
+// IssueAgentReleaseToken signs an agent release with the colony's policy
+// signing key, like capability tokens, so that agents can verify it against
+// the colony's root CA before installing it.
+func IssueAgentReleaseToken(r *AgentRelease, cert *x509.Certificate, key *ecdsa.PrivateKey) (string, error) {
+	if err := r.Validate(); err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, agentReleaseClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    r.ColonyID,
+			Audience:  jwt.ClaimStrings{agentReleaseAudience},
+			IssuedAt:  jwt.NewNumericDate(r.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(r.ExpiresAt),
+		},
+		Version:   r.Version,
+		Artifacts: r.Artifacts,
+		AgentIDs:  r.AgentIDs,
+	})
+	token.Header["x5c"] = []string{base64.StdEncoding.EncodeToString(cert.Raw)}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign agent release: %w", err)
+	}
+	return signed, nil
+}
+
+// VerifyAgentRelease checks an agent release token's signature, issuer and
+// expiry and returns the release it holds.
+func (v *CapabilityVerifier) VerifyAgentRelease(token string) (*AgentRelease, error) {
+	var claims agentReleaseClaims
+	_, err := jwt.ParseWithClaims(strings.TrimSpace(token), &claims, v.signingKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}),
+		jwt.WithIssuer(v.colonyID),
+		jwt.WithAudience(agentReleaseAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid agent release token: %w", err)
+	}
+
+	r := &AgentRelease{
+		ColonyID:  claims.Issuer,
+		Version:   claims.Version,
+		Artifacts: claims.Artifacts,
+		AgentIDs:  claims.AgentIDs,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+	if claims.IssuedAt != nil {
+		r.IssuedAt = claims.IssuedAt.Time
+	}
+	if err := r.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid agent release token: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testAgentRelease(colonyID string, expiresAt time.Time) *AgentRelease {
+	return &AgentRelease{
+		ColonyID: colonyID,
+		Version:  "v0.5.0",
+		Artifacts: []AgentArtifact{{
+			OS:     "linux",
+			Arch:   "amd64",
+			URL:    "https://example.com/coral-agent-linux-amd64",
+			SHA256: strings.Repeat("ab", 32),
+		}},
+		IssuedAt:  time.Now(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+func TestVerifyAgentRelease(t *testing.T) {
+	root := newTestCert(t, "root", true, nil)
+	policySigning := newTestCert(t, "policy signing", false, root)
+	otherRoot := newTestCert(t, "other root", true, nil)
+	otherPolicySigning := newTestCert(t, "other policy signing", false, otherRoot)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root.cert)
+	verifier := NewCapabilityVerifier(roots, "colony-1")
+
+	issue := func(signer *testCert, colonyID string, expiresAt time.Time) string {
+		t.Helper()
+		token, err := IssueAgentReleaseToken(testAgentRelease(colonyID, expiresAt), signer.cert, signer.key)
+		if err != nil {
+			t.Fatalf("IssueAgentReleaseToken() error = %v", err)
+		}
+		return token
+	}
+
+	token := issue(policySigning, "colony-1", time.Now().Add(time.Hour))
+	r, err := verifier.VerifyAgentRelease(token)
+	if err != nil {
+		t.Fatalf("VerifyAgentRelease() error = %v", err)
+	}
+	if r.Version != "v0.5.0" || r.ColonyID != "colony-1" {
+		t.Errorf("VerifyAgentRelease() = %+v, want the issued release", r)
+	}
+	if a := r.Artifact("linux", "amd64"); a == nil || a.URL != "https://example.com/coral-agent-linux-amd64" {
+		t.Errorf("Artifact(linux, amd64) = %+v, want the issued artifact", a)
+	}
+	if a := r.Artifact("darwin", "arm64"); a != nil {
+		t.Errorf("Artifact(darwin, arm64) = %+v, want nil", a)
+	}
+
+	capability, err := IssueCapabilityToken(&Capability{
+		ColonyID:  "colony-1",
+		Scopes:    []Scope{{Permission: PermissionAdmin}},
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, policySigning.cert, policySigning.key)
+	if err != nil {
+		t.Fatalf("IssueCapabilityToken() error = %v", err)
+	}
+
+	rejected := map[string]string{
+		"expired":          issue(policySigning, "colony-1", time.Now().Add(-time.Minute)),
+		"other colony":     issue(policySigning, "colony-2", time.Now().Add(time.Hour)),
+		"untrusted root":   issue(otherPolicySigning, "colony-1", time.Now().Add(time.Hour)),
+		"tampered":         token[:len(token)-4] + "AAAA",
+		"capability token": strings.TrimPrefix(capability, CapabilityTokenPrefix),
+	}
+	for name, token := range rejected {
+		if _, err := verifier.VerifyAgentRelease(token); err == nil {
+			t.Errorf("VerifyAgentRelease() accepted a token: %s", name)
+		}
+	}
+
+	// Release tokens do not grant capabilities.
+	if _, err := verifier.Verify(CapabilityTokenPrefix + token); err == nil {
+		t.Error("Verify() accepted an agent release token")
+	}
+}
+
+func TestAgentRelease_Validate(t *testing.T) {
+	tests := map[string]func(r *AgentRelease){
+		"no version":     func(r *AgentRelease) { r.Version = "" },
+		"non-semver":     func(r *AgentRelease) { r.Version = "latest" },
+		"no artifacts":   func(r *AgentRelease) { r.Artifacts = nil },
+		"no platform":    func(r *AgentRelease) { r.Artifacts[0].Arch = "" },
+		"invalid URL":    func(r *AgentRelease) { r.Artifacts[0].URL = "file:///tmp/coral" },
+		"invalid sha256": func(r *AgentRelease) { r.Artifacts[0].SHA256 = "abc" },
+		"duplicate platform": func(r *AgentRelease) {
+			r.Artifacts = append(r.Artifacts, r.Artifacts[0])
+		},
+	}
+	if err := testAgentRelease("colony-1", time.Now()).Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	for name, mutate := range tests {
+		r := testAgentRelease("colony-1", time.Now())
+		mutate(r)
+		if err := r.Validate(); err == nil {
+			t.Errorf("Validate() accepted a release with %s", name)
+		}
+	}
+}
+
+func TestIsAgentUpgrade(t *testing.T) {
+	tests := []struct {
+		current, next string
+		want          bool
+	}{
+		{"v0.4.0", "v0.5.0", true},
+		{"0.4.0", "v0.4.1", true},
+		{"v0.4.0", "v0.4.0", false},
+		{"v0.5.0", "v0.4.0", false},
+		{"v0.4.0", "latest", false},
+		{"dev", "v0.4.0", true}, // Development builds accept any release.
+	}
+	for _, tt := range tests {
+		if got := IsAgentUpgrade(tt.current, tt.next); got != tt.want {
+			t.Errorf("IsAgentUpgrade(%q, %q) = %v, want %v", tt.current, tt.next, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// CapabilityTokenPrefix identifies capability tokens among bearer tokens.
+const CapabilityTokenPrefix = "coralcap_"
+
+const (
+	// DefaultCapabilityTTL is the lifetime of capability tokens minted
+	// without an explicit one.
+	DefaultCapabilityTTL = time.Hour
+
+	// MaxCapabilityTTL bounds the lifetime of capability tokens.
+	MaxCapabilityTTL = 24 * time.Hour
+)
+
+// Scope grants a permission, optionally restricted to a single service, e.g.
+// "debug:api". A scope without a service applies to all services.
+type Scope struct {
+	Permission Permission
+	Service    string
+}
+
+// ParseScope parses a scope of the form "<permission>[:<service>]".
+func ParseScope(s string) (Scope, error) {
+	name, service, _ := strings.Cut(strings.TrimSpace(s), ":")
+	perm := ParsePermission(strings.ToLower(name))
+	if perm == "" {
+		return Scope{}, fmt.Errorf("invalid scope %q: unknown permission %q", s, name)
+	}
+	if strings.Contains(s, ":") && service == "" {
+		return Scope{}, fmt.Errorf("invalid scope %q: empty service", s)
+	}
+	return Scope{Permission: perm, Service: service}, nil
+}
+
+// String returns the scope in the form accepted by ParseScope.
+func (s Scope) String() string {
+	if s.Service == "" {
+		return string(s.Permission)
+	}
+	return string(s.Permission) + ":" + s.Service
+}
+
+// Capability is a short-lived, scoped grant minted by the colony, e.g. to
+// delegate debugging of one service to a CI job for an hour.
+type Capability struct {
+	// ID uniquely identifies the capability token.
+	ID string
+
+	// ColonyID is the colony that minted the token.
+	ColonyID string
+
+	// Subject is who the token was minted for.
+	Subject string
+
+	// IssuedBy is the identity that requested the token.
+	IssuedBy string
+
+	// Scopes are the permissions granted.
+	Scopes []Scope
+
+	// IssuedAt is when the token was minted.
+	IssuedAt time.Time
+
+	// ExpiresAt is when the token stops being valid.
+	ExpiresAt time.Time
+}
+
+// Allows reports whether the capability grants perm on service. An empty
+// service is only granted by scopes that apply to all services.
+func (c *Capability) Allows(perm Permission, service string) bool {
+	for _, s := range c.Scopes {
+		if s.Permission != perm && s.Permission != PermissionAdmin {
+			continue
+		}
+		if s.Service == "" || s.Service == service {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions returns the distinct permissions granted by the scopes,
+// regardless of their service.
+func (c *Capability) Permissions() []Permission {
+	var perms []Permission
+	seen := make(map[Permission]bool)
+	for _, s := range c.Scopes {
+		if !seen[s.Permission] {
+			seen[s.Permission] = true
+			perms = append(perms, s.Permission)
+		}
+	}
+	return perms
+}
+
+// APIToken returns the capability as an API token, so that it authenticates
+// like one. The token keeps a reference to the capability for checks of
+// service scopes.
+func (c *Capability) APIToken() *APIToken {
+	return &APIToken{
+		TokenID:     "cap:" + c.ID,
+		User:        c.Subject,
+		Permissions: c.Permissions(),
+		CreatedAt:   c.IssuedAt,
+		Capability:  c,
+	}
+}
+
+// capabilityClaims are the JWT claims of a capability token.
+type capabilityClaims struct {
+	jwt.RegisteredClaims
+	IssuedBy string   `json:"issued_by,omitempty"`
+	Scopes   []string `json:"scopes"`
+}
+
+// IssueCapabilityToken signs a capability token with the colony's policy
+// signing key. The signing certificate travels in the token's x5c header so
+// that agents can verify it against the colony's root CA alone.
+func IssueCapabilityToken(c *Capability, cert *x509.Certificate, key *ecdsa.PrivateKey) (string, error) {
+	if len(c.Scopes) == 0 {
+		return "", fmt.Errorf("capability has no scopes")
+	}
+	if c.ID == "" {
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			return "", fmt.Errorf("failed to generate capability ID: %w", err)
+		}
+		c.ID = hex.EncodeToString(id)
+	}
+
+	scopes := make([]string, len(c.Scopes))
+	for i, s := range c.Scopes {
+		scopes[i] = s.String()
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, capabilityClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        c.ID,
+			Issuer:    c.ColonyID,
+			Subject:   c.Subject,
+			IssuedAt:  jwt.NewNumericDate(c.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(c.ExpiresAt),
+		},
+		IssuedBy: c.IssuedBy,
+		Scopes:   scopes,
+	})
+	token.Header["x5c"] = []string{base64.StdEncoding.EncodeToString(cert.Raw)}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign capability token: %w", err)
+	}
+	return CapabilityTokenPrefix + signed, nil
+}
+
+// IsCapabilityToken reports whether a bearer token is a capability token.
+func IsCapabilityToken(token string) bool {
+	return strings.HasPrefix(token, CapabilityTokenPrefix)
+}
+
+// CapabilityVerifier verifies capability tokens minted by a colony.
+type CapabilityVerifier struct {
+	roots    *x509.CertPool
+	colonyID string
+}
+
+// NewCapabilityVerifier creates a verifier accepting the capability tokens of
+// colonyID, signed by a policy signing certificate issued by one of roots.
+func NewCapabilityVerifier(roots *x509.CertPool, colonyID string) *CapabilityVerifier {
+	return &CapabilityVerifier{
+		roots:    roots,
+		colonyID: colonyID,
+	}
+}
+
+// Verify checks a capability token's signature, issuer and expiry and returns
+// the capability it grants.
+func (v *CapabilityVerifier) Verify(token string) (*Capability, error) {
+	if !IsCapabilityToken(token) {
+		return nil, fmt.Errorf("not a capability token")
+	}
+
+	var claims capabilityClaims
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(token, CapabilityTokenPrefix), &claims, v.signingKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}),
+		jwt.WithIssuer(v.colonyID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid capability token: %w", err)
+	}
+
+	c := &Capability{
+		ID:       claims.ID,
+		ColonyID: claims.Issuer,
+		Subject:  claims.Subject,
+		IssuedBy: claims.IssuedBy,
+	}
+	if claims.IssuedAt != nil {
+		c.IssuedAt = claims.IssuedAt.Time
+	}
+	c.ExpiresAt = claims.ExpiresAt.Time
+	for _, s := range claims.Scopes {
+		scope, err := ParseScope(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid capability token: %w", err)
+		}
+		c.Scopes = append(c.Scopes, scope)
+	}
+	if len(c.Scopes) == 0 {
+		return nil, fmt.Errorf("invalid capability token: no scopes")
+	}
+	return c, nil
+}
+
+// signingKey returns the public key of the certificate in the token's x5c
+// header, once verified to be a policy signing certificate: a leaf issued
+// directly by a root, as intermediates issue all other leaves.
+func (v *CapabilityVerifier) signingKey(token *jwt.Token) (interface{}, error) {
+	x5c, ok := token.Header["x5c"].([]interface{})
+	if !ok || len(x5c) == 0 {
+		return nil, fmt.Errorf("missing x5c header")
+	}
+	encoded, ok := x5c[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid x5c header")
+	}
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x5c header: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing certificate: %w", err)
+	}
+
+	if cert.IsCA || cert.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return nil, fmt.Errorf("certificate %q is not a policy signing certificate", cert.Subject.CommonName)
+	}
+	chains, err := cert.Verify(x509.VerifyOptions{
+		Roots:     v.roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("untrusted signing certificate: %w", err)
+	}
+	for _, chain := range chains {
+		if len(chain) == 2 {
+			return cert.PublicKey, nil
+		}
+	}
+	return nil, fmt.Errorf("certificate %q is not a policy signing certificate", cert.Subject.CommonName)
+}
@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// testCert is a certificate and its key.
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert creates a certificate signed by parent, or self-signed if
+// parent is nil.
+func newTestCert(t *testing.T, name string, isCA bool, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return &testCert{cert: cert, key: key}
+}
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Scope
+		wantErr bool
+	}{
+		{in: "debug", want: Scope{Permission: PermissionDebug}},
+		{in: "debug:api", want: Scope{Permission: PermissionDebug, Service: "api"}},
+		{in: "Query", want: Scope{Permission: PermissionQuery}},
+		{in: "debug:", wantErr: true},
+		{in: "shell:api", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseScope(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseScope(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseScope(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		if again, _ := ParseScope(got.String()); !tt.wantErr && again != got {
+			t.Errorf("Scope.String() = %q does not round trip", got.String())
+		}
+	}
+}
+
+func TestCapability_Allows(t *testing.T) {
+	c := &Capability{Scopes: []Scope{
+		{Permission: PermissionDebug, Service: "api"},
+		{Permission: PermissionQuery},
+	}}
+
+	tests := []struct {
+		perm    Permission
+		service string
+		want    bool
+	}{
+		{PermissionDebug, "api", true},
+		{PermissionDebug, "web", false},
+		{PermissionDebug, "", false},
+		{PermissionQuery, "web", true},
+		{PermissionQuery, "", true},
+		{PermissionAnalyze, "api", false},
+	}
+	for _, tt := range tests {
+		if got := c.Allows(tt.perm, tt.service); got != tt.want {
+			t.Errorf("Allows(%s, %q) = %v, want %v", tt.perm, tt.service, got, tt.want)
+		}
+	}
+
+	token := c.APIToken()
+	if !HasPermission(token, PermissionDebug) || !HasPermission(token, PermissionQuery) {
+		t.Errorf("APIToken() permissions = %v, want debug and query", token.Permissions)
+	}
+	if token.Capability != c {
+		t.Error("APIToken() does not reference the capability")
+	}
+}
+
+func TestCapabilityVerifier(t *testing.T) {
+	root := newTestCert(t, "root", true, nil)
+	policySigning := newTestCert(t, "policy signing", false, root)
+	intermediate := newTestCert(t, "agent intermediate", true, root)
+	agentLeaf := newTestCert(t, "agent", false, intermediate)
+	otherRoot := newTestCert(t, "other root", true, nil)
+	otherPolicySigning := newTestCert(t, "other policy signing", false, otherRoot)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root.cert)
+	verifier := NewCapabilityVerifier(roots, "colony-1")
+
+	issue := func(signer *testCert, colonyID string, expiresAt time.Time) string {
+		t.Helper()
+		token, err := IssueCapabilityToken(&Capability{
+			ColonyID:  colonyID,
+			Subject:   "ci-deploy",
+			IssuedBy:  "alice",
+			Scopes:    []Scope{{Permission: PermissionDebug, Service: "api"}},
+			IssuedAt:  time.Now(),
+			ExpiresAt: expiresAt,
+		}, signer.cert, signer.key)
+		if err != nil {
+			t.Fatalf("IssueCapabilityToken() error = %v", err)
+		}
+		return token
+	}
+
+	token := issue(policySigning, "colony-1", time.Now().Add(time.Hour))
+	if !IsCapabilityToken(token) {
+		t.Fatalf("token %q lacks the capability prefix", token)
+	}
+	c, err := verifier.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if c.ID == "" || c.Subject != "ci-deploy" || c.IssuedBy != "alice" || c.ColonyID != "colony-1" {
+		t.Errorf("Verify() = %+v, want the issued capability", c)
+	}
+	if !c.Allows(PermissionDebug, "api") {
+		t.Errorf("Verify() scopes = %v, want debug:api", c.Scopes)
+	}
+
+	rejected := map[string]string{
+		"expired":                 issue(policySigning, "colony-1", time.Now().Add(-time.Minute)),
+		"other colony":            issue(policySigning, "colony-2", time.Now().Add(time.Hour)),
+		"untrusted root":          issue(otherPolicySigning, "colony-1", time.Now().Add(time.Hour)),
+		"intermediate-issued key": issue(agentLeaf, "colony-1", time.Now().Add(time.Hour)),
+		"tampered":                token[:len(token)-4] + "AAAA",
+		"API token":               "coral_abc",
+	}
+	for name, token := range rejected {
+		if _, err := verifier.Verify(token); err == nil {
+			t.Errorf("Verify() accepted a token: %s", name)
+		}
+	}
+}
+
+func TestTokenStore_ValidateCapabilityToken(t *testing.T) {
+	root := newTestCert(t, "root", true, nil)
+	policySigning := newTestCert(t, "policy signing", false, root)
+	roots := x509.NewCertPool()
+	roots.AddCert(root.cert)
+
+	token, err := IssueCapabilityToken(&Capability{
+		ColonyID:  "colony-1",
+		Subject:   "ci-deploy",
+		Scopes:    []Scope{{Permission: PermissionDebug}},
+		IssuedAt:  time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, policySigning.cert, policySigning.key)
+	if err != nil {
+		t.Fatalf("IssueCapabilityToken() error = %v", err)
+	}
+
+	ts := NewTokenStore("")
+	if _, err := ts.ValidateToken(token); err == nil {
+		t.Error("ValidateToken() accepted a capability token without a verifier")
+	}
+
+	ts.SetCapabilityVerifier(NewCapabilityVerifier(roots, "colony-1"))
+	stored, err := ts.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if stored.User != "ci-deploy" || stored.Capability == nil || !HasPermission(stored, PermissionDebug) {
+		t.Errorf("ValidateToken() = %+v, want the capability's token", stored)
+	}
+	if len(ts.ListTokens()) != 0 {
+		t.Error("capability tokens must not be stored")
+	}
+}
@@ -2,7 +2,9 @@
 package auth
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
@@ -41,6 +43,28 @@ func GenerateColonySecret() (string, error) {
 	return secret, nil
 }
 
+// colonySecretProofContext separates rotation proofs from other MACs keyed
+// with the colony secret.
+const colonySecretProofContext = "coral-colony-secret-rotation/v1"
+
+// ColonySecretProof proves that whoever rotates the colony secret to next
+// holds the current one: it is HMAC-SHA256(current, context || next).
+func ColonySecretProof(current, next string) []byte {
+	mac := hmac.New(sha256.New, []byte(current))
+	mac.Write([]byte(colonySecretProofContext))
+	mac.Write([]byte{0})
+	mac.Write([]byte(next))
+	return mac.Sum(nil)
+}
+
+// VerifyColonySecretProof checks a proof made by ColonySecretProof.
+func VerifyColonySecretProof(current, next string, proof []byte) bool {
+	if current == "" {
+		return false
+	}
+	return hmac.Equal(ColonySecretProof(current, next), proof)
+}
+
 // normalize converts a string to lowercase and replaces non-alphanumeric with hyphens.
 // Example: "My Shop!" -> "my-shop"
 func normalize(s string) string {
@@ -99,6 +99,14 @@ func TestGenerateColonySecret_Uniqueness(t *testing.T) {
 	}
 }
 
+func TestColonySecretProof(t *testing.T) {
+	proof := ColonySecretProof("old", "new")
+	assert.True(t, VerifyColonySecretProof("old", "new", proof))
+	assert.False(t, VerifyColonySecretProof("other", "new", proof), "proof is keyed with the current secret")
+	assert.False(t, VerifyColonySecretProof("old", "forged", proof), "proof covers the new secret")
+	assert.False(t, VerifyColonySecretProof("", "new", ColonySecretProof("", "new")), "no current secret, no proof")
+}
+
 func TestNormalize(t *testing.T) {
 	tests := []struct {
 		input    string
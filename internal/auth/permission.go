@@ -49,3 +49,56 @@ func ParsePermission(s string) Permission {
 		return ""
 	}
 }
+
+// Role is a named set of permissions assigned to a user's API token.
+type Role string
+
+const (
+	// RoleViewer can read colony status and query observability data.
+	RoleViewer Role = "viewer"
+
+	// RoleDebugger can additionally run analysis, attach probes, profile,
+	// and execute shell or container commands.
+	RoleDebugger Role = "debugger"
+
+	// RoleAdmin has full access.
+	RoleAdmin Role = "admin"
+)
+
+// AllRoles returns all defined roles.
+func AllRoles() []Role {
+	return []Role{
+		RoleViewer,
+		RoleDebugger,
+		RoleAdmin,
+	}
+}
+
+// ParseRole converts a string to a Role.
+// Returns empty string if the role is invalid.
+func ParseRole(s string) Role {
+	switch s {
+	case "viewer":
+		return RoleViewer
+	case "debugger":
+		return RoleDebugger
+	case "admin":
+		return RoleAdmin
+	default:
+		return ""
+	}
+}
+
+// Permissions returns the permissions granted by the role.
+func (r Role) Permissions() []Permission {
+	switch r {
+	case RoleViewer:
+		return []Permission{PermissionStatus, PermissionQuery}
+	case RoleDebugger:
+		return []Permission{PermissionStatus, PermissionQuery, PermissionAnalyze, PermissionDebug}
+	case RoleAdmin:
+		return []Permission{PermissionAdmin}
+	default:
+		return nil
+	}
+}
@@ -12,6 +12,13 @@ type APIToken struct {
 	// TokenHash is the bcrypt hash of the token (never store plaintext).
 	TokenHash string `yaml:"token_hash" json:"-"`
 
+	// User is the user the token was issued to.
+	User string `yaml:"user,omitempty" json:"user,omitempty"`
+
+	// Role is the role assigned to the token. Its permissions are granted in
+	// addition to Permissions.
+	Role Role `yaml:"role,omitempty" json:"role,omitempty"`
+
 	// Permissions is the list of permissions granted to this token.
 	Permissions []Permission `yaml:"permissions" json:"permissions"`
 
@@ -26,6 +33,9 @@ type APIToken struct {
 
 	// Revoked indicates if the token has been revoked.
 	Revoked bool `yaml:"revoked,omitempty" json:"revoked,omitempty"`
+
+	// Capability is set for capability tokens, which are never stored.
+	Capability *Capability `yaml:"-" json:"-"`
 }
 
 // TokenInfo is returned after token creation (contains plaintext token once).
@@ -36,6 +46,12 @@ type TokenInfo struct {
 	// Token is the plaintext token value (shown only once after creation).
 	Token string `json:"token"`
 
+	// User is the user the token was issued to.
+	User string `json:"user,omitempty"`
+
+	// Role is the role assigned to the token.
+	Role Role `json:"role,omitempty"`
+
 	// Permissions is the list of permissions granted to this token.
 	Permissions []Permission `json:"permissions"`
 
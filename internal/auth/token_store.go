@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
@@ -14,9 +15,10 @@ import (
 
 // TokenStore manages API tokens with in-memory caching and file persistence.
 type TokenStore struct {
-	mu       sync.RWMutex
-	tokens   map[string]*APIToken // tokenID -> token
-	filePath string               // Path to tokens.yaml
+	mu           sync.RWMutex
+	tokens       map[string]*APIToken // tokenID -> token
+	filePath     string               // Path to tokens.yaml
+	capabilities *CapabilityVerifier  // Verifies capability tokens, if set
 }
 
 // NewTokenStore creates a new token store.
@@ -52,9 +54,41 @@ func (ts *TokenStore) GenerateToken(
 	permissions []Permission,
 	rateLimit string,
 ) (*TokenInfo, error) {
+	return ts.generate(&APIToken{
+		TokenID:     tokenID,
+		Permissions: permissions,
+		RateLimit:   rateLimit,
+	})
+}
+
+// GenerateUserToken creates a new API token issued to a user with the given
+// role. Returns the token info including the plaintext token (shown only once).
+func (ts *TokenStore) GenerateUserToken(
+	tokenID string,
+	user string,
+	role Role,
+	rateLimit string,
+) (*TokenInfo, error) {
+	if role.Permissions() == nil {
+		return nil, fmt.Errorf("invalid role %q", role)
+	}
+
+	return ts.generate(&APIToken{
+		TokenID:     tokenID,
+		User:        user,
+		Role:        role,
+		Permissions: role.Permissions(),
+		RateLimit:   rateLimit,
+	})
+}
+
+// generate hashes a fresh random secret into stored and persists it.
+func (ts *TokenStore) generate(stored *APIToken) (*TokenInfo, error) {
 	ts.mu.Lock()
 	defer ts.mu.Unlock()
 
+	tokenID := stored.TokenID
+
 	// Check for duplicate token ID.
 	if _, exists := ts.tokens[tokenID]; exists {
 		return nil, fmt.Errorf("token with ID %q already exists", tokenID)
@@ -75,14 +109,8 @@ func (ts *TokenStore) GenerateToken(
 		return nil, fmt.Errorf("failed to hash token: %w", err)
 	}
 
-	// Create stored token.
-	stored := &APIToken{
-		TokenID:     tokenID,
-		TokenHash:   string(hash),
-		Permissions: permissions,
-		RateLimit:   rateLimit,
-		CreatedAt:   time.Now(),
-	}
+	stored.TokenHash = string(hash)
+	stored.CreatedAt = time.Now()
 
 	// Store in memory.
 	ts.tokens[tokenID] = stored
@@ -97,14 +125,40 @@ func (ts *TokenStore) GenerateToken(
 	return &TokenInfo{
 		TokenID:     tokenID,
 		Token:       fmt.Sprintf("coral_%s", plainToken), // Add prefix for identification.
-		Permissions: permissions,
-		RateLimit:   rateLimit,
+		User:        stored.User,
+		Role:        stored.Role,
+		Permissions: stored.Permissions,
+		RateLimit:   stored.RateLimit,
 	}, nil
 }
 
+// SetCapabilityVerifier makes ValidateToken also accept the capability
+// tokens verified by v.
+func (ts *TokenStore) SetCapabilityVerifier(v *CapabilityVerifier) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.capabilities = v
+}
+
 // ValidateToken checks a Bearer token and returns the stored token if valid.
-// The token should include the "coral_" prefix.
+// The token should include the "coral_" prefix. Capability tokens are
+// returned as an API token holding the capability.
 func (ts *TokenStore) ValidateToken(token string) (*APIToken, error) {
+	if IsCapabilityToken(token) {
+		ts.mu.RLock()
+		capabilities := ts.capabilities
+		ts.mu.RUnlock()
+		if capabilities == nil {
+			return nil, fmt.Errorf("invalid token")
+		}
+
+		c, err := capabilities.Verify(token)
+		if err != nil {
+			return nil, err
+		}
+		return c.APIToken(), nil
+	}
+
 	// Strip "coral_" prefix if present.
 	plainToken := token
 	if len(token) > 6 && token[:6] == "coral_" {
@@ -267,9 +321,22 @@ func (ts *TokenStore) saveToFile() error {
 	return nil
 }
 
-// HasPermission checks if a token has a specific permission.
+// EffectivePermissions returns the permissions granted to a token directly
+// and through its role, without duplicates.
+func EffectivePermissions(token *APIToken) []Permission {
+	var result []Permission
+	for _, p := range append(token.Role.Permissions(), token.Permissions...) {
+		if !slices.Contains(result, p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// HasPermission checks if a token has a specific permission, either directly
+// or through its role.
 func HasPermission(token *APIToken, required Permission) bool {
-	for _, p := range token.Permissions {
+	for _, p := range EffectivePermissions(token) {
 		// Admin permission grants all permissions.
 		if p == PermissionAdmin {
 			return true
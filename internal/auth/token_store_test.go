@@ -497,3 +497,94 @@ func TestAllPermissions(t *testing.T) {
 		t.Errorf("Missing permissions: %v", expected)
 	}
 }
+
+func TestTokenStore_GenerateUserToken(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "tokens.yaml")
+	ts := NewTokenStore(tmpFile)
+
+	info, err := ts.GenerateUserToken("alice-debug", "alice", RoleDebugger, "")
+	if err != nil {
+		t.Fatalf("GenerateUserToken() error = %v", err)
+	}
+	if info.User != "alice" || info.Role != RoleDebugger {
+		t.Errorf("TokenInfo user/role = %q/%q, want alice/debugger", info.User, info.Role)
+	}
+
+	if _, err := ts.GenerateUserToken("bad", "bob", Role("superuser"), ""); err == nil {
+		t.Error("GenerateUserToken() with invalid role should fail")
+	}
+
+	// Role and user survive a reload from disk.
+	reloaded := NewTokenStore(tmpFile)
+	validated, err := reloaded.ValidateToken(info.Token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if validated.User != "alice" || validated.Role != RoleDebugger {
+		t.Errorf("stored user/role = %q/%q, want alice/debugger", validated.User, validated.Role)
+	}
+	if !HasPermission(validated, PermissionDebug) {
+		t.Error("debugger token should have debug permission")
+	}
+	if HasPermission(validated, PermissionAdmin) {
+		t.Error("debugger token should not have admin permission")
+	}
+}
+
+func TestHasPermission_Role(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Permission
+		want     bool
+	}{
+		{RoleViewer, PermissionStatus, true},
+		{RoleViewer, PermissionQuery, true},
+		{RoleViewer, PermissionAnalyze, false},
+		{RoleViewer, PermissionDebug, false},
+		{RoleDebugger, PermissionAnalyze, true},
+		{RoleDebugger, PermissionDebug, true},
+		{RoleDebugger, PermissionAdmin, false},
+		{RoleAdmin, PermissionDebug, true},
+		{RoleAdmin, PermissionAdmin, true},
+		{Role("unknown"), PermissionStatus, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.required), func(t *testing.T) {
+			// The role is resolved at check time, so editing the role in
+			// tokens.yaml takes effect without reissuing the token.
+			token := &APIToken{TokenID: "test", Role: tt.role}
+
+			got := HasPermission(token, tt.required)
+			if got != tt.want {
+				t.Errorf("HasPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Role
+	}{
+		{"viewer", RoleViewer},
+		{"debugger", RoleDebugger},
+		{"admin", RoleAdmin},
+		{"invalid", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := ParseRole(tt.input)
+			if got != tt.want {
+				t.Errorf("ParseRole(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if len(AllRoles()) != 3 {
+		t.Errorf("AllRoles() returned %d roles, want 3", len(AllRoles()))
+	}
+}
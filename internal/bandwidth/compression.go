@@ -0,0 +1,70 @@
+package bandwidth
+
+import (
+	"io"
+
+	"connectrpc.com/connect"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// Zstd is the name of the zstd compression algorithm in Connect RPCs.
+	Zstd = "zstd"
+
+	// compressMinBytes is the size under which messages are not compressed;
+	// compressing them costs more CPU than it saves bandwidth.
+	compressMinBytes = 1024
+
+	// zstdMaxMemory bounds the memory a decompressed message may take, so a
+	// malicious peer cannot exhaust it with a small compressed message.
+	zstdMaxMemory = 256 << 20
+)
+
+// ClientOptions makes Connect clients accept zstd compressed responses from
+// servers supporting it.
+func ClientOptions() []connect.ClientOption {
+	return []connect.ClientOption{
+		connect.WithAcceptCompression(Zstd, newZstdDecompressor, newZstdCompressor),
+		connect.WithCompressMinBytes(compressMinBytes),
+	}
+}
+
+// HandlerOptions makes Connect handlers accept zstd compressed requests and
+// compress responses with zstd for clients accepting it.
+func HandlerOptions() []connect.HandlerOption {
+	return []connect.HandlerOption{
+		connect.WithCompression(Zstd, newZstdDecompressor, newZstdCompressor),
+		connect.WithCompressMinBytes(compressMinBytes),
+	}
+}
+
+func newZstdCompressor() connect.Compressor {
+	// The options are valid, so NewWriter cannot fail.
+	w, _ := zstd.NewWriter(nil,
+		zstd.WithEncoderLevel(zstd.SpeedFastest),
+		zstd.WithEncoderConcurrency(1))
+	return w
+}
+
+// zstdDecompressor adapts a zstd decoder to connect.Decompressor. Connect
+// pools decompressors and closes them after each message, but a closed
+// decoder cannot be reset, so Close only detaches the source.
+type zstdDecompressor struct {
+	*zstd.Decoder
+}
+
+func newZstdDecompressor() connect.Decompressor {
+	// The options are valid, so NewReader cannot fail.
+	d, _ := zstd.NewReader(nil,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxMemory(zstdMaxMemory))
+	return &zstdDecompressor{Decoder: d}
+}
+
+func (d *zstdDecompressor) Reset(r io.Reader) error {
+	return d.Decoder.Reset(r)
+}
+
+func (d *zstdDecompressor) Close() error {
+	return d.Decoder.Reset(nil)
+}
@@ -0,0 +1,76 @@
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/coral/mesh/v1/meshv1connect"
+)
+
+func TestZstd_RoundTrip(t *testing.T) {
+	payload := bytes.Repeat([]byte("uprobe event "), 1000)
+	c := newZstdCompressor()
+	d := newZstdDecompressor()
+
+	// Connect pools compressors, so each must survive being reused.
+	for i := 0; i < 2; i++ {
+		var compressed bytes.Buffer
+		c.Reset(&compressed)
+		_, err := c.Write(payload)
+		require.NoError(t, err)
+		require.NoError(t, c.Close())
+		assert.Less(t, compressed.Len(), len(payload)/10)
+
+		require.NoError(t, d.Reset(&compressed))
+		decompressed, err := io.ReadAll(d)
+		require.NoError(t, err)
+		require.NoError(t, d.Close())
+		assert.Equal(t, payload, decompressed)
+	}
+}
+
+type heartbeatServer struct {
+	meshv1connect.UnimplementedMeshServiceHandler
+}
+
+func (heartbeatServer) Heartbeat(
+	context.Context,
+	*connect.Request[meshv1.HeartbeatRequest],
+) (*connect.Response[meshv1.HeartbeatResponse], error) {
+	commands := make([]string, 1000)
+	for i := range commands {
+		commands[i] = "refresh"
+	}
+	return connect.NewResponse(&meshv1.HeartbeatResponse{Ok: true, Commands: commands}), nil
+}
+
+func TestZstd_Connect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(meshv1connect.NewMeshServiceHandler(heartbeatServer{}, HandlerOptions()...))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var encoding string
+	httpClient := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := http.DefaultTransport.RoundTrip(req)
+		if err == nil {
+			encoding = resp.Header.Get("Content-Encoding")
+		}
+		return resp, err
+	})}
+
+	client := meshv1connect.NewMeshServiceClient(httpClient, srv.URL, ClientOptions()...)
+	resp, err := client.Heartbeat(context.Background(), connect.NewRequest(&meshv1.HeartbeatRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.Commands, 1000)
+	assert.Equal(t, Zstd, encoding)
+}
@@ -0,0 +1,155 @@
+// Package bandwidth limits and compresses the data-plane transfers between
+// the colony and its agents: poller and profile pulls, and event pushes.
+// Large transfers over the WireGuard mesh can otherwise saturate small links.
+package bandwidth
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// minBurst is the smallest burst of a limiter, so that slow limits still
+// let reads of a reasonable size through.
+const minBurst = 16 * 1024
+
+// Limiter limits transfer rates per agent and across all agents. Agents are
+// identified by host: the host of the URL the colony calls, or the remote
+// host of a request an agent makes. A nil Limiter does not limit.
+type Limiter struct {
+	perAgent rate.Limit
+	global   *rate.Limiter
+
+	mu     sync.Mutex
+	agents map[string]*rate.Limiter
+}
+
+// New creates a limiter of perAgent bytes per second for each agent and
+// global bytes per second for all agents together. Zero disables a limit;
+// New returns nil if both are zero.
+func New(perAgent, global int64) *Limiter {
+	if perAgent <= 0 && global <= 0 {
+		return nil
+	}
+
+	l := &Limiter{
+		perAgent: rate.Inf,
+		agents:   make(map[string]*rate.Limiter),
+	}
+	if perAgent > 0 {
+		l.perAgent = rate.Limit(perAgent)
+	}
+	if global > 0 {
+		l.global = newRateLimiter(rate.Limit(global))
+	}
+	return l
+}
+
+func newRateLimiter(limit rate.Limit) *rate.Limiter {
+	return rate.NewLimiter(limit, max(int(limit), minBurst))
+}
+
+// agent returns the limiter of the agent at host, or nil if agents are not
+// limited individually.
+func (l *Limiter) agent(host string) *rate.Limiter {
+	if l.perAgent == rate.Inf {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lim, ok := l.agents[host]
+	if !ok {
+		lim = newRateLimiter(l.perAgent)
+		l.agents[host] = lim
+	}
+	return lim
+}
+
+// Reader returns r limited to the rates of the agent at host. Reads block
+// until the limits allow them or ctx is done.
+func (l *Limiter) Reader(ctx context.Context, host string, r io.ReadCloser) io.ReadCloser {
+	if l == nil {
+		return r
+	}
+
+	lr := &limitedReader{ctx: ctx, r: r}
+	for _, lim := range []*rate.Limiter{l.agent(host), l.global} {
+		if lim != nil {
+			lr.limiters = append(lr.limiters, lim)
+		}
+	}
+	return lr
+}
+
+type limitedReader struct {
+	ctx      context.Context
+	r        io.ReadCloser
+	limiters []*rate.Limiter
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	// Waits cannot exceed a limiter's burst, so neither can reads.
+	for _, lim := range r.limiters {
+		if len(p) > lim.Burst() {
+			p = p[:lim.Burst()]
+		}
+	}
+
+	n, err := r.r.Read(p)
+	for _, lim := range r.limiters {
+		if n == 0 {
+			break
+		}
+		if werr := lim.WaitN(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+func (r *limitedReader) Close() error {
+	return r.r.Close()
+}
+
+// Transport returns base with response bodies limited to the rates of the
+// agent the request is sent to.
+func (l *Limiter) Transport(base http.RoundTripper) http.RoundTripper {
+	if l == nil {
+		return base
+	}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = l.Reader(req.Context(), req.URL.Hostname(), resp.Body)
+		return resp, nil
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Handler returns next with request bodies limited to the rates of the agent
+// making the request.
+func (l *Limiter) Handler(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		r.Body = l.Reader(r.Context(), host, r.Body)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,78 @@
+package bandwidth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_Unlimited(t *testing.T) {
+	assert.Nil(t, New(0, 0))
+
+	var l *Limiter
+	body := io.NopCloser(bytes.NewReader(nil))
+	assert.Equal(t, body, l.Reader(context.Background(), "agent", body))
+	assert.Equal(t, http.DefaultTransport, l.Transport(http.DefaultTransport))
+}
+
+// readAll reads n bytes through l as the agent at host and returns how long
+// it took.
+func readAll(t *testing.T, l *Limiter, host string, n int) time.Duration {
+	t.Helper()
+
+	start := time.Now()
+	r := l.Reader(context.Background(), host, io.NopCloser(bytes.NewReader(make([]byte, n))))
+	read, err := io.Copy(io.Discard, r)
+	require.NoError(t, err)
+	require.EqualValues(t, n, read)
+	return time.Since(start)
+}
+
+func TestLimiter_PerAgent(t *testing.T) {
+	l := New(64*1024, 0)
+
+	// The first burst is free, the next 32 KiB take half a second.
+	assert.GreaterOrEqual(t, readAll(t, l, "10.0.0.2", 96*1024), 400*time.Millisecond)
+	assert.Less(t, readAll(t, l, "10.0.0.3", 64*1024), 200*time.Millisecond,
+		"agents have separate limits")
+}
+
+func TestLimiter_Global(t *testing.T) {
+	l := New(0, 64*1024)
+
+	readAll(t, l, "10.0.0.2", 64*1024)
+	assert.GreaterOrEqual(t, readAll(t, l, "10.0.0.3", 32*1024), 400*time.Millisecond,
+		"agents share the global limit")
+}
+
+func TestLimiter_ReaderStopsWithContext(t *testing.T) {
+	l := New(minBurst, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	r := l.Reader(ctx, "10.0.0.2", io.NopCloser(bytes.NewReader(make([]byte, 10*minBurst))))
+	_, err := io.Copy(io.Discard, r)
+	assert.Error(t, err)
+}
+
+func TestLimiter_Handler(t *testing.T) {
+	l := New(64*1024, 0)
+	srv := httptest.NewServer(l.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := io.Copy(io.Discard, r.Body)
+		assert.EqualValues(t, 96*1024, n)
+	})))
+	defer srv.Close()
+
+	start := time.Now()
+	resp, err := http.Post(srv.URL, "application/octet-stream", bytes.NewReader(make([]byte, 96*1024)))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
@@ -33,4 +33,7 @@ func addAgentCommands(cmd *cobra.Command) {
 	cmd.AddCommand(NewStatusCmd())
 	cmd.AddCommand(NewBootstrapCmd()) // RFD 048
 	cmd.AddCommand(NewCertCmd())      // RFD 048
+	cmd.AddCommand(NewDebugCmd())
+	cmd.AddCommand(NewProfileCmd())
+	cmd.AddCommand(newCoreDumpHelperCmd())
 }
@@ -0,0 +1,70 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// execAudit describes a shell or exec run for the colony's audit log. These
+// commands talk to agents directly, so the colony only learns of them when
+// the CLI reports them.
+type execAudit struct {
+	colonyID string
+	action   string
+	target   string
+	args     []string
+}
+
+// record reports the outcome of the run. Reporting is best-effort: it is
+// skipped if the colony is unreachable, and the colony discards it unless
+// mcp.security.audit_enabled is set.
+func (a execAudit) record(ctx context.Context, userID string, exitCode int32, execErr error) {
+	client, err := helpers.GetColonyClient(a.colonyID)
+	if err != nil {
+		return
+	}
+
+	req := &colonyv1.RecordAuditEventRequest{
+		Action:        a.action,
+		Target:        a.target,
+		ArgumentsHash: audit.HashArgs(a.args),
+		Result:        audit.ResultOK,
+		User:          userID,
+	}
+	switch {
+	case execErr != nil:
+		req.Result = audit.ResultFailed
+		req.Error = execErr.Error()
+	case exitCode != 0:
+		req.Result = audit.ResultFailed
+		req.Error = fmt.Sprintf("exit code %d", exitCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), constants.DefaultAuditReportTimeout)
+	defer cancel()
+	_, _ = client.RecordAuditEvent(ctx, connect.NewRequest(req))
+}
+
+// agentTarget describes the agent a shell or exec run targets.
+func agentTarget(agentID, agentAddr string) string {
+	if agentID != "" {
+		return "agent=" + agentID
+	}
+	return "agent_addr=" + agentAddr
+}
+
+// responseError converts the error message of an exec response to an error.
+func responseError(msg string) error {
+	if msg == "" {
+		return nil
+	}
+	return errors.New(msg)
+}
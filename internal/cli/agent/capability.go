@@ -0,0 +1,55 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// execCapabilityTTL is the lifetime of the capability tokens minted for a
+// single shell or exec run. Agents check the token when the call starts, so
+// it does not need to outlive an interactive session.
+const execCapabilityTTL = 5 * time.Minute
+
+// capabilityOptions returns the client options presenting a capability token
+// to agents for shell and exec: CORAL_API_TOKEN if it is a capability token,
+// otherwise one minted by the colony for this run, granting debug on service
+// if set or on all services. Minting is best-effort, as agents only require a
+// token with agent.security.require_capability_tokens.
+func capabilityOptions(ctx context.Context, colonyID, service string) []connect.ClientOption {
+	if token := os.Getenv("CORAL_API_TOKEN"); auth.IsCapabilityToken(token) {
+		return []connect.ClientOption{helpers.WithBearerToken(token)}
+	}
+
+	client, err := helpers.GetColonyClient(colonyID)
+	if err != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, colonyProbeTimeout)
+	defer cancel()
+	resp, err := client.MintCapabilityToken(ctx, connect.NewRequest(&colonyv1.MintCapabilityTokenRequest{
+		Scopes: []string{debugScope(service)},
+		Ttl:    durationpb.New(execCapabilityTTL),
+	}))
+	if err != nil {
+		return nil
+	}
+	return []connect.ClientOption{helpers.WithBearerToken(resp.Msg.Token)}
+}
+
+// debugScope returns the capability scope granting debug on service, or on
+// all services if service is empty.
+func debugScope(service string) string {
+	if service == "" {
+		return string(auth.PermissionDebug)
+	}
+	return string(auth.PermissionDebug) + ":" + service
+}
@@ -0,0 +1,24 @@
+package agent
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/coral-mesh/coral/internal/agent/coredump"
+)
+
+// newCoreDumpHelperCmd creates the hidden command the kernel runs through
+// core_pattern to hand a core dump to the agent.
+func newCoreDumpHelperCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          coredump.HelperCommand + " <spool-dir> <max-bytes> <pid> <time> <signal>",
+		Short:        "Receive a core dump from the kernel (internal)",
+		Hidden:       true,
+		Args:         cobra.ExactArgs(5),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return coredump.RunHelper(args, os.Stdin)
+		},
+	}
+}
@@ -0,0 +1,326 @@
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// NewCpCmd creates the cp command copying files to and from agents.
+func NewCpCmd() *cobra.Command {
+	var (
+		agentAddr string
+		colony    string
+		userID    string
+		maxSizeMB int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cp SRC DST",
+		Short: "Copy files to and from agent hosts",
+		Long: `Copy a file between the local machine and an agent host.
+
+One of SRC and DST is a file on an agent, written AGENT-ID:PATH with an
+absolute PATH. The file is streamed over the mesh in chunks and verified with
+its SHA-256 checksum; nothing is written at the destination unless the
+checksum matches. If DST is a directory, or a remote path ending with '/',
+the file keeps its name.
+
+Agents copy files of at most 1 GiB (agent.security.max_file_transfer_bytes).
+Copies are audited like 'coral exec' and need a capability token granting
+debug on agents with agent.security.require_capability_tokens.
+
+Examples:
+  # Pull a log file
+  coral cp hostname-api-1:/var/log/app.log ./
+
+  # Pull a core dump captured by the agent
+  coral cp hostname-api-1:/root/.coral/agent/coredumps/1767225600-4242.core.gz ./api.core.gz
+
+  # Push a config file
+  coral cp ./app.yaml hostname-api-1:/etc/app/
+
+  # Refuse files over 100 MiB
+  coral cp hostname-api-1:/tmp/heap.pprof . --max-size 100`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			srcAgent, srcPath, srcRemote := splitRemotePath(args[0])
+			dstAgent, dstPath, dstRemote := splitRemotePath(args[1])
+			if srcRemote == dstRemote {
+				return fmt.Errorf("exactly one of SRC and DST must be a file on an agent (AGENT-ID:PATH)")
+			}
+			agent := srcAgent
+			if dstRemote {
+				agent = dstAgent
+			}
+
+			if agentAddr == "" {
+				resolvedAddr, err := resolveAgentID(ctx, agent, colony)
+				if err != nil {
+					return fmt.Errorf("failed to resolve agent ID: %w", err)
+				}
+				agentAddr = resolvedAddr
+			}
+			if userID == "" {
+				userID = resolveUserID()
+			}
+			maxBytes := maxSizeMB * 1024 * 1024
+
+			client := newStreamingAgentClient(agentAddr, capabilityOptions(ctx, colony, "")...)
+			rec := execAudit{
+				colonyID: colony,
+				target:   agentTarget(agent, agentAddr),
+			}
+
+			var (
+				result *copyResult
+				err    error
+			)
+			if srcRemote {
+				rec.action, rec.args = "ReadFile", []string{srcPath}
+				result, err = copyFromAgent(ctx, client, srcPath, dstPath, userID, maxBytes)
+			} else {
+				rec.action, rec.args = "WriteFile", []string{dstPath}
+				result, err = copyToAgent(ctx, client, srcPath, dstPath, userID, maxBytes)
+			}
+			rec.record(ctx, userID, 0, err)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ Copied %s to %s (%d bytes, sha256 %s)\n", result.src, result.dst, result.bytes, result.sha256)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&agentAddr, "agent-addr", "", "Agent address (default: resolve the agent ID via colony)")
+	cmd.Flags().StringVar(&colony, "colony", "", "Colony ID (default: auto-detect)")
+	cmd.Flags().StringVar(&userID, "user-id", "", "User ID for audit (default: $USER)")
+	cmd.Flags().Int64Var(&maxSizeMB, "max-size", 0, "Refuse files larger than this many MiB (default: the agent's limit)")
+
+	return cmd
+}
+
+// copyResult describes a completed copy.
+type copyResult struct {
+	src    string
+	dst    string
+	bytes  int64
+	sha256 string
+}
+
+// splitRemotePath splits an AGENT-ID:PATH argument of coral cp. Arguments
+// whose part before the first colon contains a path separator are local
+// paths, so ./a:b stays local.
+func splitRemotePath(arg string) (agent, p string, remote bool) {
+	i := strings.Index(arg, ":")
+	if i <= 0 || strings.ContainsAny(arg[:i], `/\`) {
+		return "", arg, false
+	}
+	return arg[:i], arg[i+1:], true
+}
+
+// copyFromAgent copies the file remotePath of the agent to localPath. The
+// content goes to a temporary file that replaces localPath once the
+// checksum matches.
+func copyFromAgent(
+	ctx context.Context,
+	client agentv1connect.AgentServiceClient,
+	remotePath, localPath, userID string,
+	maxBytes int64,
+) (*copyResult, error) {
+	stream, err := client.ReadFile(ctx, connect.NewRequest(&agentv1.ReadFileRequest{
+		Path:     remotePath,
+		UserId:   userID,
+		MaxBytes: maxBytes,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy %s: %w", remotePath, err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, fmt.Errorf("failed to copy %s: %w", remotePath, err)
+		}
+		return nil, fmt.Errorf("failed to copy %s: agent sent no file", remotePath)
+	}
+	info := stream.Msg().GetInfo()
+	if info == nil {
+		return nil, fmt.Errorf("failed to copy %s: agent sent no file info", remotePath)
+	}
+
+	dst := localDestination(localPath, path.Base(info.Path))
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".coral-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+	committed := false
+	defer func() {
+		_ = tmp.Close()
+		if !committed {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	hash := sha256.New()
+	var (
+		total    int64
+		expected string
+	)
+	for stream.Receive() {
+		switch payload := stream.Msg().Payload.(type) {
+		case *agentv1.ReadFileResponse_Data:
+			total += int64(len(payload.Data))
+			hash.Write(payload.Data)
+			if _, err := tmp.Write(payload.Data); err != nil {
+				return nil, fmt.Errorf("failed to write %s: %w", dst, err)
+			}
+		case *agentv1.ReadFileResponse_Sha256:
+			expected = payload.Sha256
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, fmt.Errorf("failed to copy %s: %w", remotePath, err)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if expected == "" {
+		return nil, fmt.Errorf("failed to copy %s: agent sent no checksum", remotePath)
+	}
+	if sum != expected {
+		return nil, fmt.Errorf("failed to copy %s: checksum mismatch: got %s, want %s", remotePath, sum, expected)
+	}
+
+	if mode := os.FileMode(info.Mode).Perm(); mode != 0 {
+		if err := tmp.Chmod(mode); err != nil {
+			return nil, fmt.Errorf("failed to set mode of %s: %w", dst, err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", dst, err)
+	}
+	committed = true
+
+	return &copyResult{src: info.Path, dst: dst, bytes: total, sha256: sum}, nil
+}
+
+// copyToAgent copies the local file localPath to remotePath on the agent,
+// followed by its checksum.
+func copyToAgent(
+	ctx context.Context,
+	client agentv1connect.AgentServiceClient,
+	localPath, remotePath, userID string,
+	maxBytes int64,
+) (*copyResult, error) {
+	f, err := os.Open(localPath) // #nosec G304 - path is chosen by the user.
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !stat.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", localPath)
+	}
+	if maxBytes > 0 && stat.Size() > maxBytes {
+		return nil, fmt.Errorf("%s is %d bytes, over the %d byte limit", localPath, stat.Size(), maxBytes)
+	}
+	if strings.HasSuffix(remotePath, "/") {
+		remotePath += filepath.Base(localPath)
+	}
+
+	stream := client.WriteFile(ctx)
+	send := func(req *agentv1.WriteFileRequest) error {
+		err := stream.Send(req)
+		if errors.Is(err, io.EOF) {
+			// The agent ended the stream; its error comes with the response.
+			_, err = stream.CloseAndReceive()
+		}
+		if err != nil {
+			return fmt.Errorf("failed to copy to %s: %w", remotePath, err)
+		}
+		return nil
+	}
+
+	if err := send(&agentv1.WriteFileRequest{
+		Payload: &agentv1.WriteFileRequest_Start{Start: &agentv1.WriteFileStart{
+			Info: &agentv1.FileInfo{
+				Path: remotePath,
+				Size: stat.Size(),
+				Mode: uint32(stat.Mode().Perm()),
+			},
+			UserId: userID,
+		}},
+	}); err != nil {
+		return nil, err
+	}
+
+	// Send the size announced in the start message, even if the file grows.
+	hash := sha256.New()
+	r := io.LimitReader(f, stat.Size())
+	buf := make([]byte, constants.FileTransferChunkSize)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			hash.Write(buf[:n])
+			if err := send(&agentv1.WriteFileRequest{
+				Payload: &agentv1.WriteFileRequest_Data{Data: buf[:n]},
+			}); err != nil {
+				return nil, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", localPath, err)
+		}
+	}
+
+	if err := send(&agentv1.WriteFileRequest{
+		Payload: &agentv1.WriteFileRequest_Sha256{Sha256: hex.EncodeToString(hash.Sum(nil))},
+	}); err != nil {
+		return nil, err
+	}
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy to %s: %w", remotePath, err)
+	}
+
+	return &copyResult{src: localPath, dst: resp.Msg.Path, bytes: resp.Msg.BytesWritten, sha256: resp.Msg.Sha256}, nil
+}
+
+// localDestination returns where a file named name copied to localPath goes:
+// into localPath if it is a directory, otherwise localPath itself.
+func localDestination(localPath, name string) string {
+	if strings.HasSuffix(localPath, string(os.PathSeparator)) {
+		return filepath.Join(localPath, name)
+	}
+	if stat, err := os.Stat(localPath); err == nil && stat.IsDir() {
+		return filepath.Join(localPath, name)
+	}
+	return localPath
+}
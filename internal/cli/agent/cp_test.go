@@ -0,0 +1,139 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	"github.com/coral-mesh/coral/internal/agent"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// newFileTransferClient serves the file transfer RPCs of an agent limited to
+// maxBytes.
+func newFileTransferClient(t *testing.T, maxBytes int64) agentv1connect.AgentServiceClient {
+	t.Helper()
+
+	serviceHandler := agent.NewServiceHandler(nil, nil, nil, nil, nil, nil, nil)
+	serviceHandler.SetFileHandler(agent.NewFileHandler(maxBytes, zerolog.Nop()))
+	_, h := agentv1connect.NewAgentServiceHandler(serviceHandler)
+	srv := httptest.NewUnstartedServer(h)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return agentv1connect.NewAgentServiceClient(srv.Client(), srv.URL)
+}
+
+func TestSplitRemotePath(t *testing.T) {
+	tests := []struct {
+		arg    string
+		agent  string
+		path   string
+		remote bool
+	}{
+		{arg: "agent-1:/var/log/app.log", agent: "agent-1", path: "/var/log/app.log", remote: true},
+		{arg: "./app.log", path: "./app.log"},
+		{arg: "./a:b", path: "./a:b"},
+		{arg: ":/tmp", path: ":/tmp"},
+	}
+	for _, tt := range tests {
+		agent, path, remote := splitRemotePath(tt.arg)
+		assert.Equal(t, tt.agent, agent, tt.arg)
+		assert.Equal(t, tt.path, path, tt.arg)
+		assert.Equal(t, tt.remote, remote, tt.arg)
+	}
+}
+
+func TestCopyRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := newFileTransferClient(t, 0)
+
+	// Several chunks, the last one partial.
+	content := make([]byte, 2*constants.FileTransferChunkSize+123)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+
+	local := filepath.Join(t.TempDir(), "heap.pprof")
+	require.NoError(t, os.WriteFile(local, content, 0o640))
+	remoteDir := t.TempDir()
+
+	up, err := copyToAgent(ctx, client, local, remoteDir+"/", "alice", 0)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(remoteDir, "heap.pprof"), up.dst)
+	assert.Equal(t, int64(len(content)), up.bytes)
+
+	written, err := os.ReadFile(up.dst)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(content, written))
+	stat, err := os.Stat(up.dst)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), stat.Mode().Perm())
+
+	localDir := t.TempDir()
+	down, err := copyFromAgent(ctx, client, up.dst, localDir, "alice", 0)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(localDir, "heap.pprof"), down.dst)
+	assert.Equal(t, up.sha256, down.sha256)
+
+	read, err := os.ReadFile(down.dst)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(content, read))
+}
+
+func TestCopyFromAgent_Errors(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := newFileTransferClient(t, 10)
+
+	dir := t.TempDir()
+	big := filepath.Join(dir, "big.log")
+	require.NoError(t, os.WriteFile(big, []byte("more than ten bytes"), 0o600))
+	dst := filepath.Join(t.TempDir(), "big.log")
+
+	_, err := copyFromAgent(ctx, client, big, dst, "alice", 0)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err), "files over the agent's limit are refused")
+	assert.NoFileExists(t, dst)
+
+	_, err = copyFromAgent(ctx, client, filepath.Join(dir, "missing.log"), dst, "alice", 0)
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = copyFromAgent(ctx, client, "big.log", dst, "alice", 0)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "relative paths are refused")
+
+	_, err = copyFromAgent(ctx, client, dir, dst, "alice", 0)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "directories are refused")
+
+	entries, err := os.ReadDir(filepath.Dir(dst))
+	require.NoError(t, err)
+	assert.Empty(t, entries, "failed copies leave no temporary files")
+}
+
+func TestCopyToAgent_OverLimit(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client := newFileTransferClient(t, 10)
+
+	local := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, os.WriteFile(local, []byte("more than ten bytes"), 0o600))
+	remoteDir := t.TempDir()
+
+	_, err := copyToAgent(ctx, client, local, remoteDir+"/", "alice", 0)
+	assert.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+
+	entries, err := os.ReadDir(remoteDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
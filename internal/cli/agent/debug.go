@@ -0,0 +1,259 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+)
+
+// defaultLocalAgentURL is the agent API address on the local host.
+const defaultLocalAgentURL = "http://localhost:9001"
+
+// NewDebugCmd creates the local debug command. Unlike 'coral debug', these
+// commands talk to the local agent directly and work without a colony.
+func NewDebugCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Debug services through the local agent (no colony required)",
+		Long: `Debug services through the local agent without a colony.
+
+These commands talk directly to the agent API on this host, which makes them
+usable on air-gapped hosts or while the mesh is down. Collected events are
+persisted in the agent's local DuckDB and can be read back later with
+'debug events'.`,
+	}
+
+	cmd.AddCommand(newDebugAttachCmd())
+	cmd.AddCommand(newDebugEventsCmd())
+
+	return cmd
+}
+
+func newDebugAttachCmd() *cobra.Command {
+	var (
+		functionName string
+		duration     time.Duration
+		sdkAddr      string
+		agentURL     string
+		format       string
+
+		// Kernel-level filter flags (RFD 090).
+		minDuration time.Duration
+		maxDuration time.Duration
+		filterRate  uint32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "attach <service>",
+		Short: "Attach uprobe to a function via the local agent",
+		Long: `Attach a uprobe to a function of a local service and stream its events.
+
+The probe is detached when the duration elapses or on Ctrl+C. Events are kept
+in the agent's local DuckDB and remain queryable with 'debug events'.
+
+Examples:
+  # Trace a function for 30 seconds
+  coral-agent debug attach api --function main.handleCheckout --duration 30s
+
+  # Only show calls slower than 50ms
+  coral-agent debug attach api --function main.handleCheckout --min-duration 50ms`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serviceName := args[0]
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			client := agentv1connect.NewAgentDebugServiceClient(http.DefaultClient, agentURL)
+
+			req := &agentv1.StartUprobeCollectorRequest{
+				ServiceName:  serviceName,
+				FunctionName: functionName,
+				Duration:     durationpb.New(duration),
+				SdkAddr:      sdkAddr,
+			}
+
+			// Attach kernel-level filter if any filter flag was provided (RFD 090).
+			if minDuration > 0 || maxDuration > 0 || filterRate > 1 {
+				req.Filter = &agentv1.UprobeFilter{
+					MinDurationNs: uint64(minDuration.Nanoseconds()), // #nosec G115 - flag values are non-negative.
+					MaxDurationNs: uint64(maxDuration.Nanoseconds()), // #nosec G115 - flag values are non-negative.
+					SampleRate:    filterRate,
+				}
+			}
+
+			resp, err := client.StartUprobeCollector(ctx, connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to attach uprobe: %w\n\nIs the agent running?", err)
+			}
+			if resp.Msg.Error != "" {
+				return fmt.Errorf("failed to attach uprobe: %s", resp.Msg.Error)
+			}
+
+			collectorID := resp.Msg.CollectorId
+			fmt.Fprintf(os.Stderr, "Attached to %s/%s (collector %s), press Ctrl+C to detach\n",
+				serviceName, functionName, collectorID)
+
+			streamErr := streamUprobeEvents(ctx, client, collectorID, resp.Msg.ExpiresAt.AsTime(), format)
+
+			// Detach with a fresh context, the command context may be cancelled.
+			stopCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := client.StopUprobeCollector(stopCtx, connect.NewRequest(&agentv1.StopUprobeCollectorRequest{
+				CollectorId: collectorID,
+			})); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to detach uprobe: %v\n", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "Detached. Read events again with: coral-agent debug events %s\n", collectorID)
+
+			return streamErr
+		},
+	}
+
+	cmd.Flags().StringVarP(&functionName, "function", "f", "", "Function name to trace (required)")
+	cmd.Flags().DurationVarP(&duration, "duration", "d", 60*time.Second, "Duration of the debug session")
+	cmd.Flags().StringVar(&sdkAddr, "sdk-addr", "", "SDK address of the service (resolved by the agent if empty)")
+	cmd.Flags().StringVar(&agentURL, "agent-url", defaultLocalAgentURL, "Local agent API URL")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+
+	// Kernel-level filter flags (RFD 090).
+	cmd.Flags().DurationVar(&minDuration, "min-duration", 0, "Only emit events slower than this threshold (e.g. 50ms)")
+	cmd.Flags().DurationVar(&maxDuration, "max-duration", 0, "Only emit events faster than this threshold (e.g. 500ms)")
+	cmd.Flags().Uint32Var(&filterRate, "filter-rate", 0, "Emit 1 in every N events at kernel level (0 or 1 = all)")
+
+	cmd.MarkFlagRequired("function") //nolint:errcheck
+
+	return cmd
+}
+
+func newDebugEventsCmd() *cobra.Command {
+	var (
+		maxEvents int32
+		since     time.Duration
+		agentURL  string
+		format    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "events <collector-id>",
+		Short: "Read uprobe events stored by the local agent",
+		Long: `Read uprobe events for a collector from the local agent.
+
+Events are served from the agent's local DuckDB once the collector has
+stopped, including across agent restarts.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			client := agentv1connect.NewAgentDebugServiceClient(http.DefaultClient, agentURL)
+
+			req := &agentv1.QueryUprobeEventsRequest{
+				CollectorId: args[0],
+				MaxEvents:   maxEvents,
+			}
+			if since > 0 {
+				req.StartTime = timestamppb.New(time.Now().Add(-since))
+			}
+
+			resp, err := client.QueryUprobeEvents(ctx, connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to query events: %w", err)
+			}
+
+			for _, event := range resp.Msg.Events {
+				printUprobeEvent(event, format)
+			}
+			if resp.Msg.HasMore {
+				fmt.Fprintf(os.Stderr, "More events available, increase --max to see them\n")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int32Var(&maxEvents, "max", 1000, "Max events to retrieve")
+	cmd.Flags().DurationVar(&since, "since", 0, "Show events since duration (e.g. 5m)")
+	cmd.Flags().StringVar(&agentURL, "agent-url", defaultLocalAgentURL, "Local agent API URL")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+
+	return cmd
+}
+
+// streamUprobeEvents polls the agent for new events until ctx is done or the
+// collector expires.
+func streamUprobeEvents(
+	ctx context.Context,
+	client agentv1connect.AgentDebugServiceClient,
+	collectorID string,
+	expiresAt time.Time,
+	format string,
+) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	var last *timestamppb.Timestamp
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		resp, err := client.QueryUprobeEvents(ctx, connect.NewRequest(&agentv1.QueryUprobeEventsRequest{
+			CollectorId: collectorID,
+			StartTime:   last,
+		}))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to query events: %w", err)
+		}
+
+		for _, event := range resp.Msg.Events {
+			// StartTime is inclusive, skip events already printed.
+			if last != nil && !event.Timestamp.AsTime().After(last.AsTime()) {
+				continue
+			}
+			printUprobeEvent(event, format)
+			last = event.Timestamp
+		}
+
+		if time.Now().After(expiresAt) {
+			return nil
+		}
+	}
+}
+
+// printUprobeEvent prints a single uprobe event.
+func printUprobeEvent(event *agentv1.UprobeEvent, format string) {
+	if format == "json" {
+		data, _ := json.Marshal(event)
+		fmt.Println(string(data))
+		return
+	}
+
+	ts := event.Timestamp.AsTime().Format(time.RFC3339Nano)
+	switch event.EventType {
+	case "return":
+		fmt.Printf("[%s] %s pid=%d duration=%s\n", ts, event.FunctionName, event.Pid,
+			time.Duration(event.DurationNs)) // #nosec G115 - unlikely to ever overflow by design
+	default:
+		fmt.Printf("[%s] %s pid=%d %s\n", ts, event.FunctionName, event.Pid, event.EventType)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 )
@@ -25,10 +26,13 @@ func NewExecCmd() *cobra.Command {
 		workingDir    string
 		env           []string
 		namespaces    []string
+		interactive   bool
+		tty           bool
+		service       string
 	)
 
 	cmd := &cobra.Command{
-		Use:   "exec SERVICE [command...]",
+		Use:   "exec SERVICE [command...] | exec -it (--agent ID | --service SERVICE) [-- command...]",
 		Short: "Execute command in a service's container",
 		Long: `Execute a command in a service's container using nsenter.
 
@@ -46,6 +50,12 @@ Use coral exec to access files that only exist in the container's view:
 
 All executions are fully audited with session IDs.
 
+With -it, the command runs in a terminal on the agent, with your input
+streamed to it: in the container of --service, or on the agent host if only
+--agent is given. The command defaults to /bin/sh in a container and to the
+agent's shell on the host. Interactive sessions are recorded and can be
+replayed with 'coral debug recordings'.
+
 Examples:
   # Read nginx config from service container
   coral exec nginx cat /etc/nginx/nginx.conf
@@ -71,6 +81,12 @@ Examples:
   # Longer timeout for slow commands
   coral exec logs-processor --timeout 60 find /data -name "*.log"
 
+  # Interactive shell in the api container
+  coral exec -it --agent hostname-api-1 --service api -- /bin/sh
+
+  # Interactive shell on the agent host
+  coral exec -it --agent hostname-api-1
+
 Requirements:
   - Agent must have CAP_SYS_ADMIN and CAP_SYS_PTRACE capabilities
   - Agent must share PID namespace with container (sidecar) or use hostPID (node agent)
@@ -78,6 +94,25 @@ Requirements:
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
+			if interactive || tty {
+				if !interactive || !tty {
+					return fmt.Errorf("interactive exec requires both -i and -t (use -it)")
+				}
+				return runInteractiveExec(ctx, interactiveExec{
+					agentAddr:     agentAddr,
+					agent:         agent,
+					colony:        colony,
+					userID:        userID,
+					service:       service,
+					containerName: containerName,
+					namespaces:    namespaces,
+					command:       args,
+				})
+			}
+			if service != "" {
+				return fmt.Errorf("--service is only used with -it; pass the service as the first argument")
+			}
+
 			// Parse service name and command from args.
 			var serviceName string
 			var command []string
@@ -115,7 +150,13 @@ Requirements:
 			}
 
 			// Execute container command.
-			return runContainerExecution(ctx, agentAddr, userID, containerName, command, timeout, workingDir, env, namespaces)
+			rec := execAudit{
+				colonyID: colony,
+				action:   "ContainerExec",
+				target:   "service=" + serviceName + " " + agentTarget(agent, agentAddr),
+				args:     command,
+			}
+			return runContainerExecution(ctx, agentAddr, userID, containerName, command, timeout, workingDir, env, namespaces, rec)
 		},
 	}
 
@@ -128,10 +169,75 @@ Requirements:
 	cmd.Flags().StringVar(&workingDir, "working-dir", "", "Working directory in container")
 	cmd.Flags().StringArrayVar(&env, "env", nil, "Environment variables (KEY=VALUE)")
 	cmd.Flags().StringSliceVar(&namespaces, "namespaces", []string{"mnt"}, "Namespaces to enter (mnt,pid,net,ipc,uts,cgroup)")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Stream stdin to the command (use with -t)")
+	cmd.Flags().BoolVarP(&tty, "tty", "t", false, "Run the command in a terminal (use with -i)")
+	cmd.Flags().StringVar(&service, "service", "", "Service whose container to enter with -it (default: agent host)")
 
 	return cmd
 }
 
+// interactiveExec holds the options of coral exec -it.
+type interactiveExec struct {
+	agentAddr     string
+	agent         string
+	colony        string
+	userID        string
+	service       string
+	containerName string
+	namespaces    []string
+	command       []string
+}
+
+// runInteractiveExec runs a command in a terminal on an agent host, or in a
+// service container if a service is given.
+func runInteractiveExec(ctx context.Context, opts interactiveExec) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return fmt.Errorf("interactive exec requires a terminal on stdin")
+	}
+
+	agentAddr := opts.agentAddr
+	switch {
+	case opts.agent != "":
+		if agentAddr != "" {
+			return fmt.Errorf("cannot specify both --agent and --agent-addr")
+		}
+		resolvedAddr, err := resolveAgentID(ctx, opts.agent, opts.colony)
+		if err != nil {
+			return fmt.Errorf("failed to resolve agent ID: %w", err)
+		}
+		agentAddr = resolvedAddr
+	case agentAddr != "":
+	case opts.service != "":
+		resolvedAddr, err := resolveServiceToAgent(ctx, opts.service, opts.colony)
+		if err != nil {
+			return fmt.Errorf("failed to resolve service '%s': %w\n\nTip: Use --agent <agent-id> to target a specific agent", opts.service, err)
+		}
+		agentAddr = resolvedAddr
+	default:
+		return fmt.Errorf("--agent or --service required\n\nUsage: coral exec -it (--agent ID | --service SERVICE) [-- command...]")
+	}
+
+	start := &agentv1.ShellStart{
+		UserId:  opts.userID,
+		Command: opts.command,
+	}
+	target := agentTarget(opts.agent, agentAddr)
+	if opts.service != "" {
+		start.InContainer = true
+		start.ContainerName = opts.containerName
+		start.Namespaces = opts.namespaces
+		target = "service=" + opts.service + " " + target
+	}
+
+	rec := execAudit{
+		colonyID: opts.colony,
+		action:   "ExecInteractive",
+		target:   target,
+		args:     opts.command,
+	}
+	return runShellSession(ctx, agentAddr, start, true, rec, capabilityOptions(ctx, opts.colony, opts.service))
+}
+
 // runContainerExecution executes a command in a container's namespace (RFD 056).
 func runContainerExecution(
 	ctx context.Context,
@@ -141,6 +247,7 @@ func runContainerExecution(
 	workingDir string,
 	envVars []string,
 	namespaces []string,
+	rec execAudit,
 ) error {
 	// Get current user if not specified.
 	if userID == "" {
@@ -168,7 +275,7 @@ func runContainerExecution(
 		envMap[parts[0]] = parts[1]
 	}
 
-	client := newAgentClient(agentAddr)
+	client := newAgentClient(agentAddr, capabilityOptions(ctx, rec.colonyID, "")...)
 
 	// Prepare request.
 	req := &agentv1.ContainerExecRequest{
@@ -199,8 +306,10 @@ func runContainerExecution(
 
 	resp, err := client.ContainerExec(execCtx, connect.NewRequest(req))
 	if err != nil {
+		rec.record(ctx, userID, 0, err)
 		return fmt.Errorf("failed to execute command in container: %w", err)
 	}
+	rec.record(ctx, userID, resp.Msg.ExitCode, responseError(resp.Msg.Error))
 
 	// Show container metadata if verbose.
 	if os.Getenv("CORAL_VERBOSE") != "" {
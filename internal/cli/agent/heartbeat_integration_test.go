@@ -24,6 +24,8 @@ import (
 
 // mockColonyServer simulates a colony that receives heartbeats.
 type mockColonyServer struct {
+	meshv1connect.UnimplementedMeshServiceHandler
+
 	mu                sync.Mutex
 	heartbeats        []string // Agent IDs that sent heartbeats.
 	shouldFail        bool
@@ -0,0 +1,183 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+)
+
+// NewProfileCmd creates the local profile command. Unlike 'coral profile',
+// it talks to the local agent directly and works without a colony.
+func NewProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Profile services through the local agent (no colony required)",
+	}
+
+	cmd.AddCommand(newProfileCPUCmd())
+
+	return cmd
+}
+
+func newProfileCPUCmd() *cobra.Command {
+	var (
+		serviceName     string
+		pid             int32
+		durationSeconds int32
+		frequencyHz     int32
+		agentURL        string
+		format          string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cpu",
+		Short: "Collect CPU profile via the local agent",
+		Long: `Collect CPU profile samples for a local service using the local agent.
+
+The profile is printed and also stored in the agent's local DuckDB
+(tables cpu_profiles_local and cpu_profile_stacks_local) so it can be
+inspected later with 'coral-agent duckdb', even without a colony.
+
+Examples:
+  # Capture 30s CPU profile of a connected service
+  coral-agent profile cpu --service api --duration 30
+
+  # Profile an arbitrary process by PID
+  coral-agent profile cpu --pid 4242 --duration 10
+
+  # Generate flamegraph (requires flamegraph.pl)
+  coral-agent profile cpu --service api --format folded | flamegraph.pl > cpu.svg`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if serviceName == "" && pid == 0 {
+				return fmt.Errorf("either --service or --pid is required")
+			}
+
+			// Validate duration.
+			if durationSeconds <= 0 {
+				durationSeconds = 30 // Default 30 seconds
+			}
+			if durationSeconds > 300 {
+				return fmt.Errorf("duration cannot exceed 300 seconds")
+			}
+
+			// Validate frequency.
+			if frequencyHz <= 0 {
+				frequencyHz = 99 // Default 99Hz
+			}
+			if frequencyHz > 1000 {
+				return fmt.Errorf("frequency cannot exceed 1000Hz")
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(durationSeconds+60)*time.Second)
+			defer cancel()
+
+			if pid == 0 {
+				resolved, err := resolveLocalServicePID(ctx, agentURL, serviceName)
+				if err != nil {
+					return err
+				}
+				pid = resolved
+			}
+
+			fmt.Fprintf(os.Stderr, "Profiling CPU for pid %d (%ds at %dHz)...\n", pid, durationSeconds, frequencyHz)
+
+			client := agentv1connect.NewAgentDebugServiceClient(http.DefaultClient, agentURL)
+			resp, err := client.ProfileCPU(ctx, connect.NewRequest(&agentv1.ProfileCPUAgentRequest{
+				ServiceName:     serviceName,
+				Pid:             pid,
+				DurationSeconds: durationSeconds,
+				FrequencyHz:     frequencyHz,
+				StoreLocally:    true,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to collect CPU profile: %w\n\nIs the agent running?", err)
+			}
+			if !resp.Msg.Success {
+				return fmt.Errorf("CPU profiling failed: %s", resp.Msg.Error)
+			}
+
+			if resp.Msg.ProfileId != "" {
+				fmt.Fprintf(os.Stderr, "Stored locally as profile %s\n", resp.Msg.ProfileId)
+			} else if resp.Msg.Error != "" {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", resp.Msg.Error)
+			}
+
+			if format == "json" {
+				data, err := json.MarshalIndent(resp.Msg, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal profile: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			printLocalCPUProfileFolded(resp.Msg)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (resolved to a PID by the agent)")
+	cmd.Flags().Int32Var(&pid, "pid", 0, "Target process ID (overrides --service)")
+	cmd.Flags().Int32VarP(&durationSeconds, "duration", "d", 30, "Profiling duration in seconds (default: 30s, max: 300s)")
+	cmd.Flags().Int32Var(&frequencyHz, "frequency", 99, "Sampling frequency in Hz (default: 99Hz, max: 1000Hz)")
+	cmd.Flags().StringVar(&agentURL, "agent-url", defaultLocalAgentURL, "Local agent API URL")
+	cmd.Flags().StringVar(&format, "format", "folded", "Output format: folded (default), json")
+
+	return cmd
+}
+
+// resolveLocalServicePID looks up the PID of a service connected to the local agent.
+func resolveLocalServicePID(ctx context.Context, agentURL, serviceName string) (int32, error) {
+	client := agentv1connect.NewAgentServiceClient(http.DefaultClient, agentURL)
+
+	resp, err := client.ListServices(ctx, connect.NewRequest(&agentv1.ListServicesRequest{}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list services: %w\n\nIs the agent running?", err)
+	}
+
+	for _, svc := range resp.Msg.Services {
+		if svc.Name != serviceName {
+			continue
+		}
+		if svc.ProcessId == 0 {
+			return 0, fmt.Errorf("PID of service %s is unknown, use --pid", serviceName)
+		}
+		return svc.ProcessId, nil
+	}
+
+	return 0, fmt.Errorf("service %s is not connected to the local agent", serviceName)
+}
+
+// printLocalCPUProfileFolded prints the profile in folded stack format.
+func printLocalCPUProfileFolded(profile *agentv1.ProfileCPUAgentResponse) {
+	// Print summary to stderr.
+	fmt.Fprintf(os.Stderr, "Total samples: %d\n", profile.TotalSamples)
+	if profile.LostSamples > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: Lost %d samples due to map overflow\n", profile.LostSamples)
+	}
+	fmt.Fprintf(os.Stderr, "Unique stacks: %d\n\n", len(profile.Samples))
+
+	// Print folded stacks to stdout (for piping to flamegraph.pl).
+	// Reverse the order since BPF captures innermost first.
+	for _, sample := range profile.Samples {
+		if len(sample.FrameNames) == 0 {
+			continue
+		}
+
+		frames := make([]string, len(sample.FrameNames))
+		for i, frame := range sample.FrameNames {
+			frames[len(frames)-1-i] = frame
+		}
+		fmt.Printf("%s %d\n", strings.Join(frames, ";"), sample.Count)
+	}
+}
@@ -34,13 +34,13 @@ func normalizeAgentAddress(addr string) string {
 
 // newAgentClient creates an AgentServiceClient for addr using http.DefaultClient.
 // Any existing http:// or https:// scheme in addr is stripped before prepending http://.
-func newAgentClient(addr string) agentv1connect.AgentServiceClient {
-	return agentv1connect.NewAgentServiceClient(http.DefaultClient, fmt.Sprintf("http://%s", normalizeAgentAddress(addr)))
+func newAgentClient(addr string, opts ...connect.ClientOption) agentv1connect.AgentServiceClient {
+	return agentv1connect.NewAgentServiceClient(http.DefaultClient, fmt.Sprintf("http://%s", normalizeAgentAddress(addr)), opts...)
 }
 
 // newStreamingAgentClient creates an AgentServiceClient backed by an HTTP/2
 // cleartext (h2c) transport, required for bidirectional streaming RPCs.
-func newStreamingAgentClient(addr string) agentv1connect.AgentServiceClient {
+func newStreamingAgentClient(addr string, opts ...connect.ClientOption) agentv1connect.AgentServiceClient {
 	httpClient := &http.Client{
 		Transport: &http2.Transport{
 			AllowHTTP: true,
@@ -51,7 +51,7 @@ func newStreamingAgentClient(addr string) agentv1connect.AgentServiceClient {
 			PingTimeout:     15 * time.Second,
 		},
 	}
-	return agentv1connect.NewAgentServiceClient(httpClient, fmt.Sprintf("http://%s", normalizeAgentAddress(addr)))
+	return agentv1connect.NewAgentServiceClient(httpClient, fmt.Sprintf("http://%s", normalizeAgentAddress(addr)), opts...)
 }
 
 // listAgentsFromColony connects to the colony with automatic fallback and calls ListAgents.
@@ -104,7 +104,12 @@ Command execution mode:
 			// Check if command execution mode (args provided).
 			if len(args) > 0 {
 				// One-off command execution (like kubectl exec).
-				return runCommandExecution(ctx, agentAddr, userID, args)
+				return runCommandExecution(ctx, agentAddr, userID, args, execAudit{
+					colonyID: colony,
+					action:   "ShellExec",
+					target:   agentTarget(agent, agentAddr),
+					args:     args,
+				})
 			}
 
 			// Interactive shell mode.
@@ -134,7 +139,13 @@ Command execution mode:
 			}
 
 			// Start shell session.
-			return runShellSession(ctx, agentAddr, userID)
+			rec := execAudit{
+				colonyID: colony,
+				action:   "Shell",
+				target:   agentTarget(agent, agentAddr),
+			}
+			start := &agentv1.ShellStart{Shell: "/bin/bash", UserId: userID}
+			return runShellSession(ctx, agentAddr, start, false, rec, capabilityOptions(ctx, colony, ""))
 		},
 	}
 
@@ -154,29 +165,31 @@ func resolveUserID() string {
 	return "unknown"
 }
 
-// openShellStream creates an HTTP/2 streaming connection to the agent shell and
-// sends the initial start request.
+// openShellStream creates an HTTP/2 streaming connection to the agent and
+// sends the start request: a Shell stream, or an ExecInteractive stream if
+// exec is set.
 func openShellStream(
 	ctx context.Context,
-	agentAddr, userID string,
+	agentAddr string,
+	start *agentv1.ShellStart,
+	exec bool,
 	width, height int,
+	opts ...connect.ClientOption,
 ) (*connect.BidiStreamForClient[agentv1.ShellRequest, agentv1.ShellResponse], error) {
-	client := newStreamingAgentClient(agentAddr)
+	client := newStreamingAgentClient(agentAddr, opts...)
 
 	stream := client.Shell(ctx)
+	if exec {
+		stream = client.ExecInteractive(ctx)
+	}
 	rows, _ := safe.IntToUint32(height)
 	cols, _ := safe.IntToUint32(width)
+	start.Size = &agentv1.TerminalSize{
+		Rows: rows,
+		Cols: cols,
+	}
 	if err := stream.Send(&agentv1.ShellRequest{
-		Payload: &agentv1.ShellRequest_Start{
-			Start: &agentv1.ShellStart{
-				Shell:  "/bin/bash",
-				UserId: userID,
-				Size: &agentv1.TerminalSize{
-					Rows: rows,
-					Cols: cols,
-				},
-			},
-		},
+		Payload: &agentv1.ShellRequest_Start{Start: start},
 	}); err != nil {
 		return nil, fmt.Errorf("failed to start shell: %w", err)
 	}
@@ -335,13 +348,13 @@ func receiveShellOutput(
 
 // runCommandExecution executes a one-off command on the agent (RFD 045).
 // This is similar to kubectl exec pod -- command args.
-func runCommandExecution(ctx context.Context, agentAddr, userID string, command []string) error {
+func runCommandExecution(ctx context.Context, agentAddr, userID string, command []string, rec execAudit) error {
 	// Get current user if not specified.
 	if userID == "" {
 		userID = resolveUserID()
 	}
 
-	client := newAgentClient(agentAddr)
+	client := newAgentClient(agentAddr, capabilityOptions(ctx, rec.colonyID, "")...)
 
 	// Prepare request.
 	req := &agentv1.ShellExecRequest{
@@ -356,8 +369,10 @@ func runCommandExecution(ctx context.Context, agentAddr, userID string, command
 
 	resp, err := client.ShellExec(execCtx, connect.NewRequest(req))
 	if err != nil {
+		rec.record(ctx, userID, 0, err)
 		return fmt.Errorf("failed to execute command on agent: %w", err)
 	}
+	rec.record(ctx, userID, resp.Msg.ExitCode, responseError(resp.Msg.Error))
 
 	// Write stdout.
 	if len(resp.Msg.Stdout) > 0 {
@@ -396,8 +411,16 @@ func restoreTerminal(state *term.State) {
 	}
 }
 
-// runShellSession runs the interactive shell session.
-func runShellSession(ctx context.Context, agentAddr, userID string) error {
+// runShellSession runs an interactive session started with start: a shell,
+// or a command of coral exec -it if exec is set.
+func runShellSession(
+	ctx context.Context,
+	agentAddr string,
+	start *agentv1.ShellStart,
+	exec bool,
+	rec execAudit,
+	opts []connect.ClientOption,
+) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -405,17 +428,19 @@ func runShellSession(ctx context.Context, agentAddr, userID string) error {
 	var oldState *term.State
 	defer func() { restoreTerminal(oldState) }()
 
-	if userID == "" {
-		userID = resolveUserID()
+	if start.UserId == "" {
+		start.UserId = resolveUserID()
 	}
+	userID := start.UserId
 
 	width, height, err := term.GetSize(int(os.Stdin.Fd())) // #nosec G115 unlikely
 	if err != nil {
 		return fmt.Errorf("failed to get terminal size: %w", err)
 	}
 
-	stream, err := openShellStream(ctx, agentAddr, userID, width, height)
+	stream, err := openShellStream(ctx, agentAddr, start, exec, width, height, opts...)
 	if err != nil {
+		rec.record(ctx, userID, 0, err)
 		return err
 	}
 
@@ -436,6 +461,7 @@ func runShellSession(ctx context.Context, agentAddr, userID string) error {
 	stdinDone := pipeStdin(ctx, stream)
 
 	exitCode, sessionID, err := receiveShellOutput(ctx, stream, stdinDone)
+	rec.record(ctx, userID, exitCode, err)
 
 	// Restore terminal before any output regardless of success or failure.
 	restoreTerminal(oldState)
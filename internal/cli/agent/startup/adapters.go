@@ -138,3 +138,99 @@ func (a *debugServiceAdapter) ListCorrelations(
 	}
 	return connect.NewResponse(resp), nil
 }
+
+func (a *debugServiceAdapter) ListCoreDumps(
+	ctx context.Context,
+	req *connect.Request[agentv1.ListCoreDumpsRequest],
+) (*connect.Response[agentv1.ListCoreDumpsResponse], error) {
+	resp, err := a.service.ListCoreDumps(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *debugServiceAdapter) DownloadCoreDump(
+	ctx context.Context,
+	req *connect.Request[agentv1.DownloadCoreDumpRequest],
+	stream *connect.ServerStream[agentv1.CoreDumpChunk],
+) error {
+	return a.service.DownloadCoreDump(ctx, req.Msg, stream.Send)
+}
+
+func (a *debugServiceAdapter) DescribeFunction(
+	ctx context.Context,
+	req *connect.Request[agentv1.DescribeFunctionRequest],
+) (*connect.Response[agentv1.DescribeFunctionResponse], error) {
+	resp, err := a.service.DescribeFunction(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *debugServiceAdapter) StartHttpCapture(
+	ctx context.Context,
+	req *connect.Request[agentv1.StartHttpCaptureRequest],
+) (*connect.Response[agentv1.StartHttpCaptureResponse], error) {
+	resp, err := a.service.StartHttpCapture(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *debugServiceAdapter) StartTlsCapture(
+	ctx context.Context,
+	req *connect.Request[agentv1.StartTlsCaptureRequest],
+) (*connect.Response[agentv1.StartTlsCaptureResponse], error) {
+	resp, err := a.service.StartTlsCapture(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *debugServiceAdapter) TraceRuntime(
+	ctx context.Context,
+	req *connect.Request[agentv1.TraceRuntimeAgentRequest],
+) (*connect.Response[agentv1.TraceRuntimeAgentResponse], error) {
+	resp, err := a.service.TraceRuntime(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *debugServiceAdapter) TraceSyscalls(
+	ctx context.Context,
+	req *connect.Request[agentv1.TraceSyscallsAgentRequest],
+) (*connect.Response[agentv1.TraceSyscallsAgentResponse], error) {
+	resp, err := a.service.TraceSyscalls(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *debugServiceAdapter) ProfileIO(
+	ctx context.Context,
+	req *connect.Request[agentv1.ProfileIOAgentRequest],
+) (*connect.Response[agentv1.ProfileIOAgentResponse], error) {
+	resp, err := a.service.ProfileIO(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *debugServiceAdapter) ProfileLocks(
+	ctx context.Context,
+	req *connect.Request[agentv1.ProfileLocksAgentRequest],
+) (*connect.Response[agentv1.ProfileLocksAgentResponse], error) {
+	resp, err := a.service.ProfileLocks(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(resp), nil
+}
@@ -4,12 +4,20 @@ package startup
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
 	"github.com/coral-mesh/coral/internal/agent"
+	"github.com/coral-mesh/coral/internal/agent/certs"
+	"github.com/coral-mesh/coral/internal/agent/ebpf/bpfsign"
+	"github.com/coral-mesh/coral/internal/agent/selfupdate"
 	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
 	"github.com/coral-mesh/coral/internal/logging"
+	"github.com/coral-mesh/coral/pkg/version"
 )
 
 // AgentServer represents a running agent server.
@@ -24,6 +32,7 @@ type AgentServer struct {
 	StorageResult        *StorageResult
 	ServicesResult       *ServicesResult
 	MeshPingServer       *agent.MeshPingServer
+	Updater              *selfupdate.Updater // nil unless agent updates are enabled.
 	Logger               logging.Logger
 }
 
@@ -112,6 +121,9 @@ type AgentServerBuilder struct {
 	monitorAll       bool
 	ctx              context.Context
 
+	// coreDumpHelperCommand runs the core_pattern helper of this binary.
+	coreDumpHelperCommand []string
+
 	// Phase results.
 	configResult    *ConfigResult
 	bootstrapResult *BootstrapResult // RFD 048: Certificate bootstrap result.
@@ -124,6 +136,7 @@ type AgentServerBuilder struct {
 	runtimeService    *agent.RuntimeService
 	connectionManager *ConnectionManager
 	agentInstance     *agent.Agent
+	updater           *selfupdate.Updater
 }
 
 // NewAgentServerBuilder creates a new agent server builder.
@@ -145,6 +158,12 @@ func NewAgentServerBuilder(
 	}
 }
 
+// SetCoreDumpHelperCommand sets the command line the kernel runs to pipe core
+// dumps into this binary's coredump-helper subcommand.
+func (b *AgentServerBuilder) SetCoreDumpHelperCommand(command []string) {
+	b.coreDumpHelperCommand = command
+}
+
 // Validate performs preflight checks and config validation.
 func (b *AgentServerBuilder) Validate() error {
 	// Phase 1a: Preflight checks.
@@ -266,6 +285,25 @@ func (b *AgentServerBuilder) CreateAgentInstance() error {
 	}
 	b.runtimeService = runtimeService
 
+	// Agents configured with the colony secret register with it, or with
+	// the one the colony pushed when it last rotated its secret.
+	colonySecret, err := agent.NewColonySecret(certs.NewManager(certs.Config{
+		CertsDir: b.configResult.AgentConfig.Agent.Bootstrap.CertsDir,
+		Logger:   b.logger,
+	}).GetColonySecretPath(), b.configResult.Config.ColonySecret)
+	if err != nil {
+		return err
+	}
+
+	// Verify embedded eBPF objects with the pinned signing keys before the
+	// agent loads any of them.
+	security := b.configResult.AgentConfig.Agent.Security
+	signingKeys, err := bpfsign.ParsePublicKeys(security.EBPFSigningKeys)
+	if err != nil {
+		return err
+	}
+	bpfsign.Configure(signingKeys, security.RequireSignedEBPF, b.logger)
+
 	// Create agent instance.
 	serviceInfos := make([]*meshv1.ServiceInfo, len(b.configResult.ServiceSpecs))
 	for i, spec := range b.configResult.ServiceSpecs {
@@ -277,8 +315,18 @@ func (b *AgentServerBuilder) CreateAgentInstance() error {
 		AgentID:       b.agentID,
 		Services:      serviceInfos,
 		BeylaConfig:   b.storageResult.BeylaConfig,
+		DebugConfig:   b.configResult.AgentConfig.Debug,
 		FunctionCache: b.storageResult.FunctionCache,
+		EventStore:    b.storageResult.EventStore,
+		ProfileStore:  b.storageResult.ProfileStore,
 		Logger:        b.logger,
+
+		ServiceDiscovery: b.configResult.AgentConfig.ServiceDiscovery,
+		ResourceLimits:   b.configResult.AgentConfig.ResourceLimits,
+
+		CoreDumps:             b.coreDumpConfig(),
+		CoreDumpHelperCommand: b.coreDumpHelperCommand,
+		ColonySecret:          colonySecret,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create agent: %w", err)
@@ -292,6 +340,55 @@ func (b *AgentServerBuilder) CreateAgentInstance() error {
 	return nil
 }
 
+// coreDumpConfig returns the core dump configuration, storing dumps next to
+// the agent database unless a directory is configured.
+func (b *AgentServerBuilder) coreDumpConfig() config.CoreDumpConfig {
+	cfg := b.configResult.AgentConfig.CoreDumps
+	if cfg.Enabled && cfg.Directory == "" {
+		if homeDir, err := os.UserHomeDir(); err == nil {
+			cfg.Directory = filepath.Join(homeDir, constants.DefaultDir, "agent", "coredumps")
+		}
+	}
+	return cfg
+}
+
+// newUpdater creates the updater installing the agent releases rolled out by
+// the colony, or returns nil if agent updates are disabled.
+func (b *AgentServerBuilder) newUpdater() (*selfupdate.Updater, error) {
+	cfg := b.configResult.AgentConfig.Agent.Update
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	window, err := selfupdate.ParseWindow(cfg.MaintenanceWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	executable, err := selfupdate.CurrentExecutable()
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := loadCapabilityVerifier(b.configResult.AgentConfig.Agent.Bootstrap.CertsDir, b.configResult.Config.ColonyID, b.logger)
+	if err != nil {
+		b.logger.Warn().Err(err).Msg("Agent updates are disabled")
+		return nil, nil
+	}
+
+	b.logger.Info().
+		Str("version", version.Version).
+		Str("maintenance_window", window.String()).
+		Msg("Agent updates enabled")
+	return selfupdate.New(selfupdate.Config{
+		Version:    version.Version,
+		AgentID:    b.agentID,
+		Executable: executable,
+		Verifier:   verifier,
+		Window:     window,
+		Logger:     b.logger.With().Str("component", "selfupdate").Logger(),
+	}), nil
+}
+
 // RegisterWithColony performs colony registration and mesh configuration.
 func (b *AgentServerBuilder) RegisterWithColony() error {
 	if b.configResult == nil || b.networkResult == nil || b.runtimeService == nil {
@@ -310,6 +407,29 @@ func (b *AgentServerBuilder) RegisterWithColony() error {
 		b.logger,
 	)
 	b.connectionManager = connMgr
+	connMgr.SetObservedEndpoint(b.networkResult.AgentObservedEndpoint)
+	if b.bootstrapResult != nil {
+		connMgr.SetCertificateManager(b.bootstrapResult.CertManager)
+	}
+
+	// Report resource shedding in heartbeats, immediately on change, along
+	// with health metrics.
+	if b.agentInstance != nil {
+		connMgr.SetResourceSheddingProvider(b.agentInstance.ResourceShedding)
+		connMgr.SetHealthMetricsProvider(b.agentInstance.HealthMetrics)
+		connMgr.SetColonySecret(b.agentInstance.ColonySecret())
+		b.agentInstance.OnResourceSheddingChange(func(*agentv1.ResourceShedding) {
+			connMgr.TriggerHeartbeat()
+		})
+	}
+
+	// Install the agent releases the colony rolls out, if enabled.
+	updater, err := b.newUpdater()
+	if err != nil {
+		return err
+	}
+	b.updater = updater
+	connMgr.SetUpdater(updater)
 
 	// Attempt initial registration with colony.
 	meshIPStr, meshSubnetStr, err := connMgr.AttemptRegistration()
@@ -334,7 +454,8 @@ func (b *AgentServerBuilder) RegisterWithColony() error {
 		b.agentID,
 	)
 
-	if err := networkInitializer.ConfigureMesh(b.networkResult, meshIPStr, meshSubnetStr, colonyEndpoint); err != nil {
+	meshIPv6Str, meshSubnetIPv6Str := connMgr.GetAssignedIPv6()
+	if err := networkInitializer.ConfigureMesh(b.networkResult, meshIPStr, meshSubnetStr, meshIPv6Str, meshSubnetIPv6Str, colonyEndpoint); err != nil {
 		return fmt.Errorf("failed to configure mesh: %w", err)
 	}
 
@@ -435,6 +556,7 @@ func (b *AgentServerBuilder) Build() *AgentServer {
 		StorageResult:        b.storageResult,
 		ServicesResult:       b.servicesResult,
 		MeshPingServer:       b.servicesResult.MeshPingServer,
+		Updater:              b.updater,
 		Logger:               b.logger,
 	}
 }
@@ -2,25 +2,34 @@ package startup
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"math"
 	"math/rand"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"connectrpc.com/connect"
+
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
 	"github.com/coral-mesh/coral/coral/mesh/v1/meshv1connect"
 	"github.com/coral-mesh/coral/internal/agent"
+	"github.com/coral-mesh/coral/internal/agent/certs"
 	"github.com/coral-mesh/coral/internal/agent/heartbeat"
+	"github.com/coral-mesh/coral/internal/agent/selfupdate"
 	"github.com/coral-mesh/coral/internal/cli/agent/types"
 	"github.com/coral-mesh/coral/internal/config"
 	"github.com/coral-mesh/coral/internal/constants"
 	"github.com/coral-mesh/coral/internal/discovery"
 	"github.com/coral-mesh/coral/internal/logging"
 	wg "github.com/coral-mesh/coral/internal/wireguard"
+	"github.com/coral-mesh/coral/pkg/version"
 )
 
 // ConnectionState represents the current state of the agent's connection to the colony.
@@ -68,6 +77,8 @@ type ConnectionManager struct {
 	agentPubKey    string
 	wgDevice       *wg.Device
 	runtimeService *agent.RuntimeService // RFD 018: Runtime context for registration
+	certManager    *certs.Manager        // RFD 048: Client certificate for mTLS registration; may be nil
+	colonySecret   *agent.ColonySecret   // RFD 002: Colony secret presented at registration; may be nil
 	logger         logging.Logger
 
 	// State tracking
@@ -79,18 +90,41 @@ type ConnectionManager struct {
 	consecutiveFailures     int
 	assignedIP              string
 	assignedSubnet          string
+	assignedIPv6            string // Empty if the colony has no IPv6 mesh
+	assignedSubnetIPv6      string
+	heartbeatIPv6           bool   // Heartbeats go to the colony's IPv6 mesh IP
 	currentEndpoint         string // Tracks the currently configured WireGuard endpoint
 	lastSuccessfulEndpoint  string // Tracks the last WireGuard endpoint that successfully connected
 	lastSuccessfulRegURL    string // Tracks the last HTTP registration URL that succeeded
 
+	// Hole punching: the STUN-discovered public endpoint offered to the
+	// colony as a candidate, and whether an attempt is in progress.
+	observedEndpoint *discovery.Endpoint
+	punching         atomic.Bool
+
+	// Resource safety valve state reported in heartbeats; may be nil.
+	sheddingProvider func() *agentv1.ResourceShedding
+	healthProvider   func() *meshv1.AgentHealthMetrics
+
+	// Installs the agent releases advertised in heartbeat responses; may be
+	// nil.
+	updater *selfupdate.Updater
+
 	// Reconnection control
 	reconnectTrigger chan struct{}
 	discoveryTrigger chan struct{}
+	heartbeatTrigger chan struct{}
 	backoff          *ExponentialBackoff
 	discoveryBackoff *ExponentialBackoff
 	colonyInfoMu     sync.RWMutex // Protects colonyInfo updates
 }
 
+// holePunchAfterFailures is the number of consecutive heartbeat failures
+// after which the agent asks the colony to coordinate hole punching, and
+// falls back to the relay if that fails. It is below the failure count that
+// triggers re-registration.
+const holePunchAfterFailures = 2
+
 // ExponentialBackoff implements exponential backoff with jitter for reconnection attempts.
 type ExponentialBackoff struct {
 	InitialInterval time.Duration
@@ -154,6 +188,7 @@ func NewConnectionManager(
 		state:            initialState,
 		reconnectTrigger: make(chan struct{}, 1),
 		discoveryTrigger: make(chan struct{}, 1),
+		heartbeatTrigger: make(chan struct{}, 1),
 		backoff: &ExponentialBackoff{
 			InitialInterval: 1 * time.Second,
 			MaxInterval:     5 * time.Minute,
@@ -191,17 +226,17 @@ func (cm *ConnectionManager) setState(newState ConnectionState) {
 	}
 }
 
-// AttemptDiscovery attempts to query the discovery service for colony information.
-// Returns the colony info on success, or an error on failure.
+// AttemptDiscovery attempts to locate the colony through static config, DNS
+// or the discovery service (see LocateColony). Returns the colony info on success, or an error on failure.
 func (cm *ConnectionManager) AttemptDiscovery() (*discovery.LookupColonyResponse, error) {
 	cm.logger.Info().
 		Str("colony_id", cm.config.ColonyID).
 		Str("discovery_url", cm.config.DiscoveryURL).
 		Msg("Attempting discovery service query")
 
-	colonyInfo, err := QueryDiscoveryForColony(cm.config, cm.logger)
+	colonyInfo, err := LocateColony(cm.config, cm.logger)
 	if err != nil {
-		return nil, fmt.Errorf("discovery lookup failed: %w", err)
+		return nil, fmt.Errorf("colony lookup failed: %w", err)
 	}
 
 	// Update colony info with lock.
@@ -300,14 +335,24 @@ func (cm *ConnectionManager) AttemptRegistration() (string, string, error) {
 	// Get runtime context from runtime service (RFD 018).
 	var runtimeContext = cm.getRuntimeContext()
 
+	// Register over mTLS with the agent's certificate, if it has one.
+	var tlsConfig *tls.Config
+	if cm.certManager != nil {
+		if cfg, err := cm.certManager.GetTLSConfig(); err == nil {
+			tlsConfig = cfg
+		}
+	}
+
 	result, successfulURL, err := registerWithColony(
 		cm.config,
 		cm.agentID,
+		cm.colonySecret.Get(),
 		cm.serviceSpecs,
 		cm.agentPubKey,
 		colonyInfo,
 		runtimeContext,
 		preferredURL,
+		tlsConfig,
 		cm.logger,
 	)
 
@@ -321,20 +366,23 @@ func (cm *ConnectionManager) AttemptRegistration() (string, string, error) {
 		cm.SetLastSuccessfulRegURL(successfulURL)
 	}
 
-	// Parse registration result (format: "IP|SUBNET")
+	// Parse registration result (format: "IP|SUBNET|IPV6|SUBNETV6")
 	parts := strings.Split(result, "|")
-	if len(parts) != 2 {
+	if len(parts) != 4 {
 		cm.setState(StateUnregistered)
 		return "", "", fmt.Errorf("invalid registration response format")
 	}
 
 	cm.assignedIP = parts[0]
 	cm.assignedSubnet = parts[1]
+	cm.assignedIPv6 = parts[2]
+	cm.assignedSubnetIPv6 = parts[3]
 	cm.setState(StateRegistered)
 
 	cm.logger.Info().
 		Str("assigned_ip", cm.assignedIP).
 		Str("mesh_subnet", cm.assignedSubnet).
+		Str("assigned_ipv6", cm.assignedIPv6).
 		Msg("Successfully registered with colony")
 
 	return cm.assignedIP, cm.assignedSubnet, nil
@@ -360,12 +408,27 @@ func (cm *ConnectionManager) StartHeartbeatLoop(ctx context.Context, interval ti
 			connectPort = constants.DefaultColonyPort
 		}
 
-		colonyURL := fmt.Sprintf("http://%s", net.JoinHostPort(colonyInfo.MeshIPv4, fmt.Sprintf("%d",
+		colonyURL := fmt.Sprintf("http://%s", net.JoinHostPort(cm.heartbeatMeshIP(colonyInfo), fmt.Sprintf("%d",
 			connectPort)))
-		client := meshv1connect.NewMeshServiceClient(http.DefaultClient, colonyURL)
+		httpClient := http.DefaultClient
+		if cm.wgDevice != nil {
+			httpClient = cm.wgDevice.HTTPClient()
+		}
+		client := meshv1connect.NewMeshServiceClient(httpClient, colonyURL)
 
 		// Use the composable Agent for the actual heartbeat call.
 		agent := heartbeat.NewAgent(cm.agentID, client)
+		if cm.sheddingProvider != nil {
+			agent.SetResourceShedding(cm.sheddingProvider())
+		}
+		if cm.healthProvider != nil {
+			agent.SetHealthMetrics(cm.healthProvider())
+		}
+		if cm.updater != nil {
+			agent.SetVersion(version.Version, cm.updater.LastError())
+		} else {
+			agent.SetVersion(version.Version, "")
+		}
 
 		heartbeatCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 		defer cancel()
@@ -377,8 +440,10 @@ func (cm *ConnectionManager) StartHeartbeatLoop(ctx context.Context, interval ti
 			cm.logger.Warn().
 				Err(err).
 				Str("agent_id", cm.agentID).
+				Str("colony_url", colonyURL).
 				Int("consecutive_failures", cm.consecutiveFailures).
 				Msg("Failed to send heartbeat")
+			cm.switchHeartbeatFamily(colonyInfo)
 			return false
 		}
 
@@ -404,6 +469,11 @@ func (cm *ConnectionManager) StartHeartbeatLoop(ctx context.Context, interval ti
 			cm.SetLastSuccessfulEndpoint(currentEndpoint)
 		}
 
+		if cm.updater != nil {
+			cm.updater.Confirm()
+			cm.updater.Offer(ctx, resp.AgentUpdate)
+		}
+
 		cm.logger.Debug().
 			Str("agent_id", cm.agentID).
 			Msg("Heartbeat sent successfully")
@@ -421,8 +491,16 @@ func (cm *ConnectionManager) StartHeartbeatLoop(ctx context.Context, interval ti
 		case <-ctx.Done():
 			cm.logger.Info().Msg("Heartbeat loop stopping")
 			return
+		case <-cm.heartbeatTrigger:
+			// Out-of-band heartbeat; failures are left to the ticker to count.
+			sendHeartbeat()
 		case <-ticker.C:
 			success := sendHeartbeat()
+			if !success && cm.consecutiveFailures == holePunchAfterFailures {
+				// The tunnel may be blocked by NAT: try to punch through,
+				// or relay, before giving up on the registration.
+				go cm.restorePath(ctx)
+			}
 			if !success && cm.consecutiveFailures >= 3 {
 				// After 3 consecutive failures (~45 seconds with 15s interval),
 				// assume connection is lost and trigger reconnection.
@@ -664,8 +742,10 @@ func (cm *ConnectionManager) configureMesh(meshIPStr, meshSubnetStr string) erro
 		Str("colony_endpoint", colonyEndpoint).
 		Msg("Configuring agent mesh network")
 
+	meshIPv6, meshSubnetIPv6 := parseMeshIPv6(cm.GetAssignedIPv6())
+
 	// Call ConfigureAgentMesh to set up the complete mesh network.
-	if err := ConfigureAgentMesh(cm.wgDevice, parsedMeshIP, parsedMeshSubnet, colonyInfo, colonyEndpoint, cm.logger); err != nil {
+	if err := ConfigureAgentMesh(cm.wgDevice, parsedMeshIP, parsedMeshSubnet, meshIPv6, meshSubnetIPv6, colonyInfo, colonyEndpoint, cm.logger); err != nil {
 		return fmt.Errorf("failed to configure agent mesh: %w", err)
 	}
 
@@ -681,6 +761,206 @@ func (cm *ConnectionManager) configureMesh(meshIPStr, meshSubnetStr string) erro
 }
 
 // triggerReconnection signals the reconnection loop to attempt reconnection immediately.
+// SetCertificateManager sets the manager of the agent's client certificate,
+// with which the agent registers over mTLS.
+func (cm *ConnectionManager) SetCertificateManager(certManager *certs.Manager) {
+	cm.certManager = certManager
+}
+
+// SetResourceSheddingProvider sets the source of the resource safety valve
+// state included in heartbeats. Must be called before StartHeartbeatLoop.
+func (cm *ConnectionManager) SetResourceSheddingProvider(provider func() *agentv1.ResourceShedding) {
+	cm.sheddingProvider = provider
+}
+
+// SetHealthMetricsProvider sets the source of the health metrics included in
+// heartbeats. Must be called before StartHeartbeatLoop.
+func (cm *ConnectionManager) SetHealthMetricsProvider(provider func() *meshv1.AgentHealthMetrics) {
+	cm.healthProvider = provider
+}
+
+// SetColonySecret sets the colony secret presented at registration. secret
+// may be nil.
+func (cm *ConnectionManager) SetColonySecret(secret *agent.ColonySecret) {
+	cm.colonySecret = secret
+}
+
+// SetUpdater sets the updater installing the agent releases advertised in
+// heartbeat responses. updater may be nil.
+func (cm *ConnectionManager) SetUpdater(updater *selfupdate.Updater) {
+	cm.updater = updater
+}
+
+// SetObservedEndpoint sets the agent's STUN-discovered public endpoint,
+// offered to the colony as a hole punching candidate. ep may be nil.
+func (cm *ConnectionManager) SetObservedEndpoint(ep *discovery.Endpoint) {
+	cm.observedEndpoint = ep
+}
+
+// restorePath looks for a working path to the colony when heartbeats fail:
+// hole punching first, then the colony's relay.
+func (cm *ConnectionManager) restorePath(ctx context.Context) {
+	if cm.punchHole(ctx) {
+		return
+	}
+	cm.startRelay(ctx)
+}
+
+// punchHole asks the colony to coordinate hole punching and, if one of the
+// colony's candidate endpoints answers, points the colony peer at it. The
+// request goes to the colony's registration URL, which does not depend on
+// the tunnel. It reports whether a path was found.
+func (cm *ConnectionManager) punchHole(ctx context.Context) bool {
+	if cm.wgDevice == nil {
+		return false
+	}
+	puncher := cm.wgDevice.HolePuncher()
+	colonyInfo := cm.GetColonyInfo()
+	regURL := cm.GetLastSuccessfulRegURL()
+	if puncher == nil || colonyInfo == nil || regURL == "" {
+		return false
+	}
+	if !cm.punching.CompareAndSwap(false, true) {
+		return false
+	}
+	defer cm.punching.Store(false)
+
+	port := cm.wgDevice.ListenPort()
+	candidates := wg.HostCandidates(port, cm.wgDevice.InterfaceName())
+	if ep := cm.observedEndpoint; ep != nil && ep.IP != "" && ep.Port != 0 {
+		candidates = append([]string{net.JoinHostPort(ep.IP, strconv.FormatUint(uint64(ep.Port), 10))}, candidates...)
+	}
+
+	client := meshv1connect.NewMeshServiceClient(http.DefaultClient, regURL)
+	rpcCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	resp, err := client.PunchHole(rpcCtx, connect.NewRequest(&meshv1.PunchHoleRequest{
+		AgentId:         cm.agentID,
+		WireguardPubkey: cm.agentPubKey,
+		Candidates:      candidates,
+		ListenPort:      uint32(port), //nolint:gosec // G115: Port number is from the bound socket
+	}))
+	cancel()
+	if err != nil {
+		cm.logger.Warn().Err(err).Msg("Colony did not start hole punching")
+		return false
+	}
+
+	var session [wg.PunchSessionIDSize]byte
+	if len(resp.Msg.SessionId) != len(session) {
+		cm.logger.Warn().Int("size", len(resp.Msg.SessionId)).Msg("Invalid hole punching session ID from colony")
+		return false
+	}
+	copy(session[:], resp.Msg.SessionId)
+
+	colonyCandidates := resp.Msg.Candidates
+	for _, ep := range colonyInfo.ObservedEndpoints {
+		if ep.IP != "" && ep.Port != 0 {
+			colonyCandidates = append(colonyCandidates, net.JoinHostPort(ep.IP, strconv.FormatUint(uint64(ep.Port), 10)))
+		}
+	}
+
+	// Start when the colony does, tolerating clock skew between the two.
+	delay := min(max(time.Until(resp.Msg.StartAt.AsTime()), 0), constants.DefaultHolePunchDelay)
+	startAt := time.Now().Add(delay)
+	punchCtx, cancel := context.WithDeadline(ctx, startAt.Add(constants.DefaultHolePunchTimeout))
+	defer cancel()
+
+	cm.logger.Info().
+		Strs("candidates", colonyCandidates).
+		Msg("Hole punching to colony")
+
+	endpoint, err := puncher.Punch(punchCtx, session, colonyCandidates, startAt)
+	if err != nil {
+		cm.logger.Warn().Err(err).Msg("Hole punching to colony failed")
+		return false
+	}
+	if err := cm.wgDevice.SetPeerEndpoint(colonyInfo.Pubkey, endpoint); err != nil {
+		cm.logger.Warn().Err(err).Msg("Failed to promote hole punched colony endpoint")
+		return false
+	}
+	cm.SetCurrentEndpoint(endpoint)
+
+	cm.logger.Info().
+		Str("endpoint", endpoint).
+		Msg("Hole punching succeeded, colony endpoint updated")
+	cm.TriggerHeartbeat()
+	return true
+}
+
+// startRelay tunnels the colony peer's packets over a websocket to the
+// colony's registration URL, for networks that block the UDP path. While
+// relayed, the agent retries hole punching periodically and leaves the relay
+// once a UDP path works.
+func (cm *ConnectionManager) startRelay(ctx context.Context) {
+	if cm.wgDevice == nil {
+		return
+	}
+	relay := cm.wgDevice.Relay()
+	colonyInfo := cm.GetColonyInfo()
+	regURL := cm.GetLastSuccessfulRegURL()
+	if relay == nil || colonyInfo == nil || regURL == "" || relay.Connected(colonyInfo.Pubkey) {
+		return
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	err := relay.Dial(dialCtx, regURL, cm.agentPubKey, colonyInfo.Pubkey)
+	cancel()
+	if err != nil {
+		cm.logger.Warn().Err(err).Msg("Failed to connect to colony relay")
+		return
+	}
+
+	endpoint := wg.RelayEndpoint(colonyInfo.Pubkey)
+	if err := cm.wgDevice.SetPeerEndpoint(colonyInfo.Pubkey, endpoint); err != nil {
+		cm.logger.Warn().Err(err).Msg("Failed to switch colony endpoint to relay")
+		relay.Disconnect(colonyInfo.Pubkey)
+		return
+	}
+	cm.SetCurrentEndpoint(endpoint)
+
+	cm.logger.Warn().
+		Str("registration_url", regURL).
+		Msg("No UDP path to colony, relaying WireGuard traffic over HTTP")
+	cm.TriggerHeartbeat()
+
+	go cm.leaveRelay(ctx, colonyInfo.Pubkey)
+}
+
+// leaveRelay retries hole punching while the relay connection to the colony
+// is up, and closes it once a UDP path works.
+func (cm *ConnectionManager) leaveRelay(ctx context.Context, colonyPubkey string) {
+	relay := cm.wgDevice.Relay()
+	ticker := time.NewTicker(constants.DefaultRelayDirectRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			relay.Disconnect(colonyPubkey)
+			return
+		case <-ticker.C:
+		}
+		if !relay.Connected(colonyPubkey) {
+			return
+		}
+		if cm.punchHole(ctx) {
+			relay.Disconnect(colonyPubkey)
+			cm.logger.Info().Msg("UDP path to colony restored, relay closed")
+			return
+		}
+	}
+}
+
+// TriggerHeartbeat requests an immediate heartbeat, e.g. to report a change in
+// resource shedding without waiting for the next interval.
+func (cm *ConnectionManager) TriggerHeartbeat() {
+	select {
+	case cm.heartbeatTrigger <- struct{}{}:
+	default:
+		// A heartbeat is already pending.
+	}
+}
+
 func (cm *ConnectionManager) triggerReconnection() {
 	select {
 	case cm.reconnectTrigger <- struct{}{}:
@@ -697,6 +977,37 @@ func (cm *ConnectionManager) GetAssignedIP() (string, string) {
 	return cm.assignedIP, cm.assignedSubnet
 }
 
+// heartbeatMeshIP returns the colony mesh IP heartbeats are sent to: its IPv4
+// one unless heartbeats switched to IPv6.
+func (cm *ConnectionManager) heartbeatMeshIP(colonyInfo *discovery.LookupColonyResponse) string {
+	if cm.heartbeatIPv6 && colonyInfo.MeshIPv6 != "" {
+		return colonyInfo.MeshIPv6
+	}
+	return colonyInfo.MeshIPv4
+}
+
+// switchHeartbeatFamily switches heartbeats to the other address family
+// after a failure, if both the colony and the agent have an IPv6 mesh IP.
+// The mesh then settles on whichever family gets through, and the colony
+// calls the agent over the family its heartbeats arrive on.
+func (cm *ConnectionManager) switchHeartbeatFamily(colonyInfo *discovery.LookupColonyResponse) {
+	if assignedIPv6, _ := cm.GetAssignedIPv6(); assignedIPv6 == "" || colonyInfo.MeshIPv6 == "" {
+		return
+	}
+	cm.heartbeatIPv6 = !cm.heartbeatIPv6
+	cm.logger.Debug().
+		Str("colony_mesh_ip", cm.heartbeatMeshIP(colonyInfo)).
+		Msg("Switching heartbeats to the other address family")
+}
+
+// GetAssignedIPv6 returns the IPv6 mesh IP and subnet assigned by the colony,
+// or empty strings if the colony has no IPv6 mesh.
+func (cm *ConnectionManager) GetAssignedIPv6() (string, string) {
+	cm.stateMu.RLock()
+	defer cm.stateMu.RUnlock()
+	return cm.assignedIPv6, cm.assignedSubnetIPv6
+}
+
 // getRuntimeContext returns the cached runtime context from the runtime service.
 // Returns nil if runtime service is not available or context is not yet detected.
 func (cm *ConnectionManager) getRuntimeContext() *agentv1.RuntimeContextResponse {
@@ -731,26 +1042,7 @@ func (cm *ConnectionManager) GetColonyEndpoint() string {
 
 	// Try observed endpoints first (NAT traversal).
 	// These take highest priority as they're discovered via STUN for NAT traversal.
-	for _, observedEp := range colonyInfo.ObservedEndpoints {
-		if observedEp.IP == "" {
-			continue
-		}
-
-		// Skip invalid endpoints (port 0 means STUN failed or returned invalid data).
-		if observedEp.Port == 0 {
-			cm.logger.Debug().
-				Str("ip", observedEp.IP).
-				Msg("Skipping observed endpoint with port 0 (invalid STUN result)")
-			continue
-		}
-
-		ip := net.ParseIP(observedEp.IP)
-		// Skip IPv6 and loopback.
-		if ip != nil && (ip.To4() == nil || ip.IsLoopback()) {
-			continue
-		}
-
-		endpoint := net.JoinHostPort(observedEp.IP, fmt.Sprintf("%d", observedEp.Port))
+	if endpoint := observedColonyEndpoint(colonyInfo.ObservedEndpoints, cm.logger); endpoint != "" {
 		cm.SetCurrentEndpoint(endpoint)
 		cm.logger.Debug().
 			Str("endpoint", endpoint).
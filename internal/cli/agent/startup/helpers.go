@@ -2,9 +2,11 @@ package startup
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -24,9 +26,55 @@ import (
 	"github.com/coral-mesh/coral/internal/constants"
 	"github.com/coral-mesh/coral/internal/discovery"
 	"github.com/coral-mesh/coral/internal/logging"
+	"github.com/coral-mesh/coral/internal/protocol"
 	"github.com/coral-mesh/coral/internal/wireguard"
+	"github.com/coral-mesh/coral/pkg/version"
 )
 
+// LocateColony returns colony information from the static colony config, DNS
+// records, or the discovery service, in that order of preference.
+func LocateColony(cfg *config.ResolvedConfig, logger logging.Logger) (*discovery.LookupColonyResponse, error) {
+	if cfg.StaticColony != nil {
+		return staticColonyInfo(cfg.ColonyID, cfg.StaticColony)
+	}
+
+	if cfg.ColonyDNS != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		resp, err := discovery.LookupColonyDNS(ctx, net.DefaultResolver, cfg.ColonyDNS)
+		if err != nil {
+			return nil, fmt.Errorf("DNS colony lookup failed: %w", err)
+		}
+		if resp.MeshID != "" && resp.MeshID != cfg.ColonyID {
+			return nil, fmt.Errorf("DNS records for %s describe colony %q, expected %q", cfg.ColonyDNS, resp.MeshID, cfg.ColonyID)
+		}
+		resp.MeshID = cfg.ColonyID
+		return resp, nil
+	}
+
+	return QueryDiscoveryForColony(cfg, logger)
+}
+
+// staticColonyInfo converts the static colony config into a lookup response.
+func staticColonyInfo(colonyID string, static *config.StaticColonyConfig) (*discovery.LookupColonyResponse, error) {
+	if len(static.Endpoints) == 0 || static.MeshIPv4 == "" {
+		return nil, fmt.Errorf("static colony configuration requires endpoints and mesh_ipv4")
+	}
+	connectPort := static.ConnectPort
+	if connectPort == 0 {
+		connectPort = constants.DefaultColonyPort
+	}
+	return &discovery.LookupColonyResponse{
+		MeshID:      colonyID,
+		Pubkey:      static.Pubkey,
+		Endpoints:   static.Endpoints,
+		MeshIPv4:    static.MeshIPv4,
+		MeshIPv6:    static.MeshIPv6,
+		ConnectPort: connectPort,
+	}, nil
+}
+
 // QueryDiscoveryForColony queries the discovery service for colony information.
 func QueryDiscoveryForColony(cfg *config.ResolvedConfig, _ logging.Logger) (*discovery.LookupColonyResponse, error) {
 	// Create discovery client
@@ -58,6 +106,22 @@ func RegisterAgentWithDiscovery(
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Attach a referral ticket so discovery only accepts endpoints from
+	// admitted agents (RFD 049). Older discovery services accept unticketed
+	// registrations, so a failure here is not fatal.
+	var referralTicket string
+	token, err := client.CreateBootstrapToken(ctx, &discovery.CreateBootstrapTokenRequest{
+		ReefID:   "default",
+		ColonyID: cfg.ColonyID,
+		AgentID:  agentID,
+		Intent:   "register",
+	})
+	if err != nil {
+		logger.Debug().Err(err).Msg("Failed to obtain referral ticket, registering without it")
+	} else {
+		referralTicket = token.JWT
+	}
+
 	// Register agent
 	resp, err := client.RegisterAgent(ctx, &discovery.RegisterAgentRequest{
 		AgentID:          agentID,
@@ -66,6 +130,7 @@ func RegisterAgentWithDiscovery(
 		Endpoints:        []string{}, // Agents typically don't have static endpoints
 		ObservedEndpoint: observedEndpoint,
 		Metadata:         make(map[string]string),
+		ReferralTicket:   referralTicket,
 	})
 	if err != nil {
 		return fmt.Errorf("agent registration with discovery failed: %w", err)
@@ -91,6 +156,8 @@ func SetupAgentWireGuard(
 	colonyInfo *discovery.LookupColonyResponse,
 	stunServers []string,
 	enableRelay bool,
+	enableHolePunching bool,
+	userspace wireguard.UserspaceMode,
 	wgPort int,
 	logger logging.Logger,
 ) (*wireguard.Device, *discovery.Endpoint, string, error) {
@@ -131,6 +198,19 @@ func SetupAgentWireGuard(
 		return nil, nil, "", fmt.Errorf("failed to create WireGuard device: %w", err)
 	}
 
+	// Run the mesh in userspace where no TUN interface can be created.
+	wgDevice.SetUserspaceMode(userspace)
+
+	// Let the colony coordinate hole punching if the tunnel does not come up.
+	if enableHolePunching {
+		wgDevice.EnableHolePunching()
+	}
+
+	// Fall back to the colony's relay if no UDP path works.
+	if enableRelay {
+		wgDevice.EnableRelay()
+	}
+
 	// Start device
 	if err := wgDevice.Start(); err != nil {
 		return nil, nil, "", fmt.Errorf("failed to start WireGuard device: %w", err)
@@ -160,45 +240,11 @@ func SetupAgentWireGuard(
 
 	if colonyInfo != nil {
 		// Try observed endpoints first (these are the colony's public NAT addresses)
-		for _, observedEp := range colonyInfo.ObservedEndpoints {
-			if observedEp.IP == "" {
-				continue
-			}
-
-			// Skip invalid endpoints (port 0 means STUN failed or returned invalid data).
-			if observedEp.Port == 0 {
-				logger.Debug().
-					Str("ip", observedEp.IP).
-					Msg("Skipping observed endpoint with port 0 (invalid STUN result)")
-				continue
-			}
-
-			// LIMITATION: IPv6 support is not yet implemented.
-			// IPv6 addresses are skipped in favor of IPv4 for NAT traversal.
-			// TODO: Add proper IPv6 support with dual-stack handling.
-			// For now, only IPv4 endpoints are used for agent-colony connectivity.
-			ip := net.ParseIP(observedEp.IP)
-			if ip != nil && ip.To4() == nil {
-				// This is an IPv6 address - skip it for now as we only support IPv4.
-				logger.Debug().
-					Str("ipv6_endpoint", observedEp.IP).
-					Msg("Skipping IPv6 observed endpoint (IPv4-only mode)")
-				continue
-			}
-
-			// Skip loopback addresses
-			if ip != nil && ip.IsLoopback() {
-				logger.Debug().
-					Str("loopback_endpoint", observedEp.IP).
-					Msg("Skipping loopback observed endpoint")
-				continue
-			}
-
-			colonyEndpoint = net.JoinHostPort(observedEp.IP, fmt.Sprintf("%d", observedEp.Port))
+		colonyEndpoint = observedColonyEndpoint(colonyInfo.ObservedEndpoints, logger)
+		if colonyEndpoint != "" {
 			logger.Info().
 				Str("endpoint", colonyEndpoint).
 				Msg("Using colony's observed public endpoint for NAT traversal")
-			break
 		}
 
 		// Fall back to regular discovery endpoints
@@ -221,13 +267,13 @@ func SetupAgentWireGuard(
 					continue
 				}
 
-				// Resolve hostname to IPv4 address to avoid IPv6 issues
-				resolvedHost, err := helpers.ResolveToIPv4(host, logger)
+				// Resolve hostname, preferring IPv4 and falling back to IPv6.
+				resolvedHost, err := helpers.ResolvePreferIPv4(host, logger)
 				if err != nil {
 					logger.Warn().
 						Err(err).
 						Str("host", host).
-						Msg("Failed to resolve endpoint to IPv4, using as-is")
+						Msg("Failed to resolve endpoint, using as-is")
 					resolvedHost = host
 				}
 
@@ -305,12 +351,53 @@ func SetupAgentWireGuard(
 	return wgDevice, agentPublicEndpoint, colonyEndpoint, nil
 }
 
+// observedColonyEndpoint returns the first usable observed colony endpoint,
+// preferring IPv4 and falling back to IPv6 for IPv6-only colonies. Loopback
+// endpoints and endpoints with port 0 (failed STUN) are skipped.
+func observedColonyEndpoint(endpoints []discovery.Endpoint, logger logging.Logger) string {
+	var ipv6Endpoint string
+	for _, observedEp := range endpoints {
+		if observedEp.IP == "" {
+			continue
+		}
+
+		// Skip invalid endpoints (port 0 means STUN failed or returned invalid data).
+		if observedEp.Port == 0 {
+			logger.Debug().
+				Str("ip", observedEp.IP).
+				Msg("Skipping observed endpoint with port 0 (invalid STUN result)")
+			continue
+		}
+
+		ip := net.ParseIP(observedEp.IP)
+		if ip != nil && ip.IsLoopback() {
+			logger.Debug().
+				Str("loopback_endpoint", observedEp.IP).
+				Msg("Skipping loopback observed endpoint")
+			continue
+		}
+
+		endpoint := net.JoinHostPort(observedEp.IP, fmt.Sprintf("%d", observedEp.Port))
+		if ip != nil && ip.To4() == nil {
+			if ipv6Endpoint == "" {
+				ipv6Endpoint = endpoint
+			}
+			continue
+		}
+		return endpoint
+	}
+	return ipv6Endpoint
+}
+
 // ConfigureAgentMesh configures the agent's mesh network after registration.
 // This adds the colony as a WireGuard peer and tests connectivity (RFD 019).
+// meshIPv6 and meshSubnetIPv6 are nil if the colony has no IPv6 mesh.
 func ConfigureAgentMesh(
 	wgDevice *wireguard.Device,
 	meshIP net.IP,
 	meshSubnet *net.IPNet,
+	meshIPv6 net.IP,
+	meshSubnetIPv6 *net.IPNet,
 	colonyInfo *discovery.LookupColonyResponse,
 	colonyEndpoint string,
 	logger logging.Logger,
@@ -335,6 +422,22 @@ func ConfigureAgentMesh(
 		Str("ip", meshIP.String()).
 		Msg("Permanent IP assigned successfully")
 
+	// Assign the IPv6 mesh IP alongside; the mesh still works over IPv4
+	// if the host has IPv6 disabled.
+	if meshIPv6 != nil && meshSubnetIPv6 != nil {
+		if err := iface.AssignIP(meshIPv6, meshSubnetIPv6); err != nil {
+			logger.Warn().
+				Err(err).
+				Str("ip", meshIPv6.String()).
+				Msg("Failed to assign IPv6 mesh IP, mesh is IPv4 only")
+		} else {
+			logger.Info().
+				Str("interface", wgDevice.InterfaceName()).
+				Str("ip", meshIPv6.String()).
+				Msg("Permanent IPv6 assigned successfully")
+		}
+	}
+
 	// Build allowed IPs for colony peer.
 	allowedIPs := make([]string, 0, 2)
 	if colonyInfo.MeshIPv4 != "" {
@@ -380,7 +483,9 @@ func ConfigureAgentMesh(
 			Msg("Testing connectivity to colony via mesh")
 
 		// Try to establish TCP connection to verify tunnel is working
-		conn, err := net.DialTimeout("tcp", meshAddr, 3*time.Second)
+		dialCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		conn, err := wgDevice.DialContext(dialCtx, "tcp", meshAddr)
+		cancel()
 		if err != nil {
 			logger.Warn().
 				Err(err).
@@ -399,15 +504,17 @@ func ConfigureAgentMesh(
 }
 
 // registerWithColony sends a registration request to the colony.
-// Returns the registration result (IP|SUBNET format) and the successful URL.
+// Returns the registration result (IP|SUBNET|IPV6|SUBNETV6 format) and the successful URL.
 func registerWithColony(
 	cfg *config.ResolvedConfig,
 	agentID string,
+	colonySecret string,
 	serviceSpecs []*types.ServiceSpec,
 	agentPubKey string,
 	colonyInfo *discovery.LookupColonyResponse,
 	runtimeContext *agentv1.RuntimeContextResponse,
 	preferredURL string,
+	tlsConfig *tls.Config,
 	logger logging.Logger,
 ) (string, string, error) {
 	logger.Info().
@@ -448,9 +555,11 @@ func registerWithColony(
 	regReq := &meshv1.RegisterRequest{
 		AgentId:          agentID,
 		ColonyId:         cfg.ColonyID,
+		ColonySecret:     colonySecret,
 		WireguardPubkey:  agentPubKey,
-		Version:          "0.1.0",
-		Labels:           make(map[string]string),
+		Version:          version.Version,
+		ProtocolVersion:  strconv.Itoa(protocol.Version),
+		Labels:           cfg.Labels,
 		Services:         services,
 		EbpfCapabilities: ebpfCaps,
 		RuntimeContext:   runtimeContext,
@@ -462,9 +571,65 @@ func registerWithColony(
 		regReq.ComponentName = serviceSpecs[0].Name
 	}
 
+	// Agents holding a certificate from the colony CA register over mTLS on
+	// the public endpoint of each candidate host first. They fall back to
+	// the mesh service when it cannot be reached, but not when the colony
+	// rejects their certificate.
+	var mtlsClient *http.Client
+	if tlsConfig != nil {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.ServerName = cfg.ColonyID // The colony's server certificate names its colony ID.
+		mtlsClient = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	}
+	publicPort := colonyInfo.PublicPort
+	if publicPort == 0 {
+		publicPort = uint32(constants.DefaultPublicEndpointPort)
+	}
+
 	var lastErr error
 	var attemptErrors []string
 	for _, baseURL := range candidateURLs {
+		if mtlsClient != nil {
+			mtlsURL, err := publicEndpointURL(baseURL, publicPort)
+			if err == nil {
+				logger.Info().
+					Str("agent_id", agentID).
+					Str("endpoint", mtlsURL).
+					Msg("Attempting colony registration over mTLS")
+
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				resp, err := meshv1connect.NewMeshServiceClient(mtlsClient, mtlsURL).Register(ctx, connect.NewRequest(regReq))
+				cancel()
+
+				switch {
+				case err != nil:
+					logger.Debug().
+						Err(err).
+						Str("endpoint", mtlsURL).
+						Msg("Colony registration over mTLS failed, falling back to the mesh service")
+					attemptErrors = append(attemptErrors, fmt.Sprintf("%s: %v", mtlsURL, err))
+				case !resp.Msg.Accepted:
+					lastErr = fmt.Errorf("registration rejected by colony: %s", rejectionReason(resp.Msg))
+					logger.Warn().
+						Str("endpoint", mtlsURL).
+						Msg(lastErr.Error())
+					attemptErrors = append(attemptErrors, fmt.Sprintf("%s: %s", mtlsURL, resp.Msg.Reason))
+					if resp.Msg.Reason == reasonUnsupportedProtocol {
+						return "", "", lastErr
+					}
+					continue
+				default:
+					logger.Info().
+						Str("assigned_ip", resp.Msg.AssignedIp).
+						Str("assigned_ipv6", resp.Msg.AssignedIpv6).
+						Str("successful_url", mtlsURL).
+						Uint32("protocol_version", resp.Msg.ProtocolVersion).
+						Msg("Successfully registered with colony over mTLS")
+					return registrationResult(resp.Msg), baseURL, nil
+				}
+			}
+		}
+
 		client := meshv1connect.NewMeshServiceClient(http.DefaultClient, baseURL)
 
 		for attempt := 1; attempt <= 3; attempt++ {
@@ -495,13 +660,16 @@ func registerWithColony(
 			}
 
 			if !resp.Msg.Accepted {
-				lastErr = fmt.Errorf("registration rejected by colony: %s", resp.Msg.Reason)
+				lastErr = fmt.Errorf("registration rejected by colony: %s", rejectionReason(resp.Msg))
 				logger.Warn().
 					Str("endpoint", baseURL).
 					Int("attempt", attempt).
 					Msg(lastErr.Error())
 
 				attemptErrors = append(attemptErrors, fmt.Sprintf("%s attempt %d: %s", baseURL, attempt, resp.Msg.Reason))
+				if resp.Msg.Reason == reasonUnsupportedProtocol {
+					return "", "", lastErr
+				}
 				if attempt < 3 {
 					time.Sleep(time.Duration(attempt) * time.Second)
 				}
@@ -511,13 +679,13 @@ func registerWithColony(
 			logger.Info().
 				Str("assigned_ip", resp.Msg.AssignedIp).
 				Str("mesh_subnet", resp.Msg.MeshSubnet).
+				Str("assigned_ipv6", resp.Msg.AssignedIpv6).
 				Int("peer_count", len(resp.Msg.Peers)).
 				Str("successful_url", baseURL).
+				Uint32("protocol_version", resp.Msg.ProtocolVersion).
 				Msg("Successfully registered with colony")
 
-			// Return IP|subnet format and the successful URL
-			result := fmt.Sprintf("%s|%s", resp.Msg.AssignedIp, resp.Msg.MeshSubnet)
-			return result, baseURL, nil
+			return registrationResult(resp.Msg), baseURL, nil
 		}
 	}
 
@@ -532,6 +700,37 @@ func registerWithColony(
 	return "", "", fmt.Errorf("registration attempts exhausted: %w", lastErr)
 }
 
+// reasonUnsupportedProtocol is the reason colonies reject agents older than
+// their minimum protocol version with. Other colonies will not accept them
+// either, so registration stops.
+const reasonUnsupportedProtocol = "unsupported_protocol_version"
+
+// rejectionReason explains why the colony rejected a registration.
+func rejectionReason(resp *meshv1.RegisterResponse) string {
+	if resp.Reason != reasonUnsupportedProtocol {
+		return resp.Reason
+	}
+	return fmt.Sprintf("%s: the agent speaks protocol version %d, the colony accepts versions %d to %d; upgrade the agent",
+		resp.Reason, protocol.Version, resp.MinProtocolVersion, resp.MaxProtocolVersion)
+}
+
+// registrationResult returns the IP|subnet|IPv6|IPv6 subnet format of a
+// registration response. The IPv6 fields are empty for colonies without an
+// IPv6 mesh.
+func registrationResult(resp *meshv1.RegisterResponse) string {
+	return fmt.Sprintf("%s|%s|%s|%s", resp.AssignedIp, resp.MeshSubnet, resp.AssignedIpv6, resp.MeshSubnetIpv6)
+}
+
+// publicEndpointURL returns the URL of the colony's public endpoint on the
+// host of the mesh service URL baseURL.
+func publicEndpointURL(baseURL string, publicPort uint32) (string, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://%s", net.JoinHostPort(u.Hostname(), strconv.FormatUint(uint64(publicPort), 10))), nil
+}
+
 // buildMeshServiceURLs returns candidate URLs for contacting the colony's mesh service.
 // If preferredURL is provided and exists in the candidate list, it will be returned first.
 //
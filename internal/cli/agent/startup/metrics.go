@@ -0,0 +1,60 @@
+package startup
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/coral-mesh/coral/internal/metrics"
+)
+
+// createMetricsHandler creates the /metrics endpoint handler exporting agent
+// operational metrics in the Prometheus text format.
+func (s *ServiceRegistry) createMetricsHandler() http.Handler {
+	return metrics.Handler(func(_ context.Context, w *metrics.Writer) {
+		connected := 0.0
+		if s.connectionMgr != nil {
+			if state := s.connectionMgr.GetState(); state == StateRegistered || state == StateHealthy {
+				connected = 1
+			}
+		}
+		w.Gauge("coral_agent_colony_connected", "Whether the agent is registered with the colony.", connected)
+
+		w.Gauge("coral_agent_services", "Services monitored by the agent.", float64(s.agentInstance.GetServiceCount()))
+
+		health := s.agentInstance.HealthMetrics()
+		w.Gauge("coral_agent_uprobe_queue_depth", "Uprobe events buffered in memory and not yet written to the local store.",
+			float64(health.QueueDepth))
+		w.Gauge("coral_agent_ebpf_recent_failures", "eBPF collectors that failed to load or attach in the recent window.",
+			float64(health.EbpfFailures))
+		if ebpfManager := s.agentInstance.GetEbpfManager(); ebpfManager != nil {
+			w.Gauge("coral_agent_ebpf_collectors_active", "Running eBPF collectors.", float64(ebpfManager.ActiveCollectors()))
+		}
+
+		if s.eventPusher != nil {
+			queued, dropped := s.eventPusher.Stats()
+			w.Gauge("coral_agent_event_push_queue_depth", "Uprobe events queued for push to the colony.", float64(queued))
+			w.Counter("coral_agent_event_push_dropped_total", "Uprobe events dropped because the push queue was full.", float64(dropped))
+		}
+
+		if shedding := s.agentInstance.ResourceShedding(); shedding != nil {
+			active := 0.0
+			if shedding.Active {
+				active = 1
+			}
+			w.Gauge("coral_agent_resource_shedding_active", "Whether the agent sheds work to stay within its resource limits.", active)
+			w.Gauge("coral_agent_cpu_percent", "Agent CPU usage at the last resource check, in percent of one core.", shedding.CpuPercent)
+			w.Gauge("coral_agent_memory_bytes", "Agent resident memory at the last resource check.", float64(shedding.MemoryBytes))
+		}
+
+		if s.sharedDBPath != "" {
+			if info, err := os.Stat(s.sharedDBPath); err == nil {
+				w.Gauge("coral_agent_database_size_bytes", "Size of the agent DuckDB database file.", float64(info.Size()))
+			}
+		}
+
+		if s.wgDevice != nil {
+			w.Gauge("coral_agent_wireguard_peers", "Configured WireGuard peers.", float64(len(s.wgDevice.ListPeers())))
+		}
+	})
+}
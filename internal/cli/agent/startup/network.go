@@ -1,9 +1,11 @@
 package startup
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
+	"slices"
 	"strconv"
 	"time"
 
@@ -57,22 +59,22 @@ func NewNetworkInitializer(
 func (n *NetworkInitializer) Initialize() (*NetworkResult, error) {
 	result := &NetworkResult{}
 
-	// Step 1: Query discovery service for colony information.
+	// Step 1: Locate the colony (static config, DNS or discovery service).
 	n.logger.Info().
 		Str("colony_id", n.cfg.ColonyID).
-		Msg("Querying discovery service for colony information")
+		Msg("Locating colony")
 
-	colonyInfo, err := QueryDiscoveryForColony(n.cfg, n.logger)
+	colonyInfo, err := LocateColony(n.cfg, n.logger)
 	if err != nil {
 		n.logger.Warn().
 			Err(err).
-			Msg("Failed to query discovery service - will retry in background")
+			Msg("Failed to locate colony - will retry in background")
 		colonyInfo = nil // Agent will start in waiting_discovery state
 	} else {
 		n.logger.Info().
 			Str("colony_pubkey", colonyInfo.Pubkey).
 			Strs("endpoints", colonyInfo.Endpoints).
-			Msg("Received colony information from discovery")
+			Msg("Received colony information")
 	}
 	result.ColonyInfo = colonyInfo
 
@@ -99,6 +101,11 @@ func (n *NetworkInitializer) Initialize() (*NetworkResult, error) {
 	// Relay setting is loaded from config (env var override via MergeFromEnv)
 	enableRelay := n.agentCfg.Agent.NAT.EnableRelay
 
+	userspace, err := wireguard.ParseUserspaceMode(n.agentCfg.Agent.WireGuard.Userspace)
+	if err != nil {
+		return nil, fmt.Errorf("invalid agent.wireguard.userspace: %w", err)
+	}
+
 	// Step 5: Get WireGuard port from environment or use ephemeral (-1).
 	wgPort := -1 // Default: ephemeral port
 	if envPort := os.Getenv("CORAL_WIREGUARD_PORT"); envPort != "" {
@@ -120,6 +127,8 @@ func (n *NetworkInitializer) Initialize() (*NetworkResult, error) {
 		colonyInfo,
 		stunServers,
 		enableRelay,
+		!n.agentCfg.Agent.NAT.DisableHolePunching,
+		userspace,
 		wgPort,
 		n.logger,
 	)
@@ -132,7 +141,10 @@ func (n *NetworkInitializer) Initialize() (*NetworkResult, error) {
 	n.logger.Debug().Msg("Agent running with elevated privileges for eBPF/Beyla operations")
 
 	// Step 7: Register agent with discovery service using the observed endpoint from STUN.
-	if agentObservedEndpoint != nil {
+	// Agents locating the colony through static config or DNS bypass discovery.
+	if n.cfg.StaticColony != nil || n.cfg.ColonyDNS != "" {
+		n.logger.Debug().Msg("Colony located without discovery service, skipping discovery registration")
+	} else if agentObservedEndpoint != nil {
 		n.logger.Info().
 			Str("agent_id", n.agentID).
 			Str("public_ip", agentObservedEndpoint.IP).
@@ -150,9 +162,11 @@ func (n *NetworkInitializer) Initialize() (*NetworkResult, error) {
 }
 
 // ConfigureMesh configures the agent mesh with permanent IP from colony.
+// meshIPv6 and meshSubnetIPv6 are empty if the colony has no IPv6 mesh.
 func (n *NetworkInitializer) ConfigureMesh(
 	result *NetworkResult,
 	meshIP, meshSubnet string,
+	meshIPv6, meshSubnetIPv6 string,
 	colonyEndpoint string,
 ) error {
 	// Parse IP and subnet for mesh configuration (RFD 019).
@@ -172,7 +186,8 @@ func (n *NetworkInitializer) ConfigureMesh(
 		Str("subnet", meshSubnet).
 		Msg("Configuring agent mesh with permanent IP from colony")
 
-	if err := ConfigureAgentMesh(result.WireGuardDevice, parsedMeshIP, parsedMeshSubnet, result.ColonyInfo, colonyEndpoint, n.logger); err != nil {
+	parsedMeshIPv6, parsedMeshSubnetIPv6 := parseMeshIPv6(meshIPv6, meshSubnetIPv6)
+	if err := ConfigureAgentMesh(result.WireGuardDevice, parsedMeshIP, parsedMeshSubnet, parsedMeshIPv6, parsedMeshSubnetIPv6, result.ColonyInfo, colonyEndpoint, n.logger); err != nil {
 		return fmt.Errorf("failed to configure agent mesh: %w", err)
 	}
 
@@ -194,7 +209,9 @@ func (n *NetworkInitializer) ConfigureMesh(
 			Str("mesh_addr", meshAddr).
 			Msg("Testing connectivity to colony via mesh to establish WireGuard handshake")
 
-		conn, err := net.DialTimeout("tcp", meshAddr, 5*time.Second)
+		dialCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		conn, err := result.WireGuardDevice.DialContext(dialCtx, "tcp", meshAddr)
+		cancel()
 		if err != nil {
 			n.logger.Warn().
 				Err(err).
@@ -211,17 +228,63 @@ func (n *NetworkInitializer) ConfigureMesh(
 	return nil
 }
 
+// parseMeshIPv6 parses the IPv6 mesh assignment from the colony. It returns
+// nils if the colony has no IPv6 mesh or sent an invalid assignment.
+func parseMeshIPv6(meshIPv6, meshSubnetIPv6 string) (net.IP, *net.IPNet) {
+	ip := net.ParseIP(meshIPv6)
+	_, subnet, err := net.ParseCIDR(meshSubnetIPv6)
+	if ip == nil || ip.To4() != nil || err != nil {
+		return nil, nil
+	}
+	return ip, subnet
+}
+
 // getSTUNServers determines which STUN servers to use for NAT traversal.
-// Priority: agent config (with env override via MergeFromEnv) > discovery response > default.
-func (n *NetworkInitializer) getSTUNServers(_ *discovery.LookupColonyResponse) []string {
-	// Check agent config (env var CORAL_STUN_SERVERS is merged via MergeFromEnv).
+// The colony's WireGuard endpoints come first: colonies answer STUN on their
+// WireGuard port (RFD 029), which yields the NAT mapping for the colony's
+// address even behind symmetric NAT. The agent config (with env override via
+// MergeFromEnv) or the default public server is the fallback for colonies
+// without a STUN server.
+func (n *NetworkInitializer) getSTUNServers(colonyInfo *discovery.LookupColonyResponse) []string {
+	var servers []string
+	if !n.agentCfg.Agent.NAT.DisableColonySTUN {
+		servers = colonySTUNServers(colonyInfo)
+	}
+
 	if len(n.agentCfg.Agent.NAT.STUNServers) > 0 {
-		return n.agentCfg.Agent.NAT.STUNServers
+		return append(servers, n.agentCfg.Agent.NAT.STUNServers...)
 	}
+	return append(servers, constants.DefaultSTUNServer)
+}
 
-	// Use STUN servers from discovery response (not yet implemented in response).
-	// This would be added when colonies register with their STUN servers.
+// colonySTUNServers returns the colony's public IPv4 WireGuard endpoints,
+// observed endpoints first. Loopback endpoints are skipped: they do not
+// reveal the agent's public address.
+func colonySTUNServers(colonyInfo *discovery.LookupColonyResponse) []string {
+	if colonyInfo == nil {
+		return nil
+	}
+
+	candidates := make([]string, 0, len(colonyInfo.ObservedEndpoints)+len(colonyInfo.Endpoints))
+	for _, ep := range colonyInfo.ObservedEndpoints {
+		if ep.IP != "" && ep.Port != 0 {
+			candidates = append(candidates, net.JoinHostPort(ep.IP, strconv.FormatUint(uint64(ep.Port), 10)))
+		}
+	}
+	candidates = append(candidates, colonyInfo.Endpoints...)
 
-	// Fall back to default.
-	return []string{constants.DefaultSTUNServer}
+	var servers []string
+	for _, endpoint := range candidates {
+		host, _, err := net.SplitHostPort(endpoint)
+		if err != nil || host == "" || host == "localhost" {
+			continue
+		}
+		if ip := net.ParseIP(host); ip != nil && (ip.IsLoopback() || ip.To4() == nil) {
+			continue
+		}
+		if !slices.Contains(servers, endpoint) {
+			servers = append(servers, endpoint)
+		}
+	}
+	return servers
 }
@@ -2,6 +2,7 @@ package startup
 
 import (
 	"context"
+	"crypto/x509"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"os/exec"
 	"time"
 
+	"connectrpc.com/connect"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/protobuf/proto"
@@ -18,10 +20,16 @@ import (
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
 	"github.com/coral-mesh/coral/internal/agent"
+	"github.com/coral-mesh/coral/internal/agent/certs"
 	"github.com/coral-mesh/coral/internal/agent/collector"
+	"github.com/coral-mesh/coral/internal/agent/eventpush"
+	"github.com/coral-mesh/coral/internal/agent/logtail"
 	"github.com/coral-mesh/coral/internal/agent/netobs"
 	"github.com/coral-mesh/coral/internal/agent/profiler"
+	"github.com/coral-mesh/coral/internal/agent/recording"
 	"github.com/coral-mesh/coral/internal/agent/telemetry"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/bandwidth"
 	"github.com/coral-mesh/coral/internal/cli/agent/types"
 	"github.com/coral-mesh/coral/internal/config"
 	"github.com/coral-mesh/coral/internal/constants"
@@ -62,6 +70,8 @@ type ServiceRegistry struct {
 	meshSubnet    string // Deprecated: Use connectionManager.GetAssignedIP()
 	connectionMgr *ConnectionManager
 	sessionID     string // Database session UUID for checkpoint tracking (RFD 089).
+	eventPusher   *eventpush.Pusher
+	recordings    *recording.Uploader
 }
 
 // NewServiceRegistry creates a new service registry.
@@ -189,6 +199,12 @@ func (s *ServiceRegistry) Register(runtimeService *agent.RuntimeService) (*Servi
 		s.logger.Info().Msg("Continuous memory profiling is disabled via configuration")
 	}
 
+	// Push uprobe events of colony debug sessions as they are captured.
+	s.startEventPusher(ctx)
+
+	// Upload recordings of shell and exec sessions as they end.
+	s.startRecordingUploader(ctx)
+
 	// Create and register HTTP servers.
 	meshServer, localhostServer, err := s.createHTTPServers(runtimeService, otlpReceiver, result.SystemMetricsHandler)
 	if err != nil {
@@ -209,6 +225,11 @@ func (s *ServiceRegistry) Register(runtimeService *agent.RuntimeService) (*Servi
 	// Initialize L4 network topology observation (RFD 033).
 	s.startNetworkObserver(ctx)
 
+	// Initialize service log collection for coral tail.
+	if s.agentCfg.Logs.Enabled {
+		s.startLogCollector(ctx)
+	}
+
 	return result, nil
 }
 
@@ -244,6 +265,124 @@ func (s *ServiceRegistry) startNetworkObserver(ctx context.Context) {
 	s.logger.Info().Msg("L4 network topology observation started")
 }
 
+// startLogCollector creates and starts the collector pushing the logs of
+// opted-in services to the colony. Non-fatal: a warning is logged on failure.
+func (s *ServiceRegistry) startLogCollector(ctx context.Context) {
+	if s.connectionMgr == nil {
+		s.logger.Debug().Msg("No colony connection manager, skipping log collection")
+		return
+	}
+
+	files := make([]logtail.File, 0, len(s.agentCfg.Logs.Files))
+	for _, f := range s.agentCfg.Logs.Files {
+		files = append(files, logtail.File{Service: f.Service, Path: f.Path})
+	}
+
+	collector := logtail.NewCollector(logtail.Config{
+		AgentID: s.agentID,
+		URLProvider: func() string {
+			return s.connectionMgr.GetLastSuccessfulRegURL()
+		},
+		Services: func() []logtail.Service {
+			infos := s.agentInstance.ServiceInfos()
+			services := make([]logtail.Service, 0, len(infos))
+			for _, info := range infos {
+				services = append(services, logtail.Service{
+					Name:   info.Name,
+					Labels: info.Labels,
+					PID:    info.ProcessId,
+				})
+			}
+			return services
+		},
+		Include:    s.agentCfg.Logs.Services,
+		Files:      files,
+		DockerRoot: s.agentCfg.ServiceDiscovery.DockerRoot,
+		Interval:   s.agentCfg.Logs.Interval,
+		Redactor:   s.agentInstance.Redactor(),
+		Logger:     s.logger.With().Str("component", "logtail").Logger(),
+	})
+
+	if err := collector.Start(); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to start log collection")
+		return
+	}
+
+	// Stop the collector when the service context is cancelled.
+	go func() {
+		<-ctx.Done()
+		if err := collector.Stop(); err != nil {
+			s.logger.Warn().Err(err).Msg("Error stopping log collection")
+		}
+	}()
+}
+
+// startEventPusher creates and starts the pusher that streams uprobe events of
+// colony debug sessions to the colony. Non-fatal: a warning is logged on
+// failure.
+func (s *ServiceRegistry) startEventPusher(ctx context.Context) {
+	if s.connectionMgr == nil {
+		s.logger.Debug().Msg("No colony connection manager, skipping uprobe event push")
+		return
+	}
+
+	pusher := eventpush.NewPusher(eventpush.Config{
+		AgentID: s.agentID,
+		URLProvider: func() string {
+			return s.connectionMgr.GetLastSuccessfulRegURL()
+		},
+		Logger: s.logger.With().Str("component", "eventpush").Logger(),
+	})
+
+	if err := pusher.Start(); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to start uprobe event push")
+		return
+	}
+
+	s.agentInstance.GetEbpfManager().SetUprobeEventSink(pusher.Push)
+	s.eventPusher = pusher
+
+	// Stop the pusher when the service context is cancelled.
+	go func() {
+		<-ctx.Done()
+		s.agentInstance.GetEbpfManager().SetUprobeEventSink(nil)
+		if err := pusher.Stop(); err != nil {
+			s.logger.Warn().Err(err).Msg("Error stopping uprobe event push")
+		}
+	}()
+}
+
+// startRecordingUploader creates and starts the uploader of shell and exec
+// session recordings. Sessions are not recorded without a colony to upload
+// them to.
+func (s *ServiceRegistry) startRecordingUploader(ctx context.Context) {
+	if s.connectionMgr == nil {
+		s.logger.Debug().Msg("No colony connection manager, skipping shell recording upload")
+		return
+	}
+
+	uploader := recording.NewUploader(recording.Config{
+		AgentID: s.agentID,
+		URLProvider: func() string {
+			return s.connectionMgr.GetLastSuccessfulRegURL()
+		},
+		Logger: s.logger.With().Str("component", "recording").Logger(),
+	})
+
+	if err := uploader.Start(); err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to start shell recording upload")
+		return
+	}
+	s.recordings = uploader
+
+	go func() {
+		<-ctx.Done()
+		if err := uploader.Stop(); err != nil {
+			s.logger.Warn().Err(err).Msg("Error stopping shell recording upload")
+		}
+	}()
+}
+
 // buildTelemetryConfig creates telemetry configuration from agent config.
 func (s *ServiceRegistry) buildTelemetryConfig() telemetry.Config {
 	telemetryConfig := telemetry.Config{
@@ -442,6 +581,27 @@ func (s *ServiceRegistry) startContinuousMemoryProfiler() error {
 	return nil
 }
 
+// newCapabilityInterceptor creates the interceptor requiring capability
+// tokens for shell and exec, verified against the colony's root CA.
+func (s *ServiceRegistry) newCapabilityInterceptor() *agent.CapabilityInterceptor {
+	verifier, err := loadCapabilityVerifier(s.agentCfg.Agent.Bootstrap.CertsDir, s.cfg.ColonyID, s.logger)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Shell and exec are disabled")
+	}
+
+	services := func() []string {
+		statuses := s.agentInstance.GetServiceStatuses()
+		names := make([]string, 0, len(statuses))
+		for name := range statuses {
+			names = append(names, name)
+		}
+		return names
+	}
+
+	s.logger.Info().Msg("Shell and exec require capability tokens")
+	return agent.NewCapabilityInterceptor(verifier, services, s.logger)
+}
+
 // createHTTPServers creates mesh and localhost HTTP servers.
 func (s *ServiceRegistry) createHTTPServers(
 	runtimeService *agent.RuntimeService,
@@ -450,21 +610,37 @@ func (s *ServiceRegistry) createHTTPServers(
 ) (*http.Server, *http.Server, error) {
 	// Create shell handler (RFD 026).
 	shellHandler := agent.NewShellHandler(s.logger)
+	shellHandler.SetRedactor(s.agentInstance.Redactor())
+	if s.recordings != nil {
+		shellHandler.SetRecordingSink(s.recordings.Push)
+	}
 
 	// Create container handler (RFD 056).
 	containerHandler := agent.NewContainerHandler(s.logger)
+	containerHandler.SetRedactor(s.agentInstance.Redactor())
+	if s.recordings != nil {
+		containerHandler.SetRecordingSink(s.recordings.Push)
+	}
 
 	// Create service handler and HTTP server for gRPC API.
 	serviceHandler := agent.NewServiceHandler(s.agentInstance, runtimeService, otlpReceiver, shellHandler, containerHandler, s.functionCache, systemMetricsHandler)
 	serviceHandler.SetSessionID(s.sessionID)
 	serviceHandler.SetMeshInfoProvider(s.gatherMeshNetworkInfo)
+	serviceHandler.SetFileHandler(agent.NewFileHandler(s.agentCfg.Agent.Security.MaxFileTransferBytes, s.logger))
 	systemMetricsHandler.SetSessionID(s.sessionID)
-	path, handler := agentv1connect.NewAgentServiceHandler(serviceHandler)
+	handlerOpts := bandwidth.HandlerOptions()
+	if s.agentCfg.Agent.Security.RequireCapabilityTokens {
+		handlerOpts = append(handlerOpts, connect.WithInterceptors(s.newCapabilityInterceptor()))
+	}
+	path, handler := agentv1connect.NewAgentServiceHandler(serviceHandler, handlerOpts...)
 
 	// Create debug service handler (RFD 059).
 	debugService := agent.NewDebugService(s.agentInstance, s.logger)
 	debugService.SetSessionID(s.sessionID)
-	debugPath, debugHandler := agentv1connect.NewAgentDebugServiceHandler(&debugServiceAdapter{service: debugService})
+	if s.eventPusher != nil {
+		debugService.SetEventFlusher(s.eventPusher.Flush)
+	}
+	debugPath, debugHandler := agentv1connect.NewAgentDebugServiceHandler(&debugServiceAdapter{service: debugService}, bandwidth.HandlerOptions()...)
 
 	mux := http.NewServeMux()
 	mux.Handle(path, handler)
@@ -473,6 +649,9 @@ func (s *ServiceRegistry) createHTTPServers(
 	// Add /status endpoint.
 	mux.HandleFunc("/status", s.createStatusHandler(runtimeService))
 
+	// Add /metrics endpoint for Prometheus scraping.
+	mux.Handle("/metrics", s.createMetricsHandler())
+
 	// Add /duckdb/ endpoint for serving DuckDB files (RFD 039).
 	s.registerDuckDBHandler(mux)
 
@@ -485,8 +664,9 @@ func (s *ServiceRegistry) createHTTPServers(
 
 	// Create mesh server.
 	var meshServer *http.Server
+	userspace := s.wgDevice != nil && s.wgDevice.Userspace()
 	if s.meshIP != "" {
-		if bindAll {
+		if bindAll && !userspace {
 			s.logger.Info().
 				Str("mesh_ip", s.meshIP).
 				Msg("CORAL_AGENT_BIND_ALL enabled; mesh traffic will be handled by localhost server (0.0.0.0)")
@@ -499,18 +679,40 @@ func (s *ServiceRegistry) createHTTPServers(
 				ReadHeaderTimeout: 30 * time.Second,
 			}
 
-			go func() {
-				s.logger.Info().
-					Str("addr", meshAddr).
-					Msg("Agent API listening on WireGuard mesh")
-
-				if err := meshServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-					s.logger.Error().
-						Err(err).
-						Str("addr", meshAddr).
-						Msg("Mesh API server error")
+			// Serve on the IPv6 mesh IP too, so the colony can reach the
+			// agent over whichever family works.
+			meshAddrs := []string{meshAddr}
+			if s.connectionMgr != nil {
+				if meshIPv6, _ := s.connectionMgr.GetAssignedIPv6(); meshIPv6 != "" {
+					meshAddrs = append(meshAddrs, net.JoinHostPort(meshIPv6, "9001"))
 				}
-			}()
+			}
+
+			for _, addr := range meshAddrs {
+				go func() {
+					s.logger.Info().
+						Str("addr", addr).
+						Msg("Agent API listening on WireGuard mesh")
+
+					var ln net.Listener
+					var err error
+					if userspace {
+						// The mesh IP only exists on the userspace stack.
+						ln, err = s.wgDevice.Listen("tcp", addr)
+					} else {
+						ln, err = net.Listen("tcp", addr)
+					}
+					if err == nil {
+						err = meshServer.Serve(ln)
+					}
+					if err != nil && err != http.ErrServerClosed {
+						s.logger.Error().
+							Err(err).
+							Str("addr", addr).
+							Msg("Mesh API server error")
+					}
+				}()
+			}
 		}
 	} else {
 		s.logger.Warn().Msg("No mesh IP available, skipping mesh server (agent not registered)")
@@ -772,7 +974,13 @@ func (s *ServiceRegistry) gatherMeshNetworkInfo() map[string]interface{} {
 		connTest["target"] = meshAddr
 
 		// Quick connectivity check.
-		conn, err := net.DialTimeout("tcp", meshAddr, 2*time.Second)
+		dial := (&net.Dialer{}).DialContext
+		if s.wgDevice != nil {
+			dial = s.wgDevice.DialContext
+		}
+		dialCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		conn, err := dial(dialCtx, "tcp", meshAddr)
+		cancel()
 		if err != nil {
 			connTest["reachable"] = false
 			connTest["error"] = err.Error()
@@ -795,3 +1003,22 @@ func (s *ServiceRegistry) gatherMeshNetworkInfo() map[string]interface{} {
 
 	return info
 }
+
+// loadCapabilityVerifier creates a verifier of the tokens signed by the
+// colony colonyID, from the root CA in the agent's certificate directory.
+func loadCapabilityVerifier(certsDir, colonyID string, logger logging.Logger) (*auth.CapabilityVerifier, error) {
+	rootCAPath := certs.NewManager(certs.Config{
+		CertsDir: certsDir,
+		Logger:   logger,
+	}).GetRootCAPath()
+	//nolint:gosec // G304: Path is in the agent's certificate directory.
+	rootCA, err := os.ReadFile(rootCAPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read root CA: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(rootCA) {
+		return nil, fmt.Errorf("invalid root CA %s", rootCAPath)
+	}
+	return auth.NewCapabilityVerifier(roots, colonyID), nil
+}
@@ -1,12 +1,17 @@
 package startup
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/spf13/cobra"
 
+	"github.com/coral-mesh/coral/internal/agent/coredump"
+	"github.com/coral-mesh/coral/internal/agent/selfupdate"
 	"github.com/coral-mesh/coral/internal/cli/helpers"
 	"github.com/coral-mesh/coral/internal/discovery"
 	"github.com/coral-mesh/coral/internal/logging"
@@ -50,6 +55,7 @@ Environment Variables:
   CORAL_CA_FINGERPRINT   - Root CA fingerprint for bootstrap (sha256:hex)
   CORAL_DISCOVERY_ENDPOINT - Discovery service URL
   CORAL_SERVICES         - Services to monitor (format: name:port[:health][:type],...)
+  CORAL_AGENT_LABELS     - Labels to register with (format: key=value,...)
   CORAL_LOG_LEVEL        - Logging level (debug, info, warn, error)
   CORAL_LOG_FORMAT       - Logging format (json, pretty)
 
@@ -59,6 +65,8 @@ Configuration File Format:
     colony:
       id: "production"
       auto_discover: true
+    labels:
+      env: "prod"
   services:
     - name: "api"
       port: 8080
@@ -90,6 +98,20 @@ Examples:
 				Pretty: true,
 			}, "agent")
 
+			// Roll back an installed agent release that failed to start.
+			if executable, err := selfupdate.CurrentExecutable(); err == nil {
+				rolledBack, err := selfupdate.BeginTrial(executable)
+				if err != nil {
+					logger.Warn().Err(err).Msg("Failed to check the installed agent release")
+				}
+				if rolledBack {
+					logger.Warn().Msg("Installed agent release failed to start - restarting the previous executable")
+					if err := selfupdate.Exec(executable); err != nil {
+						return fmt.Errorf("failed to restart the previous agent executable, restart the agent: %w", err)
+					}
+				}
+			}
+
 			// Create and configure builder.
 			builder := NewAgentServerBuilder(
 				cmd.Context(),
@@ -99,6 +121,7 @@ Examples:
 				connectService,
 				monitorAll,
 			)
+			builder.SetCoreDumpHelperCommand(coreDumpHelperCommand(cmd))
 
 			// Phase 1: Validate (preflight + config).
 			if err := builder.Validate(); err != nil {
@@ -138,7 +161,11 @@ Examples:
 
 			// Build final server.
 			server := builder.Build()
+			stopped := false
 			defer func() {
+				if stopped {
+					return
+				}
 				if err := server.Stop(); err != nil {
 					logger.Error().Err(err).Msg("Error stopping agent server")
 				}
@@ -157,15 +184,35 @@ Examples:
 			go server.ConnectionManager.StartHeartbeatLoop(ctx, builder.Config().Agent.HeartbeatInterval)
 			go server.ConnectionManager.StartReconnectionLoop(ctx)
 
-			// Wait for interrupt signal.
+			// Wait for interrupt signal, or for an agent release to be
+			// installed.
 			sigChan := make(chan os.Signal, 1)
 			signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-			sig := <-sigChan
-
-			logger.Info().
-				Str("signal", sig.String()).
-				Msg("Received shutdown signal - stopping agent")
+			select {
+			case sig := <-sigChan:
+				logger.Info().
+					Str("signal", sig.String()).
+					Msg("Received shutdown signal - stopping agent")
+				return nil
+			case <-server.Updater.Restart():
+			}
 
+			// Run the installed release in place of this process.
+			logger.Info().Msg("Restarting agent to run the installed release")
+			stopped = true
+			if err := server.Stop(); err != nil {
+				logger.Error().Err(err).Msg("Error stopping agent server")
+			}
+			if err := selfupdate.Exec(server.Updater.Executable()); err != nil {
+				if errors.Is(err, selfupdate.ErrRestartUnsupported) {
+					return fmt.Errorf("failed to restart agent, restart it to run the installed release: %w", err)
+				}
+				// The release cannot run: go back to the previous executable.
+				if rollbackErr := selfupdate.Rollback(server.Updater.Executable()); rollbackErr != nil {
+					logger.Error().Err(rollbackErr).Msg("Failed to roll back the installed agent release")
+				}
+				return fmt.Errorf("failed to restart agent: %w", err)
+			}
 			return nil
 		},
 	}
@@ -178,3 +225,17 @@ Examples:
 
 	return cmd
 }
+
+// coreDumpHelperCommand returns the command line that runs the coredump-helper
+// subcommand next to the given start command, e.g. "coral agent
+// coredump-helper" or "coral-agent coredump-helper".
+func coreDumpHelperCommand(cmd *cobra.Command) []string {
+	exe, err := os.Executable()
+	if err != nil || cmd.Parent() == nil {
+		return nil
+	}
+
+	// Drop the root command name; the executable replaces it.
+	command := append([]string{exe}, strings.Fields(cmd.Parent().CommandPath())[1:]...)
+	return append(command, coredump.HelperCommand)
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/coral-mesh/coral/internal/agent"
 	"github.com/coral-mesh/coral/internal/agent/beyla"
+	"github.com/coral-mesh/coral/internal/agent/debug"
+	"github.com/coral-mesh/coral/internal/agent/ebpf"
 	"github.com/coral-mesh/coral/internal/cli/agent/types"
 	"github.com/coral-mesh/coral/internal/config"
 	"github.com/coral-mesh/coral/internal/duckdb"
@@ -22,6 +24,8 @@ type StorageResult struct {
 	SessionID     string // Unique ID for this database instance (RFD 089).
 	BeylaConfig   *beyla.Config
 	FunctionCache *agent.FunctionCache
+	EventStore    *ebpf.EventStore
+	ProfileStore  *debug.ProfileStore
 }
 
 // StorageManager handles DuckDB initialization and database setup.
@@ -172,6 +176,28 @@ func (s *StorageManager) Initialize() (*StorageResult, error) {
 		result.FunctionCache = functionCache
 	}
 
+	// Create on-disk uprobe event ring so debug events survive agent
+	// restarts and colony outages.
+	if sharedDB != nil && !s.agentCfg.Debug.EventStore.Disabled {
+		eventStore, err := ebpf.NewEventStore(sharedDB, ebpf.EventStoreConfig{
+			MaxEvents:     s.agentCfg.Debug.EventStore.MaxEvents,
+			FlushInterval: s.agentCfg.Debug.EventStore.FlushInterval,
+		}, s.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create uprobe event store: %w", err)
+		}
+		result.EventStore = eventStore
+	}
+
+	// Create store for on-demand profiles requested by the local CLI.
+	if sharedDB != nil {
+		profileStore, err := debug.NewProfileStore(sharedDB, s.logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create profile store: %w", err)
+		}
+		result.ProfileStore = profileStore
+	}
+
 	return result, nil
 }
 
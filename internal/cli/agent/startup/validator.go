@@ -272,6 +272,9 @@ func (v *ConfigValidator) loadAgentConfig() (*config.ResolvedConfig, []*types.Se
 				ServiceType:    svc.Type,
 				Labels:         make(map[string]string),
 			}
+			for k, v := range svc.Labels {
+				spec.Labels[k] = v
+			}
 			serviceSpecs = append(serviceSpecs, spec)
 		}
 	}
@@ -318,6 +321,11 @@ func (v *ConfigValidator) loadAgentConfig() (*config.ResolvedConfig, []*types.Se
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to load colony config: %w", err)
 	}
+	cfg.ColonyDNS = agentCfg.Agent.Colony.DNS
+	cfg.Labels = agentCfg.Agent.Labels
+	if agentCfg.Agent.Colony.Static.Pubkey != "" {
+		cfg.StaticColony = &agentCfg.Agent.Colony.Static
+	}
 
 	return cfg, serviceSpecs, agentCfg, nil
 }
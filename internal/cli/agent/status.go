@@ -252,6 +252,15 @@ func outputAgentStatusTable(ctx *agentv1.RuntimeContextResponse, services []*age
 			fmt.Printf("  CAP_BPF:          %s\n", formatCapability(ctx.EbpfCapabilities.CapBpf))
 			fmt.Printf("  Collectors:       %d available\n", len(ctx.EbpfCapabilities.AvailableCollectors))
 		}
+		if feats := ctx.EbpfCapabilities.KernelFeatures; feats != nil {
+			fmt.Printf("  Event Transport:  %s\n", feats.EventTransport)
+			fmt.Printf("  Ring Buffer:      %s\n", formatCapability(feats.Ringbuf))
+			fmt.Printf("  bpf_loop:         %s\n", formatCapability(feats.BpfLoop))
+			fmt.Printf("  BTF Source:       %s\n", feats.BtfSource)
+			for _, w := range feats.Warnings {
+				fmt.Printf("  ⚠ %s\n", w)
+			}
+		}
 		fmt.Println()
 	}
 
@@ -499,9 +508,42 @@ func outputAgentStatusTableWithCert(ctx *agentv1.RuntimeContextResponse, service
 		printAgentMeshStatus(meshTelemetry)
 	}
 
+	// Output resource limits if configured.
+	if ctx.ResourceShedding != nil {
+		printResourceLimits(ctx.ResourceShedding)
+	}
+
 	return nil
 }
 
+// printResourceLimits prints the agent's resource self-limits and any load
+// currently shed by the safety valve.
+func printResourceLimits(info *agentv1.ResourceShedding) {
+	fmt.Println("Resource Limits:")
+
+	if info.CpuLimitPercent > 0 {
+		fmt.Printf("  CPU:            %.1f%% of %.1f%%\n", info.CpuPercent, info.CpuLimitPercent)
+	}
+	if info.MemoryLimitBytes > 0 {
+		fmt.Printf("  Memory:         %d MB of %d MB\n", info.MemoryBytes>>20, info.MemoryLimitBytes>>20)
+	}
+
+	if !info.Active {
+		fmt.Println("  Shedding:       none")
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("  Shedding:       ⚠ %s\n", strings.Join(info.Stages, ", "))
+	if info.Reason != "" {
+		fmt.Printf("  Reason:         %s\n", info.Reason)
+	}
+	if info.Since != nil {
+		fmt.Printf("  Since:          %s\n", info.Since.AsTime().Local().Format(time.RFC3339))
+	}
+	fmt.Println()
+}
+
 // printAgentMeshStatus formats and prints WireGuard mesh telemetry natively for the agent.
 func printAgentMeshStatus(info *networkv1.MeshTelemetry) {
 	fmt.Println("Mesh Network:")
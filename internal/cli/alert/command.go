@@ -0,0 +1,24 @@
+// Package alert provides CLI commands for managing colony alert rules.
+package alert
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewAlertCmd creates the alert command and its subcommands.
+func NewAlertCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alert",
+		Short: "Manage alerts on service metrics",
+		Long: `Manage alert rules evaluated by the colony.
+
+The colony evaluates enabled rules every 30s (alerting.evaluation_interval)
+against the telemetry it stores, and notifies the rule's sinks when an alert
+fires for a service and when it resolves. Sinks (webhook, Slack, PagerDuty)
+are defined in alerting.sinks of the colony config.`,
+	}
+
+	cmd.AddCommand(newRuleCmd())
+
+	return cmd
+}
@@ -0,0 +1,283 @@
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func newRuleCmd() *cobra.Command {
+	var colonyID string
+
+	cmd := &cobra.Command{
+		Use:   "rule",
+		Short: "Manage alert rules",
+		Long: `Define thresholds on service metrics that fire alerts.
+
+Metrics:
+  latency_p95   p95 request latency in milliseconds
+  error_rate    percentage of failed requests (HTTP 5xx and error spans)
+  heap_growth   percentage growth of allocated bytes over the previous window
+
+Examples:
+  # Page on-call when api p95 latency exceeds 500ms over 5 minutes
+  coral alert rule create --name api-latency --service api \
+    --metric latency_p95 --threshold 500 --notify pagerduty
+
+  # Post to Slack when any service fails more than 5% of requests
+  coral alert rule create --name errors --metric error_rate --threshold 5 \
+    --window 10m --notify slack
+
+  coral alert rule list
+  coral alert rule disable <rule-id>`,
+	}
+
+	cmd.PersistentFlags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
+
+	cmd.AddCommand(newRuleCreateCmd(&colonyID))
+	cmd.AddCommand(newRuleListCmd(&colonyID))
+	cmd.AddCommand(newRuleDeleteCmd(&colonyID))
+	cmd.AddCommand(newRuleSetEnabledCmd(&colonyID, true))
+	cmd.AddCommand(newRuleSetEnabledCmd(&colonyID, false))
+
+	return cmd
+}
+
+func newRuleCreateCmd(colonyID *string) *cobra.Command {
+	var (
+		name        string
+		serviceName string
+		metric      string
+		threshold   float64
+		window      time.Duration
+		sinks       []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an alert rule",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			client, _, err := helpers.GetColonyClientWithFallback(ctx, *colonyID)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.CreateAlertRule(ctx, connect.NewRequest(&colonyv1.CreateAlertRuleRequest{
+				Name:        name,
+				ServiceName: serviceName,
+				Metric:      metric,
+				Threshold:   threshold,
+				Window:      durationpb.New(window),
+				Sinks:       sinks,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to create alert rule: %w", err)
+			}
+
+			r := resp.Msg.Rule
+			fmt.Printf("Created alert rule %s: %s of %s > %s over %s\n",
+				r.Id, r.Metric, ruleTarget(r), formatThreshold(r.Metric, r.Threshold), r.Window.AsDuration())
+			if len(r.Sinks) == 0 {
+				fmt.Fprintln(os.Stderr, "No sinks given: firing alerts are only logged by the colony and shown by 'coral alert rule list'.")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Rule name (required)")
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service to watch (default: every service)")
+	cmd.Flags().StringVar(&metric, "metric", "", "Metric: latency_p95, error_rate or heap_growth (required)")
+	cmd.Flags().Float64Var(&threshold, "threshold", 0, "Fire when the metric exceeds this value: milliseconds for latency_p95, percent otherwise (required)")
+	cmd.Flags().DurationVar(&window, "window", constants.DefaultAlertWindow, "Time window the metric is computed over (1m to 24h)")
+	cmd.Flags().StringSliceVar(&sinks, "notify", nil, "Sinks to notify, by name from alerting.sinks in the colony config")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("metric")
+	_ = cmd.MarkFlagRequired("threshold")
+
+	return cmd
+}
+
+func newRuleListCmd(colonyID *string) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List alert rules and firing alerts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format %q (use table or json)", format)
+			}
+
+			ctx := cmd.Context()
+
+			client, _, err := helpers.GetColonyClientWithFallback(ctx, *colonyID)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.ListAlertRules(ctx, connect.NewRequest(&colonyv1.ListAlertRulesRequest{}))
+			if err != nil {
+				return fmt.Errorf("failed to list alert rules: %w", err)
+			}
+
+			if format == "json" {
+				return printRulesJSON(resp.Msg.Rules)
+			}
+
+			if len(resp.Msg.Rules) == 0 {
+				fmt.Println("No alert rules.")
+			} else {
+				printRulesTable(resp.Msg.Rules)
+			}
+			if len(resp.Msg.Sinks) == 0 {
+				fmt.Fprintln(os.Stderr, "\nNo alert sinks configured; add them to alerting.sinks in the colony config.")
+			} else {
+				fmt.Printf("\nSinks: %s\n", strings.Join(resp.Msg.Sinks, ", "))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func newRuleDeleteCmd(colonyID *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <rule-id>",
+		Short: "Delete an alert rule",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			client, _, err := helpers.GetColonyClientWithFallback(ctx, *colonyID)
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.DeleteAlertRule(ctx, connect.NewRequest(&colonyv1.DeleteAlertRuleRequest{
+				Id: args[0],
+			})); err != nil {
+				return fmt.Errorf("failed to delete alert rule: %w", err)
+			}
+
+			fmt.Printf("Deleted alert rule %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newRuleSetEnabledCmd(colonyID *string, enabled bool) *cobra.Command {
+	use, short, done := "enable", "Enable an alert rule", "Enabled"
+	if !enabled {
+		use, short, done = "disable", "Disable an alert rule and clear its alerts", "Disabled"
+	}
+
+	return &cobra.Command{
+		Use:   use + " <rule-id>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			client, _, err := helpers.GetColonyClientWithFallback(ctx, *colonyID)
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.SetAlertRuleEnabled(ctx, connect.NewRequest(&colonyv1.SetAlertRuleEnabledRequest{
+				Id:      args[0],
+				Enabled: enabled,
+			})); err != nil {
+				return fmt.Errorf("failed to update alert rule: %w", err)
+			}
+
+			fmt.Printf("%s alert rule %s\n", done, args[0])
+			return nil
+		},
+	}
+}
+
+// printRulesTable writes alert rules as an aligned table, with the services
+// each rule is firing for.
+func printRulesTable(rules []*colonyv1.AlertRule) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tNAME\tSERVICE\tCONDITION\tWINDOW\tNOTIFY\tSTATE")
+	for _, r := range rules {
+		notify := strings.Join(r.Sinks, ",")
+		if notify == "" {
+			notify = "-"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s > %s\t%s\t%s\t%s\n",
+			r.Id,
+			r.Name,
+			ruleTarget(r),
+			r.Metric,
+			formatThreshold(r.Metric, r.Threshold),
+			r.Window.AsDuration(),
+			notify,
+			ruleState(r),
+		)
+	}
+	_ = w.Flush()
+}
+
+// printRulesJSON writes alert rules as a JSON array.
+func printRulesJSON(rules []*colonyv1.AlertRule) error {
+	out := make([]json.RawMessage, 0, len(rules))
+	for _, r := range rules {
+		data, err := protojson.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("failed to encode alert rule: %w", err)
+		}
+		out = append(out, data)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func ruleTarget(r *colonyv1.AlertRule) string {
+	if r.ServiceName == "" {
+		return "*"
+	}
+	return r.ServiceName
+}
+
+func ruleState(r *colonyv1.AlertRule) string {
+	if !r.Enabled {
+		return "disabled"
+	}
+	if len(r.Firing) == 0 {
+		return "ok"
+	}
+	firing := make([]string, 0, len(r.Firing))
+	for _, f := range r.Firing {
+		firing = append(firing, fmt.Sprintf("%s=%s", f.ServiceName, formatThreshold(r.Metric, f.Value)))
+	}
+	return "FIRING " + strings.Join(firing, ", ")
+}
+
+// formatThreshold formats a metric value with its unit.
+func formatThreshold(metric string, v float64) string {
+	if metric == "latency_p95" {
+		return fmt.Sprintf("%.0fms", v)
+	}
+	return fmt.Sprintf("%.1f%%", v)
+}
@@ -0,0 +1,290 @@
+// Package check provides the coral check command, which verifies the latency
+// and error rate of a service against thresholds to gate deployments.
+package check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// Check and report statuses.
+const (
+	statusPass   = "pass"
+	statusFail   = "fail"
+	statusNoData = "no_data"
+)
+
+// Names of the checked metrics.
+const (
+	metricP95Latency = "p95_latency_ms"
+	metricErrorRate  = "error_rate"
+)
+
+// thresholds are the limits a service is checked against.
+type thresholds struct {
+	maxP95       time.Duration // Zero is not checked.
+	maxErrorRate *float64      // Percent; nil is not checked, so that 0% can be required.
+}
+
+// checkJSON is the JSON-serializable result of a single threshold check.
+type checkJSON struct {
+	Metric    string  `json:"metric"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Status    string  `json:"status"`
+}
+
+// reportJSON is the JSON-serializable report of coral check.
+type reportJSON struct {
+	Service   string      `json:"service"`
+	Since     string      `json:"since"`
+	CheckedAt time.Time   `json:"checked_at"`
+	Requests  int64       `json:"requests"`
+	Status    string      `json:"status"`
+	Checks    []checkJSON `json:"checks"`
+}
+
+// NewCheckCmd creates the check command.
+func NewCheckCmd() *cobra.Command {
+	var (
+		service      string
+		maxP95       time.Duration
+		maxErrorRate string
+		since        string
+		exitCode     bool
+		format       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check service latency and error rate against thresholds",
+		Long: `Check the p95 latency and error rate of a service over a recent window
+against thresholds, for deployment pipelines.
+
+The command prints a report with one line per threshold. With --exit-code, it
+exits with an error when a threshold is exceeded, or when the service served
+no requests in the window and its thresholds could not be verified. Use
+--format json for a machine-readable report.
+
+Errors are HTTP responses with a 5xx status and gRPC calls with a non-OK
+status.
+
+Examples:
+  coral check --service api --max-p95 200ms --max-error-rate 1%
+  coral check --service api --max-p95 200ms --max-error-rate 1% --since 15m --exit-code
+  coral check --service checkout --max-error-rate 0.5% --exit-code --format json
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q: must be text or json", format)
+			}
+			if service == "" {
+				return fmt.Errorf("--service is required")
+			}
+			limits := thresholds{maxP95: maxP95}
+			if maxP95 < 0 {
+				return fmt.Errorf("invalid --max-p95 %s: must not be negative", maxP95)
+			}
+			if maxErrorRate != "" {
+				rate, err := parsePercent(maxErrorRate)
+				if err != nil {
+					return fmt.Errorf("invalid --max-error-rate %q: %w", maxErrorRate, err)
+				}
+				limits.maxErrorRate = &rate
+			}
+			if limits.maxP95 == 0 && limits.maxErrorRate == nil {
+				return fmt.Errorf("at least one threshold is required: --max-p95 or --max-error-rate")
+			}
+
+			client, err := helpers.GetColonyClient("")
+			if err != nil {
+				return fmt.Errorf("failed to create colony client: %w", err)
+			}
+
+			report, err := runCheck(context.Background(), client, service, since, limits)
+			if err != nil {
+				return err
+			}
+
+			if format == "json" {
+				if err := printReportJSON(os.Stdout, report); err != nil {
+					return err
+				}
+			} else {
+				printReportText(os.Stdout, report)
+			}
+
+			if exitCode {
+				return reportError(report)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&service, "service", "", "Service to check (required)")
+	cmd.Flags().DurationVar(&maxP95, "max-p95", 0, "Maximum p95 latency (e.g., 200ms)")
+	cmd.Flags().StringVar(&maxErrorRate, "max-error-rate", "", "Maximum error rate in percent (e.g., 1%, 0.5%)")
+	cmd.Flags().StringVar(&since, "since", "15m", "Window to check (e.g., 5m, 15m, 1h)")
+	cmd.Flags().BoolVar(&exitCode, "exit-code", false, "Exit with an error when a threshold is exceeded or there is no data")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	return cmd
+}
+
+// runCheck queries the metrics of service over the last since and checks
+// them against limits.
+func runCheck(
+	ctx context.Context,
+	client colonyv1connect.ColonyServiceClient,
+	service, since string,
+	limits thresholds,
+) (*reportJSON, error) {
+	window, err := time.ParseDuration(since)
+	if err != nil || window <= 0 {
+		return nil, fmt.Errorf("invalid --since %q: must be a positive duration", since)
+	}
+
+	// The baseline of a summary carries the current p95 latency and error
+	// rate of the service; a baseline of a single window keeps it cheap.
+	resp, err := client.QueryUnifiedSummary(ctx, connect.NewRequest(&colonyv1.QueryUnifiedSummaryRequest{
+		Service:         service,
+		TimeRange:       since,
+		IncludeBaseline: true,
+		BaselineWindow:  since,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query service metrics: %w", err)
+	}
+
+	var baseline *colonyv1.ServiceBaseline
+	for _, s := range resp.Msg.Summaries {
+		if s.ServiceName == service && s.Baseline != nil {
+			baseline = s.Baseline
+			break
+		}
+	}
+
+	report := &reportJSON{
+		Service:   service,
+		Since:     since,
+		CheckedAt: time.Now().UTC(),
+		Status:    statusPass,
+		Checks:    []checkJSON{},
+	}
+	if baseline != nil {
+		report.Requests = int64(math.Round(baseline.GetRequestsPerSecond().GetCurrent() * window.Seconds()))
+	}
+
+	if limits.maxP95 > 0 {
+		report.Checks = append(report.Checks, evaluate(metricP95Latency,
+			baseline.GetP95LatencyMs().GetCurrent(), float64(limits.maxP95)/float64(time.Millisecond), report.Requests))
+	}
+	if limits.maxErrorRate != nil {
+		report.Checks = append(report.Checks, evaluate(metricErrorRate,
+			baseline.GetErrorRate().GetCurrent(), *limits.maxErrorRate, report.Requests))
+	}
+
+	if report.Requests == 0 {
+		report.Status = statusNoData
+	}
+	for _, c := range report.Checks {
+		if c.Status == statusFail {
+			report.Status = statusFail
+		}
+	}
+	return report, nil
+}
+
+// evaluate checks value against threshold; without requests the value is
+// unknown.
+func evaluate(metric string, value, threshold float64, requests int64) checkJSON {
+	c := checkJSON{Metric: metric, Value: value, Threshold: threshold, Status: statusPass}
+	switch {
+	case requests == 0:
+		c.Value = 0
+		c.Status = statusNoData
+	case value > threshold:
+		c.Status = statusFail
+	}
+	return c
+}
+
+// reportError returns an error describing why the report did not pass.
+func reportError(report *reportJSON) error {
+	switch report.Status {
+	case statusNoData:
+		return fmt.Errorf("check failed: no requests from %s in the last %s", report.Service, report.Since)
+	case statusFail:
+		var failed []string
+		for _, c := range report.Checks {
+			if c.Status == statusFail {
+				failed = append(failed, fmt.Sprintf("%s %s > %s",
+					c.Metric, formatValue(c.Metric, c.Value), formatValue(c.Metric, c.Threshold)))
+			}
+		}
+		return fmt.Errorf("check failed: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// parsePercent parses a percentage such as "1%" or "0.5".
+func parsePercent(s string) (float64, error) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "%")), 64)
+	if err != nil {
+		return 0, fmt.Errorf("must be a percentage such as 1%%")
+	}
+	if v < 0 || v > 100 {
+		return 0, fmt.Errorf("must be between 0%% and 100%%")
+	}
+	return v, nil
+}
+
+func printReportJSON(w io.Writer, report *reportJSON) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal check report: %w", err)
+	}
+	_, _ = fmt.Fprintln(w, string(data))
+	return nil
+}
+
+func printReportText(w io.Writer, report *reportJSON) {
+	_, _ = fmt.Fprintf(w, "Service %s, last %s: %d requests\n\n", report.Service, report.Since, report.Requests)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHECK\tVALUE\tTHRESHOLD\tRESULT")
+	for _, c := range report.Checks {
+		value := "-"
+		if c.Status != statusNoData {
+			value = formatValue(c.Metric, c.Value)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			c.Metric, value, formatValue(c.Metric, c.Threshold), strings.ToUpper(c.Status))
+	}
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintf(w, "\nResult: %s\n", strings.ToUpper(report.Status))
+}
+
+// formatValue formats a latency in milliseconds or an error rate in percent.
+func formatValue(metric string, v float64) string {
+	if metric == metricP95Latency {
+		return fmt.Sprintf("%.0fms", v)
+	}
+	return fmt.Sprintf("%.2f%%", v)
+}
@@ -0,0 +1,127 @@
+package check
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+)
+
+// fakeColony serves a fixed summary and records the last request.
+type fakeColony struct {
+	colonyv1connect.UnimplementedColonyServiceHandler
+
+	summaries []*colonyv1.UnifiedSummaryResult
+	req       *colonyv1.QueryUnifiedSummaryRequest
+}
+
+func (c *fakeColony) QueryUnifiedSummary(
+	_ context.Context,
+	req *connect.Request[colonyv1.QueryUnifiedSummaryRequest],
+) (*connect.Response[colonyv1.QueryUnifiedSummaryResponse], error) {
+	c.req = req.Msg
+	return connect.NewResponse(&colonyv1.QueryUnifiedSummaryResponse{Summaries: c.summaries}), nil
+}
+
+func newClient(t *testing.T, colony *fakeColony) colonyv1connect.ColonyServiceClient {
+	_, h := colonyv1connect.NewColonyServiceHandler(colony)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+	return colonyv1connect.NewColonyServiceClient(srv.Client(), srv.URL)
+}
+
+func TestRunCheck(t *testing.T) {
+	colony := &fakeColony{summaries: []*colonyv1.UnifiedSummaryResult{
+		{ServiceName: "api-gateway", Baseline: &colonyv1.ServiceBaseline{
+			RequestsPerSecond: &colonyv1.MetricBaseline{Current: 100},
+			P95LatencyMs:      &colonyv1.MetricBaseline{Current: 900},
+			ErrorRate:         &colonyv1.MetricBaseline{Current: 50},
+		}},
+		{ServiceName: "api", Baseline: &colonyv1.ServiceBaseline{
+			RequestsPerSecond: &colonyv1.MetricBaseline{Current: 2},
+			P95LatencyMs:      &colonyv1.MetricBaseline{Current: 250},
+			ErrorRate:         &colonyv1.MetricBaseline{Current: 0.5},
+		}},
+	}}
+	client := newClient(t, colony)
+	rate := 1.0
+
+	report, err := runCheck(context.Background(), client, "api", "15m", thresholds{maxP95: 200 * time.Millisecond, maxErrorRate: &rate})
+	require.NoError(t, err)
+	assert.Equal(t, "15m", colony.req.TimeRange)
+	assert.True(t, colony.req.IncludeBaseline)
+
+	assert.Equal(t, int64(1800), report.Requests)
+	assert.Equal(t, statusFail, report.Status)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, checkJSON{Metric: metricP95Latency, Value: 250, Threshold: 200, Status: statusFail}, report.Checks[0])
+	assert.Equal(t, checkJSON{Metric: metricErrorRate, Value: 0.5, Threshold: 1, Status: statusPass}, report.Checks[1])
+	assert.EqualError(t, reportError(report), "check failed: p95_latency_ms 250ms > 200ms")
+
+	report, err = runCheck(context.Background(), client, "api", "15m", thresholds{maxP95: 300 * time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, statusPass, report.Status)
+	assert.Len(t, report.Checks, 1)
+	assert.NoError(t, reportError(report))
+
+	t.Run("no data", func(t *testing.T) {
+		report, err := runCheck(context.Background(), client, "checkout", "5m", thresholds{maxErrorRate: &rate})
+		require.NoError(t, err)
+		assert.Equal(t, statusNoData, report.Status)
+		assert.Equal(t, statusNoData, report.Checks[0].Status)
+		assert.EqualError(t, reportError(report), "check failed: no requests from checkout in the last 5m")
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		_, err := runCheck(context.Background(), client, "api", "soon", thresholds{maxErrorRate: &rate})
+		assert.Error(t, err)
+	})
+}
+
+func TestParsePercent(t *testing.T) {
+	for in, want := range map[string]float64{"1%": 1, "0.5": 0.5, " 2.5 % ": 2.5, "0%": 0} {
+		got, err := parsePercent(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, in := range []string{"", "%", "one", "-1%", "101%"} {
+		_, err := parsePercent(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestPrintReport(t *testing.T) {
+	report := &reportJSON{
+		Service:  "api",
+		Since:    "15m",
+		Requests: 1800,
+		Status:   statusFail,
+		Checks: []checkJSON{
+			{Metric: metricP95Latency, Value: 250, Threshold: 200, Status: statusFail},
+			{Metric: metricErrorRate, Value: 0.5, Threshold: 1, Status: statusPass},
+		},
+	}
+
+	var buf bytes.Buffer
+	printReportText(&buf, report)
+	assert.Contains(t, buf.String(), "Service api, last 15m: 1800 requests")
+	assert.Regexp(t, `p95_latency_ms\s+250ms\s+200ms\s+FAIL`, buf.String())
+	assert.Regexp(t, `error_rate\s+0.50%\s+1.00%\s+PASS`, buf.String())
+	assert.Contains(t, buf.String(), "Result: FAIL")
+
+	buf.Reset()
+	require.NoError(t, printReportJSON(&buf, report))
+	var decoded reportJSON
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report.Checks, decoded.Checks)
+	assert.Equal(t, statusFail, decoded.Status)
+}
@@ -0,0 +1,113 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/config"
+)
+
+func newAgentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent",
+		Short: "Manage individual agents",
+		Long:  `Manage individual agents of the colony. Use 'coral colony agents' to list them.`,
+	}
+
+	cmd.AddCommand(newAgentRevokeCmd())
+
+	return cmd
+}
+
+func newAgentRevokeCmd() *cobra.Command {
+	var (
+		colonyID string
+		reason   string
+		force    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "revoke <agent-id>",
+		Short: "Revoke an agent's certificates and evict it",
+		Long: `Revoke all certificates issued to an agent and evict it from the colony.
+
+The certificates are added to the colony's certificate revocation list, which
+the colony serves on /ca/crl for the servers that accept agent certificates,
+e.g. SDK debug servers. The colony itself rejects them right away: the agent
+can no longer connect or register, also with the colony secret alone. It is
+removed from the agent registry and as a WireGuard peer.
+
+If the agent's host was compromised, also rotate the bootstrap PSK with
+'coral colony psk rotate', or the agent can request a new certificate.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			agentID := args[0]
+
+			resolver, err := config.NewResolver()
+			if err != nil {
+				return fmt.Errorf("failed to create config resolver: %w", err)
+			}
+			if colonyID == "" {
+				colonyID, err = resolver.ResolveColonyID()
+				if err != nil {
+					return fmt.Errorf("failed to resolve colony: %w", err)
+				}
+			}
+
+			if !force {
+				fmt.Printf("Are you sure you want to revoke agent %q? This cannot be undone.\n", agentID)
+				fmt.Print("Type 'yes' to confirm: ")
+
+				var confirm string
+				if _, err := fmt.Scanln(&confirm); err != nil {
+					return fmt.Errorf("failed to read user confirmation: %w", err)
+				}
+				if confirm != "yes" {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+			}
+
+			client, _, err := helpers.GetColonyClientWithFallback(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := client.RevokeAgent(ctx, connect.NewRequest(&colonyv1.RevokeAgentRequest{
+				AgentId: agentID,
+				Reason:  reason,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to revoke agent: %w", err)
+			}
+
+			if len(resp.Msg.RevokedSerialNumbers) == 0 {
+				fmt.Printf("Agent %q has no active certificates.\n", agentID)
+			} else {
+				fmt.Printf("Revoked %d certificate(s) of agent %q:\n", len(resp.Msg.RevokedSerialNumbers), agentID)
+				for _, serial := range resp.Msg.RevokedSerialNumbers {
+					fmt.Printf("  %s\n", serial)
+				}
+			}
+			if resp.Msg.Evicted {
+				fmt.Printf("Agent %q has been evicted from the colony.\n", agentID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
+	cmd.Flags().StringVar(&reason, "reason", "", "Revocation reason, recorded with the revoked certificates")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+
+	return cmd
+}
@@ -8,18 +8,33 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
 	"github.com/coral-mesh/coral/internal/cli/helpers"
 	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+const (
+	// agentHistoryEventsShown is the number of recent events listed by
+	// `coral colony agents --history` without --verbose.
+	agentHistoryEventsShown = 20
+
+	agentHistoryTimeFormat = "2006-01-02 15:04"
 )
 
 func newAgentsCmd() *cobra.Command {
 	var (
-		format   string
-		verbose  bool
-		colonyID string
+		format    string
+		verbose   bool
+		colonyID  string
+		federated bool
+		history   bool
+		since     string
+		selector  string
 	)
 
 	cmd := &cobra.Command{
@@ -30,12 +45,31 @@ func newAgentsCmd() *cobra.Command {
 This command queries the running colony to retrieve real-time agent information including:
 - Agent ID and component name
 - Mesh IP addresses (IPv4 and IPv6)
-- Connection status (healthy, degraded, unhealthy)
+- Connection status (healthy, degraded, unhealthy) with the causes of any
+  degradation, e.g. clock skew, a backed-up event queue or eBPF failures
 - Last seen timestamp
 - Runtime context (with --verbose)
+- Agents on an older protocol version than the colony, with the features
+  they lack
+
+With --selector, only lists agents whose labels match, e.g. env=prod or
+env=prod,region!=us-east. Agents have the labels they registered with (from
+agent.labels in the agent config or CORAL_AGENT_LABELS), overridden by those
+assigned with 'coral colony agents label'.
+
+With --federated, a federation parent also lists the agents of its child
+colonies, labeled with the colony they belong to.
+
+With --history, shows the agent history persisted by the colony instead:
+first-seen time, connects, disconnects and status changes of every agent
+active in the --since window, flapping agents, and the most recent events.
 
 Note: The colony must be running for this command to work.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if history && federated {
+				return fmt.Errorf("--history cannot be combined with --federated")
+			}
+
 			// Create resolver
 			resolver, err := config.NewResolver()
 			if err != nil {
@@ -65,11 +99,16 @@ Note: The colony must be running for this command to work.`,
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
-			req := connect.NewRequest(&colonyv1.ListAgentsRequest{})
+			if history {
+				return outputAgentHistory(ctx, client, since, format, verbose)
+			}
+
+			req := connect.NewRequest(&colonyv1.ListAgentsRequest{Federated: federated, Selector: selector})
 			resp, err := client.ListAgents(ctx, req)
 			if err != nil {
 				return fmt.Errorf("failed to list agents: %w", err)
 			}
+			helpers.PrintFederationErrors(resp.Msg.FederationErrors)
 
 			agents := resp.Msg.Agents
 
@@ -89,11 +128,18 @@ Note: The colony must be running for this command to work.`,
 			}
 
 			if verbose {
-				return outputAgentsVerbose(agents)
+				if err := outputAgentsVerbose(agents); err != nil {
+					return err
+				}
+				outputProtocolSkew(agents, resp.Msg.ProtocolVersion)
+				return nil
 			}
 
 			fmt.Printf("Connected Agents (%d):\n\n", len(agents))
-			fmt.Printf("%-25s %-20s %-20s %-10s %-10s %s\n", "AGENT ID", "SERVICES", "RUNTIME", "MESH IP", "STATUS", "LAST SEEN")
+			if federated {
+				fmt.Printf("%-20s ", "COLONY")
+			}
+			fmt.Printf("%-25s %-20s %-20s %-10s %-10s %s\n", "AGENT ID", "SERVICES", "RUNTIME", "MESH IP", "LAST SEEN", "STATUS")
 			fmt.Println("--------------------------------------------------------------------------------------------------------")
 
 			for _, agent := range agents {
@@ -125,15 +171,19 @@ Note: The colony must be running for this command to work.`,
 					servicesStr = agent.ComponentName // Fallback for backward compatibility
 				}
 
+				if federated {
+					fmt.Printf("%-20s ", truncate(agent.ColonyId, 20))
+				}
 				fmt.Printf("%-25s %-20s %-20s %-10s %-10s %s\n",
 					truncate(agent.AgentId, 25),
 					truncate(servicesStr, 20),
 					truncate(runtimeStr, 20),
 					agent.MeshIpv4,
-					agent.Status,
 					lastSeenStr,
+					formatStatusReasons(agent),
 				)
 			}
+			outputProtocolSkew(agents, resp.Msg.ProtocolVersion)
 
 			return nil
 		},
@@ -146,10 +196,126 @@ Note: The colony must be running for this command to work.`,
 	})
 	helpers.AddVerboseFlag(cmd, &verbose)
 	helpers.AddColonyFlag(cmd, &colonyID)
+	helpers.AddFederatedFlag(cmd, &federated)
+	helpers.AddSelectorFlag(cmd, &selector)
+	cmd.Flags().BoolVar(&history, "history", false, "Show persisted agent history (churn, flapping agents, first-seen times)")
+	cmd.Flags().StringVar(&since, "since", "7d", "History window for --history (e.g. 24h, 7d, 2w)")
+
+	cmd.AddCommand(newAgentsUpgradeCmd())
+	cmd.AddCommand(newAgentsExecCmd())
+	cmd.AddCommand(newAgentsDrainCmd())
+	cmd.AddCommand(newAgentsLabelCmd())
 
 	return cmd
 }
 
+// outputProtocolSkew lists the agents whose protocol version differs from
+// the colony's, with the capabilities older agents lack (RFD 018).
+func outputProtocolSkew(agents []*colonyv1.Agent, colonyVersion uint32) {
+	var older, newer []*colonyv1.Agent
+	for _, agent := range agents {
+		switch {
+		case len(agent.MissingCapabilities) > 0:
+			older = append(older, agent)
+		case colonyVersion > 0 && agent.ColonyId == "" && agent.ProtocolVersion > colonyVersion:
+			newer = append(newer, agent)
+		}
+	}
+	if len(older) == 0 && len(newer) == 0 {
+		return
+	}
+
+	fmt.Printf("\n⚠️  %d agent(s) on a different protocol version than the colony (v%d):\n", len(older)+len(newer), colonyVersion)
+	for _, agent := range older {
+		fmt.Printf("  %-25s protocol v%d, missing:\n", truncate(agent.AgentId, 25), agent.ProtocolVersion)
+		for _, c := range agent.MissingCapabilities {
+			fmt.Printf("    - %s (v%d): cannot %s\n", c.Name, c.Since, c.Description)
+		}
+	}
+	for _, agent := range newer {
+		fmt.Printf("  %-25s protocol v%d, newer than the colony: its new features are unused\n", truncate(agent.AgentId, 25), agent.ProtocolVersion)
+	}
+	if len(older) > 0 {
+		fmt.Println("\nUpgrade these agents by hand to use all colony features.")
+	}
+}
+
+// outputAgentHistory prints the agent history within the since window.
+func outputAgentHistory(ctx context.Context, client colonyv1connect.ColonyServiceClient, since, format string, verbose bool) error {
+	window, err := helpers.ParseSince(since)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	req := &colonyv1.GetAgentHistoryRequest{
+		Since: timestamppb.New(time.Now().Add(-window)),
+	}
+	if !verbose && format == string(helpers.FormatTable) {
+		req.Limit = agentHistoryEventsShown
+	}
+
+	resp, err := client.GetAgentHistory(ctx, connect.NewRequest(req))
+	if err != nil {
+		return fmt.Errorf("failed to get agent history: %w", err)
+	}
+
+	if format != string(helpers.FormatTable) {
+		formatter, err := helpers.NewFormatter(helpers.OutputFormat(format))
+		if err != nil {
+			return err
+		}
+		return formatter.Format(resp.Msg, os.Stdout)
+	}
+
+	if len(resp.Msg.Agents) == 0 {
+		fmt.Printf("No agent activity in the last %s.\n", since)
+		return nil
+	}
+
+	fmt.Printf("Agent History (last %s, %d agents):\n\n", since, len(resp.Msg.Agents))
+	fmt.Printf("%-25s %-17s %-17s %-9s %-12s %-15s %s\n", "AGENT ID", "FIRST SEEN", "LAST EVENT", "CONNECTS", "DISCONNECTS", "STATUS CHANGES", "NOTES")
+	fmt.Println("------------------------------------------------------------------------------------------------------------")
+
+	var flapping int
+	for _, agent := range resp.Msg.Agents {
+		notes := ""
+		if agent.Flapping {
+			notes = "⚠ flapping"
+			flapping++
+		}
+		fmt.Printf("%-25s %-17s %-17s %-9d %-12d %-15d %s\n",
+			truncate(agent.AgentId, 25),
+			agent.FirstSeen.AsTime().Local().Format(agentHistoryTimeFormat),
+			agent.LastEvent.AsTime().Local().Format(agentHistoryTimeFormat),
+			agent.Connects,
+			agent.Disconnects,
+			agent.StatusChanges,
+			notes,
+		)
+	}
+	if flapping > 0 {
+		fmt.Printf("\n%d agent(s) flapping (%d+ disconnects in the window).\n", flapping, constants.AgentFlappingDisconnects)
+	}
+
+	if len(resp.Msg.Events) > 0 {
+		fmt.Println("\nRecent events:")
+		for _, event := range resp.Msg.Events {
+			line := fmt.Sprintf("  %s  %-25s %-15s %s",
+				event.Timestamp.AsTime().Local().Format(agentHistoryTimeFormat),
+				truncate(event.AgentId, 25),
+				event.Event,
+				event.Status,
+			)
+			if event.Message != "" {
+				line += fmt.Sprintf(" (%s)", event.Message)
+			}
+			fmt.Println(line)
+		}
+	}
+
+	return nil
+}
+
 // outputAgentsVerbose outputs agents in verbose format with full runtime context.
 func outputAgentsVerbose(agents []*colonyv1.Agent) error {
 	fmt.Printf("Connected Agents (%d):\n\n", len(agents))
@@ -167,8 +333,26 @@ func outputAgentsVerbose(agents []*colonyv1.Agent) error {
 
 		//nolint:staticcheck // ComponentName is deprecated but kept for backward compatibility
 		fmt.Printf("│ Component:  %-45s│\n", agent.ComponentName)
+		if agent.ColonyId != "" {
+			fmt.Printf("│ Colony:     %-45s│\n", agent.ColonyId)
+		}
 		fmt.Printf("│ Status:     %-45s│\n", formatAgentStatus(agent))
+		if agent.Status != "unhealthy" {
+			fmt.Printf("│ Health:     %-45s│\n", fmt.Sprintf("%d/100", agent.HealthScore))
+		}
+		for _, reason := range agent.HealthReasons {
+			fmt.Printf("│   - %-53s│\n", reason)
+		}
 		fmt.Printf("│ Mesh IP:    %-45s│\n", agent.MeshIpv4)
+		if agent.ProtocolVersion > 0 {
+			fmt.Printf("│ Protocol:   %-45s│\n", fmt.Sprintf("v%d", agent.ProtocolVersion))
+		}
+		if len(agent.Labels) > 0 {
+			fmt.Printf("│ Labels:     %-45s│\n", truncate(formatLabels(agent.Labels), 45))
+		}
+		if agent.Drained {
+			fmt.Printf("│ Drained:    %-45s│\n", "yes (no new debug sessions)")
+		}
 		fmt.Println("│                                                                │")
 
 		if agent.RuntimeContext != nil {
@@ -0,0 +1,326 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// bulkFlags are the flags selecting the agents of a bulk operation.
+type bulkFlags struct {
+	colonyID string
+	selector string
+	agentIDs []string
+	format   string
+}
+
+func (f *bulkFlags) register(cmd *cobra.Command) {
+	helpers.AddColonyFlag(cmd, &f.colonyID)
+	helpers.AddFormatFlag(cmd, &f.format, helpers.FormatTable, []helpers.OutputFormat{
+		helpers.FormatTable,
+		helpers.FormatJSON,
+		helpers.FormatYAML,
+	})
+	helpers.AddSelectorFlag(cmd, &f.selector)
+	cmd.Flags().StringArrayVar(&f.agentIDs, "agent", nil, "Target this agent (repeatable)")
+}
+
+// client validates the flags and connects to the colony.
+func (f *bulkFlags) client(ctx context.Context) (colonyv1connect.ColonyServiceClient, error) {
+	if f.selector == "" && len(f.agentIDs) == 0 {
+		return nil, fmt.Errorf("--selector or --agent is required")
+	}
+
+	if f.colonyID == "" {
+		resolver, err := config.NewResolver()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create config resolver: %w", err)
+		}
+		f.colonyID, err = resolver.ResolveColonyID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve colony: %w", err)
+		}
+	}
+
+	client, _, err := helpers.GetColonyClientWithFallback(ctx, f.colonyID)
+	return client, err
+}
+
+// output prints the results of a bulk operation and returns an error if the
+// operation failed on any agent.
+func (f *bulkFlags) output(results []*colonyv1.BulkAgentResult, table func([]*colonyv1.BulkAgentResult)) error {
+	if f.format != string(helpers.FormatTable) {
+		formatter, err := helpers.NewFormatter(helpers.OutputFormat(f.format))
+		if err != nil {
+			return err
+		}
+		if err := formatter.Format(results, os.Stdout); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		fmt.Println("No agents matched.")
+	} else {
+		table(results)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if !r.Success {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed on %d of %d agents", failed, len(results))
+	}
+	return nil
+}
+
+func newAgentsExecCmd() *cobra.Command {
+	var (
+		flags       bulkFlags
+		concurrency int
+		timeout     time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "exec [flags] -- <command> [args...]",
+		Short: "Run a command on many agents",
+		Long: `Run a command on all agents matching a label selector or the given agents.
+
+The colony runs the command on up to --concurrency agents at a time and
+returns the exit code and output of each. The command is not interpreted by
+a shell. The command exits non-zero if it failed on any agent.
+
+Selectors match the labels agents registered with and those assigned with
+'coral colony agents label': key=value, key!=value or key (the label
+exists), separated by commas.`,
+		Example: `  # Check the uptime of the production agents in us-east
+  coral colony agents exec --selector env=prod,region=us-east -- uptime
+
+  # Run a command on two agents, one at a time
+  coral colony agents exec --agent web-1 --agent web-2 --concurrency 1 -- df -h /`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := flags.client(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			// The colony runs the command on all agents before responding.
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+			defer cancel()
+
+			resp, err := client.ExecAgents(ctx, connect.NewRequest(&colonyv1.ExecAgentsRequest{
+				Selector:       flags.selector,
+				AgentIds:       flags.agentIDs,
+				Concurrency:    int32(concurrency), // #nosec G115 -- concurrency is small.
+				Command:        args,
+				TimeoutSeconds: uint32(timeout / time.Second), // #nosec G115 -- the colony caps the timeout.
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to run command on agents: %w", err)
+			}
+			return flags.output(resp.Msg.Results, outputExecResults)
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().IntVar(&concurrency, "concurrency", constants.DefaultBulkAgentConcurrency, "Maximum number of agents running the command at once")
+	cmd.Flags().DurationVar(&timeout, "timeout", constants.DefaultBulkExecTimeout, "Timeout of the command on each agent (max 5m)")
+
+	return cmd
+}
+
+// outputExecResults prints the outcome and output of a command on each agent.
+func outputExecResults(results []*colonyv1.BulkAgentResult) {
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		if r.Error != "" && r.ExitCode == 0 {
+			fmt.Printf("=== %s: error: %s\n", r.AgentId, r.Error)
+		} else {
+			fmt.Printf("=== %s: exit %d (%dms)\n", r.AgentId, r.ExitCode, r.DurationMs)
+		}
+		if len(r.Stdout) > 0 {
+			fmt.Print(string(r.Stdout))
+			if !strings.HasSuffix(string(r.Stdout), "\n") {
+				fmt.Println()
+			}
+		}
+		if len(r.Stderr) > 0 {
+			fmt.Fprint(os.Stderr, string(r.Stderr))
+			if !strings.HasSuffix(string(r.Stderr), "\n") {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+	}
+}
+
+func newAgentsDrainCmd() *cobra.Command {
+	var (
+		flags   bulkFlags
+		undrain bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "drain",
+		Short: "Stop starting debug sessions on agents",
+		Long: `Drain all agents matching a label selector or the given agents.
+
+The colony starts no new debug sessions, captures or profiles on drained
+agents, e.g. before maintenance; sessions already running are left alone.
+Commands can still run on them. Agents stay drained across agent and colony
+restarts until undrained with --undrain.`,
+		Example: `  # Drain the agents of a zone before maintenance
+  coral colony agents drain --selector zone=us-east-1a
+
+  # Undrain them afterwards
+  coral colony agents drain --selector zone=us-east-1a --undrain`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := flags.client(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := client.DrainAgents(ctx, connect.NewRequest(&colonyv1.DrainAgentsRequest{
+				Selector: flags.selector,
+				AgentIds: flags.agentIDs,
+				Undrain:  undrain,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to drain agents: %w", err)
+			}
+
+			state := "drained"
+			if undrain {
+				state = "undrained"
+			}
+			return flags.output(resp.Msg.Results, func(results []*colonyv1.BulkAgentResult) {
+				outputBulkResults(results, func(*colonyv1.BulkAgentResult) string { return state })
+			})
+		},
+	}
+
+	flags.register(cmd)
+	cmd.Flags().BoolVar(&undrain, "undrain", false, "Undrain the agents instead")
+
+	return cmd
+}
+
+func newAgentsLabelCmd() *cobra.Command {
+	var flags bulkFlags
+
+	cmd := &cobra.Command{
+		Use:   "label key=value... [key-...]",
+		Short: "Set or remove labels of agents",
+		Long: `Set or remove labels of all agents matching a label selector or the given agents.
+
+Labels are assigned by the colony and kept across agent and colony restarts;
+select agents by them with --selector. Set a label with key=value and remove
+one with key-.`,
+		Example: `  # Label two agents
+  coral colony agents label --agent web-1 --agent web-2 env=prod region=us-east
+
+  # Move the us-east production agents to the canary ring
+  coral colony agents label --selector env=prod,region=us-east ring=canary
+
+  # Remove a label
+  coral colony agents label --selector ring=canary ring-`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			set, remove, err := parseLabelArgs(args)
+			if err != nil {
+				return err
+			}
+
+			client, err := flags.client(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := client.LabelAgents(ctx, connect.NewRequest(&colonyv1.LabelAgentsRequest{
+				Selector: flags.selector,
+				AgentIds: flags.agentIDs,
+				Set:      set,
+				Remove:   remove,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to label agents: %w", err)
+			}
+			return flags.output(resp.Msg.Results, func(results []*colonyv1.BulkAgentResult) {
+				outputBulkResults(results, func(r *colonyv1.BulkAgentResult) string { return formatLabels(r.Labels) })
+			})
+		},
+	}
+
+	flags.register(cmd)
+
+	return cmd
+}
+
+// parseLabelArgs splits key=value arguments into labels to set and key-
+// arguments into labels to remove.
+func parseLabelArgs(args []string) (map[string]string, []string, error) {
+	set := make(map[string]string)
+	var remove []string
+	for _, arg := range args {
+		if key, ok := strings.CutSuffix(arg, "-"); ok && !strings.Contains(arg, "=") {
+			if key == "" {
+				return nil, nil, fmt.Errorf("invalid label %q: missing key", arg)
+			}
+			remove = append(remove, key)
+			continue
+		}
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, nil, fmt.Errorf("invalid label %q: want key=value or key-", arg)
+		}
+		set[key] = value
+	}
+	return set, remove, nil
+}
+
+// outputBulkResults prints a row per agent, with detail describing the
+// outcome of a successful operation.
+func outputBulkResults(results []*colonyv1.BulkAgentResult, detail func(*colonyv1.BulkAgentResult) string) {
+	fmt.Printf("%-25s %-8s %s\n", "AGENT ID", "RESULT", "DETAIL")
+	for _, r := range results {
+		if r.Success {
+			fmt.Printf("%-25s %-8s %s\n", truncate(r.AgentId, 25), "ok", detail(r))
+		} else {
+			fmt.Printf("%-25s %-8s %s\n", truncate(r.AgentId, 25), "failed", r.Error)
+		}
+	}
+}
+
+// formatLabels formats labels as sorted key=value pairs.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,228 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/config"
+)
+
+func newAgentsUpgradeCmd() *cobra.Command {
+	var (
+		colonyID  string
+		version   string
+		artifacts []string
+		checksums []string
+		agentIDs  []string
+		cancel    bool
+		force     bool
+		format    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Roll out an agent release",
+		Long: `Roll out an agent release to the agents of the colony.
+
+The colony signs the release with its policy signing key and advertises it in
+its heartbeat responses. Agents that enabled updates (agent.update.enabled)
+verify the signature against the colony's root CA, download the executable
+for their platform, check its SHA-256, and replace their executable and
+restart within their maintenance window (agent.update.maintenance_window).
+Other agents ignore the release.
+
+Give the executable of each platform with --artifact and its SHA-256 with
+--sha256, e.g. from the SHA256SUMS file of the GitHub release. Restrict the
+rollout to canary agents with --agent, then run the command again without it
+to upgrade the remaining agents.
+
+Without --to, shows the progress of the current rollout. Rollouts end when
+the colony restarts; run the command again to resume one.`,
+		Example: `  # Upgrade two canary agents
+  coral colony agents upgrade --to v0.5.0 \
+    --artifact linux/amd64=https://github.com/coral-mesh/coral/releases/download/v0.5.0/coral-agent-linux-amd64 \
+    --sha256 linux/amd64=<sha256> \
+    --agent web-1 --agent web-2
+
+  # Show the progress of the rollout
+  coral colony agents upgrade
+
+  # Stop advertising the release
+  coral colony agents upgrade --cancel`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cancel && version != "" {
+				return fmt.Errorf("--cancel cannot be combined with --to")
+			}
+
+			req := &colonyv1.UpgradeAgentsRequest{Version: version, AgentIds: agentIDs, Cancel: cancel}
+			if version != "" {
+				var err error
+				if req.Artifacts, err = parseAgentArtifacts(artifacts, checksums); err != nil {
+					return err
+				}
+			}
+
+			resolver, err := config.NewResolver()
+			if err != nil {
+				return fmt.Errorf("failed to create config resolver: %w", err)
+			}
+			if colonyID == "" {
+				colonyID, err = resolver.ResolveColonyID()
+				if err != nil {
+					return fmt.Errorf("failed to resolve colony: %w", err)
+				}
+			}
+
+			if version != "" && !force {
+				target := "all agents"
+				if len(agentIDs) > 0 {
+					target = strings.Join(agentIDs, ", ")
+				}
+				fmt.Printf("Upgrade %s of %q to %s?\n", target, colonyID, version)
+				fmt.Print("Type 'yes' to confirm: ")
+
+				var confirm string
+				if _, err := fmt.Scanln(&confirm); err != nil {
+					return fmt.Errorf("failed to read user confirmation: %w", err)
+				}
+				if confirm != "yes" {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+			}
+
+			client, _, err := helpers.GetColonyClientWithFallback(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancelCtx := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancelCtx()
+
+			var upgrade *colonyv1.AgentUpgrade
+			if version == "" && !cancel {
+				resp, err := client.GetAgentUpgrade(ctx, connect.NewRequest(&colonyv1.GetAgentUpgradeRequest{}))
+				if err != nil {
+					return fmt.Errorf("failed to get agent upgrade: %w", err)
+				}
+				upgrade = resp.Msg.Upgrade
+			} else {
+				resp, err := client.UpgradeAgents(ctx, connect.NewRequest(req))
+				if err != nil {
+					return fmt.Errorf("failed to upgrade agents: %w", err)
+				}
+				upgrade = resp.Msg.Upgrade
+			}
+
+			if format != string(helpers.FormatTable) {
+				formatter, err := helpers.NewFormatter(helpers.OutputFormat(format))
+				if err != nil {
+					return err
+				}
+				return formatter.Format(upgrade, os.Stdout)
+			}
+			if cancel {
+				fmt.Println("Agent release rollout cancelled.")
+				return nil
+			}
+			outputAgentUpgrade(upgrade)
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	helpers.AddFormatFlag(cmd, &format, helpers.FormatTable, []helpers.OutputFormat{
+		helpers.FormatTable,
+		helpers.FormatJSON,
+		helpers.FormatYAML,
+	})
+	cmd.Flags().StringVar(&version, "to", "", "Release version to roll out, e.g. v0.5.0")
+	cmd.Flags().StringArrayVar(&artifacts, "artifact", nil, "Executable of a platform, as os/arch=url (repeatable)")
+	cmd.Flags().StringArrayVar(&checksums, "sha256", nil, "SHA-256 of the executable of a platform, as os/arch=hex (repeatable)")
+	cmd.Flags().StringArrayVar(&agentIDs, "agent", nil, "Only upgrade this agent (repeatable, default: all agents)")
+	cmd.Flags().BoolVar(&cancel, "cancel", false, "Cancel the current rollout")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+// parseAgentArtifacts pairs the os/arch=url artifacts with the os/arch=hex
+// checksums of their platform.
+func parseAgentArtifacts(artifacts, checksums []string) ([]*colonyv1.AgentArtifact, error) {
+	if len(artifacts) == 0 {
+		return nil, fmt.Errorf("--artifact is required with --to")
+	}
+
+	sums := make(map[string]string)
+	for _, c := range checksums {
+		platform, sum, ok := strings.Cut(c, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --sha256 %q: want os/arch=hex", c)
+		}
+		sums[platform] = strings.ToLower(strings.TrimSpace(sum))
+	}
+
+	result := make([]*colonyv1.AgentArtifact, 0, len(artifacts))
+	for _, a := range artifacts {
+		platform, url, ok := strings.Cut(a, "=")
+		goos, goarch, okPlatform := strings.Cut(platform, "/")
+		if !ok || !okPlatform || goos == "" || goarch == "" {
+			return nil, fmt.Errorf("invalid --artifact %q: want os/arch=url", a)
+		}
+		sum, ok := sums[platform]
+		if !ok {
+			return nil, fmt.Errorf("--sha256 is missing for %s", platform)
+		}
+		delete(sums, platform)
+		result = append(result, &colonyv1.AgentArtifact{Os: goos, Arch: goarch, Url: url, Sha256: sum})
+	}
+	if len(sums) > 0 {
+		return nil, fmt.Errorf("--sha256 given for platforms without --artifact: %s", strings.Join(slices.Sorted(maps.Keys(sums)), ", "))
+	}
+	return result, nil
+}
+
+// outputAgentUpgrade prints a rollout and the progress of its agents.
+func outputAgentUpgrade(upgrade *colonyv1.AgentUpgrade) {
+	if upgrade.GetVersion() == "" {
+		fmt.Println("No agent release rollout in progress.")
+		return
+	}
+
+	fmt.Printf("Rolling out %s since %s\n", upgrade.Version, upgrade.StartedAt.AsTime().Local().Format(time.RFC3339))
+	for _, a := range upgrade.Artifacts {
+		fmt.Printf("  %s/%s: %s\n", a.Os, a.Arch, a.Url)
+	}
+	if len(upgrade.AgentIds) > 0 {
+		fmt.Printf("Agents: %s\n", strings.Join(upgrade.AgentIds, ", "))
+	}
+
+	counts := make(map[string]int)
+	for _, agent := range upgrade.Agents {
+		counts[agent.State]++
+	}
+	fmt.Printf("\nUpgraded: %d, pending: %d, failed: %d\n\n", counts["upgraded"], counts["pending"], counts["failed"])
+	if len(upgrade.Agents) == 0 {
+		return
+	}
+
+	fmt.Printf("%-25s %-15s %-10s %s\n", "AGENT ID", "VERSION", "STATE", "ERROR")
+	for _, agent := range upgrade.Agents {
+		version := agent.Version
+		if version == "" {
+			version = "-"
+		}
+		fmt.Printf("%-25s %-15s %-10s %s\n", truncate(agent.AgentId, 25), truncate(version, 15), agent.State, agent.Error)
+	}
+	fmt.Println("\nPending agents only install the release if they enabled updates, within their maintenance window.")
+}
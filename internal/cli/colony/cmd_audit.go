@@ -0,0 +1,141 @@
+package colony
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func newAuditCmd() *cobra.Command {
+	var (
+		since    time.Duration
+		action   string
+		actor    string
+		limit    int32
+		format   string
+		colonyID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the colony audit log",
+		Long: `Display control-plane actions recorded in the colony's audit log.
+
+Debug probe attach/detach, profiling runs, shell and container exec, and MCP
+tool calls are recorded with who ran them, what they targeted, a hash of their
+arguments and the result. Recording requires mcp.security.audit_enabled in the
+colony config; reading the log requires the admin permission when RBAC is
+enabled.
+
+Examples:
+  coral colony audit
+  coral colony audit --since 24h
+  coral colony audit --action ContainerExec --actor alice
+  coral colony audit --since 168h --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format %q (use table or json)", format)
+			}
+
+			ctx := cmd.Context()
+
+			client, _, err := helpers.GetColonyClientWithFallback(ctx, colonyID)
+			if err != nil {
+				return err
+			}
+
+			req := &colonyv1.ListAuditEventsRequest{
+				Action: action,
+				Actor:  actor,
+				Limit:  limit,
+			}
+			if since > 0 {
+				req.Since = timestamppb.New(time.Now().Add(-since))
+			}
+
+			resp, err := client.ListAuditEvents(ctx, connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to list audit events: %w", err)
+			}
+
+			if format == "json" {
+				return printAuditJSON(resp.Msg.Events)
+			}
+
+			if !resp.Msg.Enabled {
+				fmt.Fprintln(os.Stderr, "Audit logging is disabled; set mcp.security.audit_enabled in the colony config to record actions.")
+			}
+			if len(resp.Msg.Events) == 0 {
+				fmt.Println("No audit events.")
+				return nil
+			}
+			printAuditTable(resp.Msg.Events)
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&since, "since", 24*time.Hour, "Only show events newer than this (0 for all)")
+	cmd.Flags().StringVar(&action, "action", "", "Only show this action (e.g. AttachUprobe, ContainerExec, MCPToolCall)")
+	cmd.Flags().StringVar(&actor, "actor", "", "Only show actions by this actor")
+	cmd.Flags().Int32Var(&limit, "limit", constants.DefaultAuditListLimit, "Maximum number of events to show")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table or json")
+	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
+
+	return cmd
+}
+
+// printAuditTable writes audit events as an aligned table, newest first.
+func printAuditTable(events []*colonyv1.AuditEvent) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TIME\tACTOR\tACTION\tTARGET\tRESULT\tARGS HASH")
+	for _, e := range events {
+		result := e.Result
+		if e.Error != "" {
+			result += ": " + e.Error
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Timestamp.AsTime().Local().Format(time.DateTime),
+			e.Actor,
+			e.Action,
+			e.Target,
+			result,
+			shortHash(e.ArgumentsHash),
+		)
+	}
+	_ = w.Flush()
+}
+
+// printAuditJSON writes audit events as a JSON array.
+func printAuditJSON(events []*colonyv1.AuditEvent) error {
+	out := make([]json.RawMessage, 0, len(events))
+	for _, e := range events {
+		data, err := protojson.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit event: %w", err)
+		}
+		out = append(out, data)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// shortHash abbreviates an arguments hash for table output.
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
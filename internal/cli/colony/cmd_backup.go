@@ -0,0 +1,278 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/colony/backup"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/ha"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/daemon"
+	"github.com/coral-mesh/coral/pkg/version"
+)
+
+func newBackupCmd() *cobra.Command {
+	var (
+		colonyID string
+		out      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up colony storage and configuration",
+		Long: `Write a backup archive (zstd-compressed tar) of a colony.
+
+The archive holds a consistent snapshot of the colony database, taken with
+DuckDB EXPORT DATABASE (including mesh IP allocations), and the colony
+directory: configuration, CA certificates and API tokens.
+
+A running colony is snapshotted over its local HTTP endpoint; a stopped colony
+is read directly from its database file.
+
+Security Warning: The archive contains the colony CA private keys.
+Store it as securely as the colony host itself.
+
+Examples:
+  coral colony backup --out backup.tar.zst
+  coral colony backup --colony my-app-prod --out /backups/my-app-prod.tar.zst`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			resolver, err := config.NewResolver()
+			if err != nil {
+				return fmt.Errorf("failed to create config resolver: %w", err)
+			}
+			if colonyID == "" {
+				colonyID, err = resolver.ResolveColonyID()
+				if err != nil {
+					return fmt.Errorf("failed to resolve colony: %w", err)
+				}
+			}
+			cfg, err := resolver.ResolveConfig(colonyID)
+			if err != nil {
+				return fmt.Errorf("failed to load colony config: %w", err)
+			}
+			loader := resolver.GetLoader()
+
+			tmpDir, err := os.MkdirTemp("", "coral-backup-*")
+			if err != nil {
+				return fmt.Errorf("failed to create temporary directory: %w", err)
+			}
+			defer func() { _ = os.RemoveAll(tmpDir) }()
+
+			// EXPORT DATABASE requires a directory that does not exist yet.
+			snapshotDir := filepath.Join(tmpDir, "snapshot")
+			files := newDaemonFiles(loader, colonyID)
+			if err := snapshotColonyDatabase(ctx, loader, files, cfg.StoragePath, snapshotDir); err != nil {
+				return err
+			}
+
+			manifest := backup.Manifest{
+				ColonyID:     colonyID,
+				CreatedAt:    time.Now().UTC(),
+				CoralVersion: version.Version,
+			}
+			contents := backup.Contents{
+				SnapshotDir:     snapshotDir,
+				ColonyDir:       loader.ColonyDir(colonyID),
+				SkipConfigFiles: []string{constants.DefaultColonyPIDFile, constants.DefaultColonyLogFile},
+			}
+			if err := backup.Create(out, manifest, contents); err != nil {
+				return fmt.Errorf("failed to write backup: %w", err)
+			}
+
+			fmt.Printf("✓ Backed up colony %s to %s\n", colonyID, out)
+			if info, err := os.Stat(out); err == nil {
+				fmt.Printf("  Size: %s\n", formatBytes(info.Size()))
+			}
+			fmt.Printf("  Restore with: coral colony restore %s\n", out)
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	cmd.Flags().StringVarP(&out, "out", "o", "", "Backup archive to write (e.g. backup.tar.zst)")
+	_ = cmd.MarkFlagRequired("out")
+
+	return cmd
+}
+
+// snapshotColonyDatabase exports the colony database into dir. The database of
+// a running colony is locked by the colony process, so its snapshot is
+// fetched over the colony's local HTTP endpoint.
+func snapshotColonyDatabase(ctx context.Context, loader *config.Loader, files daemonFiles, storagePath, dir string) error {
+	_, running, err := daemon.Status(files.pidPath)
+	if err != nil {
+		return err
+	}
+
+	if running {
+		colonyConfig, err := loader.LoadColonyConfig(files.colonyID)
+		if err != nil {
+			return fmt.Errorf("failed to load colony config: %w", err)
+		}
+		connectPort := colonyConfig.Services.ConnectPort
+		if connectPort == 0 {
+			connectPort = constants.DefaultColonyPort
+		}
+
+		client := &http.Client{Timeout: constants.DefaultHASnapshotTimeout}
+		baseURL := fmt.Sprintf("http://127.0.0.1:%d", connectPort)
+		if err := ha.FetchSnapshot(ctx, client, baseURL, dir); err != nil {
+			return fmt.Errorf("failed to snapshot running colony: %w", err)
+		}
+		return nil
+	}
+
+	colonyConfig, err := loader.LoadColonyConfig(files.colonyID)
+	if err != nil {
+		return fmt.Errorf("failed to load colony config: %w", err)
+	}
+	enc, err := databaseEncryption(ctx, colonyConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load storage encryption key: %w", err)
+	}
+	if !database.Exists(storagePath, files.colonyID, enc) {
+		return fmt.Errorf("colony database not found in %s", storagePath)
+	}
+
+	db, err := openDatabase(storagePath, files.colonyID, enc, true, constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	if err != nil {
+		return fmt.Errorf("failed to open colony database: %w", err)
+	}
+	defer db.Close() // nolint:errcheck
+
+	return db.ExportSnapshot(ctx, dir)
+}
+
+func newRestoreCmd() *cobra.Command {
+	var (
+		force       bool
+		storagePath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <backup>",
+		Short: "Restore a colony from a backup",
+		Long: `Restore a colony from an archive written by 'coral colony backup'.
+
+The colony directory (configuration, CA, tokens) and database are replaced by
+the backup contents. The colony must be stopped. An existing colony directory
+is kept with a ".pre-restore" suffix.
+
+Use --storage-path when migrating to a host with a different storage layout;
+it is saved in the restored colony configuration.
+
+Examples:
+  coral colony restore backup.tar.zst
+  coral colony restore backup.tar.zst --force
+  coral colony restore backup.tar.zst --storage-path /var/lib/coral`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			archivePath := args[0]
+
+			resolver, err := config.NewResolver()
+			if err != nil {
+				return fmt.Errorf("failed to create config resolver: %w", err)
+			}
+			loader := resolver.GetLoader()
+
+			// Stage next to the colonies directory so the config is moved
+			// into place with a rename.
+			if err := os.MkdirAll(loader.ColoniesDir(), 0700); err != nil {
+				return fmt.Errorf("failed to create colonies directory: %w", err)
+			}
+			stagingDir, err := os.MkdirTemp(loader.ColoniesDir(), ".restore-*")
+			if err != nil {
+				return fmt.Errorf("failed to create staging directory: %w", err)
+			}
+			defer func() { _ = os.RemoveAll(stagingDir) }()
+
+			extracted, err := backup.Extract(archivePath, stagingDir)
+			if err != nil {
+				return err
+			}
+			colonyID := extracted.Manifest.ColonyID
+
+			if err := newDaemonFiles(loader, colonyID).checkNotRunning(); err != nil {
+				return err
+			}
+
+			colonyDir := loader.ColonyDir(colonyID)
+			if _, err := os.Stat(colonyDir); err == nil && !force {
+				return fmt.Errorf("colony %s already exists; use --force to replace it", colonyID)
+			}
+
+			rollback, err := backup.InstallConfig(extracted.ConfigDir, colonyDir)
+			if err != nil {
+				return err
+			}
+
+			if err := restoreColonyDatabase(ctx, resolver, colonyID, storagePath, extracted.SnapshotDir); err != nil {
+				if rbErr := rollback(); rbErr != nil {
+					return fmt.Errorf("%w (failed to restore previous colony directory: %v)", err, rbErr)
+				}
+				return err
+			}
+
+			fmt.Printf("✓ Restored colony %s from %s\n", colonyID, archivePath)
+			fmt.Printf("  Backup created: %s (coral %s)\n",
+				extracted.Manifest.CreatedAt.Local().Format("2006-01-02 15:04:05"), extracted.Manifest.CoralVersion)
+			fmt.Printf("  Start with: coral colony start --colony %s\n", colonyID)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Replace an existing colony")
+	cmd.Flags().StringVar(&storagePath, "storage-path", "", "Storage directory for the restored database (saved in the colony config)")
+
+	return cmd
+}
+
+// restoreColonyDatabase saves the storage path override, if any, in the
+// restored colony config and restores the database snapshot into the
+// resolved storage path.
+func restoreColonyDatabase(ctx context.Context, resolver *config.Resolver, colonyID, storagePath, snapshotDir string) error {
+	loader := resolver.GetLoader()
+
+	if storagePath != "" {
+		colonyConfig, err := loader.LoadColonyConfig(colonyID)
+		if err != nil {
+			return fmt.Errorf("failed to load restored colony config: %w", err)
+		}
+		colonyConfig.StoragePath = storagePath
+		if err := loader.SaveColonyConfig(colonyConfig); err != nil {
+			return fmt.Errorf("failed to save colony config: %w", err)
+		}
+	}
+
+	cfg, err := resolver.ResolveConfig(colonyID)
+	if err != nil {
+		return fmt.Errorf("failed to load restored colony config: %w", err)
+	}
+
+	colonyConfig, err := loader.LoadColonyConfig(colonyID)
+	if err != nil {
+		return fmt.Errorf("failed to load restored colony config: %w", err)
+	}
+	enc, err := databaseEncryption(ctx, colonyConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load storage encryption key: %w", err)
+	}
+
+	if err := database.RestoreSnapshot(ctx, snapshotDir, cfg.StoragePath, colonyID, enc); err != nil {
+		return fmt.Errorf("failed to restore colony database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package colony
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// eventTypePrefix is the enum value prefix stripped from event type names.
+const eventTypePrefix = "COLONY_EVENT_TYPE_"
+
+// eventsReconnectDelay is the pause before resubscribing after the event
+// stream ends in follow mode.
+const eventsReconnectDelay = 2 * time.Second
+
+func newEventsCmd() *cobra.Command {
+	var (
+		follow      bool
+		types       []string
+		serviceName string
+		agentID     string
+		format      string
+		colonyID    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Show and follow colony events",
+		Long: `Display recent colony events and optionally stream new ones as they happen.
+
+Event types:
+  agent_connected        Agent registered or resumed heartbeats
+  agent_disconnected     Agent stopped sending heartbeats
+  service_registered     Service added to an agent
+  service_deregistered   Service removed from an agent
+  debug_session_started  Debug session (uprobe) attached
+  debug_session_stopped  Debug session (uprobe) detached
+  profiling_completed    On-demand profiling completed
+
+Use --format json to emit one JSON object per line for automation.
+
+Examples:
+  coral colony events
+  coral colony events --follow
+  coral colony events --follow --type agent_disconnected,service_deregistered
+  coral colony events --follow --service api --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format %q (use table or json)", format)
+			}
+
+			eventTypes, err := parseEventTypes(types)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			client, _, err := helpers.GetColonyClientWithFallback(ctx, colonyID)
+			if err != nil {
+				return err
+			}
+
+			req := &colonyv1.SubscribeEventsRequest{
+				Types:       eventTypes,
+				ServiceName: serviceName,
+				AgentId:     agentID,
+				Replay:      true,
+				Follow:      follow,
+			}
+
+			for {
+				err := streamEvents(ctx, client, req, format)
+				if !follow || ctx.Err() != nil {
+					return err
+				}
+
+				// The colony restarted or the connection timed out; resume
+				// without replaying events already printed.
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Event stream interrupted: %v, reconnecting...\n", err)
+				}
+				req.Replay = false
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(eventsReconnectDelay):
+				}
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Stream new events as they happen")
+	cmd.Flags().StringSliceVarP(&types, "type", "t", nil, "Only show these event types (comma-separated)")
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Only show events for this service")
+	cmd.Flags().StringVar(&agentID, "agent", "", "Only show events for this agent")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table or json")
+	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
+
+	return cmd
+}
+
+// streamEvents prints events from one subscription until the stream ends.
+func streamEvents(
+	ctx context.Context,
+	client colonyv1connect.ColonyServiceClient,
+	req *colonyv1.SubscribeEventsRequest,
+	format string,
+) error {
+	stream, err := client.SubscribeEvents(ctx, connect.NewRequest(req))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to events: %w", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	printed := 0
+	for stream.Receive() {
+		if err := printEvent(stream.Msg(), format); err != nil {
+			return err
+		}
+		printed++
+	}
+
+	if err := stream.Err(); err != nil && !errors.Is(ctx.Err(), context.Canceled) {
+		return fmt.Errorf("event stream failed: %w", err)
+	}
+	if printed == 0 && !req.Follow && format == "table" {
+		fmt.Println("No recent events.")
+	}
+	return nil
+}
+
+// printEvent writes an event as a table row or a JSON line.
+func printEvent(event *colonyv1.ColonyEvent, format string) error {
+	if format == "json" {
+		data, err := protojson.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	var subject []string
+	if event.AgentId != "" {
+		subject = append(subject, "agent="+event.AgentId)
+	}
+	if event.ServiceName != "" {
+		subject = append(subject, "service="+event.ServiceName)
+	}
+	if event.SessionId != "" {
+		subject = append(subject, "session="+event.SessionId)
+	}
+
+	keys := make([]string, 0, len(event.Attributes))
+	for k := range event.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if v := event.Attributes[k]; v != "" {
+			subject = append(subject, k+"="+v)
+		}
+	}
+
+	fmt.Printf("%s  %-22s %s  %s\n",
+		event.Timestamp.AsTime().Local().Format(time.DateTime),
+		eventTypeName(event.Type),
+		event.Message,
+		strings.Join(subject, " "),
+	)
+	return nil
+}
+
+// eventTypeName returns the short lowercase name of an event type.
+func eventTypeName(t colonyv1.ColonyEventType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), eventTypePrefix))
+}
+
+// parseEventTypes converts short event type names to enum values.
+func parseEventTypes(names []string) ([]colonyv1.ColonyEventType, error) {
+	types := make([]colonyv1.ColonyEventType, 0, len(names))
+	for _, name := range names {
+		value, ok := colonyv1.ColonyEventType_value[eventTypePrefix+strings.ToUpper(strings.TrimSpace(name))]
+		if !ok || value == int32(colonyv1.ColonyEventType_COLONY_EVENT_TYPE_UNSPECIFIED) {
+			return nil, fmt.Errorf("unknown event type %q", name)
+		}
+		types = append(types, colonyv1.ColonyEventType(value))
+	}
+	return types, nil
+}
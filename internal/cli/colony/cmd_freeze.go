@@ -0,0 +1,185 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+func newFreezeCmd() *cobra.Command {
+	var (
+		colonyID string
+		service  string
+		duration time.Duration
+		reason   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "freeze",
+		Args:  cobra.NoArgs,
+		Short: "Block new debug and profiling sessions against a service",
+		Long: `Freeze a service for a duration, e.g. during an incident or a load test.
+
+While a service is frozen, the colony starts no new debug sessions, HTTP or
+TLS captures, correlations, runtime traces or profiles against it, including
+scheduled profiles. Sessions already running are not stopped. Debug commands
+fail with SERVICE_FROZEN (exit code 12).
+
+Admins can start a session anyway with --override-freeze on the debug and
+profile commands. Freezes, unfreezes and overrides are recorded in the audit
+log (coral colony audit).
+
+Freezing a frozen service replaces its freeze.`,
+		Example: `  # Freeze api during a load test
+  coral colony freeze --service api --for 2h --reason "load test"
+
+  # List frozen services
+  coral colony freeze list
+
+  # Lift the freeze early
+  coral colony unfreeze --service api`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if service == "" {
+				return fmt.Errorf("--service is required")
+			}
+			if duration <= 0 {
+				return fmt.Errorf("--for must be positive")
+			}
+
+			client, err := colonyClient(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+
+			resp, err := client.FreezeService(ctx, connect.NewRequest(&colonyv1.FreezeServiceRequest{
+				Service:  service,
+				Duration: durationpb.New(duration),
+				Reason:   reason,
+				User:     os.Getenv("USER"),
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to freeze service: %w", err)
+			}
+
+			fmt.Printf("✓ Service %s frozen until %s\n", service, resp.Msg.Freeze.ExpiresAt.AsTime().Local().Format(time.RFC3339))
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	cmd.Flags().StringVar(&service, "service", "", "Service to freeze")
+	cmd.Flags().DurationVar(&duration, "for", 0, "How long the service stays frozen (e.g. 30m, 2h)")
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the service is frozen")
+
+	cmd.AddCommand(newFreezeListCmd())
+
+	return cmd
+}
+
+func newFreezeListCmd() *cobra.Command {
+	var (
+		colonyID string
+		format   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List frozen services",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := colonyClient(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+
+			resp, err := client.ListServiceFreezes(ctx, connect.NewRequest(&colonyv1.ListServiceFreezesRequest{}))
+			if err != nil {
+				return fmt.Errorf("failed to list service freezes: %w", err)
+			}
+			freezes := resp.Msg.Freezes
+
+			if format != string(helpers.FormatTable) {
+				formatter, err := helpers.NewFormatter(helpers.OutputFormat(format))
+				if err != nil {
+					return err
+				}
+				return formatter.Format(freezes, os.Stdout)
+			}
+
+			if len(freezes) == 0 {
+				fmt.Println("No frozen services.")
+				return nil
+			}
+			fmt.Printf("%-20s %-20s %-12s %-20s %s\n", "SERVICE", "UNTIL", "REMAINING", "FROZEN BY", "REASON")
+			for _, f := range freezes {
+				expiresAt := f.ExpiresAt.AsTime()
+				fmt.Printf("%-20s %-20s %-12s %-20s %s\n",
+					truncate(f.Service, 20),
+					expiresAt.Local().Format("2006-01-02 15:04:05"),
+					time.Until(expiresAt).Round(time.Minute),
+					truncate(f.FrozenBy, 20),
+					f.Reason)
+			}
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	helpers.AddFormatFlag(cmd, &format, helpers.FormatTable, []helpers.OutputFormat{
+		helpers.FormatTable,
+		helpers.FormatJSON,
+		helpers.FormatYAML,
+	})
+
+	return cmd
+}
+
+func newUnfreezeCmd() *cobra.Command {
+	var (
+		colonyID string
+		service  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "unfreeze",
+		Short: "Lift the freeze of a service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if service == "" {
+				return fmt.Errorf("--service is required")
+			}
+
+			client, err := colonyClient(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+
+			if _, err := client.UnfreezeService(ctx, connect.NewRequest(&colonyv1.UnfreezeServiceRequest{Service: service})); err != nil {
+				return fmt.Errorf("failed to unfreeze service: %w", err)
+			}
+
+			fmt.Printf("✓ Service %s unfrozen\n", service)
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	cmd.Flags().StringVar(&service, "service", "", "Service to unfreeze")
+
+	return cmd
+}
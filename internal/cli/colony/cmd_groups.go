@@ -0,0 +1,182 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/config"
+)
+
+// colonyClient connects to the colony colonyID, or the current colony.
+func colonyClient(ctx context.Context, colonyID string) (colonyv1connect.ColonyServiceClient, error) {
+	if colonyID == "" {
+		resolver, err := config.NewResolver()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create config resolver: %w", err)
+		}
+		colonyID, err = resolver.ResolveColonyID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve colony: %w", err)
+		}
+	}
+
+	client, _, err := helpers.GetColonyClientWithFallback(ctx, colonyID)
+	return client, err
+}
+
+func newGroupsCmd() *cobra.Command {
+	var (
+		colonyID string
+		format   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "groups",
+		Short: "List service groups",
+		Long: `List the service groups of the colony.
+
+A service group names a set of services, e.g. "checkout-path" for api,
+payments and inventory. Profile, debug and query commands target all
+services of a group with --group:
+
+  coral profile cpu --group checkout-path
+  coral debug capture-http --group checkout-path --route /api/checkout
+  coral query summary --group checkout-path
+
+Groups are defined in service_groups of the colony config or created with
+'coral colony groups set'. Groups of the config cannot be changed with the
+CLI.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := colonyClient(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+
+			resp, err := client.ListServiceGroups(ctx, connect.NewRequest(&colonyv1.ListServiceGroupsRequest{}))
+			if err != nil {
+				return fmt.Errorf("failed to list service groups: %w", err)
+			}
+			groups := resp.Msg.Groups
+
+			if format != string(helpers.FormatTable) {
+				formatter, err := helpers.NewFormatter(helpers.OutputFormat(format))
+				if err != nil {
+					return err
+				}
+				return formatter.Format(groups, os.Stdout)
+			}
+
+			if len(groups) == 0 {
+				fmt.Println("No service groups defined.")
+				return nil
+			}
+			fmt.Printf("%-20s %-8s %-40s %s\n", "GROUP", "SOURCE", "SERVICES", "DESCRIPTION")
+			for _, g := range groups {
+				source := "cli"
+				if g.FromConfig {
+					source = "config"
+				}
+				fmt.Printf("%-20s %-8s %-40s %s\n",
+					truncate(g.Name, 20), source, truncate(strings.Join(g.Services, ","), 40), g.Description)
+			}
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	helpers.AddFormatFlag(cmd, &format, helpers.FormatTable, []helpers.OutputFormat{
+		helpers.FormatTable,
+		helpers.FormatJSON,
+		helpers.FormatYAML,
+	})
+
+	cmd.AddCommand(newGroupsSetCmd())
+	cmd.AddCommand(newGroupsDeleteCmd())
+
+	return cmd
+}
+
+func newGroupsSetCmd() *cobra.Command {
+	var (
+		colonyID    string
+		description string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set <group> <service>...",
+		Short: "Create or replace a service group",
+		Long: `Create a service group, or replace the services and description of an
+existing one. Group names use lowercase letters, digits, '.', '_' and '-'.`,
+		Example: `  # Group the services on the checkout path
+  coral colony groups set checkout-path api payments inventory --description "Checkout"`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := colonyClient(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+
+			resp, err := client.SetServiceGroup(ctx, connect.NewRequest(&colonyv1.SetServiceGroupRequest{
+				Name:        args[0],
+				Services:    args[1:],
+				Description: description,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to set service group: %w", err)
+			}
+
+			fmt.Printf("✓ Service group %s: %s\n", resp.Msg.Group.Name, strings.Join(resp.Msg.Group.Services, ", "))
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	cmd.Flags().StringVar(&description, "description", "", "Description of the group")
+
+	return cmd
+}
+
+func newGroupsDeleteCmd() *cobra.Command {
+	var colonyID string
+
+	cmd := &cobra.Command{
+		Use:   "delete <group>",
+		Short: "Delete a service group",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := colonyClient(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+
+			if _, err := client.DeleteServiceGroup(ctx, connect.NewRequest(&colonyv1.DeleteServiceGroupRequest{Name: args[0]})); err != nil {
+				return fmt.Errorf("failed to delete service group: %w", err)
+			}
+
+			fmt.Printf("✓ Service group %s deleted\n", args[0])
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+
+	return cmd
+}
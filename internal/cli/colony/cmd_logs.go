@@ -0,0 +1,62 @@
+package colony
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/daemon"
+)
+
+func newLogsCmd() *cobra.Command {
+	var (
+		colonyID string
+		follow   bool
+		lines    int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show the colony daemon log",
+		Long: `Show the log of a colony started with 'coral colony start --daemon'.
+
+Examples:
+  coral colony logs
+  coral colony logs -n 500
+  coral colony logs -f`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := resolveDaemonFiles(colonyID)
+			if err != nil {
+				return err
+			}
+
+			offset, err := daemon.Tail(files.logPath, lines, os.Stdout)
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("no log file at %s; the colony has not been started with --daemon", files.logPath)
+			}
+			if err != nil {
+				return err
+			}
+
+			if !follow {
+				return nil
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return daemon.Follow(ctx, files.logPath, offset, constants.DefaultLogFollowInterval, os.Stdout)
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing new log output as it is written")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 100, "Number of lines to show from the end of the log (-1 for all)")
+
+	return cmd
+}
@@ -0,0 +1,111 @@
+package colony
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func newMigrateCmd() *cobra.Command {
+	var (
+		colonyID string
+		dryRun   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply pending colony database schema migrations",
+		Long: `Apply pending schema migrations to the colony database.
+
+Migrations are also applied automatically when the colony starts; this
+command lets operators review (--dry-run) or apply them ahead of an upgrade.
+The colony must be stopped.
+
+Examples:
+  coral colony migrate --dry-run
+  coral colony migrate --colony my-app-prod`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			resolver, err := config.NewResolver()
+			if err != nil {
+				return fmt.Errorf("failed to create config resolver: %w", err)
+			}
+			if colonyID == "" {
+				colonyID, err = resolver.ResolveColonyID()
+				if err != nil {
+					return fmt.Errorf("failed to resolve colony: %w", err)
+				}
+			}
+			cfg, err := resolver.ResolveConfig(colonyID)
+			if err != nil {
+				return fmt.Errorf("failed to load colony config: %w", err)
+			}
+
+			// The running colony holds the database lock and has already
+			// applied its migrations on startup.
+			if err := newDaemonFiles(resolver.GetLoader(), colonyID).checkNotRunning(); err != nil {
+				return err
+			}
+
+			colonyConfig, err := resolver.GetLoader().LoadColonyConfig(colonyID)
+			if err != nil {
+				return fmt.Errorf("failed to load colony config: %w", err)
+			}
+			enc, err := databaseEncryption(ctx, colonyConfig)
+			if err != nil {
+				return fmt.Errorf("failed to load storage encryption key: %w", err)
+			}
+			if !database.Exists(cfg.StoragePath, colonyID, enc) {
+				return fmt.Errorf("colony database not found in %s", cfg.StoragePath)
+			}
+
+			roDB, err := openDatabase(cfg.StoragePath, colonyID, enc, true, constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+			if err != nil {
+				return fmt.Errorf("failed to open colony database: %w", err)
+			}
+			pending, err := roDB.Migrate(ctx, true)
+			_ = roDB.Close()
+			if err != nil {
+				return err
+			}
+
+			if len(pending) == 0 {
+				fmt.Println("✓ Colony database schema is up to date")
+				return nil
+			}
+
+			if dryRun {
+				fmt.Printf("Pending migrations for colony %s:\n", colonyID)
+				for _, m := range pending {
+					fmt.Printf("  %4d  %s\n", m.Version, m.Name)
+				}
+				return nil
+			}
+
+			// Opening the database read-write applies pending migrations.
+			db, err := openDatabase(cfg.StoragePath, colonyID, enc, false, constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+			if err != nil {
+				return err
+			}
+			defer db.Close() // nolint:errcheck
+
+			fmt.Printf("✓ Applied %d migration(s) to colony %s:\n", len(pending), colonyID)
+			for _, m := range pending {
+				fmt.Printf("  %4d  %s\n", m.Version, m.Name)
+			}
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List pending migrations without applying them")
+
+	return cmd
+}
@@ -0,0 +1,110 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/config"
+)
+
+func newRotateSecretCmd() *cobra.Command {
+	var (
+		colonyID    string
+		gracePeriod time.Duration
+		force       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate-secret",
+		Short: "Rotate the colony secret agents register with",
+		Long: `Generate a new colony secret and accept the previous one for a grace period.
+
+The running colony saves the new secret to its config and pushes it over the
+mesh to the connected agents that registered with the colony secret; they
+keep it in their certificate directory and register with it from then on.
+Agents it cannot be pushed to, e.g. disconnected ones, are listed: they keep
+registering with the previous secret until the grace period ends, and must be
+given the new secret (CORAL_COLONY_SECRET) before then.
+
+The colony's discovery registrations are endorsed with the previous secret
+during the grace period, so that discovery accepts the new signing key.
+
+Agents registering with a client certificate alone are unaffected.`,
+		Example: `  # Rotate, accepting the previous secret for 24 hours
+  coral colony rotate-secret
+
+  # Rotate with a one-week grace period
+  coral colony rotate-secret --grace-period 168h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolver, err := config.NewResolver()
+			if err != nil {
+				return fmt.Errorf("failed to create config resolver: %w", err)
+			}
+			if colonyID == "" {
+				colonyID, err = resolver.ResolveColonyID()
+				if err != nil {
+					return fmt.Errorf("failed to resolve colony: %w", err)
+				}
+			}
+
+			if !force {
+				fmt.Printf("Rotate the colony secret of %q? The previous secret stops working after %s.\n", colonyID, gracePeriod)
+				fmt.Print("Type 'yes' to confirm: ")
+
+				var confirm string
+				if _, err := fmt.Scanln(&confirm); err != nil {
+					return fmt.Errorf("failed to read user confirmation: %w", err)
+				}
+				if confirm != "yes" {
+					fmt.Println("Cancelled.")
+					return nil
+				}
+			}
+
+			client, _, err := helpers.GetColonyClientWithFallback(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 60*time.Second)
+			defer cancel()
+
+			resp, err := client.RotateColonySecret(ctx, connect.NewRequest(&colonyv1.RotateColonySecretRequest{
+				GracePeriod: durationpb.New(gracePeriod),
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to rotate colony secret: %w", err)
+			}
+
+			fmt.Printf("Colony secret rotated.\n\n")
+			fmt.Printf("New colony secret:\n  %s\n\n", resp.Msg.ColonySecret)
+			fmt.Printf("Previous secret valid until: %s\n", resp.Msg.PreviousExpiresAt.AsTime().Local().Format(time.RFC3339))
+
+			fmt.Printf("\nAgents updated: %d\n", len(resp.Msg.UpdatedAgents))
+			for _, agentID := range resp.Msg.UpdatedAgents {
+				fmt.Printf("  %s\n", agentID)
+			}
+			if len(resp.Msg.StaleAgents) > 0 {
+				fmt.Printf("\nAgents still on the previous secret: %d\n", len(resp.Msg.StaleAgents))
+				for _, agent := range resp.Msg.StaleAgents {
+					fmt.Printf("  %s: %s\n", agent.AgentId, agent.Error)
+				}
+				fmt.Printf("\nUpdate CORAL_COLONY_SECRET of these agents before the grace period ends.\n")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
+	cmd.Flags().DurationVar(&gracePeriod, "grace-period", 24*time.Hour, "How long the previous secret is still accepted")
+	cmd.Flags().BoolVar(&force, "force", false, "Skip confirmation prompt")
+
+	return cmd
+}
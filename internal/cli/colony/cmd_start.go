@@ -16,21 +16,27 @@ import (
 
 	discoverypb "github.com/coral-mesh/coral/coral/discovery/v1"
 	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/bandwidth"
 	"github.com/coral-mesh/coral/internal/colony"
 	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/ha"
+	"github.com/coral-mesh/coral/internal/colony/mesh"
+	"github.com/coral-mesh/coral/internal/colony/poller"
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 	"github.com/coral-mesh/coral/internal/colony/registry"
 	colonywg "github.com/coral-mesh/coral/internal/colony/wireguard"
 	"github.com/coral-mesh/coral/internal/config"
 	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/daemon"
 	"github.com/coral-mesh/coral/internal/discovery/registration"
 	"github.com/coral-mesh/coral/internal/logging"
 )
 
 func newStartCmd() *cobra.Command {
 	var (
-		daemon   bool
-		colonyID string
-		port     int
+		runDaemon bool
+		colonyID  string
+		port      int
 	)
 
 	cmd := &cobra.Command{
@@ -71,10 +77,20 @@ Production Deployment:
   For agents to connect from different machines, you MUST set CORAL_PUBLIC_ENDPOINT
   to your colony's publicly reachable IP address or hostname.
 
+High Availability:
+  A standby colony runs on another host with a copy of the colony config
+  directory and ha.mode: standby. It copies the primary's database every
+  ha.snapshot_interval and, once the primary has been unreachable for
+  ha.failover_after, starts up as the colony and takes over its discovery
+  registration so agents reconnect to it.
+
 Examples:
   # Local development (agents on same machine)
   coral colony start
 
+  # In the background; follow the log with 'coral colony logs -f'
+  coral colony start --daemon
+
   # Production with public IP
   CORAL_PUBLIC_ENDPOINT=203.0.113.5:41580 coral colony start
 
@@ -106,6 +122,18 @@ Examples:
 				}
 			}
 
+			files := newDaemonFiles(resolver.GetLoader(), colonyID)
+			if err := files.checkNotRunning(); err != nil {
+				return err
+			}
+
+			// With --daemon, re-run this command in the background and
+			// return once it has started. The background copy carries on
+			// below.
+			if runDaemon && !daemon.IsChild() {
+				return startDaemon(files, os.Args[1:])
+			}
+
 			// Load resolved configuration
 			cfg, err := resolver.ResolveConfig(colonyID)
 			if err != nil {
@@ -130,7 +158,7 @@ Examples:
 				Pretty: true,
 			}, "colony")
 
-			if daemon {
+			if runDaemon {
 				logger.Info().Msg("Starting colony in daemon mode")
 			} else {
 				logger.Info().Msg("Starting colony")
@@ -146,6 +174,45 @@ Examples:
 				Int("wireguard_port", cfg.WireGuard.Port).
 				Msg("Colony configuration loaded")
 
+			// Limit and compress data-plane transfers with agents before any
+			// poller or handler moves data.
+			bw := colonyConfigForEndpoints.Bandwidth
+			bandwidthLimiter := bandwidth.New(int64(bw.PerAgentKBps)*1024, int64(bw.GlobalKBps)*1024)
+			colony.ConfigureAgentClients(bandwidthLimiter, !bw.DisableCompression)
+			if bandwidthLimiter != nil {
+				logger.Info().
+					Int("per_agent_kbps", bw.PerAgentKBps).
+					Int("global_kbps", bw.GlobalKBps).
+					Msg("Agent bandwidth limits enabled")
+			}
+
+			// Fetch the storage encryption key before anything touches the
+			// database.
+			dbEncryption, err := databaseEncryption(cmd.Context(), colonyConfigForEndpoints)
+			if err != nil {
+				return fmt.Errorf("failed to load storage encryption key: %w", err)
+			}
+
+			// A standby colony replicates the primary's database until the
+			// primary fails, then continues startup to take over.
+			if colonyConfigForEndpoints.HA.Mode == config.HAModeStandby {
+				// A standby counts as started, so it can be stopped and a
+				// background standby does not time out waiting to start.
+				if err := daemon.WritePIDFile(files.pidPath); err != nil {
+					return err
+				}
+				defer daemon.RemovePIDFile(files.pidPath)
+
+				promote, err := runStandby(cfg, colonyConfigForEndpoints, dbEncryption, logger)
+				if err != nil {
+					return err
+				}
+				if !promote {
+					return nil
+				}
+				logger.Warn().Msg("Promoting standby colony to primary")
+			}
+
 			// Determine connections cache TTL (RFD 092).
 			connectionsCacheTTL := constants.DefaultConnectionsCacheTTL
 			if colonyConfigForEndpoints != nil && colonyConfigForEndpoints.Beyla.ConnectionsCacheTTL > 0 {
@@ -153,14 +220,33 @@ Examples:
 			}
 
 			// Initialize DuckDB storage.
-			db, err := database.New(cfg.StoragePath, cfg.ColonyID, connectionsCacheTTL, logger)
+			db, err := openDatabase(cfg.StoragePath, cfg.ColonyID, dbEncryption, false, connectionsCacheTTL, logger)
 			if err != nil {
 				return fmt.Errorf("failed to initialize database: %w", err)
 			}
-			defer func() { _ = db.Close() }() // TODO: errcheck
+			defer func() {
+				if err := db.Close(); err != nil {
+					logger.Error().Err(err).Msg("Failed to close database")
+				}
+			}()
 
-			// TODO: Implement remaining colony startup tasks
-			// - Start HTTP server for dashboard on cfg.Dashboard.Port
+			// Cache the results of expensive queries until new data is
+			// ingested (query_cache).
+			var queryCacheCfg config.QueryCacheConfig
+			if colonyConfigForEndpoints != nil {
+				queryCacheCfg = colonyConfigForEndpoints.QueryCache
+			}
+			if !queryCacheCfg.Disabled {
+				db.SetQueryCache(querycache.New(queryCacheCfg.Size, queryCacheCfg.TTL))
+			}
+
+			// Encrypt the database back to the storage path periodically, so
+			// a crash does not lose a working copy kept on a tmpfs.
+			if dbEncryption != nil {
+				sealCtx, stopSealing := context.WithCancel(context.Background())
+				defer stopSealing()
+				go sealDatabase(sealCtx, db, colonyConfigForEndpoints.StorageEncryption.SealInterval, logger)
+			}
 
 			// Initialize WireGuard device (but don't start it yet)
 			wgDevice, err := colonywg.CreateDevice(cfg, logger)
@@ -214,7 +300,14 @@ Examples:
 			}
 
 			// Start gRPC/Connect server for agent registration and colony management.
-			meshServer, tokenStore, err := startServers(cfg, wgDevice, agentRegistry, db, endpoints, logger)
+			// Poller per-agent stats are exposed on /status.
+			pollStats := poller.NewStatsRegistry()
+
+			// The colony secret agents register with, and the previous one
+			// during the grace period of a rotation.
+			colonySecrets := mesh.NewColonySecrets(colonyConfigForEndpoints.ColonySecret, colonyConfigForEndpoints.AgentAuth.PreviousColonySecret)
+
+			meshServer, tokenStore, err := startServers(cfg, wgDevice, agentRegistry, db, endpoints, pollStats, bandwidthLimiter, colonySecrets, cmd.Root(), logger)
 			if err != nil {
 				return fmt.Errorf("failed to start servers: %w", err)
 			}
@@ -304,8 +397,11 @@ Examples:
 				ObservedEndpoint:  colonyObservedEndpoint, // Add STUN-discovered endpoint.
 				PublicEndpoint:    publicEndpoint,         // Add public endpoint info (RFD 085).
 			}
+			regConfig.ColonySecret, regConfig.PreviousColonySecret = colonySecrets.Secrets()
 
 			regManager := registration.NewManager(regConfig, logger)
+			// Discovery re-pins the mesh to the key of a rotated secret.
+			colonySecrets.OnRotate(regManager.SetColonySecret)
 
 			// Start registration manager (performs initial registration and starts heartbeat)
 			ctx := context.Background()
@@ -337,6 +433,8 @@ Examples:
 				logger,
 			)
 
+			pollStats.Register("telemetry", telemetryPoller.Scheduler())
+
 			if err := telemetryPoller.Start(); err != nil {
 				logger.Warn().
 					Err(err).
@@ -381,6 +479,8 @@ Examples:
 				logger,
 			)
 
+			pollStats.Register("beyla", beylaPoller.Scheduler())
+
 			if err := beylaPoller.Start(); err != nil {
 				logger.Warn().
 					Err(err).
@@ -395,6 +495,12 @@ Examples:
 					Msg("Beyla metrics poller started")
 			}
 
+			// Aggregate Beyla metrics into rollups for long-range queries.
+			beylaRollup := colony.NewBeylaRollupService(ctx, db, logger)
+			if err := beylaRollup.Start(); err != nil {
+				logger.Warn().Err(err).Msg("Failed to start Beyla rollup service")
+			}
+
 			// Create and start System Metrics poller for RFD 071.
 			// Read system metrics configuration from colony config, with sensible defaults.
 			systemMetricsPollInterval := 60 * time.Second // Default: poll every 60 seconds
@@ -516,84 +622,137 @@ Examples:
 				Str("colony_id", cfg.ColonyID).
 				Msg("Colony started successfully")
 
-			if !daemon {
+			// The PID file marks the colony as started for 'coral colony
+			// start --daemon' and lets 'coral colony stop' find it.
+			if err := daemon.WritePIDFile(files.pidPath); err != nil {
+				return err
+			}
+			defer daemon.RemovePIDFile(files.pidPath)
+
+			if !runDaemon {
 				fmt.Println("\nPress Ctrl+C to stop")
+			}
 
-				// Wait for interrupt or reload signal.
-				sigChan := make(chan os.Signal, 1)
-				signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
-				for sig := range sigChan {
-					if sig == syscall.SIGHUP {
-						logger.Info().Msg("Received SIGHUP, reloading configuration")
-						reloadConfig(cfg.ColonyID, tokenStore, logger)
-						continue
-					}
-					break // SIGINT/SIGTERM → shutdown.
+			// Wait for interrupt or reload signal.
+			sigChan := make(chan os.Signal, 1)
+			signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+			for sig := range sigChan {
+				if sig == syscall.SIGHUP {
+					logger.Info().Msg("Received SIGHUP, reloading configuration")
+					reloadConfig(cfg.ColonyID, tokenStore, logger)
+					continue
 				}
+				break // SIGINT/SIGTERM → shutdown.
+			}
 
-				fmt.Println("\n\nShutting down colony...")
+			fmt.Println("\n\nShutting down colony...")
 
-				// Stop telemetry poller
-				if err := telemetryPoller.Stop(); err != nil {
-					logger.Warn().
-						Err(err).
-						Msg("Error stopping telemetry poller")
-				}
+			// Stop telemetry poller
+			if err := telemetryPoller.Stop(); err != nil {
+				logger.Warn().
+					Err(err).
+					Msg("Error stopping telemetry poller")
+			}
 
-				// Stop Beyla metrics poller
-				if err := beylaPoller.Stop(); err != nil {
-					logger.Warn().
-						Err(err).
-						Msg("Error stopping Beyla metrics poller")
-				}
+			// Stop Beyla metrics poller
+			if err := beylaPoller.Stop(); err != nil {
+				logger.Warn().
+					Err(err).
+					Msg("Error stopping Beyla metrics poller")
+			}
 
-				// Stop System Metrics poller
-				if err := systemMetricsPoller.Stop(); err != nil {
-					logger.Warn().
-						Err(err).
-						Msg("Error stopping system metrics poller")
-				}
+			// Stop Beyla rollup service
+			if err := beylaRollup.Stop(); err != nil {
+				logger.Warn().
+					Err(err).
+					Msg("Error stopping Beyla rollup service")
+			}
 
-				// Stop CPU Profile poller
-				if err := cpuProfilePoller.Stop(); err != nil {
-					logger.Warn().
-						Err(err).
-						Msg("Error stopping CPU profile poller")
-				}
+			// Stop System Metrics poller
+			if err := systemMetricsPoller.Stop(); err != nil {
+				logger.Warn().
+					Err(err).
+					Msg("Error stopping system metrics poller")
+			}
 
-				// Stop Service poller
-				if err := servicePoller.Stop(); err != nil {
-					logger.Warn().
-						Err(err).
-						Msg("Error stopping service poller")
-				}
+			// Stop CPU Profile poller
+			if err := cpuProfilePoller.Stop(); err != nil {
+				logger.Warn().
+					Err(err).
+					Msg("Error stopping CPU profile poller")
+			}
 
-				// Stop gap recovery service.
-				if err := gapRecovery.Stop(); err != nil {
-					logger.Warn().
-						Err(err).
-						Msg("Error stopping gap recovery service")
-				}
+			// Stop Service poller
+			if err := servicePoller.Stop(); err != nil {
+				logger.Warn().
+					Err(err).
+					Msg("Error stopping service poller")
+			}
 
-				// Stop registration manager
-				if err := regManager.Stop(); err != nil {
-					logger.Warn().
-						Err(err).
-						Msg("Error stopping registration manager")
-				}
+			// Stop gap recovery service.
+			if err := gapRecovery.Stop(); err != nil {
+				logger.Warn().
+					Err(err).
+					Msg("Error stopping gap recovery service")
+			}
+
+			// Stop registration manager
+			if err := regManager.Stop(); err != nil {
+				logger.Warn().
+					Err(err).
+					Msg("Error stopping registration manager")
 			}
 
 			return nil
 		},
 	}
 
-	cmd.Flags().BoolVar(&daemon, "daemon", false, "Run as background daemon")
+	cmd.Flags().BoolVar(&runDaemon, "daemon", false, "Run in the background (logs to colony.log in the colony directory)")
 	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (overrides auto-detection)")
 	cmd.Flags().IntVar(&port, "port", 0, "Dashboard port (overrides config)")
 
 	return cmd
 }
 
+// runStandby runs the colony as a standby replica (ha.mode: standby) until
+// the primary fails or the process is interrupted. It reports whether the
+// colony should be promoted.
+func runStandby(cfg *config.ResolvedConfig, colonyConfig *config.ColonyConfig, enc *database.Encryption, logger logging.Logger) (bool, error) {
+	primaryURL := colonyConfig.HA.PrimaryURL
+	if primaryURL == "" {
+		meshIPv4 := colonyConfig.WireGuard.MeshIPv4
+		if meshIPv4 == "" {
+			meshIPv4 = constants.DefaultColonyMeshIPv4
+		}
+		connectPort := colonyConfig.Services.ConnectPort
+		if connectPort == 0 {
+			connectPort = constants.DefaultColonyPort
+		}
+		primaryURL = fmt.Sprintf("http://%s:%d", meshIPv4, connectPort)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	standby := ha.NewStandby(ha.Config{
+		PrimaryURL:       primaryURL,
+		StoragePath:      cfg.StoragePath,
+		ColonyID:         cfg.ColonyID,
+		Encryption:       enc,
+		SnapshotInterval: colonyConfig.HA.SnapshotInterval,
+		FailoverAfter:    colonyConfig.HA.FailoverAfter,
+	}, logger)
+
+	if err := standby.Run(ctx); err != nil {
+		if ctx.Err() != nil {
+			fmt.Println("\n\nShutting down standby colony...")
+			return false, nil
+		}
+		return false, fmt.Errorf("standby replication failed: %w", err)
+	}
+	return true, nil
+}
+
 // reloadConfig reloads colony configuration and API tokens from disk.
 // Called on SIGHUP to pick up changes without restarting the process.
 func reloadConfig(colonyID string, tokenStore *auth.TokenStore, logger logging.Logger) {
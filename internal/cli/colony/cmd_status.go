@@ -233,7 +233,11 @@ This command is useful for troubleshooting connectivity issues.`,
 			fmt.Println("Services:")
 			fmt.Printf("  Discovery:      %s\n", globalConfig.Discovery.Endpoint)
 			fmt.Printf("  Agent Connect:  %s:%d (gRPC/Connect)\n", colonyConfig.WireGuard.MeshIPv4, connectPort)
-			fmt.Printf("  Dashboard:      http://localhost:%d (planned)\n", constants.DefaultDashboardPort)
+			dashboardPort := colonyConfig.Services.DashboardPort
+			if dashboardPort == 0 {
+				dashboardPort = constants.DefaultDashboardPort
+			}
+			fmt.Printf("  Dashboard:      http://localhost:%d\n", dashboardPort)
 			fmt.Println()
 
 			fmt.Println("Agent Connection Info:")
@@ -2,25 +2,83 @@ package colony
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/constants"
 )
 
 func newStopCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		colonyID string
+		timeout  time.Duration
+	)
+
+	cmd := &cobra.Command{
 		Use:   "stop",
 		Short: "Stop the colony daemon",
-		Long:  `Stop a running colony daemon.`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			fmt.Println("Stopping colony...")
+		Long: `Stop a running colony.
 
-			// TODO: Implement actual colony shutdown
-			// - Read PID file
-			// - Send SIGTERM to process
-			// - Wait for graceful shutdown
+Sends SIGTERM to the colony recorded in the colony's PID file and waits for it
+to shut down. Works for colonies started with 'coral colony start --daemon' as
+well as in the foreground.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := resolveDaemonFiles(colonyID)
+			if err != nil {
+				return err
+			}
 
-			fmt.Println("✓ Colony stopped")
+			stopped, err := stopDaemon(files, timeout)
+			if err != nil {
+				return err
+			}
+			if !stopped {
+				fmt.Printf("Colony %s is not running\n", files.colonyID)
+			}
 			return nil
 		},
 	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	cmd.Flags().DurationVar(&timeout, "timeout", constants.DefaultColonyStopTimeout, "How long to wait for the colony to shut down")
+
+	return cmd
+}
+
+func newRestartCmd() *cobra.Command {
+	var (
+		colonyID string
+		timeout  time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restart",
+		Short: "Restart the colony daemon",
+		Long: `Stop the running colony, if any, and start it again in the background.
+
+Equivalent to 'coral colony stop' followed by 'coral colony start --daemon'.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := resolveDaemonFiles(colonyID)
+			if err != nil {
+				return err
+			}
+
+			if _, err := stopDaemon(files, timeout); err != nil {
+				return err
+			}
+
+			// Re-run the sibling start command, e.g. "coral colony start" or
+			// "coral-colony start".
+			startArgs := append(strings.Fields(cmd.Parent().CommandPath())[1:], "start", "--daemon", "--colony", files.colonyID)
+			return startDaemon(files, startArgs)
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	cmd.Flags().DurationVar(&timeout, "timeout", constants.DefaultColonyStopTimeout, "How long to wait for the colony to shut down")
+
+	return cmd
 }
@@ -1,15 +1,21 @@
 package colony
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
 
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
 	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
 	"github.com/coral-mesh/coral/internal/config"
 )
 
@@ -30,12 +36,24 @@ Before using tokens, ensure the public endpoint is enabled in your colony config
     host: 127.0.0.1  # localhost-only for dev, 0.0.0.0 for production
     port: 8443
 
+Tokens also authorize actions on the mesh when mcp.security.require_rbac_for_actions
+is set, even if the public endpoint is disabled.
+
 Permissions:
   status  - Read colony status, agents, topology
   query   - Query metrics, traces, logs
   analyze - AI analysis (may trigger shell commands)
-  debug   - Attach live eBPF probes
-  admin   - Full administrative access`,
+  debug   - Attach live eBPF probes, profile, shell and container exec
+  admin   - Full administrative access
+
+Roles (issued per user with --user and --role):
+  viewer   - status, query
+  debugger - status, query, analyze, debug
+  admin    - Full administrative access
+
+Capability tokens (coral colony token mint) are short-lived tokens signed by
+the colony CA. They are never stored: they carry their scopes and expiry, and
+agents verify them with the colony's root CA.`,
 	}
 
 	cmd.AddCommand(newTokenCreateCmd())
@@ -43,6 +61,7 @@ Permissions:
 	cmd.AddCommand(newTokenShowCmd())
 	cmd.AddCommand(newTokenRevokeCmd())
 	cmd.AddCommand(newTokenDeleteCmd())
+	cmd.AddCommand(newTokenMintCmd())
 
 	return cmd
 }
@@ -51,6 +70,8 @@ func newTokenCreateCmd() *cobra.Command {
 	var (
 		colonyID    string
 		permissions string
+		user        string
+		role        string
 		rateLimit   string
 		recreate    bool
 	)
@@ -74,7 +95,11 @@ Examples:
   coral colony token create ide-vscode --permissions status,query,analyze
 
   # Create admin token (full access)
-  coral colony token create admin-token --permissions admin`,
+  coral colony token create admin-token --permissions admin
+
+  # Issue a token to a user with a role
+  coral colony token create alice-laptop --user alice --role debugger
+  coral colony token create bob-laptop --user bob --role viewer`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			tokenID := args[0]
@@ -103,8 +128,8 @@ Examples:
 				return fmt.Errorf("failed to load colony config: %w", err)
 			}
 
-			// Check if public endpoint is enabled.
-			if !colonyConfig.PublicEndpoint.Enabled {
+			// Check if tokens are used by the public endpoint or action RBAC.
+			if !colonyConfig.PublicEndpoint.Enabled && !colonyConfig.MCP.Security.RequireRBACForActions {
 				return fmt.Errorf("public endpoint is not enabled for colony %q\n\nEnable it in your colony config:\n  public_endpoint:\n    enabled: true", colonyID)
 			}
 
@@ -115,10 +140,28 @@ Examples:
 				tokensFile = filepath.Join(colonyDir, "tokens.yaml")
 			}
 
-			// Parse permissions.
-			perms := parsePermissions(permissions)
-			if len(perms) == 0 {
-				return fmt.Errorf("at least one permission required\n\nAvailable permissions: status, query, analyze, debug, admin")
+			// Parse the role or permissions.
+			var parsedRole auth.Role
+			var perms []auth.Permission
+			if role != "" {
+				if cmd.Flags().Changed("permissions") {
+					return fmt.Errorf("--role and --permissions are mutually exclusive")
+				}
+				if user == "" {
+					return fmt.Errorf("--user is required with --role")
+				}
+				parsedRole = auth.ParseRole(strings.TrimSpace(strings.ToLower(role)))
+				if parsedRole == "" {
+					return fmt.Errorf("invalid role %q\n\nAvailable roles: viewer, debugger, admin", role)
+				}
+			} else {
+				if user != "" {
+					return fmt.Errorf("--role is required with --user")
+				}
+				perms = parsePermissions(permissions)
+				if len(perms) == 0 {
+					return fmt.Errorf("at least one permission required\n\nAvailable permissions: status, query, analyze, debug, admin")
+				}
 			}
 
 			// Initialize token store.
@@ -134,7 +177,12 @@ Examples:
 			}
 
 			// Create token.
-			tokenInfo, err := tokenStore.GenerateToken(tokenID, perms, rateLimit)
+			var tokenInfo *auth.TokenInfo
+			if parsedRole != "" {
+				tokenInfo, err = tokenStore.GenerateUserToken(tokenID, user, parsedRole, rateLimit)
+			} else {
+				tokenInfo, err = tokenStore.GenerateToken(tokenID, perms, rateLimit)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to create token: %w", err)
 			}
@@ -142,7 +190,11 @@ Examples:
 			fmt.Println("Token created successfully!")
 			fmt.Println()
 			fmt.Printf("Token ID:    %s\n", tokenInfo.TokenID)
-			fmt.Printf("Permissions: %s\n", permissions)
+			if tokenInfo.User != "" {
+				fmt.Printf("User:        %s\n", tokenInfo.User)
+				fmt.Printf("Role:        %s\n", tokenInfo.Role)
+			}
+			fmt.Printf("Permissions: %s\n", joinPermissions(tokenInfo.Permissions))
 			if rateLimit != "" {
 				fmt.Printf("Rate Limit:  %s\n", rateLimit)
 			}
@@ -161,6 +213,8 @@ Examples:
 
 	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
 	cmd.Flags().StringVar(&permissions, "permissions", "status,query", "Comma-separated permissions (status,query,analyze,debug,admin)")
+	cmd.Flags().StringVar(&user, "user", "", "User the token is issued to (requires --role)")
+	cmd.Flags().StringVar(&role, "role", "", "Role granted to the user (viewer, debugger, admin)")
 	cmd.Flags().StringVar(&rateLimit, "rate-limit", "", "Rate limit (e.g., 100/hour, 50/minute)")
 	cmd.Flags().BoolVar(&recreate, "recreate", false, "Recreate token if it already exists (invalidates old token)")
 
@@ -223,13 +277,16 @@ Note: Token values are never displayed - only metadata.`,
 			fmt.Printf("API Tokens for colony %q:\n\n", colonyID)
 
 			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-			_, _ = fmt.Fprintln(w, "TOKEN ID\tPERMISSIONS\tRATE LIMIT\tCREATED\tLAST USED")
-			_, _ = fmt.Fprintln(w, "--------\t-----------\t----------\t-------\t---------")
+			_, _ = fmt.Fprintln(w, "TOKEN ID\tUSER\tROLE\tPERMISSIONS\tRATE LIMIT\tCREATED\tLAST USED")
+			_, _ = fmt.Fprintln(w, "--------\t----\t----\t-----------\t----------\t-------\t---------")
 
 			for _, t := range tokens {
-				perms := make([]string, len(t.Permissions))
-				for i, p := range t.Permissions {
-					perms[i] = string(p)
+				user, role := "-", "-"
+				if t.User != "" {
+					user = t.User
+				}
+				if t.Role != "" {
+					role = string(t.Role)
 				}
 
 				rateLimit := "-"
@@ -242,9 +299,11 @@ Note: Token values are never displayed - only metadata.`,
 					lastUsed = t.LastUsedAt.Format("2006-01-02 15:04")
 				}
 
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 					t.TokenID,
-					strings.Join(perms, ","),
+					user,
+					role,
+					joinPermissions(auth.EffectivePermissions(t)),
 					rateLimit,
 					t.CreatedAt.Format("2006-01-02 15:04"),
 					lastUsed,
@@ -315,12 +374,13 @@ Note: The actual token value is hashed and cannot be retrieved.`,
 			}
 
 			fmt.Printf("Token ID:    %s\n", token.TokenID)
-
-			perms := make([]string, len(token.Permissions))
-			for i, p := range token.Permissions {
-				perms[i] = string(p)
+			if token.User != "" {
+				fmt.Printf("User:        %s\n", token.User)
+			}
+			if token.Role != "" {
+				fmt.Printf("Role:        %s\n", token.Role)
 			}
-			fmt.Printf("Permissions: %s\n", strings.Join(perms, ","))
+			fmt.Printf("Permissions: %s\n", joinPermissions(auth.EffectivePermissions(token)))
 
 			rateLimit := "-"
 			if token.RateLimit != "" {
@@ -543,3 +603,95 @@ func parsePermissions(s string) []auth.Permission {
 
 	return perms
 }
+
+// joinPermissions formats permissions as a comma-separated string.
+func joinPermissions(perms []auth.Permission) string {
+	parts := make([]string, len(perms))
+	for i, p := range perms {
+		parts[i] = string(p)
+	}
+	return strings.Join(parts, ",")
+}
+
+func newTokenMintCmd() *cobra.Command {
+	var (
+		colonyID string
+		scopes   []string
+		ttl      time.Duration
+		subject  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "mint",
+		Short: "Mint a short-lived capability token",
+		Long: `Mint a short-lived capability token signed by the colony CA.
+
+A scope is a permission, optionally restricted to a service, e.g. "debug:api".
+The colony only mints scopes granted to the caller's own token. Capability
+tokens cannot mint other tokens.
+
+Agents with agent.security.require_capability_tokens set only accept shell
+and exec calls presenting a token granting debug for all services, or for one
+of the services they monitor. 'coral shell' and 'coral exec' mint one for
+each run when CORAL_API_TOKEN is not a capability token.
+
+Examples:
+  # Let a CI job debug the api service for 30 minutes
+  coral colony token mint --scope debug:api --ttl 30m --subject ci-deploy
+
+  # Read-only access for an AI assistant
+  coral colony token mint --scope status --scope query`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(scopes) == 0 {
+				return fmt.Errorf("at least one --scope required\n\nAvailable permissions: status, query, analyze, debug, admin")
+			}
+
+			resolver, err := config.NewResolver()
+			if err != nil {
+				return fmt.Errorf("failed to create config resolver: %w", err)
+			}
+			if colonyID == "" {
+				colonyID, err = resolver.ResolveColonyID()
+				if err != nil {
+					return fmt.Errorf("failed to resolve colony: %w", err)
+				}
+			}
+
+			client, _, err := helpers.GetColonyClientWithFallback(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := client.MintCapabilityToken(ctx, connect.NewRequest(&colonyv1.MintCapabilityTokenRequest{
+				Scopes:  scopes,
+				Ttl:     durationpb.New(ttl),
+				Subject: subject,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to mint capability token: %w", err)
+			}
+
+			fmt.Printf("Token ID:    %s\n", resp.Msg.TokenId)
+			fmt.Printf("Subject:     %s\n", resp.Msg.Subject)
+			fmt.Printf("Scopes:      %s\n", strings.Join(resp.Msg.Scopes, ", "))
+			fmt.Printf("Expires:     %s\n", resp.Msg.ExpiresAt.AsTime().Local().Format("2006-01-02 15:04:05"))
+			fmt.Println()
+			fmt.Printf("Token: %s\n", resp.Msg.Token)
+			fmt.Println()
+			fmt.Println("Usage:")
+			fmt.Printf("  export CORAL_API_TOKEN=%s\n", resp.Msg.Token)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
+	cmd.Flags().StringArrayVar(&scopes, "scope", nil, "Scope granted by the token, e.g. debug or debug:api (repeatable)")
+	cmd.Flags().DurationVar(&ttl, "ttl", auth.DefaultCapabilityTTL, "Token lifetime (at most 24h)")
+	cmd.Flags().StringVar(&subject, "subject", "", "Who the token is for (defaults to the caller)")
+
+	return cmd
+}
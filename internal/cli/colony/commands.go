@@ -30,19 +30,45 @@ It aggregates observations from agents, runs AI analysis, and provides insights.
 // Client-side context management commands (list, use, current, add-remote) are
 // intentionally excluded — they belong in the full coral CLI only.
 func RegisterCommands(parent *cobra.Command) {
-	addColonyCommands(parent)
+	for _, cmd := range colonyCommands() {
+		// RBAC maps command paths of the coral binary (see cliCommandPath).
+		cmd.Annotations = map[string]string{coralPathAnnotation: "colony " + cmd.Name()}
+		parent.AddCommand(cmd)
+	}
 }
 
+// coralPathAnnotation annotates a command with its path in the coral binary
+// when it differs, as in the flat coral-colony binary.
+const coralPathAnnotation = "coral.path"
+
 func addColonyCommands(cmd *cobra.Command) {
-	cmd.AddCommand(newStartCmd())
-	cmd.AddCommand(newStopCmd())
-	cmd.AddCommand(newStatusCmd())
-	cmd.AddCommand(newAgentsCmd())
-	cmd.AddCommand(newExportCmd())
-	cmd.AddCommand(newImportCmd())
-	cmd.AddCommand(newMCPCmd())
-	cmd.AddCommand(newServiceCmd()) // RFD 052 - Service-centric CLI.
-	cmd.AddCommand(NewCACmd())      // RFD 047 - CA management commands.
-	cmd.AddCommand(NewPSKCmd())     // RFD 088 - Bootstrap PSK management.
-	cmd.AddCommand(newTokenCmd())   // RFD 031 - API token management for public endpoint.
+	cmd.AddCommand(colonyCommands()...)
+}
+
+func colonyCommands() []*cobra.Command {
+	return []*cobra.Command{
+		newStartCmd(),
+		newStopCmd(),
+		newRestartCmd(),
+		newLogsCmd(),
+		newStatusCmd(),
+		newAgentsCmd(),
+		newAgentCmd(),
+		newEventsCmd(),
+		newAuditCmd(),
+		newExportCmd(),
+		newImportCmd(),
+		newBackupCmd(),
+		newRestoreCmd(),
+		newMigrateCmd(),
+		newMCPCmd(),
+		newServiceCmd(), // RFD 052 - Service-centric CLI.
+		NewCACmd(),      // RFD 047 - CA management commands.
+		NewPSKCmd(),     // RFD 088 - Bootstrap PSK management.
+		newTokenCmd(),   // RFD 031 - API token management for public endpoint.
+		newRotateSecretCmd(),
+		newGroupsCmd(),
+		newFreezeCmd(),
+		newUnfreezeCmd(),
+	}
 }
@@ -0,0 +1,115 @@
+package colony
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/daemon"
+)
+
+// daemonFiles locates a colony's PID and daemon log files, which live in the
+// colony directory.
+type daemonFiles struct {
+	colonyID string
+	pidPath  string
+	logPath  string
+}
+
+// resolveDaemonFiles resolves the colony (flag, environment or default) and
+// returns its daemon file paths.
+func resolveDaemonFiles(colonyID string) (daemonFiles, error) {
+	resolver, err := config.NewResolver()
+	if err != nil {
+		return daemonFiles{}, fmt.Errorf("failed to create config resolver: %w", err)
+	}
+
+	if colonyID == "" {
+		colonyID, err = resolver.ResolveColonyID()
+		if err != nil {
+			return daemonFiles{}, fmt.Errorf("failed to resolve colony: %w\n\nRun 'coral init <app-name>' to create a colony", err)
+		}
+	}
+
+	return newDaemonFiles(resolver.GetLoader(), colonyID), nil
+}
+
+func newDaemonFiles(loader *config.Loader, colonyID string) daemonFiles {
+	dir := loader.ColonyDir(colonyID)
+	return daemonFiles{
+		colonyID: colonyID,
+		pidPath:  filepath.Join(dir, constants.DefaultColonyPIDFile),
+		logPath:  filepath.Join(dir, constants.DefaultColonyLogFile),
+	}
+}
+
+// checkNotRunning fails if the PID file records a running colony. A stale PID
+// file left by a crashed colony is ignored.
+func (f daemonFiles) checkNotRunning() error {
+	pid, running, err := daemon.Status(f.pidPath)
+	if err != nil {
+		return err
+	}
+	if running {
+		return fmt.Errorf("colony %s is already running (PID %d); stop it with 'coral colony stop'", f.colonyID, pid)
+	}
+	return nil
+}
+
+// startDaemon starts the colony in the background by re-running the command
+// line with its output sent to the log file, and waits for it to finish
+// starting.
+func startDaemon(files daemonFiles, args []string) error {
+	if err := os.MkdirAll(filepath.Dir(files.logPath), 0700); err != nil {
+		return fmt.Errorf("failed to create colony directory: %w", err)
+	}
+
+	child, err := daemon.Spawn(args, files.logPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Starting colony %s in the background...\n", files.colonyID)
+	if err := daemon.WaitForStart(child, files.pidPath, constants.DefaultColonyDaemonStartTimeout); err != nil {
+		return fmt.Errorf("colony failed to start: %w\n\nSee %s for details", err, files.logPath)
+	}
+
+	fmt.Printf("✓ Colony started (PID %d)\n", child.Process.Pid)
+	fmt.Printf("  Logs: coral colony logs -f (%s)\n", files.logPath)
+	fmt.Println("  Stop: coral colony stop")
+	return nil
+}
+
+// stopDaemon stops a running colony and reports whether one was running.
+func stopDaemon(files daemonFiles, timeout time.Duration) (bool, error) {
+	pid, running, err := daemon.Status(files.pidPath)
+	if err != nil {
+		return false, err
+	}
+	if !running {
+		if pid != 0 {
+			// Stale PID file left by a colony that did not shut down cleanly.
+			_ = os.Remove(files.pidPath)
+		}
+		return false, nil
+	}
+
+	fmt.Printf("Stopping colony %s (PID %d)...\n", files.colonyID, pid)
+	if err := daemon.Stop(pid, timeout); err != nil {
+		if errors.Is(err, daemon.ErrStopTimeout) {
+			return true, fmt.Errorf("colony did not stop within %s", timeout)
+		}
+		if errors.Is(err, syscall.EPERM) {
+			return true, fmt.Errorf("%w (the colony runs as root; try sudo)", err)
+		}
+		return true, err
+	}
+
+	fmt.Println("✓ Colony stopped")
+	return true, nil
+}
@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -16,8 +19,14 @@ import (
 	"connectrpc.com/connect"
 
 	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/cli/ask"
 	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/colony/approval"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
 	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
 	"github.com/coral-mesh/coral/internal/logging"
 )
 
@@ -30,7 +39,10 @@ func newMCPCmd() *cobra.Command {
 
 The MCP server exposes colony observability and debugging capabilities as
 tools that can be consumed by any MCP-compatible client (Claude Desktop,
-coral ask, custom agents).
+coral ask, custom agents). Services, agents, debug sessions and recent
+profiles are also exposed as resources (e.g. coral://service/api/profile/latest)
+that clients can browse without calling a tool, and built-in investigation
+playbooks (e.g. find-memory-leak) as prompts.
 
 Examples:
   # List available MCP tools
@@ -43,13 +55,18 @@ Examples:
   coral colony mcp generate-config
 
   # Start MCP server proxy (used by Claude Desktop)
-  coral colony mcp proxy`,
+  coral colony mcp proxy
+
+  # Approve a tool call held by mcp.security.require_approval
+  coral colony mcp approvals approve <approval-id>`,
 	}
 
 	cmd.AddCommand(newMCPListToolsCmd())
 	cmd.AddCommand(newMCPTestToolCmd())
 	cmd.AddCommand(newMCPGenerateConfigCmd())
 	cmd.AddCommand(newMCPProxyCmd())
+	cmd.AddCommand(newMCPApprovalsCmd())
+	cmd.AddCommand(newMCPHistoryCmd())
 
 	return cmd
 }
@@ -300,6 +317,7 @@ Examples:
 func newMCPGenerateConfigCmd() *cobra.Command {
 	var (
 		allColonies bool
+		combined    bool
 		colonyID    string
 	)
 
@@ -323,9 +341,16 @@ Examples:
   # Generate config for all colonies
   coral colony mcp generate-config --all-colonies
 
+  # Generate one server covering all colonies
+  coral colony mcp generate-config --all-colonies --combined
+
   # Generate config for specific colony
   coral colony mcp generate-config --colony my-shop-production`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if combined && !allColonies {
+				return fmt.Errorf("--combined requires --all-colonies")
+			}
+
 			loader, err := config.NewLoader()
 			if err != nil {
 				return fmt.Errorf("failed to create config loader: %w", err)
@@ -364,13 +389,20 @@ Examples:
 
 			servers := config["mcpServers"].(map[string]interface{})
 
-			if len(colonies) == 1 {
+			switch {
+			case combined:
+				// One proxy serving every colony; tools take a colony argument.
+				servers["coral"] = map[string]interface{}{
+					"command": "coral",
+					"args":    []string{"colony", "mcp", "proxy", "--all-colonies"},
+				}
+			case len(colonies) == 1:
 				// Single colony: use simple name "coral".
 				servers["coral"] = map[string]interface{}{
 					"command": "coral",
 					"args":    []string{"colony", "mcp", "proxy"},
 				}
-			} else {
+			default:
 				// Multiple colonies: use "coral-<colony-id>".
 				for _, cid := range colonies {
 					serverName := fmt.Sprintf("coral-%s", cid)
@@ -395,6 +427,7 @@ Examples:
 	}
 
 	cmd.Flags().BoolVar(&allColonies, "all-colonies", false, "Generate config for all colonies")
+	cmd.Flags().BoolVar(&combined, "combined", false, "With --all-colonies, generate one server that serves every colony")
 	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (overrides default)")
 
 	return cmd
@@ -402,7 +435,10 @@ Examples:
 
 // newMCPProxyCmd creates the 'coral colony mcp proxy' command.
 func newMCPProxyCmd() *cobra.Command {
-	var colonyID string
+	var (
+		colonyIDs   []string
+		allColonies bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "proxy",
@@ -413,6 +449,11 @@ This command is used by Claude Desktop to communicate with the colony's
 MCP server. It connects to the running colony and forwards MCP protocol
 messages over stdio.
 
+With several colonies (--colony repeated, or --all-colonies), one proxy serves
+all of them: every tool takes a required "colony" argument naming the colony
+to run against, and resources take a ?colony= query parameter. Colonies that
+are not running are skipped.
+
 The colony must be running for this command to work.
 
 Examples:
@@ -420,7 +461,11 @@ Examples:
   coral colony mcp proxy
 
   # Connect to specific colony
-  coral colony mcp proxy --colony my-shop-production`,
+  coral colony mcp proxy --colony my-shop-production
+
+  # Serve dev, staging and prod from one MCP server
+  coral colony mcp proxy --colony my-shop-dev --colony my-shop-staging --colony my-shop-production
+  coral colony mcp proxy --all-colonies`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Create resolver.
 			resolver, err := config.NewResolver()
@@ -428,13 +473,25 @@ Examples:
 				return fmt.Errorf("failed to create config resolver: %w", err)
 			}
 
-			// Resolve colony ID.
-			if colonyID == "" {
-				colonyID, err = resolver.ResolveColonyID()
+			// Resolve colony IDs.
+			switch {
+			case allColonies:
+				colonyIDs, err = resolver.GetLoader().ListColonies()
+				if err != nil {
+					return fmt.Errorf("failed to list colonies: %w", err)
+				}
+				if len(colonyIDs) == 0 {
+					return fmt.Errorf("no colonies configured: run 'coral init'")
+				}
+			case len(colonyIDs) == 0:
+				colonyID, err := resolver.ResolveColonyID()
 				if err != nil {
 					return fmt.Errorf("failed to resolve colony: %w", err)
 				}
+				colonyIDs = []string{colonyID}
 			}
+			slices.Sort(colonyIDs)
+			colonyIDs = slices.Compact(colonyIDs)
 
 			// Initialize logger.
 			logger := logging.NewWithComponent(logging.Config{
@@ -445,49 +502,38 @@ Examples:
 
 			// Print startup banner to stderr
 			logger.Info().
-				Str("colony_id", colonyID).
+				Strs("colonies", colonyIDs).
 				Msg("Coral MCP Proxy starting...")
 
-			// Check if MCP is disabled.
-			loader := resolver.GetLoader()
-			colonyConfig, err := loader.LoadColonyConfig(colonyID)
-			if err != nil {
-				return fmt.Errorf("failed to load colony config: %w", err)
-			}
-
-			if colonyConfig.MCP.Disabled {
-				return fmt.Errorf("MCP server is disabled for colony %s", colonyID)
-			}
+			cliReference := ask.GenerateCLIReference(cmd.Root())
+			commands := newCLICommands(cmd.Root())
 
-			// Verify colony is running.
-			// Use shared helper.
-			client, baseURL, err := helpers.GetColonyClientWithFallback(cmd.Context(), colonyID)
-			if err != nil {
-				return fmt.Errorf("colony is not running or unreachable: %w", err)
-			}
-
-			logger = logger.With().
-				Str("colony_id", colonyID).
-				Str("connect_url", baseURL).
-				Logger()
-
-			logger.Info().Msg("Connecting to colony...")
-
-			// We already have the client from the helper
-
-			// Double check connection if desired, but helper does it.
-			// However, proxy command relies on client being ready.
-			// We can do a quick check to be sure and to log "Connected" message similar to before.
-			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-			defer cancel()
-
-			_, err = client.GetStatus(ctx, connect.NewRequest(&colonyv1.GetStatusRequest{}))
-			if err != nil {
-				return fmt.Errorf("failed to verify connection to colony: %w", err)
+			var proxy *mcpProxy
+			if len(colonyIDs) == 1 {
+				proxy, err = connectMCPProxy(cmd.Context(), resolver, colonyIDs[0], cliReference, commands, logger)
+				if err != nil {
+					return err
+				}
+			} else {
+				proxy = &mcpProxy{
+					logger:       logger,
+					cliReference: cliReference,
+					colonies:     make(map[string]*mcpProxy),
+					transport:    "stdio",
+				}
+				for _, colonyID := range colonyIDs {
+					colonyProxy, err := connectMCPProxy(cmd.Context(), resolver, colonyID, cliReference, commands, logger)
+					if err != nil {
+						logger.Warn().Err(err).Str("colony_id", colonyID).Msg("Skipping colony")
+						continue
+					}
+					proxy.colonies[colonyID] = colonyProxy
+				}
+				if len(proxy.colonies) == 0 {
+					return fmt.Errorf("none of the colonies %s is reachable", strings.Join(colonyIDs, ", "))
+				}
 			}
 
-			logger.Info().Msg("Connected to colony")
-
 			// Proxy handles MCP protocol on stdio. Tool calls (coral_cli) are
 			// executed locally as coral subprocesses (RFD 100).
 
@@ -495,29 +541,180 @@ Examples:
 			logger.Info().Msg("Tool dispatch: local CLI subprocess (coral_cli)")
 			logger.Info().Msg("MCP Proxy ready - waiting for requests...")
 
-			// Create MCP proxy. Tool calls are handled locally via CLI subprocesses.
-			// The colony connection above is used only to verify the colony is reachable.
-			_ = client // verified above; not used for tool dispatch (RFD 100)
-			proxy := &mcpProxy{
-				colonyID: colonyID,
-				logger:   logger,
-			}
-
 			// Start serving MCP protocol on stdio.
 			return proxy.Serve(cmd.Context())
 		},
 	}
 
-	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (overrides auto-detection)")
+	cmd.Flags().StringSliceVar(&colonyIDs, "colony", nil, "Colony ID (overrides auto-detection); repeat to serve several colonies")
+	cmd.Flags().BoolVar(&allColonies, "all-colonies", false, "Serve all configured colonies")
+	cmd.MarkFlagsMutuallyExclusive("colony", "all-colonies")
 
 	return cmd
 }
 
+// connectMCPProxy connects to a running colony and returns a proxy for it.
+// Tool calls are handled locally via CLI subprocesses; the colony connection
+// is used to check the caller's role for actions, hold calls for approval and
+// record them.
+func connectMCPProxy(
+	ctx context.Context,
+	resolver *config.Resolver,
+	colonyID, cliReference string,
+	commands *cliCommands,
+	logger logging.Logger,
+) (*mcpProxy, error) {
+	// Check if MCP is disabled.
+	colonyConfig, err := resolver.GetLoader().LoadColonyConfig(colonyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load colony config: %w", err)
+	}
+
+	if colonyConfig.MCP.Disabled {
+		return nil, fmt.Errorf("MCP server is disabled for colony %s", colonyID)
+	}
+
+	// Verify colony is running.
+	client, baseURL, err := helpers.GetColonyClientWithFallback(ctx, colonyID)
+	if err != nil {
+		return nil, fmt.Errorf("colony is not running or unreachable: %w", err)
+	}
+
+	logger = logger.With().
+		Str("colony_id", colonyID).
+		Str("connect_url", baseURL).
+		Logger()
+
+	logger.Info().Msg("Connecting to colony...")
+
+	checkCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	_, err = client.GetStatus(checkCtx, connect.NewRequest(&colonyv1.GetStatusRequest{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify connection to colony: %w", err)
+	}
+
+	logger.Info().Msg("Connected to colony")
+
+	// Actions require a token whose role grants them when either the
+	// local or the colony's config sets require_rbac_for_actions.
+	requireRBAC := colonyConfig.MCP.Security.RequireRBACForActions
+	approvalTools := colonyConfig.MCP.Security.RequireApproval
+	if identity, err := client.GetIdentity(checkCtx, connect.NewRequest(&colonyv1.GetIdentityRequest{})); err == nil {
+		requireRBAC = requireRBAC || identity.Msg.RbacForActions
+		approvalTools = append(approvalTools, identity.Msg.ApprovalRequiredTools...)
+		if identity.Msg.Authenticated {
+			logger.Info().
+				Str("user", identity.Msg.User).
+				Str("role", identity.Msg.Role).
+				Msg("Authenticated with API token")
+		}
+	}
+	if requireRBAC {
+		logger.Info().Msg("RBAC enforced for actions")
+	}
+	if len(approvalTools) > 0 {
+		logger.Info().Strs("tools", approvalTools).Msg("Approval required for tools")
+	}
+
+	return &mcpProxy{
+		colonyID:       colonyID,
+		logger:         logger,
+		requireRBAC:    requireRBAC,
+		commands:       commands,
+		cliReference:   cliReference,
+		maxResultBytes: colonyConfig.MCP.MaxResultBytes,
+		approvalTools:  approvalTools,
+		approvals:      client,
+		// coral_cli subprocesses must talk to this colony, not the default one.
+		env: []string{"CORAL_COLONY_ID=" + colonyID},
+		identity: func(ctx context.Context) (*colonyv1.GetIdentityResponse, error) {
+			resp, err := client.GetIdentity(ctx, connect.NewRequest(&colonyv1.GetIdentityRequest{}))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Msg, nil
+		},
+		audit: func(ctx context.Context, req *colonyv1.RecordAuditEventRequest) error {
+			_, err := client.RecordAuditEvent(ctx, connect.NewRequest(req))
+			return err
+		},
+		history: func(ctx context.Context, call *colonyv1.MCPToolCall) error {
+			_, err := client.RecordMCPToolCall(ctx, connect.NewRequest(&colonyv1.RecordMCPToolCallRequest{Call: call}))
+			return err
+		},
+		transport: "stdio",
+	}, nil
+}
+
 // mcpProxy handles the MCP stdio protocol for external clients (RFD 100).
 // Tool calls are handled locally via coral CLI subprocesses; no colony RPC is used for tools.
 type mcpProxy struct {
 	colonyID string
 	logger   logging.Logger
+
+	// requireRBAC checks coral_cli actions against the role of the proxy's
+	// API token (mcp.security.require_rbac_for_actions).
+	requireRBAC bool
+
+	// rbacForAllCommands also checks status and query commands. Set for
+	// remote clients, which always present an API token.
+	rbacForAllCommands bool
+
+	// commands resolves coral_cli arguments to the command they run.
+	commands *cliCommands
+
+	// allowedCommands, if set, are the only command paths coral_cli may run
+	// (httpapi.RemoteCLICommands for remote clients).
+	allowedCommands map[string]bool
+
+	// identity returns the identity of the proxy's API token (CORAL_API_TOKEN).
+	identity func(ctx context.Context) (*colonyv1.GetIdentityResponse, error)
+
+	// audit reports a tool call to the colony's audit log
+	// (mcp.security.audit_enabled).
+	audit func(ctx context.Context, req *colonyv1.RecordAuditEventRequest) error
+
+	// history records a tool call, with its arguments, in the colony's tool
+	// call history.
+	history func(ctx context.Context, call *colonyv1.MCPToolCall) error
+
+	// transport is "stdio" or "http"; client is the name and version the
+	// MCP client sent in initialize. The history records the client, or
+	// the transport if it is unknown.
+	transport string
+	client    string
+
+	// cliReference is served as the coral://cli/reference resource.
+	cliReference string
+
+	// user is recorded in the audit log; defaults to $USER.
+	user string
+
+	// env is added to the environment of coral_cli subprocesses.
+	env []string
+
+	// maxResultBytes is the size of a coral_cli result page (mcp.max_result_bytes).
+	maxResultBytes int
+
+	// approvalTools are the tools whose calls wait for a human decision
+	// (mcp.security.require_approval); approvals holds them at the colony.
+	approvalTools        []string
+	approvals            mcpApprovals
+	approvalPollInterval time.Duration
+
+	// colonies are the proxies of the colonies served when the proxy serves
+	// several (--colony repeated or --all-colonies). Tool calls and resource
+	// reads are routed to the one they name.
+	colonies map[string]*mcpProxy
+}
+
+// mcpApprovals is the part of the colony service that holds tool calls for
+// approval.
+type mcpApprovals interface {
+	CreateMCPApproval(context.Context, *connect.Request[colonyv1.CreateMCPApprovalRequest]) (*connect.Response[colonyv1.CreateMCPApprovalResponse], error)
+	GetMCPApproval(context.Context, *connect.Request[colonyv1.GetMCPApprovalRequest]) (*connect.Response[colonyv1.GetMCPApprovalResponse], error)
 }
 
 // mcpRequest represents an MCP JSON-RPC request.
@@ -566,11 +763,16 @@ func (p *mcpProxy) Serve(ctx context.Context) error {
 		}
 
 		// Log request to stderr for visibility
-		if req.Method == "tools/call" {
+		switch req.Method {
+		case "tools/call":
 			if toolName, ok := req.Params["name"].(string); ok {
 				fmt.Fprintf(os.Stderr, "→ Tool call: %s\n", toolName)
 			}
-		} else {
+		case "resources/read":
+			if uri, ok := req.Params["uri"].(string); ok {
+				fmt.Fprintf(os.Stderr, "→ Resource read: %s\n", uri)
+			}
+		default:
 			fmt.Fprintf(os.Stderr, "→ MCP request: %s\n", req.Method)
 		}
 
@@ -609,7 +811,23 @@ func (p *mcpProxy) handleRequest(ctx context.Context, req *mcpRequest) *mcpRespo
 	case "tools/list":
 		return p.handleListTools(ctx, req)
 	case "tools/call":
+		if p.colonies != nil {
+			return p.routeCallTool(ctx, req)
+		}
 		return p.handleCallTool(ctx, req)
+	case "resources/list":
+		return p.handleListResources(ctx, req)
+	case "resources/templates/list":
+		return p.handleListResourceTemplates(ctx, req)
+	case "resources/read":
+		if p.colonies != nil {
+			return p.routeReadResource(ctx, req)
+		}
+		return p.handleReadResource(ctx, req)
+	case "prompts/list":
+		return p.handleListPrompts(ctx, req)
+	case "prompts/get":
+		return p.handleGetPrompt(ctx, req)
 	case "initialize":
 		return p.handleInitialize(ctx, req)
 	default:
@@ -624,26 +842,41 @@ func (p *mcpProxy) handleRequest(ctx context.Context, req *mcpRequest) *mcpRespo
 	}
 }
 
-// handleInitialize handles MCP initialize requests.
+// handleInitialize handles MCP initialize requests. Clients speaking the
+// Streamable HTTP protocol version get it back; all others get 2024-11-05.
 func (p *mcpProxy) handleInitialize(ctx context.Context, req *mcpRequest) *mcpResponse {
+	protocolVersion := "2024-11-05"
+	if v, _ := req.Params["protocolVersion"].(string); v == "2025-03-26" {
+		protocolVersion = v
+	}
+
+	if info, ok := req.Params["clientInfo"].(map[string]interface{}); ok {
+		name, _ := info["name"].(string)
+		version, _ := info["version"].(string)
+		p.client = strings.TrimSpace(name + " " + version)
+	}
+
 	return &mcpResponse{
 		JSONRPC: "2.0",
 		ID:      req.ID,
 		Result: map[string]interface{}{
-			"protocolVersion": "2024-11-05",
+			"protocolVersion": protocolVersion,
 			"capabilities": map[string]interface{}{
-				"tools": map[string]bool{},
+				"tools":     map[string]bool{},
+				"resources": map[string]bool{},
+				"prompts":   map[string]bool{},
 			},
 			"serverInfo": map[string]interface{}{
-				"name":    fmt.Sprintf("coral-%s", p.colonyID),
+				"name":    p.serverName(),
 				"version": "1.0.0",
 			},
 		},
 	}
 }
 
-// handleListTools returns the coral_cli meta-tool schema (RFD 100).
-// The proxy handles coral_cli locally; no colony RPC is needed.
+// handleListTools returns the coral_cli meta-tool schema (RFD 100) and the
+// tools for common multi-step workflows. The proxy handles all of them
+// locally; no colony RPC is needed.
 func (p *mcpProxy) handleListTools(_ context.Context, req *mcpRequest) *mcpResponse {
 	tool := map[string]interface{}{
 		"name": "coral_cli",
@@ -659,19 +892,98 @@ func (p *mcpProxy) handleListTools(_ context.Context, req *mcpRequest) *mcpRespo
 					"description": `Coral subcommand and flags, e.g. ["query", "traces", "--service", "api", "--since", "10m"]. ` +
 						`Do not include "coral" itself. --format json is appended automatically.`,
 				},
+				"cursor": map[string]interface{}{
+					"type": "string",
+					"description": "next_cursor from a previous call, to fetch the next page of a large result. " +
+						"Pass the same args as that call.",
+				},
 			},
 			"required": []string{"args"},
 		},
 	}
+	compare := map[string]interface{}{
+		"name": "coral_compare_deployments",
+		"description": "Compare a service in the window before a deployment with the window after it. " +
+			"Returns one regression report: request count, error rate and p50/p95/p99 latency of both windows, " +
+			"CPU hotspots that appeared or grew, new kinds of failed requests, and a list of findings.",
+		"inputSchema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"service": map[string]interface{}{
+					"type":        "string",
+					"description": "Service name.",
+				},
+				"deploy_time": map[string]interface{}{
+					"type": "string",
+					"description": `Deployment time, RFC3339 or how long ago (e.g. "2h"). ` +
+						"Defaults to when the service's latest build was first seen.",
+				},
+				"window": map[string]interface{}{
+					"type":        "string",
+					"description": `Length of each window, e.g. "30m" (default) or "1h".`,
+				},
+			},
+			"required": []string{"service"},
+		},
+	}
+	topology := map[string]interface{}{
+		"name": "coral_get_service_topology",
+		"description": "Get the service dependency graph derived from traces, with requests per second, " +
+			"error rate and p95 latency per edge. With service, returns only the edges to and from it, " +
+			"its direct callers and callees, and all upstream and downstream services: its blast radius. " +
+			"Use this before attaching probes.",
+		"inputSchema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"service": map[string]interface{}{
+					"type":        "string",
+					"description": "Service to center the graph on. Omit for the whole graph.",
+				},
+				"since": map[string]interface{}{
+					"type":        "string",
+					"description": `Time window of observed calls, e.g. "15m". Defaults to "1h".`,
+				},
+				"include_l4": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Include edges seen only at the network layer, without traffic stats. Defaults to true.",
+				},
+			},
+		},
+	}
+	anomalies := map[string]interface{}{
+		"name": "coral_query_anomalies",
+		"description": "List the routes whose p95 latency or error rate deviates from its usual value at this " +
+			"hour of the day, as learned by the colony from its metric history. Each anomaly has the value, " +
+			"the baseline mean and standard deviation, and the score in standard deviations. " +
+			"Use this to find what changed before looking at traces or profiles.",
+		"inputSchema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"service": map[string]interface{}{
+					"type":        "string",
+					"description": "Service name. Omit for all services.",
+				},
+				"since": map[string]interface{}{
+					"type":        "string",
+					"description": `Time range, e.g. "24h". Defaults to "1h".`,
+				},
+				"include_normal": map[string]interface{}{
+					"type":        "boolean",
+					"description": "Also return the scores of metrics within their usual range. Defaults to false.",
+				},
+			},
+		},
+	}
 	return &mcpResponse{
 		JSONRPC: "2.0",
 		ID:      req.ID,
-		Result:  map[string]interface{}{"tools": []interface{}{tool}},
+		Result:  map[string]interface{}{"tools": p.withColonyArgument(tool, compare, topology, anomalies)},
 	}
 }
 
-// handleCallTool dispatches a tool call. coral_cli is handled locally (RFD 100);
-// all other tool names return an error since per-operation tools have been retired.
+// handleCallTool dispatches a tool call. All tools run a coral CLI command
+// locally (RFD 100) and share its RBAC checks, audit and pagination; other
+// tool names return an error since per-operation tools have been retired.
 func (p *mcpProxy) handleCallTool(ctx context.Context, req *mcpRequest) *mcpResponse {
 	// Extract tool name and arguments from params.
 	toolName, ok := req.Params["name"].(string)
@@ -683,61 +995,493 @@ func (p *mcpProxy) handleCallTool(ctx context.Context, req *mcpRequest) *mcpResp
 		}
 	}
 
-	if toolName != "coral_cli" {
-		return &mcpResponse{
-			JSONRPC: "2.0",
-			ID:      req.ID,
-			Error:   &mcpError{Code: -32601, Message: fmt.Sprintf("unknown tool: %s (only coral_cli is supported)", toolName)},
-		}
-	}
-
 	arguments, _ := req.Params["arguments"].(map[string]interface{})
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
 
-	// Extract args array from arguments.
-	argsRaw, ok := arguments["args"]
+	toolArgs, ok := mcpToolArgs[toolName]
 	if !ok {
 		return &mcpResponse{
 			JSONRPC: "2.0",
 			ID:      req.ID,
-			Error:   &mcpError{Code: -32602, Message: "coral_cli: missing required 'args' parameter"},
+			Error: &mcpError{Code: -32601, Message: fmt.Sprintf(
+				"unknown tool: %s (supported: coral_cli, coral_compare_deployments, coral_get_service_topology, coral_query_anomalies)", toolName)},
 		}
 	}
-	argsIface, ok := argsRaw.([]interface{})
-	if !ok {
+	args, err := toolArgs(arguments)
+	if err != nil {
 		return &mcpResponse{
 			JSONRPC: "2.0",
 			ID:      req.ID,
-			Error:   &mcpError{Code: -32602, Message: "coral_cli: 'args' must be an array of strings"},
+			Error:   &mcpError{Code: -32602, Message: toolName + ": " + err.Error()},
 		}
 	}
-	args := make([]string, len(argsIface))
-	for i, a := range argsIface {
-		s, ok := a.(string)
-		if !ok {
+
+	offset := 0
+	if cursor, _ := arguments["cursor"].(string); cursor != "" {
+		if offset, err = decodeCursor(cursor, args); err != nil {
 			return &mcpResponse{
 				JSONRPC: "2.0",
 				ID:      req.ID,
-				Error:   &mcpError{Code: -32602, Message: fmt.Sprintf("coral_cli: args[%d] is not a string", i)},
+				Error:   &mcpError{Code: -32602, Message: toolName + ": " + err.Error()},
 			}
 		}
-		args[i] = s
 	}
 
-	result, err := p.executeCLITool(ctx, args)
+	result, call, err := p.callTool(ctx, toolName, arguments, args)
 	if err != nil {
+		code := -32603
+		if call.Result == audit.ResultDenied {
+			code = -32600
+		}
 		return &mcpResponse{
 			JSONRPC: "2.0",
 			ID:      req.ID,
-			Error:   &mcpError{Code: -32603, Message: err.Error()},
+			Error:   &mcpError{Code: code, Message: err.Error()},
 		}
 	}
+
+	// Results larger than the budget are returned a page at a time, with a
+	// summary telling the model how much it is seeing and how to get more.
+	maxBytes := p.maxResultBytes
+	if maxBytes <= 0 {
+		maxBytes = constants.DefaultMCPMaxResultBytes
+	}
+	page := paginateResult(result, offset, maxBytes)
+
+	content := []map[string]interface{}{{"type": "text", "text": page.Text}}
+	toolResult := map[string]interface{}{"content": content}
+	if page.Next > 0 {
+		nextCursor := encodeCursor(args, page.Next)
+		page.Summary += fmt.Sprintf(" The result was truncated to %d bytes; call %s with the same arguments and cursor %q for the next page.",
+			maxBytes, toolName, nextCursor)
+		toolResult["next_cursor"] = nextCursor
+	}
+	if page.Summary != "" {
+		toolResult["content"] = append(content, map[string]interface{}{"type": "text", "text": page.Summary})
+	}
+
 	return &mcpResponse{
 		JSONRPC: "2.0",
 		ID:      req.ID,
-		Result: map[string]interface{}{
-			"content": []map[string]interface{}{{"type": "text", "text": result}},
-		},
+		Result:  toolResult,
+	}
+}
+
+// mcpToolArgs maps each tool to the function returning the coral arguments
+// of a call.
+var mcpToolArgs = map[string]func(arguments map[string]interface{}) ([]string, error){
+	"coral_cli":                  cliToolArgs,
+	"coral_compare_deployments":  compareDeploymentsArgs,
+	"coral_get_service_topology": serviceTopologyArgs,
+	"coral_query_anomalies":      anomaliesArgs,
+}
+
+// callTool runs coral <args> for a call to toolName and records the call in
+// the audit log and tool call history. It returns the full result and the
+// recorded call.
+func (p *mcpProxy) callTool(
+	ctx context.Context,
+	toolName string,
+	arguments map[string]interface{},
+	args []string,
+) (string, *colonyv1.MCPToolCall, error) {
+	start := time.Now()
+	result, outcome, err := p.runTool(ctx, toolName, args)
+	p.recordToolCall(ctx, args, outcome, err)
+
+	call := &colonyv1.MCPToolCall{
+		Tool:        toolName,
+		Command:     args,
+		DurationMs:  float64(time.Since(start).Microseconds()) / 1000,
+		ResultBytes: int64(len(result)),
+		Result:      outcome,
+	}
+	if data, err := json.Marshal(arguments); err == nil {
+		call.ArgumentsJson = string(data)
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+	p.recordHistory(ctx, call)
+
+	return result, call, err
+}
+
+// runTool checks that the caller may run coral <args>, waits for approval if
+// the tool requires it, and runs the command. The outcome is an audit result:
+// denied calls never ran.
+func (p *mcpProxy) runTool(ctx context.Context, toolName string, args []string) (string, string, error) {
+	if err := p.authorizeCLITool(ctx, args); err != nil {
+		return "", audit.ResultDenied, err
+	}
+	if err := p.awaitApproval(ctx, toolName, args); err != nil {
+		return "", audit.ResultDenied, err
+	}
+	result, err := p.executeCLITool(ctx, args)
+	if err != nil {
+		return "", audit.ResultFailed, err
+	}
+	return result, audit.ResultOK, nil
+}
+
+// cliToolArgs returns the coral arguments of a coral_cli call.
+func cliToolArgs(arguments map[string]interface{}) ([]string, error) {
+	argsRaw, ok := arguments["args"]
+	if !ok {
+		return nil, fmt.Errorf("missing required 'args' parameter")
+	}
+	argsIface, ok := argsRaw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("'args' must be an array of strings")
+	}
+	args := make([]string, len(argsIface))
+	for i, a := range argsIface {
+		s, ok := a.(string)
+		if !ok {
+			return nil, fmt.Errorf("args[%d] is not a string", i)
+		}
+		args[i] = s
+	}
+	return args, nil
+}
+
+// compareDeploymentsArgs maps a coral_compare_deployments call to
+// coral query compare, so that the regression report is one tool call.
+func compareDeploymentsArgs(arguments map[string]interface{}) ([]string, error) {
+	service, _ := arguments["service"].(string)
+	if service == "" {
+		return nil, fmt.Errorf("missing required 'service' parameter")
+	}
+	args := []string{"query", "compare", service}
+	for _, flag := range []string{"deploy_time", "window"} {
+		v, ok := arguments[flag]
+		if !ok {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("'%s' must be a string", flag)
+		}
+		if s != "" {
+			args = append(args, "--"+strings.ReplaceAll(flag, "_", "-"), s)
+		}
+	}
+	return args, nil
+}
+
+// serviceTopologyArgs maps a coral_get_service_topology call to coral query
+// topology.
+func serviceTopologyArgs(arguments map[string]interface{}) ([]string, error) {
+	args := []string{"query", "topology"}
+	for _, flag := range []string{"service", "since"} {
+		v, ok := arguments[flag]
+		if !ok {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("'%s' must be a string", flag)
+		}
+		if s != "" {
+			args = append(args, "--"+flag, s)
+		}
+	}
+	if v, ok := arguments["include_l4"]; ok {
+		includeL4, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'include_l4' must be a boolean")
+		}
+		args = append(args, fmt.Sprintf("--include-l4=%t", includeL4))
 	}
+	return args, nil
+}
+
+// anomaliesArgs maps a coral_query_anomalies call to coral query anomalies.
+func anomaliesArgs(arguments map[string]interface{}) ([]string, error) {
+	args := []string{"query", "anomalies"}
+	if v, ok := arguments["service"]; ok {
+		service, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("'service' must be a string")
+		}
+		if service != "" {
+			args = append(args, service)
+		}
+	}
+	if v, ok := arguments["since"]; ok {
+		since, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("'since' must be a string")
+		}
+		if since != "" {
+			args = append(args, "--since", since)
+		}
+	}
+	if v, ok := arguments["include_normal"]; ok {
+		includeNormal, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'include_normal' must be a boolean")
+		}
+		args = append(args, fmt.Sprintf("--all=%t", includeNormal))
+	}
+	return args, nil
+}
+
+// authorizeCLITool checks that the proxy's API token may run coral <args>.
+// Commands that do not resolve to a coral command, or are not in
+// allowedCommands, are denied. When RBAC is required for actions, status and
+// query commands are allowed unless rbacForAllCommands is set; shell, exec,
+// debug, profiling and analysis require a token whose role grants the
+// command's permission.
+func (p *mcpProxy) authorizeCLITool(ctx context.Context, args []string) error {
+	path, err := p.commands.path(args)
+	if err != nil {
+		return fmt.Errorf("permission denied: coral %s: %w", strings.Join(args, " "), err)
+	}
+	if p.allowedCommands != nil && !p.allowedCommands[path] {
+		return fmt.Errorf("permission denied: coral %s is not available to remote MCP clients", path)
+	}
+
+	if !p.requireRBAC {
+		return nil
+	}
+
+	required := httpapi.GetCLICommandPermission(strings.Fields(path))
+	if !p.rbacForAllCommands && (required == auth.PermissionStatus || required == auth.PermissionQuery) {
+		return nil
+	}
+
+	identity, err := p.identity(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify permissions for coral %s: %w", strings.Join(args, " "), err)
+	}
+	if !identity.Authenticated {
+		return fmt.Errorf("permission denied: coral %s requires %q permission; set CORAL_API_TOKEN to a token whose role grants it",
+			strings.Join(args, " "), required)
+	}
+
+	token := &auth.APIToken{TokenID: identity.TokenId, User: identity.User}
+	for _, perm := range identity.Permissions {
+		token.Permissions = append(token.Permissions, auth.Permission(perm))
+	}
+	if !auth.HasPermission(token, required) {
+		p.logger.Warn().
+			Str("token_id", identity.TokenId).
+			Str("user", identity.User).
+			Str("role", identity.Role).
+			Strs("args", args).
+			Str("required_permission", string(required)).
+			Msg("Permission denied for coral_cli")
+		return fmt.Errorf("permission denied: coral %s requires %q permission (user %q has role %q)",
+			strings.Join(args, " "), required, identity.User, identity.Role)
+	}
+
+	return nil
+}
+
+// cliCommands resolves coral_cli arguments against the command tree of the
+// coral binary that runs them.
+type cliCommands struct {
+	mu   sync.Mutex // cobra merges flags while resolving commands.
+	root *cobra.Command
+}
+
+// newCLICommands returns the resolver of commands run by root.
+func newCLICommands(root *cobra.Command) *cliCommands {
+	return &cliCommands{root: root}
+}
+
+// path returns the path of the command coral <args> runs as in the coral
+// binary, e.g. "colony token create" for "token create --role admin" run by
+// coral-colony. Flags and their values are skipped the way cobra skips them.
+// Arguments not naming a command are an error.
+func (c *cliCommands) path(args []string) (string, error) {
+	if c == nil || c.root == nil {
+		return "", fmt.Errorf("no commands to resolve")
+	}
+
+	c.mu.Lock()
+	cmd, _, err := c.root.Find(args)
+	c.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	if cmd == c.root {
+		return "", fmt.Errorf("no command given")
+	}
+
+	var words []string
+	for ; cmd != c.root; cmd = cmd.Parent() {
+		if path, ok := cmd.Annotations[coralPathAnnotation]; ok {
+			words = append(strings.Fields(path), words...)
+			break
+		}
+		words = append([]string{cmd.Name()}, words...)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// awaitApproval holds a call to a tool in approvalTools at the colony until
+// a human approves it, and returns an error if it is denied or expires.
+// coral_cli calls are matched by the tool their command replaced. An agent
+// may not decide approvals itself.
+func (p *mcpProxy) awaitApproval(ctx context.Context, toolName string, args []string) error {
+	if isApprovalDecision(args) {
+		return fmt.Errorf("coral %s: approvals must be decided by a human, not over MCP", strings.Join(args, " "))
+	}
+
+	tool := toolName
+	if toolName == "coral_cli" {
+		tool = httpapi.GetCLICommandTool(args)
+	}
+	if tool == "" || !slices.Contains(p.approvalTools, tool) {
+		return nil
+	}
+
+	command := strings.Join(args, " ")
+	if p.approvals == nil {
+		return fmt.Errorf("coral %s requires approval, but the colony cannot hold it", command)
+	}
+
+	created, err := p.approvals.CreateMCPApproval(ctx, connect.NewRequest(&colonyv1.CreateMCPApprovalRequest{
+		Tool:    tool,
+		Command: command,
+		User:    p.localUser(),
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to request approval for coral %s: %w", command, err)
+	}
+	id := created.Msg.Approval.GetId()
+
+	p.logger.Info().
+		Str("approval_id", id).
+		Str("tool", tool).
+		Msg("Tool call awaiting approval")
+	fmt.Fprintf(os.Stderr, "⏸ coral %s requires approval: coral colony mcp approvals approve %s\n", command, id)
+
+	interval := p.approvalPollInterval
+	if interval <= 0 {
+		interval = constants.DefaultMCPApprovalPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting for approval %s: %w", id, ctx.Err())
+		case <-ticker.C:
+		}
+
+		resp, err := p.approvals.GetMCPApproval(ctx, connect.NewRequest(&colonyv1.GetMCPApprovalRequest{Id: id}))
+		if err != nil {
+			return fmt.Errorf("failed to check approval %s: %w", id, err)
+		}
+
+		a := resp.Msg.Approval
+		switch approval.Status(a.GetStatus()) {
+		case approval.StatusPending:
+			continue
+		case approval.StatusApproved:
+			p.logger.Info().Str("approval_id", id).Str("decided_by", a.DecidedBy).Msg("Tool call approved")
+			return nil
+		case approval.StatusDenied:
+			msg := fmt.Sprintf("coral %s was denied by %s", command, a.DecidedBy)
+			if a.Reason != "" {
+				msg += ": " + a.Reason
+			}
+			return errors.New(msg)
+		default:
+			return fmt.Errorf("coral %s was not approved in time (approval %s %s)", command, id, a.GetStatus())
+		}
+	}
+}
+
+// isApprovalDecision reports whether args approve or deny an MCP approval.
+// Flags may appear anywhere in a cobra command line, so words are matched in
+// order rather than by position.
+func isApprovalDecision(args []string) bool {
+	want := []string{"colony", "mcp", "approvals"}
+	for _, arg := range args {
+		if len(want) == 0 {
+			if arg == "approve" || arg == "deny" {
+				return true
+			}
+		} else if arg == want[0] {
+			want = want[1:]
+		}
+	}
+	return false
+}
+
+// localUser is the user recorded for the proxy's calls; defaults to $USER.
+func (p *mcpProxy) localUser() string {
+	if p.user != "" {
+		return p.user
+	}
+	return os.Getenv("USER")
+}
+
+// recordToolCall reports a coral_cli call to the colony's audit log. The
+// arguments are recorded only as a hash since they may contain secrets.
+// Reporting is best-effort: the colony discards it unless auditing is enabled.
+func (p *mcpProxy) recordToolCall(ctx context.Context, args []string, result string, callErr error) {
+	if p.audit == nil {
+		return
+	}
+
+	req := &colonyv1.RecordAuditEventRequest{
+		Action:        "MCPToolCall",
+		Target:        cliTarget(args),
+		ArgumentsHash: audit.HashArgs(args),
+		Result:        result,
+		User:          p.localUser(),
+	}
+	if callErr != nil {
+		req.Error = callErr.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constants.DefaultAuditReportTimeout)
+	defer cancel()
+	if err := p.audit(ctx, req); err != nil {
+		p.logger.Debug().Err(err).Msg("Failed to report tool call to audit log")
+	}
+}
+
+// recordHistory reports a tool call to the colony's tool call history. Unlike
+// the audit log, the history keeps the arguments, so that the call can be
+// replayed; only admins can read it. Reporting is best-effort.
+func (p *mcpProxy) recordHistory(ctx context.Context, call *colonyv1.MCPToolCall) {
+	if p.history == nil {
+		return
+	}
+
+	call.Actor = p.localUser()
+	call.Client = p.client
+	if call.Client == "" {
+		call.Client = p.transport
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, constants.DefaultAuditReportTimeout)
+	defer cancel()
+	if err := p.history(ctx, call); err != nil {
+		p.logger.Debug().Err(err).Msg("Failed to record tool call in history")
+	}
+}
+
+// cliTarget returns the command and first positional argument of coral
+// <args>, e.g. "coral_cli exec api", omitting flags and the command to run.
+func cliTarget(args []string) string {
+	parts := []string{"coral_cli"}
+	for _, arg := range args {
+		if arg == "--" || strings.HasPrefix(arg, "-") || len(parts) == 3 {
+			break
+		}
+		parts = append(parts, arg)
+	}
+	return strings.Join(parts, " ")
 }
 
 // executeCLITool runs coral <args> --format json as a subprocess and returns stdout.
@@ -753,6 +1497,9 @@ func (p *mcpProxy) executeCLITool(ctx context.Context, args []string) (string, e
 	fmt.Fprintf(os.Stderr, "→ coral_cli: coral %s\n", strings.Join(args, " "))
 
 	cmd := exec.CommandContext(ctx, coralBin, args...) // #nosec G204
+	if len(p.env) > 0 {
+		cmd.Env = append(os.Environ(), p.env...)
+	}
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
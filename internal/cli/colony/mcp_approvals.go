@@ -0,0 +1,158 @@
+package colony
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/colony/approval"
+)
+
+// newMCPApprovalsCmd creates the 'coral colony mcp approvals' command.
+func newMCPApprovalsCmd() *cobra.Command {
+	var (
+		all      bool
+		format   string
+		colonyID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "approvals",
+		Short: "List MCP tool calls awaiting approval",
+		Long: `List MCP tool calls the colony is holding for a human decision.
+
+Calls to the tools listed in mcp.security.require_approval (for example
+coral_shell_exec, coral_container_exec and coral_attach_uprobe) wait until
+they are approved or denied, or until mcp.security.approval_timeout passes.
+Deciding requires the admin permission when RBAC is enabled, and cannot be
+done over MCP.
+
+Examples:
+  coral colony mcp approvals
+  coral colony mcp approvals --all
+  coral colony mcp approvals approve 3f2a9c1e-...
+  coral colony mcp approvals deny 3f2a9c1e-... --reason "use a read-only query"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format %q (use table or json)", format)
+			}
+
+			ctx := cmd.Context()
+			client, _, err := helpers.GetColonyClientWithFallback(ctx, colonyID)
+			if err != nil {
+				return err
+			}
+
+			req := &colonyv1.ListMCPApprovalsRequest{Status: string(approval.StatusPending)}
+			if all {
+				req.Status = ""
+			}
+			resp, err := client.ListMCPApprovals(ctx, connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to list approvals: %w", err)
+			}
+
+			if format == "json" {
+				return printApprovalsJSON(resp.Msg.Approvals)
+			}
+			if len(resp.Msg.Approvals) == 0 {
+				fmt.Println("No tool calls awaiting approval.")
+				return nil
+			}
+			printApprovalsTable(resp.Msg.Approvals)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Also show recently decided and expired calls")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table or json")
+	cmd.PersistentFlags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
+
+	cmd.AddCommand(newMCPApprovalDecisionCmd("approve", "Approve and run a pending MCP tool call", true, &colonyID))
+	cmd.AddCommand(newMCPApprovalDecisionCmd("deny", "Reject a pending MCP tool call", false, &colonyID))
+
+	return cmd
+}
+
+// newMCPApprovalDecisionCmd creates the approve and deny subcommands.
+func newMCPApprovalDecisionCmd(verb, short string, approve bool, colonyID *string) *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:   verb + " <approval-id>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			client, _, err := helpers.GetColonyClientWithFallback(ctx, *colonyID)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.DecideMCPApproval(ctx, connect.NewRequest(&colonyv1.DecideMCPApprovalRequest{
+				Id:      args[0],
+				Approve: approve,
+				Reason:  reason,
+				User:    os.Getenv("USER"),
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to %s: %w", verb, err)
+			}
+
+			a := resp.Msg.Approval
+			fmt.Printf("✓ %s: coral %s (%s, requested by %s)\n", a.Status, a.Command, a.Tool, a.Requester)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Reason shown to the requester")
+	return cmd
+}
+
+// printApprovalsTable writes approvals as an aligned table, oldest first.
+func printApprovalsTable(approvals []*colonyv1.MCPApproval) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tREQUESTED\tREQUESTER\tTOOL\tSTATUS\tCOMMAND")
+	for _, a := range approvals {
+		status := a.Status
+		switch {
+		case a.Status == string(approval.StatusPending):
+			status += fmt.Sprintf(" (%s left)", time.Until(a.ExpiresAt.AsTime()).Round(time.Second))
+		case a.DecidedBy != "":
+			status += " by " + a.DecidedBy
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\tcoral %s\n",
+			a.Id,
+			a.CreatedAt.AsTime().Local().Format(time.DateTime),
+			a.Requester,
+			a.Tool,
+			status,
+			a.Command,
+		)
+	}
+	_ = w.Flush()
+}
+
+// printApprovalsJSON writes approvals as a JSON array.
+func printApprovalsJSON(approvals []*colonyv1.MCPApproval) error {
+	out := make([]json.RawMessage, 0, len(approvals))
+	for _, a := range approvals {
+		data, err := protojson.Marshal(a)
+		if err != nil {
+			return fmt.Errorf("failed to encode approval: %w", err)
+		}
+		out = append(out, data)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
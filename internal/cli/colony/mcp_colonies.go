@@ -0,0 +1,160 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// colonyParam is the query parameter naming the colony of a resource URI
+// when the proxy serves several colonies.
+const colonyParam = "?colony="
+
+// colonyIDs returns the IDs of the colonies served, sorted.
+func (p *mcpProxy) colonyIDs() []string {
+	return slices.Sorted(maps.Keys(p.colonies))
+}
+
+// serverName is the name reported in initialize: coral-<colony>, or coral
+// when serving several colonies.
+func (p *mcpProxy) serverName() string {
+	if p.colonies != nil {
+		return "coral"
+	}
+	return fmt.Sprintf("coral-%s", p.colonyID)
+}
+
+// withColonyArgument returns tools, adding a required colony argument to
+// each when the proxy serves several colonies. Requiring it rather than
+// defaulting to one colony keeps a model from acting on production by
+// omission.
+func (p *mcpProxy) withColonyArgument(tools ...map[string]interface{}) []interface{} {
+	result := make([]interface{}, 0, len(tools))
+	for _, tool := range tools {
+		result = append(result, tool)
+		if p.colonies == nil {
+			continue
+		}
+		schema := tool["inputSchema"].(map[string]interface{})
+		schema["properties"].(map[string]interface{})["colony"] = map[string]interface{}{
+			"type":        "string",
+			"enum":        p.colonyIDs(),
+			"description": "Colony to run against.",
+		}
+		required, _ := schema["required"].([]string)
+		schema["required"] = append(slices.Clone(required), "colony")
+	}
+	return result
+}
+
+// routeCallTool runs a tool call on the colony named by its colony argument.
+func (p *mcpProxy) routeCallTool(ctx context.Context, req *mcpRequest) *mcpResponse {
+	arguments, _ := req.Params["arguments"].(map[string]interface{})
+	colonyID, _ := arguments["colony"].(string)
+	target, err := p.colonyProxy(colonyID)
+	if err != nil {
+		return &mcpResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   &mcpError{Code: -32602, Message: err.Error()},
+		}
+	}
+
+	params := maps.Clone(req.Params)
+	colonyArgs := maps.Clone(arguments)
+	delete(colonyArgs, "colony")
+	params["arguments"] = colonyArgs
+
+	// The history records the client that connected to this proxy.
+	target.client = p.client
+	return target.handleCallTool(ctx, &mcpRequest{JSONRPC: req.JSONRPC, ID: req.ID, Method: req.Method, Params: params})
+}
+
+// routeReadResource reads a resource from the colony named by the URI's
+// colony parameter. The CLI reference is the same for every colony.
+func (p *mcpProxy) routeReadResource(ctx context.Context, req *mcpRequest) *mcpResponse {
+	uri, _ := req.Params["uri"].(string)
+	if uri == cliReferenceURI {
+		return p.handleReadResource(ctx, req)
+	}
+
+	path, colonyID, found := strings.Cut(uri, colonyParam)
+	target, err := p.colonyProxy(colonyID)
+	if !found {
+		err = fmt.Errorf("resource %s needs a colony: append %s<one of %s>", uri, colonyParam, strings.Join(p.colonyIDs(), ", "))
+	}
+	if err != nil {
+		return &mcpResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   &mcpError{Code: -32002, Message: err.Error()},
+		}
+	}
+
+	resp := target.handleReadResource(ctx, &mcpRequest{
+		JSONRPC: req.JSONRPC,
+		ID:      req.ID,
+		Method:  req.Method,
+		Params:  map[string]interface{}{"uri": path},
+	})
+	// Contents carry the URI the client asked for.
+	if result, ok := resp.Result.(map[string]interface{}); ok {
+		if contents, ok := result["contents"].([]map[string]interface{}); ok {
+			for _, c := range contents {
+				c["uri"] = uri
+			}
+		}
+	}
+	return resp
+}
+
+// colonyProxy returns the proxy of a served colony.
+func (p *mcpProxy) colonyProxy(colonyID string) (*mcpProxy, error) {
+	if colonyID == "" {
+		return nil, fmt.Errorf("missing required 'colony' parameter (one of: %s)", strings.Join(p.colonyIDs(), ", "))
+	}
+	target, ok := p.colonies[colonyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown colony %q (one of: %s)", colonyID, strings.Join(p.colonyIDs(), ", "))
+	}
+	return target, nil
+}
+
+// colonyResources returns resources once per served colony, with the colony
+// in the URI and name. The CLI reference is listed once.
+func (p *mcpProxy) colonyResources(resources []mcpResource) []mcpResource {
+	if p.colonies == nil {
+		return resources
+	}
+	var result []mcpResource
+	for _, r := range resources {
+		if r.URI == cliReferenceURI {
+			result = append(result, r)
+			continue
+		}
+		for _, colonyID := range p.colonyIDs() {
+			c := r
+			c.URI += colonyParam + colonyID
+			c.Name += " (" + colonyID + ")"
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// colonyResourceTemplates returns templates with a colony parameter when the
+// proxy serves several colonies.
+func (p *mcpProxy) colonyResourceTemplates(templates []mcpResource) []mcpResource {
+	if p.colonies == nil {
+		return templates
+	}
+	result := make([]mcpResource, 0, len(templates))
+	for _, t := range templates {
+		t.URI += colonyParam + "{colony}"
+		t.Description += fmt.Sprintf(" (colony: one of %s)", strings.Join(p.colonyIDs(), ", "))
+		result = append(result, t)
+	}
+	return result
+}
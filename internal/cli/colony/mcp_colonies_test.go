@@ -0,0 +1,116 @@
+package colony
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+// newMultiColonyProxy returns a proxy serving dev and prod, whose colony
+// proxies deny actions (no API token) and record their history in calls.
+func newMultiColonyProxy(calls map[string][]*colonyv1.MCPToolCall) *mcpProxy {
+	proxy := newTestProxy()
+	proxy.colonies = make(map[string]*mcpProxy)
+	for _, id := range []string{"prod", "dev"} {
+		colonyProxy := newTestProxy()
+		colonyProxy.colonyID = id
+		colonyProxy.requireRBAC = true
+		colonyProxy.identity = func(context.Context) (*colonyv1.GetIdentityResponse, error) {
+			return &colonyv1.GetIdentityResponse{}, nil
+		}
+		colonyProxy.history = func(_ context.Context, call *colonyv1.MCPToolCall) error {
+			calls[id] = append(calls[id], call)
+			return nil
+		}
+		proxy.colonies[id] = colonyProxy
+	}
+	return proxy
+}
+
+func TestMCPProxyMultiColonyTools(t *testing.T) {
+	proxy := newMultiColonyProxy(map[string][]*colonyv1.MCPToolCall{})
+
+	resp := proxy.handleRequest(context.Background(), &mcpRequest{JSONRPC: "2.0", ID: 1, Method: "initialize"})
+	serverInfo := resp.Result.(map[string]interface{})["serverInfo"].(map[string]interface{})
+	assert.Equal(t, "coral", serverInfo["name"])
+
+	resp = proxy.handleRequest(context.Background(), &mcpRequest{JSONRPC: "2.0", ID: 2, Method: "tools/list"})
+	require.Nil(t, resp.Error)
+	tools := resp.Result.(map[string]interface{})["tools"].([]interface{})
+	require.Len(t, tools, 4)
+	for _, tool := range tools {
+		schema := tool.(map[string]interface{})["inputSchema"].(map[string]interface{})
+		colony := schema["properties"].(map[string]interface{})["colony"].(map[string]interface{})
+		assert.Equal(t, []string{"dev", "prod"}, colony["enum"])
+		assert.Contains(t, schema["required"], "colony")
+	}
+
+	// A single-colony proxy has no colony argument.
+	resp = newTestProxy().handleRequest(context.Background(), &mcpRequest{JSONRPC: "2.0", ID: 3, Method: "tools/list"})
+	schema := resp.Result.(map[string]interface{})["tools"].([]interface{})[0].(map[string]interface{})["inputSchema"].(map[string]interface{})
+	assert.NotContains(t, schema["properties"], "colony")
+}
+
+func TestMCPProxyMultiColonyCallTool(t *testing.T) {
+	calls := map[string][]*colonyv1.MCPToolCall{}
+	proxy := newMultiColonyProxy(calls)
+	proxy.client = "claude-ai 0.1.0"
+
+	call := func(arguments map[string]interface{}) *mcpResponse {
+		return proxy.handleRequest(context.Background(), &mcpRequest{
+			JSONRPC: "2.0", ID: 1, Method: "tools/call",
+			Params: map[string]interface{}{"name": "coral_cli", "arguments": arguments},
+		})
+	}
+
+	resp := call(map[string]interface{}{"args": []interface{}{"shell"}})
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Message, "missing required 'colony' parameter (one of: dev, prod)")
+
+	resp = call(map[string]interface{}{"args": []interface{}{"shell"}, "colony": "staging"})
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Message, `unknown colony "staging"`)
+	assert.Empty(t, calls)
+
+	resp = call(map[string]interface{}{"args": []interface{}{"shell"}, "colony": "prod"})
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Message, "CORAL_API_TOKEN", "runs with the prod proxy's checks")
+	require.Len(t, calls["prod"], 1)
+	assert.Empty(t, calls["dev"])
+	assert.JSONEq(t, `{"args":["shell"]}`, calls["prod"][0].ArgumentsJson, "colony is not passed to the tool")
+	assert.Equal(t, "claude-ai 0.1.0", calls["prod"][0].Client)
+}
+
+func TestMCPProxyMultiColonyResources(t *testing.T) {
+	proxy := newMultiColonyProxy(map[string][]*colonyv1.MCPToolCall{})
+	proxy.cliReference = "coral query ..."
+
+	resp := proxy.handleRequest(context.Background(), &mcpRequest{JSONRPC: "2.0", ID: 1, Method: "resources/list"})
+	var uris []string
+	for _, r := range resp.Result.(map[string]interface{})["resources"].([]map[string]interface{}) {
+		uris = append(uris, r["uri"].(string))
+	}
+	assert.Contains(t, uris, cliReferenceURI)
+	assert.Contains(t, uris, "coral://services?colony=dev")
+	assert.Contains(t, uris, "coral://services?colony=prod")
+	assert.Len(t, uris, 1+2*(len(mcpStaticResources)-1))
+
+	resp = proxy.handleRequest(context.Background(), &mcpRequest{JSONRPC: "2.0", ID: 2, Method: "resources/templates/list"})
+	templates := resp.Result.(map[string]interface{})["resourceTemplates"].([]map[string]interface{})
+	assert.Equal(t, "coral://service/{name}?colony={colony}", templates[0]["uriTemplate"])
+
+	resp = proxy.handleRequest(context.Background(), &mcpRequest{
+		JSONRPC: "2.0", ID: 3, Method: "resources/read", Params: map[string]interface{}{"uri": cliReferenceURI},
+	})
+	require.Nil(t, resp.Error)
+
+	resp = proxy.handleRequest(context.Background(), &mcpRequest{
+		JSONRPC: "2.0", ID: 4, Method: "resources/read", Params: map[string]interface{}{"uri": "coral://services"},
+	})
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Message, "append ?colony=<one of dev, prod>")
+}
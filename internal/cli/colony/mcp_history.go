@@ -0,0 +1,287 @@
+package colony
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+// newMCPHistoryCmd creates the 'coral colony mcp history' command.
+func newMCPHistoryCmd() *cobra.Command {
+	var (
+		since    string
+		tool     string
+		user     string
+		client   string
+		limit    int
+		format   string
+		colonyID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List recorded MCP tool calls",
+		Long: `List the MCP tool calls made against the colony, newest first.
+
+Every tool call, from the stdio proxy or the public endpoint, is recorded with
+its arguments, the coral command it ran, its duration, result size and
+outcome, and the client that made it. The history is kept for 30 days
+(retention.tables.mcp_tool_calls) and requires the admin permission when RBAC
+is enabled, since arguments may contain secrets.
+
+Use --format json to export calls, for example to build evaluation datasets,
+and 'coral colony mcp history replay' to run a call again.
+
+Examples:
+  coral colony mcp history
+  coral colony mcp history --since 1h --tool coral_cli
+  coral colony mcp history --client "claude-ai 0.1.0" --format json
+  coral colony mcp history replay 42`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "json" {
+				return fmt.Errorf("unsupported format %q (use table or json)", format)
+			}
+
+			req := &colonyv1.ListMCPToolCallsRequest{
+				Tool:   tool,
+				Actor:  user,
+				Client: client,
+				Limit:  int32(limit),
+			}
+			if since != "" {
+				d, err := helpers.ParseSince(since)
+				if err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
+				}
+				req.Since = timestamppb.New(time.Now().Add(-d))
+			}
+
+			ctx := cmd.Context()
+			colonyClient, _, err := helpers.GetColonyClientWithFallback(ctx, colonyID)
+			if err != nil {
+				return err
+			}
+
+			resp, err := colonyClient.ListMCPToolCalls(ctx, connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to list tool calls: %w", err)
+			}
+
+			if format == "json" {
+				return printToolCallsJSON(resp.Msg.Calls)
+			}
+			if len(resp.Msg.Calls) == 0 {
+				fmt.Println("No MCP tool calls recorded.")
+				return nil
+			}
+			printToolCallsTable(resp.Msg.Calls)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only show calls within this duration (e.g. 1h, 7d)")
+	cmd.Flags().StringVar(&tool, "tool", "", "Only show calls to this tool")
+	cmd.Flags().StringVar(&user, "user", "", "Only show calls by this user")
+	cmd.Flags().StringVar(&client, "client", "", "Only show calls from this MCP client")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of calls (default 50)")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table or json")
+	cmd.PersistentFlags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
+
+	cmd.AddCommand(newMCPReplayCmd(&colonyID))
+
+	return cmd
+}
+
+// newMCPReplayCmd creates the 'coral colony mcp history replay' command.
+func newMCPReplayCmd(colonyID *string) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "replay <call-id>",
+		Short: "Run a recorded MCP tool call again",
+		Long: `Run a recorded MCP tool call again, as the local user, and compare it with
+the original call.
+
+The tool's arguments are mapped to a coral command the way the MCP proxy maps
+them today, so a replay also shows the effect of changes to a tool. Tools the
+colony requires approval for wait for it again. The replay is recorded in the
+history with the client "replay". Its result is printed on
+stdout and the comparison on stderr; --format json prints both as one object.
+
+Examples:
+  coral colony mcp history replay 42
+  coral colony mcp history replay 42 --format json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("unsupported format %q (use text or json)", format)
+			}
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid call ID %q", args[0])
+			}
+
+			ctx := cmd.Context()
+			colonyClient, _, err := helpers.GetColonyClientWithFallback(ctx, *colonyID)
+			if err != nil {
+				return err
+			}
+
+			resp, err := colonyClient.GetMCPToolCall(ctx, connect.NewRequest(&colonyv1.GetMCPToolCallRequest{Id: id}))
+			if err != nil {
+				return fmt.Errorf("failed to get tool call: %w", err)
+			}
+			original := resp.Msg.Call
+
+			toolArgs, ok := mcpToolArgs[original.Tool]
+			if !ok {
+				return fmt.Errorf("tool %s no longer exists", original.Tool)
+			}
+			arguments := map[string]interface{}{}
+			if original.ArgumentsJson != "" {
+				if err := json.Unmarshal([]byte(original.ArgumentsJson), &arguments); err != nil {
+					return fmt.Errorf("failed to decode arguments of call %d: %w", id, err)
+				}
+			}
+			command, err := toolArgs(arguments)
+			if err != nil {
+				return fmt.Errorf("call %d: %s: %w", id, original.Tool, err)
+			}
+
+			proxy := &mcpProxy{
+				colonyID: *colonyID,
+				logger: logging.NewWithComponent(logging.Config{
+					Level:  "error",
+					Output: os.Stderr,
+				}, "mcp-replay"),
+				history: func(ctx context.Context, call *colonyv1.MCPToolCall) error {
+					_, err := colonyClient.RecordMCPToolCall(ctx, connect.NewRequest(&colonyv1.RecordMCPToolCallRequest{Call: call}))
+					return err
+				},
+				transport: "replay",
+				approvals: colonyClient,
+			}
+			// A replay waits for approval like the original call did, so
+			// replaying cannot bypass mcp.security.require_approval.
+			identity, err := colonyClient.GetIdentity(ctx, connect.NewRequest(&colonyv1.GetIdentityRequest{}))
+			if err != nil {
+				return fmt.Errorf("failed to get identity: %w", err)
+			}
+			proxy.approvalTools = identity.Msg.ApprovalRequiredTools
+			if *colonyID != "" {
+				proxy.env = []string{"CORAL_COLONY_ID=" + *colonyID}
+			}
+
+			fmt.Fprintf(os.Stderr, "Replaying call %d: coral %s\n", id, strings.Join(command, " "))
+			result, replayed, callErr := proxy.callTool(ctx, original.Tool, arguments, command)
+
+			if format == "json" {
+				return printReplayJSON(original, replayed, result)
+			}
+			if callErr == nil {
+				fmt.Println(result)
+			}
+			printReplaySummary(original, replayed)
+			return callErr
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	return cmd
+}
+
+// printToolCallsTable writes tool calls as an aligned table.
+func printToolCallsTable(calls []*colonyv1.MCPToolCall) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTIME\tCLIENT\tUSER\tTOOL\tRESULT\tDURATION\tBYTES\tCOMMAND")
+	for _, c := range calls {
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%d\tcoral %s\n",
+			c.Id,
+			c.Timestamp.AsTime().Local().Format(time.DateTime),
+			c.Client,
+			c.Actor,
+			c.Tool,
+			c.Result,
+			formatCallDuration(c.DurationMs),
+			c.ResultBytes,
+			strings.Join(c.Command, " "),
+		)
+	}
+	_ = w.Flush()
+}
+
+// printToolCallsJSON writes tool calls as a JSON array.
+func printToolCallsJSON(calls []*colonyv1.MCPToolCall) error {
+	out := make([]json.RawMessage, 0, len(calls))
+	for _, c := range calls {
+		data, err := protojson.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("failed to encode tool call: %w", err)
+		}
+		out = append(out, data)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// printReplaySummary compares a replayed call with the original on stderr.
+func printReplaySummary(original, replayed *colonyv1.MCPToolCall) {
+	w := tabwriter.NewWriter(os.Stderr, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "\tRESULT\tDURATION\tBYTES")
+	for _, row := range []struct {
+		name string
+		call *colonyv1.MCPToolCall
+	}{{"original", original}, {"replay", replayed}} {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", row.name, row.call.Result, formatCallDuration(row.call.DurationMs), row.call.ResultBytes)
+	}
+	_ = w.Flush()
+
+	if !slices.Equal(original.Command, replayed.Command) {
+		fmt.Fprintf(os.Stderr, "⚠️  The tool now runs a different command (originally: coral %s)\n", strings.Join(original.Command, " "))
+	}
+	if replayed.Error != "" {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", replayed.Error)
+	}
+}
+
+// printReplayJSON writes the original call, the replay and its output.
+func printReplayJSON(original, replayed *colonyv1.MCPToolCall, output string) error {
+	originalJSON, err := protojson.Marshal(original)
+	if err != nil {
+		return fmt.Errorf("failed to encode tool call: %w", err)
+	}
+	replayedJSON, err := protojson.Marshal(replayed)
+	if err != nil {
+		return fmt.Errorf("failed to encode tool call: %w", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{
+		"original": json.RawMessage(originalJSON),
+		"replay":   json.RawMessage(replayedJSON),
+		"output":   output,
+	})
+}
+
+// formatCallDuration formats a duration in milliseconds for display.
+func formatCallDuration(ms float64) string {
+	return time.Duration(ms * float64(time.Millisecond)).Round(time.Millisecond).String()
+}
@@ -0,0 +1,112 @@
+package colony
+
+import (
+	"context"
+	"encoding/json"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/cli/ask"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+	"github.com/coral-mesh/coral/internal/colony/server"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+// mcpHTTPServer serves remote MCP clients from the colony's public endpoint.
+// It handles requests like the stdio proxy: coral_cli runs as a coral
+// subprocess on the colony host, authenticated with the client's own API
+// token, and every command is checked against that token's role. Only the
+// read-only commands of httpapi.RemoteCLICommands may run.
+type mcpHTTPServer struct {
+	proxy mcpProxy
+}
+
+// newMCPHTTPServer creates the MCP server for the public endpoint.
+func newMCPHTTPServer(
+	colonyID string,
+	commands *cobra.Command,
+	mcpConfig config.MCPConfig,
+	colonySvc *server.Server,
+	logger logging.Logger,
+) *mcpHTTPServer {
+	return &mcpHTTPServer{
+		proxy: mcpProxy{
+			colonyID:           colonyID,
+			logger:             logger.With().Str("component", "mcp-http").Logger(),
+			requireRBAC:        true,
+			rbacForAllCommands: true,
+			commands:           newCLICommands(commands),
+			allowedCommands:    httpapi.RemoteCLICommands,
+			cliReference:       ask.GenerateCLIReference(commands),
+			maxResultBytes:     mcpConfig.MaxResultBytes,
+			approvalTools:      mcpConfig.Security.RequireApproval,
+			approvals:          colonySvc,
+			audit: func(ctx context.Context, req *colonyv1.RecordAuditEventRequest) error {
+				_, err := colonySvc.RecordAuditEvent(ctx, connect.NewRequest(req))
+				return err
+			},
+			history: func(ctx context.Context, call *colonyv1.MCPToolCall) error {
+				_, err := colonySvc.RecordMCPToolCall(ctx, connect.NewRequest(&colonyv1.RecordMCPToolCallRequest{Call: call}))
+				return err
+			},
+			transport: "http",
+		},
+	}
+}
+
+// HandleMCPRequest implements httpapi.MCPServerInterface.
+func (s *mcpHTTPServer) HandleMCPRequest(
+	ctx context.Context,
+	token *auth.APIToken,
+	credential string,
+	req *httpapi.MCPRequest,
+) *httpapi.MCPResponse {
+	request := &mcpRequest{JSONRPC: req.JSONRPC, ID: req.ID, Method: req.Method}
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &request.Params); err != nil {
+			return &httpapi.MCPResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   &httpapi.MCPError{Code: -32602, Message: "invalid params: " + err.Error()},
+			}
+		}
+	}
+
+	// Each request runs as the caller: its token's permissions, audit
+	// identity, and the credential its coral_cli subprocesses use.
+	proxy := s.proxy
+	proxy.user = token.User
+	proxy.identity = func(context.Context) (*colonyv1.GetIdentityResponse, error) {
+		return tokenIdentity(token), nil
+	}
+	proxy.env = []string{
+		"CORAL_COLONY_ID=" + proxy.colonyID,
+		"CORAL_API_TOKEN=" + credential,
+	}
+
+	resp := proxy.handleRequest(ctx, request)
+	out := &httpapi.MCPResponse{JSONRPC: resp.JSONRPC, ID: resp.ID, Result: resp.Result}
+	if resp.Error != nil {
+		out.Error = &httpapi.MCPError{Code: resp.Error.Code, Message: resp.Error.Message}
+	}
+	return out
+}
+
+// tokenIdentity describes token the way GetIdentity does.
+func tokenIdentity(token *auth.APIToken) *colonyv1.GetIdentityResponse {
+	identity := &colonyv1.GetIdentityResponse{
+		Authenticated:  true,
+		TokenId:        token.TokenID,
+		User:           token.User,
+		Role:           string(token.Role),
+		RbacForActions: true,
+	}
+	for _, p := range auth.EffectivePermissions(token) {
+		identity.Permissions = append(identity.Permissions, string(p))
+	}
+	return identity
+}
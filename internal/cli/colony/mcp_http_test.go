@@ -0,0 +1,109 @@
+package colony
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+)
+
+func TestMCPHTTPServerInitialize(t *testing.T) {
+	s := &mcpHTTPServer{proxy: *newTestProxy()}
+	token := &auth.APIToken{TokenID: "tok-1", User: "alice", Role: auth.RoleViewer}
+
+	for _, version := range []string{"2025-03-26", "2024-11-05"} {
+		resp := s.HandleMCPRequest(context.Background(), token, "secret", &httpapi.MCPRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  "initialize",
+			Params:  json.RawMessage(`{"protocolVersion":"` + version + `"}`),
+		})
+		require.Nil(t, resp.Error)
+		result, ok := resp.Result.(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, version, result["protocolVersion"])
+	}
+
+	resp := s.HandleMCPRequest(context.Background(), token, "secret", &httpapi.MCPRequest{
+		JSONRPC: "2.0",
+		ID:      2,
+		Method:  "initialize",
+		Params:  json.RawMessage(`[1, 2]`),
+	})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32602, resp.Error.Code)
+}
+
+// TestMCPHTTPServerAuthorization verifies remote callers may only run
+// read-only commands, are checked against their own token for every command,
+// queries included, and are audited as the token's user.
+func TestMCPHTTPServerAuthorization(t *testing.T) {
+	var recorded []*colonyv1.RecordAuditEventRequest
+	proxy := newTestProxy()
+	proxy.requireRBAC = true
+	proxy.rbacForAllCommands = true
+	proxy.allowedCommands = httpapi.RemoteCLICommands
+	proxy.audit = func(_ context.Context, req *colonyv1.RecordAuditEventRequest) error {
+		recorded = append(recorded, req)
+		return nil
+	}
+	s := &mcpHTTPServer{proxy: *proxy}
+
+	callTool := func(token *auth.APIToken, args string) *httpapi.MCPResponse {
+		return s.HandleMCPRequest(context.Background(), token, "secret", &httpapi.MCPRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  "tools/call",
+			Params:  json.RawMessage(`{"name":"coral_cli","arguments":{"args":` + args + `}}`),
+		})
+	}
+
+	noRole := &auth.APIToken{TokenID: "tok-1", User: "bob"}
+	resp := callTool(noRole, `["query", "traces", "api"]`)
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Message, `requires "query" permission`)
+
+	viewer := &auth.APIToken{TokenID: "tok-2", User: "carol", Role: auth.RoleViewer}
+	resp = callTool(viewer, `["colony", "agents", "--format", "json"]`)
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Message, `requires "status" permission`, "allowed remotely, checked against the role")
+
+	// Commands that are not read-only are denied, whatever the role.
+	admin := &auth.APIToken{TokenID: "tok-3", User: "dave", Role: auth.RoleAdmin}
+	for _, args := range []string{
+		`["exec", "api", "ls"]`,
+		`["colony", "agents", "exec", "--", "ls"]`,
+		`["--colony", "prod", "colony", "token", "create", "ci"]`,
+		`["colony", "mcp", "approvals", "approve", "a-1"]`,
+	} {
+		resp = callTool(admin, args)
+		require.NotNil(t, resp.Error, args)
+		assert.Contains(t, resp.Error.Message, "not available to remote MCP clients", args)
+	}
+
+	require.Len(t, recorded, 6)
+	assert.Equal(t, "bob", recorded[0].User)
+	assert.Equal(t, "carol", recorded[1].User)
+	assert.Equal(t, "permission_denied", recorded[1].Result)
+	assert.Equal(t, "dave", recorded[2].User)
+}
+
+func TestTokenIdentity(t *testing.T) {
+	identity := tokenIdentity(&auth.APIToken{
+		TokenID:     "tok-1",
+		User:        "alice",
+		Role:        auth.RoleViewer,
+		Permissions: []auth.Permission{auth.PermissionDebug},
+	})
+
+	assert.True(t, identity.Authenticated)
+	assert.Equal(t, "tok-1", identity.TokenId)
+	assert.Equal(t, "viewer", identity.Role)
+	assert.ElementsMatch(t, []string{"status", "query", "debug"}, identity.Permissions)
+}
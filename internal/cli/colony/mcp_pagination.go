@@ -0,0 +1,149 @@
+package colony
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/coral-mesh/coral/internal/colony/audit"
+)
+
+// mcpCursor locates the next page of a coral_cli result. Pages are cut
+// from a fresh run of the same command, so the cursor records which command
+// it belongs to.
+type mcpCursor struct {
+	Offset int    `json:"o"`
+	Args   string `json:"a"`
+}
+
+// cursorArgsHash identifies the command a cursor belongs to.
+func cursorArgsHash(args []string) string {
+	return audit.HashArgs(args)[:16]
+}
+
+// encodeCursor returns the opaque next_cursor for offset into the result of
+// coral <args>.
+func encodeCursor(args []string, offset int) string {
+	data, _ := json.Marshal(mcpCursor{Offset: offset, Args: cursorArgsHash(args)})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor returns the offset encoded in cursor, which must have been
+// issued for the same args.
+func decodeCursor(cursor string, args []string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	var c mcpCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Offset < 0 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	if c.Args != cursorArgsHash(args) {
+		return 0, fmt.Errorf("cursor was issued for different args; pass the same args as the call that returned it")
+	}
+	return c.Offset, nil
+}
+
+// mcpPage is one page of a coral_cli result.
+type mcpPage struct {
+	Text string
+	// Next is the offset of the following page, or 0 on the last page.
+	Next int
+	// Summary describes what the page holds when the result was split.
+	Summary string
+}
+
+// paginateResult returns the page of output starting at offset, within
+// maxBytes. JSON arrays are split between items, as is the largest array
+// of a JSON object, with its other fields repeated on every page; anything
+// else is split between lines.
+func paginateResult(output string, offset, maxBytes int) mcpPage {
+	if offset == 0 && len(output) <= maxBytes {
+		return mcpPage{Text: output}
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(output), &items); err == nil {
+		return paginateItems(items, offset, maxBytes, func(page []json.RawMessage) []byte {
+			data, _ := json.Marshal(page)
+			return data
+		}, "items")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(output), &fields); err == nil {
+		largest, largestSize := "", 0
+		for name, value := range fields {
+			var v []json.RawMessage
+			if json.Unmarshal(value, &v) == nil && len(value) > largestSize {
+				largest, largestSize = name, len(value)
+				items = v
+			}
+		}
+		if largestSize > 0 {
+			return paginateItems(items, offset, maxBytes, func(page []json.RawMessage) []byte {
+				fields[largest], _ = json.Marshal(page)
+				data, _ := json.Marshal(fields)
+				return data
+			}, fmt.Sprintf("%q items", largest))
+		}
+	}
+
+	return paginateText(output, offset, maxBytes)
+}
+
+// paginateItems returns as many items from offset as fit in maxBytes once
+// encoded, and at least one.
+func paginateItems(
+	items []json.RawMessage,
+	offset, maxBytes int,
+	encode func([]json.RawMessage) []byte,
+	what string,
+) mcpPage {
+	offset = min(offset, len(items))
+
+	// Every item after the first costs its own size plus a separating comma.
+	size := len(encode([]json.RawMessage{})) - 1
+	end := offset
+	for end < len(items) && (end == offset || size+len(items[end])+1 <= maxBytes) {
+		size += len(items[end]) + 1
+		end++
+	}
+
+	page := mcpPage{Text: string(encode(items[offset:end]))}
+	if end < len(items) {
+		page.Next = end
+	}
+	page.Summary = fmt.Sprintf("Showing %s %d-%d of %d.", what, offset+1, end, len(items))
+	if offset >= len(items) {
+		page.Summary = fmt.Sprintf("No %s left; the result has %d.", what, len(items))
+	}
+	return page
+}
+
+// paginateText returns the bytes of output from offset, cut at the last
+// line break within maxBytes when there is one.
+func paginateText(output string, offset, maxBytes int) mcpPage {
+	offset = min(offset, len(output))
+	end := min(offset+maxBytes, len(output))
+	if end < len(output) {
+		if i := strings.LastIndexByte(output[offset:end], '\n'); i > 0 {
+			end = offset + i + 1
+		}
+		for end > offset+1 && !utf8.RuneStart(output[end]) {
+			end--
+		}
+	}
+
+	page := mcpPage{
+		Text:    strings.TrimRight(output[offset:end], "\n"),
+		Summary: fmt.Sprintf("Showing bytes %d-%d of %d.", offset+1, end, len(output)),
+	}
+	if end < len(output) {
+		page.Next = end
+	}
+	return page
+}
@@ -0,0 +1,115 @@
+package colony
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPCursor(t *testing.T) {
+	args := []string{"query", "traces", "api"}
+
+	cursor := encodeCursor(args, 42)
+	offset, err := decodeCursor(cursor, args)
+	require.NoError(t, err)
+	assert.Equal(t, 42, offset)
+
+	_, err = decodeCursor(cursor, []string{"query", "traces", "web"})
+	assert.ErrorContains(t, err, "different args")
+
+	_, err = decodeCursor("not a cursor", args)
+	assert.ErrorContains(t, err, "invalid cursor")
+}
+
+func TestPaginateResult(t *testing.T) {
+	var items []string
+	for i := 0; i < 10; i++ {
+		items = append(items, fmt.Sprintf(`{"id":%d}`, i))
+	}
+	array := "[" + strings.Join(items, ",") + "]"
+	object := `{"service":"api","traces":` + array + `}`
+	lines := strings.Repeat("0123456789\n", 10)
+
+	tests := []struct {
+		name        string
+		output      string
+		offset      int
+		maxBytes    int
+		wantText    string
+		wantNext    int
+		wantSummary string
+	}{
+		{
+			name:     "fits",
+			output:   array,
+			maxBytes: 1000,
+			wantText: array,
+		},
+		{
+			name:        "array first page",
+			output:      array,
+			maxBytes:    30,
+			wantText:    `[{"id":0},{"id":1},{"id":2}]`,
+			wantNext:    3,
+			wantSummary: "Showing items 1-3 of 10.",
+		},
+		{
+			name:        "array last page",
+			output:      array,
+			offset:      8,
+			maxBytes:    30,
+			wantText:    `[{"id":8},{"id":9}]`,
+			wantSummary: "Showing items 9-10 of 10.",
+		},
+		{
+			name:        "oversized item",
+			output:      array,
+			maxBytes:    1,
+			wantText:    `[{"id":0}]`,
+			wantNext:    1,
+			wantSummary: "Showing items 1-1 of 10.",
+		},
+		{
+			name:        "offset past end",
+			output:      array,
+			offset:      20,
+			maxBytes:    30,
+			wantText:    `[]`,
+			wantSummary: "No items left; the result has 10.",
+		},
+		{
+			name:        "object keeps other fields",
+			output:      object,
+			offset:      2,
+			maxBytes:    50,
+			wantText:    `{"service":"api","traces":[{"id":2},{"id":3}]}`,
+			wantNext:    4,
+			wantSummary: `Showing "traces" items 3-4 of 10.`,
+		},
+		{
+			name:        "text splits between lines",
+			output:      lines,
+			maxBytes:    25,
+			wantText:    "0123456789\n0123456789",
+			wantNext:    22,
+			wantSummary: "Showing bytes 1-22 of 110.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := paginateResult(tt.output, tt.offset, tt.maxBytes)
+			if json.Valid([]byte(tt.wantText)) {
+				assert.JSONEq(t, tt.wantText, page.Text)
+			} else {
+				assert.Equal(t, tt.wantText, page.Text)
+			}
+			assert.Equal(t, tt.wantNext, page.Next)
+			assert.Equal(t, tt.wantSummary, page.Summary)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// mcpPromptArgument is an argument of an MCP prompt.
+type mcpPromptArgument struct {
+	Name        string
+	Description string
+	Required    bool
+	Default     string
+}
+
+// mcpPrompt is a built-in investigation playbook. Its text walks the client
+// through coral_cli calls and resources; it does not run anything itself.
+type mcpPrompt struct {
+	Name        string
+	Description string
+	Arguments   []mcpPromptArgument
+	// Template is formatted by replacing {argument} placeholders.
+	Template string
+}
+
+var serviceArgument = mcpPromptArgument{Name: "service", Description: "Service to investigate", Required: true}
+
+// mcpPrompts are the playbooks returned by prompts/list.
+var mcpPrompts = []mcpPrompt{
+	{
+		Name:        "investigate-latency",
+		Description: "Investigate high latency on a service",
+		Arguments: []mcpPromptArgument{
+			serviceArgument,
+			{Name: "since", Description: "How far back to look (default 30m)", Default: "30m"},
+		},
+		Template: `Investigate high latency on the {service} service over the last {since}.
+
+1. Read coral://service/{service} for its current health, error rate and latency.
+2. Compare latency percentiles: coral_cli ["query", "metrics", "{service}", "--since", "{since}"].
+3. Find slow requests: coral_cli ["query", "traces", "{service}", "--since", "{since}", "--min-duration-ms", "500"].
+   Note which spans dominate the slow traces.
+4. If time is spent in a downstream dependency, map it with coral_cli ["query", "topology", "--since", "{since}"]
+   and repeat steps 1-3 for that service.
+5. If time is spent in {service} itself, find the hot code paths with
+   coral_cli ["profile", "cpu", "--service", "{service}", "--duration", "30"].
+
+Conclude with the most likely cause, the evidence for it, and a next step.`,
+	},
+	{
+		Name:        "find-memory-leak",
+		Description: "Find a memory leak in a service",
+		Arguments: []mcpPromptArgument{
+			serviceArgument,
+			{Name: "since", Description: "How far back to look (default 6h)", Default: "6h"},
+		},
+		Template: `Look for a memory leak in the {service} service over the last {since}.
+
+1. Read coral://service/{service} for its memory utilization.
+2. Get allocation sites, the allocation trend and suspected leaks:
+   coral_cli ["query", "memory-profile", "--service", "{service}", "--since", "{since}"].
+   leak_candidates lists stacks whose allocation rate grew across the range.
+3. Check whether the growth follows traffic: coral_cli ["query", "metrics", "{service}", "--since", "{since}"].
+   Allocation growth that tracks request volume is load, not a leak.
+4. Confirm a suspect with an on-demand profile and heap statistics:
+   coral_cli ["profile", "memory", "--service", "{service}", "--duration", "30"].
+
+Conclude with the suspected leaking call site (root to leaf), the evidence
+for it, and what would confirm or rule it out.`,
+	},
+	{
+		Name:        "investigate-errors",
+		Description: "Investigate an elevated error rate on a service",
+		Arguments: []mcpPromptArgument{
+			serviceArgument,
+			{Name: "since", Description: "How far back to look (default 30m)", Default: "30m"},
+		},
+		Template: `Investigate errors on the {service} service over the last {since}.
+
+1. Read coral://service/{service} for its error rate and recent issues.
+2. Break errors down by status: coral_cli ["query", "metrics", "{service}", "--since", "{since}", "--status-code-range", "5xx"].
+3. Read the error logs: coral_cli ["query", "logs", "{service}", "--since", "{since}", "--level", "error"].
+4. Look at failing requests end to end: coral_cli ["query", "traces", "{service}", "--since", "{since}"].
+   If errors originate in a dependency, repeat steps 1-3 for it.
+
+Conclude with the most likely cause, when it started, and the evidence for it.`,
+	},
+}
+
+// findMCPPrompt returns the prompt with the given name.
+func findMCPPrompt(name string) (*mcpPrompt, bool) {
+	for i := range mcpPrompts {
+		if mcpPrompts[i].Name == name {
+			return &mcpPrompts[i], true
+		}
+	}
+	return nil, false
+}
+
+// render fills in the prompt's template from args, applying defaults.
+func (p *mcpPrompt) render(args map[string]string) (string, error) {
+	pairs := make([]string, 0, 2*len(p.Arguments))
+	for _, arg := range p.Arguments {
+		value := args[arg.Name]
+		if value == "" {
+			value = arg.Default
+		}
+		if value == "" && arg.Required {
+			return "", fmt.Errorf("prompt %s: missing required argument %q", p.Name, arg.Name)
+		}
+		pairs = append(pairs, "{"+arg.Name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(p.Template), nil
+}
+
+// handleListPrompts returns the built-in investigation playbooks.
+func (p *mcpProxy) handleListPrompts(_ context.Context, req *mcpRequest) *mcpResponse {
+	prompts := make([]map[string]interface{}, 0, len(mcpPrompts))
+	for _, prompt := range mcpPrompts {
+		args := make([]map[string]interface{}, 0, len(prompt.Arguments))
+		for _, arg := range prompt.Arguments {
+			args = append(args, map[string]interface{}{
+				"name":        arg.Name,
+				"description": arg.Description,
+				"required":    arg.Required,
+			})
+		}
+		prompts = append(prompts, map[string]interface{}{
+			"name":        prompt.Name,
+			"description": prompt.Description,
+			"arguments":   args,
+		})
+	}
+	return &mcpResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  map[string]interface{}{"prompts": prompts},
+	}
+}
+
+// handleGetPrompt renders a playbook with the client's arguments.
+func (p *mcpProxy) handleGetPrompt(_ context.Context, req *mcpRequest) *mcpResponse {
+	name, _ := req.Params["name"].(string)
+	prompt, ok := findMCPPrompt(name)
+	if !ok {
+		return &mcpResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   &mcpError{Code: -32602, Message: fmt.Sprintf("unknown prompt: %q", name)},
+		}
+	}
+
+	args := make(map[string]string)
+	if raw, ok := req.Params["arguments"].(map[string]interface{}); ok {
+		for k, v := range raw {
+			if s, ok := v.(string); ok {
+				args[k] = s
+			}
+		}
+	}
+
+	text, err := prompt.render(args)
+	if err != nil {
+		return &mcpResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   &mcpError{Code: -32602, Message: err.Error()},
+		}
+	}
+
+	return &mcpResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: map[string]interface{}{
+			"description": prompt.Description,
+			"messages": []map[string]interface{}{{
+				"role":    "user",
+				"content": map[string]interface{}{"type": "text", "text": text},
+			}},
+		},
+	}
+}
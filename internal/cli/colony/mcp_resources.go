@@ -0,0 +1,169 @@
+package colony
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coral-mesh/coral/internal/colony/audit"
+)
+
+// cliReferenceURI is the resource agents read before calling coral_cli.
+const cliReferenceURI = "coral://cli/reference"
+
+// mcpResource is a colony entity exposed as an MCP resource. Reading it runs
+// the coral command that produces it, so resources share coral_cli's RBAC,
+// audit log and --format json output (RFD 100).
+type mcpResource struct {
+	URI         string
+	Name        string
+	Description string
+	MimeType    string
+}
+
+// mcpStaticResources are the resources returned by resources/list.
+var mcpStaticResources = []mcpResource{
+	{cliReferenceURI, "CLI reference", "Commands available to coral_cli", "text/plain"},
+	{"coral://services", "Services", "Services known to the colony and the agents running them", "application/json"},
+	{"coral://agents", "Agents", "Agents connected to the colony and their health", "application/json"},
+	{"coral://debug/sessions", "Debug sessions", "Debug sessions across all services", "application/json"},
+}
+
+// mcpResourceTemplates are the parameterized resources returned by
+// resources/templates/list.
+var mcpResourceTemplates = []mcpResource{
+	{"coral://service/{name}", "Service summary", "Health, errors, latency and profiling summary of a service", "application/json"},
+	{"coral://service/{name}/profile/latest", "Latest profile", "Most recent scheduled profile run of a service", "application/json"},
+	{"coral://service/{name}/memory/latest", "Recent memory profile", "Top allocation sites, growth and suspected leaks over the last hour", "application/json"},
+	{"coral://debug/sessions/{id}", "Debug session", "Metadata of a debug session", "application/json"},
+}
+
+// resourceCommand returns the coral arguments that produce the resource at
+// uri, without --format json.
+func resourceCommand(uri string) ([]string, error) {
+	path, ok := strings.CutPrefix(uri, "coral://")
+	if !ok {
+		return nil, fmt.Errorf("unsupported resource URI: %s", uri)
+	}
+	parts := strings.Split(path, "/")
+	for _, part := range parts {
+		if part == "" || strings.HasPrefix(part, "-") {
+			return nil, fmt.Errorf("invalid resource URI: %s", uri)
+		}
+	}
+
+	switch {
+	case path == "services":
+		return []string{"colony", "service", "list"}, nil
+	case path == "agents":
+		return []string{"colony", "agents"}, nil
+	case path == "debug/sessions":
+		return []string{"debug", "session", "list"}, nil
+	case len(parts) == 3 && parts[0] == "debug" && parts[1] == "sessions":
+		return []string{"debug", "session", "get", parts[2]}, nil
+	case len(parts) == 2 && parts[0] == "service":
+		return []string{"query", "summary", parts[1]}, nil
+	case len(parts) == 4 && parts[0] == "service" && parts[3] == "latest":
+		switch parts[2] {
+		case "profile":
+			return []string{"profile", "schedule", "runs", "--service", parts[1], "--limit", "1"}, nil
+		case "memory":
+			return []string{"query", "memory-profile", "--service", parts[1], "--since", "1h"}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown resource: %s", uri)
+}
+
+// handleListResources returns the static resources.
+func (p *mcpProxy) handleListResources(_ context.Context, req *mcpRequest) *mcpResponse {
+	resources := make([]map[string]interface{}, 0, len(mcpStaticResources))
+	for _, r := range p.colonyResources(mcpStaticResources) {
+		resources = append(resources, map[string]interface{}{
+			"uri":         r.URI,
+			"name":        r.Name,
+			"description": r.Description,
+			"mimeType":    r.MimeType,
+		})
+	}
+	return &mcpResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  map[string]interface{}{"resources": resources},
+	}
+}
+
+// handleListResourceTemplates returns the per-service and per-session
+// resource templates.
+func (p *mcpProxy) handleListResourceTemplates(_ context.Context, req *mcpRequest) *mcpResponse {
+	templates := make([]map[string]interface{}, 0, len(mcpResourceTemplates))
+	for _, r := range p.colonyResourceTemplates(mcpResourceTemplates) {
+		templates = append(templates, map[string]interface{}{
+			"uriTemplate": r.URI,
+			"name":        r.Name,
+			"description": r.Description,
+			"mimeType":    r.MimeType,
+		})
+	}
+	return &mcpResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  map[string]interface{}{"resourceTemplates": templates},
+	}
+}
+
+// handleReadResource reads a resource. The CLI reference is generated in
+// process; other resources run their coral command like coral_cli does.
+func (p *mcpProxy) handleReadResource(ctx context.Context, req *mcpRequest) *mcpResponse {
+	uri, ok := req.Params["uri"].(string)
+	if !ok || uri == "" {
+		return &mcpResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   &mcpError{Code: -32602, Message: "missing or invalid 'uri' parameter"},
+		}
+	}
+
+	mimeType := "application/json"
+	var text string
+	if uri == cliReferenceURI {
+		mimeType = "text/plain"
+		text = p.cliReference
+	} else {
+		args, err := resourceCommand(uri)
+		if err != nil {
+			return &mcpResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   &mcpError{Code: -32002, Message: err.Error()},
+			}
+		}
+
+		if err := p.authorizeCLITool(ctx, args); err != nil {
+			p.recordToolCall(ctx, args, audit.ResultDenied, err)
+			return &mcpResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   &mcpError{Code: -32600, Message: err.Error()},
+			}
+		}
+
+		text, err = p.executeCLITool(ctx, args)
+		if err != nil {
+			p.recordToolCall(ctx, args, audit.ResultFailed, err)
+			return &mcpResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Error:   &mcpError{Code: -32603, Message: err.Error()},
+			}
+		}
+		p.recordToolCall(ctx, args, audit.ResultOK, nil)
+	}
+
+	return &mcpResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result: map[string]interface{}{
+			"contents": []map[string]interface{}{{"uri": uri, "mimeType": mimeType, "text": text}},
+		},
+	}
+}
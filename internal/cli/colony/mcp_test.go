@@ -3,11 +3,16 @@ package colony
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
 	"github.com/coral-mesh/coral/internal/logging"
 )
 
@@ -15,6 +20,99 @@ func newTestProxy() *mcpProxy {
 	return &mcpProxy{
 		colonyID: "test-colony",
 		logger:   logging.New(logging.Config{Level: "error"}),
+		commands: newCLICommands(newTestCommandTree("coral")),
+	}
+}
+
+// testCommandPaths are the commands of the tree returned by
+// newTestCommandTree, as in the coral binary.
+var testCommandPaths = []string{
+	"status",
+	"shell",
+	"exec",
+	"query traces",
+	"query summary",
+	"query topology",
+	"debug attach",
+	"debug session list",
+	"profile cpu",
+	"colony agents",
+	"colony agents exec",
+	"colony service list",
+	"colony token list",
+	"colony token create",
+	"colony mcp approvals approve",
+}
+
+// newTestCommandTree returns a command tree with testCommandPaths. Named
+// coral-colony, it is flat like the coral-colony binary: colony commands
+// are registered on the root.
+func newTestCommandTree(name string) *cobra.Command {
+	root := &cobra.Command{Use: name}
+	colonyCmd := root
+	if name == "coral" {
+		colonyCmd = &cobra.Command{Use: "colony"}
+		root.AddCommand(colonyCmd)
+	}
+
+	for _, path := range testCommandPaths {
+		parent := root
+		words := strings.Fields(path)
+		if words[0] == "colony" {
+			parent, words = colonyCmd, words[1:]
+		}
+		for _, word := range words {
+			var next *cobra.Command
+			for _, cmd := range parent.Commands() {
+				if cmd.Name() == word {
+					next = cmd
+				}
+			}
+			if next == nil {
+				next = &cobra.Command{Use: word, Run: func(*cobra.Command, []string) {}}
+				if parent == colonyCmd && parent == root {
+					next.Annotations = map[string]string{coralPathAnnotation: "colony " + word}
+				}
+				parent.AddCommand(next)
+			}
+			parent = next
+		}
+	}
+	return root
+}
+
+func TestCLICommandsPath(t *testing.T) {
+	tests := []struct {
+		root    string
+		args    []string
+		want    string
+		wantErr bool
+	}{
+		{"coral", []string{"query", "traces", "--since", "1h"}, "query traces", false},
+		{"coral", []string{"--colony", "prod", "shell", "--", "ls"}, "shell", false},
+		{"coral", []string{"colony", "--verbose=true", "agents", "exec", "--", "ls"}, "colony agents exec", false},
+		{"coral", []string{"colony", "agents"}, "colony agents", false},
+		{"coral-colony", []string{"token", "create", "ci", "--role", "admin"}, "colony token create", false},
+		{"coral-colony", []string{"mcp", "approvals", "approve", "a-1"}, "colony mcp approvals approve", false},
+		{"coral-colony", []string{"query", "summary"}, "query summary", false},
+
+		// Arguments not naming a command are denied.
+		{"coral", []string{"unknown"}, "", true},
+		{"coral", []string{"--format", "json"}, "", true},
+		{"coral", nil, "", true},
+		{"coral-colony", []string{"colony", "token", "create"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.root+" "+strings.Join(tt.args, " "), func(t *testing.T) {
+			got, err := newCLICommands(newTestCommandTree(tt.root)).path(tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
 	}
 }
 
@@ -49,9 +147,12 @@ func TestMCPProxyInitialize(t *testing.T) {
 	capabilities, ok := result["capabilities"].(map[string]interface{})
 	require.True(t, ok)
 	assert.NotNil(t, capabilities["tools"])
+	assert.NotNil(t, capabilities["resources"])
+	assert.NotNil(t, capabilities["prompts"])
 }
 
-// TestMCPProxyListTools tests that tools/list returns only coral_cli (RFD 100).
+// TestMCPProxyListTools tests that tools/list returns coral_cli (RFD 100) and
+// the workflow tools.
 func TestMCPProxyListTools(t *testing.T) {
 	proxy := newTestProxy()
 
@@ -74,13 +175,57 @@ func TestMCPProxyListTools(t *testing.T) {
 	// tools is []interface{} because handleListTools builds it that way.
 	toolsList, ok := result["tools"].([]interface{})
 	require.True(t, ok, "tools should be a list")
-	require.Len(t, toolsList, 1, "Should expose exactly one tool: coral_cli")
+	require.Len(t, toolsList, 4)
+
+	for i, name := range []string{"coral_cli", "coral_compare_deployments", "coral_get_service_topology", "coral_query_anomalies"} {
+		tool, ok := toolsList[i].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, name, tool["name"])
+		assert.NotEmpty(t, tool["description"])
+		assert.NotNil(t, tool["inputSchema"])
+	}
+}
 
-	tool, ok := toolsList[0].(map[string]interface{})
-	require.True(t, ok)
-	assert.Equal(t, "coral_cli", tool["name"])
-	assert.NotEmpty(t, tool["description"])
-	assert.NotNil(t, tool["inputSchema"])
+func TestCompareDeploymentsArgs(t *testing.T) {
+	args, err := compareDeploymentsArgs(map[string]interface{}{"service": "api"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"query", "compare", "api"}, args)
+
+	args, err = compareDeploymentsArgs(map[string]interface{}{"service": "api", "deploy_time": "2h", "window": "1h"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"query", "compare", "api", "--deploy-time", "2h", "--window", "1h"}, args)
+
+	_, err = compareDeploymentsArgs(map[string]interface{}{})
+	assert.Error(t, err)
+
+	_, err = compareDeploymentsArgs(map[string]interface{}{"service": "api", "window": 30})
+	assert.Error(t, err)
+}
+
+func TestServiceTopologyArgs(t *testing.T) {
+	args, err := serviceTopologyArgs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"query", "topology"}, args)
+
+	args, err = serviceTopologyArgs(map[string]interface{}{"service": "orders", "since": "15m", "include_l4": false})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"query", "topology", "--service", "orders", "--since", "15m", "--include-l4=false"}, args)
+
+	_, err = serviceTopologyArgs(map[string]interface{}{"include_l4": "no"})
+	assert.Error(t, err)
+}
+
+func TestAnomaliesArgs(t *testing.T) {
+	args, err := anomaliesArgs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"query", "anomalies"}, args)
+
+	args, err = anomaliesArgs(map[string]interface{}{"service": "checkout", "since": "24h", "include_normal": true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"query", "anomalies", "checkout", "--since", "24h", "--all=true"}, args)
+
+	_, err = anomaliesArgs(map[string]interface{}{"since": 24})
+	assert.Error(t, err)
 }
 
 // TestMCPProxyCallToolUnknown verifies that non-coral_cli tool names are rejected (RFD 100).
@@ -127,6 +272,150 @@ func TestMCPProxyCallToolMissingArgs(t *testing.T) {
 	assert.Contains(t, resp.Error.Message, "args")
 }
 
+// TestMCPProxyAuthorizeCLITool verifies role checks for coral_cli actions
+// when RBAC is required.
+func TestMCPProxyAuthorizeCLITool(t *testing.T) {
+	viewer := &colonyv1.GetIdentityResponse{
+		Authenticated: true, User: "bob", Role: "viewer",
+		Permissions: []string{"status", "query"},
+	}
+	debugger := &colonyv1.GetIdentityResponse{
+		Authenticated: true, User: "alice", Role: "debugger",
+		Permissions: []string{"status", "query", "analyze", "debug"},
+	}
+
+	tests := []struct {
+		name        string
+		requireRBAC bool
+		identity    *colonyv1.GetIdentityResponse
+		args        []string
+		wantErr     string
+	}{
+		{"rbac disabled", false, nil, []string{"shell", "--", "ls"}, ""},
+		{"query without token", true, &colonyv1.GetIdentityResponse{}, []string{"query", "traces"}, ""},
+		{"shell without token", true, &colonyv1.GetIdentityResponse{}, []string{"shell", "--", "ls"}, "CORAL_API_TOKEN"},
+		{"exec as viewer", true, viewer, []string{"exec", "api", "ls"}, `role "viewer"`},
+		{"attach as viewer", true, viewer, []string{"debug", "attach", "api"}, "permission denied"},
+		{"exec as debugger", true, debugger, []string{"exec", "api", "ls"}, ""},
+		{"profile as debugger", true, debugger, []string{"profile", "cpu"}, ""},
+		{"token admin as debugger", true, debugger, []string{"colony", "token", "list"}, "admin"},
+		{"unknown command", false, nil, []string{"--colony", "prod"}, "permission denied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy := newTestProxy()
+			proxy.requireRBAC = tt.requireRBAC
+			proxy.identity = func(context.Context) (*colonyv1.GetIdentityResponse, error) {
+				return tt.identity, nil
+			}
+
+			err := proxy.authorizeCLITool(context.Background(), tt.args)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestMCPProxyRecordToolCall verifies coral_cli calls are reported to the
+// audit log with hashed arguments.
+func TestMCPProxyRecordToolCall(t *testing.T) {
+	var recorded []*colonyv1.RecordAuditEventRequest
+	proxy := newTestProxy()
+	proxy.requireRBAC = true
+	proxy.identity = func(context.Context) (*colonyv1.GetIdentityResponse, error) {
+		return &colonyv1.GetIdentityResponse{}, nil
+	}
+	proxy.audit = func(_ context.Context, req *colonyv1.RecordAuditEventRequest) error {
+		recorded = append(recorded, req)
+		return nil
+	}
+
+	resp := proxy.handleCallTool(context.Background(), &mcpRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "tools/call",
+		Params: map[string]interface{}{
+			"name":      "coral_cli",
+			"arguments": map[string]interface{}{"args": []interface{}{"exec", "api", "cat", "/etc/secret"}},
+		},
+	})
+	require.NotNil(t, resp.Error)
+
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "MCPToolCall", recorded[0].Action)
+	assert.Equal(t, "coral_cli exec api", recorded[0].Target)
+	assert.Equal(t, "permission_denied", recorded[0].Result)
+	assert.Contains(t, recorded[0].Error, "CORAL_API_TOKEN")
+	assert.Len(t, recorded[0].ArgumentsHash, 64)
+}
+
+// TestMCPProxyRecordHistory verifies tool calls are recorded in the tool call
+// history with their arguments and the client from initialize.
+func TestMCPProxyRecordHistory(t *testing.T) {
+	var recorded []*colonyv1.MCPToolCall
+	proxy := newTestProxy()
+	proxy.requireRBAC = true
+	proxy.transport = "stdio"
+	proxy.user = "alice"
+	proxy.identity = func(context.Context) (*colonyv1.GetIdentityResponse, error) {
+		return &colonyv1.GetIdentityResponse{}, nil
+	}
+	proxy.history = func(_ context.Context, call *colonyv1.MCPToolCall) error {
+		recorded = append(recorded, call)
+		return nil
+	}
+
+	callTool := func() {
+		resp := proxy.handleRequest(context.Background(), &mcpRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  "tools/call",
+			Params: map[string]interface{}{
+				"name":      "coral_cli",
+				"arguments": map[string]interface{}{"args": []interface{}{"shell", "--", "ls"}},
+			},
+		})
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, -32600, resp.Error.Code)
+	}
+
+	callTool()
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "stdio", recorded[0].Client, "transport until the client identifies itself")
+
+	proxy.handleRequest(context.Background(), &mcpRequest{
+		JSONRPC: "2.0",
+		ID:      2,
+		Method:  "initialize",
+		Params: map[string]interface{}{
+			"clientInfo": map[string]interface{}{"name": "claude-ai", "version": "0.1.0"},
+		},
+	})
+	callTool()
+	require.Len(t, recorded, 2)
+
+	call := recorded[1]
+	assert.Equal(t, "claude-ai 0.1.0", call.Client)
+	assert.Equal(t, "alice", call.Actor)
+	assert.Equal(t, "coral_cli", call.Tool)
+	assert.JSONEq(t, `{"args":["shell","--","ls"]}`, call.ArgumentsJson)
+	assert.Equal(t, []string{"shell", "--", "ls"}, call.Command)
+	assert.Equal(t, "permission_denied", call.Result)
+	assert.Contains(t, call.Error, "CORAL_API_TOKEN")
+	assert.Zero(t, call.ResultBytes)
+}
+
+func TestCLITarget(t *testing.T) {
+	assert.Equal(t, "coral_cli shell", cliTarget([]string{"shell", "--", "ls"}))
+	assert.Equal(t, "coral_cli debug attach", cliTarget([]string{"debug", "attach", "api", "--function", "main"}))
+	assert.Equal(t, "coral_cli query", cliTarget([]string{"query", "--since", "1h"}))
+}
+
 // TestMCPProxyInvalidMethod tests handling of invalid methods.
 func TestMCPProxyInvalidMethod(t *testing.T) {
 	proxy := newTestProxy()
@@ -226,3 +515,272 @@ func TestAppendProxyFormatJSON(t *testing.T) {
 		assert.Equal(t, tt.expected, result)
 	}
 }
+
+// TestMCPProxyListResources verifies that colony entities are listed as
+// resources and resource templates.
+func TestMCPProxyListResources(t *testing.T) {
+	proxy := newTestProxy()
+
+	resp := proxy.handleRequest(context.Background(), &mcpRequest{JSONRPC: "2.0", ID: 1, Method: "resources/list"})
+	require.Nil(t, resp.Error)
+	result, ok := resp.Result.(map[string]interface{})
+	require.True(t, ok)
+	resources, ok := result["resources"].([]map[string]interface{})
+	require.True(t, ok)
+
+	var uris []string
+	for _, r := range resources {
+		uris = append(uris, r["uri"].(string))
+	}
+	assert.Contains(t, uris, "coral://cli/reference")
+	assert.Contains(t, uris, "coral://services")
+
+	resp = proxy.handleRequest(context.Background(), &mcpRequest{JSONRPC: "2.0", ID: 2, Method: "resources/templates/list"})
+	require.Nil(t, resp.Error)
+	result, ok = resp.Result.(map[string]interface{})
+	require.True(t, ok)
+	templates, ok := result["resourceTemplates"].([]map[string]interface{})
+	require.True(t, ok)
+
+	// Every template resolves to a command once its parameter is filled in.
+	for _, tmpl := range templates {
+		uri := strings.NewReplacer("{name}", "api", "{id}", "sess-1").Replace(tmpl["uriTemplate"].(string))
+		_, err := resourceCommand(uri)
+		assert.NoError(t, err, uri)
+	}
+}
+
+func TestResourceCommand(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    []string
+		wantErr bool
+	}{
+		{uri: "coral://agents", want: []string{"colony", "agents"}},
+		{uri: "coral://service/api", want: []string{"query", "summary", "api"}},
+		{uri: "coral://service/api/profile/latest", want: []string{"profile", "schedule", "runs", "--service", "api", "--limit", "1"}},
+		{uri: "coral://service/api/memory/latest", want: []string{"query", "memory-profile", "--service", "api", "--since", "1h"}},
+		{uri: "coral://debug/sessions/sess-1", want: []string{"debug", "session", "get", "sess-1"}},
+		{uri: "coral://service/api/logs/latest", wantErr: true},
+		{uri: "coral://service/--help", wantErr: true},
+		{uri: "coral://service//profile/latest", wantErr: true},
+		{uri: "https://example.com/services", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			got, err := resourceCommand(tt.uri)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestMCPProxyReadResource verifies reads of the CLI reference and the
+// RBAC check on other resources.
+func TestMCPProxyReadResource(t *testing.T) {
+	proxy := newTestProxy()
+	proxy.cliReference = "query summary [service]\n"
+
+	resp := proxy.handleRequest(context.Background(), &mcpRequest{
+		JSONRPC: "2.0", ID: 1, Method: "resources/read",
+		Params: map[string]interface{}{"uri": "coral://cli/reference"},
+	})
+	require.Nil(t, resp.Error)
+	result, ok := resp.Result.(map[string]interface{})
+	require.True(t, ok)
+	contents, ok := result["contents"].([]map[string]interface{})
+	require.True(t, ok)
+	require.Len(t, contents, 1)
+	assert.Equal(t, "text/plain", contents[0]["mimeType"])
+	assert.Equal(t, "query summary [service]\n", contents[0]["text"])
+
+	resp = proxy.handleRequest(context.Background(), &mcpRequest{
+		JSONRPC: "2.0", ID: 2, Method: "resources/read",
+		Params: map[string]interface{}{"uri": "coral://nope"},
+	})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32002, resp.Error.Code)
+
+	resp = proxy.handleRequest(context.Background(), &mcpRequest{
+		JSONRPC: "2.0", ID: 3, Method: "resources/read",
+		Params: map[string]interface{}{},
+	})
+	require.NotNil(t, resp.Error)
+	assert.Equal(t, -32602, resp.Error.Code)
+}
+
+// TestMCPProxyPrompts verifies the investigation playbooks are listed and
+// rendered with their arguments.
+func TestMCPProxyPrompts(t *testing.T) {
+	proxy := newTestProxy()
+
+	resp := proxy.handleRequest(context.Background(), &mcpRequest{JSONRPC: "2.0", ID: 1, Method: "prompts/list"})
+	require.Nil(t, resp.Error)
+	result, ok := resp.Result.(map[string]interface{})
+	require.True(t, ok)
+	prompts, ok := result["prompts"].([]map[string]interface{})
+	require.True(t, ok)
+	require.Len(t, prompts, len(mcpPrompts))
+	assert.Equal(t, "investigate-latency", prompts[0]["name"])
+
+	tests := []struct {
+		name     string
+		params   map[string]interface{}
+		contains []string
+		wantErr  string
+	}{
+		{
+			name: "defaults applied",
+			params: map[string]interface{}{
+				"name": "find-memory-leak", "arguments": map[string]interface{}{"service": "api"},
+			},
+			contains: []string{"coral://service/api", `"--service", "api", "--since", "6h"`},
+		},
+		{
+			name: "explicit argument",
+			params: map[string]interface{}{
+				"name": "investigate-latency", "arguments": map[string]interface{}{"service": "checkout", "since": "2h"},
+			},
+			contains: []string{`["query", "traces", "checkout", "--since", "2h"`},
+		},
+		{
+			name:    "missing service",
+			params:  map[string]interface{}{"name": "investigate-errors"},
+			wantErr: `"service"`,
+		},
+		{
+			name:    "unknown prompt",
+			params:  map[string]interface{}{"name": "fix-everything"},
+			wantErr: "unknown prompt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := proxy.handleRequest(context.Background(), &mcpRequest{
+				JSONRPC: "2.0", ID: 2, Method: "prompts/get", Params: tt.params,
+			})
+			if tt.wantErr != "" {
+				require.NotNil(t, resp.Error)
+				assert.Contains(t, resp.Error.Message, tt.wantErr)
+				return
+			}
+			require.Nil(t, resp.Error)
+
+			result, ok := resp.Result.(map[string]interface{})
+			require.True(t, ok)
+			messages, ok := result["messages"].([]map[string]interface{})
+			require.True(t, ok)
+			require.Len(t, messages, 1)
+			text := messages[0]["content"].(map[string]interface{})["text"].(string)
+			assert.NotContains(t, text, "{")
+			for _, want := range tt.contains {
+				assert.Contains(t, text, want)
+			}
+		})
+	}
+}
+
+type fakeApprovals struct {
+	created *colonyv1.CreateMCPApprovalRequest
+	status  string
+	polls   int
+}
+
+func (f *fakeApprovals) CreateMCPApproval(_ context.Context, req *connect.Request[colonyv1.CreateMCPApprovalRequest]) (*connect.Response[colonyv1.CreateMCPApprovalResponse], error) {
+	f.created = req.Msg
+	return connect.NewResponse(&colonyv1.CreateMCPApprovalResponse{
+		Approval: &colonyv1.MCPApproval{Id: "a-1", Status: "pending"},
+	}), nil
+}
+
+// GetMCPApproval reports the call as pending on the first poll and decided
+// on the next.
+func (f *fakeApprovals) GetMCPApproval(context.Context, *connect.Request[colonyv1.GetMCPApprovalRequest]) (*connect.Response[colonyv1.GetMCPApprovalResponse], error) {
+	f.polls++
+	status := f.status
+	if f.polls == 1 {
+		status = "pending"
+	}
+	return connect.NewResponse(&colonyv1.GetMCPApprovalResponse{
+		Approval: &colonyv1.MCPApproval{Id: "a-1", Status: status, DecidedBy: "bob", Reason: "not in prod"},
+	}), nil
+}
+
+func TestAwaitApproval(t *testing.T) {
+	tests := []struct {
+		name     string
+		toolName string
+		args     []string
+		status   string
+		wantTool string
+		wantErr  string
+	}{
+		{
+			name:     "tool not listed",
+			toolName: "coral_cli",
+			args:     []string{"query", "summary"},
+		},
+		{
+			name:     "approved",
+			toolName: "coral_cli",
+			args:     []string{"shell", "--agent", "api", "--", "ls"},
+			status:   "approved",
+			wantTool: "coral_shell_exec",
+		},
+		{
+			name:     "denied",
+			toolName: "coral_cli",
+			args:     []string{"exec", "api", "--", "sh"},
+			status:   "denied",
+			wantTool: "coral_container_exec",
+			wantErr:  "denied by bob: not in prod",
+		},
+		{
+			name:     "expired",
+			toolName: "coral_cli",
+			args:     []string{"shell", "--agent", "api"},
+			status:   "expired",
+			wantTool: "coral_shell_exec",
+			wantErr:  "not approved in time",
+		},
+		{
+			name:     "self approval",
+			toolName: "coral_cli",
+			args:     []string{"colony", "mcp", "approvals", "--colony", "prod", "approve", "a-1"},
+			wantErr:  "decided by a human",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			approvals := &fakeApprovals{status: tt.status}
+			proxy := newTestProxy()
+			proxy.approvalTools = []string{"coral_shell_exec", "coral_container_exec"}
+			proxy.approvals = approvals
+			proxy.approvalPollInterval = time.Millisecond
+
+			err := proxy.awaitApproval(context.Background(), tt.toolName, tt.args)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.wantTool == "" {
+				assert.Nil(t, approvals.created)
+				return
+			}
+			require.NotNil(t, approvals.created)
+			assert.Equal(t, tt.wantTool, approvals.created.Tool)
+			assert.Equal(t, strings.Join(tt.args, " "), approvals.created.Command)
+			assert.Equal(t, 2, approvals.polls, "polls until the call is decided")
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package colony
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/debug"
+	"github.com/coral-mesh/coral/internal/colony/poller"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/colony/storage"
+	"github.com/coral-mesh/coral/internal/logging"
+	"github.com/coral-mesh/coral/internal/metrics"
+	"github.com/coral-mesh/coral/internal/wireguard"
+)
+
+// colonyMetricsSources holds the components exported on /metrics.
+type colonyMetricsSources struct {
+	registry    *registry.Registry
+	debug       *debug.Orchestrator
+	pollStats   *poller.StatsRegistry
+	db          *database.Database
+	storagePath string
+	wgDevice    *wireguard.Device
+	logger      logging.Logger
+}
+
+// newMetricsHandler creates the /metrics endpoint handler exporting colony
+// operational metrics in the Prometheus text format.
+func newMetricsHandler(src colonyMetricsSources) http.Handler {
+	return metrics.Handler(func(_ context.Context, w *metrics.Writer) {
+		// Agents by status.
+		counts := map[registry.AgentStatus]int{
+			registry.StatusHealthy:   0,
+			registry.StatusDegraded:  0,
+			registry.StatusUnhealthy: 0,
+		}
+		now := time.Now()
+		for _, agent := range src.registry.ListAll() {
+			counts[registry.DetermineStatus(agent.LastSeen, now)]++
+		}
+		for _, status := range []registry.AgentStatus{registry.StatusHealthy, registry.StatusDegraded, registry.StatusUnhealthy} {
+			w.Gauge("coral_colony_agents", "Registered agents by status.", float64(counts[status]),
+				metrics.L("status", string(status)))
+		}
+
+		// Debug sessions and profiling runs.
+		if sessions, err := src.debug.ActiveSessionCount(); err != nil {
+			src.logger.Warn().Err(err).Msg("Failed to count active debug sessions for metrics")
+		} else {
+			w.Gauge("coral_colony_debug_sessions_active", "Active debug sessions.", float64(sessions))
+		}
+		w.Gauge("coral_colony_profiling_runs_active", "Function profiling runs with probes attached.",
+			float64(src.debug.ActiveProfilingRuns()))
+
+		// Per-agent poll statistics.
+		snapshot := src.pollStats.Snapshot()
+		writePollStats(w, snapshot, "coral_colony_agent_poll_latency_seconds", "Latency of the latest poll of an agent.",
+			func(s poller.AgentPollStats) float64 { return s.LastLatency.Seconds() }, false)
+		writePollStats(w, snapshot, "coral_colony_agent_poll_latency_avg_seconds", "Moving average of the poll latency of an agent.",
+			func(s poller.AgentPollStats) float64 { return s.AvgLatency.Seconds() }, false)
+		writePollStats(w, snapshot, "coral_colony_agent_poll_latency_max_seconds", "Maximum poll latency of an agent.",
+			func(s poller.AgentPollStats) float64 { return s.MaxLatency.Seconds() }, false)
+		writePollStats(w, snapshot, "coral_colony_agent_polls_total", "Polls of an agent.",
+			func(s poller.AgentPollStats) float64 { return float64(s.Polls) }, true)
+		writePollStats(w, snapshot, "coral_colony_agent_poll_failures_total", "Failed polls of an agent.",
+			func(s poller.AgentPollStats) float64 { return float64(s.Failures) }, true)
+		writePollStats(w, snapshot, "coral_colony_agent_polls_skipped_total", "Poll cycles an agent was skipped in because it was slow, failing or idle.",
+			func(s poller.AgentPollStats) float64 { return float64(s.Skipped) }, true)
+
+		// Storage.
+		if size, err := storage.CalculateSize(src.storagePath); err == nil {
+			w.Gauge("coral_colony_storage_bytes", "Size of the colony storage directory.", float64(size))
+		}
+		if info, err := os.Stat(src.db.Path()); err == nil {
+			w.Gauge("coral_colony_database_size_bytes", "Size of the colony DuckDB database file.", float64(info.Size()))
+		}
+
+		// WireGuard.
+		if src.wgDevice != nil {
+			w.Gauge("coral_colony_wireguard_peers", "Configured WireGuard peers.", float64(len(src.wgDevice.ListPeers())))
+		}
+	})
+}
+
+// writePollStats writes one metric per poller and agent.
+func writePollStats(
+	w *metrics.Writer,
+	snapshot map[string][]poller.AgentPollStats,
+	name, help string,
+	value func(poller.AgentPollStats) float64,
+	counter bool,
+) {
+	for _, pollerName := range slices.Sorted(maps.Keys(snapshot)) {
+		for _, s := range snapshot[pollerName] {
+			labels := []metrics.Label{metrics.L("poller", pollerName), metrics.L("agent_id", s.AgentID)}
+			if counter {
+				w.Counter(name, help, value(s), labels...)
+			} else {
+				w.Gauge(name, help, value(s), labels...)
+			}
+		}
+	}
+}
@@ -3,25 +3,42 @@ package colony
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
 	"path/filepath"
 	"time"
 
+	"connectrpc.com/connect"
 	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
 	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
 	"github.com/coral-mesh/coral/coral/mesh/v1/meshv1connect"
+	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/bandwidth"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
 	"github.com/coral-mesh/coral/internal/colony"
+	"github.com/coral-mesh/coral/internal/colony/alerting"
+	"github.com/coral-mesh/coral/internal/colony/anomaly"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/ca"
+	"github.com/coral-mesh/coral/internal/colony/coldstorage"
+	"github.com/coral-mesh/coral/internal/colony/dashboard"
 	"github.com/coral-mesh/coral/internal/colony/database"
 	"github.com/coral-mesh/coral/internal/colony/debug"
+	"github.com/coral-mesh/coral/internal/colony/events"
+	"github.com/coral-mesh/coral/internal/colony/ha"
 	"github.com/coral-mesh/coral/internal/colony/httpapi"
 	"github.com/coral-mesh/coral/internal/colony/jwks"
+	"github.com/coral-mesh/coral/internal/colony/logbuffer"
 	"github.com/coral-mesh/coral/internal/colony/mesh"
+	"github.com/coral-mesh/coral/internal/colony/poller"
 	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/colony/retention"
 	"github.com/coral-mesh/coral/internal/colony/server"
 	colonywg "github.com/coral-mesh/coral/internal/colony/wireguard"
 	"github.com/coral-mesh/coral/internal/config"
@@ -37,21 +54,21 @@ type registryAgentLookup struct {
 	reg *registry.Registry
 }
 
-// GetAgent returns the WireGuard mesh IPv4 address for the given agent ID.
+// GetAgent returns the WireGuard mesh IP address for the given agent ID.
 func (r *registryAgentLookup) GetAgent(agentID string) (string, error) {
 	entry, err := r.reg.Get(agentID)
 	if err != nil {
 		return "", err
 	}
-	if entry.MeshIPv4 == "" {
+	if entry.MeshIP() == "" {
 		return "", fmt.Errorf("agent %s has no mesh IP", agentID)
 	}
-	return entry.MeshIPv4, nil
+	return entry.MeshIP(), nil
 }
 
 // startServers starts the HTTP/Connect servers for agent registration and colony management.
 // Returns the HTTP server, the token store (nil if public endpoint is disabled), and any error.
-func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentRegistry *registry.Registry, db *database.Database, endpoints []string, logger logging.Logger) (*http.Server, *auth.TokenStore, error) {
+func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentRegistry *registry.Registry, db *database.Database, endpoints []string, pollStats *poller.StatsRegistry, limiter *bandwidth.Limiter, colonySecrets *mesh.ColonySecrets, commands *cobra.Command, logger logging.Logger) (*http.Server, *auth.TokenStore, error) {
 	ctx := context.Background()
 	// Get connect port from config or use default
 	loader, err := config.NewLoader()
@@ -74,9 +91,10 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 		connectPort = constants.DefaultColonyPort // Default Buf Connect port
 	}
 
-	dashboardPort := colonyConfig.Services.DashboardPort
-	if dashboardPort == 0 {
-		dashboardPort = constants.DefaultDashboardPort
+	// Reported in the colony status only when the dashboard is served.
+	var dashboardPort int
+	if cfg.Dashboard.Enabled {
+		dashboardPort = cfg.Dashboard.Port
 	}
 
 	// Create discovery client for agent endpoint lookup
@@ -88,6 +106,12 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 			Msg("Discovery client configured for agent endpoint lookup")
 	}
 
+	// Create the event broker streaming colony events to subscribers, and
+	// report agents that stop sending heartbeats.
+	eventBroker := events.NewBroker(logger.With().Str("component", "colony-events").Logger())
+	agentRegistry.SetEventBroker(eventBroker)
+	go agentRegistry.MonitorStatus(ctx, constants.DefaultAgentStatusCheckInterval)
+
 	// Create mesh service handler
 	meshSvc := mesh.NewHandler(cfg, wgDevice, agentRegistry, discoveryClient, logger)
 
@@ -101,6 +125,35 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 		return nil, nil, fmt.Errorf("failed to initialize CA manager: %w", err)
 	}
 
+	// Agents registering over mTLS are checked against the CA's revocations.
+	meshSvc.SetAgentAuth(caManager, colonyConfig.AgentAuth.RequireCertificates)
+	if colonyConfig.AgentAuth.RequireCertificates {
+		logger.Info().Msg("Agents must register with a client certificate")
+	}
+	meshSvc.SetMinProtocolVersion(colonyConfig.AgentAuth.MinProtocolVersion)
+
+	// Agents presenting the colony secret must present the current one, or
+	// the previous one during the grace period of a rotation. Rotated
+	// secrets are saved to the colony config.
+	meshSvc.SetColonySecrets(colonySecrets)
+	colonySecrets.SetPersister(func(secret string, previous *config.PreviousColonySecret) error {
+		saved, err := loader.LoadColonyConfig(cfg.ColonyID)
+		if err != nil {
+			return err
+		}
+		saved.ColonySecret = secret
+		saved.AgentAuth.PreviousColonySecret = previous
+		if os.Getenv("CORAL_COLONY_SECRET") != "" {
+			logger.Warn().Msg("CORAL_COLONY_SECRET overrides the rotated colony secret saved to the colony config; update it before the colony restarts")
+		}
+		return loader.SaveColonyConfig(saved)
+	})
+
+	// Agent releases rolled out with `coral colony agents upgrade` are
+	// advertised in heartbeats, signed with the policy signing key.
+	agentUpdates := mesh.NewAgentUpdates(caManager.IssueAgentReleaseToken)
+	meshSvc.SetAgentUpdates(agentUpdates)
+
 	// Compute public endpoint URL if enabled (RFD 031).
 	var publicEndpointURL string
 	if colonyConfig.PublicEndpoint.Enabled {
@@ -134,8 +187,18 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 		MeshIPv4:           cfg.WireGuard.MeshIPv4,
 		MeshIPv6:           cfg.WireGuard.MeshIPv6,
 		PublicEndpointURL:  publicEndpointURL,
+		RBACForActions:     colonyConfig.MCP.Security.RequireRBACForActions,
+		MCPApprovalTools:   colonyConfig.MCP.Security.RequireApproval,
+		MCPApprovalTimeout: colonyConfig.MCP.Security.ApprovalTimeout,
 	}
 	colonySvc := server.New(agentRegistry, db, caManager, colonyServerConfig, logger.With().Str("component", "colony-server").Logger())
+	colonySvc.SetEventBroker(eventBroker)
+	// Keep the recent log lines reported by agents for coral tail.
+	colonySvc.SetLogBuffer(logbuffer.New(constants.DefaultLogBufferLines, logger))
+	// Agents revoked with `coral colony agent revoke` leave the mesh.
+	colonySvc.SetAgentEvictor(meshSvc.EvictAgent)
+	colonySvc.SetColonySecretRotator(colonySecrets)
+	colonySvc.SetAgentUpgrader(agentUpdates)
 	colonySvc.SetMeshInfoProvider(func() map[string]interface{} {
 		return colonywg.GatherMeshInfo(wgDevice, cfg.WireGuard.MeshIPv4, cfg.WireGuard.MeshNetworkIPv4, cfg.ColonyID, logger)
 	})
@@ -166,6 +229,28 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 
 	// Initialize Debug Orchestrator (RFD 059 - Live Debugging, RFD 069 - Function Discovery).
 	debugOrchestrator := debug.NewOrchestrator(logger, agentRegistry, db, functionReg)
+	debugOrchestrator.SetEventBroker(eventBroker)
+
+	// Run recurring profiling jobs defined with `coral profile schedule`.
+	profileScheduler := debug.NewProfileScheduler(ctx, debugOrchestrator, db, constants.DefaultProfileRunRetention, logger)
+	if err := profileScheduler.Start(); err != nil {
+		logger.Warn().Err(err).Msg("Failed to start profile scheduler")
+	}
+
+	// Serve the web dashboard.
+	if cfg.Dashboard.Enabled {
+		dashboardHost := cfg.Dashboard.Host
+		if dashboardHost == "" {
+			dashboardHost = constants.DefaultDashboardHost
+		}
+		dashboardServer := dashboard.New(dashboard.Config{
+			Host: dashboardHost,
+			Port: cfg.Dashboard.Port,
+		}, colonySvc, debugOrchestrator, logger)
+		if err := dashboardServer.Start(); err != nil {
+			logger.Warn().Err(err).Msg("Failed to start dashboard, continuing without it")
+		}
+	}
 
 	// MCP tool dispatch is handled locally by the proxy layer (RFD 100).
 	// The colony no longer hosts per-operation MCP tools.
@@ -175,6 +260,112 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 	colonySvc.SetEbpfService(ebpfService)
 	logger.Info().Msg("eBPF query service initialized and attached to colony")
 
+	// Evaluate alert rules defined with `coral alert rule` and notify the
+	// sinks configured in alerting.sinks.
+	alertSinks, err := alerting.NewSinks(colonyConfig.Alerting.Sinks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to configure alert sinks: %w", err)
+	}
+	sinkNames := make([]string, 0, len(colonyConfig.Alerting.Sinks))
+	for _, sink := range colonyConfig.Alerting.Sinks {
+		sinkNames = append(sinkNames, sink.Name)
+	}
+	colonySvc.SetAlertSinks(sinkNames)
+
+	alertEvaluator := alerting.NewEvaluator(ctx, db, alertSinks, colonyConfig.Alerting.EvaluationInterval, logger)
+	if err := alertEvaluator.Start(); err != nil {
+		logger.Warn().Err(err).Msg("Failed to start alert evaluator")
+	}
+
+	// Learn per-route baselines and score recent traffic against them
+	// (anomaly_detection).
+	if !colonyConfig.AnomalyDetection.Disabled {
+		anomalyDetector := anomaly.NewDetector(ctx, db, colonyConfig.AnomalyDetection, logger)
+		if err := anomalyDetector.Start(); err != nil {
+			logger.Warn().Err(err).Msg("Failed to start anomaly detector")
+		}
+	}
+
+	// Roll up debug session events into per-function, per-minute statistics
+	// (debug_aggregation).
+	if !colonyConfig.DebugAggregation.Disabled {
+		debugRollup := colony.NewDebugRollupService(ctx, db, colonyConfig.DebugAggregation, logger)
+		if err := debugRollup.Start(); err != nil {
+			logger.Warn().Err(err).Msg("Failed to start debug rollup service")
+		}
+	}
+
+	// Delete expired debug, audit and history rows (retention.tables).
+	retentionManager, err := retention.NewManager(ctx, db, colonyConfig.Retention, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to configure retention: %w", err)
+	}
+	if err := retentionManager.Start(); err != nil {
+		logger.Warn().Err(err).Msg("Failed to start retention manager")
+	}
+
+	// Offload old raw Beyla metrics to object storage (cold_storage).
+	if colonyConfig.ColdStorage.Enabled {
+		coldStorage, err := coldstorage.NewManager(ctx, db, colonyConfig.ColdStorage, logger)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to configure cold storage: %w", err)
+		}
+		if err := coldStorage.Start(); err != nil {
+			logger.Warn().Err(err).Msg("Failed to start cold storage offload")
+		}
+	}
+
+	// Fan out federated queries to the child colonies in federation.children.
+	if len(colonyConfig.Federation.Children) > 0 {
+		children := make([]server.ChildColony, 0, len(colonyConfig.Federation.Children))
+		for _, child := range colonyConfig.Federation.Children {
+			client, err := helpers.NewChildColonyClient(child)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to configure child colony %s: %w", child.ColonyID, err)
+			}
+			children = append(children, server.ChildColony{ColonyID: child.ColonyID, Client: client})
+		}
+		colonySvc.SetChildColonies(children)
+		logger.Info().Int("children", len(children)).Msg("Colony federation enabled")
+	}
+
+	// Report the service level objectives in slos with `coral query slo`.
+	slos := make([]server.SLO, 0, len(colonyConfig.SLOs))
+	for _, slo := range colonyConfig.SLOs {
+		slos = append(slos, server.SLO{
+			Service:          slo.Service,
+			Availability:     slo.Availability,
+			Latency:          slo.Latency,
+			LatencyThreshold: slo.LatencyThreshold,
+			Period:           slo.Period,
+		})
+	}
+	colonySvc.SetSLOs(slos)
+
+	// Service groups of the colony config, targeted with --group.
+	groups := make([]server.ServiceGroup, 0, len(colonyConfig.ServiceGroups))
+	for _, group := range colonyConfig.ServiceGroups {
+		groups = append(groups, server.ServiceGroup{
+			Name:        group.Name,
+			Services:    group.Services,
+			Description: group.Description,
+		})
+	}
+	colonySvc.SetServiceGroups(groups)
+
+	// Accept OTLP traces and metrics exported directly by applications.
+	if colonyConfig.OTLP.Enabled {
+		otlpReceiver := colony.NewOTLPReceiver(colonyConfig.OTLP, db, logger)
+		if err := otlpReceiver.Start(ctx); err != nil {
+			logger.Warn().Err(err).Msg("Failed to start OTLP receiver, continuing without it")
+		} else {
+			go func() {
+				<-ctx.Done()
+				_ = otlpReceiver.Stop()
+			}()
+		}
+	}
+
 	// Start function registry poller if registry was created (RFD 063).
 	if functionReg != nil {
 		// Configure poll interval from config.
@@ -200,16 +391,52 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 		logger.Info().Msg("Function discovery is disabled in configuration")
 	}
 
+	// Record control-plane actions in the audit log if enabled.
+	var handlerOpts []connect.HandlerOption
+	if colonyConfig.MCP.Security.AuditEnabled {
+		auditRecorder := audit.NewRecorder(db, logger)
+		colonySvc.SetAuditRecorder(auditRecorder)
+		handlerOpts = append(handlerOpts, connect.WithInterceptors(auditRecorder.Interceptor()))
+		logger.Info().Msg("Audit log enabled for control-plane actions")
+	}
+
+	// Accept zstd compressed requests, e.g. uprobe event pushes.
+	handlerOpts = append(handlerOpts, bandwidth.HandlerOptions()...)
+
+	// Restrict capability tokens scoped to a service to requests for it.
+	handlerOpts = append(handlerOpts, connect.WithInterceptors(httpapi.CapabilityScopeInterceptor()))
+
 	// Register the handlers
 	meshPath, meshHandler := meshv1connect.NewMeshServiceHandler(meshSvc)
-	colonyPath, colonyHandler := colonyv1connect.NewColonyServiceHandler(colonySvc)
-	debugPath, debugHandler := colonyv1connect.NewColonyDebugServiceHandler(debugOrchestrator)
+	colonyPath, colonyHandler := colonyv1connect.NewColonyServiceHandler(colonySvc, handlerOpts...)
+	debugPath, debugHandler := colonyv1connect.NewColonyDebugServiceHandler(debugOrchestrator, handlerOpts...)
+	// Agents push uprobe events to the debug service.
+	debugHandler = limiter.Handler(debugHandler)
+
+	// Initialize the token store shared by the public endpoint (RFD 031) and
+	// mesh RBAC.
+	tokensFile := colonyConfig.PublicEndpoint.Auth.TokensFile
+	if tokensFile == "" {
+		tokensFile = filepath.Join(loader.ColonyDir(cfg.ColonyID), "tokens.yaml")
+	}
+	tokenStore := auth.NewTokenStore(tokensFile)
+	// Capability tokens minted by the colony authenticate like API tokens.
+	tokenStore.SetCapabilityVerifier(caManager.CapabilityVerifier())
 
 	// Create HTTP server
 	mux := http.NewServeMux()
 	mux.Handle(meshPath, meshHandler)
-	mux.Handle(colonyPath, colonyHandler)
-	mux.Handle(debugPath, debugHandler)
+
+	// Administrative procedures (upgrades, revocations, secret rotation)
+	// always require a token with the admin permission, even from mesh
+	// peers. Actions (probes, profiling, analysis) do too if configured.
+	rbacForActions := colonyConfig.MCP.Security.RequireRBACForActions
+	requireRBAC := httpapi.RequireActionRBAC(tokenStore, rbacForActions, logger.With().Str("component", "action-rbac").Logger())
+	mux.Handle(colonyPath, requireRBAC(colonyHandler))
+	mux.Handle(debugPath, requireRBAC(debugHandler))
+	if rbacForActions {
+		logger.Info().Msg("RBAC enforced for actions on the mesh listener")
+	}
 
 	// Add DuckDB HTTP handler for remote query (RFD 046).
 	// Handler is created separately from registration so it can be passed to the public endpoint.
@@ -226,6 +453,21 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 			Msg("Colony database registered for remote query")
 	}
 
+	// Serve database snapshots to standby colonies (ha.mode: standby).
+	mux.Handle(ha.SnapshotPath, ha.NewSnapshotHandler(db, logger))
+
+	// Publish revoked agent certificates to the servers that accept them.
+	crlHandler := ca.NewCRLHandler(caManager, logger)
+	mux.Handle(ca.CRLPath, crlHandler)
+
+	// Carry WireGuard packets for agents whose UDP traffic is blocked.
+	if relay := wgDevice.Relay(); relay != nil {
+		mux.Handle(wireguard.RelayPath, wireguard.NewRelayHandler(relay, func(publicKey string) bool {
+			_, ok := wgDevice.GetPeer(publicKey)
+			return ok
+		}, logger))
+	}
+
 	// Build agent DuckDB proxy handler (RFD 095).
 	// Registered on the internal HTTP server so DuckDB's httpfs can attach without
 	// TLS certificate verification issues on localhost HTTPS setups.
@@ -276,6 +518,8 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 			"network": map[string]interface{}{
 				"connect_port": resp.ConnectPort,
 			},
+			// Per-agent poll latency and backoff state of each poller.
+			"pollers": pollStats.Snapshot(),
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -287,6 +531,17 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 	// Add simple HTTP /status endpoint (similar to agent).
 	mux.Handle("/status", statusHandler)
 
+	// Export operational metrics for Prometheus scraping.
+	mux.Handle("/metrics", newMetricsHandler(colonyMetricsSources{
+		registry:    agentRegistry,
+		debug:       debugOrchestrator,
+		pollStats:   pollStats,
+		db:          db,
+		storagePath: cfg.StoragePath,
+		wgDevice:    wgDevice,
+		logger:      logger,
+	}))
+
 	addr := fmt.Sprintf(":%d", connectPort)
 	httpServer := &http.Server{
 		Addr:              addr,
@@ -309,15 +564,8 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 	}()
 
 	// Start public endpoint server if enabled (RFD 031).
-	var tokenStore *auth.TokenStore
 	if colonyConfig.PublicEndpoint.Enabled {
-		// Initialize token store with tokens from colony config directory.
 		colonyDir := loader.ColonyDir(cfg.ColonyID)
-		tokensFile := colonyConfig.PublicEndpoint.Auth.TokensFile
-		if tokensFile == "" {
-			tokensFile = filepath.Join(colonyDir, "tokens.yaml")
-		}
-		tokenStore = auth.NewTokenStore(tokensFile)
 
 		// Issue server certificate from internal CA if no cert provided.
 		var tlsCert *tls.Certificate
@@ -356,13 +604,37 @@ func startServers(cfg *config.ResolvedConfig, wgDevice *wireguard.Device, agentR
 			publicDuckDBHandler = duckdbHandler
 		}
 
+		// Remote MCP clients get the same coral_cli tool, resources and
+		// prompts as the stdio proxy, on behalf of their API token.
+		var mcpServer httpapi.MCPServerInterface
+		if colonyConfig.PublicEndpoint.MCP.Enabled && !colonyConfig.MCP.Disabled {
+			mcpServer = newMCPHTTPServer(cfg.ColonyID, commands, colonyConfig.MCP, colonySvc, logger)
+		}
+
+		// Agents holding a certificate from the colony CA register here
+		// over mTLS.
+		var (
+			agentClientCAs         *x509.CertPool
+			verifyAgentCertificate func(*x509.Certificate) error
+		)
+		if caManager != nil {
+			agentClientCAs = caManager.AgentClientCAs()
+			verifyAgentCertificate = caManager.VerifyAgentCertificate
+		}
+
 		publicConfig := httpapi.Config{
 			PublicConfig:            colonyConfig.PublicEndpoint,
+			MeshPath:                meshPath,
+			MeshHandler:             mesh.WithPeerCertificates(meshHandler),
+			ClientCAs:               agentClientCAs,
+			VerifyClientCertificate: verifyAgentCertificate,
+			CRLPath:                 ca.CRLPath,
+			CRLHandler:              crlHandler,
 			ColonyPath:              colonyPath,
 			ColonyHandler:           colonyHandler,
 			DebugPath:               debugPath,
 			DebugHandler:            debugHandler,
-			MCPServer:               nil, // Tool dispatch moved to proxy layer (RFD 100).
+			MCPServer:               mcpServer,
 			TokenStore:              tokenStore,
 			ColonyDir:               colonyDir,
 			TLSCertificate:          tlsCert,
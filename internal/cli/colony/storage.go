@@ -0,0 +1,67 @@
+package colony
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/storage"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+// databaseEncryption returns the at-rest encryption of the colony database
+// (storage_encryption), or nil if it is disabled.
+func databaseEncryption(ctx context.Context, colonyConfig *config.ColonyConfig) (*database.Encryption, error) {
+	cfg := colonyConfig.StorageEncryption
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	key, err := storage.LoadKey(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &database.Encryption{Key: key, WorkDir: cfg.WorkDir}, nil
+}
+
+// openDatabase opens the colony database in storagePath, stored encrypted
+// if enc is set.
+func openDatabase(storagePath, colonyID string, enc *database.Encryption, readOnly bool, connectionsCacheTTL time.Duration, logger zerolog.Logger) (*database.Database, error) {
+	switch {
+	case enc != nil && readOnly:
+		return database.NewEncryptedReadOnly(storagePath, colonyID, *enc, connectionsCacheTTL, logger)
+	case enc != nil:
+		return database.NewEncrypted(storagePath, colonyID, *enc, connectionsCacheTTL, logger)
+	case readOnly:
+		return database.NewReadOnly(storagePath, colonyID, connectionsCacheTTL, logger)
+	default:
+		return database.New(storagePath, colonyID, connectionsCacheTTL, logger)
+	}
+}
+
+// sealDatabase encrypts the working copy of an encrypted colony database
+// back to the storage path every interval until ctx is done.
+func sealDatabase(ctx context.Context, db *database.Database, interval time.Duration, logger logging.Logger) {
+	if interval <= 0 {
+		interval = constants.DefaultStorageSealInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			start := time.Now()
+			if err := db.Seal(ctx); err != nil {
+				logger.Warn().Err(err).Msg("Failed to encrypt colony database")
+				continue
+			}
+			logger.Debug().Dur("duration", time.Since(start)).Msg("Encrypted colony database")
+		}
+	}
+}
@@ -234,6 +234,15 @@ func formatServicesList(services []*meshv1.ServiceInfo) string {
 	return strings.Join(serviceNames, ", ")
 }
 
+// formatStatusReasons formats agent status followed by the causes of any
+// degradation, e.g. "degraded: clock skew 7s; event queue depth 12000".
+func formatStatusReasons(agent *colonyv1.Agent) string {
+	if agent.Status == "healthy" || len(agent.HealthReasons) == 0 {
+		return agent.Status
+	}
+	return agent.Status + ": " + strings.Join(agent.HealthReasons, "; ")
+}
+
 // formatAgentStatus formats agent status with last seen time.
 func formatAgentStatus(agent *colonyv1.Agent) string {
 	lastSeen := agent.LastSeen.AsTime()
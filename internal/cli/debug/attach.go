@@ -12,17 +12,22 @@ import (
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
 )
 
 func NewAttachCmd() *cobra.Command {
 	var (
-		functionName  string
-		duration      time.Duration
-		captureArgs   bool
-		captureReturn bool
-		sampleRate    uint32
-		agentID       string
-		format        string
+		functionName   string
+		duration       time.Duration
+		captureArgs    bool
+		captureReturn  bool
+		sampleRate     uint32
+		agentID        string
+		format         string
+		selector       string
+		overrideFreeze bool
 
 		// Kernel-level filter flags (RFD 090).
 		minDuration time.Duration
@@ -31,11 +36,14 @@ func NewAttachCmd() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "attach <service>",
+		Use:   "attach [service]",
 		Short: "Attach uprobe to function",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			serviceName := args[0]
+			serviceName, err := serviceArg(cmd.Context(), args, selector)
+			if err != nil {
+				return err
+			}
 			ctx := context.Background()
 
 			// Create Colony client
@@ -58,7 +66,8 @@ func NewAttachCmd() *cobra.Command {
 					CaptureReturn: captureReturn,
 					SampleRate:    sampleRate,
 				},
-				AgentId: agentID,
+				AgentId:        agentID,
+				OverrideFreeze: overrideFreeze,
 			}
 
 			// Attach kernel-level filter if any filter flag was provided (RFD 090).
@@ -73,7 +82,7 @@ func NewAttachCmd() *cobra.Command {
 			resp, err := client.AttachUprobe(ctx, connect.NewRequest(req))
 			if err != nil {
 				// Check if this is a connection error (colony not running)
-				if connect.CodeOf(err) == connect.CodeUnavailable {
+				if coralerrors.CodeOf(err) == errorsv1.ErrorCode_ERROR_CODE_COLONY_UNREACHABLE {
 					return fmt.Errorf("colony is not reachable\n"+
 						"Please ensure the colony is running with: bin/coral colony start\n"+
 						"Original error: %w", err)
@@ -82,7 +91,7 @@ func NewAttachCmd() *cobra.Command {
 			}
 
 			if !resp.Msg.Success {
-				return fmt.Errorf("failed to attach uprobe: %s", resp.Msg.Error)
+				return coralerrors.FromInfo(resp.Msg.ErrorInfo, fmt.Errorf("failed to attach uprobe: %s", resp.Msg.Error))
 			}
 
 			// Format and print output
@@ -107,6 +116,8 @@ func NewAttachCmd() *cobra.Command {
 	cmd.Flags().Uint32Var(&sampleRate, "sample-rate", 0, "Sample rate (0 = all calls)")
 	cmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (manual override)")
 	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json, csv)")
+	helpers.AddSelectorFlag(cmd, &selector)
+	helpers.AddOverrideFreezeFlag(cmd, &overrideFreeze)
 
 	// Kernel-level filter flags (RFD 090).
 	cmd.Flags().DurationVar(&minDuration, "min-duration", 0, "Only emit events slower than this threshold (e.g. 50ms)")
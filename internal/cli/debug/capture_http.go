@@ -0,0 +1,183 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// NewCaptureHttpCmd creates the `coral debug capture-http` command.
+func NewCaptureHttpCmd() *cobra.Command {
+	var (
+		serviceName    string
+		selector       string
+		group          string
+		route          string
+		sample         string
+		captureBodies  bool
+		maxBodyBytes   uint32
+		duration       time.Duration
+		agentID        string
+		format         string
+		overrideFreeze bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "capture-http",
+		Short: "Capture HTTP requests and responses for a route",
+		Long: `Capture the headers, and optionally the bodies, of HTTP requests and
+responses served by a service on a given route. The agent reads the
+service's traffic from the socket layer, so no restart or code change is
+needed. Captured exchanges are redacted and stored as events of a debug
+session.
+
+Routes match the request path: "{id}" or ":id" matches one segment and a
+trailing "*" matches any suffix. Only plaintext HTTP/1.x is captured.
+
+Examples:
+  coral debug capture-http --service api --route /api/checkout --sample 1%
+  coral debug capture-http -s api --route '/api/orders/{id}' --bodies --max-body 1024
+  coral debug capture-http --group checkout-path --route /api/checkout
+  coral debug session events <session-id> --format text
+
+With --group, the route is captured on every service of the service group,
+each in its own session. Either all captures start or none: if one fails,
+the sessions already started are stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			sampleRate, err := parseSampleRate(sample)
+			if err != nil {
+				return coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, err, "flag", "sample")
+			}
+
+			newRequest := func(service string) *colonypb.CaptureHttpRequest {
+				return &colonypb.CaptureHttpRequest{
+					ServiceName:    service,
+					Route:          route,
+					SampleRate:     sampleRate,
+					CaptureBodies:  captureBodies,
+					MaxBodyBytes:   maxBodyBytes,
+					Duration:       durationpb.New(duration),
+					AgentId:        agentID,
+					OverrideFreeze: overrideFreeze,
+				}
+			}
+
+			if group != "" {
+				services, err := helpers.ResolveGroup(cmd.Context(), group)
+				if err != nil {
+					return err
+				}
+				client, err := getColonyDebugClient()
+				if err != nil {
+					return fmt.Errorf("failed to create debug client: %w", err)
+				}
+				captures, err := startGroupCaptures(ctx, client, services, func(ctx context.Context, service string) (*groupCapture, error) {
+					resp, err := client.CaptureHttp(ctx, connect.NewRequest(newRequest(service)))
+					if err != nil {
+						return nil, err
+					}
+					if !resp.Msg.Success {
+						return nil, coralerrors.FromInfo(resp.Msg.ErrorInfo, errors.New(resp.Msg.Error))
+					}
+					return &groupCapture{Service: service, SessionID: resp.Msg.SessionId, ExpiresAt: resp.Msg.ExpiresAt.AsTime()}, nil
+				})
+				if err != nil {
+					return err
+				}
+				return printGroupCaptures("HTTP capture of "+route, group, captures, format)
+			}
+
+			service, err := helpers.ResolveService(cmd.Context(), serviceName, selector)
+			if err != nil {
+				return err
+			}
+			serviceName = service
+
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			resp, err := client.CaptureHttp(ctx, connect.NewRequest(newRequest(serviceName)))
+			if err != nil {
+				if coralerrors.CodeOf(err) == errorsv1.ErrorCode_ERROR_CODE_COLONY_UNREACHABLE {
+					return fmt.Errorf("colony is not reachable\n"+
+						"Please ensure the colony is running with: bin/coral colony start\n"+
+						"Original error: %w", err)
+				}
+				return fmt.Errorf("failed to start HTTP capture: %w", err)
+			}
+
+			if !resp.Msg.Success {
+				return coralerrors.FromInfo(resp.Msg.ErrorInfo, fmt.Errorf("failed to start HTTP capture: %s", resp.Msg.Error))
+			}
+
+			if format == "json" {
+				data, _ := json.MarshalIndent(resp.Msg, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+
+			fmt.Printf("✓ HTTP capture started for %s %s\n", serviceName, route)
+			fmt.Printf("  Session ID: %s\n", resp.Msg.SessionId)
+			fmt.Printf("  Expires at: %s\n", resp.Msg.ExpiresAt.AsTime().Format(time.RFC3339))
+			fmt.Printf("\nView captured exchanges with: coral debug session events %s --format text\n", resp.Msg.SessionId)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required without --selector or --group)")
+	helpers.AddSelectorFlag(cmd, &selector)
+	helpers.AddGroupFlag(cmd, &group)
+	cmd.Flags().StringVar(&route, "route", "", "Route to capture, e.g. /api/orders/{id} (required)")
+	cmd.Flags().StringVar(&sample, "sample", "100%", "Fraction of matching requests to capture (e.g. 1% or 0.01)")
+	cmd.Flags().BoolVar(&captureBodies, "bodies", false, "Capture request and response bodies")
+	cmd.Flags().Uint32Var(&maxBodyBytes, "max-body", 4096, "Maximum bytes captured per body")
+	cmd.Flags().DurationVarP(&duration, "duration", "d", 60*time.Second, "Duration of the capture session")
+	cmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (manual override)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	helpers.AddOverrideFreezeFlag(cmd, &overrideFreeze)
+	cmd.MarkFlagsMutuallyExclusive("service", "group")
+	cmd.MarkFlagsMutuallyExclusive("selector", "group")
+	cmd.MarkFlagsMutuallyExclusive("agent-id", "group")
+
+	if err := cmd.MarkFlagRequired("route"); err != nil {
+		fmt.Printf("failed to mark flag as required: %v\n", err)
+	}
+
+	return cmd
+}
+
+// parseSampleRate parses a sample rate given as a percentage ("1%") or a
+// fraction ("0.01") into a fraction in (0, 1].
+func parseSampleRate(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	percent := strings.HasSuffix(s, "%")
+
+	rate, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample rate %q", s)
+	}
+	if percent {
+		rate /= 100
+	}
+	if rate <= 0 || rate > 1 {
+		return 0, fmt.Errorf("sample rate %q must be greater than 0%% and at most 100%%", s)
+	}
+	return rate, nil
+}
@@ -0,0 +1,152 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// NewCaptureTlsCmd creates the `coral debug capture-tls` command.
+func NewCaptureTlsCmd() *cobra.Command {
+	var (
+		serviceName    string
+		selector       string
+		group          string
+		library        string
+		match          string
+		maxDataBytes   uint32
+		duration       time.Duration
+		agentID        string
+		format         string
+		overrideFreeze bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "capture-tls",
+		Short: "Capture the plaintext of a service's TLS connections",
+		Long: `Capture the plaintext a service reads from and writes to its TLS
+connections, before encryption and after decryption. The agent probes the
+read and write functions of the service's TLS library: crypto/tls for Go
+binaries, SSL_read and SSL_write of OpenSSL otherwise. Captured records are
+redacted and stored as events of a debug session.
+
+TLS capture is disabled by default and must be enabled on the agent with
+debug.tls_capture.enabled.
+
+With --match, only connections whose plaintext contains the given string
+are captured, from the record that first contains it.
+
+Examples:
+  coral debug capture-tls --service payments --match 'Host: api.stripe.com'
+  coral debug capture-tls -s api --library openssl --max-data 1024 -d 30s
+  coral debug capture-tls --group checkout-path --match 'POST /charge'
+  coral debug session events <session-id> --format text
+
+With --group, every service of the service group is captured in its own
+session. If a capture fails to start, those already started are stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			newRequest := func(service string) *colonypb.CaptureTlsRequest {
+				return &colonypb.CaptureTlsRequest{
+					ServiceName:    service,
+					Library:        library,
+					Match:          match,
+					MaxDataBytes:   maxDataBytes,
+					Duration:       durationpb.New(duration),
+					AgentId:        agentID,
+					OverrideFreeze: overrideFreeze,
+				}
+			}
+
+			if group != "" {
+				services, err := helpers.ResolveGroup(cmd.Context(), group)
+				if err != nil {
+					return err
+				}
+				client, err := getColonyDebugClient()
+				if err != nil {
+					return fmt.Errorf("failed to create debug client: %w", err)
+				}
+				captures, err := startGroupCaptures(ctx, client, services, func(ctx context.Context, service string) (*groupCapture, error) {
+					resp, err := client.CaptureTls(ctx, connect.NewRequest(newRequest(service)))
+					if err != nil {
+						return nil, err
+					}
+					if !resp.Msg.Success {
+						return nil, coralerrors.FromInfo(resp.Msg.ErrorInfo, errors.New(resp.Msg.Error))
+					}
+					return &groupCapture{Service: service, SessionID: resp.Msg.SessionId, ExpiresAt: resp.Msg.ExpiresAt.AsTime()}, nil
+				})
+				if err != nil {
+					return err
+				}
+				return printGroupCaptures("TLS capture", group, captures, format)
+			}
+
+			service, err := helpers.ResolveService(cmd.Context(), serviceName, selector)
+			if err != nil {
+				return err
+			}
+			serviceName = service
+
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			resp, err := client.CaptureTls(ctx, connect.NewRequest(newRequest(serviceName)))
+			if err != nil {
+				if coralerrors.CodeOf(err) == errorsv1.ErrorCode_ERROR_CODE_COLONY_UNREACHABLE {
+					return fmt.Errorf("colony is not reachable\n"+
+						"Please ensure the colony is running with: bin/coral colony start\n"+
+						"Original error: %w", err)
+				}
+				return fmt.Errorf("failed to start TLS capture: %w", err)
+			}
+
+			if !resp.Msg.Success {
+				return coralerrors.FromInfo(resp.Msg.ErrorInfo, fmt.Errorf("failed to start TLS capture: %s", resp.Msg.Error))
+			}
+
+			if format == "json" {
+				data, _ := json.MarshalIndent(resp.Msg, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+
+			fmt.Printf("✓ TLS capture started for %s\n", serviceName)
+			fmt.Printf("  Session ID: %s\n", resp.Msg.SessionId)
+			fmt.Printf("  Expires at: %s\n", resp.Msg.ExpiresAt.AsTime().Format(time.RFC3339))
+			fmt.Printf("\nView captured records with: coral debug session events %s --format text\n", resp.Msg.SessionId)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required without --selector or --group)")
+	helpers.AddSelectorFlag(cmd, &selector)
+	helpers.AddGroupFlag(cmd, &group)
+	cmd.Flags().StringVar(&library, "library", "", "TLS library to probe (go, openssl); detected if empty")
+	cmd.Flags().StringVar(&match, "match", "", "Only capture connections whose plaintext contains this string")
+	cmd.Flags().Uint32Var(&maxDataBytes, "max-data", 4096, "Maximum bytes captured per read or write (at most 16384)")
+	cmd.Flags().DurationVarP(&duration, "duration", "d", 60*time.Second, "Duration of the capture session")
+	cmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (manual override)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	helpers.AddOverrideFreezeFlag(cmd, &overrideFreeze)
+	cmd.MarkFlagsMutuallyExclusive("service", "group")
+	cmd.MarkFlagsMutuallyExclusive("selector", "group")
+	cmd.MarkFlagsMutuallyExclusive("agent-id", "group")
+
+	return cmd
+}
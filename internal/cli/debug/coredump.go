@@ -0,0 +1,258 @@
+package debug
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+// NewCoreDumpCmd creates the `coral debug coredump` command.
+func NewCoreDumpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "coredump",
+		Short: "List and download core dumps of crashed services",
+		Long: `List and download core dumps captured by agents when a service crashes.
+
+Core dump capture is opt-in per agent (core_dumps.enabled in the agent
+configuration). Dumps are stored compressed on the agent within a size quota;
+the oldest are evicted first.
+
+Examples:
+  coral debug coredump list
+  coral debug coredump list --service api
+  coral debug coredump download --service api
+  coral debug coredump download --service api --id 1767225600-4242 --decompress -o api.core`,
+	}
+
+	cmd.AddCommand(newCoreDumpListCmd())
+	cmd.AddCommand(newCoreDumpDownloadCmd())
+
+	return cmd
+}
+
+func newCoreDumpListCmd() *cobra.Command {
+	var (
+		serviceName string
+		format      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List captured core dumps",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dumps, err := listCoreDumps(context.Background(), serviceName)
+			if err != nil {
+				return err
+			}
+
+			if format == "json" {
+				return json.NewEncoder(os.Stdout).Encode(dumps)
+			}
+
+			if len(dumps) == 0 {
+				fmt.Println("No core dumps captured.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			defer func() { _ = w.Flush() }()
+			if _, err := fmt.Fprintln(w, "ID\tService\tAgent\tCrashed\tSignal\tSize\tStored"); err != nil {
+				return err
+			}
+			for _, d := range dumps {
+				size := formatDumpBytes(d.SizeBytes)
+				if d.Truncated {
+					size += " (truncated)"
+				}
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					d.Id,
+					d.ServiceName,
+					d.AgentId,
+					d.CrashedAt.AsTime().Local().Format(time.DateTime),
+					signalName(d.Signal),
+					size,
+					formatDumpBytes(d.CompressedBytes),
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Filter by service name")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func newCoreDumpDownloadCmd() *cobra.Command {
+	var (
+		serviceName string
+		dumpID      string
+		agentID     string
+		output      string
+		decompress  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "download",
+		Short: "Download a core dump (the latest by default)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			dumps, err := listCoreDumps(ctx, serviceName)
+			if err != nil {
+				return err
+			}
+
+			var dump *agentv1.CoreDumpInfo
+			for _, d := range dumps {
+				if (dumpID == "" || d.Id == dumpID) && (agentID == "" || d.AgentId == agentID) {
+					dump = d // Dumps are sorted newest first.
+					break
+				}
+			}
+			if dump == nil {
+				if dumpID != "" {
+					return fmt.Errorf("core dump %s not found for service %s", dumpID, serviceName)
+				}
+				return fmt.Errorf("no core dumps captured for service %s", serviceName)
+			}
+
+			if output == "" {
+				output = fmt.Sprintf("%s-%s.core", dump.ServiceName, dump.Id)
+				if !decompress {
+					output += ".gz"
+				}
+			}
+
+			written, err := downloadCoreDump(ctx, dump, output, decompress)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Downloaded core dump %s of %s (pid %d, %s) to %s (%s)\n",
+				dump.Id, dump.ServiceName, dump.Pid, signalName(dump.Signal), output, formatDumpBytes(uint64(written)))
+			if dump.Executable != "" {
+				fmt.Printf("Inspect with: gdb %s %s\n", dump.Executable, output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required)")
+	cmd.Flags().StringVar(&dumpID, "id", "", "Core dump ID (default: the latest)")
+	cmd.Flags().StringVar(&agentID, "agent", "", "Agent holding the core dump")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: <service>-<id>.core.gz)")
+	cmd.Flags().BoolVar(&decompress, "decompress", false, "Write the raw core dump instead of gzip")
+	_ = cmd.MarkFlagRequired("service")
+
+	return cmd
+}
+
+// listCoreDumps returns core dumps across all agents, newest first.
+func listCoreDumps(ctx context.Context, serviceName string) ([]*agentv1.CoreDumpInfo, error) {
+	client, err := getColonyDebugClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debug client: %w", err)
+	}
+
+	resp, err := client.ListCoreDumps(ctx, connect.NewRequest(&colonypb.ColonyListCoreDumpsRequest{
+		ServiceName: serviceName,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list core dumps: %w", err)
+	}
+	return resp.Msg.Dumps, nil
+}
+
+// downloadCoreDump streams a core dump into path and returns the number of
+// bytes written.
+func downloadCoreDump(ctx context.Context, dump *agentv1.CoreDumpInfo, path string, decompress bool) (int64, error) {
+	client, err := getColonyDebugClient()
+	if err != nil {
+		return 0, fmt.Errorf("failed to create debug client: %w", err)
+	}
+
+	stream, err := client.DownloadCoreDump(ctx, connect.NewRequest(&colonypb.ColonyDownloadCoreDumpRequest{
+		AgentId: dump.AgentId,
+		Id:      dump.Id,
+	}))
+	if err != nil {
+		return 0, fmt.Errorf("failed to download core dump: %w", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	f, err := os.Create(path) // #nosec G304 - path is chosen by the user.
+	if err != nil {
+		return 0, fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	// Feed the compressed stream through a pipe so it can be decompressed
+	// on the fly.
+	pr, pw := io.Pipe()
+	go func() {
+		for stream.Receive() {
+			if _, err := pw.Write(stream.Msg().Data); err != nil {
+				return
+			}
+		}
+		_ = pw.CloseWithError(stream.Err())
+	}()
+	defer func() { _ = pr.Close() }()
+
+	var src io.Reader = pr
+	if decompress {
+		zr, err := gzip.NewReader(pr)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decompress core dump: %w", err)
+		}
+		defer func() { _ = zr.Close() }()
+		src = zr
+	}
+
+	written, err := io.Copy(f, src)
+	if err != nil {
+		return written, fmt.Errorf("failed to download core dump: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return written, fmt.Errorf("failed to write output file: %w", err)
+	}
+	return written, nil
+}
+
+// signalName returns a readable name for a signal number.
+func signalName(signal int32) string {
+	if signal <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d (%s)", signal, syscall.Signal(signal).String())
+}
+
+// formatDumpBytes formats a byte count for display.
+func formatDumpBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
@@ -4,10 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
 
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
 )
 
@@ -210,9 +216,14 @@ func NewProfileCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "profile",
 		Short: "Auto-profile functions",
-		Long:  "Automatically profile multiple functions with batch instrumentation.",
+		Long: `Automatically profile multiple functions with batch instrumentation.
+
+Interrupting a synchronous run detaches all of its probes. Async runs can be
+cancelled with 'coral debug profile cancel <session-id>'.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := context.Background()
+			// Cancelling the request makes the colony detach the probes.
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
 
 			// Create Colony client
 			client, err := getColonyDebugClient()
@@ -240,6 +251,9 @@ func NewProfileCmd() *cobra.Command {
 
 			resp, err := client.ProfileFunctions(ctx, connect.NewRequest(req))
 			if err != nil {
+				if ctx.Err() != nil {
+					return fmt.Errorf("profiling interrupted, probes detached")
+				}
 				return fmt.Errorf("failed to profile functions: %w", err)
 			}
 
@@ -303,5 +317,168 @@ func NewProfileCmd() *cobra.Command {
 		fmt.Printf("failed to mark flag as required: %v\n", err)
 	}
 
+	cmd.AddCommand(newProfileCancelCmd())
+
+	return cmd
+}
+
+func newProfileCancelCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "cancel <session-id>",
+		Short: "Cancel an async profiling run",
+		Long:  "Cancel an async profiling run, detaching the probes of all its functions.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sessionID := args[0]
+			ctx := context.Background()
+
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			resp, err := client.CancelProfileFunctions(ctx, connect.NewRequest(&colonypb.CancelProfileFunctionsRequest{
+				SessionId: sessionID,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to cancel profiling: %w", err)
+			}
+
+			if format == "json" {
+				data, _ := json.MarshalIndent(resp.Msg, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+
+			fmt.Printf("✓ Profiling cancelled, %d session(s) detached\n", resp.Msg.SessionsDetached)
+			if resp.Msg.SessionsFailed > 0 {
+				fmt.Printf("  %d session(s) failed to detach and will expire on their own\n", resp.Msg.SessionsFailed)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+
 	return cmd
 }
+
+// NewDescribeCmd creates the `coral debug describe` command.
+func NewDescribeCmd() *cobra.Command {
+	var (
+		serviceName  string
+		functionName string
+		format       string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Show function signature, argument locations and probeability",
+		Long: `Show the full signature of a function, where each argument lives at
+function entry (register or stack) and whether a uprobe on it can capture
+arguments and call duration. Use it to check a function before attaching
+probes or writing capture filters.
+
+Examples:
+  coral debug describe --service api --function main.ProcessPayment
+  coral debug describe -s api -f main.ProcessPayment --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			resp, err := client.DescribeFunction(ctx, connect.NewRequest(&colonypb.ColonyDescribeFunctionRequest{
+				ServiceName:  serviceName,
+				FunctionName: functionName,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to describe function: %w", err)
+			}
+
+			if format == "json" {
+				data, _ := json.MarshalIndent(resp.Msg, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+
+			return printFunctionDescription(resp.Msg.Function)
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required)")
+	cmd.Flags().StringVarP(&functionName, "function", "f", "", "Function name (required)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+
+	if err := cmd.MarkFlagRequired("service"); err != nil {
+		fmt.Printf("failed to mark flag as required: %v\n", err)
+	}
+	if err := cmd.MarkFlagRequired("function"); err != nil {
+		fmt.Printf("failed to mark flag as required: %v\n", err)
+	}
+
+	return cmd
+}
+
+func printFunctionDescription(fn *agentv1.FunctionDescription) error {
+	if fn == nil {
+		return fmt.Errorf("empty function description")
+	}
+
+	fmt.Printf("%s\n\n", fn.Signature)
+	if fn.BinaryPath != "" {
+		fmt.Printf("Binary:    %s\n", fn.BinaryPath)
+	}
+	fmt.Printf("Offset:    0x%x\n", fn.Offset)
+	if fn.SizeBytes > 0 {
+		fmt.Printf("Size:      %d bytes\n", fn.SizeBytes)
+	}
+	fmt.Printf("Discovery: %s\n", fn.DiscoveryMethod)
+
+	if len(fn.Arguments) > 0 {
+		fmt.Printf("\nArguments:\n")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  NAME\tTYPE\tLOCATION")
+		for _, arg := range fn.Arguments {
+			location := arg.Location
+			if location == "" {
+				location = "-"
+			}
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\n", arg.Name, arg.Type, location)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(fn.ReturnValues) > 0 {
+		fmt.Printf("\nReturns:\n")
+		for _, ret := range fn.ReturnValues {
+			if ret.Name != "" && !strings.HasPrefix(ret.Name, "~") {
+				fmt.Printf("  %s %s\n", ret.Name, ret.Type)
+			} else {
+				fmt.Printf("  %s\n", ret.Type)
+			}
+		}
+	}
+
+	if p := fn.Probeability; p != nil {
+		fmt.Printf("\nProbeability:\n")
+		fmt.Printf("  Probeable:          %v\n", p.Probeable)
+		if p.DurationAvailable {
+			fmt.Printf("  Duration:           yes (%d return instructions)\n", p.ReturnInstructions)
+		} else {
+			fmt.Printf("  Duration:           no\n")
+		}
+		fmt.Printf("  Arguments located:  %v\n", p.ArgumentsLocated)
+		for _, note := range p.Notes {
+			fmt.Printf("  - %s\n", note)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// groupCapture is the session of a capture on one service of a group.
+type groupCapture struct {
+	Service   string    `json:"service"`
+	SessionID string    `json:"session_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// startGroupCaptures starts a capture with start on every service of a group
+// at once. The group is captured as a whole or not at all: if a capture
+// fails to start, the sessions of the others are stopped again.
+func startGroupCaptures(
+	ctx context.Context,
+	client colonyv1connect.ColonyDebugServiceClient,
+	services []string,
+	start func(ctx context.Context, service string) (*groupCapture, error),
+) ([]*groupCapture, error) {
+	results := helpers.RunGroup(ctx, services, start)
+
+	if err := helpers.GroupError(results); err != nil {
+		for _, r := range results {
+			if r.Err != nil {
+				continue
+			}
+			resp, stopErr := client.DetachUprobe(ctx, connect.NewRequest(&colonypb.DetachUprobeRequest{SessionId: r.Value.SessionID}))
+			if stopErr == nil && !resp.Msg.Success {
+				stopErr = errors.New(resp.Msg.Error)
+			}
+			if stopErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to stop session %s of %s: %v\n", r.Value.SessionID, r.Service, stopErr)
+			}
+		}
+		return nil, fmt.Errorf("capture not started: %w", err)
+	}
+
+	captures := make([]*groupCapture, len(results))
+	for i, r := range results {
+		captures[i] = r.Value
+	}
+	return captures, nil
+}
+
+// printGroupCaptures prints the sessions of the captures started on a group.
+func printGroupCaptures(what, group string, captures []*groupCapture, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(captures, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Printf("✓ %s started for %d services of group %s\n", what, len(captures), group)
+	for _, c := range captures {
+		fmt.Printf("  %-20s session %s (expires %s)\n", c.Service, c.SessionID, c.ExpiresAt.Format(time.RFC3339))
+	}
+	fmt.Printf("\nView the events of a session with: coral debug session events <session-id> --format text\n")
+	return nil
+}
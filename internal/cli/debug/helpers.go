@@ -1,6 +1,7 @@
 package debug
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -15,6 +16,20 @@ func getColonyDebugClient() (colonyv1connect.ColonyDebugServiceClient, error) {
 	return helpers.GetColonyDebugClient("")
 }
 
+// serviceArg returns the service named by the optional argument of a
+// command, or the single service matching selector.
+func serviceArg(ctx context.Context, args []string, selector string) (string, error) {
+	switch {
+	case len(args) > 0 && selector != "":
+		return "", fmt.Errorf("a service argument and --selector cannot be combined")
+	case len(args) > 0:
+		return args[0], nil
+	case selector == "":
+		return "", fmt.Errorf("a service argument or --selector is required")
+	}
+	return helpers.ResolveService(ctx, "", selector)
+}
+
 // parseDuration parses a duration string and returns a protobuf Duration.
 func parseDuration(s string) (*durationpb.Duration, error) {
 	d, err := time.ParseDuration(s)
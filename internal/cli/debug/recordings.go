@@ -0,0 +1,326 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// Terminal size of asciicasts of recordings made without a terminal.
+const (
+	defaultCastWidth  = 80
+	defaultCastHeight = 24
+)
+
+// NewRecordingsCmd creates the `coral debug recordings` command.
+func NewRecordingsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recordings",
+		Short: "Review recordings of shell and exec sessions",
+		Long: `Review the recordings of shell and exec sessions run on agents.
+
+Agents record the input and output of 'coral shell', 'coral exec' and the
+coral_shell_exec MCP tool with timestamps and upload the recording to the
+colony when the session ends. Exec output is recorded after redaction.
+Recordings larger than 4 MiB are truncated. Reading recordings requires the
+admin permission when RBAC is enabled; they are kept for 90 days
+(retention.tables.shell_recordings).
+
+Examples:
+  coral debug recordings list --agent hostname-api-1 --since 24h
+  coral debug recordings play 6f1c2d7e-...
+  coral debug recordings export 6f1c2d7e-... --format asciinema -o incident.cast
+  asciinema play incident.cast`,
+	}
+
+	cmd.AddCommand(newRecordingsListCmd())
+	cmd.AddCommand(newRecordingsPlayCmd())
+	cmd.AddCommand(newRecordingsExportCmd())
+
+	return cmd
+}
+
+func newRecordingsListCmd() *cobra.Command {
+	var (
+		agentID string
+		userID  string
+		since   string
+		limit   int
+		format  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recorded sessions, newest first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &colonypb.ListShellRecordingsRequest{
+				AgentId: agentID,
+				UserId:  userID,
+				Limit:   int32(limit), // #nosec G115 -- limit is small.
+			}
+			if since != "" {
+				d, err := helpers.ParseSince(since)
+				if err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
+				}
+				req.Since = timestamppb.New(time.Now().Add(-d))
+			}
+
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			resp, err := client.ListShellRecordings(cmd.Context(), connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to list recordings: %w", err)
+			}
+			recordings := resp.Msg.Recordings
+
+			if format == "json" {
+				return json.NewEncoder(os.Stdout).Encode(recordings)
+			}
+
+			if len(recordings) == 0 {
+				fmt.Println("No sessions recorded.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			defer func() { _ = w.Flush() }()
+			if _, err := fmt.Fprintln(w, "Session\tKind\tAgent\tUser\tStarted\tDuration\tExit\tSize\tCommand"); err != nil {
+				return err
+			}
+			for _, r := range recordings {
+				size := formatDumpBytes(r.SizeBytes)
+				if r.Truncated {
+					size += " (truncated)"
+				}
+				command := strings.Join(r.Command, " ")
+				if r.ContainerName != "" {
+					command = r.ContainerName + ": " + command
+				}
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+					r.SessionId,
+					r.Kind,
+					r.AgentId,
+					r.UserId,
+					r.StartedAt.AsTime().Local().Format(time.DateTime),
+					r.EndedAt.AsTime().Sub(r.StartedAt.AsTime()).Round(time.Second),
+					r.ExitCode,
+					size,
+					command,
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&agentID, "agent", "", "Only show sessions on this agent")
+	cmd.Flags().StringVar(&userID, "user", "", "Only show sessions of this user")
+	cmd.Flags().StringVar(&since, "since", "", "Only show sessions started within this duration (e.g. 1h, 7d)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of sessions (default 50)")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func newRecordingsPlayCmd() *cobra.Command {
+	var (
+		speed     float64
+		idleLimit time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "play <session-id>",
+		Short: "Replay the output of a recorded session in the terminal",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if speed <= 0 {
+				return fmt.Errorf("--speed must be positive")
+			}
+
+			rec, err := getShellRecording(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			return playRecording(cmd.Context(), os.Stdout, rec, speed, idleLimit)
+		},
+	}
+
+	cmd.Flags().Float64Var(&speed, "speed", 1, "Playback speed multiplier")
+	cmd.Flags().DurationVar(&idleLimit, "idle-limit", 2*time.Second, "Longest pause between outputs (0 keeps the recorded pauses)")
+
+	return cmd
+}
+
+func newRecordingsExportCmd() *cobra.Command {
+	var (
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export <session-id>",
+		Short: "Export a recorded session",
+		Long: `Export a recorded session as an asciicast v2 file, playable with
+'asciinema play' or the asciinema web player, or as JSON.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "asciinema" && format != "json" {
+				return fmt.Errorf("unsupported format %q (must be asciinema or json)", format)
+			}
+
+			rec, err := getShellRecording(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output) // #nosec G304 - path is chosen by the user.
+				if err != nil {
+					return fmt.Errorf("failed to create %s: %w", output, err)
+				}
+				defer func() { _ = f.Close() }()
+				w = f
+			}
+
+			if format == "json" {
+				encoder := json.NewEncoder(w)
+				encoder.SetIndent("", "  ")
+				err = encoder.Encode(rec)
+			} else {
+				err = writeAsciicast(w, rec)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to export recording: %w", err)
+			}
+
+			if output != "" {
+				fmt.Fprintf(os.Stderr, "Exported session %s to %s\n", rec.SessionId, output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "asciinema", "Export format: asciinema or json")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: stdout)")
+
+	return cmd
+}
+
+// getShellRecording fetches a recording with its events.
+func getShellRecording(ctx context.Context, sessionID string) (*colonypb.ShellRecording, error) {
+	client, err := getColonyDebugClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debug client: %w", err)
+	}
+
+	resp, err := client.GetShellRecording(ctx, connect.NewRequest(&colonypb.GetShellRecordingRequest{SessionId: sessionID}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recording: %w", err)
+	}
+	return resp.Msg.Recording, nil
+}
+
+// playRecording writes the output events of rec to w with their recorded
+// timing, sped up by speed and with pauses capped at idleLimit.
+func playRecording(ctx context.Context, w io.Writer, rec *colonypb.ShellRecording, speed float64, idleLimit time.Duration) error {
+	var last int64
+	for _, e := range rec.Events {
+		if e.Type != "o" {
+			continue
+		}
+
+		pause := time.Duration(float64(e.OffsetUs-last)/speed) * time.Microsecond
+		if idleLimit > 0 && pause > idleLimit {
+			pause = idleLimit
+		}
+		last = e.OffsetUs
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pause):
+		}
+
+		if _, err := w.Write(terminalOutput(rec, e.Data)); err != nil {
+			return err
+		}
+	}
+
+	if rec.Truncated {
+		fmt.Fprintf(os.Stderr, "\n[recording truncated]\n")
+	}
+	return nil
+}
+
+// asciicastHeader is the first line of an asciicast v2 file.
+type asciicastHeader struct {
+	Version   int    `json:"version"`
+	Width     uint32 `json:"width"`
+	Height    uint32 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Command   string `json:"command,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// writeAsciicast writes rec in the asciicast v2 format: a JSON header line
+// followed by one [seconds, type, data] line per event.
+func writeAsciicast(w io.Writer, rec *colonypb.ShellRecording) error {
+	header := asciicastHeader{
+		Version:   2,
+		Width:     rec.Cols,
+		Height:    rec.Rows,
+		Timestamp: rec.StartedAt.AsTime().Unix(),
+		Command:   strings.Join(rec.Command, " "),
+		Title:     fmt.Sprintf("%s on %s by %s", rec.Kind, rec.AgentId, rec.UserId),
+	}
+	if header.Width == 0 || header.Height == 0 {
+		header.Width, header.Height = defaultCastWidth, defaultCastHeight
+	}
+
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(header); err != nil {
+		return err
+	}
+
+	for _, e := range rec.Events {
+		data := e.Data
+		if e.Type == "o" {
+			data = terminalOutput(rec, data)
+		}
+		event := []any{float64(e.OffsetUs) / 1e6, e.Type, string(data)}
+		if err := encoder.Encode(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// terminalOutput returns output as a terminal displays it. One-off exec
+// sessions run without a terminal, so their bare line feeds get a carriage
+// return.
+func terminalOutput(rec *colonypb.ShellRecording, data []byte) []byte {
+	if rec.Kind != "exec" && rec.Kind != "container_exec" {
+		return data
+	}
+	s := strings.ReplaceAll(string(data), "\r\n", "\n")
+	return []byte(strings.ReplaceAll(s, "\n", "\r\n"))
+}
@@ -0,0 +1,80 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestWriteAsciicast(t *testing.T) {
+	rec := &colonypb.ShellRecording{
+		SessionId: "s1",
+		AgentId:   "agent-1",
+		Kind:      "shell",
+		UserId:    "alice",
+		Command:   []string{"/bin/bash"},
+		StartedAt: timestamppb.New(time.Unix(1767225600, 0)),
+		Cols:      120,
+		Rows:      40,
+		Events: []*colonypb.ShellRecordingEvent{
+			{OffsetUs: 0, Type: "o", Data: []byte("$ ")},
+			{OffsetUs: 1500000, Type: "i", Data: []byte("ls\r")},
+			{OffsetUs: 1520000, Type: "o", Data: []byte("a.txt\r\n\x1b[0m")},
+			{OffsetUs: 2000000, Type: "r", Data: []byte("100x30")},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeAsciicast(&buf, rec))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 5)
+	assert.JSONEq(t, `{"version":2,"width":120,"height":40,"timestamp":1767225600,
+		"command":"/bin/bash","title":"shell on agent-1 by alice"}`, lines[0])
+	assert.JSONEq(t, `[0, "o", "$ "]`, lines[1])
+	assert.JSONEq(t, `[1.5, "i", "ls\r"]`, lines[2])
+	assert.JSONEq(t, `[1.52, "o", "a.txt\r\n\u001b[0m"]`, lines[3])
+	assert.JSONEq(t, `[2, "r", "100x30"]`, lines[4])
+}
+
+func TestWriteAsciicast_Exec(t *testing.T) {
+	rec := &colonypb.ShellRecording{
+		Kind:      "exec",
+		Command:   []string{"ps", "aux"},
+		StartedAt: timestamppb.Now(),
+		Events:    []*colonypb.ShellRecordingEvent{{OffsetUs: 10, Type: "o", Data: []byte("a\nb\r\n")}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeAsciicast(&buf, rec))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"width":80,"height":24`, "exec sessions have no terminal size")
+	assert.JSONEq(t, `[0.00001, "o", "a\r\nb\r\n"]`, lines[1], "line feeds of exec output get a carriage return")
+}
+
+func TestPlayRecording(t *testing.T) {
+	rec := &colonypb.ShellRecording{
+		Kind: "shell",
+		Events: []*colonypb.ShellRecordingEvent{
+			{OffsetUs: 0, Type: "o", Data: []byte("$ ")},
+			{OffsetUs: 1000, Type: "i", Data: []byte("ls\r")},
+			{OffsetUs: 60000000, Type: "o", Data: []byte("a.txt\r\n")},
+		},
+	}
+
+	var buf bytes.Buffer
+	start := time.Now()
+	require.NoError(t, playRecording(context.Background(), &buf, rec, 1, 10*time.Millisecond))
+	assert.Less(t, time.Since(start), 5*time.Second, "pauses are capped at the idle limit")
+	assert.Equal(t, "$ a.txt\r\n", buf.String(), "only output is replayed")
+}
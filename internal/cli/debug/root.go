@@ -13,12 +13,23 @@ func NewDebugCmd() *cobra.Command {
 
 Function-level debugging commands:
   attach   - Attach uprobe to function
+  capture-http - Capture HTTP requests and responses for a route
+  capture-tls  - Capture the plaintext of a service's TLS connections
   profile  - Auto-profile multiple functions
   search   - Search for functions
   info     - Get function details
+  describe - Show function signature, argument locations and probeability
   trace    - Trace request path
+  runtime  - Trace GC pauses and scheduler latency of a Go service
+  syscalls - Count the syscalls of a service with their latency and errors
   session  - Manage debug sessions (list, get, query, events, stop)
 
+Crash debugging:
+  coredump - List and download core dumps of crashed services
+
+Incident review:
+  recordings - List, replay and export recorded shell and exec sessions
+
 For CPU and memory profiling, use 'coral profile' and 'coral query' commands.`,
 	}
 
@@ -26,6 +37,10 @@ For CPU and memory profiling, use 'coral profile' and 'coral query' commands.`,
 	cmd.AddCommand(NewAttachCmd())
 	cmd.AddCommand(NewProfileCmd())
 	cmd.AddCommand(NewFilterCmd())
+	cmd.AddCommand(NewCaptureHttpCmd())
+	cmd.AddCommand(NewCaptureTlsCmd())
+	cmd.AddCommand(NewRuntimeCmd())
+	cmd.AddCommand(NewSyscallsCmd())
 
 	// Session Management
 	cmd.AddCommand(NewSessionCmd())
@@ -33,10 +48,13 @@ For CPU and memory profiling, use 'coral profile' and 'coral query' commands.`,
 	// Discovery
 	cmd.AddCommand(NewSearchCmd())
 	cmd.AddCommand(NewInfoCmd())
+	cmd.AddCommand(NewDescribeCmd())
 
 	// Other
 	cmd.AddCommand(NewTraceCmd())
 	cmd.AddCommand(NewCorrelationsCmd())
+	cmd.AddCommand(NewCoreDumpCmd())
+	cmd.AddCommand(NewRecordingsCmd())
 
 	return cmd
 }
@@ -0,0 +1,210 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// NewRuntimeCmd creates the `coral debug runtime` command.
+func NewRuntimeCmd() *cobra.Command {
+	var (
+		serviceName     string
+		selector        string
+		durationSeconds int32
+		agentID         string
+		format          string
+		overrideFreeze  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "runtime",
+		Short: "Trace GC pauses and scheduler latency of a Go service",
+		Long: `Trace the Go runtime of a service for a duration and print a runtime
+health report: stop-the-world pauses and their reasons, GC cycles, and how
+long goroutines waited to be scheduled. Pauses and GC cycles are correlated
+with the latency of the requests the service served in the same window,
+when its request spans are collected.
+
+The agent attaches uprobes to runtime functions of the service's binary, so
+no SDK or restart is needed.
+
+Examples:
+  coral debug runtime --service api --duration 60
+  coral debug runtime -s api --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := helpers.ResolveService(cmd.Context(), serviceName, selector)
+			if err != nil {
+				return err
+			}
+			serviceName = service
+
+			if durationSeconds <= 0 {
+				durationSeconds = 30 // Default 30 seconds
+			}
+			if durationSeconds > 300 {
+				return coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+					fmt.Errorf("duration cannot exceed 300 seconds"), "flag", "duration")
+			}
+
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "Tracing Go runtime of service '%s' (%ds)...\n", serviceName, durationSeconds)
+
+			ctx, cancel := context.WithTimeout(context.Background(),
+				time.Duration(durationSeconds+60)*time.Second)
+			defer cancel()
+
+			resp, err := client.TraceRuntime(ctx, connect.NewRequest(&colonypb.TraceRuntimeRequest{
+				ServiceName:     serviceName,
+				DurationSeconds: durationSeconds,
+				AgentId:         agentID,
+				OverrideFreeze:  overrideFreeze,
+			}))
+			if err != nil {
+				if coralerrors.CodeOf(err) == errorsv1.ErrorCode_ERROR_CODE_COLONY_UNREACHABLE {
+					return fmt.Errorf("colony is not reachable\n"+
+						"Please ensure the colony is running with: bin/coral colony start\n"+
+						"Original error: %w", err)
+				}
+				return fmt.Errorf("failed to trace Go runtime: %w", err)
+			}
+
+			if !resp.Msg.Success {
+				return coralerrors.FromInfo(resp.Msg.ErrorInfo, fmt.Errorf("failed to trace Go runtime: %s", resp.Msg.Error))
+			}
+
+			if format == "json" {
+				data, _ := json.MarshalIndent(resp.Msg, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+
+			printRuntimeReport(resp.Msg)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required without --selector)")
+	helpers.AddSelectorFlag(cmd, &selector)
+	cmd.Flags().Int32VarP(&durationSeconds, "duration", "d", 30, "Tracing duration in seconds (default: 30s, max: 300s)")
+	cmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (manual override)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	helpers.AddOverrideFreezeFlag(cmd, &overrideFreeze)
+
+	return cmd
+}
+
+// printRuntimeReport prints a runtime health report as text.
+func printRuntimeReport(report *colonypb.TraceRuntimeResponse) {
+	window := report.EndTime.AsTime().Sub(report.StartTime.AsTime())
+	fmt.Printf("Go runtime report for %s (%s)\n", report.ServiceName, report.GoVersion)
+	fmt.Printf("Window: %s, %s\n\n", report.StartTime.AsTime().Format(time.RFC3339), window.Round(time.Second))
+
+	// Pauses, by reason.
+	type reasonStats struct {
+		count      int
+		total, max time.Duration
+	}
+	var (
+		reasons       = make(map[string]*reasonStats)
+		total, maxDur time.Duration
+	)
+	for _, pause := range report.Pauses {
+		d := time.Duration(pause.DurationNs) // #nosec G115 -- pauses are short
+		stats := reasons[pause.Reason]
+		if stats == nil {
+			stats = &reasonStats{}
+			reasons[pause.Reason] = stats
+		}
+		stats.count++
+		stats.total += d
+		stats.max = max(stats.max, d)
+		total += d
+		maxDur = max(maxDur, d)
+	}
+	fmt.Printf("Stop-the-world pauses: %d (total %s, max %s)\n", len(report.Pauses), total, maxDur)
+	names := make([]string, 0, len(reasons))
+	for name := range reasons {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return reasons[names[i]].total > reasons[names[j]].total })
+	for _, name := range names {
+		stats := reasons[name]
+		fmt.Printf("  %-28s %5d  total %-12s max %s\n", name, stats.count, stats.total, stats.max)
+	}
+
+	// GC cycles.
+	if len(report.GcCycles) > 0 {
+		var cycleTotal, cyclePause time.Duration
+		for _, cycle := range report.GcCycles {
+			cycleTotal += time.Duration(cycle.DurationNs) // #nosec G115
+			cyclePause += time.Duration(cycle.PauseNs)    // #nosec G115
+		}
+		n := time.Duration(len(report.GcCycles))
+		fmt.Printf("\nGC cycles: %d (one every %s, avg duration %s, avg pause %s)\n",
+			len(report.GcCycles), (window / n).Round(time.Millisecond), cycleTotal/n, cyclePause/n)
+		fmt.Printf("  Time in GC: %.1f%% of the window\n", 100*float64(cycleTotal)/float64(window))
+	} else {
+		fmt.Printf("\nGC cycles: 0\n")
+	}
+
+	// Scheduling latency.
+	if len(report.SchedLatency) > 0 {
+		var count uint64
+		for _, bucket := range report.SchedLatency {
+			count += bucket.Count
+		}
+		quantile := func(q float64) time.Duration {
+			rank := uint64(q * float64(count))
+			var seen uint64
+			for _, bucket := range report.SchedLatency {
+				seen += bucket.Count
+				if seen > rank {
+					return time.Duration(bucket.UpperBoundNs) // #nosec G115
+				}
+			}
+			return time.Duration(report.SchedLatency[len(report.SchedLatency)-1].UpperBoundNs) // #nosec G115
+		}
+		fmt.Printf("\nGoroutine scheduling latency (%d wake-ups): p50 < %s, p99 < %s, max < %s\n",
+			count, quantile(0.50), quantile(0.99), quantile(1))
+	} else {
+		fmt.Printf("\nGoroutine scheduling latency: not measured\n")
+	}
+
+	// Correlation with requests.
+	requests := report.Requests
+	if requests == nil {
+		fmt.Printf("\nRequest latency: no request spans collected for this window\n")
+		return
+	}
+	us := func(v uint64) time.Duration { return time.Duration(v) * time.Microsecond } // #nosec G115
+	pct := func(part, whole uint64) float64 {
+		if whole == 0 {
+			return 0
+		}
+		return 100 * float64(part) / float64(whole)
+	}
+	fmt.Printf("\nRequest latency (%d requests): p50 %s, p99 %s\n", requests.Requests, us(requests.P50Us), us(requests.P99Us))
+	fmt.Printf("  Requests during GC:   %d (%.1f%%), p99 %s vs %s outside GC\n",
+		requests.RequestsDuringGc, pct(requests.RequestsDuringGc, requests.Requests),
+		us(requests.P99DuringGcUs), us(requests.P99OutsideGcUs))
+	fmt.Printf("  Slow requests (>p99): %d, %d (%.1f%%) during a GC cycle, %d (%.1f%%) spanning a pause\n",
+		requests.SlowRequests,
+		requests.SlowDuringGc, pct(requests.SlowDuringGc, requests.SlowRequests),
+		requests.SlowDuringPause, pct(requests.SlowDuringPause, requests.SlowRequests))
+}
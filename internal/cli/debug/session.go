@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
 )
 
@@ -170,6 +172,23 @@ func newSessionEventsCmd() *cobra.Command {
 					if format == "json" {
 						data, _ := json.Marshal(event)
 						fmt.Println(string(data))
+					} else if event.Http != nil {
+						fmt.Printf("[%s] %s %s status=%d duration=%s\n",
+							event.Timestamp.AsTime().Format(time.RFC3339),
+							event.Http.Method,
+							event.Http.Path,
+							event.Http.StatusCode,
+							time.Duration(event.DurationNs), // #nolint G115 - unlikely to ever overflow by design
+						)
+					} else if event.Tls != nil {
+						fmt.Printf("[%s] tls %s conn=%x %d bytes (%s): %s\n",
+							event.Timestamp.AsTime().Format(time.RFC3339),
+							event.Tls.Direction,
+							event.Tls.ConnectionId,
+							event.Tls.Length,
+							event.Tls.Library,
+							tlsSnippet(event.Tls),
+						)
 					} else {
 						fmt.Printf("[%s] %s duration=%s\n",
 							event.Timestamp.AsTime().Format(time.RFC3339),
@@ -403,3 +422,19 @@ Examples:
 
 	return cmd
 }
+
+// tlsSnippetBytes bounds the plaintext shown per TLS record in text output.
+const tlsSnippetBytes = 120
+
+// tlsSnippet quotes the start of a captured TLS record for text output.
+func tlsSnippet(data *agentv1.TlsData) string {
+	snippet := data.Data
+	if len(snippet) > tlsSnippetBytes {
+		snippet = snippet[:tlsSnippetBytes]
+	}
+	quoted := strconv.Quote(string(snippet))
+	if len(snippet) < len(data.Data) || data.Truncated {
+		quoted += "..."
+	}
+	return quoted
+}
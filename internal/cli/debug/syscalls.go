@@ -0,0 +1,190 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// NewSyscallsCmd creates the `coral debug syscalls` command.
+func NewSyscallsCmd() *cobra.Command {
+	var (
+		serviceName     string
+		selector        string
+		durationSeconds int32
+		filter          string
+		agentID         string
+		format          string
+		overrideFreeze  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "syscalls",
+		Short: "Count the syscalls of a service with their latency and errors",
+		Long: `Trace the syscalls of a service for a duration and print how often each
+was called, how long it took and which error codes it returned.
+
+The agent attaches eBPF programs to the sys_enter and sys_exit raw
+tracepoints, filtered in the kernel to the service's cgroup (its container
+or systemd service) or, when it has none of its own, to its process and
+descendants. Calls are aggregated in the kernel, so tracing a busy service
+is cheap.
+
+Examples:
+  coral debug syscalls --service api --duration 30 --filter 'openat,connect'
+  coral debug syscalls -s api --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := helpers.ResolveService(cmd.Context(), serviceName, selector)
+			if err != nil {
+				return err
+			}
+			serviceName = service
+
+			if durationSeconds <= 0 {
+				durationSeconds = 30 // Default 30 seconds
+			}
+			if durationSeconds > 300 {
+				return coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT,
+					fmt.Errorf("duration cannot exceed 300 seconds"), "flag", "duration")
+			}
+
+			var syscalls []string
+			for _, name := range strings.Split(filter, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					syscalls = append(syscalls, name)
+				}
+			}
+
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			what := "all syscalls"
+			if len(syscalls) > 0 {
+				what = strings.Join(syscalls, ", ")
+			}
+			fmt.Fprintf(os.Stderr, "Tracing %s of service '%s' (%ds)...\n", what, serviceName, durationSeconds)
+
+			ctx, cancel := context.WithTimeout(context.Background(),
+				time.Duration(durationSeconds+60)*time.Second)
+			defer cancel()
+
+			resp, err := client.TraceSyscalls(ctx, connect.NewRequest(&colonypb.TraceSyscallsRequest{
+				ServiceName:     serviceName,
+				DurationSeconds: durationSeconds,
+				Syscalls:        syscalls,
+				AgentId:         agentID,
+				OverrideFreeze:  overrideFreeze,
+			}))
+			if err != nil {
+				if coralerrors.CodeOf(err) == errorsv1.ErrorCode_ERROR_CODE_COLONY_UNREACHABLE {
+					return fmt.Errorf("colony is not reachable\n"+
+						"Please ensure the colony is running with: bin/coral colony start\n"+
+						"Original error: %w", err)
+				}
+				return fmt.Errorf("failed to trace syscalls: %w", err)
+			}
+
+			if !resp.Msg.Success {
+				return coralerrors.FromInfo(resp.Msg.ErrorInfo, fmt.Errorf("failed to trace syscalls: %s", resp.Msg.Error))
+			}
+
+			if format == "json" {
+				data, _ := json.MarshalIndent(resp.Msg, "", "  ")
+				fmt.Println(string(data))
+				return nil
+			}
+
+			return printSyscallReport(resp.Msg)
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required without --selector)")
+	helpers.AddSelectorFlag(cmd, &selector)
+	cmd.Flags().Int32VarP(&durationSeconds, "duration", "d", 30, "Tracing duration in seconds (default: 30s, max: 300s)")
+	cmd.Flags().StringVar(&filter, "filter", "", "Comma-separated syscalls to trace, e.g. 'openat,connect' (default: all)")
+	cmd.Flags().StringVar(&agentID, "agent-id", "", "Agent ID (manual override)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	helpers.AddOverrideFreezeFlag(cmd, &overrideFreeze)
+
+	return cmd
+}
+
+// printSyscallReport prints a syscall report as a table, the syscalls taking
+// the most time first.
+func printSyscallReport(report *colonypb.TraceSyscallsResponse) error {
+	window := report.EndTime.AsTime().Sub(report.StartTime.AsTime())
+	fmt.Printf("Syscalls of %s (%s)\n", report.ServiceName, report.Target)
+	fmt.Printf("Window: %s, %s\n\n", report.StartTime.AsTime().Format(time.RFC3339), window.Round(time.Second))
+
+	if len(report.Syscalls) == 0 {
+		fmt.Println("No syscalls traced.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "SYSCALL\tCALLS\tERRORS\tTOTAL\tAVG\tP99\tTOP ERRORS"); err != nil {
+		return err
+	}
+	for _, sc := range report.Syscalls {
+		var failed uint64
+		top := make([]string, 0, 3)
+		for _, e := range sc.Errors {
+			failed += e.Count
+			if len(top) < cap(top) {
+				top = append(top, fmt.Sprintf("%s %d", e.Name, e.Count))
+			}
+		}
+
+		errs := "0"
+		if failed > 0 {
+			errs = fmt.Sprintf("%d (%.1f%%)", failed, 100*float64(failed)/float64(sc.Count))
+		}
+		total := time.Duration(sc.TotalNs) // #nosec G115 -- durations are positive
+		var avg time.Duration
+		if sc.Count > 0 {
+			avg = total / time.Duration(sc.Count) // #nosec G115
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t< %s\t%s\n",
+			sc.Name, sc.Count, errs, total, avg, latencyQuantile(sc.Latency, 0.99), strings.Join(top, ", ")); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// latencyQuantile returns the upper bound of the log2 bucket holding the
+// q-th quantile of buckets, sorted by bound.
+func latencyQuantile(buckets []*agentv1.LatencyBucket, q float64) time.Duration {
+	if len(buckets) == 0 {
+		return 0
+	}
+	var count uint64
+	for _, bucket := range buckets {
+		count += bucket.Count
+	}
+	rank := uint64(q * float64(count))
+	var seen uint64
+	for _, bucket := range buckets {
+		seen += bucket.Count
+		if seen > rank {
+			return time.Duration(bucket.UpperBoundNs) // #nosec G115
+		}
+	}
+	return time.Duration(buckets[len(buckets)-1].UpperBoundNs) // #nosec G115
+}
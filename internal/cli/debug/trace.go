@@ -11,6 +11,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
 )
 
 func NewTraceCmd() *cobra.Command {
@@ -18,15 +19,19 @@ func NewTraceCmd() *cobra.Command {
 		path     string
 		duration time.Duration
 		format   string
+		selector string
 		wait     bool
 	)
 
 	cmd := &cobra.Command{
-		Use:   "trace <service>",
+		Use:   "trace [service]",
 		Short: "Trace request path",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			serviceName := args[0]
+			serviceName, err := serviceArg(cmd.Context(), args, selector)
+			if err != nil {
+				return err
+			}
 			ctx := context.Background()
 
 			// Create Colony client
@@ -132,6 +137,7 @@ func NewTraceCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&path, "path", "p", "", "HTTP path to trace (required)")
 	cmd.Flags().DurationVarP(&duration, "duration", "d", 60*time.Second, "Duration of the trace session")
 	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json, csv)")
+	helpers.AddSelectorFlag(cmd, &selector)
 	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for trace to complete and display results")
 
 	if err := cmd.MarkFlagRequired("path"); err != nil {
@@ -22,6 +22,9 @@ Examples:
   # Interactive shell (single agent)
   coral duckdb shell agent-prod-1
 
+  # SQL REPL against the colony database
+  coral duckdb sql
+
   # One-shot query
   coral duckdb query agent-prod-1 "SELECT * FROM beyla_http_metrics_local LIMIT 10"
 
@@ -35,6 +38,9 @@ For more information, see: https://github.com/coral-mesh/coral/blob/main/RFDs/03
 	cmd.AddCommand(NewListAgentsCmd())
 	cmd.AddCommand(NewQueryCmd())
 	cmd.AddCommand(NewShellCmd())
+	cmd.AddCommand(NewSQLCmd())
+	cmd.AddCommand(NewExportCmd())
+	cmd.AddCommand(NewImportCmd())
 
 	return cmd
 }
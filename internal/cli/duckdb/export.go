@@ -0,0 +1,352 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// identifierPattern matches table names accepted by export and import.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// timeColumns are the columns used for --since filtering, in order of
+// preference.
+var timeColumns = []string{"timestamp", "bucket_time", "start_time", "created_at"}
+
+// NewExportCmd creates the export subcommand for dumping a table to Parquet
+// or CSV.
+func NewExportCmd() *cobra.Command {
+	var (
+		table    string
+		format   string
+		since    string
+		out      string
+		database string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export [agent-id]",
+		Short: "Export a colony or agent table to Parquet or CSV",
+		Long: `Export a table to a Parquet (default) or CSV file using DuckDB's native
+COPY support, for archival to object storage or offline analysis.
+
+Without an agent ID, the table is read from the colony database; the colony
+can keep running. --since keeps only rows newer than the given age, using the
+table's timestamp, bucket_time, start_time or created_at column.
+
+Examples:
+  # Last 7 days of HTTP metrics from the colony
+  coral duckdb export --table beyla_http_metrics --since 7d
+
+  # Full table to a chosen file
+  coral duckdb export --table otel_summaries --out /archive/otel.parquet
+
+  # Agent-local spans as CSV
+  coral duckdb export agent-prod-1 --table otel_spans_local --format csv
+
+Load an export back with 'coral duckdb import'.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !identifierPattern.MatchString(table) {
+				return fmt.Errorf("invalid table name %q", table)
+			}
+			if format != "parquet" && format != "csv" {
+				return fmt.Errorf("invalid format: %s (must be parquet or csv)", format)
+			}
+			var cutoff time.Time
+			if since != "" {
+				d, err := helpers.ParseSince(since)
+				if err != nil {
+					return fmt.Errorf("invalid --since: %w", err)
+				}
+				cutoff = time.Now().Add(-d)
+			}
+			if out == "" {
+				out = fmt.Sprintf("%s-%s.%s", table, time.Now().UTC().Format("20060102T150405Z"), format)
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Minute)
+			defer cancel()
+
+			db, err := createDuckDBConnection(ctx)
+			if err != nil {
+				return err
+			}
+			defer func() { _ = db.Close() }()
+
+			catalog, err := attachForExport(ctx, db, args, database)
+			if err != nil {
+				return err
+			}
+
+			rows, err := exportTable(ctx, db, catalog, table, cutoff, format, out)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ Exported %d rows from %s to %s\n", rows, table, out)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&table, "table", "t", "", "Table to export")
+	cmd.Flags().StringVarP(&format, "format", "f", "parquet", "Output format (parquet, csv)")
+	cmd.Flags().StringVar(&since, "since", "", "Only export rows newer than this age (e.g. 1h, 7d, 2w)")
+	cmd.Flags().StringVarP(&out, "out", "o", "", "Output file (default: <table>-<time>.<format>)")
+	cmd.Flags().StringVarP(&database, "database", "d", "", "Database name (e.g., metrics.duckdb)")
+	_ = cmd.MarkFlagRequired("table")
+
+	return cmd
+}
+
+// attachForExport attaches the colony database, or the agent database when an
+// agent ID is given, and returns its catalog alias.
+func attachForExport(ctx context.Context, db *sql.DB, args []string, dbName string) (string, error) {
+	if len(args) == 0 {
+		if dbName == "" {
+			databases, err := listColonyDatabases(ctx)
+			if err != nil {
+				return "", fmt.Errorf("failed to query available databases: %w", err)
+			}
+			if len(databases) == 0 {
+				return "", fmt.Errorf("colony has no available databases")
+			}
+			dbName = databases[0]
+		}
+		if err := attachColonyDatabase(ctx, db, dbName); err != nil {
+			return "", err
+		}
+		return "colony", nil
+	}
+
+	agentID := args[0]
+	agentBase, err := agentDuckDBBase(ctx, agentID, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve agent address: %w", err)
+	}
+	if dbName == "" {
+		databases, err := listAgentDatabases(ctx, agentBase)
+		if err != nil {
+			return "", fmt.Errorf("failed to query available databases: %w", err)
+		}
+		if len(databases) == 0 {
+			return "", fmt.Errorf("agent %s has no available databases", agentID)
+		}
+		dbName = databases[0]
+	}
+	if err := attachAgentDatabase(ctx, db, agentID, agentBase, dbName); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("agent_%s", sanitizeAgentID(agentID)), nil
+}
+
+// exportTable copies catalog.table, optionally limited to rows newer than
+// cutoff, to a file and returns the number of rows written.
+func exportTable(ctx context.Context, db *sql.DB, catalog, table string, cutoff time.Time, format, out string) (int64, error) {
+	columns, err := tableColumns(ctx, db, catalog, table)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s.%s", catalog, table)
+	if !cutoff.IsZero() {
+		timeColumn := ""
+		for _, c := range timeColumns {
+			if columns[c] {
+				timeColumn = c
+				break
+			}
+		}
+		if timeColumn == "" {
+			return 0, fmt.Errorf("table %s has no time column for --since (%s)", table, strings.Join(timeColumns, ", "))
+		}
+		query += fmt.Sprintf(" WHERE %s >= TIMESTAMPTZ '%s'", timeColumn, cutoff.UTC().Format("2006-01-02 15:04:05.999999+00"))
+	}
+
+	copySQL := fmt.Sprintf("COPY (%s) TO '%s' (FORMAT %s)", query, escapeLiteral(out), strings.ToUpper(format))
+	result, err := db.ExecContext(ctx, copySQL)
+	if err != nil {
+		_ = os.Remove(out)
+		return 0, fmt.Errorf("failed to export %s: %w", table, err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows, nil
+}
+
+// tableColumns returns the columns of catalog.table, failing if it does not
+// exist.
+func tableColumns(ctx context.Context, db *sql.DB, catalog, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT column_name FROM information_schema.columns WHERE table_catalog = ? AND table_name = ?`,
+		catalog, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		columns[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("table %s not found", table)
+	}
+	return columns, nil
+}
+
+// NewImportCmd creates the import subcommand for loading Parquet or CSV
+// exports.
+func NewImportCmd() *cobra.Command {
+	var (
+		table    string
+		into     string
+		colonyID string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import a Parquet or CSV export into a DuckDB database",
+		Long: `Import a file written by 'coral duckdb export' (Parquet or CSV, by extension).
+
+By default rows are inserted into the table of the local colony database,
+which requires the colony to be stopped; rows already present (same primary
+key) are skipped. With --into, rows are loaded into any DuckDB file instead,
+creating it and the table as needed, e.g. for offline analysis.
+
+Examples:
+  # Restore archived metrics into the (stopped) colony
+  coral duckdb import beyla_http_metrics-20260101T000000Z.parquet --table beyla_http_metrics
+
+  # Load an archive into a scratch database for analysis
+  coral duckdb import otel.parquet --table otel_summaries --into analysis.duckdb`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			if !identifierPattern.MatchString(table) {
+				return fmt.Errorf("invalid table name %q", table)
+			}
+			if _, err := os.Stat(path); err != nil {
+				return err
+			}
+
+			target := into
+			if target == "" {
+				dbPath, err := colonyDatabasePath(colonyID)
+				if err != nil {
+					return err
+				}
+				if _, err := os.Stat(dbPath); err != nil {
+					return fmt.Errorf("colony database not found at %s: %w", dbPath, err)
+				}
+				target = dbPath
+			}
+
+			db, err := duckdb.OpenDB(target)
+			if err != nil {
+				return fmt.Errorf("failed to open %s: %w", target, err)
+			}
+			defer func() { _ = db.Close() }()
+			if err := db.PingContext(cmd.Context()); err != nil {
+				return fmt.Errorf("failed to open %s (stop the colony before importing into its database): %w", target, err)
+			}
+
+			rows, err := importFile(cmd.Context(), db, table, path, into != "")
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("✓ Imported %d rows into %s (%s)\n", rows, table, target)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&table, "table", "t", "", "Table to import into")
+	cmd.Flags().StringVar(&into, "into", "", "DuckDB file to import into instead of the colony database")
+	helpers.AddColonyFlag(cmd, &colonyID)
+	_ = cmd.MarkFlagRequired("table")
+
+	return cmd
+}
+
+// colonyDatabasePath returns the path of the local colony database.
+func colonyDatabasePath(colonyID string) (string, error) {
+	resolver, err := config.NewResolver()
+	if err != nil {
+		return "", fmt.Errorf("failed to create config resolver: %w", err)
+	}
+	if colonyID == "" {
+		colonyID, err = resolver.ResolveColonyID()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve colony: %w", err)
+		}
+	}
+	cfg, err := resolver.ResolveConfig(colonyID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load colony config: %w", err)
+	}
+	return filepath.Join(cfg.StoragePath, colonyID+".duckdb"), nil
+}
+
+// importFile inserts the rows of a Parquet or CSV file into table, matching
+// columns by name and skipping rows that conflict with existing keys. With
+// create, a missing table is created from the file's schema. It returns the
+// number of rows inserted.
+func importFile(ctx context.Context, db *sql.DB, table, path string, create bool) (int64, error) {
+	var source string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".parquet":
+		source = fmt.Sprintf("read_parquet('%s')", escapeLiteral(path))
+	case ".csv":
+		source = fmt.Sprintf("read_csv_auto('%s')", escapeLiteral(path))
+	default:
+		return 0, fmt.Errorf("unsupported file type %q (expected .parquet or .csv)", filepath.Ext(path))
+	}
+
+	var exists int
+	if err := db.QueryRowContext(ctx,
+		`SELECT count(*) FROM information_schema.tables WHERE table_name = ?`, table,
+	).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("failed to check table %s: %w", table, err)
+	}
+
+	var query string
+	switch {
+	case exists > 0:
+		query = fmt.Sprintf("INSERT OR IGNORE INTO %s BY NAME SELECT * FROM %s", table, source)
+	case create:
+		query = fmt.Sprintf("CREATE TABLE %s AS SELECT * FROM %s", table, source)
+	default:
+		return 0, errors.New("table " + table + " does not exist")
+	}
+
+	result, err := db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to import %s: %w", path, err)
+	}
+	rows, _ := result.RowsAffected()
+	return rows, nil
+}
+
+// escapeLiteral escapes s for use in a single-quoted SQL string literal.
+func escapeLiteral(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
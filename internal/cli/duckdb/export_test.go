@@ -0,0 +1,103 @@
+package duckdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Source database standing in for the attached colony database.
+	srcPath := filepath.Join(dir, "colony.duckdb")
+	src, err := duckdb.OpenDB(srcPath)
+	require.NoError(t, err)
+	_, err = src.Exec(`
+		CREATE TABLE beyla_http_metrics (timestamp TIMESTAMPTZ, service_name VARCHAR, count BIGINT, PRIMARY KEY (timestamp, service_name));
+		CREATE TABLE system_metrics_summaries (bucket_time TIMESTAMP, metric_name VARCHAR);
+	`)
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	for i, age := range []time.Duration{10 * 24 * time.Hour, 2 * 24 * time.Hour, time.Hour} {
+		_, err = src.Exec(`INSERT INTO beyla_http_metrics VALUES (?, ?, ?)`, now.Add(-age), []string{"api", "api", "web"}[i], i+1)
+		require.NoError(t, err)
+		_, err = src.Exec(`INSERT INTO system_metrics_summaries VALUES (?, 'cpu')`, now.Add(-age))
+		require.NoError(t, err)
+	}
+	require.NoError(t, src.Close())
+
+	db, err := duckdb.OpenDB("")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	_, err = db.Exec("ATTACH '" + srcPath + "' AS colony (READ_ONLY)")
+	require.NoError(t, err)
+
+	for _, format := range []string{"parquet", "csv"} {
+		t.Run(format, func(t *testing.T) {
+			out := filepath.Join(dir, "export."+format)
+			rows, err := exportTable(ctx, db, "colony", "beyla_http_metrics", time.Now().Add(-7*24*time.Hour), format, out)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), rows)
+
+			// Import into a fresh database, creating the table.
+			dst, err := duckdb.OpenDB(filepath.Join(dir, "analysis-"+format+".duckdb"))
+			require.NoError(t, err)
+			defer func() { _ = dst.Close() }()
+
+			rows, err = importFile(ctx, dst, "beyla_http_metrics", out, true)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), rows)
+
+			var total int64
+			require.NoError(t, dst.QueryRow(`SELECT sum(count) FROM beyla_http_metrics`).Scan(&total))
+			assert.Equal(t, int64(5), total)
+		})
+	}
+
+	// --since also applies to TIMESTAMP columns.
+	rows, err := exportTable(ctx, db, "colony", "system_metrics_summaries", now.Add(-3*time.Hour), "parquet", filepath.Join(dir, "system.parquet"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	// Re-importing into a keyed table skips rows already present.
+	dst, err := duckdb.OpenDB(filepath.Join(dir, "keyed.duckdb"))
+	require.NoError(t, err)
+	defer func() { _ = dst.Close() }()
+	_, err = dst.Exec(`CREATE TABLE beyla_http_metrics (timestamp TIMESTAMPTZ, service_name VARCHAR, count BIGINT, PRIMARY KEY (timestamp, service_name))`)
+	require.NoError(t, err)
+
+	out := filepath.Join(dir, "export.parquet")
+	rows, err = importFile(ctx, dst, "beyla_http_metrics", out, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rows)
+	rows, err = importFile(ctx, dst, "beyla_http_metrics", out, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func TestExportTable_Errors(t *testing.T) {
+	ctx := context.Background()
+	db, err := duckdb.OpenDB("")
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	_, err = db.Exec(`CREATE TABLE services (id VARCHAR)`)
+	require.NoError(t, err)
+
+	out := filepath.Join(t.TempDir(), "out.parquet")
+	_, err = exportTable(ctx, db, "memory", "missing", time.Time{}, "parquet", out)
+	assert.ErrorContains(t, err, "not found")
+
+	_, err = exportTable(ctx, db, "memory", "services", time.Now(), "parquet", out)
+	assert.ErrorContains(t, err, "no time column")
+
+	_, err = importFile(ctx, db, "services", filepath.Join(t.TempDir(), "x.json"), false)
+	assert.ErrorContains(t, err, "unsupported file type")
+}
@@ -0,0 +1,409 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/chzyer/readline"
+	"github.com/spf13/cobra"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// errExitREPL is returned by meta-commands that end the SQL REPL.
+var errExitREPL = errors.New("exit")
+
+// NewSQLCmd creates the sql subcommand, an interactive SQL REPL against the
+// colony, an agent or local database files.
+func NewSQLCmd() *cobra.Command {
+	var (
+		files    []string
+		database string
+		format   string
+		maxRows  int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sql [agent-id]",
+		Short: "Interactive SQL REPL against the colony, an agent or local DuckDB files",
+		Long: `Open an interactive SQL REPL for ad-hoc exploration.
+
+Without arguments, queries run on the colony database through the colony API,
+which also works for remote colonies. With an agent ID, the agent database is
+attached over HTTP as in 'coral duckdb shell'. With --file, local DuckDB
+files (e.g. a stopped colony's database or a backup) are attached read-only.
+
+Queries end with a semicolon and may span multiple lines.
+
+Meta-commands:
+  \dt [pattern]   List tables (optionally matching a LIKE pattern)
+  \d <table>      Describe a table's columns
+  \l              List databases
+  \f [format]     Show or set the output format (table, csv, json)
+  \?              Show help
+  \q              Quit (or Ctrl+D)
+
+Examples:
+  # Colony database through the colony API
+  coral duckdb sql
+
+  # Agent database
+  coral duckdb sql agent-prod-1 -d metrics.duckdb
+
+  # Local database files
+  coral duckdb sql --file ~/.coral/data/my-colony.duckdb
+
+  # Start with CSV output
+  coral duckdb sql --format csv`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isValidFormat(format) {
+				return fmt.Errorf("invalid format: %s (must be table, csv, or json)", format)
+			}
+			if len(files) > 0 && len(args) > 0 {
+				return fmt.Errorf("--file cannot be combined with an agent ID")
+			}
+
+			ctx := cmd.Context()
+			var backend sqlBackend
+			switch {
+			case len(files) > 0:
+				db, err := openLocalFiles(ctx, files)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = db.Close() }()
+				backend = &dbBackend{db: db}
+
+			case len(args) == 1:
+				db, err := createDuckDBConnection(ctx)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = db.Close() }()
+				attached, err := attachDatabases(ctx, db, args, database)
+				if err != nil {
+					return err
+				}
+				if _, err := db.ExecContext(ctx, "USE "+attached[0]); err != nil {
+					return fmt.Errorf("failed to select database %s: %w", attached[0], err)
+				}
+				backend = &dbBackend{db: db}
+
+			default:
+				client, err := helpers.GetColonyClient("")
+				if err != nil {
+					return fmt.Errorf("failed to create colony client: %w", err)
+				}
+				backend = &rpcBackend{client: client, maxRows: maxRows}
+			}
+
+			return runSQLREPL(ctx, backend, format)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&files, "file", nil, "Local DuckDB file to attach read-only (repeatable)")
+	cmd.Flags().StringVarP(&database, "database", "d", "", "Agent database name (e.g., metrics.duckdb)")
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Output format (table, csv, json)")
+	cmd.Flags().Int32Var(&maxRows, "max-rows", 1000, "Maximum rows returned per query through the colony API")
+
+	return cmd
+}
+
+// resultRows is the subset of *sql.Rows used to print results.
+type resultRows interface {
+	Next() bool
+	Scan(dest ...interface{}) error
+	Close() error
+}
+
+// sqlBackend executes the queries of the SQL REPL.
+type sqlBackend interface {
+	Query(ctx context.Context, query string) (resultRows, []string, error)
+}
+
+// dbBackend runs queries on a local DuckDB connection with attached
+// databases.
+type dbBackend struct {
+	db *sql.DB
+}
+
+func (b *dbBackend) Query(ctx context.Context, query string) (resultRows, []string, error) {
+	rows, err := b.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		_ = rows.Close()
+		return nil, nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+	return rows, columns, nil
+}
+
+// colonyQueryClient is the part of the colony client used by rpcBackend.
+type colonyQueryClient interface {
+	ExecuteQuery(context.Context, *connect.Request[colonypb.ExecuteQueryRequest]) (*connect.Response[colonypb.ExecuteQueryResponse], error)
+}
+
+// rpcBackend runs queries on the colony database through the ExecuteQuery
+// RPC. Values are returned as strings.
+type rpcBackend struct {
+	client  colonyQueryClient
+	maxRows int32
+}
+
+func (b *rpcBackend) Query(ctx context.Context, query string) (resultRows, []string, error) {
+	resp, err := b.client.ExecuteQuery(ctx, connect.NewRequest(&colonypb.ExecuteQueryRequest{
+		Sql:     query,
+		MaxRows: b.maxRows,
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := &stringRows{pos: -1}
+	for _, row := range resp.Msg.Rows {
+		rows.values = append(rows.values, row.Values)
+	}
+	if resp.Msg.RowCount == b.maxRows {
+		fmt.Fprintf(os.Stderr, "(results limited to %d rows, see --max-rows)\n", b.maxRows)
+	}
+	return rows, resp.Msg.Columns, nil
+}
+
+// stringRows iterates over rows of string values.
+type stringRows struct {
+	values [][]string
+	pos    int
+}
+
+func (r *stringRows) Next() bool {
+	r.pos++
+	return r.pos < len(r.values)
+}
+
+func (r *stringRows) Scan(dest ...interface{}) error {
+	row := r.values[r.pos]
+	if len(dest) != len(row) {
+		return fmt.Errorf("expected %d destinations, got %d", len(row), len(dest))
+	}
+	for i, d := range dest {
+		p, ok := d.(*interface{})
+		if !ok {
+			return fmt.Errorf("unsupported scan destination %T", d)
+		}
+		*p = row[i]
+	}
+	return nil
+}
+
+func (r *stringRows) Close() error {
+	return nil
+}
+
+// openLocalFiles attaches DuckDB files read-only to an in-memory connection,
+// each under its file name without extension, and selects the first one.
+func openLocalFiles(ctx context.Context, files []string) (*sql.DB, error) {
+	db, err := duckdb.OpenDB("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open DuckDB: %w", err)
+	}
+
+	var first string
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		alias := sanitizeAgentID(strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+		attachSQL := fmt.Sprintf("ATTACH '%s' AS %s (READ_ONLY)", escapeLiteral(file), alias)
+		if _, err := db.ExecContext(ctx, attachSQL); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to attach %s: %w", file, err)
+		}
+		if first == "" {
+			first = alias
+		}
+	}
+
+	if _, err := db.ExecContext(ctx, "USE "+first); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to select database %s: %w", first, err)
+	}
+	return db, nil
+}
+
+// runSQLREPL reads queries and meta-commands until \q or EOF.
+func runSQLREPL(ctx context.Context, backend sqlBackend, format string) error {
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "sql> ",
+		HistoryFile:     os.ExpandEnv("$HOME/.coral/duckdb_sql_history"),
+		InterruptPrompt: "^C",
+		EOFPrompt:       `\q`,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize readline: %w", err)
+	}
+	defer func() { _ = rl.Close() }()
+
+	fmt.Println(`Coral SQL. End queries with ';'. Type \? for help, \q to quit.`)
+	fmt.Println()
+
+	var buffer strings.Builder
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			buffer.Reset()
+			rl.SetPrompt("sql> ")
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			fmt.Println()
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("readline error: %w", err)
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		// Meta-commands are only recognized at the start of a query.
+		if buffer.Len() == 0 && strings.HasPrefix(line, `\`) {
+			if err := handleSQLMetaCommand(ctx, backend, line, &format); err != nil {
+				if errors.Is(err, errExitREPL) {
+					return nil
+				}
+				fmt.Printf("Error: %v\n", err)
+			}
+			continue
+		}
+
+		if buffer.Len() > 0 {
+			buffer.WriteString("\n")
+		}
+		buffer.WriteString(line)
+		if !strings.HasSuffix(line, ";") {
+			rl.SetPrompt("  ..> ")
+			continue
+		}
+
+		query := buffer.String()
+		buffer.Reset()
+		rl.SetPrompt("sql> ")
+		if err := runSQLQuery(ctx, backend, query, format); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	}
+}
+
+// handleSQLMetaCommand runs a backslash meta-command.
+func handleSQLMetaCommand(ctx context.Context, backend sqlBackend, line string, format *string) error {
+	parts := strings.Fields(line)
+	switch parts[0] {
+	case `\q`:
+		return errExitREPL
+
+	case `\?`:
+		fmt.Println(`Meta-commands:
+  \dt [pattern]   List tables (optionally matching a LIKE pattern)
+  \d <table>      Describe a table's columns
+  \l              List databases
+  \f [format]     Show or set the output format (table, csv, json)
+  \?              Show this help
+  \q              Quit`)
+		return nil
+
+	case `\dt`:
+		query := `SELECT database_name, schema_name, table_name, estimated_size AS rows
+FROM duckdb_tables() WHERE NOT internal`
+		if len(parts) > 1 {
+			query += fmt.Sprintf(" AND table_name LIKE '%s'", escapeLiteral(parts[1]))
+		}
+		return runSQLQuery(ctx, backend, query+" ORDER BY 1, 2, 3", *format)
+
+	case `\d`:
+		if len(parts) != 2 {
+			return fmt.Errorf(`usage: \d <table>`)
+		}
+		return runSQLQuery(ctx, backend, describeQuery(parts[1]), *format)
+
+	case `\l`:
+		return runSQLQuery(ctx, backend,
+			`SELECT database_name, path, readonly FROM duckdb_databases() WHERE NOT internal ORDER BY 1`, *format)
+
+	case `\f`:
+		if len(parts) == 1 {
+			fmt.Printf("Output format: %s\n", *format)
+			return nil
+		}
+		if !isValidFormat(parts[1]) {
+			return fmt.Errorf("invalid format: %s (must be table, csv, or json)", parts[1])
+		}
+		*format = parts[1]
+		fmt.Printf("Output format: %s\n", *format)
+		return nil
+
+	default:
+		return fmt.Errorf(`unknown meta-command: %s (try \?)`, parts[0])
+	}
+}
+
+// describeQuery returns the query listing the columns of table, which may be
+// qualified as database.table or database.schema.table.
+func describeQuery(table string) string {
+	parts := strings.Split(table, ".")
+	conds := []string{fmt.Sprintf("table_name = '%s'", escapeLiteral(parts[len(parts)-1]))}
+	switch len(parts) {
+	case 2:
+		conds = append(conds, fmt.Sprintf("table_catalog = '%s'", escapeLiteral(parts[0])))
+	case 3:
+		conds = append(conds,
+			fmt.Sprintf("table_catalog = '%s'", escapeLiteral(parts[0])),
+			fmt.Sprintf("table_schema = '%s'", escapeLiteral(parts[1])))
+	}
+	return fmt.Sprintf(`SELECT column_name, data_type, is_nullable
+FROM information_schema.columns WHERE %s ORDER BY table_catalog, ordinal_position`, strings.Join(conds, " AND "))
+}
+
+// runSQLQuery executes query and prints its results in format.
+func runSQLQuery(ctx context.Context, backend sqlBackend, query, format string) error {
+	start := time.Now()
+	rows, columns, err := backend.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	switch format {
+	case "csv":
+		err = printResultsAsCSV(rows, columns)
+	case "json":
+		err = printResultsAsJSON(rows, columns)
+	default:
+		err = printResultsAsTable(rows, columns)
+	}
+	if err != nil {
+		return err
+	}
+	if format == "table" {
+		fmt.Printf("Time: %s\n\n", time.Since(start).Round(time.Millisecond))
+	}
+	return nil
+}
+
+func isValidFormat(format string) bool {
+	return format == "table" || format == "csv" || format == "json"
+}
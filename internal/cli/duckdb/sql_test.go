@@ -0,0 +1,118 @@
+package duckdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// queryAll runs query on backend and returns its columns and rows.
+func queryAll(t *testing.T, backend sqlBackend, query string) ([]string, [][]interface{}) {
+	t.Helper()
+	rows, columns, err := backend.Query(context.Background(), query)
+	require.NoError(t, err)
+	defer func() { _ = rows.Close() }()
+
+	var result [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		ptrs := make([]interface{}, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		require.NoError(t, rows.Scan(ptrs...))
+		result = append(result, values)
+	}
+	return columns, result
+}
+
+func TestSQL_LocalFiles(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "my-colony.duckdb")
+
+	src, err := duckdb.OpenDB(path)
+	require.NoError(t, err)
+	_, err = src.Exec(`
+		CREATE TABLE services (name VARCHAR NOT NULL, port INTEGER);
+		INSERT INTO services VALUES ('api', 8080), ('web', 3000);
+	`)
+	require.NoError(t, err)
+	require.NoError(t, src.Close())
+
+	db, err := openLocalFiles(ctx, []string{path})
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	backend := &dbBackend{db: db}
+
+	// The single attached file is selected, so table names need no prefix.
+	columns, rows := queryAll(t, backend, "SELECT name, port FROM services ORDER BY port")
+	assert.Equal(t, []string{"name", "port"}, columns)
+	assert.Equal(t, [][]interface{}{{"web", int32(3000)}, {"api", int32(8080)}}, rows)
+
+	// Attached read-only.
+	_, _, err = backend.Query(ctx, "INSERT INTO services VALUES ('db', 5432)")
+	assert.Error(t, err)
+
+	// \d accepts qualified names.
+	_, rows = queryAll(t, backend, describeQuery("my_colony.services"))
+	require.Len(t, rows, 2)
+	assert.Equal(t, []interface{}{"name", "VARCHAR", "NO"}, rows[0])
+
+	_, rows = queryAll(t, backend, describeQuery("missing"))
+	assert.Empty(t, rows)
+
+	format := "csv"
+	for _, meta := range []string{`\dt`, `\dt serv%`, `\l`, `\d services`} {
+		assert.NoError(t, handleSQLMetaCommand(ctx, backend, meta, &format), meta)
+	}
+
+	_, err = openLocalFiles(ctx, []string{filepath.Join(t.TempDir(), "missing.duckdb")})
+	assert.Error(t, err)
+}
+
+type fakeQueryClient struct {
+	req  *colonypb.ExecuteQueryRequest
+	resp *colonypb.ExecuteQueryResponse
+}
+
+func (c *fakeQueryClient) ExecuteQuery(_ context.Context, req *connect.Request[colonypb.ExecuteQueryRequest]) (*connect.Response[colonypb.ExecuteQueryResponse], error) {
+	c.req = req.Msg
+	return connect.NewResponse(c.resp), nil
+}
+
+func TestSQL_RPCBackend(t *testing.T) {
+	client := &fakeQueryClient{resp: &colonypb.ExecuteQueryResponse{
+		Columns: []string{"service_name", "count"},
+		Rows: []*colonypb.QueryRow{
+			{Values: []string{"api", "42"}},
+			{Values: []string{"web", "7"}},
+		},
+		RowCount: 2,
+	}}
+	backend := &rpcBackend{client: client, maxRows: 100}
+
+	columns, rows := queryAll(t, backend, "SELECT service_name, count FROM x")
+	assert.Equal(t, "SELECT service_name, count FROM x", client.req.Sql)
+	assert.Equal(t, int32(100), client.req.MaxRows)
+	assert.Equal(t, []string{"service_name", "count"}, columns)
+	assert.Equal(t, [][]interface{}{{"api", "42"}, {"web", "7"}}, rows)
+}
+
+func TestSQL_FormatMetaCommand(t *testing.T) {
+	format := "table"
+	require.NoError(t, handleSQLMetaCommand(context.Background(), nil, `\f csv`, &format))
+	assert.Equal(t, "csv", format)
+
+	assert.Error(t, handleSQLMetaCommand(context.Background(), nil, `\f xml`, &format))
+	assert.Equal(t, "csv", format)
+
+	assert.ErrorIs(t, handleSQLMetaCommand(context.Background(), nil, `\q`, &format), errExitREPL)
+	assert.Error(t, handleSQLMetaCommand(context.Background(), nil, `\x`, &format))
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// errorJSON is the JSON-serializable error written with --error-format json.
+type errorJSON struct {
+	Code     string            `json:"code"`
+	Message  string            `json:"message"`
+	ExitCode int               `json:"exit_code"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ReportError writes err to w in the format selected by the global
+// --error-format flag and returns the exit code of err in the error taxonomy.
+func ReportError(w io.Writer, err error) int {
+	exitCode := coralerrors.ExitCode(err)
+	if globalErrorFormat != "json" {
+		_, _ = fmt.Fprintf(w, "Error: %v\n", err)
+		return exitCode
+	}
+
+	code, metadata := coralerrors.Classify(err)
+	data, marshalErr := json.Marshal(struct {
+		Error errorJSON `json:"error"`
+	}{errorJSON{
+		Code:     coralerrors.CodeName(code),
+		Message:  err.Error(),
+		ExitCode: exitCode,
+		Metadata: metadata,
+	}})
+	if marshalErr != nil {
+		_, _ = fmt.Fprintf(w, "Error: %v\n", err)
+		return exitCode
+	}
+	_, _ = fmt.Fprintln(w, string(data))
+	return exitCode
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+func TestReportError(t *testing.T) {
+	defer func() { globalErrorFormat = "text" }()
+
+	err := fmt.Errorf("failed to attach uprobe: %w", coralerrors.New(
+		errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND, errors.New("service not found"), "service", "api"))
+
+	globalErrorFormat = "text"
+	var buf bytes.Buffer
+	assert.Equal(t, 8, ReportError(&buf, err))
+	assert.Equal(t, "Error: failed to attach uprobe: service not found\n", buf.String())
+
+	globalErrorFormat = "json"
+	buf.Reset()
+	assert.Equal(t, 8, ReportError(&buf, err))
+	var out struct {
+		Error errorJSON `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, errorJSON{
+		Code:     "SERVICE_NOT_FOUND",
+		Message:  "failed to attach uprobe: service not found",
+		ExitCode: 8,
+		Metadata: map[string]string{"service": "api"},
+	}, out.Error)
+
+	buf.Reset()
+	assert.Equal(t, 1, ReportError(&buf, errors.New("boom")))
+	assert.Contains(t, buf.String(), `"code":"UNSPECIFIED"`)
+}
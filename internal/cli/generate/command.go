@@ -0,0 +1,79 @@
+// Package generate provides CLI commands that generate deployment files from
+// the local colony configuration.
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/cli/k8s"
+)
+
+// NewGenerateCmd creates the generate command and its subcommands.
+func NewGenerateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate deployment files for a colony",
+	}
+
+	cmd.AddCommand(newK8sCmd())
+
+	return cmd
+}
+
+func newK8sCmd() *cobra.Command {
+	var (
+		colonyID string
+		output   string
+		opts     k8s.Options
+	)
+
+	cmd := &cobra.Command{
+		Use:   "k8s",
+		Short: "Generate Kubernetes manifests for a colony and its agents",
+		Long: `Generate the Kubernetes manifests deploying a local colony and its agents,
+without Helm: the namespace, the colony StatefulSet and Service, the agent
+DaemonSet with the capabilities eBPF and WireGuard need, ServiceAccounts, RBAC,
+and the Secrets holding the colony configuration, CA and agent bootstrap
+credentials.
+
+These are the manifests 'coral k8s install' applies. They contain the colony
+CA keys: store them like any other secret.
+
+Examples:
+  coral generate k8s --colony prod --namespace coral > coral.yaml
+  coral generate k8s --colony prod -o coral.yaml --service-type LoadBalancer \
+    --public-endpoint colony.example.com:41580`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			colony, err := k8s.LoadColony(cmd.Context(), colonyID)
+			if err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			if err := k8s.Render(&buf, colony, opts); err != nil {
+				return err
+			}
+
+			if output == "" || output == "-" {
+				_, err := os.Stdout.Write(buf.Bytes())
+				return err
+			}
+			if err := os.WriteFile(output, buf.Bytes(), 0600); err != nil {
+				return fmt.Errorf("failed to write manifests: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "✓ Manifests for colony %s written to %s\n", colony.ID, output)
+			return nil
+		},
+	}
+
+	helpers.AddColonyFlag(cmd, &colonyID)
+	cmd.Flags().StringVarP(&output, "output", "o", "", "File to write the manifests to (default: stdout)")
+	k8s.AddOptionsFlags(cmd, &opts)
+
+	return cmd
+}
@@ -103,22 +103,83 @@ func GetColonyDebugClient(colonyID string) (colonyv1connect.ColonyDebugServiceCl
 	return client, nil
 }
 
+// NewChildColonyClient creates a colony service client for a child colony of
+// a federation, authenticated with the child's API token.
+func NewChildColonyClient(child config.ChildColonyConfig) (colonyv1connect.ColonyServiceClient, error) {
+	tlsConfig, err := buildTLSConfig(&config.ColonyConfig{
+		Remote: config.RemoteConfig{
+			Endpoint:                 child.Endpoint,
+			CertificateAuthority:     child.CertificateAuthority,
+			CertificateAuthorityData: child.CertificateAuthorityData,
+			InsecureSkipTLSVerify:    child.InsecureSkipTLSVerify,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		Timeout:   30 * time.Second,
+	}
+
+	var opts []connect.ClientOption
+	if child.Token != "" {
+		opts = append(opts, connect.WithInterceptors(bearerTokenInterceptor{token: child.Token}))
+	}
+
+	return colonyv1connect.NewColonyServiceClient(httpClient, child.Endpoint, opts...), nil
+}
+
+// PrintFederationErrors reports child colonies that failed to answer a
+// federated query on stderr.
+func PrintFederationErrors(errs []*colonyv1.FederationError) {
+	for _, e := range errs {
+		fmt.Fprintf(os.Stderr, "Warning: colony %s did not respond: %s\n", e.ColonyId, e.Error)
+	}
+}
+
 // getInterceptorOptsFromEnv returns client options with authentication interceptor
 // if CORAL_API_TOKEN is set.
 func getInterceptorOptsFromEnv() []connect.ClientOption {
 	var opts []connect.ClientOption
 	if token := os.Getenv("CORAL_API_TOKEN"); token != "" {
-		interceptor := connect.WithInterceptors(connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
-			return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-				req.Header().Set("Authorization", "Bearer "+token)
-				return next(ctx, req)
-			})
-		}))
-		opts = append(opts, interceptor)
+		opts = append(opts, connect.WithInterceptors(bearerTokenInterceptor{token: token}))
 	}
 	return opts
 }
 
+// WithBearerToken returns a client option that authenticates calls with
+// token, e.g. a capability token presented to agents.
+func WithBearerToken(token string) connect.ClientOption {
+	return connect.WithInterceptors(bearerTokenInterceptor{token: token})
+}
+
+// bearerTokenInterceptor sets the Authorization header on unary and streaming
+// client calls.
+type bearerTokenInterceptor struct {
+	token string
+}
+
+func (i bearerTokenInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		req.Header().Set("Authorization", "Bearer "+i.token)
+		return next(ctx, req)
+	}
+}
+
+func (i bearerTokenInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		conn.RequestHeader().Set("Authorization", "Bearer "+i.token)
+		return conn
+	}
+}
+
+func (i bearerTokenInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
 // BuildHTTPClient creates an HTTP client with appropriate TLS configuration.
 // For HTTPS endpoints, it configures TLS based on colony config (with env vars merged).
 func BuildHTTPClient(colonyID string, url string) (*http.Client, error) {
@@ -1,11 +1,18 @@
 package helpers
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	"connectrpc.com/connect"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
 )
 
 func TestGetColonyURL_EnvVarPrecedence(t *testing.T) {
@@ -56,3 +63,49 @@ func TestGetColonyClientWithFallback_EnvVarPrecedence(t *testing.T) {
 	// Let's assume the integration/manual test covers the E2E part.
 	// The critical piece is getColonyURL returning the right thing.
 }
+
+// authCapturingColony records the Authorization header of each call.
+type authCapturingColony struct {
+	colonyv1connect.UnimplementedColonyServiceHandler
+	headers []string
+}
+
+func (c *authCapturingColony) GetStatus(
+	_ context.Context,
+	req *connect.Request[colonyv1.GetStatusRequest],
+) (*connect.Response[colonyv1.GetStatusResponse], error) {
+	c.headers = append(c.headers, req.Header().Get("Authorization"))
+	return connect.NewResponse(&colonyv1.GetStatusResponse{}), nil
+}
+
+func (c *authCapturingColony) SubscribeEvents(
+	_ context.Context,
+	req *connect.Request[colonyv1.SubscribeEventsRequest],
+	_ *connect.ServerStream[colonyv1.ColonyEvent],
+) error {
+	c.headers = append(c.headers, req.Header().Get("Authorization"))
+	return nil
+}
+
+func TestInterceptorOptsFromEnv_SetsTokenOnStreams(t *testing.T) {
+	t.Setenv("CORAL_API_TOKEN", "coral_test")
+
+	colony := &authCapturingColony{}
+	mux := http.NewServeMux()
+	mux.Handle(colonyv1connect.NewColonyServiceHandler(colony))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := colonyv1connect.NewColonyServiceClient(http.DefaultClient, srv.URL, getInterceptorOptsFromEnv()...)
+
+	_, err := client.GetStatus(context.Background(), connect.NewRequest(&colonyv1.GetStatusRequest{}))
+	require.NoError(t, err)
+
+	stream, err := client.SubscribeEvents(context.Background(), connect.NewRequest(&colonyv1.SubscribeEventsRequest{}))
+	require.NoError(t, err)
+	for stream.Receive() {
+	}
+	require.NoError(t, stream.Err())
+
+	assert.Equal(t, []string{"Bearer coral_test", "Bearer coral_test"}, colony.headers)
+}
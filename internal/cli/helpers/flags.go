@@ -56,3 +56,27 @@ func ValidateFormat(format string, supported []OutputFormat) error {
 	return fmt.Errorf("unsupported format %q, must be one of: %s",
 		format, strings.Join(supportedNames, ", "))
 }
+
+// AddFederatedFlag adds a standard --federated flag for querying the child
+// colonies of a federation parent as well.
+func AddFederatedFlag(cmd *cobra.Command, federatedVar *bool) {
+	cmd.Flags().BoolVar(federatedVar, "federated", false, "Include the child colonies of a federation parent")
+}
+
+// AddSelectorFlag adds a standard --selector/-l flag for selecting agents or
+// services by their labels.
+func AddSelectorFlag(cmd *cobra.Command, selectorVar *string) {
+	cmd.Flags().StringVarP(selectorVar, "selector", "l", "", "Label selector, e.g. env=prod,region!=us-east")
+}
+
+// AddGroupFlag adds a standard --group flag for targeting the services of a
+// service group.
+func AddGroupFlag(cmd *cobra.Command, groupVar *string) {
+	cmd.Flags().StringVar(groupVar, "group", "", "Service group, see 'coral colony groups'")
+}
+
+// AddOverrideFreezeFlag adds a standard --override-freeze flag for starting
+// a session against a frozen service. The colony only honors it for admins.
+func AddOverrideFreezeFlag(cmd *cobra.Command, overrideVar *bool) {
+	cmd.Flags().BoolVar(overrideVar, "override-freeze", false, "Start even if the service is frozen (admin only), see 'coral colony freeze'")
+}
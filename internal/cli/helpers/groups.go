@@ -0,0 +1,95 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+// ResolveGroup returns the services of a service group. All of them must
+// run on a registered agent, so that a command acting on the group fails
+// before acting on any service rather than on a part of the group.
+func ResolveGroup(ctx context.Context, group string) ([]string, error) {
+	client, err := GetColonyClient("")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := client.GetServiceGroup(ctx, connect.NewRequest(&colonyv1.GetServiceGroupRequest{Name: group}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service group: %w", err)
+	}
+
+	services, err := client.ListServices(ctx, connect.NewRequest(&colonyv1.ListServicesRequest{Group: group}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	return groupServices(resp.Msg.Group, services.Msg.Services)
+}
+
+// groupServices returns the services of group, or an error naming those not
+// among the services running on registered agents.
+func groupServices(group *colonyv1.ServiceGroup, running []*colonyv1.ServiceSummary) ([]string, error) {
+	var missing []string
+	for _, name := range group.Services {
+		if !slices.ContainsFunc(running, func(svc *colonyv1.ServiceSummary) bool {
+			return svc.Name == name && svc.Source != colonyv1.ServiceSource_SERVICE_SOURCE_OBSERVED
+		}) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("services of group %s are not registered with any agent: %s",
+			group.Name, strings.Join(missing, ", "))
+	}
+	return group.Services, nil
+}
+
+// GroupResult is the outcome of an operation on one service of a group.
+type GroupResult[T any] struct {
+	Service string
+	Value   T
+	Err     error
+}
+
+// RunGroup runs op on all services at once and returns the results in the
+// order of services.
+func RunGroup[T any](ctx context.Context, services []string, op func(ctx context.Context, service string) (T, error)) []GroupResult[T] {
+	results := make([]GroupResult[T], len(services))
+	var wg sync.WaitGroup
+	for i, service := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := op(ctx, service)
+			results[i] = GroupResult[T]{Service: service, Value: value, Err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// GroupError returns an error describing the failed operations of results,
+// or nil if all succeeded.
+func GroupError[T any](results []GroupResult[T]) error {
+	var failures []string
+	for _, r := range results {
+		if r.Err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", r.Service, r.Err))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed on %d of %d services:\n  %s", len(failures), len(results), strings.Join(failures, "\n  "))
+}
@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestGroupServices(t *testing.T) {
+	group := &colonyv1.ServiceGroup{Name: "checkout-path", Services: []string{"api", "payments", "inventory"}}
+
+	services, err := groupServices(group, []*colonyv1.ServiceSummary{
+		{Name: "api", Source: colonyv1.ServiceSource_SERVICE_SOURCE_REGISTERED},
+		{Name: "payments", Source: colonyv1.ServiceSource_SERVICE_SOURCE_VERIFIED},
+		{Name: "inventory", Source: colonyv1.ServiceSource_SERVICE_SOURCE_REGISTERED},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "payments", "inventory"}, services)
+
+	_, err = groupServices(group, []*colonyv1.ServiceSummary{
+		{Name: "api", Source: colonyv1.ServiceSource_SERVICE_SOURCE_REGISTERED},
+		{Name: "payments", Source: colonyv1.ServiceSource_SERVICE_SOURCE_OBSERVED},
+	})
+	assert.ErrorContains(t, err, "not registered with any agent: payments, inventory",
+		"services only observed in telemetry cannot be acted on")
+}
+
+func TestRunGroup(t *testing.T) {
+	results := RunGroup(context.Background(), []string{"api", "payments"}, func(_ context.Context, service string) (int, error) {
+		if service == "payments" {
+			return 0, errors.New("agent unreachable")
+		}
+		return len(service), nil
+	})
+	require.Len(t, results, 2)
+	assert.Equal(t, GroupResult[int]{Service: "api", Value: 3}, results[0])
+	assert.Equal(t, "payments", results[1].Service)
+
+	err := GroupError(results)
+	assert.ErrorContains(t, err, "failed on 1 of 2 services")
+	assert.ErrorContains(t, err, "payments: agent unreachable")
+
+	assert.NoError(t, GroupError(results[:1]))
+}
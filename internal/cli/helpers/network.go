@@ -7,15 +7,12 @@ import (
 	"github.com/coral-mesh/coral/internal/logging"
 )
 
-// ResolveToIPv4 resolves a hostname to an IPv4 address.
-// This ensures we don't accidentally use IPv6 addresses that may cause issues.
-func ResolveToIPv4(host string, logger logging.Logger) (string, error) {
-	// If already an IP address, validate it's IPv4
+// ResolvePreferIPv4 resolves a hostname to an IP address, preferring IPv4
+// (A records) and falling back to IPv6 (AAAA records) for IPv6-only hosts.
+// IP addresses are returned as-is.
+func ResolvePreferIPv4(host string, logger logging.Logger) (string, error) {
 	if ip := net.ParseIP(host); ip != nil {
-		if ip.To4() != nil {
-			return host, nil
-		}
-		return "", fmt.Errorf("address is IPv6, need IPv4")
+		return host, nil
 	}
 
 	// Resolve hostname to IP addresses
@@ -35,5 +32,15 @@ func ResolveToIPv4(host string, logger logging.Logger) (string, error) {
 		}
 	}
 
-	return "", fmt.Errorf("no IPv4 address found for hostname %s", host)
+	for _, ip := range ips {
+		if !ip.IsLinkLocalUnicast() {
+			logger.Debug().
+				Str("hostname", host).
+				Str("resolved_ipv6", ip.String()).
+				Msg("Resolved hostname to IPv6 (no IPv4 address)")
+			return ip.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("no usable address found for hostname %s", host)
 }
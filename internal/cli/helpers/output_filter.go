@@ -0,0 +1,128 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/itchyny/gojq"
+)
+
+// OutputFilter filters and projects the JSON output of a command, as set by
+// the global --output flag.
+type OutputFilter struct {
+	expr string
+	code *gojq.Code
+}
+
+// jsonPathBracketKey matches a quoted JSONPath child key such as ['name'].
+var jsonPathBracketKey = regexp.MustCompile(`\['([^']*)'\]`)
+
+// ParseOutputFilter parses an output filter of the form "jq=<expr>" or
+// "jsonpath=<expr>".
+//
+// JSONPath expressions support child access with dots or brackets, array
+// indexes and [*] wildcards, with or without a leading $ and kubectl-style
+// braces (e.g., "$.items[*].name" or "{.items[0].name}"); they are translated
+// to jq.
+func ParseOutputFilter(spec string) (*OutputFilter, error) {
+	kind, expr, ok := strings.Cut(spec, "=")
+	if !ok || strings.TrimSpace(expr) == "" {
+		return nil, fmt.Errorf("invalid --output %q: must be jq=<expr> or jsonpath=<expr>", spec)
+	}
+
+	switch kind {
+	case "jq":
+	case "jsonpath":
+		expr = jsonPathToJQ(expr)
+	default:
+		return nil, fmt.Errorf("invalid --output %q: unknown filter %q, must be jq or jsonpath", spec, kind)
+	}
+
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --output %s expression: %w", kind, err)
+	}
+	code, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --output %s expression: %w", kind, err)
+	}
+	return &OutputFilter{expr: expr, code: code}, nil
+}
+
+// Apply runs the filter on each JSON value of data, such as a single
+// document or one object per line, and writes every result to w. Strings
+// are written without quotes, as with jq -r, and other values as indented
+// JSON.
+func (f *OutputFilter) Apply(data []byte, w io.Writer) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	for {
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("--output requires JSON output (use --format json): %w", err)
+		}
+
+		iter := f.code.Run(v)
+		for {
+			result, ok := iter.Next()
+			if !ok {
+				break
+			}
+			if err, ok := result.(error); ok {
+				var haltErr *gojq.HaltError
+				if errors.As(err, &haltErr) && haltErr.Value() == nil {
+					break
+				}
+				return fmt.Errorf("failed to apply --output filter %q: %w", f.expr, err)
+			}
+			if err := writeFilterResult(w, result); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeFilterResult(w io.Writer, v any) error {
+	if s, ok := v.(string); ok {
+		_, err := fmt.Fprintln(w, s)
+		return err
+	}
+
+	data, err := gojq.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal filter result: %w", err)
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return fmt.Errorf("failed to format filter result: %w", err)
+	}
+	_, err = fmt.Fprintln(w, out.String())
+	return err
+}
+
+// jsonPathToJQ translates the supported subset of JSONPath to jq.
+func jsonPathToJQ(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") && strings.HasSuffix(path, "}") {
+		path = strings.TrimSpace(path[1 : len(path)-1])
+	}
+	path = strings.TrimPrefix(path, "$")
+	path = jsonPathBracketKey.ReplaceAllStringFunc(path, func(m string) string {
+		key := jsonPathBracketKey.FindStringSubmatch(m)[1]
+		quoted, _ := json.Marshal(key)
+		return "[" + string(quoted) + "]"
+	})
+	path = strings.ReplaceAll(path, "[*]", "[]")
+	if !strings.HasPrefix(path, ".") {
+		path = "." + path
+	}
+	return path
+}
@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputFilter(t *testing.T) {
+	for _, spec := range []string{"jq=.services[]", "jsonpath={.items[*].name}", "jsonpath=$.a['b c'][0]"} {
+		_, err := ParseOutputFilter(spec)
+		assert.NoError(t, err, spec)
+	}
+	for _, spec := range []string{"", ".services", "jq=", "yaml=.a", "jq=.[", "jq=undefined_fn"} {
+		_, err := ParseOutputFilter(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestJSONPathToJQ(t *testing.T) {
+	tests := map[string]string{
+		"$.items[*].name":  ".items[].name",
+		"{.items[0].name}": ".items[0].name",
+		"$['a b'].c":       `.["a b"].c`,
+		"items":            ".items",
+		"$":                ".",
+	}
+	for path, want := range tests {
+		assert.Equal(t, want, jsonPathToJQ(path), path)
+	}
+}
+
+func TestOutputFilterApply(t *testing.T) {
+	doc := []byte(`{"service": "api", "count": 12345678901234567890, "checks": [{"metric": "p95", "status": "fail"}, {"metric": "errors", "status": "pass"}]}`)
+
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"jq=.service", "api\n"},
+		{"jq=.count", "12345678901234567890\n"},
+		{"jq=.checks[] | select(.status == \"fail\") | .metric", "p95\n"},
+		{"jq={service, failed: [.checks[] | select(.status == \"fail\")] | length}", "{\n  \"failed\": 1,\n  \"service\": \"api\"\n}\n"},
+		{"jsonpath=$.checks[*].status", "fail\npass\n"},
+	}
+	for _, tt := range tests {
+		f, err := ParseOutputFilter(tt.spec)
+		require.NoError(t, err, tt.spec)
+		var out bytes.Buffer
+		require.NoError(t, f.Apply(doc, &out), tt.spec)
+		assert.Equal(t, tt.want, out.String(), tt.spec)
+	}
+
+	t.Run("json lines", func(t *testing.T) {
+		f, err := ParseOutputFilter("jq=.line")
+		require.NoError(t, err)
+		var out bytes.Buffer
+		require.NoError(t, f.Apply([]byte("{\"line\":\"a\"}\n{\"line\":\"b\"}\n"), &out))
+		assert.Equal(t, "a\nb\n", out.String())
+	})
+
+	t.Run("not json", func(t *testing.T) {
+		f, err := ParseOutputFilter("jq=.")
+		require.NoError(t, err)
+		err = f.Apply([]byte("SERVICE  STATUS\napi      ok\n"), &bytes.Buffer{})
+		assert.ErrorContains(t, err, "--format json")
+	})
+
+	t.Run("runtime error", func(t *testing.T) {
+		f, err := ParseOutputFilter("jq=.service + 1")
+		require.NoError(t, err)
+		assert.Error(t, f.Apply(doc, &bytes.Buffer{}))
+	})
+}
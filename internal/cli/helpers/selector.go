@@ -0,0 +1,64 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+// ResolveService returns the service a command targets: service, or the
+// single service matching selector. Commands that act on one service accept
+// either --service or --selector.
+func ResolveService(ctx context.Context, service, selector string) (string, error) {
+	if selector == "" {
+		if service == "" {
+			return "", fmt.Errorf("--service or --selector is required")
+		}
+		return service, nil
+	}
+	if service != "" {
+		return "", fmt.Errorf("--service and --selector cannot be combined")
+	}
+
+	client, err := GetColonyClient("")
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	resp, err := client.ListServices(ctx, connect.NewRequest(&colonyv1.ListServicesRequest{Selector: selector}))
+	if err != nil {
+		return "", fmt.Errorf("failed to list services: %w", err)
+	}
+	return selectedService(selector, resp.Msg.Services)
+}
+
+// selectedService returns the name of the single service among services,
+// the services matching selector.
+func selectedService(selector string, services []*colonyv1.ServiceSummary) (string, error) {
+	var names []string
+	for _, svc := range services {
+		if !slices.Contains(names, svc.Name) {
+			names = append(names, svc.Name)
+		}
+	}
+
+	switch len(names) {
+	case 0:
+		return "", fmt.Errorf("no service matches selector %q", selector)
+	case 1:
+		return names[0], nil
+	default:
+		slices.Sort(names)
+		return "", fmt.Errorf("selector %q matches %d services (%s): narrow it down or use --service",
+			selector, len(names), strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,22 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestSelectedService(t *testing.T) {
+	name, err := selectedService("env=prod", []*colonyv1.ServiceSummary{{Name: "api"}, {Name: "api"}})
+	require.NoError(t, err, "instances of one service are a single match")
+	assert.Equal(t, "api", name)
+
+	_, err = selectedService("env=prod", nil)
+	assert.ErrorContains(t, err, "no service matches")
+
+	_, err = selectedService("env=prod", []*colonyv1.ServiceSummary{{Name: "worker"}, {Name: "api"}})
+	assert.ErrorContains(t, err, "matches 2 services (api, worker)")
+}
@@ -2,12 +2,15 @@
 package initcmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/coral-mesh/coral/internal/auth"
 	"github.com/coral-mesh/coral/internal/colony/ca"
@@ -18,13 +21,12 @@ import (
 // NewInitCmd creates the init command
 func NewInitCmd() *cobra.Command {
 	var (
-		environment  string
-		storagePath  string
-		discoveryURL string
+		opts        options
+		interactive bool
 	)
 
 	cmd := &cobra.Command{
-		Use:   "init <app-name>",
+		Use:   "init [app-name]",
 		Short: "Initialize a new Coral colony",
 		Long: `Initialize a new Coral colony with application identity and security credentials.
 
@@ -34,24 +36,64 @@ This command creates:
 - A WireGuard key pair for mesh encryption
 - Configuration files in ~/.coral/
 
+With --template, it also generates the files to deploy agents that join the
+colony: a DaemonSet and Secret (k8s), a Compose file (docker) or a systemd
+unit (bare-metal), along with an env file holding the bootstrap credentials.
+
+Without an app name, or with --interactive, a wizard asks for each setting.
+
 Example:
   coral init my-shop --env production
-  coral init payment-api --env staging --storage /data/coral`,
-		Args: cobra.ExactArgs(1),
+  coral init payment-api --env staging --storage /data/coral
+  coral init my-shop --template k8s --output ./coral-deploy
+  coral init --interactive`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			appName := args[0]
-			return runInit(appName, environment, storagePath, discoveryURL)
+			if len(args) == 1 {
+				opts.appName = args[0]
+			}
+			if interactive || opts.appName == "" {
+				if !term.IsTerminal(int(os.Stdin.Fd())) {
+					return fmt.Errorf("app name is required when not running in a terminal")
+				}
+				if err := runWizard(bufio.NewReader(os.Stdin), &opts, cmd.Flags().Changed); err != nil {
+					return err
+				}
+			}
+			return runInit(opts)
 		},
 	}
 
-	cmd.Flags().StringVar(&environment, "env", "dev", "Environment name (dev, staging, production, etc.)")
-	cmd.Flags().StringVar(&storagePath, "storage", constants.DefaultDir, "Storage directory path")
-	cmd.Flags().StringVar(&discoveryURL, "discovery", constants.DefaultDiscoveryEndpoint, "Discovery service URL")
+	cmd.Flags().StringVar(&opts.environment, "env", "dev", "Environment name (dev, staging, production, etc.)")
+	cmd.Flags().StringVar(&opts.storagePath, "storage", constants.DefaultDir, "Storage directory path")
+	cmd.Flags().StringVar(&opts.discoveryURL, "discovery", constants.DefaultDiscoveryEndpoint, "Discovery service URL")
+	cmd.Flags().StringVar(&opts.template, "template", "", "Generate agent deployment files: "+strings.Join(templateNames(), ", "))
+	cmd.Flags().StringVar(&opts.outputDir, "output", defaultOutputDir, "Directory for the generated deployment files")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for each setting")
 
 	return cmd
 }
 
-func runInit(appName, environment, storagePath, discoveryURL string) error {
+// options holds the settings of a new colony.
+type options struct {
+	appName      string
+	environment  string
+	storagePath  string
+	discoveryURL string
+
+	// template selects the agent deployment files to generate, if any.
+	template  string
+	outputDir string
+}
+
+func runInit(opts options) error {
+	appName, environment, storagePath, discoveryURL := opts.appName, opts.environment, opts.storagePath, opts.discoveryURL
+
+	tmpl, ok := deploymentTemplates[opts.template]
+	if opts.template != "" && !ok {
+		return fmt.Errorf("unknown template %q (supported: %s)", opts.template, strings.Join(templateNames(), ", "))
+	}
+
 	fmt.Println("Initializing Coral colony...")
 
 	// Generate colony ID
@@ -169,6 +211,30 @@ func runInit(appName, environment, storagePath, discoveryURL string) error {
 	fmt.Printf("  export CORAL_BOOTSTRAP_PSK=%s\n", caResult.BootstrapPSK)
 	fmt.Println("  coral agent start")
 
+	if opts.template != "" {
+		files, err := generateTemplate(opts.template, opts.outputDir, templateData{
+			AppName:           appName,
+			Environment:       environment,
+			ColonyID:          colonyID,
+			CAFingerprint:     "sha256:" + caResult.RootFingerprint,
+			BootstrapPSK:      caResult.BootstrapPSK,
+			DiscoveryEndpoint: globalConfig.Discovery.Endpoint,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate %s deployment files: %w", opts.template, err)
+		}
+
+		fmt.Printf("\nGenerated %s deployment files:\n", opts.template)
+		for _, file := range files {
+			fmt.Printf("  %s\n", file)
+		}
+		fmt.Println("\nDeploy them with:")
+		for _, line := range tmpl.instructions {
+			fmt.Printf("  %s\n", strings.ReplaceAll(line, "{{dir}}", opts.outputDir))
+		}
+		fmt.Println("\n⚠️  Files holding the bootstrap PSK are written with mode 0600; do not commit them")
+	}
+
 	fmt.Println("\n✓ Colony initialized successfully")
 
 	return nil
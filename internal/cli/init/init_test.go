@@ -15,8 +15,8 @@ func TestNewInitCmd(t *testing.T) {
 		t.Fatal("NewInitCmd() returned nil")
 	}
 
-	if cmd.Use != "init <app-name>" {
-		t.Errorf("Use = %q, want %q", cmd.Use, "init <app-name>")
+	if cmd.Use != "init [app-name]" {
+		t.Errorf("Use = %q, want %q", cmd.Use, "init [app-name]")
 	}
 
 	if cmd.Short == "" {
@@ -68,7 +68,7 @@ func TestRunInit_BasicFlow(t *testing.T) {
 	os.Unsetenv("XDG_CONFIG_HOME")
 
 	// Run init
-	err := runInit("test-app", "dev", configDir, "http://localhost:8080")
+	err := runInit(options{appName: "test-app", environment: "dev", storagePath: configDir, discoveryURL: "http://localhost:8080"})
 
 	if err != nil {
 		t.Fatalf("runInit() error = %v", err)
@@ -113,7 +113,7 @@ func TestRunInit_CustomStorage(t *testing.T) {
 	os.Unsetenv("XDG_CONFIG_HOME")
 
 	// Run init with custom storage path
-	err := runInit("test-app", "production", customStorage, "")
+	err := runInit(options{appName: "test-app", environment: "production", storagePath: customStorage})
 
 	if err != nil {
 		t.Fatalf("runInit() with custom storage error = %v", err)
@@ -172,7 +172,7 @@ func TestRunInit_DifferentEnvironments(t *testing.T) {
 
 			configDir := filepath.Join(tmpDir, ".coral")
 
-			err := runInit(tt.appName, tt.environment, configDir, "")
+			err := runInit(options{appName: tt.appName, environment: tt.environment, storagePath: configDir})
 			if err != nil {
 				t.Errorf("runInit() error = %v", err)
 			}
@@ -207,11 +207,11 @@ func TestRunInit_DifferentEnvironments(t *testing.T) {
 func TestNewInitCmd_ArgsValidation(t *testing.T) {
 	cmd := NewInitCmd()
 
-	// Test that the command requires exactly one arg
-	// This is enforced by cobra.ExactArgs(1)
+	// The app name is optional: without it, the wizard asks for it.
+	// This is enforced by cobra.MaximumNArgs(1)
 	err := cmd.Args(cmd, []string{})
-	if err == nil {
-		t.Error("Command should require exactly one argument")
+	if err != nil {
+		t.Errorf("Command should accept no argument, got error: %v", err)
 	}
 
 	err = cmd.Args(cmd, []string{"app1", "app2"})
@@ -244,7 +244,7 @@ func TestRunInit_VerifyCACreation(t *testing.T) {
 
 	configDir := filepath.Join(tmpDir, ".coral")
 
-	err := runInit("ca-test-app", "dev", configDir, "")
+	err := runInit(options{appName: "ca-test-app", environment: "dev", storagePath: configDir})
 	if err != nil {
 		t.Fatalf("runInit() error = %v", err)
 	}
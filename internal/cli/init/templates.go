@@ -0,0 +1,126 @@
+package initcmd
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/template"
+)
+
+// defaultOutputDir is where deployment files are generated by default.
+const defaultOutputDir = "coral-deploy"
+
+//go:embed templates
+var templateFS embed.FS
+
+// templateData is the data deployment templates are rendered with.
+type templateData struct {
+	AppName           string
+	Environment       string
+	ColonyID          string
+	CAFingerprint     string // sha256:<hex>
+	BootstrapPSK      string
+	DiscoveryEndpoint string
+}
+
+// templateFile is a file generated from templates/<template>/<name>.tmpl.
+type templateFile struct {
+	name   string
+	secret bool // Holds the bootstrap PSK; written with mode 0600.
+}
+
+// deploymentTemplate generates the files to deploy agents on a platform.
+type deploymentTemplate struct {
+	files []templateFile
+
+	// instructions are printed after the files are generated; {{dir}} is
+	// replaced with the output directory.
+	instructions []string
+}
+
+// deploymentTemplates are the templates supported by --template.
+var deploymentTemplates = map[string]deploymentTemplate{
+	"k8s": {
+		files: []templateFile{
+			{name: "coral-agent-daemonset.yaml"},
+			{name: "coral-agent-secret.yaml", secret: true},
+		},
+		instructions: []string{"kubectl apply -f {{dir}}"},
+	},
+	"docker": {
+		files: []templateFile{
+			{name: "docker-compose.yml"},
+			{name: "coral-agent.env", secret: true},
+		},
+		instructions: []string{"docker compose -f {{dir}}/docker-compose.yml up -d"},
+	},
+	"bare-metal": {
+		files: []templateFile{
+			{name: "coral-agent.service"},
+			{name: "coral-agent.env", secret: true},
+		},
+		instructions: []string{
+			"sudo install -m 0600 {{dir}}/coral-agent.env /etc/coral/coral-agent.env",
+			"sudo install -m 0644 {{dir}}/coral-agent.service /etc/systemd/system/coral-agent.service",
+			"sudo systemctl daemon-reload && sudo systemctl enable --now coral-agent",
+		},
+	},
+}
+
+// templateNames returns the names of the deployment templates.
+func templateNames() []string {
+	names := make([]string, 0, len(deploymentTemplates))
+	for name := range deploymentTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// generateTemplate renders the files of the named template into dir and
+// returns their paths. Existing files are not overwritten.
+func generateTemplate(name, dir string, data templateData) ([]string, error) {
+	t, ok := deploymentTemplates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q", name)
+	}
+
+	rendered := make(map[string][]byte, len(t.files))
+	for _, file := range t.files {
+		path := filepath.Join(dir, file.name)
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("%s already exists", path)
+		}
+
+		tmpl, err := template.ParseFS(templateFS, "templates/"+name+"/"+file.name+".tmpl")
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", file.name, err)
+		}
+		rendered[file.name] = buf.Bytes()
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	paths := make([]string, 0, len(t.files))
+	for _, file := range t.files {
+		path := filepath.Join(dir, file.name)
+		mode := os.FileMode(0644)
+		if file.secret {
+			mode = 0600
+		}
+		if err := os.WriteFile(path, rendered[file.name], mode); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,124 @@
+package initcmd
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testTemplateData() templateData {
+	return templateData{
+		AppName:           "my-shop",
+		Environment:       "production",
+		ColonyID:          "my-shop-production-abc123",
+		CAFingerprint:     "sha256:0123abcd",
+		BootstrapPSK:      "coral-psk:f1e2d3c4",
+		DiscoveryEndpoint: "https://discovery.example.com",
+	}
+}
+
+func TestGenerateTemplate(t *testing.T) {
+	for _, name := range templateNames() {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "deploy")
+
+			files, err := generateTemplate(name, dir, testTemplateData())
+			if err != nil {
+				t.Fatalf("generateTemplate() error = %v", err)
+			}
+			if len(files) != len(deploymentTemplates[name].files) {
+				t.Fatalf("generateTemplate() wrote %d files, want %d", len(files), len(deploymentTemplates[name].files))
+			}
+
+			var all strings.Builder
+			for i, file := range deploymentTemplates[name].files {
+				info, err := os.Stat(files[i])
+				if err != nil {
+					t.Fatalf("%s was not created: %v", file.name, err)
+				}
+				if file.secret && info.Mode().Perm() != 0600 {
+					t.Errorf("%s mode = %v, want 0600", file.name, info.Mode().Perm())
+				}
+
+				data, err := os.ReadFile(files[i])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if strings.Contains(string(data), "<no value>") {
+					t.Errorf("%s references an undefined template field", file.name)
+				}
+				all.Write(data)
+			}
+
+			for _, want := range []string{"my-shop-production-abc123", "sha256:0123abcd", "coral-psk:f1e2d3c4", "https://discovery.example.com"} {
+				if !strings.Contains(all.String(), want) {
+					t.Errorf("generated files do not contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateTemplate_ExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "coral-agent.env"), []byte("keep"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := generateTemplate("bare-metal", dir, testTemplateData()); err == nil {
+		t.Fatal("generateTemplate() should not overwrite existing files")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "coral-agent.service")); !os.IsNotExist(err) {
+		t.Error("generateTemplate() wrote files before failing")
+	}
+}
+
+func TestRunInit_UnknownTemplate(t *testing.T) {
+	err := runInit(options{appName: "test-app", environment: "dev", template: "nomad"})
+	if err == nil || !strings.Contains(err.Error(), "unknown template") {
+		t.Errorf("runInit() error = %v, want unknown template", err)
+	}
+}
+
+func TestRunWizard(t *testing.T) {
+	opts := options{
+		environment:  "dev",
+		storagePath:  ".coral",
+		discoveryURL: "https://discovery.coralmesh.dev",
+		outputDir:    defaultOutputDir,
+	}
+	// App name, environment (re-prompted after an invalid template),
+	// discovery default, template, output directory. Storage is a flag.
+	input := "my-shop\nstaging\n\nnomad\nk8s\n./k8s\n"
+	changed := func(flag string) bool { return flag == "storage" }
+
+	if err := runWizard(bufio.NewReader(strings.NewReader(input)), &opts, changed); err != nil {
+		t.Fatalf("runWizard() error = %v", err)
+	}
+
+	want := options{
+		appName:      "my-shop",
+		environment:  "staging",
+		storagePath:  ".coral",
+		discoveryURL: "https://discovery.coralmesh.dev",
+		template:     "k8s",
+		outputDir:    "./k8s",
+	}
+	if opts != want {
+		t.Errorf("runWizard() options = %+v, want %+v", opts, want)
+	}
+}
+
+func TestRunWizard_NoTemplate(t *testing.T) {
+	opts := options{environment: "dev", template: "docker", outputDir: defaultOutputDir}
+	input := "my-shop\n\n\n\nnone\n"
+
+	if err := runWizard(bufio.NewReader(strings.NewReader(input)), &opts, func(string) bool { return false }); err != nil {
+		t.Fatalf("runWizard() error = %v", err)
+	}
+	if opts.template != "" {
+		t.Errorf("template = %q, want none", opts.template)
+	}
+}
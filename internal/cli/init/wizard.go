@@ -0,0 +1,88 @@
+package initcmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// runWizard prompts for the colony settings not given as flags (changed
+// reports whether a flag was set), defaulting to the current values of opts.
+func runWizard(r *bufio.Reader, opts *options, changed func(flag string) bool) error {
+	fmt.Println()
+	fmt.Println("╔═══════════════════════════════════════════════════════════╗")
+	fmt.Println("║           Coral Colony Setup Wizard                       ║")
+	fmt.Println("╚═══════════════════════════════════════════════════════════╝")
+	fmt.Println()
+
+	prompts := []struct {
+		flag   string
+		label  string
+		value  *string
+		accept func(string) error
+	}{
+		{"", "Application name", &opts.appName, func(v string) error {
+			if v == "" {
+				return fmt.Errorf("application name is required")
+			}
+			return nil
+		}},
+		{"env", "Environment (dev, staging, production, ...)", &opts.environment, nil},
+		{"storage", "Storage directory", &opts.storagePath, nil},
+		{"discovery", "Discovery service URL", &opts.discoveryURL, nil},
+		{"template", "Agent deployment template (" + strings.Join(templateNames(), ", ") + ", or none)", &opts.template, func(v string) error {
+			if _, ok := deploymentTemplates[v]; v != "" && !ok {
+				return fmt.Errorf("unknown template %q", v)
+			}
+			return nil
+		}},
+	}
+
+	for _, p := range prompts {
+		if p.flag != "" && changed(p.flag) {
+			continue
+		}
+		if p.flag == "" && *p.value != "" {
+			continue
+		}
+
+		for {
+			if *p.value != "" {
+				fmt.Printf("? %s [%s]: ", p.label, *p.value)
+			} else {
+				fmt.Printf("? %s: ", p.label)
+			}
+			input, err := r.ReadString('\n')
+			if err != nil && input == "" {
+				return fmt.Errorf("failed to read input: %w", err)
+			}
+
+			value := strings.TrimSpace(input)
+			if value == "" {
+				value = *p.value
+			}
+			if p.flag == "template" && value == "none" {
+				value = ""
+			}
+			if p.accept != nil {
+				if err := p.accept(value); err != nil {
+					fmt.Printf("  %v\n", err)
+					continue
+				}
+			}
+			*p.value = value
+			break
+		}
+	}
+
+	if opts.template != "" && !changed("output") {
+		fmt.Printf("? Output directory for deployment files [%s]: ", opts.outputDir)
+		input, _ := r.ReadString('\n')
+		if value := strings.TrimSpace(input); value != "" {
+			opts.outputDir = value
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
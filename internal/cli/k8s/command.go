@@ -0,0 +1,167 @@
+// Package k8s provides CLI commands for deploying a colony and its agents to
+// Kubernetes.
+package k8s
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// NewK8sCmd creates the k8s command and its subcommands.
+func NewK8sCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "k8s",
+		Short: "Deploy a colony and its agents to Kubernetes",
+		Long: `Deploy a local colony and its agents to Kubernetes with kubectl.
+
+The manifests are rendered from the local colony configuration: the colony
+runs as a single-replica StatefulSet keeping its storage on a persistent
+volume, and agents run as a privileged DaemonSet. The colony configuration,
+CA and agent bootstrap credentials are stored in Kubernetes Secrets.`,
+	}
+
+	cmd.AddCommand(newInstallCmd())
+	cmd.AddCommand(newSyncSecretsCmd())
+
+	return cmd
+}
+
+// kubectlFlags are the flags shared by commands applying manifests.
+type kubectlFlags struct {
+	colonyID    string
+	kubeContext string
+	dryRun      bool
+	opts        Options
+}
+
+func (f *kubectlFlags) add(cmd *cobra.Command) {
+	helpers.AddColonyFlag(cmd, &f.colonyID)
+	cmd.Flags().StringVar(&f.kubeContext, "context", "", "kubectl context (default: current context)")
+	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false, "Print the manifests instead of applying them")
+}
+
+// AddOptionsFlags adds the flags configuring the rendered manifests to cmd.
+func AddOptionsFlags(cmd *cobra.Command, opts *Options) {
+	addNamespaceFlag(cmd, opts)
+	cmd.Flags().StringVar(&opts.ColonyImage, "colony-image", DefaultColonyImage, "Colony container image")
+	cmd.Flags().StringVar(&opts.AgentImage, "agent-image", DefaultAgentImage, "Agent container image")
+	cmd.Flags().StringVar(&opts.StorageSize, "storage-size", DefaultStorageSize, "Size of the colony storage volume")
+	cmd.Flags().StringVar(&opts.ServiceType, "service-type", "ClusterIP", "Colony Service type (ClusterIP, NodePort, LoadBalancer)")
+	cmd.Flags().StringVar(&opts.PublicEndpoint, "public-endpoint", "", "WireGuard endpoint advertised to agents (default: the colony Service)")
+}
+
+func addNamespaceFlag(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", DefaultNamespace, "Kubernetes namespace")
+}
+
+// apply applies manifests with kubectl, or prints them on a dry run.
+func (f *kubectlFlags) apply(manifests []byte) error {
+	if f.dryRun {
+		_, err := os.Stdout.Write(manifests)
+		return err
+	}
+
+	args := []string{"apply", "-f", "-"}
+	if f.kubeContext != "" {
+		args = append([]string{"--context", f.kubeContext}, args...)
+	}
+	cmd := exec.Command("kubectl", args...)
+	cmd.Stdin = bytes.NewReader(manifests)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("kubectl apply failed: %w", err)
+	}
+	return nil
+}
+
+func newInstallCmd() *cobra.Command {
+	var flags kubectlFlags
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Deploy the colony and agent DaemonSet",
+		Long: `Deploy the colony as a StatefulSet and agents as a DaemonSet, along with
+their namespace, RBAC and Secrets.
+
+Agents in the cluster reach the colony through its Service. For agents outside
+the cluster, expose it with --service-type LoadBalancer and set
+--public-endpoint to the address they connect to.
+
+Examples:
+  coral k8s install --colony my-shop-prod-a3f2e1
+  coral k8s install --namespace coral --service-type LoadBalancer \
+    --public-endpoint colony.example.com:41580
+  coral k8s install --dry-run > coral.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			colony, err := LoadColony(cmd.Context(), flags.colonyID)
+			if err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			if err := Render(&buf, colony, flags.opts); err != nil {
+				return err
+			}
+			if err := flags.apply(buf.Bytes()); err != nil {
+				return err
+			}
+			if !flags.dryRun {
+				fmt.Printf("\n✓ Colony %s deployed to namespace %s\n", colony.ID, flags.opts.Namespace)
+			}
+			return nil
+		},
+	}
+
+	flags.add(cmd)
+	AddOptionsFlags(cmd, &flags.opts)
+
+	return cmd
+}
+
+func newSyncSecretsCmd() *cobra.Command {
+	var flags kubectlFlags
+
+	cmd := &cobra.Command{
+		Use:   "sync-secrets",
+		Short: "Update the colony Secrets from the local colony",
+		Long: `Update the Kubernetes Secrets holding the colony configuration, CA and agent
+bootstrap credentials from the local colony, e.g. after rotating the bootstrap
+PSK or the colony secret. Pods read the Secrets when they start.
+
+Examples:
+  coral k8s sync-secrets --colony my-shop-prod-a3f2e1
+  coral k8s sync-secrets && kubectl -n coral-system rollout restart statefulset/coral-colony`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			colony, err := LoadColony(cmd.Context(), flags.colonyID)
+			if err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			if err := RenderSecrets(&buf, colony, flags.opts); err != nil {
+				return err
+			}
+			if err := flags.apply(buf.Bytes()); err != nil {
+				return err
+			}
+			if !flags.dryRun {
+				fmt.Printf("\n✓ Secrets of colony %s updated\n", colony.ID)
+				fmt.Println("Restart the colony and agents to use them:")
+				fmt.Printf("  kubectl -n %s rollout restart statefulset/coral-colony daemonset/coral-agent\n", flags.opts.Namespace)
+			}
+			return nil
+		},
+	}
+
+	flags.add(cmd)
+	addNamespaceFlag(cmd, &flags.opts)
+
+	return cmd
+}
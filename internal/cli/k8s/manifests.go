@@ -0,0 +1,228 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.yaml.tmpl"))
+
+// Default manifest settings.
+const (
+	DefaultNamespace   = "coral-system"
+	DefaultColonyImage = "ghcr.io/coral-mesh/coral-colony:latest"
+	DefaultAgentImage  = "ghcr.io/coral-mesh/coral-agent:latest"
+	DefaultStorageSize = "10Gi"
+)
+
+// Options configures the rendered manifests.
+type Options struct {
+	Namespace   string
+	ColonyImage string
+	AgentImage  string
+	StorageSize string
+
+	// ServiceType is the type of the colony Service (ClusterIP, NodePort or
+	// LoadBalancer). Agents outside the cluster need one they can reach.
+	ServiceType string
+
+	// PublicEndpoint is the WireGuard endpoint the colony advertises to
+	// agents. Default: the colony Service DNS name.
+	PublicEndpoint string
+}
+
+// Colony holds the colony configuration and credentials deployed to
+// Kubernetes.
+type Colony struct {
+	ID                string
+	CAFingerprint     string // sha256:<hex>
+	BootstrapPSK      string
+	DiscoveryEndpoint string
+	WireGuardPort     int
+
+	// Files are the files of the colony directory (config and CA), relative
+	// to it.
+	Files map[string][]byte
+}
+
+// configFile is a file of the colony directory stored in the colony config
+// Secret. Secret keys cannot contain slashes, so files in subdirectories are
+// mapped back to their path when the Secret is mounted.
+type configFile struct {
+	Key  string
+	Path string
+	Data string // base64
+}
+
+// manifestData is the data the manifest templates are rendered with.
+type manifestData struct {
+	Options
+	ColonyID          string
+	CAFingerprint     string
+	BootstrapPSK      string
+	DiscoveryEndpoint string
+	WireGuardPort     int
+	ColonyPort        int
+	ConfigFiles       []configFile
+}
+
+var invalidKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// LoadColony loads the configuration and credentials of the local colony
+// colonyID (the default colony if empty).
+func LoadColony(ctx context.Context, colonyID string) (*Colony, error) {
+	manager, db, cfg, err := helpers.GetCAManager(colonyID)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close() // nolint:errcheck
+
+	if err := manager.ImportPSKFromFile(ctx); err != nil {
+		return nil, fmt.Errorf("failed to import PSK: %w", err)
+	}
+	psk, err := manager.GetActivePSK(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active PSK: %w", err)
+	}
+
+	loader, err := config.NewLoader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config loader: %w", err)
+	}
+	colonyConfig, err := loader.LoadColonyConfig(cfg.ColonyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load colony config: %w", err)
+	}
+
+	colony := &Colony{
+		ID:                cfg.ColonyID,
+		CAFingerprint:     "sha256:" + manager.GetStatus().RootCA.Fingerprint,
+		BootstrapPSK:      psk,
+		DiscoveryEndpoint: cfg.DiscoveryURL,
+		WireGuardPort:     colonyConfig.WireGuard.Port,
+		Files:             make(map[string][]byte),
+	}
+	if colony.WireGuardPort == 0 {
+		colony.WireGuardPort = constants.DefaultWireGuardPort
+	}
+
+	colonyDir := loader.ColonyDir(cfg.ColonyID)
+	err = filepath.WalkDir(colonyDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(colonyDir, path)
+		if err != nil {
+			return err
+		}
+		//nolint:gosec // G304: Path is in the colony config directory.
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		colony.Files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read colony directory: %w", err)
+	}
+
+	return colony, nil
+}
+
+// Render writes the manifests deploying colony to w: the namespace, the
+// colony and agent Secrets, the colony StatefulSet and the agent DaemonSet.
+func Render(w io.Writer, colony *Colony, opts Options) error {
+	return render(w, colony, opts, "namespace.yaml.tmpl", "secrets.yaml.tmpl", "colony.yaml.tmpl", "agent.yaml.tmpl")
+}
+
+// RenderSecrets writes the Secrets holding the colony configuration and
+// agent credentials to w.
+func RenderSecrets(w io.Writer, colony *Colony, opts Options) error {
+	return render(w, colony, opts, "secrets.yaml.tmpl")
+}
+
+func render(w io.Writer, colony *Colony, opts Options, names ...string) error {
+	opts = withDefaults(opts)
+	data := manifestData{
+		Options:           opts,
+		ColonyID:          colony.ID,
+		CAFingerprint:     colony.CAFingerprint,
+		BootstrapPSK:      colony.BootstrapPSK,
+		DiscoveryEndpoint: colony.DiscoveryEndpoint,
+		WireGuardPort:     colony.WireGuardPort,
+		ColonyPort:        constants.DefaultColonyPort,
+		ConfigFiles:       configFiles(colony.Files),
+	}
+	if data.PublicEndpoint == "" {
+		data.PublicEndpoint = fmt.Sprintf("coral-colony.%s.svc.cluster.local:%d", opts.Namespace, colony.WireGuardPort)
+	}
+
+	var buf bytes.Buffer
+	for i, name := range names {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		if err := templates.ExecuteTemplate(&buf, name, data); err != nil {
+			return fmt.Errorf("failed to render %s: %w", strings.TrimSuffix(name, ".tmpl"), err)
+		}
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// configFiles returns the colony directory files sorted by path.
+func configFiles(files map[string][]byte) []configFile {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	result := make([]configFile, 0, len(paths))
+	for _, path := range paths {
+		result = append(result, configFile{
+			Key:  invalidKeyChars.ReplaceAllString(strings.ReplaceAll(path, "/", "."), "_"),
+			Path: path,
+			Data: base64.StdEncoding.EncodeToString(files[path]),
+		})
+	}
+	return result
+}
+
+func withDefaults(opts Options) Options {
+	if opts.Namespace == "" {
+		opts.Namespace = DefaultNamespace
+	}
+	if opts.ColonyImage == "" {
+		opts.ColonyImage = DefaultColonyImage
+	}
+	if opts.AgentImage == "" {
+		opts.AgentImage = DefaultAgentImage
+	}
+	if opts.StorageSize == "" {
+		opts.StorageSize = DefaultStorageSize
+	}
+	if opts.ServiceType == "" {
+		opts.ServiceType = "ClusterIP"
+	}
+	return opts
+}
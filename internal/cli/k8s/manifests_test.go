@@ -0,0 +1,100 @@
+package k8s
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func testColony() *Colony {
+	return &Colony{
+		ID:                "my-shop-prod-a3f2e1",
+		CAFingerprint:     "sha256:0123abcd",
+		BootstrapPSK:      "coral-psk:f1e2d3c4",
+		DiscoveryEndpoint: "https://discovery.example.com",
+		WireGuardPort:     41580,
+		Files: map[string][]byte{
+			"config.yaml":    []byte("colony_id: my-shop-prod-a3f2e1\n"),
+			"ca/root-ca.crt": []byte("root"),
+			"ca/root-ca.key": []byte("key"),
+		},
+	}
+}
+
+// decodeManifests decodes a multi-document YAML stream.
+func decodeManifests(t *testing.T, data []byte) []map[string]any {
+	t.Helper()
+	var docs []map[string]any
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc map[string]any
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs
+		}
+		require.NoError(t, err)
+		docs = append(docs, doc)
+	}
+}
+
+func TestRender(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, testColony(), Options{Namespace: "coral"}))
+
+	docs := decodeManifests(t, buf.Bytes())
+	var kinds []string
+	for _, doc := range docs {
+		kinds = append(kinds, doc["kind"].(string))
+		if meta := doc["metadata"].(map[string]any); doc["kind"] != "Namespace" && doc["kind"] != "ClusterRole" && doc["kind"] != "ClusterRoleBinding" {
+			assert.Equal(t, "coral", meta["namespace"], "%s namespace", doc["kind"])
+		}
+	}
+	assert.Equal(t, []string{
+		"Namespace", "Secret", "Secret", "ServiceAccount", "Service", "StatefulSet",
+		"DaemonSet", "ServiceAccount", "ClusterRole", "ClusterRoleBinding",
+	}, kinds)
+
+	out := buf.String()
+	assert.Contains(t, out, "image: "+DefaultColonyImage)
+	assert.Contains(t, out, "image: "+DefaultAgentImage)
+	assert.Contains(t, out, `value: "coral-colony.coral.svc.cluster.local:41580"`)
+	assert.Contains(t, out, `bootstrap_psk: "coral-psk:f1e2d3c4"`)
+}
+
+func TestRender_ConfigFiles(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, RenderSecrets(&buf, testColony(), Options{}))
+
+	docs := decodeManifests(t, buf.Bytes())
+	require.Len(t, docs, 2)
+
+	data := docs[0]["data"].(map[string]any)
+	assert.Equal(t, "cm9vdA==", data["ca.root-ca.crt"])
+	assert.Contains(t, data, "ca.root-ca.key")
+	assert.Contains(t, data, "config.yaml")
+}
+
+func TestConfigFiles(t *testing.T) {
+	files := configFiles(map[string][]byte{
+		"config.yaml":       nil,
+		"ca/root-ca.key":    nil,
+		"ca/weird name.crt": nil,
+	})
+
+	var keys, paths []string
+	for _, f := range files {
+		keys = append(keys, f.Key)
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{"ca.root-ca.key", "ca.weird_name.crt", "config.yaml"}, keys)
+	assert.Equal(t, []string{"ca/root-ca.key", "ca/weird name.crt", "config.yaml"}, paths)
+	for _, key := range keys {
+		assert.False(t, strings.Contains(key, "/"))
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// outputCapture buffers what a command writes to stdout so that the global
+// --output filter can be applied once the command returns.
+type outputCapture struct {
+	filter *helpers.OutputFilter
+	stdout *os.File
+	w      *os.File
+	buf    bytes.Buffer
+	done   chan struct{}
+}
+
+// startOutputFilter parses the --output filter of cmd, selects JSON output
+// for commands with a --format flag, and starts capturing stdout.
+func startOutputFilter(cmd *cobra.Command, spec string) (*outputCapture, error) {
+	filter, err := helpers.ParseOutputFilter(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// Streaming commands never return, so their output cannot be filtered.
+	for _, name := range []string{"follow", "watch"} {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Value.String() == "true" {
+			return nil, fmt.Errorf("--output cannot be used with --%s", name)
+		}
+	}
+	if f := cmd.Flags().Lookup("format"); f != nil && !f.Changed {
+		if err := f.Value.Set("json"); err != nil {
+			return nil, fmt.Errorf("failed to select JSON output: %w", err)
+		}
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture output: %w", err)
+	}
+	c := &outputCapture{filter: filter, stdout: os.Stdout, w: w, done: make(chan struct{})}
+	go func() {
+		defer close(c.done)
+		_, _ = io.Copy(&c.buf, r)
+		_ = r.Close()
+	}()
+	os.Stdout = w
+	return c, nil
+}
+
+// finish restores stdout and writes the filtered output of the command. When
+// the command failed, its error wins and unfiltered output is written as is.
+func (c *outputCapture) finish(runErr error) error {
+	os.Stdout = c.stdout
+	_ = c.w.Close()
+	<-c.done
+
+	if runErr != nil && c.buf.Len() == 0 {
+		return runErr
+	}
+
+	var out bytes.Buffer
+	if err := c.filter.Apply(c.buf.Bytes(), &out); err != nil {
+		if runErr != nil {
+			_, _ = c.stdout.Write(c.buf.Bytes())
+			return runErr
+		}
+		return err
+	}
+	_, _ = c.stdout.Write(out.Bytes())
+	return runErr
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runFiltered runs run as a command with the --output filter spec and
+// returns what reached stdout.
+func runFiltered(t *testing.T, cmd *cobra.Command, spec string, run func() error) (string, error) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	c, err := startOutputFilter(cmd, spec)
+	if err != nil {
+		_ = w.Close()
+		return "", err
+	}
+	err = c.finish(run())
+	_ = w.Close()
+
+	out, readErr := io.ReadAll(r)
+	require.NoError(t, readErr)
+	return string(out), err
+}
+
+func TestOutputFilter(t *testing.T) {
+	var format string
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().StringVar(&format, "format", "text", "")
+	cmd.Flags().Bool("follow", false, "")
+
+	out, err := runFiltered(t, cmd, "jq=.services[].name", func() error {
+		fmt.Println(`{"services": [{"name": "api"}, {"name": "web"}]}`)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "api\nweb\n", out)
+	assert.Equal(t, "json", format, "JSON output is selected")
+
+	t.Run("command error", func(t *testing.T) {
+		out, err := runFiltered(t, cmd, "jq=.status", func() error {
+			fmt.Println(`{"status": "fail"}`)
+			return errors.New("check failed")
+		})
+		assert.EqualError(t, err, "check failed")
+		assert.Equal(t, "fail\n", out, "output is still filtered")
+	})
+
+	t.Run("text output", func(t *testing.T) {
+		out, err := runFiltered(t, cmd, "jq=.", func() error {
+			fmt.Println("SERVICE  STATUS")
+			return nil
+		})
+		assert.ErrorContains(t, err, "requires JSON output")
+		assert.Empty(t, out)
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		require.NoError(t, cmd.Flags().Set("follow", "true"))
+		_, err := runFiltered(t, cmd, "jq=.", func() error { return nil })
+		assert.EqualError(t, err, "--output cannot be used with --follow")
+	})
+}
@@ -10,16 +10,22 @@ import (
 	"github.com/spf13/cobra"
 
 	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
 )
 
 // NewCPUCmd creates the cpu profiling command.
 func NewCPUCmd() *cobra.Command {
 	var (
 		serviceName     string
+		selector        string
+		group           string
 		podName         string
 		durationSeconds int32
 		frequencyHz     int32
 		format          string
+		top             int
+		folded          bool
+		overrideFreeze  bool
 	)
 
 	cmd := &cobra.Command{
@@ -43,13 +49,24 @@ Examples:
   # Profile specific pod with custom frequency
   coral profile cpu --service api --pod api-7d8f9c --frequency 49
 
-  # JSON output for processing
-  coral profile cpu --service api --duration 10 --format json`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			if serviceName == "" {
-				return fmt.Errorf("--service is required")
-			}
+  # Profile the one service labeled app=checkout in production
+  coral profile cpu --selector app=checkout,env=prod --duration 30
+
+  # Profile all services of the checkout-path group over the same 30s
+  coral profile cpu --group checkout-path --format folded | flamegraph.pl > checkout.svg
+
+  # JSON summary of the 10 hottest stacks, with the folded stacks
+  coral profile cpu --service api --duration 10 --format json --top 10 --folded
 
+JSON output ranks the hottest stacks by share of samples ("hotspots") and
+includes the folded stacks only with --folded, keeping the output compact for
+AI assistants calling it through coral_cli.
+
+With --group, every service of the service group is profiled at once. The
+command fails before profiling any service if one is not registered with an
+agent. Folded stacks are rooted at a frame naming their service, and JSON
+output lists the profile of each service.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			// Validate duration.
 			if durationSeconds <= 0 {
 				durationSeconds = 30 // Default 30 seconds
@@ -65,6 +82,24 @@ Examples:
 			if frequencyHz > 1000 {
 				return fmt.Errorf("frequency cannot exceed 1000Hz")
 			}
+			if top <= 0 {
+				return fmt.Errorf("--top must be positive")
+			}
+
+			var services []string
+			if group != "" {
+				members, err := helpers.ResolveGroup(cmd.Context(), group)
+				if err != nil {
+					return err
+				}
+				services = members
+			} else {
+				service, err := helpers.ResolveService(cmd.Context(), serviceName, selector)
+				if err != nil {
+					return err
+				}
+				serviceName = service
+			}
 
 			// Create client.
 			client, err := getColonyDebugClient()
@@ -72,6 +107,21 @@ Examples:
 				return fmt.Errorf("failed to create debug client: %w", err)
 			}
 
+			if group != "" {
+				fmt.Fprintf(os.Stderr, "Profiling CPU for %d services of group '%s' (%ds at %dHz)...\n",
+					len(services), group, durationSeconds, frequencyHz)
+
+				ctx, cancel := context.WithTimeout(context.Background(),
+					time.Duration(durationSeconds+60)*time.Second)
+				defer cancel()
+
+				return profileCPUGroup(ctx, client, services, &debugpb.ProfileCPURequest{
+					DurationSeconds: durationSeconds,
+					FrequencyHz:     frequencyHz,
+					OverrideFreeze:  overrideFreeze,
+				}, format, top, folded)
+			}
+
 			// Show progress message.
 			fmt.Fprintf(os.Stderr, "Profiling CPU for service '%s' (%ds at %dHz)...\n",
 				serviceName, durationSeconds, frequencyHz)
@@ -82,6 +132,7 @@ Examples:
 				PodName:         podName,
 				DurationSeconds: durationSeconds,
 				FrequencyHz:     frequencyHz,
+				OverrideFreeze:  overrideFreeze,
 			})
 
 			// Call ProfileCPU RPC with extended timeout.
@@ -101,7 +152,7 @@ Examples:
 			// Output results based on format.
 			switch format {
 			case "json":
-				return printCPUProfileJSON(resp.Msg)
+				return printCPUProfileJSON(resp.Msg, top, folded)
 			case "folded":
 				fallthrough
 			default:
@@ -110,13 +161,19 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required)")
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required without --selector or --group)")
+	helpers.AddSelectorFlag(cmd, &selector)
+	helpers.AddGroupFlag(cmd, &group)
 	cmd.Flags().StringVar(&podName, "pod", "", "Pod name (optional, specific instance)")
 	cmd.Flags().Int32VarP(&durationSeconds, "duration", "d", 30, "Profiling duration in seconds (default: 30s, max: 300s)")
 	cmd.Flags().Int32Var(&frequencyHz, "frequency", 99, "Sampling frequency in Hz (default: 99Hz, max: 1000Hz)")
 	cmd.Flags().StringVar(&format, "format", "folded", "Output format: folded (default), json")
-
-	cmd.MarkFlagRequired("service") //nolint:errcheck
+	cmd.Flags().IntVar(&top, "top", 20, "Number of hotspots in JSON output")
+	cmd.Flags().BoolVar(&folded, "folded", false, "Include folded stacks in JSON output")
+	helpers.AddOverrideFreezeFlag(cmd, &overrideFreeze)
+	cmd.MarkFlagsMutuallyExclusive("service", "group")
+	cmd.MarkFlagsMutuallyExclusive("selector", "group")
+	cmd.MarkFlagsMutuallyExclusive("pod", "group")
 
 	return cmd
 }
@@ -15,6 +15,7 @@
 // Where <type> can be:
 //   - cpu: Statistical CPU sampling to identify compute hotspots
 //   - memory: Heap allocation profiling to track memory usage (RFD 077)
+//   - io: File read/write latency and throughput by file and device
 //
 // # CPU Profiling
 //
@@ -39,6 +40,22 @@
 //
 //	coral profile memory --service api --duration 30
 //
+// # I/O Profiling
+//
+// I/O profiling times the reads and writes of regular files and block
+// devices with kprobes on the kernel's VFS functions, and ranks files and
+// devices by time spent in I/O or by bytes moved:
+//
+//	coral profile io --service api --duration 30 --sort throughput
+//
+// # Lock Profiling
+//
+// Lock profiling times the futex waits of a service by user stack, whether
+// they block Go, cgo or non-Go code, and prints a flame graph of the time
+// blocked in microseconds:
+//
+//	coral profile locks --service api --duration 30 | flamegraph.pl > locks.svg
+//
 // # Output Formats
 //
 // All profiling commands support multiple output formats:
@@ -46,6 +63,9 @@
 //   - folded: Folded stack format compatible with flamegraph.pl (default)
 //   - json: JSON format for programmatic processing
 //
+// I/O profiles have no stacks and print tables (text) instead of folded
+// stacks.
+//
 // Progress messages and metadata are written to stderr, while profile data
 // is written to stdout, enabling easy piping to visualization tools.
 //
@@ -0,0 +1,148 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"connectrpc.com/connect"
+
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// groupProfile is a service's entry in the JSON output of a group profile.
+type groupProfile[T any] struct {
+	Service string `json:"service"`
+	Error   string `json:"error,omitempty"`
+	Profile *T     `json:"profile,omitempty"`
+}
+
+// profileCPUGroup profiles all services of a group at once, so that their
+// profiles cover the same window. Folded stacks are rooted at a frame naming
+// their service, giving a single flame graph of the group.
+func profileCPUGroup(
+	ctx context.Context,
+	client colonyv1connect.ColonyDebugServiceClient,
+	services []string,
+	template *debugpb.ProfileCPURequest,
+	format string,
+	top int,
+	includeFolded bool,
+) error {
+	results := helpers.RunGroup(ctx, services, func(ctx context.Context, service string) (*debugpb.ProfileCPUResponse, error) {
+		resp, err := client.ProfileCPU(ctx, connect.NewRequest(&debugpb.ProfileCPURequest{
+			ServiceName:     service,
+			DurationSeconds: template.DurationSeconds,
+			FrequencyHz:     template.FrequencyHz,
+			OverrideFreeze:  template.OverrideFreeze,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Msg.Success {
+			return nil, errors.New(resp.Msg.Error)
+		}
+		return resp.Msg, nil
+	})
+
+	if format == "json" {
+		out := make([]groupProfile[cpuProfileSummary], len(results))
+		for i, r := range results {
+			out[i].Service = r.Service
+			if r.Err != nil {
+				out[i].Error = r.Err.Error()
+				continue
+			}
+			summary := summarizeCPUProfile(r.Value, top, includeFolded)
+			out[i].Profile = &summary
+		}
+		if err := printJSON(out); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Err != nil {
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "%s: %d samples, %d unique stacks\n", r.Service, r.Value.TotalSamples, len(r.Value.Samples))
+			for _, sample := range r.Value.Samples {
+				if len(sample.FrameNames) == 0 {
+					continue
+				}
+				fmt.Printf("%s;%s %d\n", r.Service, foldStack(sample.FrameNames), sample.Count)
+			}
+		}
+	}
+
+	return helpers.GroupError(results)
+}
+
+// profileMemoryGroup profiles the allocations of all services of a group at
+// once, with stacks rooted at a frame naming their service like
+// profileCPUGroup.
+func profileMemoryGroup(
+	ctx context.Context,
+	client colonyv1connect.ColonyDebugServiceClient,
+	services []string,
+	template *debugpb.ProfileMemoryRequest,
+	format string,
+) error {
+	results := helpers.RunGroup(ctx, services, func(ctx context.Context, service string) (*debugpb.ProfileMemoryResponse, error) {
+		resp, err := client.ProfileMemory(ctx, connect.NewRequest(&debugpb.ProfileMemoryRequest{
+			ServiceName:     service,
+			DurationSeconds: template.DurationSeconds,
+			SampleRateBytes: template.SampleRateBytes,
+			OverrideFreeze:  template.OverrideFreeze,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Msg.Success {
+			return nil, errors.New(resp.Msg.Error)
+		}
+		return resp.Msg, nil
+	})
+
+	if format == "json" {
+		out := make([]groupProfile[debugpb.ProfileMemoryResponse], len(results))
+		for i, r := range results {
+			out[i].Service = r.Service
+			if r.Err != nil {
+				out[i].Error = r.Err.Error()
+				continue
+			}
+			out[i].Profile = r.Value
+		}
+		if err := printJSON(out); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Err != nil {
+				continue
+			}
+			if r.Value.Stats != nil {
+				fmt.Fprintf(os.Stderr, "%s: %s allocated, %d GC cycles\n", r.Service, formatBytes(r.Value.Stats.AllocBytes), r.Value.Stats.NumGc)
+			}
+			for _, sample := range r.Value.Samples {
+				if len(sample.FrameNames) == 0 {
+					continue
+				}
+				fmt.Printf("%s;%s %d\n", r.Service, foldStack(sample.FrameNames), sample.AllocBytes)
+			}
+		}
+	}
+
+	return helpers.GroupError(results)
+}
+
+// printJSON prints v as indented JSON.
+func printJSON(v any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
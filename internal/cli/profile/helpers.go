@@ -1,9 +1,15 @@
 package profile
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
+	"math"
 	"os"
+	"slices"
+	"strings"
 
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
 	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
 	"github.com/coral-mesh/coral/internal/cli/helpers"
@@ -29,53 +35,84 @@ func printCPUProfileFolded(profile *debugpb.ProfileCPUResponse) error {
 		if len(sample.FrameNames) == 0 {
 			continue
 		}
-
-		// Folded format: frame1;frame2;frame3 count
-		// Stack frames should be from outermost (root) to innermost (leaf).
-		// Reverse the order since BPF captures innermost first.
-		for i := len(sample.FrameNames) - 1; i >= 0; i-- {
-			fmt.Print(sample.FrameNames[i])
-			if i > 0 {
-				fmt.Print(";")
-			}
-		}
-		fmt.Printf(" %d\n", sample.Count)
+		fmt.Printf("%s %d\n", foldStack(sample.FrameNames), sample.Count)
 	}
 
 	return nil
 }
 
-// printCPUProfileJSON prints the profile in JSON format.
-func printCPUProfileJSON(profile *debugpb.ProfileCPUResponse) error {
-	// Simple JSON output without external dependencies.
-	fmt.Println("{")
-	fmt.Printf("  \"total_samples\": %d,\n", profile.TotalSamples)
-	fmt.Printf("  \"lost_samples\": %d,\n", profile.LostSamples)
-	fmt.Printf("  \"unique_stacks\": %d,\n", len(profile.Samples))
-	fmt.Println("  \"samples\": [")
+// foldStack joins frames captured innermost first into a folded stack
+// (frame1;frame2;frame3), ordered from outermost (root) to innermost (leaf).
+func foldStack(frames []string) string {
+	rootFirst := slices.Clone(frames)
+	slices.Reverse(rootFirst)
+	return strings.Join(rootFirst, ";")
+}
+
+// cpuHotspot is a stack ranked by its share of the CPU samples.
+type cpuHotspot struct {
+	Rank        int      `json:"rank"`
+	Frames      []string `json:"frames"` // Root to leaf.
+	Percentage  float64  `json:"percentage"`
+	SampleCount uint64   `json:"sample_count"`
+}
+
+// cpuProfileSummary is the JSON output of 'coral profile cpu'. It ranks the
+// hottest stacks so that callers such as AI assistants get a compact answer;
+// the full folded stacks are included on request.
+type cpuProfileSummary struct {
+	TotalSamples uint64       `json:"total_samples"`
+	LostSamples  uint32       `json:"lost_samples"`
+	UniqueStacks int          `json:"unique_stacks"`
+	Hotspots     []cpuHotspot `json:"hotspots"`
+	Folded       []string     `json:"folded,omitempty"`
+}
+
+// summarizeCPUProfile ranks the top stacks of profile by sample count.
+func summarizeCPUProfile(profile *debugpb.ProfileCPUResponse, top int, includeFolded bool) cpuProfileSummary {
+	summary := cpuProfileSummary{
+		TotalSamples: profile.TotalSamples,
+		LostSamples:  profile.LostSamples,
+		UniqueStacks: len(profile.Samples),
+		Hotspots:     []cpuHotspot{},
+	}
+
+	samples := slices.Clone(profile.Samples)
+	slices.SortStableFunc(samples, func(a, b *agentv1.StackSample) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	var total uint64
+	for _, sample := range samples {
+		total += sample.Count
+	}
 
-	for i, sample := range profile.Samples {
-		fmt.Println("    {")
-		fmt.Println("      \"frames\": [")
-		for j, frame := range sample.FrameNames {
-			fmt.Printf("        %q", frame)
-			if j < len(sample.FrameNames)-1 {
-				fmt.Println(",")
-			} else {
-				fmt.Println()
-			}
+	for _, sample := range samples {
+		if len(sample.FrameNames) == 0 {
+			continue
 		}
-		fmt.Println("      ],")
-		fmt.Printf("      \"count\": %d\n", sample.Count)
-		if i < len(profile.Samples)-1 {
-			fmt.Println("    },")
-		} else {
-			fmt.Println("    }")
+		if includeFolded {
+			summary.Folded = append(summary.Folded, fmt.Sprintf("%s %d", foldStack(sample.FrameNames), sample.Count))
 		}
+		if len(summary.Hotspots) >= top {
+			continue
+		}
+		frames := slices.Clone(sample.FrameNames)
+		slices.Reverse(frames)
+		summary.Hotspots = append(summary.Hotspots, cpuHotspot{
+			Rank:        len(summary.Hotspots) + 1,
+			Frames:      frames,
+			Percentage:  math.Round(float64(sample.Count)/float64(total)*10000) / 100,
+			SampleCount: sample.Count,
+		})
 	}
 
-	fmt.Println("  ]")
-	fmt.Println("}")
+	return summary
+}
 
-	return nil
+// printCPUProfileJSON prints the profile summary in JSON format.
+func printCPUProfileJSON(profile *debugpb.ProfileCPUResponse, top int, includeFolded bool) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(summarizeCPUProfile(profile, top, includeFolded))
 }
@@ -0,0 +1,41 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestSummarizeCPUProfile(t *testing.T) {
+	profile := &debugpb.ProfileCPUResponse{
+		TotalSamples: 100,
+		LostSamples:  2,
+		Samples: []*agentv1.StackSample{
+			{FrameNames: []string{"parse", "handle", "main"}, Count: 20},
+			{FrameNames: []string{"hash", "handle", "main"}, Count: 70},
+			{FrameNames: nil, Count: 5},
+			{FrameNames: []string{"gc"}, Count: 5},
+		},
+	}
+
+	summary := summarizeCPUProfile(profile, 2, false)
+	assert.Equal(t, uint64(100), summary.TotalSamples)
+	assert.Equal(t, uint32(2), summary.LostSamples)
+	assert.Equal(t, 4, summary.UniqueStacks)
+	assert.Nil(t, summary.Folded)
+
+	require.Len(t, summary.Hotspots, 2)
+	assert.Equal(t, cpuHotspot{Rank: 1, Frames: []string{"main", "handle", "hash"}, Percentage: 70, SampleCount: 70}, summary.Hotspots[0])
+	assert.Equal(t, cpuHotspot{Rank: 2, Frames: []string{"main", "handle", "parse"}, Percentage: 20, SampleCount: 20}, summary.Hotspots[1])
+
+	// The response is not modified.
+	assert.Equal(t, []string{"parse", "handle", "main"}, profile.Samples[0].FrameNames)
+
+	summary = summarizeCPUProfile(profile, 20, true)
+	assert.Len(t, summary.Hotspots, 3)
+	assert.Equal(t, []string{"main;handle;hash 70", "main;handle;parse 20", "gc 5"}, summary.Folded)
+}
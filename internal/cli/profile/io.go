@@ -0,0 +1,227 @@
+package profile
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// NewIOCmd creates the file I/O profiling command.
+func NewIOCmd() *cobra.Command {
+	var (
+		serviceName     string
+		selector        string
+		durationSeconds int32
+		sortBy          string
+		top             int
+		format          string
+		overrideFreeze  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "io",
+		Short: "Profile file I/O latency and throughput on-demand",
+		Long: `Profile the file reads and writes of a target service and show the files
+and devices it spends the most time on or moves the most bytes through.
+
+The agent attaches eBPF kprobes to the kernel's VFS read and write functions,
+filtered to the service's cgroup or processes, and times every read and
+write of a regular file or block device. It complements CPU profiles for
+services that are slow without being busy.
+
+Examples:
+  # Files and devices with the most I/O time over 30s
+  coral profile io --service api
+
+  # The 5 files moving the most bytes
+  coral profile io --service api --duration 60 --sort throughput --top 5
+
+  # JSON output
+  coral profile io --service api --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if durationSeconds <= 0 {
+				durationSeconds = 30 // Default 30 seconds
+			}
+			if durationSeconds > 300 {
+				return fmt.Errorf("duration cannot exceed 300 seconds")
+			}
+			if top <= 0 {
+				return fmt.Errorf("--top must be positive")
+			}
+			if sortBy != "latency" && sortBy != "throughput" {
+				return fmt.Errorf("--sort must be latency or throughput")
+			}
+
+			service, err := helpers.ResolveService(cmd.Context(), serviceName, selector)
+			if err != nil {
+				return err
+			}
+			serviceName = service
+
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "Profiling file I/O for service '%s' (%ds)...\n",
+				serviceName, durationSeconds)
+
+			ctx, cancel := context.WithTimeout(context.Background(),
+				time.Duration(durationSeconds+60)*time.Second)
+			defer cancel()
+
+			resp, err := client.ProfileIO(ctx, connect.NewRequest(&debugpb.ProfileIORequest{
+				ServiceName:     serviceName,
+				DurationSeconds: durationSeconds,
+				OverrideFreeze:  overrideFreeze,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to collect I/O profile: %w", err)
+			}
+
+			if !resp.Msg.Success {
+				return fmt.Errorf("I/O profiling failed: %s", resp.Msg.Error)
+			}
+
+			profile := resp.Msg
+			profile.Files = topIOFiles(profile.Files, sortBy, top)
+			profile.Devices = topIODevices(profile.Devices, sortBy)
+
+			switch format {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(profile)
+			default:
+				return printIOProfile(profile, sortBy)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required without --selector)")
+	helpers.AddSelectorFlag(cmd, &selector)
+	cmd.Flags().Int32VarP(&durationSeconds, "duration", "d", 30, "Profiling duration in seconds (default: 30s, max: 300s)")
+	cmd.Flags().StringVar(&sortBy, "sort", "latency", "Rank files and devices by: latency (time in I/O), throughput (bytes)")
+	cmd.Flags().IntVar(&top, "top", 10, "Number of files to show")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text, json")
+	helpers.AddOverrideFreezeFlag(cmd, &overrideFreeze)
+
+	return cmd
+}
+
+// ioRank returns the ranking key of stats for sortBy.
+func ioRank(stats *agentv1.IOStats, sortBy string) uint64 {
+	if sortBy == "throughput" {
+		return stats.GetReadBytes() + stats.GetWriteBytes()
+	}
+	return stats.GetReadNs() + stats.GetWriteNs()
+}
+
+// topIOFiles returns the top files by latency or throughput.
+func topIOFiles(files []*agentv1.FileIO, sortBy string, top int) []*agentv1.FileIO {
+	files = slices.Clone(files)
+	slices.SortStableFunc(files, func(a, b *agentv1.FileIO) int {
+		return cmp.Compare(ioRank(b.Stats, sortBy), ioRank(a.Stats, sortBy))
+	})
+	return files[:min(top, len(files))]
+}
+
+// topIODevices returns the devices by latency or throughput.
+func topIODevices(devices []*agentv1.DeviceIO, sortBy string) []*agentv1.DeviceIO {
+	devices = slices.Clone(devices)
+	slices.SortStableFunc(devices, func(a, b *agentv1.DeviceIO) int {
+		return cmp.Compare(ioRank(b.Stats, sortBy), ioRank(a.Stats, sortBy))
+	})
+	return devices
+}
+
+// printIOProfile prints the top files and devices of an I/O profile.
+func printIOProfile(profile *debugpb.ProfileIOResponse, sortBy string) error {
+	window := profile.EndTime.AsTime().Sub(profile.StartTime.AsTime())
+	fmt.Printf("File I/O of %s (%s), %s\n\n", profile.ServiceName, profile.Target, window.Round(time.Second))
+
+	fmt.Printf("Reads:  %s\n", latencySummary(profile.ReadLatency))
+	fmt.Printf("Writes: %s\n\n", latencySummary(profile.WriteLatency))
+
+	if len(profile.Files) == 0 {
+		fmt.Println("No file reads or writes.")
+		return nil
+	}
+
+	rate := func(bytes uint64) string {
+		if window <= 0 {
+			return "-"
+		}
+		return formatBytes(int64(float64(bytes)/window.Seconds())) + "/s" // #nosec G115
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Top files by %s:\n", sortBy)
+	fmt.Fprintln(w, "FILE\tREADS\tWRITES\tREAD\tWRITTEN\tI/O TIME\tMAX")
+	for _, f := range profile.Files {
+		name := f.Path
+		if name == "" {
+			name = fmt.Sprintf("(inode %d on %s)", f.Inode, f.Device)
+		}
+		s := f.Stats
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			name, s.GetReads(), s.GetWrites(),
+			formatBytes(int64(s.GetReadBytes())), formatBytes(int64(s.GetWriteBytes())), // #nosec G115
+			time.Duration(s.GetReadNs()+s.GetWriteNs()), time.Duration(s.GetMaxLatencyNs())) // #nosec G115
+	}
+
+	fmt.Fprintf(w, "\nDevices:\n")
+	fmt.Fprintln(w, "DEVICE\tMOUNT\tREADS\tWRITES\tREAD/S\tWRITTEN/S\tI/O TIME")
+	for _, d := range profile.Devices {
+		mount := d.MountPoint
+		if mount == "" {
+			mount = "-"
+		}
+		name := d.Device
+		if d.Source != "" {
+			name = fmt.Sprintf("%s (%s, %s)", d.Source, d.Device, d.FsType)
+		}
+		s := d.Stats
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
+			name, mount, s.GetReads(), s.GetWrites(),
+			rate(s.GetReadBytes()), rate(s.GetWriteBytes()),
+			time.Duration(s.GetReadNs()+s.GetWriteNs())) // #nosec G115
+	}
+	return w.Flush()
+}
+
+// latencySummary describes the count and percentiles of a latency
+// histogram in log2 buckets.
+func latencySummary(buckets []*agentv1.LatencyBucket) string {
+	var count uint64
+	for _, b := range buckets {
+		count += b.Count
+	}
+	if count == 0 {
+		return "none"
+	}
+	quantile := func(q float64) time.Duration {
+		rank := uint64(q * float64(count))
+		var seen uint64
+		for _, b := range buckets {
+			seen += b.Count
+			if seen > rank {
+				return time.Duration(b.UpperBoundNs) // #nosec G115
+			}
+		}
+		return time.Duration(buckets[len(buckets)-1].UpperBoundNs) // #nosec G115
+	}
+	return fmt.Sprintf("%d, p50 < %s, p99 < %s, max < %s", count, quantile(0.50), quantile(0.99), quantile(1))
+}
@@ -0,0 +1,35 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+)
+
+func TestTopIOFiles(t *testing.T) {
+	files := []*agentv1.FileIO{
+		{Path: "/var/log/api.log", Stats: &agentv1.IOStats{Writes: 1000, WriteBytes: 4 << 20, WriteNs: 2_000_000}},
+		{Path: "/data/app.db", Stats: &agentv1.IOStats{Reads: 50, ReadBytes: 400 << 10, ReadNs: 9_000_000}},
+		{Path: "/etc/app.yaml", Stats: &agentv1.IOStats{Reads: 1, ReadBytes: 512, ReadNs: 10_000}},
+	}
+
+	byLatency := topIOFiles(files, "latency", 2)
+	assert.Equal(t, []string{"/data/app.db", "/var/log/api.log"}, []string{byLatency[0].Path, byLatency[1].Path})
+
+	byThroughput := topIOFiles(files, "throughput", 10)
+	assert.Len(t, byThroughput, 3)
+	assert.Equal(t, "/var/log/api.log", byThroughput[0].Path)
+	assert.Equal(t, "/etc/app.yaml", byThroughput[2].Path)
+
+	assert.Equal(t, "/var/log/api.log", files[0].Path, "the input is not reordered")
+}
+
+func TestLatencySummary(t *testing.T) {
+	assert.Equal(t, "none", latencySummary(nil))
+	assert.Equal(t, "100, p50 < 16.384µs, p99 < 1.048576ms, max < 1.048576ms", latencySummary([]*agentv1.LatencyBucket{
+		{UpperBoundNs: 16384, Count: 98},
+		{UpperBoundNs: 1048576, Count: 2},
+	}))
+}
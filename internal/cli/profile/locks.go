@@ -0,0 +1,211 @@
+package profile
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// NewLocksCmd creates the lock contention profiling command.
+func NewLocksCmd() *cobra.Command {
+	var (
+		serviceName     string
+		selector        string
+		durationSeconds int32
+		format          string
+		top             int
+		folded          bool
+		overrideFreeze  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "locks",
+		Short: "Profile lock contention on-demand",
+		Long: `Profile where a target service blocks on locks, as a flame graph of the
+time its threads spend waiting in futex syscalls by user stack.
+
+Contended pthread mutexes, condition variables and semaphores, and the Go
+runtime's own locks, block in futex waits, so unlike Go runtime mutex
+profiles this covers C code called through cgo and non-Go services too. The agent times the waits
+with eBPF raw tracepoints, filtered to the service's cgroup or processes.
+
+Threads waiting for work, such as idle Go runtime threads or thread pools,
+also wait on futexes and show up under their own stacks. Goroutines waiting
+on a sync.Mutex are parked by the Go scheduler without a futex wait. Stacks
+are unwound with frame pointers, so frames of code built without them may
+be missing.
+
+Examples:
+  # Lock contention flame graph over 30s (requires flamegraph.pl)
+  coral profile locks --service api --format folded | flamegraph.pl --countname us > locks.svg
+
+  # JSON summary of the 10 stacks blocked the longest
+  coral profile locks --service api --duration 60 --format json --top 10
+
+Folded stacks are weighted by the time blocked, in microseconds.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if durationSeconds <= 0 {
+				durationSeconds = 30 // Default 30 seconds
+			}
+			if durationSeconds > 300 {
+				return fmt.Errorf("duration cannot exceed 300 seconds")
+			}
+			if top <= 0 {
+				return fmt.Errorf("--top must be positive")
+			}
+
+			service, err := helpers.ResolveService(cmd.Context(), serviceName, selector)
+			if err != nil {
+				return err
+			}
+			serviceName = service
+
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "Profiling lock contention for service '%s' (%ds)...\n",
+				serviceName, durationSeconds)
+
+			ctx, cancel := context.WithTimeout(context.Background(),
+				time.Duration(durationSeconds+60)*time.Second)
+			defer cancel()
+
+			resp, err := client.ProfileLocks(ctx, connect.NewRequest(&debugpb.ProfileLocksRequest{
+				ServiceName:     serviceName,
+				DurationSeconds: durationSeconds,
+				OverrideFreeze:  overrideFreeze,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to collect lock profile: %w", err)
+			}
+
+			if !resp.Msg.Success {
+				return fmt.Errorf("lock profiling failed: %s", resp.Msg.Error)
+			}
+
+			switch format {
+			case "json":
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(summarizeLockProfile(resp.Msg, top, folded))
+			default:
+				return printLockProfileFolded(resp.Msg)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required without --selector)")
+	helpers.AddSelectorFlag(cmd, &selector)
+	cmd.Flags().Int32VarP(&durationSeconds, "duration", "d", 30, "Profiling duration in seconds (default: 30s, max: 300s)")
+	cmd.Flags().StringVar(&format, "format", "folded", "Output format: folded (default), json")
+	cmd.Flags().IntVar(&top, "top", 20, "Number of contended stacks in JSON output")
+	cmd.Flags().BoolVar(&folded, "folded", false, "Include folded stacks in JSON output")
+	helpers.AddOverrideFreezeFlag(cmd, &overrideFreeze)
+
+	return cmd
+}
+
+// lockWeight returns the flame graph weight of a stack: the time blocked in
+// microseconds, at least 1 so that short waits are not dropped.
+func lockWeight(stack *agentv1.LockWaitStack) uint64 {
+	return max(1, (stack.WaitNs+500)/1000)
+}
+
+// printLockProfileFolded prints the profile in folded stack format.
+func printLockProfileFolded(profile *debugpb.ProfileLocksResponse) error {
+	// Print summary to stderr.
+	fmt.Fprintf(os.Stderr, "Target: %s\n", profile.Target)
+	fmt.Fprintf(os.Stderr, "Futex waits: %d, blocked %s\n", profile.TotalWaits,
+		time.Duration(profile.TotalWaitNs).Round(time.Microsecond)) // #nosec G115 -- sums of wait times fit
+	fmt.Fprintf(os.Stderr, "Unique stacks: %d\n", len(profile.Stacks))
+	fmt.Fprintf(os.Stderr, "\n")
+
+	// Print folded stacks to stdout (for piping to flamegraph.pl).
+	for _, stack := range profile.Stacks {
+		if len(stack.FrameNames) == 0 {
+			continue
+		}
+		fmt.Printf("%s %d\n", foldStack(stack.FrameNames), lockWeight(stack))
+	}
+
+	return nil
+}
+
+// lockHotspot is a stack ranked by its share of the time blocked.
+type lockHotspot struct {
+	Rank       int      `json:"rank"`
+	Frames     []string `json:"frames"` // Root to leaf.
+	Percentage float64  `json:"percentage"`
+	Waits      uint64   `json:"waits"`
+	WaitNs     uint64   `json:"wait_ns"`
+	MaxWaitNs  uint64   `json:"max_wait_ns"`
+}
+
+// lockProfileSummary is the JSON output of 'coral profile locks', ranking
+// the stacks blocked the longest like cpuProfileSummary.
+type lockProfileSummary struct {
+	Target       string        `json:"target"`
+	TotalWaits   uint64        `json:"total_waits"`
+	TotalWaitNs  uint64        `json:"total_wait_ns"`
+	UniqueStacks int           `json:"unique_stacks"`
+	Hotspots     []lockHotspot `json:"hotspots"`
+	Folded       []string      `json:"folded,omitempty"`
+}
+
+// summarizeLockProfile ranks the top stacks of profile by time blocked.
+func summarizeLockProfile(profile *debugpb.ProfileLocksResponse, top int, includeFolded bool) lockProfileSummary {
+	summary := lockProfileSummary{
+		Target:       profile.Target,
+		TotalWaits:   profile.TotalWaits,
+		TotalWaitNs:  profile.TotalWaitNs,
+		UniqueStacks: len(profile.Stacks),
+		Hotspots:     []lockHotspot{},
+	}
+
+	stacks := slices.Clone(profile.Stacks)
+	slices.SortStableFunc(stacks, func(a, b *agentv1.LockWaitStack) int {
+		return cmp.Compare(b.WaitNs, a.WaitNs)
+	})
+
+	for _, stack := range stacks {
+		if len(stack.FrameNames) == 0 {
+			continue
+		}
+		if includeFolded {
+			summary.Folded = append(summary.Folded, fmt.Sprintf("%s %d", foldStack(stack.FrameNames), lockWeight(stack)))
+		}
+		if len(summary.Hotspots) >= top {
+			continue
+		}
+		frames := slices.Clone(stack.FrameNames)
+		slices.Reverse(frames)
+		var percentage float64
+		if profile.TotalWaitNs > 0 {
+			percentage = math.Round(float64(stack.WaitNs)/float64(profile.TotalWaitNs)*10000) / 100
+		}
+		summary.Hotspots = append(summary.Hotspots, lockHotspot{
+			Rank:       len(summary.Hotspots) + 1,
+			Frames:     frames,
+			Percentage: percentage,
+			Waits:      stack.Waits,
+			WaitNs:     stack.WaitNs,
+			MaxWaitNs:  stack.MaxWaitNs,
+		})
+	}
+
+	return summary
+}
@@ -0,0 +1,42 @@
+package profile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestSummarizeLockProfile(t *testing.T) {
+	profile := &debugpb.ProfileLocksResponse{
+		Target:      "cgroup /system.slice/api.service",
+		TotalWaits:  46,
+		TotalWaitNs: 100_000_000,
+		Stacks: []*agentv1.LockWaitStack{
+			{FrameNames: []string{"runtime.futex", "runtime.lock2", "main.handle"}, Waits: 3, WaitNs: 25_000_000, MaxWaitNs: 10_000_000},
+			{FrameNames: []string{"[libc.so.6] __lll_lock_wait", "[libc.so.6] pthread_mutex_lock", "main.flush"}, Waits: 40, WaitNs: 75_000_000, MaxWaitNs: 5_000_000},
+			{FrameNames: []string{"[unknown]"}, Waits: 3, WaitNs: 400},
+		},
+	}
+
+	summary := summarizeLockProfile(profile, 2, true)
+	assert.Equal(t, 3, summary.UniqueStacks)
+	require.Len(t, summary.Hotspots, 2)
+
+	h := summary.Hotspots[0]
+	assert.Equal(t, 1, h.Rank)
+	assert.Equal(t, []string{"main.flush", "[libc.so.6] pthread_mutex_lock", "[libc.so.6] __lll_lock_wait"}, h.Frames)
+	assert.Equal(t, 75.0, h.Percentage)
+	assert.Equal(t, uint64(40), h.Waits)
+	assert.Equal(t, 25.0, summary.Hotspots[1].Percentage)
+
+	// Folded stacks are weighted in microseconds, short waits counting 1.
+	assert.Equal(t, []string{
+		"main.flush;[libc.so.6] pthread_mutex_lock;[libc.so.6] __lll_lock_wait 75000",
+		"main.handle;runtime.lock2;runtime.futex 25000",
+		"[unknown] 1",
+	}, summary.Folded)
+}
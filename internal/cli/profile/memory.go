@@ -11,15 +11,19 @@ import (
 	"github.com/spf13/cobra"
 
 	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
 )
 
 // NewMemoryCmd creates the memory profiling command.
 func NewMemoryCmd() *cobra.Command {
 	var (
-		serviceName string
-		duration    int32
-		sampleRate  int32
-		format      string
+		serviceName    string
+		selector       string
+		group          string
+		duration       int32
+		sampleRate     int32
+		format         string
+		overrideFreeze bool
 	)
 
 	cmd := &cobra.Command{
@@ -35,12 +39,12 @@ For historical memory profiles, use 'coral query memory-profile --since 1h'.
 Examples:
   coral profile memory --service api --duration 30
   coral profile memory --service api --sample-rate 4096
-  coral profile memory --service api --duration 10 --format json`,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			if serviceName == "" {
-				return fmt.Errorf("--service is required")
-			}
+  coral profile memory --service api --duration 10 --format json
+  coral profile memory --group checkout-path --duration 30
 
+With --group, every service of the service group is profiled at once, after
+checking that all of them are registered with an agent.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if duration <= 0 {
 				duration = 30
 			}
@@ -48,11 +52,41 @@ Examples:
 				return fmt.Errorf("duration cannot exceed 300 seconds")
 			}
 
+			var services []string
+			if group != "" {
+				members, err := helpers.ResolveGroup(cmd.Context(), group)
+				if err != nil {
+					return err
+				}
+				services = members
+			} else {
+				service, err := helpers.ResolveService(cmd.Context(), serviceName, selector)
+				if err != nil {
+					return err
+				}
+				serviceName = service
+			}
+
 			client, err := getColonyDebugClient()
 			if err != nil {
 				return fmt.Errorf("failed to create debug client: %w", err)
 			}
 
+			if group != "" {
+				fmt.Fprintf(os.Stderr, "Profiling memory for %d services of group '%s' (%ds)...\n",
+					len(services), group, duration)
+
+				ctx, cancel := context.WithTimeout(context.Background(),
+					time.Duration(duration+60)*time.Second)
+				defer cancel()
+
+				return profileMemoryGroup(ctx, client, services, &debugpb.ProfileMemoryRequest{
+					DurationSeconds: duration,
+					SampleRateBytes: sampleRate,
+					OverrideFreeze:  overrideFreeze,
+				}, format)
+			}
+
 			fmt.Fprintf(os.Stderr, "Profiling memory for service '%s' (%ds)...\n",
 				serviceName, duration)
 
@@ -60,6 +94,7 @@ Examples:
 				ServiceName:     serviceName,
 				DurationSeconds: duration,
 				SampleRateBytes: sampleRate,
+				OverrideFreeze:  overrideFreeze,
 			})
 
 			ctx, cancel := context.WithTimeout(context.Background(),
@@ -85,12 +120,15 @@ Examples:
 		},
 	}
 
-	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required)")
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required without --selector or --group)")
+	helpers.AddSelectorFlag(cmd, &selector)
+	helpers.AddGroupFlag(cmd, &group)
 	cmd.Flags().Int32VarP(&duration, "duration", "d", 30, "Profiling duration in seconds")
 	cmd.Flags().Int32Var(&sampleRate, "sample-rate", 512, "Sampling rate in KB (default: 512KB)")
 	cmd.Flags().StringVar(&format, "format", "folded", "Output format: folded, json")
-
-	cmd.MarkFlagRequired("service") //nolint:errcheck
+	helpers.AddOverrideFreezeFlag(cmd, &overrideFreeze)
+	cmd.MarkFlagsMutuallyExclusive("service", "group")
+	cmd.MarkFlagsMutuallyExclusive("selector", "group")
 
 	return cmd
 }
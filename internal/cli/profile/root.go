@@ -12,17 +12,26 @@ func NewProfileCmd() *cobra.Command {
 This command group provides on-demand profiling capabilities:
 - CPU profiling: Statistical sampling to identify hotspots
 - Memory profiling: Allocation tracking and heap analysis
+- I/O profiling: File read/write latency and throughput by file and device
+- Lock profiling: Time blocked on mutexes and other futexes, by stack
+- Scheduled profiling: Recurring jobs run by the colony, with retained results
 
 For historical profile queries, use 'coral query cpu-profile' or 'coral query memory-profile'.
 
 Examples:
   coral profile cpu --service api --duration 30
-  coral profile memory --service api --duration 30`,
+  coral profile memory --service api --duration 30
+  coral profile io --service api --duration 30
+  coral profile locks --service api --duration 30
+  coral profile schedule create --service api --duration 30 --every 1h`,
 	}
 
 	// Add subcommands.
 	cmd.AddCommand(NewCPUCmd())
 	cmd.AddCommand(NewMemoryCmd())
+	cmd.AddCommand(NewIOCmd())
+	cmd.AddCommand(NewLocksCmd())
+	cmd.AddCommand(NewScheduleCmd())
 
 	return cmd
 }
@@ -0,0 +1,298 @@
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+// NewScheduleCmd creates the `coral profile schedule` command.
+func NewScheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage recurring profiling jobs",
+		Long: `Define recurring profiling jobs that the colony runs on an interval.
+
+Each run collects a profile exactly like 'coral profile cpu' or
+'coral profile memory' and stores the result in the colony, where it is kept
+for 7 days. The first run starts within seconds of creating a schedule.
+
+Examples:
+  # Profile api CPU for 30s every hour
+  coral profile schedule create --service api --duration 30 --every 1h
+
+  # Profile api memory for 60s every 6 hours
+  coral profile schedule create --service api --type memory --duration 60 --every 6h
+
+  # Browse results and render a flame graph
+  coral profile schedule runs --service api
+  coral profile schedule show <run-id> | flamegraph.pl > cpu.svg`,
+	}
+
+	cmd.AddCommand(newScheduleCreateCmd())
+	cmd.AddCommand(newScheduleListCmd())
+	cmd.AddCommand(newScheduleDeleteCmd())
+	cmd.AddCommand(newScheduleRunsCmd())
+	cmd.AddCommand(newScheduleShowCmd())
+
+	return cmd
+}
+
+func newScheduleCreateCmd() *cobra.Command {
+	var (
+		serviceName     string
+		podName         string
+		profileType     string
+		durationSeconds int32
+		frequencyHz     int32
+		every           time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a recurring profiling job",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			resp, err := client.CreateProfileSchedule(context.Background(), connect.NewRequest(&debugpb.CreateProfileScheduleRequest{
+				ServiceName:     serviceName,
+				PodName:         podName,
+				ProfileType:     profileType,
+				DurationSeconds: durationSeconds,
+				Interval:        durationpb.New(every),
+				FrequencyHz:     frequencyHz,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to create profile schedule: %w", err)
+			}
+
+			s := resp.Msg.Schedule
+			fmt.Printf("Created schedule %s: %s profile of %s for %ds every %s\n",
+				s.Id, s.ProfileType, s.ServiceName, s.DurationSeconds, s.Interval.AsDuration())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Service name (required)")
+	cmd.Flags().StringVar(&podName, "pod", "", "Pod name (optional, specific instance)")
+	cmd.Flags().StringVar(&profileType, "type", "cpu", "Profile type: cpu or memory")
+	cmd.Flags().Int32VarP(&durationSeconds, "duration", "d", 30, "Profiling duration in seconds (max: 300s)")
+	cmd.Flags().Int32Var(&frequencyHz, "frequency", 99, "CPU sampling frequency in Hz (max: 1000Hz)")
+	cmd.Flags().DurationVar(&every, "every", time.Hour, "Time between runs (min: 1m)")
+	_ = cmd.MarkFlagRequired("service")
+
+	return cmd
+}
+
+func newScheduleListCmd() *cobra.Command {
+	var (
+		serviceName string
+		format      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recurring profiling jobs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			resp, err := client.ListProfileSchedules(context.Background(), connect.NewRequest(&debugpb.ListProfileSchedulesRequest{
+				ServiceName: serviceName,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to list profile schedules: %w", err)
+			}
+
+			if format == "json" {
+				return json.NewEncoder(os.Stdout).Encode(resp.Msg.Schedules)
+			}
+
+			if len(resp.Msg.Schedules) == 0 {
+				fmt.Println("No profile schedules.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			defer func() { _ = w.Flush() }()
+			if _, err := fmt.Fprintln(w, "ID\tService\tType\tDuration\tEvery\tLast Run\tStatus\tNext Run"); err != nil {
+				return err
+			}
+			for _, s := range resp.Msg.Schedules {
+				lastRun, status := "-", "-"
+				if s.LastRunAt != nil {
+					lastRun = s.LastRunAt.AsTime().Local().Format(time.DateTime)
+					status = s.LastStatus
+				}
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%ds\t%s\t%s\t%s\t%s\n",
+					s.Id,
+					s.ServiceName,
+					s.ProfileType,
+					s.DurationSeconds,
+					s.Interval.AsDuration(),
+					lastRun,
+					status,
+					s.NextRunAt.AsTime().Local().Format(time.DateTime),
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Filter by service name")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func newScheduleDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <schedule-id>",
+		Short: "Delete a recurring profiling job and its results",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			if _, err := client.DeleteProfileSchedule(context.Background(), connect.NewRequest(&debugpb.DeleteProfileScheduleRequest{
+				Id: args[0],
+			})); err != nil {
+				return fmt.Errorf("failed to delete profile schedule: %w", err)
+			}
+
+			fmt.Printf("Deleted schedule %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newScheduleRunsCmd() *cobra.Command {
+	var (
+		scheduleID  string
+		serviceName string
+		limit       int32
+		format      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "runs",
+		Short: "List results of scheduled profiling jobs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			resp, err := client.ListProfileRuns(context.Background(), connect.NewRequest(&debugpb.ListProfileRunsRequest{
+				ScheduleId:  scheduleID,
+				ServiceName: serviceName,
+				Limit:       limit,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to list profile runs: %w", err)
+			}
+
+			if format == "json" {
+				return json.NewEncoder(os.Stdout).Encode(resp.Msg.Runs)
+			}
+
+			if len(resp.Msg.Runs) == 0 {
+				fmt.Println("No profile runs.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			defer func() { _ = w.Flush() }()
+			if _, err := fmt.Fprintln(w, "ID\tService\tType\tStarted\tStatus\tTotal\tStacks"); err != nil {
+				return err
+			}
+			for _, r := range resp.Msg.Runs {
+				status := r.Status
+				if r.Error != "" {
+					status += ": " + r.Error
+				}
+				if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+					r.Id,
+					r.ServiceName,
+					r.ProfileType,
+					r.StartedAt.AsTime().Local().Format(time.DateTime),
+					status,
+					formatRunTotal(r),
+					r.UniqueStacks,
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&scheduleID, "schedule", "", "Filter by schedule ID")
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Filter by service name")
+	cmd.Flags().Int32Var(&limit, "limit", 50, "Maximum number of runs to list")
+	cmd.Flags().StringVar(&format, "format", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func newScheduleShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <run-id>",
+		Short: "Print the stacks of a profile run in folded format",
+		Long: `Print the stacks collected by a scheduled profile run in folded format,
+ready for flamegraph.pl. Values are sample counts for CPU profiles and
+allocated bytes for memory profiles.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getColonyDebugClient()
+			if err != nil {
+				return fmt.Errorf("failed to create debug client: %w", err)
+			}
+
+			resp, err := client.GetProfileRun(context.Background(), connect.NewRequest(&debugpb.GetProfileRunRequest{
+				Id: args[0],
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to get profile run: %w", err)
+			}
+
+			// Print summary to stderr so stdout can be piped to flamegraph.pl.
+			run := resp.Msg.Run
+			fmt.Fprintf(os.Stderr, "%s profile of %s at %s (%s)\n",
+				run.ProfileType, run.ServiceName, run.StartedAt.AsTime().Local().Format(time.DateTime), run.Status)
+			if run.Error != "" {
+				return fmt.Errorf("profile run failed: %s", run.Error)
+			}
+			fmt.Fprintf(os.Stderr, "Total: %s, unique stacks: %d\n\n", formatRunTotal(run), run.UniqueStacks)
+
+			fmt.Print(resp.Msg.Folded)
+			return nil
+		},
+	}
+}
+
+// formatRunTotal formats a run's total as samples or allocated bytes.
+func formatRunTotal(run *debugpb.ProfileRun) string {
+	if run.ProfileType == "memory" {
+		return formatBytes(int64(run.Total)) // #nosec G115
+	}
+	return fmt.Sprintf("%d samples", run.Total)
+}
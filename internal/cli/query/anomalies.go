@@ -0,0 +1,174 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// anomalyJSON is the JSON-serializable representation of an anomaly score.
+type anomalyJSON struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Service        string    `json:"service"`
+	Route          string    `json:"route"`
+	Metric         string    `json:"metric"`
+	Value          float64   `json:"value"`
+	BaselineMean   float64   `json:"baseline_mean"`
+	BaselineStddev float64   `json:"baseline_stddev"`
+	Score          float64   `json:"score"`
+	Anomalous      bool      `json:"anomalous"`
+}
+
+// anomaliesJSON is the JSON-serializable response of coral query anomalies.
+type anomaliesJSON struct {
+	BaselinesLearnedAt *time.Time    `json:"baselines_learned_at,omitempty"`
+	Anomalies          []anomalyJSON `json:"anomalies"`
+}
+
+// NewAnomaliesCmd creates the 'coral query anomalies' command.
+func NewAnomaliesCmd() *cobra.Command {
+	var (
+		since  string
+		all    bool
+		limit  int32
+		format string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "anomalies [service]",
+		Short: "List route latency and error rate anomalies",
+		Long: `List the routes whose p95 latency or error rate deviated from their usual
+values.
+
+The colony learns, for each HTTP route and gRPC method, the mean and standard
+deviation of its hourly p95 latency and error rate at each hour of the day
+over the last week (anomaly_detection.lookback), and scores the last few
+minutes of traffic against the baseline of the current hour. A score is the
+deviation from the baseline mean in standard deviations; scores beyond
+anomaly_detection.sigma (default 3) are anomalies.
+
+Routes need a few days of history before they are scored.
+
+Examples:
+  coral query anomalies                  # Anomalies of the last hour
+  coral query anomalies checkout --since 24h
+  coral query anomalies api --all        # Include scores within the usual range
+  coral query anomalies --format json
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q: must be text or json", format)
+			}
+
+			req := &colonypb.QueryAnomaliesRequest{
+				TimeRange:     since,
+				IncludeNormal: all,
+				Limit:         limit,
+			}
+			if len(args) > 0 {
+				req.Service = args[0]
+			}
+
+			client, err := helpers.GetColonyClient("")
+			if err != nil {
+				return fmt.Errorf("failed to create colony client: %w", err)
+			}
+
+			resp, err := client.QueryAnomalies(context.Background(), connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to query anomalies: %w", err)
+			}
+
+			if format == "json" {
+				return printAnomaliesJSON(os.Stdout, resp.Msg)
+			}
+			printAnomaliesText(os.Stdout, resp.Msg, since)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "1h", "Time range (e.g., 30m, 1h, 24h)")
+	cmd.Flags().BoolVar(&all, "all", false, "Include scores within the usual range")
+	cmd.Flags().Int32Var(&limit, "limit", 0, "Maximum scores to return, newest first (default 100)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	return cmd
+}
+
+func printAnomaliesJSON(w io.Writer, resp *colonypb.QueryAnomaliesResponse) error {
+	out := anomaliesJSON{Anomalies: make([]anomalyJSON, 0, len(resp.Anomalies))}
+	if resp.BaselinesLearnedAt != nil {
+		t := resp.BaselinesLearnedAt.AsTime()
+		out.BaselinesLearnedAt = &t
+	}
+	for _, a := range resp.Anomalies {
+		out.Anomalies = append(out.Anomalies, anomalyJSON{
+			Timestamp:      a.Timestamp.AsTime(),
+			Service:        a.Service,
+			Route:          a.Route,
+			Metric:         a.Metric,
+			Value:          a.Value,
+			BaselineMean:   a.BaselineMean,
+			BaselineStddev: a.BaselineStddev,
+			Score:          a.Score,
+			Anomalous:      a.Anomalous,
+		})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal anomalies: %w", err)
+	}
+	_, _ = fmt.Fprintln(w, string(data))
+	return nil
+}
+
+func printAnomaliesText(w io.Writer, resp *colonypb.QueryAnomaliesResponse, since string) {
+	if resp.BaselinesLearnedAt == nil {
+		_, _ = fmt.Fprintln(w, "No baselines learned yet; the colony learns them from its metric history once anomaly detection runs")
+		return
+	}
+	if len(resp.Anomalies) == 0 {
+		_, _ = fmt.Fprintf(w, "No anomalies in the last %s\n", since)
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, strings.Join([]string{"TIME", "SERVICE", "ROUTE", "METRIC", "VALUE", "BASELINE", "SCORE"}, "\t"))
+	for _, a := range resp.Anomalies {
+		score := fmt.Sprintf("%+.1fσ", a.Score)
+		if a.Anomalous {
+			score += " !"
+		}
+		_, _ = fmt.Fprintln(tw, strings.Join([]string{
+			a.Timestamp.AsTime().Local().Format(time.DateTime),
+			a.Service,
+			a.Route,
+			a.Metric,
+			formatAnomalyValue(a.Metric, a.Value),
+			formatAnomalyValue(a.Metric, a.BaselineMean) + " ± " + formatAnomalyValue(a.Metric, a.BaselineStddev),
+			score,
+		}, "\t"))
+	}
+	_ = tw.Flush()
+}
+
+// formatAnomalyValue formats a latency in milliseconds or an error rate in
+// percent.
+func formatAnomalyValue(metric string, v float64) string {
+	if metric == "latency_p95" {
+		return fmt.Sprintf("%.0fms", v)
+	}
+	return fmt.Sprintf("%.2f%%", v)
+}
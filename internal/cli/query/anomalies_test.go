@@ -0,0 +1,54 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestPrintAnomalies(t *testing.T) {
+	at := time.Date(2026, 1, 15, 14, 35, 0, 0, time.UTC)
+	resp := &colonypb.QueryAnomaliesResponse{
+		BaselinesLearnedAt: timestamppb.New(at.Add(-time.Hour)),
+		Anomalies: []*colonypb.Anomaly{
+			{Timestamp: timestamppb.New(at), Service: "checkout", Route: "POST /orders", Metric: "latency_p95",
+				Value: 480, BaselineMean: 120, BaselineStddev: 18, Score: 20, Anomalous: true},
+			{Timestamp: timestamppb.New(at), Service: "checkout", Route: "GET /cart", Metric: "error_rate",
+				Value: 0.1, BaselineMean: 0.2, BaselineStddev: 0.1, Score: -0.1},
+		},
+	}
+
+	var buf bytes.Buffer
+	printAnomaliesText(&buf, resp, "1h")
+	out := buf.String()
+	assert.Contains(t, out, "POST /orders")
+	assert.Contains(t, out, "480ms")
+	assert.Contains(t, out, "120ms ± 18ms")
+	assert.Contains(t, out, "+20.0σ !")
+	assert.Contains(t, out, "0.10%")
+	assert.Contains(t, out, "-0.1σ\n")
+
+	buf.Reset()
+	printAnomaliesText(&buf, &colonypb.QueryAnomaliesResponse{BaselinesLearnedAt: resp.BaselinesLearnedAt}, "1h")
+	assert.Equal(t, "No anomalies in the last 1h\n", buf.String())
+
+	buf.Reset()
+	printAnomaliesText(&buf, &colonypb.QueryAnomaliesResponse{}, "1h")
+	assert.Contains(t, buf.String(), "No baselines learned yet")
+
+	buf.Reset()
+	require.NoError(t, printAnomaliesJSON(&buf, resp))
+	var decoded anomaliesJSON
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded.Anomalies, 2)
+	assert.Equal(t, "POST /orders", decoded.Anomalies[0].Route)
+	assert.True(t, decoded.Anomalies[0].Anomalous)
+	require.NotNil(t, decoded.BaselinesLearnedAt)
+}
@@ -0,0 +1,193 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// deployWindowJSON is the JSON-serializable representation of one window of
+// a deployment comparison.
+type deployWindowJSON struct {
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	RequestCount int64     `json:"request_count"`
+	ErrorRate    float64   `json:"error_rate"`
+	P50Ms        float64   `json:"p50_ms"`
+	P95Ms        float64   `json:"p95_ms"`
+	P99Ms        float64   `json:"p99_ms"`
+}
+
+// newErrorJSON is a kind of failed request seen only after the deployment.
+type newErrorJSON struct {
+	Signature string `json:"signature"`
+	Count     int64  `json:"count"`
+}
+
+// deployComparisonJSON is the JSON-serializable regression report.
+type deployComparisonJSON struct {
+	Service        string           `json:"service"`
+	DeployTime     time.Time        `json:"deploy_time"`
+	BuildID        string           `json:"build_id,omitempty"`
+	Regressed      bool             `json:"regressed"`
+	Findings       []string         `json:"findings"`
+	Baseline       deployWindowJSON `json:"baseline"`
+	Current        deployWindowJSON `json:"current"`
+	CPURegressions []regressionJSON `json:"cpu_regressions"`
+	NewErrors      []newErrorJSON   `json:"new_errors"`
+}
+
+// NewCompareCmd creates the 'coral query compare' command.
+func NewCompareCmd() *cobra.Command {
+	var (
+		deployTime string
+		window     string
+		format     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "compare <service>",
+		Short: "Compare a service before and after a deployment",
+		Long: `Compare a service in the window before a deployment with the window after it.
+
+Reports latency percentiles, error rate, CPU hotspots that appeared or grew,
+and kinds of failed requests (status, method and route) that were not seen
+before the deployment.
+
+The deployment defaults to when the service's latest build was first seen.
+
+Examples:
+  coral query compare api                                  # Latest build, 30m windows
+  coral query compare api --deploy-time 2h --window 1h     # Deployed 2 hours ago
+  coral query compare api --deploy-time 2025-01-15T10:30:00Z
+  coral query compare api --format json
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &colonypb.CompareDeploymentsRequest{
+				Service: args[0],
+				Window:  window,
+			}
+			if deployTime != "" {
+				t, err := parseDeployTime(deployTime, time.Now())
+				if err != nil {
+					return err
+				}
+				req.DeployTime = timestamppb.New(t)
+			}
+
+			client, err := helpers.GetColonyClient("")
+			if err != nil {
+				return fmt.Errorf("failed to connect to colony: %w", err)
+			}
+
+			resp, err := client.CompareDeployments(context.Background(), connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to compare deployments: %w", err)
+			}
+
+			if format == "json" {
+				return printComparisonJSON(resp.Msg)
+			}
+			printComparisonText(resp.Msg)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&deployTime, "deploy-time", "", "Deployment time, RFC3339 or how long ago (e.g. '2h'); default: latest build")
+	cmd.Flags().StringVar(&window, "window", "30m", "Length of the windows compared before and after the deployment")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	return cmd
+}
+
+// parseDeployTime parses an RFC3339 time or a duration before now.
+func parseDeployTime(s string, now time.Time) (time.Time, error) {
+	if d, err := helpers.ParseSince(s); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --deploy-time %q: use RFC3339 or a duration such as 2h", s)
+	}
+	return t, nil
+}
+
+func windowToJSON(w *colonypb.DeploymentWindowStats) deployWindowJSON {
+	return deployWindowJSON{
+		Start:        w.GetStartTime().AsTime(),
+		End:          w.GetEndTime().AsTime(),
+		RequestCount: w.GetRequestCount(),
+		ErrorRate:    w.GetErrorRate(),
+		P50Ms:        w.GetP50Ms(),
+		P95Ms:        w.GetP95Ms(),
+		P99Ms:        w.GetP99Ms(),
+	}
+}
+
+func printComparisonJSON(resp *colonypb.CompareDeploymentsResponse) error {
+	out := deployComparisonJSON{
+		Service:        resp.Service,
+		DeployTime:     resp.DeployTime.AsTime(),
+		BuildID:        resp.BuildId,
+		Regressed:      resp.Regressed,
+		Findings:       append([]string{}, resp.Findings...),
+		Baseline:       windowToJSON(resp.Baseline),
+		Current:        windowToJSON(resp.Current),
+		CPURegressions: make([]regressionJSON, 0, len(resp.CpuRegressions)),
+		NewErrors:      make([]newErrorJSON, 0, len(resp.NewErrors)),
+	}
+	for _, r := range resp.CpuRegressions {
+		out.CPURegressions = append(out.CPURegressions, regressionJSON{
+			Type:               r.Type.String(),
+			Message:            r.Message,
+			BaselinePercentage: r.BaselinePercentage,
+			CurrentPercentage:  r.CurrentPercentage,
+			Delta:              r.Delta,
+		})
+	}
+	for _, e := range resp.NewErrors {
+		out.NewErrors = append(out.NewErrors, newErrorJSON{Signature: e.Signature, Count: e.Count})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal comparison: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func printComparisonText(resp *colonypb.CompareDeploymentsResponse) {
+	fmt.Printf("Deployment comparison for %s\n", resp.Service)
+	fmt.Printf("Deployed: %s", resp.DeployTime.AsTime().Local().Format(time.RFC3339))
+	if resp.BuildId != "" {
+		fmt.Printf(" (build %s)", resp.BuildId)
+	}
+	fmt.Printf("\n\n")
+
+	b, c := resp.Baseline, resp.Current
+	fmt.Printf("%-12s %12s %12s\n", "", "BEFORE", "AFTER")
+	fmt.Printf("%-12s %12d %12d\n", "Requests", b.GetRequestCount(), c.GetRequestCount())
+	fmt.Printf("%-12s %11.2f%% %11.2f%%\n", "Error rate", b.GetErrorRate(), c.GetErrorRate())
+	fmt.Printf("%-12s %10.0fms %10.0fms\n", "p50", b.GetP50Ms(), c.GetP50Ms())
+	fmt.Printf("%-12s %10.0fms %10.0fms\n", "p95", b.GetP95Ms(), c.GetP95Ms())
+	fmt.Printf("%-12s %10.0fms %10.0fms\n", "p99", b.GetP99Ms(), c.GetP99Ms())
+	fmt.Println()
+
+	if !resp.Regressed {
+		fmt.Println("✓ No regressions found")
+		return
+	}
+	fmt.Println("Regressions:")
+	for _, f := range resp.Findings {
+		fmt.Printf("  ⚠️  %s\n", f)
+	}
+}
@@ -0,0 +1,205 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// maxSignatureWidth truncates error signatures in the text output.
+const maxSignatureWidth = 60
+
+// sparkBars are the levels of a trend sparkline, lowest first.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// errorGroupJSON is the JSON-serializable representation of an error group.
+type errorGroupJSON struct {
+	Service   string    `json:"service"`
+	Source    string    `json:"source"`
+	Route     string    `json:"route"`
+	Signature string    `json:"signature"`
+	Example   string    `json:"example,omitempty"`
+	Count     int64     `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	Trend     []int64   `json:"trend"`
+}
+
+// errorsJSON is the JSON-serializable error report.
+type errorsJSON struct {
+	Start       time.Time        `json:"start"`
+	End         time.Time        `json:"end"`
+	BucketMs    int64            `json:"bucket_ms"`
+	TotalErrors int64            `json:"total_errors"`
+	TotalGroups int32            `json:"total_groups"`
+	Groups      []errorGroupJSON `json:"groups"`
+}
+
+// NewErrorsCmd creates the 'coral query errors' command.
+func NewErrorsCmd() *cobra.Command {
+	var (
+		since     string
+		buckets   int
+		maxGroups int
+		format    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "errors [service]",
+		Short: "Aggregate errors by service, route and signature",
+		Long: `Aggregate the errors of all services, or of one service, over a time range.
+
+Errors are grouped by service, route and signature:
+  http  HTTP 5xx responses, by method, route and status
+  grpc  gRPC calls with a non-OK status, by method and status
+  go    Go errors returned by functions traced in debug sessions, by
+        function and message, with IDs, addresses and numbers masked
+
+Each group has a sparkline of its errors over the time range.
+
+Examples:
+  coral query errors                          # All services, last hour
+  coral query errors api --since 24h          # One service, last day
+  coral query errors --max-groups 50
+  coral query errors api --format json
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q: must be text or json", format)
+			}
+
+			req := &colonypb.QueryErrorsRequest{
+				TimeRange:    since,
+				TrendBuckets: int32(buckets),   // #nosec G115 -- bounded by the colony.
+				MaxGroups:    int32(maxGroups), // #nosec G115 -- small CLI value.
+			}
+			if len(args) > 0 {
+				req.Service = args[0]
+			}
+
+			client, err := helpers.GetColonyClient("")
+			if err != nil {
+				return fmt.Errorf("failed to create colony client: %w", err)
+			}
+
+			resp, err := client.QueryErrors(context.Background(), connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to query errors: %w", err)
+			}
+
+			if format == "json" {
+				return printErrorsJSON(os.Stdout, resp.Msg)
+			}
+			printErrorsText(os.Stdout, resp.Msg, since)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "1h", "Time range (e.g., 1h, 30m, 24h)")
+	cmd.Flags().IntVar(&buckets, "buckets", 20, "Number of time buckets of the trend sparklines")
+	cmd.Flags().IntVar(&maxGroups, "max-groups", 20, "Maximum number of error groups to return")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	return cmd
+}
+
+func printErrorsJSON(w io.Writer, resp *colonypb.QueryErrorsResponse) error {
+	out := errorsJSON{
+		Start:       resp.StartTime.AsTime(),
+		End:         resp.EndTime.AsTime(),
+		BucketMs:    resp.BucketMs,
+		TotalErrors: resp.TotalErrors,
+		TotalGroups: resp.TotalGroups,
+		Groups:      make([]errorGroupJSON, 0, len(resp.Groups)),
+	}
+	for _, g := range resp.Groups {
+		out.Groups = append(out.Groups, errorGroupJSON{
+			Service:   g.Service,
+			Source:    g.Source,
+			Route:     g.Route,
+			Signature: g.Signature,
+			Example:   g.Example,
+			Count:     g.Count,
+			FirstSeen: g.FirstSeen.AsTime(),
+			LastSeen:  g.LastSeen.AsTime(),
+			Trend:     append([]int64{}, g.Trend...),
+		})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal errors: %w", err)
+	}
+	_, _ = fmt.Fprintln(w, string(data))
+	return nil
+}
+
+func printErrorsText(w io.Writer, resp *colonypb.QueryErrorsResponse, since string) {
+	if len(resp.Groups) == 0 {
+		_, _ = fmt.Fprintf(w, "✓ No errors in the last %s\n", since)
+		return
+	}
+
+	bucket := (time.Duration(resp.BucketMs) * time.Millisecond).Round(time.Second)
+	_, _ = fmt.Fprintf(w, "%d errors in %d groups in the last %s (%s per bar)\n\n",
+		resp.TotalErrors, resp.TotalGroups, since, bucket)
+
+	end := resp.EndTime.AsTime()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "SERVICE\tSOURCE\tROUTE\tSIGNATURE\tCOUNT\tLAST SEEN\tTREND")
+	for _, g := range resp.Groups {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s ago\t%s\n",
+			g.Service,
+			g.Source,
+			g.Route,
+			truncateSignature(g.Signature),
+			g.Count,
+			end.Sub(g.LastSeen.AsTime()).Round(time.Second),
+			sparkline(g.Trend))
+	}
+	_ = tw.Flush()
+
+	if int(resp.TotalGroups) > len(resp.Groups) {
+		_, _ = fmt.Fprintf(w, "\nShowing %d of %d groups; use --max-groups to see more.\n", len(resp.Groups), resp.TotalGroups)
+	}
+}
+
+// truncateSignature shortens a signature to maxSignatureWidth characters.
+func truncateSignature(s string) string {
+	runes := []rune(s)
+	if len(runes) <= maxSignatureWidth {
+		return s
+	}
+	return string(runes[:maxSignatureWidth-1]) + "…"
+}
+
+// sparkline draws counts as bars scaled to the highest count. Buckets without
+// errors are blank, and any other bucket shows at least the lowest bar.
+func sparkline(counts []int64) string {
+	var highest int64
+	for _, c := range counts {
+		highest = max(highest, c)
+	}
+
+	var b strings.Builder
+	for _, c := range counts {
+		if c == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		level := int((c*int64(len(sparkBars)) - 1) / highest)
+		b.WriteRune(sparkBars[min(level, len(sparkBars)-1)])
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package query
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", sparkline(nil))
+	assert.Equal(t, "    ", sparkline([]int64{0, 0, 0, 0}))
+	assert.Equal(t, "▁ ▄█", sparkline([]int64{1, 0, 4, 8}))
+	assert.Equal(t, "▁█", sparkline([]int64{1, 1000}), "any error shows")
+}
+
+func TestPrintErrorsText(t *testing.T) {
+	end := time.Now()
+	resp := &colonypb.QueryErrorsResponse{
+		StartTime:   timestamppb.New(end.Add(-time.Hour)),
+		EndTime:     timestamppb.New(end),
+		BucketMs:    (15 * time.Minute).Milliseconds(),
+		TotalErrors: 15,
+		TotalGroups: 3,
+		Groups: []*colonypb.ErrorGroup{
+			{Service: "api", Source: "http", Route: "POST /checkout", Signature: "HTTP 503", Count: 12, LastSeen: timestamppb.New(end.Add(-2 * time.Minute)), Trend: []int64{0, 2, 4, 6}},
+			{Service: "api", Source: "go", Route: "main.loadUser", Signature: strings.Repeat("x", 100), Count: 2, LastSeen: timestamppb.New(end), Trend: []int64{1, 0, 0, 1}},
+		},
+	}
+
+	var buf bytes.Buffer
+	printErrorsText(&buf, resp, "1h")
+	out := buf.String()
+
+	assert.Contains(t, out, "15 errors in 3 groups in the last 1h (15m0s per bar)")
+	assert.Regexp(t, `api +http +POST /checkout +HTTP 503 +12 +2m0s ago +  ▃▆█`, out)
+	assert.Contains(t, out, strings.Repeat("x", maxSignatureWidth-1)+"…")
+	assert.Contains(t, out, "Showing 2 of 3 groups")
+
+	buf.Reset()
+	printErrorsText(&buf, &colonypb.QueryErrorsResponse{}, "1h")
+	assert.Equal(t, "✓ No errors in the last 1h\n", buf.String())
+}
@@ -2,8 +2,11 @@ package query
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
 	"time"
 
 	"connectrpc.com/connect"
@@ -14,6 +17,9 @@ import (
 	"github.com/coral-mesh/coral/internal/cli/helpers"
 )
 
+// memoryTopStacks is the number of allocation stacks included in JSON output.
+const memoryTopStacks = 10
+
 // NewMemoryProfileCmd creates the memory-profile query command.
 func NewMemoryProfileCmd() *cobra.Command {
 	var (
@@ -34,12 +40,18 @@ func NewMemoryProfileCmd() *cobra.Command {
 This command retrieves aggregated memory allocation profiles collected by
 the continuous profiling system (RFD 077).
 
+--show-growth adds the allocation volume over the time range and the stacks
+whose allocation rate grew between its first and second half (suspected
+leaks). The json format always includes them, along with the top allocating
+functions, types and stacks.
+
 For on-demand memory profiling, use 'coral profile memory --duration 30'.
 
 Examples:
   coral query memory-profile --service api --since 1h
   coral query memory-profile --service api --since 1h --show-growth --show-types
-  coral query memory-profile --service api --build-id abc123 --since 24h`,
+  coral query memory-profile --service api --build-id abc123 --since 24h
+  coral query memory-profile --service api --since 6h --format json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if serviceName == "" {
 				return fmt.Errorf("--service is required")
@@ -84,6 +96,7 @@ Examples:
 				ServiceName: serviceName,
 				StartTime:   timestamppb.New(startTime),
 				EndTime:     timestamppb.New(endTime),
+				BuildId:     buildID,
 			})
 
 			ctx := context.Background()
@@ -96,6 +109,10 @@ Examples:
 				return fmt.Errorf("historical memory profile query failed: %s", resp.Msg.Error)
 			}
 
+			if format == "json" {
+				return printMemoryProfileJSON(serviceName, buildID, startTime, endTime, resp.Msg)
+			}
+
 			if len(resp.Msg.Samples) == 0 {
 				fmt.Fprintf(os.Stderr, "No historical memory profile data found for service '%s' in the specified time range.\n", serviceName)
 				return nil
@@ -137,8 +154,12 @@ Examples:
 					}
 					fmt.Println()
 				}
+
+				if showGrowth {
+					printMemoryGrowth(resp.Msg)
+				}
 			default:
-				return fmt.Errorf("unknown format: %s (use 'summary' or 'folded')", format)
+				return fmt.Errorf("unknown format: %s (use 'summary', 'folded' or 'json')", format)
 			}
 
 			return nil
@@ -151,13 +172,164 @@ Examples:
 	cmd.Flags().StringVar(&buildID, "build-id", "", "Filter by specific build ID")
 	cmd.Flags().BoolVar(&showGrowth, "show-growth", false, "Show heap growth trends")
 	cmd.Flags().BoolVar(&showTypes, "show-types", false, "Show allocation breakdown by type")
-	cmd.Flags().StringVarP(&format, "format", "f", "summary", "Output format: 'summary' (human/LLM readable) or 'folded' (flamegraph compatible)")
+	cmd.Flags().StringVarP(&format, "format", "f", "summary", "Output format: 'summary' (human/LLM readable), 'folded' (flamegraph compatible) or 'json'")
 
 	cmd.MarkFlagRequired("service") //nolint:errcheck
 
 	return cmd
 }
 
+// printMemoryGrowth prints the allocation trend and suspected leak stacks.
+func printMemoryGrowth(resp *colonypb.QueryHistoricalMemoryProfileResponse) {
+	if len(resp.Growth) > 0 {
+		fmt.Println("Allocation Trend:")
+		for _, bucket := range resp.Growth {
+			fmt.Printf("  %s  %s\n", bucket.StartTime.AsTime().Local().Format("15:04"), formatQueryBytes(bucket.AllocBytes))
+		}
+		fmt.Println()
+	}
+
+	if len(resp.LeakCandidates) == 0 {
+		fmt.Println("No suspected leaks: no stack's allocation rate grew over the time range.")
+		return
+	}
+	fmt.Println("Suspected Leaks (allocation rate growing):")
+	for _, leak := range resp.LeakCandidates {
+		leaf := "<unknown>"
+		if len(leak.FrameNames) > 0 {
+			leaf = leak.FrameNames[0]
+		}
+		fmt.Printf("  %+6.1f%%  %s  %s\n", leak.GrowthPct, formatQueryBytes(leak.AllocBytes), leaf)
+	}
+	fmt.Println()
+}
+
+// memoryProfileJSON is the structured output of memory-profile --format json.
+type memoryProfileJSON struct {
+	ServiceName     string                  `json:"service_name"`
+	BuildID         string                  `json:"build_id,omitempty"`
+	StartTime       time.Time               `json:"start_time"`
+	EndTime         time.Time               `json:"end_time"`
+	TotalAllocBytes int64                   `json:"total_alloc_bytes"`
+	UniqueStacks    int32                   `json:"unique_stacks"`
+	TopFunctions    []memoryAllocSiteJSON   `json:"top_functions"`
+	TopTypes        []memoryAllocSiteJSON   `json:"top_types"`
+	TopStacks       []memoryStackJSON       `json:"top_stacks"`
+	Growth          []memoryGrowthJSON      `json:"growth"`
+	LeakCandidates  []memoryLeakSuspectJSON `json:"leak_candidates"`
+}
+
+type memoryAllocSiteJSON struct {
+	Name         string  `json:"name"`
+	Percentage   float64 `json:"percentage"`
+	AllocBytes   int64   `json:"alloc_bytes"`
+	AllocObjects int64   `json:"alloc_objects"`
+}
+
+// memoryStackJSON is an allocation stack, frames ordered from root to leaf.
+type memoryStackJSON struct {
+	Frames       []string `json:"frames"`
+	Percentage   float64  `json:"percentage"`
+	AllocBytes   int64    `json:"alloc_bytes"`
+	AllocObjects int64    `json:"alloc_objects"`
+}
+
+type memoryGrowthJSON struct {
+	StartTime    time.Time `json:"start_time"`
+	AllocBytes   int64     `json:"alloc_bytes"`
+	AllocObjects int64     `json:"alloc_objects"`
+}
+
+type memoryLeakSuspectJSON struct {
+	Frames        []string `json:"frames"`
+	AllocBytes    int64    `json:"alloc_bytes"`
+	GrowthPct     float64  `json:"growth_pct"`
+	ActiveBuckets int32    `json:"active_buckets"`
+}
+
+// summarizeMemoryProfile converts a historical memory profile response into
+// its JSON form. Slices are never nil so that empty results encode as [].
+func summarizeMemoryProfile(
+	serviceName, buildID string,
+	startTime, endTime time.Time,
+	resp *colonypb.QueryHistoricalMemoryProfileResponse,
+) memoryProfileJSON {
+	out := memoryProfileJSON{
+		ServiceName:     serviceName,
+		BuildID:         buildID,
+		StartTime:       startTime.UTC(),
+		EndTime:         endTime.UTC(),
+		TotalAllocBytes: resp.TotalAllocBytes,
+		UniqueStacks:    resp.UniqueStacks,
+		TopFunctions:    []memoryAllocSiteJSON{},
+		TopTypes:        []memoryAllocSiteJSON{},
+		TopStacks:       []memoryStackJSON{},
+		Growth:          []memoryGrowthJSON{},
+		LeakCandidates:  []memoryLeakSuspectJSON{},
+	}
+
+	for _, tf := range resp.TopFunctions {
+		out.TopFunctions = append(out.TopFunctions, memoryAllocSiteJSON{
+			Name: tf.Function, Percentage: tf.Pct, AllocBytes: tf.Bytes, AllocObjects: tf.Objects,
+		})
+	}
+	for _, tt := range resp.TopTypes {
+		out.TopTypes = append(out.TopTypes, memoryAllocSiteJSON{
+			Name: tt.TypeName, Percentage: tt.Pct, AllocBytes: tt.Bytes, AllocObjects: tt.Objects,
+		})
+	}
+
+	samples := slices.Clone(resp.Samples)
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].AllocBytes > samples[j].AllocBytes })
+	for _, sample := range samples[:min(len(samples), memoryTopStacks)] {
+		pct := 0.0
+		if resp.TotalAllocBytes > 0 {
+			pct = float64(sample.AllocBytes) / float64(resp.TotalAllocBytes) * 100
+		}
+		out.TopStacks = append(out.TopStacks, memoryStackJSON{
+			Frames:       rootToLeaf(sample.FrameNames),
+			Percentage:   pct,
+			AllocBytes:   sample.AllocBytes,
+			AllocObjects: sample.AllocObjects,
+		})
+	}
+
+	for _, bucket := range resp.Growth {
+		out.Growth = append(out.Growth, memoryGrowthJSON{
+			StartTime:    bucket.StartTime.AsTime(),
+			AllocBytes:   bucket.AllocBytes,
+			AllocObjects: bucket.AllocObjects,
+		})
+	}
+	for _, leak := range resp.LeakCandidates {
+		out.LeakCandidates = append(out.LeakCandidates, memoryLeakSuspectJSON{
+			Frames:        rootToLeaf(leak.FrameNames),
+			AllocBytes:    leak.AllocBytes,
+			GrowthPct:     leak.GrowthPct,
+			ActiveBuckets: leak.ActiveBuckets,
+		})
+	}
+
+	return out
+}
+
+// rootToLeaf reverses innermost-first frame names.
+func rootToLeaf(frames []string) []string {
+	out := slices.Clone(frames)
+	slices.Reverse(out)
+	return out
+}
+
+func printMemoryProfileJSON(
+	serviceName, buildID string,
+	startTime, endTime time.Time,
+	resp *colonypb.QueryHistoricalMemoryProfileResponse,
+) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(summarizeMemoryProfile(serviceName, buildID, startTime, endTime, resp))
+}
+
 // formatQueryBytes formats bytes into human-readable form.
 func formatQueryBytes(b int64) string {
 	const (
@@ -0,0 +1,66 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestSummarizeMemoryProfile(t *testing.T) {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	resp := &colonypb.QueryHistoricalMemoryProfileResponse{
+		Success:         true,
+		TotalAllocBytes: 1000,
+		UniqueStacks:    2,
+		Samples: []*agentv1.MemoryStackSample{
+			{FrameNames: []string{"runtime.makeslice", "main.handle"}, AllocBytes: 250, AllocObjects: 5},
+			{FrameNames: []string{"runtime.mallocgc", "main.(*cache).put", "main.main"}, AllocBytes: 750, AllocObjects: 3},
+		},
+		TopFunctions: []*agentv1.TopAllocFunction{{Function: "main.(*cache).put", Bytes: 750, Objects: 3, Pct: 75}},
+		TopTypes:     []*agentv1.TopAllocType{{TypeName: "object", Bytes: 750, Objects: 3, Pct: 75}},
+		Growth: []*colonypb.MemoryGrowthBucket{
+			{StartTime: timestamppb.New(start), AllocBytes: 400},
+			{StartTime: timestamppb.New(start.Add(30 * time.Minute)), AllocBytes: 600},
+		},
+		LeakCandidates: []*colonypb.MemoryLeakCandidate{
+			{FrameNames: []string{"runtime.mallocgc", "main.(*cache).put", "main.main"}, AllocBytes: 750, GrowthPct: 50, ActiveBuckets: 2},
+		},
+	}
+
+	out := summarizeMemoryProfile("api", "b1", start, end, resp)
+
+	assert.Equal(t, "api", out.ServiceName)
+	assert.Equal(t, int64(1000), out.TotalAllocBytes)
+	require.Len(t, out.TopStacks, 2)
+	assert.Equal(t, []string{"main.main", "main.(*cache).put", "runtime.mallocgc"}, out.TopStacks[0].Frames)
+	assert.InDelta(t, 75.0, out.TopStacks[0].Percentage, 0.001)
+	assert.Equal(t, []string{"main.handle", "runtime.makeslice"}, out.TopStacks[1].Frames)
+	require.Len(t, out.Growth, 2)
+	assert.Equal(t, start, out.Growth[0].StartTime)
+	require.Len(t, out.LeakCandidates, 1)
+	assert.Equal(t, "runtime.mallocgc", out.LeakCandidates[0].Frames[2])
+	assert.Equal(t, 50.0, out.LeakCandidates[0].GrowthPct)
+
+	// The response is not modified.
+	assert.Equal(t, "runtime.makeslice", resp.Samples[0].FrameNames[0])
+}
+
+func TestSummarizeMemoryProfile_Empty(t *testing.T) {
+	now := time.Now()
+	out := summarizeMemoryProfile("api", "", now.Add(-time.Hour), now, &colonypb.QueryHistoricalMemoryProfileResponse{Success: true})
+
+	data, err := json.Marshal(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"top_stacks":[]`)
+	assert.Contains(t, string(data), `"leak_candidates":[]`)
+	assert.NotContains(t, string(data), "build_id")
+}
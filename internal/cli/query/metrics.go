@@ -25,6 +25,9 @@ func NewMetricsCmd() *cobra.Command {
 		metric          string
 		percentile      float64
 		format          string
+		federated       bool
+		selector        string
+		group           string
 	)
 
 	cmd := &cobra.Command{
@@ -42,6 +45,9 @@ Examples:
   coral query metrics api --metric http.server.duration --percentile 99  # P99 latency (RFD 076)
   coral query metrics api --metric http.server.duration --percentile 50  # P50 latency (RFD 076)
   coral query metrics api --format json                               # JSON output
+  coral query metrics api --federated                                 # Include child colonies
+  coral query metrics --selector env=prod                             # Services labeled env=prod
+  coral query metrics --group checkout-path                           # Services of a service group
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			service := ""
@@ -59,6 +65,9 @@ Examples:
 
 			// RFD 076: Focused percentile query if --metric and --percentile are specified
 			if metric != "" && percentile > 0 {
+				if federated || selector != "" || group != "" {
+					return fmt.Errorf("--federated, --selector and --group are not supported for percentile queries")
+				}
 				return executePercentileQuery(ctx, client, service, metric, percentile, since)
 			}
 
@@ -71,12 +80,16 @@ Examples:
 				HttpRoute:       httpRoute,
 				HttpMethod:      httpMethod,
 				StatusCodeRange: statusCodeRange,
+				Federated:       federated,
+				Selector:        selector,
+				Group:           group,
 			}
 
 			resp, err := client.QueryUnifiedMetrics(ctx, connect.NewRequest(req))
 			if err != nil {
 				return fmt.Errorf("failed to query metrics: %w", err)
 			}
+			helpers.PrintFederationErrors(resp.Msg.FederationErrors)
 
 			if format == "json" {
 				return json.NewEncoder(os.Stdout).Encode(resp.Msg)
@@ -93,7 +106,7 @@ Examples:
 			if len(resp.Msg.HttpMetrics) > 0 {
 				fmt.Println("HTTP Metrics:")
 				for _, m := range resp.Msg.HttpMetrics {
-					fmt.Printf("  %s %s %s\n", m.HttpMethod, m.HttpRoute, m.ServiceName)
+					fmt.Printf("  %s%s %s %s\n", colonyPrefix(m.Attributes), m.HttpMethod, m.HttpRoute, m.ServiceName)
 					// Calculate percentiles from buckets if available
 					p50, p95, p99 := "-", "-", "-"
 					if len(m.LatencyBuckets) >= 3 {
@@ -110,7 +123,7 @@ Examples:
 			if len(resp.Msg.GrpcMetrics) > 0 {
 				fmt.Printf("gRPC Metrics: %d\n", len(resp.Msg.GrpcMetrics))
 				for _, m := range resp.Msg.GrpcMetrics {
-					fmt.Printf("  %s\n", m.ServiceName)
+					fmt.Printf("  %s%s\n", colonyPrefix(m.Attributes), m.ServiceName)
 					fmt.Printf("    Requests: %d\n", m.RequestCount)
 				}
 				fmt.Println()
@@ -119,7 +132,7 @@ Examples:
 			if len(resp.Msg.SqlMetrics) > 0 {
 				fmt.Printf("SQL Metrics: %d\n", len(resp.Msg.SqlMetrics))
 				for _, m := range resp.Msg.SqlMetrics {
-					fmt.Printf("  %s\n", m.ServiceName)
+					fmt.Printf("  %s%s\n", colonyPrefix(m.Attributes), m.ServiceName)
 					fmt.Printf("    Queries: %d\n", m.QueryCount)
 				}
 				fmt.Println()
@@ -140,6 +153,9 @@ Examples:
 	cmd.Flags().StringVar(&metric, "metric", "", "Metric name for focused query (e.g., http.server.duration)")
 	cmd.Flags().Float64Var(&percentile, "percentile", 0, "Percentile to query (0-100, e.g., 99 for P99)")
 	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	helpers.AddFederatedFlag(cmd, &federated)
+	helpers.AddSelectorFlag(cmd, &selector)
+	helpers.AddGroupFlag(cmd, &group)
 
 	return cmd
 }
@@ -23,6 +23,10 @@ Commands:
   memory-profile - Historical memory profiles (RFD 077 - coming soon)
   sql            - Execute raw SQL queries (RFD 076)
   topology       - Service dependency graph (RFD 092)
+  compare        - Regressions before and after a deployment
+  errors         - Errors by service, route and signature, with trends
+  slo            - SLO compliance and error budget burn rates
+  anomalies      - Route latency and error rate anomalies vs learned baselines
 
 Examples:
   coral query summary                  # List all services with telemetry
@@ -32,6 +36,10 @@ Examples:
   coral query logs my-service --level error
   coral query cpu-profile my-service --since 1h
   coral query memory-profile my-service --since 1h --show-growth
+  coral query compare my-service --deploy-time 2h
+  coral query errors my-service --since 24h
+  coral query slo my-service --fail-on fast_burn
+  coral query anomalies my-service --since 24h
   coral query sql "SELECT service_name, COUNT(*) FROM beyla_http_metrics GROUP BY service_name"
 `,
 	}
@@ -44,6 +52,10 @@ Examples:
 	cmd.AddCommand(NewMemoryProfileCmd())
 	cmd.AddCommand(NewSQLCmd())
 	cmd.AddCommand(NewTopologyCmd()) // RFD 092: Service topology
+	cmd.AddCommand(NewCompareCmd())
+	cmd.AddCommand(NewErrorsCmd())
+	cmd.AddCommand(NewSLOCmd())
+	cmd.AddCommand(NewAnomaliesCmd())
 
 	return cmd
 }
@@ -0,0 +1,239 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+)
+
+// sloSeverity orders the SLO statuses; --fail-on fails at or above a status.
+var sloSeverity = map[string]int{
+	"ok":        0,
+	"slow_burn": 1,
+	"fast_burn": 2,
+	"exhausted": 3,
+}
+
+// sloBurnRateJSON is the JSON-serializable burn rate of an SLO over a window.
+type sloBurnRateJSON struct {
+	Window        string  `json:"window"`
+	TotalRequests int64   `json:"total_requests"`
+	BadRequests   int64   `json:"bad_requests"`
+	BurnRate      float64 `json:"burn_rate"`
+}
+
+// sloJSON is the JSON-serializable state of an SLO.
+type sloJSON struct {
+	Service              string            `json:"service"`
+	Objective            string            `json:"objective"`
+	Target               float64           `json:"target"`
+	LatencyThresholdMs   float64           `json:"latency_threshold_ms,omitempty"`
+	Period               string            `json:"period"`
+	Compliance           float64           `json:"compliance"`
+	ErrorBudgetRemaining float64           `json:"error_budget_remaining"`
+	BurnRates            []sloBurnRateJSON `json:"burn_rates"`
+	Status               string            `json:"status"`
+}
+
+// NewSLOCmd creates the 'coral query slo' command.
+func NewSLOCmd() *cobra.Command {
+	var (
+		format string
+		failOn string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "slo [service]",
+		Short: "Report SLO compliance and error budget burn rates",
+		Long: `Report the service level objectives defined in the slos section of the
+colony config: the percentage of good requests over the SLO period, the error
+budget left, and how fast the budget burns over windows from 5 minutes to 3
+days.
+
+A burn rate of 1 spends exactly the error budget over the period. Statuses
+follow multiwindow burn rate alerting:
+  ok         No significant burn
+  slow_burn  The budget will run out before the end of the period
+  fast_burn  The budget will run out within days
+  exhausted  The budget of the period is spent
+
+With --fail-on, the command exits with an error when an SLO reaches the given
+status or a more severe one, to gate deployments in CI.
+
+Examples:
+  coral query slo                              # All SLOs
+  coral query slo checkout                     # SLOs of one service
+  coral query slo checkout --fail-on fast_burn # CI gate
+  coral query slo --format json
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q: must be text or json", format)
+			}
+			if _, ok := sloSeverity[failOn]; failOn != "" && (!ok || failOn == "ok") {
+				return fmt.Errorf("invalid --fail-on %q: must be slow_burn, fast_burn or exhausted", failOn)
+			}
+
+			req := &colonypb.QuerySLORequest{}
+			if len(args) > 0 {
+				req.Service = args[0]
+			}
+
+			client, err := helpers.GetColonyClient("")
+			if err != nil {
+				return fmt.Errorf("failed to create colony client: %w", err)
+			}
+
+			resp, err := client.QuerySLO(context.Background(), connect.NewRequest(req))
+			if err != nil {
+				return fmt.Errorf("failed to query SLOs: %w", err)
+			}
+
+			if format == "json" {
+				if err := printSLOsJSON(os.Stdout, resp.Msg.Slos); err != nil {
+					return err
+				}
+			} else {
+				printSLOsText(os.Stdout, resp.Msg.Slos)
+			}
+
+			if failOn != "" {
+				return checkSLOs(resp.Msg.Slos, failOn)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	cmd.Flags().StringVar(&failOn, "fail-on", "", "Exit with an error when an SLO reaches this status: slow_burn, fast_burn or exhausted")
+	return cmd
+}
+
+// checkSLOs returns an error listing the SLOs whose status is failOn or more
+// severe.
+func checkSLOs(slos []*colonypb.SLOStatus, failOn string) error {
+	var failed []string
+	for _, slo := range slos {
+		if sloSeverity[slo.Status] >= sloSeverity[failOn] {
+			failed = append(failed, fmt.Sprintf("%s %s (%s)", slo.Service, slo.Objective, slo.Status))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("SLO check failed: %s", strings.Join(failed, ", "))
+}
+
+func printSLOsJSON(w io.Writer, slos []*colonypb.SLOStatus) error {
+	out := make([]sloJSON, 0, len(slos))
+	for _, slo := range slos {
+		s := sloJSON{
+			Service:              slo.Service,
+			Objective:            slo.Objective,
+			Target:               slo.Target,
+			LatencyThresholdMs:   slo.LatencyThresholdMs,
+			Period:               formatSLOWindow(slo.Period.AsDuration()),
+			Compliance:           slo.Compliance,
+			ErrorBudgetRemaining: slo.ErrorBudgetRemaining,
+			BurnRates:            make([]sloBurnRateJSON, 0, len(slo.BurnRates)),
+			Status:               slo.Status,
+		}
+		for _, r := range slo.BurnRates {
+			s.BurnRates = append(s.BurnRates, sloBurnRateJSON{
+				Window:        formatSLOWindow(r.Window.AsDuration()),
+				TotalRequests: r.TotalRequests,
+				BadRequests:   r.BadRequests,
+				BurnRate:      r.BurnRate,
+			})
+		}
+		out = append(out, s)
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal SLOs: %w", err)
+	}
+	_, _ = fmt.Fprintln(w, string(data))
+	return nil
+}
+
+func printSLOsText(w io.Writer, slos []*colonypb.SLOStatus) {
+	if len(slos) == 0 {
+		_, _ = fmt.Fprintln(w, "No SLOs defined; add them to the slos section of the colony config")
+		return
+	}
+
+	header := []string{"SERVICE", "OBJECTIVE", "TARGET", "PERIOD", "COMPLIANCE", "BUDGET LEFT"}
+	for _, r := range slos[0].BurnRates {
+		header = append(header, "BURN "+formatSLOWindow(r.Window.AsDuration()))
+	}
+	header = append(header, "STATUS")
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, slo := range slos {
+		objective := slo.Objective
+		if slo.LatencyThresholdMs > 0 {
+			objective += fmt.Sprintf(" (<%gms)", slo.LatencyThresholdMs)
+		}
+		row := []string{
+			slo.Service,
+			objective,
+			fmt.Sprintf("%g%%", slo.Target),
+			formatSLOWindow(slo.Period.AsDuration()),
+			fmt.Sprintf("%.3f%%", slo.Compliance),
+			fmt.Sprintf("%.1f%%", slo.ErrorBudgetRemaining),
+		}
+		for _, r := range slo.BurnRates {
+			if r.TotalRequests == 0 {
+				row = append(row, "-")
+				continue
+			}
+			row = append(row, fmt.Sprintf("%.2f", r.BurnRate))
+		}
+		row = append(row, formatSLOStatus(slo.Status))
+		_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	_ = tw.Flush()
+}
+
+// formatSLOWindow formats whole days as "30d" and other durations as "5m" or
+// "6h".
+func formatSLOWindow(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d >= day && d%day == 0:
+		return fmt.Sprintf("%dd", d/day)
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d >= time.Minute && d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return d.String()
+}
+
+func formatSLOStatus(status string) string {
+	switch status {
+	case "ok":
+		return "✓ ok"
+	case "slow_burn":
+		return "⚠️  slow burn"
+	case "fast_burn":
+		return "🔥 fast burn"
+	case "exhausted":
+		return "✗ exhausted"
+	}
+	return status
+}
@@ -0,0 +1,66 @@
+package query
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func testSLOs() []*colonypb.SLOStatus {
+	return []*colonypb.SLOStatus{
+		{
+			Service: "checkout", Objective: "availability", Target: 99.9, Period: durationpb.New(30 * 24 * time.Hour),
+			Compliance: 99.95, ErrorBudgetRemaining: 50, Status: "fast_burn",
+			BurnRates: []*colonypb.SLOBurnRate{
+				{Window: durationpb.New(5 * time.Minute), TotalRequests: 1000, BadRequests: 20, BurnRate: 20},
+				{Window: durationpb.New(72 * time.Hour)},
+			},
+		},
+		{
+			Service: "checkout", Objective: "latency", Target: 99, LatencyThresholdMs: 250, Period: durationpb.New(7 * 24 * time.Hour),
+			Compliance: 99.5, ErrorBudgetRemaining: 50, Status: "ok",
+			BurnRates: []*colonypb.SLOBurnRate{
+				{Window: durationpb.New(5 * time.Minute), TotalRequests: 1000, BadRequests: 5, BurnRate: 0.5},
+				{Window: durationpb.New(72 * time.Hour)},
+			},
+		},
+	}
+}
+
+func TestCheckSLOs(t *testing.T) {
+	slos := testSLOs()
+	assert.NoError(t, checkSLOs(slos, "exhausted"))
+	assert.EqualError(t, checkSLOs(slos, "fast_burn"), "SLO check failed: checkout availability (fast_burn)")
+	assert.EqualError(t, checkSLOs(slos, "slow_burn"), "SLO check failed: checkout availability (fast_burn)")
+
+	slos[1].Status = "exhausted"
+	assert.EqualError(t, checkSLOs(slos, "exhausted"), "SLO check failed: checkout latency (exhausted)")
+}
+
+func TestFormatSLOWindow(t *testing.T) {
+	assert.Equal(t, "5m", formatSLOWindow(5*time.Minute))
+	assert.Equal(t, "6h", formatSLOWindow(6*time.Hour))
+	assert.Equal(t, "3d", formatSLOWindow(72*time.Hour))
+	assert.Equal(t, "36h", formatSLOWindow(36*time.Hour))
+	assert.Equal(t, "1m30s", formatSLOWindow(90*time.Second))
+}
+
+func TestPrintSLOsText(t *testing.T) {
+	var buf bytes.Buffer
+	printSLOsText(&buf, testSLOs())
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^SERVICE +OBJECTIVE +TARGET +PERIOD +COMPLIANCE +BUDGET LEFT +BURN 5m +BURN 3d +STATUS$`, string(lines[0]))
+	assert.Regexp(t, `^checkout +availability +99.9% +30d +99.950% +50.0% +20.00 +- +🔥 fast burn$`, string(lines[1]))
+	assert.Regexp(t, `^checkout +latency \(<250ms\) +99% +7d +99.500% +50.0% +0.50 +- +✓ ok$`, string(lines[2]))
+
+	buf.Reset()
+	printSLOsText(&buf, nil)
+	assert.Contains(t, buf.String(), "No SLOs defined")
+}
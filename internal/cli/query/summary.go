@@ -12,11 +12,13 @@ import (
 	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
 	"github.com/coral-mesh/coral/internal/cli/helpers"
 	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
 )
 
 // summaryJSON is the JSON-serializable representation of a service summary.
 type summaryJSON struct {
 	ServiceName   string             `json:"service_name"`
+	Colony        string             `json:"colony,omitempty"`
 	Source        string             `json:"source,omitempty"`
 	Status        string             `json:"status"`
 	RequestCount  int64              `json:"request_count"`
@@ -77,6 +79,11 @@ type regressionJSON struct {
 func NewSummaryCmd() *cobra.Command {
 	var since string
 	var format string
+	var federated bool
+	var selector string
+	var group string
+	var watchEnabled bool
+	watch := summaryWatch{}
 
 	cmd := &cobra.Command{
 		Use:   "summary [service]",
@@ -91,6 +98,16 @@ Examples:
   coral query summary api                # Specific service
   coral query summary api --since 10m    # Custom time range
   coral query summary --format json      # JSON output
+  coral query summary --federated        # All services of all child colonies
+  coral query summary -l env=prod        # Services labeled env=prod
+  coral query summary --group checkout   # Services of the checkout group
+  coral query summary --watch            # Live dashboard with anomaly highlighting
+
+With --watch, the summary refreshes every --interval as a dashboard of each
+service's request rate, p95 latency and error rate. The colony compares them
+with a trailing baseline (--baseline) made of intervals as long as --since,
+and values deviating from the baseline mean by more than --sigma standard
+deviations are highlighted.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			service := ""
@@ -98,6 +115,15 @@ Examples:
 				service = args[0]
 			}
 
+			if watchEnabled {
+				if format != "text" || federated {
+					return fmt.Errorf("--watch only supports text output of the local colony")
+				}
+				if watch.interval <= 0 {
+					return fmt.Errorf("--interval must be positive")
+				}
+			}
+
 			ctx := context.Background()
 
 			// Create colony client.
@@ -106,16 +132,28 @@ Examples:
 				return fmt.Errorf("failed to create colony client: %w", err)
 			}
 
+			if watchEnabled {
+				watch.service = service
+				watch.selector = selector
+				watch.group = group
+				watch.since = since
+				return watch.run(ctx, client, os.Stdout)
+			}
+
 			// Execute RPC.
 			req := &colonypb.QueryUnifiedSummaryRequest{
 				Service:   service,
 				TimeRange: since,
+				Federated: federated,
+				Selector:  selector,
+				Group:     group,
 			}
 
 			resp, err := client.QueryUnifiedSummary(ctx, connect.NewRequest(req))
 			if err != nil {
 				return fmt.Errorf("failed to query summary: %w", err)
 			}
+			helpers.PrintFederationErrors(resp.Msg.FederationErrors)
 
 			if len(resp.Msg.Summaries) == 0 {
 				if format == "json" {
@@ -135,6 +173,13 @@ Examples:
 
 	cmd.Flags().StringVar(&since, "since", "5m", "Time range (e.g., 5m, 1h, 24h)")
 	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	cmd.Flags().BoolVarP(&watchEnabled, "watch", "w", false, "Refresh a dashboard highlighting anomalies")
+	cmd.Flags().DurationVar(&watch.interval, "interval", constants.DefaultSummaryWatchInterval, "Refresh interval of --watch")
+	cmd.Flags().StringVar(&watch.baselineWindow, "baseline", constants.DefaultBaselineWindow.String(), "Trailing baseline period of --watch")
+	cmd.Flags().Float64Var(&watch.sigma, "sigma", constants.DefaultAnomalySigma, "Standard deviations from the baseline flagged as anomalous")
+	helpers.AddFederatedFlag(cmd, &federated)
+	helpers.AddSelectorFlag(cmd, &selector)
+	helpers.AddGroupFlag(cmd, &group)
 	return cmd
 }
 
@@ -144,6 +189,7 @@ func printSummaryJSON(summaries []*colonypb.UnifiedSummaryResult) error {
 	for _, s := range summaries {
 		entry := summaryJSON{
 			ServiceName:  s.ServiceName,
+			Colony:       s.ColonyId,
 			Source:       s.Source,
 			Status:       s.Status,
 			RequestCount: s.RequestCount,
@@ -285,7 +331,11 @@ func printSummaryText(summaries []*colonypb.UnifiedSummaryResult) error {
 			statusIcon = "❌"
 		}
 
-		fmt.Printf("%s %s (%s)\n", statusIcon, summary.ServiceName, summary.Source)
+		if summary.ColonyId != "" {
+			fmt.Printf("%s %s (%s) [colony: %s]\n", statusIcon, summary.ServiceName, summary.Source, summary.ColonyId)
+		} else {
+			fmt.Printf("%s %s (%s)\n", statusIcon, summary.ServiceName, summary.Source)
+		}
 		fmt.Printf("   Status: %s\n", summary.Status)
 		fmt.Printf("   Requests: %d\n", summary.RequestCount)
 		fmt.Printf("   Error Rate: %.2f%%\n", summary.ErrorRate)
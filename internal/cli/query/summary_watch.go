@@ -0,0 +1,148 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/charmbracelet/lipgloss"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+)
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+var anomalyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+
+// summaryWatch holds the options of coral query summary --watch.
+type summaryWatch struct {
+	service        string
+	selector       string
+	group          string
+	since          string
+	baselineWindow string
+	sigma          float64
+	interval       time.Duration
+}
+
+// run refreshes the dashboard until interrupted. A failed refresh is shown
+// in place of the dashboard and retried at the next interval.
+func (w *summaryWatch) run(ctx context.Context, client colonyv1connect.ColonyServiceClient, out io.Writer) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		var screen strings.Builder
+		if err := w.refresh(ctx, client, &screen); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			screen.Reset()
+			fmt.Fprintf(&screen, "Failed to refresh summary: %v\n", err)
+		}
+		fmt.Fprint(out, clearScreen+screen.String())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh queries the summary with baselines and renders the dashboard.
+func (w *summaryWatch) refresh(ctx context.Context, client colonyv1connect.ColonyServiceClient, out io.Writer) error {
+	resp, err := client.QueryUnifiedSummary(ctx, connect.NewRequest(&colonypb.QueryUnifiedSummaryRequest{
+		Service:         w.service,
+		Selector:        w.selector,
+		Group:           w.group,
+		TimeRange:       w.since,
+		IncludeBaseline: true,
+		BaselineWindow:  w.baselineWindow,
+		AnomalySigma:    w.sigma,
+	}))
+	if err != nil {
+		return err
+	}
+
+	printSummaryDashboard(out, resp.Msg.Summaries, w, time.Now())
+	return nil
+}
+
+// printSummaryDashboard renders one line per service with its request rate,
+// p95 latency and error rate. Metrics deviating from the baseline by more
+// than the anomaly threshold are highlighted with their deviation.
+func printSummaryDashboard(out io.Writer, summaries []*colonypb.UnifiedSummaryResult, w *summaryWatch, now time.Time) {
+	fmt.Fprintf(out, "Service Health: last %s vs %s baseline, anomalies beyond %gσ  (%s, every %s, Ctrl+C to exit)\n\n",
+		w.since, w.baselineWindow, w.sigma, now.Format(time.TimeOnly), w.interval)
+
+	if len(summaries) == 0 {
+		fmt.Fprintln(out, "No data found for the specified service and time range")
+		return
+	}
+
+	sorted := append([]*colonypb.UnifiedSummaryResult(nil), summaries...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ServiceName < sorted[j].ServiceName })
+
+	nameWidth := len("SERVICE")
+	for _, s := range sorted {
+		nameWidth = max(nameWidth, len(s.ServiceName))
+	}
+
+	fmt.Fprintf(out, "%-*s  %-9s  %-20s  %-20s  %-20s\n", nameWidth, "SERVICE", "STATUS", "RPS", "P95", "ERRORS")
+	anomalies := 0
+	for _, s := range sorted {
+		b := s.Baseline
+		if b == nil {
+			b = &colonypb.ServiceBaseline{}
+		}
+		row := []string{
+			metricCell(b.RequestsPerSecond, "%.1f"),
+			metricCell(b.P95LatencyMs, "%.0fms"),
+			metricCell(b.ErrorRate, "%.2f%%"),
+		}
+		for _, m := range []*colonypb.MetricBaseline{b.RequestsPerSecond, b.P95LatencyMs, b.ErrorRate} {
+			if m.GetAnomalous() {
+				anomalies++
+			}
+		}
+		fmt.Fprintf(out, "%-*s  %-9s  %s  %s  %s\n", nameWidth, s.ServiceName, s.Status, row[0], row[1], row[2])
+	}
+
+	fmt.Fprintln(out)
+	if anomalies == 0 {
+		fmt.Fprintln(out, "No anomalies.")
+	} else {
+		fmt.Fprintf(out, "%d anomalous metric(s).\n", anomalies)
+	}
+}
+
+// metricCell formats a metric padded to its column, with its deviation from
+// the baseline when it is anomalous.
+func metricCell(m *colonypb.MetricBaseline, format string) string {
+	cell := fmt.Sprintf(format, m.GetCurrent())
+	if !m.GetAnomalous() {
+		return fmt.Sprintf("%-20s", cell)
+	}
+
+	arrow := "▲"
+	if m.Sigma < 0 {
+		arrow = "▼"
+	}
+	cell = fmt.Sprintf("%s %s%.1fσ", cell, arrow, math.Abs(m.Sigma))
+	// Pad before styling: escape sequences have no width.
+	return anomalyStyle.Render(fmt.Sprintf("%-20s", cell))
+}
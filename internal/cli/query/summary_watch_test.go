@@ -0,0 +1,56 @@
+package query
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestPrintSummaryDashboard(t *testing.T) {
+	w := &summaryWatch{since: "5m", baselineWindow: "1h", sigma: 3, interval: 5 * time.Second}
+	summaries := []*colonypb.UnifiedSummaryResult{
+		{
+			ServiceName: "web",
+			Status:      "healthy",
+			Baseline: &colonypb.ServiceBaseline{
+				RequestsPerSecond: &colonypb.MetricBaseline{Current: 12.5},
+				P95LatencyMs:      &colonypb.MetricBaseline{Current: 40},
+				ErrorRate:         &colonypb.MetricBaseline{},
+			},
+		},
+		{
+			ServiceName: "api",
+			Status:      "degraded",
+			Baseline: &colonypb.ServiceBaseline{
+				RequestsPerSecond: &colonypb.MetricBaseline{Current: 1},
+				P95LatencyMs:      &colonypb.MetricBaseline{Current: 900, Sigma: 4.23, Anomalous: true},
+				ErrorRate:         &colonypb.MetricBaseline{Current: 0, Sigma: -3.5, Anomalous: true},
+			},
+		},
+		{ServiceName: "batch", Status: "idle"},
+	}
+
+	var buf bytes.Buffer
+	printSummaryDashboard(&buf, summaries, w, time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC))
+	lines := strings.Split(buf.String(), "\n")
+
+	assert.Contains(t, lines[0], "last 5m vs 1h baseline")
+	assert.Contains(t, lines[0], "10:00:00")
+	require.GreaterOrEqual(t, len(lines), 7)
+	assert.True(t, strings.HasPrefix(lines[3], "api "), "services are sorted")
+	assert.Contains(t, lines[3], "900ms ▲4.2σ")
+	assert.Contains(t, lines[3], "0.00% ▼3.5σ")
+	assert.True(t, strings.HasPrefix(lines[4], "batch "), "services without baseline are listed")
+	assert.Contains(t, lines[5], "12.5")
+	assert.Contains(t, buf.String(), "2 anomalous metric(s).")
+
+	buf.Reset()
+	printSummaryDashboard(&buf, nil, w, time.Now())
+	assert.Contains(t, buf.String(), "No data found")
+}
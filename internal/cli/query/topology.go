@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
 
 	"connectrpc.com/connect"
@@ -15,61 +18,122 @@ import (
 
 // topologyConnectionJSON is the JSON-serializable representation of a service connection.
 type topologyConnectionJSON struct {
-	From     string `json:"from"`
-	To       string `json:"to"`
-	Protocol string `json:"protocol"`
-	Layer    string `json:"layer"`
+	From              string  `json:"from"`
+	To                string  `json:"to"`
+	Protocol          string  `json:"protocol"`
+	Layer             string  `json:"layer"`
+	RequestCount      int64   `json:"request_count"`
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	ErrorRate         float64 `json:"error_rate"`
+	P95LatencyMs      float64 `json:"p95_latency_ms"`
 }
 
 // topologyJSON is the JSON-serializable representation of the topology response.
 type topologyJSON struct {
-	ColonyID    string                   `json:"colony_id"`
+	ColonyID string `json:"colony_id"`
+	Since    string `json:"since"`
+
+	// Set with --service: the service, its direct callers and callees, and
+	// every service that reaches it (upstream) or that it reaches (downstream).
+	Service    string   `json:"service,omitempty"`
+	Callers    []string `json:"callers,omitempty"`
+	Callees    []string `json:"callees,omitempty"`
+	Upstream   []string `json:"upstream,omitempty"`
+	Downstream []string `json:"downstream,omitempty"`
+
 	Connections []topologyConnectionJSON `json:"connections"`
 }
 
+// serviceNeighborhood is the part of the dependency graph around a service.
+type serviceNeighborhood struct {
+	callers, callees     []string
+	upstream, downstream []string
+	connections          []*colonypb.Connection
+}
+
 // NewTopologyCmd creates the 'coral query topology' command (RFD 092, RFD 033).
 func NewTopologyCmd() *cobra.Command {
 	var format string
+	var since string
+	var service string
 	var includeL4 bool
 
 	cmd := &cobra.Command{
 		Use:   "topology",
 		Short: "Show the service dependency graph",
-		Long: `Show the live service dependency graph derived from observed trace and network data.
+		Long: `Show the live service dependency graph derived from observed trace, metric and network data.
 
 Displays all cross-service call relationships discovered in the last hour,
 showing which services call which other services, over what protocol, and
 at which evidence layer (L7 application trace or L4 network observation).
+Trace-derived edges also show their requests per second, error rate and p95
+latency over the window.
+
+Databases queried by services (from SQL client metrics) and gRPC servers
+without instrumentation (from gRPC client spans) are included as L7 edges
+with their request rates.
+
+The graph can be rendered as Graphviz DOT or as a Mermaid flowchart, to embed
+in docs and dashboards. Databases are drawn as cylinders and L4-only edges as
+dashed lines.
+
+With --service, only the edges leading to and from that service are shown,
+with its callers and callees: the services affected if it degrades.
 
 Examples:
   coral query topology                    # ASCII table (L4 + L7)
+  coral query topology --since 15m        # Last 15 minutes
+  coral query topology --service orders   # Callers and callees of orders
   coral query topology --include-l4=false # L7 (trace-derived) edges only
   coral query topology --format json      # JSON output
+  coral query topology --format dot | dot -Tsvg > topology.svg
+  coral query topology --format mermaid   # Mermaid flowchart for Markdown
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 
+			switch format {
+			case "text", "json", "dot", "mermaid":
+			default:
+				return fmt.Errorf("invalid --format %q: must be text, json, dot or mermaid", format)
+			}
+
 			client, err := helpers.GetColonyClient("")
 			if err != nil {
 				return fmt.Errorf("failed to connect to colony: %w", err)
 			}
 
-			resp, err := client.GetTopology(ctx, connect.NewRequest(&colonypb.GetTopologyRequest{}))
+			resp, err := client.GetTopology(ctx, connect.NewRequest(&colonypb.GetTopologyRequest{Since: since}))
 			if err != nil {
 				return fmt.Errorf("failed to get topology: %w", err)
 			}
 
 			conns := filterConnections(resp.Msg.Connections, includeL4)
 
-			if format == "json" {
-				return printTopologyJSON(resp.Msg.ColonyId, conns)
+			var hood *serviceNeighborhood
+			if service != "" {
+				hood = neighborhood(conns, service)
+				conns = hood.connections
 			}
 
-			return printTopologyText(conns)
+			switch format {
+			case "json":
+				return printTopologyJSON(resp.Msg.ColonyId, since, service, hood, conns)
+			case "dot":
+				printTopologyDOT(os.Stdout, service, conns)
+				return nil
+			case "mermaid":
+				printTopologyMermaid(os.Stdout, service, conns)
+				return nil
+			}
+
+			return printTopologyText(since, service, hood, conns)
 		},
 	}
 
-	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json, dot, mermaid)")
+	cmd.Flags().StringVar(&since, "since", "1h", "Time window of observed calls")
+	cmd.Flags().StringVar(&service, "service", "", "Only show the callers and callees of this service")
 	cmd.Flags().BoolVar(&includeL4, "include-l4", true, "Include L4 network edges (RFD 033)")
 	return cmd
 }
@@ -88,6 +152,74 @@ func filterConnections(conns []*colonypb.Connection, includeL4 bool) []*colonypb
 	return filtered
 }
 
+// neighborhood returns the direct callers and callees of service, the
+// services that reach it or that it reaches through any number of calls, and
+// the connections on those paths.
+func neighborhood(conns []*colonypb.Connection, service string) *serviceNeighborhood {
+	callersOf := make(map[string][]string)
+	calleesOf := make(map[string][]string)
+	for _, c := range conns {
+		callersOf[c.TargetId] = append(callersOf[c.TargetId], c.SourceId)
+		calleesOf[c.SourceId] = append(calleesOf[c.SourceId], c.TargetId)
+	}
+
+	upstream := reachable(callersOf, service)
+	downstream := reachable(calleesOf, service)
+
+	hood := &serviceNeighborhood{
+		callers:    sortedUnique(callersOf[service]),
+		callees:    sortedUnique(calleesOf[service]),
+		upstream:   sortedKeys(upstream),
+		downstream: sortedKeys(downstream),
+	}
+	for _, c := range conns {
+		// An edge leads to the service if its target is the service or one
+		// of its upstream callers, and away from it likewise downstream.
+		toService := c.TargetId == service || upstream[c.TargetId]
+		fromService := c.SourceId == service || downstream[c.SourceId]
+		if toService || fromService {
+			hood.connections = append(hood.connections, c)
+		}
+	}
+	return hood
+}
+
+// reachable returns the nodes reachable from start through next, excluding
+// start itself.
+func reachable(next map[string][]string, start string) map[string]bool {
+	seen := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, n := range next[node] {
+			if !seen[n] {
+				seen[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	delete(seen, start)
+	return seen
+}
+
+func sortedUnique(items []string) []string {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return sortedKeys(set)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // evidenceLayerLabel returns the short display label for an evidence layer.
 func evidenceLayerLabel(layer colonypb.EvidenceLayer) string {
 	switch layer {
@@ -96,18 +228,26 @@ func evidenceLayerLabel(layer colonypb.EvidenceLayer) string {
 	case colonypb.EvidenceLayer_EVIDENCE_LAYER_BOTH:
 		return "BOTH"
 	default:
-		// L7_TRACE and UNSPECIFIED (legacy) are both L7.
+		// L7_TRACE, L7_CLIENT and UNSPECIFIED (legacy) are all L7.
 		return "L7"
 	}
 }
 
-func printTopologyText(conns []*colonypb.Connection) error {
+func printTopologyText(since, service string, hood *serviceNeighborhood, conns []*colonypb.Connection) error {
+	if hood != nil {
+		fmt.Printf("Service: %s\n", service)
+		fmt.Printf("  Callers:    %s\n", joinOrNone(hood.callers))
+		fmt.Printf("  Callees:    %s\n", joinOrNone(hood.callees))
+		fmt.Printf("  Upstream:   %s\n", joinOrNone(hood.upstream))
+		fmt.Printf("  Downstream: %s\n\n", joinOrNone(hood.downstream))
+	}
+
 	if len(conns) == 0 {
-		fmt.Println("Service Topology: no cross-service calls observed in the last hour")
+		fmt.Printf("Service Topology: no cross-service calls observed in the last %s\n", since)
 		return nil
 	}
 
-	fmt.Printf("Service Topology (last 1h, %d connection(s)):\n\n", len(conns))
+	fmt.Printf("Service Topology (last %s, %d connection(s)):\n\n", since, len(conns))
 
 	// Calculate column widths.
 	const (
@@ -133,35 +273,64 @@ func printTopologyText(conns []*colonypb.Connection) error {
 	}
 
 	// Print header.
-	fmtStr := fmt.Sprintf("%%-%ds  →  %%-%ds  %%-%ds  %%-%ds\n", fromW, toW, protoW, layerW)
-	fmt.Printf(fmtStr, "FROM SERVICE", "TO SERVICE", "PROTOCOL", "LAYER")
-	fmt.Printf("%s     %s  %s  %s\n",
+	fmtStr := fmt.Sprintf("%%-%ds  →  %%-%ds  %%-%ds  %%-%ds  %%8s  %%7s  %%9s\n", fromW, toW, protoW, layerW)
+	fmt.Printf(fmtStr, "FROM SERVICE", "TO SERVICE", "PROTOCOL", "LAYER", "RPS", "ERRORS", "P95")
+	fmt.Printf("%s     %s  %s  %s  %s  %s  %s\n",
 		strings.Repeat("-", fromW),
 		strings.Repeat("-", toW),
 		strings.Repeat("-", protoW),
 		strings.Repeat("-", layerW),
+		strings.Repeat("-", 8),
+		strings.Repeat("-", 7),
+		strings.Repeat("-", 9),
 	)
 
-	// Print rows.
+	// Print rows. Edges without trace data (L4 only) have no traffic stats.
 	for _, c := range conns {
-		fmt.Printf(fmtStr, c.SourceId, c.TargetId, strings.ToUpper(c.ConnectionType), evidenceLayerLabel(c.EvidenceLayer))
+		rps, errRate, p95 := "-", "-", "-"
+		if c.RequestCount > 0 {
+			rps = fmt.Sprintf("%.2f", c.RequestsPerSecond)
+			errRate = fmt.Sprintf("%.1f%%", c.ErrorRate)
+			p95 = fmt.Sprintf("%.1fms", c.P95LatencyMs)
+		}
+		fmt.Printf(fmtStr, c.SourceId, c.TargetId, strings.ToUpper(c.ConnectionType), evidenceLayerLabel(c.EvidenceLayer),
+			rps, errRate, p95)
 	}
 
 	return nil
 }
 
-func printTopologyJSON(colonyID string, conns []*colonypb.Connection) error {
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return "(none)"
+	}
+	return strings.Join(items, ", ")
+}
+
+func printTopologyJSON(colonyID, since, service string, hood *serviceNeighborhood, conns []*colonypb.Connection) error {
 	out := topologyJSON{
 		ColonyID:    colonyID,
+		Since:       since,
 		Connections: make([]topologyConnectionJSON, 0, len(conns)),
 	}
+	if hood != nil {
+		out.Service = service
+		out.Callers = hood.callers
+		out.Callees = hood.callees
+		out.Upstream = hood.upstream
+		out.Downstream = hood.downstream
+	}
 
 	for _, c := range conns {
 		out.Connections = append(out.Connections, topologyConnectionJSON{
-			From:     c.SourceId,
-			To:       c.TargetId,
-			Protocol: strings.ToUpper(c.ConnectionType),
-			Layer:    evidenceLayerLabel(c.EvidenceLayer),
+			From:              c.SourceId,
+			To:                c.TargetId,
+			Protocol:          strings.ToUpper(c.ConnectionType),
+			Layer:             evidenceLayerLabel(c.EvidenceLayer),
+			RequestCount:      c.RequestCount,
+			RequestsPerSecond: c.RequestsPerSecond,
+			ErrorRate:         c.ErrorRate,
+			P95LatencyMs:      c.P95LatencyMs,
 		})
 	}
 
@@ -172,3 +341,104 @@ func printTopologyJSON(colonyID string, conns []*colonypb.Connection) error {
 	fmt.Println(string(data))
 	return nil
 }
+
+// topologyNodes returns the nodes of conns in order of first appearance.
+// Targets of SQL edges are databases.
+func topologyNodes(conns []*colonypb.Connection) (nodes []string, databases map[string]bool) {
+	seen := make(map[string]bool)
+	databases = make(map[string]bool)
+	for _, c := range conns {
+		for _, n := range []string{c.SourceId, c.TargetId} {
+			if !seen[n] {
+				seen[n] = true
+				nodes = append(nodes, n)
+			}
+		}
+		if c.ConnectionType == "sql" {
+			databases[c.TargetId] = true
+		}
+	}
+	return nodes, databases
+}
+
+// topologyEdgeLabel describes an edge by its protocol and, when it has
+// traffic, its request rate and errors, joined by sep.
+func topologyEdgeLabel(c *colonypb.Connection, sep string) string {
+	parts := []string{strings.ToUpper(c.ConnectionType)}
+	if c.RequestCount > 0 {
+		parts = append(parts, fmt.Sprintf("%.2f rps", c.RequestsPerSecond))
+		if c.ErrorRate > 0 {
+			parts = append(parts, fmt.Sprintf("%.1f%% errors", c.ErrorRate))
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// printTopologyDOT writes the graph in Graphviz DOT. The --service node, if
+// any, is drawn bold.
+func printTopologyDOT(w io.Writer, service string, conns []*colonypb.Connection) {
+	nodes, databases := topologyNodes(conns)
+
+	_, _ = fmt.Fprintln(w, "digraph topology {")
+	_, _ = fmt.Fprintln(w, "  rankdir=LR;")
+	_, _ = fmt.Fprintln(w, "  node [shape=box, style=rounded];")
+	for _, n := range nodes {
+		var attrs []string
+		if databases[n] {
+			attrs = append(attrs, "shape=cylinder")
+		}
+		if n == service {
+			attrs = append(attrs, "penwidth=2")
+		}
+		if len(attrs) > 0 {
+			_, _ = fmt.Fprintf(w, "  %s [%s];\n", dotQuote(n), strings.Join(attrs, ", "))
+		}
+	}
+	for _, c := range conns {
+		attrs := "label=" + dotQuote(topologyEdgeLabel(c, `\n`))
+		if c.EvidenceLayer == colonypb.EvidenceLayer_EVIDENCE_LAYER_L4_NETWORK {
+			attrs += ", style=dashed"
+		}
+		_, _ = fmt.Fprintf(w, "  %s -> %s [%s];\n", dotQuote(c.SourceId), dotQuote(c.TargetId), attrs)
+	}
+	_, _ = fmt.Fprintln(w, "}")
+}
+
+// dotQuote quotes a DOT ID. Escape sequences such as the \n line break of
+// labels are kept.
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// printTopologyMermaid writes the graph as a Mermaid flowchart. Node IDs are
+// generated, since service names and IP addresses are not valid Mermaid IDs.
+func printTopologyMermaid(w io.Writer, service string, conns []*colonypb.Connection) {
+	nodes, databases := topologyNodes(conns)
+	ids := make(map[string]string, len(nodes))
+
+	_, _ = fmt.Fprintln(w, "graph LR")
+	for i, n := range nodes {
+		ids[n] = fmt.Sprintf("n%d", i)
+		if databases[n] {
+			_, _ = fmt.Fprintf(w, "  %s[(\"%s\")]\n", ids[n], mermaidEscape(n))
+		} else {
+			_, _ = fmt.Fprintf(w, "  %s[\"%s\"]\n", ids[n], mermaidEscape(n))
+		}
+	}
+	for _, c := range conns {
+		arrow := "-->"
+		if c.EvidenceLayer == colonypb.EvidenceLayer_EVIDENCE_LAYER_L4_NETWORK {
+			arrow = "-.->"
+		}
+		_, _ = fmt.Fprintf(w, "  %s %s|\"%s\"| %s\n",
+			ids[c.SourceId], arrow, mermaidEscape(topologyEdgeLabel(c, "<br/>")), ids[c.TargetId])
+	}
+	if id, ok := ids[service]; ok {
+		_, _ = fmt.Fprintf(w, "  style %s stroke-width:3px\n", id)
+	}
+}
+
+// mermaidEscape escapes the double quotes of a Mermaid label.
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,3 +51,80 @@ func TestFilterConnections_Empty(t *testing.T) {
 	assert.Empty(t, filterConnections(nil, false))
 	assert.Empty(t, filterConnections(nil, true))
 }
+
+func TestNeighborhood(t *testing.T) {
+	// web → gateway → orders → payments → bank, mobile → orders,
+	// gateway → search is unrelated to orders.
+	conns := []*colonypb.Connection{
+		{SourceId: "web", TargetId: "gateway"},
+		{SourceId: "gateway", TargetId: "orders"},
+		{SourceId: "mobile", TargetId: "orders"},
+		{SourceId: "orders", TargetId: "payments"},
+		{SourceId: "payments", TargetId: "bank"},
+		{SourceId: "gateway", TargetId: "search"},
+	}
+
+	hood := neighborhood(conns, "orders")
+	assert.Equal(t, []string{"gateway", "mobile"}, hood.callers)
+	assert.Equal(t, []string{"payments"}, hood.callees)
+	assert.Equal(t, []string{"gateway", "mobile", "web"}, hood.upstream)
+	assert.Equal(t, []string{"bank", "payments"}, hood.downstream)
+
+	var edges []string
+	for _, c := range hood.connections {
+		edges = append(edges, c.SourceId+"→"+c.TargetId)
+	}
+	assert.Equal(t, []string{"web→gateway", "gateway→orders", "mobile→orders", "orders→payments", "payments→bank"}, edges)
+
+	unknown := neighborhood(conns, "billing")
+	assert.Empty(t, unknown.callers)
+	assert.Empty(t, unknown.upstream)
+	assert.Empty(t, unknown.connections)
+}
+
+// graphConnections are web → orders over HTTP with traffic, orders →
+// postgresql over SQL, and an L4-only edge from orders to an external IP.
+var graphConnections = []*colonypb.Connection{
+	{SourceId: "web", TargetId: "orders", ConnectionType: "http", EvidenceLayer: colonypb.EvidenceLayer_EVIDENCE_LAYER_L7_TRACE,
+		RequestCount: 900, RequestsPerSecond: 15, ErrorRate: 1.5},
+	{SourceId: "orders", TargetId: "postgresql", ConnectionType: "sql", EvidenceLayer: colonypb.EvidenceLayer_EVIDENCE_LAYER_L7_CLIENT,
+		RequestCount: 120, RequestsPerSecond: 2},
+	{SourceId: "orders", TargetId: "10.0.0.9", ConnectionType: "tcp", EvidenceLayer: colonypb.EvidenceLayer_EVIDENCE_LAYER_L4_NETWORK},
+}
+
+func TestPrintTopologyDOT(t *testing.T) {
+	var buf bytes.Buffer
+	printTopologyDOT(&buf, "orders", graphConnections)
+
+	assert.Equal(t, `digraph topology {
+  rankdir=LR;
+  node [shape=box, style=rounded];
+  "orders" [penwidth=2];
+  "postgresql" [shape=cylinder];
+  "web" -> "orders" [label="HTTP\n15.00 rps\n1.5% errors"];
+  "orders" -> "postgresql" [label="SQL\n2.00 rps"];
+  "orders" -> "10.0.0.9" [label="TCP", style=dashed];
+}
+`, buf.String())
+}
+
+func TestPrintTopologyMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	printTopologyMermaid(&buf, "orders", graphConnections)
+
+	assert.Equal(t, `graph LR
+  n0["web"]
+  n1["orders"]
+  n2[("postgresql")]
+  n3["10.0.0.9"]
+  n0 -->|"HTTP<br/>15.00 rps<br/>1.5% errors"| n1
+  n1 -->|"SQL<br/>2.00 rps"| n2
+  n1 -.->|"TCP"| n3
+  style n1 stroke-width:3px
+`, buf.String())
+}
+
+func TestDOTQuote(t *testing.T) {
+	assert.Equal(t, `"say \"hi\""`, dotQuote(`say "hi"`))
+	assert.Equal(t, `"a\nb"`, dotQuote(`a\nb`))
+}
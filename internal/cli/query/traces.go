@@ -2,25 +2,40 @@ package query
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	colonypb "github.com/coral-mesh/coral/coral/colony/v1"
 	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/constants"
 )
 
+// traceBarWidth is the width of the timing bars of the span tree.
+const traceBarWidth = 30
+
 // traceSpanJSON is the JSON representation of a single span.
 type traceSpanJSON struct {
-	TraceID    string            `json:"trace_id"`
-	SpanName   string            `json:"span_name"`
-	Service    string            `json:"service"`
-	DurationMs float64           `json:"duration_ms"`
-	Attributes map[string]string `json:"attributes,omitempty"`
+	TraceID      string            `json:"trace_id"`
+	SpanID       string            `json:"span_id"`
+	ParentSpanID string            `json:"parent_span_id,omitempty"`
+	SpanName     string            `json:"span_name"`
+	Service      string            `json:"service"`
+	StartTimeMs  int64             `json:"start_time_ms"`
+	DurationMs   float64           `json:"duration_ms"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
 }
 
 // tracesResponseJSON is the JSON output for coral query traces.
@@ -32,12 +47,16 @@ type tracesResponseJSON struct {
 
 func NewTracesCmd() *cobra.Command {
 	var (
+		service   string
 		since     string
 		traceID   string
 		source    string
 		minDurMs  int
 		maxTraces int
 		format    string
+		federated bool
+		selector  string
+		group     string
 	)
 
 	cmd := &cobra.Command{
@@ -45,20 +64,38 @@ func NewTracesCmd() *cobra.Command {
 		Short: "Query distributed traces",
 		Long: `Query distributed traces from all sources (eBPF + OTLP).
 
-Returns unified trace data with source annotations.
+Each trace is rendered as a span tree with the duration of every span, its
+start offset in the trace, and a timing bar. Traces include the spans of all
+the services they cross, also with --service. Aggregated OTLP summaries are
+listed after the traces.
+
+With --format otlp-json, the spans are exported as an OTLP/JSON
+ExportTraceServiceRequest, which Jaeger, Tempo and the OpenTelemetry
+Collector can import. Aggregated OTLP summaries are not exported.
 
 Examples:
-  coral query traces api                           # All traces for api service
-  coral query traces --trace-id abc123             # Specific trace
-  coral query traces api --source ebpf             # Only eBPF traces
-  coral query traces api --min-duration-ms 500     # Only slow traces
-  coral query traces api --format json             # JSON output
+  coral query traces --service api --since 1h       # Recent traces through api
+  coral query traces --trace-id abc123              # Specific trace
+  coral query traces api --source ebpf              # Only eBPF traces
+  coral query traces api --min-duration-ms 500      # Only slow traces
+  coral query traces api --format json              # JSON output
+  coral query traces --trace-id abc123 --format otlp-json > trace.json
+  coral query traces api --federated                # Include child colonies
+  coral query traces --group checkout-path          # Traces through a service group
 `,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			service := ""
 			if len(args) > 0 {
+				if service != "" && service != args[0] {
+					return fmt.Errorf("service given both as argument (%s) and with --service (%s)", args[0], service)
+				}
 				service = args[0]
 			}
+			switch format {
+			case "text", "json", "otlp-json":
+			default:
+				return fmt.Errorf("invalid --format %q: must be text, json or otlp-json", format)
+			}
 
 			ctx := context.Background()
 
@@ -76,15 +113,22 @@ Examples:
 				TraceId:       traceID,
 				MinDurationMs: int32(minDurMs),
 				MaxTraces:     int32(maxTraces),
+				Federated:     federated,
+				Selector:      selector,
+				Group:         group,
 			}
 
 			resp, err := client.QueryUnifiedTraces(ctx, connect.NewRequest(req))
 			if err != nil {
 				return fmt.Errorf("failed to query traces: %w", err)
 			}
+			helpers.PrintFederationErrors(resp.Msg.FederationErrors)
 
-			if format == "json" {
+			switch format {
+			case "json":
 				return printTracesJSON(resp.Msg.Spans, resp.Msg.TotalTraces)
+			case "otlp-json":
+				return printTracesOTLP(os.Stdout, resp.Msg.Spans)
 			}
 
 			// Print result.
@@ -93,51 +137,228 @@ Examples:
 				return nil
 			}
 
-			// Group spans by trace ID.
-			traceGroups := make(map[string][]*agentv1.EbpfTraceSpan)
-			for _, span := range resp.Msg.Spans {
-				traceGroups[span.TraceId] = append(traceGroups[span.TraceId], span)
-			}
-
-			fmt.Printf("Found %d spans across %d traces:\n\n", len(resp.Msg.Spans), resp.Msg.TotalTraces)
-
-			for traceID, traceSpans := range traceGroups {
-				fmt.Printf("Trace: %s (%d spans)\n", traceID, len(traceSpans))
-				for _, span := range traceSpans {
-					durationMs := float64(span.DurationUs) / 1000.0
-					sourceIcon := "📍" // Default eBPF
-					if span.ServiceName != "" && len(span.ServiceName) > 6 {
-						if span.ServiceName[len(span.ServiceName)-6:] == "[OTLP]" {
-							sourceIcon = "📊" // OTLP data
-						}
-					}
-
-					fmt.Printf("  %s %s: %s (%.2fms)\n",
-						sourceIcon, span.ServiceName, span.SpanName, durationMs)
-
-					// Show OTLP attributes if present.
-					if source, ok := span.Attributes["source"]; ok && source == "OTLP" {
-						fmt.Printf("     Aggregated: %s spans, %s errors\n",
-							span.Attributes["total_spans"], span.Attributes["error_count"])
-					}
-				}
-				fmt.Println()
-			}
-
+			printTraceTrees(os.Stdout, resp.Msg.Spans)
 			return nil
 		},
 	}
 
+	cmd.Flags().StringVar(&service, "service", "", "Only traces through this service")
 	cmd.Flags().StringVar(&since, "since", "1h", "Time range (e.g., 1h, 30m, 24h)")
 	cmd.Flags().StringVar(&traceID, "trace-id", "", "Specific trace ID")
 	cmd.Flags().StringVar(&source, "source", "all", "Data source: ebpf, telemetry, or all")
 	cmd.Flags().IntVar(&minDurMs, "min-duration-ms", 0, "Minimum trace duration in milliseconds")
 	cmd.Flags().IntVar(&maxTraces, "max-traces", 10, "Maximum number of traces to return")
-	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format (text, json, otlp-json)")
+	helpers.AddFederatedFlag(cmd, &federated)
+	helpers.AddSelectorFlag(cmd, &selector)
+	helpers.AddGroupFlag(cmd, &group)
 
 	return cmd
 }
 
+// spanNode is a span and its child spans in a trace tree.
+type spanNode struct {
+	span     *agentv1.EbpfTraceSpan
+	children []*spanNode
+}
+
+// traceTree is the span tree of a trace.
+type traceTree struct {
+	traceID string
+	roots   []*spanNode
+	spans   int
+
+	// start and end bound the spans of the trace, in Unix microseconds.
+	start, end int64
+}
+
+// isAggregateSpan reports whether span is a synthetic span summarizing OTLP
+// telemetry rather than a real span.
+func isAggregateSpan(span *agentv1.EbpfTraceSpan) bool {
+	return span.Attributes["source"] == "OTLP"
+}
+
+// spanStartUs returns the start time of span in Unix microseconds.
+func spanStartUs(span *agentv1.EbpfTraceSpan) int64 {
+	return span.StartTime * 1000
+}
+
+// buildTraceTrees groups spans by trace and links them to their parent span,
+// most recent trace first. Spans whose parent is not part of the result are
+// roots. Aggregate spans are skipped.
+func buildTraceTrees(spans []*agentv1.EbpfTraceSpan) []*traceTree {
+	byTrace := make(map[string][]*agentv1.EbpfTraceSpan)
+	for _, span := range spans {
+		if !isAggregateSpan(span) {
+			byTrace[span.TraceId] = append(byTrace[span.TraceId], span)
+		}
+	}
+
+	trees := make([]*traceTree, 0, len(byTrace))
+	for traceID, traceSpans := range byTrace {
+		sort.SliceStable(traceSpans, func(i, j int) bool {
+			return traceSpans[i].StartTime < traceSpans[j].StartTime
+		})
+
+		tree := &traceTree{traceID: traceID, spans: len(traceSpans)}
+		nodes := make(map[string]*spanNode, len(traceSpans))
+		for i, span := range traceSpans {
+			nodes[span.SpanId] = &spanNode{span: span}
+			start := spanStartUs(span)
+			if i == 0 || start < tree.start {
+				tree.start = start
+			}
+			if end := start + span.DurationUs; i == 0 || end > tree.end {
+				tree.end = end
+			}
+		}
+
+		// Link children to parents, guarding against cycles in bad data:
+		// a span is only attached below a parent not already below it.
+		attached := make(map[*spanNode]bool)
+		for _, span := range traceSpans {
+			node := nodes[span.SpanId]
+			parent, ok := nodes[span.ParentSpanId]
+			if !ok || parent == node || attached[node] || descends(parent, node) {
+				continue
+			}
+			parent.children = append(parent.children, node)
+			attached[node] = true
+		}
+		for _, span := range traceSpans {
+			if node := nodes[span.SpanId]; !attached[node] {
+				tree.roots = append(tree.roots, node)
+				attached[node] = true
+			}
+		}
+		trees = append(trees, tree)
+	}
+
+	sort.Slice(trees, func(i, j int) bool {
+		if trees[i].start != trees[j].start {
+			return trees[i].start > trees[j].start
+		}
+		return trees[i].traceID < trees[j].traceID
+	})
+	return trees
+}
+
+// descends reports whether node is below ancestor in the tree.
+func descends(node, ancestor *spanNode) bool {
+	for _, child := range ancestor.children {
+		if child == node || descends(node, child) {
+			return true
+		}
+	}
+	return false
+}
+
+// spanLine is a rendered span of a trace tree.
+type spanLine struct {
+	label  string
+	node   *spanNode
+	prefix string
+}
+
+// printTraceTrees renders the spans as one tree per trace, with the duration
+// and start offset of each span and a timing bar, then lists the aggregated
+// OTLP summaries.
+func printTraceTrees(w io.Writer, spans []*agentv1.EbpfTraceSpan) {
+	trees := buildTraceTrees(spans)
+
+	var aggregates []*agentv1.EbpfTraceSpan
+	for _, span := range spans {
+		if isAggregateSpan(span) {
+			aggregates = append(aggregates, span)
+		}
+	}
+
+	if len(trees) > 0 {
+		_, _ = fmt.Fprintf(w, "Found %d traces:\n\n", len(trees))
+	}
+	for _, tree := range trees {
+		_, _ = fmt.Fprintf(w, "Trace %s  %d spans  %s  %s\n",
+			tree.traceID,
+			tree.spans,
+			formatSpanDuration(tree.end-tree.start),
+			time.UnixMicro(tree.start).Local().Format("2006-01-02 15:04:05.000"))
+
+		var lines []spanLine
+		var walk func(nodes []*spanNode, indent string)
+		walk = func(nodes []*spanNode, indent string) {
+			for i, node := range nodes {
+				branch, next := "├─ ", "│  "
+				if i == len(nodes)-1 {
+					branch, next = "└─ ", "   "
+				}
+				lines = append(lines, spanLine{prefix: indent + branch, node: node, label: spanLabel(node.span)})
+				walk(node.children, indent+next)
+			}
+		}
+		walk(tree.roots, "")
+
+		width := 0
+		for _, line := range lines {
+			width = max(width, utf8.RuneCountInString(line.prefix+line.label))
+		}
+		for _, line := range lines {
+			text := line.prefix + line.label
+			span := line.node.span
+			_, _ = fmt.Fprintf(w, "  %s%s  %10s  %10s  %s\n",
+				text,
+				strings.Repeat(" ", width-utf8.RuneCountInString(text)),
+				formatSpanDuration(span.DurationUs),
+				"+"+formatSpanDuration(spanStartUs(span)-tree.start),
+				timingBar(spanStartUs(span)-tree.start, span.DurationUs, tree.end-tree.start))
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+
+	if len(aggregates) > 0 {
+		_, _ = fmt.Fprintln(w, "OTLP summaries:")
+		for _, span := range aggregates {
+			_, _ = fmt.Fprintf(w, "  📊 %s%s: %s (p95 %s, %s spans, %s errors)\n",
+				colonyPrefix(span.Attributes),
+				span.ServiceName,
+				span.SpanName,
+				formatSpanDuration(span.DurationUs),
+				span.Attributes["total_spans"],
+				span.Attributes["error_count"])
+		}
+	}
+}
+
+// spanLabel describes a span in a trace tree: its name, service and, for
+// failed requests, status code.
+func spanLabel(span *agentv1.EbpfTraceSpan) string {
+	label := fmt.Sprintf("%s (%s%s)", span.SpanName, colonyPrefix(span.Attributes), span.ServiceName)
+	if span.StatusCode >= 400 {
+		label += fmt.Sprintf(" ✗ %d", span.StatusCode)
+	}
+	return label
+}
+
+// timingBar draws a span starting offsetUs into a trace lasting totalUs as a
+// bar of traceBarWidth columns.
+func timingBar(offsetUs, durationUs, totalUs int64) string {
+	if totalUs <= 0 {
+		return strings.Repeat("█", traceBarWidth)
+	}
+	start := int(offsetUs * traceBarWidth / totalUs)
+	length := int(durationUs * traceBarWidth / totalUs)
+	start = min(max(start, 0), traceBarWidth-1)
+	length = min(max(length, 1), traceBarWidth-start)
+	return strings.Repeat(" ", start) + strings.Repeat("█", length)
+}
+
+// formatSpanDuration formats a duration in microseconds.
+func formatSpanDuration(us int64) string {
+	if us >= 1_000_000 {
+		return fmt.Sprintf("%.2fs", float64(us)/1_000_000)
+	}
+	return fmt.Sprintf("%.2fms", float64(us)/1000)
+}
+
 // printTracesJSON outputs trace spans as JSON.
 func printTracesJSON(spans []*agentv1.EbpfTraceSpan, totalTraces int32) error {
 	if len(spans) == 0 {
@@ -152,13 +373,150 @@ func printTracesJSON(spans []*agentv1.EbpfTraceSpan, totalTraces int32) error {
 	}
 	for _, s := range spans {
 		out.Spans = append(out.Spans, traceSpanJSON{
-			TraceID:    s.TraceId,
-			SpanName:   s.SpanName,
-			Service:    s.ServiceName,
-			DurationMs: float64(s.DurationUs) / 1000.0,
-			Attributes: s.Attributes,
+			TraceID:      s.TraceId,
+			SpanID:       s.SpanId,
+			ParentSpanID: s.ParentSpanId,
+			SpanName:     s.SpanName,
+			Service:      s.ServiceName,
+			StartTimeMs:  s.StartTime,
+			DurationMs:   float64(s.DurationUs) / 1000.0,
+			Attributes:   s.Attributes,
 		})
 	}
 
 	return json.NewEncoder(os.Stdout).Encode(out)
 }
+
+// printTracesOTLP outputs trace spans as an OTLP/JSON
+// ExportTraceServiceRequest.
+func printTracesOTLP(w io.Writer, spans []*agentv1.EbpfTraceSpan) error {
+	traces, skipped := tracesToOTLP(spans)
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d spans without valid trace or span IDs\n", skipped)
+	}
+
+	data, err := (&ptrace.JSONMarshaler{}).MarshalTraces(traces)
+	if err != nil {
+		return fmt.Errorf("failed to encode traces: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// tracesToOTLP converts spans to OTLP traces, with a resource per service
+// (and colony, in federated results). It returns the number of spans
+// skipped for lacking valid IDs; aggregate spans are skipped silently.
+func tracesToOTLP(spans []*agentv1.EbpfTraceSpan) (ptrace.Traces, int) {
+	traces := ptrace.NewTraces()
+	scopes := make(map[string]ptrace.SpanSlice)
+	skipped := 0
+
+	for _, span := range spans {
+		if isAggregateSpan(span) {
+			continue
+		}
+		traceID, okTrace := parseTraceID(span.TraceId)
+		spanID, okSpan := parseSpanID(span.SpanId)
+		if !okTrace || !okSpan {
+			skipped++
+			continue
+		}
+
+		colonyID := span.Attributes[constants.FederationColonyAttribute]
+		key := span.ServiceName + "\x00" + colonyID
+		scopeSpans, ok := scopes[key]
+		if !ok {
+			rs := traces.ResourceSpans().AppendEmpty()
+			rs.Resource().Attributes().PutStr("service.name", span.ServiceName)
+			if colonyID != "" {
+				rs.Resource().Attributes().PutStr(constants.FederationColonyAttribute, colonyID)
+			}
+			ss := rs.ScopeSpans().AppendEmpty()
+			ss.Scope().SetName("coral")
+			scopeSpans = ss.Spans()
+			scopes[key] = scopeSpans
+		}
+
+		s := scopeSpans.AppendEmpty()
+		s.SetTraceID(traceID)
+		s.SetSpanID(spanID)
+		if parentID, ok := parseSpanID(span.ParentSpanId); ok {
+			s.SetParentSpanID(parentID)
+		}
+		s.SetName(span.SpanName)
+		s.SetKind(otlpSpanKind(span.SpanKind))
+		start := time.UnixMilli(span.StartTime)
+		s.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		s.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Duration(span.DurationUs) * time.Microsecond)))
+
+		attrs := s.Attributes()
+		for k, v := range span.Attributes {
+			if k != constants.FederationColonyAttribute {
+				attrs.PutStr(k, v)
+			}
+		}
+		// HTTP status codes start at 100, gRPC ones end at 16.
+		switch {
+		case span.StatusCode >= 100:
+			attrs.PutInt("http.response.status_code", int64(span.StatusCode))
+			if span.StatusCode >= 500 {
+				s.Status().SetCode(ptrace.StatusCodeError)
+			}
+		case span.StatusCode > 0:
+			attrs.PutInt("rpc.grpc.status_code", int64(span.StatusCode))
+			s.Status().SetCode(ptrace.StatusCodeError)
+		}
+	}
+
+	return traces, skipped
+}
+
+// otlpSpanKind converts a span kind ("server", "client", ...) to its OTLP
+// value.
+func otlpSpanKind(kind string) ptrace.SpanKind {
+	switch strings.ToLower(kind) {
+	case "internal":
+		return ptrace.SpanKindInternal
+	case "server":
+		return ptrace.SpanKindServer
+	case "client":
+		return ptrace.SpanKindClient
+	case "producer":
+		return ptrace.SpanKindProducer
+	case "consumer":
+		return ptrace.SpanKindConsumer
+	default:
+		return ptrace.SpanKindUnspecified
+	}
+}
+
+// parseTraceID parses a 32-character hex trace ID.
+func parseTraceID(s string) (pcommon.TraceID, bool) {
+	var id pcommon.TraceID
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(id) {
+		return id, false
+	}
+	copy(id[:], b)
+	return id, !id.IsEmpty()
+}
+
+// parseSpanID parses a 16-character hex span ID.
+func parseSpanID(s string) (pcommon.SpanID, bool) {
+	var id pcommon.SpanID
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != len(id) {
+		return id, false
+	}
+	copy(id[:], b)
+	return id, !id.IsEmpty()
+}
+
+// colonyPrefix returns the "[colony] " prefix of a federated query result, or
+// an empty string for results of the local colony only.
+func colonyPrefix(attributes map[string]string) string {
+	if colonyID, ok := attributes[constants.FederationColonyAttribute]; ok {
+		return fmt.Sprintf("[%s] ", colonyID)
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testStartMs = 1_700_000_000_000
+)
+
+// testTraceSpans returns a gateway → api → db trace, with a second call
+// from the gateway to auth failing, and an OTLP summary.
+func testTraceSpans() []*agentv1.EbpfTraceSpan {
+	return []*agentv1.EbpfTraceSpan{
+		{TraceId: testTraceID, SpanId: "00f067aa0ba902b7", ParentSpanId: "", ServiceName: "gateway", SpanName: "GET /orders", SpanKind: "server", StartTime: testStartMs, DurationUs: 100_000, StatusCode: 200},
+		{TraceId: testTraceID, SpanId: "00f067aa0ba902b9", ParentSpanId: "00f067aa0ba902b7", ServiceName: "auth", SpanName: "POST /verify", SpanKind: "server", StartTime: testStartMs + 80, DurationUs: 10_000, StatusCode: 503},
+		{TraceId: testTraceID, SpanId: "00f067aa0ba902b8", ParentSpanId: "00f067aa0ba902b7", ServiceName: "api", SpanName: "GET /internal/orders", SpanKind: "server", StartTime: testStartMs + 10, DurationUs: 50_000, StatusCode: 200},
+		{TraceId: testTraceID, SpanId: "00f067aa0ba902ba", ParentSpanId: "00f067aa0ba902b8", ServiceName: "api", SpanName: "SELECT orders", SpanKind: "client", StartTime: testStartMs + 20, DurationUs: 25_000},
+		{TraceId: testTraceID, SpanId: "otlp-api-1", ServiceName: "api [OTLP]", SpanName: "OTLP Summary (server)", StartTime: testStartMs, DurationUs: 120_000, Attributes: map[string]string{"source": "OTLP", "total_spans": "100", "error_count": "2"}},
+	}
+}
+
+func TestBuildTraceTrees(t *testing.T) {
+	spans := append(testTraceSpans(),
+		// An older trace whose parent span was not captured.
+		&agentv1.EbpfTraceSpan{TraceId: "older", SpanId: "a", ParentSpanId: "missing", ServiceName: "api", StartTime: testStartMs - 1000, DurationUs: 1000},
+		// Spans that are each other's parent.
+		&agentv1.EbpfTraceSpan{TraceId: "cycle", SpanId: "x", ParentSpanId: "y", StartTime: testStartMs - 2000, DurationUs: 1000},
+		&agentv1.EbpfTraceSpan{TraceId: "cycle", SpanId: "y", ParentSpanId: "x", StartTime: testStartMs - 2000, DurationUs: 1000},
+	)
+
+	trees := buildTraceTrees(spans)
+	require.Len(t, trees, 3)
+	assert.Equal(t, []string{testTraceID, "older", "cycle"}, []string{trees[0].traceID, trees[1].traceID, trees[2].traceID}, "most recent trace first")
+
+	tree := trees[0]
+	assert.Equal(t, 4, tree.spans, "the OTLP summary is not part of the tree")
+	assert.Equal(t, int64(100_000), tree.end-tree.start)
+	require.Len(t, tree.roots, 1)
+	root := tree.roots[0]
+	assert.Equal(t, "GET /orders", root.span.SpanName)
+	require.Len(t, root.children, 2)
+	assert.Equal(t, "GET /internal/orders", root.children[0].span.SpanName, "children in start order")
+	assert.Equal(t, "POST /verify", root.children[1].span.SpanName)
+	require.Len(t, root.children[0].children, 1)
+
+	assert.Len(t, trees[1].roots, 1, "spans with a missing parent are roots")
+
+	var count func(nodes []*spanNode) int
+	count = func(nodes []*spanNode) int {
+		n := len(nodes)
+		for _, node := range nodes {
+			n += count(node.children)
+		}
+		return n
+	}
+	assert.Equal(t, 2, count(trees[2].roots), "every span of a cycle is shown once")
+}
+
+func TestPrintTraceTrees(t *testing.T) {
+	var buf bytes.Buffer
+	printTraceTrees(&buf, testTraceSpans())
+	out := buf.String()
+
+	assert.Contains(t, out, "Trace "+testTraceID+"  4 spans  100.00ms")
+	lines := strings.Split(out, "\n")
+	var tree []string
+	for _, line := range lines {
+		if strings.Contains(line, "█") {
+			tree = append(tree, line)
+		}
+	}
+	require.Len(t, tree, 4)
+	assert.True(t, strings.HasPrefix(tree[0], "  └─ GET /orders (gateway)"), tree[0])
+	assert.True(t, strings.HasPrefix(tree[1], "     ├─ GET /internal/orders (api)"), tree[1])
+	assert.True(t, strings.HasPrefix(tree[2], "     │  └─ SELECT orders (api)"), tree[2])
+	assert.True(t, strings.HasPrefix(tree[3], "     └─ POST /verify (auth) ✗ 503"), tree[3])
+	assert.Contains(t, tree[1], "50.00ms")
+	assert.Contains(t, tree[1], "+10.00ms")
+
+	assert.Contains(t, out, "OTLP summaries:")
+	assert.Contains(t, out, "api [OTLP]: OTLP Summary (server) (p95 120.00ms, 100 spans, 2 errors)")
+}
+
+func TestTimingBar(t *testing.T) {
+	assert.Equal(t, strings.Repeat("█", traceBarWidth), timingBar(0, 100, 100))
+	assert.Equal(t, strings.Repeat(" ", 15)+strings.Repeat("█", 15), timingBar(50, 50, 100))
+	assert.Equal(t, strings.Repeat(" ", traceBarWidth-1)+"█", timingBar(100, 0, 100), "spans are at least one column wide")
+	assert.Equal(t, strings.Repeat("█", traceBarWidth), timingBar(0, 0, 0))
+}
+
+func TestTracesToOTLP(t *testing.T) {
+	spans := testTraceSpans()
+	spans[1].Attributes = map[string]string{constants.FederationColonyAttribute: "child"}
+	spans = append(spans, &agentv1.EbpfTraceSpan{TraceId: "not-hex", SpanId: "00f067aa0ba902bb"})
+
+	traces, skipped := tracesToOTLP(spans)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, 4, traces.SpanCount(), "the OTLP summary is not exported")
+
+	byName := make(map[string]ptrace.Span)
+	resources := make(map[string]map[string]any)
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		rs := traces.ResourceSpans().At(i)
+		spans := rs.ScopeSpans().At(0).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			byName[spans.At(j).Name()] = spans.At(j)
+			resources[spans.At(j).Name()] = rs.Resource().Attributes().AsRaw()
+		}
+	}
+
+	root := byName["GET /orders"]
+	assert.Equal(t, testTraceID, root.TraceID().String())
+	assert.True(t, root.ParentSpanID().IsEmpty())
+	assert.Equal(t, ptrace.SpanKindServer, root.Kind())
+	assert.Equal(t, int64(100_000_000), int64(root.EndTimestamp()-root.StartTimestamp()))
+	assert.Equal(t, map[string]any{"service.name": "gateway"}, resources["GET /orders"])
+
+	auth := byName["POST /verify"]
+	assert.Equal(t, "00f067aa0ba902b7", auth.ParentSpanID().String())
+	assert.Equal(t, ptrace.StatusCodeError, auth.Status().Code())
+	status, _ := auth.Attributes().Get("http.response.status_code")
+	assert.Equal(t, int64(503), status.Int())
+	assert.Equal(t, map[string]any{"service.name": "auth", constants.FederationColonyAttribute: "child"}, resources["POST /verify"])
+
+	// IDs are hex encoded, as the OTLP/JSON encoding requires.
+	var buf bytes.Buffer
+	require.NoError(t, printTracesOTLP(&buf, testTraceSpans()))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Contains(t, buf.String(), `"traceId":"`+testTraceID+`"`)
+	assert.Contains(t, decoded, "resourceSpans")
+}
@@ -2,21 +2,28 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/coral-mesh/coral/internal/cli/agent"
+	"github.com/coral-mesh/coral/internal/cli/alert"
 	"github.com/coral-mesh/coral/internal/cli/ask"
+	"github.com/coral-mesh/coral/internal/cli/check"
 	"github.com/coral-mesh/coral/internal/cli/colony"
 	"github.com/coral-mesh/coral/internal/cli/config"
 	"github.com/coral-mesh/coral/internal/cli/debug"
 	"github.com/coral-mesh/coral/internal/cli/duckdb"
+	"github.com/coral-mesh/coral/internal/cli/generate"
 	initcmd "github.com/coral-mesh/coral/internal/cli/init"
+	"github.com/coral-mesh/coral/internal/cli/k8s"
 	"github.com/coral-mesh/coral/internal/cli/mesh"
 	"github.com/coral-mesh/coral/internal/cli/profile"
 	"github.com/coral-mesh/coral/internal/cli/proxy"
 	"github.com/coral-mesh/coral/internal/cli/query"
 	"github.com/coral-mesh/coral/internal/cli/run"
 	"github.com/coral-mesh/coral/internal/cli/script"
+	"github.com/coral-mesh/coral/internal/cli/tail"
 	"github.com/coral-mesh/coral/internal/cli/terminal"
 	"github.com/coral-mesh/coral/internal/cli/tunhelper"
 	"github.com/coral-mesh/coral/pkg/version"
@@ -25,6 +32,16 @@ import (
 var (
 	// globalVerbose is the global verbose flag accessible to all commands.
 	globalVerbose bool
+
+	// globalOutput is the jq or JSONPath filter applied to the JSON output
+	// of any command, and capture the stdout of the running command while it
+	// is set.
+	globalOutput string
+	capture      *outputCapture
+
+	// globalErrorFormat is the format of the error printed when a command
+	// fails: text or json.
+	globalErrorFormat string
 )
 
 var rootCmd = &cobra.Command{
@@ -46,11 +63,27 @@ Key capabilities:
 - Your LLM: Use OpenAI/Anthropic/Ollama - you control the AI`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if globalErrorFormat != "text" && globalErrorFormat != "json" {
+			return fmt.Errorf("invalid --error-format %q: must be text or json", globalErrorFormat)
+		}
+		if globalOutput == "" {
+			return nil
+		}
+		c, err := startOutputFilter(cmd, globalOutput)
+		if err != nil {
+			return err
+		}
+		capture = c
+		return nil
+	},
 }
 
 func init() {
 	// Add global persistent flags.
 	rootCmd.PersistentFlags().BoolVarP(&globalVerbose, "verbose", "v", false, "Verbose output (show additional details)")
+	rootCmd.PersistentFlags().StringVar(&globalOutput, "output", "", "Filter JSON output with jq=<expr> or jsonpath=<expr> (selects --format json)")
+	rootCmd.PersistentFlags().StringVar(&globalErrorFormat, "error-format", "text", "Format of errors on stderr (text, json)")
 
 	// Add subcommands
 	rootCmd.AddCommand(initcmd.NewInitCmd())
@@ -64,13 +97,19 @@ func init() {
 	rootCmd.AddCommand(proxy.Command())
 	rootCmd.AddCommand(agent.NewShellCmd())
 	rootCmd.AddCommand(agent.NewExecCmd()) // RFD 056 - Container exec.
+	rootCmd.AddCommand(agent.NewCpCmd())   // File copy to and from agents.
 	rootCmd.AddCommand(duckdb.NewDuckDBCmd())
 	rootCmd.AddCommand(debug.NewDebugCmd())
 	rootCmd.AddCommand(profile.NewProfileCmd()) // On-demand profiling (CPU, memory).
 	rootCmd.AddCommand(query.NewQueryCmd())
+	rootCmd.AddCommand(alert.NewAlertCmd())       // Alert rules and notifications.
+	rootCmd.AddCommand(check.NewCheckCmd())       // Threshold checks for deployment pipelines.
+	rootCmd.AddCommand(tail.NewTailCmd())         // Service logs collected by agents.
 	rootCmd.AddCommand(run.NewRunCmd())           // RFD 076 - TypeScript script execution.
 	rootCmd.AddCommand(script.NewScriptCmd())     // RFD 100 - Investigation scripts.
 	rootCmd.AddCommand(terminal.NewTerminalCmd()) // RFD 094 - Rich mission-control TUI.
+	rootCmd.AddCommand(k8s.NewK8sCmd())           // Kubernetes deployment.
+	rootCmd.AddCommand(generate.NewGenerateCmd()) // Deployment file generation.
 	rootCmd.AddCommand(newVersionCmd())
 
 	// Add internal commands (hidden from help)
@@ -92,7 +131,12 @@ func newVersionCmd() *cobra.Command {
 
 // Execute runs the root command.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if capture != nil {
+		err = capture.finish(err)
+		capture = nil
+	}
+	return err
 }
 
 // IsVerbose returns the value of the global verbose flag.
@@ -0,0 +1,173 @@
+// Package tail provides the coral tail command, which prints and follows
+// service logs collected by agents.
+package tail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/cli/helpers"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// reconnectDelay is the pause before reopening the log stream after it ends
+// in follow mode.
+const reconnectDelay = 2 * time.Second
+
+// NewTailCmd creates the tail command.
+func NewTailCmd() *cobra.Command {
+	var (
+		serviceName string
+		agentID     string
+		grep        string
+		lines       int
+		follow      bool
+		format      string
+		colonyID    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Show and follow service logs",
+		Long: `Display the recent log lines of services and optionally stream new ones.
+
+Agents collect the logs of the services listed in logs.services of their
+config: container logs for services running in Docker containers, the journal
+for systemd units, and otherwise the files stdout and stderr are redirected
+to. Lines are kept in a ring buffer on the colony, so only recent lines are
+available.
+
+--grep takes a regular expression (RE2 syntax) matched against each line.
+Use --format json to emit one JSON object per line for automation.
+
+Examples:
+  coral tail --service api
+  coral tail --service api --follow --grep error
+  coral tail --service api -n 200 --grep '(?i)timeout|refused'
+  coral tail --follow --format json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("unsupported format %q (use text or json)", format)
+			}
+			if _, err := regexp.Compile(grep); err != nil {
+				return fmt.Errorf("invalid --grep pattern: %w", err)
+			}
+			if lines < 0 {
+				return fmt.Errorf("--lines must not be negative")
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			client, _, err := helpers.GetColonyClientWithFallback(ctx, colonyID)
+			if err != nil {
+				return err
+			}
+
+			req := &colonyv1.TailLogsRequest{
+				Service: serviceName,
+				AgentId: agentID,
+				Grep:    grep,
+				Lines:   int32(lines), // #nosec G115 -- bounded by the flag value.
+				Follow:  follow,
+			}
+
+			out := cmd.OutOrStdout()
+			for {
+				last, err := streamLogs(ctx, client, req, format, out)
+				if !follow || ctx.Err() != nil {
+					return err
+				}
+
+				// The colony restarted or the connection timed out; resume
+				// after the last line printed.
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Log stream interrupted: %v, reconnecting...\n", err)
+				}
+				if last != nil {
+					req.Since = last
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(reconnectDelay):
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&serviceName, "service", "s", "", "Only show logs of this service")
+	cmd.Flags().StringVar(&agentID, "agent", "", "Only show logs collected by this agent")
+	cmd.Flags().StringVarP(&grep, "grep", "g", "", "Only show lines matching this regular expression")
+	cmd.Flags().IntVarP(&lines, "lines", "n", constants.DefaultTailLines, "Number of recent lines to show first")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Stream new lines as they are collected")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	cmd.Flags().StringVar(&colonyID, "colony", "", "Colony ID (defaults to current colony)")
+
+	return cmd
+}
+
+// streamLogs prints lines from one TailLogs stream until it ends. It returns
+// the timestamp of the last line printed.
+func streamLogs(
+	ctx context.Context,
+	client colonyv1connect.ColonyServiceClient,
+	req *colonyv1.TailLogsRequest,
+	format string,
+	out io.Writer,
+) (*timestamppb.Timestamp, error) {
+	stream, err := client.TailLogs(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to tail logs: %w", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	var last *timestamppb.Timestamp
+	for stream.Receive() {
+		line := stream.Msg()
+		if err := printLine(out, line, format); err != nil {
+			return last, err
+		}
+		last = line.Timestamp
+	}
+
+	if err := stream.Err(); err != nil && !errors.Is(ctx.Err(), context.Canceled) {
+		return last, fmt.Errorf("log stream failed: %w", err)
+	}
+	return last, nil
+}
+
+// printLine writes a log line as text or a JSON line.
+func printLine(out io.Writer, line *colonyv1.LogLine, format string) error {
+	if format == "json" {
+		data, err := protojson.Marshal(line)
+		if err != nil {
+			return fmt.Errorf("failed to encode log line: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+
+	_, err := fmt.Fprintf(out, "%s %s[%s] %s\n",
+		line.Timestamp.AsTime().Local().Format("2006-01-02 15:04:05.000"),
+		line.Service,
+		line.Source,
+		line.Line,
+	)
+	return err
+}
@@ -0,0 +1,103 @@
+package tail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+)
+
+// fakeColony serves fixed log lines and records the last request.
+type fakeColony struct {
+	colonyv1connect.UnimplementedColonyServiceHandler
+
+	lines []*colonyv1.LogLine
+	req   *colonyv1.TailLogsRequest
+}
+
+func (c *fakeColony) TailLogs(
+	_ context.Context,
+	req *connect.Request[colonyv1.TailLogsRequest],
+	stream *connect.ServerStream[colonyv1.LogLine],
+) error {
+	c.req = req.Msg
+	for _, line := range c.lines {
+		if err := stream.Send(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStreamLogs(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	colony := &fakeColony{lines: []*colonyv1.LogLine{
+		{Timestamp: timestamppb.New(at), Service: "api", Source: "stderr", Line: "ERROR: database timeout"},
+		{Timestamp: timestamppb.New(at.Add(time.Second)), Service: "api", Source: "stdout", Line: "error count=2"},
+	}}
+
+	_, h := colonyv1connect.NewColonyServiceHandler(colony)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	client := colonyv1connect.NewColonyServiceClient(srv.Client(), srv.URL)
+
+	req := &colonyv1.TailLogsRequest{Service: "api", Grep: "(?i)error", Lines: 10}
+
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		last, err := streamLogs(context.Background(), client, req, "text", &out)
+		require.NoError(t, err)
+		assert.Equal(t, at.Add(time.Second), last.AsTime(), "the last timestamp resumes a follow")
+		assert.Equal(t, "(?i)error", colony.req.Grep)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.True(t, strings.HasSuffix(lines[0], " api[stderr] ERROR: database timeout"), lines[0])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := streamLogs(context.Background(), client, req, "json", &out)
+		require.NoError(t, err)
+
+		var line map[string]any
+		require.NoError(t, json.Unmarshal([]byte(strings.SplitN(out.String(), "\n", 2)[0]), &line))
+		assert.Equal(t, "api", line["service"])
+		assert.Equal(t, "ERROR: database timeout", line["line"])
+	})
+}
+
+func TestTailCmd_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "format", args: []string{"--format", "yaml"}, want: `unsupported format "yaml"`},
+		{name: "grep", args: []string{"--grep", "error("}, want: "invalid --grep pattern"},
+		{name: "lines", args: []string{"-n", "-1"}, want: "--lines must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewTailCmd()
+			cmd.SetArgs(tt.args)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
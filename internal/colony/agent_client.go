@@ -6,24 +6,59 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"sync"
 
 	"connectrpc.com/connect"
 
 	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	"github.com/coral-mesh/coral/internal/bandwidth"
 	"github.com/coral-mesh/coral/internal/colony/registry"
 	"github.com/coral-mesh/coral/internal/constants"
 )
 
+var (
+	agentClientMu      sync.RWMutex
+	agentHTTPClient    connect.HTTPClient = http.DefaultClient
+	agentClientOptions                    = bandwidth.ClientOptions()
+)
+
+// ConfigureAgentClients limits the transfers of the clients GetAgentClient and
+// GetDebugClient create to limiter's rates, and makes them accept zstd
+// compressed responses unless compress is false. It should be called before
+// pollers start.
+func ConfigureAgentClients(limiter *bandwidth.Limiter, compress bool) {
+	agentClientMu.Lock()
+	defer agentClientMu.Unlock()
+
+	agentHTTPClient = http.DefaultClient
+	if limiter != nil {
+		agentHTTPClient = &http.Client{Transport: limiter.Transport(http.DefaultTransport)}
+	}
+	agentClientOptions = nil
+	if compress {
+		agentClientOptions = bandwidth.ClientOptions()
+	}
+}
+
+// agentClientConfig returns the HTTP client and options of agent clients.
+func agentClientConfig() (connect.HTTPClient, []connect.ClientOption) {
+	agentClientMu.RLock()
+	defer agentClientMu.RUnlock()
+	return agentHTTPClient, agentClientOptions
+}
+
 // GetAgentClient creates a gRPC client for communicating with an agent over the mesh network.
 // The agent must be registered in the registry to get its mesh IP address.
 func GetAgentClient(agent *registry.Entry) agentv1connect.AgentServiceClient {
-	agentAddr := net.JoinHostPort(agent.MeshIPv4, strconv.Itoa(constants.DefaultAgentPort))
+	agentAddr := net.JoinHostPort(agent.MeshIP(), strconv.Itoa(constants.DefaultAgentPort))
 	baseURL := fmt.Sprintf("http://%s", agentAddr)
 
 	// Create Connect client for agent service.
+	httpClient, opts := agentClientConfig()
 	client := agentv1connect.NewAgentServiceClient(
-		http.DefaultClient,
+		httpClient,
 		baseURL,
+		opts...,
 	)
 
 	return client
@@ -32,9 +67,11 @@ func GetAgentClient(agent *registry.Entry) agentv1connect.AgentServiceClient {
 // GetDebugClient creates a gRPC client for communicating with an agent's debug service over the mesh network.
 // This is a factory function that can be used by pollers and other colony components.
 func GetDebugClient(httpClient connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentDebugServiceClient {
+	defaultClient, defaultOpts := agentClientConfig()
 	if httpClient == nil {
-		httpClient = http.DefaultClient
+		httpClient = defaultClient
 	}
+	opts = append(append([]connect.ClientOption{}, defaultOpts...), opts...)
 
 	// Create Connect client for debug service.
 	return agentv1connect.NewAgentDebugServiceClient(httpClient, url, opts...)
@@ -0,0 +1,250 @@
+// Package alerting evaluates alert rules against the colony database and
+// notifies configured sinks when alerts fire and resolve.
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/poller"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Metrics alert rules can be defined on.
+const (
+	// MetricLatencyP95 is the p95 request latency in milliseconds.
+	MetricLatencyP95 = "latency_p95"
+
+	// MetricErrorRate is the percentage of failed requests.
+	MetricErrorRate = "error_rate"
+
+	// MetricHeapGrowth is the percentage growth of allocated bytes over the
+	// previous window.
+	MetricHeapGrowth = "heap_growth"
+)
+
+// IsValidMetric reports whether alert rules can be defined on the metric.
+func IsValidMetric(metric string) bool {
+	switch metric {
+	case MetricLatencyP95, MetricErrorRate, MetricHeapGrowth:
+		return true
+	}
+	return false
+}
+
+// Notification describes an alert firing or resolving for a service.
+type Notification struct {
+	RuleID      string
+	RuleName    string
+	ServiceName string
+	Metric      string
+	Value       float64
+	Threshold   float64
+	Window      time.Duration
+	Resolved    bool
+	Time        time.Time
+}
+
+// Summary returns a one-line, human-readable description of the notification.
+func (n Notification) Summary() string {
+	state := "FIRING"
+	if n.Resolved {
+		state = "RESOLVED"
+	}
+	return fmt.Sprintf("[%s] %s: %s of %s is %s (threshold %s over %s)",
+		state, n.RuleName, n.Metric, n.ServiceName,
+		formatValue(n.Metric, n.Value), formatValue(n.Metric, n.Threshold), n.Window)
+}
+
+func formatValue(metric string, v float64) string {
+	if metric == MetricLatencyP95 {
+		return fmt.Sprintf("%.0fms", v)
+	}
+	return fmt.Sprintf("%.1f%%", v)
+}
+
+// Evaluator periodically evaluates enabled alert rules. An alert fires for a
+// service when its metric exceeds the rule threshold, and resolves when it no
+// longer does or the service stops reporting data. Sinks are notified on both
+// transitions only.
+type Evaluator struct {
+	*poller.BasePoller
+	db     *database.Database
+	sinks  map[string]Sink
+	logger zerolog.Logger
+}
+
+// NewEvaluator creates an alert rule evaluator that runs every interval.
+func NewEvaluator(
+	ctx context.Context,
+	db *database.Database,
+	sinks map[string]Sink,
+	interval time.Duration,
+	logger zerolog.Logger,
+) *Evaluator {
+	if interval <= 0 {
+		interval = constants.DefaultAlertEvaluationInterval
+	}
+
+	componentLogger := logger.With().Str("component", "alert_evaluator").Logger()
+
+	base := poller.NewBasePoller(ctx, poller.Config{
+		Name:         "alert_evaluator",
+		PollInterval: interval,
+		Logger:       componentLogger,
+	})
+
+	return &Evaluator{
+		BasePoller: base,
+		db:         db,
+		sinks:      sinks,
+		logger:     componentLogger,
+	}
+}
+
+// Start begins evaluating alert rules.
+func (e *Evaluator) Start() error {
+	return e.BasePoller.Start(e)
+}
+
+// PollOnce evaluates every enabled alert rule.
+// Implements the poller.Poller interface.
+func (e *Evaluator) PollOnce(ctx context.Context) error {
+	rules, err := e.db.ListAlertRules(ctx, true)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	firing, err := e.db.ListAlertFiring(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, rule := range rules {
+		if err := e.evaluate(ctx, rule, firing[rule.ID], now); err != nil {
+			e.logger.Error().Err(err).
+				Str("rule_id", rule.ID).
+				Str("rule", rule.Name).
+				Msg("Failed to evaluate alert rule")
+		}
+	}
+	return nil
+}
+
+// RunCleanup is a no-op: firing state is removed when alerts resolve.
+// Implements the poller.Poller interface.
+func (e *Evaluator) RunCleanup(ctx context.Context) error {
+	return nil
+}
+
+func (e *Evaluator) evaluate(ctx context.Context, rule *database.AlertRule, firing []*database.AlertFiring, now time.Time) error {
+	values, err := e.measure(ctx, rule, now)
+	if err != nil {
+		return err
+	}
+
+	wasFiring := make(map[string]bool, len(firing))
+	for _, f := range firing {
+		wasFiring[f.ServiceName] = true
+	}
+
+	for service, value := range values {
+		if value <= rule.Threshold {
+			continue
+		}
+
+		if err := e.db.UpsertAlertFiring(ctx, &database.AlertFiring{
+			RuleID:      rule.ID,
+			ServiceName: service,
+			Value:       value,
+			Since:       now,
+		}); err != nil {
+			return err
+		}
+
+		if !wasFiring[service] {
+			e.notify(ctx, rule, service, value, false, now)
+		}
+		delete(wasFiring, service)
+	}
+
+	// Anything still marked firing is back under the threshold or has no
+	// data in the window.
+	for _, f := range firing {
+		if !wasFiring[f.ServiceName] {
+			continue
+		}
+		if err := e.db.DeleteAlertFiring(ctx, rule.ID, f.ServiceName); err != nil {
+			return err
+		}
+		value, ok := values[f.ServiceName]
+		if !ok {
+			value = f.Value
+		}
+		e.notify(ctx, rule, f.ServiceName, value, true, now)
+	}
+
+	return e.db.MarkAlertRuleEvaluated(ctx, rule.ID, now)
+}
+
+// measure computes the rule's metric for each service with data in the
+// rule's window.
+func (e *Evaluator) measure(ctx context.Context, rule *database.AlertRule, now time.Time) (map[string]float64, error) {
+	since := now.Add(-rule.Window())
+
+	switch rule.Metric {
+	case MetricLatencyP95:
+		return e.db.QueryServiceLatencyP95(ctx, rule.ServiceName, since)
+	case MetricErrorRate:
+		return e.db.QueryServiceErrorRates(ctx, rule.ServiceName, since)
+	case MetricHeapGrowth:
+		return e.db.QueryServiceHeapGrowth(ctx, rule.ServiceName, now, rule.Window())
+	default:
+		return nil, fmt.Errorf("unsupported metric %q", rule.Metric)
+	}
+}
+
+func (e *Evaluator) notify(ctx context.Context, rule *database.AlertRule, service string, value float64, resolved bool, now time.Time) {
+	n := Notification{
+		RuleID:      rule.ID,
+		RuleName:    rule.Name,
+		ServiceName: service,
+		Metric:      rule.Metric,
+		Value:       value,
+		Threshold:   rule.Threshold,
+		Window:      rule.Window(),
+		Resolved:    resolved,
+		Time:        now,
+	}
+
+	event := e.logger.Warn()
+	if resolved {
+		event = e.logger.Info()
+	}
+	event.Str("rule_id", rule.ID).Msg(n.Summary())
+
+	for _, name := range rule.SinkNames() {
+		sink, ok := e.sinks[name]
+		if !ok {
+			e.logger.Warn().
+				Str("rule_id", rule.ID).
+				Str("sink", name).
+				Msg("Alert sink is not configured")
+			continue
+		}
+		if err := sink.Notify(ctx, n); err != nil {
+			e.logger.Error().Err(err).
+				Str("rule_id", rule.ID).
+				Str("sink", name).
+				Msg("Failed to send alert notification")
+		}
+	}
+}
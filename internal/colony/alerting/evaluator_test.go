@@ -0,0 +1,122 @@
+package alerting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+type recordingSink struct {
+	notifications []Notification
+}
+
+func (s *recordingSink) Notify(_ context.Context, n Notification) error {
+	s.notifications = append(s.notifications, n)
+	return nil
+}
+
+func insertLatency(t *testing.T, db *database.Database, service string, latencyMs float64) {
+	t.Helper()
+	_, err := db.DB().Exec(`
+		INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+		VALUES (?, 'agent-1', ?, 'GET', '/', 200, ?, 10)
+	`, time.Now().Add(-time.Second), service, latencyMs)
+	require.NoError(t, err)
+}
+
+func TestEvaluator_FiresAndResolves(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	sink := &recordingSink{}
+	evaluator := NewEvaluator(ctx, db, map[string]Sink{"ops": sink}, 0, zerolog.Nop())
+
+	require.NoError(t, db.InsertAlertRule(ctx, &database.AlertRule{
+		ID: "r-1", Name: "slow api", ServiceName: "api", Metric: MetricLatencyP95,
+		Threshold: 500, WindowSeconds: 300, Sinks: "ops,missing", Enabled: true, CreatedAt: time.Now(),
+	}))
+
+	// No data: nothing fires.
+	require.NoError(t, evaluator.PollOnce(ctx))
+	assert.Empty(t, sink.notifications)
+
+	insertLatency(t, db, "api", 1000)
+	insertLatency(t, db, "web", 1000)
+	require.NoError(t, evaluator.PollOnce(ctx))
+	require.Len(t, sink.notifications, 1, "only the rule's service is evaluated")
+	n := sink.notifications[0]
+	assert.False(t, n.Resolved)
+	assert.Equal(t, "api", n.ServiceName)
+	assert.Equal(t, 1000.0, n.Value)
+	assert.Equal(t, "[FIRING] slow api: latency_p95 of api is 1000ms (threshold 500ms over 5m0s)", n.Summary())
+
+	// Still firing: no repeat notification.
+	require.NoError(t, evaluator.PollOnce(ctx))
+	assert.Len(t, sink.notifications, 1)
+
+	firing, err := db.ListAlertFiring(ctx)
+	require.NoError(t, err)
+	require.Len(t, firing["r-1"], 1)
+
+	// Data leaves the window: the alert resolves.
+	_, err = db.DB().Exec(`DELETE FROM beyla_http_metrics`)
+	require.NoError(t, err)
+	require.NoError(t, evaluator.PollOnce(ctx))
+	require.Len(t, sink.notifications, 2)
+	assert.True(t, sink.notifications[1].Resolved)
+
+	firing, err = db.ListAlertFiring(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, firing)
+
+	rules, err := db.ListAlertRules(ctx, false)
+	require.NoError(t, err)
+	assert.NotNil(t, rules[0].LastEvaluatedAt)
+}
+
+func TestEvaluator_AllServices(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	sink := &recordingSink{}
+	evaluator := NewEvaluator(ctx, db, map[string]Sink{"ops": sink}, 0, zerolog.Nop())
+
+	require.NoError(t, db.InsertAlertRule(ctx, &database.AlertRule{
+		ID: "r-1", Name: "slow", Metric: MetricLatencyP95,
+		Threshold: 500, WindowSeconds: 300, Sinks: "ops", Enabled: true, CreatedAt: time.Now(),
+	}))
+	require.NoError(t, db.InsertAlertRule(ctx, &database.AlertRule{
+		ID: "r-2", Name: "disabled", Metric: MetricLatencyP95,
+		Threshold: 1, WindowSeconds: 300, Sinks: "ops", Enabled: false, CreatedAt: time.Now(),
+	}))
+
+	insertLatency(t, db, "api", 1000)
+	insertLatency(t, db, "web", 1000)
+	insertLatency(t, db, "db", 100)
+	require.NoError(t, evaluator.PollOnce(ctx))
+
+	services := make([]string, 0, len(sink.notifications))
+	for _, n := range sink.notifications {
+		assert.Equal(t, "r-1", n.RuleID)
+		services = append(services, n.ServiceName)
+	}
+	assert.ElementsMatch(t, []string{"api", "web"}, services)
+}
+
+func TestIsValidMetric(t *testing.T) {
+	for _, metric := range []string{MetricLatencyP95, MetricErrorRate, MetricHeapGrowth} {
+		assert.True(t, IsValidMetric(metric), metric)
+	}
+	assert.False(t, IsValidMetric("cpu"))
+}
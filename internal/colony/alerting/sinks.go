@@ -0,0 +1,149 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Sink delivers alert notifications to an external system.
+type Sink interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// NewSinks creates the sinks defined in the colony alerting config, keyed by
+// name.
+func NewSinks(cfgs []config.AlertSinkConfig) (map[string]Sink, error) {
+	client := &http.Client{Timeout: constants.DefaultAlertNotifyTimeout}
+
+	sinks := make(map[string]Sink, len(cfgs))
+	for _, cfg := range cfgs {
+		sink, err := newSink(cfg, client)
+		if err != nil {
+			return nil, fmt.Errorf("alert sink %q: %w", cfg.Name, err)
+		}
+		sinks[cfg.Name] = sink
+	}
+	return sinks, nil
+}
+
+func newSink(cfg config.AlertSinkConfig, client *http.Client) (Sink, error) {
+	switch cfg.Type {
+	case config.AlertSinkWebhook:
+		return &webhookSink{client: client, url: cfg.URL, headers: cfg.Headers}, nil
+	case config.AlertSinkSlack:
+		return &slackSink{client: client, url: cfg.URL}, nil
+	case config.AlertSinkPagerDuty:
+		url := cfg.URL
+		if url == "" {
+			url = constants.DefaultPagerDutyEventsURL
+		}
+		return &pagerDutySink{client: client, url: url, routingKey: cfg.RoutingKey}, nil
+	default:
+		return nil, fmt.Errorf("unsupported sink type %q", cfg.Type)
+	}
+}
+
+// webhookSink posts the notification as a JSON document.
+type webhookSink struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+func (s *webhookSink) Notify(ctx context.Context, n Notification) error {
+	status := "firing"
+	if n.Resolved {
+		status = "resolved"
+	}
+	return postJSON(ctx, s.client, s.url, s.headers, map[string]interface{}{
+		"status":       status,
+		"rule_id":      n.RuleID,
+		"rule_name":    n.RuleName,
+		"service_name": n.ServiceName,
+		"metric":       n.Metric,
+		"value":        n.Value,
+		"threshold":    n.Threshold,
+		"window":       n.Window.String(),
+		"summary":      n.Summary(),
+		"time":         n.Time.UTC().Format(time.RFC3339),
+	})
+}
+
+// slackSink posts the notification summary to a Slack incoming webhook.
+type slackSink struct {
+	client *http.Client
+	url    string
+}
+
+func (s *slackSink) Notify(ctx context.Context, n Notification) error {
+	return postJSON(ctx, s.client, s.url, nil, map[string]string{"text": n.Summary()})
+}
+
+// pagerDutySink triggers and resolves PagerDuty incidents through the Events
+// API v2. Each rule and service pair is a separate incident.
+type pagerDutySink struct {
+	client     *http.Client
+	url        string
+	routingKey string
+}
+
+func (s *pagerDutySink) Notify(ctx context.Context, n Notification) error {
+	event := map[string]interface{}{
+		"routing_key":  s.routingKey,
+		"event_action": "trigger",
+		"dedup_key":    n.RuleID + ":" + n.ServiceName,
+		"payload": map[string]interface{}{
+			"summary":   n.Summary(),
+			"source":    n.ServiceName,
+			"severity":  "error",
+			"timestamp": n.Time.UTC().Format(time.RFC3339),
+			"custom_details": map[string]interface{}{
+				"rule":      n.RuleName,
+				"metric":    n.Metric,
+				"value":     n.Value,
+				"threshold": n.Threshold,
+				"window":    n.Window.String(),
+			},
+		},
+	}
+	if n.Resolved {
+		event["event_action"] = "resolve"
+	}
+	return postJSON(ctx, s.client, s.url, nil, event)
+}
+
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("notification rejected: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
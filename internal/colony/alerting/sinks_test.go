@@ -0,0 +1,64 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/config"
+)
+
+func TestSinks(t *testing.T) {
+	var (
+		gotBody   map[string]interface{}
+		gotHeader string
+		status    = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("Authorization")
+		gotBody = nil
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sinks, err := NewSinks([]config.AlertSinkConfig{
+		{Name: "hook", Type: config.AlertSinkWebhook, URL: server.URL, Headers: map[string]string{"Authorization": "Bearer s3cret"}},
+		{Name: "slack", Type: config.AlertSinkSlack, URL: server.URL},
+		{Name: "pd", Type: config.AlertSinkPagerDuty, URL: server.URL, RoutingKey: "key-1"},
+	})
+	require.NoError(t, err)
+
+	n := Notification{
+		RuleID: "r-1", RuleName: "errors", ServiceName: "api", Metric: MetricErrorRate,
+		Value: 12.5, Threshold: 5, Window: 5 * time.Minute, Time: time.Now(),
+	}
+	ctx := context.Background()
+
+	require.NoError(t, sinks["hook"].Notify(ctx, n))
+	assert.Equal(t, "Bearer s3cret", gotHeader)
+	assert.Equal(t, "firing", gotBody["status"])
+	assert.Equal(t, "api", gotBody["service_name"])
+	assert.Equal(t, "5m0s", gotBody["window"])
+
+	require.NoError(t, sinks["slack"].Notify(ctx, n))
+	assert.Equal(t, "[FIRING] errors: error_rate of api is 12.5% (threshold 5.0% over 5m0s)", gotBody["text"])
+
+	n.Resolved = true
+	require.NoError(t, sinks["pd"].Notify(ctx, n))
+	assert.Equal(t, "key-1", gotBody["routing_key"])
+	assert.Equal(t, "resolve", gotBody["event_action"])
+	assert.Equal(t, "r-1:api", gotBody["dedup_key"])
+
+	status = http.StatusBadRequest
+	assert.ErrorContains(t, sinks["hook"].Notify(ctx, n), "400")
+
+	_, err = NewSinks([]config.AlertSinkConfig{{Name: "x", Type: "email"}})
+	assert.Error(t, err)
+}
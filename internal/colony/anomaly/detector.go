@@ -0,0 +1,271 @@
+// Package anomaly learns the usual latency and error rate of service routes
+// from the colony database and scores recent traffic against them.
+//
+// Traffic follows a daily cycle, so a baseline is learned for each route,
+// metric and hour of the day (UTC) from the hourly values of that hour over
+// the lookback period. Every interval, the last window of each route is
+// scored against the baseline of its hour in standard deviations.
+package anomaly
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/poller"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Floors of the baseline standard deviations, so that small changes of a
+// steady route are not scored as anomalies.
+const (
+	// minStddevLatencyMs is the latency floor in milliseconds, and
+	// minStddevLatencyRatio the floor relative to the mean latency.
+	minStddevLatencyMs    = 1.0
+	minStddevLatencyRatio = 0.1
+
+	// minStddevErrorRate is the error rate floor in percentage points.
+	minStddevErrorRate = 1.0
+)
+
+// Detector periodically learns route baselines and scores the latest window
+// of each route against them.
+type Detector struct {
+	*poller.BasePoller
+	db        *database.Database
+	interval  time.Duration
+	lookback  time.Duration
+	sigma     float64
+	learnedAt time.Time
+	logger    zerolog.Logger
+}
+
+// NewDetector creates an anomaly detector from the colony anomaly detection
+// config.
+func NewDetector(
+	ctx context.Context,
+	db *database.Database,
+	cfg config.AnomalyDetectionConfig,
+	logger zerolog.Logger,
+) *Detector {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = constants.DefaultAnomalyDetectionInterval
+	}
+	lookback := cfg.Lookback
+	if lookback <= 0 {
+		lookback = constants.DefaultAnomalyLookback
+	}
+	sigma := cfg.Sigma
+	if sigma <= 0 {
+		sigma = constants.DefaultAnomalySigma
+	}
+
+	componentLogger := logger.With().Str("component", "anomaly_detector").Logger()
+
+	base := poller.NewBasePoller(ctx, poller.Config{
+		Name:         "anomaly_detector",
+		PollInterval: interval,
+		Logger:       componentLogger,
+	})
+
+	return &Detector{
+		BasePoller: base,
+		db:         db,
+		interval:   interval,
+		lookback:   lookback,
+		sigma:      sigma,
+		logger:     componentLogger,
+	}
+}
+
+// Start begins learning baselines and scoring routes.
+func (d *Detector) Start() error {
+	return d.BasePoller.Start(d)
+}
+
+// PollOnce learns the baselines again if they are due, then scores the
+// latest window.
+// Implements the poller.Poller interface.
+func (d *Detector) PollOnce(ctx context.Context) error {
+	now := time.Now()
+
+	if d.learnedAt.IsZero() {
+		learnedAt, err := d.db.AnomalyBaselinesLearnedAt(ctx)
+		if err != nil {
+			return err
+		}
+		d.learnedAt = learnedAt
+	}
+	if now.Sub(d.learnedAt) >= constants.AnomalyRelearnInterval {
+		if err := d.learn(ctx, now); err != nil {
+			return err
+		}
+	}
+
+	return d.score(ctx, now)
+}
+
+// RunCleanup is a no-op; anomaly scores are cleaned up by the retention
+// manager.
+// Implements the poller.Poller interface.
+func (d *Detector) RunCleanup(ctx context.Context) error {
+	return nil
+}
+
+func (d *Detector) learn(ctx context.Context, now time.Time) error {
+	end := now.UTC().Truncate(time.Hour)
+	start := end.Add(-d.lookback)
+	series, err := d.db.QueryRouteSeries(ctx, start, end, time.Hour)
+	if err != nil {
+		return err
+	}
+
+	baselines := LearnBaselines(series, start, now)
+	if err := d.db.ReplaceAnomalyBaselines(ctx, baselines); err != nil {
+		return err
+	}
+	d.learnedAt = now
+
+	d.logger.Debug().
+		Int("routes", len(series)).
+		Int("baselines", len(baselines)).
+		Msg("Learned anomaly baselines")
+	return nil
+}
+
+func (d *Detector) score(ctx context.Context, now time.Time) error {
+	start := now.Add(-d.interval)
+	current, err := d.db.QueryRouteSeries(ctx, start, now, d.interval)
+	if err != nil {
+		return err
+	}
+	baselines, err := d.db.ListAnomalyBaselines(ctx, start.UTC().Hour())
+	if err != nil {
+		return err
+	}
+
+	scores := ScoreWindow(current, baselines, now, d.sigma)
+	for _, s := range scores {
+		if s.Anomalous {
+			d.logger.Warn().
+				Str("service", s.ServiceName).
+				Str("route", s.Route).
+				Str("metric", s.Metric).
+				Float64("value", s.Value).
+				Float64("mean", s.Mean).
+				Float64("score", s.Score).
+				Msg("Route metric deviates from its baseline")
+		}
+	}
+	return d.db.InsertAnomalyScores(ctx, scores)
+}
+
+// LearnBaselines computes the baselines of each route metric and hour of the
+// day from hourly series starting at start. Hours with fewer than
+// MinAnomalyRequests requests are skipped.
+func LearnBaselines(series map[database.RouteKey][]database.ServiceWindowStats, start, now time.Time) []*database.AnomalyBaseline {
+	var baselines []*database.AnomalyBaseline
+	for key, hours := range series {
+		var latencies, errorRates [24][]float64
+		for i, stats := range hours {
+			if stats.Requests < constants.MinAnomalyRequests {
+				continue
+			}
+			hour := start.Add(time.Duration(i) * time.Hour).UTC().Hour()
+			latencies[hour] = append(latencies[hour], stats.P95Ms)
+			errorRates[hour] = append(errorRates[hour], stats.ErrorRate())
+		}
+
+		for hour := 0; hour < 24; hour++ {
+			for metric, values := range map[string][]float64{
+				database.AnomalyMetricLatencyP95: latencies[hour],
+				database.AnomalyMetricErrorRate:  errorRates[hour],
+			} {
+				if len(values) == 0 {
+					continue
+				}
+				mean, stddev := meanStddev(values)
+				baselines = append(baselines, &database.AnomalyBaseline{
+					ServiceName: key.Service,
+					Route:       key.Route,
+					Metric:      metric,
+					HourOfDay:   hour,
+					Mean:        mean,
+					Stddev:      stddev,
+					Samples:     len(values),
+					LearnedAt:   now,
+				})
+			}
+		}
+	}
+	return baselines
+}
+
+// ScoreWindow scores the single-interval series of each route against the
+// baselines of the window's hour. Routes with fewer than MinAnomalyRequests
+// requests, and metrics whose baseline was learned from fewer than
+// MinBaselineIntervals hours, are not scored.
+func ScoreWindow(
+	current map[database.RouteKey][]database.ServiceWindowStats,
+	baselines map[database.RouteKey]map[string]*database.AnomalyBaseline,
+	end time.Time,
+	sigma float64,
+) []*database.AnomalyScore {
+	var scores []*database.AnomalyScore
+	for key, stats := range current {
+		if len(stats) == 0 || stats[0].Requests < constants.MinAnomalyRequests {
+			continue
+		}
+		values := map[string]float64{
+			database.AnomalyMetricLatencyP95: stats[0].P95Ms,
+			database.AnomalyMetricErrorRate:  stats[0].ErrorRate(),
+		}
+		for metric, value := range values {
+			b := baselines[key][metric]
+			if b == nil || b.Samples < constants.MinBaselineIntervals {
+				continue
+			}
+			score := Score(metric, value, b.Mean, b.Stddev)
+			scores = append(scores, &database.AnomalyScore{
+				Timestamp:   end,
+				ServiceName: key.Service,
+				Route:       key.Route,
+				Metric:      metric,
+				Value:       value,
+				Mean:        b.Mean,
+				Stddev:      b.Stddev,
+				Score:       score,
+				Anomalous:   math.Abs(score) > sigma,
+			})
+		}
+	}
+	return scores
+}
+
+// Score returns the deviation of value from mean in standard deviations,
+// with the standard deviation floored for the metric.
+func Score(metric string, value, mean, stddev float64) float64 {
+	floor := minStddevErrorRate
+	if metric == database.AnomalyMetricLatencyP95 {
+		floor = math.Max(minStddevLatencyMs, minStddevLatencyRatio*mean)
+	}
+	return (value - mean) / math.Max(stddev, floor)
+}
+
+// meanStddev returns the mean and population standard deviation of values.
+func meanStddev(values []float64) (float64, float64) {
+	var mean, variance float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
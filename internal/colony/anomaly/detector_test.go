@@ -0,0 +1,131 @@
+package anomaly
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestLearnBaselines(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	key := database.RouteKey{Service: "api", Route: "GET /orders"}
+
+	// Three days of hourly stats: 9:00 has 100-120ms and 1-3% errors, 10:00
+	// too little traffic to learn from.
+	hours := make([]database.ServiceWindowStats, 3*24)
+	for day := 0; day < 3; day++ {
+		hours[day*24+9] = database.ServiceWindowStats{Requests: 100, Errors: int64(1 + day), P95Ms: float64(100 + 10*day)}
+		hours[day*24+10] = database.ServiceWindowStats{Requests: 5, P95Ms: 1000}
+	}
+
+	baselines := LearnBaselines(map[database.RouteKey][]database.ServiceWindowStats{key: hours}, start, start.Add(72*time.Hour))
+	require.Len(t, baselines, 2)
+
+	byMetric := map[string]*database.AnomalyBaseline{}
+	for _, b := range baselines {
+		assert.Equal(t, 9, b.HourOfDay)
+		assert.Equal(t, 3, b.Samples)
+		byMetric[b.Metric] = b
+	}
+	assert.InDelta(t, 110.0, byMetric[database.AnomalyMetricLatencyP95].Mean, 0.001)
+	assert.InDelta(t, 8.165, byMetric[database.AnomalyMetricLatencyP95].Stddev, 0.001)
+	assert.InDelta(t, 2.0, byMetric[database.AnomalyMetricErrorRate].Mean, 0.001)
+}
+
+func TestScoreWindow(t *testing.T) {
+	orders := database.RouteKey{Service: "api", Route: "GET /orders"}
+	cart := database.RouteKey{Service: "api", Route: "GET /cart"}
+	quiet := database.RouteKey{Service: "api", Route: "GET /health"}
+	baselines := map[database.RouteKey]map[string]*database.AnomalyBaseline{
+		orders: {
+			database.AnomalyMetricLatencyP95: {Mean: 100, Stddev: 5, Samples: 7},
+			database.AnomalyMetricErrorRate:  {Mean: 1, Stddev: 0.2, Samples: 7},
+		},
+		cart: {
+			database.AnomalyMetricLatencyP95: {Mean: 50, Stddev: 0, Samples: 2},
+		},
+		quiet: {
+			database.AnomalyMetricErrorRate: {Mean: 0, Stddev: 0, Samples: 7},
+		},
+	}
+	current := map[database.RouteKey][]database.ServiceWindowStats{
+		orders: {{Requests: 200, Errors: 2, P95Ms: 250}},
+		cart:   {{Requests: 200, P95Ms: 5000}},
+		quiet:  {{Requests: 3, Errors: 3}},
+	}
+
+	end := time.Now()
+	scores := ScoreWindow(current, baselines, end, 3)
+	require.Len(t, scores, 2, "only routes with enough traffic and history are scored")
+
+	for _, s := range scores {
+		assert.Equal(t, end, s.Timestamp)
+		switch s.Metric {
+		case database.AnomalyMetricLatencyP95:
+			// The stddev is floored at 10% of the mean.
+			assert.InDelta(t, 15.0, s.Score, 0.001)
+			assert.True(t, s.Anomalous)
+		case database.AnomalyMetricErrorRate:
+			// The stddev is floored at 1 percentage point.
+			assert.InDelta(t, 0.0, s.Score, 0.001)
+			assert.False(t, s.Anomalous)
+		}
+	}
+}
+
+func TestDetector_PollOnce(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+	insert := func(at time.Time, status, count int) {
+		t.Helper()
+		_, err := db.DB().ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', 'api', 'POST', '/orders', ?, 50, ?)
+		`, at, status, count)
+		require.NoError(t, err)
+	}
+
+	// Four days of steady traffic around this time of day, then an error
+	// burst in the scored window.
+	for day := 1; day <= 4; day++ {
+		for minute := 1; minute <= 6; minute++ {
+			at := now.Add(-time.Duration(day)*24*time.Hour - time.Duration(minute)*time.Minute)
+			insert(at, 200, 100)
+			insert(at, 500, day%2)
+		}
+	}
+	insert(now.Add(-2*time.Minute), 200, 70)
+	insert(now.Add(-2*time.Minute), 500, 30)
+
+	detector := NewDetector(ctx, db, config.AnomalyDetectionConfig{}, zerolog.Nop())
+	require.NoError(t, detector.PollOnce(ctx))
+
+	learnedAt, err := db.AnomalyBaselinesLearnedAt(ctx)
+	require.NoError(t, err)
+	assert.False(t, learnedAt.IsZero())
+
+	scores, err := db.QueryAnomalyScores(ctx, database.AnomalyScoreFilter{Since: now.Add(-time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+	for _, s := range scores {
+		assert.Equal(t, "POST /orders", s.Route)
+		if s.Metric == database.AnomalyMetricErrorRate {
+			assert.InDelta(t, 30.0, s.Value, 0.001)
+			assert.True(t, s.Anomalous)
+		} else {
+			assert.False(t, s.Anomalous)
+		}
+	}
+}
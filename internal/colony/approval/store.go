@@ -0,0 +1,164 @@
+// Package approval holds MCP tool calls that require a human decision
+// before they run (mcp.security.require_approval). Calls are kept in memory:
+// a pending call does not survive a colony restart, and its client sees it
+// expire.
+package approval
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Status of an approval request.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusDenied   Status = "denied"
+	StatusExpired  Status = "expired"
+)
+
+var (
+	// ErrNotFound is returned for an unknown or pruned request ID.
+	ErrNotFound = errors.New("approval request not found")
+
+	// ErrDecided is returned when deciding a request that is no longer
+	// pending.
+	ErrDecided = errors.New("approval request is no longer pending")
+)
+
+// Request is a tool call awaiting, or having received, a decision.
+type Request struct {
+	ID        string
+	Tool      string
+	Command   string
+	Requester string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	Status    Status
+	DecidedBy string
+	DecidedAt time.Time
+	Reason    string
+}
+
+// Store holds approval requests. Decided and expired requests are kept for
+// constants.DefaultMCPApprovalRetention so that clients and approvers can
+// see the outcome.
+type Store struct {
+	mu       sync.Mutex
+	requests map[string]*Request
+	timeout  time.Duration
+	now      func() time.Time
+}
+
+// NewStore creates a store whose requests expire after timeout, or after
+// constants.DefaultMCPApprovalTimeout if timeout is not positive.
+func NewStore(timeout time.Duration) *Store {
+	if timeout <= 0 {
+		timeout = constants.DefaultMCPApprovalTimeout
+	}
+	return &Store{
+		requests: make(map[string]*Request),
+		timeout:  timeout,
+		now:      time.Now,
+	}
+}
+
+// Create adds a pending request for a call to tool running command.
+func (s *Store) Create(tool, command, requester string) Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.pruneLocked(now)
+
+	req := &Request{
+		ID:        uuid.New().String(),
+		Tool:      tool,
+		Command:   command,
+		Requester: requester,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.timeout),
+		Status:    StatusPending,
+	}
+	s.requests[req.ID] = req
+	return *req
+}
+
+// Get returns a request by ID.
+func (s *Store) Get(id string) (Request, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked(s.now())
+	req, ok := s.requests[id]
+	if !ok {
+		return Request{}, ErrNotFound
+	}
+	return *req, nil
+}
+
+// List returns the requests with the given status, or all retained requests
+// if status is empty, oldest first.
+func (s *Store) List(status Status) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked(s.now())
+	var result []Request
+	for _, req := range s.requests {
+		if status == "" || req.Status == status {
+			result = append(result, *req)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// Decide approves or denies a pending request.
+func (s *Store) Decide(id string, approve bool, decidedBy, reason string) (Request, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.pruneLocked(now)
+	req, ok := s.requests[id]
+	if !ok {
+		return Request{}, ErrNotFound
+	}
+	if req.Status != StatusPending {
+		return *req, ErrDecided
+	}
+
+	req.Status = StatusDenied
+	if approve {
+		req.Status = StatusApproved
+	}
+	req.DecidedBy = decidedBy
+	req.DecidedAt = now
+	req.Reason = reason
+	return *req, nil
+}
+
+// pruneLocked expires pending requests past their deadline and drops
+// requests decided longer than the retention period ago.
+func (s *Store) pruneLocked(now time.Time) {
+	for id, req := range s.requests {
+		if req.Status == StatusPending && !now.Before(req.ExpiresAt) {
+			req.Status = StatusExpired
+			req.DecidedAt = req.ExpiresAt
+		}
+		if req.Status != StatusPending && now.Sub(req.DecidedAt) > constants.DefaultMCPApprovalRetention {
+			delete(s.requests, id)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package approval
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func newTestStore(timeout time.Duration) (*Store, *time.Time) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	s := NewStore(timeout)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestStore_Decide(t *testing.T) {
+	s, _ := newTestStore(time.Minute)
+
+	req := s.Create("coral_shell_exec", "shell --agent api -- ls", "alice")
+	assert.Equal(t, StatusPending, req.Status)
+	assert.Len(t, s.List(StatusPending), 1)
+
+	decided, err := s.Decide(req.ID, true, "bob", "looks safe")
+	require.NoError(t, err)
+	assert.Equal(t, StatusApproved, decided.Status)
+	assert.Equal(t, "bob", decided.DecidedBy)
+	assert.Equal(t, "looks safe", decided.Reason)
+
+	got, err := s.Get(req.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusApproved, got.Status)
+	assert.Empty(t, s.List(StatusPending))
+
+	_, err = s.Decide(req.ID, false, "carol", "")
+	assert.ErrorIs(t, err, ErrDecided)
+
+	_, err = s.Decide("missing", true, "bob", "")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_ExpiryAndRetention(t *testing.T) {
+	s, now := newTestStore(time.Minute)
+
+	req := s.Create("coral_container_exec", "exec api -- sh", "alice")
+	*now = now.Add(time.Second)
+	denied := s.Create("coral_attach_uprobe", "debug attach api --function main", "alice")
+	_, err := s.Decide(denied.ID, false, "bob", "")
+	require.NoError(t, err)
+
+	*now = now.Add(59 * time.Second)
+	got, err := s.Get(req.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusExpired, got.Status)
+
+	_, err = s.Decide(req.ID, true, "bob", "")
+	assert.ErrorIs(t, err, ErrDecided, "expired requests cannot be approved")
+
+	all := s.List("")
+	require.Len(t, all, 2)
+	assert.Equal(t, req.ID, all[0].ID, "oldest first")
+
+	*now = now.Add(constants.DefaultMCPApprovalRetention + time.Second)
+	assert.Empty(t, s.List(""))
+	_, err = s.Get(req.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,271 @@
+// Package audit records control-plane actions (debug probes, profiling,
+// exec and MCP tool calls) in the colony's append-only audit log.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+)
+
+// ResultOK is the result of a successful action.
+const ResultOK = "ok"
+
+// ResultFailed is the result of an action that returned an error in its
+// response rather than as an RPC error.
+const ResultFailed = "failed"
+
+// ResultDenied is the result of an action rejected for lack of permission.
+// It matches connect.CodePermissionDenied recorded by the interceptor.
+const ResultDenied = "permission_denied"
+
+// Store persists audit entries.
+type Store interface {
+	InsertAuditEntry(ctx context.Context, entry *database.AuditEntry) error
+}
+
+// Recorder writes audit entries to the store. A nil Recorder discards all
+// entries.
+type Recorder struct {
+	store  Store
+	logger zerolog.Logger
+}
+
+// NewRecorder creates a new audit recorder.
+func NewRecorder(store Store, logger zerolog.Logger) *Recorder {
+	return &Recorder{
+		store:  store,
+		logger: logger.With().Str("component", "audit").Logger(),
+	}
+}
+
+// Record appends an entry to the audit log. Failures are logged, never
+// returned, so auditing cannot fail the audited action.
+func (r *Recorder) Record(ctx context.Context, entry *database.AuditEntry) {
+	if r == nil {
+		return
+	}
+
+	// The entry must be written even if the caller's request was cancelled.
+	if err := r.store.InsertAuditEntry(context.WithoutCancel(ctx), entry); err != nil {
+		r.logger.Error().
+			Err(err).
+			Str("actor", entry.Actor).
+			Str("action", entry.Action).
+			Msg("Failed to record audit entry")
+		return
+	}
+
+	r.logger.Info().
+		Str("actor", entry.Actor).
+		Str("action", entry.Action).
+		Str("target", entry.Target).
+		Str("result", entry.Result).
+		Msg("Audit")
+}
+
+// Audited reports whether calls to an RPC procedure are recorded: actions
+// (analysis, probes, profiling), administrative operations and the minting of
+// capability tokens, but not status or query reads, nor reads of the audit
+// log or of MCP approvals.
+func Audited(procedure string) bool {
+	switch procedure {
+	case colonyv1connect.ColonyServiceListAuditEventsProcedure,
+		colonyv1connect.ColonyServiceListMCPApprovalsProcedure,
+		colonyv1connect.ColonyServiceListMCPToolCallsProcedure,
+		colonyv1connect.ColonyServiceGetMCPToolCallProcedure:
+		return false
+	case colonyv1connect.ColonyServiceDecideMCPApprovalProcedure:
+		// Recorded by the handler, which also serves the dashboard.
+		return false
+	case colonyv1connect.ColonyServiceMintCapabilityTokenProcedure:
+		return true
+	}
+	perm := httpapi.GetRequiredPermission(procedure)
+	return perm != auth.PermissionStatus && perm != auth.PermissionQuery
+}
+
+// Interceptor returns a connect interceptor that records audited procedures
+// served by the colony.
+func (r *Recorder) Interceptor() connect.Interceptor {
+	return &interceptor{recorder: r}
+}
+
+type interceptor struct {
+	recorder *Recorder
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		if !Audited(procedure) {
+			return next(ctx, req)
+		}
+
+		resp, err := next(ctx, req)
+
+		entry := &database.AuditEntry{
+			Actor:  Actor(ctx, req.Peer().Addr, ""),
+			Action: procedureName(procedure),
+			Result: ResultOK,
+		}
+		if msg, ok := req.Any().(proto.Message); ok {
+			entry.Target = Target(msg)
+			entry.ArgumentsHash = HashMessage(msg)
+		}
+		if err != nil {
+			entry.Result = connect.CodeOf(err).String()
+			entry.Error = err.Error()
+		} else if msg, ok := resp.Any().(proto.Message); ok {
+			if errMsg, failed := responseError(msg); failed {
+				entry.Result = ResultFailed
+				entry.Error = errMsg
+			}
+		}
+		i.recorder.Record(ctx, entry)
+
+		return resp, err
+	}
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		procedure := conn.Spec().Procedure
+		if !Audited(procedure) {
+			return next(ctx, conn)
+		}
+
+		err := next(ctx, conn)
+
+		// Streamed requests are not buffered, so only the caller and result
+		// are recorded.
+		entry := &database.AuditEntry{
+			Actor:  Actor(ctx, conn.Peer().Addr, ""),
+			Action: procedureName(procedure),
+			Result: ResultOK,
+		}
+		if err != nil {
+			entry.Result = connect.CodeOf(err).String()
+			entry.Error = err.Error()
+		}
+		i.recorder.Record(ctx, entry)
+
+		return err
+	}
+}
+
+// Actor identifies who performed an action: the user or ID of the
+// authenticated API token, otherwise the caller's address, prefixed with the
+// client-reported user if any.
+func Actor(ctx context.Context, peerAddr, user string) string {
+	if token := httpapi.GetAuthenticatedToken(ctx); token != nil {
+		if token.User != "" {
+			return token.User
+		}
+		return "token:" + token.TokenID
+	}
+
+	host := peerAddr
+	if h, _, err := net.SplitHostPort(peerAddr); err == nil {
+		host = h
+	}
+	switch {
+	case user != "" && host != "":
+		return user + "@" + host
+	case user != "":
+		return user
+	}
+	return host
+}
+
+// targetFields are the request fields describing what an action applies to,
+// in display order.
+var targetFields = []struct {
+	field protoreflect.Name
+	label string
+}{
+	{"service_name", "service"},
+	{"service", "service"},
+	{"agent_id", "agent"},
+	{"function_name", "function"},
+	{"session_id", "session"},
+	{"correlation_id", "correlation"},
+	{"selector", "selector"},
+}
+
+// Target describes what a request applies to, e.g.
+// "service=api function=main.handle".
+func Target(msg proto.Message) string {
+	fields := msg.ProtoReflect().Descriptor().Fields()
+
+	var parts []string
+	for _, tf := range targetFields {
+		fd := fields.ByName(tf.field)
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+			continue
+		}
+		if v := msg.ProtoReflect().Get(fd).String(); v != "" {
+			parts = append(parts, tf.label+"="+v)
+		}
+	}
+
+	// Sessions started despite a service freeze stand out in the log.
+	if fd := fields.ByName("override_freeze"); fd != nil && fd.Kind() == protoreflect.BoolKind && msg.ProtoReflect().Get(fd).Bool() {
+		parts = append(parts, "override_freeze")
+	}
+	return strings.Join(parts, " ")
+}
+
+// HashMessage returns the hex SHA-256 of a message's deterministic encoding.
+func HashMessage(msg proto.Message) string {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// HashArgs returns the hex SHA-256 of a command line.
+func HashArgs(args []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(args, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// responseError reports whether a response signals failure through
+// "success" and "error" fields rather than an RPC error.
+func responseError(msg proto.Message) (string, bool) {
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+
+	success := fields.ByName("success")
+	if success == nil || success.Kind() != protoreflect.BoolKind || m.Get(success).Bool() {
+		return "", false
+	}
+
+	if errField := fields.ByName("error"); errField != nil && errField.Kind() == protoreflect.StringKind {
+		return m.Get(errField).String(), true
+	}
+	return "", true
+}
+
+// procedureName returns the method name of an RPC procedure path.
+func procedureName(procedure string) string {
+	return procedure[strings.LastIndex(procedure, "/")+1:]
+}
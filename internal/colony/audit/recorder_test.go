@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+)
+
+type fakeStore struct {
+	entries []*database.AuditEntry
+}
+
+func (f *fakeStore) InsertAuditEntry(_ context.Context, entry *database.AuditEntry) error {
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+// debugHandler implements the debug procedures exercised by the tests.
+type debugHandler struct {
+	colonyv1connect.UnimplementedColonyDebugServiceHandler
+}
+
+func (debugHandler) AttachUprobe(
+	_ context.Context,
+	req *connect.Request[colonyv1.AttachUprobeRequest],
+) (*connect.Response[colonyv1.AttachUprobeResponse], error) {
+	if req.Msg.FunctionName == "missing" {
+		return connect.NewResponse(&colonyv1.AttachUprobeResponse{Success: false, Error: "function not found"}), nil
+	}
+	return connect.NewResponse(&colonyv1.AttachUprobeResponse{Success: true}), nil
+}
+
+func (debugHandler) ListDebugSessions(
+	context.Context,
+	*connect.Request[colonyv1.ListDebugSessionsRequest],
+) (*connect.Response[colonyv1.ListDebugSessionsResponse], error) {
+	return connect.NewResponse(&colonyv1.ListDebugSessionsResponse{}), nil
+}
+
+func TestInterceptor(t *testing.T) {
+	store := &fakeStore{}
+	recorder := NewRecorder(store, zerolog.Nop())
+
+	path, handler := colonyv1connect.NewColonyDebugServiceHandler(debugHandler{},
+		connect.WithInterceptors(recorder.Interceptor()))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client := colonyv1connect.NewColonyDebugServiceClient(srv.Client(), srv.URL)
+	ctx := context.Background()
+
+	_, err := client.ListDebugSessions(ctx, connect.NewRequest(&colonyv1.ListDebugSessionsRequest{}))
+	require.NoError(t, err)
+	assert.Empty(t, store.entries, "queries are not audited")
+
+	_, err = client.AttachUprobe(ctx, connect.NewRequest(&colonyv1.AttachUprobeRequest{
+		ServiceName:  "api",
+		FunctionName: "main.handle",
+	}))
+	require.NoError(t, err)
+	require.Len(t, store.entries, 1)
+	entry := store.entries[0]
+	assert.Equal(t, "AttachUprobe", entry.Action)
+	assert.Equal(t, "service=api function=main.handle", entry.Target)
+	assert.Equal(t, "127.0.0.1", entry.Actor)
+	assert.Equal(t, ResultOK, entry.Result)
+	assert.Len(t, entry.ArgumentsHash, 64)
+
+	_, err = client.AttachUprobe(ctx, connect.NewRequest(&colonyv1.AttachUprobeRequest{
+		ServiceName:  "api",
+		FunctionName: "missing",
+	}))
+	require.NoError(t, err)
+	require.Len(t, store.entries, 2)
+	assert.Equal(t, ResultFailed, store.entries[1].Result)
+	assert.Equal(t, "function not found", store.entries[1].Error)
+	assert.NotEqual(t, entry.ArgumentsHash, store.entries[1].ArgumentsHash)
+
+	_, err = client.DetachUprobe(ctx, connect.NewRequest(&colonyv1.DetachUprobeRequest{SessionId: "s1"}))
+	require.Error(t, err)
+	require.Len(t, store.entries, 3)
+	assert.Equal(t, "unimplemented", store.entries[2].Result)
+	assert.Equal(t, "session=s1", store.entries[2].Target)
+}
+
+func TestActor(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "10.42.0.5", Actor(ctx, "10.42.0.5:51234", ""))
+	assert.Equal(t, "bob@10.42.0.5", Actor(ctx, "10.42.0.5:51234", "bob"))
+
+	token := &auth.APIToken{TokenID: "ci"}
+	ctx = context.WithValue(ctx, httpapi.TokenContextKey, token)
+	assert.Equal(t, "token:ci", Actor(ctx, "10.42.0.5:51234", "bob"))
+
+	token.User = "alice"
+	assert.Equal(t, "alice", Actor(ctx, "10.42.0.5:51234", "bob"))
+}
+
+func TestTarget_OverrideFreeze(t *testing.T) {
+	assert.Equal(t, "service=api", Target(&colonyv1.ProfileCPURequest{ServiceName: "api"}))
+	assert.Equal(t, "service=api override_freeze",
+		Target(&colonyv1.ProfileCPURequest{ServiceName: "api", OverrideFreeze: true}))
+}
+
+func TestRecorder_Nil(t *testing.T) {
+	var r *Recorder
+	r.Record(context.Background(), &database.AuditEntry{Action: "Shell"})
+}
+
+func TestRecorder_StoreError(t *testing.T) {
+	r := NewRecorder(errStore{}, zerolog.Nop())
+	r.Record(context.Background(), &database.AuditEntry{Action: "Shell"})
+}
+
+type errStore struct{}
+
+func (errStore) InsertAuditEntry(context.Context, *database.AuditEntry) error {
+	return errors.New("disk full")
+}
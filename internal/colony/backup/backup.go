@@ -0,0 +1,317 @@
+// Package backup writes and reads colony backup archives.
+//
+// A backup is a zstd-compressed tar archive holding a manifest, a DuckDB
+// EXPORT DATABASE snapshot of the colony database (which includes the mesh IP
+// allocations) and the files of the colony directory (configuration, CA,
+// tokens). Archives are written to a temporary file that is renamed into
+// place, so an interrupted backup never leaves a truncated archive behind.
+package backup
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// FormatVersion is the version of the archive layout.
+const FormatVersion = 1
+
+// Archive layout.
+const (
+	manifestName = "manifest.json"
+	databaseDir  = "database"
+	configDir    = "config"
+)
+
+// maxFileSize bounds a single extracted archive file.
+const maxFileSize = 64 << 30
+
+// Manifest describes a backup archive.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	ColonyID      string    `json:"colony_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	CoralVersion  string    `json:"coral_version"`
+}
+
+// Contents lists the directories archived in a backup.
+type Contents struct {
+	// SnapshotDir holds the EXPORT DATABASE output of the colony database.
+	SnapshotDir string
+
+	// ColonyDir is the colony directory holding its configuration.
+	ColonyDir string
+
+	// SkipConfigFiles are colony directory files, relative to ColonyDir,
+	// that are not archived (e.g. PID and log files).
+	SkipConfigFiles []string
+}
+
+// Create writes a backup archive to path.
+func Create(archivePath string, manifest Manifest, contents Contents) error {
+	dir := filepath.Dir(archivePath)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(archivePath)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if err := write(tmp, manifest, contents); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to sync backup file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close backup file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, archivePath); err != nil {
+		return fmt.Errorf("failed to move backup into place: %w", err)
+	}
+	return nil
+}
+
+// write writes the archive to w.
+func write(w io.Writer, manifest Manifest, contents Contents) error {
+	zw, err := zstd.NewWriter(w)
+	if err != nil {
+		return fmt.Errorf("failed to create zstd writer: %w", err)
+	}
+	tw := tar.NewWriter(zw)
+
+	manifest.FormatVersion = FormatVersion
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0600, Size: int64(len(data)), ModTime: manifest.CreatedAt}); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := addTree(tw, contents.SnapshotDir, databaseDir, nil); err != nil {
+		return err
+	}
+
+	skip := make(map[string]bool, len(contents.SkipConfigFiles))
+	for _, name := range contents.SkipConfigFiles {
+		skip[filepath.ToSlash(name)] = true
+	}
+	if err := addTree(tw, contents.ColonyDir, configDir, skip); err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish backup archive: %w", err)
+	}
+	return zw.Close()
+}
+
+// addTree appends the regular files under root to the archive under prefix.
+func addTree(tw *tar.Writer, root, prefix string, skip map[string]bool) error {
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if skip[rel] {
+			return nil
+		}
+
+		return addFile(tw, p, path.Join(prefix, rel))
+	})
+}
+
+// addFile appends a file to the archive.
+func addFile(tw *tar.Writer, filePath, name string) error {
+	f, err := os.Open(filePath) // #nosec G304 -- path is inside a backed up directory.
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", filePath, err)
+	}
+
+	header := &tar.Header{
+		Name:    name,
+		Mode:    int64(info.Mode().Perm()),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write archive header: %w", err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// Extracted is a backup archive extracted to a directory.
+type Extracted struct {
+	Manifest Manifest
+
+	// SnapshotDir holds the database snapshot, to be restored with
+	// database.RestoreSnapshot.
+	SnapshotDir string
+
+	// ConfigDir holds the files of the colony directory.
+	ConfigDir string
+}
+
+// Extract extracts the backup archive at archivePath into dir, which must
+// exist and be empty.
+func Extract(archivePath, dir string) (*Extracted, error) {
+	f, err := os.Open(archivePath) // #nosec G304 -- path given by the operator.
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	zr, err := zstd.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	defer zr.Close()
+
+	extracted := &Extracted{
+		SnapshotDir: filepath.Join(dir, databaseDir),
+		ConfigDir:   filepath.Join(dir, configDir),
+	}
+	for _, d := range []string{extracted.SnapshotDir, extracted.ConfigDir} {
+		if err := os.Mkdir(d, 0700); err != nil {
+			return nil, fmt.Errorf("failed to create restore directory: %w", err)
+		}
+	}
+
+	var haveManifest bool
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !isLocalName(header.Name) {
+			return nil, fmt.Errorf("unexpected entry %q in backup", header.Name)
+		}
+
+		if header.Name == manifestName {
+			if err := json.NewDecoder(io.LimitReader(tr, 1<<20)).Decode(&extracted.Manifest); err != nil {
+				return nil, fmt.Errorf("failed to decode backup manifest: %w", err)
+			}
+			haveManifest = true
+			continue
+		}
+
+		top, _, _ := strings.Cut(header.Name, "/")
+		if top != databaseDir && top != configDir {
+			return nil, fmt.Errorf("unexpected entry %q in backup", header.Name)
+		}
+		if err := extractFile(tr, filepath.Join(dir, filepath.FromSlash(header.Name)), header.FileInfo().Mode().Perm()); err != nil {
+			return nil, err
+		}
+	}
+
+	if !haveManifest {
+		return nil, fmt.Errorf("backup has no manifest")
+	}
+	if extracted.Manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d (expected %d)", extracted.Manifest.FormatVersion, FormatVersion)
+	}
+	if extracted.Manifest.ColonyID == "" {
+		return nil, fmt.Errorf("backup manifest has no colony ID")
+	}
+	return extracted, nil
+}
+
+// isLocalName reports whether an archive entry name stays inside the
+// extraction directory.
+func isLocalName(name string) bool {
+	return name != "" && filepath.IsLocal(filepath.FromSlash(name)) && path.Clean(name) == name
+}
+
+// extractFile writes the current archive entry to filePath.
+func extractFile(tr *tar.Reader, filePath string, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	f, err := os.OpenFile(filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm&0700) // #nosec G304 -- name validated by caller.
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", filePath, err)
+	}
+
+	if _, err := io.Copy(f, io.LimitReader(tr, maxFileSize)); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to extract %s: %w", filePath, err)
+	}
+	return f.Close()
+}
+
+// InstallConfig replaces the colony directory with the extracted
+// configuration. An existing colony directory is kept next to it with a
+// ".pre-restore" suffix until the next restore. The returned function puts
+// the previous directory back, e.g. when restoring the database fails.
+func InstallConfig(extractedConfigDir, colonyDir string) (rollback func() error, err error) {
+	if err := os.MkdirAll(filepath.Dir(colonyDir), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create colonies directory: %w", err)
+	}
+
+	previous := colonyDir + ".pre-restore"
+	hadPrevious := false
+	if _, err := os.Stat(colonyDir); err == nil {
+		if err := os.RemoveAll(previous); err != nil {
+			return nil, fmt.Errorf("failed to remove previous colony directory backup: %w", err)
+		}
+		if err := os.Rename(colonyDir, previous); err != nil {
+			return nil, fmt.Errorf("failed to move existing colony directory aside: %w", err)
+		}
+		hadPrevious = true
+	}
+
+	rollback = func() error {
+		if err := os.RemoveAll(colonyDir); err != nil {
+			return err
+		}
+		if hadPrevious {
+			return os.Rename(previous, colonyDir)
+		}
+		return nil
+	}
+
+	if err := os.Rename(extractedConfigDir, colonyDir); err != nil {
+		_ = rollback()
+		return nil, fmt.Errorf("failed to install colony directory: %w", err)
+	}
+	return rollback, nil
+}
@@ -0,0 +1,118 @@
+package backup
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateExtract_RoundTrip(t *testing.T) {
+	src := t.TempDir()
+	snapshotDir := filepath.Join(src, "snapshot")
+	colonyDir := filepath.Join(src, "colony")
+	writeFile(t, filepath.Join(snapshotDir, "schema.sql"), "CREATE TABLE agent_ip_allocations (agent_id TEXT);")
+	writeFile(t, filepath.Join(snapshotDir, "agent_ip_allocations.parquet"), "parquet")
+	writeFile(t, filepath.Join(colonyDir, "config.yaml"), "colony_id: test")
+	writeFile(t, filepath.Join(colonyDir, "ca", "root-ca.crt"), "cert")
+	writeFile(t, filepath.Join(colonyDir, "colony.pid"), "1234")
+
+	archive := filepath.Join(src, "backup.tar.zst")
+	manifest := Manifest{ColonyID: "test-colony", CreatedAt: time.Now().UTC(), CoralVersion: "dev"}
+	contents := Contents{SnapshotDir: snapshotDir, ColonyDir: colonyDir, SkipConfigFiles: []string{"colony.pid"}}
+	if err := Create(archive, manifest, contents); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	extracted, err := Extract(archive, t.TempDir())
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if extracted.Manifest.ColonyID != "test-colony" || extracted.Manifest.FormatVersion != FormatVersion {
+		t.Errorf("unexpected manifest: %+v", extracted.Manifest)
+	}
+
+	for path, want := range map[string]string{
+		filepath.Join(extracted.SnapshotDir, "schema.sql"):                   "CREATE TABLE agent_ip_allocations (agent_id TEXT);",
+		filepath.Join(extracted.SnapshotDir, "agent_ip_allocations.parquet"): "parquet",
+		filepath.Join(extracted.ConfigDir, "config.yaml"):                    "colony_id: test",
+		filepath.Join(extracted.ConfigDir, "ca", "root-ca.crt"):              "cert",
+	} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("read %s: %v", path, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("%s: got %q, want %q", path, got, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(extracted.ConfigDir, "colony.pid")); !os.IsNotExist(err) {
+		t.Errorf("expected skipped PID file not to be restored, got err=%v", err)
+	}
+}
+
+func TestExtract_RejectsEscapingEntries(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "evil.tar.zst")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw, err := zstd.NewWriter(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(zw)
+	data := []byte("x")
+	if err := tw.WriteHeader(&tar.Header{Name: "config/../../escape", Mode: 0600, Size: int64(len(data))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	_ = tw.Close()
+	_ = zw.Close()
+	_ = f.Close()
+
+	_, err = Extract(archive, t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "unexpected entry") {
+		t.Fatalf("expected unexpected entry error, got %v", err)
+	}
+}
+
+func TestInstallConfig_Rollback(t *testing.T) {
+	root := t.TempDir()
+	colonyDir := filepath.Join(root, "colonies", "test")
+	extractedDir := filepath.Join(root, "extracted")
+	writeFile(t, filepath.Join(colonyDir, "config.yaml"), "old")
+	writeFile(t, filepath.Join(extractedDir, "config.yaml"), "new")
+
+	rollback, err := InstallConfig(extractedDir, colonyDir)
+	if err != nil {
+		t.Fatalf("InstallConfig: %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(colonyDir, "config.yaml")); string(got) != "new" {
+		t.Fatalf("expected restored config, got %q", got)
+	}
+
+	if err := rollback(); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(colonyDir, "config.yaml")); string(got) != "old" {
+		t.Fatalf("expected previous config after rollback, got %q", got)
+	}
+}
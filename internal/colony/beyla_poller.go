@@ -27,6 +27,7 @@ const (
 // Uses sequence-based checkpoints for reliable polling (RFD 089).
 type BeylaPoller struct {
 	*poller.BasePoller
+	scheduler          *poller.Scheduler
 	registry           *registry.Registry
 	db                 *database.Database
 	pollInterval       time.Duration
@@ -74,6 +75,7 @@ func NewBeylaPoller(
 
 	return &BeylaPoller{
 		BasePoller:         base,
+		scheduler:          poller.NewScheduler(poller.ScheduleConfig{Interval: pollInterval}),
 		registry:           registry,
 		db:                 db,
 		pollInterval:       pollInterval,
@@ -95,6 +97,11 @@ func (p *BeylaPoller) Stop() error {
 	return p.BasePoller.Stop()
 }
 
+// Scheduler returns the per-agent scheduler, which holds poll latency stats.
+func (p *BeylaPoller) Scheduler() *poller.Scheduler {
+	return p.scheduler
+}
+
 // PollOnce performs a single polling cycle.
 // Implements the poller.Poller interface.
 func (p *BeylaPoller) PollOnce(ctx context.Context) error {
@@ -103,23 +110,24 @@ func (p *BeylaPoller) PollOnce(ctx context.Context) error {
 	totalSQLMetrics := 0
 	totalTraces := 0
 
-	successCount, errorCount := poller.ForEachHealthyAgent(p.registry, p.logger, func(agent *registry.Entry) error {
+	successCount, errorCount, skippedCount := poller.ForEachDueAgent(ctx, p.registry, p.scheduler, p.logger, func(agent *registry.Entry) (bool, error) {
 		httpCount, grpcCount, sqlCount, traceCount, err := p.pollAgent(ctx, agent)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		totalHTTPMetrics += httpCount
 		totalGRPCMetrics += grpcCount
 		totalSQLMetrics += sqlCount
 		totalTraces += traceCount
-		return nil
+		return httpCount+grpcCount+sqlCount+traceCount > 0, nil
 	})
 
 	if totalHTTPMetrics > 0 || totalGRPCMetrics > 0 || totalSQLMetrics > 0 || totalTraces > 0 {
 		p.logger.Info().
 			Int("agents_queried", successCount).
 			Int("agents_failed", errorCount).
+			Int("agents_skipped", skippedCount).
 			Int("http_metrics", totalHTTPMetrics).
 			Int("grpc_metrics", totalGRPCMetrics).
 			Int("sql_metrics", totalSQLMetrics).
@@ -128,6 +136,7 @@ func (p *BeylaPoller) PollOnce(ctx context.Context) error {
 	} else {
 		p.logger.Debug().
 			Int("agents_queried", successCount).
+			Int("agents_skipped", skippedCount).
 			Msg("Beyla metrics poll completed with no data")
 	}
 
@@ -168,7 +177,8 @@ func (p *BeylaPoller) pollAgent(ctx context.Context, agent *registry.Entry) (htt
 		}
 	}
 
-	// Query agent with sequence-based request.
+	// Query agent with sequence-based request, acknowledging the checkpoints
+	// already committed so that the agent keeps whatever comes after them.
 	client := GetAgentClient(agent)
 	req := connect.NewRequest(&agentv1.QueryEbpfMetricsRequest{
 		HttpStartSeqId:   httpSeqID,
@@ -179,6 +189,11 @@ func (p *BeylaPoller) pollAgent(ctx context.Context, agent *registry.Entry) (htt
 		ServiceNames:     nil,  // Query all services.
 		MetricTypes:      nil,  // Query all metric types.
 		IncludeTraces:    true, // Request traces (RFD 036).
+		HttpAckSeqId:     ackedSeqID(httpCheckpoint, storedSessionID),
+		GrpcAckSeqId:     ackedSeqID(grpcCheckpoint, storedSessionID),
+		SqlAckSeqId:      ackedSeqID(sqlCheckpoint, storedSessionID),
+		TracesAckSeqId:   ackedSeqID(tracesCheckpoint, storedSessionID),
+		AckSessionId:     storedSessionID,
 	})
 
 	queryCtx, cancel := context.WithTimeout(ctx, agentQueryTimeout)
@@ -206,11 +221,12 @@ func (p *BeylaPoller) pollAgent(ctx context.Context, agent *registry.Entry) (htt
 			_ = p.db.ResetPollingCheckpoint(ctx, agent.AgentID, dt)
 		}
 
-		// Re-query from the beginning.
+		// Re-query from the beginning, with nothing acknowledged.
 		req.Msg.HttpStartSeqId = 0
 		req.Msg.GrpcStartSeqId = 0
 		req.Msg.SqlStartSeqId = 0
 		req.Msg.TracesStartSeqId = 0
+		req.Msg.AckSessionId = ""
 
 		queryCtx2, cancel2 := context.WithTimeout(ctx, agentQueryTimeout)
 		defer cancel2()
@@ -237,6 +253,7 @@ func (p *BeylaPoller) pollAgent(ctx context.Context, agent *registry.Entry) (htt
 
 		_ = p.db.ResetPollingCheckpoint(ctx, agent.AgentID, beylaTracesDataType)
 		req.Msg.TracesStartSeqId = 0
+		req.Msg.TracesAckSeqId = 0
 
 		queryCtx3, cancel3 := context.WithTimeout(ctx, agentQueryTimeout)
 		defer cancel3()
@@ -267,7 +284,7 @@ func (p *BeylaPoller) pollAgent(ctx context.Context, agent *registry.Entry) (htt
 		} else {
 			httpCount = len(resp.Msg.HttpMetrics)
 			if resp.Msg.HttpMaxSeqId > 0 {
-				_ = p.db.UpdatePollingCheckpoint(ctx, agent.AgentID, beylaHTTPDataType, sessionID, resp.Msg.HttpMaxSeqId)
+				p.commitCheckpoint(ctx, agent.AgentID, beylaHTTPDataType, sessionID, resp.Msg.HttpMaxSeqId)
 			}
 		}
 	}
@@ -290,7 +307,7 @@ func (p *BeylaPoller) pollAgent(ctx context.Context, agent *registry.Entry) (htt
 		} else {
 			grpcCount = len(resp.Msg.GrpcMetrics)
 			if resp.Msg.GrpcMaxSeqId > 0 {
-				_ = p.db.UpdatePollingCheckpoint(ctx, agent.AgentID, beylaGRPCDataType, sessionID, resp.Msg.GrpcMaxSeqId)
+				p.commitCheckpoint(ctx, agent.AgentID, beylaGRPCDataType, sessionID, resp.Msg.GrpcMaxSeqId)
 			}
 		}
 	}
@@ -313,7 +330,7 @@ func (p *BeylaPoller) pollAgent(ctx context.Context, agent *registry.Entry) (htt
 		} else {
 			sqlCount = len(resp.Msg.SqlMetrics)
 			if resp.Msg.SqlMaxSeqId > 0 {
-				_ = p.db.UpdatePollingCheckpoint(ctx, agent.AgentID, beylaSQLDataType, sessionID, resp.Msg.SqlMaxSeqId)
+				p.commitCheckpoint(ctx, agent.AgentID, beylaSQLDataType, sessionID, resp.Msg.SqlMaxSeqId)
 			}
 		}
 	}
@@ -336,7 +353,7 @@ func (p *BeylaPoller) pollAgent(ctx context.Context, agent *registry.Entry) (htt
 		} else {
 			traceCount = len(resp.Msg.TraceSpans)
 			if resp.Msg.TracesMaxSeqId > 0 {
-				_ = p.db.UpdatePollingCheckpoint(ctx, agent.AgentID, beylaTracesDataType, sessionID, resp.Msg.TracesMaxSeqId)
+				p.commitCheckpoint(ctx, agent.AgentID, beylaTracesDataType, sessionID, resp.Msg.TracesMaxSeqId)
 			}
 		}
 	}
@@ -344,6 +361,29 @@ func (p *BeylaPoller) pollAgent(ctx context.Context, agent *registry.Entry) (htt
 	return httpCount, grpcCount, sqlCount, traceCount, nil
 }
 
+// commitCheckpoint advances the checkpoint of a data type once its records
+// are stored. If that fails, the next poll fetches the records again, which
+// the idempotent upserts store only once.
+func (p *BeylaPoller) commitCheckpoint(ctx context.Context, agentID, dataType, sessionID string, seqID uint64) {
+	if err := p.db.UpdatePollingCheckpoint(ctx, agentID, dataType, sessionID, seqID); err != nil {
+		p.logger.Warn().
+			Err(err).
+			Str("agent_id", agentID).
+			Str("data_type", dataType).
+			Uint64("seq_id", seqID).
+			Msg("Failed to update Beyla checkpoint, records will be fetched again")
+	}
+}
+
+// ackedSeqID returns the seq_id a checkpoint acknowledges to an agent in
+// session sessionID, or 0 if the checkpoint belongs to another session.
+func ackedSeqID(checkpoint *database.PollingCheckpoint, sessionID string) uint64 {
+	if checkpoint == nil || sessionID == "" || checkpoint.SessionID != sessionID {
+		return 0
+	}
+	return checkpoint.LastSeqID
+}
+
 // RunCleanup performs Beyla metrics database cleanup.
 // Removes metrics older than configured retention periods.
 // Implements the poller.Poller interface.
@@ -0,0 +1,30 @@
+package colony
+
+import (
+	"testing"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+)
+
+func TestAckedSeqID(t *testing.T) {
+	checkpoint := &database.PollingCheckpoint{SessionID: "session-a", LastSeqID: 42}
+
+	tests := []struct {
+		name       string
+		checkpoint *database.PollingCheckpoint
+		sessionID  string
+		want       uint64
+	}{
+		{name: "same session", checkpoint: checkpoint, sessionID: "session-a", want: 42},
+		{name: "other session", checkpoint: checkpoint, sessionID: "session-b", want: 0},
+		{name: "unknown session", checkpoint: checkpoint, sessionID: "", want: 0},
+		{name: "no checkpoint", checkpoint: nil, sessionID: "session-a", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ackedSeqID(tt.checkpoint, tt.sessionID); got != tt.want {
+				t.Errorf("ackedSeqID() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package colony
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/poller"
+)
+
+const (
+	// beylaRollupInterval is how often Beyla metric rollups are built.
+	beylaRollupInterval = time.Minute
+
+	// beylaRollupLateness is how long after a bucket ends it is rolled up,
+	// leaving time for the Beyla poller to collect the bucket's data.
+	beylaRollupLateness = 5 * time.Minute
+)
+
+// BeylaRollupService periodically aggregates raw Beyla HTTP, gRPC and SQL
+// metrics into the 1m, 10m and 1h rollup tables used for long-range queries.
+type BeylaRollupService struct {
+	*poller.BasePoller
+	db     *database.Database
+	logger zerolog.Logger
+}
+
+// NewBeylaRollupService creates a new Beyla metrics rollup service.
+func NewBeylaRollupService(
+	ctx context.Context,
+	db *database.Database,
+	logger zerolog.Logger,
+) *BeylaRollupService {
+	componentLogger := logger.With().Str("component", "beyla_rollup").Logger()
+
+	base := poller.NewBasePoller(ctx, poller.Config{
+		Name:         "beyla_rollup",
+		PollInterval: beylaRollupInterval,
+		Logger:       componentLogger,
+	})
+
+	return &BeylaRollupService{
+		BasePoller: base,
+		db:         db,
+		logger:     componentLogger,
+	}
+}
+
+// Start begins building rollups.
+func (s *BeylaRollupService) Start() error {
+	return s.BasePoller.Start(s)
+}
+
+// PollOnce builds the rollup buckets that are complete.
+// Implements the poller.Poller interface.
+func (s *BeylaRollupService) PollOnce(ctx context.Context) error {
+	return s.db.RollupBeylaMetrics(ctx, time.Now(), beylaRollupLateness)
+}
+
+// RunCleanup is a no-op; rollup tables are cleaned up by the retention
+// manager.
+// Implements the poller.Poller interface.
+func (s *BeylaRollupService) RunCleanup(ctx context.Context) error {
+	return nil
+}
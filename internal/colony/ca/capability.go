@@ -0,0 +1,64 @@
+package ca
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/auth"
+)
+
+// IssueCapabilityToken mints a capability token granting scopes to subject
+// for ttl, signed with the policy signing key. A zero ttl defaults to
+// auth.DefaultCapabilityTTL.
+func (m *Manager) IssueCapabilityToken(subject, issuedBy string, scopes []auth.Scope, ttl time.Duration) (string, *auth.Capability, error) {
+	if len(scopes) == 0 {
+		return "", nil, fmt.Errorf("at least one scope is required")
+	}
+	if ttl == 0 {
+		ttl = auth.DefaultCapabilityTTL
+	}
+	if ttl < 0 || ttl > auth.MaxCapabilityTTL {
+		return "", nil, fmt.Errorf("ttl must be between 0 and %s", auth.MaxCapabilityTTL)
+	}
+
+	now := time.Now()
+	c := &auth.Capability{
+		ColonyID:  m.colonyID,
+		Subject:   subject,
+		IssuedBy:  issuedBy,
+		Scopes:    scopes,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+	token, err := auth.IssueCapabilityToken(c, m.crypto.GetPolicySigningCert(), m.crypto.policySigningKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	m.logger.Info().
+		Str("capability_id", c.ID).
+		Str("subject", subject).
+		Str("issued_by", issuedBy).
+		Time("expires_at", c.ExpiresAt).
+		Msg("Issued capability token")
+	return token, c, nil
+}
+
+// CapabilityVerifier returns a verifier of the capability tokens minted by
+// this colony.
+func (m *Manager) CapabilityVerifier() *auth.CapabilityVerifier {
+	roots := x509.NewCertPool()
+	roots.AddCert(m.crypto.GetRootCert())
+	return auth.NewCapabilityVerifier(roots, m.colonyID)
+}
+
+// IssueAgentReleaseToken signs an agent release rolled out to agents, valid
+// for ttl.
+func (m *Manager) IssueAgentReleaseToken(release auth.AgentRelease, ttl time.Duration) (string, error) {
+	now := time.Now()
+	release.ColonyID = m.colonyID
+	release.IssuedAt = now
+	release.ExpiresAt = now.Add(ttl)
+	return auth.IssueAgentReleaseToken(&release, m.crypto.GetPolicySigningCert(), m.crypto.policySigningKey)
+}
@@ -0,0 +1,38 @@
+package ca
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/auth"
+)
+
+func TestManager_IssueCapabilityToken(t *testing.T) {
+	m := setupRevocationTestManager(t)
+	scopes := []auth.Scope{{Permission: auth.PermissionDebug, Service: "api"}}
+
+	token, issued, err := m.IssueCapabilityToken("ci-deploy", "alice", scopes, 0)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(auth.DefaultCapabilityTTL), issued.ExpiresAt, time.Minute)
+
+	c, err := m.CapabilityVerifier().Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, issued.ID, c.ID)
+	assert.Equal(t, "revocation-test-colony", c.ColonyID)
+	assert.Equal(t, "ci-deploy", c.Subject)
+	assert.Equal(t, "alice", c.IssuedBy)
+	assert.Equal(t, scopes, c.Scopes)
+
+	// Tokens are rejected by a colony with another CA.
+	other := setupRevocationTestManager(t)
+	_, err = other.CapabilityVerifier().Verify(token)
+	assert.Error(t, err)
+
+	_, _, err = m.IssueCapabilityToken("ci-deploy", "alice", scopes, auth.MaxCapabilityTTL+time.Minute)
+	assert.Error(t, err, "ttl above the maximum")
+	_, _, err = m.IssueCapabilityToken("ci-deploy", "alice", nil, time.Hour)
+	assert.Error(t, err, "no scopes")
+}
@@ -455,6 +455,32 @@ func (m *Manager) RotateIntermediate(typeStr string) error {
 	return nil
 }
 
+// AgentClientCAs returns the pool that verifies agent client certificates:
+// the root CA and the Agent Intermediate CA, so that agents presenting only
+// their leaf certificate are verified too.
+func (m *Manager) AgentClientCAs() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(m.crypto.GetRootCert())
+	pool.AddCert(m.crypto.GetAgentIntermediateCert())
+	return pool
+}
+
+// IsCertificateRevoked reports whether the certificate with serialNumber was
+// revoked. Certificates the colony has no record of are not revoked.
+func (m *Manager) IsCertificateRevoked(ctx context.Context, serialNumber string) (bool, error) {
+	var status string
+	err := m.db.QueryRowContext(ctx, `
+		SELECT status FROM issued_certificates WHERE serial_number = ?
+	`, serialNumber).Scan(&status)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get certificate status: %w", err)
+	}
+	return status == "revoked", nil
+}
+
 // GetCAFingerprint returns the root CA fingerprint.
 func (m *Manager) GetCAFingerprint() string {
 	rootCert := m.crypto.GetRootCert()
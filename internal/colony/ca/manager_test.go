@@ -1,14 +1,17 @@
 package ca
 
 import (
+	"context"
 	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/x509"
+	"database/sql"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -734,6 +737,84 @@ func TestGetCertPEMMethods(t *testing.T) {
 	})
 }
 
+func TestAgentClientCAs(t *testing.T) {
+	caDir := filepath.Join(t.TempDir(), "ca")
+	if _, err := Initialize(caDir, "test-colony"); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	manager := &Manager{
+		colonyID:  "test-colony",
+		caDir:     caDir,
+		fsStorage: NewFilesystemStorage(caDir),
+		policy:    NewPolicyEnforcer(nil, "test-colony"),
+	}
+	if err := manager.loadCA(); err != nil {
+		t.Fatalf("loadCA failed: %v", err)
+	}
+
+	// An agent leaf certificate verifies without its intermediate.
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, leaf, manager.crypto.GetAgentIntermediateCert(), pub, manager.crypto.agentIntermediateKey)
+	if err != nil {
+		t.Fatalf("failed to create agent certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse agent certificate: %v", err)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:     manager.AgentClientCAs(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		t.Errorf("agent certificate did not verify: %v", err)
+	}
+}
+
+func TestIsCertificateRevoked(t *testing.T) {
+	db, err := sql.Open("duckdb", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec(`CREATE TABLE issued_certificates (
+		serial_number TEXT PRIMARY KEY,
+		agent_id TEXT NOT NULL,
+		colony_id TEXT NOT NULL,
+		certificate_pem TEXT NOT NULL,
+		issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		expires_at TIMESTAMP NOT NULL,
+		revoked_at TIMESTAMP,
+		revocation_reason TEXT,
+		status TEXT NOT NULL DEFAULT 'active'
+	)`); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO issued_certificates (serial_number, agent_id, colony_id, certificate_pem, expires_at, status)
+		VALUES ('1', 'agent-1', 'test-colony', '', now(), 'active'), ('2', 'agent-2', 'test-colony', '', now(), 'revoked')`); err != nil {
+		t.Fatalf("failed to insert certificates: %v", err)
+	}
+
+	manager := &Manager{db: db}
+	for serial, want := range map[string]bool{"1": false, "2": true, "3": false} {
+		revoked, err := manager.IsCertificateRevoked(context.Background(), serial)
+		if err != nil {
+			t.Fatalf("IsCertificateRevoked(%s) failed: %v", serial, err)
+		}
+		if revoked != want {
+			t.Errorf("IsCertificateRevoked(%s) = %v, want %v", serial, revoked, want)
+		}
+	}
+}
+
 func TestIssueCertificate(t *testing.T) {
 	// Skip if no test database available.
 	// This test requires a database connection.
@@ -0,0 +1,162 @@
+package ca
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// CRLPath is the path the colony serves its certificate revocation list on.
+const CRLPath = "/ca/crl"
+
+// crlValidity is how long a revocation list is valid for. Consumers should
+// fetch a new one well before, as revocations take effect on the next fetch.
+const crlValidity = time.Hour
+
+// RevokeAgent revokes all active certificates issued to agentID and returns
+// their serial numbers.
+func (m *Manager) RevokeAgent(ctx context.Context, agentID, reason, revokedBy string) ([]string, error) {
+	certs, err := m.dbStorage.ListCertificates(ctx, map[string]interface{}{
+		"agent_id": agentID,
+		"status":   "active",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	serials := make([]string, 0, len(certs))
+	for _, cert := range certs {
+		if err := m.dbStorage.RevokeCertificate(ctx, cert.SerialNumber, reason, revokedBy); err != nil {
+			return serials, fmt.Errorf("failed to revoke certificate %s: %w", cert.SerialNumber, err)
+		}
+		serials = append(serials, cert.SerialNumber)
+	}
+
+	m.logger.Info().
+		Str("agent_id", agentID).
+		Strs("serial_numbers", serials).
+		Msg("Revoked agent certificates")
+	return serials, nil
+}
+
+// IsAgentRevoked reports whether agentID was revoked: the colony revoked
+// certificates of the agent and has not issued it an active one since.
+func (m *Manager) IsAgentRevoked(ctx context.Context, agentID string) (bool, error) {
+	var active, revoked int
+	err := m.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'active'),
+			COUNT(*) FILTER (WHERE status = 'revoked')
+		FROM issued_certificates
+		WHERE agent_id = ?
+	`, agentID).Scan(&active, &revoked)
+	if err != nil {
+		return false, fmt.Errorf("failed to get agent certificates: %w", err)
+	}
+	return revoked > 0 && active == 0, nil
+}
+
+// VerifyAgentCertificate rejects revoked agent certificates. It is meant for
+// the VerifyConnection hook of TLS servers accepting agent certificates.
+func (m *Manager) VerifyAgentCertificate(cert *x509.Certificate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	revoked, err := m.IsCertificateRevoked(ctx, cert.SerialNumber.String())
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return fmt.Errorf("certificate %s is revoked", cert.SerialNumber)
+	}
+	return nil
+}
+
+// RevocationList returns the DER encoded certificate revocation list of agent
+// certificates, signed by the Agent Intermediate CA. Expired certificates are
+// left out.
+func (m *Manager) RevocationList(ctx context.Context) ([]byte, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT serial_number, revoked_at
+		FROM issued_certificates
+		WHERE status = 'revoked' AND expires_at > ?
+		ORDER BY revoked_at
+	`, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revoked certificates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []x509.RevocationListEntry
+	for rows.Next() {
+		var (
+			serial    string
+			revokedAt *time.Time
+		)
+		if err := rows.Scan(&serial, &revokedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan revoked certificate: %w", err)
+		}
+		serialNumber, ok := new(big.Int).SetString(serial, 10)
+		if !ok {
+			m.logger.Warn().Str("serial_number", serial).Msg("Skipping revoked certificate with invalid serial number")
+			continue
+		}
+		entry := x509.RevocationListEntry{SerialNumber: serialNumber, RevocationTime: time.Now()}
+		if revokedAt != nil {
+			entry.RevocationTime = *revokedAt
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read revoked certificates: %w", err)
+	}
+
+	now := time.Now()
+	return x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		RevokedCertificateEntries: entries,
+		// CRL numbers must increase; successive lists are generated at
+		// successive times.
+		Number:     big.NewInt(now.UnixNano()),
+		ThisUpdate: now,
+		NextUpdate: now.Add(crlValidity),
+	}, m.crypto.GetAgentIntermediateCert(), m.crypto.agentIntermediateKey)
+}
+
+// CRLHandler serves the certificate revocation list of agent certificates to
+// the servers that accept them, e.g. SDK debug servers.
+type CRLHandler struct {
+	manager *Manager
+	logger  zerolog.Logger
+}
+
+// NewCRLHandler creates a new revocation list handler.
+func NewCRLHandler(manager *Manager, logger zerolog.Logger) *CRLHandler {
+	return &CRLHandler{
+		manager: manager,
+		logger:  logger.With().Str("component", "ca_crl").Logger(),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *CRLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	crl, err := h.manager.RevocationList(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to generate certificate revocation list")
+		http.Error(w, "revocation list unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pkix-crl")
+	_, _ = w.Write(crl)
+}
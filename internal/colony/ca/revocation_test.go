@@ -0,0 +1,143 @@
+package ca
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRevocationTestManager creates a Manager with a CA hierarchy and the
+// certificate tables, holding certificates 1 and 2 of agent-1 and 3 of
+// agent-2, the latter expired.
+func setupRevocationTestManager(t *testing.T) *Manager {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	caDir := filepath.Join(tmpDir, "ca")
+	_, err := Initialize(caDir, "revocation-test-colony")
+	require.NoError(t, err)
+
+	db, err := sql.Open("duckdb", filepath.Join(tmpDir, "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`CREATE TABLE issued_certificates (
+		serial_number TEXT PRIMARY KEY,
+		agent_id TEXT NOT NULL,
+		colony_id TEXT NOT NULL,
+		certificate_pem TEXT NOT NULL,
+		issued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		expires_at TIMESTAMP NOT NULL,
+		revoked_at TIMESTAMP,
+		revocation_reason TEXT,
+		status TEXT NOT NULL DEFAULT 'active'
+	)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE certificate_revocations (
+		id INTEGER PRIMARY KEY,
+		serial_number TEXT NOT NULL,
+		revoked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		reason TEXT NOT NULL,
+		revoked_by TEXT
+	)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO issued_certificates (serial_number, agent_id, colony_id, certificate_pem, expires_at)
+		VALUES
+			('1', 'agent-1', 'revocation-test-colony', '', ?),
+			('2', 'agent-1', 'revocation-test-colony', '', ?),
+			('3', 'agent-2', 'revocation-test-colony', '', ?)`,
+		time.Now().Add(24*time.Hour), time.Now().Add(24*time.Hour), time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	m := &Manager{
+		db:        db,
+		colonyID:  "revocation-test-colony",
+		caDir:     caDir,
+		fsStorage: NewFilesystemStorage(caDir),
+		dbStorage: NewDatabaseStorage(db, zerolog.Nop()),
+		policy:    NewPolicyEnforcer(nil, "revocation-test-colony"),
+		logger:    zerolog.Nop(),
+	}
+	require.NoError(t, m.loadCA())
+	return m
+}
+
+func TestManager_RevokeAgent(t *testing.T) {
+	m := setupRevocationTestManager(t)
+	ctx := context.Background()
+
+	revoked, err := m.IsAgentRevoked(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	serials, err := m.RevokeAgent(ctx, "agent-1", "compromised", "admin")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1", "2"}, serials)
+
+	revoked, err = m.IsAgentRevoked(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	revoked, err = m.IsAgentRevoked(ctx, "agent-2")
+	require.NoError(t, err)
+	assert.False(t, revoked, "agents with active certificates are not revoked")
+
+	// Revoking again revokes nothing new.
+	serials, err = m.RevokeAgent(ctx, "agent-1", "compromised", "admin")
+	require.NoError(t, err)
+	assert.Empty(t, serials)
+
+	var revocations int
+	require.NoError(t, m.db.QueryRow("SELECT COUNT(*) FROM certificate_revocations").Scan(&revocations))
+	assert.Equal(t, 2, revocations)
+
+	cert, err := m.dbStorage.GetCertificate(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "revoked", cert.Status)
+	require.NotNil(t, cert.RevocationReason)
+	assert.Equal(t, "compromised", *cert.RevocationReason)
+}
+
+func TestManager_RevocationList(t *testing.T) {
+	m := setupRevocationTestManager(t)
+	ctx := context.Background()
+
+	_, err := m.RevokeAgent(ctx, "agent-1", "compromised", "admin")
+	require.NoError(t, err)
+	_, err = m.RevokeAgent(ctx, "agent-2", "decommissioned", "admin")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(NewCRLHandler(m, zerolog.Nop()))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + CRLPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/pkix-crl", resp.Header.Get("Content-Type"))
+	der, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	crl, err := x509.ParseRevocationList(der)
+	require.NoError(t, err)
+	require.NoError(t, crl.CheckSignatureFrom(m.crypto.GetAgentIntermediateCert()))
+
+	var serials []string
+	for _, entry := range crl.RevokedCertificateEntries {
+		serials = append(serials, entry.SerialNumber.String())
+	}
+	assert.ElementsMatch(t, []string{"1", "2"}, serials, "expired certificates are left out")
+	assert.True(t, crl.NextUpdate.After(crl.ThisUpdate))
+
+	cert := &x509.Certificate{SerialNumber: crl.RevokedCertificateEntries[0].SerialNumber}
+	assert.Error(t, m.VerifyAgentCertificate(cert))
+}
@@ -318,9 +318,10 @@ func (d *DatabaseStorage) RevokeCertificate(ctx context.Context, serialNumber, r
 		return fmt.Errorf("failed to update certificate status: %w", err)
 	}
 
+	// The revocation ID has no default and is generated here.
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO certificate_revocations (serial_number, reason, revoked_by)
-		VALUES (?, ?, ?)
+		INSERT INTO certificate_revocations (id, serial_number, reason, revoked_by)
+		SELECT COALESCE(MAX(id), 0) + 1, ?, ?, ? FROM certificate_revocations
 	`, serialNumber, reason, revokedBy)
 	if err != nil {
 		return fmt.Errorf("failed to record revocation: %w", err)
@@ -0,0 +1,269 @@
+// Package coldstorage offloads old raw metrics from the colony database to
+// Parquet files in object storage (S3, GCS) or a local directory.
+package coldstorage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/poller"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// secretName is the DuckDB secret holding the object storage credentials.
+const secretName = "coral_cold_storage"
+
+// TableResult is the outcome of offloading a table.
+type TableResult struct {
+	Table      string
+	Partitions []database.ColdPartition
+	Err        error
+}
+
+// Rows returns the number of rows offloaded.
+func (r TableResult) Rows() int64 {
+	var n int64
+	for _, p := range r.Partitions {
+		n += p.Rows
+	}
+	return n
+}
+
+// Report summarizes an offload run.
+type Report struct {
+	Time   time.Time
+	Tables []TableResult
+}
+
+// Rows returns the total number of rows offloaded.
+func (r *Report) Rows() int64 {
+	var n int64
+	for _, t := range r.Tables {
+		n += t.Rows()
+	}
+	return n
+}
+
+// Manager periodically offloads the days of the raw metric tables older
+// than the configured age to cold storage.
+type Manager struct {
+	*poller.BasePoller
+	ctx    context.Context
+	db     *database.Database
+	cfg    config.ColdStorageConfig
+	tables []string
+	after  time.Duration
+	logger zerolog.Logger
+
+	mu   sync.Mutex
+	last *Report
+}
+
+// NewManager creates a cold storage manager from the colony cold storage
+// config.
+func NewManager(
+	ctx context.Context,
+	db *database.Database,
+	cfg config.ColdStorageConfig,
+	logger zerolog.Logger,
+) (*Manager, error) {
+	tables := cfg.Tables
+	if len(tables) == 0 {
+		tables = database.ColdStorageTables()
+	}
+	for _, table := range tables {
+		if !slices.Contains(database.ColdStorageTables(), table) {
+			return nil, fmt.Errorf("cold_storage.tables: unknown table %q (must be one of %s)",
+				table, strings.Join(database.ColdStorageTables(), ", "))
+		}
+	}
+
+	after := cfg.After
+	if after <= 0 {
+		after = constants.DefaultColdStorageAfter
+	}
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = constants.DefaultColdStorageInterval
+	}
+
+	componentLogger := logger.With().Str("component", "cold_storage").Logger()
+
+	base := poller.NewBasePoller(ctx, poller.Config{
+		Name:         "cold_storage",
+		PollInterval: interval,
+		Logger:       componentLogger,
+	})
+
+	return &Manager{
+		BasePoller: base,
+		ctx:        ctx,
+		db:         db,
+		cfg:        cfg,
+		tables:     tables,
+		after:      after,
+		logger:     componentLogger,
+	}, nil
+}
+
+// Start configures access to the object storage and begins offloading.
+func (m *Manager) Start() error {
+	if err := m.configure(m.ctx); err != nil {
+		return err
+	}
+	return m.BasePoller.Start(m)
+}
+
+// configure loads the DuckDB httpfs extension and creates the secret used to
+// write and read remote files. Local directories need neither.
+func (m *Manager) configure(ctx context.Context) error {
+	var secretType string
+	switch {
+	case strings.HasPrefix(m.cfg.URL, "s3://"):
+		secretType = "S3"
+	case strings.HasPrefix(m.cfg.URL, "gs://"):
+		secretType = "GCS"
+	default:
+		return nil
+	}
+
+	if _, err := m.db.ExecContext(ctx, "INSTALL httpfs; LOAD httpfs;"); err != nil {
+		return fmt.Errorf("failed to load httpfs extension: %w", err)
+	}
+	if m.cfg.AccessKeyID == "" {
+		return nil
+	}
+
+	options := []string{
+		"TYPE " + secretType,
+		"KEY_ID " + quote(m.cfg.AccessKeyID),
+		"SECRET " + quote(m.cfg.SecretAccessKey),
+	}
+	if m.cfg.Region != "" {
+		options = append(options, "REGION "+quote(m.cfg.Region))
+	}
+	if m.cfg.Endpoint != "" {
+		options = append(options, "ENDPOINT "+quote(m.cfg.Endpoint))
+	}
+	if _, err := m.db.ExecContext(ctx, fmt.Sprintf("CREATE OR REPLACE SECRET %s (%s)",
+		secretName, strings.Join(options, ", "))); err != nil {
+		return fmt.Errorf("failed to create cold storage secret: %w", err)
+	}
+	return nil
+}
+
+// PollOnce offloads all tables.
+// Implements the poller.Poller interface.
+func (m *Manager) PollOnce(ctx context.Context) error {
+	_, err := m.RunOnce(ctx)
+	return err
+}
+
+// RunCleanup is a no-op; offloaded files are expired by the bucket's
+// lifecycle rules.
+// Implements the poller.Poller interface.
+func (m *Manager) RunCleanup(ctx context.Context) error {
+	return nil
+}
+
+// RunOnce offloads every whole day older than the configured age, checkpoints
+// the database and returns a report. A failing table does not stop the
+// others; its error is recorded in the report.
+func (m *Manager) RunOnce(ctx context.Context) (*Report, error) {
+	now := time.Now()
+	report := &Report{Time: now}
+
+	for _, table := range m.tables {
+		result := m.offloadTable(ctx, table, now)
+		report.Tables = append(report.Tables, result)
+		if result.Err != nil {
+			m.logger.Error().Err(result.Err).Str("table", table).Msg("Failed to offload table to cold storage")
+		}
+	}
+
+	var err error
+	if report.Rows() > 0 {
+		err = m.db.Checkpoint(ctx)
+	}
+
+	m.mu.Lock()
+	m.last = report
+	m.mu.Unlock()
+
+	if report.Rows() > 0 {
+		event := m.logger.Info().Int64("rows", report.Rows())
+		for _, t := range report.Tables {
+			if t.Rows() > 0 {
+				event = event.Int64(t.Table, t.Rows())
+			}
+		}
+		event.Msg("Offloaded rows to cold storage")
+	}
+
+	return report, err
+}
+
+// offloadTable offloads the days of table that ended before now minus the
+// configured age.
+func (m *Manager) offloadTable(ctx context.Context, table string, now time.Time) TableResult {
+	result := TableResult{Table: table}
+
+	days, err := m.db.ColdOffloadDays(ctx, table, now.Add(-m.after))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	for _, day := range days {
+		path, err := m.partitionPath(table, day, now)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		part, err := m.db.OffloadColdPartition(ctx, table, day, path)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		if part != nil {
+			result.Partitions = append(result.Partitions, *part)
+		}
+	}
+	return result
+}
+
+// partitionPath returns the path of a new file for the rows of table on day,
+// e.g. s3://bucket/prefix/beyla_http_metrics/date=2025-01-31/1738368000000.parquet.
+// Rows arriving for an already offloaded day go to an additional file.
+func (m *Manager) partitionPath(table string, day, now time.Time) (string, error) {
+	dir := fmt.Sprintf("%s/%s/date=%s", strings.TrimSuffix(m.cfg.URL, "/"), table, day.Format("2006-01-02"))
+	if filepath.IsAbs(m.cfg.URL) {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return "", fmt.Errorf("failed to create cold storage directory: %w", err)
+		}
+	}
+	return fmt.Sprintf("%s/%d.parquet", dir, now.UnixMilli()), nil
+}
+
+// LastReport returns the report of the latest run, or nil before the first
+// run.
+func (m *Manager) LastReport() *Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last
+}
+
+// quote quotes s as a SQL string literal.
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,108 @@
+package coldstorage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func insertHTTPMetric(t *testing.T, db *database.Database, ts time.Time, count int64) {
+	t.Helper()
+	_, err := db.DB().Exec(`
+		INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+		VALUES (?, 'agent-1', 'api', 'GET', '/users', 200, 10, ?)
+	`, ts, count)
+	require.NoError(t, err)
+}
+
+func count(t *testing.T, db *database.Database, table string) int {
+	t.Helper()
+	var n int
+	require.NoError(t, db.DB().QueryRow("SELECT count(*) FROM "+table).Scan(&n))
+	return n
+}
+
+func TestManager_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	now := time.Now().UTC()
+	old := now.Add(-10 * 24 * time.Hour).Truncate(24 * time.Hour)
+	insertHTTPMetric(t, db, old.Add(time.Hour), 1)
+	insertHTTPMetric(t, db, old.Add(2*time.Hour), 2)
+	insertHTTPMetric(t, db, old.Add(25*time.Hour), 4)
+	insertHTTPMetric(t, db, now.Add(-time.Hour), 8)
+
+	dir := t.TempDir()
+	m, err := NewManager(ctx, db, config.ColdStorageConfig{Enabled: true, URL: dir}, zerolog.Nop())
+	require.NoError(t, err)
+
+	report, err := m.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), report.Rows())
+	assert.Same(t, report, m.LastReport())
+	for _, r := range report.Tables {
+		assert.NoError(t, r.Err, r.Table)
+	}
+
+	// Offloaded days are written per day and removed from the table.
+	assert.Equal(t, 1, count(t, db, "beyla_http_metrics"))
+	assert.Equal(t, 2, count(t, db, "cold_storage_manifest"))
+	files, err := filepath.Glob(filepath.Join(dir, "beyla_http_metrics", "date=*", "*.parquet"))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+	_, err = os.Stat(filepath.Join(dir, "beyla_http_metrics", "date="+old.Format("2006-01-02")))
+	assert.NoError(t, err)
+
+	// Queries reaching into offloaded days read the files.
+	results, err := db.QueryBeylaHTTPMetrics(ctx, "api", now.Add(-30*24*time.Hour), now, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, int64(15), results[0].Count)
+
+	results, err = db.QueryBeylaHTTPMetrics(ctx, "api", now.Add(-2*time.Hour), now, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, int64(8), results[0].Count)
+
+	// Rows arriving late for an offloaded day go to an additional file.
+	insertHTTPMetric(t, db, old.Add(3*time.Hour), 16)
+	report, err = m.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.Rows())
+
+	parts, err := db.ColdPartitions(ctx, "beyla_http_metrics", old, old.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	assert.NotEqual(t, parts[0].Path, parts[1].Path)
+
+	results, err = db.QueryBeylaHTTPMetrics(ctx, "api", old, old.Add(24*time.Hour), nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, int64(19), results[0].Count)
+}
+
+func TestNewManager_UnknownTable(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	_, err = NewManager(context.Background(), db, config.ColdStorageConfig{
+		Enabled: true,
+		URL:     t.TempDir(),
+		Tables:  []string{"audit_log"},
+	}, zerolog.Nop())
+	assert.ErrorContains(t, err, `unknown table "audit_log"`)
+}
@@ -250,19 +250,21 @@ func (p *CPUProfilePoller) aggregateSamples(ctx context.Context,
 
 	grouped := make(map[sampleKey]*sampleGroup)
 
-	for _, sample := range samples {
+	// Encode all stacks at once, so each distinct frame is looked up once.
+	stacks := make([][]string, len(samples))
+	for i, sample := range samples {
+		stacks[i] = sample.StackFrames
+	}
+	encoded, err := p.db.EncodeStackFrameBatch(ctx, stacks)
+	if err != nil {
+		p.logger.Warn().Err(err).Int("sample_count", len(samples)).Msg("Failed to encode stack frames, skipping samples")
+		return nil
+	}
+
+	for i, sample := range samples {
 		// Truncate timestamp to minute boundary for aggregation.
 		bucketTime := sample.Timestamp.AsTime().Truncate(time.Minute)
-
-		// Encode stack frames to integer IDs using colony's frame dictionary.
-		frameIDs, err := p.db.EncodeStackFrames(ctx, sample.StackFrames)
-		if err != nil {
-			p.logger.Warn().
-				Err(err).
-				Int("stack_depth", len(sample.StackFrames)).
-				Msg("Failed to encode stack frames, skipping sample")
-			continue
-		}
+		frameIDs := encoded[i]
 
 		// Compute stack hash for deduplication.
 		stackHash := database.ComputeStackHash(frameIDs)
@@ -345,6 +347,6 @@ func (p *CPUProfilePoller) RunCleanup(ctx context.Context) error {
 // buildAgentURL constructs the agent gRPC URL from registry entry.
 // Uses the same pattern as GetAgentClient for consistency.
 func buildAgentURL(agent *registry.Entry) string {
-	agentAddr := net.JoinHostPort(agent.MeshIPv4, strconv.Itoa(constants.DefaultAgentPort))
+	agentAddr := net.JoinHostPort(agent.MeshIP(), strconv.Itoa(constants.DefaultAgentPort))
 	return fmt.Sprintf("http://%s", agentAddr)
 }
@@ -0,0 +1,218 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+// defaultRange is the time range of telemetry and flame graph queries when
+// the request does not set one.
+const defaultRange = time.Hour
+
+// maxRange bounds the time range a dashboard query may cover.
+const maxRange = 7 * 24 * time.Hour
+
+var errRangeBounds = errors.New("range must be positive and at most 168h")
+
+func (s *Server) handleAgents(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.colony.ListAgents(r.Context(), connect.NewRequest(&colonyv1.ListAgentsRequest{}))
+	if err != nil {
+		s.writeError(w, http.StatusBadGateway, "failed to list agents", err)
+		return
+	}
+	s.writeProto(w, resp.Msg)
+}
+
+func (s *Server) handleServices(w http.ResponseWriter, r *http.Request) {
+	timeRange, err := parseRange(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid range", err)
+		return
+	}
+
+	resp, err := s.colony.ListServices(r.Context(), connect.NewRequest(&colonyv1.ListServicesRequest{
+		TimeRange: timeRange.String(),
+	}))
+	if err != nil {
+		s.writeError(w, http.StatusBadGateway, "failed to list services", err)
+		return
+	}
+	s.writeProto(w, resp.Msg)
+}
+
+func (s *Server) handleSessions(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.debug.ListDebugSessions(r.Context(), connect.NewRequest(&colonyv1.ListDebugSessionsRequest{
+		Status: r.URL.Query().Get("status"),
+	}))
+	if err != nil {
+		s.writeError(w, http.StatusBadGateway, "failed to list debug sessions", err)
+		return
+	}
+	s.writeProto(w, resp.Msg)
+}
+
+func (s *Server) handleTelemetry(w http.ResponseWriter, r *http.Request) {
+	timeRange, err := parseRange(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid range", err)
+		return
+	}
+
+	resp, err := s.colony.QueryUnifiedSummary(r.Context(), connect.NewRequest(&colonyv1.QueryUnifiedSummaryRequest{
+		Service:   r.URL.Query().Get("service"),
+		TimeRange: timeRange.String(),
+	}))
+	if err != nil {
+		s.writeError(w, http.StatusBadGateway, "failed to query telemetry summary", err)
+		return
+	}
+	s.writeProto(w, resp.Msg)
+}
+
+func (s *Server) handleFlameGraph(w http.ResponseWriter, r *http.Request) {
+	service := r.URL.Query().Get("service")
+	if service == "" {
+		s.writeError(w, http.StatusBadRequest, "service is required", nil)
+		return
+	}
+
+	timeRange, err := parseRange(r)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid range", err)
+		return
+	}
+
+	end := time.Now()
+	resp, err := s.debug.QueryHistoricalCPUProfile(r.Context(), connect.NewRequest(&colonyv1.QueryHistoricalCPUProfileRequest{
+		ServiceName: service,
+		StartTime:   timestamppb.New(end.Add(-timeRange)),
+		EndTime:     timestamppb.New(end),
+	}))
+	if err != nil {
+		s.writeError(w, http.StatusBadGateway, "failed to query CPU profile", err)
+		return
+	}
+	if !resp.Msg.Success && resp.Msg.Error != "" {
+		s.writeError(w, http.StatusBadGateway, resp.Msg.Error, nil)
+		return
+	}
+
+	s.writeJSON(w, BuildFlameGraph(resp.Msg.Samples))
+}
+
+func (s *Server) handleApprovals(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.colony.ListMCPApprovals(r.Context(), connect.NewRequest(&colonyv1.ListMCPApprovalsRequest{
+		Status: r.URL.Query().Get("status"),
+	}))
+	if err != nil {
+		s.writeError(w, http.StatusBadGateway, "failed to list approvals", err)
+		return
+	}
+	s.writeProto(w, resp.Msg)
+}
+
+// approvalDecision is the body of an approve or deny request.
+type approvalDecision struct {
+	Reason string `json:"reason"`
+}
+
+// handleDecideApproval approves or denies a held MCP tool call. The request
+// must be JSON: browsers will not send that content type cross-origin without
+// a CORS preflight, which the dashboard never grants, so other sites cannot
+// approve calls on the operator's behalf.
+func (s *Server) handleDecideApproval(w http.ResponseWriter, r *http.Request) {
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		s.writeError(w, http.StatusUnsupportedMediaType, "request body must be application/json", nil)
+		return
+	}
+
+	var approve bool
+	switch r.PathValue("decision") {
+	case "approve":
+		approve = true
+	case "deny":
+	default:
+		s.writeError(w, http.StatusNotFound, "decision must be approve or deny", nil)
+		return
+	}
+
+	var body approvalDecision
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	resp, err := s.colony.DecideMCPApproval(r.Context(), connect.NewRequest(&colonyv1.DecideMCPApprovalRequest{
+		Id:      r.PathValue("id"),
+		Approve: approve,
+		Reason:  body.Reason,
+		User:    "dashboard",
+	}))
+	if err != nil {
+		switch connect.CodeOf(err) {
+		case connect.CodeNotFound:
+			s.writeError(w, http.StatusNotFound, "approval not found", err)
+		case connect.CodeFailedPrecondition:
+			s.writeError(w, http.StatusConflict, "approval is no longer pending", err)
+		default:
+			s.writeError(w, http.StatusBadGateway, "failed to decide approval", err)
+		}
+		return
+	}
+	s.writeProto(w, resp.Msg)
+}
+
+// parseRange returns the "range" query parameter, e.g. "15m" or "24h".
+func parseRange(r *http.Request) (time.Duration, error) {
+	value := r.URL.Query().Get("range")
+	if value == "" {
+		return defaultRange, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 || d > maxRange {
+		return 0, errRangeBounds
+	}
+	return d, nil
+}
+
+func (s *Server) writeProto(w http.ResponseWriter, msg proto.Message) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, "failed to encode response", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		s.logger.Debug().Err(err).Msg("Failed to write dashboard response")
+	}
+}
+
+// writeError reports a failed API request; err is logged but not returned to
+// the browser.
+func (s *Server) writeError(w http.ResponseWriter, status int, msg string, err error) {
+	if err != nil {
+		s.logger.Debug().Err(err).Msg(msg)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
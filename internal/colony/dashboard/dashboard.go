@@ -0,0 +1,128 @@
+// Package dashboard serves the colony web dashboard: an embedded single-page
+// UI and the JSON API it polls for agents, services, debug sessions,
+// telemetry summaries and CPU flame graphs. The only writes it accepts are
+// decisions on MCP tool calls awaiting approval.
+package dashboard
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+//go:embed web/index.html
+var indexHTML []byte
+
+// ColonyService is the part of the colony service the dashboard uses.
+type ColonyService interface {
+	ListAgents(context.Context, *connect.Request[colonyv1.ListAgentsRequest]) (*connect.Response[colonyv1.ListAgentsResponse], error)
+	ListServices(context.Context, *connect.Request[colonyv1.ListServicesRequest]) (*connect.Response[colonyv1.ListServicesResponse], error)
+	QueryUnifiedSummary(context.Context, *connect.Request[colonyv1.QueryUnifiedSummaryRequest]) (*connect.Response[colonyv1.QueryUnifiedSummaryResponse], error)
+	ListMCPApprovals(context.Context, *connect.Request[colonyv1.ListMCPApprovalsRequest]) (*connect.Response[colonyv1.ListMCPApprovalsResponse], error)
+	DecideMCPApproval(context.Context, *connect.Request[colonyv1.DecideMCPApprovalRequest]) (*connect.Response[colonyv1.DecideMCPApprovalResponse], error)
+}
+
+// DebugService is the part of the colony debug service the dashboard reads
+// from.
+type DebugService interface {
+	ListDebugSessions(context.Context, *connect.Request[colonyv1.ListDebugSessionsRequest]) (*connect.Response[colonyv1.ListDebugSessionsResponse], error)
+	QueryHistoricalCPUProfile(context.Context, *connect.Request[colonyv1.QueryHistoricalCPUProfileRequest]) (*connect.Response[colonyv1.QueryHistoricalCPUProfileResponse], error)
+}
+
+// Config contains dashboard server settings.
+type Config struct {
+	// Host is the address to listen on. The dashboard has no authentication,
+	// so it defaults to localhost.
+	Host string
+	Port int
+}
+
+// Server serves the colony dashboard.
+type Server struct {
+	cfg        Config
+	colony     ColonyService
+	debug      DebugService
+	logger     zerolog.Logger
+	httpServer *http.Server
+}
+
+// New creates a dashboard server.
+func New(cfg Config, colony ColonyService, debug DebugService, logger zerolog.Logger) *Server {
+	return &Server{
+		cfg:    cfg,
+		colony: colony,
+		debug:  debug,
+		logger: logger.With().Str("component", "dashboard").Logger(),
+	}
+}
+
+// Handler returns the dashboard's HTTP handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /api/agents", s.handleAgents)
+	mux.HandleFunc("GET /api/services", s.handleServices)
+	mux.HandleFunc("GET /api/sessions", s.handleSessions)
+	mux.HandleFunc("GET /api/telemetry", s.handleTelemetry)
+	mux.HandleFunc("GET /api/flamegraph", s.handleFlameGraph)
+	mux.HandleFunc("GET /api/approvals", s.handleApprovals)
+	mux.HandleFunc("POST /api/approvals/{id}/{decision}", s.handleDecideApproval)
+	return mux
+}
+
+// Start listens on the configured address and serves the dashboard in the
+// background.
+func (s *Server) Start() error {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s.httpServer = &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := s.httpServer.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error().Err(err).Msg("Dashboard server error")
+		}
+	}()
+
+	s.logger.Info().Str("url", s.URL()).Msg("Dashboard listening")
+	return nil
+}
+
+// Shutdown stops the dashboard server.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s.httpServer == nil {
+		return nil
+	}
+	return s.httpServer.Shutdown(ctx)
+}
+
+// URL returns the dashboard's base URL.
+func (s *Server) URL() string {
+	host := s.cfg.Host
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return fmt.Sprintf("http://%s", net.JoinHostPort(host, strconv.Itoa(s.cfg.Port)))
+}
+
+// handleIndex serves the embedded dashboard page.
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(indexHTML)
+}
@@ -0,0 +1,220 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+type fakeColony struct {
+	agents   []*colonyv1.Agent
+	decision *colonyv1.DecideMCPApprovalRequest
+}
+
+func (f *fakeColony) ListAgents(context.Context, *connect.Request[colonyv1.ListAgentsRequest]) (*connect.Response[colonyv1.ListAgentsResponse], error) {
+	return connect.NewResponse(&colonyv1.ListAgentsResponse{Agents: f.agents}), nil
+}
+
+func (f *fakeColony) ListServices(_ context.Context, req *connect.Request[colonyv1.ListServicesRequest]) (*connect.Response[colonyv1.ListServicesResponse], error) {
+	return connect.NewResponse(&colonyv1.ListServicesResponse{
+		Services: []*colonyv1.ServiceSummary{{Name: "api", Namespace: req.Msg.TimeRange}},
+	}), nil
+}
+
+func (f *fakeColony) QueryUnifiedSummary(context.Context, *connect.Request[colonyv1.QueryUnifiedSummaryRequest]) (*connect.Response[colonyv1.QueryUnifiedSummaryResponse], error) {
+	return nil, errors.New("database unavailable")
+}
+
+func (f *fakeColony) ListMCPApprovals(_ context.Context, req *connect.Request[colonyv1.ListMCPApprovalsRequest]) (*connect.Response[colonyv1.ListMCPApprovalsResponse], error) {
+	return connect.NewResponse(&colonyv1.ListMCPApprovalsResponse{
+		Approvals: []*colonyv1.MCPApproval{{Id: "a-1", Tool: "coral_shell_exec", Status: req.Msg.Status}},
+	}), nil
+}
+
+func (f *fakeColony) DecideMCPApproval(_ context.Context, req *connect.Request[colonyv1.DecideMCPApprovalRequest]) (*connect.Response[colonyv1.DecideMCPApprovalResponse], error) {
+	if req.Msg.Id != "a-1" {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("approval request not found"))
+	}
+	f.decision = req.Msg
+	return connect.NewResponse(&colonyv1.DecideMCPApprovalResponse{
+		Approval: &colonyv1.MCPApproval{Id: req.Msg.Id, Status: "denied"},
+	}), nil
+}
+
+type fakeDebug struct {
+	profileReq *colonyv1.QueryHistoricalCPUProfileRequest
+}
+
+func (f *fakeDebug) ListDebugSessions(context.Context, *connect.Request[colonyv1.ListDebugSessionsRequest]) (*connect.Response[colonyv1.ListDebugSessionsResponse], error) {
+	return connect.NewResponse(&colonyv1.ListDebugSessionsResponse{
+		Sessions: []*colonyv1.DebugSession{{SessionId: "s-1", ServiceName: "api", Status: "active"}},
+	}), nil
+}
+
+func (f *fakeDebug) QueryHistoricalCPUProfile(_ context.Context, req *connect.Request[colonyv1.QueryHistoricalCPUProfileRequest]) (*connect.Response[colonyv1.QueryHistoricalCPUProfileResponse], error) {
+	f.profileReq = req.Msg
+	return connect.NewResponse(&colonyv1.QueryHistoricalCPUProfileResponse{
+		Success: true,
+		Samples: []*agentv1.StackSample{
+			{FrameNames: []string{"work", "main"}, Count: 3},
+		},
+	}), nil
+}
+
+func newTestServer(t *testing.T) (*httptest.Server, *fakeColony, *fakeDebug) {
+	t.Helper()
+	colony := &fakeColony{agents: []*colonyv1.Agent{{AgentId: "agent-1", Status: "degraded", HealthReasons: []string{"clock skew 7s"}}}}
+	debug := &fakeDebug{}
+	srv := httptest.NewServer(New(Config{}, colony, debug, zerolog.Nop()).Handler())
+	t.Cleanup(srv.Close)
+	return srv, colony, debug
+}
+
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	return resp.StatusCode
+}
+
+func TestHandler_Index(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+
+	resp, err := http.Get(srv.URL + "/")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+
+	resp, err = http.Get(srv.URL + "/missing")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestHandler_API(t *testing.T) {
+	srv, _, debug := newTestServer(t)
+
+	var agents struct {
+		Agents []struct {
+			AgentID       string   `json:"agentId"`
+			HealthReasons []string `json:"healthReasons"`
+		} `json:"agents"`
+	}
+	assert.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/agents", &agents))
+	require.Len(t, agents.Agents, 1)
+	assert.Equal(t, "agent-1", agents.Agents[0].AgentID)
+	assert.Equal(t, []string{"clock skew 7s"}, agents.Agents[0].HealthReasons)
+
+	var services struct {
+		Services []struct {
+			Namespace string `json:"namespace"`
+		} `json:"services"`
+	}
+	assert.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/services?range=15m", &services))
+	require.Len(t, services.Services, 1)
+	assert.Equal(t, "15m0s", services.Services[0].Namespace, "range is passed to the colony")
+
+	var sessions struct {
+		Sessions []struct {
+			SessionID string `json:"sessionId"`
+		} `json:"sessions"`
+	}
+	assert.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/sessions", &sessions))
+	require.Len(t, sessions.Sessions, 1)
+
+	var apiErr map[string]string
+	assert.Equal(t, http.StatusBadGateway, getJSON(t, srv.URL+"/api/telemetry", &apiErr))
+	assert.Equal(t, "failed to query telemetry summary", apiErr["error"])
+
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, srv.URL+"/api/telemetry?range=-1h", &apiErr))
+	assert.Equal(t, http.StatusBadRequest, getJSON(t, srv.URL+"/api/flamegraph", &apiErr))
+
+	var flame FlameNode
+	assert.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/flamegraph?service=api&range=6h", &flame))
+	assert.Equal(t, uint64(3), flame.Value)
+	require.NotNil(t, debug.profileReq)
+	assert.Equal(t, "api", debug.profileReq.ServiceName)
+	assert.Equal(t, "6h0m0s", debug.profileReq.EndTime.AsTime().Sub(debug.profileReq.StartTime.AsTime()).String())
+}
+
+func TestHandler_Approvals(t *testing.T) {
+	srv, colony, _ := newTestServer(t)
+
+	var list struct {
+		Approvals []struct {
+			ID     string `json:"id"`
+			Status string `json:"status"`
+		} `json:"approvals"`
+	}
+	assert.Equal(t, http.StatusOK, getJSON(t, srv.URL+"/api/approvals?status=pending", &list))
+	require.Len(t, list.Approvals, 1)
+	assert.Equal(t, "pending", list.Approvals[0].Status)
+
+	post := func(path, contentType, body string) int {
+		resp, err := http.Post(srv.URL+path, contentType, strings.NewReader(body))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, post("/api/approvals/a-1/approve", "text/plain", `{}`),
+		"form and text posts are rejected so other sites cannot approve calls")
+	assert.Nil(t, colony.decision)
+
+	assert.Equal(t, http.StatusNotFound, post("/api/approvals/a-1/maybe", "application/json", `{}`))
+	assert.Equal(t, http.StatusNotFound, post("/api/approvals/a-2/approve", "application/json", `{}`))
+	assert.Equal(t, http.StatusBadRequest, post("/api/approvals/a-1/deny", "application/json", `not json`))
+
+	assert.Equal(t, http.StatusOK, post("/api/approvals/a-1/deny", "application/json; charset=utf-8", `{"reason":"too broad"}`))
+	require.NotNil(t, colony.decision)
+	assert.False(t, colony.decision.Approve)
+	assert.Equal(t, "too broad", colony.decision.Reason)
+	assert.Equal(t, "dashboard", colony.decision.User)
+}
+
+func TestBuildFlameGraph(t *testing.T) {
+	root := BuildFlameGraph([]*agentv1.StackSample{
+		{FrameNames: []string{"parse", "handle", "main"}, Count: 2},
+		{FrameNames: []string{"encode", "handle", "main"}, Count: 3},
+		{FrameNames: []string{"gc"}, Count: 1},
+		{FrameNames: []string{"idle"}, Count: 0},
+	})
+
+	assert.Equal(t, "all", root.Name)
+	assert.Equal(t, uint64(6), root.Value)
+	require.Len(t, root.Children, 2)
+
+	assert.Equal(t, "gc", root.Children[0].Name)
+	main := root.Children[1]
+	assert.Equal(t, "main", main.Name)
+	assert.Equal(t, uint64(5), main.Value)
+
+	require.Len(t, main.Children, 1)
+	handle := main.Children[0]
+	require.Len(t, handle.Children, 2)
+	assert.Equal(t, "encode", handle.Children[0].Name)
+	assert.Equal(t, uint64(3), handle.Children[0].Value)
+	assert.Equal(t, "parse", handle.Children[1].Name)
+	assert.Equal(t, uint64(2), handle.Children[1].Value)
+}
+
+func TestServer_URL(t *testing.T) {
+	assert.Equal(t, "http://127.0.0.1:3000", New(Config{Host: "127.0.0.1", Port: 3000}, nil, nil, zerolog.Nop()).URL())
+	assert.Equal(t, "http://localhost:3000", New(Config{Host: "0.0.0.0", Port: 3000}, nil, nil, zerolog.Nop()).URL())
+}
@@ -0,0 +1,66 @@
+package dashboard
+
+import (
+	"sort"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+)
+
+// FlameNode is a frame in a flame graph. Value is the number of samples in
+// which the frame appears with its parents as the calling stack.
+type FlameNode struct {
+	Name     string       `json:"name"`
+	Value    uint64       `json:"value"`
+	Children []*FlameNode `json:"children,omitempty"`
+
+	index map[string]*FlameNode
+}
+
+// BuildFlameGraph merges stack samples into a flame graph rooted at a node
+// named "all". Children are sorted by name, as is conventional for flame
+// graphs, so the layout is stable across refreshes.
+func BuildFlameGraph(samples []*agentv1.StackSample) *FlameNode {
+	root := &FlameNode{Name: "all"}
+
+	for _, sample := range samples {
+		if sample.Count == 0 {
+			continue
+		}
+		root.Value += sample.Count
+
+		// Frames are innermost first; the flame graph starts at the outermost.
+		node := root
+		for i := len(sample.FrameNames) - 1; i >= 0; i-- {
+			node = node.child(sample.FrameNames[i])
+			node.Value += sample.Count
+		}
+	}
+
+	root.sort()
+	return root
+}
+
+// child returns the child frame with the given name, creating it if needed.
+func (n *FlameNode) child(name string) *FlameNode {
+	if n.index == nil {
+		n.index = make(map[string]*FlameNode)
+	}
+	if c, ok := n.index[name]; ok {
+		return c
+	}
+	c := &FlameNode{Name: name}
+	n.index[name] = c
+	n.Children = append(n.Children, c)
+	return c
+}
+
+// sort orders children by name recursively and drops the lookup indexes.
+func (n *FlameNode) sort() {
+	n.index = nil
+	sort.Slice(n.Children, func(i, j int) bool {
+		return n.Children[i].Name < n.Children[j].Name
+	})
+	for _, c := range n.Children {
+		c.sort()
+	}
+}
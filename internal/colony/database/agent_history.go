@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Agent history event types.
+const (
+	AgentEventRegistered    = "registered"     // First registration seen by this colony.
+	AgentEventConnected     = "connected"      // Re-registration or heartbeat after a disconnect.
+	AgentEventDisconnected  = "disconnected"   // Agent stopped sending heartbeats.
+	AgentEventStatusChanged = "status_changed" // Status moved between healthy and degraded.
+	AgentEventRevoked       = "revoked"        // Agent certificates revoked, agent evicted.
+)
+
+// AgentHistoryEvent is a record of an agent connectivity or status change.
+type AgentHistoryEvent struct {
+	ID        int64     `duckdb:"-"` // Auto-increment, ignore in ORM
+	Timestamp time.Time `duckdb:"timestamp"`
+	AgentID   string    `duckdb:"agent_id"`
+	Event     string    `duckdb:"event"`
+	Status    string    `duckdb:"status"` // Agent status after the event.
+	MeshIPv4  string    `duckdb:"mesh_ipv4"`
+	Message   string    `duckdb:"message"`
+}
+
+// AgentHistoryFilters contains filters for listing agent history events.
+type AgentHistoryFilters struct {
+	Since   time.Time
+	AgentID string
+	Limit   int
+}
+
+// AgentHistorySummary summarizes the history of a single agent.
+type AgentHistorySummary struct {
+	AgentID string
+
+	// FirstSeen is the first event ever recorded for the agent, regardless of
+	// the window.
+	FirstSeen time.Time
+	LastEvent time.Time
+
+	// Counts of events within the window.
+	Connects      int
+	Disconnects   int
+	StatusChanges int
+}
+
+// InsertAgentHistoryEvent appends an event to the agent history.
+func (d *Database) InsertAgentHistoryEvent(ctx context.Context, event *AgentHistoryEvent) error {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	return d.agentHistoryTable.Insert(ctx, event)
+}
+
+// ListAgentHistoryEvents retrieves agent history events matching the provided
+// filters, newest first.
+func (d *Database) ListAgentHistoryEvents(ctx context.Context, filters AgentHistoryFilters) ([]*AgentHistoryEvent, error) {
+	query := `
+		SELECT id, timestamp, agent_id, event, status, mesh_ipv4, message
+		FROM agent_history
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if !filters.Since.IsZero() {
+		query += " AND timestamp >= ?"
+		args = append(args, filters.Since)
+	}
+
+	if filters.AgentID != "" {
+		query += " AND agent_id = ?"
+		args = append(args, filters.AgentID)
+	}
+
+	query += " ORDER BY timestamp DESC, id DESC"
+
+	if filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filters.Limit)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var events []*AgentHistoryEvent
+	for rows.Next() {
+		var event AgentHistoryEvent
+		var meshIPv4, message sql.NullString
+
+		if err := rows.Scan(
+			&event.ID,
+			&event.Timestamp,
+			&event.AgentID,
+			&event.Event,
+			&event.Status,
+			&meshIPv4,
+			&message,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan agent history event: %w", err)
+		}
+
+		event.MeshIPv4 = meshIPv4.String
+		event.Message = message.String
+
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agent history: %w", err)
+	}
+
+	return events, nil
+}
+
+// SummarizeAgentHistory returns per-agent event counts since the given time
+// for every agent with events in that window, ordered by first-seen time.
+func (d *Database) SummarizeAgentHistory(ctx context.Context, since time.Time, agentID string) ([]*AgentHistorySummary, error) {
+	query := `
+		SELECT
+			agent_id,
+			MIN(timestamp) AS first_seen,
+			MAX(timestamp) AS last_event,
+			COUNT(*) FILTER (WHERE timestamp >= ? AND event IN (?, ?)) AS connects,
+			COUNT(*) FILTER (WHERE timestamp >= ? AND event = ?) AS disconnects,
+			COUNT(*) FILTER (WHERE timestamp >= ? AND event = ?) AS status_changes
+		FROM agent_history
+	`
+	args := []interface{}{
+		since, AgentEventRegistered, AgentEventConnected,
+		since, AgentEventDisconnected,
+		since, AgentEventStatusChanged,
+	}
+
+	if agentID != "" {
+		query += " WHERE agent_id = ?"
+		args = append(args, agentID)
+	}
+
+	query += `
+		GROUP BY agent_id
+		HAVING MAX(timestamp) >= ?
+		ORDER BY first_seen, agent_id
+	`
+	args = append(args, since)
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize agent history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var summaries []*AgentHistorySummary
+	for rows.Next() {
+		var summary AgentHistorySummary
+		if err := rows.Scan(
+			&summary.AgentID,
+			&summary.FirstSeen,
+			&summary.LastEvent,
+			&summary.Connects,
+			&summary.Disconnects,
+			&summary.StatusChanges,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan agent history summary: %w", err)
+		}
+		summaries = append(summaries, &summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agent history summaries: %w", err)
+	}
+
+	return summaries, nil
+}
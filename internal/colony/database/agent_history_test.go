@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestAgentHistory(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	events := []*AgentHistoryEvent{
+		{Timestamp: now.Add(-10 * 24 * time.Hour), AgentID: "agent-1", Event: AgentEventRegistered, Status: "healthy", MeshIPv4: "100.64.0.2"},
+		{Timestamp: now.Add(-3 * time.Hour), AgentID: "agent-1", Event: AgentEventDisconnected, Status: "unhealthy"},
+		{Timestamp: now.Add(-2 * time.Hour), AgentID: "agent-1", Event: AgentEventConnected, Status: "healthy"},
+		{Timestamp: now.Add(-time.Hour), AgentID: "agent-1", Event: AgentEventStatusChanged, Status: "degraded", Message: "healthy -> degraded"},
+		{Timestamp: now.Add(-30 * time.Minute), AgentID: "agent-2", Event: AgentEventRegistered, Status: "healthy"},
+		{Timestamp: now.Add(-9 * 24 * time.Hour), AgentID: "agent-3", Event: AgentEventRegistered, Status: "healthy"},
+	}
+	for _, e := range events {
+		require.NoError(t, db.InsertAgentHistoryEvent(ctx, e))
+	}
+
+	t.Run("list events", func(t *testing.T) {
+		recent, err := db.ListAgentHistoryEvents(ctx, AgentHistoryFilters{Since: now.Add(-24 * time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, recent, 4)
+		assert.Equal(t, "agent-2", recent[0].AgentID, "newest first")
+		assert.NotZero(t, recent[0].ID)
+
+		byAgent, err := db.ListAgentHistoryEvents(ctx, AgentHistoryFilters{AgentID: "agent-1", Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, byAgent, 1)
+		assert.Equal(t, "healthy -> degraded", byAgent[0].Message)
+	})
+
+	t.Run("summarize", func(t *testing.T) {
+		summaries, err := db.SummarizeAgentHistory(ctx, now.Add(-7*24*time.Hour), "")
+		require.NoError(t, err)
+
+		// agent-3 has no events in the window.
+		require.Len(t, summaries, 2)
+
+		agent1 := summaries[0]
+		assert.Equal(t, "agent-1", agent1.AgentID)
+		assert.WithinDuration(t, now.Add(-10*24*time.Hour), agent1.FirstSeen, time.Second, "first seen ignores the window")
+		assert.Equal(t, 1, agent1.Connects)
+		assert.Equal(t, 1, agent1.Disconnects)
+		assert.Equal(t, 1, agent1.StatusChanges)
+
+		assert.Equal(t, "agent-2", summaries[1].AgentID)
+		assert.Equal(t, 1, summaries[1].Connects)
+
+		single, err := db.SummarizeAgentHistory(ctx, now.Add(-7*24*time.Hour), "agent-2")
+		require.NoError(t, err)
+		require.Len(t, single, 1)
+	})
+}
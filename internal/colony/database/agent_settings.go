@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AgentSettings holds the labels and drain state operators assign to an
+// agent through the colony, as opposed to what the agent reports itself.
+type AgentSettings struct {
+	AgentID   string    `duckdb:"agent_id,pk"`
+	Labels    string    `duckdb:"labels"` // JSON object.
+	Drained   bool      `duckdb:"drained"`
+	UpdatedAt time.Time `duckdb:"updated_at"`
+}
+
+// LabelMap decodes the settings' labels.
+func (s *AgentSettings) LabelMap() (map[string]string, error) {
+	labels := map[string]string{}
+	if s.Labels == "" {
+		return labels, nil
+	}
+	if err := json.Unmarshal([]byte(s.Labels), &labels); err != nil {
+		return nil, fmt.Errorf("failed to parse labels of agent %s: %w", s.AgentID, err)
+	}
+	return labels, nil
+}
+
+// UpsertAgentSettings stores the labels and drain state of an agent.
+func (d *Database) UpsertAgentSettings(ctx context.Context, agentID string, labels map[string]string, drained bool) error {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	data, err := json.Marshal(labels)
+	if err != nil {
+		return fmt.Errorf("failed to encode labels: %w", err)
+	}
+	return d.agentSettingsTable.Upsert(ctx, &AgentSettings{
+		AgentID:   agentID,
+		Labels:    string(data),
+		Drained:   drained,
+		UpdatedAt: time.Now(),
+	})
+}
+
+// ListAgentSettings retrieves the settings of all agents.
+func (d *Database) ListAgentSettings(ctx context.Context) ([]*AgentSettings, error) {
+	settings, err := d.agentSettingsTable.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent settings: %w", err)
+	}
+	return settings, nil
+}
+
+// DeleteAgentSettings removes the settings of an agent.
+func (d *Database) DeleteAgentSettings(ctx context.Context, agentID string) error {
+	if err := d.agentSettingsTable.Delete(ctx, agentID); err != nil {
+		return fmt.Errorf("failed to delete agent settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestAgentSettings(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	require.NoError(t, db.UpsertAgentSettings(ctx, "agent-1", map[string]string{"env": "prod"}, false))
+	require.NoError(t, db.UpsertAgentSettings(ctx, "agent-2", nil, true))
+
+	// Upserting replaces the previous settings.
+	require.NoError(t, db.UpsertAgentSettings(ctx, "agent-1", map[string]string{"env": "prod", "region": "us-east"}, true))
+
+	settings, err := db.ListAgentSettings(ctx)
+	require.NoError(t, err)
+	require.Len(t, settings, 2)
+
+	byAgent := map[string]*AgentSettings{}
+	for _, s := range settings {
+		byAgent[s.AgentID] = s
+	}
+
+	labels, err := byAgent["agent-1"].LabelMap()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "region": "us-east"}, labels)
+	assert.True(t, byAgent["agent-1"].Drained)
+
+	labels, err = byAgent["agent-2"].LabelMap()
+	require.NoError(t, err)
+	assert.Empty(t, labels)
+	assert.True(t, byAgent["agent-2"].Drained)
+
+	require.NoError(t, db.DeleteAgentSettings(ctx, "agent-1"))
+	settings, err = db.ListAgentSettings(ctx)
+	require.NoError(t, err)
+	require.Len(t, settings, 1)
+	assert.Equal(t, "agent-2", settings[0].AgentID)
+}
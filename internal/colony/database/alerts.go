@@ -0,0 +1,402 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AlertRule is a rule that fires when a service metric exceeds a threshold.
+type AlertRule struct {
+	ID              string     `duckdb:"id,pk"`
+	Name            string     `duckdb:"name,immutable"`
+	ServiceName     string     `duckdb:"service_name,immutable"` // Empty applies to every service.
+	Metric          string     `duckdb:"metric,immutable"`
+	Threshold       float64    `duckdb:"threshold,immutable"`
+	WindowSeconds   int64      `duckdb:"window_seconds,immutable"`
+	Sinks           string     `duckdb:"sinks,immutable"` // Comma-separated sink names.
+	Enabled         bool       `duckdb:"enabled"`
+	CreatedAt       time.Time  `duckdb:"created_at,immutable"`
+	LastEvaluatedAt *time.Time `duckdb:"last_evaluated_at"`
+}
+
+// Window returns the time window the rule's metric is computed over.
+func (r *AlertRule) Window() time.Duration {
+	return time.Duration(r.WindowSeconds) * time.Second
+}
+
+// SinkNames returns the names of the rule's notification sinks.
+func (r *AlertRule) SinkNames() []string {
+	if r.Sinks == "" {
+		return nil
+	}
+	return strings.Split(r.Sinks, ",")
+}
+
+// AlertFiring records that an alert rule is firing for a service. Rows are
+// removed when the alert resolves.
+type AlertFiring struct {
+	RuleID      string    `duckdb:"rule_id,pk"`
+	ServiceName string    `duckdb:"service_name,pk"`
+	Value       float64   `duckdb:"value"`
+	Since       time.Time `duckdb:"since,immutable"`
+}
+
+// InsertAlertRule persists a new alert rule.
+func (d *Database) InsertAlertRule(ctx context.Context, rule *AlertRule) error {
+	return d.alertRulesTable.Insert(ctx, rule)
+}
+
+// ListAlertRules retrieves alert rules, oldest first. If enabledOnly is set,
+// disabled rules are skipped.
+func (d *Database) ListAlertRules(ctx context.Context, enabledOnly bool) ([]*AlertRule, error) {
+	query := `
+		SELECT id, name, service_name, metric, threshold, window_seconds,
+		       sinks, enabled, created_at, last_evaluated_at
+		FROM alert_rules
+	`
+	if enabledOnly {
+		query += " WHERE enabled"
+	}
+	query += " ORDER BY created_at, id"
+
+	rows, err := d.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list alert rules: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var rules []*AlertRule
+	for rows.Next() {
+		var rule AlertRule
+		var serviceName, sinks sql.NullString
+		var lastEvaluatedAt sql.NullTime
+
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.Name,
+			&serviceName,
+			&rule.Metric,
+			&rule.Threshold,
+			&rule.WindowSeconds,
+			&sinks,
+			&rule.Enabled,
+			&rule.CreatedAt,
+			&lastEvaluatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan alert rule: %w", err)
+		}
+
+		rule.ServiceName = serviceName.String
+		rule.Sinks = sinks.String
+		if lastEvaluatedAt.Valid {
+			rule.LastEvaluatedAt = &lastEvaluatedAt.Time
+		}
+
+		rules = append(rules, &rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating alert rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// SetAlertRuleEnabled enables or disables an alert rule. Disabling a rule
+// clears its firing state. It returns sql.ErrNoRows if the rule does not
+// exist.
+func (d *Database) SetAlertRuleEnabled(ctx context.Context, id string, enabled bool) error {
+	result, err := d.db.ExecContext(ctx, `UPDATE alert_rules SET enabled = ? WHERE id = ?`, enabled, id)
+	if err != nil {
+		return fmt.Errorf("failed to update alert rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if !enabled {
+		if _, err := d.db.ExecContext(ctx, `DELETE FROM alert_firing WHERE rule_id = ?`, id); err != nil {
+			return fmt.Errorf("failed to clear alert state: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// MarkAlertRuleEvaluated records when an alert rule was last evaluated.
+func (d *Database) MarkAlertRuleEvaluated(ctx context.Context, id string, at time.Time) error {
+	return d.alertRulesTable.UpdateFields(ctx, id, map[string]interface{}{
+		"last_evaluated_at": at,
+	})
+}
+
+// DeleteAlertRule removes an alert rule and its firing state. It returns
+// sql.ErrNoRows if the rule does not exist.
+func (d *Database) DeleteAlertRule(ctx context.Context, id string) error {
+	result, err := d.db.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := d.db.ExecContext(ctx, `DELETE FROM alert_firing WHERE rule_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to clear alert state: %w", err)
+	}
+
+	return nil
+}
+
+// ListAlertFiring retrieves the services alert rules are firing for, keyed
+// by rule ID.
+func (d *Database) ListAlertFiring(ctx context.Context) (map[string][]*AlertFiring, error) {
+	firing, err := d.alertFiringTable.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list firing alerts: %w", err)
+	}
+
+	byRule := make(map[string][]*AlertFiring)
+	for _, f := range firing {
+		byRule[f.RuleID] = append(byRule[f.RuleID], f)
+	}
+	for _, list := range byRule {
+		sort.Slice(list, func(i, j int) bool { return list[i].ServiceName < list[j].ServiceName })
+	}
+	return byRule, nil
+}
+
+// UpsertAlertFiring records that a rule is firing for a service, or updates
+// the latest value if it already is.
+func (d *Database) UpsertAlertFiring(ctx context.Context, firing *AlertFiring) error {
+	return d.alertFiringTable.Upsert(ctx, firing)
+}
+
+// DeleteAlertFiring records that a rule resolved for a service.
+func (d *Database) DeleteAlertFiring(ctx context.Context, ruleID, serviceName string) error {
+	_, err := d.db.ExecContext(ctx, `DELETE FROM alert_firing WHERE rule_id = ? AND service_name = ?`, ruleID, serviceName)
+	if err != nil {
+		return fmt.Errorf("failed to delete firing alert: %w", err)
+	}
+	return nil
+}
+
+// QueryServiceLatencyP95 returns the p95 request latency in milliseconds of
+// each service since the given time, optionally for a single service. It is
+// computed from the eBPF HTTP latency histograms and the OTLP span summaries;
+// when a service has both, the higher value is returned.
+func (d *Database) QueryServiceLatencyP95(ctx context.Context, serviceName string, since time.Time) (map[string]float64, error) {
+	query := `
+		SELECT service_name, latency_bucket_ms, SUM(count)
+		FROM beyla_http_metrics
+		WHERE timestamp >= ?
+	`
+	args := []interface{}{since}
+	if serviceName != "" {
+		query += " AND service_name = ?"
+		args = append(args, serviceName)
+	}
+	query += " GROUP BY service_name, latency_bucket_ms ORDER BY service_name, latency_bucket_ms"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latency histograms: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	histograms := make(map[string][]latencyBucket)
+	for rows.Next() {
+		var service string
+		var b latencyBucket
+		if err := rows.Scan(&service, &b.upperMs, &b.count); err != nil {
+			return nil, fmt.Errorf("failed to scan latency histogram: %w", err)
+		}
+		histograms[service] = append(histograms[service], b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating latency histograms: %w", err)
+	}
+
+	p95 := make(map[string]float64)
+	for service, buckets := range histograms {
+		if value, ok := histogramQuantile(buckets, 0.95); ok {
+			p95[service] = value
+		}
+	}
+
+	otelQuery := `
+		SELECT service_name, MAX(p95_ms)
+		FROM otel_summaries
+		WHERE bucket_time >= ? AND total_spans > 0
+	`
+	otelArgs := []interface{}{since}
+	if serviceName != "" {
+		otelQuery += " AND service_name = ?"
+		otelArgs = append(otelArgs, serviceName)
+	}
+	otelQuery += " GROUP BY service_name"
+
+	otelRows, err := d.db.QueryContext(ctx, otelQuery, otelArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query span latency: %w", err)
+	}
+	defer func() { _ = otelRows.Close() }()
+
+	for otelRows.Next() {
+		var service string
+		var value sql.NullFloat64
+		if err := otelRows.Scan(&service, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan span latency: %w", err)
+		}
+		if value.Valid && value.Float64 > p95[service] {
+			p95[service] = value.Float64
+		}
+	}
+	if err := otelRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating span latency: %w", err)
+	}
+
+	return p95, nil
+}
+
+// latencyBucket is a bucket of an eBPF latency histogram.
+type latencyBucket struct {
+	upperMs float64
+	count   int64
+}
+
+// histogramQuantile returns the upper bound of the bucket holding quantile q
+// of buckets, which are sorted by upper bound. It returns false for an empty
+// histogram.
+func histogramQuantile(buckets []latencyBucket, q float64) (float64, bool) {
+	var total int64
+	for _, b := range buckets {
+		total += b.count
+	}
+	if total == 0 {
+		return 0, false
+	}
+
+	target := float64(total) * q
+	var cumulative int64
+	for _, b := range buckets {
+		cumulative += b.count
+		if float64(cumulative) >= target {
+			return b.upperMs, true
+		}
+	}
+	return buckets[len(buckets)-1].upperMs, true
+}
+
+// QueryServiceErrorRates returns the percentage of failed requests of each
+// service since the given time, optionally for a single service. eBPF HTTP
+// requests with a 5xx status and OTLP error spans count as failed.
+func (d *Database) QueryServiceErrorRates(ctx context.Context, serviceName string, since time.Time) (map[string]float64, error) {
+	serviceFilter := ""
+	args := []interface{}{since}
+	if serviceName != "" {
+		serviceFilter = " AND service_name = ?"
+		args = append(args, serviceName)
+	}
+	args = append(args, args...)
+
+	query := fmt.Sprintf(`
+		SELECT service_name, SUM(requests), SUM(errors)
+		FROM (
+			SELECT service_name, SUM(count) AS requests,
+			       SUM(CASE WHEN http_status_code >= 500 THEN count ELSE 0 END) AS errors
+			FROM beyla_http_metrics
+			WHERE timestamp >= ?%[1]s
+			GROUP BY service_name
+			UNION ALL
+			SELECT service_name, SUM(total_spans) AS requests, SUM(error_count) AS errors
+			FROM otel_summaries
+			WHERE bucket_time >= ?%[1]s
+			GROUP BY service_name
+		)
+		GROUP BY service_name
+	`, serviceFilter)
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query error rates: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	rates := make(map[string]float64)
+	for rows.Next() {
+		var service string
+		var requests, errors sql.NullFloat64
+		if err := rows.Scan(&service, &requests, &errors); err != nil {
+			return nil, fmt.Errorf("failed to scan error rate: %w", err)
+		}
+		if requests.Float64 > 0 {
+			rates[service] = errors.Float64 / requests.Float64 * 100
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating error rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+// QueryServiceHeapGrowth returns, for each service, the percentage growth of
+// bytes allocated in the last window over the window before it, from the
+// continuous memory profiles. Services without allocations in the previous
+// window are omitted.
+func (d *Database) QueryServiceHeapGrowth(ctx context.Context, serviceName string, now time.Time, window time.Duration) (map[string]float64, error) {
+	start := now.Add(-2 * window)
+	mid := now.Add(-window)
+
+	query := `
+		SELECT service_name,
+		       SUM(CASE WHEN timestamp >= ? THEN alloc_bytes ELSE 0 END) AS current_bytes,
+		       SUM(CASE WHEN timestamp < ? THEN alloc_bytes ELSE 0 END) AS previous_bytes
+		FROM memory_profile_summaries
+		WHERE timestamp >= ? AND timestamp <= ?
+	`
+	args := []interface{}{mid, mid, start, now}
+	if serviceName != "" {
+		query += " AND service_name = ?"
+		args = append(args, serviceName)
+	}
+	query += " GROUP BY service_name"
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query heap growth: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	growth := make(map[string]float64)
+	for rows.Next() {
+		var service string
+		var current, previous sql.NullFloat64
+		if err := rows.Scan(&service, &current, &previous); err != nil {
+			return nil, fmt.Errorf("failed to scan heap growth: %w", err)
+		}
+		if previous.Float64 > 0 {
+			growth[service] = (current.Float64 - previous.Float64) / previous.Float64 * 100
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating heap growth: %w", err)
+	}
+
+	return growth, nil
+}
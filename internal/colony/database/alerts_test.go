@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestAlertRules(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, db.InsertAlertRule(ctx, &AlertRule{
+		ID: "r-1", Name: "api latency", ServiceName: "api", Metric: "latency_p95",
+		Threshold: 500, WindowSeconds: 300, Sinks: "slack,oncall", Enabled: true, CreatedAt: now,
+	}))
+	require.NoError(t, db.InsertAlertRule(ctx, &AlertRule{
+		ID: "r-2", Name: "errors", Metric: "error_rate",
+		Threshold: 5, WindowSeconds: 600, Enabled: true, CreatedAt: now.Add(time.Second),
+	}))
+
+	rules, err := db.ListAlertRules(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "r-1", rules[0].ID)
+	assert.Equal(t, []string{"slack", "oncall"}, rules[0].SinkNames())
+	assert.Equal(t, 5*time.Minute, rules[0].Window())
+	assert.Empty(t, rules[1].SinkNames())
+	assert.Nil(t, rules[1].LastEvaluatedAt)
+
+	require.NoError(t, db.MarkAlertRuleEvaluated(ctx, "r-2", now))
+	require.NoError(t, db.UpsertAlertFiring(ctx, &AlertFiring{RuleID: "r-2", ServiceName: "web", Value: 7, Since: now}))
+	require.NoError(t, db.UpsertAlertFiring(ctx, &AlertFiring{RuleID: "r-2", ServiceName: "api", Value: 9, Since: now}))
+	require.NoError(t, db.UpsertAlertFiring(ctx, &AlertFiring{RuleID: "r-2", ServiceName: "api", Value: 12, Since: now.Add(time.Minute)}))
+
+	firing, err := db.ListAlertFiring(ctx)
+	require.NoError(t, err)
+	require.Len(t, firing["r-2"], 2)
+	assert.Equal(t, "api", firing["r-2"][0].ServiceName)
+	assert.Equal(t, 12.0, firing["r-2"][0].Value)
+	assert.WithinDuration(t, now, firing["r-2"][0].Since, time.Millisecond, "firing start is kept on update")
+
+	require.NoError(t, db.DeleteAlertFiring(ctx, "r-2", "web"))
+	require.NoError(t, db.SetAlertRuleEnabled(ctx, "r-2", false))
+	assert.ErrorIs(t, db.SetAlertRuleEnabled(ctx, "missing", true), sql.ErrNoRows)
+
+	enabled, err := db.ListAlertRules(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, enabled, 1)
+	assert.Equal(t, "r-1", enabled[0].ID)
+
+	firing, err = db.ListAlertFiring(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, firing, "disabling a rule clears its firing state")
+
+	require.NoError(t, db.DeleteAlertRule(ctx, "r-1"))
+	assert.ErrorIs(t, db.DeleteAlertRule(ctx, "r-1"), sql.ErrNoRows)
+}
+
+func TestAlertMetricQueries(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+	recent := now.Add(-time.Minute)
+
+	// api: 90 fast requests, 5 slow and 5 failing.
+	http := []struct {
+		service string
+		status  int
+		bucket  float64
+		count   int
+	}{
+		{"api", 200, 10, 90},
+		{"api", 200, 1000, 5},
+		{"api", 503, 2500, 5},
+		{"web", 200, 50, 10},
+	}
+	for _, m := range http {
+		_, err := db.db.ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', ?, 'GET', '/', ?, ?, ?)
+		`, recent, m.service, m.status, m.bucket, m.count)
+		require.NoError(t, err)
+	}
+
+	// web spans: slower than its HTTP histogram and with errors.
+	_, err = db.db.ExecContext(ctx, `
+		INSERT INTO otel_summaries (bucket_time, agent_id, service_name, span_kind, p95_ms, error_count, total_spans)
+		VALUES (?, 'agent-1', 'web', 'SERVER', 120, 3, 10)
+	`, recent)
+	require.NoError(t, err)
+
+	p95, err := db.QueryServiceLatencyP95(ctx, "", now.Add(-5*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, p95["api"])
+	assert.Equal(t, 120.0, p95["web"], "span p95 wins when higher")
+
+	p95, err = db.QueryServiceLatencyP95(ctx, "api", now.Add(-5*time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, p95, 1)
+
+	rates, err := db.QueryServiceErrorRates(ctx, "", now.Add(-5*time.Minute))
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0, rates["api"], 0.001)
+	assert.InDelta(t, 15.0, rates["web"], 0.001)
+
+	rates, err = db.QueryServiceErrorRates(ctx, "", now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, rates, "no data outside the window")
+
+	for i, m := range []struct {
+		at    time.Time
+		bytes int64
+	}{
+		{now.Add(-8 * time.Minute), 1000},
+		{now.Add(-2 * time.Minute), 1500},
+	} {
+		_, err := db.db.ExecContext(ctx, `
+			INSERT INTO memory_profile_summaries (timestamp, agent_id, service_name, build_id, stack_hash, stack_frame_ids, alloc_bytes, alloc_objects)
+			VALUES (?, 'agent-1', 'api', 'build-1', ?, [1, 2], ?, 1)
+		`, m.at, string(rune('a'+i)), m.bytes)
+		require.NoError(t, err)
+	}
+
+	growth, err := db.QueryServiceHeapGrowth(ctx, "api", now, 5*time.Minute)
+	require.NoError(t, err)
+	assert.InDelta(t, 50.0, growth["api"], 0.001)
+}
@@ -0,0 +1,224 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Metrics of service routes scored by the anomaly detector.
+const (
+	// AnomalyMetricLatencyP95 is the p95 request latency in milliseconds.
+	AnomalyMetricLatencyP95 = "latency_p95"
+
+	// AnomalyMetricErrorRate is the percentage of failed requests.
+	AnomalyMetricErrorRate = "error_rate"
+)
+
+// RouteKey identifies an HTTP route ("GET /orders") or gRPC method of a
+// service.
+type RouteKey struct {
+	Service string
+	Route   string
+}
+
+// AnomalyBaseline is the usual value of a route metric at an hour of the day
+// (UTC): the mean and standard deviation of its hourly values over the
+// learning period.
+type AnomalyBaseline struct {
+	ServiceName string    `duckdb:"service_name,pk"`
+	Route       string    `duckdb:"route,pk"`
+	Metric      string    `duckdb:"metric,pk"`
+	HourOfDay   int       `duckdb:"hour_of_day,pk"`
+	Mean        float64   `duckdb:"mean"`
+	Stddev      float64   `duckdb:"stddev"`
+	Samples     int       `duckdb:"samples"` // Hours the baseline was learned from.
+	LearnedAt   time.Time `duckdb:"learned_at"`
+}
+
+// AnomalyScore is the deviation of a route metric over a window from its
+// baseline, in standard deviations.
+type AnomalyScore struct {
+	Timestamp   time.Time `duckdb:"timestamp,pk"` // End of the scored window.
+	ServiceName string    `duckdb:"service_name,pk"`
+	Route       string    `duckdb:"route,pk"`
+	Metric      string    `duckdb:"metric,pk"`
+	Value       float64   `duckdb:"value"`
+	Mean        float64   `duckdb:"mean"`
+	Stddev      float64   `duckdb:"stddev"`
+	Score       float64   `duckdb:"score"`
+	Anomalous   bool      `duckdb:"anomalous"`
+}
+
+// AnomalyScoreFilter selects anomaly scores.
+type AnomalyScoreFilter struct {
+	ServiceName string // Empty selects every service.
+	Since       time.Time
+
+	// AnomalousOnly skips the scores of metrics within their usual range.
+	AnomalousOnly bool
+
+	// Limit bounds the number of scores returned; 0 returns all of them.
+	Limit int
+}
+
+// QueryRouteSeries returns the request metrics of each HTTP route and gRPC
+// method over consecutive intervals of length step from start to end. The
+// slice of a route has one entry per interval; intervals without requests
+// have zero stats. Long ranges are read from the Beyla metric rollups.
+func (d *Database) QueryRouteSeries(ctx context.Context, start, end time.Time, step time.Duration) (map[RouteKey][]ServiceWindowStats, error) {
+	if step <= 0 || !end.After(start) {
+		return nil, fmt.Errorf("invalid series: step %s from %s to %s", step, start, end)
+	}
+	intervals := int((end.Sub(start) + step - 1) / step)
+
+	rows, err := d.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT service_name, route,
+		       CAST(FLOOR((epoch_ms(timestamp) - ?) / ?) AS BIGINT) AS interval_index,
+		       latency_bucket_ms, SUM(count), SUM(errors)
+		FROM (
+			SELECT timestamp, service_name,
+			       TRIM(COALESCE(http_method, '') || ' ' || COALESCE(http_route, '')) AS route,
+			       latency_bucket_ms, count,
+			       CASE WHEN http_status_code >= 500 THEN count ELSE 0 END AS errors
+			FROM %s
+			UNION ALL
+			SELECT timestamp, service_name, COALESCE(grpc_method, '') AS route,
+			       latency_bucket_ms, count,
+			       CASE WHEN grpc_status_code != 0 THEN count ELSE 0 END AS errors
+			FROM %s
+		)
+		WHERE timestamp >= ? AND timestamp < ?
+		GROUP BY 1, 2, 3, 4
+		ORDER BY 1, 2, 3, 4
+	`, d.beylaMetricsSource(ctx, "beyla_http_metrics", start, end), d.beylaMetricsSource(ctx, "beyla_grpc_metrics", start, end)),
+		start.UnixMilli(), float64(step.Milliseconds()), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query route metrics: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	series := make(map[RouteKey][]ServiceWindowStats)
+	histograms := make(map[RouteKey]map[int][]latencyBucket)
+	for rows.Next() {
+		var key RouteKey
+		var index int
+		var b latencyBucket
+		var errors int64
+		if err := rows.Scan(&key.Service, &key.Route, &index, &b.upperMs, &b.count, &errors); err != nil {
+			return nil, fmt.Errorf("failed to scan route metrics: %w", err)
+		}
+		if index < 0 || index >= intervals {
+			continue
+		}
+		if series[key] == nil {
+			series[key] = make([]ServiceWindowStats, intervals)
+			histograms[key] = make(map[int][]latencyBucket)
+		}
+		series[key][index].Requests += b.count
+		series[key][index].Errors += errors
+		histograms[key][index] = append(histograms[key][index], b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating route metrics: %w", err)
+	}
+
+	for key, byIndex := range histograms {
+		for index, buckets := range byIndex {
+			stats := &series[key][index]
+			stats.P50Ms, _ = histogramQuantile(buckets, 0.50)
+			stats.P95Ms, _ = histogramQuantile(buckets, 0.95)
+			stats.P99Ms, _ = histogramQuantile(buckets, 0.99)
+		}
+	}
+	return series, nil
+}
+
+// ReplaceAnomalyBaselines replaces all learned baselines with baselines.
+func (d *Database) ReplaceAnomalyBaselines(ctx context.Context, baselines []*AnomalyBaseline) error {
+	if _, err := d.db.ExecContext(ctx, `DELETE FROM anomaly_baselines`); err != nil {
+		return fmt.Errorf("failed to clear anomaly baselines: %w", err)
+	}
+	if err := d.anomalyBaselinesTable.BatchUpsert(ctx, baselines); err != nil {
+		return fmt.Errorf("failed to store anomaly baselines: %w", err)
+	}
+	return nil
+}
+
+// ListAnomalyBaselines returns the baselines of an hour of the day, keyed by
+// route and metric.
+func (d *Database) ListAnomalyBaselines(ctx context.Context, hourOfDay int) (map[RouteKey]map[string]*AnomalyBaseline, error) {
+	baselines, err := d.anomalyBaselinesTable.List(ctx, map[string]interface{}{"hour_of_day": hourOfDay})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list anomaly baselines: %w", err)
+	}
+
+	byRoute := make(map[RouteKey]map[string]*AnomalyBaseline)
+	for _, b := range baselines {
+		key := RouteKey{Service: b.ServiceName, Route: b.Route}
+		if byRoute[key] == nil {
+			byRoute[key] = make(map[string]*AnomalyBaseline)
+		}
+		byRoute[key][b.Metric] = b
+	}
+	return byRoute, nil
+}
+
+// InsertAnomalyScores stores the scores of a window.
+func (d *Database) InsertAnomalyScores(ctx context.Context, scores []*AnomalyScore) error {
+	if err := d.anomalyScoresTable.BatchUpsert(ctx, scores); err != nil {
+		return fmt.Errorf("failed to store anomaly scores: %w", err)
+	}
+	return nil
+}
+
+// QueryAnomalyScores returns the anomaly scores matching filter, newest
+// first and, within a window, highest deviation first.
+func (d *Database) QueryAnomalyScores(ctx context.Context, filter AnomalyScoreFilter) ([]*AnomalyScore, error) {
+	query := `
+		SELECT timestamp, service_name, route, metric, value, mean, stddev, score, anomalous
+		FROM anomaly_scores
+		WHERE timestamp >= ? AND (? = '' OR service_name = ?)
+	`
+	args := []interface{}{filter.Since, filter.ServiceName, filter.ServiceName}
+	if filter.AnomalousOnly {
+		query += " AND anomalous"
+	}
+	query += " ORDER BY timestamp DESC, ABS(score) DESC, service_name, route, metric"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query anomaly scores: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var scores []*AnomalyScore
+	for rows.Next() {
+		var s AnomalyScore
+		if err := rows.Scan(&s.Timestamp, &s.ServiceName, &s.Route, &s.Metric, &s.Value, &s.Mean, &s.Stddev, &s.Score, &s.Anomalous); err != nil {
+			return nil, fmt.Errorf("failed to scan anomaly score: %w", err)
+		}
+		scores = append(scores, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating anomaly scores: %w", err)
+	}
+	return scores, nil
+}
+
+// AnomalyBaselinesLearnedAt returns when the baselines were last learned, or
+// the zero time if they never were.
+func (d *Database) AnomalyBaselinesLearnedAt(ctx context.Context) (time.Time, error) {
+	var learnedAt *time.Time
+	if err := d.db.QueryRowContext(ctx, `SELECT MAX(learned_at) FROM anomaly_baselines`).Scan(&learnedAt); err != nil {
+		return time.Time{}, fmt.Errorf("failed to query anomaly baselines: %w", err)
+	}
+	if learnedAt == nil {
+		return time.Time{}, nil
+	}
+	return *learnedAt, nil
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestQueryRouteSeries(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	start := time.Now().Add(-3 * time.Hour).Truncate(time.Hour)
+
+	for _, m := range []struct {
+		at     time.Time
+		route  string
+		status int
+		bucket float64
+		count  int
+	}{
+		{start.Add(time.Minute), "/orders", 200, 10, 90},
+		{start.Add(2 * time.Minute), "/orders", 503, 100, 10},
+		{start.Add(2 * time.Hour), "/orders", 200, 50, 20},
+		{start.Add(2 * time.Hour), "/cart", 200, 5, 40},
+	} {
+		_, err := db.db.ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', 'api', 'GET', ?, ?, ?, ?)
+		`, m.at, m.route, m.status, m.bucket, m.count)
+		require.NoError(t, err)
+	}
+	_, err = db.db.ExecContext(ctx, `
+		INSERT INTO beyla_grpc_metrics (timestamp, agent_id, service_name, grpc_method, grpc_status_code, latency_bucket_ms, count)
+		VALUES (?, 'agent-1', 'payments', '/payments.v1.Payments/Charge', 14, 25, 4), (?, 'agent-1', 'payments', '/payments.v1.Payments/Charge', 0, 25, 16)
+	`, start.Add(time.Hour), start.Add(time.Hour))
+	require.NoError(t, err)
+
+	series, err := db.QueryRouteSeries(ctx, start, start.Add(3*time.Hour), time.Hour)
+	require.NoError(t, err)
+	require.Len(t, series, 3)
+
+	orders := series[RouteKey{Service: "api", Route: "GET /orders"}]
+	require.Len(t, orders, 3)
+	assert.Equal(t, int64(100), orders[0].Requests)
+	assert.Equal(t, int64(10), orders[0].Errors)
+	assert.Equal(t, 100.0, orders[0].P95Ms)
+	assert.Zero(t, orders[1].Requests)
+	assert.Equal(t, int64(20), orders[2].Requests)
+
+	charge := series[RouteKey{Service: "payments", Route: "/payments.v1.Payments/Charge"}]
+	require.Len(t, charge, 3)
+	assert.Equal(t, int64(20), charge[1].Requests)
+	assert.InDelta(t, 20.0, charge[1].ErrorRate(), 0.001)
+
+	_, err = db.QueryRouteSeries(ctx, start, start, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestAnomalyBaselinesAndScores(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	learnedAt, err := db.AnomalyBaselinesLearnedAt(ctx)
+	require.NoError(t, err)
+	assert.True(t, learnedAt.IsZero())
+
+	require.NoError(t, db.ReplaceAnomalyBaselines(ctx, []*AnomalyBaseline{
+		{ServiceName: "api", Route: "GET /orders", Metric: AnomalyMetricLatencyP95, HourOfDay: 9, Mean: 100, Stddev: 10, Samples: 7, LearnedAt: now},
+		{ServiceName: "api", Route: "GET /orders", Metric: AnomalyMetricErrorRate, HourOfDay: 9, Mean: 1, Stddev: 0.5, Samples: 7, LearnedAt: now},
+		{ServiceName: "api", Route: "GET /orders", Metric: AnomalyMetricErrorRate, HourOfDay: 10, Mean: 2, Stddev: 1, Samples: 7, LearnedAt: now},
+	}))
+	// Learning again replaces every baseline.
+	require.NoError(t, db.ReplaceAnomalyBaselines(ctx, []*AnomalyBaseline{
+		{ServiceName: "api", Route: "GET /orders", Metric: AnomalyMetricLatencyP95, HourOfDay: 9, Mean: 120, Stddev: 12, Samples: 7, LearnedAt: now},
+	}))
+
+	baselines, err := db.ListAnomalyBaselines(ctx, 9)
+	require.NoError(t, err)
+	require.Len(t, baselines, 1)
+	orders := baselines[RouteKey{Service: "api", Route: "GET /orders"}]
+	require.Len(t, orders, 1)
+	assert.Equal(t, 120.0, orders[AnomalyMetricLatencyP95].Mean)
+
+	learnedAt, err = db.AnomalyBaselinesLearnedAt(ctx)
+	require.NoError(t, err)
+	assert.True(t, learnedAt.Equal(now))
+
+	require.NoError(t, db.InsertAnomalyScores(ctx, []*AnomalyScore{
+		{Timestamp: now.Add(-10 * time.Minute), ServiceName: "api", Route: "GET /orders", Metric: AnomalyMetricLatencyP95, Value: 300, Mean: 120, Stddev: 12, Score: 15, Anomalous: true},
+		{Timestamp: now, ServiceName: "api", Route: "GET /orders", Metric: AnomalyMetricLatencyP95, Value: 125, Mean: 120, Stddev: 12, Score: 0.4},
+		{Timestamp: now, ServiceName: "api", Route: "GET /cart", Metric: AnomalyMetricErrorRate, Value: 0, Mean: 5, Stddev: 1, Score: -5, Anomalous: true},
+		{Timestamp: now, ServiceName: "web", Route: "GET /", Metric: AnomalyMetricErrorRate, Value: 9, Mean: 1, Stddev: 1, Score: 8, Anomalous: true},
+		{Timestamp: now.Add(-2 * time.Hour), ServiceName: "api", Route: "GET /", Metric: AnomalyMetricErrorRate, Value: 9, Mean: 1, Stddev: 1, Score: 8, Anomalous: true},
+	}))
+
+	scores, err := db.QueryAnomalyScores(ctx, AnomalyScoreFilter{ServiceName: "api", Since: now.Add(-time.Hour), AnomalousOnly: true})
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+	assert.Equal(t, "GET /cart", scores[0].Route, "newest first")
+	assert.Equal(t, 15.0, scores[1].Score)
+
+	scores, err = db.QueryAnomalyScores(ctx, AnomalyScoreFilter{Since: now.Add(-time.Hour), Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+	assert.Equal(t, "web", scores[0].ServiceName, "highest deviation first within a window")
+	assert.Equal(t, "GET /cart", scores[1].Route)
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// AuditEntry is a record of a control-plane action in the append-only audit log.
+type AuditEntry struct {
+	ID            int64     `duckdb:"-"` // Auto-increment, ignore in ORM
+	Timestamp     time.Time `duckdb:"timestamp"`
+	Actor         string    `duckdb:"actor"`
+	Action        string    `duckdb:"action"`
+	Target        string    `duckdb:"target"`
+	ArgumentsHash string    `duckdb:"arguments_hash"`
+	Result        string    `duckdb:"result"`
+	Error         string    `duckdb:"error"`
+}
+
+// AuditFilters contains filters for listing audit entries.
+type AuditFilters struct {
+	Since  time.Time
+	Action string
+	Actor  string
+	Limit  int
+}
+
+// InsertAuditEntry appends an entry to the audit log.
+func (d *Database) InsertAuditEntry(ctx context.Context, entry *AuditEntry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	return d.auditLogTable.Insert(ctx, entry)
+}
+
+// ListAuditEntries retrieves audit entries matching the provided filters,
+// newest first.
+func (d *Database) ListAuditEntries(ctx context.Context, filters AuditFilters) ([]*AuditEntry, error) {
+	query := `
+		SELECT id, timestamp, actor, action, target, arguments_hash, result, error
+		FROM audit_log
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if !filters.Since.IsZero() {
+		query += " AND timestamp >= ?"
+		args = append(args, filters.Since)
+	}
+
+	if filters.Action != "" {
+		query += " AND action = ?"
+		args = append(args, filters.Action)
+	}
+
+	if filters.Actor != "" {
+		query += " AND actor = ?"
+		args = append(args, filters.Actor)
+	}
+
+	query += " ORDER BY timestamp DESC, id DESC"
+
+	if filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filters.Limit)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []*AuditEntry
+	for rows.Next() {
+		var entry AuditEntry
+		var target, argumentsHash, errMsg sql.NullString
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.Timestamp,
+			&entry.Actor,
+			&entry.Action,
+			&target,
+			&argumentsHash,
+			&entry.Result,
+			&errMsg,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+
+		entry.Target = target.String
+		entry.ArgumentsHash = argumentsHash.String
+		entry.Error = errMsg.String
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit entries: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestAuditEntries(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	entries := []*AuditEntry{
+		{Timestamp: now.Add(-48 * time.Hour), Actor: "alice", Action: "AttachUprobe", Target: "service=api", Result: "ok"},
+		{Timestamp: now.Add(-time.Hour), Actor: "alice", Action: "ProfileCPU", Target: "service=api", ArgumentsHash: "abc", Result: "ok"},
+		{Timestamp: now.Add(-time.Minute), Actor: "bob", Action: "ContainerExec", Target: "service=db", Result: "permission_denied", Error: "denied"},
+	}
+	for _, e := range entries {
+		require.NoError(t, db.InsertAuditEntry(ctx, e))
+	}
+
+	all, err := db.ListAuditEntries(ctx, AuditFilters{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "ContainerExec", all[0].Action, "newest first")
+	assert.Equal(t, "denied", all[0].Error)
+	assert.NotZero(t, all[0].ID)
+
+	recent, err := db.ListAuditEntries(ctx, AuditFilters{Since: now.Add(-24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Len(t, recent, 2)
+
+	byActor, err := db.ListAuditEntries(ctx, AuditFilters{Actor: "alice", Action: "ProfileCPU"})
+	require.NoError(t, err)
+	require.Len(t, byActor, 1)
+	assert.Equal(t, "abc", byActor[0].ArgumentsHash)
+
+	limited, err := db.ListAuditEntries(ctx, AuditFilters{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+}
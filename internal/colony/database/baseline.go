@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// QueryServiceMetricSeries returns the request metrics of each service over
+// consecutive intervals of length step from start to end, optionally for a
+// single service. The slice of a service has one entry per interval; intervals
+// without requests have zero stats. As with QueryServiceWindowStats, a
+// percentile is the higher of the eBPF histogram's and the OTLP spans'.
+func (d *Database) QueryServiceMetricSeries(ctx context.Context, serviceName string, start, end time.Time, step time.Duration) (map[string][]ServiceWindowStats, error) {
+	if step <= 0 || !end.After(start) {
+		return nil, fmt.Errorf("invalid series: step %s from %s to %s", step, start, end)
+	}
+	intervals := int((end.Sub(start) + step - 1) / step)
+
+	serviceFilter := ""
+	args := []interface{}{start.UnixMilli(), float64(step.Milliseconds()), start, end}
+	if serviceName != "" {
+		serviceFilter = " AND service_name = ?"
+		args = append(args, serviceName)
+	}
+
+	rows, err := d.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT service_name,
+		       CAST(FLOOR((epoch_ms(timestamp) - ?) / ?) AS BIGINT) AS interval_index,
+		       latency_bucket_ms, SUM(count),
+		       SUM(CASE WHEN http_status_code >= 500 THEN count ELSE 0 END)
+		FROM beyla_http_metrics
+		WHERE timestamp >= ? AND timestamp < ?%s
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3
+	`, serviceFilter), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latency histograms: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	series := make(map[string][]ServiceWindowStats)
+	histograms := make(map[string]map[int][]latencyBucket)
+	for rows.Next() {
+		var service string
+		var index int
+		var b latencyBucket
+		var errors int64
+		if err := rows.Scan(&service, &index, &b.upperMs, &b.count, &errors); err != nil {
+			return nil, fmt.Errorf("failed to scan latency histogram: %w", err)
+		}
+		if index < 0 || index >= intervals {
+			continue
+		}
+		if series[service] == nil {
+			series[service] = make([]ServiceWindowStats, intervals)
+			histograms[service] = make(map[int][]latencyBucket)
+		}
+		series[service][index].Requests += b.count
+		series[service][index].Errors += errors
+		histograms[service][index] = append(histograms[service][index], b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating latency histograms: %w", err)
+	}
+
+	for service, byIndex := range histograms {
+		for index, buckets := range byIndex {
+			stats := &series[service][index]
+			stats.P50Ms, _ = histogramQuantile(buckets, 0.50)
+			stats.P95Ms, _ = histogramQuantile(buckets, 0.95)
+			stats.P99Ms, _ = histogramQuantile(buckets, 0.99)
+		}
+	}
+
+	otelRows, err := d.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT service_name,
+		       CAST(FLOOR((epoch_ms(bucket_time) - ?) / ?) AS BIGINT) AS interval_index,
+		       SUM(total_spans), SUM(error_count), MAX(p50_ms), MAX(p95_ms), MAX(p99_ms)
+		FROM otel_summaries
+		WHERE bucket_time >= ? AND bucket_time < ? AND total_spans > 0%s
+		GROUP BY 1, 2
+	`, serviceFilter), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query span summaries: %w", err)
+	}
+	defer func() { _ = otelRows.Close() }()
+
+	for otelRows.Next() {
+		var service string
+		var index int
+		var spans, spanErrors sql.NullInt64
+		var p50, p95, p99 sql.NullFloat64
+		if err := otelRows.Scan(&service, &index, &spans, &spanErrors, &p50, &p95, &p99); err != nil {
+			return nil, fmt.Errorf("failed to scan span summaries: %w", err)
+		}
+		if index < 0 || index >= intervals {
+			continue
+		}
+		if series[service] == nil {
+			series[service] = make([]ServiceWindowStats, intervals)
+		}
+		stats := &series[service][index]
+		stats.Requests += spans.Int64
+		stats.Errors += spanErrors.Int64
+		stats.P50Ms = max(stats.P50Ms, p50.Float64)
+		stats.P95Ms = max(stats.P95Ms, p95.Float64)
+		stats.P99Ms = max(stats.P99Ms, p99.Float64)
+	}
+	if err := otelRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating span summaries: %w", err)
+	}
+
+	return series, nil
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestQueryServiceMetricSeries(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour).Truncate(time.Minute)
+	step := 5 * time.Minute
+
+	http := []struct {
+		at     time.Time
+		status int
+		bucket float64
+		count  int
+	}{
+		{start.Add(time.Minute), 200, 10, 90},
+		{start.Add(2 * time.Minute), 503, 100, 10},
+		{start.Add(11 * time.Minute), 200, 50, 20},
+		{start.Add(-time.Minute), 200, 10, 1000}, // Before the series.
+	}
+	for _, m := range http {
+		_, err := db.db.ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', 'api', 'GET', '/', ?, ?, ?)
+		`, m.at, m.status, m.bucket, m.count)
+		require.NoError(t, err)
+	}
+	_, err = db.db.ExecContext(ctx, `
+		INSERT INTO otel_summaries (bucket_time, agent_id, service_name, span_kind, p50_ms, p95_ms, p99_ms, error_count, total_spans)
+		VALUES (?, 'agent-1', 'worker', 'SERVER', 20, 80, 120, 1, 10)
+	`, start.Add(6*time.Minute))
+	require.NoError(t, err)
+
+	series, err := db.QueryServiceMetricSeries(ctx, "", start, start.Add(15*time.Minute), step)
+	require.NoError(t, err)
+
+	require.Len(t, series["api"], 3)
+	assert.Equal(t, int64(100), series["api"][0].Requests)
+	assert.Equal(t, int64(10), series["api"][0].Errors)
+	assert.Equal(t, 100.0, series["api"][0].P95Ms)
+	assert.Zero(t, series["api"][1].Requests, "intervals without requests are zero")
+	assert.Equal(t, int64(20), series["api"][2].Requests)
+
+	require.Len(t, series["worker"], 3)
+	assert.Equal(t, int64(10), series["worker"][1].Requests)
+	assert.Equal(t, 80.0, series["worker"][1].P95Ms)
+
+	only, err := db.QueryServiceMetricSeries(ctx, "worker", start, start.Add(15*time.Minute), step)
+	require.NoError(t, err)
+	assert.Len(t, only, 1)
+
+	_, err = db.QueryServiceMetricSeries(ctx, "", start, start, step)
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 	"github.com/coral-mesh/coral/internal/duckdb"
 	"github.com/coral-mesh/coral/internal/safe"
 )
@@ -142,6 +143,8 @@ func (d *Database) InsertBeylaHTTPMetrics(ctx context.Context, agentID string, m
 		return fmt.Errorf("failed to batch upsert HTTP metrics: %w", err)
 	}
 
+	d.queryCache.Invalidate(querycache.Metrics)
+
 	d.logger.Debug().
 		Int("metric_count", len(metrics)).
 		Int("row_count", len(items)).
@@ -228,6 +231,8 @@ func (d *Database) InsertBeylaGRPCMetrics(ctx context.Context, agentID string, m
 		return fmt.Errorf("failed to batch upsert gRPC metrics: %w", err)
 	}
 
+	d.queryCache.Invalidate(querycache.Metrics)
+
 	d.logger.Debug().
 		Int("metric_count", len(metrics)).
 		Int("row_count", len(items)).
@@ -314,6 +319,8 @@ func (d *Database) InsertBeylaSQLMetrics(ctx context.Context, agentID string, me
 		return fmt.Errorf("failed to batch upsert SQL metrics: %w", err)
 	}
 
+	d.queryCache.Invalidate(querycache.Metrics)
+
 	d.logger.Debug().
 		Int("metric_count", len(metrics)).
 		Int("row_count", len(items)).
@@ -423,6 +430,8 @@ func (d *Database) InsertBeylaTraces(ctx context.Context, agentID string, spans
 		return fmt.Errorf("failed to batch upsert trace spans (incoming: %d, items: %d, seq: %d-%d): %w", len(spans), len(items), minSeq, maxSeq, err)
 	}
 
+	d.queryCache.Invalidate(querycache.Traces)
+
 	d.logger.Info().
 		Int("span_count", len(spans)).
 		Int("item_count", len(items)).
@@ -434,8 +443,10 @@ func (d *Database) InsertBeylaTraces(ctx context.Context, agentID string, spans
 
 // QueryBeylaHTTPMetrics queries HTTP metrics from colony database.
 // Returns aggregated metrics grouped by (service, method, route, status).
+// Long ranges are served from the metric rollups; first_seen and last_seen
+// are then bucket start times.
 func (d *Database) QueryBeylaHTTPMetrics(ctx context.Context, serviceName string, startTime, endTime time.Time, filters map[string]string) ([]*BeylaHTTPMetricResult, error) {
-	b := duckdb.NewQueryBuilder("beyla_http_metrics").
+	b := duckdb.NewQueryBuilder(d.beylaMetricsSource(ctx, "beyla_http_metrics", startTime, endTime)).
 		Select(
 			"service_name",
 			"http_method",
@@ -513,7 +524,7 @@ func (d *Database) QueryBeylaHTTPMetrics(ctx context.Context, serviceName string
 
 // QueryBeylaGRPCMetrics queries gRPC metrics from colony database.
 func (d *Database) QueryBeylaGRPCMetrics(ctx context.Context, serviceName string, startTime, endTime time.Time, filters map[string]string) ([]*BeylaGRPCMetricResult, error) {
-	sql, args, err := duckdb.NewQueryBuilder("beyla_grpc_metrics").
+	sql, args, err := duckdb.NewQueryBuilder(d.beylaMetricsSource(ctx, "beyla_grpc_metrics", startTime, endTime)).
 		Select(
 			"service_name",
 			"grpc_method",
@@ -568,7 +579,7 @@ func (d *Database) QueryBeylaGRPCMetrics(ctx context.Context, serviceName string
 
 // QueryBeylaSQLMetrics queries SQL metrics from colony database.
 func (d *Database) QueryBeylaSQLMetrics(ctx context.Context, serviceName string, startTime, endTime time.Time, filters map[string]string) ([]*BeylaSQLMetricResult, error) {
-	sql, args, err := duckdb.NewQueryBuilder("beyla_sql_metrics").
+	sql, args, err := duckdb.NewQueryBuilder(d.beylaMetricsSource(ctx, "beyla_sql_metrics", startTime, endTime)).
 		Select(
 			"service_name",
 			"sql_operation",
@@ -622,7 +633,32 @@ func (d *Database) QueryBeylaSQLMetrics(ctx context.Context, serviceName string,
 }
 
 // QueryBeylaTraces queries distributed traces from colony database (RFD 036).
+// It returns all spans of the most recent maxTraces traces with a span in the
+// time range matching serviceName and minDurationUs, so that traces crossing
+// several services are complete.
 func (d *Database) QueryBeylaTraces(ctx context.Context, traceID, serviceName string, startTime, endTime time.Time, minDurationUs int64, maxTraces int) ([]*BeylaTraceResult, error) {
+	traces := duckdb.NewQueryBuilder("beyla_traces").
+		Select("trace_id").
+		TimeColumn("start_time").
+		TimeRange(startTime, endTime).
+		Eq("trace_id", traceID).
+		Eq("service_name", serviceName).
+		GroupBy("trace_id").
+		OrderBy("-max(start_time)")
+
+	if minDurationUs > 0 {
+		traces.Gte("duration_us", minDurationUs)
+	}
+
+	if maxTraces > 0 {
+		traces.Limit(maxTraces)
+	}
+
+	tracesSQL, tracesArgs, err := traces.Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
 	b := duckdb.NewQueryBuilder("beyla_traces").
 		Select(
 			"trace_id",
@@ -635,20 +671,9 @@ func (d *Database) QueryBeylaTraces(ctx context.Context, traceID, serviceName st
 			"duration_us",
 			"status_code",
 		).
-		TimeColumn("start_time").
-		TimeRange(startTime, endTime).
-		Eq("trace_id", traceID).
-		Eq("service_name", serviceName).
+		Where("trace_id IN ("+tracesSQL+")", tracesArgs...).
 		OrderBy("-start_time")
 
-	if minDurationUs > 0 {
-		b.Gte("duration_us", minDurationUs)
-	}
-
-	if maxTraces > 0 {
-		b.Limit(maxTraces)
-	}
-
 	sql, args, err := b.Build()
 
 	if err != nil {
@@ -726,6 +751,47 @@ type BeylaSQLMetricResult struct {
 	LastSeen        time.Time
 }
 
+// BeylaSpanTiming is the start and duration of a span.
+type BeylaSpanTiming struct {
+	StartTime  time.Time
+	DurationUs int64
+}
+
+// QueryBeylaServerSpanTimings returns the start and duration of the server
+// spans of a service started in the time range, oldest first.
+func (d *Database) QueryBeylaServerSpanTimings(ctx context.Context, serviceName string, startTime, endTime time.Time) ([]BeylaSpanTiming, error) {
+	sql, args, err := duckdb.NewQueryBuilder("beyla_traces").
+		Select("start_time", "duration_us").
+		TimeColumn("start_time").
+		TimeRange(startTime, endTime).
+		Eq("service_name", serviceName).
+		Where("lower(span_kind) = 'server'").
+		OrderBy("start_time").
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := d.db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query span timings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []BeylaSpanTiming
+	for rows.Next() {
+		var r BeylaSpanTiming
+		if err := rows.Scan(&r.StartTime, &r.DurationUs); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return results, nil
+}
+
 // BeylaTraceResult represents a trace span result.
 type BeylaTraceResult struct {
 	TraceID      string
@@ -746,42 +812,27 @@ func (d *Database) CleanupOldBeylaMetrics(ctx context.Context, httpRetentionDays
 
 	// Cleanup HTTP metrics.
 	httpCutoff := time.Now().Add(-time.Duration(httpRetentionDays) * 24 * time.Hour)
-	httpResult, err := d.db.ExecContext(ctx, `
-		DELETE FROM beyla_http_metrics
-		WHERE timestamp < ?
-	`, httpCutoff)
+	httpRows, err := d.beylaHTTPTable.DeleteWhere(ctx, duckdb.NewFilter().Lt("timestamp", httpCutoff))
 	if err != nil {
 		return 0, fmt.Errorf("failed to cleanup HTTP metrics: %w", err)
 	}
-	if httpRows, err := httpResult.RowsAffected(); err == nil {
-		totalDeleted += httpRows
-	}
+	totalDeleted += httpRows
 
 	// Cleanup gRPC metrics.
 	grpcCutoff := time.Now().Add(-time.Duration(grpcRetentionDays) * 24 * time.Hour)
-	grpcResult, err := d.db.ExecContext(ctx, `
-		DELETE FROM beyla_grpc_metrics
-		WHERE timestamp < ?
-	`, grpcCutoff)
+	grpcRows, err := d.beylaGRPCTable.DeleteWhere(ctx, duckdb.NewFilter().Lt("timestamp", grpcCutoff))
 	if err != nil {
 		return totalDeleted, fmt.Errorf("failed to cleanup gRPC metrics: %w", err)
 	}
-	if grpcRows, err := grpcResult.RowsAffected(); err == nil {
-		totalDeleted += grpcRows
-	}
+	totalDeleted += grpcRows
 
 	// Cleanup SQL metrics.
 	sqlCutoff := time.Now().Add(-time.Duration(sqlRetentionDays) * 24 * time.Hour)
-	sqlResult, err := d.db.ExecContext(ctx, `
-		DELETE FROM beyla_sql_metrics
-		WHERE timestamp < ?
-	`, sqlCutoff)
+	sqlRows, err := d.beylaSQLTable.DeleteWhere(ctx, duckdb.NewFilter().Lt("timestamp", sqlCutoff))
 	if err != nil {
 		return totalDeleted, fmt.Errorf("failed to cleanup SQL metrics: %w", err)
 	}
-	if sqlRows, err := sqlResult.RowsAffected(); err == nil {
-		totalDeleted += sqlRows
-	}
+	totalDeleted += sqlRows
 
 	if totalDeleted > 0 {
 		d.logger.Debug().
@@ -798,15 +849,10 @@ func (d *Database) CleanupOldBeylaMetrics(ctx context.Context, httpRetentionDays
 // CleanupOldBeylaTraces removes Beyla traces older than the specified retention period (RFD 036).
 func (d *Database) CleanupOldBeylaTraces(ctx context.Context, traceRetentionDays int) (int64, error) {
 	traceCutoff := time.Now().Add(-time.Duration(traceRetentionDays) * 24 * time.Hour)
-	traceResult, err := d.db.ExecContext(ctx, `
-		DELETE FROM beyla_traces
-		WHERE start_time < ?
-	`, traceCutoff)
+	deleted, err := d.beylaTracesTable.DeleteWhere(ctx, duckdb.NewFilter().Lt("start_time", traceCutoff))
 	if err != nil {
 		return 0, fmt.Errorf("failed to cleanup traces: %w", err)
 	}
-
-	deleted, _ := traceResult.RowsAffected()
 	if deleted > 0 {
 		d.logger.Debug().
 			Int64("rows_deleted", deleted).
@@ -8,6 +8,8 @@ import (
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	"github.com/coral-mesh/coral/internal/constants"
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInsertBeylaTraces(t *testing.T) {
@@ -399,3 +401,58 @@ func TestBeylaTracesSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestQueryBeylaTraces_WholeTraces(t *testing.T) {
+	db, cleanup := newTestDatabase(t)
+	defer cleanup()
+
+	// trace-old and trace-new cross gateway and api, trace-other stays in web.
+	insertCrossServiceSpan(t, db, "trace-old", "old-gw", "old-api", "gateway", "api", -2*time.Minute)
+	insertCrossServiceSpan(t, db, "trace-new", "new-gw", "new-api", "gateway", "api", -time.Minute)
+	insertCrossServiceSpan(t, db, "trace-other", "other-1", "other-2", "web", "web", -time.Minute)
+
+	ctx := context.Background()
+	start, end := time.Now().Add(-time.Hour), time.Now()
+	traceIDs := func(results []*BeylaTraceResult) map[string]int {
+		spans := make(map[string]int)
+		for _, r := range results {
+			spans[r.TraceID]++
+		}
+		return spans
+	}
+
+	results, err := db.QueryBeylaTraces(ctx, "", "api", start, end, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"trace-old": 2, "trace-new": 2}, traceIDs(results), "the gateway spans of api traces are included")
+
+	results, err = db.QueryBeylaTraces(ctx, "", "", start, end, 0, 2)
+	require.NoError(t, err)
+	assert.Len(t, traceIDs(results), 2, "the limit applies to traces, not spans")
+	assert.NotContains(t, traceIDs(results), "trace-old", "the most recent traces are returned")
+
+	results, err = db.QueryBeylaTraces(ctx, "", "api", start, end, 800, 10)
+	require.NoError(t, err)
+	assert.Empty(t, results, "no api trace has a span of at least 800us")
+}
+
+func TestQueryBeylaServerSpanTimings(t *testing.T) {
+	db, cleanup := newTestDatabase(t)
+	defer cleanup()
+
+	insertCrossServiceSpan(t, db, "trace-old", "old-gw", "old-api", "gateway", "api", -2*time.Minute)
+	insertCrossServiceSpan(t, db, "trace-new", "new-gw", "new-api", "gateway", "api", -time.Minute)
+	insertCrossServiceSpan(t, db, "trace-stale", "stale-gw", "stale-api", "gateway", "api", -2*time.Hour)
+
+	ctx := context.Background()
+	start, end := time.Now().Add(-time.Hour), time.Now()
+
+	timings, err := db.QueryBeylaServerSpanTimings(ctx, "api", start, end)
+	require.NoError(t, err)
+	require.Len(t, timings, 2)
+	assert.True(t, timings[0].StartTime.Before(timings[1].StartTime), "oldest first")
+	assert.Equal(t, int64(500), timings[0].DurationUs)
+
+	timings, err = db.QueryBeylaServerSpanTimings(ctx, "gateway", start, end)
+	require.NoError(t, err)
+	assert.Empty(t, timings, "gateway only has client spans")
+}
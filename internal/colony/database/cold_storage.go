@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cold storage holds rows offloaded from the raw Beyla metric tables to
+// Parquet files, one or more per table and UTC day, listed in
+// cold_storage_manifest. Metric queries whose range overlaps offloaded files
+// read them alongside the table.
+
+// ColdPartition is a cold storage file.
+type ColdPartition struct {
+	Path         string
+	Table        string
+	Day          time.Time
+	Rows         int64
+	MinTimestamp time.Time
+	MaxTimestamp time.Time
+	CreatedAt    time.Time
+}
+
+// ColdStorageTables returns the tables that can be offloaded to cold storage.
+func ColdStorageTables() []string {
+	tables := make([]string, len(rollupSources))
+	for i, src := range rollupSources {
+		tables[i] = src.table
+	}
+	return tables
+}
+
+// ColdOffloadDays returns the UTC days with rows in table that end before
+// cutoff, oldest first.
+func (d *Database) ColdOffloadDays(ctx context.Context, table string, cutoff time.Time) ([]time.Time, error) {
+	// time_bucket needs a plain TIMESTAMP; timestamps are stored in UTC.
+	rows, err := d.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT DISTINCT time_bucket(INTERVAL '1 day', timestamp::TIMESTAMP) AS day
+		FROM %s
+		WHERE timestamp < ?
+		ORDER BY day
+	`, table), cutoff.UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query days of %s: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var days []time.Time
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("failed to scan day: %w", err)
+		}
+		days = append(days, day.UTC())
+	}
+	return days, rows.Err()
+}
+
+// OffloadColdPartition exports the rows of table on the UTC day starting at
+// day to a Parquet file at path, records the file in the manifest and
+// deletes the rows. The export, manifest entry and deletion happen in one
+// transaction; if it fails, the rows stay in the table and the file, if
+// written, is not referenced. It returns nil if the day has no rows.
+func (d *Database) OffloadColdPartition(ctx context.Context, table string, day time.Time, path string) (*ColdPartition, error) {
+	start := day.UTC()
+	end := start.Add(24 * time.Hour)
+	where := fmt.Sprintf("timestamp >= %s AND timestamp < %s", timestampLiteral(start), timestampLiteral(end))
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	part := &ColdPartition{Path: path, Table: table, Day: start, CreatedAt: time.Now().UTC()}
+	var minTS, maxTS *time.Time
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf(
+		"SELECT count(*), min(timestamp), max(timestamp) FROM %s WHERE %s", table, where,
+	)).Scan(&part.Rows, &minTS, &maxTS); err != nil {
+		return nil, fmt.Errorf("failed to count rows of %s: %w", table, err)
+	}
+	if part.Rows == 0 {
+		return nil, nil
+	}
+	part.MinTimestamp, part.MaxTimestamp = minTS.UTC(), maxTS.UTC()
+
+	// COPY does not accept parameters.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+		"COPY (SELECT * FROM %s WHERE %s ORDER BY timestamp) TO %s (FORMAT parquet, COMPRESSION zstd)",
+		table, where, stringLiteral(path),
+	)); err != nil {
+		return nil, fmt.Errorf("failed to export %s to %s: %w", table, path, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO cold_storage_manifest (path, table_name, day, row_count, min_timestamp, max_timestamp, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, part.Path, part.Table, part.Day, part.Rows, part.MinTimestamp, part.MaxTimestamp, part.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to record cold partition: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s", table, where))
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete offloaded rows of %s: %w", table, err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if deleted != part.Rows {
+		return nil, fmt.Errorf("deleted %d rows of %s but exported %d", deleted, table, part.Rows)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return part, nil
+}
+
+// ColdPartitions returns the cold storage files of table with rows between
+// start and end, oldest first.
+func (d *Database) ColdPartitions(ctx context.Context, table string, start, end time.Time) ([]ColdPartition, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT path, table_name, day, row_count, min_timestamp, max_timestamp, created_at
+		FROM cold_storage_manifest
+		WHERE table_name = ? AND max_timestamp >= ? AND min_timestamp < ?
+		ORDER BY min_timestamp, path
+	`, table, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cold storage manifest: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var parts []ColdPartition
+	for rows.Next() {
+		var p ColdPartition
+		if err := rows.Scan(&p.Path, &p.Table, &p.Day, &p.Rows, &p.MinTimestamp, &p.MaxTimestamp, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan cold partition: %w", err)
+		}
+		parts = append(parts, p)
+	}
+	return parts, rows.Err()
+}
+
+// rawMetricsSource returns the FROM expression for the rows of a raw Beyla
+// metrics table between start and end: the table itself, joined with the
+// cold storage files overlapping the range if there are any.
+func (d *Database) rawMetricsSource(ctx context.Context, table string, start, end time.Time) string {
+	parts, err := d.ColdPartitions(ctx, table, start, end)
+	if err != nil {
+		d.logger.Warn().Err(err).Str("table", table).Msg("Failed to read cold storage manifest, querying the table only")
+		return table
+	}
+	if len(parts) == 0 {
+		return table
+	}
+
+	paths := make([]string, len(parts))
+	for i, p := range parts {
+		paths[i] = stringLiteral(p.Path)
+	}
+	// BY NAME tolerates columns added to the table after a file was written.
+	return fmt.Sprintf("(SELECT * FROM %[1]s UNION ALL BY NAME SELECT * FROM read_parquet([%[2]s])) AS %[1]s",
+		table, strings.Join(paths, ", "))
+}
+
+// stringLiteral quotes s as a SQL string literal.
+func stringLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -17,7 +17,8 @@ type ServiceConnection struct {
 }
 
 // MaterializeConnections re-derives service connections from the beyla_traces table
-// and upserts the results into service_connections (RFD 092).
+// and upserts the results into service_connections (RFD 092). Calls whose server
+// span has an rpc.system of grpc are gRPC connections, all others HTTP.
 func (d *Database) MaterializeConnections(ctx context.Context, since time.Time) error {
 	d.connectionsMu.Lock()
 	defer d.connectionsMu.Unlock()
@@ -54,8 +55,10 @@ func (d *Database) MaterializeConnections(ctx context.Context, since time.Time)
 			WHERE start_time >= ?
 		),
 		child_spans AS (
-			-- Destination spans to be matched (since)
-			SELECT * FROM candidates 
+			-- Destination spans to be matched (since), with the protocol of the call
+			SELECT *,
+				CASE WHEN json_extract_string(attributes, '$."rpc.system"') = 'grpc' THEN 'grpc' ELSE 'http' END as protocol
+			FROM candidates 
 			WHERE start_time >= ?
 		),
 		matches AS (
@@ -64,6 +67,7 @@ func (d *Database) MaterializeConnections(ctx context.Context, since time.Time)
 				c.span_id as child_id,
 				LOWER(p.service_name) as from_service,
 				LOWER(c.service_name) as to_service,
+				c.protocol,
 				c.start_time,
 				p.start_time as parent_time,
 				1 as priority
@@ -78,6 +82,7 @@ func (d *Database) MaterializeConnections(ctx context.Context, since time.Time)
 				c.span_id as child_id,
 				LOWER(p.service_name) as from_service,
 				LOWER(c.service_name) as to_service,
+				c.protocol,
 				c.start_time,
 				p.start_time as parent_time,
 				2 as priority
@@ -95,6 +100,7 @@ func (d *Database) MaterializeConnections(ctx context.Context, since time.Time)
 				c.span_id as child_id,
 				LOWER(p.service_name) as from_service,
 				LOWER(c.service_name) as to_service,
+				c.protocol,
 				c.start_time,
 				p.start_time as parent_time,
 				3 as priority
@@ -104,7 +110,7 @@ func (d *Database) MaterializeConnections(ctx context.Context, since time.Time)
 			  AND LOWER(c.service_name) != LOWER(p.service_name)
 		),
 		best_matches AS (
-			SELECT from_service, to_service, start_time
+			SELECT from_service, to_service, protocol, start_time
 			FROM matches
 			QUALIFY row_number() OVER (
 				PARTITION BY child_id 
@@ -115,7 +121,7 @@ func (d *Database) MaterializeConnections(ctx context.Context, since time.Time)
 			SELECT 
 				from_service, 
 				to_service, 
-				'http' as protocol,
+				protocol,
 				COUNT(*) as connection_count,
 				MIN(start_time) as first_observed,
 				MAX(start_time) as last_observed
@@ -192,3 +198,125 @@ func (d *Database) GetServiceConnections(ctx context.Context, since time.Time) (
 
 	return results, nil
 }
+
+// ServiceEdgeStats are the request metrics of calls from one service to
+// another, measured at the callee's server spans.
+type ServiceEdgeStats struct {
+	FromService  string
+	ToService    string
+	RequestCount int64
+	ErrorCount   int64
+	P95Ms        float64
+}
+
+// GetServiceEdgeStats returns the request count, HTTP 5xx count and p95
+// latency of each service-to-service edge since the given time. Unlike
+// GetServiceConnections it reflects only the window, and only calls whose
+// server span links to its caller's span, so that rates are not inflated by
+// the time-based fallback matching of MaterializeConnections.
+func (d *Database) GetServiceEdgeStats(ctx context.Context, since time.Time) ([]*ServiceEdgeStats, error) {
+	const query = `
+		SELECT
+			LOWER(p.service_name),
+			LOWER(c.service_name),
+			COUNT(*),
+			COUNT(*) FILTER (WHERE c.status_code >= 500),
+			quantile_cont(c.duration_us, 0.95) / 1000.0
+		FROM beyla_traces c
+		JOIN beyla_traces p ON c.trace_id = p.trace_id AND c.parent_span_id = p.span_id
+		WHERE c.start_time >= ?
+		  AND UPPER(c.span_kind) = 'SERVER'
+		  AND LOWER(c.service_name) != LOWER(p.service_name)
+		GROUP BY 1, 2
+		ORDER BY 3 DESC
+	`
+	rows, err := d.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query service edge stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []*ServiceEdgeStats
+	for rows.Next() {
+		var s ServiceEdgeStats
+		if err := rows.Scan(&s.FromService, &s.ToService, &s.RequestCount, &s.ErrorCount, &s.P95Ms); err != nil {
+			return nil, fmt.Errorf("failed to scan service edge stats: %w", err)
+		}
+		results = append(results, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating service edge stats: %w", err)
+	}
+
+	return results, nil
+}
+
+// ClientDependency is a dependency of a service seen only from the client
+// side: a database it queries, or a gRPC server without instrumentation.
+type ClientDependency struct {
+	FromService  string
+	Target       string
+	Protocol     string // "sql" or "grpc".
+	RequestCount int64
+}
+
+// GetClientDependencies returns the dependencies of services since the given
+// time that traces between instrumented services do not show. SQL metrics
+// name their database by its server.address attribute, or its db.system. gRPC
+// client spans without a child server span name their server by peer.service,
+// server.address or rpc.service.
+func (d *Database) GetClientDependencies(ctx context.Context, since time.Time) ([]*ClientDependency, error) {
+	const query = `
+		SELECT LOWER(service_name),
+		       COALESCE(NULLIF(json_extract_string(attributes, '$."server.address"'), ''),
+		                NULLIF(json_extract_string(attributes, '$."db.system"'), ''),
+		                'database') AS target,
+		       'sql',
+		       SUM(count)
+		FROM beyla_sql_metrics
+		WHERE timestamp >= ?
+		GROUP BY 1, 2
+
+		UNION ALL
+
+		SELECT LOWER(c.service_name),
+		       COALESCE(NULLIF(json_extract_string(c.attributes, '$."peer.service"'), ''),
+		                NULLIF(json_extract_string(c.attributes, '$."server.address"'), ''),
+		                NULLIF(json_extract_string(c.attributes, '$."rpc.service"'), '')) AS target,
+		       'grpc',
+		       COUNT(*)
+		FROM beyla_traces c
+		WHERE c.start_time >= ?
+		  AND UPPER(c.span_kind) = 'CLIENT'
+		  AND json_extract_string(c.attributes, '$."rpc.system"') = 'grpc'
+		  AND NOT EXISTS (
+		      SELECT 1 FROM beyla_traces s
+		      WHERE s.trace_id = c.trace_id AND s.parent_span_id = c.span_id
+		  )
+		GROUP BY 1, 2
+		HAVING target IS NOT NULL
+
+		ORDER BY 4 DESC
+	`
+	rows, err := d.db.QueryContext(ctx, query, since, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client dependencies: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var results []*ClientDependency
+	for rows.Next() {
+		var c ClientDependency
+		if err := rows.Scan(&c.FromService, &c.Target, &c.Protocol, &c.RequestCount); err != nil {
+			return nil, fmt.Errorf("failed to scan client dependency: %w", err)
+		}
+		results = append(results, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating client dependencies: %w", err)
+	}
+
+	return results, nil
+}
@@ -337,3 +337,117 @@ func TestGetServiceConnections_StaleCache(t *testing.T) {
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, len(conns), 2, "Expired cache should re-materialize and include new edge")
 }
+
+func TestGetServiceEdgeStats(t *testing.T) {
+	db, cleanup := newTestDatabase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	insertCrossServiceSpan(t, db, "trace000000000000000000000000060", "parentspan000060", "childspan0000060", "frontend", "orders", 0)
+	insertCrossServiceSpan(t, db, "trace000000000000000000000000061", "parentspan000061", "childspan0000061", "frontend", "orders", 0)
+	insertCrossServiceSpan(t, db, "trace000000000000000000000000062", "parentspan000062", "childspan0000062", "orders", "payments", 0)
+	// Outside the window.
+	insertCrossServiceSpan(t, db, "trace000000000000000000000000063", "parentspan000063", "childspan0000063", "frontend", "orders", -2*time.Hour)
+
+	// A failed call from frontend to orders.
+	require.NoError(t, db.InsertBeylaTraces(ctx, "agent-parent", []*agentv1.EbpfTraceSpan{{
+		TraceId: "trace000000000000000000000000064", SpanId: "parentspan000064", ServiceName: "frontend",
+		SpanName: "GET /api", SpanKind: "client", StartTime: time.Now().UnixMilli(), DurationUs: 3000, StatusCode: 503,
+	}}))
+	require.NoError(t, db.InsertBeylaTraces(ctx, "agent-child", []*agentv1.EbpfTraceSpan{{
+		TraceId: "trace000000000000000000000000064", SpanId: "childspan0000064", ParentSpanId: "parentspan000064",
+		ServiceName: "orders", SpanName: "POST /rpc", SpanKind: "server", StartTime: time.Now().UnixMilli(), DurationUs: 2500, StatusCode: 503,
+	}}))
+
+	stats, err := db.GetServiceEdgeStats(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, "frontend", stats[0].FromService)
+	assert.Equal(t, "orders", stats[0].ToService)
+	assert.Equal(t, int64(3), stats[0].RequestCount)
+	assert.Equal(t, int64(1), stats[0].ErrorCount)
+	assert.Greater(t, stats[0].P95Ms, 0.5)
+
+	assert.Equal(t, "orders", stats[1].FromService)
+	assert.Equal(t, "payments", stats[1].ToService)
+	assert.Equal(t, int64(1), stats[1].RequestCount)
+	assert.Zero(t, stats[1].ErrorCount)
+}
+
+func TestMaterializeConnections_DetectsGRPC(t *testing.T) {
+	db, cleanup := newTestDatabase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, db.InsertBeylaTraces(ctx, "agent-parent", []*agentv1.EbpfTraceSpan{{
+		TraceId: "trace000000000000000000000000070", SpanId: "parentspan000070", ServiceName: "orders",
+		SpanName: "/inventory.Stock/Reserve", SpanKind: "client", StartTime: now.UnixMilli(), DurationUs: 1000,
+	}}))
+	require.NoError(t, db.InsertBeylaTraces(ctx, "agent-child", []*agentv1.EbpfTraceSpan{{
+		TraceId: "trace000000000000000000000000070", SpanId: "childspan0000070", ParentSpanId: "parentspan000070",
+		ServiceName: "inventory", SpanName: "/inventory.Stock/Reserve", SpanKind: "server", StartTime: now.UnixMilli(), DurationUs: 500,
+		Attributes: map[string]string{"rpc.system": "grpc"},
+	}}))
+
+	require.NoError(t, db.MaterializeConnections(ctx, now.Add(-time.Hour)))
+
+	conns, err := db.GetServiceConnections(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, conns, 1)
+	assert.Equal(t, "grpc", conns[0].Protocol)
+}
+
+func TestGetClientDependencies(t *testing.T) {
+	db, cleanup := newTestDatabase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, db.InsertBeylaSQLMetrics(ctx, "agent-1", []*agentv1.EbpfSqlMetric{
+		{
+			Timestamp: now.UnixMilli(), ServiceName: "orders", SqlOperation: "SELECT", TableName: "orders",
+			LatencyBuckets: []float64{5, 10}, LatencyCounts: []uint64{40, 2},
+			Attributes: map[string]string{"db.system": "postgresql", "server.address": "orders-db"},
+		},
+		{
+			Timestamp: now.UnixMilli(), ServiceName: "search", SqlOperation: "SELECT", TableName: "docs",
+			LatencyBuckets: []float64{5}, LatencyCounts: []uint64{3},
+			Attributes: map[string]string{"db.system": "mysql"},
+		},
+		{
+			Timestamp: now.Add(-2 * time.Hour).UnixMilli(), ServiceName: "billing", SqlOperation: "SELECT", TableName: "invoices",
+			LatencyBuckets: []float64{5}, LatencyCounts: []uint64{9},
+		},
+	}))
+
+	// A gRPC call to an uninstrumented server, and one to an instrumented
+	// server whose server span the trace edges already cover.
+	grpc := map[string]string{"rpc.system": "grpc", "rpc.service": "payments.Gateway", "server.address": "payments.example.com"}
+	require.NoError(t, db.InsertBeylaTraces(ctx, "agent-1", []*agentv1.EbpfTraceSpan{
+		{
+			TraceId: "trace000000000000000000000000080", SpanId: "clientspan000080", ServiceName: "orders",
+			SpanName: "/payments.Gateway/Charge", SpanKind: "client", StartTime: now.UnixMilli(), DurationUs: 1000, Attributes: grpc,
+		},
+		{
+			TraceId: "trace000000000000000000000000081", SpanId: "clientspan000081", ServiceName: "orders",
+			SpanName: "/inventory.Stock/Reserve", SpanKind: "client", StartTime: now.UnixMilli(), DurationUs: 1000,
+			Attributes: map[string]string{"rpc.system": "grpc", "server.address": "inventory"},
+		},
+		{
+			TraceId: "trace000000000000000000000000081", SpanId: "serverspan000081", ParentSpanId: "clientspan000081",
+			ServiceName: "inventory", SpanName: "/inventory.Stock/Reserve", SpanKind: "server", StartTime: now.UnixMilli(), DurationUs: 500,
+		},
+	}))
+
+	deps, err := db.GetClientDependencies(ctx, now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, deps, 3)
+
+	assert.Equal(t, ClientDependency{FromService: "orders", Target: "orders-db", Protocol: "sql", RequestCount: 42}, *deps[0])
+	assert.Equal(t, ClientDependency{FromService: "search", Target: "mysql", Protocol: "sql", RequestCount: 3}, *deps[1])
+	assert.Equal(t, ClientDependency{FromService: "orders", Target: "payments.example.com", Protocol: "grpc", RequestCount: 1}, *deps[2])
+}
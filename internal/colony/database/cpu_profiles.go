@@ -3,12 +3,11 @@ package database
 import (
 	"context"
 	"crypto/sha256"
-	"database/sql"
 	"encoding/binary"
 	"fmt"
-	"sync"
 	"time"
 
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 	"github.com/coral-mesh/coral/internal/duckdb"
 )
 
@@ -48,162 +47,6 @@ type BinaryMetadata struct {
 	HasDebugInfo bool      `duckdb:"has_debug_info"`         // Mutable: may be discovered later.
 }
 
-// ProfileFrameStore manages the global frame dictionary for CPU profiles (RFD 072).
-// This is similar to the agent-local frame dictionary but shared across all services.
-type ProfileFrameStore struct {
-	mu             sync.RWMutex
-	frameDictCache map[string]int64 // frame_name -> frame_id.
-	nextFrameID    int64
-}
-
-// NewProfileFrameStore creates a new profile frame store.
-func NewProfileFrameStore() *ProfileFrameStore {
-	return &ProfileFrameStore{
-		frameDictCache: make(map[string]int64),
-		nextFrameID:    1,
-	}
-}
-
-// EncodeStackFrames converts frame names to integer IDs using the global dictionary.
-// This provides 85% compression by storing frame names once and referencing by ID.
-func (d *Database) EncodeStackFrames(ctx context.Context, frameNames []string) ([]int64, error) {
-	if len(frameNames) == 0 {
-		return []int64{}, nil
-	}
-
-	d.profileFrameStore.mu.Lock()
-	defer d.profileFrameStore.mu.Unlock()
-
-	frameIDs := make([]int64, len(frameNames))
-
-	for i, frameName := range frameNames {
-		// Check cache first.
-		if frameID, exists := d.profileFrameStore.frameDictCache[frameName]; exists {
-			frameIDs[i] = frameID
-			continue
-		}
-
-		// Check database.
-		var frameID int64
-		err := d.db.QueryRowContext(ctx, `
-			SELECT frame_id FROM profile_frame_dictionary WHERE frame_name = ?
-		`, frameName).Scan(&frameID)
-
-		if err == nil {
-			// Found in database, cache it.
-			d.profileFrameStore.frameDictCache[frameName] = frameID
-			frameIDs[i] = frameID
-			continue
-		}
-
-		if err != sql.ErrNoRows {
-			return nil, fmt.Errorf("failed to query frame dictionary: %w", err)
-		}
-
-		// Not found, insert new frame (or do nothing if already exists due to race).
-		_, err = d.db.ExecContext(ctx, `
-			INSERT INTO profile_frame_dictionary (frame_name)
-			VALUES (?)
-			ON CONFLICT (frame_name) DO NOTHING
-		`, frameName)
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to insert frame: %w", err)
-		}
-
-		// Query to get the frame_id (whether just inserted or already existed).
-		err = d.db.QueryRowContext(ctx, `
-			SELECT frame_id FROM profile_frame_dictionary WHERE frame_name = ?
-		`, frameName).Scan(&frameID)
-
-		if err != nil {
-			return nil, fmt.Errorf("failed to query frame_id after insert: %w", err)
-		}
-
-		// Cache the new frame ID.
-		d.profileFrameStore.frameDictCache[frameName] = frameID
-		frameIDs[i] = frameID
-	}
-
-	return frameIDs, nil
-}
-
-// DecodeStackFrames converts frame IDs back to frame names using the global dictionary.
-func (d *Database) DecodeStackFrames(ctx context.Context, frameIDs []int64) ([]string, error) {
-	if len(frameIDs) == 0 {
-		return []string{}, nil
-	}
-
-	d.profileFrameStore.mu.RLock()
-	defer d.profileFrameStore.mu.RUnlock()
-
-	// Build reverse cache lookup.
-	reverseCache := make(map[int64]string)
-	for name, id := range d.profileFrameStore.frameDictCache {
-		reverseCache[id] = name
-	}
-
-	frameNames := make([]string, len(frameIDs))
-	missingIDs := make([]int64, 0)
-
-	// First pass: check cache.
-	for i, frameID := range frameIDs {
-		if frameName, exists := reverseCache[frameID]; exists {
-			frameNames[i] = frameName
-		} else {
-			missingIDs = append(missingIDs, frameID)
-		}
-	}
-
-	// Second pass: query database for missing IDs.
-	if len(missingIDs) > 0 {
-		// Build IN clause.
-		query := `SELECT frame_id, frame_name FROM profile_frame_dictionary WHERE frame_id IN (`
-		args := make([]interface{}, len(missingIDs))
-		for i, id := range missingIDs {
-			if i > 0 {
-				query += ", "
-			}
-			query += "?"
-			args[i] = id
-		}
-		query += ")"
-
-		rows, err := d.db.QueryContext(ctx, query, args...)
-		if err != nil {
-			return nil, fmt.Errorf("failed to query frame names: %w", err)
-		}
-		defer func() { _ = rows.Close() }()
-
-		dbFrames := make(map[int64]string)
-		for rows.Next() {
-			var frameID int64
-			var frameName string
-			if err := rows.Scan(&frameID, &frameName); err != nil {
-				return nil, fmt.Errorf("failed to scan frame: %w", err)
-			}
-			dbFrames[frameID] = frameName
-		}
-
-		if err := rows.Err(); err != nil {
-			return nil, fmt.Errorf("error iterating rows: %w", err)
-		}
-
-		// Fill in missing frame names.
-		for i, frameID := range frameIDs {
-			if frameNames[i] == "" {
-				if frameName, exists := dbFrames[frameID]; exists {
-					frameNames[i] = frameName
-				} else {
-					frameNames[i] = fmt.Sprintf("unknown_frame_%d", frameID)
-				}
-			}
-		}
-	}
-
-	return frameNames, nil
-}
-
 // InsertCPUProfileSummaries inserts 1-minute aggregated CPU profile summaries.
 // Summaries are created by the colony after polling and aggregating agent samples (RFD 072).
 // InsertCPUProfileSummaries inserts 1-minute aggregated CPU profile summaries.
@@ -243,6 +86,8 @@ func (d *Database) InsertCPUProfileSummaries(ctx context.Context, summaries []CP
 		return fmt.Errorf("failed to batch upsert CPU profile summaries: %w", err)
 	}
 
+	d.queryCache.Invalidate(querycache.Profiles)
+
 	d.logger.Debug().
 		Int("summary_count", len(summaries)).
 		Msg("Inserted CPU profile summaries")
@@ -365,6 +210,8 @@ func (d *Database) CleanupOrphanedFrames(ctx context.Context) (int64, error) {
 			SELECT UNNEST(stack_frame_ids) FROM cpu_profile_summaries
 			UNION
 			SELECT UNNEST(stack_frame_ids) FROM memory_profile_summaries
+			UNION
+			SELECT UNNEST(stack_frame_ids) FROM profile_run_stacks
 		)
 	`)
 
@@ -378,6 +225,9 @@ func (d *Database) CleanupOrphanedFrames(ctx context.Context) (int64, error) {
 	}
 
 	if rowsAffected > 0 {
+		// The cache may hold deleted frames, which must be stored again
+		// when they reappear.
+		d.profileFrameStore.reset()
 		d.logger.Debug().
 			Int64("frames_deleted", rowsAffected).
 			Msg("Cleaned up orphaned frame dictionary entries")
@@ -13,6 +13,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 	"github.com/coral-mesh/coral/internal/duckdb"
 	"github.com/coral-mesh/coral/internal/privilege"
 )
@@ -22,6 +23,8 @@ type Database struct {
 	db                *sql.DB
 	path              string
 	colonyID          string
+	readOnly          bool
+	encryption        *encryptedStorage // Nil unless stored encrypted.
 	logger            zerolog.Logger
 	profileFrameStore *ProfileFrameStore // RFD 072: Global frame dictionary for CPU profiling.
 
@@ -42,35 +45,83 @@ type Database struct {
 	debugEventsTable         *duckdb.Table[DebugEvent]
 	connectionsTable         *duckdb.Table[ServiceConnection]
 	topologyConnectionsTable *duckdb.Table[TopologyConnection] // RFD 033: L4 network topology.
+	auditLogTable            *duckdb.Table[AuditEntry]
+	mcpToolCallsTable        *duckdb.Table[MCPToolCall]
+	agentHistoryTable        *duckdb.Table[AgentHistoryEvent]
+	agentSettingsTable       *duckdb.Table[AgentSettings]
+	serviceGroupsTable       *duckdb.Table[ServiceGroup]
+	serviceFreezesTable      *duckdb.Table[ServiceFreeze]
+	shellRecordingsTable     *duckdb.Table[ShellRecording]
+	profileSchedulesTable    *duckdb.Table[ProfileSchedule]
+	profileRunsTable         *duckdb.Table[ProfileRun]
+	alertRulesTable          *duckdb.Table[AlertRule]
+	alertFiringTable         *duckdb.Table[AlertFiring]
+	anomalyBaselinesTable    *duckdb.Table[AnomalyBaseline]
+	anomalyScoresTable       *duckdb.Table[AnomalyScore]
 
 	// Cache state for GetServiceConnections (RFD 092).
 	connectionsMu               sync.Mutex
 	connectionsLastMaterialized time.Time
 	connectionsCacheTTL         time.Duration
+
+	// queryCache caches query results; ingests invalidate them. Nil unless
+	// set with SetQueryCache.
+	queryCache *querycache.Cache
+}
+
+// SetQueryCache sets the cache of query results invalidated by the ingests
+// of this database.
+func (d *Database) SetQueryCache(cache *querycache.Cache) {
+	d.queryCache = cache
+}
+
+// QueryCache returns the cache of query results, or nil if query results are
+// not cached.
+func (d *Database) QueryCache() *querycache.Cache {
+	if d == nil {
+		return nil
+	}
+	return d.queryCache
 }
 
 // New creates and initializes a DuckDB database for the colony.
 // It creates the storage directory if it doesn't exist, opens the database
 // connection, and initializes the schema.
 func New(storagePath, colonyID string, connectionsCacheTTL time.Duration, logger zerolog.Logger) (*Database, error) {
-	return open(storagePath, colonyID, connectionsCacheTTL, logger, false)
+	return open(storagePath, colonyID, connectionsCacheTTL, logger, false, nil)
 }
 
 // NewReadOnly opens the database in read-only mode for read-only access.
 // This allows multiple processes to read from the same database without lock conflicts.
 func NewReadOnly(storagePath, colonyID string, connectionsCacheTTL time.Duration, logger zerolog.Logger) (*Database, error) {
-	return open(storagePath, colonyID, connectionsCacheTTL, logger, true)
+	return open(storagePath, colonyID, connectionsCacheTTL, logger, true, nil)
 }
 
-// open is the internal function that opens the database with optional read-only mode.
-func open(storagePath, colonyID string, connectionsCacheTTL time.Duration, logger zerolog.Logger, readOnly bool) (*Database, error) {
+// open is the internal function that opens the database with optional
+// read-only mode and at-rest encryption.
+func open(storagePath, colonyID string, connectionsCacheTTL time.Duration, logger zerolog.Logger, readOnly bool, enc *Encryption) (*Database, error) {
 	// Ensure storage directory exists.
 	if err := os.MkdirAll(storagePath, 0750); err != nil {
 		return nil, fmt.Errorf("failed to create storage directory: %w", err)
 	}
 
-	// Construct database file path.
+	// Construct database file path. An encrypted database is opened from its
+	// decrypted working copy.
 	dbPath := filepath.Join(storagePath, colonyID+".duckdb")
+	var encrypted *encryptedStorage
+	if enc != nil {
+		var err error
+		dbPath, encrypted, err = prepareEncrypted(storagePath, colonyID, *enc, readOnly, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	opened := false
+	defer func() {
+		if encrypted != nil && !opened {
+			encrypted.discard()
+		}
+	}()
 
 	// Build connection string.
 	connStr := dbPath
@@ -90,6 +141,12 @@ func open(storagePath, colonyID string, connectionsCacheTTL time.Duration, logge
 			_ = db.Close()
 			return nil, fmt.Errorf("failed to fix storage directory ownership: %w", err)
 		}
+		if enc != nil && enc.WorkDir != "" {
+			if err := privilege.FixFileOwnership(enc.WorkDir); err != nil {
+				_ = db.Close()
+				return nil, fmt.Errorf("failed to fix encryption work directory ownership: %w", err)
+			}
+		}
 		if err := privilege.FixFileOwnership(dbPath); err != nil {
 			_ = db.Close()
 			return nil, fmt.Errorf("failed to fix database file ownership: %w", err)
@@ -118,6 +175,8 @@ func open(storagePath, colonyID string, connectionsCacheTTL time.Duration, logge
 		db:                  db,
 		path:                dbPath,
 		colonyID:            colonyID,
+		readOnly:            readOnly,
+		encryption:          encrypted,
 		logger:              logger,
 		profileFrameStore:   NewProfileFrameStore(), // RFD 072.
 		connectionsCacheTTL: connectionsCacheTTL,
@@ -138,6 +197,19 @@ func open(storagePath, colonyID string, connectionsCacheTTL time.Duration, logge
 		debugEventsTable:         duckdb.NewTable[DebugEvent](db, "debug_events"),
 		connectionsTable:         duckdb.NewTable[ServiceConnection](db, "service_connections"),
 		topologyConnectionsTable: duckdb.NewTable[TopologyConnection](db, "topology_connections"),
+		auditLogTable:            duckdb.NewTable[AuditEntry](db, "audit_log"),
+		mcpToolCallsTable:        duckdb.NewTable[MCPToolCall](db, "mcp_tool_calls"),
+		agentHistoryTable:        duckdb.NewTable[AgentHistoryEvent](db, "agent_history"),
+		agentSettingsTable:       duckdb.NewTable[AgentSettings](db, "agent_settings"),
+		serviceGroupsTable:       duckdb.NewTable[ServiceGroup](db, "service_groups"),
+		serviceFreezesTable:      duckdb.NewTable[ServiceFreeze](db, "service_freezes"),
+		shellRecordingsTable:     duckdb.NewTable[ShellRecording](db, "shell_recordings"),
+		profileSchedulesTable:    duckdb.NewTable[ProfileSchedule](db, "profile_schedules"),
+		profileRunsTable:         duckdb.NewTable[ProfileRun](db, "profile_runs"),
+		alertRulesTable:          duckdb.NewTable[AlertRule](db, "alert_rules"),
+		alertFiringTable:         duckdb.NewTable[AlertFiring](db, "alert_firing"),
+		anomalyBaselinesTable:    duckdb.NewTable[AnomalyBaseline](db, "anomaly_baselines"),
+		anomalyScoresTable:       duckdb.NewTable[AnomalyScore](db, "anomaly_scores"),
 	}
 
 	// Initialize schema (only in read-write mode).
@@ -146,6 +218,11 @@ func open(storagePath, colonyID string, connectionsCacheTTL time.Duration, logge
 			_ = db.Close() // TODO: errcheck
 			return nil, fmt.Errorf("failed to initialize schema: %w", err)
 		}
+
+		if err := duckdb.ApplyMigrations(context.Background(), db, migrationScope, migrations, logger); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("failed to migrate schema: %w", err)
+		}
 	}
 
 	mode := "read-write"
@@ -157,8 +234,10 @@ func open(storagePath, colonyID string, connectionsCacheTTL time.Duration, logge
 		Str("path", dbPath).
 		Str("colony_id", colonyID).
 		Str("mode", mode).
+		Bool("encrypted", encrypted != nil).
 		Msg("Database initialized")
 
+	opened = true
 	return database, nil
 }
 
@@ -168,9 +247,22 @@ func (d *Database) Close() error {
 		return nil
 	}
 
+	// Fold the WAL into the working copy of an encrypted database, which is
+	// encrypted without it.
+	if d.encryption != nil && !d.readOnly {
+		if err := d.Checkpoint(context.Background()); err != nil {
+			d.logger.Warn().Err(err).Msg("Failed to checkpoint database before encrypting it")
+		}
+	}
+
 	if err := d.db.Close(); err != nil {
 		return fmt.Errorf("failed to close database: %w", err)
 	}
+	if d.encryption != nil {
+		if err := d.closeEncrypted(); err != nil {
+			return err
+		}
+	}
 
 	d.logger.Info().
 		Str("path", d.path).
@@ -10,6 +10,8 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+
+	"github.com/coral-mesh/coral/internal/duckdb"
 )
 
 // DebugEvent represents a stored uprobe event.
@@ -29,6 +31,10 @@ type DebugEvent struct {
 	Args         *string   `duckdb:"args"`
 	ReturnValue  *string   `duckdb:"return_value"`
 	Labels       *string   `duckdb:"labels"`
+	Redacted     bool      `duckdb:"redacted"`
+	GoroutineID  *int64    `duckdb:"goroutine_id"`
+	HTTP         *string   `duckdb:"http"`
+	TLS          *string   `duckdb:"tls"`
 }
 
 // InsertDebugEvents persists a batch of uprobe events to the database.
@@ -40,7 +46,7 @@ func (d *Database) InsertDebugEvents(ctx context.Context, sessionID string, even
 	var items []*DebugEvent
 	for _, event := range events {
 		// Serialize complex fields to JSON
-		var argsJSON, returnValueJSON, labelsJSON *string
+		var argsJSON, returnValueJSON, labelsJSON, httpJSON, tlsJSON *string
 
 		if len(event.Args) > 0 {
 			argsBytes, err := json.Marshal(event.Args)
@@ -69,6 +75,24 @@ func (d *Database) InsertDebugEvents(ctx context.Context, sessionID string, even
 			labelsJSON = &labelsStr
 		}
 
+		if event.Http != nil {
+			httpBytes, err := json.Marshal(event.Http)
+			if err != nil {
+				return fmt.Errorf("failed to marshal http: %w", err)
+			}
+			httpStr := string(httpBytes)
+			httpJSON = &httpStr
+		}
+
+		if event.Tls != nil {
+			tlsBytes, err := json.Marshal(event.Tls)
+			if err != nil {
+				return fmt.Errorf("failed to marshal tls: %w", err)
+			}
+			tlsStr := string(tlsBytes)
+			tlsJSON = &tlsStr
+		}
+
 		// Handle nullable duration_ns (only for return events)
 		var durationNs *int64
 		if event.DurationNs > 0 {
@@ -84,6 +108,11 @@ func (d *Database) InsertDebugEvents(ctx context.Context, sessionID string, even
 		if event.Tid != 0 {
 			tid = &event.Tid
 		}
+		var goroutineID *int64
+		if event.GoroutineId != 0 {
+			goroutineID = new(int64)
+			*goroutineID = int64(event.GoroutineId) // #nosec G115
+		}
 
 		// Note: ID field is marked with `duckdb:"-"` so it's excluded from inserts.
 		// DuckDB will auto-generate IDs using seq_debug_events_id sequence.
@@ -101,6 +130,10 @@ func (d *Database) InsertDebugEvents(ctx context.Context, sessionID string, even
 			Args:         argsJSON,
 			ReturnValue:  returnValueJSON,
 			Labels:       labelsJSON,
+			Redacted:     event.Redacted,
+			GoroutineID:  goroutineID,
+			HTTP:         httpJSON,
+			TLS:          tlsJSON,
 		})
 	}
 
@@ -111,7 +144,8 @@ func (d *Database) InsertDebugEvents(ctx context.Context, sessionID string, even
 func (d *Database) GetDebugEvents(sessionID string) ([]*agentv1.UprobeEvent, error) {
 	query := `
 		SELECT timestamp, collector_id, agent_id, service_name, function_name,
-		       event_type, duration_ns, pid, tid, args, return_value, labels
+		       event_type, duration_ns, pid, tid, args, return_value, labels,
+		       COALESCE(redacted, false), goroutine_id, http, tls
 		FROM debug_events
 		WHERE session_id = ?
 		ORDER BY timestamp ASC
@@ -123,13 +157,20 @@ func (d *Database) GetDebugEvents(sessionID string) ([]*agentv1.UprobeEvent, err
 	}
 	defer func() { _ = rows.Close() }()
 
+	return scanDebugEvents(rows)
+}
+
+// scanDebugEvents scans rows of the columns selected by GetDebugEvents.
+func scanDebugEvents(rows *sql.Rows) ([]*agentv1.UprobeEvent, error) {
 	var events []*agentv1.UprobeEvent
 	for rows.Next() {
 		var timestamp time.Time
 		var collectorID, agentID, serviceName, functionName, eventType string
 		var durationNs sql.NullInt64
 		var pid, tid sql.NullInt32
-		var argsJSON, returnValueJSON, labelsJSON sql.NullString
+		var argsJSON, returnValueJSON, labelsJSON, httpJSON, tlsJSON sql.NullString
+		var redacted bool
+		var goroutineID sql.NullInt64
 
 		if err := rows.Scan(
 			&timestamp,
@@ -144,6 +185,10 @@ func (d *Database) GetDebugEvents(sessionID string) ([]*agentv1.UprobeEvent, err
 			&argsJSON,
 			&returnValueJSON,
 			&labelsJSON,
+			&redacted,
+			&goroutineID,
+			&httpJSON,
+			&tlsJSON,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan debug event: %w", err)
 		}
@@ -155,6 +200,7 @@ func (d *Database) GetDebugEvents(sessionID string) ([]*agentv1.UprobeEvent, err
 			ServiceName:  serviceName,
 			FunctionName: functionName,
 			EventType:    eventType,
+			Redacted:     redacted,
 		}
 
 		if durationNs.Valid {
@@ -169,6 +215,10 @@ func (d *Database) GetDebugEvents(sessionID string) ([]*agentv1.UprobeEvent, err
 			event.Tid = tid.Int32
 		}
 
+		if goroutineID.Valid {
+			event.GoroutineId = uint64(goroutineID.Int64) // #nosec G115
+		}
+
 		// Deserialize JSON fields
 		if argsJSON.Valid && argsJSON.String != "" {
 			var args []*agentv1.FunctionArgument
@@ -194,6 +244,22 @@ func (d *Database) GetDebugEvents(sessionID string) ([]*agentv1.UprobeEvent, err
 			event.Labels = labels
 		}
 
+		if httpJSON.Valid && httpJSON.String != "" {
+			var exchange agentv1.HttpExchange
+			if err := json.Unmarshal([]byte(httpJSON.String), &exchange); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal http: %w", err)
+			}
+			event.Http = &exchange
+		}
+
+		if tlsJSON.Valid && tlsJSON.String != "" {
+			var data agentv1.TlsData
+			if err := json.Unmarshal([]byte(tlsJSON.String), &data); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal tls: %w", err)
+			}
+			event.Tls = &data
+		}
+
 		events = append(events, event)
 	}
 
@@ -224,3 +290,113 @@ func (d *Database) DeleteOldDebugEvents(retentionPeriod time.Duration) error {
 	}
 	return nil
 }
+
+// debugCallFilter matches the return events that measure a call.
+const debugCallFilter = "event_type = 'return' AND duration_ns > 0"
+
+// DebugEventStats are the statistics of the events of a debug session,
+// computed by DuckDB.
+type DebugEventStats struct {
+	// Events is the number of events, entry events included.
+	Events int64
+	// Calls is the number of return events with a duration.
+	Calls  int64
+	Errors int64
+	P50    time.Duration
+	P95    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+}
+
+// QueryDebugEventStats computes the call count, error count and latency
+// percentiles of a debug session in DuckDB, without loading its events.
+func (d *Database) QueryDebugEventStats(ctx context.Context, sessionID string) (*DebugEventStats, error) {
+	// Aggregates over the calls only; COUNT(*) also counts entry events.
+	calls := func(aggregate string) string {
+		return fmt.Sprintf("COALESCE(%s FILTER (WHERE %s), 0)", aggregate, debugCallFilter)
+	}
+	query, args, err := duckdb.NewQueryBuilder("debug_events").
+		Select("COUNT(*)",
+			calls("COUNT(*)"),
+			fmt.Sprintf(`COUNT(*) FILTER (WHERE %s AND return_value LIKE '%%"is_error":true%%')`, debugCallFilter),
+			calls("quantile_disc(duration_ns, 0.50)"),
+			calls("quantile_disc(duration_ns, 0.95)"),
+			calls("quantile_disc(duration_ns, 0.99)"),
+			calls("MAX(duration_ns)")).
+		Eq("session_id", sessionID).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	var (
+		stats                      DebugEventStats
+		p50, p95, p99, maxDuration int64
+	)
+	if err := d.db.QueryRowContext(ctx, query, args...).Scan(&stats.Events, &stats.Calls, &stats.Errors,
+		&p50, &p95, &p99, &maxDuration); err != nil {
+		return nil, fmt.Errorf("failed to query debug event stats: %w", err)
+	}
+	stats.P50 = time.Duration(p50)
+	stats.P95 = time.Duration(p95)
+	stats.P99 = time.Duration(p99)
+	stats.Max = time.Duration(maxDuration)
+	return &stats, nil
+}
+
+// QueryDebugEventOutliers returns the limit slowest return events of a debug
+// session that took longer than threshold, slowest first. Only the IDs of the
+// outliers are selected by duration; the events themselves are loaded by ID.
+func (d *Database) QueryDebugEventOutliers(ctx context.Context, sessionID string, threshold time.Duration, limit int) ([]*agentv1.UprobeEvent, error) {
+	query, args, err := duckdb.NewQueryBuilder("debug_events").
+		Select("id").
+		Eq("session_id", sessionID).
+		Where(debugCallFilter).
+		Gt("duration_ns", threshold.Nanoseconds()).
+		OrderBy("-duration_ns").
+		Limit(limit).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query debug event outliers: %w", err)
+	}
+	var ids []any
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("failed to scan debug event outlier: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating debug event outliers: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query, args, err = duckdb.NewQueryBuilder("debug_events").
+		Select("timestamp", "collector_id", "agent_id", "service_name", "function_name",
+			"event_type", "duration_ns", "pid", "tid", "args", "return_value", "labels",
+			"COALESCE(redacted, false)", "goroutine_id", "http", "tls").
+		In("id", ids...).
+		OrderBy("-duration_ns").
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err = d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query debug events: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	return scanDebugEvents(rows)
+}
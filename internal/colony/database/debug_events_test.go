@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestQueryDebugEventStats(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute)
+
+	// 100 calls taking 1..100ms, every tenth returning an error.
+	var events []*agentv1.UprobeEvent
+	for i := 0; i < 100; i++ {
+		ts := start.Add(time.Duration(i) * time.Millisecond)
+		events = append(events, &agentv1.UprobeEvent{
+			Timestamp:    timestamppb.New(ts),
+			CollectorId:  "col-1",
+			AgentId:      "agent-1",
+			ServiceName:  "checkout",
+			FunctionName: "main.Pay",
+			EventType:    "entry",
+			Tid:          int32(i),
+		})
+		ret := &agentv1.UprobeEvent{
+			Timestamp:    timestamppb.New(ts.Add(time.Duration(i+1) * time.Millisecond)),
+			CollectorId:  "col-1",
+			AgentId:      "agent-1",
+			ServiceName:  "checkout",
+			FunctionName: "main.Pay",
+			EventType:    "return",
+			DurationNs:   uint64(i+1) * uint64(time.Millisecond),
+			Tid:          int32(i),
+		}
+		if i%10 == 0 {
+			ret.ReturnValue = &agentv1.FunctionReturnValue{IsError: true}
+		}
+		events = append(events, ret)
+	}
+	require.NoError(t, db.InsertDebugEvents(ctx, "sess-1", events))
+
+	stats, err := db.QueryDebugEventStats(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), stats.Events)
+	assert.Equal(t, int64(100), stats.Calls)
+	assert.Equal(t, int64(10), stats.Errors)
+	assert.Equal(t, 50*time.Millisecond, stats.P50)
+	assert.Equal(t, 95*time.Millisecond, stats.P95)
+	assert.Equal(t, 99*time.Millisecond, stats.P99)
+	assert.Equal(t, 100*time.Millisecond, stats.Max)
+
+	// The slowest calls above the threshold, slowest first.
+	outliers, err := db.QueryDebugEventOutliers(ctx, "sess-1", stats.P95, 3)
+	require.NoError(t, err)
+	require.Len(t, outliers, 3)
+	assert.Equal(t, uint64(100*time.Millisecond), outliers[0].DurationNs)
+	assert.Equal(t, uint64(98*time.Millisecond), outliers[2].DurationNs)
+	assert.Equal(t, int32(99), outliers[0].Tid)
+
+	// Sessions without events have empty stats and no outliers.
+	stats, err = db.QueryDebugEventStats(ctx, "sess-2")
+	require.NoError(t, err)
+	assert.Equal(t, DebugEventStats{}, *stats)
+
+	outliers, err = db.QueryDebugEventOutliers(ctx, "sess-2", 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, outliers)
+}
@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Debug function stats roll up the return events of debug_events into
+// per-minute, per-session and per-function buckets of call counts, error
+// counts and latency percentiles, so that statistics over a session or a
+// function do not scan its events. Like the Beyla rollups, the table tracks
+// how far it has been built in rollup_watermarks; events arriving after their
+// minute was rolled up are only in debug_events.
+
+// debugFunctionStatsTable is the summary table and its watermark name.
+const debugFunctionStatsTable = "debug_function_stats"
+
+// debugStatsStep is the bucket size of the debug function stats.
+const debugStatsStep = time.Minute
+
+// DebugEventSelector selects the debug events rolled up by an aggregation
+// pipeline. Patterns are DuckDB GLOB patterns; an empty list matches all.
+type DebugEventSelector struct {
+	Services  []string
+	Functions []string
+}
+
+// DebugFunctionStats are the statistics of a function over one or more
+// minutes. Percentiles over several minutes are the call-weighted averages of
+// the per-minute percentiles, so they are approximate.
+type DebugFunctionStats struct {
+	ServiceName  string
+	FunctionName string
+	Calls        int64
+	Errors       int64
+	P50          time.Duration
+	P95          time.Duration
+	P99          time.Duration
+	Max          time.Duration
+	// Minutes is the number of minutes with calls.
+	Minutes int64
+	// LastBucket is the start of the last minute with calls.
+	LastBucket time.Time
+}
+
+// ErrorRate returns the fraction of calls that returned an error.
+func (s *DebugFunctionStats) ErrorRate() float64 {
+	if s.Calls == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Calls)
+}
+
+// CallsPerMinute returns the average number of calls per minute with calls.
+func (s *DebugFunctionStats) CallsPerMinute() float64 {
+	if s.Minutes == 0 {
+		return 0
+	}
+	return float64(s.Calls) / float64(s.Minutes)
+}
+
+// RollupDebugEvents rolls up the debug events selected by selectors in the
+// minutes that ended at least lateness before now and are not rolled up
+// yet. With no selectors, all events are rolled up. An event matching
+// several selectors is counted once.
+func (d *Database) RollupDebugEvents(ctx context.Context, now time.Time, lateness time.Duration, selectors []DebugEventSelector) error {
+	start, err := d.rollupWatermark(ctx, debugFunctionStatsTable)
+	if err != nil {
+		return err
+	}
+	if start.IsZero() {
+		// First run: start from the oldest event.
+		var oldest sql.NullTime
+		if err := d.db.QueryRowContext(ctx, "SELECT MIN(timestamp) FROM debug_events").Scan(&oldest); err != nil {
+			return fmt.Errorf("failed to find oldest debug event: %w", err)
+		}
+		if !oldest.Valid {
+			return nil
+		}
+		start = oldest.Time.UTC().Truncate(debugStatsStep)
+	}
+
+	end := now.Add(-lateness).UTC().Truncate(debugStatsStep)
+	if !end.After(start) {
+		return nil
+	}
+
+	filter, filterArgs := debugEventFilter(selectors)
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Return values are stored as JSON; error returns are matched as in
+	// QueryErrorGroups. time_bucket needs a plain TIMESTAMP; timestamps are
+	// stored in UTC.
+	args := append([]any{start, end}, filterArgs...)
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO debug_function_stats (bucket, session_id, service_name, function_name, calls, errors, p50_ns, p95_ns, p99_ns, max_ns)
+		SELECT time_bucket(INTERVAL '%d seconds', timestamp::TIMESTAMP)::TIMESTAMPTZ AS bucket,
+		       session_id, ANY_VALUE(service_name), function_name,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE return_value LIKE '%%"is_error":true%%'),
+		       quantile_disc(duration_ns, 0.50),
+		       quantile_disc(duration_ns, 0.95),
+		       quantile_disc(duration_ns, 0.99),
+		       MAX(duration_ns)
+		FROM debug_events
+		WHERE timestamp >= ? AND timestamp < ? AND event_type = 'return' AND duration_ns > 0%s
+		GROUP BY bucket, session_id, function_name
+		ON CONFLICT DO UPDATE SET
+			calls = EXCLUDED.calls, errors = EXCLUDED.errors,
+			p50_ns = EXCLUDED.p50_ns, p95_ns = EXCLUDED.p95_ns,
+			p99_ns = EXCLUDED.p99_ns, max_ns = EXCLUDED.max_ns
+	`, int(debugStatsStep.Seconds()), filter), args...); err != nil {
+		return fmt.Errorf("failed to roll up debug events: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO rollup_watermarks (table_name, rolled_up_to) VALUES (?, ?)
+		ON CONFLICT (table_name) DO UPDATE SET rolled_up_to = EXCLUDED.rolled_up_to
+	`, debugFunctionStatsTable, end); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// debugEventFilter returns the SQL condition, starting with " AND", matching
+// the events of any of selectors, and its arguments.
+func debugEventFilter(selectors []DebugEventSelector) (string, []any) {
+	var (
+		alternatives []string
+		args         []any
+	)
+	anyOf := func(column string, patterns []string) string {
+		conds := make([]string, len(patterns))
+		for i, pattern := range patterns {
+			conds[i] = column + " GLOB ?"
+			args = append(args, pattern)
+		}
+		return "(" + strings.Join(conds, " OR ") + ")"
+	}
+	for _, sel := range selectors {
+		var conds []string
+		if len(sel.Services) > 0 {
+			conds = append(conds, anyOf("service_name", sel.Services))
+		}
+		if len(sel.Functions) > 0 {
+			conds = append(conds, anyOf("function_name", sel.Functions))
+		}
+		if len(conds) == 0 {
+			// This selector matches all events.
+			return "", nil
+		}
+		alternatives = append(alternatives, strings.Join(conds, " AND "))
+	}
+	if len(alternatives) == 0 {
+		return "", nil
+	}
+	return " AND (" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// debugStatsColumns aggregates debug_function_stats rows into the columns
+// scanned by scanDebugFunctionStats.
+const debugStatsColumns = `
+	SUM(calls), SUM(errors),
+	CAST(SUM(p50_ns * calls) / SUM(calls) AS BIGINT),
+	CAST(SUM(p95_ns * calls) / SUM(calls) AS BIGINT),
+	CAST(SUM(p99_ns * calls) / SUM(calls) AS BIGINT),
+	MAX(max_ns), COUNT(DISTINCT bucket), MAX(bucket)`
+
+// scanDebugFunctionStats scans the service and function names followed by
+// debugStatsColumns.
+func scanDebugFunctionStats(row interface{ Scan(...any) error }) (*DebugFunctionStats, error) {
+	var (
+		stats                      DebugFunctionStats
+		p50, p95, p99, maxDuration int64
+	)
+	if err := row.Scan(&stats.ServiceName, &stats.FunctionName, &stats.Calls, &stats.Errors,
+		&p50, &p95, &p99, &maxDuration, &stats.Minutes, &stats.LastBucket); err != nil {
+		return nil, err
+	}
+	stats.P50 = time.Duration(p50)
+	stats.P95 = time.Duration(p95)
+	stats.P99 = time.Duration(p99)
+	stats.Max = time.Duration(maxDuration)
+	return &stats, nil
+}
+
+// GetDebugSessionStats returns the statistics of a debug session from the
+// debug function stats. It returns nil when the session has no rolled-up
+// calls or when some of its events are not rolled up yet, in which case the
+// statistics must be computed from the events.
+func (d *Database) GetDebugSessionStats(ctx context.Context, sessionID string) (*DebugFunctionStats, error) {
+	watermark, err := d.rollupWatermark(ctx, debugFunctionStatsTable)
+	if err != nil || watermark.IsZero() {
+		return nil, err
+	}
+
+	var pending bool
+	if err := d.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM debug_events WHERE session_id = ? AND timestamp >= ?)
+	`, sessionID, watermark).Scan(&pending); err != nil {
+		return nil, fmt.Errorf("failed to check pending debug events: %w", err)
+	}
+	if pending {
+		return nil, nil
+	}
+
+	stats, err := scanDebugFunctionStats(d.db.QueryRowContext(ctx, `
+		SELECT ANY_VALUE(service_name), ANY_VALUE(function_name),`+debugStatsColumns+`
+		FROM debug_function_stats
+		WHERE session_id = ?
+		HAVING COUNT(*) > 0
+	`, sessionID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query debug session stats: %w", err)
+	}
+	return stats, nil
+}
+
+// QueryDebugFunctionStats returns the statistics of each function of
+// serviceName, or of all services if empty, over the minutes since since.
+func (d *Database) QueryDebugFunctionStats(ctx context.Context, serviceName string, since time.Time) ([]*DebugFunctionStats, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT service_name, function_name,`+debugStatsColumns+`
+		FROM debug_function_stats
+		WHERE bucket >= ? AND (? = '' OR service_name = ?)
+		GROUP BY service_name, function_name
+	`, since, serviceName, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query debug function stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []*DebugFunctionStats
+	for rows.Next() {
+		stats, err := scanDebugFunctionStats(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan debug function stats: %w", err)
+		}
+		result = append(result, stats)
+	}
+	return result, rows.Err()
+}
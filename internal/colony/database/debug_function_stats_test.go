@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestRollupDebugEvents(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Minute)
+
+	// 100 returns per minute over the last 10 minutes, taking 1..100ms, every
+	// tenth returning an error; plus their entry events.
+	_, err = db.DB().Exec(`
+		INSERT INTO debug_events (session_id, timestamp, collector_id, agent_id, service_name, function_name, event_type, duration_ns, return_value)
+		SELECT 'sess-1', (?::TIMESTAMP + INTERVAL (m) MINUTE + INTERVAL (i) MILLISECOND)::TIMESTAMPTZ, 'col-1', 'agent-1', 'checkout', 'main.Pay',
+		       e, CASE WHEN e = 'return' THEN (i + 1) * 1000000 END,
+		       CASE WHEN e = 'return' AND i % 10 = 0 THEN '{"is_error":true}' END
+		FROM range(10) AS mm(m), range(100) AS ii(i), (VALUES ('entry'), ('return')) AS ee(e)
+	`, now.Add(-10*time.Minute).Format(time.DateTime))
+	require.NoError(t, err)
+	_, err = db.DB().Exec(`
+		INSERT INTO debug_events (session_id, timestamp, collector_id, agent_id, service_name, function_name, event_type, duration_ns)
+		SELECT 'sess-2', (?::TIMESTAMP + INTERVAL (i) SECOND)::TIMESTAMPTZ, 'col-2', 'agent-1', 'billing', 'main.Invoice', 'return', 5000000
+		FROM range(10) AS ii(i)
+	`, now.Add(-5*time.Minute).Format(time.DateTime))
+	require.NoError(t, err)
+
+	// Only checkout is selected; minutes up to 2 minutes ago are rolled up.
+	selectors := []DebugEventSelector{{Services: []string{"check*"}}}
+	require.NoError(t, db.RollupDebugEvents(ctx, now, 2*time.Minute, selectors))
+
+	watermark, err := db.rollupWatermark(ctx, debugFunctionStatsTable)
+	require.NoError(t, err)
+	assert.True(t, watermark.Equal(now.Add(-2*time.Minute)), "watermark %s", watermark)
+
+	var rows, calls, errs, p50 int64
+	require.NoError(t, db.DB().QueryRow(`SELECT count(*), max(calls), max(errors), max(p50_ns) FROM debug_function_stats`).
+		Scan(&rows, &calls, &errs, &p50))
+	assert.Equal(t, int64(8), rows)
+	assert.Equal(t, int64(100), calls)
+	assert.Equal(t, int64(10), errs)
+	assert.Equal(t, int64(50*time.Millisecond), p50)
+
+	// The session still has events after the watermark.
+	stats, err := db.GetDebugSessionStats(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Nil(t, stats)
+
+	stats, err = db.GetDebugSessionStats(ctx, "sess-2")
+	require.NoError(t, err)
+	assert.Nil(t, stats)
+
+	functions, err := db.QueryDebugFunctionStats(ctx, "", now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, functions, 1)
+	fn := functions[0]
+	assert.Equal(t, "checkout", fn.ServiceName)
+	assert.Equal(t, "main.Pay", fn.FunctionName)
+	assert.Equal(t, int64(800), fn.Calls)
+	assert.InDelta(t, 0.1, fn.ErrorRate(), 1e-9)
+	assert.InDelta(t, 100.0, fn.CallsPerMinute(), 1e-9)
+	assert.Equal(t, 95*time.Millisecond, fn.P95)
+	assert.Equal(t, 100*time.Millisecond, fn.Max)
+	assert.True(t, fn.LastBucket.Equal(now.Add(-3*time.Minute)), "last bucket %s", fn.LastBucket)
+
+	// Once all its events are rolled up, a session's stats come from the
+	// rollups.
+	require.NoError(t, db.RollupDebugEvents(ctx, now.Add(2*time.Minute), 2*time.Minute, selectors))
+	stats, err = db.GetDebugSessionStats(ctx, "sess-1")
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+	assert.Equal(t, int64(1000), stats.Calls)
+	assert.Equal(t, int64(10), stats.Minutes)
+	assert.Equal(t, 99*time.Millisecond, stats.P99)
+}
+
+func TestDebugEventFilter(t *testing.T) {
+	filter, args := debugEventFilter(nil)
+	assert.Empty(t, filter)
+	assert.Empty(t, args)
+
+	// A selector without patterns matches all events.
+	filter, _ = debugEventFilter([]DebugEventSelector{{Services: []string{"api"}}, {}})
+	assert.Empty(t, filter)
+
+	filter, args = debugEventFilter([]DebugEventSelector{
+		{Services: []string{"api", "web"}, Functions: []string{"main.*"}},
+		{Functions: []string{"db.Query"}},
+	})
+	assert.Equal(t, " AND ((service_name GLOB ? OR service_name GLOB ?) AND (function_name GLOB ?) OR (function_name GLOB ?))", filter)
+	assert.Equal(t, []any{"api", "web", "main.*", "db.Query"}, args)
+}
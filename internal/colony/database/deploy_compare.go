@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ServiceWindowStats are the request metrics of a service over a time window,
+// from the eBPF HTTP metrics and the OTLP span summaries.
+type ServiceWindowStats struct {
+	Requests int64
+	Errors   int64
+	P50Ms    float64
+	P95Ms    float64
+	P99Ms    float64
+}
+
+// ErrorRate returns the percentage of failed requests.
+func (s *ServiceWindowStats) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests) * 100
+}
+
+// ErrorSignature is a kind of failed request, such as "HTTP 503 POST
+// /checkout", and how often it occurred.
+type ErrorSignature struct {
+	Signature string
+	Count     int64
+}
+
+// QueryServiceWindowStats returns the request count, error count and latency
+// percentiles of a service between start and end. As with
+// QueryServiceLatencyP95, a percentile is the higher of the eBPF histogram's
+// and the OTLP spans'.
+func (d *Database) QueryServiceWindowStats(ctx context.Context, serviceName string, start, end time.Time) (*ServiceWindowStats, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT latency_bucket_ms, SUM(count),
+		       SUM(CASE WHEN http_status_code >= 500 THEN count ELSE 0 END)
+		FROM beyla_http_metrics
+		WHERE service_name = ? AND timestamp >= ? AND timestamp < ?
+		GROUP BY latency_bucket_ms
+		ORDER BY latency_bucket_ms
+	`, serviceName, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query latency histogram: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	stats := &ServiceWindowStats{}
+	var buckets []latencyBucket
+	for rows.Next() {
+		var b latencyBucket
+		var errors int64
+		if err := rows.Scan(&b.upperMs, &b.count, &errors); err != nil {
+			return nil, fmt.Errorf("failed to scan latency histogram: %w", err)
+		}
+		buckets = append(buckets, b)
+		stats.Requests += b.count
+		stats.Errors += errors
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating latency histogram: %w", err)
+	}
+
+	stats.P50Ms, _ = histogramQuantile(buckets, 0.50)
+	stats.P95Ms, _ = histogramQuantile(buckets, 0.95)
+	stats.P99Ms, _ = histogramQuantile(buckets, 0.99)
+
+	var spans, spanErrors sql.NullInt64
+	var p50, p95, p99 sql.NullFloat64
+	err = d.db.QueryRowContext(ctx, `
+		SELECT SUM(total_spans), SUM(error_count), MAX(p50_ms), MAX(p95_ms), MAX(p99_ms)
+		FROM otel_summaries
+		WHERE service_name = ? AND bucket_time >= ? AND bucket_time < ? AND total_spans > 0
+	`, serviceName, start, end).Scan(&spans, &spanErrors, &p50, &p95, &p99)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query span summaries: %w", err)
+	}
+
+	stats.Requests += spans.Int64
+	stats.Errors += spanErrors.Int64
+	stats.P50Ms = max(stats.P50Ms, p50.Float64)
+	stats.P95Ms = max(stats.P95Ms, p95.Float64)
+	stats.P99Ms = max(stats.P99Ms, p99.Float64)
+
+	return stats, nil
+}
+
+// QueryServiceErrorSignatures returns the kinds of failed requests of a
+// service between start and end, most frequent first: HTTP requests with a
+// 5xx status by method and route, and gRPC calls with a non-OK status by
+// method.
+func (d *Database) QueryServiceErrorSignatures(ctx context.Context, serviceName string, start, end time.Time) ([]ErrorSignature, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT 'HTTP ' || http_status_code || ' ' || COALESCE(http_method, '') || ' ' || COALESCE(http_route, ''), SUM(count)
+		FROM beyla_http_metrics
+		WHERE service_name = ? AND timestamp >= ? AND timestamp < ? AND http_status_code >= 500
+		GROUP BY http_status_code, http_method, http_route
+		UNION ALL
+		SELECT 'gRPC status ' || grpc_status_code || ' ' || COALESCE(grpc_method, ''), SUM(count)
+		FROM beyla_grpc_metrics
+		WHERE service_name = ? AND timestamp >= ? AND timestamp < ? AND grpc_status_code != 0
+		GROUP BY grpc_status_code, grpc_method
+	`, serviceName, start, end, serviceName, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query error signatures: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var signatures []ErrorSignature
+	for rows.Next() {
+		var s ErrorSignature
+		if err := rows.Scan(&s.Signature, &s.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan error signature: %w", err)
+		}
+		signatures = append(signatures, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating error signatures: %w", err)
+	}
+
+	sort.SliceStable(signatures, func(i, j int) bool {
+		return signatures[i].Count > signatures[j].Count
+	})
+	return signatures, nil
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestDeployComparisonQueries(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	deploy := time.Now().Add(-30 * time.Minute).Truncate(time.Minute)
+	before := deploy.Add(-10 * time.Minute)
+	after := deploy.Add(10 * time.Minute)
+
+	http := []struct {
+		at     time.Time
+		route  string
+		status int
+		bucket float64
+		count  int
+	}{
+		{before, "/", 200, 10, 99},
+		{before, "/", 503, 50, 1},
+		{after, "/", 200, 10, 45},
+		{after, "/", 200, 500, 45},
+		{after, "/checkout", 500, 1000, 10},
+	}
+	for _, m := range http {
+		_, err := db.db.ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', 'api', 'POST', ?, ?, ?, ?)
+		`, m.at, m.route, m.status, m.bucket, m.count)
+		require.NoError(t, err)
+	}
+	_, err = db.db.ExecContext(ctx, `
+		INSERT INTO beyla_grpc_metrics (timestamp, agent_id, service_name, grpc_method, grpc_status_code, latency_bucket_ms, count)
+		VALUES (?, 'agent-1', 'api', '/orders.Orders/Get', 14, 5, 3)
+	`, after)
+	require.NoError(t, err)
+
+	baseline, err := db.QueryServiceWindowStats(ctx, "api", deploy.Add(-30*time.Minute), deploy)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), baseline.Requests)
+	assert.InDelta(t, 1.0, baseline.ErrorRate(), 0.001)
+	assert.Equal(t, 10.0, baseline.P95Ms)
+
+	current, err := db.QueryServiceWindowStats(ctx, "api", deploy, deploy.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), current.Requests)
+	assert.InDelta(t, 10.0, current.ErrorRate(), 0.001)
+	assert.Equal(t, 500.0, current.P50Ms)
+	assert.Equal(t, 1000.0, current.P99Ms)
+
+	signatures, err := db.QueryServiceErrorSignatures(ctx, "api", deploy, deploy.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []ErrorSignature{
+		{Signature: "HTTP 500 POST /checkout", Count: 10},
+		{Signature: "gRPC status 14 /orders.Orders/Get", Count: 3},
+	}, signatures)
+
+	empty, err := db.QueryServiceWindowStats(ctx, "web", deploy, deploy.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Zero(t, empty.Requests)
+	assert.Zero(t, empty.ErrorRate())
+}
+
+func TestCompareHotspotsBetweenWindows(t *testing.T) {
+	db := setupTestDBForProfiling(t)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Minute)
+
+	framesOld, err := db.EncodeStackFrames(ctx, []string{"main", "handleRequest"})
+	require.NoError(t, err)
+	insertProfileSummarySQL(t, db, now.Add(-2*time.Hour), "agent-1", "test-svc", "build-1", framesOld, 100)
+
+	// Same build, but a new hotspot after the deploy boundary.
+	framesNew, err := db.EncodeStackFrames(ctx, []string{"main", "processOrder", "validateSignature"})
+	require.NoError(t, err)
+	insertProfileSummarySQL(t, db, now, "agent-1", "test-svc", "build-1", framesNew, 100)
+
+	indicators, err := db.CompareHotspotsBetweenWindows(ctx, "test-svc",
+		now.Add(-3*time.Hour), now.Add(-time.Hour), now.Add(-time.Hour), now.Add(time.Minute), 5)
+	require.NoError(t, err)
+
+	var types []string
+	for _, ind := range indicators {
+		types = append(types, ind.Type)
+	}
+	assert.ElementsMatch(t, []string{"new_hotspot", "decreased_hotspot"}, types)
+}
@@ -0,0 +1,253 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/storage"
+	"github.com/coral-mesh/coral/internal/privilege"
+)
+
+// encryptedSuffix is appended to the database file name for its encrypted
+// copy in the storage path.
+const encryptedSuffix = ".enc"
+
+// Encryption configures at-rest encryption of the colony database. DuckDB
+// cannot encrypt its files itself, so the database is stored encrypted in
+// the storage path and decrypted into a working copy in WorkDir while it is
+// open. The working copy is encrypted back by Seal and Close.
+type Encryption struct {
+	// Key is the AES-256 key (storage.KeySize bytes).
+	Key []byte
+
+	// WorkDir holds the decrypted working copy. Default: the storage path.
+	WorkDir string
+}
+
+// encryptedStorage is the state of an encrypted database.
+type encryptedStorage struct {
+	key     []byte
+	path    string // Encrypted database in the storage path.
+	tempDir string // Private decrypted copy of a read-only database.
+
+	sealMu sync.Mutex
+}
+
+// NewEncrypted opens the colony database stored encrypted with enc.
+// A database stored unencrypted is encrypted when it is closed.
+func NewEncrypted(storagePath, colonyID string, enc Encryption, connectionsCacheTTL time.Duration, logger zerolog.Logger) (*Database, error) {
+	return open(storagePath, colonyID, connectionsCacheTTL, logger, false, &enc)
+}
+
+// NewEncryptedReadOnly opens a private decrypted copy of the colony database
+// stored encrypted with enc, which is removed on Close.
+func NewEncryptedReadOnly(storagePath, colonyID string, enc Encryption, connectionsCacheTTL time.Duration, logger zerolog.Logger) (*Database, error) {
+	return open(storagePath, colonyID, connectionsCacheTTL, logger, true, &enc)
+}
+
+// Exists reports whether the colony database exists in storagePath, either
+// unencrypted or, with enc, encrypted or as a working copy.
+func Exists(storagePath, colonyID string, enc *Encryption) bool {
+	paths := []string{databasePath(storagePath, colonyID)}
+	if enc != nil {
+		paths = append(paths, databasePath(storagePath, colonyID)+encryptedSuffix, workingPath(storagePath, colonyID, *enc))
+	}
+	for _, path := range paths {
+		if fileExists(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareEncrypted returns the path DuckDB opens for a database encrypted
+// with enc. A working copy left by a colony that did not shut down cleanly,
+// or an unencrypted database, is newer than the encrypted file and is used
+// as is; otherwise the encrypted file is decrypted into the working copy.
+func prepareEncrypted(storagePath, colonyID string, enc Encryption, readOnly bool, logger zerolog.Logger) (string, *encryptedStorage, error) {
+	plainPath := databasePath(storagePath, colonyID)
+	workPath := workingPath(storagePath, colonyID, enc)
+	es := &encryptedStorage{key: enc.Key, path: plainPath + encryptedSuffix}
+
+	if err := os.MkdirAll(filepath.Dir(workPath), 0700); err != nil {
+		return "", nil, fmt.Errorf("failed to create encryption work directory: %w", err)
+	}
+
+	switch {
+	case fileExists(workPath):
+		if fileExists(es.path) {
+			logger.Warn().
+				Str("path", workPath).
+				Msg("Using the decrypted database left by an unclean shutdown")
+		}
+		return workPath, es, nil
+
+	case fileExists(plainPath):
+		if readOnly {
+			return plainPath, es, nil
+		}
+		logger.Info().
+			Str("path", plainPath).
+			Msg("Database is stored unencrypted; it is encrypted on shutdown")
+		for _, suffix := range []string{"", ".wal"} {
+			if err := moveFile(plainPath+suffix, workPath+suffix); err != nil && !os.IsNotExist(err) {
+				return "", nil, fmt.Errorf("failed to move database to the encryption work directory: %w", err)
+			}
+		}
+		return workPath, es, nil
+
+	case fileExists(es.path):
+		if readOnly {
+			tempDir, err := os.MkdirTemp(filepath.Dir(workPath), "."+colonyID+"-read-*")
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to create read-only database directory: %w", err)
+			}
+			es.tempDir = tempDir
+			workPath = filepath.Join(tempDir, colonyID+".duckdb")
+		}
+		if err := storage.DecryptFile(es.path, workPath, es.key); err != nil {
+			es.discard()
+			return "", nil, fmt.Errorf("failed to decrypt database %s: %w", es.path, err)
+		}
+		return workPath, es, nil
+	}
+
+	return workPath, es, nil
+}
+
+// Seal encrypts a consistent copy of the working database into the storage
+// path, so that a colony crash loses at most the changes made since. The copy
+// is a snapshot (ExportSnapshot) imported into a new database file. It is a
+// no-op for unencrypted and read-only databases.
+func (d *Database) Seal(ctx context.Context) error {
+	if d.encryption == nil || d.readOnly {
+		return nil
+	}
+	d.encryption.sealMu.Lock()
+	defer d.encryption.sealMu.Unlock()
+
+	tempDir, err := os.MkdirTemp(filepath.Dir(d.path), "."+d.colonyID+"-seal-*")
+	if err != nil {
+		return fmt.Errorf("failed to create seal directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	snapshotDir := filepath.Join(tempDir, "snapshot")
+	if err := d.ExportSnapshot(ctx, snapshotDir); err != nil {
+		return err
+	}
+	sealPath := filepath.Join(tempDir, d.colonyID+".duckdb")
+	if err := importSnapshot(ctx, snapshotDir, sealPath); err != nil {
+		return err
+	}
+	return d.encryption.encrypt(sealPath)
+}
+
+// closeEncrypted encrypts the working copy of a closed database back into
+// the storage path and removes it. A working copy that cannot be encrypted
+// is kept and used on the next open.
+func (d *Database) closeEncrypted() error {
+	es := d.encryption
+	if d.readOnly {
+		es.discard()
+		return nil
+	}
+
+	es.sealMu.Lock()
+	defer es.sealMu.Unlock()
+	if !fileExists(d.path) {
+		return nil
+	}
+	if fileExists(d.path + ".wal") {
+		return fmt.Errorf("failed to encrypt database: %s.wal was not checkpointed; the working copy is kept", d.path)
+	}
+	if err := es.encrypt(d.path); err != nil {
+		return err
+	}
+	removeDatabaseFiles(d.path)
+	return nil
+}
+
+// encrypt replaces the encrypted database with the database at path.
+func (es *encryptedStorage) encrypt(path string) error {
+	if err := storage.EncryptFile(path, es.path, es.key); err != nil {
+		return fmt.Errorf("failed to encrypt database: %w", err)
+	}
+	if err := privilege.FixFileOwnership(es.path); err != nil {
+		return fmt.Errorf("failed to fix encrypted database ownership: %w", err)
+	}
+	return nil
+}
+
+// discard removes the private copy of a read-only database, if any.
+func (es *encryptedStorage) discard() {
+	if es.tempDir != "" {
+		_ = os.RemoveAll(es.tempDir)
+	}
+}
+
+// databasePath returns the path of the unencrypted colony database.
+func databasePath(storagePath, colonyID string) string {
+	return filepath.Join(storagePath, colonyID+".duckdb")
+}
+
+// workingPath returns the path of the decrypted working copy of the colony
+// database.
+func workingPath(storagePath, colonyID string, enc Encryption) string {
+	if enc.WorkDir == "" {
+		return databasePath(storagePath, colonyID)
+	}
+	return databasePath(enc.WorkDir, colonyID)
+}
+
+// removeDatabaseFiles removes a database file and its WAL.
+func removeDatabaseFiles(path string) {
+	_ = os.Remove(path)
+	_ = os.Remove(path + ".wal")
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// moveFile renames src to dst, copying it when they are on different file
+// systems.
+func moveFile(src, dst string) error {
+	err := os.Rename(src, dst)
+	var linkErr *os.LinkError
+	if err == nil || !errors.As(err, &linkErr) || os.IsNotExist(err) {
+		return err
+	}
+
+	//nolint:gosec // G304: Path is in the colony storage directory.
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close() // nolint:errcheck
+
+	//nolint:gosec // G304: Path is in the encryption work directory.
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(dst)
+		return err
+	}
+	return os.Remove(src)
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/colony/storage"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestNewEncrypted(t *testing.T) {
+	ctx := context.Background()
+	storagePath := t.TempDir()
+	enc := Encryption{Key: bytes.Repeat([]byte{7}, storage.KeySize), WorkDir: filepath.Join(t.TempDir(), "work")}
+	open := func(enc Encryption) (*Database, error) {
+		return NewEncrypted(storagePath, "test-colony", enc, constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	}
+	countEvents := func(db *Database) int {
+		events, err := db.ListAgentHistoryEvents(ctx, AgentHistoryFilters{})
+		require.NoError(t, err)
+		return len(events)
+	}
+
+	db, err := open(enc)
+	require.NoError(t, err)
+	require.NoError(t, db.InsertAgentHistoryEvent(ctx, &AgentHistoryEvent{
+		Timestamp: time.Now(), AgentID: "agent-1", Event: AgentEventRegistered, Status: "healthy",
+	}))
+	require.NoError(t, db.Close())
+
+	// Only the encrypted database is left behind.
+	encPath := filepath.Join(storagePath, "test-colony.duckdb.enc")
+	require.FileExists(t, encPath)
+	assert.NoFileExists(t, filepath.Join(storagePath, "test-colony.duckdb"))
+	assert.NoFileExists(t, filepath.Join(enc.WorkDir, "test-colony.duckdb"))
+	data, err := os.ReadFile(encPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "agent-1")
+
+	_, err = open(Encryption{Key: bytes.Repeat([]byte{8}, storage.KeySize), WorkDir: enc.WorkDir})
+	assert.ErrorIs(t, err, storage.ErrDecrypt)
+
+	// Sealed changes survive the loss of the working copy, e.g. a crash
+	// with the work directory on a tmpfs.
+	db, err = open(enc)
+	require.NoError(t, err)
+	assert.Equal(t, 1, countEvents(db))
+	require.NoError(t, db.InsertAgentHistoryEvent(ctx, &AgentHistoryEvent{
+		Timestamp: time.Now(), AgentID: "agent-2", Event: AgentEventRegistered, Status: "healthy",
+	}))
+	require.NoError(t, db.Seal(ctx))
+	require.NoError(t, db.db.Close())
+	require.NoError(t, os.RemoveAll(enc.WorkDir))
+
+	db, err = open(enc)
+	require.NoError(t, err)
+	assert.Equal(t, 2, countEvents(db))
+	require.NoError(t, db.InsertAgentHistoryEvent(ctx, &AgentHistoryEvent{
+		Timestamp: time.Now(), AgentID: "agent-3", Event: AgentEventRegistered, Status: "healthy",
+	}))
+	require.NoError(t, db.Close())
+
+	// Read-only opens use a private copy removed on close.
+	ro, err := NewEncryptedReadOnly(storagePath, "test-colony", enc, constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	assert.Equal(t, 3, countEvents(ro))
+	require.NoError(t, ro.Close())
+	entries, err := os.ReadDir(enc.WorkDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.True(t, Exists(storagePath, "test-colony", &enc))
+	assert.False(t, Exists(storagePath, "test-colony", nil))
+}
+
+func TestNewEncrypted_EncryptsPlainDatabase(t *testing.T) {
+	storagePath := t.TempDir()
+	enc := Encryption{Key: bytes.Repeat([]byte{7}, storage.KeySize)}
+
+	db, err := New(storagePath, "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = NewEncrypted(storagePath, "test-colony", enc, constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	assert.FileExists(t, filepath.Join(storagePath, "test-colony.duckdb.enc"))
+	assert.NoFileExists(t, filepath.Join(storagePath, "test-colony.duckdb"))
+}
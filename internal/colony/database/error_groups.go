@@ -0,0 +1,229 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+)
+
+// Sources of error groups.
+const (
+	ErrorSourceHTTP = "http"
+	ErrorSourceGRPC = "grpc"
+	ErrorSourceGo   = "go"
+)
+
+// ErrorGroup is a kind of error of a service: failed HTTP requests or gRPC
+// calls by status and route, or Go errors by function and message.
+type ErrorGroup struct {
+	Service   string
+	Source    string
+	Route     string
+	Signature string
+	Example   string
+	Count     int64
+	FirstSeen time.Time
+	LastSeen  time.Time
+
+	// Trend holds the errors per bucket of the queried time range, oldest
+	// first.
+	Trend []int64
+}
+
+// errorMessagePlaceholders replace the parts of Go error messages that vary
+// between occurrences of the same error, in order.
+var errorMessagePlaceholders = []struct {
+	re          *regexp.Regexp
+	placeholder func(match string) string
+}{
+	{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), constPlaceholder("<id>")},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`), constPlaceholder("<ip>")},
+	{regexp.MustCompile(`\b0x[0-9a-fA-F]+\b|\b[0-9a-f]{8,}\b`), func(match string) string {
+		// Long runs of digits are numbers, not hashes.
+		if strings.Trim(match, "0123456789") == "" {
+			return match
+		}
+		return "<hex>"
+	}},
+	{regexp.MustCompile(`"[^"]*"|'[^']*'`), constPlaceholder(`"…"`)},
+	{regexp.MustCompile(`\b\d+(\.\d+)?`), constPlaceholder("<n>")},
+}
+
+func constPlaceholder(placeholder string) func(string) string {
+	return func(string) string { return placeholder }
+}
+
+// errorMessageSignature groups Go error messages that differ only by IDs,
+// addresses, quoted values or numbers, e.g. `user "42" not found` and
+// `user "7" not found`.
+func errorMessageSignature(msg string) string {
+	for _, p := range errorMessagePlaceholders {
+		msg = p.re.ReplaceAllStringFunc(msg, p.placeholder)
+	}
+	return msg
+}
+
+// QueryErrorGroups returns the errors of a service, or of all services if
+// serviceName is empty, between start and end, grouped by source, route and
+// signature, most frequent first. The trend of each group splits the time
+// range into buckets.
+func (d *Database) QueryErrorGroups(ctx context.Context, serviceName string, start, end time.Time, buckets int) ([]ErrorGroup, error) {
+	if buckets < 1 {
+		buckets = 1
+	}
+	span := end.Sub(start)
+
+	groups := make(map[string]*ErrorGroup)
+	add := func(service, source, route, signature, example string, at time.Time, count int64) {
+		key := service + "\x00" + source + "\x00" + route + "\x00" + signature
+		g, ok := groups[key]
+		if !ok {
+			g = &ErrorGroup{
+				Service:   service,
+				Source:    source,
+				Route:     route,
+				Signature: signature,
+				Example:   example,
+				FirstSeen: at,
+				LastSeen:  at,
+				Trend:     make([]int64, buckets),
+			}
+			groups[key] = g
+		}
+		g.Count += count
+		if at.Before(g.FirstSeen) {
+			g.FirstSeen = at
+		}
+		if at.After(g.LastSeen) {
+			g.LastSeen = at
+		}
+
+		bucket := 0
+		if span > 0 {
+			bucket = int(int64(at.Sub(start)) * int64(buckets) / int64(span))
+		}
+		g.Trend[min(max(bucket, 0), buckets-1)] += count
+	}
+
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT timestamp, service_name, COALESCE(http_method, ''), COALESCE(http_route, ''), http_status_code, SUM(count)
+		FROM beyla_http_metrics
+		WHERE timestamp >= ? AND timestamp < ? AND http_status_code >= 500 AND (? = '' OR service_name = ?)
+		GROUP BY timestamp, service_name, http_method, http_route, http_status_code
+	`, start, end, serviceName, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query HTTP errors: %w", err)
+	}
+	err = scanErrorRows(rows, func() error {
+		var at time.Time
+		var service, method, route string
+		var status int
+		var count int64
+		if err := rows.Scan(&at, &service, &method, &route, &status, &count); err != nil {
+			return err
+		}
+		if method != "" {
+			route = method + " " + route
+		}
+		add(service, ErrorSourceHTTP, route, "HTTP "+strconv.Itoa(status), "", at, count)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan HTTP errors: %w", err)
+	}
+
+	rows, err = d.db.QueryContext(ctx, `
+		SELECT timestamp, service_name, COALESCE(grpc_method, ''), grpc_status_code, SUM(count)
+		FROM beyla_grpc_metrics
+		WHERE timestamp >= ? AND timestamp < ? AND grpc_status_code != 0 AND (? = '' OR service_name = ?)
+		GROUP BY timestamp, service_name, grpc_method, grpc_status_code
+	`, start, end, serviceName, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query gRPC errors: %w", err)
+	}
+	err = scanErrorRows(rows, func() error {
+		var at time.Time
+		var service, method string
+		var status uint32
+		var count int64
+		if err := rows.Scan(&at, &service, &method, &status, &count); err != nil {
+			return err
+		}
+		add(service, ErrorSourceGRPC, method, "gRPC "+codes.Code(status).String(), "", at, count)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan gRPC errors: %w", err)
+	}
+
+	// Return values are stored as JSON; only error returns are decoded.
+	rows, err = d.db.QueryContext(ctx, `
+		SELECT timestamp, service_name, function_name, return_value
+		FROM debug_events
+		WHERE timestamp >= ? AND timestamp < ? AND event_type = 'return'
+		  AND return_value LIKE '%"is_error":true%' AND (? = '' OR service_name = ?)
+	`, start, end, serviceName, serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Go errors: %w", err)
+	}
+	err = scanErrorRows(rows, func() error {
+		var at time.Time
+		var service, function, returnValue string
+		if err := rows.Scan(&at, &service, &function, &returnValue); err != nil {
+			return err
+		}
+		var ret agentv1.FunctionReturnValue
+		if err := json.Unmarshal([]byte(returnValue), &ret); err != nil {
+			return fmt.Errorf("invalid return value of %s: %w", function, err)
+		}
+		msg := ret.ErrorMessage
+		if msg == "" {
+			msg = ret.Value
+		}
+		add(service, ErrorSourceGo, function, errorMessageSignature(msg), msg, at, 1)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan Go errors: %w", err)
+	}
+
+	result := make([]ErrorGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		if a.Route != b.Route {
+			return a.Route < b.Route
+		}
+		return a.Signature < b.Signature
+	})
+	return result, nil
+}
+
+// scanErrorRows calls scan for each row, then closes rows.
+func scanErrorRows(rows *sql.Rows, scan func() error) error {
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		if err := scan(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestQueryErrorGroups(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	end := time.Now().Truncate(time.Minute)
+	start := end.Add(-time.Hour)
+
+	for _, m := range []struct {
+		at      time.Time
+		service string
+		status  int
+		count   int
+	}{
+		{start.Add(5 * time.Minute), "api", 503, 4},
+		{start.Add(50 * time.Minute), "api", 503, 6},
+		{start.Add(50 * time.Minute), "api", 200, 100},
+		{start.Add(50 * time.Minute), "api", 404, 7},
+		{start.Add(-time.Minute), "api", 500, 1000}, // Before the time range.
+		{start.Add(20 * time.Minute), "web", 500, 2},
+	} {
+		_, err := db.db.ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', ?, 'POST', '/checkout', ?, 10, ?)
+		`, m.at, m.service, m.status, m.count)
+		require.NoError(t, err)
+	}
+	for _, status := range []int{0, 14} {
+		_, err := db.db.ExecContext(ctx, `
+			INSERT INTO beyla_grpc_metrics (timestamp, agent_id, service_name, grpc_method, grpc_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', 'api', '/orders.Orders/Get', ?, 10, 3)
+		`, start.Add(40*time.Minute), status)
+		require.NoError(t, err)
+	}
+
+	goEvent := func(at time.Time, ret *agentv1.FunctionReturnValue) *agentv1.UprobeEvent {
+		return &agentv1.UprobeEvent{
+			Timestamp:    timestamppb.New(at),
+			CollectorId:  "collector-1",
+			AgentId:      "agent-1",
+			ServiceName:  "api",
+			FunctionName: "main.loadUser",
+			EventType:    "return",
+			ReturnValue:  ret,
+		}
+	}
+	require.NoError(t, db.InsertDebugEvents(ctx, "session-1", []*agentv1.UprobeEvent{
+		goEvent(start.Add(10*time.Minute), &agentv1.FunctionReturnValue{Type: "error", IsError: true, ErrorMessage: `user "42" not found`}),
+		goEvent(start.Add(55*time.Minute), &agentv1.FunctionReturnValue{Type: "error", IsError: true, ErrorMessage: `user "7" not found`}),
+		goEvent(start.Add(55*time.Minute), &agentv1.FunctionReturnValue{Type: "error", Value: "<nil>"}),
+	}))
+
+	groups, err := db.QueryErrorGroups(ctx, "api", start, end, 4)
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+
+	http := groups[0]
+	assert.Equal(t, ErrorSourceHTTP, http.Source)
+	assert.Equal(t, "POST /checkout", http.Route)
+	assert.Equal(t, "HTTP 503", http.Signature)
+	assert.Equal(t, int64(10), http.Count)
+	assert.Equal(t, []int64{4, 0, 0, 6}, http.Trend)
+	assert.True(t, start.Add(5*time.Minute).Equal(http.FirstSeen))
+	assert.True(t, start.Add(50*time.Minute).Equal(http.LastSeen))
+
+	grpc := groups[1]
+	assert.Equal(t, ErrorSourceGRPC, grpc.Source)
+	assert.Equal(t, "/orders.Orders/Get", grpc.Route)
+	assert.Equal(t, "gRPC Unavailable", grpc.Signature)
+	assert.Equal(t, []int64{0, 0, 3, 0}, grpc.Trend)
+
+	goErr := groups[2]
+	assert.Equal(t, ErrorSourceGo, goErr.Source)
+	assert.Equal(t, "main.loadUser", goErr.Route)
+	assert.Equal(t, `user "…" not found`, goErr.Signature)
+	assert.Equal(t, `user "42" not found`, goErr.Example)
+	assert.Equal(t, int64(2), goErr.Count)
+	assert.Equal(t, []int64{1, 0, 0, 1}, goErr.Trend)
+
+	all, err := db.QueryErrorGroups(ctx, "", start, end, 4)
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	assert.Equal(t, "web", all[3].Service)
+}
+
+func TestErrorMessageSignature(t *testing.T) {
+	for msg, want := range map[string]string{
+		"dial tcp 10.0.0.12:5432: connect: connection refused":         "dial tcp <ip>: connect: connection refused",
+		"order 0b5e3c1a-7f2d-4c1e-9a3b-2d4e6f8a0c1e: payment declined": "order <id>: payment declined",
+		"timeout after 1.5s waiting for 3 replicas":                    "timeout after <n>s waiting for <n> replicas",
+		"invalid checksum 9f86d081884c7d65 at offset 0x1f":             "invalid checksum <hex> at offset <hex>",
+		"sequence 12345678 out of range":                               "sequence <n> out of range",
+		`key 'tenant-a' not found`:                                     `key "…" not found`,
+		"context canceled":                                             "context canceled",
+	} {
+		assert.Equal(t, want, errorMessageSignature(msg), msg)
+	}
+}
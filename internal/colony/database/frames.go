@@ -0,0 +1,355 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// frameBatchSize bounds the number of frames per dictionary query.
+const frameBatchSize = 500
+
+// Frame is an entry of the profile frame dictionary.
+type Frame struct {
+	ID   int64
+	Name string
+	// Module is the Go package path of the function, or "[kernel]" for
+	// kernel frames. It is empty for unresolved addresses.
+	Module string
+	// File and Line locate the function when the agent resolved debug info.
+	File string
+	Line int32
+}
+
+// FrameID returns the content-addressed ID of a frame name: the first 8 bytes
+// of its SHA-256, as a non-negative int64. The same frame has the same ID in
+// every colony. Frames stored before IDs were content-addressed keep their
+// sequential IDs.
+func FrameID(name string) int64 {
+	sum := sha256.Sum256([]byte(name))
+	// #nosec G115 - The top bit is cleared, so the value fits in an int64.
+	return int64(binary.BigEndian.Uint64(sum[:8]) &^ (1 << 63))
+}
+
+// ParseFrame returns the dictionary entry of a frame name as produced by the
+// agent symbolizer: "function", "function (file:line)", "[kernel] symbol" or
+// an unresolved "0x..." address.
+func ParseFrame(name string) Frame {
+	frame := Frame{ID: FrameID(name), Name: name}
+
+	if strings.HasPrefix(name, "[kernel] ") {
+		frame.Module = "[kernel]"
+		return frame
+	}
+
+	function := name
+	if i := strings.LastIndex(name, " ("); i >= 0 && strings.HasSuffix(name, ")") {
+		location := name[i+2 : len(name)-1]
+		if colon := strings.LastIndex(location, ":"); colon >= 0 {
+			if line, err := strconv.ParseInt(location[colon+1:], 10, 32); err == nil {
+				frame.File = location[:colon]
+				frame.Line = int32(line)
+				function = name[:i]
+			}
+		}
+	}
+
+	if strings.HasPrefix(function, "0x") {
+		return frame
+	}
+	// The package path ends at the first dot after the last slash, e.g.
+	// "github.com/x/y.(*T).M" is in "github.com/x/y".
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		frame.Module = function[:slash+1+dot]
+	}
+	return frame
+}
+
+// ProfileFrameStore caches the global frame dictionary for CPU and memory
+// profiles (RFD 072). This is similar to the agent-local frame dictionary but
+// shared across all services.
+type ProfileFrameStore struct {
+	mu    sync.RWMutex
+	ids   map[string]int64 // frame_name -> frame_id.
+	names map[int64]string // frame_id -> frame_name.
+}
+
+// NewProfileFrameStore creates a new profile frame store.
+func NewProfileFrameStore() *ProfileFrameStore {
+	return &ProfileFrameStore{
+		ids:   make(map[string]int64),
+		names: make(map[int64]string),
+	}
+}
+
+func (s *ProfileFrameStore) add(id int64, name string) {
+	s.ids[name] = id
+	s.names[id] = name
+}
+
+// reset drops the cache, e.g. after dictionary entries were deleted.
+func (s *ProfileFrameStore) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids = make(map[string]int64)
+	s.names = make(map[int64]string)
+}
+
+// EncodeStackFrames converts frame names to integer IDs using the global dictionary.
+// This provides 85% compression by storing frame names once and referencing by ID.
+func (d *Database) EncodeStackFrames(ctx context.Context, frameNames []string) ([]int64, error) {
+	encoded, err := d.EncodeStackFrameBatch(ctx, [][]string{frameNames})
+	if err != nil {
+		return nil, err
+	}
+	return encoded[0], nil
+}
+
+// EncodeStackFrameBatch converts the frame names of many stacks to IDs. Each
+// distinct frame is looked up and, if new, inserted once for the whole batch.
+func (d *Database) EncodeStackFrameBatch(ctx context.Context, stacks [][]string) ([][]int64, error) {
+	store := d.profileFrameStore
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	var missing []string
+	seen := make(map[string]bool)
+	for _, stack := range stacks {
+		for _, name := range stack {
+			if _, ok := store.ids[name]; !ok && !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		// Frames may already be stored, possibly under a legacy sequential ID.
+		found, err := d.lookupFrameIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+
+		var frames []Frame
+		for _, name := range missing {
+			if id, ok := found[name]; ok {
+				store.add(id, name)
+			} else {
+				frames = append(frames, ParseFrame(name))
+			}
+		}
+
+		if err := d.insertFrames(ctx, frames); err != nil {
+			return nil, err
+		}
+		for _, f := range frames {
+			store.add(f.ID, f.Name)
+		}
+	}
+
+	encoded := make([][]int64, len(stacks))
+	for i, stack := range stacks {
+		encoded[i] = make([]int64, len(stack))
+		for j, name := range stack {
+			encoded[i][j] = store.ids[name]
+		}
+	}
+	return encoded, nil
+}
+
+// lookupFrameIDs returns the IDs of the stored frames among names.
+func (d *Database) lookupFrameIDs(ctx context.Context, names []string) (map[string]int64, error) {
+	found := make(map[string]int64, len(names))
+	for start := 0; start < len(names); start += frameBatchSize {
+		batch := names[start:min(start+frameBatchSize, len(names))]
+		args := make([]interface{}, len(batch))
+		for i, name := range batch {
+			args[i] = name
+		}
+
+		rows, err := d.db.QueryContext(ctx, fmt.Sprintf(
+			`SELECT frame_id, frame_name FROM profile_frame_dictionary WHERE frame_name IN (%s)`,
+			placeholders(len(batch))), args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query frame dictionary: %w", err)
+		}
+		for rows.Next() {
+			var id int64
+			var name string
+			if err := rows.Scan(&id, &name); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to scan frame: %w", err)
+			}
+			found[name] = id
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error iterating frames: %w", err)
+		}
+	}
+	return found, nil
+}
+
+// insertFrames adds new frames to the dictionary.
+func (d *Database) insertFrames(ctx context.Context, frames []Frame) error {
+	for start := 0; start < len(frames); start += frameBatchSize {
+		batch := frames[start:min(start+frameBatchSize, len(frames))]
+		values := make([]string, len(batch))
+		args := make([]interface{}, 0, 5*len(batch))
+		for i, f := range batch {
+			values[i] = "(?, ?, ?, ?, ?)"
+			args = append(args, f.ID, f.Name, nullString(f.Module), nullString(f.File), nullInt32(f.Line))
+		}
+
+		// A conflict means another writer stored the frame concurrently, or
+		// two names share an ID; the latter is caught below.
+		if _, err := d.db.ExecContext(ctx, `
+			INSERT INTO profile_frame_dictionary (frame_id, frame_name, module, file, line)
+			VALUES `+strings.Join(values, ", ")+`
+			ON CONFLICT DO NOTHING
+		`, args...); err != nil {
+			return fmt.Errorf("failed to insert frames: %w", err)
+		}
+	}
+
+	names := make([]string, len(frames))
+	for i, f := range frames {
+		names[i] = f.Name
+	}
+	stored, err := d.lookupFrameIDs(ctx, names)
+	if err != nil {
+		return err
+	}
+	for i, f := range frames {
+		id, ok := stored[f.Name]
+		if !ok {
+			return fmt.Errorf("frame ID collision for %q", f.Name)
+		}
+		frames[i].ID = id
+	}
+	return nil
+}
+
+// DecodeStackFrames converts frame IDs back to frame names using the global dictionary.
+func (d *Database) DecodeStackFrames(ctx context.Context, frameIDs []int64) ([]string, error) {
+	decoded, err := d.DecodeStackFrameBatch(ctx, [][]int64{frameIDs})
+	if err != nil {
+		return nil, err
+	}
+	return decoded[0], nil
+}
+
+// DecodeStackFrameBatch converts the frame IDs of many stacks to names with a
+// single dictionary lookup for all uncached frames. Unknown IDs decode to
+// "unknown_frame_<id>".
+func (d *Database) DecodeStackFrameBatch(ctx context.Context, stacks [][]int64) ([][]string, error) {
+	store := d.profileFrameStore
+
+	var missing []int64
+	seen := make(map[int64]bool)
+	store.mu.RLock()
+	for _, stack := range stacks {
+		for _, id := range stack {
+			if _, ok := store.names[id]; !ok && !seen[id] {
+				seen[id] = true
+				missing = append(missing, id)
+			}
+		}
+	}
+	store.mu.RUnlock()
+
+	found := make(map[int64]string)
+	if len(missing) > 0 {
+		frames, err := d.LookupFrames(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		store.mu.Lock()
+		for _, f := range frames {
+			store.add(f.ID, f.Name)
+			found[f.ID] = f.Name
+		}
+		store.mu.Unlock()
+	}
+
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	decoded := make([][]string, len(stacks))
+	for i, stack := range stacks {
+		decoded[i] = make([]string, len(stack))
+		for j, id := range stack {
+			if name, ok := store.names[id]; ok {
+				decoded[i][j] = name
+			} else if name, ok := found[id]; ok {
+				decoded[i][j] = name
+			} else {
+				decoded[i][j] = fmt.Sprintf("unknown_frame_%d", id)
+			}
+		}
+	}
+	return decoded, nil
+}
+
+// LookupFrames returns the dictionary entries of the given frame IDs. IDs
+// without an entry are omitted.
+func (d *Database) LookupFrames(ctx context.Context, ids []int64) ([]Frame, error) {
+	var frames []Frame
+	for start := 0; start < len(ids); start += frameBatchSize {
+		batch := ids[start:min(start+frameBatchSize, len(ids))]
+		args := make([]interface{}, len(batch))
+		for i, id := range batch {
+			args[i] = id
+		}
+
+		rows, err := d.db.QueryContext(ctx, fmt.Sprintf(`
+			SELECT frame_id, frame_name, module, file, line
+			FROM profile_frame_dictionary WHERE frame_id IN (%s)
+		`, placeholders(len(batch))), args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query frame names: %w", err)
+		}
+		for rows.Next() {
+			var f Frame
+			var module, file sql.NullString
+			var line sql.NullInt32
+			if err := rows.Scan(&f.ID, &f.Name, &module, &file, &line); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to scan frame: %w", err)
+			}
+			f.Module, f.File, f.Line = module.String, file.String, line.Int32
+			frames = append(frames, f)
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error iterating rows: %w", err)
+		}
+	}
+	return frames, nil
+}
+
+// placeholders returns n comma-separated query placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullInt32(n int32) interface{} {
+	if n == 0 {
+		return nil
+	}
+	return n
+}
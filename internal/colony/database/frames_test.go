@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFrame(t *testing.T) {
+	tests := []struct {
+		name   string
+		module string
+		file   string
+		line   int32
+	}{
+		{"main.handle", "main", "", 0},
+		{"github.com/acme/api/server.(*Server).ServeHTTP (/src/server/http.go:42)", "github.com/acme/api/server", "/src/server/http.go", 42},
+		{"crypto/sha256.block", "crypto/sha256", "", 0},
+		{"[kernel] do_syscall_64", "[kernel]", "", 0},
+		{"0x4a5f10", "", "", 0},
+		{"main.func (not a location)", "main", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := ParseFrame(tt.name)
+			assert.Equal(t, tt.name, frame.Name)
+			assert.Equal(t, tt.module, frame.Module)
+			assert.Equal(t, tt.file, frame.File)
+			assert.Equal(t, tt.line, frame.Line)
+			assert.Equal(t, FrameID(tt.name), frame.ID)
+			assert.Positive(t, frame.ID)
+		})
+	}
+	assert.NotEqual(t, FrameID("main.a"), FrameID("main.b"))
+}
+
+func TestStackFrameBatch(t *testing.T) {
+	db := setupTestDBForProfiling(t)
+	ctx := context.Background()
+
+	// A frame stored with a sequential ID by an earlier release.
+	_, err := db.db.Exec(`INSERT INTO profile_frame_dictionary (frame_name) VALUES ('main.legacy')`)
+	require.NoError(t, err)
+	var legacyID int64
+	require.NoError(t, db.db.QueryRow(`SELECT frame_id FROM profile_frame_dictionary WHERE frame_name = 'main.legacy'`).Scan(&legacyID))
+
+	stacks := [][]string{
+		{"main.work (/src/main.go:10)", "main.legacy", "main.main"},
+		{"main.work (/src/main.go:10)", "runtime.goexit"},
+		{},
+	}
+	encoded, err := db.EncodeStackFrameBatch(ctx, stacks)
+	require.NoError(t, err)
+	require.Len(t, encoded, 3)
+	assert.Equal(t, encoded[0][0], encoded[1][0], "repeated frames share an ID")
+	assert.Equal(t, FrameID("main.work (/src/main.go:10)"), encoded[0][0])
+	assert.Equal(t, legacyID, encoded[0][1], "legacy frames keep their ID")
+	assert.Empty(t, encoded[2])
+
+	var count int
+	require.NoError(t, db.db.QueryRow(`SELECT count(*) FROM profile_frame_dictionary`).Scan(&count))
+	assert.Equal(t, 4, count, "each distinct frame is stored once")
+
+	// Decoding does not depend on the encoding cache.
+	db.profileFrameStore.reset()
+	decoded, err := db.DecodeStackFrameBatch(ctx, append(encoded, []int64{12345}))
+	require.NoError(t, err)
+	assert.Equal(t, stacks[0], decoded[0])
+	assert.Equal(t, stacks[1], decoded[1])
+	assert.Empty(t, decoded[2])
+	assert.Equal(t, []string{"unknown_frame_12345"}, decoded[3])
+
+	frames, err := db.LookupFrames(ctx, encoded[0][:1])
+	require.NoError(t, err)
+	require.Len(t, frames, 1)
+	assert.Equal(t, "main", frames[0].Module)
+	assert.Equal(t, "/src/main.go", frames[0].File)
+	assert.Equal(t, int32(10), frames[0].Line)
+
+	// Frames referenced by profile runs survive orphan cleanup.
+	require.NoError(t, db.InsertProfileRun(ctx, &ProfileRun{
+		ID: "r-1", ScheduleID: "s-1", ServiceName: "api", ProfileType: "cpu",
+		StartedAt: time.Now(), FinishedAt: time.Now(), Status: "success",
+		Folded: "main.main;main.work (/src/main.go:10) 5\n",
+	}))
+	deleted, err := db.CleanupOrphanedFrames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	// Deleted frames are stored again when they reappear.
+	again, err := db.EncodeStackFrames(ctx, []string{"runtime.goexit"})
+	require.NoError(t, err)
+	names, err := db.DecodeStackFrames(ctx, again)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"runtime.goexit"}, names)
+}
+
+func TestReencodeLegacyProfileRuns(t *testing.T) {
+	db := setupTestDBForProfiling(t)
+	ctx := context.Background()
+
+	// Runs stored by earlier releases hold their stacks as folded text.
+	folded := "main.main;main.work 3\nmain.main;main.idle 1\n"
+	for _, id := range []string{"r-1", "r-2", "r-3"} {
+		_, err := db.db.Exec(`
+			INSERT INTO profile_runs (id, schedule_id, service_name, profile_type, started_at, finished_at, status, error, total, unique_stacks, folded)
+			VALUES (?, 's-1', 'api', 'cpu', ?, ?, 'success', '', 4, 2, ?)
+		`, id, time.Now(), time.Now(), folded)
+		require.NoError(t, err)
+	}
+
+	n, err := db.ReencodeLegacyProfileRuns(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = db.ReencodeLegacyProfileRuns(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = db.ReencodeLegacyProfileRuns(ctx, 2)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	var legacy, stacks int
+	require.NoError(t, db.db.QueryRow(`SELECT count(*) FROM profile_runs WHERE folded <> ''`).Scan(&legacy))
+	require.NoError(t, db.db.QueryRow(`SELECT count(*) FROM profile_run_stacks`).Scan(&stacks))
+	assert.Zero(t, legacy)
+	assert.Equal(t, 6, stacks)
+
+	run, err := db.GetProfileRun(ctx, "r-2")
+	require.NoError(t, err)
+	assert.Equal(t, "main.main;main.idle 1\nmain.main;main.work 3\n", run.Folded)
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrMCPToolCallNotFound is returned by GetMCPToolCall for an unknown ID.
+var ErrMCPToolCallNotFound = errors.New("MCP tool call not found")
+
+// MCPToolCall is a recorded MCP tool call.
+type MCPToolCall struct {
+	ID          int64     `duckdb:"-"` // Auto-increment, ignore in ORM
+	Timestamp   time.Time `duckdb:"timestamp"`
+	Client      string    `duckdb:"client"`
+	Actor       string    `duckdb:"actor"`
+	Tool        string    `duckdb:"tool"`
+	Arguments   string    `duckdb:"arguments"` // JSON object sent by the client
+	Command     string    `duckdb:"command"`   // JSON array of coral CLI arguments
+	DurationMs  float64   `duckdb:"duration_ms"`
+	ResultBytes int64     `duckdb:"result_bytes"`
+	Result      string    `duckdb:"result"`
+	Error       string    `duckdb:"error"`
+}
+
+// MCPToolCallFilters contains filters for listing MCP tool calls.
+type MCPToolCallFilters struct {
+	Since  time.Time
+	Tool   string
+	Actor  string
+	Client string
+	Limit  int
+}
+
+const mcpToolCallColumns = `id, timestamp, client, actor, tool, arguments, command,
+		duration_ms, result_bytes, result, error`
+
+// InsertMCPToolCall records an MCP tool call.
+func (d *Database) InsertMCPToolCall(ctx context.Context, call *MCPToolCall) error {
+	if call.Timestamp.IsZero() {
+		call.Timestamp = time.Now()
+	}
+	return d.mcpToolCallsTable.Insert(ctx, call)
+}
+
+// ListMCPToolCalls retrieves MCP tool calls matching the provided filters,
+// newest first.
+func (d *Database) ListMCPToolCalls(ctx context.Context, filters MCPToolCallFilters) ([]*MCPToolCall, error) {
+	query := `SELECT ` + mcpToolCallColumns + ` FROM mcp_tool_calls WHERE 1=1`
+	args := []interface{}{}
+
+	if !filters.Since.IsZero() {
+		query += " AND timestamp >= ?"
+		args = append(args, filters.Since)
+	}
+
+	if filters.Tool != "" {
+		query += " AND tool = ?"
+		args = append(args, filters.Tool)
+	}
+
+	if filters.Actor != "" {
+		query += " AND actor = ?"
+		args = append(args, filters.Actor)
+	}
+
+	if filters.Client != "" {
+		query += " AND client = ?"
+		args = append(args, filters.Client)
+	}
+
+	query += " ORDER BY timestamp DESC, id DESC"
+
+	if filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filters.Limit)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MCP tool calls: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var calls []*MCPToolCall
+	for rows.Next() {
+		call, err := scanMCPToolCall(rows)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating MCP tool calls: %w", err)
+	}
+
+	return calls, nil
+}
+
+// GetMCPToolCall retrieves an MCP tool call by ID.
+func (d *Database) GetMCPToolCall(ctx context.Context, id int64) (*MCPToolCall, error) {
+	row := d.db.QueryRowContext(ctx, `SELECT `+mcpToolCallColumns+` FROM mcp_tool_calls WHERE id = ?`, id)
+	call, err := scanMCPToolCall(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrMCPToolCallNotFound
+	}
+	return call, err
+}
+
+func scanMCPToolCall(row interface{ Scan(...any) error }) (*MCPToolCall, error) {
+	var call MCPToolCall
+	var client, arguments, command, errMsg sql.NullString
+	var durationMs sql.NullFloat64
+	var resultBytes sql.NullInt64
+
+	if err := row.Scan(
+		&call.ID,
+		&call.Timestamp,
+		&client,
+		&call.Actor,
+		&call.Tool,
+		&arguments,
+		&command,
+		&durationMs,
+		&resultBytes,
+		&call.Result,
+		&errMsg,
+	); err != nil {
+		return nil, fmt.Errorf("failed to scan MCP tool call: %w", err)
+	}
+
+	call.Client = client.String
+	call.Arguments = arguments.String
+	call.Command = command.String
+	call.DurationMs = durationMs.Float64
+	call.ResultBytes = resultBytes.Int64
+	call.Error = errMsg.String
+
+	return &call, nil
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestMCPToolCalls(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	calls := []*MCPToolCall{
+		{Timestamp: now.Add(-48 * time.Hour), Client: "claude-ai 0.1.0", Actor: "alice", Tool: "coral_cli",
+			Arguments: `{"args":["query","summary"]}`, Command: `["query","summary"]`, DurationMs: 812, ResultBytes: 2048, Result: "ok"},
+		{Timestamp: now.Add(-time.Hour), Client: "cursor 1.2", Actor: "alice", Tool: "coral_get_service_topology",
+			Arguments: `{"service":"api"}`, Command: `["query","topology","--service","api"]`, Result: "ok"},
+		{Timestamp: now.Add(-time.Minute), Client: "claude-ai 0.1.0", Actor: "bob", Tool: "coral_cli",
+			Arguments: `{"args":["shell"]}`, Command: `["shell"]`, Result: "denied", Error: "permission denied"},
+	}
+	for _, c := range calls {
+		require.NoError(t, db.InsertMCPToolCall(ctx, c))
+	}
+
+	all, err := db.ListMCPToolCalls(ctx, MCPToolCallFilters{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "bob", all[0].Actor, "newest first")
+	assert.Equal(t, "permission denied", all[0].Error)
+
+	recent, err := db.ListMCPToolCalls(ctx, MCPToolCallFilters{Since: now.Add(-24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Len(t, recent, 2)
+
+	filtered, err := db.ListMCPToolCalls(ctx, MCPToolCallFilters{Tool: "coral_cli", Client: "claude-ai 0.1.0", Actor: "alice"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+
+	got, err := db.GetMCPToolCall(ctx, filtered[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, `{"args":["query","summary"]}`, got.Arguments)
+	assert.Equal(t, `["query","summary"]`, got.Command)
+	assert.Equal(t, 812.0, got.DurationMs)
+	assert.Equal(t, int64(2048), got.ResultBytes)
+
+	_, err = db.GetMCPToolCall(ctx, 999)
+	assert.ErrorIs(t, err, ErrMCPToolCallNotFound)
+
+	limited, err := db.ListMCPToolCalls(ctx, MCPToolCallFilters{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+}
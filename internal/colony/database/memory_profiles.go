@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 )
 
 // MemoryProfileSummary represents a 1-minute aggregated memory profile sample (RFD 077).
@@ -37,6 +39,8 @@ func (d *Database) InsertMemoryProfileSummaries(ctx context.Context, summaries [
 		return fmt.Errorf("failed to batch upsert memory profile summaries: %w", err)
 	}
 
+	d.queryCache.Invalidate(querycache.Profiles)
+
 	d.logger.Debug().
 		Int("summary_count", len(summaries)).
 		Msg("Inserted memory profile summaries")
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"context"
+
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// migrationScope identifies colony migrations in the schema_migrations table.
+const migrationScope = "colony"
+
+// migrations are the colony schema migrations, applied in order on top of the
+// baseline schema in schemaDDL. New tables may still be added to schemaDDL,
+// but changes to existing tables (new columns, type changes, backfills) must
+// be added here: the CREATE TABLE statements in schemaDDL only affect new
+// databases. Append new migrations; never edit or remove released ones.
+var migrations = []duckdb.Migration{
+	{
+		Version: 1,
+		Name:    "add_profile_frame_metadata",
+		SQL: `ALTER TABLE profile_frame_dictionary ADD COLUMN module VARCHAR;
+			ALTER TABLE profile_frame_dictionary ADD COLUMN file VARCHAR;
+			ALTER TABLE profile_frame_dictionary ADD COLUMN line INTEGER;`,
+	},
+	{
+		Version: 2,
+		Name:    "add_debug_events_redacted",
+		SQL:     `ALTER TABLE debug_events ADD COLUMN redacted BOOLEAN DEFAULT false;`,
+	},
+	{
+		Version: 3,
+		Name:    "add_debug_events_goroutine_id",
+		SQL:     `ALTER TABLE debug_events ADD COLUMN goroutine_id BIGINT;`,
+	},
+	{
+		Version: 4,
+		Name:    "add_debug_events_http",
+		SQL:     `ALTER TABLE debug_events ADD COLUMN http VARCHAR;`,
+	},
+	{
+		Version: 5,
+		Name:    "add_debug_events_tls",
+		SQL:     `ALTER TABLE debug_events ADD COLUMN tls VARCHAR;`,
+	},
+}
+
+// Migrate applies pending colony schema migrations and returns them. With
+// dryRun, it returns the pending migrations without applying them, which also
+// works on a database opened with NewReadOnly.
+func (d *Database) Migrate(ctx context.Context, dryRun bool) ([]duckdb.Migration, error) {
+	return duckdb.Migrate(ctx, d.db, migrationScope, migrations, dryRun)
+}
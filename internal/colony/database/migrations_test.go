@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/duckdb"
+	"github.com/rs/zerolog"
+)
+
+func TestMigrate_AppliedOnOpen(t *testing.T) {
+	tempDir := t.TempDir()
+	logger := zerolog.New(os.Stdout)
+
+	// Create a database with the current schema.
+	db, err := New(tempDir, "test-colony", constants.DefaultConnectionsCacheTTL, logger)
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	_ = db.Close()
+
+	// A newer release adds a column to an existing table.
+	saved := migrations
+	version := len(saved) + 1
+	migrations = append(slices.Clone(saved),
+		duckdb.Migration{Version: version, Name: "add_services_namespace", SQL: `ALTER TABLE services ADD COLUMN namespace VARCHAR`},
+	)
+	t.Cleanup(func() { migrations = saved })
+
+	// A read-only dry run reports the migration without applying it.
+	roDB, err := NewReadOnly(tempDir, "test-colony", constants.DefaultConnectionsCacheTTL, logger)
+	if err != nil {
+		t.Fatalf("Failed to open database read-only: %v", err)
+	}
+	pending, err := roDB.Migrate(context.Background(), true)
+	_ = roDB.Close()
+	if err != nil {
+		t.Fatalf("Dry run failed: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Version != version {
+		t.Fatalf("Expected migration %d to be pending, got %+v", version, pending)
+	}
+
+	// Opening the database read-write applies it.
+	db, err = New(tempDir, "test-colony", constants.DefaultConnectionsCacheTTL, logger)
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	var count int
+	if err := db.DB().QueryRow("SELECT COUNT(namespace) FROM services").Scan(&count); err != nil {
+		t.Fatalf("Expected namespace column after migration: %v", err)
+	}
+	if pending, err := db.Migrate(context.Background(), true); err != nil || len(pending) != 0 {
+		t.Fatalf("Expected no pending migrations, got %d (err=%v)", len(pending), err)
+	}
+}
@@ -0,0 +1,463 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// ProfileSchedule is a recurring profiling job run by the colony.
+type ProfileSchedule struct {
+	ID              string     `duckdb:"id,pk"`
+	ServiceName     string     `duckdb:"service_name,immutable"`
+	PodName         string     `duckdb:"pod_name,immutable"`
+	ProfileType     string     `duckdb:"profile_type,immutable"`
+	DurationSeconds int32      `duckdb:"duration_seconds,immutable"`
+	IntervalSeconds int64      `duckdb:"interval_seconds,immutable"`
+	FrequencyHz     int32      `duckdb:"frequency_hz,immutable"`
+	CreatedAt       time.Time  `duckdb:"created_at,immutable"`
+	NextRunAt       time.Time  `duckdb:"next_run_at"`
+	LastRunAt       *time.Time `duckdb:"last_run_at"`
+	LastStatus      string     `duckdb:"last_status"`
+}
+
+// Interval returns the time between runs of the schedule.
+func (s *ProfileSchedule) Interval() time.Duration {
+	return time.Duration(s.IntervalSeconds) * time.Second
+}
+
+// ProfileRun is the retained result of one execution of a profile schedule.
+type ProfileRun struct {
+	ID           string    `duckdb:"id,pk"`
+	ScheduleID   string    `duckdb:"schedule_id,immutable"`
+	ServiceName  string    `duckdb:"service_name,immutable"`
+	ProfileType  string    `duckdb:"profile_type,immutable"`
+	StartedAt    time.Time `duckdb:"started_at,immutable"`
+	FinishedAt   time.Time `duckdb:"finished_at,immutable"`
+	Status       string    `duckdb:"status,immutable"`
+	Error        string    `duckdb:"error,immutable"`
+	Total        int64     `duckdb:"total,immutable"`
+	UniqueStacks int32     `duckdb:"unique_stacks,immutable"`
+	Folded       string    `duckdb:"folded,immutable"`
+}
+
+// ProfileRunFilters contains filters for listing profile runs.
+type ProfileRunFilters struct {
+	ScheduleID  string
+	ServiceName string
+	Limit       int
+}
+
+// InsertProfileSchedule persists a new profile schedule.
+func (d *Database) InsertProfileSchedule(ctx context.Context, schedule *ProfileSchedule) error {
+	return d.profileSchedulesTable.Insert(ctx, schedule)
+}
+
+// GetProfileSchedule retrieves a profile schedule by ID.
+func (d *Database) GetProfileSchedule(ctx context.Context, id string) (*ProfileSchedule, error) {
+	return d.profileSchedulesTable.Get(ctx, id)
+}
+
+// ListProfileSchedules retrieves profile schedules, optionally for a single
+// service, oldest first.
+func (d *Database) ListProfileSchedules(ctx context.Context, serviceName string) ([]*ProfileSchedule, error) {
+	query := `
+		SELECT id, service_name, pod_name, profile_type, duration_seconds,
+		       interval_seconds, frequency_hz, created_at, next_run_at,
+		       last_run_at, last_status
+		FROM profile_schedules
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if serviceName != "" {
+		query += " AND service_name = ?"
+		args = append(args, serviceName)
+	}
+
+	query += " ORDER BY created_at, id"
+
+	return d.queryProfileSchedules(ctx, query, args...)
+}
+
+// ListDueProfileSchedules retrieves profile schedules whose next run is at or
+// before now.
+func (d *Database) ListDueProfileSchedules(ctx context.Context, now time.Time) ([]*ProfileSchedule, error) {
+	return d.queryProfileSchedules(ctx, `
+		SELECT id, service_name, pod_name, profile_type, duration_seconds,
+		       interval_seconds, frequency_hz, created_at, next_run_at,
+		       last_run_at, last_status
+		FROM profile_schedules
+		WHERE next_run_at <= ?
+		ORDER BY next_run_at, id
+	`, now)
+}
+
+func (d *Database) queryProfileSchedules(ctx context.Context, query string, args ...interface{}) ([]*ProfileSchedule, error) {
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profile schedules: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var schedules []*ProfileSchedule
+	for rows.Next() {
+		var schedule ProfileSchedule
+		var podName, lastStatus sql.NullString
+		var lastRunAt sql.NullTime
+
+		if err := rows.Scan(
+			&schedule.ID,
+			&schedule.ServiceName,
+			&podName,
+			&schedule.ProfileType,
+			&schedule.DurationSeconds,
+			&schedule.IntervalSeconds,
+			&schedule.FrequencyHz,
+			&schedule.CreatedAt,
+			&schedule.NextRunAt,
+			&lastRunAt,
+			&lastStatus,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan profile schedule: %w", err)
+		}
+
+		schedule.PodName = podName.String
+		schedule.LastStatus = lastStatus.String
+		if lastRunAt.Valid {
+			schedule.LastRunAt = &lastRunAt.Time
+		}
+
+		schedules = append(schedules, &schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating profile schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// SetProfileScheduleNextRun sets when a profile schedule next runs.
+func (d *Database) SetProfileScheduleNextRun(ctx context.Context, id string, nextRunAt time.Time) error {
+	return d.profileSchedulesTable.UpdateFields(ctx, id, map[string]interface{}{
+		"next_run_at": nextRunAt,
+	})
+}
+
+// DeleteProfileSchedule removes a profile schedule and its retained runs.
+// It returns sql.ErrNoRows if the schedule does not exist.
+func (d *Database) DeleteProfileSchedule(ctx context.Context, id string) error {
+	result, err := d.db.ExecContext(ctx, `DELETE FROM profile_schedules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete profile schedule: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	if _, err := d.db.ExecContext(ctx, `
+		DELETE FROM profile_run_stacks
+		WHERE run_id IN (SELECT id FROM profile_runs WHERE schedule_id = ?)
+	`, id); err != nil {
+		return fmt.Errorf("failed to delete profile run stacks: %w", err)
+	}
+
+	if _, err := d.db.ExecContext(ctx, `DELETE FROM profile_runs WHERE schedule_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete profile runs: %w", err)
+	}
+
+	return nil
+}
+
+// InsertProfileRun stores the result of a scheduled profiling job and records
+// it as the schedule's last run. The folded stacks are stored encoded with the
+// profile frame dictionary.
+func (d *Database) InsertProfileRun(ctx context.Context, run *ProfileRun) error {
+	stacks, values, err := d.encodeFolded(ctx, run.Folded)
+	if err != nil {
+		return err
+	}
+
+	row := *run
+	row.Folded = ""
+	if err := d.profileRunsTable.Insert(ctx, &row); err != nil {
+		return fmt.Errorf("failed to insert profile run: %w", err)
+	}
+	if err := insertProfileRunStacks(ctx, d.db, run.ID, stacks, values); err != nil {
+		return err
+	}
+
+	// The schedule may have been deleted while the job ran.
+	if _, err := d.db.ExecContext(ctx, `
+		UPDATE profile_schedules SET last_run_at = ?, last_status = ?
+		WHERE id = ?
+	`, run.StartedAt, run.Status, run.ScheduleID); err != nil {
+		return fmt.Errorf("failed to update profile schedule: %w", err)
+	}
+
+	return nil
+}
+
+// GetProfileRun retrieves a profile run, including its folded stacks, by ID.
+func (d *Database) GetProfileRun(ctx context.Context, id string) (*ProfileRun, error) {
+	run, err := d.profileRunsTable.Get(ctx, id)
+	if err != nil || run == nil || run.Folded != "" {
+		// Runs that are not re-encoded yet still hold their folded stacks.
+		return run, err
+	}
+
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT stack_frame_ids, value FROM profile_run_stacks WHERE run_id = ?
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profile run stacks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var stacks [][]int64
+	var values []int64
+	for rows.Next() {
+		var frameIDsRaw interface{}
+		var value int64
+		if err := rows.Scan(&frameIDsRaw, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan profile run stack: %w", err)
+		}
+		frameIDs, err := convertArrayToInt64(frameIDsRaw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert frame IDs: %w", err)
+		}
+		stacks = append(stacks, frameIDs)
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating profile run stacks: %w", err)
+	}
+	_ = rows.Close()
+
+	frames, err := d.DecodeStackFrameBatch(ctx, stacks)
+	if err != nil {
+		return nil, err
+	}
+	folded := make([]string, len(frames))
+	for i, stack := range frames {
+		folded[i] = fmt.Sprintf("%s %d\n", strings.Join(stack, ";"), values[i])
+	}
+	sort.Strings(folded)
+	run.Folded = strings.Join(folded, "")
+	return run, nil
+}
+
+// ReencodeLegacyProfileRuns moves the folded stacks of up to limit profile
+// runs stored before profile_run_stacks existed into the frame dictionary.
+// It returns the number of runs re-encoded.
+func (d *Database) ReencodeLegacyProfileRuns(ctx context.Context, limit int) (int, error) {
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT id, folded FROM profile_runs
+		WHERE folded <> ''
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query legacy profile runs: %w", err)
+	}
+	legacy := make(map[string]string)
+	for rows.Next() {
+		var id, folded string
+		if err := rows.Scan(&id, &folded); err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("failed to scan legacy profile run: %w", err)
+		}
+		legacy[id] = folded
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return 0, fmt.Errorf("error iterating legacy profile runs: %w", err)
+	}
+
+	for id, folded := range legacy {
+		stacks, values, err := d.encodeFolded(ctx, folded)
+		if err != nil {
+			return 0, err
+		}
+
+		tx, err := d.db.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		if err := insertProfileRunStacks(ctx, tx, id, stacks, values); err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE profile_runs SET folded = '' WHERE id = ?`, id); err != nil {
+			_ = tx.Rollback()
+			return 0, fmt.Errorf("failed to clear folded stacks: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(legacy), nil
+}
+
+// encodeFolded parses folded stacks ("a;b;c 42" lines) and encodes their
+// frames with the profile frame dictionary.
+func (d *Database) encodeFolded(ctx context.Context, folded string) ([][]int64, []int64, error) {
+	var stacks [][]string
+	var values []int64
+	for _, line := range strings.Split(folded, "\n") {
+		if line == "" {
+			continue
+		}
+		sep := strings.LastIndex(line, " ")
+		if sep < 0 {
+			return nil, nil, fmt.Errorf("invalid folded stack %q", line)
+		}
+		value, err := strconv.ParseInt(line[sep+1:], 10, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid folded stack %q: %w", line, err)
+		}
+		stacks = append(stacks, strings.Split(line[:sep], ";"))
+		values = append(values, value)
+	}
+
+	encoded, err := d.EncodeStackFrameBatch(ctx, stacks)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encoded, values, nil
+}
+
+// execer is implemented by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// insertProfileRunStacks stores the encoded stacks of a profile run.
+func insertProfileRunStacks(ctx context.Context, exec execer, runID string, stacks [][]int64, values []int64) error {
+	for start := 0; start < len(stacks); start += frameBatchSize {
+		end := min(start+frameBatchSize, len(stacks))
+		rows := make([]string, 0, end-start)
+		args := make([]interface{}, 0, 3*(end-start))
+		for i := start; i < end; i++ {
+			rows = append(rows, "(?, ?, ?)")
+			args = append(args, runID, duckdb.Int64ArrayToString(stacks[i]), values[i])
+		}
+		if _, err := exec.ExecContext(ctx, `
+			INSERT INTO profile_run_stacks (run_id, stack_frame_ids, value)
+			VALUES `+strings.Join(rows, ", "), args...); err != nil {
+			return fmt.Errorf("failed to insert profile run stacks: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListProfileRuns retrieves profile runs matching the provided filters,
+// newest first. Folded stacks are not loaded; use GetProfileRun.
+func (d *Database) ListProfileRuns(ctx context.Context, filters ProfileRunFilters) ([]*ProfileRun, error) {
+	query := `
+		SELECT id, schedule_id, service_name, profile_type, started_at,
+		       finished_at, status, error, total, unique_stacks
+		FROM profile_runs
+		WHERE 1=1
+	`
+	args := []interface{}{}
+
+	if filters.ScheduleID != "" {
+		query += " AND schedule_id = ?"
+		args = append(args, filters.ScheduleID)
+	}
+
+	if filters.ServiceName != "" {
+		query += " AND service_name = ?"
+		args = append(args, filters.ServiceName)
+	}
+
+	query += " ORDER BY started_at DESC, id"
+
+	if filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filters.Limit)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profile runs: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var runs []*ProfileRun
+	for rows.Next() {
+		var run ProfileRun
+		var errMsg sql.NullString
+
+		if err := rows.Scan(
+			&run.ID,
+			&run.ScheduleID,
+			&run.ServiceName,
+			&run.ProfileType,
+			&run.StartedAt,
+			&run.FinishedAt,
+			&run.Status,
+			&errMsg,
+			&run.Total,
+			&run.UniqueStacks,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan profile run: %w", err)
+		}
+
+		run.Error = errMsg.String
+		runs = append(runs, &run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating profile runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// CleanupOldProfileRuns removes profile runs older than the retention period.
+func (d *Database) CleanupOldProfileRuns(ctx context.Context, retention time.Duration) (int64, error) {
+	cutoffTime := time.Now().Add(-retention)
+
+	if _, err := d.db.ExecContext(ctx, `
+		DELETE FROM profile_run_stacks
+		WHERE run_id IN (SELECT id FROM profile_runs WHERE started_at < ?)
+	`, cutoffTime); err != nil {
+		return 0, fmt.Errorf("failed to cleanup old profile run stacks: %w", err)
+	}
+
+	result, err := d.db.ExecContext(ctx, `
+		DELETE FROM profile_runs
+		WHERE started_at < ?
+	`, cutoffTime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cleanup old profile runs: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected > 0 {
+		d.logger.Debug().
+			Int64("rows_deleted", rowsAffected).
+			Time("cutoff_time", cutoffTime).
+			Msg("Cleaned up old profile runs")
+	}
+
+	return rowsAffected, nil
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestProfileSchedules(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, db.InsertProfileSchedule(ctx, &ProfileSchedule{
+		ID: "s-1", ServiceName: "api", ProfileType: "cpu", DurationSeconds: 30,
+		IntervalSeconds: 3600, FrequencyHz: 99, CreatedAt: now, NextRunAt: now,
+	}))
+	require.NoError(t, db.InsertProfileSchedule(ctx, &ProfileSchedule{
+		ID: "s-2", ServiceName: "db", ProfileType: "memory", DurationSeconds: 30,
+		IntervalSeconds: 600, CreatedAt: now.Add(time.Second), NextRunAt: now.Add(time.Hour),
+	}))
+
+	due, err := db.ListDueProfileSchedules(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "s-1", due[0].ID)
+	assert.Nil(t, due[0].LastRunAt)
+	assert.Equal(t, time.Hour, due[0].Interval())
+
+	apiSchedules, err := db.ListProfileSchedules(ctx, "api")
+	require.NoError(t, err)
+	require.Len(t, apiSchedules, 1)
+
+	require.NoError(t, db.SetProfileScheduleNextRun(ctx, "s-1", now.Add(time.Hour)))
+	due, err = db.ListDueProfileSchedules(ctx, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, due)
+
+	runs := []*ProfileRun{
+		{ID: "r-old", ScheduleID: "s-1", ServiceName: "api", ProfileType: "cpu", StartedAt: now.Add(-10 * 24 * time.Hour), FinishedAt: now.Add(-10 * 24 * time.Hour), Status: "success"},
+		{ID: "r-new", ScheduleID: "s-1", ServiceName: "api", ProfileType: "cpu", StartedAt: now, FinishedAt: now, Status: "success", Total: 3, UniqueStacks: 1, Folded: "main;work 3\n"},
+		{ID: "r-db", ScheduleID: "s-2", ServiceName: "db", ProfileType: "memory", StartedAt: now, FinishedAt: now, Status: "failed", Error: "no agent"},
+	}
+	for _, run := range runs {
+		require.NoError(t, db.InsertProfileRun(ctx, run))
+	}
+
+	schedule, err := db.GetProfileSchedule(ctx, "s-1")
+	require.NoError(t, err)
+	require.NotNil(t, schedule.LastRunAt)
+	assert.Equal(t, "success", schedule.LastStatus)
+
+	listed, err := db.ListProfileRuns(ctx, ProfileRunFilters{ScheduleID: "s-1"})
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, "r-new", listed[0].ID, "newest first")
+	assert.Empty(t, listed[0].Folded, "stacks are only loaded by GetProfileRun")
+
+	run, err := db.GetProfileRun(ctx, "r-new")
+	require.NoError(t, err)
+	assert.Equal(t, "main;work 3\n", run.Folded)
+
+	deleted, err := db.CleanupOldProfileRuns(ctx, constants.DefaultProfileRunRetention)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	require.NoError(t, db.DeleteProfileSchedule(ctx, "s-1"))
+	assert.ErrorIs(t, db.DeleteProfileSchedule(ctx, "s-1"), sql.ErrNoRows)
+
+	remaining, err := db.ListProfileRuns(ctx, ProfileRunFilters{})
+	require.NoError(t, err)
+	require.Len(t, remaining, 1, "runs of the deleted schedule are removed")
+	assert.Equal(t, "r-db", remaining[0].ID)
+}
@@ -184,6 +184,37 @@ func (d *Database) CompareHotspotsWithBaseline(ctx context.Context, serviceName,
 		return nil, fmt.Errorf("failed to get baseline hotspots: %w", err)
 	}
 
+	return compareHotspots(currentHotspots, baselineHotspots, topK), nil
+}
+
+// CompareHotspotsBetweenWindows detects regressions by comparing the hotspots
+// of two time windows, such as before and after a deployment, whatever the
+// build.
+func (d *Database) CompareHotspotsBetweenWindows(
+	ctx context.Context,
+	serviceName string,
+	baselineStart, baselineEnd, currentStart, currentEnd time.Time,
+	topK int,
+) ([]RegressionIndicatorResult, error) {
+	if topK <= 0 {
+		topK = 5
+	}
+
+	currentHotspots, err := d.getHotspotsByBuild(ctx, serviceName, "", currentStart, currentEnd, topK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current hotspots: %w", err)
+	}
+	baselineHotspots, err := d.getHotspotsByBuild(ctx, serviceName, "", baselineStart, baselineEnd, topK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get baseline hotspots: %w", err)
+	}
+
+	return compareHotspots(currentHotspots, baselineHotspots, topK), nil
+}
+
+// compareHotspots returns the hotspots that appeared, grew or shrank between
+// the baseline and the current top-K.
+func compareHotspots(currentHotspots, baselineHotspots []hotspotEntry, topK int) []RegressionIndicatorResult {
 	// Build baseline lookup by stack hash.
 	baselineMap := make(map[string]float64) // stack_hash -> percentage.
 	for _, h := range baselineHotspots {
@@ -235,7 +266,7 @@ func (d *Database) CompareHotspotsWithBaseline(ctx context.Context, serviceName,
 		}
 	}
 
-	return indicators, nil
+	return indicators
 }
 
 // hotspotEntry is an internal type for regression comparison.
@@ -245,15 +276,20 @@ type hotspotEntry struct {
 	percentage float64
 }
 
-// getHotspotsByBuild queries hotspots filtered by build_id.
+// getHotspotsByBuild queries hotspots filtered by build_id, or of all builds
+// when buildID is empty.
 func (d *Database) getHotspotsByBuild(ctx context.Context, serviceName, buildID string, startTime, endTime time.Time, topK int) ([]hotspotEntry, error) {
 	query := `
 		SELECT stack_hash, stack_frame_ids, SUM(sample_count) as total_samples
 		FROM cpu_profile_summaries
 		WHERE service_name = ?
-		  AND build_id = ?
 	`
-	args := []interface{}{serviceName, buildID}
+	args := []interface{}{serviceName}
+
+	if buildID != "" {
+		query += " AND build_id = ?"
+		args = append(args, buildID)
+	}
 
 	if !startTime.IsZero() {
 		query += " AND timestamp >= ?"
@@ -303,19 +339,24 @@ func (d *Database) getHotspotsByBuild(ctx context.Context, serviceName, buildID
 		return nil, nil
 	}
 
-	for _, r := range rawEntries {
+	stacks := make([][]int64, len(rawEntries))
+	for i, r := range rawEntries {
 		frameIDs, err := convertArrayToInt64(r.frameIDsRaw)
 		if err != nil {
 			return nil, err
 		}
+		stacks[i] = frameIDs
+	}
+	decoded, err := d.DecodeStackFrameBatch(ctx, stacks)
+	if err != nil {
+		return nil, err
+	}
 
+	for i, r := range rawEntries {
 		// Get the leaf frame (first in stack, leaf-to-root order) as representative name.
 		topFrame := "unknown"
-		if len(frameIDs) > 0 {
-			frames, err := d.DecodeStackFrames(ctx, frameIDs)
-			if err == nil && len(frames) > 0 {
-				topFrame = SimplifyFrame(frames[0])
-			}
+		if frames := decoded[i]; len(frames) > 0 {
+			topFrame = SimplifyFrame(frames[0])
 		}
 
 		entries = append(entries, hotspotEntry{
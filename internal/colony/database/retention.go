@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DeleteExpired deletes the rows of table whose column is older than cutoff
+// and returns the number of rows deleted. table and column are trusted
+// identifiers from the retention policies, not user input.
+func (d *Database) DeleteExpired(ctx context.Context, table, column string, cutoff time.Time) (int64, error) {
+	result, err := d.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s < ?", table, column), cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired rows from %s: %w", table, err)
+	}
+	return result.RowsAffected()
+}
+
+// Checkpoint writes the WAL into the database file, letting DuckDB reuse the
+// blocks freed by deleted rows.
+func (d *Database) Checkpoint(ctx context.Context) error {
+	// Plain CHECKPOINT waits for running transactions instead of aborting
+	// them like FORCE CHECKPOINT.
+	if _, err := d.ExecContext(ctx, "CHECKPOINT"); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+	return nil
+}
+
+// FileSize returns the on-disk size of the database file and its WAL.
+func (d *Database) FileSize() int64 {
+	var size int64
+	for _, path := range []string{d.path, d.path + ".wal"} {
+		if info, err := os.Stat(path); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
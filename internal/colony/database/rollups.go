@@ -0,0 +1,252 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Beyla metric rollups aggregate the raw per-agent histograms of
+// beyla_{http,grpc,sql}_metrics into 1m, 10m and 1h buckets summed across
+// agents, so that long-range queries scan far fewer rows. Each resolution is
+// built from the previous one (raw -> 1m -> 10m -> 1h) and tracks how far it
+// has been built in rollup_watermarks. Queries read the rollup up to its
+// watermark and the raw rows after it, so recent data is never missing.
+
+// rollupSource describes a raw Beyla metrics table and its dimensions.
+type rollupSource struct {
+	table string
+	// columns defines the dimension columns, which are also the group-by
+	// columns of the rollup.
+	columns []string
+	// columnDDL are the definitions of columns.
+	columnDDL string
+}
+
+var rollupSources = []rollupSource{
+	{
+		table:   "beyla_http_metrics",
+		columns: []string{"service_name", "http_method", "http_route", "http_status_code", "latency_bucket_ms"},
+		columnDDL: `service_name TEXT NOT NULL,
+			http_method VARCHAR(10),
+			http_route VARCHAR(255),
+			http_status_code SMALLINT,
+			latency_bucket_ms DOUBLE NOT NULL`,
+	},
+	{
+		table:   "beyla_grpc_metrics",
+		columns: []string{"service_name", "grpc_method", "grpc_status_code", "latency_bucket_ms"},
+		columnDDL: `service_name TEXT NOT NULL,
+			grpc_method VARCHAR(255),
+			grpc_status_code SMALLINT,
+			latency_bucket_ms DOUBLE NOT NULL`,
+	},
+	{
+		table:   "beyla_sql_metrics",
+		columns: []string{"service_name", "sql_operation", "table_name", "latency_bucket_ms"},
+		columnDDL: `service_name TEXT NOT NULL,
+			sql_operation VARCHAR(50),
+			table_name VARCHAR(255),
+			latency_bucket_ms DOUBLE NOT NULL`,
+	},
+}
+
+// RollupResolution is a bucket size of the Beyla metric rollups.
+type RollupResolution struct {
+	// Suffix is appended to the raw table name, e.g. beyla_http_metrics_1m.
+	Suffix string
+	// Step is the bucket size.
+	Step time.Duration
+	// MinRange is the shortest query range served from this resolution.
+	MinRange time.Duration
+}
+
+// RollupResolutions are the rollup resolutions from finest to coarsest; each
+// is built from the previous one, the first from the raw table.
+var RollupResolutions = []RollupResolution{
+	{Suffix: "1m", Step: time.Minute, MinRange: 6 * time.Hour},
+	{Suffix: "10m", Step: 10 * time.Minute, MinRange: 2 * 24 * time.Hour},
+	{Suffix: "1h", Step: time.Hour, MinRange: 14 * 24 * time.Hour},
+}
+
+// RollupTables returns the names of all rollup tables.
+func RollupTables() []string {
+	var tables []string
+	for _, src := range rollupSources {
+		for _, res := range RollupResolutions {
+			tables = append(tables, src.table+"_"+res.Suffix)
+		}
+	}
+	return tables
+}
+
+// rollupSchemaDDL returns the DDL of the rollup tables and their watermarks.
+func rollupSchemaDDL() []string {
+	ddl := []string{
+		`CREATE TABLE IF NOT EXISTS rollup_watermarks (
+			table_name VARCHAR PRIMARY KEY,
+			rolled_up_to TIMESTAMPTZ NOT NULL
+		)`,
+	}
+	for _, src := range rollupSources {
+		for _, res := range RollupResolutions {
+			table := src.table + "_" + res.Suffix
+			ddl = append(ddl,
+				fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			timestamp TIMESTAMPTZ NOT NULL,
+			%s,
+			count BIGINT NOT NULL,
+			PRIMARY KEY (timestamp, %s)
+		)`, table, src.columnDDL, strings.Join(src.columns, ", ")),
+				fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_service_time ON %s(service_name, timestamp DESC)`, table, table),
+			)
+		}
+	}
+	return ddl
+}
+
+// RollupBeylaMetrics builds the rollup buckets that ended at least lateness
+// before now and are not built yet. lateness leaves time for delayed agent
+// data to arrive; rows arriving later than that are only in the raw tables.
+func (d *Database) RollupBeylaMetrics(ctx context.Context, now time.Time, lateness time.Duration) error {
+	var errs []error
+	for _, src := range rollupSources {
+		from := src.table
+		limit := now.Add(-lateness)
+		for _, res := range RollupResolutions {
+			to := src.table + "_" + res.Suffix
+			built, err := d.rollup(ctx, src, from, to, res.Step, limit)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to roll up %s: %w", to, err))
+				break
+			}
+			// Coarser resolutions can only be built as far as this one.
+			from, limit = to, built
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// rollup aggregates the rows of from into step buckets of to, from the
+// watermark of to up to limit, and returns the new watermark.
+func (d *Database) rollup(ctx context.Context, src rollupSource, from, to string, step time.Duration, limit time.Time) (time.Time, error) {
+	start, err := d.rollupWatermark(ctx, to)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if start.IsZero() {
+		// First run: start from the oldest source row.
+		var oldest sql.NullTime
+		if err := d.db.QueryRowContext(ctx, fmt.Sprintf("SELECT MIN(timestamp) FROM %s", from)).Scan(&oldest); err != nil {
+			return time.Time{}, err
+		}
+		if !oldest.Valid {
+			return time.Time{}, nil
+		}
+		start = oldest.Time.UTC().Truncate(step)
+	}
+
+	end := limit.UTC().Truncate(step)
+	if !end.After(start) {
+		return start, nil
+	}
+
+	columns := strings.Join(src.columns, ", ")
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// time_bucket needs a plain TIMESTAMP; timestamps are stored in UTC.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %s (timestamp, %s, count)
+		SELECT time_bucket(INTERVAL '%d seconds', timestamp::TIMESTAMP)::TIMESTAMPTZ AS bucket, %s, SUM(count)
+		FROM %s
+		WHERE timestamp >= ? AND timestamp < ?
+		GROUP BY bucket, %s
+		ON CONFLICT DO UPDATE SET count = EXCLUDED.count
+	`, to, columns, int(step.Seconds()), columns, from, columns), start, end); err != nil {
+		return time.Time{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO rollup_watermarks (table_name, rolled_up_to) VALUES (?, ?)
+		ON CONFLICT (table_name) DO UPDATE SET rolled_up_to = EXCLUDED.rolled_up_to
+	`, to, end); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return time.Time{}, err
+	}
+	return end, nil
+}
+
+// rollupWatermark returns the time up to which table is built, or the zero
+// time if it was never built.
+func (d *Database) rollupWatermark(ctx context.Context, table string) (time.Time, error) {
+	var t time.Time
+	err := d.db.QueryRowContext(ctx,
+		`SELECT rolled_up_to FROM rollup_watermarks WHERE table_name = ?`, table,
+	).Scan(&t)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read rollup watermark of %s: %w", table, err)
+	}
+	return t, nil
+}
+
+// beylaMetricsSource returns the FROM expression for querying the raw Beyla
+// metrics table between start and end at the coarsest resolution suited to
+// the range. The expression exposes timestamp, the dimension columns and
+// count, under the raw table's name. Raw rows offloaded to cold storage are
+// included.
+func (d *Database) beylaMetricsSource(ctx context.Context, table string, start, end time.Time) string {
+	raw := d.rawMetricsSource(ctx, table, start, end)
+
+	var src rollupSource
+	for _, s := range rollupSources {
+		if s.table == table {
+			src = s
+		}
+	}
+
+	span := end.Sub(start)
+	for i := len(RollupResolutions) - 1; i >= 0; i-- {
+		res := RollupResolutions[i]
+		if span < res.MinRange {
+			continue
+		}
+		rollupTable := table + "_" + res.Suffix
+		watermark, err := d.rollupWatermark(ctx, rollupTable)
+		if err != nil || !watermark.After(start) {
+			// Not built far enough yet; try a finer resolution.
+			continue
+		}
+
+		// Buckets are only used when they lie entirely in the range; the
+		// partial bucket at the start and the rows after the watermark are
+		// read from the raw table, so the result matches a raw query.
+		first := start.UTC().Truncate(res.Step)
+		if first.Before(start) {
+			first = first.Add(res.Step)
+		}
+		columns := "timestamp, " + strings.Join(src.columns, ", ") + ", count"
+		return fmt.Sprintf(
+			"(SELECT %[1]s FROM %[2]s WHERE timestamp >= %[4]s AND timestamp < %[5]s"+
+				" UNION ALL SELECT %[1]s FROM %[6]s WHERE timestamp < %[4]s OR timestamp >= %[5]s) AS %[3]s",
+			columns, rollupTable, table, timestampLiteral(first), timestampLiteral(watermark), raw)
+	}
+	return raw
+}
+
+// timestampLiteral formats t as a DuckDB TIMESTAMPTZ literal.
+func timestampLiteral(t time.Time) string {
+	return "TIMESTAMPTZ '" + t.UTC().Format("2006-01-02 15:04:05.999999+00") + "'"
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestRollupBeylaMetrics(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Minute)
+
+	// One row per agent every 30s over the last 3 days.
+	_, err = db.DB().Exec(`
+		INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+		SELECT ts::TIMESTAMPTZ, agent, 'api', 'GET', '/users', 200, 10, 2
+		FROM range(?::TIMESTAMP, ?::TIMESTAMP, INTERVAL 30 SECOND) AS r(ts), (VALUES ('agent-1'), ('agent-2')) AS a(agent)
+	`, now.Add(-72*time.Hour).Format(time.DateTime), now.Format(time.DateTime))
+	require.NoError(t, err)
+	rawTotal := int64(72 * 120 * 2 * 2)
+
+	require.NoError(t, db.RollupBeylaMetrics(ctx, now, 5*time.Minute))
+
+	// Buckets up to 5 minutes ago are rolled up, summed across agents.
+	watermark, err := db.rollupWatermark(ctx, "beyla_http_metrics_1m")
+	require.NoError(t, err)
+	assert.True(t, watermark.Equal(now.Add(-5*time.Minute)), "watermark %s", watermark)
+
+	var buckets, perBucket int64
+	require.NoError(t, db.DB().QueryRow(`SELECT count(*), max(count) FROM beyla_http_metrics_1m`).Scan(&buckets, &perBucket))
+	assert.Equal(t, int64(72*60-5), buckets)
+	assert.Equal(t, int64(8), perBucket)
+
+	hourly, err := db.rollupWatermark(ctx, "beyla_http_metrics_1h")
+	require.NoError(t, err)
+	assert.True(t, hourly.Equal(now.Add(-5*time.Minute).Truncate(time.Hour)), "watermark %s", hourly)
+
+	// Rolling up again is a no-op.
+	require.NoError(t, db.RollupBeylaMetrics(ctx, now, 5*time.Minute))
+	var total int64
+	require.NoError(t, db.DB().QueryRow(`SELECT sum(count) FROM beyla_http_metrics_10m`).Scan(&total))
+	var rawRolled int64
+	require.NoError(t, db.DB().QueryRow(`SELECT sum(count) FROM beyla_http_metrics WHERE timestamp < ?`,
+		now.Add(-5*time.Minute).Truncate(10*time.Minute)).Scan(&rawRolled))
+	assert.Equal(t, rawRolled, total)
+
+	// Long ranges read the rollups, plus the raw rows after the watermark.
+	start := now.Add(-72 * time.Hour)
+	assert.Contains(t, db.beylaMetricsSource(ctx, "beyla_http_metrics", start, now), "beyla_http_metrics_10m")
+	assert.Equal(t, "beyla_http_metrics", db.beylaMetricsSource(ctx, "beyla_http_metrics", now.Add(-time.Hour), now))
+
+	results, err := db.QueryBeylaHTTPMetrics(ctx, "api", start, now, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, rawTotal, results[0].Count)
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"fmt"
+	"slices"
 )
 
 // initSchema creates all required tables and indexes for colony storage.
@@ -16,7 +17,7 @@ func (d *Database) initSchema() error {
 	defer func() { _ = tx.Rollback() }() // TODO: errcheck
 
 	// Execute all DDL statements.
-	for _, ddl := range schemaDDL {
+	for _, ddl := range slices.Concat(schemaDDL, rollupSchemaDDL()) {
 		if _, err := tx.Exec(ddl); err != nil {
 			return fmt.Errorf("failed to execute DDL: %w", err)
 		}
@@ -258,6 +259,25 @@ var schemaDDL = []string{
 	`CREATE INDEX IF NOT EXISTS idx_debug_events_timestamp ON debug_events(timestamp)`,
 	`CREATE INDEX IF NOT EXISTS idx_debug_events_collector ON debug_events(collector_id)`,
 
+	// Debug function stats - per-minute rollups of debug events, built by the
+	// debug aggregation pipelines.
+	`CREATE TABLE IF NOT EXISTS debug_function_stats (
+		bucket TIMESTAMPTZ NOT NULL,
+		session_id VARCHAR NOT NULL,
+		service_name VARCHAR NOT NULL,
+		function_name VARCHAR NOT NULL,
+		calls BIGINT NOT NULL,
+		errors BIGINT NOT NULL,
+		p50_ns BIGINT NOT NULL,
+		p95_ns BIGINT NOT NULL,
+		p99_ns BIGINT NOT NULL,
+		max_ns BIGINT NOT NULL,
+		PRIMARY KEY (bucket, session_id, function_name)
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_debug_function_stats_session ON debug_function_stats(session_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_debug_function_stats_function ON debug_function_stats(service_name, function_name, bucket DESC)`,
+
 	// Function registry - discovered functions from services (RFD 063).
 	`CREATE TABLE IF NOT EXISTS functions (
 		service_name VARCHAR NOT NULL,
@@ -440,4 +460,216 @@ var schemaDDL = []string{
 	`CREATE INDEX IF NOT EXISTS idx_topology_connections_source ON topology_connections(source_agent_id)`,
 	// Note: No index on dest_agent_id due to DuckDB limitation with updating indexed columns in ON CONFLICT.
 	`CREATE INDEX IF NOT EXISTS idx_topology_connections_dest_ip ON topology_connections(dest_ip)`,
+
+	// Audit log - append-only record of control-plane actions (debug probes,
+	// profiling, exec, MCP tool calls). Rows are never updated; the retention
+	// manager deletes them once expired.
+	`CREATE SEQUENCE IF NOT EXISTS seq_audit_log_id START 1`,
+	`CREATE TABLE IF NOT EXISTS audit_log (
+		id BIGINT PRIMARY KEY DEFAULT nextval('seq_audit_log_id'),
+		timestamp TIMESTAMPTZ NOT NULL,
+		actor VARCHAR NOT NULL,
+		action VARCHAR NOT NULL,
+		target VARCHAR,
+		arguments_hash VARCHAR,
+		result VARCHAR NOT NULL,
+		error TEXT
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_audit_log_timestamp ON audit_log(timestamp)`,
+
+	// MCP tool calls - every tool call made by an MCP client, with its
+	// arguments, for debugging agent behavior and replaying calls.
+	`CREATE SEQUENCE IF NOT EXISTS seq_mcp_tool_calls_id START 1`,
+	`CREATE TABLE IF NOT EXISTS mcp_tool_calls (
+		id BIGINT PRIMARY KEY DEFAULT nextval('seq_mcp_tool_calls_id'),
+		timestamp TIMESTAMPTZ NOT NULL,
+		client VARCHAR,
+		actor VARCHAR NOT NULL,
+		tool VARCHAR NOT NULL,
+		arguments TEXT,
+		command TEXT,
+		duration_ms DOUBLE,
+		result_bytes BIGINT,
+		result VARCHAR NOT NULL,
+		error TEXT
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_mcp_tool_calls_timestamp ON mcp_tool_calls(timestamp)`,
+
+	// Agent history - append-only record of agent registrations, disconnects
+	// and status transitions, kept across colony restarts.
+	`CREATE SEQUENCE IF NOT EXISTS seq_agent_history_id START 1`,
+	`CREATE TABLE IF NOT EXISTS agent_history (
+		id BIGINT PRIMARY KEY DEFAULT nextval('seq_agent_history_id'),
+		timestamp TIMESTAMPTZ NOT NULL,
+		agent_id VARCHAR NOT NULL,
+		event VARCHAR NOT NULL,
+		status VARCHAR NOT NULL,
+		mesh_ipv4 VARCHAR,
+		message TEXT
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_agent_history_timestamp ON agent_history(timestamp)`,
+
+	// Agent settings - colony-assigned labels and drain state, kept across
+	// agent restarts and colony restarts.
+	`CREATE TABLE IF NOT EXISTS agent_settings (
+		agent_id VARCHAR PRIMARY KEY,
+		labels TEXT NOT NULL,
+		drained BOOLEAN NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL
+	)`,
+
+	// Service groups - named sets of services created through the API; groups
+	// of the colony config are not stored.
+	`CREATE TABLE IF NOT EXISTS service_groups (
+		name VARCHAR PRIMARY KEY,
+		services TEXT NOT NULL,
+		description VARCHAR NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL
+	)`,
+
+	// Service freezes - services against which no new debug or profiling
+	// sessions start until the freeze expires.
+	`CREATE TABLE IF NOT EXISTS service_freezes (
+		service VARCHAR PRIMARY KEY,
+		expires_at TIMESTAMPTZ NOT NULL,
+		reason VARCHAR NOT NULL,
+		frozen_by VARCHAR NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+
+	// Shell recordings - input and output of shell and exec sessions run on
+	// agents, uploaded when the session ends. No index on started_at: DuckDB
+	// cannot upsert rows of a table with an index, and agents re-upload a
+	// recording if the colony's response was lost.
+	`CREATE TABLE IF NOT EXISTS shell_recordings (
+		session_id VARCHAR PRIMARY KEY,
+		agent_id VARCHAR NOT NULL,
+		kind VARCHAR NOT NULL,
+		user_id VARCHAR NOT NULL,
+		command TEXT,
+		container_name VARCHAR,
+		started_at TIMESTAMPTZ NOT NULL,
+		ended_at TIMESTAMPTZ NOT NULL,
+		exit_code INTEGER NOT NULL,
+		width INTEGER NOT NULL,
+		height INTEGER NOT NULL,
+		size_bytes BIGINT NOT NULL,
+		truncated BOOLEAN NOT NULL,
+		events TEXT NOT NULL
+	)`,
+
+	// Profile schedules - recurring profiling jobs run by the colony.
+	`CREATE TABLE IF NOT EXISTS profile_schedules (
+		id VARCHAR PRIMARY KEY,
+		service_name VARCHAR NOT NULL,
+		pod_name VARCHAR,
+		profile_type VARCHAR NOT NULL,
+		duration_seconds INTEGER NOT NULL,
+		interval_seconds BIGINT NOT NULL,
+		frequency_hz INTEGER NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		next_run_at TIMESTAMPTZ NOT NULL,
+		last_run_at TIMESTAMPTZ,
+		last_status VARCHAR
+	)`,
+
+	// Profile runs - retained results of scheduled profiling jobs. Their
+	// stacks are in profile_run_stacks; folded only holds the stacks of runs
+	// stored before, until they are re-encoded.
+	`CREATE TABLE IF NOT EXISTS profile_runs (
+		id VARCHAR PRIMARY KEY,
+		schedule_id VARCHAR NOT NULL,
+		service_name VARCHAR NOT NULL,
+		profile_type VARCHAR NOT NULL,
+		started_at TIMESTAMPTZ NOT NULL,
+		finished_at TIMESTAMPTZ NOT NULL,
+		status VARCHAR NOT NULL,
+		error TEXT,
+		total BIGINT NOT NULL DEFAULT 0,
+		unique_stacks INTEGER NOT NULL DEFAULT 0,
+		folded TEXT
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_profile_runs_schedule ON profile_runs(schedule_id, started_at)`,
+
+	// Profile run stacks - the stacks of profile runs, encoded with the
+	// profile frame dictionary, outermost frame first.
+	`CREATE TABLE IF NOT EXISTS profile_run_stacks (
+		run_id VARCHAR NOT NULL,
+		stack_frame_ids BIGINT[] NOT NULL,
+		value BIGINT NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_profile_run_stacks_run ON profile_run_stacks(run_id)`,
+
+	// Alert rules - thresholds on service metrics evaluated by the colony.
+	`CREATE TABLE IF NOT EXISTS alert_rules (
+		id VARCHAR PRIMARY KEY,
+		name VARCHAR NOT NULL,
+		service_name VARCHAR,
+		metric VARCHAR NOT NULL,
+		threshold DOUBLE NOT NULL,
+		window_seconds BIGINT NOT NULL,
+		sinks VARCHAR,
+		enabled BOOLEAN NOT NULL DEFAULT true,
+		created_at TIMESTAMPTZ NOT NULL,
+		last_evaluated_at TIMESTAMPTZ
+	)`,
+
+	// Alert firing - services an alert rule is currently firing for.
+	`CREATE TABLE IF NOT EXISTS alert_firing (
+		rule_id VARCHAR NOT NULL,
+		service_name VARCHAR NOT NULL,
+		value DOUBLE NOT NULL,
+		since TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (rule_id, service_name)
+	)`,
+
+	// Anomaly baselines - the usual latency and error rate of service routes
+	// at each hour of the day (UTC), learned by the anomaly detector.
+	`CREATE TABLE IF NOT EXISTS anomaly_baselines (
+		service_name VARCHAR NOT NULL,
+		route VARCHAR NOT NULL,
+		metric VARCHAR NOT NULL,
+		hour_of_day INTEGER NOT NULL,
+		mean DOUBLE NOT NULL,
+		stddev DOUBLE NOT NULL,
+		samples INTEGER NOT NULL,
+		learned_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY (service_name, route, metric, hour_of_day)
+	)`,
+
+	// Anomaly scores - deviations of route metrics from their baselines, per
+	// scored window.
+	`CREATE TABLE IF NOT EXISTS anomaly_scores (
+		timestamp TIMESTAMPTZ NOT NULL,
+		service_name VARCHAR NOT NULL,
+		route VARCHAR NOT NULL,
+		metric VARCHAR NOT NULL,
+		value DOUBLE NOT NULL,
+		mean DOUBLE NOT NULL,
+		stddev DOUBLE NOT NULL,
+		score DOUBLE NOT NULL,
+		anomalous BOOLEAN NOT NULL,
+		PRIMARY KEY (timestamp, service_name, route, metric)
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_anomaly_scores_service_time ON anomaly_scores(service_name, timestamp DESC)`,
+
+	// Cold storage manifest - Parquet files in object storage holding rows
+	// offloaded from the raw metric tables, one or more per table and day.
+	`CREATE TABLE IF NOT EXISTS cold_storage_manifest (
+		path VARCHAR PRIMARY KEY,
+		table_name VARCHAR NOT NULL,
+		day TIMESTAMPTZ NOT NULL,
+		row_count BIGINT NOT NULL,
+		min_timestamp TIMESTAMPTZ NOT NULL,
+		max_timestamp TIMESTAMPTZ NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+
+	`CREATE INDEX IF NOT EXISTS idx_cold_storage_manifest_table ON cold_storage_manifest(table_name, min_timestamp)`,
 }
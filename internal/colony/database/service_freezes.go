@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// ServiceFreeze blocks new debug and profiling sessions against a service
+// until it expires.
+type ServiceFreeze struct {
+	Service   string    `duckdb:"service,pk"`
+	ExpiresAt time.Time `duckdb:"expires_at"`
+	Reason    string    `duckdb:"reason"`
+	FrozenBy  string    `duckdb:"frozen_by"`
+	CreatedAt time.Time `duckdb:"created_at"`
+}
+
+// Active reports whether the freeze is in effect at now.
+func (f *ServiceFreeze) Active(now time.Time) bool {
+	return now.Before(f.ExpiresAt)
+}
+
+// UpsertServiceFreeze freezes a service, replacing any freeze it already has.
+func (d *Database) UpsertServiceFreeze(ctx context.Context, freeze *ServiceFreeze) error {
+	if err := d.serviceFreezesTable.Upsert(ctx, freeze); err != nil {
+		return fmt.Errorf("failed to store service freeze: %w", err)
+	}
+	return nil
+}
+
+// GetServiceFreeze retrieves the freeze of a service in effect at now. It
+// returns sql.ErrNoRows if the service is not frozen.
+func (d *Database) GetServiceFreeze(ctx context.Context, service string, now time.Time) (*ServiceFreeze, error) {
+	freeze, err := d.serviceFreezesTable.Get(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	if !freeze.Active(now) {
+		return nil, sql.ErrNoRows
+	}
+	return freeze, nil
+}
+
+// ListServiceFreezes retrieves the freezes in effect at now, ordered by
+// service.
+func (d *Database) ListServiceFreezes(ctx context.Context, now time.Time) ([]*ServiceFreeze, error) {
+	all, err := d.serviceFreezesTable.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service freezes: %w", err)
+	}
+	var freezes []*ServiceFreeze
+	for _, f := range all {
+		if f.Active(now) {
+			freezes = append(freezes, f)
+		}
+	}
+	sort.Slice(freezes, func(i, j int) bool { return freezes[i].Service < freezes[j].Service })
+	return freezes, nil
+}
+
+// DeleteServiceFreeze lifts the freeze of a service. It returns
+// sql.ErrNoRows if the service is not frozen at now.
+func (d *Database) DeleteServiceFreeze(ctx context.Context, service string, now time.Time) error {
+	if _, err := d.GetServiceFreeze(ctx, service, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		return fmt.Errorf("failed to get service freeze: %w", err)
+	}
+	if _, err := d.serviceFreezesTable.DeleteWhere(ctx, duckdb.NewFilter().Eq("service", service)); err != nil {
+		return fmt.Errorf("failed to delete service freeze: %w", err)
+	}
+	return nil
+}
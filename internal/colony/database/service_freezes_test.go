@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServiceFreezes(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, db.UpsertServiceFreeze(ctx, &ServiceFreeze{
+		Service:   "api",
+		ExpiresAt: now.Add(2 * time.Hour),
+		Reason:    "load test",
+		FrozenBy:  "alice",
+		CreatedAt: now,
+	}))
+	require.NoError(t, db.UpsertServiceFreeze(ctx, &ServiceFreeze{
+		Service:   "payments",
+		ExpiresAt: now.Add(-time.Minute),
+		CreatedAt: now.Add(-time.Hour),
+	}))
+
+	freeze, err := db.GetServiceFreeze(ctx, "api", now)
+	require.NoError(t, err)
+	assert.Equal(t, "load test", freeze.Reason)
+	assert.Equal(t, "alice", freeze.FrozenBy)
+
+	_, err = db.GetServiceFreeze(ctx, "payments", now)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "expired freezes are not in effect")
+
+	freezes, err := db.ListServiceFreezes(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, freezes, 1)
+	assert.Equal(t, "api", freezes[0].Service)
+
+	require.NoError(t, db.DeleteServiceFreeze(ctx, "api", now))
+	assert.ErrorIs(t, db.DeleteServiceFreeze(ctx, "api", now), sql.ErrNoRows)
+	assert.ErrorIs(t, db.DeleteServiceFreeze(ctx, "payments", now), sql.ErrNoRows)
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// ServiceGroup is a named set of services created through the API.
+type ServiceGroup struct {
+	Name        string    `duckdb:"name,pk"`
+	Services    string    `duckdb:"services"` // JSON array.
+	Description string    `duckdb:"description"`
+	UpdatedAt   time.Time `duckdb:"updated_at"`
+}
+
+// ServiceNames decodes the services of the group.
+func (g *ServiceGroup) ServiceNames() ([]string, error) {
+	var services []string
+	if err := json.Unmarshal([]byte(g.Services), &services); err != nil {
+		return nil, fmt.Errorf("failed to parse services of group %s: %w", g.Name, err)
+	}
+	return services, nil
+}
+
+// UpsertServiceGroup creates a service group or replaces the one with the
+// same name.
+func (d *Database) UpsertServiceGroup(ctx context.Context, name string, services []string, description string) (*ServiceGroup, error) {
+	data, err := json.Marshal(services)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode services: %w", err)
+	}
+	group := &ServiceGroup{
+		Name:        name,
+		Services:    string(data),
+		Description: description,
+		UpdatedAt:   time.Now(),
+	}
+	if err := d.serviceGroupsTable.Upsert(ctx, group); err != nil {
+		return nil, fmt.Errorf("failed to store service group: %w", err)
+	}
+	return group, nil
+}
+
+// GetServiceGroup retrieves a service group by name. It returns
+// sql.ErrNoRows if the group does not exist.
+func (d *Database) GetServiceGroup(ctx context.Context, name string) (*ServiceGroup, error) {
+	return d.serviceGroupsTable.Get(ctx, name)
+}
+
+// ListServiceGroups retrieves all service groups, ordered by name.
+func (d *Database) ListServiceGroups(ctx context.Context) ([]*ServiceGroup, error) {
+	groups, err := d.serviceGroupsTable.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service groups: %w", err)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+// DeleteServiceGroup removes a service group. It returns sql.ErrNoRows if
+// the group does not exist.
+func (d *Database) DeleteServiceGroup(ctx context.Context, name string) error {
+	deleted, err := d.serviceGroupsTable.DeleteWhere(ctx, duckdb.NewFilter().Eq("name", name))
+	if err != nil {
+		return fmt.Errorf("failed to delete service group: %w", err)
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServiceGroups(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	_, err = db.UpsertServiceGroup(ctx, "checkout-path", []string{"api", "payments"}, "")
+	require.NoError(t, err)
+	_, err = db.UpsertServiceGroup(ctx, "backoffice", []string{"admin"}, "Internal tools")
+	require.NoError(t, err)
+
+	// Upserting replaces the services and description.
+	_, err = db.UpsertServiceGroup(ctx, "checkout-path", []string{"api", "payments", "inventory"}, "Checkout")
+	require.NoError(t, err)
+
+	groups, err := db.ListServiceGroups(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "backoffice", groups[0].Name, "ordered by name")
+	assert.Equal(t, "Internal tools", groups[0].Description)
+
+	group, err := db.GetServiceGroup(ctx, "checkout-path")
+	require.NoError(t, err)
+	services, err := group.ServiceNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "payments", "inventory"}, services)
+	assert.Equal(t, "Checkout", group.Description)
+
+	_, err = db.GetServiceGroup(ctx, "unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, db.DeleteServiceGroup(ctx, "checkout-path"))
+	assert.ErrorIs(t, db.DeleteServiceGroup(ctx, "checkout-path"), sql.ErrNoRows)
+
+	groups, err = db.ListServiceGroups(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+}
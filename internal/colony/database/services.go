@@ -175,6 +175,20 @@ func (d *Database) UpdateServiceLastSeen(ctx context.Context, agentID string, la
 	return nil
 }
 
+// DeleteAgentServices deletes the services of an agent and their heartbeats.
+func (d *Database) DeleteAgentServices(ctx context.Context, agentID string) error {
+	if _, err := d.db.ExecContext(ctx, `
+		DELETE FROM service_heartbeats
+		WHERE service_id IN (SELECT id FROM services WHERE agent_id = ?)
+	`, agentID); err != nil {
+		return fmt.Errorf("failed to delete service heartbeats: %w", err)
+	}
+	if _, err := d.db.ExecContext(ctx, "DELETE FROM services WHERE agent_id = ?", agentID); err != nil {
+		return fmt.Errorf("failed to delete services: %w", err)
+	}
+	return nil
+}
+
 // ListAllServices retrieves all services from the database.
 func (d *Database) ListAllServices(ctx context.Context) ([]*Service, error) {
 	query := `
@@ -457,3 +457,28 @@ func TestConcurrentServiceUpserts(t *testing.T) {
 	assert.Equal(t, "concurrent-service", retrieved.Name)
 	assert.Equal(t, "concurrent-agent", retrieved.AgentID)
 }
+
+func TestDeleteAgentServices(t *testing.T) {
+	tempDir := t.TempDir()
+	logger := logging.NewWithComponent(logging.Config{Level: "debug", Pretty: true}, "test")
+	db, err := New(tempDir, "test-colony", constants.DefaultConnectionsCacheTTL, logger)
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	for _, svc := range []*Service{
+		{ID: "agent-1:api", Name: "api", AppID: "api", AgentID: "agent-1", Labels: "{}", LastSeen: time.Now(), Status: "active"},
+		{ID: "agent-1:worker", Name: "worker", AppID: "worker", AgentID: "agent-1", Labels: "{}", LastSeen: time.Now(), Status: "active"},
+		{ID: "agent-2:web", Name: "web", AppID: "web", AgentID: "agent-2", Labels: "{}", LastSeen: time.Now(), Status: "active"},
+	} {
+		require.NoError(t, db.UpsertService(ctx, svc))
+	}
+
+	require.NoError(t, db.DeleteAgentServices(ctx, "agent-1"))
+
+	services, err := db.ListAllServices(ctx)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, "agent-2", services[0].AgentID)
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrShellRecordingNotFound is returned by GetShellRecording for an unknown
+// session.
+var ErrShellRecordingNotFound = errors.New("shell recording not found")
+
+// ShellRecording is the recorded input and output of a shell or exec session
+// run on an agent.
+type ShellRecording struct {
+	SessionID     string    `duckdb:"session_id,pk"`
+	AgentID       string    `duckdb:"agent_id"`
+	Kind          string    `duckdb:"kind"`
+	UserID        string    `duckdb:"user_id"`
+	Command       string    `duckdb:"command"` // JSON array.
+	ContainerName string    `duckdb:"container_name"`
+	StartedAt     time.Time `duckdb:"started_at"`
+	EndedAt       time.Time `duckdb:"ended_at"`
+	ExitCode      int       `duckdb:"exit_code"`
+	Cols          int       `duckdb:"width"`
+	Rows          int       `duckdb:"height"`
+	SizeBytes     int64     `duckdb:"size_bytes"`
+	Truncated     bool      `duckdb:"truncated"`
+	Events        string    `duckdb:"events"` // JSON array, not set by ListShellRecordings.
+}
+
+// ShellRecordingFilters contains filters for listing shell recordings.
+type ShellRecordingFilters struct {
+	Since   time.Time
+	AgentID string
+	UserID  string
+	Limit   int
+}
+
+// shellRecordingColumns are the columns read by ListShellRecordings: all but
+// the events, which can be large.
+const shellRecordingColumns = `session_id, agent_id, kind, user_id, command, container_name,
+		started_at, ended_at, exit_code, width, height, size_bytes, truncated`
+
+// InsertShellRecording stores a recording. Uploading a session again
+// replaces its recording.
+func (d *Database) InsertShellRecording(ctx context.Context, rec *ShellRecording) error {
+	if err := d.shellRecordingsTable.Upsert(ctx, rec); err != nil {
+		return fmt.Errorf("failed to store shell recording: %w", err)
+	}
+	return nil
+}
+
+// ListShellRecordings retrieves the recordings matching the provided
+// filters, newest first, without their events.
+func (d *Database) ListShellRecordings(ctx context.Context, filters ShellRecordingFilters) ([]*ShellRecording, error) {
+	query := `SELECT ` + shellRecordingColumns + ` FROM shell_recordings WHERE 1=1`
+	args := []interface{}{}
+
+	if !filters.Since.IsZero() {
+		query += " AND started_at >= ?"
+		args = append(args, filters.Since)
+	}
+
+	if filters.AgentID != "" {
+		query += " AND agent_id = ?"
+		args = append(args, filters.AgentID)
+	}
+
+	if filters.UserID != "" {
+		query += " AND user_id = ?"
+		args = append(args, filters.UserID)
+	}
+
+	query += " ORDER BY started_at DESC, session_id"
+
+	if filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filters.Limit)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shell recordings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var recordings []*ShellRecording
+	for rows.Next() {
+		var rec ShellRecording
+		var command, containerName sql.NullString
+		if err := rows.Scan(
+			&rec.SessionID,
+			&rec.AgentID,
+			&rec.Kind,
+			&rec.UserID,
+			&command,
+			&containerName,
+			&rec.StartedAt,
+			&rec.EndedAt,
+			&rec.ExitCode,
+			&rec.Cols,
+			&rec.Rows,
+			&rec.SizeBytes,
+			&rec.Truncated,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan shell recording: %w", err)
+		}
+		rec.Command = command.String
+		rec.ContainerName = containerName.String
+		recordings = append(recordings, &rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shell recordings: %w", err)
+	}
+
+	return recordings, nil
+}
+
+// GetShellRecording retrieves the recording of a session with its events.
+func (d *Database) GetShellRecording(ctx context.Context, sessionID string) (*ShellRecording, error) {
+	rec, err := d.shellRecordingsTable.Get(ctx, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrShellRecordingNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shell recording: %w", err)
+	}
+	return rec, nil
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestShellRecordings(t *testing.T) {
+	db, err := New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	recordings := []*ShellRecording{
+		{SessionID: "s1", AgentID: "agent-1", Kind: "shell", UserID: "alice", Command: `["/bin/bash"]`,
+			StartedAt: now.Add(-48 * time.Hour), EndedAt: now.Add(-47 * time.Hour), Cols: 120, Rows: 40,
+			SizeBytes: 12, Events: `[{"offset_us":0,"type":"o","data":"JCA="}]`},
+		{SessionID: "s2", AgentID: "agent-2", Kind: "exec", UserID: "alice", Command: `["ps","aux"]`,
+			StartedAt: now.Add(-time.Hour), EndedAt: now.Add(-time.Hour), Events: `[]`},
+		{SessionID: "s3", AgentID: "agent-1", Kind: "container_exec", UserID: "bob", Command: `["ls"]`,
+			ContainerName: "api", StartedAt: now.Add(-time.Minute), EndedAt: now, ExitCode: 2, Truncated: true, Events: `[]`},
+	}
+	for _, r := range recordings {
+		require.NoError(t, db.InsertShellRecording(ctx, r))
+	}
+
+	all, err := db.ListShellRecordings(ctx, ShellRecordingFilters{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "s3", all[0].SessionID, "newest first")
+	assert.Equal(t, "api", all[0].ContainerName)
+	assert.True(t, all[0].Truncated)
+	assert.Empty(t, all[0].Events, "listing leaves out events")
+
+	recent, err := db.ListShellRecordings(ctx, ShellRecordingFilters{Since: now.Add(-24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Len(t, recent, 2)
+
+	filtered, err := db.ListShellRecordings(ctx, ShellRecordingFilters{AgentID: "agent-1", UserID: "alice"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "s1", filtered[0].SessionID)
+
+	limited, err := db.ListShellRecordings(ctx, ShellRecordingFilters{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, limited, 1)
+
+	got, err := db.GetShellRecording(ctx, "s1")
+	require.NoError(t, err)
+	assert.Equal(t, `["/bin/bash"]`, got.Command)
+	assert.Equal(t, 120, got.Cols)
+	assert.Equal(t, 40, got.Rows)
+	assert.Equal(t, `[{"offset_us":0,"type":"o","data":"JCA="}]`, got.Events)
+
+	_, err = db.GetShellRecording(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrShellRecordingNotFound)
+}
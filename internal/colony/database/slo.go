@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SLOCounts are the requests of a service over a time window: how many
+// failed, and how many were slower than a latency threshold.
+type SLOCounts struct {
+	Total  int64
+	Failed int64
+	Slow   int64
+}
+
+// QuerySLOCounts counts the HTTP and gRPC requests of a service between start
+// and end. Failed requests have an HTTP status of 500 or more or a non-OK
+// gRPC status. Slow requests fall in a latency bucket whose upper bound
+// exceeds latencyThresholdMs. Long ranges are served from the metric rollups.
+func (d *Database) QuerySLOCounts(ctx context.Context, serviceName string, start, end time.Time, latencyThresholdMs float64) (*SLOCounts, error) {
+	counts := &SLOCounts{}
+	for _, q := range []struct {
+		table  string
+		failed string
+	}{
+		{"beyla_http_metrics", "http_status_code >= 500"},
+		{"beyla_grpc_metrics", "grpc_status_code != 0"},
+	} {
+		var total, failed, slow sql.NullInt64
+		// #nosec G201 - the table and the failure condition are constants, not user input.
+		err := d.db.QueryRowContext(ctx, fmt.Sprintf(`
+			SELECT SUM(count),
+			       SUM(CASE WHEN %s THEN count ELSE 0 END),
+			       SUM(CASE WHEN latency_bucket_ms > ? THEN count ELSE 0 END)
+			FROM %s
+			WHERE service_name = ? AND timestamp >= ? AND timestamp < ?
+		`, q.failed, d.beylaMetricsSource(ctx, q.table, start, end)),
+			latencyThresholdMs, serviceName, start, end).Scan(&total, &failed, &slow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count requests in %s: %w", q.table, err)
+		}
+		counts.Total += total.Int64
+		counts.Failed += failed.Int64
+		counts.Slow += slow.Int64
+	}
+	return counts, nil
+}
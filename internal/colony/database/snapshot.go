@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/coral-mesh/coral/internal/duckdb"
+)
+
+// ExportSnapshot writes a consistent copy of the database to dir as an
+// EXPORT DATABASE directory (schema, load script and Parquet data files).
+// The directory must not contain a previous export.
+func (d *Database) ExportSnapshot(ctx context.Context, dir string) error {
+	query := fmt.Sprintf("EXPORT DATABASE '%s' (FORMAT PARQUET)", escapeSQLString(dir))
+	if _, err := d.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to export database snapshot: %w", err)
+	}
+	return nil
+}
+
+// RestoreSnapshot replaces the colony database in storagePath with a snapshot
+// written by ExportSnapshot. The database must not be open. The snapshot is
+// imported into a new file which then replaces the database atomically, so a
+// failed restore leaves the previous database intact. With enc, the restored
+// database is stored encrypted.
+func RestoreSnapshot(ctx context.Context, dir, storagePath, colonyID string, enc *Encryption) error {
+	if err := os.MkdirAll(storagePath, 0750); err != nil {
+		return fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	dbPath := filepath.Join(storagePath, colonyID+".duckdb")
+	restorePath := dbPath + ".restore"
+	_ = os.Remove(restorePath)
+	_ = os.Remove(restorePath + ".wal")
+
+	if err := importSnapshot(ctx, dir, restorePath); err != nil {
+		return err
+	}
+
+	if enc != nil {
+		defer func() { _ = os.Remove(restorePath) }()
+		es := &encryptedStorage{key: enc.Key, path: dbPath + encryptedSuffix}
+		if err := es.encrypt(restorePath); err != nil {
+			return err
+		}
+		// Working copies are newer than the encrypted database on open, so
+		// those of the replaced database must go.
+		removeDatabaseFiles(dbPath)
+		removeDatabaseFiles(workingPath(storagePath, colonyID, *enc))
+		return nil
+	}
+
+	if err := os.Remove(dbPath + ".wal"); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale WAL: %w", err)
+	}
+	if err := os.Rename(restorePath, dbPath); err != nil {
+		return fmt.Errorf("failed to replace database: %w", err)
+	}
+
+	return nil
+}
+
+// importSnapshot imports a snapshot written by ExportSnapshot into a new
+// database at path, with its WAL folded into the database file.
+func importSnapshot(ctx context.Context, dir, path string) error {
+	db, err := duckdb.OpenDB(path)
+	if err != nil {
+		return fmt.Errorf("failed to create restore database: %w", err)
+	}
+
+	query := fmt.Sprintf("IMPORT DATABASE '%s'", escapeSQLString(dir))
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		_ = db.Close()
+		_ = os.Remove(path)
+		return fmt.Errorf("failed to import database snapshot: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "CHECKPOINT"); err != nil {
+		_ = db.Close()
+		_ = os.Remove(path)
+		return fmt.Errorf("failed to checkpoint restored database: %w", err)
+	}
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("failed to close restored database: %w", err)
+	}
+	return nil
+}
+
+// escapeSQLString escapes a value for use in a single-quoted SQL literal.
+func escapeSQLString(s string) string {
+	return strings.ReplaceAll(s, "'", "''")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 	"github.com/coral-mesh/coral/internal/duckdb"
 )
 
@@ -44,6 +45,8 @@ func (d *Database) InsertSystemMetricsSummaries(ctx context.Context, summaries [
 		return fmt.Errorf("failed to batch upsert system metrics summaries: %w", err)
 	}
 
+	d.queryCache.Invalidate(querycache.Metrics)
+
 	d.logger.Debug().
 		Int("summary_count", len(summaries)).
 		Msg("Inserted system metrics summaries")
@@ -112,20 +115,11 @@ func (d *Database) QuerySystemMetricsSummaries(ctx context.Context, agentID stri
 func (d *Database) CleanupOldSystemMetrics(ctx context.Context, retentionDays int) (int64, error) {
 	cutoffTime := time.Now().Add(-time.Duration(retentionDays) * 24 * time.Hour)
 
-	result, err := d.db.ExecContext(ctx, `
-		DELETE FROM system_metrics_summaries
-		WHERE bucket_time < ?
-	`, cutoffTime)
-
+	rowsAffected, err := d.systemMetricsTable.DeleteWhere(ctx, duckdb.NewFilter().Lt("bucket_time", cutoffTime))
 	if err != nil {
 		return 0, fmt.Errorf("failed to cleanup old system metrics: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return 0, fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
 	if rowsAffected > 0 {
 		d.logger.Debug().
 			Int64("rows_deleted", rowsAffected).
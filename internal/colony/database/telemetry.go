@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 	"github.com/coral-mesh/coral/internal/duckdb"
 )
 
@@ -69,6 +70,8 @@ func (d *Database) InsertTelemetrySummaries(ctx context.Context, summaries []Tel
 		return fmt.Errorf("failed to batch upsert telemetry summaries: %w", err)
 	}
 
+	d.queryCache.Invalidate(querycache.Metrics)
+
 	d.logger.Debug().
 		Int("summary_count", len(summaries)).
 		Msg("Inserted telemetry summaries")
@@ -141,20 +144,11 @@ func (d *Database) QueryTelemetrySummaries(ctx context.Context, agentID string,
 func (d *Database) CleanupOldTelemetry(ctx context.Context, retentionHours int) (int64, error) {
 	cutoffTime := time.Now().Add(-time.Duration(retentionHours) * time.Hour)
 
-	result, err := d.db.ExecContext(ctx, `
-		DELETE FROM otel_summaries
-		WHERE bucket_time < ?
-	`, cutoffTime)
-
+	rowsAffected, err := d.telemetryTable.DeleteWhere(ctx, duckdb.NewFilter().Lt("bucket_time", cutoffTime))
 	if err != nil {
 		return 0, fmt.Errorf("failed to cleanup old telemetry: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return 0, fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
 	if rowsAffected > 0 {
 		d.logger.Debug().
 			Int64("rows_deleted", rowsAffected).
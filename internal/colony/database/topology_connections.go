@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 )
 
 // TopologyConnection represents a directed L4 network edge observed by an agent (RFD 033).
@@ -92,6 +94,8 @@ func (d *Database) UpsertTopologyConnections(ctx context.Context, entries []Topo
 		return fmt.Errorf("failed to commit topology upsert transaction: %w", err)
 	}
 
+	d.queryCache.Invalidate(querycache.Connections)
+
 	d.logger.Debug().
 		Int("count", len(entries)).
 		Msg("Upserted topology connections")
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"connectrpc.com/connect"
@@ -12,8 +13,12 @@ import (
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
-
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/colony/events"
 	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/constants"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
 )
 
 // realtimeQueryTimeout is for low-latency agent queries.
@@ -24,6 +29,20 @@ type AgentCoordinator struct {
 	logger             zerolog.Logger
 	registry           *registry.Registry
 	agentClientFactory func(connect.HTTPClient, string, ...connect.ClientOption) agentv1connect.AgentServiceClient
+
+	// cache maps service names to the agent resolved for them.
+	cacheMu  sync.Mutex
+	cache    map[string]serviceAgentMapping
+	cacheTTL time.Duration
+
+	broker       *events.Broker
+	subscription *events.Subscription
+}
+
+// serviceAgentMapping is a cached service to agent resolution.
+type serviceAgentMapping struct {
+	agentID  string
+	expireAt time.Time
 }
 
 // NewAgentCoordinator creates a new agent coordinator.
@@ -36,62 +55,189 @@ func NewAgentCoordinator(
 		logger:             logger.With().Str("component", "agent_coordinator").Logger(),
 		registry:           registry,
 		agentClientFactory: agentClientFactory,
+		cache:              make(map[string]serviceAgentMapping),
+		cacheTTL:           constants.DefaultServiceAgentCacheTTL,
+	}
+}
+
+// WatchEvents invalidates cached service mappings of agents that reconnect
+// (re-registration or first heartbeat after a disconnect), disconnect, or
+// register or deregister services.
+func (ac *AgentCoordinator) WatchEvents(broker *events.Broker) {
+	ac.Stop()
+
+	sub := broker.Subscribe(events.Filter{
+		Types: []debugpb.ColonyEventType{
+			debugpb.ColonyEventType_COLONY_EVENT_TYPE_AGENT_CONNECTED,
+			debugpb.ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED,
+			debugpb.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_REGISTERED,
+			debugpb.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_DEREGISTERED,
+		},
+	})
+	ac.broker = broker
+	ac.subscription = sub
+
+	go func() {
+		for event := range sub.Events() {
+			ac.invalidateAgent(event.AgentId)
+		}
+	}()
+}
+
+// Stop stops watching registry events.
+func (ac *AgentCoordinator) Stop() {
+	if ac.subscription != nil {
+		ac.broker.Unsubscribe(ac.subscription)
+		ac.subscription = nil
 	}
 }
 
+// invalidateAgent drops the cached mappings pointing to agentID.
+func (ac *AgentCoordinator) invalidateAgent(agentID string) {
+	ac.cacheMu.Lock()
+	defer ac.cacheMu.Unlock()
+
+	for service, mapping := range ac.cache {
+		if mapping.agentID == agentID {
+			delete(ac.cache, service)
+		}
+	}
+}
+
+// cachedAgent returns the cached agent for serviceName, if the mapping has
+// not expired and the agent is still registered.
+func (ac *AgentCoordinator) cachedAgent(serviceName string) (string, bool) {
+	ac.cacheMu.Lock()
+	mapping, ok := ac.cache[serviceName]
+	if ok && time.Now().After(mapping.expireAt) {
+		delete(ac.cache, serviceName)
+		ok = false
+	}
+	ac.cacheMu.Unlock()
+
+	if !ok {
+		return "", false
+	}
+	if _, err := ac.registry.Get(mapping.agentID); err != nil {
+		ac.invalidateAgent(mapping.agentID)
+		return "", false
+	}
+	return mapping.agentID, true
+}
+
 // FindAgentForService discovers which agent hosts a given service.
-// It queries agents in real-time to find the service.
+// Agents are queried in real time and concurrently, as registry data may not
+// have services populated; the result is cached until the agent's
+// registration changes or the cache TTL expires.
 func (ac *AgentCoordinator) FindAgentForService(ctx context.Context, serviceName string) (string, error) {
+	if agentID, ok := ac.cachedAgent(serviceName); ok {
+		ac.logger.Debug().
+			Str("service", serviceName).
+			Str("agent_id", agentID).
+			Msg("Found agent for service in cache")
+		return agentID, nil
+	}
+
 	ac.logger.Debug().
 		Str("service", serviceName).
 		Msg("Finding agent for service")
 
-	// Note: registry.FindAgentForService uses cached data which may not have services populated.
-	// We need to query agents in real-time to find the service.
-	entries := ac.registry.ListAll()
+	// Agents that stopped sending heartbeats would only time out.
+	now := time.Now()
+	var entries []*registry.Entry
+	for _, entry := range ac.registry.ListAll() {
+		if entry.MeshIP() != "" && registry.DetermineStatus(entry.LastSeen, now) != registry.StatusUnhealthy {
+			entries = append(entries, entry)
+		}
+	}
 
-	var foundEntry *registry.Entry
+	searchCtx, cancelSearch := context.WithCancel(ctx)
+	defer cancelSearch()
 
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		foundID string
+		sem     = make(chan struct{}, constants.DefaultServiceResolutionConcurrency)
+	)
 	for _, entry := range entries {
-		// Query agent in real-time for services.
-		agentURL := fmt.Sprintf("http://%s:9001", entry.MeshIPv4)
-		client := ac.agentClientFactory(http.DefaultClient, agentURL)
-
-		queryCtx, cancel := context.WithTimeout(ctx, realtimeQueryTimeout)
-		resp, err := client.ListServices(queryCtx, connect.NewRequest(&agentv1.ListServicesRequest{}))
-		cancel()
-
-		if err != nil {
-			ac.logger.Debug().
-				Err(err).
-				Str("agent_id", entry.AgentID).
-				Msg("Failed to query agent services")
-			continue
+		select {
+		case sem <- struct{}{}:
+		case <-searchCtx.Done():
+		}
+		if searchCtx.Err() != nil {
+			break
 		}
 
-		// Check if this agent has the service.
-		for _, svcStatus := range resp.Msg.Services {
-			if svcStatus.Name == serviceName {
-				foundEntry = entry
-				break
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if !ac.agentHasService(searchCtx, entry, serviceName) {
+				return
 			}
-		}
 
-		if foundEntry != nil {
-			break
-		}
+			mu.Lock()
+			defer mu.Unlock()
+			if foundID == "" {
+				foundID = entry.AgentID
+				cancelSearch()
+			}
+		}()
 	}
+	wg.Wait()
 
-	if foundEntry == nil {
-		return "", fmt.Errorf("service not found")
+	if foundID == "" {
+		return "", coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND,
+			fmt.Errorf("service not found"), "service", serviceName)
 	}
 
+	ac.cacheMu.Lock()
+	ac.cache[serviceName] = serviceAgentMapping{agentID: foundID, expireAt: time.Now().Add(ac.cacheTTL)}
+	ac.cacheMu.Unlock()
+
 	ac.logger.Debug().
 		Str("service", serviceName).
-		Str("agent_id", foundEntry.AgentID).
+		Str("agent_id", foundID).
 		Msg("Found agent for service")
 
-	return foundEntry.AgentID, nil
+	return foundID, nil
+}
+
+// agentHasService queries an agent in real time for its services.
+func (ac *AgentCoordinator) agentHasService(ctx context.Context, entry *registry.Entry, serviceName string) bool {
+	agentURL := fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP()))
+	client := ac.agentClientFactory(http.DefaultClient, agentURL)
+
+	queryCtx, cancel := context.WithTimeout(ctx, realtimeQueryTimeout)
+	defer cancel()
+
+	resp, err := client.ListServices(queryCtx, connect.NewRequest(&agentv1.ListServicesRequest{}))
+	if err != nil {
+		ac.logger.Debug().
+			Err(err).
+			Str("agent_id", entry.AgentID).
+			Msg("Failed to query agent services")
+		return false
+	}
+
+	for _, svcStatus := range resp.Msg.Services {
+		if svcStatus.Name == serviceName {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNotDrained returns an AGENT_DRAINED error if operators drained the
+// agent: the colony starts no new sessions on drained agents.
+func checkNotDrained(entry *registry.Entry) error {
+	if !entry.Drained {
+		return nil
+	}
+	return coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_AGENT_DRAINED,
+		fmt.Errorf("agent %s is drained", entry.AgentID), "agent_id", entry.AgentID)
 }
 
 // GetServicePID queries an agent to get the PID for a given service.
@@ -108,7 +254,7 @@ func (ac *AgentCoordinator) GetServicePID(ctx context.Context, agentID, serviceN
 	}
 
 	// Query agent for service details to get PID.
-	agentURL := fmt.Sprintf("http://%s:9001", entry.MeshIPv4)
+	agentURL := fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP()))
 	agentClient := ac.agentClientFactory(http.DefaultClient, agentURL)
 
 	servicesResp, err := agentClient.ListServices(ctx, connect.NewRequest(&agentv1.ListServicesRequest{}))
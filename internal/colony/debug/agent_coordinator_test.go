@@ -0,0 +1,112 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	"github.com/coral-mesh/coral/internal/colony/events"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestAgentCoordinator_FindAgentForService(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		const agentCount = 40
+
+		broker := events.NewBroker(zerolog.Nop())
+		reg := registry.New(nil)
+		reg.SetEventBroker(broker)
+		for i := 0; i < agentCount; i++ {
+			_, err := reg.Register(fmt.Sprintf("agent-%d", i), "", fmt.Sprintf("10.0.0.%d", i+1), "", nil, nil, "")
+			require.NoError(t, err)
+		}
+
+		var calls, inFlight, maxInFlight atomic.Int32
+		factory := func(_ connect.HTTPClient, url string, _ ...connect.ClientOption) agentv1connect.AgentServiceClient {
+			return &mockAgentClient{
+				listServicesFunc: func(ctx context.Context, _ *connect.Request[agentv1.ListServicesRequest]) (*connect.Response[agentv1.ListServicesResponse], error) {
+					calls.Add(1)
+					n := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					for {
+						current := maxInFlight.Load()
+						if n <= current || maxInFlight.CompareAndSwap(current, n) {
+							break
+						}
+					}
+
+					select {
+					case <-time.After(100 * time.Millisecond):
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					}
+
+					var services []*agentv1.ServiceStatus
+					if url == "http://10.0.0.40:9001" {
+						services = []*agentv1.ServiceStatus{{Name: "payments"}}
+					}
+					return connect.NewResponse(&agentv1.ListServicesResponse{Services: services}), nil
+				},
+			}
+		}
+
+		ac := NewAgentCoordinator(zerolog.Nop(), reg, factory)
+		ac.WatchEvents(broker)
+		defer ac.Stop()
+
+		ctx := context.Background()
+
+		start := time.Now()
+		agentID, err := ac.FindAgentForService(ctx, "payments")
+		require.NoError(t, err)
+		assert.Equal(t, "agent-39", agentID)
+
+		// Agents are queried concurrently, up to the concurrency limit.
+		assert.Less(t, time.Since(start), agentCount*100*time.Millisecond)
+		assert.Greater(t, maxInFlight.Load(), int32(1))
+		assert.LessOrEqual(t, maxInFlight.Load(), int32(constants.DefaultServiceResolutionConcurrency))
+
+		// Resolved mappings are cached.
+		queried := calls.Load()
+		agentID, err = ac.FindAgentForService(ctx, "payments")
+		require.NoError(t, err)
+		assert.Equal(t, "agent-39", agentID)
+		assert.Equal(t, queried, calls.Load())
+
+		// A disconnect followed by a reconnecting heartbeat invalidates the
+		// agent's mappings.
+		reg.CheckDisconnected(time.Now().Add(constants.DefaultAgentDegradedThreshold + time.Second))
+		require.NoError(t, reg.UpdateHeartbeat("agent-39"))
+		synctest.Wait()
+
+		_, err = ac.FindAgentForService(ctx, "payments")
+		require.NoError(t, err)
+		assert.Greater(t, calls.Load(), queried)
+
+		// Mappings expire after the TTL.
+		queried = calls.Load()
+		time.Sleep(constants.DefaultServiceAgentCacheTTL + time.Second)
+		for i := 0; i < agentCount; i++ {
+			require.NoError(t, reg.UpdateHeartbeat(fmt.Sprintf("agent-%d", i)))
+		}
+		synctest.Wait()
+
+		_, err = ac.FindAgentForService(ctx, "payments")
+		require.NoError(t, err)
+		assert.Greater(t, calls.Load(), queried)
+
+		_, err = ac.FindAgentForService(ctx, "unknown")
+		assert.Error(t, err)
+	})
+}
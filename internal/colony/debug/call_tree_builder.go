@@ -34,15 +34,15 @@ func BuildCallTreeFromEvents(events []*agentv1.UprobeEvent, p95Duration time.Dur
 		return nil
 	}
 
-	// Group events by thread ID
-	eventsByThread := groupEventsByThread(events)
+	// Group events by goroutine (or thread, for events without goroutine ID)
+	eventsByContext := groupEventsByContext(events)
 
-	// Build call stacks for each thread
+	// Build call stacks for each goroutine
 	var allRoots []*CallStackFrame
 	totalInvocations := int64(0)
 
-	for _, threadEvents := range eventsByThread {
-		roots := buildCallStacksForThread(threadEvents)
+	for _, contextEvents := range eventsByContext {
+		roots := buildCallStacks(contextEvents)
 		allRoots = append(allRoots, roots...)
 		totalInvocations += int64(len(roots))
 	}
@@ -63,27 +63,45 @@ func BuildCallTreeFromEvents(events []*agentv1.UprobeEvent, p95Duration time.Dur
 	}
 }
 
-// groupEventsByThread groups events by their thread ID.
-func groupEventsByThread(events []*agentv1.UprobeEvent) map[int32][]*agentv1.UprobeEvent {
-	grouped := make(map[int32][]*agentv1.UprobeEvent)
+// executionContext identifies where an event ran: its goroutine when known,
+// otherwise its thread. Go moves goroutines between threads, so only the
+// goroutine keeps the entry and return events of a call together.
+type executionContext struct {
+	pid         int32
+	goroutineID uint64
+	tid         int32
+}
+
+func eventContext(event *agentv1.UprobeEvent) executionContext {
+	if event.GoroutineId != 0 {
+		return executionContext{pid: event.Pid, goroutineID: event.GoroutineId}
+	}
+	return executionContext{pid: event.Pid, tid: event.Tid}
+}
+
+// groupEventsByContext groups events by their goroutine, or by their thread
+// for events without goroutine ID.
+func groupEventsByContext(events []*agentv1.UprobeEvent) map[executionContext][]*agentv1.UprobeEvent {
+	grouped := make(map[executionContext][]*agentv1.UprobeEvent)
 
 	for _, event := range events {
-		tid := event.Tid
-		grouped[tid] = append(grouped[tid], event)
+		key := eventContext(event)
+		grouped[key] = append(grouped[key], event)
 	}
 
-	// Sort events within each thread by timestamp
-	for tid := range grouped {
-		sort.Slice(grouped[tid], func(i, j int) bool {
-			return grouped[tid][i].Timestamp.AsTime().Before(grouped[tid][j].Timestamp.AsTime())
+	// Sort events within each context by timestamp
+	for key := range grouped {
+		sort.SliceStable(grouped[key], func(i, j int) bool {
+			return grouped[key][i].Timestamp.AsTime().Before(grouped[key][j].Timestamp.AsTime())
 		})
 	}
 
 	return grouped
 }
 
-// buildCallStacksForThread builds call stacks from a thread's events.
-func buildCallStacksForThread(events []*agentv1.UprobeEvent) []*CallStackFrame {
+// buildCallStacks builds call stacks from the events of one goroutine (or
+// thread).
+func buildCallStacks(events []*agentv1.UprobeEvent) []*CallStackFrame {
 	var roots []*CallStackFrame
 	var stack []*CallStackFrame
 
@@ -298,6 +316,9 @@ func percentile(sorted []time.Duration, p float64) time.Duration {
 	return sorted[index]
 }
 
+// MaxSlowOutliers is the number of slow outliers reported in debug results.
+const MaxSlowOutliers = 10
+
 // FindSlowOutliers identifies events that exceed the P95 threshold.
 func FindSlowOutliers(events []*agentv1.UprobeEvent, p95Duration time.Duration) []*debugpb.SlowOutlier {
 	var outliers []*debugpb.SlowOutlier
@@ -316,6 +337,9 @@ func FindSlowOutliers(events []*agentv1.UprobeEvent, p95Duration time.Duration)
 						"tid":      fmt.Sprintf("%d", event.Tid),
 					},
 				}
+				if event.GoroutineId != 0 {
+					outlier.Labels["goroutine_id"] = fmt.Sprintf("%d", event.GoroutineId)
+				}
 				outliers = append(outliers, outlier)
 			}
 		}
@@ -326,9 +350,9 @@ func FindSlowOutliers(events []*agentv1.UprobeEvent, p95Duration time.Duration)
 		return outliers[i].Duration.AsDuration() > outliers[j].Duration.AsDuration()
 	})
 
-	// Limit to top 10 outliers
-	if len(outliers) > 10 {
-		outliers = outliers[:10]
+	// Limit to top outliers
+	if len(outliers) > MaxSlowOutliers {
+		outliers = outliers[:MaxSlowOutliers]
 	}
 
 	return outliers
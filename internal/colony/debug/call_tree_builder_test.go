@@ -142,6 +142,34 @@ func TestBuildCallTreeFromEvents(t *testing.T) {
 				assert.Equal(t, 100*time.Millisecond, slowNode.TotalDuration.AsDuration())
 			},
 		},
+		{
+			name: "Goroutine migrated between threads",
+			events: []*agentv1.UprobeEvent{
+				{Timestamp: timestamppb.New(baseTime), EventType: "entry", FunctionName: "A", Tid: 1, GoroutineId: 42},
+				{Timestamp: timestamppb.New(baseTime.Add(5 * time.Millisecond)), EventType: "entry", FunctionName: "A", Tid: 1, GoroutineId: 43},
+				{Timestamp: timestamppb.New(baseTime.Add(10 * time.Millisecond)), EventType: "entry", FunctionName: "B", Tid: 2, GoroutineId: 42},
+				{Timestamp: timestamppb.New(baseTime.Add(15 * time.Millisecond)), EventType: "return", FunctionName: "A", Tid: 1, GoroutineId: 43, DurationNs: 10 * 1e6},
+				{Timestamp: timestamppb.New(baseTime.Add(20 * time.Millisecond)), EventType: "return", FunctionName: "B", Tid: 3, GoroutineId: 42, DurationNs: 10 * 1e6},
+				{Timestamp: timestamppb.New(baseTime.Add(40 * time.Millisecond)), EventType: "return", FunctionName: "A", Tid: 2, GoroutineId: 42, DurationNs: 40 * 1e6},
+			},
+			p95Duration: 100 * time.Millisecond,
+			validate: func(t *testing.T, tree *debugpb.CallTree) {
+				assert.NotNil(t, tree)
+				assert.Equal(t, int64(2), tree.TotalInvocations)
+
+				// Both goroutines called A; only goroutine 42 called B, even
+				// though its events came from three threads.
+				assert.Equal(t, "A", tree.Root.FunctionName)
+				assert.Equal(t, int64(2), tree.Root.CallCount)
+				assert.Equal(t, 50*time.Millisecond, tree.Root.TotalDuration.AsDuration()) // 40 + 10
+
+				assert.Len(t, tree.Root.Children, 1)
+				child := tree.Root.Children[0]
+				assert.Equal(t, "B", child.FunctionName)
+				assert.Equal(t, int64(1), child.CallCount)
+				assert.Equal(t, 10*time.Millisecond, child.TotalDuration.AsDuration())
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -2,6 +2,7 @@ package debug
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -16,9 +17,11 @@ import (
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
 	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
 	"github.com/coral-mesh/coral/internal/colony/database"
 	"github.com/coral-mesh/coral/internal/colony/registry"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
 )
 
 // mockDebugClient implements agentv1connect.AgentDebugServiceClient
@@ -26,6 +29,14 @@ type mockDebugClient struct {
 	startFunc func(context.Context, *connect.Request[agentv1.StartUprobeCollectorRequest]) (*connect.Response[agentv1.StartUprobeCollectorResponse], error)
 	stopFunc  func(context.Context, *connect.Request[agentv1.StopUprobeCollectorRequest]) (*connect.Response[agentv1.StopUprobeCollectorResponse], error)
 	queryFunc func(context.Context, *connect.Request[agentv1.QueryUprobeEventsRequest]) (*connect.Response[agentv1.QueryUprobeEventsResponse], error)
+
+	describeFunc func(context.Context, *connect.Request[agentv1.DescribeFunctionRequest]) (*connect.Response[agentv1.DescribeFunctionResponse], error)
+	captureFunc  func(context.Context, *connect.Request[agentv1.StartHttpCaptureRequest]) (*connect.Response[agentv1.StartHttpCaptureResponse], error)
+	tlsFunc      func(context.Context, *connect.Request[agentv1.StartTlsCaptureRequest]) (*connect.Response[agentv1.StartTlsCaptureResponse], error)
+	runtimeFunc  func(context.Context, *connect.Request[agentv1.TraceRuntimeAgentRequest]) (*connect.Response[agentv1.TraceRuntimeAgentResponse], error)
+	syscallFunc  func(context.Context, *connect.Request[agentv1.TraceSyscallsAgentRequest]) (*connect.Response[agentv1.TraceSyscallsAgentResponse], error)
+	ioFunc       func(context.Context, *connect.Request[agentv1.ProfileIOAgentRequest]) (*connect.Response[agentv1.ProfileIOAgentResponse], error)
+	locksFunc    func(context.Context, *connect.Request[agentv1.ProfileLocksAgentRequest]) (*connect.Response[agentv1.ProfileLocksAgentResponse], error)
 }
 
 func (m *mockDebugClient) StartUprobeCollector(ctx context.Context, req *connect.Request[agentv1.StartUprobeCollectorRequest]) (*connect.Response[agentv1.StartUprobeCollectorResponse], error) {
@@ -81,6 +92,63 @@ func (m *mockDebugClient) ListCorrelations(ctx context.Context, req *connect.Req
 	return connect.NewResponse(&agentv1.ListCorrelationsResponse{}), nil
 }
 
+func (m *mockDebugClient) ListCoreDumps(ctx context.Context, req *connect.Request[agentv1.ListCoreDumpsRequest]) (*connect.Response[agentv1.ListCoreDumpsResponse], error) {
+	return connect.NewResponse(&agentv1.ListCoreDumpsResponse{}), nil
+}
+
+func (m *mockDebugClient) DownloadCoreDump(ctx context.Context, req *connect.Request[agentv1.DownloadCoreDumpRequest]) (*connect.ServerStreamForClient[agentv1.CoreDumpChunk], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugClient) DescribeFunction(ctx context.Context, req *connect.Request[agentv1.DescribeFunctionRequest]) (*connect.Response[agentv1.DescribeFunctionResponse], error) {
+	if m.describeFunc != nil {
+		return m.describeFunc(ctx, req)
+	}
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugClient) StartHttpCapture(ctx context.Context, req *connect.Request[agentv1.StartHttpCaptureRequest]) (*connect.Response[agentv1.StartHttpCaptureResponse], error) {
+	if m.captureFunc != nil {
+		return m.captureFunc(ctx, req)
+	}
+	return connect.NewResponse(&agentv1.StartHttpCaptureResponse{Supported: true, CollectorId: "mock-collector-id"}), nil
+}
+
+func (m *mockDebugClient) StartTlsCapture(ctx context.Context, req *connect.Request[agentv1.StartTlsCaptureRequest]) (*connect.Response[agentv1.StartTlsCaptureResponse], error) {
+	if m.tlsFunc != nil {
+		return m.tlsFunc(ctx, req)
+	}
+	return connect.NewResponse(&agentv1.StartTlsCaptureResponse{Supported: true, CollectorId: "mock-collector-id"}), nil
+}
+
+func (m *mockDebugClient) TraceRuntime(ctx context.Context, req *connect.Request[agentv1.TraceRuntimeAgentRequest]) (*connect.Response[agentv1.TraceRuntimeAgentResponse], error) {
+	if m.runtimeFunc != nil {
+		return m.runtimeFunc(ctx, req)
+	}
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugClient) TraceSyscalls(ctx context.Context, req *connect.Request[agentv1.TraceSyscallsAgentRequest]) (*connect.Response[agentv1.TraceSyscallsAgentResponse], error) {
+	if m.syscallFunc != nil {
+		return m.syscallFunc(ctx, req)
+	}
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugClient) ProfileIO(ctx context.Context, req *connect.Request[agentv1.ProfileIOAgentRequest]) (*connect.Response[agentv1.ProfileIOAgentResponse], error) {
+	if m.ioFunc != nil {
+		return m.ioFunc(ctx, req)
+	}
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugClient) ProfileLocks(ctx context.Context, req *connect.Request[agentv1.ProfileLocksAgentRequest]) (*connect.Response[agentv1.ProfileLocksAgentResponse], error) {
+	if m.locksFunc != nil {
+		return m.locksFunc(ctx, req)
+	}
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
 // mockAgentClient implements agentv1connect.AgentServiceClient for testing.
 type mockAgentClient struct {
 	listServicesFunc func(context.Context, *connect.Request[agentv1.ListServicesRequest]) (*connect.Response[agentv1.ListServicesResponse], error)
@@ -129,6 +197,18 @@ func (m *mockAgentClient) ContainerExec(ctx context.Context, req *connect.Reques
 	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("not implemented in mock"))
 }
 
+func (m *mockAgentClient) ExecInteractive(ctx context.Context) *connect.BidiStreamForClient[agentv1.ShellRequest, agentv1.ShellResponse] {
+	panic("ExecInteractive not implemented in mock")
+}
+
+func (m *mockAgentClient) ReadFile(ctx context.Context, req *connect.Request[agentv1.ReadFileRequest]) (*connect.ServerStreamForClient[agentv1.ReadFileResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("not implemented in mock"))
+}
+
+func (m *mockAgentClient) WriteFile(ctx context.Context) *connect.ClientStreamForClient[agentv1.WriteFileRequest, agentv1.WriteFileResponse] {
+	panic("WriteFile not implemented in mock")
+}
+
 func (m *mockAgentClient) ResizeShellTerminal(ctx context.Context, req *connect.Request[agentv1.ResizeShellTerminalRequest]) (*connect.Response[agentv1.ResizeShellTerminalResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("not implemented in mock"))
 }
@@ -149,6 +229,10 @@ func (m *mockAgentClient) GetFunctions(ctx context.Context, req *connect.Request
 	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("not implemented in mock"))
 }
 
+func (m *mockAgentClient) UpdateColonySecret(ctx context.Context, req *connect.Request[agentv1.UpdateColonySecretRequest]) (*connect.Response[agentv1.UpdateColonySecretResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("not implemented in mock"))
+}
+
 func TestDebugFlowIntegration(t *testing.T) {
 	// Setup dependencies
 	logger := zerolog.Nop()
@@ -347,6 +431,9 @@ func TestDebugFlow_AgentNetworkError(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, resp.Msg.Success)
 	assert.Contains(t, resp.Msg.Error, "failed to start uprobe collector")
+	require.NotNil(t, resp.Msg.ErrorInfo)
+	assert.Equal(t, errorsv1.ErrorCode_ERROR_CODE_AGENT_UNREACHABLE, resp.Msg.ErrorInfo.Code)
+	assert.Equal(t, agentID, resp.Msg.ErrorInfo.Metadata["agent_id"])
 }
 
 func TestDebugFlow_ServiceDiscovery(t *testing.T) {
@@ -646,6 +733,293 @@ func TestDebugFlow_CPUProfile(t *testing.T) {
 	})
 }
 
+func TestDebugFlow_RuntimeTrace(t *testing.T) {
+	logger := zerolog.Nop()
+	db := setupTestDB(t)
+	reg := registry.New(db)
+	defer db.Close()
+
+	agentID := "agent-1"
+	serviceName := "api"
+	_, err := reg.Register(agentID, agentID, "10.0.0.1", "", []*meshv1.ServiceInfo{
+		{Name: serviceName, Port: 8080, ProcessId: 1234},
+	}, nil, "v1")
+	require.NoError(t, err)
+
+	orch := NewOrchestrator(logger, reg, db, nil)
+	orch.agentClientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentServiceClient {
+		return &mockAgentClient{
+			listServicesFunc: func(ctx context.Context, req *connect.Request[agentv1.ListServicesRequest]) (*connect.Response[agentv1.ListServicesResponse], error) {
+				return connect.NewResponse(&agentv1.ListServicesResponse{
+					Services: []*agentv1.ServiceStatus{{Name: serviceName, ProcessId: 1234}},
+				}), nil
+			},
+		}
+	}
+
+	ctx := context.Background()
+	start := time.Now().Add(-10 * time.Second).Truncate(time.Millisecond)
+	gcStart := start.Add(2 * time.Second)
+
+	// One request ran during the GC cycle and was slow, the others did not.
+	var spans []*agentv1.EbpfTraceSpan
+	for i := 0; i < 99; i++ {
+		spans = append(spans, &agentv1.EbpfTraceSpan{
+			TraceId:     fmt.Sprintf("trace-%d", i),
+			SpanId:      fmt.Sprintf("span-%d", i),
+			ServiceName: serviceName,
+			SpanName:    "GET /api",
+			SpanKind:    "server",
+			StartTime:   start.Add(3*time.Second + time.Duration(i)*time.Millisecond).UnixMilli(),
+			DurationUs:  1000,
+		})
+	}
+	spans = append(spans, &agentv1.EbpfTraceSpan{
+		TraceId:     "trace-slow",
+		SpanId:      "span-slow",
+		ServiceName: serviceName,
+		SpanName:    "GET /api",
+		SpanKind:    "server",
+		StartTime:   gcStart.UnixMilli(),
+		DurationUs:  50000,
+	})
+	require.NoError(t, db.InsertBeylaTraces(ctx, agentID, spans))
+
+	orch.clientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentDebugServiceClient {
+		return &mockDebugClient{
+			runtimeFunc: func(ctx context.Context, req *connect.Request[agentv1.TraceRuntimeAgentRequest]) (*connect.Response[agentv1.TraceRuntimeAgentResponse], error) {
+				assert.Equal(t, int32(1234), req.Msg.Pid)
+				assert.Equal(t, int32(30), req.Msg.DurationSeconds, "default duration")
+				return connect.NewResponse(&agentv1.TraceRuntimeAgentResponse{
+					Success:   true,
+					GoVersion: "go1.22.3",
+					StartTime: timestamppb.New(start),
+					EndTime:   timestamppb.New(start.Add(5 * time.Second)),
+					Pauses: []*agentv1.RuntimePause{
+						{Start: timestamppb.New(gcStart), DurationNs: 300000, Reason: "GC sweep termination"},
+						{Start: timestamppb.New(gcStart.Add(20 * time.Millisecond)), DurationNs: 500000, Reason: "GC mark termination"},
+					},
+					GcCycles: []*agentv1.GcCycle{
+						{Start: timestamppb.New(gcStart), DurationNs: 20500000, PauseNs: 800000},
+					},
+				}), nil
+			},
+		}
+	}
+
+	resp, err := orch.TraceRuntime(ctx, connect.NewRequest(&debugpb.TraceRuntimeRequest{
+		ServiceName: serviceName,
+	}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Success, resp.Msg.Error)
+	assert.Equal(t, "go1.22.3", resp.Msg.GoVersion)
+	assert.Len(t, resp.Msg.Pauses, 2)
+	assert.Len(t, resp.Msg.GcCycles, 1)
+
+	requests := resp.Msg.Requests
+	require.NotNil(t, requests)
+	assert.Equal(t, uint64(100), requests.Requests)
+	assert.Equal(t, uint64(1), requests.RequestsDuringGc)
+	assert.Equal(t, uint64(50000), requests.P99DuringGcUs)
+	assert.Equal(t, uint64(1000), requests.P99OutsideGcUs)
+}
+
+func TestDebugFlow_SyscallTrace(t *testing.T) {
+	logger := zerolog.Nop()
+	db := setupTestDB(t)
+	reg := registry.New(db)
+	defer db.Close()
+
+	agentID := "agent-1"
+	serviceName := "api"
+	_, err := reg.Register(agentID, agentID, "10.0.0.1", "", []*meshv1.ServiceInfo{
+		{Name: serviceName, Port: 8080, ProcessId: 1234},
+	}, nil, "v1")
+	require.NoError(t, err)
+
+	orch := NewOrchestrator(logger, reg, db, nil)
+	orch.agentClientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentServiceClient {
+		return &mockAgentClient{
+			listServicesFunc: func(ctx context.Context, req *connect.Request[agentv1.ListServicesRequest]) (*connect.Response[agentv1.ListServicesResponse], error) {
+				return connect.NewResponse(&agentv1.ListServicesResponse{
+					Services: []*agentv1.ServiceStatus{{Name: serviceName, ProcessId: 1234}},
+				}), nil
+			},
+		}
+	}
+
+	start := time.Now().Add(-10 * time.Second).Truncate(time.Millisecond)
+	orch.clientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentDebugServiceClient {
+		return &mockDebugClient{
+			syscallFunc: func(ctx context.Context, req *connect.Request[agentv1.TraceSyscallsAgentRequest]) (*connect.Response[agentv1.TraceSyscallsAgentResponse], error) {
+				assert.Equal(t, int32(1234), req.Msg.Pid)
+				assert.Equal(t, int32(300), req.Msg.DurationSeconds, "duration is capped")
+				assert.Equal(t, []string{"openat", "connect"}, req.Msg.Syscalls)
+				return connect.NewResponse(&agentv1.TraceSyscallsAgentResponse{
+					Success:   true,
+					Target:    "cgroup /system.slice/api.service",
+					StartTime: timestamppb.New(start),
+					EndTime:   timestamppb.New(start.Add(5 * time.Second)),
+					Syscalls: []*agentv1.SyscallStats{
+						{
+							Name: "openat", Number: 257, Count: 120, TotalNs: 2400000,
+							Latency: []*agentv1.LatencyBucket{{UpperBoundNs: 32768, Count: 120}},
+							Errors:  []*agentv1.SyscallError{{Name: "ENOENT", Count: 40}},
+						},
+						{Name: "connect", Number: 42, Count: 3, TotalNs: 90000},
+					},
+				}), nil
+			},
+		}
+	}
+
+	resp, err := orch.TraceSyscalls(context.Background(), connect.NewRequest(&debugpb.TraceSyscallsRequest{
+		ServiceName:     serviceName,
+		DurationSeconds: 3600,
+		Syscalls:        []string{"openat", "connect"},
+	}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Success, resp.Msg.Error)
+	assert.Equal(t, agentID, resp.Msg.AgentId)
+	assert.Equal(t, "cgroup /system.slice/api.service", resp.Msg.Target)
+	require.Len(t, resp.Msg.Syscalls, 2)
+	assert.Equal(t, uint64(40), resp.Msg.Syscalls[0].Errors[0].Count)
+
+	// Failures reported by the agent are passed on.
+	orch.clientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentDebugServiceClient {
+		return &mockDebugClient{
+			syscallFunc: func(ctx context.Context, req *connect.Request[agentv1.TraceSyscallsAgentRequest]) (*connect.Response[agentv1.TraceSyscallsAgentResponse], error) {
+				return connect.NewResponse(&agentv1.TraceSyscallsAgentResponse{Error: `unknown syscall "frobnicate"`}), nil
+			},
+		}
+	}
+	resp, err = orch.TraceSyscalls(context.Background(), connect.NewRequest(&debugpb.TraceSyscallsRequest{
+		ServiceName: serviceName,
+		Syscalls:    []string{"frobnicate"},
+	}))
+	require.NoError(t, err)
+	assert.False(t, resp.Msg.Success)
+	assert.Contains(t, resp.Msg.Error, "frobnicate")
+}
+
+func TestDebugFlow_IOProfile(t *testing.T) {
+	logger := zerolog.Nop()
+	db := setupTestDB(t)
+	reg := registry.New(db)
+	defer db.Close()
+
+	agentID := "agent-1"
+	serviceName := "api"
+	_, err := reg.Register(agentID, agentID, "10.0.0.1", "", []*meshv1.ServiceInfo{
+		{Name: serviceName, Port: 8080, ProcessId: 1234},
+	}, nil, "v1")
+	require.NoError(t, err)
+
+	orch := NewOrchestrator(logger, reg, db, nil)
+	orch.agentClientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentServiceClient {
+		return &mockAgentClient{
+			listServicesFunc: func(ctx context.Context, req *connect.Request[agentv1.ListServicesRequest]) (*connect.Response[agentv1.ListServicesResponse], error) {
+				return connect.NewResponse(&agentv1.ListServicesResponse{
+					Services: []*agentv1.ServiceStatus{{Name: serviceName, ProcessId: 1234}},
+				}), nil
+			},
+		}
+	}
+
+	start := time.Now().Add(-10 * time.Second).Truncate(time.Millisecond)
+	orch.clientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentDebugServiceClient {
+		return &mockDebugClient{
+			ioFunc: func(ctx context.Context, req *connect.Request[agentv1.ProfileIOAgentRequest]) (*connect.Response[agentv1.ProfileIOAgentResponse], error) {
+				assert.Equal(t, int32(1234), req.Msg.Pid)
+				assert.Equal(t, int32(30), req.Msg.DurationSeconds, "default duration")
+				stats := &agentv1.IOStats{Reads: 100, ReadBytes: 819200, ReadNs: 9000000, MaxLatencyNs: 2000000}
+				return connect.NewResponse(&agentv1.ProfileIOAgentResponse{
+					Success:   true,
+					Target:    "cgroup /system.slice/api.service",
+					StartTime: timestamppb.New(start),
+					EndTime:   timestamppb.New(start.Add(30 * time.Second)),
+					Files:     []*agentv1.FileIO{{Path: "/data/app.db", Device: "259:2", Inode: 7, Stats: stats}},
+					Devices:   []*agentv1.DeviceIO{{Device: "259:2", Source: "/dev/nvme0n1p2", FsType: "ext4", MountPoint: "/data", Stats: stats}},
+					ReadLatency: []*agentv1.LatencyBucket{
+						{UpperBoundNs: 131072, Count: 99},
+						{UpperBoundNs: 2097152, Count: 1},
+					},
+				}), nil
+			},
+		}
+	}
+
+	resp, err := orch.ProfileIO(context.Background(), connect.NewRequest(&debugpb.ProfileIORequest{
+		ServiceName: serviceName,
+	}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Success, resp.Msg.Error)
+	assert.Equal(t, agentID, resp.Msg.AgentId)
+	assert.Equal(t, "cgroup /system.slice/api.service", resp.Msg.Target)
+	require.Len(t, resp.Msg.Files, 1)
+	assert.Equal(t, "/data/app.db", resp.Msg.Files[0].Path)
+	require.Len(t, resp.Msg.Devices, 1)
+	assert.Equal(t, "/dev/nvme0n1p2", resp.Msg.Devices[0].Source)
+	assert.Len(t, resp.Msg.ReadLatency, 2)
+}
+
+func TestDebugFlow_LockProfile(t *testing.T) {
+	logger := zerolog.Nop()
+	db := setupTestDB(t)
+	reg := registry.New(db)
+	defer db.Close()
+
+	agentID := "agent-1"
+	serviceName := "api"
+	_, err := reg.Register(agentID, agentID, "10.0.0.1", "", []*meshv1.ServiceInfo{
+		{Name: serviceName, Port: 8080, ProcessId: 1234},
+	}, nil, "v1")
+	require.NoError(t, err)
+
+	orch := NewOrchestrator(logger, reg, db, nil)
+	orch.agentClientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentServiceClient {
+		return &mockAgentClient{
+			listServicesFunc: func(ctx context.Context, req *connect.Request[agentv1.ListServicesRequest]) (*connect.Response[agentv1.ListServicesResponse], error) {
+				return connect.NewResponse(&agentv1.ListServicesResponse{
+					Services: []*agentv1.ServiceStatus{{Name: serviceName, ProcessId: 1234}},
+				}), nil
+			},
+		}
+	}
+
+	orch.clientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentDebugServiceClient {
+		return &mockDebugClient{
+			locksFunc: func(ctx context.Context, req *connect.Request[agentv1.ProfileLocksAgentRequest]) (*connect.Response[agentv1.ProfileLocksAgentResponse], error) {
+				assert.Equal(t, int32(1234), req.Msg.Pid)
+				assert.Equal(t, int32(300), req.Msg.DurationSeconds, "duration capped")
+				return connect.NewResponse(&agentv1.ProfileLocksAgentResponse{
+					Success: true,
+					Target:  "PID 1234 and 0 descendants",
+					Stacks: []*agentv1.LockWaitStack{
+						{FrameNames: []string{"[libc.so.6] __lll_lock_wait", "[libc.so.6] pthread_mutex_lock", "main.flush"}, Waits: 40, WaitNs: 80000000, MaxWaitNs: 5000000},
+						{FrameNames: []string{"runtime.futex", "runtime.lock2", "main.handle"}, Waits: 3, WaitNs: 900000, MaxWaitNs: 400000},
+					},
+					TotalWaits:  43,
+					TotalWaitNs: 80900000,
+				}), nil
+			},
+		}
+	}
+
+	resp, err := orch.ProfileLocks(context.Background(), connect.NewRequest(&debugpb.ProfileLocksRequest{
+		ServiceName:     serviceName,
+		DurationSeconds: 600,
+	}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Success, resp.Msg.Error)
+	assert.Equal(t, agentID, resp.Msg.AgentId)
+	assert.Equal(t, "PID 1234 and 0 descendants", resp.Msg.Target)
+	require.Len(t, resp.Msg.Stacks, 2)
+	assert.Equal(t, "main.flush", resp.Msg.Stacks[0].FrameNames[2])
+	assert.Equal(t, uint64(43), resp.Msg.TotalWaits)
+	assert.Equal(t, uint64(80900000), resp.Msg.TotalWaitNs)
+}
+
 // mockDebugClientWithCPUProfile extends mockDebugClient with ProfileCPU support.
 type mockDebugClientWithCPUProfile struct {
 	*mockDebugClient
@@ -792,3 +1166,229 @@ func TestDebugFlow_MemoryProfile(t *testing.T) {
 		assert.Contains(t, resp.Msg.Error, "SDK unreachable")
 	})
 }
+
+func TestDebugFlow_DescribeFunction(t *testing.T) {
+	logger := zerolog.Nop()
+	db := setupTestDB(t)
+	reg := registry.New(db)
+	defer db.Close()
+
+	agentID := "agent-1"
+	serviceName := "payment-service"
+	services := []*meshv1.ServiceInfo{{Name: serviceName, Port: 8080}}
+	_, err := reg.Register(agentID, agentID, "10.0.0.1", "", services, nil, "v1")
+	require.NoError(t, err)
+
+	orch := NewOrchestrator(logger, reg, db, nil)
+	orch.agentClientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentServiceClient {
+		return &mockAgentClient{
+			listServicesFunc: func(ctx context.Context, req *connect.Request[agentv1.ListServicesRequest]) (*connect.Response[agentv1.ListServicesResponse], error) {
+				return connect.NewResponse(&agentv1.ListServicesResponse{
+					Services: []*agentv1.ServiceStatus{{Name: serviceName}},
+				}), nil
+			},
+		}
+	}
+
+	mockClient := &mockDebugClient{
+		describeFunc: func(ctx context.Context, req *connect.Request[agentv1.DescribeFunctionRequest]) (*connect.Response[agentv1.DescribeFunctionResponse], error) {
+			assert.Equal(t, serviceName, req.Msg.ServiceName)
+			if req.Msg.FunctionName != "main.ProcessPayment" {
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("function not found"))
+			}
+			return connect.NewResponse(&agentv1.DescribeFunctionResponse{
+				Function: &agentv1.FunctionDescription{
+					Name:      "main.ProcessPayment",
+					Signature: "func main.ProcessPayment(amount int) error",
+				},
+			}), nil
+		},
+	}
+	orch.clientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentDebugServiceClient {
+		return mockClient
+	}
+
+	ctx := context.Background()
+
+	resp, err := orch.DescribeFunction(ctx, connect.NewRequest(&debugpb.ColonyDescribeFunctionRequest{
+		ServiceName:  serviceName,
+		FunctionName: "main.ProcessPayment",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, agentID, resp.Msg.AgentId)
+	assert.Equal(t, "func main.ProcessPayment(amount int) error", resp.Msg.Function.Signature)
+
+	_, err = orch.DescribeFunction(ctx, connect.NewRequest(&debugpb.ColonyDescribeFunctionRequest{
+		ServiceName:  serviceName,
+		FunctionName: "main.Missing",
+	}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = orch.DescribeFunction(ctx, connect.NewRequest(&debugpb.ColonyDescribeFunctionRequest{
+		ServiceName:  "unknown-service",
+		FunctionName: "main.ProcessPayment",
+	}))
+	assert.Equal(t, errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND, coralerrors.CodeOf(err))
+}
+
+func TestDebugFlow_CaptureHttp(t *testing.T) {
+	logger := zerolog.Nop()
+	db := setupTestDB(t)
+	reg := registry.New(db)
+	defer db.Close()
+
+	agentID := "agent-1"
+	_, err := reg.Register(agentID, "api", "10.0.0.1", "", nil, nil, "v1")
+	require.NoError(t, err)
+
+	orch := NewOrchestrator(logger, reg, db, nil)
+	mockClient := &mockDebugClient{
+		captureFunc: func(ctx context.Context, req *connect.Request[agentv1.StartHttpCaptureRequest]) (*connect.Response[agentv1.StartHttpCaptureResponse], error) {
+			assert.Equal(t, "api", req.Msg.ServiceName)
+			assert.Equal(t, "/api/checkout", req.Msg.Route)
+			assert.Equal(t, 0.01, req.Msg.SampleRate)
+			assert.True(t, req.Msg.CaptureBodies)
+			assert.NotEmpty(t, req.Msg.SessionId)
+			return connect.NewResponse(&agentv1.StartHttpCaptureResponse{
+				Supported:   true,
+				CollectorId: "capture-1",
+			}), nil
+		},
+	}
+	orch.clientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentDebugServiceClient {
+		return mockClient
+	}
+
+	ctx := context.Background()
+
+	resp, err := orch.CaptureHttp(ctx, connect.NewRequest(&debugpb.CaptureHttpRequest{
+		AgentId:       agentID,
+		ServiceName:   "api",
+		Route:         "/api/checkout",
+		SampleRate:    0.01,
+		CaptureBodies: true,
+	}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Success, resp.Msg.Error)
+
+	session, err := db.GetDebugSession(ctx, resp.Msg.SessionId)
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, "capture-1", session.CollectorID)
+	assert.Equal(t, "http:/api/checkout", session.FunctionName)
+
+	// Captured exchanges are stored as session events.
+	require.NoError(t, db.InsertDebugEvents(ctx, resp.Msg.SessionId, []*agentv1.UprobeEvent{{
+		Timestamp:    timestamppb.Now(),
+		CollectorId:  "capture-1",
+		ServiceName:  "api",
+		FunctionName: "http:/api/checkout",
+		EventType:    "http",
+		DurationNs:   2000000,
+		Http: &agentv1.HttpExchange{
+			Method:         "POST",
+			Path:           "/api/checkout",
+			StatusCode:     201,
+			RequestHeaders: []*agentv1.HttpHeader{{Name: "Authorization", Value: "[REDACTED]"}},
+			RequestBody:    `{"amount":42}`,
+		},
+		Redacted: true,
+	}}))
+	events, err := db.GetDebugEvents(resp.Msg.SessionId)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.NotNil(t, events[0].Http)
+	assert.Equal(t, int32(201), events[0].Http.StatusCode)
+	assert.Equal(t, `{"amount":42}`, events[0].Http.RequestBody)
+	assert.Equal(t, "[REDACTED]", events[0].Http.RequestHeaders[0].Value)
+
+	// Missing route.
+	resp, err = orch.CaptureHttp(ctx, connect.NewRequest(&debugpb.CaptureHttpRequest{
+		AgentId:     agentID,
+		ServiceName: "api",
+	}))
+	require.NoError(t, err)
+	assert.False(t, resp.Msg.Success)
+	assert.Equal(t, errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, resp.Msg.ErrorInfo.GetCode())
+}
+
+func TestDebugFlow_CaptureTls(t *testing.T) {
+	logger := zerolog.Nop()
+	db := setupTestDB(t)
+	reg := registry.New(db)
+	defer db.Close()
+
+	agentID := "agent-1"
+	_, err := reg.Register(agentID, "api", "10.0.0.1", "", nil, nil, "v1")
+	require.NoError(t, err)
+
+	orch := NewOrchestrator(logger, reg, db, nil)
+	mockClient := &mockDebugClient{
+		tlsFunc: func(ctx context.Context, req *connect.Request[agentv1.StartTlsCaptureRequest]) (*connect.Response[agentv1.StartTlsCaptureResponse], error) {
+			assert.Equal(t, "api", req.Msg.ServiceName)
+			assert.Equal(t, "Host: api.stripe.com", req.Msg.Match)
+			assert.Equal(t, uint32(1024), req.Msg.MaxDataBytes)
+			assert.NotEmpty(t, req.Msg.SessionId)
+			return connect.NewResponse(&agentv1.StartTlsCaptureResponse{
+				Supported:   true,
+				CollectorId: "tls-1",
+			}), nil
+		},
+	}
+	orch.clientFactory = func(client connect.HTTPClient, url string, opts ...connect.ClientOption) agentv1connect.AgentDebugServiceClient {
+		return mockClient
+	}
+
+	ctx := context.Background()
+
+	resp, err := orch.CaptureTls(ctx, connect.NewRequest(&debugpb.CaptureTlsRequest{
+		AgentId:      agentID,
+		ServiceName:  "api",
+		Match:        "Host: api.stripe.com",
+		MaxDataBytes: 1024,
+	}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Success, resp.Msg.Error)
+
+	session, err := db.GetDebugSession(ctx, resp.Msg.SessionId)
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, "tls-1", session.CollectorID)
+	assert.Equal(t, "tls:auto/Host: api.stripe.com", session.FunctionName)
+
+	// Captured records are stored as session events.
+	require.NoError(t, db.InsertDebugEvents(ctx, resp.Msg.SessionId, []*agentv1.UprobeEvent{{
+		Timestamp:    timestamppb.Now(),
+		CollectorId:  "tls-1",
+		ServiceName:  "api",
+		FunctionName: "tls:go",
+		EventType:    "tls",
+		Tls: &agentv1.TlsData{
+			Library:      "go",
+			Direction:    "write",
+			ConnectionId: 42,
+			Data:         []byte("POST /v1/charges HTTP/1.1\r\n"),
+			Length:       2048,
+			Truncated:    true,
+		},
+	}}))
+	events, err := db.GetDebugEvents(resp.Msg.SessionId)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.NotNil(t, events[0].Tls)
+	assert.Equal(t, "write", events[0].Tls.Direction)
+	assert.Equal(t, "POST /v1/charges HTTP/1.1\r\n", string(events[0].Tls.Data))
+	assert.True(t, events[0].Tls.Truncated)
+
+	// Agent refusal.
+	mockClient.tlsFunc = func(ctx context.Context, req *connect.Request[agentv1.StartTlsCaptureRequest]) (*connect.Response[agentv1.StartTlsCaptureResponse], error) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("TLS capture is disabled on this agent"))
+	}
+	resp, err = orch.CaptureTls(ctx, connect.NewRequest(&debugpb.CaptureTlsRequest{
+		AgentId:     agentID,
+		ServiceName: "api",
+	}))
+	require.NoError(t, err)
+	assert.False(t, resp.Msg.Success)
+	assert.Contains(t, resp.Msg.Error, "disabled")
+}
@@ -3,150 +3,125 @@ package debug
 
 import (
 	"context"
-	"sync"
-	"time"
+	"fmt"
 
 	"connectrpc.com/connect"
 	"github.com/rs/zerolog"
-	"google.golang.org/protobuf/types/known/timestamppb"
 
 	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
 
 	"github.com/coral-mesh/coral/internal/colony/database"
 )
 
-// eventPersistTimeout is the timeout for event persistence operations.
-const eventPersistTimeout = 30 * time.Second
+// sessionOwnerLookup returns the agent owning a debug session, and whether the
+// session is known.
+type sessionOwnerLookup func(ctx context.Context, sessionID string) (string, bool)
 
-// EventPersister handles background event persistence for debug sessions.
+// EventPersister persists uprobe events pushed by agents as they are captured.
+// It is the only writer of debug events for live sessions, so events are not
+// stored twice by concurrent pollers.
 type EventPersister struct {
-	ctx                     context.Context
-	logger                  zerolog.Logger
-	db                      *database.Database
-	queryRouter             *QueryRouter
-	stopBackgroundPersist   chan struct{}
-	timestampsMu            sync.RWMutex
-	lastPersistedTimestamps map[string]time.Time // sessionID -> last persisted event timestamp
+	logger       zerolog.Logger
+	db           *database.Database
+	sessionOwner sessionOwnerLookup
 }
 
 // NewEventPersister creates a new event persister.
 func NewEventPersister(
-	ctx context.Context,
 	logger zerolog.Logger,
 	db *database.Database,
-	queryRouter *QueryRouter,
+	sessionOwner sessionOwnerLookup,
 ) *EventPersister {
 	return &EventPersister{
-		ctx:                     ctx,
-		logger:                  logger.With().Str("component", "event_persister").Logger(),
-		db:                      db,
-		queryRouter:             queryRouter,
-		stopBackgroundPersist:   make(chan struct{}),
-		lastPersistedTimestamps: make(map[string]time.Time),
+		logger:       logger.With().Str("component", "event_persister").Logger(),
+		db:           db,
+		sessionOwner: sessionOwner,
 	}
 }
 
-// Start begins background event persistence for all sessions.
-// This ensures events are always in the database, even if DetachUprobe is never called.
-func (ep *EventPersister) Start() {
-	go ep.runBackgroundEventPersistence()
-}
-
-// runBackgroundEventPersistence continuously persists events from all active sessions.
-func (ep *EventPersister) runBackgroundEventPersistence() {
-	ticker := time.NewTicker(10 * time.Second)
-	defer ticker.Stop()
-
-	ep.logger.Info().Msg("Started background event persistence for all debug sessions")
-
-	for {
-		select {
-		case <-ticker.C:
-			ep.persistEventsFromActiveSessions()
-		case <-ep.stopBackgroundPersist:
-			ep.logger.Info().Msg("Stopped background event persistence")
-			return
+// Ingest receives a stream of event batches from an agent and persists them.
+// Batches are written as they arrive, so a slow database slows the agent down
+// rather than buffering in the colony.
+func (ep *EventPersister) Ingest(
+	ctx context.Context,
+	stream *connect.ClientStream[debugpb.IngestUprobeEventsRequest],
+) (*connect.Response[debugpb.IngestUprobeEventsResponse], error) {
+	var (
+		accepted int64
+		batches  int
+		unknown  []string
+		checked  = make(map[[2]string]bool) // (agentID, sessionID) -> session known and owned by the agent
+	)
+
+	for stream.Receive() {
+		msg := stream.Msg()
+
+		key := [2]string{msg.AgentId, msg.SessionId}
+		known, ok := checked[key]
+		if !ok {
+			owner, exists := ep.sessionOwner(ctx, msg.SessionId)
+			known = exists && owner == msg.AgentId
+			checked[key] = known
+
+			if !known {
+				ep.logger.Warn().
+					Str("agent_id", msg.AgentId).
+					Str("session_id", msg.SessionId).
+					Msg("Rejecting uprobe events for unknown session")
+				unknown = append(unknown, msg.SessionId)
+			}
 		}
-	}
-}
-
-// persistEventsFromActiveSessions queries and persists events from all active sessions.
-func (ep *EventPersister) persistEventsFromActiveSessions() {
-	ctx, cancel := context.WithTimeout(ep.ctx, eventPersistTimeout)
-	defer cancel()
-
-	// Get all active sessions from database.
-	sessions, err := ep.db.ListDebugSessions(database.DebugSessionFilters{
-		Status: "active",
-	})
-	if err != nil {
-		ep.logger.Error().Err(err).Msg("Failed to list active sessions for background persistence")
-		return
-	}
-
-	if len(sessions) == 0 {
-		return
-	}
-
-	ep.logger.Debug().
-		Int("session_count", len(sessions)).
-		Msg("Persisting events from active sessions")
-
-	persistedCount := 0
-	for _, session := range sessions {
-		// Skip expired sessions.
-		if time.Now().After(session.ExpiresAt) {
+		if !known {
 			continue
 		}
 
-		// Query new events since last persistence.
-		ep.timestampsMu.RLock()
-		lastTime := ep.lastPersistedTimestamps[session.SessionID]
-		ep.timestampsMu.RUnlock()
-
-		queryReq := connect.NewRequest(&debugpb.QueryUprobeEventsRequest{
-			SessionId: session.SessionID,
-			StartTime: timestamppb.New(lastTime),
-			MaxEvents: 10000,
-		})
+		if msg.DroppedEvents > 0 {
+			ep.logger.Warn().
+				Str("agent_id", msg.AgentId).
+				Str("session_id", msg.SessionId).
+				Uint64("dropped_events", msg.DroppedEvents).
+				Msg("Agent dropped uprobe events before pushing them")
+		}
 
-		queryResp, err := ep.queryRouter.QueryUprobeEvents(ctx, queryReq)
-		if err != nil {
-			ep.logger.Debug().
-				Err(err).
-				Str("session_id", session.SessionID).
-				Msg("Failed to query events for background persistence")
+		if len(msg.Events) == 0 {
 			continue
 		}
 
-		if len(queryResp.Msg.Events) > 0 {
-			// Persist new events to database.
-			if err := ep.db.InsertDebugEvents(ctx, session.SessionID, queryResp.Msg.Events); err != nil {
-				ep.logger.Error().
-					Err(err).
-					Str("session_id", session.SessionID).
-					Int("event_count", len(queryResp.Msg.Events)).
-					Msg("Failed to persist events in background")
-			} else {
-				persistedCount += len(queryResp.Msg.Events)
-				// Update last persisted timestamp.
-				lastEvent := queryResp.Msg.Events[len(queryResp.Msg.Events)-1]
-				ep.timestampsMu.Lock()
-				ep.lastPersistedTimestamps[session.SessionID] = lastEvent.Timestamp.AsTime()
-				ep.timestampsMu.Unlock()
+		for _, event := range msg.Events {
+			if event.AgentId == "" {
+				event.AgentId = msg.AgentId
 			}
 		}
+
+		if err := ep.db.InsertDebugEvents(ctx, msg.SessionId, msg.Events); err != nil {
+			ep.logger.Error().
+				Err(err).
+				Str("session_id", msg.SessionId).
+				Int("event_count", len(msg.Events)).
+				Msg("Failed to persist pushed uprobe events")
+			// Fail the stream so that the agent retries the push.
+			return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to persist events: %w", err))
+		}
+
+		accepted += int64(len(msg.Events))
+		batches++
 	}
 
-	if persistedCount > 0 {
-		ep.logger.Info().
-			Int("event_count", persistedCount).
-			Int("session_count", len(sessions)).
-			Msg("Background event persistence completed")
+	if err := stream.Err(); err != nil {
+		ep.logger.Warn().
+			Err(err).
+			Int("batches", batches).
+			Msg("IngestUprobeEvents stream closed with error")
+		return nil, fmt.Errorf("stream error: %w", err)
 	}
-}
 
-// Stop gracefully stops the event persister's background tasks.
-func (ep *EventPersister) Stop() {
-	close(ep.stopBackgroundPersist)
+	ep.logger.Debug().
+		Int("batches", batches).
+		Int64("events", accepted).
+		Msg("IngestUprobeEvents stream completed")
+
+	return connect.NewResponse(&debugpb.IngestUprobeEventsResponse{
+		EventsAccepted:    accepted,
+		UnknownSessionIds: unknown,
+	}), nil
 }
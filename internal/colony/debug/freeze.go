@@ -0,0 +1,59 @@
+package debug
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// checkNotFrozen returns a SERVICE_FROZEN error if operators froze the
+// service: the colony starts no new sessions against frozen services unless
+// the request overrides the freeze. Only admins may override it; requests
+// without an API token come from the mesh or the colony itself and are
+// trusted like admins. Overrides are logged, and the audit log records them
+// with the request.
+func checkNotFrozen(ctx context.Context, db *database.Database, logger zerolog.Logger, service string, override bool) error {
+	if service == "" {
+		return nil
+	}
+
+	freeze, err := db.GetServiceFreeze(ctx, service, time.Now())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check freeze of service %s: %w", service, err)
+	}
+
+	if !override {
+		msg := fmt.Sprintf("service %s is frozen until %s", service, freeze.ExpiresAt.Format(time.RFC3339))
+		if freeze.Reason != "" {
+			msg += " (" + freeze.Reason + ")"
+		}
+		return coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_SERVICE_FROZEN, errors.New(msg),
+			"service", service, "expires_at", freeze.ExpiresAt.Format(time.RFC3339))
+	}
+
+	if token := httpapi.GetAuthenticatedToken(ctx); token != nil && !auth.HasPermission(token, auth.PermissionAdmin) {
+		return coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED,
+			fmt.Errorf("overriding the freeze of service %s requires %s permission", service, auth.PermissionAdmin),
+			"service", service, "permission", string(auth.PermissionAdmin))
+	}
+
+	logger.Warn().
+		Str("service", service).
+		Str("frozen_by", freeze.FrozenBy).
+		Str("reason", freeze.Reason).
+		Msg("Service freeze overridden")
+	return nil
+}
@@ -0,0 +1,154 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// ProfileIO profiles the file reads and writes of a service for a duration,
+// by file and device, on the agent running it.
+func (o *Orchestrator) ProfileIO(
+	ctx context.Context,
+	req *connect.Request[debugpb.ProfileIORequest],
+) (*connect.Response[debugpb.ProfileIOResponse], error) {
+	o.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Int32("duration", req.Msg.DurationSeconds).
+		Msg("Starting I/O profile")
+
+	durationSeconds := req.Msg.DurationSeconds
+	if durationSeconds <= 0 {
+		durationSeconds = 30 // Default 30 seconds
+	}
+	if durationSeconds > 300 {
+		durationSeconds = 300 // Max 5 minutes
+	}
+
+	agentID := req.Msg.AgentId
+	if agentID == "" {
+		var err error
+		agentID, err = o.agentCoordinator.FindAgentForService(ctx, req.Msg.ServiceName)
+		if err != nil {
+			return connect.NewResponse(&debugpb.ProfileIOResponse{
+				Success:   false,
+				Error:     fmt.Sprintf("failed to find agent for service %s: %v", req.Msg.ServiceName, err),
+				ErrorInfo: coralerrors.Info(err),
+			}), nil
+		}
+	}
+
+	entry, err := o.registry.Get(agentID)
+	if err != nil {
+		return connect.NewResponse(&debugpb.ProfileIOResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent not found: %v", err),
+			ErrorInfo: &errorsv1.ErrorInfo{
+				Code:     errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND,
+				Metadata: map[string]string{"agent_id": agentID},
+			},
+		}), nil
+	}
+
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.ProfileIOResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, o.db, o.logger, req.Msg.ServiceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.ProfileIOResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	targetPID, err := o.agentCoordinator.GetServicePID(ctx, agentID, req.Msg.ServiceName)
+	if err != nil {
+		return connect.NewResponse(&debugpb.ProfileIOResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to get service PID: %v", err),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	debugClient := o.clientFactory(
+		http.DefaultClient,
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
+	)
+
+	agentTimeout := time.Duration(durationSeconds)*time.Second + profilingTimeout
+	agentCtx, agentCancel := context.WithTimeout(ctx, agentTimeout)
+	defer agentCancel()
+
+	profileResp, err := debugClient.ProfileIO(agentCtx, connect.NewRequest(&agentv1.ProfileIOAgentRequest{
+		AgentId:         agentID,
+		ServiceName:     req.Msg.ServiceName,
+		Pid:             targetPID,
+		DurationSeconds: durationSeconds,
+	}))
+	if err != nil {
+		o.logger.Error().Err(err).
+			Str("agent_id", agentID).
+			Str("service", req.Msg.ServiceName).
+			Msg("Failed to profile I/O on agent")
+		return connect.NewResponse(&debugpb.ProfileIOResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to profile I/O: %v", err),
+			ErrorInfo: coralerrors.Info(coralerrors.FromAgent(agentID, err)),
+		}), nil
+	}
+
+	if !profileResp.Msg.Success {
+		return connect.NewResponse(&debugpb.ProfileIOResponse{
+			Success: false,
+			Error:   profileResp.Msg.Error,
+		}), nil
+	}
+
+	profile := profileResp.Msg
+	var readBytes, writeBytes uint64
+	for _, d := range profile.Devices {
+		readBytes += d.Stats.GetReadBytes()
+		writeBytes += d.Stats.GetWriteBytes()
+	}
+
+	o.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Str("target", profile.Target).
+		Int("files", len(profile.Files)).
+		Uint64("read_bytes", readBytes).
+		Uint64("write_bytes", writeBytes).
+		Msg("I/O profile completed")
+
+	o.publishProfilingCompleted(agentID, req.Msg.ServiceName, "", "io", map[string]string{
+		"duration_seconds": fmt.Sprintf("%d", durationSeconds),
+		"files":            fmt.Sprintf("%d", len(profile.Files)),
+		"read_bytes":       fmt.Sprintf("%d", readBytes),
+		"write_bytes":      fmt.Sprintf("%d", writeBytes),
+	})
+
+	return connect.NewResponse(&debugpb.ProfileIOResponse{
+		Success:      true,
+		ServiceName:  req.Msg.ServiceName,
+		AgentId:      agentID,
+		Target:       profile.Target,
+		StartTime:    profile.StartTime,
+		EndTime:      profile.EndTime,
+		Files:        profile.Files,
+		Devices:      profile.Devices,
+		ReadLatency:  profile.ReadLatency,
+		WriteLatency: profile.WriteLatency,
+	}), nil
+}
@@ -0,0 +1,149 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// ProfileLocks profiles the lock contention of a service for a duration, as
+// the time its threads spent blocked on futexes by user stack, on the agent
+// running it.
+func (o *Orchestrator) ProfileLocks(
+	ctx context.Context,
+	req *connect.Request[debugpb.ProfileLocksRequest],
+) (*connect.Response[debugpb.ProfileLocksResponse], error) {
+	o.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Int32("duration", req.Msg.DurationSeconds).
+		Msg("Starting lock profile")
+
+	durationSeconds := req.Msg.DurationSeconds
+	if durationSeconds <= 0 {
+		durationSeconds = 30 // Default 30 seconds
+	}
+	if durationSeconds > 300 {
+		durationSeconds = 300 // Max 5 minutes
+	}
+
+	agentID := req.Msg.AgentId
+	if agentID == "" {
+		var err error
+		agentID, err = o.agentCoordinator.FindAgentForService(ctx, req.Msg.ServiceName)
+		if err != nil {
+			return connect.NewResponse(&debugpb.ProfileLocksResponse{
+				Success:   false,
+				Error:     fmt.Sprintf("failed to find agent for service %s: %v", req.Msg.ServiceName, err),
+				ErrorInfo: coralerrors.Info(err),
+			}), nil
+		}
+	}
+
+	entry, err := o.registry.Get(agentID)
+	if err != nil {
+		return connect.NewResponse(&debugpb.ProfileLocksResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent not found: %v", err),
+			ErrorInfo: &errorsv1.ErrorInfo{
+				Code:     errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND,
+				Metadata: map[string]string{"agent_id": agentID},
+			},
+		}), nil
+	}
+
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.ProfileLocksResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, o.db, o.logger, req.Msg.ServiceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.ProfileLocksResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	targetPID, err := o.agentCoordinator.GetServicePID(ctx, agentID, req.Msg.ServiceName)
+	if err != nil {
+		return connect.NewResponse(&debugpb.ProfileLocksResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to get service PID: %v", err),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	debugClient := o.clientFactory(
+		http.DefaultClient,
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
+	)
+
+	agentTimeout := time.Duration(durationSeconds)*time.Second + profilingTimeout
+	agentCtx, agentCancel := context.WithTimeout(ctx, agentTimeout)
+	defer agentCancel()
+
+	profileResp, err := debugClient.ProfileLocks(agentCtx, connect.NewRequest(&agentv1.ProfileLocksAgentRequest{
+		AgentId:         agentID,
+		ServiceName:     req.Msg.ServiceName,
+		Pid:             targetPID,
+		DurationSeconds: durationSeconds,
+	}))
+	if err != nil {
+		o.logger.Error().Err(err).
+			Str("agent_id", agentID).
+			Str("service", req.Msg.ServiceName).
+			Msg("Failed to profile locks on agent")
+		return connect.NewResponse(&debugpb.ProfileLocksResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to profile locks: %v", err),
+			ErrorInfo: coralerrors.Info(coralerrors.FromAgent(agentID, err)),
+		}), nil
+	}
+
+	if !profileResp.Msg.Success {
+		return connect.NewResponse(&debugpb.ProfileLocksResponse{
+			Success: false,
+			Error:   profileResp.Msg.Error,
+		}), nil
+	}
+
+	profile := profileResp.Msg
+
+	o.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Str("target", profile.Target).
+		Int("stacks", len(profile.Stacks)).
+		Uint64("waits", profile.TotalWaits).
+		Dur("wait_time", time.Duration(profile.TotalWaitNs)). // #nosec G115 -- sums of wait times fit
+		Msg("Lock profile completed")
+
+	o.publishProfilingCompleted(agentID, req.Msg.ServiceName, "", "locks", map[string]string{
+		"duration_seconds": fmt.Sprintf("%d", durationSeconds),
+		"stacks":           fmt.Sprintf("%d", len(profile.Stacks)),
+		"waits":            fmt.Sprintf("%d", profile.TotalWaits),
+		"wait_ns":          fmt.Sprintf("%d", profile.TotalWaitNs),
+	})
+
+	return connect.NewResponse(&debugpb.ProfileLocksResponse{
+		Success:     true,
+		ServiceName: req.Msg.ServiceName,
+		AgentId:     agentID,
+		Target:      profile.Target,
+		StartTime:   profile.StartTime,
+		EndTime:     profile.EndTime,
+		Stacks:      profile.Stacks,
+		TotalWaits:  profile.TotalWaits,
+		TotalWaitNs: profile.TotalWaitNs,
+	}), nil
+}
@@ -12,14 +12,20 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
 	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
 	"github.com/coral-mesh/coral/internal/colony"
 	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/events"
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 	"github.com/coral-mesh/coral/internal/colony/registry"
 	"github.com/coral-mesh/coral/internal/constants"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
 	"github.com/coral-mesh/coral/internal/safe"
 )
 
@@ -27,6 +33,18 @@ const (
 	// Use a timeout that covers the profiling duration plus overhead for BPF setup,
 	// symbolization, and network transfer.
 	profilingTimeout = 10 * time.Second
+
+	// memoryGrowthBuckets is the number of slices a historical memory profile
+	// query is split into for the growth trend and leak detection.
+	memoryGrowthBuckets = 12
+
+	// leakMinGrowthPct is the minimum growth of a stack's allocations between
+	// the two halves of the queried range for it to be reported as a leak
+	// candidate.
+	leakMinGrowthPct = 20.0
+
+	// maxLeakCandidates caps the leak candidates returned per query.
+	maxLeakCandidates = 10
 )
 
 // Orchestrator manages debug sessions across agents.
@@ -44,6 +62,8 @@ type Orchestrator struct {
 	agentCoordinator *AgentCoordinator
 	queryRouter      *QueryRouter
 	functionProfiler *FunctionProfiler
+
+	events *events.Broker
 }
 
 // NewOrchestrator creates a new debug orchestrator.
@@ -87,14 +107,8 @@ func NewOrchestrator(logger zerolog.Logger, registry *registry.Registry, db *dat
 		},
 	)
 
-	// Create event persister.
-	// Use background context for the event persister's lifecycle.
-	eventPersister := NewEventPersister(
-		context.Background(),
-		logger,
-		db,
-		queryRouter,
-	)
+	// Create event persister for events pushed by agents.
+	eventPersister := NewEventPersister(logger, db, sessionManager.sessionOwner)
 
 	// Assign components.
 	o.sessionManager = sessionManager
@@ -103,15 +117,40 @@ func NewOrchestrator(logger zerolog.Logger, registry *registry.Registry, db *dat
 	o.queryRouter = queryRouter
 	o.functionProfiler = NewFunctionProfiler(logger, o, o, db)
 
-	// Start background event persistence for all sessions.
-	eventPersister.Start()
-
 	return o
 }
 
+// SetEventBroker sets the broker that receives debug session and profiling
+// events.
+func (o *Orchestrator) SetEventBroker(broker *events.Broker) {
+	o.events = broker
+	o.sessionManager.events = broker
+	o.agentCoordinator.WatchEvents(broker)
+}
+
+// publishProfilingCompleted publishes a profiling completion event.
+func (o *Orchestrator) publishProfilingCompleted(
+	agentID, serviceName, sessionID, profileType string,
+	attributes map[string]string,
+) {
+	if attributes == nil {
+		attributes = make(map[string]string)
+	}
+	attributes["profile_type"] = profileType
+
+	o.events.Publish(&debugpb.ColonyEvent{
+		Type:        debugpb.ColonyEventType_COLONY_EVENT_TYPE_PROFILING_COMPLETED,
+		AgentId:     agentID,
+		ServiceName: serviceName,
+		SessionId:   sessionID,
+		Message:     fmt.Sprintf("%s profiling completed", profileType),
+		Attributes:  attributes,
+	})
+}
+
 // Stop gracefully stops the orchestrator's background tasks.
 func (o *Orchestrator) Stop() {
-	o.eventPersister.Stop()
+	o.agentCoordinator.Stop()
 }
 
 // getFunctionRegistry returns the orchestrator's function registry.
@@ -127,6 +166,24 @@ func (o *Orchestrator) AttachUprobe(
 	return o.sessionManager.AttachUprobe(ctx, req)
 }
 
+// CaptureHttp starts a debug session capturing sampled HTTP exchanges of a
+// service.
+func (o *Orchestrator) CaptureHttp(
+	ctx context.Context,
+	req *connect.Request[debugpb.CaptureHttpRequest],
+) (*connect.Response[debugpb.CaptureHttpResponse], error) {
+	return o.sessionManager.CaptureHttp(ctx, req)
+}
+
+// CaptureTls starts a debug session capturing the plaintext of a service's
+// TLS connections.
+func (o *Orchestrator) CaptureTls(
+	ctx context.Context,
+	req *connect.Request[debugpb.CaptureTlsRequest],
+) (*connect.Response[debugpb.CaptureTlsResponse], error) {
+	return o.sessionManager.CaptureTls(ctx, req)
+}
+
 // DetachUprobe stops a debug session.
 func (o *Orchestrator) DetachUprobe(
 	ctx context.Context,
@@ -151,6 +208,15 @@ func (o *Orchestrator) UpdateProbeFilter(
 	return o.sessionManager.UpdateProbeFilter(ctx, req)
 }
 
+// IngestUprobeEvents persists uprobe events pushed by an agent as they are
+// captured.
+func (o *Orchestrator) IngestUprobeEvents(
+	ctx context.Context,
+	stream *connect.ClientStream[debugpb.IngestUprobeEventsRequest],
+) (*connect.Response[debugpb.IngestUprobeEventsResponse], error) {
+	return o.eventPersister.Ingest(ctx, stream)
+}
+
 // ListDebugSessions lists all active debug sessions.
 func (o *Orchestrator) ListDebugSessions(
 	ctx context.Context,
@@ -316,6 +382,19 @@ func (o *Orchestrator) QueryFunctions(
 		}
 	}
 
+	// Query the rolled-up statistics of previously probed functions.
+	probeHistory := make(map[string]*database.DebugFunctionStats) // service/function -> stats
+	if req.Msg.IncludeMetrics {
+		since := time.Now().Add(-constants.DebugFunctionMetricsLookback)
+		history, err := o.db.QueryDebugFunctionStats(ctx, req.Msg.ServiceName, since)
+		if err != nil {
+			o.logger.Warn().Err(err).Msg("Failed to query debug function stats for function metrics")
+		}
+		for _, stats := range history {
+			probeHistory[stats.ServiceName+"/"+stats.FunctionName] = stats
+		}
+	}
+
 	// Convert function results to protobuf format
 	var results []*debugpb.FunctionResult
 	for _, fn := range colonyFunctions {
@@ -379,14 +458,36 @@ func (o *Orchestrator) QueryFunctions(
 					ErrorRate:   0.0, // TODO: Track error rate in probe data
 				}
 			}
+		} else if stats, ok := probeHistory[fn.ServiceName+"/"+fn.FunctionName]; ok {
+			// Attach metrics of past probes
+			result.Metrics = &debugpb.FunctionMetrics{
+				Source:       "probe_history",
+				LastMeasured: timestamppb.New(stats.LastBucket),
+				SampleSize:   stats.Calls,
+				P50:          durationpb.New(stats.P50),
+				P95:          durationpb.New(stats.P95),
+				P99:          durationpb.New(stats.P99),
+				CallsPerMin:  stats.CallsPerMinute(),
+				ErrorRate:    stats.ErrorRate(),
+			}
+			result.Instrumentation.LastProbed = timestamppb.New(stats.LastBucket)
 		}
 
 		results = append(results, result)
 	}
 
 	// Calculate data coverage (how many results have metrics)
-	// TODO: Implement metrics storage and retrieval
 	dataCoveragePct := int32(0)
+	if len(results) > 0 {
+		withMetrics := 0
+		for _, result := range results {
+			if result.Metrics != nil {
+				withMetrics++
+			}
+		}
+		//nolint:gosec // G115: Percentage fits in int32
+		dataCoveragePct = int32(withMetrics * 100 / len(results))
+	}
 
 	// Generate suggestion if data coverage is low
 	var suggestion string
@@ -414,7 +515,63 @@ func (o *Orchestrator) ProfileFunctions(
 	ctx context.Context,
 	req *connect.Request[debugpb.ProfileFunctionsRequest],
 ) (*connect.Response[debugpb.ProfileFunctionsResponse], error) {
-	return o.functionProfiler.Profile(ctx, req)
+	resp, err := o.functionProfiler.Profile(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Async profiling returns while collection is still in progress.
+	if status := resp.Msg.Status; status != "in_progress" && status != "failed" {
+		o.publishProfilingCompleted("", resp.Msg.ServiceName, resp.Msg.SessionId, "functions", map[string]string{
+			"status":           status,
+			"functions_probed": fmt.Sprintf("%d", resp.Msg.Summary.GetFunctionsProbed()),
+		})
+	}
+	return resp, nil
+}
+
+// CancelProfileFunctions cancels an async ProfileFunctions run, detaching all
+// of its probes.
+func (o *Orchestrator) CancelProfileFunctions(
+	ctx context.Context,
+	req *connect.Request[debugpb.CancelProfileFunctionsRequest],
+) (*connect.Response[debugpb.CancelProfileFunctionsResponse], error) {
+	if req.Msg.SessionId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("session_id is required"))
+	}
+
+	detached, failed, err := o.functionProfiler.Cancel(ctx, req.Msg.SessionId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	return connect.NewResponse(&debugpb.CancelProfileFunctionsResponse{
+		SessionsDetached: int32(detached), // #nosec G115 -- bounded by maxFunctionsLimit.
+		SessionsFailed:   int32(failed),   // #nosec G115 -- bounded by maxFunctionsLimit.
+	}), nil
+}
+
+// ActiveProfilingRuns returns the number of ProfileFunctions runs with probes
+// attached.
+func (o *Orchestrator) ActiveProfilingRuns() int {
+	return o.functionProfiler.ActiveRuns()
+}
+
+// ActiveSessionCount returns the number of active, unexpired debug sessions.
+func (o *Orchestrator) ActiveSessionCount() (int, error) {
+	sessions, err := o.db.ListDebugSessions(database.DebugSessionFilters{Status: "active"})
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	count := 0
+	for _, s := range sessions {
+		if s.ExpiresAt.After(now) {
+			count++
+		}
+	}
+	return count, nil
 }
 
 // applySelectionStrategy filters functions based on the selection strategy.
@@ -506,6 +663,20 @@ func (o *Orchestrator) ProfileCPU(
 		}), nil
 	}
 
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.ProfileCPUResponse{
+			Success: false,
+			Error:   err.Error(),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, o.db, o.logger, req.Msg.ServiceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.ProfileCPUResponse{
+			Success: false,
+			Error:   err.Error(),
+		}), nil
+	}
+
 	// Get PID for the service.
 	targetPID, err := o.agentCoordinator.GetServicePID(ctx, agentID, req.Msg.ServiceName)
 	if err != nil {
@@ -518,7 +689,7 @@ func (o *Orchestrator) ProfileCPU(
 	// Call agent to perform CPU profiling.
 	debugClient := o.clientFactory(
 		http.DefaultClient,
-		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIPv4)),
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
 	)
 
 	profileReq := connect.NewRequest(&agentv1.ProfileCPUAgentRequest{
@@ -558,6 +729,11 @@ func (o *Orchestrator) ProfileCPU(
 		Int("unique_stacks", len(profileResp.Msg.Samples)).
 		Msg("CPU profiling completed")
 
+	o.publishProfilingCompleted(agentID, req.Msg.ServiceName, "", "cpu", map[string]string{
+		"duration_seconds": fmt.Sprintf("%d", durationSeconds),
+		"total_samples":    fmt.Sprintf("%d", profileResp.Msg.TotalSamples),
+	})
+
 	return connect.NewResponse(&debugpb.ProfileCPUResponse{
 		Samples:      profileResp.Msg.Samples,
 		TotalSamples: profileResp.Msg.TotalSamples,
@@ -566,43 +742,67 @@ func (o *Orchestrator) ProfileCPU(
 	}), nil
 }
 
+// profileSources are the ingested data historical profiles are computed from.
+var profileSources = []querycache.Source{querycache.Profiles}
+
 // QueryHistoricalCPUProfile queries historical CPU profiles from continuous profiling (RFD 072).
+// Results are cached until new profiles are ingested.
 func (o *Orchestrator) QueryHistoricalCPUProfile(
 	ctx context.Context,
 	req *connect.Request[debugpb.QueryHistoricalCPUProfileRequest],
 ) (*connect.Response[debugpb.QueryHistoricalCPUProfileResponse], error) {
+	key, err := querycache.Key("QueryHistoricalCPUProfile", req.Msg, constants.QueryCacheTimeGranularity)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp, err := querycache.Get(ctx, o.db.QueryCache(), key, profileSources,
+		func(ctx context.Context) (*debugpb.QueryHistoricalCPUProfileResponse, error) {
+			return o.queryHistoricalCPUProfile(ctx, req.Msg)
+		})
+	if err != nil {
+		return connect.NewResponse(&debugpb.QueryHistoricalCPUProfileResponse{
+			Success: false,
+			Error:   err.Error(),
+		}), nil
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// queryHistoricalCPUProfile aggregates the CPU profile summaries of a service
+// over the requested time range.
+func (o *Orchestrator) queryHistoricalCPUProfile(
+	ctx context.Context,
+	msg *debugpb.QueryHistoricalCPUProfileRequest,
+) (*debugpb.QueryHistoricalCPUProfileResponse, error) {
 	o.logger.Info().
-		Str("service", req.Msg.ServiceName).
-		Time("start_time", req.Msg.StartTime.AsTime()).
-		Time("end_time", req.Msg.EndTime.AsTime()).
+		Str("service", msg.ServiceName).
+		Time("start_time", msg.StartTime.AsTime()).
+		Time("end_time", msg.EndTime.AsTime()).
 		Msg("Querying historical CPU profiles")
 
 	// Query CPU profile summaries from colony database.
 	summaries, err := o.db.QueryCPUProfileSummaries(
 		ctx,
-		req.Msg.ServiceName,
-		req.Msg.StartTime.AsTime(),
-		req.Msg.EndTime.AsTime(),
+		msg.ServiceName,
+		msg.StartTime.AsTime(),
+		msg.EndTime.AsTime(),
 	)
 	if err != nil {
 		o.logger.Error().Err(err).
-			Str("service", req.Msg.ServiceName).
+			Str("service", msg.ServiceName).
 			Msg("Failed to query CPU profile summaries")
-		return connect.NewResponse(&debugpb.QueryHistoricalCPUProfileResponse{
-			Success: false,
-			Error:   fmt.Sprintf("failed to query historical profiles: %v", err),
-		}), nil
+		return nil, fmt.Errorf("failed to query historical profiles: %w", err)
 	}
 
 	if len(summaries) == 0 {
 		o.logger.Info().
-			Str("service", req.Msg.ServiceName).
+			Str("service", msg.ServiceName).
 			Msg("No historical CPU profile data found")
-		return connect.NewResponse(&debugpb.QueryHistoricalCPUProfileResponse{
+		return &debugpb.QueryHistoricalCPUProfileResponse{
 			Success:      true,
 			Samples:      nil,
 			TotalSamples: 0,
-		}), nil
+		}, nil
 	}
 
 	// Aggregate samples by stack (sum counts across time).
@@ -635,36 +835,40 @@ func (o *Orchestrator) QueryHistoricalCPUProfile(
 	}
 
 	// Decode frame IDs to frame names and build response.
-	var samples []*agentv1.StackSample
-	totalSamples := uint64(0)
-
+	counts := make([]uint64, 0, len(aggregated))
+	stacks := make([][]int64, 0, len(aggregated))
 	for _, agg := range aggregated {
-		totalSamples += agg.sampleCount
+		counts = append(counts, agg.sampleCount)
+		stacks = append(stacks, agg.frameIDs)
+	}
 
-		// Decode stack frames.
-		frameNames, err := o.db.DecodeStackFrames(ctx, agg.frameIDs)
-		if err != nil {
-			o.logger.Warn().Err(err).Msg("Failed to decode stack frames, skipping sample")
-			continue
-		}
+	decoded, err := o.db.DecodeStackFrameBatch(ctx, stacks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode stack frames: %w", err)
+	}
+
+	var samples []*agentv1.StackSample
+	totalSamples := uint64(0)
 
+	for i, frameNames := range decoded {
+		totalSamples += counts[i]
 		samples = append(samples, &agentv1.StackSample{
 			FrameNames: frameNames,
-			Count:      agg.sampleCount,
+			Count:      counts[i],
 		})
 	}
 
 	o.logger.Info().
-		Str("service", req.Msg.ServiceName).
+		Str("service", msg.ServiceName).
 		Uint64("total_samples", totalSamples).
 		Int("unique_stacks", len(samples)).
 		Msg("Historical CPU profile query completed")
 
-	return connect.NewResponse(&debugpb.QueryHistoricalCPUProfileResponse{
+	return &debugpb.QueryHistoricalCPUProfileResponse{
 		Samples:      samples,
 		TotalSamples: totalSamples,
 		Success:      true,
-	}), nil
+	}, nil
 }
 
 // ProfileMemory collects memory profile for a target service/pod (RFD 077).
@@ -709,6 +913,20 @@ func (o *Orchestrator) ProfileMemory(
 		}), nil
 	}
 
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.ProfileMemoryResponse{
+			Success: false,
+			Error:   err.Error(),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, o.db, o.logger, req.Msg.ServiceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.ProfileMemoryResponse{
+			Success: false,
+			Error:   err.Error(),
+		}), nil
+	}
+
 	targetPID, err := o.agentCoordinator.GetServicePID(ctx, agentID, req.Msg.ServiceName)
 	if err != nil {
 		return connect.NewResponse(&debugpb.ProfileMemoryResponse{
@@ -719,7 +937,7 @@ func (o *Orchestrator) ProfileMemory(
 
 	debugClient := o.clientFactory(
 		http.DefaultClient,
-		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIPv4)),
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
 	)
 
 	profileReq := connect.NewRequest(&agentv1.ProfileMemoryAgentRequest{
@@ -750,6 +968,10 @@ func (o *Orchestrator) ProfileMemory(
 		}), nil
 	}
 
+	o.publishProfilingCompleted(agentID, req.Msg.ServiceName, "", "memory", map[string]string{
+		"duration_seconds": fmt.Sprintf("%d", durationSeconds),
+	})
+
 	return connect.NewResponse(&debugpb.ProfileMemoryResponse{
 		Samples:      profileResp.Msg.Samples,
 		Stats:        profileResp.Msg.Stats,
@@ -760,73 +982,130 @@ func (o *Orchestrator) ProfileMemory(
 }
 
 // QueryHistoricalMemoryProfile queries historical memory profiles from continuous profiling (RFD 077).
+// Results are cached until new profiles are ingested.
 func (o *Orchestrator) QueryHistoricalMemoryProfile(
 	ctx context.Context,
 	req *connect.Request[debugpb.QueryHistoricalMemoryProfileRequest],
 ) (*connect.Response[debugpb.QueryHistoricalMemoryProfileResponse], error) {
+	key, err := querycache.Key("QueryHistoricalMemoryProfile", req.Msg, constants.QueryCacheTimeGranularity)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp, err := querycache.Get(ctx, o.db.QueryCache(), key, profileSources,
+		func(ctx context.Context) (*debugpb.QueryHistoricalMemoryProfileResponse, error) {
+			return o.queryHistoricalMemoryProfile(ctx, req.Msg)
+		})
+	if err != nil {
+		return connect.NewResponse(&debugpb.QueryHistoricalMemoryProfileResponse{
+			Success: false,
+			Error:   err.Error(),
+		}), nil
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// queryHistoricalMemoryProfile aggregates the memory profile summaries of a
+// service over the requested time range, with their growth and leak
+// candidates.
+func (o *Orchestrator) queryHistoricalMemoryProfile(
+	ctx context.Context,
+	msg *debugpb.QueryHistoricalMemoryProfileRequest,
+) (*debugpb.QueryHistoricalMemoryProfileResponse, error) {
 	o.logger.Info().
-		Str("service", req.Msg.ServiceName).
+		Str("service", msg.ServiceName).
 		Msg("Querying historical memory profiles")
 
 	summaries, err := o.db.QueryMemoryProfileSummaries(
 		ctx,
-		req.Msg.ServiceName,
-		req.Msg.StartTime.AsTime(),
-		req.Msg.EndTime.AsTime(),
+		msg.ServiceName,
+		msg.StartTime.AsTime(),
+		msg.EndTime.AsTime(),
 	)
 	if err != nil {
-		return connect.NewResponse(&debugpb.QueryHistoricalMemoryProfileResponse{
-			Success: false,
-			Error:   fmt.Sprintf("failed to query historical memory profiles: %v", err),
-		}), nil
+		return nil, fmt.Errorf("failed to query historical memory profiles: %w", err)
+	}
+
+	if msg.BuildId != "" {
+		filtered := summaries[:0]
+		for _, summary := range summaries {
+			if summary.BuildID == msg.BuildId {
+				filtered = append(filtered, summary)
+			}
+		}
+		summaries = filtered
 	}
 
 	if len(summaries) == 0 {
-		return connect.NewResponse(&debugpb.QueryHistoricalMemoryProfileResponse{
+		return &debugpb.QueryHistoricalMemoryProfileResponse{
 			Success:         true,
 			Samples:         nil,
 			TotalAllocBytes: 0,
-		}), nil
+		}, nil
 	}
 
-	// Aggregate by stack hash.
+	// Aggregate by stack hash, keeping each stack's allocations per growth
+	// bucket for leak detection.
 	type stackAgg struct {
 		frameIDs     []int64
 		allocBytes   int64
 		allocObjects int64
+		buckets      []int64
+	}
+
+	startTime := msg.StartTime.AsTime()
+	bucketWidth := msg.EndTime.AsTime().Sub(startTime) / memoryGrowthBuckets
+	growth := make([]*debugpb.MemoryGrowthBucket, memoryGrowthBuckets)
+	for i := range growth {
+		growth[i] = &debugpb.MemoryGrowthBucket{
+			StartTime: timestamppb.New(startTime.Add(time.Duration(i) * bucketWidth)),
+		}
 	}
 
 	aggregated := make(map[string]*stackAgg)
 	for _, summary := range summaries {
-		if existing, exists := aggregated[summary.StackHash]; exists {
-			existing.allocBytes += summary.AllocBytes
-			existing.allocObjects += summary.AllocObjects
-		} else {
-			aggregated[summary.StackHash] = &stackAgg{
-				frameIDs:     summary.StackFrameIDs,
-				allocBytes:   summary.AllocBytes,
-				allocObjects: summary.AllocObjects,
+		existing, exists := aggregated[summary.StackHash]
+		if !exists {
+			existing = &stackAgg{
+				frameIDs: summary.StackFrameIDs,
+				buckets:  make([]int64, memoryGrowthBuckets),
 			}
+			aggregated[summary.StackHash] = existing
 		}
+		existing.allocBytes += summary.AllocBytes
+		existing.allocObjects += summary.AllocObjects
+
+		bucket := growthBucket(summary.Timestamp, startTime, bucketWidth)
+		existing.buckets[bucket] += summary.AllocBytes
+		growth[bucket].AllocBytes += summary.AllocBytes
+		growth[bucket].AllocObjects += summary.AllocObjects
+	}
+
+	aggs := make([]*stackAgg, 0, len(aggregated))
+	stacks := make([][]int64, 0, len(aggregated))
+	for _, agg := range aggregated {
+		aggs = append(aggs, agg)
+		stacks = append(stacks, agg.frameIDs)
+	}
+
+	decoded, err := o.db.DecodeStackFrameBatch(ctx, stacks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode stack frames: %w", err)
 	}
 
 	var samples []*agentv1.MemoryStackSample
 	var totalAllocBytes int64
 
+	var leakCandidates []*debugpb.MemoryLeakCandidate
+
 	// Track function and type aggregations for summaries.
 	funcBytes := make(map[string]int64)
 	funcObjects := make(map[string]int64)
 	typeBytes := make(map[string]int64)
 	typeObjects := make(map[string]int64)
 
-	for _, agg := range aggregated {
+	for i, agg := range aggs {
 		totalAllocBytes += agg.allocBytes
-
-		frameNames, err := o.db.DecodeStackFrames(ctx, agg.frameIDs)
-		if err != nil {
-			o.logger.Warn().Err(err).Msg("Failed to decode stack frames, skipping memory sample")
-			continue
-		}
+		frameNames := decoded[i]
 
 		samples = append(samples, &agentv1.MemoryStackSample{
 			FrameNames:   frameNames,
@@ -834,6 +1113,15 @@ func (o *Orchestrator) QueryHistoricalMemoryProfile(
 			AllocObjects: agg.allocObjects,
 		})
 
+		if growthPct, active, ok := leakGrowth(agg.buckets); ok {
+			leakCandidates = append(leakCandidates, &debugpb.MemoryLeakCandidate{
+				FrameNames:    frameNames,
+				AllocBytes:    agg.allocBytes,
+				GrowthPct:     growthPct,
+				ActiveBuckets: int32(active), // #nosec G115 -- bounded by memoryGrowthBuckets.
+			})
+		}
+
 		// Aggregate by function for top functions summary.
 		for _, fn := range frameNames {
 			funcBytes[fn] += agg.allocBytes
@@ -854,6 +1142,14 @@ func (o *Orchestrator) QueryHistoricalMemoryProfile(
 	// Compute top types (sorted by bytes, limited to top 10).
 	topTypes := computeTopTypes(typeBytes, typeObjects, totalAllocBytes, 10)
 
+	// Rank leak candidates by total allocation, limited to the top 10.
+	sort.Slice(leakCandidates, func(i, j int) bool {
+		return leakCandidates[i].AllocBytes > leakCandidates[j].AllocBytes
+	})
+	if len(leakCandidates) > maxLeakCandidates {
+		leakCandidates = leakCandidates[:maxLeakCandidates]
+	}
+
 	numSamples, clamped := safe.IntToInt32(len(samples))
 	if clamped {
 		o.logger.Warn().
@@ -861,14 +1157,50 @@ func (o *Orchestrator) QueryHistoricalMemoryProfile(
 			Msg("Abnormal samples size, clamped to int32")
 	}
 
-	return connect.NewResponse(&debugpb.QueryHistoricalMemoryProfileResponse{
+	return &debugpb.QueryHistoricalMemoryProfileResponse{
 		Samples:         samples,
 		TotalAllocBytes: totalAllocBytes,
 		TopFunctions:    topFunctions,
 		TopTypes:        topTypes,
 		UniqueStacks:    numSamples,
+		Growth:          growth,
+		LeakCandidates:  leakCandidates,
 		Success:         true,
-	}), nil
+	}, nil
+}
+
+// growthBucket returns the growth bucket a sample taken at ts falls into.
+func growthBucket(ts, start time.Time, width time.Duration) int {
+	if width <= 0 {
+		return 0
+	}
+	i := int(ts.Sub(start) / width)
+	return max(0, min(i, memoryGrowthBuckets-1))
+}
+
+// leakGrowth compares a stack's allocations in the second half of the growth
+// buckets with the first half. A stack is a leak candidate when it allocated in
+// at least half the buckets and its allocation rate grew by leakMinGrowthPct
+// or more: steady, rising allocation from one site is how leaks typically show
+// up in allocation profiles.
+func leakGrowth(buckets []int64) (growthPct float64, active int, ok bool) {
+	half := len(buckets) / 2
+	var first, second int64
+	for i, b := range buckets {
+		if b > 0 {
+			active++
+		}
+		if i < half {
+			first += b
+		} else {
+			second += b
+		}
+	}
+	if first == 0 || active < half {
+		return 0, active, false
+	}
+	growthPct = float64(second-first) / float64(first) * 100
+	return growthPct, active, growthPct >= leakMinGrowthPct
 }
 
 // computeTopFunctions builds a sorted list of top allocating functions.
@@ -1008,9 +1340,23 @@ func (o *Orchestrator) DeployCorrelation(
 		}), nil
 	}
 
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.ColonyDeployCorrelationResponse{
+			Success: false,
+			Error:   err.Error(),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, o.db, o.logger, serviceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.ColonyDeployCorrelationResponse{
+			Success: false,
+			Error:   err.Error(),
+		}), nil
+	}
+
 	client := o.clientFactory(
 		http.DefaultClient,
-		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIPv4)),
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
 	)
 
 	agentResp, err := client.DeployCorrelation(ctx, connect.NewRequest(&agentv1.DeployCorrelationRequest{
@@ -1047,7 +1393,7 @@ func (o *Orchestrator) RemoveCorrelation(
 	for _, entry := range entries {
 		client := o.clientFactory(
 			http.DefaultClient,
-			fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIPv4)),
+			fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
 		)
 		_, err := client.RemoveCorrelation(ctx, connect.NewRequest(&agentv1.RemoveCorrelationRequest{
 			CorrelationId: req.Msg.CorrelationId,
@@ -1081,7 +1427,7 @@ func (o *Orchestrator) ListCorrelations(
 	for _, entry := range entries {
 		client := o.clientFactory(
 			http.DefaultClient,
-			fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIPv4)),
+			fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
 		)
 		resp, err := client.ListCorrelations(ctx, connect.NewRequest(&agentv1.ListCorrelationsRequest{}))
 		if err != nil {
@@ -1143,3 +1489,117 @@ func compileCELForValidation(expr string) (bool, error) {
 	}
 	return true, nil
 }
+
+// ListCoreDumps returns core dumps captured across all agents, newest first,
+// optionally filtered by service.
+func (o *Orchestrator) ListCoreDumps(
+	ctx context.Context,
+	req *connect.Request[debugpb.ColonyListCoreDumpsRequest],
+) (*connect.Response[debugpb.ColonyListCoreDumpsResponse], error) {
+	var all []*agentv1.CoreDumpInfo
+
+	for _, entry := range o.registry.ListAll() {
+		client := o.clientFactory(
+			http.DefaultClient,
+			fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
+		)
+		resp, err := client.ListCoreDumps(ctx, connect.NewRequest(&agentv1.ListCoreDumpsRequest{
+			ServiceName: req.Msg.ServiceName,
+		}))
+		if err != nil {
+			o.logger.Warn().
+				Err(err).
+				Str("agent_id", entry.AgentID).
+				Msg("Failed to list core dumps from agent.")
+			continue
+		}
+		for _, d := range resp.Msg.Dumps {
+			if d.AgentId == "" {
+				d.AgentId = entry.AgentID
+			}
+			all = append(all, d)
+		}
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].CrashedAt.AsTime().After(all[j].CrashedAt.AsTime())
+	})
+
+	return connect.NewResponse(&debugpb.ColonyListCoreDumpsResponse{Dumps: all}), nil
+}
+
+// DownloadCoreDump streams a core dump from the agent holding it.
+func (o *Orchestrator) DownloadCoreDump(
+	ctx context.Context,
+	req *connect.Request[debugpb.ColonyDownloadCoreDumpRequest],
+	stream *connect.ServerStream[agentv1.CoreDumpChunk],
+) error {
+	if req.Msg.AgentId == "" || req.Msg.Id == "" {
+		return connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("agent_id and id are required"))
+	}
+
+	entry, err := o.registry.Get(req.Msg.AgentId)
+	if err != nil {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+
+	client := o.clientFactory(
+		http.DefaultClient,
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
+	)
+	agentStream, err := client.DownloadCoreDump(ctx, connect.NewRequest(&agentv1.DownloadCoreDumpRequest{
+		Id: req.Msg.Id,
+	}))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = agentStream.Close() }()
+
+	for agentStream.Receive() {
+		if err := stream.Send(agentStream.Msg()); err != nil {
+			return err
+		}
+	}
+	return agentStream.Err()
+}
+
+// DescribeFunction returns the signature, argument locations and probeability
+// of a function from the agent running the service.
+func (o *Orchestrator) DescribeFunction(
+	ctx context.Context,
+	req *connect.Request[debugpb.ColonyDescribeFunctionRequest],
+) (*connect.Response[debugpb.ColonyDescribeFunctionResponse], error) {
+	if req.Msg.ServiceName == "" || req.Msg.FunctionName == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("service_name and function_name are required"))
+	}
+
+	agentID, err := o.agentCoordinator.FindAgentForService(ctx, req.Msg.ServiceName)
+	if err != nil {
+		return nil, coralerrors.ToConnect(err)
+	}
+
+	entry, err := o.registry.Get(agentID)
+	if err != nil {
+		return nil, coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND,
+			fmt.Errorf("agent not found: %w", err), "agent_id", agentID))
+	}
+
+	client := o.clientFactory(
+		http.DefaultClient,
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
+	)
+	resp, err := client.DescribeFunction(ctx, connect.NewRequest(&agentv1.DescribeFunctionRequest{
+		ServiceName:  req.Msg.ServiceName,
+		FunctionName: req.Msg.FunctionName,
+	}))
+	if err != nil {
+		return nil, coralerrors.ToConnect(coralerrors.FromAgent(agentID, err))
+	}
+
+	return connect.NewResponse(&debugpb.ColonyDescribeFunctionResponse{
+		AgentId:  agentID,
+		Function: resp.Msg.Function,
+	}), nil
+}
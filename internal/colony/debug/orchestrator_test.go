@@ -3,6 +3,9 @@ package debug
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
@@ -14,8 +17,12 @@ import (
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
 	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/auth"
 	"github.com/coral-mesh/coral/internal/colony"
 	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
 	"github.com/coral-mesh/coral/internal/colony/registry"
 	"github.com/coral-mesh/coral/internal/constants"
 )
@@ -307,6 +314,84 @@ func TestAttachUprobe_AgentNotFound(t *testing.T) {
 	if resp.Msg.Error == "" {
 		t.Error("Expected error message for non-existent agent")
 	}
+
+	if got := resp.Msg.GetErrorInfo().GetCode(); got != errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND {
+		t.Errorf("Expected AGENT_NOT_FOUND error code, got %v", got)
+	}
+}
+
+func TestAttachUprobe_AgentDrained(t *testing.T) {
+	orch, db := setupTestOrchestrator(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := orch.registry.SetDrained(ctx, "test-agent", true); err != nil {
+		t.Fatalf("Failed to drain test agent: %v", err)
+	}
+
+	resp, err := orch.AttachUprobe(ctx, connect.NewRequest(&debugpb.AttachUprobeRequest{
+		AgentId:      "test-agent",
+		ServiceName:  "test-service",
+		FunctionName: "TestFunction",
+		SdkAddr:      "localhost:50051",
+	}))
+	if err != nil {
+		t.Fatalf("AttachUprobe returned error: %v", err)
+	}
+
+	if resp.Msg.Success {
+		t.Error("Expected AttachUprobe to fail for a drained agent")
+	}
+
+	if got := resp.Msg.GetErrorInfo().GetCode(); got != errorsv1.ErrorCode_ERROR_CODE_AGENT_DRAINED {
+		t.Errorf("Expected AGENT_DRAINED error code, got %v", got)
+	}
+}
+
+func TestAttachUprobe_ServiceFrozen(t *testing.T) {
+	orch, db := setupTestOrchestrator(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := db.UpsertServiceFreeze(ctx, &database.ServiceFreeze{
+		Service:   "test-service",
+		ExpiresAt: time.Now().Add(time.Hour),
+		Reason:    "load test",
+		CreatedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("Failed to freeze test service: %v", err)
+	}
+
+	req := &debugpb.AttachUprobeRequest{
+		AgentId:      "test-agent",
+		ServiceName:  "test-service",
+		FunctionName: "TestFunction",
+		SdkAddr:      "localhost:50051",
+	}
+	resp, err := orch.AttachUprobe(ctx, connect.NewRequest(req))
+	if err != nil {
+		t.Fatalf("AttachUprobe returned error: %v", err)
+	}
+	if resp.Msg.Success {
+		t.Error("Expected AttachUprobe to fail for a frozen service")
+	}
+	if got := resp.Msg.GetErrorInfo().GetCode(); got != errorsv1.ErrorCode_ERROR_CODE_SERVICE_FROZEN {
+		t.Errorf("Expected SERVICE_FROZEN error code, got %v", got)
+	}
+
+	// Only admins may override the freeze.
+	req.OverrideFreeze = true
+	tokenCtx := context.WithValue(ctx, httpapi.TokenContextKey, &auth.APIToken{
+		TokenID:     "oncall",
+		Permissions: []auth.Permission{auth.PermissionDebug},
+	})
+	resp, err = orch.AttachUprobe(tokenCtx, connect.NewRequest(req))
+	if err != nil {
+		t.Fatalf("AttachUprobe returned error: %v", err)
+	}
+	if got := resp.Msg.GetErrorInfo().GetCode(); got != errorsv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED {
+		t.Errorf("Expected PERMISSION_DENIED error code, got %v", got)
+	}
 }
 
 func TestAttachUprobe_MissingAgentID(t *testing.T) {
@@ -334,6 +419,10 @@ func TestAttachUprobe_MissingAgentID(t *testing.T) {
 	if resp.Msg.Error == "" {
 		t.Error("Expected error message for service resolution failure")
 	}
+
+	if got := resp.Msg.GetErrorInfo().GetCode(); got != errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND {
+		t.Errorf("Expected SERVICE_NOT_FOUND error code, got %v", got)
+	}
 }
 
 func TestAttachUprobe_DurationCapping(t *testing.T) {
@@ -452,6 +541,7 @@ func TestQueryUprobeEvents_AgentNotInRegistry(t *testing.T) {
 			DurationNs:   2000000,
 			Pid:          1234,
 			Tid:          1234,
+			Redacted:     true,
 		},
 	}
 
@@ -479,6 +569,9 @@ func TestQueryUprobeEvents_AgentNotInRegistry(t *testing.T) {
 	if resp.Msg.Events[0].FunctionName != "TestFunction" {
 		t.Errorf("Expected function name TestFunction, got %s", resp.Msg.Events[0].FunctionName)
 	}
+	if resp.Msg.Events[0].Redacted || !resp.Msg.Events[1].Redacted {
+		t.Errorf("Expected only the second event to be marked redacted")
+	}
 }
 
 func TestQueryUprobeEvents_AgentRPCFailsFallbackToDatabase(t *testing.T) {
@@ -597,6 +690,42 @@ func (m *mockFailingDebugServiceClient) ListCorrelations(ctx context.Context, re
 	return nil, fmt.Errorf("simulated RPC failure")
 }
 
+func (m *mockFailingDebugServiceClient) ListCoreDumps(ctx context.Context, req *connect.Request[agentv1.ListCoreDumpsRequest]) (*connect.Response[agentv1.ListCoreDumpsResponse], error) {
+	return nil, fmt.Errorf("simulated RPC failure")
+}
+
+func (m *mockFailingDebugServiceClient) DownloadCoreDump(ctx context.Context, req *connect.Request[agentv1.DownloadCoreDumpRequest]) (*connect.ServerStreamForClient[agentv1.CoreDumpChunk], error) {
+	return nil, fmt.Errorf("simulated RPC failure")
+}
+
+func (m *mockFailingDebugServiceClient) DescribeFunction(ctx context.Context, req *connect.Request[agentv1.DescribeFunctionRequest]) (*connect.Response[agentv1.DescribeFunctionResponse], error) {
+	return nil, fmt.Errorf("simulated RPC failure")
+}
+
+func (m *mockFailingDebugServiceClient) StartHttpCapture(ctx context.Context, req *connect.Request[agentv1.StartHttpCaptureRequest]) (*connect.Response[agentv1.StartHttpCaptureResponse], error) {
+	return nil, fmt.Errorf("simulated RPC failure")
+}
+
+func (m *mockFailingDebugServiceClient) StartTlsCapture(ctx context.Context, req *connect.Request[agentv1.StartTlsCaptureRequest]) (*connect.Response[agentv1.StartTlsCaptureResponse], error) {
+	return nil, fmt.Errorf("simulated RPC failure")
+}
+
+func (m *mockFailingDebugServiceClient) TraceRuntime(ctx context.Context, req *connect.Request[agentv1.TraceRuntimeAgentRequest]) (*connect.Response[agentv1.TraceRuntimeAgentResponse], error) {
+	return nil, fmt.Errorf("simulated RPC failure")
+}
+
+func (m *mockFailingDebugServiceClient) TraceSyscalls(ctx context.Context, req *connect.Request[agentv1.TraceSyscallsAgentRequest]) (*connect.Response[agentv1.TraceSyscallsAgentResponse], error) {
+	return nil, fmt.Errorf("simulated RPC failure")
+}
+
+func (m *mockFailingDebugServiceClient) ProfileIO(ctx context.Context, req *connect.Request[agentv1.ProfileIOAgentRequest]) (*connect.Response[agentv1.ProfileIOAgentResponse], error) {
+	return nil, fmt.Errorf("simulated RPC failure")
+}
+
+func (m *mockFailingDebugServiceClient) ProfileLocks(ctx context.Context, req *connect.Request[agentv1.ProfileLocksAgentRequest]) (*connect.Response[agentv1.ProfileLocksAgentResponse], error) {
+	return nil, fmt.Errorf("simulated RPC failure")
+}
+
 func TestConcurrentSessionOperations(t *testing.T) {
 	orch, db := setupTestOrchestrator(t)
 	defer db.Close()
@@ -829,6 +958,117 @@ func TestProfileFunctions_TracksRealSessionIDs(t *testing.T) {
 		resp.Msg.Status)
 }
 
+// setupProfilingOrchestrator creates a test orchestrator with two profileable
+// functions in test-service.
+func setupProfilingOrchestrator(t *testing.T) (*Orchestrator, *database.Database) {
+	orch, db := setupTestOrchestrator(t)
+
+	functionRegistry := colony.NewFunctionRegistry(db, zerolog.Nop())
+	testFunctions := []*agentv1.FunctionInfo{
+		{Name: "slowFunction", Package: "main", HasDwarf: true},
+		{Name: "fastFunction", Package: "main", HasDwarf: true},
+	}
+	if err := functionRegistry.StoreFunctions(context.Background(), "test-agent", "test-service", "test-hash", testFunctions); err != nil {
+		t.Fatalf("Failed to store test functions: %v", err)
+	}
+	orch.functionRegistry = functionRegistry
+
+	mockClientFactory := &mockDebugServiceClientFactory{
+		sessions: make(map[string]*mockSession),
+		db:       db,
+	}
+	orch.clientFactory = mockClientFactory.newClient
+
+	return orch, db
+}
+
+// assertSessionsStopped checks that all sessions created by a profiling run
+// were detached.
+func assertSessionsStopped(t *testing.T, db *database.Database) {
+	t.Helper()
+
+	sessions, err := db.ListDebugSessions(database.DebugSessionFilters{})
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) == 0 {
+		t.Fatal("Expected profiling sessions to be created")
+	}
+	for _, session := range sessions {
+		if session.Status != "stopped" {
+			t.Errorf("Expected session %s to be stopped, got %s", session.SessionID, session.Status)
+		}
+	}
+}
+
+func TestProfileFunctions_ContextCancelled(t *testing.T) {
+	orch, db := setupProfilingOrchestrator(t)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := orch.ProfileFunctions(ctx, connect.NewRequest(&debugpb.ProfileFunctionsRequest{
+		ServiceName: "test-service",
+		Query:       "function",
+		Strategy:    "all",
+		Duration:    durationpb.New(time.Minute),
+	}))
+	if connect.CodeOf(err) != connect.CodeCanceled {
+		t.Fatalf("Expected canceled error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected profiling to stop on cancellation, took %s", elapsed)
+	}
+
+	assertSessionsStopped(t, db)
+}
+
+func TestCancelProfileFunctions(t *testing.T) {
+	orch, db := setupProfilingOrchestrator(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	resp, err := orch.ProfileFunctions(ctx, connect.NewRequest(&debugpb.ProfileFunctionsRequest{
+		ServiceName: "test-service",
+		Query:       "function",
+		Strategy:    "all",
+		Duration:    durationpb.New(time.Minute),
+		Async:       true,
+	}))
+	if err != nil {
+		t.Fatalf("ProfileFunctions failed: %v", err)
+	}
+	if resp.Msg.Status != "in_progress" {
+		t.Fatalf("Expected status in_progress, got %s", resp.Msg.Status)
+	}
+
+	cancelResp, err := orch.CancelProfileFunctions(ctx, connect.NewRequest(&debugpb.CancelProfileFunctionsRequest{
+		SessionId: resp.Msg.SessionId,
+	}))
+	if err != nil {
+		t.Fatalf("CancelProfileFunctions failed: %v", err)
+	}
+	if cancelResp.Msg.SessionsDetached != resp.Msg.Summary.FunctionsProbed {
+		t.Errorf("Expected %d sessions detached, got %d", resp.Msg.Summary.FunctionsProbed, cancelResp.Msg.SessionsDetached)
+	}
+	if cancelResp.Msg.SessionsFailed != 0 {
+		t.Errorf("Expected no failed detaches, got %d", cancelResp.Msg.SessionsFailed)
+	}
+
+	assertSessionsStopped(t, db)
+
+	// The run is no longer in flight.
+	_, err = orch.CancelProfileFunctions(ctx, connect.NewRequest(&debugpb.CancelProfileFunctionsRequest{
+		SessionId: resp.Msg.SessionId,
+	}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
 // Mock debug service client factory
 type mockDebugServiceClientFactory struct {
 	sessions       map[string]*mockSession
@@ -940,6 +1180,42 @@ func (m *mockDebugServiceClient) ListCorrelations(ctx context.Context, req *conn
 	return connect.NewResponse(&agentv1.ListCorrelationsResponse{}), nil
 }
 
+func (m *mockDebugServiceClient) ListCoreDumps(ctx context.Context, req *connect.Request[agentv1.ListCoreDumpsRequest]) (*connect.Response[agentv1.ListCoreDumpsResponse], error) {
+	return connect.NewResponse(&agentv1.ListCoreDumpsResponse{}), nil
+}
+
+func (m *mockDebugServiceClient) DownloadCoreDump(ctx context.Context, req *connect.Request[agentv1.DownloadCoreDumpRequest]) (*connect.ServerStreamForClient[agentv1.CoreDumpChunk], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugServiceClient) DescribeFunction(ctx context.Context, req *connect.Request[agentv1.DescribeFunctionRequest]) (*connect.Response[agentv1.DescribeFunctionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugServiceClient) StartHttpCapture(ctx context.Context, req *connect.Request[agentv1.StartHttpCaptureRequest]) (*connect.Response[agentv1.StartHttpCaptureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugServiceClient) StartTlsCapture(ctx context.Context, req *connect.Request[agentv1.StartTlsCaptureRequest]) (*connect.Response[agentv1.StartTlsCaptureResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugServiceClient) TraceRuntime(ctx context.Context, req *connect.Request[agentv1.TraceRuntimeAgentRequest]) (*connect.Response[agentv1.TraceRuntimeAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugServiceClient) TraceSyscalls(ctx context.Context, req *connect.Request[agentv1.TraceSyscallsAgentRequest]) (*connect.Response[agentv1.TraceSyscallsAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugServiceClient) ProfileIO(ctx context.Context, req *connect.Request[agentv1.ProfileIOAgentRequest]) (*connect.Response[agentv1.ProfileIOAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (m *mockDebugServiceClient) ProfileLocks(ctx context.Context, req *connect.Request[agentv1.ProfileLocksAgentRequest]) (*connect.Response[agentv1.ProfileLocksAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
 // Generate mock events with specified latency
 func generateMockEvents(count int, latency time.Duration) []*agentv1.UprobeEvent {
 	events := make([]*agentv1.UprobeEvent, count)
@@ -1023,3 +1299,157 @@ func TestUpdateProbeFilter(t *testing.T) {
 		t.Error("Expected UpdateProbeFilter to be called on the agent client")
 	}
 }
+
+func TestIngestUprobeEvents(t *testing.T) {
+	orch, db := setupTestOrchestrator(t)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	if err := db.InsertDebugSession(ctx, &database.DebugSession{
+		SessionID:    "session-1",
+		CollectorID:  "collector-1",
+		ServiceName:  "test-service",
+		FunctionName: "main.handler",
+		AgentID:      "test-agent",
+		StartedAt:    time.Now(),
+		ExpiresAt:    time.Now().Add(time.Minute),
+		Status:       "active",
+	}); err != nil {
+		t.Fatalf("Failed to insert test session: %v", err)
+	}
+
+	// A session still being attached is accepted before it is stored.
+	orch.sessionManager.starting["session-2"] = "test-agent"
+
+	_, h := colonyv1connect.NewColonyDebugServiceHandler(orch)
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	client := colonyv1connect.NewColonyDebugServiceClient(http.DefaultClient, srv.URL)
+
+	stream := client.IngestUprobeEvents(ctx)
+	batches := []*debugpb.IngestUprobeEventsRequest{
+		{AgentId: "test-agent", SessionId: "session-1", Events: generateMockEvents(3, time.Millisecond)},
+		{AgentId: "test-agent", SessionId: "session-2", Events: generateMockEvents(2, time.Millisecond)},
+		{AgentId: "other-agent", SessionId: "session-1", Events: generateMockEvents(1, time.Millisecond)},
+		{AgentId: "test-agent", SessionId: "unknown", Events: generateMockEvents(1, time.Millisecond)},
+	}
+	for _, batch := range batches {
+		if err := stream.Send(batch); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+	resp, err := stream.CloseAndReceive()
+	if err != nil {
+		t.Fatalf("IngestUprobeEvents failed: %v", err)
+	}
+
+	if resp.Msg.EventsAccepted != 5 {
+		t.Errorf("Expected 5 events accepted, got %d", resp.Msg.EventsAccepted)
+	}
+	if !slices.Contains(resp.Msg.UnknownSessionIds, "unknown") {
+		t.Errorf("Expected unknown session to be reported, got %v", resp.Msg.UnknownSessionIds)
+	}
+
+	events, err := db.GetDebugEvents("session-1")
+	if err != nil {
+		t.Fatalf("Failed to get events: %v", err)
+	}
+	if len(events) != 3 {
+		t.Errorf("Expected 3 events for session-1 (other agent rejected), got %d", len(events))
+	}
+	for _, event := range events {
+		if event.AgentId != "test-agent" {
+			t.Errorf("Expected agent_id to be filled in, got %q", event.AgentId)
+		}
+	}
+
+	events, err = db.GetDebugEvents("session-2")
+	if err != nil {
+		t.Fatalf("Failed to get events: %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("Expected 2 events for session-2, got %d", len(events))
+	}
+}
+
+func TestQueryHistoricalMemoryProfile_GrowthAndLeaks(t *testing.T) {
+	orch, db := setupTestOrchestrator(t)
+	ctx := context.Background()
+
+	leakyIDs, err := db.EncodeStackFrames(ctx, []string{"runtime.mallocgc", "main.(*cache).put"})
+	if err != nil {
+		t.Fatalf("EncodeStackFrames failed: %v", err)
+	}
+	steadyIDs, err := db.EncodeStackFrames(ctx, []string{"runtime.makeslice", "main.handle"})
+	if err != nil {
+		t.Fatalf("EncodeStackFrames failed: %v", err)
+	}
+
+	// One sample per minute over an hour: the cache allocates more every
+	// minute while the handler allocates at a constant rate.
+	end := time.Now().Truncate(time.Minute)
+	start := end.Add(-time.Hour)
+	var summaries []database.MemoryProfileSummary
+	for i := 0; i < 60; i++ {
+		ts := start.Add(time.Duration(i)*time.Minute + time.Second)
+		summaries = append(summaries,
+			database.MemoryProfileSummary{
+				Timestamp: ts, AgentID: "test-agent", ServiceName: "api", BuildID: "b1",
+				StackFrameIDs: leakyIDs, AllocBytes: int64(1000 * (i + 1)), AllocObjects: 1,
+			},
+			database.MemoryProfileSummary{
+				Timestamp: ts, AgentID: "test-agent", ServiceName: "api", BuildID: "b1",
+				StackFrameIDs: steadyIDs, AllocBytes: 50000, AllocObjects: 1,
+			},
+		)
+	}
+	if err := db.InsertMemoryProfileSummaries(ctx, summaries); err != nil {
+		t.Fatalf("InsertMemoryProfileSummaries failed: %v", err)
+	}
+
+	resp, err := orch.QueryHistoricalMemoryProfile(ctx, connect.NewRequest(&debugpb.QueryHistoricalMemoryProfileRequest{
+		ServiceName: "api",
+		StartTime:   timestamppb.New(start),
+		EndTime:     timestamppb.New(end),
+	}))
+	if err != nil {
+		t.Fatalf("QueryHistoricalMemoryProfile failed: %v", err)
+	}
+	if !resp.Msg.Success {
+		t.Fatalf("Expected success, got error: %s", resp.Msg.Error)
+	}
+
+	if len(resp.Msg.Growth) != memoryGrowthBuckets {
+		t.Fatalf("Expected %d growth buckets, got %d", memoryGrowthBuckets, len(resp.Msg.Growth))
+	}
+	first, last := resp.Msg.Growth[0], resp.Msg.Growth[memoryGrowthBuckets-1]
+	if last.AllocBytes <= first.AllocBytes {
+		t.Errorf("Expected allocations to grow, first bucket %d, last bucket %d", first.AllocBytes, last.AllocBytes)
+	}
+
+	if len(resp.Msg.LeakCandidates) != 1 {
+		t.Fatalf("Expected 1 leak candidate, got %d", len(resp.Msg.LeakCandidates))
+	}
+	leak := resp.Msg.LeakCandidates[0]
+	if !slices.Equal(leak.FrameNames, []string{"runtime.mallocgc", "main.(*cache).put"}) {
+		t.Errorf("Unexpected leak candidate stack: %v", leak.FrameNames)
+	}
+	if leak.GrowthPct < leakMinGrowthPct || leak.ActiveBuckets != memoryGrowthBuckets {
+		t.Errorf("Unexpected leak candidate growth %.1f%% over %d buckets", leak.GrowthPct, leak.ActiveBuckets)
+	}
+
+	// Filtering on an unknown build returns no data.
+	resp, err = orch.QueryHistoricalMemoryProfile(ctx, connect.NewRequest(&debugpb.QueryHistoricalMemoryProfileRequest{
+		ServiceName: "api",
+		StartTime:   timestamppb.New(start),
+		EndTime:     timestamppb.New(end),
+		BuildId:     "b2",
+	}))
+	if err != nil {
+		t.Fatalf("QueryHistoricalMemoryProfile failed: %v", err)
+	}
+	if len(resp.Msg.Samples) != 0 || len(resp.Msg.Growth) != 0 {
+		t.Errorf("Expected no data for unknown build, got %d samples", len(resp.Msg.Samples))
+	}
+}
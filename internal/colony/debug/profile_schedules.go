@@ -0,0 +1,215 @@
+package debug
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// CreateProfileSchedule defines a recurring profiling job. The first run
+// starts on the scheduler's next check.
+func (o *Orchestrator) CreateProfileSchedule(
+	ctx context.Context,
+	req *connect.Request[debugpb.CreateProfileScheduleRequest],
+) (*connect.Response[debugpb.CreateProfileScheduleResponse], error) {
+	schedule, err := newProfileSchedule(req.Msg, time.Now())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := o.db.InsertProfileSchedule(ctx, schedule); err != nil {
+		o.logger.Error().Err(err).
+			Str("service", schedule.ServiceName).
+			Msg("Failed to create profile schedule")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	o.logger.Info().
+		Str("schedule_id", schedule.ID).
+		Str("service", schedule.ServiceName).
+		Str("type", schedule.ProfileType).
+		Dur("interval", schedule.Interval()).
+		Msg("Profile schedule created")
+
+	return connect.NewResponse(&debugpb.CreateProfileScheduleResponse{
+		Schedule: profileScheduleToProto(schedule),
+	}), nil
+}
+
+// ListProfileSchedules returns all recurring profiling jobs.
+func (o *Orchestrator) ListProfileSchedules(
+	ctx context.Context,
+	req *connect.Request[debugpb.ListProfileSchedulesRequest],
+) (*connect.Response[debugpb.ListProfileSchedulesResponse], error) {
+	schedules, err := o.db.ListProfileSchedules(ctx, req.Msg.ServiceName)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	resp := &debugpb.ListProfileSchedulesResponse{}
+	for _, schedule := range schedules {
+		resp.Schedules = append(resp.Schedules, profileScheduleToProto(schedule))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteProfileSchedule removes a recurring profiling job and its results.
+func (o *Orchestrator) DeleteProfileSchedule(
+	ctx context.Context,
+	req *connect.Request[debugpb.DeleteProfileScheduleRequest],
+) (*connect.Response[debugpb.DeleteProfileScheduleResponse], error) {
+	if err := o.db.DeleteProfileSchedule(ctx, req.Msg.Id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("profile schedule not found: %s", req.Msg.Id))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	o.logger.Info().Str("schedule_id", req.Msg.Id).Msg("Profile schedule deleted")
+	return connect.NewResponse(&debugpb.DeleteProfileScheduleResponse{}), nil
+}
+
+// ListProfileRuns returns retained results of scheduled profiling jobs.
+func (o *Orchestrator) ListProfileRuns(
+	ctx context.Context,
+	req *connect.Request[debugpb.ListProfileRunsRequest],
+) (*connect.Response[debugpb.ListProfileRunsResponse], error) {
+	limit := int(req.Msg.Limit)
+	if limit <= 0 {
+		limit = constants.DefaultProfileRunsLimit
+	}
+
+	runs, err := o.db.ListProfileRuns(ctx, database.ProfileRunFilters{
+		ScheduleID:  req.Msg.ScheduleId,
+		ServiceName: req.Msg.ServiceName,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	resp := &debugpb.ListProfileRunsResponse{}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, profileRunToProto(run))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetProfileRun returns a retained profiling result with its folded stacks.
+func (o *Orchestrator) GetProfileRun(
+	ctx context.Context,
+	req *connect.Request[debugpb.GetProfileRunRequest],
+) (*connect.Response[debugpb.GetProfileRunResponse], error) {
+	run, err := o.db.GetProfileRun(ctx, req.Msg.Id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("profile run not found: %s", req.Msg.Id))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	return connect.NewResponse(&debugpb.GetProfileRunResponse{
+		Run:    profileRunToProto(run),
+		Folded: run.Folded,
+	}), nil
+}
+
+// newProfileSchedule validates a create request and applies the same defaults
+// and limits as on-demand profiling.
+func newProfileSchedule(req *debugpb.CreateProfileScheduleRequest, now time.Time) (*database.ProfileSchedule, error) {
+	if req.ServiceName == "" {
+		return nil, errors.New("service name is required")
+	}
+
+	profileType := req.ProfileType
+	if profileType == "" {
+		profileType = profileTypeCPU
+	}
+	if profileType != profileTypeCPU && profileType != profileTypeMemory {
+		return nil, fmt.Errorf("invalid profile type %q: must be cpu or memory", profileType)
+	}
+
+	durationSeconds := req.DurationSeconds
+	if durationSeconds <= 0 {
+		durationSeconds = 30
+	}
+	if durationSeconds > 300 {
+		return nil, errors.New("duration cannot exceed 300 seconds")
+	}
+
+	var frequencyHz int32
+	if profileType == profileTypeCPU {
+		frequencyHz = req.FrequencyHz
+		if frequencyHz <= 0 {
+			frequencyHz = 99
+		}
+		if frequencyHz > 1000 {
+			return nil, errors.New("frequency cannot exceed 1000Hz")
+		}
+	}
+
+	interval := req.Interval.AsDuration()
+	if interval < constants.MinProfileScheduleInterval {
+		return nil, fmt.Errorf("interval must be at least %s", constants.MinProfileScheduleInterval)
+	}
+	if interval <= time.Duration(durationSeconds)*time.Second {
+		return nil, errors.New("interval must be longer than the profiling duration")
+	}
+
+	return &database.ProfileSchedule{
+		ID:              uuid.New().String(),
+		ServiceName:     req.ServiceName,
+		PodName:         req.PodName,
+		ProfileType:     profileType,
+		DurationSeconds: durationSeconds,
+		IntervalSeconds: int64(interval / time.Second),
+		FrequencyHz:     frequencyHz,
+		CreatedAt:       now,
+		NextRunAt:       now,
+	}, nil
+}
+
+func profileScheduleToProto(schedule *database.ProfileSchedule) *debugpb.ProfileSchedule {
+	pb := &debugpb.ProfileSchedule{
+		Id:              schedule.ID,
+		ServiceName:     schedule.ServiceName,
+		PodName:         schedule.PodName,
+		ProfileType:     schedule.ProfileType,
+		DurationSeconds: schedule.DurationSeconds,
+		Interval:        durationpb.New(schedule.Interval()),
+		FrequencyHz:     schedule.FrequencyHz,
+		CreatedAt:       timestamppb.New(schedule.CreatedAt),
+		NextRunAt:       timestamppb.New(schedule.NextRunAt),
+		LastStatus:      schedule.LastStatus,
+	}
+	if schedule.LastRunAt != nil {
+		pb.LastRunAt = timestamppb.New(*schedule.LastRunAt)
+	}
+	return pb
+}
+
+func profileRunToProto(run *database.ProfileRun) *debugpb.ProfileRun {
+	return &debugpb.ProfileRun{
+		Id:           run.ID,
+		ScheduleId:   run.ScheduleID,
+		ServiceName:  run.ServiceName,
+		ProfileType:  run.ProfileType,
+		StartedAt:    timestamppb.New(run.StartedAt),
+		FinishedAt:   timestamppb.New(run.FinishedAt),
+		Status:       run.Status,
+		Error:        run.Error,
+		Total:        uint64(run.Total), // #nosec G115
+		UniqueStacks: run.UniqueStacks,
+	}
+}
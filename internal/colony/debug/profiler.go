@@ -5,12 +5,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"connectrpc.com/connect"
 	"github.com/rs/zerolog"
 	"google.golang.org/protobuf/types/known/durationpb"
-	"google.golang.org/protobuf/types/known/timestamppb"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
@@ -23,7 +24,6 @@ import (
 type probeAttacher interface {
 	AttachUprobe(ctx context.Context, req *connect.Request[debugpb.AttachUprobeRequest]) (*connect.Response[debugpb.AttachUprobeResponse], error)
 	DetachUprobe(ctx context.Context, req *connect.Request[debugpb.DetachUprobeRequest]) (*connect.Response[debugpb.DetachUprobeResponse], error)
-	QueryUprobeEvents(ctx context.Context, req *connect.Request[debugpb.QueryUprobeEventsRequest]) (*connect.Response[debugpb.QueryUprobeEventsResponse], error)
 }
 
 // functionRegistryGetter provides access to the function registry.
@@ -37,6 +37,14 @@ type FunctionProfiler struct {
 	registryGetter functionRegistryGetter
 	probeAttacher  probeAttacher
 	db             *database.Database
+
+	// runs maps the primary session ID of in-flight async runs to all of
+	// their session IDs, so that they can be cancelled.
+	runsMu sync.Mutex
+	runs   map[string][]string
+
+	// syncRuns counts in-flight synchronous runs.
+	syncRuns atomic.Int64
 }
 
 // NewFunctionProfiler creates a new function profiler.
@@ -51,6 +59,7 @@ func NewFunctionProfiler(
 		registryGetter: registryGetter,
 		probeAttacher:  probeAttacher,
 		db:             db,
+		runs:           make(map[string][]string),
 	}
 }
 
@@ -85,16 +94,30 @@ func (fp *FunctionProfiler) Profile(
 
 	// Attach probes to discovered functions.
 	state := fp.attachAllProbes(ctx, functions, cfg)
+	if err := ctx.Err(); err != nil {
+		fp.detachAllSessions(ctx, state.SessionIDs)
+		return nil, connect.NewError(connect.CodeCanceled, err)
+	}
 
 	// For async mode, return immediately.
 	if cfg.Async {
+		fp.trackRun(state.SessionIDs, cfg.Duration+sessionBuffer)
 		return connect.NewResponse(fp.buildAsyncResponse(cfg, state)), nil
 	}
 
-	// Synchronous mode: collect events, compute stats, cleanup.
-	totalEvents := fp.collectEventsSync(ctx, state, cfg.Duration)
-	bottlenecks := fp.computeBottlenecks(ctx, state, cfg.Duration)
+	fp.syncRuns.Add(1)
+	defer fp.syncRuns.Add(-1)
+
+	// Synchronous mode: agents push events while we wait. Detaching makes
+	// them push their remaining events, after which stats are computed. If
+	// the caller is gone, probes are detached right away instead of staying
+	// attached until they expire.
+	fp.waitForCollection(ctx, state, cfg.Duration)
 	fp.detachAllSessions(ctx, state.SessionIDs)
+	if err := ctx.Err(); err != nil {
+		return nil, connect.NewError(connect.CodeCanceled, err)
+	}
+	bottlenecks, totalEvents := fp.computeBottlenecks(ctx, state, cfg.Duration)
 
 	return connect.NewResponse(fp.buildSyncResponse(cfg, state, bottlenecks, totalEvents)), nil
 }
@@ -137,6 +160,10 @@ func (fp *FunctionProfiler) attachAllProbes(ctx context.Context, functions []*co
 	sessionDuration := durationpb.New(cfg.Duration + sessionBuffer)
 
 	for _, fn := range functions {
+		if ctx.Err() != nil {
+			break
+		}
+
 		result, sessionID := fp.attachProbe(ctx, fn, cfg, sessionDuration)
 		state.Results = append(state.Results, result)
 
@@ -194,99 +221,31 @@ func (fp *FunctionProfiler) attachProbe(
 	}, attachResp.Msg.SessionId
 }
 
-// collectEventsSync continuously queries and persists events during the profiling window.
-func (fp *FunctionProfiler) collectEventsSync(ctx context.Context, state *profileState, duration time.Duration) int64 {
+// waitForCollection waits for the profiling window while agents push events.
+func (fp *FunctionProfiler) waitForCollection(ctx context.Context, state *profileState, duration time.Duration) {
 	fp.logger.Info().
 		Dur("duration", duration).
 		Int("session_count", len(state.SessionIDs)).
-		Msg("Starting profiling with continuous event persistence")
-
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
-
-	deadline := time.Now().Add(duration)
-	var totalEvents int64
-	lastPersistedTime := make(map[string]time.Time)
-
-	for time.Now().Before(deadline) {
-		select {
-		case <-ticker.C:
-			events, updated := fp.persistEventsForSessions(ctx, state.SessionIDs, lastPersistedTime)
-			totalEvents += events
-			lastPersistedTime = updated
-		case <-ctx.Done():
-			fp.logger.Info().
-				Int64("total_events", totalEvents).
-				Msg("Profiling collection interrupted by context")
-			return totalEvents
-		}
-	}
-
-	fp.logger.Info().
-		Int64("total_events", totalEvents).
-		Msg("Profiling collection completed")
-
-	return totalEvents
-}
-
-// persistEventsForSessions queries and persists events from all sessions.
-func (fp *FunctionProfiler) persistEventsForSessions(
-	ctx context.Context,
-	sessionIDs []string,
-	lastPersistedTime map[string]time.Time,
-) (int64, map[string]time.Time) {
-	var totalEvents int64
-	updated := make(map[string]time.Time)
-	for k, v := range lastPersistedTime {
-		updated[k] = v
-	}
-
-	for _, sessionID := range sessionIDs {
-		queryReq := connect.NewRequest(&debugpb.QueryUprobeEventsRequest{
-			SessionId: sessionID,
-			StartTime: timestamppb.New(lastPersistedTime[sessionID]),
-			MaxEvents: 10000,
-		})
-
-		queryResp, err := fp.probeAttacher.QueryUprobeEvents(ctx, queryReq)
-		if err != nil {
-			fp.logger.Debug().
-				Err(err).
-				Str("session_id", sessionID).
-				Msg("Failed to query events during profiling")
-			continue
-		}
-
-		if len(queryResp.Msg.Events) == 0 {
-			continue
-		}
+		Msg("Starting profiling")
 
-		if err := fp.db.InsertDebugEvents(ctx, sessionID, queryResp.Msg.Events); err != nil {
-			fp.logger.Error().
-				Err(err).
-				Str("session_id", sessionID).
-				Int("event_count", len(queryResp.Msg.Events)).
-				Msg("Failed to persist events during profiling")
-			continue
-		}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
 
-		totalEvents += int64(len(queryResp.Msg.Events))
-		lastEvent := queryResp.Msg.Events[len(queryResp.Msg.Events)-1]
-		updated[sessionID] = lastEvent.Timestamp.AsTime()
-
-		fp.logger.Debug().
-			Str("session_id", sessionID).
-			Int("event_count", len(queryResp.Msg.Events)).
-			Int64("total_events", totalEvents).
-			Msg("Persisted events during profiling")
+	select {
+	case <-timer.C:
+		fp.logger.Info().Msg("Profiling collection completed")
+	case <-ctx.Done():
+		fp.logger.Info().Msg("Profiling collection interrupted by context")
 	}
-
-	return totalEvents, updated
 }
 
-// computeBottlenecks calculates statistics and identifies bottlenecks from collected events.
-func (fp *FunctionProfiler) computeBottlenecks(ctx context.Context, state *profileState, duration time.Duration) []*debugpb.Bottleneck {
-	var bottlenecks []*debugpb.Bottleneck
+// computeBottlenecks calculates statistics and identifies bottlenecks from
+// collected events. It also returns the total number of events.
+func (fp *FunctionProfiler) computeBottlenecks(ctx context.Context, state *profileState, duration time.Duration) ([]*debugpb.Bottleneck, int64) {
+	var (
+		bottlenecks []*debugpb.Bottleneck
+		totalEvents int64
+	)
 
 	for i, result := range state.Results {
 		if !result.ProbeSuccessful || i >= len(state.SessionIDs) {
@@ -294,28 +253,33 @@ func (fp *FunctionProfiler) computeBottlenecks(ctx context.Context, state *profi
 		}
 
 		sessionID := state.SessionIDs[i]
-		events, err := fp.db.GetDebugEvents(sessionID)
+		eventStats, err := fp.db.QueryDebugEventStats(ctx, sessionID)
 		if err != nil {
 			fp.logger.Warn().
 				Err(err).
 				Str("session_id", sessionID).
-				Msg("Failed to query events from database")
+				Msg("Failed to query event statistics from database")
 			continue
 		}
 
-		if len(events) == 0 {
+		if eventStats.Events == 0 {
 			continue
 		}
+		totalEvents += eventStats.Events
 
 		// Calculate statistics for this function.
-		stats := AggregateStatistics(events)
+		stats := debugStatistics(eventStats.Calls, eventStats.P50, eventStats.P95, eventStats.P99, eventStats.Max)
+		var errorRate float64
+		if eventStats.Calls > 0 {
+			errorRate = float64(eventStats.Errors) / float64(eventStats.Calls)
+		}
 		result.Metrics = &debugpb.FunctionMetrics{
 			Source:      "probe_history",
 			P50:         stats.DurationP50,
 			P95:         stats.DurationP95,
 			P99:         stats.DurationP99,
 			CallsPerMin: float64(stats.TotalCalls) / duration.Minutes(),
-			ErrorRate:   0.0,
+			ErrorRate:   errorRate,
 			SampleSize:  stats.TotalCalls,
 		}
 
@@ -328,21 +292,75 @@ func (fp *FunctionProfiler) computeBottlenecks(ctx context.Context, state *profi
 				ContributionPct: 100,
 				Severity:        severityFromDuration(p95Duration),
 				Impact:          fmt.Sprintf("P95 latency: %s", p95Duration.String()),
-				Recommendation:  fmt.Sprintf("High latency detected. Captured %d events with P95=%s", len(events), p95Duration.String()),
+				Recommendation:  fmt.Sprintf("High latency detected. Captured %d events with P95=%s", eventStats.Events, p95Duration.String()),
 			})
 		}
 
 		fp.logger.Debug().
 			Str("session_id", sessionID).
-			Int("event_count", len(events)).
+			Int64("event_count", eventStats.Events).
 			Msg("Collected events from session")
 	}
 
-	return bottlenecks
+	return bottlenecks, totalEvents
 }
 
-// detachAllSessions detaches all probes and cleans up sessions.
-func (fp *FunctionProfiler) detachAllSessions(ctx context.Context, sessionIDs []string) {
+// Cancel detaches all probes of the async run identified by its primary
+// session ID. It returns the number of sessions detached and failed.
+func (fp *FunctionProfiler) Cancel(ctx context.Context, sessionID string) (int, int, error) {
+	fp.runsMu.Lock()
+	sessionIDs, ok := fp.runs[sessionID]
+	delete(fp.runs, sessionID)
+	fp.runsMu.Unlock()
+
+	if !ok {
+		return 0, 0, fmt.Errorf("no in-flight profiling run with session ID %s", sessionID)
+	}
+
+	fp.logger.Info().
+		Str("session_id", sessionID).
+		Int("session_count", len(sessionIDs)).
+		Msg("Cancelling profiling run")
+
+	detached, failed := fp.detachAllSessions(ctx, sessionIDs)
+	return detached, failed, nil
+}
+
+// ActiveRuns returns the number of profiling runs with probes attached.
+func (fp *FunctionProfiler) ActiveRuns() int {
+	fp.runsMu.Lock()
+	async := len(fp.runs)
+	fp.runsMu.Unlock()
+	return async + int(fp.syncRuns.Load())
+}
+
+// trackRun records the sessions of an async run until they expire.
+func (fp *FunctionProfiler) trackRun(sessionIDs []string, ttl time.Duration) {
+	if len(sessionIDs) == 0 {
+		return
+	}
+
+	primarySessionID := sessionIDs[0]
+
+	fp.runsMu.Lock()
+	fp.runs[primarySessionID] = sessionIDs
+	fp.runsMu.Unlock()
+
+	time.AfterFunc(ttl, func() {
+		fp.runsMu.Lock()
+		delete(fp.runs, primarySessionID)
+		fp.runsMu.Unlock()
+	})
+}
+
+// detachAllSessions detaches all probes and cleans up sessions. Detaching is
+// not bound to the cancellation of ctx so that probes are still removed when
+// the caller has gone away.
+func (fp *FunctionProfiler) detachAllSessions(ctx context.Context, sessionIDs []string) (int, int) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), detachTimeout)
+	defer cancel()
+
+	var detached, failed int
 	for _, sessionID := range sessionIDs {
 		detachReq := connect.NewRequest(&debugpb.DetachUprobeRequest{
 			SessionId: sessionID,
@@ -354,8 +372,13 @@ func (fp *FunctionProfiler) detachAllSessions(ctx context.Context, sessionIDs []
 				Err(err).
 				Str("session_id", sessionID).
 				Msg("Failed to detach session after profiling")
+			failed++
+			continue
 		}
+		detached++
 	}
+
+	return detached, failed
 }
 
 // errorResponse builds a failed response with the given error message.
@@ -382,6 +405,9 @@ func (fp *FunctionProfiler) buildAsyncResponse(cfg *profileConfig, state *profil
 	for _, sid := range state.SessionIDs {
 		nextSteps = append(nextSteps, fmt.Sprintf("Run 'coral debug session events %s' to see events", sid))
 	}
+	if primarySessionID != "" {
+		nextSteps = append(nextSteps, fmt.Sprintf("Run 'coral debug profile cancel %s' to stop profiling early", primarySessionID))
+	}
 
 	return &debugpb.ProfileFunctionsResponse{
 		SessionId:      primarySessionID,
@@ -14,7 +14,7 @@ const (
 	defaultDuration             = 60 * time.Second
 	maxDuration                 = 5 * time.Minute
 	sessionBuffer               = 30 * time.Second
-	pollInterval                = 5 * time.Second
+	detachTimeout               = 30 * time.Second
 	defaultStrategy             = "critical_path"
 	bottleneckMinorThreshold    = 100 * time.Millisecond
 	bottleneckMajorThreshold    = 500 * time.Millisecond
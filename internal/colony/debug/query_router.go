@@ -126,7 +126,7 @@ func (qr *QueryRouter) QueryUprobeEvents(
 
 		if !agentQueryFailed {
 			// Call agent to query events.
-			agentAddr := buildAgentAddress(entry.MeshIPv4)
+			agentAddr := buildAgentAddress(entry.MeshIP())
 			agentClient := qr.clientFactory(
 				http.DefaultClient,
 				fmt.Sprintf("http://%s", agentAddr),
@@ -281,7 +281,7 @@ func (qr *QueryRouter) GetDebugResults(
 		}
 
 		// Call agent to query uprobe events.
-		agentAddr := buildAgentAddress(entry.MeshIPv4)
+		agentAddr := buildAgentAddress(entry.MeshIP())
 		agentClient := qr.clientFactory(
 			http.DefaultClient,
 			fmt.Sprintf("http://%s", agentAddr),
@@ -312,15 +312,42 @@ func (qr *QueryRouter) GetDebugResults(
 			Msg("Retrieved uprobe events from agent")
 	}
 
-	// Aggregate statistics.
-	statistics := AggregateStatistics(uprobeEvents)
+	// Aggregate statistics. Sessions that ended read them from the debug
+	// function stats once their events are rolled up, or compute them in
+	// the database.
+	var statistics *debugpb.DebugStatistics
+	var slowOutliers []*debugpb.SlowOutlier
+	if sessionExpired {
+		stats, err := qr.db.GetDebugSessionStats(ctx, req.Msg.SessionId)
+		if err != nil {
+			qr.logger.Warn().Err(err).
+				Str("session_id", req.Msg.SessionId).
+				Msg("Failed to query debug session stats, computing them from events")
+		} else if stats != nil {
+			statistics = debugStatistics(stats.Calls, stats.P50, stats.P95, stats.P99, stats.Max)
+		}
 
-	// Find slow outliers.
-	p95Duration := time.Duration(0)
-	if statistics.DurationP95 != nil {
-		p95Duration = statistics.DurationP95.AsDuration()
+		if statistics == nil {
+			stats, err := qr.db.QueryDebugEventStats(ctx, req.Msg.SessionId)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query event statistics: %w", err))
+			}
+			statistics = debugStatistics(stats.Calls, stats.P50, stats.P95, stats.P99, stats.Max)
+			if stats.Calls == 0 {
+				statistics.TotalCalls = stats.Events / 2 // Approximate: entry + exit
+			}
+		}
+
+		outliers, err := qr.db.QueryDebugEventOutliers(ctx, req.Msg.SessionId, statistics.DurationP95.AsDuration(), MaxSlowOutliers)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query slow outliers: %w", err))
+		}
+		slowOutliers = FindSlowOutliers(outliers, statistics.DurationP95.AsDuration())
+	} else {
+		statistics = AggregateStatistics(uprobeEvents)
+		slowOutliers = FindSlowOutliers(uprobeEvents, statistics.DurationP95.AsDuration())
 	}
-	slowOutliers := FindSlowOutliers(uprobeEvents, p95Duration)
+	p95Duration := statistics.DurationP95.AsDuration()
 
 	// Build call tree.
 	callTree := BuildCallTreeFromEvents(uprobeEvents, p95Duration)
@@ -339,3 +366,18 @@ func (qr *QueryRouter) GetDebugResults(
 		BinaryPath:   binaryPath,
 	}), nil
 }
+
+// debugStatistics returns the debug statistics of calls with the given
+// latencies; sessions without calls have no latencies.
+func debugStatistics(calls int64, p50, p95, p99, maxDuration time.Duration) *debugpb.DebugStatistics {
+	if calls == 0 {
+		return &debugpb.DebugStatistics{}
+	}
+	return &debugpb.DebugStatistics{
+		TotalCalls:  calls,
+		DurationP50: durationpb.New(p50),
+		DurationP95: durationpb.New(p95),
+		DurationP99: durationpb.New(p99),
+		DurationMax: durationpb.New(maxDuration),
+	}
+}
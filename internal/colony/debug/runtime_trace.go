@@ -0,0 +1,247 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// TraceRuntime traces the Go runtime of a service for a duration and
+// correlates its pauses and GC cycles with the latency of the requests the
+// service served in the same window.
+func (o *Orchestrator) TraceRuntime(
+	ctx context.Context,
+	req *connect.Request[debugpb.TraceRuntimeRequest],
+) (*connect.Response[debugpb.TraceRuntimeResponse], error) {
+	o.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Int32("duration", req.Msg.DurationSeconds).
+		Msg("Starting Go runtime trace")
+
+	durationSeconds := req.Msg.DurationSeconds
+	if durationSeconds <= 0 {
+		durationSeconds = 30 // Default 30 seconds
+	}
+	if durationSeconds > 300 {
+		durationSeconds = 300 // Max 5 minutes
+	}
+
+	agentID := req.Msg.AgentId
+	if agentID == "" {
+		var err error
+		agentID, err = o.agentCoordinator.FindAgentForService(ctx, req.Msg.ServiceName)
+		if err != nil {
+			return connect.NewResponse(&debugpb.TraceRuntimeResponse{
+				Success:   false,
+				Error:     fmt.Sprintf("failed to find agent for service %s: %v", req.Msg.ServiceName, err),
+				ErrorInfo: coralerrors.Info(err),
+			}), nil
+		}
+	}
+
+	entry, err := o.registry.Get(agentID)
+	if err != nil {
+		return connect.NewResponse(&debugpb.TraceRuntimeResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent not found: %v", err),
+			ErrorInfo: &errorsv1.ErrorInfo{
+				Code:     errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND,
+				Metadata: map[string]string{"agent_id": agentID},
+			},
+		}), nil
+	}
+
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.TraceRuntimeResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, o.db, o.logger, req.Msg.ServiceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.TraceRuntimeResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	targetPID, err := o.agentCoordinator.GetServicePID(ctx, agentID, req.Msg.ServiceName)
+	if err != nil {
+		return connect.NewResponse(&debugpb.TraceRuntimeResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to get service PID: %v", err),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	debugClient := o.clientFactory(
+		http.DefaultClient,
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
+	)
+
+	agentTimeout := time.Duration(durationSeconds)*time.Second + profilingTimeout
+	agentCtx, agentCancel := context.WithTimeout(ctx, agentTimeout)
+	defer agentCancel()
+
+	traceResp, err := debugClient.TraceRuntime(agentCtx, connect.NewRequest(&agentv1.TraceRuntimeAgentRequest{
+		AgentId:         agentID,
+		ServiceName:     req.Msg.ServiceName,
+		Pid:             targetPID,
+		DurationSeconds: durationSeconds,
+	}))
+	if err != nil {
+		o.logger.Error().Err(err).
+			Str("agent_id", agentID).
+			Str("service", req.Msg.ServiceName).
+			Msg("Failed to trace Go runtime on agent")
+		return connect.NewResponse(&debugpb.TraceRuntimeResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to trace Go runtime: %v", err),
+			ErrorInfo: coralerrors.Info(coralerrors.FromAgent(agentID, err)),
+		}), nil
+	}
+
+	if !traceResp.Msg.Success {
+		return connect.NewResponse(&debugpb.TraceRuntimeResponse{
+			Success: false,
+			Error:   traceResp.Msg.Error,
+		}), nil
+	}
+
+	trace := traceResp.Msg
+	resp := &debugpb.TraceRuntimeResponse{
+		Success:      true,
+		ServiceName:  req.Msg.ServiceName,
+		AgentId:      agentID,
+		GoVersion:    trace.GoVersion,
+		StartTime:    trace.StartTime,
+		EndTime:      trace.EndTime,
+		Pauses:       trace.Pauses,
+		GcCycles:     trace.GcCycles,
+		SchedLatency: trace.SchedLatency,
+	}
+
+	// Spans polled from the agent after the trace ended are not included.
+	spans, err := o.db.QueryBeylaServerSpanTimings(ctx, req.Msg.ServiceName,
+		trace.StartTime.AsTime(), trace.EndTime.AsTime())
+	if err != nil {
+		o.logger.Warn().Err(err).
+			Str("service", req.Msg.ServiceName).
+			Msg("Failed to query request spans for runtime trace")
+	} else if len(spans) > 0 {
+		resp.Requests = correlateRequests(trace.Pauses, trace.GcCycles, spans)
+	}
+
+	o.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Int("pauses", len(trace.Pauses)).
+		Int("gc_cycles", len(trace.GcCycles)).
+		Int("requests", len(spans)).
+		Msg("Go runtime trace completed")
+
+	o.publishProfilingCompleted(agentID, req.Msg.ServiceName, "", "runtime", map[string]string{
+		"duration_seconds": fmt.Sprintf("%d", durationSeconds),
+		"pauses":           fmt.Sprintf("%d", len(trace.Pauses)),
+		"gc_cycles":        fmt.Sprintf("%d", len(trace.GcCycles)),
+	})
+
+	return connect.NewResponse(resp), nil
+}
+
+// interval is a time range of the runtime, a pause or a GC cycle.
+type interval struct {
+	start, end time.Time
+}
+
+// overlapsAny reports whether [start, end) overlaps one of the intervals,
+// sorted by start.
+func overlapsAny(intervals []interval, start, end time.Time) bool {
+	for _, iv := range intervals {
+		if !iv.start.Before(end) {
+			return false
+		}
+		if iv.end.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// correlateRequests relates the latency of request spans to the pauses and
+// GC cycles they overlapped.
+func correlateRequests(
+	pauses []*agentv1.RuntimePause,
+	cycles []*agentv1.GcCycle,
+	spans []database.BeylaSpanTiming,
+) *debugpb.RequestLatencyCorrelation {
+	toIntervals := func(n int, at func(int) (time.Time, uint64)) []interval {
+		intervals := make([]interval, 0, n)
+		for i := 0; i < n; i++ {
+			start, ns := at(i)
+			intervals = append(intervals, interval{start, start.Add(time.Duration(ns))}) // #nosec G115
+		}
+		sort.Slice(intervals, func(i, j int) bool { return intervals[i].start.Before(intervals[j].start) })
+		return intervals
+	}
+	pauseIntervals := toIntervals(len(pauses), func(i int) (time.Time, uint64) {
+		return pauses[i].Start.AsTime(), pauses[i].DurationNs
+	})
+	gcIntervals := toIntervals(len(cycles), func(i int) (time.Time, uint64) {
+		return cycles[i].Start.AsTime(), cycles[i].DurationNs
+	})
+
+	all := make([]time.Duration, 0, len(spans))
+	for _, span := range spans {
+		all = append(all, time.Duration(span.DurationUs)*time.Microsecond)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+	p99 := percentile(all, 0.99)
+
+	result := &debugpb.RequestLatencyCorrelation{
+		Requests: uint64(len(spans)),
+		P50Us:    uint64(percentile(all, 0.50).Microseconds()), // #nosec G115 -- durations are positive
+		P99Us:    uint64(p99.Microseconds()),                   // #nosec G115
+	}
+
+	var duringGC, outsideGC []time.Duration
+	for _, span := range spans {
+		duration := time.Duration(span.DurationUs) * time.Microsecond
+		end := span.StartTime.Add(duration)
+		inGC := overlapsAny(gcIntervals, span.StartTime, end)
+
+		if inGC {
+			duringGC = append(duringGC, duration)
+		} else {
+			outsideGC = append(outsideGC, duration)
+		}
+
+		if duration > p99 {
+			result.SlowRequests++
+			if overlapsAny(pauseIntervals, span.StartTime, end) {
+				result.SlowDuringPause++
+			}
+			if inGC {
+				result.SlowDuringGc++
+			}
+		}
+	}
+
+	sort.Slice(duringGC, func(i, j int) bool { return duringGC[i] < duringGC[j] })
+	sort.Slice(outsideGC, func(i, j int) bool { return outsideGC[i] < outsideGC[j] })
+	result.RequestsDuringGc = uint64(len(duringGC))
+	result.P99DuringGcUs = uint64(percentile(duringGC, 0.99).Microseconds())   // #nosec G115
+	result.P99OutsideGcUs = uint64(percentile(outsideGC, 0.99).Microseconds()) // #nosec G115
+	return result
+}
@@ -0,0 +1,60 @@
+package debug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+)
+
+func TestCorrelateRequests(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+
+	pauses := []*agentv1.RuntimePause{
+		{Start: timestamppb.New(at(1000)), DurationNs: uint64(time.Millisecond)},
+		{Start: timestamppb.New(at(1050)), DurationNs: uint64(time.Millisecond)},
+	}
+	cycles := []*agentv1.GcCycle{
+		{Start: timestamppb.New(at(1000)), DurationNs: uint64(51 * time.Millisecond), PauseNs: uint64(2 * time.Millisecond)},
+	}
+
+	// 200 fast requests away from the GC cycle, and two slow ones: one
+	// spanning a pause, one only overlapping the mark phase.
+	var spans []database.BeylaSpanTiming
+	for i := 0; i < 200; i++ {
+		spans = append(spans, database.BeylaSpanTiming{StartTime: at(2000 + i), DurationUs: 500})
+	}
+	spans = append(spans,
+		database.BeylaSpanTiming{StartTime: at(999), DurationUs: 40000},
+		database.BeylaSpanTiming{StartTime: at(1010), DurationUs: 30000},
+	)
+
+	result := correlateRequests(pauses, cycles, spans)
+	assert.Equal(t, uint64(202), result.Requests)
+	assert.Equal(t, uint64(500), result.P50Us)
+	assert.Equal(t, uint64(500), result.P99Us)
+	assert.Equal(t, uint64(2), result.SlowRequests)
+	assert.Equal(t, uint64(1), result.SlowDuringPause, "the second slow request ends before the second pause")
+	assert.Equal(t, uint64(2), result.SlowDuringGc)
+	assert.Equal(t, uint64(2), result.RequestsDuringGc)
+	assert.Equal(t, uint64(40000), result.P99DuringGcUs)
+	assert.Equal(t, uint64(500), result.P99OutsideGcUs)
+}
+
+func TestOverlapsAny(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	intervals := []interval{
+		{base.Add(10 * time.Second), base.Add(11 * time.Second)},
+		{base.Add(20 * time.Second), base.Add(21 * time.Second)},
+	}
+
+	assert.True(t, overlapsAny(intervals, base.Add(9*time.Second), base.Add(10500*time.Millisecond)))
+	assert.True(t, overlapsAny(intervals, base.Add(20500*time.Millisecond), base.Add(30*time.Second)))
+	assert.False(t, overlapsAny(intervals, base.Add(11*time.Second), base.Add(20*time.Second)), "bounds are exclusive")
+	assert.False(t, overlapsAny(nil, base, base.Add(time.Hour)))
+}
@@ -0,0 +1,310 @@
+package debug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/poller"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Profile types supported by profile schedules.
+const (
+	profileTypeCPU    = "cpu"
+	profileTypeMemory = "memory"
+)
+
+// Profile run statuses.
+const (
+	profileRunSuccess = "success"
+	profileRunFailed  = "failed"
+)
+
+// profileRunReencodeBatch is the number of legacy profile runs re-encoded per
+// poll.
+const profileRunReencodeBatch = 20
+
+// Profiler collects on-demand profiles. It is implemented by Orchestrator.
+type Profiler interface {
+	ProfileCPU(context.Context, *connect.Request[debugpb.ProfileCPURequest]) (*connect.Response[debugpb.ProfileCPUResponse], error)
+	ProfileMemory(context.Context, *connect.Request[debugpb.ProfileMemoryRequest]) (*connect.Response[debugpb.ProfileMemoryResponse], error)
+}
+
+// ProfileScheduler runs recurring profiling jobs defined by profile schedules
+// and stores their results as profile runs.
+type ProfileScheduler struct {
+	*poller.BasePoller
+	profiler  Profiler
+	db        *database.Database
+	retention time.Duration
+	logger    zerolog.Logger
+
+	// running tracks schedules with a job in progress, so a slow job is
+	// never started twice.
+	mu      sync.Mutex
+	running map[string]bool
+	jobs    sync.WaitGroup
+
+	// legacyReencoded is set once no legacy profile runs are left. It is
+	// only used by PollOnce.
+	legacyReencoded bool
+}
+
+// NewProfileScheduler creates a profile scheduler. Runs older than retention
+// are deleted.
+func NewProfileScheduler(
+	ctx context.Context,
+	profiler Profiler,
+	db *database.Database,
+	retention time.Duration,
+	logger zerolog.Logger,
+) *ProfileScheduler {
+	if retention <= 0 {
+		retention = constants.DefaultProfileRunRetention
+	}
+
+	componentLogger := logger.With().Str("component", "profile_scheduler").Logger()
+
+	base := poller.NewBasePoller(ctx, poller.Config{
+		Name:         "profile_scheduler",
+		PollInterval: constants.DefaultProfileSchedulerInterval,
+		Logger:       componentLogger,
+	})
+
+	return &ProfileScheduler{
+		BasePoller: base,
+		profiler:   profiler,
+		db:         db,
+		retention:  retention,
+		logger:     componentLogger,
+		running:    make(map[string]bool),
+	}
+}
+
+// Start begins running due profile schedules.
+func (s *ProfileScheduler) Start() error {
+	return s.BasePoller.Start(s)
+}
+
+// Stop stops the scheduler and waits for running jobs to finish.
+func (s *ProfileScheduler) Stop() error {
+	err := s.BasePoller.Stop()
+	s.jobs.Wait()
+	return err
+}
+
+// PollOnce starts a job for every due profile schedule.
+// Implements the poller.Poller interface.
+func (s *ProfileScheduler) PollOnce(ctx context.Context) error {
+	now := time.Now()
+
+	due, err := s.db.ListDueProfileSchedules(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range due {
+		if !s.claim(schedule.ID) {
+			continue
+		}
+
+		// Missed runs are not caught up: the next run is one interval from
+		// now, however late this one is.
+		if err := s.db.SetProfileScheduleNextRun(ctx, schedule.ID, now.Add(schedule.Interval())); err != nil {
+			s.release(schedule.ID)
+			s.logger.Error().Err(err).
+				Str("schedule_id", schedule.ID).
+				Msg("Failed to advance profile schedule")
+			continue
+		}
+
+		s.jobs.Add(1)
+		go func(schedule *database.ProfileSchedule) {
+			defer s.jobs.Done()
+			defer s.release(schedule.ID)
+
+			run := s.runSchedule(ctx, schedule)
+			if err := s.db.InsertProfileRun(context.WithoutCancel(ctx), run); err != nil {
+				s.logger.Error().Err(err).
+					Str("schedule_id", schedule.ID).
+					Msg("Failed to store profile run")
+			}
+		}(schedule)
+	}
+
+	s.reencodeLegacyRuns(ctx)
+	return nil
+}
+
+// reencodeLegacyRuns moves the folded stacks of runs stored by earlier
+// releases into the frame dictionary, one batch per poll until none are left.
+func (s *ProfileScheduler) reencodeLegacyRuns(ctx context.Context) {
+	if s.legacyReencoded {
+		return
+	}
+
+	n, err := s.db.ReencodeLegacyProfileRuns(ctx, profileRunReencodeBatch)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to re-encode legacy profile runs")
+		return
+	}
+	if n > 0 {
+		s.logger.Info().Int("runs", n).Msg("Re-encoded legacy profile runs")
+	}
+	s.legacyReencoded = n < profileRunReencodeBatch
+}
+
+// RunCleanup deletes profile runs older than the retention period.
+// Implements the poller.Poller interface.
+func (s *ProfileScheduler) RunCleanup(ctx context.Context) error {
+	_, err := s.db.CleanupOldProfileRuns(ctx, s.retention)
+	return err
+}
+
+func (s *ProfileScheduler) claim(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[id] {
+		return false
+	}
+	s.running[id] = true
+	return true
+}
+
+func (s *ProfileScheduler) release(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, id)
+}
+
+// runSchedule collects one profile for the schedule. Failures are recorded in
+// the returned run rather than returned.
+func (s *ProfileScheduler) runSchedule(ctx context.Context, schedule *database.ProfileSchedule) *database.ProfileRun {
+	run := &database.ProfileRun{
+		ID:          uuid.New().String(),
+		ScheduleID:  schedule.ID,
+		ServiceName: schedule.ServiceName,
+		ProfileType: schedule.ProfileType,
+		StartedAt:   time.Now(),
+	}
+
+	s.logger.Info().
+		Str("schedule_id", schedule.ID).
+		Str("service", schedule.ServiceName).
+		Str("type", schedule.ProfileType).
+		Msg("Running scheduled profile")
+
+	var err error
+	switch schedule.ProfileType {
+	case profileTypeCPU:
+		err = s.profileCPU(ctx, schedule, run)
+	case profileTypeMemory:
+		err = s.profileMemory(ctx, schedule, run)
+	default:
+		err = fmt.Errorf("unsupported profile type %q", schedule.ProfileType)
+	}
+
+	run.FinishedAt = time.Now()
+	run.Status = profileRunSuccess
+	if err != nil {
+		run.Status = profileRunFailed
+		run.Error = err.Error()
+		s.logger.Warn().Err(err).
+			Str("schedule_id", schedule.ID).
+			Str("service", schedule.ServiceName).
+			Msg("Scheduled profile failed")
+	}
+
+	return run
+}
+
+func (s *ProfileScheduler) profileCPU(ctx context.Context, schedule *database.ProfileSchedule, run *database.ProfileRun) error {
+	resp, err := s.profiler.ProfileCPU(ctx, connect.NewRequest(&debugpb.ProfileCPURequest{
+		ServiceName:     schedule.ServiceName,
+		PodName:         schedule.PodName,
+		DurationSeconds: schedule.DurationSeconds,
+		FrequencyHz:     schedule.FrequencyHz,
+	}))
+	if err != nil {
+		return err
+	}
+	if !resp.Msg.Success {
+		return errors.New(resp.Msg.Error)
+	}
+
+	folded := make(map[string]int64, len(resp.Msg.Samples))
+	for _, sample := range resp.Msg.Samples {
+		folded[foldFrames(sample.FrameNames)] += int64(sample.Count) // #nosec G115
+	}
+
+	run.Total = int64(resp.Msg.TotalSamples) // #nosec G115
+	run.UniqueStacks = int32(len(folded))    // #nosec G115
+	run.Folded = formatFolded(folded)
+	return nil
+}
+
+func (s *ProfileScheduler) profileMemory(ctx context.Context, schedule *database.ProfileSchedule, run *database.ProfileRun) error {
+	resp, err := s.profiler.ProfileMemory(ctx, connect.NewRequest(&debugpb.ProfileMemoryRequest{
+		ServiceName:     schedule.ServiceName,
+		PodName:         schedule.PodName,
+		DurationSeconds: schedule.DurationSeconds,
+	}))
+	if err != nil {
+		return err
+	}
+	if !resp.Msg.Success {
+		return errors.New(resp.Msg.Error)
+	}
+
+	folded := make(map[string]int64, len(resp.Msg.Samples))
+	for _, sample := range resp.Msg.Samples {
+		folded[foldFrames(sample.FrameNames)] += sample.AllocBytes
+		run.Total += sample.AllocBytes
+	}
+
+	run.UniqueStacks = int32(len(folded)) // #nosec G115
+	run.Folded = formatFolded(folded)
+	return nil
+}
+
+// foldFrames joins innermost-first frames into a folded stack, outermost
+// first.
+func foldFrames(frames []string) string {
+	reversed := make([]string, len(frames))
+	for i, frame := range frames {
+		reversed[len(frames)-1-i] = frame
+	}
+	return strings.Join(reversed, ";")
+}
+
+// formatFolded renders folded stacks as "stack value" lines, sorted by stack.
+func formatFolded(stacks map[string]int64) string {
+	keys := make([]string, 0, len(stacks))
+	for stack, value := range stacks {
+		if stack == "" || value == 0 {
+			continue
+		}
+		keys = append(keys, stack)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, stack := range keys {
+		fmt.Fprintf(&b, "%s %d\n", stack, stacks[stack])
+	}
+	return b.String()
+}
+
+// Ensure the orchestrator can run scheduled profiles.
+var _ Profiler = (*Orchestrator)(nil)
@@ -0,0 +1,195 @@
+package debug
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+)
+
+type fakeProfiler struct {
+	cpuReq *debugpb.ProfileCPURequest
+}
+
+func (f *fakeProfiler) ProfileCPU(_ context.Context, req *connect.Request[debugpb.ProfileCPURequest]) (*connect.Response[debugpb.ProfileCPUResponse], error) {
+	f.cpuReq = req.Msg
+	return connect.NewResponse(&debugpb.ProfileCPUResponse{
+		Success:      true,
+		TotalSamples: 5,
+		Samples: []*agentv1.StackSample{
+			{FrameNames: []string{"work", "handle", "main"}, Count: 3},
+			{FrameNames: []string{"encode", "handle", "main"}, Count: 2},
+		},
+	}), nil
+}
+
+func (f *fakeProfiler) ProfileMemory(context.Context, *connect.Request[debugpb.ProfileMemoryRequest]) (*connect.Response[debugpb.ProfileMemoryResponse], error) {
+	return connect.NewResponse(&debugpb.ProfileMemoryResponse{
+		Success: false,
+		Error:   "no agent found for service api",
+	}), nil
+}
+
+func TestProfileScheduleRPCs(t *testing.T) {
+	orch, _ := setupTestOrchestrator(t)
+	ctx := context.Background()
+
+	invalid := []*debugpb.CreateProfileScheduleRequest{
+		{Interval: durationpb.New(time.Hour)},
+		{ServiceName: "api", ProfileType: "block", Interval: durationpb.New(time.Hour)},
+		{ServiceName: "api", Interval: durationpb.New(10 * time.Second)},
+		{ServiceName: "api", DurationSeconds: 120, Interval: durationpb.New(time.Minute)},
+		{ServiceName: "api", DurationSeconds: 301, Interval: durationpb.New(time.Hour)},
+	}
+	for _, req := range invalid {
+		_, err := orch.CreateProfileSchedule(ctx, connect.NewRequest(req))
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("CreateProfileSchedule(%v) error = %v, want InvalidArgument", req, err)
+		}
+	}
+
+	created, err := orch.CreateProfileSchedule(ctx, connect.NewRequest(&debugpb.CreateProfileScheduleRequest{
+		ServiceName: "api",
+		Interval:    durationpb.New(time.Hour),
+	}))
+	if err != nil {
+		t.Fatalf("CreateProfileSchedule failed: %v", err)
+	}
+	schedule := created.Msg.Schedule
+	if schedule.ProfileType != "cpu" || schedule.DurationSeconds != 30 || schedule.FrequencyHz != 99 {
+		t.Errorf("defaults not applied: %v", schedule)
+	}
+
+	list, err := orch.ListProfileSchedules(ctx, connect.NewRequest(&debugpb.ListProfileSchedulesRequest{}))
+	if err != nil {
+		t.Fatalf("ListProfileSchedules failed: %v", err)
+	}
+	if len(list.Msg.Schedules) != 1 || list.Msg.Schedules[0].Id != schedule.Id {
+		t.Fatalf("ListProfileSchedules = %v, want the created schedule", list.Msg.Schedules)
+	}
+	if list.Msg.Schedules[0].Interval.AsDuration() != time.Hour {
+		t.Errorf("interval = %v, want 1h", list.Msg.Schedules[0].Interval.AsDuration())
+	}
+
+	if _, err := orch.DeleteProfileSchedule(ctx, connect.NewRequest(&debugpb.DeleteProfileScheduleRequest{Id: schedule.Id})); err != nil {
+		t.Fatalf("DeleteProfileSchedule failed: %v", err)
+	}
+	_, err = orch.DeleteProfileSchedule(ctx, connect.NewRequest(&debugpb.DeleteProfileScheduleRequest{Id: schedule.Id}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("second DeleteProfileSchedule error = %v, want NotFound", err)
+	}
+
+	_, err = orch.GetProfileRun(ctx, connect.NewRequest(&debugpb.GetProfileRunRequest{Id: "missing"}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("GetProfileRun error = %v, want NotFound", err)
+	}
+}
+
+func TestProfileScheduler_PollOnce(t *testing.T) {
+	orch, db := setupTestOrchestrator(t)
+	ctx := context.Background()
+	profiler := &fakeProfiler{}
+	scheduler := NewProfileScheduler(ctx, profiler, db, 0, zerolog.Nop())
+
+	now := time.Now()
+	schedules := []*database.ProfileSchedule{
+		{ID: "cpu-1", ServiceName: "api", ProfileType: "cpu", DurationSeconds: 10, IntervalSeconds: 3600, FrequencyHz: 49, CreatedAt: now, NextRunAt: now.Add(-time.Minute)},
+		{ID: "mem-1", ServiceName: "api", ProfileType: "memory", DurationSeconds: 10, IntervalSeconds: 3600, CreatedAt: now, NextRunAt: now.Add(-time.Minute)},
+		{ID: "later", ServiceName: "api", ProfileType: "cpu", DurationSeconds: 10, IntervalSeconds: 3600, CreatedAt: now, NextRunAt: now.Add(time.Hour)},
+	}
+	for _, s := range schedules {
+		if err := db.InsertProfileSchedule(ctx, s); err != nil {
+			t.Fatalf("InsertProfileSchedule failed: %v", err)
+		}
+	}
+
+	if err := scheduler.PollOnce(ctx); err != nil {
+		t.Fatalf("PollOnce failed: %v", err)
+	}
+	scheduler.jobs.Wait()
+
+	if profiler.cpuReq == nil || profiler.cpuReq.FrequencyHz != 49 || profiler.cpuReq.DurationSeconds != 10 {
+		t.Errorf("ProfileCPU request = %v, want the schedule's settings", profiler.cpuReq)
+	}
+
+	runs, err := orch.ListProfileRuns(ctx, connect.NewRequest(&debugpb.ListProfileRunsRequest{ServiceName: "api"}))
+	if err != nil {
+		t.Fatalf("ListProfileRuns failed: %v", err)
+	}
+	if len(runs.Msg.Runs) != 2 {
+		t.Fatalf("got %d runs, want 2", len(runs.Msg.Runs))
+	}
+
+	byType := make(map[string]*debugpb.ProfileRun)
+	for _, r := range runs.Msg.Runs {
+		byType[r.ProfileType] = r
+	}
+	if r := byType["memory"]; r == nil || r.Status != profileRunFailed || r.Error != "no agent found for service api" {
+		t.Errorf("memory run = %v, want failed with the profiler error", r)
+	}
+	cpuRun := byType["cpu"]
+	if cpuRun == nil || cpuRun.Status != profileRunSuccess || cpuRun.Total != 5 || cpuRun.UniqueStacks != 2 {
+		t.Fatalf("cpu run = %v, want success with 5 samples in 2 stacks", cpuRun)
+	}
+
+	got, err := orch.GetProfileRun(ctx, connect.NewRequest(&debugpb.GetProfileRunRequest{Id: cpuRun.Id}))
+	if err != nil {
+		t.Fatalf("GetProfileRun failed: %v", err)
+	}
+	wantFolded := "main;handle;encode 2\nmain;handle;work 3\n"
+	if got.Msg.Folded != wantFolded {
+		t.Errorf("folded = %q, want %q", got.Msg.Folded, wantFolded)
+	}
+
+	schedule, err := db.GetProfileSchedule(ctx, "cpu-1")
+	if err != nil {
+		t.Fatalf("GetProfileSchedule failed: %v", err)
+	}
+	if schedule.LastRunAt == nil || schedule.LastStatus != profileRunSuccess {
+		t.Errorf("last run not recorded: %+v", schedule)
+	}
+	if !schedule.NextRunAt.After(now.Add(59 * time.Minute)) {
+		t.Errorf("next run = %v, want about an hour from now", schedule.NextRunAt)
+	}
+
+	// Nothing is due until the next interval.
+	profiler.cpuReq = nil
+	if err := scheduler.PollOnce(ctx); err != nil {
+		t.Fatalf("PollOnce failed: %v", err)
+	}
+	scheduler.jobs.Wait()
+	if profiler.cpuReq != nil {
+		t.Error("schedule ran again before its interval elapsed")
+	}
+}
+
+func TestProfileScheduler_SkipsRunningSchedule(t *testing.T) {
+	scheduler := NewProfileScheduler(context.Background(), &fakeProfiler{}, nil, 0, zerolog.Nop())
+
+	if !scheduler.claim("s-1") {
+		t.Fatal("first claim failed")
+	}
+	if scheduler.claim("s-1") {
+		t.Error("schedule claimed twice while running")
+	}
+	scheduler.release("s-1")
+	if !scheduler.claim("s-1") {
+		t.Error("claim failed after release")
+	}
+}
+
+func TestProfileScheduler_UnsupportedType(t *testing.T) {
+	scheduler := NewProfileScheduler(context.Background(), &fakeProfiler{}, nil, 0, zerolog.Nop())
+
+	run := scheduler.runSchedule(context.Background(), &database.ProfileSchedule{ID: "s-1", ProfileType: "block"})
+	if run.Status != profileRunFailed || run.Error != `unsupported profile type "block"` {
+		t.Errorf("run = %+v, want failed with unsupported type", run)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"connectrpc.com/connect"
@@ -16,9 +17,12 @@ import (
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
 	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
 
 	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/events"
 	"github.com/coral-mesh/coral/internal/colony/registry"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
 )
 
 // SessionManager manages the lifecycle of debug sessions.
@@ -28,6 +32,12 @@ type SessionManager struct {
 	db               *database.Database
 	agentCoordinator *AgentCoordinator
 	clientFactory    func(connect.HTTPClient, string, ...connect.ClientOption) agentv1connect.AgentDebugServiceClient
+	events           *events.Broker
+
+	// starting maps the IDs of sessions being attached to their agent, so
+	// that events the agent pushes before the session is stored are accepted.
+	startingMu sync.Mutex
+	starting   map[string]string
 }
 
 // NewSessionManager creates a new session manager.
@@ -44,7 +54,25 @@ func NewSessionManager(
 		db:               db,
 		agentCoordinator: agentCoordinator,
 		clientFactory:    clientFactory,
+		starting:         make(map[string]string),
+	}
+}
+
+// sessionOwner returns the agent owning a debug session, and whether the
+// session is known.
+func (sm *SessionManager) sessionOwner(ctx context.Context, sessionID string) (string, bool) {
+	sm.startingMu.Lock()
+	agentID, ok := sm.starting[sessionID]
+	sm.startingMu.Unlock()
+	if ok {
+		return agentID, true
+	}
+
+	session, err := sm.db.GetDebugSession(ctx, sessionID)
+	if err != nil || session == nil {
+		return "", false
 	}
+	return session.AgentID, true
 }
 
 // AttachUprobe starts a new debug session by attaching a uprobe to a function.
@@ -62,8 +90,9 @@ func (sm *SessionManager) AttachUprobe(
 		agentID, err := sm.agentCoordinator.FindAgentForService(ctx, req.Msg.ServiceName)
 		if err != nil {
 			return connect.NewResponse(&debugpb.AttachUprobeResponse{
-				Success: false,
-				Error:   fmt.Sprintf("failed to find agent for service %s: %v", req.Msg.ServiceName, err),
+				Success:   false,
+				Error:     fmt.Sprintf("failed to find agent for service %s: %v", req.Msg.ServiceName, err),
+				ErrorInfo: coralerrors.Info(err),
 			}), nil
 		}
 		req.Msg.AgentId = agentID
@@ -95,16 +124,47 @@ func (sm *SessionManager) AttachUprobe(
 		return connect.NewResponse(&debugpb.AttachUprobeResponse{
 			Success: false,
 			Error:   fmt.Sprintf("agent not found: %v", err),
+			ErrorInfo: &errorsv1.ErrorInfo{
+				Code:     errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND,
+				Metadata: map[string]string{"agent_id": req.Msg.AgentId},
+			},
+		}), nil
+	}
+
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.AttachUprobeResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, sm.db, sm.logger, req.Msg.ServiceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.AttachUprobeResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
 		}), nil
 	}
 
 	// Call agent to start uprobe collector.
-	agentAddr := buildAgentAddress(entry.MeshIPv4)
+	agentAddr := buildAgentAddress(entry.MeshIP())
 	agentClient := sm.clientFactory(
 		http.DefaultClient,
 		fmt.Sprintf("http://%s", agentAddr),
 	)
 
+	// The agent pushes events as soon as the collector starts, possibly
+	// before the session is stored.
+	sm.startingMu.Lock()
+	sm.starting[sessionID] = req.Msg.AgentId
+	sm.startingMu.Unlock()
+	defer func() {
+		sm.startingMu.Lock()
+		delete(sm.starting, sessionID)
+		sm.startingMu.Unlock()
+	}()
+
 	startReq := connect.NewRequest(&agentv1.StartUprobeCollectorRequest{
 		AgentId:      req.Msg.AgentId,
 		ServiceName:  req.Msg.ServiceName,
@@ -113,6 +173,7 @@ func (sm *SessionManager) AttachUprobe(
 		Config:       req.Msg.Config,
 		SdkAddr:      req.Msg.SdkAddr,
 		Filter:       req.Msg.Filter, // Forward kernel-level filter to agent (RFD 090).
+		SessionId:    sessionID,
 	})
 
 	startResp, err := agentClient.StartUprobeCollector(ctx, startReq)
@@ -122,8 +183,9 @@ func (sm *SessionManager) AttachUprobe(
 			Str("function", req.Msg.FunctionName).
 			Msg("Failed to start uprobe collector on agent")
 		return connect.NewResponse(&debugpb.AttachUprobeResponse{
-			Success: false,
-			Error:   fmt.Sprintf("failed to start uprobe collector: %v", err),
+			Success:   false,
+			Error:     fmt.Sprintf("failed to start uprobe collector: %v", err),
+			ErrorInfo: coralerrors.Info(coralerrors.FromAgent(req.Msg.AgentId, err)),
 		}), nil
 	}
 
@@ -164,6 +226,18 @@ func (sm *SessionManager) AttachUprobe(
 		Time("expires_at", expiresAt).
 		Msg("Debug session created")
 
+	sm.events.Publish(&debugpb.ColonyEvent{
+		Type:        debugpb.ColonyEventType_COLONY_EVENT_TYPE_DEBUG_SESSION_STARTED,
+		AgentId:     session.AgentID,
+		ServiceName: session.ServiceName,
+		SessionId:   sessionID,
+		Message:     fmt.Sprintf("Debug session attached to %s", session.FunctionName),
+		Attributes: map[string]string{
+			"function":   session.FunctionName,
+			"expires_at": expiresAt.UTC().Format(time.RFC3339),
+		},
+	})
+
 	return connect.NewResponse(&debugpb.AttachUprobeResponse{
 		SessionId: sessionID,
 		ExpiresAt: timestamppb.New(expiresAt),
@@ -171,6 +245,321 @@ func (sm *SessionManager) AttachUprobe(
 	}), nil
 }
 
+// CaptureHttp starts a debug session capturing the HTTP exchanges of a
+// service for a route. Captured exchanges are stored as events of type
+// "http"; the session is stopped like uprobe sessions, with DetachUprobe.
+func (sm *SessionManager) CaptureHttp(
+	ctx context.Context,
+	req *connect.Request[debugpb.CaptureHttpRequest],
+) (*connect.Response[debugpb.CaptureHttpResponse], error) {
+	sm.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Str("route", req.Msg.Route).
+		Float64("sample_rate", req.Msg.SampleRate).
+		Msg("Starting HTTP capture")
+
+	if req.Msg.Route == "" {
+		return connect.NewResponse(&debugpb.CaptureHttpResponse{
+			Success:   false,
+			Error:     "route is required",
+			ErrorInfo: &errorsv1.ErrorInfo{Code: errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT},
+		}), nil
+	}
+
+	if req.Msg.AgentId == "" {
+		agentID, err := sm.agentCoordinator.FindAgentForService(ctx, req.Msg.ServiceName)
+		if err != nil {
+			return connect.NewResponse(&debugpb.CaptureHttpResponse{
+				Success:   false,
+				Error:     fmt.Sprintf("failed to find agent for service %s: %v", req.Msg.ServiceName, err),
+				ErrorInfo: coralerrors.Info(err),
+			}), nil
+		}
+		req.Msg.AgentId = agentID
+	}
+
+	sessionID := uuid.New().String()
+
+	duration := req.Msg.Duration
+	if duration == nil || duration.AsDuration() > 10*time.Minute {
+		duration = durationpb.New(60 * time.Second) // Default: 60s, Max: 10min
+	}
+	expiresAt := time.Now().Add(duration.AsDuration())
+
+	entry, err := sm.registry.Get(req.Msg.AgentId)
+	if err != nil {
+		return connect.NewResponse(&debugpb.CaptureHttpResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent not found: %v", err),
+			ErrorInfo: &errorsv1.ErrorInfo{
+				Code:     errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND,
+				Metadata: map[string]string{"agent_id": req.Msg.AgentId},
+			},
+		}), nil
+	}
+
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.CaptureHttpResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, sm.db, sm.logger, req.Msg.ServiceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.CaptureHttpResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	agentClient := sm.clientFactory(
+		http.DefaultClient,
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
+	)
+
+	// Exchanges are pushed as soon as the capture starts.
+	sm.startingMu.Lock()
+	sm.starting[sessionID] = req.Msg.AgentId
+	sm.startingMu.Unlock()
+	defer func() {
+		sm.startingMu.Lock()
+		delete(sm.starting, sessionID)
+		sm.startingMu.Unlock()
+	}()
+
+	startResp, err := agentClient.StartHttpCapture(ctx, connect.NewRequest(&agentv1.StartHttpCaptureRequest{
+		AgentId:       req.Msg.AgentId,
+		ServiceName:   req.Msg.ServiceName,
+		Route:         req.Msg.Route,
+		SampleRate:    req.Msg.SampleRate,
+		CaptureBodies: req.Msg.CaptureBodies,
+		MaxBodyBytes:  req.Msg.MaxBodyBytes,
+		Duration:      duration,
+		SessionId:     sessionID,
+	}))
+	if err != nil {
+		sm.logger.Error().Err(err).
+			Str("agent_id", req.Msg.AgentId).
+			Str("route", req.Msg.Route).
+			Msg("Failed to start HTTP capture on agent")
+		return connect.NewResponse(&debugpb.CaptureHttpResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to start HTTP capture: %v", err),
+			ErrorInfo: coralerrors.Info(coralerrors.FromAgent(req.Msg.AgentId, err)),
+		}), nil
+	}
+
+	if !startResp.Msg.Supported || startResp.Msg.Error != "" {
+		return connect.NewResponse(&debugpb.CaptureHttpResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent failed to start HTTP capture: %s", startResp.Msg.Error),
+		}), nil
+	}
+
+	session := &database.DebugSession{
+		SessionID:    sessionID,
+		CollectorID:  startResp.Msg.CollectorId,
+		ServiceName:  req.Msg.ServiceName,
+		FunctionName: "http:" + req.Msg.Route,
+		AgentID:      req.Msg.AgentId,
+		StartedAt:    time.Now(),
+		ExpiresAt:    expiresAt,
+		Status:       "active",
+	}
+	if err := sm.db.InsertDebugSession(ctx, session); err != nil {
+		sm.logger.Error().Err(err).
+			Str("session_id", sessionID).
+			Msg("Failed to insert debug session into database")
+		return connect.NewResponse(&debugpb.CaptureHttpResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to store session: %v", err),
+		}), nil
+	}
+
+	sm.logger.Info().
+		Str("session_id", sessionID).
+		Str("route", req.Msg.Route).
+		Time("expires_at", expiresAt).
+		Msg("HTTP capture session created")
+
+	sm.events.Publish(&debugpb.ColonyEvent{
+		Type:        debugpb.ColonyEventType_COLONY_EVENT_TYPE_DEBUG_SESSION_STARTED,
+		AgentId:     session.AgentID,
+		ServiceName: session.ServiceName,
+		SessionId:   sessionID,
+		Message:     fmt.Sprintf("HTTP capture started for %s", req.Msg.Route),
+		Attributes: map[string]string{
+			"function":   session.FunctionName,
+			"route":      req.Msg.Route,
+			"expires_at": expiresAt.UTC().Format(time.RFC3339),
+		},
+	})
+
+	return connect.NewResponse(&debugpb.CaptureHttpResponse{
+		SessionId: sessionID,
+		ExpiresAt: timestamppb.New(expiresAt),
+		Success:   true,
+	}), nil
+}
+
+// CaptureTls starts a debug session capturing the plaintext of a service's
+// TLS connections. Captured reads and writes are stored as events of type
+// "tls"; the session is stopped like uprobe sessions, with DetachUprobe.
+func (sm *SessionManager) CaptureTls(
+	ctx context.Context,
+	req *connect.Request[debugpb.CaptureTlsRequest],
+) (*connect.Response[debugpb.CaptureTlsResponse], error) {
+	sm.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Str("library", req.Msg.Library).
+		Str("match", req.Msg.Match).
+		Msg("Starting TLS capture")
+
+	if req.Msg.AgentId == "" {
+		agentID, err := sm.agentCoordinator.FindAgentForService(ctx, req.Msg.ServiceName)
+		if err != nil {
+			return connect.NewResponse(&debugpb.CaptureTlsResponse{
+				Success:   false,
+				Error:     fmt.Sprintf("failed to find agent for service %s: %v", req.Msg.ServiceName, err),
+				ErrorInfo: coralerrors.Info(err),
+			}), nil
+		}
+		req.Msg.AgentId = agentID
+	}
+
+	sessionID := uuid.New().String()
+
+	duration := req.Msg.Duration
+	if duration == nil || duration.AsDuration() > 10*time.Minute {
+		duration = durationpb.New(60 * time.Second) // Default: 60s, Max: 10min
+	}
+	expiresAt := time.Now().Add(duration.AsDuration())
+
+	entry, err := sm.registry.Get(req.Msg.AgentId)
+	if err != nil {
+		return connect.NewResponse(&debugpb.CaptureTlsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent not found: %v", err),
+			ErrorInfo: &errorsv1.ErrorInfo{
+				Code:     errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND,
+				Metadata: map[string]string{"agent_id": req.Msg.AgentId},
+			},
+		}), nil
+	}
+
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.CaptureTlsResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, sm.db, sm.logger, req.Msg.ServiceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.CaptureTlsResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	agentClient := sm.clientFactory(
+		http.DefaultClient,
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
+	)
+
+	// Records are pushed as soon as the capture starts.
+	sm.startingMu.Lock()
+	sm.starting[sessionID] = req.Msg.AgentId
+	sm.startingMu.Unlock()
+	defer func() {
+		sm.startingMu.Lock()
+		delete(sm.starting, sessionID)
+		sm.startingMu.Unlock()
+	}()
+
+	startResp, err := agentClient.StartTlsCapture(ctx, connect.NewRequest(&agentv1.StartTlsCaptureRequest{
+		AgentId:      req.Msg.AgentId,
+		ServiceName:  req.Msg.ServiceName,
+		Library:      req.Msg.Library,
+		Match:        req.Msg.Match,
+		MaxDataBytes: req.Msg.MaxDataBytes,
+		Duration:     duration,
+		SessionId:    sessionID,
+	}))
+	if err != nil {
+		sm.logger.Error().Err(err).
+			Str("agent_id", req.Msg.AgentId).
+			Msg("Failed to start TLS capture on agent")
+		return connect.NewResponse(&debugpb.CaptureTlsResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to start TLS capture: %v", err),
+			ErrorInfo: coralerrors.Info(coralerrors.FromAgent(req.Msg.AgentId, err)),
+		}), nil
+	}
+
+	if !startResp.Msg.Supported || startResp.Msg.Error != "" {
+		return connect.NewResponse(&debugpb.CaptureTlsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent failed to start TLS capture: %s", startResp.Msg.Error),
+		}), nil
+	}
+
+	library := req.Msg.Library
+	if library == "" {
+		library = "auto"
+	}
+	functionName := "tls:" + library
+	if req.Msg.Match != "" {
+		functionName += "/" + req.Msg.Match
+	}
+
+	session := &database.DebugSession{
+		SessionID:    sessionID,
+		CollectorID:  startResp.Msg.CollectorId,
+		ServiceName:  req.Msg.ServiceName,
+		FunctionName: functionName,
+		AgentID:      req.Msg.AgentId,
+		StartedAt:    time.Now(),
+		ExpiresAt:    expiresAt,
+		Status:       "active",
+	}
+	if err := sm.db.InsertDebugSession(ctx, session); err != nil {
+		sm.logger.Error().Err(err).
+			Str("session_id", sessionID).
+			Msg("Failed to insert debug session into database")
+		return connect.NewResponse(&debugpb.CaptureTlsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("failed to store session: %v", err),
+		}), nil
+	}
+
+	sm.logger.Info().
+		Str("session_id", sessionID).
+		Time("expires_at", expiresAt).
+		Msg("TLS capture session created")
+
+	sm.events.Publish(&debugpb.ColonyEvent{
+		Type:        debugpb.ColonyEventType_COLONY_EVENT_TYPE_DEBUG_SESSION_STARTED,
+		AgentId:     session.AgentID,
+		ServiceName: session.ServiceName,
+		SessionId:   sessionID,
+		Message:     fmt.Sprintf("TLS capture started for %s", req.Msg.ServiceName),
+		Attributes: map[string]string{
+			"function":   session.FunctionName,
+			"expires_at": expiresAt.UTC().Format(time.RFC3339),
+		},
+	})
+
+	return connect.NewResponse(&debugpb.CaptureTlsResponse{
+		SessionId: sessionID,
+		ExpiresAt: timestamppb.New(expiresAt),
+		Success:   true,
+	}), nil
+}
+
 // DetachUprobe stops a debug session.
 func (sm *SessionManager) DetachUprobe(
 	ctx context.Context,
@@ -209,54 +598,16 @@ func (sm *SessionManager) DetachUprobe(
 			Msg("Agent not in registry - will mark session as stopped without contacting agent")
 	}
 
-	// Try to fetch and persist events if agent is available.
+	// Stop the collector if the agent is available. The agent pushes the
+	// session's remaining events before acknowledging the stop.
 	if agentAvailable {
 		// Setup agent client.
-		agentAddr := buildAgentAddress(entry.MeshIPv4)
+		agentAddr := buildAgentAddress(entry.MeshIP())
 		agentClient := sm.clientFactory(
 			http.DefaultClient,
 			fmt.Sprintf("http://%s", agentAddr),
 		)
 
-		// Fetch and persist events before stopping collector (RFD 062 - event persistence).
-		queryReq := connect.NewRequest(&agentv1.QueryUprobeEventsRequest{
-			CollectorId: session.CollectorID,
-			StartTime:   timestamppb.New(session.StartedAt),
-			EndTime:     timestamppb.New(time.Now()),
-			MaxEvents:   100000, // Fetch all events
-		})
-
-		queryResp, err := agentClient.QueryUprobeEvents(ctx, queryReq)
-		if err != nil {
-			sm.logger.Warn().Err(err).
-				Str("session_id", req.Msg.SessionId).
-				Str("collector_id", session.CollectorID).
-				Msg("Failed to fetch events before detaching (continuing with detach)")
-			// Continue with detach even if event fetch fails
-		} else {
-			// Events are already UprobeEvents (not wrapped in EbpfEvent).
-			uprobeEvents := queryResp.Msg.Events
-
-			// Persist events to database.
-			if len(uprobeEvents) > 0 {
-				if err := sm.db.InsertDebugEvents(ctx, req.Msg.SessionId, uprobeEvents); err != nil {
-					sm.logger.Error().Err(err).
-						Str("session_id", req.Msg.SessionId).
-						Int("event_count", len(uprobeEvents)).
-						Msg("Failed to persist debug events (continuing with detach)")
-				} else {
-					sm.logger.Info().
-						Str("session_id", req.Msg.SessionId).
-						Int("event_count", len(uprobeEvents)).
-						Msg("Persisted debug events to database")
-				}
-			} else {
-				sm.logger.Debug().
-					Str("session_id", req.Msg.SessionId).
-					Msg("No events to persist from collector")
-			}
-		}
-
 		// Call agent to stop uprobe collector.
 		stopReq := connect.NewRequest(&agentv1.StopUprobeCollectorRequest{
 			CollectorId: session.CollectorID,
@@ -290,6 +641,17 @@ func (sm *SessionManager) DetachUprobe(
 		Str("session_id", req.Msg.SessionId).
 		Msg("Debug session detached")
 
+	sm.events.Publish(&debugpb.ColonyEvent{
+		Type:        debugpb.ColonyEventType_COLONY_EVENT_TYPE_DEBUG_SESSION_STOPPED,
+		AgentId:     session.AgentID,
+		ServiceName: session.ServiceName,
+		SessionId:   session.SessionID,
+		Message:     fmt.Sprintf("Debug session detached from %s", session.FunctionName),
+		Attributes: map[string]string{
+			"function": session.FunctionName,
+		},
+	})
+
 	return connect.NewResponse(&debugpb.DetachUprobeResponse{
 		Success: true,
 	}), nil
@@ -327,7 +689,7 @@ func (sm *SessionManager) UpdateProbeFilter(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %w", err))
 	}
 
-	agentAddr := buildAgentAddress(entry.MeshIPv4)
+	agentAddr := buildAgentAddress(entry.MeshIP())
 	agentClient := sm.clientFactory(
 		http.DefaultClient,
 		fmt.Sprintf("http://%s", agentAddr),
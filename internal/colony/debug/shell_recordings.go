@@ -0,0 +1,186 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+)
+
+// defaultShellRecordingsLimit is the number of recordings listed when the
+// request sets no limit.
+const defaultShellRecordingsLimit = 50
+
+// shellRecordingEvent is the stored form of a recording event; data is
+// base64 encoded by encoding/json.
+type shellRecordingEvent struct {
+	OffsetUs int64  `json:"offset_us"`
+	Type     string `json:"type"`
+	Data     []byte `json:"data"`
+}
+
+// UploadShellRecording stores the recording of a shell or exec session that
+// ended on an agent.
+func (o *Orchestrator) UploadShellRecording(
+	ctx context.Context,
+	req *connect.Request[debugpb.UploadShellRecordingRequest],
+) (*connect.Response[debugpb.UploadShellRecordingResponse], error) {
+	rec := req.Msg.Recording
+	if rec == nil || rec.SessionId == "" || rec.AgentId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("recording with session_id and agent_id is required"))
+	}
+
+	row, err := shellRecordingFromProto(rec)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := o.db.InsertShellRecording(ctx, row); err != nil {
+		o.logger.Error().Err(err).
+			Str("session_id", rec.SessionId).
+			Str("agent_id", rec.AgentId).
+			Msg("Failed to store shell recording")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	o.logger.Info().
+		Str("session_id", rec.SessionId).
+		Str("agent_id", rec.AgentId).
+		Str("kind", rec.Kind).
+		Str("user_id", rec.UserId).
+		Uint64("size_bytes", rec.SizeBytes).
+		Bool("truncated", rec.Truncated).
+		Msg("Shell recording stored")
+
+	return connect.NewResponse(&debugpb.UploadShellRecordingResponse{}), nil
+}
+
+// ListShellRecordings returns stored recordings, newest first, without
+// their events.
+func (o *Orchestrator) ListShellRecordings(
+	ctx context.Context,
+	req *connect.Request[debugpb.ListShellRecordingsRequest],
+) (*connect.Response[debugpb.ListShellRecordingsResponse], error) {
+	filters := database.ShellRecordingFilters{
+		AgentID: req.Msg.AgentId,
+		UserID:  req.Msg.UserId,
+		Limit:   int(req.Msg.Limit),
+	}
+	if req.Msg.Since != nil {
+		filters.Since = req.Msg.Since.AsTime()
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = defaultShellRecordingsLimit
+	}
+
+	rows, err := o.db.ListShellRecordings(ctx, filters)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	resp := &debugpb.ListShellRecordingsResponse{}
+	for _, row := range rows {
+		rec, err := shellRecordingToProto(row)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		resp.Recordings = append(resp.Recordings, rec)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetShellRecording returns a recording with its events.
+func (o *Orchestrator) GetShellRecording(
+	ctx context.Context,
+	req *connect.Request[debugpb.GetShellRecordingRequest],
+) (*connect.Response[debugpb.GetShellRecordingResponse], error) {
+	row, err := o.db.GetShellRecording(ctx, req.Msg.SessionId)
+	if err != nil {
+		if errors.Is(err, database.ErrShellRecordingNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no recording of session %s", req.Msg.SessionId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("database error: %w", err))
+	}
+
+	rec, err := shellRecordingToProto(row)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&debugpb.GetShellRecordingResponse{Recording: rec}), nil
+}
+
+func shellRecordingFromProto(rec *debugpb.ShellRecording) (*database.ShellRecording, error) {
+	command, err := json.Marshal(rec.Command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode command: %w", err)
+	}
+
+	events := make([]shellRecordingEvent, len(rec.Events))
+	for i, e := range rec.Events {
+		events[i] = shellRecordingEvent{OffsetUs: e.OffsetUs, Type: e.Type, Data: e.Data}
+	}
+	eventsJSON, err := json.Marshal(events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode events: %w", err)
+	}
+
+	return &database.ShellRecording{
+		SessionID:     rec.SessionId,
+		AgentID:       rec.AgentId,
+		Kind:          rec.Kind,
+		UserID:        rec.UserId,
+		Command:       string(command),
+		ContainerName: rec.ContainerName,
+		StartedAt:     rec.StartedAt.AsTime(),
+		EndedAt:       rec.EndedAt.AsTime(),
+		ExitCode:      int(rec.ExitCode),
+		Cols:          int(rec.Cols),
+		Rows:          int(rec.Rows),
+		SizeBytes:     int64(rec.SizeBytes), // #nosec G115 -- capped by the agent.
+		Truncated:     rec.Truncated,
+		Events:        string(eventsJSON),
+	}, nil
+}
+
+// shellRecordingToProto converts a stored recording. Events are only set if
+// the row has them.
+func shellRecordingToProto(row *database.ShellRecording) (*debugpb.ShellRecording, error) {
+	rec := &debugpb.ShellRecording{
+		SessionId:     row.SessionID,
+		AgentId:       row.AgentID,
+		Kind:          row.Kind,
+		UserId:        row.UserID,
+		ContainerName: row.ContainerName,
+		StartedAt:     timestamppb.New(row.StartedAt),
+		EndedAt:       timestamppb.New(row.EndedAt),
+		ExitCode:      int32(row.ExitCode),   // #nosec G115 -- exit codes fit in int32.
+		Cols:          uint32(row.Cols),      // #nosec G115 -- terminal sizes are small.
+		Rows:          uint32(row.Rows),      // #nosec G115 -- terminal sizes are small.
+		SizeBytes:     uint64(row.SizeBytes), // #nosec G115 -- sizes are positive.
+		Truncated:     row.Truncated,
+	}
+
+	if row.Command != "" {
+		if err := json.Unmarshal([]byte(row.Command), &rec.Command); err != nil {
+			return nil, fmt.Errorf("failed to parse command of recording %s: %w", row.SessionID, err)
+		}
+	}
+
+	if row.Events != "" {
+		var events []shellRecordingEvent
+		if err := json.Unmarshal([]byte(row.Events), &events); err != nil {
+			return nil, fmt.Errorf("failed to parse events of recording %s: %w", row.SessionID, err)
+		}
+		for _, e := range events {
+			rec.Events = append(rec.Events, &debugpb.ShellRecordingEvent{OffsetUs: e.OffsetUs, Type: e.Type, Data: e.Data})
+		}
+	}
+
+	return rec, nil
+}
@@ -0,0 +1,155 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	debugpb "github.com/coral-mesh/coral/coral/colony/v1"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// TraceSyscalls counts the syscalls of a service for a duration, with their
+// latency and error codes, on the agent running it.
+func (o *Orchestrator) TraceSyscalls(
+	ctx context.Context,
+	req *connect.Request[debugpb.TraceSyscallsRequest],
+) (*connect.Response[debugpb.TraceSyscallsResponse], error) {
+	o.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Int32("duration", req.Msg.DurationSeconds).
+		Strs("syscalls", req.Msg.Syscalls).
+		Msg("Starting syscall trace")
+
+	durationSeconds := req.Msg.DurationSeconds
+	if durationSeconds <= 0 {
+		durationSeconds = 30 // Default 30 seconds
+	}
+	if durationSeconds > 300 {
+		durationSeconds = 300 // Max 5 minutes
+	}
+
+	agentID := req.Msg.AgentId
+	if agentID == "" {
+		var err error
+		agentID, err = o.agentCoordinator.FindAgentForService(ctx, req.Msg.ServiceName)
+		if err != nil {
+			return connect.NewResponse(&debugpb.TraceSyscallsResponse{
+				Success:   false,
+				Error:     fmt.Sprintf("failed to find agent for service %s: %v", req.Msg.ServiceName, err),
+				ErrorInfo: coralerrors.Info(err),
+			}), nil
+		}
+	}
+
+	entry, err := o.registry.Get(agentID)
+	if err != nil {
+		return connect.NewResponse(&debugpb.TraceSyscallsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("agent not found: %v", err),
+			ErrorInfo: &errorsv1.ErrorInfo{
+				Code:     errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND,
+				Metadata: map[string]string{"agent_id": agentID},
+			},
+		}), nil
+	}
+
+	if err := checkNotDrained(entry); err != nil {
+		return connect.NewResponse(&debugpb.TraceSyscallsResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	if err := checkNotFrozen(ctx, o.db, o.logger, req.Msg.ServiceName, req.Msg.OverrideFreeze); err != nil {
+		return connect.NewResponse(&debugpb.TraceSyscallsResponse{
+			Success:   false,
+			Error:     err.Error(),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	targetPID, err := o.agentCoordinator.GetServicePID(ctx, agentID, req.Msg.ServiceName)
+	if err != nil {
+		return connect.NewResponse(&debugpb.TraceSyscallsResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to get service PID: %v", err),
+			ErrorInfo: coralerrors.Info(err),
+		}), nil
+	}
+
+	debugClient := o.clientFactory(
+		http.DefaultClient,
+		fmt.Sprintf("http://%s", buildAgentAddress(entry.MeshIP())),
+	)
+
+	agentTimeout := time.Duration(durationSeconds)*time.Second + profilingTimeout
+	agentCtx, agentCancel := context.WithTimeout(ctx, agentTimeout)
+	defer agentCancel()
+
+	traceResp, err := debugClient.TraceSyscalls(agentCtx, connect.NewRequest(&agentv1.TraceSyscallsAgentRequest{
+		AgentId:         agentID,
+		ServiceName:     req.Msg.ServiceName,
+		Pid:             targetPID,
+		DurationSeconds: durationSeconds,
+		Syscalls:        req.Msg.Syscalls,
+	}))
+	if err != nil {
+		o.logger.Error().Err(err).
+			Str("agent_id", agentID).
+			Str("service", req.Msg.ServiceName).
+			Msg("Failed to trace syscalls on agent")
+		return connect.NewResponse(&debugpb.TraceSyscallsResponse{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to trace syscalls: %v", err),
+			ErrorInfo: coralerrors.Info(coralerrors.FromAgent(agentID, err)),
+		}), nil
+	}
+
+	if !traceResp.Msg.Success {
+		return connect.NewResponse(&debugpb.TraceSyscallsResponse{
+			Success: false,
+			Error:   traceResp.Msg.Error,
+		}), nil
+	}
+
+	trace := traceResp.Msg
+	var calls, failed uint64
+	for _, sc := range trace.Syscalls {
+		calls += sc.Count
+		for _, e := range sc.Errors {
+			failed += e.Count
+		}
+	}
+
+	o.logger.Info().
+		Str("service", req.Msg.ServiceName).
+		Str("target", trace.Target).
+		Uint64("calls", calls).
+		Uint64("errors", failed).
+		Msg("Syscall trace completed")
+
+	o.publishProfilingCompleted(agentID, req.Msg.ServiceName, "", "syscalls", map[string]string{
+		"duration_seconds": fmt.Sprintf("%d", durationSeconds),
+		"syscalls":         strings.Join(req.Msg.Syscalls, ","),
+		"calls":            fmt.Sprintf("%d", calls),
+		"errors":           fmt.Sprintf("%d", failed),
+	})
+
+	return connect.NewResponse(&debugpb.TraceSyscallsResponse{
+		Success:     true,
+		ServiceName: req.Msg.ServiceName,
+		AgentId:     agentID,
+		Target:      trace.Target,
+		StartTime:   trace.StartTime,
+		EndTime:     trace.EndTime,
+		Syscalls:    trace.Syscalls,
+	}), nil
+}
@@ -0,0 +1,84 @@
+package colony
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/poller"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// DebugRollupService periodically runs the debug aggregation pipelines,
+// rolling up debug session events into the per-function, per-minute
+// statistics of debug_function_stats.
+type DebugRollupService struct {
+	*poller.BasePoller
+	db        *database.Database
+	lateness  time.Duration
+	selectors []database.DebugEventSelector
+	logger    zerolog.Logger
+}
+
+// NewDebugRollupService creates a debug event rollup service from the colony
+// debug aggregation config.
+func NewDebugRollupService(
+	ctx context.Context,
+	db *database.Database,
+	cfg config.DebugAggregationConfig,
+	logger zerolog.Logger,
+) *DebugRollupService {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = constants.DefaultDebugAggregationInterval
+	}
+	lateness := cfg.Lateness
+	if lateness <= 0 {
+		lateness = constants.DefaultDebugAggregationLateness
+	}
+
+	selectors := make([]database.DebugEventSelector, 0, len(cfg.Pipelines))
+	for _, p := range cfg.Pipelines {
+		selectors = append(selectors, database.DebugEventSelector{
+			Services:  p.Services,
+			Functions: p.Functions,
+		})
+	}
+
+	componentLogger := logger.With().Str("component", "debug_rollup").Logger()
+
+	base := poller.NewBasePoller(ctx, poller.Config{
+		Name:         "debug_rollup",
+		PollInterval: interval,
+		Logger:       componentLogger,
+	})
+
+	return &DebugRollupService{
+		BasePoller: base,
+		db:         db,
+		lateness:   lateness,
+		selectors:  selectors,
+		logger:     componentLogger,
+	}
+}
+
+// Start begins rolling up debug events.
+func (s *DebugRollupService) Start() error {
+	return s.BasePoller.Start(s)
+}
+
+// PollOnce rolls up the minutes of debug events that are complete.
+// Implements the poller.Poller interface.
+func (s *DebugRollupService) PollOnce(ctx context.Context) error {
+	return s.db.RollupDebugEvents(ctx, time.Now(), s.lateness, s.selectors)
+}
+
+// RunCleanup is a no-op; debug_function_stats is cleaned up by the retention
+// manager.
+// Implements the poller.Poller interface.
+func (s *DebugRollupService) RunCleanup(ctx context.Context) error {
+	return nil
+}
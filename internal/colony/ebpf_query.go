@@ -209,7 +209,9 @@ func (s *EbpfQueryService) querySQLMetrics(ctx context.Context, req *agentv1.Que
 	return allMetrics, nil
 }
 
-func (s *EbpfQueryService) queryTraceSpans(ctx context.Context, req *agentv1.QueryEbpfMetricsRequest, startTime, endTime time.Time) ([]*agentv1.EbpfTraceSpan, error) {
+// queryTraceSpans returns the spans of the traces matching req with a span
+// of at least minDurationUs.
+func (s *EbpfQueryService) queryTraceSpans(ctx context.Context, req *agentv1.QueryEbpfMetricsRequest, startTime, endTime time.Time, minDurationUs int64) ([]*agentv1.EbpfTraceSpan, error) {
 	var allSpans []*agentv1.EbpfTraceSpan
 
 	// If trace ID is specified, query by trace ID only (ignore service filter for efficiency).
@@ -219,7 +221,7 @@ func (s *EbpfQueryService) queryTraceSpans(ctx context.Context, req *agentv1.Que
 			maxTraces = 100
 		}
 
-		results, err := s.db.QueryBeylaTraces(ctx, req.TraceId, "", startTime, endTime, 0, maxTraces)
+		results, err := s.db.QueryBeylaTraces(ctx, req.TraceId, "", startTime, endTime, minDurationUs, maxTraces)
 		if err != nil {
 			return nil, err
 		}
@@ -260,7 +262,7 @@ func (s *EbpfQueryService) queryTraceSpans(ctx context.Context, req *agentv1.Que
 			maxTraces = 100
 		}
 
-		results, err := s.db.QueryBeylaTraces(ctx, "", serviceName, startTime, endTime, 0, maxTraces)
+		results, err := s.db.QueryBeylaTraces(ctx, "", serviceName, startTime, endTime, minDurationUs, maxTraces)
 		if err != nil {
 			return nil, err
 		}
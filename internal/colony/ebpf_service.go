@@ -89,7 +89,7 @@ func (s *EbpfQueryService) QueryMetrics(ctx context.Context, startTime, endTime
 
 	// Query traces if requested.
 	if req.IncludeTraces {
-		traceSpans, err := s.queryTraceSpans(ctx, req, startTime, endTime)
+		traceSpans, err := s.queryTraceSpans(ctx, req, startTime, endTime, 0)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query trace spans: %w", err)
 		}
@@ -530,7 +530,7 @@ func (s *EbpfQueryService) QueryUnifiedTraces(ctx context.Context, traceID, serv
 			}
 			return v
 		}(),
-	}, startTime, endTime)
+	}, startTime, endTime, minDurationUs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query eBPF traces: %w", err)
 	}
@@ -547,10 +547,13 @@ func (s *EbpfQueryService) QueryUnifiedTraces(ctx context.Context, traceID, serv
 	// 3. Convert OTLP summaries to synthetic spans.
 	otlpSpans := make([]*agentv1.EbpfTraceSpan, 0)
 	for _, summary := range telemetrySummaries {
-		// Filter by service name if specified
+		// Filter by service name and minimum duration if specified
 		if serviceName != "" && summary.ServiceName != serviceName {
 			continue
 		}
+		if minDurationUs > 0 && int64(summary.P95Ms*1000) < minDurationUs {
+			continue
+		}
 
 		// Filter by trace ID if specified (check sample traces)
 		if traceID != "" {
@@ -603,22 +606,23 @@ func (s *EbpfQueryService) QueryUnifiedTraces(ctx context.Context, traceID, serv
 	mergedSpans = append(mergedSpans, ebpfSpans...)
 	mergedSpans = append(mergedSpans, otlpSpans...)
 
-	// 5. Apply filters.
-	filteredSpans := make([]*agentv1.EbpfTraceSpan, 0)
+	// 5. Limit results to maxTraces traces, keeping all of their spans.
+	if maxTraces <= 0 {
+		return mergedSpans, nil
+	}
+	traces := make(map[string]bool)
+	limitedSpans := make([]*agentv1.EbpfTraceSpan, 0, len(mergedSpans))
 	for _, span := range mergedSpans {
-		// Filter by minimum duration if specified
-		if minDurationUs > 0 && span.DurationUs < minDurationUs {
-			continue
+		if !traces[span.TraceId] {
+			if len(traces) == maxTraces {
+				continue
+			}
+			traces[span.TraceId] = true
 		}
-		filteredSpans = append(filteredSpans, span)
-	}
-
-	// 6. Limit results.
-	if maxTraces > 0 && len(filteredSpans) > maxTraces {
-		filteredSpans = filteredSpans[:maxTraces]
+		limitedSpans = append(limitedSpans, span)
 	}
 
-	return filteredSpans, nil
+	return limitedSpans, nil
 }
 
 // QueryUnifiedMetrics queries metrics from both eBPF and OTLP sources.
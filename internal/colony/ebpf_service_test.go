@@ -57,7 +57,23 @@ func (m *mockDatabase) QueryBeylaTraces(ctx context.Context, traceID, serviceNam
 	if m.queryError != nil {
 		return nil, m.queryError
 	}
-	return m.traceResults, nil
+	if minDurationUs <= 0 {
+		return m.traceResults, nil
+	}
+	// The database selects traces with a span of at least minDurationUs.
+	slowTraces := make(map[string]bool)
+	for _, r := range m.traceResults {
+		if r.DurationUs >= minDurationUs {
+			slowTraces[r.TraceID] = true
+		}
+	}
+	var results []*database.BeylaTraceResult
+	for _, r := range m.traceResults {
+		if slowTraces[r.TraceID] {
+			results = append(results, r)
+		}
+	}
+	return results, nil
 }
 
 func (m *mockDatabase) QueryTelemetrySummaries(ctx context.Context, agentID string, startTime, endTime time.Time) ([]database.TelemetrySummary, error) {
@@ -1482,6 +1498,7 @@ func TestQueryUnifiedTraces_SpanMerging(t *testing.T) {
 		mockDB := &mockDatabase{
 			traceResults: []*database.BeylaTraceResult{
 				{TraceID: "trace-1", SpanID: "span-1", ServiceName: "api", SpanName: "op1", SpanKind: "server", StartTime: startTime, DurationUs: 1000},
+				{TraceID: "trace-1", SpanID: "span-1b", ParentSpanID: "span-1", ServiceName: "db", SpanName: "query", SpanKind: "client", StartTime: startTime, DurationUs: 500},
 				{TraceID: "trace-2", SpanID: "span-2", ServiceName: "api", SpanName: "op2", SpanKind: "server", StartTime: startTime, DurationUs: 2000},
 				{TraceID: "trace-3", SpanID: "span-3", ServiceName: "api", SpanName: "op3", SpanKind: "server", StartTime: startTime, DurationUs: 3000},
 			},
@@ -1493,10 +1510,13 @@ func TestQueryUnifiedTraces_SpanMerging(t *testing.T) {
 		service := &EbpfQueryService{db: mockDB}
 		ctx := context.Background()
 
-		// Limit to 2 spans
+		// Limit to 2 traces, keeping all of their spans
 		spans, err := service.QueryUnifiedTraces(ctx, "", "", startTime, endTime, 0, 2)
 		require.NoError(t, err)
-		assert.Len(t, spans, 2)
+		assert.Len(t, spans, 3)
+		for _, span := range spans {
+			assert.Contains(t, []string{"trace-1", "trace-2"}, span.TraceId)
+		}
 	})
 
 	t.Run("continues without OTLP if unavailable", func(t *testing.T) {
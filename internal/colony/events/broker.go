@@ -0,0 +1,160 @@
+// Package events provides the colony event broker, which fans out agent,
+// service, debug session and profiling events to streaming subscribers.
+package events
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Filter selects the events delivered to a subscription. Zero values match
+// everything.
+type Filter struct {
+	Types       []colonyv1.ColonyEventType
+	ServiceName string
+	AgentID     string
+}
+
+// Matches reports whether the event passes the filter.
+func (f Filter) Matches(event *colonyv1.ColonyEvent) bool {
+	if len(f.Types) > 0 && !slices.Contains(f.Types, event.Type) {
+		return false
+	}
+	if f.ServiceName != "" && event.ServiceName != f.ServiceName {
+		return false
+	}
+	if f.AgentID != "" && event.AgentId != f.AgentID {
+		return false
+	}
+	return true
+}
+
+// Subscription receives the events matching its filter.
+type Subscription struct {
+	filter  Filter
+	events  chan *colonyv1.ColonyEvent
+	recent  []*colonyv1.ColonyEvent
+	dropped atomic.Uint64
+}
+
+// Recent returns the events from the broker history matching the filter, as
+// of the time of subscribing. Events on the channel follow them without gaps.
+func (s *Subscription) Recent() []*colonyv1.ColonyEvent {
+	return s.recent
+}
+
+// Events returns the channel of events. It is closed when the subscription
+// is cancelled.
+func (s *Subscription) Events() <-chan *colonyv1.ColonyEvent {
+	return s.events
+}
+
+// Dropped returns the number of events dropped because the subscriber fell
+// behind.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Broker fans out published events to subscribers and keeps a bounded
+// history of recent events. Publishing never blocks: events are dropped for
+// subscribers whose buffer is full. A nil Broker discards all events.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[*Subscription]struct{}
+	history     []*colonyv1.ColonyEvent
+	historySize int
+	bufferSize  int
+	logger      zerolog.Logger
+}
+
+// NewBroker creates a new event broker.
+func NewBroker(logger zerolog.Logger) *Broker {
+	return &Broker{
+		subscribers: make(map[*Subscription]struct{}),
+		historySize: constants.DefaultEventHistorySize,
+		bufferSize:  constants.DefaultEventSubscriberBuffer,
+		logger:      logger.With().Str("component", "event_broker").Logger(),
+	}
+}
+
+// Subscribe registers a subscription for the events matching filter.
+func (b *Broker) Subscribe(filter Filter) *Subscription {
+	sub := &Subscription{
+		filter: filter,
+		events: make(chan *colonyv1.ColonyEvent, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, event := range b.history {
+		if filter.Matches(event) {
+			sub.recent = append(sub.recent, event)
+		}
+	}
+	b.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+// Unsubscribe cancels a subscription and closes its event channel.
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	close(sub.events)
+
+	if dropped := sub.Dropped(); dropped > 0 {
+		b.logger.Warn().
+			Uint64("dropped", dropped).
+			Msg("Event subscriber fell behind, events were dropped")
+	}
+}
+
+// SubscriberCount returns the number of active subscriptions.
+func (b *Broker) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// Publish records an event in the history and delivers it to all matching
+// subscribers. The timestamp is set to now when missing.
+func (b *Broker) Publish(event *colonyv1.ColonyEvent) {
+	if b == nil {
+		return
+	}
+	if event.Timestamp == nil {
+		event.Timestamp = timestamppb.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for sub := range b.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
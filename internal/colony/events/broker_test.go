@@ -0,0 +1,93 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestBroker_PublishFiltersSubscribers(t *testing.T) {
+	b := NewBroker(zerolog.Nop())
+
+	all := b.Subscribe(Filter{})
+	api := b.Subscribe(Filter{ServiceName: "api"})
+	disconnects := b.Subscribe(Filter{
+		Types: []colonyv1.ColonyEventType{colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED},
+	})
+	defer b.Unsubscribe(all)
+	defer b.Unsubscribe(api)
+	defer b.Unsubscribe(disconnects)
+
+	b.Publish(&colonyv1.ColonyEvent{
+		Type:        colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_REGISTERED,
+		AgentId:     "agent-1",
+		ServiceName: "api",
+	})
+	b.Publish(&colonyv1.ColonyEvent{
+		Type:    colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED,
+		AgentId: "agent-1",
+	})
+
+	assert.Len(t, all.Events(), 2)
+	assert.Len(t, api.Events(), 1)
+	require.Len(t, disconnects.Events(), 1)
+
+	event := <-disconnects.Events()
+	assert.Equal(t, "agent-1", event.AgentId)
+	assert.NotNil(t, event.Timestamp, "timestamp is set on publish")
+}
+
+func TestBroker_RecentHistory(t *testing.T) {
+	b := NewBroker(zerolog.Nop())
+	b.historySize = 2
+
+	for _, agentID := range []string{"agent-1", "agent-2", "agent-3"} {
+		b.Publish(&colonyv1.ColonyEvent{
+			Type:    colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_CONNECTED,
+			AgentId: agentID,
+		})
+	}
+
+	sub := b.Subscribe(Filter{})
+	defer b.Unsubscribe(sub)
+
+	recent := sub.Recent()
+	require.Len(t, recent, 2)
+	assert.Equal(t, "agent-2", recent[0].AgentId)
+	assert.Equal(t, "agent-3", recent[1].AgentId)
+	assert.Empty(t, sub.Events(), "history is not replayed on the channel")
+
+	filtered := b.Subscribe(Filter{AgentID: "agent-3"})
+	defer b.Unsubscribe(filtered)
+	assert.Len(t, filtered.Recent(), 1)
+}
+
+func TestBroker_SlowSubscriberDropsEvents(t *testing.T) {
+	b := NewBroker(zerolog.Nop())
+	b.bufferSize = 1
+
+	sub := b.Subscribe(Filter{})
+	for i := 0; i < 3; i++ {
+		b.Publish(&colonyv1.ColonyEvent{Type: colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_CONNECTED})
+	}
+
+	assert.Len(t, sub.Events(), 1)
+	assert.Equal(t, uint64(2), sub.Dropped())
+
+	b.Unsubscribe(sub)
+	assert.Equal(t, 0, b.SubscriberCount())
+	<-sub.Events()
+	_, ok := <-sub.Events()
+	assert.False(t, ok, "channel is closed on unsubscribe")
+}
+
+func TestBroker_NilIsNoop(t *testing.T) {
+	var b *Broker
+	assert.NotPanics(t, func() {
+		b.Publish(&colonyv1.ColonyEvent{})
+	})
+}
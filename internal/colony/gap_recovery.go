@@ -482,14 +482,20 @@ func (s *GapRecoveryService) aggregateCPUProfileForRecovery(
 
 	grouped := make(map[sampleKey]*sampleGroup)
 
-	for _, sample := range samples {
-		bucketTime := sample.Timestamp.AsTime().Truncate(time.Minute)
+	// Encode all stacks at once, so each distinct frame is looked up once.
+	stacks := make([][]string, len(samples))
+	for i, sample := range samples {
+		stacks[i] = sample.StackFrames
+	}
+	encoded, err := s.db.EncodeStackFrameBatch(ctx, stacks)
+	if err != nil {
+		s.logger.Warn().Err(err).Int("sample_count", len(samples)).Msg("Failed to encode stack frames during recovery, skipping samples")
+		return nil
+	}
 
-		frameIDs, err := s.db.EncodeStackFrames(ctx, sample.StackFrames)
-		if err != nil {
-			s.logger.Warn().Err(err).Msg("Failed to encode stack frames during recovery, skipping sample")
-			continue
-		}
+	for i, sample := range samples {
+		bucketTime := sample.Timestamp.AsTime().Truncate(time.Minute)
+		frameIDs := encoded[i]
 
 		stackHash := database.ComputeStackHash(frameIDs)
 		key := sampleKey{bucketTime: bucketTime, buildID: sample.BuildId, stackHash: stackHash}
@@ -547,14 +553,20 @@ func (s *GapRecoveryService) aggregateMemoryProfileForRecovery(
 
 	grouped := make(map[sampleKey]*sampleGroup)
 
-	for _, sample := range samples {
-		bucketTime := sample.Timestamp.AsTime().Truncate(time.Minute)
+	// Encode all stacks at once, so each distinct frame is looked up once.
+	stacks := make([][]string, len(samples))
+	for i, sample := range samples {
+		stacks[i] = sample.StackFrames
+	}
+	encoded, err := s.db.EncodeStackFrameBatch(ctx, stacks)
+	if err != nil {
+		s.logger.Warn().Err(err).Int("sample_count", len(samples)).Msg("Failed to encode stack frames during recovery, skipping samples")
+		return nil
+	}
 
-		frameIDs, err := s.db.EncodeStackFrames(ctx, sample.StackFrames)
-		if err != nil {
-			s.logger.Warn().Err(err).Msg("Failed to encode stack frames during recovery, skipping sample")
-			continue
-		}
+	for i, sample := range samples {
+		bucketTime := sample.Timestamp.AsTime().Truncate(time.Minute)
+		frameIDs := encoded[i]
 
 		stackHash := database.ComputeStackHash(frameIDs)
 		key := sampleKey{bucketTime: bucketTime, buildID: sample.BuildId, stackHash: stackHash}
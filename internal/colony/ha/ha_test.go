@@ -0,0 +1,144 @@
+package ha
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func newPrimary(t *testing.T) (*database.Database, *httptest.Server) {
+	t.Helper()
+
+	db, err := database.New(t.TempDir(), "primary", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mux := http.NewServeMux()
+	mux.Handle(SnapshotPath, NewSnapshotHandler(db, zerolog.Nop()))
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return db, srv
+}
+
+func TestStandby_Replicate(t *testing.T) {
+	primaryDB, srv := newPrimary(t)
+	ctx := context.Background()
+
+	require.NoError(t, primaryDB.InsertAuditEntry(ctx, &database.AuditEntry{
+		Actor: "alice", Action: "AttachUprobe", Result: "ok",
+	}))
+
+	storagePath := t.TempDir()
+	standby := NewStandby(Config{
+		PrimaryURL:  srv.URL + "/",
+		StoragePath: storagePath,
+		ColonyID:    "standby",
+	}, zerolog.Nop())
+
+	require.NoError(t, standby.checkPrimary(ctx))
+	require.NoError(t, standby.syncSnapshot(ctx))
+
+	// A second snapshot replaces the first.
+	require.NoError(t, primaryDB.InsertAuditEntry(ctx, &database.AuditEntry{
+		Actor: "bob", Action: "ProfileCPU", Result: "ok",
+	}))
+	require.NoError(t, standby.syncSnapshot(ctx))
+
+	replica, err := database.New(storagePath, "standby", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = replica.Close() }()
+
+	entries, err := replica.ListAuditEntries(ctx, database.AuditFilters{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "bob", entries[0].Actor)
+
+	// Sequences continue after the replicated rows once promoted.
+	require.NoError(t, replica.InsertAuditEntry(ctx, &database.AuditEntry{
+		Actor: "carol", Action: "Shell", Result: "ok",
+	}))
+	entries, err = replica.ListAuditEntries(ctx, database.AuditFilters{Actor: "carol"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, int64(3), entries[0].ID)
+}
+
+func TestStandby_RunFailsOver(t *testing.T) {
+	_, srv := newPrimary(t)
+
+	standby := NewStandby(Config{
+		PrimaryURL:          srv.URL,
+		StoragePath:         t.TempDir(),
+		ColonyID:            "standby",
+		SnapshotInterval:    time.Hour,
+		HealthCheckInterval: 20 * time.Millisecond,
+		FailoverAfter:       100 * time.Millisecond,
+	}, zerolog.Nop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- standby.Run(ctx) }()
+
+	// The primary is healthy: the standby keeps replicating.
+	select {
+	case err := <-done:
+		t.Fatalf("standby returned while primary healthy: %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+	assert.False(t, standby.lastSnapshot.IsZero())
+
+	srv.Close()
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "standby should return nil to promote")
+	case <-ctx.Done():
+		t.Fatal("standby did not fail over")
+	}
+}
+
+func TestStandby_RunCancelled(t *testing.T) {
+	_, srv := newPrimary(t)
+
+	standby := NewStandby(Config{
+		PrimaryURL:  srv.URL,
+		StoragePath: t.TempDir(),
+		ColonyID:    "standby",
+	}, zerolog.Nop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, standby.Run(ctx), context.Canceled)
+}
+
+func TestExtractArchive_RejectsPaths(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../escape.parquet", Mode: 0600, Size: 1}))
+	_, err := tw.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	err = extractArchive(&buf, t.TempDir())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected entry")
+}
@@ -0,0 +1,207 @@
+// Package ha implements colony high availability: a primary colony serves
+// database snapshots, and a standby colony replicates them and takes over
+// when the primary becomes unreachable.
+package ha
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// SnapshotPath is the path the primary colony serves database snapshots on.
+const SnapshotPath = "/ha/snapshot"
+
+// maxSnapshotFileSize bounds a single extracted snapshot file.
+const maxSnapshotFileSize = 64 << 30
+
+// Exporter writes a snapshot of the colony database to a directory.
+type Exporter interface {
+	ExportSnapshot(ctx context.Context, dir string) error
+}
+
+// SnapshotHandler serves gzipped tar archives of database snapshots to
+// standby colonies.
+type SnapshotHandler struct {
+	db     Exporter
+	logger zerolog.Logger
+
+	// mu serializes exports; each one copies the whole database.
+	mu sync.Mutex
+}
+
+// NewSnapshotHandler creates a new snapshot handler.
+func NewSnapshotHandler(db Exporter, logger zerolog.Logger) *SnapshotHandler {
+	return &SnapshotHandler{
+		db:     db,
+		logger: logger.With().Str("component", "ha_snapshot").Logger(),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *SnapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	tmpDir, err := os.MkdirTemp("", "coral-snapshot-*")
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create snapshot directory")
+		http.Error(w, "snapshot failed", http.StatusInternalServerError)
+		return
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	// EXPORT DATABASE requires a directory that does not exist yet.
+	exportDir := filepath.Join(tmpDir, "export")
+	if err := h.db.ExportSnapshot(r.Context(), exportDir); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to export database snapshot")
+		http.Error(w, "snapshot failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	if err := writeArchive(w, exportDir); err != nil {
+		// Headers are already sent; the standby detects the truncated archive.
+		h.logger.Error().Err(err).Msg("Failed to stream database snapshot")
+		return
+	}
+
+	h.logger.Info().
+		Str("remote_addr", r.RemoteAddr).
+		Msg("Served database snapshot to standby")
+}
+
+// FetchSnapshot downloads a database snapshot from the colony at baseURL and
+// extracts it into dir, which must not exist yet. The snapshot can be restored
+// with database.RestoreSnapshot.
+func FetchSnapshot(ctx context.Context, client *http.Client, baseURL, dir string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+SnapshotPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch snapshot: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch snapshot: colony returned %s", resp.Status)
+	}
+
+	if err := os.Mkdir(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	return extractArchive(resp.Body, dir)
+}
+
+// writeArchive writes the files in dir to w as a gzipped tar archive.
+func writeArchive(w io.Writer, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot directory: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		if err := addArchiveFile(tw, filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish snapshot archive: %w", err)
+	}
+	return gz.Close()
+}
+
+// addArchiveFile appends a file to the archive under its base name.
+func addArchiveFile(tw *tar.Writer, path string) error {
+	f, err := os.Open(path) // #nosec G304 -- path is inside the snapshot directory.
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat snapshot file: %w", err)
+	}
+
+	header := &tar.Header{
+		Name:    filepath.Base(path),
+		Mode:    0600,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write snapshot archive header: %w", err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	return nil
+}
+
+// extractArchive extracts a gzipped tar archive written by writeArchive
+// into dir. Only plain files without directory components are accepted.
+func extractArchive(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read snapshot archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || header.Name != filepath.Base(header.Name) || header.Name == ".." {
+			return fmt.Errorf("unexpected entry %q in snapshot archive", header.Name)
+		}
+
+		if err := extractArchiveFile(tr, filepath.Join(dir, header.Name)); err != nil {
+			return err
+		}
+	}
+}
+
+// extractArchiveFile writes the current archive entry to path.
+func extractArchiveFile(tr *tar.Reader, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600) // #nosec G304 -- name validated by caller.
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+
+	if _, err := io.Copy(f, io.LimitReader(tr, maxSnapshotFileSize)); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to extract snapshot file: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,176 @@
+package ha
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Config contains standby replication settings.
+type Config struct {
+	// PrimaryURL is the primary colony's mesh listener, e.g.
+	// http://100.64.0.1:9000.
+	PrimaryURL string
+
+	// StoragePath and ColonyID locate the standby's copy of the database.
+	StoragePath string
+	ColonyID    string
+
+	// Encryption stores the copy encrypted, if set.
+	Encryption *database.Encryption
+
+	SnapshotInterval    time.Duration
+	HealthCheckInterval time.Duration
+	FailoverAfter       time.Duration
+}
+
+// Standby replicates a primary colony's database and detects when the
+// primary fails.
+type Standby struct {
+	cfg    Config
+	client *http.Client
+	logger zerolog.Logger
+
+	lastSnapshot time.Time
+}
+
+// NewStandby creates a new standby. Zero intervals use the defaults.
+func NewStandby(cfg Config, logger zerolog.Logger) *Standby {
+	if cfg.SnapshotInterval <= 0 {
+		cfg.SnapshotInterval = constants.DefaultHASnapshotInterval
+	}
+	if cfg.HealthCheckInterval <= 0 {
+		cfg.HealthCheckInterval = constants.DefaultHAHealthCheckInterval
+	}
+	if cfg.FailoverAfter <= 0 {
+		cfg.FailoverAfter = constants.DefaultHAFailoverAfter
+	}
+	cfg.PrimaryURL = strings.TrimSuffix(cfg.PrimaryURL, "/")
+
+	return &Standby{
+		cfg:    cfg,
+		client: &http.Client{},
+		logger: logger.With().Str("component", "ha_standby").Logger(),
+	}
+}
+
+// Run replicates the primary's database until the primary has been
+// unreachable for FailoverAfter, then returns nil so the caller can promote
+// this colony. It returns the context's error if ctx is cancelled first.
+func (s *Standby) Run(ctx context.Context) error {
+	s.logger.Info().
+		Str("primary_url", s.cfg.PrimaryURL).
+		Dur("snapshot_interval", s.cfg.SnapshotInterval).
+		Dur("failover_after", s.cfg.FailoverAfter).
+		Msg("Running as standby colony")
+
+	lastHealthy := time.Now()
+	s.replicate(ctx)
+
+	snapshotTicker := time.NewTicker(s.cfg.SnapshotInterval)
+	defer snapshotTicker.Stop()
+	healthTicker := time.NewTicker(s.cfg.HealthCheckInterval)
+	defer healthTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-snapshotTicker.C:
+			s.replicate(ctx)
+
+		case <-healthTicker.C:
+			err := s.checkPrimary(ctx)
+			if err == nil {
+				lastHealthy = time.Now()
+				continue
+			}
+
+			down := time.Since(lastHealthy)
+			s.logger.Warn().
+				Err(err).
+				Dur("unreachable_for", down).
+				Msg("Primary colony health check failed")
+
+			if down >= s.cfg.FailoverAfter {
+				event := s.logger.Warn().Dur("unreachable_for", down)
+				if s.lastSnapshot.IsZero() {
+					event.Msg("Primary colony unreachable, taking over without a replicated snapshot")
+				} else {
+					event.Time("snapshot_time", s.lastSnapshot).
+						Msg("Primary colony unreachable, taking over from last snapshot")
+				}
+				return nil
+			}
+		}
+	}
+}
+
+// replicate copies the primary's database, logging failures; the previous
+// snapshot is kept if the copy fails.
+func (s *Standby) replicate(ctx context.Context) {
+	start := time.Now()
+	if err := s.syncSnapshot(ctx); err != nil {
+		if ctx.Err() == nil {
+			s.logger.Warn().Err(err).Msg("Failed to replicate primary database")
+		}
+		return
+	}
+
+	s.lastSnapshot = time.Now()
+	s.logger.Info().
+		Dur("duration", time.Since(start)).
+		Msg("Replicated primary database snapshot")
+}
+
+// syncSnapshot downloads a snapshot from the primary and replaces the local
+// database with it.
+func (s *Standby) syncSnapshot(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, constants.DefaultHASnapshotTimeout)
+	defer cancel()
+
+	tmpDir, err := os.MkdirTemp("", "coral-standby-*")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	snapshotDir := filepath.Join(tmpDir, "snapshot")
+	if err := FetchSnapshot(ctx, s.client, s.cfg.PrimaryURL, snapshotDir); err != nil {
+		return err
+	}
+
+	return database.RestoreSnapshot(ctx, snapshotDir, s.cfg.StoragePath, s.cfg.ColonyID, s.cfg.Encryption)
+}
+
+// checkPrimary reports whether the primary's status endpoint is reachable.
+func (s *Standby) checkPrimary(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.HealthCheckInterval)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.cfg.PrimaryURL+"/status", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create health check request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("primary returned %s", resp.Status)
+	}
+	return nil
+}
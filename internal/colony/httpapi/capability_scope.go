@@ -0,0 +1,95 @@
+// Package httpapi provides capability token scope enforcement for the colony's RPC handlers.
+package httpapi
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	coralerrors "github.com/coral-mesh/coral/internal/errors"
+)
+
+// serviceFields are the request fields naming the service a request applies
+// to.
+var serviceFields = []protoreflect.Name{"service_name", "service"}
+
+// CapabilityScopeInterceptor returns a connect interceptor that restricts
+// capability tokens scoped to a service, e.g. "debug:api", to requests for
+// that service. The auth middlewares only check the permission, as the
+// service is part of the request message. Streaming requests are not
+// inspected, so they need a scope covering all services.
+func CapabilityScopeInterceptor() connect.Interceptor {
+	return capabilityScopeInterceptor{}
+}
+
+type capabilityScopeInterceptor struct{}
+
+func (capabilityScopeInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		var service string
+		if msg, ok := req.Any().(proto.Message); ok {
+			service = requestService(msg)
+		}
+		if err := checkCapabilityScope(ctx, req.Spec().Procedure, service); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (capabilityScopeInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (capabilityScopeInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := checkCapabilityScope(ctx, conn.Spec().Procedure, ""); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// checkCapabilityScope rejects a capability token that grants the permission
+// of procedure, but not for service. Other tokens, and permissions the
+// capability does not grant at all, are left to the auth middlewares.
+func checkCapabilityScope(ctx context.Context, procedure, service string) error {
+	token := GetAuthenticatedToken(ctx)
+	if token == nil || token.Capability == nil {
+		return nil
+	}
+
+	perm := GetRequiredPermission(procedure)
+	if !auth.HasPermission(token, perm) || token.Capability.Allows(perm, service) {
+		return nil
+	}
+	if service == "" {
+		return coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED,
+			fmt.Errorf("capability token grants %s only for specific services", perm),
+			"permission", string(perm)))
+	}
+	return coralerrors.ToConnect(coralerrors.New(errorsv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED,
+		fmt.Errorf("capability token does not grant %s for service %q", perm, service),
+		"permission", string(perm), "service", service))
+}
+
+// requestService returns the service a request message applies to, or "".
+func requestService(msg proto.Message) string {
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	for _, name := range serviceFields {
+		fd := fields.ByName(name)
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+			continue
+		}
+		if v := m.Get(fd).String(); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package httpapi
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+)
+
+func TestCapabilityScopeInterceptor(t *testing.T) {
+	const (
+		attach = "/coral.colony.v1.ColonyDebugService/AttachUprobe"
+		query  = "/coral.colony.v1.ColonyService/QueryUnifiedTraces"
+	)
+
+	scoped := (&auth.Capability{ID: "cap-1", Scopes: []auth.Scope{
+		{Permission: auth.PermissionDebug, Service: "api"},
+	}}).APIToken()
+	unscoped := (&auth.Capability{ID: "cap-2", Scopes: []auth.Scope{
+		{Permission: auth.PermissionDebug},
+	}}).APIToken()
+	apiToken := &auth.APIToken{TokenID: "bob-debug", Role: auth.RoleDebugger}
+
+	tests := []struct {
+		name      string
+		token     *auth.APIToken
+		procedure string
+		service   string
+		wantErr   bool
+	}{
+		{"no token", nil, attach, "web", false},
+		{"API token", apiToken, attach, "web", false},
+		{"scoped service", scoped, attach, "api", false},
+		{"other service", scoped, attach, "web", true},
+		{"no service", scoped, attach, "", true},
+		{"permission not granted", scoped, query, "web", false},
+		{"unscoped", unscoped, attach, "web", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.token != nil {
+				ctx = context.WithValue(ctx, TokenContextKey, tt.token)
+			}
+
+			called := false
+			next := connect.UnaryFunc(func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+				called = true
+				return nil, nil
+			})
+			req := &scopedRequest{
+				Request:   connect.NewRequest(&colonyv1.AttachUprobeRequest{ServiceName: tt.service}),
+				procedure: tt.procedure,
+			}
+
+			_, err := CapabilityScopeInterceptor().WrapUnary(next)(ctx, req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && connect.CodeOf(err) != connect.CodePermissionDenied {
+				t.Errorf("code = %v, want %v", connect.CodeOf(err), connect.CodePermissionDenied)
+			}
+			if called == tt.wantErr {
+				t.Errorf("handler called = %v, want %v", called, !tt.wantErr)
+			}
+		})
+	}
+}
+
+// scopedRequest is a request for a given procedure.
+type scopedRequest struct {
+	*connect.Request[colonyv1.AttachUprobeRequest]
+	procedure string
+}
+
+func (r *scopedRequest) Spec() connect.Spec {
+	return connect.Spec{Procedure: r.procedure}
+}
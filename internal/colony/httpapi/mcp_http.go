@@ -0,0 +1,327 @@
+// Package httpapi provides MCP HTTP transports for the HTTP API endpoint (RFD 031).
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/auth"
+)
+
+// maxMCPMessageSize bounds the body of an MCP POST request.
+const maxMCPMessageSize = 1 << 20
+
+// MCPHandler serves MCP over HTTP on a single path, with both transports:
+//
+//   - Streamable HTTP (protocol version 2025-03-26): the client POSTs a
+//     JSON-RPC message or batch and receives the responses in the HTTP
+//     response body. The server assigns no session IDs.
+//   - HTTP+SSE (protocol version 2024-11-05), for older clients: a GET opens
+//     an event stream whose first "endpoint" event gives the URL to POST
+//     messages to; responses are sent back as "message" events.
+type MCPHandler struct {
+	mcpServer MCPServerInterface
+	logger    zerolog.Logger
+
+	mu       sync.Mutex
+	sessions map[string]*mcpSSESession
+}
+
+// mcpSSESession is an open HTTP+SSE event stream.
+type mcpSSESession struct {
+	tokenID  string
+	ctx      context.Context
+	messages chan *MCPResponse
+}
+
+// NewMCPHandler creates a new MCP HTTP handler.
+func NewMCPHandler(mcpServer MCPServerInterface, logger zerolog.Logger) *MCPHandler {
+	return &MCPHandler{
+		mcpServer: mcpServer,
+		logger:    logger.With().Str("handler", "mcp").Logger(),
+		sessions:  make(map[string]*mcpSSESession),
+	}
+}
+
+// MCPRequest represents an MCP JSON-RPC request.
+type MCPRequest struct {
+	// JSONRPC version (should be "2.0").
+	JSONRPC string `json:"jsonrpc"`
+
+	// ID is the request ID. Notifications have none.
+	ID interface{} `json:"id"`
+
+	// Method is the MCP method (e.g., "tools/call", "tools/list").
+	Method string `json:"method"`
+
+	// Params contains the method parameters.
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// MCPResponse represents an MCP response.
+type MCPResponse struct {
+	// JSONRPC version.
+	JSONRPC string `json:"jsonrpc"`
+
+	// ID is the request ID.
+	ID interface{} `json:"id"`
+
+	// Result contains the method result (success case).
+	Result interface{} `json:"result,omitempty"`
+
+	// Error contains the error (failure case).
+	Error *MCPError `json:"error,omitempty"`
+}
+
+// MCPError represents an MCP error.
+type MCPError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ServeHTTP handles MCP requests.
+func (h *MCPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Verify authentication.
+	token := GetAuthenticatedToken(r.Context())
+	if token == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	h.logger.Debug().
+		Str("token_id", token.TokenID).
+		Str("method", r.Method).
+		Msg("MCP request received")
+
+	switch {
+	case r.Method == http.MethodGet:
+		h.handleSSEConnection(w, r, token)
+	case r.Method == http.MethodPost && r.URL.Query().Has("sessionId"):
+		h.handleSSEMessage(w, r, token)
+	case r.Method == http.MethodPost:
+		h.handleStreamableHTTP(w, r, token)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleStreamableHTTP handles a Streamable HTTP POST. Notifications are
+// acknowledged with 202 Accepted; requests are answered in the body, as a
+// batch when the client sent one.
+func (h *MCPHandler) handleStreamableHTTP(w http.ResponseWriter, r *http.Request, token *auth.APIToken) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMCPMessageSize))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+
+	var requests []MCPRequest
+	if batch {
+		err = json.Unmarshal(body, &requests)
+	} else {
+		requests = make([]MCPRequest, 1)
+		err = json.Unmarshal(body, &requests[0])
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		h.sendJSONResponse(w, &MCPResponse{
+			JSONRPC: "2.0",
+			Error:   &MCPError{Code: -32700, Message: "Parse error: " + err.Error()},
+		})
+		return
+	}
+
+	credential := bearerToken(r)
+	var responses []*MCPResponse
+	for i := range requests {
+		if resp := h.dispatch(r.Context(), token, credential, &requests[i]); resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+
+	switch {
+	case len(responses) == 0:
+		w.WriteHeader(http.StatusAccepted)
+	case batch:
+		h.sendJSONResponse(w, responses)
+	default:
+		h.sendJSONResponse(w, responses[0])
+	}
+}
+
+// handleSSEConnection opens an HTTP+SSE event stream.
+func (h *MCPHandler) handleSSEConnection(w http.ResponseWriter, r *http.Request, token *auth.APIToken) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		return
+	}
+
+	sessionID, err := newMCPSessionID()
+	if err != nil {
+		http.Error(w, "failed to create session", http.StatusInternalServerError)
+		return
+	}
+
+	ctx := r.Context()
+	session := &mcpSSESession{
+		tokenID:  token.TokenID,
+		ctx:      ctx,
+		messages: make(chan *MCPResponse, 16),
+	}
+	h.mu.Lock()
+	h.sessions[sessionID] = session
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.sessions, sessionID)
+		h.mu.Unlock()
+	}()
+
+	// Set SSE headers.
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering.
+
+	h.logger.Info().
+		Str("token_id", token.TokenID).
+		Str("session_id", sessionID).
+		Msg("MCP SSE connection established")
+
+	// Tell the client where to POST its messages.
+	h.sendEvent(w, flusher, "endpoint", r.URL.Path+"?sessionId="+sessionID)
+
+	// Keep connection alive with periodic pings until client disconnects.
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Debug().
+				Str("token_id", token.TokenID).
+				Str("session_id", sessionID).
+				Msg("MCP SSE connection closed")
+			return
+		case resp := <-session.messages:
+			payload, err := json.Marshal(resp)
+			if err != nil {
+				h.logger.Error().Err(err).Msg("Failed to encode MCP response")
+				continue
+			}
+			h.sendEvent(w, flusher, "message", string(payload))
+		case <-ticker.C:
+			h.sendEvent(w, flusher, "ping", fmt.Sprintf(`{"timestamp":%d}`, time.Now().Unix()))
+		}
+	}
+}
+
+// handleSSEMessage accepts a message for an open HTTP+SSE stream. The
+// response is sent on the stream, so the request is handled in the
+// background and bound to the stream's lifetime.
+func (h *MCPHandler) handleSSEMessage(w http.ResponseWriter, r *http.Request, token *auth.APIToken) {
+	h.mu.Lock()
+	session, ok := h.sessions[r.URL.Query().Get("sessionId")]
+	h.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown MCP session", http.StatusNotFound)
+		return
+	}
+	// A session can only be used with the token that opened it.
+	if session.tokenID != token.TokenID {
+		http.Error(w, "Forbidden: MCP session belongs to another token", http.StatusForbidden)
+		return
+	}
+
+	var request MCPRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxMCPMessageSize)).Decode(&request); err != nil {
+		http.Error(w, "Parse error: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	credential := bearerToken(r)
+	w.WriteHeader(http.StatusAccepted)
+
+	go func() {
+		resp := h.dispatch(session.ctx, token, credential, &request)
+		if resp == nil {
+			return
+		}
+		select {
+		case session.messages <- resp:
+		case <-session.ctx.Done():
+		}
+	}()
+}
+
+// dispatch hands a request to the MCP server. Notifications get no response.
+func (h *MCPHandler) dispatch(
+	ctx context.Context,
+	token *auth.APIToken,
+	credential string,
+	request *MCPRequest,
+) *MCPResponse {
+	if request.ID == nil {
+		return nil
+	}
+
+	h.logger.Debug().
+		Str("token_id", token.TokenID).
+		Str("method", request.Method).
+		Interface("id", request.ID).
+		Msg("MCP request dispatched")
+
+	return h.mcpServer.HandleMCPRequest(ctx, token, credential, request)
+}
+
+// sendEvent sends an SSE event.
+func (h *MCPHandler) sendEvent(w http.ResponseWriter, flusher http.Flusher, event, data string) {
+	_, _ = fmt.Fprintf(w, "event: %s\n", event)
+	if data != "" {
+		_, _ = fmt.Fprintf(w, "data: %s\n", data)
+	}
+	_, _ = fmt.Fprint(w, "\n")
+	flusher.Flush()
+}
+
+// sendJSONResponse sends a JSON response.
+func (h *MCPHandler) sendJSONResponse(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode MCP response")
+	}
+}
+
+// bearerToken returns the bearer token of the request's Authorization header.
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return token
+}
+
+// newMCPSessionID returns a random HTTP+SSE session ID.
+func newMCPSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
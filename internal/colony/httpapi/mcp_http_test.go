@@ -0,0 +1,164 @@
+package httpapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/auth"
+)
+
+// echoMCPServer answers every request with the caller's token ID and credential.
+type echoMCPServer struct{}
+
+func (echoMCPServer) HandleMCPRequest(_ context.Context, token *auth.APIToken, credential string, req *MCPRequest) *MCPResponse {
+	return &MCPResponse{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  map[string]string{"method": req.Method, "token": token.TokenID, "credential": credential},
+	}
+}
+
+// newTestMCPServer serves an MCPHandler, authenticating "Bearer <id>" as token <id>.
+func newTestMCPServer(t *testing.T) *httptest.Server {
+	handler := NewMCPHandler(echoMCPServer{}, zerolog.Nop())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := bearerToken(r); id != "" {
+			r = r.WithContext(context.WithValue(r.Context(), TokenContextKey, &auth.APIToken{TokenID: id}))
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func postMCP(t *testing.T, url, token, body string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestMCPHandler_StreamableHTTP(t *testing.T) {
+	srv := newTestMCPServer(t)
+
+	tests := []struct {
+		name       string
+		token      string
+		body       string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "unauthenticated",
+			body:       `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "request",
+			token:      "tok-1",
+			body:       `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `{"jsonrpc":"2.0","id":1,"result":{"credential":"tok-1","method":"tools/list","token":"tok-1"}}`,
+		},
+		{
+			name:       "batch skips notifications",
+			token:      "tok-1",
+			body:       `[{"jsonrpc":"2.0","method":"notifications/initialized"},{"jsonrpc":"2.0","id":"a","method":"prompts/list"}]`,
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"jsonrpc":"2.0","id":"a","result":{"credential":"tok-1","method":"prompts/list","token":"tok-1"}}]`,
+		},
+		{
+			name:       "notification only",
+			token:      "tok-1",
+			body:       `{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "parse error",
+			token:      "tok-1",
+			body:       `{"jsonrpc":`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := postMCP(t, srv.URL+"/mcp", tt.token, tt.body)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantBody != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestMCPHandler_SSE(t *testing.T) {
+	srv := newTestMCPServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/mcp", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer tok-1")
+	stream, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = stream.Body.Close() }()
+	require.Equal(t, "text/event-stream", stream.Header.Get("Content-Type"))
+
+	events := bufio.NewReader(stream.Body)
+	readEvent := func() (string, string) {
+		var event, data string
+		for {
+			line, err := events.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case line == "":
+				return event, data
+			case strings.HasPrefix(line, "event: "):
+				event = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+
+	event, endpoint := readEvent()
+	require.Equal(t, "endpoint", event)
+	require.True(t, strings.HasPrefix(endpoint, "/mcp?sessionId="), endpoint)
+
+	// The session can only be used by the token that opened it.
+	resp := postMCP(t, srv.URL+endpoint, "tok-2", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = postMCP(t, srv.URL+"/mcp?sessionId=unknown", "tok-1", `{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = postMCP(t, srv.URL+endpoint, "tok-1", `{"jsonrpc":"2.0","id":7,"method":"tools/list"}`)
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	event, data := readEvent()
+	require.Equal(t, "message", event)
+	var msg MCPResponse
+	require.NoError(t, json.Unmarshal([]byte(data), &msg))
+	assert.Equal(t, float64(7), msg.ID)
+	assert.Equal(t, map[string]interface{}{"credential": "tok-1", "method": "tools/list", "token": "tok-1"}, msg.Result)
+}
@@ -0,0 +1,109 @@
+// Package httpapi provides action RBAC middleware for the colony mesh listener.
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/auth"
+)
+
+// ActionRBACMiddleware enforces RBAC on the colony's mesh listener. Mesh
+// peers keep unauthenticated access to status and query procedures, but
+// administrative procedures always need a Bearer token whose role grants
+// them. When mcp.security.require_rbac_for_actions is set, so do procedures
+// that require an action permission (analysis, probes, profiling).
+type ActionRBACMiddleware struct {
+	tokenStore     *auth.TokenStore
+	requireActions bool
+	logger         zerolog.Logger
+}
+
+// NewActionRBACMiddleware creates a new action RBAC middleware, requiring
+// tokens for actions too if requireActions is set.
+func NewActionRBACMiddleware(store *auth.TokenStore, requireActions bool, logger zerolog.Logger) *ActionRBACMiddleware {
+	return &ActionRBACMiddleware{
+		tokenStore:     store,
+		requireActions: requireActions,
+		logger:         logger.With().Str("middleware", "action_rbac").Logger(),
+	}
+}
+
+// Handler wraps an http.Handler with action RBAC checks.
+func (m *ActionRBACMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Authenticate any presented token so handlers can see the caller's
+		// identity, even for procedures that do not require one.
+		var token *auth.APIToken
+		if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+			parts := strings.SplitN(authHeader, " ", 2)
+			if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+				http.Error(w, "Unauthorized: invalid Authorization format (expected 'Bearer <token>')", http.StatusUnauthorized)
+				return
+			}
+
+			stored, err := m.tokenStore.ValidateToken(parts[1])
+			if err != nil {
+				m.logger.Warn().
+					Str("path", r.URL.Path).
+					Str("remote_addr", r.RemoteAddr).
+					Msg("Invalid token")
+				http.Error(w, "Unauthorized: invalid token", http.StatusUnauthorized)
+				return
+			}
+			token = stored
+			r = r.WithContext(context.WithValue(r.Context(), TokenContextKey, token))
+		}
+
+		requiredPerm := GetRequiredPermission(r.URL.Path)
+		if !m.requiresToken(requiredPerm) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if token == nil {
+			m.logger.Warn().
+				Str("path", r.URL.Path).
+				Str("remote_addr", r.RemoteAddr).
+				Str("required_permission", string(requiredPerm)).
+				Msg("Procedure requested without a token")
+			http.Error(w, "Unauthorized: this procedure requires an API token (set CORAL_API_TOKEN)", http.StatusUnauthorized)
+			return
+		}
+
+		if !auth.HasPermission(token, requiredPerm) {
+			m.logger.Warn().
+				Str("token_id", token.TokenID).
+				Str("user", token.User).
+				Str("role", string(token.Role)).
+				Str("path", r.URL.Path).
+				Str("required_permission", string(requiredPerm)).
+				Msg("Permission denied")
+			http.Error(w, "Forbidden: insufficient permissions", http.StatusForbidden)
+			return
+		}
+
+		m.logger.Debug().
+			Str("token_id", token.TokenID).
+			Str("path", r.URL.Path).
+			Str("permission", string(requiredPerm)).
+			Msg("Action permitted")
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requiresToken reports whether procedures requiring perm need a token.
+func (m *ActionRBACMiddleware) requiresToken(perm auth.Permission) bool {
+	return perm == auth.PermissionAdmin || (m.requireActions && IsActionPermission(perm))
+}
+
+// RequireActionRBAC creates an action RBAC middleware.
+// This is a convenience function.
+func RequireActionRBAC(store *auth.TokenStore, requireActions bool, logger zerolog.Logger) func(http.Handler) http.Handler {
+	mw := NewActionRBACMiddleware(store, requireActions, logger)
+	return mw.Handler
+}
@@ -3,9 +3,11 @@ package httpapi
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"strings"
 
+	"connectrpc.com/connect"
 	"github.com/rs/zerolog"
 
 	"github.com/coral-mesh/coral/internal/auth"
@@ -89,6 +91,22 @@ func GetAuthenticatedToken(ctx context.Context) *auth.APIToken {
 	return token
 }
 
+// RequirePermission returns a permission denied error unless the request was
+// authenticated with a token granting perm. Handlers use it for procedures
+// that must never run for tokenless callers, whatever the listener.
+func RequirePermission(ctx context.Context, perm auth.Permission) error {
+	token := GetAuthenticatedToken(ctx)
+	if token == nil {
+		return connect.NewError(connect.CodePermissionDenied,
+			fmt.Errorf("this operation requires an API token with %s permission (set CORAL_API_TOKEN)", perm))
+	}
+	if !auth.HasPermission(token, perm) {
+		return connect.NewError(connect.CodePermissionDenied,
+			fmt.Errorf("this operation requires %s permission", perm))
+	}
+	return nil
+}
+
 // RequireAuth creates an auth middleware that requires authentication.
 // This is a convenience function that combines NewAuthMiddleware with Handler.
 func RequireAuth(store *auth.TokenStore, logger zerolog.Logger) func(http.Handler) http.Handler {
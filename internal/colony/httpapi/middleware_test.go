@@ -163,7 +163,7 @@ func TestRBACMiddleware_PermissionGranted(t *testing.T) {
 		},
 		{
 			name:        "admin has all permissions",
-			path:        "/coral.colony.v1.ColonyService/RequestCertificate",
+			path:        "/coral.colony.v1.ColonyService/RevokeCertificate",
 			permissions: []auth.Permission{auth.PermissionAdmin},
 			wantStatus:  http.StatusOK,
 		},
@@ -410,3 +410,74 @@ func TestRateLimitMiddleware_WindowExpiry(t *testing.T) {
 		t.Errorf("After expiry: Status = %d, want %d", rec.Code, http.StatusOK)
 	}
 }
+
+// Action RBAC middleware tests.
+
+func TestActionRBACMiddleware(t *testing.T) {
+	store := auth.NewTokenStore("")
+	viewer, err := store.GenerateUserToken("alice-view", "alice", auth.RoleViewer, "")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	debugger, err := store.GenerateUserToken("bob-debug", "bob", auth.RoleDebugger, "")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	admin, err := store.GenerateUserToken("carol-admin", "carol", auth.RoleAdmin, "")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	const (
+		attach  = "/coral.colony.v1.ColonyDebugService/AttachUprobe"
+		status  = "/coral.colony.v1.ColonyService/GetStatus"
+		upgrade = "/coral.colony.v1.ColonyService/UpgradeAgents"
+	)
+
+	tests := []struct {
+		name     string
+		path     string
+		token    string
+		wantCode int
+		wantUser string
+	}{
+		{"status without token", status, "", http.StatusOK, ""},
+		{"status with token", status, viewer.Token, http.StatusOK, "alice"},
+		{"action without token", attach, "", http.StatusUnauthorized, ""},
+		{"action with invalid token", attach, "coral_invalid", http.StatusUnauthorized, ""},
+		{"action as viewer", attach, viewer.Token, http.StatusForbidden, ""},
+		{"action as debugger", attach, debugger.Token, http.StatusOK, "bob"},
+		{"admin without token", upgrade, "", http.StatusUnauthorized, ""},
+		{"admin as debugger", upgrade, debugger.Token, http.StatusForbidden, ""},
+		{"admin as admin", upgrade, admin.Token, http.StatusOK, "carol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedUser string
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if token := GetAuthenticatedToken(r.Context()); token != nil {
+					capturedUser = token.User
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			wrapped := NewActionRBACMiddleware(store, true, zerolog.Nop()).Handler(handler)
+
+			req := httptest.NewRequest("POST", tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+
+			wrapped.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("Status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if capturedUser != tt.wantUser {
+				t.Errorf("User = %q, want %q", capturedUser, tt.wantUser)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@
 package httpapi
 
 import (
+	"strings"
+
 	"github.com/coral-mesh/coral/internal/auth"
 )
 
@@ -9,11 +11,16 @@ import (
 // Methods not in this map default to PermissionStatus.
 var MethodPermissions = map[string]auth.Permission{
 	// Colony status operations (PermissionStatus).
-	"/coral.colony.v1.ColonyService/GetStatus":    auth.PermissionStatus,
-	"/coral.colony.v1.ColonyService/ListAgents":   auth.PermissionStatus,
-	"/coral.colony.v1.ColonyService/GetTopology":  auth.PermissionStatus,
-	"/coral.colony.v1.ColonyService/ListServices": auth.PermissionStatus,
-	"/coral.colony.v1.ColonyService/ListTools":    auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/GetStatus":       auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/ListAgents":      auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/GetAgentHistory": auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/GetTopology":     auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/ListServices":    auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/ListTools":       auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/GetIdentity":     auth.PermissionStatus,
+
+	// Event subscriptions (PermissionStatus).
+	"/coral.colony.v1.ColonyService/SubscribeEvents": auth.PermissionStatus,
 
 	// Query operations (PermissionQuery).
 	"/coral.colony.v1.ColonyService/QueryUnifiedSummary": auth.PermissionQuery,
@@ -24,25 +31,114 @@ var MethodPermissions = map[string]auth.Permission{
 	"/coral.colony.v1.ColonyService/GetServiceActivity":  auth.PermissionQuery,
 	"/coral.colony.v1.ColonyService/ListServiceActivity": auth.PermissionQuery,
 	"/coral.colony.v1.ColonyService/ExecuteQuery":        auth.PermissionQuery,
+	"/coral.colony.v1.ColonyService/QuerySQL":            auth.PermissionQuery,
+	"/coral.colony.v1.ColonyService/CompareDeployments":  auth.PermissionQuery,
+	"/coral.colony.v1.ColonyService/QueryErrors":         auth.PermissionQuery,
+	"/coral.colony.v1.ColonyService/QuerySLO":            auth.PermissionQuery,
+	"/coral.colony.v1.ColonyService/QueryAnomalies":      auth.PermissionQuery,
+	"/coral.colony.v1.ColonyService/TailLogs":            auth.PermissionQuery,
 
 	// MCP tool operations (PermissionAnalyze by default, may vary by tool).
 	"/coral.colony.v1.ColonyService/CallTool":   auth.PermissionAnalyze,
 	"/coral.colony.v1.ColonyService/StreamTool": auth.PermissionAnalyze,
 
-	// Debug operations (PermissionDebug).
-	"/coral.colony.v1.ColonyDebugService/StartSession":      auth.PermissionDebug,
-	"/coral.colony.v1.ColonyDebugService/StopSession":       auth.PermissionDebug,
-	"/coral.colony.v1.ColonyDebugService/AttachProbe":       auth.PermissionDebug,
-	"/coral.colony.v1.ColonyDebugService/DetachProbe":       auth.PermissionDebug,
-	"/coral.colony.v1.ColonyDebugService/GetResults":        auth.PermissionQuery,
-	"/coral.colony.v1.ColonyDebugService/ListSessions":      auth.PermissionQuery,
-	"/coral.colony.v1.ColonyDebugService/StreamEvents":      auth.PermissionDebug,
-	"/coral.colony.v1.ColonyDebugService/ListFunctions":     auth.PermissionQuery,
-	"/coral.colony.v1.ColonyDebugService/UpdateProbeFilter": auth.PermissionDebug, // RFD 090
-
-	// Certificate operations (PermissionAdmin).
-	"/coral.colony.v1.ColonyService/RequestCertificate": auth.PermissionAdmin,
+	// Debug queries (PermissionQuery).
+	"/coral.colony.v1.ColonyDebugService/ListDebugSessions":            auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/GetDebugResults":              auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/QueryUprobeEvents":            auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/QueryFunctions":               auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/DescribeFunction":             auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/QueryHistoricalCPUProfile":    auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/QueryHistoricalMemoryProfile": auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/ListCorrelations":             auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/ListCoreDumps":                auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/ListProfileSchedules":         auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/ListProfileRuns":              auth.PermissionQuery,
+	"/coral.colony.v1.ColonyDebugService/GetProfileRun":                auth.PermissionQuery,
+
+	// Debug actions (PermissionDebug).
+	"/coral.colony.v1.ColonyDebugService/AttachUprobe":           auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/DetachUprobe":           auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/CaptureHttp":            auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/CaptureTls":             auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/TraceRuntime":           auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/TraceSyscalls":          auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/TraceRequestPath":       auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/UpdateProbeFilter":      auth.PermissionDebug, // RFD 090
+	"/coral.colony.v1.ColonyDebugService/IngestUprobeEvents":     auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/ProfileFunctions":       auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/CancelProfileFunctions": auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/ProfileCPU":             auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/ProfileMemory":          auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/ProfileIO":              auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/ProfileLocks":           auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/DeployCorrelation":      auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/RemoveCorrelation":      auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/DownloadCoreDump":       auth.PermissionDebug,
+
+	// Scheduled profiling jobs (PermissionDebug).
+	"/coral.colony.v1.ColonyDebugService/CreateProfileSchedule": auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/DeleteProfileSchedule": auth.PermissionDebug,
+
+	// Shell recordings (agents upload, reading requires PermissionAdmin as
+	// recordings hold everything typed and printed in a session).
+	"/coral.colony.v1.ColonyDebugService/UploadShellRecording": auth.PermissionDebug,
+	"/coral.colony.v1.ColonyDebugService/ListShellRecordings":  auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyDebugService/GetShellRecording":    auth.PermissionAdmin,
+
+	// Audit log (RecordAuditEvent appends, reading requires PermissionAdmin).
+	"/coral.colony.v1.ColonyService/RecordAuditEvent": auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/ListAuditEvents":  auth.PermissionAdmin,
+
+	// MCP tool call approvals (clients hold calls, admins decide).
+	"/coral.colony.v1.ColonyService/CreateMCPApproval": auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/GetMCPApproval":    auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/ListMCPApprovals":  auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/DecideMCPApproval": auth.PermissionAdmin,
+
+	// MCP tool call history (arguments may hold secrets, so reading requires
+	// PermissionAdmin).
+	"/coral.colony.v1.ColonyService/RecordMCPToolCall": auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/ListMCPToolCalls":  auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/GetMCPToolCall":    auth.PermissionAdmin,
+
+	// Alert rules (listing requires PermissionQuery, changes PermissionAdmin).
+	"/coral.colony.v1.ColonyService/ListAlertRules":      auth.PermissionQuery,
+	"/coral.colony.v1.ColonyService/CreateAlertRule":     auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/DeleteAlertRule":     auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/SetAlertRuleEnabled": auth.PermissionAdmin,
+
+	// Certificate operations (PermissionAdmin). Agents request certificates
+	// without a token: the bootstrap referral ticket authenticates them.
+	"/coral.colony.v1.ColonyService/RequestCertificate": auth.PermissionStatus,
 	"/coral.colony.v1.ColonyService/RevokeCertificate":  auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/RevokeAgent":        auth.PermissionAdmin,
+
+	// Colony secret rotation (PermissionAdmin).
+	"/coral.colony.v1.ColonyService/RotateColonySecret": auth.PermissionAdmin,
+
+	// Agent release rollouts (PermissionAdmin).
+	"/coral.colony.v1.ColonyService/UpgradeAgents":   auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/GetAgentUpgrade": auth.PermissionStatus,
+
+	// Bulk agent operations (PermissionAdmin).
+	"/coral.colony.v1.ColonyService/ExecAgents":  auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/DrainAgents": auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/LabelAgents": auth.PermissionAdmin,
+
+	// Service groups (reading requires PermissionStatus, changes PermissionAdmin).
+	"/coral.colony.v1.ColonyService/ListServiceGroups":  auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/GetServiceGroup":    auth.PermissionStatus,
+	"/coral.colony.v1.ColonyService/SetServiceGroup":    auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/DeleteServiceGroup": auth.PermissionAdmin,
+
+	// Service freezes (listing requires PermissionStatus, changes PermissionAdmin).
+	"/coral.colony.v1.ColonyService/FreezeService":      auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/UnfreezeService":    auth.PermissionAdmin,
+	"/coral.colony.v1.ColonyService/ListServiceFreezes": auth.PermissionStatus,
+
+	// Capability tokens (the handler checks the caller grants every scope).
+	"/coral.colony.v1.ColonyService/MintCapabilityToken": auth.PermissionStatus,
 }
 
 // MCPToolPermissions maps MCP tool names to required permissions.
@@ -54,19 +150,20 @@ var MCPToolPermissions = map[string]auth.Permission{
 	"coral_get_status":    auth.PermissionStatus,
 
 	// Query tools (PermissionQuery).
-	"coral_query_summary":       auth.PermissionQuery,
-	"coral_query_traces":        auth.PermissionQuery,
-	"coral_query_metrics":       auth.PermissionQuery,
-	"coral_query_logs":          auth.PermissionQuery,
-	"coral_list_debug_sessions": auth.PermissionQuery,
-	"coral_get_debug_results":   auth.PermissionQuery,
-	"coral_discover_functions":  auth.PermissionQuery,
-
-	// Analyze tools (PermissionAnalyze) - may trigger shell commands.
-	"coral_shell_exec":     auth.PermissionAnalyze,
-	"coral_container_exec": auth.PermissionAnalyze,
-
-	// Debug tools (PermissionDebug) - attach eBPF probes.
+	"coral_query_summary":        auth.PermissionQuery,
+	"coral_query_traces":         auth.PermissionQuery,
+	"coral_query_metrics":        auth.PermissionQuery,
+	"coral_query_logs":           auth.PermissionQuery,
+	"coral_list_debug_sessions":  auth.PermissionQuery,
+	"coral_get_debug_results":    auth.PermissionQuery,
+	"coral_discover_functions":   auth.PermissionQuery,
+	"coral_compare_deployments":  auth.PermissionQuery,
+	"coral_get_service_topology": auth.PermissionQuery,
+	"coral_query_anomalies":      auth.PermissionQuery,
+
+	// Debug tools (PermissionDebug) - run commands or attach eBPF probes.
+	"coral_shell_exec":          auth.PermissionDebug,
+	"coral_container_exec":      auth.PermissionDebug,
 	"coral_attach_uprobe":       auth.PermissionDebug,
 	"coral_detach_uprobe":       auth.PermissionDebug,
 	"coral_trace_request_path":  auth.PermissionDebug,
@@ -75,6 +172,115 @@ var MCPToolPermissions = map[string]auth.Permission{
 	"coral_stop_debug_session":  auth.PermissionDebug,
 }
 
+// CLICommandPermissions maps coral CLI command paths, as run by the MCP
+// coral_cli tool (RFD 100), to required permissions. The longest matching
+// command path wins. Commands not in this map default to PermissionAnalyze.
+var CLICommandPermissions = map[string]auth.Permission{
+	// Status commands (PermissionStatus).
+	"status":  auth.PermissionStatus,
+	"version": auth.PermissionStatus,
+	"colony":  auth.PermissionStatus,
+	"agent":   auth.PermissionStatus,
+
+	// Query commands (PermissionQuery).
+	"query":              auth.PermissionQuery,
+	"duckdb":             auth.PermissionQuery,
+	"debug search":       auth.PermissionQuery,
+	"debug info":         auth.PermissionQuery,
+	"debug describe":     auth.PermissionQuery,
+	"debug session":      auth.PermissionQuery,
+	"debug correlations": auth.PermissionQuery,
+	"debug coredump":     auth.PermissionQuery,
+
+	"profile schedule list": auth.PermissionQuery,
+	"profile schedule runs": auth.PermissionQuery,
+	"profile schedule show": auth.PermissionQuery,
+	"alert rule list":       auth.PermissionQuery,
+	"check":                 auth.PermissionQuery,
+
+	// Debug commands (PermissionDebug) - run commands, attach probes or profile.
+	"shell":         auth.PermissionDebug,
+	"exec":          auth.PermissionDebug,
+	"debug":         auth.PermissionDebug,
+	"profile":       auth.PermissionDebug,
+	"agent debug":   auth.PermissionDebug,
+	"agent profile": auth.PermissionDebug,
+
+	"debug session stop":        auth.PermissionDebug,
+	"debug correlations remove": auth.PermissionDebug,
+	"debug coredump download":   auth.PermissionDebug,
+
+	// Administrative commands (PermissionAdmin).
+	"colony token":          auth.PermissionAdmin,
+	"colony audit":          auth.PermissionAdmin,
+	"colony mcp approvals":  auth.PermissionAdmin,
+	"colony mcp history":    auth.PermissionAdmin,
+	"colony agents upgrade": auth.PermissionAdmin,
+	"colony agents exec":    auth.PermissionDebug,
+	"alert":                 auth.PermissionAdmin,
+}
+
+// RemoteCLICommands are the coral CLI command paths remote MCP clients may
+// run through coral_cli on the colony host. Remote calls run as a
+// subprocess of the colony, so only commands reading colony data are
+// allowed; any other command is denied, whatever the caller's role. Paths
+// are matched exactly, as in the coral binary, e.g. "colony agents" but not
+// "colony agents exec".
+var RemoteCLICommands = map[string]bool{
+	"status":  true,
+	"version": true,
+	"check":   true,
+
+	"colony status":       true,
+	"colony agents":       true,
+	"colony events":       true,
+	"colony service list": true,
+	"colony groups":       true,
+	"colony freeze list":  true,
+	"colony ca status":    true,
+
+	"query anomalies":      true,
+	"query compare":        true,
+	"query cpu-profile":    true,
+	"query errors":         true,
+	"query logs":           true,
+	"query memory-profile": true,
+	"query metrics":        true,
+	"query slo":            true,
+	"query sql":            true,
+	"query summary":        true,
+	"query topology":       true,
+	"query traces":         true,
+
+	"debug search":            true,
+	"debug info":              true,
+	"debug describe":          true,
+	"debug session list":      true,
+	"debug session get":       true,
+	"debug session events":    true,
+	"debug session query":     true,
+	"debug correlations list": true,
+	"debug coredump list":     true,
+
+	"profile schedule list": true,
+	"profile schedule runs": true,
+	"profile schedule show": true,
+	"alert rule list":       true,
+}
+
+// CLICommandTools maps coral CLI command paths to the per-operation MCP tools
+// they replaced (RFD 100), so that per-tool policies such as
+// mcp.security.require_approval also apply to coral_cli calls.
+var CLICommandTools = map[string]string{
+	"shell":              "coral_shell_exec",
+	"exec":               "coral_container_exec",
+	"debug attach":       "coral_attach_uprobe",
+	"debug trace":        "coral_trace_request_path",
+	"debug profile":      "coral_profile_functions",
+	"debug session stop": "coral_stop_debug_session",
+	"colony agents exec": "coral_shell_exec",
+}
+
 // GetRequiredPermission returns the required permission for a method path.
 // Returns PermissionStatus if the method is not explicitly mapped.
 func GetRequiredPermission(method string) auth.Permission {
@@ -92,3 +298,47 @@ func GetMCPToolPermission(toolName string) auth.Permission {
 	}
 	return auth.PermissionAnalyze
 }
+
+// GetCLICommandPermission returns the required permission for a coral CLI
+// invocation. Flags and their values end the command path.
+// Returns PermissionAnalyze if no command path is mapped.
+func GetCLICommandPermission(args []string) auth.Permission {
+	if perm, ok := lookupCommand(CLICommandPermissions, args); ok {
+		return perm
+	}
+	return auth.PermissionAnalyze
+}
+
+// GetCLICommandTool returns the MCP tool a coral CLI invocation corresponds
+// to, or "" if it has none.
+func GetCLICommandTool(args []string) string {
+	tool, _ := lookupCommand(CLICommandTools, args)
+	return tool
+}
+
+// lookupCommand returns the value of the longest command path of args in m.
+// Flags and their values end the command path.
+func lookupCommand[V any](m map[string]V, args []string) (V, bool) {
+	var words []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		words = append(words, arg)
+	}
+
+	for n := len(words); n > 0; n-- {
+		if v, ok := m[strings.Join(words[:n], " ")]; ok {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// IsActionPermission reports whether a permission guards actions that change
+// or inspect running workloads (analysis, probes, profiling, shell and exec)
+// rather than reading colony data.
+func IsActionPermission(perm auth.Permission) bool {
+	return perm == auth.PermissionAnalyze || perm == auth.PermissionDebug
+}
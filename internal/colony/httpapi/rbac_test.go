@@ -2,6 +2,7 @@
 package httpapi
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/coral-mesh/coral/internal/auth"
@@ -25,23 +26,38 @@ func TestGetRequiredPermission(t *testing.T) {
 		{"/coral.colony.v1.ColonyService/QueryUnifiedMetrics", auth.PermissionQuery},
 		{"/coral.colony.v1.ColonyService/QueryUnifiedLogs", auth.PermissionQuery},
 		{"/coral.colony.v1.ColonyService/ExecuteQuery", auth.PermissionQuery},
+		{"/coral.colony.v1.ColonyService/QuerySQL", auth.PermissionQuery},
 
 		// Analyze operations.
 		{"/coral.colony.v1.ColonyService/CallTool", auth.PermissionAnalyze},
 		{"/coral.colony.v1.ColonyService/StreamTool", auth.PermissionAnalyze},
 
 		// Debug operations.
-		{"/coral.colony.v1.ColonyDebugService/StartSession", auth.PermissionDebug},
-		{"/coral.colony.v1.ColonyDebugService/StopSession", auth.PermissionDebug},
-		{"/coral.colony.v1.ColonyDebugService/AttachProbe", auth.PermissionDebug},
-		{"/coral.colony.v1.ColonyDebugService/DetachProbe", auth.PermissionDebug},
-		{"/coral.colony.v1.ColonyDebugService/GetResults", auth.PermissionQuery},
-		{"/coral.colony.v1.ColonyDebugService/ListSessions", auth.PermissionQuery},
-		{"/coral.colony.v1.ColonyDebugService/StreamEvents", auth.PermissionDebug},
+		{"/coral.colony.v1.ColonyDebugService/AttachUprobe", auth.PermissionDebug},
+		{"/coral.colony.v1.ColonyDebugService/DetachUprobe", auth.PermissionDebug},
+		{"/coral.colony.v1.ColonyDebugService/TraceRequestPath", auth.PermissionDebug},
+		{"/coral.colony.v1.ColonyDebugService/ProfileCPU", auth.PermissionDebug},
+		{"/coral.colony.v1.ColonyDebugService/ProfileMemory", auth.PermissionDebug},
+		{"/coral.colony.v1.ColonyDebugService/ProfileFunctions", auth.PermissionDebug},
+		{"/coral.colony.v1.ColonyDebugService/CancelProfileFunctions", auth.PermissionDebug},
+		{"/coral.colony.v1.ColonyDebugService/IngestUprobeEvents", auth.PermissionDebug},
+
+		// Debug queries.
+		{"/coral.colony.v1.ColonyDebugService/GetDebugResults", auth.PermissionQuery},
+		{"/coral.colony.v1.ColonyDebugService/ListDebugSessions", auth.PermissionQuery},
+		{"/coral.colony.v1.ColonyDebugService/QueryUprobeEvents", auth.PermissionQuery},
 
 		// Admin operations.
-		{"/coral.colony.v1.ColonyService/RequestCertificate", auth.PermissionAdmin},
+		{"/coral.colony.v1.ColonyService/RequestCertificate", auth.PermissionStatus},
+		{"/coral.colony.v1.ColonyService/RevokeCertificate", auth.PermissionAdmin},
 		{"/coral.colony.v1.ColonyService/RevokeCertificate", auth.PermissionAdmin},
+		{"/coral.colony.v1.ColonyService/RevokeAgent", auth.PermissionAdmin},
+		{"/coral.colony.v1.ColonyService/RotateColonySecret", auth.PermissionAdmin},
+		{"/coral.colony.v1.ColonyService/UpgradeAgents", auth.PermissionAdmin},
+		{"/coral.colony.v1.ColonyService/GetAgentUpgrade", auth.PermissionStatus},
+		{"/coral.colony.v1.ColonyService/MintCapabilityToken", auth.PermissionStatus},
+		{"/coral.colony.v1.ColonyService/CreateAlertRule", auth.PermissionAdmin},
+		{"/coral.colony.v1.ColonyService/ListAlertRules", auth.PermissionQuery},
 
 		// Unknown methods default to Status.
 		{"/coral.colony.v1.ColonyService/UnknownMethod", auth.PermissionStatus},
@@ -77,11 +93,9 @@ func TestGetMCPToolPermission(t *testing.T) {
 		{"coral_get_debug_results", auth.PermissionQuery},
 		{"coral_discover_functions", auth.PermissionQuery},
 
-		// Analyze tools.
-		{"coral_shell_exec", auth.PermissionAnalyze},
-		{"coral_container_exec", auth.PermissionAnalyze},
-
 		// Debug tools.
+		{"coral_shell_exec", auth.PermissionDebug},
+		{"coral_container_exec", auth.PermissionDebug},
 		{"coral_attach_uprobe", auth.PermissionDebug},
 		{"coral_detach_uprobe", auth.PermissionDebug},
 		{"coral_trace_request_path", auth.PermissionDebug},
@@ -104,6 +118,108 @@ func TestGetMCPToolPermission(t *testing.T) {
 	}
 }
 
+func TestGetCLICommandPermission(t *testing.T) {
+	tests := []struct {
+		args []string
+		want auth.Permission
+	}{
+		{[]string{"status"}, auth.PermissionStatus},
+		{[]string{"colony", "agents"}, auth.PermissionStatus},
+		{[]string{"query", "traces", "api", "--since", "1h"}, auth.PermissionQuery},
+		{[]string{"debug", "session", "list"}, auth.PermissionQuery},
+		{[]string{"debug", "session", "stop", "abc"}, auth.PermissionDebug},
+		{[]string{"debug", "attach", "api", "--function", "main.handle"}, auth.PermissionDebug},
+		{[]string{"profile", "cpu", "--service", "api"}, auth.PermissionDebug},
+		{[]string{"profile", "schedule", "create", "--service", "api"}, auth.PermissionDebug},
+		{[]string{"profile", "schedule", "runs"}, auth.PermissionQuery},
+		{[]string{"shell", "--agent", "agent-1", "--", "ls"}, auth.PermissionDebug},
+		{[]string{"exec", "api", "cat", "/etc/hosts"}, auth.PermissionDebug},
+		{[]string{"colony", "token", "create", "ci"}, auth.PermissionAdmin},
+		{[]string{"colony", "audit", "--since", "24h"}, auth.PermissionAdmin},
+		{[]string{"colony", "mcp", "approvals", "approve", "abc"}, auth.PermissionAdmin},
+		{[]string{"colony", "mcp", "history", "replay", "42"}, auth.PermissionAdmin},
+		{[]string{"colony", "agents", "upgrade", "--version", "v0.5.0"}, auth.PermissionAdmin},
+		{[]string{"alert", "rule", "create", "--name", "slow"}, auth.PermissionAdmin},
+		{[]string{"alert", "rule", "list"}, auth.PermissionQuery},
+		{[]string{"check", "--service", "api", "--exit-code"}, auth.PermissionQuery},
+
+		// Flags end the command path.
+		{[]string{"--colony", "prod", "shell"}, auth.PermissionAnalyze},
+
+		// Unknown commands default to Analyze.
+		{[]string{"run", "script.ts"}, auth.PermissionAnalyze},
+		{nil, auth.PermissionAnalyze},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			got := GetCLICommandPermission(tt.args)
+			if got != tt.want {
+				t.Errorf("GetCLICommandPermission(%q) = %v, want %v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCLICommandTool(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"shell", "--agent", "agent-1", "--", "ls"}, "coral_shell_exec"},
+		{[]string{"exec", "api", "cat", "/etc/hosts"}, "coral_container_exec"},
+		{[]string{"debug", "attach", "api", "--function", "main.handle"}, "coral_attach_uprobe"},
+		{[]string{"debug", "session", "stop", "abc"}, "coral_stop_debug_session"},
+		{[]string{"debug", "session", "list"}, ""},
+		{[]string{"query", "traces", "api"}, ""},
+		{[]string{"--agent", "agent-1", "shell"}, ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			if got := GetCLICommandTool(tt.args); got != tt.want {
+				t.Errorf("GetCLICommandTool(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRBACRoles(t *testing.T) {
+	viewer := &auth.APIToken{TokenID: "viewer", Role: auth.RoleViewer}
+	debugger := &auth.APIToken{TokenID: "debugger", Role: auth.RoleDebugger}
+
+	for _, method := range []string{
+		"/coral.colony.v1.ColonyDebugService/AttachUprobe",
+		"/coral.colony.v1.ColonyDebugService/ProfileCPU",
+	} {
+		required := GetRequiredPermission(method)
+		if auth.HasPermission(viewer, required) {
+			t.Errorf("Viewer should not have access to %q", method)
+		}
+		if !auth.HasPermission(debugger, required) {
+			t.Errorf("Debugger should have access to %q", method)
+		}
+	}
+
+	for _, tool := range []string{"coral_shell_exec", "coral_container_exec"} {
+		required := GetMCPToolPermission(tool)
+		if auth.HasPermission(viewer, required) {
+			t.Errorf("Viewer should not have access to tool %q", tool)
+		}
+		if !auth.HasPermission(debugger, required) {
+			t.Errorf("Debugger should have access to tool %q", tool)
+		}
+	}
+
+	if !auth.HasPermission(viewer, GetRequiredPermission("/coral.colony.v1.ColonyService/QueryUnifiedTraces")) {
+		t.Error("Viewer should have access to queries")
+	}
+	if auth.HasPermission(debugger, GetRequiredPermission("/coral.colony.v1.ColonyService/RevokeCertificate")) {
+		t.Error("Debugger should not have access to admin operations")
+	}
+}
+
 func TestRBACPermissionHierarchy(t *testing.T) {
 	// Verify that admin permission grants access to all operations.
 	adminToken := &auth.APIToken{
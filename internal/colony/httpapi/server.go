@@ -4,6 +4,7 @@ package httpapi
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
@@ -21,13 +22,13 @@ import (
 )
 
 // MCPServerInterface defines the interface for MCP server operations.
-// This allows the httpapi package to work with MCP servers without importing the mcp package.
+// This allows the httpapi package to serve MCP without importing the CLI
+// package that implements it.
 type MCPServerInterface interface {
-	// ListToolNames returns the names of all available MCP tools.
-	ListToolNames() []string
-
-	// ExecuteTool executes an MCP tool by name with the given arguments.
-	ExecuteTool(ctx context.Context, toolName, arguments string) (string, error)
+	// HandleMCPRequest handles a JSON-RPC request on behalf of the caller
+	// authenticated with token. credential is the caller's bearer token, for
+	// work done on its behalf.
+	HandleMCPRequest(ctx context.Context, token *auth.APIToken, credential string, req *MCPRequest) *MCPResponse
 }
 
 // Server is the HTTP API endpoint server for Colony.
@@ -56,8 +57,7 @@ type Config struct {
 	// DebugHandler is the HTTP handler for debug service.
 	DebugHandler http.Handler
 
-	// MCPServer is the MCP server for SSE transport (optional).
-	// Must implement MCPServerInterface.
+	// MCPServer serves MCP over Streamable HTTP and SSE (optional).
 	MCPServer MCPServerInterface
 
 	// TokenStore is the token store for authentication.
@@ -66,6 +66,25 @@ type Config struct {
 	// ColonyDir is the directory path for colony config (for token storage).
 	ColonyDir string
 
+	// MeshPath and MeshHandler serve the mesh service, so that agents can
+	// register over mTLS (optional). Agents authenticate with their client
+	// certificates rather than API tokens.
+	MeshPath    string
+	MeshHandler http.Handler
+
+	// ClientCAs verifies the client certificates agents present (optional).
+	// Clients without a certificate are still accepted.
+	ClientCAs *x509.CertPool
+
+	// VerifyClientCertificate rejects client certificates that verify
+	// against ClientCAs but were revoked since (optional).
+	VerifyClientCertificate func(cert *x509.Certificate) error
+
+	// CRLPath and CRLHandler serve the revocation list of agent certificates
+	// (optional). It is public, like the CA certificates it is signed with.
+	CRLPath    string
+	CRLHandler http.Handler
+
 	// TLSCertificate is the pre-loaded TLS certificate (optional).
 	// If provided, it overrides CertFile/KeyFile in PublicConfig.
 	TLSCertificate *tls.Certificate
@@ -133,19 +152,34 @@ func New(cfg Config) (*Server, error) {
 		logger.Debug().Str("path", cfg.ColonyPath).Msg("Registered colony service handler")
 	}
 
+	if cfg.MeshHandler != nil {
+		mux.Handle(cfg.MeshPath, cfg.MeshHandler)
+		logger.Debug().Str("path", cfg.MeshPath).Msg("Registered mesh service handler")
+	}
+
+	if cfg.CRLHandler != nil {
+		mux.Handle(cfg.CRLPath, cfg.CRLHandler)
+		logger.Debug().Str("path", cfg.CRLPath).Msg("Registered revocation list handler")
+	}
+
 	if cfg.DebugHandler != nil {
 		mux.Handle(cfg.DebugPath, cfg.DebugHandler)
 		logger.Debug().Str("path", cfg.DebugPath).Msg("Registered debug service handler")
 	}
 
-	// Register MCP SSE endpoint if enabled.
+	// Register MCP endpoint if enabled.
 	if cfg.PublicConfig.MCP.Enabled && cfg.MCPServer != nil {
 		mcpPath := cfg.PublicConfig.MCP.Path
 		if mcpPath == "" {
-			mcpPath = constants.DefaultMCPSSEPath
+			mcpPath = constants.DefaultMCPPath
 		}
-		mux.Handle(mcpPath, NewMCPSSEHandler(cfg.MCPServer, tokenStore, logger))
-		logger.Info().Str("path", mcpPath).Msg("MCP SSE endpoint registered")
+		mcpHandler := NewMCPHandler(cfg.MCPServer, logger)
+		mux.Handle(mcpPath, mcpHandler)
+		// Clients configured for earlier releases connect to the old path.
+		if mcpPath == constants.DefaultMCPPath {
+			mux.Handle(constants.LegacyMCPSSEPath, mcpHandler)
+		}
+		logger.Info().Str("path", mcpPath).Msg("MCP endpoint registered")
 	}
 
 	// Register /status endpoint if handler provided.
@@ -203,6 +237,16 @@ func New(cfg Config) (*Server, error) {
 			requireAuth = false
 		}
 
+		// Agents call the mesh service with their client certificate, which
+		// the mesh handlers check, not a token.
+		if cfg.MeshHandler != nil && strings.HasPrefix(r.URL.Path, cfg.MeshPath) {
+			requireAuth = false
+		}
+
+		if cfg.CRLHandler != nil && r.URL.Path == cfg.CRLPath {
+			requireAuth = false
+		}
+
 		if requireAuth {
 			// Apply full middleware chain.
 			// The order MUST be: audit -> auth -> rate -> rbac -> handler
@@ -238,6 +282,7 @@ func New(cfg Config) (*Server, error) {
 			Certificates: []tls.Certificate{*cfg.TLSCertificate},
 			MinVersion:   tls.VersionTLS13,
 		}
+		setClientCAs(httpServer.TLSConfig, cfg.ClientCAs, cfg.VerifyClientCertificate)
 	} else if !isLocalhost {
 		if cfg.PublicConfig.TLS.CertFile == "" || cfg.PublicConfig.TLS.KeyFile == "" {
 			return nil, fmt.Errorf("TLS cert and key required for non-localhost HTTP API endpoint (host=%s)", host)
@@ -252,6 +297,7 @@ func New(cfg Config) (*Server, error) {
 			Certificates: []tls.Certificate{cert},
 			MinVersion:   tls.VersionTLS13,
 		}
+		setClientCAs(httpServer.TLSConfig, cfg.ClientCAs, cfg.VerifyClientCertificate)
 	}
 
 	return &Server{
@@ -263,6 +309,24 @@ func New(cfg Config) (*Server, error) {
 	}, nil
 }
 
+// setClientCAs makes tlsConfig verify the client certificates presented
+// against pool, if set, and with verify, if set.
+func setClientCAs(tlsConfig *tls.Config, pool *x509.CertPool, verify func(*x509.Certificate) error) {
+	if pool == nil {
+		return
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	if verify != nil {
+		tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return nil
+			}
+			return verify(cs.PeerCertificates[0])
+		}
+	}
+}
+
 // Start starts the HTTP API endpoint server in a background goroutine.
 func (s *Server) Start() error {
 	s.logger.Info().
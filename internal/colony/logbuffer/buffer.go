@@ -0,0 +1,219 @@
+// Package logbuffer keeps the most recent log lines of each service reported
+// by agents, and fans out new lines to coral tail --follow streams.
+package logbuffer
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Filter selects log lines. Zero values match everything.
+type Filter struct {
+	Service string
+	AgentID string
+	Pattern *regexp.Regexp
+	Since   time.Time // Only lines after this time.
+}
+
+// Matches reports whether the line passes the filter.
+func (f Filter) Matches(line *colonyv1.LogLine) bool {
+	if f.Service != "" && line.Service != f.Service {
+		return false
+	}
+	if f.AgentID != "" && line.AgentId != f.AgentID {
+		return false
+	}
+	if !f.Since.IsZero() && !line.Timestamp.AsTime().After(f.Since) {
+		return false
+	}
+	if f.Pattern != nil && !f.Pattern.MatchString(line.Line) {
+		return false
+	}
+	return true
+}
+
+// Subscription receives the new lines matching its filter.
+type Subscription struct {
+	filter  Filter
+	lines   chan *colonyv1.LogLine
+	recent  []*colonyv1.LogLine
+	dropped atomic.Uint64
+}
+
+// Recent returns the buffered lines matching the filter as of the time of
+// subscribing, oldest first. Lines on the channel follow them without gaps.
+func (s *Subscription) Recent() []*colonyv1.LogLine {
+	return s.recent
+}
+
+// Lines returns the channel of new lines. It is closed when the subscription
+// is cancelled.
+func (s *Subscription) Lines() <-chan *colonyv1.LogLine {
+	return s.lines
+}
+
+// Dropped returns the number of lines dropped because the subscriber fell
+// behind.
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// ring holds the last lines of a service.
+type ring struct {
+	lines []*colonyv1.LogLine
+	next  int // Index overwritten by the next line once the ring is full.
+}
+
+func (r *ring) add(line *colonyv1.LogLine, capacity int) {
+	if len(r.lines) < capacity {
+		r.lines = append(r.lines, line)
+		return
+	}
+	r.lines[r.next] = line
+	r.next = (r.next + 1) % capacity
+}
+
+// ordered returns the lines oldest first.
+func (r *ring) ordered() []*colonyv1.LogLine {
+	return append(r.lines[r.next:len(r.lines):len(r.lines)], r.lines[:r.next]...)
+}
+
+// Buffer keeps the last lines of each service in a ring and delivers new
+// lines to subscribers. Appending never blocks: lines are dropped for
+// subscribers whose channel is full.
+type Buffer struct {
+	mu          sync.RWMutex
+	rings       map[string]*ring
+	capacity    int
+	subscribers map[*Subscription]struct{}
+	bufferSize  int
+	logger      zerolog.Logger
+}
+
+// New creates a buffer keeping capacity lines per service
+// (default: constants.DefaultLogBufferLines).
+func New(capacity int, logger zerolog.Logger) *Buffer {
+	if capacity <= 0 {
+		capacity = constants.DefaultLogBufferLines
+	}
+	return &Buffer{
+		rings:       make(map[string]*ring),
+		capacity:    capacity,
+		subscribers: make(map[*Subscription]struct{}),
+		bufferSize:  constants.DefaultLogSubscriberBuffer,
+		logger:      logger.With().Str("component", "log_buffer").Logger(),
+	}
+}
+
+// Append adds lines to the rings of their services and delivers them to the
+// matching subscribers.
+func (b *Buffer) Append(lines []*colonyv1.LogLine) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, line := range lines {
+		r := b.rings[line.Service]
+		if r == nil {
+			r = &ring{}
+			b.rings[line.Service] = r
+		}
+		r.add(line, b.capacity)
+
+		for sub := range b.subscribers {
+			if !sub.filter.Matches(line) {
+				continue
+			}
+			select {
+			case sub.lines <- line:
+			default:
+				sub.dropped.Add(1)
+			}
+		}
+	}
+}
+
+// Recent returns the last n buffered lines matching filter, oldest first.
+func (b *Buffer) Recent(filter Filter, n int) []*colonyv1.LogLine {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.recentLocked(filter, n)
+}
+
+// recentLocked returns the last n lines matching filter across services,
+// ordered by timestamp. Caller must hold b.mu.
+func (b *Buffer) recentLocked(filter Filter, n int) []*colonyv1.LogLine {
+	if n <= 0 {
+		return nil
+	}
+
+	var matched []*colonyv1.LogLine
+	for service, r := range b.rings {
+		if filter.Service != "" && service != filter.Service {
+			continue
+		}
+		for _, line := range r.ordered() {
+			if filter.Matches(line) {
+				matched = append(matched, line)
+			}
+		}
+	}
+
+	// Lines of one service are in order already; the stable sort keeps it
+	// for lines with equal timestamps.
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].Timestamp.AsTime().Before(matched[j].Timestamp.AsTime())
+	})
+	if len(matched) > n {
+		matched = matched[len(matched)-n:]
+	}
+	return matched
+}
+
+// Subscribe registers a subscription for the new lines matching filter. Its
+// Recent lines are the last n buffered lines matching filter.
+func (b *Buffer) Subscribe(filter Filter, n int) *Subscription {
+	sub := &Subscription{
+		filter: filter,
+		lines:  make(chan *colonyv1.LogLine, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub.recent = b.recentLocked(filter, n)
+	b.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// SubscriberCount returns the number of active subscriptions.
+func (b *Buffer) SubscriberCount() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// Unsubscribe cancels a subscription and closes its channel.
+func (b *Buffer) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	close(sub.lines)
+
+	if dropped := sub.Dropped(); dropped > 0 {
+		b.logger.Warn().
+			Uint64("dropped", dropped).
+			Msg("Log tail subscriber fell behind, lines were dropped")
+	}
+}
@@ -0,0 +1,106 @@
+package logbuffer
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func logLine(service, text string, at time.Time) *colonyv1.LogLine {
+	return &colonyv1.LogLine{
+		Timestamp: timestamppb.New(at),
+		AgentId:   "agent-1",
+		Service:   service,
+		Source:    "stdout",
+		Line:      text,
+	}
+}
+
+func texts(lines []*colonyv1.LogLine) []string {
+	out := make([]string, 0, len(lines))
+	for _, l := range lines {
+		out = append(out, l.Line)
+	}
+	return out
+}
+
+func TestBuffer_RingKeepsLastLinesPerService(t *testing.T) {
+	b := New(3, zerolog.Nop())
+	start := time.Now()
+
+	for i := 0; i < 5; i++ {
+		b.Append([]*colonyv1.LogLine{logLine("api", fmt.Sprintf("api %d", i), start.Add(time.Duration(i)*time.Second))})
+	}
+	b.Append([]*colonyv1.LogLine{logLine("web", "web 0", start.Add(2500*time.Millisecond))})
+
+	assert.Equal(t, []string{"api 2", "api 3", "api 4"}, texts(b.Recent(Filter{Service: "api"}, 10)))
+	assert.Equal(t, []string{"api 2", "web 0", "api 3", "api 4"}, texts(b.Recent(Filter{}, 10)),
+		"services are merged by timestamp")
+	assert.Equal(t, []string{"api 3", "api 4"}, texts(b.Recent(Filter{}, 2)))
+	assert.Empty(t, b.Recent(Filter{}, 0))
+}
+
+func TestBuffer_Filter(t *testing.T) {
+	b := New(10, zerolog.Nop())
+	now := time.Now()
+	b.Append([]*colonyv1.LogLine{
+		logLine("api", "GET /orders 200", now),
+		logLine("api", "ERROR database timeout", now),
+		logLine("web", "error rendering page", now),
+	})
+
+	errors := Filter{Pattern: regexp.MustCompile(`(?i)error`)}
+	assert.Equal(t, []string{"ERROR database timeout", "error rendering page"}, texts(b.Recent(errors, 10)))
+
+	errors.Service = "api"
+	assert.Equal(t, []string{"ERROR database timeout"}, texts(b.Recent(errors, 10)))
+
+	assert.Empty(t, b.Recent(Filter{AgentID: "agent-2"}, 10))
+
+	b.Append([]*colonyv1.LogLine{logLine("api", "later", now.Add(time.Second))})
+	assert.Equal(t, []string{"later"}, texts(b.Recent(Filter{Since: now}, 10)))
+}
+
+func TestBuffer_Subscribe(t *testing.T) {
+	b := New(10, zerolog.Nop())
+	now := time.Now()
+	b.Append([]*colonyv1.LogLine{logLine("api", "before", now)})
+
+	sub := b.Subscribe(Filter{Service: "api"}, 10)
+	assert.Equal(t, []string{"before"}, texts(sub.Recent()))
+
+	b.Append([]*colonyv1.LogLine{
+		logLine("web", "other service", now),
+		logLine("api", "after", now),
+	})
+	require.Len(t, sub.Lines(), 1)
+	assert.Equal(t, "after", (<-sub.Lines()).Line)
+
+	b.Unsubscribe(sub)
+	_, open := <-sub.Lines()
+	assert.False(t, open, "channel is closed on unsubscribe")
+	b.Unsubscribe(sub) // No-op.
+}
+
+func TestBuffer_SlowSubscriberDropsLines(t *testing.T) {
+	b := New(10, zerolog.Nop())
+	b.bufferSize = 1
+
+	sub := b.Subscribe(Filter{}, 0)
+	defer b.Unsubscribe(sub)
+	for i := 0; i < 3; i++ {
+		b.Append([]*colonyv1.LogLine{logLine("api", "line", time.Now())})
+	}
+
+	assert.Len(t, sub.Lines(), 1)
+	assert.Equal(t, uint64(2), sub.Dropped())
+	assert.Len(t, b.Recent(Filter{}, 10), 3, "the ring keeps lines dropped for subscribers")
+}
@@ -237,17 +237,20 @@ func (p *MemoryProfilePoller) aggregateSamples(ctx context.Context,
 
 	grouped := make(map[sampleKey]*sampleGroup)
 
-	for _, sample := range samples {
-		bucketTime := sample.Timestamp.AsTime().Truncate(time.Minute)
+	// Encode all stacks at once, so each distinct frame is looked up once.
+	stacks := make([][]string, len(samples))
+	for i, sample := range samples {
+		stacks[i] = sample.StackFrames
+	}
+	encoded, err := p.db.EncodeStackFrameBatch(ctx, stacks)
+	if err != nil {
+		p.logger.Warn().Err(err).Int("sample_count", len(samples)).Msg("Failed to encode stack frames, skipping memory samples")
+		return nil
+	}
 
-		frameIDs, err := p.db.EncodeStackFrames(ctx, sample.StackFrames)
-		if err != nil {
-			p.logger.Warn().
-				Err(err).
-				Int("stack_depth", len(sample.StackFrames)).
-				Msg("Failed to encode stack frames, skipping memory sample")
-			continue
-		}
+	for i, sample := range samples {
+		bucketTime := sample.Timestamp.AsTime().Truncate(time.Minute)
+		frameIDs := encoded[i]
 
 		stackHash := database.ComputeStackHash(frameIDs)
 
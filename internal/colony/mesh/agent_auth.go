@@ -0,0 +1,178 @@
+package mesh
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/netip"
+)
+
+// Registration rejection reasons of certificate authentication.
+const (
+	reasonCertificateRequired = "certificate_required"
+	reasonCertificateMismatch = "certificate_mismatch"
+	reasonCertificateRevoked  = "certificate_revoked"
+	reasonAgentRevoked        = "agent_revoked"
+)
+
+// RevocationChecker reports whether an agent certificate, or an agent as a
+// whole, was revoked.
+type RevocationChecker interface {
+	IsCertificateRevoked(ctx context.Context, serialNumber string) (bool, error)
+	IsAgentRevoked(ctx context.Context, agentID string) (bool, error)
+}
+
+type peerCertificateKey struct{}
+
+// WithPeerCertificates passes the verified client certificate of TLS requests
+// on to the mesh handlers, which authenticate agents registering with it.
+// The TLS server must verify client certificates against the agent CA.
+func WithPeerCertificates(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+			ctx := context.WithValue(r.Context(), peerCertificateKey{}, r.TLS.VerifiedChains[0][0])
+			r = r.WithContext(ctx)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// peerCertificate returns the verified client certificate of the request, or
+// nil if the request did not come with one.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	cert, _ := ctx.Value(peerCertificateKey{}).(*x509.Certificate)
+	return cert
+}
+
+// agentSPIFFEID returns the SPIFFE ID of an agent certificate issued by the
+// colony CA.
+func agentSPIFFEID(colonyID, agentID string) string {
+	return fmt.Sprintf("spiffe://coral/colony/%s/agent/%s", colonyID, agentID)
+}
+
+// SetAgentAuth makes registration check the client certificates of agents
+// against revocations. If requireCertificates is set, agents registering
+// without a certificate, i.e. with the colony secret alone, are rejected.
+func (h *Handler) SetAgentAuth(revocations RevocationChecker, requireCertificates bool) {
+	h.revocations = revocations
+	h.requireCertificates = requireCertificates
+}
+
+// authenticateAgent checks the client certificate of a registration for
+// agentID. It returns the reason to reject the registration, or "".
+func (h *Handler) authenticateAgent(ctx context.Context, agentID string) string {
+	cert := peerCertificate(ctx)
+	if cert == nil {
+		if h.requireCertificates {
+			return reasonCertificateRequired
+		}
+		// The colony secret alone does not let a revoked agent back in.
+		if h.revocations != nil {
+			revoked, err := h.revocations.IsAgentRevoked(ctx, agentID)
+			if err != nil {
+				h.logger.Error().
+					Err(err).
+					Str("agent_id", agentID).
+					Msg("Failed to check agent revocation")
+				return reasonAgentRevoked
+			}
+			if revoked {
+				h.logger.Warn().
+					Str("agent_id", agentID).
+					Msg("Agent registration rejected: agent revoked")
+				return reasonAgentRevoked
+			}
+		}
+		return ""
+	}
+
+	want := agentSPIFFEID(h.cfg.ColonyID, agentID)
+	matched := false
+	for _, uri := range cert.URIs {
+		if uri.String() == want {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		h.logger.Warn().
+			Str("agent_id", agentID).
+			Str("serial_number", cert.SerialNumber.String()).
+			Msg("Agent registration rejected: certificate issued to another agent")
+		return reasonCertificateMismatch
+	}
+
+	if h.revocations != nil {
+		revoked, err := h.revocations.IsCertificateRevoked(ctx, cert.SerialNumber.String())
+		if err != nil {
+			// Fail closed: a certificate that cannot be checked may be revoked.
+			h.logger.Error().
+				Err(err).
+				Str("agent_id", agentID).
+				Msg("Failed to check agent certificate revocation")
+			return reasonCertificateRevoked
+		}
+		if revoked {
+			h.logger.Warn().
+				Str("agent_id", agentID).
+				Str("serial_number", cert.SerialNumber.String()).
+				Msg("Agent registration rejected: certificate revoked")
+			return reasonCertificateRevoked
+		}
+	}
+	return ""
+}
+
+// EvictAgent removes a revoked agent from the mesh: from the registry, as a
+// WireGuard peer, and releases its mesh IP.
+func (h *Handler) EvictAgent(ctx context.Context, agentID, message string) error {
+	entry, err := h.registry.Evict(ctx, agentID, message)
+	if err != nil {
+		return err
+	}
+	if h.wgDevice == nil {
+		return nil
+	}
+
+	// Peers are keyed by public key, which the registry does not keep; find
+	// the agent's by its mesh IPs.
+	meshIPs := make(map[string]bool)
+	if entry != nil {
+		for _, ip := range []string{entry.MeshIPv4, entry.MeshIPv6} {
+			if ip != "" {
+				meshIPs[ip] = true
+			}
+		}
+	}
+	allocator := h.wgDevice.Allocator()
+	if allocator != nil {
+		if ip, err := allocator.GetAgentIP(agentID); err == nil {
+			meshIPs[ip.String()] = true
+		}
+	}
+
+	for _, peer := range h.wgDevice.ListPeers() {
+		for _, allowedIP := range peer.AllowedIPs {
+			prefix, err := netip.ParsePrefix(allowedIP)
+			if err != nil || !meshIPs[prefix.Addr().String()] {
+				continue
+			}
+			if err := h.wgDevice.RemovePeer(peer.PublicKey); err != nil {
+				return fmt.Errorf("failed to remove WireGuard peer of agent %s: %w", agentID, err)
+			}
+			h.logger.Info().
+				Str("agent_id", agentID).
+				Str("allowed_ip", allowedIP).
+				Msg("Removed WireGuard peer of evicted agent")
+			break
+		}
+	}
+
+	if allocator != nil {
+		// The agent may have no IP, e.g. if it never registered since the
+		// colony started.
+		_ = allocator.ReleaseByAgent(agentID)
+	}
+	return nil
+}
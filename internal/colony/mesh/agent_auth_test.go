@@ -0,0 +1,164 @@
+package mesh
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/logging"
+	"github.com/coral-mesh/coral/internal/wireguard"
+)
+
+// fakeRevocations holds revoked certificate serial numbers and agent IDs.
+type fakeRevocations map[string]bool
+
+func (f fakeRevocations) IsCertificateRevoked(_ context.Context, serialNumber string) (bool, error) {
+	return f[serialNumber], nil
+}
+
+func (f fakeRevocations) IsAgentRevoked(_ context.Context, agentID string) (bool, error) {
+	return f[agentID], nil
+}
+
+func agentCertificate(t *testing.T, serial int64, spiffeID string) *x509.Certificate {
+	t.Helper()
+	uri, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+	return &x509.Certificate{SerialNumber: big.NewInt(serial), URIs: []*url.URL{uri}}
+}
+
+func TestRegister_AgentCertificates(t *testing.T) {
+	logger := logging.NewWithComponent(logging.Config{Level: "error"}, "agent-auth-test")
+	h := NewHandler(&config.ResolvedConfig{ColonyID: "colony-1"}, nil, registry.New(nil), nil, logger)
+	h.SetAgentAuth(fakeRevocations{"2": true}, true)
+
+	// Registrations are rejected before the WireGuard public key is checked,
+	// so accepted ones end with missing_wireguard_pubkey.
+	tests := []struct {
+		name   string
+		cert   *x509.Certificate
+		reason string
+	}{
+		{"no certificate", nil, reasonCertificateRequired},
+		{"certificate of another agent", agentCertificate(t, 1, "spiffe://coral/colony/colony-1/agent/agent-2"), reasonCertificateMismatch},
+		{"certificate of another colony", agentCertificate(t, 1, "spiffe://coral/colony/colony-2/agent/agent-1"), reasonCertificateMismatch},
+		{"revoked certificate", agentCertificate(t, 2, "spiffe://coral/colony/colony-1/agent/agent-1"), reasonCertificateRevoked},
+		{"valid certificate", agentCertificate(t, 1, "spiffe://coral/colony/colony-1/agent/agent-1"), "missing_wireguard_pubkey"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.cert != nil {
+				ctx = context.WithValue(ctx, peerCertificateKey{}, tt.cert)
+			}
+			resp, err := h.Register(ctx, connect.NewRequest(&meshv1.RegisterRequest{
+				AgentId:  "agent-1",
+				ColonyId: "colony-1",
+			}))
+			require.NoError(t, err)
+			assert.False(t, resp.Msg.Accepted)
+			assert.Equal(t, tt.reason, resp.Msg.Reason)
+		})
+	}
+
+	// Without require_certificates, agents may still register with the
+	// colony secret alone, unless they were revoked.
+	h.SetAgentAuth(fakeRevocations{"agent-2": true}, false)
+	for agentID, reason := range map[string]string{
+		"agent-1": "missing_wireguard_pubkey",
+		"agent-2": reasonAgentRevoked,
+	} {
+		resp, err := h.Register(context.Background(), connect.NewRequest(&meshv1.RegisterRequest{
+			AgentId:  agentID,
+			ColonyId: "colony-1",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, reason, resp.Msg.Reason, agentID)
+	}
+}
+
+func TestEvictAgent(t *testing.T) {
+	logger := logging.NewWithComponent(logging.Config{Level: "error"}, "agent-auth-test")
+	dev, err := wireguard.NewDevice(&config.WireGuardConfig{
+		PrivateKey: "yGzL6W6Q6/lDtb59+dG/F/B3BmVN/D9wUqX/77L1oGE=",
+		PublicKey:  "wGtt3f4A633lH6/gC/g8e1/N2r7M77u5gS1bI9n9AWE=",
+	}, logger)
+	require.NoError(t, err)
+	reg := registry.New(nil)
+	h := NewHandler(&config.ResolvedConfig{ColonyID: "colony-1"}, dev, reg, nil, logger)
+
+	meshIP, err := dev.Allocator().Allocate("agent-1")
+	require.NoError(t, err)
+	_, err = reg.Register("agent-1", "", meshIP.String(), "", nil, nil, "")
+	require.NoError(t, err)
+
+	require.NoError(t, h.EvictAgent(context.Background(), "agent-1", "Agent certificates revoked"))
+
+	_, err = reg.Get("agent-1")
+	assert.Error(t, err, "evicted agents leave the registry")
+	assert.False(t, dev.Allocator().IsAllocated(meshIP), "evicted agents release their mesh IP")
+
+	// Agents that are not registered are evicted without error.
+	require.NoError(t, h.EvictAgent(context.Background(), "agent-2", "Agent certificates revoked"))
+}
+
+func TestWithPeerCertificates(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	uri, err := url.Parse("spiffe://coral/colony/colony-1/agent/agent-1")
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:          big.NewInt(7),
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		URIs:                  []*url.URL{uri},
+	}, &x509.Certificate{SerialNumber: big.NewInt(7)}, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	var got *x509.Certificate
+	srv := httptest.NewUnstartedServer(WithPeerCertificates(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = peerCertificate(r.Context())
+	})))
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	srv.TLS = &tls.Config{ClientCAs: pool, ClientAuth: tls.VerifyClientCertIfGiven}
+	srv.StartTLS()
+	defer srv.Close()
+
+	client := srv.Client()
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Nil(t, got, "requests without a client certificate carry none")
+
+	client.CloseIdleConnections()
+	client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+	}}
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.NotNil(t, got)
+	assert.Equal(t, "7", got.SerialNumber.String())
+}
@@ -0,0 +1,168 @@
+package mesh
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/protocol"
+)
+
+// agentReleaseTTL is the lifetime of the release tokens advertised to
+// agents. Tokens are signed again halfway through, so that a rollout can
+// outlast them.
+const agentReleaseTTL = 24 * time.Hour
+
+// AgentReleaseSigner signs an agent release for ttl, see
+// ca.Manager.IssueAgentReleaseToken.
+type AgentReleaseSigner func(release auth.AgentRelease, ttl time.Duration) (string, error)
+
+// AgentRollout is an agent release rolled out to agents.
+type AgentRollout struct {
+	Version   string
+	Artifacts []auth.AgentArtifact
+
+	// AgentIDs restricts the rollout to these agents; empty for all agents.
+	AgentIDs []string
+
+	StartedAt time.Time
+}
+
+// Targets reports whether the rollout upgrades agentID.
+func (r *AgentRollout) Targets(agentID string) bool {
+	release := r.release()
+	return release.Targets(agentID)
+}
+
+// release returns the release the rollout's tokens sign: only the agents of
+// the rollout accept it.
+func (r *AgentRollout) release() auth.AgentRelease {
+	return auth.AgentRelease{
+		Version:   r.Version,
+		Artifacts: r.Artifacts,
+		AgentIDs:  r.AgentIDs,
+	}
+}
+
+// AgentUpdates holds the agent release rollout the colony advertises to
+// agents in heartbeat responses. Rollouts are not persisted: restarting the
+// colony ends them.
+type AgentUpdates struct {
+	mu       sync.Mutex
+	sign     AgentReleaseSigner
+	rollout  *AgentRollout
+	token    string
+	signedAt time.Time
+}
+
+// NewAgentUpdates creates agent updates signing releases with sign.
+func NewAgentUpdates(sign AgentReleaseSigner) *AgentUpdates {
+	return &AgentUpdates{sign: sign}
+}
+
+// StartRollout replaces the current rollout with one of the release version
+// to agentIDs, or all agents if empty.
+func (u *AgentUpdates) StartRollout(version string, artifacts []auth.AgentArtifact, agentIDs []string) error {
+	rollout := &AgentRollout{
+		Version:   version,
+		Artifacts: slices.Clone(artifacts),
+		AgentIDs:  slices.Clone(agentIDs),
+		StartedAt: time.Now(),
+	}
+	release := rollout.release()
+	if err := release.Validate(); err != nil {
+		return err
+	}
+	token, err := u.sign(release, agentReleaseTTL)
+	if err != nil {
+		return fmt.Errorf("failed to sign agent release: %w", err)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.rollout = rollout
+	u.token, u.signedAt = token, time.Now()
+	return nil
+}
+
+// CancelRollout ends the current rollout. Agents already upgraded keep the
+// release.
+func (u *AgentUpdates) CancelRollout() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.rollout, u.token = nil, ""
+}
+
+// Rollout returns the current rollout; ok is false if there is none.
+func (u *AgentUpdates) Rollout() (rollout AgentRollout, ok bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.rollout == nil {
+		return AgentRollout{}, false
+	}
+	return *u.rollout, true
+}
+
+// Offer returns the update to advertise to agentID running version, or nil
+// if the agent is not part of the rollout or already runs its release.
+func (u *AgentUpdates) Offer(agentID, version string) (*meshv1.AgentUpdate, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.rollout == nil || !u.rollout.Targets(agentID) || version == u.rollout.Version {
+		return nil, nil
+	}
+	if time.Since(u.signedAt) > agentReleaseTTL/2 {
+		token, err := u.sign(u.rollout.release(), agentReleaseTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign agent release: %w", err)
+		}
+		u.token, u.signedAt = token, time.Now()
+	}
+	return &meshv1.AgentUpdate{
+		Version: u.rollout.Version,
+		Token:   u.token,
+	}, nil
+}
+
+// SetAgentUpdates makes heartbeat responses advertise the agent release
+// rollout of updates.
+func (h *Handler) SetAgentUpdates(updates *AgentUpdates) {
+	h.updates = updates
+}
+
+// agentUpdate returns the update to advertise in the heartbeat response to
+// agentID, or nil.
+func (h *Handler) agentUpdate(agentID string) *meshv1.AgentUpdate {
+	if h.updates == nil {
+		return nil
+	}
+	entry, err := h.registry.Get(agentID)
+	if err != nil || !protocol.Supports(entry.Protocol(), protocol.CapabilityAgentUpdates) {
+		return nil
+	}
+	update, err := h.updates.Offer(agentID, entry.Version)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("agent_id", agentID).
+			Msg("Failed to advertise agent release")
+		return nil
+	}
+	return update
+}
+
+// recordVersion stores the version and update error reported in an agent
+// heartbeat and logs when the agent starts failing to install the release.
+func (h *Handler) recordVersion(agentID, version, updateError string) {
+	if entry, err := h.registry.Get(agentID); err == nil && updateError != "" && updateError != entry.UpdateError {
+		h.logger.Warn().
+			Str("agent_id", agentID).
+			Str("error", updateError).
+			Msg("Agent failed to install the agent release")
+	}
+	_ = h.registry.UpdateVersion(agentID, version, updateError)
+}
@@ -0,0 +1,116 @@
+package mesh
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+var testArtifacts = []auth.AgentArtifact{{
+	OS:     "linux",
+	Arch:   "amd64",
+	URL:    "https://example.com/coral-agent-linux-amd64",
+	SHA256: strings.Repeat("ab", 32),
+}}
+
+// fakeSigner signs releases with a token counting the signatures.
+func fakeSigner(signed *int) AgentReleaseSigner {
+	return func(release auth.AgentRelease, _ time.Duration) (string, error) {
+		*signed++
+		return release.Version + "-token", nil
+	}
+}
+
+func TestAgentUpdates_Offer(t *testing.T) {
+	var signed int
+	updates := NewAgentUpdates(fakeSigner(&signed))
+
+	update, err := updates.Offer("agent-1", "v0.4.0")
+	require.NoError(t, err)
+	assert.Nil(t, update, "no rollout")
+
+	assert.Error(t, updates.StartRollout("v0.5.0", nil, nil), "a release needs artifacts")
+	require.NoError(t, updates.StartRollout("v0.5.0", testArtifacts, []string{"agent-1"}))
+	assert.Equal(t, 1, signed)
+
+	update, err = updates.Offer("agent-1", "v0.4.0")
+	require.NoError(t, err)
+	require.NotNil(t, update)
+	assert.Equal(t, "v0.5.0", update.Version)
+	assert.Equal(t, "v0.5.0-token", update.Token)
+
+	update, _ = updates.Offer("agent-1", "v0.5.0")
+	assert.Nil(t, update, "the agent runs the release")
+	update, _ = updates.Offer("agent-2", "v0.4.0")
+	assert.Nil(t, update, "the agent is not part of the rollout")
+
+	// Tokens are signed again before they expire.
+	updates.signedAt = time.Now().Add(-agentReleaseTTL)
+	_, err = updates.Offer("agent-1", "v0.4.0")
+	require.NoError(t, err)
+	assert.Equal(t, 2, signed)
+
+	updates.CancelRollout()
+	update, _ = updates.Offer("agent-1", "v0.4.0")
+	assert.Nil(t, update, "the rollout was cancelled")
+	_, ok := updates.Rollout()
+	assert.False(t, ok)
+}
+
+func TestHeartbeat_AgentUpdate(t *testing.T) {
+	logger := logging.NewWithComponent(logging.Config{Level: "error"}, "agent-update-test")
+	reg := registry.New(nil)
+	h := NewHandler(&config.ResolvedConfig{ColonyID: "colony-1"}, nil, reg, nil, logger)
+
+	var signed int
+	updates := NewAgentUpdates(fakeSigner(&signed))
+	require.NoError(t, updates.StartRollout("v0.5.0", testArtifacts, nil))
+	h.SetAgentUpdates(updates)
+
+	_, err := reg.Register("agent-1", "", "100.64.0.2", "", nil, nil, "2")
+	require.NoError(t, err)
+	_, err = reg.Register("legacy-agent", "", "100.64.0.3", "", nil, nil, "1")
+	require.NoError(t, err)
+
+	resp, err := h.Heartbeat(context.Background(), connect.NewRequest(&meshv1.HeartbeatRequest{
+		AgentId: "legacy-agent",
+		Version: "v0.4.0",
+	}))
+	require.NoError(t, err)
+	assert.Nil(t, resp.Msg.AgentUpdate, "protocol version 1 agents cannot install releases")
+
+	heartbeat := func(version, updateError string) *meshv1.HeartbeatResponse {
+		t.Helper()
+		resp, err := h.Heartbeat(context.Background(), connect.NewRequest(&meshv1.HeartbeatRequest{
+			AgentId:     "agent-1",
+			Version:     version,
+			UpdateError: updateError,
+		}))
+		require.NoError(t, err)
+		require.True(t, resp.Msg.Ok)
+		return resp.Msg
+	}
+
+	update := heartbeat("v0.4.0", "checksum mismatch").AgentUpdate
+	require.NotNil(t, update)
+	assert.Equal(t, "v0.5.0", update.Version)
+
+	entry, err := reg.Get("agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, "v0.4.0", entry.Version)
+	assert.Equal(t, "checksum mismatch", entry.UpdateError)
+
+	assert.Nil(t, heartbeat("v0.5.0", "").AgentUpdate, "the agent is upgraded")
+	assert.Empty(t, entry.UpdateError)
+}
@@ -0,0 +1,166 @@
+package mesh
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/config"
+)
+
+// reasonInvalidSecret rejects agents presenting a colony secret the colony
+// does not accept (RFD 002).
+const reasonInvalidSecret = "invalid_secret"
+
+// ColonySecrets holds the colony secret agents register with and, during
+// the grace period after a rotation, the previous one.
+type ColonySecrets struct {
+	mu                sync.RWMutex
+	current           string
+	previous          string
+	previousExpiresAt time.Time
+
+	// persist saves the secrets, e.g. to the colony config, before a
+	// rotation takes effect.
+	persist func(current string, previous *config.PreviousColonySecret) error
+
+	// onRotate are called after a rotation.
+	onRotate []func(current, previous string)
+}
+
+// NewColonySecrets creates the colony secrets from the colony config.
+func NewColonySecrets(current string, previous *config.PreviousColonySecret) *ColonySecrets {
+	s := &ColonySecrets{current: current}
+	if previous != nil && previous.Secret != "" {
+		s.previous = previous.Secret
+		s.previousExpiresAt = previous.ExpiresAt
+	}
+	return s
+}
+
+// SetPersister sets the function saving the secrets when they are rotated.
+func (s *ColonySecrets) SetPersister(persist func(current string, previous *config.PreviousColonySecret) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.persist = persist
+}
+
+// OnRotate registers fn to be called with the new and previous secrets after
+// each rotation.
+func (s *ColonySecrets) OnRotate(fn func(current, previous string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRotate = append(s.onRotate, fn)
+}
+
+// Secrets returns the current secret and the previous one, which is empty
+// outside of a grace period.
+func (s *ColonySecrets) Secrets() (current, previous string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.current, s.previousLocked(time.Now())
+}
+
+// previousLocked returns the previous secret if its grace period has not
+// ended at now.
+func (s *ColonySecrets) previousLocked(now time.Time) string {
+	if s.previous == "" || !now.Before(s.previousExpiresAt) {
+		return ""
+	}
+	return s.previous
+}
+
+// Rotate generates a new colony secret and keeps accepting the current one
+// for gracePeriod. It returns the new secret, the one it replaced and when
+// that one expires. A rotation during the grace period of the previous one
+// ends that grace period.
+func (s *ColonySecrets) Rotate(gracePeriod time.Duration) (string, string, time.Time, error) {
+	next, err := auth.GenerateColonySecret()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	s.mu.Lock()
+	if s.current == "" {
+		s.mu.Unlock()
+		return "", "", time.Time{}, fmt.Errorf("the colony has no secret to rotate")
+	}
+	previous := s.current
+	expiresAt := time.Now().Add(gracePeriod)
+	if s.persist != nil {
+		if err := s.persist(next, &config.PreviousColonySecret{Secret: previous, ExpiresAt: expiresAt}); err != nil {
+			s.mu.Unlock()
+			return "", "", time.Time{}, fmt.Errorf("failed to save the colony secret: %w", err)
+		}
+	}
+	s.current, s.previous, s.previousExpiresAt = next, previous, expiresAt
+	onRotate := s.onRotate
+	s.mu.Unlock()
+
+	for _, fn := range onRotate {
+		fn(next, previous)
+	}
+	return next, previous, expiresAt, nil
+}
+
+// Check returns which secret presented is. ok is false if the colony does
+// not accept it: it is neither the current secret nor the previous one in
+// its grace period.
+func (s *ColonySecrets) Check(presented string, now time.Time) (status registry.ColonySecretStatus, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if equalSecrets(presented, s.current) {
+		return registry.ColonySecretCurrent, true
+	}
+	if previous := s.previousLocked(now); equalSecrets(presented, previous) {
+		return registry.ColonySecretPrevious, true
+	}
+	return registry.ColonySecretNone, false
+}
+
+// equalSecrets compares secrets in constant time. Empty secrets are never
+// equal.
+func equalSecrets(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// SetColonySecrets makes registration check the colony secret agents
+// present. Agents registering without one are still accepted, unless
+// certificates are required (see SetAgentAuth).
+func (h *Handler) SetColonySecrets(secrets *ColonySecrets) {
+	h.secrets = secrets
+}
+
+// checkColonySecret checks the colony secret presented by agentID. It
+// returns which secret the agent holds, and the reason to reject the
+// registration, or "".
+func (h *Handler) checkColonySecret(agentID, presented string) (registry.ColonySecretStatus, string) {
+	if presented == "" || h.secrets == nil {
+		return registry.ColonySecretNone, ""
+	}
+	if current, _ := h.secrets.Secrets(); current == "" {
+		// The colony has no secret to check against.
+		return registry.ColonySecretNone, ""
+	}
+
+	status, ok := h.secrets.Check(presented, time.Now())
+	if !ok {
+		h.logger.Warn().
+			Str("agent_id", agentID).
+			Msg("Agent registration rejected: invalid colony secret")
+		return registry.ColonySecretNone, reasonInvalidSecret
+	}
+	if status == registry.ColonySecretPrevious {
+		h.logger.Warn().
+			Str("agent_id", agentID).
+			Msg("Agent registered with the previous colony secret; it is rejected once the rotation grace period ends")
+	}
+	return status, ""
+}
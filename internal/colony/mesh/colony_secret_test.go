@@ -0,0 +1,90 @@
+package mesh
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+func TestColonySecrets_Rotate(t *testing.T) {
+	secrets := NewColonySecrets("old", nil)
+
+	var saved *config.PreviousColonySecret
+	secrets.SetPersister(func(secret string, previous *config.PreviousColonySecret) error {
+		saved = previous
+		return nil
+	})
+	var rotated []string
+	secrets.OnRotate(func(current, previous string) {
+		rotated = append(rotated, current, previous)
+	})
+
+	next, previous, expiresAt, err := secrets.Rotate(time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, "old", next)
+	assert.Equal(t, "old", previous)
+	require.NotNil(t, saved)
+	assert.Equal(t, config.PreviousColonySecret{Secret: "old", ExpiresAt: expiresAt}, *saved)
+	assert.Equal(t, []string{next, "old"}, rotated)
+
+	now := time.Now()
+	status, ok := secrets.Check(next, now)
+	assert.True(t, ok)
+	assert.Equal(t, registry.ColonySecretCurrent, status)
+	status, ok = secrets.Check("old", now)
+	assert.True(t, ok, "the previous secret is accepted during the grace period")
+	assert.Equal(t, registry.ColonySecretPrevious, status)
+	_, ok = secrets.Check("old", expiresAt)
+	assert.False(t, ok, "the previous secret expires with the grace period")
+	_, ok = secrets.Check("guessed", now)
+	assert.False(t, ok)
+	_, ok = secrets.Check("", now)
+	assert.False(t, ok)
+
+	// A failed save leaves the secrets unchanged.
+	secrets.SetPersister(func(string, *config.PreviousColonySecret) error {
+		return errors.New("read-only config")
+	})
+	_, _, _, err = secrets.Rotate(time.Hour)
+	assert.ErrorContains(t, err, "read-only config")
+	current, _ := secrets.Secrets()
+	assert.Equal(t, next, current)
+
+	_, _, _, err = NewColonySecrets("", nil).Rotate(time.Hour)
+	assert.Error(t, err, "a colony without a secret has none to rotate")
+}
+
+func TestRegister_ColonySecret(t *testing.T) {
+	logger := logging.NewWithComponent(logging.Config{Level: "error"}, "colony-secret-test")
+	h := NewHandler(&config.ResolvedConfig{ColonyID: "colony-1"}, nil, registry.New(nil), nil, logger)
+	h.SetColonySecrets(NewColonySecrets("current", &config.PreviousColonySecret{
+		Secret:    "previous",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}))
+
+	// Accepted registrations end with missing_wireguard_pubkey.
+	for secret, reason := range map[string]string{
+		"":         "missing_wireguard_pubkey",
+		"current":  "missing_wireguard_pubkey",
+		"previous": "missing_wireguard_pubkey",
+		"guessed":  reasonInvalidSecret,
+	} {
+		resp, err := h.Register(context.Background(), connect.NewRequest(&meshv1.RegisterRequest{
+			AgentId:      "agent-1",
+			ColonyId:     "colony-1",
+			ColonySecret: secret,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, reason, resp.Msg.Reason, "secret %q", secret)
+	}
+}
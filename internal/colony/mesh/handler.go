@@ -7,10 +7,14 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
+	"strconv"
+	"time"
 
 	"connectrpc.com/connect"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
 	"github.com/coral-mesh/coral/internal/colony/registry"
 	"github.com/coral-mesh/coral/internal/config"
@@ -26,6 +30,19 @@ type Handler struct {
 	registry        *registry.Registry
 	logger          logging.Logger
 	discoveryClient *discovery.Client
+
+	// Agent certificate authentication, see SetAgentAuth.
+	revocations         RevocationChecker
+	requireCertificates bool
+
+	// Colony secrets agents may register with, see SetColonySecrets.
+	secrets *ColonySecrets
+
+	// Agent release rollout advertised in heartbeats, see SetAgentUpdates.
+	updates *AgentUpdates
+
+	// Oldest agent protocol version accepted, see SetMinProtocolVersion.
+	minProtocolVersion int
 }
 
 // NewHandler creates a new mesh service handler.
@@ -62,7 +79,7 @@ func (h *Handler) Register(
 		Str("peer_addr", peerAddr).
 		Msg("Agent registration request received")
 
-	// Validate colony_id and colony_secret (RFD 002)
+	// Validate colony_id (RFD 002)
 	if req.Msg.ColonyId != h.cfg.ColonyID {
 		h.logger.Warn().
 			Str("agent_id", req.Msg.AgentId).
@@ -76,6 +93,40 @@ func (h *Handler) Register(
 		}), nil
 	}
 
+	// Authenticate the agent by its client certificate, if it has one.
+	if reason := h.authenticateAgent(ctx, req.Msg.AgentId); reason != "" {
+		if reason == reasonCertificateRequired {
+			h.logger.Warn().
+				Str("agent_id", req.Msg.AgentId).
+				Msg("Agent registration rejected: no client certificate")
+		}
+		return connect.NewResponse(&meshv1.RegisterResponse{
+			Accepted: false,
+			Reason:   reason,
+		}), nil
+	}
+
+	// Check the colony secret, if the agent presented one (RFD 002).
+	secretStatus, reason := h.checkColonySecret(req.Msg.AgentId, req.Msg.ColonySecret)
+	if reason != "" {
+		return connect.NewResponse(&meshv1.RegisterResponse{
+			Accepted: false,
+			Reason:   reason,
+		}), nil
+	}
+
+	// Negotiate the protocol version (RFD 018).
+	minProtocol, maxProtocol := h.protocolRange()
+	agentProtocol, negotiatedProtocol, reason := h.negotiateProtocol(req.Msg.AgentId, req.Msg.ProtocolVersion)
+	if reason != "" {
+		return connect.NewResponse(&meshv1.RegisterResponse{
+			Accepted:           false,
+			Reason:             reason,
+			MinProtocolVersion: uint32(minProtocol), // #nosec G115 -- protocol versions are small.
+			MaxProtocolVersion: uint32(maxProtocol), // #nosec G115 -- protocol versions are small.
+		}), nil
+	}
+
 	// Validate WireGuard public key
 	if req.Msg.WireguardPubkey == "" {
 		h.logger.Warn().
@@ -168,11 +219,18 @@ func (h *Handler) Register(
 			Msg("No endpoint available for agent (discovery lookup failed, agent did not register with STUN endpoint). WireGuard roaming mode enabled - agent must initiate connection.")
 	}
 
+	// Derive the agent's IPv6 mesh IP from its IPv4 one (dual-stack mesh).
+	allowedIPs := []string{meshIP.String() + "/32"}
+	meshIPv6 := h.meshIPv6(meshIP)
+	if meshIPv6 != nil {
+		allowedIPs = append(allowedIPs, meshIPv6.String()+"/128")
+	}
+
 	// Add agent as WireGuard peer
 	peerConfig := &wireguard.PeerConfig{
 		PublicKey:           req.Msg.WireguardPubkey,
 		Endpoint:            agentEndpoint, // Use detected endpoint
-		AllowedIPs:          []string{meshIP.String() + "/32"},
+		AllowedIPs:          allowedIPs,
 		PersistentKeepalive: 25, // Keep NAT mappings alive
 	}
 
@@ -197,21 +255,30 @@ func (h *Handler) Register(
 		}), nil
 	}
 
+	meshIPv6Str, meshSubnetIPv6 := "", ""
+	if meshIPv6 != nil {
+		meshIPv6Str, meshSubnetIPv6 = meshIPv6.String(), h.cfg.WireGuard.MeshNetworkIPv6
+	}
+
 	// Register agent in the registry for tracking.
-	// Note: We don't have IPv6 mesh IP yet (future enhancement).
 	//nolint:staticcheck // ComponentName is deprecated but kept for backward compatibility
-	if _, err := h.registry.Register(req.Msg.AgentId, req.Msg.ComponentName, meshIP.String(), "", req.Msg.Services, req.Msg.RuntimeContext, req.Msg.ProtocolVersion); err != nil {
+	if _, err := h.registry.Register(req.Msg.AgentId, req.Msg.ComponentName, meshIP.String(), meshIPv6Str, req.Msg.Services, req.Msg.RuntimeContext, strconv.Itoa(agentProtocol)); err != nil {
 		h.logger.Warn().
 			Err(err).
 			Str("agent_id", req.Msg.AgentId).
 			Msg("Failed to register agent in registry (non-fatal)")
+	} else {
+		_ = h.registry.SetColonySecretStatus(req.Msg.AgentId, secretStatus)
+		_ = h.registry.UpdateVersion(req.Msg.AgentId, req.Msg.Version, "")
+		_ = h.registry.SetReportedLabels(req.Msg.AgentId, req.Msg.Labels)
 	}
 
 	// Log registration with service details
 	logEvent := h.logger.Info().
 		Str("agent_id", req.Msg.AgentId).
 		Str("component_name", req.Msg.ComponentName). //nolint:staticcheck // ComponentName is deprecated but kept for backward compatibility
-		Str("mesh_ip", meshIP.String())
+		Str("mesh_ip", meshIP.String()).
+		Str("mesh_ipv6", meshIPv6Str)
 
 	if len(req.Msg.Services) > 0 {
 		logEvent.Int("service_count", len(req.Msg.Services))
@@ -235,14 +302,46 @@ func (h *Handler) Register(
 
 	// Return successful registration response
 	return connect.NewResponse(&meshv1.RegisterResponse{
-		Accepted:     true,
-		AssignedIp:   meshIP.String(),
-		MeshSubnet:   h.cfg.WireGuard.MeshNetworkIPv4,
-		Peers:        peers,
-		RegisteredAt: timestamppb.Now(),
+		Accepted:       true,
+		AssignedIp:     meshIP.String(),
+		MeshSubnet:     h.cfg.WireGuard.MeshNetworkIPv4,
+		Peers:          peers,
+		RegisteredAt:   timestamppb.Now(),
+		AssignedIpv6:   meshIPv6Str,
+		MeshSubnetIpv6: meshSubnetIPv6,
+
+		MinProtocolVersion: uint32(minProtocol),        // #nosec G115 -- protocol versions are small.
+		MaxProtocolVersion: uint32(maxProtocol),        // #nosec G115 -- protocol versions are small.
+		ProtocolVersion:    uint32(negotiatedProtocol), // #nosec G115 -- protocol versions are small.
 	}), nil
 }
 
+// meshIPv6 returns the IPv6 mesh IP of the agent with IPv4 mesh IP meshIP,
+// or nil if the colony has no IPv6 mesh network.
+func (h *Handler) meshIPv6(meshIP net.IP) net.IP {
+	if h.cfg.WireGuard.MeshNetworkIPv6 == "" {
+		return nil
+	}
+	_, v4Subnet, err := net.ParseCIDR(h.cfg.WireGuard.MeshNetworkIPv4)
+	if err != nil {
+		return nil
+	}
+	_, v6Subnet, err := net.ParseCIDR(h.cfg.WireGuard.MeshNetworkIPv6)
+	if err != nil {
+		return nil
+	}
+	ip, err := wireguard.MapIPv6(meshIP, v4Subnet, v6Subnet)
+	if err != nil {
+		h.logger.Warn().Err(err).Msg("Cannot derive IPv6 mesh IP, agent is IPv4 only")
+		return nil
+	}
+	// A colony configured with a custom IPv6 address may own this one.
+	if ip.Equal(net.ParseIP(h.cfg.WireGuard.MeshIPv6)) {
+		return nil
+	}
+	return ip
+}
+
 // Heartbeat handles agent heartbeat requests to update last_seen timestamp.
 func (h *Handler) Heartbeat(
 	ctx context.Context,
@@ -272,16 +371,58 @@ func (h *Handler) Heartbeat(
 		}), nil
 	}
 
+	// Call the agent back over the address family its heartbeats use.
+	if host, _, err := net.SplitHostPort(req.Peer().Addr); err == nil {
+		_ = h.registry.UpdateHeartbeatAddr(req.Msg.AgentId, host)
+	}
+
+	h.recordResourceShedding(req.Msg.AgentId, req.Msg.ResourceShedding)
+	h.recordVersion(req.Msg.AgentId, req.Msg.Version, req.Msg.UpdateError)
+
+	var sentAt time.Time
+	if req.Msg.SentAt != nil {
+		sentAt = req.Msg.SentAt.AsTime()
+	}
+	if err := h.registry.UpdateHealthMetrics(req.Msg.AgentId, req.Msg.HealthMetrics, sentAt); err != nil {
+		h.logger.Debug().
+			Err(err).
+			Str("agent_id", req.Msg.AgentId).
+			Msg("Failed to record agent health metrics")
+	}
+
 	h.logger.Debug().
 		Str("agent_id", req.Msg.AgentId).
 		Msg("Agent heartbeat updated successfully")
 
 	return connect.NewResponse(&meshv1.HeartbeatResponse{
-		Ok:       true,
-		Commands: []string{}, // Future: colony can send commands to agent
+		Ok:          true,
+		Commands:    []string{}, // Future: colony can send commands to agent
+		AgentUpdate: h.agentUpdate(req.Msg.AgentId),
 	}), nil
 }
 
+// recordResourceShedding stores the agent's resource safety valve state and
+// logs when the agent starts or stops shedding load.
+func (h *Handler) recordResourceShedding(agentID string, shedding *agentv1.ResourceShedding) {
+	previous, err := h.registry.UpdateResourceShedding(agentID, shedding)
+	if err != nil {
+		return
+	}
+
+	switch {
+	case shedding.GetActive() && !slices.Equal(previous.GetStages(), shedding.GetStages()):
+		h.logger.Warn().
+			Str("agent_id", agentID).
+			Strs("stages", shedding.GetStages()).
+			Str("reason", shedding.GetReason()).
+			Msg("Agent is shedding load to stay within resource limits")
+	case previous.GetActive() && !shedding.GetActive():
+		h.logger.Info().
+			Str("agent_id", agentID).
+			Msg("Agent stopped shedding load")
+	}
+}
+
 // selectBestAgentEndpoint selects the best WireGuard endpoint for an agent from a list of observed endpoints.
 // Strategy:
 //  1. Skip localhost/127.0.0.1 endpoints (would be self-referential from colony's perspective)
@@ -2,11 +2,14 @@ package mesh
 
 import (
 	"fmt"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/config"
 	"github.com/coral-mesh/coral/internal/discovery"
 	"github.com/coral-mesh/coral/internal/logging"
 )
@@ -310,3 +313,21 @@ func BenchmarkSelectBestAgentEndpoint(b *testing.B) {
 		selectBestAgentEndpoint(endpoints, peerHost, logger, fmt.Sprintf("agent-%d", i))
 	}
 }
+
+func TestHandler_MeshIPv6(t *testing.T) {
+	logger := logging.NewWithComponent(logging.Config{Level: "error"}, "mesh-ipv6-test")
+	newHandler := func(colonyIPv6, subnetIPv6 string) *Handler {
+		cfg := &config.ResolvedConfig{}
+		cfg.WireGuard.MeshNetworkIPv4 = "100.64.0.0/10"
+		cfg.WireGuard.MeshIPv6 = colonyIPv6
+		cfg.WireGuard.MeshNetworkIPv6 = subnetIPv6
+		return NewHandler(cfg, nil, registry.New(nil), nil, logger)
+	}
+
+	h := newHandler("fd42::1", "fd42::/48")
+	assert.Equal(t, "fd42::2a", h.meshIPv6(net.ParseIP("100.64.0.42")).String())
+
+	assert.Nil(t, newHandler("", "").meshIPv6(net.ParseIP("100.64.0.42")), "IPv4-only colony")
+	assert.Nil(t, newHandler("fd42::2a", "fd42::/48").meshIPv6(net.ParseIP("100.64.0.42")),
+		"the colony's own IPv6 address is never handed out")
+}
@@ -0,0 +1,107 @@
+package mesh
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/colony/wireguard"
+	"github.com/coral-mesh/coral/internal/constants"
+	wg "github.com/coral-mesh/coral/internal/wireguard"
+)
+
+// PunchHole coordinates hole punching with an agent whose tunnel does not
+// come up: it returns the colony's candidate endpoints and a start time,
+// probes the agent's candidates from then on, and points the agent's peer
+// at the first one that answers. The agent does the same on its side.
+func (h *Handler) PunchHole(
+	ctx context.Context,
+	req *connect.Request[meshv1.PunchHoleRequest],
+) (*connect.Response[meshv1.PunchHoleResponse], error) {
+	puncher := h.wgDevice.HolePuncher()
+	if puncher == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("hole punching is disabled on this colony"))
+	}
+
+	entry, err := h.registry.Get(req.Msg.AgentId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent %s is not registered", req.Msg.AgentId))
+	}
+	peer, ok := h.wgDevice.GetPeer(req.Msg.WireguardPubkey)
+	if !ok || !slices.Contains(peer.AllowedIPs, entry.MeshIPv4+"/32") {
+		return nil, connect.NewError(connect.CodePermissionDenied,
+			fmt.Errorf("WireGuard key does not belong to agent %s", req.Msg.AgentId))
+	}
+
+	agentCandidates := slices.Clone(req.Msg.Candidates)
+	if host, _, err := net.SplitHostPort(req.Peer().Addr); err == nil && req.Msg.ListenPort > 0 {
+		agentCandidates = append(agentCandidates, net.JoinHostPort(host, strconv.FormatUint(uint64(req.Msg.ListenPort), 10)))
+	}
+
+	var session [wg.PunchSessionIDSize]byte
+	if _, err := rand.Read(session[:]); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create session ID: %w", err))
+	}
+	startAt := time.Now().Add(constants.DefaultHolePunchDelay)
+
+	h.logger.Info().
+		Str("agent_id", req.Msg.AgentId).
+		Strs("candidates", agentCandidates).
+		Msg("Starting hole punching with agent")
+
+	go h.punchAgent(puncher, req.Msg.AgentId, req.Msg.WireguardPubkey, session, agentCandidates, startAt)
+
+	return connect.NewResponse(&meshv1.PunchHoleResponse{
+		SessionId:  session[:],
+		Candidates: h.colonyCandidates(),
+		StartAt:    timestamppb.New(startAt),
+	}), nil
+}
+
+// punchAgent probes the agent's candidates and promotes the path that
+// answers to the agent's peer endpoint.
+func (h *Handler) punchAgent(
+	puncher *wg.HolePuncher,
+	agentID, pubkey string,
+	session [wg.PunchSessionIDSize]byte,
+	candidates []string,
+	startAt time.Time,
+) {
+	ctx, cancel := context.WithDeadline(context.Background(), startAt.Add(constants.DefaultHolePunchTimeout))
+	defer cancel()
+
+	endpoint, err := puncher.Punch(ctx, session, candidates, startAt)
+	if err != nil {
+		h.logger.Warn().Err(err).Str("agent_id", agentID).Msg("Hole punching with agent failed")
+		return
+	}
+	if err := h.wgDevice.SetPeerEndpoint(pubkey, endpoint); err != nil {
+		h.logger.Warn().Err(err).Str("agent_id", agentID).Msg("Failed to promote hole punched endpoint")
+		return
+	}
+	h.logger.Info().
+		Str("agent_id", agentID).
+		Str("endpoint", endpoint).
+		Msg("Hole punching succeeded, agent endpoint updated")
+}
+
+// colonyCandidates returns the endpoints agents may reach the colony's
+// WireGuard port on: configured public endpoints, then host addresses.
+func (h *Handler) colonyCandidates() []string {
+	port := h.wgDevice.ListenPort()
+	candidates := wireguard.BuildEndpoints(port, h.cfg.WireGuard)
+	for _, candidate := range wg.HostCandidates(port, h.wgDevice.InterfaceName()) {
+		if !slices.Contains(candidates, candidate) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates
+}
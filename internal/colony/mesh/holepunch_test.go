@@ -0,0 +1,43 @@
+package mesh
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/logging"
+	"github.com/coral-mesh/coral/internal/wireguard"
+)
+
+func TestPunchHole_Rejected(t *testing.T) {
+	logger := logging.NewWithComponent(logging.Config{Level: "error"}, "hole-punch-test")
+
+	newHandler := func(enabled bool) *Handler {
+		dev, err := wireguard.NewDevice(&config.WireGuardConfig{
+			PrivateKey: "yGzL6W6Q6/lDtb59+dG/F/B3BmVN/D9wUqX/77L1oGE=",
+			PublicKey:  "wGtt3f4A633lH6/gC/g8e1/N2r7M77u5gS1bI9n9AWE=",
+		}, logger)
+		require.NoError(t, err)
+		if enabled {
+			dev.EnableHolePunching()
+		}
+		return NewHandler(&config.ResolvedConfig{}, dev, registry.New(nil), nil, logger)
+	}
+	req := connect.NewRequest(&meshv1.PunchHoleRequest{
+		AgentId:         "agent-1",
+		WireguardPubkey: "wGtt3f4A633lH6/gC/g8e1/N2r7M77u5gS1bI9n9AWE=",
+		Candidates:      []string{"203.0.113.45:41581"},
+	})
+
+	_, err := newHandler(false).PunchHole(context.Background(), req)
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	_, err = newHandler(true).PunchHole(context.Background(), req)
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err), "unregistered agents cannot start hole punching")
+}
@@ -0,0 +1,58 @@
+package mesh
+
+import (
+	"github.com/coral-mesh/coral/internal/protocol"
+)
+
+// reasonUnsupportedProtocol rejects agents older than the minimum protocol
+// version.
+const reasonUnsupportedProtocol = "unsupported_protocol_version"
+
+// SetMinProtocolVersion rejects agents registering with a protocol version
+// older than v (default: protocol.MinVersion).
+func (h *Handler) SetMinProtocolVersion(v int) {
+	h.minProtocolVersion = v
+}
+
+// protocolRange returns the protocol versions the colony accepts.
+func (h *Handler) protocolRange() (minVersion, maxVersion int) {
+	minVersion = h.minProtocolVersion
+	if minVersion < protocol.MinVersion {
+		minVersion = protocol.MinVersion
+	}
+	return minVersion, protocol.Version
+}
+
+// negotiateProtocol returns the protocol version the agent reported, and the
+// version to use with it. It returns the reason to reject the registration,
+// or "".
+func (h *Handler) negotiateProtocol(agentID, reported string) (agentVersion, negotiated int, reason string) {
+	minVersion, maxVersion := h.protocolRange()
+
+	agentVersion, err := protocol.Parse(reported)
+	if err == nil {
+		negotiated, err = protocol.Negotiate(agentVersion, minVersion, maxVersion)
+	}
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("agent_id", agentID).
+			Int("min_protocol_version", minVersion).
+			Int("max_protocol_version", maxVersion).
+			Msg("Agent registration rejected: unsupported protocol version, upgrade the agent")
+		return 0, 0, reasonUnsupportedProtocol
+	}
+
+	if missing := protocol.Missing(agentVersion, maxVersion); len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, c := range missing {
+			names[i] = c.Name
+		}
+		h.logger.Warn().
+			Str("agent_id", agentID).
+			Int("protocol_version", agentVersion).
+			Strs("missing_capabilities", names).
+			Msg("Agent speaks an older protocol version, features it lacks are disabled")
+	}
+	return agentVersion, negotiated, ""
+}
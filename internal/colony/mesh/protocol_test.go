@@ -0,0 +1,45 @@
+package mesh
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/logging"
+	"github.com/coral-mesh/coral/internal/protocol"
+)
+
+func TestRegister_ProtocolVersion(t *testing.T) {
+	logger := logging.NewWithComponent(logging.Config{Level: "error"}, "protocol-test")
+	h := NewHandler(&config.ResolvedConfig{ColonyID: "colony-1"}, nil, registry.New(nil), nil, logger)
+
+	register := func(version string) *meshv1.RegisterResponse {
+		t.Helper()
+		resp, err := h.Register(context.Background(), connect.NewRequest(&meshv1.RegisterRequest{
+			AgentId:         "agent-1",
+			ColonyId:        "colony-1",
+			ProtocolVersion: version,
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+
+	// Accepted registrations end with missing_wireguard_pubkey.
+	for _, version := range []string{"", "1", "2", "99"} {
+		assert.Equal(t, "missing_wireguard_pubkey", register(version).Reason, "version %q", version)
+	}
+	assert.Equal(t, reasonUnsupportedProtocol, register("2.0.0").Reason)
+
+	h.SetMinProtocolVersion(protocol.Version)
+	assert.Equal(t, "missing_wireguard_pubkey", register("2").Reason)
+	resp := register("")
+	assert.Equal(t, reasonUnsupportedProtocol, resp.Reason, "agents without a version speak version 1")
+	assert.Equal(t, uint32(protocol.Version), resp.MinProtocolVersion)
+	assert.Equal(t, uint32(protocol.Version), resp.MaxProtocolVersion)
+}
@@ -0,0 +1,558 @@
+package colony
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// OTLPReceiver accepts OTLP traces and metrics exported directly to the
+// colony by instrumented applications, without an agent or collector in
+// between. Data is stored in the same tables as telemetry polled from agents,
+// under the agent ID constants.OTLPIngestAgentID:
+//   - every span is aggregated into 1-minute otel_summaries buckets,
+//   - error, high-latency and sampled spans are stored in beyla_traces,
+//   - HTTP, gRPC and SQL duration histograms are stored in the beyla_*_metrics
+//     tables.
+type OTLPReceiver struct {
+	config     config.OTLPIngestConfig
+	db         *database.Database
+	aggregator *TelemetryAggregator
+	aggMu      sync.Mutex // Serializes aggregation with summary flushes.
+	logger     zerolog.Logger
+
+	grpcServer *grpc.Server
+	httpServer *http.Server
+	cancel     context.CancelFunc
+	running    bool
+	mu         sync.Mutex
+	wg         sync.WaitGroup
+
+	warnedCumulative map[string]bool // Services warned about cumulative metrics.
+	warnMu           sync.Mutex
+}
+
+// otlpTraceServer adapts OTLPReceiver to the OTLP trace gRPC service.
+type otlpTraceServer struct {
+	ptraceotlp.UnimplementedGRPCServer
+	receiver *OTLPReceiver
+}
+
+// otlpMetricsServer adapts OTLPReceiver to the OTLP metrics gRPC service.
+type otlpMetricsServer struct {
+	pmetricotlp.UnimplementedGRPCServer
+	receiver *OTLPReceiver
+}
+
+// otlpMessage is implemented by pdata OTLP export requests and responses.
+type otlpMessage interface {
+	MarshalProto() ([]byte, error)
+	UnmarshalProto(data []byte) error
+	MarshalJSON() ([]byte, error)
+	UnmarshalJSON(data []byte) error
+}
+
+// NewOTLPReceiver creates a colony OTLP receiver.
+func NewOTLPReceiver(cfg config.OTLPIngestConfig, db *database.Database, logger zerolog.Logger) *OTLPReceiver {
+	if cfg.GRPCEndpoint == "" {
+		cfg.GRPCEndpoint = fmt.Sprintf("0.0.0.0:%d", constants.DefaultOTLPGRPCPort)
+	}
+	if cfg.HTTPEndpoint == "" {
+		cfg.HTTPEndpoint = fmt.Sprintf("0.0.0.0:%d", constants.DefaultOTLPHTTPPort)
+	}
+	if cfg.SampleRate <= 0 {
+		cfg.SampleRate = constants.DefaultSampleRate
+	}
+
+	return &OTLPReceiver{
+		config:           cfg,
+		db:               db,
+		aggregator:       NewTelemetryAggregator(),
+		logger:           logger.With().Str("component", "colony_otlp_receiver").Logger(),
+		warnedCumulative: make(map[string]bool),
+	}
+}
+
+// Start starts the OTLP gRPC and HTTP receivers and the summary flush loop.
+func (r *OTLPReceiver) Start(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.running {
+		return fmt.Errorf("OTLP receiver already running")
+	}
+
+	grpcLis, err := net.Listen("tcp", r.config.GRPCEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", r.config.GRPCEndpoint, err)
+	}
+	httpLis, err := net.Listen("tcp", r.config.HTTPEndpoint)
+	if err != nil {
+		_ = grpcLis.Close()
+		return fmt.Errorf("failed to listen on %s: %w", r.config.HTTPEndpoint, err)
+	}
+
+	r.grpcServer = grpc.NewServer()
+	ptraceotlp.RegisterGRPCServer(r.grpcServer, &otlpTraceServer{receiver: r})
+	pmetricotlp.RegisterGRPCServer(r.grpcServer, &otlpMetricsServer{receiver: r})
+
+	r.httpServer = &http.Server{
+		Handler:           r.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	r.wg.Add(2)
+	go func() {
+		defer r.wg.Done()
+		if err := r.grpcServer.Serve(grpcLis); err != nil {
+			r.logger.Error().Err(err).Msg("OTLP gRPC server error")
+		}
+	}()
+	go func() {
+		defer r.wg.Done()
+		if err := r.httpServer.Serve(httpLis); err != nil && err != http.ErrServerClosed {
+			r.logger.Error().Err(err).Msg("OTLP HTTP server error")
+		}
+	}()
+
+	flushCtx, cancel := context.WithCancel(ctx)
+	r.cancel = cancel
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.runFlushLoop(flushCtx)
+	}()
+
+	r.running = true
+
+	r.logger.Info().
+		Str("grpc_endpoint", r.config.GRPCEndpoint).
+		Str("http_endpoint", r.config.HTTPEndpoint).
+		Float64("sample_rate", r.config.SampleRate).
+		Msg("Colony OTLP receiver started")
+
+	return nil
+}
+
+// Stop stops the receivers and writes pending span summaries.
+func (r *OTLPReceiver) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.running {
+		return nil
+	}
+
+	r.grpcServer.GracefulStop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.httpServer.Shutdown(ctx); err != nil {
+		r.logger.Warn().Err(err).Msg("OTLP HTTP server shutdown error")
+	}
+
+	r.cancel()
+	r.wg.Wait()
+
+	if err := r.Flush(ctx); err != nil {
+		r.logger.Warn().Err(err).Msg("Failed to write pending span summaries")
+	}
+
+	r.running = false
+	r.logger.Info().Msg("Colony OTLP receiver stopped")
+	return nil
+}
+
+// Handler returns the OTLP/HTTP handler serving /v1/traces and /v1/metrics.
+func (r *OTLPReceiver) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/traces", func(w http.ResponseWriter, req *http.Request) {
+		exportReq := ptraceotlp.NewExportRequest()
+		r.serveHTTP(w, req, exportReq, ptraceotlp.NewExportResponse(), func(ctx context.Context) error {
+			return r.ConsumeTraces(ctx, exportReq.Traces())
+		})
+	})
+	mux.HandleFunc("/v1/metrics", func(w http.ResponseWriter, req *http.Request) {
+		exportReq := pmetricotlp.NewExportRequest()
+		r.serveHTTP(w, req, exportReq, pmetricotlp.NewExportResponse(), func(ctx context.Context) error {
+			return r.ConsumeMetrics(ctx, exportReq.Metrics())
+		})
+	})
+	return mux
+}
+
+// serveHTTP decodes an OTLP/HTTP export request (protobuf or JSON, optionally
+// gzip-compressed), consumes it and writes the response in the same encoding.
+func (r *OTLPReceiver) serveHTTP(w http.ResponseWriter, req *http.Request, exportReq, exportResp otlpMessage, consume func(context.Context) error) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	isJSON := strings.HasPrefix(req.Header.Get("Content-Type"), "application/json")
+
+	var body io.Reader = req.Body
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(w, "invalid gzip body", http.StatusBadRequest)
+			return
+		}
+		defer func() { _ = gz.Close() }()
+		body = gz
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if isJSON {
+		err = exportReq.UnmarshalJSON(data)
+	} else {
+		err = exportReq.UnmarshalProto(data)
+	}
+	if err != nil {
+		http.Error(w, "failed to parse OTLP request", http.StatusBadRequest)
+		return
+	}
+
+	if err := consume(req.Context()); err != nil {
+		r.logger.Error().Err(err).Str("path", req.URL.Path).Msg("Failed to store OTLP data")
+		http.Error(w, "failed to store telemetry", http.StatusInternalServerError)
+		return
+	}
+
+	var respData []byte
+	if isJSON {
+		w.Header().Set("Content-Type", "application/json")
+		respData, err = exportResp.MarshalJSON()
+	} else {
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		respData, err = exportResp.MarshalProto()
+	}
+	if err != nil {
+		http.Error(w, "failed to marshal response", http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(respData)
+}
+
+// Export implements the OTLP trace gRPC service.
+func (s *otlpTraceServer) Export(ctx context.Context, req ptraceotlp.ExportRequest) (ptraceotlp.ExportResponse, error) {
+	return ptraceotlp.NewExportResponse(), s.receiver.ConsumeTraces(ctx, req.Traces())
+}
+
+// Export implements the OTLP metrics gRPC service.
+func (s *otlpMetricsServer) Export(ctx context.Context, req pmetricotlp.ExportRequest) (pmetricotlp.ExportResponse, error) {
+	return pmetricotlp.NewExportResponse(), s.receiver.ConsumeMetrics(ctx, req.Metrics())
+}
+
+// ConsumeTraces aggregates spans into summaries and stores error,
+// high-latency and sampled spans as traces.
+func (r *OTLPReceiver) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	var (
+		spans  []*agentv1.TelemetrySpan
+		traces []*agentv1.EbpfTraceSpan
+	)
+
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		serviceName := otlpServiceName(rs.Resource())
+
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+
+				startTime := span.StartTimestamp().AsTime()
+				duration := span.EndTimestamp().AsTime().Sub(startTime)
+				durationMs := float64(duration.Microseconds()) / 1000.0
+				isError := span.Status().Code() == ptrace.StatusCodeError
+				spanKind := otlpSpanKind(span.Kind())
+
+				spans = append(spans, &agentv1.TelemetrySpan{
+					Timestamp:   startTime.UnixMilli(),
+					TraceId:     span.TraceID().String(),
+					SpanId:      span.SpanID().String(),
+					ServiceName: serviceName,
+					SpanKind:    spanKind,
+					DurationMs:  durationMs,
+					IsError:     isError,
+				})
+
+				if !r.shouldStoreTrace(isError, durationMs) {
+					continue
+				}
+
+				traces = append(traces, &agentv1.EbpfTraceSpan{
+					TraceId:      span.TraceID().String(),
+					SpanId:       span.SpanID().String(),
+					ParentSpanId: span.ParentSpanID().String(),
+					ServiceName:  serviceName,
+					SpanName:     span.Name(),
+					SpanKind:     strings.ToLower(spanKind),
+					StartTime:    startTime.UnixMilli(),
+					DurationUs:   duration.Microseconds(),
+					StatusCode:   otlpStatusCode(span.Attributes()),
+					Attributes:   otlpAttributes(span.Attributes()),
+				})
+			}
+		}
+	}
+
+	r.aggMu.Lock()
+	r.aggregator.AddSpans(constants.OTLPIngestAgentID, spans)
+	r.aggMu.Unlock()
+
+	if err := r.db.InsertBeylaTraces(ctx, constants.OTLPIngestAgentID, traces); err != nil {
+		return fmt.Errorf("failed to store traces: %w", err)
+	}
+
+	return nil
+}
+
+// shouldStoreTrace applies the same rules as the agent span filter: errors
+// and high-latency spans are always kept, other spans are sampled.
+func (r *OTLPReceiver) shouldStoreTrace(isError bool, durationMs float64) bool {
+	if isError || durationMs > constants.DefaultHighLatencyThresholdMs {
+		return true
+	}
+	//nolint:gosec // G404: Weak random is acceptable for telemetry sampling.
+	return rand.Float64() < r.config.SampleRate
+}
+
+// ConsumeMetrics stores HTTP, gRPC and SQL duration histograms. Only delta
+// temporality is accepted: the colony stores each export as an event, so
+// cumulative histograms would be counted again on every export.
+func (r *OTLPReceiver) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var (
+		httpMetrics []*agentv1.EbpfHttpMetric
+		grpcMetrics []*agentv1.EbpfGrpcMetric
+		sqlMetrics  []*agentv1.EbpfSqlMetric
+	)
+
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		serviceName := otlpServiceName(rm.Resource())
+
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				if metric.Type() != pmetric.MetricTypeHistogram {
+					continue
+				}
+
+				hist := metric.Histogram()
+				if hist.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+					r.warnCumulative(serviceName, metric.Name())
+					continue
+				}
+
+				for l := 0; l < hist.DataPoints().Len(); l++ {
+					dp := hist.DataPoints().At(l)
+					attrs := dp.Attributes()
+					timestamp := dp.Timestamp().AsTime().UnixMilli()
+					buckets, counts := otlpHistogramBuckets(dp, metric.Unit())
+
+					switch metric.Name() {
+					case "http.server.request.duration", "http.server.duration":
+						httpMetrics = append(httpMetrics, &agentv1.EbpfHttpMetric{
+							Timestamp:      timestamp,
+							ServiceName:    serviceName,
+							HttpMethod:     otlpStringAttr(attrs, "GET", "http.request.method", "http.method"),
+							HttpRoute:      otlpStringAttr(attrs, "/", "http.route", "url.path"),
+							HttpStatusCode: otlpUintAttr(attrs, 200, "http.response.status_code", "http.status_code"),
+							LatencyBuckets: buckets,
+							LatencyCounts:  counts,
+							RequestCount:   dp.Count(),
+							Attributes:     otlpAttributes(attrs),
+						})
+
+					case "rpc.server.duration":
+						grpcMetrics = append(grpcMetrics, &agentv1.EbpfGrpcMetric{
+							Timestamp:      timestamp,
+							ServiceName:    serviceName,
+							GrpcMethod:     otlpStringAttr(attrs, "unknown", "rpc.method"),
+							GrpcStatusCode: otlpUintAttr(attrs, 0, "rpc.grpc.status_code"),
+							LatencyBuckets: buckets,
+							LatencyCounts:  counts,
+							RequestCount:   dp.Count(),
+							Attributes:     otlpAttributes(attrs),
+						})
+
+					case "db.client.operation.duration":
+						sqlMetrics = append(sqlMetrics, &agentv1.EbpfSqlMetric{
+							Timestamp:      timestamp,
+							ServiceName:    serviceName,
+							SqlOperation:   otlpStringAttr(attrs, "QUERY", "db.operation.name", "db.operation"),
+							TableName:      otlpStringAttr(attrs, "", "db.collection.name", "db.sql.table"),
+							LatencyBuckets: buckets,
+							LatencyCounts:  counts,
+							QueryCount:     dp.Count(),
+							Attributes:     otlpAttributes(attrs),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	if err := r.db.InsertBeylaHTTPMetrics(ctx, constants.OTLPIngestAgentID, httpMetrics); err != nil {
+		return fmt.Errorf("failed to store HTTP metrics: %w", err)
+	}
+	if err := r.db.InsertBeylaGRPCMetrics(ctx, constants.OTLPIngestAgentID, grpcMetrics); err != nil {
+		return fmt.Errorf("failed to store gRPC metrics: %w", err)
+	}
+	if err := r.db.InsertBeylaSQLMetrics(ctx, constants.OTLPIngestAgentID, sqlMetrics); err != nil {
+		return fmt.Errorf("failed to store SQL metrics: %w", err)
+	}
+
+	return nil
+}
+
+// warnCumulative logs once per service that cumulative histograms are ignored.
+func (r *OTLPReceiver) warnCumulative(serviceName, metricName string) {
+	r.warnMu.Lock()
+	defer r.warnMu.Unlock()
+
+	if r.warnedCumulative[serviceName] {
+		return
+	}
+	r.warnedCumulative[serviceName] = true
+
+	r.logger.Warn().
+		Str("service", serviceName).
+		Str("metric", metricName).
+		Msg("Ignoring cumulative histograms; set OTEL_EXPORTER_OTLP_METRICS_TEMPORALITY_PREFERENCE=delta in the application")
+}
+
+// runFlushLoop periodically writes span summaries until ctx is canceled.
+func (r *OTLPReceiver) runFlushLoop(ctx context.Context) {
+	ticker := time.NewTicker(constants.DefaultOTLPIngestFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				r.logger.Error().Err(err).Msg("Failed to write span summaries")
+			}
+		}
+	}
+}
+
+// Flush writes the summaries of all buckets aggregated so far. Buckets are
+// kept for constants.OTLPIngestSummaryHorizon after their minute ends, so
+// each flush rewrites a bucket with every span received for it.
+func (r *OTLPReceiver) Flush(ctx context.Context) error {
+	r.aggMu.Lock()
+	defer r.aggMu.Unlock()
+
+	if err := r.db.InsertTelemetrySummaries(ctx, r.aggregator.GetSummaries()); err != nil {
+		return err
+	}
+
+	r.aggregator.ClearBefore(time.Now().Add(-constants.OTLPIngestSummaryHorizon).Truncate(time.Minute))
+	return nil
+}
+
+// otlpServiceName returns the service.name resource attribute.
+func otlpServiceName(resource pcommon.Resource) string {
+	if v, ok := resource.Attributes().Get("service.name"); ok && v.Str() != "" {
+		return v.Str()
+	}
+	return "unknown"
+}
+
+// otlpSpanKind converts an OTLP span kind to the upper-case form used in
+// telemetry summaries.
+func otlpSpanKind(kind ptrace.SpanKind) string {
+	switch kind {
+	case ptrace.SpanKindInternal:
+		return "INTERNAL"
+	case ptrace.SpanKindServer:
+		return "SERVER"
+	case ptrace.SpanKindClient:
+		return "CLIENT"
+	case ptrace.SpanKindProducer:
+		return "PRODUCER"
+	case ptrace.SpanKindConsumer:
+		return "CONSUMER"
+	default:
+		return "UNSPECIFIED"
+	}
+}
+
+// otlpStatusCode returns the HTTP or gRPC status code of a span.
+func otlpStatusCode(attrs pcommon.Map) uint32 {
+	return otlpUintAttr(attrs, 0, "http.response.status_code", "http.status_code", "rpc.grpc.status_code")
+}
+
+// otlpStringAttr returns the first of keys present in attrs.
+func otlpStringAttr(attrs pcommon.Map, defaultValue string, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := attrs.Get(key); ok {
+			return v.AsString()
+		}
+	}
+	return defaultValue
+}
+
+// otlpUintAttr returns the first of keys present in attrs as an integer.
+func otlpUintAttr(attrs pcommon.Map, defaultValue uint32, keys ...string) uint32 {
+	for _, key := range keys {
+		if v, ok := attrs.Get(key); ok && v.Type() == pcommon.ValueTypeInt && v.Int() >= 0 {
+			return uint32(v.Int()) //nolint:gosec // G115: Checked non-negative; status codes are small.
+		}
+	}
+	return defaultValue
+}
+
+// otlpAttributes converts OTLP attributes to a string map.
+func otlpAttributes(attrs pcommon.Map) map[string]string {
+	result := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		result[k] = v.AsString()
+		return true
+	})
+	return result
+}
+
+// otlpHistogramBuckets returns the explicit bounds of a histogram data point
+// in milliseconds, and its bucket counts.
+func otlpHistogramBuckets(dp pmetric.HistogramDataPoint, unit string) ([]float64, []uint64) {
+	bounds := dp.ExplicitBounds().AsRaw()
+	if unit == "s" {
+		for i := range bounds {
+			bounds[i] *= 1000
+		}
+	}
+	return bounds, dp.BucketCounts().AsRaw()
+}
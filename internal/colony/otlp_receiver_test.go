@@ -0,0 +1,207 @@
+package colony
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func newOTLPTestReceiver(t *testing.T) (*OTLPReceiver, *database.Database) {
+	t.Helper()
+
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// A tiny sample rate keeps only error and high-latency spans as traces.
+	return NewOTLPReceiver(config.OTLPIngestConfig{SampleRate: 1e-12}, db, zerolog.Nop()), db
+}
+
+func postOTLP(t *testing.T, handler http.Handler, path, contentType string, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOTLPReceiver_Traces(t *testing.T) {
+	ctx := context.Background()
+	r, db := newOTLPTestReceiver(t)
+
+	start := time.Now().Add(-time.Minute).Truncate(time.Minute).Add(10 * time.Second)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "otel-app")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	addSpan := func(id byte, duration time.Duration, isError bool) {
+		span := spans.AppendEmpty()
+		span.SetTraceID(pcommon.TraceID{1, id})
+		span.SetSpanID(pcommon.SpanID{2, id})
+		span.SetName("GET /api")
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(duration)))
+		span.Attributes().PutInt("http.response.status_code", 200)
+		if isError {
+			span.Status().SetCode(ptrace.StatusCodeError)
+			span.Attributes().PutInt("http.response.status_code", 500)
+		}
+	}
+	addSpan(1, 10*time.Millisecond, false)
+	addSpan(2, 20*time.Millisecond, false)
+	addSpan(3, 30*time.Millisecond, true)
+	addSpan(4, time.Second, false)
+
+	body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	if err != nil {
+		t.Fatalf("Failed to marshal traces: %v", err)
+	}
+
+	rec := postOTLP(t, r.Handler(), "/v1/traces", "application/x-protobuf", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	// Only the error and high-latency spans are stored as traces.
+	traces, err := db.QueryBeylaTraces(ctx, "", "otel-app", start.Add(-time.Minute), time.Now(), 0, 100)
+	if err != nil {
+		t.Fatalf("Failed to query traces: %v", err)
+	}
+	if len(traces) != 2 {
+		t.Fatalf("Expected 2 stored traces, got %d", len(traces))
+	}
+	for _, trace := range traces {
+		if trace.SpanKind != "server" {
+			t.Errorf("Expected span kind server, got %q", trace.SpanKind)
+		}
+	}
+
+	// Every span is counted in the summary.
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Failed to flush summaries: %v", err)
+	}
+	summaries, err := db.QueryTelemetrySummaries(ctx, constants.OTLPIngestAgentID, start.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatalf("Failed to query summaries: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("Expected 1 summary, got %d", len(summaries))
+	}
+	if summaries[0].ServiceName != "otel-app" || summaries[0].SpanKind != "SERVER" {
+		t.Errorf("Unexpected summary %s/%s", summaries[0].ServiceName, summaries[0].SpanKind)
+	}
+	if summaries[0].TotalSpans != 4 || summaries[0].ErrorCount != 1 {
+		t.Errorf("Expected 4 spans and 1 error, got %d and %d", summaries[0].TotalSpans, summaries[0].ErrorCount)
+	}
+
+	// A later export for the same minute rewrites the bucket with all spans.
+	rec = postOTLP(t, r.Handler(), "/v1/traces", "application/x-protobuf", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("Failed to flush summaries: %v", err)
+	}
+	summaries, err = db.QueryTelemetrySummaries(ctx, constants.OTLPIngestAgentID, start.Add(-time.Minute), time.Now())
+	if err != nil {
+		t.Fatalf("Failed to query summaries: %v", err)
+	}
+	if len(summaries) != 1 || summaries[0].TotalSpans != 8 {
+		t.Errorf("Expected a single summary of 8 spans, got %+v", summaries)
+	}
+}
+
+func TestOTLPReceiver_Metrics(t *testing.T) {
+	ctx := context.Background()
+	r, db := newOTLPTestReceiver(t)
+
+	now := time.Now().Truncate(time.Second)
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "otel-app")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	addHistogram := func(temporality pmetric.AggregationTemporality, route string) {
+		m := metrics.AppendEmpty()
+		m.SetName("http.server.request.duration")
+		m.SetUnit("s")
+		hist := m.SetEmptyHistogram()
+		hist.SetAggregationTemporality(temporality)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(now))
+		dp.Attributes().PutStr("http.request.method", "GET")
+		dp.Attributes().PutStr("http.route", route)
+		dp.Attributes().PutInt("http.response.status_code", 200)
+		dp.ExplicitBounds().FromRaw([]float64{0.01, 0.1})
+		dp.BucketCounts().FromRaw([]uint64{3, 2, 0})
+		dp.SetCount(5)
+	}
+	addHistogram(pmetric.AggregationTemporalityDelta, "/delta")
+	addHistogram(pmetric.AggregationTemporalityCumulative, "/cumulative")
+
+	body, err := pmetricotlp.NewExportRequestFromMetrics(md).MarshalJSON()
+	if err != nil {
+		t.Fatalf("Failed to marshal metrics: %v", err)
+	}
+
+	rec := postOTLP(t, r.Handler(), "/v1/metrics", "application/json", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Expected JSON response, got %q", got)
+	}
+
+	results, err := db.QueryBeylaHTTPMetrics(ctx, "otel-app", now.Add(-time.Minute), now.Add(time.Minute), nil)
+	if err != nil {
+		t.Fatalf("Failed to query HTTP metrics: %v", err)
+	}
+
+	// Cumulative histograms are ignored; bounds are converted to milliseconds.
+	counts := make(map[float64]int64)
+	for _, res := range results {
+		if res.HTTPRoute != "/delta" {
+			t.Errorf("Unexpected route %q", res.HTTPRoute)
+		}
+		counts[res.LatencyBucketMs] += res.Count
+	}
+	if counts[10] != 3 || counts[100] != 2 {
+		t.Errorf("Expected 3 requests under 10ms and 2 under 100ms, got %v", counts)
+	}
+}
+
+func TestOTLPReceiver_InvalidRequest(t *testing.T) {
+	r, _ := newOTLPTestReceiver(t)
+
+	rec := postOTLP(t, r.Handler(), "/v1/traces", "application/x-protobuf", []byte("not protobuf"))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/metrics", nil)
+	rec = httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405, got %d", rec.Code)
+	}
+}
@@ -173,17 +173,12 @@ func ForEachHealthyAgent(
 	logger zerolog.Logger,
 	visitor AgentVisitor,
 ) (success, errors int) {
-	agents := reg.ListAll()
+	agents := healthyAgents(reg, time.Now())
 	if len(agents) == 0 {
-		logger.Debug().Msg("No agents registered, skipping poll")
+		logger.Debug().Msg("No healthy agents registered, skipping poll")
 		return 0, 0
 	}
-	now := time.Now()
 	for _, agent := range agents {
-		status := registry.DetermineStatus(agent.LastSeen, now)
-		if status == registry.StatusUnhealthy {
-			continue
-		}
 		if err := visitor(agent); err != nil {
 			logger.Warn().Err(err).
 				Str("agent_id", agent.AgentID).
@@ -0,0 +1,286 @@
+package poller
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// latencyEWMAWeight is the weight of the latest sample in the average latency.
+const latencyEWMAWeight = 0.2
+
+// ScheduleConfig configures adaptive per-agent polling.
+type ScheduleConfig struct {
+	// Interval is the poll cycle interval. Agent polls of a cycle are spread
+	// over the first half of it.
+	Interval time.Duration
+
+	// SlowThreshold is the poll latency above which an agent is backed off
+	// (default: constants.DefaultPollerSlowAgentThreshold).
+	SlowThreshold time.Duration
+
+	// MaxBackoffCycles caps how many cycles a slow or failing agent is
+	// skipped for (default: constants.DefaultPollerMaxBackoffCycles).
+	MaxBackoffCycles int
+
+	// MaxIdleSkipCycles caps how many cycles an agent without new data is
+	// skipped for (default: constants.DefaultPollerMaxIdleSkipCycles).
+	// Set to a negative value to poll idle agents every cycle.
+	MaxIdleSkipCycles int
+}
+
+// AgentPollStats holds poll statistics of a single agent.
+type AgentPollStats struct {
+	AgentID      string        `json:"agent_id"`
+	Polls        uint64        `json:"polls"`
+	Failures     uint64        `json:"failures"`
+	Skipped      uint64        `json:"skipped"`
+	LastLatency  time.Duration `json:"last_latency_ns"`
+	AvgLatency   time.Duration `json:"avg_latency_ns"`
+	MaxLatency   time.Duration `json:"max_latency_ns"`
+	LastPollAt   time.Time     `json:"last_poll_at"`
+	SkipCycles   int           `json:"skip_cycles"`
+	BackoffLevel int           `json:"backoff_level"`
+}
+
+// agentSchedule is the scheduling state of a single agent.
+type agentSchedule struct {
+	stats   AgentPollStats
+	skip    int // cycles left to skip
+	backoff int // cycles skipped after the latest slow or failed poll
+	idle    int // consecutive polls without new data
+}
+
+// Scheduler decides which agents are polled in a cycle and records their poll
+// latency. Agents are polled every cycle while they return new data. Agents
+// that are slow or failing are skipped for an exponentially growing number of
+// cycles, and agents without new data since their checkpoint (delta sync, RFD
+// 089) are skipped for a bounded number of cycles.
+type Scheduler struct {
+	interval      time.Duration
+	slowThreshold time.Duration
+	maxBackoff    int
+	maxIdleSkip   int
+
+	mu     sync.Mutex
+	agents map[string]*agentSchedule
+}
+
+// NewScheduler creates a new scheduler.
+func NewScheduler(cfg ScheduleConfig) *Scheduler {
+	if cfg.SlowThreshold == 0 {
+		cfg.SlowThreshold = constants.DefaultPollerSlowAgentThreshold
+	}
+	if cfg.MaxBackoffCycles == 0 {
+		cfg.MaxBackoffCycles = constants.DefaultPollerMaxBackoffCycles
+	}
+	if cfg.MaxIdleSkipCycles == 0 {
+		cfg.MaxIdleSkipCycles = constants.DefaultPollerMaxIdleSkipCycles
+	}
+
+	return &Scheduler{
+		interval:      cfg.Interval,
+		slowThreshold: cfg.SlowThreshold,
+		maxBackoff:    cfg.MaxBackoffCycles,
+		maxIdleSkip:   max(cfg.MaxIdleSkipCycles, 0),
+		agents:        make(map[string]*agentSchedule),
+	}
+}
+
+// Stats returns the poll statistics of all known agents, sorted by agent ID.
+func (s *Scheduler) Stats() []AgentPollStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make([]AgentPollStats, 0, len(s.agents))
+	for _, a := range s.agents {
+		st := a.stats
+		st.SkipCycles = a.skip
+		st.BackoffLevel = a.backoff
+		stats = append(stats, st)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].AgentID < stats[j].AgentID })
+	return stats
+}
+
+// plan returns the agents due in this cycle, and the number of skipped ones.
+// State of agents that are no longer listed is dropped.
+func (s *Scheduler) plan(agents []*registry.Entry) (due []*registry.Entry, skipped int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	listed := make(map[string]bool, len(agents))
+	for _, agent := range agents {
+		listed[agent.AgentID] = true
+
+		a, ok := s.agents[agent.AgentID]
+		if !ok {
+			a = &agentSchedule{stats: AgentPollStats{AgentID: agent.AgentID}}
+			s.agents[agent.AgentID] = a
+		}
+		if a.skip > 0 {
+			a.skip--
+			a.stats.Skipped++
+			skipped++
+			continue
+		}
+		due = append(due, agent)
+	}
+
+	for agentID := range s.agents {
+		if !listed[agentID] {
+			delete(s.agents, agentID)
+		}
+	}
+
+	return due, skipped
+}
+
+// record updates the schedule of an agent after a poll.
+func (s *Scheduler) record(agentID string, latency time.Duration, changed bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.agents[agentID]
+	if !ok {
+		return
+	}
+
+	st := &a.stats
+	st.Polls++
+	st.LastLatency = latency
+	st.LastPollAt = time.Now()
+	st.MaxLatency = max(st.MaxLatency, latency)
+	if st.Polls == 1 {
+		st.AvgLatency = latency
+	} else {
+		st.AvgLatency = time.Duration(latencyEWMAWeight*float64(latency) + (1-latencyEWMAWeight)*float64(st.AvgLatency))
+	}
+
+	switch {
+	case err != nil || latency > s.slowThreshold:
+		if err != nil {
+			st.Failures++
+		}
+		a.backoff = min(max(a.backoff*2, 1), s.maxBackoff)
+		a.skip = a.backoff
+		a.idle = 0
+	case !changed:
+		a.backoff = 0
+		a.idle++
+		a.skip = min(a.idle, s.maxIdleSkip)
+	default:
+		a.backoff = 0
+		a.idle = 0
+		a.skip = 0
+	}
+}
+
+// DeltaVisitor is called for each agent due in a cycle. It reports whether
+// the agent returned data past its checkpoint.
+type DeltaVisitor func(agent *registry.Entry) (changed bool, err error)
+
+// ForEachDueAgent polls the healthy agents that are due in this cycle. Polls
+// are staggered over the first half of the scheduler interval, so that agents
+// are not all queried at once, and their latency is recorded in the
+// scheduler. Returns (successCount, errorCount, skippedCount).
+func ForEachDueAgent(
+	ctx context.Context,
+	reg *registry.Registry,
+	sched *Scheduler,
+	logger zerolog.Logger,
+	visitor DeltaVisitor,
+) (success, errors, skipped int) {
+	agents := healthyAgents(reg, time.Now())
+	if len(agents) == 0 {
+		logger.Debug().Msg("No healthy agents registered, skipping poll")
+		return 0, 0, 0
+	}
+
+	due, skipped := sched.plan(agents)
+
+	var step time.Duration
+	if len(due) > 1 {
+		step = sched.interval / 2 / time.Duration(len(due))
+	}
+
+	start := time.Now()
+	for i, agent := range due {
+		if wait := time.Until(start.Add(time.Duration(i) * step)); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return success, errors, skipped
+			case <-timer.C:
+			}
+		}
+
+		pollStart := time.Now()
+		changed, err := visitor(agent)
+		sched.record(agent.AgentID, time.Since(pollStart), changed, err)
+
+		if err != nil {
+			logger.Warn().Err(err).
+				Str("agent_id", agent.AgentID).
+				Str("mesh_ip", agent.MeshIPv4).
+				Msg("Failed to poll agent")
+			errors++
+			continue
+		}
+		success++
+	}
+
+	return success, errors, skipped
+}
+
+// healthyAgents returns the registered agents that are not unhealthy, sorted
+// by agent ID.
+func healthyAgents(reg *registry.Registry, now time.Time) []*registry.Entry {
+	var healthy []*registry.Entry
+	for _, agent := range reg.ListAll() {
+		if registry.DetermineStatus(agent.LastSeen, now) == registry.StatusUnhealthy {
+			continue
+		}
+		healthy = append(healthy, agent)
+	}
+	sort.Slice(healthy, func(i, j int) bool { return healthy[i].AgentID < healthy[j].AgentID })
+	return healthy
+}
+
+// StatsRegistry collects the schedulers of named pollers so that their
+// per-agent poll statistics can be exposed.
+type StatsRegistry struct {
+	mu         sync.RWMutex
+	schedulers map[string]*Scheduler
+}
+
+// NewStatsRegistry creates an empty stats registry.
+func NewStatsRegistry() *StatsRegistry {
+	return &StatsRegistry{schedulers: make(map[string]*Scheduler)}
+}
+
+// Register adds the scheduler of a poller.
+func (r *StatsRegistry) Register(name string, sched *Scheduler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.schedulers[name] = sched
+}
+
+// Snapshot returns the per-agent poll statistics of each registered poller.
+func (r *StatsRegistry) Snapshot() map[string][]AgentPollStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := make(map[string][]AgentPollStats, len(r.schedulers))
+	for name, sched := range r.schedulers {
+		snapshot[name] = sched.Stats()
+	}
+	return snapshot
+}
@@ -0,0 +1,126 @@
+package poller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/synctest"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/registry"
+)
+
+func newTestRegistry(t *testing.T, agentIDs ...string) *registry.Registry {
+	t.Helper()
+
+	reg := registry.New(nil)
+	for i, id := range agentIDs {
+		if _, err := reg.Register(id, "", fmt.Sprintf("10.0.0.%d", i+1), "", nil, nil, ""); err != nil {
+			t.Fatalf("Failed to register agent: %v", err)
+		}
+	}
+	return reg
+}
+
+func TestForEachDueAgent_Staggers(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		reg := newTestRegistry(t, "agent-a", "agent-b", "agent-c", "agent-d")
+		sched := NewScheduler(ScheduleConfig{Interval: 40 * time.Second})
+
+		start := time.Now()
+		var offsets []time.Duration
+		success, failed, skipped := ForEachDueAgent(context.Background(), reg, sched, zerolog.Nop(), func(agent *registry.Entry) (bool, error) {
+			offsets = append(offsets, time.Since(start))
+			return true, nil
+		})
+
+		if success != 4 || failed != 0 || skipped != 0 {
+			t.Fatalf("expected 4 successful polls, got success=%d failed=%d skipped=%d", success, failed, skipped)
+		}
+
+		// Polls are spread over the first half of the interval.
+		for i, offset := range offsets {
+			if want := time.Duration(i) * 5 * time.Second; offset != want {
+				t.Errorf("poll %d started at %v, expected %v", i, offset, want)
+			}
+		}
+	})
+}
+
+func TestForEachDueAgent_BacksOffSlowAgents(t *testing.T) {
+	synctest.Test(t, func(t *testing.T) {
+		reg := newTestRegistry(t, "fast", "slow")
+		sched := NewScheduler(ScheduleConfig{
+			SlowThreshold:    time.Second,
+			MaxBackoffCycles: 2,
+		})
+
+		polled := make(map[string]int)
+		visitor := func(agent *registry.Entry) (bool, error) {
+			polled[agent.AgentID]++
+			if agent.AgentID == "slow" {
+				time.Sleep(2 * time.Second)
+			}
+			return true, nil
+		}
+
+		// Cycle 1 polls both; the slow agent then skips 1 cycle, then 2.
+		for range 6 {
+			ForEachDueAgent(context.Background(), reg, sched, zerolog.Nop(), visitor)
+		}
+
+		if polled["fast"] != 6 {
+			t.Errorf("expected fast agent polled every cycle, got %d", polled["fast"])
+		}
+		if polled["slow"] != 3 {
+			t.Errorf("expected slow agent polled in cycles 1, 3 and 6, got %d polls", polled["slow"])
+		}
+
+		stats := sched.Stats()
+		if len(stats) != 2 || stats[1].AgentID != "slow" {
+			t.Fatalf("unexpected stats: %+v", stats)
+		}
+		if stats[1].LastLatency != 2*time.Second || stats[1].Skipped != 3 || stats[1].BackoffLevel != 2 {
+			t.Errorf("unexpected slow agent stats: %+v", stats[1])
+		}
+	})
+}
+
+func TestForEachDueAgent_SkipsUnchangedAgents(t *testing.T) {
+	reg := newTestRegistry(t, "idle", "busy")
+	sched := NewScheduler(ScheduleConfig{MaxIdleSkipCycles: 1})
+
+	polled := make(map[string]int)
+	failIdle := false
+	visitor := func(agent *registry.Entry) (bool, error) {
+		polled[agent.AgentID]++
+		if agent.AgentID == "idle" && failIdle {
+			return false, errors.New("unreachable")
+		}
+		return agent.AgentID == "busy", nil
+	}
+
+	for range 4 {
+		ForEachDueAgent(context.Background(), reg, sched, zerolog.Nop(), visitor)
+	}
+
+	if polled["busy"] != 4 {
+		t.Errorf("expected busy agent polled every cycle, got %d", polled["busy"])
+	}
+	if polled["idle"] != 2 {
+		t.Errorf("expected idle agent polled every other cycle, got %d", polled["idle"])
+	}
+
+	// A failed poll is counted and backs the agent off.
+	failIdle = true
+	_, failed, _ := ForEachDueAgent(context.Background(), reg, sched, zerolog.Nop(), visitor)
+	if failed != 1 {
+		t.Errorf("expected 1 failed poll, got %d", failed)
+	}
+	if stats := sched.Stats(); stats[1].Failures != 1 || stats[1].SkipCycles != 1 {
+		t.Errorf("expected a failure recorded and the agent backed off, got %+v", stats[1])
+	}
+}
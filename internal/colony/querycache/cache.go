@@ -0,0 +1,230 @@
+// Package querycache caches the results of expensive colony queries, such as
+// historical profiles, the service topology and service summaries, so that
+// repeated queries from AI assistants and dashboards are served from memory.
+//
+// Results are kept in an LRU cache with a TTL, keyed by the normalized query
+// parameters. Each result records the kinds of ingested data it was computed
+// from, and is dropped as soon as new data of one of these kinds is ingested.
+package querycache
+
+import (
+	"container/list"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Source is a kind of ingested data that cached results depend on.
+type Source string
+
+const (
+	// Metrics are Beyla metrics, OTel telemetry summaries and system metrics.
+	Metrics Source = "metrics"
+
+	// Traces are Beyla trace spans.
+	Traces Source = "traces"
+
+	// Profiles are CPU and memory profile summaries.
+	Profiles Source = "profiles"
+
+	// Connections are the L4 connections reported by agents.
+	Connections Source = "connections"
+)
+
+// Cache is an LRU cache of query results with a TTL. It is safe for
+// concurrent use. A nil Cache caches nothing.
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	lru      *list.List // Most recently used first.
+
+	// generation is incremented by every invalidation, so results computed
+	// while data was ingested are not cached.
+	generation uint64
+
+	hits   uint64
+	misses uint64
+
+	now func() time.Time
+}
+
+type entry struct {
+	key       string
+	value     any
+	sources   []Source
+	expiresAt time.Time
+}
+
+// New creates a cache holding up to capacity results for ttl. Zero values
+// select the defaults.
+func New(capacity int, ttl time.Duration) *Cache {
+	if capacity <= 0 {
+		capacity = constants.DefaultQueryCacheSize
+	}
+	if ttl <= 0 {
+		ttl = constants.DefaultQueryCacheTTL
+	}
+	return &Cache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get returns the cached result for key, computing and caching it with
+// compute if it is missing or expired. The result is cached as depending on
+// sources; errors are not cached. Concurrent misses for the same key each
+// compute the result.
+func Get[T any](ctx context.Context, c *Cache, key string, sources []Source, compute func(context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return compute(ctx)
+	}
+
+	if value, ok := c.get(key); ok {
+		return value.(T), nil
+	}
+
+	generation := c.currentGeneration()
+	value, err := compute(ctx)
+	if err != nil {
+		return value, err
+	}
+	c.put(key, value, sources, generation)
+	return value, nil
+}
+
+// Invalidate drops the cached results that depend on any of sources. It is
+// called when data of these kinds is ingested.
+func (c *Cache) Invalidate(sources ...Source) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for el := c.lru.Front(); el != nil; {
+		next := el.Next()
+		if e := el.Value.(*entry); dependsOn(e.sources, sources) {
+			c.remove(el)
+		}
+		el = next
+	}
+}
+
+// Stats returns the number of cached results, and the cache hits and misses
+// so far.
+func (c *Cache) Stats() (size int, hits, misses uint64) {
+	if c == nil {
+		return 0, 0, 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len(), c.hits, c.misses
+}
+
+func (c *Cache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if ok && c.now().Before(el.Value.(*entry).expiresAt) {
+		c.lru.MoveToFront(el)
+		c.hits++
+		return el.Value.(*entry).value, true
+	}
+	if ok {
+		c.remove(el)
+	}
+	c.misses++
+	return nil, false
+}
+
+func (c *Cache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// put caches value unless the cache was invalidated since generation.
+func (c *Cache) put(key string, value any, sources []Source, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	c.entries[key] = c.lru.PushFront(&entry{
+		key:       key,
+		value:     value,
+		sources:   sources,
+		expiresAt: c.now().Add(c.ttl),
+	})
+	for c.lru.Len() > c.capacity {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.entries, el.Value.(*entry).key)
+}
+
+func dependsOn(have, invalidated []Source) bool {
+	for _, h := range have {
+		for _, s := range invalidated {
+			if h == s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Key returns the cache key of a query: the method name and its request,
+// marshaled deterministically so that equal requests have equal keys.
+// Timestamps in the request are truncated to granularity, so that queries
+// over time ranges ending "now" share a key for that long; zero keeps them
+// exact.
+func Key(method string, req proto.Message, granularity time.Duration) (string, error) {
+	if granularity > 0 {
+		req = proto.Clone(req)
+		truncateTimestamps(req.ProtoReflect(), granularity)
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal query: %w", err)
+	}
+	return method + ":" + base64.RawStdEncoding.EncodeToString(data), nil
+}
+
+// truncateTimestamps truncates the top-level timestamp fields of msg.
+func truncateTimestamps(msg protoreflect.Message, granularity time.Duration) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return true
+		}
+		if ts, ok := v.Message().Interface().(*timestamppb.Timestamp); ok {
+			truncated := timestamppb.New(ts.AsTime().Truncate(granularity))
+			msg.Set(fd, protoreflect.ValueOfMessage(truncated.ProtoReflect()))
+		}
+		return true
+	})
+}
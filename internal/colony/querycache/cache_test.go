@@ -0,0 +1,153 @@
+package querycache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+// counter returns a compute function returning the number of times it was
+// called.
+func counter() func(context.Context) (int, error) {
+	calls := 0
+	return func(context.Context) (int, error) {
+		calls++
+		return calls, nil
+	}
+}
+
+func TestCacheGet(t *testing.T) {
+	ctx := context.Background()
+	c := New(2, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	compute := counter()
+	v, err := Get(ctx, c, "a", []Source{Metrics}, compute)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	// Cached until the TTL expires.
+	v, _ = Get(ctx, c, "a", []Source{Metrics}, compute)
+	assert.Equal(t, 1, v)
+
+	now = now.Add(time.Minute)
+	v, _ = Get(ctx, c, "a", []Source{Metrics}, compute)
+	assert.Equal(t, 2, v)
+
+	size, hits, misses := c.Stats()
+	assert.Equal(t, 1, size)
+	assert.Equal(t, uint64(1), hits)
+	assert.Equal(t, uint64(2), misses)
+}
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := New(2, time.Minute)
+
+	_, _ = Get(ctx, c, "a", nil, counter())
+	_, _ = Get(ctx, c, "b", nil, counter())
+	_, _ = Get(ctx, c, "a", nil, counter()) // a is now more recent than b.
+	_, _ = Get(ctx, c, "c", nil, counter())
+
+	_, ok := c.get("b")
+	assert.False(t, ok)
+	_, ok = c.get("a")
+	assert.True(t, ok)
+	_, ok = c.get("c")
+	assert.True(t, ok)
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	ctx := context.Background()
+	c := New(10, time.Minute)
+
+	_, _ = Get(ctx, c, "summary", []Source{Metrics, Profiles}, counter())
+	_, _ = Get(ctx, c, "topology", []Source{Connections}, counter())
+
+	c.Invalidate(Profiles)
+
+	_, ok := c.get("summary")
+	assert.False(t, ok)
+	_, ok = c.get("topology")
+	assert.True(t, ok)
+}
+
+func TestCacheSkipsResultsComputedDuringIngest(t *testing.T) {
+	ctx := context.Background()
+	c := New(10, time.Minute)
+
+	_, err := Get(ctx, c, "a", []Source{Metrics}, func(context.Context) (int, error) {
+		c.Invalidate(Traces)
+		return 1, nil
+	})
+	require.NoError(t, err)
+
+	_, ok := c.get("a")
+	assert.False(t, ok)
+}
+
+func TestCacheDoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	c := New(10, time.Minute)
+
+	_, err := Get(ctx, c, "a", nil, func(context.Context) (int, error) {
+		return 0, errors.New("boom")
+	})
+	require.Error(t, err)
+
+	v, err := Get(ctx, c, "a", nil, counter())
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
+
+func TestNilCache(t *testing.T) {
+	var c *Cache
+	compute := counter()
+
+	_, _ = Get(context.Background(), c, "a", nil, compute)
+	v, _ := Get(context.Background(), c, "a", nil, compute)
+	assert.Equal(t, 2, v)
+
+	c.Invalidate(Metrics)
+	size, _, _ := c.Stats()
+	assert.Zero(t, size)
+}
+
+func TestKey(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	req := func(end time.Time) *colonyv1.QueryHistoricalCPUProfileRequest {
+		return &colonyv1.QueryHistoricalCPUProfileRequest{
+			ServiceName: "api",
+			StartTime:   timestamppb.New(end.Add(-time.Hour)),
+			EndTime:     timestamppb.New(end),
+		}
+	}
+
+	k1, err := Key("QueryHistoricalCPUProfile", req(base.Add(2*time.Second)), 10*time.Second)
+	require.NoError(t, err)
+	k2, err := Key("QueryHistoricalCPUProfile", req(base.Add(7*time.Second)), 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, k1, k2)
+
+	k3, err := Key("QueryHistoricalCPUProfile", req(base.Add(12*time.Second)), 10*time.Second)
+	require.NoError(t, err)
+	assert.NotEqual(t, k1, k3)
+
+	k4, err := Key("QueryHistoricalMemoryProfile", req(base.Add(2*time.Second)), 10*time.Second)
+	require.NoError(t, err)
+	assert.NotEqual(t, k1, k4)
+
+	// The request is not modified.
+	r := req(base.Add(2 * time.Second))
+	_, err = Key("QueryHistoricalCPUProfile", r, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, base.Add(2*time.Second), r.EndTime.AsTime())
+}
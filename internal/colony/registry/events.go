@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/events"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// SetEventBroker sets the broker that receives agent and service events.
+// Must be called before the registry is used concurrently.
+func (r *Registry) SetEventBroker(broker *events.Broker) {
+	r.events = broker
+}
+
+// CheckDisconnected reports agents that stopped sending heartbeats since the
+// last check as disconnected, and records status transitions of connected
+// agents in the agent history.
+func (r *Registry) CheckDisconnected(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range r.entries {
+		if entry.disconnected {
+			continue
+		}
+
+		status := EntryStatus(entry, now)
+		if status == StatusUnhealthy {
+			entry.disconnected = true
+			entry.lastStatus = status
+			r.publishAgentEvent(entry, colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED, "Agent stopped sending heartbeats")
+			r.recordHistory(entry, database.AgentEventDisconnected, "Agent stopped sending heartbeats")
+			continue
+		}
+
+		if status != entry.lastStatus {
+			message := fmt.Sprintf("%s -> %s", entry.lastStatus, status)
+			entry.lastStatus = status
+			r.recordHistory(entry, database.AgentEventStatusChanged, message)
+		}
+	}
+}
+
+// MonitorStatus runs CheckDisconnected on every interval until ctx is
+// cancelled.
+func (r *Registry) MonitorStatus(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.CheckDisconnected(now)
+		}
+	}
+}
+
+// publishAgentEvent publishes an agent connectivity event. Caller must hold
+// r.mu.
+func (r *Registry) publishAgentEvent(entry *Entry, eventType colonyv1.ColonyEventType, message string) {
+	r.events.Publish(&colonyv1.ColonyEvent{
+		Type:    eventType,
+		AgentId: entry.AgentID,
+		Message: message,
+		Attributes: map[string]string{
+			"mesh_ipv4": entry.MeshIPv4,
+			"last_seen": entry.LastSeen.UTC().Format(time.RFC3339),
+		},
+	})
+}
+
+// recordHistory persists an agent history event asynchronously, with the
+// entry's current status. Caller must hold r.mu.
+func (r *Registry) recordHistory(entry *Entry, event, message string) {
+	if r.db == nil {
+		return
+	}
+
+	record := &database.AgentHistoryEvent{
+		Timestamp: time.Now(),
+		AgentID:   entry.AgentID,
+		Event:     event,
+		Status:    string(entry.lastStatus),
+		MeshIPv4:  entry.MeshIPv4,
+		Message:   message,
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), constants.DefaultColonyServiceQueryTimeout)
+		defer cancel()
+
+		if err := r.db.InsertAgentHistoryEvent(ctx, record); err != nil {
+			log.Warn().Err(err).
+				Str("agent_id", record.AgentID).
+				Str("event", record.Event).
+				Msg("Failed to persist agent history event")
+		}
+	}()
+}
+
+// publishServiceChanges publishes an event for every service added to or
+// removed from an agent's registration.
+func (r *Registry) publishServiceChanges(agentID string, previous, current []*meshv1.ServiceInfo) {
+	before := make(map[string]bool, len(previous))
+	for _, svc := range previous {
+		before[svc.Name] = true
+	}
+	after := make(map[string]bool, len(current))
+	for _, svc := range current {
+		after[svc.Name] = true
+	}
+
+	for _, svc := range current {
+		if before[svc.Name] {
+			continue
+		}
+		r.events.Publish(&colonyv1.ColonyEvent{
+			Type:        colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_REGISTERED,
+			AgentId:     agentID,
+			ServiceName: svc.Name,
+			Message:     "Service registered",
+		})
+	}
+	for _, svc := range previous {
+		if after[svc.Name] {
+			continue
+		}
+		r.events.Publish(&colonyv1.ColonyEvent{
+			Type:        colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_DEREGISTERED,
+			AgentId:     agentID,
+			ServiceName: svc.Name,
+			Message:     "Service deregistered",
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/colony/events"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// drain returns the events buffered on a subscription.
+func drain(sub *events.Subscription) []*colonyv1.ColonyEvent {
+	var out []*colonyv1.ColonyEvent
+	for {
+		select {
+		case event := <-sub.Events():
+			out = append(out, event)
+		default:
+			return out
+		}
+	}
+}
+
+func eventTypes(evts []*colonyv1.ColonyEvent) []colonyv1.ColonyEventType {
+	types := make([]colonyv1.ColonyEventType, len(evts))
+	for i, e := range evts {
+		types[i] = e.Type
+	}
+	return types
+}
+
+func TestRegistry_Events(t *testing.T) {
+	broker := events.NewBroker(zerolog.Nop())
+	sub := broker.Subscribe(events.Filter{})
+	defer broker.Unsubscribe(sub)
+
+	reg := New(nil)
+	reg.SetEventBroker(broker)
+
+	services := func(names ...string) []*meshv1.ServiceInfo {
+		out := make([]*meshv1.ServiceInfo, len(names))
+		for i, name := range names {
+			out[i] = &meshv1.ServiceInfo{Name: name}
+		}
+		return out
+	}
+
+	t.Run("first registration", func(t *testing.T) {
+		_, err := reg.Register("agent-1", "", "100.64.0.2", "", services("api", "worker"), nil, "")
+		require.NoError(t, err)
+
+		evts := drain(sub)
+		assert.Equal(t, []colonyv1.ColonyEventType{
+			colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_CONNECTED,
+			colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_REGISTERED,
+			colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_REGISTERED,
+		}, eventTypes(evts))
+		assert.Equal(t, "api", evts[1].ServiceName)
+	})
+
+	t.Run("service changes", func(t *testing.T) {
+		_, err := reg.Register("agent-1", "", "100.64.0.2", "", services("api", "cache"), nil, "")
+		require.NoError(t, err)
+
+		evts := drain(sub)
+		require.Equal(t, []colonyv1.ColonyEventType{
+			colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_REGISTERED,
+			colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_DEREGISTERED,
+		}, eventTypes(evts), "re-registration is not a new connection")
+		assert.Equal(t, "cache", evts[0].ServiceName)
+		assert.Equal(t, "worker", evts[1].ServiceName)
+	})
+
+	t.Run("disconnect and reconnect", func(t *testing.T) {
+		now := time.Now()
+		reg.CheckDisconnected(now)
+		assert.Empty(t, drain(sub), "healthy agents are not reported")
+
+		later := now.Add(constants.DefaultAgentDegradedThreshold + time.Second)
+		reg.CheckDisconnected(later)
+		reg.CheckDisconnected(later)
+		evts := drain(sub)
+		require.Len(t, evts, 1, "disconnect is reported once")
+		assert.Equal(t, colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED, evts[0].Type)
+		assert.Equal(t, "agent-1", evts[0].AgentId)
+
+		require.NoError(t, reg.UpdateHeartbeat("agent-1"))
+		evts = drain(sub)
+		require.Len(t, evts, 1)
+		assert.Equal(t, colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_CONNECTED, evts[0].Type)
+	})
+	t.Run("eviction", func(t *testing.T) {
+		entry, err := reg.Evict(context.Background(), "agent-1", "Agent certificates revoked")
+		require.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, "100.64.0.2", entry.MeshIPv4)
+
+		evts := drain(sub)
+		assert.Equal(t, []colonyv1.ColonyEventType{
+			colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED,
+			colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_DEREGISTERED,
+			colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_DEREGISTERED,
+		}, eventTypes(evts))
+		assert.Equal(t, "Agent certificates revoked", evts[0].Message)
+
+		_, err = reg.Get("agent-1")
+		assert.Error(t, err)
+
+		entry, err = reg.Evict(context.Background(), "agent-1", "Agent certificates revoked")
+		require.NoError(t, err)
+		assert.Nil(t, entry, "unknown agents are not evicted")
+	})
+}
@@ -0,0 +1,68 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Health score penalties. Each one on its own is enough to take an agent
+// below DefaultAgentHealthyScore.
+const (
+	penaltyLateHeartbeat = 30
+	penaltyShedding      = 30
+	penaltyQueueDepth    = 25
+	penaltyEBPFFailures  = 25
+	penaltyClockSkew     = 25
+)
+
+// HealthScore scores an agent from 0 (unusable) to 100 (fully healthy) from
+// its heartbeat freshness and the metrics reported in its latest heartbeat,
+// and returns the causes of any reduction.
+func HealthScore(entry *Entry, now time.Time) (int, []string) {
+	elapsed := now.Sub(entry.LastSeen)
+	if elapsed >= constants.DefaultAgentDegradedThreshold {
+		return 0, []string{fmt.Sprintf("no heartbeat for %s", formatAge(elapsed))}
+	}
+
+	score := 100
+	var reasons []string
+	penalize := func(penalty int, reason string) {
+		score -= penalty
+		reasons = append(reasons, reason)
+	}
+
+	if elapsed >= constants.DefaultAgentHealthyThreshold {
+		penalize(penaltyLateHeartbeat, fmt.Sprintf("last heartbeat %s ago", formatAge(elapsed)))
+	}
+
+	if shedding := entry.ResourceShedding; shedding.GetActive() {
+		reason := "shedding load"
+		if len(shedding.GetStages()) > 0 {
+			reason += " (" + strings.Join(shedding.GetStages(), ", ") + ")"
+		}
+		penalize(penaltyShedding, reason)
+	}
+
+	if depth := entry.HealthMetrics.GetQueueDepth(); depth > constants.DefaultAgentQueueDepthThreshold {
+		penalize(penaltyQueueDepth, fmt.Sprintf("event queue depth %d", depth))
+	}
+
+	if failures := entry.HealthMetrics.GetEbpfFailures(); failures > 0 {
+		penalize(penaltyEBPFFailures, fmt.Sprintf("%d eBPF collector failures in %s",
+			failures, formatAge(constants.DefaultAgentHealthFailureWindow)))
+	}
+
+	if skew := entry.ClockSkew.Abs(); skew > constants.DefaultAgentClockSkewThreshold {
+		penalize(penaltyClockSkew, fmt.Sprintf("clock skew %s", skew.Round(time.Second)))
+	}
+
+	return max(score, 0), reasons
+}
+
+// formatAge formats a duration at second granularity, e.g. "45s" or "2m0s".
+func formatAge(d time.Duration) string {
+	return d.Truncate(time.Second).String()
+}
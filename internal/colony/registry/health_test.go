@@ -0,0 +1,106 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+)
+
+func TestHealthScore(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		entry       *Entry
+		wantScore   int
+		wantReasons []string
+		wantStatus  AgentStatus
+	}{
+		{
+			name:       "fresh heartbeat without metrics",
+			entry:      &Entry{LastSeen: now},
+			wantScore:  100,
+			wantStatus: StatusHealthy,
+		},
+		{
+			name:        "late heartbeat",
+			entry:       &Entry{LastSeen: now.Add(-45 * time.Second)},
+			wantScore:   70,
+			wantReasons: []string{"last heartbeat 45s ago"},
+			wantStatus:  StatusDegraded,
+		},
+		{
+			name:        "missing heartbeat",
+			entry:       &Entry{LastSeen: now.Add(-3 * time.Minute)},
+			wantScore:   0,
+			wantReasons: []string{"no heartbeat for 3m0s"},
+			wantStatus:  StatusUnhealthy,
+		},
+		{
+			name: "shedding load",
+			entry: &Entry{
+				LastSeen:         now,
+				ResourceShedding: &agentv1.ResourceShedding{Active: true, Stages: []string{"profiling"}},
+			},
+			wantScore:   70,
+			wantReasons: []string{"shedding load (profiling)"},
+			wantStatus:  StatusDegraded,
+		},
+		{
+			name: "backed up queue and eBPF failures",
+			entry: &Entry{
+				LastSeen:      now,
+				HealthMetrics: &meshv1.AgentHealthMetrics{QueueDepth: 20000, EbpfFailures: 3},
+			},
+			wantScore:   50,
+			wantReasons: []string{"event queue depth 20000", "3 eBPF collector failures in 5m0s"},
+			wantStatus:  StatusDegraded,
+		},
+		{
+			name:        "clock skew behind",
+			entry:       &Entry{LastSeen: now, ClockSkew: -42 * time.Second},
+			wantScore:   75,
+			wantReasons: []string{"clock skew 42s"},
+			wantStatus:  StatusDegraded,
+		},
+		{
+			name:       "small clock skew and queue",
+			entry:      &Entry{LastSeen: now, ClockSkew: time.Second, HealthMetrics: &meshv1.AgentHealthMetrics{QueueDepth: 10}},
+			wantScore:  100,
+			wantStatus: StatusHealthy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := HealthScore(tt.entry, now)
+			assert.Equal(t, tt.wantScore, score)
+			assert.Equal(t, tt.wantReasons, reasons)
+			assert.Equal(t, tt.wantStatus, EntryStatus(tt.entry, now))
+		})
+	}
+}
+
+func TestRegistry_UpdateHealthMetrics(t *testing.T) {
+	reg := New(nil)
+	_, err := reg.Register("agent-1", "frontend", "100.64.0.2", "", nil, nil, "")
+	require.NoError(t, err)
+
+	metrics := &meshv1.AgentHealthMetrics{QueueDepth: 5}
+	require.NoError(t, reg.UpdateHealthMetrics("agent-1", metrics, time.Now().Add(-time.Minute)))
+
+	entry, err := reg.Get("agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, metrics, entry.HealthMetrics)
+	assert.InDelta(t, -time.Minute, entry.ClockSkew, float64(time.Second))
+
+	require.NoError(t, reg.UpdateHealthMetrics("agent-1", nil, time.Time{}))
+	assert.Zero(t, entry.ClockSkew)
+
+	assert.Error(t, reg.UpdateHealthMetrics("missing", metrics, time.Now()))
+}
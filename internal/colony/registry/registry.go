@@ -5,15 +5,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/proto"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
 	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/events"
 	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/protocol"
 )
 
 // AgentStatus represents the health status of an agent.
@@ -25,17 +30,113 @@ const (
 	StatusUnhealthy AgentStatus = "unhealthy"
 )
 
+// ColonySecretStatus is which colony secret an agent holds.
+type ColonySecretStatus string
+
+const (
+	// ColonySecretNone: the agent registered without the colony secret,
+	// e.g. with a client certificate alone.
+	ColonySecretNone ColonySecretStatus = ""
+	// ColonySecretCurrent: the agent holds the current colony secret.
+	ColonySecretCurrent ColonySecretStatus = "current"
+	// ColonySecretPrevious: the agent holds the secret before the last
+	// rotation, accepted until its grace period ends.
+	ColonySecretPrevious ColonySecretStatus = "previous"
+)
+
 // Entry represents a registered agent in the colony.
 type Entry struct {
 	AgentID         string
 	Name            string // Deprecated: Use Services field for multi-service agents
 	MeshIPv4        string
 	MeshIPv6        string
+	PreferIPv6      bool // The agent's latest heartbeat arrived over IPv6
 	RegisteredAt    time.Time
 	LastSeen        time.Time
 	Services        []*meshv1.ServiceInfo           // RFD 011: Multi-service support
 	RuntimeContext  *agentv1.RuntimeContextResponse // RFD 018: Runtime context
 	ProtocolVersion string                          // RFD 018: Protocol version
+
+	// ResourceShedding is the agent's resource safety valve state from its
+	// latest heartbeat; nil when the agent has no resource limits.
+	ResourceShedding *agentv1.ResourceShedding
+
+	// HealthMetrics are the agent's self-reported health metrics from its
+	// latest heartbeat; nil for agents that do not report them.
+	HealthMetrics *meshv1.AgentHealthMetrics
+
+	// ClockSkew is the offset between the agent's and the colony's clocks,
+	// measured from the send time of the latest heartbeat.
+	ClockSkew time.Duration
+
+	// ColonySecret is which colony secret the agent registered with, or
+	// received when the secret was rotated.
+	ColonySecret ColonySecretStatus
+
+	// Version is the agent version, from its registration or latest
+	// heartbeat.
+	Version string
+
+	// UpdateError is why the agent failed to install the agent release the
+	// colony advertises, from its latest heartbeat.
+	UpdateError string
+
+	// Labels are the labels operators assigned to the agent through the
+	// colony. The map is replaced, never modified, when labels change.
+	Labels map[string]string
+
+	// ReportedLabels are the labels the agent registered with, from its
+	// configuration. Labels override them.
+	ReportedLabels map[string]string
+
+	// Drained is set when operators drained the agent: the colony starts no
+	// new debug sessions or profiles on it.
+	Drained bool
+
+	// disconnected is set once the agent is reported as disconnected, or when
+	// it was restored from the database and has not registered since.
+	disconnected bool
+
+	// lastStatus is the status last recorded in the agent history.
+	lastStatus AgentStatus
+}
+
+// MeshIP returns the mesh IP to reach the agent on: its IPv6 one if its
+// heartbeats arrive over IPv6, its IPv4 one otherwise.
+func (e *Entry) MeshIP() string {
+	if (e.PreferIPv6 || e.MeshIPv4 == "") && e.MeshIPv6 != "" {
+		return e.MeshIPv6
+	}
+	return e.MeshIPv4
+}
+
+// AllLabels returns the labels the agent reported, overridden by the labels
+// operators assigned to it. The result must not be modified.
+func (e *Entry) AllLabels() map[string]string {
+	if len(e.ReportedLabels) == 0 {
+		return e.Labels
+	}
+	if len(e.Labels) == 0 {
+		return e.ReportedLabels
+	}
+	labels := make(map[string]string, len(e.ReportedLabels)+len(e.Labels))
+	for k, v := range e.ReportedLabels {
+		labels[k] = v
+	}
+	for k, v := range e.Labels {
+		labels[k] = v
+	}
+	return labels
+}
+
+// Protocol returns the protocol version the agent reported at registration,
+// 1 for agents that did not report one.
+func (e *Entry) Protocol() int {
+	v, err := protocol.Parse(e.ProtocolVersion)
+	if err != nil {
+		return 1
+	}
+	return v
 }
 
 // Registry is an in-memory store for agent registrations.
@@ -43,13 +144,25 @@ type Registry struct {
 	mu      sync.RWMutex
 	entries map[string]*Entry
 	db      *database.Database
+	events  *events.Broker
+
+	// settings are persisted agent settings of agents that have not
+	// registered since the colony started.
+	settings map[string]agentSettings
+}
+
+// agentSettings are the colony-assigned settings of an agent.
+type agentSettings struct {
+	labels  map[string]string
+	drained bool
 }
 
 // New creates a new Registry.
 func New(db *database.Database) *Registry {
 	return &Registry{
-		entries: make(map[string]*Entry),
-		db:      db,
+		entries:  make(map[string]*Entry),
+		db:       db,
+		settings: make(map[string]agentSettings),
 	}
 }
 
@@ -61,6 +174,10 @@ func (r *Registry) LoadFromDatabase(ctx context.Context) error {
 		return nil
 	}
 
+	if err := r.loadSettings(ctx); err != nil {
+		return err
+	}
+
 	services, err := r.db.ListAllServices(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list services from database: %w", err)
@@ -131,7 +248,10 @@ func (r *Registry) LoadFromDatabase(ctx context.Context) error {
 			RegisteredAt: lastSeen,
 			LastSeen:     lastSeen,
 			Services:     services,
+			disconnected: true,
+			lastStatus:   StatusUnhealthy,
 		}
+		r.applySettings(entry)
 
 		r.entries[agentID] = entry
 		loadedCount++
@@ -146,6 +266,45 @@ func (r *Registry) LoadFromDatabase(ctx context.Context) error {
 	return nil
 }
 
+// loadSettings loads the persisted agent settings, applied to agents when
+// they are restored or register.
+func (r *Registry) loadSettings(ctx context.Context) error {
+	settings, err := r.db.ListAgentSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range settings {
+		labels, err := s.LabelMap()
+		if err != nil {
+			log.Warn().Err(err).Str("agent_id", s.AgentID).Msg("Failed to parse agent settings")
+			continue
+		}
+		if entry, ok := r.entries[s.AgentID]; ok {
+			entry.Labels = labels
+			entry.Drained = s.Drained
+			continue
+		}
+		r.settings[s.AgentID] = agentSettings{labels: labels, drained: s.Drained}
+	}
+	return nil
+}
+
+// applySettings applies the loaded settings of a new entry. The caller must
+// hold the lock.
+func (r *Registry) applySettings(entry *Entry) {
+	settings, ok := r.settings[entry.AgentID]
+	if !ok {
+		return
+	}
+	delete(r.settings, entry.AgentID)
+	entry.Labels = settings.labels
+	entry.Drained = settings.drained
+}
+
 // Register adds or updates an agent registration.
 // For backward compatibility, componentName can be provided for single-service agents.
 // Multi-service agents should provide services instead.
@@ -167,7 +326,15 @@ func (r *Registry) Register(
 
 	// Check if agent already exists.
 	var entry *Entry
+	var previousServices []*meshv1.ServiceInfo
+	connected := true
+	historyEvent := database.AgentEventRegistered
 	if existing, ok := r.entries[agentID]; ok {
+		previousServices = existing.Services
+		connected = existing.disconnected
+		existing.disconnected = false
+		historyEvent = database.AgentEventConnected
+
 		// Update existing entry.
 		existing.Name = name
 		existing.MeshIPv4 = meshIPv4
@@ -190,9 +357,17 @@ func (r *Registry) Register(
 			RuntimeContext:  runtimeContext,
 			ProtocolVersion: protocolVersion,
 		}
+		r.applySettings(entry)
 		r.entries[agentID] = entry
 	}
 
+	if connected {
+		entry.lastStatus = StatusHealthy
+		r.publishAgentEvent(entry, colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_CONNECTED, "Agent connected")
+		r.recordHistory(entry, historyEvent, "")
+	}
+	r.publishServiceChanges(agentID, previousServices, services)
+
 	// Persist to database asynchronously.
 	if r.db != nil {
 		log.Debug().Msg("DB is connected, attempting to persist services")
@@ -279,6 +454,12 @@ func (r *Registry) UpdateHeartbeat(agentID string) error {
 	}
 
 	entry.LastSeen = time.Now()
+	if entry.disconnected {
+		entry.disconnected = false
+		entry.lastStatus = StatusHealthy
+		r.publishAgentEvent(entry, colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_CONNECTED, "Agent reconnected")
+		r.recordHistory(entry, database.AgentEventConnected, "")
+	}
 
 	// Update persistence.
 	if r.db != nil {
@@ -297,6 +478,237 @@ func (r *Registry) UpdateHeartbeat(agentID string) error {
 	return nil
 }
 
+// UpdateHeartbeatAddr records the mesh IP an agent's heartbeat came from, so
+// that the colony calls the agent over the address family that works.
+// Addresses other than the agent's mesh IPs leave the preference unchanged.
+func (r *Registry) UpdateHeartbeatAddr(agentID, ip string) error {
+	if agentID == "" {
+		return fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[agentID]
+	if !ok {
+		return fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	switch ip {
+	case "":
+	case entry.MeshIPv6:
+		entry.PreferIPv6 = true
+	case entry.MeshIPv4:
+		entry.PreferIPv6 = false
+	}
+	return nil
+}
+
+// UpdateResourceShedding records the resource safety valve state reported in
+// an agent heartbeat and returns the previously recorded state.
+func (r *Registry) UpdateResourceShedding(
+	agentID string,
+	shedding *agentv1.ResourceShedding,
+) (*agentv1.ResourceShedding, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[agentID]
+	if !ok {
+		return nil, fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	previous := entry.ResourceShedding
+	entry.ResourceShedding = shedding
+	return previous, nil
+}
+
+// UpdateHealthMetrics records the health metrics reported in an agent
+// heartbeat. sentAt is the agent's send time and is used to estimate clock
+// skew; a zero sentAt (older agents) leaves the skew at zero.
+func (r *Registry) UpdateHealthMetrics(
+	agentID string,
+	metrics *meshv1.AgentHealthMetrics,
+	sentAt time.Time,
+) error {
+	if agentID == "" {
+		return fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[agentID]
+	if !ok {
+		return fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	entry.HealthMetrics = metrics
+	entry.ClockSkew = 0
+	if !sentAt.IsZero() {
+		entry.ClockSkew = sentAt.Sub(time.Now())
+	}
+	return nil
+}
+
+// UpdateVersion records the version and update error an agent reported. An
+// empty version, e.g. from older agents, keeps the recorded one.
+func (r *Registry) UpdateVersion(agentID, version, updateError string) error {
+	if agentID == "" {
+		return fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[agentID]
+	if !ok {
+		return fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	if version != "" {
+		entry.Version = version
+	}
+	entry.UpdateError = updateError
+	return nil
+}
+
+// SetColonySecretStatus records which colony secret an agent holds.
+func (r *Registry) SetColonySecretStatus(agentID string, status ColonySecretStatus) error {
+	if agentID == "" {
+		return fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[agentID]
+	if !ok {
+		return fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	entry.ColonySecret = status
+	return nil
+}
+
+// SetReportedLabels records the labels an agent registered with.
+func (r *Registry) SetReportedLabels(agentID string, labels map[string]string) error {
+	if agentID == "" {
+		return fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[agentID]
+	if !ok {
+		return fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	entry.ReportedLabels = labels
+	return nil
+}
+
+// SetServiceLabels records the labels an agent reports for one of its
+// services, e.g. those set through the SDK. Unknown services are ignored.
+func (r *Registry) SetServiceLabels(agentID, serviceName string, labels map[string]string) error {
+	if agentID == "" {
+		return fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[agentID]
+	if !ok {
+		return fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	for i, service := range entry.Services {
+		if service.Name != serviceName || maps.Equal(service.Labels, labels) {
+			continue
+		}
+		// Replace rather than modify the services, which callers may hold.
+		updated := proto.Clone(service).(*meshv1.ServiceInfo)
+		updated.Labels = labels
+		services := make([]*meshv1.ServiceInfo, len(entry.Services))
+		copy(services, entry.Services)
+		services[i] = updated
+		entry.Services = services
+	}
+	return nil
+}
+
+// SetLabels sets and removes labels of an agent and returns its resulting
+// labels. A label both set and removed is removed.
+func (r *Registry) SetLabels(
+	ctx context.Context,
+	agentID string,
+	set map[string]string,
+	remove []string,
+) (map[string]string, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	entry, ok := r.entries[agentID]
+	if !ok {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	labels := make(map[string]string, len(entry.Labels)+len(set))
+	for k, v := range entry.Labels {
+		labels[k] = v
+	}
+	for k, v := range set {
+		labels[k] = v
+	}
+	for _, k := range remove {
+		delete(labels, k)
+	}
+	entry.Labels = labels
+	drained := entry.Drained
+	r.mu.Unlock()
+
+	return labels, r.persistSettings(ctx, agentID, labels, drained)
+}
+
+// SetDrained drains or undrains an agent.
+func (r *Registry) SetDrained(ctx context.Context, agentID string, drained bool) error {
+	if agentID == "" {
+		return fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	entry, ok := r.entries[agentID]
+	if !ok {
+		r.mu.Unlock()
+		return fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	entry.Drained = drained
+	labels := entry.Labels
+	r.mu.Unlock()
+
+	return r.persistSettings(ctx, agentID, labels, drained)
+}
+
+func (r *Registry) persistSettings(ctx context.Context, agentID string, labels map[string]string, drained bool) error {
+	if r.db == nil {
+		return nil
+	}
+	if err := r.db.UpsertAgentSettings(ctx, agentID, labels, drained); err != nil {
+		return fmt.Errorf("failed to persist settings of agent %s: %w", agentID, err)
+	}
+	return nil
+}
+
 // Get retrieves an agent registration by agent ID.
 func (r *Registry) Get(agentID string) (*Entry, error) {
 	if agentID == "" {
@@ -314,6 +726,38 @@ func (r *Registry) Get(agentID string) (*Entry, error) {
 	return entry, nil
 }
 
+// Evict removes an agent from the registry and deletes its persisted
+// services and settings, so that it is not restored on the next start either. It returns
+// the removed entry, or nil if the agent was not registered.
+func (r *Registry) Evict(ctx context.Context, agentID, message string) (*Entry, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("agent_id cannot be empty")
+	}
+
+	r.mu.Lock()
+	delete(r.settings, agentID)
+	entry, ok := r.entries[agentID]
+	if ok {
+		delete(r.entries, agentID)
+		if !entry.disconnected {
+			r.publishAgentEvent(entry, colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED, message)
+		}
+		r.recordHistory(entry, database.AgentEventRevoked, message)
+		r.publishServiceChanges(agentID, entry.Services, nil)
+	}
+	r.mu.Unlock()
+
+	if r.db != nil {
+		if err := r.db.DeleteAgentServices(ctx, agentID); err != nil {
+			return entry, fmt.Errorf("failed to delete services of agent %s: %w", agentID, err)
+		}
+		if err := r.db.DeleteAgentSettings(ctx, agentID); err != nil {
+			return entry, fmt.Errorf("failed to delete settings of agent %s: %w", agentID, err)
+		}
+	}
+	return entry, nil
+}
+
 // ListAll returns all registered agents.
 func (r *Registry) ListAll() []*Entry {
 	r.mu.RLock()
@@ -349,7 +793,7 @@ func (r *Registry) CountByStatus() (active, degraded int32) {
 
 	now := time.Now()
 	for _, entry := range r.entries {
-		status := DetermineStatus(entry.LastSeen, now)
+		status := EntryStatus(entry, now)
 		switch status {
 		case StatusHealthy:
 			active++
@@ -379,6 +823,20 @@ func DetermineStatus(lastSeen, now time.Time) AgentStatus {
 	return StatusUnhealthy
 }
 
+// EntryStatus calculates agent status from its last_seen timestamp, reporting
+// an otherwise healthy agent as degraded when its health score drops below
+// DefaultAgentHealthyScore.
+func EntryStatus(entry *Entry, now time.Time) AgentStatus {
+	status := DetermineStatus(entry.LastSeen, now)
+	if status != StatusHealthy {
+		return status
+	}
+	if score, _ := HealthScore(entry, now); score < constants.DefaultAgentHealthyScore {
+		return StatusDegraded
+	}
+	return status
+}
+
 // FindAgentForService returns the first agent running the specified service.
 func (r *Registry) FindAgentForService(serviceName string) (*Entry, *meshv1.ServiceInfo, error) {
 	r.mu.RLock()
@@ -65,3 +65,34 @@ func TestFindAgentByIP_LastSeenUpdated(t *testing.T) {
 	}
 	_ = entry.LastSeen.Before(time.Now()) // just ensure it's populated
 }
+
+func TestUpdateHeartbeatAddr_PrefersWorkingFamily(t *testing.T) {
+	r := New(nil)
+
+	entry, _ := r.Register("agent-f", "f", "10.100.0.6", "fd00::6", nil, nil, "")
+	if got := entry.MeshIP(); got != "10.100.0.6" {
+		t.Errorf("expected IPv4 by default, got %s", got)
+	}
+
+	if err := r.UpdateHeartbeatAddr("agent-f", "fd00::6"); err != nil {
+		t.Fatalf("UpdateHeartbeatAddr failed: %v", err)
+	}
+	if got := entry.MeshIP(); got != "fd00::6" {
+		t.Errorf("expected IPv6 after an IPv6 heartbeat, got %s", got)
+	}
+
+	// Heartbeats from outside the mesh say nothing about the mesh families.
+	_ = r.UpdateHeartbeatAddr("agent-f", "127.0.0.1")
+	if got := entry.MeshIP(); got != "fd00::6" {
+		t.Errorf("expected IPv6 to stick, got %s", got)
+	}
+
+	_ = r.UpdateHeartbeatAddr("agent-f", "10.100.0.6")
+	if got := entry.MeshIP(); got != "10.100.0.6" {
+		t.Errorf("expected IPv4 after an IPv4 heartbeat, got %s", got)
+	}
+
+	if err := r.UpdateHeartbeatAddr("agent-unknown", "fd00::6"); err == nil {
+		t.Error("expected error for unknown agent")
+	}
+}
@@ -214,3 +214,82 @@ func TestRegistry_PersistenceWithDatabase(t *testing.T) {
 		assert.False(t, entry.LastSeen.IsZero(), "Valid agent should have non-zero timestamp")
 	})
 }
+
+func TestRegistry_History(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, logging.NewWithComponent(logging.Config{Level: "error"}, "test"))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	reg := New(db)
+
+	// waitForEvents waits for the async history writes to land.
+	waitForEvents := func(n int) []*database.AgentHistoryEvent {
+		var events []*database.AgentHistoryEvent
+		require.Eventually(t, func() bool {
+			events, err = db.ListAgentHistoryEvents(ctx, database.AgentHistoryFilters{AgentID: "agent-1"})
+			return err == nil && len(events) == n
+		}, 2*time.Second, 10*time.Millisecond)
+		return events
+	}
+
+	_, err = reg.Register("agent-1", "", "100.64.0.2", "", nil, nil, "")
+	require.NoError(t, err)
+	events := waitForEvents(1)
+	assert.Equal(t, database.AgentEventRegistered, events[0].Event)
+	assert.Equal(t, "100.64.0.2", events[0].MeshIPv4)
+
+	// Heartbeats of a connected agent are not recorded.
+	require.NoError(t, reg.UpdateHeartbeat("agent-1"))
+	reg.CheckDisconnected(time.Now())
+
+	reg.CheckDisconnected(time.Now().Add(constants.DefaultAgentDegradedThreshold + time.Second))
+	events = waitForEvents(2)
+	assert.Equal(t, database.AgentEventDisconnected, events[0].Event)
+	assert.Equal(t, string(StatusUnhealthy), events[0].Status)
+
+	require.NoError(t, reg.UpdateHeartbeat("agent-1"))
+	events = waitForEvents(3)
+	assert.Equal(t, database.AgentEventConnected, events[0].Event)
+	assert.Equal(t, string(StatusHealthy), events[0].Status)
+}
+
+func TestRegistry_Settings(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, logging.NewWithComponent(logging.Config{Level: "error"}, "test"))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	reg := New(db)
+
+	_, err = reg.Register("agent-1", "", "100.64.0.2", "", nil, nil, "")
+	require.NoError(t, err)
+
+	labels, err := reg.SetLabels(ctx, "agent-1", map[string]string{"env": "prod", "region": "us-east"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "region": "us-east"}, labels)
+
+	labels, err = reg.SetLabels(ctx, "agent-1", map[string]string{"tier": "web"}, []string{"region"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "tier": "web"}, labels)
+
+	require.NoError(t, reg.SetDrained(ctx, "agent-1", true))
+
+	_, err = reg.SetLabels(ctx, "agent-unknown", map[string]string{"env": "prod"}, nil)
+	assert.Error(t, err)
+
+	// A restarted colony applies the settings when the agent registers.
+	restarted := New(db)
+	require.NoError(t, restarted.LoadFromDatabase(ctx))
+	entry, err := restarted.Register("agent-1", "", "100.64.0.2", "", nil, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "tier": "web"}, entry.Labels)
+	assert.True(t, entry.Drained)
+
+	// Evicting the agent forgets its settings.
+	_, err = restarted.Evict(ctx, "agent-1", "revoked")
+	require.NoError(t, err)
+	settings, err := db.ListAgentSettings(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, settings)
+}
@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Requirement is a single condition of a Selector on an agent label.
+type Requirement struct {
+	Key   string
+	Value string
+
+	// Op is "=" (the label has the value), "!=" (the label is missing or
+	// has another value) or "" (the label exists, with any value).
+	Op string
+}
+
+// Selector selects agents by their labels, e.g. "env=prod,region!=us-east".
+// An agent matches when it meets all requirements; an empty selector
+// matches all agents.
+type Selector []Requirement
+
+// ParseSelector parses a comma-separated list of key=value, key!=value and
+// key requirements.
+func ParseSelector(s string) (Selector, error) {
+	var sel Selector
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var req Requirement
+		switch {
+		case strings.Contains(part, "!="):
+			k, v, _ := strings.Cut(part, "!=")
+			req = Requirement{Key: strings.TrimSpace(k), Value: strings.TrimSpace(v), Op: "!="}
+		case strings.Contains(part, "="):
+			k, v, _ := strings.Cut(part, "=")
+			req = Requirement{Key: strings.TrimSpace(k), Value: strings.TrimSpace(v), Op: "="}
+		default:
+			req = Requirement{Key: part}
+		}
+		if req.Key == "" {
+			return nil, fmt.Errorf("invalid selector requirement %q: missing label key", part)
+		}
+		sel = append(sel, req)
+	}
+	return sel, nil
+}
+
+// Matches reports whether labels meet all requirements of the selector.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		v, ok := labels[req.Key]
+		switch req.Op {
+		case "=":
+			if !ok || v != req.Value {
+				return false
+			}
+		case "!=":
+			if ok && v == req.Value {
+				return false
+			}
+		default:
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String returns the selector in the format ParseSelector accepts.
+func (s Selector) String() string {
+	parts := make([]string, len(s))
+	for i, req := range s {
+		parts[i] = req.Key + req.Op + req.Value
+	}
+	return strings.Join(parts, ",")
+}
+
+// Select returns the registered agents whose labels match the selector.
+func (r *Registry) Select(sel Selector) []*Entry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*Entry
+	for _, entry := range r.entries {
+		if sel.Matches(entry.AllLabels()) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// SelectServices returns the names of the services whose labels match the
+// selector. A service has the labels of its agent, overridden by the labels
+// it registered with.
+func (r *Registry) SelectServices(sel Selector) map[string]bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make(map[string]bool)
+	for _, entry := range r.entries {
+		agentLabels := entry.AllLabels()
+		for _, service := range entry.Services {
+			if sel.Matches(serviceLabels(agentLabels, service.Labels)) {
+				names[service.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+// ServiceLabels returns the labels of the first registered instance of a
+// service, or nil when no agent runs it.
+func (r *Registry) ServiceLabels(serviceName string) map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, entry := range r.entries {
+		for _, service := range entry.Services {
+			if service.Name == serviceName {
+				return serviceLabels(entry.AllLabels(), service.Labels)
+			}
+		}
+	}
+	return nil
+}
+
+// serviceLabels overlays the labels of a service on those of its agent.
+func serviceLabels(agentLabels, labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return agentLabels
+	}
+	merged := make(map[string]string, len(agentLabels)+len(labels))
+	for k, v := range agentLabels {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return merged
+}
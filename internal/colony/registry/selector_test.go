@@ -0,0 +1,110 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+)
+
+func TestParseSelector(t *testing.T) {
+	sel, err := ParseSelector("env=prod, region!=us-east,canary")
+	require.NoError(t, err)
+	assert.Equal(t, Selector{
+		{Key: "env", Value: "prod", Op: "="},
+		{Key: "region", Value: "us-east", Op: "!="},
+		{Key: "canary"},
+	}, sel)
+	assert.Equal(t, "env=prod,region!=us-east,canary", sel.String())
+
+	empty, err := ParseSelector("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	_, err = ParseSelector("=prod")
+	assert.Error(t, err)
+}
+
+func TestSelector_Matches(t *testing.T) {
+	sel, err := ParseSelector("env=prod,region!=us-east,canary")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   bool
+	}{
+		{"all met", map[string]string{"env": "prod", "region": "eu-west", "canary": ""}, true},
+		{"missing != label", map[string]string{"env": "prod", "canary": "true"}, true},
+		{"wrong value", map[string]string{"env": "staging", "canary": "true"}, false},
+		{"excluded value", map[string]string{"env": "prod", "region": "us-east", "canary": "true"}, false},
+		{"missing existence label", map[string]string{"env": "prod"}, false},
+		{"no labels", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sel.Matches(tt.labels))
+		})
+	}
+
+	assert.True(t, Selector(nil).Matches(nil), "empty selector matches all agents")
+}
+
+func TestRegistry_Select(t *testing.T) {
+	reg := New(nil)
+	for _, id := range []string{"agent-1", "agent-2"} {
+		_, err := reg.Register(id, "", "", "", nil, nil, "")
+		require.NoError(t, err)
+	}
+	_, err := reg.SetLabels(t.Context(), "agent-1", map[string]string{"env": "prod"}, nil)
+	require.NoError(t, err)
+
+	sel, err := ParseSelector("env=prod")
+	require.NoError(t, err)
+	entries := reg.Select(sel)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "agent-1", entries[0].AgentID)
+
+	assert.Len(t, reg.Select(nil), 2)
+}
+
+func TestRegistry_SelectServices(t *testing.T) {
+	reg := New(nil)
+	_, err := reg.Register("agent-1", "", "", "", []*meshv1.ServiceInfo{
+		{Name: "api", Labels: map[string]string{"tier": "web"}},
+		{Name: "worker", Labels: map[string]string{"env": "staging"}},
+	}, nil, "")
+	require.NoError(t, err)
+	_, err = reg.Register("agent-2", "", "", "", []*meshv1.ServiceInfo{{Name: "db"}}, nil, "")
+	require.NoError(t, err)
+
+	require.NoError(t, reg.SetReportedLabels("agent-1", map[string]string{"env": "prod", "region": "us-east"}))
+	require.NoError(t, reg.SetReportedLabels("agent-2", map[string]string{"env": "dev"}))
+
+	// Assigned labels override the reported ones.
+	_, err = reg.SetLabels(t.Context(), "agent-2", map[string]string{"env": "prod"}, nil)
+	require.NoError(t, err)
+
+	entry, err := reg.Get("agent-2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "prod"}, entry.AllLabels())
+
+	sel, err := ParseSelector("env=prod")
+	require.NoError(t, err)
+	assert.Len(t, reg.Select(sel), 2)
+	assert.Equal(t, map[string]bool{"api": true, "db": true}, reg.SelectServices(sel),
+		"service labels override agent labels")
+
+	sel, err = ParseSelector("region=us-east,tier=web")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"api": true}, reg.SelectServices(sel))
+
+	// Labels polled from the agent, e.g. set through the SDK.
+	require.NoError(t, reg.SetServiceLabels("agent-2", "db", map[string]string{"tier": "web"}))
+	assert.Equal(t, map[string]bool{"api": true}, reg.SelectServices(sel))
+	sel, err = ParseSelector("tier=web")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"api": true, "db": true}, reg.SelectServices(sel))
+}
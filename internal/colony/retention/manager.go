@@ -0,0 +1,229 @@
+// Package retention deletes expired rows from colony tables according to
+// per-table TTL policies and reclaims the space they used.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/poller"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Policy is the retention of a table: rows whose Column is older than TTL are
+// deleted. A zero TTL keeps the rows forever.
+type Policy struct {
+	Table  string
+	Column string
+	TTL    time.Duration
+}
+
+// rollupRetention is the default TTL of the Beyla metric rollups by
+// resolution.
+var rollupRetention = map[string]time.Duration{
+	"1m":  constants.DefaultRollup1mRetention,
+	"10m": constants.DefaultRollup10mRetention,
+	"1h":  constants.DefaultRollup1hRetention,
+}
+
+// DefaultPolicies returns the tables managed by the retention manager with
+// their default TTLs, including the Beyla metric rollups. The raw tables fed
+// by pollers (Beyla, telemetry, system metrics, profile summaries) are
+// cleaned up by their poller instead.
+func DefaultPolicies() []Policy {
+	policies := []Policy{
+		{Table: "debug_events", Column: "timestamp", TTL: constants.DefaultDebugEventsRetention},
+		{Table: "debug_sessions", Column: "expires_at", TTL: constants.DefaultDebugSessionsRetention},
+		{Table: "debug_function_stats", Column: "bucket", TTL: constants.DefaultDebugFunctionStatsRetention},
+		{Table: "function_metrics", Column: "timestamp", TTL: constants.DefaultFunctionMetricsRetention},
+		{Table: "correlation_triggers", Column: "fired_at", TTL: constants.DefaultCorrelationTriggersRetention},
+		{Table: "agent_history", Column: "timestamp", TTL: constants.DefaultAgentHistoryRetention},
+		{Table: "audit_log", Column: "timestamp", TTL: constants.DefaultAuditLogRetention},
+		{Table: "mcp_tool_calls", Column: "timestamp", TTL: constants.DefaultMCPToolCallsRetention},
+		{Table: "shell_recordings", Column: "started_at", TTL: constants.DefaultShellRecordingsRetention},
+		{Table: "anomaly_scores", Column: "timestamp", TTL: constants.DefaultAnomalyScoresRetention},
+	}
+	for _, table := range database.RollupTables() {
+		suffix := table[strings.LastIndex(table, "_")+1:]
+		policies = append(policies, Policy{Table: table, Column: "timestamp", TTL: rollupRetention[suffix]})
+	}
+	return policies
+}
+
+// Policies returns the default policies with the TTLs in overrides applied.
+// Overrides for tables without a policy are rejected.
+func Policies(overrides map[string]time.Duration) ([]Policy, error) {
+	policies := DefaultPolicies()
+	for table, ttl := range overrides {
+		i := slices.IndexFunc(policies, func(p Policy) bool { return p.Table == table })
+		if i < 0 {
+			return nil, fmt.Errorf("retention.tables: unknown table %q (must be one of %s)", table, strings.Join(tableNames(policies), ", "))
+		}
+		policies[i].TTL = ttl
+	}
+	return policies, nil
+}
+
+func tableNames(policies []Policy) []string {
+	names := make([]string, len(policies))
+	for i, p := range policies {
+		names[i] = p.Table
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TableResult is the outcome of applying a policy.
+type TableResult struct {
+	Table   string
+	Deleted int64
+	Err     error
+}
+
+// Report summarizes a retention run.
+type Report struct {
+	Time       time.Time
+	Tables     []TableResult
+	SizeBefore int64
+	SizeAfter  int64
+}
+
+// Deleted returns the total number of rows deleted.
+func (r *Report) Deleted() int64 {
+	var n int64
+	for _, t := range r.Tables {
+		n += t.Deleted
+	}
+	return n
+}
+
+// Reclaimed returns the number of bytes the database shrank by. DuckDB
+// reuses freed blocks before growing the file, so space can be reclaimed
+// without the file shrinking; Reclaimed is then 0.
+func (r *Report) Reclaimed() int64 {
+	return max(r.SizeBefore-r.SizeAfter, 0)
+}
+
+// Manager periodically applies retention policies to the colony database and
+// checkpoints it so the freed space can be reused.
+type Manager struct {
+	*poller.BasePoller
+	db       *database.Database
+	policies []Policy
+	logger   zerolog.Logger
+
+	mu   sync.Mutex
+	last *Report
+}
+
+// NewManager creates a retention manager from the colony retention config.
+func NewManager(
+	ctx context.Context,
+	db *database.Database,
+	cfg config.RetentionConfig,
+	logger zerolog.Logger,
+) (*Manager, error) {
+	policies, err := Policies(cfg.Tables)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = constants.DefaultRetentionInterval
+	}
+
+	componentLogger := logger.With().Str("component", "retention_manager").Logger()
+
+	base := poller.NewBasePoller(ctx, poller.Config{
+		Name:         "retention_manager",
+		PollInterval: interval,
+		Logger:       componentLogger,
+	})
+
+	return &Manager{
+		BasePoller: base,
+		db:         db,
+		policies:   policies,
+		logger:     componentLogger,
+	}, nil
+}
+
+// Start begins applying retention policies.
+func (m *Manager) Start() error {
+	return m.BasePoller.Start(m)
+}
+
+// PollOnce applies all retention policies.
+// Implements the poller.Poller interface.
+func (m *Manager) PollOnce(ctx context.Context) error {
+	_, err := m.RunOnce(ctx)
+	return err
+}
+
+// RunCleanup is a no-op; the retention pass runs on every poll.
+// Implements the poller.Poller interface.
+func (m *Manager) RunCleanup(ctx context.Context) error {
+	return nil
+}
+
+// RunOnce deletes the expired rows of every table, checkpoints the database
+// and returns a report. A failing table does not stop the others; its error
+// is recorded in the report.
+func (m *Manager) RunOnce(ctx context.Context) (*Report, error) {
+	now := time.Now()
+	report := &Report{Time: now, SizeBefore: m.db.FileSize()}
+
+	for _, p := range m.policies {
+		if p.TTL <= 0 {
+			continue
+		}
+		deleted, err := m.db.DeleteExpired(ctx, p.Table, p.Column, now.Add(-p.TTL))
+		report.Tables = append(report.Tables, TableResult{Table: p.Table, Deleted: deleted, Err: err})
+		if err != nil {
+			m.logger.Error().Err(err).Str("table", p.Table).Msg("Failed to apply retention policy")
+		}
+	}
+
+	var err error
+	if report.Deleted() > 0 {
+		err = m.db.Checkpoint(ctx)
+	}
+	report.SizeAfter = m.db.FileSize()
+
+	m.mu.Lock()
+	m.last = report
+	m.mu.Unlock()
+
+	if report.Deleted() > 0 {
+		event := m.logger.Info().
+			Int64("deleted", report.Deleted()).
+			Int64("reclaimed_bytes", report.Reclaimed()).
+			Int64("size_bytes", report.SizeAfter)
+		for _, t := range report.Tables {
+			if t.Deleted > 0 {
+				event = event.Int64(t.Table, t.Deleted)
+			}
+		}
+		event.Msg("Deleted expired rows")
+	}
+
+	return report, err
+}
+
+// LastReport returns the report of the latest run, or nil before the first
+// run.
+func (m *Manager) LastReport() *Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.last
+}
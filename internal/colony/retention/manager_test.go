@@ -0,0 +1,90 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func insertRows(t *testing.T, db *database.Database, ages ...time.Duration) {
+	t.Helper()
+	for _, age := range ages {
+		ts := time.Now().Add(-age)
+		_, err := db.DB().Exec(`
+			INSERT INTO audit_log (timestamp, actor, action, result) VALUES (?, 'cli', 'probe.attach', 'ok')
+		`, ts)
+		require.NoError(t, err)
+		_, err = db.DB().Exec(`
+			INSERT INTO debug_events (session_id, timestamp, collector_id, agent_id, service_name, function_name, event_type)
+			VALUES ('s1', ?, 'c1', 'agent-1', 'api', 'main.handle', 'entry')
+		`, ts)
+		require.NoError(t, err)
+	}
+}
+
+func count(t *testing.T, db *database.Database, table string) int {
+	t.Helper()
+	var n int
+	require.NoError(t, db.DB().QueryRow("SELECT count(*) FROM "+table).Scan(&n))
+	return n
+}
+
+func TestManager_RunOnce(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	insertRows(t, db, time.Hour, 10*24*time.Hour, 100*24*time.Hour)
+
+	m, err := NewManager(context.Background(), db, config.RetentionConfig{}, zerolog.Nop())
+	require.NoError(t, err)
+
+	report, err := m.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	// debug_events keeps 7 days, audit_log 90 days.
+	assert.Equal(t, 1, count(t, db, "debug_events"))
+	assert.Equal(t, 2, count(t, db, "audit_log"))
+	assert.Equal(t, int64(3), report.Deleted())
+	assert.Greater(t, report.SizeAfter, int64(0))
+	assert.Same(t, report, m.LastReport())
+
+	for _, r := range report.Tables {
+		assert.NoError(t, r.Err, r.Table)
+	}
+}
+
+func TestManager_Overrides(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	insertRows(t, db, time.Hour, 3*time.Hour)
+
+	m, err := NewManager(context.Background(), db, config.RetentionConfig{
+		Tables: map[string]time.Duration{
+			"debug_events": 2 * time.Hour,
+			"audit_log":    0,
+		},
+	}, zerolog.Nop())
+	require.NoError(t, err)
+
+	_, err = m.RunOnce(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, count(t, db, "debug_events"))
+	assert.Equal(t, 2, count(t, db, "audit_log"), "TTL 0 keeps rows forever")
+}
+
+func TestPolicies_UnknownTable(t *testing.T) {
+	_, err := Policies(map[string]time.Duration{"beyla_http_metrics": time.Hour})
+	assert.ErrorContains(t, err, `unknown table "beyla_http_metrics"`)
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// GetAgentHistory returns per-agent history summaries and the most recent
+// agent history events.
+func (s *Server) GetAgentHistory(
+	ctx context.Context,
+	req *connect.Request[colonyv1.GetAgentHistoryRequest],
+) (*connect.Response[colonyv1.GetAgentHistoryResponse], error) {
+	filters := database.AgentHistoryFilters{
+		Since:   time.Now().Add(-constants.DefaultAgentHistoryWindow),
+		AgentID: req.Msg.AgentId,
+		Limit:   int(req.Msg.Limit),
+	}
+	if req.Msg.Since != nil {
+		filters.Since = req.Msg.Since.AsTime()
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = constants.DefaultAgentHistoryListLimit
+	}
+
+	summaries, err := s.database.SummarizeAgentHistory(ctx, filters.Since, filters.AgentID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to summarize agent history: %w", err))
+	}
+
+	events, err := s.database.ListAgentHistoryEvents(ctx, filters)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list agent history: %w", err))
+	}
+
+	resp := &colonyv1.GetAgentHistoryResponse{}
+	for _, a := range summaries {
+		resp.Agents = append(resp.Agents, &colonyv1.AgentHistorySummary{
+			AgentId:       a.AgentID,
+			FirstSeen:     timestamppb.New(a.FirstSeen),
+			LastEvent:     timestamppb.New(a.LastEvent),
+			Connects:      int32(a.Connects),
+			Disconnects:   int32(a.Disconnects),
+			StatusChanges: int32(a.StatusChanges),
+			Flapping:      a.Disconnects >= constants.AgentFlappingDisconnects,
+		})
+	}
+	for _, e := range events {
+		resp.Events = append(resp.Events, &colonyv1.AgentHistoryEvent{
+			Id:        e.ID,
+			Timestamp: timestamppb.New(e.Timestamp),
+			AgentId:   e.AgentID,
+			Event:     e.Event,
+			Status:    e.Status,
+			MeshIpv4:  e.MeshIPv4,
+			Message:   e.Message,
+		})
+	}
+
+	return connect.NewResponse(resp), nil
+}
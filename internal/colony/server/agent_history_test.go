@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_GetAgentHistory(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{database: db}
+	ctx := context.Background()
+	now := time.Now()
+
+	insert := func(agentID, event string, age time.Duration) {
+		require.NoError(t, db.InsertAgentHistoryEvent(ctx, &database.AgentHistoryEvent{
+			Timestamp: now.Add(-age),
+			AgentID:   agentID,
+			Event:     event,
+			Status:    "healthy",
+		}))
+	}
+	insert("stable", database.AgentEventRegistered, 30*24*time.Hour)
+	insert("stable", database.AgentEventStatusChanged, time.Hour)
+	insert("flappy", database.AgentEventRegistered, 2*time.Hour)
+	for i := 0; i < constants.AgentFlappingDisconnects; i++ {
+		insert("flappy", database.AgentEventDisconnected, time.Duration(i+1)*time.Minute)
+	}
+
+	resp, err := s.GetAgentHistory(ctx, connect.NewRequest(&colonyv1.GetAgentHistoryRequest{}))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Msg.Agents, 2)
+	assert.Equal(t, "stable", resp.Msg.Agents[0].AgentId)
+	assert.False(t, resp.Msg.Agents[0].Flapping)
+	assert.Equal(t, "flappy", resp.Msg.Agents[1].AgentId)
+	assert.True(t, resp.Msg.Agents[1].Flapping)
+	assert.Len(t, resp.Msg.Events, 1+1+constants.AgentFlappingDisconnects, "events outside the default window are excluded")
+
+	resp, err = s.GetAgentHistory(ctx, connect.NewRequest(&colonyv1.GetAgentHistoryRequest{
+		Since: timestamppb.New(now.Add(-90 * time.Minute)),
+		Limit: 2,
+	}))
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.Agents, 2)
+	assert.Len(t, resp.Msg.Events, 2)
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/mesh"
+	"github.com/coral-mesh/coral/internal/protocol"
+)
+
+// Agent upgrade states reported by GetAgentUpgrade.
+const (
+	agentUpgradeUpgraded = "upgraded"
+	agentUpgradePending  = "pending"
+	agentUpgradeFailed   = "failed"
+
+	// agentUpgradeUnsupported: the agent's protocol version predates agent
+	// updates, it must be upgraded by hand.
+	agentUpgradeUnsupported = "unsupported"
+)
+
+// AgentUpgrader rolls out agent releases (see mesh.AgentUpdates).
+type AgentUpgrader interface {
+	StartRollout(version string, artifacts []auth.AgentArtifact, agentIDs []string) error
+	CancelRollout()
+	Rollout() (mesh.AgentRollout, bool)
+}
+
+// SetAgentUpgrader enables UpgradeAgents.
+func (s *Server) SetAgentUpgrader(upgrader AgentUpgrader) {
+	s.agentUpgrader = upgrader
+}
+
+// UpgradeAgents starts or cancels an agent release rollout.
+func (s *Server) UpgradeAgents(
+	ctx context.Context,
+	req *connect.Request[colonyv1.UpgradeAgentsRequest],
+) (*connect.Response[colonyv1.UpgradeAgentsResponse], error) {
+	if s.agentUpgrader == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent upgrades are not available"))
+	}
+
+	if req.Msg.Cancel {
+		s.agentUpgrader.CancelRollout()
+		s.logger.Info().Msg("Agent release rollout cancelled")
+		return connect.NewResponse(&colonyv1.UpgradeAgentsResponse{Upgrade: s.agentUpgrade()}), nil
+	}
+
+	artifacts := make([]auth.AgentArtifact, 0, len(req.Msg.Artifacts))
+	for _, a := range req.Msg.Artifacts {
+		artifacts = append(artifacts, auth.AgentArtifact{
+			OS:     a.Os,
+			Arch:   a.Arch,
+			URL:    a.Url,
+			SHA256: a.Sha256,
+		})
+	}
+	if err := s.agentUpgrader.StartRollout(req.Msg.Version, artifacts, req.Msg.AgentIds); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	s.logger.Info().
+		Str("version", req.Msg.Version).
+		Int("artifacts", len(artifacts)).
+		Strs("agent_ids", req.Msg.AgentIds).
+		Msg("Agent release rollout started")
+
+	return connect.NewResponse(&colonyv1.UpgradeAgentsResponse{Upgrade: s.agentUpgrade()}), nil
+}
+
+// GetAgentUpgrade reports the agent release rollout.
+func (s *Server) GetAgentUpgrade(
+	ctx context.Context,
+	req *connect.Request[colonyv1.GetAgentUpgradeRequest],
+) (*connect.Response[colonyv1.GetAgentUpgradeResponse], error) {
+	if s.agentUpgrader == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("agent upgrades are not available"))
+	}
+	return connect.NewResponse(&colonyv1.GetAgentUpgradeResponse{Upgrade: s.agentUpgrade()}), nil
+}
+
+// agentUpgrade returns the current rollout and the progress of the agents it
+// targets; an empty rollout if there is none.
+func (s *Server) agentUpgrade() *colonyv1.AgentUpgrade {
+	rollout, ok := s.agentUpgrader.Rollout()
+	if !ok {
+		return &colonyv1.AgentUpgrade{}
+	}
+
+	upgrade := &colonyv1.AgentUpgrade{
+		Version:   rollout.Version,
+		AgentIds:  rollout.AgentIDs,
+		StartedAt: timestamppb.New(rollout.StartedAt),
+	}
+	for _, a := range rollout.Artifacts {
+		upgrade.Artifacts = append(upgrade.Artifacts, &colonyv1.AgentArtifact{
+			Os:     a.OS,
+			Arch:   a.Arch,
+			Url:    a.URL,
+			Sha256: a.SHA256,
+		})
+	}
+
+	for _, entry := range s.registry.ListAll() {
+		if !rollout.Targets(entry.AgentID) {
+			continue
+		}
+		status := &colonyv1.AgentUpgradeStatus{
+			AgentId: entry.AgentID,
+			Version: entry.Version,
+			State:   agentUpgradePending,
+			Error:   entry.UpdateError,
+		}
+		switch {
+		case entry.Version == rollout.Version:
+			status.State = agentUpgradeUpgraded
+		case !protocol.Supports(entry.Protocol(), protocol.CapabilityAgentUpdates):
+			status.State = agentUpgradeUnsupported
+		case entry.UpdateError != "":
+			status.State = agentUpgradeFailed
+		}
+		upgrade.Agents = append(upgrade.Agents, status)
+	}
+	sort.Slice(upgrade.Agents, func(i, j int) bool {
+		return upgrade.Agents[i].AgentId < upgrade.Agents[j].AgentId
+	})
+	return upgrade
+}
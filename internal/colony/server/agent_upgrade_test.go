@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/mesh"
+)
+
+func TestServer_UpgradeAgents(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+
+	_, err := server.UpgradeAgents(context.Background(), connect.NewRequest(&colonyv1.UpgradeAgentsRequest{}))
+	assert.Equal(t, connect.CodeUnavailable, connect.CodeOf(err), "upgrades need an upgrader")
+
+	server.SetAgentUpgrader(mesh.NewAgentUpdates(func(release auth.AgentRelease, _ time.Duration) (string, error) {
+		return release.Version + "-token", nil
+	}))
+
+	for agentID, version := range map[string]string{
+		"agent-1": "v0.5.0",
+		"agent-2": "v0.4.0",
+		"agent-3": "v0.4.0",
+		"agent-4": "v0.4.0",
+	} {
+		_, err := server.registry.Register(agentID, "", "100.64.0.2", "", nil, nil, "2")
+		require.NoError(t, err)
+		require.NoError(t, server.registry.UpdateVersion(agentID, version, ""))
+	}
+	require.NoError(t, server.registry.UpdateVersion("agent-3", "", "checksum mismatch"))
+	_, err = server.registry.Register("legacy-agent", "", "100.64.0.3", "", nil, nil, "1")
+	require.NoError(t, err)
+
+	_, err = server.UpgradeAgents(context.Background(), connect.NewRequest(&colonyv1.UpgradeAgentsRequest{
+		Version: "v0.5.0",
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "a release needs artifacts")
+
+	resp, err := server.UpgradeAgents(context.Background(), connect.NewRequest(&colonyv1.UpgradeAgentsRequest{
+		Version: "v0.5.0",
+		Artifacts: []*colonyv1.AgentArtifact{{
+			Os:     "linux",
+			Arch:   "amd64",
+			Url:    "https://example.com/coral-agent-linux-amd64",
+			Sha256: strings.Repeat("ab", 32),
+		}},
+		AgentIds: []string{"agent-1", "agent-2", "agent-3", "legacy-agent"},
+	}))
+	require.NoError(t, err)
+
+	upgrade := resp.Msg.Upgrade
+	assert.Equal(t, "v0.5.0", upgrade.Version)
+	require.Len(t, upgrade.Artifacts, 1)
+	assert.Equal(t, "linux", upgrade.Artifacts[0].Os)
+
+	states := make(map[string]string)
+	for _, agent := range upgrade.Agents {
+		states[agent.AgentId] = agent.State
+	}
+	assert.Equal(t, map[string]string{
+		"agent-1":      agentUpgradeUpgraded,
+		"agent-2":      agentUpgradePending,
+		"agent-3":      agentUpgradeFailed,
+		"legacy-agent": agentUpgradeUnsupported,
+	}, states, "agent-4 is not part of the rollout")
+
+	resp, err = server.UpgradeAgents(context.Background(), connect.NewRequest(&colonyv1.UpgradeAgentsRequest{Cancel: true}))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Msg.Upgrade.Version)
+
+	status, err := server.GetAgentUpgrade(context.Background(), connect.NewRequest(&colonyv1.GetAgentUpgradeRequest{}))
+	require.NoError(t, err)
+	assert.Empty(t, status.Msg.Upgrade.Version)
+}
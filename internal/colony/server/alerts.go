@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/alerting"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// SetAlertSinks sets the names of the notification sinks alert rules may
+// refer to (alerting.sinks in the colony config).
+func (s *Server) SetAlertSinks(names []string) {
+	s.alertSinks = names
+}
+
+// CreateAlertRule defines an alert rule. It is evaluated from the next
+// evaluation onwards.
+func (s *Server) CreateAlertRule(
+	ctx context.Context,
+	req *connect.Request[colonyv1.CreateAlertRuleRequest],
+) (*connect.Response[colonyv1.CreateAlertRuleResponse], error) {
+	rule, err := s.newAlertRule(req.Msg, time.Now())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := s.database.InsertAlertRule(ctx, rule); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create alert rule: %w", err))
+	}
+
+	s.logger.Info().
+		Str("rule_id", rule.ID).
+		Str("rule", rule.Name).
+		Str("metric", rule.Metric).
+		Float64("threshold", rule.Threshold).
+		Msg("Alert rule created")
+
+	return connect.NewResponse(&colonyv1.CreateAlertRuleResponse{
+		Rule: alertRuleToProto(rule, nil),
+	}), nil
+}
+
+// ListAlertRules returns all alert rules with the services they are firing
+// for.
+func (s *Server) ListAlertRules(
+	ctx context.Context,
+	req *connect.Request[colonyv1.ListAlertRulesRequest],
+) (*connect.Response[colonyv1.ListAlertRulesResponse], error) {
+	rules, err := s.database.ListAlertRules(ctx, false)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list alert rules: %w", err))
+	}
+
+	firing, err := s.database.ListAlertFiring(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list firing alerts: %w", err))
+	}
+
+	resp := &colonyv1.ListAlertRulesResponse{Sinks: s.alertSinks}
+	for _, rule := range rules {
+		resp.Rules = append(resp.Rules, alertRuleToProto(rule, firing[rule.ID]))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteAlertRule removes an alert rule.
+func (s *Server) DeleteAlertRule(
+	ctx context.Context,
+	req *connect.Request[colonyv1.DeleteAlertRuleRequest],
+) (*connect.Response[colonyv1.DeleteAlertRuleResponse], error) {
+	if err := s.database.DeleteAlertRule(ctx, req.Msg.Id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("alert rule not found: %s", req.Msg.Id))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete alert rule: %w", err))
+	}
+
+	s.logger.Info().Str("rule_id", req.Msg.Id).Msg("Alert rule deleted")
+	return connect.NewResponse(&colonyv1.DeleteAlertRuleResponse{}), nil
+}
+
+// SetAlertRuleEnabled enables or disables an alert rule. Alerts of a disabled
+// rule are cleared without notifying sinks.
+func (s *Server) SetAlertRuleEnabled(
+	ctx context.Context,
+	req *connect.Request[colonyv1.SetAlertRuleEnabledRequest],
+) (*connect.Response[colonyv1.SetAlertRuleEnabledResponse], error) {
+	if err := s.database.SetAlertRuleEnabled(ctx, req.Msg.Id, req.Msg.Enabled); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("alert rule not found: %s", req.Msg.Id))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update alert rule: %w", err))
+	}
+
+	s.logger.Info().
+		Str("rule_id", req.Msg.Id).
+		Bool("enabled", req.Msg.Enabled).
+		Msg("Alert rule updated")
+	return connect.NewResponse(&colonyv1.SetAlertRuleEnabledResponse{}), nil
+}
+
+// newAlertRule validates a create request against the configured sinks.
+func (s *Server) newAlertRule(req *colonyv1.CreateAlertRuleRequest, now time.Time) (*database.AlertRule, error) {
+	if req.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if !alerting.IsValidMetric(req.Metric) {
+		return nil, fmt.Errorf("invalid metric %q: must be %s, %s or %s",
+			req.Metric, alerting.MetricLatencyP95, alerting.MetricErrorRate, alerting.MetricHeapGrowth)
+	}
+	if req.Threshold <= 0 {
+		return nil, errors.New("threshold must be positive")
+	}
+
+	window := constants.DefaultAlertWindow
+	if req.Window != nil {
+		window = req.Window.AsDuration()
+	}
+	if window < constants.MinAlertWindow || window > constants.MaxAlertWindow {
+		return nil, fmt.Errorf("window must be between %s and %s", constants.MinAlertWindow, constants.MaxAlertWindow)
+	}
+
+	for _, sink := range req.Sinks {
+		if !slices.Contains(s.alertSinks, sink) {
+			return nil, fmt.Errorf("unknown alert sink %q: sinks are defined in alerting.sinks of the colony config", sink)
+		}
+	}
+
+	return &database.AlertRule{
+		ID:            uuid.New().String(),
+		Name:          req.Name,
+		ServiceName:   req.ServiceName,
+		Metric:        req.Metric,
+		Threshold:     req.Threshold,
+		WindowSeconds: int64(window / time.Second),
+		Sinks:         strings.Join(req.Sinks, ","),
+		Enabled:       true,
+		CreatedAt:     now,
+	}, nil
+}
+
+func alertRuleToProto(rule *database.AlertRule, firing []*database.AlertFiring) *colonyv1.AlertRule {
+	pb := &colonyv1.AlertRule{
+		Id:          rule.ID,
+		Name:        rule.Name,
+		ServiceName: rule.ServiceName,
+		Metric:      rule.Metric,
+		Threshold:   rule.Threshold,
+		Window:      durationpb.New(rule.Window()),
+		Sinks:       rule.SinkNames(),
+		Enabled:     rule.Enabled,
+		CreatedAt:   timestamppb.New(rule.CreatedAt),
+	}
+	if rule.LastEvaluatedAt != nil {
+		pb.LastEvaluatedAt = timestamppb.New(*rule.LastEvaluatedAt)
+	}
+	for _, f := range firing {
+		pb.Firing = append(pb.Firing, &colonyv1.AlertFiring{
+			ServiceName: f.ServiceName,
+			Value:       f.Value,
+			Since:       timestamppb.New(f.Since),
+		})
+	}
+	return pb
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_AlertRules(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{database: db, logger: zerolog.Nop()}
+	s.SetAlertSinks([]string{"slack", "oncall"})
+	ctx := context.Background()
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := []*colonyv1.CreateAlertRuleRequest{
+			{Metric: "latency_p95", Threshold: 500},
+			{Name: "x", Metric: "cpu", Threshold: 500},
+			{Name: "x", Metric: "latency_p95"},
+			{Name: "x", Metric: "latency_p95", Threshold: 500, Window: durationpb.New(10 * time.Second)},
+			{Name: "x", Metric: "latency_p95", Threshold: 500, Window: durationpb.New(48 * time.Hour)},
+			{Name: "x", Metric: "latency_p95", Threshold: 500, Sinks: []string{"email"}},
+		}
+		for _, req := range invalid {
+			_, err := s.CreateAlertRule(ctx, connect.NewRequest(req))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "%v", req)
+		}
+	})
+
+	created, err := s.CreateAlertRule(ctx, connect.NewRequest(&colonyv1.CreateAlertRuleRequest{
+		Name:        "api errors",
+		ServiceName: "api",
+		Metric:      "error_rate",
+		Threshold:   5,
+		Sinks:       []string{"slack", "oncall"},
+	}))
+	require.NoError(t, err)
+	rule := created.Msg.Rule
+	assert.Equal(t, constants.DefaultAlertWindow, rule.Window.AsDuration())
+	assert.True(t, rule.Enabled)
+
+	require.NoError(t, db.UpsertAlertFiring(ctx, &database.AlertFiring{RuleID: rule.Id, ServiceName: "api", Value: 9, Since: time.Now()}))
+
+	list, err := s.ListAlertRules(ctx, connect.NewRequest(&colonyv1.ListAlertRulesRequest{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"slack", "oncall"}, list.Msg.Sinks)
+	require.Len(t, list.Msg.Rules, 1)
+	assert.Equal(t, []string{"slack", "oncall"}, list.Msg.Rules[0].Sinks)
+	require.Len(t, list.Msg.Rules[0].Firing, 1)
+	assert.Equal(t, 9.0, list.Msg.Rules[0].Firing[0].Value)
+
+	_, err = s.SetAlertRuleEnabled(ctx, connect.NewRequest(&colonyv1.SetAlertRuleEnabledRequest{Id: rule.Id}))
+	require.NoError(t, err)
+	list, err = s.ListAlertRules(ctx, connect.NewRequest(&colonyv1.ListAlertRulesRequest{}))
+	require.NoError(t, err)
+	assert.False(t, list.Msg.Rules[0].Enabled)
+	assert.Empty(t, list.Msg.Rules[0].Firing)
+
+	_, err = s.SetAlertRuleEnabled(ctx, connect.NewRequest(&colonyv1.SetAlertRuleEnabledRequest{Id: "missing", Enabled: true}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = s.DeleteAlertRule(ctx, connect.NewRequest(&colonyv1.DeleteAlertRuleRequest{Id: rule.Id}))
+	require.NoError(t, err)
+	_, err = s.DeleteAlertRule(ctx, connect.NewRequest(&colonyv1.DeleteAlertRuleRequest{Id: rule.Id}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// QueryAnomalies lists the route anomaly scores stored by the anomaly
+// detector over a time range.
+func (s *Server) QueryAnomalies(
+	ctx context.Context,
+	req *connect.Request[colonyv1.QueryAnomaliesRequest],
+) (*connect.Response[colonyv1.QueryAnomaliesResponse], error) {
+	if s.database == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("database not available"))
+	}
+
+	startTime, _, err := parseTimeRange(req.Msg.TimeRange)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid time_range: %w", err))
+	}
+	limit := constants.DefaultAnomaliesLimit
+	if req.Msg.Limit > 0 {
+		limit = int(req.Msg.Limit)
+	}
+
+	scores, err := s.database.QueryAnomalyScores(ctx, database.AnomalyScoreFilter{
+		ServiceName:   req.Msg.Service,
+		Since:         startTime,
+		AnomalousOnly: !req.Msg.IncludeNormal,
+		Limit:         limit,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	learnedAt, err := s.database.AnomalyBaselinesLearnedAt(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &colonyv1.QueryAnomaliesResponse{}
+	if !learnedAt.IsZero() {
+		resp.BaselinesLearnedAt = timestamppb.New(learnedAt)
+	}
+	for _, a := range scores {
+		resp.Anomalies = append(resp.Anomalies, &colonyv1.Anomaly{
+			Timestamp:      timestamppb.New(a.Timestamp),
+			Service:        a.ServiceName,
+			Route:          a.Route,
+			Metric:         a.Metric,
+			Value:          a.Value,
+			BaselineMean:   a.Mean,
+			BaselineStddev: a.Stddev,
+			Score:          a.Score,
+			Anomalous:      a.Anomalous,
+		})
+	}
+
+	return connect.NewResponse(resp), nil
+}
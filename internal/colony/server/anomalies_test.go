@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_QueryAnomalies(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{database: db, logger: zerolog.Nop()}
+	ctx := context.Background()
+
+	resp, err := s.QueryAnomalies(ctx, connect.NewRequest(&colonyv1.QueryAnomaliesRequest{}))
+	require.NoError(t, err)
+	assert.Nil(t, resp.Msg.BaselinesLearnedAt, "no baselines learned yet")
+	assert.Empty(t, resp.Msg.Anomalies)
+
+	now := time.Now()
+	require.NoError(t, db.ReplaceAnomalyBaselines(ctx, []*database.AnomalyBaseline{
+		{ServiceName: "api", Route: "GET /orders", Metric: database.AnomalyMetricErrorRate, Mean: 1, Stddev: 0.5, Samples: 7, LearnedAt: now},
+	}))
+	require.NoError(t, db.InsertAnomalyScores(ctx, []*database.AnomalyScore{
+		{Timestamp: now, ServiceName: "api", Route: "GET /orders", Metric: database.AnomalyMetricErrorRate, Value: 12, Mean: 1, Stddev: 0.5, Score: 11, Anomalous: true},
+		{Timestamp: now, ServiceName: "api", Route: "GET /orders", Metric: database.AnomalyMetricLatencyP95, Value: 100, Mean: 95, Stddev: 10, Score: 0.5},
+	}))
+
+	resp, err = s.QueryAnomalies(ctx, connect.NewRequest(&colonyv1.QueryAnomaliesRequest{Service: "api"}))
+	require.NoError(t, err)
+	require.NotNil(t, resp.Msg.BaselinesLearnedAt)
+	require.Len(t, resp.Msg.Anomalies, 1, "normal scores are skipped by default")
+	a := resp.Msg.Anomalies[0]
+	assert.Equal(t, "GET /orders", a.Route)
+	assert.Equal(t, 12.0, a.Value)
+	assert.Equal(t, 1.0, a.BaselineMean)
+	assert.Equal(t, 11.0, a.Score)
+
+	resp, err = s.QueryAnomalies(ctx, connect.NewRequest(&colonyv1.QueryAnomaliesRequest{IncludeNormal: true}))
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.Anomalies, 2)
+
+	_, err = s.QueryAnomalies(ctx, connect.NewRequest(&colonyv1.QueryAnomaliesRequest{TimeRange: "yesterday"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
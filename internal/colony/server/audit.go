@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// SetAuditRecorder enables the audit log (mcp.security.audit_enabled).
+func (s *Server) SetAuditRecorder(recorder *audit.Recorder) {
+	s.audit = recorder
+}
+
+// RecordAuditEvent records an action reported by a client, such as a shell
+// or container exec run directly against an agent, or an MCP tool call.
+func (s *Server) RecordAuditEvent(
+	ctx context.Context,
+	req *connect.Request[colonyv1.RecordAuditEventRequest],
+) (*connect.Response[colonyv1.RecordAuditEventResponse], error) {
+	if req.Msg.Action == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("action is required"))
+	}
+	if s.audit == nil {
+		return connect.NewResponse(&colonyv1.RecordAuditEventResponse{}), nil
+	}
+
+	result := req.Msg.Result
+	if result == "" {
+		result = audit.ResultOK
+	}
+
+	s.audit.Record(ctx, &database.AuditEntry{
+		Actor:         audit.Actor(ctx, req.Peer().Addr, req.Msg.User),
+		Action:        req.Msg.Action,
+		Target:        req.Msg.Target,
+		ArgumentsHash: req.Msg.ArgumentsHash,
+		Result:        result,
+		Error:         req.Msg.Error,
+	})
+
+	return connect.NewResponse(&colonyv1.RecordAuditEventResponse{Recorded: true}), nil
+}
+
+// ListAuditEvents returns audit log entries, newest first.
+func (s *Server) ListAuditEvents(
+	ctx context.Context,
+	req *connect.Request[colonyv1.ListAuditEventsRequest],
+) (*connect.Response[colonyv1.ListAuditEventsResponse], error) {
+	filters := database.AuditFilters{
+		Action: req.Msg.Action,
+		Actor:  req.Msg.Actor,
+		Limit:  int(req.Msg.Limit),
+	}
+	if req.Msg.Since != nil {
+		filters.Since = req.Msg.Since.AsTime()
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = constants.DefaultAuditListLimit
+	}
+
+	entries, err := s.database.ListAuditEntries(ctx, filters)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list audit events: %w", err))
+	}
+
+	resp := &colonyv1.ListAuditEventsResponse{
+		Enabled: s.audit != nil,
+	}
+	for _, e := range entries {
+		resp.Events = append(resp.Events, &colonyv1.AuditEvent{
+			Id:            e.ID,
+			Timestamp:     timestamppb.New(e.Timestamp),
+			Actor:         e.Actor,
+			Action:        e.Action,
+			Target:        e.Target,
+			ArgumentsHash: e.ArgumentsHash,
+			Result:        e.Result,
+			Error:         e.Error,
+		})
+	}
+
+	return connect.NewResponse(resp), nil
+}
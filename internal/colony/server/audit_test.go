@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_AuditEvents(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{database: db}
+	ctx := context.Background()
+
+	t.Run("disabled", func(t *testing.T) {
+		resp, err := s.RecordAuditEvent(ctx, connect.NewRequest(&colonyv1.RecordAuditEventRequest{Action: "ShellExec"}))
+		require.NoError(t, err)
+		assert.False(t, resp.Msg.Recorded)
+
+		list, err := s.ListAuditEvents(ctx, connect.NewRequest(&colonyv1.ListAuditEventsRequest{}))
+		require.NoError(t, err)
+		assert.False(t, list.Msg.Enabled)
+		assert.Empty(t, list.Msg.Events)
+	})
+
+	s.SetAuditRecorder(audit.NewRecorder(db, zerolog.Nop()))
+
+	t.Run("missing action", func(t *testing.T) {
+		_, err := s.RecordAuditEvent(ctx, connect.NewRequest(&colonyv1.RecordAuditEventRequest{}))
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("record and list", func(t *testing.T) {
+		token := &auth.APIToken{TokenID: "alice-laptop", User: "alice", Role: auth.RoleDebugger}
+		tokenCtx := context.WithValue(ctx, httpapi.TokenContextKey, token)
+
+		resp, err := s.RecordAuditEvent(tokenCtx, connect.NewRequest(&colonyv1.RecordAuditEventRequest{
+			Action:        "ContainerExec",
+			Target:        "service=api",
+			ArgumentsHash: audit.HashArgs([]string{"cat", "/etc/passwd"}),
+			User:          "ignored",
+		}))
+		require.NoError(t, err)
+		assert.True(t, resp.Msg.Recorded)
+
+		list, err := s.ListAuditEvents(ctx, connect.NewRequest(&colonyv1.ListAuditEventsRequest{Actor: "alice"}))
+		require.NoError(t, err)
+		assert.True(t, list.Msg.Enabled)
+		require.Len(t, list.Msg.Events, 1)
+
+		event := list.Msg.Events[0]
+		assert.Equal(t, "ContainerExec", event.Action)
+		assert.Equal(t, "service=api", event.Target)
+		assert.Equal(t, audit.ResultOK, event.Result)
+		assert.Len(t, event.ArgumentsHash, 64)
+	})
+}
@@ -0,0 +1,330 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// bulkExecCapabilityTTL is the lifetime of the capability token presented to
+// each agent by ExecAgents. Agents check it when the call starts.
+const bulkExecCapabilityTTL = time.Minute
+
+// bulkTarget is an agent targeted by a bulk operation; entry is nil when the
+// agent was requested by ID but is not registered.
+type bulkTarget struct {
+	agentID string
+	entry   *registry.Entry
+}
+
+// bulkTargets resolves the agents matching selector and agentIDs. Both may
+// be given, in which case agents must match both; at least one is required.
+func (s *Server) bulkTargets(selector string, agentIDs []string) ([]bulkTarget, error) {
+	if selector == "" && len(agentIDs) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("a selector or agent IDs are required"))
+	}
+
+	sel, err := registry.ParseSelector(selector)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var targets []bulkTarget
+	if len(agentIDs) > 0 {
+		seen := make(map[string]bool, len(agentIDs))
+		for _, id := range agentIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			entry, err := s.registry.Get(id)
+			if err != nil {
+				targets = append(targets, bulkTarget{agentID: id})
+				continue
+			}
+			if sel.Matches(entry.AllLabels()) {
+				targets = append(targets, bulkTarget{agentID: id, entry: entry})
+			}
+		}
+	} else {
+		for _, entry := range s.registry.Select(sel) {
+			targets = append(targets, bulkTarget{agentID: entry.AgentID, entry: entry})
+		}
+	}
+
+	sort.Slice(targets, func(i, j int) bool { return targets[i].agentID < targets[j].agentID })
+	return targets, nil
+}
+
+// runBulk runs op on each target, at most concurrency at once, and returns
+// the results in the order of targets. Targets that are not registered fail
+// without running op.
+func runBulk(
+	ctx context.Context,
+	targets []bulkTarget,
+	concurrency int,
+	op func(ctx context.Context, entry *registry.Entry) *colonyv1.BulkAgentResult,
+) []*colonyv1.BulkAgentResult {
+	if concurrency <= 0 {
+		concurrency = constants.DefaultBulkAgentConcurrency
+	}
+
+	results := make([]*colonyv1.BulkAgentResult, len(targets))
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	for i, target := range targets {
+		if target.entry == nil {
+			results[i] = &colonyv1.BulkAgentResult{
+				AgentId: target.agentID,
+				Error:   fmt.Sprintf("agent not found: %s", target.agentID),
+			}
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = &colonyv1.BulkAgentResult{AgentId: target.agentID, Error: ctx.Err().Error()}
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := op(ctx, target.entry)
+			result.AgentId = target.agentID
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// auditBulk records the outcome of a bulk operation on each target, as the
+// operation's own audit entry only names its selector.
+func (s *Server) auditBulk(ctx context.Context, actor, action string, args []string, results []*colonyv1.BulkAgentResult) {
+	if s.audit == nil {
+		return
+	}
+	argumentsHash := audit.HashArgs(args)
+	for _, result := range results {
+		entry := &database.AuditEntry{
+			Actor:         actor,
+			Action:        action,
+			Target:        "agent=" + result.AgentId,
+			ArgumentsHash: argumentsHash,
+			Result:        audit.ResultOK,
+		}
+		if !result.Success {
+			entry.Result = audit.ResultFailed
+			entry.Error = result.Error
+			if entry.Error == "" {
+				entry.Error = fmt.Sprintf("exit code %d", result.ExitCode)
+			}
+		}
+		s.audit.Record(ctx, entry)
+	}
+}
+
+// execCapability mints the capability token letting the colony run a command
+// on an agent requiring capability tokens, on behalf of actor.
+func (s *Server) execCapability(actor string) (string, error) {
+	if s.caManager == nil {
+		return "", fmt.Errorf("CA is not initialized")
+	}
+	signed, _, err := s.caManager.IssueCapabilityToken(actor, actor,
+		[]auth.Scope{{Permission: auth.PermissionDebug}}, bulkExecCapabilityTTL)
+	if err != nil {
+		return "", fmt.Errorf("failed to mint capability token: %w", err)
+	}
+	return signed, nil
+}
+
+// ExecAgents runs a command on all agents matching a selector, a bounded
+// number at a time, and returns the output of each. Each agent is called on
+// behalf of the caller's API token, with its own capability token.
+func (s *Server) ExecAgents(
+	ctx context.Context,
+	req *connect.Request[colonyv1.ExecAgentsRequest],
+) (*connect.Response[colonyv1.ExecAgentsResponse], error) {
+	if err := httpapi.RequirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	if len(req.Msg.Command) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("command is required"))
+	}
+
+	timeout := constants.DefaultBulkExecTimeout
+	if req.Msg.TimeoutSeconds > 0 {
+		timeout = time.Duration(req.Msg.TimeoutSeconds) * time.Second
+	}
+	if timeout > constants.MaxBulkExecTimeout {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("timeout must not exceed %s", constants.MaxBulkExecTimeout))
+	}
+
+	targets, err := s.bulkTargets(req.Msg.Selector, req.Msg.AgentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client-reported user is not trusted: agents record the caller.
+	actor := audit.Actor(ctx, req.Peer().Addr, "")
+
+	results := runBulk(ctx, targets, int(req.Msg.Concurrency), func(ctx context.Context, entry *registry.Entry) *colonyv1.BulkAgentResult {
+		if status := registry.EntryStatus(entry, time.Now()); status == registry.StatusUnhealthy {
+			return &colonyv1.BulkAgentResult{Error: fmt.Sprintf("agent is %s", status)}
+		}
+
+		capability, err := s.execCapability(actor)
+		if err != nil {
+			return &colonyv1.BulkAgentResult{Error: err.Error()}
+		}
+
+		// Leave the agent time to report its own timeout.
+		execCtx, cancel := context.WithTimeout(ctx, timeout+5*time.Second)
+		defer cancel()
+
+		execReq := connect.NewRequest(&agentv1.ShellExecRequest{
+			Command:        req.Msg.Command,
+			UserId:         actor,
+			TimeoutSeconds: uint32(timeout / time.Second), // #nosec G115 -- at most MaxBulkExecTimeout.
+		})
+		execReq.Header().Set("Authorization", "Bearer "+capability)
+
+		resp, err := s.agentClient(entry).ShellExec(execCtx, execReq)
+		if err != nil {
+			return &colonyv1.BulkAgentResult{Error: err.Error()}
+		}
+		return &colonyv1.BulkAgentResult{
+			Success:    resp.Msg.Error == "" && resp.Msg.ExitCode == 0,
+			Error:      resp.Msg.Error,
+			ExitCode:   resp.Msg.ExitCode,
+			Stdout:     resp.Msg.Stdout,
+			Stderr:     resp.Msg.Stderr,
+			DurationMs: resp.Msg.DurationMs,
+		}
+	})
+
+	s.auditBulk(ctx, actor, "ExecAgents", req.Msg.Command, results)
+
+	s.logger.Info().
+		Str("selector", req.Msg.Selector).
+		Strs("command", req.Msg.Command).
+		Int("agents", len(results)).
+		Msg("Ran command on agents")
+
+	return connect.NewResponse(&colonyv1.ExecAgentsResponse{Results: results}), nil
+}
+
+// DrainAgents drains or undrains all agents matching a selector. The colony
+// starts no new debug sessions or profiles on drained agents; running ones
+// are left alone.
+func (s *Server) DrainAgents(
+	ctx context.Context,
+	req *connect.Request[colonyv1.DrainAgentsRequest],
+) (*connect.Response[colonyv1.DrainAgentsResponse], error) {
+	if err := httpapi.RequirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+
+	targets, err := s.bulkTargets(req.Msg.Selector, req.Msg.AgentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	drained := !req.Msg.Undrain
+	results := runBulk(ctx, targets, 1, func(ctx context.Context, entry *registry.Entry) *colonyv1.BulkAgentResult {
+		if err := s.registry.SetDrained(ctx, entry.AgentID, drained); err != nil {
+			return &colonyv1.BulkAgentResult{Error: err.Error()}
+		}
+		return &colonyv1.BulkAgentResult{Success: true}
+	})
+
+	action := "DrainAgents"
+	if !drained {
+		action = "UndrainAgents"
+	}
+	s.auditBulk(ctx, audit.Actor(ctx, req.Peer().Addr, ""), action, nil, results)
+
+	s.logger.Info().
+		Str("selector", req.Msg.Selector).
+		Bool("drained", drained).
+		Int("agents", len(results)).
+		Msg("Changed drain state of agents")
+
+	return connect.NewResponse(&colonyv1.DrainAgentsResponse{Results: results}), nil
+}
+
+// LabelAgents sets and removes labels of all agents matching a selector.
+func (s *Server) LabelAgents(
+	ctx context.Context,
+	req *connect.Request[colonyv1.LabelAgentsRequest],
+) (*connect.Response[colonyv1.LabelAgentsResponse], error) {
+	if err := httpapi.RequirePermission(ctx, auth.PermissionAdmin); err != nil {
+		return nil, err
+	}
+	if len(req.Msg.Set) == 0 && len(req.Msg.Remove) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("labels to set or remove are required"))
+	}
+	for key := range req.Msg.Set {
+		if key == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("label keys must not be empty"))
+		}
+	}
+
+	targets, err := s.bulkTargets(req.Msg.Selector, req.Msg.AgentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	results := runBulk(ctx, targets, 1, func(ctx context.Context, entry *registry.Entry) *colonyv1.BulkAgentResult {
+		labels, err := s.registry.SetLabels(ctx, entry.AgentID, req.Msg.Set, req.Msg.Remove)
+		if err != nil {
+			return &colonyv1.BulkAgentResult{Error: err.Error()}
+		}
+		return &colonyv1.BulkAgentResult{Success: true, Labels: labels}
+	})
+
+	s.auditBulk(ctx, audit.Actor(ctx, req.Peer().Addr, ""), "LabelAgents", labelArgs(req.Msg.Set, req.Msg.Remove), results)
+
+	s.logger.Info().
+		Str("selector", req.Msg.Selector).
+		Int("agents", len(results)).
+		Msg("Changed labels of agents")
+
+	return connect.NewResponse(&colonyv1.LabelAgentsResponse{Results: results}), nil
+}
+
+// labelArgs lists label changes as "key=value" and "key-" arguments, like
+// coral colony agents label, in a stable order.
+func labelArgs(set map[string]string, remove []string) []string {
+	args := make([]string, 0, len(set)+len(remove))
+	for key, value := range set {
+		args = append(args, key+"="+value)
+	}
+	sort.Strings(args)
+	for _, key := range remove {
+		args = append(args, key+"-")
+	}
+	return args
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+)
+
+// echoAgentHandler runs "echo" and fails any other command. Like agents with
+// agent.security.require_capability_tokens, it requires a capability token.
+type echoAgentHandler struct {
+	agentv1connect.UnimplementedAgentServiceHandler
+}
+
+func (echoAgentHandler) ShellExec(
+	_ context.Context,
+	req *connect.Request[agentv1.ShellExecRequest],
+) (*connect.Response[agentv1.ShellExecResponse], error) {
+	if !auth.IsCapabilityToken(strings.TrimPrefix(req.Header().Get("Authorization"), "Bearer ")) {
+		return nil, connect.NewError(connect.CodeUnauthenticated, nil)
+	}
+	if req.Msg.UserId != "alice" {
+		return connect.NewResponse(&agentv1.ShellExecResponse{Error: "unexpected user " + req.Msg.UserId}), nil
+	}
+	if req.Msg.Command[0] != "echo" {
+		return connect.NewResponse(&agentv1.ShellExecResponse{ExitCode: 127, Stderr: []byte("not found")}), nil
+	}
+	return connect.NewResponse(&agentv1.ShellExecResponse{
+		Stdout: []byte(strings.Join(req.Msg.Command[1:], " ")),
+	}), nil
+}
+
+func TestServer_BulkAgents(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+	server.SetAuditRecorder(audit.NewRecorder(server.database, zerolog.Nop()))
+	ctx := context.WithValue(context.Background(), httpapi.TokenContextKey,
+		&auth.APIToken{TokenID: "alice-laptop", User: "alice", Role: auth.RoleAdmin})
+
+	_, h := agentv1connect.NewAgentServiceHandler(echoAgentHandler{})
+	agentSrv := httptest.NewServer(h)
+	defer agentSrv.Close()
+	server.agentClient = func(*registry.Entry) agentv1connect.AgentServiceClient {
+		return agentv1connect.NewAgentServiceClient(http.DefaultClient, agentSrv.URL)
+	}
+
+	for _, agentID := range []string{"agent-1", "agent-2", "agent-3"} {
+		_, err := server.registry.Register(agentID, "", "100.64.0.2", "", nil, nil, "")
+		require.NoError(t, err)
+	}
+
+	t.Run("requires admin", func(t *testing.T) {
+		debugCtx := context.WithValue(context.Background(), httpapi.TokenContextKey,
+			&auth.APIToken{TokenID: "bob-laptop", User: "bob", Role: auth.RoleDebugger})
+		for name, ctx := range map[string]context.Context{"no token": context.Background(), "debugger": debugCtx} {
+			_, err := server.ExecAgents(ctx, connect.NewRequest(&colonyv1.ExecAgentsRequest{
+				AgentIds: []string{"agent-1"},
+				Command:  []string{"echo"},
+			}))
+			assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), name)
+			_, err = server.DrainAgents(ctx, connect.NewRequest(&colonyv1.DrainAgentsRequest{AgentIds: []string{"agent-1"}}))
+			assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), name)
+			_, err = server.LabelAgents(ctx, connect.NewRequest(&colonyv1.LabelAgentsRequest{
+				AgentIds: []string{"agent-1"},
+				Set:      map[string]string{"env": "prod"},
+			}))
+			assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err), name)
+		}
+	})
+
+	t.Run("label", func(t *testing.T) {
+		resp, err := server.LabelAgents(ctx, connect.NewRequest(&colonyv1.LabelAgentsRequest{
+			AgentIds: []string{"agent-2", "agent-1", "agent-unknown"},
+			Set:      map[string]string{"env": "prod"},
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Results, 3)
+		assert.Equal(t, "agent-1", resp.Msg.Results[0].AgentId, "ordered by agent ID")
+		assert.True(t, resp.Msg.Results[0].Success)
+		assert.Equal(t, map[string]string{"env": "prod"}, resp.Msg.Results[0].Labels)
+		assert.Equal(t, "agent-unknown", resp.Msg.Results[2].AgentId)
+		assert.False(t, resp.Msg.Results[2].Success)
+		assert.Contains(t, resp.Msg.Results[2].Error, "agent not found")
+
+		_, err = server.LabelAgents(ctx, connect.NewRequest(&colonyv1.LabelAgentsRequest{
+			Set: map[string]string{"env": "prod"},
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "a selector or agent IDs are required")
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		resp, err := server.ExecAgents(ctx, connect.NewRequest(&colonyv1.ExecAgentsRequest{
+			Selector:    "env=prod",
+			Command:     []string{"echo", "hello"},
+			Concurrency: 1,
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Results, 2)
+		for i, agentID := range []string{"agent-1", "agent-2"} {
+			assert.Equal(t, agentID, resp.Msg.Results[i].AgentId)
+			assert.True(t, resp.Msg.Results[i].Success)
+			assert.Equal(t, "hello", string(resp.Msg.Results[i].Stdout))
+		}
+
+		resp, err = server.ExecAgents(ctx, connect.NewRequest(&colonyv1.ExecAgentsRequest{
+			AgentIds: []string{"agent-3"},
+			Command:  []string{"false"},
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Results, 1)
+		assert.False(t, resp.Msg.Results[0].Success, "a non-zero exit code fails")
+		assert.Equal(t, int32(127), resp.Msg.Results[0].ExitCode)
+
+		entries, err := server.database.ListAuditEntries(ctx, database.AuditFilters{Action: "ExecAgents", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, entries, 3, "one entry per agent")
+		for _, entry := range entries {
+			assert.Equal(t, "alice", entry.Actor)
+		}
+		assert.Equal(t, "agent=agent-3", entries[0].Target, "newest first")
+		assert.Equal(t, audit.ResultFailed, entries[0].Result)
+		assert.Equal(t, audit.HashArgs([]string{"false"}), entries[0].ArgumentsHash)
+
+		_, err = server.ExecAgents(ctx, connect.NewRequest(&colonyv1.ExecAgentsRequest{
+			Selector:       "env=prod",
+			Command:        []string{"echo"},
+			TimeoutSeconds: 3600,
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("drain", func(t *testing.T) {
+		resp, err := server.DrainAgents(ctx, connect.NewRequest(&colonyv1.DrainAgentsRequest{Selector: "env=prod"}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Results, 2)
+
+		for agentID, drained := range map[string]bool{"agent-1": true, "agent-2": true, "agent-3": false} {
+			entry, err := server.registry.Get(agentID)
+			require.NoError(t, err)
+			assert.Equal(t, drained, entry.Drained, agentID)
+		}
+
+		_, err = server.DrainAgents(ctx, connect.NewRequest(&colonyv1.DrainAgentsRequest{
+			AgentIds: []string{"agent-1"},
+			Undrain:  true,
+		}))
+		require.NoError(t, err)
+		entry, err := server.registry.Get("agent-1")
+		require.NoError(t, err)
+		assert.False(t, entry.Drained)
+	})
+}
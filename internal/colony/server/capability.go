@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+)
+
+// MintCapabilityToken mints a short-lived capability token. Callers delegate
+// a subset of their own permissions: an API token must grant every requested
+// scope, and capability tokens cannot mint further tokens. Mesh peers
+// without a token may mint tokens for anything but admin, unless actions
+// require RBAC.
+func (s *Server) MintCapabilityToken(
+	ctx context.Context,
+	req *connect.Request[colonyv1.MintCapabilityTokenRequest],
+) (*connect.Response[colonyv1.MintCapabilityTokenResponse], error) {
+	if len(req.Msg.Scopes) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at least one scope is required"))
+	}
+	if s.caManager == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("CA is not initialized"))
+	}
+
+	scopes := make([]auth.Scope, 0, len(req.Msg.Scopes))
+	for _, raw := range req.Msg.Scopes {
+		scope, err := auth.ParseScope(raw)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		scopes = append(scopes, scope)
+	}
+
+	token := httpapi.GetAuthenticatedToken(ctx)
+	switch {
+	case token != nil && token.Capability != nil:
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("capability tokens cannot mint capability tokens"))
+	case token == nil && s.config.RBACForActions:
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("minting capability tokens requires an API token (set CORAL_API_TOKEN)"))
+	}
+	for _, scope := range scopes {
+		granted := scope.Permission != auth.PermissionAdmin
+		if token != nil {
+			granted = auth.HasPermission(token, scope.Permission)
+		}
+		if !granted {
+			return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("scope %q exceeds the caller's permissions", scope))
+		}
+	}
+
+	issuedBy := audit.Actor(ctx, req.Peer().Addr, "")
+	subject := req.Msg.Subject
+	if subject == "" {
+		subject = issuedBy
+	}
+
+	signed, c, err := s.caManager.IssueCapabilityToken(subject, issuedBy, scopes, req.Msg.Ttl.AsDuration())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	resp := &colonyv1.MintCapabilityTokenResponse{
+		Token:     signed,
+		TokenId:   c.ID,
+		Subject:   c.Subject,
+		ExpiresAt: timestamppb.New(c.ExpiresAt),
+	}
+	for _, scope := range c.Scopes {
+		resp.Scopes = append(resp.Scopes, scope.String())
+	}
+	return connect.NewResponse(resp), nil
+}
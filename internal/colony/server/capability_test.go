@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+)
+
+func TestServer_MintCapabilityToken(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+
+	debugger := &auth.APIToken{TokenID: "alice-laptop", User: "alice", Role: auth.RoleDebugger}
+	debuggerCtx := context.WithValue(context.Background(), httpapi.TokenContextKey, debugger)
+
+	mint := func(ctx context.Context, msg *colonyv1.MintCapabilityTokenRequest) (*colonyv1.MintCapabilityTokenResponse, error) {
+		resp, err := server.MintCapabilityToken(ctx, connect.NewRequest(msg))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	t.Run("delegates the caller's permissions", func(t *testing.T) {
+		resp, err := mint(debuggerCtx, &colonyv1.MintCapabilityTokenRequest{
+			Scopes:  []string{"debug:api"},
+			Ttl:     durationpb.New(30 * time.Minute),
+			Subject: "ci-deploy",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "ci-deploy", resp.Subject)
+		assert.Equal(t, []string{"debug:api"}, resp.Scopes)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), resp.ExpiresAt.AsTime(), time.Minute)
+
+		c, err := server.caManager.CapabilityVerifier().Verify(resp.Token)
+		require.NoError(t, err)
+		assert.Equal(t, resp.TokenId, c.ID)
+		assert.Equal(t, "alice", c.IssuedBy)
+		assert.True(t, c.Allows(auth.PermissionDebug, "api"))
+		assert.False(t, c.Allows(auth.PermissionDebug, "web"))
+	})
+
+	t.Run("defaults the subject to the caller", func(t *testing.T) {
+		resp, err := mint(debuggerCtx, &colonyv1.MintCapabilityTokenRequest{Scopes: []string{"query"}})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", resp.Subject)
+	})
+
+	t.Run("rejects scopes beyond the caller's permissions", func(t *testing.T) {
+		_, err := mint(debuggerCtx, &colonyv1.MintCapabilityTokenRequest{Scopes: []string{"admin"}})
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("rejects capability tokens", func(t *testing.T) {
+		capability := (&auth.Capability{
+			ID:     "cap-1",
+			Scopes: []auth.Scope{{Permission: auth.PermissionDebug}},
+		}).APIToken()
+		ctx := context.WithValue(context.Background(), httpapi.TokenContextKey, capability)
+
+		_, err := mint(ctx, &colonyv1.MintCapabilityTokenRequest{Scopes: []string{"debug"}})
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("mesh peers without a token", func(t *testing.T) {
+		_, err := mint(context.Background(), &colonyv1.MintCapabilityTokenRequest{Scopes: []string{"debug"}})
+		require.NoError(t, err)
+
+		_, err = mint(context.Background(), &colonyv1.MintCapabilityTokenRequest{Scopes: []string{"admin"}})
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+		server.config.RBACForActions = true
+		defer func() { server.config.RBACForActions = false }()
+		_, err = mint(context.Background(), &colonyv1.MintCapabilityTokenRequest{Scopes: []string{"debug"}})
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := mint(debuggerCtx, &colonyv1.MintCapabilityTokenRequest{})
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = mint(debuggerCtx, &colonyv1.MintCapabilityTokenRequest{Scopes: []string{"shell"}})
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = mint(debuggerCtx, &colonyv1.MintCapabilityTokenRequest{
+			Scopes: []string{"debug"},
+			Ttl:    durationpb.New(48 * time.Hour),
+		})
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+)
+
+const (
+	// DefaultColonySecretGracePeriod is how long the previous colony secret
+	// is accepted after a rotation, unless the request sets it.
+	DefaultColonySecretGracePeriod = 24 * time.Hour
+
+	// colonySecretPushTimeout bounds pushing the new secret to an agent.
+	colonySecretPushTimeout = 10 * time.Second
+)
+
+// ColonySecretRotator rotates the colony secret agents register with (see
+// mesh.ColonySecrets).
+type ColonySecretRotator interface {
+	Rotate(gracePeriod time.Duration) (current, previous string, previousExpiresAt time.Time, err error)
+}
+
+// SetColonySecretRotator enables RotateColonySecret.
+func (s *Server) SetColonySecretRotator(rotator ColonySecretRotator) {
+	s.secretRotator = rotator
+}
+
+// RotateColonySecret rotates the colony secret and pushes the new one to the
+// connected agents that registered with the colony secret. Agents it cannot
+// be pushed to are reported: they keep registering with the previous secret
+// until its grace period ends.
+func (s *Server) RotateColonySecret(
+	ctx context.Context,
+	req *connect.Request[colonyv1.RotateColonySecretRequest],
+) (*connect.Response[colonyv1.RotateColonySecretResponse], error) {
+	if s.secretRotator == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("colony secret rotation is not available"))
+	}
+
+	gracePeriod := DefaultColonySecretGracePeriod
+	if req.Msg.GracePeriod != nil {
+		gracePeriod = req.Msg.GracePeriod.AsDuration()
+	}
+	if gracePeriod < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("grace period must not be negative"))
+	}
+
+	current, previous, expiresAt, err := s.secretRotator.Rotate(gracePeriod)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to rotate colony secret")
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+
+	resp := &colonyv1.RotateColonySecretResponse{
+		ColonySecret:      current,
+		PreviousExpiresAt: timestamppb.New(expiresAt),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	proof := auth.ColonySecretProof(previous, current)
+	for _, entry := range s.registry.ListAll() {
+		if entry.ColonySecret == registry.ColonySecretNone {
+			continue
+		}
+		wg.Add(1)
+		go func(e *registry.Entry) {
+			defer wg.Done()
+			err := s.pushColonySecret(ctx, e, current, proof)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				s.logger.Warn().
+					Err(err).
+					Str("agent_id", e.AgentID).
+					Msg("Failed to push the new colony secret to agent")
+				_ = s.registry.SetColonySecretStatus(e.AgentID, registry.ColonySecretPrevious)
+				resp.StaleAgents = append(resp.StaleAgents, &colonyv1.StaleColonySecretAgent{
+					AgentId: e.AgentID,
+					Error:   err.Error(),
+				})
+				return
+			}
+			_ = s.registry.SetColonySecretStatus(e.AgentID, registry.ColonySecretCurrent)
+			resp.UpdatedAgents = append(resp.UpdatedAgents, e.AgentID)
+		}(entry)
+	}
+	wg.Wait()
+
+	sort.Strings(resp.UpdatedAgents)
+	sort.Slice(resp.StaleAgents, func(i, j int) bool {
+		return resp.StaleAgents[i].AgentId < resp.StaleAgents[j].AgentId
+	})
+
+	s.logger.Info().
+		Time("previous_expires_at", expiresAt).
+		Int("updated_agents", len(resp.UpdatedAgents)).
+		Int("stale_agents", len(resp.StaleAgents)).
+		Msg("Colony secret rotated")
+
+	return connect.NewResponse(resp), nil
+}
+
+// pushColonySecret sends the new colony secret to an agent over the mesh.
+func (s *Server) pushColonySecret(ctx context.Context, entry *registry.Entry, secret string, proof []byte) error {
+	if status := registry.EntryStatus(entry, time.Now()); status == registry.StatusUnhealthy {
+		return fmt.Errorf("agent is %s", status)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, colonySecretPushTimeout)
+	defer cancel()
+	_, err := s.agentClient(entry).UpdateColonySecret(ctx, connect.NewRequest(&agentv1.UpdateColonySecretRequest{
+		ColonySecret: secret,
+		Proof:        proof,
+	}))
+	return err
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+)
+
+// fakeRotator rotates the colony secret "old" to "new".
+type fakeRotator struct {
+	gracePeriod time.Duration
+}
+
+func (f *fakeRotator) Rotate(gracePeriod time.Duration) (string, string, time.Time, error) {
+	f.gracePeriod = gracePeriod
+	return "new", "old", time.Now().Add(gracePeriod), nil
+}
+
+// secretAgentHandler accepts colony secrets proven with "old".
+type secretAgentHandler struct {
+	agentv1connect.UnimplementedAgentServiceHandler
+}
+
+func (secretAgentHandler) UpdateColonySecret(
+	_ context.Context,
+	req *connect.Request[agentv1.UpdateColonySecretRequest],
+) (*connect.Response[agentv1.UpdateColonySecretResponse], error) {
+	if !auth.VerifyColonySecretProof("old", req.Msg.ColonySecret, req.Msg.Proof) {
+		return nil, connect.NewError(connect.CodePermissionDenied, nil)
+	}
+	return connect.NewResponse(&agentv1.UpdateColonySecretResponse{}), nil
+}
+
+func TestServer_RotateColonySecret(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+
+	_, err := server.RotateColonySecret(context.Background(), connect.NewRequest(&colonyv1.RotateColonySecretRequest{}))
+	assert.Equal(t, connect.CodeUnavailable, connect.CodeOf(err), "rotation needs a rotator")
+
+	rotator := &fakeRotator{}
+	server.SetColonySecretRotator(rotator)
+
+	// agent-1 takes the new secret, agent-2 is too old to know the RPC and
+	// agent-3 registered with a certificate alone.
+	urls := make(map[string]string)
+	for agentID, handler := range map[string]agentv1connect.AgentServiceHandler{
+		"agent-1": secretAgentHandler{},
+		"agent-2": agentv1connect.UnimplementedAgentServiceHandler{},
+	} {
+		_, h := agentv1connect.NewAgentServiceHandler(handler)
+		srv := httptest.NewServer(h)
+		defer srv.Close()
+		urls[agentID] = srv.URL
+	}
+	server.agentClient = func(e *registry.Entry) agentv1connect.AgentServiceClient {
+		return agentv1connect.NewAgentServiceClient(http.DefaultClient, urls[e.AgentID])
+	}
+	for agentID, status := range map[string]registry.ColonySecretStatus{
+		"agent-1": registry.ColonySecretCurrent,
+		"agent-2": registry.ColonySecretCurrent,
+		"agent-3": registry.ColonySecretNone,
+	} {
+		_, err := server.registry.Register(agentID, "", "100.64.0.2", "", nil, nil, "")
+		require.NoError(t, err)
+		require.NoError(t, server.registry.SetColonySecretStatus(agentID, status))
+	}
+
+	resp, err := server.RotateColonySecret(context.Background(), connect.NewRequest(&colonyv1.RotateColonySecretRequest{
+		GracePeriod: durationpb.New(time.Hour),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, rotator.gracePeriod)
+	assert.Equal(t, "new", resp.Msg.ColonySecret)
+	assert.Equal(t, []string{"agent-1"}, resp.Msg.UpdatedAgents)
+	require.Len(t, resp.Msg.StaleAgents, 1)
+	assert.Equal(t, "agent-2", resp.Msg.StaleAgents[0].AgentId)
+
+	for agentID, want := range map[string]registry.ColonySecretStatus{
+		"agent-1": registry.ColonySecretCurrent,
+		"agent-2": registry.ColonySecretPrevious,
+		"agent-3": registry.ColonySecretNone,
+	} {
+		entry, err := server.registry.Get(agentID)
+		require.NoError(t, err)
+		assert.Equal(t, want, entry.ColonySecret, agentID)
+	}
+
+	_, err = server.RotateColonySecret(context.Background(), connect.NewRequest(&colonyv1.RotateColonySecretRequest{}))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultColonySecretGracePeriod, rotator.gracePeriod)
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+)
+
+const (
+	// defaultDeployCompareWindow is the length of the windows compared
+	// before and after a deployment.
+	defaultDeployCompareWindow = 30 * time.Minute
+
+	// latencyRegressionPct is the latency percentile increase reported as
+	// a regression, ignoring increases under latencyRegressionMinMs.
+	latencyRegressionPct   = 20.0
+	latencyRegressionMinMs = 5.0
+
+	// errorRateRegressionPts is the error rate increase, in percentage
+	// points, reported as a regression.
+	errorRateRegressionPts = 1.0
+
+	// deployCompareTopK is the number of CPU hotspots compared.
+	deployCompareTopK = 10
+
+	// maxNewErrorSignatures bounds the new kinds of errors reported.
+	maxNewErrorSignatures = 10
+)
+
+// CompareDeployments compares a service in the window before a deployment
+// with the window after it, and reports regressions.
+func (s *Server) CompareDeployments(
+	ctx context.Context,
+	req *connect.Request[colonyv1.CompareDeploymentsRequest],
+) (*connect.Response[colonyv1.CompareDeploymentsResponse], error) {
+	service := req.Msg.Service
+	if service == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("service is required"))
+	}
+
+	window := defaultDeployCompareWindow
+	if req.Msg.Window != "" {
+		d, err := time.ParseDuration(req.Msg.Window)
+		if err != nil || d <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid window: %q", req.Msg.Window))
+		}
+		window = d
+	}
+
+	resp := &colonyv1.CompareDeploymentsResponse{Service: service}
+
+	var deployTime time.Time
+	if req.Msg.DeployTime != nil {
+		deployTime = req.Msg.DeployTime.AsTime()
+	} else {
+		build, err := s.database.GetLatestBinaryMetadata(ctx, service)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition,
+				fmt.Errorf("no deployment recorded for service %s; pass a deploy time", service))
+		}
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to look up latest build: %w", err))
+		}
+		deployTime = build.FirstSeen
+		resp.BuildId = build.BuildID
+	}
+
+	now := time.Now()
+	if deployTime.After(now) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("deploy time %s is in the future", deployTime.Format(time.RFC3339)))
+	}
+	resp.DeployTime = timestamppb.New(deployTime)
+
+	baselineStart := deployTime.Add(-window)
+	currentEnd := deployTime.Add(window)
+	if currentEnd.After(now) {
+		currentEnd = now
+	}
+
+	baseline, err := s.database.QueryServiceWindowStats(ctx, service, baselineStart, deployTime)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	current, err := s.database.QueryServiceWindowStats(ctx, service, deployTime, currentEnd)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp.Baseline = windowStatsToProto(baseline, baselineStart, deployTime)
+	resp.Current = windowStatsToProto(current, deployTime, currentEnd)
+
+	hotspots, err := s.database.CompareHotspotsBetweenWindows(ctx, service, baselineStart, deployTime, deployTime, currentEnd, deployCompareTopK)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	for _, ind := range hotspots {
+		regType := colonyv1.RegressionType_REGRESSION_TYPE_NEW_HOTSPOT
+		switch ind.Type {
+		case "increased_hotspot":
+			regType = colonyv1.RegressionType_REGRESSION_TYPE_INCREASED_HOTSPOT
+		case "decreased_hotspot":
+			regType = colonyv1.RegressionType_REGRESSION_TYPE_DECREASED_HOTSPOT
+		}
+		resp.CpuRegressions = append(resp.CpuRegressions, &colonyv1.RegressionIndicator{
+			Type:               regType,
+			Message:            ind.Message,
+			BaselinePercentage: ind.BaselinePercentage,
+			CurrentPercentage:  ind.CurrentPercentage,
+			Delta:              ind.Delta,
+		})
+	}
+
+	baselineErrors, err := s.database.QueryServiceErrorSignatures(ctx, service, baselineStart, deployTime)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	currentErrors, err := s.database.QueryServiceErrorSignatures(ctx, service, deployTime, currentEnd)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp.NewErrors = newErrorSignatures(baselineErrors, currentErrors)
+
+	resp.Findings = deploymentFindings(baseline, current, resp.CpuRegressions, resp.NewErrors)
+	resp.Regressed = len(resp.Findings) > 0
+
+	return connect.NewResponse(resp), nil
+}
+
+// windowStatsToProto converts the stats of the window from start to end.
+func windowStatsToProto(stats *database.ServiceWindowStats, start, end time.Time) *colonyv1.DeploymentWindowStats {
+	return &colonyv1.DeploymentWindowStats{
+		StartTime:    timestamppb.New(start),
+		EndTime:      timestamppb.New(end),
+		RequestCount: stats.Requests,
+		ErrorRate:    stats.ErrorRate(),
+		P50Ms:        stats.P50Ms,
+		P95Ms:        stats.P95Ms,
+		P99Ms:        stats.P99Ms,
+	}
+}
+
+// newErrorSignatures returns the current error signatures absent from the
+// baseline, most frequent first.
+func newErrorSignatures(baseline, current []database.ErrorSignature) []*colonyv1.NewErrorSignature {
+	seen := make(map[string]bool, len(baseline))
+	for _, sig := range baseline {
+		seen[sig.Signature] = true
+	}
+
+	var result []*colonyv1.NewErrorSignature
+	for _, sig := range current {
+		if seen[sig.Signature] {
+			continue
+		}
+		result = append(result, &colonyv1.NewErrorSignature{Signature: sig.Signature, Count: sig.Count})
+		if len(result) == maxNewErrorSignatures {
+			break
+		}
+	}
+	return result
+}
+
+// deploymentFindings describes the regressions between the baseline and the
+// current window: traffic loss, error rate, new errors, latency, then CPU.
+func deploymentFindings(
+	baseline, current *database.ServiceWindowStats,
+	cpu []*colonyv1.RegressionIndicator,
+	newErrors []*colonyv1.NewErrorSignature,
+) []string {
+	var findings []string
+
+	if baseline.Requests > 0 && current.Requests == 0 {
+		findings = append(findings, fmt.Sprintf("No requests since the deployment (%d before)", baseline.Requests))
+	}
+
+	if delta := current.ErrorRate() - baseline.ErrorRate(); current.Requests > 0 && delta >= errorRateRegressionPts {
+		findings = append(findings, fmt.Sprintf("Error rate rose from %.1f%% to %.1f%%", baseline.ErrorRate(), current.ErrorRate()))
+	}
+
+	for _, sig := range newErrors {
+		findings = append(findings, fmt.Sprintf("New error: %s (%d)", sig.Signature, sig.Count))
+	}
+
+	for _, p := range []struct {
+		name              string
+		baseline, current float64
+	}{
+		{"p99", baseline.P99Ms, current.P99Ms},
+		{"p95", baseline.P95Ms, current.P95Ms},
+	} {
+		if p.baseline <= 0 || p.current-p.baseline < latencyRegressionMinMs {
+			continue
+		}
+		if pct := (p.current - p.baseline) / p.baseline * 100; pct >= latencyRegressionPct {
+			findings = append(findings, fmt.Sprintf("%s latency rose %.0f%% (%.0fms to %.0fms)", p.name, pct, p.baseline, p.current))
+		}
+	}
+
+	for _, ind := range cpu {
+		if ind.Type != colonyv1.RegressionType_REGRESSION_TYPE_DECREASED_HOTSPOT {
+			findings = append(findings, "CPU: "+ind.Message)
+		}
+	}
+
+	return findings
+}
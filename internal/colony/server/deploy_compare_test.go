@@ -0,0 +1,125 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_CompareDeployments(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{database: db, logger: zerolog.Nop()}
+	ctx := context.Background()
+	deploy := time.Now().Add(-time.Hour).Truncate(time.Minute)
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := s.CompareDeployments(ctx, connect.NewRequest(&colonyv1.CompareDeploymentsRequest{}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = s.CompareDeployments(ctx, connect.NewRequest(&colonyv1.CompareDeploymentsRequest{Service: "api", Window: "soon"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = s.CompareDeployments(ctx, connect.NewRequest(&colonyv1.CompareDeploymentsRequest{
+			Service: "api", DeployTime: timestamppb.New(time.Now().Add(time.Hour)),
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = s.CompareDeployments(ctx, connect.NewRequest(&colonyv1.CompareDeploymentsRequest{Service: "api"}))
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err), "no build recorded")
+	})
+
+	for _, m := range []struct {
+		at     time.Time
+		status int
+		bucket float64
+		count  int
+	}{
+		{deploy.Add(-10 * time.Minute), 200, 100, 100},
+		{deploy.Add(10 * time.Minute), 200, 250, 95},
+		{deploy.Add(10 * time.Minute), 503, 250, 5},
+	} {
+		_, err := db.DB().ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', 'api', 'GET', '/orders', ?, ?, ?)
+		`, m.at, m.status, m.bucket, m.count)
+		require.NoError(t, err)
+	}
+
+	resp, err := s.CompareDeployments(ctx, connect.NewRequest(&colonyv1.CompareDeploymentsRequest{
+		Service:    "api",
+		DeployTime: timestamppb.New(deploy),
+		Window:     "20m",
+	}))
+	require.NoError(t, err)
+
+	report := resp.Msg
+	assert.True(t, deploy.Add(-20*time.Minute).Equal(report.Baseline.StartTime.AsTime()))
+	assert.True(t, deploy.Add(20*time.Minute).Equal(report.Current.EndTime.AsTime()))
+	assert.Equal(t, int64(100), report.Baseline.RequestCount)
+	assert.InDelta(t, 5.0, report.Current.ErrorRate, 0.001)
+	require.Len(t, report.NewErrors, 1)
+	assert.Equal(t, "HTTP 503 GET /orders", report.NewErrors[0].Signature)
+	assert.True(t, report.Regressed)
+	assert.Equal(t, []string{
+		"Error rate rose from 0.0% to 5.0%",
+		"New error: HTTP 503 GET /orders (5)",
+		"p99 latency rose 150% (100ms to 250ms)",
+		"p95 latency rose 150% (100ms to 250ms)",
+	}, report.Findings)
+}
+
+func TestDeploymentFindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseline database.ServiceWindowStats
+		current  database.ServiceWindowStats
+		cpu      []*colonyv1.RegressionIndicator
+		want     []string
+	}{
+		{
+			name:     "unchanged",
+			baseline: database.ServiceWindowStats{Requests: 100, Errors: 1, P95Ms: 100, P99Ms: 200},
+			current:  database.ServiceWindowStats{Requests: 100, Errors: 1, P95Ms: 110, P99Ms: 210},
+		},
+		{
+			name:     "small absolute latency increase",
+			baseline: database.ServiceWindowStats{Requests: 100, P95Ms: 2, P99Ms: 4},
+			current:  database.ServiceWindowStats{Requests: 100, P95Ms: 5, P99Ms: 8},
+		},
+		{
+			name:     "traffic lost",
+			baseline: database.ServiceWindowStats{Requests: 100},
+			current:  database.ServiceWindowStats{},
+			want:     []string{"No requests since the deployment (100 before)"},
+		},
+		{
+			name:     "cpu regressions only",
+			baseline: database.ServiceWindowStats{Requests: 100},
+			current:  database.ServiceWindowStats{Requests: 100},
+			cpu: []*colonyv1.RegressionIndicator{
+				{Type: colonyv1.RegressionType_REGRESSION_TYPE_NEW_HOTSPOT, Message: "json.Marshal (30.0%) was not in top-10 before this deployment"},
+				{Type: colonyv1.RegressionType_REGRESSION_TYPE_DECREASED_HOTSPOT, Message: "gzip.Write decreased from 40.0% to 10.0%"},
+			},
+			want: []string{"CPU: json.Marshal (30.0%) was not in top-10 before this deployment"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, deploymentFindings(&tt.baseline, &tt.current, tt.cpu, nil))
+		})
+	}
+}
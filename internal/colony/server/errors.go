@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+const (
+	// defaultErrorTrendBuckets is the number of buckets of the trend of an
+	// error group, and maxErrorTrendBuckets its bound.
+	defaultErrorTrendBuckets = 20
+	maxErrorTrendBuckets     = 100
+
+	// defaultMaxErrorGroups is the number of error groups returned.
+	defaultMaxErrorGroups = 20
+)
+
+// QueryErrors aggregates the failed HTTP requests, failed gRPC calls and Go
+// errors captured by debug sessions over a time range.
+func (s *Server) QueryErrors(
+	ctx context.Context,
+	req *connect.Request[colonyv1.QueryErrorsRequest],
+) (*connect.Response[colonyv1.QueryErrorsResponse], error) {
+	startTime, endTime, err := parseTimeRange(req.Msg.TimeRange)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid time_range: %w", err))
+	}
+	if !endTime.After(startTime) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid time_range: %q", req.Msg.TimeRange))
+	}
+
+	buckets := defaultErrorTrendBuckets
+	if req.Msg.TrendBuckets > 0 {
+		buckets = min(int(req.Msg.TrendBuckets), maxErrorTrendBuckets)
+	}
+	maxGroups := defaultMaxErrorGroups
+	if req.Msg.MaxGroups > 0 {
+		maxGroups = int(req.Msg.MaxGroups)
+	}
+
+	groups, err := s.database.QueryErrorGroups(ctx, req.Msg.Service, startTime, endTime, buckets)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &colonyv1.QueryErrorsResponse{
+		StartTime:   timestamppb.New(startTime),
+		EndTime:     timestamppb.New(endTime),
+		BucketMs:    endTime.Sub(startTime).Milliseconds() / int64(buckets),
+		TotalGroups: int32(len(groups)), // #nosec G115 -- bounded by the rows of the time range.
+	}
+	for i, g := range groups {
+		resp.TotalErrors += g.Count
+		if i >= maxGroups {
+			continue
+		}
+		resp.Groups = append(resp.Groups, &colonyv1.ErrorGroup{
+			Service:   g.Service,
+			Source:    g.Source,
+			Route:     g.Route,
+			Signature: g.Signature,
+			Example:   g.Example,
+			Count:     g.Count,
+			FirstSeen: timestamppb.New(g.FirstSeen),
+			LastSeen:  timestamppb.New(g.LastSeen),
+			Trend:     g.Trend,
+		})
+	}
+
+	return connect.NewResponse(resp), nil
+}
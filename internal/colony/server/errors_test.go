@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_QueryErrors(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{database: db, logger: zerolog.Nop()}
+	ctx := context.Background()
+
+	_, err = s.QueryErrors(ctx, connect.NewRequest(&colonyv1.QueryErrorsRequest{TimeRange: "recently"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = s.QueryErrors(ctx, connect.NewRequest(&colonyv1.QueryErrorsRequest{TimeRange: "-1h"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	for i, route := range []string{"/a", "/b", "/c"} {
+		_, err := db.DB().ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', 'api', 'GET', ?, 500, 10, ?)
+		`, time.Now().Add(-5*time.Minute), route, i+1)
+		require.NoError(t, err)
+	}
+
+	resp, err := s.QueryErrors(ctx, connect.NewRequest(&colonyv1.QueryErrorsRequest{
+		Service:      "api",
+		TimeRange:    "30m",
+		TrendBuckets: 3,
+		MaxGroups:    2,
+	}))
+	require.NoError(t, err)
+
+	msg := resp.Msg
+	assert.Equal(t, int64(6), msg.TotalErrors)
+	assert.Equal(t, int32(3), msg.TotalGroups)
+	assert.Equal(t, (10 * time.Minute).Milliseconds(), msg.BucketMs)
+	require.Len(t, msg.Groups, 2)
+	assert.Equal(t, "GET /c", msg.Groups[0].Route)
+	assert.Equal(t, "HTTP 500", msg.Groups[0].Signature)
+	assert.Equal(t, []int64{0, 0, 3}, msg.Groups[0].Trend)
+	assert.Equal(t, "GET /b", msg.Groups[1].Route)
+
+	resp, err = s.QueryErrors(ctx, connect.NewRequest(&colonyv1.QueryErrorsRequest{TrendBuckets: 1000}))
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.Groups[0].Trend, maxErrorTrendBuckets)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/events"
+)
+
+// SetEventBroker sets the broker backing SubscribeEvents.
+func (s *Server) SetEventBroker(broker *events.Broker) {
+	s.events = broker
+}
+
+// SubscribeEvents streams colony events matching the request filter: recent
+// events first when replay is requested, then new events until the client
+// disconnects when follow is requested.
+func (s *Server) SubscribeEvents(
+	ctx context.Context,
+	req *connect.Request[colonyv1.SubscribeEventsRequest],
+	stream *connect.ServerStream[colonyv1.ColonyEvent],
+) error {
+	if s.events == nil {
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("event streaming is not enabled"))
+	}
+
+	sub := s.events.Subscribe(events.Filter{
+		Types:       req.Msg.Types,
+		ServiceName: req.Msg.ServiceName,
+		AgentID:     req.Msg.AgentId,
+	})
+	defer s.events.Unsubscribe(sub)
+
+	if req.Msg.Replay {
+		for _, event := range sub.Recent() {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+	if !req.Msg.Follow {
+		return nil
+	}
+
+	// Flush the response headers so the client sees the subscription as
+	// established before the first event.
+	if err := stream.Send(nil); err != nil {
+		return err
+	}
+
+	s.logger.Debug().
+		Str("service", req.Msg.ServiceName).
+		Str("agent_id", req.Msg.AgentId).
+		Int("subscribers", s.events.SubscriberCount()).
+		Msg("Event subscriber connected")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/colony/events"
+)
+
+func newEventsTestClient(t *testing.T, broker *events.Broker) colonyv1connect.ColonyServiceClient {
+	t.Helper()
+
+	s := &Server{logger: zerolog.Nop()}
+	if broker != nil {
+		s.SetEventBroker(broker)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(colonyv1connect.NewColonyServiceHandler(s))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return colonyv1connect.NewColonyServiceClient(srv.Client(), srv.URL)
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	broker := events.NewBroker(zerolog.Nop())
+	client := newEventsTestClient(t, broker)
+
+	broker.Publish(&colonyv1.ColonyEvent{
+		Type:        colonyv1.ColonyEventType_COLONY_EVENT_TYPE_SERVICE_REGISTERED,
+		AgentId:     "agent-1",
+		ServiceName: "api",
+	})
+	broker.Publish(&colonyv1.ColonyEvent{
+		Type:    colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_CONNECTED,
+		AgentId: "agent-2",
+	})
+
+	t.Run("replay only", func(t *testing.T) {
+		stream, err := client.SubscribeEvents(context.Background(), connect.NewRequest(&colonyv1.SubscribeEventsRequest{
+			ServiceName: "api",
+			Replay:      true,
+		}))
+		require.NoError(t, err)
+		defer func() { _ = stream.Close() }()
+
+		var received []*colonyv1.ColonyEvent
+		for stream.Receive() {
+			received = append(received, stream.Msg())
+		}
+		require.NoError(t, stream.Err())
+		require.Len(t, received, 1)
+		assert.Equal(t, "api", received[0].ServiceName)
+	})
+
+	t.Run("follow", func(t *testing.T) {
+		broker := events.NewBroker(zerolog.Nop())
+		client := newEventsTestClient(t, broker)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.SubscribeEvents(ctx, connect.NewRequest(&colonyv1.SubscribeEventsRequest{
+			AgentId: "agent-3",
+			Follow:  true,
+		}))
+		require.NoError(t, err)
+		defer func() { _ = stream.Close() }()
+
+		// Publish once the subscription is registered.
+		go func() {
+			for broker.SubscriberCount() == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			broker.Publish(&colonyv1.ColonyEvent{
+				Type:    colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED,
+				AgentId: "agent-3",
+			})
+		}()
+
+		require.True(t, stream.Receive(), "stream error: %v", stream.Err())
+		assert.Equal(t, colonyv1.ColonyEventType_COLONY_EVENT_TYPE_AGENT_DISCONNECTED, stream.Msg().Type)
+	})
+}
+
+func TestSubscribeEvents_NoBroker(t *testing.T) {
+	client := newEventsTestClient(t, nil)
+
+	stream, err := client.SubscribeEvents(context.Background(), connect.NewRequest(&colonyv1.SubscribeEventsRequest{Follow: true}))
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	assert.False(t, stream.Receive())
+	assert.Equal(t, connect.CodeUnavailable, connect.CodeOf(stream.Err()))
+}
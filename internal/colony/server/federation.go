@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"sync"
+
+	"connectrpc.com/connect"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// ChildColony is a child colony federated requests fan out to.
+type ChildColony struct {
+	ColonyID string
+	Client   colonyv1connect.ColonyServiceClient
+}
+
+// SetChildColonies sets the child colonies of this colony
+// (federation.children in the colony config).
+func (s *Server) SetChildColonies(children []ChildColony) {
+	s.children = children
+}
+
+// childResponse is the response of a single child colony.
+type childResponse[T any] struct {
+	colonyID string
+	msg      *T
+}
+
+// fanOut calls every child colony concurrently. Responses are returned in the
+// order children were configured; children that failed are reported as
+// federation errors.
+func fanOut[T any](
+	ctx context.Context,
+	children []ChildColony,
+	call func(context.Context, colonyv1connect.ColonyServiceClient) (*connect.Response[T], error),
+) ([]childResponse[T], []*colonyv1.FederationError) {
+	msgs := make([]*T, len(children))
+	errs := make([]error, len(children))
+
+	var wg sync.WaitGroup
+	for i, child := range children {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			childCtx, cancel := context.WithTimeout(ctx, constants.DefaultFederationQueryTimeout)
+			defer cancel()
+
+			resp, err := call(childCtx, child.Client)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			msgs[i] = resp.Msg
+		}()
+	}
+	wg.Wait()
+
+	var (
+		responses []childResponse[T]
+		fedErrs   []*colonyv1.FederationError
+	)
+	for i, child := range children {
+		if errs[i] != nil {
+			fedErrs = append(fedErrs, &colonyv1.FederationError{
+				ColonyId: child.ColonyID,
+				Error:    errs[i].Error(),
+			})
+			continue
+		}
+		responses = append(responses, childResponse[T]{colonyID: child.ColonyID, msg: msgs[i]})
+	}
+
+	return responses, fedErrs
+}
+
+// federateListAgents labels local agents with this colony's ID and appends
+// the agents of every child colony.
+func (s *Server) federateListAgents(ctx context.Context, req *colonyv1.ListAgentsRequest, resp *colonyv1.ListAgentsResponse) {
+	for _, agent := range resp.Agents {
+		agent.ColonyId = s.config.ColonyID
+	}
+
+	children, fedErrs := fanOut(ctx, s.children, func(ctx context.Context, client colonyv1connect.ColonyServiceClient) (*connect.Response[colonyv1.ListAgentsResponse], error) {
+		return client.ListAgents(ctx, connect.NewRequest(&colonyv1.ListAgentsRequest{Selector: req.Selector}))
+	})
+	for _, child := range children {
+		for _, agent := range child.msg.Agents {
+			agent.ColonyId = child.colonyID
+			resp.Agents = append(resp.Agents, agent)
+		}
+	}
+	resp.FederationErrors = fedErrs
+}
+
+// federateSummary labels local summaries with this colony's ID and appends
+// the summaries of every child colony.
+func (s *Server) federateSummary(ctx context.Context, req *colonyv1.QueryUnifiedSummaryRequest, resp *colonyv1.QueryUnifiedSummaryResponse) {
+	for _, summary := range resp.Summaries {
+		summary.ColonyId = s.config.ColonyID
+	}
+
+	childReq := &colonyv1.QueryUnifiedSummaryRequest{
+		Service:          req.Service,
+		TimeRange:        req.TimeRange,
+		IncludeProfiling: req.IncludeProfiling,
+		TopKHotspots:     req.TopKHotspots,
+		Selector:         req.Selector,
+	}
+	children, fedErrs := fanOut(ctx, s.children, func(ctx context.Context, client colonyv1connect.ColonyServiceClient) (*connect.Response[colonyv1.QueryUnifiedSummaryResponse], error) {
+		return client.QueryUnifiedSummary(ctx, connect.NewRequest(childReq))
+	})
+	for _, child := range children {
+		for _, summary := range child.msg.Summaries {
+			summary.ColonyId = child.colonyID
+			resp.Summaries = append(resp.Summaries, summary)
+		}
+	}
+	resp.FederationErrors = fedErrs
+}
+
+// federateTraces labels local spans with this colony's ID and appends the
+// spans of every child colony.
+func (s *Server) federateTraces(ctx context.Context, req *colonyv1.QueryUnifiedTracesRequest, resp *colonyv1.QueryUnifiedTracesResponse) {
+	for _, span := range resp.Spans {
+		span.Attributes = withColonyLabel(span.Attributes, s.config.ColonyID)
+	}
+
+	childReq := &colonyv1.QueryUnifiedTracesRequest{
+		Service:       req.Service,
+		TimeRange:     req.TimeRange,
+		Source:        req.Source,
+		TraceId:       req.TraceId,
+		MinDurationMs: req.MinDurationMs,
+		MaxTraces:     req.MaxTraces,
+		Selector:      req.Selector,
+	}
+	children, fedErrs := fanOut(ctx, s.children, func(ctx context.Context, client colonyv1connect.ColonyServiceClient) (*connect.Response[colonyv1.QueryUnifiedTracesResponse], error) {
+		return client.QueryUnifiedTraces(ctx, connect.NewRequest(childReq))
+	})
+	for _, child := range children {
+		for _, span := range child.msg.Spans {
+			span.Attributes = withColonyLabel(span.Attributes, child.colonyID)
+			resp.Spans = append(resp.Spans, span)
+		}
+		resp.TotalTraces += child.msg.TotalTraces
+	}
+	resp.FederationErrors = fedErrs
+}
+
+// federateMetrics labels local metrics with this colony's ID and appends the
+// metrics of every child colony.
+func (s *Server) federateMetrics(ctx context.Context, req *colonyv1.QueryUnifiedMetricsRequest, resp *colonyv1.QueryUnifiedMetricsResponse) {
+	labelMetrics(resp, s.config.ColonyID)
+
+	childReq := &colonyv1.QueryUnifiedMetricsRequest{
+		Service:         req.Service,
+		TimeRange:       req.TimeRange,
+		Source:          req.Source,
+		Protocol:        req.Protocol,
+		HttpRoute:       req.HttpRoute,
+		HttpMethod:      req.HttpMethod,
+		StatusCodeRange: req.StatusCodeRange,
+		Selector:        req.Selector,
+	}
+	children, fedErrs := fanOut(ctx, s.children, func(ctx context.Context, client colonyv1connect.ColonyServiceClient) (*connect.Response[colonyv1.QueryUnifiedMetricsResponse], error) {
+		return client.QueryUnifiedMetrics(ctx, connect.NewRequest(childReq))
+	})
+	for _, child := range children {
+		labelMetrics(child.msg, child.colonyID)
+		resp.HttpMetrics = append(resp.HttpMetrics, child.msg.HttpMetrics...)
+		resp.GrpcMetrics = append(resp.GrpcMetrics, child.msg.GrpcMetrics...)
+		resp.SqlMetrics = append(resp.SqlMetrics, child.msg.SqlMetrics...)
+	}
+	resp.TotalMetrics = int32(len(resp.HttpMetrics) + len(resp.GrpcMetrics) + len(resp.SqlMetrics)) // #nosec G115 -- bounded by query limits.
+	resp.FederationErrors = fedErrs
+}
+
+// labelMetrics sets the colony label on every metric of resp.
+func labelMetrics(resp *colonyv1.QueryUnifiedMetricsResponse, colonyID string) {
+	for _, m := range resp.HttpMetrics {
+		m.Attributes = withColonyLabel(m.Attributes, colonyID)
+	}
+	for _, m := range resp.GrpcMetrics {
+		m.Attributes = withColonyLabel(m.Attributes, colonyID)
+	}
+	for _, m := range resp.SqlMetrics {
+		m.Attributes = withColonyLabel(m.Attributes, colonyID)
+	}
+}
+
+// withColonyLabel adds the colony label to attrs, allocating it if needed.
+func withColonyLabel(attrs map[string]string, colonyID string) map[string]string {
+	if attrs == nil {
+		attrs = make(map[string]string, 1)
+	}
+	attrs[constants.FederationColonyAttribute] = colonyID
+	return attrs
+}
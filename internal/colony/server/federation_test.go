@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/colony"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// fakeChildColony serves canned responses for federated queries.
+type fakeChildColony struct {
+	colonyv1connect.UnimplementedColonyServiceHandler
+	federatedCalls int
+}
+
+func (c *fakeChildColony) ListAgents(
+	_ context.Context,
+	req *connect.Request[colonyv1.ListAgentsRequest],
+) (*connect.Response[colonyv1.ListAgentsResponse], error) {
+	if req.Msg.Federated {
+		c.federatedCalls++
+	}
+	return connect.NewResponse(&colonyv1.ListAgentsResponse{
+		Agents: []*colonyv1.Agent{{AgentId: "child-agent"}},
+	}), nil
+}
+
+func (c *fakeChildColony) QueryUnifiedTraces(
+	_ context.Context,
+	req *connect.Request[colonyv1.QueryUnifiedTracesRequest],
+) (*connect.Response[colonyv1.QueryUnifiedTracesResponse], error) {
+	if req.Msg.Federated {
+		c.federatedCalls++
+	}
+	return connect.NewResponse(&colonyv1.QueryUnifiedTracesResponse{
+		Spans:       []*agentv1.EbpfTraceSpan{{TraceId: "child-trace", ServiceName: "api"}},
+		TotalTraces: 1,
+	}), nil
+}
+
+func newTestChildColony(t *testing.T, colonyID string, handler colonyv1connect.ColonyServiceHandler) ChildColony {
+	t.Helper()
+
+	_, h := colonyv1connect.NewColonyServiceHandler(handler)
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	return ChildColony{
+		ColonyID: colonyID,
+		Client:   colonyv1connect.NewColonyServiceClient(http.DefaultClient, srv.URL),
+	}
+}
+
+func TestFederation_ListAgents(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "parent"})
+	defer cleanup()
+
+	child := &fakeChildColony{}
+	server.SetChildColonies([]ChildColony{
+		newTestChildColony(t, "child-a", child),
+		newTestChildColony(t, "child-b", &colonyv1connect.UnimplementedColonyServiceHandler{}),
+	})
+
+	t.Run("not federated", func(t *testing.T) {
+		resp, err := server.ListAgents(context.Background(), connect.NewRequest(&colonyv1.ListAgentsRequest{}))
+		require.NoError(t, err)
+		assert.Empty(t, resp.Msg.Agents)
+		assert.Empty(t, resp.Msg.FederationErrors)
+	})
+
+	t.Run("federated", func(t *testing.T) {
+		resp, err := server.ListAgents(context.Background(), connect.NewRequest(&colonyv1.ListAgentsRequest{Federated: true}))
+		require.NoError(t, err)
+
+		require.Len(t, resp.Msg.Agents, 1)
+		assert.Equal(t, "child-agent", resp.Msg.Agents[0].AgentId)
+		assert.Equal(t, "child-a", resp.Msg.Agents[0].ColonyId)
+
+		// The failing child is reported without failing the whole query.
+		require.Len(t, resp.Msg.FederationErrors, 1)
+		assert.Equal(t, "child-b", resp.Msg.FederationErrors[0].ColonyId)
+
+		// Children are never asked to federate further.
+		assert.Zero(t, child.federatedCalls)
+	})
+}
+
+func TestFederation_QueryUnifiedTraces(t *testing.T) {
+	server := &Server{
+		config: Config{ColonyID: "parent"},
+		ebpfService: &mockEbpfService{
+			traceSpans: []*agentv1.EbpfTraceSpan{{TraceId: "local-trace", ServiceName: "api"}},
+		},
+	}
+	server.SetChildColonies([]ChildColony{newTestChildColony(t, "child-a", &fakeChildColony{})})
+
+	resp, err := server.QueryUnifiedTraces(context.Background(), connect.NewRequest(&colonyv1.QueryUnifiedTracesRequest{
+		Service:   "api",
+		Federated: true,
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Msg.Spans, 2)
+	assert.Equal(t, int32(2), resp.Msg.TotalTraces)
+	assert.Equal(t, "parent", resp.Msg.Spans[0].Attributes[constants.FederationColonyAttribute])
+	assert.Equal(t, "child-a", resp.Msg.Spans[1].Attributes[constants.FederationColonyAttribute])
+}
+
+func TestFederation_QueryUnifiedSummary(t *testing.T) {
+	server := &Server{
+		config: Config{ColonyID: "parent"},
+		ebpfService: &mockEbpfService{
+			summaryResults: []colony.UnifiedSummaryResult{{ServiceName: "api", Status: colony.ServiceStatusHealthy}},
+		},
+	}
+	server.SetChildColonies([]ChildColony{newTestChildColony(t, "child-a", &colonyv1connect.UnimplementedColonyServiceHandler{})})
+
+	resp, err := server.QueryUnifiedSummary(context.Background(), connect.NewRequest(&colonyv1.QueryUnifiedSummaryRequest{
+		Federated: true,
+	}))
+	require.NoError(t, err)
+
+	require.Len(t, resp.Msg.Summaries, 1)
+	assert.Equal(t, "parent", resp.Msg.Summaries[0].ColonyId)
+	require.Len(t, resp.Msg.FederationErrors, 1)
+	assert.Equal(t, "child-a", resp.Msg.FederationErrors[0].ColonyId)
+}
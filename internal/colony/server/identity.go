@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+)
+
+// GetIdentity returns the identity of the API token authenticated by the
+// public endpoint or the mesh action RBAC middleware.
+func (s *Server) GetIdentity(
+	ctx context.Context,
+	req *connect.Request[colonyv1.GetIdentityRequest],
+) (*connect.Response[colonyv1.GetIdentityResponse], error) {
+	resp := &colonyv1.GetIdentityResponse{
+		RbacForActions:        s.config.RBACForActions,
+		ApprovalRequiredTools: s.config.MCPApprovalTools,
+	}
+
+	token := httpapi.GetAuthenticatedToken(ctx)
+	if token == nil {
+		return connect.NewResponse(resp), nil
+	}
+
+	resp.Authenticated = true
+	resp.TokenId = token.TokenID
+	resp.User = token.User
+	resp.Role = string(token.Role)
+	for _, p := range auth.EffectivePermissions(token) {
+		resp.Permissions = append(resp.Permissions, string(p))
+	}
+
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+)
+
+func TestServer_GetIdentity(t *testing.T) {
+	s := &Server{config: Config{RBACForActions: true}}
+	req := connect.NewRequest(&colonyv1.GetIdentityRequest{})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		resp, err := s.GetIdentity(context.Background(), req)
+		require.NoError(t, err)
+		assert.False(t, resp.Msg.Authenticated)
+		assert.True(t, resp.Msg.RbacForActions)
+	})
+
+	t.Run("role token", func(t *testing.T) {
+		token := &auth.APIToken{TokenID: "alice-laptop", User: "alice", Role: auth.RoleDebugger}
+		ctx := context.WithValue(context.Background(), httpapi.TokenContextKey, token)
+
+		resp, err := s.GetIdentity(ctx, req)
+		require.NoError(t, err)
+		assert.True(t, resp.Msg.Authenticated)
+		assert.Equal(t, "alice-laptop", resp.Msg.TokenId)
+		assert.Equal(t, "alice", resp.Msg.User)
+		assert.Equal(t, "debugger", resp.Msg.Role)
+		assert.Equal(t, []string{"status", "query", "analyze", "debug"}, resp.Msg.Permissions)
+	})
+}
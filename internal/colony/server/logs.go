@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/logbuffer"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// SetLogBuffer sets the ring buffer backing ReportLogs and TailLogs.
+func (s *Server) SetLogBuffer(buffer *logbuffer.Buffer) {
+	s.logs = buffer
+}
+
+// ReportLogs receives batches of service log lines from an agent and adds
+// them to the log buffer.
+func (s *Server) ReportLogs(
+	ctx context.Context,
+	stream *connect.ClientStream[colonyv1.ReportLogsRequest],
+) (*connect.Response[colonyv1.ReportLogsResponse], error) {
+	if s.logs == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("log collection is not enabled"))
+	}
+
+	resp := &colonyv1.ReportLogsResponse{}
+	for stream.Receive() {
+		msg := stream.Msg()
+		if msg.AgentId == "" {
+			s.logger.Warn().Msg("ReportLogs: received batch with empty agent_id, skipping")
+			continue
+		}
+		if msg.Dropped > 0 {
+			s.logger.Warn().
+				Str("agent_id", msg.AgentId).
+				Uint64("dropped", msg.Dropped).
+				Msg("Agent dropped log lines")
+		}
+
+		lines := make([]*colonyv1.LogLine, 0, len(msg.Lines))
+		for _, line := range msg.Lines {
+			if line.Service == "" {
+				continue
+			}
+			// Lines are attributed to the reporting agent.
+			line.AgentId = msg.AgentId
+			if line.Timestamp == nil {
+				line.Timestamp = timestamppb.Now()
+			}
+			lines = append(lines, line)
+		}
+		s.logs.Append(lines)
+		resp.LinesAccepted += int64(len(lines))
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// TailLogs streams the buffered log lines matching the request filter, then
+// new lines until the client disconnects when follow is requested.
+func (s *Server) TailLogs(
+	ctx context.Context,
+	req *connect.Request[colonyv1.TailLogsRequest],
+	stream *connect.ServerStream[colonyv1.LogLine],
+) error {
+	if s.logs == nil {
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("log collection is not enabled"))
+	}
+
+	filter := logbuffer.Filter{
+		Service: req.Msg.Service,
+		AgentID: req.Msg.AgentId,
+	}
+	if req.Msg.Since != nil {
+		filter.Since = req.Msg.Since.AsTime()
+	}
+	if req.Msg.Grep != "" {
+		pattern, err := regexp.Compile(req.Msg.Grep)
+		if err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid grep pattern: %w", err))
+		}
+		filter.Pattern = pattern
+	}
+
+	n := int(req.Msg.Lines)
+	if n <= 0 {
+		n = constants.DefaultTailLines
+	}
+
+	sub := s.logs.Subscribe(filter, n)
+	defer s.logs.Unsubscribe(sub)
+
+	for _, line := range sub.Recent() {
+		if err := stream.Send(line); err != nil {
+			return err
+		}
+	}
+	if !req.Msg.Follow {
+		return nil
+	}
+
+	// Flush the response headers so the client sees the stream as
+	// established before the first new line.
+	if err := stream.Send(nil); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case line, ok := <-sub.Lines():
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(line); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
+	"github.com/coral-mesh/coral/internal/colony/logbuffer"
+)
+
+func newLogsTestClient(t *testing.T, buffer *logbuffer.Buffer) colonyv1connect.ColonyServiceClient {
+	t.Helper()
+
+	s := &Server{logger: zerolog.Nop()}
+	if buffer != nil {
+		s.SetLogBuffer(buffer)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(colonyv1connect.NewColonyServiceHandler(s))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return colonyv1connect.NewColonyServiceClient(srv.Client(), srv.URL)
+}
+
+func reportLogs(t *testing.T, client colonyv1connect.ColonyServiceClient, req *colonyv1.ReportLogsRequest) int64 {
+	t.Helper()
+
+	stream := client.ReportLogs(context.Background())
+	require.NoError(t, stream.Send(req))
+	resp, err := stream.CloseAndReceive()
+	require.NoError(t, err)
+	return resp.Msg.LinesAccepted
+}
+
+func TestReportAndTailLogs(t *testing.T) {
+	buffer := logbuffer.New(100, zerolog.Nop())
+	client := newLogsTestClient(t, buffer)
+
+	accepted := reportLogs(t, client, &colonyv1.ReportLogsRequest{
+		AgentId: "agent-1",
+		Lines: []*colonyv1.LogLine{
+			{Service: "api", Source: "stdout", Line: "GET /orders 200", AgentId: "spoofed"},
+			{Service: "api", Source: "stderr", Line: "ERROR: database timeout"},
+			{Service: "web", Source: "journald", Line: "error rendering page"},
+			{Source: "stdout", Line: "no service"},
+		},
+	})
+	assert.Equal(t, int64(3), accepted)
+
+	t.Run("buffered lines", func(t *testing.T) {
+		stream, err := client.TailLogs(context.Background(), connect.NewRequest(&colonyv1.TailLogsRequest{
+			Service: "api",
+			Grep:    "ERROR",
+		}))
+		require.NoError(t, err)
+		defer func() { _ = stream.Close() }()
+
+		var received []*colonyv1.LogLine
+		for stream.Receive() {
+			received = append(received, stream.Msg())
+		}
+		require.NoError(t, stream.Err())
+		require.Len(t, received, 1)
+		assert.Equal(t, "ERROR: database timeout", received[0].Line)
+		assert.Equal(t, "agent-1", received[0].AgentId)
+		assert.NotNil(t, received[0].Timestamp)
+	})
+
+	t.Run("agent ID is set by the colony", func(t *testing.T) {
+		lines := buffer.Recent(logbuffer.Filter{AgentID: "spoofed"}, 10)
+		assert.Empty(t, lines)
+	})
+
+	t.Run("follow", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		stream, err := client.TailLogs(ctx, connect.NewRequest(&colonyv1.TailLogsRequest{
+			Service: "web",
+			Lines:   1,
+			Follow:  true,
+		}))
+		require.NoError(t, err)
+		defer func() { _ = stream.Close() }()
+
+		require.True(t, stream.Receive(), "stream error: %v", stream.Err())
+		assert.Equal(t, "error rendering page", stream.Msg().Line)
+
+		// Report once the subscription is registered.
+		go func() {
+			for buffer.SubscriberCount() == 0 {
+				time.Sleep(time.Millisecond)
+			}
+			buffer.Append([]*colonyv1.LogLine{{Service: "web", Line: "page rendered"}})
+		}()
+
+		require.True(t, stream.Receive(), "stream error: %v", stream.Err())
+		assert.Equal(t, "page rendered", stream.Msg().Line)
+	})
+}
+
+func TestTailLogs_InvalidGrep(t *testing.T) {
+	client := newLogsTestClient(t, logbuffer.New(10, zerolog.Nop()))
+
+	stream, err := client.TailLogs(context.Background(), connect.NewRequest(&colonyv1.TailLogsRequest{Grep: "("}))
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	assert.False(t, stream.Receive())
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(stream.Err()))
+}
+
+func TestTailLogs_NoBuffer(t *testing.T) {
+	client := newLogsTestClient(t, nil)
+
+	stream, err := client.TailLogs(context.Background(), connect.NewRequest(&colonyv1.TailLogsRequest{}))
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	assert.False(t, stream.Receive())
+	assert.Equal(t, connect.CodeUnavailable, connect.CodeOf(stream.Err()))
+}
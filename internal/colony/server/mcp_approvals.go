@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/approval"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/database"
+)
+
+// CreateMCPApproval holds an MCP tool call until a human approves or denies
+// it. The client polls GetMCPApproval for the decision.
+func (s *Server) CreateMCPApproval(
+	ctx context.Context,
+	req *connect.Request[colonyv1.CreateMCPApprovalRequest],
+) (*connect.Response[colonyv1.CreateMCPApprovalResponse], error) {
+	if req.Msg.Tool == "" || req.Msg.Command == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("tool and command are required"))
+	}
+
+	created := s.approvals.Create(req.Msg.Tool, req.Msg.Command, audit.Actor(ctx, req.Peer().Addr, req.Msg.User))
+	s.logger.Info().
+		Str("approval_id", created.ID).
+		Str("tool", created.Tool).
+		Str("requester", created.Requester).
+		Msg("MCP tool call awaiting approval")
+
+	return connect.NewResponse(&colonyv1.CreateMCPApprovalResponse{Approval: approvalToProto(created)}), nil
+}
+
+// GetMCPApproval returns the status of an approval.
+func (s *Server) GetMCPApproval(
+	ctx context.Context,
+	req *connect.Request[colonyv1.GetMCPApprovalRequest],
+) (*connect.Response[colonyv1.GetMCPApprovalResponse], error) {
+	got, err := s.approvals.Get(req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewResponse(&colonyv1.GetMCPApprovalResponse{Approval: approvalToProto(got)}), nil
+}
+
+// ListMCPApprovals returns pending and recently decided approvals.
+func (s *Server) ListMCPApprovals(
+	ctx context.Context,
+	req *connect.Request[colonyv1.ListMCPApprovalsRequest],
+) (*connect.Response[colonyv1.ListMCPApprovalsResponse], error) {
+	resp := &colonyv1.ListMCPApprovalsResponse{}
+	for _, a := range s.approvals.List(approval.Status(req.Msg.Status)) {
+		resp.Approvals = append(resp.Approvals, approvalToProto(a))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DecideMCPApproval approves or denies a pending tool call. The decision is
+// recorded in the audit log whether it comes over RPC or from the dashboard.
+func (s *Server) DecideMCPApproval(
+	ctx context.Context,
+	req *connect.Request[colonyv1.DecideMCPApprovalRequest],
+) (*connect.Response[colonyv1.DecideMCPApprovalResponse], error) {
+	decidedBy := audit.Actor(ctx, req.Peer().Addr, req.Msg.User)
+
+	decided, err := s.approvals.Decide(req.Msg.Id, req.Msg.Approve, decidedBy, req.Msg.Reason)
+	switch {
+	case errors.Is(err, approval.ErrNotFound):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, approval.ErrDecided):
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("approval %s is already %s", decided.ID, decided.Status))
+	case err != nil:
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	s.audit.Record(ctx, &database.AuditEntry{
+		Actor:  decidedBy,
+		Action: "DecideMCPApproval",
+		Target: fmt.Sprintf("approval=%s tool=%s requester=%s", decided.ID, decided.Tool, decided.Requester),
+		Result: string(decided.Status),
+	})
+	s.logger.Info().
+		Str("approval_id", decided.ID).
+		Str("tool", decided.Tool).
+		Str("decided_by", decidedBy).
+		Str("status", string(decided.Status)).
+		Msg("MCP tool call decided")
+
+	return connect.NewResponse(&colonyv1.DecideMCPApprovalResponse{Approval: approvalToProto(decided)}), nil
+}
+
+func approvalToProto(a approval.Request) *colonyv1.MCPApproval {
+	out := &colonyv1.MCPApproval{
+		Id:        a.ID,
+		Tool:      a.Tool,
+		Command:   a.Command,
+		Requester: a.Requester,
+		CreatedAt: timestamppb.New(a.CreatedAt),
+		ExpiresAt: timestamppb.New(a.ExpiresAt),
+		Status:    string(a.Status),
+		DecidedBy: a.DecidedBy,
+		Reason:    a.Reason,
+	}
+	if !a.DecidedAt.IsZero() {
+		out.DecidedAt = timestamppb.New(a.DecidedAt)
+	}
+	return out
+}
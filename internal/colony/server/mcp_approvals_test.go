@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/approval"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_MCPApprovals(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{database: db, approvals: approval.NewStore(0), logger: zerolog.Nop()}
+	s.SetAuditRecorder(audit.NewRecorder(db, zerolog.Nop()))
+	ctx := context.Background()
+
+	_, err = s.CreateMCPApproval(ctx, connect.NewRequest(&colonyv1.CreateMCPApprovalRequest{Tool: "coral_shell_exec"}))
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	created, err := s.CreateMCPApproval(ctx, connect.NewRequest(&colonyv1.CreateMCPApprovalRequest{
+		Tool:    "coral_shell_exec",
+		Command: "shell --agent api -- ls",
+		User:    "alice",
+	}))
+	require.NoError(t, err)
+	id := created.Msg.Approval.Id
+	assert.Equal(t, "pending", created.Msg.Approval.Status)
+	assert.Equal(t, "alice", created.Msg.Approval.Requester)
+
+	pending, err := s.ListMCPApprovals(ctx, connect.NewRequest(&colonyv1.ListMCPApprovalsRequest{Status: "pending"}))
+	require.NoError(t, err)
+	require.Len(t, pending.Msg.Approvals, 1)
+
+	decided, err := s.DecideMCPApproval(ctx, connect.NewRequest(&colonyv1.DecideMCPApprovalRequest{
+		Id: id, Approve: false, Reason: "use a read-only query", User: "bob",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "denied", decided.Msg.Approval.Status)
+	assert.Equal(t, "bob", decided.Msg.Approval.DecidedBy)
+	assert.NotNil(t, decided.Msg.Approval.DecidedAt)
+
+	got, err := s.GetMCPApproval(ctx, connect.NewRequest(&colonyv1.GetMCPApprovalRequest{Id: id}))
+	require.NoError(t, err)
+	assert.Equal(t, "use a read-only query", got.Msg.Approval.Reason)
+
+	_, err = s.DecideMCPApproval(ctx, connect.NewRequest(&colonyv1.DecideMCPApprovalRequest{Id: id, Approve: true, User: "mallory"}))
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	_, err = s.GetMCPApproval(ctx, connect.NewRequest(&colonyv1.GetMCPApprovalRequest{Id: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	events, err := s.ListAuditEvents(ctx, connect.NewRequest(&colonyv1.ListAuditEventsRequest{Actor: "bob"}))
+	require.NoError(t, err)
+	require.Len(t, events.Msg.Events, 1)
+	assert.Equal(t, "DecideMCPApproval", events.Msg.Events[0].Action)
+	assert.Equal(t, "denied", events.Msg.Events[0].Result)
+	assert.Contains(t, events.Msg.Events[0].Target, "tool=coral_shell_exec")
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// RecordMCPToolCall appends a tool call reported by an MCP proxy to the tool
+// call history.
+func (s *Server) RecordMCPToolCall(
+	ctx context.Context,
+	req *connect.Request[colonyv1.RecordMCPToolCallRequest],
+) (*connect.Response[colonyv1.RecordMCPToolCallResponse], error) {
+	call := req.Msg.Call
+	if call == nil || call.Tool == "" || call.Result == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("call with tool and result is required"))
+	}
+
+	command, err := json.Marshal(call.Command)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid command: %w", err))
+	}
+
+	if err := s.database.InsertMCPToolCall(ctx, &database.MCPToolCall{
+		Client:      call.Client,
+		Actor:       audit.Actor(ctx, req.Peer().Addr, call.Actor),
+		Tool:        call.Tool,
+		Arguments:   call.ArgumentsJson,
+		Command:     string(command),
+		DurationMs:  call.DurationMs,
+		ResultBytes: call.ResultBytes,
+		Result:      call.Result,
+		Error:       call.Error,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to record tool call: %w", err))
+	}
+
+	return connect.NewResponse(&colonyv1.RecordMCPToolCallResponse{}), nil
+}
+
+// ListMCPToolCalls returns recorded MCP tool calls, newest first.
+func (s *Server) ListMCPToolCalls(
+	ctx context.Context,
+	req *connect.Request[colonyv1.ListMCPToolCallsRequest],
+) (*connect.Response[colonyv1.ListMCPToolCallsResponse], error) {
+	filters := database.MCPToolCallFilters{
+		Tool:   req.Msg.Tool,
+		Actor:  req.Msg.Actor,
+		Client: req.Msg.Client,
+		Limit:  int(req.Msg.Limit),
+	}
+	if req.Msg.Since != nil {
+		filters.Since = req.Msg.Since.AsTime()
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = constants.DefaultMCPHistoryListLimit
+	}
+
+	calls, err := s.database.ListMCPToolCalls(ctx, filters)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list tool calls: %w", err))
+	}
+
+	resp := &colonyv1.ListMCPToolCallsResponse{}
+	for _, c := range calls {
+		resp.Calls = append(resp.Calls, mcpToolCallToProto(c))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetMCPToolCall returns one recorded MCP tool call.
+func (s *Server) GetMCPToolCall(
+	ctx context.Context,
+	req *connect.Request[colonyv1.GetMCPToolCallRequest],
+) (*connect.Response[colonyv1.GetMCPToolCallResponse], error) {
+	call, err := s.database.GetMCPToolCall(ctx, req.Msg.Id)
+	if errors.Is(err, database.ErrMCPToolCallNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("tool call %d not found", req.Msg.Id))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get tool call: %w", err))
+	}
+	return connect.NewResponse(&colonyv1.GetMCPToolCallResponse{Call: mcpToolCallToProto(call)}), nil
+}
+
+func mcpToolCallToProto(c *database.MCPToolCall) *colonyv1.MCPToolCall {
+	out := &colonyv1.MCPToolCall{
+		Id:            c.ID,
+		Timestamp:     timestamppb.New(c.Timestamp),
+		Client:        c.Client,
+		Actor:         c.Actor,
+		Tool:          c.Tool,
+		ArgumentsJson: c.Arguments,
+		DurationMs:    c.DurationMs,
+		ResultBytes:   c.ResultBytes,
+		Result:        c.Result,
+		Error:         c.Error,
+	}
+	// Rows are written by RecordMCPToolCall, so the command is always a
+	// JSON array; an unreadable one is returned empty.
+	_ = json.Unmarshal([]byte(c.Command), &out.Command)
+	return out
+}
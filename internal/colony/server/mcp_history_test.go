@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/httpapi"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_MCPToolCalls(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{database: db, logger: zerolog.Nop()}
+	ctx := context.Background()
+
+	_, err = s.RecordMCPToolCall(ctx, connect.NewRequest(&colonyv1.RecordMCPToolCallRequest{}))
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	token := &auth.APIToken{TokenID: "ci", User: "ci-bot", Role: auth.RoleViewer}
+	tokenCtx := context.WithValue(ctx, httpapi.TokenContextKey, token)
+	_, err = s.RecordMCPToolCall(tokenCtx, connect.NewRequest(&colonyv1.RecordMCPToolCallRequest{
+		Call: &colonyv1.MCPToolCall{
+			Client:        "http",
+			Actor:         "ignored",
+			Tool:          "coral_get_service_topology",
+			ArgumentsJson: `{"service":"api"}`,
+			Command:       []string{"query", "topology", "--service", "api"},
+			DurationMs:    120.5,
+			ResultBytes:   900,
+			Result:        "ok",
+		},
+	}))
+	require.NoError(t, err)
+
+	list, err := s.ListMCPToolCalls(ctx, connect.NewRequest(&colonyv1.ListMCPToolCallsRequest{Actor: "ci-bot"}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.Calls, 1)
+
+	got, err := s.GetMCPToolCall(ctx, connect.NewRequest(&colonyv1.GetMCPToolCallRequest{Id: list.Msg.Calls[0].Id}))
+	require.NoError(t, err)
+	call := got.Msg.Call
+	assert.Equal(t, "http", call.Client)
+	assert.Equal(t, []string{"query", "topology", "--service", "api"}, call.Command)
+	assert.Equal(t, `{"service":"api"}`, call.ArgumentsJson)
+	assert.Equal(t, 120.5, call.DurationMs)
+	assert.Equal(t, int64(900), call.ResultBytes)
+
+	_, err = s.GetMCPToolCall(ctx, connect.NewRequest(&colonyv1.GetMCPToolCallRequest{Id: 999}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
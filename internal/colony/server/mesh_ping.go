@@ -266,6 +266,9 @@ func assessNAT(registered, observed string, handshakeAge int64) string {
 	if handshakeAge == -1 {
 		return "no_handshake"
 	}
+	if wireguard.IsRelayEndpoint(observed) {
+		return "relayed" // No UDP path: packets go through the colony's relay.
+	}
 	if registered == "" {
 		return "roaming" // No STUN at registration; WireGuard roaming mode.
 	}
@@ -283,6 +286,6 @@ func assessNAT(registered, observed string, handshakeAge int64) string {
 	case regHost == obsHost:
 		return "symmetric" // Same IP but different port: symmetric NAT.
 	default:
-		return "unexpected" // Different IP: double NAT, relay, or carrier-grade NAT.
+		return "unexpected" // Different IP: double NAT or carrier-grade NAT.
 	}
 }
@@ -35,6 +35,15 @@ func (s *Server) ListServices(
 	}
 	cutoff := time.Now().Add(-duration)
 
+	selected, err := s.selectServices(req.Msg.Selector)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.groupServices(ctx, req.Msg.Group)
+	if err != nil {
+		return nil, err
+	}
+
 	// Enhanced query combining both registry and telemetry sources (RFD 084).
 	// Uses FULL OUTER JOIN to include services from either source.
 	query := `
@@ -123,6 +132,11 @@ func (s *Server) ListServices(
 			continue
 		}
 
+		// Apply label selector and service group if specified.
+		if (selected != nil && !selected[name]) || (members != nil && !members[name]) {
+			continue
+		}
+
 		// Determine service status based on source and registration status.
 		var status *colonyv1.ServiceStatus
 		switch source {
@@ -156,6 +170,7 @@ func (s *Server) ListServices(
 			LastSeen:      timestamppb.New(lastSeen),
 			Source:        source,
 			Status:        status,
+			Labels:        s.registry.ServiceLabels(name),
 		}
 
 		// Include agent_id if present and service is registered.
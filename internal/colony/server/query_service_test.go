@@ -13,7 +13,9 @@ import (
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
 	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/registry"
 	"github.com/coral-mesh/coral/internal/constants"
 )
 
@@ -109,6 +111,7 @@ func setupTestServerWithMetrics(t *testing.T) (*Server, *database.Database) {
 
 	server := &Server{
 		database: db,
+		registry: registry.New(nil),
 		logger:   logger,
 	}
 
@@ -141,6 +144,23 @@ func TestListServicesIntegration(t *testing.T) {
 			"Should find at least one of our test services")
 	})
 
+	t.Run("filters services by label selector", func(t *testing.T) {
+		server, _ := setupTestServerWithMetrics(t)
+		_, err := server.registry.Register("agent-2", "", "", "", []*meshv1.ServiceInfo{
+			{Name: "payment-service", Labels: map[string]string{"team": "payments"}},
+		}, nil, "")
+		require.NoError(t, err)
+		require.NoError(t, server.registry.SetReportedLabels("agent-2", map[string]string{"env": "prod"}))
+
+		resp, err := server.ListServices(context.Background(), connect.NewRequest(&colonyv1.ListServicesRequest{
+			Selector: "env=prod",
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Services, 1)
+		assert.Equal(t, "payment-service", resp.Msg.Services[0].Name)
+		assert.Equal(t, map[string]string{"env": "prod", "team": "payments"}, resp.Msg.Services[0].Labels)
+	})
+
 	t.Run("returns empty list when database is empty", func(t *testing.T) {
 		logger := zerolog.New(os.Stdout).Level(zerolog.Disabled)
 		tmpDir := t.TempDir()
@@ -149,6 +169,7 @@ func TestListServicesIntegration(t *testing.T) {
 
 		server := &Server{
 			database: db,
+			registry: registry.New(nil),
 			logger:   logger,
 		}
 
@@ -353,6 +374,7 @@ func TestListServicesDualSourceDiscovery(t *testing.T) {
 
 	server := &Server{
 		database: db,
+		registry: registry.New(nil),
 		logger:   logger,
 	}
 
@@ -531,6 +553,7 @@ func TestTableNameRegression(t *testing.T) {
 
 	server := &Server{
 		database: db,
+		registry: registry.New(nil),
 		logger:   logger,
 	}
 
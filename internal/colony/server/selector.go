@@ -0,0 +1,41 @@
+package server
+
+import (
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+)
+
+// selectServices returns the names of the services whose labels match
+// selector, or nil when selector is empty and all services are selected.
+func (s *Server) selectServices(selector string) (map[string]bool, error) {
+	if selector == "" {
+		return nil, nil
+	}
+	sel, err := registry.ParseSelector(selector)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return s.registry.SelectServices(sel), nil
+}
+
+// filterServices keeps the items of the selected services.
+func filterServices[T interface{ GetServiceName() string }](items []T, services map[string]bool) []T {
+	filtered := items[:0]
+	for _, item := range items {
+		if services[item.GetServiceName()] {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// countTraces returns the number of distinct traces spans belong to.
+func countTraces(spans []*agentv1.EbpfTraceSpan) int32 {
+	traces := make(map[string]bool)
+	for _, span := range spans {
+		traces[span.TraceId] = true
+	}
+	return int32(len(traces)) // #nosec G115 -- bounded by query limits.
+}
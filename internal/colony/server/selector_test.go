@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
+	"github.com/coral-mesh/coral/internal/colony"
+	"github.com/coral-mesh/coral/internal/colony/registry"
+)
+
+// newLabeledRegistry returns a registry with a prod agent running "api" and
+// a staging agent running "worker".
+func newLabeledRegistry(t *testing.T) *registry.Registry {
+	t.Helper()
+
+	reg := registry.New(nil)
+	_, err := reg.Register("agent-1", "", "", "", []*meshv1.ServiceInfo{{Name: "api"}}, nil, "")
+	require.NoError(t, err)
+	_, err = reg.Register("agent-2", "", "", "", []*meshv1.ServiceInfo{{Name: "worker"}}, nil, "")
+	require.NoError(t, err)
+	require.NoError(t, reg.SetReportedLabels("agent-1", map[string]string{"env": "prod"}))
+	require.NoError(t, reg.SetReportedLabels("agent-2", map[string]string{"env": "staging"}))
+	return reg
+}
+
+func TestQueryHandlers_Selector(t *testing.T) {
+	ctx := context.Background()
+	mockSvc := &mockEbpfService{
+		summaryResults: []colony.UnifiedSummaryResult{
+			{ServiceName: "api", Status: colony.ServiceStatusHealthy},
+			{ServiceName: "worker", Status: colony.ServiceStatusHealthy},
+		},
+		traceSpans: []*agentv1.EbpfTraceSpan{
+			{TraceId: "trace-1", ServiceName: "api"},
+			{TraceId: "trace-2", ServiceName: "worker"},
+		},
+		metricsResponse: &agentv1.QueryEbpfMetricsResponse{
+			HttpMetrics: []*agentv1.EbpfHttpMetric{{ServiceName: "api"}, {ServiceName: "worker"}},
+			SqlMetrics:  []*agentv1.EbpfSqlMetric{{ServiceName: "worker"}},
+		},
+	}
+	server := &Server{ebpfService: mockSvc, registry: newLabeledRegistry(t)}
+
+	t.Run("summary", func(t *testing.T) {
+		resp, err := server.QueryUnifiedSummary(ctx, connect.NewRequest(&colonyv1.QueryUnifiedSummaryRequest{
+			Selector: "env=prod",
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Summaries, 1)
+		assert.Equal(t, "api", resp.Msg.Summaries[0].ServiceName)
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		resp, err := server.QueryUnifiedTraces(ctx, connect.NewRequest(&colonyv1.QueryUnifiedTracesRequest{
+			Selector: "env!=prod",
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Spans, 1)
+		assert.Equal(t, "worker", resp.Msg.Spans[0].ServiceName)
+		assert.Equal(t, int32(1), resp.Msg.TotalTraces)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		resp, err := server.QueryUnifiedMetrics(ctx, connect.NewRequest(&colonyv1.QueryUnifiedMetricsRequest{
+			Selector: "env=staging",
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.HttpMetrics, 1)
+		assert.Equal(t, "worker", resp.Msg.HttpMetrics[0].ServiceName)
+		assert.Len(t, resp.Msg.SqlMetrics, 1)
+		assert.Equal(t, int32(2), resp.Msg.TotalMetrics)
+	})
+
+	t.Run("invalid selector", func(t *testing.T) {
+		_, err := server.QueryUnifiedTraces(ctx, connect.NewRequest(&colonyv1.QueryUnifiedTracesRequest{
+			Selector: "=prod",
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+}
+
+func TestServer_ListAgentsSelector(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+	server.registry = newLabeledRegistry(t)
+
+	_, err := server.registry.SetLabels(context.Background(), "agent-2", map[string]string{"env": "prod"}, nil)
+	require.NoError(t, err)
+
+	resp, err := server.ListAgents(context.Background(), connect.NewRequest(&colonyv1.ListAgentsRequest{
+		Selector: "env=prod",
+	}))
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.Agents, 2, "assigned labels override reported ones")
+
+	resp, err = server.ListAgents(context.Background(), connect.NewRequest(&colonyv1.ListAgentsRequest{
+		Selector: "env=staging",
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Msg.Agents)
+}
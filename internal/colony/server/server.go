@@ -11,22 +11,32 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
 	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
 	"github.com/coral-mesh/coral/coral/colony/v1/colonyv1connect"
 	meshv1 "github.com/coral-mesh/coral/coral/mesh/v1"
 	networkv1 "github.com/coral-mesh/coral/coral/network/v1"
 	"github.com/coral-mesh/coral/internal/colony"
+	"github.com/coral-mesh/coral/internal/colony/approval"
+	"github.com/coral-mesh/coral/internal/colony/audit"
 	"github.com/coral-mesh/coral/internal/colony/ca"
 	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/colony/events"
+	"github.com/coral-mesh/coral/internal/colony/logbuffer"
+	"github.com/coral-mesh/coral/internal/colony/querycache"
 	"github.com/coral-mesh/coral/internal/colony/registry"
 	"github.com/coral-mesh/coral/internal/colony/storage"
 	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/protocol"
 	"github.com/coral-mesh/coral/internal/wireguard"
 )
 
 // MeshInfoProvider is a callback that fetches live WireGuard/mesh statistics.
 type MeshInfoProvider func() map[string]interface{}
 
+// AgentEvictor removes a revoked agent from the registry and WireGuard peers.
+type AgentEvictor func(ctx context.Context, agentID, message string) error
+
 // WGStatsProvider returns the WireGuard device's live peer stats and configured peer list.
 // Returns (nil, nil) if the device is not available.
 type WGStatsProvider func() (*wireguard.DeviceStats, []*wireguard.PeerConfig)
@@ -45,6 +55,12 @@ type Config struct {
 	MeshIPv4           string
 	MeshIPv6           string
 	PublicEndpointURL  string // RFD 031 - public endpoint URL if enabled.
+	RBACForActions     bool   // Actions require a token with a matching role.
+
+	// MCP tools whose calls are held for a human decision, and how long a
+	// call waits (mcp.security.require_approval, approval_timeout).
+	MCPApprovalTools   []string
+	MCPApprovalTimeout time.Duration
 }
 
 // Server implements the ColonyService.
@@ -58,6 +74,18 @@ type Server struct {
 	logger           zerolog.Logger
 	meshInfoProvider MeshInfoProvider
 	wgStatsProvider  WGStatsProvider
+	agentEvictor     AgentEvictor
+	secretRotator    ColonySecretRotator
+	agentUpgrader    AgentUpgrader
+	agentClient      func(*registry.Entry) agentv1connect.AgentServiceClient
+	events           *events.Broker
+	logs             *logbuffer.Buffer // Recent service log lines (coral tail).
+	audit            *audit.Recorder
+	approvals        *approval.Store
+	alertSinks       []string
+	children         []ChildColony  // Federation children (federation.children).
+	slos             []SLO          // Service level objectives (slos).
+	serviceGroups    []ServiceGroup // Service groups of the colony config (service_groups).
 }
 
 // New creates a new colony server.
@@ -69,6 +97,9 @@ func New(reg *registry.Registry, db *database.Database, caManager *ca.Manager, c
 		config:    config,
 		startTime: time.Now(),
 		logger:    logger,
+		approvals: approval.NewStore(config.MCPApprovalTimeout),
+
+		agentClient: colony.GetAgentClient,
 	}
 }
 
@@ -82,6 +113,12 @@ func (s *Server) SetWGStatsProvider(provider WGStatsProvider) {
 	s.wgStatsProvider = provider
 }
 
+// SetAgentEvictor sets the callback that evicts agents revoked with
+// RevokeAgent.
+func (s *Server) SetAgentEvictor(evictor AgentEvictor) {
+	s.agentEvictor = evictor
+}
+
 // SetEbpfService sets the eBPF query service instance.
 func (s *Server) SetEbpfService(ebpfService interface{}) {
 	s.ebpfService = ebpfService
@@ -174,8 +211,13 @@ func (s *Server) ListAgents(
 ) (*connect.Response[colonyv1.ListAgentsResponse], error) {
 	s.logger.Debug().Msg("List agents request received")
 
-	// Get all registered agents.
-	entries := s.registry.ListAll()
+	sel, err := registry.ParseSelector(req.Msg.Selector)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// Get the registered agents matching the selector.
+	entries := s.registry.Select(sel)
 
 	// Convert registry entries to protobuf agents.
 	agents := make([]*colonyv1.Agent, len(entries))
@@ -193,18 +235,32 @@ func (s *Server) ListAgents(
 		go func() {
 			defer wg.Done()
 
-			status := registry.DetermineStatus(e.LastSeen, now)
+			status := registry.EntryStatus(e, now)
+			score, reasons := registry.HealthScore(e, now)
 
 			// Initialize agent with registry data.
 			agent := &colonyv1.Agent{
-				AgentId:        e.AgentID,
-				ComponentName:  e.Name,
-				MeshIpv4:       e.MeshIPv4,
-				MeshIpv6:       e.MeshIPv6,
-				LastSeen:       timestamppb.New(e.LastSeen),
-				Status:         string(status),
-				Services:       e.Services,       // Default to registry data
-				RuntimeContext: e.RuntimeContext, // RFD 018: Runtime context
+				AgentId:          e.AgentID,
+				ComponentName:    e.Name,
+				MeshIpv4:         e.MeshIPv4,
+				MeshIpv6:         e.MeshIPv6,
+				LastSeen:         timestamppb.New(e.LastSeen),
+				Status:           string(status),
+				Services:         e.Services,       // Default to registry data
+				RuntimeContext:   e.RuntimeContext, // RFD 018: Runtime context
+				ResourceShedding: e.ResourceShedding,
+				HealthScore:      int32(score), // #nosec G115 -- score is 0-100.
+				HealthReasons:    reasons,
+				ProtocolVersion:  uint32(e.Protocol()), // #nosec G115 -- protocol versions are small.
+				Labels:           e.AllLabels(),
+				Drained:          e.Drained,
+			}
+			for _, c := range protocol.Missing(e.Protocol(), protocol.Version) {
+				agent.MissingCapabilities = append(agent.MissingCapabilities, &colonyv1.ProtocolCapability{
+					Name:        c.Name,
+					Since:       uint32(c.Since), // #nosec G115 -- protocol versions are small.
+					Description: c.Description,
+				})
 			}
 
 			// If agent is healthy/degraded, try to query real-time services.
@@ -250,7 +306,11 @@ func (s *Server) ListAgents(
 	wg.Wait()
 
 	resp := &colonyv1.ListAgentsResponse{
-		Agents: agents,
+		Agents:          agents,
+		ProtocolVersion: protocol.Version,
+	}
+	if req.Msg.Federated {
+		s.federateListAgents(ctx, req.Msg, resp)
 	}
 
 	s.logger.Debug().
@@ -262,7 +322,7 @@ func (s *Server) ListAgents(
 
 // GetTopology handles topology request (RFD 092).
 // Returns all registered agents and the live service dependency graph derived
-// from observed trace data.
+// from observed trace data, client metrics and network connections.
 func (s *Server) GetTopology(
 	ctx context.Context,
 	req *connect.Request[colonyv1.GetTopologyRequest],
@@ -277,23 +337,71 @@ func (s *Server) GetTopology(
 	now := time.Now()
 
 	for _, entry := range entries {
-		status := registry.DetermineStatus(entry.LastSeen, now)
+		status := registry.EntryStatus(entry, now)
+		score, reasons := registry.HealthScore(entry, now)
 
 		agent := &colonyv1.Agent{
-			AgentId:        entry.AgentID,
-			ComponentName:  entry.Name,
-			MeshIpv4:       entry.MeshIPv4,
-			MeshIpv6:       entry.MeshIPv6,
-			LastSeen:       timestamppb.New(entry.LastSeen),
-			Status:         string(status),
-			Services:       entry.Services,       // RFD 011: Multi-service support
-			RuntimeContext: entry.RuntimeContext, // RFD 018: Runtime context
+			AgentId:          entry.AgentID,
+			ComponentName:    entry.Name,
+			MeshIpv4:         entry.MeshIPv4,
+			MeshIpv6:         entry.MeshIPv6,
+			LastSeen:         timestamppb.New(entry.LastSeen),
+			Status:           string(status),
+			Services:         entry.Services,       // RFD 011: Multi-service support
+			RuntimeContext:   entry.RuntimeContext, // RFD 018: Runtime context
+			ResourceShedding: entry.ResourceShedding,
+			HealthScore:      int32(score), // #nosec G115 -- score is 0-100.
+			HealthReasons:    reasons,
 		}
 		agents = append(agents, agent)
 	}
 
 	// Derive service connections from trace data (default 1h window).
-	since := time.Now().Add(-time.Hour)
+	window := time.Hour
+	if req.Msg.Since != "" {
+		d, err := time.ParseDuration(req.Msg.Since)
+		if err != nil || d <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid since: %q", req.Msg.Since))
+		}
+		window = d
+	}
+
+	// Connections are derived from ingested data, so they are cached until
+	// new data is ingested; agents come from the registry.
+	key, err := querycache.Key("GetTopology", req.Msg, 0)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	connections, err := querycache.Get(ctx, s.database.QueryCache(), key, topologySources,
+		func(ctx context.Context) ([]*colonyv1.Connection, error) {
+			return s.topologyConnections(ctx, window), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &colonyv1.GetTopologyResponse{
+		ColonyId:    s.config.ColonyID,
+		Agents:      agents,
+		Connections: connections,
+	}
+
+	s.logger.Debug().
+		Int("agent_count", len(agents)).
+		Int("connection_count", len(connections)).
+		Msg("Get topology response prepared")
+
+	return connect.NewResponse(resp), nil
+}
+
+// topologySources are the ingested data the topology connections are derived
+// from.
+var topologySources = []querycache.Source{querycache.Metrics, querycache.Traces, querycache.Connections}
+
+// topologyConnections derives the service connections observed over the
+// window from trace data, client metrics and network connections.
+func (s *Server) topologyConnections(ctx context.Context, window time.Duration) []*colonyv1.Connection {
+	since := time.Now().Add(-window)
 	serviceConns, err := s.database.GetServiceConnections(ctx, since)
 	if err != nil {
 		// Non-fatal: return agents without connections rather than failing.
@@ -305,17 +413,57 @@ func (s *Server) GetTopology(
 	type edgeKey struct{ src, dst string }
 	l7Edges := make(map[edgeKey]bool, len(serviceConns))
 
+	// Per-edge traffic over the window, for blast radius reasoning.
+	edgeStats, err := s.database.GetServiceEdgeStats(ctx, since)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to fetch service edge stats for topology")
+		edgeStats = nil
+	}
+	statsByEdge := make(map[edgeKey]*database.ServiceEdgeStats, len(edgeStats))
+	for _, es := range edgeStats {
+		statsByEdge[edgeKey{es.FromService, es.ToService}] = es
+	}
+
 	connections := make([]*colonyv1.Connection, 0, len(serviceConns))
 	for _, sc := range serviceConns {
-		connections = append(connections, &colonyv1.Connection{
+		conn := &colonyv1.Connection{
 			SourceId:       sc.FromService,
 			TargetId:       sc.ToService,
 			ConnectionType: sc.Protocol,
 			EvidenceLayer:  colonyv1.EvidenceLayer_EVIDENCE_LAYER_L7_TRACE,
-		})
+		}
+		if es := statsByEdge[edgeKey{sc.FromService, sc.ToService}]; es != nil {
+			conn.RequestCount = es.RequestCount
+			conn.RequestsPerSecond = float64(es.RequestCount) / window.Seconds()
+			conn.ErrorRate = float64(es.ErrorCount) / float64(es.RequestCount) * 100
+			conn.P95LatencyMs = es.P95Ms
+		}
+		connections = append(connections, conn)
 		l7Edges[edgeKey{sc.FromService, sc.ToService}] = true
 	}
 
+	// Add the databases and uninstrumented gRPC servers seen from clients.
+	clientDeps, err := s.database.GetClientDependencies(ctx, since)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("Failed to fetch client dependencies for topology")
+		clientDeps = nil
+	}
+	for _, cd := range clientDeps {
+		key := edgeKey{cd.FromService, cd.Target}
+		if l7Edges[key] {
+			continue
+		}
+		connections = append(connections, &colonyv1.Connection{
+			SourceId:          cd.FromService,
+			TargetId:          cd.Target,
+			ConnectionType:    cd.Protocol,
+			EvidenceLayer:     colonyv1.EvidenceLayer_EVIDENCE_LAYER_L7_CLIENT,
+			RequestCount:      cd.RequestCount,
+			RequestsPerSecond: float64(cd.RequestCount) / window.Seconds(),
+		})
+		l7Edges[key] = true
+	}
+
 	// Fetch L4 network connections and merge (RFD 033).
 	l4Conns, err := s.database.GetL4Connections(ctx, since)
 	if err != nil {
@@ -350,18 +498,7 @@ func (s *Server) GetTopology(
 		})
 	}
 
-	resp := &colonyv1.GetTopologyResponse{
-		ColonyId:    s.config.ColonyID,
-		Agents:      agents,
-		Connections: connections,
-	}
-
-	s.logger.Debug().
-		Int("agent_count", len(agents)).
-		Int("connection_count", len(connections)).
-		Msg("Get topology response prepared")
-
-	return connect.NewResponse(resp), nil
+	return connections
 }
 
 // ReportConnections receives a stream of L4 connection batches from an agent,
@@ -618,6 +755,59 @@ func (s *Server) RevokeCertificate(
 	return connect.NewResponse(resp), nil
 }
 
+// RevokeAgent revokes all active certificates of an agent, which puts them
+// on the colony's revocation list, and evicts the agent from the mesh.
+func (s *Server) RevokeAgent(
+	ctx context.Context,
+	req *connect.Request[colonyv1.RevokeAgentRequest],
+) (*connect.Response[colonyv1.RevokeAgentResponse], error) {
+	if req.Msg.AgentId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("agent_id is required"))
+	}
+	if s.caManager == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("CA is not initialized"))
+	}
+
+	reason := req.Msg.Reason
+	if reason == "" {
+		reason = "unspecified"
+	}
+
+	serials, err := s.caManager.RevokeAgent(ctx, req.Msg.AgentId, reason, "admin")
+	if err != nil {
+		s.logger.Error().
+			Err(err).
+			Str("agent_id", req.Msg.AgentId).
+			Msg("Failed to revoke agent certificates")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke agent certificates: %w", err))
+	}
+
+	evicted := false
+	if s.agentEvictor != nil {
+		_, err := s.registry.Get(req.Msg.AgentId)
+		evicted = err == nil
+		if err := s.agentEvictor(ctx, req.Msg.AgentId, fmt.Sprintf("Agent revoked: %s", reason)); err != nil {
+			s.logger.Error().
+				Err(err).
+				Str("agent_id", req.Msg.AgentId).
+				Msg("Failed to evict revoked agent")
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("certificates revoked, but failed to evict agent: %w", err))
+		}
+	}
+
+	s.logger.Info().
+		Str("agent_id", req.Msg.AgentId).
+		Str("reason", reason).
+		Int("revoked_certificates", len(serials)).
+		Bool("evicted", evicted).
+		Msg("Agent revoked")
+
+	return connect.NewResponse(&colonyv1.RevokeAgentResponse{
+		RevokedSerialNumbers: serials,
+		Evicted:              evicted,
+	}), nil
+}
+
 // GetCAStatus handles CA status requests (RFD 047).
 func (s *Server) GetCAStatus(
 	ctx context.Context,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 	"testing/synctest"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/coral-mesh/coral/internal/colony/database"
 	"github.com/coral-mesh/coral/internal/colony/registry"
 	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/protocol"
 )
 
 func newTestServer(t *testing.T, config Config) (*Server, func()) {
@@ -312,6 +314,29 @@ func TestServer_ListAgents(t *testing.T) {
 		assert.Equal(t, "degraded", agentStatuses["agent-degraded"])
 		assert.Equal(t, "unhealthy", agentStatuses["agent-unhealthy"])
 	})
+
+	t.Run("protocol version skew", func(t *testing.T) {
+		server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+		defer cleanup()
+
+		_, _ = server.registry.Register("agent-current", "frontend", "100.64.0.2", "", nil, nil, strconv.Itoa(protocol.Version))
+		_, _ = server.registry.Register("agent-legacy", "api", "100.64.0.3", "", nil, nil, "")
+
+		resp, err := server.ListAgents(context.Background(), connect.NewRequest(&colonyv1.ListAgentsRequest{}))
+		require.NoError(t, err)
+		assert.Equal(t, uint32(protocol.Version), resp.Msg.ProtocolVersion)
+
+		agents := make(map[string]*colonyv1.Agent)
+		for _, agent := range resp.Msg.Agents {
+			agents[agent.AgentId] = agent
+		}
+		assert.Equal(t, uint32(protocol.Version), agents["agent-current"].ProtocolVersion)
+		assert.Empty(t, agents["agent-current"].MissingCapabilities)
+
+		assert.Equal(t, uint32(1), agents["agent-legacy"].ProtocolVersion)
+		require.NotEmpty(t, agents["agent-legacy"].MissingCapabilities)
+		assert.Equal(t, protocol.CapabilityAgentUpdates, agents["agent-legacy"].MissingCapabilities[0].Name)
+	})
 }
 
 func TestServer_GetTopology(t *testing.T) {
@@ -413,6 +438,30 @@ func TestServer_GetTopology_WithConnections(t *testing.T) {
 	assert.NotEmpty(t, conn.ConnectionType)
 }
 
+func TestServer_GetTopology_ClientDependencies(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+
+	ctx := context.Background()
+	require.NoError(t, server.database.InsertBeylaSQLMetrics(ctx, "agent-1", []*agentv1.EbpfSqlMetric{{
+		Timestamp: time.Now().UnixMilli(), ServiceName: "orders", SqlOperation: "SELECT", TableName: "orders",
+		LatencyBuckets: []float64{5}, LatencyCounts: []uint64{36},
+		Attributes: map[string]string{"db.system": "postgresql"},
+	}}))
+
+	resp, err := server.GetTopology(ctx, connect.NewRequest(&colonyv1.GetTopologyRequest{Since: "1m"}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Connections, 1)
+
+	conn := resp.Msg.Connections[0]
+	assert.Equal(t, "orders", conn.SourceId)
+	assert.Equal(t, "postgresql", conn.TargetId)
+	assert.Equal(t, "sql", conn.ConnectionType)
+	assert.Equal(t, colonyv1.EvidenceLayer_EVIDENCE_LAYER_L7_CLIENT, conn.EvidenceLayer)
+	assert.Equal(t, int64(36), conn.RequestCount)
+	assert.InDelta(t, 0.6, conn.RequestsPerSecond, 1e-9)
+}
+
 func TestServer_determineColonyStatus(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -486,3 +535,39 @@ func TestServer_determineColonyStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_RevokeAgent(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+
+	_, err := server.database.DB().Exec(`INSERT INTO issued_certificates (serial_number, agent_id, colony_id, certificate_pem, expires_at)
+		VALUES ('101', 'agent-1', 'test-colony', '', ?)`, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	_, err = server.registry.Register("agent-1", "frontend", "100.64.0.2", "", nil, nil, "")
+	require.NoError(t, err)
+
+	var evictedMessage string
+	server.SetAgentEvictor(func(ctx context.Context, agentID, message string) error {
+		evictedMessage = message
+		_, err := server.registry.Evict(ctx, agentID, message)
+		return err
+	})
+
+	_, err = server.RevokeAgent(context.Background(), connect.NewRequest(&colonyv1.RevokeAgentRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	resp, err := server.RevokeAgent(context.Background(), connect.NewRequest(&colonyv1.RevokeAgentRequest{
+		AgentId: "agent-1",
+		Reason:  "key compromise",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"101"}, resp.Msg.RevokedSerialNumbers)
+	assert.True(t, resp.Msg.Evicted)
+	assert.Equal(t, "Agent revoked: key compromise", evictedMessage)
+
+	_, err = server.registry.Get("agent-1")
+	assert.Error(t, err)
+	revoked, err := server.caManager.IsAgentRevoked(context.Background(), "agent-1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
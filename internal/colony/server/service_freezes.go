@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/audit"
+	"github.com/coral-mesh/coral/internal/colony/database"
+)
+
+// FreezeService blocks new debug and profiling sessions against a service
+// for a duration. Freezing a frozen service replaces its freeze. Sessions
+// already running are not stopped.
+func (s *Server) FreezeService(
+	ctx context.Context,
+	req *connect.Request[colonyv1.FreezeServiceRequest],
+) (*connect.Response[colonyv1.FreezeServiceResponse], error) {
+	if req.Msg.Service == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("service is required"))
+	}
+	duration := req.Msg.GetDuration().AsDuration()
+	if duration <= 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("duration must be positive"))
+	}
+
+	now := time.Now()
+	freeze := &database.ServiceFreeze{
+		Service:   req.Msg.Service,
+		ExpiresAt: now.Add(duration),
+		Reason:    req.Msg.Reason,
+		FrozenBy:  audit.Actor(ctx, req.Peer().Addr, req.Msg.User),
+		CreatedAt: now,
+	}
+	if err := s.database.UpsertServiceFreeze(ctx, freeze); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	s.logger.Info().
+		Str("service", freeze.Service).
+		Time("expires_at", freeze.ExpiresAt).
+		Str("frozen_by", freeze.FrozenBy).
+		Str("reason", freeze.Reason).
+		Msg("Service frozen")
+
+	return connect.NewResponse(&colonyv1.FreezeServiceResponse{Freeze: serviceFreezeToProto(freeze)}), nil
+}
+
+// UnfreezeService lifts the freeze of a service before it expires.
+func (s *Server) UnfreezeService(
+	ctx context.Context,
+	req *connect.Request[colonyv1.UnfreezeServiceRequest],
+) (*connect.Response[colonyv1.UnfreezeServiceResponse], error) {
+	err := s.database.DeleteServiceFreeze(ctx, req.Msg.Service, time.Now())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("service %s is not frozen", req.Msg.Service))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	s.logger.Info().Str("service", req.Msg.Service).Msg("Service unfrozen")
+
+	return connect.NewResponse(&colonyv1.UnfreezeServiceResponse{}), nil
+}
+
+// ListServiceFreezes returns the freezes in effect.
+func (s *Server) ListServiceFreezes(
+	ctx context.Context,
+	req *connect.Request[colonyv1.ListServiceFreezesRequest],
+) (*connect.Response[colonyv1.ListServiceFreezesResponse], error) {
+	freezes, err := s.database.ListServiceFreezes(ctx, time.Now())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &colonyv1.ListServiceFreezesResponse{}
+	for _, f := range freezes {
+		resp.Freezes = append(resp.Freezes, serviceFreezeToProto(f))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func serviceFreezeToProto(f *database.ServiceFreeze) *colonyv1.ServiceFreeze {
+	return &colonyv1.ServiceFreeze{
+		Service:   f.Service,
+		ExpiresAt: timestamppb.New(f.ExpiresAt),
+		Reason:    f.Reason,
+		FrozenBy:  f.FrozenBy,
+		CreatedAt: timestamppb.New(f.CreatedAt),
+	}
+}
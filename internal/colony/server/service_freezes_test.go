@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestServer_ServiceFreezes(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+	ctx := context.Background()
+
+	resp, err := server.FreezeService(ctx, connect.NewRequest(&colonyv1.FreezeServiceRequest{
+		Service:  "api",
+		Duration: durationpb.New(2 * time.Hour),
+		Reason:   "load test",
+		User:     "alice",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", resp.Msg.Freeze.FrozenBy)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), resp.Msg.Freeze.ExpiresAt.AsTime(), time.Minute)
+
+	_, err = server.FreezeService(ctx, connect.NewRequest(&colonyv1.FreezeServiceRequest{Service: "api"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "a duration is required")
+
+	list, err := server.ListServiceFreezes(ctx, connect.NewRequest(&colonyv1.ListServiceFreezesRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.Freezes, 1)
+	assert.Equal(t, "load test", list.Msg.Freezes[0].Reason)
+
+	_, err = server.UnfreezeService(ctx, connect.NewRequest(&colonyv1.UnfreezeServiceRequest{Service: "api"}))
+	require.NoError(t, err)
+	_, err = server.UnfreezeService(ctx, connect.NewRequest(&colonyv1.UnfreezeServiceRequest{Service: "api"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
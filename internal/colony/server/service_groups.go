@@ -0,0 +1,209 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+)
+
+// serviceGroupName is the format of service group names, e.g. "checkout-path".
+var serviceGroupName = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+
+// ServiceGroup is a named set of services defined in the colony config
+// (service_groups).
+type ServiceGroup struct {
+	Name        string
+	Services    []string
+	Description string
+}
+
+// SetServiceGroups sets the service groups of the colony config. They take
+// precedence over groups of the same name created through the API and
+// cannot be changed through it.
+func (s *Server) SetServiceGroups(groups []ServiceGroup) {
+	s.serviceGroups = groups
+}
+
+// configServiceGroup returns the config group named name, or nil.
+func (s *Server) configServiceGroup(name string) *ServiceGroup {
+	for i := range s.serviceGroups {
+		if s.serviceGroups[i].Name == name {
+			return &s.serviceGroups[i]
+		}
+	}
+	return nil
+}
+
+// serviceGroup returns the group named name from the colony config or the
+// database, or a NotFound error.
+func (s *Server) serviceGroup(ctx context.Context, name string) (*colonyv1.ServiceGroup, error) {
+	if group := s.configServiceGroup(name); group != nil {
+		return configServiceGroupToProto(group), nil
+	}
+
+	group, err := s.database.GetServiceGroup(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("service group not found: %s", name))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get service group: %w", err))
+	}
+	return serviceGroupToProto(group)
+}
+
+// groupServices returns the services of the group named name, or nil when
+// name is empty and queries are not restricted to a group.
+func (s *Server) groupServices(ctx context.Context, name string) (map[string]bool, error) {
+	if name == "" {
+		return nil, nil
+	}
+	group, err := s.serviceGroup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	services := make(map[string]bool, len(group.Services))
+	for _, service := range group.Services {
+		services[service] = true
+	}
+	return services, nil
+}
+
+// ListServiceGroups returns the groups of the colony config and those
+// created through the API, ordered by name.
+func (s *Server) ListServiceGroups(
+	ctx context.Context,
+	req *connect.Request[colonyv1.ListServiceGroupsRequest],
+) (*connect.Response[colonyv1.ListServiceGroupsResponse], error) {
+	stored, err := s.database.ListServiceGroups(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list service groups: %w", err))
+	}
+
+	resp := &colonyv1.ListServiceGroupsResponse{}
+	for i := range s.serviceGroups {
+		resp.Groups = append(resp.Groups, configServiceGroupToProto(&s.serviceGroups[i]))
+	}
+	for _, group := range stored {
+		if s.configServiceGroup(group.Name) != nil {
+			continue
+		}
+		pb, err := serviceGroupToProto(group)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		resp.Groups = append(resp.Groups, pb)
+	}
+	sort.Slice(resp.Groups, func(i, j int) bool { return resp.Groups[i].Name < resp.Groups[j].Name })
+
+	return connect.NewResponse(resp), nil
+}
+
+// GetServiceGroup returns one service group.
+func (s *Server) GetServiceGroup(
+	ctx context.Context,
+	req *connect.Request[colonyv1.GetServiceGroupRequest],
+) (*connect.Response[colonyv1.GetServiceGroupResponse], error) {
+	group, err := s.serviceGroup(ctx, req.Msg.Name)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&colonyv1.GetServiceGroupResponse{Group: group}), nil
+}
+
+// SetServiceGroup creates a service group or replaces the services and
+// description of an existing one.
+func (s *Server) SetServiceGroup(
+	ctx context.Context,
+	req *connect.Request[colonyv1.SetServiceGroupRequest],
+) (*connect.Response[colonyv1.SetServiceGroupResponse], error) {
+	if !serviceGroupName.MatchString(req.Msg.Name) {
+		return nil, connect.NewError(connect.CodeInvalidArgument,
+			fmt.Errorf("invalid service group name %q: use lowercase letters, digits, '.', '_' and '-'", req.Msg.Name))
+	}
+	if s.configServiceGroup(req.Msg.Name) != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("service group %s is defined in the colony config", req.Msg.Name))
+	}
+
+	var services []string
+	for _, service := range req.Msg.Services {
+		if service == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("service names must not be empty"))
+		}
+		if !slices.Contains(services, service) {
+			services = append(services, service)
+		}
+	}
+	if len(services) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at least one service is required"))
+	}
+
+	group, err := s.database.UpsertServiceGroup(ctx, req.Msg.Name, services, req.Msg.Description)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	s.logger.Info().
+		Str("group", group.Name).
+		Strs("services", services).
+		Msg("Service group set")
+
+	pb, err := serviceGroupToProto(group)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&colonyv1.SetServiceGroupResponse{Group: pb}), nil
+}
+
+// DeleteServiceGroup removes a service group created through the API.
+func (s *Server) DeleteServiceGroup(
+	ctx context.Context,
+	req *connect.Request[colonyv1.DeleteServiceGroupRequest],
+) (*connect.Response[colonyv1.DeleteServiceGroupResponse], error) {
+	if s.configServiceGroup(req.Msg.Name) != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition,
+			fmt.Errorf("service group %s is defined in the colony config", req.Msg.Name))
+	}
+
+	if err := s.database.DeleteServiceGroup(ctx, req.Msg.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("service group not found: %s", req.Msg.Name))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	s.logger.Info().Str("group", req.Msg.Name).Msg("Service group deleted")
+	return connect.NewResponse(&colonyv1.DeleteServiceGroupResponse{}), nil
+}
+
+func configServiceGroupToProto(group *ServiceGroup) *colonyv1.ServiceGroup {
+	return &colonyv1.ServiceGroup{
+		Name:        group.Name,
+		Services:    group.Services,
+		Description: group.Description,
+		FromConfig:  true,
+	}
+}
+
+func serviceGroupToProto(group *database.ServiceGroup) (*colonyv1.ServiceGroup, error) {
+	services, err := group.ServiceNames()
+	if err != nil {
+		return nil, err
+	}
+	return &colonyv1.ServiceGroup{
+		Name:        group.Name,
+		Services:    services,
+		Description: group.Description,
+		UpdatedAt:   timestamppb.New(group.UpdatedAt),
+	}, nil
+}
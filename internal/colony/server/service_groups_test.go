@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony"
+)
+
+func TestServer_ServiceGroups(t *testing.T) {
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+	ctx := context.Background()
+
+	server.SetServiceGroups([]ServiceGroup{{Name: "checkout-path", Services: []string{"api", "payments"}}})
+
+	t.Run("set", func(t *testing.T) {
+		resp, err := server.SetServiceGroup(ctx, connect.NewRequest(&colonyv1.SetServiceGroupRequest{
+			Name:        "backoffice",
+			Services:    []string{"admin", "reports", "admin"},
+			Description: "Internal tools",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin", "reports"}, resp.Msg.Group.Services, "duplicates are dropped")
+		assert.False(t, resp.Msg.Group.FromConfig)
+
+		_, err = server.SetServiceGroup(ctx, connect.NewRequest(&colonyv1.SetServiceGroupRequest{
+			Name:     "checkout-path",
+			Services: []string{"api"},
+		}))
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err), "config groups cannot be changed")
+
+		_, err = server.SetServiceGroup(ctx, connect.NewRequest(&colonyv1.SetServiceGroupRequest{
+			Name:     "Checkout Path",
+			Services: []string{"api"},
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+		_, err = server.SetServiceGroup(ctx, connect.NewRequest(&colonyv1.SetServiceGroupRequest{Name: "empty"}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	})
+
+	t.Run("list and get", func(t *testing.T) {
+		resp, err := server.ListServiceGroups(ctx, connect.NewRequest(&colonyv1.ListServiceGroupsRequest{}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Groups, 2)
+		assert.Equal(t, "backoffice", resp.Msg.Groups[0].Name)
+		assert.Equal(t, "checkout-path", resp.Msg.Groups[1].Name)
+		assert.True(t, resp.Msg.Groups[1].FromConfig)
+
+		got, err := server.GetServiceGroup(ctx, connect.NewRequest(&colonyv1.GetServiceGroupRequest{Name: "backoffice"}))
+		require.NoError(t, err)
+		assert.Equal(t, "Internal tools", got.Msg.Group.Description)
+
+		_, err = server.GetServiceGroup(ctx, connect.NewRequest(&colonyv1.GetServiceGroupRequest{Name: "unknown"}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := server.DeleteServiceGroup(ctx, connect.NewRequest(&colonyv1.DeleteServiceGroupRequest{Name: "checkout-path"}))
+		assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+		_, err = server.DeleteServiceGroup(ctx, connect.NewRequest(&colonyv1.DeleteServiceGroupRequest{Name: "backoffice"}))
+		require.NoError(t, err)
+
+		_, err = server.DeleteServiceGroup(ctx, connect.NewRequest(&colonyv1.DeleteServiceGroupRequest{Name: "backoffice"}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
+
+func TestQueryHandlers_Group(t *testing.T) {
+	ctx := context.Background()
+	mockSvc := &mockEbpfService{
+		summaryResults: []colony.UnifiedSummaryResult{
+			{ServiceName: "api", Status: colony.ServiceStatusHealthy},
+			{ServiceName: "worker", Status: colony.ServiceStatusHealthy},
+		},
+		traceSpans: []*agentv1.EbpfTraceSpan{
+			{TraceId: "trace-1", ServiceName: "api"},
+			{TraceId: "trace-1", ServiceName: "payments"},
+			{TraceId: "trace-2", ServiceName: "worker"},
+		},
+		metricsResponse: &agentv1.QueryEbpfMetricsResponse{
+			HttpMetrics: []*agentv1.EbpfHttpMetric{{ServiceName: "api"}, {ServiceName: "worker"}},
+			SqlMetrics:  []*agentv1.EbpfSqlMetric{{ServiceName: "worker"}},
+		},
+	}
+	server, cleanup := newTestServer(t, Config{ColonyID: "test-colony"})
+	defer cleanup()
+	server.ebpfService = mockSvc
+	server.registry = newLabeledRegistry(t)
+	server.SetServiceGroups([]ServiceGroup{{Name: "checkout-path", Services: []string{"api", "payments"}}})
+
+	t.Run("summary", func(t *testing.T) {
+		resp, err := server.QueryUnifiedSummary(ctx, connect.NewRequest(&colonyv1.QueryUnifiedSummaryRequest{
+			Group: "checkout-path",
+		}))
+		require.NoError(t, err)
+		require.Len(t, resp.Msg.Summaries, 1)
+		assert.Equal(t, "api", resp.Msg.Summaries[0].ServiceName)
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		resp, err := server.QueryUnifiedTraces(ctx, connect.NewRequest(&colonyv1.QueryUnifiedTracesRequest{
+			Group: "checkout-path",
+		}))
+		require.NoError(t, err)
+		assert.Len(t, resp.Msg.Spans, 2)
+		assert.Equal(t, int32(1), resp.Msg.TotalTraces)
+	})
+
+	t.Run("metrics with selector", func(t *testing.T) {
+		resp, err := server.QueryUnifiedMetrics(ctx, connect.NewRequest(&colonyv1.QueryUnifiedMetricsRequest{
+			Group:    "checkout-path",
+			Selector: "env=staging",
+		}))
+		require.NoError(t, err)
+		assert.Zero(t, resp.Msg.TotalMetrics, "services must match the selector and be in the group")
+	})
+
+	t.Run("unknown group", func(t *testing.T) {
+		_, err := server.QueryUnifiedTraces(ctx, connect.NewRequest(&colonyv1.QueryUnifiedTracesRequest{
+			Group: "checkout",
+		}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
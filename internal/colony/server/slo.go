@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+)
+
+// SLO objectives.
+const (
+	sloObjectiveAvailability = "availability"
+	sloObjectiveLatency      = "latency"
+)
+
+// SLO statuses, from least to most severe.
+const (
+	sloStatusOK        = "ok"
+	sloStatusSlowBurn  = "slow_burn"
+	sloStatusFastBurn  = "fast_burn"
+	sloStatusExhausted = "exhausted"
+)
+
+// defaultSLOPeriod is the compliance period of SLOs that do not set one.
+const defaultSLOPeriod = 30 * 24 * time.Hour
+
+// sloBurnWindows are the windows burn rates are reported over.
+var sloBurnWindows = []time.Duration{
+	5 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	2 * time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+	72 * time.Hour,
+}
+
+// sloBurnAlerts are the multiwindow burn rate alerts of the Google SRE
+// workbook. An SLO is burning when both windows burn at the rate that spends
+// budget of the error budget over the long window; the short window makes
+// the alert clear soon after the burn stops. Fast burns are checked first.
+var sloBurnAlerts = []struct {
+	status      string
+	long, short time.Duration
+	budget      float64
+}{
+	{sloStatusFastBurn, time.Hour, 5 * time.Minute, 0.02},
+	{sloStatusFastBurn, 6 * time.Hour, 30 * time.Minute, 0.05},
+	{sloStatusSlowBurn, 24 * time.Hour, 2 * time.Hour, 0.10},
+	{sloStatusSlowBurn, 72 * time.Hour, 6 * time.Hour, 0.10},
+}
+
+// SLO is the service level objectives of a service (slos in the colony
+// config). A zero target disables its objective.
+type SLO struct {
+	Service          string
+	Availability     float64
+	Latency          float64
+	LatencyThreshold time.Duration
+	Period           time.Duration
+}
+
+// SetSLOs sets the service level objectives reported by QuerySLO.
+func (s *Server) SetSLOs(slos []SLO) {
+	s.slos = slos
+}
+
+// QuerySLO reports the compliance, error budget and burn rates of the
+// configured SLOs.
+func (s *Server) QuerySLO(
+	ctx context.Context,
+	req *connect.Request[colonyv1.QuerySLORequest],
+) (*connect.Response[colonyv1.QuerySLOResponse], error) {
+	var slos []SLO
+	for _, slo := range s.slos {
+		if req.Msg.Service == "" || slo.Service == req.Msg.Service {
+			slos = append(slos, slo)
+		}
+	}
+	if req.Msg.Service != "" && len(slos) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no SLO defined for service %s", req.Msg.Service))
+	}
+
+	now := time.Now()
+	resp := &colonyv1.QuerySLOResponse{}
+	for _, slo := range slos {
+		period := slo.Period
+		if period <= 0 {
+			period = defaultSLOPeriod
+		}
+		thresholdMs := float64(slo.LatencyThreshold) / float64(time.Millisecond)
+
+		counts := make(map[time.Duration]*database.SLOCounts, len(sloBurnWindows)+1)
+		for _, window := range append([]time.Duration{period}, sloBurnWindows...) {
+			if counts[window] != nil {
+				continue
+			}
+			c, err := s.database.QuerySLOCounts(ctx, slo.Service, now.Add(-window), now, thresholdMs)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			counts[window] = c
+		}
+
+		if slo.Availability > 0 {
+			status := evaluateSLO(slo.Availability, period, counts, func(c *database.SLOCounts) int64 { return c.Failed })
+			status.Service = slo.Service
+			status.Objective = sloObjectiveAvailability
+			resp.Slos = append(resp.Slos, status)
+		}
+		if slo.Latency > 0 {
+			status := evaluateSLO(slo.Latency, period, counts, func(c *database.SLOCounts) int64 { return c.Slow })
+			status.Service = slo.Service
+			status.Objective = sloObjectiveLatency
+			status.LatencyThresholdMs = thresholdMs
+			resp.Slos = append(resp.Slos, status)
+		}
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// evaluateSLO computes the compliance, error budget, burn rates and status of
+// an objective of target percent over period, from the request counts of the
+// period and of each burn rate window. bad returns the bad requests of a
+// count.
+func evaluateSLO(
+	target float64,
+	period time.Duration,
+	counts map[time.Duration]*database.SLOCounts,
+	bad func(*database.SLOCounts) int64,
+) *colonyv1.SLOStatus {
+	budget := (100 - target) / 100
+	burnRate := func(c *database.SLOCounts) float64 {
+		if c.Total == 0 {
+			return 0
+		}
+		return float64(bad(c)) / float64(c.Total) / budget
+	}
+
+	periodCounts := counts[period]
+	status := &colonyv1.SLOStatus{
+		Target:               target,
+		Period:               durationpb.New(period),
+		Compliance:           100,
+		ErrorBudgetRemaining: (1 - burnRate(periodCounts)) * 100,
+		Status:               sloStatusOK,
+	}
+	if periodCounts.Total > 0 {
+		status.Compliance = (1 - float64(bad(periodCounts))/float64(periodCounts.Total)) * 100
+	}
+
+	rates := make(map[time.Duration]float64, len(sloBurnWindows))
+	for _, window := range sloBurnWindows {
+		c := counts[window]
+		rates[window] = burnRate(c)
+		status.BurnRates = append(status.BurnRates, &colonyv1.SLOBurnRate{
+			Window:        durationpb.New(window),
+			TotalRequests: c.Total,
+			BadRequests:   bad(c),
+			BurnRate:      rates[window],
+		})
+	}
+
+	if periodCounts.Total > 0 && status.ErrorBudgetRemaining <= 0 {
+		status.Status = sloStatusExhausted
+		return status
+	}
+	for _, alert := range sloBurnAlerts {
+		threshold := alert.budget * float64(period) / float64(alert.long)
+		if rates[alert.long] >= threshold && rates[alert.short] >= threshold {
+			status.Status = alert.status
+			break
+		}
+	}
+	return status
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_QuerySLO(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{database: db, logger: zerolog.Nop()}
+	s.SetSLOs([]SLO{
+		{Service: "api", Availability: 99.9, Latency: 90, LatencyThreshold: 100 * time.Millisecond},
+		{Service: "idle", Availability: 99},
+	})
+	ctx := context.Background()
+
+	_, err = s.QuerySLO(ctx, connect.NewRequest(&colonyv1.QuerySLORequest{Service: "web"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// Plenty of good requests ten days ago, then 2% of failed requests in
+	// the last minutes: a fast burn of a budget that is far from spent.
+	now := time.Now()
+	for _, m := range []struct {
+		at     time.Time
+		status int
+		bucket float64
+		count  int
+	}{
+		{now.Add(-10 * 24 * time.Hour), 200, 50, 1_000_000},
+		{now.Add(-2 * time.Minute), 200, 50, 980},
+		{now.Add(-2 * time.Minute), 503, 500, 20},
+	} {
+		_, err := db.DB().ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', 'api', 'GET', '/orders', ?, ?, ?)
+		`, m.at, m.status, m.bucket, m.count)
+		require.NoError(t, err)
+	}
+
+	resp, err := s.QuerySLO(ctx, connect.NewRequest(&colonyv1.QuerySLORequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Slos, 3)
+
+	availability := resp.Msg.Slos[0]
+	assert.Equal(t, "api", availability.Service)
+	assert.Equal(t, sloObjectiveAvailability, availability.Objective)
+	assert.Equal(t, defaultSLOPeriod, availability.Period.AsDuration())
+	assert.InDelta(t, 100*(1-20.0/1_001_000), availability.Compliance, 1e-9)
+	assert.InDelta(t, 100*(1-20.0/1_001_000/0.001), availability.ErrorBudgetRemaining, 1e-6)
+	require.Len(t, availability.BurnRates, len(sloBurnWindows))
+	fiveMinutes := availability.BurnRates[0]
+	assert.Equal(t, 5*time.Minute, fiveMinutes.Window.AsDuration())
+	assert.Equal(t, int64(1000), fiveMinutes.TotalRequests)
+	assert.Equal(t, int64(20), fiveMinutes.BadRequests)
+	assert.InDelta(t, 20, fiveMinutes.BurnRate, 1e-9)
+	assert.Equal(t, sloStatusFastBurn, availability.Status)
+
+	latency := resp.Msg.Slos[1]
+	assert.Equal(t, sloObjectiveLatency, latency.Objective)
+	assert.Equal(t, 100.0, latency.LatencyThresholdMs)
+	assert.InDelta(t, 0.2, latency.BurnRates[0].BurnRate, 1e-9)
+	assert.Equal(t, sloStatusOK, latency.Status)
+
+	idle := resp.Msg.Slos[2]
+	assert.Equal(t, "idle", idle.Service)
+	assert.Equal(t, 100.0, idle.Compliance)
+	assert.Equal(t, 100.0, idle.ErrorBudgetRemaining)
+	assert.Equal(t, sloStatusOK, idle.Status)
+}
+
+func TestEvaluateSLO(t *testing.T) {
+	period := 7 * 24 * time.Hour
+	counts := func(overPeriod, longWindow, shortWindow database.SLOCounts) map[time.Duration]*database.SLOCounts {
+		m := make(map[time.Duration]*database.SLOCounts)
+		for _, w := range sloBurnWindows {
+			c := longWindow
+			if w < 2*time.Hour {
+				c = shortWindow
+			}
+			m[w] = &c
+		}
+		m[period] = &overPeriod
+		return m
+	}
+	failed := func(c *database.SLOCounts) int64 { return c.Failed }
+
+	// Over 7 days, a slow burn spends 10% of the budget in a day: a burn
+	// rate of 0.7.
+	status := evaluateSLO(99, period, counts(
+		database.SLOCounts{Total: 1000, Failed: 1},
+		database.SLOCounts{Total: 1000, Failed: 8},
+		database.SLOCounts{Total: 100, Failed: 0},
+	), failed)
+	assert.Equal(t, sloStatusSlowBurn, status.Status)
+
+	status = evaluateSLO(99, period, counts(
+		database.SLOCounts{Total: 1000, Failed: 10},
+		database.SLOCounts{Total: 1000, Failed: 8},
+		database.SLOCounts{Total: 100, Failed: 0},
+	), failed)
+	assert.Equal(t, sloStatusExhausted, status.Status)
+	assert.InDelta(t, 0, status.ErrorBudgetRemaining, 1e-9)
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// QuerySQL runs a read-only SELECT on the colony database with a row limit and
+// a statement timeout.
+func (s *Server) QuerySQL(
+	ctx context.Context,
+	req *connect.Request[colonyv1.QuerySQLRequest],
+) (*connect.Response[colonyv1.QuerySQLResponse], error) {
+	if strings.TrimSpace(req.Msg.Sql) == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("sql is required"))
+	}
+
+	maxRows := int(req.Msg.MaxRows)
+	if maxRows <= 0 {
+		maxRows = constants.DefaultSQLQueryMaxRows
+	}
+	maxRows = min(maxRows, constants.MaxSQLQueryRows)
+
+	timeout := time.Duration(req.Msg.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = constants.DefaultSQLQueryTimeout
+	}
+	timeout = min(timeout, constants.MaxSQLQueryTimeout)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	db := s.database.DB()
+	if err := checkReadOnlySQL(ctx, db, req.Msg.Sql); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, req.Msg.Sql)
+	if err != nil {
+		return nil, sqlQueryError(ctx, timeout, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get columns: %w", err))
+	}
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get column types: %w", err))
+	}
+
+	resp := &colonyv1.QuerySQLResponse{
+		Columns:     columns,
+		ColumnTypes: make([]string, len(columnTypes)),
+	}
+	for i, ct := range columnTypes {
+		resp.ColumnTypes[i] = ct.DatabaseTypeName()
+	}
+
+	values := make([]interface{}, len(columns))
+	valuePtrs := make([]interface{}, len(columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if len(resp.Rows) == maxRows {
+			resp.Truncated = true
+			break
+		}
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to scan row: %w", err))
+		}
+
+		row := &colonyv1.QueryRow{Values: make([]string, len(columns))}
+		for i, val := range values {
+			if val != nil {
+				row.Values[i] = fmt.Sprintf("%v", val)
+			}
+		}
+		resp.Rows = append(resp.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, sqlQueryError(ctx, timeout, err)
+	}
+
+	resp.RowCount = int32(len(resp.Rows))
+	resp.DurationMs = time.Since(start).Milliseconds()
+	return connect.NewResponse(resp), nil
+}
+
+// sqlQueryError maps a query error to a connect error, reporting timeouts as
+// such rather than as DuckDB interrupts.
+func sqlQueryError(ctx context.Context, timeout time.Duration, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return connect.NewError(connect.CodeDeadlineExceeded, fmt.Errorf("query exceeded timeout of %s", timeout))
+	}
+	return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to execute query: %w", err))
+}
+
+// plainTableName matches the names of tables in the colony database. Other
+// names in FROM are resolved by DuckDB replacement scans, e.g. as file paths.
+var plainTableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// deniedFunctionPrefixes and deniedFunctions are the functions that access
+// files, the network, the environment or run SQL strings, none of which are
+// allowed in read-only queries.
+var (
+	deniedFunctionPrefixes = []string{
+		"read_", "parquet_", "iceberg_", "delta_", "sqlite_", "postgres_", "mysql_", "json_execute",
+	}
+	deniedFunctions = map[string]bool{
+		"glob":           true,
+		"getenv":         true,
+		"query":          true,
+		"query_table":    true,
+		"sniff_csv":      true,
+		"csv_scan":       true,
+		"parquet_scan":   true,
+		"duckdb_secrets": true,
+		"which_secret":   true,
+	}
+)
+
+// checkReadOnlySQL parses query with DuckDB and checks that it is a single
+// SELECT statement reading only colony tables.
+func checkReadOnlySQL(ctx context.Context, db *sql.DB, query string) error {
+	var serialized string
+	if err := db.QueryRowContext(ctx, "SELECT json_serialize_sql(?::VARCHAR)::VARCHAR", query).Scan(&serialized); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to parse query: %w", err))
+	}
+
+	var parsed struct {
+		Error        bool              `json:"error"`
+		ErrorType    string            `json:"error_type"`
+		ErrorMessage string            `json:"error_message"`
+		Statements   []json.RawMessage `json:"statements"`
+	}
+	if err := json.Unmarshal([]byte(serialized), &parsed); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to parse query: %w", err))
+	}
+	if parsed.Error {
+		// Non-SELECT statements fail to serialize as "not implemented".
+		if parsed.ErrorType == "not implemented" {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("only SELECT statements are allowed"))
+		}
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid query: %s", parsed.ErrorMessage))
+	}
+	if len(parsed.Statements) != 1 {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("exactly one statement is allowed, got %d", len(parsed.Statements)))
+	}
+
+	var tree interface{}
+	if err := json.Unmarshal(parsed.Statements[0], &tree); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to parse query: %w", err))
+	}
+	if err := checkQueryNode(tree); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return nil
+}
+
+// checkQueryNode walks the serialized syntax tree and rejects denied
+// functions and FROM clauses that are not plain table names.
+func checkQueryNode(node interface{}) error {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if name, ok := n["function_name"].(string); ok {
+			name = strings.ToLower(name)
+			if deniedFunctions[name] || hasAnyPrefix(name, deniedFunctionPrefixes) {
+				return fmt.Errorf("function %s is not allowed", name)
+			}
+		}
+		if n["type"] == "BASE_TABLE" {
+			if name, _ := n["table_name"].(string); !plainTableName.MatchString(name) {
+				return fmt.Errorf("table %q is not allowed", name)
+			}
+		}
+		for _, child := range n {
+			if err := checkQueryNode(child); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range n {
+			if err := checkQueryNode(child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+)
+
+func TestQuerySQL(t *testing.T) {
+	server, _ := setupTestServerWithMetrics(t)
+	ctx := context.Background()
+
+	t.Run("runs SELECT with column types", func(t *testing.T) {
+		resp, err := server.QuerySQL(ctx, connect.NewRequest(&colonyv1.QuerySQLRequest{
+			Sql: `WITH s AS (SELECT service_name, sum(count) AS total FROM beyla_http_metrics GROUP BY 1)
+				SELECT service_name, total, NULL AS note FROM s ORDER BY service_name`,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"service_name", "total", "note"}, resp.Msg.Columns)
+		assert.Equal(t, "VARCHAR", resp.Msg.ColumnTypes[0])
+		require.Equal(t, int32(2), resp.Msg.RowCount)
+		assert.Equal(t, []string{"api-service", "105", ""}, resp.Msg.Rows[0].Values)
+		assert.False(t, resp.Msg.Truncated)
+	})
+
+	t.Run("truncates at max rows", func(t *testing.T) {
+		resp, err := server.QuerySQL(ctx, connect.NewRequest(&colonyv1.QuerySQLRequest{
+			Sql:     "SELECT * FROM beyla_http_metrics",
+			MaxRows: 3,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), resp.Msg.RowCount)
+		assert.True(t, resp.Msg.Truncated)
+	})
+
+	t.Run("enforces timeout", func(t *testing.T) {
+		_, err := server.QuerySQL(ctx, connect.NewRequest(&colonyv1.QuerySQLRequest{
+			Sql:       "SELECT count(*) FROM range(1000000000000) a",
+			TimeoutMs: 100,
+		}))
+		require.Error(t, err)
+		assert.Equal(t, connect.CodeDeadlineExceeded, connect.CodeOf(err))
+
+		// The database remains usable.
+		_, err = server.QuerySQL(ctx, connect.NewRequest(&colonyv1.QuerySQLRequest{Sql: "SELECT 1"}))
+		assert.NoError(t, err)
+	})
+
+	rejected := map[string]string{
+		"delete":              "DELETE FROM beyla_http_metrics",
+		"create":              "CREATE TABLE x AS SELECT 1",
+		"attach":              "ATTACH '/tmp/x.duckdb' AS x",
+		"copy":                "COPY beyla_http_metrics TO '/tmp/out.csv'",
+		"pragma":              "PRAGMA database_list",
+		"multiple statements": "SELECT 1; DROP TABLE beyla_http_metrics",
+		"file table function": "SELECT * FROM read_csv('/etc/passwd')",
+		"file replacement":    "SELECT * FROM '/etc/hosts'",
+		"nested file read":    "SELECT (SELECT count(*) FROM read_text('/etc/hosts'))",
+		"environment":         "SELECT getenv('HOME')",
+		"dynamic sql":         "SELECT * FROM query('DELETE FROM beyla_http_metrics')",
+		"syntax error":        "SELEC 1",
+		"empty":               "  ",
+	}
+	for name, sql := range rejected {
+		t.Run("rejects "+name, func(t *testing.T) {
+			_, err := server.QuerySQL(ctx, connect.NewRequest(&colonyv1.QuerySQLRequest{Sql: sql}))
+			require.Error(t, err)
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), err.Error())
+		})
+	}
+
+	// Nothing was modified by the rejected statements.
+	resp, err := server.QuerySQL(ctx, connect.NewRequest(&colonyv1.QuerySQLRequest{
+		Sql: "SELECT count(*) FROM beyla_http_metrics",
+	}))
+	require.NoError(t, err)
+	assert.NotEqual(t, "0", resp.Msg.Rows[0].Values[0])
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"connectrpc.com/connect"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// Floors of the baseline standard deviations, so that small changes of a
+// flat baseline are not flagged as anomalies.
+const (
+	minStddevRPS       = 0.1 // Requests per second.
+	minStddevLatencyMs = 1.0
+	minStddevErrorRate = 0.5 // Percentage points.
+)
+
+// summaryBaselineOptions returns the baseline window and anomaly threshold of
+// a summary request.
+func summaryBaselineOptions(req *colonyv1.QueryUnifiedSummaryRequest, window time.Duration) (time.Duration, float64, error) {
+	baselineWindow := constants.DefaultBaselineWindow
+	if req.BaselineWindow != "" {
+		d, err := time.ParseDuration(req.BaselineWindow)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid baseline_window: %w", err)
+		}
+		baselineWindow = d
+	}
+	if baselineWindow < window {
+		return 0, 0, fmt.Errorf("baseline_window %s is shorter than time_range %s", baselineWindow, window)
+	}
+	if baselineWindow/window > constants.MaxBaselineIntervals {
+		return 0, 0, fmt.Errorf("baseline_window %s spans more than %d intervals of %s", baselineWindow, constants.MaxBaselineIntervals, window)
+	}
+
+	sigma := req.AnomalySigma
+	if sigma < 0 {
+		return 0, 0, fmt.Errorf("anomaly_sigma must not be negative")
+	}
+	if sigma == 0 {
+		sigma = constants.DefaultAnomalySigma
+	}
+	return baselineWindow, sigma, nil
+}
+
+// addSummaryBaselines compares the request metrics of each summarized service
+// between start and end with the intervals of the same length preceding it.
+func (s *Server) addSummaryBaselines(
+	ctx context.Context,
+	req *colonyv1.QueryUnifiedSummaryRequest,
+	start, end time.Time,
+	summaries []*colonyv1.UnifiedSummaryResult,
+) error {
+	window := end.Sub(start)
+	baselineWindow, sigma, err := summaryBaselineOptions(req, window)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if s.database == nil {
+		return connect.NewError(connect.CodeUnavailable, fmt.Errorf("database not available"))
+	}
+
+	current, err := s.database.QueryServiceMetricSeries(ctx, req.Service, start, end, window)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query service metrics: %w", err))
+	}
+	baselineStart := start.Add(-window * (baselineWindow / window))
+	baseline, err := s.database.QueryServiceMetricSeries(ctx, req.Service, baselineStart, start, window)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query service baseline: %w", err))
+	}
+
+	for _, summary := range summaries {
+		var now database.ServiceWindowStats
+		if stats := current[summary.ServiceName]; len(stats) > 0 {
+			now = stats[0]
+		}
+		summary.Baseline = serviceBaseline(now, baseline[summary.ServiceName], int(baselineWindow/window), window, sigma)
+	}
+	return nil
+}
+
+// serviceBaseline compares the current stats of a service with its baseline
+// intervals. Latency and error rate are compared over the intervals with
+// requests only, and are not flagged while the service has no requests.
+func serviceBaseline(current database.ServiceWindowStats, intervals []database.ServiceWindowStats, n int, window time.Duration, sigma float64) *colonyv1.ServiceBaseline {
+	seconds := window.Seconds()
+	rps := make([]float64, n) // Intervals without data had no requests.
+	var latencies, errorRates []float64
+	for i, stats := range intervals {
+		rps[i] = float64(stats.Requests) / seconds
+		if stats.Requests > 0 {
+			latencies = append(latencies, stats.P95Ms)
+			errorRates = append(errorRates, stats.ErrorRate())
+		}
+	}
+
+	result := &colonyv1.ServiceBaseline{
+		RequestsPerSecond: metricBaseline(float64(current.Requests)/seconds, rps, minStddevRPS, sigma),
+		P95LatencyMs:      metricBaseline(current.P95Ms, latencies, minStddevLatencyMs, sigma),
+		ErrorRate:         metricBaseline(current.ErrorRate(), errorRates, minStddevErrorRate, sigma),
+		Intervals:         int32(n), // #nosec G115 -- bounded by MaxBaselineIntervals.
+	}
+	if current.Requests == 0 {
+		result.P95LatencyMs.Anomalous = false
+		result.ErrorRate.Anomalous = false
+	}
+	return result
+}
+
+// metricBaseline compares current with the mean of values, in standard
+// deviations floored at minStddev.
+func metricBaseline(current float64, values []float64, minStddev, sigma float64) *colonyv1.MetricBaseline {
+	m := &colonyv1.MetricBaseline{Current: current}
+	if len(values) == 0 {
+		return m
+	}
+
+	for _, v := range values {
+		m.Mean += v
+	}
+	m.Mean /= float64(len(values))
+	for _, v := range values {
+		m.Stddev += (v - m.Mean) * (v - m.Mean)
+	}
+	m.Stddev = math.Sqrt(m.Stddev / float64(len(values)))
+
+	m.Sigma = (current - m.Mean) / math.Max(m.Stddev, minStddev)
+	m.Anomalous = len(values) >= constants.MinBaselineIntervals && math.Abs(m.Sigma) > sigma
+	return m
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
+	"github.com/coral-mesh/coral/internal/colony"
+	"github.com/coral-mesh/coral/internal/colony/database"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+func TestServer_QueryUnifiedSummary_Baseline(t *testing.T) {
+	db, err := database.New(t.TempDir(), "test-colony", constants.DefaultConnectionsCacheTTL, zerolog.Nop())
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	s := &Server{
+		database: db,
+		logger:   zerolog.Nop(),
+		ebpfService: &mockEbpfService{summaryResults: []colony.UnifiedSummaryResult{
+			{ServiceName: "api", Status: colony.ServiceStatusHealthy},
+			{ServiceName: "web", Status: colony.ServiceStatusIdle},
+		}},
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	insert := func(at time.Time, status int, bucket float64, count int) {
+		t.Helper()
+		_, err := db.DB().ExecContext(ctx, `
+			INSERT INTO beyla_http_metrics (timestamp, agent_id, service_name, http_method, http_route, http_status_code, latency_bucket_ms, count)
+			VALUES (?, 'agent-1', 'api', 'GET', '/orders', ?, ?, ?)
+		`, at, status, bucket, count)
+		require.NoError(t, err)
+	}
+
+	// Steady traffic over the last hour: ~300 requests per 5 minutes at
+	// 50ms, no errors. Then an error burst in the last 5 minutes.
+	for i := 1; i <= 11; i++ {
+		insert(now.Add(-time.Duration(i)*5*time.Minute-time.Minute), 200, 50, 290+2*(i%3))
+	}
+	insert(now.Add(-time.Minute), 200, 50, 240)
+	insert(now.Add(-time.Minute), 503, 50, 60)
+
+	resp, err := s.QueryUnifiedSummary(ctx, connect.NewRequest(&colonyv1.QueryUnifiedSummaryRequest{
+		TimeRange:       "5m",
+		IncludeBaseline: true,
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Summaries, 2)
+
+	api := resp.Msg.Summaries[0].Baseline
+	require.NotNil(t, api)
+	assert.Equal(t, int32(12), api.Intervals)
+	assert.InDelta(t, 1.0, api.RequestsPerSecond.Current, 0.001)
+	assert.False(t, api.RequestsPerSecond.Anomalous, "traffic is steady")
+	assert.InDelta(t, 20.0, api.ErrorRate.Current, 0.001)
+	assert.True(t, api.ErrorRate.Anomalous, "error burst")
+	assert.Greater(t, api.ErrorRate.Sigma, 3.0)
+	assert.False(t, api.P95LatencyMs.Anomalous)
+
+	web := resp.Msg.Summaries[1].Baseline
+	require.NotNil(t, web)
+	assert.Zero(t, web.RequestsPerSecond.Current)
+	assert.False(t, web.ErrorRate.Anomalous)
+
+	t.Run("invalid options", func(t *testing.T) {
+		for _, req := range []*colonyv1.QueryUnifiedSummaryRequest{
+			{TimeRange: "5m", IncludeBaseline: true, BaselineWindow: "later"},
+			{TimeRange: "1h", IncludeBaseline: true, BaselineWindow: "5m"},
+			{TimeRange: "1m", IncludeBaseline: true, BaselineWindow: "72h"},
+			{TimeRange: "5m", IncludeBaseline: true, AnomalySigma: -1},
+		} {
+			_, err := s.QueryUnifiedSummary(ctx, connect.NewRequest(req))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), req.String())
+		}
+	})
+}
+
+func TestMetricBaseline(t *testing.T) {
+	m := metricBaseline(10, []float64{10, 10, 10}, 0.5, 3)
+	assert.Zero(t, m.Sigma)
+	assert.False(t, m.Anomalous)
+
+	m = metricBaseline(12, []float64{10, 10, 10}, 0.5, 3)
+	assert.InDelta(t, 4.0, m.Sigma, 0.001, "the stddev floor applies to a flat baseline")
+	assert.True(t, m.Anomalous)
+
+	m = metricBaseline(1, []float64{8, 10, 12}, 0.5, 3)
+	assert.InDelta(t, 10.0, m.Mean, 0.001)
+	assert.Less(t, m.Sigma, -3.0)
+	assert.True(t, m.Anomalous, "drops are anomalies too")
+
+	m = metricBaseline(100, []float64{10, 10}, 0.5, 3)
+	assert.False(t, m.Anomalous, "too few intervals")
+}
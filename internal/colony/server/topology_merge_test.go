@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
 	"github.com/coral-mesh/coral/internal/colony/database"
 )
@@ -91,3 +93,41 @@ func TestGetTopology_EmptyReturnsNoConnections(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, resp.Msg.Connections)
 }
+
+// TestGetTopology_EdgeStats verifies that L7 edges carry the traffic of the
+// requested window.
+func TestGetTopology_EdgeStats(t *testing.T) {
+	srv, cleanup := newTestServer(t, Config{ColonyID: "test-stats"})
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	for i, status := range []uint32{200, 200, 200, 503} {
+		traceID := fmt.Sprintf("trace%027d", i)
+		parentID := fmt.Sprintf("parent%010d", i)
+		require.NoError(t, srv.database.InsertBeylaTraces(ctx, "agent-a", []*agentv1.EbpfTraceSpan{{
+			TraceId: traceID, SpanId: parentID, ServiceName: "frontend", SpanName: "GET /orders",
+			SpanKind: "client", StartTime: now.UnixMilli(), DurationUs: 2000, StatusCode: status,
+		}}))
+		require.NoError(t, srv.database.InsertBeylaTraces(ctx, "agent-b", []*agentv1.EbpfTraceSpan{{
+			TraceId: traceID, SpanId: fmt.Sprintf("child%011d", i), ParentSpanId: parentID, ServiceName: "orders",
+			SpanName: "GET /orders", SpanKind: "server", StartTime: now.UnixMilli(), DurationUs: 1000, StatusCode: status,
+		}}))
+	}
+
+	resp, err := srv.GetTopology(ctx, connect.NewRequest(&colonyv1.GetTopologyRequest{Since: "10m"}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Connections, 1)
+
+	c := resp.Msg.Connections[0]
+	assert.Equal(t, "frontend", c.SourceId)
+	assert.Equal(t, "orders", c.TargetId)
+	assert.Equal(t, int64(4), c.RequestCount)
+	assert.InDelta(t, 4.0/600, c.RequestsPerSecond, 1e-9)
+	assert.InDelta(t, 25.0, c.ErrorRate, 1e-9)
+	assert.InDelta(t, 1.0, c.P95LatencyMs, 1e-9)
+
+	_, err = srv.GetTopology(ctx, connect.NewRequest(&colonyv1.GetTopologyRequest{Since: "recently"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
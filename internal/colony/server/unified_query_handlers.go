@@ -6,18 +6,79 @@ import (
 	"time"
 
 	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
 	colonyv1 "github.com/coral-mesh/coral/coral/colony/v1"
 	"github.com/coral-mesh/coral/internal/colony"
+	"github.com/coral-mesh/coral/internal/colony/querycache"
+	"github.com/coral-mesh/coral/internal/constants"
 )
 
+// summarySources are the ingested data service summaries are computed from.
+var summarySources = []querycache.Source{querycache.Metrics, querycache.Traces, querycache.Profiles}
+
 // QueryUnifiedSummary handles unified summary queries (RFD 067).
 func (s *Server) QueryUnifiedSummary(
 	ctx context.Context,
 	req *connect.Request[colonyv1.QueryUnifiedSummaryRequest],
 ) (*connect.Response[colonyv1.QueryUnifiedSummaryResponse], error) {
+	services, err := s.selectServices(req.Msg.Selector)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.groupServices(ctx, req.Msg.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	// The summaries of this colony are cached until new data is ingested.
+	// Labels and groups change without new data, so the selector and group
+	// are applied afterwards.
+	cacheReq := req.Msg
+	if cacheReq.Selector != "" || cacheReq.Group != "" {
+		cacheReq = proto.Clone(req.Msg).(*colonyv1.QueryUnifiedSummaryRequest)
+		cacheReq.Selector = ""
+		cacheReq.Group = ""
+	}
+	key, err := querycache.Key("QueryUnifiedSummary", cacheReq, constants.QueryCacheTimeGranularity)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp, err := querycache.Get(ctx, s.database.QueryCache(), key, summarySources,
+		func(ctx context.Context) (*colonyv1.QueryUnifiedSummaryResponse, error) {
+			return s.queryUnifiedSummary(ctx, cacheReq)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if services != nil || members != nil || req.Msg.Federated {
+		// Cached responses are shared; filter and add the child colonies to
+		// a copy.
+		resp = proto.Clone(resp).(*colonyv1.QueryUnifiedSummaryResponse)
+	}
+	if services != nil {
+		resp.Summaries = filterServices(resp.Summaries, services)
+	}
+	if req.Msg.Federated {
+		s.federateSummary(ctx, req.Msg, resp)
+	}
+	if members != nil {
+		// Groups are defined on this colony, so the summaries of child
+		// colonies are filtered here too.
+		resp.Summaries = filterServices(resp.Summaries, members)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// queryUnifiedSummary computes the service summaries of this colony.
+func (s *Server) queryUnifiedSummary(
+	ctx context.Context,
+	msg *colonyv1.QueryUnifiedSummaryRequest,
+) (*colonyv1.QueryUnifiedSummaryResponse, error) {
 	// Type assert to get the actual eBPF service.
 	ebpfQueryService, ok := s.ebpfService.(interface {
 		QueryUnifiedSummary(ctx context.Context, serviceName string, startTime, endTime time.Time) ([]colony.UnifiedSummaryResult, error)
@@ -27,13 +88,13 @@ func (s *Server) QueryUnifiedSummary(
 	}
 
 	// Parse time range
-	startTime, endTime, err := parseTimeRange(req.Msg.TimeRange)
+	startTime, endTime, err := parseTimeRange(msg.TimeRange)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid time_range: %w", err))
 	}
 
 	// Call backend service
-	results, err := ebpfQueryService.QueryUnifiedSummary(ctx, req.Msg.Service, startTime, endTime)
+	results, err := ebpfQueryService.QueryUnifiedSummary(ctx, msg.Service, startTime, endTime)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query summary: %w", err))
 	}
@@ -120,9 +181,15 @@ func (s *Server) QueryUnifiedSummary(
 		summaries = append(summaries, result)
 	}
 
-	return connect.NewResponse(&colonyv1.QueryUnifiedSummaryResponse{
+	if msg.IncludeBaseline {
+		if err := s.addSummaryBaselines(ctx, msg, startTime, endTime, summaries); err != nil {
+			return nil, err
+		}
+	}
+
+	return &colonyv1.QueryUnifiedSummaryResponse{
 		Summaries: summaries,
-	}), nil
+	}, nil
 }
 
 // QueryUnifiedTraces handles unified trace queries (RFD 067).
@@ -144,6 +211,15 @@ func (s *Server) QueryUnifiedTraces(
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid time_range: %w", err))
 	}
 
+	services, err := s.selectServices(req.Msg.Selector)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.groupServices(ctx, req.Msg.Group)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert min_duration_ms to microseconds
 	minDurationUs := int64(req.Msg.MinDurationMs) * 1000
 
@@ -152,17 +228,23 @@ func (s *Server) QueryUnifiedTraces(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query traces: %w", err))
 	}
-
-	// Count unique traces
-	traceGroups := make(map[string]bool)
-	for _, span := range spans {
-		traceGroups[span.TraceId] = true
+	if services != nil {
+		spans = filterServices(spans, services)
 	}
 
-	return connect.NewResponse(&colonyv1.QueryUnifiedTracesResponse{
+	resp := &colonyv1.QueryUnifiedTracesResponse{
 		Spans:       spans,
-		TotalTraces: int32(len(traceGroups)),
-	}), nil
+		TotalTraces: countTraces(spans),
+	}
+	if req.Msg.Federated {
+		s.federateTraces(ctx, req.Msg, resp)
+	}
+	if members != nil {
+		resp.Spans = filterServices(resp.Spans, members)
+		resp.TotalTraces = countTraces(resp.Spans)
+	}
+
+	return connect.NewResponse(resp), nil
 }
 
 // QueryUnifiedMetrics handles unified metrics queries (RFD 067).
@@ -184,21 +266,46 @@ func (s *Server) QueryUnifiedMetrics(
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid time_range: %w", err))
 	}
 
+	services, err := s.selectServices(req.Msg.Selector)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.groupServices(ctx, req.Msg.Group)
+	if err != nil {
+		return nil, err
+	}
+
 	// Call backend service
 	metrics, err := ebpfQueryService.QueryUnifiedMetrics(ctx, req.Msg.Service, startTime, endTime)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query metrics: %w", err))
 	}
+	if services != nil {
+		metrics.HttpMetrics = filterServices(metrics.HttpMetrics, services)
+		metrics.GrpcMetrics = filterServices(metrics.GrpcMetrics, services)
+		metrics.SqlMetrics = filterServices(metrics.SqlMetrics, services)
+	}
 
 	// Calculate total metrics count
 	totalMetrics := len(metrics.HttpMetrics) + len(metrics.GrpcMetrics) + len(metrics.SqlMetrics)
 
-	return connect.NewResponse(&colonyv1.QueryUnifiedMetricsResponse{
+	resp := &colonyv1.QueryUnifiedMetricsResponse{
 		HttpMetrics:  metrics.HttpMetrics,
 		GrpcMetrics:  metrics.GrpcMetrics,
 		SqlMetrics:   metrics.SqlMetrics,
 		TotalMetrics: int32(totalMetrics),
-	}), nil
+	}
+	if req.Msg.Federated {
+		s.federateMetrics(ctx, req.Msg, resp)
+	}
+	if members != nil {
+		resp.HttpMetrics = filterServices(resp.HttpMetrics, members)
+		resp.GrpcMetrics = filterServices(resp.GrpcMetrics, members)
+		resp.SqlMetrics = filterServices(resp.SqlMetrics, members)
+		resp.TotalMetrics = int32(len(resp.HttpMetrics) + len(resp.GrpcMetrics) + len(resp.SqlMetrics)) // #nosec G115 -- bounded by query limits.
+	}
+
+	return connect.NewResponse(resp), nil
 }
 
 // QueryUnifiedLogs handles unified log queries (RFD 067).
@@ -2,6 +2,7 @@ package colony
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -74,6 +75,13 @@ func (p *ServicePoller) PollOnce(ctx context.Context) error {
 		for _, svc := range services {
 			serviceID := fmt.Sprintf("%s-%s", agent.AgentID, svc.Name)
 
+			labels, err := json.Marshal(svc.Labels)
+			if err != nil {
+				return fmt.Errorf("failed to marshal labels of service %s: %w", svc.Name, err)
+			}
+			// Labels set through the SDK are only known from polling.
+			_ = p.registry.SetServiceLabels(agent.AgentID, svc.Name, svc.Labels)
+
 			now := time.Now()
 			dbService := &database.Service{
 				ID:           serviceID,
@@ -81,7 +89,7 @@ func (p *ServicePoller) PollOnce(ctx context.Context) error {
 				AppID:        svc.Name, // Use service name as app ID for now.
 				Version:      "",       // Version not available from ListServices.
 				AgentID:      agent.AgentID,
-				Labels:       "", // Convert labels map to JSON if needed.
+				Labels:       string(labels),
 				Status:       "active",
 				RegisteredAt: now,
 				LastSeen:     now,
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// KeySize is the size of storage encryption keys (AES-256).
+const KeySize = 32
+
+// Encrypted files start with encryptionMagic and a random salt from which the
+// file key is derived, followed by the plaintext in chunks of
+// encryptionChunkSize bytes, each sealed with AES-GCM. The nonce of a chunk is
+// its index, with the last byte set on the final chunk, so that reordered,
+// dropped or truncated chunks fail to open.
+const (
+	encryptionMagic     = "coral-enc/v1\n"
+	encryptionSaltSize  = 32
+	encryptionChunkSize = 64 * 1024
+	encryptionInfo      = "coral-storage-encryption/v1"
+)
+
+// ErrDecrypt is returned when a file cannot be decrypted, either because the
+// key is wrong or because the file was corrupted or truncated.
+var ErrDecrypt = errors.New("failed to decrypt: wrong key or corrupted file")
+
+// EncryptFile encrypts src with key into dst. dst is written to a temporary
+// file first and renamed, so it is either left untouched or fully replaced.
+func EncryptFile(src, dst string, key []byte) error {
+	//nolint:gosec // G304: Path is in the colony storage directory.
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close() // nolint:errcheck
+
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	aead, err := fileCipher(key, salt)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(dst, func(w io.Writer) error {
+		if _, err := io.WriteString(w, encryptionMagic); err != nil {
+			return err
+		}
+		if _, err := w.Write(salt); err != nil {
+			return err
+		}
+
+		r := bufio.NewReaderSize(in, encryptionChunkSize)
+		buf := make([]byte, encryptionChunkSize, encryptionChunkSize+aead.Overhead())
+		for index := uint64(0); ; index++ {
+			n, err := io.ReadFull(r, buf)
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+				return err
+			}
+			final := n < encryptionChunkSize
+			if !final {
+				_, peekErr := r.Peek(1)
+				final = errors.Is(peekErr, io.EOF)
+			}
+			if _, err := w.Write(aead.Seal(buf[:0], chunkNonce(index, final), buf[:n], nil)); err != nil {
+				return err
+			}
+			if final {
+				return nil
+			}
+		}
+	})
+}
+
+// DecryptFile decrypts src, written by EncryptFile, with key into dst. A
+// partially written dst is removed when decryption fails.
+func DecryptFile(src, dst string, key []byte) error {
+	//nolint:gosec // G304: Path is in the colony storage directory.
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close() // nolint:errcheck
+
+	r := bufio.NewReaderSize(in, encryptionChunkSize+64)
+	header := make([]byte, len(encryptionMagic)+encryptionSaltSize)
+	if _, err := io.ReadFull(r, header); err != nil || string(header[:len(encryptionMagic)]) != encryptionMagic {
+		return fmt.Errorf("%s is not an encrypted coral file", src)
+	}
+	aead, err := fileCipher(key, header[len(encryptionMagic):])
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(dst, func(w io.Writer) error {
+		buf := make([]byte, encryptionChunkSize+aead.Overhead())
+		for index := uint64(0); ; index++ {
+			n, err := io.ReadFull(r, buf)
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return ErrDecrypt
+			}
+			final := n < len(buf)
+			if !final {
+				_, peekErr := r.Peek(1)
+				final = errors.Is(peekErr, io.EOF)
+			}
+			plaintext, err := aead.Open(buf[:0], chunkNonce(index, final), buf[:n], nil)
+			if err != nil {
+				return ErrDecrypt
+			}
+			if _, err := w.Write(plaintext); err != nil {
+				return err
+			}
+			if final {
+				return nil
+			}
+		}
+	})
+}
+
+// fileCipher derives the AES-GCM cipher of a file from key and its salt.
+func fileCipher(key, salt []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("storage encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+	fileKey, err := hkdf.Key(sha256.New, key, salt, encryptionInfo, KeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(fileKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of the chunk at index.
+func chunkNonce(index uint64, final bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[3:11], index)
+	if final {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// writeFileAtomic writes path with write through a temporary file in the
+// same directory, replacing path only once write succeeded.
+func writeFileAtomic(path string, write func(w io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // nolint:errcheck
+
+	w := bufio.NewWriterSize(tmp, encryptionChunkSize)
+	if err := write(w); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
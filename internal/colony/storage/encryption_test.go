@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptFile(t *testing.T) {
+	dir := t.TempDir()
+	key := bytes.Repeat([]byte{1}, KeySize)
+
+	for _, size := range []int{0, 1, encryptionChunkSize, encryptionChunkSize + 1, 3 * encryptionChunkSize} {
+		plaintext := make([]byte, size)
+		_, _ = rand.Read(plaintext)
+		src := filepath.Join(dir, "plain")
+		require.NoError(t, os.WriteFile(src, plaintext, 0600))
+
+		encrypted := filepath.Join(dir, "encrypted")
+		require.NoError(t, EncryptFile(src, encrypted, key))
+		decrypted := filepath.Join(dir, "decrypted")
+		require.NoError(t, DecryptFile(encrypted, decrypted, key))
+
+		got, err := os.ReadFile(decrypted)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(plaintext, got), "size %d", size)
+	}
+}
+
+func TestDecryptFile_Rejects(t *testing.T) {
+	dir := t.TempDir()
+	key := bytes.Repeat([]byte{1}, KeySize)
+
+	src := filepath.Join(dir, "plain")
+	require.NoError(t, os.WriteFile(src, bytes.Repeat([]byte("secret"), encryptionChunkSize), 0600))
+	encrypted := filepath.Join(dir, "encrypted")
+	require.NoError(t, EncryptFile(src, encrypted, key))
+	data, err := os.ReadFile(encrypted)
+	require.NoError(t, err)
+
+	tampered := bytes.Clone(data)
+	tampered[len(tampered)/2] ^= 1
+	header := len(encryptionMagic) + encryptionSaltSize
+	chunk := encryptionChunkSize + 16
+
+	for name, content := range map[string][]byte{
+		"tampered":           tampered,
+		"truncated":          data[:len(data)-1],
+		"truncated at chunk": data[:header+chunk],
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, content, 0600))
+		out := filepath.Join(dir, name+".out")
+		assert.ErrorIs(t, DecryptFile(path, out, key), ErrDecrypt, name)
+		assert.NoFileExists(t, out, name)
+	}
+
+	assert.ErrorIs(t, DecryptFile(encrypted, filepath.Join(dir, "out"), bytes.Repeat([]byte{2}, KeySize)), ErrDecrypt)
+	assert.Error(t, DecryptFile(src, filepath.Join(dir, "out"), key), "not an encrypted file")
+	assert.Error(t, EncryptFile(src, encrypted, key[:16]), "short key")
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/coral-mesh/coral/internal/config"
+	"github.com/coral-mesh/coral/internal/constants"
+)
+
+// LoadKey returns the storage encryption key of cfg, taken from the
+// environment (CORAL_STORAGE_ENCRYPTION_KEY), the key file or the output of
+// the key command, in that order.
+func LoadKey(ctx context.Context, cfg config.StorageEncryptionConfig) ([]byte, error) {
+	switch {
+	case cfg.Key != "":
+		return parseKey([]byte(cfg.Key), "CORAL_STORAGE_ENCRYPTION_KEY")
+
+	case cfg.KeyFile != "":
+		//nolint:gosec // G304: Key file path comes from the colony config.
+		data, err := os.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read storage encryption key: %w", err)
+		}
+		return parseKey(data, cfg.KeyFile)
+
+	case len(cfg.KeyCommand) > 0:
+		ctx, cancel := context.WithTimeout(ctx, constants.DefaultStorageKeyCommandTimeout)
+		defer cancel()
+
+		//nolint:gosec // G204: The key command comes from the colony config.
+		cmd := exec.CommandContext(ctx, cfg.KeyCommand[0], cfg.KeyCommand[1:]...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("storage encryption key command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return parseKey(out, "key command output")
+
+	default:
+		return nil, fmt.Errorf("storage encryption is enabled but no key is configured (set CORAL_STORAGE_ENCRYPTION_KEY, key_file or key_command)")
+	}
+}
+
+// parseKey decodes a base64-encoded key, or takes data as the raw key if it
+// is exactly KeySize bytes long.
+func parseKey(data []byte, source string) ([]byte, error) {
+	if key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data))); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	if len(data) == KeySize {
+		return data, nil
+	}
+	return nil, fmt.Errorf("invalid storage encryption key in %s: must be %d bytes, base64-encoded or raw", source, KeySize)
+}
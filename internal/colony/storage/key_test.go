@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/config"
+)
+
+func TestLoadKey(t *testing.T) {
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{3}, KeySize)
+	encoded := base64.StdEncoding.EncodeToString(key)
+
+	keyFile := filepath.Join(t.TempDir(), "storage.key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(encoded+"\n"), 0600))
+
+	for name, cfg := range map[string]config.StorageEncryptionConfig{
+		"env":         {Key: encoded},
+		"file":        {KeyFile: keyFile},
+		"command":     {KeyCommand: []string{"echo", encoded}},
+		"raw command": {KeyCommand: []string{"printf", string(key)}},
+	} {
+		got, err := LoadKey(ctx, cfg)
+		require.NoError(t, err, name)
+		assert.Equal(t, key, got, name)
+	}
+
+	_, err := LoadKey(ctx, config.StorageEncryptionConfig{})
+	assert.ErrorContains(t, err, "no key is configured")
+	_, err = LoadKey(ctx, config.StorageEncryptionConfig{Key: "c2hvcnQ="})
+	assert.ErrorContains(t, err, "must be 32 bytes")
+	_, err = LoadKey(ctx, config.StorageEncryptionConfig{KeyCommand: []string{"false"}})
+	assert.ErrorContains(t, err, "key command failed")
+}
@@ -116,6 +116,18 @@ func (a *TelemetryAggregator) Clear() {
 	a.buckets = make(map[string]*bucketData)
 }
 
+// ClearBefore removes buckets that start before cutoff.
+func (a *TelemetryAggregator) ClearBefore(cutoff time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for key, data := range a.buckets {
+		if data.bucketTime.Before(cutoff) {
+			delete(a.buckets, key)
+		}
+	}
+}
+
 // getBucketKey creates a unique key for a bucket.
 func getBucketKey(agentID string, bucketTime time.Time, service, kind string) string {
 	return agentID + "|" + bucketTime.Format(time.RFC3339) + "|" + service + "|" + kind
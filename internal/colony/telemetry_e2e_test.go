@@ -304,6 +304,14 @@ func (h *testAgentHandler) GetFunctions(
 	}), nil
 }
 
+// UpdateColonySecret implements the UpdateColonySecret RPC (stub for testing).
+func (h *testAgentHandler) UpdateColonySecret(
+	ctx context.Context,
+	req *connect.Request[agentv1.UpdateColonySecretRequest],
+) (*connect.Response[agentv1.UpdateColonySecretResponse], error) {
+	return connect.NewResponse(&agentv1.UpdateColonySecretResponse{}), nil
+}
+
 func (h *testAgentHandler) QueryTelemetry(
 	ctx context.Context,
 	req *connect.Request[agentv1.QueryTelemetryRequest],
@@ -380,6 +388,18 @@ func (h *testAgentHandler) ContainerExec(ctx context.Context, req *connect.Reque
 	return nil, connect.NewError(connect.CodeUnimplemented, nil)
 }
 
+func (h *testAgentHandler) ExecInteractive(ctx context.Context, stream *connect.BidiStream[agentv1.ShellRequest, agentv1.ShellResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (h *testAgentHandler) ReadFile(ctx context.Context, req *connect.Request[agentv1.ReadFileRequest], stream *connect.ServerStream[agentv1.ReadFileResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (h *testAgentHandler) WriteFile(ctx context.Context, stream *connect.ClientStream[agentv1.WriteFileRequest]) (*connect.Response[agentv1.WriteFileResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
 func (h *testAgentHandler) ResizeShellTerminal(ctx context.Context, req *connect.Request[agentv1.ResizeShellTerminalRequest]) (*connect.Response[agentv1.ResizeShellTerminalResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, nil)
 }
@@ -21,6 +21,7 @@ const telemetryDataType = "telemetry"
 // Uses sequence-based checkpoints for reliable polling (RFD 089).
 type TelemetryPoller struct {
 	*poller.BasePoller
+	scheduler      *poller.Scheduler
 	registry       *registry.Registry
 	db             *database.Database
 	pollInterval   time.Duration
@@ -52,6 +53,7 @@ func NewTelemetryPoller(
 
 	return &TelemetryPoller{
 		BasePoller:     base,
+		scheduler:      poller.NewScheduler(poller.ScheduleConfig{Interval: pollInterval}),
 		registry:       registry,
 		db:             db,
 		pollInterval:   pollInterval,
@@ -70,6 +72,11 @@ func (p *TelemetryPoller) Stop() error {
 	return p.BasePoller.Stop()
 }
 
+// Scheduler returns the per-agent scheduler, which holds poll latency stats.
+func (p *TelemetryPoller) Scheduler() *poller.Scheduler {
+	return p.scheduler
+}
+
 // PollOnce performs a single polling cycle.
 // Implements the poller.Poller interface.
 func (p *TelemetryPoller) PollOnce(ctx context.Context) error {
@@ -77,15 +84,15 @@ func (p *TelemetryPoller) PollOnce(ctx context.Context) error {
 	aggregator := NewTelemetryAggregator()
 	totalSpans := 0
 
-	successCount, errorCount := poller.ForEachHealthyAgent(p.registry, p.logger, func(agent *registry.Entry) error {
+	successCount, errorCount, skippedCount := poller.ForEachDueAgent(ctx, p.registry, p.scheduler, p.logger, func(agent *registry.Entry) (bool, error) {
 		spans, err := p.pollAgent(ctx, agent)
 		if err != nil {
-			return err
+			return false, err
 		}
 
 		aggregator.AddSpans(agent.AgentID, spans)
 		totalSpans += len(spans)
-		return nil
+		return len(spans) > 0, nil
 	})
 
 	// Get aggregated summaries.
@@ -104,12 +111,14 @@ func (p *TelemetryPoller) PollOnce(ctx context.Context) error {
 		p.logger.Info().
 			Int("agents_queried", successCount).
 			Int("agents_failed", errorCount).
+			Int("agents_skipped", skippedCount).
 			Int("total_spans", totalSpans).
 			Int("summaries", len(summaries)).
 			Msg("Telemetry poll completed")
 	} else {
 		p.logger.Debug().
 			Int("agents_queried", successCount).
+			Int("agents_skipped", skippedCount).
 			Msg("Telemetry poll completed with no data")
 	}
 
@@ -7,6 +7,7 @@ package wireguard
 import (
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/coral-mesh/coral/internal/colony/database"
 	"github.com/coral-mesh/coral/internal/config"
@@ -28,6 +29,30 @@ func CreateDevice(cfg *config.ResolvedConfig, logger logging.Logger) (*wireguard
 		return nil, fmt.Errorf("failed to create WireGuard device: %w", err)
 	}
 
+	// Answer STUN requests on the WireGuard port so agents discover the
+	// endpoint their NAT maps to this colony (RFD 029).
+	if stunCfg := cfg.WireGuard.STUNServer; !stunCfg.Disabled {
+		rateLimit := stunCfg.RateLimit
+		if rateLimit == 0 {
+			rateLimit = constants.DefaultSTUNRateLimit
+		}
+		wgDevice.EnableSTUN(wireguard.NewSTUNResponder(rateLimit, logger))
+		logger.Info().
+			Int("port", cfg.WireGuard.Port).
+			Int("rate_limit", rateLimit).
+			Msg("STUN server enabled on WireGuard port")
+	}
+
+	// Probe agents that cannot reach the colony through NAT.
+	if !cfg.WireGuard.HolePunching.Disabled {
+		wgDevice.EnableHolePunching()
+	}
+
+	// Relay packets over HTTP for agents no UDP path reaches.
+	if !cfg.WireGuard.Relay.Disabled {
+		wgDevice.EnableRelay()
+	}
+
 	return wgDevice, nil
 }
 
@@ -75,6 +100,33 @@ func StartDevice(wgDevice *wireguard.Device, cfg *config.ResolvedConfig, logger
 			Msg("Successfully assigned IP to WireGuard interface")
 	}
 
+	// Assign the IPv6 mesh IP alongside. The mesh keeps working over IPv4
+	// if the host has IPv6 disabled.
+	if cfg.WireGuard.MeshIPv6 != "" && cfg.WireGuard.MeshNetworkIPv6 != "" {
+		meshIPv6 := net.ParseIP(cfg.WireGuard.MeshIPv6)
+		if meshIPv6 == nil || meshIPv6.To4() != nil {
+			return fmt.Errorf("invalid mesh IPv6 address: %s", cfg.WireGuard.MeshIPv6)
+		}
+
+		_, meshNetV6, err := net.ParseCIDR(cfg.WireGuard.MeshNetworkIPv6)
+		if err != nil {
+			return fmt.Errorf("invalid mesh IPv6 network CIDR: %w", err)
+		}
+
+		if err := wgDevice.Interface().AssignIP(meshIPv6, meshNetV6); err != nil {
+			logger.Warn().
+				Err(err).
+				Str("interface", wgDevice.InterfaceName()).
+				Str("ip", meshIPv6.String()).
+				Msg("Failed to assign IPv6 to WireGuard interface, mesh is IPv4 only")
+		} else {
+			logger.Info().
+				Str("interface", wgDevice.InterfaceName()).
+				Str("ip", meshIPv6.String()).
+				Msg("Successfully assigned IPv6 to WireGuard interface")
+		}
+	}
+
 	// Save the assigned interface name to config for future reference
 	interfaceName := wgDevice.InterfaceName()
 	if interfaceName != "" {
@@ -160,8 +212,8 @@ func BuildEndpoints(port int, wireguardConfig config.WireGuardConfig) []string {
 		if h, _, err := net.SplitHostPort(endpoint); err == nil {
 			host = h
 		} else {
-			// No port in the endpoint, use as-is
-			host = endpoint
+			// No port in the endpoint, use as-is (unbracketing IPv6 literals)
+			host = strings.TrimSuffix(strings.TrimPrefix(endpoint, "["), "]")
 		}
 
 		// Build WireGuard endpoint with the configured WireGuard port
@@ -0,0 +1,30 @@
+package wireguard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/coral-mesh/coral/internal/config"
+)
+
+func TestBuildEndpoints(t *testing.T) {
+	endpoints := BuildEndpoints(41580, config.WireGuardConfig{
+		PublicEndpoints: []string{
+			"colony.example.com:9000",
+			"203.0.113.10",
+			"[2001:db8::10]:9000",
+			"[2001:db8::11]",
+			"2001:db8::12",
+		},
+	})
+	assert.Equal(t, []string{
+		"colony.example.com:41580",
+		"203.0.113.10:41580",
+		"[2001:db8::10]:41580",
+		"[2001:db8::11]:41580",
+		"[2001:db8::12]:41580",
+	}, endpoints)
+
+	assert.Equal(t, []string{"127.0.0.1:41580"}, BuildEndpoints(41580, config.WireGuardConfig{}))
+}
@@ -128,6 +128,8 @@ func DefaultAgentConfig() *AgentConfig {
 	cfg.Debug.Limits.MaxMemoryMB = constants.DefaultMaxMemoryMB
 	cfg.Debug.BPF.MapSize = constants.DefaultBPFMapSize
 	cfg.Debug.BPF.PerfBufferPages = constants.DefaultBPFPerfBufferPages
+	cfg.Debug.EventStore.MaxEvents = constants.DefaultDebugEventStoreMaxEvents
+	cfg.Debug.EventStore.FlushInterval = constants.DefaultDebugEventStoreFlushInterval
 
 	// SystemMetrics defaults (RFD 071)
 	cfg.SystemMetrics.Disabled = false
@@ -145,6 +147,28 @@ func DefaultAgentConfig() *AgentConfig {
 	cfg.ContinuousProfiling.CPU.Retention = constants.DefaultCPUProfilingRetention
 	cfg.ContinuousProfiling.CPU.MetadataRetention = constants.DefaultCPUProfilingMetadataRetention
 
+	// ServiceDiscovery defaults (disabled unless opted in)
+	cfg.ServiceDiscovery.Exclude = append([]string(nil), constants.DefaultServiceDiscoveryExclude...)
+	cfg.ServiceDiscovery.Interval = constants.DefaultServiceDiscoveryInterval
+	cfg.ServiceDiscovery.MinUptime = constants.DefaultServiceDiscoveryMinUptime
+	cfg.ServiceDiscovery.ServiceLabel = constants.DefaultServiceDiscoveryLabel
+	cfg.ServiceDiscovery.DockerRoot = constants.DefaultDockerRoot
+
+	// ResourceLimits defaults (no limits unless configured)
+	cfg.ResourceLimits.CheckInterval = constants.DefaultResourceCheckInterval
+	cfg.ResourceLimits.HighWatermark = constants.DefaultResourceHighWatermark
+	cfg.ResourceLimits.LowWatermark = constants.DefaultResourceLowWatermark
+	cfg.ResourceLimits.UprobeSampleDivisor = constants.DefaultShedUprobeSampleDivisor
+
+	// CoreDumps defaults (disabled unless opted in)
+	cfg.CoreDumps.Mode = constants.DefaultCoreDumpMode
+	cfg.CoreDumps.MaxTotalMB = constants.DefaultCoreDumpMaxTotalMB
+	cfg.CoreDumps.MaxDumpMB = constants.DefaultCoreDumpMaxDumpMB
+	cfg.CoreDumps.PollInterval = constants.DefaultCoreDumpPollInterval
+
+	// Logs defaults (disabled unless opted in)
+	cfg.Logs.Interval = constants.DefaultLogCollectInterval
+
 	return cfg
 }
 
@@ -130,6 +130,22 @@ func setFieldValue(field reflect.Value, value string, fieldName string, envVar s
 			return fmt.Errorf("unsupported slice type for %s (%s)", fieldName, envVar)
 		}
 
+	case reflect.Map:
+		// Handle string maps (comma-separated key=value pairs)
+		if field.Type().Key().Kind() != reflect.String || field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type for %s (%s)", fieldName, envVar)
+		}
+		values := make(map[string]string)
+		for _, pair := range strings.Split(value, ",") {
+			k, v, ok := strings.Cut(pair, "=")
+			k = strings.TrimSpace(k)
+			if !ok || k == "" {
+				return fmt.Errorf("invalid key=value pair %q for %s (%s)", pair, fieldName, envVar)
+			}
+			values[k] = strings.TrimSpace(v)
+		}
+		field.Set(reflect.ValueOf(values))
+
 	default:
 		return fmt.Errorf("unsupported type %s for %s (%s)", field.Kind(), fieldName, envVar)
 	}
@@ -21,6 +21,7 @@ func TestLoadFromEnv_AgentConfig(t *testing.T) {
 		"CORAL_TELEMETRY_RETENTION_HOURS": "2",
 		"CORAL_STUN_SERVERS":              "stun1.example.com:3478,stun2.example.com:3478",
 		"CORAL_ENABLE_RELAY":              "true",
+		"CORAL_AGENT_LABELS":              "env=prod, region=us-east",
 	}
 
 	// Set environment variables
@@ -93,6 +94,10 @@ func TestLoadFromEnv_AgentConfig(t *testing.T) {
 	if cfg.Agent.NAT.EnableRelay != true {
 		t.Errorf("Agent.NAT.EnableRelay = %v, want true", cfg.Agent.NAT.EnableRelay)
 	}
+
+	if len(cfg.Agent.Labels) != 2 || cfg.Agent.Labels["env"] != "prod" || cfg.Agent.Labels["region"] != "us-east" {
+		t.Errorf("Agent.Labels = %v, want map[env:prod region:us-east]", cfg.Agent.Labels)
+	}
 }
 
 func TestLoadFromEnv_GlobalConfig(t *testing.T) {
@@ -6,6 +6,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -357,6 +359,100 @@ func ValidateColonyConfig(cfg *ColonyConfig) error {
 		return fmt.Errorf("invalid MTU: %d (must be 0-9000)", cfg.WireGuard.MTU)
 	}
 
+	// Validate STUN server rate limit.
+	if cfg.WireGuard.STUNServer.RateLimit < 0 {
+		return fmt.Errorf("invalid wireguard.stun_server.rate_limit: %d", cfg.WireGuard.STUNServer.RateLimit)
+	}
+
+	// Validate HA mode.
+	if cfg.HA.Mode != "" && cfg.HA.Mode != HAModeStandby {
+		return fmt.Errorf("invalid ha.mode: %q (must be empty or %q)", cfg.HA.Mode, HAModeStandby)
+	}
+
+	// Validate federation children.
+	childIDs := make(map[string]bool)
+	for i, child := range cfg.Federation.Children {
+		if child.ColonyID == "" {
+			return fmt.Errorf("federation.children[%d]: colony_id is required", i)
+		}
+		if child.ColonyID == cfg.ColonyID {
+			return fmt.Errorf("federation.children[%d]: colony %q cannot be its own child", i, child.ColonyID)
+		}
+		if childIDs[child.ColonyID] {
+			return fmt.Errorf("federation.children[%d]: duplicate colony_id %q", i, child.ColonyID)
+		}
+		childIDs[child.ColonyID] = true
+		if !strings.HasPrefix(child.Endpoint, "https://") && !strings.HasPrefix(child.Endpoint, "http://") {
+			return fmt.Errorf("federation.children[%d]: endpoint must be an http(s) URL", i)
+		}
+	}
+
+	// Validate OTLP sample rate.
+	if cfg.OTLP.SampleRate < 0 || cfg.OTLP.SampleRate > 1 {
+		return fmt.Errorf("invalid otlp.sample_rate: %v (must be between 0 and 1)", cfg.OTLP.SampleRate)
+	}
+
+	// Validate retention settings.
+	if cfg.Retention.Interval < 0 {
+		return fmt.Errorf("invalid retention.interval: %s", cfg.Retention.Interval)
+	}
+	for table, ttl := range cfg.Retention.Tables {
+		if ttl < 0 {
+			return fmt.Errorf("invalid retention.tables.%s: %s", table, ttl)
+		}
+	}
+
+	// Validate cold storage settings.
+	if cs := cfg.ColdStorage; cs.Enabled {
+		if !strings.HasPrefix(cs.URL, "s3://") && !strings.HasPrefix(cs.URL, "gs://") && !filepath.IsAbs(cs.URL) {
+			return fmt.Errorf("invalid cold_storage.url: %q (must be an s3:// or gs:// URL or an absolute path)", cs.URL)
+		}
+		if cs.After != 0 && cs.After < 24*time.Hour {
+			return fmt.Errorf("invalid cold_storage.after: %s (must be at least 24h)", cs.After)
+		}
+		if cs.Interval < 0 {
+			return fmt.Errorf("invalid cold_storage.interval: %s", cs.Interval)
+		}
+	}
+
+	// Validate storage encryption settings.
+	if se := cfg.StorageEncryption; se.Enabled {
+		if se.KeyFile != "" && len(se.KeyCommand) > 0 {
+			return fmt.Errorf("storage_encryption: key_file and key_command are mutually exclusive")
+		}
+		if se.WorkDir != "" && !filepath.IsAbs(se.WorkDir) {
+			return fmt.Errorf("invalid storage_encryption.work_dir: %q (must be an absolute path)", se.WorkDir)
+		}
+		if se.SealInterval < 0 {
+			return fmt.Errorf("invalid storage_encryption.seal_interval: %s", se.SealInterval)
+		}
+	}
+
+	// Validate alert sinks.
+	sinkNames := make(map[string]bool)
+	for i, sink := range cfg.Alerting.Sinks {
+		if sink.Name == "" {
+			return fmt.Errorf("alerting.sinks[%d]: name is required", i)
+		}
+		if sinkNames[sink.Name] {
+			return fmt.Errorf("alerting.sinks[%d]: duplicate name %q", i, sink.Name)
+		}
+		sinkNames[sink.Name] = true
+
+		switch sink.Type {
+		case AlertSinkWebhook, AlertSinkSlack:
+			if sink.URL == "" {
+				return fmt.Errorf("alerting.sinks[%d]: url is required for %s sinks", i, sink.Type)
+			}
+		case AlertSinkPagerDuty:
+			if sink.RoutingKey == "" {
+				return fmt.Errorf("alerting.sinks[%d]: routing_key is required for pagerduty sinks", i)
+			}
+		default:
+			return fmt.Errorf("alerting.sinks[%d]: invalid type %q (must be webhook, slack or pagerduty)", i, sink.Type)
+		}
+	}
+
 	return nil
 }
 
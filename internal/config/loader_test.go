@@ -484,6 +484,162 @@ func TestValidateColonyConfig_MissingApplicationName(t *testing.T) {
 	assert.Contains(t, err.Error(), "application_name")
 }
 
+func TestValidateColonyConfig_InvalidHAMode(t *testing.T) {
+	config := &ColonyConfig{
+		ColonyID:        "my-colony",
+		ApplicationName: "my-app",
+		HA:              HAConfig{Mode: "primary"},
+	}
+
+	err := ValidateColonyConfig(config)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "ha.mode")
+
+	config.HA.Mode = HAModeStandby
+	assert.NoError(t, ValidateColonyConfig(config))
+}
+
+func TestValidateColonyConfig_ColdStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ColdStorageConfig
+		wantErr string
+	}{
+		{name: "disabled", cfg: ColdStorageConfig{URL: "relative/dir"}},
+		{name: "s3", cfg: ColdStorageConfig{Enabled: true, URL: "s3://bucket/coral", After: 72 * time.Hour}},
+		{name: "local", cfg: ColdStorageConfig{Enabled: true, URL: "/var/lib/coral/cold"}},
+		{name: "relative path", cfg: ColdStorageConfig{Enabled: true, URL: "cold"}, wantErr: "cold_storage.url"},
+		{name: "http url", cfg: ColdStorageConfig{Enabled: true, URL: "https://example.com"}, wantErr: "cold_storage.url"},
+		{name: "after too short", cfg: ColdStorageConfig{Enabled: true, URL: "gs://bucket", After: time.Hour}, wantErr: "cold_storage.after"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &ColonyConfig{
+				ColonyID:        "my-colony",
+				ApplicationName: "my-app",
+				ColdStorage:     tt.cfg,
+			}
+			err := ValidateColonyConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateColonyConfig_StorageEncryption(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     StorageEncryptionConfig
+		wantErr string
+	}{
+		{name: "disabled", cfg: StorageEncryptionConfig{WorkDir: "relative/dir"}},
+		{name: "key file", cfg: StorageEncryptionConfig{Enabled: true, KeyFile: "/etc/coral/storage.key", WorkDir: "/dev/shm/coral"}},
+		{name: "key command", cfg: StorageEncryptionConfig{Enabled: true, KeyCommand: []string{"vault", "read", "-field=key", "secret/coral"}}},
+		{name: "both key sources", cfg: StorageEncryptionConfig{Enabled: true, KeyFile: "/key", KeyCommand: []string{"cat", "/key"}}, wantErr: "mutually exclusive"},
+		{name: "relative work dir", cfg: StorageEncryptionConfig{Enabled: true, WorkDir: "work"}, wantErr: "storage_encryption.work_dir"},
+		{name: "negative seal interval", cfg: StorageEncryptionConfig{Enabled: true, SealInterval: -time.Minute}, wantErr: "storage_encryption.seal_interval"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &ColonyConfig{
+				ColonyID:          "my-colony",
+				ApplicationName:   "my-app",
+				StorageEncryption: tt.cfg,
+			}
+			err := ValidateColonyConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateColonyConfig_AlertSinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		sinks   []AlertSinkConfig
+		wantErr string
+	}{
+		{
+			name: "valid",
+			sinks: []AlertSinkConfig{
+				{Name: "hook", Type: AlertSinkWebhook, URL: "https://example.com/alerts"},
+				{Name: "oncall", Type: AlertSinkPagerDuty, RoutingKey: "key"},
+			},
+		},
+		{name: "missing name", sinks: []AlertSinkConfig{{Type: AlertSinkSlack, URL: "https://hooks.slack.com/x"}}, wantErr: "name is required"},
+		{name: "duplicate name", sinks: []AlertSinkConfig{
+			{Name: "a", Type: AlertSinkSlack, URL: "https://hooks.slack.com/x"},
+			{Name: "a", Type: AlertSinkSlack, URL: "https://hooks.slack.com/y"},
+		}, wantErr: "duplicate name"},
+		{name: "missing url", sinks: []AlertSinkConfig{{Name: "a", Type: AlertSinkWebhook}}, wantErr: "url is required"},
+		{name: "missing routing key", sinks: []AlertSinkConfig{{Name: "a", Type: AlertSinkPagerDuty}}, wantErr: "routing_key is required"},
+		{name: "invalid type", sinks: []AlertSinkConfig{{Name: "a", Type: "email"}}, wantErr: "invalid type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &ColonyConfig{
+				ColonyID:        "my-colony",
+				ApplicationName: "my-app",
+				Alerting:        AlertingConfig{Sinks: tt.sinks},
+			}
+
+			err := ValidateColonyConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateColonyConfig_FederationChildren(t *testing.T) {
+	tests := []struct {
+		name     string
+		children []ChildColonyConfig
+		wantErr  string
+	}{
+		{
+			name: "valid",
+			children: []ChildColonyConfig{
+				{ColonyID: "eu", Endpoint: "https://eu.example.com:8443", Token: "cpt_x"},
+				{ColonyID: "us", Endpoint: "https://us.example.com:8443", Token: "cpt_y"},
+			},
+		},
+		{name: "missing colony id", children: []ChildColonyConfig{{Endpoint: "https://eu.example.com:8443"}}, wantErr: "colony_id is required"},
+		{name: "self", children: []ChildColonyConfig{{ColonyID: "my-colony", Endpoint: "https://eu.example.com:8443"}}, wantErr: "own child"},
+		{name: "duplicate colony id", children: []ChildColonyConfig{
+			{ColonyID: "eu", Endpoint: "https://eu.example.com:8443"},
+			{ColonyID: "eu", Endpoint: "https://eu2.example.com:8443"},
+		}, wantErr: "duplicate colony_id"},
+		{name: "invalid endpoint", children: []ChildColonyConfig{{ColonyID: "eu", Endpoint: "eu.example.com:8443"}}, wantErr: "endpoint"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &ColonyConfig{
+				ColonyID:        "my-colony",
+				ApplicationName: "my-app",
+				Federation:      FederationConfig{Children: tt.children},
+			}
+
+			err := ValidateColonyConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestValidateColonyConfig_InvalidMeshSubnet(t *testing.T) {
 	config := &ColonyConfig{
 		ColonyID:        "my-colony",
@@ -143,6 +143,7 @@ func (r *Resolver) ResolveConfig(colonyID string) (*ResolvedConfig, error) {
 	// Build resolved config with priority
 	resolved := &ResolvedConfig{
 		ColonyID:        colonyConfig.ColonyID,
+		ColonySecret:    colonyConfig.ColonySecret,
 		ApplicationName: colonyConfig.ApplicationName,
 		Environment:     colonyConfig.Environment,
 		WireGuard:       colonyConfig.WireGuard,
@@ -158,6 +159,24 @@ func (r *Resolver) ResolveConfig(colonyID string) (*ResolvedConfig, error) {
 	resolved.WireGuard.MeshNetworkIPv4 = meshSubnet
 	resolved.WireGuard.MeshIPv4 = colonyIP
 
+	// Colonies configured before IPv6 mesh support have no IPv6 addresses.
+	if resolved.WireGuard.MeshNetworkIPv6 == "" {
+		resolved.WireGuard.MeshNetworkIPv6 = constants.DefaultColonyMeshIPv6Subnet
+	}
+	if resolved.WireGuard.MeshIPv6 == "" {
+		resolved.WireGuard.MeshIPv6 = constants.DefaultColonyMeshIPv6
+	}
+
+	// The dashboard is served on the colony's dashboard port unless the
+	// project config overrides it.
+	resolved.Dashboard = DashboardConfig{
+		Port:    colonyConfig.Services.DashboardPort,
+		Enabled: true,
+	}
+	if resolved.Dashboard.Port == 0 {
+		resolved.Dashboard.Port = constants.DefaultDashboardPort
+	}
+
 	// Apply project config overrides
 	if projectConfig != nil {
 		if projectConfig.Dashboard.Port > 0 {
@@ -98,6 +98,20 @@ type ColonyConfig struct {
 	ContinuousProfiling ContinuousProfilingPollerConfig `yaml:"continuous_profiling,omitempty"` // RFD 072
 	FunctionRegistry    FunctionRegistryConfig          `yaml:"function_registry,omitempty"`    // RFD 063
 	Ask                 *AskConfig                      `yaml:"ask,omitempty"`                  // Per-colony ask overrides (RFD 030)
+	HA                  HAConfig                        `yaml:"ha,omitempty"`                   // Standby replica for failover
+	Alerting            AlertingConfig                  `yaml:"alerting,omitempty"`             // Alert notification sinks
+	OTLP                OTLPIngestConfig                `yaml:"otlp,omitempty"`                 // Direct OTLP ingestion
+	Federation          FederationConfig                `yaml:"federation,omitempty"`           // Child colonies for federated queries
+	Retention           RetentionConfig                 `yaml:"retention,omitempty"`            // Per-table TTLs enforced by the retention manager
+	ColdStorage         ColdStorageConfig               `yaml:"cold_storage,omitempty"`         // Object-storage offload of old raw metrics
+	Bandwidth           BandwidthConfig                 `yaml:"bandwidth,omitempty"`            // Data-plane transfer limits and compression
+	AgentAuth           AgentAuthConfig                 `yaml:"agent_auth,omitempty"`           // Agent authentication at registration
+	StorageEncryption   StorageEncryptionConfig         `yaml:"storage_encryption,omitempty"`   // At-rest encryption of the colony database
+	SLOs                []SLOConfig                     `yaml:"slos,omitempty"`                 // Service level objectives reported by coral query slo
+	ServiceGroups       []ServiceGroupConfig            `yaml:"service_groups,omitempty"`       // Named sets of services targeted with --group
+	AnomalyDetection    AnomalyDetectionConfig          `yaml:"anomaly_detection,omitempty"`    // Per-route baselines and anomaly scores
+	DebugAggregation    DebugAggregationConfig          `yaml:"debug_aggregation,omitempty"`    // Per-minute rollups of debug session events
+	QueryCache          QueryCacheConfig                `yaml:"query_cache,omitempty"`          // Cache of expensive query results
 	CreatedAt           time.Time                       `yaml:"created_at"`
 	CreatedBy           string                          `yaml:"created_by"`
 	LastUsed            time.Time                       `yaml:"last_used,omitempty"`
@@ -138,6 +152,49 @@ type WireGuardConfig struct {
 	MeshNetworkIPv6     string   `yaml:"mesh_network_ipv6,omitempty"`                             // IPv6 network CIDR
 	MTU                 int      `yaml:"mtu,omitempty"`                                           // Interface MTU
 	PersistentKeepalive int      `yaml:"persistent_keepalive,omitempty" env:"CORAL_WG_KEEPALIVE"` // Keepalive interval (seconds)
+
+	// STUNServer configures the STUN responder colonies run on the WireGuard
+	// port (RFD 029).
+	STUNServer STUNServerConfig `yaml:"stun_server,omitempty"`
+
+	// HolePunching configures coordinated hole punching with agents whose
+	// tunnel does not come up behind NAT.
+	HolePunching HolePunchingConfig `yaml:"hole_punching,omitempty"`
+
+	// Relay configures the WireGuard relay agents fall back to when no UDP
+	// path to the colony works.
+	Relay RelayConfig `yaml:"relay,omitempty"`
+}
+
+// HolePunchingConfig configures the colony side of hole punching. The colony
+// relays candidate endpoints between itself and an agent, both probe each
+// other from their WireGuard ports, and the first path that answers becomes
+// the peer endpoint.
+type HolePunchingConfig struct {
+	// Disabled turns off hole punching. Default: false (enabled).
+	Disabled bool `yaml:"disabled,omitempty" env:"CORAL_HOLE_PUNCHING_DISABLED"`
+}
+
+// RelayConfig configures the colony side of the WireGuard relay. Agents that
+// enable agent.nat.enable_relay tunnel their WireGuard packets over a
+// websocket to the colony's HTTP port when neither the direct path nor hole
+// punching works.
+type RelayConfig struct {
+	// Disabled turns off the relay. Default: false (enabled).
+	Disabled bool `yaml:"disabled,omitempty" env:"CORAL_RELAY_DISABLED"`
+}
+
+// STUNServerConfig configures the colony's built-in STUN server (RFD 029).
+// The colony answers STUN Binding Requests on its WireGuard port, so agents
+// behind NAT learn the public endpoint their NAT maps to the colony's address
+// rather than to a third-party STUN server.
+type STUNServerConfig struct {
+	// Disabled turns off the STUN responder. Default: false (enabled).
+	Disabled bool `yaml:"disabled,omitempty" env:"CORAL_STUN_SERVER_DISABLED"`
+
+	// RateLimit is the number of requests per minute answered for each
+	// source IP. Default: 60.
+	RateLimit int `yaml:"rate_limit,omitempty"`
 }
 
 // DiscoveryColony contains colony-specific discovery settings.
@@ -149,6 +206,217 @@ type DiscoveryColony struct {
 	STUNServers      []string      `yaml:"stun_servers,omitempty"` // STUN servers for NAT traversal
 }
 
+// HAModeStandby runs a colony as a standby replica of a primary colony.
+const HAModeStandby = "standby"
+
+// HAConfig configures colony high availability. A standby colony shares the
+// primary's colony config (keys, CA, colony ID), periodically copies the
+// primary's database and takes over discovery registration when the primary
+// becomes unreachable.
+type HAConfig struct {
+	// Mode is "standby" to run as a standby replica. Empty runs the colony
+	// as a primary.
+	Mode string `yaml:"mode,omitempty" env:"CORAL_HA_MODE"`
+
+	// PrimaryURL is the primary colony's mesh listener, e.g.
+	// http://100.64.0.1:9000. Default: the colony mesh IP and connect port.
+	PrimaryURL string `yaml:"primary_url,omitempty" env:"CORAL_HA_PRIMARY_URL"`
+
+	// SnapshotInterval is how often the standby copies the primary's database.
+	// Default: 5m.
+	SnapshotInterval time.Duration `yaml:"snapshot_interval,omitempty"`
+
+	// FailoverAfter is how long the primary must be unreachable before the
+	// standby takes over. Default: 30s.
+	FailoverAfter time.Duration `yaml:"failover_after,omitempty"`
+}
+
+// OTLPIngestConfig configures the colony's OTLP receiver, which lets
+// instrumented applications export traces and metrics to the colony directly
+// instead of through an agent.
+type OTLPIngestConfig struct {
+	// Enabled starts the receiver. Default: false.
+	Enabled bool `yaml:"enabled,omitempty" env:"CORAL_COLONY_OTLP_ENABLED"`
+
+	// GRPCEndpoint is the OTLP/gRPC listen address. Default: 0.0.0.0:4317.
+	GRPCEndpoint string `yaml:"grpc_endpoint,omitempty" env:"CORAL_COLONY_OTLP_GRPC_ENDPOINT"`
+
+	// HTTPEndpoint is the OTLP/HTTP listen address. Default: 0.0.0.0:4318.
+	HTTPEndpoint string `yaml:"http_endpoint,omitempty" env:"CORAL_COLONY_OTLP_HTTP_ENDPOINT"`
+
+	// SampleRate is the fraction of normal spans stored as traces (0.0 to
+	// 1.0). Error and high-latency spans are always stored, and summaries
+	// are computed from every span. Default: 0.10.
+	SampleRate float64 `yaml:"sample_rate,omitempty"`
+}
+
+// FederationConfig lists the child colonies a parent colony fans federated
+// queries out to (`coral query --federated`, `coral colony agents
+// --federated`).
+type FederationConfig struct {
+	Children []ChildColonyConfig `yaml:"children,omitempty"`
+}
+
+// ChildColonyConfig is a child colony reached over its public HTTPS endpoint
+// (RFD 031).
+type ChildColonyConfig struct {
+	// ColonyID labels results from the child.
+	ColonyID string `yaml:"colony_id"`
+
+	// Endpoint is the child's public endpoint URL.
+	// Example: "https://eu-colony.example.com:8443"
+	Endpoint string `yaml:"endpoint"`
+
+	// Token is an API token issued by the child with the status and query
+	// permissions (`coral colony token create`).
+	Token string `yaml:"token,omitempty"`
+
+	// CertificateAuthority is the path to the child's CA certificate.
+	CertificateAuthority string `yaml:"certificate_authority,omitempty"`
+
+	// CertificateAuthorityData is the base64-encoded CA certificate. Takes
+	// precedence over CertificateAuthority.
+	CertificateAuthorityData string `yaml:"certificate_authority_data,omitempty"`
+
+	// InsecureSkipTLSVerify disables TLS certificate verification (testing only).
+	InsecureSkipTLSVerify bool `yaml:"insecure_skip_tls_verify,omitempty"`
+}
+
+// Alert sink types.
+const (
+	AlertSinkWebhook   = "webhook"
+	AlertSinkSlack     = "slack"
+	AlertSinkPagerDuty = "pagerduty"
+)
+
+// SLOConfig defines the service level objectives of a service, evaluated
+// from its Beyla HTTP and gRPC metrics.
+type SLOConfig struct {
+	// Service is the service the objectives apply to.
+	Service string `yaml:"service"`
+
+	// Availability is the target percentage of requests that do not fail
+	// (HTTP status below 500, gRPC status OK), e.g. 99.9. 0 disables it.
+	Availability float64 `yaml:"availability,omitempty"`
+
+	// Latency is the target percentage of requests completing within
+	// LatencyThreshold, e.g. 99. 0 disables it. Latencies are known per
+	// histogram bucket, so the threshold should be a bucket boundary.
+	Latency          float64       `yaml:"latency,omitempty"`
+	LatencyThreshold time.Duration `yaml:"latency_threshold,omitempty"`
+
+	// Period is the compliance period the error budget is spread over.
+	// Default: 720h (30 days).
+	Period time.Duration `yaml:"period,omitempty"`
+}
+
+// ServiceGroupConfig defines a named set of services, e.g. the services on
+// the checkout path, that profile, debug and query commands target together
+// with --group.
+type ServiceGroupConfig struct {
+	// Name identifies the group: lowercase letters, digits, '.', '_' and '-'.
+	Name string `yaml:"name"`
+
+	// Services are the names of the services in the group.
+	Services []string `yaml:"services"`
+
+	Description string `yaml:"description,omitempty"`
+}
+
+// AnomalyDetectionConfig configures the colony job that learns the usual
+// latency and error rate of each service route at each hour of the day, and
+// scores recent traffic against them. Scores are reported by
+// `coral query anomalies`.
+type AnomalyDetectionConfig struct {
+	// Disabled turns anomaly detection off.
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Interval is how often routes are scored, and the length of the scored
+	// window. Default: 5m.
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// Lookback is the history baselines are learned from. Default: 168h
+	// (7 days).
+	Lookback time.Duration `yaml:"lookback,omitempty"`
+
+	// Sigma is the deviation from the baseline mean, in standard deviations,
+	// above which a metric is anomalous. Default: 3.
+	Sigma float64 `yaml:"sigma,omitempty"`
+}
+
+// QueryCacheConfig configures the colony cache of expensive query results:
+// historical profiles, the service topology and service summaries. Cached
+// results are dropped when new data they depend on is ingested.
+type QueryCacheConfig struct {
+	// Disabled turns the query cache off.
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Size is the number of results cached. Default: 256.
+	Size int `yaml:"size,omitempty"`
+
+	// TTL is how long a result is cached. Default: 30s.
+	TTL time.Duration `yaml:"ttl,omitempty"`
+}
+
+// DebugAggregationConfig configures the colony pipelines that roll up the
+// events of debug sessions into per-function, per-minute call counts, error
+// counts and latency percentiles. Debug results and function metrics read
+// the rollups instead of computing percentiles from the events.
+type DebugAggregationConfig struct {
+	// Disabled turns the aggregation pipelines off.
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Interval is how often events are rolled up. Default: 1m.
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// Lateness is how long after a minute ends its events are rolled up,
+	// leaving time for agents to push them. Default: 2m.
+	Lateness time.Duration `yaml:"lateness,omitempty"`
+
+	// Pipelines select the events that are rolled up. Default: one pipeline
+	// rolling up all events.
+	Pipelines []DebugAggregationPipelineConfig `yaml:"pipelines,omitempty"`
+}
+
+// DebugAggregationPipelineConfig selects debug events to roll up by service
+// and function name. Names are glob patterns, e.g. "checkout-*"; an empty
+// list matches all names.
+type DebugAggregationPipelineConfig struct {
+	Name      string   `yaml:"name"`
+	Services  []string `yaml:"services,omitempty"`
+	Functions []string `yaml:"functions,omitempty"`
+}
+
+// AlertingConfig configures alert evaluation and notification sinks. Alert
+// rules are managed with `coral alert rule` and stored in the colony database;
+// sinks are defined here because they hold credentials.
+type AlertingConfig struct {
+	// EvaluationInterval is how often alert rules are evaluated. Default: 30s.
+	EvaluationInterval time.Duration `yaml:"evaluation_interval,omitempty"`
+
+	// Sinks are the notification destinations alert rules refer to by name.
+	Sinks []AlertSinkConfig `yaml:"sinks,omitempty"`
+}
+
+// AlertSinkConfig is a notification destination for alerts.
+type AlertSinkConfig struct {
+	// Name identifies the sink in alert rules.
+	Name string `yaml:"name"`
+
+	// Type is "webhook", "slack" or "pagerduty".
+	Type string `yaml:"type"`
+
+	// URL is the webhook endpoint or Slack incoming webhook URL. For
+	// PagerDuty it overrides the Events API v2 endpoint.
+	URL string `yaml:"url,omitempty"`
+
+	// RoutingKey is the PagerDuty Events API v2 integration key.
+	RoutingKey string `yaml:"routing_key,omitempty"`
+
+	// Headers are added to webhook requests, e.g. for authentication.
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
 // MCPConfig contains MCP server configuration (RFD 004).
 type MCPConfig struct {
 	// Disabled controls whether the MCP server is enabled.
@@ -159,6 +427,11 @@ type MCPConfig struct {
 	// If empty, all tools are enabled.
 	EnabledTools []string `yaml:"enabled_tools,omitempty"`
 
+	// MaxResultBytes is the size of a tool result page. Larger results are
+	// split, and clients fetch the rest with the returned next_cursor.
+	// Default: 65536.
+	MaxResultBytes int `yaml:"max_result_bytes,omitempty"`
+
 	// Security settings.
 	Security MCPSecurityConfig `yaml:"security,omitempty"`
 }
@@ -169,8 +442,21 @@ type MCPSecurityConfig struct {
 	// (exec, shell, start_ebpf).
 	RequireRBACForActions bool `yaml:"require_rbac_for_actions,omitempty"`
 
-	// AuditEnabled enables auditing of all MCP tool calls.
+	// AuditEnabled records debug, profiling, exec and MCP tool call actions
+	// in the colony audit log.
 	AuditEnabled bool `yaml:"audit_enabled,omitempty"`
+
+	// RequireApproval lists the MCP tools whose calls the colony holds until
+	// a human approves them with 'coral colony mcp approvals' or the
+	// dashboard, e.g. coral_shell_exec, coral_container_exec and
+	// coral_attach_uprobe. coral_cli calls are matched by the tool their
+	// command replaced.
+	RequireApproval []string `yaml:"require_approval,omitempty"`
+
+	// ApprovalTimeout is how long a call waits for a decision before it is
+	// rejected.
+	// Default: 5m.
+	ApprovalTimeout time.Duration `yaml:"approval_timeout,omitempty"`
 }
 
 // RemoteConfig contains client-side connection settings for remote colonies.
@@ -209,7 +495,7 @@ type CAFingerprintConfig struct {
 
 // PublicEndpointConfig contains optional public HTTPS endpoint configuration (RFD 031).
 // When enabled, Colony exposes a public HTTPS endpoint in addition to the WireGuard mesh.
-// This enables CLI access without coral proxy, external integrations, and MCP SSE transport.
+// This enables CLI access without coral proxy, external integrations, and remote MCP clients.
 type PublicEndpointConfig struct {
 	// Enabled controls whether the public endpoint is active.
 	// Default: false (opt-in for security).
@@ -228,7 +514,7 @@ type PublicEndpointConfig struct {
 	// Required when Host is not "127.0.0.1" or "localhost".
 	TLS TLSConfig `yaml:"tls,omitempty"`
 
-	// MCP contains MCP-over-SSE configuration for AI assistant integration.
+	// MCP contains MCP-over-HTTP configuration for AI assistant integration.
 	MCP PublicMCPConfig `yaml:"mcp,omitempty"`
 
 	// Auth contains authentication configuration for the public endpoint.
@@ -260,16 +546,17 @@ type TLSConfig struct {
 	KeyFile string `yaml:"key,omitempty"`
 }
 
-// PublicMCPConfig contains MCP-over-SSE configuration for the public endpoint.
+// PublicMCPConfig contains MCP-over-HTTP configuration for the public endpoint.
 type PublicMCPConfig struct {
-	// Enabled controls whether MCP SSE endpoint is active.
+	// Enabled controls whether the MCP endpoint is active.
 	Enabled bool `yaml:"enabled"`
 
-	// Transport is the MCP transport type (currently only "sse" supported).
+	// Transport is the MCP transport type. Unused: the endpoint serves both
+	// Streamable HTTP and the older HTTP+SSE transport.
 	Transport string `yaml:"transport,omitempty"`
 
-	// Path is the URL path for the MCP SSE endpoint.
-	// Default: "/mcp/sse".
+	// Path is the URL path for the MCP endpoint.
+	// Default: "/mcp".
 	Path string `yaml:"path,omitempty"`
 }
 
@@ -307,6 +594,130 @@ type FunctionRegistryConfig struct {
 	Disabled bool `yaml:"disabled,omitempty"`
 }
 
+// RetentionConfig configures the colony retention manager, which deletes
+// expired rows from the debug, audit, history and Beyla rollup tables and
+// checkpoints the database. Raw Beyla metrics, telemetry, system metrics and
+// profile summaries keep their retention settings in their own sections.
+type RetentionConfig struct {
+	// Interval is how often expired rows are deleted. Default: 1h.
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// Tables overrides the TTL of individual tables, e.g. "debug_events: 72h".
+	// A TTL of 0 keeps the table's rows forever.
+	Tables map[string]time.Duration `yaml:"tables,omitempty"`
+}
+
+// AgentAuthConfig controls how agents authenticate when they register.
+// Agents holding a certificate from the colony CA register over mTLS on the
+// public endpoint, where the colony verifies their SPIFFE ID and checks the
+// certificate has not been revoked.
+type AgentAuthConfig struct {
+	// RequireCertificates rejects agents registering without a client
+	// certificate, i.e. with the colony secret alone. Default: false.
+	RequireCertificates bool `yaml:"require_certificates,omitempty" env:"CORAL_REQUIRE_AGENT_CERTIFICATES"`
+
+	// MinProtocolVersion rejects agents registering with an older protocol
+	// version (RFD 018). Agents that report none speak version 1. Default:
+	// 1, accept all agents.
+	MinProtocolVersion int `yaml:"min_protocol_version,omitempty" env:"CORAL_MIN_AGENT_PROTOCOL_VERSION"`
+
+	// PreviousColonySecret is the colony secret before the last rotation
+	// (`coral colony rotate-secret`), still accepted from agents until it
+	// expires. Managed by the colony.
+	PreviousColonySecret *PreviousColonySecret `yaml:"previous_colony_secret,omitempty"`
+}
+
+// PreviousColonySecret is a rotated-out colony secret in its grace period.
+type PreviousColonySecret struct {
+	Secret    string    `yaml:"secret"`
+	ExpiresAt time.Time `yaml:"expires_at"`
+}
+
+// StorageEncryptionConfig configures at-rest encryption of the colony
+// database, which holds captured function arguments and other sensitive
+// debug data. The database is kept encrypted with AES-256-GCM in the storage
+// directory; while the colony runs, DuckDB works on a decrypted copy in
+// WorkDir that is encrypted back on shutdown and every SealInterval.
+type StorageEncryptionConfig struct {
+	// Enabled turns on encryption. A database stored unencrypted is
+	// encrypted on the next shutdown.
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// Key is the base64-encoded 32-byte key. It is only read from the
+	// environment, never from the config file.
+	Key string `yaml:"-" env:"CORAL_STORAGE_ENCRYPTION_KEY"`
+
+	// KeyFile is a file holding the base64-encoded key.
+	KeyFile string `yaml:"key_file,omitempty" env:"CORAL_STORAGE_ENCRYPTION_KEY_FILE"`
+
+	// KeyCommand is a command printing the key, base64-encoded or raw, e.g.
+	// a KMS CLI decrypting a wrapped key. It runs on each colony start.
+	KeyCommand []string `yaml:"key_command,omitempty"`
+
+	// WorkDir holds the decrypted working copy of the database while the
+	// colony runs. Default: the storage path. Use a tmpfs so that no
+	// plaintext reaches the disk.
+	WorkDir string `yaml:"work_dir,omitempty" env:"CORAL_STORAGE_ENCRYPTION_WORK_DIR"`
+
+	// SealInterval is how often the working copy is encrypted back to the
+	// storage path, bounding what is lost if the colony crashes with WorkDir
+	// on a tmpfs. Default: 15m.
+	SealInterval time.Duration `yaml:"seal_interval,omitempty"`
+}
+
+// BandwidthConfig limits the bandwidth of data-plane transfers between the
+// colony and agents: poller and profile pulls, and uprobe event pushes.
+type BandwidthConfig struct {
+	// PerAgentKBps caps the transfer rate with each agent, in KiB per
+	// second. Default: 0 (unlimited).
+	PerAgentKBps int `yaml:"per_agent_kbps,omitempty" env:"CORAL_BANDWIDTH_PER_AGENT_KBPS"`
+
+	// GlobalKBps caps the transfer rate with all agents together, in KiB
+	// per second. Default: 0 (unlimited).
+	GlobalKBps int `yaml:"global_kbps,omitempty" env:"CORAL_BANDWIDTH_GLOBAL_KBPS"`
+
+	// DisableCompression stops the colony from asking agents for zstd
+	// compressed responses.
+	DisableCompression bool `yaml:"disable_compression,omitempty" env:"CORAL_BANDWIDTH_DISABLE_COMPRESSION"`
+}
+
+// ColdStorageConfig configures the object-storage offload tier. Raw Beyla
+// metrics older than After are exported per day to Parquet files under URL
+// and deleted from the colony database; metric queries read the files back
+// when their range extends into offloaded days. Offloaded files are never
+// deleted by the colony; use the bucket's lifecycle rules to expire them.
+type ColdStorageConfig struct {
+	// Enabled turns on offloading.
+	Enabled bool `yaml:"enabled,omitempty"`
+
+	// URL is the destination: "s3://bucket/prefix", "gs://bucket/prefix" or
+	// an absolute local directory.
+	URL string `yaml:"url,omitempty" env:"CORAL_COLD_STORAGE_URL"`
+
+	// After is the age at which whole days are offloaded. It must be shorter
+	// than the Beyla retention of the offloaded tables. Default: 7d.
+	After time.Duration `yaml:"after,omitempty"`
+
+	// Interval is how often data is offloaded. Default: 1h.
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// Tables are the raw tables to offload. Default: beyla_http_metrics,
+	// beyla_grpc_metrics and beyla_sql_metrics.
+	Tables []string `yaml:"tables,omitempty"`
+
+	// Region is the S3 region.
+	Region string `yaml:"region,omitempty" env:"CORAL_COLD_STORAGE_REGION"`
+
+	// Endpoint overrides the S3 endpoint, for S3-compatible stores such as
+	// MinIO or R2.
+	Endpoint string `yaml:"endpoint,omitempty" env:"CORAL_COLD_STORAGE_ENDPOINT"`
+
+	// AccessKeyID and SecretAccessKey authenticate to S3, or to GCS with
+	// HMAC keys.
+	AccessKeyID     string `yaml:"access_key_id,omitempty" env:"CORAL_COLD_STORAGE_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secret_access_key,omitempty" env:"CORAL_COLD_STORAGE_SECRET_ACCESS_KEY"`
+}
+
 // BeylaRetentionConfig contains retention periods for Beyla data.
 type BeylaRetentionConfig struct {
 	// HTTPDays is retention period for HTTP metrics (days).
@@ -377,6 +788,9 @@ type ProjectConfig struct {
 type DashboardConfig struct {
 	Port    int  `yaml:"port"`
 	Enabled bool `yaml:"enabled"`
+	// Host is the address the dashboard listens on. The dashboard has no
+	// authentication, so it defaults to localhost.
+	Host string `yaml:"host,omitempty"`
 }
 
 // ProjectStorage contains project-specific storage settings.
@@ -507,27 +921,54 @@ type CPUProfilingConfig struct {
 // ResolvedConfig is the final merged configuration after resolution.
 type ResolvedConfig struct {
 	ColonyID        string
-	ColonySecret    string // Deprecated
+	ColonySecret    string // Presented by agents when registering (RFD 002)
 	ApplicationName string
 	Environment     string
 	WireGuard       WireGuardConfig
 	StoragePath     string
 	DiscoveryURL    string
 	Dashboard       DashboardConfig
+
+	// ColonyDNS and StaticColony locate the colony without the discovery
+	// service. They are set for agents only.
+	ColonyDNS    string
+	StaticColony *StaticColonyConfig
+
+	// Labels are the labels the agent registers with. They are set for
+	// agents only.
+	Labels map[string]string
 }
 
 // AgentConfig represents agent-specific configuration (RFD 025).
 type AgentConfig struct {
 	Agent struct {
-		Runtime string `yaml:"runtime" env:"CORAL_AGENT_RUNTIME"` // auto, native, docker, kubernetes
+		Runtime string            `yaml:"runtime" env:"CORAL_AGENT_RUNTIME"`         // auto, native, docker, kubernetes
+		Labels  map[string]string `yaml:"labels,omitempty" env:"CORAL_AGENT_LABELS"` // Labels to register with, matched by selectors (format: key=value,...)
 		Colony  struct {
-			ID           string `yaml:"id" env:"CORAL_COLONY_ID"`
-			AutoDiscover bool   `yaml:"auto_discover" env:"CORAL_AUTO_DISCOVER"`
+			ID           string             `yaml:"id" env:"CORAL_COLONY_ID"`
+			AutoDiscover bool               `yaml:"auto_discover" env:"CORAL_AUTO_DISCOVER"`
+			DNS          string             `yaml:"dns,omitempty" env:"CORAL_COLONY_DNS"` // Locate the colony from DNS SRV/TXT records under this domain
+			Static       StaticColonyConfig `yaml:"static,omitempty"`                     // Locate the colony from config
 		} `yaml:"colony"`
 		NAT struct {
-			STUNServers []string `yaml:"stun_servers,omitempty" env:"CORAL_STUN_SERVERS"` // STUN servers for NAT traversal
-			EnableRelay bool     `yaml:"enable_relay,omitempty" env:"CORAL_ENABLE_RELAY"` // Enable relay fallback
+			STUNServers         []string `yaml:"stun_servers,omitempty" env:"CORAL_STUN_SERVERS"`                   // STUN servers for NAT traversal
+			EnableRelay         bool     `yaml:"enable_relay,omitempty" env:"CORAL_ENABLE_RELAY"`                   // Fall back to the colony's relay when UDP is blocked
+			DisableColonySTUN   bool     `yaml:"disable_colony_stun,omitempty" env:"CORAL_DISABLE_COLONY_STUN"`     // Skip the colony's STUN server (RFD 029)
+			DisableHolePunching bool     `yaml:"disable_hole_punching,omitempty" env:"CORAL_DISABLE_HOLE_PUNCHING"` // Don't ask the colony to punch through NAT
 		} `yaml:"nat,omitempty"`
+		WireGuard struct {
+			Userspace string `yaml:"userspace,omitempty" env:"CORAL_WIREGUARD_USERSPACE"` // auto, always, never: when to run the mesh on a userspace network stack
+		} `yaml:"wireguard,omitempty"`
+		Security struct {
+			RequireCapabilityTokens bool     `yaml:"require_capability_tokens,omitempty" env:"CORAL_REQUIRE_CAPABILITY_TOKENS"` // Shell and exec require a capability token minted by the colony
+			EBPFSigningKeys         []string `yaml:"ebpf_signing_keys,omitempty" env:"CORAL_EBPF_SIGNING_KEYS"`                 // Base64 Ed25519 public keys embedded eBPF objects are verified with
+			RequireSignedEBPF       bool     `yaml:"require_signed_ebpf,omitempty" env:"CORAL_REQUIRE_SIGNED_EBPF"`             // Refuse to load eBPF objects without a valid signature
+			MaxFileTransferBytes    int64    `yaml:"max_file_transfer_bytes,omitempty" env:"CORAL_MAX_FILE_TRANSFER_BYTES"`     // Largest file coral cp copies to or from the agent (default: 1 GiB)
+		} `yaml:"security,omitempty"`
+		Update struct {
+			Enabled           bool   `yaml:"enabled,omitempty" env:"CORAL_AGENT_UPDATE_ENABLED"`           // Install agent releases rolled out by the colony
+			MaintenanceWindow string `yaml:"maintenance_window,omitempty" env:"CORAL_AGENT_UPDATE_WINDOW"` // Daily UTC window releases are installed in, e.g. "02:00-04:00" (default: any time)
+		} `yaml:"update,omitempty"`
 		Bootstrap         BootstrapConfig `yaml:"bootstrap,omitempty"` // RFD 048
 		HeartbeatInterval time.Duration   `yaml:"heartbeat_interval,omitempty" env:"CORAL_HEARTBEAT_INTERVAL"`
 	} `yaml:"agent"`
@@ -544,15 +985,137 @@ type AgentConfig struct {
 		} `yaml:"filters,omitempty"`
 	} `yaml:"telemetry,omitempty"`
 	Services []struct {
-		Name           string `yaml:"name"`
-		Port           int    `yaml:"port"`
-		HealthEndpoint string `yaml:"health_endpoint,omitempty"`
-		Type           string `yaml:"type,omitempty"`
+		Name           string            `yaml:"name"`
+		Port           int               `yaml:"port"`
+		HealthEndpoint string            `yaml:"health_endpoint,omitempty"`
+		Type           string            `yaml:"type,omitempty"`
+		Labels         map[string]string `yaml:"labels,omitempty"`
 	} `yaml:"services"`
 	Beyla               BeylaConfig               `yaml:"beyla,omitempty"`
 	SystemMetrics       SystemMetricsConfig       `yaml:"system_metrics,omitempty"`
 	Debug               DebugConfig               `yaml:"debug,omitempty"`
 	ContinuousProfiling ContinuousProfilingConfig `yaml:"continuous_profiling,omitempty"` // RFD 072
+	ServiceDiscovery    ServiceDiscoveryConfig    `yaml:"service_discovery,omitempty"`
+	ResourceLimits      ResourceLimitsConfig      `yaml:"resource_limits,omitempty"`
+	CoreDumps           CoreDumpConfig            `yaml:"core_dumps,omitempty"`
+	Logs                LogsConfig                `yaml:"logs,omitempty"`
+}
+
+// LogsConfig configures collection of service logs for coral tail.
+type LogsConfig struct {
+	// Enabled turns on log collection (default: false).
+	Enabled bool `yaml:"enabled" env:"CORAL_LOGS_ENABLED"`
+
+	// Services are glob patterns of the registered services whose stdout,
+	// journal or container logs are collected. Services matching none are
+	// not collected.
+	Services []string `yaml:"services,omitempty" env:"CORAL_LOGS_SERVICES"`
+
+	// Files are log files collected in addition, attributed to a service.
+	Files []LogFileConfig `yaml:"files,omitempty"`
+
+	// Interval is how often new lines are read and pushed (default: 1s).
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+// LogFileConfig is a log file collected as the logs of a service.
+type LogFileConfig struct {
+	Service string `yaml:"service"`
+	Path    string `yaml:"path"`
+}
+
+// CoreDumpConfig configures capture of core dumps from crashed services.
+type CoreDumpConfig struct {
+	// Enabled turns on core dump capture (default: false).
+	Enabled bool `yaml:"enabled" env:"CORAL_CORE_DUMPS_ENABLED"`
+
+	// Mode is the capture source: "auto", "core_pattern" or "coredumpctl".
+	Mode string `yaml:"mode,omitempty"`
+
+	// Directory stores compressed core dumps (default: ~/.coral/agent/coredumps).
+	Directory string `yaml:"directory,omitempty" env:"CORAL_CORE_DUMPS_DIR"`
+
+	// MaxTotalMB is the disk quota for stored core dumps; the oldest are
+	// evicted first (default: 2048).
+	MaxTotalMB int `yaml:"max_total_mb,omitempty"`
+
+	// MaxDumpMB truncates larger core dumps (default: 1024).
+	MaxDumpMB int `yaml:"max_dump_mb,omitempty"`
+
+	// PollInterval is how often new core dumps are collected (default: 5s).
+	PollInterval time.Duration `yaml:"poll_interval,omitempty"`
+}
+
+// ResourceLimitsConfig bounds the agent's own CPU and memory usage. When usage
+// nears a limit the agent sheds load in stages: continuous profiling is paused
+// first, then uprobe sampling is reduced. Stages are restored once usage drops.
+type ResourceLimitsConfig struct {
+	// MaxCPUPercent is the CPU limit in percent of one core (0 = unlimited).
+	MaxCPUPercent float64 `yaml:"max_cpu_percent,omitempty" env:"CORAL_AGENT_MAX_CPU_PERCENT"`
+
+	// MaxMemoryMB is the resident memory limit (0 = unlimited).
+	MaxMemoryMB int `yaml:"max_memory_mb,omitempty" env:"CORAL_AGENT_MAX_MEMORY_MB"`
+
+	// CheckInterval is how often usage is sampled (default: 10s).
+	CheckInterval time.Duration `yaml:"check_interval,omitempty"`
+
+	// HighWatermark is the fraction of a limit at which the next stage is
+	// shed (default: 0.9).
+	HighWatermark float64 `yaml:"high_watermark,omitempty"`
+
+	// LowWatermark is the fraction of a limit below which the last shed
+	// stage is restored (default: 0.7).
+	LowWatermark float64 `yaml:"low_watermark,omitempty"`
+
+	// UprobeSampleDivisor is the factor uprobe sampling is reduced by while
+	// the uprobe stage is shed (default: 10, i.e. keep 1 in 10 events).
+	UprobeSampleDivisor uint32 `yaml:"uprobe_sample_divisor,omitempty"`
+}
+
+// ServiceDiscoveryConfig configures automatic registration of long-running
+// processes as services from their systemd unit or container cgroup.
+type ServiceDiscoveryConfig struct {
+	Enabled bool `yaml:"enabled" env:"CORAL_SERVICE_DISCOVERY_ENABLED"`
+
+	// Include and Exclude are glob patterns matched against the service name
+	// and the unit or container name (e.g. "nginx*", "*.service"). An empty
+	// Include matches everything; Exclude always wins. Setting Exclude
+	// replaces the default list of system units.
+	Include []string `yaml:"include,omitempty" env:"CORAL_SERVICE_DISCOVERY_INCLUDE"`
+	Exclude []string `yaml:"exclude,omitempty" env:"CORAL_SERVICE_DISCOVERY_EXCLUDE"`
+
+	// Interval is how often cgroups are scanned (default: 30s).
+	Interval time.Duration `yaml:"interval,omitempty" env:"CORAL_SERVICE_DISCOVERY_INTERVAL"`
+
+	// MinUptime is how long a unit or container must run before it is
+	// registered, so short-lived jobs are ignored (default: 1m).
+	MinUptime time.Duration `yaml:"min_uptime,omitempty" env:"CORAL_SERVICE_DISCOVERY_MIN_UPTIME"`
+
+	// ServiceLabel is the container label holding the service name. Falls
+	// back to the Compose service label, then the container name.
+	ServiceLabel string `yaml:"service_label,omitempty"`
+
+	// DockerRoot is the Docker data directory (default: /var/lib/docker).
+	DockerRoot string `yaml:"docker_root,omitempty"`
+}
+
+// StaticColonyConfig describes the colony's mesh identity so that agents can
+// connect without the discovery service, e.g. in air-gapped environments.
+type StaticColonyConfig struct {
+	// Pubkey is the colony's WireGuard public key (base64). Static colony
+	// configuration is used when it is set.
+	Pubkey string `yaml:"pubkey,omitempty" env:"CORAL_COLONY_PUBKEY"`
+
+	// Endpoints are the colony's WireGuard endpoints (host:port).
+	Endpoints []string `yaml:"endpoints,omitempty" env:"CORAL_COLONY_ENDPOINTS"`
+
+	// MeshIPv4 and MeshIPv6 are the colony's mesh addresses.
+	MeshIPv4 string `yaml:"mesh_ipv4,omitempty" env:"CORAL_COLONY_MESH_IPV4"`
+	MeshIPv6 string `yaml:"mesh_ipv6,omitempty" env:"CORAL_COLONY_MESH_IPV6"`
+
+	// ConnectPort is the colony's Buf Connect port on the mesh.
+	// Default: 9000.
+	ConnectPort uint32 `yaml:"connect_port,omitempty" env:"CORAL_COLONY_CONNECT_PORT"`
 }
 
 // BootstrapConfig contains certificate bootstrap configuration (RFD 048).
@@ -643,7 +1206,60 @@ type DebugConfig struct {
 	BPF struct {
 		MapSize         int `yaml:"map_size"`
 		PerfBufferPages int `yaml:"perf_buffer_pages"`
+
+		// BTFPath is an external vmlinux BTF file for CO-RE relocations on
+		// kernels without /sys/kernel/btf/vmlinux (e.g. from BTFHub).
+		BTFPath string `yaml:"btf_path,omitempty" env:"CORAL_BTF_PATH"`
 	} `yaml:"bpf"`
+
+	// EventStore persists uprobe events to the agent's local DuckDB so they
+	// survive agent restarts and colony outages until the colony backfills them.
+	EventStore struct {
+		Disabled bool `yaml:"disabled" env:"CORAL_DEBUG_EVENT_STORE_DISABLED"`
+
+		// MaxEvents bounds the on-disk ring; the oldest events are dropped first.
+		MaxEvents int `yaml:"max_events" env:"CORAL_DEBUG_EVENT_STORE_MAX_EVENTS"`
+
+		// FlushInterval controls how often buffered events are written to disk.
+		FlushInterval time.Duration `yaml:"flush_interval"`
+	} `yaml:"event_store"`
+
+	// Redaction masks credentials and personal data in captured uprobe
+	// arguments and return values and in shell exec output before they
+	// leave the host.
+	Redaction RedactionConfig `yaml:"redaction,omitempty"`
+
+	// TLSCapture allows debug sessions to capture the plaintext of TLS
+	// connections with uprobes on the services' TLS libraries. Off by
+	// default, since it exposes decrypted traffic.
+	TLSCapture struct {
+		Enabled bool `yaml:"enabled" env:"CORAL_DEBUG_TLS_CAPTURE_ENABLED"`
+	} `yaml:"tls_capture,omitempty"`
+}
+
+// RedactionConfig configures the agent's redaction engine. Patterns and fields
+// are added to the built-in rules.
+type RedactionConfig struct {
+	Disabled bool `yaml:"disabled,omitempty" env:"CORAL_REDACTION_DISABLED"`
+
+	// Patterns are regular expressions whose matches are masked.
+	Patterns []string `yaml:"patterns,omitempty"`
+
+	// Fields are names whose values are masked: argument names and keys of
+	// key=value or key: value pairs in output. A name matches when it contains
+	// a field, ignoring case and separators.
+	Fields []string `yaml:"fields,omitempty"`
+
+	// Services overrides the rules per service name.
+	Services map[string]ServiceRedactionConfig `yaml:"services,omitempty"`
+}
+
+// ServiceRedactionConfig overrides redaction for one service. Its patterns and
+// fields are added to the agent-wide ones.
+type ServiceRedactionConfig struct {
+	Disabled bool     `yaml:"disabled,omitempty"`
+	Patterns []string `yaml:"patterns,omitempty"`
+	Fields   []string `yaml:"fields,omitempty"`
 }
 
 // ResolveMeshSubnet resolves the mesh subnet to use, with the following precedence:
@@ -1,11 +1,23 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"fmt"
 	"net"
+	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
+
+	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/protocol"
 )
 
+// serviceGroupName is the format of service group names.
+var serviceGroupName = regexp.MustCompile(`^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$`)
+
 // Validator is the interface for validating configuration.
 type Validator interface {
 	Validate() error
@@ -164,6 +176,117 @@ func (c *ColonyConfig) Validate() error {
 		})
 	}
 
+	if c.Bandwidth.PerAgentKBps < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "bandwidth.per_agent_kbps",
+			Message: "per-agent bandwidth limit cannot be negative",
+		})
+	}
+
+	if c.Bandwidth.GlobalKBps < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "bandwidth.global_kbps",
+			Message: "global bandwidth limit cannot be negative",
+		})
+	}
+
+	if v := c.AgentAuth.MinProtocolVersion; v < 0 || v > protocol.Version {
+		errors = append(errors, ValidationError{
+			Field:   "agent_auth.min_protocol_version",
+			Message: fmt.Sprintf("minimum protocol version must be between 1 and %d", protocol.Version),
+		})
+	}
+
+	sloServices := make(map[string]bool, len(c.SLOs))
+	for i, slo := range c.SLOs {
+		field := fmt.Sprintf("slos[%d]", i)
+		switch {
+		case slo.Service == "":
+			errors = append(errors, ValidationError{Field: field + ".service", Message: "service is required"})
+		case sloServices[slo.Service]:
+			errors = append(errors, ValidationError{Field: field + ".service", Message: fmt.Sprintf("duplicate SLO for service %s", slo.Service)})
+		}
+		sloServices[slo.Service] = true
+
+		if slo.Availability == 0 && slo.Latency == 0 {
+			errors = append(errors, ValidationError{Field: field, Message: "availability or latency target is required"})
+		}
+		if slo.Availability < 0 || slo.Availability >= 100 {
+			errors = append(errors, ValidationError{Field: field + ".availability", Message: "availability target must be between 0 and 100 (exclusive)"})
+		}
+		if slo.Latency < 0 || slo.Latency >= 100 {
+			errors = append(errors, ValidationError{Field: field + ".latency", Message: "latency target must be between 0 and 100 (exclusive)"})
+		}
+		if slo.Latency > 0 && slo.LatencyThreshold <= 0 {
+			errors = append(errors, ValidationError{Field: field + ".latency_threshold", Message: "latency threshold is required with a latency target"})
+		}
+		if slo.Period < 0 {
+			errors = append(errors, ValidationError{Field: field + ".period", Message: "period cannot be negative"})
+		}
+	}
+
+	groupNames := make(map[string]bool, len(c.ServiceGroups))
+	for i, group := range c.ServiceGroups {
+		field := fmt.Sprintf("service_groups[%d]", i)
+		switch {
+		case !serviceGroupName.MatchString(group.Name):
+			errors = append(errors, ValidationError{Field: field + ".name", Message: fmt.Sprintf("invalid group name %q: use lowercase letters, digits, '.', '_' and '-'", group.Name)})
+		case groupNames[group.Name]:
+			errors = append(errors, ValidationError{Field: field + ".name", Message: fmt.Sprintf("duplicate service group %s", group.Name)})
+		}
+		groupNames[group.Name] = true
+
+		if len(group.Services) == 0 {
+			errors = append(errors, ValidationError{Field: field + ".services", Message: "at least one service is required"})
+		}
+		for j, service := range group.Services {
+			if service == "" {
+				errors = append(errors, ValidationError{Field: fmt.Sprintf("%s.services[%d]", field, j), Message: "service name cannot be empty"})
+			}
+		}
+	}
+
+	if d := c.AnomalyDetection; !d.Disabled {
+		if d.Interval != 0 && d.Interval < constants.MinAnomalyDetectionInterval {
+			errors = append(errors, ValidationError{Field: "anomaly_detection.interval", Message: fmt.Sprintf("interval must be at least %s", constants.MinAnomalyDetectionInterval)})
+		}
+		if d.Lookback != 0 && d.Lookback < constants.MinAnomalyLookback {
+			errors = append(errors, ValidationError{Field: "anomaly_detection.lookback", Message: fmt.Sprintf("lookback must be at least %s", constants.MinAnomalyLookback)})
+		}
+		if d.Sigma < 0 {
+			errors = append(errors, ValidationError{Field: "anomaly_detection.sigma", Message: "sigma cannot be negative"})
+		}
+	}
+
+	if q := c.QueryCache; !q.Disabled {
+		if q.Size < 0 {
+			errors = append(errors, ValidationError{Field: "query_cache.size", Message: "size cannot be negative"})
+		}
+		if q.TTL < 0 {
+			errors = append(errors, ValidationError{Field: "query_cache.ttl", Message: "ttl cannot be negative"})
+		}
+	}
+
+	if a := c.DebugAggregation; !a.Disabled {
+		if a.Interval != 0 && a.Interval < constants.MinDebugAggregationInterval {
+			errors = append(errors, ValidationError{Field: "debug_aggregation.interval", Message: fmt.Sprintf("interval must be at least %s", constants.MinDebugAggregationInterval)})
+		}
+		if a.Lateness < 0 {
+			errors = append(errors, ValidationError{Field: "debug_aggregation.lateness", Message: "lateness cannot be negative"})
+		}
+		pipelineNames := make(map[string]bool)
+		for i, p := range a.Pipelines {
+			field := fmt.Sprintf("debug_aggregation.pipelines[%d]", i)
+			switch {
+			case p.Name == "":
+				errors = append(errors, ValidationError{Field: field + ".name", Message: "name is required"})
+			case pipelineNames[p.Name]:
+				errors = append(errors, ValidationError{Field: field + ".name", Message: fmt.Sprintf("duplicate pipeline %s", p.Name)})
+			}
+			pipelineNames[p.Name] = true
+		}
+	}
+
 	if len(errors) > 0 {
 		return &MultiValidationError{Errors: errors}
 	}
@@ -314,6 +437,37 @@ func (c *AgentConfig) Validate() error {
 		})
 	}
 
+	// Validate static colony configuration
+	static := c.Agent.Colony.Static
+	if static.Pubkey != "" {
+		if c.Agent.Colony.DNS != "" {
+			errors = append(errors, ValidationError{
+				Field:   "agent.colony.dns",
+				Message: "dns and static colony configuration are mutually exclusive",
+			})
+		}
+		if len(static.Endpoints) == 0 {
+			errors = append(errors, ValidationError{
+				Field:   "agent.colony.static.endpoints",
+				Message: "at least one colony endpoint is required",
+			})
+		}
+		for _, ep := range static.Endpoints {
+			if _, _, err := net.SplitHostPort(ep); err != nil {
+				errors = append(errors, ValidationError{
+					Field:   "agent.colony.static.endpoints",
+					Message: fmt.Sprintf("invalid endpoint %q: must be host:port", ep),
+				})
+			}
+		}
+		if ip := net.ParseIP(static.MeshIPv4); ip == nil || ip.To4() == nil {
+			errors = append(errors, ValidationError{
+				Field:   "agent.colony.static.mesh_ipv4",
+				Message: "a valid colony mesh IPv4 address is required",
+			})
+		}
+	}
+
 	// Validate telemetry endpoints
 	if !c.Telemetry.Disabled {
 		if c.Telemetry.GRPCEndpoint == "" {
@@ -346,6 +500,30 @@ func (c *AgentConfig) Validate() error {
 		})
 	}
 
+	// Validate eBPF object signing keys
+	for _, key := range c.Agent.Security.EBPFSigningKeys {
+		if decoded, err := base64.StdEncoding.DecodeString(key); err != nil || len(decoded) != ed25519.PublicKeySize {
+			errors = append(errors, ValidationError{
+				Field:   "agent.security.ebpf_signing_keys",
+				Message: fmt.Sprintf("invalid key %q: must be a base64-encoded Ed25519 public key", key),
+			})
+		}
+	}
+	if c.Agent.Security.RequireSignedEBPF && len(c.Agent.Security.EBPFSigningKeys) == 0 {
+		errors = append(errors, ValidationError{
+			Field:   "agent.security.require_signed_ebpf",
+			Message: "ebpf_signing_keys are required to verify eBPF object signatures",
+		})
+	}
+
+	// Validate the agent update maintenance window
+	if window := c.Agent.Update.MaintenanceWindow; window != "" && !validMaintenanceWindow(window) {
+		errors = append(errors, ValidationError{
+			Field:   "agent.update.maintenance_window",
+			Message: fmt.Sprintf("invalid window %q: must be HH:MM-HH:MM in UTC, e.g. 02:00-04:00", window),
+		})
+	}
+
 	// Validate debug session limits
 	if c.Debug.Limits.MaxConcurrentSessions <= 0 {
 		errors = append(errors, ValidationError{
@@ -361,6 +539,26 @@ func (c *AgentConfig) Validate() error {
 		})
 	}
 
+	// Validate redaction patterns
+	for _, p := range c.Debug.Redaction.Patterns {
+		if _, err := regexp.Compile(p); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "debug.redaction.patterns",
+				Message: fmt.Sprintf("invalid pattern %q: %v", p, err),
+			})
+		}
+	}
+	for name, svc := range c.Debug.Redaction.Services {
+		for _, p := range svc.Patterns {
+			if _, err := regexp.Compile(p); err != nil {
+				errors = append(errors, ValidationError{
+					Field:   fmt.Sprintf("debug.redaction.services.%s.patterns", name),
+					Message: fmt.Sprintf("invalid pattern %q: %v", p, err),
+				})
+			}
+		}
+	}
+
 	// Validate CPU profiling frequency
 	if c.ContinuousProfiling.CPU.FrequencyHz <= 0 {
 		errors = append(errors, ValidationError{
@@ -369,8 +567,95 @@ func (c *AgentConfig) Validate() error {
 		})
 	}
 
+	// Validate resource limits
+	if c.ResourceLimits.MaxCPUPercent < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "resource_limits.max_cpu_percent",
+			Message: "CPU limit must not be negative",
+		})
+	}
+
+	if c.ResourceLimits.MaxMemoryMB < 0 {
+		errors = append(errors, ValidationError{
+			Field:   "resource_limits.max_memory_mb",
+			Message: "memory limit must not be negative",
+		})
+	}
+
+	if c.ResourceLimits.MaxCPUPercent > 0 || c.ResourceLimits.MaxMemoryMB > 0 {
+		low, high := c.ResourceLimits.LowWatermark, c.ResourceLimits.HighWatermark
+		if low <= 0 || high > 1.0 || low >= high {
+			errors = append(errors, ValidationError{
+				Field:   "resource_limits.low_watermark",
+				Message: "watermarks must satisfy 0 < low_watermark < high_watermark <= 1.0",
+			})
+		}
+	}
+
+	// Validate core dump capture
+	if c.CoreDumps.Enabled {
+		switch c.CoreDumps.Mode {
+		case "", "auto", "core_pattern", "coredumpctl":
+		default:
+			errors = append(errors, ValidationError{
+				Field:   "core_dumps.mode",
+				Message: fmt.Sprintf("must be auto, core_pattern or coredumpctl, got %q", c.CoreDumps.Mode),
+			})
+		}
+
+		if c.CoreDumps.MaxTotalMB < 0 || c.CoreDumps.MaxDumpMB < 0 {
+			errors = append(errors, ValidationError{
+				Field:   "core_dumps.max_total_mb",
+				Message: "core dump quotas must not be negative",
+			})
+		}
+	}
+
+	// Validate log collection
+	if c.Logs.Enabled {
+		if len(c.Logs.Services) == 0 && len(c.Logs.Files) == 0 {
+			errors = append(errors, ValidationError{
+				Field:   "logs.services",
+				Message: "log collection requires services or files to collect",
+			})
+		}
+
+		for i, pattern := range c.Logs.Services {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errors = append(errors, ValidationError{
+					Field:   fmt.Sprintf("logs.services[%d]", i),
+					Message: fmt.Sprintf("invalid pattern %q: %v", pattern, err),
+				})
+			}
+		}
+
+		for i, f := range c.Logs.Files {
+			if f.Service == "" || !filepath.IsAbs(f.Path) {
+				errors = append(errors, ValidationError{
+					Field:   fmt.Sprintf("logs.files[%d]", i),
+					Message: "a service and an absolute path are required",
+				})
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return &MultiValidationError{Errors: errors}
 	}
 	return nil
 }
+
+// validMaintenanceWindow reports whether window is a non-empty daily window
+// of the form HH:MM-HH:MM.
+func validMaintenanceWindow(window string) bool {
+	from, to, ok := strings.Cut(window, "-")
+	if !ok {
+		return false
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(to))
+	return err == nil && !start.Equal(end)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGlobalConfig_Validate(t *testing.T) {
@@ -255,6 +256,149 @@ func TestColonyConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "mesh ID must match colony ID",
 		},
+		{
+			name: "negative per-agent bandwidth",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.Bandwidth.PerAgentKBps = -1
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "per-agent bandwidth limit cannot be negative",
+		},
+		{
+			name: "negative global bandwidth",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.Bandwidth.GlobalKBps = -1
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "global bandwidth limit cannot be negative",
+		},
+		{
+			name: "unknown minimum protocol version",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.AgentAuth.MinProtocolVersion = 99
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "minimum protocol version must be between 1 and",
+		},
+		{
+			name: "valid SLOs",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.Services.ConnectPort = 9000
+				cfg.Services.DashboardPort = 3000
+				cfg.SLOs = []SLOConfig{
+					{Service: "api", Availability: 99.9},
+					{Service: "checkout", Latency: 99, LatencyThreshold: 250 * time.Millisecond, Period: 7 * 24 * time.Hour},
+				}
+				return cfg
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "SLO without target",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.SLOs = []SLOConfig{{Service: "api"}}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "availability or latency target is required",
+		},
+		{
+			name: "latency SLO without threshold",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.SLOs = []SLOConfig{{Service: "api", Latency: 99}}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "latency threshold is required",
+		},
+		{
+			name: "duplicate SLO",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.SLOs = []SLOConfig{{Service: "api", Availability: 99}, {Service: "api", Availability: 100}}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "duplicate SLO for service api",
+		},
+		{
+			name: "valid service groups",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.Services.ConnectPort = 9000
+				cfg.Services.DashboardPort = 3000
+				cfg.ServiceGroups = []ServiceGroupConfig{
+					{Name: "checkout-path", Services: []string{"api", "payments", "inventory"}},
+					{Name: "backoffice", Services: []string{"admin"}},
+				}
+				return cfg
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "duplicate service group",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.ServiceGroups = []ServiceGroupConfig{
+					{Name: "checkout", Services: []string{"api"}},
+					{Name: "checkout", Services: []string{"payments"}},
+				}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "duplicate service group checkout",
+		},
+		{
+			name: "service group without services",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.ServiceGroups = []ServiceGroupConfig{{Name: "Checkout Path"}}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "at least one service is required",
+		},
+		{
+			name: "anomaly detection lookback too short",
+			cfg: func() *ColonyConfig {
+				cfg := DefaultColonyConfig("test-colony", "test-app", "dev")
+				cfg.WireGuard.PrivateKey = "test-private-key"
+				cfg.WireGuard.PublicKey = "test-public-key"
+				cfg.AnomalyDetection.Lookback = 6 * time.Hour
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "lookback must be at least 24h0m0s",
+		},
 	}
 
 	for _, tt := range tests {
@@ -420,6 +564,44 @@ func TestAgentConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "colony ID is required when auto_discover is false",
 		},
+		{
+			name: "valid static colony",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Agent.Colony.Static = StaticColonyConfig{
+					Pubkey:    "colony-pubkey",
+					Endpoints: []string{"colony.example.com:41580"},
+					MeshIPv4:  "100.64.0.1",
+				}
+				return cfg
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "static colony without endpoints",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Agent.Colony.Static = StaticColonyConfig{Pubkey: "colony-pubkey", MeshIPv4: "100.64.0.1"}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "at least one colony endpoint is required",
+		},
+		{
+			name: "static colony and DNS",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Agent.Colony.DNS = "colony.example.com"
+				cfg.Agent.Colony.Static = StaticColonyConfig{
+					Pubkey:    "colony-pubkey",
+					Endpoints: []string{"colony.example.com:41580"},
+					MeshIPv4:  "100.64.0.1",
+				}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "dns and static colony configuration are mutually exclusive",
+		},
 		{
 			name: "invalid sample rate (negative)",
 			cfg: func() *AgentConfig {
@@ -450,6 +632,56 @@ func TestAgentConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "sample rate must be between 0.0 and 1.0",
 		},
+		{
+			name: "invalid eBPF signing key",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Agent.Security.EBPFSigningKeys = []string{"c2hvcnQ="}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "must be a base64-encoded Ed25519 public key",
+		},
+		{
+			name: "require signed eBPF without keys",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Agent.Security.RequireSignedEBPF = true
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "ebpf_signing_keys are required",
+		},
+		{
+			name: "require signed eBPF",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Agent.Security.EBPFSigningKeys = []string{"pZzwbrxNzUGW9Cc8a3LCrs/mz7vZI7/uElDCDNmZCLE="}
+				cfg.Agent.Security.RequireSignedEBPF = true
+				return cfg
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "invalid update maintenance window",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Agent.Update.MaintenanceWindow = "2am-4am"
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "must be HH:MM-HH:MM in UTC",
+		},
+		{
+			name: "update maintenance window spanning midnight",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Agent.Update.Enabled = true
+				cfg.Agent.Update.MaintenanceWindow = "23:00-01:00"
+				return cfg
+			}(),
+			wantErr: false,
+		},
 		{
 			name: "invalid max concurrent sessions",
 			cfg: func() *AgentConfig {
@@ -460,6 +692,18 @@ func TestAgentConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "max concurrent sessions must be positive",
 		},
+		{
+			name: "invalid redaction pattern",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Debug.Redaction.Services = map[string]ServiceRedactionConfig{
+					"api": {Patterns: []string{`card=(\d+`}},
+				}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  `invalid pattern "card=(\\d+"`,
+		},
 		{
 			name: "invalid CPU profiling frequency",
 			cfg: func() *AgentConfig {
@@ -470,6 +714,37 @@ func TestAgentConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "CPU profiling frequency must be positive",
 		},
+		{
+			name: "log collection without services or files",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Logs.Enabled = true
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "log collection requires services or files to collect",
+		},
+		{
+			name: "log file with relative path",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Logs.Enabled = true
+				cfg.Logs.Files = []LogFileConfig{{Service: "worker", Path: "worker.log"}}
+				return cfg
+			}(),
+			wantErr: true,
+			errMsg:  "a service and an absolute path are required",
+		},
+		{
+			name: "log collection of service patterns",
+			cfg: func() *AgentConfig {
+				cfg := DefaultAgentConfig()
+				cfg.Logs.Enabled = true
+				cfg.Logs.Services = []string{"api*", "worker"}
+				return cfg
+			}(),
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -35,6 +35,10 @@ var (
 	// DefaultSTUNServer is the default STUN server for NAT traversal.
 	DefaultSTUNServer = "stun.cloudflare.com:3478"
 
+	// DefaultSTUNRateLimit is the number of STUN binding requests per minute
+	// a colony answers for each source IP (RFD 029).
+	DefaultSTUNRateLimit = 60
+
 	// DefaultWireGuardPort is the default WireGuard peering port for colonies.
 	DefaultWireGuardPort = 41580
 
@@ -42,6 +46,20 @@ var (
 
 	// DefaultWireGuardMTU is default MTU for WireGuard (1500 - 80 overhead).
 	DefaultWireGuardMTU = 1420
+
+	// DefaultHolePunchDelay is how far ahead the colony schedules the start of
+	// a hole punching attempt, so that both peers start probing together.
+	DefaultHolePunchDelay = 500 * time.Millisecond
+
+	// DefaultHolePunchInterval is the interval between probe rounds.
+	DefaultHolePunchInterval = 200 * time.Millisecond
+
+	// DefaultHolePunchTimeout bounds a hole punching attempt.
+	DefaultHolePunchTimeout = 10 * time.Second
+
+	// DefaultRelayDirectRetryInterval is how often a relayed agent retries
+	// hole punching to get back to a UDP path.
+	DefaultRelayDirectRetryInterval = 5 * time.Minute
 )
 
 // Service Ports.
@@ -49,6 +67,10 @@ const (
 	// DefaultDashboardPort is the default port for the dashboard.
 	DefaultDashboardPort = 3000
 
+	// DefaultDashboardHost is the default listen address for the dashboard.
+	// The dashboard has no authentication, so it is localhost-only by default.
+	DefaultDashboardHost = "127.0.0.1"
+
 	// DefaultPublicEndpointPort is the default port for the public HTTPS endpoint (RFD 031).
 	DefaultPublicEndpointPort = 8443
 
@@ -215,6 +237,65 @@ const (
 
 	// DefaultBeylaTraceRetentionDays is the default retention period for Beyla traces.
 	DefaultBeylaTraceRetentionDays = 7
+
+	// DefaultRetentionInterval is how often the colony retention manager runs.
+	DefaultRetentionInterval = 1 * time.Hour
+
+	// DefaultDebugEventsRetention is how long uprobe events of debug sessions are kept.
+	DefaultDebugEventsRetention = 7 * 24 * time.Hour
+
+	// DefaultDebugSessionsRetention is how long debug sessions are kept after they expire.
+	DefaultDebugSessionsRetention = 7 * 24 * time.Hour
+
+	// DefaultFunctionMetricsRetention is the default retention for per-function metrics.
+	DefaultFunctionMetricsRetention = 7 * 24 * time.Hour
+
+	// DefaultCorrelationTriggersRetention is the default retention for correlation triggers.
+	DefaultCorrelationTriggersRetention = 7 * 24 * time.Hour
+
+	// DefaultAgentHistoryRetention is the default retention for agent history events.
+	DefaultAgentHistoryRetention = 30 * 24 * time.Hour
+
+	// DefaultAuditLogRetention is the default retention for audit log entries.
+	DefaultAuditLogRetention = 90 * 24 * time.Hour
+
+	// DefaultMCPToolCallsRetention is the default retention for the MCP tool call history.
+	DefaultMCPToolCallsRetention = 30 * 24 * time.Hour
+
+	// DefaultShellRecordingsRetention is the default retention for recordings of
+	// shell and exec sessions run on agents.
+	DefaultShellRecordingsRetention = 90 * 24 * time.Hour
+
+	// DefaultAnomalyScoresRetention is the default retention for route anomaly scores.
+	DefaultAnomalyScoresRetention = 14 * 24 * time.Hour
+
+	// DefaultDebugFunctionStatsRetention is the default retention for the
+	// per-minute rollups of debug events.
+	DefaultDebugFunctionStatsRetention = 30 * 24 * time.Hour
+
+	// DefaultRollup1mRetention is the default retention for 1-minute Beyla metric rollups.
+	DefaultRollup1mRetention = 30 * 24 * time.Hour
+
+	// DefaultRollup10mRetention is the default retention for 10-minute Beyla metric rollups.
+	DefaultRollup10mRetention = 90 * 24 * time.Hour
+
+	// DefaultRollup1hRetention is the default retention for 1-hour Beyla metric rollups.
+	DefaultRollup1hRetention = 365 * 24 * time.Hour
+
+	// DefaultColdStorageAfter is the age after which raw Beyla metrics are
+	// offloaded to cold storage.
+	DefaultColdStorageAfter = 7 * 24 * time.Hour
+
+	// DefaultColdStorageInterval is how often the colony offloads data to cold storage.
+	DefaultColdStorageInterval = 1 * time.Hour
+
+	// DefaultStorageSealInterval is how often an encrypted colony database is
+	// encrypted back to the storage path while the colony runs.
+	DefaultStorageSealInterval = 15 * time.Minute
+
+	// DefaultStorageKeyCommandTimeout bounds the command fetching the storage
+	// encryption key.
+	DefaultStorageKeyCommandTimeout = 30 * time.Second
 )
 
 // Sampling and Filtering.
@@ -232,6 +313,19 @@ const (
 	DefaultAgentHealthyThreshold = 30 * time.Second
 	// DefaultAgentDegradedThreshold is based on last_seen timestamp.
 	DefaultAgentDegradedThreshold = 2 * time.Minute
+
+	// DefaultAgentHealthyScore is the lowest health score reported as healthy;
+	// agents scoring below it are degraded.
+	DefaultAgentHealthyScore = 80
+	// DefaultAgentHealthFailureWindow is the window over which agents report
+	// eBPF collector failures in heartbeats.
+	DefaultAgentHealthFailureWindow = 5 * time.Minute
+	// DefaultAgentQueueDepthThreshold is the number of buffered uprobe events
+	// above which an agent's event store is considered backlogged.
+	DefaultAgentQueueDepthThreshold = 10000
+	// DefaultAgentClockSkewThreshold is the clock offset between agent and
+	// colony above which timestamps of correlated events become unreliable.
+	DefaultAgentClockSkewThreshold = 5 * time.Second
 )
 
 // CPU Profiling.
@@ -259,6 +353,90 @@ const (
 	DefaultSDKAPIRetryAttempts = 3
 )
 
+// Debug Service Resolution.
+const (
+	// DefaultServiceResolutionConcurrency bounds how many agents the colony
+	// queries at once when resolving which agent hosts a service.
+	DefaultServiceResolutionConcurrency = 16
+
+	// DefaultServiceAgentCacheTTL is how long a resolved service to agent
+	// mapping is reused. Mappings are dropped earlier when the agent
+	// reconnects, disconnects or changes its services.
+	DefaultServiceAgentCacheTTL = 5 * time.Minute
+)
+
+// Bulk Agent Operations.
+const (
+	// DefaultBulkAgentConcurrency bounds how many agents a bulk operation
+	// (coral colony agents exec) runs on at once, unless the request sets it.
+	DefaultBulkAgentConcurrency = 10
+
+	// DefaultBulkExecTimeout is how long a bulk command may run on each
+	// agent, unless the request sets it.
+	DefaultBulkExecTimeout = 30 * time.Second
+
+	// MaxBulkExecTimeout is the longest a bulk command may run on each agent.
+	MaxBulkExecTimeout = 300 * time.Second
+)
+
+// Debug Event Push.
+const (
+	// DefaultEventPushInterval is how often agents push captured uprobe
+	// events to the colony. A push also starts early once a full batch is
+	// queued.
+	DefaultEventPushInterval = time.Second
+
+	// DefaultEventPushBatchSize is the maximum number of events sent in a
+	// single IngestUprobeEvents message.
+	DefaultEventPushBatchSize = 500
+
+	// DefaultEventPushQueueSize bounds the events an agent holds while the
+	// colony is slow or unreachable. The oldest events are dropped first.
+	DefaultEventPushQueueSize = 20000
+)
+
+// Shell Recordings.
+const (
+	// DefaultShellRecordingMaxBytes caps the input and output recorded for a
+	// single shell or exec session. Events past the cap are not recorded and
+	// the recording is marked truncated.
+	DefaultShellRecordingMaxBytes = 4 * 1024 * 1024
+
+	// DefaultShellRecordingUploadInterval is how often agents retry uploading
+	// recordings the colony has not accepted yet.
+	DefaultShellRecordingUploadInterval = 10 * time.Second
+
+	// DefaultShellRecordingQueueSize bounds the recordings an agent holds
+	// while the colony is unreachable. The oldest are dropped first.
+	DefaultShellRecordingQueueSize = 64
+)
+
+// File Transfer.
+const (
+	// DefaultFileTransferMaxBytes is the largest file agents copy with
+	// coral cp unless agent.security.max_file_transfer_bytes is set.
+	DefaultFileTransferMaxBytes = 1 << 30
+
+	// FileTransferChunkSize is the size of the chunks files are streamed in.
+	FileTransferChunkSize = 256 * 1024
+)
+
+// Poller Scheduling.
+const (
+	// DefaultPollerSlowAgentThreshold is the poll latency above which an
+	// agent is considered slow and polled less often.
+	DefaultPollerSlowAgentThreshold = 5 * time.Second
+
+	// DefaultPollerMaxBackoffCycles caps how many poll cycles a slow or
+	// failing agent is skipped for.
+	DefaultPollerMaxBackoffCycles = 8
+
+	// DefaultPollerMaxIdleSkipCycles caps how many poll cycles an agent that
+	// returned no new data is skipped for. It bounds the extra delay before
+	// data of an agent that becomes active again is collected.
+	DefaultPollerMaxIdleSkipCycles = 2
+)
+
 // BPF Configuration.
 const (
 	// DefaultBPFMapSize is the default BPF map size.
@@ -268,6 +446,378 @@ const (
 	DefaultBPFPerfBufferPages = 64
 )
 
+// Debug Event Store.
+const (
+	// DefaultDebugEventStoreMaxEvents bounds the agent's on-disk uprobe event ring.
+	DefaultDebugEventStoreMaxEvents = 100000
+
+	// DefaultDebugEventStoreFlushInterval is how often buffered events are written to disk.
+	DefaultDebugEventStoreFlushInterval = 1 * time.Second
+)
+
+// Service Discovery (cgroups).
+const (
+	// DefaultServiceDiscoveryInterval is how often cgroups are scanned for services.
+	DefaultServiceDiscoveryInterval = 30 * time.Second
+
+	// DefaultServiceDiscoveryMinUptime is how long a unit or container must run before it is registered.
+	DefaultServiceDiscoveryMinUptime = 1 * time.Minute
+
+	// DefaultServiceDiscoveryLabel is the container label holding the service name.
+	DefaultServiceDiscoveryLabel = "coral.service"
+
+	// DefaultDockerRoot is the Docker data directory.
+	DefaultDockerRoot = "/var/lib/docker"
+)
+
+// Agent Resource Limits.
+const (
+	// DefaultResourceCheckInterval is how often the agent samples its own CPU and memory usage.
+	DefaultResourceCheckInterval = 10 * time.Second
+
+	// DefaultResourceHighWatermark is the fraction of a limit at which load is shed.
+	DefaultResourceHighWatermark = 0.9
+
+	// DefaultResourceLowWatermark is the fraction of a limit below which shed load is restored.
+	DefaultResourceLowWatermark = 0.7
+
+	// DefaultShedUprobeSampleDivisor is the factor uprobe sampling is reduced by while shedding.
+	DefaultShedUprobeSampleDivisor = 10
+)
+
+// Agent Core Dump Capture.
+const (
+	// DefaultCoreDumpMode selects the capture source automatically.
+	DefaultCoreDumpMode = "auto"
+
+	// DefaultCoreDumpMaxTotalMB bounds the disk space used by stored core dumps.
+	DefaultCoreDumpMaxTotalMB = 2048
+
+	// DefaultCoreDumpMaxDumpMB bounds the uncompressed size of a single core dump.
+	DefaultCoreDumpMaxDumpMB = 1024
+
+	// DefaultCoreDumpPollInterval is how often new core dumps are collected.
+	DefaultCoreDumpPollInterval = 5 * time.Second
+)
+
+// Service Log Collection.
+const (
+	// DefaultLogCollectInterval is how often agents read new log lines and
+	// report them to the colony.
+	DefaultLogCollectInterval = time.Second
+
+	// DefaultLogMaxLineBytes truncates longer log lines.
+	DefaultLogMaxLineBytes = 16 * 1024
+
+	// DefaultLogQueueSize bounds the log lines an agent holds while the
+	// colony is slow or unreachable. The oldest lines are dropped first.
+	DefaultLogQueueSize = 5000
+
+	// DefaultLogBatchSize is the maximum number of lines sent in a single
+	// ReportLogs message.
+	DefaultLogBatchSize = 500
+
+	// DefaultLogBufferLines is the number of recent lines the colony keeps
+	// per service for coral tail.
+	DefaultLogBufferLines = 1000
+
+	// DefaultLogSubscriberBuffer is the number of lines buffered per
+	// coral tail --follow stream; lines are dropped for streams that fall
+	// further behind.
+	DefaultLogSubscriberBuffer = 1024
+
+	// DefaultTailLines is the number of buffered lines coral tail prints.
+	DefaultTailLines = 50
+)
+
+// Colony Events.
+const (
+	// DefaultEventSubscriberBuffer is the number of events buffered per
+	// subscriber; events are dropped for subscribers that fall further behind.
+	DefaultEventSubscriberBuffer = 256
+
+	// DefaultEventHistorySize is the number of recent events kept for replay
+	// to new subscribers.
+	DefaultEventHistorySize = 200
+
+	// DefaultAgentStatusCheckInterval is how often the colony checks for
+	// agents that stopped sending heartbeats.
+	DefaultAgentStatusCheckInterval = 10 * time.Second
+)
+
+// Agent History.
+const (
+	// DefaultAgentHistoryWindow is the window of agent history returned when
+	// no start time is requested.
+	DefaultAgentHistoryWindow = 7 * 24 * time.Hour
+
+	// DefaultAgentHistoryListLimit is the number of agent history events
+	// returned when no limit is requested.
+	DefaultAgentHistoryListLimit = 100
+
+	// AgentFlappingDisconnects is the number of disconnects within the
+	// history window from which an agent is reported as flapping.
+	AgentFlappingDisconnects = 3
+)
+
+// Audit Log.
+const (
+	// DefaultAuditListLimit is the number of audit entries returned when no
+	// limit is requested.
+	DefaultAuditListLimit = 100
+
+	// DefaultAuditReportTimeout bounds how long the CLI waits to report an
+	// exec or MCP tool call to the colony audit log.
+	DefaultAuditReportTimeout = 3 * time.Second
+)
+
+// MCP.
+const (
+	// DefaultMCPMaxResultBytes is the size of a coral_cli result page; larger
+	// results are split and fetched with a cursor.
+	DefaultMCPMaxResultBytes = 64 * 1024
+
+	// DefaultMCPApprovalTimeout is how long a tool call requiring approval
+	// waits for a human decision before it expires.
+	DefaultMCPApprovalTimeout = 5 * time.Minute
+
+	// DefaultMCPApprovalPollInterval is how often the MCP proxy checks a
+	// pending approval.
+	DefaultMCPApprovalPollInterval = 2 * time.Second
+
+	// DefaultMCPApprovalRetention is how long decided approvals are listed.
+	DefaultMCPApprovalRetention = time.Hour
+
+	// DefaultMCPHistoryListLimit is the number of recorded tool calls
+	// returned when no limit is requested.
+	DefaultMCPHistoryListLimit = 50
+)
+
+// Read-only SQL Endpoint.
+const (
+	// DefaultSQLQueryMaxRows is the number of rows QuerySQL returns when no
+	// limit is requested.
+	DefaultSQLQueryMaxRows = 1000
+
+	// MaxSQLQueryRows bounds the rows returned by a single QuerySQL call.
+	MaxSQLQueryRows = 10000
+
+	// DefaultSQLQueryTimeout is the statement timeout of QuerySQL when none
+	// is requested.
+	DefaultSQLQueryTimeout = 30 * time.Second
+
+	// MaxSQLQueryTimeout bounds the statement timeout of QuerySQL. The colony
+	// database has a single connection, so long queries delay ingestion.
+	MaxSQLQueryTimeout = 2 * time.Minute
+)
+
+// Colony High Availability.
+const (
+	// DefaultHASnapshotInterval is how often a standby colony copies the
+	// primary's database.
+	DefaultHASnapshotInterval = 5 * time.Minute
+
+	// DefaultHAHealthCheckInterval is how often a standby colony checks that
+	// the primary is reachable.
+	DefaultHAHealthCheckInterval = 5 * time.Second
+
+	// DefaultHAFailoverAfter is how long the primary must be unreachable
+	// before a standby colony takes over.
+	DefaultHAFailoverAfter = 30 * time.Second
+
+	// DefaultHASnapshotTimeout bounds the download of a database snapshot.
+	DefaultHASnapshotTimeout = 10 * time.Minute
+)
+
+// Colony Daemon.
+const (
+	// DefaultColonyPIDFile is the PID file name in the colony directory.
+	DefaultColonyPIDFile = "colony.pid"
+
+	// DefaultColonyLogFile is the daemon log file name in the colony directory.
+	DefaultColonyLogFile = "colony.log"
+
+	// DefaultColonyDaemonStartTimeout is how long `coral colony start --daemon`
+	// waits for the background colony to finish starting.
+	DefaultColonyDaemonStartTimeout = 60 * time.Second
+
+	// DefaultColonyStopTimeout is how long `coral colony stop` waits for the
+	// colony to shut down after SIGTERM.
+	DefaultColonyStopTimeout = 30 * time.Second
+
+	// DefaultLogFollowInterval is how often `coral colony logs -f` polls the
+	// log file for new output.
+	DefaultLogFollowInterval = 500 * time.Millisecond
+)
+
+// Scheduled Profiling.
+const (
+	// DefaultProfileSchedulerInterval is how often the colony checks for due
+	// profile schedules.
+	DefaultProfileSchedulerInterval = 15 * time.Second
+
+	// MinProfileScheduleInterval is the shortest allowed time between runs of
+	// a profile schedule.
+	MinProfileScheduleInterval = 1 * time.Minute
+
+	// DefaultProfileRunRetention is how long results of scheduled profiling
+	// jobs are kept.
+	DefaultProfileRunRetention = 7 * 24 * time.Hour
+
+	// DefaultProfileRunsLimit is the default number of profile runs listed.
+	DefaultProfileRunsLimit = 50
+)
+
+// Alerting.
+const (
+	// DefaultAlertEvaluationInterval is how often alert rules are evaluated.
+	DefaultAlertEvaluationInterval = 30 * time.Second
+
+	// DefaultAlertWindow is the default time window alert metrics are
+	// computed over.
+	DefaultAlertWindow = 5 * time.Minute
+
+	// MinAlertWindow is the shortest time window of an alert rule.
+	MinAlertWindow = time.Minute
+
+	// MaxAlertWindow bounds the time window of an alert rule.
+	MaxAlertWindow = 24 * time.Hour
+
+	// DefaultAlertNotifyTimeout bounds a single notification to an alert sink.
+	DefaultAlertNotifyTimeout = 10 * time.Second
+
+	// DefaultPagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+	DefaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+)
+
+// Summary Baselines.
+const (
+	// DefaultBaselineWindow is the trailing period service metrics are
+	// compared with in coral query summary --watch.
+	DefaultBaselineWindow = time.Hour
+
+	// MaxBaselineIntervals bounds the number of intervals of a baseline.
+	MaxBaselineIntervals = 1440
+
+	// MinBaselineIntervals is the number of baseline intervals needed to
+	// flag anomalies.
+	MinBaselineIntervals = 3
+
+	// DefaultAnomalySigma is the deviation from the baseline mean, in
+	// standard deviations, above which a metric is flagged as anomalous.
+	DefaultAnomalySigma = 3.0
+
+	// DefaultSummaryWatchInterval is how often coral query summary --watch
+	// refreshes.
+	DefaultSummaryWatchInterval = 5 * time.Second
+)
+
+// Anomaly Detection.
+const (
+	// DefaultAnomalyDetectionInterval is how often the colony scores the
+	// latency and error rate of each route, and the length of the scored
+	// window.
+	DefaultAnomalyDetectionInterval = 5 * time.Minute
+
+	// MinAnomalyDetectionInterval is the shortest anomaly scoring interval.
+	MinAnomalyDetectionInterval = time.Minute
+
+	// DefaultAnomalyLookback is the history route baselines are learned from.
+	DefaultAnomalyLookback = 7 * 24 * time.Hour
+
+	// MinAnomalyLookback is the shortest history route baselines can be
+	// learned from: a day covers every hour once.
+	MinAnomalyLookback = 24 * time.Hour
+
+	// AnomalyRelearnInterval is how often route baselines are learned again.
+	AnomalyRelearnInterval = time.Hour
+
+	// MinAnomalyRequests is the number of requests a route needs in a window
+	// for its latency and error rate to be learned or scored.
+	MinAnomalyRequests = 20
+
+	// DefaultAnomaliesLimit is the number of anomaly scores returned by
+	// coral query anomalies.
+	DefaultAnomaliesLimit = 100
+)
+
+// Debug Event Aggregation.
+const (
+	// DefaultDebugAggregationInterval is how often the colony rolls up debug
+	// events into per-function, per-minute statistics.
+	DefaultDebugAggregationInterval = time.Minute
+
+	// MinDebugAggregationInterval is the shortest debug aggregation interval.
+	MinDebugAggregationInterval = 10 * time.Second
+
+	// DefaultDebugAggregationLateness is how long after a minute ends its
+	// debug events are rolled up.
+	DefaultDebugAggregationLateness = 2 * time.Minute
+
+	// DebugFunctionMetricsLookback is the history of rolled-up debug events
+	// function metrics are reported from.
+	DebugFunctionMetricsLookback = 24 * time.Hour
+)
+
+// Query Result Cache.
+const (
+	// DefaultQueryCacheSize is the number of query results the colony caches.
+	DefaultQueryCacheSize = 256
+
+	// DefaultQueryCacheTTL is how long the colony caches a query result.
+	DefaultQueryCacheTTL = 30 * time.Second
+
+	// QueryCacheTimeGranularity is the precision of the time ranges in cached
+	// queries: queries whose ranges differ by less share a result.
+	QueryCacheTimeGranularity = 10 * time.Second
+)
+
+// Colony OTLP ingestion.
+const (
+	// OTLPIngestAgentID is the agent ID recorded on telemetry the colony
+	// receives directly over OTLP rather than polls from an agent.
+	OTLPIngestAgentID = "colony-otlp"
+
+	// DefaultOTLPIngestFlushInterval is how often the colony OTLP receiver
+	// writes span summaries to the database.
+	DefaultOTLPIngestFlushInterval = 10 * time.Second
+
+	// OTLPIngestSummaryHorizon is how long the colony OTLP receiver keeps
+	// aggregating a summary bucket after its minute ends, to absorb late
+	// exports.
+	OTLPIngestSummaryHorizon = 2 * time.Minute
+)
+
+// Federation.
+const (
+	// DefaultFederationQueryTimeout bounds a federated request to a single
+	// child colony. It stays below the CLI request timeouts so a slow child
+	// is reported as a federation error instead of failing the whole query.
+	DefaultFederationQueryTimeout = 8 * time.Second
+
+	// FederationColonyAttribute is the attribute key labeling spans and
+	// metrics returned by federated queries with their colony ID.
+	FederationColonyAttribute = "colony.id"
+)
+
+// DefaultServiceDiscoveryExclude lists system units that are never registered as services.
+var DefaultServiceDiscoveryExclude = []string{
+	"systemd-*",
+	"dbus*",
+	"getty@*",
+	"serial-getty@*",
+	"user@*",
+	"user-runtime-dir@*",
+	"polkit*",
+	"ssh*",
+	"cron*",
+	"rsyslog*",
+	"containerd.service",
+	"docker.service",
+	"snapd*",
+	"coral*",
+}
+
 // Binary Scanning.
 const (
 	// DefaultBinaryAccessMethod is the default method for accessing container binaries.
@@ -313,8 +863,13 @@ const (
 	// Defaults to localhost-only for security (no network exposure by default).
 	DefaultPublicEndpointHost = "127.0.0.1"
 
-	// DefaultMCPSSEPath is the default path for the MCP SSE endpoint.
-	DefaultMCPSSEPath = "/mcp/sse"
+	// DefaultMCPPath is the default path for the MCP endpoint (Streamable
+	// HTTP and SSE).
+	DefaultMCPPath = "/mcp"
+
+	// LegacyMCPSSEPath is the MCP endpoint path of earlier releases, which
+	// served only SSE. It remains served with the default path.
+	LegacyMCPSSEPath = "/mcp/sse"
 
 	// DefaultRateLimitWindow is the default rate limit time window.
 	DefaultRateLimitWindow = 1 * time.Hour
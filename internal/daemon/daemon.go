@@ -0,0 +1,154 @@
+// Package daemon runs coral commands as background processes: it spawns a
+// detached copy of the current executable, tracks it with a PID file, stops
+// it with SIGTERM, and reads its log file.
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ChildEnv is set in the environment of processes started by Spawn.
+const ChildEnv = "CORAL_DAEMON_CHILD"
+
+// pollInterval is how often process state is checked while waiting.
+const pollInterval = 100 * time.Millisecond
+
+// ErrStopTimeout is returned by Stop when the process is still running after
+// the timeout.
+var ErrStopTimeout = errors.New("timed out waiting for process to exit")
+
+// IsChild reports whether the current process was started by Spawn.
+func IsChild() bool {
+	return os.Getenv(ChildEnv) == "1"
+}
+
+// WritePIDFile records the current process ID in path.
+func WritePIDFile(path string) error {
+	data := []byte(strconv.Itoa(os.Getpid()) + "\n")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
+	return nil
+}
+
+// RemovePIDFile removes path if it still records the current process, so a
+// process never removes the PID file of one that replaced it.
+func RemovePIDFile(path string) {
+	if pid, err := readPIDFile(path); err == nil && pid == os.Getpid() {
+		_ = os.Remove(path)
+	}
+}
+
+// Status returns the process ID recorded in path and whether that process is
+// running. A missing PID file is reported as not running.
+func Status(path string) (int, bool, error) {
+	pid, err := readPIDFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return pid, Running(pid), nil
+}
+
+// Running reports whether a process with the given ID exists.
+func Running(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	// EPERM means the process exists but belongs to another user.
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// Stop sends SIGTERM to pid and waits up to timeout for it to exit.
+func Stop(pid int, timeout time.Duration) error {
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return nil
+		}
+		return fmt.Errorf("failed to signal process %d: %w", pid, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for Running(pid) {
+		if time.Now().After(deadline) {
+			return ErrStopTimeout
+		}
+		time.Sleep(pollInterval)
+	}
+	return nil
+}
+
+// Spawn starts the current executable with args in a new session, detached
+// from the terminal, with its output appended to logPath. The child sees
+// ChildEnv in its environment.
+func Spawn(args []string, logPath string) (*exec.Cmd, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate executable: %w", err)
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600) // #nosec G304 -- path is built by the caller.
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	cmd := exec.Command(exe, args...) // #nosec G204 -- re-executes the running binary.
+	cmd.Env = append(os.Environ(), ChildEnv+"=1")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start background process: %w", err)
+	}
+	return cmd, nil
+}
+
+// WaitForStart waits until the process started by Spawn records itself in
+// pidPath, which it does once it has finished starting. It fails if the
+// process exits first or timeout elapses.
+func WaitForStart(cmd *exec.Cmd, pidPath string, timeout time.Duration) error {
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+
+	for {
+		select {
+		case err := <-exited:
+			if err != nil {
+				return fmt.Errorf("process exited during startup: %w", err)
+			}
+			return errors.New("process exited during startup")
+		case <-deadline:
+			return fmt.Errorf("process did not finish starting within %s", timeout)
+		case <-ticker.C:
+			if pid, err := readPIDFile(pidPath); err == nil && pid == cmd.Process.Pid {
+				return nil
+			}
+		}
+	}
+}
+
+// readPIDFile parses the process ID in path.
+func readPIDFile(path string) (int, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is built by the caller.
+	if err != nil {
+		return 0, err
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid PID file %s", path)
+	}
+	return pid, nil
+}
@@ -0,0 +1,185 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain lets the test binary act as the background process in TestSpawn.
+func TestMain(m *testing.M) {
+	if IsChild() {
+		if err := WritePIDFile(os.Getenv("TEST_PID_FILE")); err != nil {
+			os.Exit(1)
+		}
+		time.Sleep(30 * time.Second)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestSpawn(t *testing.T) {
+	dir := t.TempDir()
+	pidPath := filepath.Join(dir, "test.pid")
+	t.Setenv("TEST_PID_FILE", pidPath)
+
+	cmd, err := Spawn([]string{"-test.run=^$"}, filepath.Join(dir, "test.log"))
+	require.NoError(t, err)
+	require.NoError(t, WaitForStart(cmd, pidPath, 10*time.Second))
+
+	pid, running, err := Status(pidPath)
+	require.NoError(t, err)
+	assert.Equal(t, cmd.Process.Pid, pid)
+	assert.True(t, running)
+
+	require.NoError(t, Stop(pid, 5*time.Second))
+}
+
+func TestWaitForStart_Exited(t *testing.T) {
+	dir := t.TempDir()
+	// The child cannot write its PID file into a missing directory.
+	t.Setenv("TEST_PID_FILE", filepath.Join(dir, "missing", "test.pid"))
+
+	cmd, err := Spawn([]string{"-test.run=^$"}, filepath.Join(dir, "test.log"))
+	require.NoError(t, err)
+	assert.ErrorContains(t, WaitForStart(cmd, filepath.Join(dir, "test.pid"), 10*time.Second), "exited during startup")
+}
+
+func TestPIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.pid")
+
+	pid, running, err := Status(path)
+	require.NoError(t, err)
+	assert.Zero(t, pid)
+	assert.False(t, running)
+
+	require.NoError(t, WritePIDFile(path))
+	pid, running, err = Status(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), pid)
+	assert.True(t, running)
+
+	RemovePIDFile(path)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRemovePIDFile_OtherProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.pid")
+	require.NoError(t, os.WriteFile(path, []byte(strconv.Itoa(os.Getpid()+1)), 0600))
+
+	RemovePIDFile(path)
+	_, err := os.Stat(path)
+	assert.NoError(t, err, "PID file of another process must be kept")
+}
+
+func TestStatus_InvalidPIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.pid")
+	require.NoError(t, os.WriteFile(path, []byte("not-a-pid"), 0600))
+
+	_, _, err := Status(path)
+	assert.Error(t, err)
+}
+
+func TestStop(t *testing.T) {
+	cmd := exec.Command("sleep", "30")
+	require.NoError(t, cmd.Start())
+	// Reap the process so it does not linger as a zombie after SIGTERM.
+	go func() { _ = cmd.Wait() }()
+
+	require.True(t, Running(cmd.Process.Pid))
+	require.NoError(t, Stop(cmd.Process.Pid, 5*time.Second))
+	assert.False(t, Running(cmd.Process.Pid))
+}
+
+func TestTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	require.NoError(t, os.WriteFile(path, []byte("one\ntwo\nthree\n"), 0600))
+
+	tests := []struct {
+		name string
+		n    int
+		want string
+	}{
+		{name: "last two lines", n: 2, want: "two\nthree\n"},
+		{name: "more lines than file", n: 10, want: "one\ntwo\nthree\n"},
+		{name: "whole file", n: -1, want: "one\ntwo\nthree\n"},
+		{name: "no lines", n: 0, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			size, err := Tail(path, tt.n, &buf)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+			assert.Equal(t, int64(14), size)
+		})
+	}
+}
+
+func TestTail_LargeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	line := strings.Repeat("x", 1000) + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat(line, 200)+"last\n"), 0600))
+
+	var buf bytes.Buffer
+	_, err := Tail(path, 2, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, line+"last\n", buf.String())
+}
+
+func TestFollow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	require.NoError(t, os.WriteFile(path, []byte("old\n"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+	go func() { done <- Follow(ctx, path, 4, 10*time.Millisecond, out) }()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString("new\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Eventually(t, func() bool { return out.String() == "new\n" }, 2*time.Second, 10*time.Millisecond)
+
+	// A truncated file is followed from its beginning.
+	require.NoError(t, os.WriteFile(path, []byte("a\n"), 0600))
+	assert.Eventually(t, func() bool { return out.String() == "new\na\n" }, 2*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
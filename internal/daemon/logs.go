@@ -0,0 +1,124 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// tailBlockSize is the size of the blocks Tail reads backwards from the end
+// of the file.
+const tailBlockSize = 64 << 10
+
+// Tail writes the last n lines of the file at path to w, or the whole file if
+// n is negative. It returns the file size, from which Follow can continue.
+func Tail(path string, n int, w io.Writer) (int64, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is built by the caller.
+	if err != nil {
+		return 0, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat log file: %w", err)
+	}
+	size := info.Size()
+
+	start := int64(0)
+	if n >= 0 {
+		start, err = lineOffset(f, size, n)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := io.Copy(w, io.NewSectionReader(f, start, size-start)); err != nil {
+		return 0, fmt.Errorf("failed to read log file: %w", err)
+	}
+	return size, nil
+}
+
+// Follow writes data appended to the file at path after offset to w, polling
+// every interval until ctx is cancelled. If the file shrinks, e.g. because
+// it was truncated, Follow continues from its beginning.
+func Follow(ctx context.Context, path string, offset int64, interval time.Duration, w io.Writer) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			next, err := copyFrom(path, offset, w)
+			if err != nil {
+				return err
+			}
+			offset = next
+		}
+	}
+}
+
+// copyFrom writes the content of the file at path after offset to w and
+// returns the new end offset.
+func copyFrom(path string, offset int64, w io.Writer) (int64, error) {
+	f, err := os.Open(path) // #nosec G304 -- path is built by the caller.
+	if os.IsNotExist(err) {
+		// The file may be between rotation and recreation.
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat log file: %w", err)
+	}
+	size := info.Size()
+	if size < offset {
+		offset = 0
+	}
+	if size == offset {
+		return offset, nil
+	}
+
+	if _, err := io.Copy(w, io.NewSectionReader(f, offset, size-offset)); err != nil {
+		return 0, fmt.Errorf("failed to read log file: %w", err)
+	}
+	return size, nil
+}
+
+// lineOffset returns the offset at which the last n lines of f start.
+func lineOffset(f *os.File, size int64, n int) (int64, error) {
+	if n == 0 {
+		return size, nil
+	}
+
+	buf := make([]byte, tailBlockSize)
+	newlines := 0
+	for end := size; end > 0; {
+		start := max(end-tailBlockSize, 0)
+		block := buf[:end-start]
+		if _, err := f.ReadAt(block, start); err != nil && err != io.EOF {
+			return 0, fmt.Errorf("failed to read log file: %w", err)
+		}
+
+		for i := len(block) - 1; i >= 0; i-- {
+			// The final newline ends the last line rather than starting one.
+			if block[i] != '\n' || start+int64(i) == size-1 {
+				continue
+			}
+			newlines++
+			if newlines == n {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
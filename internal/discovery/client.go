@@ -3,8 +3,13 @@ package discovery
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"connectrpc.com/connect"
@@ -17,6 +22,44 @@ const (
 	defaultTimeout = 10 * time.Second
 )
 
+// RateLimitError is returned when the discovery service rejects a request
+// because the caller exceeded its rate limit (HTTP 429).
+type RateLimitError struct {
+	// RetryAfter is the delay requested by the service, or zero if unknown.
+	RetryAfter time.Duration
+
+	err error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited by discovery service (retry after %s): %v", e.RetryAfter, e.err)
+	}
+	return fmt.Sprintf("rate limited by discovery service: %v", e.err)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.err
+}
+
+// classifyError converts rate limit rejections into a *RateLimitError and
+// returns other errors unchanged.
+func classifyError(err error) error {
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		return err
+	}
+
+	var retryAfter time.Duration
+	if secs, parseErr := strconv.Atoi(connectErr.Meta().Get("Retry-After")); parseErr == nil && secs > 0 {
+		retryAfter = time.Duration(secs) * time.Second
+	}
+	if connectErr.Code() != connect.CodeResourceExhausted && retryAfter == 0 {
+		return err
+	}
+	return &RateLimitError{RetryAfter: retryAfter, err: err}
+}
+
 // Option defines a function signature for configuring the client.
 type Option func(*Client)
 
@@ -36,13 +79,19 @@ func WithHTTPClient(httpClient *http.Client) Option {
 
 // Client wraps the discovery service client.
 type Client struct {
-	client     discoveryv1connect.DiscoveryServiceClient
+	// clients holds one client per discovery replica.
+	clients    []discoveryv1connect.DiscoveryServiceClient
+	preferred  atomic.Int32 // Index of the last replica that answered.
 	timeout    time.Duration
 	httpClient *http.Client
 }
 
 // NewClient creates a raw Connect client for the discovery service.
 // Uses JSON encoding for compatibility with Cloudflare Workers.
+//
+// endpoint may list several discovery replicas separated by commas; requests
+// go to the last replica that answered and fail over to the others when it is
+// unreachable.
 func NewClient(endpoint string, opts ...Option) *Client {
 	c := &Client{
 		timeout: defaultTimeout,
@@ -58,15 +107,70 @@ func NewClient(endpoint string, opts ...Option) *Client {
 		c.httpClient = &http.Client{Timeout: c.timeout}
 	}
 
-	c.client = discoveryv1connect.NewDiscoveryServiceClient(
-		c.httpClient,
-		endpoint,
-		connect.WithProtoJSON(),
-	)
+	for _, ep := range splitEndpoints(endpoint) {
+		c.clients = append(c.clients, discoveryv1connect.NewDiscoveryServiceClient(
+			c.httpClient,
+			ep,
+			connect.WithProtoJSON(),
+		))
+	}
 
 	return c
 }
 
+// splitEndpoints splits a comma-separated list of discovery endpoints.
+func splitEndpoints(endpoint string) []string {
+	var endpoints []string
+	for _, ep := range strings.Split(endpoint, ",") {
+		if ep = strings.TrimSpace(ep); ep != "" {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	if len(endpoints) == 0 {
+		return []string{endpoint}
+	}
+	return endpoints
+}
+
+// invoke calls rpc on the preferred discovery replica, trying the others in
+// turn while replicas are unreachable.
+func invoke[Req, Resp any](
+	ctx context.Context,
+	c *Client,
+	rpc func(discoveryv1connect.DiscoveryServiceClient, context.Context, *connect.Request[Req]) (*connect.Response[Resp], error),
+	req *Req,
+) (*connect.Response[Resp], error) {
+	start := int(c.preferred.Load())
+	var err error
+	for i := range c.clients {
+		idx := (start + i) % len(c.clients)
+		var resp *connect.Response[Resp]
+		resp, err = rpc(c.clients[idx], ctx, connect.NewRequest(req))
+		if err == nil {
+			c.preferred.Store(int32(idx)) // #nosec G115: bounded by the number of endpoints
+			return resp, nil
+		}
+		if !unreachable(ctx, err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// unreachable reports whether err means the replica could not serve the
+// request, so that another replica should be tried.
+func unreachable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeDeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
 // RegisterColonyRequest contains the information needed to register a colony.
 type RegisterColonyRequest struct {
 	MeshID           string
@@ -77,8 +181,13 @@ type RegisterColonyRequest struct {
 	ConnectPort      uint32
 	PublicPort       uint32 // Public HTTPS port for bootstrap (e.g., 8443).
 	Metadata         map[string]string
-	ObservedEndpoint interface{} // *discoveryv1.Endpoint (using interface{} to avoid import issues)
-	PublicEndpoint   interface{} // *discoveryv1.PublicEndpointInfo (RFD 085)
+	ObservedEndpoint interface{}        // *discoveryv1.Endpoint (using interface{} to avoid import issues)
+	PublicEndpoint   interface{}        // *discoveryv1.PublicEndpointInfo (RFD 085)
+	SigningKey       ed25519.PrivateKey // Signs the registration when set (see DeriveRegistrationKey).
+
+	// PreviousSigningKey endorses SigningKey while the colony secret is
+	// rotated (see EndorseRegistrationKey).
+	PreviousSigningKey ed25519.PrivateKey
 }
 
 // RegisterColonyResponse contains the registration response.
@@ -119,10 +228,16 @@ func (c *Client) RegisterColony(ctx context.Context, req *RegisterColonyRequest)
 		ObservedEndpoint: observedEndpoint,
 		PublicEndpoint:   publicEndpoint,
 	}
+	if req.SigningKey != nil {
+		protoReq.Signature = SignColonyRegistration(req.SigningKey, req.MeshID, req.PublicKey, req.Endpoints, time.Now())
+		if req.PreviousSigningKey != nil {
+			EndorseRegistrationKey(protoReq.Signature, req.PreviousSigningKey, req.MeshID)
+		}
+	}
 
-	resp, err := c.client.RegisterColony(ctx, connect.NewRequest(protoReq))
+	resp, err := invoke(ctx, c, discoveryv1connect.DiscoveryServiceClient.RegisterColony, protoReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register colony: %w", err)
+		return nil, fmt.Errorf("failed to register colony: %w", classifyError(err))
 	}
 
 	var expiresAt time.Time
@@ -176,9 +291,9 @@ func (c *Client) LookupColony(ctx context.Context, meshID string) (*LookupColony
 		MeshId: meshID,
 	}
 
-	resp, err := c.client.LookupColony(ctx, connect.NewRequest(protoReq))
+	resp, err := invoke(ctx, c, discoveryv1connect.DiscoveryServiceClient.LookupColony, protoReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to lookup colony: %w", err)
+		return nil, fmt.Errorf("failed to lookup colony: %w", classifyError(err))
 	}
 
 	var lastSeen time.Time
@@ -239,9 +354,9 @@ type HealthResponse struct {
 
 // Health checks the health of the discovery service.
 func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
-	resp, err := c.client.Health(ctx, connect.NewRequest(&discoveryv1.HealthRequest{}))
+	resp, err := invoke(ctx, c, discoveryv1connect.DiscoveryServiceClient.Health, &discoveryv1.HealthRequest{})
 	if err != nil {
-		return nil, fmt.Errorf("health check failed: %w", err)
+		return nil, fmt.Errorf("health check failed: %w", classifyError(err))
 	}
 	return &HealthResponse{
 		Status:             resp.Msg.Status,
@@ -308,9 +423,9 @@ func (c *Client) CreateBootstrapToken(ctx context.Context, req *CreateBootstrapT
 		Intent:   req.Intent,
 	}
 
-	resp, err := c.client.CreateBootstrapToken(ctx, connect.NewRequest(protoReq))
+	resp, err := invoke(ctx, c, discoveryv1connect.DiscoveryServiceClient.CreateBootstrapToken, protoReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create bootstrap token: %w", err)
+		return nil, fmt.Errorf("failed to create bootstrap token: %w", classifyError(err))
 	}
 
 	return &CreateBootstrapTokenResponse{
@@ -327,6 +442,7 @@ type RegisterAgentRequest struct {
 	Endpoints        []string
 	ObservedEndpoint *Endpoint
 	Metadata         map[string]string
+	ReferralTicket   string // Bootstrap token proving mesh admission (RFD 049).
 }
 
 // RegisterAgentResponse contains the agent registration response.
@@ -347,11 +463,12 @@ func (c *Client) RegisterAgent(ctx context.Context, req *RegisterAgentRequest) (
 		Endpoints:        req.Endpoints,
 		ObservedEndpoint: req.ObservedEndpoint.toProto(),
 		Metadata:         req.Metadata,
+		ReferralTicket:   req.ReferralTicket,
 	}
 
-	resp, err := c.client.RegisterAgent(ctx, connect.NewRequest(protoReq))
+	resp, err := invoke(ctx, c, discoveryv1connect.DiscoveryServiceClient.RegisterAgent, protoReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to register agent: %w", err)
+		return nil, fmt.Errorf("failed to register agent: %w", classifyError(err))
 	}
 
 	var expiresAt time.Time
@@ -391,9 +508,9 @@ func (c *Client) RequestRelay(ctx context.Context, req *RequestRelayRequest) (*R
 		ColonyPubkey: req.ColonyPubkey,
 	}
 
-	resp, err := c.client.RequestRelay(ctx, connect.NewRequest(protoReq))
+	resp, err := invoke(ctx, c, discoveryv1connect.DiscoveryServiceClient.RequestRelay, protoReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to request relay: %w", err)
+		return nil, fmt.Errorf("failed to request relay: %w", classifyError(err))
 	}
 
 	var expiresAt time.Time
@@ -427,9 +544,9 @@ func (c *Client) LookupAgent(ctx context.Context, agentID, meshID string) (*Look
 		MeshId:  meshID,
 	}
 
-	resp, err := c.client.LookupAgent(ctx, connect.NewRequest(protoReq))
+	resp, err := invoke(ctx, c, discoveryv1connect.DiscoveryServiceClient.LookupAgent, protoReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to lookup agent: %w", err)
+		return nil, fmt.Errorf("failed to lookup agent: %w", classifyError(err))
 	}
 
 	var lastSeen time.Time
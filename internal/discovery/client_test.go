@@ -0,0 +1,107 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	discoveryv1 "github.com/coral-mesh/coral/coral/discovery/v1"
+	"github.com/coral-mesh/coral/coral/discovery/v1/discoveryv1connect"
+)
+
+// limitedHandler rejects lookups with the configured error.
+type limitedHandler struct {
+	discoveryv1connect.UnimplementedDiscoveryServiceHandler
+	err error
+}
+
+func (h *limitedHandler) LookupColony(
+	context.Context,
+	*connect.Request[discoveryv1.LookupColonyRequest],
+) (*connect.Response[discoveryv1.LookupColonyResponse], error) {
+	return nil, h.err
+}
+
+func TestClient_RateLimited(t *testing.T) {
+	limited := connect.NewError(connect.CodeResourceExhausted, errors.New("too many requests"))
+	limited.Meta().Set("Retry-After", "30")
+
+	tests := []struct {
+		name        string
+		err         error
+		rateLimited bool
+		retryAfter  time.Duration
+	}{
+		{name: "retry after", err: limited, rateLimited: true, retryAfter: 30 * time.Second},
+		{name: "resource exhausted", err: connect.NewError(connect.CodeResourceExhausted, errors.New("slow down")), rateLimited: true},
+		{name: "not found", err: connect.NewError(connect.CodeNotFound, errors.New("no such colony"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.Handle(discoveryv1connect.NewDiscoveryServiceHandler(&limitedHandler{err: tt.err}))
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			_, err := NewClient(srv.URL).LookupColony(context.Background(), "colony")
+			require.Error(t, err)
+
+			var rateLimitErr *RateLimitError
+			assert.Equal(t, tt.rateLimited, errors.As(err, &rateLimitErr))
+			if tt.rateLimited {
+				assert.Equal(t, tt.retryAfter, rateLimitErr.RetryAfter)
+			}
+		})
+	}
+}
+
+// healthHandler answers health checks with its name.
+type healthHandler struct {
+	discoveryv1connect.UnimplementedDiscoveryServiceHandler
+	name string
+}
+
+func (h *healthHandler) Health(
+	context.Context,
+	*connect.Request[discoveryv1.HealthRequest],
+) (*connect.Response[discoveryv1.HealthResponse], error) {
+	return connect.NewResponse(&discoveryv1.HealthResponse{Status: "ok", Version: h.name}), nil
+}
+
+func TestClient_Failover(t *testing.T) {
+	newReplica := func(name string) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.Handle(discoveryv1connect.NewDiscoveryServiceHandler(&healthHandler{name: name}))
+		return httptest.NewServer(mux)
+	}
+	a := newReplica("a")
+	defer a.Close()
+	b := newReplica("b")
+	defer b.Close()
+
+	client := NewClient(a.URL + ", " + b.URL)
+
+	resp, err := client.Health(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "a", resp.Version)
+
+	// The first replica goes down: requests fail over and stick to the second.
+	a.Close()
+	resp, err = client.Health(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "b", resp.Version)
+	assert.Equal(t, int32(1), client.preferred.Load())
+
+	// With every replica down, the last error is returned.
+	b.Close()
+	_, err = client.Health(context.Background())
+	assert.Equal(t, connect.CodeUnavailable, connect.CodeOf(err))
+}
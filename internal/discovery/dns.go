@@ -0,0 +1,100 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// DNSRecordPrefix is prepended to the colony domain to form the name of the
+// SRV and TXT records describing the colony.
+const DNSRecordPrefix = "_coral._udp."
+
+// DNSResolver is the subset of *net.Resolver used for DNS-based colony lookup.
+type DNSResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// LookupColonyDNS resolves colony information from DNS, without the discovery
+// service:
+//
+//	_coral._udp.<domain>. SRV 10 0 41580 colony.example.com.
+//	_coral._udp.<domain>. TXT "v=coral1 mesh_id=prod pubkey=<base64> mesh_ipv4=100.64.0.1 connect_port=9000"
+//
+// SRV records give the WireGuard endpoints in priority order; the TXT record
+// gives the colony's mesh identity.
+func LookupColonyDNS(ctx context.Context, resolver DNSResolver, domain string) (*LookupColonyResponse, error) {
+	name := DNSRecordPrefix + strings.TrimSuffix(domain, ".")
+
+	// Query the full record name, as LookupSRV("", "", name) does.
+	_, srvs, err := resolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup SRV records for %s: %w", name, err)
+	}
+	txts, err := resolver.LookupTXT(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup TXT records for %s: %w", name, err)
+	}
+
+	resp := &LookupColonyResponse{}
+	found := false
+	for _, txt := range txts {
+		fields := strings.Fields(txt)
+		if len(fields) == 0 || fields[0] != "v=coral1" {
+			continue
+		}
+		if err := parseColonyTXT(fields[1:], resp); err != nil {
+			return nil, fmt.Errorf("invalid TXT record for %s: %w", name, err)
+		}
+		found = true
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("no v=coral1 TXT record found for %s", name)
+	}
+	if resp.Pubkey == "" || resp.MeshIPv4 == "" {
+		return nil, fmt.Errorf("TXT record for %s must set pubkey and mesh_ipv4", name)
+	}
+
+	for _, srv := range srvs {
+		host := strings.TrimSuffix(srv.Target, ".")
+		resp.Endpoints = append(resp.Endpoints, net.JoinHostPort(host, strconv.Itoa(int(srv.Port))))
+	}
+	if len(resp.Endpoints) == 0 {
+		return nil, fmt.Errorf("no SRV records found for %s", name)
+	}
+
+	return resp, nil
+}
+
+// parseColonyTXT parses the key=value fields of a colony TXT record.
+func parseColonyTXT(fields []string, resp *LookupColonyResponse) error {
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("malformed field %q", field)
+		}
+		switch key {
+		case "mesh_id":
+			resp.MeshID = value
+		case "pubkey":
+			resp.Pubkey = value
+		case "mesh_ipv4":
+			resp.MeshIPv4 = value
+		case "mesh_ipv6":
+			resp.MeshIPv6 = value
+		case "connect_port":
+			port, err := strconv.ParseUint(value, 10, 16)
+			if err != nil {
+				return fmt.Errorf("invalid connect_port %q", value)
+			}
+			resp.ConnectPort = uint32(port)
+		default:
+			// Unknown keys are ignored for forward compatibility.
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package discovery
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver serves fixed SRV and TXT records for a single name.
+type fakeResolver struct {
+	name string
+	srvs []*net.SRV
+	txts []string
+}
+
+func (r *fakeResolver) LookupSRV(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if service != "" || proto != "" || name != r.name {
+		return "", nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return name, r.srvs, nil
+}
+
+func (r *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if name != r.name {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return r.txts, nil
+}
+
+func TestLookupColonyDNS(t *testing.T) {
+	resolver := &fakeResolver{
+		name: "_coral._udp.prod.example.com",
+		srvs: []*net.SRV{
+			{Target: "colony-a.example.com.", Port: 41580, Priority: 10},
+			{Target: "colony-b.example.com.", Port: 41581, Priority: 20},
+		},
+		txts: []string{
+			"some-other=record",
+			"v=coral1 mesh_id=prod pubkey=abc+def/ghi= mesh_ipv4=100.64.0.1 mesh_ipv6=fd42::1 connect_port=9001 future=1",
+		},
+	}
+
+	resp, err := LookupColonyDNS(context.Background(), resolver, "prod.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", resp.MeshID)
+	assert.Equal(t, "abc+def/ghi=", resp.Pubkey)
+	assert.Equal(t, "100.64.0.1", resp.MeshIPv4)
+	assert.Equal(t, "fd42::1", resp.MeshIPv6)
+	assert.Equal(t, uint32(9001), resp.ConnectPort)
+	assert.Equal(t, []string{"colony-a.example.com:41580", "colony-b.example.com:41581"}, resp.Endpoints)
+}
+
+func TestLookupColonyDNS_Errors(t *testing.T) {
+	srvs := []*net.SRV{{Target: "colony.example.com.", Port: 41580}}
+	tests := []struct {
+		name   string
+		srvs   []*net.SRV
+		txts   []string
+		errMsg string
+	}{
+		{name: "no coral TXT record", srvs: srvs, txts: []string{"v=spf1 -all"}, errMsg: "no v=coral1 TXT record"},
+		{name: "missing pubkey", srvs: srvs, txts: []string{"v=coral1 mesh_ipv4=100.64.0.1"}, errMsg: "must set pubkey and mesh_ipv4"},
+		{name: "malformed field", srvs: srvs, txts: []string{"v=coral1 pubkey"}, errMsg: "malformed field"},
+		{name: "invalid port", srvs: srvs, txts: []string{"v=coral1 pubkey=k mesh_ipv4=100.64.0.1 connect_port=x"}, errMsg: "invalid connect_port"},
+		{name: "no SRV records", txts: []string{"v=coral1 pubkey=k mesh_ipv4=100.64.0.1"}, errMsg: "no SRV records"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := &fakeResolver{name: "_coral._udp.example.com", srvs: tt.srvs, txts: tt.txts}
+			_, err := LookupColonyDNS(context.Background(), resolver, "example.com")
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
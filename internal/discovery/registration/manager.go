@@ -3,6 +3,8 @@ package registration
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -60,6 +62,14 @@ type Config struct {
 	// PublicEndpoint contains public HTTPS endpoint info for CLI access (RFD 085).
 	// This includes CA certificate and fingerprint for CLI users to verify.
 	PublicEndpoint interface{} // *discoveryv1.PublicEndpointInfo (avoiding import cycle)
+
+	// ColonySecret derives the key that signs registrations. Registrations
+	// are unsigned when empty.
+	ColonySecret string
+
+	// PreviousColonySecret is the secret before a rotation, set during its
+	// grace period. Its key endorses the new one so that discovery accepts it.
+	PreviousColonySecret string
 }
 
 // Manager handles continuous registration and reconnection.
@@ -68,6 +78,12 @@ type Manager struct {
 	client *discovery.Client
 	logger zerolog.Logger
 
+	// signingKey is derived from the colony secret, nil if unsigned;
+	// previousSigningKey from the previous secret during a rotation.
+	keyMu              sync.RWMutex
+	signingKey         ed25519.PrivateKey
+	previousSigningKey ed25519.PrivateKey
+
 	// State tracking.
 	mu              sync.RWMutex
 	registered      bool
@@ -83,13 +99,39 @@ type Manager struct {
 
 // NewManager creates a new registration manager.
 func NewManager(cfg Config, logger zerolog.Logger) *Manager {
-	return &Manager{
+	m := &Manager{
 		config: cfg,
 		client: discovery.NewClient(cfg.DiscoveryEndpoint, discovery.WithTimeout(cfg.DiscoveryTimeout)),
 		logger: logger,
 		stopCh: make(chan struct{}),
 		doneCh: make(chan struct{}),
 	}
+	m.SetColonySecret(cfg.ColonySecret, cfg.PreviousColonySecret)
+	return m
+}
+
+// SetColonySecret replaces the colony secret registrations are signed with,
+// e.g. when it is rotated. previous is the secret before the rotation, or
+// empty once its grace period is over.
+func (m *Manager) SetColonySecret(secret, previous string) {
+	m.keyMu.Lock()
+	defer m.keyMu.Unlock()
+
+	// Registrations stay unsigned without a colony secret.
+	m.signingKey, m.previousSigningKey = nil, nil
+	if key, err := discovery.DeriveRegistrationKey(secret, m.config.MeshID); err == nil {
+		m.signingKey = key
+	}
+	if key, err := discovery.DeriveRegistrationKey(previous, m.config.MeshID); err == nil && m.signingKey != nil {
+		m.previousSigningKey = key
+	}
+}
+
+// signingKeys returns the keys signing and endorsing registrations.
+func (m *Manager) signingKeys() (ed25519.PrivateKey, ed25519.PrivateKey) {
+	m.keyMu.RLock()
+	defer m.keyMu.RUnlock()
+	return m.signingKey, m.previousSigningKey
 }
 
 // Start begins the registration manager lifecycle.
@@ -191,7 +233,11 @@ func (m *Manager) registerWithRetry(ctx context.Context) error {
 			return err
 		}
 		return nil
-	}, nil) // Retry all errors.
+	}, func(err error) bool {
+		// Retrying a rate limited registration only extends the rejection.
+		var rateLimitErr *discovery.RateLimitError
+		return !errors.As(err, &rateLimitErr)
+	})
 
 	return err
 }
@@ -239,6 +285,7 @@ func (m *Manager) register(ctx context.Context) error {
 		publicEndpoint = m.config.PublicEndpoint
 	}
 
+	signingKey, previousSigningKey := m.signingKeys()
 	req := &discovery.RegisterColonyRequest{
 		MeshID:           m.config.MeshID,
 		PublicKey:        m.config.PublicKey,
@@ -250,6 +297,9 @@ func (m *Manager) register(ctx context.Context) error {
 		Metadata:         m.config.Metadata,
 		ObservedEndpoint: observedEndpoint,
 		PublicEndpoint:   publicEndpoint,
+		SigningKey:       signingKey,
+
+		PreviousSigningKey: previousSigningKey,
 	}
 
 	m.logger.Debug().
@@ -260,6 +310,7 @@ func (m *Manager) register(ctx context.Context) error {
 		Str("mesh_ipv6", req.MeshIPv6).
 		Uint32("connect_port", req.ConnectPort).
 		Interface("metadata", req.Metadata).
+		Bool("signed", req.SigningKey != nil).
 		Msg("Sending registration request")
 
 	resp, err := m.client.RegisterColony(regCtx, req)
@@ -306,20 +357,35 @@ func (m *Manager) heartbeatLoop() {
 		Dur("interval", m.config.RegisterInterval).
 		Msg("Heartbeat loop started")
 
+	// Set when the discovery service asks to back off for longer than the
+	// heartbeat interval.
+	var retryAt time.Time
+
 	for {
 		select {
 		case <-m.stopCh:
 			m.logger.Debug().Msg("Heartbeat loop stopped")
 			return
 
-		case <-ticker.C:
+		case now := <-ticker.C:
+			if now.Before(retryAt) {
+				m.logger.Debug().Time("retry_at", retryAt).Msg("Heartbeat tick: rate limited, skipping")
+				continue
+			}
 			m.logger.Debug().Msg("Heartbeat tick: re-registering")
 
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			err := m.registerWithRetry(ctx)
 			cancel()
 
-			if err != nil {
+			var rateLimitErr *discovery.RateLimitError
+			if errors.As(err, &rateLimitErr) {
+				retryAt = time.Now().Add(rateLimitErr.RetryAfter)
+				m.logger.Warn().
+					Err(err).
+					Dur("retry_after", rateLimitErr.RetryAfter).
+					Msg("Heartbeat registration rate limited")
+			} else if err != nil {
 				m.logger.Error().
 					Err(err).
 					Msg("Heartbeat registration failed")
@@ -0,0 +1,132 @@
+package discovery
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	discoveryv1 "github.com/coral-mesh/coral/coral/discovery/v1"
+)
+
+const (
+	// registrationKeyContext separates the registration key from other keys
+	// derived from the colony secret.
+	registrationKeyContext = "coral-discovery-registration-key/v1"
+
+	// registrationPayloadPrefix versions the signed registration payload.
+	registrationPayloadPrefix = "coral-discovery-register-colony/v1"
+
+	// endorsementPayloadPrefix versions the payload with which the previous
+	// registration key endorses the new one.
+	endorsementPayloadPrefix = "coral-discovery-endorse-key/v1"
+
+	// MaxRegistrationSignatureAge is how far signed_at may be from the
+	// verifier's clock.
+	MaxRegistrationSignatureAge = 5 * time.Minute
+)
+
+// DeriveRegistrationKey derives the Ed25519 key that signs registrations of
+// meshID from the colony secret. The seed is HMAC-SHA256(secret, context ||
+// meshID), so the same secret always yields the same key and the secret itself
+// never leaves the colony.
+func DeriveRegistrationKey(colonySecret, meshID string) (ed25519.PrivateKey, error) {
+	if colonySecret == "" {
+		return nil, errors.New("colony secret is empty")
+	}
+	mac := hmac.New(sha256.New, []byte(colonySecret))
+	mac.Write([]byte(registrationKeyContext))
+	mac.Write([]byte{0})
+	mac.Write([]byte(meshID))
+	return ed25519.NewKeyFromSeed(mac.Sum(nil)), nil
+}
+
+// registrationPayload returns the canonical bytes signed for a colony
+// registration.
+func registrationPayload(meshID, pubkey string, endpoints []string, signedAt int64) []byte {
+	return []byte(strings.Join([]string{
+		registrationPayloadPrefix,
+		meshID,
+		pubkey,
+		strings.Join(endpoints, ","),
+		strconv.FormatInt(signedAt, 10),
+	}, "\n"))
+}
+
+// SignColonyRegistration signs the identifying fields of a colony registration.
+func SignColonyRegistration(
+	key ed25519.PrivateKey,
+	meshID, pubkey string,
+	endpoints []string,
+	now time.Time,
+) *discoveryv1.RegistrationSignature {
+	signedAt := now.Unix()
+	return &discoveryv1.RegistrationSignature{
+		PublicKey: key.Public().(ed25519.PublicKey),
+		SignedAt:  signedAt,
+		Signature: ed25519.Sign(key, registrationPayload(meshID, pubkey, endpoints, signedAt)),
+	}
+}
+
+// endorsementPayload returns the canonical bytes the previous registration
+// key signs to endorse a new one.
+func endorsementPayload(meshID string, publicKey []byte, signedAt int64) []byte {
+	return []byte(strings.Join([]string{
+		endorsementPayloadPrefix,
+		meshID,
+		hex.EncodeToString(publicKey),
+		strconv.FormatInt(signedAt, 10),
+	}, "\n"))
+}
+
+// EndorseRegistrationKey adds the endorsement of the registration key that
+// signed sig by previous, the key derived from the colony secret before it
+// was rotated. Discovery then moves the mesh's pinned key to the new one.
+func EndorseRegistrationKey(sig *discoveryv1.RegistrationSignature, previous ed25519.PrivateKey, meshID string) {
+	sig.PreviousPublicKey = previous.Public().(ed25519.PublicKey)
+	sig.PreviousSignature = ed25519.Sign(previous, endorsementPayload(meshID, sig.PublicKey, sig.SignedAt))
+}
+
+// VerifyColonyRegistration checks the signature of a colony registration. The
+// discovery service calls it with the public key pinned for the mesh ID (nil
+// on first registration, in which case the request's key is accepted). A
+// registration signed with another key is accepted if the pinned key endorsed
+// it (see EndorseRegistrationKey); the discovery service then pins the
+// request's key.
+func VerifyColonyRegistration(
+	req *discoveryv1.RegisterColonyRequest,
+	pinnedKey ed25519.PublicKey,
+	now time.Time,
+) error {
+	sig := req.GetSignature()
+	if sig == nil {
+		return errors.New("registration is not signed")
+	}
+	if len(sig.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid registration public key size %d", len(sig.PublicKey))
+	}
+	if pinnedKey != nil && !pinnedKey.Equal(ed25519.PublicKey(sig.PublicKey)) {
+		if !pinnedKey.Equal(ed25519.PublicKey(sig.PreviousPublicKey)) {
+			return errors.New("registration key does not match the key pinned for this mesh")
+		}
+		if !ed25519.Verify(pinnedKey, endorsementPayload(req.MeshId, sig.PublicKey, sig.SignedAt), sig.PreviousSignature) {
+			return errors.New("invalid endorsement of the registration key by the pinned key")
+		}
+	}
+
+	age := now.Sub(time.Unix(sig.SignedAt, 0))
+	if age > MaxRegistrationSignatureAge || age < -MaxRegistrationSignatureAge {
+		return fmt.Errorf("registration signature is stale (signed %s ago)", age.Round(time.Second))
+	}
+
+	payload := registrationPayload(req.MeshId, req.Pubkey, req.Endpoints, sig.SignedAt)
+	if !ed25519.Verify(sig.PublicKey, payload, sig.Signature) {
+		return errors.New("invalid registration signature")
+	}
+	return nil
+}
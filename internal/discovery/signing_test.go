@@ -0,0 +1,95 @@
+package discovery
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	discoveryv1 "github.com/coral-mesh/coral/coral/discovery/v1"
+)
+
+func TestDeriveRegistrationKey(t *testing.T) {
+	key, err := DeriveRegistrationKey("secret", "mesh-a")
+	require.NoError(t, err)
+
+	again, err := DeriveRegistrationKey("secret", "mesh-a")
+	require.NoError(t, err)
+	assert.True(t, key.Equal(again), "derivation is deterministic")
+
+	otherMesh, err := DeriveRegistrationKey("secret", "mesh-b")
+	require.NoError(t, err)
+	assert.False(t, key.Equal(otherMesh))
+
+	otherSecret, err := DeriveRegistrationKey("other", "mesh-a")
+	require.NoError(t, err)
+	assert.False(t, key.Equal(otherSecret))
+
+	_, err = DeriveRegistrationKey("", "mesh-a")
+	assert.Error(t, err)
+}
+
+func TestVerifyColonyRegistration(t *testing.T) {
+	key, err := DeriveRegistrationKey("secret", "mesh-a")
+	require.NoError(t, err)
+	now := time.Unix(1_700_000_000, 0)
+
+	signed := func() *discoveryv1.RegisterColonyRequest {
+		req := &discoveryv1.RegisterColonyRequest{
+			MeshId:    "mesh-a",
+			Pubkey:    "wg-pubkey",
+			Endpoints: []string{"203.0.113.5:41580"},
+		}
+		req.Signature = SignColonyRegistration(key, req.MeshId, req.Pubkey, req.Endpoints, now)
+		return req
+	}
+	pinned := key.Public().(ed25519.PublicKey)
+
+	assert.NoError(t, VerifyColonyRegistration(signed(), nil, now), "first registration pins the key")
+	assert.NoError(t, VerifyColonyRegistration(signed(), pinned, now.Add(time.Minute)))
+
+	req := signed()
+	req.Endpoints = []string{"198.51.100.9:41580"}
+	assert.ErrorContains(t, VerifyColonyRegistration(req, pinned, now), "invalid registration signature")
+
+	intruder, err := DeriveRegistrationKey("guessed", "mesh-a")
+	require.NoError(t, err)
+	req = signed()
+	req.Signature = SignColonyRegistration(intruder, req.MeshId, req.Pubkey, req.Endpoints, now)
+	assert.ErrorContains(t, VerifyColonyRegistration(req, pinned, now), "does not match")
+
+	assert.ErrorContains(t, VerifyColonyRegistration(signed(), pinned, now.Add(time.Hour)), "stale")
+
+	req = signed()
+	req.Signature = nil
+	assert.ErrorContains(t, VerifyColonyRegistration(req, pinned, now), "not signed")
+}
+
+func TestVerifyColonyRegistration_Endorsed(t *testing.T) {
+	previous, err := DeriveRegistrationKey("secret", "mesh-a")
+	require.NoError(t, err)
+	next, err := DeriveRegistrationKey("rotated", "mesh-a")
+	require.NoError(t, err)
+	now := time.Unix(1_700_000_000, 0)
+	pinned := previous.Public().(ed25519.PublicKey)
+
+	req := &discoveryv1.RegisterColonyRequest{
+		MeshId:    "mesh-a",
+		Pubkey:    "wg-pubkey",
+		Endpoints: []string{"203.0.113.5:41580"},
+	}
+	req.Signature = SignColonyRegistration(next, req.MeshId, req.Pubkey, req.Endpoints, now)
+	assert.ErrorContains(t, VerifyColonyRegistration(req, pinned, now), "does not match")
+
+	EndorseRegistrationKey(req.Signature, previous, req.MeshId)
+	assert.NoError(t, VerifyColonyRegistration(req, pinned, now), "the pinned key endorsed the new key")
+
+	intruder, err := DeriveRegistrationKey("guessed", "mesh-a")
+	require.NoError(t, err)
+	req.Signature = SignColonyRegistration(intruder, req.MeshId, req.Pubkey, req.Endpoints, now)
+	req.Signature.PreviousPublicKey = pinned
+	req.Signature.PreviousSignature = ed25519.Sign(intruder, []byte("forged"))
+	assert.ErrorContains(t, VerifyColonyRegistration(req, pinned, now), "invalid endorsement")
+}
@@ -39,6 +39,14 @@ func NewQueryBuilder(table string) *Builder {
 	}
 }
 
+// NewFilter creates a builder used only for its WHERE conditions, e.g. with
+// Table.UpdateWhere and Table.DeleteWhere:
+//
+//	deleted, err := table.DeleteWhere(ctx, duckdb.NewFilter().Lt("timestamp", cutoff))
+func NewFilter() *Builder {
+	return NewQueryBuilder("")
+}
+
 // Select specifies the columns to retrieve.
 // Supports column names, aggregates, and aliases.
 // Examples:
@@ -195,13 +203,10 @@ func (b *Builder) Build() (string, []interface{}, error) {
 
 	// WHERE clause.
 	if len(b.where) > 0 {
+		where, args := b.whereSQL()
 		query.WriteString(" WHERE ")
-		exprs := make([]string, len(b.where))
-		for i, w := range b.where {
-			exprs[i] = w.expr
-			b.args = append(b.args, w.args...)
-		}
-		query.WriteString(strings.Join(exprs, " AND "))
+		query.WriteString(where)
+		b.args = append(b.args, args...)
 	}
 
 	// GROUP BY clause.
@@ -233,6 +238,17 @@ func (b *Builder) Build() (string, []interface{}, error) {
 	return query.String(), b.args, nil
 }
 
+// whereSQL returns the WHERE conditions combined with AND, and their arguments.
+func (b *Builder) whereSQL() (string, []interface{}) {
+	exprs := make([]string, len(b.where))
+	var args []interface{}
+	for i, w := range b.where {
+		exprs[i] = w.expr
+		args = append(args, w.args...)
+	}
+	return strings.Join(exprs, " AND "), args
+}
+
 // MustBuild builds the query and panics on error.
 // Useful for tests and cases where query construction should never fail.
 func (b *Builder) MustBuild() (string, []interface{}) {
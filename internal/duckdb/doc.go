@@ -13,6 +13,24 @@
 //	table := duckdb.NewTable[User](db, "users")
 //	err := table.BatchUpsert(ctx, []*User{...})
 //
+// Rows matching builder conditions are changed without raw SQL, and a
+// `version` tag option enables optimistic concurrency for Update, which then
+// returns ErrVersionConflict if the row changed since it was read:
+//
+//	n, err := table.UpdateWhere(ctx, map[string]any{"status": "resolved"},
+//	    duckdb.NewFilter().Eq("rule_id", id))
+//	n, err = table.DeleteWhere(ctx, duckdb.NewFilter().Lt("timestamp", cutoff))
+//
+// Large tables are read with SelectIterator, which streams rows in batches
+// instead of loading them all like List:
+//
+//	it := table.SelectIterator(ctx, duckdb.SelectOptions{BatchSize: 5000})
+//	defer it.Close()
+//	for it.Next() {
+//	    user := it.Item()
+//	}
+//	err := it.Err()
+//
 // # Query Builder
 //
 // The query builder provides a fluent API for constructing SELECT queries with
@@ -33,4 +51,17 @@
 // It supports flexible time column names (timestamp, bucket_time, start_time)
 // via the TimeColumn() method, and automatically skips empty string filters
 // for wildcard behavior.
+//
+// # Migrations
+//
+// Migrate applies versioned schema changes that CREATE TABLE IF NOT EXISTS
+// cannot express, such as new columns on existing tables. Applied migrations
+// are recorded per scope in the schema_migrations table:
+//
+//	applied, err := duckdb.Migrate(ctx, db, "telemetry", []duckdb.Migration{
+//	    {Version: 1, Name: "add_spans_namespace", SQL: `ALTER TABLE spans ADD COLUMN namespace VARCHAR`},
+//	}, false)
+//
+// With dryRun set, Migrate returns the pending migrations without applying
+// them.
 package duckdb
@@ -0,0 +1,224 @@
+package duckdb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DefaultIteratorBatchSize is the number of rows an Iterator fetches per query
+// when SelectOptions.BatchSize is not set.
+const DefaultIteratorBatchSize = 10000
+
+// SelectOptions configures the rows streamed by SelectIterator.
+type SelectOptions struct {
+	// Filters are simple "column = value" pairs, as in List.
+	Filters map[string]interface{}
+
+	// Where is an optional extra condition with ? placeholders bound to
+	// WhereArgs, e.g. "timestamp >= ?".
+	Where     string
+	WhereArgs []interface{}
+
+	// OrderBy orders the rows; use a "-" prefix for DESC order. When empty,
+	// rows are ordered by primary key and paged by key instead of OFFSET,
+	// which keeps every batch query cheap on large tables. Tables without a
+	// primary key are paged with OFFSET in insertion order.
+	OrderBy []string
+
+	// BatchSize is the number of rows fetched per query. Defaults to
+	// DefaultIteratorBatchSize.
+	BatchSize int
+
+	// Limit caps the total number of rows returned; 0 means no limit.
+	Limit int
+}
+
+// Iterator is a typed cursor over the rows of a table, returned by
+// SelectIterator. Rows are fetched in batches of BatchSize, each with its own
+// query, so at most one batch is held in memory and the connection is
+// released between batches. Use it like sql.Rows:
+//
+//	it := table.SelectIterator(ctx, duckdb.SelectOptions{BatchSize: 5000})
+//	defer it.Close()
+//	for it.Next() {
+//	    process(it.Item())
+//	}
+//	if err := it.Err(); err != nil { ... }
+//
+// Rows written while iterating may or may not be returned.
+type Iterator[T any] struct {
+	ctx   context.Context
+	table *Table[T]
+	opts  SelectOptions
+
+	keyset   bool
+	batch    []*T
+	pos      int
+	fetched  int
+	lastKeys []interface{}
+	done     bool
+	err      error
+}
+
+// SelectIterator returns an Iterator over the rows matching opts.
+func (t *Table[T]) SelectIterator(ctx context.Context, opts SelectOptions) *Iterator[T] {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultIteratorBatchSize
+	}
+	return &Iterator[T]{
+		ctx:    ctx,
+		table:  t,
+		opts:   opts,
+		keyset: len(opts.OrderBy) == 0 && len(t.pkColumns) > 0,
+		pos:    -1,
+	}
+}
+
+// Next advances to the next row, fetching the next batch when needed. It
+// returns false when the rows are exhausted or an error occurred; check Err.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.pos+1 < len(it.batch) {
+		it.pos++
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	if err := it.fetch(); err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+	if len(it.batch) == 0 {
+		it.done = true
+		return false
+	}
+	it.pos = 0
+	return true
+}
+
+// Item returns the current row.
+func (it *Iterator[T]) Item() *T {
+	if it.pos < 0 || it.pos >= len(it.batch) {
+		return nil
+	}
+	return it.batch[it.pos]
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and releases the current batch.
+func (it *Iterator[T]) Close() error {
+	it.done = true
+	it.batch = nil
+	it.pos = -1
+	return nil
+}
+
+// fetch replaces the current batch with the next one.
+func (it *Iterator[T]) fetch() error {
+	t := it.table
+	size := it.opts.BatchSize
+	if it.opts.Limit > 0 {
+		size = min(size, it.opts.Limit-it.fetched)
+		if size <= 0 {
+			it.batch = nil
+			return nil
+		}
+	}
+
+	query, args := it.buildQuery(size)
+	rows, err := t.db.QueryContext(it.ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", t.tableName, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	batch := make([]*T, 0, size)
+	for rows.Next() {
+		item, err := t.scanRows(rows)
+		if err != nil {
+			return fmt.Errorf("failed to scan %s row: %w", t.tableName, err)
+		}
+		batch = append(batch, item)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read %s rows: %w", t.tableName, err)
+	}
+
+	it.batch = batch
+	it.fetched += len(batch)
+	if len(batch) < size {
+		// Short batch: no more rows after this one.
+		it.done = true
+	}
+	if it.keyset && len(batch) > 0 {
+		it.lastKeys = t.pkValues(batch[len(batch)-1])
+	}
+	return nil
+}
+
+// buildQuery builds the query fetching the next batch of at most size rows.
+func (it *Iterator[T]) buildQuery(size int) (string, []interface{}) {
+	t := it.table
+	var clauses []string
+	var args []interface{}
+
+	for col, val := range it.opts.Filters {
+		clauses = append(clauses, fmt.Sprintf("%s = ?", col))
+		args = append(args, val)
+	}
+	if it.opts.Where != "" {
+		clauses = append(clauses, "("+it.opts.Where+")")
+		args = append(args, it.opts.WhereArgs...)
+	}
+	if it.keyset && it.lastKeys != nil {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(t.pkColumns)), ", ")
+		clauses = append(clauses, fmt.Sprintf("(%s) > (%s)", strings.Join(t.pkColumns, ", "), placeholders))
+		args = append(args, it.lastKeys...)
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(t.columns, ", "), t.tableName)
+	if len(clauses) > 0 {
+		query += " WHERE " + strings.Join(clauses, " AND ")
+	}
+
+	if it.keyset {
+		query += " ORDER BY " + strings.Join(t.pkColumns, ", ")
+	} else if len(it.opts.OrderBy) > 0 {
+		order := make([]string, len(it.opts.OrderBy))
+		for i, col := range it.opts.OrderBy {
+			if strings.HasPrefix(col, "-") {
+				order[i] = col[1:] + " DESC"
+			} else {
+				order[i] = col + " ASC"
+			}
+		}
+		query += " ORDER BY " + strings.Join(order, ", ")
+	}
+
+	query += fmt.Sprintf(" LIMIT %d", size)
+	if !it.keyset && it.fetched > 0 {
+		query += fmt.Sprintf(" OFFSET %d", it.fetched)
+	}
+	return query, args
+}
+
+// pkValues returns the primary key values of item.
+func (t *Table[T]) pkValues(item *T) []interface{} {
+	val := reflect.ValueOf(item).Elem()
+	keys := make([]interface{}, len(t.pkColumns))
+	for i, col := range t.pkColumns {
+		keys[i] = convertFieldValue(val.Field(t.fieldMap[col]).Interface())
+	}
+	return keys
+}
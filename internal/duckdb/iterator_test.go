@@ -0,0 +1,146 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+type iterEvent struct {
+	SessionID string `duckdb:"session_id,pk"`
+	Seq       int64  `duckdb:"seq,pk"`
+	Name      string `duckdb:"name"`
+}
+
+type iterLog struct {
+	Seq  int64  `duckdb:"seq"`
+	Name string `duckdb:"name"`
+}
+
+func openIteratorTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.duckdb"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.Exec(`
+		CREATE TABLE events (session_id VARCHAR, seq BIGINT, name VARCHAR, PRIMARY KEY (session_id, seq));
+		CREATE TABLE logs (seq BIGINT, name VARCHAR);
+	`); err != nil {
+		t.Fatalf("Failed to create tables: %v", err)
+	}
+	for i := 0; i < 25; i++ {
+		session := "a"
+		if i%2 == 1 {
+			session = "b"
+		}
+		if _, err := db.Exec(`INSERT INTO events VALUES (?, ?, ?)`, session, i, fmt.Sprintf("event-%d", i)); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+		if _, err := db.Exec(`INSERT INTO logs VALUES (?, ?)`, i, fmt.Sprintf("log-%d", i)); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+	return db
+}
+
+func collect[T any](t *testing.T, it *Iterator[T]) []*T {
+	t.Helper()
+	defer func() { _ = it.Close() }()
+	var items []*T
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+	return items
+}
+
+func TestSelectIterator_KeysetPaging(t *testing.T) {
+	db := openIteratorTestDB(t)
+	table := NewTable[iterEvent](db, "events")
+
+	items := collect(t, table.SelectIterator(context.Background(), SelectOptions{BatchSize: 4}))
+	if len(items) != 25 {
+		t.Fatalf("expected 25 rows, got %d", len(items))
+	}
+
+	// Rows come in primary key order, each exactly once.
+	seen := make(map[int64]bool)
+	for i, item := range items {
+		if seen[item.Seq] {
+			t.Fatalf("row %d returned twice", item.Seq)
+		}
+		seen[item.Seq] = true
+		if i > 0 {
+			prev := items[i-1]
+			if prev.SessionID > item.SessionID || (prev.SessionID == item.SessionID && prev.Seq >= item.Seq) {
+				t.Fatalf("rows out of order: %+v before %+v", prev, item)
+			}
+		}
+	}
+}
+
+func TestSelectIterator_FiltersAndLimit(t *testing.T) {
+	db := openIteratorTestDB(t)
+	table := NewTable[iterEvent](db, "events")
+
+	items := collect(t, table.SelectIterator(context.Background(), SelectOptions{
+		Filters:   map[string]interface{}{"session_id": "a"},
+		Where:     "seq >= ?",
+		WhereArgs: []interface{}{10},
+		BatchSize: 2,
+		Limit:     5,
+	}))
+	if len(items) != 5 {
+		t.Fatalf("expected 5 rows, got %d", len(items))
+	}
+	for _, item := range items {
+		if item.SessionID != "a" || item.Seq < 10 {
+			t.Fatalf("unexpected row %+v", item)
+		}
+	}
+	if items[0].Seq != 10 || items[4].Seq != 18 {
+		t.Fatalf("unexpected rows: first %d, last %d", items[0].Seq, items[4].Seq)
+	}
+}
+
+func TestSelectIterator_OffsetPaging(t *testing.T) {
+	db := openIteratorTestDB(t)
+
+	// Custom order on a keyed table.
+	events := collect(t, NewTable[iterEvent](db, "events").SelectIterator(context.Background(), SelectOptions{
+		OrderBy:   []string{"-seq"},
+		BatchSize: 7,
+	}))
+	if len(events) != 25 || events[0].Seq != 24 || events[24].Seq != 0 {
+		t.Fatalf("unexpected descending rows: %d rows", len(events))
+	}
+
+	// Table without a primary key.
+	logs := collect(t, NewTable[iterLog](db, "logs").SelectIterator(context.Background(), SelectOptions{BatchSize: 10}))
+	if len(logs) != 25 {
+		t.Fatalf("expected 25 rows, got %d", len(logs))
+	}
+	for i, l := range logs {
+		if l.Seq != int64(i) {
+			t.Fatalf("expected insertion order, got seq %d at %d", l.Seq, i)
+		}
+	}
+}
+
+func TestSelectIterator_Error(t *testing.T) {
+	db := openIteratorTestDB(t)
+	it := NewTable[iterEvent](db, "missing").SelectIterator(context.Background(), SelectOptions{})
+	if it.Next() {
+		t.Fatal("expected no rows")
+	}
+	if it.Err() == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,170 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// MigrationsTable records the schema migrations applied to a database.
+const MigrationsTable = "schema_migrations"
+
+// Migration is a versioned schema change. Exactly one of SQL or Func is set.
+//
+// Migrations of a scope are applied in version order, each in its own
+// transaction together with its schema_migrations record. Once released, a
+// migration must never be edited or removed; fix mistakes with a new one.
+type Migration struct {
+	// Version orders the migrations of a scope. Versions start at 1 and
+	// increase with every migration.
+	Version int
+
+	// Name is a short description, e.g. "add_services_namespace".
+	Name string
+
+	// SQL holds one or more statements executed as-is.
+	SQL string
+
+	// Func is a Go migration, for changes that need data transformation.
+	Func func(ctx context.Context, tx *sql.Tx) error
+}
+
+// Migrate applies the migrations of scope that are not yet recorded in the
+// schema_migrations table. It returns the migrations applied or, when dryRun
+// is set, the migrations that would be applied; a dry run does not write to
+// the database, so it works on read-only connections.
+//
+// The scope separates the migrations of components sharing a database, such
+// as the agent storages. Migrate fails if the database holds a migration
+// newer than the latest known one, i.e. it was written by a newer Coral
+// version.
+func Migrate(ctx context.Context, db *sql.DB, scope string, migrations []Migration, dryRun bool) ([]Migration, error) {
+	if err := validateMigrations(migrations); err != nil {
+		return nil, fmt.Errorf("invalid %s migrations: %w", scope, err)
+	}
+
+	if !dryRun {
+		if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+MigrationsTable+` (
+			scope VARCHAR NOT NULL,
+			version INTEGER NOT NULL,
+			name VARCHAR NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (scope, version)
+		)`); err != nil {
+			return nil, fmt.Errorf("failed to create migrations table: %w", err)
+		}
+	}
+
+	current, err := currentVersion(ctx, db, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+	if current > latest {
+		return nil, fmt.Errorf("%s schema is at version %d, newer than the latest known migration %d; upgrade coral", scope, current, latest)
+	}
+
+	var pending []Migration
+	for _, m := range migrations {
+		if m.Version > current {
+			pending = append(pending, m)
+		}
+	}
+	if dryRun {
+		return pending, nil
+	}
+
+	for i, m := range pending {
+		if err := applyMigration(ctx, db, scope, m); err != nil {
+			return pending[:i], fmt.Errorf("failed to apply %s migration %d (%s): %w", scope, m.Version, m.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// ApplyMigrations applies the pending migrations of scope and logs each
+// applied migration. Storages call it after creating their baseline schema.
+func ApplyMigrations(ctx context.Context, db *sql.DB, scope string, migrations []Migration, logger zerolog.Logger) error {
+	applied, err := Migrate(ctx, db, scope, migrations, false)
+	for _, m := range applied {
+		logger.Info().
+			Str("scope", scope).
+			Int("version", m.Version).
+			Str("name", m.Name).
+			Msg("Applied schema migration")
+	}
+	return err
+}
+
+// validateMigrations checks that migrations are well-formed and ordered.
+func validateMigrations(migrations []Migration) error {
+	prev := 0
+	for _, m := range migrations {
+		if m.Version <= prev {
+			return fmt.Errorf("migration %d (%s) is out of order", m.Version, m.Name)
+		}
+		if m.Name == "" {
+			return fmt.Errorf("migration %d has no name", m.Version)
+		}
+		if (m.SQL == "") == (m.Func == nil) {
+			return fmt.Errorf("migration %d (%s) must set exactly one of SQL or Func", m.Version, m.Name)
+		}
+		prev = m.Version
+	}
+	return nil
+}
+
+// currentVersion returns the latest migration applied in scope, or 0.
+func currentVersion(ctx context.Context, db *sql.DB, scope string) (int, error) {
+	var tables int
+	if err := db.QueryRowContext(ctx,
+		`SELECT count(*) FROM information_schema.tables WHERE table_name = ?`, MigrationsTable,
+	).Scan(&tables); err != nil {
+		return 0, fmt.Errorf("failed to check migrations table: %w", err)
+	}
+	if tables == 0 {
+		return 0, nil
+	}
+
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx,
+		`SELECT max(version) FROM `+MigrationsTable+` WHERE scope = ?`, scope,
+	).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+// applyMigration runs a migration and records it in one transaction.
+func applyMigration(ctx context.Context, db *sql.DB, scope string, m Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if m.Func != nil {
+		err = m.Func(ctx, tx)
+	} else {
+		_, err = tx.ExecContext(ctx, m.SQL)
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO `+MigrationsTable+` (scope, version, name, applied_at) VALUES (?, ?, ?, ?)`,
+		scope, m.Version, m.Name, time.Now().UTC(),
+	); err != nil {
+		return fmt.Errorf("failed to record migration: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,136 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func openMigrateTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.duckdb"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_items", SQL: `CREATE TABLE items (id INTEGER PRIMARY KEY, name VARCHAR)`},
+	{Version: 2, Name: "add_items_label", SQL: `ALTER TABLE items ADD COLUMN label VARCHAR`},
+	{Version: 3, Name: "backfill_items_label", Func: func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE items SET label = upper(name)`)
+		return err
+	}},
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	db := openMigrateTestDB(t)
+
+	// Dry run on a fresh database lists everything and writes nothing.
+	pending, err := Migrate(ctx, db, "test", testMigrations[:2], true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending migrations, got %d", len(pending))
+	}
+	var tables int
+	_ = db.QueryRow(`SELECT count(*) FROM information_schema.tables WHERE table_name IN ('items', ?)`, MigrationsTable).Scan(&tables)
+	if tables != 0 {
+		t.Fatalf("dry run created %d tables", tables)
+	}
+
+	applied, err := Migrate(ctx, db, "test", testMigrations[:2], false)
+	if err != nil || len(applied) != 2 {
+		t.Fatalf("expected 2 applied migrations, got %d (err=%v)", len(applied), err)
+	}
+	if _, err := db.Exec(`INSERT INTO items VALUES (1, 'a', NULL)`); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	// A newer release adds a Go migration; only it is applied.
+	applied, err = Migrate(ctx, db, "test", testMigrations, false)
+	if err != nil || len(applied) != 1 || applied[0].Version != 3 {
+		t.Fatalf("expected migration 3 to be applied, got %+v (err=%v)", applied, err)
+	}
+	var label string
+	if err := db.QueryRow(`SELECT label FROM items WHERE id = 1`).Scan(&label); err != nil || label != "A" {
+		t.Fatalf("expected backfilled label, got %q (err=%v)", label, err)
+	}
+
+	// Re-running is a no-op, and other scopes are tracked separately.
+	if applied, err := Migrate(ctx, db, "test", testMigrations, false); err != nil || len(applied) != 0 {
+		t.Fatalf("expected no migrations, got %d (err=%v)", len(applied), err)
+	}
+	if pending, err := Migrate(ctx, db, "other", testMigrations[:1], true); err != nil || len(pending) != 1 {
+		t.Fatalf("expected other scope to be pending, got %d (err=%v)", len(pending), err)
+	}
+}
+
+func TestMigrate_FailedMigrationIsRolledBack(t *testing.T) {
+	ctx := context.Background()
+	db := openMigrateTestDB(t)
+
+	migrations := []Migration{
+		{Version: 1, Name: "create_items", SQL: `CREATE TABLE items (id INTEGER)`},
+		{Version: 2, Name: "broken", SQL: `CREATE TABLE other (id INTEGER); ALTER TABLE missing ADD COLUMN x INTEGER`},
+	}
+	applied, err := Migrate(ctx, db, "test", migrations, false)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if len(applied) != 1 {
+		t.Fatalf("expected migration 1 to be applied, got %d", len(applied))
+	}
+
+	var tables int
+	_ = db.QueryRow(`SELECT count(*) FROM information_schema.tables WHERE table_name = 'other'`).Scan(&tables)
+	if tables != 0 {
+		t.Fatal("expected failed migration to be rolled back")
+	}
+	if pending, err := Migrate(ctx, db, "test", migrations, true); err != nil || len(pending) != 1 || pending[0].Version != 2 {
+		t.Fatalf("expected migration 2 to remain pending, got %+v (err=%v)", pending, err)
+	}
+}
+
+func TestMigrate_Validation(t *testing.T) {
+	ctx := context.Background()
+	db := openMigrateTestDB(t)
+
+	tests := []struct {
+		name       string
+		migrations []Migration
+		wantErr    string
+	}{
+		{"out of order", []Migration{{Version: 2, Name: "b", SQL: "SELECT 1"}, {Version: 1, Name: "a", SQL: "SELECT 1"}}, "out of order"},
+		{"duplicate", []Migration{{Version: 1, Name: "a", SQL: "SELECT 1"}, {Version: 1, Name: "b", SQL: "SELECT 1"}}, "out of order"},
+		{"no name", []Migration{{Version: 1, SQL: "SELECT 1"}}, "no name"},
+		{"no body", []Migration{{Version: 1, Name: "a"}}, "exactly one"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Migrate(ctx, db, "test", tt.migrations, false)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMigrate_RejectsNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	db := openMigrateTestDB(t)
+
+	if _, err := Migrate(ctx, db, "test", testMigrations, false); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	_, err := Migrate(ctx, db, "test", testMigrations[:1], false)
+	if err == nil || !strings.Contains(err.Error(), "newer than the latest known migration") {
+		t.Fatalf("expected newer schema error, got %v", err)
+	}
+}
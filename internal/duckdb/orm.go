@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -27,10 +29,19 @@ type Table[T any] struct {
 	pkColumns       []string
 	immutableFields map[string]bool // Fields that can't be updated
 	fieldMap        map[string]int  // Map column name to field index
+	versionColumn   string          // Optimistic concurrency column, if any
 }
 
+// ErrVersionConflict is returned by Update when the row's version column no
+// longer matches the item, i.e. the row was modified since it was read.
+var ErrVersionConflict = errors.New("row was modified concurrently (version mismatch)")
+
 // NewTable creates a new Table[T] instance.
-// T must be a struct with `duckdb` tags.
+// T must be a struct with `duckdb` tags. Tag options:
+//   - pk: part of the primary key
+//   - immutable: never changed by upserts and updates
+//   - version: integer column for optimistic concurrency; Update only
+//     succeeds if it still matches, and every update increments it
 func NewTable[T any](db Execer, tableName string) *Table[T] {
 	var zero T
 	t := reflect.TypeOf(zero)
@@ -45,6 +56,7 @@ func NewTable[T any](db Execer, tableName string) *Table[T] {
 	var pkColumns []string
 	immutableFields := make(map[string]bool)
 	fieldMap := make(map[string]int)
+	var versionColumn string
 
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
@@ -65,6 +77,11 @@ func NewTable[T any](db Execer, tableName string) *Table[T] {
 				pkColumns = append(pkColumns, colName)
 			case "immutable":
 				immutableFields[colName] = true
+			case "version":
+				if k := field.Type.Kind(); k < reflect.Int || k > reflect.Int64 {
+					panic("version column must be a signed integer field")
+				}
+				versionColumn = colName
 			}
 		}
 	}
@@ -76,6 +93,7 @@ func NewTable[T any](db Execer, tableName string) *Table[T] {
 		pkColumns:       pkColumns,
 		immutableFields: immutableFields,
 		fieldMap:        fieldMap,
+		versionColumn:   versionColumn,
 	}
 }
 
@@ -331,6 +349,9 @@ func (t *Table[T]) Get(ctx context.Context, id any) (*T, error) {
 // Update updates an existing item in the database using a regular UPDATE statement.
 // This is useful for updating indexed columns that DuckDB doesn't allow in ON CONFLICT.
 // Only non-PK and non-immutable fields will be updated.
+// If the table has a version column, the row is only updated if its version
+// still matches the item's, otherwise ErrVersionConflict is returned; on
+// success the version is incremented in the row and in item.
 func (t *Table[T]) Update(ctx context.Context, item *T) error {
 	if len(t.pkColumns) == 0 {
 		return errors.New("no primary key defined for table")
@@ -353,8 +374,8 @@ func (t *Table[T]) Update(ctx context.Context, item *T) error {
 				break
 			}
 		}
-		// Skip PKs and immutable fields
-		if !isPK && !t.immutableFields[col] {
+		// Skip PKs, immutable fields and the version column
+		if !isPK && !t.immutableFields[col] && col != t.versionColumn {
 			setClauses = append(setClauses, fmt.Sprintf("%s = ?", col))
 			fieldIdx := t.fieldMap[col]
 			values = append(values, val.Field(fieldIdx).Interface())
@@ -373,6 +394,15 @@ func (t *Table[T]) Update(ctx context.Context, item *T) error {
 		values = append(values, val.Field(fieldIdx).Interface())
 	}
 
+	// Optimistic concurrency: only update the version that was read.
+	var version reflect.Value
+	if t.versionColumn != "" {
+		version = val.Field(t.fieldMap[t.versionColumn])
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", t.versionColumn, t.versionColumn))
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", t.versionColumn))
+		values = append(values, version.Interface())
+	}
+
 	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s",
 		t.tableName,
 		strings.Join(setClauses, ", "),
@@ -398,12 +428,35 @@ func (t *Table[T]) Update(ctx context.Context, item *T) error {
 			return fmt.Errorf("failed to get rows affected: %w", err)
 		}
 		if rows == 0 {
+			if version.IsValid() && t.exists(ctx, val) {
+				return ErrVersionConflict
+			}
 			return fmt.Errorf("no rows updated (record may not exist)")
 		}
+		if version.IsValid() {
+			version.SetInt(version.Int() + 1)
+		}
 		return nil
 	}, isTransactionConflict)
 }
 
+// exists reports whether a row with the primary key of val exists.
+func (t *Table[T]) exists(ctx context.Context, val reflect.Value) bool {
+	var whereClauses []string
+	var values []interface{}
+	for _, pk := range t.pkColumns {
+		whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", pk))
+		values = append(values, val.Field(t.fieldMap[pk]).Interface())
+	}
+
+	query := fmt.Sprintf("SELECT count(*) FROM %s WHERE %s", t.tableName, strings.Join(whereClauses, " AND "))
+	var count int
+	if err := t.db.QueryRowContext(ctx, query, values...).Scan(&count); err != nil {
+		return false
+	}
+	return count > 0
+}
+
 // UpdateFields updates specific fields of an item by PK.
 // This bypasses immutability checks and is useful for updating indexed fields.
 // fieldUpdates is a map of column names to new values.
@@ -435,6 +488,12 @@ func (t *Table[T]) UpdateFields(ctx context.Context, pk any, fieldUpdates map[st
 		values = append(values, value)
 	}
 
+	if t.versionColumn != "" {
+		if _, set := fieldUpdates[t.versionColumn]; !set {
+			setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", t.versionColumn, t.versionColumn))
+		}
+	}
+
 	// Add PK to WHERE clause
 	values = append(values, pk)
 
@@ -480,6 +539,92 @@ func (t *Table[T]) Delete(ctx context.Context, id any) error {
 	return err
 }
 
+// UpdateWhere sets columns to the given values on all rows matching filter,
+// a builder whose conditions (Eq, Lt, TimeRange, Where, ...) select the rows;
+// see NewFilter. It increments the version column, if any, and returns the
+// number of rows updated. A filter without conditions is rejected so a
+// skipped wildcard filter cannot update the whole table.
+func (t *Table[T]) UpdateWhere(ctx context.Context, updates map[string]interface{}, filter *Builder) (int64, error) {
+	if len(updates) == 0 {
+		return 0, errors.New("no fields to update")
+	}
+	where, whereArgs, err := filterSQL(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var setClauses []string
+	var values []interface{}
+	for _, col := range slices.Sorted(maps.Keys(updates)) {
+		if _, exists := t.fieldMap[col]; !exists {
+			return 0, fmt.Errorf("column %s does not exist in table %s", col, t.tableName)
+		}
+		if slices.Contains(t.pkColumns, col) {
+			return 0, fmt.Errorf("cannot update primary key column %s", col)
+		}
+		if col == t.versionColumn {
+			return 0, fmt.Errorf("cannot set version column %s", col)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("%s = ?", col))
+		values = append(values, convertFieldValue(updates[col]))
+	}
+	if t.versionColumn != "" {
+		setClauses = append(setClauses, fmt.Sprintf("%s = %s + 1", t.versionColumn, t.versionColumn))
+	}
+	values = append(values, whereArgs...)
+
+	// #nosec G201 - table and column names are not user input, they come from struct tags
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", t.tableName, strings.Join(setClauses, ", "), where)
+	return t.execAffected(ctx, query, values)
+}
+
+// DeleteWhere deletes all rows matching filter and returns the number of rows
+// deleted. Like UpdateWhere, it rejects a filter without conditions.
+func (t *Table[T]) DeleteWhere(ctx context.Context, filter *Builder) (int64, error) {
+	where, args, err := filterSQL(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	// #nosec G201 - table name is not user input
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", t.tableName, where)
+	return t.execAffected(ctx, query, args)
+}
+
+// filterSQL returns the WHERE conditions of a filter builder.
+func filterSQL(filter *Builder) (string, []interface{}, error) {
+	if filter == nil || len(filter.where) == 0 {
+		return "", nil, errors.New("filter has no conditions")
+	}
+	where, args := filter.whereSQL()
+	return where, args, nil
+}
+
+// execAffected runs a statement with conflict retries and returns the number
+// of affected rows.
+func (t *Table[T]) execAffected(ctx context.Context, query string, values []interface{}) (int64, error) {
+	cfg := retry.Config{
+		MaxRetries:     10,
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     500 * time.Millisecond,
+		Jitter:         0.1,
+	}
+
+	var affected int64
+	err := retry.Do(ctx, cfg, func() error {
+		result, err := t.db.ExecContext(ctx, query, values...)
+		if err != nil {
+			return err
+		}
+		affected, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		return nil
+	}, isTransactionConflict)
+	return affected, err
+}
+
 // List retrieves all items with optional filters.
 // filters are simple "column = value" pairs.
 func (t *Table[T]) List(ctx context.Context, filters map[string]interface{}) ([]*T, error) {
@@ -0,0 +1,125 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type ormRule struct {
+	ID        string    `duckdb:"id,pk"`
+	Status    string    `duckdb:"status"`
+	UpdatedAt time.Time `duckdb:"updated_at"`
+	Version   int64     `duckdb:"version,version"`
+}
+
+func openORMTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := OpenDB(filepath.Join(t.TempDir(), "test.duckdb"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.Exec(`CREATE TABLE rules (id VARCHAR PRIMARY KEY, status VARCHAR, updated_at TIMESTAMP, version BIGINT NOT NULL DEFAULT 0)`); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	return db
+}
+
+func TestTable_UpdateOptimisticConcurrency(t *testing.T) {
+	ctx := context.Background()
+	table := NewTable[ormRule](openORMTestDB(t), "rules")
+
+	if err := table.Insert(ctx, &ormRule{ID: "r1", Status: "ok", UpdatedAt: time.Now()}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	// Two readers load the same version.
+	a, err := table.Get(ctx, "r1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	b, _ := table.Get(ctx, "r1")
+
+	a.Status = "firing"
+	if err := table.Update(ctx, a); err != nil {
+		t.Fatalf("first update: %v", err)
+	}
+	if a.Version != 1 {
+		t.Fatalf("expected in-memory version 1, got %d", a.Version)
+	}
+
+	// The second writer holds a stale version.
+	b.Status = "resolved"
+	if err := table.Update(ctx, b); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+
+	got, _ := table.Get(ctx, "r1")
+	if got.Status != "firing" || got.Version != 1 {
+		t.Fatalf("unexpected row %+v", got)
+	}
+
+	// A missing row is not a version conflict.
+	err = table.Update(ctx, &ormRule{ID: "missing", Status: "ok"})
+	if err == nil || errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected missing row error, got %v", err)
+	}
+}
+
+func TestTable_UpdateWhereDeleteWhere(t *testing.T) {
+	ctx := context.Background()
+	table := NewTable[ormRule](openORMTestDB(t), "rules")
+
+	now := time.Now().UTC()
+	for _, r := range []*ormRule{
+		{ID: "old-1", Status: "ok", UpdatedAt: now.Add(-48 * time.Hour)},
+		{ID: "old-2", Status: "firing", UpdatedAt: now.Add(-36 * time.Hour)},
+		{ID: "new-1", Status: "firing", UpdatedAt: now},
+	} {
+		if err := table.Insert(ctx, r); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+
+	updated, err := table.UpdateWhere(ctx, map[string]interface{}{"status": "resolved"}, NewFilter().Eq("status", "firing"))
+	if err != nil || updated != 2 {
+		t.Fatalf("expected 2 rows updated, got %d (err=%v)", updated, err)
+	}
+	got, _ := table.Get(ctx, "new-1")
+	if got.Status != "resolved" || got.Version != 1 {
+		t.Fatalf("expected resolved status and bumped version, got %+v", got)
+	}
+
+	deleted, err := table.DeleteWhere(ctx, NewFilter().Lt("updated_at", now.Add(-24*time.Hour)))
+	if err != nil || deleted != 2 {
+		t.Fatalf("expected 2 rows deleted, got %d (err=%v)", deleted, err)
+	}
+	remaining, _ := table.List(ctx, nil)
+	if len(remaining) != 1 || remaining[0].ID != "new-1" {
+		t.Fatalf("unexpected remaining rows %+v", remaining)
+	}
+}
+
+func TestTable_WhereRequiresConditions(t *testing.T) {
+	ctx := context.Background()
+	table := NewTable[ormRule](openORMTestDB(t), "rules")
+
+	// Eq skips empty strings, so this filter has no conditions.
+	if _, err := table.DeleteWhere(ctx, NewFilter().Eq("status", "")); err == nil {
+		t.Fatal("expected DeleteWhere without conditions to fail")
+	}
+	if _, err := table.UpdateWhere(ctx, map[string]interface{}{"status": "x"}, nil); err == nil {
+		t.Fatal("expected UpdateWhere without filter to fail")
+	}
+	if _, err := table.UpdateWhere(ctx, map[string]interface{}{"version": 5}, NewFilter().Eq("id", "r1")); err == nil {
+		t.Fatal("expected setting the version column to fail")
+	}
+	if _, err := table.UpdateWhere(ctx, map[string]interface{}{"id": "r2"}, NewFilter().Eq("id", "r1")); err == nil {
+		t.Fatal("expected updating the primary key to fail")
+	}
+}
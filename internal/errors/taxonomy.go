@@ -0,0 +1,219 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"maps"
+	"net"
+	"net/url"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+)
+
+// Error is a failure classified by an ErrorCode of the error taxonomy.
+// Handlers convert it to a Connect error carrying an ErrorInfo detail with
+// ToConnect, or report its Info in a response field, so that the code
+// survives the agent to colony to CLI hops.
+type Error struct {
+	Code     errorsv1.ErrorCode
+	Metadata map[string]string
+	Err      error
+}
+
+// New classifies err with code. Metadata is given as key-value pairs, such as
+// "service", "api".
+func New(code errorsv1.ErrorCode, err error, keyvals ...string) *Error {
+	e := &Error{Code: code, Err: err}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if e.Metadata == nil {
+			e.Metadata = make(map[string]string)
+		}
+		e.Metadata[keyvals[i]] = keyvals[i+1]
+	}
+	return e
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// connectCodes are the Connect codes of the taxonomy codes.
+var connectCodes = map[errorsv1.ErrorCode]connect.Code{
+	errorsv1.ErrorCode_ERROR_CODE_AGENT_UNREACHABLE:   connect.CodeUnavailable,
+	errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND:     connect.CodeNotFound,
+	errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND:   connect.CodeNotFound,
+	errorsv1.ErrorCode_ERROR_CODE_PROBE_ATTACH_FAILED: connect.CodeFailedPrecondition,
+	errorsv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED:   connect.CodePermissionDenied,
+	errorsv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED:     connect.CodeUnauthenticated,
+	errorsv1.ErrorCode_ERROR_CODE_COLONY_UNREACHABLE:  connect.CodeUnavailable,
+	errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT:    connect.CodeInvalidArgument,
+	errorsv1.ErrorCode_ERROR_CODE_TIMEOUT:             connect.CodeDeadlineExceeded,
+	errorsv1.ErrorCode_ERROR_CODE_AGENT_DRAINED:       connect.CodeFailedPrecondition,
+	errorsv1.ErrorCode_ERROR_CODE_SERVICE_FROZEN:      connect.CodeFailedPrecondition,
+}
+
+// exitCodes are the CLI exit codes of the taxonomy codes. Unclassified
+// errors exit with 1.
+var exitCodes = map[errorsv1.ErrorCode]int{
+	errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT:    2,
+	errorsv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED:     3,
+	errorsv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED:   4,
+	errorsv1.ErrorCode_ERROR_CODE_COLONY_UNREACHABLE:  5,
+	errorsv1.ErrorCode_ERROR_CODE_AGENT_UNREACHABLE:   6,
+	errorsv1.ErrorCode_ERROR_CODE_AGENT_NOT_FOUND:     7,
+	errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND:   8,
+	errorsv1.ErrorCode_ERROR_CODE_PROBE_ATTACH_FAILED: 9,
+	errorsv1.ErrorCode_ERROR_CODE_TIMEOUT:             10,
+	errorsv1.ErrorCode_ERROR_CODE_AGENT_DRAINED:       11,
+	errorsv1.ErrorCode_ERROR_CODE_SERVICE_FROZEN:      12,
+}
+
+// ToConnect converts a classified err to a Connect error with an ErrorInfo
+// detail and the matching Connect code. The message is that of err, including
+// any context wrapped around the classified error. Other errors are returned
+// unchanged.
+func ToConnect(err error) error {
+	var e *Error
+	if !stderrors.As(err, &e) {
+		return err
+	}
+
+	code, ok := connectCodes[e.Code]
+	if !ok {
+		code = connect.CodeUnknown
+	}
+	connectErr := connect.NewError(code, stderrors.New(err.Error()))
+	if detail, detailErr := connect.NewErrorDetail(&errorsv1.ErrorInfo{Code: e.Code, Metadata: e.Metadata}); detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// Info returns the ErrorInfo of a classified err, for responses that report
+// failures in a field rather than as an error, or nil.
+func Info(err error) *errorsv1.ErrorInfo {
+	var e *Error
+	if stderrors.As(err, &e) {
+		return &errorsv1.ErrorInfo{Code: e.Code, Metadata: e.Metadata}
+	}
+	return detailOf(err)
+}
+
+// FromInfo classifies err with the code and metadata of info, as reported in
+// a response. err is returned unchanged without info.
+func FromInfo(info *errorsv1.ErrorInfo, err error) error {
+	if info == nil || info.Code == errorsv1.ErrorCode_ERROR_CODE_UNSPECIFIED {
+		return err
+	}
+	return &Error{Code: info.Code, Metadata: info.Metadata, Err: err}
+}
+
+// FromAgent classifies the error of a call from the colony to an agent. The
+// code and metadata of an agent's ErrorInfo are kept; otherwise, an agent
+// that cannot be reached or does not answer in time is AgentUnreachable.
+func FromAgent(agentID string, err error) error {
+	info := detailOf(err)
+	switch {
+	case info != nil:
+		metadata := maps.Clone(info.Metadata)
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		metadata["agent_id"] = agentID
+		return &Error{Code: info.Code, Metadata: metadata, Err: err}
+	case connect.CodeOf(err) == connect.CodeUnavailable,
+		connect.CodeOf(err) == connect.CodeDeadlineExceeded,
+		isTransportError(err):
+		return New(errorsv1.ErrorCode_ERROR_CODE_AGENT_UNREACHABLE, err, "agent_id", agentID)
+	}
+	return err
+}
+
+// CodeOf returns the taxonomy code of err: the code of a classified error or
+// of an ErrorInfo detail, or else one inferred from the Connect code. Errors
+// that cannot be classified have ERROR_CODE_UNSPECIFIED.
+func CodeOf(err error) errorsv1.ErrorCode {
+	code, _ := Classify(err)
+	return code
+}
+
+// Classify returns the taxonomy code and metadata of err, as CodeOf.
+func Classify(err error) (errorsv1.ErrorCode, map[string]string) {
+	if err == nil {
+		return errorsv1.ErrorCode_ERROR_CODE_UNSPECIFIED, nil
+	}
+	if info := Info(err); info != nil {
+		return info.Code, info.Metadata
+	}
+
+	// Errors without an ErrorInfo, such as those of the HTTP middlewares or
+	// raised by the Connect client itself.
+	switch connect.CodeOf(err) {
+	case connect.CodePermissionDenied:
+		return errorsv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED, nil
+	case connect.CodeUnauthenticated:
+		return errorsv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED, nil
+	case connect.CodeInvalidArgument:
+		return errorsv1.ErrorCode_ERROR_CODE_INVALID_ARGUMENT, nil
+	case connect.CodeDeadlineExceeded:
+		return errorsv1.ErrorCode_ERROR_CODE_TIMEOUT, nil
+	case connect.CodeUnavailable:
+		if isTransportError(err) {
+			return errorsv1.ErrorCode_ERROR_CODE_COLONY_UNREACHABLE, nil
+		}
+	}
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return errorsv1.ErrorCode_ERROR_CODE_TIMEOUT, nil
+	}
+	return errorsv1.ErrorCode_ERROR_CODE_UNSPECIFIED, nil
+}
+
+// ExitCode returns the CLI exit code of err: 0 without error, a distinct code
+// for each taxonomy code, and 1 for other errors.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if code, ok := exitCodes[CodeOf(err)]; ok {
+		return code
+	}
+	return 1
+}
+
+// CodeName returns the short name of a code, such as "SERVICE_NOT_FOUND".
+func CodeName(code errorsv1.ErrorCode) string {
+	return strings.TrimPrefix(code.String(), "ERROR_CODE_")
+}
+
+// detailOf returns the ErrorInfo detail of a Connect error in err's chain.
+func detailOf(err error) *errorsv1.ErrorInfo {
+	var connectErr *connect.Error
+	if !stderrors.As(err, &connectErr) {
+		return nil
+	}
+	for _, detail := range connectErr.Details() {
+		msg, valueErr := detail.Value()
+		if valueErr != nil {
+			continue
+		}
+		if info, ok := msg.(*errorsv1.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// isTransportError reports whether err comes from failing to reach the
+// server rather than from its response.
+func isTransportError(err error) bool {
+	var urlErr *url.Error
+	var netErr net.Error
+	return stderrors.As(err, &urlErr) || stderrors.As(err, &netErr)
+}
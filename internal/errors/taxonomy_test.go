@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	agentv1 "github.com/coral-mesh/coral/coral/agent/v1"
+	"github.com/coral-mesh/coral/coral/agent/v1/agentv1connect"
+	errorsv1 "github.com/coral-mesh/coral/coral/errors/v1"
+)
+
+func TestToConnect(t *testing.T) {
+	err := fmt.Errorf("failed to resolve sdk_addr: %w",
+		New(errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND, errors.New("service not found: api"), "service", "api"))
+
+	connectErr := ToConnect(err)
+	if got := connect.CodeOf(connectErr); got != connect.CodeNotFound {
+		t.Errorf("connect code = %v, want %v", got, connect.CodeNotFound)
+	}
+	if got := connectErr.(*connect.Error).Message(); got != "failed to resolve sdk_addr: service not found: api" {
+		t.Errorf("message = %q", got)
+	}
+
+	code, metadata := Classify(connectErr)
+	if code != errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND {
+		t.Errorf("code = %v, want SERVICE_NOT_FOUND", code)
+	}
+	if metadata["service"] != "api" {
+		t.Errorf("metadata = %v, want service=api", metadata)
+	}
+
+	plain := errors.New("boom")
+	if ToConnect(plain) != plain {
+		t.Error("unclassified errors must be returned unchanged")
+	}
+}
+
+func TestFromAgent(t *testing.T) {
+	agentErr := ToConnect(New(errorsv1.ErrorCode_ERROR_CODE_PROBE_ATTACH_FAILED, errors.New("symbol not found"), "function", "main.handle"))
+	err := FromAgent("agent-1", fmt.Errorf("call failed: %w", agentErr))
+	code, metadata := Classify(err)
+	if code != errorsv1.ErrorCode_ERROR_CODE_PROBE_ATTACH_FAILED {
+		t.Errorf("code = %v, want PROBE_ATTACH_FAILED", code)
+	}
+	if metadata["function"] != "main.handle" || metadata["agent_id"] != "agent-1" {
+		t.Errorf("metadata = %v", metadata)
+	}
+
+	err = FromAgent("agent-2", connect.NewError(connect.CodeUnavailable, errors.New("connection refused")))
+	if got := CodeOf(err); got != errorsv1.ErrorCode_ERROR_CODE_AGENT_UNREACHABLE {
+		t.Errorf("code = %v, want AGENT_UNREACHABLE", got)
+	}
+
+	internal := connect.NewError(connect.CodeInternal, errors.New("boom"))
+	if FromAgent("agent-3", internal) != internal {
+		t.Error("other agent errors must be returned unchanged")
+	}
+}
+
+func TestClassifyFallback(t *testing.T) {
+	// A server that is not listening anymore.
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+	client := agentv1connect.NewAgentServiceClient(http.DefaultClient, url)
+	_, dialErr := client.ListServices(context.Background(), connect.NewRequest(&agentv1.ListServicesRequest{}))
+
+	tests := []struct {
+		name string
+		err  error
+		want errorsv1.ErrorCode
+		exit int
+	}{
+		{"nil", nil, errorsv1.ErrorCode_ERROR_CODE_UNSPECIFIED, 0},
+		{"plain", errors.New("boom"), errorsv1.ErrorCode_ERROR_CODE_UNSPECIFIED, 1},
+		{"permission denied", connect.NewError(connect.CodePermissionDenied, errors.New("forbidden")), errorsv1.ErrorCode_ERROR_CODE_PERMISSION_DENIED, 4},
+		{"unauthenticated", connect.NewError(connect.CodeUnauthenticated, errors.New("no token")), errorsv1.ErrorCode_ERROR_CODE_UNAUTHENTICATED, 3},
+		{"server unavailable", connect.NewError(connect.CodeUnavailable, errors.New("database not available")), errorsv1.ErrorCode_ERROR_CODE_UNSPECIFIED, 1},
+		{"colony unreachable", fmt.Errorf("failed to list services: %w", dialErr), errorsv1.ErrorCode_ERROR_CODE_COLONY_UNREACHABLE, 5},
+		{"deadline", fmt.Errorf("wait: %w", context.DeadlineExceeded), errorsv1.ErrorCode_ERROR_CODE_TIMEOUT, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf() = %v, want %v", got, tt.want)
+			}
+			if got := ExitCode(tt.err); got != tt.exit {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.exit)
+			}
+		})
+	}
+}
+
+func TestExitCodesAreDistinct(t *testing.T) {
+	seen := make(map[int]errorsv1.ErrorCode)
+	for code := range errorsv1.ErrorCode_name {
+		c := errorsv1.ErrorCode(code)
+		if c == errorsv1.ErrorCode_ERROR_CODE_UNSPECIFIED {
+			continue
+		}
+		exit, ok := exitCodes[c]
+		if !ok {
+			t.Errorf("%s has no exit code", c)
+			continue
+		}
+		if exit <= 1 {
+			t.Errorf("%s exit code %d collides with success or generic failure", c, exit)
+		}
+		if other, dup := seen[exit]; dup {
+			t.Errorf("%s and %s share exit code %d", c, other, exit)
+		}
+		seen[exit] = c
+		if _, ok := connectCodes[c]; !ok {
+			t.Errorf("%s has no Connect code", c)
+		}
+	}
+	if got := CodeName(errorsv1.ErrorCode_ERROR_CODE_SERVICE_NOT_FOUND); got != "SERVICE_NOT_FOUND" {
+		t.Errorf("CodeName() = %q", got)
+	}
+}
@@ -0,0 +1,144 @@
+// Package metrics exports operational metrics in the Prometheus text
+// exposition format (version 0.0.4), so that colonies and agents can be
+// scraped by an existing Prometheus stack.
+//
+// Metrics are gathered on each scrape by a collect function writing samples
+// to a Writer; there is no registry of long-lived metric objects.
+//
+// # Basic Usage
+//
+//	mux.Handle("/metrics", metrics.Handler(func(ctx context.Context, w *metrics.Writer) {
+//	    w.Gauge("coral_agent_services", "Services monitored by the agent.", float64(count))
+//	    w.Counter("coral_agent_events_dropped_total", "Events dropped.", float64(dropped),
+//	        metrics.L("reason", "queue_full"))
+//	}))
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Label is a metric label.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// L returns a label.
+func L(name, value string) Label {
+	return Label{Name: name, Value: value}
+}
+
+// Writer writes metric samples in the Prometheus text format. The HELP and
+// TYPE lines of a metric are written before its first sample, so all samples
+// of a metric must be written consecutively.
+type Writer struct {
+	w        io.Writer
+	err      error
+	declared map[string]bool
+}
+
+// NewWriter returns a writer writing to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w, declared: make(map[string]bool)}
+}
+
+// Gauge writes a gauge sample.
+func (w *Writer) Gauge(name, help string, value float64, labels ...Label) {
+	w.sample(name, "gauge", help, value, labels)
+}
+
+// Counter writes a counter sample. By convention, counter names end with
+// "_total".
+func (w *Writer) Counter(name, help string, value float64, labels ...Label) {
+	w.sample(name, "counter", help, value, labels)
+}
+
+// Err returns the first error encountered while writing.
+func (w *Writer) Err() error {
+	return w.err
+}
+
+func (w *Writer) sample(name, typ, help string, value float64, labels []Label) {
+	if w.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	if !w.declared[name] {
+		w.declared[name] = true
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, escapeHelp(help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, typ)
+	}
+
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(&b, "%s=\"%s\"", l.Name, escapeLabelValue(l.Value))
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(' ')
+	b.WriteString(formatValue(value))
+	b.WriteByte('\n')
+
+	_, w.err = io.WriteString(w.w, b.String())
+}
+
+// Handler returns an HTTP handler serving the samples written by collect.
+// The output is buffered so that a failed collection does not produce a
+// truncated response.
+func Handler(collect func(ctx context.Context, w *Writer)) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		w := NewWriter(&buf)
+		collect(r.Context(), w)
+		if err := w.Err(); err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", ContentType)
+		_, _ = rw.Write(buf.Bytes())
+	})
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
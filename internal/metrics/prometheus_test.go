@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	var b strings.Builder
+	w := NewWriter(&b)
+
+	w.Gauge("coral_agents", "Registered agents.", 2, L("status", "healthy"))
+	w.Gauge("coral_agents", "Registered agents.", 1, L("status", "degraded"))
+	w.Counter("coral_polls_total", "Polls by agent.\nMultiline help.", 3, L("agent_id", `a"b\c`), L("poller", "beyla"))
+	w.Gauge("coral_latency_seconds", "Latency.", 0.25)
+	w.Gauge("coral_inf", "Infinity.", math.Inf(1))
+
+	want := `# HELP coral_agents Registered agents.
+# TYPE coral_agents gauge
+coral_agents{status="healthy"} 2
+coral_agents{status="degraded"} 1
+# HELP coral_polls_total Polls by agent.\nMultiline help.
+# TYPE coral_polls_total counter
+coral_polls_total{agent_id="a\"b\\c",poller="beyla"} 3
+# HELP coral_latency_seconds Latency.
+# TYPE coral_latency_seconds gauge
+coral_latency_seconds 0.25
+# HELP coral_inf Infinity.
+# TYPE coral_inf gauge
+coral_inf +Inf
+`
+	if got := b.String(); got != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	h := Handler(func(_ context.Context, w *Writer) {
+		w.Gauge("coral_up", "Whether the process is up.", 1)
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("expected content type %q, got %q", ContentType, ct)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	if !strings.Contains(string(body), "coral_up 1\n") {
+		t.Errorf("expected coral_up sample, got:\n%s", body)
+	}
+}
@@ -0,0 +1,98 @@
+// Package protocol defines the version of the agent-colony protocol and the
+// capabilities each version adds (RFD 018).
+//
+// Agents report their protocol version when they register. The colony
+// accepts versions from its configured minimum up to Version, and stops
+// using the capabilities an older agent lacks. Agents that report no version
+// predate negotiation and speak version 1.
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Version is the protocol version of this build.
+	Version = 2
+
+	// MinVersion is the oldest version the colony accepts by default.
+	MinVersion = 1
+)
+
+// CapabilityAgentUpdates: the agent installs releases the colony
+// advertises in heartbeat responses.
+const CapabilityAgentUpdates = "agent_updates"
+
+// Capability is a protocol feature that agents support from a version on.
+type Capability struct {
+	// Name identifies the capability, e.g. "agent_updates".
+	Name string
+
+	// Since is the protocol version that added the capability.
+	Since int
+
+	// Description says what the colony cannot do with older agents.
+	Description string
+}
+
+// Capabilities is the compatibility matrix: the capabilities added after
+// version 1, in the order they were added.
+var Capabilities = []Capability{
+	{
+		Name:        CapabilityAgentUpdates,
+		Since:       2,
+		Description: "install releases rolled out with `coral colony agents upgrade`",
+	},
+}
+
+// Parse parses a protocol version as reported by an agent. The empty string
+// is version 1.
+func Parse(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 1, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("invalid protocol version %q", s)
+	}
+	return v, nil
+}
+
+// Supports reports whether agents speaking version v have the capability
+// name. Unknown capabilities are not supported.
+func Supports(v int, name string) bool {
+	for _, c := range Capabilities {
+		if c.Name == name {
+			return v >= c.Since
+		}
+	}
+	return false
+}
+
+// Missing returns the capabilities of version colonyVersion that agents
+// speaking version v lack.
+func Missing(v, colonyVersion int) []Capability {
+	var missing []Capability
+	for _, c := range Capabilities {
+		if c.Since > v && c.Since <= colonyVersion {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+// Negotiate returns the version an agent speaking agentVersion and a colony
+// accepting versions from minVersion to maxVersion use, or an error if the
+// agent is too old. Agents newer than the colony fall back to maxVersion.
+func Negotiate(agentVersion, minVersion, maxVersion int) (int, error) {
+	if agentVersion < minVersion {
+		return 0, fmt.Errorf("agent protocol version %d is older than the minimum supported version %d", agentVersion, minVersion)
+	}
+	if agentVersion > maxVersion {
+		return maxVersion, nil
+	}
+	return agentVersion, nil
+}
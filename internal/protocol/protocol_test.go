@@ -0,0 +1,56 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	v, err := Parse("")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v, "agents predating negotiation speak version 1")
+
+	v, err = Parse("2")
+	require.NoError(t, err)
+	assert.Equal(t, 2, v)
+
+	for _, invalid := range []string{"0", "-1", "2.0.0", "v2"} {
+		_, err := Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	v, err := Negotiate(1, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	v, err = Negotiate(3, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, v, "newer agents fall back to the colony version")
+
+	_, err = Negotiate(1, 2, 2)
+	assert.Error(t, err)
+}
+
+func TestCapabilities(t *testing.T) {
+	for i, c := range Capabilities {
+		assert.Greater(t, c.Since, 1, c.Name)
+		assert.LessOrEqual(t, c.Since, Version, c.Name)
+		if i > 0 {
+			assert.GreaterOrEqual(t, c.Since, Capabilities[i-1].Since, "capabilities are in the order they were added")
+		}
+	}
+
+	assert.False(t, Supports(1, "agent_updates"))
+	assert.True(t, Supports(2, "agent_updates"))
+	assert.False(t, Supports(Version, "unknown"))
+
+	missing := Missing(1, 2)
+	require.Len(t, missing, 1)
+	assert.Equal(t, "agent_updates", missing[0].Name)
+	assert.Empty(t, Missing(2, 2))
+	assert.Empty(t, Missing(1, 1), "capabilities the colony lacks are not missing")
+}
@@ -174,6 +174,95 @@ func GetBinaryPath(pid int) (string, error) {
 	return exeLink, nil
 }
 
+// GetStartTime returns the start time of the given PID in clock ticks since
+// boot (field 22 of /proc/PID/stat). Together with the PID it uniquely
+// identifies a process instance, since PIDs are reused after a process exits.
+func GetStartTime(pid int) (uint64, error) {
+	field, err := readStatField(pid, 22)
+	if err != nil {
+		return 0, err
+	}
+
+	startTime, err := strconv.ParseUint(field, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse start time of PID %d: %w", pid, err)
+	}
+
+	return startTime, nil
+}
+
+// GetParentPID returns the parent PID of the given PID (field 4 of
+// /proc/PID/stat).
+func GetParentPID(pid int) (int, error) {
+	field, err := readStatField(pid, 4)
+	if err != nil {
+		return 0, err
+	}
+
+	ppid, err := strconv.Atoi(field)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse parent PID of PID %d: %w", pid, err)
+	}
+
+	return ppid, nil
+}
+
+// readStatField returns field n (numbered from 1, as in proc(5)) of
+// /proc/PID/stat. Fields before the state (3) are not supported.
+func readStatField(pid int, n int) (string, error) {
+	statPath := fmt.Sprintf("/proc/%d/stat", pid)
+	data, err := os.ReadFile(statPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", statPath, err)
+	}
+
+	// The command name (field 2) is wrapped in parentheses and may contain
+	// spaces, so parse the fields following the last closing parenthesis.
+	stat := string(data)
+	idx := strings.LastIndexByte(stat, ')')
+	if idx < 0 {
+		return "", fmt.Errorf("malformed %s", statPath)
+	}
+
+	// Fields after the command name start at field 3 (state).
+	fields := strings.Fields(stat[idx+1:])
+	if n < 3 || len(fields) <= n-3 {
+		return "", fmt.Errorf("malformed %s: too few fields", statPath)
+	}
+
+	return fields[n-3], nil
+}
+
+// Descendants returns the given PID followed by the PIDs of its children,
+// grandchildren and so on, e.g. the workers of a server.
+func Descendants(pid int) ([]int, error) {
+	pids, err := ListPids()
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[int][]int)
+	for _, p := range pids {
+		ppid, err := GetParentPID(p)
+		if err != nil {
+			continue // Exited since listed.
+		}
+		children[ppid] = append(children[ppid], p)
+	}
+
+	tree := []int{pid}
+	for i := 0; i < len(tree); i++ {
+		tree = append(tree, children[tree[i]]...)
+	}
+	return tree, nil
+}
+
+// Exists reports whether a process with the given PID is running.
+func Exists(pid int) bool {
+	_, err := os.Stat(fmt.Sprintf("/proc/%d", pid))
+	return err == nil
+}
+
 // InNamespacePath strips the /proc/PID/root prefix from a path returned by
 // GetBinaryPath, recovering the original in-namespace path as seen by the
 // target process. This is useful when matching against /proc/PID/maps entries
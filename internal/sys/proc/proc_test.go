@@ -58,3 +58,53 @@ func TestReadKallsyms(t *testing.T) {
 		t.Logf("ReadKallsyms found %d zero addresses (permissions)", zeroAddresses)
 	}
 }
+
+func TestGetStartTime(t *testing.T) {
+	startTime, err := GetStartTime(os.Getpid())
+	if err != nil {
+		// If /proc doesn't exist (macOS), it returns error.
+		if os.Getenv("GOOS") == "linux" {
+			t.Errorf("GetStartTime returned error on Linux: %v", err)
+		}
+		return
+	}
+
+	again, err := GetStartTime(os.Getpid())
+	if err != nil {
+		t.Fatalf("GetStartTime returned error on second call: %v", err)
+	}
+	if startTime != again {
+		t.Errorf("GetStartTime is not stable: %d != %d", startTime, again)
+	}
+}
+
+func TestDescendants(t *testing.T) {
+	ppid, err := GetParentPID(os.Getpid())
+	if err != nil {
+		// If /proc doesn't exist (macOS), it returns error.
+		if os.Getenv("GOOS") == "linux" {
+			t.Errorf("GetParentPID returned error on Linux: %v", err)
+		}
+		return
+	}
+	if ppid != os.Getppid() {
+		t.Errorf("GetParentPID = %d, want %d", ppid, os.Getppid())
+	}
+
+	pids, err := Descendants(ppid)
+	if err != nil {
+		t.Fatalf("Descendants returned error: %v", err)
+	}
+	if len(pids) < 2 || pids[0] != ppid {
+		t.Fatalf("Descendants(%d) = %v, want the PID followed by its descendants", ppid, pids)
+	}
+	found := false
+	for _, pid := range pids[1:] {
+		if pid == os.Getpid() {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Descendants(%d) = %v, missing %d", ppid, pids, os.Getpid())
+	}
+}
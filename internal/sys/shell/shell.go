@@ -37,45 +37,43 @@ type Session struct {
 	cmd    *exec.Cmd
 	pty    *os.File
 	cancel context.CancelFunc
+	done   chan struct{} // Closed once the process has exited.
 	mu     sync.Mutex
 }
 
+// ptyDrainTimeout is how long the PTY stays open after the process exited,
+// so that readers get the output still buffered in it. Processes the shell
+// left running in the background lose their terminal after it.
+const ptyDrainTimeout = 500 * time.Millisecond
+
 // StartConfig configuration for starting a shell session.
 type StartConfig struct {
-	Shell  string
-	UserID string
-	Env    map[string]string
-	Rows   uint16
-	Cols   uint16
+	Shell string
+	// Command runs instead of the shell if set.
+	Command []string
+	UserID  string
+	Env     map[string]string
+	Rows    uint16
+	Cols    uint16
 }
 
 // Start creates and starts a new shell session.
 func Start(ctx context.Context, config StartConfig) (*Session, error) {
-	// Determine shell to use.
-	shell := config.Shell
-	if shell == "" {
-		shell = "/bin/bash"
-	}
-
-	// Check if shell exists.
-	if _, err := os.Stat(shell); err != nil {
-		if os.IsNotExist(err) {
-			// Fallback to /bin/sh.
-			shell = "/bin/sh"
-			if _, err := os.Stat(shell); err != nil {
-				return nil, fmt.Errorf("no shell available: %w", err)
-			}
-		} else {
-			return nil, fmt.Errorf("failed to check shell: %w", err)
+	argv := config.Command
+	if len(argv) == 0 {
+		shell, err := resolveShell(config.Shell)
+		if err != nil {
+			return nil, err
 		}
+		argv = []string{shell}
 	}
 
 	// Create session context with cancellation.
 	sessionCtx, cancel := context.WithCancel(ctx)
 
 	// Create command.
-	//nolint:gosec // G204: shell is from user configuration, intentional
-	cmd := exec.CommandContext(sessionCtx, shell)
+	//nolint:gosec // G204: shell and command are chosen by the caller, intentional
+	cmd := exec.CommandContext(sessionCtx, argv[0], argv[1:]...)
 
 	// Set environment variables.
 	cmd.Env = os.Environ()
@@ -122,6 +120,7 @@ func Start(ctx context.Context, config StartConfig) (*Session, error) {
 		cmd:        cmd,
 		pty:        ptmx,
 		cancel:     cancel,
+		done:       make(chan struct{}),
 	}
 
 	// Set initial size if provided
@@ -136,6 +135,28 @@ func Start(ctx context.Context, config StartConfig) (*Session, error) {
 	return session, nil
 }
 
+// resolveShell returns shell, or /bin/bash if unset, falling back to /bin/sh
+// if it does not exist.
+func resolveShell(shell string) (string, error) {
+	if shell == "" {
+		shell = "/bin/bash"
+	}
+
+	// Check if shell exists.
+	if _, err := os.Stat(shell); err != nil {
+		if os.IsNotExist(err) {
+			// Fallback to /bin/sh.
+			shell = "/bin/sh"
+			if _, err := os.Stat(shell); err != nil {
+				return "", fmt.Errorf("no shell available: %w", err)
+			}
+		} else {
+			return "", fmt.Errorf("failed to check shell: %w", err)
+		}
+	}
+	return shell, nil
+}
+
 // Resize resizes the PTY to the specified dimensions.
 func (s *Session) Resize(rows, cols uint16) error {
 	s.mu.Lock()
@@ -262,17 +283,45 @@ func (s *Session) monitorProcess() {
 		s.ExitCode = &exitCode
 	}
 
-	// Ensure PTY is closed
+	close(s.done)
+
+	// Ensure PTY is closed once readers had a chance to drain it.
 	if s.pty != nil {
-		_ = s.pty.Close()
+		pty := s.pty
+		time.AfterFunc(ptyDrainTimeout, func() { _ = pty.Close() })
 	}
 }
 
+// Wait blocks until the process has exited and returns its exit code.
+func (s *Session) Wait() int {
+	<-s.done
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.ExitCode
+}
+
+// ExitStatus returns the exit code of the process, or -1 if it has not
+// exited.
+func (s *Session) ExitStatus() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ExitCode == nil {
+		return -1
+	}
+	return *s.ExitCode
+}
+
 // PTY returns the PTY file for reading/writing.
 func (s *Session) PTY() *os.File {
 	return s.pty
 }
 
+// Shell returns the path of the shell the session runs.
+func (s *Session) Shell() string {
+	return s.cmd.Path
+}
+
 // UpdateLastActive updates the LastActive timestamp.
 func (s *Session) UpdateLastActive() {
 	s.mu.Lock()
@@ -160,3 +160,28 @@ func incrementIP(ip net.IP) net.IP {
 
 	return result
 }
+
+// MapIPv6 returns the address at ipv4's offset within v4Subnet in v6Subnet,
+// e.g. 100.64.0.42 in 100.64.0.0/10 maps to fd42::2a in fd42::/48. Agents'
+// IPv6 mesh addresses are derived this way from their allocated IPv4
+// addresses, so they need no allocation or persistence of their own.
+func MapIPv6(ipv4 net.IP, v4Subnet, v6Subnet *net.IPNet) (net.IP, error) {
+	ip4 := ipv4.To4()
+	if ip4 == nil || !v4Subnet.Contains(ip4) {
+		return nil, fmt.Errorf("IP %s is not in subnet %s", ipv4, v4Subnet)
+	}
+	if v6Subnet.IP.To4() != nil || len(v6Subnet.IP) != net.IPv6len {
+		return nil, fmt.Errorf("subnet %s is not an IPv6 subnet", v6Subnet)
+	}
+	if ones, _ := v6Subnet.Mask.Size(); ones > 96 {
+		return nil, fmt.Errorf("IPv6 subnet %s is too small for the IPv4 mesh", v6Subnet)
+	}
+
+	mask := net.IP(v4Subnet.Mask).To4()
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, v6Subnet.IP.Mask(v6Subnet.Mask))
+	for i := 0; i < net.IPv4len; i++ {
+		ip[12+i] |= ip4[i] &^ mask[i]
+	}
+	return ip, nil
+}
@@ -205,3 +205,33 @@ func TestIncrementIP(t *testing.T) {
 		})
 	}
 }
+
+func TestMapIPv6(t *testing.T) {
+	_, v4Subnet, _ := net.ParseCIDR("100.64.0.0/10")
+	_, v6Subnet, _ := net.ParseCIDR("fd42::/48")
+
+	tests := []struct {
+		ipv4     string
+		expected string
+	}{
+		{"100.64.0.1", "fd42::1"}, // The colony maps to its default address.
+		{"100.64.0.42", "fd42::2a"},
+		{"100.65.1.2", "fd42::1:102"},
+	}
+	for _, tt := range tests {
+		ip, err := MapIPv6(net.ParseIP(tt.ipv4), v4Subnet, v6Subnet)
+		if err != nil {
+			t.Fatalf("MapIPv6(%s) failed: %v", tt.ipv4, err)
+		}
+		if ip.String() != tt.expected {
+			t.Errorf("MapIPv6(%s) = %s, expected %s", tt.ipv4, ip, tt.expected)
+		}
+	}
+
+	if _, err := MapIPv6(net.ParseIP("10.0.0.2"), v4Subnet, v6Subnet); err == nil {
+		t.Error("expected error for IP outside the IPv4 subnet")
+	}
+	if _, err := MapIPv6(net.ParseIP("100.64.0.2"), v4Subnet, v4Subnet); err == nil {
+		t.Error("expected error for IPv4 target subnet")
+	}
+}
@@ -1,15 +1,18 @@
 package wireguard
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"runtime"
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"golang.zx2c4.com/wireguard/conn"
 	"golang.zx2c4.com/wireguard/device"
@@ -33,6 +36,11 @@ type Device struct {
 	wgLogger    *device.Logger // WireGuard internal logger.
 	logger      zerolog.Logger // Application logger.
 	actualPort  int            // Actual bound UDP port (for ephemeral ports).
+	stun        *STUNResponder // Answers STUN requests on the WireGuard port (RFD 029).
+	puncher     *HolePuncher   // Sends and answers hole punching probes on the WireGuard port.
+	relay       *Relay         // Carries packets for peers UDP cannot reach.
+	userspace   UserspaceMode  // When to use a userspace network stack instead of TUN.
+	meshClient  *http.Client   // HTTP client dialing through the userspace stack.
 }
 
 // NewDevice creates a new WireGuard device with the given configuration.
@@ -103,23 +111,26 @@ func (d *Device) Start() error {
 		return fmt.Errorf("device already started")
 	}
 
-	// Create TUN interface. This requires root privileges.
-	iface, err := CreateTUN("", d.cfg.MTU, d.logger)
+	iface, err := d.createInterface()
 	if err != nil {
-		// If we are here, it means we don't have privileges. The new architecture
-		// requires the process to be started as root, so we wrap this in a
-		// permission error.
-		if isPermissionError(err) {
-			return wrapPermissionError(err)
-		}
-		return fmt.Errorf("failed to create TUN interface: %w", err)
+		return err
 	}
 	d.iface = iface
 	d.tunDevice = iface.Device()
 
 	// Create WireGuard device with default bind.
 	// STUN discovery runs before WireGuard starts to avoid port conflicts.
+	// Colonies also answer STUN requests on the WireGuard port (RFD 029).
 	bind := conn.NewDefaultBind()
+	if d.stun != nil {
+		bind = NewSTUNBind(bind, d.stun)
+	}
+	if d.puncher != nil {
+		bind = newPunchBind(bind, d.puncher)
+	}
+	if d.relay != nil {
+		bind = newRelayBind(bind, d.relay)
+	}
 	d.wgDevice = device.NewDevice(d.tunDevice, bind, d.wgLogger)
 
 	// Configure device via UAPI
@@ -147,6 +158,150 @@ func (d *Device) Start() error {
 	return nil
 }
 
+// createInterface creates the TUN interface, or a userspace network stack
+// depending on the userspace mode.
+func (d *Device) createInterface() (*Interface, error) {
+	if d.userspace == UserspaceAlways {
+		d.logger.Info().Msg("Using userspace network stack for the mesh")
+		return CreateNetstack(d.cfg.MTU, d.logger)
+	}
+
+	// Create TUN interface. This requires root privileges.
+	iface, err := CreateTUN("", d.cfg.MTU, d.logger)
+	if err == nil {
+		return iface, nil
+	}
+
+	if d.userspace == UserspaceAuto {
+		d.logger.Warn().
+			Err(err).
+			Msg("Cannot create TUN interface, falling back to userspace network stack; the mesh is only reachable by Coral itself")
+		return CreateNetstack(d.cfg.MTU, d.logger)
+	}
+
+	// If we are here, it means we don't have privileges. The new architecture
+	// requires the process to be started as root, so we wrap this in a
+	// permission error.
+	if isPermissionError(err) {
+		return nil, wrapPermissionError(err)
+	}
+	return nil, fmt.Errorf("failed to create TUN interface: %w", err)
+}
+
+// SetUserspaceMode sets when the device uses a userspace network stack
+// instead of a TUN interface. The default is UserspaceNever. It must be
+// called before Start.
+func (d *Device) SetUserspaceMode(mode UserspaceMode) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.userspace = mode
+}
+
+// Userspace reports whether the device runs on a userspace network stack.
+// Mesh connections must then go through DialContext and Listen.
+func (d *Device) Userspace() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.iface != nil && d.iface.Userspace()
+}
+
+// DialContext connects to address over the mesh: through the userspace
+// stack if the device uses one, otherwise through the host's sockets.
+func (d *Device) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.mu.RLock()
+	iface := d.iface
+	d.mu.RUnlock()
+	if iface != nil && iface.netstack != nil {
+		return iface.netstack.DialContext(ctx, network, address)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, address)
+}
+
+// Listen listens on address in the mesh: on the userspace stack if the
+// device uses one, otherwise on the host's sockets.
+func (d *Device) Listen(network, address string) (net.Listener, error) {
+	d.mu.RLock()
+	iface := d.iface
+	d.mu.RUnlock()
+	if iface != nil && iface.netstack != nil {
+		return iface.netstack.Listen(network, address)
+	}
+	return net.Listen(network, address)
+}
+
+// HTTPClient returns an HTTP client for requests to mesh addresses. It is
+// http.DefaultClient unless the device uses a userspace stack.
+func (d *Device) HTTPClient() *http.Client {
+	if !d.Userspace() {
+		return http.DefaultClient
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.meshClient == nil {
+		d.meshClient = &http.Client{
+			Transport: &http.Transport{
+				DialContext:     d.iface.netstack.DialContext,
+				MaxIdleConns:    10,
+				IdleConnTimeout: 90 * time.Second,
+			},
+		}
+	}
+	return d.meshClient
+}
+
+// EnableSTUN makes the device answer STUN Binding Requests received on its
+// UDP port with responder, so that peers behind NAT can discover the public
+// endpoint mapped to this device (RFD 029). It must be called before Start.
+func (d *Device) EnableSTUN(responder *STUNResponder) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stun = responder
+}
+
+// STUNStats returns the counters of the device's STUN responder, or nil if
+// STUN is not enabled.
+func (d *Device) STUNStats() *STUNStats {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.stun == nil {
+		return nil
+	}
+	stats := d.stun.Stats()
+	return &stats
+}
+
+// EnableHolePunching makes the device send and answer hole punching probes
+// on its UDP port. It must be called before Start.
+func (d *Device) EnableHolePunching() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.puncher = NewHolePuncher(d.logger)
+}
+
+// HolePuncher returns the device's hole puncher, or nil if hole punching is
+// not enabled.
+func (d *Device) HolePuncher() *HolePuncher {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.puncher
+}
+
+// EnableRelay lets the device reach peers at relay endpoints (see
+// RelayEndpoint). It must be called before Start.
+func (d *Device) EnableRelay() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.relay = NewRelay(d.logger)
+}
+
+// Relay returns the device's relay, or nil if the relay is not enabled.
+func (d *Device) Relay() *Relay {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.relay
+}
+
 // Stop tears down the WireGuard device gracefully.
 func (d *Device) Stop() error {
 	d.mu.Lock()
@@ -170,6 +325,7 @@ func (d *Device) Stop() error {
 	}
 
 	d.iface = nil
+	d.meshClient = nil
 
 	return nil
 }
@@ -223,6 +379,35 @@ func (d *Device) AddPeer(peerConfig *PeerConfig) error {
 	return nil
 }
 
+// SetPeerEndpoint points an existing peer at endpoint, e.g. the path found
+// by hole punching.
+func (d *Device) SetPeerEndpoint(publicKey, endpoint string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.wgDevice == nil {
+		return fmt.Errorf("device not started")
+	}
+
+	peer, ok := d.peers[publicKey]
+	if !ok {
+		return fmt.Errorf("peer not found: %s", publicKey)
+	}
+
+	pubKeyBytes, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key encoding: %w", err)
+	}
+
+	uapiConfig := fmt.Sprintf("public_key=%x\nupdate_only=true\nendpoint=%s\n", pubKeyBytes, endpoint)
+	if err := d.wgDevice.IpcSet(uapiConfig); err != nil {
+		return fmt.Errorf("failed to set peer endpoint: %w", err)
+	}
+
+	peer.Endpoint = endpoint
+	return nil
+}
+
 // RemovePeer removes a WireGuard peer by public key.
 func (d *Device) RemovePeer(publicKey string) error {
 	d.mu.Lock()
@@ -0,0 +1,279 @@
+package wireguard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"golang.zx2c4.com/wireguard/conn"
+
+	"github.com/coral-mesh/coral/internal/constants"
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+// Hole punching probes are sent from the WireGuard socket, so that the NAT
+// mappings they open are the ones WireGuard uses. A probe is the magic, a
+// type byte and the session ID. The first magic byte is neither a WireGuard
+// message type (1-4) nor the leading zero of a STUN message, so probes share
+// the port with both.
+const (
+	probeRequest  byte = 1
+	probeResponse byte = 2
+
+	// PunchSessionIDSize is the size of a hole punching session ID.
+	PunchSessionIDSize = 16
+
+	probeSize = len(probeMagic) + 1 + PunchSessionIDSize
+)
+
+var probeMagic = [4]byte{0xc0, 'r', 'l', 'p'}
+
+// IsProbePacket reports whether packet is a hole punching probe.
+func IsProbePacket(packet []byte) bool {
+	return len(packet) == probeSize &&
+		bytes.Equal(packet[:len(probeMagic)], probeMagic[:]) &&
+		(packet[len(probeMagic)] == probeRequest || packet[len(probeMagic)] == probeResponse)
+}
+
+func buildProbe(kind byte, session [PunchSessionIDSize]byte) []byte {
+	packet := make([]byte, 0, probeSize)
+	packet = append(packet, probeMagic[:]...)
+	packet = append(packet, kind)
+	return append(packet, session[:]...)
+}
+
+// punchSession tracks the endpoints probed in one attempt.
+type punchSession struct {
+	targets []netip.AddrPort
+	winner  chan netip.AddrPort
+}
+
+// HolePuncher finds a working path to a peer behind NAT. Both peers probe
+// each other's candidate endpoints at the same time; the outgoing probes open
+// NAT mappings for the incoming ones, and the first candidate that answers is
+// the path to use. A probe received from an address that is not a candidate
+// (the peer's NAT picked a new port for us) is answered and that address is
+// probed too.
+//
+// Probes are not authenticated: the session ID only ties them to an attempt.
+// A spoofed answer can at worst point WireGuard at a dead endpoint, which
+// WireGuard roaming corrects as soon as the peer's packets arrive.
+type HolePuncher struct {
+	logger logging.Logger
+
+	mu       sync.Mutex
+	send     func(packet []byte, to netip.AddrPort) error
+	sessions map[[PunchSessionIDSize]byte]*punchSession
+}
+
+// NewHolePuncher creates a hole puncher. It sends and receives probes once
+// installed on a bind (see Device.EnableHolePunching).
+func NewHolePuncher(logger logging.Logger) *HolePuncher {
+	return &HolePuncher{
+		logger:   logger.With().Str("component", "hole_punch").Logger(),
+		sessions: make(map[[PunchSessionIDSize]byte]*punchSession),
+	}
+}
+
+// Punch probes candidates ("ip:port" or "host:port") from startAt until one
+// answers or ctx is done, and returns the endpoint that answered.
+func (p *HolePuncher) Punch(
+	ctx context.Context,
+	session [PunchSessionIDSize]byte,
+	candidates []string,
+	startAt time.Time,
+) (string, error) {
+	s := &punchSession{winner: make(chan netip.AddrPort, 1)}
+	for _, candidate := range candidates {
+		addr, err := net.ResolveUDPAddr("udp", candidate)
+		if err != nil {
+			p.logger.Debug().Err(err).Str("candidate", candidate).Msg("Skipping unresolvable candidate")
+			continue
+		}
+		target := addr.AddrPort()
+		s.addTarget(netip.AddrPortFrom(target.Addr().Unmap(), target.Port()))
+	}
+	if len(s.targets) == 0 {
+		return "", fmt.Errorf("no usable candidate endpoints")
+	}
+
+	p.mu.Lock()
+	if p.send == nil {
+		p.mu.Unlock()
+		return "", fmt.Errorf("hole puncher is not installed on a WireGuard bind")
+	}
+	p.sessions[session] = s
+	p.mu.Unlock()
+
+	// Keep answering the peer's probes after this side is done: the peer
+	// may not have seen an answer yet.
+	defer time.AfterFunc(constants.DefaultHolePunchTimeout, func() {
+		p.mu.Lock()
+		if p.sessions[session] == s {
+			delete(p.sessions, session)
+		}
+		p.mu.Unlock()
+	})
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(time.Until(startAt)):
+	}
+
+	ticker := time.NewTicker(constants.DefaultHolePunchInterval)
+	defer ticker.Stop()
+
+	request := buildProbe(probeRequest, session)
+	for {
+		p.mu.Lock()
+		targets := append([]netip.AddrPort(nil), s.targets...)
+		p.mu.Unlock()
+		for _, target := range targets {
+			if err := p.send(request, target); err != nil {
+				p.logger.Debug().Err(err).Str("target", target.String()).Msg("Failed to send probe")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("no candidate answered: %w", ctx.Err())
+		case winner := <-s.winner:
+			return winner.String(), nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// handle processes a probe received from from.
+func (p *HolePuncher) handle(packet []byte, from netip.AddrPort) {
+	var session [PunchSessionIDSize]byte
+	copy(session[:], packet[len(probeMagic)+1:])
+
+	p.mu.Lock()
+	s, ok := p.sessions[session]
+	if ok && packet[len(probeMagic)] == probeRequest {
+		s.addTarget(from)
+	}
+	send := p.send
+	p.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if packet[len(probeMagic)] == probeResponse {
+		select {
+		case s.winner <- from:
+		default:
+		}
+		return
+	}
+
+	if err := send(buildProbe(probeResponse, session), from); err != nil {
+		p.logger.Debug().Err(err).Str("addr", from.String()).Msg("Failed to answer probe")
+	}
+}
+
+func (s *punchSession) addTarget(addr netip.AddrPort) {
+	addr = netip.AddrPortFrom(addr.Addr().Unmap(), addr.Port())
+	for _, target := range s.targets {
+		if target == addr {
+			return
+		}
+	}
+	s.targets = append(s.targets, addr)
+}
+
+// punchBind wraps a WireGuard bind to handle hole punching probes arriving
+// on the WireGuard port. Other packets are passed to WireGuard unchanged.
+type punchBind struct {
+	conn.Bind
+	puncher *HolePuncher
+}
+
+// newPunchBind returns a bind that hands probes to puncher and passes all
+// other packets to bind. It makes puncher send its probes through bind.
+func newPunchBind(bind conn.Bind, puncher *HolePuncher) conn.Bind {
+	b := &punchBind{Bind: bind, puncher: puncher}
+	puncher.mu.Lock()
+	puncher.send = b.sendTo
+	puncher.mu.Unlock()
+	return b
+}
+
+func (b *punchBind) Open(port uint16) ([]conn.ReceiveFunc, uint16, error) {
+	fns, actualPort, err := b.Bind.Open(port)
+	if err != nil {
+		return nil, 0, err
+	}
+	wrapped := make([]conn.ReceiveFunc, len(fns))
+	for i, fn := range fns {
+		wrapped[i] = b.receive(fn)
+	}
+	return wrapped, actualPort, nil
+}
+
+// receive filters probes out of the packets returned by fn and compacts the
+// remaining packets to the front of the batch.
+func (b *punchBind) receive(fn conn.ReceiveFunc) conn.ReceiveFunc {
+	return func(packets [][]byte, sizes []int, eps []conn.Endpoint) (int, error) {
+		n, err := fn(packets, sizes, eps)
+		kept := 0
+		for i := 0; i < n; i++ {
+			packet := packets[i][:sizes[i]]
+			if IsProbePacket(packet) {
+				if from, perr := netip.ParseAddrPort(eps[i].DstToString()); perr == nil {
+					b.puncher.handle(packet, from)
+				}
+				continue
+			}
+			if kept != i {
+				sizes[kept] = copy(packets[kept], packet)
+				eps[kept] = eps[i]
+			}
+			kept++
+		}
+		return kept, err
+	}
+}
+
+func (b *punchBind) sendTo(packet []byte, to netip.AddrPort) error {
+	ep, err := b.ParseEndpoint(to.String())
+	if err != nil {
+		return err
+	}
+	return b.Send([][]byte{packet}, ep)
+}
+
+// HostCandidates returns the host's IPv4 and IPv6 addresses on port, for
+// peers on the same network. Loopback, link-local and excluded interface
+// addresses are skipped.
+func HostCandidates(port int, excludeInterface string) []string {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil
+	}
+
+	var candidates []string
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Name == excludeInterface {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+				continue
+			}
+			candidates = append(candidates, net.JoinHostPort(ipNet.IP.String(), fmt.Sprint(port)))
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,123 @@
+package wireguard
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.zx2c4.com/wireguard/conn"
+)
+
+// openPunchBind opens a loopback bind with a hole puncher installed and
+// drains it as WireGuard would.
+func openPunchBind(t *testing.T) (*HolePuncher, int) {
+	t.Helper()
+
+	puncher := NewHolePuncher(zerolog.Nop())
+	bind := newPunchBind(conn.NewDefaultBind(), puncher)
+	fns, port, err := bind.Open(0)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = bind.Close() })
+
+	for _, fn := range fns {
+		go func(fn conn.ReceiveFunc) {
+			packets := make([][]byte, bind.BatchSize())
+			for i := range packets {
+				packets[i] = make([]byte, 1500)
+			}
+			sizes := make([]int, len(packets))
+			eps := make([]conn.Endpoint, len(packets))
+			for {
+				if _, err := fn(packets, sizes, eps); err != nil {
+					return
+				}
+			}
+		}(fn)
+	}
+	return puncher, int(port)
+}
+
+func TestHolePuncher_Punch(t *testing.T) {
+	agent, agentPort := openPunchBind(t)
+	colony, colonyPort := openPunchBind(t)
+
+	var session [PunchSessionIDSize]byte
+	copy(session[:], "test-session-id!")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	startAt := time.Now().Add(50 * time.Millisecond)
+
+	// The colony only knows a stale agent endpoint, as when the agent's NAT
+	// maps the colony to a different port than its STUN server: it learns
+	// the agent's address from the agent's probes.
+	colonyResult := make(chan string, 1)
+	go func() {
+		winner, err := colony.Punch(ctx, session, []string{"127.0.0.1:1"}, startAt)
+		assert.NoError(t, err)
+		colonyResult <- winner
+	}()
+
+	winner, err := agent.Punch(ctx, session, []string{
+		"127.0.0.1:2",
+		fmt.Sprintf("127.0.0.1:%d", colonyPort),
+	}, startAt)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("127.0.0.1:%d", colonyPort), winner)
+	assert.Equal(t, fmt.Sprintf("127.0.0.1:%d", agentPort), <-colonyResult)
+}
+
+func TestHolePuncher_PunchTimeout(t *testing.T) {
+	puncher, _ := openPunchBind(t)
+
+	var session [PunchSessionIDSize]byte
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	_, err := puncher.Punch(ctx, session, []string{"127.0.0.1:1"}, time.Now())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = puncher.Punch(context.Background(), session, []string{"not an endpoint"}, time.Now())
+	assert.ErrorContains(t, err, "no usable candidate")
+}
+
+func TestPunchBind(t *testing.T) {
+	handshake := make([]byte, 148)
+	handshake[0] = 1
+	data := make([]byte, 64)
+	data[0] = 4
+	var session [PunchSessionIDSize]byte
+	probe := buildProbe(probeRequest, session)
+	require.True(t, IsProbePacket(probe))
+	assert.False(t, IsProbePacket(handshake))
+
+	inner := &fakeBind{
+		packets: [][]byte{handshake, probe, data},
+		from:    netip.MustParseAddrPort("203.0.113.45:54322"),
+	}
+	bind := newPunchBind(inner, NewHolePuncher(zerolog.Nop()))
+	fns, _, err := bind.Open(41580)
+	require.NoError(t, err)
+
+	packets := make([][]byte, 3)
+	for i := range packets {
+		packets[i] = make([]byte, 1500)
+	}
+	sizes := make([]int, 3)
+	eps := make([]conn.Endpoint, 3)
+	n, err := fns[0](packets, sizes, eps)
+	require.NoError(t, err)
+
+	// WireGuard receives its packets in order, without the probe.
+	require.Equal(t, 2, n)
+	assert.Equal(t, handshake, packets[0][:sizes[0]])
+	assert.Equal(t, data, packets[1][:sizes[1]])
+
+	// Probes for unknown sessions are not answered.
+	assert.Empty(t, inner.sent)
+}
@@ -14,6 +14,10 @@ type Interface struct {
 	name   string
 	mtu    int
 	logger zerolog.Logger
+
+	// netstack is set for userspace interfaces, which have no kernel
+	// addresses or routes (see CreateNetstack).
+	netstack *Netstack
 }
 
 // CreateTUN is implemented in platform-specific files:
@@ -35,6 +39,12 @@ func (i *Interface) MTU() int {
 	return i.mtu
 }
 
+// Userspace reports whether the interface is a userspace network stack
+// rather than a kernel TUN interface.
+func (i *Interface) Userspace() bool {
+	return i.netstack != nil
+}
+
 // Device returns the underlying TUN device.
 func (i *Interface) Device() tun.Device {
 	return i.device
@@ -57,6 +67,10 @@ func (i *Interface) AssignIP(ip net.IP, subnet *net.IPNet) error {
 		return fmt.Errorf("subnet is nil")
 	}
 
+	if i.netstack != nil {
+		return i.netstack.setAddress(ip, subnet)
+	}
+
 	// Call platform-specific implementation
 	return i.AssignIPPlatform(ip, subnet)
 }
@@ -87,6 +101,11 @@ func (i *Interface) AddRoutesForPeer(allowedIPs []string) error {
 		return fmt.Errorf("interface name is empty")
 	}
 
+	// The userspace stack routes everything to WireGuard.
+	if i.netstack != nil {
+		return nil
+	}
+
 	// Call platform-specific implementation
 	return i.AddRoutesForPeerPlatform(allowedIPs)
 }
@@ -101,6 +120,9 @@ func (i *Interface) DeleteRoute(ip net.IP) error {
 	if ip == nil {
 		return fmt.Errorf("IP is nil")
 	}
+	if i.netstack != nil {
+		return nil
+	}
 
 	// Call platform-specific implementation.
 	return i.DeleteRoutePlatform(ip)
@@ -111,6 +111,10 @@ func (i *Interface) AssignIPPlatform(ip net.IP, subnet *net.IPNet) error {
 		return fmt.Errorf("interface name is empty")
 	}
 
+	if ip.To4() == nil {
+		return i.assignIPv6(ip)
+	}
+
 	// First, try to delete any existing IPv4 address on the interface.
 	// This allows us to replace an existing IP (e.g., temporary IP with assigned IP).
 	// We ignore errors here because the interface might not have an IP yet.
@@ -161,6 +165,19 @@ func (i *Interface) AssignIPPlatform(ip net.IP, subnet *net.IPNet) error {
 	return nil
 }
 
+// assignIPv6 adds an IPv6 address to the interface alongside its IPv4
+// address. As with IPv4, a host prefix avoids subnet-wide routes.
+func (i *Interface) assignIPv6(ip net.IP) error {
+	//nolint:gosec // G204: Interface configuration with controlled arguments
+	cmd := exec.Command("ifconfig", i.name, "inet6", ip.String(), "prefixlen", "128", "alias")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to assign IP %s to interface %s: %w (output: %s)",
+			ip.String(), i.name, err, string(output))
+	}
+	return nil
+}
+
 // AddRoutesForPeerPlatform adds routes for a peer's AllowedIPs on macOS.
 func (i *Interface) AddRoutesForPeerPlatform(allowedIPs []string) error {
 	i.logger.Debug().
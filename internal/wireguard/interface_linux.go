@@ -100,7 +100,7 @@ func CreateTUNFromFD(name string, fd int, mtu int, logger zerolog.Logger) (*Inte
 }
 
 // AssignIPPlatform assigns an IP address to the interface on Linux using ip command.
-// If the interface already has an IP address, it will be replaced.
+// If the interface already has an IP address of the same family, it will be replaced.
 func (i *Interface) AssignIPPlatform(ip net.IP, subnet *net.IPNet) error {
 	if i.name == "" {
 		return fmt.Errorf("interface name is empty")
@@ -119,24 +119,35 @@ func (i *Interface) AssignIPPlatform(ip net.IP, subnet *net.IPNet) error {
 			i.name, err, string(output))
 	}
 
-	// Flush any existing IPv4 addresses on the interface.
-	// This allows us to replace an existing IP (e.g., temporary IP with assigned IP).
+	family, hostPrefix := "-4", "/32"
+	if ip.To4() == nil {
+		family, hostPrefix = "-6", "/128"
+	}
+
+	// Flush any existing addresses of this family on the interface.
+	// This allows us to replace an existing IP (e.g., temporary IP with assigned IP)
+	// while keeping the address of the other family in a dual-stack mesh.
 	// We ignore errors here because the interface might not have an IP yet.
 	// #nosec G204 -- interface name is validated by validateInterfaceName
-	flushCmd := exec.Command("ip", "addr", "flush", "dev", i.name)
+	flushCmd := exec.Command("ip", family, "addr", "flush", "dev", i.name)
 	_ = flushCmd.Run() // Ignore error - interface might not have an IP.
 
 	// Calculate the prefix length from the subnet mask.
 	ones, _ := subnet.Mask.Size()
 
-	// Assign the IP address with /32 netmask to create only a host route.
-	// This prevents subnet-wide routing that would conflict when multiple WireGuard
-	// instances run on the same host. Peer-specific routes are added separately
-	// via AddRoutesForPeer.
+	// Assign the IP address with a host prefix (/32 or /128) to create only a
+	// host route. This prevents subnet-wide routing that would conflict when
+	// multiple WireGuard instances run on the same host. Peer-specific routes
+	// are added separately via AddRoutesForPeer.
 	//
 	// On Linux we use: ip addr add <ip>/32 dev <interface>
-	ipWithPrefix := fmt.Sprintf("%s/32", ip.String())
-	args := []string{"addr", "add", ipWithPrefix, "dev", i.name}
+	// IPv6 addresses skip duplicate address detection, which would otherwise
+	// keep them unusable for a second or so.
+	ipWithPrefix := ip.String() + hostPrefix
+	args := []string{family, "addr", "add", ipWithPrefix, "dev", i.name}
+	if ip.To4() == nil {
+		args = append(args, "nodad")
+	}
 
 	// #nosec G204 -- interface name is validated, IP is from net.IP type
 	cmd := exec.Command("ip", args...)
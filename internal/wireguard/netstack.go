@@ -0,0 +1,285 @@
+package wireguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"golang.zx2c4.com/wireguard/tun"
+	"gvisor.dev/gvisor/pkg/buffer"
+	"gvisor.dev/gvisor/pkg/tcpip"
+	"gvisor.dev/gvisor/pkg/tcpip/adapters/gonet"
+	"gvisor.dev/gvisor/pkg/tcpip/header"
+	"gvisor.dev/gvisor/pkg/tcpip/link/channel"
+	"gvisor.dev/gvisor/pkg/tcpip/network/ipv4"
+	"gvisor.dev/gvisor/pkg/tcpip/network/ipv6"
+	"gvisor.dev/gvisor/pkg/tcpip/stack"
+	"gvisor.dev/gvisor/pkg/tcpip/transport/icmp"
+	"gvisor.dev/gvisor/pkg/tcpip/transport/tcp"
+	"gvisor.dev/gvisor/pkg/tcpip/transport/udp"
+
+	"github.com/rs/zerolog"
+)
+
+// UserspaceMode selects when a device runs its mesh network stack in
+// userspace instead of on a TUN interface.
+type UserspaceMode string
+
+const (
+	// UserspaceNever always uses a TUN interface.
+	UserspaceNever UserspaceMode = "never"
+	// UserspaceAuto falls back to userspace when no TUN interface can be
+	// created, e.g. without root or in unprivileged containers.
+	UserspaceAuto UserspaceMode = "auto"
+	// UserspaceAlways never creates a TUN interface.
+	UserspaceAlways UserspaceMode = "always"
+)
+
+// ParseUserspaceMode parses a userspace mode. An empty string is
+// UserspaceAuto.
+func ParseUserspaceMode(s string) (UserspaceMode, error) {
+	switch mode := UserspaceMode(s); mode {
+	case "":
+		return UserspaceAuto, nil
+	case UserspaceNever, UserspaceAuto, UserspaceAlways:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid userspace mode %q (use auto, always or never)", s)
+	}
+}
+
+// netstackNIC is the ID of the netstack's only network interface.
+const netstackNIC tcpip.NICID = 1
+
+// Netstack is a TCP/IP stack running in userspace on gVisor, standing in for
+// the TUN interface where one cannot be created. WireGuard reads and writes
+// IP packets to it like to a TUN device; the mesh is only reachable through
+// its DialContext and Listen, not through the host's sockets.
+type Netstack struct {
+	ep           *channel.Endpoint
+	stack        *stack.Stack
+	events       chan tun.Event
+	notifyHandle *channel.NotificationHandle
+	incoming     chan *buffer.View
+	mtu          int
+	closeOnce    sync.Once
+
+	mu    sync.Mutex
+	addrs []tcpip.ProtocolAddress
+}
+
+// newNetstack creates a userspace stack with no address. Every destination
+// is routed to WireGuard, which drops packets outside peers' allowed IPs.
+func newNetstack(mtu int) (*Netstack, error) {
+	ns := &Netstack{
+		ep: channel.New(1024, uint32(mtu), ""), // #nosec G115 -- MTU is validated by the device.
+		stack: stack.New(stack.Options{
+			NetworkProtocols:   []stack.NetworkProtocolFactory{ipv4.NewProtocol, ipv6.NewProtocol},
+			TransportProtocols: []stack.TransportProtocolFactory{tcp.NewProtocol, udp.NewProtocol, icmp.NewProtocol4, icmp.NewProtocol6},
+			HandleLocal:        true,
+		}),
+		events:   make(chan tun.Event, 1),
+		incoming: make(chan *buffer.View),
+		mtu:      mtu,
+	}
+
+	sack := tcpip.TCPSACKEnabled(true)
+	if err := ns.stack.SetTransportProtocolOption(tcp.ProtocolNumber, &sack); err != nil {
+		return nil, fmt.Errorf("failed to enable TCP SACK: %s", err)
+	}
+	ns.notifyHandle = ns.ep.AddNotify(ns)
+	if err := ns.stack.CreateNIC(netstackNIC, ns.ep); err != nil {
+		return nil, fmt.Errorf("failed to create netstack NIC: %s", err)
+	}
+	ns.stack.SetRouteTable([]tcpip.Route{
+		{Destination: header.IPv4EmptySubnet, NIC: netstackNIC},
+		{Destination: header.IPv6EmptySubnet, NIC: netstackNIC},
+	})
+
+	ns.events <- tun.EventUp
+	return ns, nil
+}
+
+// setAddress replaces the stack's address of ip's family with ip in subnet.
+func (ns *Netstack) setAddress(ip net.IP, subnet *net.IPNet) error {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return fmt.Errorf("invalid IP %s", ip)
+	}
+	addr = addr.Unmap()
+	proto := ipv4.ProtocolNumber
+	if addr.Is6() {
+		proto = ipv6.ProtocolNumber
+	}
+	ones, _ := subnet.Mask.Size()
+	protoAddr := tcpip.ProtocolAddress{
+		Protocol: proto,
+		AddressWithPrefix: tcpip.AddressWithPrefix{
+			Address:   tcpip.AddrFromSlice(addr.AsSlice()),
+			PrefixLen: ones,
+		},
+	}
+
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	kept := ns.addrs[:0]
+	for _, old := range ns.addrs {
+		if old.Protocol != proto {
+			kept = append(kept, old)
+			continue
+		}
+		_ = ns.stack.RemoveAddress(netstackNIC, old.AddressWithPrefix.Address)
+	}
+	ns.addrs = kept
+	if err := ns.stack.AddProtocolAddress(netstackNIC, protoAddr, stack.AddressProperties{}); err != nil {
+		return fmt.Errorf("failed to add address %s: %s", addr, err)
+	}
+	ns.addrs = append(ns.addrs, protoAddr)
+	return nil
+}
+
+// DialContext connects to address ("ip:port") over the mesh. Only "tcp" and
+// "udp" networks (and their 4/6 variants) are supported.
+func (ns *Netstack) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	addr, proto, err := netstackAddr(address)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: network, Err: err}
+	}
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		return gonet.DialContextTCP(ctx, ns.stack, addr, proto)
+	case "udp", "udp4", "udp6":
+		return gonet.DialUDP(ns.stack, nil, &addr, proto)
+	default:
+		return nil, &net.OpError{Op: "dial", Net: network, Err: net.UnknownNetworkError(network)}
+	}
+}
+
+// Listen listens for TCP connections from the mesh on address ("ip:port").
+func (ns *Netstack) Listen(network, address string) (net.Listener, error) {
+	if network != "tcp" && network != "tcp4" && network != "tcp6" {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: net.UnknownNetworkError(network)}
+	}
+	addr, proto, err := netstackAddr(address)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: network, Err: err}
+	}
+	return gonet.ListenTCP(ns.stack, addr, proto)
+}
+
+func netstackAddr(address string) (tcpip.FullAddress, tcpip.NetworkProtocolNumber, error) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return tcpip.FullAddress{}, 0, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return tcpip.FullAddress{}, 0, fmt.Errorf("invalid port %q", portStr)
+	}
+	full := tcpip.FullAddress{NIC: netstackNIC, Port: uint16(port)} // #nosec G115 -- parsed as 16 bits.
+	if host == "" {
+		return full, ipv4.ProtocolNumber, nil
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return tcpip.FullAddress{}, 0, fmt.Errorf("netstack needs an IP address, got %q", host)
+	}
+	ip = ip.Unmap()
+	full.Addr = tcpip.AddrFromSlice(ip.AsSlice())
+	if ip.Is6() {
+		return full, ipv6.ProtocolNumber, nil
+	}
+	return full, ipv4.ProtocolNumber, nil
+}
+
+// tun.Device implementation, used by WireGuard.
+
+func (ns *Netstack) File() *os.File           { return nil }
+func (ns *Netstack) MTU() (int, error)        { return ns.mtu, nil }
+func (ns *Netstack) Name() (string, error)    { return netstackInterfaceName, nil }
+func (ns *Netstack) Events() <-chan tun.Event { return ns.events }
+func (ns *Netstack) BatchSize() int           { return 1 }
+
+func (ns *Netstack) Read(bufs [][]byte, sizes []int, offset int) (int, error) {
+	view, ok := <-ns.incoming
+	if !ok {
+		return 0, os.ErrClosed
+	}
+	n, err := view.Read(bufs[0][offset:])
+	if err != nil {
+		return 0, err
+	}
+	sizes[0] = n
+	return 1, nil
+}
+
+func (ns *Netstack) Write(bufs [][]byte, offset int) (int, error) {
+	for _, buf := range bufs {
+		packet := buf[offset:]
+		if len(packet) == 0 {
+			continue
+		}
+		pkt := stack.NewPacketBuffer(stack.PacketBufferOptions{Payload: buffer.MakeWithData(packet)})
+		switch packet[0] >> 4 {
+		case 4:
+			ns.ep.InjectInbound(header.IPv4ProtocolNumber, pkt)
+		case 6:
+			ns.ep.InjectInbound(header.IPv6ProtocolNumber, pkt)
+		default:
+			pkt.DecRef()
+			return 0, syscall.EAFNOSUPPORT
+		}
+	}
+	return len(bufs), nil
+}
+
+// WriteNotify is called by the stack when it has a packet for WireGuard.
+func (ns *Netstack) WriteNotify() {
+	pkt := ns.ep.Read()
+	if pkt == nil {
+		return
+	}
+	view := pkt.ToView()
+	pkt.DecRef()
+	ns.incoming <- view
+}
+
+// Close shuts the stack down. WireGuard and the interface both close it, so
+// it may be called more than once.
+func (ns *Netstack) Close() error {
+	ns.closeOnce.Do(func() {
+		ns.stack.RemoveNIC(netstackNIC)
+		ns.stack.Close()
+		ns.ep.RemoveNotify(ns.notifyHandle)
+		ns.ep.Close()
+		close(ns.events)
+		close(ns.incoming)
+	})
+	return nil
+}
+
+// netstackInterfaceName is the name reported for userspace interfaces.
+const netstackInterfaceName = "userspace"
+
+// CreateNetstack creates a userspace interface (see Netstack).
+func CreateNetstack(mtu int, logger zerolog.Logger) (*Interface, error) {
+	if mtu <= 0 {
+		mtu = 1420 // Default MTU for WireGuard (1500 - 80 overhead)
+	}
+	ns, err := newNetstack(mtu)
+	if err != nil {
+		return nil, err
+	}
+	return &Interface{
+		device:   ns,
+		name:     netstackInterfaceName,
+		mtu:      mtu,
+		netstack: ns,
+		logger:   logger.With().Str("component", "wireguard.interface").Str("name", netstackInterfaceName).Logger(),
+	}, nil
+}
@@ -0,0 +1,106 @@
+package wireguard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coral-mesh/coral/internal/auth"
+	"github.com/coral-mesh/coral/internal/config"
+)
+
+// startUserspaceDevice starts a device on a userspace stack with meshIP and
+// meshIPv6, which needs no privileges.
+func startUserspaceDevice(t *testing.T, meshIP, meshIPv6 string) (*Device, *auth.WireGuardKeyPair) {
+	t.Helper()
+
+	keys, err := auth.GenerateWireGuardKeyPair()
+	require.NoError(t, err)
+	dev, err := NewDevice(&config.WireGuardConfig{
+		PrivateKey: keys.PrivateKey,
+		PublicKey:  keys.PublicKey,
+		Port:       -1,
+	}, zerolog.Nop())
+	require.NoError(t, err)
+	dev.SetUserspaceMode(UserspaceAlways)
+	require.NoError(t, dev.Start())
+	t.Cleanup(func() { _ = dev.Stop() })
+
+	require.True(t, dev.Userspace())
+	_, subnet, _ := net.ParseCIDR("100.64.0.0/10")
+	require.NoError(t, dev.Interface().AssignIP(net.ParseIP(meshIP), subnet))
+	_, subnetV6, _ := net.ParseCIDR("fd42::/48")
+	require.NoError(t, dev.Interface().AssignIP(net.ParseIP(meshIPv6), subnetV6))
+	return dev, keys
+}
+
+// echo sends a message to the echo server at addr over agent's mesh and
+// checks it comes back.
+func echo(t *testing.T, agent *Device, addr string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := agent.DialContext(ctx, "tcp", addr)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	_, err = conn.Write([]byte("heartbeat"))
+	require.NoError(t, err)
+	buf := make([]byte, len("heartbeat"))
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "heartbeat", string(buf))
+}
+
+func TestUserspaceDevice(t *testing.T) {
+	colony, colonyKeys := startUserspaceDevice(t, "100.64.0.1", "fd42::1")
+	agent, agentKeys := startUserspaceDevice(t, "100.64.0.2", "fd42::2")
+
+	require.NoError(t, colony.AddPeer(&PeerConfig{
+		PublicKey:  agentKeys.PublicKey,
+		AllowedIPs: []string{"100.64.0.2/32", "fd42::2/128"},
+	}))
+	require.NoError(t, agent.AddPeer(&PeerConfig{
+		PublicKey:  colonyKeys.PublicKey,
+		Endpoint:   fmt.Sprintf("127.0.0.1:%d", colony.ListenPort()),
+		AllowedIPs: []string{"100.64.0.1/32", "fd42::1/128"},
+	}))
+
+	for _, addr := range []string{"100.64.0.1:9000", "[fd42::1]:9000"} {
+		ln, err := colony.Listen("tcp", addr)
+		require.NoError(t, err)
+		defer func() { _ = ln.Close() }()
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer func() { _ = conn.Close() }()
+			_, _ = io.Copy(conn, conn)
+		}()
+
+		echo(t, agent, addr)
+	}
+}
+
+func TestParseUserspaceMode(t *testing.T) {
+	mode, err := ParseUserspaceMode("")
+	require.NoError(t, err)
+	assert.Equal(t, UserspaceAuto, mode)
+
+	mode, err = ParseUserspaceMode("always")
+	require.NoError(t, err)
+	assert.Equal(t, UserspaceAlways, mode)
+
+	_, err = ParseUserspaceMode("sometimes")
+	assert.Error(t, err)
+}
@@ -0,0 +1,305 @@
+package wireguard
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"golang.zx2c4.com/wireguard/conn"
+
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+const (
+	// RelayPath is the HTTP path the colony serves the WireGuard relay on.
+	RelayPath = "/wireguard/relay"
+
+	// RelayKeyHeader carries the WireGuard public key of the peer opening a
+	// relay connection.
+	RelayKeyHeader = "X-Coral-WireGuard-Key"
+
+	relayEndpointPrefix = "relay:"
+
+	// relayMaxMessageSize is the largest WireGuard message, a data packet
+	// carrying a maximum size IP packet.
+	relayMaxMessageSize = 65535
+
+	relayInboundQueue = 256
+)
+
+// RelayEndpoint returns the WireGuard endpoint of a peer reached through the
+// relay connection registered for publicKey.
+func RelayEndpoint(publicKey string) string {
+	return relayEndpointPrefix + publicKey
+}
+
+// IsRelayEndpoint reports whether endpoint is a relay endpoint.
+func IsRelayEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, relayEndpointPrefix)
+}
+
+// relayEndpoint is the conn.Endpoint of a peer reached through the relay.
+type relayEndpoint struct {
+	key string
+}
+
+func (e *relayEndpoint) ClearSrc()           {}
+func (e *relayEndpoint) SrcToString() string { return "" }
+func (e *relayEndpoint) DstToString() string { return RelayEndpoint(e.key) }
+func (e *relayEndpoint) DstToBytes() []byte  { return []byte(e.key) }
+func (e *relayEndpoint) DstIP() netip.Addr   { return netip.Addr{} }
+func (e *relayEndpoint) SrcIP() netip.Addr   { return netip.Addr{} }
+
+type relayPacket struct {
+	data []byte
+	from string
+}
+
+// relayConn is a websocket carrying one peer's packets.
+type relayConn struct {
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+}
+
+// Relay carries WireGuard packets over websocket connections to the colony's
+// HTTP port, for peers whose UDP packets cannot get through (hostile NAT,
+// UDP blocked by a firewall). Each connection is registered under the public
+// key of the peer at its far end; WireGuard reaches that peer at
+// RelayEndpoint(key).
+//
+// The relay only moves packets WireGuard has already encrypted. WireGuard
+// still authenticates every packet, and roaming moves a peer back to its UDP
+// endpoint as soon as packets arrive from there. A client claiming another
+// peer's key can at worst take over that peer's relay connection, which
+// disrupts its traffic the way spoofed UDP packets would.
+type Relay struct {
+	logger  logging.Logger
+	inbound chan relayPacket
+
+	mu    sync.Mutex
+	conns map[string]*relayConn
+}
+
+// NewRelay creates a relay. It carries packets once installed on a bind (see
+// Device.EnableRelay).
+func NewRelay(logger logging.Logger) *Relay {
+	return &Relay{
+		logger:  logger.With().Str("component", "relay").Logger(),
+		inbound: make(chan relayPacket, relayInboundQueue),
+		conns:   make(map[string]*relayConn),
+	}
+}
+
+// Dial opens a relay connection to the colony at baseURL (its HTTP
+// registration URL) for the colony peer peerKey, identifying this side by
+// localKey. Packets flow until the connection fails or Disconnect is called.
+func (r *Relay) Dial(ctx context.Context, baseURL, localKey, peerKey string) error {
+	url := strings.TrimSuffix(baseURL, "/") + RelayPath
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		url = "wss://" + strings.TrimPrefix(url, "https://")
+	case strings.HasPrefix(url, "http://"):
+		url = "ws://" + strings.TrimPrefix(url, "http://")
+	}
+
+	header := http.Header{}
+	header.Set(RelayKeyHeader, localKey)
+	ws, resp, err := websocket.DefaultDialer.DialContext(ctx, url, header)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("failed to connect to relay at %s: %w (HTTP %d)", url, err, resp.StatusCode)
+		}
+		return fmt.Errorf("failed to connect to relay at %s: %w", url, err)
+	}
+
+	go r.attach(peerKey, ws)
+	return nil
+}
+
+// Connected reports whether a relay connection is registered for publicKey.
+func (r *Relay) Connected(publicKey string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.conns[publicKey]
+	return ok
+}
+
+// Disconnect closes the relay connection registered for publicKey, if any.
+func (r *Relay) Disconnect(publicKey string) {
+	r.mu.Lock()
+	c, ok := r.conns[publicKey]
+	delete(r.conns, publicKey)
+	r.mu.Unlock()
+	if ok {
+		_ = c.ws.Close()
+	}
+}
+
+// attach registers ws for publicKey, replacing any previous connection, and
+// queues the packets it carries for WireGuard until it fails.
+func (r *Relay) attach(publicKey string, ws *websocket.Conn) {
+	ws.SetReadLimit(relayMaxMessageSize)
+	c := &relayConn{ws: ws}
+
+	r.mu.Lock()
+	old := r.conns[publicKey]
+	r.conns[publicKey] = c
+	r.mu.Unlock()
+	if old != nil {
+		_ = old.ws.Close()
+	}
+
+	r.logger.Info().Str("peer", publicKey).Msg("Relay connection established")
+	defer func() {
+		r.mu.Lock()
+		if r.conns[publicKey] == c {
+			delete(r.conns, publicKey)
+		}
+		r.mu.Unlock()
+		_ = ws.Close()
+		r.logger.Info().Str("peer", publicKey).Msg("Relay connection closed")
+	}()
+
+	for {
+		kind, data, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		if kind != websocket.BinaryMessage {
+			continue
+		}
+		// Like a UDP socket, drop packets WireGuard is too slow to take.
+		select {
+		case r.inbound <- relayPacket{data: data, from: publicKey}:
+		default:
+		}
+	}
+}
+
+// send writes packets to the relay connection registered for publicKey.
+func (r *Relay) send(publicKey string, bufs [][]byte) error {
+	r.mu.Lock()
+	c, ok := r.conns[publicKey]
+	r.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no relay connection for peer %s", publicKey)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	for _, buf := range bufs {
+		if err := c.ws.WriteMessage(websocket.BinaryMessage, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relayUpgrader accepts relay connections. Relay clients are agents, not
+// browsers, so requests carrying an Origin header are refused.
+var relayUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		return r.Header.Get("Origin") == ""
+	},
+}
+
+// NewRelayHandler returns the HTTP handler for RelayPath. It accepts
+// connections from peers isPeer knows and registers them with relay.
+func NewRelayHandler(relay *Relay, isPeer func(publicKey string) bool, logger logging.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(RelayKeyHeader)
+		if key == "" {
+			http.Error(w, "missing "+RelayKeyHeader+" header", http.StatusBadRequest)
+			return
+		}
+		if !isPeer(key) {
+			http.Error(w, "unknown WireGuard peer", http.StatusForbidden)
+			return
+		}
+
+		ws, err := relayUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			logger.Debug().Err(err).Str("peer", key).Msg("Relay upgrade failed")
+			return
+		}
+		relay.attach(key, ws)
+	})
+}
+
+// relayBind wraps a WireGuard bind to send packets for relay endpoints over
+// relay connections and to deliver the packets they carry. UDP endpoints are
+// handled by the wrapped bind.
+type relayBind struct {
+	conn.Bind
+	relay *Relay
+
+	mu     sync.Mutex
+	closed chan struct{}
+}
+
+// newRelayBind returns a bind that carries relay endpoint traffic over relay
+// and everything else over bind.
+func newRelayBind(bind conn.Bind, relay *Relay) conn.Bind {
+	return &relayBind{Bind: bind, relay: relay}
+}
+
+func (b *relayBind) Open(port uint16) ([]conn.ReceiveFunc, uint16, error) {
+	fns, actualPort, err := b.Bind.Open(port)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	closed := make(chan struct{})
+	b.mu.Lock()
+	b.closed = closed
+	b.mu.Unlock()
+	return append(fns, b.receive(closed)), actualPort, nil
+}
+
+func (b *relayBind) Close() error {
+	b.mu.Lock()
+	if b.closed != nil {
+		close(b.closed)
+		b.closed = nil
+	}
+	b.mu.Unlock()
+	return b.Bind.Close()
+}
+
+// receive returns packets arriving over relay connections, one at a time.
+func (b *relayBind) receive(closed <-chan struct{}) conn.ReceiveFunc {
+	return func(packets [][]byte, sizes []int, eps []conn.Endpoint) (int, error) {
+		select {
+		case <-closed:
+			return 0, net.ErrClosed
+		case p := <-b.relay.inbound:
+			sizes[0] = copy(packets[0], p.data)
+			eps[0] = &relayEndpoint{key: p.from}
+			return 1, nil
+		}
+	}
+}
+
+func (b *relayBind) ParseEndpoint(s string) (conn.Endpoint, error) {
+	if !IsRelayEndpoint(s) {
+		return b.Bind.ParseEndpoint(s)
+	}
+	key := strings.TrimPrefix(s, relayEndpointPrefix)
+	if raw, err := base64.StdEncoding.DecodeString(key); err != nil || len(raw) != 32 {
+		return nil, fmt.Errorf("invalid relay endpoint %q", s)
+	}
+	return &relayEndpoint{key: key}, nil
+}
+
+func (b *relayBind) Send(bufs [][]byte, ep conn.Endpoint) error {
+	if rep, ok := ep.(*relayEndpoint); ok {
+		return b.relay.send(rep.key, bufs)
+	}
+	return b.Bind.Send(bufs, ep)
+}
@@ -0,0 +1,114 @@
+package wireguard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.zx2c4.com/wireguard/conn"
+)
+
+const (
+	relayTestAgentKey  = "wGtt3f4A633lH6/gC/g8e1/N2r7M77u5gS1bI9n9AWE="
+	relayTestColonyKey = "yGzL6W6Q6/lDtb59+dG/F/B3BmVN/D9wUqX/77L1oGE="
+)
+
+// openRelayBind opens a relay bind over a fake UDP bind and returns it with
+// the receive function for relayed packets.
+func openRelayBind(t *testing.T, relay *Relay) (conn.Bind, conn.ReceiveFunc) {
+	t.Helper()
+
+	bind := newRelayBind(&fakeBind{}, relay)
+	fns, _, err := bind.Open(0)
+	require.NoError(t, err)
+	return bind, fns[len(fns)-1]
+}
+
+func receiveRelayed(t *testing.T, fn conn.ReceiveFunc) ([]byte, conn.Endpoint) {
+	t.Helper()
+
+	packets := [][]byte{make([]byte, 1500)}
+	sizes := make([]int, 1)
+	eps := make([]conn.Endpoint, 1)
+	n, err := fn(packets, sizes, eps)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	return packets[0][:sizes[0]], eps[0]
+}
+
+func TestRelay(t *testing.T) {
+	colonyRelay := NewRelay(zerolog.Nop())
+	colonyBind, colonyReceive := openRelayBind(t, colonyRelay)
+	srv := httptest.NewServer(NewRelayHandler(colonyRelay, func(key string) bool {
+		return key == relayTestAgentKey
+	}, zerolog.Nop()))
+	defer srv.Close()
+
+	agentRelay := NewRelay(zerolog.Nop())
+	agentBind, agentReceive := openRelayBind(t, agentRelay)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, agentRelay.Dial(ctx, srv.URL, relayTestAgentKey, relayTestColonyKey))
+	require.Eventually(t, func() bool {
+		return agentRelay.Connected(relayTestColonyKey) && colonyRelay.Connected(relayTestAgentKey)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// Agent to colony: the colony sees the packet come from the agent's
+	// relay endpoint, which WireGuard roaming adopts as the peer endpoint.
+	ep, err := agentBind.ParseEndpoint(RelayEndpoint(relayTestColonyKey))
+	require.NoError(t, err)
+	require.NoError(t, agentBind.Send([][]byte{[]byte("handshake initiation")}, ep))
+	packet, from := receiveRelayed(t, colonyReceive)
+	assert.Equal(t, "handshake initiation", string(packet))
+	assert.Equal(t, RelayEndpoint(relayTestAgentKey), from.DstToString())
+
+	// Colony to agent, through the endpoint the colony learned.
+	require.NoError(t, colonyBind.Send([][]byte{[]byte("handshake response")}, from))
+	packet, from = receiveRelayed(t, agentReceive)
+	assert.Equal(t, "handshake response", string(packet))
+	assert.Equal(t, RelayEndpoint(relayTestColonyKey), from.DstToString())
+
+	agentRelay.Disconnect(relayTestColonyKey)
+	assert.Error(t, agentBind.Send([][]byte{[]byte("data")}, ep))
+	assert.Eventually(t, func() bool {
+		return !colonyRelay.Connected(relayTestAgentKey)
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestRelay_RejectsUnknownPeers(t *testing.T) {
+	srv := httptest.NewServer(NewRelayHandler(NewRelay(zerolog.Nop()), func(string) bool {
+		return false
+	}, zerolog.Nop()))
+	defer srv.Close()
+
+	err := NewRelay(zerolog.Nop()).Dial(context.Background(), srv.URL, relayTestAgentKey, relayTestColonyKey)
+	assert.ErrorContains(t, err, "HTTP 403")
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "the key header is required")
+}
+
+func TestRelayBind_ParseEndpoint(t *testing.T) {
+	bind := newRelayBind(conn.NewDefaultBind(), NewRelay(zerolog.Nop()))
+
+	ep, err := bind.ParseEndpoint(RelayEndpoint(relayTestColonyKey))
+	require.NoError(t, err)
+	assert.Equal(t, "relay:"+relayTestColonyKey, ep.DstToString())
+	assert.True(t, IsRelayEndpoint(ep.DstToString()))
+
+	_, err = bind.ParseEndpoint("relay:not-a-key")
+	assert.Error(t, err)
+
+	ep, err = bind.ParseEndpoint("203.0.113.10:41580")
+	require.NoError(t, err)
+	assert.Equal(t, "203.0.113.10:41580", ep.DstToString())
+}
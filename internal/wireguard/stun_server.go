@@ -0,0 +1,230 @@
+package wireguard
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/stun"
+	"golang.zx2c4.com/wireguard/conn"
+
+	"github.com/coral-mesh/coral/internal/logging"
+)
+
+// stunRateLimitMaxSources bounds the number of source IPs tracked per rate
+// limit window; requests from further sources are dropped until the window
+// ends.
+const stunRateLimitMaxSources = 10000
+
+// STUN message header size and magic cookie (RFC 5389, section 6).
+const (
+	stunHeaderSize  = 20
+	stunMagicCookie = 0x2112A442
+)
+
+// IsSTUNBindingRequest reports whether packet is a STUN Binding Request (RFC
+// 5389). WireGuard messages start with a non-zero type byte (1-4), STUN
+// requests with 0x00 followed by the magic cookie at offset 4, so the two can
+// share a UDP port (RFD 029).
+func IsSTUNBindingRequest(packet []byte) bool {
+	return len(packet) >= stunHeaderSize &&
+		packet[0] == 0x00 && packet[1] == 0x01 &&
+		binary.BigEndian.Uint32(packet[4:8]) == stunMagicCookie
+}
+
+// STUNStats counts the packets handled by a STUN responder.
+type STUNStats struct {
+	Requests    uint64
+	Responses   uint64
+	Malformed   uint64
+	RateLimited uint64
+}
+
+// STUNResponder answers STUN Binding Requests with the source address they
+// were received from (XOR-MAPPED-ADDRESS), so that agents learn the public
+// endpoint their NAT maps to the responder's address (RFD 029). Requests are
+// rate limited per source IP.
+type STUNResponder struct {
+	rateLimit int
+	logger    logging.Logger
+
+	mu          sync.Mutex
+	windowStart time.Time
+	counts      map[netip.Addr]int
+
+	requests    atomic.Uint64
+	responses   atomic.Uint64
+	malformed   atomic.Uint64
+	rateLimited atomic.Uint64
+}
+
+// NewSTUNResponder creates a STUN responder answering at most rateLimit
+// requests per minute for each source IP.
+func NewSTUNResponder(rateLimit int, logger logging.Logger) *STUNResponder {
+	return &STUNResponder{
+		rateLimit: rateLimit,
+		logger:    logger.With().Str("component", "stun_server").Logger(),
+		counts:    make(map[netip.Addr]int),
+	}
+}
+
+// Respond returns the Binding Success Response to the request received from
+// from. It returns false if the request is malformed or rate limited.
+func (r *STUNResponder) Respond(request []byte, from netip.AddrPort) ([]byte, bool) {
+	r.requests.Add(1)
+
+	msg := &stun.Message{Raw: append([]byte(nil), request...)}
+	if err := msg.Decode(); err != nil || msg.Type != stun.BindingRequest {
+		r.malformed.Add(1)
+		return nil, false
+	}
+
+	if !r.allow(from.Addr(), time.Now()) {
+		r.rateLimited.Add(1)
+		return nil, false
+	}
+
+	resp, err := stun.Build(
+		stun.NewTransactionIDSetter(msg.TransactionID),
+		stun.BindingSuccess,
+		&stun.XORMappedAddress{IP: from.Addr().Unmap().AsSlice(), Port: int(from.Port())},
+		stun.Fingerprint,
+	)
+	if err != nil {
+		r.malformed.Add(1)
+		return nil, false
+	}
+
+	r.responses.Add(1)
+	return resp.Raw, true
+}
+
+// allow records a request from addr and reports whether it is within the
+// rate limit.
+func (r *STUNResponder) allow(addr netip.Addr, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now.Sub(r.windowStart) >= time.Minute {
+		r.windowStart = now
+		clear(r.counts)
+	}
+	count, ok := r.counts[addr]
+	if !ok && len(r.counts) >= stunRateLimitMaxSources {
+		return false
+	}
+	if count >= r.rateLimit {
+		return false
+	}
+	r.counts[addr] = count + 1
+	return true
+}
+
+// Stats returns the packet counters of the responder.
+func (r *STUNResponder) Stats() STUNStats {
+	return STUNStats{
+		Requests:    r.requests.Load(),
+		Responses:   r.responses.Load(),
+		Malformed:   r.malformed.Load(),
+		RateLimited: r.rateLimited.Load(),
+	}
+}
+
+// Serve answers STUN requests received on pc until ctx is done or pc is
+// closed. It is used to run a STUN server on a dedicated socket, e.g. in the
+// discovery service; colonies answer on the WireGuard port instead (see
+// NewSTUNBind).
+func (r *STUNResponder) Serve(ctx context.Context, pc net.PacketConn) error {
+	go func() {
+		<-ctx.Done()
+		_ = pc.Close()
+	}()
+
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		udpAddr, ok := addr.(*net.UDPAddr)
+		if !ok || !IsSTUNBindingRequest(buf[:n]) {
+			r.malformed.Add(1)
+			continue
+		}
+		if resp, ok := r.Respond(buf[:n], udpAddr.AddrPort()); ok {
+			if _, err := pc.WriteTo(resp, addr); err != nil {
+				r.logger.Debug().Err(err).Str("addr", addr.String()).Msg("Failed to send STUN response")
+			}
+		}
+	}
+}
+
+// stunBind wraps a WireGuard bind to answer STUN Binding Requests arriving
+// on the WireGuard port. Other packets are passed to WireGuard unchanged.
+type stunBind struct {
+	conn.Bind
+	responder *STUNResponder
+}
+
+// NewSTUNBind returns a bind that answers STUN requests with responder and
+// passes all other packets to bind.
+func NewSTUNBind(bind conn.Bind, responder *STUNResponder) conn.Bind {
+	return &stunBind{Bind: bind, responder: responder}
+}
+
+func (b *stunBind) Open(port uint16) ([]conn.ReceiveFunc, uint16, error) {
+	fns, actualPort, err := b.Bind.Open(port)
+	if err != nil {
+		return nil, 0, err
+	}
+	wrapped := make([]conn.ReceiveFunc, len(fns))
+	for i, fn := range fns {
+		wrapped[i] = b.receive(fn)
+	}
+	return wrapped, actualPort, nil
+}
+
+// receive filters STUN requests out of the packets returned by fn, answering
+// them, and compacts the remaining packets to the front of the batch.
+func (b *stunBind) receive(fn conn.ReceiveFunc) conn.ReceiveFunc {
+	return func(packets [][]byte, sizes []int, eps []conn.Endpoint) (int, error) {
+		n, err := fn(packets, sizes, eps)
+		kept := 0
+		for i := 0; i < n; i++ {
+			packet := packets[i][:sizes[i]]
+			if IsSTUNBindingRequest(packet) {
+				b.answer(packet, eps[i])
+				continue
+			}
+			if kept != i {
+				sizes[kept] = copy(packets[kept], packet)
+				eps[kept] = eps[i]
+			}
+			kept++
+		}
+		return kept, err
+	}
+}
+
+func (b *stunBind) answer(request []byte, ep conn.Endpoint) {
+	from, err := netip.ParseAddrPort(ep.DstToString())
+	if err != nil {
+		b.responder.malformed.Add(1)
+		return
+	}
+	resp, ok := b.responder.Respond(request, from)
+	if !ok {
+		return
+	}
+	if err := b.Send([][]byte{resp}, ep); err != nil {
+		b.responder.logger.Debug().Err(err).Str("addr", from.String()).Msg("Failed to send STUN response")
+	}
+}
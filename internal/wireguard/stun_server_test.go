@@ -0,0 +1,146 @@
+package wireguard
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/pion/stun"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.zx2c4.com/wireguard/conn"
+)
+
+func TestIsSTUNBindingRequest(t *testing.T) {
+	request := stun.MustBuild(stun.TransactionID, stun.BindingRequest).Raw
+	assert.True(t, IsSTUNBindingRequest(request))
+
+	response := stun.MustBuild(stun.TransactionID, stun.BindingSuccess).Raw
+	assert.False(t, IsSTUNBindingRequest(response))
+
+	// WireGuard handshake initiation, response, cookie reply and data.
+	for msgType := byte(1); msgType <= 4; msgType++ {
+		packet := make([]byte, 148)
+		packet[0] = msgType
+		assert.False(t, IsSTUNBindingRequest(packet), "WireGuard message type %d", msgType)
+	}
+	assert.False(t, IsSTUNBindingRequest(request[:10]))
+}
+
+func TestSTUNResponder_Respond(t *testing.T) {
+	r := NewSTUNResponder(2, zerolog.Nop())
+	from := netip.MustParseAddrPort("203.0.113.45:54322")
+
+	request := stun.MustBuild(stun.TransactionID, stun.BindingRequest)
+	raw, ok := r.Respond(request.Raw, from)
+	require.True(t, ok)
+
+	resp := &stun.Message{Raw: raw}
+	require.NoError(t, resp.Decode())
+	assert.Equal(t, stun.BindingSuccess, resp.Type)
+	assert.Equal(t, request.TransactionID, resp.TransactionID)
+	var addr stun.XORMappedAddress
+	require.NoError(t, addr.GetFrom(resp))
+	assert.Equal(t, "203.0.113.45", addr.IP.String())
+	assert.Equal(t, 54322, addr.Port)
+
+	// The second request is allowed, the third exceeds the rate limit.
+	_, ok = r.Respond(request.Raw, from)
+	assert.True(t, ok)
+	_, ok = r.Respond(request.Raw, from)
+	assert.False(t, ok)
+	_, ok = r.Respond(request.Raw, netip.MustParseAddrPort("198.51.100.7:4000"))
+	assert.True(t, ok, "the limit applies per source IP")
+
+	_, ok = r.Respond([]byte("not stun"), from)
+	assert.False(t, ok)
+
+	assert.Equal(t, STUNStats{Requests: 5, Responses: 3, Malformed: 1, RateLimited: 1}, r.Stats())
+}
+
+func TestSTUNResponder_Serve(t *testing.T) {
+	pc, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- NewSTUNResponder(60, zerolog.Nop()).Serve(ctx, pc) }()
+
+	// Pick a free local port for the client, as the agent uses its WireGuard port.
+	probe, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+	localPort := probe.LocalAddr().(*net.UDPAddr).Port
+	require.NoError(t, probe.Close())
+
+	endpoint := DiscoverPublicEndpoint([]string{pc.LocalAddr().String()}, localPort, zerolog.Nop())
+	require.NotNil(t, endpoint)
+	assert.Equal(t, "127.0.0.1", endpoint.IP)
+	assert.Equal(t, uint32(localPort), endpoint.Port)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+// fakeBind returns a fixed batch of packets and records sent packets.
+type fakeBind struct {
+	conn.Bind
+	packets [][]byte
+	from    netip.AddrPort
+	sent    [][]byte
+}
+
+func (b *fakeBind) Open(port uint16) ([]conn.ReceiveFunc, uint16, error) {
+	receive := func(packets [][]byte, sizes []int, eps []conn.Endpoint) (int, error) {
+		for i, p := range b.packets {
+			sizes[i] = copy(packets[i], p)
+			eps[i] = &StdNetEndpoint{AddrPort: b.from}
+		}
+		return len(b.packets), nil
+	}
+	return []conn.ReceiveFunc{receive}, port, nil
+}
+
+func (b *fakeBind) Send(bufs [][]byte, ep conn.Endpoint) error {
+	b.sent = append(b.sent, bufs...)
+	return nil
+}
+
+func TestSTUNBind(t *testing.T) {
+	handshake := make([]byte, 148)
+	handshake[0] = 1
+	data := make([]byte, 64)
+	data[0] = 4
+	request := stun.MustBuild(stun.TransactionID, stun.BindingRequest).Raw
+
+	inner := &fakeBind{
+		packets: [][]byte{handshake, request, data},
+		from:    netip.MustParseAddrPort("203.0.113.45:54322"),
+	}
+	bind := NewSTUNBind(inner, NewSTUNResponder(60, zerolog.Nop()))
+	fns, _, err := bind.Open(41580)
+	require.NoError(t, err)
+
+	packets := make([][]byte, 3)
+	for i := range packets {
+		packets[i] = make([]byte, 1500)
+	}
+	sizes := make([]int, 3)
+	eps := make([]conn.Endpoint, 3)
+	n, err := fns[0](packets, sizes, eps)
+	require.NoError(t, err)
+
+	// WireGuard receives its packets in order, without the STUN request.
+	require.Equal(t, 2, n)
+	assert.Equal(t, handshake, packets[0][:sizes[0]])
+	assert.Equal(t, data, packets[1][:sizes[1]])
+
+	// The STUN request was answered on the same socket.
+	require.Len(t, inner.sent, 1)
+	resp := &stun.Message{Raw: inner.sent[0]}
+	require.NoError(t, resp.Decode())
+	var addr stun.XORMappedAddress
+	require.NoError(t, addr.GetFrom(resp))
+	assert.Equal(t, 54322, addr.Port)
+}
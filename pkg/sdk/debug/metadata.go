@@ -31,6 +31,12 @@ type FunctionMetadataProvider struct {
 	closer     interface{ Close() error } // Either *elf.File or *macho.File
 	baseAddr   uint64                     // Base address for offset calculation
 
+	// DWARF 5 sections used to resolve argument location lists, and the
+	// machine that names their registers (ELF only).
+	locLists  []byte
+	debugAddr []byte
+	machine   elf.Machine
+
 	// Minimal index built at startup
 	mu         sync.RWMutex
 	basicIndex []*BasicInfo // Sorted by name for stable pagination
@@ -82,10 +88,16 @@ type ArgumentMetadata struct {
 	Name   string
 	Type   string
 	Offset uint64 // Stack/register offset
+
+	// Location is where the argument is at function entry, such as "rax",
+	// "rax, rbx" for a value split across registers, or "fbreg+8" for a stack
+	// slot. Empty when DWARF does not describe it.
+	Location string
 }
 
 // ReturnValueMetadata describes a return value.
 type ReturnValueMetadata struct {
+	Name   string
 	Type   string
 	Offset uint64
 }
@@ -115,6 +127,9 @@ func NewFunctionMetadataProviderForBinary(logger *slog.Logger, binaryPath string
 		fileCloser interface{ Close() error }
 		dwarfErr   error
 		baseAddr   uint64 // Base address for offset calculation
+		locLists   []byte
+		debugAddr  []byte
+		machine    elf.Machine
 	)
 
 	switch runtime.GOOS {
@@ -135,6 +150,13 @@ func NewFunctionMetadataProviderForBinary(logger *slog.Logger, binaryPath string
 			fileCloser = nil
 		}
 
+		// Location lists are needed to tell where arguments are at entry.
+		if dwarfErr == nil {
+			locLists = elfSectionData(elfFile, ".debug_loclists")
+			debugAddr = elfSectionData(elfFile, ".debug_addr")
+			machine = elfFile.Machine
+		}
+
 		// Get base address from ELF for offset calculation
 		if elfFile != nil {
 			for _, prog := range elfFile.Progs {
@@ -194,6 +216,9 @@ func NewFunctionMetadataProviderForBinary(logger *slog.Logger, binaryPath string
 		dwarf:       dwarfData,
 		closer:      fileCloser,
 		baseAddr:    baseAddr,
+		locLists:    locLists,
+		debugAddr:   debugAddr,
+		machine:     machine,
 		indexMap:    make(map[string]*BasicInfo),
 		detailCache: newLRUCache(100), // LRU cache with 100-entry limit as per RFD 066
 	}
@@ -678,12 +703,20 @@ func (p *FunctionMetadataProvider) searchDWARFForFunction(
 ) (*dwarfFunctionResult, error) {
 	reader := p.dwarf.Reader()
 
+	// Location lists index addresses relative to the compile unit's base.
+	var addrBase uint64
+
 	for {
 		entry, err := reader.Next()
 		if err != nil || entry == nil {
 			break
 		}
 
+		if entry.Tag == dwarf.TagCompileUnit {
+			addrBase = compileUnitAddrBase(entry)
+			continue
+		}
+
 		// Look for subprogram entries (functions).
 		if entry.Tag == dwarf.TagSubprogram {
 			name, _ := entry.Val(dwarf.AttrName).(string)
@@ -697,7 +730,7 @@ func (p *FunctionMetadataProvider) searchDWARFForFunction(
 				}
 
 				// Parse function arguments and return values.
-				args, retVals := p.parseFunctionParameters(reader, entry)
+				args, retVals := p.parseFunctionParameters(reader, entry, lowPC, addrBase)
 
 				// Convert virtual address to file offset by subtracting base address.
 				fileOffset := lowPC
@@ -739,14 +772,23 @@ func (p *FunctionMetadataProvider) searchDWARFForFunction(
 	return nil, fmt.Errorf("function not found in DWARF symbols")
 }
 
-// parseFunctionParameters extracts argument and return value metadata from a function entry.
+// parseFunctionParameters extracts argument and return value metadata from a
+// function entry. Go describes results as formal parameters with
+// DW_AT_variable_parameter set. Argument locations are resolved at lowPC, the
+// function entry, where uprobes fire.
 func (p *FunctionMetadataProvider) parseFunctionParameters(
 	reader *dwarf.Reader,
 	funcEntry *dwarf.Entry,
+	lowPC uint64,
+	addrBase uint64,
 ) ([]*ArgumentMetadata, []*ReturnValueMetadata) {
 	var args []*ArgumentMetadata
 	var retVals []*ReturnValueMetadata
 
+	if !funcEntry.Children {
+		return args, retVals
+	}
+
 	// Read child entries (parameters, local variables, etc.).
 	depth := 0
 	for {
@@ -755,7 +797,8 @@ func (p *FunctionMetadataProvider) parseFunctionParameters(
 			break
 		}
 
-		// Track depth to stay within the function scope.
+		// Track depth to stay within the function scope. A null entry ends
+		// the children of the innermost open entry.
 		if entry.Tag == 0 {
 			depth--
 			if depth < 0 {
@@ -764,49 +807,93 @@ func (p *FunctionMetadataProvider) parseFunctionParameters(
 			continue
 		}
 
-		depth++
+		level := depth
+		if entry.Children {
+			depth++
+		}
 
-		// Look for formal parameters (function arguments).
-		if entry.Tag == dwarf.TagFormalParameter {
-			arg := &ArgumentMetadata{
+		// Parameters of inlined calls are nested deeper.
+		if level > 0 || entry.Tag != dwarf.TagFormalParameter {
+			continue
+		}
+
+		if isResult, _ := entry.Val(dwarf.AttrVarParam).(bool); isResult {
+			retVals = append(retVals, &ReturnValueMetadata{
 				Name: getEntryName(entry),
 				Type: getEntryType(entry, p.dwarf),
-			}
+			})
+			continue
+		}
+
+		arg := &ArgumentMetadata{
+			Name: getEntryName(entry),
+			Type: getEntryType(entry, p.dwarf),
+		}
+
+		// Parse DWARF location expression to get argument location, either
+		// inline or, for optimized code, from the location list entry that
+		// covers the function entry.
+		var locExpr []byte
+		switch v := entry.Val(dwarf.AttrLocation).(type) {
+		case []byte:
+			locExpr = v
+		case int64:
+			locExpr = p.entryLocation(uint64(v), lowPC, addrBase) // #nosec G115
+		}
 
-			// Parse DWARF location expression to get argument location.
-			if locExpr, ok := entry.Val(dwarf.AttrLocation).([]byte); ok && len(locExpr) > 0 {
-				if loc, err := parseLocationExpr(locExpr); err == nil {
-					// Convert location to offset for uprobe attachment.
-					// For registers, use register number as offset hint.
-					// For frame-relative, use the stack offset.
-					switch loc.Type {
-					case LocationRegister:
-						// Register-based: use register number + offset as hint
-						arg.Offset = uint64(loc.Register*8) + uint64(loc.Offset)
-					case LocationFrameBase:
-						// Stack-based: use absolute offset value
-						if loc.Offset >= 0 {
-							arg.Offset = uint64(loc.Offset)
-						} else {
-							// Negative offsets are typical for stack frames
-							arg.Offset = uint64(-loc.Offset)
-						}
-					case LocationMemory:
-						arg.Offset = loc.Address
+		if pieces, err := parseLocationPieces(locExpr); err == nil && len(pieces) > 0 {
+			arg.Location = formatLocation(pieces, p.machine)
+
+			// Convert location to offset for uprobe attachment.
+			// For registers, use register number as offset hint.
+			// For frame-relative, use the stack offset.
+			if loc := pieces[0]; loc != nil {
+				switch loc.Type {
+				case LocationRegister:
+					// Register-based: use register number + offset as hint
+					arg.Offset = uint64(loc.Register*8) + uint64(loc.Offset)
+				case LocationFrameBase:
+					// Stack-based: use absolute offset value
+					if loc.Offset >= 0 {
+						arg.Offset = uint64(loc.Offset)
+					} else {
+						// Negative offsets are typical for stack frames
+						arg.Offset = uint64(-loc.Offset)
 					}
+				case LocationMemory:
+					arg.Offset = loc.Address
 				}
 			}
-
-			args = append(args, arg)
 		}
 
-		// Note: Go return values are typically not in DWARF as formal parameters.
-		// They're part of the function type. This is a simplified implementation.
+		args = append(args, arg)
 	}
 
 	return args, retVals
 }
 
+// entryLocation returns the location expression that applies at pc in the
+// location list at offset of .debug_loclists, or nil.
+func (p *FunctionMetadataProvider) entryLocation(offset, pc, addrBase uint64) []byte {
+	if offset >= uint64(len(p.locLists)) {
+		return nil
+	}
+	return findLocListEntry(p.locLists[offset:], p.debugAddr, addrBase, pc)
+}
+
+// elfSectionData returns the decompressed content of an ELF section, or nil.
+func elfSectionData(f *elf.File, name string) []byte {
+	section := f.Section(name)
+	if section == nil {
+		return nil
+	}
+	data, err := section.Data()
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
 // getEntryName extracts the name attribute from a DWARF entry.
 func getEntryName(entry *dwarf.Entry) string {
 	if name, ok := entry.Val(dwarf.AttrName).(string); ok {
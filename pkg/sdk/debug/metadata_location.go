@@ -1,8 +1,11 @@
 package debug
 
 import (
+	"debug/dwarf"
+	"debug/elf"
 	"encoding/binary"
 	"fmt"
+	"strings"
 )
 
 // LocationType describes the type of location (register, stack, etc.).
@@ -32,8 +35,32 @@ const (
 	opBreg31 = 0x8f // Base register 31 + offset
 	opRegx   = 0x90 // Register with ULEB128 number
 	opFbreg  = 0x91 // Frame base relative
+	opPiece  = 0x93 // Piece of a value split across locations
 )
 
+// DWARF 5 location list entry kinds (DW_LLE_*).
+const (
+	lleEndOfList       = 0x00
+	lleBaseAddressx    = 0x01
+	lleStartxEndx      = 0x02
+	lleStartxLength    = 0x03
+	lleOffsetPair      = 0x04
+	lleDefaultLocation = 0x05
+	lleBaseAddress     = 0x06
+	lleStartEnd        = 0x07
+	lleStartLength     = 0x08
+)
+
+// debugAddrHeaderSize is the size of the .debug_addr header of a 32-bit DWARF
+// unit, the default addr_base when a compile unit does not specify one.
+const debugAddrHeaderSize = 8
+
+// amd64Registers are the names of the x86-64 DWARF register numbers.
+var amd64Registers = []string{
+	"rax", "rdx", "rcx", "rbx", "rsi", "rdi", "rbp", "rsp",
+	"r8", "r9", "r10", "r11", "r12", "r13", "r14", "r15",
+}
+
 // parseLocationExpr parses a DWARF location expression.
 // This handles the most common cases for function parameters:
 // - DW_OP_reg0-31: value in register
@@ -173,3 +200,226 @@ func (l *Location) String() string {
 		return "<unknown>"
 	}
 }
+
+// locationOpLen returns the length of the single location operation at the
+// start of expr.
+func locationOpLen(expr []byte) (int, error) {
+	if len(expr) == 0 {
+		return 0, fmt.Errorf("empty location expression")
+	}
+
+	op := expr[0]
+	switch {
+	case op >= opReg0 && op <= opReg31:
+		return 1, nil
+	case op == opRegx:
+		_, n := decodeULEB128(expr[1:])
+		if n == 0 {
+			return 0, fmt.Errorf("DW_OP_regx: invalid ULEB128")
+		}
+		return 1 + n, nil
+	case op == opFbreg, op >= opBreg0 && op <= opBreg31:
+		_, n := decodeSLEB128(expr[1:])
+		if n == 0 {
+			return 0, fmt.Errorf("invalid SLEB128 operand of opcode 0x%02x", op)
+		}
+		return 1 + n, nil
+	case op == opAddr:
+		return 9, nil
+	default:
+		return 0, fmt.Errorf("unsupported location opcode: 0x%02x", op)
+	}
+}
+
+// parseLocationPieces parses a location expression that may describe a value
+// split across registers and stack slots with DW_OP_piece, as Go does for
+// strings, slices and interfaces. A nil piece is optimized out.
+func parseLocationPieces(expr []byte) ([]*Location, error) {
+	var pieces []*Location
+
+	for len(expr) > 0 {
+		var loc *Location
+		if expr[0] != opPiece {
+			n, err := locationOpLen(expr)
+			if err != nil {
+				return nil, err
+			}
+			if n > len(expr) {
+				return nil, fmt.Errorf("truncated location expression")
+			}
+			if loc, err = parseLocationExpr(expr[:n]); err != nil {
+				return nil, err
+			}
+			expr = expr[n:]
+		}
+
+		if len(expr) == 0 {
+			pieces = append(pieces, loc)
+			break
+		}
+		if expr[0] != opPiece {
+			return nil, fmt.Errorf("unsupported location expression opcode: 0x%02x", expr[0])
+		}
+		_, n := decodeULEB128(expr[1:])
+		if n == 0 {
+			return nil, fmt.Errorf("DW_OP_piece: invalid ULEB128")
+		}
+		expr = expr[1+n:]
+		pieces = append(pieces, loc)
+	}
+
+	return pieces, nil
+}
+
+// formatLocation describes the pieces of a location, naming registers after
+// machine, e.g. "rax, rbx" or "fbreg-24".
+func formatLocation(pieces []*Location, machine elf.Machine) string {
+	parts := make([]string, 0, len(pieces))
+	for _, loc := range pieces {
+		switch {
+		case loc == nil:
+			parts = append(parts, "<optimized out>")
+		case loc.Type == LocationRegister:
+			name := registerName(machine, loc.Register)
+			if loc.Offset != 0 {
+				name = fmt.Sprintf("%s%+d", name, loc.Offset)
+			}
+			parts = append(parts, name)
+		default:
+			parts = append(parts, loc.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// registerName returns the name of a DWARF register number on machine.
+func registerName(machine elf.Machine, reg int) string {
+	switch machine {
+	case elf.EM_X86_64:
+		if reg >= 0 && reg < len(amd64Registers) {
+			return amd64Registers[reg]
+		}
+	case elf.EM_AARCH64:
+		if reg == 31 {
+			return "sp"
+		}
+		if reg >= 0 && reg < 31 {
+			return fmt.Sprintf("x%d", reg)
+		}
+	}
+	return fmt.Sprintf("reg%d", reg)
+}
+
+// compileUnitAddrBase returns the offset of a compile unit's entries in
+// .debug_addr.
+func compileUnitAddrBase(cu *dwarf.Entry) uint64 {
+	if base, ok := cu.Val(dwarf.AttrAddrBase).(int64); ok && base >= 0 {
+		return uint64(base)
+	}
+	return debugAddrHeaderSize
+}
+
+// findLocListEntry walks a DWARF 5 location list and returns the location
+// expression of the entry covering pc, or nil. Addresses are 64-bit little
+// endian, as on the platforms the agent supports.
+func findLocListEntry(list, debugAddr []byte, addrBase, pc uint64) []byte {
+	r := &dwarfBuf{data: list}
+	addrx := func(index uint64) uint64 {
+		off := addrBase + index*8
+		if off+8 > uint64(len(debugAddr)) {
+			r.fail()
+			return 0
+		}
+		return binary.LittleEndian.Uint64(debugAddr[off:])
+	}
+
+	var base uint64
+	for !r.failed {
+		var begin, end uint64
+		switch r.byte() {
+		case lleEndOfList:
+			return nil
+		case lleBaseAddressx:
+			base = addrx(r.uleb())
+			continue
+		case lleBaseAddress:
+			base = r.u64()
+			continue
+		case lleStartxEndx:
+			begin, end = addrx(r.uleb()), addrx(r.uleb())
+		case lleStartxLength:
+			begin = addrx(r.uleb())
+			end = begin + r.uleb()
+		case lleOffsetPair:
+			begin, end = base+r.uleb(), base+r.uleb()
+		case lleDefaultLocation:
+			begin, end = 0, ^uint64(0)
+		case lleStartEnd:
+			begin, end = r.u64(), r.u64()
+		case lleStartLength:
+			begin = r.u64()
+			end = begin + r.uleb()
+		default:
+			return nil
+		}
+
+		expr := r.bytes(r.uleb())
+		if !r.failed && pc >= begin && pc < end {
+			return expr
+		}
+	}
+
+	return nil
+}
+
+// dwarfBuf reads DWARF encoded values, recording reads past the end of data
+// instead of panicking.
+type dwarfBuf struct {
+	data   []byte
+	failed bool
+}
+
+func (b *dwarfBuf) fail() {
+	b.failed = true
+	b.data = nil
+}
+
+func (b *dwarfBuf) byte() byte {
+	if len(b.data) < 1 {
+		b.fail()
+		return 0
+	}
+	v := b.data[0]
+	b.data = b.data[1:]
+	return v
+}
+
+func (b *dwarfBuf) u64() uint64 {
+	if len(b.data) < 8 {
+		b.fail()
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(b.data)
+	b.data = b.data[8:]
+	return v
+}
+
+func (b *dwarfBuf) uleb() uint64 {
+	v, n := decodeULEB128(b.data)
+	if n == 0 {
+		b.fail()
+		return 0
+	}
+	b.data = b.data[n:]
+	return v
+}
+
+func (b *dwarfBuf) bytes(n uint64) []byte {
+	if n > uint64(len(b.data)) {
+		b.fail()
+		return nil
+	}
+	v := b.data[:n]
+	b.data = b.data[n:]
+	return v
+}
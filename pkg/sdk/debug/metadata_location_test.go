@@ -1,6 +1,9 @@
 package debug
 
 import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
 	"testing"
 )
 
@@ -331,3 +334,64 @@ func TestParseLocationExpr_RealWorld(t *testing.T) {
 		})
 	}
 }
+
+// TestParseLocationPieces tests values split across locations with DW_OP_piece.
+func TestParseLocationPieces(t *testing.T) {
+	tests := []struct {
+		name string
+		expr []byte
+		want string
+	}{
+		{"single register", []byte{0x50}, "rax"},
+		{"string in two registers", []byte{0x53, 0x93, 0x08, 0x52, 0x93, 0x08}, "rbx, rcx"},
+		{"stack slots", []byte{0x91, 0x40, 0x93, 0x08, 0x91, 0x48, 0x93, 0x08}, "fbreg-64, fbreg-56"},
+		{"optimized out piece", []byte{0x93, 0x08, 0x55, 0x93, 0x08}, "<optimized out>, rdi"},
+		{"register relative", []byte{0x77, 0x10}, "rsp+16"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pieces, err := parseLocationPieces(tt.expr)
+			if err != nil {
+				t.Fatalf("parseLocationPieces() error = %v", err)
+			}
+			if got := formatLocation(pieces, elf.EM_X86_64); got != tt.want {
+				t.Errorf("formatLocation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := parseLocationPieces([]byte{0x50, 0x9c}); err == nil {
+		t.Error("parseLocationPieces() should reject unsupported operations")
+	}
+	if got := registerName(elf.EM_AARCH64, 1); got != "x1" {
+		t.Errorf("registerName(arm64, 1) = %q, want x1", got)
+	}
+}
+
+// TestFindLocListEntry tests resolving a location list at a program counter.
+func TestFindLocListEntry(t *testing.T) {
+	// .debug_addr: 8-byte header, then the function address at index 0.
+	debugAddr := make([]byte, 16)
+	binary.LittleEndian.PutUint64(debugAddr[8:], 0x401000)
+
+	list := []byte{
+		lleBaseAddressx, 0x00,
+		lleOffsetPair, 0x00, 0x10, 0x01, 0x50, // [0x401000, 0x401010): rax
+		lleOffsetPair, 0x10, 0x40, 0x02, 0x91, 0x40, // [0x401010, 0x401040): fbreg-64
+		lleEndOfList,
+	}
+
+	if got := findLocListEntry(list, debugAddr, 8, 0x401000); !bytes.Equal(got, []byte{0x50}) {
+		t.Errorf("at entry = %x, want 50", got)
+	}
+	if got := findLocListEntry(list, debugAddr, 8, 0x401020); !bytes.Equal(got, []byte{0x91, 0x40}) {
+		t.Errorf("in body = %x, want 9140", got)
+	}
+	if got := findLocListEntry(list, debugAddr, 8, 0x402000); got != nil {
+		t.Errorf("outside = %x, want nil", got)
+	}
+	if got := findLocListEntry(list[:5], debugAddr, 8, 0x401000); got != nil {
+		t.Errorf("truncated = %x, want nil", got)
+	}
+}
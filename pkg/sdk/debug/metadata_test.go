@@ -1,7 +1,9 @@
 package debug
 
 import (
+	"fmt"
 	"log/slog"
+	"runtime"
 	"strings"
 	"sync"
 	"testing"
@@ -1207,3 +1209,63 @@ func BenchmarkSymbolAccess(b *testing.B) {
 		}
 	})
 }
+
+// describeFixture is a function with known parameters for
+// TestFunctionParameters.
+//
+//go:noinline
+func describeFixture(amount int, name string) (int, error) {
+	if amount < 0 {
+		return 0, fmt.Errorf("negative amount for %s", name)
+	}
+	return amount + len(name), nil
+}
+
+// TestFunctionParameters tests argument and return value extraction.
+func TestFunctionParameters(t *testing.T) {
+	if _, err := describeFixture(1, "x"); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := NewFunctionMetadataProvider(slog.Default())
+	if err != nil {
+		t.Fatalf("NewFunctionMetadataProvider() error = %v", err)
+	}
+	defer provider.Close() // nolint:errcheck
+
+	if !provider.HasDWARF() {
+		t.Skip("Test binary doesn't have DWARF symbols")
+	}
+
+	meta, err := provider.GetFunctionMetadata("github.com/coral-mesh/coral/pkg/sdk/debug.describeFixture")
+	if err != nil {
+		t.Fatalf("GetFunctionMetadata() error = %v", err)
+	}
+
+	if len(meta.Arguments) != 2 {
+		t.Fatalf("got %d arguments, want 2", len(meta.Arguments))
+	}
+	if meta.Arguments[0].Name != "amount" || meta.Arguments[0].Type != "int" {
+		t.Errorf("argument 0 = %+v", meta.Arguments[0])
+	}
+	if meta.Arguments[1].Name != "name" || meta.Arguments[1].Type != "string" {
+		t.Errorf("argument 1 = %+v", meta.Arguments[1])
+	}
+
+	if len(meta.ReturnValues) != 2 {
+		t.Fatalf("got %d return values, want 2", len(meta.ReturnValues))
+	}
+	if meta.ReturnValues[0].Type != "int" || meta.ReturnValues[1].Type != "error" {
+		t.Errorf("return values = %+v, %+v", meta.ReturnValues[0], meta.ReturnValues[1])
+	}
+
+	// Register ABI: the int in rax, the string pointer and length in rbx, rcx.
+	if runtime.GOARCH == "amd64" && provider.locLists != nil {
+		if got := meta.Arguments[0].Location; got != "rax" {
+			t.Errorf("amount location = %q, want rax", got)
+		}
+		if got := meta.Arguments[1].Location; got != "rbx, rcx" {
+			t.Errorf("name location = %q, want rbx, rcx", got)
+		}
+	}
+}
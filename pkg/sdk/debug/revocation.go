@@ -0,0 +1,97 @@
+package debug
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+)
+
+// maxRevocationListSize bounds the size of a fetched revocation list.
+const maxRevocationListSize = 16 << 20
+
+// RevocationList holds the serial numbers of revoked client certificates, as
+// published by the colony's certificate revocation list.
+type RevocationList struct {
+	mu      sync.RWMutex
+	serials map[string]struct{}
+	loaded  bool
+}
+
+// NewRevocationList creates an empty revocation list.
+func NewRevocationList() *RevocationList {
+	return &RevocationList{serials: make(map[string]struct{})}
+}
+
+// Update replaces the revoked serial numbers with those of the DER encoded
+// certificate revocation list.
+func (l *RevocationList) Update(der []byte) error {
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return fmt.Errorf("failed to parse revocation list: %w", err)
+	}
+
+	serials := make(map[string]struct{}, len(crl.RevokedCertificateEntries))
+	for _, entry := range crl.RevokedCertificateEntries {
+		serials[entry.SerialNumber.String()] = struct{}{}
+	}
+
+	l.mu.Lock()
+	l.serials = serials
+	l.loaded = true
+	l.mu.Unlock()
+	return nil
+}
+
+// Fetch downloads the revocation list from url and updates the list with it.
+func (l *RevocationList) Fetch(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create revocation list request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch revocation list: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch revocation list: status %d", resp.StatusCode)
+	}
+	der, err := io.ReadAll(io.LimitReader(resp.Body, maxRevocationListSize))
+	if err != nil {
+		return fmt.Errorf("failed to read revocation list: %w", err)
+	}
+	return l.Update(der)
+}
+
+// IsRevoked reports whether the certificate with serialNumber is revoked.
+func (l *RevocationList) IsRevoked(serialNumber *big.Int) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	_, ok := l.serials[serialNumber.String()]
+	return ok
+}
+
+// Loaded reports whether a revocation list was loaded yet.
+func (l *RevocationList) Loaded() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.loaded
+}
+
+// VerifyConnection rejects TLS clients presenting a revoked certificate. It
+// is meant for tls.Config.VerifyConnection.
+func (l *RevocationList) VerifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return nil
+	}
+	if cert := cs.PeerCertificates[0]; l.IsRevoked(cert.SerialNumber) {
+		return fmt.Errorf("client certificate %s is revoked", cert.SerialNumber)
+	}
+	return nil
+}
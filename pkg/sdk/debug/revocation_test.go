@@ -0,0 +1,171 @@
+package debug
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testCA issues the certificates and revocation lists of revocation tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) issue(t *testing.T, serial int64, usage x509.ExtKeyUsage) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func (ca *testCA) revocationList(t *testing.T, serials ...int64) []byte {
+	t.Helper()
+	entries := make([]x509.RevocationListEntry, len(serials))
+	for i, serial := range serials {
+		entries[i] = x509.RevocationListEntry{SerialNumber: big.NewInt(serial), RevocationTime: time.Now()}
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		RevokedCertificateEntries: entries,
+		Number:                    big.NewInt(1),
+		ThisUpdate:                time.Now(),
+		NextUpdate:                time.Now().Add(time.Hour),
+	}, ca.cert, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create revocation list: %v", err)
+	}
+	return der
+}
+
+func TestRevocationList_Fetch(t *testing.T) {
+	ca := newTestCA(t)
+	crl := ca.revocationList(t, 2, 3)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(crl)
+	}))
+	defer srv.Close()
+
+	revocations := NewRevocationList()
+	if revocations.Loaded() {
+		t.Error("Loaded() = true before the first fetch")
+	}
+	if err := revocations.Fetch(context.Background(), srv.Client(), srv.URL); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if !revocations.Loaded() {
+		t.Error("Loaded() = false after a fetch")
+	}
+
+	for serial, want := range map[int64]bool{1: false, 2: true, 3: true} {
+		if got := revocations.IsRevoked(big.NewInt(serial)); got != want {
+			t.Errorf("IsRevoked(%d) = %v, want %v", serial, got, want)
+		}
+	}
+
+	// A newer list replaces the revoked serial numbers.
+	if err := revocations.Update(ca.revocationList(t, 3)); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if revocations.IsRevoked(big.NewInt(2)) {
+		t.Error("IsRevoked(2) = true after it left the revocation list")
+	}
+
+	if err := revocations.Update([]byte("not a CRL")); err == nil {
+		t.Error("Update() accepted an invalid revocation list")
+	}
+}
+
+func TestServer_RejectsRevokedClientCertificates(t *testing.T) {
+	ca := newTestCA(t)
+	revocations := NewRevocationList()
+	if err := revocations.Update(ca.revocationList(t, 3)); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	server, err := NewServer(slog.Default(), nil)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	server.SetTLSConfig(&tls.Config{
+		Certificates:     []tls.Certificate{ca.issue(t, 10, x509.ExtKeyUsageServerAuth)},
+		ClientCAs:        pool,
+		ClientAuth:       tls.RequireAndVerifyClientCert,
+		VerifyConnection: revocations.VerifyConnection,
+		MinVersion:       tls.VersionTLS12,
+	})
+	if err := server.Start("127.0.0.1:0"); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer server.Stop()
+
+	get := func(clientCert tls.Certificate) error {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      pool,
+			Certificates: []tls.Certificate{clientCert},
+		}}}
+		defer client.CloseIdleConnections()
+		resp, err := client.Get("https://" + server.Addr() + "/debug/memstats")
+		if err != nil {
+			return err
+		}
+		_ = resp.Body.Close()
+		return nil
+	}
+
+	if err := get(ca.issue(t, 2, x509.ExtKeyUsageClientAuth)); err != nil {
+		t.Errorf("valid client certificate rejected: %v", err)
+	}
+	if err := get(ca.issue(t, 3, x509.ExtKeyUsageClientAuth)); err == nil {
+		t.Error("revoked client certificate accepted")
+	}
+}
@@ -2,6 +2,7 @@ package debug
 
 import (
 	"compress/gzip"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,6 +25,12 @@ type Server struct {
 	listener net.Listener
 	server   *http.Server
 	addr     string
+
+	// tlsConfig serves the debug server over TLS, see SetTLSConfig.
+	tlsConfig *tls.Config
+
+	// labels are reported in the capabilities, see SetLabels.
+	labels map[string]string
 }
 
 // NewServer creates a new SDK debug server.
@@ -34,12 +41,26 @@ func NewServer(logger *slog.Logger, provider *FunctionMetadataProvider) (*Server
 	}, nil
 }
 
+// SetTLSConfig makes the server serve over TLS. Must be called before Start.
+func (s *Server) SetTLSConfig(tlsConfig *tls.Config) {
+	s.tlsConfig = tlsConfig
+}
+
+// SetLabels sets the service labels reported to agents. Must be called
+// before Start.
+func (s *Server) SetLabels(labels map[string]string) {
+	s.labels = labels
+}
+
 // Start starts the HTTP server on the specified address.
 func (s *Server) Start(listenAddr string) error {
 	listener, err := net.Listen("tcp", listenAddr)
 	if err != nil {
 		return fmt.Errorf("failed to create listener: %w", err)
 	}
+	if s.tlsConfig != nil {
+		listener = tls.NewListener(listener, s.tlsConfig)
+	}
 	s.listener = listener
 	s.addr = s.listener.Addr().String()
 
@@ -120,6 +141,8 @@ type CapabilitiesResponse struct {
 	FunctionCount   int    `json:"function_count"`
 	BinaryPath      string `json:"binary_path"`
 	BinaryHash      string `json:"binary_hash"`
+
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 func (s *Server) handleCapabilities(w http.ResponseWriter, r *http.Request) {
@@ -136,6 +159,7 @@ func (s *Server) handleCapabilities(w http.ResponseWriter, r *http.Request) {
 		FunctionCount:   s.provider.GetFunctionCount(),
 		BinaryPath:      s.provider.BinaryPath(),
 		BinaryHash:      binHash,
+		Labels:          s.labels,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -1,19 +1,31 @@
 package sdk
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/coral-mesh/coral/pkg/sdk/debug"
 )
 
+// defaultRevocationListInterval is how often the colony's certificate
+// revocation list is fetched by default.
+const defaultRevocationListInterval = 5 * time.Minute
+
 // SDK represents the Coral SDK instance embedded in an application.
 type SDK struct {
 	logger           *slog.Logger
 	debugServer      *debug.Server
 	metadataProvider *debug.FunctionMetadataProvider
 	debugAddr        string
+	config           Config
+
+	// stopRevocations stops refreshing the revocation list.
+	stopRevocations chan struct{}
 }
 
 // Config contains SDK configuration options.
@@ -23,6 +35,28 @@ type Config struct {
 
 	// Logger is the logger instance (optional, defaults to slog.Default()).
 	Logger *slog.Logger
+
+	// TLSConfig serves the debug server over TLS (optional). Set ClientCAs to
+	// the colony root CA and ClientAuth to tls.RequireAndVerifyClientCert to
+	// only accept agents holding a certificate from the colony CA.
+	TLSConfig *tls.Config
+
+	// RevocationListURL is the URL of the colony's certificate revocation
+	// list, e.g. "https://colony.example.com:8443/ca/crl" (optional, requires
+	// TLSConfig). Clients presenting a revoked certificate, e.g. of an agent
+	// revoked with `coral colony agent revoke`, are rejected. The list is
+	// fetched over TLS verified against TLSConfig.ClientCAs.
+	RevocationListURL string
+
+	// RevocationListInterval is how often the revocation list is fetched
+	// again (default: 5m).
+	RevocationListInterval time.Duration
+
+	// Labels are added to the labels of the service in the colony, e.g.
+	// {"team": "payments"}, and matched by selectors like
+	// `coral query summary --selector team=payments` (optional). Labels set
+	// in the agent configuration take precedence.
+	Labels map[string]string
 }
 
 // New creates a new Coral SDK instance.
@@ -40,9 +74,17 @@ func New(config Config) (*SDK, error) {
 		debugAddr = ":9002"
 	}
 
+	if config.RevocationListURL != "" && config.TLSConfig == nil {
+		return nil, fmt.Errorf("RevocationListURL requires TLSConfig")
+	}
+	if config.RevocationListInterval <= 0 {
+		config.RevocationListInterval = defaultRevocationListInterval
+	}
+
 	sdk := &SDK{
 		logger:    logger,
 		debugAddr: debugAddr,
+		config:    config,
 	}
 
 	logger.Info("Coral SDK initialized", "debug_addr", debugAddr)
@@ -54,6 +96,11 @@ func New(config Config) (*SDK, error) {
 func (s *SDK) Close() error {
 	s.logger.Info("Shutting down Coral SDK")
 
+	if s.stopRevocations != nil {
+		close(s.stopRevocations)
+		s.stopRevocations = nil
+	}
+
 	if s.debugServer != nil {
 		if err := s.debugServer.Stop(); err != nil {
 			s.logger.Error("Failed to stop debug server", "error", err)
@@ -97,6 +144,15 @@ func (s *SDK) initializeDebugServer() error {
 		return fmt.Errorf("failed to create debug server: %w", err)
 	}
 	s.debugServer = server
+	server.SetLabels(s.config.Labels)
+
+	if s.config.TLSConfig != nil {
+		tlsConfig := s.config.TLSConfig.Clone()
+		if s.config.RevocationListURL != "" {
+			s.enableRevocationList(tlsConfig)
+		}
+		server.SetTLSConfig(tlsConfig)
+	}
 
 	// Start the server with configured listen address.
 	if err := server.Start(s.debugAddr); err != nil {
@@ -111,6 +167,55 @@ func (s *SDK) initializeDebugServer() error {
 	return nil
 }
 
+// enableRevocationList makes tlsConfig reject client certificates on the
+// colony's revocation list, and keeps the list up to date until Close.
+func (s *SDK) enableRevocationList(tlsConfig *tls.Config) {
+	revocations := debug.NewRevocationList()
+	verifyConnection := tlsConfig.VerifyConnection
+	tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		if verifyConnection != nil {
+			if err := verifyConnection(cs); err != nil {
+				return err
+			}
+		}
+		return revocations.VerifyConnection(cs)
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    tlsConfig.ClientCAs,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}
+	url := s.config.RevocationListURL
+	interval := s.config.RevocationListInterval
+	stop := make(chan struct{})
+	s.stopRevocations = stop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if err := revocations.Fetch(ctx, client, url); err != nil {
+				// Keep the last list; certificates revoked since are
+				// accepted until the colony is reachable again.
+				s.logger.Warn("Failed to fetch certificate revocation list", "url", url, "error", err)
+			}
+			cancel()
+
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
 // Global SDK instance
 var globalSDK *SDK
 var globalSDKMu sync.Mutex
@@ -119,6 +224,15 @@ var globalSDKMu sync.Mutex
 type Options struct {
 	// DebugAddr is the address to listen on for the debug server (default: ":9002").
 	DebugAddr string
+
+	// TLSConfig, RevocationListURL and RevocationListInterval secure the
+	// debug server, see Config.
+	TLSConfig              *tls.Config
+	RevocationListURL      string
+	RevocationListInterval time.Duration
+
+	// Labels are added to the labels of the service, see Config.
+	Labels map[string]string
 }
 
 // EnableRuntimeMonitoring starts the HTTP debug server.
@@ -132,8 +246,12 @@ func EnableRuntimeMonitoring(opts Options) error {
 
 	// Create SDK instance
 	sdk, err := New(Config{
-		DebugAddr: opts.DebugAddr,
-		Logger:    slog.Default(),
+		DebugAddr:              opts.DebugAddr,
+		Logger:                 slog.Default(),
+		TLSConfig:              opts.TLSConfig,
+		RevocationListURL:      opts.RevocationListURL,
+		RevocationListInterval: opts.RevocationListInterval,
+		Labels:                 opts.Labels,
 	})
 	if err != nil {
 		return err
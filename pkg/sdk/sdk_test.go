@@ -26,6 +26,14 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "revocation list without TLS",
+			config: Config{
+				Logger:            slog.Default(),
+				RevocationListURL: "https://colony:8443/ca/crl",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {